@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-api-gateway/internal/middleware"
+)
+
+// MaintenanceHandler serves the admin API for toggling maintenance mode
+type MaintenanceHandler struct {
+	guard *middleware.MaintenanceGuard
+}
+
+// NewMaintenanceHandler creates a new MaintenanceHandler
+func NewMaintenanceHandler(guard *middleware.MaintenanceGuard) *MaintenanceHandler {
+	return &MaintenanceHandler{
+		guard: guard,
+	}
+}
+
+// UpdateMaintenanceRequest represents the request to configure maintenance mode
+type UpdateMaintenanceRequest struct {
+	Enabled    *bool      `json:"enabled" binding:"required"`
+	Message    string     `json:"message"`
+	StartsAt   *time.Time `json:"starts_at"` // Optional; starts immediately when omitted
+	EndsAt     *time.Time `json:"ends_at"`   // Optional; runs until disabled when omitted
+	AllowPaths []string   `json:"allow_paths"`
+	AllowIPs   []string   `json:"allow_ips"`
+	AllowRoles []string   `json:"allow_roles"`
+}
+
+// Get handles GET /api/v1/gateway/maintenance - returns the current maintenance state
+func (h *MaintenanceHandler) Get(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    h.response(h.guard.State()),
+	})
+}
+
+// Update handles PUT /api/v1/gateway/maintenance - replaces the maintenance state
+func (h *MaintenanceHandler) Update(c *gin.Context) {
+	var req UpdateMaintenanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INVALID_REQUEST",
+				"message": "enabled is required",
+			},
+		})
+		return
+	}
+
+	state := &middleware.MaintenanceState{
+		Enabled:    *req.Enabled,
+		Message:    req.Message,
+		StartsAt:   req.StartsAt,
+		EndsAt:     req.EndsAt,
+		AllowPaths: req.AllowPaths,
+		AllowIPs:   req.AllowIPs,
+		AllowRoles: req.AllowRoles,
+		UpdatedBy:  c.GetString("user_id"),
+		UpdatedAt:  time.Now().UTC(),
+	}
+	if err := state.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INVALID_REQUEST",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	h.save(c, state)
+}
+
+// Disable handles DELETE /api/v1/gateway/maintenance - turns maintenance mode off
+func (h *MaintenanceHandler) Disable(c *gin.Context) {
+	h.save(c, &middleware.MaintenanceState{
+		UpdatedBy: c.GetString("user_id"),
+		UpdatedAt: time.Now().UTC(),
+	})
+}
+
+func (h *MaintenanceHandler) save(c *gin.Context, state *middleware.MaintenanceState) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.guard.Set(ctx, state); err != nil {
+		_ = c.Error(err)
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "MAINTENANCE_UPDATE_FAILED",
+				"message": "Failed to save maintenance state",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    h.response(state),
+	})
+}
+
+// response wraps the state with whether it is in effect right now
+func (h *MaintenanceHandler) response(state *middleware.MaintenanceState) gin.H {
+	if state == nil {
+		state = &middleware.MaintenanceState{}
+	}
+	return gin.H{
+		"state":  state,
+		"active": state.IsActive(time.Now()),
+	}
+}
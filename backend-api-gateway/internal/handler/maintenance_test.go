@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-api-gateway/internal/middleware"
+)
+
+func newTestMaintenanceHandler(t *testing.T) (*MaintenanceHandler, *middleware.MaintenanceGuard) {
+	t.Helper()
+	guard := middleware.NewMaintenanceGuard(middleware.NewMemoryMaintenanceStore(), middleware.DefaultMaintenanceConfig("secret"))
+	t.Cleanup(guard.Stop)
+	return NewMaintenanceHandler(guard), guard
+}
+
+func TestMaintenanceHandler_Get(t *testing.T) {
+	handler, _ := newTestMaintenanceHandler(t)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/gateway/maintenance", nil)
+
+	handler.Get(c)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if !contains(w.Body.String(), `"active":false`) {
+		t.Errorf("Expected inactive state in response: %s", w.Body.String())
+	}
+}
+
+func TestMaintenanceHandler_Update(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantActive bool
+	}{
+		{"enable", `{"enabled":true,"message":"Back soon","allow_ips":["10.0.0.0/8"]}`, http.StatusOK, true},
+		{"schedule", `{"enabled":true,"starts_at":"2999-01-01T00:00:00Z","ends_at":"2999-01-01T01:00:00Z"}`, http.StatusOK, false},
+		{"missing enabled", `{"message":"Back soon"}`, http.StatusBadRequest, false},
+		{"end before start", `{"enabled":true,"starts_at":"2999-01-01T01:00:00Z","ends_at":"2999-01-01T00:00:00Z"}`, http.StatusBadRequest, false},
+		{"invalid IP", `{"enabled":true,"allow_ips":["nope"]}`, http.StatusBadRequest, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, guard := newTestMaintenanceHandler(t)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPut, "/api/v1/gateway/maintenance", bytes.NewBufferString(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("user_id", "admin-1")
+
+			handler.Update(c)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if guard.Active() != tt.wantActive {
+				t.Errorf("Expected active=%v", tt.wantActive)
+			}
+			if tt.wantStatus == http.StatusOK && guard.State().UpdatedBy != "admin-1" {
+				t.Errorf("Expected updated_by to be recorded, got %q", guard.State().UpdatedBy)
+			}
+		})
+	}
+}
+
+func TestMaintenanceHandler_Disable(t *testing.T) {
+	handler, guard := newTestMaintenanceHandler(t)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPut, "/api/v1/gateway/maintenance", bytes.NewBufferString(`{"enabled":true}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	handler.Update(c)
+	if !guard.Active() {
+		t.Fatal("Expected maintenance to be active")
+	}
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodDelete, "/api/v1/gateway/maintenance", nil)
+	handler.Disable(c)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if guard.Active() {
+		t.Error("Expected maintenance to be disabled")
+	}
+}
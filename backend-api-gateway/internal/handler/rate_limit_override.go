@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-api-gateway/internal/middleware"
+)
+
+// RateLimitOverrideHandler serves the admin API for per-user and per-API-key rate limit overrides
+type RateLimitOverrideHandler struct {
+	overrides *middleware.RateLimitOverrides
+}
+
+// NewRateLimitOverrideHandler creates a new RateLimitOverrideHandler
+func NewRateLimitOverrideHandler(overrides *middleware.RateLimitOverrides) *RateLimitOverrideHandler {
+	return &RateLimitOverrideHandler{
+		overrides: overrides,
+	}
+}
+
+// PutRateLimitOverrideRequest represents the request to set the override of a user or API key.
+// Exactly one of UserID and APIKey is required; the API key itself is never stored.
+type PutRateLimitOverrideRequest struct {
+	UserID            string     `json:"user_id"`
+	APIKey            string     `json:"api_key"`
+	RequestsPerSecond int        `json:"requests_per_second"`
+	BurstSize         int        `json:"burst_size"`
+	Bypass            bool       `json:"bypass"` // VIP: never rate limited
+	Note              string     `json:"note"`
+	ExpiresAt         *time.Time `json:"expires_at"` // Optional; applies until deleted when omitted
+}
+
+// List handles GET /api/v1/gateway/rate-limit/overrides - returns every override
+func (h *RateLimitOverrideHandler) List(c *gin.Context) {
+	if !h.enabled(c) {
+		return
+	}
+
+	overrides := h.overrides.List()
+	now := time.Now()
+	data := make([]gin.H, 0, len(overrides))
+	for _, override := range overrides {
+		data = append(data, gin.H{
+			"override": override,
+			"active":   override.IsActive(now),
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    data,
+	})
+}
+
+// Put handles PUT /api/v1/gateway/rate-limit/overrides - creates or replaces the override
+// of a user or API key
+func (h *RateLimitOverrideHandler) Put(c *gin.Context) {
+	if !h.enabled(c) {
+		return
+	}
+
+	var req PutRateLimitOverrideRequest
+	if err := c.ShouldBindJSON(&req); err != nil || (req.UserID == "") == (req.APIKey == "") {
+		h.invalid(c, "exactly one of user_id and api_key is required")
+		return
+	}
+
+	subject := middleware.UserOverrideSubject(req.UserID)
+	if req.APIKey != "" {
+		subject = middleware.APIKeyOverrideSubject(req.APIKey)
+	}
+	override := &middleware.RateLimitOverride{
+		Subject:           subject,
+		RequestsPerSecond: req.RequestsPerSecond,
+		BurstSize:         req.BurstSize,
+		Bypass:            req.Bypass,
+		Note:              req.Note,
+		ExpiresAt:         req.ExpiresAt,
+		UpdatedBy:         c.GetString("user_id"),
+		UpdatedAt:         time.Now().UTC(),
+	}
+	if err := override.Validate(); err != nil {
+		h.invalid(c, err.Error())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.overrides.Put(ctx, override); err != nil {
+		_ = c.Error(err)
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "OVERRIDE_UPDATE_FAILED",
+				"message": "Failed to save rate limit override",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"override": override,
+			"active":   override.IsActive(time.Now()),
+		},
+	})
+}
+
+// Delete handles DELETE /api/v1/gateway/rate-limit/overrides/:subject - removes an override,
+// e.g. user:<id> or api_key:<hash> as listed
+func (h *RateLimitOverrideHandler) Delete(c *gin.Context) {
+	if !h.enabled(c) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	deleted, err := h.overrides.Delete(ctx, c.Param("subject"))
+	if err != nil {
+		_ = c.Error(err)
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "OVERRIDE_UPDATE_FAILED",
+				"message": "Failed to delete rate limit override",
+			},
+		})
+		return
+	}
+	if !deleted {
+		c.JSON(http.StatusNotFound, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "OVERRIDE_NOT_FOUND",
+				"message": "Rate limit override not found",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+	})
+}
+
+// enabled writes a 404 response when rate limiting is disabled
+func (h *RateLimitOverrideHandler) enabled(c *gin.Context) bool {
+	if h.overrides != nil {
+		return true
+	}
+	c.JSON(http.StatusNotFound, gin.H{
+		"success": false,
+		"error": gin.H{
+			"code":    "RATE_LIMIT_DISABLED",
+			"message": "Rate limiting is disabled",
+		},
+	})
+	return false
+}
+
+func (h *RateLimitOverrideHandler) invalid(c *gin.Context, message string) {
+	c.JSON(http.StatusBadRequest, gin.H{
+		"success": false,
+		"error": gin.H{
+			"code":    "INVALID_REQUEST",
+			"message": message,
+		},
+	})
+}
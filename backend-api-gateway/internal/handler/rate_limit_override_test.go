@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-api-gateway/internal/middleware"
+)
+
+func newTestRateLimitOverrideHandler(t *testing.T) (*RateLimitOverrideHandler, *middleware.RateLimitOverrides) {
+	t.Helper()
+	overrides := middleware.NewRateLimitOverrides(middleware.NewMemoryRateLimitOverrideStore(), middleware.DefaultRateLimitOverrideConfig("secret"))
+	t.Cleanup(overrides.Stop)
+	return NewRateLimitOverrideHandler(overrides), overrides
+}
+
+func TestRateLimitOverrideHandler_Put(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantStatus  int
+		wantSubject string
+	}{
+		{"user limit", `{"user_id":"u1","requests_per_second":500,"burst_size":50}`, http.StatusOK, "user:u1"},
+		{"api key bypass", `{"api_key":"partner-key","bypass":true}`, http.StatusOK, middleware.APIKeyOverrideSubject("partner-key")},
+		{"both subjects", `{"user_id":"u1","api_key":"partner-key","bypass":true}`, http.StatusBadRequest, ""},
+		{"no subject", `{"bypass":true}`, http.StatusBadRequest, ""},
+		{"no limit", `{"user_id":"u1"}`, http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, overrides := newTestRateLimitOverrideHandler(t)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPut, "/api/v1/gateway/rate-limit/overrides", bytes.NewBufferString(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("user_id", "admin-1")
+
+			handler.Put(c)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			list := overrides.List()
+			if tt.wantSubject == "" {
+				if len(list) != 0 {
+					t.Errorf("Expected no override to be saved, got %+v", list)
+				}
+				return
+			}
+			if len(list) != 1 || list[0].Subject != tt.wantSubject || list[0].UpdatedBy != "admin-1" {
+				t.Errorf("Expected override for %s updated by admin-1, got %+v", tt.wantSubject, list)
+			}
+			if contains(w.Body.String(), "partner-key") {
+				t.Errorf("Expected the API key not to be echoed: %s", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestRateLimitOverrideHandler_Delete(t *testing.T) {
+	handler, overrides := newTestRateLimitOverrideHandler(t)
+	_ = overrides.Put(context.Background(), &middleware.RateLimitOverride{Subject: "user:u1", Bypass: true})
+
+	for _, wantStatus := range []int{http.StatusOK, http.StatusNotFound} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodDelete, "/api/v1/gateway/rate-limit/overrides/user:u1", nil)
+		c.Params = gin.Params{{Key: "subject", Value: "user:u1"}}
+
+		handler.Delete(c)
+
+		if w.Code != wantStatus {
+			t.Errorf("Expected status %d, got %d", wantStatus, w.Code)
+		}
+	}
+}
+
+func TestRateLimitOverrideHandler_Disabled(t *testing.T) {
+	handler := NewRateLimitOverrideHandler(nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/gateway/rate-limit/overrides", nil)
+
+	handler.List(c)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-api-gateway/internal/middleware"
+)
+
+// RateLimitShadowHandler serves the rate limiter shadow mode report
+type RateLimitShadowHandler struct {
+	recorder *middleware.ShadowRecorder
+}
+
+// NewRateLimitShadowHandler creates a new RateLimitShadowHandler
+func NewRateLimitShadowHandler(recorder *middleware.ShadowRecorder) *RateLimitShadowHandler {
+	return &RateLimitShadowHandler{
+		recorder: recorder,
+	}
+}
+
+// Report returns what the rate limiter would have blocked
+// Query params: hours (window, default 1, capped at retention), top (users/tenants, default 20, max 100)
+func (h *RateLimitShadowHandler) Report(c *gin.Context) {
+	if h.recorder == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "SHADOW_MODE_DISABLED",
+				"message": "Rate limiter is not running in shadow mode",
+			},
+		})
+		return
+	}
+
+	hours := 1
+	if v := c.Query("hours"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error": gin.H{
+					"code":    "INVALID_REQUEST",
+					"message": "hours must be a positive integer",
+				},
+			})
+			return
+		}
+		hours = n
+	}
+
+	top := middleware.DefaultShadowReportTop
+	if v := c.Query("top"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 && n <= 100 {
+			top = n
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	report, err := h.recorder.Report(ctx, time.Duration(hours)*time.Hour, top)
+	if err != nil {
+		_ = c.Error(err)
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "SHADOW_REPORT_UNAVAILABLE",
+				"message": "Failed to load shadow mode report",
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    report,
+	})
+}
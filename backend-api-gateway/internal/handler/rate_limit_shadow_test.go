@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-api-gateway/internal/middleware"
+)
+
+func TestRateLimitShadowHandler_Disabled(t *testing.T) {
+	handler := NewRateLimitShadowHandler(nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/gateway/rate-limit/shadow", nil)
+
+	handler.Report(c)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+	if !contains(w.Body.String(), "SHADOW_MODE_DISABLED") {
+		t.Error("Expected 'SHADOW_MODE_DISABLED' in response")
+	}
+}
+
+func TestRateLimitShadowHandler_Report(t *testing.T) {
+	recorder := middleware.NewShadowRecorder(middleware.NewMemoryShadowStore(time.Hour), time.Hour, time.Hour)
+	defer recorder.Stop()
+	recorder.Record("POST /api/v1/bookings", true, "user-1", "tenant-1")
+
+	handler := NewRateLimitShadowHandler(recorder)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/gateway/rate-limit/shadow?hours=2&top=5", nil)
+
+	handler.Report(c)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	body := w.Body.String()
+	for _, want := range []string{"POST /api/v1/bookings", "user-1", "tenant-1", "would_block"} {
+		if !contains(body, want) {
+			t.Errorf("Expected %q in response", want)
+		}
+	}
+}
+
+func TestRateLimitShadowHandler_InvalidHours(t *testing.T) {
+	recorder := middleware.NewShadowRecorder(middleware.NewMemoryShadowStore(time.Hour), time.Hour, time.Hour)
+	defer recorder.Stop()
+
+	handler := NewRateLimitShadowHandler(recorder)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/gateway/rate-limit/shadow?hours=abc", nil)
+
+	handler.Report(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
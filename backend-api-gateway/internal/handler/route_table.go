@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-api-gateway/internal/proxy"
+)
+
+// RouteTableHandler serves the admin API for inspecting and reloading the proxy's route table
+type RouteTableHandler struct {
+	proxy  *proxy.ReverseProxy
+	loader *proxy.RouteTableLoader
+}
+
+// NewRouteTableHandler creates a new RouteTableHandler. loader is nil when the gateway
+// serves the compiled-in route table, which can't be reloaded.
+func NewRouteTableHandler(rp *proxy.ReverseProxy, loader *proxy.RouteTableLoader) *RouteTableHandler {
+	return &RouteTableHandler{
+		proxy:  rp,
+		loader: loader,
+	}
+}
+
+// RouteResponse describes a route of the table
+type RouteResponse struct {
+	Path           string   `json:"path"`
+	Methods        []string `json:"methods,omitempty"`
+	Auth           bool     `json:"auth"`
+	Roles          []string `json:"roles,omitempty"`
+	RateLimitClass string   `json:"rate_limit_class,omitempty"`
+	Upstream       string   `json:"upstream"`
+	Timeout        string   `json:"timeout,omitempty"`
+	Description    string   `json:"description,omitempty"`
+}
+
+// List handles GET /api/v1/gateway/routes - returns the routes being served
+func (h *RouteTableHandler) List(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    h.response(h.proxy.Routes()),
+	})
+}
+
+// Reload handles POST /api/v1/gateway/routes/reload - re-reads the route table file
+func (h *RouteTableHandler) Reload(c *gin.Context) {
+	if h.loader == nil {
+		c.JSON(http.StatusConflict, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "ROUTE_TABLE_NOT_RELOADABLE",
+				"message": "Gateway serves the default route table (GATEWAY_ROUTES_FILE is not set)",
+			},
+		})
+		return
+	}
+
+	routes, err := h.loader.Reload()
+	if err != nil {
+		// The running routes are kept
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INVALID_ROUTE_TABLE",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    h.response(routes),
+	})
+}
+
+// response lists routes with the file they were loaded from
+func (h *RouteTableHandler) response(routes []proxy.RouteConfig) gin.H {
+	source := "default"
+	if h.loader != nil {
+		source = h.loader.Path()
+	}
+
+	items := make([]RouteResponse, 0, len(routes))
+	for _, route := range routes {
+		item := RouteResponse{
+			Path:           route.PathPrefix,
+			Methods:        route.AllowedMethods,
+			Auth:           route.RequireAuth,
+			Roles:          route.Roles,
+			RateLimitClass: route.RateLimitClass,
+			Upstream:       route.Service.Name,
+			Description:    route.Description,
+		}
+		if route.Service.Timeout > 0 {
+			item.Timeout = route.Service.Timeout.String()
+		}
+		items = append(items, item)
+	}
+	return gin.H{
+		"source": source,
+		"routes": items,
+	}
+}
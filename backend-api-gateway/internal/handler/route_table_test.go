@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-api-gateway/internal/proxy"
+)
+
+func TestRouteTableHandler_List(t *testing.T) {
+	rp := proxy.NewReverseProxy(proxy.ConfigFromEnv("", "", "", "", "secret"))
+	handler := NewRouteTableHandler(rp, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/gateway/routes", nil)
+
+	handler.List(c)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if !contains(w.Body.String(), `"source":"default"`) || !contains(w.Body.String(), `"path":"/api/v1/bookings"`) {
+		t.Errorf("Expected the default routes in response: %s", w.Body.String())
+	}
+}
+
+func TestRouteTableHandler_Reload(t *testing.T) {
+	rp := proxy.NewReverseProxy(proxy.ConfigFromEnv("", "", "", "", "secret"))
+	path := filepath.Join(t.TempDir(), "routes.json")
+
+	tests := []struct {
+		name       string
+		loader     *proxy.RouteTableLoader
+		table      string
+		wantStatus int
+	}{
+		{"default table", nil, "", http.StatusConflict},
+		{"valid file", proxy.NewRouteTableLoader(path, rp, nil, nil),
+			`{"upstreams": {"a": {"url": "http://a:80"}}, "routes": [{"path": "/api/v1/a", "upstream": "a"}]}`, http.StatusOK},
+		{"invalid file", proxy.NewRouteTableLoader(path, rp, nil, nil),
+			`{"upstreams": {"a": {"url": "http://a:80"}}, "routes": [{"path": "/api/v1/a", "upstream": "b"}]}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.table != "" {
+				if err := os.WriteFile(path, []byte(tt.table), 0o644); err != nil {
+					t.Fatalf("WriteFile() error = %v", err)
+				}
+			}
+			handler := NewRouteTableHandler(rp, tt.loader)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/gateway/routes/reload", nil)
+
+			handler.Reload(c)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/metering"
+)
+
+// defaultUsageDays is the range returned when no from/to is given
+const defaultUsageDays = 30
+
+// TenantUsageHandler serves per-tenant usage aggregates
+type TenantUsageHandler struct {
+	recorder *metering.Recorder
+	store    metering.Store
+	quota    *metering.Quota
+}
+
+// NewTenantUsageHandler creates a new TenantUsageHandler
+func NewTenantUsageHandler(recorder *metering.Recorder, store metering.Store, quota *metering.Quota) *TenantUsageHandler {
+	return &TenantUsageHandler{
+		recorder: recorder,
+		store:    store,
+		quota:    quota,
+	}
+}
+
+// Usage returns a tenant's daily usage, totals and plan limits
+// Query params: from, to (YYYY-MM-DD, UTC, inclusive; default the last 30 days, at most 92 days)
+func (h *TenantUsageHandler) Usage(c *gin.Context) {
+	if h.recorder == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "USAGE_METERING_DISABLED",
+				"message": "Usage metering is disabled",
+			},
+		})
+		return
+	}
+
+	to := metering.Day(time.Now())
+	from := to.AddDate(0, 0, -(defaultUsageDays - 1))
+	for param, target := range map[string]*time.Time{"from": &from, "to": &to} {
+		v := c.Query(param)
+		if v == "" {
+			continue
+		}
+		day, err := time.Parse(metering.DateLayout, v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error": gin.H{
+					"code":    "INVALID_REQUEST",
+					"message": param + " must be a date in YYYY-MM-DD format",
+				},
+			})
+			return
+		}
+		*target = day
+	}
+
+	days, err := metering.Days(from, to)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "INVALID_REQUEST",
+				"message": err.Error(),
+			},
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	tenantID := c.Param("id")
+
+	// Include counts still buffered in this instance
+	if err := h.recorder.Flush(ctx); err != nil {
+		_ = c.Error(err)
+	}
+	usage, err := h.store.Daily(ctx, tenantID, days)
+	if err != nil {
+		_ = c.Error(err)
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "USAGE_UNAVAILABLE",
+				"message": "Failed to load tenant usage",
+			},
+		})
+		return
+	}
+
+	data := gin.H{
+		"tenant_id": tenantID,
+		"from":      days[0].Format(metering.DateLayout),
+		"to":        days[len(days)-1].Format(metering.DateLayout),
+		"days":      usage,
+		"totals":    metering.Total(usage),
+	}
+	if h.quota != nil {
+		plan, limits := h.quota.Plan(ctx, tenantID)
+		if limits == nil {
+			limits = metering.Counts{}
+		}
+		data["plan"] = gin.H{
+			"name":         plan,
+			"daily_limits": limits,
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    data,
+	})
+}
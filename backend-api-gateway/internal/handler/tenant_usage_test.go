@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/metering"
+)
+
+func newTenantUsageContext(w *httptest.ResponseRecorder, target string) *gin.Context {
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	c.Params = gin.Params{{Key: "id", Value: "tenant-1"}}
+	return c
+}
+
+func TestTenantUsageHandler_Disabled(t *testing.T) {
+	handler := NewTenantUsageHandler(nil, nil, nil)
+
+	w := httptest.NewRecorder()
+	handler.Usage(newTenantUsageContext(w, "/api/v1/admin/tenants/tenant-1/usage"))
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestTenantUsageHandler_Usage(t *testing.T) {
+	store := metering.NewMemoryStore(0)
+	recorder := metering.NewRecorder(store, time.Hour)
+	defer recorder.Stop()
+	quota := metering.NewQuota(metering.QuotaConfig{
+		Store:       store,
+		Plans:       metering.Plans{"free": {metering.MetricAPICalls: 1000}},
+		DefaultPlan: "free",
+	})
+
+	yesterday := time.Now().AddDate(0, 0, -1)
+	_ = store.Add(context.Background(), yesterday, map[string]metering.Counts{"tenant-1": {metering.MetricBookingsCreated: 4}})
+	recorder.Record("tenant-1", metering.MetricAPICalls, 3)
+
+	handler := NewTenantUsageHandler(recorder, store, quota)
+
+	w := httptest.NewRecorder()
+	from := yesterday.UTC().Format(metering.DateLayout)
+	handler.Usage(newTenantUsageContext(w, "/api/v1/admin/tenants/tenant-1/usage?from="+from))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	body := w.Body.String()
+	for _, want := range []string{`"from":"` + from + `"`, `"api_calls":3`, `"bookings_created":4`, `"name":"free"`, `"daily_limits":{"api_calls":1000}`} {
+		if !contains(body, want) {
+			t.Errorf("Expected %q in response: %s", want, body)
+		}
+	}
+}
+
+func TestTenantUsageHandler_InvalidRange(t *testing.T) {
+	store := metering.NewMemoryStore(0)
+	recorder := metering.NewRecorder(store, time.Hour)
+	defer recorder.Stop()
+
+	handler := NewTenantUsageHandler(recorder, store, nil)
+
+	for _, query := range []string{"?from=yesterday", "?from=2026-02-01&to=2026-01-01", "?from=2025-01-01&to=2026-01-01"} {
+		w := httptest.NewRecorder()
+		handler.Usage(newTenantUsageContext(w, "/api/v1/admin/tenants/tenant-1/usage"+query))
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status %d, got %d", query, http.StatusBadRequest, w.Code)
+		}
+	}
+}
@@ -35,16 +35,22 @@ func DefaultCORSConfig() CORSConfig {
 			"Content-Length",
 			"Accept",
 			"Accept-Encoding",
+			"Accept-Language",
 			"Authorization",
 			"X-Request-ID",
 			"X-Requested-With",
 			"X-Idempotency-Key",
 			"X-Queue-Pass",
+			// gRPC-web and Connect clients
+			"X-Grpc-Web",
+			"X-User-Agent",
+			"Connect-Protocol-Version",
 		},
 		ExposeHeaders: []string{
 			"Content-Length",
 			"Content-Type",
 			"X-Request-ID",
+			"X-Error-Code",
 		},
 		AllowCredentials: true,
 		MaxAge:           86400, // 24 hours
@@ -0,0 +1,438 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/i18n"
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+	"github.com/redis/go-redis/v9"
+)
+
+// Maintenance mode defaults
+const (
+	DefaultMaintenanceKey             = "gateway:maintenance"
+	DefaultMaintenanceRefreshInterval = 2 * time.Second
+	DefaultMaintenanceMessage         = "The service is undergoing scheduled maintenance. Please try again later."
+
+	// MaintenanceAPIPath is the admin API that toggles maintenance mode; it is never blocked
+	MaintenanceAPIPath = "/api/v1/gateway/maintenance"
+)
+
+// MaintenanceState is the maintenance mode switch shared by all gateway instances
+type MaintenanceState struct {
+	Enabled bool   `json:"enabled"`
+	Message string `json:"message,omitempty"`
+	// Optional schedule; maintenance is only active inside [StartsAt, EndsAt)
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+	EndsAt   *time.Time `json:"ends_at,omitempty"`
+	// Allowlists for traffic that keeps flowing during maintenance
+	AllowPaths []string  `json:"allow_paths,omitempty"` // path prefixes
+	AllowIPs   []string  `json:"allow_ips,omitempty"`   // IPs or CIDRs
+	AllowRoles []string  `json:"allow_roles,omitempty"` // JWT roles
+	UpdatedBy  string    `json:"updated_by,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// IsActive reports whether maintenance is in effect at now
+func (s *MaintenanceState) IsActive(now time.Time) bool {
+	if s == nil || !s.Enabled {
+		return false
+	}
+	if s.StartsAt != nil && now.Before(*s.StartsAt) {
+		return false
+	}
+	if s.EndsAt != nil && !now.Before(*s.EndsAt) {
+		return false
+	}
+	return true
+}
+
+// Validate checks the schedule and allowlisted IPs
+func (s *MaintenanceState) Validate() error {
+	if s.StartsAt != nil && s.EndsAt != nil && !s.EndsAt.After(*s.StartsAt) {
+		return errors.New("ends_at must be after starts_at")
+	}
+	for _, ip := range s.AllowIPs {
+		if _, err := parseMaintenanceIP(ip); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MaintenanceStore persists the maintenance state
+type MaintenanceStore interface {
+	// Get returns the current state, or nil if maintenance was never configured
+	Get(ctx context.Context) (*MaintenanceState, error)
+	// Set replaces the current state
+	Set(ctx context.Context, state *MaintenanceState) error
+}
+
+// MaintenanceConfig holds configuration for the maintenance mode middleware
+type MaintenanceConfig struct {
+	// Secret key for reading the role from the caller's access token
+	JWTSecret string
+	// How often the state is reloaded from the store
+	RefreshInterval time.Duration
+	// Path prefixes that are never blocked (health checks, the admin API)
+	AlwaysAllowPaths []string
+	// Roles that are never blocked
+	AlwaysAllowRoles []string
+}
+
+// DefaultMaintenanceConfig returns sensible defaults
+func DefaultMaintenanceConfig(jwtSecret string) MaintenanceConfig {
+	return MaintenanceConfig{
+		JWTSecret:        jwtSecret,
+		RefreshInterval:  DefaultMaintenanceRefreshInterval,
+		AlwaysAllowPaths: []string{"/health", "/ready", MaintenanceAPIPath},
+		AlwaysAllowRoles: []string{"admin"},
+	}
+}
+
+// maintenanceSnapshot is a loaded state with its parsed IP allowlist
+type maintenanceSnapshot struct {
+	state *MaintenanceState
+	nets  []*net.IPNet
+}
+
+// MaintenanceGuard caches the maintenance state and refreshes it in the
+// background, so the hot path never waits on Redis. When the store is
+// unreachable the last known state is kept.
+type MaintenanceGuard struct {
+	store  MaintenanceStore
+	config MaintenanceConfig
+	now    func() time.Time
+
+	mu       sync.RWMutex
+	snapshot *maintenanceSnapshot
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// NewMaintenanceGuard creates a guard, loads the current state and starts its refresh loop
+func NewMaintenanceGuard(store MaintenanceStore, config MaintenanceConfig) *MaintenanceGuard {
+	if config.RefreshInterval <= 0 {
+		config.RefreshInterval = DefaultMaintenanceRefreshInterval
+	}
+
+	g := &MaintenanceGuard{
+		store:    store,
+		config:   config,
+		now:      time.Now,
+		snapshot: &maintenanceSnapshot{},
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.RefreshInterval)
+	_ = g.Refresh(ctx) // Starts disabled if the store is unreachable
+	cancel()
+
+	go g.refreshLoop()
+
+	return g
+}
+
+// State returns the cached maintenance state, or nil if none is configured
+func (g *MaintenanceGuard) State() *MaintenanceState {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.snapshot.state
+}
+
+// Active reports whether maintenance is currently in effect
+func (g *MaintenanceGuard) Active() bool {
+	return g.State().IsActive(g.now())
+}
+
+// Set stores a new state and applies it to this instance immediately;
+// other instances pick it up on their next refresh.
+func (g *MaintenanceGuard) Set(ctx context.Context, state *MaintenanceState) error {
+	if err := state.Validate(); err != nil {
+		return err
+	}
+	if err := g.store.Set(ctx, state); err != nil {
+		return err
+	}
+	g.apply(state)
+	return nil
+}
+
+// Refresh reloads the state from the store
+func (g *MaintenanceGuard) Refresh(ctx context.Context) error {
+	state, err := g.store.Get(ctx)
+	if err != nil {
+		return err
+	}
+	g.apply(state)
+	return nil
+}
+
+// Stop stops the refresh loop
+func (g *MaintenanceGuard) Stop() {
+	g.stopOnce.Do(func() {
+		close(g.stop)
+		<-g.done
+	})
+}
+
+func (g *MaintenanceGuard) apply(state *MaintenanceState) {
+	snapshot := &maintenanceSnapshot{state: state}
+	if state != nil {
+		for _, ip := range state.AllowIPs {
+			if ipNet, err := parseMaintenanceIP(ip); err == nil {
+				snapshot.nets = append(snapshot.nets, ipNet)
+			}
+		}
+	}
+
+	g.mu.Lock()
+	g.snapshot = snapshot
+	g.mu.Unlock()
+}
+
+func (g *MaintenanceGuard) refreshLoop() {
+	defer close(g.done)
+
+	ticker := time.NewTicker(g.config.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), g.config.RefreshInterval)
+			_ = g.Refresh(ctx) // Keep the last known state on errors
+			cancel()
+		case <-g.stop:
+			return
+		}
+	}
+}
+
+// allowed reports whether the request may pass during maintenance
+func (g *MaintenanceGuard) allowed(c *gin.Context, snapshot *maintenanceSnapshot) bool {
+	path := c.Request.URL.Path
+	if matchPathPrefix(path, g.config.AlwaysAllowPaths) || matchPathPrefix(path, snapshot.state.AllowPaths) {
+		return true
+	}
+
+	if ip := net.ParseIP(c.ClientIP()); ip != nil {
+		for _, ipNet := range snapshot.nets {
+			if ipNet.Contains(ip) {
+				return true
+			}
+		}
+	}
+
+	if len(g.config.AlwaysAllowRoles) == 0 && len(snapshot.state.AllowRoles) == 0 {
+		return false
+	}
+	role := g.callerRole(c)
+	if role == "" {
+		return false
+	}
+	return containsString(g.config.AlwaysAllowRoles, role) || containsString(snapshot.state.AllowRoles, role)
+}
+
+// callerRole returns the role claim of a valid Bearer token, or "" if there is none.
+// The gateway runs before JWT validation, so the token is verified here.
+func (g *MaintenanceGuard) callerRole(c *gin.Context) string {
+	authHeader := c.GetHeader("Authorization")
+	const bearerPrefix = "Bearer "
+	if !strings.HasPrefix(authHeader, bearerPrefix) || g.config.JWTSecret == "" {
+		return ""
+	}
+
+	token, err := jwt.Parse(authHeader[len(bearerPrefix):], func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return []byte(g.config.JWTSecret), nil
+	})
+	if err != nil || !token.Valid {
+		return ""
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return ""
+	}
+	role, _ := claims["role"].(string)
+	return role
+}
+
+// Maintenance creates a middleware that rejects requests with 503 while
+// maintenance mode is active, except for allowlisted paths, IPs and roles
+func Maintenance(guard *MaintenanceGuard) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		guard.mu.RLock()
+		snapshot := guard.snapshot
+		guard.mu.RUnlock()
+
+		now := guard.now()
+		if !snapshot.state.IsActive(now) || guard.allowed(c, snapshot) {
+			c.Next()
+			return
+		}
+
+		state := snapshot.state
+		message := state.Message
+		if message == "" {
+			message = i18n.T(i18n.FromContext(c.Request.Context()), "MAINTENANCE_MODE", DefaultMaintenanceMessage)
+		}
+
+		maintenance := gin.H{
+			"message": message,
+		}
+		if state.StartsAt != nil {
+			maintenance["starts_at"] = state.StartsAt.UTC()
+		}
+		if state.EndsAt != nil {
+			maintenance["ends_at"] = state.EndsAt.UTC()
+			retryAfter := int(state.EndsAt.Sub(now).Seconds()) + 1
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+		}
+
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+			"success": false,
+			"error": gin.H{
+				"code":    "MAINTENANCE_MODE",
+				"message": message,
+			},
+			"maintenance": maintenance,
+		})
+	}
+}
+
+// matchPathPrefix reports whether path equals or is nested under one of prefixes
+func matchPathPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if prefix == "" {
+			continue
+		}
+		if path == prefix || strings.HasPrefix(path, strings.TrimSuffix(prefix, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// parseMaintenanceIP parses an IP or CIDR; a bare IP matches only itself
+func parseMaintenanceIP(value string) (*net.IPNet, error) {
+	if strings.Contains(value, "/") {
+		_, ipNet, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q", value)
+		}
+		return ipNet, nil
+	}
+
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP address %q", value)
+	}
+	bits := 32
+	if ip.To4() == nil {
+		bits = 128
+	} else {
+		ip = ip.To4()
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+}
+
+// MemoryMaintenanceStore keeps the maintenance state in process memory.
+// Used when the gateway runs without Redis; the state only applies to this instance.
+type MemoryMaintenanceStore struct {
+	mu    sync.Mutex
+	state *MaintenanceState
+}
+
+// NewMemoryMaintenanceStore creates an in-memory maintenance store
+func NewMemoryMaintenanceStore() *MemoryMaintenanceStore {
+	return &MemoryMaintenanceStore{}
+}
+
+// Get returns the stored state
+func (s *MemoryMaintenanceStore) Get(ctx context.Context) (*MaintenanceState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state, nil
+}
+
+// Set replaces the stored state
+func (s *MemoryMaintenanceStore) Set(ctx context.Context, state *MaintenanceState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = state
+	return nil
+}
+
+// RedisMaintenanceStore keeps the maintenance state as a JSON document in a
+// single Redis key, so toggling it affects every gateway instance.
+type RedisMaintenanceStore struct {
+	client *pkgredis.Client
+	key    string
+}
+
+// NewRedisMaintenanceStore creates a Redis-backed maintenance store
+func NewRedisMaintenanceStore(client *pkgredis.Client, key string) *RedisMaintenanceStore {
+	if key == "" {
+		key = DefaultMaintenanceKey
+	}
+	return &RedisMaintenanceStore{
+		client: client,
+		key:    key,
+	}
+}
+
+// Get loads the state from Redis
+func (s *RedisMaintenanceStore) Get(ctx context.Context) (*MaintenanceState, error) {
+	raw, err := s.client.Get(ctx, s.key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to load maintenance state: %w", err)
+	}
+
+	var state MaintenanceState
+	if err := json.Unmarshal([]byte(raw), &state); err != nil {
+		return nil, fmt.Errorf("failed to decode maintenance state: %w", err)
+	}
+	return &state, nil
+}
+
+// Set writes the state to Redis without expiry
+func (s *RedisMaintenanceStore) Set(ctx context.Context, state *MaintenanceState) error {
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode maintenance state: %w", err)
+	}
+	if err := s.client.Set(ctx, s.key, raw, 0).Err(); err != nil {
+		return fmt.Errorf("failed to save maintenance state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testMaintenanceSecret = "maintenance-secret"
+
+func newTestMaintenanceGuard(t *testing.T, state *MaintenanceState) *MaintenanceGuard {
+	t.Helper()
+	guard := NewMaintenanceGuard(NewMemoryMaintenanceStore(), DefaultMaintenanceConfig(testMaintenanceSecret))
+	t.Cleanup(guard.Stop)
+	if state != nil {
+		if err := guard.Set(context.Background(), state); err != nil {
+			t.Fatalf("Set failed: %v", err)
+		}
+	}
+	return guard
+}
+
+func setupMaintenanceRouter(guard *MaintenanceGuard) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Maintenance(guard))
+	r.NoRoute(func(c *gin.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+	return r
+}
+
+func maintenanceToken(t *testing.T, role string) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": "user-1",
+		"role":    role,
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	})
+	signed, err := token.SignedString([]byte(testMaintenanceSecret))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signed
+}
+
+func TestMaintenanceState_IsActive(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name  string
+		state *MaintenanceState
+		want  bool
+	}{
+		{"not configured", nil, false},
+		{"disabled", &MaintenanceState{}, false},
+		{"enabled", &MaintenanceState{Enabled: true}, true},
+		{"scheduled", &MaintenanceState{Enabled: true, StartsAt: &future}, false},
+		{"started", &MaintenanceState{Enabled: true, StartsAt: &past, EndsAt: &future}, true},
+		{"ended", &MaintenanceState{Enabled: true, EndsAt: &past}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.state.IsActive(now); got != tt.want {
+				t.Errorf("IsActive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaintenanceState_Validate(t *testing.T) {
+	now := time.Now()
+	earlier := now.Add(-time.Minute)
+
+	if err := (&MaintenanceState{StartsAt: &now, EndsAt: &earlier}).Validate(); err == nil {
+		t.Error("Expected error for end before start")
+	}
+	if err := (&MaintenanceState{AllowIPs: []string{"10.0.0.0/33"}}).Validate(); err == nil {
+		t.Error("Expected error for invalid CIDR")
+	}
+	if err := (&MaintenanceState{AllowIPs: []string{"not-an-ip"}}).Validate(); err == nil {
+		t.Error("Expected error for invalid IP")
+	}
+	if err := (&MaintenanceState{AllowIPs: []string{"10.0.0.0/8", "192.168.1.1", "::1"}}).Validate(); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestMaintenance_Inactive(t *testing.T) {
+	r := setupMaintenanceRouter(newTestMaintenanceGuard(t, nil))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/events", nil))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+}
+
+func TestMaintenance_RejectsWithPayload(t *testing.T) {
+	endsAt := time.Now().Add(30 * time.Minute)
+	r := setupMaintenanceRouter(newTestMaintenanceGuard(t, &MaintenanceState{
+		Enabled: true,
+		Message: "Upgrading the database",
+		EndsAt:  &endsAt,
+	}))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/bookings", nil))
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected status %d, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+	body := w.Body.String()
+	for _, want := range []string{"MAINTENANCE_MODE", "Upgrading the database", "ends_at"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected %q in response: %s", want, body)
+		}
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("Expected Retry-After header when an end time is scheduled")
+	}
+}
+
+func TestMaintenance_Allowlist(t *testing.T) {
+	r := setupMaintenanceRouter(newTestMaintenanceGuard(t, &MaintenanceState{
+		Enabled:    true,
+		AllowPaths: []string{"/api/v1/auth/"},
+		AllowIPs:   []string{"10.1.0.0/16", "192.168.1.50"},
+		AllowRoles: []string{"organizer"},
+	}))
+
+	tests := []struct {
+		name       string
+		path       string
+		remoteAddr string
+		role       string
+		wantStatus int
+	}{
+		{"health check", "/health", "1.2.3.4:1000", "", http.StatusOK},
+		{"readiness check", "/ready", "1.2.3.4:1000", "", http.StatusOK},
+		{"admin API", MaintenanceAPIPath, "1.2.3.4:1000", "", http.StatusOK},
+		{"allowlisted path", "/api/v1/auth/login", "1.2.3.4:1000", "", http.StatusOK},
+		{"path prefix only matches whole segments", "/api/v1/authz", "1.2.3.4:1000", "", http.StatusServiceUnavailable},
+		{"allowlisted CIDR", "/api/v1/bookings", "10.1.2.3:1000", "", http.StatusOK},
+		{"allowlisted IP", "/api/v1/bookings", "192.168.1.50:1000", "", http.StatusOK},
+		{"other IP", "/api/v1/bookings", "192.168.1.51:1000", "", http.StatusServiceUnavailable},
+		{"admin role", "/api/v1/bookings", "1.2.3.4:1000", "admin", http.StatusOK},
+		{"allowlisted role", "/api/v1/bookings", "1.2.3.4:1000", "organizer", http.StatusOK},
+		{"other role", "/api/v1/bookings", "1.2.3.4:1000", "customer", http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.role != "" {
+				req.Header.Set("Authorization", "Bearer "+maintenanceToken(t, tt.role))
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestMaintenance_IgnoresForgedRole(t *testing.T) {
+	r := setupMaintenanceRouter(newTestMaintenanceGuard(t, &MaintenanceState{Enabled: true}))
+
+	forged, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": "user-1",
+		"role":    "admin",
+	}).SignedString([]byte("wrong-secret"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/bookings", nil)
+	req.Header.Set("Authorization", "Bearer "+forged)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status %d, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+}
+
+func TestMaintenanceGuard_RefreshPicksUpStoreChanges(t *testing.T) {
+	store := NewMemoryMaintenanceStore()
+	guard := NewMaintenanceGuard(store, DefaultMaintenanceConfig(testMaintenanceSecret))
+	defer guard.Stop()
+
+	if guard.Active() {
+		t.Fatal("Expected maintenance to be inactive")
+	}
+
+	// Another gateway instance enables maintenance
+	_ = store.Set(context.Background(), &MaintenanceState{Enabled: true})
+	if err := guard.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+	if !guard.Active() {
+		t.Error("Expected maintenance to be active after refresh")
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/i18n"
 	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
 )
 
@@ -206,7 +207,7 @@ func QueuePassMiddleware(config QueuePassConfig) gin.HandlerFunc {
 					"success": false,
 					"error": gin.H{
 						"code":    "QUEUE_REQUIRED",
-						"message": "High traffic detected. Please join the queue first.",
+						"message": i18n.T(i18n.FromContext(c.Request.Context()), "QUEUE_REQUIRED", "High traffic detected. Please join the queue first."),
 					},
 				})
 				return
@@ -0,0 +1,416 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+)
+
+// Rate limit override defaults
+const (
+	DefaultRateLimitOverrideKey             = "gateway:rate_limit_overrides"
+	DefaultRateLimitOverrideRefreshInterval = 5 * time.Second
+
+	// HeaderAPIKey carries the API key of partner integrations
+	HeaderAPIKey = "X-API-Key"
+
+	// overrideRule names the limit of requests with an override in shadow mode reports
+	overrideRule = "override"
+)
+
+// Override subject kinds; a subject is the kind and an ID joined by a colon
+const (
+	OverrideSubjectUser   = "user"    // user:<user ID from the access token>
+	OverrideSubjectAPIKey = "api_key" // api_key:<SHA-256 of the API key, hex>
+)
+
+// RateLimitOverride replaces the endpoint rules for one user or API key, e.g. for partner
+// integrations that need higher limits or VIP accounts that are never limited
+type RateLimitOverride struct {
+	Subject           string     `json:"subject"`
+	RequestsPerSecond int        `json:"requests_per_second,omitempty"`
+	BurstSize         int        `json:"burst_size,omitempty"`
+	Bypass            bool       `json:"bypass,omitempty"` // Never rate limited
+	Note              string     `json:"note,omitempty"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"` // Optional; applies until deleted when omitted
+	UpdatedBy         string     `json:"updated_by,omitempty"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// UserOverrideSubject returns the override subject of a user
+func UserOverrideSubject(userID string) string {
+	return OverrideSubjectUser + ":" + userID
+}
+
+// APIKeyOverrideSubject returns the override subject of an API key. Only a hash of the
+// key is stored, so overrides can be listed without exposing partner keys.
+func APIKeyOverrideSubject(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+	return OverrideSubjectAPIKey + ":" + hex.EncodeToString(sum[:])
+}
+
+// IsActive reports whether the override applies at now
+func (o *RateLimitOverride) IsActive(now time.Time) bool {
+	return o != nil && (o.ExpiresAt == nil || now.Before(*o.ExpiresAt))
+}
+
+// Validate checks the subject and that the override either bypasses or sets a limit
+func (o *RateLimitOverride) Validate() error {
+	kind, id, ok := strings.Cut(o.Subject, ":")
+	if !ok || id == "" || (kind != OverrideSubjectUser && kind != OverrideSubjectAPIKey) {
+		return fmt.Errorf("invalid override subject %q", o.Subject)
+	}
+	if o.Bypass {
+		return nil
+	}
+	if o.RequestsPerSecond <= 0 || o.BurstSize <= 0 {
+		return errors.New("requests_per_second and burst_size must be positive unless bypass is set")
+	}
+	return nil
+}
+
+// RateLimitOverrideStore persists rate limit overrides
+type RateLimitOverrideStore interface {
+	// List returns every stored override
+	List(ctx context.Context) ([]*RateLimitOverride, error)
+	// Put creates or replaces the override of its subject
+	Put(ctx context.Context, override *RateLimitOverride) error
+	// Delete removes the override of subject, returns false if there was none
+	Delete(ctx context.Context, subject string) (bool, error)
+}
+
+// RateLimitOverrideConfig holds configuration for rate limit overrides
+type RateLimitOverrideConfig struct {
+	// Secret key for reading the user from the caller's access token
+	JWTSecret string
+	// How often overrides are reloaded from the store
+	RefreshInterval time.Duration
+}
+
+// DefaultRateLimitOverrideConfig returns sensible defaults
+func DefaultRateLimitOverrideConfig(jwtSecret string) RateLimitOverrideConfig {
+	return RateLimitOverrideConfig{
+		JWTSecret:       jwtSecret,
+		RefreshInterval: DefaultRateLimitOverrideRefreshInterval,
+	}
+}
+
+// overrideSnapshot is a loaded set of overrides by subject
+type overrideSnapshot struct {
+	bySubject map[string]*RateLimitOverride
+	hasUsers  bool // Whether the caller's access token needs to be read
+}
+
+// RateLimitOverrides caches the overrides and refreshes them in the background, so the
+// hot path never waits on Redis. When the store is unreachable the last known overrides
+// are kept.
+type RateLimitOverrides struct {
+	store  RateLimitOverrideStore
+	config RateLimitOverrideConfig
+	now    func() time.Time
+
+	mu       sync.RWMutex
+	snapshot *overrideSnapshot
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// NewRateLimitOverrides creates the override cache, loads the stored overrides and starts
+// its refresh loop
+func NewRateLimitOverrides(store RateLimitOverrideStore, config RateLimitOverrideConfig) *RateLimitOverrides {
+	if config.RefreshInterval <= 0 {
+		config.RefreshInterval = DefaultRateLimitOverrideRefreshInterval
+	}
+
+	o := &RateLimitOverrides{
+		store:    store,
+		config:   config,
+		now:      time.Now,
+		snapshot: &overrideSnapshot{},
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.RefreshInterval)
+	_ = o.Refresh(ctx) // Starts without overrides if the store is unreachable
+	cancel()
+
+	go o.refreshLoop()
+
+	return o
+}
+
+// List returns the cached overrides ordered by subject, including expired ones
+func (o *RateLimitOverrides) List() []*RateLimitOverride {
+	o.mu.RLock()
+	snapshot := o.snapshot
+	o.mu.RUnlock()
+
+	overrides := make([]*RateLimitOverride, 0, len(snapshot.bySubject))
+	for _, override := range snapshot.bySubject {
+		overrides = append(overrides, override)
+	}
+	sort.Slice(overrides, func(i, j int) bool { return overrides[i].Subject < overrides[j].Subject })
+	return overrides
+}
+
+// Put stores an override and applies it to this instance immediately;
+// other instances pick it up on their next refresh.
+func (o *RateLimitOverrides) Put(ctx context.Context, override *RateLimitOverride) error {
+	if err := override.Validate(); err != nil {
+		return err
+	}
+	if err := o.store.Put(ctx, override); err != nil {
+		return err
+	}
+	o.update(func(bySubject map[string]*RateLimitOverride) {
+		bySubject[override.Subject] = override
+	})
+	return nil
+}
+
+// Delete removes an override, returns false if there was none
+func (o *RateLimitOverrides) Delete(ctx context.Context, subject string) (bool, error) {
+	deleted, err := o.store.Delete(ctx, subject)
+	if err != nil {
+		return false, err
+	}
+	o.update(func(bySubject map[string]*RateLimitOverride) {
+		delete(bySubject, subject)
+	})
+	return deleted, nil
+}
+
+// Refresh reloads the overrides from the store
+func (o *RateLimitOverrides) Refresh(ctx context.Context) error {
+	overrides, err := o.store.List(ctx)
+	if err != nil {
+		return err
+	}
+	bySubject := make(map[string]*RateLimitOverride, len(overrides))
+	for _, override := range overrides {
+		bySubject[override.Subject] = override
+	}
+	o.apply(bySubject)
+	return nil
+}
+
+// Stop stops the refresh loop
+func (o *RateLimitOverrides) Stop() {
+	o.stopOnce.Do(func() {
+		close(o.stop)
+		<-o.done
+	})
+}
+
+// Lookup returns the active override of the request's API key or, failing that, of the
+// user of its access token. It returns nil without reading the request when there are no
+// overrides, and is safe to call on a nil RateLimitOverrides.
+func (o *RateLimitOverrides) Lookup(c *gin.Context) *RateLimitOverride {
+	if o == nil {
+		return nil
+	}
+	o.mu.RLock()
+	snapshot := o.snapshot
+	o.mu.RUnlock()
+	if len(snapshot.bySubject) == 0 {
+		return nil
+	}
+
+	now := o.now()
+	if apiKey := c.GetHeader(HeaderAPIKey); apiKey != "" {
+		if override := snapshot.bySubject[APIKeyOverrideSubject(apiKey)]; override.IsActive(now) {
+			return override
+		}
+	}
+	if snapshot.hasUsers {
+		if userID := o.callerUserID(c); userID != "" {
+			if override := snapshot.bySubject[UserOverrideSubject(userID)]; override.IsActive(now) {
+				return override
+			}
+		}
+	}
+	return nil
+}
+
+// update applies a change to a copy of the cached overrides
+func (o *RateLimitOverrides) update(change func(map[string]*RateLimitOverride)) {
+	o.mu.RLock()
+	bySubject := make(map[string]*RateLimitOverride, len(o.snapshot.bySubject)+1)
+	for subject, override := range o.snapshot.bySubject {
+		bySubject[subject] = override
+	}
+	o.mu.RUnlock()
+
+	change(bySubject)
+	o.apply(bySubject)
+}
+
+func (o *RateLimitOverrides) apply(bySubject map[string]*RateLimitOverride) {
+	snapshot := &overrideSnapshot{bySubject: bySubject}
+	for subject := range bySubject {
+		if strings.HasPrefix(subject, OverrideSubjectUser+":") {
+			snapshot.hasUsers = true
+			break
+		}
+	}
+
+	o.mu.Lock()
+	o.snapshot = snapshot
+	o.mu.Unlock()
+}
+
+func (o *RateLimitOverrides) refreshLoop() {
+	defer close(o.done)
+
+	ticker := time.NewTicker(o.config.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), o.config.RefreshInterval)
+			_ = o.Refresh(ctx) // Keep the last known overrides on errors
+			cancel()
+		case <-o.stop:
+			return
+		}
+	}
+}
+
+// callerUserID returns the user_id claim of a valid Bearer token, or "" if there is none.
+// Rate limiting runs before JWT validation, so the token is verified here.
+func (o *RateLimitOverrides) callerUserID(c *gin.Context) string {
+	authHeader := c.GetHeader("Authorization")
+	const bearerPrefix = "Bearer "
+	if !strings.HasPrefix(authHeader, bearerPrefix) || o.config.JWTSecret == "" {
+		return ""
+	}
+
+	token, err := jwt.Parse(authHeader[len(bearerPrefix):], func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return []byte(o.config.JWTSecret), nil
+	})
+	if err != nil || !token.Valid {
+		return ""
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return ""
+	}
+	userID, _ := claims["user_id"].(string)
+	return userID
+}
+
+// MemoryRateLimitOverrideStore keeps overrides in process memory.
+// Used when the gateway runs without Redis; overrides only apply to this instance.
+type MemoryRateLimitOverrideStore struct {
+	mu        sync.Mutex
+	overrides map[string]*RateLimitOverride
+}
+
+// NewMemoryRateLimitOverrideStore creates an in-memory override store
+func NewMemoryRateLimitOverrideStore() *MemoryRateLimitOverrideStore {
+	return &MemoryRateLimitOverrideStore{overrides: make(map[string]*RateLimitOverride)}
+}
+
+// List returns the stored overrides
+func (s *MemoryRateLimitOverrideStore) List(ctx context.Context) ([]*RateLimitOverride, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	overrides := make([]*RateLimitOverride, 0, len(s.overrides))
+	for _, override := range s.overrides {
+		overrides = append(overrides, override)
+	}
+	return overrides, nil
+}
+
+// Put stores an override
+func (s *MemoryRateLimitOverrideStore) Put(ctx context.Context, override *RateLimitOverride) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.overrides[override.Subject] = override
+	return nil
+}
+
+// Delete removes an override
+func (s *MemoryRateLimitOverrideStore) Delete(ctx context.Context, subject string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.overrides[subject]
+	delete(s.overrides, subject)
+	return ok, nil
+}
+
+// RedisRateLimitOverrideStore keeps overrides as JSON documents in a Redis hash keyed by
+// subject, so changes affect every gateway instance
+type RedisRateLimitOverrideStore struct {
+	client *pkgredis.Client
+	key    string
+}
+
+// NewRedisRateLimitOverrideStore creates a Redis-backed override store
+func NewRedisRateLimitOverrideStore(client *pkgredis.Client, key string) *RedisRateLimitOverrideStore {
+	if key == "" {
+		key = DefaultRateLimitOverrideKey
+	}
+	return &RedisRateLimitOverrideStore{
+		client: client,
+		key:    key,
+	}
+}
+
+// List loads every override from Redis, skipping entries that fail to decode
+func (s *RedisRateLimitOverrideStore) List(ctx context.Context) ([]*RateLimitOverride, error) {
+	raw, err := s.client.HGetAll(ctx, s.key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rate limit overrides: %w", err)
+	}
+
+	overrides := make([]*RateLimitOverride, 0, len(raw))
+	for _, value := range raw {
+		var override RateLimitOverride
+		if err := json.Unmarshal([]byte(value), &override); err != nil {
+			continue
+		}
+		overrides = append(overrides, &override)
+	}
+	return overrides, nil
+}
+
+// Put writes an override to Redis without expiry; expired overrides stay listed until deleted
+func (s *RedisRateLimitOverrideStore) Put(ctx context.Context, override *RateLimitOverride) error {
+	raw, err := json.Marshal(override)
+	if err != nil {
+		return fmt.Errorf("failed to encode rate limit override: %w", err)
+	}
+	if err := s.client.HSet(ctx, s.key, override.Subject, raw).Err(); err != nil {
+		return fmt.Errorf("failed to save rate limit override: %w", err)
+	}
+	return nil
+}
+
+// Delete removes an override from Redis
+func (s *RedisRateLimitOverrideStore) Delete(ctx context.Context, subject string) (bool, error) {
+	removed, err := s.client.Client().HDel(ctx, s.key, subject).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete rate limit override: %w", err)
+	}
+	return removed > 0, nil
+}
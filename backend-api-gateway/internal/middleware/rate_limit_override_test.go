@@ -0,0 +1,157 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testOverrideSecret = "override-secret"
+
+func newTestRateLimitOverrides(t *testing.T, overrides ...*RateLimitOverride) *RateLimitOverrides {
+	t.Helper()
+	o := NewRateLimitOverrides(NewMemoryRateLimitOverrideStore(), DefaultRateLimitOverrideConfig(testOverrideSecret))
+	t.Cleanup(o.Stop)
+	for _, override := range overrides {
+		if err := o.Put(context.Background(), override); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+	return o
+}
+
+func overrideToken(t *testing.T, userID string) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": userID,
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	})
+	signed, err := token.SignedString([]byte(testOverrideSecret))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signed
+}
+
+func TestRateLimitOverride_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		override RateLimitOverride
+		wantErr  bool
+	}{
+		{"user limit", RateLimitOverride{Subject: "user:u1", RequestsPerSecond: 50, BurstSize: 10}, false},
+		{"api key bypass", RateLimitOverride{Subject: APIKeyOverrideSubject("key"), Bypass: true}, false},
+		{"unknown kind", RateLimitOverride{Subject: "ip:10.0.0.1", Bypass: true}, true},
+		{"missing id", RateLimitOverride{Subject: "user:", Bypass: true}, true},
+		{"no limit", RateLimitOverride{Subject: "user:u1"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.override.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPerEndpointRateLimiter_Overrides(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	expired := time.Now().Add(-time.Minute)
+	overrides := newTestRateLimitOverrides(t,
+		&RateLimitOverride{Subject: UserOverrideSubject("partner-1"), RequestsPerSecond: 100, BurstSize: 5},
+		&RateLimitOverride{Subject: APIKeyOverrideSubject("vip-key"), Bypass: true},
+		&RateLimitOverride{Subject: UserOverrideSubject("lapsed-1"), Bypass: true, ExpiresAt: &expired},
+	)
+
+	config := PerEndpointRateLimitConfig{
+		Default:         RateLimitConfig{RequestsPerSecond: 100, BurstSize: 2},
+		Overrides:       overrides,
+		CleanupInterval: time.Minute,
+		EntryTTL:        time.Minute,
+	}
+
+	_, r := gin.CreateTestContext(httptest.NewRecorder())
+	r.Use(PerEndpointRateLimiter(config))
+	r.NoRoute(func(c *gin.Context) {
+		c.String(http.StatusOK, "proxied")
+	})
+
+	send := func(setHeaders func(req *http.Request)) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/events", nil)
+		req.RemoteAddr = "192.168.1.1:12345"
+		setHeaders(req)
+		r.ServeHTTP(w, req)
+		return w
+	}
+	countAllowed := func(n int, setHeaders func(req *http.Request)) int {
+		allowed := 0
+		for i := 0; i < n; i++ {
+			if send(setHeaders).Code == http.StatusOK {
+				allowed++
+			}
+		}
+		return allowed
+	}
+
+	// The partner's own bucket gets its burst even though its IP is shared
+	partner := func(req *http.Request) { req.Header.Set("Authorization", "Bearer "+overrideToken(t, "partner-1")) }
+	if allowed := countAllowed(7, partner); allowed != 5 {
+		t.Errorf("Expected the partner override to allow 5 requests, got %d", allowed)
+	}
+
+	// A VIP API key is never limited
+	vip := func(req *http.Request) { req.Header.Set(HeaderAPIKey, "vip-key") }
+	if allowed := countAllowed(10, vip); allowed != 10 {
+		t.Errorf("Expected the VIP API key to bypass the limit, got %d allowed", allowed)
+	}
+	if w := send(vip); w.Header().Get("X-RateLimit-Bypass") != "override" {
+		t.Errorf("Expected the bypass header, got %q", w.Header().Get("X-RateLimit-Bypass"))
+	}
+
+	// Expired overrides, unknown keys and forged tokens fall back to the IP's default limit
+	lapsed := func(req *http.Request) { req.Header.Set("Authorization", "Bearer "+overrideToken(t, "lapsed-1")) }
+	forged := func(req *http.Request) {
+		req.Header.Set("Authorization", "Bearer forged."+overrideToken(t, "partner-1"))
+	}
+	unknown := func(req *http.Request) { req.Header.Set(HeaderAPIKey, "other-key") }
+	if allowed := countAllowed(1, lapsed) + countAllowed(1, forged) + countAllowed(1, unknown); allowed != 2 {
+		t.Errorf("Expected the default burst of 2 for requests without an active override, got %d", allowed)
+	}
+}
+
+func TestRateLimitOverrides_RefreshAndDelete(t *testing.T) {
+	store := NewMemoryRateLimitOverrideStore()
+	overrides := NewRateLimitOverrides(store, DefaultRateLimitOverrideConfig(testOverrideSecret))
+	t.Cleanup(overrides.Stop)
+
+	// Another instance wrote an override
+	_ = store.Put(context.Background(), &RateLimitOverride{Subject: "user:u1", Bypass: true})
+	if len(overrides.List()) != 0 {
+		t.Fatal("Expected no overrides before refresh")
+	}
+	if err := overrides.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+	if list := overrides.List(); len(list) != 1 || list[0].Subject != "user:u1" {
+		t.Fatalf("Expected the stored override after refresh, got %+v", list)
+	}
+
+	deleted, err := overrides.Delete(context.Background(), "user:u1")
+	if err != nil || !deleted {
+		t.Fatalf("Delete() = %v, %v, want true, nil", deleted, err)
+	}
+	if len(overrides.List()) != 0 {
+		t.Error("Expected the override to be removed")
+	}
+	if deleted, _ := overrides.Delete(context.Background(), "user:u1"); deleted {
+		t.Error("Expected deleting a missing override to report false")
+	}
+}
@@ -0,0 +1,513 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+	"github.com/redis/go-redis/v9"
+)
+
+// Shadow mode defaults
+const (
+	DefaultShadowFlushInterval = 5 * time.Second
+	DefaultShadowRetention     = 48 * time.Hour
+	DefaultShadowReportTop     = 20
+
+	// ShadowAnonymousUser labels would-block requests that carried no authenticated user
+	ShadowAnonymousUser = "anonymous"
+
+	// shadowDefaultRule names the fallback limit applied when no endpoint rule matches
+	shadowDefaultRule = "default"
+
+	shadowBucketLayout = "2006010215" // one bucket per UTC hour
+)
+
+// ShadowCounts holds would-block counters for one hour bucket
+type ShadowCounts struct {
+	Evaluated  map[string]int64 // requests checked, per rule
+	WouldBlock map[string]int64 // requests that would have been rejected, per rule
+	Users      map[string]int64 // would-block requests per user
+	Tenants    map[string]int64 // would-block requests per tenant
+}
+
+// NewShadowCounts creates an empty set of counters
+func NewShadowCounts() *ShadowCounts {
+	return &ShadowCounts{
+		Evaluated:  make(map[string]int64),
+		WouldBlock: make(map[string]int64),
+		Users:      make(map[string]int64),
+		Tenants:    make(map[string]int64),
+	}
+}
+
+// add merges other into c
+func (c *ShadowCounts) add(other *ShadowCounts) {
+	for k, v := range other.Evaluated {
+		c.Evaluated[k] += v
+	}
+	for k, v := range other.WouldBlock {
+		c.WouldBlock[k] += v
+	}
+	for k, v := range other.Users {
+		c.Users[k] += v
+	}
+	for k, v := range other.Tenants {
+		c.Tenants[k] += v
+	}
+}
+
+// ShadowRuleStat summarizes one rate limit rule over the report window
+type ShadowRuleStat struct {
+	Rule       string  `json:"rule"`
+	Evaluated  int64   `json:"evaluated"`
+	WouldBlock int64   `json:"would_block"`
+	BlockRatio float64 `json:"block_ratio"`
+}
+
+// ShadowCount is a would-block counter for a single user or tenant
+type ShadowCount struct {
+	ID         string `json:"id"`
+	WouldBlock int64  `json:"would_block"`
+}
+
+// ShadowReport shows what the rate limiter would have rejected over a time window
+type ShadowReport struct {
+	From            time.Time        `json:"from"`
+	To              time.Time        `json:"to"`
+	TotalEvaluated  int64            `json:"total_evaluated"`
+	TotalWouldBlock int64            `json:"total_would_block"`
+	Rules           []ShadowRuleStat `json:"rules"`
+	TopUsers        []ShadowCount    `json:"top_users"`
+	TopTenants      []ShadowCount    `json:"top_tenants"`
+}
+
+// ShadowStore persists shadow mode counters and reads them back for reporting
+type ShadowStore interface {
+	// Flush adds counts to the hour bucket starting at bucket
+	Flush(ctx context.Context, bucket time.Time, counts *ShadowCounts) error
+	// Report aggregates the given hour buckets, keeping the top users and tenants
+	Report(ctx context.Context, buckets []time.Time, top int) (*ShadowReport, error)
+}
+
+// ShadowRecorder aggregates would-block decisions in memory and periodically
+// flushes them to a ShadowStore, so the hot path never waits on Redis.
+type ShadowRecorder struct {
+	store         ShadowStore
+	flushInterval time.Duration
+	retention     time.Duration
+	now           func() time.Time
+
+	mu      sync.Mutex
+	pending map[int64]*ShadowCounts // keyed by bucket start (unix seconds)
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// NewShadowRecorder creates a recorder and starts its background flush loop
+func NewShadowRecorder(store ShadowStore, flushInterval, retention time.Duration) *ShadowRecorder {
+	if flushInterval <= 0 {
+		flushInterval = DefaultShadowFlushInterval
+	}
+	if retention <= 0 {
+		retention = DefaultShadowRetention
+	}
+
+	r := &ShadowRecorder{
+		store:         store,
+		flushInterval: flushInterval,
+		retention:     retention,
+		now:           time.Now,
+		pending:       make(map[int64]*ShadowCounts),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+
+	go r.flushLoop()
+
+	return r
+}
+
+// Retention returns how far back reports can look
+func (r *ShadowRecorder) Retention() time.Duration {
+	return r.retention
+}
+
+// Record counts one rate limit evaluation for rule.
+// User and tenant counters are only kept for requests that would have been blocked.
+func (r *ShadowRecorder) Record(rule string, wouldBlock bool, userID, tenantID string) {
+	bucket := shadowBucket(r.now()).Unix()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	counts, ok := r.pending[bucket]
+	if !ok {
+		counts = NewShadowCounts()
+		r.pending[bucket] = counts
+	}
+
+	counts.Evaluated[rule]++
+	if !wouldBlock {
+		return
+	}
+
+	counts.WouldBlock[rule]++
+	if userID == "" {
+		userID = ShadowAnonymousUser
+	}
+	counts.Users[userID]++
+	if tenantID != "" {
+		counts.Tenants[tenantID]++
+	}
+}
+
+// Flush writes pending counters to the store.
+// Buckets that fail to flush are merged back so they are retried on the next flush.
+func (r *ShadowRecorder) Flush(ctx context.Context) error {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = make(map[int64]*ShadowCounts)
+	r.mu.Unlock()
+
+	var firstErr error
+	for bucket, counts := range pending {
+		if err := r.store.Flush(ctx, time.Unix(bucket, 0).UTC(), counts); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			r.requeue(bucket, counts)
+		}
+	}
+	return firstErr
+}
+
+// requeue merges counts that could not be flushed back into pending
+func (r *ShadowRecorder) requeue(bucket int64, counts *ShadowCounts) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.pending[bucket]; ok {
+		existing.add(counts)
+		return
+	}
+	r.pending[bucket] = counts
+}
+
+// Report flushes pending counters and returns the report for the last window,
+// rounded up to whole hour buckets and capped at the retention period.
+func (r *ShadowRecorder) Report(ctx context.Context, window time.Duration, top int) (*ShadowReport, error) {
+	if err := r.Flush(ctx); err != nil {
+		return nil, fmt.Errorf("failed to flush shadow counters: %w", err)
+	}
+	if top <= 0 {
+		top = DefaultShadowReportTop
+	}
+
+	now := r.now()
+	buckets := shadowBuckets(now, window, r.retention)
+
+	report, err := r.store.Report(ctx, buckets, top)
+	if err != nil {
+		return nil, err
+	}
+	report.From = buckets[0]
+	report.To = now.UTC()
+	return report, nil
+}
+
+// Stop stops the flush loop after a final flush
+func (r *ShadowRecorder) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.stop)
+		<-r.done
+	})
+}
+
+func (r *ShadowRecorder) flushLoop() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), r.flushInterval)
+			_ = r.Flush(ctx) // Failed buckets stay pending for the next tick
+			cancel()
+		case <-r.stop:
+			ctx, cancel := context.WithTimeout(context.Background(), r.flushInterval)
+			_ = r.Flush(ctx)
+			cancel()
+			return
+		}
+	}
+}
+
+// shadowBucket returns the start of the UTC hour containing t
+func shadowBucket(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Hour)
+}
+
+// shadowBuckets returns the hour buckets covering window, oldest first
+func shadowBuckets(now time.Time, window, retention time.Duration) []time.Time {
+	if window > retention {
+		window = retention
+	}
+	hours := int((window + time.Hour - 1) / time.Hour)
+	if hours < 1 {
+		hours = 1
+	}
+
+	current := shadowBucket(now)
+	buckets := make([]time.Time, hours)
+	for i := 0; i < hours; i++ {
+		buckets[i] = current.Add(-time.Duration(hours-1-i) * time.Hour)
+	}
+	return buckets
+}
+
+// newShadowReport builds a report from per-rule counters and pre-ranked user and tenant counts
+func newShadowReport(evaluated, wouldBlock map[string]int64, users, tenants []ShadowCount) *ShadowReport {
+	report := &ShadowReport{
+		Rules:      make([]ShadowRuleStat, 0, len(evaluated)),
+		TopUsers:   users,
+		TopTenants: tenants,
+	}
+
+	for rule, total := range evaluated {
+		blocked := wouldBlock[rule]
+		stat := ShadowRuleStat{
+			Rule:       rule,
+			Evaluated:  total,
+			WouldBlock: blocked,
+		}
+		if total > 0 {
+			stat.BlockRatio = float64(blocked) / float64(total)
+		}
+		report.Rules = append(report.Rules, stat)
+		report.TotalEvaluated += total
+		report.TotalWouldBlock += blocked
+	}
+
+	// Rules that would block the most come first
+	sort.Slice(report.Rules, func(i, j int) bool {
+		if report.Rules[i].WouldBlock != report.Rules[j].WouldBlock {
+			return report.Rules[i].WouldBlock > report.Rules[j].WouldBlock
+		}
+		return report.Rules[i].Rule < report.Rules[j].Rule
+	})
+
+	return report
+}
+
+// topShadowCounts returns the n largest counters, highest first
+func topShadowCounts(counts map[string]int64, n int) []ShadowCount {
+	result := make([]ShadowCount, 0, len(counts))
+	for id, count := range counts {
+		result = append(result, ShadowCount{ID: id, WouldBlock: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].WouldBlock != result[j].WouldBlock {
+			return result[i].WouldBlock > result[j].WouldBlock
+		}
+		return result[i].ID < result[j].ID
+	})
+	if len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
+
+// MemoryShadowStore keeps shadow counters in process memory.
+// Used when the gateway runs without Redis; counters are per instance and lost on restart.
+type MemoryShadowStore struct {
+	mu        sync.Mutex
+	buckets   map[int64]*ShadowCounts
+	retention time.Duration
+}
+
+// NewMemoryShadowStore creates an in-memory shadow store
+func NewMemoryShadowStore(retention time.Duration) *MemoryShadowStore {
+	if retention <= 0 {
+		retention = DefaultShadowRetention
+	}
+	return &MemoryShadowStore{
+		buckets:   make(map[int64]*ShadowCounts),
+		retention: retention,
+	}
+}
+
+// Flush adds counts to the bucket and drops buckets older than the retention period
+func (s *MemoryShadowStore) Flush(ctx context.Context, bucket time.Time, counts *ShadowCounts) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := bucket.Unix()
+	existing, ok := s.buckets[key]
+	if !ok {
+		existing = NewShadowCounts()
+		s.buckets[key] = existing
+	}
+	existing.add(counts)
+
+	cutoff := bucket.Add(-s.retention).Unix()
+	for k := range s.buckets {
+		if k < cutoff {
+			delete(s.buckets, k)
+		}
+	}
+	return nil
+}
+
+// Report aggregates the requested buckets
+func (s *MemoryShadowStore) Report(ctx context.Context, buckets []time.Time, top int) (*ShadowReport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	total := NewShadowCounts()
+	for _, bucket := range buckets {
+		if counts, ok := s.buckets[bucket.Unix()]; ok {
+			total.add(counts)
+		}
+	}
+
+	return newShadowReport(total.Evaluated, total.WouldBlock,
+		topShadowCounts(total.Users, top), topShadowCounts(total.Tenants, top)), nil
+}
+
+// RedisShadowStore keeps shadow counters in Redis so every gateway instance
+// contributes to the same report.
+//
+// Each hour bucket uses four keys:
+//
+//	{prefix}shadow:{YYYYMMDDHH}:evaluated  hash  rule -> requests checked
+//	{prefix}shadow:{YYYYMMDDHH}:blocked    hash  rule -> would-block requests
+//	{prefix}shadow:{YYYYMMDDHH}:users      zset  user -> would-block requests
+//	{prefix}shadow:{YYYYMMDDHH}:tenants    zset  tenant -> would-block requests
+type RedisShadowStore struct {
+	client    *pkgredis.Client
+	keyPrefix string
+	retention time.Duration
+}
+
+// NewRedisShadowStore creates a Redis-backed shadow store
+func NewRedisShadowStore(client *pkgredis.Client, keyPrefix string, retention time.Duration) *RedisShadowStore {
+	if keyPrefix == "" {
+		keyPrefix = "ratelimit:"
+	}
+	if retention <= 0 {
+		retention = DefaultShadowRetention
+	}
+	return &RedisShadowStore{
+		client:    client,
+		keyPrefix: keyPrefix,
+		retention: retention,
+	}
+}
+
+func (s *RedisShadowStore) key(bucket time.Time, kind string) string {
+	return fmt.Sprintf("%sshadow:%s:%s", s.keyPrefix, bucket.UTC().Format(shadowBucketLayout), kind)
+}
+
+// Flush increments the bucket's counters in a single pipeline
+func (s *RedisShadowStore) Flush(ctx context.Context, bucket time.Time, counts *ShadowCounts) error {
+	evaluatedKey := s.key(bucket, "evaluated")
+	blockedKey := s.key(bucket, "blocked")
+	usersKey := s.key(bucket, "users")
+	tenantsKey := s.key(bucket, "tenants")
+
+	pipe := s.client.Pipeline()
+	for rule, n := range counts.Evaluated {
+		pipe.HIncrBy(ctx, evaluatedKey, rule, n)
+	}
+	for rule, n := range counts.WouldBlock {
+		pipe.HIncrBy(ctx, blockedKey, rule, n)
+	}
+	for user, n := range counts.Users {
+		pipe.ZIncrBy(ctx, usersKey, float64(n), user)
+	}
+	for tenant, n := range counts.Tenants {
+		pipe.ZIncrBy(ctx, tenantsKey, float64(n), tenant)
+	}
+
+	// Keep buckets around for the full retention period after the hour ends
+	ttl := s.retention + time.Hour
+	for _, key := range []string{evaluatedKey, blockedKey, usersKey, tenantsKey} {
+		pipe.Expire(ctx, key, ttl)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to flush shadow counters: %w", err)
+	}
+	return nil
+}
+
+// Report sums the hashes of each bucket and unions the user and tenant sets
+// into short-lived keys so only the top entries are read back.
+func (s *RedisShadowStore) Report(ctx context.Context, buckets []time.Time, top int) (*ShadowReport, error) {
+	first := buckets[0].UTC().Format(shadowBucketLayout)
+	last := buckets[len(buckets)-1].UTC().Format(shadowBucketLayout)
+	usersDest := fmt.Sprintf("%sshadow:report:%s-%s:users", s.keyPrefix, first, last)
+	tenantsDest := fmt.Sprintf("%sshadow:report:%s-%s:tenants", s.keyPrefix, first, last)
+
+	userKeys := make([]string, len(buckets))
+	tenantKeys := make([]string, len(buckets))
+	for i, bucket := range buckets {
+		userKeys[i] = s.key(bucket, "users")
+		tenantKeys[i] = s.key(bucket, "tenants")
+	}
+
+	pipe := s.client.Pipeline()
+	evaluatedCmds := make([]*redis.MapStringStringCmd, len(buckets))
+	blockedCmds := make([]*redis.MapStringStringCmd, len(buckets))
+	for i, bucket := range buckets {
+		evaluatedCmds[i] = pipe.HGetAll(ctx, s.key(bucket, "evaluated"))
+		blockedCmds[i] = pipe.HGetAll(ctx, s.key(bucket, "blocked"))
+	}
+	pipe.ZUnionStore(ctx, usersDest, &redis.ZStore{Keys: userKeys})
+	pipe.ZUnionStore(ctx, tenantsDest, &redis.ZStore{Keys: tenantKeys})
+	pipe.Expire(ctx, usersDest, time.Minute)
+	pipe.Expire(ctx, tenantsDest, time.Minute)
+	usersCmd := pipe.ZRevRangeWithScores(ctx, usersDest, 0, int64(top-1))
+	tenantsCmd := pipe.ZRevRangeWithScores(ctx, tenantsDest, 0, int64(top-1))
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to read shadow counters: %w", err)
+	}
+
+	evaluated := make(map[string]int64)
+	wouldBlock := make(map[string]int64)
+	for i := range buckets {
+		sumShadowHash(evaluated, evaluatedCmds[i].Val())
+		sumShadowHash(wouldBlock, blockedCmds[i].Val())
+	}
+
+	return newShadowReport(evaluated, wouldBlock,
+		shadowCountsFromZ(usersCmd.Val()), shadowCountsFromZ(tenantsCmd.Val())), nil
+}
+
+// sumShadowHash adds the integer fields of a Redis hash into dst
+func sumShadowHash(dst map[string]int64, fields map[string]string) {
+	for field, raw := range fields {
+		if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			dst[field] += n
+		}
+	}
+}
+
+func shadowCountsFromZ(members []redis.Z) []ShadowCount {
+	result := make([]ShadowCount, 0, len(members))
+	for _, m := range members {
+		id, _ := m.Member.(string)
+		result = append(result, ShadowCount{ID: id, WouldBlock: int64(m.Score)})
+	}
+	return result
+}
@@ -0,0 +1,239 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// failingShadowStore fails every flush until fail is cleared
+type failingShadowStore struct {
+	*MemoryShadowStore
+	fail bool
+}
+
+func (s *failingShadowStore) Flush(ctx context.Context, bucket time.Time, counts *ShadowCounts) error {
+	if s.fail {
+		return errors.New("store unavailable")
+	}
+	return s.MemoryShadowStore.Flush(ctx, bucket, counts)
+}
+
+func TestPerEndpointRateLimiter_ShadowModeNeverRejects(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	recorder := NewShadowRecorder(NewMemoryShadowStore(time.Hour), time.Hour, time.Hour)
+	defer recorder.Stop()
+
+	config := PerEndpointRateLimitConfig{
+		Default: RateLimitConfig{
+			RequestsPerSecond: 1000,
+			BurstSize:         100,
+		},
+		Endpoints: []EndpointRateLimitConfig{
+			{
+				PathPattern:       "/api/v1/bookings",
+				Methods:           []string{"POST"},
+				RequestsPerSecond: 1,
+				BurstSize:         2,
+			},
+		},
+		CleanupInterval: time.Minute,
+		EntryTTL:        time.Minute,
+		ShadowMode:      true,
+		ShadowRecorder:  recorder,
+	}
+
+	_, r := gin.CreateTestContext(httptest.NewRecorder())
+
+	r.Use(PerEndpointRateLimiter(config))
+	r.POST("/api/v1/bookings", func(c *gin.Context) {
+		// Simulates the JWT middleware that runs after the rate limiter
+		c.Set("user_id", "user-1")
+		c.Set("tenant_id", "tenant-1")
+		c.String(http.StatusOK, "ok")
+	})
+
+	for i := 0; i < 5; i++ {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/bookings", nil)
+		req.RemoteAddr = "192.168.1.1:12345"
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("Request %d should be allowed in shadow mode, got %d", i+1, w.Code)
+		}
+		if w.Header().Get("X-RateLimit-Limit") != "" {
+			t.Error("Shadow mode should not set rate limit headers")
+		}
+	}
+
+	report, err := recorder.Report(context.Background(), time.Hour, 10)
+	if err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+
+	if len(report.Rules) != 1 {
+		t.Fatalf("Expected 1 rule, got %d", len(report.Rules))
+	}
+	rule := report.Rules[0]
+	if rule.Rule != "POST /api/v1/bookings" {
+		t.Errorf("Expected rule 'POST /api/v1/bookings', got %q", rule.Rule)
+	}
+	if rule.Evaluated != 5 || rule.WouldBlock != 3 {
+		t.Errorf("Expected 5 evaluated and 3 would-block, got %d and %d", rule.Evaluated, rule.WouldBlock)
+	}
+	if rule.BlockRatio != 0.6 {
+		t.Errorf("Expected block ratio 0.6, got %f", rule.BlockRatio)
+	}
+	if len(report.TopUsers) != 1 || report.TopUsers[0].ID != "user-1" || report.TopUsers[0].WouldBlock != 3 {
+		t.Errorf("Expected user-1 with 3 would-block, got %+v", report.TopUsers)
+	}
+	if len(report.TopTenants) != 1 || report.TopTenants[0].ID != "tenant-1" {
+		t.Errorf("Expected tenant-1, got %+v", report.TopTenants)
+	}
+}
+
+func TestShadowRecorder_ReportRanksAndLabelsAnonymous(t *testing.T) {
+	recorder := NewShadowRecorder(NewMemoryShadowStore(time.Hour), time.Hour, time.Hour)
+	defer recorder.Stop()
+
+	recorder.Record("default", false, "user-1", "")
+	recorder.Record("default", true, "", "")
+	recorder.Record("POST /api/v1/bookings", true, "user-2", "tenant-a")
+	recorder.Record("POST /api/v1/bookings", true, "user-2", "tenant-a")
+	recorder.Record("POST /api/v1/bookings", true, "user-3", "tenant-b")
+
+	report, err := recorder.Report(context.Background(), time.Hour, 2)
+	if err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+
+	if report.TotalEvaluated != 5 || report.TotalWouldBlock != 4 {
+		t.Errorf("Expected totals 5/4, got %d/%d", report.TotalEvaluated, report.TotalWouldBlock)
+	}
+	if report.Rules[0].Rule != "POST /api/v1/bookings" {
+		t.Errorf("Expected rule with most would-block first, got %q", report.Rules[0].Rule)
+	}
+
+	// top=2 keeps user-2 (2) then the tie between anonymous and user-3 broken by ID
+	if len(report.TopUsers) != 2 {
+		t.Fatalf("Expected 2 top users, got %d", len(report.TopUsers))
+	}
+	if report.TopUsers[0].ID != "user-2" || report.TopUsers[1].ID != ShadowAnonymousUser {
+		t.Errorf("Unexpected top users: %+v", report.TopUsers)
+	}
+	if len(report.TopTenants) != 2 || report.TopTenants[0].ID != "tenant-a" {
+		t.Errorf("Unexpected top tenants: %+v", report.TopTenants)
+	}
+}
+
+func TestShadowRecorder_RequeuesOnFlushFailure(t *testing.T) {
+	store := &failingShadowStore{MemoryShadowStore: NewMemoryShadowStore(time.Hour), fail: true}
+	recorder := NewShadowRecorder(store, time.Hour, time.Hour)
+	defer recorder.Stop()
+
+	recorder.Record("default", true, "user-1", "")
+	if err := recorder.Flush(context.Background()); err == nil {
+		t.Fatal("Expected flush error")
+	}
+	recorder.Record("default", true, "user-1", "")
+
+	store.fail = false
+	report, err := recorder.Report(context.Background(), time.Hour, 10)
+	if err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+	if report.TotalWouldBlock != 2 {
+		t.Errorf("Expected counters kept across failed flush, got %d", report.TotalWouldBlock)
+	}
+}
+
+func TestShadowRecorder_ReportWindow(t *testing.T) {
+	store := NewMemoryShadowStore(48 * time.Hour)
+	recorder := NewShadowRecorder(store, time.Hour, 48*time.Hour)
+	defer recorder.Stop()
+
+	now := time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC)
+	recorder.now = func() time.Time { return now.Add(-3 * time.Hour) }
+	recorder.Record("default", true, "old-user", "")
+	recorder.now = func() time.Time { return now }
+	recorder.Record("default", true, "new-user", "")
+
+	report, err := recorder.Report(context.Background(), time.Hour, 10)
+	if err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+	if report.TotalWouldBlock != 1 || report.TopUsers[0].ID != "new-user" {
+		t.Errorf("Expected only the current hour, got %+v", report)
+	}
+	if !report.From.Equal(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected report start: %v", report.From)
+	}
+
+	report, err = recorder.Report(context.Background(), 4*time.Hour, 10)
+	if err != nil {
+		t.Fatalf("Report failed: %v", err)
+	}
+	if report.TotalWouldBlock != 2 {
+		t.Errorf("Expected both hours in a 4h window, got %d", report.TotalWouldBlock)
+	}
+}
+
+func TestShadowBuckets(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		window    time.Duration
+		retention time.Duration
+		expected  int
+	}{
+		{"zero window uses current hour", 0, 48 * time.Hour, 1},
+		{"partial hour rounds up", 90 * time.Minute, 48 * time.Hour, 2},
+		{"capped at retention", 100 * time.Hour, 48 * time.Hour, 48},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buckets := shadowBuckets(now, tt.window, tt.retention)
+			if len(buckets) != tt.expected {
+				t.Fatalf("Expected %d buckets, got %d", tt.expected, len(buckets))
+			}
+			last := buckets[len(buckets)-1]
+			if !last.Equal(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)) {
+				t.Errorf("Expected newest bucket to be the current hour, got %v", last)
+			}
+		})
+	}
+}
+
+func TestFindEndpointRule(t *testing.T) {
+	config := PerEndpointRateLimitConfig{
+		Default: RateLimitConfig{RequestsPerSecond: 1000, BurstSize: 100},
+		Endpoints: []EndpointRateLimitConfig{
+			{PathPattern: "/api/v1/bookings", Methods: []string{"POST"}, RequestsPerSecond: 100, BurstSize: 20},
+			{PathPattern: "/api/v1/events/*", RequestsPerSecond: 2000, BurstSize: 200},
+		},
+	}
+
+	tests := []struct {
+		method, path, rule string
+	}{
+		{http.MethodPost, "/api/v1/bookings", "POST /api/v1/bookings"},
+		{http.MethodGet, "/api/v1/events/123", "/api/v1/events/*"},
+		{http.MethodGet, "/api/v1/bookings", "default"},
+	}
+
+	for _, tt := range tests {
+		rule, _, _ := config.findEndpointRule(tt.method, tt.path)
+		if rule != tt.rule {
+			t.Errorf("%s %s: expected rule %q, got %q", tt.method, tt.path, tt.rule, rule)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -12,12 +13,20 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/i18n"
 	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 )
 
+// retryAfterMessage returns the rate limit message in the request locale
+func retryAfterMessage(c *gin.Context, retryAfter int) string {
+	return i18n.Render(i18n.FromContext(c.Request.Context()), "TOO_MANY_REQUESTS.retry_after", map[string]string{
+		"seconds": strconv.Itoa(retryAfter),
+	})
+}
+
 // getEnvInt reads an integer from environment variable with a default value
 func getEnvInt(key string, defaultVal int) int {
 	if val := os.Getenv(key); val != "" {
@@ -58,12 +67,24 @@ type EndpointRateLimitConfig struct {
 	BurstSize int
 }
 
+// RateLimitClass is a named rate limit that gateway routes select with their rate-limit class
+type RateLimitClass struct {
+	// Rate limit per second per IP
+	RequestsPerSecond int
+	// Burst size (token bucket capacity)
+	BurstSize int
+}
+
 // PerEndpointRateLimitConfig holds configuration for per-endpoint rate limiting
 type PerEndpointRateLimitConfig struct {
 	// Default rate limit for endpoints not in the list
 	Default RateLimitConfig
 	// Per-endpoint configurations (checked in order, first match wins)
 	Endpoints []EndpointRateLimitConfig
+	// Classes are the named limits of routes with a rate-limit class, applied before Endpoints
+	Classes map[string]RateLimitClass
+	// ClassOf returns the rate-limit class of a request's route ("" = use Endpoints)
+	ClassOf func(method, path string) string
 	// Whether to use Redis for distributed rate limiting
 	UseRedis bool
 	// Redis client (required if UseRedis is true)
@@ -74,6 +95,12 @@ type PerEndpointRateLimitConfig struct {
 	CleanupInterval time.Duration
 	// Entry TTL for local rate limiter
 	EntryTTL time.Duration
+	// Shadow mode evaluates limits and records would-block counts without rejecting requests
+	ShadowMode bool
+	// Recorder for shadow mode decisions (required if ShadowMode is true)
+	ShadowRecorder *ShadowRecorder
+	// Per-user and per-API-key overrides, consulted before the class and endpoint rules (optional)
+	Overrides *RateLimitOverrides
 }
 
 // DefaultRateLimitConfig returns sensible defaults
@@ -233,6 +260,11 @@ end
 	}
 }
 
+// LoadScript loads the token bucket script into Redis, failing if Redis rejects it
+func (rl *RedisRateLimiter) LoadScript(ctx context.Context) error {
+	return rl.config.RedisClient.Client().ScriptLoad(ctx, rl.script).Err()
+}
+
 // Allow checks if a request should be allowed using Redis
 func (rl *RedisRateLimiter) Allow(ctx context.Context, key string) (bool, error) {
 	allowed, _, err := rl.AllowWithRemaining(ctx, key, rl.config.RequestsPerSecond, rl.config.BurstSize)
@@ -367,7 +399,7 @@ func RateLimiter(config RateLimitConfig) gin.HandlerFunc {
 				"success": false,
 				"error": gin.H{
 					"code":    "TOO_MANY_REQUESTS",
-					"message": "Rate limit exceeded. Please retry after " + strconv.Itoa(retryAfter) + " second(s).",
+					"message": retryAfterMessage(c, retryAfter),
 				},
 			})
 			return
@@ -440,7 +472,7 @@ func ConcurrencyLimiter(maxConcurrent int64) gin.HandlerFunc {
 				"success": false,
 				"error": gin.H{
 					"code":    "TOO_MANY_REQUESTS",
-					"message": "Server is at capacity. Please retry in a moment.",
+					"message": i18n.T(i18n.FromContext(c.Request.Context()), "TOO_MANY_REQUESTS.server_capacity", "Server is at capacity. Please retry in a moment."),
 				},
 			})
 			return
@@ -464,11 +496,14 @@ func min(a, b float64) float64 {
 // - RATE_LIMIT_BURST: default burst size
 // - BOOKING_RATE_LIMIT_REQUESTS_PER_MINUTE: booking endpoint requests per minute
 // - BOOKING_RATE_LIMIT_BURST: booking endpoint burst size
+//
+// The default, booking, read and auth classes carry the same limits for routes that
+// select them; RATE_LIMIT_CLASSES adds or overrides classes (see ParseRateLimitClasses).
 func DefaultPerEndpointConfig() PerEndpointRateLimitConfig {
 	// Read from ENV with defaults (convert per-minute to per-second)
-	defaultRPS := getEnvInt("RATE_LIMIT_REQUESTS_PER_MINUTE", 60000) / 60     // default 1000/s
+	defaultRPS := getEnvInt("RATE_LIMIT_REQUESTS_PER_MINUTE", 60000) / 60 // default 1000/s
 	defaultBurst := getEnvInt("RATE_LIMIT_BURST", 100)
-	bookingRPS := getEnvInt("BOOKING_RATE_LIMIT_REQUESTS_PER_MINUTE", 6000) / 60  // default 100/s
+	bookingRPS := getEnvInt("BOOKING_RATE_LIMIT_REQUESTS_PER_MINUTE", 6000) / 60 // default 100/s
 	bookingBurst := getEnvInt("BOOKING_RATE_LIMIT_BURST", 20)
 
 	return PerEndpointRateLimitConfig{
@@ -511,10 +546,55 @@ func DefaultPerEndpointConfig() PerEndpointRateLimitConfig {
 				BurstSize:         5,
 			},
 		},
+		Classes: map[string]RateLimitClass{
+			"default": {RequestsPerSecond: defaultRPS, BurstSize: defaultBurst},
+			"booking": {RequestsPerSecond: bookingRPS, BurstSize: bookingBurst},
+			"read":    {RequestsPerSecond: defaultRPS * 2, BurstSize: defaultBurst * 2},
+			"auth":    {RequestsPerSecond: 20, BurstSize: 5},
+		},
 		KeyPrefix:       "ratelimit:",
 		CleanupInterval: time.Minute,
 		EntryTTL:        time.Minute,
+		ShadowMode:      os.Getenv("RATE_LIMIT_SHADOW_MODE") == "true",
+	}
+}
+
+// ParseRateLimitClasses parses RATE_LIMIT_CLASSES, a comma-separated list of
+// name=requests_per_minute:burst entries such as "checkout=3000:10,search=120000:200"
+func ParseRateLimitClasses(spec string) (map[string]RateLimitClass, error) {
+	classes := make(map[string]RateLimitClass)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, limit, ok := strings.Cut(entry, "=")
+		rpm, burst, ok2 := strings.Cut(limit, ":")
+		name = strings.TrimSpace(name)
+		if !ok || !ok2 || name == "" {
+			return nil, fmt.Errorf("invalid rate limit class %q, want name=requests_per_minute:burst", entry)
+		}
+		perMinute, err := strconv.Atoi(strings.TrimSpace(rpm))
+		if err != nil || perMinute < 0 {
+			return nil, fmt.Errorf("invalid requests per minute in rate limit class %q", entry)
+		}
+		size, err := strconv.Atoi(strings.TrimSpace(burst))
+		if err != nil || size < 0 {
+			return nil, fmt.Errorf("invalid burst in rate limit class %q", entry)
+		}
+		classes[name] = RateLimitClass{RequestsPerSecond: perMinute / 60, BurstSize: size}
 	}
+	return classes, nil
+}
+
+// ClassNames returns the names of the configured rate-limit classes
+func (c *PerEndpointRateLimitConfig) ClassNames() []string {
+	names := make([]string, 0, len(c.Classes))
+	for name := range c.Classes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
 // matchPath checks if a request path matches a pattern
@@ -579,12 +659,36 @@ func containsMethod(methods []string, method string) bool {
 
 // findEndpointConfig finds the matching endpoint configuration
 func (c *PerEndpointRateLimitConfig) findEndpointConfig(method, path string) (int, int) {
+	_, rps, burst := c.findEndpointRule(method, path)
+	return rps, burst
+}
+
+// findRequestRule finds the limit of a request: its route's rate-limit class, or else
+// the endpoint rule of path (the registered path pattern when there is one)
+func (c *PerEndpointRateLimitConfig) findRequestRule(method, requestPath, path string) (string, int, int) {
+	if c.ClassOf != nil {
+		if name := c.ClassOf(method, requestPath); name != "" {
+			if class, ok := c.Classes[name]; ok {
+				return "class:" + name, class.RequestsPerSecond, class.BurstSize
+			}
+		}
+	}
+	return c.findEndpointRule(method, path)
+}
+
+// findEndpointRule finds the matching endpoint configuration and names the rule,
+// e.g. "POST /api/v1/bookings", or "default" when no endpoint matches
+func (c *PerEndpointRateLimitConfig) findEndpointRule(method, path string) (string, int, int) {
 	for _, endpoint := range c.Endpoints {
 		if matchPath(endpoint.PathPattern, path) && containsMethod(endpoint.Methods, method) {
-			return endpoint.RequestsPerSecond, endpoint.BurstSize
+			rule := endpoint.PathPattern
+			if len(endpoint.Methods) > 0 {
+				rule = strings.Join(endpoint.Methods, ",") + " " + endpoint.PathPattern
+			}
+			return rule, endpoint.RequestsPerSecond, endpoint.BurstSize
 		}
 	}
-	return c.Default.RequestsPerSecond, c.Default.BurstSize
+	return shadowDefaultRule, c.Default.RequestsPerSecond, c.Default.BurstSize
 }
 
 // PerEndpointRateLimiter creates a middleware with per-endpoint rate limiting
@@ -645,13 +749,29 @@ func PerEndpointRateLimiter(config PerEndpointRateLimitConfig) gin.HandlerFunc {
 		clientIP := c.ClientIP()
 
 		// Get rate limit config for this endpoint
-		rps, burst := config.findEndpointConfig(method, path)
+		rule, rps, burst := config.findRequestRule(method, c.Request.URL.Path, path)
+
+		// An override replaces the rule, and its subject gets its own bucket so partners
+		// behind a shared IP are not limited together
+		limitKey := clientIP
+		if override := config.Overrides.Lookup(c); override != nil {
+			if override.Bypass {
+				span.SetAttributes(attribute.String("override", override.Subject))
+				span.SetStatus(codes.Ok, "")
+				c.Header("X-RateLimit-Bypass", "override")
+				c.Next()
+				return
+			}
+			rule, rps, burst, limitKey = overrideRule, override.RequestsPerSecond, override.BurstSize, override.Subject
+			span.SetAttributes(attribute.String("override", override.Subject))
+		}
 
 		span.SetAttributes(
 			attribute.String("client_ip", clientIP),
 			attribute.String("path", path),
 			attribute.Int("rps", rps),
 			attribute.Int("burst", burst),
+			attribute.Bool("shadow_mode", config.ShadowMode),
 		)
 
 		// Skip rate limiting if unlimited
@@ -666,7 +786,7 @@ func PerEndpointRateLimiter(config PerEndpointRateLimitConfig) gin.HandlerFunc {
 
 		if redisLimiter != nil {
 			// For Redis, include the rate config in the key for per-endpoint limits
-			redisKey := fmt.Sprintf("%s:%d:%d", clientIP, rps, burst)
+			redisKey := fmt.Sprintf("%s:%d:%d", limitKey, rps, burst)
 			var err error
 			allowed, remainingTokens, err = redisLimiter.AllowWithRemaining(ctx, redisKey, rps, burst)
 			if err != nil {
@@ -676,11 +796,22 @@ func PerEndpointRateLimiter(config PerEndpointRateLimitConfig) gin.HandlerFunc {
 			}
 		} else {
 			limiter := getLimiter(rps, burst)
-			allowed, remainingTokens = limiter.AllowWithRemaining(clientIP)
+			allowed, remainingTokens = limiter.AllowWithRemaining(limitKey)
 		}
 
 		span.SetAttributes(attribute.Bool("allowed", allowed))
 
+		if config.ShadowMode {
+			// Never reject or expose limit headers; record the decision after the
+			// request has run so the user and tenant set by JWT validation are known
+			span.SetStatus(codes.Ok, "")
+			c.Next()
+			if config.ShadowRecorder != nil {
+				config.ShadowRecorder.Record(rule, !allowed, c.GetString("user_id"), c.GetString("tenant_id"))
+			}
+			return
+		}
+
 		// Calculate remaining (at least 0)
 		remaining := int(remainingTokens)
 		if remaining < 0 {
@@ -715,7 +846,7 @@ func PerEndpointRateLimiter(config PerEndpointRateLimitConfig) gin.HandlerFunc {
 				"success": false,
 				"error": gin.H{
 					"code":    "TOO_MANY_REQUESTS",
-					"message": "Rate limit exceeded. Please retry after " + strconv.Itoa(retryAfter) + " second(s).",
+					"message": retryAfterMessage(c, retryAfter),
 				},
 			})
 			return
@@ -3,6 +3,7 @@ package middleware
 import (
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -486,3 +487,74 @@ func TestDefaultPerEndpointConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestPerEndpointRateLimiterMiddleware_RateLimitClass(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	config := PerEndpointRateLimitConfig{
+		Default: RateLimitConfig{
+			RequestsPerSecond: 1000,
+			BurstSize:         100,
+		},
+		Classes: map[string]RateLimitClass{
+			"checkout": {RequestsPerSecond: 100, BurstSize: 2},
+		},
+		ClassOf: func(method, path string) string {
+			if strings.HasPrefix(path, "/api/v1/cart") {
+				return "checkout"
+			}
+			return ""
+		},
+		CleanupInterval: time.Minute,
+		EntryTTL:        time.Minute,
+	}
+
+	_, r := gin.CreateTestContext(httptest.NewRecorder())
+	r.Use(PerEndpointRateLimiter(config))
+	r.NoRoute(func(c *gin.Context) {
+		c.String(http.StatusOK, "proxied")
+	})
+
+	// The class applies to the route's requests, which only the proxy's catch-all serves
+	for i := 0; i < 4; i++ {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/cart/checkouts", nil)
+		req.RemoteAddr = "192.168.1.1:12345"
+		r.ServeHTTP(w, req)
+
+		if i < 2 && w.Code != http.StatusOK {
+			t.Errorf("Request %d to the checkout class should be allowed", i+1)
+		}
+		if i >= 2 && w.Code != http.StatusTooManyRequests {
+			t.Errorf("Request %d to the checkout class should be rejected", i+1)
+		}
+	}
+
+	// Routes without a class keep the endpoint rules
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/bookings", nil)
+	req.RemoteAddr = "192.168.1.1:12345"
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || w.Header().Get("X-RateLimit-Burst") != "100" {
+		t.Errorf("Expected the default limit for a route without a class, got %d (burst %s)", w.Code, w.Header().Get("X-RateLimit-Burst"))
+	}
+}
+
+func TestParseRateLimitClasses(t *testing.T) {
+	classes, err := ParseRateLimitClasses("checkout=3000:10, search = 120000:200")
+	if err != nil {
+		t.Fatalf("ParseRateLimitClasses() error = %v", err)
+	}
+	if classes["checkout"] != (RateLimitClass{RequestsPerSecond: 50, BurstSize: 10}) {
+		t.Errorf("checkout = %+v, want 50 rps and burst 10", classes["checkout"])
+	}
+	if classes["search"] != (RateLimitClass{RequestsPerSecond: 2000, BurstSize: 200}) {
+		t.Errorf("search = %+v, want 2000 rps and burst 200", classes["search"])
+	}
+
+	for _, spec := range []string{"checkout", "checkout=3000", "=3000:10", "checkout=fast:10", "checkout=3000:-1"} {
+		if _, err := ParseRateLimitClasses(spec); err == nil {
+			t.Errorf("ParseRateLimitClasses(%q) expected an error", spec)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/i18n"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/metering"
+	pkgmiddleware "github.com/prohmpiriya/booking-rush-10k-rps/pkg/middleware"
+)
+
+// Context key set when a request was rejected for quota, so it is not metered
+const ContextKeyQuotaRejected = "quota_rejected"
+
+// quotaReservePath is the booking endpoint that also counts against the bookings_created quota
+const quotaReservePath = "/api/v1/bookings/reserve"
+
+// UsageMetering counts an API call for the tenant of every authenticated request.
+// JWT is verified further down the chain, so the tenant is read after the request completes.
+// Requests rejected for quota are not counted.
+func UsageMetering(recorder *metering.Recorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if c.GetBool(ContextKeyQuotaRejected) {
+			return
+		}
+		tenantID, ok := pkgmiddleware.GetTenantID(c)
+		if !ok || tenantID == "" {
+			return
+		}
+		recorder.Record(tenantID, metering.MetricAPICalls, 1)
+	}
+}
+
+// QuotaEnforcer rejects requests of tenants that used up a daily quota of their plan.
+// It must run after JWT verification and is called inline by the proxy router,
+// so it does not call c.Next.
+func QuotaEnforcer(quota *metering.Quota) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID, ok := pkgmiddleware.GetTenantID(c)
+		if !ok || tenantID == "" {
+			return
+		}
+
+		metrics := []string{metering.MetricAPICalls}
+		if c.Request.Method == http.MethodPost && strings.TrimSuffix(c.Request.URL.Path, "/") == quotaReservePath {
+			metrics = append(metrics, metering.MetricBookingsCreated)
+		}
+
+		for _, metric := range metrics {
+			status := quota.Check(c.Request.Context(), tenantID, metric)
+			if status.Limit <= 0 {
+				continue
+			}
+
+			c.Header("X-Quota-Limit", strconv.FormatInt(status.Limit, 10))
+			c.Header("X-Quota-Remaining", strconv.FormatInt(status.Remaining, 10))
+			c.Header("X-Quota-Reset", strconv.FormatInt(status.ResetAt.Unix(), 10))
+			if !status.Exceeded {
+				continue
+			}
+
+			c.Set(ContextKeyQuotaRejected, true)
+			c.Header("Retry-After", strconv.Itoa(int(time.Until(status.ResetAt).Seconds())+1))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"success": false,
+				"error": gin.H{
+					"code": "QUOTA_EXCEEDED",
+					"message": i18n.Render(i18n.FromContext(c.Request.Context()), "QUOTA_EXCEEDED", map[string]string{
+						"metric": metric,
+						"reset":  status.ResetAt.Format(time.RFC3339),
+					}),
+					"plan":   status.Plan,
+					"metric": metric,
+					"limit":  status.Limit,
+				},
+			})
+			return
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/metering"
+	pkgmiddleware "github.com/prohmpiriya/booking-rush-10k-rps/pkg/middleware"
+)
+
+// setupUsageRouter meters every request and authenticates it as tenantID when set
+func setupUsageRouter(recorder *metering.Recorder, quota *metering.Quota, tenantID string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(UsageMetering(recorder))
+	r.NoRoute(func(c *gin.Context) {
+		if tenantID != "" {
+			c.Set(pkgmiddleware.ContextKeyTenantID, tenantID)
+		}
+		if quota != nil {
+			QuotaEnforcer(quota)(c)
+			if c.IsAborted() {
+				return
+			}
+		}
+		c.String(http.StatusOK, "ok")
+	})
+	return r
+}
+
+func TestUsageMetering_CountsAuthenticatedRequests(t *testing.T) {
+	recorder := metering.NewRecorder(metering.NewMemoryStore(0), time.Hour)
+	defer recorder.Stop()
+
+	for _, tenantID := range []string{"tenant-1", "tenant-1", ""} {
+		r := setupUsageRouter(recorder, nil, tenantID)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/events", nil))
+	}
+
+	if got := recorder.Pending("tenant-1")[metering.MetricAPICalls]; got != 2 {
+		t.Errorf("Expected 2 api calls for tenant-1, got %d", got)
+	}
+}
+
+func TestQuotaEnforcer_RejectsExceededTenants(t *testing.T) {
+	store := metering.NewMemoryStore(0)
+	recorder := metering.NewRecorder(store, time.Hour)
+	defer recorder.Stop()
+	_ = store.Add(context.Background(), time.Now(), map[string]metering.Counts{
+		"tenant-1": {metering.MetricAPICalls: 5, metering.MetricBookingsCreated: 2},
+	})
+
+	quota := metering.NewQuota(metering.QuotaConfig{
+		Store:       store,
+		Recorder:    recorder,
+		Plans:       metering.Plans{"free": {metering.MetricAPICalls: 10, metering.MetricBookingsCreated: 2}},
+		DefaultPlan: "free",
+	})
+	r := setupUsageRouter(recorder, quota, "tenant-1")
+
+	// Within the api_calls quota
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/events", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if w.Header().Get("X-Quota-Limit") != "10" || w.Header().Get("X-Quota-Remaining") != "5" {
+		t.Errorf("Unexpected quota headers: limit=%q remaining=%q",
+			w.Header().Get("X-Quota-Limit"), w.Header().Get("X-Quota-Remaining"))
+	}
+
+	// Reserving counts against bookings_created, which is used up
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/bookings/reserve", nil))
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected status %d, got %d", http.StatusTooManyRequests, w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "QUOTA_EXCEEDED") || w.Header().Get("Retry-After") == "" {
+		t.Errorf("Expected QUOTA_EXCEEDED with Retry-After, got %s", w.Body.String())
+	}
+
+	// The rejected request is not metered
+	if got := recorder.Pending("tenant-1")[metering.MetricAPICalls]; got != 1 {
+		t.Errorf("Expected 1 metered api call, got %d", got)
+	}
+}
@@ -0,0 +1,130 @@
+package proxy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ServiceRoute is a route a backend service exposes, in gin syntax (/api/v1/events/:id)
+type ServiceRoute struct {
+	Method string
+	Path   string
+}
+
+// ContractViolationKind classifies how the gateway's routes drifted from a service's
+type ContractViolationKind string
+
+const (
+	// ContractUnrouted is a public service route no gateway route forwards, so the gateway answers 404
+	ContractUnrouted ContractViolationKind = "unrouted"
+	// ContractMisrouted is a public service route the gateway forwards to another service
+	ContractMisrouted ContractViolationKind = "misrouted"
+	// ContractDangling is a gateway route that reaches no route of its service, so every request 404s
+	ContractDangling ContractViolationKind = "dangling"
+)
+
+// ContractViolation is a mismatch between the gateway's routes and a service's routes
+type ContractViolation struct {
+	Kind ContractViolationKind
+	// Method and Path are the service route, or the gateway route's methods and path prefix when dangling
+	Method string
+	Path   string
+	// Service owns the service route, or is the gateway route's upstream when dangling
+	Service string
+	// RoutedTo is the service a misrouted route is forwarded to
+	RoutedTo string
+}
+
+// String describes the violation
+func (v ContractViolation) String() string {
+	switch v.Kind {
+	case ContractMisrouted:
+		return fmt.Sprintf("%s %s of %s is forwarded to %s", v.Method, v.Path, v.Service, v.RoutedTo)
+	case ContractDangling:
+		return fmt.Sprintf("gateway route %s %s reaches no route of %s", v.Method, v.Path, v.Service)
+	default:
+		return fmt.Sprintf("%s %s of %s has no gateway route", v.Method, v.Path, v.Service)
+	}
+}
+
+// CheckRouteContract compares the gateway's routes with the routes of the services, keyed
+// by upstream name. Every public (/api/) service route must be forwarded to its service,
+// and every gateway route to a checked service must reach at least one of its routes.
+// Upstreams missing from services are not checked.
+func CheckRouteContract(routes []RouteConfig, services map[string][]ServiceRoute) []ContractViolation {
+	var violations []ContractViolation
+	reached := make([]bool, len(routes))
+
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, sr := range services[name] {
+			if i := reachingRoute(routes, name, sr); i >= 0 {
+				reached[i] = true
+				continue
+			}
+			if !strings.HasPrefix(sr.Path, "/api/") {
+				continue // Health checks and /internal routes are called directly, not through the gateway
+			}
+
+			violation := ContractViolation{Kind: ContractUnrouted, Method: sr.Method, Path: sr.Path, Service: name}
+			if i := matchingRoute(routes, sr.Path, sr.Method); i >= 0 {
+				violation.Kind = ContractMisrouted
+				violation.RoutedTo = routes[i].Service.Name
+			}
+			violations = append(violations, violation)
+		}
+	}
+
+	for i, route := range routes {
+		if _, checked := services[route.Service.Name]; !checked || reached[i] {
+			continue
+		}
+		methods := "*"
+		if len(route.AllowedMethods) > 0 {
+			methods = strings.Join(route.AllowedMethods, ",")
+		}
+		violations = append(violations, ContractViolation{
+			Kind:    ContractDangling,
+			Method:  methods,
+			Path:    route.PathPrefix,
+			Service: route.Service.Name,
+		})
+	}
+	return violations
+}
+
+// reachingRoute returns the index of the gateway route that forwards a request to the
+// service route of service, or -1. A route stripping a prefix is reached by the requests
+// carrying that prefix in front of the service path.
+func reachingRoute(routes []RouteConfig, service string, sr ServiceRoute) int {
+	for i, route := range routes {
+		if route.Service.Name != service {
+			continue
+		}
+		if matchingRoute(routes, route.StripPrefix+sr.Path, sr.Method) == i {
+			return i
+		}
+	}
+	return -1
+}
+
+// matchingRoute returns the index of the route the gateway matches a request to, or -1,
+// first match winning as in findRoute
+func matchingRoute(routes []RouteConfig, path, method string) int {
+	for i, route := range routes {
+		if !matchRoutePath(route.PathPrefix, path) {
+			continue
+		}
+		if len(route.AllowedMethods) > 0 && !containsMethod(route.AllowedMethods, method) {
+			continue
+		}
+		return i
+	}
+	return -1
+}
@@ -0,0 +1,310 @@
+package proxy
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// serviceSources are the files registering each upstream's routes, relative to this package
+var serviceSources = map[string]string{
+	"auth-service":    "../../../backend-auth/main.go",
+	"ticket-service":  "../../../backend-ticket/main.go",
+	"booking-service": "../../../backend-booking/main.go",
+	"payment-service": "../../../backend-payment/main.go",
+}
+
+// ginMethods are the gin route registration methods and the HTTP method they register
+var ginMethods = map[string]string{
+	"GET": "GET", "POST": "POST", "PUT": "PUT", "PATCH": "PATCH", "DELETE": "DELETE", "HEAD": "HEAD", "OPTIONS": "OPTIONS",
+}
+
+// serviceRoutesFromSource reads the routes a service registers from its source: gin
+// engines created with gin.New or gin.Default, groups of them and the routes of both.
+// Routes registered conditionally are included, as they exist in some deployment.
+// Paths may be string literals, package-level string constants or sums of them.
+func serviceRoutesFromSource(t *testing.T, file string) []ServiceRoute {
+	t.Helper()
+
+	src, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", file, err)
+	}
+	parsed, err := parser.ParseFile(token.NewFileSet(), file, src, 0)
+	if err != nil {
+		t.Fatalf("failed to parse %s: %v", file, err)
+	}
+
+	consts := stringConsts(parsed)
+
+	// Prefix of each engine and group variable
+	prefixes := make(map[string]string)
+	var routes []ServiceRoute
+	ast.Inspect(parsed, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.AssignStmt:
+			if len(n.Lhs) != 1 || len(n.Rhs) != 1 {
+				return true
+			}
+			name, ok := n.Lhs[0].(*ast.Ident)
+			if !ok {
+				return true
+			}
+			call, ok := n.Rhs[0].(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			receiver, method, ok := selectorCall(call)
+			switch {
+			case !ok:
+			case receiver == "gin" && (method == "New" || method == "Default"):
+				prefixes[name.Name] = ""
+			case method == "Group":
+				if parent, known := prefixes[receiver]; known {
+					if rel, ok := stringArg(call, 0, consts); ok {
+						prefixes[name.Name] = joinRoutePath(parent, rel)
+					}
+				}
+			}
+		case *ast.CallExpr:
+			receiver, method, ok := selectorCall(n)
+			if !ok {
+				return true
+			}
+			httpMethod, isRoute := ginMethods[method]
+			prefix, known := prefixes[receiver]
+			if !isRoute || !known {
+				return true
+			}
+			if rel, ok := stringArg(n, 0, consts); ok {
+				routes = append(routes, ServiceRoute{Method: httpMethod, Path: joinRoutePath(prefix, rel)})
+			}
+		}
+		return true
+	})
+	return routes
+}
+
+// selectorCall splits a call of the form receiver.Method(...)
+func selectorCall(call *ast.CallExpr) (receiver, method string, ok bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", "", false
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return "", "", false
+	}
+	return ident.Name, sel.Sel.Name, true
+}
+
+// stringConsts returns the package-level string constants of a file
+func stringConsts(file *ast.File) map[string]string {
+	consts := make(map[string]string)
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			value := spec.(*ast.ValueSpec)
+			for i, name := range value.Names {
+				if i < len(value.Values) {
+					if s, ok := stringValue(value.Values[i], consts); ok {
+						consts[name.Name] = s
+					}
+				}
+			}
+		}
+	}
+	return consts
+}
+
+// stringArg returns the constant string argument i of a call
+func stringArg(call *ast.CallExpr, i int, consts map[string]string) (string, bool) {
+	if len(call.Args) <= i {
+		return "", false
+	}
+	return stringValue(call.Args[i], consts)
+}
+
+// stringValue evaluates a string literal, a known constant or a sum of them
+func stringValue(expr ast.Expr, consts map[string]string) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.STRING {
+			return "", false
+		}
+		value, err := strconv.Unquote(e.Value)
+		return value, err == nil
+	case *ast.Ident:
+		value, ok := consts[e.Name]
+		return value, ok
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return "", false
+		}
+		left, ok := stringValue(e.X, consts)
+		if !ok {
+			return "", false
+		}
+		right, ok := stringValue(e.Y, consts)
+		return left + right, ok
+	}
+	return "", false
+}
+
+// joinRoutePath joins a group prefix and a relative path the way gin does
+func joinRoutePath(prefix, rel string) string {
+	if rel == "" {
+		if prefix == "" {
+			return "/"
+		}
+		return prefix
+	}
+	joined := path.Join(prefix, rel)
+	if strings.HasSuffix(rel, "/") && !strings.HasSuffix(joined, "/") {
+		joined += "/"
+	}
+	return joined
+}
+
+// notProxiedRoutes are public service routes deliberately kept off the gateway
+var notProxiedRoutes = map[string]bool{
+	// Every service answers its own status; the gateway has /health
+	"GET /api/v1/status": true,
+	// The async saga flow is exercised against booking-service directly (scripts/run-saga-workers.sh)
+	"POST /api/v1/saga/bookings":         true,
+	"GET /api/v1/saga/bookings/:saga_id": true,
+}
+
+func TestCheckRouteContract(t *testing.T) {
+	service := func(name string) ServiceConfig {
+		return ServiceConfig{Name: name, BaseURL: "http://" + name}
+	}
+	routes := []RouteConfig{
+		{PathPrefix: "/api/v1/events", AllowedMethods: []string{"GET"}, Service: service("ticket-service")},
+		{PathPrefix: "/api/v1/events", AllowedMethods: []string{"POST"}, Service: service("ticket-service"), RequireAuth: true},
+		{PathPrefix: "/api/v1/bookings", Service: service("booking-service"), RequireAuth: true},
+		{PathPrefix: "/api/v1/shows/:id/reviews", Service: service("booking-service")},
+		{PathPrefix: "/api/v1/shows", Service: service("ticket-service")},
+		{PathPrefix: "/api/v2/payments", StripPrefix: "/api/v2", Service: service("payment-service")},
+		{PathPrefix: "/api/v1/users", Service: service("auth-service")},
+	}
+	services := map[string][]ServiceRoute{
+		"ticket-service": {
+			{Method: "GET", Path: "/api/v1/events/:id"},
+			{Method: "POST", Path: "/api/v1/events"},
+			{Method: "DELETE", Path: "/api/v1/events/:id"}, // No gateway route allows DELETE
+			{Method: "GET", Path: "/api/v1/shows/:id"},
+			{Method: "GET", Path: "/api/v1/shows/:id/reviews"}, // Forwarded to booking-service
+			{Method: "GET", Path: "/health"},
+		},
+		"booking-service": {
+			{Method: "POST", Path: "/api/v1/bookings/reserve"},
+			{Method: "GET", Path: "/api/v1/shows/:id/reviews/summary"},
+			{Method: "GET", Path: "/internal/users/:user_id/bookings/:id"},
+		},
+		"payment-service": {
+			{Method: "GET", Path: "/payments/:id"}, // Reached through the /api/v2 prefix
+		},
+		"auth-service": {
+			{Method: "POST", Path: "/api/v1/auth/login"},
+		},
+	}
+
+	var got []string
+	for _, violation := range CheckRouteContract(routes, services) {
+		got = append(got, violation.String())
+	}
+	want := []string{
+		"POST /api/v1/auth/login of auth-service has no gateway route",
+		"DELETE /api/v1/events/:id of ticket-service has no gateway route",
+		"GET /api/v1/shows/:id/reviews of ticket-service is forwarded to booking-service",
+		"gateway route * /api/v1/users reaches no route of auth-service",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("CheckRouteContract() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestServiceRoutesFromSource(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "main.go")
+	src := `package main
+
+const filesPath = "/api/v1/files"
+
+func main() {
+	router := gin.New()
+	router.GET("/health", health)
+
+	v1 := router.Group("/api/v1")
+	{
+		events := v1.Group("/events")
+		events.GET("", list)
+		protected := events.Group("")
+		protected.Use(auth)
+		if enabled {
+			protected.POST("/:id/publish", publish)
+		}
+		other.GET("/ignored", handler)
+	}
+	router.Group("/internal").PUT("/x", handler)
+	router.GET(filesPath+"/*key", files)
+	router.GET(prefix+"/dynamic", handler)
+}
+`
+	if err := os.WriteFile(file, []byte(src), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	got := serviceRoutesFromSource(t, file)
+	want := []ServiceRoute{
+		{Method: "GET", Path: "/health"},
+		{Method: "GET", Path: "/api/v1/events"},
+		{Method: "POST", Path: "/api/v1/events/:id/publish"},
+		{Method: "GET", Path: "/api/v1/files/*key"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("serviceRoutesFromSource() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("route %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDefaultRouteTable_MatchesServiceRoutes(t *testing.T) {
+	routes, err := DefaultRouteTable().Build(ServiceURLLookup("http://auth:8081", "http://ticket:8082", "http://booking:8083", "http://payment:8084"))
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	services := make(map[string][]ServiceRoute, len(serviceSources))
+	for name, file := range serviceSources {
+		file = filepath.FromSlash(file)
+		if _, err := os.Stat(file); err != nil {
+			t.Skipf("service sources not checked out next to the gateway: %v", err)
+		}
+		for _, route := range serviceRoutesFromSource(t, file) {
+			if notProxiedRoutes[route.Method+" "+route.Path] {
+				continue
+			}
+			services[name] = append(services[name], route)
+		}
+		if len(services[name]) == 0 {
+			t.Fatalf("found no routes in %s", file)
+		}
+	}
+
+	for _, violation := range CheckRouteContract(routes, services) {
+		t.Errorf("route drift: %s", violation)
+	}
+}
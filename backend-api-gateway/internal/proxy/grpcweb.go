@@ -0,0 +1,510 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// GRPCWebService is the RPC service mobile clients call over gRPC-web or Connect
+// (see proto/booking/v1/read_service.proto). Calls are served as POST /<service>/<method>.
+const GRPCWebService = "booking.v1.BookingReadService"
+
+// Content types of the JSON codec. The gateway has no generated protobuf types, so
+// calls using the proto codec are answered with unimplemented.
+const (
+	contentTypeGRPCWebJSON     = "application/grpc-web+json"
+	contentTypeGRPCWebTextJSON = "application/grpc-web-text+json"
+	contentTypeConnectJSON     = "application/json"
+)
+
+// maxRPCMessageBytes caps request messages; read paths only carry a few IDs
+const maxRPCMessageBytes = 64 << 10
+
+// gRPC-web frame flags
+const (
+	grpcWebDataFrame    byte = 0x00
+	grpcWebTrailerFrame byte = 0x80
+)
+
+// GRPCWebMethod maps an RPC to the REST read path that serves it
+type GRPCWebMethod struct {
+	// Name is the RPC method name (e.g., "GetQueuePosition")
+	Name string
+	// Path is the REST path with {field} placeholders filled from the request message
+	Path string
+	// Query lists optional request message fields forwarded as query parameters
+	Query []string
+}
+
+// DefaultGRPCWebMethods returns the read paths exposed to mobile clients
+func DefaultGRPCWebMethods() []GRPCWebMethod {
+	return []GRPCWebMethod{
+		{Name: "GetShowAvailability", Path: "/api/v1/shows/{show_id}/zones"},
+		{Name: "GetQueuePosition", Path: "/api/v1/queue/position/{event_id}"},
+		{Name: "GetBookingStatus", Path: "/api/v1/bookings/{booking_id}/full"},
+	}
+}
+
+// GRPCWebTranslator serves unary gRPC-web and Connect calls by rewriting them into GET
+// requests on the REST read paths and handing them to the proxy route handler, so auth,
+// identity forwarding and upstream timeouts are those of the REST route.
+type GRPCWebTranslator struct {
+	methods map[string]GRPCWebMethod
+	next    gin.HandlerFunc
+}
+
+// NewGRPCWebTranslator creates a translator serving methods through next (usually
+// Router.MatchHandler)
+func NewGRPCWebTranslator(methods []GRPCWebMethod, next gin.HandlerFunc) *GRPCWebTranslator {
+	t := &GRPCWebTranslator{
+		methods: make(map[string]GRPCWebMethod, len(methods)),
+		next:    next,
+	}
+	for _, m := range methods {
+		t.methods[m.Name] = m
+	}
+	return t
+}
+
+// rpcCode is a gRPC status code
+type rpcCode int
+
+const (
+	rpcOK                 rpcCode = 0
+	rpcInvalidArgument    rpcCode = 3
+	rpcDeadlineExceeded   rpcCode = 4
+	rpcNotFound           rpcCode = 5
+	rpcPermissionDenied   rpcCode = 7
+	rpcResourceExhausted  rpcCode = 8
+	rpcFailedPrecondition rpcCode = 9
+	rpcAborted            rpcCode = 10
+	rpcUnimplemented      rpcCode = 12
+	rpcInternal           rpcCode = 13
+	rpcUnavailable        rpcCode = 14
+	rpcUnauthenticated    rpcCode = 16
+)
+
+// connectName returns the code's name in Connect error bodies
+func (c rpcCode) connectName() string {
+	switch c {
+	case rpcInvalidArgument:
+		return "invalid_argument"
+	case rpcDeadlineExceeded:
+		return "deadline_exceeded"
+	case rpcNotFound:
+		return "not_found"
+	case rpcPermissionDenied:
+		return "permission_denied"
+	case rpcResourceExhausted:
+		return "resource_exhausted"
+	case rpcFailedPrecondition:
+		return "failed_precondition"
+	case rpcAborted:
+		return "aborted"
+	case rpcUnimplemented:
+		return "unimplemented"
+	case rpcUnavailable:
+		return "unavailable"
+	case rpcUnauthenticated:
+		return "unauthenticated"
+	default:
+		return "internal"
+	}
+}
+
+// connectHTTPStatus returns the HTTP status Connect uses for the code
+func (c rpcCode) connectHTTPStatus() int {
+	switch c {
+	case rpcInvalidArgument, rpcFailedPrecondition:
+		return http.StatusBadRequest
+	case rpcDeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case rpcNotFound:
+		return http.StatusNotFound
+	case rpcPermissionDenied:
+		return http.StatusForbidden
+	case rpcResourceExhausted:
+		return http.StatusTooManyRequests
+	case rpcAborted:
+		return http.StatusConflict
+	case rpcUnimplemented:
+		return http.StatusNotImplemented
+	case rpcUnavailable:
+		return http.StatusServiceUnavailable
+	case rpcUnauthenticated:
+		return http.StatusUnauthorized
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// rpcCodeFromHTTP maps a REST response status to the gRPC code clients see
+func rpcCodeFromHTTP(status int) rpcCode {
+	switch {
+	case status < http.StatusBadRequest:
+		return rpcOK
+	case status == http.StatusBadRequest:
+		return rpcInvalidArgument
+	case status == http.StatusUnauthorized:
+		return rpcUnauthenticated
+	case status == http.StatusForbidden:
+		return rpcPermissionDenied
+	case status == http.StatusNotFound:
+		return rpcNotFound
+	case status == http.StatusConflict:
+		return rpcAborted
+	case status == http.StatusTooManyRequests:
+		return rpcResourceExhausted
+	case status < http.StatusInternalServerError:
+		return rpcFailedPrecondition
+	case status == http.StatusNotImplemented:
+		return rpcUnimplemented
+	case status == http.StatusBadGateway, status == http.StatusServiceUnavailable:
+		return rpcUnavailable
+	case status == http.StatusGatewayTimeout:
+		return rpcDeadlineExceeded
+	default:
+		return rpcInternal
+	}
+}
+
+// rpcCall is one unary call being translated
+type rpcCall struct {
+	contentType string
+	grpcWeb     bool
+	text        bool // grpc-web-text: frames are base64 encoded
+}
+
+// Handler returns a Gin handler for POST /<GRPCWebService>/:method
+func (t *GRPCWebTranslator) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, span := telemetry.StartSpan(c.Request.Context(), "gateway.grpcweb")
+		defer span.End()
+		c.Request = c.Request.WithContext(ctx)
+
+		methodName := c.Param("method")
+		call := &rpcCall{contentType: strings.ToLower(strings.TrimSpace(strings.Split(c.ContentType(), ";")[0]))}
+		switch call.contentType {
+		case contentTypeGRPCWebJSON:
+			call.grpcWeb = true
+		case contentTypeGRPCWebTextJSON:
+			call.grpcWeb, call.text = true, true
+		case contentTypeConnectJSON:
+		default:
+			span.SetStatus(codes.Error, "unsupported content type")
+			if strings.HasPrefix(call.contentType, "application/grpc-web") {
+				// Answer in the client's framing so it can read the status
+				call.grpcWeb = true
+				call.text = strings.HasPrefix(call.contentType, "application/grpc-web-text")
+				t.writeError(c, call, rpcUnimplemented, "only the json codec is supported", "")
+				return
+			}
+			c.AbortWithStatus(http.StatusUnsupportedMediaType)
+			return
+		}
+
+		span.SetAttributes(
+			attribute.String("rpc.service", GRPCWebService),
+			attribute.String("rpc.method", methodName),
+			attribute.Bool("rpc.grpc_web", call.grpcWeb),
+		)
+
+		method, ok := t.methods[methodName]
+		if !ok {
+			span.SetStatus(codes.Error, "unknown method")
+			t.writeError(c, call, rpcUnimplemented, fmt.Sprintf("%s/%s is not implemented", GRPCWebService, methodName), "")
+			return
+		}
+
+		fields, err := readRPCRequest(c.Request, call)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			t.writeError(c, call, rpcInvalidArgument, err.Error(), "")
+			return
+		}
+
+		path, query, err := method.restRequest(fields)
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			t.writeError(c, call, rpcInvalidArgument, err.Error(), "")
+			return
+		}
+
+		// Serve the call as the REST read it maps to
+		c.Request.Method = http.MethodGet
+		c.Request.URL.Path = path
+		c.Request.URL.RawPath = ""
+		c.Request.URL.RawQuery = query
+		c.Request.Body = http.NoBody
+		c.Request.ContentLength = 0
+		c.Request.Header.Del("Content-Type")
+		c.Request.Header.Del("Content-Length")
+		c.Request.Header.Set("Accept", "application/json")
+
+		capture := newCaptureWriter(c.Writer)
+		c.Writer = capture
+		t.next(c)
+		c.Writer = capture.ResponseWriter
+
+		code := rpcCodeFromHTTP(capture.status)
+		span.SetAttributes(
+			attribute.Int("http.upstream_status", capture.status),
+			attribute.Int("rpc.grpc_status", int(code)),
+		)
+		if code != rpcOK {
+			message, errorCode := restError(capture.body.Bytes(), capture.status)
+			span.SetStatus(codes.Error, message)
+			t.writeError(c, call, code, message, errorCode)
+			return
+		}
+
+		span.SetStatus(codes.Ok, "")
+		t.writeMessage(c, call, restMessage(capture.body.Bytes()))
+	}
+}
+
+// restRequest builds the REST path and query of a call from its request message fields
+func (m GRPCWebMethod) restRequest(fields map[string]string) (string, string, error) {
+	path := m.Path
+	for {
+		start := strings.Index(path, "{")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(path[start:], "}")
+		if end < 0 {
+			return "", "", fmt.Errorf("invalid path template %s", m.Path)
+		}
+		name := path[start+1 : start+end]
+		value := fields[name]
+		if value == "" {
+			return "", "", fmt.Errorf("%s is required", name)
+		}
+		path = path[:start] + url.PathEscape(value) + path[start+end+1:]
+	}
+
+	query := url.Values{}
+	for _, name := range m.Query {
+		if value := fields[name]; value != "" {
+			query.Set(name, value)
+		}
+	}
+	return path, query.Encode(), nil
+}
+
+// readRPCRequest decodes the request message into its top-level scalar fields
+func readRPCRequest(r *http.Request, call *rpcCall) (map[string]string, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRPCMessageBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request: %w", err)
+	}
+	if len(body) > maxRPCMessageBytes {
+		return nil, fmt.Errorf("request message exceeds %d bytes", maxRPCMessageBytes)
+	}
+
+	if call.grpcWeb {
+		if call.text {
+			if body, err = base64.StdEncoding.DecodeString(string(bytes.TrimSpace(body))); err != nil {
+				return nil, fmt.Errorf("invalid grpc-web-text body: %w", err)
+			}
+		}
+		if len(body) < 5 || body[0] != grpcWebDataFrame {
+			return nil, fmt.Errorf("request must be one uncompressed grpc-web data frame")
+		}
+		length := binary.BigEndian.Uint32(body[1:5])
+		if uint32(len(body)-5) < length {
+			return nil, fmt.Errorf("truncated grpc-web frame")
+		}
+		body = body[5 : 5+length]
+	}
+
+	fields := make(map[string]string)
+	if len(bytes.TrimSpace(body)) == 0 {
+		return fields, nil
+	}
+
+	var message map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&message); err != nil {
+		return nil, fmt.Errorf("request message must be a JSON object: %w", err)
+	}
+	for name, value := range message {
+		switch v := value.(type) {
+		case string:
+			fields[name] = v
+		case json.Number:
+			fields[name] = v.String()
+		case bool:
+			fields[name] = strconv.FormatBool(v)
+		}
+	}
+	return fields, nil
+}
+
+// restMessage returns the response message of a REST body: the data of
+// {"success": true, "data": ...} envelopes, otherwise the whole body
+func restMessage(body []byte) []byte {
+	var envelope struct {
+		Success *bool           `json:"success"`
+		Data    json.RawMessage `json:"data"`
+	}
+	if json.Unmarshal(body, &envelope) == nil && envelope.Success != nil && len(envelope.Data) > 0 {
+		return envelope.Data
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return []byte("{}")
+	}
+	return body
+}
+
+// restError extracts the message and error code of a REST error body. Services answer
+// with either {"error": {"code", "message"}} or {"error", "code", "message"}.
+func restError(body []byte, status int) (message, errorCode string) {
+	var nested struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(body, &nested) == nil && nested.Error.Message != "" {
+		return nested.Error.Message, nested.Error.Code
+	}
+
+	var flat struct {
+		Error   string `json:"error"`
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(body, &flat) == nil && (flat.Error != "" || flat.Message != "") {
+		if flat.Message != "" {
+			return flat.Message, flat.Code
+		}
+		return flat.Error, flat.Code
+	}
+
+	return http.StatusText(status), ""
+}
+
+// writeMessage answers a successful call
+func (t *GRPCWebTranslator) writeMessage(c *gin.Context, call *rpcCall, message []byte) {
+	if !call.grpcWeb {
+		c.Data(http.StatusOK, contentTypeConnectJSON, message)
+		return
+	}
+
+	var body bytes.Buffer
+	writeGRPCWebFrame(&body, grpcWebDataFrame, message)
+	writeGRPCWebFrame(&body, grpcWebTrailerFrame, grpcWebTrailers(rpcOK, "", ""))
+	t.writeGRPCWeb(c, call, body.Bytes())
+}
+
+// writeError answers a failed call. The REST error code is passed on as x-error-code
+// metadata so clients can keep their existing error handling.
+func (t *GRPCWebTranslator) writeError(c *gin.Context, call *rpcCall, code rpcCode, message, errorCode string) {
+	c.Abort()
+	if !call.grpcWeb {
+		if errorCode != "" {
+			c.Header("X-Error-Code", errorCode)
+		}
+		c.JSON(code.connectHTTPStatus(), gin.H{
+			"code":    code.connectName(),
+			"message": message,
+		})
+		return
+	}
+
+	var body bytes.Buffer
+	writeGRPCWebFrame(&body, grpcWebTrailerFrame, grpcWebTrailers(code, message, errorCode))
+	t.writeGRPCWeb(c, call, body.Bytes())
+}
+
+// writeGRPCWeb writes gRPC-web frames; statuses travel in the trailer frame, so the HTTP
+// status is always 200
+func (t *GRPCWebTranslator) writeGRPCWeb(c *gin.Context, call *rpcCall, frames []byte) {
+	if call.text {
+		frames = []byte(base64.StdEncoding.EncodeToString(frames))
+	}
+	c.Data(http.StatusOK, call.contentType, frames)
+}
+
+// writeGRPCWebFrame appends a length-prefixed gRPC-web frame
+func writeGRPCWebFrame(buf *bytes.Buffer, flag byte, payload []byte) {
+	var header [5]byte
+	header[0] = flag
+	binary.BigEndian.PutUint32(header[1:], uint32(len(payload)))
+	buf.Write(header[:])
+	buf.Write(payload)
+}
+
+// grpcWebTrailers encodes the trailer frame payload
+func grpcWebTrailers(code rpcCode, message, errorCode string) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "grpc-status: %d\r\n", code)
+	if message != "" {
+		fmt.Fprintf(&b, "grpc-message: %s\r\n", percentEncodeGRPCMessage(message))
+	}
+	if errorCode != "" {
+		fmt.Fprintf(&b, "x-error-code: %s\r\n", errorCode)
+	}
+	return []byte(b.String())
+}
+
+// percentEncodeGRPCMessage encodes grpc-message as the gRPC spec requires: printable
+// ASCII other than % is sent as is, everything else percent-encoded
+func percentEncodeGRPCMessage(message string) string {
+	var b strings.Builder
+	for i := 0; i < len(message); i++ {
+		ch := message[i]
+		if ch >= 0x20 && ch <= 0x7e && ch != '%' {
+			b.WriteByte(ch)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", ch)
+	}
+	return b.String()
+}
+
+// captureWriter buffers the REST response so it can be re-encoded for the RPC client
+type captureWriter struct {
+	gin.ResponseWriter
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newCaptureWriter(w gin.ResponseWriter) *captureWriter {
+	return &captureWriter{ResponseWriter: w, header: make(http.Header), status: http.StatusOK}
+}
+
+func (w *captureWriter) Header() http.Header { return w.header }
+
+func (w *captureWriter) WriteHeader(status int) { w.status = status }
+
+func (w *captureWriter) WriteHeaderNow() {}
+
+func (w *captureWriter) Write(b []byte) (int, error) { return w.body.Write(b) }
+
+func (w *captureWriter) WriteString(s string) (int, error) { return w.body.WriteString(s) }
+
+func (w *captureWriter) Status() int { return w.status }
+
+func (w *captureWriter) Size() int { return w.body.Len() }
+
+func (w *captureWriter) Written() bool { return w.body.Len() > 0 }
+
+func (w *captureWriter) Flush() {}
@@ -0,0 +1,173 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newGRPCWebTestRouter serves the translator in front of a public route to backend
+func newGRPCWebTestRouter(backend *httptest.Server) *gin.Engine {
+	rp := NewReverseProxy(ProxyConfig{
+		Routes: []RouteConfig{
+			{
+				PathPrefix: "/api/v1/shows",
+				Service:    ServiceConfig{Name: "ticket-service", BaseURL: backend.URL},
+			},
+			{
+				PathPrefix:  "/api/v1/bookings",
+				RequireAuth: true,
+				Service:     ServiceConfig{Name: "booking-service", BaseURL: backend.URL},
+			},
+		},
+	})
+	translator := NewGRPCWebTranslator(DefaultGRPCWebMethods(), NewRouter(rp, "test-secret").MatchHandler())
+
+	engine := gin.New()
+	engine.POST("/"+GRPCWebService+"/:method", translator.Handler())
+	return engine
+}
+
+// grpcWebFrames splits a gRPC-web response body into its message and trailers
+func grpcWebFrames(t *testing.T, body []byte) (message []byte, trailers string) {
+	t.Helper()
+	for len(body) > 0 {
+		if len(body) < 5 {
+			t.Fatalf("truncated frame header: %q", body)
+		}
+		length := binary.BigEndian.Uint32(body[1:5])
+		payload := body[5 : 5+length]
+		if body[0] == grpcWebTrailerFrame {
+			trailers = string(payload)
+		} else {
+			message = payload
+		}
+		body = body[5+length:]
+	}
+	return message, trailers
+}
+
+func TestGRPCWebTranslator_GRPCWeb(t *testing.T) {
+	var gotMethod, gotPath string
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath = r.Method, r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data":    []map[string]interface{}{{"id": "zone-1", "available_seats": 12}},
+		})
+	}))
+	defer backend.Close()
+	router := newGRPCWebTestRouter(backend)
+
+	var body bytes.Buffer
+	writeGRPCWebFrame(&body, grpcWebDataFrame, []byte(`{"show_id":"show-1"}`))
+	req := httptest.NewRequest(http.MethodPost, "/"+GRPCWebService+"/GetShowAvailability", &body)
+	req.Header.Set("Content-Type", contentTypeGRPCWebJSON)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != contentTypeGRPCWebJSON {
+		t.Fatalf("status = %d, content type = %q, want 200 grpc-web+json", w.Code, w.Header().Get("Content-Type"))
+	}
+	if gotMethod != http.MethodGet || gotPath != "/api/v1/shows/show-1/zones" {
+		t.Errorf("backend got %s %s, want GET /api/v1/shows/show-1/zones", gotMethod, gotPath)
+	}
+
+	message, trailers := grpcWebFrames(t, w.Body.Bytes())
+	var zones []map[string]interface{}
+	if err := json.Unmarshal(message, &zones); err != nil || len(zones) != 1 || zones[0]["id"] != "zone-1" {
+		t.Errorf("message = %s, want the data payload", message)
+	}
+	if !strings.Contains(trailers, "grpc-status: 0") {
+		t.Errorf("trailers = %q, want grpc-status 0", trailers)
+	}
+}
+
+func TestGRPCWebTranslator_GRPCWebText_Unauthenticated(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unauthenticated call reached the backend")
+	}))
+	defer backend.Close()
+	router := newGRPCWebTestRouter(backend)
+
+	var frame bytes.Buffer
+	writeGRPCWebFrame(&frame, grpcWebDataFrame, []byte(`{"booking_id":"booking-1"}`))
+	req := httptest.NewRequest(http.MethodPost, "/"+GRPCWebService+"/GetBookingStatus",
+		strings.NewReader(base64.StdEncoding.EncodeToString(frame.Bytes())))
+	req.Header.Set("Content-Type", contentTypeGRPCWebTextJSON)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	raw, err := base64.StdEncoding.DecodeString(w.Body.String())
+	if err != nil {
+		t.Fatalf("response is not base64: %v", err)
+	}
+	_, trailers := grpcWebFrames(t, raw)
+	if w.Code != http.StatusOK || !strings.Contains(trailers, "grpc-status: 16") {
+		t.Errorf("status = %d, trailers = %q, want 200 with grpc-status 16", w.Code, trailers)
+	}
+}
+
+func TestGRPCWebTranslator_Connect(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": "show not found", "code": "SHOW_NOT_FOUND"})
+	}))
+	defer backend.Close()
+	router := newGRPCWebTestRouter(backend)
+
+	tests := []struct {
+		name        string
+		method      string
+		contentType string
+		body        string
+		wantStatus  int
+		wantCode    string
+	}{
+		{"upstream error", "GetShowAvailability", contentTypeConnectJSON, `{"show_id":"missing"}`, http.StatusNotFound, "not_found"},
+		{"missing field", "GetShowAvailability", contentTypeConnectJSON, `{}`, http.StatusBadRequest, "invalid_argument"},
+		{"unknown method", "ListBookings", contentTypeConnectJSON, `{}`, http.StatusNotImplemented, "unimplemented"},
+		{"proto codec", "GetShowAvailability", "application/proto", ``, http.StatusUnsupportedMediaType, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/"+GRPCWebService+"/"+tt.method, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantCode == "" {
+				return
+			}
+			var rpcErr struct {
+				Code    string `json:"code"`
+				Message string `json:"message"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &rpcErr); err != nil || rpcErr.Code != tt.wantCode {
+				t.Errorf("body = %s, want code %s", w.Body.String(), tt.wantCode)
+			}
+		})
+	}
+
+	// The REST error code is kept as metadata
+	req := httptest.NewRequest(http.MethodPost, "/"+GRPCWebService+"/GetShowAvailability", strings.NewReader(`{"show_id":"missing"}`))
+	req.Header.Set("Content-Type", contentTypeConnectJSON)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if got := w.Header().Get("X-Error-Code"); got != "SHOW_NOT_FOUND" {
+		t.Errorf("X-Error-Code = %q, want SHOW_NOT_FOUND", got)
+	}
+}
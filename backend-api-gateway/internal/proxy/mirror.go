@@ -0,0 +1,259 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// MirrorHeader marks requests duplicated to the shadow upstream
+const MirrorHeader = "X-Mirrored-Request"
+
+// MirrorConfig holds settings for mirroring live traffic to a shadow upstream.
+// Mirrored requests are fire-and-forget: responses are discarded and never affect the client.
+type MirrorConfig struct {
+	// Enabled turns mirroring on
+	Enabled bool
+	// TargetURL is the shadow upstream base URL (e.g., a staging booking-service)
+	TargetURL string
+	// Percent of matching requests to mirror (0-100)
+	Percent float64
+	// Services limits mirroring to routes targeting these services (empty = all)
+	Services []string
+	// MaxConcurrent caps in-flight mirrored requests; extra samples are dropped
+	MaxConcurrent int
+	// MaxBodyBytes skips mirroring requests with larger bodies
+	MaxBodyBytes int64
+	// Timeout bounds each mirrored request
+	Timeout time.Duration
+}
+
+// DefaultMirrorConfig returns mirroring settings (disabled) for booking-service traffic
+func DefaultMirrorConfig() MirrorConfig {
+	return MirrorConfig{
+		Enabled:       false,
+		Percent:       1,
+		Services:      []string{"booking-service"},
+		MaxConcurrent: 100,
+		MaxBodyBytes:  1 << 20,
+		Timeout:       5 * time.Second,
+	}
+}
+
+// MirrorConfigFromEnv returns mirroring settings with environment overrides
+func MirrorConfigFromEnv() MirrorConfig {
+	cfg := DefaultMirrorConfig()
+	cfg.Enabled = getEnvBool("PROXY_MIRROR_ENABLED", cfg.Enabled)
+	cfg.TargetURL = os.Getenv("PROXY_MIRROR_URL")
+	if value := os.Getenv("PROXY_MIRROR_PERCENT"); value != "" {
+		if p, err := strconv.ParseFloat(value, 64); err == nil {
+			cfg.Percent = p
+		}
+	}
+	if value, ok := os.LookupEnv("PROXY_MIRROR_SERVICES"); ok {
+		cfg.Services = nil
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				cfg.Services = append(cfg.Services, name)
+			}
+		}
+	}
+	cfg.MaxConcurrent = getEnvInt("PROXY_MIRROR_MAX_CONCURRENT", cfg.MaxConcurrent)
+	cfg.MaxBodyBytes = int64(getEnvInt("PROXY_MIRROR_MAX_BODY_BYTES", int(cfg.MaxBodyBytes)))
+	cfg.Timeout = getEnvDuration("PROXY_MIRROR_TIMEOUT", cfg.Timeout)
+	return cfg
+}
+
+// Validate checks the mirroring settings
+func (c MirrorConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	target, err := url.Parse(c.TargetURL)
+	if err != nil || target.Scheme == "" || target.Host == "" {
+		return fmt.Errorf("invalid mirror target URL %q", c.TargetURL)
+	}
+	if c.Percent < 0 || c.Percent > 100 {
+		return fmt.Errorf("mirror percent must be between 0 and 100, got %v", c.Percent)
+	}
+	if c.MaxConcurrent <= 0 {
+		return errors.New("mirror max concurrent must be positive")
+	}
+	return nil
+}
+
+// MirrorStats is a snapshot of mirroring activity
+type MirrorStats struct {
+	TargetURL string  `json:"target_url"`
+	Percent   float64 `json:"percent"`
+	InFlight  int     `json:"in_flight"`
+	Sampled   int64   `json:"sampled"`
+	Sent      int64   `json:"sent"`
+	Errors    int64   `json:"errors"`
+	// Dropped counts samples discarded because MaxConcurrent requests were in flight
+	Dropped int64 `json:"dropped"`
+	// SkippedBody counts samples not mirrored because the body exceeded MaxBodyBytes
+	SkippedBody int64 `json:"skipped_body"`
+}
+
+// mirror duplicates sampled requests to a shadow upstream
+type mirror struct {
+	config   MirrorConfig
+	target   *url.URL
+	services map[string]bool
+	client   *http.Client
+	slots    chan struct{}
+	sample   func() float64 // returns [0, 100)
+
+	sampled     atomic.Int64
+	sent        atomic.Int64
+	errors      atomic.Int64
+	dropped     atomic.Int64
+	skippedBody atomic.Int64
+}
+
+// newMirror creates a mirror from validated settings. The shadow upstream gets its own
+// connection pool so a slow shadow cannot starve production upstreams.
+func newMirror(config MirrorConfig, transport TransportConfig) (*mirror, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	target, _ := url.Parse(config.TargetURL)
+
+	services := make(map[string]bool, len(config.Services))
+	for _, name := range config.Services {
+		services[name] = true
+	}
+
+	transport.MaxConnsPerHost = config.MaxConcurrent
+	return &mirror{
+		config:   config,
+		target:   target,
+		services: services,
+		client: &http.Client{
+			Transport: newTransport(transport),
+			Timeout:   config.Timeout,
+			// Redirects from the shadow are not followed
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		slots:  make(chan struct{}, config.MaxConcurrent),
+		sample: func() float64 { return rand.Float64() * 100 },
+	}, nil
+}
+
+// matches reports whether requests for a service are mirrored
+func (m *mirror) matches(service string) bool {
+	return len(m.services) == 0 || m.services[service]
+}
+
+// maybeMirror samples a request and, if picked, sends a copy to the shadow upstream in the
+// background. The request body is buffered and restored so the primary proxy still reads it.
+// Must be called after the request is fully prepared for the primary upstream.
+func (m *mirror) maybeMirror(req *http.Request) {
+	if m.sample() >= m.config.Percent {
+		return
+	}
+	m.sampled.Add(1)
+
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.ContentLength > m.config.MaxBodyBytes {
+			m.skippedBody.Add(1)
+			return
+		}
+		buf, err := io.ReadAll(io.LimitReader(req.Body, m.config.MaxBodyBytes+1))
+		if err != nil {
+			// Hand the primary whatever was read plus the unread remainder
+			req.Body = readCloser{io.MultiReader(bytes.NewReader(buf), req.Body), req.Body}
+			m.errors.Add(1)
+			return
+		}
+		if int64(len(buf)) > m.config.MaxBodyBytes {
+			req.Body = readCloser{io.MultiReader(bytes.NewReader(buf), req.Body), req.Body}
+			m.skippedBody.Add(1)
+			return
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(buf))
+		body = buf
+	}
+
+	// Never block the client on the shadow
+	select {
+	case m.slots <- struct{}{}:
+	default:
+		m.dropped.Add(1)
+		return
+	}
+
+	shadow, err := m.shadowRequest(req, body)
+	if err != nil {
+		<-m.slots
+		m.errors.Add(1)
+		return
+	}
+
+	go func() {
+		defer func() { <-m.slots }()
+		resp, err := m.client.Do(shadow)
+		if err != nil {
+			m.errors.Add(1)
+			return
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		m.sent.Add(1)
+	}()
+}
+
+// shadowRequest copies req for the shadow upstream, detached from the client's context
+func (m *mirror) shadowRequest(req *http.Request, body []byte) (*http.Request, error) {
+	shadowURL := *m.target
+	shadowURL.Path = strings.TrimRight(m.target.Path, "/") + req.URL.Path
+	shadowURL.RawQuery = req.URL.RawQuery
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	// The client timeout bounds the request; the client's own context ends with its response
+	shadow, err := http.NewRequestWithContext(context.Background(), req.Method, shadowURL.String(), reader)
+	if err != nil {
+		return nil, err
+	}
+	shadow.Header = req.Header.Clone()
+	shadow.Header.Set(MirrorHeader, "true")
+	return shadow, nil
+}
+
+// stats returns a snapshot of mirroring activity
+func (m *mirror) stats() *MirrorStats {
+	return &MirrorStats{
+		TargetURL:   m.config.TargetURL,
+		Percent:     m.config.Percent,
+		InFlight:    len(m.slots),
+		Sampled:     m.sampled.Load(),
+		Sent:        m.sent.Load(),
+		Errors:      m.errors.Load(),
+		Dropped:     m.dropped.Load(),
+		SkippedBody: m.skippedBody.Load(),
+	}
+}
+
+// readCloser pairs a reader with the closer of the original body
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
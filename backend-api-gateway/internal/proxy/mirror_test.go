@@ -0,0 +1,234 @@
+package proxy
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	pkgmiddleware "github.com/prohmpiriya/booking-rush-10k-rps/pkg/middleware"
+)
+
+// shadowRequestInfo is what the shadow upstream saw
+type shadowRequestInfo struct {
+	path     string
+	body     string
+	userID   string
+	mirrored string
+}
+
+// newMirrorTestProxy returns a proxy for a booking-service backend mirroring to shadow
+func newMirrorTestProxy(t *testing.T, backendURL, shadowURL string, mutate func(*MirrorConfig)) *ReverseProxy {
+	t.Helper()
+	mirrorConfig := DefaultMirrorConfig()
+	mirrorConfig.Enabled = true
+	mirrorConfig.TargetURL = shadowURL
+	mirrorConfig.Percent = 100
+	if mutate != nil {
+		mutate(&mirrorConfig)
+	}
+
+	rp := NewReverseProxy(ProxyConfig{
+		DefaultTimeout: 5 * time.Second,
+		Mirror:         &mirrorConfig,
+		Routes: []RouteConfig{
+			{
+				PathPrefix: "/api/v1/bookings",
+				Service:    ServiceConfig{Name: "booking-service", BaseURL: backendURL},
+			},
+			{
+				PathPrefix: "/api/v1/events",
+				Service:    ServiceConfig{Name: "ticket-service", BaseURL: backendURL},
+			},
+		},
+	})
+	if rp.mirror == nil {
+		t.Fatal("Expected mirroring to be enabled")
+	}
+	return rp
+}
+
+func serveProxy(rp *ReverseProxy, method, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, path, strings.NewReader(body))
+	c.Set(pkgmiddleware.ContextKeyUserID, "user-123")
+	rp.Handler()(c)
+	return w
+}
+
+func TestMirror_DuplicatesRequestToShadow(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Write(body)
+	}))
+	defer backend.Close()
+
+	received := make(chan shadowRequestInfo, 1)
+	shadow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- shadowRequestInfo{
+			path:     r.URL.RequestURI(),
+			body:     string(body),
+			userID:   r.Header.Get("X-User-ID"),
+			mirrored: r.Header.Get(MirrorHeader),
+		}
+		// Shadow failures must not leak to the client
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer shadow.Close()
+
+	rp := newMirrorTestProxy(t, backend.URL, shadow.URL, nil)
+	w := serveProxy(rp, "POST", "/api/v1/bookings/reserve?src=web", `{"quantity":2}`)
+
+	if w.Code != http.StatusOK || w.Body.String() != `{"quantity":2}` {
+		t.Errorf("Primary response = %d %q, want 200 with the original body", w.Code, w.Body.String())
+	}
+
+	select {
+	case got := <-received:
+		if got.path != "/api/v1/bookings/reserve?src=web" {
+			t.Errorf("Shadow path = %q", got.path)
+		}
+		if got.body != `{"quantity":2}` {
+			t.Errorf("Shadow body = %q", got.body)
+		}
+		if got.userID != "user-123" {
+			t.Errorf("Shadow X-User-ID = %q, want user-123", got.userID)
+		}
+		if got.mirrored != "true" {
+			t.Errorf("Shadow %s = %q, want true", MirrorHeader, got.mirrored)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Shadow upstream did not receive the mirrored request")
+	}
+}
+
+func TestMirror_SkipsUnmatchedServicesAndUnsampled(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer backend.Close()
+
+	received := make(chan struct{}, 2)
+	shadow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- struct{}{}
+	}))
+	defer shadow.Close()
+
+	rp := newMirrorTestProxy(t, backend.URL, shadow.URL, func(c *MirrorConfig) { c.Percent = 50 })
+	rp.mirror.sample = func() float64 { return 75 }
+
+	serveProxy(rp, "GET", "/api/v1/events", "")
+	serveProxy(rp, "GET", "/api/v1/bookings", "")
+
+	select {
+	case <-received:
+		t.Fatal("Expected no mirrored requests")
+	case <-time.After(100 * time.Millisecond):
+	}
+	if stats := rp.MirrorStats(); stats.Sampled != 0 {
+		t.Errorf("Sampled = %d, want 0", stats.Sampled)
+	}
+}
+
+func TestMirror_DropsWhenConcurrencyCapReached(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer backend.Close()
+
+	release := make(chan struct{})
+	shadow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer shadow.Close()
+	defer close(release)
+
+	rp := newMirrorTestProxy(t, backend.URL, shadow.URL, func(c *MirrorConfig) { c.MaxConcurrent = 1 })
+
+	serveProxy(rp, "GET", "/api/v1/bookings/1", "")
+	serveProxy(rp, "GET", "/api/v1/bookings/2", "")
+
+	stats := rp.MirrorStats()
+	if stats.Sampled != 2 || stats.Dropped != 1 || stats.InFlight != 1 {
+		t.Errorf("Stats = %+v, want 2 sampled, 1 dropped, 1 in flight", stats)
+	}
+}
+
+func TestMirror_SkipsLargeBodies(t *testing.T) {
+	var primaryBody string
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		primaryBody = string(body)
+	}))
+	defer backend.Close()
+
+	shadow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer shadow.Close()
+
+	rp := newMirrorTestProxy(t, backend.URL, shadow.URL, func(c *MirrorConfig) { c.MaxBodyBytes = 4 })
+
+	// Unknown length so the body is only found to be too large while buffering
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/api/v1/bookings", io.NopCloser(strings.NewReader("0123456789")))
+	c.Request.ContentLength = -1
+	rp.Handler()(c)
+
+	if primaryBody != "0123456789" {
+		t.Errorf("Primary body = %q, want the full body", primaryBody)
+	}
+	if stats := rp.MirrorStats(); stats.SkippedBody != 1 {
+		t.Errorf("SkippedBody = %d, want 1", stats.SkippedBody)
+	}
+}
+
+func TestMirrorConfig_Validate(t *testing.T) {
+	valid := DefaultMirrorConfig()
+	valid.Enabled = true
+	valid.TargetURL = "http://booking-shadow:8083"
+
+	tests := []struct {
+		name    string
+		mutate  func(*MirrorConfig)
+		wantErr bool
+	}{
+		{"valid", func(c *MirrorConfig) {}, false},
+		{"disabled ignores settings", func(c *MirrorConfig) { c.Enabled = false; c.TargetURL = "" }, false},
+		{"missing target", func(c *MirrorConfig) { c.TargetURL = "" }, true},
+		{"percent too high", func(c *MirrorConfig) { c.Percent = 101 }, true},
+		{"no concurrency", func(c *MirrorConfig) { c.MaxConcurrent = 0 }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			tt.mutate(&cfg)
+			if err := cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMirrorConfigFromEnv(t *testing.T) {
+	os.Setenv("PROXY_MIRROR_ENABLED", "true")
+	os.Setenv("PROXY_MIRROR_URL", "http://booking-shadow:8083")
+	os.Setenv("PROXY_MIRROR_PERCENT", "12.5")
+	os.Setenv("PROXY_MIRROR_SERVICES", "booking-service, ticket-service")
+	defer func() {
+		os.Unsetenv("PROXY_MIRROR_ENABLED")
+		os.Unsetenv("PROXY_MIRROR_URL")
+		os.Unsetenv("PROXY_MIRROR_PERCENT")
+		os.Unsetenv("PROXY_MIRROR_SERVICES")
+	}()
+
+	cfg := MirrorConfigFromEnv()
+	if !cfg.Enabled || cfg.TargetURL != "http://booking-shadow:8083" || cfg.Percent != 12.5 {
+		t.Errorf("MirrorConfigFromEnv() = %+v", cfg)
+	}
+	if len(cfg.Services) != 2 || cfg.Services[1] != "ticket-service" {
+		t.Errorf("Services = %v, want [booking-service ticket-service]", cfg.Services)
+	}
+}
@@ -1,7 +1,9 @@
 package proxy
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net"
@@ -10,12 +12,15 @@ import (
 	"net/url"
 	"os"
 	"runtime/debug"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/i18n"
 	pkgmiddleware "github.com/prohmpiriya/booking-rush-10k-rps/pkg/middleware"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/response"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -30,7 +35,8 @@ type ServiceConfig struct {
 
 // RouteConfig holds configuration for a route
 type RouteConfig struct {
-	// PathPrefix is the prefix that triggers this route (e.g., "/api/v1/auth")
+	// PathPrefix is the prefix that triggers this route (e.g., "/api/v1/auth"). Segments
+	// that are * or :param match any one segment (e.g., "/api/v1/events/:id/reviews").
 	PathPrefix string
 	// StripPrefix removes the prefix before forwarding (e.g., strip "/api/v1" from path)
 	StripPrefix string
@@ -40,21 +46,45 @@ type RouteConfig struct {
 	RequireAuth bool
 	// AllowedMethods restricts which HTTP methods are allowed (empty = all)
 	AllowedMethods []string
+	// Roles restricts an authenticated route to users with one of these roles (empty = any user)
+	Roles []string
+	// RateLimitClass names the rate limit applied to the route (empty = per-endpoint rules)
+	RateLimitClass string
+	// ForwardToken keeps the Authorization header for services that need the raw token
+	// (auth-service revokes and refreshes it); other services only get signed identity headers
+	ForwardToken bool
+	// Description documents the route in the route table listing
+	Description string
 }
 
 // ProxyConfig holds the overall proxy configuration
 type ProxyConfig struct {
-	Routes        []RouteConfig
+	Routes         []RouteConfig
 	DefaultTimeout time.Duration
-	JWTSecret     string
+	JWTSecret      string
+	// InternalAuthSecret signs the identity headers forwarded after JWT validation,
+	// so services verify one HMAC instead of the token (empty = unsigned headers plus the token)
+	InternalAuthSecret string
+	// Transport tunes upstream connection pooling (nil = DefaultTransportConfig)
+	Transport *TransportConfig
+	// Mirror duplicates a share of traffic to a shadow upstream (nil = disabled)
+	Mirror *MirrorConfig
+	// Sticky routes each user to one instance of a service by consistent hashing (nil = disabled)
+	Sticky *StickyConfig
 }
 
 // ReverseProxy manages routing to backend services
 type ReverseProxy struct {
-	config   ProxyConfig
-	proxies  map[string]*httputil.ReverseProxy
-	mu       sync.RWMutex
-	client   *http.Client
+	config  ProxyConfig
+	mu      sync.RWMutex
+	routes  []RouteConfig
+	proxies map[string]*httputil.ReverseProxy
+	// upstreams holds the base URL each service's proxy forwards to
+	upstreams map[string]string
+	client    *http.Client
+	tracker   *trackingTransport
+	mirror    *mirror
+	sticky    *stickyBalancer
 }
 
 // NewReverseProxy creates a new reverse proxy instance
@@ -63,48 +93,86 @@ func NewReverseProxy(config ProxyConfig) *ReverseProxy {
 		config.DefaultTimeout = 30 * time.Second
 	}
 
-	// Create optimized HTTP transport for high performance
-	// MaxIdleConns/MaxIdleConnsPerHost set to 15000 to handle 10K+ SSE connections at scale
-	transport := &http.Transport{
-		DialContext: (&net.Dialer{
-			Timeout:   5 * time.Second,
-			KeepAlive: 30 * time.Second,
-		}).DialContext,
-		MaxIdleConns:          15000,
-		MaxIdleConnsPerHost:   15000,
-		IdleConnTimeout:       90 * time.Second,
-		TLSHandshakeTimeout:   5 * time.Second,
-		ExpectContinueTimeout: 1 * time.Second,
-		DisableCompression:    false,
-		ForceAttemptHTTP2:     true,
+	if config.Transport == nil {
+		defaults := DefaultTransportConfig()
+		config.Transport = &defaults
 	}
 
+	// Create optimized HTTP transport for high performance, wrapped to track connection reuse
+	tracker := newTrackingTransport(newTransport(*config.Transport))
+
 	rp := &ReverseProxy{
-		config:  config,
-		proxies: make(map[string]*httputil.ReverseProxy),
+		config: config,
 		client: &http.Client{
-			Transport: transport,
+			Transport: tracker,
 			Timeout:   config.DefaultTimeout,
 		},
+		tracker: tracker,
 	}
 
-	// Initialize proxies for each unique service
-	for _, route := range config.Routes {
-		if _, exists := rp.proxies[route.Service.Name]; !exists {
-			rp.initProxy(route.Service)
+	// Invalid mirror settings leave mirroring off; callers validate with MirrorConfig.Validate
+	if config.Mirror != nil && config.Mirror.Enabled {
+		if m, err := newMirror(*config.Mirror, *config.Transport); err == nil {
+			rp.mirror = m
 		}
 	}
 
+	// Invalid sticky settings leave sticky routing off; callers validate with StickyConfig.Validate
+	if config.Sticky != nil && config.Sticky.Enabled {
+		if b, err := newStickyBalancer(*config.Sticky, rp); err == nil {
+			rp.sticky = b
+		}
+	}
+
+	rp.SetRoutes(config.Routes)
+
 	return rp
 }
 
-// initProxy initializes a reverse proxy for a service
-func (rp *ReverseProxy) initProxy(service ServiceConfig) {
-	targetURL, err := url.Parse(service.BaseURL)
-	if err != nil {
-		return
+// SetRoutes replaces the routes the proxy serves. Requests already matched finish on the
+// old routes; services whose base URL is unchanged keep their proxy and connections.
+func (rp *ReverseProxy) SetRoutes(routes []RouteConfig) {
+	rp.mu.RLock()
+	current, currentURLs := rp.proxies, rp.upstreams
+	rp.mu.RUnlock()
+
+	// Initialize proxies for each unique service
+	proxies := make(map[string]*httputil.ReverseProxy)
+	upstreams := make(map[string]string)
+	for _, route := range routes {
+		service := route.Service
+		if _, exists := upstreams[service.Name]; exists {
+			continue
+		}
+		if proxy, ok := current[service.Name]; ok && currentURLs[service.Name] == service.BaseURL {
+			proxies[service.Name] = proxy
+			upstreams[service.Name] = service.BaseURL
+			continue
+		}
+		targetURL, err := url.Parse(service.BaseURL)
+		if err != nil {
+			continue
+		}
+		proxies[service.Name] = rp.newUpstreamProxy(targetURL)
+		upstreams[service.Name] = service.BaseURL
 	}
 
+	rp.mu.Lock()
+	rp.routes = routes
+	rp.proxies = proxies
+	rp.upstreams = upstreams
+	rp.mu.Unlock()
+}
+
+// Routes returns the routes the proxy serves
+func (rp *ReverseProxy) Routes() []RouteConfig {
+	rp.mu.RLock()
+	defer rp.mu.RUnlock()
+	return rp.routes
+}
+
+// newUpstreamProxy creates a reverse proxy forwarding to one upstream base URL
+func (rp *ReverseProxy) newUpstreamProxy(targetURL *url.URL) *httputil.ReverseProxy {
 	proxy := httputil.NewSingleHostReverseProxy(targetURL)
 	proxy.Transport = rp.client.Transport
 
@@ -118,46 +186,73 @@ func (rp *ReverseProxy) initProxy(service ServiceConfig) {
 	// Custom error handler
 	proxy.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
 		w.Header().Set("Content-Type", "application/json")
+		locale := i18n.FromContext(r.Context())
+		var resp *response.Response
 		if isTimeoutError(err) {
 			w.WriteHeader(http.StatusGatewayTimeout)
-			io.WriteString(w, `{"success":false,"error":{"code":"GATEWAY_TIMEOUT","message":"Backend service timed out"}}`)
+			resp = response.Error("GATEWAY_TIMEOUT", "Backend service timed out")
 		} else if isConnectionError(err) {
 			w.WriteHeader(http.StatusBadGateway)
-			io.WriteString(w, `{"success":false,"error":{"code":"BAD_GATEWAY","message":"Backend service unavailable"}}`)
+			resp = response.Error("BAD_GATEWAY", "Backend service unavailable")
 		} else {
 			w.WriteHeader(http.StatusBadGateway)
-			io.WriteString(w, `{"success":false,"error":{"code":"BAD_GATEWAY","message":"Backend service error"}}`)
+			resp = response.Error("BAD_GATEWAY", "Backend service error")
 		}
+		body, _ := json.Marshal(resp.Localize(locale))
+		w.Write(body)
 	}
 
 	// Custom response modifier
 	proxy.ModifyResponse = func(resp *http.Response) error {
 		// Add gateway headers
 		resp.Header.Set("X-Proxied-By", "api-gateway")
+		return localizeErrorResponse(resp)
+	}
+
+	return proxy
+}
+
+// maxLocalizedErrorBody caps the error bodies the gateway rewrites into the client's locale
+const maxLocalizedErrorBody = 64 << 10
+
+// localizeErrorResponse translates the message of a JSON error response into the locale
+// negotiated for the request. Services answer in English, so English responses, successful
+// responses and compressed or large bodies are passed through untouched.
+func localizeErrorResponse(resp *http.Response) error {
+	if resp.StatusCode < http.StatusBadRequest || resp.Request == nil {
+		return nil
+	}
+	locale := i18n.FromContext(resp.Request.Context())
+	if locale == i18n.DefaultLocale ||
+		resp.Header.Get("Content-Encoding") != "" ||
+		!strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") ||
+		resp.ContentLength > maxLocalizedErrorBody {
 		return nil
 	}
 
-	rp.mu.Lock()
-	rp.proxies[service.Name] = proxy
-	rp.mu.Unlock()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxLocalizedErrorBody+1))
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+	if len(body) <= maxLocalizedErrorBody {
+		body, _ = response.LocalizeErrorBody(body, locale)
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	resp.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	resp.Header.Set("Content-Language", locale.String())
+	return nil
 }
 
 // findRoute finds the matching route for a request
 func (rp *ReverseProxy) findRoute(path, method string) *RouteConfig {
-	for _, route := range rp.config.Routes {
-		if strings.HasPrefix(path, route.PathPrefix) {
+	for _, route := range rp.Routes() {
+		if matchRoutePath(route.PathPrefix, path) {
 			// Check method if restricted
-			if len(route.AllowedMethods) > 0 {
-				allowed := false
-				for _, m := range route.AllowedMethods {
-					if strings.EqualFold(m, method) {
-						allowed = true
-						break
-					}
-				}
-				if !allowed {
-					continue
-				}
+			if len(route.AllowedMethods) > 0 && !containsMethod(route.AllowedMethods, method) {
+				continue
 			}
 			return &route
 		}
@@ -165,6 +260,15 @@ func (rp *ReverseProxy) findRoute(path, method string) *RouteConfig {
 	return nil
 }
 
+// RateLimitClass returns the rate-limit class of the route a request matches, empty when
+// the route has none or no route matches
+func (rp *ReverseProxy) RateLimitClass(method, path string) string {
+	if route := rp.findRoute(path, method); route != nil {
+		return route.RateLimitClass
+	}
+	return ""
+}
+
 // Handler returns a Gin handler for proxying requests
 func (rp *ReverseProxy) Handler() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -177,6 +281,7 @@ func (rp *ReverseProxy) Handler() gin.HandlerFunc {
 			attribute.String("http.path", c.Request.URL.Path),
 		)
 
+		locale := i18n.FromContext(ctx)
 		route := rp.findRoute(c.Request.URL.Path, c.Request.Method)
 		if route == nil {
 			span.SetStatus(codes.Error, "No route configured for this path")
@@ -184,7 +289,7 @@ func (rp *ReverseProxy) Handler() gin.HandlerFunc {
 				"success": false,
 				"error": gin.H{
 					"code":    "ROUTE_NOT_FOUND",
-					"message": "No route configured for this path",
+					"message": i18n.T(locale, "ROUTE_NOT_FOUND", "No route configured for this path"),
 				},
 			})
 			c.Abort()
@@ -204,13 +309,21 @@ func (rp *ReverseProxy) Handler() gin.HandlerFunc {
 				"success": false,
 				"error": gin.H{
 					"code":    "SERVICE_NOT_CONFIGURED",
-					"message": "Backend service not configured",
+					"message": i18n.T(locale, "SERVICE_NOT_CONFIGURED", "Backend service not configured"),
 				},
 			})
 			c.Abort()
 			return
 		}
 
+		// Keep released users on the instance whose caches are warm for them
+		if rp.sticky != nil && rp.sticky.matches(route.Service.Name) {
+			if upstream := rp.sticky.pick(c); upstream != nil {
+				proxy = upstream.proxy
+				span.SetAttributes(attribute.String("target.instance", upstream.url))
+			}
+		}
+
 		// Strip prefix if configured
 		if route.StripPrefix != "" {
 			c.Request.URL.Path = strings.TrimPrefix(c.Request.URL.Path, route.StripPrefix)
@@ -220,24 +333,21 @@ func (rp *ReverseProxy) Handler() gin.HandlerFunc {
 		}
 
 		// Add user context headers if authenticated
-		if userID, exists := c.Get(pkgmiddleware.ContextKeyUserID); exists {
-			c.Request.Header.Set("X-User-ID", userID.(string))
-		}
-		if email, exists := c.Get(pkgmiddleware.ContextKeyEmail); exists {
-			c.Request.Header.Set("X-User-Email", email.(string))
-		}
-		if role, exists := c.Get(pkgmiddleware.ContextKeyRole); exists {
-			c.Request.Header.Set("X-User-Role", role.(string))
-		}
-		if tenantID, exists := c.Get(pkgmiddleware.ContextKeyTenantID); exists {
-			c.Request.Header.Set("X-Tenant-ID", tenantID.(string))
-		}
+		rp.forwardIdentity(c, route)
+
+		// Backend services localize notifications with the negotiated locale
+		c.Request.Header.Set(i18n.HeaderLocale, locale.String())
 
 		// Add request ID for tracing
 		if requestID := c.GetHeader("X-Request-ID"); requestID != "" {
 			c.Request.Header.Set("X-Request-ID", requestID)
 		}
 
+		// Duplicate to the shadow upstream before the body is consumed
+		if rp.mirror != nil && rp.mirror.matches(route.Service.Name) {
+			rp.mirror.maybeMirror(c.Request)
+		}
+
 		// Set timeout context
 		timeout := route.Service.Timeout
 		if timeout == 0 {
@@ -287,6 +397,48 @@ func isTimeoutError(err error) bool {
 	return strings.Contains(err.Error(), "timeout")
 }
 
+// forwardIdentity replaces client-supplied identity headers with the claims validated by
+// JWTMiddleware. With an internal auth secret the headers are signed and the token is
+// stripped, so backend services skip JWT verification.
+func (rp *ReverseProxy) forwardIdentity(c *gin.Context, route *RouteConfig) {
+	pkgmiddleware.StripIdentityHeaders(c.Request.Header)
+
+	userID, authenticated := pkgmiddleware.GetUserID(c)
+	if !authenticated {
+		return
+	}
+	email, _ := pkgmiddleware.GetEmail(c)
+	role, _ := pkgmiddleware.GetRole(c)
+	tenantID, _ := pkgmiddleware.GetTenantID(c)
+	verification, _ := pkgmiddleware.GetVerification(c)
+
+	if rp.config.InternalAuthSecret == "" {
+		c.Request.Header.Set(pkgmiddleware.HeaderUserID, userID)
+		c.Request.Header.Set(pkgmiddleware.HeaderUserEmail, email)
+		c.Request.Header.Set(pkgmiddleware.HeaderUserRole, role)
+		c.Request.Header.Set(pkgmiddleware.HeaderTenantID, tenantID)
+		if verification != nil {
+			c.Request.Header.Set(pkgmiddleware.HeaderUserVerification, verification.Encode())
+		}
+		return
+	}
+
+	claims := &pkgmiddleware.InternalClaims{
+		UserID:       userID,
+		Email:        email,
+		TenantID:     tenantID,
+		Verification: verification,
+	}
+	if role != "" {
+		claims.Roles = []string{role}
+	}
+	pkgmiddleware.SignInternalRequest(c.Request, rp.config.InternalAuthSecret, claims, time.Now())
+
+	if !route.ForwardToken {
+		c.Request.Header.Del("Authorization")
+	}
+}
+
 // isConnectionError checks if error is a connection error
 func isConnectionError(err error) bool {
 	if err == nil {
@@ -370,13 +522,15 @@ func DefaultConfig() ProxyConfig {
 					BaseURL: authURL,
 					Timeout: 10 * time.Second,
 				},
-				RequireAuth: true,
+				RequireAuth:  true,
+				ForwardToken: true, // auth-service validates sessions itself
 			},
 		},
 	}
 }
 
-// ConfigFromEnv creates proxy config from environment variables
+// ConfigFromEnv creates proxy config from environment variables, with the routes of
+// the default route table (see default_routes.json)
 func ConfigFromEnv(authURL, ticketURL, bookingURL, paymentURL, jwtSecret string) ProxyConfig {
 	if authURL == "" {
 		authURL = "http://localhost:8081"
@@ -391,168 +545,22 @@ func ConfigFromEnv(authURL, ticketURL, bookingURL, paymentURL, jwtSecret string)
 		paymentURL = "http://localhost:8084"
 	}
 
+	routes, err := DefaultRouteTable().Build(ServiceURLLookup(authURL, ticketURL, bookingURL, paymentURL))
+	if err != nil {
+		panic(fmt.Sprintf("default route table: %v", err))
+	}
+
 	return ProxyConfig{
 		DefaultTimeout: 30 * time.Second,
 		JWTSecret:      jwtSecret,
-		Routes: []RouteConfig{
-			// Auth Service routes
-			{
-				PathPrefix:  "/api/v1/auth",
-				StripPrefix: "",
-				Service: ServiceConfig{
-					Name:    "auth-service",
-					BaseURL: authURL,
-					Timeout: 10 * time.Second,
-				},
-				RequireAuth: false,
-			},
-			// Events/Tickets - GET is public
-			{
-				PathPrefix:  "/api/v1/events",
-				StripPrefix: "",
-				Service: ServiceConfig{
-					Name:    "ticket-service",
-					BaseURL: ticketURL,
-					Timeout: 15 * time.Second,
-				},
-				RequireAuth:    false,
-				AllowedMethods: []string{"GET"},
-			},
-			// Events/Tickets - Write operations need auth
-			{
-				PathPrefix:  "/api/v1/events",
-				StripPrefix: "",
-				Service: ServiceConfig{
-					Name:    "ticket-service",
-					BaseURL: ticketURL,
-					Timeout: 15 * time.Second,
-				},
-				RequireAuth:    true,
-				AllowedMethods: []string{"POST", "PUT", "DELETE", "PATCH"},
-			},
-			// Shows - public GET
-			{
-				PathPrefix:  "/api/v1/shows",
-				StripPrefix: "",
-				Service: ServiceConfig{
-					Name:    "ticket-service",
-					BaseURL: ticketURL,
-					Timeout: 15 * time.Second,
-				},
-				RequireAuth:    false,
-				AllowedMethods: []string{"GET"},
-			},
-			// Shows - protected writes
-			{
-				PathPrefix:  "/api/v1/shows",
-				StripPrefix: "",
-				Service: ServiceConfig{
-					Name:    "ticket-service",
-					BaseURL: ticketURL,
-					Timeout: 15 * time.Second,
-				},
-				RequireAuth:    true,
-				AllowedMethods: []string{"POST", "PUT", "DELETE", "PATCH"},
-			},
-			// Zones - public GET
-			{
-				PathPrefix:  "/api/v1/zones",
-				StripPrefix: "",
-				Service: ServiceConfig{
-					Name:    "ticket-service",
-					BaseURL: ticketURL,
-					Timeout: 15 * time.Second,
-				},
-				RequireAuth:    false,
-				AllowedMethods: []string{"GET"},
-			},
-			// Zones - protected writes
-			{
-				PathPrefix:  "/api/v1/zones",
-				StripPrefix: "",
-				Service: ServiceConfig{
-					Name:    "ticket-service",
-					BaseURL: ticketURL,
-					Timeout: 15 * time.Second,
-				},
-				RequireAuth:    true,
-				AllowedMethods: []string{"POST", "PUT", "DELETE", "PATCH"},
-			},
-			// Bookings - all protected
-			{
-				PathPrefix:  "/api/v1/bookings",
-				StripPrefix: "",
-				Service: ServiceConfig{
-					Name:    "booking-service",
-					BaseURL: bookingURL,
-					Timeout: 30 * time.Second,
-				},
-				RequireAuth: true,
-			},
-			// Queue - all protected (SSE needs 5 minutes timeout)
-			{
-				PathPrefix:  "/api/v1/queue",
-				StripPrefix: "",
-				Service: ServiceConfig{
-					Name:    "booking-service",
-					BaseURL: bookingURL,
-					Timeout: 5 * time.Minute, // SSE streaming requires longer timeout
-				},
-				RequireAuth: true,
-			},
-			// Admin - booking service admin endpoints (protected)
-			{
-				PathPrefix:  "/api/v1/admin",
-				StripPrefix: "",
-				Service: ServiceConfig{
-					Name:    "booking-service",
-					BaseURL: bookingURL,
-					Timeout: 60 * time.Second, // Sync may take longer
-				},
-				RequireAuth: true,
-			},
-			// Payments - all protected
-			{
-				PathPrefix:  "/api/v1/payments",
-				StripPrefix: "",
-				Service: ServiceConfig{
-					Name:    "payment-service",
-					BaseURL: paymentURL,
-					Timeout: 60 * time.Second, // Payment needs longer timeout
-				},
-				RequireAuth: true,
-			},
-			// Stripe Webhooks - public (uses Stripe signature verification)
-			{
-				PathPrefix:  "/api/v1/webhooks",
-				StripPrefix: "",
-				Service: ServiceConfig{
-					Name:    "payment-service",
-					BaseURL: paymentURL,
-					Timeout: 30 * time.Second,
-				},
-				RequireAuth:    false,
-				AllowedMethods: []string{"POST"},
-			},
-			// User profile - protected
-			{
-				PathPrefix:  "/api/v1/users",
-				StripPrefix: "",
-				Service: ServiceConfig{
-					Name:    "auth-service",
-					BaseURL: authURL,
-					Timeout: 10 * time.Second,
-				},
-				RequireAuth: true,
-			},
-		},
+		Routes:         routes,
 	}
 }
 
 // GetRequireAuthRoutes returns routes that require authentication
 func (rp *ReverseProxy) GetRequireAuthRoutes() []RouteConfig {
 	var routes []RouteConfig
-	for _, r := range rp.config.Routes {
+	for _, r := range rp.Routes() {
 		if r.RequireAuth {
 			routes = append(routes, r)
 		}
@@ -563,7 +571,7 @@ func (rp *ReverseProxy) GetRequireAuthRoutes() []RouteConfig {
 // GetPublicRoutes returns routes that don't require authentication
 func (rp *ReverseProxy) GetPublicRoutes() []RouteConfig {
 	var routes []RouteConfig
-	for _, r := range rp.config.Routes {
+	for _, r := range rp.Routes() {
 		if !r.RequireAuth {
 			routes = append(routes, r)
 		}
@@ -571,6 +579,41 @@ func (rp *ReverseProxy) GetPublicRoutes() []RouteConfig {
 	return routes
 }
 
+// ConnStats returns upstream connection reuse statistics keyed by upstream host
+func (rp *ReverseProxy) ConnStats() map[string]ConnStats {
+	return rp.tracker.Stats()
+}
+
+// TransportConfig returns the effective upstream transport settings
+func (rp *ReverseProxy) TransportConfig() TransportConfig {
+	return *rp.config.Transport
+}
+
+// MirrorStats returns traffic mirroring statistics, nil when mirroring is disabled
+func (rp *ReverseProxy) MirrorStats() *MirrorStats {
+	if rp.mirror == nil {
+		return nil
+	}
+	return rp.mirror.stats()
+}
+
+// StickyStats returns sticky routing statistics, nil when sticky routing is disabled
+func (rp *ReverseProxy) StickyStats() *StickyStats {
+	if rp.sticky == nil {
+		return nil
+	}
+	return rp.sticky.stats()
+}
+
+// RunStickyHealthChecks checks the sticky upstreams until ctx is cancelled.
+// Returns immediately when sticky routing is disabled.
+func (rp *ReverseProxy) RunStickyHealthChecks(ctx context.Context) {
+	if rp.sticky == nil {
+		return
+	}
+	rp.sticky.run(ctx)
+}
+
 // HealthCheck checks if all backend services are reachable
 func (rp *ReverseProxy) HealthCheck(ctx context.Context) map[string]bool {
 	results := make(map[string]bool)
@@ -579,7 +622,7 @@ func (rp *ReverseProxy) HealthCheck(ctx context.Context) map[string]bool {
 
 	// Get unique services
 	services := make(map[string]ServiceConfig)
-	for _, route := range rp.config.Routes {
+	for _, route := range rp.Routes() {
 		services[route.Service.Name] = route.Service
 	}
 
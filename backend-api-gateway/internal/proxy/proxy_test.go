@@ -3,12 +3,15 @@ package proxy
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/i18n"
+	pkgmiddleware "github.com/prohmpiriya/booking-rush-10k-rps/pkg/middleware"
 )
 
 func init() {
@@ -183,6 +186,52 @@ func TestReverseProxy_Handler_NotFound(t *testing.T) {
 	}
 }
 
+func TestReverseProxy_LocalizesUpstreamErrors(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(i18n.HeaderLocale) != "th" {
+			t.Errorf("Expected X-Locale th to be forwarded, got %q", r.Header.Get(i18n.HeaderLocale))
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"success":false,"error":{"code":"NOT_FOUND","message":"Event not found"}}`))
+	}))
+	defer backend.Close()
+
+	rp := NewReverseProxy(ProxyConfig{
+		Routes: []RouteConfig{
+			{PathPrefix: "/api/v1/events", Service: ServiceConfig{Name: "ticket-service", BaseURL: backend.URL}},
+		},
+	})
+
+	r := gin.New()
+	r.Use(i18n.Middleware())
+	r.GET("/api/v1/events/*path", rp.Handler())
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/v1/events/123", nil)
+	req.Header.Set("Accept-Language", "th-TH,th;q=0.9,en;q=0.5")
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("Expected status 404, got %d", w.Code)
+	}
+	var resp struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if resp.Error.Code != "NOT_FOUND" || resp.Error.Message != "ไม่พบข้อมูลที่ต้องการ" {
+		t.Errorf("Expected Thai NOT_FOUND message, got %+v", resp.Error)
+	}
+	if got := w.Header().Get("Content-Language"); got != "th" {
+		t.Errorf("Expected Content-Language th, got %q", got)
+	}
+}
+
 func TestDefaultConfig(t *testing.T) {
 	config := DefaultConfig()
 
@@ -243,6 +292,42 @@ func TestConfigFromEnv(t *testing.T) {
 	}
 }
 
+func TestConfigFromEnv_WebhookRoutes(t *testing.T) {
+	rp := NewReverseProxy(ConfigFromEnv(
+		"http://auth:8081",
+		"http://ticket:8082",
+		"http://booking:8083",
+		"http://payment:8084",
+		"test-secret",
+	))
+
+	tests := []struct {
+		path        string
+		method      string
+		service     string
+		requireAuth bool
+	}{
+		{"/api/v1/webhook-subscriptions", "POST", "booking-service", true},
+		{"/api/v1/webhook-subscriptions/sub-1", "PATCH", "booking-service", true},
+		{"/api/v1/webhook-deliveries/d-1/retry", "POST", "booking-service", true},
+		{"/api/v1/webhooks/stripe", "POST", "payment-service", false},
+		{"/api/v1/team/members/user-2", "PUT", "ticket-service", true},
+		{"/api/v1/cart/checkouts/c-1/confirm", "POST", "booking-service", true},
+	}
+
+	for _, tt := range tests {
+		route := rp.findRoute(tt.path, tt.method)
+		if route == nil {
+			t.Errorf("%s %s: expected a route", tt.method, tt.path)
+			continue
+		}
+		if route.Service.Name != tt.service || route.RequireAuth != tt.requireAuth {
+			t.Errorf("%s %s: got %s (auth=%v), want %s (auth=%v)",
+				tt.method, tt.path, route.Service.Name, route.RequireAuth, tt.service, tt.requireAuth)
+		}
+	}
+}
+
 func TestGetRequireAuthRoutes(t *testing.T) {
 	config := ProxyConfig{
 		Routes: []RouteConfig{
@@ -487,3 +572,91 @@ func TestReverseProxyStripPrefix(t *testing.T) {
 		t.Errorf("Expected stripped path '/test/hello', got '%s'", receivedPath)
 	}
 }
+
+// TestReverseProxySignedIdentityHeaders tests JWT offload with signed identity headers
+func TestReverseProxySignedIdentityHeaders(t *testing.T) {
+	const secret = "internal-secret"
+
+	var receivedClaims *pkgmiddleware.InternalClaims
+	var verifyErr error
+	var receivedAuth string
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedClaims, verifyErr = pkgmiddleware.VerifyInternalRequest(r, secret, 0, time.Now())
+		receivedAuth = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer backend.Close()
+
+	config := ProxyConfig{
+		InternalAuthSecret: secret,
+		Routes: []RouteConfig{
+			{
+				PathPrefix: "/api/v1/test",
+				Service: ServiceConfig{
+					Name:    "test-service",
+					BaseURL: backend.URL,
+				},
+			},
+			{
+				PathPrefix:   "/api/v1/users",
+				ForwardToken: true,
+				Service: ServiceConfig{
+					Name:    "user-service",
+					BaseURL: backend.URL,
+				},
+			},
+		},
+	}
+	handler := NewReverseProxy(config).Handler()
+
+	serve := func(path string, authenticated bool) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", path, nil)
+		c.Request.Header.Set("Authorization", "Bearer token")
+		c.Request.Header.Set("X-User-ID", "spoofed")
+		c.Request.Header.Set("X-Internal-Signature", "spoofed")
+		if authenticated {
+			c.Set("user_id", "user-123")
+			c.Set("email", "test@example.com")
+			c.Set("role", "admin")
+			c.Set("tenant_id", "tenant-456")
+			c.Set(pkgmiddleware.ContextKeyVerification, &pkgmiddleware.Verification{EmailVerified: true, RiskLevel: pkgmiddleware.RiskLevelHigh})
+		}
+		handler(c)
+	}
+
+	t.Run("authenticated request is signed and token stripped", func(t *testing.T) {
+		serve("/api/v1/test/items", true)
+		if verifyErr != nil {
+			t.Fatalf("Expected valid signature, got %v", verifyErr)
+		}
+		if receivedClaims.UserID != "user-123" || receivedClaims.TenantID != "tenant-456" || receivedClaims.Role() != "admin" {
+			t.Errorf("Unexpected forwarded claims: %+v", receivedClaims)
+		}
+		if v := receivedClaims.Verification; v == nil || !v.EmailVerified || !v.IsHighRisk() {
+			t.Errorf("Unexpected forwarded verification: %+v", v)
+		}
+		if receivedAuth != "" {
+			t.Errorf("Expected Authorization header to be stripped, got %q", receivedAuth)
+		}
+	})
+
+	t.Run("forward token route keeps token", func(t *testing.T) {
+		serve("/api/v1/users/me", true)
+		if verifyErr != nil {
+			t.Fatalf("Expected valid signature, got %v", verifyErr)
+		}
+		if receivedAuth != "Bearer token" {
+			t.Errorf("Expected Authorization header to be forwarded, got %q", receivedAuth)
+		}
+	})
+
+	t.Run("client identity headers are dropped", func(t *testing.T) {
+		serve("/api/v1/test/items", false)
+		if !errors.Is(verifyErr, pkgmiddleware.ErrMissingSignature) {
+			t.Errorf("Expected no signature on unauthenticated request, got %v", verifyErr)
+		}
+	})
+}
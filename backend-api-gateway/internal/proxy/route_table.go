@@ -0,0 +1,388 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidRouteTable is returned when a route table file can't be parsed or a route in it is invalid
+var ErrInvalidRouteTable = errors.New("invalid route table")
+
+//go:embed default_routes.json
+var defaultRouteTable []byte
+
+// routeMethods are the HTTP methods a route may be restricted to
+var routeMethods = map[string]bool{
+	"GET": true, "POST": true, "PUT": true, "DELETE": true, "PATCH": true, "OPTIONS": true, "HEAD": true,
+}
+
+// RouteTable is the declarative route table: the backend services and the routes
+// forwarded to them. It is read from JSON, see default_routes.json.
+type RouteTable struct {
+	// Upstreams are the backend services routes forward to, keyed by service name
+	Upstreams map[string]UpstreamSpec `json:"upstreams"`
+	// Routes are matched in order; the first route matching the path and method wins
+	Routes []RouteSpec `json:"routes"`
+}
+
+// UpstreamSpec describes a backend service of the route table
+type UpstreamSpec struct {
+	// URL is the base URL; ${VAR} references are expanded (e.g. ${BOOKING_SERVICE_URL})
+	URL string `json:"url"`
+	// Timeout bounds requests to the service (empty = proxy default)
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// RouteSpec describes a route of the route table
+type RouteSpec struct {
+	// Path is a path prefix (/api/v1/bookings) or a pattern whose * and :param segments
+	// match any one segment (/api/v1/events/:id/reviews)
+	Path string `json:"path"`
+	// Methods restricts the route to these HTTP methods (empty = all)
+	Methods []string `json:"methods,omitempty"`
+	// Auth requires a valid JWT
+	Auth bool `json:"auth,omitempty"`
+	// Roles requires the JWT to carry one of these roles (empty = any authenticated user)
+	Roles []string `json:"roles,omitempty"`
+	// RateLimitClass selects the named rate limit of the route (empty = per-endpoint rules)
+	RateLimitClass string `json:"rate_limit_class,omitempty"`
+	// Upstream names the backend service of the route
+	Upstream string `json:"upstream"`
+	// Timeout overrides the upstream's timeout for this route
+	Timeout string `json:"timeout,omitempty"`
+	// StripPrefix removes this prefix from the path before forwarding
+	StripPrefix string `json:"strip_prefix,omitempty"`
+	// ForwardToken keeps the Authorization header (see RouteConfig.ForwardToken)
+	ForwardToken bool `json:"forward_token,omitempty"`
+	// Description documents the route
+	Description string `json:"description,omitempty"`
+}
+
+// ParseRouteTable parses a JSON route table. Unknown fields are rejected so a
+// misspelled setting doesn't silently open a route.
+func ParseRouteTable(data []byte) (*RouteTable, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var table RouteTable
+	if err := decoder.Decode(&table); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRouteTable, err)
+	}
+	return &table, nil
+}
+
+// DefaultRouteTable returns the route table compiled into the gateway
+func DefaultRouteTable() *RouteTable {
+	table, err := ParseRouteTable(defaultRouteTable)
+	if err != nil {
+		panic(fmt.Sprintf("default route table: %v", err))
+	}
+	return table
+}
+
+// ServiceURLLookup expands the service URL variables of a route table to the given URLs,
+// falling back to the environment for any other variable
+func ServiceURLLookup(authURL, ticketURL, bookingURL, paymentURL string) func(string) string {
+	urls := map[string]string{
+		"AUTH_SERVICE_URL":    authURL,
+		"TICKET_SERVICE_URL":  ticketURL,
+		"BOOKING_SERVICE_URL": bookingURL,
+		"PAYMENT_SERVICE_URL": paymentURL,
+	}
+	return func(name string) string {
+		if value, ok := urls[name]; ok {
+			return value
+		}
+		return os.Getenv(name)
+	}
+}
+
+// Build resolves the table into the proxy's routes. lookup expands the ${VAR} references
+// in upstream URLs (nil = environment).
+func (t *RouteTable) Build(lookup func(string) string) ([]RouteConfig, error) {
+	if lookup == nil {
+		lookup = os.Getenv
+	}
+	if len(t.Routes) == 0 {
+		return nil, fmt.Errorf("%w: no routes", ErrInvalidRouteTable)
+	}
+
+	var errs []error
+	services := make(map[string]ServiceConfig, len(t.Upstreams))
+	for name, upstream := range t.Upstreams {
+		timeout, err := parseRouteTimeout(upstream.Timeout)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w: upstream %s: %v", ErrInvalidRouteTable, name, err))
+		}
+		services[name] = ServiceConfig{
+			Name:    name,
+			BaseURL: os.Expand(upstream.URL, lookup),
+			Timeout: timeout,
+		}
+	}
+
+	routes := make([]RouteConfig, 0, len(t.Routes))
+	for i, spec := range t.Routes {
+		service, ok := services[spec.Upstream]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%w: route %d (%s): unknown upstream %q", ErrInvalidRouteTable, i, spec.Path, spec.Upstream))
+		}
+		if spec.Timeout != "" {
+			timeout, err := parseRouteTimeout(spec.Timeout)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%w: route %d (%s): %v", ErrInvalidRouteTable, i, spec.Path, err))
+			}
+			service.Timeout = timeout
+		}
+
+		routes = append(routes, RouteConfig{
+			PathPrefix:     spec.Path,
+			StripPrefix:    spec.StripPrefix,
+			Service:        service,
+			RequireAuth:    spec.Auth,
+			AllowedMethods: spec.Methods,
+			Roles:          spec.Roles,
+			RateLimitClass: spec.RateLimitClass,
+			ForwardToken:   spec.ForwardToken,
+			Description:    spec.Description,
+		})
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return routes, nil
+}
+
+// parseRouteTimeout parses a route table timeout, empty meaning the proxy default
+func parseRouteTimeout(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout < 0 {
+		return 0, fmt.Errorf("invalid timeout %q", value)
+	}
+	return timeout, nil
+}
+
+// ValidateRoutes checks routes before the proxy serves them. A route's rate-limit class
+// must be one of rateLimitClasses, unless that is nil (rate limiting disabled).
+func ValidateRoutes(routes []RouteConfig, rateLimitClasses []string) error {
+	classes := make(map[string]bool, len(rateLimitClasses))
+	for _, class := range rateLimitClasses {
+		classes[class] = true
+	}
+
+	var errs []error
+	for i, route := range routes {
+		invalid := func(format string, args ...any) {
+			errs = append(errs, fmt.Errorf("%w: route %d (%s): %s", ErrInvalidRouteTable, i, route.PathPrefix, fmt.Sprintf(format, args...)))
+		}
+
+		if !strings.HasPrefix(route.PathPrefix, "/") {
+			invalid("path must start with /")
+		}
+		if route.StripPrefix != "" && !strings.HasPrefix(route.PathPrefix, route.StripPrefix) {
+			invalid("strip prefix %q is not a prefix of the path", route.StripPrefix)
+		}
+		for _, method := range route.AllowedMethods {
+			if !routeMethods[strings.ToUpper(method)] {
+				invalid("unknown method %q", method)
+			}
+		}
+		if len(route.Roles) > 0 && !route.RequireAuth {
+			invalid("roles require auth")
+		}
+		for _, role := range route.Roles {
+			if strings.TrimSpace(role) == "" {
+				invalid("empty role")
+			}
+		}
+		if route.RateLimitClass != "" && rateLimitClasses != nil && !classes[route.RateLimitClass] {
+			invalid("unknown rate limit class %q", route.RateLimitClass)
+		}
+
+		if route.Service.Name == "" {
+			invalid("no upstream")
+		} else if target, err := url.Parse(route.Service.BaseURL); err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+			invalid("upstream %s has invalid URL %q", route.Service.Name, route.Service.BaseURL)
+		}
+
+		// First match wins, so a route covered by an earlier one is never reached
+		for j, earlier := range routes[:i] {
+			if matchRoutePath(earlier.PathPrefix, route.PathPrefix) && coversMethods(earlier.AllowedMethods, route.AllowedMethods) {
+				invalid("unreachable, shadowed by route %d (%s)", j, earlier.PathPrefix)
+				break
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// coversMethods reports whether every method of a route restricted to methods is also
+// allowed by outer (empty = all methods)
+func coversMethods(outer, methods []string) bool {
+	if len(outer) == 0 {
+		return true
+	}
+	if len(methods) == 0 {
+		return false
+	}
+	for _, method := range methods {
+		if !containsMethod(outer, method) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsMethod reports whether method is one of methods
+func containsMethod(methods []string, method string) bool {
+	for _, m := range methods {
+		if strings.EqualFold(m, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchRoutePath reports whether path falls under a route's path. A literal path
+// matches as a prefix; a pattern matches the leading segments of the path, its * and
+// :param segments matching any one segment.
+func matchRoutePath(pattern, path string) bool {
+	patternParts := strings.Split(strings.Trim(pattern, "/"), "/")
+	wildcard := false
+	for _, part := range patternParts {
+		if part == "*" || strings.HasPrefix(part, ":") {
+			wildcard = true
+			break
+		}
+	}
+	if !wildcard {
+		return strings.HasPrefix(path, pattern)
+	}
+
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(pathParts) < len(patternParts) {
+		return false
+	}
+	for i, part := range patternParts {
+		if part != "*" && !strings.HasPrefix(part, ":") && part != pathParts[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// RouteTableLoader loads a route table file into the proxy, at startup and whenever a
+// reload is requested, so routes change without a gateway release
+type RouteTableLoader struct {
+	path             string
+	proxy            *ReverseProxy
+	lookup           func(string) string
+	rateLimitClasses []string
+
+	// mu serializes reloads so two requests can't interleave their routes
+	mu      sync.Mutex
+	modTime time.Time
+}
+
+// NewRouteTableLoader creates a loader for the route table file at path. lookup expands
+// upstream URL variables and rateLimitClasses are the classes routes may select (see ValidateRoutes).
+func NewRouteTableLoader(path string, proxy *ReverseProxy, lookup func(string) string, rateLimitClasses []string) *RouteTableLoader {
+	return &RouteTableLoader{
+		path:             path,
+		proxy:            proxy,
+		lookup:           lookup,
+		rateLimitClasses: rateLimitClasses,
+	}
+}
+
+// Path returns the route table file the loader reads
+func (l *RouteTableLoader) Path() string {
+	return l.path
+}
+
+// Reload reads the route table file and swaps it into the proxy. The running routes are
+// kept if the file is invalid.
+func (l *RouteTableLoader) Reload() ([]RouteConfig, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	info, err := os.Stat(l.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read route table file: %w", err)
+	}
+	data, err := os.ReadFile(l.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read route table file: %w", err)
+	}
+	table, err := ParseRouteTable(data)
+	if err != nil {
+		return nil, err
+	}
+	routes, err := table.Build(l.lookup)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateRoutes(routes, l.rateLimitClasses); err != nil {
+		return nil, err
+	}
+
+	l.proxy.SetRoutes(routes)
+	l.modTime = info.ModTime()
+	return routes, nil
+}
+
+// Watch reloads the file every interval in which it was modified, until ctx is done.
+// onReload is called with the outcome of each reload.
+func (l *RouteTableLoader) Watch(ctx context.Context, interval time.Duration, onReload func([]RouteConfig, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !l.modified() {
+				continue
+			}
+			routes, err := l.Reload()
+			if err != nil {
+				// Don't retry the same bad file every tick
+				l.skipCurrent()
+			}
+			onReload(routes, err)
+		}
+	}
+}
+
+// modified reports whether the file changed since it was last loaded
+func (l *RouteTableLoader) modified() bool {
+	info, err := os.Stat(l.path)
+	if err != nil {
+		return false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return !info.ModTime().Equal(l.modTime)
+}
+
+// skipCurrent marks the file's current version as seen
+func (l *RouteTableLoader) skipCurrent() {
+	if info, err := os.Stat(l.path); err == nil {
+		l.mu.Lock()
+		l.modTime = info.ModTime()
+		l.mu.Unlock()
+	}
+}
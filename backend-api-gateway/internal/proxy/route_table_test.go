@@ -0,0 +1,314 @@
+package proxy
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestDefaultRouteTable_Valid(t *testing.T) {
+	routes, err := DefaultRouteTable().Build(ServiceURLLookup("http://auth:8081", "http://ticket:8082", "http://booking:8083", "http://payment:8084"))
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if err := ValidateRoutes(routes, []string{}); err != nil {
+		t.Errorf("ValidateRoutes() error = %v", err)
+	}
+}
+
+func TestDefaultRouteTable_AdminRoles(t *testing.T) {
+	routes, err := DefaultRouteTable().Build(ServiceURLLookup("http://auth:8081", "http://ticket:8082", "http://booking:8083", "http://payment:8084"))
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	firstMatch := func(method, path string) *RouteConfig {
+		for i, route := range routes {
+			if !matchRoutePath(route.PathPrefix, path) {
+				continue
+			}
+			if len(route.AllowedMethods) == 0 || strings.Contains(strings.Join(route.AllowedMethods, ","), method) {
+				return &routes[i]
+			}
+		}
+		return nil
+	}
+
+	tests := []struct {
+		method string
+		path   string
+		roles  string
+	}{
+		{"POST", "/api/v1/admin/compensations", "admin,super_admin"},
+		{"POST", "/api/v1/admin/sync-inventory", "admin,super_admin"},
+		{"GET", "/api/v1/admin/bookings/search", "admin,super_admin,support"},
+		{"PUT", "/api/v1/admin/zones/zone-1/seat-map", "admin,super_admin,organizer"},
+		{"GET", "/api/v1/admin/queue/analytics/event-1", "admin,super_admin,organizer"},
+		{"POST", "/api/v1/admin/queue/revoke-passes", "admin,super_admin,organizer"},
+	}
+	for _, tt := range tests {
+		route := firstMatch(tt.method, tt.path)
+		if route == nil || !route.RequireAuth || strings.Join(route.Roles, ",") != tt.roles {
+			t.Errorf("%s %s route = %+v, want auth with roles %s", tt.method, tt.path, route, tt.roles)
+		}
+	}
+}
+
+func TestParseRouteTable_UnknownField(t *testing.T) {
+	_, err := ParseRouteTable([]byte(`{"routes":[{"path":"/api/v1/x","upstream":"a","require_auth":true}]}`))
+	if !errors.Is(err, ErrInvalidRouteTable) {
+		t.Errorf("ParseRouteTable() error = %v, want ErrInvalidRouteTable for a misspelled field", err)
+	}
+}
+
+func TestRouteTable_Build(t *testing.T) {
+	table, err := ParseRouteTable([]byte(`{
+		"upstreams": {"reviews-service": {"url": "${REVIEWS_SERVICE_URL}", "timeout": "5s"}},
+		"routes": [
+			{"path": "/api/v1/reviews", "methods": ["GET"], "upstream": "reviews-service"},
+			{"path": "/api/v1/reviews", "auth": true, "roles": ["organizer"], "rate_limit_class": "booking", "upstream": "reviews-service", "timeout": "20s"}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("ParseRouteTable() error = %v", err)
+	}
+
+	routes, err := table.Build(func(name string) string {
+		if name == "REVIEWS_SERVICE_URL" {
+			return "http://reviews:8090"
+		}
+		return ""
+	})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if len(routes) != 2 {
+		t.Fatalf("Build() = %d routes, want 2", len(routes))
+	}
+	if routes[0].Service.BaseURL != "http://reviews:8090" || routes[0].Service.Timeout != 5*time.Second {
+		t.Errorf("Route 0 service = %+v, want the expanded URL and upstream timeout", routes[0].Service)
+	}
+	write := routes[1]
+	if !write.RequireAuth || len(write.Roles) != 1 || write.RateLimitClass != "booking" || write.Service.Timeout != 20*time.Second {
+		t.Errorf("Route 1 = %+v, want auth, organizer role, booking class and the route timeout", write)
+	}
+}
+
+func TestRouteTable_BuildErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		table string
+	}{
+		{"no routes", `{"upstreams": {"a": {"url": "http://a"}}}`},
+		{"unknown upstream", `{"upstreams": {"a": {"url": "http://a"}}, "routes": [{"path": "/x", "upstream": "b"}]}`},
+		{"bad upstream timeout", `{"upstreams": {"a": {"url": "http://a", "timeout": "soon"}}, "routes": [{"path": "/x", "upstream": "a"}]}`},
+		{"bad route timeout", `{"upstreams": {"a": {"url": "http://a"}}, "routes": [{"path": "/x", "upstream": "a", "timeout": "-1s"}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := ParseRouteTable([]byte(tt.table))
+			if err != nil {
+				t.Fatalf("ParseRouteTable() error = %v", err)
+			}
+			if _, err := table.Build(nil); !errors.Is(err, ErrInvalidRouteTable) {
+				t.Errorf("Build() error = %v, want ErrInvalidRouteTable", err)
+			}
+		})
+	}
+}
+
+func TestValidateRoutes(t *testing.T) {
+	service := ServiceConfig{Name: "booking-service", BaseURL: "http://booking:8083"}
+	classes := []string{"default", "booking"}
+
+	tests := []struct {
+		name    string
+		routes  []RouteConfig
+		wantErr string
+	}{
+		{
+			name: "valid",
+			routes: []RouteConfig{
+				{PathPrefix: "/api/v1/bookings", AllowedMethods: []string{"GET"}, Service: service},
+				{PathPrefix: "/api/v1/bookings", RequireAuth: true, Roles: []string{"admin"}, RateLimitClass: "booking", Service: service},
+			},
+		},
+		{
+			name:    "relative path",
+			routes:  []RouteConfig{{PathPrefix: "api/v1/bookings", Service: service}},
+			wantErr: "path must start with /",
+		},
+		{
+			name:    "unknown method",
+			routes:  []RouteConfig{{PathPrefix: "/api/v1/bookings", AllowedMethods: []string{"FETCH"}, Service: service}},
+			wantErr: `unknown method "FETCH"`,
+		},
+		{
+			name:    "roles without auth",
+			routes:  []RouteConfig{{PathPrefix: "/api/v1/bookings", Roles: []string{"admin"}, Service: service}},
+			wantErr: "roles require auth",
+		},
+		{
+			name:    "unknown rate limit class",
+			routes:  []RouteConfig{{PathPrefix: "/api/v1/bookings", RateLimitClass: "vip", Service: service}},
+			wantErr: `unknown rate limit class "vip"`,
+		},
+		{
+			name:    "invalid upstream URL",
+			routes:  []RouteConfig{{PathPrefix: "/api/v1/bookings", Service: ServiceConfig{Name: "booking-service", BaseURL: "booking:8083"}}},
+			wantErr: "invalid URL",
+		},
+		{
+			name:    "strip prefix outside path",
+			routes:  []RouteConfig{{PathPrefix: "/api/v1/bookings", StripPrefix: "/api/v2", Service: service}},
+			wantErr: "is not a prefix of the path",
+		},
+		{
+			name: "shadowed route",
+			routes: []RouteConfig{
+				{PathPrefix: "/api/v1/admin", RequireAuth: true, Service: service},
+				{PathPrefix: "/api/v1/admin/cache", RequireAuth: true, Service: service},
+			},
+			wantErr: "shadowed by route 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRoutes(tt.routes, classes)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateRoutes() error = %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidRouteTable) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateRoutes() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMatchRoutePath(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"/api/v1/bookings", "/api/v1/bookings", true},
+		{"/api/v1/bookings", "/api/v1/bookings/b-1/confirm", true},
+		{"/api/v1/bookings", "/api/v1/events", false},
+		{"/api/v1/events/:id/reviews", "/api/v1/events/e-1/reviews", true},
+		{"/api/v1/events/:id/reviews", "/api/v1/events/e-1/reviews/r-1", true},
+		{"/api/v1/events/*/reviews", "/api/v1/events/e-1/zones", false},
+		{"/api/v1/events/:id/reviews", "/api/v1/events/e-1", false},
+	}
+
+	for _, tt := range tests {
+		if got := matchRoutePath(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("matchRoutePath(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestRouteTableLoader_Reload(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer backend.Close()
+
+	rp := NewReverseProxy(ConfigFromEnv("", "", "", "", "test-secret"))
+	path := filepath.Join(t.TempDir(), "routes.json")
+	loader := NewRouteTableLoader(path, rp, func(string) string { return backend.URL }, []string{"default"})
+
+	writeTable := func(table string) {
+		if err := os.WriteFile(path, []byte(table), 0o644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+
+	writeTable(`{"upstreams": {"reviews-service": {"url": "${REVIEWS_SERVICE_URL}"}},
+		"routes": [{"path": "/api/v1/reviews", "auth": true, "rate_limit_class": "default", "upstream": "reviews-service"}]}`)
+	if _, err := loader.Reload(); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	route := rp.findRoute("/api/v1/reviews/r-1", "GET")
+	if route == nil || route.Service.Name != "reviews-service" || !route.RequireAuth {
+		t.Fatalf("findRoute() = %+v, want the reloaded reviews route", route)
+	}
+	if rp.findRoute("/api/v1/bookings", "GET") != nil {
+		t.Error("Expected the file to replace the default routes")
+	}
+	if class := rp.RateLimitClass("GET", "/api/v1/reviews"); class != "default" {
+		t.Errorf("RateLimitClass() = %q, want default", class)
+	}
+
+	// An invalid file keeps the running routes
+	writeTable(`{"upstreams": {"reviews-service": {"url": "${REVIEWS_SERVICE_URL}"}},
+		"routes": [{"path": "/api/v1/reviews", "rate_limit_class": "vip", "upstream": "reviews-service"}]}`)
+	if _, err := loader.Reload(); !errors.Is(err, ErrInvalidRouteTable) {
+		t.Errorf("Reload() error = %v, want ErrInvalidRouteTable", err)
+	}
+	if route := rp.findRoute("/api/v1/reviews", "GET"); route == nil || !route.RequireAuth {
+		t.Error("Expected the previous routes to stay in place after a failed reload")
+	}
+}
+
+func TestRouter_MatchHandler_Roles(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer backend.Close()
+
+	jwtSecret := "test-secret-key"
+	rp := NewReverseProxy(ProxyConfig{
+		Routes: []RouteConfig{
+			{
+				PathPrefix:  "/api/v1/admin",
+				RequireAuth: true,
+				Roles:       []string{"admin"},
+				Service: ServiceConfig{
+					Name:    "booking-service",
+					BaseURL: backend.URL,
+				},
+			},
+		},
+	})
+	handler := NewRouter(rp, jwtSecret).MatchHandler()
+
+	tests := []struct {
+		role       string
+		wantStatus int
+	}{
+		{"admin", http.StatusOK},
+		{"user", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"user_id": "user-123",
+			"role":    tt.role,
+			"exp":     time.Now().Add(time.Hour).Unix(),
+		})
+		tokenString, _ := token.SignedString([]byte(jwtSecret))
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/api/v1/admin/sync", nil)
+		c.Request.Header.Set("Authorization", "Bearer "+tokenString)
+
+		handler(c)
+
+		if w.Code != tt.wantStatus {
+			t.Errorf("role %s: expected status %d, got %d", tt.role, tt.wantStatus, w.Code)
+		}
+	}
+}
@@ -1,16 +1,19 @@
 package proxy
 
 import (
+	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	pkgmiddleware "github.com/prohmpiriya/booking-rush-10k-rps/pkg/middleware"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/response"
 )
 
 // Router manages API Gateway routing with JWT middleware
 type Router struct {
 	proxy     *ReverseProxy
 	jwtConfig *pkgmiddleware.JWTConfig
+	afterAuth []gin.HandlerFunc
 }
 
 // NewRouter creates a new router with proxy and JWT configuration
@@ -24,6 +27,17 @@ func NewRouter(proxy *ReverseProxy, jwtSecret string) *Router {
 	}
 }
 
+// SetTenantIsolation makes the router reject tokens not minted for their tenant
+func (r *Router) SetTenantIsolation(isolation *pkgmiddleware.TenantIsolation) {
+	r.jwtConfig.TenantIsolation = isolation
+}
+
+// UseAfterAuth adds handlers that MatchHandler runs after JWT verification on
+// authenticated routes. They are called inline, so they must abort instead of calling c.Next.
+func (r *Router) UseAfterAuth(handlers ...gin.HandlerFunc) {
+	r.afterAuth = append(r.afterAuth, handlers...)
+}
+
 // SetupRoutes configures all routes on the given router group
 func (r *Router) SetupRoutes(router *gin.Engine) {
 	// Create handlers for public and protected routes
@@ -31,7 +45,7 @@ func (r *Router) SetupRoutes(router *gin.Engine) {
 	protectedHandler := r.createProtectedHandler()
 
 	// Setup routes based on configuration
-	for _, route := range r.proxy.config.Routes {
+	for _, route := range r.proxy.Routes() {
 		if route.RequireAuth {
 			r.setupProtectedRoute(router, route, protectedHandler)
 		} else {
@@ -127,7 +141,7 @@ func (r *Router) setupProtectedRoute(router *gin.Engine, route RouteConfig, hand
 func (r *Router) SetupRoutesV2(engine *gin.Engine) {
 	// Group routes by path prefix to handle method-based auth correctly
 	routeGroups := make(map[string][]RouteConfig)
-	for _, route := range r.proxy.config.Routes {
+	for _, route := range r.proxy.Routes() {
 		routeGroups[route.PathPrefix] = append(routeGroups[route.PathPrefix], route)
 	}
 
@@ -237,9 +251,30 @@ func (r *Router) MatchHandler() gin.HandlerFunc {
 			if c.IsAborted() {
 				return
 			}
+			if len(route.Roles) > 0 && !hasRouteRole(c, route.Roles) {
+				c.AbortWithStatusJSON(http.StatusForbidden, response.Error("FORBIDDEN", "Insufficient permissions"))
+				return
+			}
+			for _, handler := range r.afterAuth {
+				handler(c)
+				if c.IsAborted() {
+					return
+				}
+			}
 		}
 
 		// Proxy the request
 		proxyHandler(c)
 	}
 }
+
+// hasRouteRole reports whether the authenticated user has one of roles
+func hasRouteRole(c *gin.Context, roles []string) bool {
+	role, _ := pkgmiddleware.GetRole(c)
+	for _, r := range roles {
+		if role == r {
+			return true
+		}
+	}
+	return false
+}
@@ -402,3 +402,61 @@ func TestRouteByMethod(t *testing.T) {
 		}
 	})
 }
+
+func TestRouter_MatchHandler_AfterAuthAborts(t *testing.T) {
+	backendCalled := false
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		backendCalled = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer backend.Close()
+
+	jwtSecret := "test-secret-key"
+
+	config := ProxyConfig{
+		Routes: []RouteConfig{
+			{
+				PathPrefix:  "/api/v1/bookings",
+				RequireAuth: true,
+				Service: ServiceConfig{
+					Name:    "booking-service",
+					BaseURL: backend.URL,
+				},
+			},
+		},
+	}
+
+	rp := NewReverseProxy(config)
+	router := NewRouter(rp, jwtSecret)
+	var seenUser interface{}
+	router.UseAfterAuth(func(c *gin.Context) {
+		seenUser, _ = c.Get("user_id")
+		c.AbortWithStatus(http.StatusTooManyRequests)
+	})
+	handler := router.MatchHandler()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": "user-123",
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	})
+	tokenString, _ := token.SignedString([]byte(jwtSecret))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	req := httptest.NewRequest("GET", "/api/v1/bookings", nil)
+	req.Header.Set("Authorization", "Bearer "+tokenString)
+	c.Request = req
+
+	handler(c)
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected status 429 from the after-auth handler, got %d", w.Code)
+	}
+	if seenUser != "user-123" {
+		t.Errorf("Expected the after-auth handler to see the verified user, got %v", seenUser)
+	}
+	if backendCalled {
+		t.Error("Expected the request not to be proxied")
+	}
+}
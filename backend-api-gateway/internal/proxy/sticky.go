@@ -0,0 +1,355 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	pkgmiddleware "github.com/prohmpiriya/booking-rush-10k-rps/pkg/middleware"
+)
+
+// StickyQueuePassHeader carries the queue pass of users released from the virtual queue
+const StickyQueuePassHeader = "X-Queue-Pass"
+
+// StickyConfig holds settings for consistent-hash routing of a user to one upstream instance.
+// Users released from the queue keep hitting the booking-service instance whose local caches
+// (zone availability, queue pass verification) are already warm for them.
+type StickyConfig struct {
+	// Enabled turns sticky routing on
+	Enabled bool
+	// Upstreams are the base URLs of the service instances (e.g., http://booking-1:8083)
+	Upstreams []string
+	// Services limits sticky routing to routes targeting these services
+	Services []string
+	// VirtualNodes is the number of ring points per upstream; more points spread keys more evenly
+	VirtualNodes int
+	// HealthInterval is the time between active /health checks of each upstream
+	HealthInterval time.Duration
+	// HealthTimeout bounds each health check
+	HealthTimeout time.Duration
+	// UnhealthyThreshold is the number of consecutive failed checks before an upstream is skipped
+	UnhealthyThreshold int
+}
+
+// DefaultStickyConfig returns sticky routing settings (disabled) for booking-service traffic
+func DefaultStickyConfig() StickyConfig {
+	return StickyConfig{
+		Enabled:            false,
+		Services:           []string{"booking-service"},
+		VirtualNodes:       100,
+		HealthInterval:     5 * time.Second,
+		HealthTimeout:      2 * time.Second,
+		UnhealthyThreshold: 2,
+	}
+}
+
+// StickyConfigFromEnv returns sticky routing settings with environment overrides
+func StickyConfigFromEnv() StickyConfig {
+	cfg := DefaultStickyConfig()
+	cfg.Enabled = getEnvBool("PROXY_STICKY_ENABLED", cfg.Enabled)
+	cfg.Upstreams = splitList(os.Getenv("PROXY_STICKY_UPSTREAMS"))
+	if value, ok := os.LookupEnv("PROXY_STICKY_SERVICES"); ok {
+		cfg.Services = splitList(value)
+	}
+	cfg.VirtualNodes = getEnvInt("PROXY_STICKY_VIRTUAL_NODES", cfg.VirtualNodes)
+	cfg.HealthInterval = getEnvDuration("PROXY_STICKY_HEALTH_INTERVAL", cfg.HealthInterval)
+	cfg.HealthTimeout = getEnvDuration("PROXY_STICKY_HEALTH_TIMEOUT", cfg.HealthTimeout)
+	cfg.UnhealthyThreshold = getEnvInt("PROXY_STICKY_UNHEALTHY_THRESHOLD", cfg.UnhealthyThreshold)
+	return cfg
+}
+
+// splitList splits a comma-separated list, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Validate checks the sticky routing settings
+func (c StickyConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if len(c.Upstreams) == 0 {
+		return errors.New("sticky routing needs at least one upstream")
+	}
+	seen := make(map[string]bool, len(c.Upstreams))
+	for _, upstream := range c.Upstreams {
+		target, err := url.Parse(upstream)
+		if err != nil || target.Scheme == "" || target.Host == "" {
+			return fmt.Errorf("invalid sticky upstream URL %q", upstream)
+		}
+		if seen[upstream] {
+			return fmt.Errorf("duplicate sticky upstream %q", upstream)
+		}
+		seen[upstream] = true
+	}
+	if len(c.Services) == 0 {
+		return errors.New("sticky routing needs at least one service")
+	}
+	if c.VirtualNodes <= 0 {
+		return errors.New("sticky virtual nodes must be positive")
+	}
+	if c.HealthInterval <= 0 || c.HealthTimeout <= 0 {
+		return errors.New("sticky health interval and timeout must be positive")
+	}
+	if c.UnhealthyThreshold <= 0 {
+		return errors.New("sticky unhealthy threshold must be positive")
+	}
+	return nil
+}
+
+// StickyStats is a snapshot of sticky routing activity
+type StickyStats struct {
+	// Hits counts keyed requests routed to the key's own upstream
+	Hits int64 `json:"hits"`
+	// Fallbacks counts keyed requests moved to another upstream because the key's own was unhealthy
+	Fallbacks int64 `json:"fallbacks"`
+	// Unkeyed counts requests without a user or queue pass, sent to the service's default URL
+	Unkeyed int64 `json:"unkeyed"`
+	// HitRate is Hits / (Hits + Fallbacks)
+	HitRate   float64                        `json:"hit_rate"`
+	Upstreams map[string]StickyUpstreamStats `json:"upstreams"`
+}
+
+// StickyUpstreamStats is the state of one sticky upstream
+type StickyUpstreamStats struct {
+	Healthy  bool  `json:"healthy"`
+	Requests int64 `json:"requests"`
+	// Failures is the current streak of failed health checks or connection errors
+	Failures int64 `json:"failures"`
+}
+
+// stickyUpstream is one service instance on the ring
+type stickyUpstream struct {
+	url      string
+	proxy    *httputil.ReverseProxy
+	healthy  atomic.Bool
+	failures atomic.Int64
+	requests atomic.Int64
+}
+
+// recordFailure counts a failed check or connection error, marking the upstream unhealthy at threshold
+func (u *stickyUpstream) recordFailure(threshold int) {
+	if u.failures.Add(1) >= int64(threshold) {
+		u.healthy.Store(false)
+	}
+}
+
+// recordSuccess marks the upstream healthy again
+func (u *stickyUpstream) recordSuccess() {
+	u.failures.Store(0)
+	u.healthy.Store(true)
+}
+
+// ringPoint maps a hash on the ring to an upstream
+type ringPoint struct {
+	hash     uint32
+	upstream int
+}
+
+// stickyBalancer routes each user to an upstream chosen by consistent hashing. Adding or
+// removing an instance only moves the users on its ring segments, and an unhealthy
+// instance's users move to the next healthy instance clockwise until it recovers.
+type stickyBalancer struct {
+	config    StickyConfig
+	services  map[string]bool
+	upstreams []*stickyUpstream
+	ring      []ringPoint
+	client    *http.Client
+
+	hits      atomic.Int64
+	fallbacks atomic.Int64
+	unkeyed   atomic.Int64
+}
+
+// newStickyBalancer creates a balancer from validated settings, proxying through rp's transport
+func newStickyBalancer(config StickyConfig, rp *ReverseProxy) (*stickyBalancer, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	services := make(map[string]bool, len(config.Services))
+	for _, name := range config.Services {
+		services[name] = true
+	}
+
+	b := &stickyBalancer{
+		config:   config,
+		services: services,
+		client: &http.Client{
+			Transport: rp.client.Transport,
+			Timeout:   config.HealthTimeout,
+		},
+	}
+
+	for i, raw := range config.Upstreams {
+		target, _ := url.Parse(raw)
+		upstream := &stickyUpstream{url: raw, proxy: rp.newUpstreamProxy(target)}
+		upstream.healthy.Store(true) // Until the first check says otherwise
+
+		// Connection errors count as failed checks, so a dead instance is skipped before the next check
+		errorHandler := upstream.proxy.ErrorHandler
+		upstream.proxy.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+			if isConnectionError(err) {
+				upstream.recordFailure(config.UnhealthyThreshold)
+			}
+			errorHandler(w, r, err)
+		}
+		b.upstreams = append(b.upstreams, upstream)
+
+		for v := 0; v < config.VirtualNodes; v++ {
+			b.ring = append(b.ring, ringPoint{hash: hashKey(raw + "#" + strconv.Itoa(v)), upstream: i})
+		}
+	}
+	sort.Slice(b.ring, func(i, j int) bool { return b.ring[i].hash < b.ring[j].hash })
+
+	return b, nil
+}
+
+// matches reports whether requests for a service are routed stickily
+func (b *stickyBalancer) matches(service string) bool {
+	return b.services[service]
+}
+
+// routingKey returns the authenticated user, or the queue pass for requests without one
+func routingKey(c *gin.Context) string {
+	if userID, ok := pkgmiddleware.GetUserID(c); ok && userID != "" {
+		return "user:" + userID
+	}
+	if pass := c.GetHeader(StickyQueuePassHeader); pass != "" {
+		return "pass:" + pass
+	}
+	return ""
+}
+
+// pick returns the upstream for the request's key, or nil if the request has no key
+func (b *stickyBalancer) pick(c *gin.Context) *stickyUpstream {
+	key := routingKey(c)
+	if key == "" {
+		b.unkeyed.Add(1)
+		return nil
+	}
+
+	upstream, hit := b.lookup(key)
+	if hit {
+		b.hits.Add(1)
+	} else {
+		b.fallbacks.Add(1)
+	}
+	upstream.requests.Add(1)
+	return upstream
+}
+
+// lookup walks the ring clockwise from key's hash to the first healthy upstream.
+// hit is false when the key's own upstream was skipped; with every upstream unhealthy
+// the key's own is returned so the client gets the upstream's error.
+func (b *stickyBalancer) lookup(key string) (upstream *stickyUpstream, hit bool) {
+	h := hashKey(key)
+	start := sort.Search(len(b.ring), func(i int) bool { return b.ring[i].hash >= h })
+	if start == len(b.ring) {
+		start = 0
+	}
+
+	owner := b.upstreams[b.ring[start].upstream]
+	if owner.healthy.Load() {
+		return owner, true
+	}
+	for i := 1; i < len(b.ring); i++ {
+		candidate := b.upstreams[b.ring[(start+i)%len(b.ring)].upstream]
+		if candidate.healthy.Load() {
+			return candidate, false
+		}
+	}
+	return owner, false
+}
+
+// run checks every upstream's /health each HealthInterval until ctx is cancelled
+func (b *stickyBalancer) run(ctx context.Context) {
+	ticker := time.NewTicker(b.config.HealthInterval)
+	defer ticker.Stop()
+
+	for {
+		for _, upstream := range b.upstreams {
+			go b.check(ctx, upstream)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check probes one upstream's /health endpoint
+func (b *stickyBalancer) check(ctx context.Context, upstream *stickyUpstream) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(upstream.url, "/")+"/health", nil)
+	if err != nil {
+		return
+	}
+	resp, err := b.client.Do(req)
+	if err != nil {
+		if ctx.Err() == nil {
+			upstream.recordFailure(b.config.UnhealthyThreshold)
+		}
+		return
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		upstream.recordSuccess()
+	} else {
+		upstream.recordFailure(b.config.UnhealthyThreshold)
+	}
+}
+
+// stats returns a snapshot of sticky routing activity
+func (b *stickyBalancer) stats() *StickyStats {
+	s := &StickyStats{
+		Hits:      b.hits.Load(),
+		Fallbacks: b.fallbacks.Load(),
+		Unkeyed:   b.unkeyed.Load(),
+		Upstreams: make(map[string]StickyUpstreamStats, len(b.upstreams)),
+	}
+	if keyed := s.Hits + s.Fallbacks; keyed > 0 {
+		s.HitRate = float64(s.Hits) / float64(keyed)
+	}
+	for _, upstream := range b.upstreams {
+		s.Upstreams[upstream.url] = StickyUpstreamStats{
+			Healthy:  upstream.healthy.Load(),
+			Requests: upstream.requests.Load(),
+			Failures: upstream.failures.Load(),
+		}
+	}
+	return s
+}
+
+// hashKey hashes a routing key or ring point with 32-bit FNV-1a. The murmur3 finalizer
+// spreads ring points whose names differ only in the last characters.
+func hashKey(key string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	x := h.Sum32()
+	x ^= x >> 16
+	x *= 0x85ebca6b
+	x ^= x >> 13
+	x *= 0xc2b2ae35
+	x ^= x >> 16
+	return x
+}
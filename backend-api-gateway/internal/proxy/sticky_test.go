@@ -0,0 +1,216 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	pkgmiddleware "github.com/prohmpiriya/booking-rush-10k-rps/pkg/middleware"
+)
+
+// newInstance starts a backend answering with its name, or 503 from /health while *down is set
+func newInstance(t *testing.T, name string, down *bool) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" && down != nil && *down {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(name))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// newStickyTestProxy returns a proxy routing booking-service stickily across upstreams
+func newStickyTestProxy(t *testing.T, defaultURL string, upstreams ...string) *ReverseProxy {
+	t.Helper()
+	stickyConfig := DefaultStickyConfig()
+	stickyConfig.Enabled = true
+	stickyConfig.Upstreams = upstreams
+
+	rp := NewReverseProxy(ProxyConfig{
+		DefaultTimeout: 5 * time.Second,
+		Sticky:         &stickyConfig,
+		Routes: []RouteConfig{
+			{
+				PathPrefix: "/api/v1/bookings",
+				Service:    ServiceConfig{Name: "booking-service", BaseURL: defaultURL},
+			},
+			{
+				PathPrefix: "/api/v1/events",
+				Service:    ServiceConfig{Name: "ticket-service", BaseURL: defaultURL},
+			},
+		},
+	})
+	if rp.sticky == nil {
+		t.Fatal("Expected sticky routing to be enabled")
+	}
+	return rp
+}
+
+// serveAs proxies a request for userID (empty = anonymous) and queue pass
+func serveAs(rp *ReverseProxy, path, userID, queuePass string) string {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", path, nil)
+	if userID != "" {
+		c.Set(pkgmiddleware.ContextKeyUserID, userID)
+	}
+	if queuePass != "" {
+		c.Request.Header.Set(StickyQueuePassHeader, queuePass)
+	}
+	rp.Handler()(c)
+	return w.Body.String()
+}
+
+func TestStickyConfig_Validate(t *testing.T) {
+	valid := DefaultStickyConfig()
+	valid.Enabled = true
+	valid.Upstreams = []string{"http://booking-1:8083", "http://booking-2:8083"}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if err := DefaultStickyConfig().Validate(); err != nil {
+		t.Errorf("disabled config should be valid, got %v", err)
+	}
+
+	tests := map[string]func(*StickyConfig){
+		"no upstreams":         func(c *StickyConfig) { c.Upstreams = nil },
+		"invalid upstream":     func(c *StickyConfig) { c.Upstreams = []string{"booking-1:8083"} },
+		"duplicate upstream":   func(c *StickyConfig) { c.Upstreams = []string{"http://a:1", "http://a:1"} },
+		"no services":          func(c *StickyConfig) { c.Services = nil },
+		"no virtual nodes":     func(c *StickyConfig) { c.VirtualNodes = 0 },
+		"no health interval":   func(c *StickyConfig) { c.HealthInterval = 0 },
+		"no failure threshold": func(c *StickyConfig) { c.UnhealthyThreshold = 0 },
+	}
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := valid
+			cfg.Upstreams = append([]string(nil), valid.Upstreams...)
+			mutate(&cfg)
+			if err := cfg.Validate(); err == nil {
+				t.Error("Expected validation error")
+			}
+		})
+	}
+}
+
+func TestSticky_SameUserSameInstance(t *testing.T) {
+	defaultSrv := newInstance(t, "default", nil)
+	a := newInstance(t, "a", nil)
+	b := newInstance(t, "b", nil)
+	c := newInstance(t, "c", nil)
+	rp := newStickyTestProxy(t, defaultSrv.URL, a.URL, b.URL, c.URL)
+
+	counts := make(map[string]int)
+	for i := 0; i < 300; i++ {
+		user := fmt.Sprintf("user-%d", i)
+		first := serveAs(rp, "/api/v1/bookings/reserve", user, "")
+		for j := 0; j < 3; j++ {
+			if got := serveAs(rp, "/api/v1/bookings/reserve", user, ""); got != first {
+				t.Fatalf("%s moved from %s to %s", user, first, got)
+			}
+		}
+		counts[first]++
+	}
+	// Consistent hashing with virtual nodes spreads users roughly evenly
+	for _, name := range []string{"a", "b", "c"} {
+		if counts[name] < 50 {
+			t.Errorf("instance %s got %d of 300 users, want a fair share (%v)", name, counts[name], counts)
+		}
+	}
+
+	// Queue passes key requests without a user; other services and anonymous requests are not sticky
+	pass := serveAs(rp, "/api/v1/bookings/reserve", "", "pass-token")
+	if pass == "default" || serveAs(rp, "/api/v1/bookings/reserve", "", "pass-token") != pass {
+		t.Errorf("queue pass request went to %s, want one sticky instance", pass)
+	}
+	if got := serveAs(rp, "/api/v1/bookings/reserve", "", ""); got != "default" {
+		t.Errorf("unkeyed request went to %s, want the default URL", got)
+	}
+	if got := serveAs(rp, "/api/v1/events/1", "user-1", ""); got != "default" {
+		t.Errorf("ticket-service request went to %s, want the default URL", got)
+	}
+
+	stats := rp.StickyStats()
+	if stats.Hits != 1202 || stats.Fallbacks != 0 || stats.Unkeyed != 1 || stats.HitRate != 1 {
+		t.Errorf("stats = %+v", stats)
+	}
+}
+
+func TestSticky_FallsBackWhileInstanceIsUnhealthy(t *testing.T) {
+	defaultSrv := newInstance(t, "default", nil)
+	aDown, bDown := false, false
+	a := newInstance(t, "a", &aDown)
+	b := newInstance(t, "b", &bDown)
+	rp := newStickyTestProxy(t, defaultSrv.URL, a.URL, b.URL)
+
+	// Find a user owned by a
+	user := ""
+	for i := 0; user == ""; i++ {
+		candidate := fmt.Sprintf("user-%d", i)
+		if serveAs(rp, "/api/v1/bookings/reserve", candidate, "") == "a" {
+			user = candidate
+		}
+	}
+
+	ctx := context.Background()
+	aDown = true
+	for i := 0; i < rp.sticky.config.UnhealthyThreshold; i++ {
+		rp.sticky.check(ctx, rp.sticky.upstreams[0])
+	}
+	if got := serveAs(rp, "/api/v1/bookings/reserve", user, ""); got != "b" {
+		t.Fatalf("request while a is unhealthy went to %s, want b", got)
+	}
+
+	// The user returns to a once it recovers
+	aDown = false
+	rp.sticky.check(ctx, rp.sticky.upstreams[0])
+	if got := serveAs(rp, "/api/v1/bookings/reserve", user, ""); got != "a" {
+		t.Errorf("request after recovery went to %s, want a", got)
+	}
+
+	stats := rp.StickyStats()
+	if stats.Fallbacks != 1 || stats.HitRate >= 1 {
+		t.Errorf("stats = %+v, want one fallback", stats)
+	}
+	if !stats.Upstreams[a.URL].Healthy || stats.Upstreams[a.URL].Failures != 0 {
+		t.Errorf("upstream a = %+v, want healthy", stats.Upstreams[a.URL])
+	}
+}
+
+func TestSticky_ConnectionErrorsMarkInstanceUnhealthy(t *testing.T) {
+	defaultSrv := newInstance(t, "default", nil)
+	a := newInstance(t, "a", nil)
+	b := newInstance(t, "b", nil)
+	rp := newStickyTestProxy(t, defaultSrv.URL, a.URL, b.URL)
+
+	user := ""
+	for i := 0; user == ""; i++ {
+		candidate := fmt.Sprintf("user-%d", i)
+		if serveAs(rp, "/api/v1/bookings/reserve", candidate, "") == "a" {
+			user = candidate
+		}
+	}
+
+	a.Close()
+	// A kept-alive connection may fail first with a read error, which is not counted
+	got := ""
+	for i := 0; i <= rp.sticky.config.UnhealthyThreshold+1 && got != "b"; i++ {
+		if got = serveAs(rp, "/api/v1/bookings/reserve", user, ""); got != "b" && !strings.Contains(got, "BAD_GATEWAY") {
+			t.Fatalf("request to a closed instance = %q, want BAD_GATEWAY", got)
+		}
+	}
+	if got != "b" {
+		t.Errorf("requests after connection errors still go to a: %q", got)
+	}
+	if rp.StickyStats().Upstreams[a.URL].Healthy {
+		t.Error("closed instance still marked healthy")
+	}
+}
@@ -0,0 +1,227 @@
+package proxy
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// TransportConfig holds tuning settings for the upstream HTTP transport
+type TransportConfig struct {
+	// MaxIdleConns limits idle (keep-alive) connections across all upstreams
+	MaxIdleConns int
+	// MaxIdleConnsPerHost limits idle connections kept per upstream host
+	MaxIdleConnsPerHost int
+	// MaxConnsPerHost limits total connections per upstream host (0 = unlimited)
+	MaxConnsPerHost int
+	// IdleConnTimeout closes idle connections after this duration
+	IdleConnTimeout time.Duration
+	// DialTimeout is the TCP connect timeout
+	DialTimeout time.Duration
+	// KeepAlive is the TCP keep-alive probe interval
+	KeepAlive time.Duration
+	// TLSHandshakeTimeout limits the TLS handshake for https upstreams
+	TLSHandshakeTimeout time.Duration
+	// ResponseHeaderTimeout limits waiting for upstream response headers (0 = no limit)
+	ResponseHeaderTimeout time.Duration
+	// ExpectContinueTimeout limits waiting for 100-continue
+	ExpectContinueTimeout time.Duration
+	// EnableHTTP2 attempts HTTP/2 over TLS for https upstreams
+	EnableHTTP2 bool
+	// UpstreamH2C uses HTTP/2 with prior knowledge (h2c) for http:// upstreams.
+	// Only enable when every upstream serves unencrypted HTTP/2.
+	UpstreamH2C bool
+}
+
+// DefaultTransportConfig returns transport settings tuned for 10k RPS
+// MaxIdleConns/MaxIdleConnsPerHost set to 15000 to handle 10K+ SSE connections at scale
+func DefaultTransportConfig() TransportConfig {
+	return TransportConfig{
+		MaxIdleConns:          15000,
+		MaxIdleConnsPerHost:   15000,
+		MaxConnsPerHost:       0,
+		IdleConnTimeout:       90 * time.Second,
+		DialTimeout:           5 * time.Second,
+		KeepAlive:             30 * time.Second,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ResponseHeaderTimeout: 0,
+		ExpectContinueTimeout: 1 * time.Second,
+		EnableHTTP2:           true,
+		UpstreamH2C:           false,
+	}
+}
+
+// TransportConfigFromEnv returns transport settings with environment overrides
+func TransportConfigFromEnv() TransportConfig {
+	cfg := DefaultTransportConfig()
+	cfg.MaxIdleConns = getEnvInt("PROXY_MAX_IDLE_CONNS", cfg.MaxIdleConns)
+	cfg.MaxIdleConnsPerHost = getEnvInt("PROXY_MAX_IDLE_CONNS_PER_HOST", cfg.MaxIdleConnsPerHost)
+	cfg.MaxConnsPerHost = getEnvInt("PROXY_MAX_CONNS_PER_HOST", cfg.MaxConnsPerHost)
+	cfg.IdleConnTimeout = getEnvDuration("PROXY_IDLE_CONN_TIMEOUT", cfg.IdleConnTimeout)
+	cfg.DialTimeout = getEnvDuration("PROXY_DIAL_TIMEOUT", cfg.DialTimeout)
+	cfg.KeepAlive = getEnvDuration("PROXY_KEEP_ALIVE", cfg.KeepAlive)
+	cfg.TLSHandshakeTimeout = getEnvDuration("PROXY_TLS_HANDSHAKE_TIMEOUT", cfg.TLSHandshakeTimeout)
+	cfg.ResponseHeaderTimeout = getEnvDuration("PROXY_RESPONSE_HEADER_TIMEOUT", cfg.ResponseHeaderTimeout)
+	cfg.EnableHTTP2 = getEnvBool("PROXY_HTTP2_ENABLED", cfg.EnableHTTP2)
+	cfg.UpstreamH2C = getEnvBool("PROXY_UPSTREAM_H2C", cfg.UpstreamH2C)
+	return cfg
+}
+
+// newTransport builds an http.Transport from the given settings
+func newTransport(cfg TransportConfig) *http.Transport {
+	transport := &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout:   cfg.DialTimeout,
+			KeepAlive: cfg.KeepAlive,
+		}).DialContext,
+		MaxIdleConns:          cfg.MaxIdleConns,
+		MaxIdleConnsPerHost:   cfg.MaxIdleConnsPerHost,
+		MaxConnsPerHost:       cfg.MaxConnsPerHost,
+		IdleConnTimeout:       cfg.IdleConnTimeout,
+		TLSHandshakeTimeout:   cfg.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: cfg.ResponseHeaderTimeout,
+		ExpectContinueTimeout: cfg.ExpectContinueTimeout,
+		DisableCompression:    false,
+		ForceAttemptHTTP2:     cfg.EnableHTTP2,
+	}
+
+	if cfg.UpstreamH2C {
+		// HTTP/1 must be disabled for the transport to use h2c on http:// URLs
+		protocols := new(http.Protocols)
+		protocols.SetHTTP2(true)
+		protocols.SetUnencryptedHTTP2(true)
+		transport.Protocols = protocols
+	}
+
+	return transport
+}
+
+// ConnStats is a snapshot of upstream connection usage
+type ConnStats struct {
+	Requests      int64 `json:"requests"`
+	NewConns      int64 `json:"new_conns"`
+	ReusedConns   int64 `json:"reused_conns"`
+	IdleReused    int64 `json:"idle_reused"`
+	HTTP2Requests int64 `json:"http2_requests"`
+	Errors        int64 `json:"errors"`
+	// ReuseRatio is ReusedConns / (NewConns + ReusedConns)
+	ReuseRatio float64 `json:"reuse_ratio"`
+}
+
+// connCounters holds live counters for a single upstream host
+type connCounters struct {
+	requests    atomic.Int64
+	newConns    atomic.Int64
+	reusedConns atomic.Int64
+	idleReused  atomic.Int64
+	http2       atomic.Int64
+	errors      atomic.Int64
+}
+
+func (c *connCounters) snapshot() ConnStats {
+	s := ConnStats{
+		Requests:      c.requests.Load(),
+		NewConns:      c.newConns.Load(),
+		ReusedConns:   c.reusedConns.Load(),
+		IdleReused:    c.idleReused.Load(),
+		HTTP2Requests: c.http2.Load(),
+		Errors:        c.errors.Load(),
+	}
+	if total := s.NewConns + s.ReusedConns; total > 0 {
+		s.ReuseRatio = float64(s.ReusedConns) / float64(total)
+	}
+	return s
+}
+
+// trackingTransport wraps a RoundTripper and records connection reuse per upstream host
+type trackingTransport struct {
+	base  http.RoundTripper
+	hosts sync.Map // host -> *connCounters
+}
+
+func newTrackingTransport(base http.RoundTripper) *trackingTransport {
+	return &trackingTransport{base: base}
+}
+
+func (t *trackingTransport) counters(host string) *connCounters {
+	if c, ok := t.hosts.Load(host); ok {
+		return c.(*connCounters)
+	}
+	c, _ := t.hosts.LoadOrStore(host, &connCounters{})
+	return c.(*connCounters)
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *trackingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	counters := t.counters(req.URL.Host)
+	counters.requests.Add(1)
+
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if info.Reused {
+				counters.reusedConns.Add(1)
+			} else {
+				counters.newConns.Add(1)
+			}
+			if info.WasIdle {
+				counters.idleReused.Add(1)
+			}
+		},
+	}
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		counters.errors.Add(1)
+		return nil, err
+	}
+	if resp.ProtoMajor == 2 {
+		counters.http2.Add(1)
+	}
+	return resp, nil
+}
+
+// Stats returns a snapshot of connection usage keyed by upstream host
+func (t *trackingTransport) Stats() map[string]ConnStats {
+	stats := make(map[string]ConnStats)
+	t.hosts.Range(func(key, value interface{}) bool {
+		stats[key.(string)] = value.(*connCounters).snapshot()
+		return true
+	})
+	return stats
+}
+
+// getEnvInt returns an integer environment variable or default
+func getEnvInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if n, err := strconv.Atoi(value); err == nil {
+			return n
+		}
+	}
+	return defaultValue
+}
+
+// getEnvDuration returns a duration environment variable or default
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
+
+// getEnvBool returns a boolean environment variable or default
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,129 @@
+package proxy
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestTransportConfigFromEnv(t *testing.T) {
+	t.Setenv("PROXY_MAX_IDLE_CONNS_PER_HOST", "512")
+	t.Setenv("PROXY_MAX_CONNS_PER_HOST", "1024")
+	t.Setenv("PROXY_IDLE_CONN_TIMEOUT", "2m")
+	t.Setenv("PROXY_UPSTREAM_H2C", "true")
+	t.Setenv("PROXY_DIAL_TIMEOUT", "not-a-duration")
+
+	cfg := TransportConfigFromEnv()
+
+	if cfg.MaxIdleConnsPerHost != 512 {
+		t.Errorf("Expected MaxIdleConnsPerHost 512, got %d", cfg.MaxIdleConnsPerHost)
+	}
+	if cfg.MaxConnsPerHost != 1024 {
+		t.Errorf("Expected MaxConnsPerHost 1024, got %d", cfg.MaxConnsPerHost)
+	}
+	if cfg.IdleConnTimeout != 2*time.Minute {
+		t.Errorf("Expected IdleConnTimeout 2m, got %v", cfg.IdleConnTimeout)
+	}
+	if !cfg.UpstreamH2C {
+		t.Error("Expected UpstreamH2C to be enabled")
+	}
+	// Invalid values fall back to defaults
+	if cfg.DialTimeout != DefaultTransportConfig().DialTimeout {
+		t.Errorf("Expected default DialTimeout, got %v", cfg.DialTimeout)
+	}
+	if cfg.MaxIdleConns != DefaultTransportConfig().MaxIdleConns {
+		t.Errorf("Expected default MaxIdleConns, got %d", cfg.MaxIdleConns)
+	}
+}
+
+func TestNewTransport_H2C(t *testing.T) {
+	cfg := DefaultTransportConfig()
+	if tr := newTransport(cfg); tr.Protocols != nil {
+		t.Error("Expected default transport to keep default protocols")
+	}
+
+	cfg.UpstreamH2C = true
+	tr := newTransport(cfg)
+	if tr.Protocols == nil || !tr.Protocols.UnencryptedHTTP2() || tr.Protocols.HTTP1() {
+		t.Error("Expected h2c transport to use unencrypted HTTP/2 only")
+	}
+}
+
+func TestTrackingTransport_ConnectionReuse(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer backend.Close()
+
+	tracker := newTrackingTransport(newTransport(DefaultTransportConfig()))
+	client := &http.Client{Transport: tracker}
+
+	for i := 0; i < 5; i++ {
+		resp, err := client.Get(backend.URL)
+		if err != nil {
+			t.Fatalf("Request %d failed: %v", i, err)
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+
+	host := mustHost(t, backend.URL)
+	stats, ok := tracker.Stats()[host]
+	if !ok {
+		t.Fatalf("Expected stats for host %s", host)
+	}
+	if stats.Requests != 5 {
+		t.Errorf("Expected 5 requests, got %d", stats.Requests)
+	}
+	if stats.NewConns != 1 {
+		t.Errorf("Expected 1 new connection, got %d", stats.NewConns)
+	}
+	if stats.ReusedConns != 4 {
+		t.Errorf("Expected 4 reused connections, got %d", stats.ReusedConns)
+	}
+	if stats.ReuseRatio != 0.8 {
+		t.Errorf("Expected reuse ratio 0.8, got %v", stats.ReuseRatio)
+	}
+}
+
+func TestTrackingTransport_Errors(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	backendURL := backend.URL
+	backend.Close()
+
+	tracker := newTrackingTransport(newTransport(DefaultTransportConfig()))
+	client := &http.Client{Transport: tracker}
+
+	if _, err := client.Get(backendURL); err == nil {
+		t.Fatal("Expected error for closed backend")
+	}
+
+	stats := tracker.Stats()[mustHost(t, backendURL)]
+	if stats.Errors != 1 {
+		t.Errorf("Expected 1 error, got %d", stats.Errors)
+	}
+}
+
+func TestReverseProxy_DefaultTransport(t *testing.T) {
+	rp := NewReverseProxy(DefaultConfig())
+
+	if rp.TransportConfig().MaxIdleConnsPerHost != DefaultTransportConfig().MaxIdleConnsPerHost {
+		t.Error("Expected default transport config when none is provided")
+	}
+	if rp.ConnStats() == nil {
+		t.Error("Expected non-nil connection stats")
+	}
+}
+
+func mustHost(t *testing.T, rawURL string) string {
+	t.Helper()
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatalf("Invalid URL %s: %v", rawURL, err)
+	}
+	return u.Host
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net/http"
@@ -15,12 +16,19 @@ import (
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-api-gateway/internal/middleware"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-api-gateway/internal/proxy"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/config"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/i18n"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/metering"
+	pkgmiddleware "github.com/prohmpiriya/booking-rush-10k-rps/pkg/middleware"
 	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/tenantconfig"
 )
 
 func main() {
+	smokeTest := flag.Bool("smoke", false, "check dependencies end to end and exit non-zero on failure")
+	flag.Parse()
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
@@ -29,7 +37,8 @@ func main() {
 
 	// Initialize logger with OTLP export support
 	logCfg := &logger.Config{
-		Level:        cfg.App.Environment,
+		Level:        cfg.Log.Level,
+		ModuleLevels: cfg.Log.ModuleLevels,
 		ServiceName:  "api-gateway",
 		Development:  cfg.IsDevelopment(),
 		OTLPEnabled:  cfg.OTel.Enabled && cfg.OTel.LogExportEnabled,
@@ -44,16 +53,25 @@ func main() {
 	log := logger.Get()
 	log.Info("Starting API Gateway...")
 
+	required := []config.Requirement{config.RequireRedis, config.RequireJWT}
+	if err := cfg.ValidateFor(required...); err != nil {
+		log.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
+	log.Info("Effective configuration: " + cfg.Summary(required...))
+
 	ctx := context.Background()
 
 	// Initialize OpenTelemetry
 	telemetryCfg := &telemetry.Config{
-		Enabled:        cfg.OTel.Enabled,
-		ServiceName:    "api-gateway",
-		ServiceVersion: cfg.App.Version,
-		Environment:    cfg.App.Environment,
-		CollectorAddr:  cfg.OTel.CollectorAddr,
-		SampleRatio:    cfg.OTel.SampleRatio,
+		Enabled:         cfg.OTel.Enabled,
+		ServiceName:     "api-gateway",
+		ServiceVersion:  cfg.App.Version,
+		Environment:     cfg.App.Environment,
+		CollectorAddr:   cfg.OTel.CollectorAddr,
+		SampleRatio:     cfg.OTel.SampleRatio,
+		DynamicSampling: cfg.OTel.DynamicSampling,
+		SlowThreshold:   cfg.OTel.SlowThreshold,
+		SamplingRoutes:  cfg.OTel.SamplingRoutes,
 	}
 	if _, err := telemetry.Init(ctx, telemetryCfg); err != nil {
 		log.Warn(fmt.Sprintf("Failed to initialize telemetry: %v", err))
@@ -69,15 +87,16 @@ func main() {
 	// Initialize Redis connection (for rate limiting and /ready check)
 	var redis *pkgredis.Client
 	redisCfg := &pkgredis.Config{
-		Host:          cfg.Redis.Host,
-		Port:          cfg.Redis.Port,
-		Password:      cfg.Redis.Password,
-		DB:            cfg.Redis.DB,
-		PoolSize:      cfg.Redis.PoolSize,
-		MaxRetries:    3,
-		RetryInterval: 2 * time.Second,
-		EnableTracing: cfg.OTel.Enabled,
-		ServiceName:   "api-gateway",
+		Host:                 cfg.Redis.Host,
+		Port:                 cfg.Redis.Port,
+		Password:             cfg.Redis.Password,
+		DB:                   cfg.Redis.DB,
+		PoolSize:             cfg.Redis.PoolSize,
+		MaxRetries:           3,
+		RetryInterval:        2 * time.Second,
+		EnableTracing:        cfg.OTel.Enabled,
+		SlowCommandThreshold: cfg.Redis.SlowCommandThreshold,
+		ServiceName:          "api-gateway",
 	}
 	redis, err = pkgredis.NewClient(ctx, redisCfg)
 	if err != nil {
@@ -85,6 +104,9 @@ func main() {
 	} else {
 		defer redis.Close()
 		log.Info("Redis connected")
+
+		// Per-module log levels shared by every instance, changeable without a restart
+		go redis.WatchLogLevels(ctx, cfg.Log.RedisKey+":api-gateway", cfg.Log.PollInterval)
 	}
 
 	// Setup Gin
@@ -107,9 +129,111 @@ func main() {
 	router.Use(middleware.Logger(log))
 	router.Use(middleware.CORS())
 
+	// Negotiate the client's language before any middleware can answer with an error;
+	// the locale is forwarded to backend services in X-Locale
+	router.Use(i18n.Middleware())
+
+	// Maintenance mode: reject traffic with 503 except allowlisted paths, IPs and roles
+	var maintenanceStore middleware.MaintenanceStore
+	if redis != nil {
+		maintenanceStore = middleware.NewRedisMaintenanceStore(redis, middleware.DefaultMaintenanceKey)
+	} else {
+		maintenanceStore = middleware.NewMemoryMaintenanceStore()
+		log.Warn("Maintenance mode state is local to this instance (Redis unavailable)")
+	}
+	maintenanceGuard := middleware.NewMaintenanceGuard(maintenanceStore, middleware.DefaultMaintenanceConfig(cfg.JWT.Secret))
+	defer maintenanceGuard.Stop()
+	if maintenanceGuard.Active() {
+		log.Warn("Gateway starting in MAINTENANCE MODE")
+	}
+	router.Use(middleware.Maintenance(maintenanceGuard))
+
+	// Configure reverse proxy and usage metering from the environment
+	env := config.NewEnv()
+	authServiceURL := env.String("AUTH_SERVICE_URL", "http://localhost:8081")
+	ticketServiceURL := env.String("TICKET_SERVICE_URL", "http://localhost:8082")
+	bookingServiceURL := env.String("BOOKING_SERVICE_URL", "http://localhost:8083")
+	paymentServiceURL := env.String("PAYMENT_SERVICE_URL", "http://localhost:8084")
+	jwtOffload := env.Bool("GATEWAY_JWT_OFFLOAD", true)
+	usageEnabled := env.Bool("USAGE_METERING_ENABLED", true)
+	quotaEnforce := env.Bool("USAGE_QUOTA_ENFORCE", false)
+	usagePlans := env.String("USAGE_PLANS", "")
+	usageDefaultPlan := env.String("USAGE_DEFAULT_PLAN", "free")
+	routesFile := env.String("GATEWAY_ROUTES_FILE", "")
+	routesWatchInterval := env.Duration("GATEWAY_ROUTES_WATCH_INTERVAL", 0)
+	rateLimitClassSpec := env.String("RATE_LIMIT_CLASSES", "")
+	if err := env.Err(); err != nil {
+		log.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
+
+	// Deploys gate on the self-check of every dependency before taking traffic
+	if *smokeTest {
+		suite := newSmokeSuite(redis, map[string]string{
+			"auth":    authServiceURL,
+			"ticket":  ticketServiceURL,
+			"booking": bookingServiceURL,
+			"payment": paymentServiceURL,
+		})
+		if err := suite.Run(ctx, os.Stdout); err != nil {
+			log.Fatal(fmt.Sprintf("Smoke test failed: %v", err))
+		}
+		return
+	}
+
+	// Configure reverse proxy for backend services
+	proxyConfig := proxy.ConfigFromEnv(
+		authServiceURL,
+		ticketServiceURL,
+		bookingServiceURL,
+		paymentServiceURL,
+		cfg.JWT.Secret,
+	)
+	// Verify JWTs once here and forward signed identity headers instead of the token
+	if jwtOffload {
+		proxyConfig.InternalAuthSecret = cfg.InternalAuth.Secret
+		log.Info("JWT offload enabled: forwarding signed identity headers to backend services")
+	}
+	transportConfig := proxy.TransportConfigFromEnv()
+	proxyConfig.Transport = &transportConfig
+	mirrorConfig := proxy.MirrorConfigFromEnv()
+	if err := mirrorConfig.Validate(); err != nil {
+		log.Warn(fmt.Sprintf("Traffic mirroring disabled: %v", err))
+	} else if mirrorConfig.Enabled {
+		proxyConfig.Mirror = &mirrorConfig
+		log.Info(fmt.Sprintf("Traffic mirroring: %.2f%% of %v to %s (maxConcurrent=%d)",
+			mirrorConfig.Percent, mirrorConfig.Services, mirrorConfig.TargetURL, mirrorConfig.MaxConcurrent))
+	}
+
+	stickyConfig := proxy.StickyConfigFromEnv()
+	if err := stickyConfig.Validate(); err != nil {
+		log.Warn(fmt.Sprintf("Sticky routing disabled: %v", err))
+	} else if stickyConfig.Enabled {
+		proxyConfig.Sticky = &stickyConfig
+		log.Info(fmt.Sprintf("Sticky routing: %v across %d instances (%v)",
+			stickyConfig.Services, len(stickyConfig.Upstreams), stickyConfig.Upstreams))
+	}
+
+	reverseProxy := proxy.NewReverseProxy(proxyConfig)
+	go reverseProxy.RunStickyHealthChecks(ctx)
+
 	// Configure per-endpoint rate limiting (can be disabled via ENV for load testing)
+	var shadowRecorder *middleware.ShadowRecorder
+	var rateLimitOverrides *middleware.RateLimitOverrides
+	var rateLimitClasses []string
 	if os.Getenv("RATE_LIMIT_ENABLED") != "false" {
 		rateLimitConfig := middleware.DefaultPerEndpointConfig()
+
+		// Routes that name a rate-limit class get the class's limit instead of the endpoint rules
+		classes, err := middleware.ParseRateLimitClasses(rateLimitClassSpec)
+		if err != nil {
+			log.Fatal(fmt.Sprintf("Invalid configuration: RATE_LIMIT_CLASSES: %v", err))
+		}
+		for name, class := range classes {
+			rateLimitConfig.Classes[name] = class
+		}
+		rateLimitConfig.ClassOf = reverseProxy.RateLimitClass
+		rateLimitClasses = rateLimitConfig.ClassNames()
+
 		if redis != nil {
 			rateLimitConfig.UseRedis = true
 			rateLimitConfig.RedisClient = redis
@@ -117,16 +241,128 @@ func main() {
 		} else {
 			log.Info("Rate limiting enabled (local, non-distributed)")
 		}
+
+		// Per-user and per-API-key overrides for partner and VIP accounts
+		var overrideStore middleware.RateLimitOverrideStore
+		if redis != nil {
+			overrideStore = middleware.NewRedisRateLimitOverrideStore(redis, middleware.DefaultRateLimitOverrideKey)
+		} else {
+			overrideStore = middleware.NewMemoryRateLimitOverrideStore()
+		}
+		rateLimitOverrides = middleware.NewRateLimitOverrides(overrideStore, middleware.DefaultRateLimitOverrideConfig(cfg.JWT.Secret))
+		defer rateLimitOverrides.Stop()
+		rateLimitConfig.Overrides = rateLimitOverrides
+
+		// Shadow mode: evaluate limits and record would-block counts without rejecting
+		if rateLimitConfig.ShadowMode {
+			var shadowStore middleware.ShadowStore
+			if redis != nil {
+				shadowStore = middleware.NewRedisShadowStore(redis, rateLimitConfig.KeyPrefix, middleware.DefaultShadowRetention)
+			} else {
+				shadowStore = middleware.NewMemoryShadowStore(middleware.DefaultShadowRetention)
+			}
+			shadowRecorder = middleware.NewShadowRecorder(shadowStore, middleware.DefaultShadowFlushInterval, middleware.DefaultShadowRetention)
+			defer shadowRecorder.Stop()
+			rateLimitConfig.ShadowRecorder = shadowRecorder
+			log.Warn("Rate limiter running in SHADOW MODE (RATE_LIMIT_SHADOW_MODE=true), requests are never rejected")
+		}
+
 		router.Use(middleware.PerEndpointRateLimiter(rateLimitConfig))
 	} else {
 		log.Warn("Rate limiting DISABLED (RATE_LIMIT_ENABLED=false)")
 	}
 
+	// Optional route table file replacing the compiled-in routes (default_routes.json). It is
+	// re-read on SIGHUP, when it changes (GATEWAY_ROUTES_WATCH_INTERVAL) and on
+	// POST /api/v1/gateway/routes/reload, so new service routes don't need a gateway release.
+	var routeLoader *proxy.RouteTableLoader
+	if routesFile != "" {
+		lookup := proxy.ServiceURLLookup(authServiceURL, ticketServiceURL, bookingServiceURL, paymentServiceURL)
+		routeLoader = proxy.NewRouteTableLoader(routesFile, reverseProxy, lookup, rateLimitClasses)
+		routes, err := routeLoader.Reload()
+		if err != nil {
+			log.Fatal(fmt.Sprintf("Failed to load route table: %v", err))
+		}
+		log.Info(fmt.Sprintf("Route table loaded from %s (%d routes)", routesFile, len(routes)))
+
+		onReload := func(routes []proxy.RouteConfig, err error) {
+			// A bad file keeps the routes that are running
+			if err != nil {
+				log.Error(fmt.Sprintf("Failed to reload route table: %v", err))
+				return
+			}
+			log.Info(fmt.Sprintf("Route table reloaded from %s (%d routes)", routesFile, len(routes)))
+		}
+		reload := make(chan os.Signal, 1)
+		signal.Notify(reload, syscall.SIGHUP)
+		go func() {
+			for range reload {
+				onReload(routeLoader.Reload())
+			}
+		}()
+		if routesWatchInterval > 0 {
+			go routeLoader.Watch(ctx, routesWatchInterval, onReload)
+		}
+	}
+
+	// Usage metering: count API calls per tenant into daily aggregates shared with the usage worker
+	var (
+		usageRecorder *metering.Recorder
+		usageStore    metering.Store
+		usageQuota    *metering.Quota
+	)
+	if usageEnabled {
+		if redis != nil {
+			usageStore = metering.NewRedisStore(redis, metering.DefaultRetention)
+		} else {
+			usageStore = metering.NewMemoryStore(metering.DefaultRetention)
+			log.Warn("Usage metering is local to this instance (Redis unavailable)")
+		}
+		usageRecorder = metering.NewRecorder(usageStore, metering.DefaultFlushInterval)
+		defer usageRecorder.Stop()
+		router.Use(middleware.UsageMetering(usageRecorder))
+
+		plans, err := metering.ParsePlans(usagePlans)
+		if err != nil {
+			log.Fatal(fmt.Sprintf("Invalid configuration: USAGE_PLANS: %v", err))
+		}
+		tenantSettings := &tenantconfig.ClientConfig{
+			BaseURL:    authServiceURL,
+			HTTPClient: &http.Client{Timeout: time.Second},
+		}
+		if redis != nil {
+			tenantSettings.Redis = redis
+		}
+		settingsClient := tenantconfig.NewClient(tenantSettings)
+		usageQuota = metering.NewQuota(metering.QuotaConfig{
+			Store:       usageStore,
+			Recorder:    usageRecorder,
+			Plans:       plans,
+			DefaultPlan: usageDefaultPlan,
+			Lookup: metering.PlanLookupFunc(func(ctx context.Context, tenantID string) (string, error) {
+				settings, err := settingsClient.Get(ctx, tenantID)
+				if err != nil {
+					return "", err
+				}
+				return settings.Plan, nil
+			}),
+		})
+		log.Info(fmt.Sprintf("Usage metering enabled (plans=%d, default=%s, enforce=%v)", len(plans), usageDefaultPlan, quotaEnforce))
+	} else {
+		log.Warn("Usage metering DISABLED (USAGE_METERING_ENABLED=false)")
+	}
+
 	// Health check handlers (no database - microservice pattern)
 	healthHandler := handler.NewHealthHandler(nil, redis)
 	router.GET("/health", healthHandler.Health)
 	router.GET("/ready", healthHandler.Ready)
 
+	// Tokens must be minted for the tenant they claim (iss/aud derived from JWT_ISSUER)
+	tenantIsolation := &pkgmiddleware.TenantIsolation{
+		Issuer:        cfg.JWT.Issuer,
+		RequireClaims: cfg.JWT.RequireTenantClaims,
+	}
+
 	// API version prefix
 	v1 := router.Group("/api/v1")
 	{
@@ -138,30 +374,112 @@ func main() {
 				"service": "api-gateway",
 			})
 		})
-	}
 
-	// Configure reverse proxy for backend services
-	authServiceURL := getEnv("AUTH_SERVICE_URL", "http://localhost:8081")
-	ticketServiceURL := getEnv("TICKET_SERVICE_URL", "http://localhost:8082")
-	bookingServiceURL := getEnv("BOOKING_SERVICE_URL", "http://localhost:8083")
-	paymentServiceURL := getEnv("PAYMENT_SERVICE_URL", "http://localhost:8084")
+		// Rate limiter shadow mode report (admin only)
+		shadowHandler := handler.NewRateLimitShadowHandler(shadowRecorder)
+		v1.GET("/gateway/rate-limit/shadow",
+			pkgmiddleware.JWTMiddleware(&pkgmiddleware.JWTConfig{Secret: cfg.JWT.Secret, TenantIsolation: tenantIsolation}),
+			pkgmiddleware.RequireRole("admin"),
+			shadowHandler.Report,
+		)
 
-	proxyConfig := proxy.ConfigFromEnv(
-		authServiceURL,
-		ticketServiceURL,
-		bookingServiceURL,
-		paymentServiceURL,
-		cfg.JWT.Secret,
-	)
+		// Per-user and per-API-key rate limit overrides (admin only)
+		overrideHandler := handler.NewRateLimitOverrideHandler(rateLimitOverrides)
+		overrides := v1.Group("/gateway/rate-limit/overrides",
+			pkgmiddleware.JWTMiddleware(&pkgmiddleware.JWTConfig{Secret: cfg.JWT.Secret, TenantIsolation: tenantIsolation}),
+			pkgmiddleware.RequireRole("admin"),
+		)
+		{
+			overrides.GET("", overrideHandler.List)
+			overrides.PUT("", overrideHandler.Put)
+			overrides.DELETE("/:subject", overrideHandler.Delete)
+		}
+
+		// Maintenance mode switch (admin only)
+		maintenanceHandler := handler.NewMaintenanceHandler(maintenanceGuard)
+		maintenance := v1.Group("/gateway/maintenance",
+			pkgmiddleware.JWTMiddleware(&pkgmiddleware.JWTConfig{Secret: cfg.JWT.Secret, TenantIsolation: tenantIsolation}),
+			pkgmiddleware.RequireRole("admin"),
+		)
+		{
+			maintenance.GET("", maintenanceHandler.Get)
+			maintenance.PUT("", maintenanceHandler.Update)
+			maintenance.DELETE("", maintenanceHandler.Disable)
+		}
+
+		// Dynamic trace sampling policy of this instance (admin only)
+		sampling := v1.Group("/gateway/telemetry/sampling",
+			pkgmiddleware.JWTMiddleware(&pkgmiddleware.JWTConfig{Secret: cfg.JWT.Secret, TenantIsolation: tenantIsolation}),
+			pkgmiddleware.RequireRole("admin"),
+		)
+		{
+			sampling.GET("", telemetry.GetSamplingPolicyHandler())
+			sampling.PUT("", telemetry.UpdateSamplingPolicyHandler())
+		}
+
+		// Per-module log levels of this instance (admin only)
+		logLevels := v1.Group("/gateway/logging/levels",
+			pkgmiddleware.JWTMiddleware(&pkgmiddleware.JWTConfig{Secret: cfg.JWT.Secret, TenantIsolation: tenantIsolation}),
+			pkgmiddleware.RequireRole("admin"),
+		)
+		{
+			logLevels.GET("", logger.GetModuleLevelsHandler())
+			logLevels.PUT("", logger.UpdateModuleLevelsHandler())
+		}
+
+		// Proxy route table listing and reload (admin only)
+		routeTableHandler := handler.NewRouteTableHandler(reverseProxy, routeLoader)
+		routeTable := v1.Group("/gateway/routes",
+			pkgmiddleware.JWTMiddleware(&pkgmiddleware.JWTConfig{Secret: cfg.JWT.Secret, TenantIsolation: tenantIsolation}),
+			pkgmiddleware.RequireRole("admin"),
+		)
+		{
+			routeTable.GET("", routeTableHandler.List)
+			routeTable.POST("/reload", routeTableHandler.Reload)
+		}
+
+		// Per-tenant usage aggregates (admin only)
+		usageHandler := handler.NewTenantUsageHandler(usageRecorder, usageStore, usageQuota)
+		v1.GET("/admin/tenants/:id/usage",
+			pkgmiddleware.JWTMiddleware(&pkgmiddleware.JWTConfig{Secret: cfg.JWT.Secret, TenantIsolation: &pkgmiddleware.TenantIsolation{
+				Issuer:        cfg.JWT.Issuer,
+				RequireClaims: cfg.JWT.RequireTenantClaims,
+				Target:        pkgmiddleware.TenantFromParam("id"),
+			}}),
+			pkgmiddleware.RequireRole("admin"),
+			usageHandler.Usage,
+		)
+	}
 
-	reverseProxy := proxy.NewReverseProxy(proxyConfig)
 	proxyRouter := proxy.NewRouter(reverseProxy, cfg.JWT.Secret)
+	proxyRouter.SetTenantIsolation(tenantIsolation)
+	if usageQuota != nil && quotaEnforce {
+		proxyRouter.UseAfterAuth(middleware.QuotaEnforcer(usageQuota))
+		log.Info("Plan quotas enforced (USAGE_QUOTA_ENFORCE=true)")
+	}
 
 	// Use catch-all handler for proxied routes
 	router.NoRoute(proxyRouter.MatchHandler())
 
+	// gRPC-web and Connect (JSON codec) for mobile clients, served by the REST read routes
+	grpcWeb := proxy.NewGRPCWebTranslator(proxy.DefaultGRPCWebMethods(), proxyRouter.MatchHandler())
+	router.POST("/"+proxy.GRPCWebService+"/:method", grpcWeb.Handler())
+
 	log.Info(fmt.Sprintf("Proxy configured: auth=%s, ticket=%s, booking=%s, payment=%s",
 		authServiceURL, ticketServiceURL, bookingServiceURL, paymentServiceURL))
+	log.Info(fmt.Sprintf("Proxy transport: maxIdleConnsPerHost=%d, maxConnsPerHost=%d, idleTimeout=%v, http2=%v, h2c=%v",
+		transportConfig.MaxIdleConnsPerHost, transportConfig.MaxConnsPerHost, transportConfig.IdleConnTimeout,
+		transportConfig.EnableHTTP2, transportConfig.UpstreamH2C))
+
+	// Upstream connection pool metrics (reuse ratio per backend host)
+	router.GET("/metrics/proxy", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"transport": reverseProxy.TransportConfig(),
+			"upstreams": reverseProxy.ConnStats(),
+			"mirror":    reverseProxy.MirrorStats(),
+			"sticky":    reverseProxy.StickyStats(),
+		})
+	})
 
 	// Create HTTP server
 	addr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
@@ -197,11 +515,3 @@ func main() {
 
 	log.Info("Server exited gracefully")
 }
-
-// getEnv returns environment variable value or default
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
-}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-api-gateway/internal/middleware"
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/smoke"
+)
+
+// newSmokeSuite builds the self-check run by -smoke. The gateway has no database or
+// topics of its own; it needs Redis for rate limiting and every upstream service
+// answering its /health. upstreams maps service names to base URLs.
+func newSmokeSuite(redis *pkgredis.Client, upstreams map[string]string) *smoke.Suite {
+	suite := smoke.NewSuite("api-gateway", 0)
+
+	if redis == nil {
+		suite.Add("redis", func(ctx context.Context) error {
+			return errors.New("not connected, rate limits would only be enforced per instance")
+		})
+	} else {
+		suite.Add("redis", smoke.Redis(redis))
+		limiter := middleware.NewRedisRateLimiter(middleware.RateLimitConfig{RedisClient: redis})
+		suite.Add("redis rate limit script", limiter.LoadScript)
+	}
+
+	for _, name := range []string{"auth", "ticket", "booking", "payment"} {
+		suite.Add(name+" service", smoke.HTTP(strings.TrimRight(upstreams[name], "/")+"/health"))
+	}
+	return suite
+}
@@ -13,18 +13,41 @@ type Container struct {
 	DB *database.PostgresDB
 
 	// Repositories
-	UserRepo    repository.UserRepository
-	SessionRepo repository.SessionRepository
-	TenantRepo  repository.TenantRepository
+	UserRepo           repository.UserRepository
+	SessionRepo        repository.SessionRepository
+	TenantRepo         repository.TenantRepository
+	TenantSettingsRepo repository.TenantSettingsRepository
+	ExportRepo         repository.DataExportRepository
+	ChallengeRepo      repository.VerificationChallengeRepository
+	DeviceRepo         repository.DeviceRepository
+	RevocationRepo     repository.TokenRevocationRepository
+	PurchaseRepo       repository.PurchaseRepository
+	OAuthProviderRepo  repository.OAuthProviderRepository
+	IdentityRepo       repository.UserIdentityRepository
 
 	// Services
-	AuthService   service.AuthService
-	TenantService service.TenantService
+	AuthService            service.AuthService
+	TokenValidationService service.TokenValidationService
+	TenantService          service.TenantService
+	TenantSettingsService  service.TenantSettingsService
+	PrivacyService         service.PrivacyService
+	VerificationService    service.VerificationService
+	DeviceService          service.DeviceService
+	PurchaseService        service.PurchaseService
+	OAuthService           service.OAuthService
+	LoadTestService        service.LoadTestService // nil unless load-test mode is enabled
 
 	// Handlers
-	HealthHandler *handler.HealthHandler
-	AuthHandler   *handler.AuthHandler
-	TenantHandler *handler.TenantHandler
+	HealthHandler         *handler.HealthHandler
+	AuthHandler           *handler.AuthHandler
+	TenantHandler         *handler.TenantHandler
+	TenantSettingsHandler *handler.TenantSettingsHandler
+	PrivacyHandler        *handler.PrivacyHandler
+	VerificationHandler   *handler.VerificationHandler
+	DeviceHandler         *handler.DeviceHandler
+	PurchaseHandler       *handler.PurchaseHandler
+	OAuthHandler          *handler.OAuthHandler
+	LoadTestHandler       *handler.LoadTestHandler
 }
 
 // ContainerConfig contains configuration for building the container
@@ -33,7 +56,34 @@ type ContainerConfig struct {
 	UserRepo      repository.UserRepository
 	SessionRepo   repository.SessionRepository
 	TenantRepo    repository.TenantRepository
+	ExportRepo    repository.DataExportRepository
 	ServiceConfig *service.AuthServiceConfig
+	// TenantSettingsRepo and TenantSettingsPublisher back the tenant settings endpoints
+	TenantSettingsRepo      repository.TenantSettingsRepository
+	TenantSettingsPublisher service.TenantSettingsPublisher
+	// SenderDomainConfig verifies tenants' email sender domains (nil = defaults)
+	SenderDomainConfig *service.SenderDomainConfig
+	// UserDataClients are the services holding user data for GDPR export/deletion
+	UserDataClients []service.UserDataClient
+	PrivacyConfig   *service.PrivacyServiceConfig
+	// ChallengeRepo and VerificationCodeSender back the account verification endpoints
+	ChallengeRepo          repository.VerificationChallengeRepository
+	VerificationCodeSender service.VerificationCodeSender
+	VerificationConfig     *service.VerificationServiceConfig
+	// DeviceRepo backs push device registration
+	DeviceRepo repository.DeviceRepository
+	// RevocationRepo records logout-all so access tokens are rejected before they expire
+	RevocationRepo        repository.TokenRevocationRepository
+	TokenValidationConfig *service.TokenValidationConfig
+	// PurchaseRepo backs the purchase summary, projected from booking and payment events
+	PurchaseRepo   repository.PurchaseRepository
+	PurchaseConfig *service.PurchaseServiceConfig
+	// OAuthProviderRepo and IdentityRepo back social login with tenants' or the platform's clients
+	OAuthProviderRepo repository.OAuthProviderRepository
+	IdentityRepo      repository.UserIdentityRepository
+	OAuthConfig       *service.OAuthServiceConfig
+	// LoadTestConfig enables synthetic user tokens for load tests (nil = disabled)
+	LoadTestConfig *service.LoadTestServiceConfig
 }
 
 // NewContainer creates a new dependency injection container
@@ -43,6 +93,15 @@ func NewContainer(cfg *ContainerConfig) *Container {
 		UserRepo:    cfg.UserRepo,
 		SessionRepo: cfg.SessionRepo,
 		TenantRepo:  cfg.TenantRepo,
+		ExportRepo:  cfg.ExportRepo,
+
+		TenantSettingsRepo: cfg.TenantSettingsRepo,
+		ChallengeRepo:      cfg.ChallengeRepo,
+		DeviceRepo:         cfg.DeviceRepo,
+		RevocationRepo:     cfg.RevocationRepo,
+		PurchaseRepo:       cfg.PurchaseRepo,
+		OAuthProviderRepo:  cfg.OAuthProviderRepo,
+		IdentityRepo:       cfg.IdentityRepo,
 	}
 
 	// Initialize services
@@ -51,12 +110,61 @@ func NewContainer(cfg *ContainerConfig) *Container {
 		c.SessionRepo,
 		cfg.ServiceConfig,
 	)
+	c.TokenValidationService = service.NewTokenValidationService(
+		c.AuthService,
+		c.RevocationRepo,
+		cfg.TokenValidationConfig,
+	)
 	c.TenantService = service.NewTenantService(c.TenantRepo)
+	c.TenantSettingsService = service.NewTenantSettingsService(
+		c.TenantRepo,
+		c.TenantSettingsRepo,
+		cfg.TenantSettingsPublisher,
+		service.NewSenderDomainVerifier(cfg.SenderDomainConfig),
+	)
+	c.PrivacyService = service.NewPrivacyService(
+		c.UserRepo,
+		c.SessionRepo,
+		c.ExportRepo,
+		c.DeviceRepo,
+		c.PurchaseRepo,
+		c.IdentityRepo,
+		cfg.UserDataClients,
+		cfg.PrivacyConfig,
+	)
+	c.VerificationService = service.NewVerificationService(
+		c.UserRepo,
+		c.ChallengeRepo,
+		cfg.VerificationCodeSender,
+		cfg.VerificationConfig,
+	)
+	c.DeviceService = service.NewDeviceService(c.DeviceRepo)
+	c.PurchaseService = service.NewPurchaseService(c.PurchaseRepo, cfg.PurchaseConfig)
+	c.OAuthService = service.NewOAuthService(
+		c.UserRepo,
+		c.IdentityRepo,
+		c.OAuthProviderRepo,
+		c.TenantRepo,
+		c.AuthService,
+		cfg.OAuthConfig,
+	)
+	if cfg.LoadTestConfig != nil {
+		c.LoadTestService = service.NewLoadTestService(cfg.LoadTestConfig)
+	}
 
 	// Initialize handlers
 	c.HealthHandler = handler.NewHealthHandler(c.DB)
-	c.AuthHandler = handler.NewAuthHandler(c.AuthService)
+	c.AuthHandler = handler.NewAuthHandler(c.AuthService, c.TokenValidationService)
 	c.TenantHandler = handler.NewTenantHandler(c.TenantService)
+	c.TenantSettingsHandler = handler.NewTenantSettingsHandler(c.TenantSettingsService)
+	c.PrivacyHandler = handler.NewPrivacyHandler(c.PrivacyService)
+	c.VerificationHandler = handler.NewVerificationHandler(c.VerificationService)
+	c.DeviceHandler = handler.NewDeviceHandler(c.DeviceService)
+	c.PurchaseHandler = handler.NewPurchaseHandler(c.PurchaseService)
+	c.OAuthHandler = handler.NewOAuthHandler(c.OAuthService)
+	if c.LoadTestService != nil {
+		c.LoadTestHandler = handler.NewLoadTestHandler(c.LoadTestService)
+	}
 
 	return c
 }
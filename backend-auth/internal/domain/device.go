@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"time"
+)
+
+// DevicePlatform is the mobile platform of a registered device
+type DevicePlatform string
+
+const (
+	DevicePlatformAndroid DevicePlatform = "android" // Delivered through FCM
+	DevicePlatformIOS     DevicePlatform = "ios"     // Delivered through APNs
+)
+
+// MaxDevicesPerUser is how many devices a user can register; registering another
+// replaces the one seen least recently
+const MaxDevicesPerUser = 10
+
+// Device is a mobile app installation that receives push notifications.
+// A token belongs to one device, so registering it again moves it to the new user.
+type Device struct {
+	ID         string         `json:"id"`
+	UserID     string         `json:"user_id"`
+	Platform   DevicePlatform `json:"platform"`
+	Token      string         `json:"-"` // Provider token, never returned to clients
+	Locale     string         `json:"locale,omitempty"`
+	AppVersion string         `json:"app_version,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+	LastSeenAt time.Time      `json:"last_seen_at"`
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"time"
+)
+
+// Social login providers
+const (
+	OAuthProviderGoogle   = "google"
+	OAuthProviderFacebook = "facebook"
+	OAuthProviderLINE     = "line"
+)
+
+// OAuthProviders are the supported social login providers, in display order
+var OAuthProviders = []string{OAuthProviderGoogle, OAuthProviderFacebook, OAuthProviderLINE}
+
+// IsOAuthProvider reports whether name is a supported social login provider
+func IsOAuthProvider(name string) bool {
+	for _, provider := range OAuthProviders {
+		if provider == name {
+			return true
+		}
+	}
+	return false
+}
+
+// OAuthProviderConfig is the client a tenant registered with a social login provider.
+// Tenants without one for a provider use the platform's client.
+type OAuthProviderConfig struct {
+	TenantID     string    `json:"tenant_id,omitempty"` // "" = the platform's client
+	Provider     string    `json:"provider"`
+	ClientID     string    `json:"client_id"`
+	ClientSecret string    `json:"-"` // Never serialized
+	RedirectURL  string    `json:"redirect_url"`
+	Enabled      bool      `json:"enabled"`
+	UpdatedBy    string    `json:"updated_by,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// UserIdentity links a provider account to a user
+type UserIdentity struct {
+	ID          string     `json:"id"`
+	UserID      string     `json:"user_id"`
+	Provider    string     `json:"provider"`
+	Subject     string     `json:"subject"` // The provider's stable account ID
+	Email       string     `json:"email,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+}
+
+// OAuthProfile is what a provider asserted about the account that signed in
+type OAuthProfile struct {
+	Provider string
+	Subject  string
+	Email    string
+	// EmailVerified is set when the provider vouches the account owns Email,
+	// which is required to link the account to an existing user
+	EmailVerified bool
+	Name          string
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"time"
+)
+
+// DataExportStatus represents the lifecycle of a user data export
+type DataExportStatus string
+
+const (
+	DataExportStatusPending DataExportStatus = "pending" // Archive is being compiled
+	DataExportStatusReady   DataExportStatus = "ready"   // Archive is available for download
+	DataExportStatusFailed  DataExportStatus = "failed"  // Compilation failed
+)
+
+// DataExport represents a user's request for a copy of their personal data (GDPR right of access)
+type DataExport struct {
+	ID          string           `json:"id"`
+	UserID      string           `json:"user_id"`
+	Status      DataExportStatus `json:"status"`
+	Error       string           `json:"error,omitempty"`
+	Archive     []byte           `json:"-"` // Zip archive, never serialized in API responses
+	ArchiveKey  string           `json:"-"` // Object storage key of the archive when kept out of the database
+	SizeBytes   int64            `json:"size_bytes"`
+	CreatedAt   time.Time        `json:"created_at"`
+	CompletedAt *time.Time       `json:"completed_at,omitempty"`
+	ExpiresAt   time.Time        `json:"expires_at"`
+}
+
+// IsExpired checks if the export archive is past its retention period
+func (e *DataExport) IsExpired() bool {
+	return time.Now().After(e.ExpiresAt)
+}
+
+// MarkReady stores the compiled archive and marks the export as ready
+func (e *DataExport) MarkReady(archive []byte) {
+	now := time.Now()
+	e.Status = DataExportStatusReady
+	e.Archive = archive
+	e.SizeBytes = int64(len(archive))
+	e.Error = ""
+	e.CompletedAt = &now
+}
+
+// MarkStored records the archive kept in object storage under key and marks the export as ready
+func (e *DataExport) MarkStored(key string, size int64) {
+	now := time.Now()
+	e.Status = DataExportStatusReady
+	e.Archive = nil
+	e.ArchiveKey = key
+	e.SizeBytes = size
+	e.Error = ""
+	e.CompletedAt = &now
+}
+
+// MarkFailed marks the export as failed with the given reason
+func (e *DataExport) MarkFailed(err error) {
+	now := time.Now()
+	e.Status = DataExportStatusFailed
+	e.Archive = nil
+	e.SizeBytes = 0
+	if err != nil {
+		e.Error = err.Error()
+	}
+	e.CompletedAt = &now
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"time"
+)
+
+// PurchaseStatus is the booking status of a purchase
+type PurchaseStatus string
+
+const (
+	PurchaseStatusPending   PurchaseStatus = "pending"   // Only a payment event has been seen so far
+	PurchaseStatusConfirmed PurchaseStatus = "confirmed" // The booking is confirmed
+	PurchaseStatusCancelled PurchaseStatus = "cancelled" // The confirmed booking was cancelled
+)
+
+// Purchase is a booking in a user's purchase history, projected from booking and payment events
+type Purchase struct {
+	BookingID      string
+	UserID         string
+	EventID        string
+	ShowID         string
+	Quantity       int
+	BookingAmount  float64 // Total price of the booking
+	Currency       string
+	Status         PurchaseStatus
+	ConfirmedAt    *time.Time
+	PaidAmount     *float64 // Nil until a payment event is seen
+	RefundedAmount float64
+	ShowStartsAt   *time.Time // Nil until the show's start time is resolved
+}
+
+// Spent returns what the user was charged for the purchase net of refunds. Bookings
+// paid through the saga flow publish no payment event, so a confirmed booking counts
+// its price until one is seen.
+func (p *Purchase) Spent() float64 {
+	charged := 0.0
+	switch {
+	case p.PaidAmount != nil:
+		charged = *p.PaidAmount
+	case p.Status == PurchaseStatusConfirmed:
+		charged = p.BookingAmount
+	}
+	if spent := charged - p.RefundedAmount; spent > 0 {
+		return spent
+	}
+	return 0
+}
+
+// PurchaseBookingUpdate applies a booking event to a purchase
+type PurchaseBookingUpdate struct {
+	BookingID   string
+	UserID      string
+	EventID     string
+	ShowID      string
+	Quantity    int
+	Amount      float64
+	Currency    string
+	Status      PurchaseStatus
+	ConfirmedAt *time.Time
+	OccurredAt  time.Time
+}
+
+// PurchasePaymentUpdate applies a payment event to a purchase. Only one of Paid and
+// Refunded is set.
+type PurchasePaymentUpdate struct {
+	BookingID  string
+	UserID     string
+	Currency   string
+	Paid       *float64 // Amount charged (payment.success)
+	Refunded   *float64 // Total refunded so far (payment.refunded)
+	OccurredAt time.Time
+}
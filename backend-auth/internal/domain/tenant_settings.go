@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// DefaultTenantCurrency is the currency of tenants that have not chosen one
+const DefaultTenantCurrency = "THB"
+
+// TenantSettings is the typed configuration of a tenant
+type TenantSettings struct {
+	TenantID          string                 `json:"tenant_id"`
+	Currency          string                 `json:"currency"`
+	MaxTicketsPerUser int                    `json:"max_tickets_per_user"` // 0 = service default
+	Plan              string                 `json:"plan"`                 // Usage plan; "" = the gateway's default plan
+	Queue             TenantQueueSettings    `json:"queue"`
+	Branding          TenantBrandingSettings `json:"branding"`
+	Sender            TenantSenderSettings   `json:"sender"`
+	Version           int64                  `json:"version"` // 0 = never saved
+	UpdatedBy         string                 `json:"updated_by,omitempty"`
+	CreatedAt         time.Time              `json:"created_at"`
+	UpdatedAt         time.Time              `json:"updated_at"`
+}
+
+// TenantQueueSettings are the virtual queue defaults for the tenant's events
+type TenantQueueSettings struct {
+	RequirePass    bool  `json:"require_pass"`
+	MaxSize        int64 `json:"max_size"`         // 0 = unlimited
+	PassTTLSeconds int   `json:"pass_ttl_seconds"` // 0 = service default
+}
+
+// TenantBrandingSettings control how the tenant is presented to customers
+type TenantBrandingSettings struct {
+	DisplayName  string `json:"display_name,omitempty"`
+	LogoURL      string `json:"logo_url,omitempty"`
+	PrimaryColor string `json:"primary_color,omitempty"`
+	SupportEmail string `json:"support_email,omitempty"`
+}
+
+// TenantSenderSettings are the address the tenant's notification emails are sent from.
+// The address is used once its domain publishes the verification token and
+// authorizes our mail servers.
+type TenantSenderSettings struct {
+	FromAddress string `json:"from_address,omitempty"`
+	FromName    string `json:"from_name,omitempty"`
+	ReplyTo     string `json:"reply_to,omitempty"`
+	// VerificationToken is published in DNS to prove ownership of the from domain;
+	// a new token is issued whenever the from address changes
+	VerificationToken string     `json:"verification_token,omitempty"`
+	VerifiedAt        *time.Time `json:"verified_at,omitempty"`
+}
+
+// Domain returns the domain of the from address
+func (s *TenantSenderSettings) Domain() string {
+	at := strings.LastIndex(s.FromAddress, "@")
+	if at < 0 {
+		return ""
+	}
+	return strings.ToLower(s.FromAddress[at+1:])
+}
+
+// IsVerified reports whether emails can be sent from the from address
+func (s *TenantSenderSettings) IsVerified() bool {
+	return s.FromAddress != "" && s.VerifiedAt != nil
+}
+
+// NewDefaultTenantSettings returns the settings of a tenant that has saved none
+func NewDefaultTenantSettings(tenantID string) *TenantSettings {
+	return &TenantSettings{
+		TenantID: tenantID,
+		Currency: DefaultTenantCurrency,
+	}
+}
@@ -12,8 +12,22 @@ const (
 	RoleOrganizer  Role = "organizer"
 	RoleAdmin      Role = "admin"
 	RoleSuperAdmin Role = "super_admin"
+	RoleSupport    Role = "support" // Customer support agents: read-only booking search
 )
 
+// RiskLevel represents how likely an account is to be used for scalping
+type RiskLevel string
+
+const (
+	RiskLevelNormal RiskLevel = "normal"
+	RiskLevelHigh   RiskLevel = "high" // Must pass a verification challenge before booking
+)
+
+// IsValid checks if the risk level is known
+func (r RiskLevel) IsValid() bool {
+	return r == RiskLevelNormal || r == RiskLevelHigh
+}
+
 // User represents a user entity
 type User struct {
 	ID               string    `json:"id"`
@@ -26,6 +40,14 @@ type User struct {
 	IsActive         bool      `json:"is_active"`
 	CreatedAt        time.Time `json:"created_at"`
 	UpdatedAt        time.Time `json:"updated_at"`
+
+	// Account verification
+	Phone           string     `json:"phone,omitempty"`
+	EmailVerified   bool       `json:"email_verified"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	PhoneVerifiedAt *time.Time `json:"phone_verified_at,omitempty"`
+	RiskLevel       RiskLevel  `json:"risk_level"`
+	VerifiedAt      *time.Time `json:"verified_at,omitempty"` // Last passed verification challenge
 }
 
 // Session represents a user session
@@ -52,4 +74,14 @@ type Claims struct {
 	Email    string `json:"email"`
 	Role     Role   `json:"role"`
 	TenantID string `json:"tenant_id"`
+	// Audience is the tenant the token was minted for (nil for tokens issued before tenant isolation)
+	Audience []string `json:"audience,omitempty"`
+	// IssuedAt is when the token was signed, checked against the user's last revocation
+	IssuedAt  time.Time `json:"issued_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+
+	EmailVerified bool       `json:"email_verified"`
+	PhoneVerified bool       `json:"phone_verified"`
+	RiskLevel     RiskLevel  `json:"risk_level"`
+	VerifiedAt    *time.Time `json:"verified_at,omitempty"`
 }
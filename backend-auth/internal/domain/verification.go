@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+)
+
+// VerificationChannel is where a verification code is delivered
+type VerificationChannel string
+
+const (
+	VerificationChannelEmail VerificationChannel = "email"
+	VerificationChannelPhone VerificationChannel = "phone"
+)
+
+// MaxVerificationAttempts is how many wrong codes a challenge accepts before it is locked
+const MaxVerificationAttempts = 5
+
+// VerificationChallenge is a one-time code sent to a user's email or phone.
+// Passing it verifies the channel and satisfies the challenge required of high-risk accounts.
+type VerificationChallenge struct {
+	ID          string              `json:"id"`
+	UserID      string              `json:"user_id"`
+	Channel     VerificationChannel `json:"channel"`
+	Destination string              `json:"destination"`
+	CodeHash    string              `json:"-"` // SHA-256 of the code, never serialized
+	Attempts    int                 `json:"attempts"`
+	CreatedAt   time.Time           `json:"created_at"`
+	ExpiresAt   time.Time           `json:"expires_at"`
+	VerifiedAt  *time.Time          `json:"verified_at,omitempty"`
+}
+
+// IsExpired checks if the challenge code can no longer be used
+func (c *VerificationChallenge) IsExpired() bool {
+	return time.Now().After(c.ExpiresAt)
+}
+
+// IsLocked checks if the challenge used up its attempts
+func (c *VerificationChallenge) IsLocked() bool {
+	return c.Attempts >= MaxVerificationAttempts
+}
@@ -97,6 +97,15 @@ type UserResponse struct {
 	CreatedAt string `json:"created_at"`
 }
 
+// UserLookupResponse identifies a user found by email for other services
+type UserLookupResponse struct {
+	ID       string `json:"id"`
+	TenantID string `json:"tenant_id,omitempty"`
+	Email    string `json:"email"`
+	Name     string `json:"name"`
+	Role     string `json:"role"`
+}
+
 // UpdateProfileRequest represents profile update request
 type UpdateProfileRequest struct {
 	Name string `json:"name" binding:"omitempty,min=2,max=100"`
@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+// RegisterDeviceRequest represents request to register a device for push notifications
+type RegisterDeviceRequest struct {
+	Platform string `json:"platform" binding:"required,oneof=android ios"`
+	// Token is the FCM registration token or APNs device token
+	Token      string `json:"token" binding:"required,max=4096"`
+	Locale     string `json:"locale" binding:"omitempty,max=10"`
+	AppVersion string `json:"app_version" binding:"omitempty,max=50"`
+}
+
+// RevokeDeviceTokensRequest represents request to drop tokens the push providers rejected
+type RevokeDeviceTokensRequest struct {
+	Tokens []string `json:"tokens" binding:"required,min=1,max=1000,dive,required"`
+}
+
+// DeviceResponse represents a registered device
+type DeviceResponse struct {
+	ID         string    `json:"id"`
+	Platform   string    `json:"platform"`
+	Locale     string    `json:"locale,omitempty"`
+	AppVersion string    `json:"app_version,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+}
+
+// PushDeviceResponse represents a device with its token, for the notification pipeline only
+type PushDeviceResponse struct {
+	ID       string `json:"id"`
+	Platform string `json:"platform"`
+	Token    string `json:"token"`
+	Locale   string `json:"locale,omitempty"`
+}
+
+// RevokeDeviceTokensResponse represents the result of dropping rejected tokens
+type RevokeDeviceTokensResponse struct {
+	Deleted int64 `json:"deleted"`
+}
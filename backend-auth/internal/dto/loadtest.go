@@ -0,0 +1,29 @@
+package dto
+
+import "time"
+
+// MintLoadTestUsersRequest represents request to mint tokens for synthetic load-test users.
+// Users are numbered from Offset within a run; the same run and number always yield the
+// same user ID, so a scenario can be re-run against the same identities.
+type MintLoadTestUsersRequest struct {
+	RunID    string `json:"run_id" binding:"required,max=64"`
+	Count    int    `json:"count" binding:"required,min=1"`
+	Offset   int    `json:"offset" binding:"min=0"`
+	TenantID string `json:"tenant_id,omitempty"`
+	// RiskLevel of the users (default normal); high exercises verification challenges
+	RiskLevel string `json:"risk_level,omitempty" binding:"omitempty,oneof=normal high"`
+}
+
+// LoadTestUser is a synthetic user with a signed access token
+type LoadTestUser struct {
+	UserID      string `json:"user_id"`
+	Email       string `json:"email"`
+	AccessToken string `json:"access_token"`
+}
+
+// MintLoadTestUsersResponse represents minted synthetic users
+type MintLoadTestUsersResponse struct {
+	RunID     string         `json:"run_id"`
+	ExpiresAt time.Time      `json:"expires_at"`
+	Users     []LoadTestUser `json:"users"`
+}
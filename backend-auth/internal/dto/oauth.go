@@ -0,0 +1,53 @@
+package dto
+
+import "time"
+
+// OAuthAuthorizeResponse is where to send the user to sign in with a provider.
+// The client keeps State (e.g. in session storage) and checks the provider
+// redirects back with the same value before posting the code.
+type OAuthAuthorizeResponse struct {
+	Provider         string    `json:"provider"`
+	AuthorizationURL string    `json:"authorization_url"`
+	State            string    `json:"state"`
+	ExpiresAt        time.Time `json:"expires_at"`
+}
+
+// OAuthCallbackRequest represents the code and state a provider redirected back with
+type OAuthCallbackRequest struct {
+	Code  string `json:"code" binding:"required,max=2048"`
+	State string `json:"state" binding:"required,max=2048"`
+}
+
+// OAuthProvidersResponse lists the providers users can sign in with
+type OAuthProvidersResponse struct {
+	Providers []string `json:"providers"`
+}
+
+// SaveOAuthProviderRequest represents request to set a tenant's client of a provider
+type SaveOAuthProviderRequest struct {
+	ClientID string `json:"client_id" binding:"required,max=255"`
+	// ClientSecret may be omitted to keep the stored secret
+	ClientSecret string `json:"client_secret" binding:"omitempty,max=1024"`
+	RedirectURL  string `json:"redirect_url" binding:"required,url"`
+	Enabled      *bool  `json:"enabled" binding:"omitempty"` // Default true
+}
+
+// OAuthProviderResponse represents a tenant's client of a provider, without its secret
+type OAuthProviderResponse struct {
+	Provider        string    `json:"provider"`
+	ClientID        string    `json:"client_id"`
+	HasClientSecret bool      `json:"has_client_secret"`
+	RedirectURL     string    `json:"redirect_url"`
+	Enabled         bool      `json:"enabled"`
+	UpdatedBy       string    `json:"updated_by,omitempty"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// UserIdentityResponse represents a provider account linked to the user
+type UserIdentityResponse struct {
+	ID          string     `json:"id"`
+	Provider    string     `json:"provider"`
+	Email       string     `json:"email,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+}
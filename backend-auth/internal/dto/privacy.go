@@ -0,0 +1,49 @@
+package dto
+
+// DataExportResponse represents a user data export request status
+type DataExportResponse struct {
+	ID          string `json:"id"`
+	Status      string `json:"status"`
+	Error       string `json:"error,omitempty"`
+	SizeBytes   int64  `json:"size_bytes"`
+	CreatedAt   string `json:"created_at"`
+	CompletedAt string `json:"completed_at,omitempty"`
+	ExpiresAt   string `json:"expires_at"`
+	DownloadURL string `json:"download_url,omitempty"` // Set once the archive is ready
+}
+
+// AccountDeletionResponse represents the result of the account deletion saga
+type AccountDeletionResponse struct {
+	SagaID string                `json:"saga_id"`
+	Status string                `json:"status"`
+	Steps  []AccountDeletionStep `json:"steps"`
+}
+
+// AccountDeletionStep represents the outcome of one account deletion saga step
+type AccountDeletionStep struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ExportedProfile is the profile.json entry of a data export archive
+type ExportedProfile struct {
+	ID               string `json:"id"`
+	Email            string `json:"email"`
+	Name             string `json:"name"`
+	Role             string `json:"role"`
+	TenantID         string `json:"tenant_id,omitempty"`
+	StripeCustomerID string `json:"stripe_customer_id,omitempty"`
+	IsActive         bool   `json:"is_active"`
+	CreatedAt        string `json:"created_at"`
+	UpdatedAt        string `json:"updated_at"`
+}
+
+// ExportedSession is an entry of sessions.json in a data export archive (refresh tokens are never exported)
+type ExportedSession struct {
+	ID        string `json:"id"`
+	UserAgent string `json:"user_agent"`
+	IP        string `json:"ip"`
+	CreatedAt string `json:"created_at"`
+	ExpiresAt string `json:"expires_at"`
+}
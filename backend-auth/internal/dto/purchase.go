@@ -0,0 +1,41 @@
+package dto
+
+import "time"
+
+// PurchaseSummaryResponse represents a user's purchase history and spending
+type PurchaseSummaryResponse struct {
+	// TotalSpent is what the user was charged net of refunds, per currency
+	TotalSpent        []CurrencyAmount   `json:"total_spent"`
+	ConfirmedBookings int                `json:"confirmed_bookings"`
+	TicketsPurchased  int                `json:"tickets_purchased"`
+	EventsAttended    int                `json:"events_attended"`
+	UpcomingEvents    []UpcomingEvent    `json:"upcoming_events"`
+	RecentPurchases   []PurchaseResponse `json:"recent_purchases"`
+}
+
+// CurrencyAmount represents an amount in a currency
+type CurrencyAmount struct {
+	Currency string  `json:"currency"`
+	Amount   float64 `json:"amount"`
+}
+
+// UpcomingEvent represents a show the user holds confirmed tickets for
+type UpcomingEvent struct {
+	EventID string `json:"event_id"`
+	ShowID  string `json:"show_id,omitempty"`
+	// StartsAt is omitted while the show's start time is not resolved yet
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+	Tickets  int        `json:"tickets"`
+}
+
+// PurchaseResponse represents a booking in the purchase history
+type PurchaseResponse struct {
+	BookingID   string     `json:"booking_id"`
+	EventID     string     `json:"event_id,omitempty"`
+	ShowID      string     `json:"show_id,omitempty"`
+	Quantity    int        `json:"quantity"`
+	Status      string     `json:"status"`
+	Spent       float64    `json:"spent"`
+	Currency    string     `json:"currency"`
+	ConfirmedAt *time.Time `json:"confirmed_at,omitempty"`
+}
@@ -2,6 +2,8 @@ package dto
 
 import (
 	"regexp"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 )
 
 // CreateTenantRequest represents request to create a new tenant (organizer)
@@ -64,6 +66,8 @@ type ListTenantsQuery struct {
 	Limit    int    `form:"limit" binding:"omitempty,min=1,max=100"`
 	IsActive *bool  `form:"is_active" binding:"omitempty"`
 	Search   string `form:"search" binding:"omitempty,max=255"`
+	// Cursor is the next_cursor of a previous page; when set, Page is ignored
+	Cursor string `form:"cursor" binding:"omitempty"`
 }
 
 // SetDefaults sets default values for query parameters
@@ -83,4 +87,5 @@ type ListTenantsResponse struct {
 	Page       int              `json:"page"`
 	Limit      int              `json:"limit"`
 	TotalPages int              `json:"total_pages"`
+	pagination.Info
 }
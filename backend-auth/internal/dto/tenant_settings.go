@@ -0,0 +1,68 @@
+package dto
+
+import "time"
+
+// UpdateTenantSettingsRequest represents request to update tenant settings.
+// Omitted fields keep their current values.
+type UpdateTenantSettingsRequest struct {
+	Currency          *string                       `json:"currency" binding:"omitempty,len=3,alpha"`
+	MaxTicketsPerUser *int                          `json:"max_tickets_per_user" binding:"omitempty,min=0,max=100"`
+	Plan              *string                       `json:"plan" binding:"omitempty,max=50"` // "" returns the tenant to the default plan
+	Queue             *UpdateTenantQueueSettings    `json:"queue" binding:"omitempty"`
+	Branding          *UpdateTenantBrandingSettings `json:"branding" binding:"omitempty"`
+	Sender            *UpdateTenantSenderSettings   `json:"sender" binding:"omitempty"`
+	// Version is the version the client last read; 0 skips the concurrency check
+	Version int64 `json:"version" binding:"omitempty,min=0"`
+}
+
+// UpdateTenantQueueSettings represents the queue part of a settings update
+type UpdateTenantQueueSettings struct {
+	RequirePass    *bool  `json:"require_pass" binding:"omitempty"`
+	MaxSize        *int64 `json:"max_size" binding:"omitempty,min=0"`
+	PassTTLSeconds *int   `json:"pass_ttl_seconds" binding:"omitempty,min=0,max=86400"`
+}
+
+// UpdateTenantBrandingSettings represents the branding part of a settings update
+type UpdateTenantBrandingSettings struct {
+	DisplayName  *string `json:"display_name" binding:"omitempty,max=255"`
+	LogoURL      *string `json:"logo_url" binding:"omitempty,url"`
+	PrimaryColor *string `json:"primary_color" binding:"omitempty,hexcolor"`
+	SupportEmail *string `json:"support_email" binding:"omitempty,email"`
+}
+
+// UpdateTenantSenderSettings represents the email sender part of a settings update.
+// Changing the from address requires verifying its domain again; "" returns the
+// tenant to the default sender.
+type UpdateTenantSenderSettings struct {
+	FromAddress *string `json:"from_address" binding:"omitempty,email,max=255"`
+	FromName    *string `json:"from_name" binding:"omitempty,max=100"`
+	ReplyTo     *string `json:"reply_to" binding:"omitempty,email,max=255"`
+}
+
+// SenderDNSRecord is a DNS record the tenant publishes on the from domain
+type SenderDNSRecord struct {
+	Purpose string `json:"purpose"` // ownership, spf or dkim
+	Type    string `json:"type"`    // TXT or CNAME
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	// Found reports whether the last check saw the record (nil = not checked)
+	Found *bool `json:"found,omitempty"`
+}
+
+// SenderVerificationResponse is the verification state of a tenant's from domain
+// with the records to publish
+type SenderVerificationResponse struct {
+	FromAddress string            `json:"from_address"`
+	Domain      string            `json:"domain"`
+	Verified    bool              `json:"verified"`
+	VerifiedAt  *time.Time        `json:"verified_at,omitempty"`
+	Records     []SenderDNSRecord `json:"records"`
+}
+
+// Validate validates that at least one field is provided for update
+func (r *UpdateTenantSettingsRequest) Validate() (bool, string) {
+	if r.Currency == nil && r.MaxTicketsPerUser == nil && r.Plan == nil && r.Queue == nil && r.Branding == nil && r.Sender == nil {
+		return false, "At least one field must be provided for update"
+	}
+	return true, ""
+}
@@ -0,0 +1,48 @@
+package dto
+
+import "time"
+
+// StartVerificationRequest represents request to start a verification challenge
+type StartVerificationRequest struct {
+	Channel string `json:"channel" binding:"required,oneof=email phone"`
+	// Phone is the E.164 number to verify, required for the phone channel
+	Phone string `json:"phone" binding:"omitempty,e164"`
+}
+
+// Validate validates that the phone channel has a number to send the code to
+func (r *StartVerificationRequest) Validate() (bool, string) {
+	if r.Channel == "phone" && r.Phone == "" {
+		return false, "Phone is required for the phone channel"
+	}
+	return true, ""
+}
+
+// VerifyChallengeRequest represents request to complete a verification challenge
+type VerifyChallengeRequest struct {
+	Code string `json:"code" binding:"required,len=6,numeric"`
+}
+
+// SetRiskLevelRequest represents request to change the risk level of a user
+type SetRiskLevelRequest struct {
+	RiskLevel string `json:"risk_level" binding:"required,oneof=normal high"`
+}
+
+// VerificationStatusResponse represents the verification state of a user
+type VerificationStatusResponse struct {
+	EmailVerified bool       `json:"email_verified"`
+	PhoneVerified bool       `json:"phone_verified"`
+	RiskLevel     string     `json:"risk_level"`
+	VerifiedAt    *time.Time `json:"verified_at,omitempty"`
+	// RefreshRequired is set when the state changed; access tokens only carry it after /auth/refresh
+	RefreshRequired bool `json:"refresh_required,omitempty"`
+}
+
+// VerificationChallengeResponse represents a started verification challenge
+type VerificationChallengeResponse struct {
+	ID          string    `json:"id"`
+	Channel     string    `json:"channel"`
+	Destination string    `json:"destination"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	// DebugCode is the code itself, only returned in development
+	DebugCode string `json:"debug_code,omitempty"`
+}
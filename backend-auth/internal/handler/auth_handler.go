@@ -2,7 +2,9 @@ package handler
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/dto"
@@ -15,12 +17,13 @@ import (
 
 // AuthHandler handles authentication HTTP requests
 type AuthHandler struct {
-	authService service.AuthService
+	authService     service.AuthService
+	tokenValidation service.TokenValidationService
 }
 
 // NewAuthHandler creates a new AuthHandler
-func NewAuthHandler(authService service.AuthService) *AuthHandler {
-	return &AuthHandler{authService: authService}
+func NewAuthHandler(authService service.AuthService, tokenValidation service.TokenValidationService) *AuthHandler {
+	return &AuthHandler{authService: authService, tokenValidation: tokenValidation}
 }
 
 // Register handles user registration
@@ -207,6 +210,14 @@ func (h *AuthHandler) LogoutAll(c *gin.Context) {
 		return
 	}
 
+	// Access tokens outlive their sessions, so they are revoked as well
+	if err := h.tokenValidation.RevokeUserTokens(ctx, userID.(string)); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		c.JSON(http.StatusInternalServerError, response.InternalError(err.Error()))
+		return
+	}
+
 	span.SetStatus(codes.Ok, "")
 	c.JSON(http.StatusOK, response.Success(gin.H{"message": "All sessions logged out successfully"}))
 }
@@ -306,6 +317,8 @@ func (h *AuthHandler) UpdateMe(c *gin.Context) {
 
 // ValidateToken validates a token (internal endpoint for other services)
 // POST /api/v1/auth/validate
+// The result carries an ETag and Cache-Control max-age; callers revalidating a cached
+// result with If-None-Match get 304 Not Modified while it still holds.
 func (h *AuthHandler) ValidateToken(c *gin.Context) {
 	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.auth.validate_token")
 	defer span.End()
@@ -327,26 +340,53 @@ func (h *AuthHandler) ValidateToken(c *gin.Context) {
 	}
 	token := authHeader[len(bearerPrefix):]
 
-	claims, err := h.authService.ValidateToken(ctx, token)
+	validation, err := h.tokenValidation.Validate(ctx, token)
 	if err != nil {
 		span.RecordError(err)
-		if errors.Is(err, service.ErrTokenExpired) {
+		c.Header("Cache-Control", "no-store")
+		switch {
+		case errors.Is(err, service.ErrTokenExpired):
 			span.SetStatus(codes.Error, "token expired")
 			c.JSON(http.StatusUnauthorized, response.Error("TOKEN_EXPIRED", "Access token has expired"))
-			return
+		case errors.Is(err, service.ErrTokenRevoked):
+			span.SetStatus(codes.Error, "token revoked")
+			c.JSON(http.StatusUnauthorized, response.Error("TOKEN_REVOKED", "Access token has been revoked"))
+		case errors.Is(err, service.ErrInvalidToken):
+			span.SetStatus(codes.Error, "invalid token")
+			c.JSON(http.StatusUnauthorized, response.Error("INVALID_TOKEN", "Invalid access token"))
+		default:
+			span.SetStatus(codes.Error, err.Error())
+			c.JSON(http.StatusServiceUnavailable, response.Error("VALIDATION_UNAVAILABLE", "Token validation is temporarily unavailable"))
 		}
-		span.SetStatus(codes.Error, "invalid token")
-		c.JSON(http.StatusUnauthorized, response.Error("INVALID_TOKEN", "Invalid access token"))
 		return
 	}
 
+	claims := validation.Claims
 	span.SetAttributes(attribute.String("user_id", claims.UserID))
+	c.Header("ETag", validation.ETag)
+	c.Header("Cache-Control", fmt.Sprintf("private, max-age=%d", int(validation.MaxAge.Seconds())))
+	if !validation.RevokedAt.IsZero() {
+		c.Header("X-Auth-Revoked-At", strconv.FormatInt(validation.RevokedAt.Unix(), 10))
+	}
+	if c.GetHeader("If-None-Match") == validation.ETag {
+		span.SetStatus(codes.Ok, "not modified")
+		c.Status(http.StatusNotModified)
+		return
+	}
+
 	span.SetStatus(codes.Ok, "")
 	c.JSON(http.StatusOK, response.Success(gin.H{
-		"user_id":   claims.UserID,
-		"email":     claims.Email,
-		"role":      claims.Role,
-		"tenant_id": claims.TenantID,
+		"user_id":        claims.UserID,
+		"email":          claims.Email,
+		"role":           claims.Role,
+		"tenant_id":      claims.TenantID,
+		"audience":       claims.Audience,
+		"email_verified": claims.EmailVerified,
+		"phone_verified": claims.PhoneVerified,
+		"risk_level":     claims.RiskLevel,
+		"verified_at":    claims.VerifiedAt,
+		"issued_at":      claims.IssuedAt.Unix(),
+		"expires_at":     claims.ExpiresAt.Unix(),
 	}))
 }
 
@@ -425,3 +465,42 @@ func (h *AuthHandler) UpdateStripeCustomerID(c *gin.Context) {
 		"stripe_customer_id": req.StripeCustomerID,
 	}))
 }
+
+// LookupUsers finds users by email for other services (booking search by support agents)
+// GET /internal/users?email=&tenant_id=
+func (h *AuthHandler) LookupUsers(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.auth.lookup_users")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	tenantID := c.Query("tenant_id")
+	span.SetAttributes(attribute.String("tenant_id", tenantID))
+
+	users, err := h.authService.LookupUsersByEmail(ctx, c.Query("email"), tenantID)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, service.ErrEmailRequired) {
+			span.SetStatus(codes.Error, "email required")
+			c.JSON(http.StatusBadRequest, response.BadRequest(err.Error()))
+			return
+		}
+		span.SetStatus(codes.Error, err.Error())
+		c.JSON(http.StatusInternalServerError, response.InternalError(err.Error()))
+		return
+	}
+
+	result := make([]dto.UserLookupResponse, len(users))
+	for i, user := range users {
+		result[i] = dto.UserLookupResponse{
+			ID:       user.ID,
+			TenantID: user.TenantID,
+			Email:    user.Email,
+			Name:     user.Name,
+			Role:     string(user.Role),
+		}
+	}
+
+	span.SetAttributes(attribute.Int("count", len(result)))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(result))
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/response"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// DeviceHandler handles push device registration HTTP requests
+type DeviceHandler struct {
+	deviceService service.DeviceService
+}
+
+// NewDeviceHandler creates a new DeviceHandler
+func NewDeviceHandler(deviceService service.DeviceService) *DeviceHandler {
+	return &DeviceHandler{deviceService: deviceService}
+}
+
+// Register registers the current user's device for push notifications.
+// Apps call it on every launch so the token and last_seen_at stay current.
+// POST /api/v1/auth/me/devices
+func (h *DeviceHandler) Register(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.device.register")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		span.SetStatus(codes.Error, "user not authenticated")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User not authenticated"))
+		return
+	}
+
+	var req dto.RegisterDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request body")
+		c.JSON(http.StatusBadRequest, response.BadRequest(err.Error()))
+		return
+	}
+
+	result, err := h.deviceService.RegisterDevice(ctx, userID.(string), &req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		c.JSON(http.StatusInternalServerError, response.InternalError(err.Error()))
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(result))
+}
+
+// List lists the current user's devices
+// GET /api/v1/auth/me/devices
+func (h *DeviceHandler) List(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User not authenticated"))
+		return
+	}
+
+	result, err := h.deviceService.ListDevices(c.Request.Context(), userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.InternalError(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success(result))
+}
+
+// Remove unregisters a device of the current user, e.g. on sign-out
+// DELETE /api/v1/auth/me/devices/:id
+func (h *DeviceHandler) Remove(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User not authenticated"))
+		return
+	}
+
+	if err := h.deviceService.RemoveDevice(c.Request.Context(), userID.(string), c.Param("id")); err != nil {
+		if errors.Is(err, service.ErrDeviceNotFound) {
+			c.JSON(http.StatusNotFound, response.NotFound("Device not found"))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.InternalError(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success(gin.H{"message": "Device removed"}))
+}
+
+// ListPushDevices lists a user's devices with their tokens (internal endpoint for the notification pipeline)
+// GET /internal/users/:id/devices
+func (h *DeviceHandler) ListPushDevices(c *gin.Context) {
+	userID := c.Param("id")
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, response.BadRequest("User ID is required"))
+		return
+	}
+
+	result, err := h.deviceService.ListPushDevices(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.InternalError(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.Success(result))
+}
+
+// RevokeTokens drops devices whose tokens the push providers rejected (internal endpoint for the notification pipeline)
+// POST /internal/devices/revoke
+func (h *DeviceHandler) RevokeTokens(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.device.revoke_tokens")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	var req dto.RevokeDeviceTokensRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request body")
+		c.JSON(http.StatusBadRequest, response.BadRequest(err.Error()))
+		return
+	}
+
+	span.SetAttributes(attribute.Int("count", len(req.Tokens)))
+
+	result, err := h.deviceService.RevokeTokens(ctx, &req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		c.JSON(http.StatusInternalServerError, response.InternalError(err.Error()))
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(result))
+}
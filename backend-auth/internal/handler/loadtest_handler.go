@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/response"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// LoadTestHandler handles load-test orchestration requests (load-test mode only)
+type LoadTestHandler struct {
+	loadTestService service.LoadTestService
+}
+
+// NewLoadTestHandler creates a new LoadTestHandler
+func NewLoadTestHandler(loadTestService service.LoadTestService) *LoadTestHandler {
+	return &LoadTestHandler{loadTestService: loadTestService}
+}
+
+// MintUsers mints access tokens for a batch of synthetic users
+// POST /internal/loadtest/users
+func (h *LoadTestHandler) MintUsers(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.loadtest.mint_users")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	var req dto.MintLoadTestUsersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request body")
+		c.JSON(http.StatusBadRequest, response.BadRequest(err.Error()))
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("run_id", req.RunID),
+		attribute.Int("count", req.Count),
+	)
+
+	result, err := h.loadTestService.MintUsers(ctx, &req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if errors.Is(err, service.ErrLoadTestBatchTooLarge) {
+			c.JSON(http.StatusBadRequest, response.BadRequest(err.Error()))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, response.InternalError("Failed to mint load test users"))
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(result))
+}
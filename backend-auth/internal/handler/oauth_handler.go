@@ -0,0 +1,250 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/response"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// OAuthHandler handles social login HTTP requests
+type OAuthHandler struct {
+	oauthService service.OAuthService
+}
+
+// NewOAuthHandler creates a new OAuthHandler
+func NewOAuthHandler(oauthService service.OAuthService) *OAuthHandler {
+	return &OAuthHandler{oauthService: oauthService}
+}
+
+// Providers lists the providers the login page offers
+// GET /api/v1/auth/oauth/providers?tenant_id=
+func (h *OAuthHandler) Providers(c *gin.Context) {
+	providers, err := h.oauthService.Providers(c.Request.Context(), c.Query("tenant_id"))
+	if err != nil {
+		h.handleError(c, trace.SpanFromContext(c.Request.Context()), err)
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(dto.OAuthProvidersResponse{Providers: providers}))
+}
+
+// Authorize returns the provider's consent page to send the user to
+// GET /api/v1/auth/oauth/:provider/authorize?tenant_id=
+func (h *OAuthHandler) Authorize(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.oauth.authorize")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	result, err := h.oauthService.Authorize(ctx, c.Param("provider"), c.Query("tenant_id"))
+	if err != nil {
+		span.RecordError(err)
+		h.handleError(c, span, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(result))
+}
+
+// Callback signs the user in with the code the provider redirected back with
+// POST /api/v1/auth/oauth/:provider/callback
+func (h *OAuthHandler) Callback(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.oauth.callback")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	var req dto.OAuthCallbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request body")
+		c.JSON(http.StatusBadRequest, response.BadRequest(err.Error()))
+		return
+	}
+
+	result, err := h.oauthService.Callback(ctx, c.Param("provider"), &req, c.GetHeader("User-Agent"), c.ClientIP())
+	if err != nil {
+		span.RecordError(err)
+		h.handleError(c, span, err)
+		return
+	}
+
+	span.SetAttributes(attribute.String("user_id", result.User.ID))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(result))
+}
+
+// Link links a provider account to the current user
+// POST /api/v1/auth/me/identities/:provider
+func (h *OAuthHandler) Link(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.oauth.link")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		span.SetStatus(codes.Error, "user not authenticated")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User not authenticated"))
+		return
+	}
+
+	var req dto.OAuthCallbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request body")
+		c.JSON(http.StatusBadRequest, response.BadRequest(err.Error()))
+		return
+	}
+
+	identity, err := h.oauthService.Link(ctx, userID.(string), c.Param("provider"), &req)
+	if err != nil {
+		span.RecordError(err)
+		h.handleError(c, span, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(toUserIdentityResponse(identity)))
+}
+
+// ListIdentities lists the provider accounts linked to the current user
+// GET /api/v1/auth/me/identities
+func (h *OAuthHandler) ListIdentities(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User not authenticated"))
+		return
+	}
+
+	identities, err := h.oauthService.ListIdentities(c.Request.Context(), userID.(string))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, response.InternalError(err.Error()))
+		return
+	}
+
+	result := make([]dto.UserIdentityResponse, 0, len(identities))
+	for _, identity := range identities {
+		result = append(result, toUserIdentityResponse(identity))
+	}
+	c.JSON(http.StatusOK, response.Success(result))
+}
+
+// ListTenantProviders lists the clients a tenant registered with providers
+// GET /api/v1/tenants/:id/oauth-providers
+func (h *OAuthHandler) ListTenantProviders(c *gin.Context) {
+	configs, err := h.oauthService.ListTenantProviders(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, trace.SpanFromContext(c.Request.Context()), err)
+		return
+	}
+
+	result := make([]dto.OAuthProviderResponse, 0, len(configs))
+	for _, config := range configs {
+		result = append(result, toOAuthProviderResponse(config))
+	}
+	c.JSON(http.StatusOK, response.Success(result))
+}
+
+// SaveTenantProvider sets the client a tenant registered with a provider
+// PUT /api/v1/tenants/:id/oauth-providers/:provider
+func (h *OAuthHandler) SaveTenantProvider(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.oauth.save_tenant_provider")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	var req dto.SaveOAuthProviderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request body")
+		c.JSON(http.StatusBadRequest, response.BadRequest(err.Error()))
+		return
+	}
+
+	config, err := h.oauthService.SaveTenantProvider(ctx, c.Param("id"), c.GetString("user_id"), c.Param("provider"), &req)
+	if err != nil {
+		span.RecordError(err)
+		h.handleError(c, span, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(toOAuthProviderResponse(config)))
+}
+
+// DeleteTenantProvider returns a tenant to the platform's client of a provider
+// DELETE /api/v1/tenants/:id/oauth-providers/:provider
+func (h *OAuthHandler) DeleteTenantProvider(c *gin.Context) {
+	if err := h.oauthService.DeleteTenantProvider(c.Request.Context(), c.Param("id"), c.Param("provider")); err != nil {
+		h.handleError(c, trace.SpanFromContext(c.Request.Context()), err)
+		return
+	}
+	c.JSON(http.StatusOK, response.Success(gin.H{"message": "Provider client removed"}))
+}
+
+// handleError converts service errors to HTTP responses
+func (h *OAuthHandler) handleError(c *gin.Context, span trace.Span, err error) {
+	switch {
+	case errors.Is(err, service.ErrOAuthProviderUnsupported):
+		span.SetStatus(codes.Error, "unsupported provider")
+		c.JSON(http.StatusNotFound, response.Error("OAUTH_PROVIDER_UNSUPPORTED", "Supported providers are google, facebook and line"))
+	case errors.Is(err, service.ErrOAuthProviderNotConfigured):
+		span.SetStatus(codes.Error, "provider not configured")
+		c.JSON(http.StatusNotFound, response.Error("OAUTH_PROVIDER_NOT_CONFIGURED", "Sign-in with this provider is not available"))
+	case errors.Is(err, service.ErrTenantNotFound):
+		span.SetStatus(codes.Error, "tenant not found")
+		c.JSON(http.StatusNotFound, response.NotFound("Tenant not found"))
+	case errors.Is(err, service.ErrOAuthInvalidState):
+		span.SetStatus(codes.Error, "invalid state")
+		c.JSON(http.StatusBadRequest, response.Error("INVALID_OAUTH_STATE", "The sign-in expired or was not started here, start again"))
+	case errors.Is(err, service.ErrOAuthExchangeFailed):
+		span.SetStatus(codes.Error, "code exchange failed")
+		c.JSON(http.StatusUnauthorized, response.Error("OAUTH_EXCHANGE_FAILED", "The provider did not confirm the sign-in, start again"))
+	case errors.Is(err, service.ErrOAuthEmailRequired):
+		span.SetStatus(codes.Error, "email required")
+		c.JSON(http.StatusUnprocessableEntity, response.Error("OAUTH_EMAIL_REQUIRED", "Allow access to your email address to sign in with this provider"))
+	case errors.Is(err, service.ErrOAuthAccountExists):
+		span.SetStatus(codes.Error, "account exists")
+		c.JSON(http.StatusConflict, response.Error("OAUTH_ACCOUNT_EXISTS", "An account with this email exists, sign in with your password and link the provider from your account"))
+	case errors.Is(err, service.ErrOAuthIdentityLinked):
+		span.SetStatus(codes.Error, "identity linked to another user")
+		c.JSON(http.StatusConflict, response.Error("OAUTH_IDENTITY_LINKED", "This provider account is linked to another user"))
+	case errors.Is(err, service.ErrOAuthClientSecretRequired):
+		span.SetStatus(codes.Error, "client secret required")
+		c.JSON(http.StatusBadRequest, response.BadRequest("client_secret is required for a new provider client"))
+	case errors.Is(err, service.ErrUserInactive):
+		span.SetStatus(codes.Error, "user inactive")
+		c.JSON(http.StatusForbidden, response.Error("USER_INACTIVE", "User account is inactive"))
+	default:
+		span.SetStatus(codes.Error, err.Error())
+		c.JSON(http.StatusInternalServerError, response.InternalError(err.Error()))
+	}
+}
+
+func toUserIdentityResponse(identity *domain.UserIdentity) dto.UserIdentityResponse {
+	return dto.UserIdentityResponse{
+		ID:          identity.ID,
+		Provider:    identity.Provider,
+		Email:       identity.Email,
+		CreatedAt:   identity.CreatedAt,
+		LastLoginAt: identity.LastLoginAt,
+	}
+}
+
+func toOAuthProviderResponse(config *domain.OAuthProviderConfig) dto.OAuthProviderResponse {
+	return dto.OAuthProviderResponse{
+		Provider:        config.Provider,
+		ClientID:        config.ClientID,
+		HasClientSecret: config.ClientSecret != "",
+		RedirectURL:     config.RedirectURL,
+		Enabled:         config.Enabled,
+		UpdatedBy:       config.UpdatedBy,
+		UpdatedAt:       config.UpdatedAt,
+	}
+}
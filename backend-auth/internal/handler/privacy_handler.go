@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/response"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// PrivacyHandler handles GDPR data export and account deletion HTTP requests
+type PrivacyHandler struct {
+	privacyService service.PrivacyService
+}
+
+// NewPrivacyHandler creates a new PrivacyHandler
+func NewPrivacyHandler(privacyService service.PrivacyService) *PrivacyHandler {
+	return &PrivacyHandler{privacyService: privacyService}
+}
+
+// RequestDataExport starts compiling a copy of the user's data
+// POST /api/v1/auth/me/data-export
+func (h *PrivacyHandler) RequestDataExport(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.privacy.request_data_export")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		span.SetStatus(codes.Error, "user not authenticated")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User not authenticated"))
+		return
+	}
+
+	span.SetAttributes(attribute.String("user_id", userID.(string)))
+
+	export, err := h.privacyService.RequestDataExport(ctx, userID.(string))
+	if err != nil {
+		span.RecordError(err)
+		h.handleError(c, span, err)
+		return
+	}
+
+	span.SetAttributes(attribute.String("export_id", export.ID))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusAccepted, response.Success(toDataExportResponse(export)))
+}
+
+// GetDataExport returns the status of a data export
+// GET /api/v1/auth/me/data-export/:id
+func (h *PrivacyHandler) GetDataExport(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.privacy.get_data_export")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		span.SetStatus(codes.Error, "user not authenticated")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User not authenticated"))
+		return
+	}
+
+	exportID := c.Param("id")
+	span.SetAttributes(
+		attribute.String("user_id", userID.(string)),
+		attribute.String("export_id", exportID),
+	)
+
+	export, err := h.privacyService.GetDataExport(ctx, userID.(string), exportID)
+	if err != nil {
+		span.RecordError(err)
+		h.handleError(c, span, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(toDataExportResponse(export)))
+}
+
+// DownloadDataExport streams the compiled zip archive, or redirects to it in object storage
+// GET /api/v1/auth/me/data-export/:id/download
+func (h *PrivacyHandler) DownloadDataExport(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.privacy.download_data_export")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		span.SetStatus(codes.Error, "user not authenticated")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User not authenticated"))
+		return
+	}
+
+	exportID := c.Param("id")
+	span.SetAttributes(
+		attribute.String("user_id", userID.(string)),
+		attribute.String("export_id", exportID),
+	)
+
+	export, err := h.privacyService.GetDataExport(ctx, userID.(string), exportID)
+	if err == nil {
+		switch {
+		case export.IsExpired():
+			err = service.ErrDataExportExpired
+		case export.Status != domain.DataExportStatusReady:
+			err = service.ErrDataExportNotReady
+		}
+	}
+	if err != nil {
+		span.RecordError(err)
+		h.handleError(c, span, err)
+		return
+	}
+
+	span.SetAttributes(attribute.Int64("size_bytes", export.SizeBytes))
+
+	// Archives in object storage are downloaded straight from there
+	if export.ArchiveKey != "" {
+		url, err := h.privacyService.DataExportURL(ctx, export)
+		if err != nil {
+			span.RecordError(err)
+			h.handleError(c, span, err)
+			return
+		}
+		span.SetStatus(codes.Ok, "")
+		c.Redirect(http.StatusFound, url)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="data-export-%s.zip"`, export.ID))
+	c.Data(http.StatusOK, "application/zip", export.Archive)
+}
+
+// DeleteMe anonymizes the current user's personal data in every service
+// DELETE /api/v1/auth/me
+func (h *PrivacyHandler) DeleteMe(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.privacy.delete_me")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		span.SetStatus(codes.Error, "user not authenticated")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User not authenticated"))
+		return
+	}
+
+	span.SetAttributes(attribute.String("user_id", userID.(string)))
+
+	result, err := h.privacyService.DeleteAccount(ctx, userID.(string))
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, service.ErrAccountDeletionFailed) && result != nil {
+			span.SetStatus(codes.Error, "account deletion failed")
+			c.JSON(http.StatusBadGateway, response.Response{
+				Success: false,
+				Data:    result,
+				Error: &response.ErrorInfo{
+					Code:    "DELETION_FAILED",
+					Message: "Account deletion could not be completed in all services; access has been restored, please retry",
+				},
+			})
+			return
+		}
+		h.handleError(c, span, err)
+		return
+	}
+
+	span.SetAttributes(attribute.String("saga_id", result.SagaID))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(result))
+}
+
+// handleError converts service errors to HTTP responses
+func (h *PrivacyHandler) handleError(c *gin.Context, span trace.Span, err error) {
+	switch {
+	case errors.Is(err, service.ErrUserNotFound):
+		span.SetStatus(codes.Error, "user not found")
+		c.JSON(http.StatusNotFound, response.NotFound("User not found"))
+	case errors.Is(err, service.ErrDataExportNotFound):
+		span.SetStatus(codes.Error, "data export not found")
+		c.JSON(http.StatusNotFound, response.NotFound("Data export not found"))
+	case errors.Is(err, service.ErrDataExportNotReady):
+		span.SetStatus(codes.Error, "data export not ready")
+		c.JSON(http.StatusConflict, response.Error("EXPORT_NOT_READY", "Data export is not ready for download"))
+	case errors.Is(err, service.ErrDataExportExpired):
+		span.SetStatus(codes.Error, "data export expired")
+		c.JSON(http.StatusGone, response.Error("EXPORT_EXPIRED", "Data export has expired, please request a new one"))
+	default:
+		span.SetStatus(codes.Error, err.Error())
+		c.JSON(http.StatusInternalServerError, response.InternalError(err.Error()))
+	}
+}
+
+// toDataExportResponse converts a DataExport to its API representation
+func toDataExportResponse(export *domain.DataExport) dto.DataExportResponse {
+	resp := dto.DataExportResponse{
+		ID:        export.ID,
+		Status:    string(export.Status),
+		Error:     export.Error,
+		SizeBytes: export.SizeBytes,
+		CreatedAt: export.CreatedAt.Format(time.RFC3339),
+		ExpiresAt: export.ExpiresAt.Format(time.RFC3339),
+	}
+	if export.CompletedAt != nil {
+		resp.CompletedAt = export.CompletedAt.Format(time.RFC3339)
+	}
+	if export.Status == domain.DataExportStatusReady {
+		resp.DownloadURL = fmt.Sprintf("/api/v1/auth/me/data-export/%s/download", export.ID)
+	}
+	return resp
+}
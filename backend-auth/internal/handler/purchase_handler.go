@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/response"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// PurchaseHandler handles purchase history HTTP requests
+type PurchaseHandler struct {
+	purchaseService service.PurchaseService
+}
+
+// NewPurchaseHandler creates a new PurchaseHandler
+func NewPurchaseHandler(purchaseService service.PurchaseService) *PurchaseHandler {
+	return &PurchaseHandler{purchaseService: purchaseService}
+}
+
+// Summary returns the current user's spending, attended and upcoming events.
+// It is served from the purchase history projection, which trails bookings and
+// payments by the Kafka consumer lag.
+// GET /api/v1/auth/me/purchase-summary
+func (h *PurchaseHandler) Summary(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.purchase.summary")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		span.SetStatus(codes.Error, "user not authenticated")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User not authenticated"))
+		return
+	}
+
+	summary, err := h.purchaseService.GetSummary(ctx, userID.(string))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		c.JSON(http.StatusInternalServerError, response.InternalError(err.Error()))
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(summary))
+}
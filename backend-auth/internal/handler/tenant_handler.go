@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/dto"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/response"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
 	"go.opentelemetry.io/otel/attribute"
@@ -157,6 +158,10 @@ func (h *TenantHandler) List(c *gin.Context) {
 
 	result, err := h.tenantService.List(ctx, &query)
 	if err != nil {
+		if errors.Is(err, pagination.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, response.Error("INVALID_CURSOR", "Invalid pagination cursor"))
+			return
+		}
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 		c.JSON(http.StatusInternalServerError, response.InternalError(err.Error()))
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/response"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TenantSettingsHandler handles tenant settings HTTP requests
+type TenantSettingsHandler struct {
+	settingsService service.TenantSettingsService
+}
+
+// NewTenantSettingsHandler creates a new TenantSettingsHandler
+func NewTenantSettingsHandler(settingsService service.TenantSettingsService) *TenantSettingsHandler {
+	return &TenantSettingsHandler{settingsService: settingsService}
+}
+
+// Get handles retrieving the settings of a tenant
+// GET /api/v1/tenants/:id/settings
+// GET /internal/tenants/:id/settings
+func (h *TenantSettingsHandler) Get(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.tenant_settings.get")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	id := c.Param("id")
+	if id == "" {
+		span.SetStatus(codes.Error, "tenant_id required")
+		c.JSON(http.StatusBadRequest, response.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	span.SetAttributes(attribute.String("tenant_id", id))
+
+	result, err := h.settingsService.Get(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		h.handleError(c, span, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(result))
+}
+
+// Update handles updating the settings of a tenant
+// PUT /api/v1/tenants/:id/settings
+func (h *TenantSettingsHandler) Update(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.tenant_settings.update")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	id := c.Param("id")
+	if id == "" {
+		span.SetStatus(codes.Error, "tenant_id required")
+		c.JSON(http.StatusBadRequest, response.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	span.SetAttributes(attribute.String("tenant_id", id))
+
+	var req dto.UpdateTenantSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request body")
+		c.JSON(http.StatusBadRequest, response.BadRequest(err.Error()))
+		return
+	}
+
+	// Validate that at least one field is provided
+	if valid, msg := req.Validate(); !valid {
+		span.SetStatus(codes.Error, "validation error")
+		c.JSON(http.StatusBadRequest, response.Error("INVALID_UPDATE", msg))
+		return
+	}
+
+	result, err := h.settingsService.Update(ctx, id, c.GetString("user_id"), &req)
+	if err != nil {
+		span.RecordError(err)
+		h.handleError(c, span, err)
+		return
+	}
+
+	span.SetAttributes(attribute.Int64("settings_version", result.Version))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(result))
+}
+
+// Reset handles restoring the default settings of a tenant
+// DELETE /api/v1/tenants/:id/settings
+func (h *TenantSettingsHandler) Reset(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.tenant_settings.reset")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	id := c.Param("id")
+	if id == "" {
+		span.SetStatus(codes.Error, "tenant_id required")
+		c.JSON(http.StatusBadRequest, response.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	span.SetAttributes(attribute.String("tenant_id", id))
+
+	result, err := h.settingsService.Reset(ctx, id, c.GetString("user_id"))
+	if err != nil {
+		span.RecordError(err)
+		h.handleError(c, span, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(result))
+}
+
+// GetSender handles retrieving the email sender verification state of a tenant
+// GET /api/v1/tenants/:id/settings/sender
+func (h *TenantSettingsHandler) GetSender(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.tenant_settings.get_sender")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	id := c.Param("id")
+	if id == "" {
+		span.SetStatus(codes.Error, "tenant_id required")
+		c.JSON(http.StatusBadRequest, response.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	span.SetAttributes(attribute.String("tenant_id", id))
+
+	result, err := h.settingsService.GetSender(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		h.handleError(c, span, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(result))
+}
+
+// VerifySender handles checking the DNS records of a tenant's from domain
+// POST /api/v1/tenants/:id/settings/sender/verify
+func (h *TenantSettingsHandler) VerifySender(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.tenant_settings.verify_sender")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	id := c.Param("id")
+	if id == "" {
+		span.SetStatus(codes.Error, "tenant_id required")
+		c.JSON(http.StatusBadRequest, response.BadRequest("Tenant ID is required"))
+		return
+	}
+
+	span.SetAttributes(attribute.String("tenant_id", id))
+
+	result, err := h.settingsService.VerifySender(ctx, id, c.GetString("user_id"))
+	if err != nil {
+		span.RecordError(err)
+		h.handleError(c, span, err)
+		return
+	}
+
+	span.SetAttributes(attribute.Bool("sender_verified", result.Verified))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(result))
+}
+
+// handleError converts service errors to HTTP responses
+func (h *TenantSettingsHandler) handleError(c *gin.Context, span trace.Span, err error) {
+	switch {
+	case errors.Is(err, service.ErrTenantNotFound):
+		span.SetStatus(codes.Error, "tenant not found")
+		c.JSON(http.StatusNotFound, response.NotFound("Tenant not found"))
+	case errors.Is(err, service.ErrTenantSettingsConflict):
+		span.SetStatus(codes.Error, "settings version conflict")
+		c.JSON(http.StatusConflict, response.Error("SETTINGS_CONFLICT", "Tenant settings were modified, reload and try again"))
+	case errors.Is(err, service.ErrSenderNotConfigured):
+		span.SetStatus(codes.Error, "sender not configured")
+		c.JSON(http.StatusNotFound, response.Error("SENDER_NOT_CONFIGURED", "Set sender.from_address in the tenant settings first"))
+	case errors.Is(err, service.ErrSenderDNSLookupFailed):
+		span.SetStatus(codes.Error, "dns lookup failed")
+		c.JSON(http.StatusServiceUnavailable, response.Error("DNS_LOOKUP_FAILED", err.Error()))
+	default:
+		span.SetStatus(codes.Error, err.Error())
+		c.JSON(http.StatusInternalServerError, response.InternalError(err.Error()))
+	}
+}
@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/response"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// VerificationHandler handles account verification HTTP requests
+type VerificationHandler struct {
+	verificationService service.VerificationService
+}
+
+// NewVerificationHandler creates a new VerificationHandler
+func NewVerificationHandler(verificationService service.VerificationService) *VerificationHandler {
+	return &VerificationHandler{verificationService: verificationService}
+}
+
+// GetStatus returns the verification state of the current user
+// GET /api/v1/auth/me/verification
+func (h *VerificationHandler) GetStatus(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.verification.get_status")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		span.SetStatus(codes.Error, "user not authenticated")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User not authenticated"))
+		return
+	}
+
+	span.SetAttributes(attribute.String("user_id", userID.(string)))
+
+	result, err := h.verificationService.GetStatus(ctx, userID.(string))
+	if err != nil {
+		span.RecordError(err)
+		h.handleError(c, span, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(result))
+}
+
+// StartChallenge sends a verification code to the current user's email or phone.
+// Booking-service points high-risk users here when a reservation needs a fresh challenge.
+// POST /api/v1/auth/me/verification/challenges
+func (h *VerificationHandler) StartChallenge(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.verification.start_challenge")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		span.SetStatus(codes.Error, "user not authenticated")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User not authenticated"))
+		return
+	}
+
+	var req dto.StartVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request body")
+		c.JSON(http.StatusBadRequest, response.BadRequest(err.Error()))
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("user_id", userID.(string)),
+		attribute.String("channel", req.Channel),
+	)
+
+	result, err := h.verificationService.StartChallenge(ctx, userID.(string), &req)
+	if err != nil {
+		span.RecordError(err)
+		h.handleError(c, span, err)
+		return
+	}
+
+	span.SetAttributes(attribute.String("challenge_id", result.ID))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusCreated, response.Success(result))
+}
+
+// VerifyChallenge completes a verification challenge with the code the user received
+// POST /api/v1/auth/me/verification/challenges/:id/verify
+func (h *VerificationHandler) VerifyChallenge(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.verification.verify_challenge")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		span.SetStatus(codes.Error, "user not authenticated")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User not authenticated"))
+		return
+	}
+
+	challengeID := c.Param("id")
+	span.SetAttributes(
+		attribute.String("user_id", userID.(string)),
+		attribute.String("challenge_id", challengeID),
+	)
+
+	var req dto.VerifyChallengeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request body")
+		c.JSON(http.StatusBadRequest, response.BadRequest(err.Error()))
+		return
+	}
+
+	result, err := h.verificationService.VerifyChallenge(ctx, userID.(string), challengeID, &req)
+	if err != nil {
+		span.RecordError(err)
+		h.handleError(c, span, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(result))
+}
+
+// SetRiskLevel changes the risk level of a user (internal endpoint for fraud tooling)
+// PUT /internal/users/:id/risk-level
+func (h *VerificationHandler) SetRiskLevel(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.verification.set_risk_level")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	userID := c.Param("id")
+	if userID == "" {
+		span.SetStatus(codes.Error, "user_id required")
+		c.JSON(http.StatusBadRequest, response.BadRequest("User ID is required"))
+		return
+	}
+
+	var req dto.SetRiskLevelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request body")
+		c.JSON(http.StatusBadRequest, response.BadRequest(err.Error()))
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("user_id", userID),
+		attribute.String("risk_level", req.RiskLevel),
+	)
+
+	result, err := h.verificationService.SetRiskLevel(ctx, userID, &req)
+	if err != nil {
+		span.RecordError(err)
+		h.handleError(c, span, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(result))
+}
+
+func (h *VerificationHandler) handleError(c *gin.Context, span trace.Span, err error) {
+	switch {
+	case errors.Is(err, service.ErrUserNotFound):
+		span.SetStatus(codes.Error, "user not found")
+		c.JSON(http.StatusNotFound, response.NotFound("User not found"))
+	case errors.Is(err, service.ErrChallengeNotFound):
+		span.SetStatus(codes.Error, "challenge not found")
+		c.JSON(http.StatusNotFound, response.NotFound("Verification challenge not found"))
+	case errors.Is(err, service.ErrInvalidVerificationInput):
+		span.SetStatus(codes.Error, "invalid request")
+		c.JSON(http.StatusBadRequest, response.BadRequest(err.Error()))
+	case errors.Is(err, service.ErrInvalidVerificationCode):
+		span.SetStatus(codes.Error, "invalid code")
+		c.JSON(http.StatusBadRequest, response.Error("INVALID_CODE", "Verification code is incorrect"))
+	case errors.Is(err, service.ErrChallengeExpired):
+		span.SetStatus(codes.Error, "challenge expired")
+		c.JSON(http.StatusGone, response.Error("CHALLENGE_EXPIRED", "Verification code has expired, request a new one"))
+	case errors.Is(err, service.ErrChallengeAlreadyUsed):
+		span.SetStatus(codes.Error, "challenge already used")
+		c.JSON(http.StatusConflict, response.Error("CHALLENGE_ALREADY_USED", "Verification challenge was already completed"))
+	case errors.Is(err, service.ErrChallengeLocked):
+		span.SetStatus(codes.Error, "challenge locked")
+		c.JSON(http.StatusTooManyRequests, response.Error("CHALLENGE_LOCKED", "Too many incorrect codes, request a new one"))
+	case errors.Is(err, service.ErrVerificationRateLimited):
+		span.SetStatus(codes.Error, "rate limited")
+		c.JSON(http.StatusTooManyRequests, response.TooManyRequests("Too many verification codes requested, try again later"))
+	default:
+		span.SetStatus(codes.Error, err.Error())
+		c.JSON(http.StatusInternalServerError, response.InternalError(err.Error()))
+	}
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+)
+
+// DataExportRepository defines the interface for user data export persistence
+type DataExportRepository interface {
+	// Create creates a new data export record
+	Create(ctx context.Context, export *domain.DataExport) error
+	// GetByID retrieves a data export by ID (including the archive)
+	GetByID(ctx context.Context, id string) (*domain.DataExport, error)
+	// Update updates status, archive (or its storage key) and completion time of a data export
+	Update(ctx context.Context, export *domain.DataExport) error
+	// DeleteByUserID deletes all data exports for a user
+	DeleteByUserID(ctx context.Context, userID string) error
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+)
+
+// DeviceRepository defines the interface for push device persistence
+type DeviceRepository interface {
+	// Upsert registers a device by token. A known token is moved to device.UserID and
+	// keeps its ID; device.ID and CreatedAt are set to the stored values.
+	Upsert(ctx context.Context, device *domain.Device) error
+	// ListByUserID lists a user's devices, most recently seen first
+	ListByUserID(ctx context.Context, userID string) ([]*domain.Device, error)
+	// Delete removes a device of a user, reporting whether it existed
+	Delete(ctx context.Context, userID, id string) (bool, error)
+	// DeleteByTokens removes the devices holding any of the tokens
+	DeleteByTokens(ctx context.Context, tokens []string) (int64, error)
+	// DeleteByUserID removes all devices of a user
+	DeleteByUserID(ctx context.Context, userID string) error
+	// TrimByUserID removes all but the keep most recently seen devices of a user
+	TrimByUserID(ctx context.Context, userID string, keep int) (int64, error)
+}
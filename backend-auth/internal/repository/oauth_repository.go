@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+)
+
+// OAuthProviderRepository defines the interface for tenants' social login clients
+type OAuthProviderRepository interface {
+	// Get retrieves a tenant's client for a provider (nil if the tenant has none)
+	Get(ctx context.Context, tenantID, provider string) (*domain.OAuthProviderConfig, error)
+	// ListByTenantID lists a tenant's clients
+	ListByTenantID(ctx context.Context, tenantID string) ([]*domain.OAuthProviderConfig, error)
+	// Save inserts or replaces a tenant's client and sets its CreatedAt and UpdatedAt
+	Save(ctx context.Context, config *domain.OAuthProviderConfig) error
+	// Delete removes a tenant's client, reporting whether it existed
+	Delete(ctx context.Context, tenantID, provider string) (bool, error)
+}
+
+// UserIdentityRepository defines the interface for provider accounts linked to users
+type UserIdentityRepository interface {
+	// Create links a provider account to a user
+	Create(ctx context.Context, identity *domain.UserIdentity) error
+	// GetBySubject retrieves the link of a provider account (nil if it is not linked)
+	GetBySubject(ctx context.Context, provider, subject string) (*domain.UserIdentity, error)
+	// ListByUserID lists the provider accounts linked to a user
+	ListByUserID(ctx context.Context, userID string) ([]*domain.UserIdentity, error)
+	// TouchLogin records a sign-in with a linked provider account
+	TouchLogin(ctx context.Context, id string, at time.Time) error
+	// DeleteByUserID removes all provider accounts linked to a user
+	DeleteByUserID(ctx context.Context, userID string) error
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+)
+
+// PostgresDataExportRepository implements DataExportRepository using PostgreSQL
+type PostgresDataExportRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresDataExportRepository creates a new PostgresDataExportRepository
+func NewPostgresDataExportRepository(pool *pgxpool.Pool) *PostgresDataExportRepository {
+	return &PostgresDataExportRepository{pool: pool}
+}
+
+// Create creates a new data export record
+func (r *PostgresDataExportRepository) Create(ctx context.Context, export *domain.DataExport) error {
+	query := `
+		INSERT INTO data_exports (id, user_id, status, size_bytes, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := r.pool.Exec(ctx, query,
+		export.ID,
+		export.UserID,
+		export.Status,
+		export.SizeBytes,
+		export.CreatedAt,
+		export.ExpiresAt,
+	)
+	return err
+}
+
+// GetByID retrieves a data export by ID (including the archive)
+func (r *PostgresDataExportRepository) GetByID(ctx context.Context, id string) (*domain.DataExport, error) {
+	query := `
+		SELECT id, user_id, status, COALESCE(error, ''), archive, COALESCE(archive_key, ''), size_bytes, created_at, completed_at, expires_at
+		FROM data_exports
+		WHERE id = $1
+	`
+	export := &domain.DataExport{}
+	err := r.pool.QueryRow(ctx, query, id).Scan(
+		&export.ID,
+		&export.UserID,
+		&export.Status,
+		&export.Error,
+		&export.Archive,
+		&export.ArchiveKey,
+		&export.SizeBytes,
+		&export.CreatedAt,
+		&export.CompletedAt,
+		&export.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return export, nil
+}
+
+// Update updates status, archive (or its storage key) and completion time of a data export
+func (r *PostgresDataExportRepository) Update(ctx context.Context, export *domain.DataExport) error {
+	query := `
+		UPDATE data_exports
+		SET status = $2, error = $3, archive = $4, archive_key = $5, size_bytes = $6, completed_at = $7
+		WHERE id = $1
+	`
+	// Convert empty error and archive key to nil for NULL in database
+	var exportError, archiveKey interface{}
+	if export.Error != "" {
+		exportError = export.Error
+	}
+	if export.ArchiveKey != "" {
+		archiveKey = export.ArchiveKey
+	}
+
+	_, err := r.pool.Exec(ctx, query,
+		export.ID,
+		export.Status,
+		exportError,
+		export.Archive,
+		archiveKey,
+		export.SizeBytes,
+		export.CompletedAt,
+	)
+	return err
+}
+
+// DeleteByUserID deletes all data exports for a user
+func (r *PostgresDataExportRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	query := `DELETE FROM data_exports WHERE user_id = $1`
+	_, err := r.pool.Exec(ctx, query, userID)
+	return err
+}
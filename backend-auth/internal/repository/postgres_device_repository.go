@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+)
+
+// PostgresDeviceRepository implements DeviceRepository using PostgreSQL
+type PostgresDeviceRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresDeviceRepository creates a new PostgresDeviceRepository
+func NewPostgresDeviceRepository(pool *pgxpool.Pool) *PostgresDeviceRepository {
+	return &PostgresDeviceRepository{pool: pool}
+}
+
+// Upsert registers a device by token
+func (r *PostgresDeviceRepository) Upsert(ctx context.Context, device *domain.Device) error {
+	query := `
+		INSERT INTO devices (id, user_id, platform, token, locale, app_version, created_at, last_seen_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''), $7, $8)
+		ON CONFLICT (token) DO UPDATE SET
+			user_id = EXCLUDED.user_id,
+			platform = EXCLUDED.platform,
+			locale = EXCLUDED.locale,
+			app_version = EXCLUDED.app_version,
+			last_seen_at = EXCLUDED.last_seen_at
+		RETURNING id, created_at
+	`
+	return r.pool.QueryRow(ctx, query,
+		device.ID,
+		device.UserID,
+		device.Platform,
+		device.Token,
+		device.Locale,
+		device.AppVersion,
+		device.CreatedAt,
+		device.LastSeenAt,
+	).Scan(&device.ID, &device.CreatedAt)
+}
+
+// ListByUserID lists a user's devices, most recently seen first
+func (r *PostgresDeviceRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.Device, error) {
+	query := `
+		SELECT id, user_id, platform, token, COALESCE(locale, ''), COALESCE(app_version, ''), created_at, last_seen_at
+		FROM devices
+		WHERE user_id = $1
+		ORDER BY last_seen_at DESC
+	`
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var devices []*domain.Device
+	for rows.Next() {
+		device := &domain.Device{}
+		if err := rows.Scan(
+			&device.ID,
+			&device.UserID,
+			&device.Platform,
+			&device.Token,
+			&device.Locale,
+			&device.AppVersion,
+			&device.CreatedAt,
+			&device.LastSeenAt,
+		); err != nil {
+			return nil, err
+		}
+		devices = append(devices, device)
+	}
+	return devices, rows.Err()
+}
+
+// Delete removes a device of a user
+func (r *PostgresDeviceRepository) Delete(ctx context.Context, userID, id string) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM devices WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// DeleteByTokens removes the devices holding any of the tokens
+func (r *PostgresDeviceRepository) DeleteByTokens(ctx context.Context, tokens []string) (int64, error) {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM devices WHERE token = ANY($1)`, tokens)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// DeleteByUserID removes all devices of a user
+func (r *PostgresDeviceRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM devices WHERE user_id = $1`, userID)
+	return err
+}
+
+// TrimByUserID removes all but the keep most recently seen devices of a user
+func (r *PostgresDeviceRepository) TrimByUserID(ctx context.Context, userID string, keep int) (int64, error) {
+	query := `
+		DELETE FROM devices
+		WHERE user_id = $1 AND id NOT IN (
+			SELECT id FROM devices WHERE user_id = $1 ORDER BY last_seen_at DESC LIMIT $2
+		)
+	`
+	tag, err := r.pool.Exec(ctx, query, userID, keep)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
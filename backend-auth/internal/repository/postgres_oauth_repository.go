@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+)
+
+// PostgresOAuthProviderRepository implements OAuthProviderRepository using PostgreSQL
+type PostgresOAuthProviderRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresOAuthProviderRepository creates a new PostgresOAuthProviderRepository
+func NewPostgresOAuthProviderRepository(pool *pgxpool.Pool) *PostgresOAuthProviderRepository {
+	return &PostgresOAuthProviderRepository{pool: pool}
+}
+
+const oauthProviderColumns = `tenant_id::text, provider, client_id, client_secret, redirect_url, enabled,
+		       COALESCE(updated_by::text, '') as updated_by, created_at, updated_at`
+
+// Get retrieves a tenant's client for a provider
+func (r *PostgresOAuthProviderRepository) Get(ctx context.Context, tenantID, provider string) (*domain.OAuthProviderConfig, error) {
+	query := `
+		SELECT ` + oauthProviderColumns + `
+		FROM tenant_oauth_providers
+		WHERE tenant_id = $1 AND provider = $2
+	`
+	config, err := scanOAuthProvider(r.pool.QueryRow(ctx, query, tenantID, provider))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return config, nil
+}
+
+// ListByTenantID lists a tenant's clients
+func (r *PostgresOAuthProviderRepository) ListByTenantID(ctx context.Context, tenantID string) ([]*domain.OAuthProviderConfig, error) {
+	query := `
+		SELECT ` + oauthProviderColumns + `
+		FROM tenant_oauth_providers
+		WHERE tenant_id = $1
+		ORDER BY provider
+	`
+	rows, err := r.pool.Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var configs []*domain.OAuthProviderConfig
+	for rows.Next() {
+		config, err := scanOAuthProvider(rows)
+		if err != nil {
+			return nil, err
+		}
+		configs = append(configs, config)
+	}
+	return configs, rows.Err()
+}
+
+// Save inserts or replaces a tenant's client
+func (r *PostgresOAuthProviderRepository) Save(ctx context.Context, config *domain.OAuthProviderConfig) error {
+	query := `
+		INSERT INTO tenant_oauth_providers (tenant_id, provider, client_id, client_secret, redirect_url, enabled, updated_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, '')::uuid, NOW(), NOW())
+		ON CONFLICT (tenant_id, provider) DO UPDATE
+		SET client_id = EXCLUDED.client_id,
+		    client_secret = EXCLUDED.client_secret,
+		    redirect_url = EXCLUDED.redirect_url,
+		    enabled = EXCLUDED.enabled,
+		    updated_by = EXCLUDED.updated_by,
+		    updated_at = NOW()
+		RETURNING created_at, updated_at
+	`
+	return r.pool.QueryRow(ctx, query,
+		config.TenantID,
+		config.Provider,
+		config.ClientID,
+		config.ClientSecret,
+		config.RedirectURL,
+		config.Enabled,
+		config.UpdatedBy,
+	).Scan(&config.CreatedAt, &config.UpdatedAt)
+}
+
+// Delete removes a tenant's client
+func (r *PostgresOAuthProviderRepository) Delete(ctx context.Context, tenantID, provider string) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM tenant_oauth_providers WHERE tenant_id = $1 AND provider = $2`, tenantID, provider)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func scanOAuthProvider(row pgx.Row) (*domain.OAuthProviderConfig, error) {
+	config := &domain.OAuthProviderConfig{}
+	err := row.Scan(
+		&config.TenantID,
+		&config.Provider,
+		&config.ClientID,
+		&config.ClientSecret,
+		&config.RedirectURL,
+		&config.Enabled,
+		&config.UpdatedBy,
+		&config.CreatedAt,
+		&config.UpdatedAt,
+	)
+	return config, err
+}
+
+// PostgresUserIdentityRepository implements UserIdentityRepository using PostgreSQL
+type PostgresUserIdentityRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresUserIdentityRepository creates a new PostgresUserIdentityRepository
+func NewPostgresUserIdentityRepository(pool *pgxpool.Pool) *PostgresUserIdentityRepository {
+	return &PostgresUserIdentityRepository{pool: pool}
+}
+
+// Create links a provider account to a user
+func (r *PostgresUserIdentityRepository) Create(ctx context.Context, identity *domain.UserIdentity) error {
+	query := `
+		INSERT INTO user_identities (id, user_id, provider, subject, email, created_at, last_login_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7)
+	`
+	_, err := r.pool.Exec(ctx, query,
+		identity.ID,
+		identity.UserID,
+		identity.Provider,
+		identity.Subject,
+		identity.Email,
+		identity.CreatedAt,
+		identity.LastLoginAt,
+	)
+	return err
+}
+
+// GetBySubject retrieves the link of a provider account
+func (r *PostgresUserIdentityRepository) GetBySubject(ctx context.Context, provider, subject string) (*domain.UserIdentity, error) {
+	query := `
+		SELECT id, user_id, provider, subject, COALESCE(email, ''), created_at, last_login_at
+		FROM user_identities
+		WHERE provider = $1 AND subject = $2
+	`
+	identity := &domain.UserIdentity{}
+	err := r.pool.QueryRow(ctx, query, provider, subject).Scan(
+		&identity.ID,
+		&identity.UserID,
+		&identity.Provider,
+		&identity.Subject,
+		&identity.Email,
+		&identity.CreatedAt,
+		&identity.LastLoginAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return identity, nil
+}
+
+// ListByUserID lists the provider accounts linked to a user
+func (r *PostgresUserIdentityRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.UserIdentity, error) {
+	query := `
+		SELECT id, user_id, provider, subject, COALESCE(email, ''), created_at, last_login_at
+		FROM user_identities
+		WHERE user_id = $1
+		ORDER BY created_at
+	`
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var identities []*domain.UserIdentity
+	for rows.Next() {
+		identity := &domain.UserIdentity{}
+		if err := rows.Scan(
+			&identity.ID,
+			&identity.UserID,
+			&identity.Provider,
+			&identity.Subject,
+			&identity.Email,
+			&identity.CreatedAt,
+			&identity.LastLoginAt,
+		); err != nil {
+			return nil, err
+		}
+		identities = append(identities, identity)
+	}
+	return identities, rows.Err()
+}
+
+// TouchLogin records a sign-in with a linked provider account
+func (r *PostgresUserIdentityRepository) TouchLogin(ctx context.Context, id string, at time.Time) error {
+	_, err := r.pool.Exec(ctx, `UPDATE user_identities SET last_login_at = $2 WHERE id = $1`, id, at)
+	return err
+}
+
+// DeleteByUserID removes all provider accounts linked to a user
+func (r *PostgresUserIdentityRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM user_identities WHERE user_id = $1`, userID)
+	return err
+}
@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+)
+
+// PostgresPurchaseRepository implements PurchaseRepository using PostgreSQL
+type PostgresPurchaseRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresPurchaseRepository creates a new PostgresPurchaseRepository
+func NewPostgresPurchaseRepository(pool *pgxpool.Pool) *PostgresPurchaseRepository {
+	return &PostgresPurchaseRepository{pool: pool}
+}
+
+// ApplyBookingEvent creates or updates a purchase from a booking event
+func (r *PostgresPurchaseRepository) ApplyBookingEvent(ctx context.Context, update *domain.PurchaseBookingUpdate, insert bool) error {
+	query := `
+		UPDATE user_purchases SET
+			quantity = $5,
+			booking_amount = $6,
+			currency = $7,
+			status = $8,
+			confirmed_at = COALESCE($9, confirmed_at),
+			booking_event_at = $10,
+			updated_at = NOW()
+		WHERE booking_id = $1 AND (booking_event_at IS NULL OR booking_event_at < $10)
+	`
+	if insert {
+		query = `
+			INSERT INTO user_purchases (booking_id, user_id, event_id, show_id, quantity, booking_amount,
+				currency, status, confirmed_at, booking_event_at)
+			VALUES ($1, $2, NULLIF($3, '')::uuid, NULLIF($4, '')::uuid, $5, $6, $7, $8, $9, $10)
+			ON CONFLICT (booking_id) DO UPDATE SET
+				user_id = EXCLUDED.user_id,
+				event_id = EXCLUDED.event_id,
+				show_id = COALESCE(EXCLUDED.show_id, user_purchases.show_id),
+				quantity = EXCLUDED.quantity,
+				booking_amount = EXCLUDED.booking_amount,
+				currency = EXCLUDED.currency,
+				status = EXCLUDED.status,
+				confirmed_at = COALESCE(EXCLUDED.confirmed_at, user_purchases.confirmed_at),
+				booking_event_at = EXCLUDED.booking_event_at,
+				updated_at = NOW()
+			WHERE user_purchases.booking_event_at IS NULL OR user_purchases.booking_event_at < EXCLUDED.booking_event_at
+		`
+	}
+
+	_, err := r.pool.Exec(ctx, query,
+		update.BookingID,
+		update.UserID,
+		update.EventID,
+		update.ShowID,
+		update.Quantity,
+		update.Amount,
+		update.Currency,
+		update.Status,
+		update.ConfirmedAt,
+		update.OccurredAt,
+	)
+	return err
+}
+
+// ApplyPaymentEvent creates or updates a purchase from a payment event. Refunds only
+// grow, so the largest refunded total seen wins whatever order events arrive in.
+func (r *PostgresPurchaseRepository) ApplyPaymentEvent(ctx context.Context, update *domain.PurchasePaymentUpdate) error {
+	query := `
+		INSERT INTO user_purchases (booking_id, user_id, currency, paid_amount, refunded_amount, payment_event_at)
+		VALUES ($1, $2, $3, $4, COALESCE($5::numeric, 0), $6)
+		ON CONFLICT (booking_id) DO UPDATE SET
+			paid_amount = COALESCE(EXCLUDED.paid_amount, user_purchases.paid_amount),
+			refunded_amount = GREATEST(user_purchases.refunded_amount, EXCLUDED.refunded_amount),
+			payment_event_at = GREATEST(user_purchases.payment_event_at, EXCLUDED.payment_event_at),
+			updated_at = NOW()
+	`
+	_, err := r.pool.Exec(ctx, query,
+		update.BookingID,
+		update.UserID,
+		update.Currency,
+		update.Paid,
+		update.Refunded,
+		update.OccurredAt,
+	)
+	return err
+}
+
+// ListByUserID lists a user's purchases with their show start times, most recently confirmed first
+func (r *PostgresPurchaseRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.Purchase, error) {
+	query := `
+		SELECT p.booking_id, p.user_id, COALESCE(p.event_id::text, ''), COALESCE(p.show_id::text, ''),
+			p.quantity, p.booking_amount, p.currency, p.status, p.confirmed_at,
+			p.paid_amount, p.refunded_amount, s.starts_at
+		FROM user_purchases p
+		LEFT JOIN purchase_shows s ON s.show_id = p.show_id
+		WHERE p.user_id = $1
+		ORDER BY p.confirmed_at DESC NULLS LAST
+	`
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var purchases []*domain.Purchase
+	for rows.Next() {
+		var purchase domain.Purchase
+		if err := rows.Scan(
+			&purchase.BookingID,
+			&purchase.UserID,
+			&purchase.EventID,
+			&purchase.ShowID,
+			&purchase.Quantity,
+			&purchase.BookingAmount,
+			&purchase.Currency,
+			&purchase.Status,
+			&purchase.ConfirmedAt,
+			&purchase.PaidAmount,
+			&purchase.RefundedAmount,
+			&purchase.ShowStartsAt,
+		); err != nil {
+			return nil, err
+		}
+		purchases = append(purchases, &purchase)
+	}
+	return purchases, rows.Err()
+}
+
+// HasShowStartTime reports whether a show's start time is already resolved
+func (r *PostgresPurchaseRepository) HasShowStartTime(ctx context.Context, showID string) (bool, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM purchase_shows WHERE show_id = $1)`, showID).Scan(&exists)
+	return exists, err
+}
+
+// SaveShowStartTime records a show's start time, replacing a rescheduled one
+func (r *PostgresPurchaseRepository) SaveShowStartTime(ctx context.Context, showID string, startsAt time.Time) error {
+	query := `
+		INSERT INTO purchase_shows (show_id, starts_at, resolved_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (show_id) DO UPDATE SET
+			starts_at = EXCLUDED.starts_at,
+			resolved_at = EXCLUDED.resolved_at
+	`
+	_, err := r.pool.Exec(ctx, query, showID, startsAt)
+	return err
+}
+
+// DeleteByUserID removes a user's purchase history
+func (r *PostgresPurchaseRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM user_purchases WHERE user_id = $1`, userID)
+	return err
+}
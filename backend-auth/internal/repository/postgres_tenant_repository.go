@@ -9,6 +9,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 )
 
 // PostgresTenantRepository implements TenantRepository using PostgreSQL
@@ -102,7 +103,7 @@ func (r *PostgresTenantRepository) GetBySlug(ctx context.Context, slug string) (
 }
 
 // List retrieves tenants with pagination and filters
-func (r *PostgresTenantRepository) List(ctx context.Context, page, limit int, isActive *bool, search string) ([]*domain.Tenant, int, error) {
+func (r *PostgresTenantRepository) List(ctx context.Context, page pagination.Params, isActive *bool, search string) ([]*domain.Tenant, int, error) {
 	// Build WHERE clause
 	whereClause := "WHERE deleted_at IS NULL"
 	args := []interface{}{}
@@ -128,18 +129,24 @@ func (r *PostgresTenantRepository) List(ctx context.Context, page, limit int, is
 		return nil, 0, err
 	}
 
+	// The cursor narrows the page but not the total
+	if page.After != nil {
+		whereClause += " AND " + pagination.Keyset("created_at", "id", argIndex)
+		args = append(args, page.After.Time, page.After.ID)
+		argIndex += 2
+	}
+
 	// Get paginated records
-	offset := (page - 1) * limit
 	query := fmt.Sprintf(`
 		SELECT id, name, slug, COALESCE(domain, '') as domain, COALESCE(logo_url, '') as logo_url,
 		       COALESCE(settings, '{}'::jsonb) as settings, is_active, created_at, updated_at, deleted_at
 		FROM tenants
 		%s
-		ORDER BY created_at DESC
+		ORDER BY created_at DESC, id DESC
 		LIMIT $%d OFFSET $%d
 	`, whereClause, argIndex, argIndex+1)
 
-	args = append(args, limit, offset)
+	args = append(args, page.Limit, page.Offset)
 
 	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
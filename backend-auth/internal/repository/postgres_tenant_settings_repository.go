@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+)
+
+// PostgresTenantSettingsRepository implements TenantSettingsRepository using PostgreSQL
+type PostgresTenantSettingsRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresTenantSettingsRepository creates a new PostgresTenantSettingsRepository
+func NewPostgresTenantSettingsRepository(pool *pgxpool.Pool) *PostgresTenantSettingsRepository {
+	return &PostgresTenantSettingsRepository{pool: pool}
+}
+
+// GetByTenantID retrieves the saved settings of a tenant
+func (r *PostgresTenantSettingsRepository) GetByTenantID(ctx context.Context, tenantID string) (*domain.TenantSettings, error) {
+	query := `
+		SELECT tenant_id, currency, max_tickets_per_user, plan, queue, branding, sender, version,
+		       COALESCE(updated_by::text, '') as updated_by, created_at, updated_at
+		FROM tenant_settings
+		WHERE tenant_id = $1
+	`
+	settings := &domain.TenantSettings{}
+	err := r.pool.QueryRow(ctx, query, tenantID).Scan(
+		&settings.TenantID,
+		&settings.Currency,
+		&settings.MaxTicketsPerUser,
+		&settings.Plan,
+		&settings.Queue,
+		&settings.Branding,
+		&settings.Sender,
+		&settings.Version,
+		&settings.UpdatedBy,
+		&settings.CreatedAt,
+		&settings.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return settings, nil
+}
+
+// Save inserts or replaces the settings, bumping the version on every write
+func (r *PostgresTenantSettingsRepository) Save(ctx context.Context, settings *domain.TenantSettings, expectedVersion int64) error {
+	query := `
+		INSERT INTO tenant_settings (tenant_id, currency, max_tickets_per_user, plan, queue, branding, sender, version, updated_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, 1, $8, NOW(), NOW())
+		ON CONFLICT (tenant_id) DO UPDATE
+		SET currency = EXCLUDED.currency,
+		    max_tickets_per_user = EXCLUDED.max_tickets_per_user,
+		    plan = EXCLUDED.plan,
+		    queue = EXCLUDED.queue,
+		    branding = EXCLUDED.branding,
+		    sender = EXCLUDED.sender,
+		    updated_by = EXCLUDED.updated_by,
+		    version = tenant_settings.version + 1,
+		    updated_at = NOW()
+		WHERE $9 = 0 OR tenant_settings.version = $9
+		RETURNING version, created_at, updated_at
+	`
+	err := r.pool.QueryRow(ctx, query,
+		settings.TenantID,
+		settings.Currency,
+		settings.MaxTicketsPerUser,
+		settings.Plan,
+		settings.Queue,
+		settings.Branding,
+		settings.Sender,
+		nullStringOrValue(settings.UpdatedBy),
+		expectedVersion,
+	).Scan(&settings.Version, &settings.CreatedAt, &settings.UpdatedAt)
+	if err != nil {
+		// The conflict target exists but the version check filtered the update out
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrTenantSettingsVersionConflict
+		}
+		return err
+	}
+	return nil
+}
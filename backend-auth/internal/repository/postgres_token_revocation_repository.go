@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresTokenRevocationRepository implements TokenRevocationRepository using PostgreSQL
+type PostgresTokenRevocationRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresTokenRevocationRepository creates a new PostgresTokenRevocationRepository
+func NewPostgresTokenRevocationRepository(pool *pgxpool.Pool) *PostgresTokenRevocationRepository {
+	return &PostgresTokenRevocationRepository{pool: pool}
+}
+
+// Revoke records the latest revocation of a user's tokens
+func (r *PostgresTokenRevocationRepository) Revoke(ctx context.Context, userID string, at time.Time) error {
+	query := `
+		INSERT INTO token_revocations (user_id, revoked_at)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET
+			revoked_at = GREATEST(token_revocations.revoked_at, EXCLUDED.revoked_at)
+	`
+	_, err := r.pool.Exec(ctx, query, userID, at)
+	return err
+}
+
+// GetRevokedAt returns when the user's tokens were last revoked, or the zero time
+func (r *PostgresTokenRevocationRepository) GetRevokedAt(ctx context.Context, userID string) (time.Time, error) {
+	var revokedAt time.Time
+	err := r.pool.QueryRow(ctx, `SELECT revoked_at FROM token_revocations WHERE user_id = $1`, userID).Scan(&revokedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	return revokedAt, err
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -49,7 +50,8 @@ func (r *PostgresUserRepository) Create(ctx context.Context, user *domain.User)
 // GetByID retrieves a user by ID
 func (r *PostgresUserRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
 	query := `
-		SELECT id, email, password_hash, COALESCE(first_name, '') as first_name, role, COALESCE(tenant_id::text, '') as tenant_id, COALESCE(stripe_customer_id, '') as stripe_customer_id, is_active, created_at, updated_at
+		SELECT id, email, password_hash, COALESCE(first_name, '') as first_name, role, COALESCE(tenant_id::text, '') as tenant_id, COALESCE(stripe_customer_id, '') as stripe_customer_id, is_active, created_at, updated_at,
+			COALESCE(phone, '') as phone, COALESCE(email_verified, false) as email_verified, email_verified_at, phone_verified_at, COALESCE(risk_level, 'normal') as risk_level, verified_at
 		FROM users
 		WHERE id = $1
 	`
@@ -65,6 +67,12 @@ func (r *PostgresUserRepository) GetByID(ctx context.Context, id string) (*domai
 		&user.IsActive,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.Phone,
+		&user.EmailVerified,
+		&user.EmailVerifiedAt,
+		&user.PhoneVerifiedAt,
+		&user.RiskLevel,
+		&user.VerifiedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -78,7 +86,8 @@ func (r *PostgresUserRepository) GetByID(ctx context.Context, id string) (*domai
 // GetByEmail retrieves a user by email
 func (r *PostgresUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	query := `
-		SELECT id, email, password_hash, COALESCE(first_name, '') as first_name, role, COALESCE(tenant_id::text, '') as tenant_id, COALESCE(stripe_customer_id, '') as stripe_customer_id, is_active, created_at, updated_at
+		SELECT id, email, password_hash, COALESCE(first_name, '') as first_name, role, COALESCE(tenant_id::text, '') as tenant_id, COALESCE(stripe_customer_id, '') as stripe_customer_id, is_active, created_at, updated_at,
+			COALESCE(phone, '') as phone, COALESCE(email_verified, false) as email_verified, email_verified_at, phone_verified_at, COALESCE(risk_level, 'normal') as risk_level, verified_at
 		FROM users
 		WHERE email = $1
 	`
@@ -94,6 +103,12 @@ func (r *PostgresUserRepository) GetByEmail(ctx context.Context, email string) (
 		&user.IsActive,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.Phone,
+		&user.EmailVerified,
+		&user.EmailVerifiedAt,
+		&user.PhoneVerifiedAt,
+		&user.RiskLevel,
+		&user.VerifiedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -148,9 +163,115 @@ func (r *PostgresUserRepository) ExistsByEmail(ctx context.Context, email string
 	return exists, err
 }
 
+// ListByEmail retrieves users by email (case-insensitive), optionally within a tenant
+func (r *PostgresUserRepository) ListByEmail(ctx context.Context, email, tenantID string) ([]*domain.User, error) {
+	query := `
+		SELECT id, email, password_hash, COALESCE(first_name, '') as first_name, role, COALESCE(tenant_id::text, '') as tenant_id, COALESCE(stripe_customer_id, '') as stripe_customer_id, is_active, created_at, updated_at,
+			COALESCE(phone, '') as phone, COALESCE(email_verified, false) as email_verified, email_verified_at, phone_verified_at, COALESCE(risk_level, 'normal') as risk_level, verified_at
+		FROM users
+		WHERE lower(email) = lower($1)
+		  AND ($2 = '' OR tenant_id::text = $2)
+		ORDER BY created_at
+	`
+	rows, err := r.pool.Query(ctx, query, email, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*domain.User
+	for rows.Next() {
+		user := &domain.User{}
+		if err := rows.Scan(
+			&user.ID,
+			&user.Email,
+			&user.PasswordHash,
+			&user.Name,
+			&user.Role,
+			&user.TenantID,
+			&user.StripeCustomerID,
+			&user.IsActive,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+			&user.Phone,
+			&user.EmailVerified,
+			&user.EmailVerifiedAt,
+			&user.PhoneVerifiedAt,
+			&user.RiskLevel,
+			&user.VerifiedAt,
+		); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
 // UpdateStripeCustomerID updates the Stripe Customer ID for a user
 func (r *PostgresUserRepository) UpdateStripeCustomerID(ctx context.Context, userID, stripeCustomerID string) error {
 	query := `UPDATE users SET stripe_customer_id = $2, updated_at = $3 WHERE id = $1`
 	_, err := r.pool.Exec(ctx, query, userID, stripeCustomerID, time.Now())
 	return err
 }
+
+// MarkVerified records a passed verification challenge. Verifying the phone channel also
+// stores the phone number the code was sent to.
+func (r *PostgresUserRepository) MarkVerified(ctx context.Context, userID string, channel domain.VerificationChannel, destination string, at time.Time) error {
+	var query string
+	switch channel {
+	case domain.VerificationChannelEmail:
+		query = `
+			UPDATE users
+			SET email_verified = true, email_verified_at = $2, verified_at = $2, updated_at = NOW()
+			WHERE id = $1
+		`
+	case domain.VerificationChannelPhone:
+		query = `
+			UPDATE users
+			SET phone = $3, phone_verified_at = $2, verified_at = $2, updated_at = NOW()
+			WHERE id = $1
+		`
+	default:
+		return fmt.Errorf("unknown verification channel: %s", channel)
+	}
+	_, err := r.pool.Exec(ctx, query, userID, at, destination)
+	return err
+}
+
+// UpdateRiskLevel updates the risk level of a user
+func (r *PostgresUserRepository) UpdateRiskLevel(ctx context.Context, userID string, level domain.RiskLevel) error {
+	query := `UPDATE users SET risk_level = $2, updated_at = $3 WHERE id = $1`
+	_, err := r.pool.Exec(ctx, query, userID, level, time.Now())
+	return err
+}
+
+// Anonymize irreversibly replaces a user's personal data and deactivates the account.
+// The row is kept (soft-deleted) so references from other services stay resolvable.
+func (r *PostgresUserRepository) Anonymize(ctx context.Context, id string) error {
+	query := `
+		WITH deleted_challenges AS (
+			DELETE FROM verification_challenges WHERE user_id = $1
+		)
+		UPDATE users
+		SET email = 'deleted-' || id::text || '@anonymized.invalid',
+			password_hash = '',
+			name = NULL,
+			first_name = NULL,
+			last_name = NULL,
+			phone = NULL,
+			avatar_url = NULL,
+			stripe_customer_id = NULL,
+			metadata = '{}',
+			email_verified = false,
+			email_verified_at = NULL,
+			phone_verified_at = NULL,
+			verified_at = NULL,
+			last_login_at = NULL,
+			is_active = false,
+			deleted_at = COALESCE(deleted_at, NOW()),
+			updated_at = NOW()
+		WHERE id = $1
+	`
+	_, err := r.pool.Exec(ctx, query, id)
+	return err
+}
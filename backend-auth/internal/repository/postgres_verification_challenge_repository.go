@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+)
+
+// PostgresVerificationChallengeRepository implements VerificationChallengeRepository using PostgreSQL
+type PostgresVerificationChallengeRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresVerificationChallengeRepository creates a new PostgresVerificationChallengeRepository
+func NewPostgresVerificationChallengeRepository(pool *pgxpool.Pool) *PostgresVerificationChallengeRepository {
+	return &PostgresVerificationChallengeRepository{pool: pool}
+}
+
+// Create creates a new challenge
+func (r *PostgresVerificationChallengeRepository) Create(ctx context.Context, challenge *domain.VerificationChallenge) error {
+	query := `
+		INSERT INTO verification_challenges (id, user_id, channel, destination, code_hash, attempts, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err := r.pool.Exec(ctx, query,
+		challenge.ID,
+		challenge.UserID,
+		challenge.Channel,
+		challenge.Destination,
+		challenge.CodeHash,
+		challenge.Attempts,
+		challenge.CreatedAt,
+		challenge.ExpiresAt,
+	)
+	return err
+}
+
+// GetByID retrieves a challenge by ID
+func (r *PostgresVerificationChallengeRepository) GetByID(ctx context.Context, id string) (*domain.VerificationChallenge, error) {
+	query := `
+		SELECT id, user_id, channel, destination, code_hash, attempts, created_at, expires_at, verified_at
+		FROM verification_challenges
+		WHERE id = $1
+	`
+	challenge := &domain.VerificationChallenge{}
+	err := r.pool.QueryRow(ctx, query, id).Scan(
+		&challenge.ID,
+		&challenge.UserID,
+		&challenge.Channel,
+		&challenge.Destination,
+		&challenge.CodeHash,
+		&challenge.Attempts,
+		&challenge.CreatedAt,
+		&challenge.ExpiresAt,
+		&challenge.VerifiedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return challenge, nil
+}
+
+// Update updates the attempts and verified_at of a challenge
+func (r *PostgresVerificationChallengeRepository) Update(ctx context.Context, challenge *domain.VerificationChallenge) error {
+	query := `UPDATE verification_challenges SET attempts = $2, verified_at = $3 WHERE id = $1`
+	_, err := r.pool.Exec(ctx, query, challenge.ID, challenge.Attempts, challenge.VerifiedAt)
+	return err
+}
+
+// CountSince counts the challenges a user started since the given time
+func (r *PostgresVerificationChallengeRepository) CountSince(ctx context.Context, userID string, since time.Time) (int, error) {
+	query := `SELECT COUNT(*) FROM verification_challenges WHERE user_id = $1 AND created_at >= $2`
+	var count int
+	err := r.pool.QueryRow(ctx, query, userID, since).Scan(&count)
+	return count, err
+}
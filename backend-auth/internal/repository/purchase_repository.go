@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+)
+
+// PurchaseRepository defines the interface for the purchase history projection
+type PurchaseRepository interface {
+	// ApplyBookingEvent creates or updates a purchase from a booking event. Events older
+	// than the last one applied to the purchase are ignored. With insert false, only a
+	// known purchase is updated (e.g. a cancellation of a booking never confirmed).
+	ApplyBookingEvent(ctx context.Context, update *domain.PurchaseBookingUpdate, insert bool) error
+	// ApplyPaymentEvent creates or updates a purchase from a payment event
+	ApplyPaymentEvent(ctx context.Context, update *domain.PurchasePaymentUpdate) error
+	// ListByUserID lists a user's purchases with their show start times
+	ListByUserID(ctx context.Context, userID string) ([]*domain.Purchase, error)
+	// HasShowStartTime reports whether a show's start time is already resolved
+	HasShowStartTime(ctx context.Context, showID string) (bool, error)
+	// SaveShowStartTime records a show's start time
+	SaveShowStartTime(ctx context.Context, showID string, startsAt time.Time) error
+	// DeleteByUserID removes a user's purchase history
+	DeleteByUserID(ctx context.Context, userID string) error
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 )
 
 // TenantRepository defines the interface for tenant data access
@@ -15,7 +16,7 @@ type TenantRepository interface {
 	// GetBySlug retrieves a tenant by slug
 	GetBySlug(ctx context.Context, slug string) (*domain.Tenant, error)
 	// List retrieves tenants with pagination and filters
-	List(ctx context.Context, page, limit int, isActive *bool, search string) ([]*domain.Tenant, int, error)
+	List(ctx context.Context, page pagination.Params, isActive *bool, search string) ([]*domain.Tenant, int, error)
 	// Update updates a tenant
 	Update(ctx context.Context, tenant *domain.Tenant) error
 	// SoftDelete soft deletes a tenant
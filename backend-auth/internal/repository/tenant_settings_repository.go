@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+)
+
+// ErrTenantSettingsVersionConflict is returned when settings changed since the expected version
+var ErrTenantSettingsVersionConflict = errors.New("tenant settings version conflict")
+
+// TenantSettingsRepository defines the interface for tenant settings persistence
+type TenantSettingsRepository interface {
+	// GetByTenantID retrieves the saved settings of a tenant (nil if none are saved)
+	GetByTenantID(ctx context.Context, tenantID string) (*domain.TenantSettings, error)
+	// Save inserts or replaces the settings and sets their new Version and UpdatedAt.
+	// A non-zero expectedVersion must match the stored version.
+	Save(ctx context.Context, settings *domain.TenantSettings, expectedVersion int64) error
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// TokenRevocationRepository records when a user's access tokens were last revoked
+type TokenRevocationRepository interface {
+	// Revoke records that the user's tokens issued before at are no longer valid
+	Revoke(ctx context.Context, userID string, at time.Time) error
+	// GetRevokedAt returns when the user's tokens were last revoked, or the zero time
+	GetRevokedAt(ctx context.Context, userID string) (time.Time, error)
+}
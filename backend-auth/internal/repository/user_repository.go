@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
 )
@@ -22,6 +23,27 @@ type UserRepository interface {
 	ExistsByEmail(ctx context.Context, email string) (bool, error)
 	// UpdateStripeCustomerID updates the Stripe Customer ID for a user
 	UpdateStripeCustomerID(ctx context.Context, userID, stripeCustomerID string) error
+	// Anonymize irreversibly replaces a user's personal data and deactivates the account
+	Anonymize(ctx context.Context, id string) error
+	// ListByEmail retrieves users by email (case-insensitive) across tenants, or within
+	// tenantID if set. An email is unique per tenant, so it can match several users.
+	ListByEmail(ctx context.Context, email, tenantID string) ([]*domain.User, error)
+	// MarkVerified records a passed verification challenge on the given channel
+	MarkVerified(ctx context.Context, userID string, channel domain.VerificationChannel, destination string, at time.Time) error
+	// UpdateRiskLevel updates the risk level of a user
+	UpdateRiskLevel(ctx context.Context, userID string, level domain.RiskLevel) error
+}
+
+// VerificationChallengeRepository defines the interface for verification challenge data access
+type VerificationChallengeRepository interface {
+	// Create creates a new challenge
+	Create(ctx context.Context, challenge *domain.VerificationChallenge) error
+	// GetByID retrieves a challenge by ID
+	GetByID(ctx context.Context, id string) (*domain.VerificationChallenge, error)
+	// Update updates the attempts and verified_at of a challenge
+	Update(ctx context.Context, challenge *domain.VerificationChallenge) error
+	// CountSince counts the challenges a user started since the given time
+	CountSince(ctx context.Context, userID string, since time.Time) (int, error)
 }
 
 // SessionRepository defines the interface for session data access
@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -12,6 +13,7 @@ import (
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/dto"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/middleware"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -25,15 +27,19 @@ var (
 	ErrUserInactive       = errors.New("user is inactive")
 	ErrInvalidToken       = errors.New("invalid token")
 	ErrTokenExpired       = errors.New("token expired")
+	ErrTokenRevoked       = errors.New("token revoked")
 	ErrSessionNotFound    = errors.New("session not found")
+	ErrEmailRequired      = errors.New("email is required")
 )
 
 // AuthServiceConfig holds configuration for AuthService
 type AuthServiceConfig struct {
-	JWTSecret          string
-	AccessTokenExpiry  time.Duration
-	RefreshTokenExpiry time.Duration
-	BcryptCost         int
+	JWTSecret           string
+	JWTIssuer           string // Access tokens are issued under it for the user's tenant (empty omits tenant claims)
+	RequireTenantClaims bool   // Rejects access tokens without tenant claims
+	AccessTokenExpiry   time.Duration
+	RefreshTokenExpiry  time.Duration
+	BcryptCost          int
 }
 
 // AuthService defines the interface for authentication operations
@@ -58,6 +64,11 @@ type AuthService interface {
 	GetStripeCustomerID(ctx context.Context, userID string) (string, error)
 	// UpdateStripeCustomerID updates the Stripe Customer ID for a user
 	UpdateStripeCustomerID(ctx context.Context, userID, stripeCustomerID string) error
+	// LookupUsersByEmail finds the users registered with an email, optionally within a tenant
+	LookupUsersByEmail(ctx context.Context, email, tenantID string) ([]*domain.User, error)
+	// IssueSession signs tokens for a user authenticated by other means (e.g. social login)
+	// and starts a session, as a successful Login does
+	IssueSession(ctx context.Context, user *domain.User, userAgent, ip string) (*dto.AuthResponse, error)
 }
 
 // authService implements AuthService
@@ -186,14 +197,48 @@ func (s *authService) Login(ctx context.Context, req *dto.LoginRequest, userAgen
 		return nil, ErrInvalidCredentials
 	}
 
-	// Generate tokens
-	tokenPair, err := s.generateTokenPair(user)
+	result, err := s.startSession(ctx, user, userAgent, ip)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.String("user_id", user.ID))
+	span.SetStatus(codes.Ok, "")
+	return result, nil
+}
+
+// IssueSession signs tokens for a user authenticated by other means and starts a session
+func (s *authService) IssueSession(ctx context.Context, user *domain.User, userAgent, ip string) (*dto.AuthResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.auth.issue_session")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", user.ID))
+
+	if !user.IsActive {
+		span.SetStatus(codes.Error, "user inactive")
+		return nil, ErrUserInactive
+	}
+
+	result, err := s.startSession(ctx, user, userAgent, ip)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 		return nil, err
 	}
 
+	span.SetStatus(codes.Ok, "")
+	return result, nil
+}
+
+// startSession generates a token pair for the user and stores its refresh token as a new session
+func (s *authService) startSession(ctx context.Context, user *domain.User, userAgent, ip string) (*dto.AuthResponse, error) {
+	tokenPair, err := s.generateTokenPair(user)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create session
 	session := &domain.Session{
 		ID:           uuid.New().String(),
@@ -206,14 +251,9 @@ func (s *authService) Login(ctx context.Context, req *dto.LoginRequest, userAgen
 	}
 
 	if err := s.sessionRepo.Create(ctx, session); err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
 		return nil, err
 	}
 
-	span.SetAttributes(attribute.String("user_id", user.ID))
-	span.SetStatus(codes.Ok, "")
-
 	return &dto.AuthResponse{
 		AccessToken:  tokenPair.AccessToken,
 		RefreshToken: tokenPair.RefreshToken,
@@ -380,16 +420,44 @@ func (s *authService) ValidateToken(ctx context.Context, tokenString string) (*d
 		tenantID = tid
 	}
 
+	// A token must have been minted for the tenant it claims
+	var audience []string
+	if s.config.JWTIssuer != "" {
+		isolation := &middleware.TenantIsolation{Issuer: s.config.JWTIssuer, RequireClaims: s.config.RequireTenantClaims}
+		if audience, err = isolation.Verify(claims, tenantID); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "invalid tenant claims")
+			return nil, ErrInvalidToken
+		}
+	}
+
 	userID := claims["user_id"].(string)
 	span.SetAttributes(attribute.String("user_id", userID))
 	span.SetStatus(codes.Ok, "")
 
-	return &domain.Claims{
+	result := &domain.Claims{
 		UserID:   userID,
 		Email:    claims["email"].(string),
 		Role:     domain.Role(claims["role"].(string)),
 		TenantID: tenantID,
-	}, nil
+		Audience: audience,
+	}
+	if iat, ok := claims["iat"].(float64); ok {
+		result.IssuedAt = time.Unix(int64(iat), 0)
+	}
+	if exp, ok := claims["exp"].(float64); ok {
+		result.ExpiresAt = time.Unix(int64(exp), 0)
+	}
+
+	verification := middleware.VerificationFromClaims(claims)
+	result.EmailVerified = verification.EmailVerified
+	result.PhoneVerified = verification.PhoneVerified
+	result.RiskLevel = domain.RiskLevel(verification.RiskLevel)
+	if !verification.VerifiedAt.IsZero() {
+		result.VerifiedAt = &verification.VerifiedAt
+	}
+
+	return result, nil
 }
 
 // GetUser retrieves user by ID
@@ -445,18 +513,7 @@ func (s *authService) UpdateProfile(ctx context.Context, userID string, req *dto
 
 // generateTokenPair generates access and refresh tokens
 func (s *authService) generateTokenPair(user *domain.User) (*domain.TokenPair, error) {
-	// Generate access token
-	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"sub":       user.ID, // Standard JWT subject claim
-		"user_id":   user.ID,
-		"email":     user.Email,
-		"role":      string(user.Role),
-		"tenant_id": user.TenantID,
-		"exp":       time.Now().Add(s.config.AccessTokenExpiry).Unix(),
-		"iat":       time.Now().Unix(),
-	})
-
-	accessTokenString, err := accessToken.SignedString([]byte(s.config.JWTSecret))
+	accessTokenString, err := signAccessToken(s.config.JWTSecret, s.config.JWTIssuer, user, s.config.AccessTokenExpiry)
 	if err != nil {
 		return nil, err
 	}
@@ -475,6 +532,48 @@ func (s *authService) generateTokenPair(user *domain.User) (*domain.TokenPair, e
 	}, nil
 }
 
+// signAccessToken signs an access token for the user, valid for expiry.
+// With an issuer, the token is issued by the user's tenant and only valid for that tenant.
+func signAccessToken(secret, issuer string, user *domain.User, expiry time.Duration) (string, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"sub":       user.ID, // Standard JWT subject claim
+		"user_id":   user.ID,
+		"email":     user.Email,
+		"role":      string(user.Role),
+		"tenant_id": user.TenantID,
+		"exp":       now.Add(expiry).Unix(),
+		"iat":       now.Unix(),
+	}
+	// Verification state is enforced by booking-service, so it changes only on token refresh
+	for name, value := range userVerification(user).JWTClaims() {
+		claims[name] = value
+	}
+	if issuer != "" {
+		for name, value := range middleware.TenantClaims(issuer, user.TenantID, string(user.Role)) {
+			claims[name] = value
+		}
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+}
+
+// userVerification returns the verification state of a user as issued in access tokens
+func userVerification(user *domain.User) *middleware.Verification {
+	v := &middleware.Verification{
+		EmailVerified: user.EmailVerified,
+		PhoneVerified: user.PhoneVerifiedAt != nil,
+		RiskLevel:     string(user.RiskLevel),
+	}
+	if v.RiskLevel == "" {
+		v.RiskLevel = middleware.RiskLevelNormal
+	}
+	if user.VerifiedAt != nil {
+		v.VerifiedAt = *user.VerifiedAt
+	}
+	return v
+}
+
 // toUserResponse converts User to UserResponse
 func (s *authService) toUserResponse(user *domain.User) dto.UserResponse {
 	return dto.UserResponse{
@@ -524,3 +623,28 @@ func (s *authService) UpdateStripeCustomerID(ctx context.Context, userID, stripe
 	span.SetStatus(codes.Ok, "")
 	return nil
 }
+
+// LookupUsersByEmail finds the users registered with an email, optionally within a tenant
+func (s *authService) LookupUsersByEmail(ctx context.Context, email, tenantID string) ([]*domain.User, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.auth.lookup_users_by_email")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("tenant_id", tenantID))
+
+	email = strings.TrimSpace(email)
+	if email == "" {
+		span.SetStatus(codes.Error, "email required")
+		return nil, ErrEmailRequired
+	}
+
+	users, err := s.userRepo.ListByEmail(ctx, email, tenantID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("count", len(users)))
+	span.SetStatus(codes.Ok, "")
+	return users, nil
+}
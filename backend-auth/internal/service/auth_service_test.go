@@ -2,9 +2,11 @@ package service
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/dto"
 	"golang.org/x/crypto/bcrypt"
@@ -69,6 +71,55 @@ func (r *mockUserRepository) UpdateStripeCustomerID(ctx context.Context, userID,
 	return nil
 }
 
+func (r *mockUserRepository) ListByEmail(ctx context.Context, email, tenantID string) ([]*domain.User, error) {
+	var users []*domain.User
+	for _, user := range r.users {
+		if strings.EqualFold(user.Email, email) && (tenantID == "" || user.TenantID == tenantID) {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
+
+func (r *mockUserRepository) MarkVerified(ctx context.Context, userID string, channel domain.VerificationChannel, destination string, at time.Time) error {
+	user := r.users[userID]
+	if user == nil {
+		return nil
+	}
+	switch channel {
+	case domain.VerificationChannelEmail:
+		user.EmailVerified = true
+		user.EmailVerifiedAt = &at
+	case domain.VerificationChannelPhone:
+		user.Phone = destination
+		user.PhoneVerifiedAt = &at
+	}
+	user.VerifiedAt = &at
+	return nil
+}
+
+func (r *mockUserRepository) UpdateRiskLevel(ctx context.Context, userID string, level domain.RiskLevel) error {
+	user := r.users[userID]
+	if user != nil {
+		user.RiskLevel = level
+	}
+	return nil
+}
+
+func (r *mockUserRepository) Anonymize(ctx context.Context, id string) error {
+	user := r.users[id]
+	if user != nil {
+		delete(r.emailIndex, user.Email)
+		user.Email = "deleted-" + id + "@anonymized.invalid"
+		user.Name = ""
+		user.PasswordHash = ""
+		user.StripeCustomerID = ""
+		user.IsActive = false
+		r.emailIndex[user.Email] = user
+	}
+	return nil
+}
+
 // mockSessionRepository is a mock implementation of SessionRepository
 type mockSessionRepository struct {
 	sessions          map[string]*domain.Session
@@ -815,6 +866,101 @@ func TestJWTClaimsContainTenantID(t *testing.T) {
 	}
 }
 
+func TestJWTClaimsScopedToTenant(t *testing.T) {
+	config := &AuthServiceConfig{
+		JWTSecret:          "test-secret-key",
+		JWTIssuer:          "booking-rush",
+		AccessTokenExpiry:  15 * time.Minute,
+		RefreshTokenExpiry: 7 * 24 * time.Hour,
+		BcryptCost:         10,
+	}
+	svc := NewAuthService(newMockUserRepository(), newMockSessionRepository(), config)
+	user := &domain.User{ID: "tenant-user-id", Email: "tenant@example.com", Role: domain.RoleCustomer, TenantID: "tenant-123"}
+
+	token, err := signAccessToken(config.JWTSecret, config.JWTIssuer, user, time.Minute)
+	if err != nil {
+		t.Fatalf("signAccessToken() error = %v", err)
+	}
+	claims, err := svc.ValidateToken(context.Background(), token)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+	if len(claims.Audience) != 1 || claims.Audience[0] != "tenant:tenant-123" {
+		t.Errorf("ValidateToken() Audience = %v, want [tenant:tenant-123]", claims.Audience)
+	}
+
+	// A token claiming another tenant than the one it was issued for is rejected
+	forged, _ := jwt.Parse(token, func(*jwt.Token) (interface{}, error) { return []byte(config.JWTSecret), nil })
+	forgedClaims := forged.Claims.(jwt.MapClaims)
+	forgedClaims["tenant_id"] = "tenant-456"
+	forgedToken, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, forgedClaims).SignedString([]byte(config.JWTSecret))
+	if _, err := svc.ValidateToken(context.Background(), forgedToken); err != ErrInvalidToken {
+		t.Errorf("ValidateToken() error = %v, want %v", err, ErrInvalidToken)
+	}
+
+	// Tokens issued before tenant claims are accepted until they are required
+	legacyToken, _ := signAccessToken(config.JWTSecret, "", user, time.Minute)
+	if _, err := svc.ValidateToken(context.Background(), legacyToken); err != nil {
+		t.Errorf("ValidateToken() of a legacy token error = %v, want nil", err)
+	}
+	config.RequireTenantClaims = true
+	strict := NewAuthService(newMockUserRepository(), newMockSessionRepository(), config)
+	if _, err := strict.ValidateToken(context.Background(), legacyToken); err != ErrInvalidToken {
+		t.Errorf("ValidateToken() of a legacy token error = %v, want %v", err, ErrInvalidToken)
+	}
+}
+
+func TestJWTClaimsContainVerification(t *testing.T) {
+	userRepo := newMockUserRepository()
+	sessionRepo := newMockSessionRepository()
+	config := &AuthServiceConfig{
+		JWTSecret:          "test-secret-key",
+		AccessTokenExpiry:  15 * time.Minute,
+		RefreshTokenExpiry: 7 * 24 * time.Hour,
+		BcryptCost:         10,
+	}
+	svc := NewAuthService(userRepo, sessionRepo, config)
+
+	verifiedAt := time.Now().Add(-5 * time.Minute).Truncate(time.Second)
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("Password1!"), 10)
+	testUser := &domain.User{
+		ID:              "verified-user-id",
+		Email:           "verified@example.com",
+		PasswordHash:    string(hashedPassword),
+		Role:            domain.RoleCustomer,
+		IsActive:        true,
+		EmailVerified:   true,
+		PhoneVerifiedAt: &verifiedAt,
+		RiskLevel:       domain.RiskLevelHigh,
+		VerifiedAt:      &verifiedAt,
+	}
+	userRepo.users[testUser.ID] = testUser
+	userRepo.emailIndex[testUser.Email] = testUser
+
+	loginResp, err := svc.Login(context.Background(), &dto.LoginRequest{
+		Email:    "verified@example.com",
+		Password: "Password1!",
+	}, "Test-Agent", "127.0.0.1")
+	if err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+
+	claims, err := svc.ValidateToken(context.Background(), loginResp.AccessToken)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+
+	if !claims.EmailVerified || !claims.PhoneVerified {
+		t.Errorf("ValidateToken() EmailVerified = %v, PhoneVerified = %v, want both true", claims.EmailVerified, claims.PhoneVerified)
+	}
+	if claims.RiskLevel != domain.RiskLevelHigh {
+		t.Errorf("ValidateToken() RiskLevel = %v, want %v", claims.RiskLevel, domain.RiskLevelHigh)
+	}
+	if claims.VerifiedAt == nil || !claims.VerifiedAt.Equal(verifiedAt) {
+		t.Errorf("ValidateToken() VerifiedAt = %v, want %v", claims.VerifiedAt, verifiedAt)
+	}
+}
+
 func TestTokenExpiry(t *testing.T) {
 	userRepo := newMockUserRepository()
 	sessionRepo := newMockSessionRepository()
@@ -1042,3 +1188,38 @@ func TestAuthService_UpdateProfile(t *testing.T) {
 		}
 	})
 }
+
+func TestAuthService_LookupUsersByEmail(t *testing.T) {
+	userRepo := newMockUserRepository()
+	svc := NewAuthService(userRepo, newMockSessionRepository(), &AuthServiceConfig{JWTSecret: "test-secret-key"})
+
+	// The same email registered in two tenants
+	userRepo.users["user-a"] = &domain.User{ID: "user-a", Email: "Fan@Example.com", TenantID: "tenant-a"}
+	userRepo.users["user-b"] = &domain.User{ID: "user-b", Email: "fan@example.com", TenantID: "tenant-b"}
+
+	t.Run("all tenants", func(t *testing.T) {
+		users, err := svc.LookupUsersByEmail(context.Background(), " fan@example.com ", "")
+		if err != nil {
+			t.Fatalf("LookupUsersByEmail() error = %v", err)
+		}
+		if len(users) != 2 {
+			t.Errorf("LookupUsersByEmail() returned %d users, want 2", len(users))
+		}
+	})
+
+	t.Run("within tenant", func(t *testing.T) {
+		users, err := svc.LookupUsersByEmail(context.Background(), "fan@example.com", "tenant-b")
+		if err != nil {
+			t.Fatalf("LookupUsersByEmail() error = %v", err)
+		}
+		if len(users) != 1 || users[0].ID != "user-b" {
+			t.Errorf("LookupUsersByEmail() = %v, want only user-b", users)
+		}
+	})
+
+	t.Run("email required", func(t *testing.T) {
+		if _, err := svc.LookupUsersByEmail(context.Background(), "  ", ""); err != ErrEmailRequired {
+			t.Errorf("LookupUsersByEmail() error = %v, want %v", err, ErrEmailRequired)
+		}
+	})
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/i18n"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// ErrDeviceNotFound is returned when a device does not exist or belongs to another user
+var ErrDeviceNotFound = errors.New("device not found")
+
+// DeviceService manages the devices users register for push notifications
+type DeviceService interface {
+	// RegisterDevice registers or refreshes the current user's device
+	RegisterDevice(ctx context.Context, userID string, req *dto.RegisterDeviceRequest) (*dto.DeviceResponse, error)
+	// ListDevices lists the current user's devices
+	ListDevices(ctx context.Context, userID string) ([]*dto.DeviceResponse, error)
+	// RemoveDevice unregisters a device of the current user
+	RemoveDevice(ctx context.Context, userID, deviceID string) error
+	// ListPushDevices lists a user's devices with their tokens for the notification pipeline
+	ListPushDevices(ctx context.Context, userID string) ([]*dto.PushDeviceResponse, error)
+	// RevokeTokens drops devices whose tokens the push providers rejected
+	RevokeTokens(ctx context.Context, req *dto.RevokeDeviceTokensRequest) (*dto.RevokeDeviceTokensResponse, error)
+}
+
+// deviceService implements DeviceService
+type deviceService struct {
+	deviceRepo repository.DeviceRepository
+}
+
+// NewDeviceService creates a new DeviceService
+func NewDeviceService(deviceRepo repository.DeviceRepository) DeviceService {
+	return &deviceService{deviceRepo: deviceRepo}
+}
+
+// RegisterDevice registers or refreshes the current user's device
+func (s *deviceService) RegisterDevice(ctx context.Context, userID string, req *dto.RegisterDeviceRequest) (*dto.DeviceResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.device.register")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID),
+		attribute.String("platform", req.Platform),
+	)
+
+	// Notifications are rendered in the device's locale, falling back to the request's
+	locale, ok := i18n.Parse(req.Locale)
+	if !ok {
+		locale = i18n.FromContext(ctx)
+	}
+
+	now := time.Now()
+	device := &domain.Device{
+		ID:         uuid.New().String(),
+		UserID:     userID,
+		Platform:   domain.DevicePlatform(req.Platform),
+		Token:      req.Token,
+		Locale:     locale.String(),
+		AppVersion: req.AppVersion,
+		CreatedAt:  now,
+		LastSeenAt: now,
+	}
+	if err := s.deviceRepo.Upsert(ctx, device); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	// Reinstalls leave stale tokens behind, so keep only the most recent devices
+	if _, err := s.deviceRepo.TrimByUserID(ctx, userID, domain.MaxDevicesPerUser); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.String("device_id", device.ID))
+	span.SetStatus(codes.Ok, "")
+	return toDeviceResponse(device), nil
+}
+
+// ListDevices lists the current user's devices
+func (s *deviceService) ListDevices(ctx context.Context, userID string) ([]*dto.DeviceResponse, error) {
+	devices, err := s.deviceRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*dto.DeviceResponse, 0, len(devices))
+	for _, device := range devices {
+		resp = append(resp, toDeviceResponse(device))
+	}
+	return resp, nil
+}
+
+// RemoveDevice unregisters a device of the current user
+func (s *deviceService) RemoveDevice(ctx context.Context, userID, deviceID string) error {
+	if _, err := uuid.Parse(deviceID); err != nil {
+		return ErrDeviceNotFound
+	}
+
+	deleted, err := s.deviceRepo.Delete(ctx, userID, deviceID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrDeviceNotFound
+	}
+	return nil
+}
+
+// ListPushDevices lists a user's devices with their tokens for the notification pipeline
+func (s *deviceService) ListPushDevices(ctx context.Context, userID string) ([]*dto.PushDeviceResponse, error) {
+	devices, err := s.deviceRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*dto.PushDeviceResponse, 0, len(devices))
+	for _, device := range devices {
+		resp = append(resp, &dto.PushDeviceResponse{
+			ID:       device.ID,
+			Platform: string(device.Platform),
+			Token:    device.Token,
+			Locale:   device.Locale,
+		})
+	}
+	return resp, nil
+}
+
+// RevokeTokens drops devices whose tokens the push providers rejected
+func (s *deviceService) RevokeTokens(ctx context.Context, req *dto.RevokeDeviceTokensRequest) (*dto.RevokeDeviceTokensResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.device.revoke_tokens")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("count", len(req.Tokens)))
+
+	deleted, err := s.deviceRepo.DeleteByTokens(ctx, req.Tokens)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int64("deleted", deleted))
+	span.SetStatus(codes.Ok, "")
+	return &dto.RevokeDeviceTokensResponse{Deleted: deleted}, nil
+}
+
+// toDeviceResponse converts a device to its response without the token
+func toDeviceResponse(device *domain.Device) *dto.DeviceResponse {
+	return &dto.DeviceResponse{
+		ID:         device.ID,
+		Platform:   string(device.Platform),
+		Locale:     device.Locale,
+		AppVersion: device.AppVersion,
+		CreatedAt:  device.CreatedAt,
+		LastSeenAt: device.LastSeenAt,
+	}
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/i18n"
+)
+
+// mockDeviceRepository is an in-memory DeviceRepository keyed by token
+type mockDeviceRepository struct {
+	devices map[string]*domain.Device
+}
+
+func newMockDeviceRepository() *mockDeviceRepository {
+	return &mockDeviceRepository{devices: make(map[string]*domain.Device)}
+}
+
+func (r *mockDeviceRepository) Upsert(ctx context.Context, device *domain.Device) error {
+	if existing, ok := r.devices[device.Token]; ok {
+		device.ID = existing.ID
+		device.CreatedAt = existing.CreatedAt
+	}
+	stored := *device
+	r.devices[device.Token] = &stored
+	return nil
+}
+
+func (r *mockDeviceRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.Device, error) {
+	var devices []*domain.Device
+	for _, device := range r.devices {
+		if device.UserID == userID {
+			devices = append(devices, device)
+		}
+	}
+	sort.Slice(devices, func(i, j int) bool { return devices[i].LastSeenAt.After(devices[j].LastSeenAt) })
+	return devices, nil
+}
+
+func (r *mockDeviceRepository) Delete(ctx context.Context, userID, id string) (bool, error) {
+	for token, device := range r.devices {
+		if device.ID == id && device.UserID == userID {
+			delete(r.devices, token)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *mockDeviceRepository) DeleteByTokens(ctx context.Context, tokens []string) (int64, error) {
+	var deleted int64
+	for _, token := range tokens {
+		if _, ok := r.devices[token]; ok {
+			delete(r.devices, token)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (r *mockDeviceRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	for token, device := range r.devices {
+		if device.UserID == userID {
+			delete(r.devices, token)
+		}
+	}
+	return nil
+}
+
+func (r *mockDeviceRepository) TrimByUserID(ctx context.Context, userID string, keep int) (int64, error) {
+	devices, _ := r.ListByUserID(ctx, userID)
+	var deleted int64
+	for _, device := range devices[min(keep, len(devices)):] {
+		delete(r.devices, device.Token)
+		deleted++
+	}
+	return deleted, nil
+}
+
+func TestDeviceService_RegisterDevice(t *testing.T) {
+	repo := newMockDeviceRepository()
+	svc := NewDeviceService(repo)
+	ctx := i18n.WithLocale(context.Background(), i18n.Thai)
+
+	first, err := svc.RegisterDevice(ctx, "user-1", &dto.RegisterDeviceRequest{Platform: "ios", Token: "token-1"})
+	if err != nil {
+		t.Fatalf("RegisterDevice() error = %v", err)
+	}
+	if first.Locale != "th" {
+		t.Errorf("RegisterDevice() locale = %q, want the request locale th", first.Locale)
+	}
+
+	// The same token signed in as another user moves to that user and keeps its ID
+	moved, err := svc.RegisterDevice(context.Background(), "user-2", &dto.RegisterDeviceRequest{Platform: "ios", Token: "token-1", Locale: "en"})
+	if err != nil {
+		t.Fatalf("RegisterDevice() error = %v", err)
+	}
+	if moved.ID != first.ID {
+		t.Errorf("RegisterDevice() ID = %s, want %s", moved.ID, first.ID)
+	}
+	if devices, _ := svc.ListPushDevices(context.Background(), "user-1"); len(devices) != 0 {
+		t.Errorf("Expected user-1 to have no devices, got %d", len(devices))
+	}
+	devices, _ := svc.ListPushDevices(context.Background(), "user-2")
+	if len(devices) != 1 || devices[0].Token != "token-1" || devices[0].Locale != "en" {
+		t.Errorf("ListPushDevices() = %+v, want token-1 in en", devices)
+	}
+}
+
+func TestDeviceService_RegisterDeviceKeepsRecentDevices(t *testing.T) {
+	repo := newMockDeviceRepository()
+	svc := NewDeviceService(repo)
+
+	for i := 0; i <= domain.MaxDevicesPerUser; i++ {
+		token := "token-" + string(rune('a'+i))
+		if _, err := svc.RegisterDevice(context.Background(), "user-1", &dto.RegisterDeviceRequest{Platform: "android", Token: token}); err != nil {
+			t.Fatalf("RegisterDevice() error = %v", err)
+		}
+		repo.devices[token].LastSeenAt = time.Now().Add(time.Duration(i) * time.Second)
+	}
+
+	devices, _ := svc.ListDevices(context.Background(), "user-1")
+	if len(devices) != domain.MaxDevicesPerUser {
+		t.Fatalf("Expected %d devices, got %d", domain.MaxDevicesPerUser, len(devices))
+	}
+	if _, ok := repo.devices["token-a"]; ok {
+		t.Error("Expected the least recently seen device to be removed")
+	}
+}
+
+func TestDeviceService_RemoveDevice(t *testing.T) {
+	repo := newMockDeviceRepository()
+	svc := NewDeviceService(repo)
+
+	device, _ := svc.RegisterDevice(context.Background(), "user-1", &dto.RegisterDeviceRequest{Platform: "android", Token: "token-1"})
+
+	if err := svc.RemoveDevice(context.Background(), "user-2", device.ID); !errors.Is(err, ErrDeviceNotFound) {
+		t.Errorf("RemoveDevice() of another user's device error = %v, want ErrDeviceNotFound", err)
+	}
+	if err := svc.RemoveDevice(context.Background(), "user-1", device.ID); err != nil {
+		t.Fatalf("RemoveDevice() error = %v", err)
+	}
+
+	_, _ = svc.RegisterDevice(context.Background(), "user-1", &dto.RegisterDeviceRequest{Platform: "android", Token: "token-2"})
+	resp, err := svc.RevokeTokens(context.Background(), &dto.RevokeDeviceTokensRequest{Tokens: []string{"token-2", "unknown"}})
+	if err != nil {
+		t.Fatalf("RevokeTokens() error = %v", err)
+	}
+	if resp.Deleted != 1 || len(repo.devices) != 0 {
+		t.Errorf("RevokeTokens() deleted = %d, remaining = %d, want 1 and 0", resp.Deleted, len(repo.devices))
+	}
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// ErrLoadTestBatchTooLarge is returned when more users are requested than one batch allows
+var ErrLoadTestBatchTooLarge = errors.New("load test batch too large")
+
+// loadTestNamespace scopes synthetic user IDs so they never collide with registered users
+var loadTestNamespace = uuid.NewSHA1(uuid.NameSpaceURL, []byte("https://booking-rush/loadtest"))
+
+// LoadTestService mints credentials for synthetic users in load-test environments
+type LoadTestService interface {
+	// MintUsers signs access tokens for a batch of synthetic users. The users are not
+	// stored: tokens are verified by signature only, like those of real users.
+	MintUsers(ctx context.Context, req *dto.MintLoadTestUsersRequest) (*dto.MintLoadTestUsersResponse, error)
+}
+
+// LoadTestServiceConfig contains configuration for the load test service
+type LoadTestServiceConfig struct {
+	JWTSecret string
+	JWTIssuer string        // Issues tokens for their tenant, like those of real users
+	MaxBatch  int           // Most users minted per request (default: 1000)
+	TokenTTL  time.Duration // Lifetime of minted tokens (default: 1 hour)
+}
+
+// loadTestService implements LoadTestService
+type loadTestService struct {
+	jwtSecret string
+	jwtIssuer string
+	maxBatch  int
+	tokenTTL  time.Duration
+}
+
+// NewLoadTestService creates a new load test service
+func NewLoadTestService(cfg *LoadTestServiceConfig) LoadTestService {
+	s := &loadTestService{
+		maxBatch: 1000,
+		tokenTTL: time.Hour,
+	}
+	if cfg != nil {
+		s.jwtSecret = cfg.JWTSecret
+		s.jwtIssuer = cfg.JWTIssuer
+		if cfg.MaxBatch > 0 {
+			s.maxBatch = cfg.MaxBatch
+		}
+		if cfg.TokenTTL > 0 {
+			s.tokenTTL = cfg.TokenTTL
+		}
+	}
+	return s
+}
+
+// MintUsers signs access tokens for users Offset..Offset+Count-1 of the run
+func (s *loadTestService) MintUsers(ctx context.Context, req *dto.MintLoadTestUsersRequest) (*dto.MintLoadTestUsersResponse, error) {
+	_, span := telemetry.StartSpan(ctx, "service.loadtest.mint_users")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("run_id", req.RunID),
+		attribute.Int("count", req.Count),
+		attribute.Int("offset", req.Offset),
+	)
+
+	if req.Count > s.maxBatch {
+		span.SetStatus(codes.Error, "batch too large")
+		return nil, fmt.Errorf("%w: %d users requested, at most %d per request", ErrLoadTestBatchTooLarge, req.Count, s.maxBatch)
+	}
+
+	riskLevel := domain.RiskLevel(req.RiskLevel)
+	if riskLevel == "" {
+		riskLevel = domain.RiskLevelNormal
+	}
+
+	// Synthetic users have verified contacts; high-risk ones have never passed a challenge,
+	// so their reservations exercise the verification gate
+	now := time.Now()
+	resp := &dto.MintLoadTestUsersResponse{
+		RunID:     req.RunID,
+		ExpiresAt: now.Add(s.tokenTTL),
+		Users:     make([]dto.LoadTestUser, 0, req.Count),
+	}
+	for i := req.Offset; i < req.Offset+req.Count; i++ {
+		user := &domain.User{
+			ID:              LoadTestUserID(req.RunID, i),
+			Email:           fmt.Sprintf("loadtest+%s-%d@loadtest.invalid", req.RunID, i),
+			Role:            domain.RoleCustomer,
+			TenantID:        req.TenantID,
+			EmailVerified:   true,
+			PhoneVerifiedAt: &now,
+			RiskLevel:       riskLevel,
+		}
+
+		token, err := signAccessToken(s.jwtSecret, s.jwtIssuer, user, s.tokenTTL)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, fmt.Errorf("failed to sign load test token: %w", err)
+		}
+		resp.Users = append(resp.Users, dto.LoadTestUser{
+			UserID:      user.ID,
+			Email:       user.Email,
+			AccessToken: token,
+		})
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return resp, nil
+}
+
+// LoadTestUserID returns the ID of synthetic user n of a run
+func LoadTestUserID(runID string, n int) string {
+	return uuid.NewSHA1(loadTestNamespace, []byte(fmt.Sprintf("%s:%d", runID, n))).String()
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/dto"
+)
+
+func TestLoadTestService_MintUsers(t *testing.T) {
+	svc := NewLoadTestService(&LoadTestServiceConfig{JWTSecret: "test-secret", MaxBatch: 10})
+	ctx := context.Background()
+
+	resp, err := svc.MintUsers(ctx, &dto.MintLoadTestUsersRequest{RunID: "run-1", Count: 3, Offset: 5, TenantID: "tenant-1"})
+	if err != nil {
+		t.Fatalf("MintUsers() error = %v", err)
+	}
+	if len(resp.Users) != 3 {
+		t.Fatalf("MintUsers() returned %d users, want 3", len(resp.Users))
+	}
+
+	// Tokens carry the claims issued to real users
+	user := resp.Users[0]
+	token, err := jwt.Parse(user.AccessToken, func(token *jwt.Token) (interface{}, error) {
+		return []byte("test-secret"), nil
+	})
+	if err != nil || !token.Valid {
+		t.Fatalf("Minted token is invalid: %v", err)
+	}
+	claims := token.Claims.(jwt.MapClaims)
+	if claims["user_id"] != user.UserID || claims["role"] != "customer" || claims["tenant_id"] != "tenant-1" {
+		t.Errorf("Unexpected claims: %v", claims)
+	}
+	if claims["email_verified"] != true || claims["risk_level"] != "normal" {
+		t.Errorf("Expected a verified normal-risk user, got %v", claims)
+	}
+
+	// The same run and number map to the same user
+	again, err := svc.MintUsers(ctx, &dto.MintLoadTestUsersRequest{RunID: "run-1", Count: 1, Offset: 5})
+	if err != nil {
+		t.Fatalf("MintUsers() error = %v", err)
+	}
+	if again.Users[0].UserID != user.UserID || LoadTestUserID("run-2", 5) == user.UserID {
+		t.Error("Expected user IDs to be stable within a run and differ across runs")
+	}
+
+	if _, err := svc.MintUsers(ctx, &dto.MintLoadTestUsersRequest{RunID: "run-1", Count: 11}); !errors.Is(err, ErrLoadTestBatchTooLarge) {
+		t.Errorf("MintUsers() over batch error = %v, want ErrLoadTestBatchTooLarge", err)
+	}
+}
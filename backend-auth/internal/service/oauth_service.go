@@ -0,0 +1,510 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+var (
+	ErrOAuthProviderUnsupported   = errors.New("unsupported social login provider")
+	ErrOAuthProviderNotConfigured = errors.New("social login provider is not configured")
+	ErrOAuthInvalidState          = errors.New("invalid or expired social login state")
+	ErrOAuthExchangeFailed        = errors.New("social login code exchange failed")
+	ErrOAuthEmailRequired         = errors.New("the provider account has no email")
+	ErrOAuthAccountExists         = errors.New("an account with this email already exists")
+	ErrOAuthIdentityLinked        = errors.New("the provider account is linked to another user")
+	ErrOAuthClientSecretRequired  = errors.New("client_secret is required")
+)
+
+// oauthStateAudience marks state tokens so they are never mistaken for other tokens
+const oauthStateAudience = "oauth-state"
+
+// OAuthServiceConfig holds configuration for OAuthService
+type OAuthServiceConfig struct {
+	// StateSecret signs the state round-tripped through the provider
+	StateSecret string
+	StateTTL    time.Duration // How long a user has to finish signing in
+	// Platform are the platform's clients, used by tenants without their own
+	Platform map[string]*domain.OAuthProviderConfig
+	// Clients replace the providers' HTTP clients (nil = DefaultOAuthEndpoints)
+	Clients map[string]OAuthProviderClient
+}
+
+// OAuthService handles sign-in with Google, Facebook and LINE
+type OAuthService interface {
+	// Providers lists the providers users of a tenant can sign in with ("" = platform)
+	Providers(ctx context.Context, tenantID string) ([]string, error)
+	// Authorize returns the provider's consent page to send the user to
+	Authorize(ctx context.Context, provider, tenantID string) (*dto.OAuthAuthorizeResponse, error)
+	// Callback redeems the code the provider redirected back with and signs the user in,
+	// linking the provider account to the user with its email or creating one
+	Callback(ctx context.Context, provider string, req *dto.OAuthCallbackRequest, userAgent, ip string) (*dto.AuthResponse, error)
+	// Link redeems a code and links the provider account to a signed-in user
+	Link(ctx context.Context, userID, provider string, req *dto.OAuthCallbackRequest) (*domain.UserIdentity, error)
+	// ListIdentities lists the provider accounts linked to a user
+	ListIdentities(ctx context.Context, userID string) ([]*domain.UserIdentity, error)
+	// ListTenantProviders lists the clients a tenant registered with providers
+	ListTenantProviders(ctx context.Context, tenantID string) ([]*domain.OAuthProviderConfig, error)
+	// SaveTenantProvider sets the client a tenant registered with a provider
+	SaveTenantProvider(ctx context.Context, tenantID, userID, provider string, req *dto.SaveOAuthProviderRequest) (*domain.OAuthProviderConfig, error)
+	// DeleteTenantProvider removes a tenant's client, returning the tenant to the platform's
+	DeleteTenantProvider(ctx context.Context, tenantID, provider string) error
+}
+
+// oauthService implements OAuthService
+type oauthService struct {
+	userRepo     repository.UserRepository
+	identityRepo repository.UserIdentityRepository
+	providerRepo repository.OAuthProviderRepository
+	tenantRepo   repository.TenantRepository
+	authService  AuthService
+	clients      map[string]OAuthProviderClient
+	stateKey     []byte
+	config       *OAuthServiceConfig
+}
+
+// NewOAuthService creates a new OAuthService
+func NewOAuthService(
+	userRepo repository.UserRepository,
+	identityRepo repository.UserIdentityRepository,
+	providerRepo repository.OAuthProviderRepository,
+	tenantRepo repository.TenantRepository,
+	authService AuthService,
+	config *OAuthServiceConfig,
+) OAuthService {
+	if config.StateTTL == 0 {
+		config.StateTTL = 10 * time.Minute
+	}
+	clients := make(map[string]OAuthProviderClient, len(domain.OAuthProviders))
+	for _, provider := range domain.OAuthProviders {
+		if client := config.Clients[provider]; client != nil {
+			clients[provider] = client
+		} else {
+			clients[provider] = NewOAuthProviderClient(provider, DefaultOAuthEndpoints(provider))
+		}
+	}
+
+	// State is signed with a key of its own, derived from the secret
+	mac := hmac.New(sha256.New, []byte(config.StateSecret))
+	mac.Write([]byte(oauthStateAudience))
+
+	return &oauthService{
+		userRepo:     userRepo,
+		identityRepo: identityRepo,
+		providerRepo: providerRepo,
+		tenantRepo:   tenantRepo,
+		authService:  authService,
+		clients:      clients,
+		stateKey:     mac.Sum(nil),
+		config:       config,
+	}
+}
+
+// Providers lists the providers users of a tenant can sign in with
+func (s *oauthService) Providers(ctx context.Context, tenantID string) ([]string, error) {
+	providers := []string{}
+	for _, provider := range domain.OAuthProviders {
+		if _, err := s.client(ctx, tenantID, provider); err != nil {
+			if errors.Is(err, ErrOAuthProviderNotConfigured) {
+				continue
+			}
+			return nil, err
+		}
+		providers = append(providers, provider)
+	}
+	return providers, nil
+}
+
+// Authorize returns the provider's consent page to send the user to
+func (s *oauthService) Authorize(ctx context.Context, provider, tenantID string) (*dto.OAuthAuthorizeResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.oauth.authorize")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("provider", provider), attribute.String("tenant_id", tenantID))
+
+	client, err := s.client(ctx, tenantID, provider)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	nonce, err := randomToken()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	expiresAt := time.Now().Add(s.config.StateTTL)
+	state, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"aud":       oauthStateAudience,
+		"provider":  provider,
+		"tenant_id": tenantID,
+		"nonce":     nonce,
+		"exp":       expiresAt.Unix(),
+	}).SignedString(s.stateKey)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return &dto.OAuthAuthorizeResponse{
+		Provider:         provider,
+		AuthorizationURL: s.clients[provider].AuthCodeURL(client, state, nonce),
+		State:            state,
+		ExpiresAt:        expiresAt,
+	}, nil
+}
+
+// Callback redeems the code the provider redirected back with and signs the user in
+func (s *oauthService) Callback(ctx context.Context, provider string, req *dto.OAuthCallbackRequest, userAgent, ip string) (*dto.AuthResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.oauth.callback")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("provider", provider))
+
+	profile, err := s.exchange(ctx, provider, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	user, err := s.signInUser(ctx, profile)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	result, err := s.authService.IssueSession(ctx, user, userAgent, ip)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.String("user_id", user.ID))
+	span.SetStatus(codes.Ok, "")
+	return result, nil
+}
+
+// signInUser returns the user a provider account signs in as. An unlinked account
+// is linked to the user holding its email when the provider verified that email,
+// and gets a new user when no user holds it.
+func (s *oauthService) signInUser(ctx context.Context, profile *domain.OAuthProfile) (*domain.User, error) {
+	now := time.Now()
+	identity, err := s.identityRepo.GetBySubject(ctx, profile.Provider, profile.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if identity != nil {
+		user, err := s.userRepo.GetByID(ctx, identity.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			return nil, ErrUserNotFound
+		}
+		if err := s.identityRepo.TouchLogin(ctx, identity.ID, now); err != nil {
+			return nil, err
+		}
+		return user, nil
+	}
+
+	if profile.Email == "" {
+		return nil, ErrOAuthEmailRequired
+	}
+	user, err := s.userRepo.GetByEmail(ctx, profile.Email)
+	if err != nil {
+		return nil, err
+	}
+	if user != nil {
+		// Anyone can put an email on an account at some providers, so only a verified
+		// email proves the account belongs to the user holding it
+		if !profile.EmailVerified {
+			return nil, ErrOAuthAccountExists
+		}
+		if !user.IsActive {
+			return nil, ErrUserInactive
+		}
+	} else {
+		user = &domain.User{
+			ID:        uuid.New().String(),
+			Email:     profile.Email,
+			Name:      profileName(profile),
+			Role:      domain.RoleCustomer,
+			IsActive:  true,
+			CreatedAt: now,
+			UpdatedAt: now,
+			// No password: the account signs in with its providers until one is set
+		}
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+
+	if profile.EmailVerified && !user.EmailVerified {
+		if err := s.userRepo.MarkVerified(ctx, user.ID, domain.VerificationChannelEmail, profile.Email, now); err != nil {
+			return nil, err
+		}
+		user.EmailVerified = true
+		user.EmailVerifiedAt = &now
+		user.VerifiedAt = &now
+	}
+
+	if err := s.identityRepo.Create(ctx, newUserIdentity(user.ID, profile, now)); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// Link redeems a code and links the provider account to a signed-in user
+func (s *oauthService) Link(ctx context.Context, userID, provider string, req *dto.OAuthCallbackRequest) (*domain.UserIdentity, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.oauth.link")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("provider", provider), attribute.String("user_id", userID))
+
+	profile, err := s.exchange(ctx, provider, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	identity, err := s.identityRepo.GetBySubject(ctx, profile.Provider, profile.Subject)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if identity != nil {
+		if identity.UserID != userID {
+			span.SetStatus(codes.Error, "identity linked to another user")
+			return nil, ErrOAuthIdentityLinked
+		}
+		span.SetStatus(codes.Ok, "")
+		return identity, nil
+	}
+
+	identity = newUserIdentity(userID, profile, time.Now())
+	identity.LastLoginAt = nil
+	if err := s.identityRepo.Create(ctx, identity); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return identity, nil
+}
+
+// ListIdentities lists the provider accounts linked to a user
+func (s *oauthService) ListIdentities(ctx context.Context, userID string) ([]*domain.UserIdentity, error) {
+	return s.identityRepo.ListByUserID(ctx, userID)
+}
+
+// ListTenantProviders lists the clients a tenant registered with providers
+func (s *oauthService) ListTenantProviders(ctx context.Context, tenantID string) ([]*domain.OAuthProviderConfig, error) {
+	if err := s.requireTenant(ctx, tenantID); err != nil {
+		return nil, err
+	}
+	return s.providerRepo.ListByTenantID(ctx, tenantID)
+}
+
+// SaveTenantProvider sets the client a tenant registered with a provider
+func (s *oauthService) SaveTenantProvider(ctx context.Context, tenantID, userID, provider string, req *dto.SaveOAuthProviderRequest) (*domain.OAuthProviderConfig, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.oauth.save_tenant_provider")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("tenant_id", tenantID), attribute.String("provider", provider))
+
+	if !domain.IsOAuthProvider(provider) {
+		span.SetStatus(codes.Error, "unsupported provider")
+		return nil, ErrOAuthProviderUnsupported
+	}
+	if err := s.requireTenant(ctx, tenantID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	config := &domain.OAuthProviderConfig{
+		TenantID:     tenantID,
+		Provider:     provider,
+		ClientID:     strings.TrimSpace(req.ClientID),
+		ClientSecret: req.ClientSecret,
+		RedirectURL:  req.RedirectURL,
+		Enabled:      req.Enabled == nil || *req.Enabled,
+		UpdatedBy:    userID,
+	}
+	if config.ClientSecret == "" {
+		existing, err := s.providerRepo.Get(ctx, tenantID, provider)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		if existing == nil {
+			span.SetStatus(codes.Error, "client secret required")
+			return nil, ErrOAuthClientSecretRequired
+		}
+		config.ClientSecret = existing.ClientSecret
+	}
+
+	if err := s.providerRepo.Save(ctx, config); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return config, nil
+}
+
+// DeleteTenantProvider removes a tenant's client, returning the tenant to the platform's
+func (s *oauthService) DeleteTenantProvider(ctx context.Context, tenantID, provider string) error {
+	if !domain.IsOAuthProvider(provider) {
+		return ErrOAuthProviderUnsupported
+	}
+	if err := s.requireTenant(ctx, tenantID); err != nil {
+		return err
+	}
+	deleted, err := s.providerRepo.Delete(ctx, tenantID, provider)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrOAuthProviderNotConfigured
+	}
+	return nil
+}
+
+// client returns the client users of a tenant sign in to provider with: the tenant's
+// own when it registered one, the platform's otherwise. A tenant's disabled client
+// turns the provider off for the tenant.
+func (s *oauthService) client(ctx context.Context, tenantID, provider string) (*domain.OAuthProviderConfig, error) {
+	if !domain.IsOAuthProvider(provider) {
+		return nil, ErrOAuthProviderUnsupported
+	}
+	if tenantID != "" {
+		if _, err := uuid.Parse(tenantID); err != nil {
+			return nil, ErrTenantNotFound
+		}
+		config, err := s.providerRepo.Get(ctx, tenantID, provider)
+		if err != nil {
+			return nil, err
+		}
+		if config != nil {
+			if !config.Enabled {
+				return nil, ErrOAuthProviderNotConfigured
+			}
+			return config, nil
+		}
+	}
+	if config := s.config.Platform[provider]; config != nil && config.ClientID != "" && config.Enabled {
+		return config, nil
+	}
+	return nil, ErrOAuthProviderNotConfigured
+}
+
+// exchange checks the state and redeems the code with the client the state was issued for
+func (s *oauthService) exchange(ctx context.Context, provider string, req *dto.OAuthCallbackRequest) (*domain.OAuthProfile, error) {
+	if !domain.IsOAuthProvider(provider) {
+		return nil, ErrOAuthProviderUnsupported
+	}
+	token, err := jwt.Parse(req.State, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrOAuthInvalidState
+		}
+		return s.stateKey, nil
+	}, jwt.WithAudience(oauthStateAudience), jwt.WithExpirationRequired())
+	if err != nil || !token.Valid {
+		return nil, ErrOAuthInvalidState
+	}
+	claims, _ := token.Claims.(jwt.MapClaims)
+	stateProvider, _ := claims["provider"].(string)
+	tenantID, _ := claims["tenant_id"].(string)
+	nonce, _ := claims["nonce"].(string)
+	if stateProvider != provider || nonce == "" {
+		return nil, ErrOAuthInvalidState
+	}
+
+	client, err := s.client(ctx, tenantID, provider)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := s.clients[provider].Exchange(ctx, client, req.Code, nonce)
+	if err != nil {
+		return nil, err
+	}
+	profile.Email = strings.TrimSpace(profile.Email)
+	return profile, nil
+}
+
+// requireTenant checks the tenant exists
+func (s *oauthService) requireTenant(ctx context.Context, tenantID string) error {
+	if _, err := uuid.Parse(tenantID); err != nil {
+		return ErrTenantNotFound
+	}
+	tenant, err := s.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	if tenant == nil {
+		return ErrTenantNotFound
+	}
+	return nil
+}
+
+// newUserIdentity links a provider account to a user that just signed in with it
+func newUserIdentity(userID string, profile *domain.OAuthProfile, now time.Time) *domain.UserIdentity {
+	return &domain.UserIdentity{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		Provider:    profile.Provider,
+		Subject:     profile.Subject,
+		Email:       profile.Email,
+		CreatedAt:   now,
+		LastLoginAt: &now,
+	}
+}
+
+// profileName returns the name of a new user signing up with a provider account
+func profileName(profile *domain.OAuthProfile) string {
+	if name := strings.TrimSpace(profile.Name); name != "" {
+		return name
+	}
+	if at := strings.Index(profile.Email, "@"); at > 0 {
+		return profile.Email[:at]
+	}
+	return fmt.Sprintf("%s user", profile.Provider)
+}
+
+// randomToken returns 16 random bytes, URL-safe encoded
+func randomToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,490 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/dto"
+)
+
+// mockUserIdentityRepository is a mock implementation of UserIdentityRepository
+type mockUserIdentityRepository struct {
+	identities map[string]*domain.UserIdentity
+}
+
+func (r *mockUserIdentityRepository) Create(ctx context.Context, identity *domain.UserIdentity) error {
+	r.identities[identity.Provider+"/"+identity.Subject] = identity
+	return nil
+}
+
+func (r *mockUserIdentityRepository) GetBySubject(ctx context.Context, provider, subject string) (*domain.UserIdentity, error) {
+	return r.identities[provider+"/"+subject], nil
+}
+
+func (r *mockUserIdentityRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.UserIdentity, error) {
+	var identities []*domain.UserIdentity
+	for _, identity := range r.identities {
+		if identity.UserID == userID {
+			identities = append(identities, identity)
+		}
+	}
+	return identities, nil
+}
+
+func (r *mockUserIdentityRepository) TouchLogin(ctx context.Context, id string, at time.Time) error {
+	for _, identity := range r.identities {
+		if identity.ID == id {
+			identity.LastLoginAt = &at
+		}
+	}
+	return nil
+}
+
+func (r *mockUserIdentityRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	for key, identity := range r.identities {
+		if identity.UserID == userID {
+			delete(r.identities, key)
+		}
+	}
+	return nil
+}
+
+// mockOAuthProviderRepository is a mock implementation of OAuthProviderRepository
+type mockOAuthProviderRepository struct {
+	configs map[string]*domain.OAuthProviderConfig
+}
+
+func (r *mockOAuthProviderRepository) Get(ctx context.Context, tenantID, provider string) (*domain.OAuthProviderConfig, error) {
+	return r.configs[tenantID+"/"+provider], nil
+}
+
+func (r *mockOAuthProviderRepository) ListByTenantID(ctx context.Context, tenantID string) ([]*domain.OAuthProviderConfig, error) {
+	var configs []*domain.OAuthProviderConfig
+	for _, config := range r.configs {
+		if config.TenantID == tenantID {
+			configs = append(configs, config)
+		}
+	}
+	return configs, nil
+}
+
+func (r *mockOAuthProviderRepository) Save(ctx context.Context, config *domain.OAuthProviderConfig) error {
+	config.CreatedAt, config.UpdatedAt = time.Now(), time.Now()
+	r.configs[config.TenantID+"/"+config.Provider] = config
+	return nil
+}
+
+func (r *mockOAuthProviderRepository) Delete(ctx context.Context, tenantID, provider string) (bool, error) {
+	_, ok := r.configs[tenantID+"/"+provider]
+	delete(r.configs, tenantID+"/"+provider)
+	return ok, nil
+}
+
+// fakeOAuthProvider is a provider's token and Graph endpoints. Codes are registered
+// with the account that consented and the nonce the consent page was opened with.
+type fakeOAuthProvider struct {
+	server   *httptest.Server
+	clientID string
+	secret   string
+	codes    map[string]fakeOAuthGrant
+	tokens   map[string]fakeOAuthGrant
+	issuer   string
+	audience string // Overrides the aud claim when set
+}
+
+type fakeOAuthGrant struct {
+	nonce   string
+	profile domain.OAuthProfile
+}
+
+func newFakeOAuthProvider(t *testing.T) *fakeOAuthProvider {
+	p := &fakeOAuthProvider{
+		clientID: "platform-client",
+		secret:   "platform-secret",
+		codes:    make(map[string]fakeOAuthGrant),
+		tokens:   make(map[string]fakeOAuthGrant),
+		issuer:   "https://accounts.google.com",
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		grant, ok := p.codes[r.PostForm.Get("code")]
+		if !ok || r.PostForm.Get("client_id") != p.clientID || r.PostForm.Get("client_secret") != p.secret {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		delete(p.codes, r.PostForm.Get("code"))
+
+		audience := p.clientID
+		if p.audience != "" {
+			audience = p.audience
+		}
+		claims, _ := json.Marshal(map[string]interface{}{
+			"iss":            p.issuer,
+			"aud":            []string{audience},
+			"sub":            grant.profile.Subject,
+			"exp":            time.Now().Add(time.Hour).Unix(),
+			"nonce":          grant.nonce,
+			"email":          grant.profile.Email,
+			"email_verified": grant.profile.EmailVerified,
+			"name":           grant.profile.Name,
+		})
+		accessToken := "access-" + grant.profile.Subject
+		p.tokens[accessToken] = grant
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"access_token": accessToken,
+			"id_token":     "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString(claims) + ".sig",
+		})
+	})
+	mux.HandleFunc("/me", func(w http.ResponseWriter, r *http.Request) {
+		accessToken := r.URL.Query().Get("access_token")
+		mac := hmac.New(sha256.New, []byte(p.secret))
+		mac.Write([]byte(accessToken))
+		grant, ok := p.tokens[accessToken]
+		if !ok || r.URL.Query().Get("appsecret_proof") != hex.EncodeToString(mac.Sum(nil)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"id":    grant.profile.Subject,
+			"name":  grant.profile.Name,
+			"email": grant.profile.Email,
+		})
+	})
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+func (p *fakeOAuthProvider) endpoints(oidc bool) OAuthEndpoints {
+	endpoints := OAuthEndpoints{
+		AuthURL:  p.server.URL + "/authorize",
+		TokenURL: p.server.URL + "/token",
+	}
+	if oidc {
+		endpoints.Issuers = []string{"https://accounts.google.com"}
+	} else {
+		endpoints.ProfileURL = p.server.URL + "/me"
+	}
+	return endpoints
+}
+
+// consent opens the consent page as the account and returns the code and state
+// the provider redirects back with
+func (p *fakeOAuthProvider) consent(t *testing.T, authorize *dto.OAuthAuthorizeResponse, profile domain.OAuthProfile) *dto.OAuthCallbackRequest {
+	t.Helper()
+	consentURL, err := url.Parse(authorize.AuthorizationURL)
+	if err != nil {
+		t.Fatalf("invalid authorization URL: %v", err)
+	}
+	query := consentURL.Query()
+	if query.Get("client_id") == "" || query.Get("redirect_uri") == "" || query.Get("state") != authorize.State {
+		t.Fatalf("authorization URL %s misses the client, redirect or state", authorize.AuthorizationURL)
+	}
+	code := "code-" + profile.Subject
+	p.codes[code] = fakeOAuthGrant{nonce: query.Get("nonce"), profile: profile}
+	return &dto.OAuthCallbackRequest{Code: code, State: query.Get("state")}
+}
+
+type oauthTestEnv struct {
+	service   OAuthService
+	auth      AuthService
+	users     *mockUserRepository
+	identity  *mockUserIdentityRepository
+	providers *mockOAuthProviderRepository
+	tenants   *mockTenantRepository
+	google    *fakeOAuthProvider
+	facebook  *fakeOAuthProvider
+}
+
+func newOAuthTestEnv(t *testing.T) *oauthTestEnv {
+	env := &oauthTestEnv{
+		users:     newMockUserRepository(),
+		identity:  &mockUserIdentityRepository{identities: make(map[string]*domain.UserIdentity)},
+		providers: &mockOAuthProviderRepository{configs: make(map[string]*domain.OAuthProviderConfig)},
+		tenants:   &mockTenantRepository{tenants: make(map[string]*domain.Tenant)},
+		google:    newFakeOAuthProvider(t),
+		facebook:  newFakeOAuthProvider(t),
+	}
+	env.auth = NewAuthService(env.users, newMockSessionRepository(), &AuthServiceConfig{
+		JWTSecret:  "test-secret",
+		BcryptCost: 4,
+	})
+	env.service = NewOAuthService(env.users, env.identity, env.providers, env.tenants, env.auth, &OAuthServiceConfig{
+		StateSecret: "test-secret",
+		Platform: map[string]*domain.OAuthProviderConfig{
+			domain.OAuthProviderGoogle: {
+				Provider: domain.OAuthProviderGoogle, ClientID: "platform-client", ClientSecret: "platform-secret",
+				RedirectURL: "https://app.example.com/auth/google", Enabled: true,
+			},
+			domain.OAuthProviderFacebook: {
+				Provider: domain.OAuthProviderFacebook, ClientID: "platform-client", ClientSecret: "platform-secret",
+				RedirectURL: "https://app.example.com/auth/facebook", Enabled: true,
+			},
+		},
+		Clients: map[string]OAuthProviderClient{
+			domain.OAuthProviderGoogle:   NewOAuthProviderClient(domain.OAuthProviderGoogle, env.google.endpoints(true)),
+			domain.OAuthProviderFacebook: NewOAuthProviderClient(domain.OAuthProviderFacebook, env.facebook.endpoints(false)),
+		},
+	})
+	return env
+}
+
+// signIn runs the whole flow with provider as the account
+func (env *oauthTestEnv) signIn(t *testing.T, fake *fakeOAuthProvider, provider, tenantID string, profile domain.OAuthProfile) (*dto.AuthResponse, error) {
+	t.Helper()
+	authorize, err := env.service.Authorize(context.Background(), provider, tenantID)
+	if err != nil {
+		t.Fatalf("Authorize() error = %v", err)
+	}
+	return env.service.Callback(context.Background(), provider, fake.consent(t, authorize, profile), "test-agent", "127.0.0.1")
+}
+
+var googleJane = domain.OAuthProfile{Subject: "g-1", Email: "jane@example.com", EmailVerified: true, Name: "Jane"}
+
+func TestOAuthService_Callback_NewUser(t *testing.T) {
+	env := newOAuthTestEnv(t)
+
+	result, err := env.signIn(t, env.google, domain.OAuthProviderGoogle, "", googleJane)
+	if err != nil {
+		t.Fatalf("Callback() error = %v", err)
+	}
+
+	user := env.users.emailIndex["jane@example.com"]
+	if user == nil || user.Name != "Jane" || user.Role != domain.RoleCustomer || !user.EmailVerified {
+		t.Fatalf("Callback() created user %+v, want a verified customer named Jane", user)
+	}
+	if user.PasswordHash != "" {
+		t.Error("Callback() set a password on a social login account")
+	}
+	identity := env.identity.identities["google/g-1"]
+	if identity == nil || identity.UserID != user.ID || identity.LastLoginAt == nil {
+		t.Errorf("Callback() linked identity %+v, want google/g-1 linked to %s", identity, user.ID)
+	}
+
+	// The tokens carry the same claims as a password login
+	claims, err := env.auth.ValidateToken(context.Background(), result.AccessToken)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+	if claims.UserID != user.ID || claims.Email != user.Email || claims.Role != domain.RoleCustomer || !claims.EmailVerified {
+		t.Errorf("access token claims = %+v", claims)
+	}
+	if result.RefreshToken == "" {
+		t.Error("Callback() started no session")
+	}
+
+	// Signing in again finds the user through the identity
+	if _, err := env.signIn(t, env.google, domain.OAuthProviderGoogle, "", googleJane); err != nil {
+		t.Fatalf("second Callback() error = %v", err)
+	}
+	if len(env.users.users) != 1 {
+		t.Errorf("second Callback() created another user, have %d", len(env.users.users))
+	}
+}
+
+func TestOAuthService_Callback_LinksExistingUser(t *testing.T) {
+	env := newOAuthTestEnv(t)
+	existing := &domain.User{ID: "user-1", Email: "jane@example.com", Name: "Jane", Role: domain.RoleOrganizer, IsActive: true}
+	_ = env.users.Create(context.Background(), existing)
+
+	result, err := env.signIn(t, env.google, domain.OAuthProviderGoogle, "", googleJane)
+	if err != nil {
+		t.Fatalf("Callback() error = %v", err)
+	}
+	if result.User.ID != "user-1" || result.User.Role != string(domain.RoleOrganizer) {
+		t.Errorf("Callback() signed in as %+v, want the existing organizer", result.User)
+	}
+	if env.identity.identities["google/g-1"].UserID != "user-1" {
+		t.Error("Callback() did not link the identity to the existing user")
+	}
+	if !existing.EmailVerified {
+		t.Error("Callback() did not mark the email the provider verified")
+	}
+}
+
+func TestOAuthService_Callback_UnverifiedEmailOfExistingUser(t *testing.T) {
+	env := newOAuthTestEnv(t)
+	_ = env.users.Create(context.Background(), &domain.User{ID: "user-1", Email: "jane@example.com", IsActive: true})
+
+	unverified := googleJane
+	unverified.EmailVerified = false
+	_, err := env.signIn(t, env.google, domain.OAuthProviderGoogle, "", unverified)
+	if !errors.Is(err, ErrOAuthAccountExists) {
+		t.Fatalf("Callback() error = %v, want ErrOAuthAccountExists", err)
+	}
+	if len(env.identity.identities) != 0 {
+		t.Error("Callback() linked an unverified email to the existing user")
+	}
+
+	// The user links the account after signing in with a password
+	authorize, _ := env.service.Authorize(context.Background(), domain.OAuthProviderGoogle, "")
+	identity, err := env.service.Link(context.Background(), "user-1", domain.OAuthProviderGoogle, env.google.consent(t, authorize, unverified))
+	if err != nil {
+		t.Fatalf("Link() error = %v", err)
+	}
+	if identity.UserID != "user-1" {
+		t.Errorf("Link() linked to %s, want user-1", identity.UserID)
+	}
+
+	// The account cannot then be linked to someone else
+	authorize, _ = env.service.Authorize(context.Background(), domain.OAuthProviderGoogle, "")
+	if _, err := env.service.Link(context.Background(), "user-2", domain.OAuthProviderGoogle, env.google.consent(t, authorize, unverified)); !errors.Is(err, ErrOAuthIdentityLinked) {
+		t.Errorf("Link() to another user error = %v, want ErrOAuthIdentityLinked", err)
+	}
+}
+
+func TestOAuthService_Callback_Facebook(t *testing.T) {
+	env := newOAuthTestEnv(t)
+
+	profile := domain.OAuthProfile{Subject: "fb-1", Email: "somchai@example.com", Name: "Somchai"}
+	result, err := env.signIn(t, env.facebook, domain.OAuthProviderFacebook, "", profile)
+	if err != nil {
+		t.Fatalf("Callback() error = %v", err)
+	}
+	if result.User.Email != "somchai@example.com" || env.identity.identities["facebook/fb-1"] == nil {
+		t.Errorf("Callback() = %+v, want a user linked to facebook/fb-1", result.User)
+	}
+
+	_, err = env.signIn(t, env.facebook, domain.OAuthProviderFacebook, "", domain.OAuthProfile{Subject: "fb-2"})
+	if !errors.Is(err, ErrOAuthEmailRequired) {
+		t.Errorf("Callback() without email error = %v, want ErrOAuthEmailRequired", err)
+	}
+}
+
+func TestOAuthService_Callback_Rejected(t *testing.T) {
+	env := newOAuthTestEnv(t)
+	ctx := context.Background()
+
+	authorize, _ := env.service.Authorize(ctx, domain.OAuthProviderGoogle, "")
+	req := env.google.consent(t, authorize, googleJane)
+
+	tampered := *req
+	tampered.State = req.State[:len(req.State)-2] + "xx"
+	if _, err := env.service.Callback(ctx, domain.OAuthProviderGoogle, &tampered, "", ""); !errors.Is(err, ErrOAuthInvalidState) {
+		t.Errorf("Callback() with tampered state error = %v, want ErrOAuthInvalidState", err)
+	}
+	if _, err := env.service.Callback(ctx, domain.OAuthProviderFacebook, req, "", ""); !errors.Is(err, ErrOAuthInvalidState) {
+		t.Errorf("Callback() with another provider's state error = %v, want ErrOAuthInvalidState", err)
+	}
+	if _, err := env.auth.ValidateToken(ctx, req.State); err == nil {
+		t.Error("ValidateToken() accepted a state as an access token")
+	}
+
+	// An ID token issued to another client
+	env.google.audience = "other-client"
+	if _, err := env.service.Callback(ctx, domain.OAuthProviderGoogle, req, "", ""); !errors.Is(err, ErrOAuthExchangeFailed) {
+		t.Errorf("Callback() with foreign ID token error = %v, want ErrOAuthExchangeFailed", err)
+	}
+	env.google.audience = ""
+
+	// The code was already redeemed
+	if _, err := env.service.Callback(ctx, domain.OAuthProviderGoogle, req, "", ""); !errors.Is(err, ErrOAuthExchangeFailed) {
+		t.Errorf("Callback() with a used code error = %v, want ErrOAuthExchangeFailed", err)
+	}
+
+	// A consent page opened with another nonce
+	authorize, _ = env.service.Authorize(ctx, domain.OAuthProviderGoogle, "")
+	req = env.google.consent(t, authorize, googleJane)
+	grant := env.google.codes[req.Code]
+	grant.nonce = "replayed"
+	env.google.codes[req.Code] = grant
+	if _, err := env.service.Callback(ctx, domain.OAuthProviderGoogle, req, "", ""); !errors.Is(err, ErrOAuthExchangeFailed) {
+		t.Errorf("Callback() with nonce mismatch error = %v, want ErrOAuthExchangeFailed", err)
+	}
+	if len(env.users.users) != 0 {
+		t.Errorf("rejected callbacks created %d users", len(env.users.users))
+	}
+}
+
+func TestOAuthService_TenantProviders(t *testing.T) {
+	env := newOAuthTestEnv(t)
+	ctx := context.Background()
+	tenantID := "6f1c1b1e-9a55-4d43-9a0e-0a3c7d2b1f00"
+	env.tenants.tenants[tenantID] = &domain.Tenant{ID: tenantID, Name: "Organizer"}
+
+	providers, _ := env.service.Providers(ctx, "")
+	if strings.Join(providers, ",") != "google,facebook" {
+		t.Errorf("Providers() = %v, want the platform's google and facebook", providers)
+	}
+
+	if _, err := env.service.SaveTenantProvider(ctx, tenantID, "admin-1", domain.OAuthProviderLINE, &dto.SaveOAuthProviderRequest{
+		ClientID: "line-client", RedirectURL: "https://organizer.example.com/auth/line",
+	}); !errors.Is(err, ErrOAuthClientSecretRequired) {
+		t.Errorf("SaveTenantProvider() without secret error = %v, want ErrOAuthClientSecretRequired", err)
+	}
+
+	// The tenant signs in to Google with its own client and turns Facebook off
+	config, err := env.service.SaveTenantProvider(ctx, tenantID, "admin-1", domain.OAuthProviderGoogle, &dto.SaveOAuthProviderRequest{
+		ClientID: "tenant-client", ClientSecret: "tenant-secret", RedirectURL: "https://organizer.example.com/auth/google",
+	})
+	if err != nil {
+		t.Fatalf("SaveTenantProvider() error = %v", err)
+	}
+	if !config.Enabled || config.UpdatedBy != "admin-1" {
+		t.Errorf("SaveTenantProvider() = %+v, want an enabled client", config)
+	}
+	disabled := false
+	if _, err := env.service.SaveTenantProvider(ctx, tenantID, "admin-1", domain.OAuthProviderFacebook, &dto.SaveOAuthProviderRequest{
+		ClientID: "fb", ClientSecret: "fb-secret", RedirectURL: "https://organizer.example.com/auth/facebook", Enabled: &disabled,
+	}); err != nil {
+		t.Fatalf("SaveTenantProvider() error = %v", err)
+	}
+
+	providers, _ = env.service.Providers(ctx, tenantID)
+	if strings.Join(providers, ",") != "google" {
+		t.Errorf("Providers(tenant) = %v, want google only", providers)
+	}
+	authorize, err := env.service.Authorize(ctx, domain.OAuthProviderGoogle, tenantID)
+	if err != nil {
+		t.Fatalf("Authorize() error = %v", err)
+	}
+	if !strings.Contains(authorize.AuthorizationURL, "client_id=tenant-client") {
+		t.Errorf("Authorize() = %s, want the tenant's client", authorize.AuthorizationURL)
+	}
+	if _, err := env.service.Authorize(ctx, domain.OAuthProviderFacebook, tenantID); !errors.Is(err, ErrOAuthProviderNotConfigured) {
+		t.Errorf("Authorize() with a disabled client error = %v, want ErrOAuthProviderNotConfigured", err)
+	}
+
+	// The code is redeemed with the tenant's secret
+	env.google.clientID, env.google.secret = "tenant-client", "tenant-secret"
+	if _, err := env.service.Callback(ctx, domain.OAuthProviderGoogle, env.google.consent(t, authorize, googleJane), "", ""); err != nil {
+		t.Fatalf("Callback() with the tenant's client error = %v", err)
+	}
+
+	// Updating without a secret keeps it
+	config, err = env.service.SaveTenantProvider(ctx, tenantID, "admin-2", domain.OAuthProviderGoogle, &dto.SaveOAuthProviderRequest{
+		ClientID: "tenant-client", RedirectURL: "https://organizer.example.com/auth/google",
+	})
+	if err != nil || config.ClientSecret != "tenant-secret" {
+		t.Errorf("SaveTenantProvider() without secret = %+v, %v, want the stored secret kept", config, err)
+	}
+
+	if err := env.service.DeleteTenantProvider(ctx, tenantID, domain.OAuthProviderFacebook); err != nil {
+		t.Fatalf("DeleteTenantProvider() error = %v", err)
+	}
+	providers, _ = env.service.Providers(ctx, tenantID)
+	if strings.Join(providers, ",") != "google,facebook" {
+		t.Errorf("Providers(tenant) after delete = %v, want the platform's facebook back", providers)
+	}
+
+	if _, err := env.service.ListTenantProviders(ctx, "unknown"); !errors.Is(err, ErrTenantNotFound) {
+		t.Errorf("ListTenantProviders(unknown) error = %v, want ErrTenantNotFound", err)
+	}
+	if _, err := env.service.Authorize(ctx, "twitter", ""); !errors.Is(err, ErrOAuthProviderUnsupported) {
+		t.Errorf("Authorize(twitter) error = %v, want ErrOAuthProviderUnsupported", err)
+	}
+}
@@ -0,0 +1,482 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	pkgsaga "github.com/prohmpiriya/booking-rush-10k-rps/pkg/saga"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/storage"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+var (
+	ErrDataExportNotFound    = errors.New("data export not found")
+	ErrDataExportNotReady    = errors.New("data export is not ready")
+	ErrDataExportExpired     = errors.New("data export has expired")
+	ErrAccountDeletionFailed = errors.New("account deletion failed")
+)
+
+// AccountDeletionSagaName is the saga definition name for coordinated account deletion
+const AccountDeletionSagaName = "account-deletion"
+
+// DataExportPrefix is the object storage prefix of data export archives
+const DataExportPrefix = "data-exports/"
+
+// PrivacyServiceConfig holds configuration for PrivacyService
+type PrivacyServiceConfig struct {
+	ExportTTL     time.Duration // How long a compiled archive can be downloaded
+	ExportTimeout time.Duration // Max time to compile an archive
+	DeleteTimeout time.Duration // Max time for the whole deletion saga
+
+	// Archives keeps compiled archives in object storage instead of the database when set
+	Archives    storage.Store
+	DownloadTTL time.Duration // How long a signed archive download URL is valid
+}
+
+// PrivacyService handles GDPR data export and account deletion
+type PrivacyService interface {
+	// RequestDataExport creates an export request and compiles the archive asynchronously
+	RequestDataExport(ctx context.Context, userID string) (*domain.DataExport, error)
+	// GetDataExport retrieves an export owned by the user (including the archive when ready)
+	GetDataExport(ctx context.Context, userID, exportID string) (*domain.DataExport, error)
+	// DataExportURL returns a signed download URL of an archive kept in object storage
+	DataExportURL(ctx context.Context, export *domain.DataExport) (string, error)
+	// DeleteAccount anonymizes the user's PII in every service via the account deletion saga
+	DeleteAccount(ctx context.Context, userID string) (*dto.AccountDeletionResponse, error)
+}
+
+// privacyService implements PrivacyService
+type privacyService struct {
+	userRepo     repository.UserRepository
+	sessionRepo  repository.SessionRepository
+	exportRepo   repository.DataExportRepository
+	deviceRepo   repository.DeviceRepository
+	purchaseRepo repository.PurchaseRepository
+	identityRepo repository.UserIdentityRepository
+	clients      []UserDataClient
+	orchestrator *pkgsaga.Orchestrator
+	config       *PrivacyServiceConfig
+}
+
+// NewPrivacyService creates a new PrivacyService.
+// clients are the other services holding user data (e.g. booking and payment).
+func NewPrivacyService(
+	userRepo repository.UserRepository,
+	sessionRepo repository.SessionRepository,
+	exportRepo repository.DataExportRepository,
+	deviceRepo repository.DeviceRepository,
+	purchaseRepo repository.PurchaseRepository,
+	identityRepo repository.UserIdentityRepository,
+	clients []UserDataClient,
+	config *PrivacyServiceConfig,
+) PrivacyService {
+	if config == nil {
+		config = &PrivacyServiceConfig{}
+	}
+	if config.ExportTTL == 0 {
+		config.ExportTTL = 7 * 24 * time.Hour
+	}
+	if config.ExportTimeout == 0 {
+		config.ExportTimeout = 2 * time.Minute
+	}
+	if config.DeleteTimeout == 0 {
+		config.DeleteTimeout = 8 * time.Second // Fits within the HTTP server write timeout
+	}
+	if config.DownloadTTL == 0 {
+		config.DownloadTTL = 5 * time.Minute
+	}
+
+	s := &privacyService{
+		userRepo:     userRepo,
+		sessionRepo:  sessionRepo,
+		exportRepo:   exportRepo,
+		deviceRepo:   deviceRepo,
+		purchaseRepo: purchaseRepo,
+		identityRepo: identityRepo,
+		clients:      clients,
+		orchestrator: pkgsaga.NewOrchestrator(&pkgsaga.OrchestratorConfig{
+			Store: pkgsaga.NewMemoryStore(),
+		}),
+		config: config,
+	}
+	_ = s.orchestrator.RegisterDefinition(s.accountDeletionSaga())
+	return s
+}
+
+// RequestDataExport creates an export request and compiles the archive asynchronously
+func (s *privacyService) RequestDataExport(ctx context.Context, userID string) (*domain.DataExport, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.privacy.request_data_export")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", userID))
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if user == nil {
+		span.SetStatus(codes.Error, "user not found")
+		return nil, ErrUserNotFound
+	}
+
+	now := time.Now()
+	export := &domain.DataExport{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Status:    domain.DataExportStatusPending,
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.config.ExportTTL),
+	}
+
+	if err := s.exportRepo.Create(ctx, export); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	// Compile a copy in the background; the request context ends when the response is sent
+	pending := *export
+	go s.compileExport(context.WithoutCancel(ctx), &pending)
+
+	span.SetAttributes(attribute.String("export_id", export.ID))
+	span.SetStatus(codes.Ok, "")
+	return export, nil
+}
+
+// GetDataExport retrieves an export owned by the user
+func (s *privacyService) GetDataExport(ctx context.Context, userID, exportID string) (*domain.DataExport, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.privacy.get_data_export")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID),
+		attribute.String("export_id", exportID),
+	)
+
+	export, err := s.exportRepo.GetByID(ctx, exportID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	// Exports of other users are reported as not found to avoid leaking their existence
+	if export == nil || export.UserID != userID {
+		span.SetStatus(codes.Error, "data export not found")
+		return nil, ErrDataExportNotFound
+	}
+
+	span.SetAttributes(attribute.String("status", string(export.Status)))
+	span.SetStatus(codes.Ok, "")
+	return export, nil
+}
+
+// compileExport gathers the user's data from every service and stores it as a zip archive
+func (s *privacyService) compileExport(ctx context.Context, export *domain.DataExport) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.ExportTimeout)
+	defer cancel()
+
+	ctx, span := telemetry.StartSpan(ctx, "service.privacy.compile_data_export")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", export.UserID),
+		attribute.String("export_id", export.ID),
+	)
+
+	archive, err := s.buildArchive(ctx, export.UserID)
+	if err == nil {
+		err = s.keepArchive(ctx, export, archive)
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		export.MarkFailed(err)
+		logger.Get().Warn(fmt.Sprintf("Data export failed: export_id=%s, user_id=%s, error=%v", export.ID, export.UserID, err))
+	} else {
+		span.SetAttributes(attribute.Int64("size_bytes", export.SizeBytes))
+		span.SetStatus(codes.Ok, "")
+	}
+
+	if err := s.exportRepo.Update(ctx, export); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		logger.Get().Error(fmt.Sprintf("Failed to save data export: export_id=%s, error=%v", export.ID, err))
+	}
+}
+
+// keepArchive stores a compiled archive in object storage, or in the export itself
+// without storage, and marks the export as ready
+func (s *privacyService) keepArchive(ctx context.Context, export *domain.DataExport, archive []byte) error {
+	if s.config.Archives == nil {
+		export.MarkReady(archive)
+		return nil
+	}
+
+	key := DataExportPrefix + export.UserID + "/" + export.ID + ".zip"
+	object, err := s.config.Archives.Put(ctx, key, bytes.NewReader(archive), storage.PutOptions{
+		ContentType:        "application/zip",
+		ContentDisposition: fmt.Sprintf(`attachment; filename="data-export-%s.zip"`, export.ID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store archive: %w", err)
+	}
+	export.MarkStored(key, object.Size)
+	return nil
+}
+
+// DataExportURL returns a signed download URL of an archive kept in object storage,
+// valid for DownloadTTL but never past the export's expiry
+func (s *privacyService) DataExportURL(ctx context.Context, export *domain.DataExport) (string, error) {
+	if s.config.Archives == nil || export.ArchiveKey == "" {
+		return "", ErrDataExportNotReady
+	}
+	ttl := s.config.DownloadTTL
+	if remaining := time.Until(export.ExpiresAt); remaining < ttl {
+		ttl = remaining
+	}
+	if ttl <= 0 {
+		return "", ErrDataExportExpired
+	}
+	return s.config.Archives.SignedURL(ctx, export.ArchiveKey, ttl)
+}
+
+// buildArchive builds a zip archive with profile.json, sessions.json and one file per remote service
+func (s *privacyService) buildArchive(ctx context.Context, userID string) ([]byte, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	sessions, err := s.sessionRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string]interface{}{
+		"profile.json": dto.ExportedProfile{
+			ID:               user.ID,
+			Email:            user.Email,
+			Name:             user.Name,
+			Role:             string(user.Role),
+			TenantID:         user.TenantID,
+			StripeCustomerID: user.StripeCustomerID,
+			IsActive:         user.IsActive,
+			CreatedAt:        user.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:        user.UpdatedAt.Format(time.RFC3339),
+		},
+	}
+
+	exportedSessions := make([]dto.ExportedSession, 0, len(sessions))
+	for _, session := range sessions {
+		exportedSessions = append(exportedSessions, dto.ExportedSession{
+			ID:        session.ID,
+			UserAgent: session.UserAgent,
+			IP:        session.IP,
+			CreatedAt: session.CreatedAt.Format(time.RFC3339),
+			ExpiresAt: session.ExpiresAt.Format(time.RFC3339),
+		})
+	}
+	files["sessions.json"] = exportedSessions
+
+	for _, client := range s.clients {
+		data, err := client.ExportUserData(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export %s: %w", client.Name(), err)
+		}
+		files[client.Name()+".json"] = data
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		data, err := json.MarshalIndent(content, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		w, err := zw.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// DeleteAccount anonymizes the user's PII in every service via the account deletion saga
+func (s *privacyService) DeleteAccount(ctx context.Context, userID string) (*dto.AccountDeletionResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.privacy.delete_account")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", userID))
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if user == nil {
+		span.SetStatus(codes.Error, "user not found")
+		return nil, ErrUserNotFound
+	}
+
+	// The same pseudonymous ID replaces the user ID in every service so cross-service
+	// references (booking <-> payment) stay consistent after deletion
+	data := map[string]interface{}{
+		"user_id":       userID,
+		"anonymized_id": uuid.New().String(),
+		"was_active":    user.IsActive,
+	}
+
+	// Run detached from the request so a client disconnect cannot stop the saga halfway
+	instance, sagaErr := s.orchestrator.Execute(context.WithoutCancel(ctx), AccountDeletionSagaName, data)
+	if instance == nil {
+		span.RecordError(sagaErr)
+		span.SetStatus(codes.Error, sagaErr.Error())
+		return nil, sagaErr
+	}
+
+	resp := &dto.AccountDeletionResponse{
+		SagaID: instance.ID,
+		Status: string(instance.GetStatus()),
+		Steps:  make([]dto.AccountDeletionStep, 0, len(instance.StepResults)),
+	}
+	for _, result := range instance.StepResults {
+		resp.Steps = append(resp.Steps, dto.AccountDeletionStep{
+			Name:   result.StepName,
+			Status: string(result.Status),
+			Error:  result.Error,
+		})
+	}
+
+	span.SetAttributes(
+		attribute.String("saga_id", instance.ID),
+		attribute.String("status", resp.Status),
+	)
+
+	if sagaErr != nil {
+		span.RecordError(sagaErr)
+		span.SetStatus(codes.Error, sagaErr.Error())
+		return resp, fmt.Errorf("%w: %s", ErrAccountDeletionFailed, instance.Error)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return resp, nil
+}
+
+// accountDeletionSaga defines the coordinated deletion:
+//  1. revoke-access: deactivate the account and drop sessions (compensated by reactivating)
+//  2. anonymize-<service>: anonymize records in each remote service (idempotent, retried)
+//  3. anonymize-account: irreversibly anonymize the auth user and purge data exports, push devices and purchase history
+//
+// If a remote service fails, access is restored and the user can retry; records already
+// anonymized in other services stay anonymized and are skipped on the next run.
+func (s *privacyService) accountDeletionSaga() *pkgsaga.Definition {
+	def := pkgsaga.NewDefinition(AccountDeletionSagaName, "Anonymize user PII across auth, booking and payment").
+		WithTimeout(s.config.DeleteTimeout)
+
+	def.AddStep(&pkgsaga.Step{
+		Name:        "revoke-access",
+		Description: "Deactivate account and revoke all sessions",
+		Execute: func(ctx context.Context, data map[string]interface{}) (map[string]interface{}, error) {
+			return nil, s.setActive(ctx, data["user_id"].(string), false, true)
+		},
+		Compensate: func(ctx context.Context, data map[string]interface{}) error {
+			wasActive, _ := data["was_active"].(bool)
+			return s.setActive(ctx, data["user_id"].(string), wasActive, false)
+		},
+		Timeout: 2 * time.Second,
+	})
+
+	for _, client := range s.clients {
+		def.AddStep(&pkgsaga.Step{
+			Name:        "anonymize-" + client.Name(),
+			Description: fmt.Sprintf("Anonymize %s in remote service", client.Name()),
+			Execute: func(ctx context.Context, data map[string]interface{}) (map[string]interface{}, error) {
+				return nil, client.AnonymizeUserData(ctx, data["user_id"].(string), data["anonymized_id"].(string))
+			},
+			Timeout: 2 * time.Second,
+			Retries: 1,
+		})
+	}
+
+	def.AddStep(&pkgsaga.Step{
+		Name:        "anonymize-account",
+		Description: "Anonymize auth user and purge data exports, push devices, purchase history and linked logins",
+		Execute: func(ctx context.Context, data map[string]interface{}) (map[string]interface{}, error) {
+			userID := data["user_id"].(string)
+			if s.config.Archives != nil {
+				// Every archive of the user, so those still compiling are caught on retry
+				if _, err := s.config.Archives.DeleteBefore(ctx, DataExportPrefix+userID+"/", time.Now()); err != nil {
+					return nil, err
+				}
+			}
+			if err := s.exportRepo.DeleteByUserID(ctx, userID); err != nil {
+				return nil, err
+			}
+			if s.deviceRepo != nil {
+				if err := s.deviceRepo.DeleteByUserID(ctx, userID); err != nil {
+					return nil, err
+				}
+			}
+			if s.purchaseRepo != nil {
+				if err := s.purchaseRepo.DeleteByUserID(ctx, userID); err != nil {
+					return nil, err
+				}
+			}
+			if s.identityRepo != nil {
+				if err := s.identityRepo.DeleteByUserID(ctx, userID); err != nil {
+					return nil, err
+				}
+			}
+			return nil, s.userRepo.Anonymize(ctx, userID)
+		},
+		Timeout: 2 * time.Second,
+	})
+
+	return def
+}
+
+// setActive updates the account active flag, optionally revoking all sessions
+func (s *privacyService) setActive(ctx context.Context, userID string, active, revokeSessions bool) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	user.IsActive = active
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	if revokeSessions {
+		return s.sessionRepo.DeleteByUserID(ctx, userID)
+	}
+	return nil
+}
@@ -0,0 +1,307 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/storage"
+)
+
+// mockDataExportRepository is a mock implementation of DataExportRepository
+type mockDataExportRepository struct {
+	mu      sync.Mutex
+	exports map[string]*domain.DataExport
+}
+
+func newMockDataExportRepository() *mockDataExportRepository {
+	return &mockDataExportRepository{exports: make(map[string]*domain.DataExport)}
+}
+
+func (r *mockDataExportRepository) Create(ctx context.Context, export *domain.DataExport) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e := *export
+	r.exports[export.ID] = &e
+	return nil
+}
+
+func (r *mockDataExportRepository) GetByID(ctx context.Context, id string) (*domain.DataExport, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	export, ok := r.exports[id]
+	if !ok {
+		return nil, nil
+	}
+	e := *export
+	return &e, nil
+}
+
+func (r *mockDataExportRepository) Update(ctx context.Context, export *domain.DataExport) error {
+	return r.Create(ctx, export)
+}
+
+func (r *mockDataExportRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, export := range r.exports {
+		if export.UserID == userID {
+			delete(r.exports, id)
+		}
+	}
+	return nil
+}
+
+// mockUserDataClient is a mock implementation of UserDataClient
+type mockUserDataClient struct {
+	name          string
+	data          json.RawMessage
+	exportErr     error
+	anonymizeErr  error
+	anonymizedIDs []string
+}
+
+func (c *mockUserDataClient) Name() string { return c.name }
+
+func (c *mockUserDataClient) ExportUserData(ctx context.Context, userID string) (json.RawMessage, error) {
+	return c.data, c.exportErr
+}
+
+func (c *mockUserDataClient) AnonymizeUserData(ctx context.Context, userID, anonymizedID string) error {
+	if c.anonymizeErr != nil {
+		return c.anonymizeErr
+	}
+	c.anonymizedIDs = append(c.anonymizedIDs, anonymizedID)
+	return nil
+}
+
+func setupPrivacyService(clients ...UserDataClient) (*privacyService, *mockUserRepository, *mockSessionRepository, *mockDataExportRepository) {
+	userRepo := newMockUserRepository()
+	sessionRepo := newMockSessionRepository()
+	exportRepo := newMockDataExportRepository()
+
+	user := &domain.User{
+		ID:               "user-1",
+		Email:            "jane@example.com",
+		Name:             "Jane",
+		Role:             domain.RoleCustomer,
+		StripeCustomerID: "cus_123",
+		IsActive:         true,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+	_ = userRepo.Create(context.Background(), user)
+	_ = sessionRepo.Create(context.Background(), &domain.Session{
+		ID:           "session-1",
+		UserID:       user.ID,
+		RefreshToken: "secret-refresh-token",
+		UserAgent:    "test-agent",
+		IP:           "127.0.0.1",
+		ExpiresAt:    time.Now().Add(time.Hour),
+		CreatedAt:    time.Now(),
+	})
+
+	svc := NewPrivacyService(userRepo, sessionRepo, exportRepo, nil, nil, nil, clients, nil).(*privacyService)
+	return svc, userRepo, sessionRepo, exportRepo
+}
+
+func TestPrivacyService_CompileExport(t *testing.T) {
+	bookings := &mockUserDataClient{name: "bookings", data: json.RawMessage(`[{"id":"booking-1"}]`)}
+	payments := &mockUserDataClient{name: "payments", data: json.RawMessage(`[]`)}
+	svc, _, _, exportRepo := setupPrivacyService(bookings, payments)
+	ctx := context.Background()
+
+	export := &domain.DataExport{ID: "export-1", UserID: "user-1", Status: domain.DataExportStatusPending, ExpiresAt: time.Now().Add(time.Hour)}
+	_ = exportRepo.Create(ctx, export)
+
+	svc.compileExport(ctx, export)
+
+	stored, err := svc.GetDataExport(ctx, "user-1", "export-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stored.Status != domain.DataExportStatusReady {
+		t.Fatalf("expected status ready, got %s (error: %s)", stored.Status, stored.Error)
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(stored.Archive), int64(len(stored.Archive)))
+	if err != nil {
+		t.Fatalf("archive is not a valid zip: %v", err)
+	}
+
+	contents := make(map[string]string)
+	for _, f := range reader.File {
+		rc, _ := f.Open()
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		contents[f.Name] = string(data)
+	}
+
+	for _, name := range []string{"profile.json", "sessions.json", "bookings.json", "payments.json"} {
+		if _, ok := contents[name]; !ok {
+			t.Errorf("expected %s in archive", name)
+		}
+	}
+	if !strings.Contains(contents["profile.json"], "jane@example.com") {
+		t.Error("expected profile.json to contain the user's email")
+	}
+	if !strings.Contains(contents["bookings.json"], "booking-1") {
+		t.Error("expected bookings.json to contain remote booking data")
+	}
+	if strings.Contains(contents["sessions.json"], "secret-refresh-token") {
+		t.Error("refresh tokens must not be exported")
+	}
+}
+
+func TestPrivacyService_CompileExport_ObjectStorage(t *testing.T) {
+	svc, _, _, exportRepo := setupPrivacyService()
+	signer, _ := storage.NewURLSigner("http://localhost:8080/api/v1/files/auth", "signing-key")
+	archives, err := storage.NewLocalStore(t.TempDir(), signer)
+	if err != nil {
+		t.Fatalf("NewLocalStore() error = %v", err)
+	}
+	svc.config.Archives = archives
+	ctx := context.Background()
+
+	export := &domain.DataExport{ID: "export-1", UserID: "user-1", Status: domain.DataExportStatusPending, ExpiresAt: time.Now().Add(time.Hour)}
+	_ = exportRepo.Create(ctx, export)
+
+	svc.compileExport(ctx, export)
+
+	stored, _ := exportRepo.GetByID(ctx, "export-1")
+	if stored.Status != domain.DataExportStatusReady || stored.Archive != nil || stored.ArchiveKey != "data-exports/user-1/export-1.zip" {
+		t.Fatalf("expected a ready export kept in storage, got status %s, key %q (error: %s)", stored.Status, stored.ArchiveKey, stored.Error)
+	}
+	body, object, err := archives.Get(ctx, stored.ArchiveKey)
+	if err != nil {
+		t.Fatalf("archive not stored: %v", err)
+	}
+	body.Close()
+	if object.Size != stored.SizeBytes || object.ContentType != "application/zip" {
+		t.Errorf("stored object = %+v, export size = %d", object, stored.SizeBytes)
+	}
+
+	url, err := svc.DataExportURL(ctx, stored)
+	if err != nil || !strings.HasPrefix(url, "http://localhost:8080/api/v1/files/auth/data-exports/user-1/export-1.zip?") {
+		t.Errorf("DataExportURL() = %q, %v", url, err)
+	}
+
+	// Account deletion removes the stored archives too
+	if _, err := svc.DeleteAccount(ctx, "user-1"); err != nil {
+		t.Fatalf("DeleteAccount() error = %v", err)
+	}
+	if _, _, err := archives.Get(ctx, stored.ArchiveKey); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("archive after account deletion: %v, want ErrNotFound", err)
+	}
+}
+
+func TestPrivacyService_CompileExport_RemoteFailure(t *testing.T) {
+	bookings := &mockUserDataClient{name: "bookings", exportErr: errors.New("booking service unavailable")}
+	svc, _, _, exportRepo := setupPrivacyService(bookings)
+	ctx := context.Background()
+
+	export := &domain.DataExport{ID: "export-1", UserID: "user-1", Status: domain.DataExportStatusPending, ExpiresAt: time.Now().Add(time.Hour)}
+	_ = exportRepo.Create(ctx, export)
+
+	svc.compileExport(ctx, export)
+
+	stored, _ := exportRepo.GetByID(ctx, "export-1")
+	if stored.Status != domain.DataExportStatusFailed {
+		t.Errorf("expected status failed, got %s", stored.Status)
+	}
+	if stored.Archive != nil {
+		t.Error("expected no archive for failed export")
+	}
+}
+
+func TestPrivacyService_GetDataExport_OtherUser(t *testing.T) {
+	svc, _, _, exportRepo := setupPrivacyService()
+	ctx := context.Background()
+
+	_ = exportRepo.Create(ctx, &domain.DataExport{ID: "export-1", UserID: "user-1"})
+
+	if _, err := svc.GetDataExport(ctx, "user-2", "export-1"); !errors.Is(err, ErrDataExportNotFound) {
+		t.Errorf("expected ErrDataExportNotFound, got %v", err)
+	}
+}
+
+func TestPrivacyService_DeleteAccount(t *testing.T) {
+	bookings := &mockUserDataClient{name: "bookings"}
+	payments := &mockUserDataClient{name: "payments"}
+	svc, userRepo, sessionRepo, exportRepo := setupPrivacyService(bookings, payments)
+	ctx := context.Background()
+
+	_ = exportRepo.Create(ctx, &domain.DataExport{ID: "export-1", UserID: "user-1"})
+
+	result, err := svc.DeleteAccount(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != "completed" {
+		t.Errorf("expected saga completed, got %s", result.Status)
+	}
+	if len(result.Steps) != 4 {
+		t.Errorf("expected 4 saga steps, got %d", len(result.Steps))
+	}
+
+	user, _ := userRepo.GetByID(ctx, "user-1")
+	if user.IsActive || user.Email == "jane@example.com" || user.Name != "" {
+		t.Error("expected user to be anonymized and deactivated")
+	}
+
+	sessions, _ := sessionRepo.GetByUserID(ctx, "user-1")
+	if len(sessions) != 0 {
+		t.Error("expected all sessions to be revoked")
+	}
+
+	if export, _ := exportRepo.GetByID(ctx, "export-1"); export != nil {
+		t.Error("expected data exports to be purged")
+	}
+
+	// Every service must receive the same pseudonymous ID
+	if len(bookings.anonymizedIDs) != 1 || len(payments.anonymizedIDs) != 1 {
+		t.Fatal("expected each service to be anonymized once")
+	}
+	if bookings.anonymizedIDs[0] != payments.anonymizedIDs[0] {
+		t.Error("expected the same anonymized ID across services")
+	}
+}
+
+func TestPrivacyService_DeleteAccount_RemoteFailureRestoresAccess(t *testing.T) {
+	bookings := &mockUserDataClient{name: "bookings"}
+	payments := &mockUserDataClient{name: "payments", anonymizeErr: errors.New("payment service unavailable")}
+	svc, userRepo, _, _ := setupPrivacyService(bookings, payments)
+	ctx := context.Background()
+
+	result, err := svc.DeleteAccount(ctx, "user-1")
+	if !errors.Is(err, ErrAccountDeletionFailed) {
+		t.Fatalf("expected ErrAccountDeletionFailed, got %v", err)
+	}
+	if result == nil || result.Status != "compensated" {
+		t.Fatalf("expected compensated saga result, got %+v", result)
+	}
+
+	user, _ := userRepo.GetByID(ctx, "user-1")
+	if !user.IsActive {
+		t.Error("expected account access to be restored")
+	}
+	if user.Email != "jane@example.com" {
+		t.Error("expected auth profile to be left intact")
+	}
+}
+
+func TestPrivacyService_DeleteAccount_UserNotFound(t *testing.T) {
+	svc, _, _, _ := setupPrivacyService()
+
+	if _, err := svc.DeleteAccount(context.Background(), "missing"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+}
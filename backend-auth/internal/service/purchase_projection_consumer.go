@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+)
+
+// DefaultPurchaseProjectionGroupID is the consumer group feeding the purchase history projection
+const DefaultPurchaseProjectionGroupID = "auth-purchase-summary"
+
+// PurchaseProjectionConsumerConfig holds configuration for PurchaseProjectionConsumer
+type PurchaseProjectionConsumerConfig struct {
+	Brokers  []string
+	GroupID  string
+	ClientID string
+}
+
+// PurchaseProjectionConsumer feeds booking and payment events to PurchaseService
+type PurchaseProjectionConsumer struct {
+	consumer        *kafka.Consumer
+	purchaseService PurchaseService
+}
+
+// NewPurchaseProjectionConsumer creates a consumer of booking-events and payment-events
+func NewPurchaseProjectionConsumer(ctx context.Context, cfg *PurchaseProjectionConsumerConfig, purchaseService PurchaseService) (*PurchaseProjectionConsumer, error) {
+	if cfg == nil || len(cfg.Brokers) == 0 {
+		return nil, fmt.Errorf("kafka brokers are required")
+	}
+	groupID := cfg.GroupID
+	if groupID == "" {
+		groupID = DefaultPurchaseProjectionGroupID
+	}
+	clientID := cfg.ClientID
+	if clientID == "" {
+		clientID = "auth-service-purchases"
+	}
+
+	consumer, err := kafka.NewConsumer(ctx, &kafka.ConsumerConfig{
+		Brokers:  cfg.Brokers,
+		GroupID:  groupID,
+		Topics:   []string{TopicBookingEvents, TopicPaymentEvents},
+		ClientID: clientID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka consumer: %w", err)
+	}
+
+	return &PurchaseProjectionConsumer{
+		consumer:        consumer,
+		purchaseService: purchaseService,
+	}, nil
+}
+
+// Run consumes events until ctx is cancelled. Events that fail to apply are logged
+// and committed, so a bad event cannot stall the projection.
+func (c *PurchaseProjectionConsumer) Run(ctx context.Context) {
+	log := logger.Get()
+	for {
+		records, err := c.consumer.Poll(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.ErrorContext(ctx, fmt.Sprintf("Failed to poll purchase events: %v", err))
+			time.Sleep(time.Second)
+			continue
+		}
+
+		for _, record := range records {
+			recordCtx := record.ExtractContext(ctx)
+			if err := c.purchaseService.HandleEvent(recordCtx, record.Topic, record.Value); err != nil {
+				log.ErrorContext(recordCtx, fmt.Sprintf("Failed to apply %s event to purchase history: %v", record.Topic, err))
+			}
+		}
+
+		if len(records) > 0 {
+			if err := c.consumer.CommitRecords(ctx, records); err != nil {
+				log.ErrorContext(ctx, fmt.Sprintf("Failed to commit purchase events: %v", err))
+			}
+		}
+	}
+}
+
+// Close closes the underlying Kafka consumer
+func (c *PurchaseProjectionConsumer) Close() {
+	c.consumer.Close()
+}
@@ -0,0 +1,325 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/events"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// Topics the purchase history projection is fed from
+const (
+	TopicBookingEvents = events.TopicBookingEvents
+	TopicPaymentEvents = events.TopicPaymentEvents
+)
+
+// DefaultRecentPurchases is how many purchases the summary lists by default
+const DefaultRecentPurchases = 10
+
+// PurchaseServiceConfig holds configuration for PurchaseService
+type PurchaseServiceConfig struct {
+	// Shows resolves show start times when a booking is confirmed (nil = every
+	// confirmed booking is reported as upcoming)
+	Shows ShowScheduleClient
+	// RecentPurchases is how many purchases the summary lists
+	RecentPurchases int
+}
+
+// PurchaseService maintains the purchase history projection and summarizes it per user.
+// Summaries are read from the projection only, so requests never fan out to the
+// booking, payment or ticket services.
+type PurchaseService interface {
+	// GetSummary returns the user's spending, attended and upcoming events
+	GetSummary(ctx context.Context, userID string) (*dto.PurchaseSummaryResponse, error)
+	// HandleEvent applies a booking or payment event consumed from topic to the projection
+	HandleEvent(ctx context.Context, topic string, value []byte) error
+}
+
+// purchaseService implements PurchaseService
+type purchaseService struct {
+	purchaseRepo repository.PurchaseRepository
+	config       *PurchaseServiceConfig
+	now          func() time.Time
+}
+
+// NewPurchaseService creates a new PurchaseService
+func NewPurchaseService(purchaseRepo repository.PurchaseRepository, config *PurchaseServiceConfig) PurchaseService {
+	if config == nil {
+		config = &PurchaseServiceConfig{}
+	}
+	if config.RecentPurchases <= 0 {
+		config.RecentPurchases = DefaultRecentPurchases
+	}
+	return &purchaseService{
+		purchaseRepo: purchaseRepo,
+		config:       config,
+		now:          time.Now,
+	}
+}
+
+// GetSummary returns the user's spending, attended and upcoming events
+func (s *purchaseService) GetSummary(ctx context.Context, userID string) (*dto.PurchaseSummaryResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.purchase.get_summary")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", userID))
+
+	purchases, err := s.purchaseRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	now := s.now()
+	summary := &dto.PurchaseSummaryResponse{
+		TotalSpent:      []dto.CurrencyAmount{},
+		UpcomingEvents:  []dto.UpcomingEvent{},
+		RecentPurchases: []dto.PurchaseResponse{},
+	}
+	spent := make(map[string]float64)
+	attended := make(map[string]bool)
+	upcoming := make(map[string]*dto.UpcomingEvent)
+
+	for _, p := range purchases {
+		if amount := p.Spent(); amount > 0 {
+			spent[p.Currency] += amount
+		}
+
+		// Payment events seen before the booking's own event are not purchases yet
+		if p.Status == domain.PurchaseStatusPending {
+			continue
+		}
+		if len(summary.RecentPurchases) < s.config.RecentPurchases {
+			summary.RecentPurchases = append(summary.RecentPurchases, toPurchaseResponse(p))
+		}
+		if p.Status != domain.PurchaseStatusConfirmed {
+			continue
+		}
+
+		summary.ConfirmedBookings++
+		summary.TicketsPurchased += p.Quantity
+
+		if p.ShowStartsAt != nil && p.ShowStartsAt.Before(now) {
+			attended[p.EventID] = true
+			continue
+		}
+		key := p.ShowID
+		if key == "" {
+			key = "event:" + p.EventID
+		}
+		if event, ok := upcoming[key]; ok {
+			event.Tickets += p.Quantity
+			continue
+		}
+		upcoming[key] = &dto.UpcomingEvent{
+			EventID:  p.EventID,
+			ShowID:   p.ShowID,
+			StartsAt: p.ShowStartsAt,
+			Tickets:  p.Quantity,
+		}
+	}
+
+	for currency, amount := range spent {
+		summary.TotalSpent = append(summary.TotalSpent, dto.CurrencyAmount{Currency: currency, Amount: amount})
+	}
+	sort.Slice(summary.TotalSpent, func(i, j int) bool {
+		return summary.TotalSpent[i].Currency < summary.TotalSpent[j].Currency
+	})
+
+	summary.EventsAttended = len(attended)
+
+	// Soonest first; shows without a resolved start time go last
+	for _, event := range upcoming {
+		summary.UpcomingEvents = append(summary.UpcomingEvents, *event)
+	}
+	sort.Slice(summary.UpcomingEvents, func(i, j int) bool {
+		a, b := summary.UpcomingEvents[i], summary.UpcomingEvents[j]
+		switch {
+		case a.StartsAt == nil || b.StartsAt == nil:
+			if (a.StartsAt == nil) != (b.StartsAt == nil) {
+				return b.StartsAt == nil
+			}
+			return a.ShowID < b.ShowID
+		case !a.StartsAt.Equal(*b.StartsAt):
+			return a.StartsAt.Before(*b.StartsAt)
+		default:
+			return a.ShowID < b.ShowID
+		}
+	})
+
+	span.SetAttributes(attribute.Int("purchases", len(purchases)))
+	span.SetStatus(codes.Ok, "")
+	return summary, nil
+}
+
+// HandleEvent applies a booking or payment event consumed from topic to the projection
+func (s *purchaseService) HandleEvent(ctx context.Context, topic string, value []byte) error {
+	switch topic {
+	case TopicBookingEvents:
+		return s.handleBookingEvent(ctx, value)
+	case TopicPaymentEvents:
+		return s.handlePaymentEvent(ctx, value)
+	default:
+		return nil
+	}
+}
+
+// handleBookingEvent records confirmed bookings and applies later changes to them.
+// Bookings never confirmed are not purchases, so other events only update known ones.
+func (s *purchaseService) handleBookingEvent(ctx context.Context, value []byte) error {
+	var event events.BookingEvent
+	if err := json.Unmarshal(value, &event); err != nil {
+		return fmt.Errorf("failed to unmarshal booking event: %w", err)
+	}
+	if event.BookingData == nil || event.BookingData.BookingID == "" || event.BookingData.UserID == "" {
+		return nil
+	}
+
+	var status domain.PurchaseStatus
+	insert := false
+	switch event.EventType {
+	case events.BookingEventConfirmed:
+		status, insert = domain.PurchaseStatusConfirmed, true
+	case events.BookingEventModified:
+		status = domain.PurchaseStatusConfirmed
+	case events.BookingEventCancelled:
+		status = domain.PurchaseStatusCancelled
+	default:
+		return nil
+	}
+
+	ctx, span := telemetry.StartSpan(ctx, "service.purchase.apply_booking_event")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("booking_id", event.BookingData.BookingID),
+		attribute.String("event_type", string(event.EventType)),
+	)
+
+	occurredAt := event.OccurredAt
+	if occurredAt.IsZero() {
+		occurredAt = s.now()
+	}
+	update := &domain.PurchaseBookingUpdate{
+		BookingID:   event.BookingData.BookingID,
+		UserID:      event.BookingData.UserID,
+		EventID:     event.BookingData.EventID,
+		ShowID:      event.BookingData.ShowID,
+		Quantity:    event.BookingData.Quantity,
+		Amount:      event.BookingData.TotalPrice,
+		Currency:    event.BookingData.Currency,
+		Status:      status,
+		ConfirmedAt: event.BookingData.ConfirmedAt,
+		OccurredAt:  occurredAt,
+	}
+	if update.Currency == "" {
+		update.Currency = "THB"
+	}
+	if err := s.purchaseRepo.ApplyBookingEvent(ctx, update, insert); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	// Start times are resolved once per show here, so summaries never call the ticket service.
+	// A failure leaves the show upcoming until another booking for it is confirmed.
+	if insert && update.ShowID != "" {
+		if err := s.resolveShowStartTime(ctx, update.ShowID); err != nil {
+			logger.Get().WarnContext(ctx, fmt.Sprintf("Failed to resolve start time of show %s: %v", update.ShowID, err))
+		}
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// resolveShowStartTime records the show's start time unless it is already known
+func (s *purchaseService) resolveShowStartTime(ctx context.Context, showID string) error {
+	if s.config.Shows == nil {
+		return nil
+	}
+	known, err := s.purchaseRepo.HasShowStartTime(ctx, showID)
+	if err != nil || known {
+		return err
+	}
+	startsAt, err := s.config.Shows.GetShowStartTime(ctx, showID)
+	if err != nil {
+		return err
+	}
+	return s.purchaseRepo.SaveShowStartTime(ctx, showID, startsAt)
+}
+
+// handlePaymentEvent records what was charged and refunded for a booking
+func (s *purchaseService) handlePaymentEvent(ctx context.Context, value []byte) error {
+	var event events.PaymentEvent
+	if err := json.Unmarshal(value, &event); err != nil {
+		return fmt.Errorf("failed to unmarshal payment event: %w", err)
+	}
+	if event.PaymentData == nil || event.PaymentData.BookingID == "" || event.PaymentData.UserID == "" {
+		return nil
+	}
+
+	amount := event.PaymentData.Amount
+	update := &domain.PurchasePaymentUpdate{
+		BookingID:  event.PaymentData.BookingID,
+		UserID:     event.PaymentData.UserID,
+		Currency:   event.PaymentData.Currency,
+		OccurredAt: event.OccurredAt,
+	}
+	switch event.EventType {
+	case events.PaymentEventSuccess:
+		update.Paid = &amount
+	case events.PaymentEventRefunded:
+		update.Refunded = &amount
+	default:
+		return nil
+	}
+	if update.Currency == "" {
+		update.Currency = "THB"
+	}
+	if update.OccurredAt.IsZero() {
+		update.OccurredAt = s.now()
+	}
+
+	ctx, span := telemetry.StartSpan(ctx, "service.purchase.apply_payment_event")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("booking_id", update.BookingID),
+		attribute.String("event_type", string(event.EventType)),
+	)
+
+	if err := s.purchaseRepo.ApplyPaymentEvent(ctx, update); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// toPurchaseResponse converts a purchase to its response
+func toPurchaseResponse(p *domain.Purchase) dto.PurchaseResponse {
+	return dto.PurchaseResponse{
+		BookingID:   p.BookingID,
+		EventID:     p.EventID,
+		ShowID:      p.ShowID,
+		Quantity:    p.Quantity,
+		Status:      string(p.Status),
+		Spent:       p.Spent(),
+		Currency:    p.Currency,
+		ConfirmedAt: p.ConfirmedAt,
+	}
+}
@@ -0,0 +1,356 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+)
+
+// mockPurchaseRepository is an in-memory PurchaseRepository keyed by booking
+type mockPurchaseRepository struct {
+	purchases  map[string]*domain.Purchase
+	bookingAt  map[string]time.Time
+	showStarts map[string]time.Time
+	listErr    error
+}
+
+func newMockPurchaseRepository() *mockPurchaseRepository {
+	return &mockPurchaseRepository{
+		purchases:  make(map[string]*domain.Purchase),
+		bookingAt:  make(map[string]time.Time),
+		showStarts: make(map[string]time.Time),
+	}
+}
+
+func (r *mockPurchaseRepository) ApplyBookingEvent(ctx context.Context, update *domain.PurchaseBookingUpdate, insert bool) error {
+	p, ok := r.purchases[update.BookingID]
+	if !ok {
+		if !insert {
+			return nil
+		}
+		p = &domain.Purchase{BookingID: update.BookingID}
+		r.purchases[update.BookingID] = p
+	}
+	if last, seen := r.bookingAt[update.BookingID]; seen && !last.Before(update.OccurredAt) {
+		return nil
+	}
+	r.bookingAt[update.BookingID] = update.OccurredAt
+	if insert {
+		p.UserID, p.EventID = update.UserID, update.EventID
+		if update.ShowID != "" {
+			p.ShowID = update.ShowID
+		}
+	}
+	p.Quantity = update.Quantity
+	p.BookingAmount = update.Amount
+	p.Currency = update.Currency
+	p.Status = update.Status
+	if update.ConfirmedAt != nil {
+		p.ConfirmedAt = update.ConfirmedAt
+	}
+	return nil
+}
+
+func (r *mockPurchaseRepository) ApplyPaymentEvent(ctx context.Context, update *domain.PurchasePaymentUpdate) error {
+	p, ok := r.purchases[update.BookingID]
+	if !ok {
+		p = &domain.Purchase{
+			BookingID: update.BookingID,
+			UserID:    update.UserID,
+			Currency:  update.Currency,
+			Status:    domain.PurchaseStatusPending,
+		}
+		r.purchases[update.BookingID] = p
+	}
+	if update.Paid != nil {
+		paid := *update.Paid
+		p.PaidAmount = &paid
+	}
+	if update.Refunded != nil && *update.Refunded > p.RefundedAmount {
+		p.RefundedAmount = *update.Refunded
+	}
+	return nil
+}
+
+func (r *mockPurchaseRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.Purchase, error) {
+	if r.listErr != nil {
+		return nil, r.listErr
+	}
+	var purchases []*domain.Purchase
+	for _, p := range r.purchases {
+		if p.UserID != userID {
+			continue
+		}
+		listed := *p
+		if startsAt, ok := r.showStarts[p.ShowID]; ok {
+			listed.ShowStartsAt = &startsAt
+		}
+		purchases = append(purchases, &listed)
+	}
+	sort.Slice(purchases, func(i, j int) bool {
+		a, b := purchases[i].ConfirmedAt, purchases[j].ConfirmedAt
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		return a.After(*b)
+	})
+	return purchases, nil
+}
+
+func (r *mockPurchaseRepository) HasShowStartTime(ctx context.Context, showID string) (bool, error) {
+	_, ok := r.showStarts[showID]
+	return ok, nil
+}
+
+func (r *mockPurchaseRepository) SaveShowStartTime(ctx context.Context, showID string, startsAt time.Time) error {
+	r.showStarts[showID] = startsAt
+	return nil
+}
+
+func (r *mockPurchaseRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	for id, p := range r.purchases {
+		if p.UserID == userID {
+			delete(r.purchases, id)
+		}
+	}
+	return nil
+}
+
+// mockShowScheduleClient returns canned show start times and counts lookups
+type mockShowScheduleClient struct {
+	starts map[string]time.Time
+	calls  int
+}
+
+func (c *mockShowScheduleClient) GetShowStartTime(ctx context.Context, showID string) (time.Time, error) {
+	c.calls++
+	startsAt, ok := c.starts[showID]
+	if !ok {
+		return time.Time{}, errors.New("show not found")
+	}
+	return startsAt, nil
+}
+
+var purchaseTestNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func setupPurchaseService(shows ShowScheduleClient) (*purchaseService, *mockPurchaseRepository) {
+	repo := newMockPurchaseRepository()
+	svc := NewPurchaseService(repo, &PurchaseServiceConfig{Shows: shows}).(*purchaseService)
+	svc.now = func() time.Time { return purchaseTestNow }
+	return svc, repo
+}
+
+func bookingEventJSON(t *testing.T, eventType, bookingID, showID string, quantity int, total float64, occurredAt time.Time) []byte {
+	t.Helper()
+	data := map[string]interface{}{
+		"booking_id":  bookingID,
+		"user_id":     "user-1",
+		"event_id":    "event-" + showID,
+		"show_id":     showID,
+		"quantity":    quantity,
+		"total_price": total,
+		"currency":    "THB",
+	}
+	if eventType == "booking.confirmed" {
+		data["confirmed_at"] = occurredAt
+	}
+	value, err := json.Marshal(map[string]interface{}{
+		"event_type":  eventType,
+		"occurred_at": occurredAt,
+		"data":        data,
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal booking event: %v", err)
+	}
+	return value
+}
+
+func paymentEventJSON(t *testing.T, eventType, bookingID string, amount float64, occurredAt time.Time) []byte {
+	t.Helper()
+	value, err := json.Marshal(map[string]interface{}{
+		"event_type":  eventType,
+		"occurred_at": occurredAt,
+		"data": map[string]interface{}{
+			"booking_id": bookingID,
+			"user_id":    "user-1",
+			"amount":     amount,
+			"currency":   "THB",
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal payment event: %v", err)
+	}
+	return value
+}
+
+func TestPurchaseService_GetSummary(t *testing.T) {
+	shows := &mockShowScheduleClient{starts: map[string]time.Time{
+		"past":   purchaseTestNow.Add(-48 * time.Hour),
+		"future": purchaseTestNow.Add(72 * time.Hour),
+	}}
+	svc, _ := setupPurchaseService(shows)
+	ctx := context.Background()
+	at := purchaseTestNow.Add(-7 * 24 * time.Hour)
+
+	events := []struct {
+		topic string
+		value []byte
+	}{
+		{TopicBookingEvents, bookingEventJSON(t, "booking.confirmed", "b-past", "past", 2, 2000, at)},
+		{TopicBookingEvents, bookingEventJSON(t, "booking.confirmed", "b-future", "future", 1, 1500, at.Add(time.Hour))},
+		{TopicBookingEvents, bookingEventJSON(t, "booking.confirmed", "b-future-2", "future", 3, 4500, at.Add(2*time.Hour))},
+		// Cancelled after confirmation and partly refunded
+		{TopicBookingEvents, bookingEventJSON(t, "booking.confirmed", "b-cancelled", "future", 1, 1000, at.Add(3*time.Hour))},
+		{TopicPaymentEvents, paymentEventJSON(t, "payment.success", "b-cancelled", 1000, at.Add(3*time.Hour))},
+		{TopicBookingEvents, bookingEventJSON(t, "booking.cancelled", "b-cancelled", "future", 1, 1000, at.Add(4*time.Hour))},
+		{TopicPaymentEvents, paymentEventJSON(t, "payment.refunded", "b-cancelled", 800, at.Add(4*time.Hour))},
+		// Reserved but never confirmed
+		{TopicBookingEvents, bookingEventJSON(t, "booking.created", "b-created", "future", 1, 500, at)},
+		{TopicBookingEvents, bookingEventJSON(t, "booking.expired", "b-created", "future", 1, 500, at.Add(time.Hour))},
+	}
+	for _, e := range events {
+		if err := svc.HandleEvent(ctx, e.topic, e.value); err != nil {
+			t.Fatalf("HandleEvent() error = %v", err)
+		}
+	}
+
+	summary, err := svc.GetSummary(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("GetSummary() error = %v", err)
+	}
+
+	if len(summary.TotalSpent) != 1 || summary.TotalSpent[0].Currency != "THB" || summary.TotalSpent[0].Amount != 8200 {
+		t.Errorf("TotalSpent = %+v, want 8200 THB", summary.TotalSpent)
+	}
+	if summary.ConfirmedBookings != 3 {
+		t.Errorf("ConfirmedBookings = %d, want 3", summary.ConfirmedBookings)
+	}
+	if summary.TicketsPurchased != 6 {
+		t.Errorf("TicketsPurchased = %d, want 6", summary.TicketsPurchased)
+	}
+	if summary.EventsAttended != 1 {
+		t.Errorf("EventsAttended = %d, want 1", summary.EventsAttended)
+	}
+	if len(summary.UpcomingEvents) != 1 || summary.UpcomingEvents[0].ShowID != "future" || summary.UpcomingEvents[0].Tickets != 4 {
+		t.Errorf("UpcomingEvents = %+v, want 4 tickets for show future", summary.UpcomingEvents)
+	}
+	if len(summary.RecentPurchases) != 4 {
+		t.Fatalf("RecentPurchases = %d, want 4", len(summary.RecentPurchases))
+	}
+	if got := summary.RecentPurchases[0]; got.BookingID != "b-cancelled" || got.Status != "cancelled" || got.Spent != 200 {
+		t.Errorf("RecentPurchases[0] = %+v, want cancelled b-cancelled with 200 spent", got)
+	}
+
+	// Each show's start time is fetched once
+	if shows.calls != 2 {
+		t.Errorf("show lookups = %d, want 2", shows.calls)
+	}
+}
+
+func TestPurchaseService_HandleEvent_OutOfOrder(t *testing.T) {
+	svc, repo := setupPurchaseService(nil)
+	ctx := context.Background()
+	at := purchaseTestNow.Add(-time.Hour)
+
+	// A cancellation of a booking never confirmed is not a purchase
+	if err := svc.HandleEvent(ctx, TopicBookingEvents, bookingEventJSON(t, "booking.cancelled", "b-1", "show", 1, 100, at)); err != nil {
+		t.Fatalf("HandleEvent() error = %v", err)
+	}
+	if len(repo.purchases) != 0 {
+		t.Fatalf("purchases = %d, want 0", len(repo.purchases))
+	}
+
+	// A modification redelivered after a later one is ignored
+	_ = svc.HandleEvent(ctx, TopicBookingEvents, bookingEventJSON(t, "booking.confirmed", "b-1", "show", 2, 200, at))
+	_ = svc.HandleEvent(ctx, TopicBookingEvents, bookingEventJSON(t, "booking.modified", "b-1", "show", 4, 400, at.Add(2*time.Minute)))
+	_ = svc.HandleEvent(ctx, TopicBookingEvents, bookingEventJSON(t, "booking.modified", "b-1", "show", 3, 300, at.Add(time.Minute)))
+
+	summary, err := svc.GetSummary(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("GetSummary() error = %v", err)
+	}
+	if summary.TicketsPurchased != 4 || summary.TotalSpent[0].Amount != 400 {
+		t.Errorf("summary = %d tickets, %+v, want 4 tickets for 400", summary.TicketsPurchased, summary.TotalSpent)
+	}
+	// Without a show schedule, the show is upcoming with an unknown start
+	if len(summary.UpcomingEvents) != 1 || summary.UpcomingEvents[0].StartsAt != nil {
+		t.Errorf("UpcomingEvents = %+v, want one without a start time", summary.UpcomingEvents)
+	}
+}
+
+func TestPurchaseService_HandleEvent_PaymentBeforeBooking(t *testing.T) {
+	svc, _ := setupPurchaseService(nil)
+	ctx := context.Background()
+	at := purchaseTestNow.Add(-time.Hour)
+
+	_ = svc.HandleEvent(ctx, TopicPaymentEvents, paymentEventJSON(t, "payment.success", "b-1", 900, at))
+
+	summary, err := svc.GetSummary(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("GetSummary() error = %v", err)
+	}
+	if summary.ConfirmedBookings != 0 || len(summary.RecentPurchases) != 0 {
+		t.Errorf("summary = %+v, want no purchases before the booking is confirmed", summary)
+	}
+
+	// The charged amount wins over the booking price once the booking arrives
+	_ = svc.HandleEvent(ctx, TopicBookingEvents, bookingEventJSON(t, "booking.confirmed", "b-1", "show", 1, 1000, at))
+	summary, _ = svc.GetSummary(ctx, "user-1")
+	if summary.ConfirmedBookings != 1 || summary.TotalSpent[0].Amount != 900 {
+		t.Errorf("summary = %+v, want 1 booking with 900 spent", summary)
+	}
+}
+
+func TestPurchaseService_HandleEvent_InvalidPayload(t *testing.T) {
+	svc, _ := setupPurchaseService(nil)
+
+	if err := svc.HandleEvent(context.Background(), TopicBookingEvents, []byte("not json")); err == nil {
+		t.Error("HandleEvent() expected error for invalid payload")
+	}
+	if err := svc.HandleEvent(context.Background(), "other-topic", []byte("not json")); err != nil {
+		t.Errorf("HandleEvent() error = %v for unrelated topic", err)
+	}
+}
+
+func TestPurchaseService_GetSummary_Empty(t *testing.T) {
+	svc, repo := setupPurchaseService(nil)
+
+	summary, err := svc.GetSummary(context.Background(), "user-1")
+	if err != nil {
+		t.Fatalf("GetSummary() error = %v", err)
+	}
+	if summary.TotalSpent == nil || summary.UpcomingEvents == nil || summary.RecentPurchases == nil {
+		t.Errorf("summary = %+v, want empty lists rather than null", summary)
+	}
+
+	repo.listErr = errors.New("db down")
+	if _, err := svc.GetSummary(context.Background(), "user-1"); err == nil {
+		t.Error("GetSummary() expected error")
+	}
+}
+
+func TestShowStartTime(t *testing.T) {
+	timeOfDay := time.Date(0, 1, 1, 19, 30, 0, 0, time.UTC)
+	got, err := showStartTime("2026-05-20", timeOfDay)
+	if err != nil {
+		t.Fatalf("showStartTime() error = %v", err)
+	}
+	if want := time.Date(2026, 5, 20, 19, 30, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("showStartTime() = %v, want %v", got, want)
+	}
+
+	full := time.Date(2026, 5, 20, 2, 0, 0, 0, time.FixedZone("ICT", 7*3600))
+	got, _ = showStartTime("2026-05-20", full)
+	if !got.Equal(full) {
+		t.Errorf("showStartTime() = %v, want %v", got, full)
+	}
+
+	if _, err := showStartTime("bad", timeOfDay); err == nil {
+		t.Error("showStartTime() expected error for invalid date")
+	}
+}
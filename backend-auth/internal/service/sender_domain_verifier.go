@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/dto"
+)
+
+// Default sender domain verification settings
+const (
+	DefaultSenderSPFInclude    = "_spf.bookingrush.app"
+	DefaultSenderDKIMSelector  = "bookingrush"
+	DefaultSenderDKIMTarget    = "bookingrush._domainkey.bookingrush.app"
+	senderVerificationLabel    = "_bookingrush-verification"
+	senderVerificationPrefix   = "bookingrush-verification="
+	senderVerificationTokenLen = 16
+)
+
+// ErrSenderDNSLookupFailed is returned when the sender domain's records could not be looked up
+var ErrSenderDNSLookupFailed = errors.New("sender domain DNS lookup failed")
+
+// Sender DNS record purposes
+const (
+	SenderRecordOwnership = "ownership"
+	SenderRecordSPF       = "spf"
+	SenderRecordDKIM      = "dkim"
+)
+
+// DNSResolver looks up the records a sender domain publishes; *net.Resolver implements it
+type DNSResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+	LookupCNAME(ctx context.Context, host string) (string, error)
+}
+
+// SenderDomainConfig contains configuration for sender domain verification
+type SenderDomainConfig struct {
+	// SPFInclude is the include: mechanism the domain's SPF record must contain
+	SPFInclude string
+	// DKIMSelector and DKIMTarget are the selector the domain delegates to our signing key
+	// and the record it points at with a CNAME
+	DKIMSelector string
+	DKIMTarget   string
+	// Resolver is used to look the records up (default: net.DefaultResolver)
+	Resolver DNSResolver
+}
+
+// SenderDomainVerifier checks that a tenant owns its from domain and authorized
+// our mail servers to send for it
+type SenderDomainVerifier struct {
+	spfInclude   string
+	dkimSelector string
+	dkimTarget   string
+	resolver     DNSResolver
+}
+
+// NewSenderDomainVerifier creates a new SenderDomainVerifier
+func NewSenderDomainVerifier(cfg *SenderDomainConfig) *SenderDomainVerifier {
+	v := &SenderDomainVerifier{
+		spfInclude:   DefaultSenderSPFInclude,
+		dkimSelector: DefaultSenderDKIMSelector,
+		dkimTarget:   DefaultSenderDKIMTarget,
+		resolver:     net.DefaultResolver,
+	}
+	if cfg != nil {
+		if cfg.SPFInclude != "" {
+			v.spfInclude = cfg.SPFInclude
+		}
+		if cfg.DKIMSelector != "" {
+			v.dkimSelector = cfg.DKIMSelector
+		}
+		if cfg.DKIMTarget != "" {
+			v.dkimTarget = cfg.DKIMTarget
+		}
+		if cfg.Resolver != nil {
+			v.resolver = cfg.Resolver
+		}
+	}
+	return v
+}
+
+// Records returns the DNS records the sender's domain must publish
+func (v *SenderDomainVerifier) Records(sender *domain.TenantSenderSettings) []dto.SenderDNSRecord {
+	senderDomain := sender.Domain()
+	return []dto.SenderDNSRecord{
+		{
+			Purpose: SenderRecordOwnership,
+			Type:    "TXT",
+			Name:    senderVerificationLabel + "." + senderDomain,
+			Value:   senderVerificationPrefix + sender.VerificationToken,
+		},
+		{
+			Purpose: SenderRecordSPF,
+			Type:    "TXT",
+			Name:    senderDomain,
+			Value:   "v=spf1 include:" + v.spfInclude + " ~all",
+		},
+		{
+			Purpose: SenderRecordDKIM,
+			Type:    "CNAME",
+			Name:    v.dkimSelector + "._domainkey." + senderDomain,
+			Value:   v.dkimTarget,
+		},
+	}
+}
+
+// Check looks up each record, setting Found, and reports whether all are published.
+// Lookup failures other than a missing record are returned so a DNS outage is not
+// mistaken for a misconfigured domain.
+func (v *SenderDomainVerifier) Check(ctx context.Context, sender *domain.TenantSenderSettings) ([]dto.SenderDNSRecord, bool, error) {
+	records := v.Records(sender)
+	verified := true
+	for i := range records {
+		found, err := v.checkRecord(ctx, &records[i])
+		if err != nil {
+			return nil, false, fmt.Errorf("%w: %s record %s: %v", ErrSenderDNSLookupFailed, records[i].Purpose, records[i].Name, err)
+		}
+		records[i].Found = &found
+		verified = verified && found
+	}
+	return records, verified, nil
+}
+
+// checkRecord reports whether a record is published
+func (v *SenderDomainVerifier) checkRecord(ctx context.Context, record *dto.SenderDNSRecord) (bool, error) {
+	if record.Type == "CNAME" {
+		target, err := v.resolver.LookupCNAME(ctx, record.Name)
+		if isNotFound(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return strings.EqualFold(strings.TrimSuffix(target, "."), record.Value), nil
+	}
+
+	values, err := v.resolver.LookupTXT(ctx, record.Name)
+	if isNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	for _, value := range values {
+		switch record.Purpose {
+		case SenderRecordSPF:
+			// The domain may authorize other senders too; only our include matters
+			if strings.HasPrefix(value, "v=spf1 ") && containsField(value, "include:"+v.spfInclude) {
+				return true, nil
+			}
+		default:
+			if strings.TrimSpace(value) == record.Value {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// isNotFound reports whether a lookup failed because the record does not exist
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+// containsField reports whether a space separated record contains field
+func containsField(value, field string) bool {
+	for _, f := range strings.Fields(value) {
+		if strings.EqualFold(f, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// newSenderVerificationToken returns a random token to publish on the from domain
+func newSenderVerificationToken() (string, error) {
+	b := make([]byte, senderVerificationTokenLen)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate sender verification token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
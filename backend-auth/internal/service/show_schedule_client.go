@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ShowScheduleClient resolves when shows start, used to tell attended events from upcoming ones
+type ShowScheduleClient interface {
+	// GetShowStartTime returns when the show starts
+	GetShowStartTime(ctx context.Context, showID string) (time.Time, error)
+}
+
+// httpShowScheduleClient implements ShowScheduleClient over the ticket service API
+type httpShowScheduleClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewHTTPShowScheduleClient creates a ShowScheduleClient for the ticket service at baseURL
+func NewHTTPShowScheduleClient(baseURL string) ShowScheduleClient {
+	return &httpShowScheduleClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+	}
+}
+
+// GetShowStartTime returns when the show starts
+func (c *httpShowScheduleClient) GetShowStartTime(ctx context.Context, showID string) (time.Time, error) {
+	endpoint := fmt.Sprintf("%s/api/v1/shows/%s", c.baseURL, url.PathEscape(showID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to create show request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("ticket service request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return time.Time{}, fmt.Errorf("ticket service returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	var result struct {
+		Data struct {
+			ShowDate  string    `json:"show_date"`
+			StartTime time.Time `json:"start_time"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return time.Time{}, fmt.Errorf("failed to decode ticket service response: %w", err)
+	}
+
+	return showStartTime(result.Data.ShowDate, result.Data.StartTime)
+}
+
+// showStartTime returns the start time when it is a full timestamp, otherwise combines
+// the show's date with the clock of a start time stored as a time of day
+func showStartTime(showDate string, startTime time.Time) (time.Time, error) {
+	if startTime.Year() > 1 {
+		return startTime.UTC(), nil
+	}
+	date, err := time.Parse("2006-01-02", showDate)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid show date %q: %w", showDate, err)
+	}
+	startTime = startTime.UTC()
+	return time.Date(date.Year(), date.Month(), date.Day(),
+		startTime.Hour(), startTime.Minute(), startTime.Second(), 0, time.UTC), nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/dto"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 )
 
 var (
@@ -117,11 +118,22 @@ func (s *tenantService) List(ctx context.Context, query *dto.ListTenantsQuery) (
 	// Set defaults
 	query.SetDefaults()
 
+	page, err := pagination.NewParams(query.Cursor, query.Limit, (query.Page-1)*query.Limit)
+	if err != nil {
+		return nil, err
+	}
+	if page.After != nil {
+		query.Page = 1
+	}
+
 	// Get tenants from repository
-	tenants, totalCount, err := s.tenantRepo.List(ctx, query.Page, query.Limit, query.IsActive, query.Search)
+	tenants, totalCount, err := s.tenantRepo.List(ctx, page.Lookahead(), query.IsActive, query.Search)
 	if err != nil {
 		return nil, err
 	}
+	tenants, info := pagination.Paginate(tenants, page.Limit, func(t *domain.Tenant) pagination.Cursor {
+		return pagination.Cursor{Time: t.CreatedAt, ID: t.ID}
+	})
 
 	// Convert to response DTOs
 	tenantResponses := make([]dto.TenantResponse, 0, len(tenants))
@@ -138,6 +150,7 @@ func (s *tenantService) List(ctx context.Context, query *dto.ListTenantsQuery) (
 		Page:       query.Page,
 		Limit:      query.Limit,
 		TotalPages: totalPages,
+		Info:       info,
 	}, nil
 }
 
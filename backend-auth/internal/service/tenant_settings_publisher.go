@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/tenantconfig"
+)
+
+// TenantSettingsPublisher announces tenant settings changes so other services refresh their caches
+type TenantSettingsPublisher interface {
+	// PublishSettingsChanged publishes a settings change event
+	PublishSettingsChanged(ctx context.Context, event *tenantconfig.ChangeEvent) error
+
+	// Close closes the publisher
+	Close() error
+}
+
+// TenantSettingsPublisherConfig contains configuration for the Kafka settings publisher
+type TenantSettingsPublisherConfig struct {
+	Brokers     []string
+	Topic       string
+	ServiceName string
+	ClientID    string
+}
+
+// KafkaTenantSettingsPublisher implements TenantSettingsPublisher using Kafka
+type KafkaTenantSettingsPublisher struct {
+	producer    *kafka.Producer
+	topic       string
+	serviceName string
+}
+
+// NewKafkaTenantSettingsPublisher creates a new Kafka settings publisher
+func NewKafkaTenantSettingsPublisher(ctx context.Context, cfg *TenantSettingsPublisherConfig) (*KafkaTenantSettingsPublisher, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("tenant settings publisher config is required")
+	}
+
+	if len(cfg.Brokers) == 0 {
+		return nil, fmt.Errorf("kafka brokers are required")
+	}
+
+	topic := cfg.Topic
+	if topic == "" {
+		topic = tenantconfig.TopicSettingsChanged
+	}
+
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = "auth-service"
+	}
+
+	clientID := cfg.ClientID
+	if clientID == "" {
+		clientID = "auth-service-tenant-settings"
+	}
+
+	producer, err := kafka.NewProducer(ctx, &kafka.ProducerConfig{
+		Brokers:       cfg.Brokers,
+		ClientID:      clientID,
+		MaxRetries:    3,
+		RetryInterval: 2 * time.Second,
+		BatchSize:     100,
+		LingerMs:      10,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka producer: %w", err)
+	}
+
+	return &KafkaTenantSettingsPublisher{
+		producer:    producer,
+		topic:       topic,
+		serviceName: serviceName,
+	}, nil
+}
+
+// PublishSettingsChanged publishes a settings change event asynchronously.
+// Events are keyed by tenant so each tenant's changes stay in order.
+func (p *KafkaTenantSettingsPublisher) PublishSettingsChanged(ctx context.Context, event *tenantconfig.ChangeEvent) error {
+	value, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	msg := &kafka.Message{
+		Topic: p.topic,
+		Key:   []byte(event.TenantID),
+		Value: value,
+		Headers: map[string]string{
+			"event_type":   event.EventType,
+			"event_id":     event.EventID,
+			"source":       p.serviceName,
+			"content_type": "application/json",
+		},
+		Timestamp: event.OccurredAt,
+	}
+
+	// Settings are already saved; caches expire on their own if the event is lost
+	p.producer.ProduceAsync(context.Background(), msg, func(err error) {
+		if err != nil {
+			logger.Get().Error(fmt.Sprintf("Failed to publish tenant settings change: tenant_id=%s, version=%d, error=%v", event.TenantID, event.Version, err))
+		}
+	})
+
+	return nil
+}
+
+// Close closes the settings publisher
+func (p *KafkaTenantSettingsPublisher) Close() error {
+	if p.producer != nil {
+		p.producer.Close()
+	}
+	return nil
+}
+
+// NoOpTenantSettingsPublisher is a no-op implementation of TenantSettingsPublisher for testing
+type NoOpTenantSettingsPublisher struct{}
+
+// NewNoOpTenantSettingsPublisher creates a new no-op settings publisher
+func NewNoOpTenantSettingsPublisher() *NoOpTenantSettingsPublisher {
+	return &NoOpTenantSettingsPublisher{}
+}
+
+// PublishSettingsChanged is a no-op
+func (p *NoOpTenantSettingsPublisher) PublishSettingsChanged(ctx context.Context, event *tenantconfig.ChangeEvent) error {
+	return nil
+}
+
+// Close is a no-op
+func (p *NoOpTenantSettingsPublisher) Close() error {
+	return nil
+}
@@ -0,0 +1,311 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/tenantconfig"
+)
+
+// ErrTenantSettingsConflict is returned when settings changed since the version the client read
+var ErrTenantSettingsConflict = errors.New("tenant settings were modified by another request")
+
+// ErrSenderNotConfigured is returned when verifying a tenant that has no from address
+var ErrSenderNotConfigured = errors.New("tenant has no email sender configured")
+
+// TenantSettingsService defines the interface for tenant settings operations
+type TenantSettingsService interface {
+	// Get retrieves the settings of a tenant, falling back to defaults if none are saved
+	Get(ctx context.Context, tenantID string) (*tenantconfig.Settings, error)
+	// Update changes the provided settings fields and publishes a change event
+	Update(ctx context.Context, tenantID, userID string, req *dto.UpdateTenantSettingsRequest) (*tenantconfig.Settings, error)
+	// Reset restores the default settings and publishes a change event
+	Reset(ctx context.Context, tenantID, userID string) (*tenantconfig.Settings, error)
+	// GetSender returns the verification state of the tenant's from domain and the DNS records to publish
+	GetSender(ctx context.Context, tenantID string) (*dto.SenderVerificationResponse, error)
+	// VerifySender checks the from domain's DNS records, marking the sender verified
+	// once all are published and unverified if they were removed
+	VerifySender(ctx context.Context, tenantID, userID string) (*dto.SenderVerificationResponse, error)
+}
+
+// tenantSettingsService implements TenantSettingsService
+type tenantSettingsService struct {
+	tenantRepo   repository.TenantRepository
+	settingsRepo repository.TenantSettingsRepository
+	publisher    TenantSettingsPublisher
+	verifier     *SenderDomainVerifier
+}
+
+// NewTenantSettingsService creates a new TenantSettingsService
+func NewTenantSettingsService(
+	tenantRepo repository.TenantRepository,
+	settingsRepo repository.TenantSettingsRepository,
+	publisher TenantSettingsPublisher,
+	verifier *SenderDomainVerifier,
+) TenantSettingsService {
+	if publisher == nil {
+		publisher = NewNoOpTenantSettingsPublisher()
+	}
+	if verifier == nil {
+		verifier = NewSenderDomainVerifier(nil)
+	}
+	return &tenantSettingsService{
+		tenantRepo:   tenantRepo,
+		settingsRepo: settingsRepo,
+		publisher:    publisher,
+		verifier:     verifier,
+	}
+}
+
+// Get retrieves the settings of a tenant, falling back to defaults if none are saved
+func (s *tenantSettingsService) Get(ctx context.Context, tenantID string) (*tenantconfig.Settings, error) {
+	settings, err := s.load(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return toTenantConfigSettings(settings), nil
+}
+
+// Update changes the provided settings fields and publishes a change event
+func (s *tenantSettingsService) Update(ctx context.Context, tenantID, userID string, req *dto.UpdateTenantSettingsRequest) (*tenantconfig.Settings, error) {
+	// Validate that at least one field is provided
+	if valid, errMsg := req.Validate(); !valid {
+		return nil, errors.New(errMsg)
+	}
+
+	settings, err := s.load(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Version != 0 && req.Version != settings.Version {
+		return nil, ErrTenantSettingsConflict
+	}
+
+	// Update fields if provided
+	if req.Currency != nil {
+		settings.Currency = strings.ToUpper(*req.Currency)
+	}
+	if req.MaxTicketsPerUser != nil {
+		settings.MaxTicketsPerUser = *req.MaxTicketsPerUser
+	}
+	if req.Plan != nil {
+		settings.Plan = strings.ToLower(strings.TrimSpace(*req.Plan))
+	}
+	if q := req.Queue; q != nil {
+		if q.RequirePass != nil {
+			settings.Queue.RequirePass = *q.RequirePass
+		}
+		if q.MaxSize != nil {
+			settings.Queue.MaxSize = *q.MaxSize
+		}
+		if q.PassTTLSeconds != nil {
+			settings.Queue.PassTTLSeconds = *q.PassTTLSeconds
+		}
+	}
+	if b := req.Branding; b != nil {
+		if b.DisplayName != nil {
+			settings.Branding.DisplayName = *b.DisplayName
+		}
+		if b.LogoURL != nil {
+			settings.Branding.LogoURL = *b.LogoURL
+		}
+		if b.PrimaryColor != nil {
+			settings.Branding.PrimaryColor = *b.PrimaryColor
+		}
+		if b.SupportEmail != nil {
+			settings.Branding.SupportEmail = *b.SupportEmail
+		}
+	}
+	if snd := req.Sender; snd != nil {
+		if snd.FromAddress != nil {
+			if err := setSenderFromAddress(&settings.Sender, strings.TrimSpace(*snd.FromAddress)); err != nil {
+				return nil, err
+			}
+		}
+		if snd.FromName != nil {
+			settings.Sender.FromName = strings.TrimSpace(*snd.FromName)
+		}
+		if snd.ReplyTo != nil {
+			settings.Sender.ReplyTo = strings.TrimSpace(*snd.ReplyTo)
+		}
+	}
+
+	return s.save(ctx, settings, userID, tenantconfig.EventSettingsUpdated)
+}
+
+// Reset restores the default settings and publishes a change event.
+// Defaults are saved rather than deleted so the version keeps increasing
+// and consumers can still discard stale events.
+func (s *tenantSettingsService) Reset(ctx context.Context, tenantID, userID string) (*tenantconfig.Settings, error) {
+	current, err := s.load(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if current.Version == 0 {
+		// Nothing saved, the tenant already uses the defaults
+		return toTenantConfigSettings(current), nil
+	}
+
+	settings := domain.NewDefaultTenantSettings(tenantID)
+	settings.Version = current.Version
+	return s.save(ctx, settings, userID, tenantconfig.EventSettingsReset)
+}
+
+// GetSender returns the verification state of the tenant's from domain and the DNS records to publish
+func (s *tenantSettingsService) GetSender(ctx context.Context, tenantID string) (*dto.SenderVerificationResponse, error) {
+	settings, err := s.load(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if settings.Sender.FromAddress == "" {
+		return nil, ErrSenderNotConfigured
+	}
+	return senderResponse(&settings.Sender, s.verifier.Records(&settings.Sender)), nil
+}
+
+// VerifySender checks the from domain's DNS records and saves the outcome if it changed
+func (s *tenantSettingsService) VerifySender(ctx context.Context, tenantID, userID string) (*dto.SenderVerificationResponse, error) {
+	settings, err := s.load(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if settings.Sender.FromAddress == "" {
+		return nil, ErrSenderNotConfigured
+	}
+
+	records, verified, err := s.verifier.Check(ctx, &settings.Sender)
+	if err != nil {
+		return nil, err
+	}
+
+	if verified != settings.Sender.IsVerified() {
+		if verified {
+			now := time.Now()
+			settings.Sender.VerifiedAt = &now
+		} else {
+			// Records were removed; keep mail off the domain until they are back
+			settings.Sender.VerifiedAt = nil
+		}
+		if _, err := s.save(ctx, settings, userID, tenantconfig.EventSettingsUpdated); err != nil {
+			return nil, err
+		}
+	}
+
+	return senderResponse(&settings.Sender, records), nil
+}
+
+// setSenderFromAddress changes the from address, issuing a new verification token
+// since ownership of the old domain says nothing about the new one
+func setSenderFromAddress(sender *domain.TenantSenderSettings, fromAddress string) error {
+	if strings.EqualFold(fromAddress, sender.FromAddress) {
+		return nil
+	}
+	sender.FromAddress = fromAddress
+	sender.VerifiedAt = nil
+	sender.VerificationToken = ""
+	if fromAddress == "" {
+		return nil
+	}
+	token, err := newSenderVerificationToken()
+	if err != nil {
+		return err
+	}
+	sender.VerificationToken = token
+	return nil
+}
+
+// senderResponse converts the sender settings and their DNS records to a response
+func senderResponse(sender *domain.TenantSenderSettings, records []dto.SenderDNSRecord) *dto.SenderVerificationResponse {
+	return &dto.SenderVerificationResponse{
+		FromAddress: sender.FromAddress,
+		Domain:      sender.Domain(),
+		Verified:    sender.IsVerified(),
+		VerifiedAt:  sender.VerifiedAt,
+		Records:     records,
+	}
+}
+
+// load returns the saved settings of an existing tenant, or its defaults
+func (s *tenantSettingsService) load(ctx context.Context, tenantID string) (*domain.TenantSettings, error) {
+	tenant, err := s.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if tenant == nil {
+		return nil, ErrTenantNotFound
+	}
+
+	settings, err := s.settingsRepo.GetByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		settings = domain.NewDefaultTenantSettings(tenantID)
+	}
+	return settings, nil
+}
+
+// save stores the settings against the version they were read at and announces the change
+func (s *tenantSettingsService) save(ctx context.Context, settings *domain.TenantSettings, userID, eventType string) (*tenantconfig.Settings, error) {
+	settings.UpdatedBy = userID
+	if err := s.settingsRepo.Save(ctx, settings, settings.Version); err != nil {
+		if errors.Is(err, repository.ErrTenantSettingsVersionConflict) {
+			return nil, ErrTenantSettingsConflict
+		}
+		return nil, err
+	}
+
+	result := toTenantConfigSettings(settings)
+
+	// Settings are saved; a lost event only delays cache refresh until the TTL expires
+	event := &tenantconfig.ChangeEvent{
+		EventID:    uuid.New().String(),
+		EventType:  eventType,
+		TenantID:   settings.TenantID,
+		Version:    settings.Version,
+		Settings:   result,
+		OccurredAt: time.Now(),
+	}
+	if err := s.publisher.PublishSettingsChanged(ctx, event); err != nil {
+		logger.Get().Warn(fmt.Sprintf("Failed to publish tenant settings change: tenant_id=%s, error=%v", settings.TenantID, err))
+	}
+
+	return result, nil
+}
+
+// toTenantConfigSettings converts domain.TenantSettings to the shared tenantconfig.Settings
+func toTenantConfigSettings(settings *domain.TenantSettings) *tenantconfig.Settings {
+	return &tenantconfig.Settings{
+		TenantID:          settings.TenantID,
+		Currency:          settings.Currency,
+		MaxTicketsPerUser: settings.MaxTicketsPerUser,
+		Plan:              settings.Plan,
+		Queue: tenantconfig.QueueSettings{
+			RequirePass:    settings.Queue.RequirePass,
+			MaxSize:        settings.Queue.MaxSize,
+			PassTTLSeconds: settings.Queue.PassTTLSeconds,
+		},
+		Branding: tenantconfig.BrandingSettings{
+			DisplayName:  settings.Branding.DisplayName,
+			LogoURL:      settings.Branding.LogoURL,
+			PrimaryColor: settings.Branding.PrimaryColor,
+			SupportEmail: settings.Branding.SupportEmail,
+		},
+		Sender: tenantconfig.SenderSettings{
+			FromAddress: settings.Sender.FromAddress,
+			FromName:    settings.Sender.FromName,
+			ReplyTo:     settings.Sender.ReplyTo,
+			Verified:    settings.Sender.IsVerified(),
+		},
+		Version:   settings.Version,
+		UpdatedAt: settings.UpdatedAt,
+	}
+}
@@ -0,0 +1,331 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/tenantconfig"
+)
+
+// mockTenantRepository is a mock implementation of TenantRepository
+type mockTenantRepository struct {
+	tenants map[string]*domain.Tenant
+}
+
+func (r *mockTenantRepository) Create(ctx context.Context, tenant *domain.Tenant) error {
+	r.tenants[tenant.ID] = tenant
+	return nil
+}
+
+func (r *mockTenantRepository) GetByID(ctx context.Context, id string) (*domain.Tenant, error) {
+	return r.tenants[id], nil
+}
+
+func (r *mockTenantRepository) GetBySlug(ctx context.Context, slug string) (*domain.Tenant, error) {
+	for _, tenant := range r.tenants {
+		if tenant.Slug == slug {
+			return tenant, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *mockTenantRepository) List(ctx context.Context, page pagination.Params, isActive *bool, search string) ([]*domain.Tenant, int, error) {
+	return nil, 0, nil
+}
+
+func (r *mockTenantRepository) Update(ctx context.Context, tenant *domain.Tenant) error {
+	return r.Create(ctx, tenant)
+}
+
+func (r *mockTenantRepository) SoftDelete(ctx context.Context, id string) error {
+	delete(r.tenants, id)
+	return nil
+}
+
+func (r *mockTenantRepository) ExistsBySlug(ctx context.Context, slug string) (bool, error) {
+	tenant, _ := r.GetBySlug(ctx, slug)
+	return tenant != nil, nil
+}
+
+// mockTenantSettingsRepository is a mock implementation of TenantSettingsRepository
+type mockTenantSettingsRepository struct {
+	mu       sync.Mutex
+	settings map[string]*domain.TenantSettings
+}
+
+func (r *mockTenantSettingsRepository) GetByTenantID(ctx context.Context, tenantID string) (*domain.TenantSettings, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	settings, ok := r.settings[tenantID]
+	if !ok {
+		return nil, nil
+	}
+	s := *settings
+	return &s, nil
+}
+
+func (r *mockTenantSettingsRepository) Save(ctx context.Context, settings *domain.TenantSettings, expectedVersion int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var version int64
+	if current, ok := r.settings[settings.TenantID]; ok {
+		version = current.Version
+	}
+	if expectedVersion != 0 && expectedVersion != version {
+		return repository.ErrTenantSettingsVersionConflict
+	}
+	settings.Version = version + 1
+	settings.UpdatedAt = time.Now()
+	s := *settings
+	r.settings[settings.TenantID] = &s
+	return nil
+}
+
+// mockTenantSettingsPublisher records published change events
+type mockTenantSettingsPublisher struct {
+	events []*tenantconfig.ChangeEvent
+}
+
+func (p *mockTenantSettingsPublisher) PublishSettingsChanged(ctx context.Context, event *tenantconfig.ChangeEvent) error {
+	p.events = append(p.events, event)
+	return nil
+}
+
+func (p *mockTenantSettingsPublisher) Close() error { return nil }
+
+func newTestTenantSettingsService() (TenantSettingsService, *mockTenantSettingsRepository, *mockTenantSettingsPublisher) {
+	tenantRepo := &mockTenantRepository{tenants: map[string]*domain.Tenant{
+		"tenant-1": {ID: "tenant-1", Name: "Organizer", Slug: "organizer", IsActive: true},
+	}}
+	settingsRepo := &mockTenantSettingsRepository{settings: make(map[string]*domain.TenantSettings)}
+	publisher := &mockTenantSettingsPublisher{}
+	return NewTenantSettingsService(tenantRepo, settingsRepo, publisher, nil), settingsRepo, publisher
+}
+
+func TestTenantSettingsService_Get(t *testing.T) {
+	svc, _, _ := newTestTenantSettingsService()
+	ctx := context.Background()
+
+	settings, err := svc.Get(ctx, "tenant-1")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if settings.Currency != domain.DefaultTenantCurrency || settings.Version != 0 {
+		t.Errorf("Expected unsaved default settings, got %+v", settings)
+	}
+
+	if _, err := svc.Get(ctx, "tenant-2"); !errors.Is(err, ErrTenantNotFound) {
+		t.Errorf("Expected %v, got %v", ErrTenantNotFound, err)
+	}
+}
+
+func TestTenantSettingsService_Update(t *testing.T) {
+	svc, settingsRepo, publisher := newTestTenantSettingsService()
+	ctx := context.Background()
+
+	currency := "usd"
+	maxTickets := 4
+	requirePass := true
+	plan := " Pro"
+	settings, err := svc.Update(ctx, "tenant-1", "admin-1", &dto.UpdateTenantSettingsRequest{
+		Currency:          &currency,
+		MaxTicketsPerUser: &maxTickets,
+		Plan:              &plan,
+		Queue:             &dto.UpdateTenantQueueSettings{RequirePass: &requirePass},
+	})
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if settings.Currency != "USD" || settings.MaxTicketsPerUser != 4 || settings.Plan != "pro" || !settings.Queue.RequirePass || settings.Version != 1 {
+		t.Errorf("Unexpected settings: %+v", settings)
+	}
+	if saved := settingsRepo.settings["tenant-1"]; saved == nil || saved.UpdatedBy != "admin-1" {
+		t.Errorf("Expected settings saved by admin-1, got %+v", saved)
+	}
+
+	if len(publisher.events) != 1 {
+		t.Fatalf("Expected 1 change event, got %d", len(publisher.events))
+	}
+	event := publisher.events[0]
+	if event.EventType != tenantconfig.EventSettingsUpdated || event.Version != 1 || event.Settings == nil || event.Settings.Currency != "USD" {
+		t.Errorf("Unexpected change event: %+v", event)
+	}
+
+	// A partial update keeps the other fields
+	maxTickets = 6
+	settings, err = svc.Update(ctx, "tenant-1", "admin-1", &dto.UpdateTenantSettingsRequest{MaxTicketsPerUser: &maxTickets, Version: 1})
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if settings.Currency != "USD" || settings.MaxTicketsPerUser != 6 || settings.Plan != "pro" || settings.Version != 2 {
+		t.Errorf("Unexpected settings: %+v", settings)
+	}
+}
+
+func TestTenantSettingsService_Update_Errors(t *testing.T) {
+	svc, _, publisher := newTestTenantSettingsService()
+	ctx := context.Background()
+	maxTickets := 4
+
+	if _, err := svc.Update(ctx, "tenant-1", "admin-1", &dto.UpdateTenantSettingsRequest{}); err == nil {
+		t.Error("Expected error for empty update")
+	}
+	if _, err := svc.Update(ctx, "tenant-2", "admin-1", &dto.UpdateTenantSettingsRequest{MaxTicketsPerUser: &maxTickets}); !errors.Is(err, ErrTenantNotFound) {
+		t.Errorf("Expected %v, got %v", ErrTenantNotFound, err)
+	}
+	if _, err := svc.Update(ctx, "tenant-1", "admin-1", &dto.UpdateTenantSettingsRequest{MaxTicketsPerUser: &maxTickets, Version: 3}); !errors.Is(err, ErrTenantSettingsConflict) {
+		t.Errorf("Expected %v, got %v", ErrTenantSettingsConflict, err)
+	}
+	if len(publisher.events) != 0 {
+		t.Errorf("Expected no change events, got %d", len(publisher.events))
+	}
+}
+
+func TestTenantSettingsService_Reset(t *testing.T) {
+	svc, _, publisher := newTestTenantSettingsService()
+	ctx := context.Background()
+
+	// Resetting unsaved settings is a no-op
+	if _, err := svc.Reset(ctx, "tenant-1", "admin-1"); err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	if len(publisher.events) != 0 {
+		t.Errorf("Expected no change events, got %d", len(publisher.events))
+	}
+
+	currency := "USD"
+	if _, err := svc.Update(ctx, "tenant-1", "admin-1", &dto.UpdateTenantSettingsRequest{Currency: &currency}); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	settings, err := svc.Reset(ctx, "tenant-1", "admin-1")
+	if err != nil {
+		t.Fatalf("Reset failed: %v", err)
+	}
+	// The version keeps increasing so consumers discard the older update
+	if settings.Currency != domain.DefaultTenantCurrency || settings.Version != 2 {
+		t.Errorf("Expected default settings at version 2, got %+v", settings)
+	}
+	if len(publisher.events) != 2 || publisher.events[1].EventType != tenantconfig.EventSettingsReset {
+		t.Errorf("Expected a reset event, got %+v", publisher.events)
+	}
+}
+
+// fakeDNSResolver serves TXT and CNAME records from maps
+type fakeDNSResolver struct {
+	txt   map[string][]string
+	cname map[string]string
+	err   error
+}
+
+func (r *fakeDNSResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	values, ok := r.txt[name]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+	return values, nil
+}
+
+func (r *fakeDNSResolver) LookupCNAME(ctx context.Context, host string) (string, error) {
+	if r.err != nil {
+		return "", r.err
+	}
+	target, ok := r.cname[host]
+	if !ok {
+		return "", &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	return target, nil
+}
+
+func TestTenantSettingsService_VerifySender(t *testing.T) {
+	tenantRepo := &mockTenantRepository{tenants: map[string]*domain.Tenant{
+		"tenant-1": {ID: "tenant-1", Name: "Organizer", Slug: "organizer", IsActive: true},
+	}}
+	settingsRepo := &mockTenantSettingsRepository{settings: make(map[string]*domain.TenantSettings)}
+	publisher := &mockTenantSettingsPublisher{}
+	resolver := &fakeDNSResolver{txt: map[string][]string{}, cname: map[string]string{}}
+	svc := NewTenantSettingsService(tenantRepo, settingsRepo, publisher, NewSenderDomainVerifier(&SenderDomainConfig{Resolver: resolver}))
+	ctx := context.Background()
+
+	if _, err := svc.VerifySender(ctx, "tenant-1", "admin-1"); !errors.Is(err, ErrSenderNotConfigured) {
+		t.Fatalf("Expected %v, got %v", ErrSenderNotConfigured, err)
+	}
+
+	from := "Tickets@Concerts.example.com"
+	replyTo := "help@concerts.example.com"
+	settings, err := svc.Update(ctx, "tenant-1", "admin-1", &dto.UpdateTenantSettingsRequest{
+		Sender: &dto.UpdateTenantSenderSettings{FromAddress: &from, ReplyTo: &replyTo},
+	})
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if settings.Sender.FromAddress != from || settings.Sender.Verified {
+		t.Errorf("Expected an unverified sender, got %+v", settings.Sender)
+	}
+
+	sender, err := svc.GetSender(ctx, "tenant-1")
+	if err != nil {
+		t.Fatalf("GetSender failed: %v", err)
+	}
+	if sender.Domain != "concerts.example.com" || len(sender.Records) != 3 {
+		t.Fatalf("Unexpected sender verification: %+v", sender)
+	}
+
+	// Only the ownership token is published so far
+	ownership := sender.Records[0]
+	resolver.txt[ownership.Name] = []string{ownership.Value}
+	sender, err = svc.VerifySender(ctx, "tenant-1", "admin-1")
+	if err != nil {
+		t.Fatalf("VerifySender failed: %v", err)
+	}
+	if sender.Verified || !*sender.Records[0].Found || *sender.Records[1].Found || *sender.Records[2].Found {
+		t.Errorf("Expected only the ownership record found, got %+v", sender)
+	}
+
+	// SPF may authorize other senders alongside ours; the CNAME lookup returns a trailing dot
+	resolver.txt["concerts.example.com"] = []string{"google-site-verification=abc", "v=spf1 include:_spf.google.com include:" + DefaultSenderSPFInclude + " ~all"}
+	resolver.cname[DefaultSenderDKIMSelector+"._domainkey.concerts.example.com"] = DefaultSenderDKIMTarget + "."
+	sender, err = svc.VerifySender(ctx, "tenant-1", "admin-1")
+	if err != nil {
+		t.Fatalf("VerifySender failed: %v", err)
+	}
+	if !sender.Verified || sender.VerifiedAt == nil {
+		t.Errorf("Expected a verified sender, got %+v", sender)
+	}
+	last := publisher.events[len(publisher.events)-1]
+	if !last.Settings.Sender.Verified {
+		t.Errorf("Expected the change event to carry the verified sender, got %+v", last.Settings.Sender)
+	}
+
+	// A DNS outage does not unverify the sender
+	resolver.err = errors.New("i/o timeout")
+	if _, err := svc.VerifySender(ctx, "tenant-1", "admin-1"); !errors.Is(err, ErrSenderDNSLookupFailed) {
+		t.Errorf("Expected %v, got %v", ErrSenderDNSLookupFailed, err)
+	}
+	resolver.err = nil
+
+	// Changing the from address requires verifying the new domain
+	token := settingsRepo.settings["tenant-1"].Sender.VerificationToken
+	from = "tickets@other.example.com"
+	settings, err = svc.Update(ctx, "tenant-1", "admin-1", &dto.UpdateTenantSettingsRequest{
+		Sender: &dto.UpdateTenantSenderSettings{FromAddress: &from},
+	})
+	if err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if settings.Sender.Verified || settingsRepo.settings["tenant-1"].Sender.VerificationToken == token {
+		t.Errorf("Expected an unverified sender with a new token, got %+v", settingsRepo.settings["tenant-1"].Sender)
+	}
+}
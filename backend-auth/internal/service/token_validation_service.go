@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// TokenValidationConfig holds the cache settings of TokenValidationService
+type TokenValidationConfig struct {
+	// CacheTTL is how long a validation result is reused by this service
+	CacheTTL time.Duration
+	// ClientMaxAge is how long callers may reuse a result before revalidating it. It
+	// bounds how long a revoked token keeps working in other services.
+	ClientMaxAge time.Duration
+	// NegativeTTL is how long a rejected token is remembered so floods of bad tokens stay cheap
+	NegativeTTL time.Duration
+	// RevocationTTL is how long a user's last revocation is reused before it is read again.
+	// Revocations made on another replica take effect within this window.
+	RevocationTTL time.Duration
+	// MaxEntries bounds the number of cached results
+	MaxEntries int
+}
+
+// TokenValidation is the result of validating an access token
+type TokenValidation struct {
+	Claims *domain.Claims
+	// RevokedAt is when the user's tokens were last revoked (zero if never)
+	RevokedAt time.Time
+	// ETag identifies this result; it changes when the user's tokens are revoked
+	ETag string
+	// MaxAge is how long callers may reuse the result without asking again
+	MaxAge time.Duration
+}
+
+// TokenValidationService validates access tokens for other services. Results are cached
+// by token hash so per-request validation doesn't re-verify the same token, while
+// revocations still take effect within seconds.
+type TokenValidationService interface {
+	// Validate validates an access token, rejecting tokens issued before the user's last revocation
+	Validate(ctx context.Context, token string) (*TokenValidation, error)
+	// RevokeUserTokens rejects every access token issued to the user so far
+	RevokeUserTokens(ctx context.Context, userID string) error
+}
+
+// cachedValidation is a cached validation result
+type cachedValidation struct {
+	claims    *domain.Claims
+	err       error
+	expiresAt time.Time
+}
+
+// cachedRevocation is a user's last revocation as last read from the repository
+type cachedRevocation struct {
+	revokedAt time.Time
+	fetchedAt time.Time
+}
+
+// tokenValidationService implements TokenValidationService
+type tokenValidationService struct {
+	authService AuthService
+	revocations repository.TokenRevocationRepository // nil disables revocation checks
+	config      *TokenValidationConfig
+	now         func() time.Time
+
+	mu        sync.Mutex
+	results   map[string]*cachedValidation // token hash -> result
+	revokedAt map[string]cachedRevocation  // user ID -> last revocation
+}
+
+// NewTokenValidationService creates a new TokenValidationService
+func NewTokenValidationService(authService AuthService, revocations repository.TokenRevocationRepository, config *TokenValidationConfig) TokenValidationService {
+	if config == nil {
+		config = &TokenValidationConfig{}
+	}
+	if config.CacheTTL == 0 {
+		config.CacheTTL = 30 * time.Second
+	}
+	if config.ClientMaxAge == 0 {
+		config.ClientMaxAge = 5 * time.Second
+	}
+	if config.NegativeTTL == 0 {
+		config.NegativeTTL = 5 * time.Second
+	}
+	if config.RevocationTTL == 0 {
+		config.RevocationTTL = 2 * time.Second
+	}
+	if config.MaxEntries == 0 {
+		config.MaxEntries = 100000
+	}
+	return &tokenValidationService{
+		authService: authService,
+		revocations: revocations,
+		config:      config,
+		now:         time.Now,
+		results:     make(map[string]*cachedValidation),
+		revokedAt:   make(map[string]cachedRevocation),
+	}
+}
+
+// Validate validates an access token
+func (s *tokenValidationService) Validate(ctx context.Context, token string) (*TokenValidation, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.token_validation.validate")
+	defer span.End()
+
+	key := tokenCacheKey(token)
+	now := s.now()
+
+	var claims *domain.Claims
+	var err error
+	entry, cached := s.cachedResult(key, now)
+	span.SetAttributes(attribute.Bool("cache_hit", cached))
+	if cached {
+		claims, err = entry.claims, entry.err
+	} else {
+		claims, err = s.authService.ValidateToken(ctx, token)
+		s.storeResult(key, claims, err, now)
+	}
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	revokedAt, err := s.userRevokedAt(ctx, claims.UserID, now)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	// iat has second precision, so tokens signed in the second of the revocation stay valid
+	if claims.IssuedAt.Before(revokedAt.Truncate(time.Second)) {
+		span.SetStatus(codes.Error, "token revoked")
+		return nil, ErrTokenRevoked
+	}
+
+	maxAge := s.config.ClientMaxAge
+	if !claims.ExpiresAt.IsZero() && claims.ExpiresAt.Sub(now) < maxAge {
+		maxAge = max(claims.ExpiresAt.Sub(now), 0)
+	}
+
+	span.SetAttributes(attribute.String("user_id", claims.UserID))
+	span.SetStatus(codes.Ok, "")
+	return &TokenValidation{
+		Claims:    claims,
+		RevokedAt: revokedAt,
+		ETag:      validationETag(key, revokedAt),
+		MaxAge:    maxAge,
+	}, nil
+}
+
+// RevokeUserTokens rejects every access token issued to the user so far
+func (s *tokenValidationService) RevokeUserTokens(ctx context.Context, userID string) error {
+	ctx, span := telemetry.StartSpan(ctx, "service.token_validation.revoke")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", userID))
+
+	if s.revocations == nil {
+		span.SetStatus(codes.Ok, "revocation disabled")
+		return nil
+	}
+
+	now := s.now()
+	if err := s.revocations.Revoke(ctx, userID, now); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to revoke tokens: %w", err)
+	}
+
+	// This replica applies the revocation at once, the others after RevocationTTL
+	s.mu.Lock()
+	s.revokedAt[userID] = cachedRevocation{revokedAt: now, fetchedAt: now}
+	s.mu.Unlock()
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// cachedResult returns the cached result of a token, if it is still fresh
+func (s *tokenValidationService) cachedResult(key string, now time.Time) (*cachedValidation, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.results[key]
+	if !ok || !now.Before(entry.expiresAt) {
+		return nil, false
+	}
+	return entry, true
+}
+
+// storeResult caches a validation result. Only token errors are cached: a failure of the
+// service itself must not be remembered.
+func (s *tokenValidationService) storeResult(key string, claims *domain.Claims, err error, now time.Time) {
+	ttl := s.config.CacheTTL
+	if err != nil {
+		if !errors.Is(err, ErrInvalidToken) && !errors.Is(err, ErrTokenExpired) {
+			return
+		}
+		ttl = s.config.NegativeTTL
+	} else if !claims.ExpiresAt.IsZero() && claims.ExpiresAt.Sub(now) < ttl {
+		ttl = claims.ExpiresAt.Sub(now)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.results) >= s.config.MaxEntries {
+		for k, entry := range s.results {
+			if !now.Before(entry.expiresAt) {
+				delete(s.results, k)
+			}
+		}
+	}
+	if len(s.results) >= s.config.MaxEntries {
+		// A flood of random tokens must not push valid tokens out of the cache
+		if err != nil {
+			return
+		}
+		for k := range s.results {
+			delete(s.results, k)
+			break
+		}
+	}
+	s.results[key] = &cachedValidation{claims: claims, err: err, expiresAt: now.Add(ttl)}
+}
+
+// userRevokedAt returns when the user's tokens were last revoked. If the repository
+// can't be read, the last known revocation is used.
+func (s *tokenValidationService) userRevokedAt(ctx context.Context, userID string, now time.Time) (time.Time, error) {
+	if s.revocations == nil {
+		return time.Time{}, nil
+	}
+
+	s.mu.Lock()
+	cached, ok := s.revokedAt[userID]
+	s.mu.Unlock()
+	if ok && now.Sub(cached.fetchedAt) < s.config.RevocationTTL {
+		return cached.revokedAt, nil
+	}
+
+	revokedAt, err := s.revocations.GetRevokedAt(ctx, userID)
+	if err != nil {
+		if ok {
+			return cached.revokedAt, nil
+		}
+		return time.Time{}, fmt.Errorf("failed to read token revocation: %w", err)
+	}
+
+	s.mu.Lock()
+	if len(s.revokedAt) >= s.config.MaxEntries {
+		for k, entry := range s.revokedAt {
+			if now.Sub(entry.fetchedAt) >= s.config.RevocationTTL {
+				delete(s.revokedAt, k)
+			}
+		}
+	}
+	s.revokedAt[userID] = cachedRevocation{revokedAt: revokedAt, fetchedAt: now}
+	s.mu.Unlock()
+	return revokedAt, nil
+}
+
+// tokenCacheKey hashes a token so the cache never holds usable tokens
+func tokenCacheKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// validationETag identifies a validation result of a token and the user's last revocation
+func validationETag(key string, revokedAt time.Time) string {
+	sum := sha256.Sum256([]byte(key + ":" + strconv.FormatInt(revokedAt.Unix(), 10)))
+	return `"` + hex.EncodeToString(sum[:8]) + `"`
+}
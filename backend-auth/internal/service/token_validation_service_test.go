@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/dto"
+)
+
+// countingAuthService counts the tokens AuthService had to verify
+type countingAuthService struct {
+	AuthService
+	mu    sync.Mutex
+	calls int
+}
+
+func (s *countingAuthService) ValidateToken(ctx context.Context, token string) (*domain.Claims, error) {
+	s.mu.Lock()
+	s.calls++
+	s.mu.Unlock()
+	return s.AuthService.ValidateToken(ctx, token)
+}
+
+// mockTokenRevocationRepository is a mock implementation of TokenRevocationRepository
+type mockTokenRevocationRepository struct {
+	mu        sync.Mutex
+	revokedAt map[string]time.Time
+	reads     int
+	err       error
+}
+
+func newMockTokenRevocationRepository() *mockTokenRevocationRepository {
+	return &mockTokenRevocationRepository{revokedAt: make(map[string]time.Time)}
+}
+
+func (r *mockTokenRevocationRepository) Revoke(ctx context.Context, userID string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if at.After(r.revokedAt[userID]) {
+		r.revokedAt[userID] = at
+	}
+	return nil
+}
+
+func (r *mockTokenRevocationRepository) GetRevokedAt(ctx context.Context, userID string) (time.Time, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reads++
+	if r.err != nil {
+		return time.Time{}, r.err
+	}
+	return r.revokedAt[userID], nil
+}
+
+func newTokenValidationFixture(t *testing.T) (*countingAuthService, *mockTokenRevocationRepository, *tokenValidationService, *dto.AuthResponse) {
+	t.Helper()
+	authService := &countingAuthService{AuthService: NewAuthService(newMockUserRepository(), newMockSessionRepository(), &AuthServiceConfig{
+		JWTSecret:          "test-secret-key",
+		AccessTokenExpiry:  15 * time.Minute,
+		RefreshTokenExpiry: 7 * 24 * time.Hour,
+		BcryptCost:         10,
+	})}
+	resp, err := authService.Register(context.Background(), &dto.RegisterRequest{
+		Email:    "validate-cache@example.com",
+		Password: "Password1!",
+		Name:     "Validate Cache",
+	})
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	revocations := newMockTokenRevocationRepository()
+	svc := NewTokenValidationService(authService, revocations, nil).(*tokenValidationService)
+	return authService, revocations, svc, resp
+}
+
+func TestTokenValidationService_CachesResults(t *testing.T) {
+	authService, revocations, svc, resp := newTokenValidationFixture(t)
+	ctx := context.Background()
+
+	first, err := svc.Validate(ctx, resp.AccessToken)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	second, err := svc.Validate(ctx, resp.AccessToken)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	if authService.calls != 1 || revocations.reads != 1 {
+		t.Errorf("token verified %d times, revocation read %d times, want 1 each", authService.calls, revocations.reads)
+	}
+	if first.ETag == "" || first.ETag != second.ETag {
+		t.Errorf("ETag = %q then %q, want a stable ETag", first.ETag, second.ETag)
+	}
+	if first.MaxAge != svc.config.ClientMaxAge {
+		t.Errorf("MaxAge = %v, want %v", first.MaxAge, svc.config.ClientMaxAge)
+	}
+	if first.Claims.IssuedAt.IsZero() || !first.Claims.ExpiresAt.After(first.Claims.IssuedAt) {
+		t.Errorf("Claims issued_at = %v expires_at = %v, want both set", first.Claims.IssuedAt, first.Claims.ExpiresAt)
+	}
+}
+
+func TestTokenValidationService_CachesRejectedTokens(t *testing.T) {
+	authService, _, svc, _ := newTokenValidationFixture(t)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if _, err := svc.Validate(ctx, "invalid-token"); !errors.Is(err, ErrInvalidToken) {
+			t.Fatalf("Validate() error = %v, want %v", err, ErrInvalidToken)
+		}
+	}
+	if authService.calls != 1 {
+		t.Errorf("rejected token verified %d times, want 1", authService.calls)
+	}
+}
+
+func TestTokenValidationService_RevokeUserTokens(t *testing.T) {
+	_, revocations, svc, resp := newTokenValidationFixture(t)
+	ctx := context.Background()
+
+	before, err := svc.Validate(ctx, resp.AccessToken)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	// Revoke a couple of seconds later so the token's iat is before the revocation
+	svc.now = func() time.Time { return time.Now().Add(2 * time.Second) }
+	if err := svc.RevokeUserTokens(ctx, resp.User.ID); err != nil {
+		t.Fatalf("RevokeUserTokens() error = %v", err)
+	}
+
+	if _, err := svc.Validate(ctx, resp.AccessToken); !errors.Is(err, ErrTokenRevoked) {
+		t.Errorf("Validate() error = %v, want %v", err, ErrTokenRevoked)
+	}
+
+	// Another replica picks the revocation up from the repository
+	other := NewTokenValidationService(svc.authService, revocations, nil)
+	if _, err := other.Validate(ctx, resp.AccessToken); !errors.Is(err, ErrTokenRevoked) {
+		t.Errorf("Validate() on another replica error = %v, want %v", err, ErrTokenRevoked)
+	}
+
+	// A token signed after the revocation gets a new ETag
+	svc.revokedAt[resp.User.ID] = cachedRevocation{revokedAt: time.Now().Add(-time.Hour), fetchedAt: svc.now()}
+	after, err := svc.Validate(ctx, resp.AccessToken)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if after.ETag == before.ETag {
+		t.Error("ETag did not change after a revocation")
+	}
+}
+
+func TestTokenValidationService_RevocationReadFailure(t *testing.T) {
+	_, revocations, svc, resp := newTokenValidationFixture(t)
+	ctx := context.Background()
+	revocations.err = errors.New("connection refused")
+
+	if _, err := svc.Validate(ctx, resp.AccessToken); err == nil || errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("Validate() error = %v, want a repository error", err)
+	}
+
+	// Once a revocation is known it is used while the repository is down
+	revocations.err = nil
+	if _, err := svc.Validate(ctx, resp.AccessToken); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	revocations.err = errors.New("connection refused")
+	svc.now = func() time.Time { return time.Now().Add(time.Minute) }
+	if _, err := svc.Validate(ctx, resp.AccessToken); err != nil {
+		t.Errorf("Validate() with stale revocation error = %v", err)
+	}
+}
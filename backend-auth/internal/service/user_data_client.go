@@ -0,0 +1,108 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// UserDataClient exports and anonymizes a user's data held by another service.
+// Each service exposes GET /internal/users/:user_id/data and POST /internal/users/:user_id/anonymize.
+type UserDataClient interface {
+	// Name returns the data set name used in export archives and saga step names (e.g. "bookings")
+	Name() string
+	// ExportUserData returns the user's records as raw JSON
+	ExportUserData(ctx context.Context, userID string) (json.RawMessage, error)
+	// AnonymizeUserData re-assigns the user's records to anonymizedID and strips PII
+	AnonymizeUserData(ctx context.Context, userID, anonymizedID string) error
+}
+
+// httpUserDataClient implements UserDataClient over the internal HTTP API of a service
+type httpUserDataClient struct {
+	name       string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewHTTPUserDataClient creates a UserDataClient for the service at baseURL
+func NewHTTPUserDataClient(name, baseURL string) UserDataClient {
+	return &httpUserDataClient{
+		name:    name,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+	}
+}
+
+// Name returns the data set name
+func (c *httpUserDataClient) Name() string {
+	return c.name
+}
+
+// ExportUserData returns the user's records as raw JSON
+func (c *httpUserDataClient) ExportUserData(ctx context.Context, userID string) (json.RawMessage, error) {
+	endpoint := fmt.Sprintf("%s/internal/users/%s/data", c.baseURL, url.PathEscape(userID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s export request: %w", c.name, err)
+	}
+
+	var result struct {
+		Success bool            `json:"success"`
+		Data    json.RawMessage `json:"data"`
+	}
+	if err := c.do(req, &result); err != nil {
+		return nil, err
+	}
+
+	if len(result.Data) == 0 {
+		return json.RawMessage("[]"), nil
+	}
+	return result.Data, nil
+}
+
+// AnonymizeUserData re-assigns the user's records to anonymizedID and strips PII
+func (c *httpUserDataClient) AnonymizeUserData(ctx context.Context, userID, anonymizedID string) error {
+	body, err := json.Marshal(map[string]string{"anonymized_id": anonymizedID})
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("%s/internal/users/%s/anonymize", c.baseURL, url.PathEscape(userID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create %s anonymize request: %w", c.name, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return c.do(req, nil)
+}
+
+// do sends the request and decodes a successful JSON response into out (if non-nil)
+func (c *httpUserDataClient) do(req *http.Request, out interface{}) error {
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s service request failed: %w", c.name, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s service returned status %d: %s", c.name, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s service response: %w", c.name, err)
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/i18n"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
+)
+
+// TopicVerificationCodes is the Kafka topic verification codes are published to for delivery
+const TopicVerificationCodes = "auth.verification-codes"
+
+// EventVerificationCodeRequested is the event type of a verification code message
+const EventVerificationCodeRequested = "verification.code_requested"
+
+// VerificationCodeSender delivers verification codes to users
+type VerificationCodeSender interface {
+	// SendCode delivers the code of a challenge to its destination
+	SendCode(ctx context.Context, challenge *domain.VerificationChallenge, code string) error
+
+	// Close closes the sender
+	Close() error
+}
+
+// VerificationCodeSenderConfig contains configuration for the Kafka code sender
+type VerificationCodeSenderConfig struct {
+	Brokers     []string
+	Topic       string
+	ServiceName string
+	ClientID    string
+}
+
+// verificationCodeMessage is consumed by the notification pipeline that emails or texts the code
+type verificationCodeMessage struct {
+	EventID     string    `json:"event_id"`
+	EventType   string    `json:"event_type"`
+	ChallengeID string    `json:"challenge_id"`
+	UserID      string    `json:"user_id"`
+	Channel     string    `json:"channel"`
+	Destination string    `json:"destination"`
+	Code        string    `json:"code"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	OccurredAt  time.Time `json:"occurred_at"`
+	// Notification is the message to deliver, rendered in the requesting user's locale
+	Notification *i18n.Notification `json:"notification"`
+}
+
+// KafkaVerificationCodeSender implements VerificationCodeSender using Kafka
+type KafkaVerificationCodeSender struct {
+	producer    *kafka.Producer
+	topic       string
+	serviceName string
+}
+
+// NewKafkaVerificationCodeSender creates a new Kafka code sender
+func NewKafkaVerificationCodeSender(ctx context.Context, cfg *VerificationCodeSenderConfig) (*KafkaVerificationCodeSender, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("verification code sender config is required")
+	}
+
+	if len(cfg.Brokers) == 0 {
+		return nil, fmt.Errorf("kafka brokers are required")
+	}
+
+	topic := cfg.Topic
+	if topic == "" {
+		topic = TopicVerificationCodes
+	}
+
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = "auth-service"
+	}
+
+	clientID := cfg.ClientID
+	if clientID == "" {
+		clientID = "auth-service-verification"
+	}
+
+	producer, err := kafka.NewProducer(ctx, &kafka.ProducerConfig{
+		Brokers:       cfg.Brokers,
+		ClientID:      clientID,
+		MaxRetries:    3,
+		RetryInterval: 2 * time.Second,
+		BatchSize:     100,
+		LingerMs:      10,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka producer: %w", err)
+	}
+
+	return &KafkaVerificationCodeSender{
+		producer:    producer,
+		topic:       topic,
+		serviceName: serviceName,
+	}, nil
+}
+
+// SendCode publishes the code synchronously so the caller knows whether delivery was queued
+func (s *KafkaVerificationCodeSender) SendCode(ctx context.Context, challenge *domain.VerificationChallenge, code string) error {
+	now := time.Now()
+	event := &verificationCodeMessage{
+		EventID:      uuid.New().String(),
+		EventType:    EventVerificationCodeRequested,
+		ChallengeID:  challenge.ID,
+		UserID:       challenge.UserID,
+		Channel:      string(challenge.Channel),
+		Destination:  challenge.Destination,
+		Code:         code,
+		ExpiresAt:    challenge.ExpiresAt,
+		OccurredAt:   now,
+		Notification: renderVerificationCode(i18n.FromContext(ctx), challenge, code, now),
+	}
+
+	value, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	msg := &kafka.Message{
+		Topic: s.topic,
+		Key:   []byte(challenge.UserID),
+		Value: value,
+		Headers: map[string]string{
+			"event_type":   event.EventType,
+			"event_id":     event.EventID,
+			"source":       s.serviceName,
+			"content_type": "application/json",
+		},
+		Timestamp: event.OccurredAt,
+	}
+
+	if err := s.producer.Produce(ctx, msg); err != nil {
+		return fmt.Errorf("failed to publish verification code: %w", err)
+	}
+	return nil
+}
+
+// renderVerificationCode renders the email or SMS template for a challenge's channel
+func renderVerificationCode(locale i18n.Locale, challenge *domain.VerificationChallenge, code string, now time.Time) *i18n.Notification {
+	template := i18n.TemplateVerificationCodeEmail
+	if challenge.Channel == domain.VerificationChannelPhone {
+		template = i18n.TemplateVerificationCodeSMS
+	}
+
+	minutes := int(math.Ceil(challenge.ExpiresAt.Sub(now).Minutes()))
+	if minutes < 1 {
+		minutes = 1
+	}
+	return i18n.RenderNotification(locale, template, map[string]string{
+		"code":    code,
+		"minutes": strconv.Itoa(minutes),
+	})
+}
+
+// Close closes the code sender
+func (s *KafkaVerificationCodeSender) Close() error {
+	if s.producer != nil {
+		s.producer.Close()
+	}
+	return nil
+}
+
+// NoOpVerificationCodeSender is a no-op implementation of VerificationCodeSender for testing
+type NoOpVerificationCodeSender struct{}
+
+// NewNoOpVerificationCodeSender creates a new no-op code sender
+func NewNoOpVerificationCodeSender() *NoOpVerificationCodeSender {
+	return &NoOpVerificationCodeSender{}
+}
+
+// SendCode is a no-op
+func (s *NoOpVerificationCodeSender) SendCode(ctx context.Context, challenge *domain.VerificationChallenge, code string) error {
+	return nil
+}
+
+// Close is a no-op
+func (s *NoOpVerificationCodeSender) Close() error {
+	return nil
+}
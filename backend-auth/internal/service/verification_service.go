@@ -0,0 +1,261 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/repository"
+)
+
+var (
+	ErrChallengeNotFound        = errors.New("verification challenge not found")
+	ErrChallengeExpired         = errors.New("verification challenge expired")
+	ErrChallengeAlreadyUsed     = errors.New("verification challenge already used")
+	ErrChallengeLocked          = errors.New("too many attempts for verification challenge")
+	ErrInvalidVerificationCode  = errors.New("invalid verification code")
+	ErrVerificationRateLimited  = errors.New("too many verification challenges, try again later")
+	ErrInvalidVerificationInput = errors.New("invalid verification request")
+)
+
+// verificationCodeDigits is the length of the one-time codes
+const verificationCodeDigits = 6
+
+// VerificationService defines the interface for account verification operations
+type VerificationService interface {
+	// GetStatus returns the verification state of a user
+	GetStatus(ctx context.Context, userID string) (*dto.VerificationStatusResponse, error)
+	// StartChallenge sends a one-time code to the user's email or phone
+	StartChallenge(ctx context.Context, userID string, req *dto.StartVerificationRequest) (*dto.VerificationChallengeResponse, error)
+	// VerifyChallenge checks a code and marks the channel verified
+	VerifyChallenge(ctx context.Context, userID, challengeID string, req *dto.VerifyChallengeRequest) (*dto.VerificationStatusResponse, error)
+	// SetRiskLevel changes the risk level of a user
+	SetRiskLevel(ctx context.Context, userID string, req *dto.SetRiskLevelRequest) (*dto.VerificationStatusResponse, error)
+}
+
+// VerificationServiceConfig contains configuration for the verification service
+type VerificationServiceConfig struct {
+	// CodeTTL is how long a code can be used (default 10 minutes)
+	CodeTTL time.Duration
+	// MaxChallengesPerHour limits how many codes a user can request (default 5)
+	MaxChallengesPerHour int
+	// ExposeCodes returns codes in responses; for development only
+	ExposeCodes bool
+}
+
+// verificationService implements VerificationService
+type verificationService struct {
+	userRepo      repository.UserRepository
+	challengeRepo repository.VerificationChallengeRepository
+	sender        VerificationCodeSender
+	config        *VerificationServiceConfig
+}
+
+// NewVerificationService creates a new VerificationService
+func NewVerificationService(
+	userRepo repository.UserRepository,
+	challengeRepo repository.VerificationChallengeRepository,
+	sender VerificationCodeSender,
+	config *VerificationServiceConfig,
+) VerificationService {
+	if sender == nil {
+		sender = NewNoOpVerificationCodeSender()
+	}
+	if config == nil {
+		config = &VerificationServiceConfig{}
+	}
+	if config.CodeTTL == 0 {
+		config.CodeTTL = 10 * time.Minute
+	}
+	if config.MaxChallengesPerHour == 0 {
+		config.MaxChallengesPerHour = 5
+	}
+	return &verificationService{
+		userRepo:      userRepo,
+		challengeRepo: challengeRepo,
+		sender:        sender,
+		config:        config,
+	}
+}
+
+// GetStatus returns the verification state of a user
+func (s *verificationService) GetStatus(ctx context.Context, userID string) (*dto.VerificationStatusResponse, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return toVerificationStatusResponse(user), nil
+}
+
+// StartChallenge sends a one-time code to the user's email or phone
+func (s *verificationService) StartChallenge(ctx context.Context, userID string, req *dto.StartVerificationRequest) (*dto.VerificationChallengeResponse, error) {
+	if valid, errMsg := req.Validate(); !valid {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidVerificationInput, errMsg)
+	}
+
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every code costs an email or SMS, so limit how often they can be requested
+	now := time.Now()
+	count, err := s.challengeRepo.CountSince(ctx, userID, now.Add(-time.Hour))
+	if err != nil {
+		return nil, err
+	}
+	if count >= s.config.MaxChallengesPerHour {
+		return nil, ErrVerificationRateLimited
+	}
+
+	channel := domain.VerificationChannel(req.Channel)
+	destination := user.Email
+	if channel == domain.VerificationChannelPhone {
+		destination = req.Phone
+	}
+
+	code, err := generateVerificationCode()
+	if err != nil {
+		return nil, err
+	}
+
+	challenge := &domain.VerificationChallenge{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		Channel:     channel,
+		Destination: destination,
+		CodeHash:    hashVerificationCode(code),
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.config.CodeTTL),
+	}
+	if err := s.challengeRepo.Create(ctx, challenge); err != nil {
+		return nil, err
+	}
+	if err := s.sender.SendCode(ctx, challenge, code); err != nil {
+		return nil, err
+	}
+
+	resp := &dto.VerificationChallengeResponse{
+		ID:          challenge.ID,
+		Channel:     string(challenge.Channel),
+		Destination: challenge.Destination,
+		ExpiresAt:   challenge.ExpiresAt,
+	}
+	if s.config.ExposeCodes {
+		resp.DebugCode = code
+	}
+	return resp, nil
+}
+
+// VerifyChallenge checks a code and marks the channel verified
+func (s *verificationService) VerifyChallenge(ctx context.Context, userID, challengeID string, req *dto.VerifyChallengeRequest) (*dto.VerificationStatusResponse, error) {
+	challenge, err := s.challengeRepo.GetByID(ctx, challengeID)
+	if err != nil {
+		return nil, err
+	}
+	// Another user's challenge is reported as missing rather than forbidden
+	if challenge == nil || challenge.UserID != userID {
+		return nil, ErrChallengeNotFound
+	}
+	if challenge.VerifiedAt != nil {
+		return nil, ErrChallengeAlreadyUsed
+	}
+	if challenge.IsExpired() {
+		return nil, ErrChallengeExpired
+	}
+	if challenge.IsLocked() {
+		return nil, ErrChallengeLocked
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashVerificationCode(req.Code)), []byte(challenge.CodeHash)) != 1 {
+		challenge.Attempts++
+		if err := s.challengeRepo.Update(ctx, challenge); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidVerificationCode
+	}
+
+	now := time.Now()
+	challenge.VerifiedAt = &now
+	if err := s.challengeRepo.Update(ctx, challenge); err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.MarkVerified(ctx, userID, challenge.Channel, challenge.Destination, now); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.GetStatus(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	resp.RefreshRequired = true
+	return resp, nil
+}
+
+// SetRiskLevel changes the risk level of a user
+func (s *verificationService) SetRiskLevel(ctx context.Context, userID string, req *dto.SetRiskLevelRequest) (*dto.VerificationStatusResponse, error) {
+	level := domain.RiskLevel(req.RiskLevel)
+	if !level.IsValid() {
+		return nil, fmt.Errorf("%w: unknown risk level %s", ErrInvalidVerificationInput, req.RiskLevel)
+	}
+
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userRepo.UpdateRiskLevel(ctx, userID, level); err != nil {
+		return nil, err
+	}
+	user.RiskLevel = level
+	return toVerificationStatusResponse(user), nil
+}
+
+func (s *verificationService) getUser(ctx context.Context, userID string) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+// toVerificationStatusResponse converts the verification fields of a user to a response
+func toVerificationStatusResponse(user *domain.User) *dto.VerificationStatusResponse {
+	v := userVerification(user)
+	return &dto.VerificationStatusResponse{
+		EmailVerified: v.EmailVerified,
+		PhoneVerified: v.PhoneVerified,
+		RiskLevel:     v.RiskLevel,
+		VerifiedAt:    user.VerifiedAt,
+	}
+}
+
+// generateVerificationCode returns a random numeric code
+func generateVerificationCode() (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < verificationCodeDigits; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", verificationCodeDigits, n.Int64()), nil
+}
+
+// hashVerificationCode returns the SHA-256 of a code; codes are short-lived so no salt is needed
+func hashVerificationCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
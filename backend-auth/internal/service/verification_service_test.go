@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/dto"
+)
+
+// mockVerificationChallengeRepository is a mock implementation of VerificationChallengeRepository
+type mockVerificationChallengeRepository struct {
+	challenges map[string]*domain.VerificationChallenge
+}
+
+func newMockVerificationChallengeRepository() *mockVerificationChallengeRepository {
+	return &mockVerificationChallengeRepository{challenges: make(map[string]*domain.VerificationChallenge)}
+}
+
+func (r *mockVerificationChallengeRepository) Create(ctx context.Context, challenge *domain.VerificationChallenge) error {
+	r.challenges[challenge.ID] = challenge
+	return nil
+}
+
+func (r *mockVerificationChallengeRepository) GetByID(ctx context.Context, id string) (*domain.VerificationChallenge, error) {
+	return r.challenges[id], nil
+}
+
+func (r *mockVerificationChallengeRepository) Update(ctx context.Context, challenge *domain.VerificationChallenge) error {
+	r.challenges[challenge.ID] = challenge
+	return nil
+}
+
+func (r *mockVerificationChallengeRepository) CountSince(ctx context.Context, userID string, since time.Time) (int, error) {
+	count := 0
+	for _, challenge := range r.challenges {
+		if challenge.UserID == userID && !challenge.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// mockVerificationCodeSender records the codes it was asked to send
+type mockVerificationCodeSender struct {
+	codes map[string]string
+}
+
+func (s *mockVerificationCodeSender) SendCode(ctx context.Context, challenge *domain.VerificationChallenge, code string) error {
+	s.codes[challenge.ID] = code
+	return nil
+}
+
+func (s *mockVerificationCodeSender) Close() error {
+	return nil
+}
+
+func newTestVerificationService(t *testing.T) (VerificationService, *mockUserRepository, *mockVerificationCodeSender) {
+	t.Helper()
+	userRepo := newMockUserRepository()
+	userRepo.users["user-1"] = &domain.User{
+		ID:        "user-1",
+		Email:     "user@example.com",
+		Role:      domain.RoleCustomer,
+		RiskLevel: domain.RiskLevelNormal,
+		IsActive:  true,
+	}
+	sender := &mockVerificationCodeSender{codes: make(map[string]string)}
+	svc := NewVerificationService(userRepo, newMockVerificationChallengeRepository(), sender, &VerificationServiceConfig{
+		MaxChallengesPerHour: 2,
+	})
+	return svc, userRepo, sender
+}
+
+func TestVerificationService_VerifyChallenge(t *testing.T) {
+	svc, userRepo, sender := newTestVerificationService(t)
+	ctx := context.Background()
+
+	challenge, err := svc.StartChallenge(ctx, "user-1", &dto.StartVerificationRequest{Channel: "phone", Phone: "+66812345678"})
+	if err != nil {
+		t.Fatalf("StartChallenge failed: %v", err)
+	}
+	if challenge.DebugCode != "" {
+		t.Error("Expected code not to be exposed")
+	}
+	code := sender.codes[challenge.ID]
+	if len(code) != verificationCodeDigits {
+		t.Fatalf("Expected a %d digit code, got %q", verificationCodeDigits, code)
+	}
+
+	status, err := svc.VerifyChallenge(ctx, "user-1", challenge.ID, &dto.VerifyChallengeRequest{Code: code})
+	if err != nil {
+		t.Fatalf("VerifyChallenge failed: %v", err)
+	}
+	if !status.PhoneVerified || status.EmailVerified || status.VerifiedAt == nil || !status.RefreshRequired {
+		t.Errorf("Unexpected status: %+v", status)
+	}
+	if userRepo.users["user-1"].Phone != "+66812345678" {
+		t.Errorf("Expected verified phone to be saved, got %q", userRepo.users["user-1"].Phone)
+	}
+
+	if _, err := svc.VerifyChallenge(ctx, "user-1", challenge.ID, &dto.VerifyChallengeRequest{Code: code}); !errors.Is(err, ErrChallengeAlreadyUsed) {
+		t.Errorf("Expected %v, got %v", ErrChallengeAlreadyUsed, err)
+	}
+}
+
+func TestVerificationService_VerifyChallenge_Errors(t *testing.T) {
+	svc, _, sender := newTestVerificationService(t)
+	ctx := context.Background()
+
+	challenge, err := svc.StartChallenge(ctx, "user-1", &dto.StartVerificationRequest{Channel: "email"})
+	if err != nil {
+		t.Fatalf("StartChallenge failed: %v", err)
+	}
+	wrong := "000000"
+	if sender.codes[challenge.ID] == wrong {
+		wrong = "111111"
+	}
+
+	if _, err := svc.VerifyChallenge(ctx, "user-2", challenge.ID, &dto.VerifyChallengeRequest{Code: wrong}); !errors.Is(err, ErrChallengeNotFound) {
+		t.Errorf("Expected %v for another user's challenge, got %v", ErrChallengeNotFound, err)
+	}
+
+	for i := 0; i < domain.MaxVerificationAttempts; i++ {
+		if _, err := svc.VerifyChallenge(ctx, "user-1", challenge.ID, &dto.VerifyChallengeRequest{Code: wrong}); !errors.Is(err, ErrInvalidVerificationCode) {
+			t.Fatalf("Attempt %d: expected %v, got %v", i+1, ErrInvalidVerificationCode, err)
+		}
+	}
+
+	// The right code no longer works once the challenge is locked
+	_, err = svc.VerifyChallenge(ctx, "user-1", challenge.ID, &dto.VerifyChallengeRequest{Code: sender.codes[challenge.ID]})
+	if !errors.Is(err, ErrChallengeLocked) {
+		t.Errorf("Expected %v, got %v", ErrChallengeLocked, err)
+	}
+}
+
+func TestVerificationService_StartChallenge_Errors(t *testing.T) {
+	svc, _, _ := newTestVerificationService(t)
+	ctx := context.Background()
+
+	if _, err := svc.StartChallenge(ctx, "user-1", &dto.StartVerificationRequest{Channel: "phone"}); !errors.Is(err, ErrInvalidVerificationInput) {
+		t.Errorf("Expected %v for phone channel without number, got %v", ErrInvalidVerificationInput, err)
+	}
+	if _, err := svc.StartChallenge(ctx, "missing", &dto.StartVerificationRequest{Channel: "email"}); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Expected %v, got %v", ErrUserNotFound, err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := svc.StartChallenge(ctx, "user-1", &dto.StartVerificationRequest{Channel: "email"}); err != nil {
+			t.Fatalf("StartChallenge %d failed: %v", i+1, err)
+		}
+	}
+	if _, err := svc.StartChallenge(ctx, "user-1", &dto.StartVerificationRequest{Channel: "email"}); !errors.Is(err, ErrVerificationRateLimited) {
+		t.Errorf("Expected %v, got %v", ErrVerificationRateLimited, err)
+	}
+}
+
+func TestVerificationService_SetRiskLevel(t *testing.T) {
+	svc, userRepo, _ := newTestVerificationService(t)
+	ctx := context.Background()
+
+	status, err := svc.SetRiskLevel(ctx, "user-1", &dto.SetRiskLevelRequest{RiskLevel: "high"})
+	if err != nil {
+		t.Fatalf("SetRiskLevel failed: %v", err)
+	}
+	if status.RiskLevel != "high" || userRepo.users["user-1"].RiskLevel != domain.RiskLevelHigh {
+		t.Errorf("Expected risk level high, got %+v", status)
+	}
+
+	if _, err := svc.SetRiskLevel(ctx, "user-1", &dto.SetRiskLevelRequest{RiskLevel: "extreme"}); !errors.Is(err, ErrInvalidVerificationInput) {
+		t.Errorf("Expected %v, got %v", ErrInvalidVerificationInput, err)
+	}
+}
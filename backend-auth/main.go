@@ -2,25 +2,46 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/di"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/repository"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/service"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/config"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/i18n"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/middleware"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/storage"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/tenantconfig"
 )
 
+// filesPath is where signed downloads of stored files are served; the gateway proxies it
+const filesPath = "/api/v1/files/auth"
+
+// topicSpecs are the Kafka topics the auth service publishes to
+var topicSpecs = []kafka.TopicSpec{
+	{Name: tenantconfig.TopicSettingsChanged, Retention: 24 * time.Hour},
+	// Codes expire long before this, so they are not kept around
+	{Name: service.TopicVerificationCodes, Retention: time.Hour},
+}
+
 func main() {
+	smokeTest := flag.Bool("smoke", false, "check dependencies end to end and exit non-zero on failure")
+	flag.Parse()
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
@@ -29,7 +50,8 @@ func main() {
 
 	// Initialize logger with OTLP export support
 	logCfg := &logger.Config{
-		Level:        cfg.App.Environment,
+		Level:        cfg.Log.Level,
+		ModuleLevels: cfg.Log.ModuleLevels,
 		ServiceName:  "auth-service",
 		Development:  cfg.IsDevelopment(),
 		OTLPEnabled:  cfg.OTel.Enabled && cfg.OTel.LogExportEnabled,
@@ -44,16 +66,25 @@ func main() {
 	appLog := logger.Get()
 	appLog.Info("Starting Auth Service...")
 
+	required := []config.Requirement{config.RequireAuthDatabase, config.RequireKafka, config.RequireJWT}
+	if err := cfg.ValidateFor(required...); err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
+	appLog.Info("Effective configuration: " + cfg.Summary(required...))
+
 	ctx := context.Background()
 
 	// Initialize OpenTelemetry
 	telemetryCfg := &telemetry.Config{
-		Enabled:        cfg.OTel.Enabled,
-		ServiceName:    "auth-service",
-		ServiceVersion: cfg.App.Version,
-		Environment:    cfg.App.Environment,
-		CollectorAddr:  cfg.OTel.CollectorAddr,
-		SampleRatio:    cfg.OTel.SampleRatio,
+		Enabled:         cfg.OTel.Enabled,
+		ServiceName:     "auth-service",
+		ServiceVersion:  cfg.App.Version,
+		Environment:     cfg.App.Environment,
+		CollectorAddr:   cfg.OTel.CollectorAddr,
+		SampleRatio:     cfg.OTel.SampleRatio,
+		DynamicSampling: cfg.OTel.DynamicSampling,
+		SlowThreshold:   cfg.OTel.SlowThreshold,
+		SamplingRoutes:  cfg.OTel.SamplingRoutes,
 	}
 	if _, err := telemetry.Init(ctx, telemetryCfg); err != nil {
 		appLog.Warn(fmt.Sprintf("Failed to initialize telemetry: %v", err))
@@ -65,20 +96,21 @@ func main() {
 	// Initialize database connection (uses AuthDatabase config)
 	var db *database.PostgresDB
 	dbCfg := &database.PostgresConfig{
-		Host:            cfg.AuthDatabase.Host,
-		Port:            cfg.AuthDatabase.Port,
-		User:            cfg.AuthDatabase.User,
-		Password:        cfg.AuthDatabase.Password,
-		Database:        cfg.AuthDatabase.DBName,
-		SSLMode:         cfg.AuthDatabase.SSLMode,
-		MaxConns:        10, // Optimized: auth has low DB usage
-		MinConns:        2,
-		MaxConnLifetime: 30 * time.Minute,
-		MaxConnIdleTime: 5 * time.Minute,
-		ConnectTimeout:  5 * time.Second,
-		MaxRetries:      3,
-		RetryInterval:   1 * time.Second,
-		EnableTracing:   cfg.OTel.Enabled,
+		Host:               cfg.AuthDatabase.Host,
+		Port:               cfg.AuthDatabase.Port,
+		User:               cfg.AuthDatabase.User,
+		Password:           cfg.AuthDatabase.Password,
+		Database:           cfg.AuthDatabase.DBName,
+		SSLMode:            cfg.AuthDatabase.SSLMode,
+		MaxConns:           10, // Optimized: auth has low DB usage
+		MinConns:           2,
+		MaxConnLifetime:    30 * time.Minute,
+		MaxConnIdleTime:    5 * time.Minute,
+		ConnectTimeout:     5 * time.Second,
+		MaxRetries:         3,
+		RetryInterval:      1 * time.Second,
+		EnableTracing:      cfg.OTel.Enabled,
+		SlowQueryThreshold: cfg.AuthDatabase.SlowQueryThreshold,
 	}
 	db, err = database.NewPostgres(ctx, dbCfg)
 	if err != nil {
@@ -91,6 +123,89 @@ func main() {
 	userRepo := repository.NewPostgresUserRepository(db.Pool())
 	sessionRepo := repository.NewPostgresSessionRepository(db.Pool())
 	tenantRepo := repository.NewPostgresTenantRepository(db.Pool())
+	exportRepo := repository.NewPostgresDataExportRepository(db.Pool())
+	tenantSettingsRepo := repository.NewPostgresTenantSettingsRepository(db.Pool())
+	challengeRepo := repository.NewPostgresVerificationChallengeRepository(db.Pool())
+	deviceRepo := repository.NewPostgresDeviceRepository(db.Pool())
+	revocationRepo := repository.NewPostgresTokenRevocationRepository(db.Pool())
+	purchaseRepo := repository.NewPostgresPurchaseRepository(db.Pool())
+	oauthProviderRepo := repository.NewPostgresOAuthProviderRepository(db.Pool())
+	identityRepo := repository.NewPostgresUserIdentityRepository(db.Pool())
+
+	// Deploys gate on the self-check of every dependency before taking traffic
+	if *smokeTest {
+		suite := newSmokeSuite(&smokeDeps{
+			DB: db,
+			Kafka: kafka.TopicManagerConfig{
+				Brokers:  cfg.Kafka.Brokers,
+				ClientID: "auth-service-smoke",
+			},
+			Users:          userRepo,
+			Sessions:       sessionRepo,
+			Tenants:        tenantRepo,
+			Exports:        exportRepo,
+			TenantSettings: tenantSettingsRepo,
+			Challenges:     challengeRepo,
+			Devices:        deviceRepo,
+			Revocations:    revocationRepo,
+			Purchases:      purchaseRepo,
+			OAuthProviders: oauthProviderRepo,
+			Identities:     identityRepo,
+		})
+		if err := suite.Run(ctx, os.Stdout); err != nil {
+			appLog.Fatal(fmt.Sprintf("Smoke test failed: %v", err))
+		}
+		return
+	}
+
+	// Check the Kafka topics this service owns (missing topics are created when
+	// KAFKA_AUTO_CREATE_TOPICS is set, drift from the specs is only reported)
+	if err := kafka.ProvisionTopics(ctx, &kafka.TopicManagerConfig{
+		Brokers:           cfg.Kafka.Brokers,
+		ClientID:          "auth-service-admin",
+		Partitions:        int32(cfg.Kafka.TopicPartitions),
+		ReplicationFactor: int16(cfg.Kafka.TopicReplicationFactor),
+		AutoCreate:        cfg.Kafka.AutoCreateTopics,
+	}, topicSpecs); err != nil {
+		appLog.Warn(fmt.Sprintf("Kafka topic check failed: %v", err))
+	}
+
+	// Tenant settings changes are published so other services refresh their caches
+	var tenantSettingsPublisher service.TenantSettingsPublisher
+	tenantSettingsPublisher, err = service.NewKafkaTenantSettingsPublisher(ctx, &service.TenantSettingsPublisherConfig{
+		Brokers:     cfg.Kafka.Brokers,
+		ServiceName: "auth-service",
+		ClientID:    "auth-service-tenant-settings",
+	})
+	if err != nil {
+		appLog.Warn(fmt.Sprintf("Kafka connection failed, tenant settings changes will not be published: %v", err))
+		tenantSettingsPublisher = service.NewNoOpTenantSettingsPublisher()
+	} else {
+		appLog.Info("Tenant settings publisher connected")
+	}
+	defer tenantSettingsPublisher.Close()
+
+	// Verification codes are delivered by the notification pipeline consuming this topic
+	var verificationCodeSender service.VerificationCodeSender
+	verificationCodeSender, err = service.NewKafkaVerificationCodeSender(ctx, &service.VerificationCodeSenderConfig{
+		Brokers:     cfg.Kafka.Brokers,
+		ServiceName: "auth-service",
+		ClientID:    "auth-service-verification",
+	})
+	if err != nil {
+		appLog.Warn(fmt.Sprintf("Kafka connection failed, verification codes will not be delivered: %v", err))
+		verificationCodeSender = service.NewNoOpVerificationCodeSender()
+	} else {
+		appLog.Info("Verification code sender connected")
+	}
+	defer verificationCodeSender.Close()
+
+	// Services holding user data, used for GDPR data export and account deletion
+	env := config.NewEnv()
+	userDataClients := []service.UserDataClient{
+		service.NewHTTPUserDataClient("bookings", env.String("BOOKING_SERVICE_URL", "http://localhost:8083")),
+		service.NewHTTPUserDataClient("payments", env.String("PAYMENT_SERVICE_URL", "http://localhost:8084")),
+	}
 
 	// Get JWT secret from environment
 	jwtSecret := os.Getenv("JWT_SECRET")
@@ -103,20 +218,128 @@ func main() {
 		}
 	}
 
+	// Load-test mode mints tokens for synthetic users (config validation rejects it in production)
+	var loadTestConfig *service.LoadTestServiceConfig
+	if cfg.LoadTest.Enabled {
+		loadTestConfig = &service.LoadTestServiceConfig{
+			JWTSecret: jwtSecret,
+			JWTIssuer: cfg.JWT.Issuer,
+			MaxBatch:  cfg.LoadTest.MaxBatch,
+			TokenTTL:  cfg.LoadTest.TokenTTL,
+		}
+		appLog.Warn(fmt.Sprintf("LOAD TEST MODE ENABLED: synthetic user tokens can be minted at /internal/loadtest/users (max_batch=%d, token_ttl=%v)",
+			cfg.LoadTest.MaxBatch, cfg.LoadTest.TokenTTL))
+	}
+
+	// Validation results of /auth/validate are cached per token; logout-all revocations
+	// reach every replica within AUTH_REVOCATION_REFRESH and callers within
+	// AUTH_VALIDATE_CLIENT_MAX_AGE on top of that
+	tokenValidationConfig := &service.TokenValidationConfig{
+		CacheTTL:      env.Duration("AUTH_VALIDATE_CACHE_TTL", 30*time.Second),
+		ClientMaxAge:  env.Duration("AUTH_VALIDATE_CLIENT_MAX_AGE", 5*time.Second),
+		NegativeTTL:   env.Duration("AUTH_VALIDATE_NEGATIVE_TTL", 5*time.Second),
+		RevocationTTL: env.Duration("AUTH_REVOCATION_REFRESH", 2*time.Second),
+		MaxEntries:    env.Int("AUTH_VALIDATE_CACHE_SIZE", 100000),
+	}
+
+	// Tenants' own from addresses must authorize our mail servers before they are used
+	senderDomainConfig := &service.SenderDomainConfig{
+		SPFInclude:   env.String("SENDER_SPF_INCLUDE", service.DefaultSenderSPFInclude),
+		DKIMSelector: env.String("SENDER_DKIM_SELECTOR", service.DefaultSenderDKIMSelector),
+		DKIMTarget:   env.String("SENDER_DKIM_TARGET", service.DefaultSenderDKIMTarget),
+	}
+
+	// Show start times tell attended events from upcoming ones in the purchase summary
+	purchaseConfig := &service.PurchaseServiceConfig{
+		Shows: service.NewHTTPShowScheduleClient(env.String("TICKET_SERVICE_URL", "http://localhost:8082")),
+	}
+
+	// Social login uses the platform's clients for tenants that registered none of their own
+	oauthConfig := &service.OAuthServiceConfig{
+		StateSecret: jwtSecret,
+		StateTTL:    env.Duration("OAUTH_STATE_TTL", 10*time.Minute),
+		Platform:    oauthPlatformClients(env),
+	}
+
+	// Data export archives go to object storage when configured, the database otherwise
+	exportTTL := 7 * 24 * time.Hour
+	storageCfg := storageConfig(env)
+	lifecycleInterval := env.Duration("STORAGE_LIFECYCLE_INTERVAL", time.Hour)
+	if err := env.Err(); err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
+	fileStore, err := storage.Open(storageCfg)
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("File storage unavailable: %v", err))
+	}
+	lifecycleCtx, stopLifecycle := context.WithCancel(ctx)
+	defer stopLifecycle()
+	if fileStore != nil {
+		appLog.Info(fmt.Sprintf("File storage enabled (backend: %s)", storageCfg.Backend))
+		go storage.RunLifecycle(lifecycleCtx, fileStore, []storage.LifecycleRule{
+			{Prefix: service.DataExportPrefix, MaxAge: exportTTL},
+		}, lifecycleInterval)
+	}
+
 	// Build dependency injection container
 	container := di.NewContainer(&di.ContainerConfig{
 		DB:          db,
 		UserRepo:    userRepo,
 		SessionRepo: sessionRepo,
 		TenantRepo:  tenantRepo,
+		ExportRepo:  exportRepo,
+
+		TenantSettingsRepo:      tenantSettingsRepo,
+		TenantSettingsPublisher: tenantSettingsPublisher,
+		SenderDomainConfig:      senderDomainConfig,
+
+		ChallengeRepo:          challengeRepo,
+		DeviceRepo:             deviceRepo,
+		RevocationRepo:         revocationRepo,
+		TokenValidationConfig:  tokenValidationConfig,
+		PurchaseRepo:           purchaseRepo,
+		PurchaseConfig:         purchaseConfig,
+		OAuthProviderRepo:      oauthProviderRepo,
+		IdentityRepo:           identityRepo,
+		OAuthConfig:            oauthConfig,
+		VerificationCodeSender: verificationCodeSender,
+		VerificationConfig: &service.VerificationServiceConfig{
+			CodeTTL:              10 * time.Minute,
+			MaxChallengesPerHour: 5,
+			ExposeCodes:          cfg.IsDevelopment(), // Lets local setups verify without a mail or SMS provider
+		},
 		ServiceConfig: &service.AuthServiceConfig{
-			JWTSecret:          jwtSecret,
-			AccessTokenExpiry:  15 * time.Minute,
-			RefreshTokenExpiry: 7 * 24 * time.Hour,
-			BcryptCost:         12, // Per P3-02 requirement
+			JWTSecret:           jwtSecret,
+			JWTIssuer:           cfg.JWT.Issuer,              // Access tokens are scoped to the user's tenant
+			RequireTenantClaims: cfg.JWT.RequireTenantClaims, // Rejects tokens minted before tenant claims
+			AccessTokenExpiry:   15 * time.Minute,
+			RefreshTokenExpiry:  7 * 24 * time.Hour,
+			BcryptCost:          12, // Per P3-02 requirement
+		},
+		UserDataClients: userDataClients,
+		LoadTestConfig:  loadTestConfig,
+		PrivacyConfig: &service.PrivacyServiceConfig{
+			ExportTTL:     exportTTL,
+			ExportTimeout: 2 * time.Minute,
+			DeleteTimeout: 8 * time.Second, // Must fit within server WriteTimeout
+			Archives:      fileStore,
 		},
 	})
 
+	// The purchase summary is served from a projection of booking and payment events
+	consumerCtx, stopConsumers := context.WithCancel(ctx)
+	defer stopConsumers()
+	purchaseConsumer, err := service.NewPurchaseProjectionConsumer(consumerCtx, &service.PurchaseProjectionConsumerConfig{
+		Brokers: cfg.Kafka.Brokers,
+	}, container.PurchaseService)
+	if err != nil {
+		appLog.Warn(fmt.Sprintf("Kafka connection failed, purchase summaries will not be updated: %v", err))
+	} else {
+		defer purchaseConsumer.Close()
+		go purchaseConsumer.Run(consumerCtx)
+		appLog.Info("Purchase history consumer started")
+	}
+
 	// Setup Gin
 	if cfg.IsDevelopment() {
 		gin.SetMode(gin.DebugMode)
@@ -133,6 +356,9 @@ func main() {
 		router.Use(telemetry.TraceHeaderMiddleware())
 	}
 
+	// Locale negotiated by the gateway, used to localize notifications
+	router.Use(i18n.Middleware())
+
 	// Health check endpoints
 	router.GET("/health", container.HealthHandler.Health)
 	router.GET("/ready", container.HealthHandler.Ready)
@@ -148,16 +374,45 @@ func main() {
 			auth.POST("/refresh", container.AuthHandler.RefreshToken)
 			auth.POST("/logout", container.AuthHandler.Logout)
 
+			// Social login: the client sends the user to authorization_url and posts
+			// back the code and state the provider redirected to it with
+			auth.GET("/oauth/providers", container.OAuthHandler.Providers)
+			auth.GET("/oauth/:provider/authorize", container.OAuthHandler.Authorize)
+			auth.POST("/oauth/:provider/callback", container.OAuthHandler.Callback)
+
 			// Internal endpoint for token validation (used by other services)
 			auth.POST("/validate", container.AuthHandler.ValidateToken)
 
 			// Protected endpoints (require authentication)
 			protected := auth.Group("")
-			protected.Use(authMiddleware(container.AuthService))
+			protected.Use(authMiddleware(container.TokenValidationService))
 			{
 				protected.GET("/me", container.AuthHandler.Me)
 				protected.PUT("/me", container.AuthHandler.UpdateMe)
 				protected.POST("/logout-all", container.AuthHandler.LogoutAll)
+
+				// GDPR: data export (async archive) and account deletion (anonymization saga)
+				protected.DELETE("/me", container.PrivacyHandler.DeleteMe)
+				protected.POST("/me/data-export", container.PrivacyHandler.RequestDataExport)
+				protected.GET("/me/data-export/:id", container.PrivacyHandler.GetDataExport)
+				protected.GET("/me/data-export/:id/download", container.PrivacyHandler.DownloadDataExport)
+
+				// Account verification, required by events with a verification policy
+				protected.GET("/me/verification", container.VerificationHandler.GetStatus)
+				protected.POST("/me/verification/challenges", container.VerificationHandler.StartChallenge)
+				protected.POST("/me/verification/challenges/:id/verify", container.VerificationHandler.VerifyChallenge)
+
+				// Push notification devices
+				protected.POST("/me/devices", container.DeviceHandler.Register)
+				protected.GET("/me/devices", container.DeviceHandler.List)
+				protected.DELETE("/me/devices/:id", container.DeviceHandler.Remove)
+
+				// Spending, attended and upcoming events from the purchase history projection
+				protected.GET("/me/purchase-summary", container.PurchaseHandler.Summary)
+
+				// Social login accounts linked to the user
+				protected.GET("/me/identities", container.OAuthHandler.ListIdentities)
+				protected.POST("/me/identities/:provider", container.OAuthHandler.Link)
 			}
 
 			// Internal endpoints for service-to-service communication
@@ -171,8 +426,9 @@ func main() {
 
 		// Tenant management routes (Admin/Super Admin only)
 		tenants := v1.Group("/tenants")
-		tenants.Use(authMiddleware(container.AuthService))
+		tenants.Use(authMiddleware(container.TokenValidationService))
 		tenants.Use(adminOnlyMiddleware())
+		tenants.Use(middleware.RequireTenantAccess(middleware.TenantFromParam("id"))) // Tenant admins only reach their own tenant
 		{
 			tenants.POST("", container.TenantHandler.Create)
 			tenants.GET("", container.TenantHandler.List)
@@ -180,7 +436,55 @@ func main() {
 			tenants.GET("/slug/:slug", container.TenantHandler.GetBySlug)
 			tenants.PUT("/:id", container.TenantHandler.Update)
 			tenants.DELETE("/:id", container.TenantHandler.Delete)
+
+			// Typed tenant settings; reads are cached by other services via pkg/tenantconfig
+			tenants.GET("/:id/settings", container.TenantSettingsHandler.Get)
+			tenants.PUT("/:id/settings", container.TenantSettingsHandler.Update)
+			tenants.DELETE("/:id/settings", container.TenantSettingsHandler.Reset)
+			// Per-tenant email sender; the from domain is verified through DNS
+			tenants.GET("/:id/settings/sender", container.TenantSettingsHandler.GetSender)
+			tenants.POST("/:id/settings/sender/verify", container.TenantSettingsHandler.VerifySender)
+			// Tenants' own social login clients (secrets are never returned)
+			tenants.GET("/:id/oauth-providers", container.OAuthHandler.ListTenantProviders)
+			tenants.PUT("/:id/oauth-providers/:provider", container.OAuthHandler.SaveTenantProvider)
+			tenants.DELETE("/:id/oauth-providers/:provider", container.OAuthHandler.DeleteTenantProvider)
+		}
+	}
+
+	// Signed downloads of local or encrypted files (authorized by the URL signature)
+	if fileStore != nil {
+		files, err := storage.NewServingHandler(fileStore, storageCfg)
+		if err != nil {
+			appLog.Fatal(fmt.Sprintf("File storage unavailable: %v", err))
 		}
+		if files != nil {
+			router.GET(filesPath+"/*key", gin.WrapH(http.StripPrefix(filesPath, files)))
+		}
+	}
+
+	// Internal routes for service-to-service calls (not exposed via API gateway)
+	// Used by booking-service to resolve customer emails for support booking search
+	internalUsers := router.Group("/internal/users")
+	{
+		internalUsers.GET("", container.AuthHandler.LookupUsers)
+		// Used by fraud tooling to route users through verification challenges
+		internalUsers.PUT("/:id/risk-level", container.VerificationHandler.SetRiskLevel)
+		// Used by the notification pipeline to deliver push notifications
+		internalUsers.GET("/:id/devices", container.DeviceHandler.ListPushDevices)
+	}
+
+	// Used by the notification pipeline to drop tokens the push providers rejected
+	router.POST("/internal/devices/revoke", container.DeviceHandler.RevokeTokens)
+
+	// Used by pkg/tenantconfig clients on a cache miss
+	internalTenants := router.Group("/internal/tenants")
+	{
+		internalTenants.GET("/:id/settings", container.TenantSettingsHandler.Get)
+	}
+
+	// Used by k6/vegeta scenarios to sign in synthetic users (load-test mode only)
+	if container.LoadTestHandler != nil {
+		router.POST("/internal/loadtest/users", container.LoadTestHandler.MintUsers)
 	}
 
 	// Create HTTP server
@@ -224,7 +528,7 @@ func main() {
 }
 
 // authMiddleware validates JWT token and sets user claims in context
-func authMiddleware(authService service.AuthService) gin.HandlerFunc {
+func authMiddleware(tokenValidation service.TokenValidationService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -252,7 +556,7 @@ func authMiddleware(authService service.AuthService) gin.HandlerFunc {
 		}
 		token := authHeader[len(bearerPrefix):]
 
-		claims, err := authService.ValidateToken(c.Request.Context(), token)
+		validation, err := tokenValidation.Validate(c.Request.Context(), token)
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
 				"success": false,
@@ -265,9 +569,12 @@ func authMiddleware(authService service.AuthService) gin.HandlerFunc {
 		}
 
 		// Set user info in context
+		claims := validation.Claims
 		c.Set("user_id", claims.UserID)
 		c.Set("email", claims.Email)
 		c.Set("role", string(claims.Role))
+		c.Set(middleware.ContextKeyTenantID, claims.TenantID)
+		c.Set(middleware.ContextKeyAudience, claims.Audience)
 		c.Next()
 	}
 }
@@ -302,3 +609,39 @@ func adminOnlyMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// storageConfig reads the object storage configuration shared by the services
+func storageConfig(env *config.Env) *storage.Config {
+	return &storage.Config{
+		Backend:  env.String("STORAGE_BACKEND", storage.BackendNone),
+		LocalDir: env.String("STORAGE_LOCAL_DIR", "./data/files"),
+		S3: storage.S3Config{
+			Endpoint:  env.String("STORAGE_S3_ENDPOINT", ""),
+			Region:    env.String("STORAGE_S3_REGION", "ap-southeast-1"),
+			Bucket:    env.String("STORAGE_S3_BUCKET", ""),
+			AccessKey: env.String("STORAGE_S3_ACCESS_KEY", ""),
+			SecretKey: env.String("STORAGE_S3_SECRET_KEY", ""),
+			PathStyle: env.Bool("STORAGE_S3_PATH_STYLE", false),
+		},
+		PublicURL:      env.String("STORAGE_PUBLIC_URL", "http://localhost:8080") + filesPath,
+		SigningKey:     env.String("STORAGE_SIGNING_KEY", ""),
+		EncryptionKeys: env.String("STORAGE_ENCRYPTION_KEYS", ""),
+	}
+}
+
+// oauthPlatformClients reads the platform's social login clients; a provider without
+// a client ID is offered only to tenants that registered their own client
+func oauthPlatformClients(env *config.Env) map[string]*domain.OAuthProviderConfig {
+	clients := make(map[string]*domain.OAuthProviderConfig, len(domain.OAuthProviders))
+	for _, provider := range domain.OAuthProviders {
+		prefix := "OAUTH_" + strings.ToUpper(provider) + "_"
+		clients[provider] = &domain.OAuthProviderConfig{
+			Provider:     provider,
+			ClientID:     env.String(prefix+"CLIENT_ID", ""),
+			ClientSecret: env.String(prefix+"CLIENT_SECRET", ""),
+			RedirectURL:  env.String(prefix+"REDIRECT_URL", ""),
+			Enabled:      true,
+		}
+	}
+	return clients
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-auth/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/smoke"
+)
+
+// schemaVersion is the latest migration in scripts/migrations/auth. -smoke fails
+// until the auth database is migrated to it.
+const schemaVersion = 14
+
+// smokeDeps are the dependencies checked by -smoke
+type smokeDeps struct {
+	DB             *database.PostgresDB
+	Kafka          kafka.TopicManagerConfig
+	Users          repository.UserRepository
+	Sessions       repository.SessionRepository
+	Tenants        repository.TenantRepository
+	Exports        repository.DataExportRepository
+	TenantSettings repository.TenantSettingsRepository
+	Challenges     repository.VerificationChallengeRepository
+	Devices        repository.DeviceRepository
+	Revocations    repository.TokenRevocationRepository
+	Purchases      repository.PurchaseRepository
+	OAuthProviders repository.OAuthProviderRepository
+	Identities     repository.UserIdentityRepository
+}
+
+// newSmokeSuite builds the self-check run by -smoke. Repositories return nil for
+// the probe ID, so any error fails the round trip.
+func newSmokeSuite(d *smokeDeps) *smoke.Suite {
+	suite := smoke.NewSuite("auth-service", 0)
+
+	suite.Add("postgres", smoke.Postgres(d.DB))
+	suite.Add("migrations", smoke.Migrations(d.DB, schemaVersion))
+	suite.Add("kafka topics", smoke.KafkaTopics(d.Kafka, topicSpecs))
+
+	// One read per repository; the probe ID matches nothing
+	suite.Add("users repository", func(ctx context.Context) error {
+		_, err := d.Users.GetByID(ctx, smoke.ProbeID)
+		return err
+	})
+	suite.Add("sessions repository", func(ctx context.Context) error {
+		_, err := d.Sessions.GetByID(ctx, smoke.ProbeID)
+		return err
+	})
+	suite.Add("tenants repository", func(ctx context.Context) error {
+		_, err := d.Tenants.GetByID(ctx, smoke.ProbeID)
+		return err
+	})
+	suite.Add("data exports repository", func(ctx context.Context) error {
+		_, err := d.Exports.GetByID(ctx, smoke.ProbeID)
+		return err
+	})
+	suite.Add("tenant settings repository", func(ctx context.Context) error {
+		_, err := d.TenantSettings.GetByTenantID(ctx, smoke.ProbeID)
+		return err
+	})
+	suite.Add("verification challenges repository", func(ctx context.Context) error {
+		_, err := d.Challenges.GetByID(ctx, smoke.ProbeID)
+		return err
+	})
+	suite.Add("devices repository", func(ctx context.Context) error {
+		_, err := d.Devices.ListByUserID(ctx, smoke.ProbeID)
+		return err
+	})
+	suite.Add("token revocations repository", func(ctx context.Context) error {
+		_, err := d.Revocations.GetRevokedAt(ctx, smoke.ProbeID)
+		return err
+	})
+	suite.Add("purchases repository", func(ctx context.Context) error {
+		_, err := d.Purchases.ListByUserID(ctx, smoke.ProbeID)
+		return err
+	})
+	suite.Add("oauth providers repository", func(ctx context.Context) error {
+		_, err := d.OAuthProviders.Get(ctx, smoke.ProbeID, domain.OAuthProviderGoogle)
+		return err
+	})
+	suite.Add("user identities repository", func(ctx context.Context) error {
+		_, err := d.Identities.ListByUserID(ctx, smoke.ProbeID)
+		return err
+	})
+	return suite
+}
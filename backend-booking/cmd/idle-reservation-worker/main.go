@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/sharding"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/worker"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/config"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/push"
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+)
+
+func main() {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Initialize logger with OTLP export support
+	logCfg := &logger.Config{
+		Level:        cfg.Log.Level,
+		ModuleLevels: cfg.Log.ModuleLevels,
+		ServiceName:  "idle-reservation-worker",
+		Development:  cfg.IsDevelopment(),
+		OTLPEnabled:  cfg.OTel.Enabled && cfg.OTel.LogExportEnabled,
+		OTLPEndpoint: cfg.OTel.CollectorAddr,
+		OTLPInsecure: true,
+	}
+	if err := logger.Init(logCfg); err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer logger.Sync()
+
+	appLog := logger.Get()
+	appLog.Info("Starting Idle Reservation Worker...")
+
+	required := []config.Requirement{config.RequireBookingDatabase, config.RequireRedis, config.RequireKafka, config.RequireJWT}
+	if err := cfg.ValidateFor(required...); err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
+	appLog.Info("Effective configuration: " + cfg.Summary(required...))
+
+	env := config.NewEnv()
+	idleAfter := env.Duration("IDLE_RESERVATION_AFTER", 3*time.Minute)
+	pollInterval := env.Duration("IDLE_RESERVATION_POLL_INTERVAL", 15*time.Second)
+	releaseURL := env.String("IDLE_RELEASE_URL", "http://localhost:8080/api/v1/bookings/release-link")
+	if err := env.Err(); err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Initialize database connection (reservations are in booking_db)
+	dbCfg := &database.PostgresConfig{
+		Host:          cfg.BookingDatabase.Host,
+		Port:          cfg.BookingDatabase.Port,
+		User:          cfg.BookingDatabase.User,
+		Password:      cfg.BookingDatabase.Password,
+		Database:      cfg.BookingDatabase.DBName,
+		SSLMode:       cfg.BookingDatabase.SSLMode,
+		MaxConns:      int32(cfg.BookingDatabase.MaxOpenConns),
+		MinConns:      int32(cfg.BookingDatabase.MaxIdleConns),
+		MaxRetries:    3,
+		RetryInterval: 2 * time.Second,
+	}
+	db, err := database.NewPostgres(ctx, dbCfg)
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("Failed to connect to database: %v", err))
+	}
+	defer db.Close()
+	appLog.Info("Database connected")
+
+	// Initialize Redis connection (started payments and sent reminders)
+	redisCfg := &pkgredis.Config{
+		Host:          cfg.Redis.Host,
+		Port:          cfg.Redis.Port,
+		Password:      cfg.Redis.Password,
+		DB:            cfg.Redis.DB,
+		PoolSize:      cfg.Redis.PoolSize,
+		MaxRetries:    3,
+		RetryInterval: 2 * time.Second,
+	}
+	redis, err := pkgredis.NewClient(ctx, redisCfg)
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("Failed to connect to Redis: %v", err))
+	}
+	defer redis.Close()
+	appLog.Info("Redis connected")
+
+	// Reminders are push notifications; without a provider the worker stays idle
+	pushWorker := newPushNotifier(ctx, cfg, appLog)
+	if pushWorker == nil {
+		appLog.Warn("Idle reservation reminders disabled: they need push notifications")
+		waitForShutdown()
+		return
+	}
+
+	workerCfg := worker.DefaultIdleReservationWorkerConfig()
+	workerCfg.PollInterval = pollInterval
+
+	// Initialize Kafka consumer for payments started in payment-service
+	consumerCfg := &kafka.ConsumerConfig{
+		Brokers:        cfg.Kafka.Brokers,
+		GroupID:        "idle-reservation-worker",
+		Topics:         []string{workerCfg.PaymentTopic},
+		ClientID:       "idle-reservation-worker",
+		MaxRetries:     3,
+		RetryInterval:  2 * time.Second,
+		SessionTimeout: 30 * time.Second,
+	}
+	consumer, err := kafka.NewConsumer(ctx, consumerCfg)
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("Failed to create Kafka consumer: %v", err))
+	}
+	defer consumer.Close()
+	appLog.Info("Kafka consumer connected")
+
+	// Initialize repositories
+	var bookingRepo repository.BookingRepository = repository.NewPostgresBookingRepository(db.Pool())
+	tenantShards, err := database.OpenShardResolver(ctx, db, dbCfg, cfg.BookingDatabase.Shards, cfg.BookingDatabase.ShardRefreshInterval)
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("Tenant shards unavailable: %v", err))
+	}
+	if tenantShards != nil {
+		defer tenantShards.Close()
+		bookingRepo = sharding.NewPostgresBookingRepository(tenantShards)
+	}
+
+	// Links are released by booking-service, which verifies them with the same secret
+	idleService := service.NewIdleReservationService(bookingRepo, repository.NewRedisIdleReservationRepository(redis), nil, pushWorker, &service.IdleReservationServiceConfig{
+		IdleAfter:      idleAfter,
+		PaymentMarkTTL: cfg.Booking.PaymentGrace.MaxHold,
+		Secret:         cfg.JWT.Secret,
+		ReleaseURL:     releaseURL,
+	})
+
+	// Create and start idle reservation worker
+	idleWorker := worker.NewIdleReservationWorker(workerCfg, consumer, idleService, appLog)
+	go idleWorker.Start(ctx)
+	appLog.Info(fmt.Sprintf("Idle reservation worker started (idle after: %v)", idleAfter))
+
+	waitForShutdown()
+
+	appLog.Info("Shutting down idle reservation worker...")
+	cancel()
+
+	// Give queued reminders time to be delivered
+	time.Sleep(2 * time.Second)
+	pushWorker.Wait()
+	appLog.Info("Idle reservation worker stopped")
+}
+
+// waitForShutdown blocks until the process is asked to stop
+func waitForShutdown() {
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+}
+
+// newPushNotifier starts the push worker when FCM or APNs credentials are configured,
+// returning nil when push is disabled
+func newPushNotifier(ctx context.Context, cfg *config.Config, appLog *logger.Logger) *worker.PushWorker {
+	env := config.NewEnv()
+	providers, err := push.NewProviders(&push.ProvidersConfig{
+		FCMCredentialsFile: env.String("PUSH_FCM_CREDENTIALS_FILE", ""),
+		APNsKeyFile:        env.String("PUSH_APNS_KEY_FILE", ""),
+		APNsKeyID:          env.String("PUSH_APNS_KEY_ID", ""),
+		APNsTeamID:         env.String("PUSH_APNS_TEAM_ID", ""),
+		APNsTopic:          env.String("PUSH_APNS_TOPIC", ""),
+		APNsProduction:     env.Bool("PUSH_APNS_PRODUCTION", cfg.IsProduction()),
+	})
+	if err == nil {
+		err = env.Err()
+	}
+	if err != nil {
+		appLog.Warn(fmt.Sprintf("Push notifications disabled: %v", err))
+		return nil
+	}
+
+	dispatcher := push.NewDispatcher(nil, providers...)
+	if !dispatcher.Enabled() {
+		appLog.Info("Push notifications disabled: no FCM or APNs credentials configured")
+		return nil
+	}
+	if cfg.Services.AuthServiceURL == "" {
+		appLog.Warn("Push notifications disabled: AUTH_SERVICE_URL is not set")
+		return nil
+	}
+
+	notifier := service.NewDevicePushNotifier(service.NewHTTPDeviceDirectory(cfg.Services.AuthServiceURL), dispatcher, service.NewZapLoggerAdapter(appLog))
+	pushWorker := worker.NewPushWorker(notifier, worker.DefaultPushWorkerConfig(), appLog)
+	pushWorker.Start(ctx)
+	appLog.Info(fmt.Sprintf("Push notifications enabled for %d provider(s)", len(providers)))
+	return pushWorker
+}
@@ -9,9 +9,11 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/worker"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/config"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/events"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
 	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
@@ -24,11 +26,15 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
-	// Initialize logger
+	// Initialize logger with OTLP export support
 	logCfg := &logger.Config{
-		Level:       cfg.App.Environment,
-		ServiceName: "inventory-worker",
-		Development: cfg.IsDevelopment(),
+		Level:        cfg.Log.Level,
+		ModuleLevels: cfg.Log.ModuleLevels,
+		ServiceName:  "inventory-worker",
+		Development:  cfg.IsDevelopment(),
+		OTLPEnabled:  cfg.OTel.Enabled && cfg.OTel.LogExportEnabled,
+		OTLPEndpoint: cfg.OTel.CollectorAddr,
+		OTLPInsecure: true,
 	}
 	if err := logger.Init(logCfg); err != nil {
 		log.Fatalf("Failed to initialize logger: %v", err)
@@ -38,6 +44,12 @@ func main() {
 	appLog := logger.Get()
 	appLog.Info("Starting Inventory Sync Worker...")
 
+	required := []config.Requirement{config.RequireTicketDatabase, config.RequireRedis, config.RequireKafka}
+	if err := cfg.ValidateFor(required...); err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
+	appLog.Info("Effective configuration: " + cfg.Summary(required...))
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -82,7 +94,7 @@ func main() {
 	consumerCfg := &kafka.ConsumerConfig{
 		Brokers:        cfg.Kafka.Brokers,
 		GroupID:        "inventory-sync-worker",
-		Topics:         []string{"booking-events"},
+		Topics:         []string{events.TopicBookingEvents, events.TopicZoneCapacityEvents},
 		ClientID:       "inventory-worker",
 		MaxRetries:     3,
 		RetryInterval:  2 * time.Second,
@@ -100,10 +112,21 @@ func main() {
 		BatchInterval:    5 * time.Second,
 		MaxBatchSize:     1000,
 		RebuildOnStartup: true,
+		BookingTopic:     events.TopicBookingEvents,
+		CapacityTopic:    events.TopicZoneCapacityEvents,
 	}
 
+	// Zone capacity changes are applied through a Lua script so in-flight holds are respected
+	capacityRepo := repository.NewRedisZoneCapacityRepository(redis)
+	if err := capacityRepo.LoadScripts(ctx); err != nil {
+		appLog.Warn(fmt.Sprintf("Failed to pre-load zone capacity Lua script: %v", err))
+	}
+
+	// Rebuilds fold sharded zones back onto their primary counter
+	shardRepo := repository.NewRedisZoneShardRepository(redis)
+
 	// Create and start inventory worker
-	inventoryWorker := worker.NewInventoryWorker(workerCfg, consumer, db, redis, appLog)
+	inventoryWorker := worker.NewInventoryWorker(workerCfg, consumer, db, redis, capacityRepo, shardRepo, appLog)
 
 	// Rebuild Redis from DB on startup if enabled
 	if workerCfg.RebuildOnStartup {
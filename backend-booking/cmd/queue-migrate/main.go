@@ -0,0 +1,98 @@
+// Command queue-migrate moves virtual queues between queue backends (zset, streams)
+// without taking the queue offline.
+//
+// Online migration:
+//  1. Deploy booking-service and queue-release-worker with QUEUE_BACKEND=<new> and
+//     QUEUE_BACKEND_MIGRATE_FROM=<old>. New joins go to the new backend while users
+//     already queued in the old one keep their place and are released first.
+//  2. Run queue-migrate -from <old> -to <new>. Queues are moved in join order; queues
+//     whose target already has users are reported as draining and stay in the old backend.
+//  3. Re-run until nothing is left (exit status 2 while queues are still draining), then
+//     unset QUEUE_BACKEND_MIGRATE_FROM.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/config"
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+)
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	if err := cfg.ValidateFor(config.RequireRedis); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+
+	defaultFrom := cfg.Booking.Queue.MigrateFrom
+	if defaultFrom == "" {
+		defaultFrom = repository.QueueBackendZSet
+	}
+	from := flag.String("from", defaultFrom, "source queue backend (zset, streams)")
+	to := flag.String("to", cfg.Booking.Queue.Backend, "target queue backend (zset, streams)")
+	dryRun := flag.Bool("dry-run", false, "report queues that would be moved without writing")
+	timeout := flag.Duration("timeout", 10*time.Minute, "overall timeout")
+	flag.Parse()
+
+	if *from == *to {
+		log.Fatalf("Source and target backend are both %q", *from)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	redisCfg := &pkgredis.Config{
+		Host:          cfg.Redis.Host,
+		Port:          cfg.Redis.Port,
+		Password:      cfg.Redis.Password,
+		DB:            cfg.Redis.DB,
+		PoolSize:      cfg.Redis.PoolSize,
+		MaxRetries:    3,
+		RetryInterval: 2 * time.Second,
+	}
+	redis, err := pkgredis.NewClient(ctx, redisCfg)
+	if err != nil {
+		log.Fatalf("Failed to connect to Redis: %v", err)
+	}
+	defer redis.Close()
+
+	source, err := repository.NewQueueBackend(redis, *from)
+	if err != nil {
+		log.Fatalf("Invalid source backend: %v", err)
+	}
+	target, err := repository.NewQueueBackend(redis, *to)
+	if err != nil {
+		log.Fatalf("Invalid target backend: %v", err)
+	}
+	for _, backend := range []repository.QueueBackend{source, target} {
+		if err := backend.LoadScripts(ctx); err != nil {
+			log.Fatalf("Failed to load %s queue scripts: %v", backend.Name(), err)
+		}
+	}
+
+	log.Printf("Migrating queues from %s to %s (dry run: %v)", source.Name(), target.Name(), *dryRun)
+	report, err := repository.MigrateQueues(ctx, source, target, *dryRun)
+	if report != nil {
+		out, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Println(string(out))
+	}
+	if err != nil {
+		log.Fatalf("Migration failed: %v", err)
+	}
+
+	if len(report.Draining) > 0 {
+		log.Printf("%d queue(s) still draining from %s; re-run to check again", len(report.Draining), source.Name())
+		os.Exit(2)
+	}
+	log.Printf("Migrated %d user(s) in %d queue(s)", report.Users, len(report.Migrated))
+}
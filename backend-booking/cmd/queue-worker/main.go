@@ -9,11 +9,15 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/metrics"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/worker"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/config"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/push"
 	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
 )
 
 func main() {
@@ -23,11 +27,15 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
-	// Initialize logger
+	// Initialize logger with OTLP export support
 	logCfg := &logger.Config{
-		Level:       cfg.App.Environment,
-		ServiceName: "queue-release-worker",
-		Development: cfg.IsDevelopment(),
+		Level:        cfg.Log.Level,
+		ModuleLevels: cfg.Log.ModuleLevels,
+		ServiceName:  "queue-release-worker",
+		Development:  cfg.IsDevelopment(),
+		OTLPEnabled:  cfg.OTel.Enabled && cfg.OTel.LogExportEnabled,
+		OTLPEndpoint: cfg.OTel.CollectorAddr,
+		OTLPInsecure: true,
 	}
 	if err := logger.Init(logCfg); err != nil {
 		log.Fatalf("Failed to initialize logger: %v", err)
@@ -37,9 +45,38 @@ func main() {
 	appLog := logger.Get()
 	appLog.Info("Starting Queue Release Worker...")
 
+	required := []config.Requirement{config.RequireRedis, config.RequireJWT}
+	if err := cfg.ValidateFor(required...); err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
+	appLog.Info("Effective configuration: " + cfg.Summary(required...))
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Initialize OpenTelemetry for lease ownership metrics
+	if cfg.OTel.Enabled {
+		_, err := telemetry.Init(ctx, &telemetry.Config{
+			Enabled:         true,
+			ServiceName:     "queue-release-worker",
+			CollectorAddr:   cfg.OTel.CollectorAddr,
+			SampleRatio:     cfg.OTel.SampleRatio,
+			DynamicSampling: cfg.OTel.DynamicSampling,
+			SlowThreshold:   cfg.OTel.SlowThreshold,
+			SamplingRoutes:  cfg.OTel.SamplingRoutes,
+			Environment:     cfg.App.Environment,
+		})
+		if err != nil {
+			appLog.Warn(fmt.Sprintf("Failed to initialize telemetry (continuing without it): %v", err))
+		} else {
+			defer telemetry.Shutdown(ctx)
+			appLog.Info("OpenTelemetry initialized")
+		}
+	}
+	if err := metrics.Init(); err != nil {
+		appLog.Warn(fmt.Sprintf("Failed to initialize metrics: %v", err))
+	}
+
 	// Initialize Redis connection
 	redisCfg := &pkgredis.Config{
 		Host:          cfg.Redis.Host,
@@ -57,8 +94,13 @@ func main() {
 	defer redis.Close()
 	appLog.Info("Redis connected")
 
-	// Create queue repository
-	queueRepo := repository.NewRedisQueueRepository(redis)
+	// Create queue repository on the configured backend
+	queueBackend, err := repository.NewQueueBackendFromConfig(redis, cfg.Booking.Queue.Backend, cfg.Booking.Queue.MigrateFrom)
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid queue backend: %v", err))
+	}
+	queueRepo := repository.NewRedisQueueRepositoryWithBackend(redis, queueBackend)
+	appLog.Info(fmt.Sprintf("Queue backend: %s", queueBackend.Name()))
 
 	// Load queue Lua scripts
 	if err := queueRepo.LoadScripts(ctx); err != nil {
@@ -66,20 +108,43 @@ func main() {
 	}
 
 	// Get worker configuration from environment or use defaults
-	defaultMaxConcurrent := getEnvInt("QUEUE_DEFAULT_MAX_CONCURRENT", 500)
-	releaseInterval := getEnvDuration("QUEUE_RELEASE_INTERVAL", 1*time.Second)
-	defaultQueuePassTTL := getEnvDuration("QUEUE_DEFAULT_PASS_TTL", 5*time.Minute)
-	jwtSecret := getEnvString("QUEUE_JWT_SECRET", cfg.JWT.Secret)
+	env := config.NewEnv()
+	defaultMaxConcurrent := env.Int("QUEUE_DEFAULT_MAX_CONCURRENT", 500)
+	releaseInterval := env.Duration("QUEUE_RELEASE_INTERVAL", 1*time.Second)
+	defaultQueuePassTTL := env.Duration("QUEUE_DEFAULT_PASS_TTL", 5*time.Minute)
+	jwtSecret := env.String("QUEUE_JWT_SECRET", cfg.JWT.Secret)
+	useLeases := env.Bool("QUEUE_RELEASE_LEASES", true)
+	leaseTTL := env.Duration("QUEUE_RELEASE_LEASE_TTL", 5*time.Second)
+	if err := env.Err(); err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
 
 	workerCfg := &worker.QueueReleaseWorkerConfig{
 		DefaultMaxConcurrent: defaultMaxConcurrent,
 		ReleaseInterval:      releaseInterval,
 		DefaultQueuePassTTL:  defaultQueuePassTTL,
 		JWTSecret:            jwtSecret,
+		// Admitted users and queue depth for the admin queue analytics
+		Analytics: service.NewQueueAnalyticsService(repository.NewRedisQueueAnalyticsRepository(redis), nil),
+	}
+	// Per-event leases let several worker replicas run without double-releasing users
+	if useLeases {
+		workerCfg.Leases = repository.NewRedisQueueReleaseLeaseRepository(redis)
+		workerCfg.LeaseTTL = leaseTTL
+		workerCfg.ReplicaName, _ = os.Hostname()
+	}
+	// Stateless passes are accepted without a Redis lookup until they expire
+	if cfg.Booking.QueuePass.Stateless {
+		workerCfg.MaxQueuePassTTL = cfg.Booking.QueuePass.StatelessTTL
+	}
+	// Tell released users on their phones that it is their turn
+	pushWorker := newPushNotifier(ctx, cfg, appLog)
+	if pushWorker != nil {
+		workerCfg.PushNotifier = pushWorker
 	}
 
-	appLog.Info(fmt.Sprintf("Worker configuration: DefaultMaxConcurrent=%d, ReleaseInterval=%v, DefaultQueuePassTTL=%v",
-		workerCfg.DefaultMaxConcurrent, workerCfg.ReleaseInterval, workerCfg.DefaultQueuePassTTL))
+	appLog.Info(fmt.Sprintf("Worker configuration: DefaultMaxConcurrent=%d, ReleaseInterval=%v, DefaultQueuePassTTL=%v, MaxQueuePassTTL=%v, Leases=%v, LeaseTTL=%v",
+		workerCfg.DefaultMaxConcurrent, workerCfg.ReleaseInterval, workerCfg.DefaultQueuePassTTL, workerCfg.MaxQueuePassTTL, useLeases, leaseTTL))
 
 	// Create and start queue release worker (pass redis client for Pub/Sub publishing)
 	queueWorker := worker.NewQueueReleaseWorker(workerCfg, queueRepo, redis, appLog)
@@ -101,6 +166,9 @@ func main() {
 
 	// Give worker time to finish
 	time.Sleep(2 * time.Second)
+	if pushWorker != nil {
+		pushWorker.Wait()
+	}
 	appLog.Info("Queue release worker stopped")
 }
 
@@ -116,38 +184,46 @@ func reportMetrics(ctx context.Context, w *worker.QueueReleaseWorker, log *logge
 		case <-ticker.C:
 			totalReleased, lastReleaseTime, lastReleaseCount := w.GetMetrics()
 			if totalReleased > 0 {
-				log.Info(fmt.Sprintf("Metrics: Total released=%d, Last release=%d users at %v",
-					totalReleased, lastReleaseCount, lastReleaseTime.Format(time.RFC3339)))
+				log.Info(fmt.Sprintf("Metrics: Total released=%d, Last release=%d users at %v, Owned events=%d",
+					totalReleased, lastReleaseCount, lastReleaseTime.Format(time.RFC3339), len(w.OwnedEvents())))
 			}
 		}
 	}
 }
 
-// getEnvString gets a string environment variable with a default
-func getEnvString(key, defaultVal string) string {
-	if val := os.Getenv(key); val != "" {
-		return val
+// newPushNotifier starts the push worker when FCM or APNs credentials are configured,
+// returning nil when push is disabled
+func newPushNotifier(ctx context.Context, cfg *config.Config, appLog *logger.Logger) *worker.PushWorker {
+	env := config.NewEnv()
+	providers, err := push.NewProviders(&push.ProvidersConfig{
+		FCMCredentialsFile: env.String("PUSH_FCM_CREDENTIALS_FILE", ""),
+		APNsKeyFile:        env.String("PUSH_APNS_KEY_FILE", ""),
+		APNsKeyID:          env.String("PUSH_APNS_KEY_ID", ""),
+		APNsTeamID:         env.String("PUSH_APNS_TEAM_ID", ""),
+		APNsTopic:          env.String("PUSH_APNS_TOPIC", ""),
+		APNsProduction:     env.Bool("PUSH_APNS_PRODUCTION", cfg.IsProduction()),
+	})
+	if err == nil {
+		err = env.Err()
 	}
-	return defaultVal
-}
-
-// getEnvInt gets an integer environment variable with a default
-func getEnvInt(key string, defaultVal int) int {
-	if val := os.Getenv(key); val != "" {
-		var i int
-		if _, err := fmt.Sscanf(val, "%d", &i); err == nil {
-			return i
-		}
+	if err != nil {
+		appLog.Warn(fmt.Sprintf("Push notifications disabled: %v", err))
+		return nil
 	}
-	return defaultVal
-}
 
-// getEnvDuration gets a duration environment variable with a default
-func getEnvDuration(key string, defaultVal time.Duration) time.Duration {
-	if val := os.Getenv(key); val != "" {
-		if d, err := time.ParseDuration(val); err == nil {
-			return d
-		}
+	dispatcher := push.NewDispatcher(nil, providers...)
+	if !dispatcher.Enabled() {
+		appLog.Info("Push notifications disabled: no FCM or APNs credentials configured")
+		return nil
 	}
-	return defaultVal
+	if cfg.Services.AuthServiceURL == "" {
+		appLog.Warn("Push notifications disabled: AUTH_SERVICE_URL is not set")
+		return nil
+	}
+
+	notifier := service.NewDevicePushNotifier(service.NewHTTPDeviceDirectory(cfg.Services.AuthServiceURL), dispatcher, service.NewZapLoggerAdapter(appLog))
+	pushWorker := worker.NewPushWorker(notifier, worker.DefaultPushWorkerConfig(), appLog)
+	pushWorker.Start(ctx)
+	appLog.Info(fmt.Sprintf("Push notifications enabled for %d provider(s)", len(providers)))
+	return pushWorker
 }
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/saga"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/worker"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/config"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+)
+
+func main() {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Initialize logger with OTLP export support
+	logCfg := &logger.Config{
+		Level:        cfg.Log.Level,
+		ModuleLevels: cfg.Log.ModuleLevels,
+		ServiceName:  "refund-worker",
+		Development:  cfg.IsDevelopment(),
+		OTLPEnabled:  cfg.OTel.Enabled && cfg.OTel.LogExportEnabled,
+		OTLPEndpoint: cfg.OTel.CollectorAddr,
+		OTLPInsecure: true,
+	}
+	if err := logger.Init(logCfg); err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer logger.Sync()
+
+	appLog := logger.Get()
+	appLog.Info("Starting Refund Batch Worker...")
+
+	required := []config.Requirement{config.RequireBookingDatabase, config.RequireKafka}
+	if err := cfg.ValidateFor(required...); err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
+	appLog.Info("Effective configuration: " + cfg.Summary(required...))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Initialize database connection (refund batches and bookings are in booking_db)
+	dbCfg := &database.PostgresConfig{
+		Host:          cfg.BookingDatabase.Host,
+		Port:          cfg.BookingDatabase.Port,
+		User:          cfg.BookingDatabase.User,
+		Password:      cfg.BookingDatabase.Password,
+		Database:      cfg.BookingDatabase.DBName,
+		SSLMode:       cfg.BookingDatabase.SSLMode,
+		MaxConns:      int32(cfg.BookingDatabase.MaxOpenConns),
+		MinConns:      int32(cfg.BookingDatabase.MaxIdleConns),
+		MaxRetries:    3,
+		RetryInterval: 2 * time.Second,
+	}
+	db, err := database.NewPostgres(ctx, dbCfg)
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("Failed to connect to database: %v", err))
+	}
+	defer db.Close()
+	appLog.Info("Database connected")
+
+	workerCfg := worker.DefaultRefundBatchWorkerConfig()
+
+	// Initialize Kafka consumer for refund results from payment-service
+	consumerCfg := &kafka.ConsumerConfig{
+		Brokers:        cfg.Kafka.Brokers,
+		GroupID:        "refund-worker",
+		Topics:         []string{workerCfg.PaymentTopic},
+		ClientID:       "refund-worker",
+		MaxRetries:     3,
+		RetryInterval:  2 * time.Second,
+		SessionTimeout: 30 * time.Second,
+	}
+	consumer, err := kafka.NewConsumer(ctx, consumerCfg)
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("Failed to create Kafka consumer: %v", err))
+	}
+	defer consumer.Close()
+	appLog.Info("Kafka consumer connected")
+
+	// Initialize Kafka producer for refund-payment commands
+	producer, err := saga.NewKafkaSagaProducer(ctx, &saga.KafkaSagaProducerConfig{
+		Brokers:       cfg.Kafka.Brokers,
+		ClientID:      "refund-worker-producer",
+		MaxRetries:    3,
+		RetryInterval: time.Second,
+		Logger:        &saga.ZapLogger{},
+	})
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("Failed to create Kafka producer: %v", err))
+	}
+	defer producer.Close()
+	appLog.Info("Kafka producer connected")
+
+	batchRepo := repository.NewPostgresRefundBatchRepository(db.Pool())
+	refundService := service.NewRefundBatchService(batchRepo, nil, producer)
+
+	// Create and start refund batch worker
+	refundWorker := worker.NewRefundBatchWorker(workerCfg, consumer, refundService, appLog)
+	go refundWorker.Start(ctx)
+	appLog.Info("Refund batch worker started")
+
+	// Wait for shutdown signal
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	appLog.Info("Shutting down refund batch worker...")
+	cancel()
+
+	// Give in-flight refund commands time to finish
+	time.Sleep(2 * time.Second)
+	appLog.Info("Refund batch worker stopped")
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/worker"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/config"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+)
+
+func main() {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Initialize logger with OTLP export support
+	logCfg := &logger.Config{
+		Level:        cfg.Log.Level,
+		ModuleLevels: cfg.Log.ModuleLevels,
+		ServiceName:  "report-worker",
+		Development:  cfg.IsDevelopment(),
+		OTLPEnabled:  cfg.OTel.Enabled && cfg.OTel.LogExportEnabled,
+		OTLPEndpoint: cfg.OTel.CollectorAddr,
+		OTLPInsecure: true,
+	}
+	if err := logger.Init(logCfg); err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer logger.Sync()
+
+	appLog := logger.Get()
+	appLog.Info("Starting Organizer Report Worker...")
+
+	required := []config.Requirement{config.RequireBookingDatabase, config.RequireRedis, config.RequireKafka}
+	if err := cfg.ValidateFor(required...); err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
+	appLog.Info("Effective configuration: " + cfg.Summary(required...))
+
+	env := config.NewEnv()
+	unsubscribeURL := env.String("REPORT_UNSUBSCRIBE_URL", "http://localhost:8080/api/v1/reports/unsubscribe")
+	pollInterval := env.Duration("REPORT_POLL_INTERVAL", time.Minute)
+	snapshotInterval := env.Duration("SALES_SNAPSHOT_INTERVAL", time.Hour)
+	if err := env.Err(); err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Initialize database connection (schedules, bookings and refunds are in booking_db)
+	dbCfg := &database.PostgresConfig{
+		Host:          cfg.BookingDatabase.Host,
+		Port:          cfg.BookingDatabase.Port,
+		User:          cfg.BookingDatabase.User,
+		Password:      cfg.BookingDatabase.Password,
+		Database:      cfg.BookingDatabase.DBName,
+		SSLMode:       cfg.BookingDatabase.SSLMode,
+		MaxConns:      int32(cfg.BookingDatabase.MaxOpenConns),
+		MinConns:      int32(cfg.BookingDatabase.MaxIdleConns),
+		MaxRetries:    3,
+		RetryInterval: 2 * time.Second,
+	}
+	db, err := database.NewPostgres(ctx, dbCfg)
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("Failed to connect to database: %v", err))
+	}
+	defer db.Close()
+	appLog.Info("Database connected")
+
+	// Initialize Redis connection (queue stats of each reported event)
+	redisCfg := &pkgredis.Config{
+		Host:          cfg.Redis.Host,
+		Port:          cfg.Redis.Port,
+		Password:      cfg.Redis.Password,
+		DB:            cfg.Redis.DB,
+		PoolSize:      cfg.Redis.PoolSize,
+		MaxRetries:    3,
+		RetryInterval: 2 * time.Second,
+	}
+	redis, err := pkgredis.NewClient(ctx, redisCfg)
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("Failed to connect to Redis: %v", err))
+	}
+	defer redis.Close()
+	appLog.Info("Redis connected")
+
+	queueBackend, err := repository.NewQueueBackendFromConfig(redis, cfg.Booking.Queue.Backend, cfg.Booking.Queue.MigrateFrom)
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid queue backend: %v", err))
+	}
+	queueService := service.NewQueueService(repository.NewRedisQueueRepositoryWithBackend(redis, queueBackend), nil)
+
+	// Initialize Kafka producer for report emails, delivered by the notification pipeline
+	sender, err := service.NewKafkaReportSender(ctx, &service.ReportSenderConfig{
+		Brokers:     cfg.Kafka.Brokers,
+		ServiceName: "report-worker",
+		ClientID:    "report-worker-producer",
+	})
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("Failed to create Kafka producer: %v", err))
+	}
+	defer sender.Close()
+	appLog.Info("Kafka producer connected")
+
+	reportRepo := repository.NewPostgresReportRepository(db.Pool())
+	reportService := service.NewReportService(reportRepo, reportRepo, queueService, sender, &service.ReportServiceConfig{
+		UnsubscribeURL: unsubscribeURL,
+	})
+
+	// Create and start report worker
+	workerCfg := worker.DefaultReportWorkerConfig()
+	workerCfg.PollInterval = pollInterval
+	reportWorker := worker.NewReportWorker(workerCfg, reportService, appLog)
+	go reportWorker.Start(ctx)
+	appLog.Info("Report worker started")
+
+	// Hourly zone snapshots for historical charts (SALES_SNAPSHOT_INTERVAL=0 disables them)
+	if snapshotInterval > 0 {
+		// Seat counts come from seat_zones in ticket_db; snapshots keep sales without them
+		var inventory repository.ZoneInventoryRepository
+		ticketDB, err := database.NewPostgres(ctx, &database.PostgresConfig{
+			Host:          cfg.TicketDatabase.Host,
+			Port:          cfg.TicketDatabase.Port,
+			User:          cfg.TicketDatabase.User,
+			Password:      cfg.TicketDatabase.Password,
+			Database:      cfg.TicketDatabase.DBName,
+			SSLMode:       cfg.TicketDatabase.SSLMode,
+			MaxConns:      2,
+			MinConns:      1,
+			MaxRetries:    3,
+			RetryInterval: 2 * time.Second,
+		})
+		if err != nil {
+			appLog.Warn(fmt.Sprintf("Sales snapshots will have no seat counts: ticket database unavailable: %v", err))
+		} else {
+			defer ticketDB.Close()
+			inventory = repository.NewPostgresZoneInventoryRepository(ticketDB.Pool())
+		}
+
+		snapshotService := service.NewSalesSnapshotService(repository.NewPostgresSalesSnapshotRepository(db.Pool()), inventory)
+		snapshotWorker := worker.NewSalesSnapshotWorker(&worker.SalesSnapshotWorkerConfig{
+			Interval: snapshotInterval,
+		}, snapshotService, appLog)
+		go snapshotWorker.Start(ctx)
+		appLog.Info(fmt.Sprintf("Sales snapshot worker started (interval: %v)", snapshotInterval))
+	}
+
+	// Wait for shutdown signal
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	appLog.Info("Shutting down report worker...")
+	cancel()
+
+	// Give in-flight reports time to finish
+	time.Sleep(2 * time.Second)
+	appLog.Info("Report worker stopped")
+}
@@ -4,15 +4,22 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/handler"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/metrics"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/saga"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/config"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/middleware"
 	pkgsaga "github.com/prohmpiriya/booking-rush-10k-rps/pkg/saga"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
 )
@@ -24,11 +31,15 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
-	// Initialize logger
+	// Initialize logger with OTLP export support
 	logCfg := &logger.Config{
-		Level:       cfg.App.Environment,
-		ServiceName: "saga-orchestrator",
-		Development: cfg.IsDevelopment(),
+		Level:        cfg.Log.Level,
+		ModuleLevels: cfg.Log.ModuleLevels,
+		ServiceName:  "saga-orchestrator",
+		Development:  cfg.IsDevelopment(),
+		OTLPEnabled:  cfg.OTel.Enabled && cfg.OTel.LogExportEnabled,
+		OTLPEndpoint: cfg.OTel.CollectorAddr,
+		OTLPInsecure: true,
 	}
 	if err := logger.Init(logCfg); err != nil {
 		log.Fatalf("Failed to initialize logger: %v", err)
@@ -38,17 +49,26 @@ func main() {
 	appLog := logger.Get()
 	appLog.Info("Starting Saga Orchestrator Worker...")
 
+	required := []config.Requirement{config.RequireBookingDatabase, config.RequireKafka}
+	if err := cfg.ValidateFor(required...); err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
+	appLog.Info("Effective configuration: " + cfg.Summary(required...))
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	// Initialize OpenTelemetry tracing
 	if cfg.OTel.Enabled {
 		_, err := telemetry.Init(ctx, &telemetry.Config{
-			Enabled:       true,
-			ServiceName:   "saga-orchestrator",
-			CollectorAddr: cfg.OTel.CollectorAddr,
-			SampleRatio:   cfg.OTel.SampleRatio,
-			Environment:   cfg.App.Environment,
+			Enabled:         true,
+			ServiceName:     "saga-orchestrator",
+			CollectorAddr:   cfg.OTel.CollectorAddr,
+			SampleRatio:     cfg.OTel.SampleRatio,
+			DynamicSampling: cfg.OTel.DynamicSampling,
+			SlowThreshold:   cfg.OTel.SlowThreshold,
+			SamplingRoutes:  cfg.OTel.SamplingRoutes,
+			Environment:     cfg.App.Environment,
 		})
 		if err != nil {
 			appLog.Warn(fmt.Sprintf("Failed to initialize tracer (continuing without tracing): %v", err))
@@ -58,6 +78,11 @@ func main() {
 		}
 	}
 
+	// Initialize saga metrics (no-op when telemetry is disabled)
+	if err := metrics.Init(); err != nil {
+		appLog.Warn(fmt.Sprintf("Failed to initialize saga metrics: %v", err))
+	}
+
 	// Initialize PostgreSQL connection for saga store (primary source of truth)
 	dbCfg := &database.PostgresConfig{
 		Host:          cfg.BookingDatabase.Host,
@@ -82,6 +107,18 @@ func main() {
 	store := pkgsaga.NewPostgresStore(db.Pool())
 	appLog.Info("Saga store initialized (PostgreSQL)")
 
+	// Check the Kafka topics this service owns (missing topics are created when
+	// KAFKA_AUTO_CREATE_TOPICS is set, drift from the specs is only reported)
+	if err := kafka.ProvisionTopics(ctx, &kafka.TopicManagerConfig{
+		Brokers:           cfg.Kafka.Brokers,
+		ClientID:          "saga-orchestrator-admin",
+		Partitions:        int32(cfg.Kafka.TopicPartitions),
+		ReplicationFactor: int16(cfg.Kafka.TopicReplicationFactor),
+		AutoCreate:        cfg.Kafka.AutoCreateTopics,
+	}, saga.TopicSpecs()); err != nil {
+		appLog.Warn(fmt.Sprintf("Kafka topic check failed: %v", err))
+	}
+
 	// Initialize Kafka producer
 	producer, err := saga.NewKafkaSagaProducer(ctx, &saga.KafkaSagaProducerConfig{
 		Brokers:       cfg.Kafka.Brokers,
@@ -122,8 +159,70 @@ func main() {
 	}
 	appLog.Info("Post-payment saga definition registered")
 
+	// Optional definition file that retunes the compiled-in sagas (timeouts, retries, topics)
+	// or adds sagas composed of existing steps. It is re-read on SIGHUP and on
+	// POST /admin/saga/definitions/reload, so tuning doesn't need a redeploy.
+	env := config.NewEnv()
+	definitionsFile := env.String("SAGA_DEFINITIONS_FILE", "")
+	adminAddr := env.String("SAGA_ADMIN_ADDR", "")
+	if err := env.Err(); err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
+
+	var definitionLoader *saga.DefinitionLoader
+	if definitionsFile != "" {
+		registry := saga.NewStepHandlerRegistry(orchestrator.Definitions()...)
+		definitionLoader = saga.NewDefinitionLoader(definitionsFile, registry, orchestrator)
+		if _, err := definitionLoader.Reload(ctx); err != nil {
+			appLog.Fatal(fmt.Sprintf("Failed to load saga definitions: %v", err))
+		}
+		appLog.Info(fmt.Sprintf("Saga definitions loaded from %s", definitionsFile))
+
+		reload := make(chan os.Signal, 1)
+		signal.Notify(reload, syscall.SIGHUP)
+		go func() {
+			for range reload {
+				// A bad file keeps the definitions that are running
+				if _, err := definitionLoader.Reload(ctx); err != nil {
+					appLog.Error(fmt.Sprintf("Failed to reload saga definitions: %v", err))
+				}
+			}
+		}()
+	}
+
+	// Admin API for inspecting and reloading definitions (disabled unless SAGA_ADMIN_ADDR is set)
+	if adminAddr != "" {
+		definitionHandler := handler.NewSagaDefinitionHandler(orchestrator, definitionLoader)
+		router := gin.New()
+		router.Use(gin.Recovery())
+		admin := router.Group("/admin/saga")
+		admin.Use(middleware.InternalAuthMiddleware(&middleware.InternalAuthConfig{
+			Secret:        cfg.InternalAuth.Secret,
+			MaxClockSkew:  cfg.InternalAuth.MaxClockSkew,
+			AllowUnsigned: cfg.InternalAuth.AllowUnsigned,
+		}))
+		{
+			admin.GET("/definitions", definitionHandler.ListDefinitions)
+			admin.POST("/definitions/reload", definitionHandler.ReloadDefinitions)
+		}
+
+		adminSrv := &http.Server{
+			Addr:              adminAddr,
+			Handler:           router,
+			ReadHeaderTimeout: 2 * time.Second,
+		}
+		go func() {
+			appLog.Info(fmt.Sprintf("Saga admin API listening on %s", adminAddr))
+			if err := adminSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				appLog.Error(fmt.Sprintf("Saga admin API error: %v", err))
+			}
+		}()
+		defer adminSrv.Close()
+	}
+
 	// Create event handler
 	eventHandler := saga.NewOrchestratorEventHandler(orchestrator, producer, store)
+	eventHandler.SetCompensationRepository(repository.NewPostgresCompensationRepository(db.Pool()))
 
 	// Initialize Kafka consumer
 	consumer, err := saga.NewSagaConsumer(ctx, &saga.SagaConsumerConfig{
@@ -159,6 +258,7 @@ func main() {
 		Store:            store,
 		Producer:         producer,
 		Logger:           &saga.ZapLogger{},
+		Orchestrator:     orchestrator,
 		SessionTimeout:   30 * time.Second,
 		RebalanceTimeout: 60 * time.Second,
 	})
@@ -4,20 +4,27 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dualwrite"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/saga"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/sharding"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/worker"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/config"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/id"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/push"
 	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
 	pkgsaga "github.com/prohmpiriya/booking-rush-10k-rps/pkg/saga"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/tenantconfig"
 )
 
 func main() {
@@ -27,11 +34,15 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
-	// Initialize logger
+	// Initialize logger with OTLP export support
 	logCfg := &logger.Config{
-		Level:       cfg.App.Environment,
-		ServiceName: "saga-step-worker",
-		Development: cfg.IsDevelopment(),
+		Level:        cfg.Log.Level,
+		ModuleLevels: cfg.Log.ModuleLevels,
+		ServiceName:  "saga-step-worker",
+		Development:  cfg.IsDevelopment(),
+		OTLPEnabled:  cfg.OTel.Enabled && cfg.OTel.LogExportEnabled,
+		OTLPEndpoint: cfg.OTel.CollectorAddr,
+		OTLPInsecure: true,
 	}
 	if err := logger.Init(logCfg); err != nil {
 		log.Fatalf("Failed to initialize logger: %v", err)
@@ -41,6 +52,19 @@ func main() {
 	appLog := logger.Get()
 	appLog.Info("Starting Saga Step Worker...")
 
+	required := []config.Requirement{config.RequireBookingDatabase, config.RequireRedis, config.RequireKafka}
+	if err := cfg.ValidateFor(required...); err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
+	appLog.Info("Effective configuration: " + cfg.Summary(required...))
+
+	// Time-ordered primary keys for hot insert paths (ID_STRATEGY, ID_NODE_ID)
+	idGenerator, err := id.FromEnv()
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
+	id.SetDefault(idGenerator)
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -83,8 +107,26 @@ func main() {
 	appLog.Info("Redis connected")
 
 	// Initialize repositories
-	bookingRepo := repository.NewPostgresBookingRepository(db.Pool())
+	var bookingRepo repository.BookingRepository = repository.NewPostgresBookingRepository(db.Pool())
+	tenantShards, err := database.OpenShardResolver(ctx, db, dbCfg, cfg.BookingDatabase.Shards, cfg.BookingDatabase.ShardRefreshInterval)
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("Tenant shards unavailable: %v", err))
+	}
+	if tenantShards != nil {
+		defer tenantShards.Close()
+		bookingRepo = sharding.NewPostgresBookingRepository(tenantShards)
+	}
 	reservationRepo := repository.NewRedisReservationRepository(redis)
+	var journalRetention time.Duration
+	if cfg.Booking.ReservationJournal.Enabled {
+		journalRetention = cfg.Booking.ReservationJournal.Retention
+		reservationRepo.SetJournalRetention(journalRetention)
+	}
+
+	// Releases and compensations bring sold-out zones back; announce it like booking-service does
+	if cfg.Booking.AvailabilityBroadcast.Enabled {
+		reservationRepo.SetAvailabilityBroadcaster(repository.NewRedisAvailabilityBroadcaster(redis, cfg.Booking.AvailabilityBroadcast.LowStockThreshold))
+	}
 
 	// Pre-load Lua scripts into Redis
 	if err := reservationRepo.LoadScripts(ctx); err != nil {
@@ -93,6 +135,22 @@ func main() {
 		appLog.Info("Lua scripts pre-loaded into Redis")
 	}
 
+	// Mirror reservation writes to the new Redis during a migration (see booking-service)
+	var reservations repository.ReservationRepository = reservationRepo
+	if cfg.Booking.ReservationShadow.Enabled() {
+		shadow, closeShadow, err := dualwrite.Open(ctx, &cfg.Booking.ReservationShadow, reservationRepo, *redisCfg, journalRetention)
+		if err != nil {
+			appLog.Fatal(fmt.Sprintf("Reservation shadow init failed: %v", err))
+		}
+		defer closeShadow()
+		reservations = shadow
+		appLog.Info(fmt.Sprintf("Reservation shadow enabled: mode=%s", cfg.Booking.ReservationShadow.Mode))
+	}
+
+	// Stop pulling step commands while Postgres or Redis fail, instead of failing
+	// every saga and flooding the DLQ; consumption resumes once they recover
+	downstream := kafka.NewErrorRateProbe("booking-store", kafka.DefaultErrorRateProbeConfig())
+
 	// Initialize Kafka consumer for booking step commands
 	consumerCfg := &kafka.ConsumerConfig{
 		Brokers: cfg.Kafka.Brokers,
@@ -107,6 +165,11 @@ func main() {
 		MaxRetries:     3,
 		RetryInterval:  2 * time.Second,
 		SessionTimeout: 30 * time.Second,
+		Health: kafka.AllHealthy(
+			downstream,
+			kafka.HealthProbeFunc(db.Ping),
+			kafka.HealthProbeFunc(redis.Ping),
+		),
 	}
 	consumer, err := kafka.NewConsumer(ctx, consumerCfg)
 	if err != nil {
@@ -137,18 +200,27 @@ func main() {
 	dlqHandler := saga.NewDLQHandler(producer, sagaStore, &saga.ZapLogger{})
 	appLog.Info("DLQ handler initialized")
 
+	// Payment-due and confirmation pushes
+	stepWorkerCfg := &worker.SagaStepWorkerConfig{
+		WorkerCount:   5,
+		RetryAttempts: 3,
+		RetryDelay:    time.Second,
+		Downstream:    downstream,
+	}
+	stepWorkerCfg.DefaultSender, stepWorkerCfg.TenantSettings = newEmailSender(cfg, redis)
+	pushWorker := newPushNotifier(ctx, cfg, appLog)
+	if pushWorker != nil {
+		stepWorkerCfg.PushNotifier = pushWorker
+	}
+
 	// Create step worker
 	stepWorker := worker.NewSagaStepWorker(
 		consumer,
 		producer,
 		bookingRepo,
-		reservationRepo,
+		reservations,
 		dlqHandler, // DLQ handler for non-critical step failures
-		&worker.SagaStepWorkerConfig{
-			WorkerCount:   5,
-			RetryAttempts: 3,
-			RetryDelay:    time.Second,
-		},
+		stepWorkerCfg,
 	)
 
 	// Start worker
@@ -169,5 +241,63 @@ func main() {
 	cancel()
 
 	time.Sleep(2 * time.Second)
+	if pushWorker != nil {
+		pushWorker.Wait()
+	}
 	appLog.Info("Worker exited gracefully")
 }
+
+// newEmailSender returns the default email sender and, when auth-service is reachable,
+// the tenant settings that carry each tenant's own sender and branding
+func newEmailSender(cfg *config.Config, redis *pkgredis.Client) (tenantconfig.EmailIdentity, service.TenantSettingsSource) {
+	env := config.NewEnv()
+	sender := tenantconfig.EmailIdentity{
+		FromAddress: env.String("EMAIL_FROM_ADDRESS", service.DefaultEmailFromAddress),
+		FromName:    env.String("EMAIL_FROM_NAME", service.DefaultEmailFromName),
+	}
+	if cfg.Services.AuthServiceURL == "" {
+		return sender, nil
+	}
+	return sender, tenantconfig.NewClient(&tenantconfig.ClientConfig{
+		BaseURL:    cfg.Services.AuthServiceURL,
+		Redis:      redis,
+		HTTPClient: &http.Client{Timeout: time.Second},
+	})
+}
+
+// newPushNotifier starts the push worker when FCM or APNs credentials are configured,
+// returning nil when push is disabled
+func newPushNotifier(ctx context.Context, cfg *config.Config, appLog *logger.Logger) *worker.PushWorker {
+	env := config.NewEnv()
+	providers, err := push.NewProviders(&push.ProvidersConfig{
+		FCMCredentialsFile: env.String("PUSH_FCM_CREDENTIALS_FILE", ""),
+		APNsKeyFile:        env.String("PUSH_APNS_KEY_FILE", ""),
+		APNsKeyID:          env.String("PUSH_APNS_KEY_ID", ""),
+		APNsTeamID:         env.String("PUSH_APNS_TEAM_ID", ""),
+		APNsTopic:          env.String("PUSH_APNS_TOPIC", ""),
+		APNsProduction:     env.Bool("PUSH_APNS_PRODUCTION", cfg.IsProduction()),
+	})
+	if err == nil {
+		err = env.Err()
+	}
+	if err != nil {
+		appLog.Warn(fmt.Sprintf("Push notifications disabled: %v", err))
+		return nil
+	}
+
+	dispatcher := push.NewDispatcher(nil, providers...)
+	if !dispatcher.Enabled() {
+		appLog.Info("Push notifications disabled: no FCM or APNs credentials configured")
+		return nil
+	}
+	if cfg.Services.AuthServiceURL == "" {
+		appLog.Warn("Push notifications disabled: AUTH_SERVICE_URL is not set")
+		return nil
+	}
+
+	notifier := service.NewDevicePushNotifier(service.NewHTTPDeviceDirectory(cfg.Services.AuthServiceURL), dispatcher, service.NewZapLoggerAdapter(appLog))
+	pushWorker := worker.NewPushWorker(notifier, worker.DefaultPushWorkerConfig(), appLog)
+	pushWorker.Start(ctx)
+	appLog.Info(fmt.Sprintf("Push notifications enabled for %d provider(s)", len(providers)))
+	return pushWorker
+}
@@ -9,10 +9,14 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dualwrite"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/sharding"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/worker"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/config"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/events"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
 	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
@@ -25,11 +29,15 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
-	// Initialize logger
+	// Initialize logger with OTLP export support
 	logCfg := &logger.Config{
-		Level:       cfg.App.Environment,
-		ServiceName: "seat-release-worker",
-		Development: cfg.IsDevelopment(),
+		Level:        cfg.Log.Level,
+		ModuleLevels: cfg.Log.ModuleLevels,
+		ServiceName:  "seat-release-worker",
+		Development:  cfg.IsDevelopment(),
+		OTLPEnabled:  cfg.OTel.Enabled && cfg.OTel.LogExportEnabled,
+		OTLPEndpoint: cfg.OTel.CollectorAddr,
+		OTLPInsecure: true,
 	}
 	if err := logger.Init(logCfg); err != nil {
 		log.Fatalf("Failed to initialize logger: %v", err)
@@ -39,6 +47,12 @@ func main() {
 	appLog := logger.Get()
 	appLog.Info("Starting Seat Release Worker...")
 
+	required := []config.Requirement{config.RequireBookingDatabase, config.RequireRedis, config.RequireKafka}
+	if err := cfg.ValidateFor(required...); err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
+	appLog.Info("Effective configuration: " + cfg.Summary(required...))
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -84,7 +98,7 @@ func main() {
 	consumerCfg := &kafka.ConsumerConfig{
 		Brokers:        cfg.Kafka.Brokers,
 		GroupID:        "seat-release-worker",
-		Topics:         []string{"payment.seat-release"},
+		Topics:         []string{events.TopicSeatRelease},
 		ClientID:       "seat-release-worker",
 		MaxRetries:     3,
 		RetryInterval:  2 * time.Second,
@@ -97,9 +111,43 @@ func main() {
 	defer consumer.Close()
 	appLog.Info("Kafka consumer connected")
 
+	// Initialize standby offer notifier
+	var standbyNotifier service.StandbyNotifier
+	standbyNotifier, err = service.NewKafkaStandbyNotifier(ctx, &service.StandbyNotifierConfig{
+		Brokers:     cfg.Kafka.Brokers,
+		Topic:       service.DefaultStandbyOfferTopic,
+		ServiceName: "seat-release-worker",
+		ClientID:    "seat-release-worker-standby-notifier",
+		Logger:      service.NewZapLoggerAdapter(appLog),
+	})
+	if err != nil {
+		appLog.Warn(fmt.Sprintf("Failed to create standby notifier, offers will not be notified: %v", err))
+		standbyNotifier = service.NewNoOpStandbyNotifier()
+	}
+	defer standbyNotifier.Close()
+
 	// Initialize repositories
-	bookingRepo := repository.NewPostgresBookingRepository(db.Pool())
+	var bookingRepo repository.BookingRepository = repository.NewPostgresBookingRepository(db.Pool())
+	tenantShards, err := database.OpenShardResolver(ctx, db, dbCfg, cfg.BookingDatabase.Shards, cfg.BookingDatabase.ShardRefreshInterval)
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("Tenant shards unavailable: %v", err))
+	}
+	if tenantShards != nil {
+		defer tenantShards.Close()
+		bookingRepo = sharding.NewPostgresBookingRepository(tenantShards)
+	}
 	reservationRepo := repository.NewRedisReservationRepository(redis)
+	var journalRetention time.Duration
+	if cfg.Booking.ReservationJournal.Enabled {
+		journalRetention = cfg.Booking.ReservationJournal.Retention
+		reservationRepo.SetJournalRetention(journalRetention)
+	}
+	standbyRepo := repository.NewRedisStandbyRepository(redis)
+
+	// Expired reservations bring sold-out zones back; announce it like booking-service does
+	if cfg.Booking.AvailabilityBroadcast.Enabled {
+		reservationRepo.SetAvailabilityBroadcaster(repository.NewRedisAvailabilityBroadcaster(redis, cfg.Booking.AvailabilityBroadcast.LowStockThreshold))
+	}
 
 	// Pre-load Lua scripts into Redis
 	if err := reservationRepo.LoadScripts(ctx); err != nil {
@@ -107,16 +155,36 @@ func main() {
 	} else {
 		appLog.Info("Lua scripts pre-loaded into Redis")
 	}
+	if err := standbyRepo.LoadScripts(ctx); err != nil {
+		appLog.Warn(fmt.Sprintf("Failed to pre-load standby Lua scripts: %v", err))
+	}
+
+	// Mirror reservation writes to the new Redis during a migration (see booking-service)
+	var reservations repository.ReservationRepository = reservationRepo
+	if cfg.Booking.ReservationShadow.Enabled() {
+		shadow, closeShadow, err := dualwrite.Open(ctx, &cfg.Booking.ReservationShadow, reservationRepo, *redisCfg, journalRetention)
+		if err != nil {
+			appLog.Fatal(fmt.Sprintf("Reservation shadow init failed: %v", err))
+		}
+		defer closeShadow()
+		reservations = shadow
+		appLog.Info(fmt.Sprintf("Reservation shadow enabled: mode=%s", cfg.Booking.ReservationShadow.Mode))
+	}
 
 	// Create worker
 	seatReleaseWorker := worker.NewSeatReleaseWorker(
 		consumer,
 		bookingRepo,
-		reservationRepo,
+		reservations,
+		standbyRepo,
+		standbyNotifier,
 		&worker.SeatReleaseWorkerConfig{
-			WorkerCount:   5,
-			RetryAttempts: 3,
-			RetryDelay:    time.Second,
+			WorkerCount:          5,
+			RetryAttempts:        3,
+			RetryDelay:           time.Second,
+			BatchSize:            200,
+			StandbyOfferWindow:   2 * time.Minute,
+			StandbySweepInterval: 5 * time.Second,
 		},
 	)
 
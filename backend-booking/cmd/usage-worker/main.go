@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/worker"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/config"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/events"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/metering"
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+)
+
+func main() {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Initialize logger with OTLP export support
+	logCfg := &logger.Config{
+		Level:        cfg.Log.Level,
+		ModuleLevels: cfg.Log.ModuleLevels,
+		ServiceName:  "usage-worker",
+		Development:  cfg.IsDevelopment(),
+		OTLPEnabled:  cfg.OTel.Enabled && cfg.OTel.LogExportEnabled,
+		OTLPEndpoint: cfg.OTel.CollectorAddr,
+		OTLPInsecure: true,
+	}
+	if err := logger.Init(logCfg); err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer logger.Sync()
+
+	appLog := logger.Get()
+	appLog.Info("Starting Usage Metering Worker...")
+
+	required := []config.Requirement{config.RequireRedis, config.RequireKafka}
+	if err := cfg.ValidateFor(required...); err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
+	appLog.Info("Effective configuration: " + cfg.Summary(required...))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Initialize Redis connection (usage aggregates are shared with the API gateway)
+	redisCfg := &pkgredis.Config{
+		Host:          cfg.Redis.Host,
+		Port:          cfg.Redis.Port,
+		Password:      cfg.Redis.Password,
+		DB:            cfg.Redis.DB,
+		PoolSize:      cfg.Redis.PoolSize,
+		MaxRetries:    3,
+		RetryInterval: 2 * time.Second,
+	}
+	redis, err := pkgredis.NewClient(ctx, redisCfg)
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("Failed to connect to Redis: %v", err))
+	}
+	defer redis.Close()
+	appLog.Info("Redis connected")
+
+	// Initialize Kafka consumer
+	consumerCfg := &kafka.ConsumerConfig{
+		Brokers:        cfg.Kafka.Brokers,
+		GroupID:        "usage-metering-worker",
+		Topics:         []string{events.TopicBookingEvents},
+		ClientID:       "usage-worker",
+		MaxRetries:     3,
+		RetryInterval:  2 * time.Second,
+		SessionTimeout: 30 * time.Second,
+	}
+	consumer, err := kafka.NewConsumer(ctx, consumerCfg)
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("Failed to create Kafka consumer: %v", err))
+	}
+	defer consumer.Close()
+	appLog.Info("Kafka consumer connected")
+
+	// Create and start usage worker
+	store := metering.NewRedisStore(redis, metering.DefaultRetention)
+	usageWorker := worker.NewUsageWorker(&worker.UsageWorkerConfig{}, consumer, store, appLog)
+
+	go usageWorker.Start(ctx)
+	appLog.Info("Usage worker started")
+
+	// Wait for shutdown signal
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	appLog.Info("Shutting down usage worker...")
+	cancel()
+
+	// Give worker time to finish
+	time.Sleep(2 * time.Second)
+	appLog.Info("Usage worker stopped")
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/worker"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/config"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+)
+
+func main() {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Initialize logger with OTLP export support
+	logCfg := &logger.Config{
+		Level:        cfg.Log.Level,
+		ModuleLevels: cfg.Log.ModuleLevels,
+		ServiceName:  "webhook-worker",
+		Development:  cfg.IsDevelopment(),
+		OTLPEnabled:  cfg.OTel.Enabled && cfg.OTel.LogExportEnabled,
+		OTLPEndpoint: cfg.OTel.CollectorAddr,
+		OTLPInsecure: true,
+	}
+	if err := logger.Init(logCfg); err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer logger.Sync()
+
+	appLog := logger.Get()
+	appLog.Info("Starting Webhook Delivery Worker...")
+
+	required := []config.Requirement{config.RequireBookingDatabase, config.RequireKafka}
+	if err := cfg.ValidateFor(required...); err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
+	appLog.Info("Effective configuration: " + cfg.Summary(required...))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Initialize database connection (webhook subscriptions and deliveries are in booking_db)
+	dbCfg := &database.PostgresConfig{
+		Host:          cfg.BookingDatabase.Host,
+		Port:          cfg.BookingDatabase.Port,
+		User:          cfg.BookingDatabase.User,
+		Password:      cfg.BookingDatabase.Password,
+		Database:      cfg.BookingDatabase.DBName,
+		SSLMode:       cfg.BookingDatabase.SSLMode,
+		MaxConns:      int32(cfg.BookingDatabase.MaxOpenConns),
+		MinConns:      int32(cfg.BookingDatabase.MaxIdleConns),
+		MaxRetries:    3,
+		RetryInterval: 2 * time.Second,
+	}
+	db, err := database.NewPostgres(ctx, dbCfg)
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("Failed to connect to database: %v", err))
+	}
+	defer db.Close()
+	appLog.Info("Database connected")
+
+	workerCfg := worker.DefaultWebhookWorkerConfig()
+
+	// Initialize Kafka consumer for booking and payment lifecycle events
+	consumerCfg := &kafka.ConsumerConfig{
+		Brokers:        cfg.Kafka.Brokers,
+		GroupID:        "webhook-worker",
+		Topics:         []string{workerCfg.BookingTopic, workerCfg.PaymentTopic},
+		ClientID:       "webhook-worker",
+		MaxRetries:     3,
+		RetryInterval:  2 * time.Second,
+		SessionTimeout: 30 * time.Second,
+	}
+	consumer, err := kafka.NewConsumer(ctx, consumerCfg)
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("Failed to create Kafka consumer: %v", err))
+	}
+	defer consumer.Close()
+	appLog.Info("Kafka consumer connected")
+
+	subscriptionRepo := repository.NewPostgresWebhookSubscriptionRepository(db.Pool())
+	deliveryRepo := repository.NewPostgresWebhookDeliveryRepository(db.Pool())
+	webhookService := service.NewWebhookService(subscriptionRepo, deliveryRepo, &service.WebhookServiceConfig{
+		MaxAttempts: service.DefaultWebhookMaxAttempts,
+	})
+
+	// Create and start webhook worker
+	webhookWorker := worker.NewWebhookWorker(workerCfg, consumer, webhookService, subscriptionRepo, deliveryRepo, appLog)
+	go webhookWorker.Start(ctx)
+	appLog.Info("Webhook worker started")
+
+	// Wait for shutdown signal
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	appLog.Info("Shutting down webhook worker...")
+	cancel()
+
+	// Give in-flight deliveries time to finish
+	time.Sleep(2 * time.Second)
+	appLog.Info("Webhook worker stopped")
+}
@@ -0,0 +1,182 @@
+// Package chaos injects failures into booking-service dependencies so load tests can
+// exercise compensation, retries and idempotency. It is wired only when CHAOS_ENABLED
+// is set and must never run in production.
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/metrics"
+)
+
+// ErrInjected is wrapped by every injected failure
+var ErrInjected = errors.New("chaos: injected failure")
+
+// Fault kinds, used in stats and metrics
+const (
+	KindLuaBeforeWrite = "lua_before_write"
+	KindLuaAfterWrite  = "lua_after_write"
+	KindRedisLatency   = "redis_latency"
+	KindKafkaFailure   = "kafka_failure"
+)
+
+// Config holds fault injection rates. Rates are probabilities in [0, 1].
+type Config struct {
+	// LuaFailureRate fails reservation Lua scripts. Half of the failures happen before the
+	// script runs; the other half after it ran, so the write is applied but the reply is lost.
+	LuaFailureRate float64 `json:"lua_failure_rate"`
+	// RedisLatencyRate delays reservation Redis calls by RedisLatency
+	RedisLatencyRate float64       `json:"redis_latency_rate"`
+	RedisLatency     time.Duration `json:"redis_latency_ns"`
+	// KafkaFailureRate fails Kafka publishes before they are sent
+	KafkaFailureRate float64 `json:"kafka_failure_rate"`
+	// Seed makes runs reproducible (0 = time based)
+	Seed int64 `json:"seed"`
+}
+
+// Validate validates the fault injection rates
+func (c Config) Validate() error {
+	rates := map[string]float64{
+		"lua failure rate":   c.LuaFailureRate,
+		"redis latency rate": c.RedisLatencyRate,
+		"kafka failure rate": c.KafkaFailureRate,
+	}
+	for name, rate := range rates {
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("chaos %s must be between 0 and 1, got %v", name, rate)
+		}
+	}
+	if c.RedisLatencyRate > 0 && c.RedisLatency <= 0 {
+		return errors.New("chaos redis latency must be positive when its rate is set")
+	}
+	return nil
+}
+
+// Stats counts injected faults since startup
+type Stats struct {
+	Config          Config `json:"config"`
+	LuaBeforeWrite  int64  `json:"lua_before_write"`
+	LuaAfterWrite   int64  `json:"lua_after_write"`
+	RedisDelays     int64  `json:"redis_delays"`
+	KafkaFailures   int64  `json:"kafka_failures"`
+	TotalInjections int64  `json:"total_injections"`
+}
+
+// luaFault is where an injected Lua failure happens relative to the script
+type luaFault int
+
+const (
+	luaFaultNone luaFault = iota
+	luaFaultBeforeWrite
+	luaFaultAfterWrite
+)
+
+// Injector decides which calls fail and records what it injected
+type Injector struct {
+	config Config
+
+	mu  sync.Mutex
+	rng *rand.Rand
+
+	luaBeforeWrite atomic.Int64
+	luaAfterWrite  atomic.Int64
+	redisDelays    atomic.Int64
+	kafkaFailures  atomic.Int64
+}
+
+// NewInjector creates a new fault injector
+func NewInjector(config Config) (*Injector, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &Injector{
+		config: config,
+		rng:    rand.New(rand.NewSource(seed)),
+	}, nil
+}
+
+// roll returns true with the given probability
+func (i *Injector) roll(rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.rng.Float64() < rate
+}
+
+// redisDelay sleeps for the configured latency on a share of calls.
+// Returns the context error if the caller gives up while waiting.
+func (i *Injector) redisDelay(ctx context.Context, op string) error {
+	if !i.roll(i.config.RedisLatencyRate) {
+		return nil
+	}
+	i.redisDelays.Add(1)
+	metrics.RecordChaosInjection(ctx, KindRedisLatency, op)
+
+	timer := time.NewTimer(i.config.RedisLatency)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// luaFault picks whether a Lua script call fails, and when
+func (i *Injector) luaFault(ctx context.Context, script string) luaFault {
+	if !i.roll(i.config.LuaFailureRate) {
+		return luaFaultNone
+	}
+	if i.roll(0.5) {
+		i.luaBeforeWrite.Add(1)
+		metrics.RecordChaosInjection(ctx, KindLuaBeforeWrite, script)
+		return luaFaultBeforeWrite
+	}
+	i.luaAfterWrite.Add(1)
+	metrics.RecordChaosInjection(ctx, KindLuaAfterWrite, script)
+	return luaFaultAfterWrite
+}
+
+// kafkaFailure returns an injected error for a share of publishes; target names the
+// topic or message type being published
+func (i *Injector) kafkaFailure(ctx context.Context, target string) error {
+	if !i.roll(i.config.KafkaFailureRate) {
+		return nil
+	}
+	i.kafkaFailures.Add(1)
+	metrics.RecordChaosInjection(ctx, KindKafkaFailure, target)
+	return fmt.Errorf("%w: kafka publish %s", ErrInjected, target)
+}
+
+// Stats returns injected fault counts
+func (i *Injector) Stats() *Stats {
+	s := &Stats{
+		Config:         i.config,
+		LuaBeforeWrite: i.luaBeforeWrite.Load(),
+		LuaAfterWrite:  i.luaAfterWrite.Load(),
+		RedisDelays:    i.redisDelays.Load(),
+		KafkaFailures:  i.kafkaFailures.Load(),
+	}
+	s.TotalInjections = s.LuaBeforeWrite + s.LuaAfterWrite + s.RedisDelays + s.KafkaFailures
+	return s
+}
+
+// luaError is the error returned for an injected Lua failure
+func luaError(script string, fault luaFault) error {
+	if fault == luaFaultAfterWrite {
+		return fmt.Errorf("%w: lua script %s reply lost after write", ErrInjected, script)
+	}
+	return fmt.Errorf("%w: lua script %s", ErrInjected, script)
+}
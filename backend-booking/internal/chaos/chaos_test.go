@@ -0,0 +1,144 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+)
+
+// stubReservationRepository counts calls that reach the real repository
+type stubReservationRepository struct {
+	reserveCalls int
+}
+
+func (s *stubReservationRepository) ReserveSeats(ctx context.Context, params repository.ReserveParams) (*repository.ReserveResult, error) {
+	s.reserveCalls++
+	return &repository.ReserveResult{Success: true, BookingID: "b-1"}, nil
+}
+
+func (s *stubReservationRepository) ConfirmBooking(ctx context.Context, bookingID, userID, paymentID string) (*repository.ConfirmResult, error) {
+	return &repository.ConfirmResult{Success: true}, nil
+}
+
+func (s *stubReservationRepository) ExtendHold(ctx context.Context, params repository.ExtendHoldParams) (*repository.ExtendHoldResult, error) {
+	return &repository.ExtendHoldResult{Success: true, Extended: true, ExpiresAt: params.Until}, nil
+}
+
+func (s *stubReservationRepository) ReleaseSeats(ctx context.Context, bookingID, userID string) (*repository.ReleaseResult, error) {
+	return &repository.ReleaseResult{Success: true}, nil
+}
+
+func (s *stubReservationRepository) ModifyReservation(ctx context.Context, params repository.ModifyParams) (*repository.ModifyResult, error) {
+	return &repository.ModifyResult{Success: true}, nil
+}
+
+func (s *stubReservationRepository) GetZoneAvailability(ctx context.Context, zoneID string) (int64, error) {
+	return 100, nil
+}
+
+func (s *stubReservationRepository) SetZoneAvailability(ctx context.Context, zoneID string, seats int64) error {
+	return nil
+}
+
+func newTestInjector(t *testing.T, config Config) *Injector {
+	t.Helper()
+	if config.Seed == 0 {
+		config.Seed = 42
+	}
+	injector, err := NewInjector(config)
+	if err != nil {
+		t.Fatalf("NewInjector() error = %v", err)
+	}
+	return injector
+}
+
+func TestReservationRepository_NoFaultsAtZeroRate(t *testing.T) {
+	stub := &stubReservationRepository{}
+	repo := NewReservationRepository(stub, newTestInjector(t, Config{}))
+
+	for i := 0; i < 100; i++ {
+		if _, err := repo.ReserveSeats(context.Background(), repository.ReserveParams{ZoneID: "zone-1", Quantity: 1}); err != nil {
+			t.Fatalf("ReserveSeats() error = %v", err)
+		}
+	}
+	if stub.reserveCalls != 100 {
+		t.Errorf("reserveCalls = %d, want 100", stub.reserveCalls)
+	}
+}
+
+func TestReservationRepository_LuaFaults(t *testing.T) {
+	stub := &stubReservationRepository{}
+	injector := newTestInjector(t, Config{LuaFailureRate: 1})
+	repo := NewReservationRepository(stub, injector)
+
+	const calls = 200
+	for i := 0; i < calls; i++ {
+		_, err := repo.ReserveSeats(context.Background(), repository.ReserveParams{ZoneID: "zone-1", Quantity: 1})
+		if !errors.Is(err, ErrInjected) {
+			t.Fatalf("ReserveSeats() error = %v, want ErrInjected", err)
+		}
+	}
+
+	stats := injector.Stats()
+	if stats.LuaBeforeWrite+stats.LuaAfterWrite != calls {
+		t.Errorf("Lua faults = %d, want %d", stats.LuaBeforeWrite+stats.LuaAfterWrite, calls)
+	}
+	if stats.LuaBeforeWrite == 0 || stats.LuaAfterWrite == 0 {
+		t.Errorf("Expected both fault kinds, got %+v", stats)
+	}
+	// Only after-write faults let the script run
+	if int64(stub.reserveCalls) != stats.LuaAfterWrite {
+		t.Errorf("reserveCalls = %d, want %d (after-write faults)", stub.reserveCalls, stats.LuaAfterWrite)
+	}
+}
+
+func TestReservationRepository_RedisLatencyHonoursContext(t *testing.T) {
+	injector := newTestInjector(t, Config{RedisLatencyRate: 1, RedisLatency: time.Minute})
+	repo := NewReservationRepository(&stubReservationRepository{}, injector)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := repo.GetZoneAvailability(ctx, "zone-1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetZoneAvailability() error = %v, want context.DeadlineExceeded", err)
+	}
+	if stats := injector.Stats(); stats.RedisDelays != 1 {
+		t.Errorf("RedisDelays = %d, want 1", stats.RedisDelays)
+	}
+}
+
+func TestInjector_KafkaFailure(t *testing.T) {
+	injector := newTestInjector(t, Config{KafkaFailureRate: 1})
+	if err := injector.kafkaFailure(context.Background(), "booking.created"); !errors.Is(err, ErrInjected) {
+		t.Errorf("kafkaFailure() error = %v, want ErrInjected", err)
+	}
+
+	injector = newTestInjector(t, Config{})
+	if err := injector.kafkaFailure(context.Background(), "booking.created"); err != nil {
+		t.Errorf("kafkaFailure() error = %v, want nil", err)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{"zero rates", Config{}, false},
+		{"valid rates", Config{LuaFailureRate: 0.1, RedisLatencyRate: 0.5, RedisLatency: time.Second, KafkaFailureRate: 1}, false},
+		{"negative rate", Config{LuaFailureRate: -0.1}, true},
+		{"rate above one", Config{KafkaFailureRate: 1.5}, true},
+		{"latency rate without latency", Config{RedisLatencyRate: 0.5}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
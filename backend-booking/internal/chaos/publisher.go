@@ -0,0 +1,167 @@
+package chaos
+
+import (
+	"context"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/saga"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+)
+
+// eventPublisher wraps an EventPublisher with Kafka publish failures
+type eventPublisher struct {
+	publisher service.EventPublisher
+	injector  *Injector
+}
+
+// NewEventPublisher wraps publisher with fault injection
+func NewEventPublisher(publisher service.EventPublisher, injector *Injector) service.EventPublisher {
+	return &eventPublisher{
+		publisher: publisher,
+		injector:  injector,
+	}
+}
+
+// PublishBookingCreated publishes a booking created event unless a failure is injected
+func (p *eventPublisher) PublishBookingCreated(ctx context.Context, booking *domain.Booking) error {
+	if err := p.injector.kafkaFailure(ctx, "booking.created"); err != nil {
+		return err
+	}
+	return p.publisher.PublishBookingCreated(ctx, booking)
+}
+
+// PublishBookingConfirmed publishes a booking confirmed event unless a failure is injected
+func (p *eventPublisher) PublishBookingConfirmed(ctx context.Context, booking *domain.Booking) error {
+	if err := p.injector.kafkaFailure(ctx, "booking.confirmed"); err != nil {
+		return err
+	}
+	return p.publisher.PublishBookingConfirmed(ctx, booking)
+}
+
+// PublishBookingCancelled publishes a booking cancelled event unless a failure is injected
+func (p *eventPublisher) PublishBookingCancelled(ctx context.Context, booking *domain.Booking) error {
+	if err := p.injector.kafkaFailure(ctx, "booking.cancelled"); err != nil {
+		return err
+	}
+	return p.publisher.PublishBookingCancelled(ctx, booking)
+}
+
+// PublishBookingExpired publishes a booking expired event unless a failure is injected
+func (p *eventPublisher) PublishBookingExpired(ctx context.Context, booking *domain.Booking) error {
+	if err := p.injector.kafkaFailure(ctx, "booking.expired"); err != nil {
+		return err
+	}
+	return p.publisher.PublishBookingExpired(ctx, booking)
+}
+
+// PublishBookingModified publishes a booking modified event unless a failure is injected
+func (p *eventPublisher) PublishBookingModified(ctx context.Context, booking, previous *domain.Booking) error {
+	if err := p.injector.kafkaFailure(ctx, "booking.modified"); err != nil {
+		return err
+	}
+	return p.publisher.PublishBookingModified(ctx, booking, previous)
+}
+
+// Close closes the wrapped publisher
+func (p *eventPublisher) Close() error {
+	return p.publisher.Close()
+}
+
+// sagaProducer wraps a SagaProducer with Kafka publish failures
+type sagaProducer struct {
+	producer saga.SagaProducer
+	injector *Injector
+}
+
+// NewSagaProducer wraps producer with fault injection
+func NewSagaProducer(producer saga.SagaProducer, injector *Injector) saga.SagaProducer {
+	return &sagaProducer{
+		producer: producer,
+		injector: injector,
+	}
+}
+
+// SendCommand sends a saga command unless a failure is injected
+func (p *sagaProducer) SendCommand(ctx context.Context, command *saga.SagaCommand) error {
+	if err := p.injector.kafkaFailure(ctx, "saga.command"); err != nil {
+		return err
+	}
+	return p.producer.SendCommand(ctx, command)
+}
+
+// SendCompensationCommand sends a compensation command unless a failure is injected
+func (p *sagaProducer) SendCompensationCommand(ctx context.Context, command *saga.CompensationCommand) error {
+	if err := p.injector.kafkaFailure(ctx, "saga.compensation"); err != nil {
+		return err
+	}
+	return p.producer.SendCompensationCommand(ctx, command)
+}
+
+// SendStepSuccessEvent sends a step success event unless a failure is injected
+func (p *sagaProducer) SendStepSuccessEvent(ctx context.Context, event *saga.SagaEvent) error {
+	if err := p.injector.kafkaFailure(ctx, "saga.step_success"); err != nil {
+		return err
+	}
+	return p.producer.SendStepSuccessEvent(ctx, event)
+}
+
+// SendStepFailureEvent sends a step failure event unless a failure is injected
+func (p *sagaProducer) SendStepFailureEvent(ctx context.Context, event *saga.SagaEvent) error {
+	if err := p.injector.kafkaFailure(ctx, "saga.step_failure"); err != nil {
+		return err
+	}
+	return p.producer.SendStepFailureEvent(ctx, event)
+}
+
+// SendSagaStartedEvent sends a saga started event unless a failure is injected
+func (p *sagaProducer) SendSagaStartedEvent(ctx context.Context, event *saga.SagaLifecycleEvent) error {
+	if err := p.injector.kafkaFailure(ctx, "saga.started"); err != nil {
+		return err
+	}
+	return p.producer.SendSagaStartedEvent(ctx, event)
+}
+
+// SendSagaCompletedEvent sends a saga completed event unless a failure is injected
+func (p *sagaProducer) SendSagaCompletedEvent(ctx context.Context, event *saga.SagaLifecycleEvent) error {
+	if err := p.injector.kafkaFailure(ctx, "saga.completed"); err != nil {
+		return err
+	}
+	return p.producer.SendSagaCompletedEvent(ctx, event)
+}
+
+// SendSagaFailedEvent sends a saga failed event unless a failure is injected
+func (p *sagaProducer) SendSagaFailedEvent(ctx context.Context, event *saga.SagaLifecycleEvent) error {
+	if err := p.injector.kafkaFailure(ctx, "saga.failed"); err != nil {
+		return err
+	}
+	return p.producer.SendSagaFailedEvent(ctx, event)
+}
+
+// SendSagaCompensatedEvent sends a saga compensated event unless a failure is injected
+func (p *sagaProducer) SendSagaCompensatedEvent(ctx context.Context, event *saga.SagaLifecycleEvent) error {
+	if err := p.injector.kafkaFailure(ctx, "saga.compensated"); err != nil {
+		return err
+	}
+	return p.producer.SendSagaCompensatedEvent(ctx, event)
+}
+
+// ScheduleTimeoutCheck schedules a timeout check unless a failure is injected
+func (p *sagaProducer) ScheduleTimeoutCheck(ctx context.Context, check *saga.TimeoutCheck) error {
+	if err := p.injector.kafkaFailure(ctx, "saga.timeout_check"); err != nil {
+		return err
+	}
+	return p.producer.ScheduleTimeoutCheck(ctx, check)
+}
+
+// Publish publishes a raw message unless a failure is injected
+func (p *sagaProducer) Publish(ctx context.Context, topic string, key string, value []byte) error {
+	if err := p.injector.kafkaFailure(ctx, topic); err != nil {
+		return err
+	}
+	return p.producer.Publish(ctx, topic, key, value)
+}
+
+// Close closes the wrapped producer
+func (p *sagaProducer) Close() error {
+	return p.producer.Close()
+}
@@ -0,0 +1,122 @@
+package chaos
+
+import (
+	"context"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+)
+
+// reservationRepository wraps a ReservationRepository with Redis latency and Lua failures
+type reservationRepository struct {
+	repo     repository.ReservationRepository
+	injector *Injector
+}
+
+// NewReservationRepository wraps repo with fault injection
+func NewReservationRepository(repo repository.ReservationRepository, injector *Injector) repository.ReservationRepository {
+	return &reservationRepository{
+		repo:     repo,
+		injector: injector,
+	}
+}
+
+// ReserveSeats runs the reserve script with injected faults
+func (r *reservationRepository) ReserveSeats(ctx context.Context, params repository.ReserveParams) (*repository.ReserveResult, error) {
+	if err := r.injector.redisDelay(ctx, "reserve_seats"); err != nil {
+		return nil, err
+	}
+	fault := r.injector.luaFault(ctx, "reserve_seats")
+	if fault == luaFaultBeforeWrite {
+		return nil, luaError("reserve_seats", fault)
+	}
+
+	result, err := r.repo.ReserveSeats(ctx, params)
+	if err == nil && fault == luaFaultAfterWrite {
+		return nil, luaError("reserve_seats", fault)
+	}
+	return result, err
+}
+
+// ConfirmBooking runs the confirm script with injected faults
+func (r *reservationRepository) ConfirmBooking(ctx context.Context, bookingID, userID, paymentID string) (*repository.ConfirmResult, error) {
+	if err := r.injector.redisDelay(ctx, "confirm_booking"); err != nil {
+		return nil, err
+	}
+	fault := r.injector.luaFault(ctx, "confirm_booking")
+	if fault == luaFaultBeforeWrite {
+		return nil, luaError("confirm_booking", fault)
+	}
+
+	result, err := r.repo.ConfirmBooking(ctx, bookingID, userID, paymentID)
+	if err == nil && fault == luaFaultAfterWrite {
+		return nil, luaError("confirm_booking", fault)
+	}
+	return result, err
+}
+
+// ExtendHold runs the extend hold script with injected faults
+func (r *reservationRepository) ExtendHold(ctx context.Context, params repository.ExtendHoldParams) (*repository.ExtendHoldResult, error) {
+	if err := r.injector.redisDelay(ctx, "extend_hold"); err != nil {
+		return nil, err
+	}
+	fault := r.injector.luaFault(ctx, "extend_hold")
+	if fault == luaFaultBeforeWrite {
+		return nil, luaError("extend_hold", fault)
+	}
+
+	result, err := r.repo.ExtendHold(ctx, params)
+	if err == nil && fault == luaFaultAfterWrite {
+		return nil, luaError("extend_hold", fault)
+	}
+	return result, err
+}
+
+// ReleaseSeats runs the release script with injected faults
+func (r *reservationRepository) ReleaseSeats(ctx context.Context, bookingID, userID string) (*repository.ReleaseResult, error) {
+	if err := r.injector.redisDelay(ctx, "release_seats"); err != nil {
+		return nil, err
+	}
+	fault := r.injector.luaFault(ctx, "release_seats")
+	if fault == luaFaultBeforeWrite {
+		return nil, luaError("release_seats", fault)
+	}
+
+	result, err := r.repo.ReleaseSeats(ctx, bookingID, userID)
+	if err == nil && fault == luaFaultAfterWrite {
+		return nil, luaError("release_seats", fault)
+	}
+	return result, err
+}
+
+// ModifyReservation runs the modify script with injected faults
+func (r *reservationRepository) ModifyReservation(ctx context.Context, params repository.ModifyParams) (*repository.ModifyResult, error) {
+	if err := r.injector.redisDelay(ctx, "modify_reservation"); err != nil {
+		return nil, err
+	}
+	fault := r.injector.luaFault(ctx, "modify_reservation")
+	if fault == luaFaultBeforeWrite {
+		return nil, luaError("modify_reservation", fault)
+	}
+
+	result, err := r.repo.ModifyReservation(ctx, params)
+	if err == nil && fault == luaFaultAfterWrite {
+		return nil, luaError("modify_reservation", fault)
+	}
+	return result, err
+}
+
+// GetZoneAvailability reads zone availability with injected latency
+func (r *reservationRepository) GetZoneAvailability(ctx context.Context, zoneID string) (int64, error) {
+	if err := r.injector.redisDelay(ctx, "get_zone_availability"); err != nil {
+		return 0, err
+	}
+	return r.repo.GetZoneAvailability(ctx, zoneID)
+}
+
+// SetZoneAvailability sets zone availability with injected latency
+func (r *reservationRepository) SetZoneAvailability(ctx context.Context, zoneID string, seats int64) error {
+	if err := r.injector.redisDelay(ctx, "set_zone_availability"); err != nil {
+		return err
+	}
+	return r.repo.SetZoneAvailability(ctx, zoneID, seats)
+}
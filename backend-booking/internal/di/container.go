@@ -1,6 +1,8 @@
 package di
 
 import (
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/chaos"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dualwrite"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/handler"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/saga"
@@ -17,24 +19,88 @@ type Container struct {
 	Redis *redis.Client
 
 	// Repositories
-	BookingRepo     repository.BookingRepository
-	ReservationRepo repository.ReservationRepository
-	QueueRepo       repository.QueueRepository
+	BookingRepo        repository.BookingRepository
+	ReservationRepo    repository.ReservationRepository
+	QueueRepo          repository.QueueRepository
+	CompensationRepo   repository.CompensationRepository
+	RefundBatchRepo    repository.RefundBatchRepository
+	UserDataRepo       repository.UserDataRepository
+	StandbyRepo        repository.StandbyRepository
+	WebhookSubRepo     repository.WebhookSubscriptionRepository
+	WebhookDelivRepo   repository.WebhookDeliveryRepository
+	SeatMapRepo        repository.SeatMapRepository
+	ConfirmJobRepo     repository.ConfirmJobRepository
+	CartRepo           repository.CartRepository
+	SearchRepo         repository.BookingSearchRepository
+	VerificationRepo   repository.VerificationPolicyRepository
+	PersistQueue       repository.BookingPersistQueue
+	ZoneShardRepo      repository.ZoneShardRepository
+	ReportScheduleRepo repository.ReportScheduleRepository
+	SalesReportRepo    repository.SalesReportRepository
+	SalesSnapshotRepo  repository.SalesSnapshotRepository
+	QueueAnalyticsRepo repository.QueueAnalyticsRepository
+	PriceTierRepo      repository.PriceTierRepository
+	AddOnRepo          repository.AddOnRepository
+	IdleRepo           repository.IdleReservationRepository
 
 	// Publishers
 	EventPublisher service.EventPublisher
 
 	// Services
-	BookingService service.BookingService
-	QueueService   service.QueueService
-	SagaService    service.SagaService
+	BookingService        service.BookingService
+	QueueService          service.QueueService
+	SagaService           service.SagaService
+	CompensationService   service.CompensationService
+	RefundBatchService    service.RefundBatchService
+	EventAccess           service.EventAccessChecker // Nil without ticket service; only admins manage events then
+	SagaStatsService      service.SagaStatsService
+	SagaStepRetryService  service.SagaStepRetryService
+	UserDataService       service.UserDataService
+	StandbyService        service.StandbyService
+	WebhookService        service.WebhookService
+	SeatMapService        service.SeatMapService
+	AsyncConfirmService   service.AsyncConfirmService
+	ModificationService   service.BookingModificationService
+	CancellationService   service.BookingCancellationService // Set only when cancellation quotes are configured
+	CartService           service.CartService
+	SearchService         service.BookingSearchService
+	VerificationGate      service.VerificationGate
+	WriteBehindService    service.WriteBehindService
+	ZoneShardService      service.ZoneShardService
+	AlternativesService   service.SeatAlternativesService
+	StatusService         service.BookingStatusService
+	ReportService         service.ReportService
+	SalesSnapshotService  service.SalesSnapshotService   // Set only when sales snapshots are configured
+	QueueAnalyticsService service.QueueAnalyticsService  // Set only when queue analytics are enabled
+	ZoneCatalog           *service.ZoneCatalog           // Set only when ticket service is configured
+	IdleService           service.IdleReservationService // Set only when release links are configured
 
 	// Handlers
-	HealthHandler  *handler.HealthHandler
-	BookingHandler *handler.BookingHandler
-	QueueHandler   *handler.QueueHandler
-	AdminHandler   *handler.AdminHandler
-	SagaHandler    *handler.SagaHandler
+	HealthHandler            *handler.HealthHandler
+	BookingHandler           *handler.BookingHandler
+	QueueHandler             *handler.QueueHandler
+	AdminHandler             *handler.AdminHandler
+	SagaHandler              *handler.SagaHandler
+	CompensationHandler      *handler.CompensationHandler
+	RefundBatchHandler       *handler.RefundBatchHandler
+	SagaStatsHandler         *handler.SagaStatsHandler
+	SagaStepRetryHandler     *handler.SagaStepRetryHandler
+	UserDataHandler          *handler.UserDataHandler
+	StandbyHandler           *handler.StandbyHandler
+	WebhookHandler           *handler.WebhookHandler
+	SeatMapHandler           *handler.SeatMapHandler
+	ChaosHandler             *handler.ChaosHandler
+	ReservationShadowHandler *handler.ReservationShadowHandler
+	TenantShardHandler       *handler.TenantShardHandler
+	RedisMemoryHandler       *handler.RedisMemoryHandler
+	LoadTestHandler          *handler.LoadTestHandler
+	CartHandler              *handler.CartHandler
+	SearchHandler            *handler.BookingSearchHandler
+	ZoneShardHandler         *handler.ZoneShardHandler
+	ReportHandler            *handler.ReportHandler
+	SalesSnapshotHandler     *handler.SalesSnapshotHandler
+	QueueAnalyticsHandler    *handler.QueueAnalyticsHandler
+	AvailabilityHandler      *handler.AvailabilityHandler
 }
 
 // ContainerConfig contains configuration for building the container
@@ -44,14 +110,59 @@ type ContainerConfig struct {
 	BookingRepo          repository.BookingRepository
 	ReservationRepo      repository.ReservationRepository
 	QueueRepo            repository.QueueRepository
+	CompensationRepo     repository.CompensationRepository
+	RefundBatchRepo      repository.RefundBatchRepository
+	UserDataRepo         repository.UserDataRepository
+	StandbyRepo          repository.StandbyRepository
+	WebhookSubRepo       repository.WebhookSubscriptionRepository
+	WebhookDelivRepo     repository.WebhookDeliveryRepository
+	SeatMapRepo          repository.SeatMapRepository
+	ConfirmJobRepo       repository.ConfirmJobRepository // Set only when async confirm is enabled
+	CartRepo             repository.CartRepository
+	SearchRepo           repository.BookingSearchRepository
+	VerificationRepo     repository.VerificationPolicyRepository
+	PersistQueue         repository.BookingPersistQueue // Set only when write-behind reservations are enabled
+	ZoneShardRepo        repository.ZoneShardRepository
+	ReportScheduleRepo   repository.ReportScheduleRepository // Set with SalesReportRepo to enable organizer sales reports
+	SalesReportRepo      repository.SalesReportRepository
+	SalesSnapshotRepo    repository.SalesSnapshotRepository   // Set to serve hourly zone snapshots to charts
+	QueueAnalyticsRepo   repository.QueueAnalyticsRepository  // Set to record queue funnels
+	PriceTierRepo        repository.PriceTierRepository       // Set to sell zones at their scheduled price tiers
+	AddOnRepo            repository.AddOnRepository           // Set to sell event add-ons with reservations
+	IdleRepo             repository.IdleReservationRepository // Set with IdleConfig to enable release links
 	EventPublisher       service.EventPublisher
 	ServiceConfig        *service.BookingServiceConfig
 	QueueServiceConfig   *service.QueueServiceConfig
+	StandbyServiceConfig *service.StandbyServiceConfig
+	WebhookServiceConfig *service.WebhookServiceConfig
+	SeatMapServiceConfig *service.SeatMapServiceConfig
+	AsyncConfirmConfig   *service.AsyncConfirmServiceConfig
+	CartServiceConfig    *service.CartServiceConfig
+	ModificationConfig   *service.BookingModificationConfig
+	CancellationConfig   *service.BookingCancellationConfig    // Set with a secret to enable cancellation quotes and refunds
+	IdleConfig           *service.IdleReservationServiceConfig // Set with a secret to enable one-click release of idle reservations
+	VerificationConfig   *service.PolicyVerificationGateConfig
+	WriteBehindConfig    *service.WriteBehindServiceConfig
+	ReportServiceConfig  *service.ReportServiceConfig
+	BookingStatusConfig  *service.BookingStatusConfig
+	ZoneCatalogConfig    *service.ZoneCatalogConfig
 	TicketServiceURL     string // URL of ticket service for zone sync
+	AuthServiceURL       string // URL of auth service for booking search by email
+	PaymentServiceURL    string // URL of payment service for booking search by card and payment cross-references
+	InternalAuthSecret   string // Signs requests to other services made on behalf of an admin
 	SagaProducer         saga.SagaProducer
 	SagaStore            pkgsaga.Store
+	SagaStatsStore       pkgsaga.StatsStore // Aggregates for the admin saga stats endpoint
 	SagaServiceConfig    *service.SagaServiceConfig
 	BookingHandlerConfig *handler.BookingHandlerConfig
+	ChaosInjector        *chaos.Injector                  // Set only in chaos mode; dependencies are already wrapped
+	ReservationShadow    *dualwrite.ReservationRepository // Set only in shadow mode; ReservationRepo already includes it
+	TenantShards         *database.ShardResolver          // Set only with tenant shards; BookingRepo already routes by it
+	LoadTestConfig       *service.LoadTestServiceConfig   // Set only in load-test mode
+	MemoryGovernor       *redis.MemoryGovernor            // Set only when Redis memory audits are enabled
+	AvailabilityStream   *service.AvailabilityStream      // Set only when availability flips are broadcast
+	// Set only when availability reads are verified against PostgreSQL
+	AvailabilityVerifier *service.AvailabilityVerifier
 	// Note: Saga is now triggered asynchronously after payment success via webhook
 	// Booking handler always uses fast path (Redis Lua + PostgreSQL)
 }
@@ -59,12 +170,45 @@ type ContainerConfig struct {
 // NewContainer creates a new dependency injection container
 func NewContainer(cfg *ContainerConfig) *Container {
 	c := &Container{
-		DB:              cfg.DB,
-		Redis:           cfg.Redis,
-		BookingRepo:     cfg.BookingRepo,
-		ReservationRepo: cfg.ReservationRepo,
-		QueueRepo:       cfg.QueueRepo,
-		EventPublisher:  cfg.EventPublisher,
+		DB:                 cfg.DB,
+		Redis:              cfg.Redis,
+		BookingRepo:        cfg.BookingRepo,
+		ReservationRepo:    cfg.ReservationRepo,
+		QueueRepo:          cfg.QueueRepo,
+		CompensationRepo:   cfg.CompensationRepo,
+		RefundBatchRepo:    cfg.RefundBatchRepo,
+		UserDataRepo:       cfg.UserDataRepo,
+		StandbyRepo:        cfg.StandbyRepo,
+		WebhookSubRepo:     cfg.WebhookSubRepo,
+		WebhookDelivRepo:   cfg.WebhookDelivRepo,
+		SeatMapRepo:        cfg.SeatMapRepo,
+		ConfirmJobRepo:     cfg.ConfirmJobRepo,
+		CartRepo:           cfg.CartRepo,
+		SearchRepo:         cfg.SearchRepo,
+		VerificationRepo:   cfg.VerificationRepo,
+		ZoneShardRepo:      cfg.ZoneShardRepo,
+		ReportScheduleRepo: cfg.ReportScheduleRepo,
+		SalesReportRepo:    cfg.SalesReportRepo,
+		SalesSnapshotRepo:  cfg.SalesSnapshotRepo,
+		QueueAnalyticsRepo: cfg.QueueAnalyticsRepo,
+		PriceTierRepo:      cfg.PriceTierRepo,
+		AddOnRepo:          cfg.AddOnRepo,
+		IdleRepo:           cfg.IdleRepo,
+		PersistQueue:       cfg.PersistQueue,
+		EventPublisher:     cfg.EventPublisher,
+	}
+
+	// Write-behind reservations (optional - bookings are inserted while reserving without it).
+	// Every service reads through the wrapped repository so queued bookings are never missed.
+	if c.PersistQueue != nil {
+		c.WriteBehindService = service.NewWriteBehindService(
+			c.BookingRepo,
+			c.ReservationRepo,
+			c.PersistQueue,
+			c.EventPublisher,
+			cfg.WriteBehindConfig,
+		)
+		c.BookingRepo = service.NewWriteBehindBookingRepository(c.BookingRepo, c.WriteBehindService)
 	}
 
 	// Initialize zone syncer for auto-sync on ZONE_NOT_FOUND
@@ -72,6 +216,74 @@ func NewContainer(cfg *ContainerConfig) *Container {
 	if cfg.TicketServiceURL != "" {
 		zoneFetcher := service.NewHTTPZoneFetcher(cfg.TicketServiceURL)
 		zoneSyncer = service.NewZoneSyncer(zoneFetcher, c.ReservationRepo)
+
+		// Zone metadata and prices for hot paths; the syncer needs live counts and bypasses it
+		catalogConfig := service.ZoneCatalogConfig{}
+		if cfg.ZoneCatalogConfig != nil {
+			catalogConfig = *cfg.ZoneCatalogConfig
+		}
+		if catalogConfig.Redis == nil {
+			catalogConfig.Redis = c.Redis
+		}
+		if catalogConfig.PriceTiers == nil && c.PriceTierRepo != nil {
+			catalogConfig.PriceTiers = zoneFetcher
+		}
+		if catalogConfig.AddOns == nil && c.AddOnRepo != nil {
+			catalogConfig.AddOns = zoneFetcher
+		}
+		c.ZoneCatalog = service.NewZoneCatalog(zoneFetcher, zoneFetcher, &catalogConfig)
+
+		// Sibling zones for INSUFFICIENT_SEATS responses
+		alternativesConfig := &service.SeatAlternativesConfig{Verifier: cfg.AvailabilityVerifier}
+		if cfg.ServiceConfig != nil {
+			alternativesConfig.MaxPerUser = cfg.ServiceConfig.MaxPerUser
+		}
+		c.AlternativesService = service.NewSeatAlternativesService(c.ZoneCatalog, c.ZoneCatalog, c.ReservationRepo, alternativesConfig)
+	}
+
+	// Standby list for sold-out zones (optional - reservations fail hard without it)
+	if c.StandbyRepo != nil {
+		c.StandbyService = service.NewStandbyService(c.StandbyRepo, cfg.StandbyServiceConfig)
+	}
+
+	// Reserved seating (optional - zones stay general admission without it)
+	if c.SeatMapRepo != nil {
+		c.SeatMapService = service.NewSeatMapService(c.SeatMapRepo, cfg.SeatMapServiceConfig)
+	}
+
+	// Counter sharding of hot zones
+	c.ZoneShardService = service.NewZoneShardService(c.ZoneShardRepo)
+
+	// Anti-scalping verification (optional - events are open to every account without it)
+	if c.VerificationRepo != nil {
+		c.VerificationGate = service.NewPolicyVerificationGate(c.VerificationRepo, cfg.VerificationConfig)
+	}
+
+	// Queue funnel analytics (optional - joins, reservations and confirmations go uncounted without it)
+	serviceConfig := service.BookingServiceConfig{}
+	if cfg.ServiceConfig != nil {
+		serviceConfig = *cfg.ServiceConfig
+	}
+	// Reservations that do not name a unit price take the zone's price
+	if c.ZoneCatalog != nil && serviceConfig.ZonePrices == nil {
+		serviceConfig.ZonePrices = c.ZoneCatalog
+	}
+	// Zones with a price schedule sell at their current price tier
+	if c.ZoneCatalog != nil && c.PriceTierRepo != nil && serviceConfig.PriceTiers == nil {
+		serviceConfig.PriceTiers = service.NewZonePricer(c.ZoneCatalog, c.PriceTierRepo)
+	}
+	// Events with add-ons sell them from their own inventory with reservations
+	if c.ZoneCatalog != nil && c.AddOnRepo != nil && serviceConfig.AddOns == nil {
+		serviceConfig.AddOns = service.NewAddOnSeller(c.ZoneCatalog, c.AddOnRepo)
+	}
+	queueServiceConfig := service.QueueServiceConfig{}
+	if cfg.QueueServiceConfig != nil {
+		queueServiceConfig = *cfg.QueueServiceConfig
+	}
+	if c.QueueAnalyticsRepo != nil {
+		c.QueueAnalyticsService = service.NewQueueAnalyticsService(c.QueueAnalyticsRepo, c.QueueRepo)
+		serviceConfig.QueueAnalytics = c.QueueAnalyticsService
+		queueServiceConfig.Analytics = c.QueueAnalyticsService
 	}
 
 	// Initialize services
@@ -80,12 +292,16 @@ func NewContainer(cfg *ContainerConfig) *Container {
 		c.ReservationRepo,
 		c.EventPublisher,
 		zoneSyncer,
-		cfg.ServiceConfig,
+		c.StandbyService,
+		c.SeatMapService,
+		c.VerificationGate,
+		c.WriteBehindService,
+		&serviceConfig,
 	)
 
 	c.QueueService = service.NewQueueService(
 		c.QueueRepo,
-		cfg.QueueServiceConfig,
+		&queueServiceConfig,
 	)
 
 	// Initialize saga service (optional - depends on Kafka availability)
@@ -95,16 +311,175 @@ func NewContainer(cfg *ContainerConfig) *Container {
 		c.SagaService = service.NewNoOpSagaService()
 	}
 
+	// Compensation service works without Kafka for listing; triggering requires the saga producer
+	c.CompensationService = service.NewCompensationService(c.BookingRepo, c.CompensationRepo, cfg.SagaProducer)
+
+	// Cancelling shows needs ticket service; refunds are dispatched by the refund-worker
+	var showCanceller service.ShowCanceller
+	if cfg.TicketServiceURL != "" {
+		showCanceller = service.NewHTTPShowCanceller(cfg.TicketServiceURL, cfg.InternalAuthSecret)
+		// Organizers' access to events managed through admin routes is checked there too
+		c.EventAccess = service.NewHTTPEventAccessChecker(cfg.TicketServiceURL, cfg.InternalAuthSecret)
+	}
+	c.RefundBatchService = service.NewRefundBatchService(c.RefundBatchRepo, showCanceller, nil)
+
+	// Saga stats read the saga store directly and do not depend on Kafka
+	c.SagaStatsService = service.NewSagaStatsService(cfg.SagaStatsStore)
+
+	// Retrying dead-lettered saga steps needs the saga producer and a store that keeps dead letters
+	deadLetters, _ := cfg.SagaStore.(pkgsaga.DeadLetterStore)
+	c.SagaStepRetryService = service.NewSagaStepRetryService(cfg.SagaProducer, cfg.SagaStore, deadLetters, cfg.SagaServiceConfig)
+
+	// User data export/anonymization for GDPR requests coordinated by auth-service
+	c.UserDataService = service.NewUserDataService(c.UserDataRepo)
+
+	// Tenant webhook subscriptions and delivery log; deliveries are sent by webhook-worker
+	c.WebhookService = service.NewWebhookService(c.WebhookSubRepo, c.WebhookDelivRepo, cfg.WebhookServiceConfig)
+
+	// Organizer sales report schedules; reports are sent by report-worker
+	if c.ReportScheduleRepo != nil && c.SalesReportRepo != nil {
+		c.ReportService = service.NewReportService(c.ReportScheduleRepo, c.SalesReportRepo, c.QueueService, nil, cfg.ReportServiceConfig)
+	}
+	// Historical seat and revenue charts; snapshots are taken by report-worker
+	if c.SalesSnapshotRepo != nil {
+		c.SalesSnapshotService = service.NewSalesSnapshotService(c.SalesSnapshotRepo, nil)
+	}
+
+	// Multi-show carts reserve through the booking service, one booking per item
+	c.CartService = service.NewCartService(c.CartRepo, c.BookingService, c.QueueService, cfg.CartServiceConfig)
+
+	// Support booking search; email and last4 filters need the auth and payment services
+	if c.SearchRepo != nil {
+		var userLookup service.UserLookup
+		if cfg.AuthServiceURL != "" {
+			userLookup = service.NewHTTPUserLookup(cfg.AuthServiceURL)
+		}
+		var paymentLookup service.PaymentLookup
+		if cfg.PaymentServiceURL != "" {
+			paymentLookup = service.NewHTTPPaymentLookup(cfg.PaymentServiceURL)
+		}
+		c.SearchService = service.NewBookingSearchService(c.SearchRepo, userLookup, paymentLookup)
+	}
+
+	// Aggregated "where is my order" status; payment and saga sections are best effort
+	var statusPayments service.PaymentLookup
+	if cfg.PaymentServiceURL != "" {
+		statusPayments = service.NewHTTPPaymentLookup(cfg.PaymentServiceURL)
+	}
+	sagaLookup, _ := cfg.SagaStore.(pkgsaga.BookingLookupStore)
+	c.StatusService = service.NewBookingStatusService(c.BookingRepo, statusPayments, sagaLookup, cfg.BookingStatusConfig)
+
+	// Booking modification; paid bookings can only change price with payment service
+	var paymentAdjuster service.PaymentAdjuster
+	if cfg.PaymentServiceURL != "" {
+		paymentAdjuster = service.NewHTTPPaymentAdjuster(cfg.PaymentServiceURL)
+	}
+	c.ModificationService = service.NewBookingModificationService(
+		c.BookingRepo,
+		c.ReservationRepo,
+		paymentAdjuster,
+		c.EventPublisher,
+		cfg.ModificationConfig,
+	)
+
+	handlerConfig := handler.BookingHandlerConfig{}
+	if cfg.BookingHandlerConfig != nil {
+		handlerConfig = *cfg.BookingHandlerConfig
+	}
+	// Self-service cancellation; confirmed bookings are refunded through payment service
+	if cfg.CancellationConfig != nil && cfg.CancellationConfig.Secret != "" {
+		c.CancellationService = service.NewBookingCancellationService(
+			c.BookingService,
+			c.BookingRepo,
+			c.ReservationRepo,
+			paymentAdjuster,
+			c.EventPublisher,
+			c.StandbyService,
+			cfg.CancellationConfig,
+		)
+	}
+
+	// One-click release of idle reservations; reminders are sent by idle-reservation-worker
+	if c.IdleRepo != nil && cfg.IdleConfig != nil && cfg.IdleConfig.Secret != "" {
+		c.IdleService = service.NewIdleReservationService(c.BookingRepo, c.IdleRepo, c.BookingService, nil, cfg.IdleConfig)
+	}
+
+	handlerConfig.ModificationService = c.ModificationService
+	handlerConfig.CancellationService = c.CancellationService
+	handlerConfig.AlternativesService = c.AlternativesService
+	handlerConfig.StatusService = c.StatusService
+	handlerConfig.IdleReleaseService = c.IdleService
+	bookingHandlerConfig := &handlerConfig
+
+	// Async confirmation (optional - confirm stays synchronous without the job queue)
+	if c.ConfirmJobRepo != nil {
+		c.AsyncConfirmService = service.NewAsyncConfirmService(
+			c.BookingService,
+			c.BookingRepo,
+			c.ConfirmJobRepo,
+			c.WebhookService,
+			c.VerificationGate,
+			cfg.AsyncConfirmConfig,
+		)
+		handlerConfig.AsyncConfirmService = c.AsyncConfirmService
+	}
+
 	// Initialize handlers
 	c.HealthHandler = handler.NewHealthHandler(c.DB, c.Redis)
 
 	// Booking handler uses fast path (Redis Lua + PostgreSQL)
 	// Saga is triggered asynchronously after payment success via webhook
-	c.BookingHandler = handler.NewBookingHandler(c.BookingService, c.QueueService, cfg.BookingHandlerConfig)
+	c.BookingHandler = handler.NewBookingHandler(c.BookingService, c.QueueService, c.StandbyService, bookingHandlerConfig)
 
-	c.QueueHandler = handler.NewQueueHandler(c.QueueService, c.Redis)
-	c.AdminHandler = handler.NewAdminHandler(c.Redis)
+	c.QueueHandler = handler.NewQueueHandler(c.QueueService, c.Redis, c.EventAccess)
+	c.AdminHandler = handler.NewAdminHandler(c.Redis, c.ZoneShardService)
+	c.ZoneShardHandler = handler.NewZoneShardHandler(c.ZoneShardService)
 	c.SagaHandler = handler.NewSagaHandler(c.SagaService)
+	c.CompensationHandler = handler.NewCompensationHandler(c.CompensationService)
+	c.RefundBatchHandler = handler.NewRefundBatchHandler(c.RefundBatchService)
+	c.SagaStatsHandler = handler.NewSagaStatsHandler(c.SagaStatsService)
+	c.SagaStepRetryHandler = handler.NewSagaStepRetryHandler(c.SagaStepRetryService)
+	c.UserDataHandler = handler.NewUserDataHandler(c.UserDataService)
+	if c.StandbyService != nil {
+		c.StandbyHandler = handler.NewStandbyHandler(c.StandbyService)
+	}
+	c.WebhookHandler = handler.NewWebhookHandler(c.WebhookService)
+	if c.SeatMapService != nil {
+		c.SeatMapHandler = handler.NewSeatMapHandler(c.SeatMapService, c.EventAccess)
+	}
+	c.CartHandler = handler.NewCartHandler(c.CartService)
+	if c.ReportService != nil {
+		c.ReportHandler = handler.NewReportHandler(c.ReportService)
+	}
+	if c.SalesSnapshotService != nil {
+		c.SalesSnapshotHandler = handler.NewSalesSnapshotHandler(c.SalesSnapshotService)
+	}
+	if c.QueueAnalyticsService != nil {
+		c.QueueAnalyticsHandler = handler.NewQueueAnalyticsHandler(c.QueueAnalyticsService, c.EventAccess)
+	}
+	if c.SearchService != nil {
+		c.SearchHandler = handler.NewBookingSearchHandler(c.SearchService)
+	}
+	if cfg.ChaosInjector != nil {
+		c.ChaosHandler = handler.NewChaosHandler(cfg.ChaosInjector)
+	}
+	if cfg.ReservationShadow != nil {
+		c.ReservationShadowHandler = handler.NewReservationShadowHandler(cfg.ReservationShadow)
+	}
+	if cfg.TenantShards != nil {
+		c.TenantShardHandler = handler.NewTenantShardHandler(cfg.TenantShards)
+	}
+	if cfg.MemoryGovernor != nil {
+		c.RedisMemoryHandler = handler.NewRedisMemoryHandler(cfg.MemoryGovernor)
+	}
+	if cfg.AvailabilityStream != nil {
+		c.AvailabilityHandler = handler.NewAvailabilityHandler(cfg.AvailabilityStream)
+	}
+	if cfg.LoadTestConfig != nil {
+		loadTestRepo := repository.NewRedisLoadTestRepository(c.Redis)
+		loadTestService := service.NewLoadTestService(c.QueueRepo, c.ReservationRepo, loadTestRepo, cfg.LoadTestConfig)
+		c.LoadTestHandler = handler.NewLoadTestHandler(loadTestService)
+	}
 
 	return c
 }
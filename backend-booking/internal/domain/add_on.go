@@ -0,0 +1,43 @@
+package domain
+
+import "strings"
+
+// MaxAddOnQuantity is the most units of an add-on a reservation can request
+const MaxAddOnQuantity = 10
+
+// AddOnSelection is a quantity of an event add-on requested with a reservation
+type AddOnSelection struct {
+	AddOnID  string `json:"add_on_id"`
+	Quantity int    `json:"quantity"`
+}
+
+// Validate validates the add-on ID and quantity
+func (s *AddOnSelection) Validate() error {
+	if strings.TrimSpace(s.AddOnID) == "" {
+		return ErrAddOnNotFound
+	}
+	if s.Quantity <= 0 || s.Quantity > MaxAddOnQuantity {
+		return ErrInvalidAddOnQuantity
+	}
+	return nil
+}
+
+// BookingAddOn is an add-on item (parking, merchandise) sold with a booking's tickets,
+// priced when it was reserved
+type BookingAddOn struct {
+	AddOnID    string  `json:"add_on_id"`
+	Name       string  `json:"name"`
+	Category   string  `json:"category,omitempty"`
+	Quantity   int     `json:"quantity"`
+	UnitPrice  float64 `json:"unit_price"`
+	TotalPrice float64 `json:"total_price"`
+}
+
+// AddOnsTotal returns the price of the add-ons sold with the booking
+func (b *Booking) AddOnsTotal() float64 {
+	var total float64
+	for _, addOn := range b.AddOns {
+		total += addOn.TotalPrice
+	}
+	return total
+}
@@ -9,16 +9,18 @@ import (
 type BookingStatus string
 
 const (
-	BookingStatusReserved  BookingStatus = "reserved"
-	BookingStatusConfirmed BookingStatus = "confirmed"
-	BookingStatusCancelled BookingStatus = "cancelled"
-	BookingStatusExpired   BookingStatus = "expired"
+	BookingStatusReserved       BookingStatus = "reserved"
+	BookingStatusPendingPayment BookingStatus = "pending_payment" // Reserved, the customer is paying at the gateway (3-D Secure, bank transfer)
+	BookingStatusConfirmed      BookingStatus = "confirmed"
+	BookingStatusCancelled      BookingStatus = "cancelled"
+	BookingStatusExpired        BookingStatus = "expired"
+	BookingStatusRefunded       BookingStatus = "refunded" // Confirmed, then refunded because the show was cancelled
 )
 
 // IsValid checks if the status is a valid BookingStatus
 func (s BookingStatus) IsValid() bool {
 	switch s {
-	case BookingStatusReserved, BookingStatusConfirmed, BookingStatusCancelled, BookingStatusExpired:
+	case BookingStatusReserved, BookingStatusPendingPayment, BookingStatusConfirmed, BookingStatusCancelled, BookingStatusExpired, BookingStatusRefunded:
 		return true
 	}
 	return false
@@ -46,12 +48,15 @@ type Booking struct {
 	IdempotencyKey   string        `json:"idempotency_key,omitempty"`
 	PaymentID        string        `json:"payment_id,omitempty"`
 	ConfirmationCode string        `json:"confirmation_code,omitempty"`
+	SeatLabels       []string      `json:"seat_labels,omitempty"` // Assigned seats in reserved seating zones
 	ReservedAt       time.Time     `json:"reserved_at"`
 	ConfirmedAt      *time.Time    `json:"confirmed_at,omitempty"`
 	CancelledAt      *time.Time    `json:"cancelled_at,omitempty"`
 	ExpiresAt        time.Time     `json:"expires_at"`
 	CreatedAt        time.Time     `json:"created_at"`
 	UpdatedAt        time.Time     `json:"updated_at"`
+	// AddOns are the add-on items sold with the tickets, included in TotalPrice
+	AddOns []BookingAddOn `json:"add_ons,omitempty"`
 }
 
 // Validate validates all booking fields
@@ -146,14 +151,25 @@ func (b *Booking) IsExpiredAt(t time.Time) bool {
 	return t.After(b.ExpiresAt)
 }
 
+// SameRequest checks if b was reserved by the same user and tenant for the same
+// show, zone and quantity as other, so it may answer a retry of other's idempotency key
+func (b *Booking) SameRequest(other *Booking) bool {
+	return b.UserID == other.UserID &&
+		b.TenantID == other.TenantID &&
+		b.EventID == other.EventID &&
+		b.ShowID == other.ShowID &&
+		b.ZoneID == other.ZoneID &&
+		b.Quantity == other.Quantity
+}
+
 // CanConfirm checks if the booking can be confirmed
 func (b *Booking) CanConfirm() bool {
-	return b.Status == BookingStatusReserved && !b.IsExpired()
+	return b.IsAwaitingPayment() && !b.IsExpired()
 }
 
 // CanCancel checks if the booking can be cancelled
 func (b *Booking) CanCancel() bool {
-	return b.Status == BookingStatusReserved
+	return b.IsAwaitingPayment()
 }
 
 // IsReserved checks if the booking is in reserved status
@@ -161,6 +177,17 @@ func (b *Booking) IsReserved() bool {
 	return b.Status == BookingStatusReserved
 }
 
+// IsPendingPayment checks if the customer is completing the payment at the gateway
+func (b *Booking) IsPendingPayment() bool {
+	return b.Status == BookingStatusPendingPayment
+}
+
+// IsAwaitingPayment checks if the booking holds seats until it is paid for, either
+// reserved or pending an external payment
+func (b *Booking) IsAwaitingPayment() bool {
+	return b.IsReserved() || b.IsPendingPayment()
+}
+
 // IsConfirmed checks if the booking is in confirmed status
 func (b *Booking) IsConfirmed() bool {
 	return b.Status == BookingStatusConfirmed
@@ -187,6 +214,42 @@ func (b *Booking) Confirm(paymentID string) error {
 	return nil
 }
 
+// CanModify checks if the zone or quantity of the booking can still change
+func (b *Booking) CanModify() bool {
+	return (b.Status == BookingStatusReserved && !b.IsExpired()) || b.Status == BookingStatusConfirmed
+}
+
+// Modify moves the booking to another zone, quantity or price per seat
+func (b *Booking) Modify(zoneID string, quantity int, unitPrice float64) error {
+	if !b.CanModify() {
+		if b.Status == BookingStatusReserved {
+			return ErrBookingExpired
+		}
+		return ErrModificationNotAllowed
+	}
+	if len(b.SeatLabels) > 0 {
+		return ErrAssignedSeatsNotModified
+	}
+	if zoneID == "" {
+		return ErrInvalidZoneID
+	}
+	if quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+	if unitPrice < 0 {
+		return ErrInvalidUnitPrice
+	}
+	if zoneID == b.ZoneID && quantity == b.Quantity && unitPrice == b.UnitPrice {
+		return ErrNoModification
+	}
+	b.ZoneID = zoneID
+	b.Quantity = quantity
+	b.UnitPrice = unitPrice
+	b.TotalPrice = unitPrice*float64(quantity) + b.AddOnsTotal()
+	b.UpdatedAt = time.Now()
+	return nil
+}
+
 // Cancel marks the booking as cancelled
 func (b *Booking) Cancel() error {
 	if !b.CanCancel() {
@@ -203,9 +266,24 @@ func (b *Booking) Cancel() error {
 	return nil
 }
 
+// AwaitPayment hands the reservation off to a payment the customer completes at the
+// gateway, such as 3-D Secure or a bank transfer
+func (b *Booking) AwaitPayment(paymentID string) error {
+	if !b.IsAwaitingPayment() {
+		return ErrInvalidBookingStatus
+	}
+	if b.IsExpired() {
+		return ErrBookingExpired
+	}
+	b.Status = BookingStatusPendingPayment
+	b.PaymentID = paymentID
+	b.UpdatedAt = time.Now()
+	return nil
+}
+
 // Expire marks the booking as expired
 func (b *Booking) Expire() error {
-	if b.Status != BookingStatusReserved {
+	if !b.IsAwaitingPayment() {
 		return ErrInvalidBookingStatus
 	}
 	b.Status = BookingStatusExpired
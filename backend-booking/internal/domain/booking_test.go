@@ -211,6 +211,11 @@ func TestBooking_CanConfirm(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name:   "pending external payment",
+			modify: func(b *Booking) { b.Status = BookingStatusPendingPayment },
+			want:   true,
+		},
 		{
 			name:   "already confirmed",
 			modify: func(b *Booking) { b.Status = BookingStatusConfirmed },
@@ -241,6 +246,7 @@ func TestBooking_CanCancel(t *testing.T) {
 		want   bool
 	}{
 		{"reserved", BookingStatusReserved, true},
+		{"pending payment", BookingStatusPendingPayment, true},
 		{"confirmed", BookingStatusConfirmed, false},
 		{"cancelled", BookingStatusCancelled, false},
 		{"expired", BookingStatusExpired, false},
@@ -376,6 +382,15 @@ func TestBooking_Expire(t *testing.T) {
 		}
 	})
 
+	t.Run("expire booking pending payment", func(t *testing.T) {
+		b := newValidBooking()
+		b.Status = BookingStatusPendingPayment
+
+		if err := b.Expire(); err != nil {
+			t.Errorf("Booking.Expire() error = %v, want nil", err)
+		}
+	})
+
 	t.Run("expire confirmed booking", func(t *testing.T) {
 		b := newValidBooking()
 		b.Status = BookingStatusConfirmed
@@ -387,6 +402,81 @@ func TestBooking_Expire(t *testing.T) {
 	})
 }
 
+func TestBooking_AwaitPayment(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Booking)
+		wantErr error
+	}{
+		{"reserved", func(b *Booking) {}, nil},
+		{"already pending payment", func(b *Booking) { b.Status = BookingStatusPendingPayment }, nil},
+		{"expired hold", func(b *Booking) { b.ExpiresAt = time.Now().Add(-time.Minute) }, ErrBookingExpired},
+		{"confirmed", func(b *Booking) { b.Status = BookingStatusConfirmed }, ErrInvalidBookingStatus},
+		{"cancelled", func(b *Booking) { b.Status = BookingStatusCancelled }, ErrInvalidBookingStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newValidBooking()
+			tt.modify(b)
+			err := b.AwaitPayment("payment-1")
+			if err != tt.wantErr {
+				t.Fatalf("Booking.AwaitPayment() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (b.Status != BookingStatusPendingPayment || b.PaymentID != "payment-1") {
+				t.Errorf("booking = %s with payment %q, want pending_payment with payment-1", b.Status, b.PaymentID)
+			}
+		})
+	}
+}
+
+func TestBooking_Modify(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(*Booking)
+		zoneID   string
+		quantity int
+		wantErr  error
+	}{
+		{"upgrade reserved booking", func(b *Booking) {}, "zone-vip", 2, nil},
+		{"change quantity of confirmed booking", func(b *Booking) { b.Status = BookingStatusConfirmed }, "zone-abc", 3, nil},
+		{"expired reservation", func(b *Booking) { b.ExpiresAt = time.Now().Add(-time.Minute) }, "zone-vip", 2, ErrBookingExpired},
+		{"cancelled booking", func(b *Booking) { b.Status = BookingStatusCancelled }, "zone-vip", 2, ErrModificationNotAllowed},
+		{"assigned seats", func(b *Booking) { b.SeatLabels = []string{"A-1", "A-2"} }, "zone-vip", 2, ErrAssignedSeatsNotModified},
+		{"unchanged", func(b *Booking) {}, "zone-abc", 2, ErrNoModification},
+		{"zero quantity", func(b *Booking) {}, "zone-abc", 0, ErrInvalidQuantity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newValidBooking()
+			b.UnitPrice = 50.00
+			tt.setup(b)
+
+			err := b.Modify(tt.zoneID, tt.quantity, 50.00)
+			if err != tt.wantErr {
+				t.Fatalf("Booking.Modify() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && b.TotalPrice != 50.00*float64(tt.quantity) {
+				t.Errorf("TotalPrice = %.2f, want %.2f", b.TotalPrice, 50.00*float64(tt.quantity))
+			}
+		})
+	}
+}
+
+func TestBooking_Modify_KeepsAddOns(t *testing.T) {
+	b := newValidBooking()
+	b.UnitPrice = 50.00
+	b.AddOns = []BookingAddOn{{AddOnID: "parking", Name: "Parking", Quantity: 1, UnitPrice: 20.00, TotalPrice: 20.00}}
+
+	if err := b.Modify("zone-vip", 3, 80.00); err != nil {
+		t.Fatalf("Booking.Modify() error = %v", err)
+	}
+	if b.TotalPrice != 3*80.00+20.00 {
+		t.Errorf("TotalPrice = %.2f, want %.2f", b.TotalPrice, 3*80.00+20.00)
+	}
+}
+
 func TestBooking_TimeUntilExpiry(t *testing.T) {
 	b := newValidBooking()
 	b.ExpiresAt = time.Now().Add(5 * time.Minute)
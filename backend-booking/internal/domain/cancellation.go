@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// CancellationTier refunds a share of a paid booking cancelled at least Before the show starts
+type CancellationTier struct {
+	Before        time.Duration `json:"before"`
+	RefundPercent float64       `json:"refund_percent"`
+}
+
+// CancellationPolicy decides how much of a paid booking is refunded when its user cancels it
+type CancellationPolicy struct {
+	// Tiers are matched by notice: the tier with the longest Before the notice meets applies.
+	// A cancellation meeting no tier is not refunded.
+	Tiers []CancellationTier
+	// Fee is a flat processing fee kept from every refund
+	Fee float64
+}
+
+// DefaultCancellationPolicy refunds in full up to a week before the show,
+// half up to two days before and nothing after that
+func DefaultCancellationPolicy() *CancellationPolicy {
+	return &CancellationPolicy{
+		Tiers: []CancellationTier{
+			{Before: 7 * 24 * time.Hour, RefundPercent: 100},
+			{Before: 48 * time.Hour, RefundPercent: 50},
+		},
+	}
+}
+
+// CancellationQuote is the refund a booking gets when cancelled at QuotedAt
+type CancellationQuote struct {
+	BookingID     string
+	TotalPaid     float64    // Zero for bookings that are not paid yet
+	RefundPercent float64    // Share of TotalPaid the matched tier refunds
+	Forfeited     float64    // Part of TotalPaid the tier keeps
+	Fee           float64    // Processing fee kept from the refund
+	RefundAmount  float64    // TotalPaid - Forfeited - Fee, never negative
+	ShowStartsAt  *time.Time // Nil when the booking has no show
+	QuotedAt      time.Time
+}
+
+// Quote prices the cancellation of booking at now. Bookings without a show get the longest
+// notice tier. Only confirmed bookings with a payment are refunded.
+func (p *CancellationPolicy) Quote(booking *Booking, showStartsAt *time.Time, now time.Time) (*CancellationQuote, error) {
+	switch booking.Status {
+	case BookingStatusReserved, BookingStatusPendingPayment, BookingStatusConfirmed:
+	case BookingStatusExpired:
+		return nil, ErrBookingExpired
+	default:
+		return nil, ErrAlreadyReleased
+	}
+	if showStartsAt != nil && !now.Before(*showStartsAt) {
+		return nil, ErrCancellationClosed
+	}
+
+	quote := &CancellationQuote{
+		BookingID:    booking.ID,
+		ShowStartsAt: showStartsAt,
+		QuotedAt:     now,
+	}
+	if !booking.IsConfirmed() || booking.PaymentID == "" {
+		return quote, nil
+	}
+
+	quote.TotalPaid = booking.TotalPrice
+	if tier, ok := p.tierFor(showStartsAt, now); ok {
+		quote.RefundPercent = tier.RefundPercent
+	}
+	refundable := roundAmount(quote.TotalPaid * quote.RefundPercent / 100)
+	quote.Forfeited = roundAmount(quote.TotalPaid - refundable)
+	if refundable > 0 {
+		quote.Fee = math.Min(p.Fee, refundable)
+	}
+	quote.RefundAmount = roundAmount(refundable - quote.Fee)
+	return quote, nil
+}
+
+// tierFor returns the tier with the longest notice met at now
+func (p *CancellationPolicy) tierFor(showStartsAt *time.Time, now time.Time) (CancellationTier, bool) {
+	var (
+		best  CancellationTier
+		found bool
+	)
+	for _, tier := range p.Tiers {
+		if showStartsAt != nil && showStartsAt.Sub(now) < tier.Before {
+			continue
+		}
+		if !found || tier.Before > best.Before {
+			best, found = tier, true
+		}
+	}
+	return best, found
+}
+
+// roundAmount rounds a currency amount to two decimals
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
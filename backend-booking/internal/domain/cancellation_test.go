@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCancellationPolicy_Quote(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	policy := DefaultCancellationPolicy()
+	policy.Fee = 20
+
+	tests := []struct {
+		name        string
+		status      BookingStatus
+		paymentID   string
+		notice      time.Duration
+		wantPercent float64
+		wantFee     float64
+		wantRefund  float64
+	}{
+		{"full refund a week before", BookingStatusConfirmed, "payment-1", 8 * 24 * time.Hour, 100, 20, 980},
+		{"half refund two days before", BookingStatusConfirmed, "payment-1", 72 * time.Hour, 50, 20, 480},
+		{"no refund on the day", BookingStatusConfirmed, "payment-1", 3 * time.Hour, 0, 0, 0},
+		{"unpaid reservation", BookingStatusReserved, "", 8 * 24 * time.Hour, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			booking := &Booking{ID: "booking-1", Status: tt.status, PaymentID: tt.paymentID, TotalPrice: 1000}
+			startsAt := now.Add(tt.notice)
+
+			quote, err := policy.Quote(booking, &startsAt, now)
+			if err != nil {
+				t.Fatalf("Quote() error = %v", err)
+			}
+			if quote.RefundPercent != tt.wantPercent || quote.Fee != tt.wantFee || quote.RefundAmount != tt.wantRefund {
+				t.Errorf("Quote() = %+v, want %.0f%% fee %.2f refund %.2f", quote, tt.wantPercent, tt.wantFee, tt.wantRefund)
+			}
+			if quote.TotalPaid > 0 && quote.Forfeited+quote.Fee+quote.RefundAmount != quote.TotalPaid {
+				t.Errorf("Quote() breakdown does not add up to %.2f: %+v", quote.TotalPaid, quote)
+			}
+		})
+	}
+}
+
+func TestCancellationPolicy_QuoteClosed(t *testing.T) {
+	now := time.Now()
+	started := now.Add(-time.Minute)
+	booking := &Booking{ID: "booking-1", Status: BookingStatusConfirmed, PaymentID: "payment-1", TotalPrice: 1000}
+
+	if _, err := DefaultCancellationPolicy().Quote(booking, &started, now); !errors.Is(err, ErrCancellationClosed) {
+		t.Errorf("Quote() error = %v, want %v", err, ErrCancellationClosed)
+	}
+
+	// Without a show the longest notice tier applies
+	quote, err := DefaultCancellationPolicy().Quote(booking, nil, now)
+	if err != nil || quote.RefundAmount != 1000 {
+		t.Errorf("Quote() without show = %+v, %v, want full refund", quote, err)
+	}
+
+	booking.Status = BookingStatusCancelled
+	if _, err := DefaultCancellationPolicy().Quote(booking, nil, now); !errors.Is(err, ErrAlreadyReleased) {
+		t.Errorf("Quote() error = %v, want %v", err, ErrAlreadyReleased)
+	}
+}
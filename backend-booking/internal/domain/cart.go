@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"time"
+)
+
+// MaxCartItems is the most items a cart can hold
+const MaxCartItems = 10
+
+// CartItem is a zone and quantity the user intends to book
+type CartItem struct {
+	ID        string    `json:"id"`
+	EventID   string    `json:"event_id"`
+	ShowID    string    `json:"show_id,omitempty"`
+	ZoneID    string    `json:"zone_id"`
+	TenantID  string    `json:"tenant_id,omitempty"`
+	Quantity  int       `json:"quantity"`
+	UnitPrice float64   `json:"unit_price"`
+	AddedAt   time.Time `json:"added_at"`
+	// AddOns are event add-ons to reserve with the seats, priced at checkout
+	AddOns []AddOnSelection `json:"add_ons,omitempty"`
+}
+
+// Subtotal returns the item price before checkout, add-ons excluded
+func (i *CartItem) Subtotal() float64 {
+	return i.UnitPrice * float64(i.Quantity)
+}
+
+// Cart holds items across shows and zones until they are checked out together
+type Cart struct {
+	UserID    string      `json:"user_id"`
+	Items     []*CartItem `json:"items"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// FindItem returns the item with the given ID, nil if it is not in the cart
+func (c *Cart) FindItem(itemID string) *CartItem {
+	for _, item := range c.Items {
+		if item.ID == itemID {
+			return item
+		}
+	}
+	return nil
+}
+
+// FindZoneItem returns the item for a show and zone, nil if there is none
+func (c *Cart) FindZoneItem(showID, zoneID string) *CartItem {
+	for _, item := range c.Items {
+		if item.ShowID == showID && item.ZoneID == zoneID {
+			return item
+		}
+	}
+	return nil
+}
+
+// RemoveItems drops items by ID and reports whether any were removed
+func (c *Cart) RemoveItems(itemIDs ...string) bool {
+	remove := make(map[string]bool, len(itemIDs))
+	for _, id := range itemIDs {
+		remove[id] = true
+	}
+	kept := c.Items[:0]
+	for _, item := range c.Items {
+		if !remove[item.ID] {
+			kept = append(kept, item)
+		}
+	}
+	removed := len(kept) != len(c.Items)
+	c.Items = kept
+	return removed
+}
+
+// Total returns the cart price before checkout
+func (c *Cart) Total() float64 {
+	var total float64
+	for _, item := range c.Items {
+		total += item.Subtotal()
+	}
+	return total
+}
+
+// CheckoutStatus represents the status of a cart checkout
+type CheckoutStatus string
+
+const (
+	CheckoutStatusReserved           CheckoutStatus = "reserved"            // All items reserved, awaiting payment
+	CheckoutStatusPartiallyReserved  CheckoutStatus = "partially_reserved"  // Some items reserved, others failed
+	CheckoutStatusFailed             CheckoutStatus = "failed"              // Nothing reserved
+	CheckoutStatusConfirmed          CheckoutStatus = "confirmed"           // All reserved items confirmed
+	CheckoutStatusPartiallyConfirmed CheckoutStatus = "partially_confirmed" // Some reserved items failed to confirm
+	CheckoutStatusCancelled          CheckoutStatus = "cancelled"           // Reserved items released
+)
+
+// String returns the string representation of CheckoutStatus
+func (s CheckoutStatus) String() string {
+	return string(s)
+}
+
+// CheckoutItemStatus represents the outcome of one checkout item
+type CheckoutItemStatus string
+
+const (
+	CheckoutItemStatusReserved  CheckoutItemStatus = "reserved"
+	CheckoutItemStatusConfirmed CheckoutItemStatus = "confirmed"
+	CheckoutItemStatusReleased  CheckoutItemStatus = "released"
+	CheckoutItemStatusFailed    CheckoutItemStatus = "failed"
+	CheckoutItemStatusSkipped   CheckoutItemStatus = "skipped" // Not attempted after another item failed
+)
+
+// String returns the string representation of CheckoutItemStatus
+func (s CheckoutItemStatus) String() string {
+	return string(s)
+}
+
+// CheckoutItem is a cart item with the booking it was reserved as
+type CheckoutItem struct {
+	CartItem
+	BookingID        string             `json:"booking_id,omitempty"`
+	Status           CheckoutItemStatus `json:"status"`
+	TotalPrice       float64            `json:"total_price"`
+	Seats            []string           `json:"seats,omitempty"`
+	ConfirmationCode string             `json:"confirmation_code,omitempty"`
+	ErrorCode        string             `json:"error_code,omitempty"`
+	ErrorMessage     string             `json:"error_message,omitempty"`
+}
+
+// Checkout groups the bookings reserved from one cart so they are paid with a single
+// payment (created with the checkout ID as its booking reference) and confirmed together
+type Checkout struct {
+	ID         string          `json:"id"`
+	UserID     string          `json:"user_id"`
+	Status     CheckoutStatus  `json:"status"`
+	Items      []*CheckoutItem `json:"items"`
+	TotalPrice float64         `json:"total_price"` // Sum of reserved items, the amount to pay
+	PaymentID  string          `json:"payment_id,omitempty"`
+	ExpiresAt  time.Time       `json:"expires_at"` // Earliest reservation expiry
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+}
+
+// ReservedItems returns items holding a reservation that is not confirmed yet
+func (c *Checkout) ReservedItems() []*CheckoutItem {
+	var items []*CheckoutItem
+	for _, item := range c.Items {
+		if item.Status == CheckoutItemStatusReserved {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// CountItems returns how many items have the given status
+func (c *Checkout) CountItems(status CheckoutItemStatus) int {
+	n := 0
+	for _, item := range c.Items {
+		if item.Status == status {
+			n++
+		}
+	}
+	return n
+}
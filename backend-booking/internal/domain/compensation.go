@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"time"
+)
+
+// CompensationTrigger represents how a compensation run was started
+type CompensationTrigger string
+
+const (
+	CompensationTriggerAutomatic CompensationTrigger = "automatic" // Started by the saga orchestrator
+	CompensationTriggerManual    CompensationTrigger = "manual"    // Started by an operator via admin API
+)
+
+// CompensationStatus represents the result of a compensation run
+type CompensationStatus string
+
+const (
+	CompensationStatusTriggered CompensationStatus = "triggered" // Commands dispatched, waiting for workers
+	CompensationStatusCompleted CompensationStatus = "completed" // All steps dispatched successfully
+	CompensationStatusPartial   CompensationStatus = "partial"   // Some steps failed to dispatch
+	CompensationStatusFailed    CompensationStatus = "failed"    // No step could be dispatched
+)
+
+// IsValid checks if the status is a valid CompensationStatus
+func (s CompensationStatus) IsValid() bool {
+	switch s {
+	case CompensationStatusTriggered, CompensationStatusCompleted, CompensationStatusPartial, CompensationStatusFailed:
+		return true
+	}
+	return false
+}
+
+// String returns the string representation of CompensationStatus
+func (s CompensationStatus) String() string {
+	return string(s)
+}
+
+// CompensationStep records the outcome of a single compensated saga step
+type CompensationStep struct {
+	StepName     string    `json:"step_name"`    // Original step being compensated (e.g. process-payment)
+	Compensation string    `json:"compensation"` // Compensating action (e.g. refund-payment)
+	Success      bool      `json:"success"`
+	Error        string    `json:"error,omitempty"`
+	ExecutedAt   time.Time `json:"executed_at"`
+}
+
+// Compensation is an audit record for one compensation run of a saga or booking
+type Compensation struct {
+	ID          string              `json:"id"`
+	SagaID      string              `json:"saga_id,omitempty"`
+	BookingID   string              `json:"booking_id,omitempty"`
+	Trigger     CompensationTrigger `json:"trigger"`
+	RequestedBy string              `json:"requested_by,omitempty"`
+	Reason      string              `json:"reason"`
+	Steps       []CompensationStep  `json:"steps"`
+	Status      CompensationStatus  `json:"status"`
+	Error       string              `json:"error,omitempty"`
+	CreatedAt   time.Time           `json:"created_at"`
+	CompletedAt *time.Time          `json:"completed_at,omitempty"`
+}
+
+// NewCompensation creates a new compensation record in triggered state
+func NewCompensation(sagaID, bookingID string, trigger CompensationTrigger, reason string) *Compensation {
+	return &Compensation{
+		SagaID:    sagaID,
+		BookingID: bookingID,
+		Trigger:   trigger,
+		Reason:    reason,
+		Steps:     []CompensationStep{},
+		Status:    CompensationStatusTriggered,
+		CreatedAt: time.Now(),
+	}
+}
+
+// AddStep appends a step outcome to the compensation record
+func (c *Compensation) AddStep(stepName, compensation string, err error) {
+	step := CompensationStep{
+		StepName:     stepName,
+		Compensation: compensation,
+		Success:      err == nil,
+		ExecutedAt:   time.Now(),
+	}
+	if err != nil {
+		step.Error = err.Error()
+	}
+	c.Steps = append(c.Steps, step)
+}
+
+// Finish derives the final status from the recorded steps
+func (c *Compensation) Finish() {
+	now := time.Now()
+	c.CompletedAt = &now
+
+	failed := 0
+	for _, step := range c.Steps {
+		if !step.Success {
+			failed++
+		}
+	}
+
+	switch {
+	case failed == 0:
+		c.Status = CompensationStatusCompleted
+	case failed == len(c.Steps):
+		c.Status = CompensationStatusFailed
+	default:
+		c.Status = CompensationStatusPartial
+	}
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCompensationStatus_IsValid(t *testing.T) {
+	tests := []struct {
+		name   string
+		status CompensationStatus
+		want   bool
+	}{
+		{"triggered is valid", CompensationStatusTriggered, true},
+		{"completed is valid", CompensationStatusCompleted, true},
+		{"partial is valid", CompensationStatusPartial, true},
+		{"failed is valid", CompensationStatusFailed, true},
+		{"unknown is invalid", CompensationStatus("unknown"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.status.IsValid(); got != tt.want {
+				t.Errorf("CompensationStatus.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewCompensation(t *testing.T) {
+	c := NewCompensation("saga-1", "booking-1", CompensationTriggerManual, "show cancelled")
+
+	if c.SagaID != "saga-1" || c.BookingID != "booking-1" {
+		t.Errorf("unexpected ids: saga=%s booking=%s", c.SagaID, c.BookingID)
+	}
+	if c.Trigger != CompensationTriggerManual {
+		t.Errorf("Trigger = %v, want %v", c.Trigger, CompensationTriggerManual)
+	}
+	if c.Status != CompensationStatusTriggered {
+		t.Errorf("Status = %v, want %v", c.Status, CompensationStatusTriggered)
+	}
+	if c.Steps == nil || len(c.Steps) != 0 {
+		t.Errorf("Steps should be an empty slice, got %v", c.Steps)
+	}
+	if c.CreatedAt.IsZero() {
+		t.Error("CreatedAt should be set")
+	}
+}
+
+func TestCompensation_Finish(t *testing.T) {
+	tests := []struct {
+		name  string
+		steps []error
+		want  CompensationStatus
+	}{
+		{"no steps", nil, CompensationStatusCompleted},
+		{"all succeeded", []error{nil, nil}, CompensationStatusCompleted},
+		{"some failed", []error{errors.New("kafka down"), nil}, CompensationStatusPartial},
+		{"all failed", []error{errors.New("kafka down"), errors.New("kafka down")}, CompensationStatusFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCompensation("saga-1", "booking-1", CompensationTriggerAutomatic, "step failed")
+			for _, err := range tt.steps {
+				c.AddStep("process-payment", "refund-payment", err)
+			}
+			c.Finish()
+
+			if c.Status != tt.want {
+				t.Errorf("Status = %v, want %v", c.Status, tt.want)
+			}
+			if c.CompletedAt == nil {
+				t.Error("CompletedAt should be set")
+			}
+		})
+	}
+}
+
+func TestCompensation_AddStep(t *testing.T) {
+	c := NewCompensation("saga-1", "booking-1", CompensationTriggerAutomatic, "step failed")
+	c.AddStep("process-payment", "refund-payment", errors.New("kafka down"))
+
+	if len(c.Steps) != 1 {
+		t.Fatalf("expected 1 step, got %d", len(c.Steps))
+	}
+	step := c.Steps[0]
+	if step.Success {
+		t.Error("step should not be successful")
+	}
+	if step.Error != "kafka down" {
+		t.Errorf("Error = %q, want %q", step.Error, "kafka down")
+	}
+	if step.Compensation != "refund-payment" {
+		t.Errorf("Compensation = %q, want %q", step.Compensation, "refund-payment")
+	}
+}
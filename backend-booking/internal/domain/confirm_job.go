@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"time"
+)
+
+// ConfirmJobStatus represents the state of an asynchronous booking confirmation
+type ConfirmJobStatus string
+
+const (
+	ConfirmJobStatusPending    ConfirmJobStatus = "pending"    // Waiting in the confirm queue
+	ConfirmJobStatusProcessing ConfirmJobStatus = "processing" // Picked up by a confirm worker
+	ConfirmJobStatusSucceeded  ConfirmJobStatus = "succeeded"  // Booking confirmed
+	ConfirmJobStatusFailed     ConfirmJobStatus = "failed"     // Confirmation rejected or gave up
+)
+
+// String returns the string representation of ConfirmJobStatus
+func (s ConfirmJobStatus) String() string {
+	return string(s)
+}
+
+// ConfirmJob is a queued booking confirmation. There is at most one job per booking;
+// the token lets clients tell a resubmitted job from an earlier one.
+type ConfirmJob struct {
+	Token            string           `json:"token"`
+	BookingID        string           `json:"booking_id"`
+	UserID           string           `json:"user_id"`
+	TenantID         string           `json:"tenant_id,omitempty"`
+	PaymentID        string           `json:"payment_id,omitempty"`
+	Status           ConfirmJobStatus `json:"status"`
+	Attempts         int              `json:"attempts"`
+	ConfirmationCode string           `json:"confirmation_code,omitempty"`
+	ConfirmedAt      *time.Time       `json:"confirmed_at,omitempty"`
+	ErrorCode        string           `json:"error_code,omitempty"`
+	ErrorMessage     string           `json:"error_message,omitempty"`
+	CreatedAt        time.Time        `json:"created_at"`
+	UpdatedAt        time.Time        `json:"updated_at"`
+}
+
+// IsTerminal checks if the job will not change anymore
+func (j *ConfirmJob) IsTerminal() bool {
+	return j.Status == ConfirmJobStatusSucceeded || j.Status == ConfirmJobStatusFailed
+}
+
+// IsStale checks if a non-terminal job has not progressed for longer than staleAfter,
+// e.g. because the worker holding it crashed
+func (j *ConfirmJob) IsStale(staleAfter time.Duration) bool {
+	return !j.IsTerminal() && time.Since(j.UpdatedAt) > staleAfter
+}
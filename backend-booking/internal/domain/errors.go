@@ -9,6 +9,7 @@ var (
 	ErrBookingExpired       = errors.New("booking has expired")
 	ErrBookingAlreadyExists = errors.New("booking already exists")
 	ErrInvalidBookingStatus = errors.New("invalid booking status")
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used for another request")
 
 	// Reservation errors
 	ErrReservationNotFound = errors.New("reservation not found")
@@ -25,28 +26,128 @@ var (
 	ErrInvalidQuantity   = errors.New("quantity must be greater than zero")
 	ErrInvalidTotalPrice = errors.New("total price cannot be negative")
 	ErrInvalidUnitPrice  = errors.New("unit price cannot be negative")
+	ErrInvalidTenantID   = errors.New("invalid tenant id")
+	ErrInvalidPaymentID  = errors.New("invalid payment id")
 
 	// Availability errors
 	ErrInsufficientSeats  = errors.New("insufficient seats available")
 	ErrMaxTicketsExceeded = errors.New("maximum tickets per user exceeded")
 
 	// Zone errors
-	ErrZoneNotFound = errors.New("zone not found")
+	ErrZoneNotFound       = errors.New("zone not found")
+	ErrInvalidShardCount  = errors.New("zone shard count must be between 1 and 64")
+	ErrShardLayoutChanged = errors.New("zone shard layout changed concurrently, please retry")
+
+	// Seat map errors
+	ErrSeatMapNotFound        = errors.New("zone has no seat map")
+	ErrInvalidSeatMap         = errors.New("seat map needs 1-500 rows with unique labels of up to 8 characters, 1-1000 seats each and aisles within the row")
+	ErrSeatMapInUse           = errors.New("seat map cannot change while seats are held")
+	ErrNoContiguousSeats      = errors.New("no contiguous block of seats is available")
+	ErrSeatAllocationConflict = errors.New("seats were taken concurrently, please retry")
+
+	// Modification errors
+	ErrModificationNotAllowed   = errors.New("only reserved or confirmed bookings can be modified")
+	ErrNoModification           = errors.New("zone_id or quantity must differ from the booking")
+	ErrShowStarted              = errors.New("booking cannot be modified once the show has started")
+	ErrAssignedSeatsNotModified = errors.New("bookings with assigned seats cannot be modified")
+	ErrModificationInProgress   = errors.New("another change to this booking is in progress")
+	ErrPaymentAdjustmentFailed  = errors.New("price difference could not be settled with payment service")
+
+	// Cancellation errors
+	ErrCancellationClosed       = errors.New("booking cannot be cancelled once the show has started")
+	ErrInvalidCancellationQuote = errors.New("cancellation quote is invalid or was issued for another booking state")
+	ErrCancellationQuoteExpired = errors.New("cancellation quote has expired")
+	ErrCancellationRefundFailed = errors.New("refund could not be issued by payment service")
+
+	// Idle reservation release errors
+	ErrInvalidReleaseLink  = errors.New("release link is malformed or its signature is invalid")
+	ErrReleaseLinkExpired  = errors.New("release link has expired")
+	ErrReleaseLinkOutdated = errors.New("release link was issued for another booking state")
+	ErrPaymentInProgress   = errors.New("a payment was started for this booking")
 
 	// Event errors
-	ErrEventNotFound = errors.New("event not found")
+	ErrEventNotFound          = errors.New("event not found")
+	ErrEventAccessDenied      = errors.New("caller may not manage this event")
+	ErrEventAccessCheckFailed = errors.New("ticket service could not check event access")
+
+	// Compensation errors
+	ErrCompensationNotFound   = errors.New("compensation not found")
+	ErrCompensationNotAllowed = errors.New("booking cannot be compensated in its current status")
+
+	// Refund batch errors
+	ErrRefundBatchNotFound     = errors.New("refund batch not found")
+	ErrShowNotFound            = errors.New("show not found")
+	ErrShowCancelFailed        = errors.New("ticket service could not cancel the show")
+	ErrInvalidRefundItemStatus = errors.New("refund status must be pending, requested, refunded, failed or skipped")
+
+	// Webhook errors
+	ErrWebhookSubscriptionNotFound = errors.New("webhook subscription not found")
+	ErrWebhookDeliveryNotFound     = errors.New("webhook delivery not found")
+	ErrWebhookDeliveryNotRetryable = errors.New("only failed webhook deliveries can be retried")
+	ErrInvalidWebhookURL           = errors.New("webhook url must be an absolute http or https url")
+	ErrInvalidWebhookEventType     = errors.New("webhook event types must be one or more of booking.confirmed, booking.cancelled, booking.confirm_failed, payment.refunded")
+	ErrInvalidWebhookStatus        = errors.New("webhook delivery status must be pending, delivered or failed")
+
+	// Report schedule errors
+	ErrReportScheduleNotFound = errors.New("report schedule not found")
+	ErrInvalidReportSchedule  = errors.New("report schedule needs an email, a frequency of daily or weekly, a send_hour of 0-23 and a weekday of 0-6")
+	ErrInvalidSnapshotRange   = errors.New("from and to must be RFC 3339 times with from before to, at most 92 days apart")
+
+	// Saga stats errors
+	ErrSagaStatsUnavailable = errors.New("saga stats are not available")
+
+	// Saga step retry errors
+	ErrInvalidSagaStep          = errors.New("step must be reserve-seats, process-payment, confirm-booking or send-notification")
+	ErrSagaStepNotDeadLettered  = errors.New("saga step has no dead-lettered command to retry")
+	ErrSagaStepRetryNotAllowed  = errors.New("steps of a compensating or compensated saga cannot be retried")
+	ErrSagaStepRetryUnavailable = errors.New("saga step retries are not available")
+
+	// Async confirm errors
+	ErrConfirmJobNotFound = errors.New("no confirmation in progress for this booking")
+
+	// Cart errors
+	ErrCartEmpty          = errors.New("cart is empty")
+	ErrCartFull           = errors.New("cart can hold at most 10 items")
+	ErrCartItemNotFound   = errors.New("cart item not found")
+	ErrCheckoutNotFound   = errors.New("checkout not found")
+	ErrCheckoutNotPending = errors.New("checkout has no reserved items left")
+
+	// Booking search errors
+	ErrSearchFilterRequired    = errors.New("at least one of email, confirmation_code, payment_id or last4 is required")
+	ErrInvalidCardLastFour     = errors.New("last4 must be 4 digits")
+	ErrSearchLookupUnavailable = errors.New("user and payment lookups are not configured")
+
+	// Add-on errors
+	ErrAddOnNotFound        = errors.New("add-on is not on sale for this event")
+	ErrInvalidAddOnQuantity = errors.New("add-on quantity must be between 1 and 10")
+	ErrAddOnLimitExceeded   = errors.New("add-on quantity exceeds its per-booking limit")
+	ErrAddOnSoldOut         = errors.New("add-on is sold out")
+
+	// Standby errors
+	ErrAlreadyOnStandby     = errors.New("user is already on the standby list for this zone")
+	ErrNotOnStandby         = errors.New("user is not on the standby list for this zone")
+	ErrStandbyOfferMismatch = errors.New("request does not match the seats held from the standby list")
+
+	// Verification errors
+	ErrAccountNotVerified            = errors.New("event requires a verified account")
+	ErrVerificationChallengeRequired = errors.New("a recent verification challenge is required to book")
 
 	// Queue errors
-	ErrQueueNotOpen          = errors.New("queue is not open for this event")
-	ErrAlreadyInQueue        = errors.New("user is already in queue")
-	ErrNotInQueue            = errors.New("user is not in queue")
-	ErrQueueFull             = errors.New("queue is full")
-	ErrInvalidQueueToken     = errors.New("invalid queue token")
-	ErrQueuePassRequired     = errors.New("queue pass is required")
-	ErrInvalidQueuePass      = errors.New("invalid queue pass")
-	ErrQueuePassExpired      = errors.New("queue pass has expired or already used")
-	ErrQueuePassUserMismatch = errors.New("queue pass does not belong to this user")
+	ErrQueueNotOpen           = errors.New("queue is not open for this event")
+	ErrAlreadyInQueue         = errors.New("user is already in queue")
+	ErrNotInQueue             = errors.New("user is not in queue")
+	ErrQueueFull              = errors.New("queue is full")
+	ErrInvalidQueueToken      = errors.New("invalid queue token")
+	ErrQueuePassRequired      = errors.New("queue pass is required")
+	ErrInvalidQueuePass       = errors.New("invalid queue pass")
+	ErrQueuePassExpired       = errors.New("queue pass has expired or already used")
+	ErrQueuePassUserMismatch  = errors.New("queue pass does not belong to this user")
 	ErrQueuePassEventMismatch = errors.New("queue pass is for a different event")
+	ErrQueuePassZoneMismatch  = errors.New("queue pass does not cover this zone")
+	ErrQueuePassRevoked       = errors.New("queue pass has been revoked")
+
+	// Queue analytics errors
+	ErrInvalidQueueAnalyticsRange = errors.New("window must be at most 24h and interval a whole number of minutes no longer than the window")
 )
 
 // IsNotFoundError checks if the error is a not found error
@@ -54,7 +155,18 @@ func IsNotFoundError(err error) bool {
 	return errors.Is(err, ErrBookingNotFound) ||
 		errors.Is(err, ErrReservationNotFound) ||
 		errors.Is(err, ErrZoneNotFound) ||
-		errors.Is(err, ErrEventNotFound)
+		errors.Is(err, ErrEventNotFound) ||
+		errors.Is(err, ErrCompensationNotFound) ||
+		errors.Is(err, ErrRefundBatchNotFound) ||
+		errors.Is(err, ErrShowNotFound) ||
+		errors.Is(err, ErrNotOnStandby) ||
+		errors.Is(err, ErrWebhookSubscriptionNotFound) ||
+		errors.Is(err, ErrWebhookDeliveryNotFound) ||
+		errors.Is(err, ErrSeatMapNotFound) ||
+		errors.Is(err, ErrConfirmJobNotFound) ||
+		errors.Is(err, ErrCartItemNotFound) ||
+		errors.Is(err, ErrCheckoutNotFound) ||
+		errors.Is(err, ErrReportScheduleNotFound)
 }
 
 // IsValidationError checks if the error is a validation error
@@ -67,7 +179,24 @@ func IsValidationError(err error) bool {
 		errors.Is(err, ErrInvalidQuantity) ||
 		errors.Is(err, ErrInvalidTotalPrice) ||
 		errors.Is(err, ErrInvalidUnitPrice) ||
-		errors.Is(err, ErrInvalidBookingStatus)
+		errors.Is(err, ErrInvalidBookingStatus) ||
+		errors.Is(err, ErrInvalidTenantID) ||
+		errors.Is(err, ErrInvalidWebhookURL) ||
+		errors.Is(err, ErrInvalidWebhookEventType) ||
+		errors.Is(err, ErrInvalidWebhookStatus) ||
+		errors.Is(err, ErrInvalidRefundItemStatus) ||
+		errors.Is(err, ErrInvalidReportSchedule) ||
+		errors.Is(err, ErrInvalidSnapshotRange) ||
+		errors.Is(err, ErrInvalidSeatMap) ||
+		errors.Is(err, ErrInvalidShardCount) ||
+		errors.Is(err, ErrCartEmpty) ||
+		errors.Is(err, ErrCartFull) ||
+		errors.Is(err, ErrSearchFilterRequired) ||
+		errors.Is(err, ErrInvalidCardLastFour) ||
+		errors.Is(err, ErrNoModification) ||
+		errors.Is(err, ErrAddOnNotFound) ||
+		errors.Is(err, ErrInvalidAddOnQuantity) ||
+		errors.Is(err, ErrAddOnLimitExceeded)
 }
 
 // IsConflictError checks if the error is a conflict error
@@ -75,12 +204,29 @@ func IsConflictError(err error) bool {
 	return errors.Is(err, ErrAlreadyConfirmed) ||
 		errors.Is(err, ErrAlreadyReleased) ||
 		errors.Is(err, ErrBookingAlreadyExists) ||
+		errors.Is(err, ErrIdempotencyKeyReused) ||
 		errors.Is(err, ErrInsufficientSeats) ||
-		errors.Is(err, ErrMaxTicketsExceeded)
+		errors.Is(err, ErrMaxTicketsExceeded) ||
+		errors.Is(err, ErrCompensationNotAllowed) ||
+		errors.Is(err, ErrAlreadyOnStandby) ||
+		errors.Is(err, ErrWebhookDeliveryNotRetryable) ||
+		errors.Is(err, ErrSeatMapInUse) ||
+		errors.Is(err, ErrNoContiguousSeats) ||
+		errors.Is(err, ErrSeatAllocationConflict) ||
+		errors.Is(err, ErrShardLayoutChanged) ||
+		errors.Is(err, ErrCheckoutNotPending) ||
+		errors.Is(err, ErrModificationNotAllowed) ||
+		errors.Is(err, ErrShowStarted) ||
+		errors.Is(err, ErrAssignedSeatsNotModified) ||
+		errors.Is(err, ErrModificationInProgress) ||
+		errors.Is(err, ErrCancellationClosed) ||
+		errors.Is(err, ErrInvalidCancellationQuote) ||
+		errors.Is(err, ErrAddOnSoldOut)
 }
 
 // IsExpiredError checks if the error is an expiration error
 func IsExpiredError(err error) bool {
 	return errors.Is(err, ErrBookingExpired) ||
-		errors.Is(err, ErrReservationExpired)
+		errors.Is(err, ErrReservationExpired) ||
+		errors.Is(err, ErrCancellationQuoteExpired)
 }
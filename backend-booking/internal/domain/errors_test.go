@@ -15,6 +15,9 @@ func TestIsNotFoundError(t *testing.T) {
 		{"reservation not found", ErrReservationNotFound, true},
 		{"zone not found", ErrZoneNotFound, true},
 		{"event not found", ErrEventNotFound, true},
+		{"webhook subscription not found", ErrWebhookSubscriptionNotFound, true},
+		{"webhook delivery not found", ErrWebhookDeliveryNotFound, true},
+		{"seat map not found", ErrSeatMapNotFound, true},
 		{"insufficient seats", ErrInsufficientSeats, false},
 		{"invalid user id", ErrInvalidUserID, false},
 		{"nil error", nil, false},
@@ -44,6 +47,10 @@ func TestIsValidationError(t *testing.T) {
 		{"invalid total price", ErrInvalidTotalPrice, true},
 		{"invalid unit price", ErrInvalidUnitPrice, true},
 		{"invalid booking status", ErrInvalidBookingStatus, true},
+		{"invalid webhook url", ErrInvalidWebhookURL, true},
+		{"invalid webhook event type", ErrInvalidWebhookEventType, true},
+		{"invalid webhook status", ErrInvalidWebhookStatus, true},
+		{"invalid seat map", ErrInvalidSeatMap, true},
 		{"booking not found", ErrBookingNotFound, false},
 		{"insufficient seats", ErrInsufficientSeats, false},
 		{"nil error", nil, false},
@@ -70,6 +77,10 @@ func TestIsConflictError(t *testing.T) {
 		{"booking already exists", ErrBookingAlreadyExists, true},
 		{"insufficient seats", ErrInsufficientSeats, true},
 		{"max tickets exceeded", ErrMaxTicketsExceeded, true},
+		{"seat map in use", ErrSeatMapInUse, true},
+		{"no contiguous seats", ErrNoContiguousSeats, true},
+		{"seat allocation conflict", ErrSeatAllocationConflict, true},
+		{"cancellation closed", ErrCancellationClosed, true},
 		{"booking not found", ErrBookingNotFound, false},
 		{"invalid user id", ErrInvalidUserID, false},
 		{"nil error", nil, false},
@@ -93,6 +104,7 @@ func TestIsExpiredError(t *testing.T) {
 	}{
 		{"booking expired", ErrBookingExpired, true},
 		{"reservation expired", ErrReservationExpired, true},
+		{"cancellation quote expired", ErrCancellationQuoteExpired, true},
 		{"booking not found", ErrBookingNotFound, false},
 		{"insufficient seats", ErrInsufficientSeats, false},
 		{"nil error", nil, false},
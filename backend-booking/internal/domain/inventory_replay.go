@@ -0,0 +1,249 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// Inventory rebuild actions, what a rebuild from events does to a zone's Redis counter
+const (
+	InventoryRebuildUnchanged = "unchanged" // The counter already holds the replayed availability
+	InventoryRebuildSet       = "set"       // The counter is overwritten with the replayed availability
+	InventoryRebuildRemove    = "remove"    // The zone was deactivated, its counter is dropped
+	InventoryRebuildSkip      = "skip"      // The events retained do not establish the zone's availability
+)
+
+// ReplayedZone is a zone's availability as of the last event replayed
+type ReplayedZone struct {
+	ZoneID    string
+	Available int64
+	// Baselined is set once a zone.created or zone.updated event gave the zone's
+	// availability; without it Available only sums the booking events seen
+	Baselined      bool
+	Removed        bool // Deactivated by the last zone event
+	HoldTTLSeconds *int
+}
+
+// InventoryReplay folds zone lifecycle, capacity and booking events, oldest first,
+// into each zone's available seats the way the inventory worker and reservation
+// scripts move the Redis counters. Redelivered events are applied once.
+type InventoryReplay struct {
+	zones       map[string]*ReplayedZone
+	seen        map[string]struct{}
+	applied     int
+	duplicates  int
+	rejected    int
+	lastApplied time.Time
+}
+
+// NewInventoryReplay creates an empty inventory replay
+func NewInventoryReplay() *InventoryReplay {
+	return &InventoryReplay{
+		zones: make(map[string]*ReplayedZone),
+		seen:  make(map[string]struct{}),
+	}
+}
+
+// ApplyZoneEvent applies a zone.created or zone.updated event. A zone not on sale
+// takes the event's availability; one on sale keeps its counter, which may have
+// holds, unless no counter exists yet.
+func (r *InventoryReplay) ApplyZoneEvent(event *ZoneEvent) bool {
+	if event.ZoneID == "" || !r.firstSeen(event.EventID) {
+		return false
+	}
+
+	zone := r.zone(event.ZoneID)
+	if !event.IsActive {
+		zone.Removed = true
+		zone.Baselined = true
+		zone.Available = 0
+		return r.apply(event.OccurredAt)
+	}
+
+	if !event.OnSale || !zone.Baselined || zone.Removed {
+		zone.Available = int64(event.AvailableSeats)
+	}
+	zone.Baselined = true
+	zone.Removed = false
+	zone.HoldTTLSeconds = event.HoldTTLSeconds
+	return r.apply(event.OccurredAt)
+}
+
+// ApplyCapacityChange applies a zone capacity change. A reduction larger than the
+// seats left is rejected, as the capacity script rejects it.
+func (r *InventoryReplay) ApplyCapacityChange(event *ZoneCapacityChangedEvent) bool {
+	if event.ZoneID == "" || !r.firstSeen(event.EventID) {
+		return false
+	}
+
+	zone := r.zone(event.ZoneID)
+	if zone.Removed {
+		// No counter to change; seat_zones alone takes the new capacity
+		return r.apply(event.RequestedAt)
+	}
+	if zone.Baselined && zone.Available+int64(event.Delta()) < 0 {
+		r.rejected++
+		return false
+	}
+	zone.Available += int64(event.Delta())
+	return r.apply(event.RequestedAt)
+}
+
+// ApplyBookingEvent applies a booking event: reservations take seats, cancellations
+// and expiries give them back, and modifications move them between zones
+func (r *InventoryReplay) ApplyBookingEvent(event *BookingEvent) bool {
+	if event.BookingData == nil || !r.firstSeen(event.EventID) {
+		return false
+	}
+
+	data := event.BookingData
+	quantity := int64(data.Quantity)
+	switch event.EventType {
+	case BookingEventCreated:
+		r.zone(data.ZoneID).Available -= quantity
+	case BookingEventCancelled, BookingEventExpired:
+		r.zone(data.ZoneID).Available += quantity
+	case BookingEventModified:
+		r.zone(data.PreviousZoneID).Available += int64(data.PreviousQuantity)
+		r.zone(data.ZoneID).Available -= quantity
+	case BookingEventConfirmed:
+		// Confirmed seats were already taken by the reservation
+	default:
+		return false
+	}
+	return r.apply(event.OccurredAt)
+}
+
+// Zones returns the replayed zones ordered by zone ID
+func (r *InventoryReplay) Zones() []*ReplayedZone {
+	zones := make([]*ReplayedZone, 0, len(r.zones))
+	for _, zone := range r.zones {
+		if zone.ZoneID != "" {
+			zones = append(zones, zone)
+		}
+	}
+	sort.Slice(zones, func(i, j int) bool { return zones[i].ZoneID < zones[j].ZoneID })
+	return zones
+}
+
+// Zone returns a replayed zone, or nil if no event touched it
+func (r *InventoryReplay) Zone(zoneID string) *ReplayedZone {
+	return r.zones[zoneID]
+}
+
+// Applied returns the number of events applied
+func (r *InventoryReplay) Applied() int {
+	return r.applied
+}
+
+// Duplicates returns the number of redelivered events skipped
+func (r *InventoryReplay) Duplicates() int {
+	return r.duplicates
+}
+
+// Rejected returns the number of capacity changes rejected
+func (r *InventoryReplay) Rejected() int {
+	return r.rejected
+}
+
+// LastApplied returns when the latest event applied occurred
+func (r *InventoryReplay) LastApplied() time.Time {
+	return r.lastApplied
+}
+
+// firstSeen records an event ID, reporting false for one already applied.
+// Events without an ID cannot be told apart and are always applied.
+func (r *InventoryReplay) firstSeen(eventID string) bool {
+	if eventID == "" {
+		return true
+	}
+	if _, ok := r.seen[eventID]; ok {
+		r.duplicates++
+		return false
+	}
+	r.seen[eventID] = struct{}{}
+	return true
+}
+
+func (r *InventoryReplay) apply(occurredAt time.Time) bool {
+	r.applied++
+	if occurredAt.After(r.lastApplied) {
+		r.lastApplied = occurredAt
+	}
+	return true
+}
+
+func (r *InventoryReplay) zone(zoneID string) *ReplayedZone {
+	zone, ok := r.zones[zoneID]
+	if !ok {
+		zone = &ReplayedZone{ZoneID: zoneID}
+		r.zones[zoneID] = zone
+	}
+	return zone
+}
+
+// InventoryRebuildZone is the rebuild of one zone's Redis counter
+type InventoryRebuildZone struct {
+	ZoneID string `json:"zone_id"`
+	Action string `json:"action"`
+	// Replayed is the availability at the target time (nil for removed or skipped zones)
+	Replayed *int64 `json:"replayed"`
+	// Redis is the counter before the rebuild (nil if the zone is not live)
+	Redis *int64 `json:"redis"`
+	// Database is seat_zones.available_seats, for comparison (nil if the zone has no row)
+	Database *int64 `json:"database"`
+	// Diff is Replayed minus Redis, for zones live in both
+	Diff   *int64 `json:"diff,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// InventoryRebuildReport is the outcome of a rebuild of Redis counters from events
+type InventoryRebuildReport struct {
+	Until  time.Time `json:"until,omitempty"`
+	DryRun bool      `json:"dry_run"`
+	// Records is the number of Kafka records read; Events the events applied from them
+	Records    int `json:"records"`
+	Events     int `json:"events"`
+	Duplicates int `json:"duplicates"`
+	Rejected   int `json:"rejected_capacity_changes"`
+	Undecoded  int `json:"undecoded"`
+	// LastEvent is when the latest event applied occurred
+	LastEvent time.Time              `json:"last_event,omitempty"`
+	Zones     []InventoryRebuildZone `json:"zones"`
+	// Changed counts zones set or removed (or that would be, on a dry run)
+	Changed int `json:"changed"`
+	// Unfinished names partitions whose replay stopped short of the target time
+	Unfinished []string `json:"unfinished,omitempty"`
+}
+
+// PlanInventoryRebuild decides what rebuilding a replayed zone does to its counter,
+// given the counter and the seat_zones availability as they are (nil when absent)
+func PlanInventoryRebuild(zone *ReplayedZone, redis, database *int64) InventoryRebuildZone {
+	plan := InventoryRebuildZone{ZoneID: zone.ZoneID, Redis: redis, Database: database}
+	switch {
+	case !zone.Baselined:
+		plan.Action = InventoryRebuildSkip
+		plan.Reason = "no zone.created or zone.updated event retained before the target time"
+	case zone.Removed && redis == nil:
+		plan.Action = InventoryRebuildUnchanged
+	case zone.Removed:
+		plan.Action = InventoryRebuildRemove
+	case zone.Available < 0:
+		plan.Action = InventoryRebuildSkip
+		plan.Reason = "replayed availability is negative, events are missing"
+		available := zone.Available
+		plan.Replayed = &available
+	default:
+		available := zone.Available
+		plan.Replayed = &available
+		plan.Action = InventoryRebuildSet
+		if redis != nil {
+			diff := available - *redis
+			plan.Diff = &diff
+			if diff == 0 {
+				plan.Action = InventoryRebuildUnchanged
+			}
+		}
+	}
+	return plan
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func bookingEvent(id string, eventType BookingEventType, zoneID string, quantity int) *BookingEvent {
+	return &BookingEvent{
+		EventID:     id,
+		EventType:   eventType,
+		BookingData: &BookingEventData{ZoneID: zoneID, Quantity: quantity},
+	}
+}
+
+func TestInventoryReplay(t *testing.T) {
+	replay := NewInventoryReplay()
+	replay.ApplyZoneEvent(&ZoneEvent{EventID: "z1", EventType: ZoneCreatedEventType, ZoneID: "zone-1", AvailableSeats: 100, IsActive: true})
+	replay.ApplyZoneEvent(&ZoneEvent{EventID: "z2", EventType: ZoneCreatedEventType, ZoneID: "zone-2", AvailableSeats: 50, IsActive: true})
+
+	replay.ApplyBookingEvent(bookingEvent("b1", BookingEventCreated, "zone-1", 4))
+	replay.ApplyBookingEvent(bookingEvent("b1", BookingEventCreated, "zone-1", 4)) // Redelivered
+	replay.ApplyBookingEvent(bookingEvent("b2", BookingEventConfirmed, "zone-1", 4))
+	replay.ApplyBookingEvent(bookingEvent("b3", BookingEventCreated, "zone-1", 2))
+	replay.ApplyBookingEvent(bookingEvent("b4", BookingEventExpired, "zone-1", 2))
+	replay.ApplyBookingEvent(&BookingEvent{
+		EventID:     "b5",
+		EventType:   BookingEventModified,
+		BookingData: &BookingEventData{ZoneID: "zone-2", Quantity: 3, PreviousZoneID: "zone-1", PreviousQuantity: 4},
+	})
+
+	// An on-sale update keeps the live counter, which has the holds
+	replay.ApplyZoneEvent(&ZoneEvent{EventID: "z3", EventType: ZoneUpdatedEventType, ZoneID: "zone-2", AvailableSeats: 50, IsActive: true, OnSale: true})
+	replay.ApplyCapacityChange(&ZoneCapacityChangedEvent{EventID: "c1", ZoneID: "zone-2", PreviousTotal: 50, NewTotal: 60})
+	// A reduction below the seats taken is rejected
+	replay.ApplyCapacityChange(&ZoneCapacityChangedEvent{EventID: "c2", ZoneID: "zone-2", PreviousTotal: 60, NewTotal: 0})
+
+	if got := replay.Zone("zone-1").Available; got != 100 {
+		t.Errorf("Expected zone-1 to have 100 seats, got %d", got)
+	}
+	if got := replay.Zone("zone-2").Available; got != 57 {
+		t.Errorf("Expected zone-2 to have 57 seats, got %d", got)
+	}
+	if replay.Duplicates() != 1 || replay.Rejected() != 1 {
+		t.Errorf("Expected 1 duplicate and 1 rejected change, got %d and %d", replay.Duplicates(), replay.Rejected())
+	}
+	if replay.Applied() != 9 {
+		t.Errorf("Expected 9 events applied, got %d", replay.Applied())
+	}
+}
+
+func TestInventoryReplay_ZoneLifecycle(t *testing.T) {
+	replay := NewInventoryReplay()
+	replay.ApplyBookingEvent(bookingEvent("b1", BookingEventCreated, "zone-1", 2))
+	if replay.Zone("zone-1").Baselined {
+		t.Error("Expected a zone without a lifecycle event to have no baseline")
+	}
+
+	// A zone not on sale takes the availability of seat_zones
+	replay.ApplyZoneEvent(&ZoneEvent{EventID: "z1", ZoneID: "zone-1", AvailableSeats: 80, IsActive: true})
+	if zone := replay.Zone("zone-1"); !zone.Baselined || zone.Available != 80 {
+		t.Errorf("Expected zone-1 baselined at 80, got %+v", zone)
+	}
+
+	replay.ApplyZoneEvent(&ZoneEvent{EventID: "z2", ZoneID: "zone-1", IsActive: false})
+	if !replay.Zone("zone-1").Removed {
+		t.Error("Expected a deactivated zone to be removed")
+	}
+}
+
+func TestPlanInventoryRebuild(t *testing.T) {
+	seats := func(n int64) *int64 { return &n }
+
+	tests := []struct {
+		name     string
+		zone     *ReplayedZone
+		redis    *int64
+		want     string
+		wantDiff *int64
+	}{
+		{"counter drifted", &ReplayedZone{ZoneID: "z", Available: 40, Baselined: true}, seats(35), InventoryRebuildSet, seats(5)},
+		{"counter matches", &ReplayedZone{ZoneID: "z", Available: 40, Baselined: true}, seats(40), InventoryRebuildUnchanged, seats(0)},
+		{"counter missing", &ReplayedZone{ZoneID: "z", Available: 40, Baselined: true}, nil, InventoryRebuildSet, nil},
+		{"no baseline", &ReplayedZone{ZoneID: "z", Available: -3}, seats(10), InventoryRebuildSkip, nil},
+		{"negative", &ReplayedZone{ZoneID: "z", Available: -3, Baselined: true}, seats(10), InventoryRebuildSkip, nil},
+		{"removed", &ReplayedZone{ZoneID: "z", Baselined: true, Removed: true}, seats(10), InventoryRebuildRemove, nil},
+		{"removed and gone", &ReplayedZone{ZoneID: "z", Baselined: true, Removed: true}, nil, InventoryRebuildUnchanged, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := PlanInventoryRebuild(tt.zone, tt.redis, nil)
+			if plan.Action != tt.want {
+				t.Errorf("Expected action %s, got %s", tt.want, plan.Action)
+			}
+			if (plan.Diff == nil) != (tt.wantDiff == nil) || (plan.Diff != nil && *plan.Diff != *tt.wantDiff) {
+				t.Errorf("Expected diff %v, got %v", tt.wantDiff, plan.Diff)
+			}
+		})
+	}
+}
+
+func TestInventoryReplay_LastApplied(t *testing.T) {
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	replay := NewInventoryReplay()
+	replay.ApplyZoneEvent(&ZoneEvent{EventID: "z1", ZoneID: "zone-1", AvailableSeats: 10, IsActive: true, OccurredAt: at})
+	replay.ApplyZoneEvent(&ZoneEvent{EventID: "z0", ZoneID: "zone-2", AvailableSeats: 10, IsActive: true, OccurredAt: at.Add(-time.Hour)})
+
+	if !replay.LastApplied().Equal(at) {
+		t.Errorf("Expected last event at %s, got %s", at, replay.LastApplied())
+	}
+}
@@ -3,6 +3,8 @@ package domain
 import (
 	"encoding/json"
 	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/events"
 )
 
 // OutboxStatus represents the status of an outbox message
@@ -102,12 +104,15 @@ func (m *OutboxMessage) GetPayload(v interface{}) error {
 
 // BookingOutboxEvent creates an outbox message for a booking event
 func BookingOutboxEvent(eventType BookingEventType, booking *Booking, eventID string) (*OutboxMessage, error) {
-	event := NewBookingEvent(eventType, booking, eventID)
+	event, err := NewBookingEvent(eventType, booking, eventID)
+	if err != nil {
+		return nil, err
+	}
 	return NewOutboxMessage(
 		"booking",
 		booking.ID,
 		string(eventType),
-		"booking-events",
+		events.TopicBookingEvents,
 		event,
 	)
 }
@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// QueueAnalyticsBucketSize is the resolution queue analytics are recorded at
+const QueueAnalyticsBucketSize = time.Minute
+
+// QueueAnalyticsMaxWindow is the longest window queue analytics can be read for
+const QueueAnalyticsMaxWindow = 24 * time.Hour
+
+// QueueFunnelStep is a step users take from joining a queue to a confirmed booking
+type QueueFunnelStep string
+
+const (
+	QueueStepJoined    QueueFunnelStep = "joined"    // Joined the queue
+	QueueStepLeft      QueueFunnelStep = "left"      // Left the queue before being admitted
+	QueueStepAdmitted  QueueFunnelStep = "admitted"  // Released with a queue pass
+	QueueStepReserved  QueueFunnelStep = "reserved"  // Reserved seats with a queue pass
+	QueueStepConfirmed QueueFunnelStep = "confirmed" // Confirmed a booking for the event
+)
+
+// QueueFunnelSteps lists every funnel step in order
+var QueueFunnelSteps = []QueueFunnelStep{
+	QueueStepJoined,
+	QueueStepLeft,
+	QueueStepAdmitted,
+	QueueStepReserved,
+	QueueStepConfirmed,
+}
+
+// QueueAnalyticsBucket holds the funnel counts of an event queue for one bucket
+type QueueAnalyticsBucket struct {
+	Start  time.Time
+	Counts map[QueueFunnelStep]int64
+	// Depth is the last queue size sampled by the queue worker in the bucket, -1 if none was
+	Depth int64
+}
+
+// NewQueueAnalyticsBucket creates an empty bucket starting at start
+func NewQueueAnalyticsBucket(start time.Time) *QueueAnalyticsBucket {
+	return &QueueAnalyticsBucket{
+		Start:  start,
+		Counts: make(map[QueueFunnelStep]int64, len(QueueFunnelSteps)),
+		Depth:  -1,
+	}
+}
@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"time"
+)
+
+// RefundItemStatus represents the refund state of one booking in a refund batch
+type RefundItemStatus string
+
+const (
+	RefundItemPending   RefundItemStatus = "pending"   // Waiting for the refund worker
+	RefundItemRequested RefundItemStatus = "requested" // Refund command sent to payment-service
+	RefundItemRefunded  RefundItemStatus = "refunded"  // payment.refunded received
+	RefundItemFailed    RefundItemStatus = "failed"    // payment.refund_failed received
+	RefundItemSkipped   RefundItemStatus = "skipped"   // Booking has no payment to refund
+)
+
+// IsValid checks if the status is a valid RefundItemStatus
+func (s RefundItemStatus) IsValid() bool {
+	switch s {
+	case RefundItemPending, RefundItemRequested, RefundItemRefunded, RefundItemFailed, RefundItemSkipped:
+		return true
+	}
+	return false
+}
+
+// IsFinal reports whether no further refund attempt will be made without operator action
+func (s RefundItemStatus) IsFinal() bool {
+	return s == RefundItemRefunded || s == RefundItemFailed || s == RefundItemSkipped
+}
+
+// String returns the string representation of RefundItemStatus
+func (s RefundItemStatus) String() string {
+	return string(s)
+}
+
+// RefundBatchStatus represents the overall progress of a refund batch
+type RefundBatchStatus string
+
+const (
+	RefundBatchProcessing RefundBatchStatus = "processing" // Some refunds are still outstanding
+	RefundBatchCompleted  RefundBatchStatus = "completed"  // Every payment was refunded
+	RefundBatchPartial    RefundBatchStatus = "partial"    // Done, but some refunds failed
+	RefundBatchFailed     RefundBatchStatus = "failed"     // Done, and every refund failed
+)
+
+// String returns the string representation of RefundBatchStatus
+func (s RefundBatchStatus) String() string {
+	return string(s)
+}
+
+// DefaultShowCancelledReason is used when an operator cancels a show without a reason
+const DefaultShowCancelledReason = "show cancelled"
+
+// RefundBatchItem is the refund of a single confirmed booking of a cancelled show
+type RefundBatchItem struct {
+	BatchID     string           `json:"batch_id"`
+	BookingID   string           `json:"booking_id"`
+	TenantID    string           `json:"tenant_id"`
+	UserID      string           `json:"user_id"`
+	PaymentID   string           `json:"payment_id,omitempty"`
+	Amount      float64          `json:"amount"`
+	Currency    string           `json:"currency"`
+	Status      RefundItemStatus `json:"status"`
+	Attempts    int              `json:"attempts"`
+	Error       string           `json:"error,omitempty"`
+	RequestedAt *time.Time       `json:"requested_at,omitempty"`
+	RefundedAt  *time.Time       `json:"refunded_at,omitempty"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+// RefundBatchSummary counts the items of a refund batch by status
+type RefundBatchSummary struct {
+	Total          int     `json:"total"`
+	Pending        int     `json:"pending"`
+	Requested      int     `json:"requested"`
+	Refunded       int     `json:"refunded"`
+	Failed         int     `json:"failed"`
+	Skipped        int     `json:"skipped"`
+	RefundedAmount float64 `json:"refunded_amount"`
+}
+
+// RefundBatch tracks the refunds of every confirmed booking of a cancelled show.
+// There is one batch per show; cancelling the show again adds bookings confirmed
+// since and retries failed refunds.
+type RefundBatch struct {
+	ID          string             `json:"id"`
+	ShowID      string             `json:"show_id"`
+	Reason      string             `json:"reason"`
+	RequestedBy string             `json:"requested_by,omitempty"`
+	Status      RefundBatchStatus  `json:"status"`
+	Summary     RefundBatchSummary `json:"summary"`
+	Items       []*RefundBatchItem `json:"items,omitempty"`
+	CreatedAt   time.Time          `json:"created_at"`
+	CompletedAt *time.Time         `json:"completed_at,omitempty"`
+}
+
+// NewRefundBatch creates a new refund batch for a show
+func NewRefundBatch(showID, reason, requestedBy string) *RefundBatch {
+	if reason == "" {
+		reason = DefaultShowCancelledReason
+	}
+	return &RefundBatch{
+		ShowID:      showID,
+		Reason:      reason,
+		RequestedBy: requestedBy,
+		Status:      RefundBatchProcessing,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// Summarize derives the summary and status of the batch from its items
+func (b *RefundBatch) Summarize() {
+	summary := RefundBatchSummary{Total: len(b.Items)}
+	for _, item := range b.Items {
+		switch item.Status {
+		case RefundItemPending:
+			summary.Pending++
+		case RefundItemRequested:
+			summary.Requested++
+		case RefundItemRefunded:
+			summary.Refunded++
+			summary.RefundedAmount += item.Amount
+		case RefundItemFailed:
+			summary.Failed++
+		case RefundItemSkipped:
+			summary.Skipped++
+		}
+	}
+	b.Summary = summary
+	b.Status = summary.Status()
+}
+
+// Status derives the batch status from the item counts
+func (s RefundBatchSummary) Status() RefundBatchStatus {
+	switch {
+	case s.Pending > 0 || s.Requested > 0:
+		return RefundBatchProcessing
+	case s.Failed == 0:
+		return RefundBatchCompleted
+	case s.Refunded == 0:
+		return RefundBatchFailed
+	default:
+		return RefundBatchPartial
+	}
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestRefundBatch_Summarize(t *testing.T) {
+	items := func(statuses ...RefundItemStatus) []*RefundBatchItem {
+		out := make([]*RefundBatchItem, 0, len(statuses))
+		for _, s := range statuses {
+			out = append(out, &RefundBatchItem{Status: s, Amount: 100})
+		}
+		return out
+	}
+
+	tests := []struct {
+		name  string
+		items []*RefundBatchItem
+		want  RefundBatchStatus
+	}{
+		{"no bookings is completed", nil, RefundBatchCompleted},
+		{"outstanding refunds are processing", items(RefundItemRefunded, RefundItemRequested), RefundBatchProcessing},
+		{"pending refunds are processing", items(RefundItemPending, RefundItemFailed), RefundBatchProcessing},
+		{"all refunded is completed", items(RefundItemRefunded, RefundItemSkipped), RefundBatchCompleted},
+		{"some failed is partial", items(RefundItemRefunded, RefundItemFailed), RefundBatchPartial},
+		{"all failed is failed", items(RefundItemFailed, RefundItemSkipped), RefundBatchFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batch := NewRefundBatch("show-1", "", "admin-1")
+			batch.Items = tt.items
+			batch.Summarize()
+			if batch.Status != tt.want {
+				t.Errorf("Status = %v, want %v", batch.Status, tt.want)
+			}
+			if batch.Summary.Total != len(tt.items) {
+				t.Errorf("Total = %d, want %d", batch.Summary.Total, len(tt.items))
+			}
+		})
+	}
+}
+
+func TestRefundBatch_RefundedAmount(t *testing.T) {
+	batch := NewRefundBatch("show-1", "weather", "admin-1")
+	batch.Items = []*RefundBatchItem{
+		{Status: RefundItemRefunded, Amount: 1500},
+		{Status: RefundItemRefunded, Amount: 500},
+		{Status: RefundItemFailed, Amount: 700},
+	}
+	batch.Summarize()
+
+	if batch.Summary.RefundedAmount != 2000 {
+		t.Errorf("RefundedAmount = %v, want 2000", batch.Summary.RefundedAmount)
+	}
+	if batch.Reason != "weather" {
+		t.Errorf("Reason = %q, want %q", batch.Reason, "weather")
+	}
+}
@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// ReportFrequency represents how often an organizer receives the sales summary email
+type ReportFrequency string
+
+const (
+	ReportFrequencyDaily  ReportFrequency = "daily"  // Covers the 24 hours before the send time
+	ReportFrequencyWeekly ReportFrequency = "weekly" // Covers the 7 days before the send time
+)
+
+// IsValid checks if the frequency is a valid ReportFrequency
+func (f ReportFrequency) IsValid() bool {
+	return f == ReportFrequencyDaily || f == ReportFrequencyWeekly
+}
+
+// String returns the string representation of ReportFrequency
+func (f ReportFrequency) String() string {
+	return string(f)
+}
+
+// ReportSchedule is an organizer's sales summary email subscription.
+// Send times are in UTC.
+type ReportSchedule struct {
+	TenantID  string          `json:"tenant_id"`
+	Email     string          `json:"email"`
+	Locale    string          `json:"locale"`
+	Frequency ReportFrequency `json:"frequency"`
+	SendHour  int             `json:"send_hour"`
+	Weekday   time.Weekday    `json:"weekday"` // Weekly only
+	// EventIDs limits the report to these events; empty reports every event with activity
+	EventIDs []string `json:"event_ids"`
+
+	UnsubscribeToken string     `json:"-"`
+	UnsubscribedAt   *time.Time `json:"unsubscribed_at,omitempty"`
+
+	NextRunAt  time.Time  `json:"next_run_at"`
+	LastSentAt *time.Time `json:"last_sent_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// Validate checks the schedule's delivery settings
+func (s *ReportSchedule) Validate() error {
+	if !strings.Contains(s.Email, "@") || !s.Frequency.IsValid() ||
+		s.SendHour < 0 || s.SendHour > 23 ||
+		s.Weekday < time.Sunday || s.Weekday > time.Saturday {
+		return ErrInvalidReportSchedule
+	}
+	return nil
+}
+
+// IsSubscribed reports whether reports are still sent
+func (s *ReportSchedule) IsSubscribed() bool {
+	return s.UnsubscribedAt == nil
+}
+
+// NextRun returns the first send time strictly after t
+func (s *ReportSchedule) NextRun(t time.Time) time.Time {
+	t = t.UTC()
+	next := time.Date(t.Year(), t.Month(), t.Day(), s.SendHour, 0, 0, 0, time.UTC)
+	if s.Frequency == ReportFrequencyWeekly {
+		next = next.AddDate(0, 0, (int(s.Weekday)-int(next.Weekday())+7)%7)
+		if !next.After(t) {
+			next = next.AddDate(0, 0, 7)
+		}
+		return next
+	}
+	if !next.After(t) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// Period returns the time range [from, to) reported by the run at runAt
+func (s *ReportSchedule) Period(runAt time.Time) (from, to time.Time) {
+	to = runAt.UTC()
+	if s.Frequency == ReportFrequencyWeekly {
+		return to.AddDate(0, 0, -7), to
+	}
+	return to.AddDate(0, 0, -1), to
+}
+
+// EventSalesSummary is one event's sales activity over a report period
+type EventSalesSummary struct {
+	EventID string `json:"event_id"`
+	// Bookings counts reservations made in the period, whatever became of them
+	Bookings          int     `json:"bookings"`
+	ConfirmedBookings int     `json:"confirmed_bookings"`
+	TicketsSold       int     `json:"tickets_sold"`
+	Revenue           float64 `json:"revenue"`
+	Refunds           int     `json:"refunds"`
+	RefundedAmount    float64 `json:"refunded_amount"`
+	Currency          string  `json:"currency"`
+	// Queue is the virtual queue at the time the report was built; nil if unknown
+	Queue *EventQueueSnapshot `json:"queue,omitempty"`
+}
+
+// EventQueueSnapshot is the state of an event's virtual queue
+type EventQueueSnapshot struct {
+	Waiting int64 `json:"waiting"`
+	IsOpen  bool  `json:"is_open"`
+}
+
+// SalesReport is the sales summary sent to an organizer for one period
+type SalesReport struct {
+	TenantID  string               `json:"tenant_id"`
+	Frequency ReportFrequency      `json:"frequency"`
+	From      time.Time            `json:"from"`
+	To        time.Time            `json:"to"`
+	Events    []*EventSalesSummary `json:"events"`
+	Totals    EventSalesSummary    `json:"totals"`
+}
+
+// NewSalesReport builds a report from per-event summaries and totals them.
+// Revenue of events in different currencies is summed as is.
+func NewSalesReport(schedule *ReportSchedule, from, to time.Time, events []*EventSalesSummary) *SalesReport {
+	report := &SalesReport{
+		TenantID:  schedule.TenantID,
+		Frequency: schedule.Frequency,
+		From:      from,
+		To:        to,
+		Events:    events,
+	}
+	if report.Events == nil {
+		report.Events = []*EventSalesSummary{}
+	}
+	for _, e := range report.Events {
+		report.Totals.Bookings += e.Bookings
+		report.Totals.ConfirmedBookings += e.ConfirmedBookings
+		report.Totals.TicketsSold += e.TicketsSold
+		report.Totals.Revenue += e.Revenue
+		report.Totals.Refunds += e.Refunds
+		report.Totals.RefundedAmount += e.RefundedAmount
+		if report.Totals.Currency == "" {
+			report.Totals.Currency = e.Currency
+		}
+	}
+	return report
+}
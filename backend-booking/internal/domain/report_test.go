@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReportSchedule_NextRun(t *testing.T) {
+	// 2026-05-06 is a Wednesday
+	now := time.Date(2026, 5, 6, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		schedule ReportSchedule
+		want     time.Time
+	}{
+		{"daily later today", ReportSchedule{Frequency: ReportFrequencyDaily, SendHour: 18}, time.Date(2026, 5, 6, 18, 0, 0, 0, time.UTC)},
+		{"daily already sent today", ReportSchedule{Frequency: ReportFrequencyDaily, SendHour: 8}, time.Date(2026, 5, 7, 8, 0, 0, 0, time.UTC)},
+		{"weekly later this week", ReportSchedule{Frequency: ReportFrequencyWeekly, SendHour: 8, Weekday: time.Friday}, time.Date(2026, 5, 8, 8, 0, 0, 0, time.UTC)},
+		{"weekly already sent today", ReportSchedule{Frequency: ReportFrequencyWeekly, SendHour: 8, Weekday: time.Wednesday}, time.Date(2026, 5, 13, 8, 0, 0, 0, time.UTC)},
+		{"weekly earlier in the week", ReportSchedule{Frequency: ReportFrequencyWeekly, SendHour: 8, Weekday: time.Monday}, time.Date(2026, 5, 11, 8, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.schedule.NextRun(now); !got.Equal(tt.want) {
+				t.Errorf("NextRun() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReportSchedule_Validate(t *testing.T) {
+	valid := ReportSchedule{Email: "org@example.com", Frequency: ReportFrequencyWeekly, SendHour: 23, Weekday: time.Saturday}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	for _, s := range []ReportSchedule{
+		{Email: "org", Frequency: ReportFrequencyDaily},
+		{Email: "org@example.com", Frequency: "monthly"},
+		{Email: "org@example.com", Frequency: ReportFrequencyDaily, SendHour: 24},
+		{Email: "org@example.com", Frequency: ReportFrequencyWeekly, Weekday: 7},
+	} {
+		if err := s.Validate(); err != ErrInvalidReportSchedule {
+			t.Errorf("Validate(%+v) error = %v, want ErrInvalidReportSchedule", s, err)
+		}
+	}
+}
+
+func TestNewSalesReport_Totals(t *testing.T) {
+	schedule := &ReportSchedule{TenantID: "tenant-1", Frequency: ReportFrequencyWeekly}
+	runAt := time.Date(2026, 5, 11, 8, 0, 0, 0, time.UTC)
+	from, to := schedule.Period(runAt)
+	if !from.Equal(runAt.AddDate(0, 0, -7)) || !to.Equal(runAt) {
+		t.Fatalf("Period() = %v, %v", from, to)
+	}
+
+	report := NewSalesReport(schedule, from, to, []*EventSalesSummary{
+		{EventID: "event-1", Bookings: 10, ConfirmedBookings: 8, TicketsSold: 16, Revenue: 16000, Currency: "THB"},
+		{EventID: "event-2", Bookings: 2, ConfirmedBookings: 1, TicketsSold: 2, Revenue: 3000, Refunds: 1, RefundedAmount: 1500, Currency: "THB"},
+	})
+	if report.Totals.Bookings != 12 || report.Totals.TicketsSold != 18 || report.Totals.Revenue != 19000 ||
+		report.Totals.Refunds != 1 || report.Totals.Currency != "THB" {
+		t.Errorf("Totals = %+v", report.Totals)
+	}
+}
@@ -0,0 +1,163 @@
+package domain
+
+import "time"
+
+// Reservation journal operations, one entry per change the reservation scripts make
+const (
+	JournalReserve = "reserve"
+	JournalConfirm = "confirm"
+	JournalRelease = "release"
+)
+
+// Reservation journal anomaly kinds
+const (
+	JournalReservedTwice         = "reserved_twice"          // A booking ID was reserved again
+	JournalConfirmedTwice        = "confirmed_twice"         // A reservation was confirmed again
+	JournalConfirmedAfterRelease = "confirmed_after_release" // A released reservation was confirmed
+	JournalReleasedTwice         = "released_twice"          // Seats of one reservation went back twice
+	JournalCounterDrift          = "counter_drift"           // The counter moved by more than the journaled changes
+)
+
+// JournalEntry is one reserve, confirm or release recorded in a zone's reservation
+// journal by the script that made it
+type JournalEntry struct {
+	ID        string    `json:"id"` // Stream ID, in the order the scripts ran
+	At        time.Time `json:"at"`
+	Op        string    `json:"op"`
+	BookingID string    `json:"booking_id"`
+	UserID    string    `json:"user_id"`
+	EventID   string    `json:"event_id,omitempty"`
+	Quantity  int64     `json:"quantity"`
+	// Available is the zone counter after a reserve or release (nil for confirms).
+	// Releases of a sharded zone only see its primary counter.
+	Available    *int64 `json:"available,omitempty"`
+	UserReserved *int64 `json:"user_reserved,omitempty"`
+	Shards       int    `json:"shards,omitempty"`     // Shard count a reserve found
+	Status       string `json:"status,omitempty"`     // Status a release found: reserved, or confirmed for a cancellation
+	PaymentID    string `json:"payment_id,omitempty"` // Payment a confirm recorded
+}
+
+// JournalAnomaly is an entry of the journal that should not have happened
+type JournalAnomaly struct {
+	Kind      string `json:"kind"`
+	EntryID   string `json:"entry_id"`
+	BookingID string `json:"booking_id,omitempty"`
+	// Drift is how many seats the counter moved beyond the journaled change, for counter_drift
+	Drift int64 `json:"drift,omitempty"`
+}
+
+// JournalAnalysis summarizes a zone's journal over a window
+type JournalAnalysis struct {
+	Entries        int            `json:"entries"`
+	From           time.Time      `json:"from,omitempty"`
+	To             time.Time      `json:"to,omitempty"`
+	Ops            map[string]int `json:"ops"`
+	SeatsReserved  int64          `json:"seats_reserved"`
+	SeatsConfirmed int64          `json:"seats_confirmed"`
+	SeatsReleased  int64          `json:"seats_released"`
+	// OpenHolds are bookings reserved in the window and neither confirmed nor released by
+	// its end: holds still running, or holds that expired without their seats coming back
+	OpenHolds []string `json:"open_holds"`
+	// Unmatched counts confirms and releases of bookings reserved before the window
+	Unmatched int `json:"unmatched"`
+	// CounterChecked is false for sharded zones, whose releases only see one shard
+	CounterChecked bool             `json:"counter_checked"`
+	Anomalies      []JournalAnomaly `json:"anomalies"`
+}
+
+// AnalyzeJournal replays a zone's journal entries, oldest first, and reports bookings
+// whose changes are out of order and counter moves the journal does not explain, such
+// as seats added or removed outside the reservation scripts.
+func AnalyzeJournal(entries []*JournalEntry) *JournalAnalysis {
+	analysis := &JournalAnalysis{
+		Entries:        len(entries),
+		Ops:            make(map[string]int),
+		OpenHolds:      []string{},
+		CounterChecked: true,
+		Anomalies:      []JournalAnomaly{},
+	}
+	if len(entries) == 0 {
+		return analysis
+	}
+	analysis.From, analysis.To = entries[0].At, entries[len(entries)-1].At
+	for _, entry := range entries {
+		if entry.Op == JournalReserve && entry.Shards > 1 {
+			analysis.CounterChecked = false
+		}
+	}
+
+	last := make(map[string]string) // Last op of each booking
+	var reservedOrder []string
+	var counter *int64
+	for _, entry := range entries {
+		analysis.Ops[entry.Op]++
+		previous, seen := last[entry.BookingID]
+		anomaly := ""
+
+		switch entry.Op {
+		case JournalReserve:
+			analysis.SeatsReserved += entry.Quantity
+			if seen {
+				anomaly = JournalReservedTwice
+			} else {
+				reservedOrder = append(reservedOrder, entry.BookingID)
+			}
+		case JournalConfirm:
+			analysis.SeatsConfirmed += entry.Quantity
+			switch {
+			case !seen:
+				analysis.Unmatched++
+			case previous == JournalConfirm:
+				anomaly = JournalConfirmedTwice
+			case previous == JournalRelease:
+				anomaly = JournalConfirmedAfterRelease
+			}
+		case JournalRelease:
+			analysis.SeatsReleased += entry.Quantity
+			switch {
+			case !seen:
+				analysis.Unmatched++
+			case previous == JournalRelease:
+				anomaly = JournalReleasedTwice
+			}
+		}
+		if anomaly != "" {
+			analysis.Anomalies = append(analysis.Anomalies, JournalAnomaly{Kind: anomaly, EntryID: entry.ID, BookingID: entry.BookingID})
+		}
+		last[entry.BookingID] = entry.Op
+
+		if !analysis.CounterChecked || entry.Available == nil {
+			continue
+		}
+		if counter != nil {
+			expected := *counter + journalDelta(entry)
+			if drift := *entry.Available - expected; drift != 0 {
+				analysis.Anomalies = append(analysis.Anomalies, JournalAnomaly{
+					Kind:      JournalCounterDrift,
+					EntryID:   entry.ID,
+					BookingID: entry.BookingID,
+					Drift:     drift,
+				})
+			}
+		}
+		counter = entry.Available
+	}
+
+	for _, bookingID := range reservedOrder {
+		if last[bookingID] == JournalReserve {
+			analysis.OpenHolds = append(analysis.OpenHolds, bookingID)
+		}
+	}
+	return analysis
+}
+
+// journalDelta is how an entry moves the zone counter
+func journalDelta(entry *JournalEntry) int64 {
+	switch entry.Op {
+	case JournalReserve:
+		return -entry.Quantity
+	case JournalRelease:
+		return entry.Quantity
+	}
+	return 0
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func journalEntry(id, op, bookingID string, quantity int64, available ...int64) *JournalEntry {
+	entry := &JournalEntry{ID: id, At: time.Unix(1700000000, 0), Op: op, BookingID: bookingID, Quantity: quantity}
+	if len(available) > 0 {
+		entry.Available = &available[0]
+	}
+	return entry
+}
+
+func TestAnalyzeJournal(t *testing.T) {
+	entries := []*JournalEntry{
+		journalEntry("1-0", JournalReserve, "b1", 2, 98),
+		journalEntry("2-0", JournalReserve, "b2", 1, 97),
+		journalEntry("3-0", JournalConfirm, "b1", 2),
+		journalEntry("4-0", JournalRelease, "b2", 1, 98),
+		// An admin sync added 10 seats between these entries
+		journalEntry("5-0", JournalReserve, "b3", 3, 105),
+		journalEntry("6-0", JournalRelease, "b2", 1, 106),
+		journalEntry("7-0", JournalRelease, "b0", 4, 110),
+		journalEntry("8-0", JournalConfirm, "b2", 1),
+	}
+
+	analysis := AnalyzeJournal(entries)
+
+	if analysis.Entries != 8 || analysis.Ops[JournalReserve] != 3 || analysis.Ops[JournalConfirm] != 2 || analysis.Ops[JournalRelease] != 3 {
+		t.Errorf("AnalyzeJournal() ops = %v over %d entries", analysis.Ops, analysis.Entries)
+	}
+	if analysis.SeatsReserved != 6 || analysis.SeatsConfirmed != 3 || analysis.SeatsReleased != 6 {
+		t.Errorf("AnalyzeJournal() seats = %d reserved, %d confirmed, %d released, want 6, 3, 6",
+			analysis.SeatsReserved, analysis.SeatsConfirmed, analysis.SeatsReleased)
+	}
+	if len(analysis.OpenHolds) != 1 || analysis.OpenHolds[0] != "b3" {
+		t.Errorf("AnalyzeJournal() open holds = %v, want [b3]", analysis.OpenHolds)
+	}
+	if analysis.Unmatched != 1 {
+		t.Errorf("AnalyzeJournal() unmatched = %d, want the release of b0", analysis.Unmatched)
+	}
+	if !analysis.CounterChecked {
+		t.Error("AnalyzeJournal() did not check the counter of an unsharded zone")
+	}
+
+	want := []JournalAnomaly{
+		{Kind: JournalCounterDrift, EntryID: "5-0", BookingID: "b3", Drift: 10},
+		{Kind: JournalReleasedTwice, EntryID: "6-0", BookingID: "b2"},
+		{Kind: JournalConfirmedAfterRelease, EntryID: "8-0", BookingID: "b2"},
+	}
+	if len(analysis.Anomalies) != len(want) {
+		t.Fatalf("AnalyzeJournal() anomalies = %+v, want %+v", analysis.Anomalies, want)
+	}
+	for i := range want {
+		if analysis.Anomalies[i] != want[i] {
+			t.Errorf("anomaly %d = %+v, want %+v", i, analysis.Anomalies[i], want[i])
+		}
+	}
+}
+
+func TestAnalyzeJournal_ShardedZone(t *testing.T) {
+	reserve := journalEntry("1-0", JournalReserve, "b1", 2, 98)
+	reserve.Shards = 4
+	// Releases of a sharded zone report the primary shard only
+	entries := []*JournalEntry{reserve, journalEntry("2-0", JournalRelease, "b1", 2, 27)}
+
+	analysis := AnalyzeJournal(entries)
+	if analysis.CounterChecked || len(analysis.Anomalies) != 0 {
+		t.Errorf("AnalyzeJournal() of a sharded zone = checked %v, anomalies %+v, want the counter unchecked",
+			analysis.CounterChecked, analysis.Anomalies)
+	}
+}
+
+func TestAnalyzeJournal_Empty(t *testing.T) {
+	analysis := AnalyzeJournal(nil)
+	if analysis.Entries != 0 || analysis.OpenHolds == nil || analysis.Anomalies == nil {
+		t.Errorf("AnalyzeJournal(nil) = %+v, want an empty analysis with empty lists", analysis)
+	}
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// ZoneSalesSnapshot is a zone's seats and revenue at the top of an hour, materialized into
+// the reporting schema so historical charts never query the transactional tables
+type ZoneSalesSnapshot struct {
+	SnapshotAt time.Time `json:"snapshot_at"`
+	TenantID   string    `json:"tenant_id"`
+	EventID    string    `json:"event_id"`
+	ShowID     string    `json:"show_id"`
+	ZoneID     string    `json:"zone_id"`
+	// TotalSeats and AvailableSeats are nil when the zone's inventory could not be read
+	TotalSeats     *int64  `json:"total_seats"`
+	AvailableSeats *int64  `json:"available_seats"`
+	HeldSeats      int64   `json:"held_seats"`
+	SoldSeats      int64   `json:"sold_seats"`
+	Revenue        float64 `json:"revenue"`
+	Currency       string  `json:"currency"`
+}
+
+// ZoneSeatCounts is a zone's capacity as last synced to seat_zones
+type ZoneSeatCounts struct {
+	Total     int64
+	Available int64
+}
+
+// SnapshotHour returns the hour a snapshot taken at t belongs to
+func SnapshotHour(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Hour)
+}
@@ -0,0 +1,324 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Seat map limits
+const (
+	MaxSeatMapRows    = 500
+	MaxSeatsPerRow    = 1000
+	MaxSeatMapSeats   = 100000
+	maxSeatLabelRunes = 8
+)
+
+// SeatMap is the reserved seating layout of a zone.
+// Rows are ordered front (closest to the stage) to back; seats in a row are numbered from 1.
+// Each seat has a fixed offset into the zone's seat bitmap: rows are laid out one after another.
+type SeatMap struct {
+	ZoneID string    `json:"zone_id"`
+	Rows   []SeatRow `json:"rows"`
+}
+
+// SeatRow is a single row of a seat map
+type SeatRow struct {
+	Label string `json:"label"`
+	Seats int    `json:"seats"`
+	// AisleAfter lists seat numbers followed by an aisle; 0 marks an aisle before seat 1
+	// and Seats marks an aisle after the last seat. Contiguous blocks never cross an aisle.
+	AisleAfter []int `json:"aisle_after,omitempty"`
+}
+
+// Seat is an allocated seat
+type Seat struct {
+	Row    string `json:"row"`
+	Number int    `json:"number"`
+	Offset int    `json:"-"` // Position in the zone seat bitmap
+}
+
+// Label returns the printable seat label, e.g. "C-12"
+func (s Seat) Label() string {
+	return fmt.Sprintf("%s-%d", s.Row, s.Number)
+}
+
+// SeatLabels returns the labels of seats in order
+func SeatLabels(seats []Seat) []string {
+	labels := make([]string, len(seats))
+	for i, seat := range seats {
+		labels[i] = seat.Label()
+	}
+	return labels
+}
+
+// SeatOffsets returns the bitmap offsets of seats in order
+func SeatOffsets(seats []Seat) []int {
+	offsets := make([]int, len(seats))
+	for i, seat := range seats {
+		offsets[i] = seat.Offset
+	}
+	return offsets
+}
+
+// SeatPreference tunes best-available allocation
+type SeatPreference struct {
+	// Rows are tried first, in the given order, before the rest of the zone front to back
+	Rows []string `json:"rows,omitempty"`
+	// Aisle prefers blocks that include an aisle seat
+	Aisle bool `json:"aisle,omitempty"`
+	// RequireContiguous fails instead of splitting the party across rows or aisles
+	RequireContiguous bool `json:"require_contiguous,omitempty"`
+}
+
+// SeatBitmap is the Redis bitmap of held seats for a zone (bit set = taken).
+// Bits are in Redis SETBIT order: offset 0 is the most significant bit of the first byte.
+type SeatBitmap []byte
+
+// Taken reports whether the seat at offset is held. Offsets past the end of the bitmap are free.
+func (b SeatBitmap) Taken(offset int) bool {
+	idx := offset / 8
+	if offset < 0 || idx >= len(b) {
+		return false
+	}
+	return b[idx]&(0x80>>uint(offset%8)) != 0
+}
+
+// Validate validates the seat map layout
+func (m *SeatMap) Validate() error {
+	if strings.TrimSpace(m.ZoneID) == "" {
+		return ErrInvalidZoneID
+	}
+	if len(m.Rows) == 0 || len(m.Rows) > MaxSeatMapRows {
+		return ErrInvalidSeatMap
+	}
+
+	labels := make(map[string]bool, len(m.Rows))
+	total := 0
+	for _, row := range m.Rows {
+		label := strings.TrimSpace(row.Label)
+		if label == "" || label != row.Label || len([]rune(label)) > maxSeatLabelRunes || labels[label] {
+			return ErrInvalidSeatMap
+		}
+		labels[label] = true
+
+		if row.Seats <= 0 || row.Seats > MaxSeatsPerRow {
+			return ErrInvalidSeatMap
+		}
+		for _, a := range row.AisleAfter {
+			if a < 0 || a > row.Seats {
+				return ErrInvalidSeatMap
+			}
+		}
+		total += row.Seats
+	}
+	if total > MaxSeatMapSeats {
+		return ErrInvalidSeatMap
+	}
+	return nil
+}
+
+// TotalSeats returns the number of seats in the map
+func (m *SeatMap) TotalSeats() int {
+	total := 0
+	for _, row := range m.Rows {
+		total += row.Seats
+	}
+	return total
+}
+
+// rowOffsets returns the bitmap offset of seat 1 of each row
+func (m *SeatMap) rowOffsets() []int {
+	offsets := make([]int, len(m.Rows))
+	next := 0
+	for i, row := range m.Rows {
+		offsets[i] = next
+		next += row.Seats
+	}
+	return offsets
+}
+
+// SeatAt returns the seat at a bitmap offset
+func (m *SeatMap) SeatAt(offset int) (Seat, bool) {
+	next := 0
+	for _, row := range m.Rows {
+		if offset < next+row.Seats {
+			if offset < next {
+				break
+			}
+			return Seat{Row: row.Label, Number: offset - next + 1, Offset: offset}, true
+		}
+		next += row.Seats
+	}
+	return Seat{}, false
+}
+
+// Allocate picks quantity free seats using best-available rules:
+//  1. The best contiguous block in a single row section (between aisles), trying
+//     preferred rows first, then rows front to back. Within a row, blocks with an
+//     aisle seat win when preferred, then blocks closest to the row center.
+//  2. If no block fits and the preference allows it, the best single free seat and
+//     the free seats nearest to it.
+//
+// The second return value reports whether the seats are contiguous.
+func (m *SeatMap) Allocate(taken SeatBitmap, quantity int, pref *SeatPreference) ([]Seat, bool, error) {
+	if quantity <= 0 {
+		return nil, false, ErrInvalidQuantity
+	}
+	if pref == nil {
+		pref = &SeatPreference{}
+	}
+
+	free := 0
+	for offset := 0; offset < m.TotalSeats(); offset++ {
+		if !taken.Taken(offset) {
+			free++
+		}
+	}
+	if free < quantity {
+		return nil, false, ErrInsufficientSeats
+	}
+
+	offsets := m.rowOffsets()
+	order := m.rowOrder(pref.Rows)
+
+	if block := m.bestBlock(taken, offsets, order, quantity, pref.Aisle); block != nil {
+		return block, true, nil
+	}
+	if pref.RequireContiguous {
+		return nil, false, ErrNoContiguousSeats
+	}
+
+	anchor := m.bestBlock(taken, offsets, order, 1, pref.Aisle)
+	return m.nearestSeats(taken, offsets, anchor[0], quantity), false, nil
+}
+
+// rowOrder returns row indexes with preferred rows first, then the rest front to back
+func (m *SeatMap) rowOrder(preferred []string) []int {
+	order := make([]int, 0, len(m.Rows))
+	used := make(map[int]bool, len(m.Rows))
+	for _, label := range preferred {
+		for i, row := range m.Rows {
+			if row.Label == label && !used[i] {
+				order = append(order, i)
+				used[i] = true
+			}
+		}
+	}
+	for i := range m.Rows {
+		if !used[i] {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
+// bestBlock returns the first row (in order) holding a free block of quantity seats,
+// choosing the best block within that row
+func (m *SeatMap) bestBlock(taken SeatBitmap, offsets, order []int, quantity int, preferAisle bool) []Seat {
+	for _, r := range order {
+		row := m.Rows[r]
+		aisles := make(map[int]bool, len(row.AisleAfter))
+		for _, a := range row.AisleAfter {
+			aisles[a] = true
+		}
+
+		bestStart := 0
+		bestAisleMiss := 0
+		bestCenterDist := 0.0
+		center := float64(row.Seats+1) / 2
+
+		for start := 1; start+quantity-1 <= row.Seats; start++ {
+			end := start + quantity - 1
+			if !blockFree(taken, offsets[r], start, end, aisles) {
+				continue
+			}
+
+			aisleMiss := 0
+			if preferAisle && !aisles[start-1] && !aisles[end] {
+				aisleMiss = 1
+			}
+			centerDist := float64(start+end)/2 - center
+			if centerDist < 0 {
+				centerDist = -centerDist
+			}
+
+			if bestStart == 0 || aisleMiss < bestAisleMiss ||
+				(aisleMiss == bestAisleMiss && centerDist < bestCenterDist) {
+				bestStart, bestAisleMiss, bestCenterDist = start, aisleMiss, centerDist
+			}
+		}
+
+		if bestStart > 0 {
+			seats := make([]Seat, quantity)
+			for i := range seats {
+				number := bestStart + i
+				seats[i] = Seat{Row: row.Label, Number: number, Offset: offsets[r] + number - 1}
+			}
+			return seats
+		}
+	}
+	return nil
+}
+
+// blockFree reports whether seats start..end are free and not split by an aisle
+func blockFree(taken SeatBitmap, rowOffset, start, end int, aisles map[int]bool) bool {
+	for number := start; number <= end; number++ {
+		if taken.Taken(rowOffset + number - 1) {
+			return false
+		}
+		if number < end && aisles[number] {
+			return false
+		}
+	}
+	return true
+}
+
+// nearestSeats returns anchor plus the free seats closest to it.
+// A row step counts as two seats of distance so the party stays in nearby rows.
+func (m *SeatMap) nearestSeats(taken SeatBitmap, offsets []int, anchor Seat, quantity int) []Seat {
+	anchorRow := 0
+	for i, row := range m.Rows {
+		if row.Label == anchor.Row {
+			anchorRow = i
+			break
+		}
+	}
+
+	type candidate struct {
+		seat     Seat
+		distance int
+	}
+	candidates := make([]candidate, 0)
+	for r, row := range m.Rows {
+		rowDist := r - anchorRow
+		if rowDist < 0 {
+			rowDist = -rowDist
+		}
+		for number := 1; number <= row.Seats; number++ {
+			offset := offsets[r] + number - 1
+			if offset == anchor.Offset || taken.Taken(offset) {
+				continue
+			}
+			seatDist := number - anchor.Number
+			if seatDist < 0 {
+				seatDist = -seatDist
+			}
+			candidates = append(candidates, candidate{
+				seat:     Seat{Row: row.Label, Number: number, Offset: offset},
+				distance: rowDist*2 + seatDist,
+			})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+
+	seats := []Seat{anchor}
+	for _, c := range candidates[:quantity-1] {
+		seats = append(seats, c.seat)
+	}
+	sort.Slice(seats, func(i, j int) bool { return seats[i].Offset < seats[j].Offset })
+	return seats
+}
@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// bitmapWith returns a bitmap with the given offsets taken
+func bitmapWith(offsets ...int) SeatBitmap {
+	b := make(SeatBitmap, 64)
+	for _, o := range offsets {
+		b[o/8] |= 0x80 >> uint(o%8)
+	}
+	return b
+}
+
+// threeRowMap has rows A-C of 10 seats with an aisle between seats 5 and 6
+func threeRowMap() *SeatMap {
+	return &SeatMap{
+		ZoneID: "zone-1",
+		Rows: []SeatRow{
+			{Label: "A", Seats: 10, AisleAfter: []int{5}},
+			{Label: "B", Seats: 10, AisleAfter: []int{5}},
+			{Label: "C", Seats: 10, AisleAfter: []int{5}},
+		},
+	}
+}
+
+func TestSeatBitmap_Taken(t *testing.T) {
+	b := bitmapWith(0, 9, 17)
+
+	for _, offset := range []int{0, 9, 17} {
+		if !b.Taken(offset) {
+			t.Errorf("offset %d should be taken", offset)
+		}
+	}
+	for _, offset := range []int{1, 8, 16, 1000, -1} {
+		if b.Taken(offset) {
+			t.Errorf("offset %d should be free", offset)
+		}
+	}
+	if SeatBitmap(nil).Taken(0) {
+		t.Error("empty bitmap should have no taken seats")
+	}
+}
+
+func TestSeatMap_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		seatMap *SeatMap
+		wantErr error
+	}{
+		{"valid", threeRowMap(), nil},
+		{"missing zone", &SeatMap{Rows: []SeatRow{{Label: "A", Seats: 1}}}, ErrInvalidZoneID},
+		{"no rows", &SeatMap{ZoneID: "z"}, ErrInvalidSeatMap},
+		{"empty label", &SeatMap{ZoneID: "z", Rows: []SeatRow{{Label: " ", Seats: 1}}}, ErrInvalidSeatMap},
+		{"duplicate label", &SeatMap{ZoneID: "z", Rows: []SeatRow{{Label: "A", Seats: 1}, {Label: "A", Seats: 1}}}, ErrInvalidSeatMap},
+		{"zero seats", &SeatMap{ZoneID: "z", Rows: []SeatRow{{Label: "A", Seats: 0}}}, ErrInvalidSeatMap},
+		{"too many seats", &SeatMap{ZoneID: "z", Rows: []SeatRow{{Label: "A", Seats: MaxSeatsPerRow + 1}}}, ErrInvalidSeatMap},
+		{"aisle outside row", &SeatMap{ZoneID: "z", Rows: []SeatRow{{Label: "A", Seats: 4, AisleAfter: []int{5}}}}, ErrInvalidSeatMap},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.seatMap.Validate(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSeatMap_SeatAt(t *testing.T) {
+	m := threeRowMap()
+
+	seat, ok := m.SeatAt(12)
+	if !ok || seat.Row != "B" || seat.Number != 3 {
+		t.Errorf("SeatAt(12) = %+v, %v, want B-3", seat, ok)
+	}
+	if _, ok := m.SeatAt(30); ok {
+		t.Error("SeatAt(30) should be outside the map")
+	}
+}
+
+func TestSeatMap_Allocate(t *testing.T) {
+	tests := []struct {
+		name           string
+		taken          SeatBitmap
+		quantity       int
+		pref           *SeatPreference
+		wantLabels     []string
+		wantContiguous bool
+		wantErr        error
+	}{
+		{
+			name:           "front row closest to center without crossing the aisle",
+			taken:          bitmapWith(),
+			quantity:       2,
+			wantLabels:     []string{"A-4", "A-5"},
+			wantContiguous: true,
+		},
+		{
+			name:           "aisle preference picks a block on the aisle",
+			taken:          bitmapWith(4),
+			quantity:       2,
+			pref:           &SeatPreference{Aisle: true},
+			wantLabels:     []string{"A-6", "A-7"},
+			wantContiguous: true,
+		},
+		{
+			name:           "block larger than a section moves to a later row",
+			taken:          bitmapWith(0, 1, 2, 3, 4, 5, 6, 7, 8, 9),
+			quantity:       5,
+			wantLabels:     []string{"B-1", "B-2", "B-3", "B-4", "B-5"},
+			wantContiguous: true,
+		},
+		{
+			name:           "preferred rows are tried first",
+			taken:          bitmapWith(),
+			quantity:       1,
+			pref:           &SeatPreference{Rows: []string{"C"}},
+			wantLabels:     []string{"C-5"},
+			wantContiguous: true,
+		},
+		{
+			// Every section has at most 2 free seats in a row
+			name:           "falls back to the nearest free seats",
+			taken:          bitmapWith(2, 3, 4, 5, 6, 7, 12, 13, 14, 15, 16, 17, 22, 23, 24, 25, 26, 27),
+			quantity:       3,
+			wantLabels:     []string{"A-1", "A-2", "B-2"},
+			wantContiguous: false,
+		},
+		{
+			name:     "require contiguous fails instead of splitting",
+			taken:    bitmapWith(2, 3, 4, 5, 6, 7, 12, 13, 14, 15, 16, 17, 22, 23, 24, 25, 26, 27),
+			quantity: 3,
+			pref:     &SeatPreference{RequireContiguous: true},
+			wantErr:  ErrNoContiguousSeats,
+		},
+		{
+			name:     "not enough free seats",
+			taken:    bitmapWith(0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27),
+			quantity: 3,
+			wantErr:  ErrInsufficientSeats,
+		},
+		{
+			name:     "invalid quantity",
+			taken:    bitmapWith(),
+			quantity: 0,
+			wantErr:  ErrInvalidQuantity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seats, contiguous, err := threeRowMap().Allocate(tt.taken, tt.quantity, tt.pref)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Allocate() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got := SeatLabels(seats); !reflect.DeepEqual(got, tt.wantLabels) {
+				t.Errorf("Allocate() seats = %v, want %v", got, tt.wantLabels)
+			}
+			if contiguous != tt.wantContiguous {
+				t.Errorf("Allocate() contiguous = %v, want %v", contiguous, tt.wantContiguous)
+			}
+			for _, seat := range seats {
+				if tt.taken.Taken(seat.Offset) {
+					t.Errorf("Allocate() returned taken seat %s", seat.Label())
+				}
+			}
+		})
+	}
+}
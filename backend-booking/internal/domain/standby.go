@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/i18n"
+)
+
+// StandbyStatus represents the state of a user on a zone standby list
+type StandbyStatus string
+
+const (
+	StandbyStatusWaiting StandbyStatus = "waiting" // Waiting for seats to be released
+	StandbyStatusOffered StandbyStatus = "offered" // Seats are held for the user during the exclusive window
+)
+
+// Channels a standby user can be invited through when seats are held for them
+const (
+	StandbyNotifyEmail = "email"
+	StandbyNotifySMS   = "sms"
+	StandbyNotifyPush  = "push"
+)
+
+// DefaultStandbyNotifyChannels is used when the user did not choose any channels
+var DefaultStandbyNotifyChannels = []string{StandbyNotifyEmail}
+
+// String returns the string representation of StandbyStatus
+func (s StandbyStatus) String() string {
+	return string(s)
+}
+
+// StandbyEntry is a user's place on a zone standby list
+type StandbyEntry struct {
+	ZoneID   string        `json:"zone_id"`
+	EventID  string        `json:"event_id"`
+	ShowID   string        `json:"show_id,omitempty"`
+	UserID   string        `json:"user_id"`
+	Quantity int           `json:"quantity"`
+	Status   StandbyStatus `json:"status"`
+	Position int64         `json:"position,omitempty"` // 1-based, only while waiting
+	JoinedAt time.Time     `json:"joined_at"`
+	// NotifyChannels are how the user wants to be invited when seats are held
+	NotifyChannels []string `json:"notify_channels,omitempty"`
+	// OfferExpiresAt is when held seats go back to the pool if not claimed
+	OfferExpiresAt *time.Time `json:"offer_expires_at,omitempty"`
+}
+
+// IsOffered checks if seats are currently held for the user
+func (e *StandbyEntry) IsOffered() bool {
+	return e.Status == StandbyStatusOffered && e.OfferExpiresAt != nil && time.Now().Before(*e.OfferExpiresAt)
+}
+
+// StandbyOffer is an exclusive hold of released seats for a standby user
+type StandbyOffer struct {
+	ZoneID         string    `json:"zone_id"`
+	EventID        string    `json:"event_id"`
+	ShowID         string    `json:"show_id,omitempty"`
+	UserID         string    `json:"user_id"`
+	Quantity       int       `json:"quantity"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	NotifyChannels []string  `json:"notify_channels,omitempty"`
+	Locale         string    `json:"locale,omitempty"` // Language of the invitation, from the request that joined
+}
+
+// StandbyStats tracks how a zone's standby list turns released seats into reservations.
+// Counters are cumulative; Waiting and Holding are the current list size.
+type StandbyStats struct {
+	ZoneID  string `json:"zone_id"`
+	Waiting int64  `json:"waiting"` // Users currently waiting
+	Holding int64  `json:"holding"` // Offers currently held, including lapsed ones not yet reclaimed
+	Joined  int64  `json:"joined"`
+	Left    int64  `json:"left"`    // Users who left the list, with or without a held offer
+	Offered int64  `json:"offered"` // Offers made
+	Claimed int64  `json:"claimed"` // Offers turned into reservations
+	Lapsed  int64  `json:"lapsed"`  // Offers whose window ended unclaimed
+}
+
+// ConversionRate returns the share of offers that were claimed
+func (s *StandbyStats) ConversionRate() float64 {
+	if s.Offered == 0 {
+		return 0
+	}
+	return float64(s.Claimed) / float64(s.Offered)
+}
+
+// StandbyEventOffered is the event type published when seats are held for a standby user
+const StandbyEventOffered = "standby.offered"
+
+// StandbyOfferEvent invites a standby user to claim the seats held for them
+type StandbyOfferEvent struct {
+	EventID    string        `json:"event_id"`
+	EventType  string        `json:"event_type"`
+	OccurredAt time.Time     `json:"occurred_at"`
+	Version    int           `json:"version"`
+	Offer      *StandbyOffer `json:"data"`
+	// Notification is the invitation to deliver, rendered in the user's locale
+	Notification *i18n.Notification `json:"notification,omitempty"`
+}
+
+// NewStandbyOfferEvent creates a new standby offer event
+func NewStandbyOfferEvent(offer *StandbyOffer, eventID string) *StandbyOfferEvent {
+	return &StandbyOfferEvent{
+		EventID:    eventID,
+		EventType:  StandbyEventOffered,
+		OccurredAt: time.Now(),
+		Version:    1,
+		Offer:      offer,
+		Notification: i18n.RenderNotification(i18n.Locale(offer.Locale), i18n.TemplateStandbyOffer, map[string]string{
+			"quantity":   strconv.Itoa(offer.Quantity),
+			"expires_at": offer.ExpiresAt.UTC().Format("2006-01-02 15:04 MST"),
+		}),
+	}
+}
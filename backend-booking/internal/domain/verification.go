@@ -0,0 +1,8 @@
+package domain
+
+// Verification policies set per event by organizers in ticket-service
+const (
+	VerificationPolicyNone     = "none"     // Anyone can book
+	VerificationPolicyEmail    = "email"    // Requires a verified email
+	VerificationPolicyVerified = "verified" // Requires a verified email and phone
+)
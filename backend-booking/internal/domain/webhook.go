@@ -0,0 +1,269 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// WebhookEventType represents a booking lifecycle event that tenants can subscribe to
+type WebhookEventType string
+
+const (
+	WebhookEventBookingConfirmed WebhookEventType = "booking.confirmed"
+	WebhookEventBookingCancelled WebhookEventType = "booking.cancelled"
+	WebhookEventPaymentRefunded  WebhookEventType = "payment.refunded"
+	// WebhookEventBookingConfirmFailed is sent when an asynchronous confirmation is rejected
+	WebhookEventBookingConfirmFailed WebhookEventType = "booking.confirm_failed"
+)
+
+// IsValid checks if the event type can be subscribed to
+func (t WebhookEventType) IsValid() bool {
+	switch t {
+	case WebhookEventBookingConfirmed, WebhookEventBookingCancelled, WebhookEventPaymentRefunded,
+		WebhookEventBookingConfirmFailed:
+		return true
+	}
+	return false
+}
+
+// String returns the string representation of WebhookEventType
+func (t WebhookEventType) String() string {
+	return string(t)
+}
+
+// WebhookSubscription is a tenant's endpoint for receiving booking lifecycle webhooks
+type WebhookSubscription struct {
+	ID          string             `json:"id"`
+	TenantID    string             `json:"tenant_id"`
+	URL         string             `json:"url"`
+	Secret      string             `json:"-"` // HMAC signing key, only returned when the subscription is created
+	EventTypes  []WebhookEventType `json:"event_types"`
+	Description string             `json:"description,omitempty"`
+	Active      bool               `json:"active"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+// NewWebhookSubscription creates an active subscription with a freshly generated signing secret
+func NewWebhookSubscription(tenantID, endpoint string, eventTypes []WebhookEventType, description string) (*WebhookSubscription, error) {
+	if tenantID == "" {
+		return nil, ErrInvalidTenantID
+	}
+	if err := ValidateWebhookURL(endpoint); err != nil {
+		return nil, err
+	}
+	if err := ValidateWebhookEventTypes(eventTypes); err != nil {
+		return nil, err
+	}
+
+	secret, err := GenerateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &WebhookSubscription{
+		TenantID:    tenantID,
+		URL:         endpoint,
+		Secret:      secret,
+		EventTypes:  eventTypes,
+		Description: description,
+		Active:      true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// Subscribes checks if the subscription should receive the given event type
+func (s *WebhookSubscription) Subscribes(eventType WebhookEventType) bool {
+	if !s.Active {
+		return false
+	}
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateWebhookURL checks that the endpoint is an absolute http(s) URL
+func ValidateWebhookURL(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return ErrInvalidWebhookURL
+	}
+	return nil
+}
+
+// ValidateWebhookEventTypes checks that at least one known event type is given
+func ValidateWebhookEventTypes(eventTypes []WebhookEventType) error {
+	if len(eventTypes) == 0 {
+		return ErrInvalidWebhookEventType
+	}
+	for _, t := range eventTypes {
+		if !t.IsValid() {
+			return ErrInvalidWebhookEventType
+		}
+	}
+	return nil
+}
+
+// GenerateWebhookSecret returns a random signing secret
+func GenerateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// SignWebhookPayload returns the hex HMAC-SHA256 of "{timestamp}.{payload}".
+// Receivers recompute it with their secret and compare it to the v1 value of the signature header.
+func SignWebhookPayload(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookSignatureHeader formats the signature header value, e.g. "t=1700000000,v1=ab12..."
+func WebhookSignatureHeader(secret string, timestamp int64, payload []byte) string {
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, SignWebhookPayload(secret, timestamp, payload))
+}
+
+// WebhookPayload is the JSON body posted to subscriber endpoints
+type WebhookPayload struct {
+	ID        string           `json:"id"` // Source event ID, stable across retries
+	Type      WebhookEventType `json:"type"`
+	TenantID  string           `json:"tenant_id"`
+	CreatedAt time.Time        `json:"created_at"`
+	Data      interface{}      `json:"data"`
+}
+
+// WebhookDeliveryStatus represents the state of a webhook delivery
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"   // Waiting for its first or next attempt
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered" // Endpoint answered 2xx
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"    // All attempts exhausted
+)
+
+// IsValid checks if the status is a valid WebhookDeliveryStatus
+func (s WebhookDeliveryStatus) IsValid() bool {
+	switch s {
+	case WebhookDeliveryPending, WebhookDeliveryDelivered, WebhookDeliveryFailed:
+		return true
+	}
+	return false
+}
+
+// String returns the string representation of WebhookDeliveryStatus
+func (s WebhookDeliveryStatus) String() string {
+	return string(s)
+}
+
+// WebhookDelivery records one event sent to one subscription, including retries
+type WebhookDelivery struct {
+	ID             string                `json:"id"`
+	SubscriptionID string                `json:"subscription_id"`
+	TenantID       string                `json:"tenant_id"`
+	EventID        string                `json:"event_id"`
+	EventType      WebhookEventType      `json:"event_type"`
+	Payload        json.RawMessage       `json:"payload"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	MaxAttempts    int                   `json:"max_attempts"`
+	LastStatusCode int                   `json:"last_status_code,omitempty"`
+	LastError      string                `json:"last_error,omitempty"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+}
+
+// NewWebhookDelivery creates a pending delivery that is due immediately
+func NewWebhookDelivery(sub *WebhookSubscription, eventID string, eventType WebhookEventType, payload []byte, maxAttempts int) *WebhookDelivery {
+	now := time.Now()
+	return &WebhookDelivery{
+		SubscriptionID: sub.ID,
+		TenantID:       sub.TenantID,
+		EventID:        eventID,
+		EventType:      eventType,
+		Payload:        payload,
+		Status:         WebhookDeliveryPending,
+		MaxAttempts:    maxAttempts,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// MarkDelivered records a successful attempt
+func (d *WebhookDelivery) MarkDelivered(statusCode int) {
+	now := time.Now()
+	d.Attempts++
+	d.Status = WebhookDeliveryDelivered
+	d.LastStatusCode = statusCode
+	d.LastError = ""
+	d.DeliveredAt = &now
+	d.UpdatedAt = now
+}
+
+// MarkAttemptFailed records a failed attempt and schedules the next one after retryDelay,
+// or marks the delivery failed once all attempts are used
+func (d *WebhookDelivery) MarkAttemptFailed(statusCode int, errMsg string, retryDelay time.Duration) {
+	now := time.Now()
+	d.Attempts++
+	d.LastStatusCode = statusCode
+	d.LastError = errMsg
+	d.UpdatedAt = now
+
+	if d.Attempts >= d.MaxAttempts {
+		d.Status = WebhookDeliveryFailed
+		return
+	}
+	d.Status = WebhookDeliveryPending
+	d.NextAttemptAt = now.Add(retryDelay)
+}
+
+// ResetForRetry makes a failed delivery due again with maxAttempts more attempts
+func (d *WebhookDelivery) ResetForRetry(maxAttempts int) error {
+	if d.Status != WebhookDeliveryFailed {
+		return ErrWebhookDeliveryNotRetryable
+	}
+	now := time.Now()
+	d.Status = WebhookDeliveryPending
+	d.MaxAttempts = d.Attempts + maxAttempts
+	d.NextAttemptAt = now
+	d.UpdatedAt = now
+	return nil
+}
+
+// WebhookRetryDelay returns the exponential backoff before the next attempt:
+// base * 2^(attempts-1), capped at max
+func WebhookRetryDelay(attempts int, base, max time.Duration) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	delay := base
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	if delay > max {
+		return max
+	}
+	return delay
+}
@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewWebhookSubscription(t *testing.T) {
+	sub, err := NewWebhookSubscription("tenant-1", "https://example.com/hooks", []WebhookEventType{WebhookEventBookingConfirmed}, "orders")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !sub.Active {
+		t.Error("expected new subscription to be active")
+	}
+	if !strings.HasPrefix(sub.Secret, "whsec_") || len(sub.Secret) != len("whsec_")+64 {
+		t.Errorf("unexpected secret format: %q", sub.Secret)
+	}
+	if !sub.Subscribes(WebhookEventBookingConfirmed) {
+		t.Error("expected subscription to receive booking.confirmed")
+	}
+	if sub.Subscribes(WebhookEventPaymentRefunded) {
+		t.Error("expected subscription not to receive payment.refunded")
+	}
+
+	sub.Active = false
+	if sub.Subscribes(WebhookEventBookingConfirmed) {
+		t.Error("expected inactive subscription not to receive events")
+	}
+}
+
+func TestNewWebhookSubscription_Validation(t *testing.T) {
+	tests := []struct {
+		name       string
+		tenantID   string
+		url        string
+		eventTypes []WebhookEventType
+		wantErr    error
+	}{
+		{"missing tenant", "", "https://example.com", []WebhookEventType{WebhookEventBookingConfirmed}, ErrInvalidTenantID},
+		{"relative url", "tenant-1", "/hooks", []WebhookEventType{WebhookEventBookingConfirmed}, ErrInvalidWebhookURL},
+		{"unsupported scheme", "tenant-1", "ftp://example.com/hooks", []WebhookEventType{WebhookEventBookingConfirmed}, ErrInvalidWebhookURL},
+		{"no event types", "tenant-1", "https://example.com", nil, ErrInvalidWebhookEventType},
+		{"unknown event type", "tenant-1", "https://example.com", []WebhookEventType{"booking.created"}, ErrInvalidWebhookEventType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewWebhookSubscription(tt.tenantID, tt.url, tt.eventTypes, "")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	payload := []byte(`{"id":"evt-1"}`)
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("1700000000." + string(payload)))
+	want := hex.EncodeToString(mac.Sum(nil))
+
+	if got := SignWebhookPayload("secret", 1700000000, payload); got != want {
+		t.Errorf("SignWebhookPayload() = %s, want %s", got, want)
+	}
+	if got := WebhookSignatureHeader("secret", 1700000000, payload); got != "t=1700000000,v1="+want {
+		t.Errorf("WebhookSignatureHeader() = %s", got)
+	}
+	if SignWebhookPayload("other", 1700000000, payload) == want {
+		t.Error("expected different secrets to produce different signatures")
+	}
+}
+
+func TestWebhookDelivery_Attempts(t *testing.T) {
+	sub := &WebhookSubscription{ID: "sub-1", TenantID: "tenant-1"}
+	d := NewWebhookDelivery(sub, "evt-1", WebhookEventBookingCancelled, []byte(`{}`), 2)
+
+	if d.Status != WebhookDeliveryPending || d.SubscriptionID != "sub-1" || d.TenantID != "tenant-1" {
+		t.Fatalf("unexpected new delivery: %+v", d)
+	}
+
+	before := time.Now()
+	d.MarkAttemptFailed(503, "service unavailable", time.Minute)
+	if d.Status != WebhookDeliveryPending || d.Attempts != 1 {
+		t.Errorf("expected pending after first failure, got %s with %d attempts", d.Status, d.Attempts)
+	}
+	if d.NextAttemptAt.Before(before.Add(time.Minute)) {
+		t.Errorf("expected next attempt at least 1m later, got %v", d.NextAttemptAt)
+	}
+
+	d.MarkAttemptFailed(0, "connection refused", time.Minute)
+	if d.Status != WebhookDeliveryFailed || d.Attempts != 2 {
+		t.Errorf("expected failed after last attempt, got %s with %d attempts", d.Status, d.Attempts)
+	}
+	if d.LastError != "connection refused" {
+		t.Errorf("LastError = %q", d.LastError)
+	}
+
+	if err := d.ResetForRetry(3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Status != WebhookDeliveryPending || d.MaxAttempts != 5 {
+		t.Errorf("expected pending with 5 max attempts, got %s with %d", d.Status, d.MaxAttempts)
+	}
+
+	d.MarkDelivered(200)
+	if d.Status != WebhookDeliveryDelivered || d.DeliveredAt == nil || d.LastError != "" {
+		t.Errorf("unexpected delivered state: %+v", d)
+	}
+	if err := d.ResetForRetry(3); !errors.Is(err, ErrWebhookDeliveryNotRetryable) {
+		t.Errorf("expected ErrWebhookDeliveryNotRetryable, got %v", err)
+	}
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, 10 * time.Second},
+		{1, 10 * time.Second},
+		{2, 20 * time.Second},
+		{4, 80 * time.Second},
+		{10, 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := WebhookRetryDelay(tt.attempts, 10*time.Second, 5*time.Minute); got != tt.want {
+			t.Errorf("WebhookRetryDelay(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
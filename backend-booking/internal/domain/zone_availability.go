@@ -0,0 +1,78 @@
+package domain
+
+import "time"
+
+// ZoneAvailabilityLevel is a coarse view of a zone's remaining seats, what a frontend
+// shows instead of the exact count
+type ZoneAvailabilityLevel string
+
+const (
+	ZoneAvailabilityAvailable ZoneAvailabilityLevel = "available"
+	ZoneAvailabilityLow       ZoneAvailabilityLevel = "low"      // At or below the low stock threshold
+	ZoneAvailabilitySoldOut   ZoneAvailabilityLevel = "sold_out" // No seats left
+)
+
+// Zone availability event types, published when a zone changes level
+const (
+	ZoneLowStockEventType    = "zone.low_stock"
+	ZoneSoldOutEventType     = "zone.sold_out"
+	ZoneBackInStockEventType = "zone.back_in_stock"
+)
+
+// ZoneAvailabilityLevelFor returns the level of a zone with available seats left.
+// A lowStockThreshold of 0 only distinguishes sold out zones.
+func ZoneAvailabilityLevelFor(available, lowStockThreshold int64) ZoneAvailabilityLevel {
+	switch {
+	case available <= 0:
+		return ZoneAvailabilitySoldOut
+	case available <= lowStockThreshold:
+		return ZoneAvailabilityLow
+	default:
+		return ZoneAvailabilityAvailable
+	}
+}
+
+// ZoneAvailabilityEvent is published when a zone's availability level flips
+type ZoneAvailabilityEvent struct {
+	EventType      string                `json:"event_type"`
+	EventID        string                `json:"event_id"`
+	ZoneID         string                `json:"zone_id"`
+	Level          ZoneAvailabilityLevel `json:"level"`
+	PreviousLevel  ZoneAvailabilityLevel `json:"previous_level,omitempty"`
+	AvailableSeats int64                 `json:"available_seats"`
+	OccurredAt     time.Time             `json:"occurred_at"`
+}
+
+// NewZoneAvailabilityEvent returns the event for a zone moving from previous to level,
+// or nil if the move is not worth announcing. previous is empty when the zone's level
+// was not known, in which case only a low or sold out zone is announced.
+func NewZoneAvailabilityEvent(eventID, zoneID string, previous, level ZoneAvailabilityLevel, available int64, now time.Time) *ZoneAvailabilityEvent {
+	if previous == level {
+		return nil
+	}
+
+	var eventType string
+	switch {
+	case level == ZoneAvailabilitySoldOut:
+		eventType = ZoneSoldOutEventType
+	case previous == ZoneAvailabilitySoldOut || (previous == ZoneAvailabilityLow && level == ZoneAvailabilityAvailable):
+		eventType = ZoneBackInStockEventType
+	case level == ZoneAvailabilityLow:
+		eventType = ZoneLowStockEventType
+	default:
+		return nil
+	}
+
+	if available < 0 {
+		available = 0
+	}
+	return &ZoneAvailabilityEvent{
+		EventType:      eventType,
+		EventID:        eventID,
+		ZoneID:         zoneID,
+		Level:          level,
+		PreviousLevel:  previous,
+		AvailableSeats: available,
+		OccurredAt:     now,
+	}
+}
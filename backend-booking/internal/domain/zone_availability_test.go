@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewZoneAvailabilityEvent(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		previous  ZoneAvailabilityLevel
+		available int64
+		wantType  string // "" = no event
+	}{
+		{"unchanged", ZoneAvailabilityAvailable, 50, ""},
+		{"first seen available", "", 50, ""},
+		{"first seen low", "", 5, ZoneLowStockEventType},
+		{"drops to low", ZoneAvailabilityAvailable, 10, ZoneLowStockEventType},
+		{"sells out", ZoneAvailabilityLow, 0, ZoneSoldOutEventType},
+		{"sells out in one reservation", ZoneAvailabilityAvailable, 0, ZoneSoldOutEventType},
+		{"release after sold out", ZoneAvailabilitySoldOut, 2, ZoneBackInStockEventType},
+		{"large release after sold out", ZoneAvailabilitySoldOut, 40, ZoneBackInStockEventType},
+		{"low recovers", ZoneAvailabilityLow, 11, ZoneBackInStockEventType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level := ZoneAvailabilityLevelFor(tt.available, 10)
+			event := NewZoneAvailabilityEvent("event-1", "zone-1", tt.previous, level, tt.available, now)
+			if tt.wantType == "" {
+				if event != nil {
+					t.Errorf("expected no event, got %+v", event)
+				}
+				return
+			}
+			if event == nil || event.EventType != tt.wantType || event.Level != level || event.AvailableSeats != tt.available {
+				t.Errorf("expected %s at %s, got %+v", tt.wantType, level, event)
+			}
+		})
+	}
+}
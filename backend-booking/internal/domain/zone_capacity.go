@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/events"
+)
+
+// Zone capacity changes are defined in the shared events catalog
+type ZoneCapacityChangedEvent = events.ZoneCapacityChangedEvent
+
+const ZoneCapacityChangedEventType = events.ZoneCapacityChangedEventType
+
+// Zone capacity change outcomes
+const (
+	ZoneCapacityChangeApplied  = "applied"
+	ZoneCapacityChangeRejected = "rejected"
+)
+
+// ZoneCapacityStatus is the outcome of the latest capacity change for a zone,
+// read by ticket-service to report rejected changes to the organizer
+type ZoneCapacityStatus struct {
+	EventID        string    `json:"event_id"`
+	ZoneID         string    `json:"zone_id"`
+	Status         string    `json:"status"`
+	PreviousTotal  int       `json:"previous_total"`
+	RequestedTotal int       `json:"requested_total"`
+	AvailableSeats int       `json:"available_seats"`
+	Reason         string    `json:"reason,omitempty"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
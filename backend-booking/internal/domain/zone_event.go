@@ -0,0 +1,13 @@
+package domain
+
+import (
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/events"
+)
+
+// Zone lifecycle events are defined in the shared events catalog
+type ZoneEvent = events.ZoneEvent
+
+const (
+	ZoneCreatedEventType = events.ZoneCreatedEventType
+	ZoneUpdatedEventType = events.ZoneUpdatedEventType
+)
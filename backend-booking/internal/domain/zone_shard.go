@@ -0,0 +1,13 @@
+package domain
+
+// MaxZoneShards bounds how many counters a zone's availability can be split across
+const MaxZoneShards = 64
+
+// ZoneShardLayout describes how a zone's availability is split across counters.
+// Shard 0 is the zone's primary counter; a zone with one shard is not sharded.
+type ZoneShardLayout struct {
+	ZoneID    string  `json:"zone_id"`
+	Shards    int     `json:"shards"`
+	Available []int64 `json:"available"` // Available seats per shard
+	Total     int64   `json:"total"`     // Available seats of the zone
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/middleware"
 )
 
 // ReserveSeatsRequest represents request to reserve seats
@@ -13,9 +14,17 @@ type ReserveSeatsRequest struct {
 	ShowID         string  `json:"show_id,omitempty"`
 	TenantID       string  `json:"tenant_id,omitempty"`
 	Quantity       int     `json:"quantity" binding:"required,min=1,max=10"`
-	UnitPrice      float64 `json:"unit_price,omitempty"`
 	IdempotencyKey string  `json:"idempotency_key,omitempty"`
-	QueuePass      string  `json:"queue_pass,omitempty"` // JWT token from virtual queue
+	QueuePass      string  `json:"queue_pass,omitempty"`   // JWT token from virtual queue
+	JoinStandby    bool    `json:"join_standby,omitempty"` // Join the zone standby list if sold out
+	// StandbyNotify lists invitation channels for the standby list (email, sms, push)
+	StandbyNotify []string `json:"standby_notify,omitempty" binding:"omitempty,max=3,dive,oneof=email sms push"`
+	// SeatPreference tunes best-available allocation in zones with a seat map
+	SeatPreference *domain.SeatPreference `json:"seat_preference,omitempty"`
+	// AddOns are add-on items (parking, merchandise) of the event to buy with the tickets
+	AddOns []domain.AddOnSelection `json:"add_ons,omitempty" binding:"omitempty,max=10"`
+	// Verification is the caller's verification state, set by the handler from the identity headers
+	Verification *middleware.Verification `json:"-"`
 }
 
 // ReserveSeatsResponse represents response after reserving seats
@@ -24,11 +33,21 @@ type ReserveSeatsResponse struct {
 	Status     string    `json:"status"`
 	ExpiresAt  time.Time `json:"expires_at"`
 	TotalPrice float64   `json:"total_price"`
+	Seats      []string  `json:"seats,omitempty"`      // Assigned seats in reserved seating zones, e.g. "C-12"
+	PriceTier  string    `json:"price_tier,omitempty"` // Scheduled price tier the seats sold at, e.g. "Early Bird"
+	// AddOns are the add-on items sold with the tickets, included in TotalPrice
+	AddOns []domain.BookingAddOn `json:"add_ons,omitempty"`
+	// Standby is set instead of a booking when the zone was sold out and the
+	// user opted into the standby list
+	Standby *StandbyStatusResponse `json:"standby,omitempty"`
 }
 
 // ConfirmBookingRequest represents request to confirm a booking
 type ConfirmBookingRequest struct {
 	PaymentID string `json:"payment_id,omitempty"`
+	// Verification is the caller's verification state, set by the handler from the identity
+	// headers. Nil skips the verification gate for internal confirmations already checked.
+	Verification *middleware.Verification `json:"-"`
 }
 
 // ConfirmBookingResponse represents response after confirming a booking
@@ -46,6 +65,28 @@ type ReleaseBookingResponse struct {
 	Message   string `json:"message"`
 }
 
+// ReleaseLinkPreview represents the reservation a release link would release
+type ReleaseLinkPreview struct {
+	BookingID string    `json:"booking_id"`
+	EventID   string    `json:"event_id"`
+	Quantity  int       `json:"quantity"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Message   string    `json:"message"`
+}
+
+// HoldExtensionResponse represents a booking hold after a request to extend it
+type HoldExtensionResponse struct {
+	BookingID string    `json:"booking_id"`
+	Status    string    `json:"status"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Extended  bool      `json:"extended"` // False when the hold already ran as long as allowed
+}
+
+// AwaitPaymentRequest hands a booking off to a payment completed at the gateway
+type AwaitPaymentRequest struct {
+	PaymentID string `json:"payment_id" binding:"required"`
+}
+
 // BookingResponse represents a booking in API response
 type BookingResponse struct {
 	ID          string     `json:"id"`
@@ -55,10 +96,14 @@ type BookingResponse struct {
 	Quantity    int        `json:"quantity"`
 	Status      string     `json:"status"`
 	TotalPrice  float64    `json:"total_price"`
+	Currency    string     `json:"currency,omitempty"`
 	PaymentID   string     `json:"payment_id,omitempty"`
 	ReservedAt  time.Time  `json:"reserved_at"`
 	ConfirmedAt *time.Time `json:"confirmed_at,omitempty"`
 	ExpiresAt   time.Time  `json:"expires_at"`
+	Seats       []string   `json:"seats,omitempty"`
+	// AddOns are the add-on items sold with the tickets, included in TotalPrice
+	AddOns []domain.BookingAddOn `json:"add_ons,omitempty"`
 }
 
 // UserBookingSummaryResponse represents user's booking summary for an event
@@ -80,9 +125,97 @@ func FromDomain(b *domain.Booking) *BookingResponse {
 		Quantity:    b.Quantity,
 		Status:      string(b.Status),
 		TotalPrice:  b.TotalPrice,
+		Currency:    b.Currency,
 		PaymentID:   b.PaymentID,
 		ReservedAt:  b.ReservedAt,
 		ConfirmedAt: b.ConfirmedAt,
 		ExpiresAt:   b.ExpiresAt,
+		Seats:       b.SeatLabels,
+		AddOns:      b.AddOns,
 	}
 }
+
+// ConfirmStatusResponse represents the state of an asynchronous booking confirmation
+type ConfirmStatusResponse struct {
+	BookingID        string     `json:"booking_id"`
+	StatusToken      string     `json:"status_token"`
+	Status           string     `json:"status"` // pending, processing, succeeded, failed
+	StatusURL        string     `json:"status_url"`
+	ConfirmationCode string     `json:"confirmation_code,omitempty"`
+	ConfirmedAt      *time.Time `json:"confirmed_at,omitempty"`
+	ErrorCode        string     `json:"error_code,omitempty"` // Same codes as the synchronous confirm errors
+	Error            string     `json:"error,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// ConfirmStatusFromDomain converts domain ConfirmJob to ConfirmStatusResponse
+func ConfirmStatusFromDomain(j *domain.ConfirmJob) *ConfirmStatusResponse {
+	return &ConfirmStatusResponse{
+		BookingID:        j.BookingID,
+		StatusToken:      j.Token,
+		Status:           j.Status.String(),
+		StatusURL:        "/api/v1/bookings/" + j.BookingID + "/confirm-status",
+		ConfirmationCode: j.ConfirmationCode,
+		ConfirmedAt:      j.ConfirmedAt,
+		ErrorCode:        j.ErrorCode,
+		Error:            j.ErrorMessage,
+		CreatedAt:        j.CreatedAt,
+		UpdatedAt:        j.UpdatedAt,
+	}
+}
+
+// ModifyBookingRequest represents request to change the zone or quantity of a booking.
+// Omitted fields keep their current value.
+type ModifyBookingRequest struct {
+	ZoneID    string  `json:"zone_id,omitempty"`
+	Quantity  int     `json:"quantity,omitempty" binding:"omitempty,min=1,max=10"`
+	UnitPrice float64 `json:"unit_price,omitempty"`
+}
+
+// ModifyBookingResponse represents response after modifying a booking
+type ModifyBookingResponse struct {
+	Booking            *BookingResponse `json:"booking"`
+	PreviousZoneID     string           `json:"previous_zone_id"`
+	PreviousQuantity   int              `json:"previous_quantity"`
+	PreviousTotalPrice float64          `json:"previous_total_price"`
+	PriceDifference    float64          `json:"price_difference"` // Positive was charged, negative refunded
+	Adjustment         string           `json:"adjustment"`       // none, charged or refunded
+}
+
+// CancellationQuoteResponse represents the refund a booking gets if cancelled now
+type CancellationQuoteResponse struct {
+	BookingID     string     `json:"booking_id"`
+	Status        string     `json:"status"`
+	Currency      string     `json:"currency"`
+	TotalPaid     float64    `json:"total_paid"`
+	RefundPercent float64    `json:"refund_percent"`
+	Forfeited     float64    `json:"forfeited"`     // Part of the payment the policy keeps
+	Fee           float64    `json:"fee"`           // Processing fee kept from the refund
+	RefundAmount  float64    `json:"refund_amount"` // What the user gets back
+	ShowStartsAt  *time.Time `json:"show_starts_at,omitempty"`
+	QuoteToken    string     `json:"quote_token"` // Send to POST /bookings/:id/cancel to get this refund
+	ExpiresAt     time.Time  `json:"expires_at"`  // Until when the quote token is honored
+}
+
+// CancelBookingRequest represents request to cancel a booking
+type CancelBookingRequest struct {
+	QuoteToken string `json:"quote_token,omitempty"` // Optional; refunds the quoted amount while valid
+}
+
+// CancelBookingResponse represents response after cancelling a booking
+type CancelBookingResponse struct {
+	BookingID    string  `json:"booking_id"`
+	Status       string  `json:"status"`
+	Message      string  `json:"message"`
+	TotalPaid    float64 `json:"total_paid"`
+	Fee          float64 `json:"fee"`
+	RefundAmount float64 `json:"refund_amount"`
+	QuoteApplied bool    `json:"quote_applied"` // The refund is the amount of the quote token
+}
+
+// ZoneAvailabilityResponse represents the seats still available in a zone
+type ZoneAvailabilityResponse struct {
+	ZoneID         string `json:"zone_id"`
+	AvailableSeats int64  `json:"available_seats"`
+}
@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// BookingSearchRequest represents a support agent's booking search
+type BookingSearchRequest struct {
+	TenantID         string // Scope set by the handler from the caller's role; empty searches all tenants
+	Email            string // Customer email, resolved through auth-service
+	ConfirmationCode string
+	PaymentID        string
+	LastFour         string // Card last four digits, resolved through payment-service
+	Limit            int
+}
+
+// PaymentSummary is a payment made for a booking, as returned by payment-service
+type PaymentSummary struct {
+	ID               string    `json:"id"`
+	BookingID        string    `json:"booking_id"`
+	Amount           float64   `json:"amount"`
+	Currency         string    `json:"currency"`
+	Status           string    `json:"status"`
+	Method           string    `json:"method,omitempty"`
+	Gateway          string    `json:"gateway,omitempty"`
+	GatewayPaymentID string    `json:"gateway_payment_id,omitempty"`
+	CardLastFour     string    `json:"card_last_four,omitempty"`
+	CardBrand        string    `json:"card_brand,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// BookingSearchResult is a matching booking with its payments
+type BookingSearchResult struct {
+	Booking  *domain.Booking   `json:"booking"`
+	Payments []*PaymentSummary `json:"payments"`
+}
+
+// BookingSearchResponse represents the result of a booking search
+type BookingSearchResponse struct {
+	Results []*BookingSearchResult `json:"results"`
+	Count   int                    `json:"count"`
+	// PaymentsUnavailable is set when payment-service could not be reached; bookings are
+	// still returned without payments
+	PaymentsUnavailable bool `json:"payments_unavailable,omitempty"`
+}
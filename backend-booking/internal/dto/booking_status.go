@@ -0,0 +1,63 @@
+package dto
+
+import "time"
+
+// Section states of a BookingFullStatusResponse
+const (
+	// StatusSectionOK means the section was fetched
+	StatusSectionOK = "ok"
+	// StatusSectionNone means there is nothing to show yet, e.g. no payment attempted
+	StatusSectionNone = "none"
+	// StatusSectionUnavailable means the owning service could not be reached in time
+	StatusSectionUnavailable = "unavailable"
+)
+
+// Ticket issuance statuses
+const (
+	TicketStatusPending = "pending" // Reserved, issued once the booking is confirmed
+	TicketStatusIssued  = "issued"  // Confirmed; the confirmation code is the ticket
+	TicketStatusVoid    = "void"    // Cancelled, expired or refunded
+)
+
+// BookingFullStatusResponse answers "where is my order" in one call: the booking with
+// its payment, saga and ticket issuance. Sections other than the booking are fetched
+// from other services and report their own state, so one slow service does not fail
+// the whole response.
+type BookingFullStatusResponse struct {
+	Booking *BookingResponse     `json:"booking"`
+	Payment *BookingPaymentState `json:"payment"`
+	Saga    *BookingSagaState    `json:"saga"`
+	Ticket  *BookingTicketState  `json:"ticket"`
+	// Partial is set when a section is unavailable
+	Partial   bool      `json:"partial"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// BookingPaymentState is the payment section of a BookingFullStatusResponse
+type BookingPaymentState struct {
+	State    string            `json:"state"`
+	Status   string            `json:"status,omitempty"`   // Status of the latest payment
+	Payments []*PaymentSummary `json:"payments,omitempty"` // Newest first, including failed attempts
+}
+
+// BookingSagaState is the saga section of a BookingFullStatusResponse, the latest saga
+// run for the booking
+type BookingSagaState struct {
+	State       string     `json:"state"`
+	SagaID      string     `json:"saga_id,omitempty"`
+	Name        string     `json:"name,omitempty"`
+	Status      string     `json:"status,omitempty"`
+	CurrentStep int        `json:"current_step,omitempty"`
+	Error       string     `json:"error,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// BookingTicketState is the ticket issuance section of a BookingFullStatusResponse
+type BookingTicketState struct {
+	State            string     `json:"state"`
+	Status           string     `json:"status,omitempty"`
+	ConfirmationCode string     `json:"confirmation_code,omitempty"`
+	Seats            []string   `json:"seats,omitempty"`
+	IssuedAt         *time.Time `json:"issued_at,omitempty"`
+}
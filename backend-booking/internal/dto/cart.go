@@ -0,0 +1,87 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/middleware"
+)
+
+// AddCartItemRequest represents request to add seats for a show and zone to the cart.
+// Adding a zone already in the cart replaces its quantity.
+type AddCartItemRequest struct {
+	EventID   string  `json:"event_id" binding:"required"`
+	ZoneID    string  `json:"zone_id" binding:"required"`
+	ShowID    string  `json:"show_id,omitempty"`
+	Quantity  int     `json:"quantity" binding:"required,min=1,max=10"`
+	UnitPrice float64 `json:"unit_price,omitempty"`
+	// AddOns are event add-ons to reserve with the seats, priced at checkout
+	AddOns []domain.AddOnSelection `json:"add_ons,omitempty" binding:"omitempty,max=10"`
+}
+
+// CartResponse represents a cart in API response
+type CartResponse struct {
+	UserID     string             `json:"user_id"`
+	Items      []*domain.CartItem `json:"items"`
+	TotalPrice float64            `json:"total_price"`
+	UpdatedAt  time.Time          `json:"updated_at"`
+}
+
+// CartFromDomain converts domain Cart to CartResponse
+func CartFromDomain(c *domain.Cart) *CartResponse {
+	items := c.Items
+	if items == nil {
+		items = []*domain.CartItem{}
+	}
+	return &CartResponse{
+		UserID:     c.UserID,
+		Items:      items,
+		TotalPrice: c.Total(),
+		UpdatedAt:  c.UpdatedAt,
+	}
+}
+
+// CheckoutRequest represents request to reserve every item in the cart
+type CheckoutRequest struct {
+	// AllowPartial keeps the items that could be reserved when others fail;
+	// by default a failed item releases the whole checkout
+	AllowPartial   bool   `json:"allow_partial,omitempty"`
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+	// QueuePasses maps event_id to a virtual queue pass, required when queue passes are enforced
+	QueuePasses map[string]string `json:"queue_passes,omitempty"`
+	// Verification is the caller's verification state, set by the handler from the identity headers
+	Verification *middleware.Verification `json:"-"`
+}
+
+// ConfirmCheckoutRequest represents request to confirm a checkout after its single payment
+type ConfirmCheckoutRequest struct {
+	PaymentID string `json:"payment_id,omitempty"`
+	// Verification is the caller's verification state, set by the handler from the identity headers
+	Verification *middleware.Verification `json:"-"`
+}
+
+// CheckoutResponse represents a checkout and the outcome of each item
+type CheckoutResponse struct {
+	CheckoutID string                 `json:"checkout_id"`
+	Status     string                 `json:"status"`
+	Items      []*domain.CheckoutItem `json:"items"`
+	TotalPrice float64                `json:"total_price"` // Amount of the consolidated payment
+	PaymentID  string                 `json:"payment_id,omitempty"`
+	ExpiresAt  time.Time              `json:"expires_at"`
+	CreatedAt  time.Time              `json:"created_at"`
+	UpdatedAt  time.Time              `json:"updated_at"`
+}
+
+// CheckoutFromDomain converts domain Checkout to CheckoutResponse
+func CheckoutFromDomain(c *domain.Checkout) *CheckoutResponse {
+	return &CheckoutResponse{
+		CheckoutID: c.ID,
+		Status:     c.Status.String(),
+		Items:      c.Items,
+		TotalPrice: c.TotalPrice,
+		PaymentID:  c.PaymentID,
+		ExpiresAt:  c.ExpiresAt,
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
+	}
+}
@@ -0,0 +1,8 @@
+package dto
+
+// TriggerCompensationRequest represents request to manually compensate a booking
+type TriggerCompensationRequest struct {
+	BookingID string `json:"booking_id" binding:"required"`
+	SagaID    string `json:"saga_id,omitempty"` // Optional saga to associate with the compensation
+	Reason    string `json:"reason" binding:"required"`
+}
@@ -0,0 +1,37 @@
+package dto
+
+import "time"
+
+// IssueQueuePassesRequest represents request to issue queue passes to load-test users
+// as if they had waited through the virtual queue
+type IssueQueuePassesRequest struct {
+	UserIDs []string `json:"user_ids" binding:"required,min=1,dive,required"`
+	// ZoneIDs scopes the passes (default: the event's queue config)
+	ZoneIDs []string `json:"zone_ids,omitempty"`
+}
+
+// IssuedQueuePass is a queue pass issued to a load-test user
+type IssuedQueuePass struct {
+	UserID    string `json:"user_id"`
+	QueuePass string `json:"queue_pass"`
+}
+
+// IssueQueuePassesResponse represents issued queue passes
+type IssueQueuePassesResponse struct {
+	EventID   string            `json:"event_id"`
+	ExpiresAt time.Time         `json:"expires_at"`
+	Passes    []IssuedQueuePass `json:"passes"`
+}
+
+// ResetLoadTestEventRequest represents request to reset an event between load-test runs
+type ResetLoadTestEventRequest struct {
+	// Zones maps zone IDs to the available seats to restore
+	Zones map[string]int64 `json:"zones,omitempty" binding:"omitempty,dive,min=0"`
+}
+
+// ResetLoadTestEventResponse represents the outcome of an event reset
+type ResetLoadTestEventResponse struct {
+	EventID     string `json:"event_id"`
+	KeysDeleted int64  `json:"keys_deleted"`
+	ZonesReset  int    `json:"zones_reset"`
+}
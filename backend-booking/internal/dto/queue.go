@@ -49,3 +49,16 @@ type LeaveQueueResponse struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
 }
+
+// RevokeQueuePassesRequest represents an emergency revocation of queue passes.
+// Without user_id every pass for the event is revoked.
+type RevokeQueuePassesRequest struct {
+	EventID string `json:"event_id" binding:"required"`
+	UserID  string `json:"user_id,omitempty"`
+}
+
+// RevokeQueuePassesResponse represents response after revoking queue passes
+type RevokeQueuePassesResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+// QueueAnalyticsResponse represents the queue funnel of an event over a window, with a
+// time-bucketed series for dashboards
+type QueueAnalyticsResponse struct {
+	EventID      string    `json:"event_id"`
+	GeneratedAt  time.Time `json:"generated_at"`
+	Since        time.Time `json:"since"`
+	Window       string    `json:"window"`
+	Interval     string    `json:"interval"`
+	CurrentDepth int64     `json:"current_depth"` // Users waiting in the queue right now
+	QueueFunnelStats
+	Series []*QueueAnalyticsPoint `json:"series"`
+}
+
+// QueueAnalyticsPoint is the queue funnel within one interval of the series
+type QueueAnalyticsPoint struct {
+	Start time.Time `json:"start"`
+	// Depth is the last queue size sampled in the interval, null if the queue worker sampled none
+	Depth *int64 `json:"depth"`
+	QueueFunnelStats
+}
+
+// QueueFunnelStats counts users at each funnel step.
+// AbandonmentRate is the share of joined users that left the queue before being admitted;
+// AdmitToReserveRate the share of admitted users that reserved seats and
+// ReserveToConfirmRate the share of reservations that were confirmed.
+type QueueFunnelStats struct {
+	Joined               int64   `json:"joined"`
+	Left                 int64   `json:"left"`
+	Admitted             int64   `json:"admitted"`
+	Reserved             int64   `json:"reserved"`
+	Confirmed            int64   `json:"confirmed"`
+	JoinsPerMinute       float64 `json:"joins_per_minute"`
+	AbandonmentRate      float64 `json:"abandonment_rate"`
+	AdmitToReserveRate   float64 `json:"admit_to_reserve_rate"`
+	ReserveToConfirmRate float64 `json:"reserve_to_confirm_rate"`
+}
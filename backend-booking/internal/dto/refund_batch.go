@@ -0,0 +1,6 @@
+package dto
+
+// CancelShowRequest represents request to cancel a show and refund its confirmed bookings
+type CancelShowRequest struct {
+	Reason string `json:"reason,omitempty"` // Defaults to "show cancelled"
+}
@@ -0,0 +1,107 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// ReportScheduleRequest represents request to set an organizer's sales summary email schedule
+type ReportScheduleRequest struct {
+	Email     string `json:"email" binding:"required,email"`
+	Locale    string `json:"locale,omitempty"`
+	Frequency string `json:"frequency" binding:"required,oneof=daily weekly"`
+	// SendHour is the UTC hour reports are sent at (default: 8)
+	SendHour *int `json:"send_hour,omitempty" binding:"omitempty,min=0,max=23"`
+	// Weekday is the day weekly reports are sent on, 0 = Sunday (default: 1, Monday)
+	Weekday *int `json:"weekday,omitempty" binding:"omitempty,min=0,max=6"`
+	// EventIDs limits reports to these events; empty reports every event with activity
+	EventIDs []string `json:"event_ids,omitempty" binding:"omitempty,max=50,dive,uuid"`
+}
+
+// ReportScheduleResponse represents an organizer's report schedule in API responses
+type ReportScheduleResponse struct {
+	Email          string     `json:"email"`
+	Locale         string     `json:"locale"`
+	Frequency      string     `json:"frequency"`
+	SendHour       int        `json:"send_hour"`
+	Weekday        int        `json:"weekday"`
+	EventIDs       []string   `json:"event_ids"`
+	Subscribed     bool       `json:"subscribed"`
+	NextRunAt      *time.Time `json:"next_run_at,omitempty"` // Omitted once unsubscribed
+	LastSentAt     *time.Time `json:"last_sent_at,omitempty"`
+	UnsubscribedAt *time.Time `json:"unsubscribed_at,omitempty"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// ReportScheduleFromDomain converts domain ReportSchedule to ReportScheduleResponse
+func ReportScheduleFromDomain(s *domain.ReportSchedule) *ReportScheduleResponse {
+	resp := &ReportScheduleResponse{
+		Email:          s.Email,
+		Locale:         s.Locale,
+		Frequency:      s.Frequency.String(),
+		SendHour:       s.SendHour,
+		Weekday:        int(s.Weekday),
+		EventIDs:       s.EventIDs,
+		Subscribed:     s.IsSubscribed(),
+		LastSentAt:     s.LastSentAt,
+		UnsubscribedAt: s.UnsubscribedAt,
+		UpdatedAt:      s.UpdatedAt,
+	}
+	if resp.EventIDs == nil {
+		resp.EventIDs = []string{}
+	}
+	if s.IsSubscribed() {
+		next := s.NextRunAt
+		resp.NextRunAt = &next
+	}
+	return resp
+}
+
+// EventSnapshotsResponse represents an event's hourly zone snapshots for historical charts
+type EventSnapshotsResponse struct {
+	EventID string                      `json:"event_id"`
+	From    time.Time                   `json:"from"`
+	To      time.Time                   `json:"to"`
+	Zones   []*domain.ZoneSalesSnapshot `json:"zones"`
+	Totals  []*EventSnapshotTotals      `json:"totals"`
+}
+
+// EventSnapshotTotals sums an event's zone snapshots of one hour.
+// Revenue of zones in different currencies is summed as is.
+type EventSnapshotTotals struct {
+	SnapshotAt     time.Time `json:"snapshot_at"`
+	AvailableSeats int64     `json:"available_seats"`
+	HeldSeats      int64     `json:"held_seats"`
+	SoldSeats      int64     `json:"sold_seats"`
+	Revenue        float64   `json:"revenue"`
+}
+
+// EventSnapshotsFromDomain builds the response from snapshots ordered by time
+func EventSnapshotsFromDomain(eventID string, from, to time.Time, snapshots []*domain.ZoneSalesSnapshot) *EventSnapshotsResponse {
+	resp := &EventSnapshotsResponse{
+		EventID: eventID,
+		From:    from,
+		To:      to,
+		Zones:   snapshots,
+		Totals:  []*EventSnapshotTotals{},
+	}
+	if resp.Zones == nil {
+		resp.Zones = []*domain.ZoneSalesSnapshot{}
+	}
+
+	var current *EventSnapshotTotals
+	for _, s := range resp.Zones {
+		if current == nil || !current.SnapshotAt.Equal(s.SnapshotAt) {
+			current = &EventSnapshotTotals{SnapshotAt: s.SnapshotAt}
+			resp.Totals = append(resp.Totals, current)
+		}
+		if s.AvailableSeats != nil {
+			current.AvailableSeats += *s.AvailableSeats
+		}
+		current.HeldSeats += s.HeldSeats
+		current.SoldSeats += s.SoldSeats
+		current.Revenue += s.Revenue
+	}
+	return resp
+}
@@ -0,0 +1,6 @@
+package dto
+
+// SetReservationShadowModeRequest represents request to switch which Redis serves reservations
+type SetReservationShadowModeRequest struct {
+	Mode string `json:"mode" binding:"required,oneof=shadow cutover"` // shadow serves from the current Redis, cutover from the new one
+}
@@ -1,5 +1,7 @@
 package dto
 
+import "github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
+
 // ErrorResponse represents an error response
 type ErrorResponse struct {
 	Error   string `json:"error"`
@@ -21,4 +23,5 @@ type PaginatedResponse struct {
 	PageSize   int         `json:"page_size"`
 	TotalItems int64       `json:"total_items"`
 	TotalPages int         `json:"total_pages"`
+	pagination.Info
 }
@@ -0,0 +1,23 @@
+package dto
+
+// SagaDefinitionResponse represents a saga definition the orchestrator runs
+type SagaDefinitionResponse struct {
+	Name        string                       `json:"name"`
+	Description string                       `json:"description"`
+	Timeout     string                       `json:"timeout"`
+	Steps       []SagaDefinitionStepResponse `json:"steps"`
+}
+
+// SagaDefinitionStepResponse represents a step of a saga definition
+type SagaDefinitionStepResponse struct {
+	Name    string `json:"name"`
+	Topic   string `json:"topic,omitempty"`
+	Timeout string `json:"timeout"`
+	Retries int    `json:"retries"`
+}
+
+// SagaDefinitionReloadResponse represents the result of reloading the saga definition file
+type SagaDefinitionReloadResponse struct {
+	Path        string                   `json:"path"`
+	Definitions []SagaDefinitionResponse `json:"definitions"`
+}
@@ -0,0 +1,50 @@
+package dto
+
+import "time"
+
+// SagaStatsResponse represents rolling saga aggregates for SLO dashboards, keyed by window ("1h", "24h")
+type SagaStatsResponse struct {
+	GeneratedAt time.Time                   `json:"generated_at"`
+	Windows     map[string]*SagaWindowStats `json:"windows"`
+}
+
+// SagaWindowStats aggregates sagas started within a rolling window
+type SagaWindowStats struct {
+	Since time.Time `json:"since"`
+	SagaOutcomeCounts
+	Sagas []*SagaDefinitionStats `json:"sagas"`
+	Steps []*SagaStepStats       `json:"steps"`
+}
+
+// SagaOutcomeCounts counts sagas by outcome.
+// CompensationRate is the share of started sagas that entered compensation;
+// SuccessRate is the share that completed.
+type SagaOutcomeCounts struct {
+	Started          int64   `json:"started"`
+	InProgress       int64   `json:"in_progress"` // pending or running
+	Completed        int64   `json:"completed"`
+	Compensating     int64   `json:"compensating"`
+	Compensated      int64   `json:"compensated"`
+	Failed           int64   `json:"failed"`
+	CompensationRate float64 `json:"compensation_rate"`
+	SuccessRate      float64 `json:"success_rate"`
+}
+
+// SagaDefinitionStats aggregates sagas of a single saga type within a window
+type SagaDefinitionStats struct {
+	SagaName string `json:"saga_name"`
+	SagaOutcomeCounts
+	AvgDurationMs float64 `json:"avg_duration_ms"` // finished sagas only
+	P95DurationMs float64 `json:"p95_duration_ms"` // finished sagas only
+}
+
+// SagaStepStats aggregates step latencies of a single step and outcome within a window
+type SagaStepStats struct {
+	SagaName      string  `json:"saga_name"`
+	StepName      string  `json:"step_name"`
+	Status        string  `json:"status"`
+	Count         int64   `json:"count"`
+	AvgDurationMs float64 `json:"avg_duration_ms"`
+	P95DurationMs float64 `json:"p95_duration_ms"`
+	MaxDurationMs float64 `json:"max_duration_ms"`
+}
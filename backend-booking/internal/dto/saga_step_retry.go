@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+// SagaStepRetryResponse describes a dead-lettered saga step command re-emitted by an operator
+type SagaStepRetryResponse struct {
+	SagaID       string    `json:"saga_id"`
+	StepName     string    `json:"step_name"`
+	Topic        string    `json:"topic"`
+	MessageID    string    `json:"message_id"` // ID of the re-emitted command
+	Attempt      int       `json:"attempt"`    // Retry count carried by the re-emitted command
+	DeadLetterID string    `json:"dead_letter_id"`
+	RetriedBy    string    `json:"retried_by"`
+	RetriedAt    time.Time `json:"retried_at"`
+}
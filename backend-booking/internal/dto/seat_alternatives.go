@@ -0,0 +1,30 @@
+package dto
+
+// InsufficientSeatsResponse is the 409 body of a reservation that cannot be filled.
+// Alternatives is omitted when they cannot be looked up.
+type InsufficientSeatsResponse struct {
+	ErrorResponse
+	Alternatives *SeatAlternatives `json:"alternatives,omitempty"`
+}
+
+// SeatAlternatives describes what can still be reserved instead of the requested quantity
+type SeatAlternatives struct {
+	ZoneID            string `json:"zone_id"`
+	RequestedQuantity int    `json:"requested_quantity"`
+	// AvailableInZone is the number of seats left in the requested zone
+	AvailableInZone int64 `json:"available_in_zone"`
+	// MaxQuantity is the largest quantity that can still be reserved in the requested zone (0 if sold out)
+	MaxQuantity int `json:"max_quantity"`
+	// Zones lists the nearest sibling zones of the show with seats left, closest first
+	Zones []ZoneAlternative `json:"zones"`
+}
+
+// ZoneAlternative is a sibling zone with seats left
+type ZoneAlternative struct {
+	ZoneID         string  `json:"zone_id"`
+	Name           string  `json:"name"`
+	Price          float64 `json:"price"`
+	AvailableSeats int64   `json:"available_seats"`
+	// FitsQuantity reports whether the requested quantity is still available in this zone
+	FitsQuantity bool `json:"fits_quantity"`
+}
@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// SetSeatMapRequest represents request to set a zone's reserved seating layout
+type SetSeatMapRequest struct {
+	Rows []domain.SeatRow `json:"rows" binding:"required,min=1"`
+}
+
+// SeatMapRowResponse represents a seat map row with its held seats
+type SeatMapRowResponse struct {
+	Label      string `json:"label"`
+	Seats      int    `json:"seats"`
+	AisleAfter []int  `json:"aisle_after,omitempty"`
+	Taken      []int  `json:"taken"` // Seat numbers currently held
+}
+
+// SeatMapResponse represents a zone's seat map and availability
+type SeatMapResponse struct {
+	ZoneID         string               `json:"zone_id"`
+	TotalSeats     int                  `json:"total_seats"`
+	AvailableSeats int                  `json:"available_seats"`
+	Rows           []SeatMapRowResponse `json:"rows"`
+}
+
+// SeatMapFromDomain converts a domain SeatMap and its bitmap to SeatMapResponse
+func SeatMapFromDomain(m *domain.SeatMap, taken domain.SeatBitmap) *SeatMapResponse {
+	resp := &SeatMapResponse{
+		ZoneID:     m.ZoneID,
+		TotalSeats: m.TotalSeats(),
+		Rows:       make([]SeatMapRowResponse, len(m.Rows)),
+	}
+
+	offset := 0
+	for i, row := range m.Rows {
+		held := make([]int, 0)
+		for number := 1; number <= row.Seats; number++ {
+			if taken.Taken(offset) {
+				held = append(held, number)
+			}
+			offset++
+		}
+		resp.Rows[i] = SeatMapRowResponse{
+			Label:      row.Label,
+			Seats:      row.Seats,
+			AisleAfter: row.AisleAfter,
+			Taken:      held,
+		}
+		resp.AvailableSeats += row.Seats - len(held)
+	}
+
+	return resp
+}
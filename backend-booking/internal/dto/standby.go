@@ -0,0 +1,75 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// JoinStandbyRequest represents request to join a zone standby list
+// (built from a sold-out ReserveSeatsRequest with join_standby set)
+type JoinStandbyRequest struct {
+	EventID  string `json:"event_id"`
+	ZoneID   string `json:"zone_id"`
+	ShowID   string `json:"show_id,omitempty"`
+	Quantity int    `json:"quantity"`
+	// NotifyChannels are how the user is invited when seats are held (default email)
+	NotifyChannels []string `json:"notify_channels,omitempty"`
+}
+
+// StandbyStatusResponse represents a user's place on a zone standby list
+type StandbyStatusResponse struct {
+	ZoneID   string `json:"zone_id"`
+	EventID  string `json:"event_id"`
+	Quantity int    `json:"quantity"`
+	Status   string `json:"status"`             // waiting or offered
+	Position int64  `json:"position,omitempty"` // 1-based, only while waiting
+	// OfferExpiresAt is the end of the exclusive window; reserve the same
+	// quantity before then to claim the held seats
+	OfferExpiresAt *time.Time `json:"offer_expires_at,omitempty"`
+	NotifyChannels []string   `json:"notify_channels,omitempty"`
+	JoinedAt       time.Time  `json:"joined_at"`
+}
+
+// StandbyFromDomain converts domain StandbyEntry to StandbyStatusResponse
+func StandbyFromDomain(e *domain.StandbyEntry) *StandbyStatusResponse {
+	return &StandbyStatusResponse{
+		ZoneID:         e.ZoneID,
+		EventID:        e.EventID,
+		Quantity:       e.Quantity,
+		Status:         e.Status.String(),
+		Position:       e.Position,
+		OfferExpiresAt: e.OfferExpiresAt,
+		NotifyChannels: e.NotifyChannels,
+		JoinedAt:       e.JoinedAt,
+	}
+}
+
+// StandbyStatsResponse represents a zone's standby list size and offer conversion
+type StandbyStatsResponse struct {
+	ZoneID  string `json:"zone_id"`
+	Waiting int64  `json:"waiting"`
+	Holding int64  `json:"holding"`
+	Joined  int64  `json:"joined"`
+	Left    int64  `json:"left"`
+	Offered int64  `json:"offered"`
+	Claimed int64  `json:"claimed"`
+	Lapsed  int64  `json:"lapsed"`
+	// ConversionRate is claimed / offered, 0 when nothing was offered yet
+	ConversionRate float64 `json:"conversion_rate"`
+}
+
+// StandbyStatsFromDomain converts domain StandbyStats to StandbyStatsResponse
+func StandbyStatsFromDomain(s *domain.StandbyStats) *StandbyStatsResponse {
+	return &StandbyStatsResponse{
+		ZoneID:         s.ZoneID,
+		Waiting:        s.Waiting,
+		Holding:        s.Holding,
+		Joined:         s.Joined,
+		Left:           s.Left,
+		Offered:        s.Offered,
+		Claimed:        s.Claimed,
+		Lapsed:         s.Lapsed,
+		ConversionRate: s.ConversionRate(),
+	}
+}
@@ -0,0 +1,14 @@
+package dto
+
+import "github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+
+// TenantShardsResponse lists the configured shards and the tenants assigned to them
+type TenantShardsResponse struct {
+	Shards      []string               `json:"shards"` // The default shard first
+	Assignments []database.TenantShard `json:"assignments"`
+}
+
+// AssignTenantShardRequest represents request to move a tenant to a shard
+type AssignTenantShardRequest struct {
+	Shard string `json:"shard" binding:"required"` // A configured shard, or "default"
+}
@@ -0,0 +1,13 @@
+package dto
+
+// AnonymizeUserDataRequest represents request to anonymize a user's bookings (GDPR deletion)
+type AnonymizeUserDataRequest struct {
+	AnonymizedID string `json:"anonymized_id" binding:"required,uuid"` // Replacement user ID shared across services
+}
+
+// AnonymizeUserDataResponse represents the result of a user data anonymization
+type AnonymizeUserDataResponse struct {
+	UserID       string `json:"user_id"`
+	AnonymizedID string `json:"anonymized_id"`
+	Anonymized   int64  `json:"anonymized"` // Number of bookings anonymized
+}
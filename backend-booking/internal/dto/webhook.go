@@ -0,0 +1,113 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// CreateWebhookSubscriptionRequest represents request to register a webhook endpoint
+type CreateWebhookSubscriptionRequest struct {
+	URL         string   `json:"url" binding:"required"`
+	EventTypes  []string `json:"event_types" binding:"required"`
+	Description string   `json:"description,omitempty"`
+}
+
+// UpdateWebhookSubscriptionRequest represents request to change a webhook endpoint.
+// Omitted fields are left unchanged.
+type UpdateWebhookSubscriptionRequest struct {
+	URL         *string  `json:"url,omitempty"`
+	EventTypes  []string `json:"event_types,omitempty"`
+	Description *string  `json:"description,omitempty"`
+	Active      *bool    `json:"active,omitempty"`
+}
+
+// WebhookSubscriptionResponse represents a webhook subscription in API responses
+type WebhookSubscriptionResponse struct {
+	ID          string   `json:"id"`
+	URL         string   `json:"url"`
+	EventTypes  []string `json:"event_types"`
+	Description string   `json:"description,omitempty"`
+	Active      bool     `json:"active"`
+	// Secret is only returned by the create call; store it to verify X-Webhook-Signature
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WebhookSubscriptionFromDomain converts domain WebhookSubscription to WebhookSubscriptionResponse
+func WebhookSubscriptionFromDomain(s *domain.WebhookSubscription) *WebhookSubscriptionResponse {
+	eventTypes := make([]string, 0, len(s.EventTypes))
+	for _, t := range s.EventTypes {
+		eventTypes = append(eventTypes, t.String())
+	}
+	return &WebhookSubscriptionResponse{
+		ID:          s.ID,
+		URL:         s.URL,
+		EventTypes:  eventTypes,
+		Description: s.Description,
+		Active:      s.Active,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+}
+
+// WebhookDeliveryListRequest represents filters for the webhook delivery log
+type WebhookDeliveryListRequest struct {
+	SubscriptionID string
+	EventID        string
+	EventType      string
+	Status         string
+	Limit          int
+	Offset         int
+}
+
+// WebhookDeliveryResponse represents a webhook delivery log entry
+type WebhookDeliveryResponse struct {
+	ID             string          `json:"id"`
+	SubscriptionID string          `json:"subscription_id"`
+	EventID        string          `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	MaxAttempts    int             `json:"max_attempts"`
+	LastStatusCode int             `json:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"` // Only while pending
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	Payload        json.RawMessage `json:"payload,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// WebhookDeliveryFromDomain converts domain WebhookDelivery to WebhookDeliveryResponse
+func WebhookDeliveryFromDomain(d *domain.WebhookDelivery) *WebhookDeliveryResponse {
+	resp := &WebhookDeliveryResponse{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      d.EventType.String(),
+		Status:         d.Status.String(),
+		Attempts:       d.Attempts,
+		MaxAttempts:    d.MaxAttempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		DeliveredAt:    d.DeliveredAt,
+		Payload:        d.Payload,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	}
+	if d.Status == domain.WebhookDeliveryPending {
+		next := d.NextAttemptAt
+		resp.NextAttemptAt = &next
+	}
+	return resp
+}
+
+// WebhookDeliveryListResponse represents a page of the webhook delivery log
+type WebhookDeliveryListResponse struct {
+	Deliveries []*WebhookDeliveryResponse `json:"deliveries"`
+	Limit      int                        `json:"limit"`
+	Offset     int                        `json:"offset"`
+}
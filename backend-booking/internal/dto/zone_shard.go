@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// SetZoneShardsRequest represents request to split a zone's availability across counters
+type SetZoneShardsRequest struct {
+	Shards int `json:"shards" binding:"required"` // 1 folds the zone back into one counter
+}
+
+// ZoneShardsResponse represents how a zone's availability is split
+type ZoneShardsResponse struct {
+	ZoneID         string  `json:"zone_id"`
+	Shards         int     `json:"shards"`
+	AvailableSeats int64   `json:"available_seats"`
+	ShardSeats     []int64 `json:"shard_seats"` // Available seats per shard, primary counter first
+}
+
+// ZoneShardsFromDomain converts a domain ZoneShardLayout to ZoneShardsResponse
+func ZoneShardsFromDomain(layout *domain.ZoneShardLayout) *ZoneShardsResponse {
+	return &ZoneShardsResponse{
+		ZoneID:         layout.ZoneID,
+		Shards:         layout.Shards,
+		AvailableSeats: layout.Total,
+		ShardSeats:     layout.Available,
+	}
+}
@@ -0,0 +1,468 @@
+// Package dualwrite mirrors reservation writes to a second Redis so the booking service
+// can move to a new cluster without downtime. The serving repository answers every call;
+// the mirror replays it in the background and the two results are compared, so
+// divergence shows up in metrics before the switch. Cutover swaps the roles and keeps
+// the old cluster mirrored, which leaves a rollback path until it is turned off.
+package dualwrite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/metrics"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+)
+
+// Modes
+const (
+	ModeShadow  = "shadow"  // Primary serves, shadow is mirrored
+	ModeCutover = "cutover" // Shadow serves, primary is mirrored
+)
+
+// Comparison outcomes, used in stats and metrics
+const (
+	OutcomeMatch           = "match"
+	OutcomeOutcomeMismatch = "outcome_mismatch" // Success or error code differ
+	OutcomeCountMismatch   = "count_mismatch"   // Same outcome, different seat counts
+	OutcomeMirrorError     = "mirror_error"
+	OutcomeDropped         = "dropped" // Mirror queue full or shutting down
+)
+
+// ErrInvalidMode is returned for a mode other than shadow or cutover
+var ErrInvalidMode = errors.New("dualwrite: mode must be shadow or cutover")
+
+// Config holds dual-write settings
+type Config struct {
+	Mode      string        // shadow or cutover
+	Timeout   time.Duration // Upper bound on a mirrored call (default: 500ms)
+	Workers   int           // Mirror lanes; calls for one booking or zone share a lane (default: 16)
+	QueueSize int           // Calls waiting per lane before new ones are dropped (default: 1024)
+}
+
+// Divergence describes a mirrored call whose result differed from the serving one
+type Divergence struct {
+	Operation string    `json:"operation"`
+	Key       string    `json:"key"` // Booking or zone ID
+	Outcome   string    `json:"outcome"`
+	Serving   string    `json:"serving"`
+	Mirror    string    `json:"mirror"`
+	At        time.Time `json:"at"`
+}
+
+// Stats counts mirrored calls by outcome since startup
+type Stats struct {
+	Mode              string      `json:"mode"`
+	Mirrored          int64       `json:"mirrored"`
+	Matched           int64       `json:"matched"`
+	OutcomeMismatches int64       `json:"outcome_mismatches"`
+	CountMismatches   int64       `json:"count_mismatches"`
+	MirrorErrors      int64       `json:"mirror_errors"`
+	Dropped           int64       `json:"dropped"`
+	Pending           int         `json:"pending"`
+	LastDivergence    *Divergence `json:"last_divergence,omitempty"`
+	LastMirrorError   string      `json:"last_mirror_error,omitempty"`
+}
+
+// ReservationRepository writes to both Redis clusters and serves from one of them
+type ReservationRepository struct {
+	primary repository.ReservationRepository
+	shadow  repository.ReservationRepository
+	config  Config
+	cutover atomic.Bool
+
+	lanes []chan func()
+	wg    sync.WaitGroup
+	// mu guards closed so nothing is sent on a lane after Close
+	mu     sync.RWMutex
+	closed bool
+
+	mirrored, matched, outcomeMismatches, countMismatches, mirrorErrors, dropped atomic.Int64
+
+	lastMu          sync.Mutex
+	lastDivergence  *Divergence
+	lastMirrorError string
+}
+
+// New creates a dual-write repository over the current (primary) and new (shadow) Redis
+// and starts its mirror lanes. The shadow must start as a copy of the primary.
+func New(primary, shadow repository.ReservationRepository, config Config) (*ReservationRepository, error) {
+	if config.Timeout <= 0 {
+		config.Timeout = 500 * time.Millisecond
+	}
+	if config.Workers <= 0 {
+		config.Workers = 16
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = 1024
+	}
+
+	r := &ReservationRepository{
+		primary: primary,
+		shadow:  shadow,
+		config:  config,
+		lanes:   make([]chan func(), config.Workers),
+	}
+	if err := r.SetMode(config.Mode); err != nil {
+		return nil, err
+	}
+	for i := range r.lanes {
+		r.lanes[i] = make(chan func(), config.QueueSize)
+		r.wg.Add(1)
+		go r.runLane(r.lanes[i])
+	}
+	return r, nil
+}
+
+// SetMode switches which Redis serves. Calls already queued for the mirror still run
+// against the repository that was mirrored when they were made.
+func (r *ReservationRepository) SetMode(mode string) error {
+	switch mode {
+	case ModeShadow:
+		r.cutover.Store(false)
+	case ModeCutover:
+		r.cutover.Store(true)
+	default:
+		return fmt.Errorf("%w, got %q", ErrInvalidMode, mode)
+	}
+	return nil
+}
+
+// Mode returns the current mode
+func (r *ReservationRepository) Mode() string {
+	if r.cutover.Load() {
+		return ModeCutover
+	}
+	return ModeShadow
+}
+
+// Stats returns mirrored call counts
+func (r *ReservationRepository) Stats() *Stats {
+	pending := 0
+	for _, lane := range r.lanes {
+		pending += len(lane)
+	}
+
+	r.lastMu.Lock()
+	defer r.lastMu.Unlock()
+	return &Stats{
+		Mode:              r.Mode(),
+		Mirrored:          r.mirrored.Load(),
+		Matched:           r.matched.Load(),
+		OutcomeMismatches: r.outcomeMismatches.Load(),
+		CountMismatches:   r.countMismatches.Load(),
+		MirrorErrors:      r.mirrorErrors.Load(),
+		Dropped:           r.dropped.Load(),
+		Pending:           pending,
+		LastDivergence:    r.lastDivergence,
+		LastMirrorError:   r.lastMirrorError,
+	}
+}
+
+// Close stops accepting mirrored calls and waits for the queued ones to finish
+func (r *ReservationRepository) Close() {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return
+	}
+	r.closed = true
+	for _, lane := range r.lanes {
+		close(lane)
+	}
+	r.mu.Unlock()
+	r.wg.Wait()
+}
+
+// ReserveSeats reserves on the serving Redis and mirrors the reservation under the same booking ID
+func (r *ReservationRepository) ReserveSeats(ctx context.Context, params repository.ReserveParams) (*repository.ReserveResult, error) {
+	serving, mirror := r.roles()
+	result, err := serving.ReserveSeats(ctx, params)
+	// A replay took no seats, so there is nothing to mirror
+	if err != nil || result.Replayed {
+		return result, err
+	}
+
+	if result.BookingID != "" {
+		params.BookingID = result.BookingID
+	}
+	key := params.BookingID
+	if key == "" {
+		key = params.ZoneID
+	}
+	r.mirror(ctx, "reserve_seats", key, func(ctx context.Context) (verdict, error) {
+		got, err := mirror.ReserveSeats(ctx, params)
+		if err != nil {
+			return verdict{}, err
+		}
+		return compareReserve(result, got)
+	})
+	return result, nil
+}
+
+// ConfirmBooking confirms on the serving Redis and mirrors the confirmation
+func (r *ReservationRepository) ConfirmBooking(ctx context.Context, bookingID, userID, paymentID string) (*repository.ConfirmResult, error) {
+	serving, mirror := r.roles()
+	result, err := serving.ConfirmBooking(ctx, bookingID, userID, paymentID)
+	if err != nil {
+		return result, err
+	}
+
+	r.mirror(ctx, "confirm_booking", bookingID, func(ctx context.Context) (verdict, error) {
+		got, err := mirror.ConfirmBooking(ctx, bookingID, userID, paymentID)
+		if err != nil {
+			return verdict{}, err
+		}
+		return compare(
+			outcome{result.Success, result.ErrorCode},
+			outcome{got.Success, got.ErrorCode},
+			nil, nil,
+		)
+	})
+	return result, nil
+}
+
+// ExtendHold extends the hold on the serving Redis and mirrors the extension
+func (r *ReservationRepository) ExtendHold(ctx context.Context, params repository.ExtendHoldParams) (*repository.ExtendHoldResult, error) {
+	serving, mirror := r.roles()
+	result, err := serving.ExtendHold(ctx, params)
+	if err != nil {
+		return result, err
+	}
+
+	r.mirror(ctx, "extend_hold", params.BookingID, func(ctx context.Context) (verdict, error) {
+		got, err := mirror.ExtendHold(ctx, params)
+		if err != nil {
+			return verdict{}, err
+		}
+		return compare(
+			outcome{result.Success, result.ErrorCode},
+			outcome{got.Success, got.ErrorCode},
+			[]int64{result.ExpiresAt.Unix()},
+			[]int64{got.ExpiresAt.Unix()},
+		)
+	})
+	return result, nil
+}
+
+// ReleaseSeats releases on the serving Redis and mirrors the release
+func (r *ReservationRepository) ReleaseSeats(ctx context.Context, bookingID, userID string) (*repository.ReleaseResult, error) {
+	serving, mirror := r.roles()
+	result, err := serving.ReleaseSeats(ctx, bookingID, userID)
+	if err != nil {
+		return result, err
+	}
+
+	r.mirror(ctx, "release_seats", bookingID, func(ctx context.Context) (verdict, error) {
+		got, err := mirror.ReleaseSeats(ctx, bookingID, userID)
+		if err != nil {
+			return verdict{}, err
+		}
+		return compare(
+			outcome{result.Success, result.ErrorCode},
+			outcome{got.Success, got.ErrorCode},
+			[]int64{result.AvailableSeats, result.UserReserved},
+			[]int64{got.AvailableSeats, got.UserReserved},
+		)
+	})
+	return result, nil
+}
+
+// ModifyReservation runs a change phase on the serving Redis and mirrors it. Phases of one
+// booking share a mirror lane, so a hold is always mirrored before its commit or abort.
+func (r *ReservationRepository) ModifyReservation(ctx context.Context, params repository.ModifyParams) (*repository.ModifyResult, error) {
+	serving, mirror := r.roles()
+	result, err := serving.ModifyReservation(ctx, params)
+	if err != nil {
+		return result, err
+	}
+
+	r.mirror(ctx, "modify_reservation", params.BookingID, func(ctx context.Context) (verdict, error) {
+		got, err := mirror.ModifyReservation(ctx, params)
+		if err != nil {
+			return verdict{}, err
+		}
+		return compare(
+			outcome{result.Success, result.ErrorCode},
+			outcome{got.Success, got.ErrorCode},
+			[]int64{result.AvailableSeats, result.UserReserved},
+			[]int64{got.AvailableSeats, got.UserReserved},
+		)
+	})
+	return result, nil
+}
+
+// GetZoneAvailability reads from the serving Redis; the mirror's count is compared in the background
+func (r *ReservationRepository) GetZoneAvailability(ctx context.Context, zoneID string) (int64, error) {
+	serving, mirror := r.roles()
+	seats, err := serving.GetZoneAvailability(ctx, zoneID)
+	if err != nil {
+		return seats, err
+	}
+
+	r.mirror(ctx, "get_zone_availability", zoneID, func(ctx context.Context) (verdict, error) {
+		got, err := mirror.GetZoneAvailability(ctx, zoneID)
+		if err != nil {
+			return verdict{}, err
+		}
+		return compare(outcome{success: true}, outcome{success: true}, []int64{seats}, []int64{got})
+	})
+	return seats, nil
+}
+
+// SetZoneAvailability sets availability on the serving Redis and mirrors it
+func (r *ReservationRepository) SetZoneAvailability(ctx context.Context, zoneID string, seats int64) error {
+	serving, mirror := r.roles()
+	if err := serving.SetZoneAvailability(ctx, zoneID, seats); err != nil {
+		return err
+	}
+
+	r.mirror(ctx, "set_zone_availability", zoneID, func(ctx context.Context) (verdict, error) {
+		if err := mirror.SetZoneAvailability(ctx, zoneID, seats); err != nil {
+			return verdict{}, err
+		}
+		return verdict{outcome: OutcomeMatch}, nil
+	})
+	return nil
+}
+
+// roles returns the serving and mirrored repository for the current mode
+func (r *ReservationRepository) roles() (serving, mirror repository.ReservationRepository) {
+	if r.cutover.Load() {
+		return r.shadow, r.primary
+	}
+	return r.primary, r.shadow
+}
+
+// verdict is how a mirrored result compared with the serving one
+type verdict struct {
+	outcome string
+	serving string // Describes the serving result when they differ
+	mirror  string // Describes the mirrored result when they differ
+}
+
+// mirrorCall replays a call on the mirror and compares the results
+type mirrorCall func(ctx context.Context) (verdict, error)
+
+// mirror queues a call on the lane of key. Calls are dropped rather than delaying the
+// request when the lane is full.
+func (r *ReservationRepository) mirror(ctx context.Context, operation, key string, call mirrorCall) {
+	// The mirrored call outlives the request but keeps its trace
+	ctx = context.WithoutCancel(ctx)
+	task := func() {
+		callCtx, cancel := context.WithTimeout(ctx, r.config.Timeout)
+		defer cancel()
+		v, err := call(callCtx)
+		r.record(ctx, operation, key, v, err)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if !r.closed {
+		select {
+		case r.lanes[laneIndex(key, len(r.lanes))] <- task:
+			return
+		default:
+		}
+	}
+	r.record(ctx, operation, key, verdict{outcome: OutcomeDropped}, nil)
+}
+
+// runLane runs the mirrored calls of one lane in order
+func (r *ReservationRepository) runLane(lane chan func()) {
+	defer r.wg.Done()
+	for task := range lane {
+		task()
+	}
+}
+
+// record counts a mirrored call and keeps the latest divergence
+func (r *ReservationRepository) record(ctx context.Context, operation, key string, v verdict, err error) {
+	if err != nil {
+		v.outcome = OutcomeMirrorError
+		r.lastMu.Lock()
+		r.lastMirrorError = fmt.Sprintf("%s %s: %v", operation, key, err)
+		r.lastMu.Unlock()
+	}
+	metrics.RecordReservationShadow(ctx, operation, v.outcome)
+
+	switch v.outcome {
+	case OutcomeDropped:
+		r.dropped.Add(1)
+		return
+	case OutcomeMatch:
+		r.matched.Add(1)
+	case OutcomeMirrorError:
+		r.mirrorErrors.Add(1)
+	case OutcomeOutcomeMismatch, OutcomeCountMismatch:
+		if v.outcome == OutcomeOutcomeMismatch {
+			r.outcomeMismatches.Add(1)
+		} else {
+			r.countMismatches.Add(1)
+		}
+		r.lastMu.Lock()
+		r.lastDivergence = &Divergence{
+			Operation: operation,
+			Key:       key,
+			Outcome:   v.outcome,
+			Serving:   v.serving,
+			Mirror:    v.mirror,
+			At:        time.Now(),
+		}
+		r.lastMu.Unlock()
+	}
+	r.mirrored.Add(1)
+}
+
+// outcome is the part of a result both clusters must agree on
+type outcome struct {
+	success bool
+	code    string
+}
+
+// String describes the outcome in a divergence
+func (o outcome) String() string {
+	if o.success {
+		return "success " + o.code
+	}
+	return "failed " + o.code
+}
+
+// compare classifies a mirrored result. Seat counts can legitimately differ for a moment
+// under concurrent load, since the mirror applies calls after the serving Redis did, so
+// they are reported apart from outcome mismatches.
+func compare(serving, mirror outcome, servingCounts, mirrorCounts []int64) (verdict, error) {
+	if serving != mirror {
+		return verdict{OutcomeOutcomeMismatch, serving.String(), mirror.String()}, nil
+	}
+	for i := range servingCounts {
+		if servingCounts[i] != mirrorCounts[i] {
+			return verdict{OutcomeCountMismatch, fmt.Sprint(servingCounts), fmt.Sprint(mirrorCounts)}, nil
+		}
+	}
+	return verdict{outcome: OutcomeMatch}, nil
+}
+
+// compareReserve classifies a mirrored reservation, which must also land on the same booking ID
+func compareReserve(serving, mirror *repository.ReserveResult) (verdict, error) {
+	if serving.Success && mirror.Success && serving.BookingID != mirror.BookingID {
+		return verdict{OutcomeOutcomeMismatch, "booking " + serving.BookingID, "booking " + mirror.BookingID}, nil
+	}
+	return compare(
+		outcome{serving.Success, serving.ErrorCode},
+		outcome{mirror.Success, mirror.ErrorCode},
+		[]int64{serving.AvailableSeats, serving.UserReserved},
+		[]int64{mirror.AvailableSeats, mirror.UserReserved},
+	)
+}
+
+// laneIndex maps a booking or zone ID to a mirror lane
+func laneIndex(key string, lanes int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum32() % uint32(lanes))
+}
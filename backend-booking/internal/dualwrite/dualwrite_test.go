@@ -0,0 +1,212 @@
+package dualwrite
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+)
+
+// stubReservationRepository records the calls it receives and answers with fixed results
+type stubReservationRepository struct {
+	mu        sync.Mutex
+	calls     []string
+	bookingID string
+	available int64
+	errorCode string
+	err       error
+	block     chan struct{} // Blocks every call until closed (nil = no blocking)
+}
+
+func (s *stubReservationRepository) record(call string) {
+	if s.block != nil {
+		<-s.block
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, call)
+}
+
+func (s *stubReservationRepository) recorded() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.calls...)
+}
+
+func (s *stubReservationRepository) ReserveSeats(ctx context.Context, params repository.ReserveParams) (*repository.ReserveResult, error) {
+	s.record("reserve:" + params.BookingID)
+	if s.err != nil {
+		return nil, s.err
+	}
+	bookingID := params.BookingID
+	if bookingID == "" {
+		bookingID = s.bookingID
+	}
+	return &repository.ReserveResult{Success: s.errorCode == "", ErrorCode: s.errorCode, BookingID: bookingID, AvailableSeats: s.available}, nil
+}
+
+func (s *stubReservationRepository) ConfirmBooking(ctx context.Context, bookingID, userID, paymentID string) (*repository.ConfirmResult, error) {
+	s.record("confirm:" + bookingID)
+	return &repository.ConfirmResult{Success: s.errorCode == "", ErrorCode: s.errorCode}, s.err
+}
+
+func (s *stubReservationRepository) ExtendHold(ctx context.Context, params repository.ExtendHoldParams) (*repository.ExtendHoldResult, error) {
+	s.record("extend:" + params.BookingID)
+	return &repository.ExtendHoldResult{Success: s.errorCode == "", ErrorCode: s.errorCode, ExpiresAt: params.Until}, s.err
+}
+
+func (s *stubReservationRepository) ReleaseSeats(ctx context.Context, bookingID, userID string) (*repository.ReleaseResult, error) {
+	s.record("release:" + bookingID)
+	return &repository.ReleaseResult{Success: s.errorCode == "", ErrorCode: s.errorCode, AvailableSeats: s.available}, s.err
+}
+
+func (s *stubReservationRepository) ModifyReservation(ctx context.Context, params repository.ModifyParams) (*repository.ModifyResult, error) {
+	s.record("modify:" + string(params.Phase))
+	return &repository.ModifyResult{Success: s.errorCode == "", ErrorCode: s.errorCode, AvailableSeats: s.available}, s.err
+}
+
+func (s *stubReservationRepository) GetZoneAvailability(ctx context.Context, zoneID string) (int64, error) {
+	s.record("get:" + zoneID)
+	return s.available, s.err
+}
+
+func (s *stubReservationRepository) SetZoneAvailability(ctx context.Context, zoneID string, seats int64) error {
+	s.record("set:" + zoneID)
+	return s.err
+}
+
+func newTestRepository(t *testing.T, primary, shadow *stubReservationRepository, config Config) *ReservationRepository {
+	t.Helper()
+	if config.Mode == "" {
+		config.Mode = ModeShadow
+	}
+	repo, err := New(primary, shadow, config)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return repo
+}
+
+func TestReservationRepository_MirrorsWritesUnderTheSameBookingID(t *testing.T) {
+	primary := &stubReservationRepository{bookingID: "b-1", available: 9}
+	shadow := &stubReservationRepository{bookingID: "other", available: 9}
+	repo := newTestRepository(t, primary, shadow, Config{})
+	ctx := context.Background()
+
+	result, err := repo.ReserveSeats(ctx, repository.ReserveParams{ZoneID: "zone-1", Quantity: 1})
+	if err != nil || result.BookingID != "b-1" {
+		t.Fatalf("ReserveSeats() = %+v, %v", result, err)
+	}
+	if _, err := repo.ConfirmBooking(ctx, "b-1", "user-1", "pay-1"); err != nil {
+		t.Fatalf("ConfirmBooking() error = %v", err)
+	}
+	repo.Close()
+
+	calls := shadow.recorded()
+	if len(calls) != 2 || calls[0] != "reserve:b-1" || calls[1] != "confirm:b-1" {
+		t.Errorf("shadow calls = %v, want reserve then confirm of b-1", calls)
+	}
+	stats := repo.Stats()
+	if stats.Mirrored != 2 || stats.Matched != 2 {
+		t.Errorf("Stats() = %+v, want 2 matched", stats)
+	}
+}
+
+func TestReservationRepository_ClassifiesDivergence(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		shadow *stubReservationRepository
+		check  func(*Stats) bool
+	}{
+		{"outcome mismatch", &stubReservationRepository{errorCode: "INSUFFICIENT_STOCK", available: 9}, func(s *Stats) bool {
+			return s.OutcomeMismatches == 1 && s.LastDivergence.Outcome == OutcomeOutcomeMismatch
+		}},
+		{"count mismatch", &stubReservationRepository{available: 8}, func(s *Stats) bool {
+			return s.CountMismatches == 1 && s.LastDivergence.Serving == "[9 0]"
+		}},
+		{"mirror error", &stubReservationRepository{err: errors.New("connection refused")}, func(s *Stats) bool {
+			return s.MirrorErrors == 1 && s.LastMirrorError != ""
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary := &stubReservationRepository{bookingID: "b-1", available: 9}
+			repo := newTestRepository(t, primary, tt.shadow, Config{})
+			if _, err := repo.ReserveSeats(ctx, repository.ReserveParams{ZoneID: "zone-1", Quantity: 1}); err != nil {
+				t.Fatalf("ReserveSeats() error = %v", err)
+			}
+			repo.Close()
+			if stats := repo.Stats(); !tt.check(stats) {
+				t.Errorf("Stats() = %+v, last divergence %+v", stats, stats.LastDivergence)
+			}
+		})
+	}
+}
+
+func TestReservationRepository_ServingErrorsAreNotMirrored(t *testing.T) {
+	primary := &stubReservationRepository{err: errors.New("lua failed")}
+	shadow := &stubReservationRepository{}
+	repo := newTestRepository(t, primary, shadow, Config{})
+
+	if _, err := repo.ReleaseSeats(context.Background(), "b-1", "user-1"); err == nil {
+		t.Fatal("ReleaseSeats() error = nil, want the serving error")
+	}
+	repo.Close()
+	if calls := shadow.recorded(); len(calls) != 0 {
+		t.Errorf("shadow calls = %v, want none", calls)
+	}
+}
+
+func TestReservationRepository_DropsWhenTheLaneIsFull(t *testing.T) {
+	primary := &stubReservationRepository{available: 9}
+	shadow := &stubReservationRepository{available: 9, block: make(chan struct{})}
+	repo := newTestRepository(t, primary, shadow, Config{Workers: 1, QueueSize: 1, Timeout: time.Second})
+	ctx := context.Background()
+
+	// The first call occupies the lane, the second waits, the third has no room
+	for i := 0; i < 3; i++ {
+		if err := repo.SetZoneAvailability(ctx, "zone-1", 9); err != nil {
+			t.Fatalf("SetZoneAvailability() error = %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	close(shadow.block)
+	repo.Close()
+
+	if stats := repo.Stats(); stats.Dropped != 1 || stats.Matched != 2 {
+		t.Errorf("Stats() = %+v, want 1 dropped and 2 matched", stats)
+	}
+}
+
+func TestReservationRepository_Cutover(t *testing.T) {
+	primary := &stubReservationRepository{available: 5}
+	shadow := &stubReservationRepository{available: 7}
+	repo := newTestRepository(t, primary, shadow, Config{})
+	ctx := context.Background()
+
+	if err := repo.SetMode("mirror"); !errors.Is(err, ErrInvalidMode) {
+		t.Fatalf("SetMode() error = %v, want %v", err, ErrInvalidMode)
+	}
+	if err := repo.SetMode(ModeCutover); err != nil {
+		t.Fatalf("SetMode() error = %v", err)
+	}
+
+	seats, err := repo.GetZoneAvailability(ctx, "zone-1")
+	if err != nil || seats != 7 {
+		t.Errorf("GetZoneAvailability() = %d, %v, want the new cluster's 7", seats, err)
+	}
+	repo.Close()
+
+	// The old cluster keeps being mirrored for rollback
+	if calls := primary.recorded(); len(calls) != 1 || calls[0] != "get:zone-1" {
+		t.Errorf("primary calls = %v, want the mirrored read", calls)
+	}
+	if stats := repo.Stats(); stats.Mode != ModeCutover || stats.CountMismatches != 1 {
+		t.Errorf("Stats() = %+v, want cutover with 1 count mismatch", stats)
+	}
+}
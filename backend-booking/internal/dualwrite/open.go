@@ -0,0 +1,48 @@
+package dualwrite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/config"
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+)
+
+// Open connects to the shadow Redis of cfg and wraps primary with dual-write. The shadow
+// client reuses the pool and timeout settings of the primary's redisCfg. The returned
+// close function drains the mirror lanes and closes the shadow client. The shadow keeps
+// its own reservation journal for journalRetention (0 = none), like the primary.
+func Open(ctx context.Context, cfg *config.ReservationShadowConfig, primary repository.ReservationRepository, redisCfg pkgredis.Config, journalRetention time.Duration) (*ReservationRepository, func(), error) {
+	redisCfg.Host = cfg.Host
+	redisCfg.Port = cfg.Port
+	redisCfg.Password = cfg.Password
+	redisCfg.DB = cfg.DB
+	client, err := pkgredis.NewClient(ctx, &redisCfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to shadow Redis: %w", err)
+	}
+
+	shadow := repository.NewRedisReservationRepository(client)
+	shadow.SetJournalRetention(journalRetention)
+	if err := shadow.LoadScripts(ctx); err != nil {
+		client.Close()
+		return nil, nil, fmt.Errorf("failed to load reservation scripts into shadow Redis: %w", err)
+	}
+
+	repo, err := New(primary, shadow, Config{
+		Mode:      cfg.Mode,
+		Timeout:   cfg.Timeout,
+		Workers:   cfg.Workers,
+		QueueSize: cfg.QueueSize,
+	})
+	if err != nil {
+		client.Close()
+		return nil, nil, err
+	}
+	return repo, func() {
+		repo.Close()
+		client.Close()
+	}, nil
+}
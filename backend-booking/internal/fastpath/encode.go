@@ -0,0 +1,129 @@
+package fastpath
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+)
+
+// The append encoders below write the same bytes encoding/json writes for the dto types,
+// without reflection or allocations beyond growing the buffer
+
+// appendReserveResponse appends a dto.ReserveSeatsResponse without a standby status
+func appendReserveResponse(b []byte, r *dto.ReserveSeatsResponse) []byte {
+	b = append(b, `{"booking_id":`...)
+	b = appendString(b, r.BookingID)
+	b = append(b, `,"status":`...)
+	b = appendString(b, r.Status)
+	b = append(b, `,"expires_at":`...)
+	b = appendTime(b, r.ExpiresAt)
+	b = append(b, `,"total_price":`...)
+	b = appendFloat(b, r.TotalPrice)
+	if len(r.Seats) > 0 {
+		b = append(b, `,"seats":[`...)
+		for i, seat := range r.Seats {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = appendString(b, seat)
+		}
+		b = append(b, ']')
+	}
+	return append(b, '}')
+}
+
+// appendAvailabilityResponse appends a dto.ZoneAvailabilityResponse
+func appendAvailabilityResponse(b []byte, zoneID string, available int64) []byte {
+	b = append(b, `{"zone_id":`...)
+	b = appendString(b, zoneID)
+	b = append(b, `,"available_seats":`...)
+	b = strconv.AppendInt(b, available, 10)
+	return append(b, '}')
+}
+
+// appendErrorResponse appends a dto.ErrorResponse
+func appendErrorResponse(b []byte, errMsg, code, message string) []byte {
+	b = append(b, `{"error":`...)
+	b = appendString(b, errMsg)
+	if code != "" {
+		b = append(b, `,"code":`...)
+		b = appendString(b, code)
+	}
+	if message != "" {
+		b = append(b, `,"message":`...)
+		b = appendString(b, message)
+	}
+	return append(b, '}')
+}
+
+// appendTime appends t as a quoted RFC 3339 time, like time.Time.MarshalJSON
+func appendTime(b []byte, t time.Time) []byte {
+	b = append(b, '"')
+	b = t.AppendFormat(b, time.RFC3339Nano)
+	return append(b, '"')
+}
+
+// appendFloat appends f like encoding/json, which switches to exponents for very small
+// and very large numbers; prices never need them, so those fall back to encoding/json
+func appendFloat(b []byte, f float64) []byte {
+	if abs := math.Abs(f); abs != 0 && (abs < 1e-6 || abs >= 1e21) || math.IsNaN(f) || math.IsInf(f, 0) {
+		encoded, _ := json.Marshal(f)
+		return append(b, encoded...)
+	}
+	return strconv.AppendFloat(b, f, 'f', -1, 64)
+}
+
+const hexDigits = "0123456789abcdef"
+
+// appendString appends s as a JSON string, escaping like encoding/json: control
+// characters, <, > and & as \u00XX, U+2028 and U+2029, and invalid UTF-8 as U+FFFD
+func appendString(b []byte, s string) []byte {
+	b = append(b, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= ' ' && c != '"' && c != '\\' && c != '<' && c != '>' && c != '&' {
+				i++
+				continue
+			}
+			b = append(b, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				b = append(b, '\\', c)
+			case '\n':
+				b = append(b, '\\', 'n')
+			case '\r':
+				b = append(b, '\\', 'r')
+			case '\t':
+				b = append(b, '\\', 't')
+			default:
+				b = append(b, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			b = append(b, s[start:i]...)
+			b = append(b, "\ufffd"...)
+			i += size
+			start = i
+			continue
+		}
+		if r == '\u2028' || r == '\u2029' {
+			b = append(b, s[start:i]...)
+			b = append(b, '\\', 'u', '2', '0', '2', hexDigits[r&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	b = append(b, s[start:]...)
+	return append(b, '"')
+}
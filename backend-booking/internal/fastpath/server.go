@@ -0,0 +1,408 @@
+// Package fastpath serves the two hottest booking routes, reserve and zone availability,
+// on a minimal net/http listener next to the Gin router. It exists to measure framework
+// overhead at peak in load tests: no middleware, pooled buffers and hand-written JSON
+// encoding, with the same services, identity checks and response bodies as the Gin route.
+//
+// Without the idempotency middleware, retries are deduplicated by the booking service:
+// an X-Idempotency-Key header is used as the reservation's idempotency key.
+package fastpath
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/middleware"
+)
+
+// Routes served by the fast path; reserve has the Gin route's path so gateway signatures verify
+const (
+	ReservePath        = "/api/v1/bookings/reserve"
+	AvailabilityPrefix = "/api/v1/bookings/availability/" // Followed by the zone ID
+)
+
+// DefaultMaxBodyBytes caps reserve request bodies, matching the request body limit of the Gin route
+const DefaultMaxBodyBytes = 16 << 10
+
+// Pooled buffers start at bufferSize, which reserve bodies and responses fit; buffers
+// grown past maxPooledBufferSize are left to the garbage collector
+const (
+	bufferSize          = 1 << 10
+	maxPooledBufferSize = 64 << 10
+)
+
+// errBodyTooLarge is returned by readBody past the body limit
+var errBodyTooLarge = errors.New("request body too large")
+
+// loadTestUserID is used for unsigned requests without a user, like the Gin user ID middleware
+const loadTestUserID = "test-user-1"
+
+// Reserver reserves seats (BookingService)
+type Reserver interface {
+	ReserveSeats(ctx context.Context, userID string, req *dto.ReserveSeatsRequest) (*dto.ReserveSeatsResponse, error)
+}
+
+// QueuePasses validates and consumes queue passes (QueueService)
+type QueuePasses interface {
+	ValidateQueuePass(ctx context.Context, userID, eventID, zoneID, queuePass string) error
+	DeleteQueuePass(ctx context.Context, userID, eventID string) error
+}
+
+// StandbyStatuses tells whether seats are held for a user from the standby list (StandbyService)
+type StandbyStatuses interface {
+	GetStandbyStatus(ctx context.Context, userID, zoneID string) (*dto.StandbyStatusResponse, error)
+}
+
+// AvailabilityReader reads zone availability (ReservationRepository)
+type AvailabilityReader interface {
+	GetZoneAvailability(ctx context.Context, zoneID string) (int64, error)
+}
+
+// Config contains configuration for the fast path handler
+type Config struct {
+	// InternalSecret verifies identity headers signed by the API gateway
+	InternalSecret string
+	// MaxClockSkew bounds the age of a gateway signature (default middleware.DefaultInternalClockSkew)
+	MaxClockSkew time.Duration
+	// AllowUnsigned trusts plain identity headers, as the Gin routes do in load tests
+	AllowUnsigned bool
+	// RequireQueuePass enforces the virtual queue on reserve
+	RequireQueuePass bool
+	// MaxBodyBytes caps reserve request bodies (default DefaultMaxBodyBytes)
+	MaxBodyBytes int64
+}
+
+// Handler serves the fast path routes
+type Handler struct {
+	reserver     Reserver
+	queuePasses  QueuePasses
+	standby      StandbyStatuses
+	availability AvailabilityReader
+	cfg          Config
+
+	buffers sync.Pool
+}
+
+// NewHandler creates a new fast path handler. standby may be nil.
+func NewHandler(reserver Reserver, queuePasses QueuePasses, standby StandbyStatuses, availability AvailabilityReader, cfg *Config) *Handler {
+	h := &Handler{
+		reserver:     reserver,
+		queuePasses:  queuePasses,
+		standby:      standby,
+		availability: availability,
+	}
+	if cfg != nil {
+		h.cfg = *cfg
+	}
+	if h.cfg.MaxBodyBytes <= 0 {
+		h.cfg.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+	h.buffers.New = func() interface{} {
+		buf := make([]byte, 0, bufferSize)
+		return &buf
+	}
+	return h
+}
+
+// ServeHTTP routes by exact path instead of a router tree; anything else is not found
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == ReservePath:
+		if r.Method != http.MethodPost {
+			h.writeError(w, http.StatusMethodNotAllowed, "method not allowed", "METHOD_NOT_ALLOWED", "")
+			return
+		}
+		h.reserve(w, r)
+	case strings.HasPrefix(r.URL.Path, AvailabilityPrefix):
+		if r.Method != http.MethodGet {
+			h.writeError(w, http.StatusMethodNotAllowed, "method not allowed", "METHOD_NOT_ALLOWED", "")
+			return
+		}
+		h.getAvailability(w, r)
+	default:
+		h.writeError(w, http.StatusNotFound, "not found", "NOT_FOUND", "")
+	}
+}
+
+// reserve handles POST /api/v1/bookings/reserve like BookingHandler.ReserveSeats
+func (h *Handler) reserve(w http.ResponseWriter, r *http.Request) {
+	claims, ok := h.authenticate(w, r)
+	if !ok {
+		return
+	}
+
+	buf := h.getBuffer()
+	defer h.putBuffer(buf)
+
+	var err error
+	if *buf, err = readBody(*buf, r.Body, h.cfg.MaxBodyBytes); err != nil {
+		if errors.Is(err, errBodyTooLarge) {
+			h.writeError(w, http.StatusRequestEntityTooLarge, "request body too large", "PAYLOAD_TOO_LARGE", "")
+			return
+		}
+		h.writeError(w, http.StatusBadRequest, "invalid request", "INVALID_REQUEST", "failed to read request body")
+		return
+	}
+
+	var req dto.ReserveSeatsRequest
+	if err := json.Unmarshal(*buf, &req); err != nil {
+		h.writeError(w, http.StatusBadRequest, "invalid request", "INVALID_REQUEST", err.Error())
+		return
+	}
+	if msg := validateReserve(&req); msg != "" {
+		h.writeError(w, http.StatusBadRequest, "invalid request", "INVALID_REQUEST", msg)
+		return
+	}
+
+	if req.TenantID == "" {
+		req.TenantID = claims.TenantID
+	}
+	if req.IdempotencyKey == "" {
+		req.IdempotencyKey = r.Header.Get(middleware.IdempotencyKeyHeader)
+	}
+	req.Verification = claims.Verification
+	if req.Verification == nil {
+		req.Verification = &middleware.Verification{RiskLevel: middleware.RiskLevelNormal}
+	}
+
+	ctx := r.Context()
+	if h.cfg.RequireQueuePass && !h.hasStandbyOffer(ctx, claims.UserID, req.ZoneID) {
+		if err := h.queuePasses.ValidateQueuePass(ctx, claims.UserID, req.EventID, req.ZoneID, req.QueuePass); err != nil {
+			h.writeServiceError(w, err)
+			return
+		}
+	}
+
+	result, err := h.reserver.ReserveSeats(ctx, claims.UserID, &req)
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+
+	// Sold out and placed on the standby list; rare enough for encoding/json
+	if result.Standby != nil {
+		body, err := json.Marshal(result)
+		if err != nil {
+			h.writeError(w, http.StatusInternalServerError, "internal server error", "INTERNAL_ERROR", "")
+			return
+		}
+		writeJSON(w, http.StatusAccepted, body)
+		return
+	}
+
+	if h.cfg.RequireQueuePass {
+		// Queue passes are one-time use; don't block the response
+		userID, eventID := claims.UserID, req.EventID
+		go func() {
+			_ = h.queuePasses.DeleteQueuePass(context.Background(), userID, eventID)
+		}()
+	}
+
+	// The request has been decoded into req, so its buffer is reused for the response
+	*buf = appendReserveResponse((*buf)[:0], result)
+	writeJSON(w, http.StatusCreated, *buf)
+}
+
+// getAvailability handles GET /api/v1/bookings/availability/:zone_id
+func (h *Handler) getAvailability(w http.ResponseWriter, r *http.Request) {
+	if _, ok := h.authenticate(w, r); !ok {
+		return
+	}
+
+	zoneID := strings.TrimPrefix(r.URL.Path, AvailabilityPrefix)
+	if zoneID == "" || strings.Contains(zoneID, "/") {
+		h.writeError(w, http.StatusBadRequest, "zone id required", "INVALID_REQUEST", "")
+		return
+	}
+
+	available, err := h.availability.GetZoneAvailability(r.Context(), zoneID)
+	if err != nil {
+		h.writeServiceError(w, err)
+		return
+	}
+	if available < 0 {
+		available = 0
+	}
+
+	buf := h.getBuffer()
+	defer h.putBuffer(buf)
+	*buf = appendAvailabilityResponse(*buf, zoneID, available)
+	writeJSON(w, http.StatusOK, *buf)
+}
+
+// authenticate verifies the caller like middleware.InternalAuthMiddleware, writing the
+// error response when it fails
+func (h *Handler) authenticate(w http.ResponseWriter, r *http.Request) (*middleware.InternalClaims, bool) {
+	if r.Header.Get(middleware.HeaderInternalSignature) != "" {
+		claims, err := middleware.VerifyInternalRequest(r, h.cfg.InternalSecret, h.cfg.MaxClockSkew, time.Now())
+		if err != nil {
+			if errors.Is(err, middleware.ErrSignatureExpired) {
+				h.writeError(w, http.StatusUnauthorized, "Internal signature has expired", "SIGNATURE_EXPIRED", "")
+				return nil, false
+			}
+			h.writeError(w, http.StatusUnauthorized, "Invalid internal signature", "INVALID_SIGNATURE", "")
+			return nil, false
+		}
+		return claims, true
+	}
+
+	if !h.cfg.AllowUnsigned {
+		h.writeError(w, http.StatusUnauthorized, "Internal signature is required", "MISSING_SIGNATURE", "")
+		return nil, false
+	}
+	claims := &middleware.InternalClaims{
+		UserID:   r.Header.Get(middleware.HeaderUserID),
+		TenantID: r.Header.Get(middleware.HeaderTenantID),
+	}
+	if claims.UserID == "" {
+		claims.UserID = loadTestUserID
+	}
+	if verification := r.Header.Get(middleware.HeaderUserVerification); verification != "" {
+		claims.Verification = middleware.ParseVerification(verification)
+	}
+	return claims, true
+}
+
+// hasStandbyOffer checks if seats in the zone are currently held for the user
+func (h *Handler) hasStandbyOffer(ctx context.Context, userID, zoneID string) bool {
+	if h.standby == nil {
+		return false
+	}
+	status, err := h.standby.GetStandbyStatus(ctx, userID, zoneID)
+	return err == nil && status.Status == domain.StandbyStatusOffered.String()
+}
+
+// validateReserve applies the binding rules of dto.ReserveSeatsRequest, returning the
+// failure or "" when the request is valid
+func validateReserve(req *dto.ReserveSeatsRequest) string {
+	switch {
+	case req.EventID == "":
+		return "event_id is required"
+	case req.ZoneID == "":
+		return "zone_id is required"
+	case req.Quantity < 1 || req.Quantity > 10:
+		return "quantity must be between 1 and 10"
+	case len(req.StandbyNotify) > 3:
+		return "standby_notify accepts at most 3 channels"
+	}
+	for _, channel := range req.StandbyNotify {
+		if channel != "email" && channel != "sms" && channel != "push" {
+			return "standby_notify channels must be email, sms or push"
+		}
+	}
+	if len(req.AddOns) > 10 {
+		return "add_ons accepts at most 10 items"
+	}
+	for _, addOn := range req.AddOns {
+		if err := addOn.Validate(); err != nil {
+			return err.Error()
+		}
+	}
+	return ""
+}
+
+// serviceErrors maps reserve and availability errors to the status and code the Gin routes respond with
+var serviceErrors = []struct {
+	err    error
+	status int
+	code   string
+}{
+	{domain.ErrZoneNotFound, http.StatusNotFound, "ZONE_NOT_FOUND"},
+	{domain.ErrInvalidUserID, http.StatusForbidden, "FORBIDDEN"},
+	{domain.ErrInvalidShowID, http.StatusBadRequest, "INVALID_SHOW_ID"},
+	{domain.ErrInvalidZoneID, http.StatusBadRequest, "INVALID_REQUEST"},
+	{domain.ErrInvalidQuantity, http.StatusBadRequest, "INVALID_REQUEST"},
+	{domain.ErrInvalidUnitPrice, http.StatusBadRequest, "INVALID_REQUEST"},
+	{domain.ErrInsufficientSeats, http.StatusConflict, "INSUFFICIENT_SEATS"},
+	{domain.ErrMaxTicketsExceeded, http.StatusConflict, "MAX_TICKETS_EXCEEDED"},
+	{domain.ErrIdempotencyKeyReused, http.StatusConflict, "IDEMPOTENCY_KEY_REUSED"},
+	{domain.ErrStandbyOfferMismatch, http.StatusConflict, "STANDBY_OFFER_MISMATCH"},
+	{domain.ErrAddOnNotFound, http.StatusBadRequest, "INVALID_ADD_ON"},
+	{domain.ErrInvalidAddOnQuantity, http.StatusBadRequest, "INVALID_ADD_ON"},
+	{domain.ErrAddOnLimitExceeded, http.StatusBadRequest, "INVALID_ADD_ON"},
+	{domain.ErrAddOnSoldOut, http.StatusConflict, "ADD_ON_SOLD_OUT"},
+	{domain.ErrNoContiguousSeats, http.StatusConflict, "NO_CONTIGUOUS_SEATS"},
+	{domain.ErrSeatAllocationConflict, http.StatusConflict, "SEAT_ALLOCATION_CONFLICT"},
+	{domain.ErrAccountNotVerified, http.StatusForbidden, "ACCOUNT_NOT_VERIFIED"},
+	{domain.ErrVerificationChallengeRequired, http.StatusForbidden, "VERIFICATION_CHALLENGE_REQUIRED"},
+	{domain.ErrQueuePassRequired, http.StatusForbidden, "QUEUE_PASS_REQUIRED"},
+	{domain.ErrInvalidQueuePass, http.StatusForbidden, "INVALID_QUEUE_PASS"},
+	{domain.ErrQueuePassExpired, http.StatusForbidden, "QUEUE_PASS_EXPIRED"},
+	{domain.ErrQueuePassUserMismatch, http.StatusForbidden, "QUEUE_PASS_MISMATCH"},
+	{domain.ErrQueuePassEventMismatch, http.StatusForbidden, "QUEUE_PASS_MISMATCH"},
+	{domain.ErrQueuePassZoneMismatch, http.StatusForbidden, "QUEUE_PASS_MISMATCH"},
+	{domain.ErrQueuePassRevoked, http.StatusForbidden, "QUEUE_PASS_REVOKED"},
+}
+
+// writeServiceError writes the response for an error returned by a service
+func (h *Handler) writeServiceError(w http.ResponseWriter, err error) {
+	for _, mapping := range serviceErrors {
+		if errors.Is(err, mapping.err) {
+			h.writeError(w, mapping.status, err.Error(), mapping.code, "")
+			return
+		}
+	}
+	h.writeError(w, http.StatusInternalServerError, "internal server error", "INTERNAL_ERROR", "")
+}
+
+// writeError writes a dto.ErrorResponse
+func (h *Handler) writeError(w http.ResponseWriter, status int, errMsg, code, message string) {
+	buf := h.getBuffer()
+	defer h.putBuffer(buf)
+	*buf = appendErrorResponse(*buf, errMsg, code, message)
+	writeJSON(w, status, *buf)
+}
+
+// getBuffer returns an empty pooled buffer
+func (h *Handler) getBuffer() *[]byte {
+	buf := h.buffers.Get().(*[]byte)
+	*buf = (*buf)[:0]
+	return buf
+}
+
+// putBuffer returns a buffer to the pool
+func (h *Handler) putBuffer(buf *[]byte) {
+	if cap(*buf) <= maxPooledBufferSize {
+		h.buffers.Put(buf)
+	}
+}
+
+// readBody appends body to buf, failing with errBodyTooLarge past limit bytes
+func readBody(buf []byte, body io.Reader, limit int64) ([]byte, error) {
+	for {
+		if len(buf) == cap(buf) {
+			buf = append(buf, 0)[:len(buf)]
+		}
+		n, err := body.Read(buf[len(buf):cap(buf)])
+		buf = buf[:len(buf)+n]
+		if int64(len(buf)) > limit {
+			return buf, errBodyTooLarge
+		}
+		if err == io.EOF {
+			return buf, nil
+		}
+		if err != nil {
+			return buf, err
+		}
+	}
+}
+
+// contentTypeJSON is assigned to the header map directly, skipping key canonicalization
+var contentTypeJSON = []string{"application/json; charset=utf-8"}
+
+// writeJSON writes an encoded JSON body
+func writeJSON(w http.ResponseWriter, status int, body []byte) {
+	header := w.Header()
+	header["Content-Type"] = contentTypeJSON
+	header["Content-Length"] = []string{strconv.Itoa(len(body))}
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
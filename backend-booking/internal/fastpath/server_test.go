@@ -0,0 +1,221 @@
+package fastpath
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/middleware"
+)
+
+const testSecret = "test-internal-secret"
+
+type mockReserver struct {
+	userID string
+	req    *dto.ReserveSeatsRequest
+	result *dto.ReserveSeatsResponse
+	err    error
+}
+
+func (m *mockReserver) ReserveSeats(ctx context.Context, userID string, req *dto.ReserveSeatsRequest) (*dto.ReserveSeatsResponse, error) {
+	m.userID = userID
+	m.req = req
+	return m.result, m.err
+}
+
+type mockQueuePasses struct {
+	err error
+}
+
+func (m *mockQueuePasses) ValidateQueuePass(ctx context.Context, userID, eventID, zoneID, queuePass string) error {
+	return m.err
+}
+
+func (m *mockQueuePasses) DeleteQueuePass(ctx context.Context, userID, eventID string) error {
+	return nil
+}
+
+type mockAvailability map[string]int64
+
+func (m mockAvailability) GetZoneAvailability(ctx context.Context, zoneID string) (int64, error) {
+	available, ok := m[zoneID]
+	if !ok {
+		return 0, domain.ErrZoneNotFound
+	}
+	return available, nil
+}
+
+// signedRequest builds a request signed like the API gateway does
+func signedRequest(method, path, body string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	middleware.SignInternalRequest(req, testSecret, &middleware.InternalClaims{UserID: "user-1", TenantID: "tenant-1"}, time.Now())
+	return req
+}
+
+func TestHandler_Reserve(t *testing.T) {
+	reserver := &mockReserver{result: &dto.ReserveSeatsResponse{
+		BookingID:  "booking-1",
+		Status:     "reserved",
+		ExpiresAt:  time.Date(2026, 10, 14, 12, 0, 0, 500, time.UTC),
+		TotalPrice: 3000.5,
+		Seats:      []string{"A-1", "A-2"},
+	}}
+	h := NewHandler(reserver, &mockQueuePasses{}, nil, mockAvailability{}, &Config{InternalSecret: testSecret})
+
+	req := signedRequest(http.MethodPost, ReservePath, `{"event_id":"event-1","zone_id":"zone-1","quantity":2}`)
+	req.Header.Set(middleware.IdempotencyKeyHeader, "retry-1")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if reserver.userID != "user-1" || reserver.req.TenantID != "tenant-1" || reserver.req.IdempotencyKey != "retry-1" {
+		t.Errorf("expected the caller's identity and idempotency key, got user %q and %+v", reserver.userID, reserver.req)
+	}
+
+	// The hand-written encoding is byte for byte what the Gin route returns
+	want, _ := json.Marshal(reserver.result)
+	if !bytes.Equal(w.Body.Bytes(), want) {
+		t.Errorf("body = %s, want %s", w.Body.String(), want)
+	}
+}
+
+func TestHandler_ReserveErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        Config
+		request    func() *http.Request
+		reserveErr error
+		passErr    error
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name: "unsigned",
+			request: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, ReservePath, strings.NewReader(`{}`))
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   "MISSING_SIGNATURE",
+		},
+		{
+			name: "signed for another path",
+			request: func() *http.Request {
+				req := signedRequest(http.MethodPost, "/api/v1/bookings/other", `{}`)
+				req.URL.Path = ReservePath
+				return req
+			},
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   "INVALID_SIGNATURE",
+		},
+		{
+			name: "invalid quantity",
+			request: func() *http.Request {
+				return signedRequest(http.MethodPost, ReservePath, `{"event_id":"e","zone_id":"z","quantity":11}`)
+			},
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "INVALID_REQUEST",
+		},
+		{
+			name: "body too large",
+			request: func() *http.Request {
+				return signedRequest(http.MethodPost, ReservePath, `{"event_id":"`+strings.Repeat("e", DefaultMaxBodyBytes)+`"}`)
+			},
+			wantStatus: http.StatusRequestEntityTooLarge,
+			wantCode:   "PAYLOAD_TOO_LARGE",
+		},
+		{
+			name: "queue pass required",
+			cfg:  Config{RequireQueuePass: true},
+			request: func() *http.Request {
+				return signedRequest(http.MethodPost, ReservePath, `{"event_id":"e","zone_id":"z","quantity":1}`)
+			},
+			passErr:    domain.ErrQueuePassRequired,
+			wantStatus: http.StatusForbidden,
+			wantCode:   "QUEUE_PASS_REQUIRED",
+		},
+		{
+			name: "sold out",
+			request: func() *http.Request {
+				return signedRequest(http.MethodPost, ReservePath, `{"event_id":"e","zone_id":"z","quantity":1}`)
+			},
+			reserveErr: domain.ErrInsufficientSeats,
+			wantStatus: http.StatusConflict,
+			wantCode:   "INSUFFICIENT_SEATS",
+		},
+		{
+			name:       "wrong method",
+			request:    func() *http.Request { return signedRequest(http.MethodGet, ReservePath, "") },
+			wantStatus: http.StatusMethodNotAllowed,
+			wantCode:   "METHOD_NOT_ALLOWED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			cfg.InternalSecret = testSecret
+			h := NewHandler(&mockReserver{err: tt.reserveErr}, &mockQueuePasses{err: tt.passErr}, nil, mockAvailability{}, &cfg)
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, tt.request())
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			var resp dto.ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || resp.Code != tt.wantCode {
+				t.Errorf("expected code %s, got %s (%v)", tt.wantCode, w.Body.String(), err)
+			}
+		})
+	}
+}
+
+func TestHandler_Availability(t *testing.T) {
+	h := NewHandler(&mockReserver{}, &mockQueuePasses{}, nil, mockAvailability{"zone-1": 42, "zone-2": -1}, &Config{AllowUnsigned: true})
+
+	tests := []struct {
+		zoneID     string
+		wantStatus int
+		wantSeats  int64
+	}{
+		{"zone-1", http.StatusOK, 42},
+		{"zone-2", http.StatusOK, 0},
+		{"zone-3", http.StatusNotFound, 0},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, AvailabilityPrefix+tt.zoneID, nil))
+
+		if w.Code != tt.wantStatus {
+			t.Fatalf("%s: expected status %d, got %d", tt.zoneID, tt.wantStatus, w.Code)
+		}
+		if tt.wantStatus != http.StatusOK {
+			continue
+		}
+		var resp dto.ZoneAvailabilityResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("%s: invalid body %s: %v", tt.zoneID, w.Body.String(), err)
+		}
+		if resp.ZoneID != tt.zoneID || resp.AvailableSeats != tt.wantSeats {
+			t.Errorf("%s: got %+v, want %d seats", tt.zoneID, resp, tt.wantSeats)
+		}
+	}
+}
+
+func TestAppendString(t *testing.T) {
+	for _, s := range []string{"plain", `quote " and \ backslash`, "tab\tnew\nline\x01", "<b>&amp;</b>", "ไทย", "line\u2028sep", "bad\xffutf8"} {
+		want, _ := json.Marshal(s)
+		if got := appendString(nil, s); !bytes.Equal(got, want) {
+			t.Errorf("appendString(%q) = %s, want %s", s, got, want)
+		}
+	}
+}
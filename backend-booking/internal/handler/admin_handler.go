@@ -10,6 +10,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
 	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
 	"go.opentelemetry.io/otel/attribute"
@@ -19,12 +20,13 @@ import (
 // AdminHandler handles admin HTTP requests
 type AdminHandler struct {
 	redis            *pkgredis.Client
+	shardService     service.ZoneShardService
 	ticketServiceURL string
 	httpClient       *http.Client
 }
 
 // NewAdminHandler creates a new admin handler
-func NewAdminHandler(redis *pkgredis.Client) *AdminHandler {
+func NewAdminHandler(redis *pkgredis.Client, shardService service.ZoneShardService) *AdminHandler {
 	ticketURL := os.Getenv("TICKET_SERVICE_URL")
 	if ticketURL == "" {
 		ticketURL = "http://localhost:8082"
@@ -32,6 +34,7 @@ func NewAdminHandler(redis *pkgredis.Client) *AdminHandler {
 
 	return &AdminHandler{
 		redis:            redis,
+		shardService:     shardService,
 		ticketServiceURL: ticketURL,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
@@ -120,9 +123,8 @@ func (h *AdminHandler) syncZoneAvailability(ctx context.Context) (int, error) {
 
 	count := 0
 	for _, zone := range ticketResp.Data {
-		// Set zone availability in Redis
-		key := fmt.Sprintf("zone:availability:%s", zone.ID)
-		if err := h.redis.Set(ctx, key, zone.AvailableSeats, 0).Err(); err != nil {
+		// Set zone availability in Redis; sharded zones rebalance from the primary counter
+		if err := h.shardService.ResetZoneAvailability(ctx, zone.ID, int64(zone.AvailableSeats)); err != nil {
 			continue
 		}
 		count++
@@ -193,6 +195,7 @@ func (h *AdminHandler) GetInventoryStatus(c *gin.Context) {
 		TicketAvailable int    `json:"ticket_available"`
 		TicketTotal     int    `json:"ticket_total"`
 		RedisAvailable  int64  `json:"redis_available"`
+		Shards          int    `json:"shards,omitempty"`
 		InSync          bool   `json:"in_sync"`
 	}
 
@@ -205,14 +208,14 @@ func (h *AdminHandler) GetInventoryStatus(c *gin.Context) {
 			TicketTotal:     zone.TotalSeats,
 		}
 
-		// Get Redis value
-		key := fmt.Sprintf("zone:availability:%s", zone.ID)
-		val, err := h.redis.Get(ctx, key).Int64()
+		// Get Redis value, summed over the zone's shards
+		layout, err := h.shardService.GetZoneShards(ctx, zone.ID)
 		if err != nil {
 			z.RedisAvailable = -1 // Not set in Redis
 			z.InSync = false
 		} else {
-			z.RedisAvailable = val
+			z.RedisAvailable = layout.AvailableSeats
+			z.Shards = layout.Shards
 			z.InSync = (int64(zone.AvailableSeats) == z.RedisAvailable)
 		}
 
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// AvailabilityHandler streams zone availability flips to frontends
+type AvailabilityHandler struct {
+	stream *service.AvailabilityStream
+}
+
+// NewAvailabilityHandler creates a new availability handler
+func NewAvailabilityHandler(stream *service.AvailabilityStream) *AvailabilityHandler {
+	return &AvailabilityHandler{
+		stream: stream,
+	}
+}
+
+// StreamAvailability handles GET /bookings/events/:event_id/availability/stream (SSE)
+// Sends a zone.low_stock, zone.sold_out or zone.back_in_stock event whenever one of the
+// event's zones crosses a threshold. Only flips are sent, so clients should read the
+// zones' availability when they (re)connect.
+func (h *AvailabilityHandler) StreamAvailability(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.availability.stream")
+	defer span.End()
+
+	eventID := c.Param("event_id")
+	if eventID == "" {
+		span.SetStatus(codes.Error, "event_id required")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "event_id required",
+			Code:  "INVALID_REQUEST",
+		})
+		return
+	}
+	span.SetAttributes(attribute.String("event_id", eventID))
+
+	events, cancel := h.stream.Subscribe(eventID)
+	defer cancel()
+
+	// Set SSE headers
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Disable nginx buffering
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	// Flips are rare; keep idle connections from being closed by proxies
+	keepalive := time.NewTicker(15 * time.Second)
+	defer keepalive.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// Client disconnected
+			span.SetStatus(codes.Ok, "")
+			return
+
+		case event := <-events:
+			data, _ := json.Marshal(event)
+			c.Writer.WriteString(fmt.Sprintf("event: %s\ndata: %s\n\n", event.EventType, data))
+			c.Writer.Flush()
+
+		case <-keepalive.C:
+			c.Writer.WriteString(":keepalive\n\n")
+			c.Writer.Flush()
+		}
+	}
+}
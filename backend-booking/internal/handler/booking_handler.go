@@ -1,14 +1,18 @@
 package handler
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/middleware"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -20,25 +24,67 @@ import (
 type BookingHandler struct {
 	bookingService   service.BookingService
 	queueService     service.QueueService
+	standbyService   service.StandbyService
 	requireQueuePass bool
+
+	// Async confirmation (optional)
+	asyncConfirmService service.AsyncConfirmService
+	asyncConfirmDefault bool
+
+	// Booking modification (optional)
+	modificationService service.BookingModificationService
+
+	// Self-service cancellation with refunds (optional)
+	cancellationService service.BookingCancellationService
+
+	// Alternatives for INSUFFICIENT_SEATS (optional)
+	alternativesService service.SeatAlternativesService
+
+	// Aggregated booking status (optional)
+	statusService service.BookingStatusService
+
+	// One-click release of idle reservations (optional)
+	idleReleaseService service.IdleReservationService
 }
 
 // BookingHandlerConfig contains configuration for booking handler
 type BookingHandlerConfig struct {
 	RequireQueuePass bool
+	// AsyncConfirmService enables 202 Accepted confirmations; nil confirms synchronously
+	AsyncConfirmService service.AsyncConfirmService
+	// AsyncConfirmDefault confirms asynchronously unless the client sends ?async=false
+	AsyncConfirmDefault bool
+	// ModificationService enables PATCH /bookings/:id; nil responds 501 Not Implemented
+	ModificationService service.BookingModificationService
+	// CancellationService enables cancellation quotes and refunds of confirmed bookings;
+	// nil only cancels unconfirmed reservations
+	CancellationService service.BookingCancellationService
+	// AlternativesService adds remaining quantity and sibling zones to INSUFFICIENT_SEATS; nil omits them
+	AlternativesService service.SeatAlternativesService
+	// StatusService enables GET /bookings/:id/full; nil responds 501 Not Implemented
+	StatusService service.BookingStatusService
+	// IdleReleaseService enables GET (preview) and POST (release) /bookings/release-link; nil responds 501 Not Implemented
+	IdleReleaseService service.IdleReservationService
 }
 
 // NewBookingHandler creates a new booking handler
-func NewBookingHandler(bookingService service.BookingService, queueService service.QueueService, cfg *BookingHandlerConfig) *BookingHandler {
-	requireQueuePass := false
-	if cfg != nil {
-		requireQueuePass = cfg.RequireQueuePass
+func NewBookingHandler(bookingService service.BookingService, queueService service.QueueService, standbyService service.StandbyService, cfg *BookingHandlerConfig) *BookingHandler {
+	h := &BookingHandler{
+		bookingService: bookingService,
+		queueService:   queueService,
+		standbyService: standbyService,
 	}
-	return &BookingHandler{
-		bookingService:   bookingService,
-		queueService:     queueService,
-		requireQueuePass: requireQueuePass,
+	if cfg != nil {
+		h.requireQueuePass = cfg.RequireQueuePass
+		h.asyncConfirmService = cfg.AsyncConfirmService
+		h.asyncConfirmDefault = cfg.AsyncConfirmDefault
+		h.modificationService = cfg.ModificationService
+		h.cancellationService = cfg.CancellationService
+		h.alternativesService = cfg.AlternativesService
+		h.statusService = cfg.StatusService
+		h.idleReleaseService = cfg.IdleReleaseService
 	}
+	return h
 }
 
 // ReserveSeats handles POST /bookings/reserve
@@ -76,6 +122,7 @@ func (h *BookingHandler) ReserveSeats(c *gin.Context) {
 	if req.TenantID == "" {
 		req.TenantID = c.GetString("tenant_id")
 	}
+	req.Verification = callerVerification(c)
 
 	span.SetAttributes(
 		attribute.String("user_id", userID),
@@ -87,8 +134,9 @@ func (h *BookingHandler) ReserveSeats(c *gin.Context) {
 	)
 
 	// Validate queue pass if required
-	if h.requireQueuePass {
-		if err := h.queueService.ValidateQueuePass(ctx, userID, req.EventID, req.QueuePass); err != nil {
+	// Users holding a standby offer already passed the queue when they joined standby
+	if h.requireQueuePass && !h.hasStandbyOffer(ctx, userID, req.ZoneID) {
+		if err := h.queueService.ValidateQueuePass(ctx, userID, req.EventID, req.ZoneID, req.QueuePass); err != nil {
 			span.RecordError(err)
 			span.SetStatus(codes.Error, err.Error())
 			h.handleError(c, err)
@@ -102,10 +150,22 @@ func (h *BookingHandler) ReserveSeats(c *gin.Context) {
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
+		if errors.Is(err, domain.ErrInsufficientSeats) && h.alternativesService != nil {
+			h.respondInsufficientSeats(c, &req, err)
+			return
+		}
 		h.handleError(c, err)
 		return
 	}
 
+	// Sold out and placed on the standby list; keep the queue pass
+	if result.Standby != nil {
+		span.SetAttributes(attribute.String("standby_status", result.Standby.Status))
+		span.SetStatus(codes.Ok, "")
+		c.JSON(http.StatusAccepted, result)
+		return
+	}
+
 	// Delete queue pass after successful reservation (one-time use)
 	if h.requireQueuePass && h.queueService != nil {
 		// Run in background - don't block the response
@@ -120,6 +180,8 @@ func (h *BookingHandler) ReserveSeats(c *gin.Context) {
 }
 
 // ConfirmBooking handles POST /bookings/:id/confirm
+// With ?async=true or "Prefer: respond-async" (when async confirm is enabled) the confirmation
+// is queued and 202 Accepted is returned with a status token; poll GET /bookings/:id/confirm-status
 func (h *BookingHandler) ConfirmBooking(c *gin.Context) {
 	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.booking.confirm")
 	defer span.End()
@@ -153,11 +215,28 @@ func (h *BookingHandler) ConfirmBooking(c *gin.Context) {
 	var req dto.ConfirmBookingRequest
 	// PaymentID is optional, so we don't fail if body is empty
 	_ = c.ShouldBindJSON(&req)
+	req.Verification = callerVerification(c)
 
 	if req.PaymentID != "" {
 		span.SetAttributes(attribute.String("payment_id", req.PaymentID))
 	}
 
+	if h.confirmAsync(c) {
+		span.SetAttributes(attribute.Bool("async", true))
+		status, err := h.asyncConfirmService.EnqueueConfirm(ctx, bookingID, userID, c.GetString("tenant_id"), &req)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			h.handleError(c, err)
+			return
+		}
+
+		span.SetStatus(codes.Ok, "")
+		c.Header("Location", status.StatusURL)
+		c.JSON(http.StatusAccepted, status)
+		return
+	}
+
 	result, err := h.bookingService.ConfirmBooking(ctx, bookingID, userID, &req)
 	if err != nil {
 		span.RecordError(err)
@@ -170,6 +249,55 @@ func (h *BookingHandler) ConfirmBooking(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// GetConfirmStatus handles GET /bookings/:id/confirm-status
+func (h *BookingHandler) GetConfirmStatus(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.booking.confirm_status")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	userID := c.GetString("user_id")
+	if userID == "" {
+		span.SetStatus(codes.Error, "unauthorized")
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error: "unauthorized",
+			Code:  "UNAUTHORIZED",
+		})
+		return
+	}
+
+	bookingID := c.Param("id")
+	if bookingID == "" {
+		span.SetStatus(codes.Error, "booking id required")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "booking id required",
+			Code:  "INVALID_REQUEST",
+		})
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("booking_id", bookingID),
+		attribute.String("user_id", userID),
+	)
+
+	if h.asyncConfirmService == nil {
+		span.SetStatus(codes.Error, "async confirm disabled")
+		h.handleError(c, domain.ErrConfirmJobNotFound)
+		return
+	}
+
+	result, err := h.asyncConfirmService.GetConfirmStatus(ctx, bookingID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, result)
+}
+
 // ReleaseBooking handles DELETE /bookings/:id
 func (h *BookingHandler) ReleaseBooking(c *gin.Context) {
 	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.booking.release")
@@ -239,12 +367,233 @@ func (h *BookingHandler) CancelBooking(c *gin.Context) {
 		return
 	}
 
+	var req dto.CancelBookingRequest
+	// The body is optional; without a quote token the current policy applies
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "invalid request")
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "invalid request",
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
 	span.SetAttributes(
 		attribute.String("booking_id", bookingID),
 		attribute.String("user_id", userID),
+		attribute.Bool("quoted", req.QuoteToken != ""),
 	)
 
-	result, err := h.bookingService.CancelBooking(ctx, bookingID, userID)
+	if h.cancellationService == nil {
+		result, err := h.bookingService.CancelBooking(ctx, bookingID, userID)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			h.handleError(c, err)
+			return
+		}
+
+		span.SetStatus(codes.Ok, "")
+		c.JSON(http.StatusOK, result)
+		return
+	}
+
+	result, err := h.cancellationService.CancelBooking(ctx, bookingID, userID, req.QuoteToken)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, result)
+}
+
+// PreviewReleaseLink handles GET /bookings/release-link?token=...
+// Shows the reservation the link in a reminder would release without releasing it, so
+// mail scanners and link prefetchers fetching the URL cannot release holds
+func (h *BookingHandler) PreviewReleaseLink(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.booking.preview_release_link")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	token, ok := h.releaseLinkToken(c)
+	if !ok {
+		span.SetStatus(codes.Error, "invalid request")
+		return
+	}
+
+	result, err := h.idleReleaseService.PreviewReleaseLink(ctx, token)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, result)
+}
+
+// ReleaseWithLink handles POST /bookings/release-link?token=...
+// Releases an idle reservation from the link in a reminder; the token authorizes the request
+func (h *BookingHandler) ReleaseWithLink(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.booking.release_with_link")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	token, ok := h.releaseLinkToken(c)
+	if !ok {
+		span.SetStatus(codes.Error, "invalid request")
+		return
+	}
+
+	result, err := h.idleReleaseService.ReleaseWithLink(ctx, token)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, result)
+}
+
+// releaseLinkToken returns the token of a release link request, or responds with an
+// error if release links are disabled or the token is missing
+func (h *BookingHandler) releaseLinkToken(c *gin.Context) (string, bool) {
+	if h.idleReleaseService == nil {
+		c.JSON(http.StatusNotImplemented, dto.ErrorResponse{
+			Error: "release links are not enabled",
+			Code:  "NOT_IMPLEMENTED",
+		})
+		return "", false
+	}
+
+	token := c.Query("token")
+	if token == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "token required",
+			Code:  "INVALID_REQUEST",
+		})
+		return "", false
+	}
+	return token, true
+}
+
+// GetCancellationQuote handles GET /bookings/:id/cancellation-quote
+// Returns the refund the booking gets if cancelled now, with a token guaranteeing it for a short time
+func (h *BookingHandler) GetCancellationQuote(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.booking.cancellation_quote")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	if h.cancellationService == nil {
+		span.SetStatus(codes.Error, "cancellation quotes not enabled")
+		c.JSON(http.StatusNotImplemented, dto.ErrorResponse{
+			Error: "cancellation quotes are not enabled",
+			Code:  "NOT_IMPLEMENTED",
+		})
+		return
+	}
+
+	userID := c.GetString("user_id")
+	if userID == "" {
+		span.SetStatus(codes.Error, "unauthorized")
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error: "unauthorized",
+			Code:  "UNAUTHORIZED",
+		})
+		return
+	}
+
+	bookingID := c.Param("id")
+	if bookingID == "" {
+		span.SetStatus(codes.Error, "booking id required")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "booking id required",
+			Code:  "INVALID_REQUEST",
+		})
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("booking_id", bookingID),
+		attribute.String("user_id", userID),
+	)
+
+	result, err := h.cancellationService.QuoteCancellation(ctx, bookingID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, result)
+}
+
+// ModifyBooking handles PATCH /bookings/:id
+// Changes the zone or quantity of a booking; paid bookings are charged or refunded the difference
+func (h *BookingHandler) ModifyBooking(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.booking.modify")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	if h.modificationService == nil {
+		span.SetStatus(codes.Error, "modification not enabled")
+		c.JSON(http.StatusNotImplemented, dto.ErrorResponse{
+			Error: "booking modification is not enabled",
+			Code:  "NOT_IMPLEMENTED",
+		})
+		return
+	}
+
+	userID := c.GetString("user_id")
+	if userID == "" {
+		span.SetStatus(codes.Error, "unauthorized")
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error: "unauthorized",
+			Code:  "UNAUTHORIZED",
+		})
+		return
+	}
+
+	bookingID := c.Param("id")
+	if bookingID == "" {
+		span.SetStatus(codes.Error, "booking id required")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "booking id required",
+			Code:  "INVALID_REQUEST",
+		})
+		return
+	}
+
+	var req dto.ModifyBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid request",
+			Code:    "INVALID_REQUEST",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("booking_id", bookingID),
+		attribute.String("user_id", userID),
+	)
+
+	result, err := h.modificationService.ModifyBooking(ctx, bookingID, userID, &req)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
@@ -299,6 +648,151 @@ func (h *BookingHandler) GetBooking(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// GetBookingFull handles GET /bookings/:id/full
+// Returns the booking with its payment, saga and ticket issuance; sections whose service
+// is down are reported unavailable instead of failing the request
+func (h *BookingHandler) GetBookingFull(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.booking.get_full")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	if h.statusService == nil {
+		span.SetStatus(codes.Error, "booking status not enabled")
+		c.JSON(http.StatusNotImplemented, dto.ErrorResponse{
+			Error: "booking status is not enabled",
+			Code:  "NOT_IMPLEMENTED",
+		})
+		return
+	}
+
+	userID := c.GetString("user_id")
+	if userID == "" {
+		span.SetStatus(codes.Error, "unauthorized")
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error: "unauthorized",
+			Code:  "UNAUTHORIZED",
+		})
+		return
+	}
+
+	bookingID := c.Param("id")
+	span.SetAttributes(
+		attribute.String("booking_id", bookingID),
+		attribute.String("user_id", userID),
+	)
+
+	result, err := h.statusService.GetFullStatus(ctx, bookingID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetAttributes(attribute.Bool("partial", result.Partial))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, result)
+}
+
+// GetInternalBooking handles GET /internal/users/:user_id/bookings/:id
+// Used by payment-service to check payment amounts against the booking total
+func (h *BookingHandler) GetInternalBooking(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.booking.get_internal")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	userID := c.Param("user_id")
+	bookingID := c.Param("id")
+	span.SetAttributes(
+		attribute.String("booking_id", bookingID),
+		attribute.String("user_id", userID),
+	)
+
+	result, err := h.bookingService.GetBooking(ctx, bookingID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Data:    result,
+	})
+}
+
+// ExtendInternalHold handles POST /internal/users/:user_id/bookings/:id/extend-hold.
+// The payment service calls it while a payment is still processing at the gateway,
+// so a delayed webhook does not let the booking expire after the customer paid.
+func (h *BookingHandler) ExtendInternalHold(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.booking.extend_internal_hold")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	userID := c.Param("user_id")
+	bookingID := c.Param("id")
+	span.SetAttributes(
+		attribute.String("booking_id", bookingID),
+		attribute.String("user_id", userID),
+	)
+
+	result, err := h.bookingService.ExtendHold(ctx, bookingID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Data:    result,
+	})
+}
+
+// AwaitInternalPayment handles POST /internal/users/:user_id/bookings/:id/await-payment.
+// The payment service calls it when the customer has to finish paying at the gateway,
+// such as 3-D Secure or a bank transfer, so the booking waits for the webhook or return.
+func (h *BookingHandler) AwaitInternalPayment(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.booking.await_internal_payment")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	userID := c.Param("user_id")
+	bookingID := c.Param("id")
+	span.SetAttributes(
+		attribute.String("booking_id", bookingID),
+		attribute.String("user_id", userID),
+	)
+
+	var req dto.AwaitPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.SetStatus(codes.Error, "invalid request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_REQUEST",
+		})
+		return
+	}
+
+	result, err := h.bookingService.AwaitPayment(ctx, bookingID, userID, req.PaymentID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Data:    result,
+	})
+}
+
 // GetUserBookings handles GET /bookings
 func (h *BookingHandler) GetUserBookings(c *gin.Context) {
 	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.booking.list")
@@ -315,27 +809,38 @@ func (h *BookingHandler) GetUserBookings(c *gin.Context) {
 		return
 	}
 
-	// Parse pagination parameters
+	// Parse pagination parameters; cursor takes precedence over page
 	page := 1
-	pageSize := 20
+	pageSize := pagination.DefaultLimit
 	if p := c.Query("page"); p != "" {
 		if n, err := strconv.Atoi(p); err == nil && n > 0 {
 			page = n
 		}
 	}
 	if ps := c.Query("page_size"); ps != "" {
-		if n, err := strconv.Atoi(ps); err == nil && n > 0 && n <= 100 {
+		if n, err := strconv.Atoi(ps); err == nil && n > 0 && n <= pagination.MaxLimit {
 			pageSize = n
 		}
 	}
 
+	params, err := pagination.NewParams(c.Query("cursor"), pageSize, (page-1)*pageSize)
+	if err != nil {
+		span.SetStatus(codes.Error, "invalid cursor")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "invalid cursor",
+			Code:  "INVALID_CURSOR",
+		})
+		return
+	}
+
 	span.SetAttributes(
 		attribute.String("user_id", userID),
 		attribute.Int("page", page),
 		attribute.Int("page_size", pageSize),
+		attribute.Bool("cursor", params.After != nil),
 	)
 
-	result, err := h.bookingService.GetUserBookings(ctx, userID, page, pageSize)
+	result, err := h.bookingService.GetUserBookings(ctx, userID, params)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
@@ -422,11 +927,64 @@ func (h *BookingHandler) GetPendingBookings(c *gin.Context) {
 	})
 }
 
+// callerVerification returns the caller's verification state from the identity headers.
+// Requests without it are treated as unverified so the verification gate cannot be skipped.
+func callerVerification(c *gin.Context) *middleware.Verification {
+	if verification, ok := middleware.GetVerification(c); ok && verification != nil {
+		return verification
+	}
+	return &middleware.Verification{RiskLevel: middleware.RiskLevelNormal}
+}
+
+// hasStandbyOffer checks if seats in the zone are currently held for the user
+func (h *BookingHandler) hasStandbyOffer(ctx context.Context, userID, zoneID string) bool {
+	if h.standbyService == nil {
+		return false
+	}
+	status, err := h.standbyService.GetStandbyStatus(ctx, userID, zoneID)
+	return err == nil && status.Status == domain.StandbyStatusOffered.String()
+}
+
+// confirmAsync reports whether a confirm request should be queued. Async is a preference:
+// without an async confirm service the booking is confirmed synchronously.
+func (h *BookingHandler) confirmAsync(c *gin.Context) bool {
+	if h.asyncConfirmService == nil {
+		return false
+	}
+	if async, err := strconv.ParseBool(c.Query("async")); err == nil {
+		return async
+	}
+	for _, pref := range strings.Split(c.GetHeader("Prefer"), ",") {
+		if strings.EqualFold(strings.TrimSpace(pref), "respond-async") {
+			return true
+		}
+	}
+	return h.asyncConfirmDefault
+}
+
 // handleError converts domain errors to HTTP responses
+// respondInsufficientSeats responds 409 INSUFFICIENT_SEATS with what can still be reserved.
+// Alternatives are omitted if they cannot be looked up.
+func (h *BookingHandler) respondInsufficientSeats(c *gin.Context, req *dto.ReserveSeatsRequest, err error) {
+	alternatives, lookupErr := h.alternativesService.FindAlternatives(c.Request.Context(), req.ShowID, req.ZoneID, req.Quantity)
+	if lookupErr != nil {
+		_ = c.Error(lookupErr)
+		alternatives = nil
+	}
+	c.JSON(http.StatusConflict, dto.InsufficientSeatsResponse{
+		ErrorResponse: dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "INSUFFICIENT_SEATS",
+		},
+		Alternatives: alternatives,
+	})
+}
+
 func (h *BookingHandler) handleError(c *gin.Context, err error) {
 	switch {
 	case errors.Is(err, domain.ErrBookingNotFound),
-		errors.Is(err, domain.ErrReservationNotFound):
+		errors.Is(err, domain.ErrReservationNotFound),
+		errors.Is(err, domain.ErrConfirmJobNotFound):
 		c.JSON(http.StatusNotFound, dto.ErrorResponse{
 			Error: err.Error(),
 			Code:  "NOT_FOUND",
@@ -457,11 +1015,131 @@ func (h *BookingHandler) handleError(c *gin.Context, err error) {
 			Error: err.Error(),
 			Code:  "MAX_TICKETS_EXCEEDED",
 		})
+	case errors.Is(err, domain.ErrIdempotencyKeyReused):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   err.Error(),
+			Code:    "IDEMPOTENCY_KEY_REUSED",
+			Message: "Use a new idempotency key for a different reservation",
+		})
+	case errors.Is(err, domain.ErrStandbyOfferMismatch):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   err.Error(),
+			Code:    "STANDBY_OFFER_MISMATCH",
+			Message: "Reserve the same event and quantity you joined the standby list with",
+		})
+	case errors.Is(err, domain.ErrAddOnNotFound),
+		errors.Is(err, domain.ErrInvalidAddOnQuantity),
+		errors.Is(err, domain.ErrAddOnLimitExceeded):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_ADD_ON",
+		})
+	case errors.Is(err, domain.ErrAddOnSoldOut):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "ADD_ON_SOLD_OUT",
+		})
+	case errors.Is(err, domain.ErrNoContiguousSeats):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   err.Error(),
+			Code:    "NO_CONTIGUOUS_SEATS",
+			Message: "Retry without require_contiguous to accept seats split across rows",
+		})
+	case errors.Is(err, domain.ErrSeatAllocationConflict):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "SEAT_ALLOCATION_CONFLICT",
+		})
 	case errors.Is(err, domain.ErrAlreadyConfirmed):
 		c.JSON(http.StatusConflict, dto.ErrorResponse{
 			Error: err.Error(),
 			Code:  "ALREADY_CONFIRMED",
 		})
+	// Modification errors
+	case errors.Is(err, domain.ErrNoModification),
+		errors.Is(err, domain.ErrInvalidZoneID),
+		errors.Is(err, domain.ErrInvalidQuantity),
+		errors.Is(err, domain.ErrInvalidUnitPrice),
+		errors.Is(err, domain.ErrInvalidPaymentID):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_REQUEST",
+		})
+	case errors.Is(err, domain.ErrModificationNotAllowed):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "MODIFICATION_NOT_ALLOWED",
+		})
+	case errors.Is(err, domain.ErrShowStarted):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "SHOW_STARTED",
+		})
+	case errors.Is(err, domain.ErrAssignedSeatsNotModified):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   err.Error(),
+			Code:    "ASSIGNED_SEATS",
+			Message: "Bookings with assigned seats cannot change zone or quantity; cancel and book again",
+		})
+	case errors.Is(err, domain.ErrModificationInProgress):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "MODIFICATION_IN_PROGRESS",
+		})
+	case errors.Is(err, domain.ErrPaymentAdjustmentFailed):
+		c.JSON(http.StatusPaymentRequired, dto.ErrorResponse{
+			Error:   err.Error(),
+			Code:    "PAYMENT_FAILED",
+			Message: "The price difference could not be settled; the booking is unchanged",
+		})
+	// Cancellation errors
+	case errors.Is(err, domain.ErrCancellationClosed):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "CANCELLATION_CLOSED",
+		})
+	case errors.Is(err, domain.ErrInvalidCancellationQuote):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   err.Error(),
+			Code:    "INVALID_QUOTE",
+			Message: "Request a new cancellation quote and try again",
+		})
+	case errors.Is(err, domain.ErrCancellationQuoteExpired):
+		c.JSON(http.StatusGone, dto.ErrorResponse{
+			Error:   err.Error(),
+			Code:    "QUOTE_EXPIRED",
+			Message: "Request a new cancellation quote and try again",
+		})
+	case errors.Is(err, domain.ErrCancellationRefundFailed):
+		c.JSON(http.StatusBadGateway, dto.ErrorResponse{
+			Error:   err.Error(),
+			Code:    "REFUND_FAILED",
+			Message: "The refund could not be issued; the booking is unchanged",
+		})
+	// Idle reservation release errors
+	case errors.Is(err, domain.ErrInvalidReleaseLink):
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_RELEASE_LINK",
+		})
+	case errors.Is(err, domain.ErrReleaseLinkExpired):
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error:   err.Error(),
+			Code:    "RELEASE_LINK_EXPIRED",
+			Message: "The reservation has already expired and its seats were released",
+		})
+	case errors.Is(err, domain.ErrReleaseLinkOutdated):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   err.Error(),
+			Code:    "RELEASE_LINK_OUTDATED",
+			Message: "The reservation changed since the link was sent; release it from your bookings instead",
+		})
+	case errors.Is(err, domain.ErrPaymentInProgress):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   err.Error(),
+			Code:    "PAYMENT_IN_PROGRESS",
+			Message: "A payment was started for this reservation; it was not released",
+		})
 	case errors.Is(err, domain.ErrAlreadyReleased):
 		c.JSON(http.StatusConflict, dto.ErrorResponse{
 			Error: err.Error(),
@@ -473,6 +1151,19 @@ func (h *BookingHandler) handleError(c *gin.Context, err error) {
 			Error: err.Error(),
 			Code:  "EXPIRED",
 		})
+	// Verification errors
+	case errors.Is(err, domain.ErrAccountNotVerified):
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error:   err.Error(),
+			Code:    "ACCOUNT_NOT_VERIFIED",
+			Message: "Verify your email and phone number, refresh your session and try again",
+		})
+	case errors.Is(err, domain.ErrVerificationChallengeRequired):
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error:   err.Error(),
+			Code:    "VERIFICATION_CHALLENGE_REQUIRED",
+			Message: "Complete a verification challenge at " + service.VerificationChallengeURL + ", refresh your session and try again",
+		})
 	// Queue pass errors
 	case errors.Is(err, domain.ErrQueuePassRequired):
 		c.JSON(http.StatusForbidden, dto.ErrorResponse{
@@ -492,11 +1183,18 @@ func (h *BookingHandler) handleError(c *gin.Context, err error) {
 			Message: "Your queue pass has expired. Please rejoin the queue.",
 		})
 	case errors.Is(err, domain.ErrQueuePassUserMismatch),
-		errors.Is(err, domain.ErrQueuePassEventMismatch):
+		errors.Is(err, domain.ErrQueuePassEventMismatch),
+		errors.Is(err, domain.ErrQueuePassZoneMismatch):
 		c.JSON(http.StatusForbidden, dto.ErrorResponse{
 			Error: err.Error(),
 			Code:  "QUEUE_PASS_MISMATCH",
 		})
+	case errors.Is(err, domain.ErrQueuePassRevoked):
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error:   err.Error(),
+			Code:    "QUEUE_PASS_REVOKED",
+			Message: "Your queue pass is no longer valid. Please rejoin the queue.",
+		})
 	default:
 		_ = c.Error(err) // Log the error with gin
 		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -12,19 +13,22 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 )
 
 // MockBookingService is a mock implementation of BookingService for testing
 type MockBookingService struct {
-	ReserveSeatsFunc           func(ctx context.Context, userID string, req *dto.ReserveSeatsRequest) (*dto.ReserveSeatsResponse, error)
-	ConfirmBookingFunc         func(ctx context.Context, bookingID, userID string, req *dto.ConfirmBookingRequest) (*dto.ConfirmBookingResponse, error)
-	CancelBookingFunc          func(ctx context.Context, bookingID, userID string) (*dto.ReleaseBookingResponse, error)
-	ReleaseBookingFunc         func(ctx context.Context, bookingID, userID string) (*dto.ReleaseBookingResponse, error)
-	GetBookingFunc             func(ctx context.Context, bookingID, userID string) (*dto.BookingResponse, error)
-	GetUserBookingsFunc        func(ctx context.Context, userID string, page, pageSize int) (*dto.PaginatedResponse, error)
-	GetUserBookingSummaryFunc  func(ctx context.Context, userID, eventID string) (*dto.UserBookingSummaryResponse, error)
-	GetPendingBookingsFunc     func(ctx context.Context, limit int) ([]*dto.BookingResponse, error)
-	ExpireReservationsFunc     func(ctx context.Context, limit int) (int, error)
+	ReserveSeatsFunc          func(ctx context.Context, userID string, req *dto.ReserveSeatsRequest) (*dto.ReserveSeatsResponse, error)
+	ConfirmBookingFunc        func(ctx context.Context, bookingID, userID string, req *dto.ConfirmBookingRequest) (*dto.ConfirmBookingResponse, error)
+	CancelBookingFunc         func(ctx context.Context, bookingID, userID string) (*dto.ReleaseBookingResponse, error)
+	ReleaseBookingFunc        func(ctx context.Context, bookingID, userID string) (*dto.ReleaseBookingResponse, error)
+	GetBookingFunc            func(ctx context.Context, bookingID, userID string) (*dto.BookingResponse, error)
+	GetUserBookingsFunc       func(ctx context.Context, userID string, page pagination.Params) (*dto.PaginatedResponse, error)
+	GetUserBookingSummaryFunc func(ctx context.Context, userID, eventID string) (*dto.UserBookingSummaryResponse, error)
+	GetPendingBookingsFunc    func(ctx context.Context, limit int) ([]*dto.BookingResponse, error)
+	ExpireReservationsFunc    func(ctx context.Context, limit int) (int, error)
+	ExtendHoldFunc            func(ctx context.Context, bookingID, userID string) (*dto.HoldExtensionResponse, error)
+	AwaitPaymentFunc          func(ctx context.Context, bookingID, userID, paymentID string) (*dto.HoldExtensionResponse, error)
 }
 
 func (m *MockBookingService) ReserveSeats(ctx context.Context, userID string, req *dto.ReserveSeatsRequest) (*dto.ReserveSeatsResponse, error) {
@@ -62,9 +66,9 @@ func (m *MockBookingService) GetBooking(ctx context.Context, bookingID, userID s
 	return nil, nil
 }
 
-func (m *MockBookingService) GetUserBookings(ctx context.Context, userID string, page, pageSize int) (*dto.PaginatedResponse, error) {
+func (m *MockBookingService) GetUserBookings(ctx context.Context, userID string, page pagination.Params) (*dto.PaginatedResponse, error) {
 	if m.GetUserBookingsFunc != nil {
-		return m.GetUserBookingsFunc(ctx, userID, page, pageSize)
+		return m.GetUserBookingsFunc(ctx, userID, page)
 	}
 	return nil, nil
 }
@@ -90,6 +94,20 @@ func (m *MockBookingService) ExpireReservations(ctx context.Context, limit int)
 	return 0, nil
 }
 
+func (m *MockBookingService) ExtendHold(ctx context.Context, bookingID, userID string) (*dto.HoldExtensionResponse, error) {
+	if m.ExtendHoldFunc != nil {
+		return m.ExtendHoldFunc(ctx, bookingID, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockBookingService) AwaitPayment(ctx context.Context, bookingID, userID, paymentID string) (*dto.HoldExtensionResponse, error) {
+	if m.AwaitPaymentFunc != nil {
+		return m.AwaitPaymentFunc(ctx, bookingID, userID, paymentID)
+	}
+	return nil, nil
+}
+
 // newTestBookingHandler creates a BookingHandler for testing with mock services
 func newTestBookingHandler(bookingService *MockBookingService) *BookingHandler {
 	return &BookingHandler{
@@ -584,7 +602,7 @@ func TestBookingHandler_GetUserBookings(t *testing.T) {
 		name            string
 		userID          string
 		query           string
-		mockFunc        func(ctx context.Context, userID string, page, pageSize int) (*dto.PaginatedResponse, error)
+		mockFunc        func(ctx context.Context, userID string, page pagination.Params) (*dto.PaginatedResponse, error)
 		expectedStatus  int
 		expectedCode    string
 		checkPagination func(t *testing.T, ctx context.Context, userID string, page, pageSize int)
@@ -593,11 +611,11 @@ func TestBookingHandler_GetUserBookings(t *testing.T) {
 			name:   "successful list with defaults",
 			userID: "user-123",
 			query:  "",
-			mockFunc: func(ctx context.Context, userID string, page, pageSize int) (*dto.PaginatedResponse, error) {
+			mockFunc: func(ctx context.Context, userID string, page pagination.Params) (*dto.PaginatedResponse, error) {
 				return &dto.PaginatedResponse{
 					Data:     []*dto.BookingResponse{},
-					Page:     page,
-					PageSize: pageSize,
+					Page:     1,
+					PageSize: page.Limit,
 				}, nil
 			},
 			expectedStatus: http.StatusOK,
@@ -606,21 +624,47 @@ func TestBookingHandler_GetUserBookings(t *testing.T) {
 			name:   "successful list with pagination",
 			userID: "user-123",
 			query:  "?page=2&page_size=50",
-			mockFunc: func(ctx context.Context, userID string, page, pageSize int) (*dto.PaginatedResponse, error) {
-				if page != 2 {
-					t.Errorf("expected page 2, got %d", page)
+			mockFunc: func(ctx context.Context, userID string, page pagination.Params) (*dto.PaginatedResponse, error) {
+				if page.Offset != 50 {
+					t.Errorf("expected offset 50, got %d", page.Offset)
+				}
+				if page.Limit != 50 {
+					t.Errorf("expected limit 50, got %d", page.Limit)
+				}
+				return &dto.PaginatedResponse{
+					Data:     []*dto.BookingResponse{},
+					Page:     2,
+					PageSize: page.Limit,
+				}, nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "successful list with cursor",
+			userID: "user-123",
+			query:  "?page=3&cursor=" + pagination.Cursor{Time: time.Now(), ID: "booking-1"}.Encode(),
+			mockFunc: func(ctx context.Context, userID string, page pagination.Params) (*dto.PaginatedResponse, error) {
+				if page.After == nil || page.After.ID != "booking-1" {
+					t.Errorf("expected cursor at booking-1, got %+v", page.After)
 				}
-				if pageSize != 50 {
-					t.Errorf("expected pageSize 50, got %d", pageSize)
+				if page.Offset != 0 {
+					t.Errorf("expected cursor to override page, got offset %d", page.Offset)
 				}
 				return &dto.PaginatedResponse{
 					Data:     []*dto.BookingResponse{},
-					Page:     page,
-					PageSize: pageSize,
+					Page:     1,
+					PageSize: page.Limit,
 				}, nil
 			},
 			expectedStatus: http.StatusOK,
 		},
+		{
+			name:           "invalid cursor",
+			userID:         "user-123",
+			query:          "?cursor=not-a-cursor",
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   "INVALID_CURSOR",
+		},
 		{
 			name:           "unauthorized - no user_id",
 			userID:         "",
@@ -834,3 +878,466 @@ func TestBookingHandler_InvalidRequestBody(t *testing.T) {
 		t.Errorf("expected code INVALID_REQUEST, got %s", response.Code)
 	}
 }
+
+// MockAsyncConfirmService is a mock implementation of AsyncConfirmService for testing
+type MockAsyncConfirmService struct {
+	EnqueueConfirmFunc   func(ctx context.Context, bookingID, userID, tenantID string, req *dto.ConfirmBookingRequest) (*dto.ConfirmStatusResponse, error)
+	GetConfirmStatusFunc func(ctx context.Context, bookingID, userID string) (*dto.ConfirmStatusResponse, error)
+}
+
+func (m *MockAsyncConfirmService) EnqueueConfirm(ctx context.Context, bookingID, userID, tenantID string, req *dto.ConfirmBookingRequest) (*dto.ConfirmStatusResponse, error) {
+	if m.EnqueueConfirmFunc != nil {
+		return m.EnqueueConfirmFunc(ctx, bookingID, userID, tenantID, req)
+	}
+	return nil, nil
+}
+
+func (m *MockAsyncConfirmService) GetConfirmStatus(ctx context.Context, bookingID, userID string) (*dto.ConfirmStatusResponse, error) {
+	if m.GetConfirmStatusFunc != nil {
+		return m.GetConfirmStatusFunc(ctx, bookingID, userID)
+	}
+	return nil, domain.ErrConfirmJobNotFound
+}
+
+func (m *MockAsyncConfirmService) ProcessNext(ctx context.Context, wait time.Duration) (bool, error) {
+	return false, nil
+}
+
+func TestBookingHandler_ConfirmBookingAsync(t *testing.T) {
+	asyncService := &MockAsyncConfirmService{
+		EnqueueConfirmFunc: func(ctx context.Context, bookingID, userID, tenantID string, req *dto.ConfirmBookingRequest) (*dto.ConfirmStatusResponse, error) {
+			if bookingID == "expired" {
+				return nil, domain.ErrBookingExpired
+			}
+			return &dto.ConfirmStatusResponse{
+				BookingID:   bookingID,
+				StatusToken: "token-1",
+				Status:      "pending",
+				StatusURL:   "/api/v1/bookings/" + bookingID + "/confirm-status",
+			}, nil
+		},
+	}
+	syncService := &MockBookingService{
+		ConfirmBookingFunc: func(ctx context.Context, bookingID, userID string, req *dto.ConfirmBookingRequest) (*dto.ConfirmBookingResponse, error) {
+			return &dto.ConfirmBookingResponse{BookingID: bookingID, Status: "confirmed"}, nil
+		},
+	}
+
+	tests := []struct {
+		name           string
+		asyncDefault   bool
+		path           string
+		prefer         string
+		expectedStatus int
+	}{
+		{"sync by default", false, "/bookings/booking-123/confirm", "", http.StatusOK},
+		{"async query", false, "/bookings/booking-123/confirm?async=true", "", http.StatusAccepted},
+		{"prefer header", false, "/bookings/booking-123/confirm", "wait=5, respond-async", http.StatusAccepted},
+		{"async default", true, "/bookings/booking-123/confirm", "", http.StatusAccepted},
+		{"async default opted out", true, "/bookings/booking-123/confirm?async=false", "", http.StatusOK},
+		{"enqueue rejected", false, "/bookings/expired/confirm?async=true", "", http.StatusGone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewBookingHandler(syncService, &MockQueueService{}, nil, &BookingHandlerConfig{
+				AsyncConfirmService: asyncService,
+				AsyncConfirmDefault: tt.asyncDefault,
+			})
+			router := setupTestRouterWithAuth(handler, "user-123")
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			if tt.prefer != "" {
+				req.Header.Set("Prefer", tt.prefer)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if w.Code == http.StatusAccepted {
+				if loc := w.Header().Get("Location"); loc != "/api/v1/bookings/booking-123/confirm-status" {
+					t.Errorf("expected Location header, got %q", loc)
+				}
+			}
+		})
+	}
+}
+
+func TestBookingHandler_GetConfirmStatus(t *testing.T) {
+	asyncService := &MockAsyncConfirmService{
+		GetConfirmStatusFunc: func(ctx context.Context, bookingID, userID string) (*dto.ConfirmStatusResponse, error) {
+			if bookingID != "booking-123" {
+				return nil, domain.ErrConfirmJobNotFound
+			}
+			return &dto.ConfirmStatusResponse{BookingID: bookingID, Status: "succeeded", ConfirmationCode: "ABC12345"}, nil
+		},
+	}
+
+	tests := []struct {
+		name           string
+		asyncService   *MockAsyncConfirmService
+		bookingID      string
+		expectedStatus int
+	}{
+		{"succeeded", asyncService, "booking-123", http.StatusOK},
+		{"no job", asyncService, "booking-456", http.StatusNotFound},
+		{"async confirm disabled", nil, "booking-123", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &BookingHandlerConfig{}
+			if tt.asyncService != nil {
+				cfg.AsyncConfirmService = tt.asyncService
+			}
+			handler := NewBookingHandler(&MockBookingService{}, &MockQueueService{}, nil, cfg)
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Set("user_id", "user-123")
+				c.Next()
+			})
+			router.GET("/bookings/:id/confirm-status", handler.GetConfirmStatus)
+
+			req := httptest.NewRequest(http.MethodGet, "/bookings/"+tt.bookingID+"/confirm-status", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if w.Code == http.StatusOK {
+				var response dto.ConfirmStatusResponse
+				if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+					t.Fatalf("failed to unmarshal response: %v", err)
+				}
+				if response.ConfirmationCode != "ABC12345" {
+					t.Errorf("expected confirmation code ABC12345, got %s", response.ConfirmationCode)
+				}
+			}
+		})
+	}
+}
+
+// MockModificationService is a mock implementation of BookingModificationService
+type MockModificationService struct {
+	ModifyBookingFunc func(ctx context.Context, bookingID, userID string, req *dto.ModifyBookingRequest) (*dto.ModifyBookingResponse, error)
+}
+
+func (m *MockModificationService) ModifyBooking(ctx context.Context, bookingID, userID string, req *dto.ModifyBookingRequest) (*dto.ModifyBookingResponse, error) {
+	return m.ModifyBookingFunc(ctx, bookingID, userID, req)
+}
+
+func TestBookingHandler_ModifyBooking(t *testing.T) {
+	modifications := &MockModificationService{
+		ModifyBookingFunc: func(ctx context.Context, bookingID, userID string, req *dto.ModifyBookingRequest) (*dto.ModifyBookingResponse, error) {
+			if req.ZoneID == "sold-out" {
+				return nil, domain.ErrInsufficientSeats
+			}
+			if req.Quantity == 5 {
+				return nil, fmt.Errorf("%w: card declined", domain.ErrPaymentAdjustmentFailed)
+			}
+			return &dto.ModifyBookingResponse{
+				Booking:         &dto.BookingResponse{ID: bookingID, ZoneID: req.ZoneID, Quantity: 2},
+				PreviousZoneID:  "zone-a",
+				PriceDifference: 600,
+				Adjustment:      "charged",
+			}, nil
+		},
+	}
+
+	tests := []struct {
+		name           string
+		service        *MockModificationService
+		body           string
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"zone upgrade", modifications, `{"zone_id":"zone-vip"}`, http.StatusOK, ""},
+		{"quantity out of range", modifications, `{"quantity":11}`, http.StatusBadRequest, "INVALID_REQUEST"},
+		{"new zone sold out", modifications, `{"zone_id":"sold-out"}`, http.StatusConflict, "INSUFFICIENT_SEATS"},
+		{"payment declined", modifications, `{"quantity":5}`, http.StatusPaymentRequired, "PAYMENT_FAILED"},
+		{"modification disabled", nil, `{"zone_id":"zone-vip"}`, http.StatusNotImplemented, "NOT_IMPLEMENTED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &BookingHandlerConfig{}
+			if tt.service != nil {
+				cfg.ModificationService = tt.service
+			}
+			handler := NewBookingHandler(&MockBookingService{}, &MockQueueService{}, nil, cfg)
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Set("user_id", "user-123")
+				c.Next()
+			})
+			router.PATCH("/bookings/:id", handler.ModifyBooking)
+
+			req := httptest.NewRequest(http.MethodPatch, "/bookings/booking-123", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if tt.expectedCode != "" {
+				var response dto.ErrorResponse
+				if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+					t.Fatalf("failed to unmarshal response: %v", err)
+				}
+				if response.Code != tt.expectedCode {
+					t.Errorf("expected code %s, got %s", tt.expectedCode, response.Code)
+				}
+			}
+		})
+	}
+}
+
+type MockCancellationService struct {
+	QuoteToken string // Token the mock hands out and accepts
+}
+
+func (m *MockCancellationService) QuoteCancellation(ctx context.Context, bookingID, userID string) (*dto.CancellationQuoteResponse, error) {
+	if bookingID == "started" {
+		return nil, domain.ErrCancellationClosed
+	}
+	return &dto.CancellationQuoteResponse{BookingID: bookingID, TotalPaid: 1000, RefundPercent: 50, Forfeited: 500, RefundAmount: 500, QuoteToken: m.QuoteToken}, nil
+}
+
+func (m *MockCancellationService) CancelBooking(ctx context.Context, bookingID, userID, quoteToken string) (*dto.CancelBookingResponse, error) {
+	if quoteToken == "stale" {
+		return nil, domain.ErrCancellationQuoteExpired
+	}
+	return &dto.CancelBookingResponse{BookingID: bookingID, Status: "cancelled", RefundAmount: 500, QuoteApplied: quoteToken == m.QuoteToken}, nil
+}
+
+func TestBookingHandler_CancellationQuote(t *testing.T) {
+	tests := []struct {
+		name           string
+		service        *MockCancellationService
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"quote", &MockCancellationService{QuoteToken: "quote-1"}, http.MethodGet, "/bookings/booking-123/cancellation-quote", "", http.StatusOK, ""},
+		{"quote after show start", &MockCancellationService{}, http.MethodGet, "/bookings/started/cancellation-quote", "", http.StatusConflict, "CANCELLATION_CLOSED"},
+		{"quotes disabled", nil, http.MethodGet, "/bookings/booking-123/cancellation-quote", "", http.StatusNotImplemented, "NOT_IMPLEMENTED"},
+		{"cancel with quote", &MockCancellationService{QuoteToken: "quote-1"}, http.MethodPost, "/bookings/booking-123/cancel", `{"quote_token":"quote-1"}`, http.StatusOK, ""},
+		{"cancel without body", &MockCancellationService{}, http.MethodPost, "/bookings/booking-123/cancel", "", http.StatusOK, ""},
+		{"cancel with expired quote", &MockCancellationService{}, http.MethodPost, "/bookings/booking-123/cancel", `{"quote_token":"stale"}`, http.StatusGone, "QUOTE_EXPIRED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &BookingHandlerConfig{}
+			if tt.service != nil {
+				cfg.CancellationService = tt.service
+			}
+			handler := NewBookingHandler(&MockBookingService{}, &MockQueueService{}, nil, cfg)
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Set("user_id", "user-123")
+				c.Next()
+			})
+			router.GET("/bookings/:id/cancellation-quote", handler.GetCancellationQuote)
+			router.POST("/bookings/:id/cancel", handler.CancelBooking)
+
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedCode != "" {
+				var response dto.ErrorResponse
+				if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+					t.Fatalf("failed to unmarshal response: %v", err)
+				}
+				if response.Code != tt.expectedCode {
+					t.Errorf("expected code %s, got %s", tt.expectedCode, response.Code)
+				}
+			}
+		})
+	}
+}
+
+// MockIdleReleaseService releases the booking named by the token
+type MockIdleReleaseService struct {
+	released []string
+}
+
+func (m *MockIdleReleaseService) RemindIdle(ctx context.Context, limit int) (int, error) {
+	return 0, nil
+}
+
+func (m *MockIdleReleaseService) RecordPaymentStarted(ctx context.Context, bookingID string) error {
+	return nil
+}
+
+func (m *MockIdleReleaseService) PreviewReleaseLink(ctx context.Context, token string) (*dto.ReleaseLinkPreview, error) {
+	if err := m.check(token); err != nil {
+		return nil, err
+	}
+	return &dto.ReleaseLinkPreview{BookingID: token}, nil
+}
+
+func (m *MockIdleReleaseService) ReleaseWithLink(ctx context.Context, token string) (*dto.ReleaseBookingResponse, error) {
+	if err := m.check(token); err != nil {
+		return nil, err
+	}
+	m.released = append(m.released, token)
+	return &dto.ReleaseBookingResponse{BookingID: token, Status: "cancelled"}, nil
+}
+
+func (m *MockIdleReleaseService) check(token string) error {
+	switch token {
+	case "expired":
+		return domain.ErrReleaseLinkExpired
+	case "paying":
+		return domain.ErrPaymentInProgress
+	case "forged":
+		return domain.ErrInvalidReleaseLink
+	case "outdated":
+		return domain.ErrReleaseLinkOutdated
+	}
+	return nil
+}
+
+func TestBookingHandler_ReleaseWithLink(t *testing.T) {
+	tests := []struct {
+		name           string
+		service        *MockIdleReleaseService
+		method         string
+		path           string
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"preview", &MockIdleReleaseService{}, http.MethodGet, "/bookings/release-link?token=booking-123", http.StatusOK, ""},
+		{"release", &MockIdleReleaseService{}, http.MethodPost, "/bookings/release-link?token=booking-123", http.StatusOK, ""},
+		{"missing token", &MockIdleReleaseService{}, http.MethodGet, "/bookings/release-link", http.StatusBadRequest, "INVALID_REQUEST"},
+		{"forged link", &MockIdleReleaseService{}, http.MethodGet, "/bookings/release-link?token=forged", http.StatusUnauthorized, "INVALID_RELEASE_LINK"},
+		{"expired link", &MockIdleReleaseService{}, http.MethodGet, "/bookings/release-link?token=expired", http.StatusUnauthorized, "RELEASE_LINK_EXPIRED"},
+		{"booking changed", &MockIdleReleaseService{}, http.MethodGet, "/bookings/release-link?token=outdated", http.StatusConflict, "RELEASE_LINK_OUTDATED"},
+		{"payment started", &MockIdleReleaseService{}, http.MethodGet, "/bookings/release-link?token=paying", http.StatusConflict, "PAYMENT_IN_PROGRESS"},
+		{"release links disabled", nil, http.MethodGet, "/bookings/release-link?token=booking-123", http.StatusNotImplemented, "NOT_IMPLEMENTED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &BookingHandlerConfig{}
+			if tt.service != nil {
+				cfg.IdleReleaseService = tt.service
+			}
+			handler := NewBookingHandler(&MockBookingService{}, &MockQueueService{}, nil, cfg)
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.GET("/bookings/release-link", handler.PreviewReleaseLink)
+			router.POST("/bookings/release-link", handler.ReleaseWithLink)
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedCode != "" {
+				var response dto.ErrorResponse
+				if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+					t.Fatalf("failed to unmarshal response: %v", err)
+				}
+				if response.Code != tt.expectedCode {
+					t.Errorf("expected code %s, got %s", tt.expectedCode, response.Code)
+				}
+			}
+			// Only POST releases; fetching the link must not
+			if tt.service != nil && tt.method == http.MethodGet && len(tt.service.released) != 0 {
+				t.Errorf("GET released %v, want nothing released", tt.service.released)
+			}
+		})
+	}
+}
+
+// MockSeatAlternativesService is a mock implementation of SeatAlternativesService
+type MockSeatAlternativesService struct {
+	FindAlternativesFunc func(ctx context.Context, showID, zoneID string, quantity int) (*dto.SeatAlternatives, error)
+}
+
+func (m *MockSeatAlternativesService) FindAlternatives(ctx context.Context, showID, zoneID string, quantity int) (*dto.SeatAlternatives, error) {
+	return m.FindAlternativesFunc(ctx, showID, zoneID, quantity)
+}
+
+func TestBookingHandler_ReserveSeatsAlternatives(t *testing.T) {
+	bookings := &MockBookingService{
+		ReserveSeatsFunc: func(ctx context.Context, userID string, req *dto.ReserveSeatsRequest) (*dto.ReserveSeatsResponse, error) {
+			return nil, domain.ErrInsufficientSeats
+		},
+	}
+	alternatives := &MockSeatAlternativesService{
+		FindAlternativesFunc: func(ctx context.Context, showID, zoneID string, quantity int) (*dto.SeatAlternatives, error) {
+			if zoneID == "zone-unknown" {
+				return nil, domain.ErrZoneNotFound
+			}
+			return &dto.SeatAlternatives{
+				ZoneID:            zoneID,
+				RequestedQuantity: quantity,
+				AvailableInZone:   2,
+				MaxQuantity:       2,
+				Zones:             []dto.ZoneAlternative{{ZoneID: "zone-b", AvailableSeats: 40, FitsQuantity: true}},
+			}, nil
+		},
+	}
+
+	tests := []struct {
+		name             string
+		zoneID           string
+		wantAlternatives bool
+	}{
+		{"alternatives found", "zone-a", true},
+		{"lookup failed", "zone-unknown", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewBookingHandler(bookings, &MockQueueService{}, nil, &BookingHandlerConfig{AlternativesService: alternatives})
+			router := setupTestRouterWithAuth(handler, "user-123")
+
+			body, _ := json.Marshal(&dto.ReserveSeatsRequest{EventID: "event-123", ZoneID: tt.zoneID, Quantity: 5})
+			req := httptest.NewRequest(http.MethodPost, "/bookings/reserve", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusConflict {
+				t.Fatalf("expected status %d, got %d", http.StatusConflict, w.Code)
+			}
+			var response dto.InsufficientSeatsResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to unmarshal response: %v", err)
+			}
+			if response.Code != "INSUFFICIENT_SEATS" {
+				t.Errorf("expected code INSUFFICIENT_SEATS, got %s", response.Code)
+			}
+			if got := response.Alternatives != nil; got != tt.wantAlternatives {
+				t.Fatalf("alternatives present = %v, want %v", got, tt.wantAlternatives)
+			}
+			if tt.wantAlternatives && (response.Alternatives.MaxQuantity != 2 || len(response.Alternatives.Zones) != 1) {
+				t.Errorf("unexpected alternatives: %+v", response.Alternatives)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// BookingSearchRoles are the roles allowed to search bookings
+var BookingSearchRoles = []string{"admin", "super_admin", "support"}
+
+// BookingSearchHandler handles booking search requests from support agents
+type BookingSearchHandler struct {
+	searchService service.BookingSearchService
+}
+
+// NewBookingSearchHandler creates a new booking search handler
+func NewBookingSearchHandler(searchService service.BookingSearchService) *BookingSearchHandler {
+	return &BookingSearchHandler{
+		searchService: searchService,
+	}
+}
+
+// SearchBookings handles GET /admin/bookings/search
+// Supports email, confirmation_code, payment_id, last4 and limit query parameters.
+// super_admin searches all tenants (or tenant_id if given); other roles only their own tenant.
+func (h *BookingSearchHandler) SearchBookings(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.admin.search_bookings")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	req := &dto.BookingSearchRequest{
+		Email:            c.Query("email"),
+		ConfirmationCode: c.Query("confirmation_code"),
+		PaymentID:        c.Query("payment_id"),
+		LastFour:         c.Query("last4"),
+	}
+	if l := c.Query("limit"); l != "" {
+		if n, err := strconv.Atoi(l); err == nil && n > 0 {
+			req.Limit = n
+		}
+	}
+
+	role := c.GetString("role")
+	if role == "super_admin" {
+		req.TenantID = c.Query("tenant_id")
+	} else {
+		req.TenantID = c.GetString("tenant_id")
+		if req.TenantID == "" {
+			span.SetStatus(codes.Error, "missing tenant")
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{
+				Error: "booking search requires a tenant",
+				Code:  "FORBIDDEN",
+			})
+			return
+		}
+	}
+
+	span.SetAttributes(
+		attribute.String("role", role),
+		attribute.String("tenant_id", req.TenantID),
+	)
+
+	result, err := h.searchService.SearchBookings(ctx, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("count", result.Count))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Data:    result,
+	})
+}
+
+// handleError maps booking search errors to HTTP responses
+func (h *BookingSearchHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case domain.IsValidationError(err):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_REQUEST",
+		})
+	case errors.Is(err, domain.ErrSearchLookupUnavailable):
+		c.JSON(http.StatusServiceUnavailable, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "SEARCH_LOOKUP_UNAVAILABLE",
+		})
+	default:
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "internal server error",
+			Code:  "INTERNAL_ERROR",
+		})
+	}
+}
@@ -0,0 +1,350 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/middleware"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// CartHandler handles cart and multi-show checkout HTTP requests.
+// A checkout reserves every cart item; the client then pays once for the checkout total
+// (using the checkout ID as the payment's booking reference) and confirms the checkout.
+type CartHandler struct {
+	cartService service.CartService
+}
+
+// NewCartHandler creates a new cart handler
+func NewCartHandler(cartService service.CartService) *CartHandler {
+	return &CartHandler{
+		cartService: cartService,
+	}
+}
+
+// GetCart handles GET /cart
+func (h *CartHandler) GetCart(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.cart.get")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	userID, ok := h.requireUser(c)
+	if !ok {
+		span.SetStatus(codes.Error, "unauthorized")
+		return
+	}
+	span.SetAttributes(attribute.String("user_id", userID))
+
+	result, err := h.cartService.GetCart(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, result)
+}
+
+// AddItem handles POST /cart/items
+func (h *CartHandler) AddItem(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.cart.add_item")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	userID, ok := h.requireUser(c)
+	if !ok {
+		span.SetStatus(codes.Error, "unauthorized")
+		return
+	}
+
+	var req dto.AddCartItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid request",
+			Code:    "INVALID_REQUEST",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("user_id", userID),
+		attribute.String("event_id", req.EventID),
+		attribute.String("zone_id", req.ZoneID),
+		attribute.Int("quantity", req.Quantity),
+	)
+
+	result, err := h.cartService.AddItem(ctx, userID, c.GetString("tenant_id"), &req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, result)
+}
+
+// RemoveItem handles DELETE /cart/items/:item_id
+func (h *CartHandler) RemoveItem(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.cart.remove_item")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	userID, ok := h.requireUser(c)
+	if !ok {
+		span.SetStatus(codes.Error, "unauthorized")
+		return
+	}
+
+	itemID := c.Param("item_id")
+	span.SetAttributes(
+		attribute.String("user_id", userID),
+		attribute.String("item_id", itemID),
+	)
+
+	result, err := h.cartService.RemoveItem(ctx, userID, itemID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, result)
+}
+
+// ClearCart handles DELETE /cart
+func (h *CartHandler) ClearCart(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.cart.clear")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	userID, ok := h.requireUser(c)
+	if !ok {
+		span.SetStatus(codes.Error, "unauthorized")
+		return
+	}
+	span.SetAttributes(attribute.String("user_id", userID))
+
+	if err := h.cartService.ClearCart(ctx, userID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Cart cleared",
+	})
+}
+
+// Checkout handles POST /cart/checkout
+// Returns 201 when items were reserved and 409 with per-item errors when none were
+func (h *CartHandler) Checkout(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.cart.checkout")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	userID, ok := h.requireUser(c)
+	if !ok {
+		span.SetStatus(codes.Error, "unauthorized")
+		return
+	}
+
+	var req dto.CheckoutRequest
+	// All fields are optional, so we don't fail if body is empty
+	_ = c.ShouldBindJSON(&req)
+	if req.IdempotencyKey == "" {
+		req.IdempotencyKey = c.GetString(middleware.ContextKeyIdempotencyKey)
+	}
+	req.Verification = callerVerification(c)
+
+	span.SetAttributes(
+		attribute.String("user_id", userID),
+		attribute.Bool("allow_partial", req.AllowPartial),
+	)
+
+	result, err := h.cartService.Checkout(ctx, userID, &req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("checkout_id", result.CheckoutID),
+		attribute.String("status", result.Status),
+	)
+	if result.Status == domain.CheckoutStatusFailed.String() {
+		span.SetStatus(codes.Error, "checkout failed")
+		c.JSON(http.StatusConflict, result)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusCreated, result)
+}
+
+// GetCheckout handles GET /cart/checkouts/:id
+func (h *CartHandler) GetCheckout(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.cart.get_checkout")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	userID, ok := h.requireUser(c)
+	if !ok {
+		span.SetStatus(codes.Error, "unauthorized")
+		return
+	}
+
+	checkoutID := c.Param("id")
+	span.SetAttributes(
+		attribute.String("user_id", userID),
+		attribute.String("checkout_id", checkoutID),
+	)
+
+	result, err := h.cartService.GetCheckout(ctx, checkoutID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, result)
+}
+
+// ConfirmCheckout handles POST /cart/checkouts/:id/confirm
+func (h *CartHandler) ConfirmCheckout(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.cart.confirm_checkout")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	userID, ok := h.requireUser(c)
+	if !ok {
+		span.SetStatus(codes.Error, "unauthorized")
+		return
+	}
+
+	checkoutID := c.Param("id")
+	span.SetAttributes(
+		attribute.String("user_id", userID),
+		attribute.String("checkout_id", checkoutID),
+	)
+
+	var req dto.ConfirmCheckoutRequest
+	// PaymentID is optional, so we don't fail if body is empty
+	_ = c.ShouldBindJSON(&req)
+	req.Verification = callerVerification(c)
+
+	result, err := h.cartService.ConfirmCheckout(ctx, checkoutID, userID, &req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetAttributes(attribute.String("status", result.Status))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, result)
+}
+
+// CancelCheckout handles POST /cart/checkouts/:id/cancel
+func (h *CartHandler) CancelCheckout(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.cart.cancel_checkout")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	userID, ok := h.requireUser(c)
+	if !ok {
+		span.SetStatus(codes.Error, "unauthorized")
+		return
+	}
+
+	checkoutID := c.Param("id")
+	span.SetAttributes(
+		attribute.String("user_id", userID),
+		attribute.String("checkout_id", checkoutID),
+	)
+
+	result, err := h.cartService.CancelCheckout(ctx, checkoutID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, result)
+}
+
+// requireUser returns the user ID, responding 401 if there is none
+func (h *CartHandler) requireUser(c *gin.Context) (string, bool) {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error: "unauthorized",
+			Code:  "UNAUTHORIZED",
+		})
+		return "", false
+	}
+	return userID, true
+}
+
+// handleError converts domain errors to HTTP responses
+func (h *CartHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrCartItemNotFound),
+		errors.Is(err, domain.ErrCheckoutNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "NOT_FOUND",
+		})
+	case errors.Is(err, domain.ErrCartEmpty):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   err.Error(),
+			Code:    "CART_EMPTY",
+			Message: "Add items to the cart before checking out",
+		})
+	case errors.Is(err, domain.ErrCartFull):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "CART_FULL",
+		})
+	case errors.Is(err, domain.ErrCheckoutNotPending):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "CHECKOUT_NOT_PENDING",
+		})
+	case domain.IsValidationError(err):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_REQUEST",
+		})
+	default:
+		_ = c.Error(err) // Log the error with gin
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "internal server error",
+			Code:  "INTERNAL_ERROR",
+		})
+	}
+}
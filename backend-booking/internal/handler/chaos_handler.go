@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/chaos"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// ChaosHandler reports injected faults so load tests can correlate failures with chaos mode
+type ChaosHandler struct {
+	injector *chaos.Injector
+}
+
+// NewChaosHandler creates a new chaos handler
+func NewChaosHandler(injector *chaos.Injector) *ChaosHandler {
+	return &ChaosHandler{
+		injector: injector,
+	}
+}
+
+// GetStats handles GET /admin/chaos
+func (h *ChaosHandler) GetStats(c *gin.Context) {
+	_, span := telemetry.StartSpan(c.Request.Context(), "handler.admin.chaos_stats")
+	defer span.End()
+
+	stats := h.injector.Stats()
+	span.SetAttributes(attribute.Int64("total_injections", stats.TotalInjections))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Data:    stats,
+	})
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// CompensationRoles are the roles allowed to list and trigger compensations
+var CompensationRoles = []string{"admin", "super_admin"}
+
+// CompensationHandler handles admin HTTP requests for saga compensations
+type CompensationHandler struct {
+	compensationService service.CompensationService
+}
+
+// NewCompensationHandler creates a new compensation handler
+func NewCompensationHandler(compensationService service.CompensationService) *CompensationHandler {
+	return &CompensationHandler{
+		compensationService: compensationService,
+	}
+}
+
+// ListCompensations handles GET /admin/compensations
+// Supports optional saga_id, booking_id, limit and offset query parameters
+func (h *CompensationHandler) ListCompensations(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.admin.list_compensations")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	filter := &repository.CompensationFilter{
+		SagaID:    c.Query("saga_id"),
+		BookingID: c.Query("booking_id"),
+		Limit:     50,
+	}
+	if l := c.Query("limit"); l != "" {
+		if n, err := strconv.Atoi(l); err == nil && n > 0 && n <= 500 {
+			filter.Limit = n
+		}
+	}
+	if o := c.Query("offset"); o != "" {
+		if n, err := strconv.Atoi(o); err == nil && n >= 0 {
+			filter.Offset = n
+		}
+	}
+
+	span.SetAttributes(
+		attribute.String("saga_id", filter.SagaID),
+		attribute.String("booking_id", filter.BookingID),
+		attribute.Int("limit", filter.Limit),
+	)
+
+	compensations, err := h.compensationService.ListCompensations(ctx, filter)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	if compensations == nil {
+		compensations = []*domain.Compensation{}
+	}
+
+	span.SetAttributes(attribute.Int("count", len(compensations)))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    compensations,
+		"count":   len(compensations),
+	})
+}
+
+// TriggerCompensation handles POST /admin/compensations
+// Manually compensates a booking: refunds the payment (if any) and releases the seats
+func (h *CompensationHandler) TriggerCompensation(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.admin.trigger_compensation")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	var req dto.TriggerCompensationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid request",
+			Code:    "INVALID_REQUEST",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	requestedBy := c.GetString("user_id")
+	span.SetAttributes(
+		attribute.String("booking_id", req.BookingID),
+		attribute.String("requested_by", requestedBy),
+	)
+
+	compensation, err := h.compensationService.TriggerCompensation(ctx, &req, requestedBy)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetAttributes(attribute.String("status", compensation.Status.String()))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusAccepted, dto.SuccessResponse{
+		Success: true,
+		Data:    compensation,
+		Message: "Compensation commands dispatched",
+	})
+}
+
+// handleError converts domain errors to HTTP responses
+func (h *CompensationHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrBookingNotFound),
+		errors.Is(err, domain.ErrCompensationNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "NOT_FOUND",
+		})
+	case errors.Is(err, domain.ErrCompensationNotAllowed):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "COMPENSATION_NOT_ALLOWED",
+		})
+	default:
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "internal server error",
+			Code:  "INTERNAL_ERROR",
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubEventAccess answers every access check with err and records the callers checked
+type stubEventAccess struct {
+	err     error
+	callers []*middleware.InternalClaims
+}
+
+func (s *stubEventAccess) AuthorizeEvent(ctx context.Context, caller *middleware.InternalClaims, eventID string) error {
+	s.callers = append(s.callers, caller)
+	return s.err
+}
+
+func (s *stubEventAccess) AuthorizeZone(ctx context.Context, caller *middleware.InternalClaims, zoneID string) error {
+	s.callers = append(s.callers, caller)
+	return s.err
+}
+
+func TestAuthorizeZone(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		role       string
+		access     *stubEventAccess
+		wantStatus int
+		wantCheck  bool
+	}{
+		{"admin", "admin", &stubEventAccess{err: domain.ErrEventAccessDenied}, http.StatusOK, false},
+		{"super admin", "super_admin", &stubEventAccess{err: domain.ErrEventAccessDenied}, http.StatusOK, false},
+		{"organizer of the event", "organizer", &stubEventAccess{}, http.StatusOK, true},
+		{"organizer of another event", "organizer", &stubEventAccess{err: domain.ErrEventAccessDenied}, http.StatusForbidden, true},
+		{"zone of another tenant", "organizer", &stubEventAccess{err: domain.ErrZoneNotFound}, http.StatusNotFound, true},
+		{"ticket service down", "organizer", &stubEventAccess{err: errors.New("connection refused")}, http.StatusBadGateway, true},
+		{"no access checker", "organizer", nil, http.StatusForbidden, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var access service.EventAccessChecker
+			if tt.access != nil {
+				access = tt.access
+			}
+
+			router := gin.New()
+			router.GET("/zones/:id", func(c *gin.Context) {
+				c.Set("user_id", "user-1")
+				c.Set("tenant_id", "tenant-1")
+				c.Set("role", tt.role)
+				if authorizeZone(c, access, c.Param("id")) {
+					c.Status(http.StatusOK)
+				}
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/zones/zone-1", nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantCheck {
+				assert.Len(t, tt.access.callers, 1)
+				assert.Equal(t, &middleware.InternalClaims{UserID: "user-1", TenantID: "tenant-1", Roles: []string{"organizer"}}, tt.access.callers[0])
+			} else if tt.access != nil {
+				assert.Empty(t, tt.access.callers)
+			}
+		})
+	}
+}
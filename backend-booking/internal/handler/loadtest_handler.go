@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// LoadTestHandler handles load-test orchestration requests (load-test mode only)
+type LoadTestHandler struct {
+	loadTestService service.LoadTestService
+}
+
+// NewLoadTestHandler creates a new load test handler
+func NewLoadTestHandler(loadTestService service.LoadTestService) *LoadTestHandler {
+	return &LoadTestHandler{
+		loadTestService: loadTestService,
+	}
+}
+
+// IssueQueuePasses handles POST /internal/loadtest/events/:id/queue-passes
+func (h *LoadTestHandler) IssueQueuePasses(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.loadtest.issue_queue_passes")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	eventID := c.Param("id")
+	var req dto.IssueQueuePassesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid request",
+			Code:    "INVALID_REQUEST",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("event_id", eventID),
+		attribute.Int("count", len(req.UserIDs)),
+	)
+
+	result, err := h.loadTestService.IssueQueuePasses(ctx, eventID, &req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Data:    result,
+	})
+}
+
+// ResetEvent handles POST /internal/loadtest/events/:id/reset
+func (h *LoadTestHandler) ResetEvent(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.loadtest.reset_event")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	eventID := c.Param("id")
+	var req dto.ResetLoadTestEventRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "invalid request")
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "invalid request",
+				Code:    "INVALID_REQUEST",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
+	span.SetAttributes(attribute.String("event_id", eventID))
+
+	result, err := h.loadTestService.ResetEvent(ctx, eventID, &req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Data:    result,
+	})
+}
+
+func (h *LoadTestHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrLoadTestBatchTooLarge),
+		errors.Is(err, domain.ErrInvalidEventID):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_REQUEST",
+		})
+	default:
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "internal server error",
+			Code:  "INTERNAL_ERROR",
+		})
+	}
+}
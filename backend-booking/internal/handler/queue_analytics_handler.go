@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// QueueAnalyticsHandler handles admin HTTP requests for queue funnel dashboards.
+// Organizers see the analytics of their own events only.
+type QueueAnalyticsHandler struct {
+	analyticsService service.QueueAnalyticsService
+	access           service.EventAccessChecker
+}
+
+// NewQueueAnalyticsHandler creates a new queue analytics handler; without an access checker
+// only admins see queue analytics
+func NewQueueAnalyticsHandler(analyticsService service.QueueAnalyticsService, access service.EventAccessChecker) *QueueAnalyticsHandler {
+	return &QueueAnalyticsHandler{
+		analyticsService: analyticsService,
+		access:           access,
+	}
+}
+
+// GetQueueAnalytics handles GET /admin/queue/analytics/:event_id?window=1h&interval=1m
+// Returns join rate, current depth, abandonment and conversion rates over the window,
+// with a series of interval-long points
+func (h *QueueAnalyticsHandler) GetQueueAnalytics(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.admin.queue_analytics")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	window, err := parseOptionalDuration(c.Query("window"))
+	if err != nil {
+		span.SetStatus(codes.Error, "invalid window")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "window must be a duration such as 30m or 6h",
+			Code:  "INVALID_REQUEST",
+		})
+		return
+	}
+	interval, err := parseOptionalDuration(c.Query("interval"))
+	if err != nil {
+		span.SetStatus(codes.Error, "invalid interval")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "interval must be a duration such as 1m or 15m",
+			Code:  "INVALID_REQUEST",
+		})
+		return
+	}
+
+	if !authorizeEvent(c, h.access, c.Param("event_id")) {
+		span.SetStatus(codes.Error, "event access denied")
+		return
+	}
+
+	analytics, err := h.analyticsService.GetAnalytics(ctx, c.Param("event_id"), window, interval)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		switch {
+		case errors.Is(err, domain.ErrInvalidEventID):
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: err.Error(),
+				Code:  "INVALID_EVENT_ID",
+			})
+		case errors.Is(err, domain.ErrInvalidQueueAnalyticsRange):
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: err.Error(),
+				Code:  "INVALID_REQUEST",
+			})
+		default:
+			_ = c.Error(err)
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Error: "internal server error",
+				Code:  "INTERNAL_ERROR",
+			})
+		}
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Data:    analytics,
+	})
+}
+
+// parseOptionalDuration parses a duration query parameter, 0 when it is empty
+func parseOptionalDuration(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(value)
+}
@@ -13,7 +13,7 @@ import (
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
-	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/worker"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/events"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
 	"go.opentelemetry.io/otel/attribute"
@@ -23,14 +23,17 @@ import (
 // QueueHandler handles queue HTTP requests
 type QueueHandler struct {
 	queueService service.QueueService
-	redisClient  *redis.Client // For Pub/Sub subscription in SSE
+	redisClient  *redis.Client              // For Pub/Sub subscription in SSE
+	access       service.EventAccessChecker // Organizers revoke passes of their own events only
 }
 
-// NewQueueHandler creates a new queue handler
-func NewQueueHandler(queueService service.QueueService, redisClient *redis.Client) *QueueHandler {
+// NewQueueHandler creates a new queue handler; without an access checker only admins
+// revoke queue passes
+func NewQueueHandler(queueService service.QueueService, redisClient *redis.Client, access service.EventAccessChecker) *QueueHandler {
 	return &QueueHandler{
 		queueService: queueService,
 		redisClient:  redisClient,
+		access:       access,
 	}
 }
 
@@ -167,6 +170,48 @@ func (h *QueueHandler) LeaveQueue(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// RevokeQueuePasses handles POST /admin/queue/revoke-passes
+func (h *QueueHandler) RevokeQueuePasses(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.queue.revoke_passes")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	var req dto.RevokeQueuePassesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid request",
+			Code:    "INVALID_REQUEST",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("event_id", req.EventID),
+		attribute.String("user_id", req.UserID),
+	)
+
+	if !authorizeEvent(c, h.access, req.EventID) {
+		span.SetStatus(codes.Error, "event access denied")
+		return
+	}
+
+	if err := h.queueService.RevokeQueuePasses(ctx, req.EventID, req.UserID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.RevokeQueuePassesResponse{
+		Success: true,
+		Message: "Queue passes revoked",
+	})
+}
+
 // GetQueueStatus handles GET /queue/status/:event_id
 func (h *QueueHandler) GetQueueStatus(c *gin.Context) {
 	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.queue.status")
@@ -289,7 +334,7 @@ func (h *QueueHandler) StreamPosition(c *gin.Context) {
 func (h *QueueHandler) streamWithPubSub(c *gin.Context, ctx context.Context, userID, eventID string) {
 	// Subscribe to queue pass channel for this USER (targeted delivery)
 	// Trade-off: More Redis connections but no broadcast storm
-	channel := worker.QueuePassChannelKey(eventID, userID)
+	channel := events.QueuePassChannel(eventID, userID)
 	pubsub := h.redisClient.Subscribe(ctx, channel)
 	defer pubsub.Close()
 
@@ -312,7 +357,7 @@ func (h *QueueHandler) streamWithPubSub(c *gin.Context, ctx context.Context, use
 
 		case msg := <-msgChan:
 			// Received queue pass notification - this is already for this user (per-user channel)
-			var queuePassMsg worker.QueuePassReadyMessage
+			var queuePassMsg events.QueuePassReadyEvent
 			if err := json.Unmarshal([]byte(msg.Payload), &queuePassMsg); err != nil {
 				// Invalid message, continue waiting
 				continue
@@ -326,7 +371,7 @@ func (h *QueueHandler) streamWithPubSub(c *gin.Context, ctx context.Context, use
 				TotalInQueue:       0,
 				IsReady:            true,
 				QueuePass:          queuePassMsg.QueuePass,
-				QueuePassExpiresAt: time.Unix(queuePassMsg.ExpiresAt, 0),
+				QueuePassExpiresAt: queuePassMsg.ExpiresAtTime(),
 			}
 			data, _ := json.Marshal(result)
 			c.Writer.WriteString(fmt.Sprintf("event: position\ndata: %s\n\n", data))
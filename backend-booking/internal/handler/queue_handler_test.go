@@ -53,8 +53,8 @@ func (m *MockQueueService) GetQueueStatus(ctx context.Context, eventID string) (
 	return args.Get(0).(*dto.QueueStatusResponse), args.Error(1)
 }
 
-func (m *MockQueueService) ValidateQueuePass(ctx context.Context, userID, eventID, queuePass string) error {
-	args := m.Called(ctx, userID, eventID, queuePass)
+func (m *MockQueueService) ValidateQueuePass(ctx context.Context, userID, eventID, zoneID, queuePass string) error {
+	args := m.Called(ctx, userID, eventID, zoneID, queuePass)
 	return args.Error(0)
 }
 
@@ -63,6 +63,11 @@ func (m *MockQueueService) DeleteQueuePass(ctx context.Context, userID, eventID
 	return args.Error(0)
 }
 
+func (m *MockQueueService) RevokeQueuePasses(ctx context.Context, eventID, userID string) error {
+	args := m.Called(ctx, eventID, userID)
+	return args.Error(0)
+}
+
 // newTestQueueHandler creates a QueueHandler for testing
 func newTestQueueHandler(queueService *MockQueueService) *QueueHandler {
 	return &QueueHandler{
@@ -81,6 +86,9 @@ func setupQueueTestRouter(handler *QueueHandler) *gin.Engine {
 		if userID != "" {
 			c.Set("user_id", userID)
 		}
+		if role := c.GetHeader("X-User-Role"); role != "" {
+			c.Set("role", role)
+		}
 		c.Next()
 	})
 
@@ -91,6 +99,7 @@ func setupQueueTestRouter(handler *QueueHandler) *gin.Engine {
 		queue.DELETE("/leave", handler.LeaveQueue)
 		queue.GET("/status/:event_id", handler.GetQueueStatus)
 	}
+	router.POST("/api/v1/admin/queue/revoke-passes", handler.RevokeQueuePasses)
 
 	return router
 }
@@ -450,3 +459,51 @@ func TestQueueHandler_GetPosition_NoQueuePassWhenNotReady(t *testing.T) {
 
 	mockService.AssertExpectations(t)
 }
+
+func TestQueueHandler_RevokeQueuePasses(t *testing.T) {
+	mockService := new(MockQueueService)
+	handler := newTestQueueHandler(mockService)
+	router := setupQueueTestRouter(handler)
+
+	mockService.On("RevokeQueuePasses", mock.Anything, "event-123", "user-123").Return(nil)
+
+	body, _ := json.Marshal(dto.RevokeQueuePassesRequest{EventID: "event-123", UserID: "user-123"})
+	req, _ := http.NewRequest("POST", "/api/v1/admin/queue/revoke-passes", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-Role", "admin")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+
+	// event_id is required
+	req, _ = http.NewRequest("POST", "/api/v1/admin/queue/revoke-passes", bytes.NewBufferString(`{"user_id":"user-123"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-Role", "admin")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestQueueHandler_RevokeQueuePasses_OtherOrganizer(t *testing.T) {
+	mockService := new(MockQueueService)
+	handler := newTestQueueHandler(mockService)
+	handler.access = &stubEventAccess{err: domain.ErrEventAccessDenied}
+	router := setupQueueTestRouter(handler)
+
+	body, _ := json.Marshal(dto.RevokeQueuePassesRequest{EventID: "event-123"})
+	req, _ := http.NewRequest("POST", "/api/v1/admin/queue/revoke-passes", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-ID", "organizer-2")
+	req.Header.Set("X-User-Role", "organizer")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockService.AssertNotCalled(t, "RevokeQueuePasses", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// RedisMemoryHandler reports Redis memory use per key namespace against its budget
+type RedisMemoryHandler struct {
+	governor *pkgredis.MemoryGovernor
+}
+
+// NewRedisMemoryHandler creates a new Redis memory handler
+func NewRedisMemoryHandler(governor *pkgredis.MemoryGovernor) *RedisMemoryHandler {
+	return &RedisMemoryHandler{
+		governor: governor,
+	}
+}
+
+// GetMemoryReport handles GET /admin/redis/memory
+// Returns the latest periodic audit; ?refresh=true audits now, which scans the keyspace
+func (h *RedisMemoryHandler) GetMemoryReport(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.admin.redis_memory")
+	defer span.End()
+
+	report := h.governor.LastReport()
+	if report == nil || c.Query("refresh") == "true" {
+		var err error
+		report, err = h.governor.Audit(ctx)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Error:   "memory audit failed",
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			})
+			return
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("namespaces", len(report.Namespaces)),
+		attribute.Int64("used_memory", report.UsedMemory),
+	)
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Data:    report,
+	})
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// RefundBatchRoles are the roles allowed to cancel shows and view their refunds
+var RefundBatchRoles = []string{"admin", "super_admin"}
+
+// RefundBatchHandler handles admin HTTP requests for cancelling shows and their bulk refunds
+type RefundBatchHandler struct {
+	refundService service.RefundBatchService
+}
+
+// NewRefundBatchHandler creates a new refund batch handler
+func NewRefundBatchHandler(refundService service.RefundBatchService) *RefundBatchHandler {
+	return &RefundBatchHandler{
+		refundService: refundService,
+	}
+}
+
+// CancelShow handles POST /admin/shows/:id/cancel
+// Marks the show cancelled and queues a refund for every confirmed booking
+func (h *RefundBatchHandler) CancelShow(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.admin.cancel_show")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	// The body is optional; an empty one cancels with the default reason
+	var req dto.CancelShowRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid request",
+			Code:    "INVALID_REQUEST",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	showID := c.Param("id")
+	requestedBy := c.GetHeader("X-User-ID")
+	span.SetAttributes(
+		attribute.String("show_id", showID),
+		attribute.String("requested_by", requestedBy),
+	)
+
+	batch, err := h.refundService.CancelShow(ctx, showID, &req, requestedBy)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	// Per-booking results are served by GET /admin/refund-batches/:id
+	batch.Items = nil
+
+	span.SetAttributes(attribute.String("batch_id", batch.ID))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusAccepted, dto.SuccessResponse{
+		Success: true,
+		Data:    batch,
+		Message: "Show cancelled, refunds queued",
+	})
+}
+
+// GetRefundBatch handles GET /admin/refund-batches/:id
+// Supports an optional status query parameter to list only items in that status
+func (h *RefundBatchHandler) GetRefundBatch(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.admin.get_refund_batch")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	id := c.Param("id")
+	status := domain.RefundItemStatus(c.Query("status"))
+	span.SetAttributes(
+		attribute.String("batch_id", id),
+		attribute.String("status_filter", status.String()),
+	)
+
+	batch, err := h.refundService.GetBatch(ctx, id, status)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	if batch.Items == nil {
+		batch.Items = []*domain.RefundBatchItem{}
+	}
+
+	span.SetAttributes(attribute.String("status", batch.Status.String()))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Data:    batch,
+	})
+}
+
+// handleError converts domain errors to HTTP responses
+func (h *RefundBatchHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case domain.IsNotFoundError(err):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "NOT_FOUND",
+		})
+	case domain.IsValidationError(err):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_REQUEST",
+		})
+	case errors.Is(err, domain.ErrShowCancelFailed):
+		c.JSON(http.StatusBadGateway, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "SHOW_CANCEL_FAILED",
+		})
+	default:
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "internal server error",
+			Code:  "INTERNAL_ERROR",
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// ReportHandler handles organizer sales report schedule HTTP requests.
+// Schedule calls are scoped to the tenant_id set from the X-Tenant-ID gateway header;
+// unsubscribe is authorized by the token in the report email.
+type ReportHandler struct {
+	reportService service.ReportService
+}
+
+// NewReportHandler creates a new report handler
+func NewReportHandler(reportService service.ReportService) *ReportHandler {
+	return &ReportHandler{
+		reportService: reportService,
+	}
+}
+
+// SetSchedule handles PUT /report-schedule
+func (h *ReportHandler) SetSchedule(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.report.set_schedule")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	tenantID, ok := h.requireTenant(c)
+	if !ok {
+		span.SetStatus(codes.Error, "missing tenant")
+		return
+	}
+	span.SetAttributes(attribute.String("tenant_id", tenantID))
+
+	var req dto.ReportScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid request",
+			Code:    "INVALID_REQUEST",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	result, err := h.reportService.SetSchedule(ctx, tenantID, &req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Data:    result,
+	})
+}
+
+// GetSchedule handles GET /report-schedule
+func (h *ReportHandler) GetSchedule(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.report.get_schedule")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	tenantID, ok := h.requireTenant(c)
+	if !ok {
+		span.SetStatus(codes.Error, "missing tenant")
+		return
+	}
+	span.SetAttributes(attribute.String("tenant_id", tenantID))
+
+	result, err := h.reportService.GetSchedule(ctx, tenantID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Data:    result,
+	})
+}
+
+// DeleteSchedule handles DELETE /report-schedule
+func (h *ReportHandler) DeleteSchedule(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.report.delete_schedule")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	tenantID, ok := h.requireTenant(c)
+	if !ok {
+		span.SetStatus(codes.Error, "missing tenant")
+		return
+	}
+	span.SetAttributes(attribute.String("tenant_id", tenantID))
+
+	if err := h.reportService.DeleteSchedule(ctx, tenantID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Report schedule deleted",
+	})
+}
+
+// PreviewReport handles GET /report-schedule/preview
+// Returns the report the schedule would send now
+func (h *ReportHandler) PreviewReport(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.report.preview")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	tenantID, ok := h.requireTenant(c)
+	if !ok {
+		span.SetStatus(codes.Error, "missing tenant")
+		return
+	}
+	span.SetAttributes(attribute.String("tenant_id", tenantID))
+
+	report, err := h.reportService.PreviewReport(ctx, tenantID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Data:    report,
+	})
+}
+
+// Unsubscribe handles GET and POST /reports/unsubscribe?token=...
+// POST supports one-click unsubscribe from mail clients
+func (h *ReportHandler) Unsubscribe(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.report.unsubscribe")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	if err := h.reportService.Unsubscribe(ctx, c.Query("token")); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "You will no longer receive sales reports",
+	})
+}
+
+// requireTenant returns the caller's tenant, writing a 403 response if there is none
+func (h *ReportHandler) requireTenant(c *gin.Context) (string, bool) {
+	tenantID := c.GetString("tenant_id")
+	if tenantID == "" {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error:   "tenant required",
+			Code:    "TENANT_REQUIRED",
+			Message: "sales reports are only available to tenant accounts",
+		})
+		return "", false
+	}
+	return tenantID, true
+}
+
+// handleError converts domain errors to HTTP responses
+func (h *ReportHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrReportScheduleNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "REPORT_SCHEDULE_NOT_FOUND",
+		})
+	case domain.IsValidationError(err):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_REQUEST",
+		})
+	default:
+		_ = c.Error(err) // Log the error with gin
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "internal server error",
+			Code:  "INTERNAL_ERROR",
+		})
+	}
+}
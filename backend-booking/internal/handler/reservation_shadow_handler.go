@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dualwrite"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// ReservationShadowHandler reports divergence between the Redis clusters of a migration
+// and switches which one serves
+type ReservationShadowHandler struct {
+	repo *dualwrite.ReservationRepository
+}
+
+// NewReservationShadowHandler creates a new reservation shadow handler
+func NewReservationShadowHandler(repo *dualwrite.ReservationRepository) *ReservationShadowHandler {
+	return &ReservationShadowHandler{
+		repo: repo,
+	}
+}
+
+// GetStats handles GET /admin/reservation-shadow
+func (h *ReservationShadowHandler) GetStats(c *gin.Context) {
+	_, span := telemetry.StartSpan(c.Request.Context(), "handler.admin.reservation_shadow_stats")
+	defer span.End()
+
+	stats := h.repo.Stats()
+	span.SetAttributes(
+		attribute.String("mode", stats.Mode),
+		attribute.Int64("outcome_mismatches", stats.OutcomeMismatches),
+	)
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Data:    stats,
+	})
+}
+
+// SetMode handles PUT /admin/reservation-shadow/mode. The switch applies to this
+// instance only, so every booking-service replica and worker has to be switched.
+func (h *ReservationShadowHandler) SetMode(c *gin.Context) {
+	_, span := telemetry.StartSpan(c.Request.Context(), "handler.admin.reservation_shadow_mode")
+	defer span.End()
+
+	var req dto.SetReservationShadowModeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid request",
+			Code:    "INVALID_REQUEST",
+			Message: err.Error(),
+		})
+		return
+	}
+	if err := h.repo.SetMode(req.Mode); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_MODE",
+		})
+		return
+	}
+
+	span.SetAttributes(attribute.String("mode", req.Mode))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Data:    h.repo.Stats(),
+	})
+}
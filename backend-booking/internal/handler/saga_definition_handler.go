@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/saga"
+	pkgsaga "github.com/prohmpiriya/booking-rush-10k-rps/pkg/saga"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// SagaDefinitionHandler handles admin HTTP requests for the saga orchestrator's definitions
+type SagaDefinitionHandler struct {
+	orchestrator *pkgsaga.Orchestrator
+	loader       *saga.DefinitionLoader // nil when no definition file is configured
+}
+
+// NewSagaDefinitionHandler creates a new saga definition handler
+func NewSagaDefinitionHandler(orchestrator *pkgsaga.Orchestrator, loader *saga.DefinitionLoader) *SagaDefinitionHandler {
+	return &SagaDefinitionHandler{
+		orchestrator: orchestrator,
+		loader:       loader,
+	}
+}
+
+// ListDefinitions handles GET /admin/saga/definitions
+// Returns the definitions the orchestrator currently runs, with their step tuning
+func (h *SagaDefinitionHandler) ListDefinitions(c *gin.Context) {
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Data:    sagaDefinitionResponses(h.orchestrator.Definitions()),
+	})
+}
+
+// ReloadDefinitions handles POST /admin/saga/definitions/reload
+// Re-reads the saga definition file; nothing is applied if any definition is invalid
+func (h *SagaDefinitionHandler) ReloadDefinitions(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.admin.saga_definitions_reload")
+	defer span.End()
+
+	if h.loader == nil {
+		span.SetStatus(codes.Error, "no definition file")
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error: "no saga definition file is configured",
+			Code:  "SAGA_DEFINITIONS_NOT_CONFIGURED",
+		})
+		return
+	}
+
+	defs, err := h.loader.Reload(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		switch {
+		case errors.Is(err, pkgsaga.ErrInvalidDefinitionSpec),
+			errors.Is(err, pkgsaga.ErrUnknownStepHandler),
+			errors.Is(err, pkgsaga.ErrDefinitionStepsChanged):
+			c.JSON(http.StatusUnprocessableEntity, dto.ErrorResponse{
+				Error: err.Error(),
+				Code:  "INVALID_SAGA_DEFINITIONS",
+			})
+		default:
+			_ = c.Error(err)
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+				Error: "failed to reload saga definitions",
+				Code:  "INTERNAL_ERROR",
+			})
+		}
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Data: dto.SagaDefinitionReloadResponse{
+			Path:        h.loader.Path(),
+			Definitions: sagaDefinitionResponses(defs),
+		},
+	})
+}
+
+// sagaDefinitionResponses maps saga definitions to their responses
+func sagaDefinitionResponses(defs []*pkgsaga.Definition) []dto.SagaDefinitionResponse {
+	responses := make([]dto.SagaDefinitionResponse, 0, len(defs))
+	for _, def := range defs {
+		steps := make([]dto.SagaDefinitionStepResponse, 0, len(def.Steps))
+		for _, step := range def.Steps {
+			steps = append(steps, dto.SagaDefinitionStepResponse{
+				Name:    step.Name,
+				Topic:   step.Topic,
+				Timeout: step.Timeout.String(),
+				Retries: step.Retries,
+			})
+		}
+		responses = append(responses, dto.SagaDefinitionResponse{
+			Name:        def.Name,
+			Description: def.Description,
+			Timeout:     def.Timeout.String(),
+			Steps:       steps,
+		})
+	}
+	return responses
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// SagaStatsHandler handles admin HTTP requests for saga SLO dashboards
+type SagaStatsHandler struct {
+	sagaStatsService service.SagaStatsService
+}
+
+// NewSagaStatsHandler creates a new saga stats handler
+func NewSagaStatsHandler(sagaStatsService service.SagaStatsService) *SagaStatsHandler {
+	return &SagaStatsHandler{
+		sagaStatsService: sagaStatsService,
+	}
+}
+
+// GetSagaStats handles GET /admin/saga/stats
+// Returns rolling 1h and 24h saga outcome counts, compensation rate and step latencies
+func (h *SagaStatsHandler) GetSagaStats(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.admin.saga_stats")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	stats, err := h.sagaStatsService.GetStats(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if errors.Is(err, domain.ErrSagaStatsUnavailable) {
+			c.JSON(http.StatusServiceUnavailable, dto.ErrorResponse{
+				Error: err.Error(),
+				Code:  "SAGA_STATS_UNAVAILABLE",
+			})
+			return
+		}
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "internal server error",
+			Code:  "INTERNAL_ERROR",
+		})
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Data:    stats,
+	})
+}
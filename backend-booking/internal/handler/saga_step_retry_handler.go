@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	pkgsaga "github.com/prohmpiriya/booking-rush-10k-rps/pkg/saga"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// SagaStepRetryRoles are the roles allowed to retry dead-lettered saga steps
+var SagaStepRetryRoles = []string{"admin", "super_admin"}
+
+// SagaStepRetryHandler handles admin HTTP requests for retrying dead-lettered saga steps
+type SagaStepRetryHandler struct {
+	retryService service.SagaStepRetryService
+}
+
+// NewSagaStepRetryHandler creates a new saga step retry handler
+func NewSagaStepRetryHandler(retryService service.SagaStepRetryService) *SagaStepRetryHandler {
+	return &SagaStepRetryHandler{
+		retryService: retryService,
+	}
+}
+
+// RetryStep handles POST /admin/saga/:saga_id/steps/:step/retry
+// Re-emits the step's dead-lettered command with its original payload as the next attempt
+func (h *SagaStepRetryHandler) RetryStep(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.admin.retry_saga_step")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	sagaID := c.Param("saga_id")
+	stepName := c.Param("step")
+	retriedBy := c.GetHeader("X-User-ID")
+	span.SetAttributes(
+		attribute.String("saga_id", sagaID),
+		attribute.String("step_name", stepName),
+		attribute.String("retried_by", retriedBy),
+	)
+
+	retry, err := h.retryService.RetryStep(ctx, sagaID, stepName, retriedBy)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("attempt", retry.Attempt))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusAccepted, dto.SuccessResponse{
+		Success: true,
+		Data:    retry,
+		Message: "Saga step command re-emitted",
+	})
+}
+
+// handleError converts saga step retry errors to HTTP responses
+func (h *SagaStepRetryHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidSagaStep):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_REQUEST",
+		})
+	case errors.Is(err, pkgsaga.ErrSagaNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "NOT_FOUND",
+		})
+	case errors.Is(err, domain.ErrSagaStepNotDeadLettered):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "STEP_NOT_DEAD_LETTERED",
+		})
+	case errors.Is(err, domain.ErrSagaStepRetryNotAllowed):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "RETRY_NOT_ALLOWED",
+		})
+	case errors.Is(err, domain.ErrSagaStepRetryUnavailable):
+		c.JSON(http.StatusServiceUnavailable, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "SAGA_RETRY_UNAVAILABLE",
+		})
+	default:
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "internal server error",
+			Code:  "INTERNAL_ERROR",
+		})
+	}
+}
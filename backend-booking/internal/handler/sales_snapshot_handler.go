@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// SalesSnapshotHandler serves the hourly zone snapshots behind organizers' historical charts.
+// Calls are scoped to the tenant_id set from the X-Tenant-ID gateway header.
+type SalesSnapshotHandler struct {
+	snapshotService service.SalesSnapshotService
+}
+
+// NewSalesSnapshotHandler creates a new sales snapshot handler
+func NewSalesSnapshotHandler(snapshotService service.SalesSnapshotService) *SalesSnapshotHandler {
+	return &SalesSnapshotHandler{
+		snapshotService: snapshotService,
+	}
+}
+
+// GetEventSnapshots handles GET /reports/events/:event_id/snapshots?from=...&to=...
+// Returns each zone's held, sold and available seats and revenue per hour, with hourly
+// event totals. from and to are RFC 3339 times; the default is the last 7 days.
+func (h *SalesSnapshotHandler) GetEventSnapshots(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.sales_snapshot.get_event")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	tenantID := c.GetString("tenant_id")
+	if tenantID == "" {
+		span.SetStatus(codes.Error, "missing tenant")
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error:   "tenant required",
+			Code:    "TENANT_REQUIRED",
+			Message: "sales snapshots are only available to tenant accounts",
+		})
+		return
+	}
+	span.SetAttributes(attribute.String("tenant_id", tenantID))
+
+	from, errFrom := parseOptionalTime(c.Query("from"))
+	to, errTo := parseOptionalTime(c.Query("to"))
+	if errFrom != nil || errTo != nil {
+		span.SetStatus(codes.Error, "invalid range")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: domain.ErrInvalidSnapshotRange.Error(),
+			Code:  "INVALID_REQUEST",
+		})
+		return
+	}
+
+	snapshots, err := h.snapshotService.GetEventSnapshots(ctx, tenantID, c.Param("event_id"), from, to)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if domain.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: err.Error(),
+				Code:  "INVALID_REQUEST",
+			})
+			return
+		}
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "internal server error",
+			Code:  "INTERNAL_ERROR",
+		})
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Data:    snapshots,
+	})
+}
+
+// parseOptionalTime parses an RFC 3339 query parameter, the zero time when it is empty
+func parseOptionalTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// SeatMapHandler handles admin reserved seating layout HTTP requests.
+// Reservations in a zone with a seat map get best-available seats assigned automatically.
+// Organizers manage the seat maps of their own events' zones only.
+type SeatMapHandler struct {
+	seatMapService service.SeatMapService
+	access         service.EventAccessChecker
+}
+
+// NewSeatMapHandler creates a new seat map handler; without an access checker only admins
+// manage seat maps
+func NewSeatMapHandler(seatMapService service.SeatMapService, access service.EventAccessChecker) *SeatMapHandler {
+	return &SeatMapHandler{
+		seatMapService: seatMapService,
+		access:         access,
+	}
+}
+
+// SetSeatMap handles PUT /admin/zones/:id/seat-map
+func (h *SeatMapHandler) SetSeatMap(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.seat_map.set")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	zoneID := c.Param("id")
+	span.SetAttributes(attribute.String("zone_id", zoneID))
+
+	if !authorizeZone(c, h.access, zoneID) {
+		span.SetStatus(codes.Error, "zone access denied")
+		return
+	}
+
+	var req dto.SetSeatMapRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid request",
+			Code:    "INVALID_REQUEST",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	result, err := h.seatMapService.SetSeatMap(ctx, zoneID, &req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, result)
+}
+
+// GetSeatMap handles GET /admin/zones/:id/seat-map
+func (h *SeatMapHandler) GetSeatMap(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.seat_map.get")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	zoneID := c.Param("id")
+	span.SetAttributes(attribute.String("zone_id", zoneID))
+
+	if !authorizeZone(c, h.access, zoneID) {
+		span.SetStatus(codes.Error, "zone access denied")
+		return
+	}
+
+	result, err := h.seatMapService.GetSeatMap(ctx, zoneID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("available_seats", result.AvailableSeats))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, result)
+}
+
+// DeleteSeatMap handles DELETE /admin/zones/:id/seat-map
+func (h *SeatMapHandler) DeleteSeatMap(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.seat_map.delete")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	zoneID := c.Param("id")
+	span.SetAttributes(attribute.String("zone_id", zoneID))
+
+	if !authorizeZone(c, h.access, zoneID) {
+		span.SetStatus(codes.Error, "zone access denied")
+		return
+	}
+
+	if err := h.seatMapService.DeleteSeatMap(ctx, zoneID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Seat map deleted, zone is general admission",
+	})
+}
+
+// handleError maps seat map errors to HTTP responses
+func (h *SeatMapHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrSeatMapNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "SEAT_MAP_NOT_FOUND",
+		})
+	case errors.Is(err, domain.ErrSeatMapInUse):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error:   err.Error(),
+			Code:    "SEAT_MAP_IN_USE",
+			Message: "Wait for held seats to be released or expire before changing the layout",
+		})
+	case domain.IsValidationError(err):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_REQUEST",
+		})
+	default:
+		_ = c.Error(err) // Log the error with gin
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "internal server error",
+			Code:  "INTERNAL_ERROR",
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// StandbyHandler handles zone standby list HTTP requests.
+// Users join the standby list through POST /bookings/reserve with join_standby=true,
+// so the queue pass check applies before anyone is placed on the list.
+type StandbyHandler struct {
+	standbyService service.StandbyService
+}
+
+// NewStandbyHandler creates a new standby handler
+func NewStandbyHandler(standbyService service.StandbyService) *StandbyHandler {
+	return &StandbyHandler{
+		standbyService: standbyService,
+	}
+}
+
+// GetStandbyStatus handles GET /bookings/standby/:zone_id
+func (h *StandbyHandler) GetStandbyStatus(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.standby.status")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	userID := c.GetString("user_id")
+	if userID == "" {
+		span.SetStatus(codes.Error, "unauthorized")
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error: "unauthorized",
+			Code:  "UNAUTHORIZED",
+		})
+		return
+	}
+
+	zoneID := c.Param("zone_id")
+	span.SetAttributes(
+		attribute.String("user_id", userID),
+		attribute.String("zone_id", zoneID),
+	)
+
+	result, err := h.standbyService.GetStandbyStatus(ctx, userID, zoneID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetAttributes(attribute.String("status", result.Status))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, result)
+}
+
+// LeaveStandby handles DELETE /bookings/standby/:zone_id
+func (h *StandbyHandler) LeaveStandby(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.standby.leave")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	userID := c.GetString("user_id")
+	if userID == "" {
+		span.SetStatus(codes.Error, "unauthorized")
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error: "unauthorized",
+			Code:  "UNAUTHORIZED",
+		})
+		return
+	}
+
+	zoneID := c.Param("zone_id")
+	span.SetAttributes(
+		attribute.String("user_id", userID),
+		attribute.String("zone_id", zoneID),
+	)
+
+	if err := h.standbyService.LeaveStandby(ctx, userID, zoneID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Left the standby list",
+	})
+}
+
+// GetStandbyStats handles GET /admin/zones/:id/standby
+// Returns the zone's standby list size and how many offers were claimed or lapsed
+func (h *StandbyHandler) GetStandbyStats(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.admin.standby_stats")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	zoneID := c.Param("id")
+	span.SetAttributes(attribute.String("zone_id", zoneID))
+
+	stats, err := h.standbyService.GetStandbyStats(ctx, zoneID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Data:    stats,
+	})
+}
+
+// handleError converts domain errors to HTTP responses
+func (h *StandbyHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotOnStandby):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "NOT_ON_STANDBY",
+		})
+	case errors.Is(err, domain.ErrInvalidZoneID):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_REQUEST",
+		})
+	default:
+		_ = c.Error(err) // Log the error with gin
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "internal server error",
+			Code:  "INTERNAL_ERROR",
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// TenantShardRoles are the roles allowed to view and assign tenant shards
+var TenantShardRoles = []string{"super_admin"}
+
+// TenantShardHandler shows and assigns the database shards of tenants
+type TenantShardHandler struct {
+	resolver *database.ShardResolver
+}
+
+// NewTenantShardHandler creates a new tenant shard handler
+func NewTenantShardHandler(resolver *database.ShardResolver) *TenantShardHandler {
+	return &TenantShardHandler{
+		resolver: resolver,
+	}
+}
+
+// List handles GET /admin/tenant-shards
+func (h *TenantShardHandler) List(c *gin.Context) {
+	_, span := telemetry.StartSpan(c.Request.Context(), "handler.admin.tenant_shards_list")
+	defer span.End()
+
+	resp := dto.TenantShardsResponse{
+		Shards:      h.resolver.Shards(),
+		Assignments: h.resolver.Assignments(),
+	}
+	span.SetAttributes(attribute.Int("assignments", len(resp.Assignments)))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Data:    resp,
+	})
+}
+
+// Assign handles PUT /admin/tenant-shards/:tenant_id. Existing bookings of the tenant
+// are not moved, and other replicas follow within the shard refresh interval.
+func (h *TenantShardHandler) Assign(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.admin.tenant_shards_assign")
+	defer span.End()
+
+	tenantID := c.Param("tenant_id")
+	span.SetAttributes(attribute.String("tenant_id", tenantID))
+
+	var req dto.AssignTenantShardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid request",
+			Code:    "INVALID_REQUEST",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	assignment, err := h.resolver.Assign(ctx, tenantID, req.Shard)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if errors.Is(err, database.ErrUnknownShard) {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: err.Error(),
+				Code:  "UNKNOWN_SHARD",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "failed to assign tenant shard",
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	span.SetAttributes(attribute.String("shard", assignment.Shard))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Data:    assignment,
+	})
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// UserDataHandler handles internal user data export/anonymization requests from auth-service
+type UserDataHandler struct {
+	userDataService service.UserDataService
+}
+
+// NewUserDataHandler creates a new user data handler
+func NewUserDataHandler(userDataService service.UserDataService) *UserDataHandler {
+	return &UserDataHandler{
+		userDataService: userDataService,
+	}
+}
+
+// ExportUserData handles GET /internal/users/:user_id/data
+func (h *UserDataHandler) ExportUserData(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.user_data.export")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	userID := c.Param("user_id")
+	span.SetAttributes(attribute.String("user_id", userID))
+
+	bookings, err := h.userDataService.ExportUserData(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("count", len(bookings)))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Data:    bookings,
+	})
+}
+
+// AnonymizeUserData handles POST /internal/users/:user_id/anonymize
+func (h *UserDataHandler) AnonymizeUserData(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.user_data.anonymize")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	userID := c.Param("user_id")
+	span.SetAttributes(attribute.String("user_id", userID))
+
+	var req dto.AnonymizeUserDataRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid request",
+			Code:    "INVALID_REQUEST",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	count, err := h.userDataService.AnonymizeUserData(ctx, userID, req.AnonymizedID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetAttributes(attribute.Int64("anonymized", count))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Data: &dto.AnonymizeUserDataResponse{
+			UserID:       userID,
+			AnonymizedID: req.AnonymizedID,
+			Anonymized:   count,
+		},
+	})
+}
+
+// handleError converts domain errors to HTTP responses
+func (h *UserDataHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidUserID):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_USER_ID",
+		})
+	default:
+		_ = c.Error(err)
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "internal server error",
+			Code:  "INTERNAL_ERROR",
+		})
+	}
+}
@@ -0,0 +1,349 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// WebhookHandler handles tenant webhook subscription and delivery log HTTP requests.
+// Every call is scoped to the tenant_id set from the X-Tenant-ID gateway header.
+type WebhookHandler struct {
+	webhookService service.WebhookService
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(webhookService service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// CreateSubscription handles POST /webhook-subscriptions
+func (h *WebhookHandler) CreateSubscription(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.webhook.create_subscription")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	tenantID, ok := h.requireTenant(c)
+	if !ok {
+		span.SetStatus(codes.Error, "missing tenant")
+		return
+	}
+	span.SetAttributes(attribute.String("tenant_id", tenantID))
+
+	var req dto.CreateWebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid request",
+			Code:    "INVALID_REQUEST",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	result, err := h.webhookService.CreateSubscription(ctx, tenantID, &req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetAttributes(attribute.String("subscription_id", result.ID))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusCreated, result)
+}
+
+// ListSubscriptions handles GET /webhook-subscriptions
+func (h *WebhookHandler) ListSubscriptions(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.webhook.list_subscriptions")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	tenantID, ok := h.requireTenant(c)
+	if !ok {
+		span.SetStatus(codes.Error, "missing tenant")
+		return
+	}
+	span.SetAttributes(attribute.String("tenant_id", tenantID))
+
+	result, err := h.webhookService.ListSubscriptions(ctx, tenantID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Data:    result,
+	})
+}
+
+// GetSubscription handles GET /webhook-subscriptions/:id
+func (h *WebhookHandler) GetSubscription(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.webhook.get_subscription")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	tenantID, ok := h.requireTenant(c)
+	if !ok {
+		span.SetStatus(codes.Error, "missing tenant")
+		return
+	}
+	id := c.Param("id")
+	span.SetAttributes(
+		attribute.String("tenant_id", tenantID),
+		attribute.String("subscription_id", id),
+	)
+
+	result, err := h.webhookService.GetSubscription(ctx, tenantID, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, result)
+}
+
+// UpdateSubscription handles PATCH /webhook-subscriptions/:id
+func (h *WebhookHandler) UpdateSubscription(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.webhook.update_subscription")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	tenantID, ok := h.requireTenant(c)
+	if !ok {
+		span.SetStatus(codes.Error, "missing tenant")
+		return
+	}
+	id := c.Param("id")
+	span.SetAttributes(
+		attribute.String("tenant_id", tenantID),
+		attribute.String("subscription_id", id),
+	)
+
+	var req dto.UpdateWebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid request",
+			Code:    "INVALID_REQUEST",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	result, err := h.webhookService.UpdateSubscription(ctx, tenantID, id, &req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, result)
+}
+
+// DeleteSubscription handles DELETE /webhook-subscriptions/:id
+func (h *WebhookHandler) DeleteSubscription(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.webhook.delete_subscription")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	tenantID, ok := h.requireTenant(c)
+	if !ok {
+		span.SetStatus(codes.Error, "missing tenant")
+		return
+	}
+	id := c.Param("id")
+	span.SetAttributes(
+		attribute.String("tenant_id", tenantID),
+		attribute.String("subscription_id", id),
+	)
+
+	if err := h.webhookService.DeleteSubscription(ctx, tenantID, id); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.SuccessResponse{
+		Success: true,
+		Message: "Webhook subscription deleted",
+	})
+}
+
+// ListDeliveries handles GET /webhook-deliveries
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.webhook.list_deliveries")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	tenantID, ok := h.requireTenant(c)
+	if !ok {
+		span.SetStatus(codes.Error, "missing tenant")
+		return
+	}
+
+	// Parse filter and pagination parameters
+	req := &dto.WebhookDeliveryListRequest{
+		SubscriptionID: c.Query("subscription_id"),
+		EventID:        c.Query("event_id"),
+		EventType:      c.Query("event_type"),
+		Status:         c.Query("status"),
+		Limit:          50,
+	}
+	if l := c.Query("limit"); l != "" {
+		if n, err := strconv.Atoi(l); err == nil && n > 0 && n <= 200 {
+			req.Limit = n
+		}
+	}
+	if o := c.Query("offset"); o != "" {
+		if n, err := strconv.Atoi(o); err == nil && n >= 0 {
+			req.Offset = n
+		}
+	}
+
+	span.SetAttributes(
+		attribute.String("tenant_id", tenantID),
+		attribute.String("subscription_id", req.SubscriptionID),
+		attribute.Int("limit", req.Limit),
+		attribute.Int("offset", req.Offset),
+	)
+
+	result, err := h.webhookService.ListDeliveries(ctx, tenantID, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, result)
+}
+
+// GetDelivery handles GET /webhook-deliveries/:id
+func (h *WebhookHandler) GetDelivery(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.webhook.get_delivery")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	tenantID, ok := h.requireTenant(c)
+	if !ok {
+		span.SetStatus(codes.Error, "missing tenant")
+		return
+	}
+	id := c.Param("id")
+	span.SetAttributes(
+		attribute.String("tenant_id", tenantID),
+		attribute.String("delivery_id", id),
+	)
+
+	result, err := h.webhookService.GetDelivery(ctx, tenantID, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, result)
+}
+
+// RetryDelivery handles POST /webhook-deliveries/:id/retry
+func (h *WebhookHandler) RetryDelivery(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.webhook.retry_delivery")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	tenantID, ok := h.requireTenant(c)
+	if !ok {
+		span.SetStatus(codes.Error, "missing tenant")
+		return
+	}
+	id := c.Param("id")
+	span.SetAttributes(
+		attribute.String("tenant_id", tenantID),
+		attribute.String("delivery_id", id),
+	)
+
+	result, err := h.webhookService.RetryDelivery(ctx, tenantID, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusAccepted, result)
+}
+
+// requireTenant returns the caller's tenant, writing a 403 response if there is none
+func (h *WebhookHandler) requireTenant(c *gin.Context) (string, bool) {
+	tenantID := c.GetString("tenant_id")
+	if tenantID == "" {
+		c.JSON(http.StatusForbidden, dto.ErrorResponse{
+			Error:   "tenant required",
+			Code:    "TENANT_REQUIRED",
+			Message: "webhooks are only available to tenant accounts",
+		})
+		return "", false
+	}
+	return tenantID, true
+}
+
+// handleError converts domain errors to HTTP responses
+func (h *WebhookHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrWebhookSubscriptionNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "WEBHOOK_SUBSCRIPTION_NOT_FOUND",
+		})
+	case errors.Is(err, domain.ErrWebhookDeliveryNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "WEBHOOK_DELIVERY_NOT_FOUND",
+		})
+	case errors.Is(err, domain.ErrWebhookDeliveryNotRetryable):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "WEBHOOK_DELIVERY_NOT_RETRYABLE",
+		})
+	case domain.IsValidationError(err):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_REQUEST",
+		})
+	default:
+		_ = c.Error(err) // Log the error with gin
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "internal server error",
+			Code:  "INTERNAL_ERROR",
+		})
+	}
+}
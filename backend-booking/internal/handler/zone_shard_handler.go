@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// ZoneShardHandler handles admin counter sharding HTTP requests for hot zones
+type ZoneShardHandler struct {
+	shardService service.ZoneShardService
+}
+
+// NewZoneShardHandler creates a new zone shard handler
+func NewZoneShardHandler(shardService service.ZoneShardService) *ZoneShardHandler {
+	return &ZoneShardHandler{
+		shardService: shardService,
+	}
+}
+
+// SetZoneShards handles PUT /admin/zones/:id/shards
+func (h *ZoneShardHandler) SetZoneShards(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.zone_shard.set")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	zoneID := c.Param("id")
+	span.SetAttributes(attribute.String("zone_id", zoneID))
+
+	var req dto.SetZoneShardsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request")
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid request",
+			Code:    "INVALID_REQUEST",
+			Message: err.Error(),
+		})
+		return
+	}
+
+	result, err := h.shardService.SetZoneShards(ctx, zoneID, &req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, result)
+}
+
+// GetZoneShards handles GET /admin/zones/:id/shards
+func (h *ZoneShardHandler) GetZoneShards(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.zone_shard.get")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	zoneID := c.Param("id")
+	span.SetAttributes(attribute.String("zone_id", zoneID))
+
+	result, err := h.shardService.GetZoneShards(ctx, zoneID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("shards", result.Shards))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, result)
+}
+
+// handleError maps zone shard errors to HTTP responses
+func (h *ZoneShardHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrZoneNotFound):
+		c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error:   err.Error(),
+			Code:    "ZONE_NOT_FOUND",
+			Message: "Zone availability is not live in Redis",
+		})
+	case errors.Is(err, domain.ErrShardLayoutChanged):
+		c.JSON(http.StatusConflict, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "SHARD_LAYOUT_CHANGED",
+		})
+	case domain.IsValidationError(err):
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+			Code:  "INVALID_REQUEST",
+		})
+	default:
+		_ = c.Error(err) // Log the error with gin
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "internal server error",
+			Code:  "INTERNAL_ERROR",
+		})
+	}
+}
@@ -21,9 +21,26 @@ var (
 	QueueLeft   *telemetry.Counter
 
 	// Error tracking counters
-	ErrorsTotal      *telemetry.Counter
+	ErrorsTotal       *telemetry.Counter
 	SlowRequestsTotal *telemetry.Counter
 
+	// Chaos mode fault injections (load tests only)
+	ChaosInjections *telemetry.Counter
+
+	// Mirrored reservation writes by outcome (Redis migration shadow mode)
+	ReservationShadowCalls *telemetry.Counter
+
+	// Async confirmation outcomes
+	AsyncConfirms *telemetry.Counter
+
+	// Sampled availability reads compared with PostgreSQL, by outcome and, when they
+	// diverged, by how many seats and in which zone
+	AvailabilityVerifications *telemetry.Counter
+	AvailabilityDivergence    *telemetry.Histogram
+
+	// Per-event queue release leases taken, lost or given up by queue-release-worker replicas
+	QueueReleaseLeaseChanges *telemetry.Counter
+
 	// Histograms
 	ReservationDuration *telemetry.Histogram
 	QueueWaitTime       *telemetry.Histogram
@@ -33,6 +50,22 @@ var (
 	ActiveReservations *telemetry.UpDownCounter
 	QueueDepth         *telemetry.UpDownCounter
 
+	// Saga counters
+	SagasStarted     *telemetry.Counter
+	SagasCompleted   *telemetry.Counter
+	SagasCompensated *telemetry.Counter
+	SagasFailed      *telemetry.Counter
+
+	// Saga histograms
+	SagaStepDuration *telemetry.Histogram
+	SagaDuration     *telemetry.Histogram
+
+	// Saga compensation rate (compensated / finished sagas since process start)
+	SagaCompensationRate *telemetry.FloatGauge
+
+	sagaOutcomesMu sync.Mutex
+	sagaOutcomes   = make(map[string]*sagaOutcomeCount)
+
 	initOnce sync.Once
 	initErr  error
 )
@@ -180,6 +213,129 @@ func initMetrics() error {
 		return err
 	}
 
+	ChaosInjections, err = telemetry.NewCounter(telemetry.MetricOpts{
+		Name:        "booking_chaos_injections_total",
+		Description: "Total number of faults injected by chaos mode",
+		Unit:        "1",
+	})
+	if err != nil {
+		return err
+	}
+
+	ReservationShadowCalls, err = telemetry.NewCounter(telemetry.MetricOpts{
+		Name:        "booking_reservation_shadow_calls_total",
+		Description: "Total number of reservation calls mirrored to the shadow Redis, by comparison outcome",
+		Unit:        "1",
+	})
+	if err != nil {
+		return err
+	}
+
+	AsyncConfirms, err = telemetry.NewCounter(telemetry.MetricOpts{
+		Name:        "booking_async_confirms_total",
+		Description: "Total number of asynchronous confirmations by outcome",
+		Unit:        "1",
+	})
+	if err != nil {
+		return err
+	}
+
+	AvailabilityVerifications, err = telemetry.NewCounter(telemetry.MetricOpts{
+		Name:        "booking_availability_verifications_total",
+		Description: "Total number of sampled availability reads compared with PostgreSQL, by outcome",
+		Unit:        "1",
+	})
+	if err != nil {
+		return err
+	}
+
+	AvailabilityDivergence, err = telemetry.NewHistogramWithBuckets(telemetry.MetricOpts{
+		Name:        "booking_availability_divergence_seats",
+		Description: "Seats by which a sampled Redis availability read differed from PostgreSQL, by zone",
+		Unit:        "1",
+	}, []float64{1, 2, 5, 10, 25, 50, 100, 250, 500, 1000})
+	if err != nil {
+		return err
+	}
+
+	QueueReleaseLeaseChanges, err = telemetry.NewCounter(telemetry.MetricOpts{
+		Name:        "booking_queue_release_lease_changes_total",
+		Description: "Queue release lease ownership changes by event and change (acquired, lost, released)",
+		Unit:        "1",
+	})
+	if err != nil {
+		return err
+	}
+
+	return initSagaMetrics()
+}
+
+func initSagaMetrics() error {
+	var err error
+
+	SagasStarted, err = telemetry.NewCounter(telemetry.MetricOpts{
+		Name:        "saga_started_total",
+		Description: "Total number of sagas started",
+		Unit:        "1",
+	})
+	if err != nil {
+		return err
+	}
+
+	SagasCompleted, err = telemetry.NewCounter(telemetry.MetricOpts{
+		Name:        "saga_completed_total",
+		Description: "Total number of sagas completed successfully",
+		Unit:        "1",
+	})
+	if err != nil {
+		return err
+	}
+
+	SagasCompensated, err = telemetry.NewCounter(telemetry.MetricOpts{
+		Name:        "saga_compensated_total",
+		Description: "Total number of sagas rolled back by compensation",
+		Unit:        "1",
+	})
+	if err != nil {
+		return err
+	}
+
+	SagasFailed, err = telemetry.NewCounter(telemetry.MetricOpts{
+		Name:        "saga_failed_total",
+		Description: "Total number of sagas failed by a step failure or timeout",
+		Unit:        "1",
+	})
+	if err != nil {
+		return err
+	}
+
+	SagaStepDuration, err = telemetry.NewHistogramWithBuckets(telemetry.MetricOpts{
+		Name:        "saga_step_duration_seconds",
+		Description: "Duration of individual saga steps",
+		Unit:        "s",
+	}, []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}) // 5ms to 30s (step timeout)
+	if err != nil {
+		return err
+	}
+
+	SagaDuration, err = telemetry.NewHistogramWithBuckets(telemetry.MetricOpts{
+		Name:        "saga_duration_seconds",
+		Description: "Duration from saga start to completion or compensation",
+		Unit:        "s",
+	}, []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300}) // 50ms to 5min (saga timeout)
+	if err != nil {
+		return err
+	}
+
+	SagaCompensationRate, err = telemetry.NewFloatGauge(telemetry.MetricOpts{
+		Name:        "saga_compensation_rate",
+		Description: "Share of finished sagas that were compensated",
+		Unit:        "1",
+	})
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -226,6 +382,62 @@ func RecordExpiration(ctx context.Context, eventID string, count int64) {
 	}
 }
 
+// RecordChaosInjection records a fault injected by chaos mode
+func RecordChaosInjection(ctx context.Context, kind, target string) {
+	if ChaosInjections != nil {
+		ChaosInjections.Inc(ctx,
+			attribute.String("kind", kind),
+			attribute.String("target", target),
+		)
+	}
+}
+
+// RecordReservationShadow records how a mirrored reservation call compared with the serving one
+func RecordReservationShadow(ctx context.Context, operation, outcome string) {
+	if ReservationShadowCalls != nil {
+		ReservationShadowCalls.Inc(ctx,
+			attribute.String("operation", operation),
+			attribute.String("outcome", outcome),
+		)
+	}
+}
+
+// RecordAvailabilityVerification records how a sampled availability read compared with PostgreSQL
+func RecordAvailabilityVerification(ctx context.Context, outcome string) {
+	if AvailabilityVerifications != nil {
+		AvailabilityVerifications.Inc(ctx, attribute.String("outcome", outcome))
+	}
+}
+
+// RecordAvailabilityDivergence records a zone whose Redis availability differed from PostgreSQL.
+// direction is redis_higher or redis_lower.
+func RecordAvailabilityDivergence(ctx context.Context, zoneID, direction string, seats int64) {
+	if AvailabilityDivergence != nil {
+		AvailabilityDivergence.Record(ctx, float64(seats),
+			attribute.String("zone_id", zoneID),
+			attribute.String("direction", direction),
+		)
+	}
+}
+
+// RecordQueueReleaseLeaseChange records a queue-release-worker replica taking, losing or
+// giving up the release lease of an event
+func RecordQueueReleaseLeaseChange(ctx context.Context, eventID, change string) {
+	if QueueReleaseLeaseChanges != nil {
+		QueueReleaseLeaseChanges.Inc(ctx,
+			attribute.String("event_id", eventID),
+			attribute.String("change", change),
+		)
+	}
+}
+
+// RecordAsyncConfirm records an async confirmation outcome (queued, retried, succeeded, failed)
+func RecordAsyncConfirm(ctx context.Context, outcome string) {
+	if AsyncConfirms != nil {
+		AsyncConfirms.Inc(ctx, attribute.String("outcome", outcome))
+	}
+}
+
 // RecordFailure records a booking failure metric
 func RecordFailure(ctx context.Context, eventID, reason string) {
 	if BookingsFailed != nil {
@@ -311,3 +523,95 @@ func RecordSlowRequest(ctx context.Context, operation string, durationSeconds fl
 		)
 	}
 }
+
+// sagaOutcomeCount tracks finished sagas per saga name for the compensation rate gauge
+type sagaOutcomeCount struct {
+	completed   int64
+	compensated int64
+}
+
+// RecordSagaStarted records a saga start
+func RecordSagaStarted(ctx context.Context, sagaName string) {
+	if SagasStarted != nil {
+		SagasStarted.Inc(ctx,
+			attribute.String("saga_name", sagaName),
+		)
+	}
+}
+
+// RecordSagaCompleted records a successfully completed saga and its total duration
+func RecordSagaCompleted(ctx context.Context, sagaName string, durationSeconds float64) {
+	if SagasCompleted != nil {
+		SagasCompleted.Inc(ctx,
+			attribute.String("saga_name", sagaName),
+		)
+	}
+	recordSagaDuration(ctx, sagaName, "completed", durationSeconds)
+	recordSagaOutcome(ctx, sagaName, false)
+}
+
+// RecordSagaCompensated records a saga rolled back by compensation and its total duration
+func RecordSagaCompensated(ctx context.Context, sagaName string, durationSeconds float64) {
+	if SagasCompensated != nil {
+		SagasCompensated.Inc(ctx,
+			attribute.String("saga_name", sagaName),
+		)
+	}
+	recordSagaDuration(ctx, sagaName, "compensated", durationSeconds)
+	recordSagaOutcome(ctx, sagaName, true)
+}
+
+// RecordSagaFailed records a saga failure by the step that failed and the reason (failed, timeout)
+func RecordSagaFailed(ctx context.Context, sagaName, stepName, reason string) {
+	if SagasFailed != nil {
+		SagasFailed.Inc(ctx,
+			attribute.String("saga_name", sagaName),
+			attribute.String("step_name", stepName),
+			attribute.String("reason", reason),
+		)
+	}
+}
+
+// RecordSagaStep records the duration of a saga step by outcome
+func RecordSagaStep(ctx context.Context, sagaName, stepName, status string, durationSeconds float64) {
+	if SagaStepDuration != nil {
+		SagaStepDuration.Record(ctx, durationSeconds,
+			attribute.String("saga_name", sagaName),
+			attribute.String("step_name", stepName),
+			attribute.String("status", status),
+		)
+	}
+}
+
+func recordSagaDuration(ctx context.Context, sagaName, outcome string, durationSeconds float64) {
+	if SagaDuration != nil {
+		SagaDuration.Record(ctx, durationSeconds,
+			attribute.String("saga_name", sagaName),
+			attribute.String("outcome", outcome),
+		)
+	}
+}
+
+func recordSagaOutcome(ctx context.Context, sagaName string, compensated bool) {
+	if SagaCompensationRate == nil {
+		return
+	}
+
+	sagaOutcomesMu.Lock()
+	count, ok := sagaOutcomes[sagaName]
+	if !ok {
+		count = &sagaOutcomeCount{}
+		sagaOutcomes[sagaName] = count
+	}
+	if compensated {
+		count.compensated++
+	} else {
+		count.completed++
+	}
+	rate := float64(count.compensated) / float64(count.completed+count.compensated)
+	sagaOutcomesMu.Unlock()
+
+	SagaCompensationRate.Record(ctx, rate,
+		attribute.String("saga_name", sagaName),
+	)
+}
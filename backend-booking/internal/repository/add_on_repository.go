@@ -0,0 +1,23 @@
+package repository
+
+import "context"
+
+// AddOnClaim is a quantity of an event add-on a reservation claims, with the add-on's inventory
+type AddOnClaim struct {
+	AddOnID   string
+	Quantity  int
+	Inventory int // Units for sale (0 = no limit)
+}
+
+// AddOnRepository defines the interface for the units sold per event add-on
+type AddOnRepository interface {
+	// ClaimAddOns atomically counts a reservation's add-ons against their inventory,
+	// all or none. Returns "" when claimed, or the ID of the first add-on without
+	// enough units left. Claiming again for the same booking claims nothing more.
+	ClaimAddOns(ctx context.Context, eventID, bookingID string, claims []AddOnClaim) (string, error)
+
+	// ReleaseAddOns gives a reservation's add-ons back to their inventory. Releasing
+	// the reservation's seats does the same, so this is only needed when the
+	// reservation was never made.
+	ReleaseAddOns(ctx context.Context, eventID, bookingID string) error
+}
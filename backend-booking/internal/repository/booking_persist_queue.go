@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// BookingPersistQueue defines the interface for the write-behind queue of reserved
+// bookings that are not yet stored in PostgreSQL
+type BookingPersistQueue interface {
+	// Enqueue stores a pending booking and queues it for persistence
+	Enqueue(ctx context.Context, booking *domain.Booking, ttl time.Duration) error
+
+	// Dequeue blocks up to wait for the next queued booking, nil if none arrived.
+	// The booking stays in flight until Ack or Requeue.
+	Dequeue(ctx context.Context, wait time.Duration) (*domain.Booking, error)
+
+	// Ack removes a persisted booking from the queue
+	Ack(ctx context.Context, bookingID string) error
+
+	// Requeue puts an in-flight booking back on the queue after a failed attempt
+	Requeue(ctx context.Context, bookingID string) error
+
+	// RecoverInFlight requeues bookings left in flight by a stopped worker
+	RecoverInFlight(ctx context.Context) (int64, error)
+
+	// GetPending retrieves a booking that is not yet persisted, nil if there is none
+	GetPending(ctx context.Context, bookingID string) (*domain.Booking, error)
+
+	// ForgetIdempotencyKey drops the idempotency index of a reservation that will
+	// never be persisted, so a retry can reserve again
+	ForgetIdempotencyKey(ctx context.Context, key, bookingID string) error
+}
@@ -2,20 +2,25 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 )
 
 // BookingRepository defines the interface for booking data access
 type BookingRepository interface {
-	// Create creates a new booking record in the database
+	// Create creates a new booking record in the database. If a booking of the same
+	// request exists with the idempotency key, booking is replaced with it and
+	// domain.ErrBookingAlreadyExists is returned; a booking of another user, tenant
+	// or request returns domain.ErrIdempotencyKeyReused.
 	Create(ctx context.Context, booking *domain.Booking) error
 
 	// GetByID retrieves a booking by its ID
 	GetByID(ctx context.Context, id string) (*domain.Booking, error)
 
-	// GetByUserID retrieves all bookings for a user
-	GetByUserID(ctx context.Context, userID string, limit, offset int) ([]*domain.Booking, error)
+	// GetByUserID retrieves a page of a user's bookings, newest first
+	GetByUserID(ctx context.Context, userID string, page pagination.Params) ([]*domain.Booking, error)
 
 	// Update updates an existing booking
 	Update(ctx context.Context, booking *domain.Booking) error
@@ -38,6 +43,15 @@ type BookingRepository interface {
 	// MarkAsExpired marks a booking as expired
 	MarkAsExpired(ctx context.Context, id string) error
 
+	// ExtendReservation pushes back the expiry of a reserved booking to expiresAt.
+	// Reservations already expiring later are left alone.
+	ExtendReservation(ctx context.Context, id string, expiresAt time.Time) error
+
+	// AwaitPayment moves a held booking to pending_payment for paymentID, the payment the
+	// customer completes at the gateway, and pushes its expiry back to expiresAt.
+	// Returns ErrBookingNotFound if the booking no longer holds its seats.
+	AwaitPayment(ctx context.Context, id, paymentID string, expiresAt time.Time) error
+
 	// GetByIdempotencyKey retrieves a booking by idempotency key
 	GetByIdempotencyKey(ctx context.Context, key string) (*domain.Booking, error)
 
@@ -46,4 +60,34 @@ type BookingRepository interface {
 
 	// GetTenantIDByShowID retrieves tenant_id from shows table
 	GetTenantIDByShowID(ctx context.Context, showID string) (string, error)
+
+	// GetShowStartTime retrieves when a show starts from shows table
+	GetShowStartTime(ctx context.Context, showID string) (time.Time, error)
+}
+
+// BookingBatchRepository is implemented by booking repositories that can read and cancel
+// many bookings in one query, for workers draining mass expiries
+type BookingBatchRepository interface {
+	// GetByIDs retrieves the bookings with the given IDs; unknown IDs are skipped
+	GetByIDs(ctx context.Context, ids []string) ([]*domain.Booking, error)
+
+	// CancelReserved cancels the given bookings that are still pending or reserved
+	// and returns the IDs it cancelled
+	CancelReserved(ctx context.Context, ids []string) ([]string, error)
+}
+
+// BookingBulkInserter is implemented by booking repositories that can insert many
+// bookings with one statement, for batching reservations under burst load
+type BookingBulkInserter interface {
+	// CreateMany inserts the bookings and returns the IDs it inserted. Bookings whose
+	// idempotency key is already stored (or repeated in the batch) are skipped.
+	CreateMany(ctx context.Context, bookings []*domain.Booking) (map[string]bool, error)
+}
+
+// IdleReservationFinder is implemented by booking repositories that can list reservations
+// no payment was started for, for suggesting their early release
+type IdleReservationFinder interface {
+	// GetIdleReservations gets up to limit reserved bookings with no payment, reserved
+	// after reservedAfter and at or before reservedBefore, the oldest first
+	GetIdleReservations(ctx context.Context, reservedAfter, reservedBefore time.Time, limit int) ([]*domain.Booking, error)
 }
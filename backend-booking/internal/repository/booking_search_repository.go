@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// BookingSearchFilter holds filters for the support booking search. Set filters are
+// combined with AND; every filter is backed by an index on bookings.
+type BookingSearchFilter struct {
+	TenantID         string   // Empty searches all tenants
+	UserIDs          []string // Resolved from email by auth-service
+	BookingIDs       []string // Resolved from card last four by payment-service
+	ConfirmationCode string   // Case-insensitive
+	PaymentID        string
+	Limit            int
+}
+
+// BookingSearchRepository defines booking lookups for support agents
+type BookingSearchRepository interface {
+	// Search returns the most recent bookings matching the filter
+	Search(ctx context.Context, filter *BookingSearchFilter) ([]*domain.Booking, error)
+}
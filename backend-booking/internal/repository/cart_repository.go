@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// CartRepository defines the interface for carts and their checkouts
+type CartRepository interface {
+	// GetCart retrieves the user's cart, nil if the user has none
+	GetCart(ctx context.Context, userID string) (*domain.Cart, error)
+
+	// SaveCart stores the user's cart, refreshing its TTL
+	SaveCart(ctx context.Context, cart *domain.Cart, ttl time.Duration) error
+
+	// DeleteCart removes the user's cart
+	DeleteCart(ctx context.Context, userID string) error
+
+	// SaveCheckout stores a checkout
+	SaveCheckout(ctx context.Context, checkout *domain.Checkout, ttl time.Duration) error
+
+	// GetCheckout retrieves a checkout by ID, nil if it does not exist
+	GetCheckout(ctx context.Context, checkoutID string) (*domain.Checkout, error)
+}
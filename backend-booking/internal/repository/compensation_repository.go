@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// CompensationFilter holds optional filters for listing compensations
+type CompensationFilter struct {
+	SagaID    string
+	BookingID string
+	Limit     int
+	Offset    int
+}
+
+// CompensationRepository defines the interface for compensation audit trail access
+type CompensationRepository interface {
+	// Create inserts a new compensation record
+	Create(ctx context.Context, compensation *domain.Compensation) error
+
+	// GetByID retrieves a compensation record by its ID
+	GetByID(ctx context.Context, id string) (*domain.Compensation, error)
+
+	// List retrieves compensation records matching the filter, newest first
+	List(ctx context.Context, filter *CompensationFilter) ([]*domain.Compensation, error)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// ConfirmJobRepository defines the interface for the asynchronous confirm queue
+type ConfirmJobRepository interface {
+	// Create stores a new job and queues it, unless the booking already has a job.
+	// Returns false with the existing job in that case.
+	Create(ctx context.Context, job *domain.ConfirmJob, ttl time.Duration) (bool, *domain.ConfirmJob, error)
+
+	// Requeue replaces the booking's job and queues it again
+	Requeue(ctx context.Context, job *domain.ConfirmJob, ttl time.Duration) error
+
+	// Dequeue blocks up to wait for the next queued job, nil if none arrived
+	Dequeue(ctx context.Context, wait time.Duration) (*domain.ConfirmJob, error)
+
+	// Save updates the job's status
+	Save(ctx context.Context, job *domain.ConfirmJob, ttl time.Duration) error
+
+	// GetByBookingID retrieves the booking's job, nil if it has none
+	GetByBookingID(ctx context.Context, bookingID string) (*domain.ConfirmJob, error)
+}
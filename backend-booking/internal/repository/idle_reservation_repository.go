@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// IdleReservationRepository tracks reservations offered for early release: whether the
+// customer started paying and whether they were already reminded
+type IdleReservationRepository interface {
+	// MarkPaymentStarted records that a payment was created for the booking; the mark
+	// lasts for ttl
+	MarkPaymentStarted(ctx context.Context, bookingID string, ttl time.Duration) error
+
+	// PaymentStarted returns the bookings of bookingIDs a payment was created for
+	PaymentStarted(ctx context.Context, bookingIDs []string) (map[string]bool, error)
+
+	// MarkReminded records that the holder of the booking was reminded, for ttl.
+	// Returns false if they already were.
+	MarkReminded(ctx context.Context, bookingID string, ttl time.Duration) (bool, error)
+}
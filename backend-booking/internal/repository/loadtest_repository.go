@@ -0,0 +1,11 @@
+package repository
+
+import "context"
+
+// LoadTestRepository clears the Redis state load-test runs leave behind
+type LoadTestRepository interface {
+	// ResetEvent deletes the virtual queue (every backend), queue passes and per-user
+	// reservation counters of an event and returns the number of keys deleted.
+	// Reservations and zone availability are left to the caller.
+	ResetEvent(ctx context.Context, eventID string) (int64, error)
+}
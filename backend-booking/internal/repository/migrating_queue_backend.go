@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+)
+
+// MigratingQueueBackend serves queues while they move between backends. Users still in
+// the old backend are ahead of everyone in the new one: they keep their positions and are
+// released first, while new joins go to the new backend. Once MigrateQueues reports no
+// queues left in the old backend, switch to the new backend alone.
+type MigratingQueueBackend struct {
+	from QueueBackend
+	to   QueueBackend
+}
+
+// NewMigratingQueueBackend creates a backend draining from and writing to to
+func NewMigratingQueueBackend(from, to QueueBackend) *MigratingQueueBackend {
+	return &MigratingQueueBackend{from: from, to: to}
+}
+
+// Name returns the target backend name
+func (b *MigratingQueueBackend) Name() string {
+	return b.to.Name()
+}
+
+// LoadScripts loads the scripts of both backends
+func (b *MigratingQueueBackend) LoadScripts(ctx context.Context) error {
+	if err := b.from.LoadScripts(ctx); err != nil {
+		return err
+	}
+	return b.to.LoadScripts(ctx)
+}
+
+// Join adds a user to the new backend unless they are still queued in the old one.
+// The size limit counts both backends; it is not atomic across them, so the limit can be
+// exceeded by in-flight joins during a migration.
+func (b *MigratingQueueBackend) Join(ctx context.Context, params JoinQueueParams) (*JoinQueueResult, error) {
+	existing, err := b.from.Position(ctx, params.EventID, params.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.IsInQueue {
+		return &JoinQueueResult{
+			Success:      false,
+			ErrorCode:    "ALREADY_IN_QUEUE",
+			ErrorMessage: fmt.Sprintf("User is already in queue at position %d", existing.Position),
+		}, nil
+	}
+
+	draining, err := b.from.Size(ctx, params.EventID)
+	if err != nil {
+		return nil, err
+	}
+	if params.MaxQueueSize > 0 {
+		if draining >= params.MaxQueueSize {
+			return &JoinQueueResult{
+				Success:      false,
+				ErrorCode:    "QUEUE_FULL",
+				ErrorMessage: fmt.Sprintf("Queue has reached maximum capacity of %d", params.MaxQueueSize),
+			}, nil
+		}
+		params.MaxQueueSize -= draining
+	}
+
+	result, err := b.to.Join(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if result.Success {
+		result.Position += draining
+		result.TotalInQueue += draining
+	}
+	return result, nil
+}
+
+// Position returns the user's position across both backends
+func (b *MigratingQueueBackend) Position(ctx context.Context, eventID, userID string) (*QueuePositionResult, error) {
+	old, err := b.from.Position(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	newSize, err := b.to.Size(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if old.IsInQueue {
+		old.TotalInQueue += newSize
+		return old, nil
+	}
+
+	draining, err := b.from.Size(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	result, err := b.to.Position(ctx, eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if result.IsInQueue {
+		result.Position += draining
+		result.TotalInQueue += draining
+	}
+	return result, nil
+}
+
+// Remove removes users from both backends
+func (b *MigratingQueueBackend) Remove(ctx context.Context, eventID string, userIDs ...string) (int64, error) {
+	removedOld, err := b.from.Remove(ctx, eventID, userIDs...)
+	if err != nil {
+		return 0, err
+	}
+	removedNew, err := b.to.Remove(ctx, eventID, userIDs...)
+	if err != nil {
+		return removedOld, err
+	}
+	return removedOld + removedNew, nil
+}
+
+// Size returns the combined queue size
+func (b *MigratingQueueBackend) Size(ctx context.Context, eventID string) (int64, error) {
+	draining, err := b.from.Size(ctx, eventID)
+	if err != nil {
+		return 0, err
+	}
+	size, err := b.to.Size(ctx, eventID)
+	if err != nil {
+		return 0, err
+	}
+	return draining + size, nil
+}
+
+// Pop releases users from the old backend first, then from the new one
+func (b *MigratingQueueBackend) Pop(ctx context.Context, eventID string, count int64) ([]string, error) {
+	users, err := b.from.Pop(ctx, eventID, count)
+	if err != nil {
+		return nil, err
+	}
+	if len(users) > 0 {
+		// A user copied by MigrateQueues but not yet removed from the old backend must not
+		// be released twice
+		if _, err := b.to.Remove(ctx, eventID, users...); err != nil {
+			return nil, err
+		}
+	}
+
+	remaining := count - int64(len(users))
+	if remaining <= 0 {
+		return users, nil
+	}
+	more, err := b.to.Pop(ctx, eventID, remaining)
+	if err != nil {
+		return nil, err
+	}
+	return append(users, more...), nil
+}
+
+// EventIDs returns events with a queue in either backend
+func (b *MigratingQueueBackend) EventIDs(ctx context.Context) ([]string, error) {
+	oldIDs, err := b.from.EventIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	newIDs, err := b.to.EventIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(oldIDs)+len(newIDs))
+	eventIDs := make([]string, 0, len(oldIDs)+len(newIDs))
+	for _, id := range append(oldIDs, newIDs...) {
+		if !seen[id] {
+			seen[id] = true
+			eventIDs = append(eventIDs, id)
+		}
+	}
+	return eventIDs, nil
+}
+
+// Entries returns users from the old backend followed by the new one
+func (b *MigratingQueueBackend) Entries(ctx context.Context, eventID string) ([]QueueEntry, error) {
+	oldEntries, err := b.from.Entries(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	newEntries, err := b.to.Entries(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(oldEntries))
+	for _, e := range oldEntries {
+		seen[e.UserID] = true
+	}
+	for _, e := range newEntries {
+		if !seen[e.UserID] {
+			oldEntries = append(oldEntries, e)
+		}
+	}
+	return oldEntries, nil
+}
+
+// Import imports into the new backend
+func (b *MigratingQueueBackend) Import(ctx context.Context, eventID string, entries []QueueEntry) (int64, error) {
+	return b.to.Import(ctx, eventID, entries)
+}
+
+// Ensure MigratingQueueBackend implements QueueBackend
+var _ QueueBackend = (*MigratingQueueBackend)(nil)
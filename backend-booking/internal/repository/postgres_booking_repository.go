@@ -4,27 +4,36 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.uber.org/zap"
 )
 
 // PostgresBookingRepository implements BookingRepository using PostgreSQL with pgxpool
 type PostgresBookingRepository struct {
 	pool *pgxpool.Pool
+	log  *logger.Logger // Debug logs of state changes that did not apply (module booking.repository)
 }
 
 // NewPostgresBookingRepository creates a new PostgresBookingRepository
 func NewPostgresBookingRepository(pool *pgxpool.Pool) *PostgresBookingRepository {
-	return &PostgresBookingRepository{pool: pool}
+	return &PostgresBookingRepository{pool: pool, log: logger.Module("booking.repository")}
 }
 
-// Create creates a new booking record in the database
+// Create creates a new booking record in the database.
+// The insert is atomic with respect to the idempotency key: if a retry of the same
+// request already stored a booking with the key, booking is replaced with the stored
+// row and domain.ErrBookingAlreadyExists is returned. A key stored by another user,
+// tenant or request returns domain.ErrIdempotencyKeyReused.
 func (r *PostgresBookingRepository) Create(ctx context.Context, booking *domain.Booking) error {
 	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.booking.create")
 	defer span.End()
@@ -39,15 +48,18 @@ func (r *PostgresBookingRepository) Create(ctx context.Context, booking *domain.
 		INSERT INTO bookings (
 			id, tenant_id, user_id, event_id, show_id, zone_id,
 			quantity, unit_price, total_amount, currency, status,
-			idempotency_key, reserved_at, reservation_expires_at, created_at, updated_at
+			idempotency_key, reserved_at, reservation_expires_at, created_at, updated_at,
+			seat_labels, add_ons
 		) VALUES (
 			$1, $2, $3, $4, $5, $6,
 			$7, $8, $9, $10, $11,
-			$12, $13, $14, $15, $16
+			$12, $13, $14, $15, $16,
+			$17, $18
 		)
+		ON CONFLICT (idempotency_key) DO NOTHING
 	`
 
-	_, err := r.pool.Exec(ctx, query,
+	tag, err := r.pool.Exec(ctx, query,
 		booking.ID,
 		nullString(booking.TenantID),
 		booking.UserID,
@@ -64,6 +76,8 @@ func (r *PostgresBookingRepository) Create(ctx context.Context, booking *domain.
 		booking.ExpiresAt,
 		booking.CreatedAt,
 		booking.UpdatedAt,
+		booking.SeatLabels,
+		nullAddOns(booking.AddOns),
 	)
 
 	if err != nil {
@@ -72,10 +86,128 @@ func (r *PostgresBookingRepository) Create(ctx context.Context, booking *domain.
 		return fmt.Errorf("failed to create booking: %w", err)
 	}
 
+	// The UNIQUE constraint on idempotency_key makes concurrent retries race on the
+	// insert instead of the lookup; NULL keys never conflict
+	if tag.RowsAffected() == 0 {
+		existing, err := r.GetByIdempotencyKey(ctx, booking.IdempotencyKey)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return err
+		}
+		if existing == nil {
+			// The conflicting row was erased between the insert and the lookup
+			span.SetStatus(codes.Error, "idempotency key conflict")
+			return errors.New("failed to create booking: idempotency key conflict")
+		}
+		span.SetAttributes(attribute.String("existing_booking_id", existing.ID))
+		if !existing.SameRequest(booking) {
+			span.SetStatus(codes.Error, "idempotency key reused")
+			return domain.ErrIdempotencyKeyReused
+		}
+		*booking = *existing
+		r.log.DebugContext(ctx, "Booking insert replayed by idempotency key",
+			zap.String("booking_id", existing.ID),
+			zap.String("status", existing.Status.String()),
+		)
+		span.SetStatus(codes.Ok, "idempotent replay")
+		return domain.ErrBookingAlreadyExists
+	}
+
 	span.SetStatus(codes.Ok, "")
 	return nil
 }
 
+// bookingInsertColumns is the number of columns CreateMany inserts per booking
+const bookingInsertColumns = 18
+
+// CreateMany inserts the bookings with one multi-row statement and returns the IDs it
+// inserted. Bookings whose idempotency key is already stored, or repeated in the batch,
+// are skipped; Create reports them with the stored booking.
+func (r *PostgresBookingRepository) CreateMany(ctx context.Context, bookings []*domain.Booking) (map[string]bool, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.booking.create_many")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("requested", len(bookings)))
+	if len(bookings) == 0 {
+		return map[string]bool{}, nil
+	}
+
+	var query strings.Builder
+	query.WriteString(`
+		INSERT INTO bookings (
+			id, tenant_id, user_id, event_id, show_id, zone_id,
+			quantity, unit_price, total_amount, currency, status,
+			idempotency_key, reserved_at, reservation_expires_at, created_at, updated_at,
+			seat_labels, add_ons
+		) VALUES `)
+	args := make([]interface{}, 0, len(bookings)*bookingInsertColumns)
+	for i, booking := range bookings {
+		if i > 0 {
+			query.WriteString(", ")
+		}
+		query.WriteString("(")
+		for col := 1; col <= bookingInsertColumns; col++ {
+			if col > 1 {
+				query.WriteString(", ")
+			}
+			fmt.Fprintf(&query, "$%d", i*bookingInsertColumns+col)
+		}
+		query.WriteString(")")
+		args = append(args,
+			booking.ID,
+			nullString(booking.TenantID),
+			booking.UserID,
+			booking.EventID,
+			nullString(booking.ShowID),
+			booking.ZoneID,
+			booking.Quantity,
+			booking.UnitPrice,
+			booking.TotalPrice,
+			booking.Currency,
+			booking.Status.String(),
+			nullString(booking.IdempotencyKey),
+			booking.ReservedAt,
+			booking.ExpiresAt,
+			booking.CreatedAt,
+			booking.UpdatedAt,
+			booking.SeatLabels,
+			nullAddOns(booking.AddOns),
+		)
+	}
+	query.WriteString(`
+		ON CONFLICT (idempotency_key) DO NOTHING
+		RETURNING id::text`)
+
+	rows, err := r.pool.Query(ctx, query.String(), args...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to create bookings: %w", err)
+	}
+	defer rows.Close()
+
+	inserted := make(map[string]bool, len(bookings))
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, fmt.Errorf("failed to scan created booking: %w", err)
+		}
+		inserted[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to create bookings: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("inserted", len(inserted)))
+	span.SetStatus(codes.Ok, "")
+	return inserted, nil
+}
+
 // GetByID retrieves a booking by its ID
 func (r *PostgresBookingRepository) GetByID(ctx context.Context, id string) (*domain.Booking, error) {
 	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.booking.get_by_id")
@@ -89,7 +221,7 @@ func (r *PostgresBookingRepository) GetByID(ctx context.Context, id string) (*do
 			quantity, unit_price, total_amount, currency, status,
 			idempotency_key, reserved_at, reservation_expires_at,
 			confirmed_at, confirmation_code, payment_id,
-			cancelled_at, created_at, updated_at
+			cancelled_at, created_at, updated_at, seat_labels, add_ons
 		FROM bookings
 		WHERE id = $1
 	`
@@ -129,6 +261,8 @@ func (r *PostgresBookingRepository) GetByID(ctx context.Context, id string) (*do
 		&cancelledAt,
 		&booking.CreatedAt,
 		&booking.UpdatedAt,
+		&booking.SeatLabels,
+		&booking.AddOns,
 	)
 
 	if err != nil {
@@ -174,31 +308,39 @@ func (r *PostgresBookingRepository) GetByID(ctx context.Context, id string) (*do
 	return booking, nil
 }
 
-// GetByUserID retrieves all bookings for a user
-func (r *PostgresBookingRepository) GetByUserID(ctx context.Context, userID string, limit, offset int) ([]*domain.Booking, error) {
+// GetByUserID retrieves a page of a user's bookings, newest first
+func (r *PostgresBookingRepository) GetByUserID(ctx context.Context, userID string, page pagination.Params) ([]*domain.Booking, error) {
 	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.booking.get_by_user_id")
 	defer span.End()
 
 	span.SetAttributes(
 		attribute.String("user_id", userID),
-		attribute.Int("limit", limit),
-		attribute.Int("offset", offset),
+		attribute.Int("limit", page.Limit),
+		attribute.Int("offset", page.Offset),
+		attribute.Bool("cursor", page.After != nil),
 	)
 
-	query := `
+	where := "user_id = $1"
+	args := []interface{}{userID, page.Limit, page.Offset}
+	if page.After != nil {
+		where += " AND " + pagination.Keyset("created_at", "id", 4)
+		args = append(args, page.After.Time, page.After.ID)
+	}
+
+	query := fmt.Sprintf(`
 		SELECT
 			id, tenant_id, user_id, event_id, show_id, zone_id,
 			quantity, unit_price, total_amount, currency, status,
 			idempotency_key, reserved_at, reservation_expires_at,
 			confirmed_at, confirmation_code, payment_id,
-			cancelled_at, created_at, updated_at
+			cancelled_at, created_at, updated_at, seat_labels, add_ons
 		FROM bookings
-		WHERE user_id = $1
-		ORDER BY created_at DESC
+		WHERE %s
+		ORDER BY created_at DESC, id DESC
 		LIMIT $2 OFFSET $3
-	`
+	`, where)
 
-	rows, err := r.pool.Query(ctx, query, userID, limit, offset)
+	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
@@ -244,7 +386,8 @@ func (r *PostgresBookingRepository) Update(ctx context.Context, booking *domain.
 			confirmed_at = $6,
 			payment_id = $7,
 			cancelled_at = $8,
-			updated_at = $9
+			updated_at = $9,
+			zone_id = $10
 		WHERE id = $1
 	`
 
@@ -258,6 +401,7 @@ func (r *PostgresBookingRepository) Update(ctx context.Context, booking *domain.
 		nullString(booking.PaymentID),
 		booking.CancelledAt,
 		time.Now(),
+		booking.ZoneID,
 	)
 
 	if err != nil {
@@ -300,6 +444,7 @@ func (r *PostgresBookingRepository) UpdateStatus(ctx context.Context, id string,
 	}
 
 	if result.RowsAffected() == 0 {
+		r.log.DebugContext(ctx, "Booking status not updated: not found", zap.String("booking_id", id), zap.String("status", status.String()))
 		span.SetStatus(codes.Error, "not found")
 		return domain.ErrBookingNotFound
 	}
@@ -349,7 +494,7 @@ func (r *PostgresBookingRepository) Confirm(ctx context.Context, id, paymentID s
 			payment_id = $3,
 			confirmed_at = $4,
 			updated_at = $5
-		WHERE id = $1 AND status = 'reserved'
+		WHERE id = $1 AND status IN ('reserved', 'pending_payment')
 	`
 
 	now := time.Now()
@@ -369,6 +514,7 @@ func (r *PostgresBookingRepository) Confirm(ctx context.Context, id, paymentID s
 			span.SetStatus(codes.Error, err.Error())
 			return fmt.Errorf("failed to check booking existence: %w", err)
 		}
+		r.log.DebugContext(ctx, "Booking not confirmed: not reserved", zap.String("booking_id", id), zap.Bool("exists", exists))
 		if !exists {
 			span.SetStatus(codes.Error, "not found")
 			return domain.ErrBookingNotFound
@@ -393,7 +539,7 @@ func (r *PostgresBookingRepository) Cancel(ctx context.Context, id string) error
 			status = $2,
 			cancelled_at = $3,
 			updated_at = $4
-		WHERE id = $1 AND status = 'reserved'
+		WHERE id = $1 AND status IN ('reserved', 'pending_payment')
 	`
 
 	now := time.Now()
@@ -417,6 +563,7 @@ func (r *PostgresBookingRepository) Cancel(ctx context.Context, id string) error
 			span.SetStatus(codes.Error, err.Error())
 			return fmt.Errorf("failed to check booking status: %w", err)
 		}
+		r.log.DebugContext(ctx, "Booking not cancelled: not reserved", zap.String("booking_id", id), zap.String("status", status))
 		if status == "confirmed" {
 			span.SetStatus(codes.Error, "already confirmed")
 			return domain.ErrAlreadyConfirmed
@@ -446,9 +593,9 @@ func (r *PostgresBookingRepository) GetExpiredReservations(ctx context.Context,
 			quantity, unit_price, total_amount, currency, status,
 			idempotency_key, reserved_at, reservation_expires_at,
 			confirmed_at, confirmation_code, payment_id,
-			cancelled_at, created_at, updated_at
+			cancelled_at, created_at, updated_at, seat_labels, add_ons
 		FROM bookings
-		WHERE status = 'reserved'
+		WHERE status IN ('reserved', 'pending_payment')
 			AND reservation_expires_at IS NOT NULL
 			AND reservation_expires_at < $1
 		LIMIT $2
@@ -484,6 +631,62 @@ func (r *PostgresBookingRepository) GetExpiredReservations(ctx context.Context,
 	return bookings, nil
 }
 
+// GetIdleReservations gets up to limit reserved bookings with no payment, reserved after
+// reservedAfter and at or before reservedBefore, the oldest first. Holds already past
+// their expiry are left to the expiry worker.
+func (r *PostgresBookingRepository) GetIdleReservations(ctx context.Context, reservedAfter, reservedBefore time.Time, limit int) ([]*domain.Booking, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.booking.get_idle")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("limit", limit))
+
+	query := `
+		SELECT
+			id, tenant_id, user_id, event_id, show_id, zone_id,
+			quantity, unit_price, total_amount, currency, status,
+			idempotency_key, reserved_at, reservation_expires_at,
+			confirmed_at, confirmation_code, payment_id,
+			cancelled_at, created_at, updated_at, seat_labels, add_ons
+		FROM bookings
+		WHERE status = 'reserved'
+			AND payment_id IS NULL
+			AND reserved_at > $1
+			AND reserved_at <= $2
+			AND reservation_expires_at > $3
+		ORDER BY reserved_at
+		LIMIT $4
+	`
+
+	rows, err := r.pool.Query(ctx, query, reservedAfter, reservedBefore, time.Now(), limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to get idle reservations: %w", err)
+	}
+	defer rows.Close()
+
+	var bookings []*domain.Booking
+	for rows.Next() {
+		booking, err := scanBooking(rows)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		bookings = append(bookings, booking)
+	}
+
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("error iterating bookings: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("count", len(bookings)))
+	span.SetStatus(codes.Ok, "")
+	return bookings, nil
+}
+
 // MarkAsExpired marks a booking as expired
 func (r *PostgresBookingRepository) MarkAsExpired(ctx context.Context, id string) error {
 	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.booking.mark_expired")
@@ -496,7 +699,7 @@ func (r *PostgresBookingRepository) MarkAsExpired(ctx context.Context, id string
 			status = $2,
 			status_reason = $3,
 			updated_at = $4
-		WHERE id = $1 AND status = 'reserved'
+		WHERE id = $1 AND status IN ('reserved', 'pending_payment')
 	`
 
 	result, err := r.pool.Exec(ctx, query,
@@ -511,6 +714,67 @@ func (r *PostgresBookingRepository) MarkAsExpired(ctx context.Context, id string
 		return fmt.Errorf("failed to mark booking as expired: %w", err)
 	}
 
+	if result.RowsAffected() == 0 {
+		r.log.DebugContext(ctx, "Booking not expired: not reserved", zap.String("booking_id", id))
+		span.SetStatus(codes.Error, "not found")
+		return domain.ErrBookingNotFound
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// ExtendReservation pushes back the expiry of a reserved booking to expiresAt
+func (r *PostgresBookingRepository) ExtendReservation(ctx context.Context, id string, expiresAt time.Time) error {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.booking.extend_reservation")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("booking_id", id))
+
+	query := `
+		UPDATE bookings SET
+			reservation_expires_at = $2,
+			updated_at = $3
+		WHERE id = $1 AND status IN ('reserved', 'pending_payment')
+			AND (reservation_expires_at IS NULL OR reservation_expires_at < $2)
+	`
+
+	if _, err := r.pool.Exec(ctx, query, id, expiresAt, time.Now()); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to extend reservation: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// AwaitPayment hands a held booking off to a payment completed at the gateway
+func (r *PostgresBookingRepository) AwaitPayment(ctx context.Context, id, paymentID string, expiresAt time.Time) error {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.booking.await_payment")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("booking_id", id),
+		attribute.String("payment_id", paymentID),
+	)
+
+	query := `
+		UPDATE bookings SET
+			status = $2,
+			payment_id = $3,
+			reservation_expires_at = GREATEST(reservation_expires_at, $4),
+			updated_at = $5
+		WHERE id = $1 AND status IN ('reserved', 'pending_payment')
+	`
+
+	result, err := r.pool.Exec(ctx, query, id, domain.BookingStatusPendingPayment.String(), paymentID, expiresAt, time.Now())
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to mark booking pending payment: %w", err)
+	}
+
 	if result.RowsAffected() == 0 {
 		span.SetStatus(codes.Error, "not found")
 		return domain.ErrBookingNotFound
@@ -533,7 +797,7 @@ func (r *PostgresBookingRepository) GetByIdempotencyKey(ctx context.Context, key
 			quantity, unit_price, total_amount, currency, status,
 			idempotency_key, reserved_at, reservation_expires_at,
 			confirmed_at, confirmation_code, payment_id,
-			cancelled_at, created_at, updated_at
+			cancelled_at, created_at, updated_at, seat_labels, add_ons
 		FROM bookings
 		WHERE idempotency_key = $1
 	`
@@ -573,6 +837,8 @@ func (r *PostgresBookingRepository) GetByIdempotencyKey(ctx context.Context, key
 		&cancelledAt,
 		&booking.CreatedAt,
 		&booking.UpdatedAt,
+		&booking.SeatLabels,
+		&booking.AddOns,
 	)
 
 	if err != nil {
@@ -620,6 +886,106 @@ func (r *PostgresBookingRepository) GetByIdempotencyKey(ctx context.Context, key
 	return booking, nil
 }
 
+// GetByIDs retrieves the bookings with the given IDs; unknown IDs are skipped
+func (r *PostgresBookingRepository) GetByIDs(ctx context.Context, ids []string) ([]*domain.Booking, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.booking.get_by_ids")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("requested", len(ids)))
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT
+			id, tenant_id, user_id, event_id, show_id, zone_id,
+			quantity, unit_price, total_amount, currency, status,
+			idempotency_key, reserved_at, reservation_expires_at,
+			confirmed_at, confirmation_code, payment_id,
+			cancelled_at, created_at, updated_at, seat_labels, add_ons
+		FROM bookings
+		WHERE id = ANY($1::uuid[])
+	`
+
+	rows, err := r.pool.Query(ctx, query, ids)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to get bookings by IDs: %w", err)
+	}
+	defer rows.Close()
+
+	bookings := make([]*domain.Booking, 0, len(ids))
+	for rows.Next() {
+		booking, err := scanBooking(rows)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		bookings = append(bookings, booking)
+	}
+
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("error iterating bookings: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("count", len(bookings)))
+	span.SetStatus(codes.Ok, "")
+	return bookings, nil
+}
+
+// CancelReserved cancels the given bookings that are still pending or reserved in one statement
+func (r *PostgresBookingRepository) CancelReserved(ctx context.Context, ids []string) ([]string, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.booking.cancel_reserved")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("requested", len(ids)))
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		UPDATE bookings SET
+			status = $2,
+			cancelled_at = $3,
+			updated_at = $3
+		WHERE id = ANY($1::uuid[]) AND status IN ('pending', 'reserved', 'pending_payment')
+		RETURNING id
+	`
+
+	rows, err := r.pool.Query(ctx, query, ids, domain.BookingStatusCancelled.String(), time.Now())
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to cancel bookings: %w", err)
+	}
+	defer rows.Close()
+
+	cancelled := make([]string, 0, len(ids))
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, fmt.Errorf("failed to scan cancelled booking: %w", err)
+		}
+		cancelled = append(cancelled, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("error iterating cancelled bookings: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("cancelled", len(cancelled)))
+	span.SetStatus(codes.Ok, "")
+	return cancelled, nil
+}
+
 // CountByUserAndEvent counts bookings for a user on an event
 func (r *PostgresBookingRepository) CountByUserAndEvent(ctx context.Context, userID, eventID string) (int, error) {
 	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.booking.count_by_user_event")
@@ -632,7 +998,7 @@ func (r *PostgresBookingRepository) CountByUserAndEvent(ctx context.Context, use
 
 	query := `
 		SELECT COUNT(*) FROM bookings
-		WHERE user_id = $1 AND event_id = $2 AND status IN ('reserved', 'confirmed')
+		WHERE user_id = $1 AND event_id = $2 AND status IN ('reserved', 'pending_payment', 'confirmed')
 	`
 
 	var count int
@@ -685,6 +1051,8 @@ func scanBooking(rows pgx.Rows) (*domain.Booking, error) {
 		&cancelledAt,
 		&booking.CreatedAt,
 		&booking.UpdatedAt,
+		&booking.SeatLabels,
+		&booking.AddOns,
 	)
 
 	if err != nil {
@@ -731,6 +1099,14 @@ func nullString(s string) *string {
 	return &s
 }
 
+// nullAddOns stores a booking without add-ons as NULL
+func nullAddOns(addOns []domain.BookingAddOn) interface{} {
+	if len(addOns) == 0 {
+		return nil
+	}
+	return addOns
+}
+
 // GetTenantIDByShowID retrieves tenant_id from shows table via events
 func (r *PostgresBookingRepository) GetTenantIDByShowID(ctx context.Context, showID string) (string, error) {
 	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.booking.get_tenant_by_show")
@@ -762,5 +1138,30 @@ func (r *PostgresBookingRepository) GetTenantIDByShowID(ctx context.Context, sho
 	return tenantID, nil
 }
 
+// GetShowStartTime retrieves when a show starts from its date and start time
+func (r *PostgresBookingRepository) GetShowStartTime(ctx context.Context, showID string) (time.Time, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.booking.get_show_start_time")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("show_id", showID))
+
+	query := `SELECT s.show_date + s.start_time FROM shows s WHERE s.id = $1`
+
+	var startsAt time.Time
+	err := r.pool.QueryRow(ctx, query, showID).Scan(&startsAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			span.SetStatus(codes.Error, "show not found")
+			return time.Time{}, domain.ErrShowNotFound
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return time.Time{}, fmt.Errorf("failed to get show start time: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return startsAt, nil
+}
+
 // Ensure PostgresBookingRepository implements BookingRepository
 var _ BookingRepository = (*PostgresBookingRepository)(nil)
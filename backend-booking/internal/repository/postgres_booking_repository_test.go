@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"testing"
@@ -10,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 )
 
 // getPostgresPool creates a PostgreSQL connection pool for testing
@@ -146,6 +148,71 @@ func TestPostgresBookingRepository_Create(t *testing.T) {
 	_ = repo.Delete(ctx, booking.ID)
 }
 
+func TestPostgresBookingRepository_Create_IdempotencyKey(t *testing.T) {
+	skipIfNoIntegration(t)
+
+	pool := getPostgresPool(t)
+	defer pool.Close()
+
+	repo := NewPostgresBookingRepository(pool)
+	ctx := context.Background()
+
+	t.Skip("Skipping: requires existing tenant, user, event, show, zone records")
+
+	first := createTestBooking("test-tenant-id", "test-user-id", "test-event-id", "test-show-id", "test-zone-id")
+	first.IdempotencyKey = "test-replay-" + first.ID
+	if err := repo.Create(ctx, first); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	defer func() { _ = repo.Delete(ctx, first.ID) }()
+
+	// A retry of the same request is answered with the stored booking
+	retry := createTestBooking("test-tenant-id", "test-user-id", "test-event-id", "test-show-id", "test-zone-id")
+	retry.IdempotencyKey = first.IdempotencyKey
+	if err := repo.Create(ctx, retry); !errors.Is(err, domain.ErrBookingAlreadyExists) || retry.ID != first.ID {
+		t.Errorf("Create() retry = %s, %v, want %s, %v", retry.ID, err, first.ID, domain.ErrBookingAlreadyExists)
+	}
+
+	// Another user's request with the same key gets nothing of the stored booking
+	other := createTestBooking("test-tenant-id", "other-user-id", "test-event-id", "test-show-id", "test-zone-id")
+	other.IdempotencyKey = first.IdempotencyKey
+	otherID := other.ID
+	if err := repo.Create(ctx, other); !errors.Is(err, domain.ErrIdempotencyKeyReused) || other.ID != otherID {
+		t.Errorf("Create() by another user = %s, %v, want %s, %v", other.ID, err, otherID, domain.ErrIdempotencyKeyReused)
+	}
+}
+
+func TestPostgresBookingRepository_CreateMany(t *testing.T) {
+	skipIfNoIntegration(t)
+
+	pool := getPostgresPool(t)
+	defer pool.Close()
+
+	repo := NewPostgresBookingRepository(pool)
+	ctx := context.Background()
+
+	t.Skip("Skipping: requires existing tenant, user, event, show, zone records")
+
+	first := createTestBooking("test-tenant-id", "test-user-id", "test-event-id", "test-show-id", "test-zone-id")
+	first.IdempotencyKey = "test-batch-" + first.ID
+	retry := createTestBooking("test-tenant-id", "test-user-id", "test-event-id", "test-show-id", "test-zone-id")
+	retry.IdempotencyKey = first.IdempotencyKey
+	other := createTestBooking("test-tenant-id", "test-user-id", "test-event-id", "test-show-id", "test-zone-id")
+
+	inserted, err := repo.CreateMany(ctx, []*domain.Booking{first, retry, other})
+	if err != nil {
+		t.Fatalf("CreateMany() error = %v", err)
+	}
+	// The retry repeats the first booking's idempotency key and is skipped
+	if len(inserted) != 2 || !inserted[first.ID] || !inserted[other.ID] {
+		t.Errorf("CreateMany() inserted %v, want %s and %s", inserted, first.ID, other.ID)
+	}
+
+	// Cleanup
+	_ = repo.Delete(ctx, first.ID)
+	_ = repo.Delete(ctx, other.ID)
+}
+
 func TestPostgresBookingRepository_GetByID_NotFound(t *testing.T) {
 	skipIfNoIntegration(t)
 
@@ -289,7 +356,7 @@ func TestPostgresBookingRepository_GetByUserID(t *testing.T) {
 	// This test checks that the query works, even if it returns empty
 	userID := uuid.New().String()
 
-	bookings, err := repo.GetByUserID(ctx, userID, 10, 0)
+	bookings, err := repo.GetByUserID(ctx, userID, pagination.Params{Limit: 10})
 	if err != nil {
 		t.Fatalf("GetByUserID() error = %v", err)
 	}
@@ -339,6 +406,30 @@ func TestPostgresBookingRepository_GetExpiredReservations(t *testing.T) {
 	t.Logf("Found %d expired reservations", len(bookings))
 }
 
+func TestPostgresBookingRepository_GetIdleReservations(t *testing.T) {
+	skipIfNoIntegration(t)
+
+	pool := getPostgresPool(t)
+	defer pool.Close()
+
+	repo := NewPostgresBookingRepository(pool)
+	ctx := context.Background()
+
+	now := time.Now()
+	bookings, err := repo.GetIdleReservations(ctx, now.Add(-time.Hour), now.Add(-5*time.Minute), 100)
+	if err != nil {
+		t.Fatalf("GetIdleReservations() error = %v", err)
+	}
+	for i, booking := range bookings {
+		if booking.Status != domain.BookingStatusReserved || booking.PaymentID != "" {
+			t.Errorf("GetIdleReservations() returned booking %s (%s, payment %q)", booking.ID, booking.Status, booking.PaymentID)
+		}
+		if i > 0 && booking.ReservedAt.Before(bookings[i-1].ReservedAt) {
+			t.Errorf("GetIdleReservations() is not ordered by reserved_at")
+		}
+	}
+}
+
 func TestPostgresBookingRepository_MarkAsExpired(t *testing.T) {
 	skipIfNoIntegration(t)
 
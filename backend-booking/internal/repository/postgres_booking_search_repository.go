@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// PostgresBookingSearchRepository implements BookingSearchRepository using PostgreSQL
+type PostgresBookingSearchRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresBookingSearchRepository creates a new PostgresBookingSearchRepository
+func NewPostgresBookingSearchRepository(pool *pgxpool.Pool) *PostgresBookingSearchRepository {
+	return &PostgresBookingSearchRepository{pool: pool}
+}
+
+var _ BookingSearchRepository = (*PostgresBookingSearchRepository)(nil)
+
+// Search returns the most recent bookings matching the filter
+func (r *PostgresBookingSearchRepository) Search(ctx context.Context, filter *BookingSearchFilter) ([]*domain.Booking, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.booking_search.search")
+	defer span.End()
+
+	if filter.Limit <= 0 {
+		filter.Limit = 20
+	}
+
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	if filter.TenantID != "" {
+		args = append(args, filter.TenantID)
+		conditions = append(conditions, fmt.Sprintf("tenant_id = $%d", len(args)))
+	}
+	if len(filter.UserIDs) > 0 {
+		args = append(args, filter.UserIDs)
+		conditions = append(conditions, fmt.Sprintf("user_id = ANY($%d::uuid[])", len(args)))
+	}
+	if len(filter.BookingIDs) > 0 {
+		args = append(args, filter.BookingIDs)
+		conditions = append(conditions, fmt.Sprintf("id = ANY($%d::uuid[])", len(args)))
+	}
+	if filter.ConfirmationCode != "" {
+		// Matches idx_bookings_confirmation_code_lower
+		args = append(args, strings.ToLower(filter.ConfirmationCode))
+		conditions = append(conditions, fmt.Sprintf("lower(confirmation_code) = $%d", len(args)))
+	}
+	if filter.PaymentID != "" {
+		args = append(args, filter.PaymentID)
+		conditions = append(conditions, fmt.Sprintf("payment_id = $%d", len(args)))
+	}
+
+	span.SetAttributes(
+		attribute.String("tenant_id", filter.TenantID),
+		attribute.Int("filters", len(conditions)),
+	)
+
+	query := `
+		SELECT
+			id, tenant_id, user_id, event_id, show_id, zone_id,
+			quantity, unit_price, total_amount, currency, status,
+			idempotency_key, reserved_at, reservation_expires_at,
+			confirmed_at, confirmation_code, payment_id,
+			cancelled_at, created_at, updated_at, seat_labels, add_ons
+		FROM bookings`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d", len(args))
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to search bookings: %w", err)
+	}
+	defer rows.Close()
+
+	bookings := []*domain.Booking{}
+	for rows.Next() {
+		booking, err := scanBooking(rows)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		bookings = append(bookings, booking)
+	}
+
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("error iterating bookings: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("count", len(bookings)))
+	span.SetStatus(codes.Ok, "")
+	return bookings, nil
+}
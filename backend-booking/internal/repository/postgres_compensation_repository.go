@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// PostgresCompensationRepository implements CompensationRepository using PostgreSQL
+type PostgresCompensationRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresCompensationRepository creates a new PostgresCompensationRepository
+func NewPostgresCompensationRepository(pool *pgxpool.Pool) *PostgresCompensationRepository {
+	return &PostgresCompensationRepository{pool: pool}
+}
+
+const compensationColumns = `
+	id, saga_id, booking_id, trigger_type, requested_by,
+	reason, steps, status, error, created_at, completed_at
+`
+
+// Create inserts a new compensation record
+func (r *PostgresCompensationRepository) Create(ctx context.Context, c *domain.Compensation) error {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.compensation.create")
+	defer span.End()
+
+	if c.ID == "" {
+		c.ID = uuid.New().String()
+	}
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = time.Now()
+	}
+
+	span.SetAttributes(
+		attribute.String("compensation_id", c.ID),
+		attribute.String("saga_id", c.SagaID),
+		attribute.String("booking_id", c.BookingID),
+	)
+
+	steps, err := json.Marshal(c.Steps)
+	if err != nil {
+		return fmt.Errorf("failed to marshal compensation steps: %w", err)
+	}
+
+	query := `
+		INSERT INTO saga_compensations (` + compensationColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	_, err = r.pool.Exec(ctx, query,
+		c.ID,
+		nullString(c.SagaID),
+		nullString(c.BookingID),
+		string(c.Trigger),
+		nullString(c.RequestedBy),
+		c.Reason,
+		steps,
+		c.Status.String(),
+		nullString(c.Error),
+		c.CreatedAt,
+		c.CompletedAt,
+	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to create compensation: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// GetByID retrieves a compensation record by its ID
+func (r *PostgresCompensationRepository) GetByID(ctx context.Context, id string) (*domain.Compensation, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.compensation.get_by_id")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("compensation_id", id))
+
+	query := `SELECT ` + compensationColumns + ` FROM saga_compensations WHERE id = $1`
+
+	c, err := scanCompensation(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			span.SetStatus(codes.Error, "not found")
+			return nil, domain.ErrCompensationNotFound
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to get compensation: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return c, nil
+}
+
+// List retrieves compensation records matching the filter, newest first
+func (r *PostgresCompensationRepository) List(ctx context.Context, filter *CompensationFilter) ([]*domain.Compensation, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.compensation.list")
+	defer span.End()
+
+	if filter == nil {
+		filter = &CompensationFilter{}
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 50
+	}
+
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	if filter.SagaID != "" {
+		args = append(args, filter.SagaID)
+		conditions = append(conditions, fmt.Sprintf("saga_id = $%d", len(args)))
+	}
+	if filter.BookingID != "" {
+		args = append(args, filter.BookingID)
+		conditions = append(conditions, fmt.Sprintf("booking_id = $%d", len(args)))
+	}
+
+	query := `SELECT ` + compensationColumns + ` FROM saga_compensations`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, filter.Limit, filter.Offset)
+	query += fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to list compensations: %w", err)
+	}
+	defer rows.Close()
+
+	var compensations []*domain.Compensation
+	for rows.Next() {
+		c, err := scanCompensation(rows)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, fmt.Errorf("failed to scan compensation: %w", err)
+		}
+		compensations = append(compensations, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating compensations: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("count", len(compensations)))
+	span.SetStatus(codes.Ok, "")
+	return compensations, nil
+}
+
+// scanCompensation scans a single row into a Compensation
+func scanCompensation(row pgx.Row) (*domain.Compensation, error) {
+	c := &domain.Compensation{}
+	var (
+		sagaID      *string
+		bookingID   *string
+		trigger     string
+		requestedBy *string
+		steps       []byte
+		status      string
+		errMsg      *string
+	)
+
+	if err := row.Scan(
+		&c.ID,
+		&sagaID,
+		&bookingID,
+		&trigger,
+		&requestedBy,
+		&c.Reason,
+		&steps,
+		&status,
+		&errMsg,
+		&c.CreatedAt,
+		&c.CompletedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if sagaID != nil {
+		c.SagaID = *sagaID
+	}
+	if bookingID != nil {
+		c.BookingID = *bookingID
+	}
+	if requestedBy != nil {
+		c.RequestedBy = *requestedBy
+	}
+	if errMsg != nil {
+		c.Error = *errMsg
+	}
+	c.Trigger = domain.CompensationTrigger(trigger)
+	c.Status = domain.CompensationStatus(status)
+
+	c.Steps = []domain.CompensationStep{}
+	if len(steps) > 0 {
+		if err := json.Unmarshal(steps, &c.Steps); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal compensation steps: %w", err)
+		}
+	}
+
+	return c, nil
+}
+
+// Ensure PostgresCompensationRepository implements CompensationRepository
+var _ CompensationRepository = (*PostgresCompensationRepository)(nil)
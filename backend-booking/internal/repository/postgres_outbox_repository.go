@@ -6,10 +6,10 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/id"
 )
 
 // PostgresOutboxRepository implements OutboxRepository using PostgreSQL
@@ -25,7 +25,7 @@ func NewPostgresOutboxRepository(pool *pgxpool.Pool) *PostgresOutboxRepository {
 // Create creates a new outbox message
 func (r *PostgresOutboxRepository) Create(ctx context.Context, msg *domain.OutboxMessage) error {
 	if msg.ID == "" {
-		msg.ID = uuid.New().String()
+		msg.ID = id.New()
 	}
 
 	query := `
@@ -62,7 +62,7 @@ func (r *PostgresOutboxRepository) Create(ctx context.Context, msg *domain.Outbo
 // CreateTx creates a new outbox message within a transaction
 func (r *PostgresOutboxRepository) CreateTx(ctx context.Context, tx pgx.Tx, msg *domain.OutboxMessage) error {
 	if msg.ID == "" {
-		msg.ID = uuid.New().String()
+		msg.ID = id.New()
 	}
 
 	query := `
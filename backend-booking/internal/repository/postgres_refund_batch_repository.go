@@ -0,0 +1,382 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// PostgresRefundBatchRepository implements RefundBatchRepository using PostgreSQL
+type PostgresRefundBatchRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresRefundBatchRepository creates a new PostgresRefundBatchRepository
+func NewPostgresRefundBatchRepository(pool *pgxpool.Pool) *PostgresRefundBatchRepository {
+	return &PostgresRefundBatchRepository{pool: pool}
+}
+
+const refundBatchItemColumns = `
+	batch_id, booking_id, tenant_id, user_id, payment_id, amount, currency, status, attempts,
+	error, requested_at, refunded_at, updated_at
+`
+
+// CreateForShow creates or reuses the show's batch and adds its confirmed bookings
+func (r *PostgresRefundBatchRepository) CreateForShow(ctx context.Context, batch *domain.RefundBatch) (*domain.RefundBatch, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.refund_batch.create_for_show")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("show_id", batch.ShowID))
+
+	if _, err := uuid.Parse(batch.ShowID); err != nil {
+		span.SetStatus(codes.Error, "invalid show id")
+		return nil, domain.ErrInvalidShowID
+	}
+	if batch.ID == "" {
+		batch.ID = uuid.New().String()
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// The no-op update makes RETURNING yield the existing batch of the show
+	var batchID string
+	err = tx.QueryRow(ctx, `
+		INSERT INTO refund_batches (id, show_id, reason, requested_by, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (show_id) DO UPDATE SET show_id = EXCLUDED.show_id
+		RETURNING id
+	`, batch.ID, batch.ShowID, batch.Reason, nullString(batch.RequestedBy), batch.CreatedAt).Scan(&batchID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to create refund batch: %w", err)
+	}
+
+	// Bookings without a payment have nothing to refund
+	added, err := tx.Exec(ctx, `
+		INSERT INTO refund_batch_items (batch_id, booking_id, tenant_id, user_id, payment_id, amount, currency, status)
+		SELECT $1, id, tenant_id, user_id, payment_id, total_amount, COALESCE(currency, 'THB'),
+			CASE WHEN COALESCE(payment_id, '') = '' THEN 'skipped' ELSE 'pending' END
+		FROM bookings
+		WHERE show_id = $2 AND status = 'confirmed'
+		ON CONFLICT (batch_id, booking_id) DO NOTHING
+	`, batchID, batch.ShowID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to add refund batch items: %w", err)
+	}
+
+	retried, err := tx.Exec(ctx, `
+		UPDATE refund_batch_items
+		SET status = 'pending', error = NULL, next_attempt_at = NOW(), updated_at = NOW()
+		WHERE batch_id = $1 AND status = 'failed'
+	`, batchID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to retry failed refunds: %w", err)
+	}
+
+	if err := completeRefundBatchTx(ctx, tx, batchID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	span.SetAttributes(
+		attribute.String("batch_id", batchID),
+		attribute.Int64("added", added.RowsAffected()),
+		attribute.Int64("retried", retried.RowsAffected()),
+	)
+	span.SetStatus(codes.Ok, "")
+	return r.GetByID(ctx, batchID)
+}
+
+// GetByID retrieves a batch with all its items
+func (r *PostgresRefundBatchRepository) GetByID(ctx context.Context, id string) (*domain.RefundBatch, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.refund_batch.get_by_id")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("batch_id", id))
+
+	if _, err := uuid.Parse(id); err != nil {
+		span.SetStatus(codes.Error, "not found")
+		return nil, domain.ErrRefundBatchNotFound
+	}
+
+	batch := &domain.RefundBatch{}
+	var requestedBy *string
+	err := r.pool.QueryRow(ctx, `
+		SELECT id, show_id, reason, requested_by, created_at, completed_at
+		FROM refund_batches WHERE id = $1
+	`, id).Scan(&batch.ID, &batch.ShowID, &batch.Reason, &requestedBy, &batch.CreatedAt, &batch.CompletedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			span.SetStatus(codes.Error, "not found")
+			return nil, domain.ErrRefundBatchNotFound
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to get refund batch: %w", err)
+	}
+	if requestedBy != nil {
+		batch.RequestedBy = *requestedBy
+	}
+
+	query := `SELECT ` + refundBatchItemColumns + ` FROM refund_batch_items WHERE batch_id = $1 ORDER BY created_at, booking_id`
+	batch.Items, err = r.queryItems(ctx, query, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to list refund batch items: %w", err)
+	}
+	batch.Summarize()
+
+	span.SetAttributes(
+		attribute.Int("items", len(batch.Items)),
+		attribute.String("status", batch.Status.String()),
+	)
+	span.SetStatus(codes.Ok, "")
+	return batch, nil
+}
+
+// ClaimPending returns pending items and leases them to the caller
+func (r *PostgresRefundBatchRepository) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*domain.RefundBatchItem, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.refund_batch.claim_pending")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("limit", limit))
+
+	query := `
+		UPDATE refund_batch_items
+		SET next_attempt_at = NOW() + $2 * INTERVAL '1 millisecond'
+		WHERE (batch_id, booking_id) IN (
+			SELECT batch_id, booking_id FROM refund_batch_items
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at ASC
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + refundBatchItemColumns
+
+	items, err := r.queryItems(ctx, query, limit, lease.Milliseconds())
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to claim pending refunds: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("count", len(items)))
+	span.SetStatus(codes.Ok, "")
+	return items, nil
+}
+
+// MarkRequested records that the refund command of a pending item was sent.
+// An item whose result already arrived is left alone.
+func (r *PostgresRefundBatchRepository) MarkRequested(ctx context.Context, batchID, bookingID string) error {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.refund_batch.mark_requested")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("batch_id", batchID),
+		attribute.String("booking_id", bookingID),
+	)
+
+	_, err := r.pool.Exec(ctx, `
+		UPDATE refund_batch_items
+		SET status = 'requested', attempts = attempts + 1, requested_at = NOW(), updated_at = NOW()
+		WHERE batch_id = $1 AND booking_id = $2 AND status = 'pending'
+	`, batchID, bookingID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to mark refund requested: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// RecordResult stores the refund outcome of a booking's outstanding item
+func (r *PostgresRefundBatchRepository) RecordResult(ctx context.Context, bookingID string, refunded bool, errMsg string) (bool, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.refund_batch.record_result")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("booking_id", bookingID),
+		attribute.Bool("refunded", refunded),
+	)
+
+	if _, err := uuid.Parse(bookingID); err != nil {
+		span.SetStatus(codes.Ok, "")
+		return false, nil
+	}
+
+	status := domain.RefundItemFailed
+	if refunded {
+		status = domain.RefundItemRefunded
+		errMsg = ""
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// A refund that succeeds later (e.g. a manual compensation) also settles a failed item
+	var batchID string
+	err = tx.QueryRow(ctx, `
+		UPDATE refund_batch_items
+		SET status = $2,
+			error = $3,
+			refunded_at = CASE WHEN $4 THEN NOW() ELSE refunded_at END,
+			updated_at = NOW()
+		WHERE booking_id = $1 AND (status IN ('pending', 'requested') OR ($4 AND status = 'failed'))
+		RETURNING batch_id
+	`, bookingID, status.String(), nullString(errMsg), refunded).Scan(&batchID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			span.SetStatus(codes.Ok, "")
+			return false, nil
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return false, fmt.Errorf("failed to record refund result: %w", err)
+	}
+
+	if refunded {
+		_, err = tx.Exec(ctx, `
+			UPDATE bookings
+			SET status = 'refunded', status_reason = $2, cancelled_at = NOW(), updated_at = NOW()
+			WHERE id = $1 AND status = 'confirmed'
+		`, bookingID, domain.DefaultShowCancelledReason)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return false, fmt.Errorf("failed to mark booking refunded: %w", err)
+		}
+	}
+
+	if err := completeRefundBatchTx(ctx, tx, batchID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return false, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	span.SetAttributes(attribute.String("batch_id", batchID))
+	span.SetStatus(codes.Ok, "")
+	return true, nil
+}
+
+// completeRefundBatchTx sets completed_at once no refund of the batch is outstanding,
+// and clears it again when new refunds were added
+func completeRefundBatchTx(ctx context.Context, tx pgx.Tx, batchID string) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE refund_batches
+		SET completed_at = CASE
+			WHEN EXISTS (
+				SELECT 1 FROM refund_batch_items
+				WHERE batch_id = $1 AND status IN ('pending', 'requested')
+			) THEN NULL
+			ELSE COALESCE(completed_at, NOW())
+		END
+		WHERE id = $1
+	`, batchID)
+	if err != nil {
+		return fmt.Errorf("failed to update refund batch completion: %w", err)
+	}
+	return nil
+}
+
+// queryItems runs an item query and scans all rows
+func (r *PostgresRefundBatchRepository) queryItems(ctx context.Context, query string, args ...interface{}) ([]*domain.RefundBatchItem, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*domain.RefundBatchItem
+	for rows.Next() {
+		item, err := scanRefundBatchItem(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan refund batch item: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating refund batch items: %w", err)
+	}
+	return items, nil
+}
+
+// scanRefundBatchItem scans a single row into a RefundBatchItem
+func scanRefundBatchItem(row pgx.Row) (*domain.RefundBatchItem, error) {
+	item := &domain.RefundBatchItem{}
+	var (
+		paymentID *string
+		status    string
+		errMsg    *string
+	)
+
+	if err := row.Scan(
+		&item.BatchID,
+		&item.BookingID,
+		&item.TenantID,
+		&item.UserID,
+		&paymentID,
+		&item.Amount,
+		&item.Currency,
+		&status,
+		&item.Attempts,
+		&errMsg,
+		&item.RequestedAt,
+		&item.RefundedAt,
+		&item.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	item.Status = domain.RefundItemStatus(status)
+	if paymentID != nil {
+		item.PaymentID = *paymentID
+	}
+	if errMsg != nil {
+		item.Error = *errMsg
+	}
+	return item, nil
+}
+
+// Ensure PostgresRefundBatchRepository implements RefundBatchRepository
+var _ RefundBatchRepository = (*PostgresRefundBatchRepository)(nil)
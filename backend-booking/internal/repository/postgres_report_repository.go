@@ -0,0 +1,302 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// PostgresReportRepository implements ReportScheduleRepository and SalesReportRepository using PostgreSQL
+type PostgresReportRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresReportRepository creates a new PostgresReportRepository
+func NewPostgresReportRepository(pool *pgxpool.Pool) *PostgresReportRepository {
+	return &PostgresReportRepository{pool: pool}
+}
+
+const reportScheduleColumns = `
+	tenant_id, email, locale, frequency, send_hour, weekday, event_ids::text[], unsubscribe_token,
+	unsubscribed_at, next_run_at, last_sent_at, created_at, updated_at
+`
+
+// Upsert creates or replaces the tenant's schedule
+func (r *PostgresReportRepository) Upsert(ctx context.Context, schedule *domain.ReportSchedule) (*domain.ReportSchedule, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.report_schedule.upsert")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("tenant_id", schedule.TenantID))
+
+	eventIDs := schedule.EventIDs
+	if eventIDs == nil {
+		eventIDs = []string{}
+	}
+
+	row := r.pool.QueryRow(ctx, `
+		INSERT INTO report_schedules (
+			tenant_id, email, locale, frequency, send_hour, weekday, event_ids, unsubscribe_token,
+			next_run_at, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7::uuid[], $8, $9, $10, $10)
+		ON CONFLICT (tenant_id) DO UPDATE SET
+			email = EXCLUDED.email,
+			locale = EXCLUDED.locale,
+			frequency = EXCLUDED.frequency,
+			send_hour = EXCLUDED.send_hour,
+			weekday = EXCLUDED.weekday,
+			event_ids = EXCLUDED.event_ids,
+			unsubscribed_at = NULL,
+			next_run_at = EXCLUDED.next_run_at,
+			claimed_until = NULL,
+			updated_at = EXCLUDED.updated_at
+		RETURNING `+reportScheduleColumns,
+		schedule.TenantID, schedule.Email, schedule.Locale, schedule.Frequency.String(), schedule.SendHour,
+		int(schedule.Weekday), eventIDs, schedule.UnsubscribeToken, schedule.NextRunAt, schedule.UpdatedAt,
+	)
+
+	saved, err := scanReportSchedule(row)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to save report schedule: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return saved, nil
+}
+
+// GetByTenant retrieves the tenant's schedule
+func (r *PostgresReportRepository) GetByTenant(ctx context.Context, tenantID string) (*domain.ReportSchedule, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.report_schedule.get_by_tenant")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("tenant_id", tenantID))
+
+	schedule, err := scanReportSchedule(r.pool.QueryRow(ctx,
+		`SELECT `+reportScheduleColumns+` FROM report_schedules WHERE tenant_id = $1`, tenantID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			span.SetStatus(codes.Error, "not found")
+			return nil, domain.ErrReportScheduleNotFound
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to get report schedule: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return schedule, nil
+}
+
+// Delete removes the tenant's schedule
+func (r *PostgresReportRepository) Delete(ctx context.Context, tenantID string) error {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.report_schedule.delete")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("tenant_id", tenantID))
+
+	result, err := r.pool.Exec(ctx, `DELETE FROM report_schedules WHERE tenant_id = $1`, tenantID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to delete report schedule: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		span.SetStatus(codes.Error, "not found")
+		return domain.ErrReportScheduleNotFound
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// Unsubscribe stops the reports of the schedule with the token.
+// Unsubscribing twice keeps the first unsubscribe time.
+func (r *PostgresReportRepository) Unsubscribe(ctx context.Context, token string) (*domain.ReportSchedule, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.report_schedule.unsubscribe")
+	defer span.End()
+
+	schedule, err := scanReportSchedule(r.pool.QueryRow(ctx, `
+		UPDATE report_schedules
+		SET unsubscribed_at = COALESCE(unsubscribed_at, NOW()), updated_at = NOW()
+		WHERE unsubscribe_token = $1
+		RETURNING `+reportScheduleColumns, token))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			span.SetStatus(codes.Error, "not found")
+			return nil, domain.ErrReportScheduleNotFound
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to unsubscribe report schedule: %w", err)
+	}
+
+	span.SetAttributes(attribute.String("tenant_id", schedule.TenantID))
+	span.SetStatus(codes.Ok, "")
+	return schedule, nil
+}
+
+// ClaimDue returns due schedules and hides them from other workers for lease
+func (r *PostgresReportRepository) ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*domain.ReportSchedule, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.report_schedule.claim_due")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("limit", limit))
+
+	rows, err := r.pool.Query(ctx, `
+		UPDATE report_schedules
+		SET claimed_until = $1 + $3 * INTERVAL '1 millisecond'
+		WHERE tenant_id IN (
+			SELECT tenant_id FROM report_schedules
+			WHERE unsubscribed_at IS NULL AND next_run_at <= $1
+				AND (claimed_until IS NULL OR claimed_until <= $1)
+			ORDER BY next_run_at ASC
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+reportScheduleColumns, now, limit, lease.Milliseconds())
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to claim due report schedules: %w", err)
+	}
+	defer rows.Close()
+
+	var schedules []*domain.ReportSchedule
+	for rows.Next() {
+		schedule, err := scanReportSchedule(rows)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, fmt.Errorf("failed to scan report schedule: %w", err)
+		}
+		schedules = append(schedules, schedule)
+	}
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to claim due report schedules: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("count", len(schedules)))
+	span.SetStatus(codes.Ok, "")
+	return schedules, nil
+}
+
+// MarkSent records the report of the run at runAt and schedules the next one
+func (r *PostgresReportRepository) MarkSent(ctx context.Context, tenantID string, runAt, nextRunAt time.Time) error {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.report_schedule.mark_sent")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("tenant_id", tenantID))
+
+	_, err := r.pool.Exec(ctx, `
+		UPDATE report_schedules
+		SET next_run_at = $3, last_sent_at = NOW(), claimed_until = NULL
+		WHERE tenant_id = $1 AND next_run_at = $2
+	`, tenantID, runAt, nextRunAt)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to mark report sent: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// SummarizeEvents aggregates the tenant's bookings and refunds in [from, to) per event.
+// Reservations count by creation time, sales by confirmation time and refunds by refund time.
+func (r *PostgresReportRepository) SummarizeEvents(ctx context.Context, tenantID string, eventIDs []string, from, to time.Time) ([]*domain.EventSalesSummary, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.sales_report.summarize_events")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("tenant_id", tenantID),
+		attribute.Int("event_ids", len(eventIDs)),
+	)
+
+	if eventIDs == nil {
+		eventIDs = []string{}
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		WITH sales AS (
+			SELECT event_id,
+				COUNT(*) FILTER (WHERE created_at >= $2 AND created_at < $3) AS bookings,
+				COUNT(*) FILTER (WHERE confirmed_at >= $2 AND confirmed_at < $3) AS confirmed,
+				COALESCE(SUM(quantity) FILTER (WHERE confirmed_at >= $2 AND confirmed_at < $3), 0) AS tickets,
+				COALESCE(SUM(total_amount) FILTER (WHERE confirmed_at >= $2 AND confirmed_at < $3), 0) AS revenue,
+				MAX(COALESCE(currency, 'THB')) AS currency
+			FROM bookings
+			WHERE tenant_id = $1
+				AND ((created_at >= $2 AND created_at < $3) OR (confirmed_at >= $2 AND confirmed_at < $3))
+				AND (cardinality($4::uuid[]) = 0 OR event_id = ANY($4::uuid[]))
+			GROUP BY event_id
+		), refunds AS (
+			SELECT b.event_id, COUNT(*) AS refunds, COALESCE(SUM(i.amount), 0) AS refunded,
+				MAX(i.currency) AS currency
+			FROM refund_batch_items i
+			JOIN bookings b ON b.id = i.booking_id
+			WHERE i.tenant_id = $1 AND i.status = 'refunded'
+				AND i.refunded_at >= $2 AND i.refunded_at < $3
+				AND (cardinality($4::uuid[]) = 0 OR b.event_id = ANY($4::uuid[]))
+			GROUP BY b.event_id
+		)
+		SELECT COALESCE(s.event_id, f.event_id)::text,
+			COALESCE(s.bookings, 0), COALESCE(s.confirmed, 0), COALESCE(s.tickets, 0),
+			COALESCE(s.revenue, 0)::float8, COALESCE(f.refunds, 0), COALESCE(f.refunded, 0)::float8,
+			COALESCE(s.currency, f.currency, 'THB')
+		FROM sales s
+		FULL OUTER JOIN refunds f ON f.event_id = s.event_id
+		ORDER BY COALESCE(s.revenue, 0) DESC, 1
+	`, tenantID, from, to, eventIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to summarize sales: %w", err)
+	}
+	defer rows.Close()
+
+	var summaries []*domain.EventSalesSummary
+	for rows.Next() {
+		var s domain.EventSalesSummary
+		if err := rows.Scan(&s.EventID, &s.Bookings, &s.ConfirmedBookings, &s.TicketsSold,
+			&s.Revenue, &s.Refunds, &s.RefundedAmount, &s.Currency); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, fmt.Errorf("failed to scan sales summary: %w", err)
+		}
+		summaries = append(summaries, &s)
+	}
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to summarize sales: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("events", len(summaries)))
+	span.SetStatus(codes.Ok, "")
+	return summaries, nil
+}
+
+func scanReportSchedule(row pgx.Row) (*domain.ReportSchedule, error) {
+	var s domain.ReportSchedule
+	var frequency string
+	var weekday int
+	if err := row.Scan(&s.TenantID, &s.Email, &s.Locale, &frequency, &s.SendHour, &weekday, &s.EventIDs,
+		&s.UnsubscribeToken, &s.UnsubscribedAt, &s.NextRunAt, &s.LastSentAt, &s.CreatedAt, &s.UpdatedAt); err != nil {
+		return nil, err
+	}
+	s.Frequency = domain.ReportFrequency(frequency)
+	s.Weekday = time.Weekday(weekday)
+	return &s, nil
+}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// PostgresSalesSnapshotRepository implements SalesSnapshotRepository using PostgreSQL.
+// Aggregates read the bookings table; snapshots live in reporting.zone_snapshots.
+type PostgresSalesSnapshotRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresSalesSnapshotRepository creates a new PostgresSalesSnapshotRepository
+func NewPostgresSalesSnapshotRepository(pool *pgxpool.Pool) *PostgresSalesSnapshotRepository {
+	return &PostgresSalesSnapshotRepository{pool: pool}
+}
+
+var _ SalesSnapshotRepository = (*PostgresSalesSnapshotRepository)(nil)
+
+// AggregateZones sums held and sold seats and revenue per zone. Holds whose reservation
+// expired by at are not counted, even if the expiry worker has not caught up with them.
+func (r *PostgresSalesSnapshotRepository) AggregateZones(ctx context.Context, at time.Time) ([]*domain.ZoneSalesSnapshot, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.sales_snapshot.aggregate_zones")
+	defer span.End()
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT tenant_id::text, event_id::text, show_id::text, zone_id::text,
+			COALESCE(SUM(quantity) FILTER (
+				WHERE status IN ('pending', 'reserved', 'pending_payment')
+					AND (reservation_expires_at IS NULL OR reservation_expires_at > $1)
+			), 0) AS held,
+			COALESCE(SUM(quantity) FILTER (WHERE status = 'confirmed'), 0) AS sold,
+			COALESCE(SUM(total_amount) FILTER (WHERE status = 'confirmed'), 0)::float8 AS revenue,
+			MAX(COALESCE(currency, 'THB'))
+		FROM bookings
+		WHERE status IN ('pending', 'reserved', 'pending_payment', 'confirmed')
+		GROUP BY tenant_id, event_id, show_id, zone_id
+	`, at)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to aggregate zone sales: %w", err)
+	}
+	defer rows.Close()
+
+	var snapshots []*domain.ZoneSalesSnapshot
+	for rows.Next() {
+		s := &domain.ZoneSalesSnapshot{SnapshotAt: at}
+		if err := rows.Scan(&s.TenantID, &s.EventID, &s.ShowID, &s.ZoneID,
+			&s.HeldSeats, &s.SoldSeats, &s.Revenue, &s.Currency); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, fmt.Errorf("failed to scan zone sales: %w", err)
+		}
+		snapshots = append(snapshots, s)
+	}
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to aggregate zone sales: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("zones", len(snapshots)))
+	span.SetStatus(codes.Ok, "")
+	return snapshots, nil
+}
+
+// SaveSnapshots upserts snapshots in one statement
+func (r *PostgresSalesSnapshotRepository) SaveSnapshots(ctx context.Context, snapshots []*domain.ZoneSalesSnapshot) error {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.sales_snapshot.save")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("zones", len(snapshots)))
+
+	if len(snapshots) == 0 {
+		span.SetStatus(codes.Ok, "")
+		return nil
+	}
+
+	n := len(snapshots)
+	snapshotAt := make([]time.Time, n)
+	tenantIDs, eventIDs, showIDs, zoneIDs := make([]string, n), make([]string, n), make([]string, n), make([]string, n)
+	totals, available := make([]*int64, n), make([]*int64, n)
+	held, sold := make([]int64, n), make([]int64, n)
+	revenue := make([]float64, n)
+	currencies := make([]string, n)
+	for i, s := range snapshots {
+		snapshotAt[i] = s.SnapshotAt
+		tenantIDs[i], eventIDs[i], showIDs[i], zoneIDs[i] = s.TenantID, s.EventID, s.ShowID, s.ZoneID
+		totals[i], available[i] = s.TotalSeats, s.AvailableSeats
+		held[i], sold[i] = s.HeldSeats, s.SoldSeats
+		revenue[i] = s.Revenue
+		currencies[i] = s.Currency
+	}
+
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO reporting.zone_snapshots (
+			snapshot_at, tenant_id, event_id, show_id, zone_id,
+			total_seats, available_seats, held_seats, sold_seats, revenue, currency
+		)
+		SELECT * FROM unnest(
+			$1::timestamptz[], $2::uuid[], $3::uuid[], $4::uuid[], $5::uuid[],
+			$6::int[], $7::int[], $8::int[], $9::int[], $10::numeric[], $11::varchar[]
+		)
+		ON CONFLICT (zone_id, snapshot_at) DO UPDATE SET
+			total_seats = EXCLUDED.total_seats,
+			available_seats = EXCLUDED.available_seats,
+			held_seats = EXCLUDED.held_seats,
+			sold_seats = EXCLUDED.sold_seats,
+			revenue = EXCLUDED.revenue,
+			currency = EXCLUDED.currency,
+			created_at = NOW()
+	`, snapshotAt, tenantIDs, eventIDs, showIDs, zoneIDs, totals, available, held, sold, revenue, currencies)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to save zone snapshots: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// ListEventSnapshots returns the tenant's snapshots of an event in [from, to), oldest first
+func (r *PostgresSalesSnapshotRepository) ListEventSnapshots(ctx context.Context, tenantID, eventID string, from, to time.Time) ([]*domain.ZoneSalesSnapshot, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.sales_snapshot.list_event")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("tenant_id", tenantID),
+		attribute.String("event_id", eventID),
+	)
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT snapshot_at, tenant_id::text, event_id::text, show_id::text, zone_id::text,
+			total_seats, available_seats, held_seats, sold_seats, revenue::float8, currency
+		FROM reporting.zone_snapshots
+		WHERE tenant_id = $1 AND event_id = $2
+			AND snapshot_at >= $3 AND snapshot_at < $4
+		ORDER BY snapshot_at, zone_id
+	`, tenantID, eventID, from, to)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to list zone snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	snapshots := []*domain.ZoneSalesSnapshot{}
+	for rows.Next() {
+		var s domain.ZoneSalesSnapshot
+		if err := rows.Scan(&s.SnapshotAt, &s.TenantID, &s.EventID, &s.ShowID, &s.ZoneID,
+			&s.TotalSeats, &s.AvailableSeats, &s.HeldSeats, &s.SoldSeats, &s.Revenue, &s.Currency); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, fmt.Errorf("failed to scan zone snapshot: %w", err)
+		}
+		snapshots = append(snapshots, &s)
+	}
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to list zone snapshots: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("snapshots", len(snapshots)))
+	span.SetStatus(codes.Ok, "")
+	return snapshots, nil
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// PostgresUserDataRepository implements UserDataRepository using PostgreSQL
+type PostgresUserDataRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresUserDataRepository creates a new PostgresUserDataRepository
+func NewPostgresUserDataRepository(pool *pgxpool.Pool) *PostgresUserDataRepository {
+	return &PostgresUserDataRepository{pool: pool}
+}
+
+var _ UserDataRepository = (*PostgresUserDataRepository)(nil)
+
+// ListByUserID returns every booking owned by a user
+func (r *PostgresUserDataRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.Booking, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.user_data.list_by_user_id")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", userID))
+
+	query := `
+		SELECT
+			id, tenant_id, user_id, event_id, show_id, zone_id,
+			quantity, unit_price, total_amount, currency, status,
+			idempotency_key, reserved_at, reservation_expires_at,
+			confirmed_at, confirmation_code, payment_id,
+			cancelled_at, created_at, updated_at, seat_labels, add_ons
+		FROM bookings
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to list bookings for user: %w", err)
+	}
+	defer rows.Close()
+
+	var bookings []*domain.Booking
+	for rows.Next() {
+		booking, err := scanBooking(rows)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		bookings = append(bookings, booking)
+	}
+
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("error iterating bookings: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("count", len(bookings)))
+	span.SetStatus(codes.Ok, "")
+	return bookings, nil
+}
+
+// AnonymizeByUserID re-assigns a user's bookings to an anonymized ID.
+// Idempotency keys are client-generated and may embed user identifiers, so they are cleared.
+func (r *PostgresUserDataRepository) AnonymizeByUserID(ctx context.Context, userID, anonymizedID string) (int64, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.user_data.anonymize_by_user_id")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", userID))
+
+	query := `
+		UPDATE bookings SET
+			user_id = $2,
+			idempotency_key = NULL,
+			updated_at = NOW()
+		WHERE user_id = $1
+	`
+
+	result, err := r.pool.Exec(ctx, query, userID, anonymizedID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return 0, fmt.Errorf("failed to anonymize bookings: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int64("rows_affected", result.RowsAffected()))
+	span.SetStatus(codes.Ok, "")
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,593 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// PostgresWebhookSubscriptionRepository implements WebhookSubscriptionRepository using PostgreSQL
+type PostgresWebhookSubscriptionRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresWebhookSubscriptionRepository creates a new PostgresWebhookSubscriptionRepository
+func NewPostgresWebhookSubscriptionRepository(pool *pgxpool.Pool) *PostgresWebhookSubscriptionRepository {
+	return &PostgresWebhookSubscriptionRepository{pool: pool}
+}
+
+const webhookSubscriptionColumns = `
+	id, tenant_id, url, secret, event_types, description, active, created_at, updated_at
+`
+
+// Create inserts a new subscription
+func (r *PostgresWebhookSubscriptionRepository) Create(ctx context.Context, sub *domain.WebhookSubscription) error {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.webhook_subscription.create")
+	defer span.End()
+
+	if sub.ID == "" {
+		sub.ID = uuid.New().String()
+	}
+
+	span.SetAttributes(
+		attribute.String("subscription_id", sub.ID),
+		attribute.String("tenant_id", sub.TenantID),
+	)
+
+	query := `
+		INSERT INTO webhook_subscriptions (` + webhookSubscriptionColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		sub.ID,
+		sub.TenantID,
+		sub.URL,
+		sub.Secret,
+		webhookEventTypesToStrings(sub.EventTypes),
+		nullString(sub.Description),
+		sub.Active,
+		sub.CreatedAt,
+		sub.UpdatedAt,
+	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// GetByID retrieves a tenant's subscription by its ID
+func (r *PostgresWebhookSubscriptionRepository) GetByID(ctx context.Context, tenantID, id string) (*domain.WebhookSubscription, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.webhook_subscription.get_by_id")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("subscription_id", id),
+		attribute.String("tenant_id", tenantID),
+	)
+
+	if _, err := uuid.Parse(id); err != nil {
+		span.SetStatus(codes.Error, "not found")
+		return nil, domain.ErrWebhookSubscriptionNotFound
+	}
+
+	query := `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscriptions WHERE id = $1 AND tenant_id = $2`
+
+	sub, err := scanWebhookSubscription(r.pool.QueryRow(ctx, query, id, tenantID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			span.SetStatus(codes.Error, "not found")
+			return nil, domain.ErrWebhookSubscriptionNotFound
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return sub, nil
+}
+
+// ListByTenant retrieves all subscriptions of a tenant, newest first
+func (r *PostgresWebhookSubscriptionRepository) ListByTenant(ctx context.Context, tenantID string) ([]*domain.WebhookSubscription, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.webhook_subscription.list_by_tenant")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("tenant_id", tenantID))
+
+	query := `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscriptions WHERE tenant_id = $1 ORDER BY created_at DESC`
+
+	subs, err := r.query(ctx, query, tenantID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("count", len(subs)))
+	span.SetStatus(codes.Ok, "")
+	return subs, nil
+}
+
+// ListActiveForEvent retrieves a tenant's active subscriptions to the given event type
+func (r *PostgresWebhookSubscriptionRepository) ListActiveForEvent(ctx context.Context, tenantID string, eventType domain.WebhookEventType) ([]*domain.WebhookSubscription, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.webhook_subscription.list_active_for_event")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("tenant_id", tenantID),
+		attribute.String("event_type", eventType.String()),
+	)
+
+	query := `
+		SELECT ` + webhookSubscriptionColumns + `
+		FROM webhook_subscriptions
+		WHERE tenant_id = $1 AND active = TRUE AND $2 = ANY(event_types)
+	`
+
+	subs, err := r.query(ctx, query, tenantID, eventType.String())
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to list webhook subscriptions for event: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("count", len(subs)))
+	span.SetStatus(codes.Ok, "")
+	return subs, nil
+}
+
+// Update saves the URL, event types, description and active flag of a subscription
+func (r *PostgresWebhookSubscriptionRepository) Update(ctx context.Context, sub *domain.WebhookSubscription) error {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.webhook_subscription.update")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("subscription_id", sub.ID),
+		attribute.String("tenant_id", sub.TenantID),
+	)
+
+	query := `
+		UPDATE webhook_subscriptions
+		SET url = $3, event_types = $4, description = $5, active = $6, updated_at = $7
+		WHERE id = $1 AND tenant_id = $2
+	`
+
+	result, err := r.pool.Exec(ctx, query,
+		sub.ID,
+		sub.TenantID,
+		sub.URL,
+		webhookEventTypesToStrings(sub.EventTypes),
+		nullString(sub.Description),
+		sub.Active,
+		sub.UpdatedAt,
+	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to update webhook subscription: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		span.SetStatus(codes.Error, "not found")
+		return domain.ErrWebhookSubscriptionNotFound
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// Delete removes a tenant's subscription and its delivery log
+func (r *PostgresWebhookSubscriptionRepository) Delete(ctx context.Context, tenantID, id string) error {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.webhook_subscription.delete")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("subscription_id", id),
+		attribute.String("tenant_id", tenantID),
+	)
+
+	if _, err := uuid.Parse(id); err != nil {
+		span.SetStatus(codes.Error, "not found")
+		return domain.ErrWebhookSubscriptionNotFound
+	}
+
+	result, err := r.pool.Exec(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		span.SetStatus(codes.Error, "not found")
+		return domain.ErrWebhookSubscriptionNotFound
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// query runs a subscription query and scans all rows
+func (r *PostgresWebhookSubscriptionRepository) query(ctx context.Context, query string, args ...interface{}) ([]*domain.WebhookSubscription, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subs []*domain.WebhookSubscription
+	for rows.Next() {
+		sub, err := scanWebhookSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook subscription: %w", err)
+		}
+		subs = append(subs, sub)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook subscriptions: %w", err)
+	}
+	return subs, nil
+}
+
+// scanWebhookSubscription scans a single row into a WebhookSubscription
+func scanWebhookSubscription(row pgx.Row) (*domain.WebhookSubscription, error) {
+	sub := &domain.WebhookSubscription{}
+	var (
+		eventTypes  []string
+		description *string
+	)
+
+	if err := row.Scan(
+		&sub.ID,
+		&sub.TenantID,
+		&sub.URL,
+		&sub.Secret,
+		&eventTypes,
+		&description,
+		&sub.Active,
+		&sub.CreatedAt,
+		&sub.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if description != nil {
+		sub.Description = *description
+	}
+	sub.EventTypes = make([]domain.WebhookEventType, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		sub.EventTypes = append(sub.EventTypes, domain.WebhookEventType(t))
+	}
+
+	return sub, nil
+}
+
+func webhookEventTypesToStrings(eventTypes []domain.WebhookEventType) []string {
+	out := make([]string, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		out = append(out, t.String())
+	}
+	return out
+}
+
+// PostgresWebhookDeliveryRepository implements WebhookDeliveryRepository using PostgreSQL
+type PostgresWebhookDeliveryRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresWebhookDeliveryRepository creates a new PostgresWebhookDeliveryRepository
+func NewPostgresWebhookDeliveryRepository(pool *pgxpool.Pool) *PostgresWebhookDeliveryRepository {
+	return &PostgresWebhookDeliveryRepository{pool: pool}
+}
+
+const webhookDeliveryColumns = `
+	id, subscription_id, tenant_id, event_id, event_type, payload, status, attempts, max_attempts,
+	last_status_code, last_error, next_attempt_at, delivered_at, created_at, updated_at
+`
+
+// Create inserts a new delivery, ignoring duplicates of the same event for the same subscription
+func (r *PostgresWebhookDeliveryRepository) Create(ctx context.Context, d *domain.WebhookDelivery) (bool, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.webhook_delivery.create")
+	defer span.End()
+
+	if d.ID == "" {
+		d.ID = uuid.New().String()
+	}
+
+	span.SetAttributes(
+		attribute.String("delivery_id", d.ID),
+		attribute.String("subscription_id", d.SubscriptionID),
+		attribute.String("event_id", d.EventID),
+		attribute.String("event_type", d.EventType.String()),
+	)
+
+	query := `
+		INSERT INTO webhook_deliveries (` + webhookDeliveryColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		ON CONFLICT (subscription_id, event_id) DO NOTHING
+	`
+
+	result, err := r.pool.Exec(ctx, query,
+		d.ID,
+		d.SubscriptionID,
+		d.TenantID,
+		d.EventID,
+		d.EventType.String(),
+		[]byte(d.Payload),
+		d.Status.String(),
+		d.Attempts,
+		d.MaxAttempts,
+		nullInt(d.LastStatusCode),
+		nullString(d.LastError),
+		d.NextAttemptAt,
+		d.DeliveredAt,
+		d.CreatedAt,
+		d.UpdatedAt,
+	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return false, fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+
+	created := result.RowsAffected() > 0
+	span.SetAttributes(attribute.Bool("created", created))
+	span.SetStatus(codes.Ok, "")
+	return created, nil
+}
+
+// GetByID retrieves a tenant's delivery by its ID
+func (r *PostgresWebhookDeliveryRepository) GetByID(ctx context.Context, tenantID, id string) (*domain.WebhookDelivery, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.webhook_delivery.get_by_id")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("delivery_id", id),
+		attribute.String("tenant_id", tenantID),
+	)
+
+	if _, err := uuid.Parse(id); err != nil {
+		span.SetStatus(codes.Error, "not found")
+		return nil, domain.ErrWebhookDeliveryNotFound
+	}
+
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE id = $1 AND tenant_id = $2`
+
+	d, err := scanWebhookDelivery(r.pool.QueryRow(ctx, query, id, tenantID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			span.SetStatus(codes.Error, "not found")
+			return nil, domain.ErrWebhookDeliveryNotFound
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return d, nil
+}
+
+// List retrieves deliveries matching the filter, newest first
+func (r *PostgresWebhookDeliveryRepository) List(ctx context.Context, filter *WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.webhook_delivery.list")
+	defer span.End()
+
+	if filter == nil {
+		filter = &WebhookDeliveryFilter{}
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 50
+	}
+
+	args := []interface{}{filter.TenantID}
+	conditions := []string{"tenant_id = $1"}
+	if filter.SubscriptionID != "" {
+		if _, err := uuid.Parse(filter.SubscriptionID); err != nil {
+			span.SetStatus(codes.Ok, "")
+			return nil, nil
+		}
+		args = append(args, filter.SubscriptionID)
+		conditions = append(conditions, fmt.Sprintf("subscription_id = $%d", len(args)))
+	}
+	if filter.EventID != "" {
+		args = append(args, filter.EventID)
+		conditions = append(conditions, fmt.Sprintf("event_id = $%d", len(args)))
+	}
+	if filter.EventType != "" {
+		args = append(args, filter.EventType.String())
+		conditions = append(conditions, fmt.Sprintf("event_type = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status.String())
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	span.SetAttributes(
+		attribute.String("tenant_id", filter.TenantID),
+		attribute.String("subscription_id", filter.SubscriptionID),
+		attribute.Int("limit", filter.Limit),
+	)
+
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE ` + strings.Join(conditions, " AND ")
+	args = append(args, filter.Limit, filter.Offset)
+	query += fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	deliveries, err := r.query(ctx, query, args...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("count", len(deliveries)))
+	span.SetStatus(codes.Ok, "")
+	return deliveries, nil
+}
+
+// ClaimDue returns due pending deliveries and leases them to the caller
+func (r *PostgresWebhookDeliveryRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*domain.WebhookDelivery, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.webhook_delivery.claim_due")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("limit", limit))
+
+	query := `
+		UPDATE webhook_deliveries
+		SET next_attempt_at = NOW() + $2 * INTERVAL '1 millisecond'
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at ASC
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + webhookDeliveryColumns
+
+	deliveries, err := r.query(ctx, query, limit, lease.Milliseconds())
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to claim due webhook deliveries: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("count", len(deliveries)))
+	span.SetStatus(codes.Ok, "")
+	return deliveries, nil
+}
+
+// Update saves the attempt outcome of a delivery
+func (r *PostgresWebhookDeliveryRepository) Update(ctx context.Context, d *domain.WebhookDelivery) error {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.webhook_delivery.update")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("delivery_id", d.ID),
+		attribute.String("status", d.Status.String()),
+		attribute.Int("attempts", d.Attempts),
+	)
+
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, max_attempts = $4, last_status_code = $5, last_error = $6,
+			next_attempt_at = $7, delivered_at = $8, updated_at = $9
+		WHERE id = $1
+	`
+
+	result, err := r.pool.Exec(ctx, query,
+		d.ID,
+		d.Status.String(),
+		d.Attempts,
+		d.MaxAttempts,
+		nullInt(d.LastStatusCode),
+		nullString(d.LastError),
+		d.NextAttemptAt,
+		d.DeliveredAt,
+		d.UpdatedAt,
+	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		span.SetStatus(codes.Error, "not found")
+		return domain.ErrWebhookDeliveryNotFound
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// query runs a delivery query and scans all rows
+func (r *PostgresWebhookDeliveryRepository) query(ctx context.Context, query string, args ...interface{}) ([]*domain.WebhookDelivery, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*domain.WebhookDelivery
+	for rows.Next() {
+		d, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// scanWebhookDelivery scans a single row into a WebhookDelivery
+func scanWebhookDelivery(row pgx.Row) (*domain.WebhookDelivery, error) {
+	d := &domain.WebhookDelivery{}
+	var (
+		eventType  string
+		payload    []byte
+		status     string
+		statusCode *int
+		lastError  *string
+	)
+
+	if err := row.Scan(
+		&d.ID,
+		&d.SubscriptionID,
+		&d.TenantID,
+		&d.EventID,
+		&eventType,
+		&payload,
+		&status,
+		&d.Attempts,
+		&d.MaxAttempts,
+		&statusCode,
+		&lastError,
+		&d.NextAttemptAt,
+		&d.DeliveredAt,
+		&d.CreatedAt,
+		&d.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	d.EventType = domain.WebhookEventType(eventType)
+	d.Status = domain.WebhookDeliveryStatus(status)
+	d.Payload = payload
+	if statusCode != nil {
+		d.LastStatusCode = *statusCode
+	}
+	if lastError != nil {
+		d.LastError = *lastError
+	}
+
+	return d, nil
+}
+
+// nullInt returns nil for zero so optional integer columns stay NULL
+func nullInt(i int) *int {
+	if i == 0 {
+		return nil
+	}
+	return &i
+}
+
+// Ensure the webhook repositories implement their interfaces
+var (
+	_ WebhookSubscriptionRepository = (*PostgresWebhookSubscriptionRepository)(nil)
+	_ WebhookDeliveryRepository     = (*PostgresWebhookDeliveryRepository)(nil)
+)
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// PostgresZoneInventoryRepository implements ZoneInventoryRepository using PostgreSQL
+type PostgresZoneInventoryRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresZoneInventoryRepository creates a new PostgresZoneInventoryRepository
+func NewPostgresZoneInventoryRepository(pool *pgxpool.Pool) *PostgresZoneInventoryRepository {
+	return &PostgresZoneInventoryRepository{pool: pool}
+}
+
+var _ ZoneInventoryRepository = (*PostgresZoneInventoryRepository)(nil)
+
+// GetAvailableSeats returns the available seats of a zone as last synced to PostgreSQL
+func (r *PostgresZoneInventoryRepository) GetAvailableSeats(ctx context.Context, zoneID string) (int64, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.zone_inventory.get_available_seats")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("zone_id", zoneID))
+
+	var seats int64
+	err := r.pool.QueryRow(ctx, `SELECT available_seats FROM seat_zones WHERE id::text = $1`, zoneID).Scan(&seats)
+	if errors.Is(err, pgx.ErrNoRows) {
+		span.SetStatus(codes.Ok, "zone not found")
+		return 0, domain.ErrZoneNotFound
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return 0, fmt.Errorf("failed to get zone available seats: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return seats, nil
+}
+
+// GetZoneSeats returns the total and available seats of the zones as last synced to PostgreSQL
+func (r *PostgresZoneInventoryRepository) GetZoneSeats(ctx context.Context, zoneIDs []string) (map[string]domain.ZoneSeatCounts, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.postgres.zone_inventory.get_zone_seats")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("zones", len(zoneIDs)))
+
+	seats := make(map[string]domain.ZoneSeatCounts, len(zoneIDs))
+	if len(zoneIDs) == 0 {
+		span.SetStatus(codes.Ok, "")
+		return seats, nil
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT id::text, total_seats, available_seats
+		FROM seat_zones
+		WHERE id::text = ANY($1)
+	`, zoneIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to get zone seats: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var zoneID string
+		var counts domain.ZoneSeatCounts
+		if err := rows.Scan(&zoneID, &counts.Total, &counts.Available); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, fmt.Errorf("failed to scan zone seats: %w", err)
+		}
+		seats[zoneID] = counts
+	}
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to get zone seats: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return seats, nil
+}
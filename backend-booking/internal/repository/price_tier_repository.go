@@ -0,0 +1,22 @@
+package repository
+
+import "context"
+
+// PriceTierAllocation is a price tier a reservation may claim, with the seats it sells
+type PriceTierAllocation struct {
+	TierID     string
+	Allocation int // Seats sold at the tier (0 = no limit)
+}
+
+// PriceTierRepository defines the interface for the seats sold per zone price tier
+type PriceTierRepository interface {
+	// ClaimTier atomically counts a reservation's seats against the first tier, in
+	// order, with room left and returns its ID; "" when every tier is sold out.
+	// Claiming again for the same booking returns the tier claimed before.
+	ClaimTier(ctx context.Context, zoneID, bookingID string, quantity int, tiers []PriceTierAllocation) (string, error)
+
+	// ReleaseTier gives a reservation's seats back to the tier it claimed. Releasing
+	// the reservation's seats does the same, so this is only needed when the
+	// reservation was never made.
+	ReleaseTier(ctx context.Context, zoneID, bookingID string) error
+}
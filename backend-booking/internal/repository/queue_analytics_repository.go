@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// QueueAnalyticsRepository stores queue funnel counts of each event in buckets of
+// domain.QueueAnalyticsBucketSize. Bucket times are truncated to the bucket size.
+type QueueAnalyticsRepository interface {
+	// IncrStep adds count to a funnel step in the bucket starting at bucket
+	IncrStep(ctx context.Context, eventID string, step domain.QueueFunnelStep, bucket time.Time, count int64) error
+
+	// SetDepth records the queue size sampled in the bucket starting at bucket
+	SetDepth(ctx context.Context, eventID string, bucket time.Time, depth int64) error
+
+	// GetBuckets returns n consecutive buckets starting at from, oldest first.
+	// Buckets nothing was recorded in are returned empty.
+	GetBuckets(ctx context.Context, eventID string, from time.Time, n int) ([]*domain.QueueAnalyticsBucket, error)
+}
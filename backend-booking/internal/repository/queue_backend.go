@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+)
+
+// Queue backend names, selected with QUEUE_BACKEND
+const (
+	QueueBackendZSet    = "zset"
+	QueueBackendStreams = "streams"
+)
+
+// ErrQueueNotEmpty is returned by Import when the target queue already holds users
+// and the backend cannot merge entries while preserving join order
+var ErrQueueNotEmpty = errors.New("target queue is not empty")
+
+// QueueEntry is a user waiting in the virtual queue, in join order
+type QueueEntry struct {
+	UserID   string  `json:"user_id"`
+	JoinedAt float64 `json:"joined_at"`
+}
+
+// QueueBackend stores the order of users in the virtual queue.
+// Per-user queue info (queue:user:*), queue passes and event queue config are plain Redis
+// keys owned by RedisQueueRepository and are shared by every backend.
+type QueueBackend interface {
+	// Name returns the backend name (zset, streams)
+	Name() string
+	// LoadScripts loads the backend's Lua scripts into Redis
+	LoadScripts(ctx context.Context) error
+	// Join appends a user to the queue and stores their queue info
+	Join(ctx context.Context, params JoinQueueParams) (*JoinQueueResult, error)
+	// Position returns a user's 1-indexed position
+	Position(ctx context.Context, eventID, userID string) (*QueuePositionResult, error)
+	// Remove removes users from the queue and returns how many were removed
+	Remove(ctx context.Context, eventID string, userIDs ...string) (int64, error)
+	// Size returns the number of users in the queue
+	Size(ctx context.Context, eventID string) (int64, error)
+	// Pop removes and returns up to count users from the head of the queue
+	Pop(ctx context.Context, eventID string, count int64) ([]string, error)
+	// EventIDs returns all events that have a queue in this backend
+	EventIDs(ctx context.Context) ([]string, error)
+	// Entries returns every user in the queue in join order
+	Entries(ctx context.Context, eventID string) ([]QueueEntry, error)
+	// Import adds entries (in join order) to the queue, skipping users already in it.
+	// Returns ErrQueueNotEmpty if the backend cannot merge into a non-empty queue.
+	Import(ctx context.Context, eventID string, entries []QueueEntry) (int64, error)
+}
+
+// NewQueueBackend creates the queue backend with the given name
+func NewQueueBackend(client *pkgredis.Client, name string) (QueueBackend, error) {
+	switch name {
+	case "", QueueBackendZSet:
+		return NewRedisZSetQueueBackend(client), nil
+	case QueueBackendStreams:
+		return NewRedisStreamQueueBackend(client), nil
+	default:
+		return nil, fmt.Errorf("unknown queue backend %q (want %s or %s)", name, QueueBackendZSet, QueueBackendStreams)
+	}
+}
+
+// NewQueueBackendFromConfig creates the configured queue backend. When migrateFrom names a
+// different backend, queues still held there are served (and drained) first while new joins
+// go to the configured backend; see MigrateQueues.
+func NewQueueBackendFromConfig(client *pkgredis.Client, name, migrateFrom string) (QueueBackend, error) {
+	backend, err := NewQueueBackend(client, name)
+	if err != nil {
+		return nil, err
+	}
+	if migrateFrom == "" || migrateFrom == backend.Name() {
+		return backend, nil
+	}
+	from, err := NewQueueBackend(client, migrateFrom)
+	if err != nil {
+		return nil, err
+	}
+	return NewMigratingQueueBackend(from, backend), nil
+}
+
+// queueUserKey is the per-user queue info hash shared by all backends
+func queueUserKey(eventID, userID string) string {
+	return fmt.Sprintf("queue:user:%s:%s", eventID, userID)
+}
+
+// parseJoinResult parses the {1, position, total, joined_at} / {0, code, message} reply
+// returned by the join scripts
+func parseJoinResult(values []interface{}) (*JoinQueueResult, error) {
+	if len(values) < 3 {
+		return nil, fmt.Errorf("unexpected script result length: %d", len(values))
+	}
+
+	success, _ := toInt64(values[0])
+	if success == 1 {
+		position, _ := toInt64(values[1])
+		totalInQueue, _ := toInt64(values[2])
+		var joinedAt float64
+		if len(values) > 3 {
+			joinedAt, _ = toFloat64(values[3])
+		}
+		return &JoinQueueResult{
+			Success:      true,
+			Position:     position,
+			TotalInQueue: totalInQueue,
+			JoinedAt:     joinedAt,
+		}, nil
+	}
+
+	errorCode, _ := values[1].(string)
+	errorMessage, _ := values[2].(string)
+	return &JoinQueueResult{
+		Success:      false,
+		ErrorCode:    errorCode,
+		ErrorMessage: errorMessage,
+	}, nil
+}
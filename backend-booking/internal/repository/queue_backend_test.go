@@ -0,0 +1,260 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+// memoryQueueBackend is an in-memory QueueBackend for testing
+type memoryQueueBackend struct {
+	name        string
+	queues      map[string][]QueueEntry
+	importEmpty bool // Import refuses non-empty queues, like the stream backend
+	clock       float64
+}
+
+func newMemoryQueueBackend(name string, importEmpty bool) *memoryQueueBackend {
+	return &memoryQueueBackend{name: name, queues: make(map[string][]QueueEntry), importEmpty: importEmpty}
+}
+
+func (b *memoryQueueBackend) Name() string                      { return b.name }
+func (b *memoryQueueBackend) LoadScripts(context.Context) error { return nil }
+
+func (b *memoryQueueBackend) index(eventID, userID string) int {
+	for i, e := range b.queues[eventID] {
+		if e.UserID == userID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (b *memoryQueueBackend) Join(ctx context.Context, params JoinQueueParams) (*JoinQueueResult, error) {
+	if i := b.index(params.EventID, params.UserID); i >= 0 {
+		return &JoinQueueResult{ErrorCode: "ALREADY_IN_QUEUE"}, nil
+	}
+	if params.MaxQueueSize > 0 && int64(len(b.queues[params.EventID])) >= params.MaxQueueSize {
+		return &JoinQueueResult{ErrorCode: "QUEUE_FULL"}, nil
+	}
+	b.clock++
+	b.queues[params.EventID] = append(b.queues[params.EventID], QueueEntry{UserID: params.UserID, JoinedAt: b.clock})
+	size := int64(len(b.queues[params.EventID]))
+	return &JoinQueueResult{Success: true, Position: size, TotalInQueue: size, JoinedAt: b.clock}, nil
+}
+
+func (b *memoryQueueBackend) Position(ctx context.Context, eventID, userID string) (*QueuePositionResult, error) {
+	i := b.index(eventID, userID)
+	if i < 0 {
+		return &QueuePositionResult{}, nil
+	}
+	return &QueuePositionResult{Position: int64(i + 1), TotalInQueue: int64(len(b.queues[eventID])), IsInQueue: true}, nil
+}
+
+func (b *memoryQueueBackend) Remove(ctx context.Context, eventID string, userIDs ...string) (int64, error) {
+	var removed int64
+	for _, userID := range userIDs {
+		if i := b.index(eventID, userID); i >= 0 {
+			b.queues[eventID] = append(b.queues[eventID][:i], b.queues[eventID][i+1:]...)
+			removed++
+		}
+	}
+	if len(b.queues[eventID]) == 0 {
+		delete(b.queues, eventID)
+	}
+	return removed, nil
+}
+
+func (b *memoryQueueBackend) Size(ctx context.Context, eventID string) (int64, error) {
+	return int64(len(b.queues[eventID])), nil
+}
+
+func (b *memoryQueueBackend) Pop(ctx context.Context, eventID string, count int64) ([]string, error) {
+	users := []string{}
+	for _, e := range b.queues[eventID] {
+		if int64(len(users)) == count {
+			break
+		}
+		users = append(users, e.UserID)
+	}
+	b.Remove(ctx, eventID, users...)
+	return users, nil
+}
+
+func (b *memoryQueueBackend) EventIDs(ctx context.Context) ([]string, error) {
+	ids := []string{}
+	for id := range b.queues {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (b *memoryQueueBackend) Entries(ctx context.Context, eventID string) ([]QueueEntry, error) {
+	return append([]QueueEntry{}, b.queues[eventID]...), nil
+}
+
+func (b *memoryQueueBackend) Import(ctx context.Context, eventID string, entries []QueueEntry) (int64, error) {
+	if b.importEmpty && len(b.queues[eventID]) > 0 {
+		return 0, ErrQueueNotEmpty
+	}
+	var added int64
+	for _, e := range entries {
+		if b.index(eventID, e.UserID) < 0 {
+			b.queues[eventID] = append(b.queues[eventID], e)
+			added++
+		}
+	}
+	return added, nil
+}
+
+func joinUsers(t *testing.T, b QueueBackend, eventID string, userIDs ...string) {
+	t.Helper()
+	for _, userID := range userIDs {
+		result, err := b.Join(context.Background(), JoinQueueParams{EventID: eventID, UserID: userID})
+		if err != nil || !result.Success {
+			t.Fatalf("Join(%s) = %+v, %v", userID, result, err)
+		}
+	}
+}
+
+func queueOrder(entries []QueueEntry) string {
+	order := ""
+	for _, e := range entries {
+		order += e.UserID
+	}
+	return order
+}
+
+func TestMigratingQueueBackend_OldQueueAheadOfNewJoins(t *testing.T) {
+	ctx := context.Background()
+	from := newMemoryQueueBackend(QueueBackendZSet, false)
+	to := newMemoryQueueBackend(QueueBackendStreams, true)
+	joinUsers(t, from, "event-1", "a", "b")
+
+	backend := NewMigratingQueueBackend(from, to)
+	if backend.Name() != QueueBackendStreams {
+		t.Errorf("Name() = %q, want %q", backend.Name(), QueueBackendStreams)
+	}
+
+	result, err := backend.Join(ctx, JoinQueueParams{EventID: "event-1", UserID: "c"})
+	if err != nil {
+		t.Fatalf("Join() error = %v", err)
+	}
+	if !result.Success || result.Position != 3 || result.TotalInQueue != 3 {
+		t.Errorf("Join() = %+v, want position 3 of 3", result)
+	}
+	if to.index("event-1", "c") != 0 {
+		t.Error("Expected new join to go to the target backend")
+	}
+
+	// Users still in the old backend cannot join twice
+	result, _ = backend.Join(ctx, JoinQueueParams{EventID: "event-1", UserID: "a"})
+	if result.Success || result.ErrorCode != "ALREADY_IN_QUEUE" {
+		t.Errorf("Join(existing) = %+v, want ALREADY_IN_QUEUE", result)
+	}
+
+	pos, _ := backend.Position(ctx, "event-1", "b")
+	if pos.Position != 2 || pos.TotalInQueue != 3 {
+		t.Errorf("Position(b) = %+v, want 2 of 3", pos)
+	}
+	pos, _ = backend.Position(ctx, "event-1", "c")
+	if pos.Position != 3 || pos.TotalInQueue != 3 {
+		t.Errorf("Position(c) = %+v, want 3 of 3", pos)
+	}
+
+	users, _ := backend.Pop(ctx, "event-1", 2)
+	if fmt.Sprint(users) != "[a b]" {
+		t.Errorf("Pop(2) = %v, want old queue first", users)
+	}
+	users, _ = backend.Pop(ctx, "event-1", 2)
+	if fmt.Sprint(users) != "[c]" {
+		t.Errorf("Pop(2) = %v, want [c]", users)
+	}
+}
+
+func TestMigratingQueueBackend_MaxQueueSizeCountsBothBackends(t *testing.T) {
+	from := newMemoryQueueBackend(QueueBackendZSet, false)
+	to := newMemoryQueueBackend(QueueBackendStreams, true)
+	joinUsers(t, from, "event-1", "a", "b")
+	backend := NewMigratingQueueBackend(from, to)
+
+	joinUsers(t, backend, "event-1", "c")
+	result, _ := backend.Join(context.Background(), JoinQueueParams{EventID: "event-1", UserID: "d", MaxQueueSize: 3})
+	if result.Success || result.ErrorCode != "QUEUE_FULL" {
+		t.Errorf("Join() = %+v, want QUEUE_FULL", result)
+	}
+}
+
+func TestMigrateQueues(t *testing.T) {
+	ctx := context.Background()
+	from := newMemoryQueueBackend(QueueBackendZSet, false)
+	to := newMemoryQueueBackend(QueueBackendStreams, true)
+	joinUsers(t, from, "idle", "a", "b", "c")
+	joinUsers(t, from, "busy", "x", "y")
+	// The busy event already took new joins in the target backend
+	joinUsers(t, to, "busy", "z")
+
+	report, err := MigrateQueues(ctx, from, to, false)
+	if err != nil {
+		t.Fatalf("MigrateQueues() error = %v", err)
+	}
+	if report.Events != 2 || report.Users != 3 {
+		t.Errorf("Report = %+v, want 2 events and 3 users", report)
+	}
+	if fmt.Sprint(report.Migrated) != "[idle]" || fmt.Sprint(report.Draining) != "[busy]" {
+		t.Errorf("Migrated = %v, Draining = %v", report.Migrated, report.Draining)
+	}
+
+	if got := queueOrder(to.queues["idle"]); got != "abc" {
+		t.Errorf("Target order = %q, want abc", got)
+	}
+	if _, ok := from.queues["idle"]; ok {
+		t.Error("Expected migrated queue to be removed from the source")
+	}
+	if got := queueOrder(from.queues["busy"]); got != "xy" {
+		t.Errorf("Draining queue = %q, want xy left in the source", got)
+	}
+}
+
+func TestMigrateQueues_DryRun(t *testing.T) {
+	from := newMemoryQueueBackend(QueueBackendZSet, false)
+	to := newMemoryQueueBackend(QueueBackendStreams, true)
+	joinUsers(t, from, "event-1", "a", "b")
+
+	report, err := MigrateQueues(context.Background(), from, to, true)
+	if err != nil {
+		t.Fatalf("MigrateQueues() error = %v", err)
+	}
+	if report.Users != 2 || len(to.queues) != 0 || len(from.queues["event-1"]) != 2 {
+		t.Errorf("Dry run wrote changes: report = %+v", report)
+	}
+}
+
+func TestNewQueueBackendFromConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		backend     string
+		migrateFrom string
+		wantType    string
+		wantErr     bool
+	}{
+		{"default", "", "", "*repository.RedisZSetQueueBackend", false},
+		{"streams", QueueBackendStreams, "", "*repository.RedisStreamQueueBackend", false},
+		{"same migrate from", QueueBackendStreams, QueueBackendStreams, "*repository.RedisStreamQueueBackend", false},
+		{"migrating", QueueBackendStreams, QueueBackendZSet, "*repository.MigratingQueueBackend", false},
+		{"unknown", "kafka", "", "", true},
+		{"unknown migrate from", QueueBackendStreams, "list", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, err := NewQueueBackendFromConfig(nil, tt.backend, tt.migrateFrom)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewQueueBackendFromConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && fmt.Sprintf("%T", backend) != tt.wantType {
+				t.Errorf("Backend type = %T, want %s", backend, tt.wantType)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// QueueMigrationReport summarizes a MigrateQueues run
+type QueueMigrationReport struct {
+	Events   int      `json:"events"`   // Queues found in the source backend
+	Migrated []string `json:"migrated"` // Queues moved to the target backend
+	Users    int64    `json:"users"`    // Users moved to the target backend
+	Draining []string `json:"draining"` // Queues left to drain because the target already has users
+}
+
+// MigrateQueues moves every queue from one backend to another, preserving join order.
+// It is safe to run online while booking-service runs a MigratingQueueBackend over the
+// same backends: queues the target cannot take in order (it already has newer joins) are
+// left in the source and drain first through the migrating backend. Re-run until no
+// queues are reported as draining. With dryRun, nothing is written.
+func MigrateQueues(ctx context.Context, from, to QueueBackend, dryRun bool) (*QueueMigrationReport, error) {
+	eventIDs, err := from.EventIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &QueueMigrationReport{Migrated: []string{}, Draining: []string{}}
+	for _, eventID := range eventIDs {
+		entries, err := from.Entries(ctx, eventID)
+		if err != nil {
+			return report, fmt.Errorf("failed to read queue %s: %w", eventID, err)
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		report.Events++
+
+		if dryRun {
+			report.Migrated = append(report.Migrated, eventID)
+			report.Users += int64(len(entries))
+			continue
+		}
+
+		imported, err := to.Import(ctx, eventID, entries)
+		if errors.Is(err, ErrQueueNotEmpty) {
+			report.Draining = append(report.Draining, eventID)
+			continue
+		}
+		if err != nil {
+			return report, fmt.Errorf("failed to import queue %s: %w", eventID, err)
+		}
+
+		// Only remove the users that were copied; anyone who joined the source since is
+		// left there to drain
+		userIDs := make([]string, len(entries))
+		for i, e := range entries {
+			userIDs[i] = e.UserID
+		}
+		if _, err := from.Remove(ctx, eventID, userIDs...); err != nil {
+			return report, fmt.Errorf("failed to remove migrated users from queue %s: %w", eventID, err)
+		}
+
+		report.Migrated = append(report.Migrated, eventID)
+		report.Users += imported
+	}
+
+	return report, nil
+}
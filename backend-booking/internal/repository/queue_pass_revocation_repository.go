@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// QueuePassRevocationRepository stores emergency revocations of queue passes.
+// An entry names a user's passes for an event or all passes for an event; passes
+// issued before the entry's revocation time are rejected until the entry expires.
+type QueuePassRevocationRepository interface {
+	// Revoke records a revocation that lasts for ttl
+	Revoke(ctx context.Context, entry string, revokedAt time.Time, ttl time.Duration) error
+
+	// GetRevokedAt returns when an entry was revoked, false if it is not revoked
+	GetRevokedAt(ctx context.Context, entry string) (time.Time, bool, error)
+
+	// ListRevoked returns all entries that have not expired
+	ListRevoked(ctx context.Context) ([]string, error)
+}
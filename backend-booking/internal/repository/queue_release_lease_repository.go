@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// QueueReleaseLeaseRepository hands out per-event leases on releasing users from the
+// virtual queue, so several queue-release-worker replicas never release for the same
+// event at once. A lease that is not renewed expires, letting another replica take over.
+type QueueReleaseLeaseRepository interface {
+	// Acquire takes the event's lease for owner, or renews it when owner already holds it.
+	// Returns false while another owner holds the lease.
+	Acquire(ctx context.Context, eventID, owner string, ttl time.Duration) (bool, error)
+
+	// Release gives up the event's lease, unless it has already passed to another owner
+	Release(ctx context.Context, eventID, owner string) error
+}
@@ -23,7 +23,7 @@ type QueuePositionResult struct {
 
 // QueueRepository defines the interface for Redis-based queue operations
 type QueueRepository interface {
-	// JoinQueue adds a user to the queue using Sorted Set
+	// JoinQueue adds a user to the queue
 	JoinQueue(ctx context.Context, params JoinQueueParams) (*JoinQueueResult, error)
 
 	// GetPosition gets the user's current position in queue
@@ -71,8 +71,9 @@ type QueueRepository interface {
 
 // EventQueueConfig holds queue configuration for an event
 type EventQueueConfig struct {
-	MaxConcurrentBookings int `json:"max_concurrent_bookings"`
-	QueuePassTTLMinutes   int `json:"queue_pass_ttl_minutes"`
+	MaxConcurrentBookings int      `json:"max_concurrent_bookings"`
+	QueuePassTTLMinutes   int      `json:"queue_pass_ttl_minutes"`
+	PassZoneIDs           []string `json:"pass_zone_ids,omitempty"` // Zones queue passes admit to (empty = all zones)
 }
 
 // JoinQueueParams contains parameters for joining a queue
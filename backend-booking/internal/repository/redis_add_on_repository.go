@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+//go:embed scripts/claim_add_ons.lua
+var claimAddOnsScript string
+
+//go:embed scripts/release_add_ons.lua
+var releaseAddOnsScript string
+
+// Script names for caching
+const (
+	scriptClaimAddOns   = "claim_add_ons"
+	scriptReleaseAddOns = "release_add_ons"
+)
+
+// addOnSoldKey returns the Redis key of the units sold per add-on of an event
+func addOnSoldKey(eventID string) string {
+	return fmt.Sprintf("event:add_ons:%s", eventID)
+}
+
+// addOnClaimsKey returns the Redis key of the add-ons claimed per reservation of an event
+func addOnClaimsKey(eventID string) string {
+	return fmt.Sprintf("event:add_on_claims:%s", eventID)
+}
+
+// RedisAddOnRepository implements AddOnRepository using Redis
+type RedisAddOnRepository struct {
+	client *pkgredis.Client
+}
+
+// NewRedisAddOnRepository creates a new RedisAddOnRepository
+func NewRedisAddOnRepository(client *pkgredis.Client) *RedisAddOnRepository {
+	return &RedisAddOnRepository{client: client}
+}
+
+// LoadScripts loads the add-on Lua scripts into Redis
+func (r *RedisAddOnRepository) LoadScripts(ctx context.Context) error {
+	scripts := map[string]string{
+		scriptClaimAddOns:   claimAddOnsScript,
+		scriptReleaseAddOns: releaseAddOnsScript,
+	}
+
+	for name, script := range scripts {
+		if _, err := r.client.LoadScript(ctx, name, script); err != nil {
+			return fmt.Errorf("failed to load script %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// ClaimAddOns counts a reservation's add-ons against their inventory, all or none
+func (r *RedisAddOnRepository) ClaimAddOns(ctx context.Context, eventID, bookingID string, claims []AddOnClaim) (string, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.add_on.claim")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("event_id", eventID),
+		attribute.String("booking_id", bookingID),
+		attribute.Int("add_ons", len(claims)),
+	)
+
+	args := make([]interface{}, 0, 1+3*len(claims))
+	args = append(args, bookingID)
+	for _, claim := range claims {
+		args = append(args, claim.AddOnID, claim.Quantity, claim.Inventory)
+	}
+
+	keys := []string{addOnSoldKey(eventID), addOnClaimsKey(eventID)}
+	values, err := r.client.EvalWithFallback(ctx, scriptClaimAddOns, claimAddOnsScript, keys, args...).Slice()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", fmt.Errorf("failed to execute claim_add_ons script: %w", err)
+	}
+	if len(values) < 2 {
+		span.SetStatus(codes.Error, "unexpected result length")
+		return "", fmt.Errorf("unexpected script result length: %d", len(values))
+	}
+
+	if claimed, _ := toInt64(values[0]); claimed != 1 {
+		soldOut, _ := values[1].(string)
+		span.SetAttributes(attribute.String("sold_out", soldOut))
+		span.SetStatus(codes.Ok, "sold out")
+		return soldOut, nil
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return "", nil
+}
+
+// ReleaseAddOns gives a reservation's add-ons back to their inventory
+func (r *RedisAddOnRepository) ReleaseAddOns(ctx context.Context, eventID, bookingID string) error {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.add_on.release")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("event_id", eventID),
+		attribute.String("booking_id", bookingID),
+	)
+
+	keys := []string{addOnSoldKey(eventID), addOnClaimsKey(eventID)}
+	if err := r.client.EvalWithFallback(ctx, scriptReleaseAddOns, releaseAddOnsScript, keys, bookingID).Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to execute release_add_ons script: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
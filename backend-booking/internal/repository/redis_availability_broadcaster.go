@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+)
+
+//go:embed scripts/flip_zone_availability.lua
+var flipZoneAvailabilityScript string
+
+const (
+	scriptFlipZoneAvailability = "flip_zone_availability"
+
+	// AvailabilityChannel is the Redis pub/sub channel zone availability flips are
+	// published on. Flips are rare, so one channel serves every event.
+	AvailabilityChannel = "zone:availability:flips"
+
+	// zoneAvailabilityLevelTTL bounds how long a zone's level is kept after its last flip
+	zoneAvailabilityLevelTTL = 24 * time.Hour
+
+	// maxTrackedZoneLevels bounds the levels remembered per instance
+	maxTrackedZoneLevels = 100000
+)
+
+// AvailabilityBroadcaster publishes zone availability flips
+type AvailabilityBroadcaster interface {
+	// Observe records a zone's seats after a change, publishing an event if its level flipped
+	Observe(ctx context.Context, eventID, zoneID string, available int64) error
+	// Subscribe delivers every published flip until close is called or ctx is done
+	Subscribe(ctx context.Context) (events <-chan *domain.ZoneAvailabilityEvent, close func() error)
+}
+
+// RedisAvailabilityBroadcaster implements AvailabilityBroadcaster using Redis pub/sub
+type RedisAvailabilityBroadcaster struct {
+	client            *pkgredis.Client
+	lowStockThreshold int64
+	now               func() time.Time
+
+	// levels is the last level this instance saw per zone; only a change costs a
+	// round trip, so reservations that keep a zone at its level stay free
+	mu     sync.Mutex
+	levels map[string]domain.ZoneAvailabilityLevel
+}
+
+// NewRedisAvailabilityBroadcaster creates a new RedisAvailabilityBroadcaster
+func NewRedisAvailabilityBroadcaster(client *pkgredis.Client, lowStockThreshold int64) *RedisAvailabilityBroadcaster {
+	return &RedisAvailabilityBroadcaster{
+		client:            client,
+		lowStockThreshold: lowStockThreshold,
+		now:               time.Now,
+		levels:            make(map[string]domain.ZoneAvailabilityLevel),
+	}
+}
+
+// Observe records a zone's seats after a change, publishing an event if its level flipped
+func (b *RedisAvailabilityBroadcaster) Observe(ctx context.Context, eventID, zoneID string, available int64) error {
+	level := domain.ZoneAvailabilityLevelFor(available, b.lowStockThreshold)
+
+	b.mu.Lock()
+	seen, ok := b.levels[zoneID]
+	b.mu.Unlock()
+	if ok && seen == level {
+		return nil
+	}
+
+	result := b.client.EvalWithFallback(ctx, scriptFlipZoneAvailability, flipZoneAvailabilityScript,
+		[]string{zoneAvailabilityLevelKey(zoneID)}, string(level), int(zoneAvailabilityLevelTTL.Seconds()))
+	previous, err := result.Text()
+	flipped := true
+	if err != nil {
+		if err.Error() != "redis: nil" {
+			return fmt.Errorf("failed to record zone availability level: %w", err)
+		}
+		flipped = false
+	}
+
+	b.mu.Lock()
+	if len(b.levels) >= maxTrackedZoneLevels {
+		b.levels = make(map[string]domain.ZoneAvailabilityLevel)
+	}
+	b.levels[zoneID] = level
+	b.mu.Unlock()
+
+	if !flipped {
+		return nil
+	}
+	event := domain.NewZoneAvailabilityEvent(eventID, zoneID, domain.ZoneAvailabilityLevel(previous), level, available, b.now())
+	if event == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal zone availability event: %w", err)
+	}
+	if err := b.client.Publish(ctx, AvailabilityChannel, data).Err(); err != nil {
+		return fmt.Errorf("failed to publish zone availability event: %w", err)
+	}
+	return nil
+}
+
+// Subscribe delivers every published flip until close is called or ctx is done.
+// Malformed messages are skipped.
+func (b *RedisAvailabilityBroadcaster) Subscribe(ctx context.Context) (<-chan *domain.ZoneAvailabilityEvent, func() error) {
+	pubsub := b.client.Subscribe(ctx, AvailabilityChannel)
+	messages := pubsub.Channel()
+	events := make(chan *domain.ZoneAvailabilityEvent, 64)
+
+	go func() {
+		defer close(events)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				var event domain.ZoneAvailabilityEvent
+				if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+					continue
+				}
+				select {
+				case events <- &event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events, pubsub.Close
+}
+
+// zoneAvailabilityLevelKey returns the key of a zone's last announced level
+func zoneAvailabilityLevelKey(zoneID string) string {
+	return fmt.Sprintf("zone:availability_level:%s", zoneID)
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+func TestRedisAvailabilityBroadcaster_SoldOutFlips(t *testing.T) {
+	skipIfNoIntegration(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := getRedisClient(t)
+	defer client.Close()
+
+	broadcaster := NewRedisAvailabilityBroadcaster(client, 2)
+	events, closeEvents := broadcaster.Subscribe(ctx)
+	defer closeEvents()
+
+	repo := NewRedisReservationRepository(client)
+	repo.SetAvailabilityBroadcaster(broadcaster)
+	if err := repo.LoadScripts(ctx); err != nil {
+		t.Fatalf("Failed to load scripts: %v", err)
+	}
+
+	zoneID := "zone-flip-test"
+	if err := repo.SetZoneAvailability(ctx, zoneID, 4); err != nil {
+		t.Fatalf("Failed to set zone availability: %v", err)
+	}
+
+	reserve := func(quantity int) *ReserveResult {
+		result, err := repo.ReserveSeats(ctx, ReserveParams{
+			ZoneID:     zoneID,
+			UserID:     "user-flip",
+			EventID:    "event-flip",
+			Quantity:   quantity,
+			TTLSeconds: 600,
+			Price:      100,
+		})
+		if err != nil || !result.Success {
+			t.Fatalf("Failed to reserve seats: %v, %+v", err, result)
+		}
+		return result
+	}
+
+	next := func(wantType string) {
+		t.Helper()
+		select {
+		case event := <-events:
+			if event.EventType != wantType || event.ZoneID != zoneID || event.EventID != "event-flip" {
+				t.Errorf("Expected %s for %s, got %+v", wantType, zoneID, event)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Timed out waiting for %s", wantType)
+		}
+	}
+
+	// 4 -> 3 stays available, 3 -> 2 is low, 2 -> 0 sells out
+	reserve(1)
+	reserve(1)
+	next(domain.ZoneLowStockEventType)
+	last := reserve(2)
+	next(domain.ZoneSoldOutEventType)
+
+	if _, err := repo.ReleaseSeats(ctx, last.BookingID, "user-flip"); err != nil {
+		t.Fatalf("ReleaseSeats() error = %v", err)
+	}
+	next(domain.ZoneBackInStockEventType)
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// Write-behind queue keys: booking IDs wait in the queue list and move to the
+// in-flight list while a worker persists them
+const (
+	persistQueueKey    = "booking:persist:queue"
+	persistInFlightKey = "booking:persist:inflight"
+)
+
+// persistPendingKey returns the key holding a booking that is not yet persisted
+func persistPendingKey(bookingID string) string {
+	return fmt.Sprintf("booking:pending:%s", bookingID)
+}
+
+// forgetIdempotencyScript deletes the idempotency index only while it still points
+// at the given booking
+const forgetIdempotencyScript = `
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+    return redis.call("DEL", KEYS[1])
+end
+return 0
+`
+
+// RedisBookingPersistQueue implements BookingPersistQueue using Redis lists for the
+// queue and one JSON value per pending booking
+type RedisBookingPersistQueue struct {
+	client *pkgredis.Client
+}
+
+// NewRedisBookingPersistQueue creates a new RedisBookingPersistQueue
+func NewRedisBookingPersistQueue(client *pkgredis.Client) *RedisBookingPersistQueue {
+	return &RedisBookingPersistQueue{client: client}
+}
+
+// Enqueue stores a pending booking and queues it for persistence
+func (q *RedisBookingPersistQueue) Enqueue(ctx context.Context, booking *domain.Booking, ttl time.Duration) error {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.booking_persist.enqueue")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("booking_id", booking.ID))
+
+	data, err := json.Marshal(booking)
+	if err != nil {
+		return fmt.Errorf("failed to marshal pending booking: %w", err)
+	}
+
+	// One round trip for both writes
+	pipe := q.client.TxPipeline()
+	pipe.Set(ctx, persistPendingKey(booking.ID), data, ttl)
+	pipe.LPush(ctx, persistQueueKey, booking.ID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to queue pending booking: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// Dequeue blocks up to wait for the next queued booking, nil if none arrived
+func (q *RedisBookingPersistQueue) Dequeue(ctx context.Context, wait time.Duration) (*domain.Booking, error) {
+	for {
+		bookingID, err := q.client.Client().BLMove(ctx, persistQueueKey, persistInFlightKey, "RIGHT", "LEFT", wait).Result()
+		if err != nil {
+			if err.Error() == "redis: nil" {
+				return nil, nil // Nothing queued within wait
+			}
+			return nil, fmt.Errorf("failed to dequeue pending booking: %w", err)
+		}
+
+		booking, err := q.GetPending(ctx, bookingID)
+		if err != nil {
+			return nil, err
+		}
+		if booking != nil {
+			return booking, nil
+		}
+		// Already persisted through a read, or expired while queued
+		if err := q.Ack(ctx, bookingID); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Ack removes a persisted booking from the queue
+func (q *RedisBookingPersistQueue) Ack(ctx context.Context, bookingID string) error {
+	pipe := q.client.TxPipeline()
+	pipe.LRem(ctx, persistInFlightKey, 0, bookingID)
+	pipe.Del(ctx, persistPendingKey(bookingID))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to ack pending booking: %w", err)
+	}
+	return nil
+}
+
+// Requeue puts an in-flight booking back on the queue after a failed attempt
+func (q *RedisBookingPersistQueue) Requeue(ctx context.Context, bookingID string) error {
+	pipe := q.client.TxPipeline()
+	pipe.LRem(ctx, persistInFlightKey, 0, bookingID)
+	pipe.LPush(ctx, persistQueueKey, bookingID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to requeue pending booking: %w", err)
+	}
+	return nil
+}
+
+// RecoverInFlight requeues bookings left in flight by a stopped worker. Bookings another
+// instance is still persisting may be persisted twice, which Persist tolerates.
+func (q *RedisBookingPersistQueue) RecoverInFlight(ctx context.Context) (int64, error) {
+	var recovered int64
+	for {
+		err := q.client.Client().LMove(ctx, persistInFlightKey, persistQueueKey, "RIGHT", "LEFT").Err()
+		if err != nil {
+			if err.Error() == "redis: nil" {
+				return recovered, nil
+			}
+			return recovered, fmt.Errorf("failed to recover in-flight bookings: %w", err)
+		}
+		recovered++
+	}
+}
+
+// GetPending retrieves a booking that is not yet persisted, nil if there is none
+func (q *RedisBookingPersistQueue) GetPending(ctx context.Context, bookingID string) (*domain.Booking, error) {
+	data, err := q.client.Get(ctx, persistPendingKey(bookingID)).Bytes()
+	if err != nil {
+		if err.Error() == "redis: nil" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get pending booking: %w", err)
+	}
+
+	var booking domain.Booking
+	if err := json.Unmarshal(data, &booking); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal pending booking: %w", err)
+	}
+	return &booking, nil
+}
+
+// ForgetIdempotencyKey drops the idempotency index of a reservation that will never be persisted
+func (q *RedisBookingPersistQueue) ForgetIdempotencyKey(ctx context.Context, key, bookingID string) error {
+	if key == "" {
+		return nil
+	}
+	if err := q.client.Eval(ctx, forgetIdempotencyScript, []string{bookingIdempotencyKey(key)}, bookingID).Err(); err != nil {
+		return fmt.Errorf("failed to forget idempotency key: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// cartKey returns the key holding a user's cart
+func cartKey(userID string) string {
+	return fmt.Sprintf("cart:%s", userID)
+}
+
+// checkoutKey returns the key holding a checkout
+func checkoutKey(checkoutID string) string {
+	return fmt.Sprintf("checkout:%s", checkoutID)
+}
+
+// RedisCartRepository implements CartRepository with one JSON value per cart and checkout
+type RedisCartRepository struct {
+	client *pkgredis.Client
+}
+
+// NewRedisCartRepository creates a new RedisCartRepository
+func NewRedisCartRepository(client *pkgredis.Client) *RedisCartRepository {
+	return &RedisCartRepository{client: client}
+}
+
+// GetCart retrieves the user's cart, nil if the user has none
+func (r *RedisCartRepository) GetCart(ctx context.Context, userID string) (*domain.Cart, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.cart.get")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", userID))
+
+	var cart domain.Cart
+	found, err := r.getJSON(ctx, cartKey(userID), &cart)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to get cart: %w", err)
+	}
+	span.SetStatus(codes.Ok, "")
+	if !found {
+		return nil, nil
+	}
+	return &cart, nil
+}
+
+// SaveCart stores the user's cart, refreshing its TTL
+func (r *RedisCartRepository) SaveCart(ctx context.Context, cart *domain.Cart, ttl time.Duration) error {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.cart.save")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", cart.UserID),
+		attribute.Int("items", len(cart.Items)),
+	)
+
+	if err := r.setJSON(ctx, cartKey(cart.UserID), cart, ttl); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to save cart: %w", err)
+	}
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// DeleteCart removes the user's cart
+func (r *RedisCartRepository) DeleteCart(ctx context.Context, userID string) error {
+	if err := r.client.Del(ctx, cartKey(userID)).Err(); err != nil {
+		return fmt.Errorf("failed to delete cart: %w", err)
+	}
+	return nil
+}
+
+// SaveCheckout stores a checkout
+func (r *RedisCartRepository) SaveCheckout(ctx context.Context, checkout *domain.Checkout, ttl time.Duration) error {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.checkout.save")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("checkout_id", checkout.ID),
+		attribute.String("status", checkout.Status.String()),
+	)
+
+	if err := r.setJSON(ctx, checkoutKey(checkout.ID), checkout, ttl); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to save checkout: %w", err)
+	}
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// GetCheckout retrieves a checkout by ID, nil if it does not exist
+func (r *RedisCartRepository) GetCheckout(ctx context.Context, checkoutID string) (*domain.Checkout, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.checkout.get")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("checkout_id", checkoutID))
+
+	var checkout domain.Checkout
+	found, err := r.getJSON(ctx, checkoutKey(checkoutID), &checkout)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to get checkout: %w", err)
+	}
+	span.SetStatus(codes.Ok, "")
+	if !found {
+		return nil, nil
+	}
+	return &checkout, nil
+}
+
+// getJSON decodes the value at key into v, reporting false if the key does not exist
+func (r *RedisCartRepository) getJSON(ctx context.Context, key string, v interface{}) (bool, error) {
+	data, err := r.client.Get(ctx, key).Bytes()
+	if err != nil {
+		if err.Error() == "redis: nil" {
+			return false, nil
+		}
+		return false, err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// setJSON stores v as JSON at key
+func (r *RedisCartRepository) setJSON(ctx context.Context, key string, v interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, key, data, ttl).Err()
+}
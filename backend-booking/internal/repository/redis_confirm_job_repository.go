@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// confirmQueueKey is the list of booking IDs waiting to be confirmed
+const confirmQueueKey = "confirm:queue"
+
+// confirmJobKey returns the key holding a booking's confirm job
+func confirmJobKey(bookingID string) string {
+	return fmt.Sprintf("confirm:job:%s", bookingID)
+}
+
+// RedisConfirmJobRepository implements ConfirmJobRepository using a Redis list as the
+// queue and one JSON value per booking for the job status
+type RedisConfirmJobRepository struct {
+	client *pkgredis.Client
+}
+
+// NewRedisConfirmJobRepository creates a new RedisConfirmJobRepository
+func NewRedisConfirmJobRepository(client *pkgredis.Client) *RedisConfirmJobRepository {
+	return &RedisConfirmJobRepository{client: client}
+}
+
+// Create stores a new job and queues it, unless the booking already has a job
+func (r *RedisConfirmJobRepository) Create(ctx context.Context, job *domain.ConfirmJob, ttl time.Duration) (bool, *domain.ConfirmJob, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.confirm_job.create")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("booking_id", job.BookingID))
+
+	data, err := json.Marshal(job)
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to marshal confirm job: %w", err)
+	}
+
+	created, err := r.client.SetNX(ctx, confirmJobKey(job.BookingID), data, ttl).Result()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return false, nil, fmt.Errorf("failed to store confirm job: %w", err)
+	}
+	if !created {
+		existing, err := r.GetByBookingID(ctx, job.BookingID)
+		if err != nil {
+			return false, nil, err
+		}
+		if existing != nil {
+			span.SetAttributes(attribute.Bool("existing", true))
+			span.SetStatus(codes.Ok, "")
+			return false, existing, nil
+		}
+		// Expired between SETNX and GET; take the slot
+		return true, nil, r.Requeue(ctx, job, ttl)
+	}
+
+	if err := r.client.LPush(ctx, confirmQueueKey, job.BookingID).Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		// Drop the job so the client can resubmit instead of polling a job no worker will see
+		r.client.Del(ctx, confirmJobKey(job.BookingID))
+		return false, nil, fmt.Errorf("failed to queue confirm job: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return true, nil, nil
+}
+
+// Requeue replaces the booking's job and queues it again
+func (r *RedisConfirmJobRepository) Requeue(ctx context.Context, job *domain.ConfirmJob, ttl time.Duration) error {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.confirm_job.requeue")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("booking_id", job.BookingID))
+
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to marshal confirm job: %w", err)
+	}
+
+	pipe := r.client.TxPipeline()
+	pipe.Set(ctx, confirmJobKey(job.BookingID), data, ttl)
+	pipe.LPush(ctx, confirmQueueKey, job.BookingID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to requeue confirm job: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// Dequeue blocks up to wait for the next queued job, nil if none arrived
+func (r *RedisConfirmJobRepository) Dequeue(ctx context.Context, wait time.Duration) (*domain.ConfirmJob, error) {
+	for {
+		values, err := r.client.Client().BRPop(ctx, wait, confirmQueueKey).Result()
+		if err != nil {
+			if err.Error() == "redis: nil" {
+				return nil, nil // Nothing queued within wait
+			}
+			return nil, fmt.Errorf("failed to dequeue confirm job: %w", err)
+		}
+		if len(values) != 2 {
+			return nil, fmt.Errorf("unexpected BRPOP result: %v", values)
+		}
+
+		job, err := r.GetByBookingID(ctx, values[1])
+		if err != nil {
+			return nil, err
+		}
+		// Skip bookings whose job expired while queued
+		if job != nil {
+			return job, nil
+		}
+	}
+}
+
+// Save updates the job's status
+func (r *RedisConfirmJobRepository) Save(ctx context.Context, job *domain.ConfirmJob, ttl time.Duration) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to marshal confirm job: %w", err)
+	}
+	if err := r.client.Set(ctx, confirmJobKey(job.BookingID), data, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to save confirm job: %w", err)
+	}
+	return nil
+}
+
+// GetByBookingID retrieves the booking's job, nil if it has none
+func (r *RedisConfirmJobRepository) GetByBookingID(ctx context.Context, bookingID string) (*domain.ConfirmJob, error) {
+	data, err := r.client.Get(ctx, confirmJobKey(bookingID)).Bytes()
+	if err != nil {
+		if err.Error() == "redis: nil" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get confirm job: %w", err)
+	}
+
+	var job domain.ConfirmJob
+	if err := json.Unmarshal(data, &job); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal confirm job: %w", err)
+	}
+	return &job, nil
+}
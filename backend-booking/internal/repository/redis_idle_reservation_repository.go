@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+)
+
+// idlePaymentStartedKey returns the key marking that a payment was created for a booking
+func idlePaymentStartedKey(bookingID string) string {
+	return fmt.Sprintf("booking:idle:payment:%s", bookingID)
+}
+
+// idleRemindedKey returns the key marking that the holder of a booking was reminded
+func idleRemindedKey(bookingID string) string {
+	return fmt.Sprintf("booking:idle:reminded:%s", bookingID)
+}
+
+// RedisIdleReservationRepository implements IdleReservationRepository using Redis
+type RedisIdleReservationRepository struct {
+	client *pkgredis.Client
+}
+
+// NewRedisIdleReservationRepository creates a new RedisIdleReservationRepository
+func NewRedisIdleReservationRepository(client *pkgredis.Client) *RedisIdleReservationRepository {
+	return &RedisIdleReservationRepository{client: client}
+}
+
+// MarkPaymentStarted records that a payment was created for the booking
+func (r *RedisIdleReservationRepository) MarkPaymentStarted(ctx context.Context, bookingID string, ttl time.Duration) error {
+	if err := r.client.Set(ctx, idlePaymentStartedKey(bookingID), 1, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to mark payment started: %w", err)
+	}
+	return nil
+}
+
+// PaymentStarted returns the bookings of bookingIDs a payment was created for
+func (r *RedisIdleReservationRepository) PaymentStarted(ctx context.Context, bookingIDs []string) (map[string]bool, error) {
+	started := make(map[string]bool)
+	if len(bookingIDs) == 0 {
+		return started, nil
+	}
+
+	pipe := r.client.Pipeline()
+	cmds := make(map[string]interface{ Val() int64 }, len(bookingIDs))
+	for _, id := range bookingIDs {
+		cmds[id] = pipe.Exists(ctx, idlePaymentStartedKey(id))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to check payments started: %w", err)
+	}
+	for id, cmd := range cmds {
+		if cmd.Val() > 0 {
+			started[id] = true
+		}
+	}
+	return started, nil
+}
+
+// MarkReminded records that the holder of the booking was reminded.
+// Returns false if they already were.
+func (r *RedisIdleReservationRepository) MarkReminded(ctx context.Context, bookingID string, ttl time.Duration) (bool, error) {
+	marked, err := r.client.SetNX(ctx, idleRemindedKey(bookingID), 1, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to mark reservation reminded: %w", err)
+	}
+	return marked, nil
+}
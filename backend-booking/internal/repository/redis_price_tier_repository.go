@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+//go:embed scripts/claim_price_tier.lua
+var claimPriceTierScript string
+
+//go:embed scripts/release_price_tier.lua
+var releasePriceTierScript string
+
+// Script names for caching
+const (
+	scriptClaimPriceTier   = "claim_price_tier"
+	scriptReleasePriceTier = "release_price_tier"
+)
+
+// priceTierSoldKey returns the Redis key of the seats sold per price tier of a zone
+func priceTierSoldKey(zoneID string) string {
+	return fmt.Sprintf("zone:price_tiers:%s", zoneID)
+}
+
+// priceTierClaimsKey returns the Redis key of the tier claimed per reservation of a zone
+func priceTierClaimsKey(zoneID string) string {
+	return fmt.Sprintf("zone:price_tier_claims:%s", zoneID)
+}
+
+// RedisPriceTierRepository implements PriceTierRepository using Redis
+type RedisPriceTierRepository struct {
+	client *pkgredis.Client
+}
+
+// NewRedisPriceTierRepository creates a new RedisPriceTierRepository
+func NewRedisPriceTierRepository(client *pkgredis.Client) *RedisPriceTierRepository {
+	return &RedisPriceTierRepository{client: client}
+}
+
+// LoadScripts loads the price tier Lua scripts into Redis
+func (r *RedisPriceTierRepository) LoadScripts(ctx context.Context) error {
+	scripts := map[string]string{
+		scriptClaimPriceTier:   claimPriceTierScript,
+		scriptReleasePriceTier: releasePriceTierScript,
+	}
+
+	for name, script := range scripts {
+		if _, err := r.client.LoadScript(ctx, name, script); err != nil {
+			return fmt.Errorf("failed to load script %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// ClaimTier counts a reservation's seats against the first tier with room left
+func (r *RedisPriceTierRepository) ClaimTier(ctx context.Context, zoneID, bookingID string, quantity int, tiers []PriceTierAllocation) (string, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.price_tier.claim")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("zone_id", zoneID),
+		attribute.String("booking_id", bookingID),
+		attribute.Int("quantity", quantity),
+		attribute.Int("tiers", len(tiers)),
+	)
+
+	args := make([]interface{}, 0, 2+2*len(tiers))
+	args = append(args, bookingID, quantity)
+	for _, tier := range tiers {
+		args = append(args, tier.TierID, tier.Allocation)
+	}
+
+	keys := []string{priceTierSoldKey(zoneID), priceTierClaimsKey(zoneID)}
+	values, err := r.client.EvalWithFallback(ctx, scriptClaimPriceTier, claimPriceTierScript, keys, args...).Slice()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", fmt.Errorf("failed to execute claim_price_tier script: %w", err)
+	}
+	if len(values) < 2 {
+		span.SetStatus(codes.Error, "unexpected result length")
+		return "", fmt.Errorf("unexpected script result length: %d", len(values))
+	}
+
+	tierID, _ := values[0].(string)
+	sold, _ := toInt64(values[1])
+	span.SetAttributes(attribute.String("tier_id", tierID), attribute.Int64("sold", sold))
+	span.SetStatus(codes.Ok, "")
+	return tierID, nil
+}
+
+// ReleaseTier gives a reservation's seats back to the tier it claimed
+func (r *RedisPriceTierRepository) ReleaseTier(ctx context.Context, zoneID, bookingID string) error {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.price_tier.release")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("zone_id", zoneID),
+		attribute.String("booking_id", bookingID),
+	)
+
+	keys := []string{priceTierSoldKey(zoneID), priceTierClaimsKey(zoneID)}
+	if err := r.client.EvalWithFallback(ctx, scriptReleasePriceTier, releasePriceTierScript, keys, bookingID).Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to execute release_price_tier script: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
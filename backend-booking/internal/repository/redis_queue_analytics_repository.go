@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+)
+
+// queueAnalyticsRetention is how long a bucket is kept, the longest readable window plus a day
+const queueAnalyticsRetention = domain.QueueAnalyticsMaxWindow + 24*time.Hour
+
+// queueAnalyticsDepthField is the bucket hash field holding the sampled queue size
+const queueAnalyticsDepthField = "depth"
+
+// queueAnalyticsKey returns the hash holding one bucket of an event's funnel counts.
+// Kept out of the "queue:" namespace, which the zset backend scans for event queues.
+func queueAnalyticsKey(eventID string, bucket time.Time) string {
+	return fmt.Sprintf("qanalytics:%s:%d", eventID, bucket.Unix())
+}
+
+// incrQueueAnalyticsScript increments a field of a bucket and refreshes its expiry.
+// KEYS[1] = bucket key; ARGV = field, increment, ttl seconds
+const incrQueueAnalyticsScript = `
+redis.call("HINCRBY", KEYS[1], ARGV[1], ARGV[2])
+redis.call("EXPIRE", KEYS[1], ARGV[3])
+return 1
+`
+
+// setQueueAnalyticsScript sets a field of a bucket and refreshes its expiry.
+// KEYS[1] = bucket key; ARGV = field, value, ttl seconds
+const setQueueAnalyticsScript = `
+redis.call("HSET", KEYS[1], ARGV[1], ARGV[2])
+redis.call("EXPIRE", KEYS[1], ARGV[3])
+return 1
+`
+
+// getQueueAnalyticsScript returns the fields of every bucket, in key order.
+// KEYS = bucket keys
+const getQueueAnalyticsScript = `
+local result = {}
+for i, key in ipairs(KEYS) do
+	result[i] = redis.call("HGETALL", key)
+end
+return result
+`
+
+// RedisQueueAnalyticsRepository implements QueueAnalyticsRepository using Redis hashes
+type RedisQueueAnalyticsRepository struct {
+	client *pkgredis.Client
+}
+
+// NewRedisQueueAnalyticsRepository creates a new RedisQueueAnalyticsRepository
+func NewRedisQueueAnalyticsRepository(client *pkgredis.Client) *RedisQueueAnalyticsRepository {
+	return &RedisQueueAnalyticsRepository{client: client}
+}
+
+// IncrStep adds count to a funnel step in a bucket
+func (r *RedisQueueAnalyticsRepository) IncrStep(ctx context.Context, eventID string, step domain.QueueFunnelStep, bucket time.Time, count int64) error {
+	key := queueAnalyticsKey(eventID, bucket)
+	ttl := int64(queueAnalyticsRetention.Seconds())
+	if err := r.client.Eval(ctx, incrQueueAnalyticsScript, []string{key}, string(step), count, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to record queue %s: %w", step, err)
+	}
+	return nil
+}
+
+// SetDepth records the queue size sampled in a bucket
+func (r *RedisQueueAnalyticsRepository) SetDepth(ctx context.Context, eventID string, bucket time.Time, depth int64) error {
+	key := queueAnalyticsKey(eventID, bucket)
+	ttl := int64(queueAnalyticsRetention.Seconds())
+	if err := r.client.Eval(ctx, setQueueAnalyticsScript, []string{key}, queueAnalyticsDepthField, depth, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to record queue depth: %w", err)
+	}
+	return nil
+}
+
+// GetBuckets returns n consecutive buckets starting at from, oldest first
+func (r *RedisQueueAnalyticsRepository) GetBuckets(ctx context.Context, eventID string, from time.Time, n int) ([]*domain.QueueAnalyticsBucket, error) {
+	if n <= 0 {
+		return []*domain.QueueAnalyticsBucket{}, nil
+	}
+
+	buckets := make([]*domain.QueueAnalyticsBucket, n)
+	keys := make([]string, n)
+	for i := range buckets {
+		start := from.Add(time.Duration(i) * domain.QueueAnalyticsBucketSize)
+		buckets[i] = domain.NewQueueAnalyticsBucket(start)
+		keys[i] = queueAnalyticsKey(eventID, start)
+	}
+
+	result, err := r.client.Eval(ctx, getQueueAnalyticsScript, keys).Slice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get queue analytics: %w", err)
+	}
+
+	for i, raw := range result {
+		if i >= n {
+			break
+		}
+		fields, ok := raw.([]interface{})
+		if !ok {
+			continue
+		}
+		for j := 0; j+1 < len(fields); j += 2 {
+			name, _ := fields[j].(string)
+			value, _ := fields[j+1].(string)
+			parsed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid queue analytics %s=%q: %w", name, value, err)
+			}
+			if name == queueAnalyticsDepthField {
+				buckets[i].Depth = parsed
+				continue
+			}
+			buckets[i].Counts[domain.QueueFunnelStep(name)] = parsed
+		}
+	}
+
+	return buckets, nil
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+)
+
+// queuePassRevokedIndexKey is a sorted set of revoked entries scored by expiry, so
+// booking-service instances can load every live revocation in one call
+const queuePassRevokedIndexKey = "queue:pass:revoked"
+
+// queuePassRevokedKey returns the key holding the revocation time of an entry
+func queuePassRevokedKey(entry string) string {
+	return fmt.Sprintf("queue:pass:revoked:%s", entry)
+}
+
+// revokeQueuePassScript stores the revocation time and indexes the entry by expiry.
+// KEYS[1] = entry key, KEYS[2] = index; ARGV = entry, revoked_at, ttl seconds, expires_at
+const revokeQueuePassScript = `
+redis.call("SET", KEYS[1], ARGV[2], "EX", ARGV[3])
+redis.call("ZADD", KEYS[2], ARGV[4], ARGV[1])
+return 1
+`
+
+// listRevokedQueuePassesScript drops expired entries from the index and returns the rest.
+// KEYS[1] = index; ARGV[1] = now
+const listRevokedQueuePassesScript = `
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", ARGV[1])
+return redis.call("ZRANGE", KEYS[1], 0, -1)
+`
+
+// RedisQueuePassRevocationRepository implements QueuePassRevocationRepository using Redis
+type RedisQueuePassRevocationRepository struct {
+	client *pkgredis.Client
+}
+
+// NewRedisQueuePassRevocationRepository creates a new RedisQueuePassRevocationRepository
+func NewRedisQueuePassRevocationRepository(client *pkgredis.Client) *RedisQueuePassRevocationRepository {
+	return &RedisQueuePassRevocationRepository{client: client}
+}
+
+// Revoke records a revocation that lasts for ttl
+func (r *RedisQueuePassRevocationRepository) Revoke(ctx context.Context, entry string, revokedAt time.Time, ttl time.Duration) error {
+	keys := []string{queuePassRevokedKey(entry), queuePassRevokedIndexKey}
+	seconds := int64(ttl.Seconds())
+	if seconds < 1 {
+		seconds = 1
+	}
+	expiresAt := revokedAt.Unix() + seconds
+	if err := r.client.Eval(ctx, revokeQueuePassScript, keys, entry, revokedAt.UnixMilli(), seconds, expiresAt).Err(); err != nil {
+		return fmt.Errorf("failed to revoke queue pass: %w", err)
+	}
+	return nil
+}
+
+// GetRevokedAt returns when an entry was revoked, false if it is not revoked
+func (r *RedisQueuePassRevocationRepository) GetRevokedAt(ctx context.Context, entry string) (time.Time, bool, error) {
+	value, err := r.client.Get(ctx, queuePassRevokedKey(entry)).Result()
+	if err != nil {
+		if err.Error() == "redis: nil" {
+			return time.Time{}, false, nil
+		}
+		return time.Time{}, false, fmt.Errorf("failed to get queue pass revocation: %w", err)
+	}
+	millis, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid queue pass revocation %q: %w", value, err)
+	}
+	return time.UnixMilli(millis), true, nil
+}
+
+// ListRevoked returns all entries that have not expired
+func (r *RedisQueuePassRevocationRepository) ListRevoked(ctx context.Context) ([]string, error) {
+	result, err := r.client.Eval(ctx, listRevokedQueuePassesScript, []string{queuePassRevokedIndexKey}, time.Now().Unix()).StringSlice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list queue pass revocations: %w", err)
+	}
+	return result, nil
+}
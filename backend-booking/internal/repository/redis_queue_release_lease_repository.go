@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+)
+
+// queueReleaseLeaseKey returns the key holding the owner of an event's release lease
+func queueReleaseLeaseKey(eventID string) string {
+	return fmt.Sprintf("queue:release:lease:%s", eventID)
+}
+
+// acquireQueueReleaseLeaseScript renews the lease when owner holds it, otherwise takes it
+// only when it is free. KEYS[1] = lease key; ARGV = owner, ttl milliseconds
+const acquireQueueReleaseLeaseScript = `
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+	return 1
+end
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	return 1
+end
+return 0
+`
+
+// releaseQueueReleaseLeaseScript deletes the lease only when owner still holds it.
+// KEYS[1] = lease key; ARGV[1] = owner
+const releaseQueueReleaseLeaseScript = `
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`
+
+// RedisQueueReleaseLeaseRepository implements QueueReleaseLeaseRepository with one
+// SET NX PX key per event, owned by a random token per worker replica
+type RedisQueueReleaseLeaseRepository struct {
+	client *pkgredis.Client
+}
+
+// NewRedisQueueReleaseLeaseRepository creates a new RedisQueueReleaseLeaseRepository
+func NewRedisQueueReleaseLeaseRepository(client *pkgredis.Client) *RedisQueueReleaseLeaseRepository {
+	return &RedisQueueReleaseLeaseRepository{client: client}
+}
+
+// Acquire takes the event's lease for owner, or renews it when owner already holds it
+func (r *RedisQueueReleaseLeaseRepository) Acquire(ctx context.Context, eventID, owner string, ttl time.Duration) (bool, error) {
+	millis := ttl.Milliseconds()
+	if millis < 1 {
+		millis = 1
+	}
+	acquired, err := r.client.Eval(ctx, acquireQueueReleaseLeaseScript, []string{queueReleaseLeaseKey(eventID)}, owner, millis).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire queue release lease: %w", err)
+	}
+	return acquired == 1, nil
+}
+
+// Release gives up the event's lease, unless it has already passed to another owner
+func (r *RedisQueueReleaseLeaseRepository) Release(ctx context.Context, eventID, owner string) error {
+	if err := r.client.Eval(ctx, releaseQueueReleaseLeaseScript, []string{queueReleaseLeaseKey(eventID)}, owner).Err(); err != nil {
+		return fmt.Errorf("failed to release queue release lease: %w", err)
+	}
+	return nil
+}
@@ -2,8 +2,8 @@ package repository
 
 import (
 	"context"
-	_ "embed"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
@@ -13,38 +13,29 @@ import (
 	"go.opentelemetry.io/otel/codes"
 )
 
-//go:embed scripts/join_queue.lua
-var joinQueueScript string
-
-// Script name for caching
-const scriptJoinQueue = "join_queue"
-
-// RedisQueueRepository implements QueueRepository using Redis
+// RedisQueueRepository implements QueueRepository using Redis. The queue order lives in a
+// QueueBackend; user queue info, queue passes and event config are plain Redis keys.
 type RedisQueueRepository struct {
-	client *pkgredis.Client
+	client  *pkgredis.Client
+	backend QueueBackend
 }
 
-// NewRedisQueueRepository creates a new RedisQueueRepository
+// NewRedisQueueRepository creates a new RedisQueueRepository backed by Sorted Sets
 func NewRedisQueueRepository(client *pkgredis.Client) *RedisQueueRepository {
-	return &RedisQueueRepository{client: client}
+	return NewRedisQueueRepositoryWithBackend(client, NewRedisZSetQueueBackend(client))
+}
+
+// NewRedisQueueRepositoryWithBackend creates a new RedisQueueRepository using backend for queue order
+func NewRedisQueueRepositoryWithBackend(client *pkgredis.Client, backend QueueBackend) *RedisQueueRepository {
+	return &RedisQueueRepository{client: client, backend: backend}
 }
 
 // LoadScripts loads all queue Lua scripts into Redis
 func (r *RedisQueueRepository) LoadScripts(ctx context.Context) error {
-	scripts := map[string]string{
-		scriptJoinQueue: joinQueueScript,
-	}
-
-	for name, script := range scripts {
-		if _, err := r.client.LoadScript(ctx, name, script); err != nil {
-			return fmt.Errorf("failed to load script %s: %w", name, err)
-		}
-	}
-
-	return nil
+	return r.backend.LoadScripts(ctx)
 }
 
-// JoinQueue adds a user to the queue using Sorted Set
+// JoinQueue adds a user to the queue
 func (r *RedisQueueRepository) JoinQueue(ctx context.Context, params JoinQueueParams) (*JoinQueueResult, error) {
 	ctx, span := telemetry.StartSpan(ctx, "repo.redis.queue.join")
 	defer span.End()
@@ -52,69 +43,29 @@ func (r *RedisQueueRepository) JoinQueue(ctx context.Context, params JoinQueuePa
 	span.SetAttributes(
 		attribute.String("event_id", params.EventID),
 		attribute.String("user_id", params.UserID),
+		attribute.String("queue_backend", r.backend.Name()),
 	)
 
-	// Build Redis keys
-	queueKey := fmt.Sprintf("queue:%s", params.EventID)
-	userQueueKey := fmt.Sprintf("queue:user:%s:%s", params.EventID, params.UserID)
-
-	keys := []string{queueKey, userQueueKey}
-	args := []interface{}{
-		params.UserID,       // ARGV[1]: user_id
-		params.EventID,      // ARGV[2]: event_id
-		params.Token,        // ARGV[3]: token
-		params.TTLSeconds,   // ARGV[4]: ttl_seconds
-		params.MaxQueueSize, // ARGV[5]: max_queue_size
-	}
-
-	result := r.client.EvalWithFallback(ctx, scriptJoinQueue, joinQueueScript, keys, args...)
-	if result.Err() != nil {
-		span.RecordError(result.Err())
-		span.SetStatus(codes.Error, result.Err().Error())
-		return nil, fmt.Errorf("failed to execute join_queue script: %w", result.Err())
-	}
-
-	// Parse result
-	values, err := result.Slice()
+	result, err := r.backend.Join(ctx, params)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
-		return nil, fmt.Errorf("failed to parse script result: %w", err)
-	}
-
-	if len(values) < 3 {
-		span.SetStatus(codes.Error, "unexpected result length")
-		return nil, fmt.Errorf("unexpected script result length: %d", len(values))
+		return nil, err
 	}
 
-	success, _ := toInt64(values[0])
-	if success == 1 {
-		position, _ := toInt64(values[1])
-		totalInQueue, _ := toInt64(values[2])
-		joinedAt, _ := toFloat64(values[3])
+	if result.Success {
 		span.SetAttributes(
-			attribute.Int64("position", position),
-			attribute.Int64("total_in_queue", totalInQueue),
+			attribute.Int64("position", result.Position),
+			attribute.Int64("total_in_queue", result.TotalInQueue),
 		)
 		span.SetStatus(codes.Ok, "")
-		return &JoinQueueResult{
-			Success:      true,
-			Position:     position,
-			TotalInQueue: totalInQueue,
-			JoinedAt:     joinedAt,
-		}, nil
+		return result, nil
 	}
 
 	// Error case
-	errorCode, _ := values[1].(string)
-	errorMessage, _ := values[2].(string)
-	span.SetAttributes(attribute.String("error_code", errorCode))
-	span.SetStatus(codes.Error, errorCode)
-	return &JoinQueueResult{
-		Success:      false,
-		ErrorCode:    errorCode,
-		ErrorMessage: errorMessage,
-	}, nil
+	span.SetAttributes(attribute.String("error_code", result.ErrorCode))
+	span.SetStatus(codes.Error, result.ErrorCode)
+	return result, nil
 }
 
 // GetPosition gets the user's current position in queue
@@ -127,43 +78,24 @@ func (r *RedisQueueRepository) GetPosition(ctx context.Context, eventID, userID
 		attribute.String("user_id", userID),
 	)
 
-	queueKey := fmt.Sprintf("queue:%s", eventID)
-
-	// Get user's rank in sorted set (0-indexed)
-	rank, err := r.client.ZRank(ctx, queueKey, userID).Result()
+	result, err := r.backend.Position(ctx, eventID, userID)
 	if err != nil {
-		// User not in queue
-		if err.Error() == "redis: nil" {
-			span.SetStatus(codes.Ok, "not in queue")
-			return &QueuePositionResult{
-				Position:     0,
-				TotalInQueue: 0,
-				IsInQueue:    false,
-			}, nil
-		}
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
-		return nil, fmt.Errorf("failed to get queue position: %w", err)
+		return nil, err
 	}
 
-	// Get total count
-	total, err := r.client.ZCard(ctx, queueKey).Result()
-	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
-		return nil, fmt.Errorf("failed to get queue size: %w", err)
+	if !result.IsInQueue {
+		span.SetStatus(codes.Ok, "not in queue")
+		return result, nil
 	}
 
 	span.SetAttributes(
-		attribute.Int64("position", rank+1),
-		attribute.Int64("total_in_queue", total),
+		attribute.Int64("position", result.Position),
+		attribute.Int64("total_in_queue", result.TotalInQueue),
 	)
 	span.SetStatus(codes.Ok, "")
-	return &QueuePositionResult{
-		Position:     rank + 1, // Convert to 1-indexed
-		TotalInQueue: total,
-		IsInQueue:    true,
-	}, nil
+	return result, nil
 }
 
 // LeaveQueue removes a user from the queue
@@ -177,7 +109,7 @@ func (r *RedisQueueRepository) LeaveQueue(ctx context.Context, eventID, userID,
 	)
 
 	// First verify the token
-	userQueueKey := fmt.Sprintf("queue:user:%s:%s", eventID, userID)
+	userQueueKey := queueUserKey(eventID, userID)
 	storedToken, err := r.client.HGet(ctx, userQueueKey, "token").Result()
 	if err != nil {
 		if err.Error() == "redis: nil" {
@@ -194,13 +126,11 @@ func (r *RedisQueueRepository) LeaveQueue(ctx context.Context, eventID, userID,
 		return domain.ErrInvalidQueueToken
 	}
 
-	// Remove from sorted set
-	queueKey := fmt.Sprintf("queue:%s", eventID)
-	removed, err := r.client.ZRem(ctx, queueKey, userID).Result()
+	removed, err := r.backend.Remove(ctx, eventID, userID)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
-		return fmt.Errorf("failed to remove from queue: %w", err)
+		return err
 	}
 
 	if removed == 0 {
@@ -222,12 +152,11 @@ func (r *RedisQueueRepository) GetQueueSize(ctx context.Context, eventID string)
 
 	span.SetAttributes(attribute.String("event_id", eventID))
 
-	queueKey := fmt.Sprintf("queue:%s", eventID)
-	count, err := r.client.ZCard(ctx, queueKey).Result()
+	count, err := r.backend.Size(ctx, eventID)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
-		return 0, fmt.Errorf("failed to get queue size: %w", err)
+		return 0, err
 	}
 
 	span.SetAttributes(attribute.Int64("count", count))
@@ -237,8 +166,7 @@ func (r *RedisQueueRepository) GetQueueSize(ctx context.Context, eventID string)
 
 // GetUserQueueInfo gets the user's queue info (token, joined_at, etc.)
 func (r *RedisQueueRepository) GetUserQueueInfo(ctx context.Context, eventID, userID string) (map[string]string, error) {
-	userQueueKey := fmt.Sprintf("queue:user:%s:%s", eventID, userID)
-	result, err := r.client.HGetAll(ctx, userQueueKey).Result()
+	result, err := r.client.HGetAll(ctx, queueUserKey(eventID, userID)).Result()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user queue info: %w", err)
 	}
@@ -315,29 +243,16 @@ func (r *RedisQueueRepository) DeleteQueuePass(ctx context.Context, eventID, use
 	return nil
 }
 
-// PopUsersFromQueue pops the first N users from the queue (lowest scores = earliest joined)
+// PopUsersFromQueue pops the first N users from the queue (earliest joined)
 func (r *RedisQueueRepository) PopUsersFromQueue(ctx context.Context, eventID string, count int64) ([]string, error) {
-	queueKey := fmt.Sprintf("queue:%s", eventID)
-
-	// Get users with lowest scores (earliest joined)
-	result, err := r.client.ZRange(ctx, queueKey, 0, count-1).Result()
+	result, err := r.backend.Pop(ctx, eventID, count)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get users from queue: %w", err)
-	}
-
-	if len(result) == 0 {
-		return []string{}, nil
-	}
-
-	// Remove the users from the sorted set
-	if _, err := r.client.ZRem(ctx, queueKey, stringSliceToInterface(result)...).Result(); err != nil {
-		return nil, fmt.Errorf("failed to remove users from queue: %w", err)
+		return nil, err
 	}
 
 	// Clean up user queue info for each user
 	for _, userID := range result {
-		userQueueKey := fmt.Sprintf("queue:user:%s:%s", eventID, userID)
-		r.client.Del(ctx, userQueueKey)
+		r.client.Del(ctx, queueUserKey(eventID, userID))
 	}
 
 	return result, nil
@@ -345,53 +260,17 @@ func (r *RedisQueueRepository) PopUsersFromQueue(ctx context.Context, eventID st
 
 // GetAllQueueEventIDs returns all event IDs that have active queues
 func (r *RedisQueueRepository) GetAllQueueEventIDs(ctx context.Context) ([]string, error) {
-	// Scan for all queue keys matching pattern "queue:*"
-	// But exclude user-specific keys "queue:user:*" and "queue:pass:*"
-	var eventIDs []string
-	var cursor uint64
-
-	for {
-		keys, nextCursor, err := r.client.Scan(ctx, cursor, "queue:*", 100).Result()
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan queue keys: %w", err)
-		}
-
-		for _, key := range keys {
-			// Skip user-specific keys
-			if len(key) > 11 && key[6:10] == "user" {
-				continue
-			}
-			if len(key) > 11 && key[6:10] == "pass" {
-				continue
-			}
-			// Extract event ID from "queue:{eventID}"
-			if len(key) > 6 {
-				eventID := key[6:] // Remove "queue:" prefix
-				eventIDs = append(eventIDs, eventID)
-			}
-		}
-
-		cursor = nextCursor
-		if cursor == 0 {
-			break
-		}
-	}
-
-	return eventIDs, nil
+	return r.backend.EventIDs(ctx)
 }
 
 // RemoveUserFromQueue removes a user from the queue without token verification
 func (r *RedisQueueRepository) RemoveUserFromQueue(ctx context.Context, eventID, userID string) error {
-	queueKey := fmt.Sprintf("queue:%s", eventID)
-	userQueueKey := fmt.Sprintf("queue:user:%s:%s", eventID, userID)
-
-	// Remove from sorted set
-	if _, err := r.client.ZRem(ctx, queueKey, userID).Result(); err != nil {
-		return fmt.Errorf("failed to remove from queue: %w", err)
+	if _, err := r.backend.Remove(ctx, eventID, userID); err != nil {
+		return err
 	}
 
 	// Remove user queue info
-	r.client.Del(ctx, userQueueKey)
+	r.client.Del(ctx, queueUserKey(eventID, userID))
 
 	return nil
 }
@@ -447,6 +326,9 @@ func (r *RedisQueueRepository) GetEventQueueConfig(ctx context.Context, eventID
 	if val, ok := result["queue_pass_ttl_minutes"]; ok {
 		fmt.Sscanf(val, "%d", &config.QueuePassTTLMinutes)
 	}
+	if val, ok := result["pass_zone_ids"]; ok && val != "" {
+		config.PassZoneIDs = strings.Split(val, ",")
+	}
 
 	return config, nil
 }
@@ -457,6 +339,7 @@ func (r *RedisQueueRepository) SetEventQueueConfig(ctx context.Context, eventID
 	err := r.client.HSet(ctx, key,
 		"max_concurrent_bookings", config.MaxConcurrentBookings,
 		"queue_pass_ttl_minutes", config.QueuePassTTLMinutes,
+		"pass_zone_ids", strings.Join(config.PassZoneIDs, ","),
 	).Err()
 	if err != nil {
 		return fmt.Errorf("failed to set event queue config: %w", err)
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+)
+
+// journalPageSize is how many journal entries are read per XRANGE
+const journalPageSize = 1000
+
+// zoneJournalKey returns the Redis key of a zone's reservation journal stream
+func zoneJournalKey(zoneID string) string {
+	return fmt.Sprintf("zone:journal:%s", zoneID)
+}
+
+// RedisReservationJournal reads the reservation journals the reservation scripts write
+// when RedisReservationRepository.SetJournalRetention is set
+type RedisReservationJournal struct {
+	client *pkgredis.Client
+}
+
+// NewRedisReservationJournal creates a new RedisReservationJournal
+func NewRedisReservationJournal(client *pkgredis.Client) *RedisReservationJournal {
+	return &RedisReservationJournal{client: client}
+}
+
+// Read returns a zone's journal entries recorded between from and to, oldest first.
+// A zero to reads up to the latest entry; limit caps the entries read (0 = all).
+func (j *RedisReservationJournal) Read(ctx context.Context, zoneID string, from, to time.Time, limit int) ([]*domain.JournalEntry, error) {
+	start, end := "-", "+"
+	if !from.IsZero() {
+		start = strconv.FormatInt(from.UnixMilli(), 10)
+	}
+	if !to.IsZero() {
+		end = strconv.FormatInt(to.UnixMilli(), 10)
+	}
+
+	var entries []*domain.JournalEntry
+	for {
+		count := int64(journalPageSize)
+		if limit > 0 && limit-len(entries) < journalPageSize {
+			count = int64(limit - len(entries))
+		}
+		messages, err := j.client.Client().XRangeN(ctx, zoneJournalKey(zoneID), start, end, count).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read reservation journal: %w", err)
+		}
+		for _, msg := range messages {
+			entries = append(entries, parseJournalEntry(msg.ID, msg.Values))
+		}
+		if int64(len(messages)) < count || (limit > 0 && len(entries) >= limit) {
+			return entries, nil
+		}
+		start = "(" + messages[len(messages)-1].ID
+	}
+}
+
+// parseJournalEntry converts the fields of a journal stream entry
+func parseJournalEntry(id string, values map[string]interface{}) *domain.JournalEntry {
+	field := func(name string) string {
+		value, _ := values[name].(string)
+		return value
+	}
+	number := func(name string) *int64 {
+		if n, ok := toInt64(values[name]); ok {
+			return &n
+		}
+		return nil
+	}
+
+	entry := &domain.JournalEntry{
+		ID:           id,
+		Op:           field("op"),
+		BookingID:    field("booking_id"),
+		UserID:       field("user_id"),
+		EventID:      field("event_id"),
+		Available:    number("available"),
+		UserReserved: number("user_reserved"),
+		Status:       field("status"),
+		PaymentID:    field("payment_id"),
+	}
+	if millis, _, ok := strings.Cut(id, "-"); ok {
+		if ms, err := strconv.ParseInt(millis, 10, 64); err == nil {
+			entry.At = time.UnixMilli(ms).UTC()
+		}
+	}
+	if quantity := number("quantity"); quantity != nil {
+		entry.Quantity = *quantity
+	}
+	if shards := number("shards"); shards != nil {
+		entry.Shards = int(*shards)
+	}
+	return entry
+}
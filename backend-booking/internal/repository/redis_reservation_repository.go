@@ -5,12 +5,14 @@ import (
 	_ "embed"
 	"fmt"
 	"strconv"
+	"time"
 
-	"github.com/google/uuid"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/id"
 	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 //go:embed scripts/reserve_seats.lua
@@ -22,21 +24,50 @@ var releaseSeatsScript string
 //go:embed scripts/confirm_booking.lua
 var confirmBookingScript string
 
+//go:embed scripts/modify_reservation.lua
+var modifyReservationScript string
+
+//go:embed scripts/extend_hold.lua
+var extendHoldScript string
+
 // Script names for caching
 const (
 	scriptReserveSeats   = "reserve_seats"
 	scriptReleaseSeats   = "release_seats"
 	scriptConfirmBooking = "confirm_booking"
+	scriptModify         = "modify_reservation"
+	scriptExtendHold     = "extend_hold"
 )
 
 // RedisReservationRepository implements ReservationRepository using Redis
 type RedisReservationRepository struct {
-	client *pkgredis.Client
+	client      *pkgredis.Client
+	shards      *zoneShardCache
+	broadcaster AvailabilityBroadcaster
+	journalTTL  time.Duration
 }
 
 // NewRedisReservationRepository creates a new RedisReservationRepository
 func NewRedisReservationRepository(client *pkgredis.Client) *RedisReservationRepository {
-	return &RedisReservationRepository{client: client}
+	return &RedisReservationRepository{client: client, shards: newZoneShardCache(client)}
+}
+
+// SetAvailabilityBroadcaster announces the zones that reservations and releases sell
+// out, bring low on stock or bring back in stock (nil = no announcements)
+func (r *RedisReservationRepository) SetAvailabilityBroadcaster(broadcaster AvailabilityBroadcaster) {
+	r.broadcaster = broadcaster
+}
+
+// SetJournalRetention makes the scripts record every reserve, confirm and release in
+// the journal of its zone, kept for retention (0 = no journal). Confirmations then
+// read the zone of the reservation first, one more round trip.
+func (r *RedisReservationRepository) SetJournalRetention(retention time.Duration) {
+	r.journalTTL = retention
+}
+
+// journalRetention is the ARGV the scripts read the journal retention from
+func (r *RedisReservationRepository) journalRetention() int64 {
+	return r.journalTTL.Milliseconds()
 }
 
 // LoadScripts loads all Lua scripts into Redis
@@ -45,6 +76,8 @@ func (r *RedisReservationRepository) LoadScripts(ctx context.Context) error {
 		scriptReserveSeats:   reserveSeatsScript,
 		scriptReleaseSeats:   releaseSeatsScript,
 		scriptConfirmBooking: confirmBookingScript,
+		scriptModify:         modifyReservationScript,
+		scriptExtendHold:     extendHoldScript,
 	}
 
 	for name, script := range scripts {
@@ -69,60 +102,112 @@ func (r *RedisReservationRepository) ReserveSeats(ctx context.Context, params Re
 	)
 
 	// Generate booking ID if not provided
-	bookingID := uuid.New().String()
+	bookingID := params.BookingID
+	if bookingID == "" {
+		bookingID = id.New()
+	}
 
 	// Build Redis keys
-	zoneAvailabilityKey := fmt.Sprintf("zone:availability:%s", params.ZoneID)
 	userReservationsKey := fmt.Sprintf("user:reservations:%s:%s", params.UserID, params.EventID)
 	reservationKey := fmt.Sprintf("reservation:%s", bookingID)
 
-	keys := []string{zoneAvailabilityKey, userReservationsKey, reservationKey}
 	args := []interface{}{
-		params.Quantity,    // ARGV[1]: quantity
-		params.MaxPerUser,  // ARGV[2]: max_per_user
-		params.UserID,      // ARGV[3]: user_id
-		bookingID,          // ARGV[4]: booking_id
-		params.ZoneID,      // ARGV[5]: zone_id
-		params.EventID,     // ARGV[6]: event_id
-		"",                 // ARGV[7]: show_id (optional)
-		params.Price,       // ARGV[8]: unit_price
-		params.TTLSeconds,  // ARGV[9]: ttl_seconds
-	}
-
-	result := r.client.EvalWithFallback(ctx, scriptReserveSeats, reserveSeatsScript, keys, args...)
-	if result.Err() != nil {
-		span.RecordError(result.Err())
-		span.SetStatus(codes.Error, result.Err().Error())
-		return nil, fmt.Errorf("failed to execute reserve_seats script: %w", result.Err())
+		params.Quantity,              // ARGV[1]: quantity
+		params.MaxPerUser,            // ARGV[2]: max_per_user
+		params.UserID,                // ARGV[3]: user_id
+		bookingID,                    // ARGV[4]: booking_id
+		params.ZoneID,                // ARGV[5]: zone_id
+		params.EventID,               // ARGV[6]: event_id
+		params.ShowID,                // ARGV[7]: show_id (optional)
+		params.Price,                 // ARGV[8]: unit_price
+		params.TTLSeconds,            // ARGV[9]: ttl_seconds
+		params.IdempotencyKey,        // ARGV[10]: idempotency_key (optional)
+		params.IdempotencyTTLSeconds, // ARGV[11]: idempotency_ttl
 	}
 
-	// Parse result
-	values, err := result.Slice()
-	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
-		return nil, fmt.Errorf("failed to parse script result: %w", err)
-	}
+	// A sharded zone reserves from a random shard; a reshard since the layout was
+	// cached is refused by the script and retried once with the fresh layout
+	var values []interface{}
+	for attempt := 0; ; attempt++ {
+		shards, err := r.shards.shards(ctx, params.ZoneID)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		shard := pickShard(shards)
+		span.SetAttributes(attribute.Int("shards", shards), attribute.Int("shard", shard))
+
+		keys := []string{
+			zoneShardKey(params.ZoneID, shard),
+			userReservationsKey,
+			reservationKey,
+			zoneHoldTTLKey(params.ZoneID),
+			eventHoldTTLKey(params.EventID),
+			bookingIdempotencyKey(params.IdempotencyKey),
+			zoneShardLayoutKey(params.ZoneID),
+			zoneJournalKey(params.ZoneID),
+		}
+		if shards > 1 {
+			keys = append(keys, zoneShardKeys(params.ZoneID, shards)...)
+		}
+		shardArgs := append(args,
+			shards,               // ARGV[12]: shard_count
+			shard,                // ARGV[13]: shard_index
+			r.journalRetention(), // ARGV[14]: journal_retention
+		)
 
-	if len(values) < 3 {
-		span.SetStatus(codes.Error, "unexpected result length")
-		return nil, fmt.Errorf("unexpected script result length: %d", len(values))
+		result := r.client.EvalWithFallback(ctx, scriptReserveSeats, reserveSeatsScript, keys, shardArgs...)
+		if result.Err() != nil {
+			span.RecordError(result.Err())
+			span.SetStatus(codes.Error, result.Err().Error())
+			return nil, fmt.Errorf("failed to execute reserve_seats script: %w", result.Err())
+		}
+
+		// Parse result
+		values, err = result.Slice()
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, fmt.Errorf("failed to parse script result: %w", err)
+		}
+
+		if len(values) < 3 {
+			span.SetStatus(codes.Error, "unexpected result length")
+			return nil, fmt.Errorf("unexpected script result length: %d", len(values))
+		}
+
+		if code, _ := values[1].(string); code != "SHARD_LAYOUT_CHANGED" || attempt > 0 {
+			break
+		}
+		r.shards.invalidate(params.ZoneID)
 	}
 
 	success, _ := toInt64(values[0])
+	if success == 2 {
+		existingID, _ := values[1].(string)
+		span.SetAttributes(attribute.String("existing_booking_id", existingID))
+		span.SetStatus(codes.Ok, "idempotent replay")
+		return &ReserveResult{Replayed: true, BookingID: existingID}, nil
+	}
 	if success == 1 {
 		availableSeats, _ := toInt64(values[1])
 		userReserved, _ := toInt64(values[2])
+		ttlSeconds := reservedTTL(values, params.TTLSeconds)
 		span.SetAttributes(
 			attribute.String("booking_id", bookingID),
 			attribute.Int64("available_seats", availableSeats),
+			attribute.Int("ttl_seconds", ttlSeconds),
 		)
+		// The script returns the seats left over all shards
+		r.observeAvailability(ctx, params.EventID, params.ZoneID, availableSeats, true)
 		span.SetStatus(codes.Ok, "")
 		return &ReserveResult{
 			Success:        true,
 			BookingID:      bookingID,
 			AvailableSeats: availableSeats,
 			UserReserved:   userReserved,
+			TTLSeconds:     ttlSeconds,
 		}, nil
 	}
 
@@ -149,8 +234,21 @@ func (r *RedisReservationRepository) ConfirmBooking(ctx context.Context, booking
 	)
 
 	reservationKey := fmt.Sprintf("reservation:%s", bookingID)
-	keys := []string{reservationKey}
+	keys := []string{reservationKey, reservationSeatsKey(bookingID)}
 	args := []interface{}{bookingID, userID, paymentID}
+	if r.journalTTL > 0 {
+		// The journal is kept per zone and the script only finds the zone in the record
+		zoneID, err := r.client.HGet(ctx, reservationKey, "zone_id").Result()
+		if err != nil && err.Error() != "redis: nil" {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, fmt.Errorf("failed to get reservation zone: %w", err)
+		}
+		if zoneID != "" {
+			keys = append(keys, zoneJournalKey(zoneID))
+			args = append(args, r.journalRetention())
+		}
+	}
 
 	result := r.client.EvalWithFallback(ctx, scriptConfirmBooking, confirmBookingScript, keys, args...)
 	if result.Err() != nil {
@@ -196,14 +294,86 @@ func (r *RedisReservationRepository) ConfirmBooking(ctx context.Context, booking
 	}, nil
 }
 
+// ExtendHold pushes back the expiry of a reserved, unconfirmed reservation
+func (r *RedisReservationRepository) ExtendHold(ctx context.Context, params ExtendHoldParams) (*ExtendHoldResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.reservation.extend_hold")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("booking_id", params.BookingID),
+		attribute.String("user_id", params.UserID),
+		attribute.String("until", params.Until.UTC().Format(time.RFC3339)),
+	)
+
+	keys := []string{
+		fmt.Sprintf("reservation:%s", params.BookingID),
+		fmt.Sprintf("user:reservations:%s:%s", params.UserID, params.EventID),
+		reservationSeatsKey(params.BookingID),
+	}
+	args := []interface{}{params.BookingID, params.UserID, params.Until.Unix()}
+
+	result := r.client.EvalWithFallback(ctx, scriptExtendHold, extendHoldScript, keys, args...)
+	if result.Err() != nil {
+		span.RecordError(result.Err())
+		span.SetStatus(codes.Error, result.Err().Error())
+		return nil, fmt.Errorf("failed to execute extend_hold script: %w", result.Err())
+	}
+
+	values, err := result.Slice()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to parse script result: %w", err)
+	}
+
+	if len(values) < 3 {
+		span.SetStatus(codes.Error, "unexpected result length")
+		return nil, fmt.Errorf("unexpected script result length: %d", len(values))
+	}
+
+	success, _ := toInt64(values[0])
+	if success == 1 {
+		expiresAt, _ := toInt64(values[1])
+		extended, _ := toInt64(values[2])
+		span.SetAttributes(attribute.Bool("extended", extended == 1))
+		span.SetStatus(codes.Ok, "")
+		return &ExtendHoldResult{
+			Success:   true,
+			Extended:  extended == 1,
+			ExpiresAt: time.Unix(expiresAt, 0).UTC(),
+		}, nil
+	}
+
+	errorCode, _ := values[1].(string)
+	errorMessage, _ := values[2].(string)
+	span.SetAttributes(attribute.String("error_code", errorCode))
+	span.SetStatus(codes.Error, errorCode)
+	return &ExtendHoldResult{
+		Success:      false,
+		ErrorCode:    errorCode,
+		ErrorMessage: errorMessage,
+	}, nil
+}
+
 // ReleaseSeats releases reserved seats back to inventory
 func (r *RedisReservationRepository) ReleaseSeats(ctx context.Context, bookingID, userID string) (*ReleaseResult, error) {
-	ctx, span := telemetry.StartSpan(ctx, "repo.redis.reservation.release_seats")
+	return r.releaseSeats(ctx, "repo.redis.reservation.release_seats", bookingID, userID, false)
+}
+
+// ReleaseSoldSeats releases the seats of a reserved or confirmed booking back to inventory
+func (r *RedisReservationRepository) ReleaseSoldSeats(ctx context.Context, bookingID, userID string) (*ReleaseResult, error) {
+	return r.releaseSeats(ctx, "repo.redis.reservation.release_sold_seats", bookingID, userID, true)
+}
+
+// releaseSeats runs the release script; sold also releases a confirmed reservation
+func (r *RedisReservationRepository) releaseSeats(ctx context.Context, spanName, bookingID, userID string, sold bool) (*ReleaseResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, spanName)
 	defer span.End()
 
 	span.SetAttributes(
 		attribute.String("booking_id", bookingID),
 		attribute.String("user_id", userID),
+		attribute.Bool("sold", sold),
 	)
 
 	// First, get the reservation to find the zone_id and event_id
@@ -216,6 +386,11 @@ func (r *RedisReservationRepository) ReleaseSeats(ctx context.Context, bookingID
 	}
 
 	if len(reservationData) == 0 {
+		// Seats assigned in a reserved seating zone outlive the reservation record;
+		// free them so an expired hold does not keep the seats taken
+		if err := r.releaseExpiredSeats(ctx, bookingID, userID); err != nil {
+			span.RecordError(err)
+		}
 		span.SetStatus(codes.Error, "RESERVATION_NOT_FOUND")
 		return &ReleaseResult{
 			Success:      false,
@@ -236,8 +411,22 @@ func (r *RedisReservationRepository) ReleaseSeats(ctx context.Context, bookingID
 	zoneAvailabilityKey := fmt.Sprintf("zone:availability:%s", zoneID)
 	userReservationsKey := fmt.Sprintf("user:reservations:%s:%s", userID, eventID)
 
-	keys := []string{zoneAvailabilityKey, userReservationsKey, reservationKey}
-	args := []interface{}{bookingID, userID}
+	keys := []string{
+		zoneAvailabilityKey,
+		userReservationsKey,
+		reservationKey,
+		seatBitmapKey(zoneID),
+		reservationSeatsKey(bookingID),
+		zoneJournalKey(zoneID),
+		priceTierSoldKey(zoneID),
+		priceTierClaimsKey(zoneID),
+		addOnSoldKey(eventID),
+		addOnClaimsKey(eventID),
+	}
+	args := []interface{}{bookingID, userID, "0", r.journalRetention()}
+	if sold {
+		args[2] = "1"
+	}
 
 	result := r.client.EvalWithFallback(ctx, scriptReleaseSeats, releaseSeatsScript, keys, args...)
 	if result.Err() != nil {
@@ -254,6 +443,214 @@ func (r *RedisReservationRepository) ReleaseSeats(ctx context.Context, bookingID
 		return nil, fmt.Errorf("failed to parse script result: %w", err)
 	}
 
+	release, err := parseReleaseResult(values)
+	if err != nil {
+		span.SetStatus(codes.Error, "unexpected result length")
+		return nil, err
+	}
+
+	if release.Success {
+		span.SetAttributes(attribute.Int64("available_seats", release.AvailableSeats))
+		r.observeAvailability(ctx, eventID, zoneID, release.AvailableSeats, false)
+		span.SetStatus(codes.Ok, "")
+		return release, nil
+	}
+
+	// Error case
+	span.SetAttributes(attribute.String("error_code", release.ErrorCode))
+	span.SetStatus(codes.Error, release.ErrorCode)
+	return release, nil
+}
+
+// parseReleaseResult converts the release_seats script reply into a ReleaseResult
+func parseReleaseResult(values []interface{}) (*ReleaseResult, error) {
+	if len(values) < 3 {
+		return nil, fmt.Errorf("unexpected script result length: %d", len(values))
+	}
+
+	success, _ := toInt64(values[0])
+	if success == 1 {
+		availableSeats, _ := toInt64(values[1])
+		userReserved, _ := toInt64(values[2])
+		return &ReleaseResult{
+			Success:        true,
+			AvailableSeats: availableSeats,
+			UserReserved:   userReserved,
+		}, nil
+	}
+
+	errorCode, _ := values[1].(string)
+	errorMessage, _ := values[2].(string)
+	return &ReleaseResult{
+		Success:      false,
+		ErrorCode:    errorCode,
+		ErrorMessage: errorMessage,
+	}, nil
+}
+
+// ReleaseSeatsBatch releases many reservations with two pipelined round trips: one
+// reading the reservations and one running the release script for each.
+// Reservations that are gone or hit an unloaded script fall back to ReleaseSeats.
+func (r *RedisReservationRepository) ReleaseSeatsBatch(ctx context.Context, requests []ReleaseRequest) ([]*ReleaseResult, []error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.reservation.release_seats_batch")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("count", len(requests)))
+
+	results := make([]*ReleaseResult, len(requests))
+	errs := make([]error, len(requests))
+	if len(requests) == 0 {
+		return results, errs
+	}
+
+	// Round trip 1: read every reservation to find its zone and event
+	readPipe := r.client.Pipeline()
+	reads := make([]interface {
+		Result() (map[string]string, error)
+	}, len(requests))
+	for i, req := range requests {
+		reads[i] = readPipe.HGetAll(ctx, fmt.Sprintf("reservation:%s", req.BookingID))
+	}
+	_, _ = readPipe.Exec(ctx) // Errors are read per command below
+
+	sha, scriptLoaded := r.client.GetScriptSHA(scriptReleaseSeats)
+
+	// Round trip 2: run the release script for every reservation that still exists
+	releasePipe := r.client.Pipeline()
+	releases := make([]interface {
+		Slice() ([]interface{}, error)
+	}, len(requests))
+	zones := make([][2]string, len(requests)) // Event and zone of each queued release
+	var (
+		fallback []int
+		queued   int
+	)
+	for i, req := range requests {
+		reservationData, err := reads[i].Result()
+		if err != nil {
+			errs[i] = fmt.Errorf("failed to get reservation: %w", err)
+			continue
+		}
+		if len(reservationData) == 0 || !scriptLoaded {
+			fallback = append(fallback, i)
+			continue
+		}
+
+		zoneID := reservationData["zone_id"]
+		eventID := reservationData["event_id"]
+		keys := []string{
+			fmt.Sprintf("zone:availability:%s", zoneID),
+			fmt.Sprintf("user:reservations:%s:%s", req.UserID, eventID),
+			fmt.Sprintf("reservation:%s", req.BookingID),
+			seatBitmapKey(zoneID),
+			reservationSeatsKey(req.BookingID),
+			zoneJournalKey(zoneID),
+			priceTierSoldKey(zoneID),
+			priceTierClaimsKey(zoneID),
+			addOnSoldKey(eventID),
+			addOnClaimsKey(eventID),
+		}
+		releases[i] = releasePipe.EvalSha(ctx, sha, keys, req.BookingID, req.UserID, "0", r.journalRetention())
+		zones[i] = [2]string{eventID, zoneID}
+		queued++
+	}
+	if queued > 0 {
+		_, _ = releasePipe.Exec(ctx)
+	}
+
+	for i, release := range releases {
+		if release == nil {
+			continue
+		}
+		values, err := release.Slice()
+		if err != nil {
+			// NOSCRIPT after a Redis restart is handled by the single release reloading the script
+			fallback = append(fallback, i)
+			continue
+		}
+		results[i], errs[i] = parseReleaseResult(values)
+		if errs[i] == nil && results[i].Success {
+			r.observeAvailability(ctx, zones[i][0], zones[i][1], results[i].AvailableSeats, false)
+		}
+	}
+
+	for _, i := range fallback {
+		results[i], errs[i] = r.ReleaseSeats(ctx, requests[i].BookingID, requests[i].UserID)
+	}
+
+	span.SetAttributes(attribute.Int("fallbacks", len(fallback)))
+	span.SetStatus(codes.Ok, "")
+	return results, errs
+}
+
+// ModifyReservation runs one phase of moving a reservation to another zone or quantity
+func (r *RedisReservationRepository) ModifyReservation(ctx context.Context, params ModifyParams) (*ModifyResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.reservation.modify")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("booking_id", params.BookingID),
+		attribute.String("user_id", params.UserID),
+		attribute.String("phase", string(params.Phase)),
+	)
+
+	// The keys depend on the zones recorded in the reservation
+	reservationKey := fmt.Sprintf("reservation:%s", params.BookingID)
+	reservationData, err := r.client.HGetAll(ctx, reservationKey).Result()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to get reservation: %w", err)
+	}
+	if len(reservationData) == 0 {
+		span.SetStatus(codes.Error, "RESERVATION_NOT_FOUND")
+		return &ModifyResult{
+			ErrorCode:    "RESERVATION_NOT_FOUND",
+			ErrorMessage: "Reservation does not exist or has expired",
+		}, nil
+	}
+
+	zoneID := reservationData["zone_id"]
+	pendingZoneID := reservationData["pending_zone_id"]
+	if pendingZoneID == "" {
+		pendingZoneID = zoneID
+	}
+	newZoneID := params.ZoneID
+	if params.Phase != ModifyHold {
+		newZoneID = pendingZoneID
+	}
+
+	keys := []string{
+		reservationKey,
+		fmt.Sprintf("zone:availability:%s", zoneID),
+		fmt.Sprintf("zone:availability:%s", newZoneID),
+		fmt.Sprintf("user:reservations:%s:%s", params.UserID, reservationData["event_id"]),
+		fmt.Sprintf("zone:availability:%s", pendingZoneID),
+	}
+	args := []interface{}{
+		string(params.Phase), // ARGV[1]: mode
+		params.UserID,        // ARGV[2]: user_id
+		newZoneID,            // ARGV[3]: new_zone_id
+		params.Quantity,      // ARGV[4]: new_quantity
+		params.UnitPrice,     // ARGV[5]: new_unit_price
+		params.MaxPerUser,    // ARGV[6]: max_per_user
+		params.PendingTTL,    // ARGV[7]: pending_ttl
+	}
+
+	result := r.client.EvalWithFallback(ctx, scriptModify, modifyReservationScript, keys, args...)
+	if result.Err() != nil {
+		span.RecordError(result.Err())
+		span.SetStatus(codes.Error, result.Err().Error())
+		return nil, fmt.Errorf("failed to execute modify_reservation script: %w", result.Err())
+	}
+
+	values, err := result.Slice()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to parse script result: %w", err)
+	}
+
 	if len(values) < 3 {
 		span.SetStatus(codes.Error, "unexpected result length")
 		return nil, fmt.Errorf("unexpected script result length: %d", len(values))
@@ -264,8 +661,13 @@ func (r *RedisReservationRepository) ReleaseSeats(ctx context.Context, bookingID
 		availableSeats, _ := toInt64(values[1])
 		userReserved, _ := toInt64(values[2])
 		span.SetAttributes(attribute.Int64("available_seats", availableSeats))
+		r.observeAvailability(ctx, reservationData["event_id"], newZoneID, availableSeats, false)
+		if zoneID != newZoneID && params.Phase == ModifyCommit {
+			// Seats of the old zone went back on sale
+			r.observeZone(ctx, reservationData["event_id"], zoneID)
+		}
 		span.SetStatus(codes.Ok, "")
-		return &ReleaseResult{
+		return &ModifyResult{
 			Success:        true,
 			AvailableSeats: availableSeats,
 			UserReserved:   userReserved,
@@ -277,37 +679,135 @@ func (r *RedisReservationRepository) ReleaseSeats(ctx context.Context, bookingID
 	errorMessage, _ := values[2].(string)
 	span.SetAttributes(attribute.String("error_code", errorCode))
 	span.SetStatus(codes.Error, errorCode)
-	return &ReleaseResult{
+	return &ModifyResult{
 		Success:      false,
 		ErrorCode:    errorCode,
 		ErrorMessage: errorMessage,
 	}, nil
 }
 
-// GetZoneAvailability gets the current available seats for a zone
+// observeAvailability reports a zone's seats after a change to the broadcaster.
+// Scripts other than reserve only see the primary counter of a sharded zone, so
+// unless available is the zone total it is read again for sharded zones.
+func (r *RedisReservationRepository) observeAvailability(ctx context.Context, eventID, zoneID string, available int64, total bool) {
+	if r.broadcaster == nil {
+		return
+	}
+	if !total {
+		if shards, err := r.shards.shards(ctx, zoneID); err != nil || shards > 1 {
+			r.observeZone(ctx, eventID, zoneID)
+			return
+		}
+	}
+	if err := r.broadcaster.Observe(ctx, eventID, zoneID, available); err != nil {
+		trace.SpanFromContext(ctx).RecordError(err)
+	}
+}
+
+// observeZone reads a zone's seats and reports them to the broadcaster. Announcements
+// are best effort and never fail the reservation call.
+func (r *RedisReservationRepository) observeZone(ctx context.Context, eventID, zoneID string) {
+	if r.broadcaster == nil {
+		return
+	}
+	available, err := r.GetZoneAvailability(ctx, zoneID)
+	if err == nil {
+		err = r.broadcaster.Observe(ctx, eventID, zoneID, available)
+	}
+	if err != nil {
+		trace.SpanFromContext(ctx).RecordError(err)
+	}
+}
+
+// releaseExpiredSeats frees the seats of a reservation whose record has already expired
+func (r *RedisReservationRepository) releaseExpiredSeats(ctx context.Context, bookingID, userID string) error {
+	seatsKey := reservationSeatsKey(bookingID)
+	seatsData, err := r.client.HGetAll(ctx, seatsKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to get reservation seats: %w", err)
+	}
+	if len(seatsData) == 0 {
+		return nil // General admission reservation or seats already freed
+	}
+
+	zoneID := seatsData["zone_id"]
+	keys := []string{
+		fmt.Sprintf("zone:availability:%s", zoneID),
+		fmt.Sprintf("user:reservations:%s:%s", userID, seatsData["event_id"]),
+		fmt.Sprintf("reservation:%s", bookingID),
+		seatBitmapKey(zoneID),
+		seatsKey,
+	}
+
+	if err := r.client.EvalWithFallback(ctx, scriptReleaseSeats, releaseSeatsScript, keys, bookingID, userID).Err(); err != nil {
+		return fmt.Errorf("failed to free expired reservation seats: %w", err)
+	}
+	return nil
+}
+
+// reservedTTL reads the hold applied by a reserve script, falling back to the requested one
+func reservedTTL(values []interface{}, requested int) int {
+	if len(values) > 3 {
+		if ttl, ok := toInt64(values[3]); ok && ttl > 0 {
+			return int(ttl)
+		}
+	}
+	return requested
+}
+
+// zoneHoldTTLKey returns the Redis key of a zone's organizer-configured hold in seconds
+func zoneHoldTTLKey(zoneID string) string {
+	return fmt.Sprintf("zone:hold_ttl:%s", zoneID)
+}
+
+// eventHoldTTLKey returns the Redis key of an event's organizer-configured hold in seconds
+func eventHoldTTLKey(eventID string) string {
+	return fmt.Sprintf("event:hold_ttl:%s", eventID)
+}
+
+// bookingIdempotencyKey returns the Redis key of the booking reserved for an idempotency key
+func bookingIdempotencyKey(key string) string {
+	return fmt.Sprintf("booking:idempotency:%s", key)
+}
+
+// GetZoneAvailability gets the current available seats for a zone, summed over its shards
 func (r *RedisReservationRepository) GetZoneAvailability(ctx context.Context, zoneID string) (int64, error) {
 	ctx, span := telemetry.StartSpan(ctx, "repo.redis.reservation.get_zone_availability")
 	defer span.End()
 
 	span.SetAttributes(attribute.String("zone_id", zoneID))
 
-	key := fmt.Sprintf("zone:availability:%s", zoneID)
-	result, err := r.client.Get(ctx, key).Result()
+	shards, err := r.shards.shards(ctx, zoneID)
 	if err != nil {
-		if err.Error() == "redis: nil" {
-			span.SetStatus(codes.Ok, "zone not found")
-			return 0, nil // Zone not found, return 0
-		}
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
-		return 0, fmt.Errorf("failed to get zone availability: %w", err)
+		return 0, err
 	}
 
-	seats, err := strconv.ParseInt(result, 10, 64)
+	results, err := r.client.Client().MGet(ctx, zoneShardKeys(zoneID, shards)...).Result()
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
-		return 0, fmt.Errorf("failed to parse availability: %w", err)
+		return 0, fmt.Errorf("failed to get zone availability: %w", err)
+	}
+	if results[0] == nil {
+		span.SetStatus(codes.Ok, "zone not found")
+		return 0, nil // Zone not found, return 0
+	}
+
+	var seats int64
+	for _, result := range results {
+		value, ok := result.(string)
+		if !ok {
+			continue // Shard not filled yet
+		}
+		shardSeats, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return 0, fmt.Errorf("failed to parse availability: %w", err)
+		}
+		seats += shardSeats
 	}
 
 	span.SetAttributes(attribute.Int64("available_seats", seats))
@@ -325,12 +825,11 @@ func (r *RedisReservationRepository) SetZoneAvailability(ctx context.Context, zo
 		attribute.Int64("seats", seats),
 	)
 
-	key := fmt.Sprintf("zone:availability:%s", zoneID)
-	err := r.client.Set(ctx, key, seats, 0).Err()
-	if err != nil {
+	// Other shards are emptied so seats are not counted twice
+	if err := resetZoneAvailability(ctx, r.client, zoneID, seats); err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
-		return fmt.Errorf("failed to set zone availability: %w", err)
+		return err
 	}
 
 	span.SetStatus(codes.Ok, "")
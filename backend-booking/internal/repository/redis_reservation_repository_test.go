@@ -2,22 +2,24 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
 	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
 )
 
 // skipIfNoIntegration skips the test if INTEGRATION_TEST env var is not set
-func skipIfNoIntegration(t *testing.T) {
+func skipIfNoIntegration(t testing.TB) {
 	if os.Getenv("INTEGRATION_TEST") != "true" {
 		t.Skip("Skipping integration test. Set INTEGRATION_TEST=true to run")
 	}
 }
 
 // getRedisClient creates a Redis client for testing
-func getRedisClient(t *testing.T) *pkgredis.Client {
+func getRedisClient(t testing.TB) *pkgredis.Client {
 	host := os.Getenv("TEST_REDIS_HOST")
 	if host == "" {
 		host = "localhost"
@@ -203,6 +205,73 @@ func TestRedisReservationRepository_ReserveSeats_InsufficientStock(t *testing.T)
 	}
 }
 
+func TestRedisReservationRepository_ReserveSeats_HoldTTL(t *testing.T) {
+	skipIfNoIntegration(t)
+
+	ctx := context.Background()
+	client := getRedisClient(t)
+	defer client.Close()
+
+	repo := NewRedisReservationRepository(client)
+
+	if err := repo.LoadScripts(ctx); err != nil {
+		t.Fatalf("Failed to load scripts: %v", err)
+	}
+
+	if err := repo.SetZoneAvailability(ctx, "zone-hold", 100); err != nil {
+		t.Fatalf("Failed to set zone availability: %v", err)
+	}
+	if err := repo.SetZoneAvailability(ctx, "zone-hold-override", 100); err != nil {
+		t.Fatalf("Failed to set zone availability: %v", err)
+	}
+
+	// Written by ticket-service from the organizer's settings
+	client.Set(ctx, eventHoldTTLKey("event-hold"), 300, 0)
+	client.Set(ctx, zoneHoldTTLKey("zone-hold-override"), 120, 0)
+
+	tests := []struct {
+		name    string
+		zoneID  string
+		eventID string
+		wantTTL int
+	}{
+		{"service default", "zone-hold", "event-default", 600},
+		{"event hold", "zone-hold", "event-hold", 300},
+		{"zone overrides event", "zone-hold-override", "event-hold", 120},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := repo.ReserveSeats(ctx, ReserveParams{
+				ZoneID:     tt.zoneID,
+				UserID:     fmt.Sprintf("user-%d", i),
+				EventID:    tt.eventID,
+				Quantity:   1,
+				MaxPerUser: 4,
+				TTLSeconds: 600,
+				Price:      100.00,
+			})
+			if err != nil {
+				t.Fatalf("ReserveSeats() error = %v", err)
+			}
+			if !result.Success {
+				t.Fatalf("ReserveSeats() failed: %s", result.ErrorCode)
+			}
+			if result.TTLSeconds != tt.wantTTL {
+				t.Errorf("ReserveSeats() TTLSeconds = %d, want %d", result.TTLSeconds, tt.wantTTL)
+			}
+
+			ttl, err := client.Client().TTL(ctx, "reservation:"+result.BookingID).Result()
+			if err != nil {
+				t.Fatalf("Failed to read reservation TTL: %v", err)
+			}
+			if ttl <= 0 || ttl > time.Duration(tt.wantTTL)*time.Second {
+				t.Errorf("Reservation key TTL = %v, want at most %ds", ttl, tt.wantTTL)
+			}
+		})
+	}
+}
+
 func TestRedisReservationRepository_ReleaseSeats(t *testing.T) {
 	skipIfNoIntegration(t)
 
@@ -267,6 +336,62 @@ func TestRedisReservationRepository_ReleaseSeats(t *testing.T) {
 	}
 }
 
+func TestRedisReservationRepository_ReleaseSeatsBatch(t *testing.T) {
+	skipIfNoIntegration(t)
+
+	ctx := context.Background()
+	client := getRedisClient(t)
+	defer client.Close()
+
+	repo := NewRedisReservationRepository(client)
+
+	if err := repo.LoadScripts(ctx); err != nil {
+		t.Fatalf("Failed to load scripts: %v", err)
+	}
+
+	zoneID := "zone-release-batch-test"
+	if err := repo.SetZoneAvailability(ctx, zoneID, 100); err != nil {
+		t.Fatalf("Failed to set zone availability: %v", err)
+	}
+
+	var requests []ReleaseRequest
+	for i := 0; i < 5; i++ {
+		userID := fmt.Sprintf("user-batch-%d", i)
+		reserveResult, err := repo.ReserveSeats(ctx, ReserveParams{
+			ZoneID:     zoneID,
+			UserID:     userID,
+			EventID:    "event-release-batch",
+			Quantity:   2,
+			MaxPerUser: 10,
+			TTLSeconds: 600,
+			Price:      100.00,
+		})
+		if err != nil || !reserveResult.Success {
+			t.Fatalf("Failed to reserve seats: %v, %+v", err, reserveResult)
+		}
+		requests = append(requests, ReleaseRequest{BookingID: reserveResult.BookingID, UserID: userID})
+	}
+	requests = append(requests, ReleaseRequest{BookingID: "missing-booking", UserID: "user-batch-0"})
+
+	results, errs := repo.ReleaseSeatsBatch(ctx, requests)
+	for i := range requests[:5] {
+		if errs[i] != nil || !results[i].Success {
+			t.Errorf("ReleaseSeatsBatch()[%d] = %+v, %v", i, results[i], errs[i])
+		}
+	}
+	if errs[5] != nil || results[5].ErrorCode != "RESERVATION_NOT_FOUND" {
+		t.Errorf("ReleaseSeatsBatch()[5] = %+v, %v, want RESERVATION_NOT_FOUND", results[5], errs[5])
+	}
+
+	available, err := repo.GetZoneAvailability(ctx, zoneID)
+	if err != nil {
+		t.Fatalf("Failed to get availability: %v", err)
+	}
+	if available != 100 {
+		t.Errorf("Available seats after batch release = %d, want 100", available)
+	}
+}
+
 func TestRedisReservationRepository_ConfirmBooking(t *testing.T) {
 	skipIfNoIntegration(t)
 
@@ -330,6 +455,221 @@ func TestRedisReservationRepository_ConfirmBooking(t *testing.T) {
 	}
 }
 
+func TestRedisReservationRepository_ExtendHold(t *testing.T) {
+	skipIfNoIntegration(t)
+
+	ctx := context.Background()
+	client := getRedisClient(t)
+	defer client.Close()
+
+	repo := NewRedisReservationRepository(client)
+
+	if err := repo.LoadScripts(ctx); err != nil {
+		t.Fatalf("Failed to load scripts: %v", err)
+	}
+
+	zoneID := "zone-extend-test"
+	if err := repo.SetZoneAvailability(ctx, zoneID, 100); err != nil {
+		t.Fatalf("Failed to set zone availability: %v", err)
+	}
+
+	reserveResult, err := repo.ReserveSeats(ctx, ReserveParams{
+		ZoneID:     zoneID,
+		UserID:     "user-extend",
+		EventID:    "event-extend",
+		Quantity:   2,
+		MaxPerUser: 10,
+		TTLSeconds: 60,
+		Price:      100.00,
+	})
+	if err != nil || !reserveResult.Success {
+		t.Fatalf("Failed to reserve seats: %v, %+v", err, reserveResult)
+	}
+
+	params := ExtendHoldParams{
+		BookingID: reserveResult.BookingID,
+		UserID:    "user-extend",
+		EventID:   "event-extend",
+		Until:     time.Now().Add(10 * time.Minute),
+	}
+	extendResult, err := repo.ExtendHold(ctx, params)
+	if err != nil {
+		t.Fatalf("ExtendHold() error = %v", err)
+	}
+	if !extendResult.Success || !extendResult.Extended || extendResult.ExpiresAt.Unix() != params.Until.Unix() {
+		t.Errorf("ExtendHold() = %+v, want extended to %v", extendResult, params.Until)
+	}
+
+	ttl, err := client.Client().TTL(ctx, fmt.Sprintf("reservation:%s", reserveResult.BookingID)).Result()
+	if err != nil {
+		t.Fatalf("TTL() error = %v", err)
+	}
+	if ttl < 9*time.Minute {
+		t.Errorf("reservation TTL = %v, want about 10m", ttl)
+	}
+
+	// An earlier expiry never shortens the hold
+	params.Until = time.Now().Add(2 * time.Minute)
+	shorter, err := repo.ExtendHold(ctx, params)
+	if err != nil {
+		t.Fatalf("ExtendHold() error = %v", err)
+	}
+	if !shorter.Success || shorter.Extended || shorter.ExpiresAt.Unix() != extendResult.ExpiresAt.Unix() {
+		t.Errorf("ExtendHold() with an earlier expiry = %+v, want the hold left at %v", shorter, extendResult.ExpiresAt)
+	}
+
+	// Confirmed reservations are no longer held
+	if _, err := repo.ConfirmBooking(ctx, reserveResult.BookingID, "user-extend", "payment-123"); err != nil {
+		t.Fatalf("ConfirmBooking() error = %v", err)
+	}
+	params.Until = time.Now().Add(20 * time.Minute)
+	confirmed, err := repo.ExtendHold(ctx, params)
+	if err != nil {
+		t.Fatalf("ExtendHold() error = %v", err)
+	}
+	if confirmed.Success || confirmed.ErrorCode != "INVALID_STATUS" {
+		t.Errorf("ExtendHold() of a confirmed reservation = %+v, want INVALID_STATUS", confirmed)
+	}
+}
+
+func TestRedisReservationRepository_ReleaseSoldSeats(t *testing.T) {
+	skipIfNoIntegration(t)
+
+	ctx := context.Background()
+	client := getRedisClient(t)
+	defer client.Close()
+
+	repo := NewRedisReservationRepository(client)
+
+	if err := repo.LoadScripts(ctx); err != nil {
+		t.Fatalf("Failed to load scripts: %v", err)
+	}
+
+	zoneID := "zone-release-sold-test"
+	if err := repo.SetZoneAvailability(ctx, zoneID, 100); err != nil {
+		t.Fatalf("Failed to set zone availability: %v", err)
+	}
+
+	reserveResult, err := repo.ReserveSeats(ctx, ReserveParams{
+		ZoneID:     zoneID,
+		UserID:     "user-release-sold",
+		EventID:    "event-release-sold",
+		Quantity:   2,
+		MaxPerUser: 10,
+		TTLSeconds: 600,
+		Price:      100.00,
+	})
+	if err != nil || !reserveResult.Success {
+		t.Fatalf("Failed to reserve seats: %v, %+v", err, reserveResult)
+	}
+	if _, err := repo.ConfirmBooking(ctx, reserveResult.BookingID, "user-release-sold", "payment-123"); err != nil {
+		t.Fatalf("ConfirmBooking() error = %v", err)
+	}
+
+	// Sold seats are not released by ReleaseSeats
+	releaseResult, err := repo.ReleaseSeats(ctx, reserveResult.BookingID, "user-release-sold")
+	if err != nil {
+		t.Fatalf("ReleaseSeats() error = %v", err)
+	}
+	if releaseResult.Success || releaseResult.ErrorCode != "ALREADY_RELEASED" {
+		t.Errorf("ReleaseSeats() = %+v, want ALREADY_RELEASED", releaseResult)
+	}
+
+	releaseResult, err = repo.ReleaseSoldSeats(ctx, reserveResult.BookingID, "user-release-sold")
+	if err != nil {
+		t.Fatalf("ReleaseSoldSeats() error = %v", err)
+	}
+	if !releaseResult.Success || releaseResult.AvailableSeats != 100 {
+		t.Errorf("ReleaseSoldSeats() = %+v, want 100 seats available", releaseResult)
+	}
+}
+
+func TestRedisReservationRepository_Journal(t *testing.T) {
+	skipIfNoIntegration(t)
+
+	ctx := context.Background()
+	client := getRedisClient(t)
+	defer client.Close()
+
+	repo := NewRedisReservationRepository(client)
+	repo.SetJournalRetention(time.Hour)
+	if err := repo.LoadScripts(ctx); err != nil {
+		t.Fatalf("Failed to load scripts: %v", err)
+	}
+
+	zoneID := "zone-journal-test"
+	if err := repo.SetZoneAvailability(ctx, zoneID, 100); err != nil {
+		t.Fatalf("Failed to set zone availability: %v", err)
+	}
+
+	reserve := func(userID string) string {
+		result, err := repo.ReserveSeats(ctx, ReserveParams{
+			ZoneID:     zoneID,
+			UserID:     userID,
+			EventID:    "event-journal",
+			Quantity:   2,
+			MaxPerUser: 10,
+			TTLSeconds: 600,
+			Price:      100.00,
+		})
+		if err != nil || !result.Success {
+			t.Fatalf("Failed to reserve seats: %v, %+v", err, result)
+		}
+		return result.BookingID
+	}
+	sold, released := reserve("user-journal-1"), reserve("user-journal-2")
+	if _, err := repo.ConfirmBooking(ctx, sold, "user-journal-1", "payment-journal"); err != nil {
+		t.Fatalf("ConfirmBooking() error = %v", err)
+	}
+	if _, err := repo.ReleaseSeats(ctx, released, "user-journal-2"); err != nil {
+		t.Fatalf("ReleaseSeats() error = %v", err)
+	}
+	// Rejected calls change nothing and are not journaled
+	if _, err := repo.ReleaseSeats(ctx, released, "user-journal-2"); err != nil {
+		t.Fatalf("ReleaseSeats() error = %v", err)
+	}
+
+	entries, err := NewRedisReservationJournal(client).Read(ctx, zoneID, time.Now().Add(-time.Minute), time.Time{}, 0)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	want := []struct {
+		op        string
+		bookingID string
+		available int64 // -1 = not recorded
+	}{
+		{domain.JournalReserve, sold, 98},
+		{domain.JournalReserve, released, 96},
+		{domain.JournalConfirm, sold, -1},
+		{domain.JournalRelease, released, 98},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("Read() = %d entries, want %d", len(entries), len(want))
+	}
+	for i, w := range want {
+		entry := entries[i]
+		if entry.Op != w.op || entry.BookingID != w.bookingID || entry.Quantity != 2 {
+			t.Errorf("entry %d = %+v, want %s of %s", i, entry, w.op, w.bookingID)
+		}
+		if (w.available < 0) != (entry.Available == nil) || (entry.Available != nil && *entry.Available != w.available) {
+			t.Errorf("entry %d available = %v, want %d", i, entry.Available, w.available)
+		}
+	}
+	if entries[2].PaymentID != "payment-journal" {
+		t.Errorf("confirm entry payment = %q, want payment-journal", entries[2].PaymentID)
+	}
+
+	analysis := domain.AnalyzeJournal(entries)
+	if len(analysis.Anomalies) != 0 || len(analysis.OpenHolds) != 0 {
+		t.Errorf("AnalyzeJournal() = %+v, want a clean journal", analysis)
+	}
+
+	limited, err := NewRedisReservationJournal(client).Read(ctx, zoneID, time.Time{}, time.Time{}, 3)
+	if err != nil || len(limited) != 3 {
+		t.Errorf("Read() with limit 3 = %d entries, %v", len(limited), err)
+	}
+}
+
 func TestRedisReservationRepository_GetZoneAvailability(t *testing.T) {
 	skipIfNoIntegration(t)
 
@@ -0,0 +1,596 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// Benchmarks and concurrency tests of the hot-path Lua scripts against a real Redis.
+// Like the other Redis tests they need INTEGRATION_TEST=true:
+//
+//	INTEGRATION_TEST=true go test ./internal/repository -run LuaScript -bench Script -benchmem
+
+// scriptThroughputFloors are the ops/sec each hot-path script must sustain from one
+// process against the test Redis; TestLuaScriptThroughput fails below them.
+// LUA_BENCH_FLOOR_SCALE scales them for slower runners (e.g. 0.5).
+var scriptThroughputFloors = map[string]float64{
+	"reserve_seats":          4000,
+	"reserve_seats_sold_out": 6000,
+	"release_seats":          3000,
+	"join_queue_zset":        4000,
+	"join_queue_streams":     4000,
+	"claim_price_tier":       5000,
+}
+
+// scriptBenchmarks are the benchmarks measured against the throughput floors
+var scriptBenchmarks = map[string]func(*testing.B){
+	"reserve_seats":          BenchmarkReserveSeatsScript,
+	"reserve_seats_sold_out": BenchmarkReserveSeatsScript_SoldOut,
+	"release_seats":          BenchmarkReleaseSeatsScript,
+	"join_queue_zset":        func(b *testing.B) { benchmarkJoinQueueScript(b, QueueBackendZSet) },
+	"join_queue_streams":     func(b *testing.B) { benchmarkJoinQueueScript(b, QueueBackendStreams) },
+	"claim_price_tier":       BenchmarkClaimPriceTierScript,
+}
+
+// reportOpsPerSec adds the ops/sec the regression floors are expressed in
+func reportOpsPerSec(b *testing.B) {
+	if elapsed := b.Elapsed(); elapsed > 0 {
+		b.ReportMetric(float64(b.N)/elapsed.Seconds(), "ops/s")
+	}
+}
+
+// newBenchReservationRepo returns a reservation repository with its scripts loaded
+// and a zone holding seats
+func newBenchReservationRepo(tb testing.TB, zoneID string, seats int64) *RedisReservationRepository {
+	client := getRedisClient(tb)
+	tb.Cleanup(func() { client.Close() })
+
+	repo := NewRedisReservationRepository(client)
+	if err := repo.LoadScripts(context.Background()); err != nil {
+		tb.Fatalf("Failed to load scripts: %v", err)
+	}
+	if err := repo.SetZoneAvailability(context.Background(), zoneID, seats); err != nil {
+		tb.Fatalf("Failed to set zone availability: %v", err)
+	}
+	return repo
+}
+
+// benchReserveParams reserves one seat for a user of its own
+func benchReserveParams(zoneID string, n int64) ReserveParams {
+	return ReserveParams{
+		ZoneID:     zoneID,
+		UserID:     fmt.Sprintf("user-%d", n),
+		EventID:    "event-bench",
+		ShowID:     "show-bench",
+		Quantity:   1,
+		MaxPerUser: 4,
+		TTLSeconds: 600,
+		Price:      100.00,
+	}
+}
+
+func BenchmarkReserveSeatsScript(b *testing.B) {
+	skipIfNoIntegration(b)
+
+	ctx := context.Background()
+	repo := newBenchReservationRepo(b, "zone-bench", int64(b.N)+1)
+
+	var seq atomic.Int64
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			result, err := repo.ReserveSeats(ctx, benchReserveParams("zone-bench", seq.Add(1)))
+			if err != nil {
+				b.Error(err)
+				return
+			}
+			if !result.Success {
+				b.Errorf("ReserveSeats() error code = %s", result.ErrorCode)
+				return
+			}
+		}
+	})
+	reportOpsPerSec(b)
+}
+
+// BenchmarkReserveSeatsScript_SoldOut measures the rejection path that sheds the
+// surge hitting a sold-out zone
+func BenchmarkReserveSeatsScript_SoldOut(b *testing.B) {
+	skipIfNoIntegration(b)
+
+	ctx := context.Background()
+	repo := newBenchReservationRepo(b, "zone-sold-out", 0)
+
+	var seq atomic.Int64
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			result, err := repo.ReserveSeats(ctx, benchReserveParams("zone-sold-out", seq.Add(1)))
+			if err != nil {
+				b.Error(err)
+				return
+			}
+			if result.ErrorCode != "INSUFFICIENT_STOCK" {
+				b.Errorf("ReserveSeats() on a sold-out zone = %+v, want INSUFFICIENT_STOCK", result)
+				return
+			}
+		}
+	})
+	reportOpsPerSec(b)
+}
+
+func BenchmarkReleaseSeatsScript(b *testing.B) {
+	skipIfNoIntegration(b)
+
+	ctx := context.Background()
+	repo := newBenchReservationRepo(b, "zone-bench", int64(b.N)+1)
+
+	type reservation struct{ bookingID, userID string }
+	reservations := make([]reservation, b.N)
+	for i := range reservations {
+		params := benchReserveParams("zone-bench", int64(i))
+		result, err := repo.ReserveSeats(ctx, params)
+		if err != nil || !result.Success {
+			b.Fatalf("ReserveSeats() = %+v, %v", result, err)
+		}
+		reservations[i] = reservation{bookingID: result.BookingID, userID: params.UserID}
+	}
+
+	var seq atomic.Int64
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			r := reservations[seq.Add(1)-1]
+			result, err := repo.ReleaseSeats(ctx, r.bookingID, r.userID)
+			if err != nil {
+				b.Error(err)
+				return
+			}
+			if !result.Success {
+				b.Errorf("ReleaseSeats() error code = %s", result.ErrorCode)
+				return
+			}
+		}
+	})
+	reportOpsPerSec(b)
+}
+
+func BenchmarkJoinQueueScript(b *testing.B) {
+	for _, name := range []string{QueueBackendZSet, QueueBackendStreams} {
+		b.Run(name, func(b *testing.B) { benchmarkJoinQueueScript(b, name) })
+	}
+}
+
+// benchmarkJoinQueueScript measures joins of distinct users to one event's queue
+func benchmarkJoinQueueScript(b *testing.B, backendName string) {
+	skipIfNoIntegration(b)
+
+	ctx := context.Background()
+	client := getRedisClient(b)
+	defer client.Close()
+
+	backend, err := NewQueueBackend(client, backendName)
+	if err != nil {
+		b.Fatalf("NewQueueBackend() error = %v", err)
+	}
+	if err := backend.LoadScripts(ctx); err != nil {
+		b.Fatalf("Failed to load scripts: %v", err)
+	}
+
+	var seq atomic.Int64
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			n := seq.Add(1)
+			result, err := backend.Join(ctx, JoinQueueParams{
+				UserID:     fmt.Sprintf("user-%d", n),
+				EventID:    "event-bench",
+				Token:      fmt.Sprintf("token-%d", n),
+				TTLSeconds: 1800,
+			})
+			if err != nil {
+				b.Error(err)
+				return
+			}
+			if !result.Success {
+				b.Errorf("Join() error code = %s", result.ErrorCode)
+				return
+			}
+		}
+	})
+	reportOpsPerSec(b)
+}
+
+func BenchmarkClaimPriceTierScript(b *testing.B) {
+	skipIfNoIntegration(b)
+
+	ctx := context.Background()
+	client := getRedisClient(b)
+	defer client.Close()
+
+	repo := NewRedisPriceTierRepository(client)
+	if err := repo.LoadScripts(ctx); err != nil {
+		b.Fatalf("Failed to load scripts: %v", err)
+	}
+	// Half the claims sell out the first tier, the rest fall through to the second
+	tiers := []PriceTierAllocation{{TierID: "early", Allocation: b.N/2 + 1}, {TierID: "regular"}}
+
+	var seq atomic.Int64
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			tierID, err := repo.ClaimTier(ctx, "zone-bench", fmt.Sprintf("booking-%d", seq.Add(1)), 1, tiers)
+			if err != nil {
+				b.Error(err)
+				return
+			}
+			if tierID == "" {
+				b.Error("ClaimTier() found no tier with seats left")
+				return
+			}
+		}
+	})
+	reportOpsPerSec(b)
+}
+
+func TestLuaScriptThroughput(t *testing.T) {
+	skipIfNoIntegration(t)
+	if testing.Short() {
+		t.Skip("Skipping script throughput floors in short mode")
+	}
+
+	scale := 1.0
+	if value := os.Getenv("LUA_BENCH_FLOOR_SCALE"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil || parsed <= 0 {
+			t.Fatalf("Invalid LUA_BENCH_FLOOR_SCALE %q", value)
+		}
+		scale = parsed
+	}
+
+	names := make([]string, 0, len(scriptThroughputFloors))
+	for name := range scriptThroughputFloors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			result := testing.Benchmark(scriptBenchmarks[name])
+			if result.N == 0 || result.T <= 0 {
+				t.Fatal("Benchmark failed; run it with -bench for the error")
+			}
+
+			opsPerSec := float64(result.N) / result.T.Seconds()
+			floor := scriptThroughputFloors[name] * scale
+			t.Logf("%s: %.0f ops/s over %d runs (floor %.0f ops/s)", name, opsPerSec, result.N, floor)
+			if opsPerSec < floor {
+				t.Errorf("%s throughput %.0f ops/s is below the floor of %.0f ops/s", name, opsPerSec, floor)
+			}
+		})
+	}
+}
+
+func TestLuaScripts_SoldOutShedding(t *testing.T) {
+	skipIfNoIntegration(t)
+
+	ctx := context.Background()
+	const seats, requests = 50, 500
+	repo := newBenchReservationRepo(t, "zone-shedding", seats)
+
+	// reserveAll runs one single-seat reservation per user concurrently and counts
+	// the outcomes by error code ("" = reserved)
+	reserveAll := func(from, count int) map[string]int {
+		var (
+			mu       sync.Mutex
+			wg       sync.WaitGroup
+			outcomes = map[string]int{}
+		)
+		for i := from; i < from+count; i++ {
+			wg.Add(1)
+			go func(n int) {
+				defer wg.Done()
+				result, err := repo.ReserveSeats(ctx, benchReserveParams("zone-shedding", int64(n)))
+				mu.Lock()
+				defer mu.Unlock()
+				switch {
+				case err != nil:
+					outcomes["error"]++
+				case result.Success:
+					outcomes[""]++
+				default:
+					outcomes[result.ErrorCode]++
+				}
+			}(i)
+		}
+		wg.Wait()
+		return outcomes
+	}
+
+	outcomes := reserveAll(0, requests)
+	if outcomes[""] != seats || outcomes["INSUFFICIENT_STOCK"] != requests-seats {
+		t.Errorf("Reservations racing for %d seats = %v, want %d reserved and %d INSUFFICIENT_STOCK",
+			seats, outcomes, seats, requests-seats)
+	}
+
+	// The surge after the sell-out is rejected without touching inventory
+	outcomes = reserveAll(requests, requests)
+	if outcomes["INSUFFICIENT_STOCK"] != requests {
+		t.Errorf("Reservations on a sold-out zone = %v, want %d INSUFFICIENT_STOCK", outcomes, requests)
+	}
+
+	available, err := repo.GetZoneAvailability(ctx, "zone-shedding")
+	if err != nil {
+		t.Fatalf("GetZoneAvailability() error = %v", err)
+	}
+	if available != 0 {
+		t.Errorf("Availability after the sell-out = %d, want 0", available)
+	}
+	reserved, err := repo.GetUserReservedCount(ctx, fmt.Sprintf("user-%d", requests), "event-bench")
+	if err != nil {
+		t.Fatalf("GetUserReservedCount() error = %v", err)
+	}
+	if reserved != 0 {
+		t.Errorf("Reserved count of a rejected user = %d, want 0", reserved)
+	}
+}
+
+func TestLuaScripts_ConcurrentReserveRelease(t *testing.T) {
+	skipIfNoIntegration(t)
+
+	ctx := context.Background()
+	const seats, workers, rounds = 20, 100, 10
+	repo := newBenchReservationRepo(t, "zone-churn", seats)
+
+	var reserved, released, failures atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for round := 0; round < rounds; round++ {
+				params := benchReserveParams("zone-churn", int64(worker*rounds+round))
+				result, err := repo.ReserveSeats(ctx, params)
+				if err != nil {
+					failures.Add(1)
+					continue
+				}
+				if !result.Success {
+					continue // Sold out for now; another worker is holding the seats
+				}
+				reserved.Add(1)
+				release, err := repo.ReleaseSeats(ctx, result.BookingID, params.UserID)
+				if err != nil || !release.Success {
+					failures.Add(1)
+					continue
+				}
+				released.Add(1)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	if failures.Load() != 0 {
+		t.Errorf("Reserve/release failures = %d, want 0", failures.Load())
+	}
+	if reserved.Load() == 0 || reserved.Load() != released.Load() {
+		t.Errorf("Reserved %d and released %d, want the same non-zero count", reserved.Load(), released.Load())
+	}
+
+	available, err := repo.GetZoneAvailability(ctx, "zone-churn")
+	if err != nil {
+		t.Fatalf("GetZoneAvailability() error = %v", err)
+	}
+	if available != seats {
+		t.Errorf("Availability after churn = %d, want %d", available, seats)
+	}
+}
+
+func TestLuaScripts_ConcurrentQueueJoins(t *testing.T) {
+	skipIfNoIntegration(t)
+
+	const maxQueueSize, joins = 50, 200
+	for _, name := range []string{QueueBackendZSet, QueueBackendStreams} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			client := getRedisClient(t)
+			defer client.Close()
+
+			backend, err := NewQueueBackend(client, name)
+			if err != nil {
+				t.Fatalf("NewQueueBackend() error = %v", err)
+			}
+			if err := backend.LoadScripts(ctx); err != nil {
+				t.Fatalf("Failed to load scripts: %v", err)
+			}
+
+			var (
+				mu       sync.Mutex
+				wg       sync.WaitGroup
+				outcomes = map[string]int{}
+			)
+			for i := 0; i < joins; i++ {
+				wg.Add(1)
+				go func(n int) {
+					defer wg.Done()
+					result, err := backend.Join(ctx, JoinQueueParams{
+						UserID:       fmt.Sprintf("user-%d", n),
+						EventID:      "event-queue",
+						Token:        fmt.Sprintf("token-%d", n),
+						TTLSeconds:   1800,
+						MaxQueueSize: maxQueueSize,
+					})
+					mu.Lock()
+					defer mu.Unlock()
+					switch {
+					case err != nil:
+						outcomes["error"]++
+					case result.Success && (result.Position < 1 || result.Position > maxQueueSize):
+						outcomes["bad_position"]++
+					case result.Success:
+						outcomes[""]++
+					default:
+						outcomes[result.ErrorCode]++
+					}
+				}(i)
+			}
+			wg.Wait()
+
+			if outcomes[""] != maxQueueSize || outcomes["QUEUE_FULL"] != joins-maxQueueSize {
+				t.Errorf("Joins racing for %d places = %v, want %d joined and %d QUEUE_FULL",
+					maxQueueSize, outcomes, maxQueueSize, joins-maxQueueSize)
+			}
+			size, err := backend.Size(ctx, "event-queue")
+			if err != nil {
+				t.Fatalf("Size() error = %v", err)
+			}
+			if size != maxQueueSize {
+				t.Errorf("Queue size = %d, want %d", size, maxQueueSize)
+			}
+		})
+	}
+}
+
+func TestLuaScripts_ConcurrentPriceTierClaims(t *testing.T) {
+	skipIfNoIntegration(t)
+
+	ctx := context.Background()
+	client := getRedisClient(t)
+	defer client.Close()
+
+	repo := NewRedisPriceTierRepository(client)
+	if err := repo.LoadScripts(ctx); err != nil {
+		t.Fatalf("Failed to load scripts: %v", err)
+	}
+
+	const allocation, claims = 10, 100
+	tiers := []PriceTierAllocation{{TierID: "early", Allocation: allocation}, {TierID: "regular"}}
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		byTier = map[string][]string{}
+	)
+	for i := 0; i < claims; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			bookingID := fmt.Sprintf("booking-%d", n)
+			tierID, err := repo.ClaimTier(ctx, "zone-tiers", bookingID, 1, tiers)
+			if err != nil {
+				tierID = "error"
+			}
+			mu.Lock()
+			byTier[tierID] = append(byTier[tierID], bookingID)
+			mu.Unlock()
+		}(i)
+	}
+	wg.Wait()
+
+	if len(byTier["early"]) != allocation || len(byTier["regular"]) != claims-allocation {
+		t.Fatalf("Claims racing for %d early-bird seats: %d early, %d regular, %d failed",
+			allocation, len(byTier["early"]), len(byTier["regular"]), len(byTier["error"]))
+	}
+
+	// Concurrent releases give every early-bird seat back exactly once
+	for _, bookingID := range byTier["early"] {
+		wg.Add(2)
+		for i := 0; i < 2; i++ {
+			go func(bookingID string) {
+				defer wg.Done()
+				if err := repo.ReleaseTier(ctx, "zone-tiers", bookingID); err != nil {
+					t.Errorf("ReleaseTier() error = %v", err)
+				}
+			}(bookingID)
+		}
+	}
+	wg.Wait()
+
+	sold, err := client.HGet(ctx, priceTierSoldKey("zone-tiers"), "early").Int()
+	if err != nil {
+		t.Fatalf("Failed to read early-bird seats sold: %v", err)
+	}
+	if sold != 0 {
+		t.Errorf("Early-bird seats sold after releases = %d, want 0", sold)
+	}
+	if remaining := client.Client().HLen(ctx, priceTierClaimsKey("zone-tiers")).Val(); remaining != claims-allocation {
+		t.Errorf("Claims left = %d, want %d", remaining, claims-allocation)
+	}
+}
+
+func TestLuaScripts_ConcurrentAddOnClaims(t *testing.T) {
+	skipIfNoIntegration(t)
+
+	ctx := context.Background()
+	client := getRedisClient(t)
+	defer client.Close()
+
+	repo := NewRedisAddOnRepository(client)
+	if err := repo.LoadScripts(ctx); err != nil {
+		t.Fatalf("Failed to load scripts: %v", err)
+	}
+	client.Del(ctx, addOnSoldKey("event-add-ons"), addOnClaimsKey("event-add-ons"))
+
+	const inventory, claims = 10, 100
+	addOns := []AddOnClaim{{AddOnID: "parking", Quantity: 1, Inventory: inventory}, {AddOnID: "tshirt", Quantity: 2}}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		claimed []string
+	)
+	for i := 0; i < claims; i++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			bookingID := fmt.Sprintf("booking-%d", n)
+			soldOut, err := repo.ClaimAddOns(ctx, "event-add-ons", bookingID, addOns)
+			if err != nil || soldOut != "" {
+				return
+			}
+			mu.Lock()
+			claimed = append(claimed, bookingID)
+			mu.Unlock()
+		}(i)
+	}
+	wg.Wait()
+
+	if len(claimed) != inventory {
+		t.Fatalf("Claims racing for %d parking passes: %d claimed", inventory, len(claimed))
+	}
+	// A sold-out parking pass claims no t-shirts either
+	if sold, _ := client.HGet(ctx, addOnSoldKey("event-add-ons"), "tshirt").Int(); sold != 2*inventory {
+		t.Errorf("T-shirts sold = %d, want %d", sold, 2*inventory)
+	}
+
+	// Concurrent releases give every add-on back exactly once
+	for _, bookingID := range claimed {
+		wg.Add(2)
+		for i := 0; i < 2; i++ {
+			go func(bookingID string) {
+				defer wg.Done()
+				if err := repo.ReleaseAddOns(ctx, "event-add-ons", bookingID); err != nil {
+					t.Errorf("ReleaseAddOns() error = %v", err)
+				}
+			}(bookingID)
+		}
+	}
+	wg.Wait()
+
+	for _, addOnID := range []string{"parking", "tshirt"} {
+		if sold, _ := client.HGet(ctx, addOnSoldKey("event-add-ons"), addOnID).Int(); sold != 0 {
+			t.Errorf("%s sold after releases = %d, want 0", addOnID, sold)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package repository
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+//go:embed scripts/save_seat_map.lua
+var saveSeatMapScript string
+
+//go:embed scripts/assign_seats.lua
+var assignSeatsScript string
+
+// Script names for caching
+const (
+	scriptSaveSeatMap = "save_seat_map"
+	scriptAssignSeats = "assign_seats"
+)
+
+func seatMapKey(zoneID string) string    { return fmt.Sprintf("zone:seatmap:%s", zoneID) }
+func seatBitmapKey(zoneID string) string { return fmt.Sprintf("zone:seats:%s", zoneID) }
+func reservationSeatsKey(bookingID string) string {
+	return fmt.Sprintf("reservation:seats:%s", bookingID)
+}
+
+// RedisSeatMapRepository implements SeatMapRepository using Redis
+type RedisSeatMapRepository struct {
+	client *pkgredis.Client
+}
+
+// NewRedisSeatMapRepository creates a new RedisSeatMapRepository
+func NewRedisSeatMapRepository(client *pkgredis.Client) *RedisSeatMapRepository {
+	return &RedisSeatMapRepository{client: client}
+}
+
+// LoadScripts loads all seat map Lua scripts into Redis
+func (r *RedisSeatMapRepository) LoadScripts(ctx context.Context) error {
+	scripts := map[string]string{
+		scriptSaveSeatMap: saveSeatMapScript,
+		scriptAssignSeats: assignSeatsScript,
+	}
+
+	for name, script := range scripts {
+		if _, err := r.client.LoadScript(ctx, name, script); err != nil {
+			return fmt.Errorf("failed to load script %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// Save replaces a zone's seat map and resets its bitmap
+func (r *RedisSeatMapRepository) Save(ctx context.Context, seatMap *domain.SeatMap) error {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.seat_map.save")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("zone_id", seatMap.ZoneID),
+		attribute.Int("rows", len(seatMap.Rows)),
+		attribute.Int("total_seats", seatMap.TotalSeats()),
+	)
+
+	layout, err := json.Marshal(seatMap)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to marshal seat map: %w", err)
+	}
+
+	return r.save(ctx, seatMap.ZoneID, string(layout), seatMap.TotalSeats())
+}
+
+// Delete removes a zone's seat map
+func (r *RedisSeatMapRepository) Delete(ctx context.Context, zoneID string) error {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.seat_map.delete")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("zone_id", zoneID))
+
+	return r.save(ctx, zoneID, "", 0)
+}
+
+// save runs save_seat_map.lua; an empty layout removes the seat map
+func (r *RedisSeatMapRepository) save(ctx context.Context, zoneID, layout string, totalSeats int) error {
+	span := telemetry.SpanFromContext(ctx)
+
+	keys := []string{seatMapKey(zoneID), seatBitmapKey(zoneID)}
+	result := r.client.EvalWithFallback(ctx, scriptSaveSeatMap, saveSeatMapScript, keys, layout, totalSeats)
+	if result.Err() != nil {
+		span.RecordError(result.Err())
+		span.SetStatus(codes.Error, result.Err().Error())
+		return fmt.Errorf("failed to execute save_seat_map script: %w", result.Err())
+	}
+
+	values, err := result.Slice()
+	if err != nil || len(values) < 3 {
+		span.SetStatus(codes.Error, "unexpected result")
+		return fmt.Errorf("failed to parse script result: %v", values)
+	}
+
+	if success, _ := toInt64(values[0]); success != 1 {
+		errorCode, _ := values[1].(string)
+		span.SetAttributes(attribute.String("error_code", errorCode))
+		span.SetStatus(codes.Error, errorCode)
+		if errorCode == "SEAT_MAP_IN_USE" {
+			return domain.ErrSeatMapInUse
+		}
+		errorMessage, _ := values[2].(string)
+		return fmt.Errorf("save_seat_map failed: %s: %s", errorCode, errorMessage)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// Get returns a zone's seat map
+func (r *RedisSeatMapRepository) Get(ctx context.Context, zoneID string) (*domain.SeatMap, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.seat_map.get")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("zone_id", zoneID))
+
+	layout, err := r.client.Get(ctx, seatMapKey(zoneID)).Result()
+	if err != nil {
+		if err.Error() == "redis: nil" {
+			span.SetStatus(codes.Ok, "no seat map")
+			return nil, domain.ErrSeatMapNotFound
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to get seat map: %w", err)
+	}
+
+	var seatMap domain.SeatMap
+	if err := json.Unmarshal([]byte(layout), &seatMap); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to unmarshal seat map: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return &seatMap, nil
+}
+
+// GetBitmap returns the held seats bitmap of a zone
+func (r *RedisSeatMapRepository) GetBitmap(ctx context.Context, zoneID string) (domain.SeatBitmap, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.seat_map.get_bitmap")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("zone_id", zoneID))
+
+	bitmap, err := r.client.Get(ctx, seatBitmapKey(zoneID)).Bytes()
+	if err != nil {
+		if err.Error() == "redis: nil" {
+			span.SetStatus(codes.Ok, "empty bitmap")
+			return domain.SeatBitmap{}, nil
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to get seat bitmap: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return domain.SeatBitmap(bitmap), nil
+}
+
+// AssignSeats atomically marks seats as held by a reservation
+func (r *RedisSeatMapRepository) AssignSeats(ctx context.Context, zoneID, bookingID string, seats []domain.Seat) (*AssignSeatsResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.seat_map.assign_seats")
+	defer span.End()
+
+	labels := domain.SeatLabels(seats)
+	span.SetAttributes(
+		attribute.String("zone_id", zoneID),
+		attribute.String("booking_id", bookingID),
+		attribute.StringSlice("seats", labels),
+	)
+
+	keys := []string{
+		seatBitmapKey(zoneID),
+		fmt.Sprintf("reservation:%s", bookingID),
+		reservationSeatsKey(bookingID),
+	}
+	args := []interface{}{
+		bookingID,                 // ARGV[1]: booking_id
+		zoneID,                    // ARGV[2]: zone_id
+		strings.Join(labels, ","), // ARGV[3]: seat_labels
+	}
+	for _, offset := range domain.SeatOffsets(seats) {
+		args = append(args, offset) // ARGV[4..n]: offsets
+	}
+
+	result := r.client.EvalWithFallback(ctx, scriptAssignSeats, assignSeatsScript, keys, args...)
+	if result.Err() != nil {
+		span.RecordError(result.Err())
+		span.SetStatus(codes.Error, result.Err().Error())
+		return nil, fmt.Errorf("failed to execute assign_seats script: %w", result.Err())
+	}
+
+	values, err := result.Slice()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to parse script result: %w", err)
+	}
+
+	if len(values) < 3 {
+		span.SetStatus(codes.Error, "unexpected result length")
+		return nil, fmt.Errorf("unexpected script result length: %d", len(values))
+	}
+
+	success, _ := toInt64(values[0])
+	if success == 1 {
+		assigned, _ := toInt64(values[1])
+		span.SetStatus(codes.Ok, "")
+		return &AssignSeatsResult{
+			Success:  true,
+			Assigned: assigned,
+		}, nil
+	}
+
+	// Error case
+	errorCode, _ := values[1].(string)
+	errorMessage, _ := values[2].(string)
+	span.SetAttributes(attribute.String("error_code", errorCode))
+	span.SetStatus(codes.Error, errorCode)
+	return &AssignSeatsResult{
+		Success:      false,
+		ErrorCode:    errorCode,
+		ErrorMessage: errorMessage,
+	}, nil
+}
+
+// Ensure RedisSeatMapRepository implements SeatMapRepository
+var _ SeatMapRepository = (*RedisSeatMapRepository)(nil)
@@ -0,0 +1,525 @@
+package repository
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+//go:embed scripts/join_standby.lua
+var joinStandbyScript string
+
+//go:embed scripts/leave_standby.lua
+var leaveStandbyScript string
+
+//go:embed scripts/offer_standby.lua
+var offerStandbyScript string
+
+//go:embed scripts/claim_standby.lua
+var claimStandbyScript string
+
+// Script names for caching
+const (
+	scriptJoinStandby  = "join_standby"
+	scriptLeaveStandby = "leave_standby"
+	scriptOfferStandby = "offer_standby"
+	scriptClaimStandby = "claim_standby"
+)
+
+// standbyZonesKey tracks zones with waiting users or held offers
+const standbyZonesKey = "standby:zones"
+
+// standbyRecord is the JSON value stored in the standby entries and offers hashes
+type standbyRecord struct {
+	EventID   string  `json:"event_id"`
+	ShowID    string  `json:"show_id"`
+	Quantity  int     `json:"quantity"`
+	JoinedAt  float64 `json:"joined_at"`
+	Notify    string  `json:"notify,omitempty"` // Comma-separated invitation channels
+	Locale    string  `json:"locale,omitempty"` // Language of the invitation
+	ExpiresAt int64   `json:"expires_at,omitempty"`
+}
+
+// RedisStandbyRepository implements StandbyRepository using Redis
+type RedisStandbyRepository struct {
+	client *pkgredis.Client
+}
+
+// NewRedisStandbyRepository creates a new RedisStandbyRepository
+func NewRedisStandbyRepository(client *pkgredis.Client) *RedisStandbyRepository {
+	return &RedisStandbyRepository{client: client}
+}
+
+// LoadScripts loads all standby Lua scripts into Redis
+func (r *RedisStandbyRepository) LoadScripts(ctx context.Context) error {
+	scripts := map[string]string{
+		scriptJoinStandby:  joinStandbyScript,
+		scriptLeaveStandby: leaveStandbyScript,
+		scriptOfferStandby: offerStandbyScript,
+		scriptClaimStandby: claimStandbyScript,
+	}
+
+	for name, script := range scripts {
+		if _, err := r.client.LoadScript(ctx, name, script); err != nil {
+			return fmt.Errorf("failed to load script %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func standbyQueueKey(zoneID string) string   { return fmt.Sprintf("standby:queue:%s", zoneID) }
+func standbyEntriesKey(zoneID string) string { return fmt.Sprintf("standby:entries:%s", zoneID) }
+func standbyOffersKey(zoneID string) string  { return fmt.Sprintf("standby:offers:%s", zoneID) }
+func standbyStatsKey(zoneID string) string   { return fmt.Sprintf("standby:stats:%s", zoneID) }
+
+// Join adds a user to the end of a zone's standby list
+func (r *RedisStandbyRepository) Join(ctx context.Context, params JoinStandbyParams) (*JoinStandbyResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.standby.join")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("zone_id", params.ZoneID),
+		attribute.String("user_id", params.UserID),
+		attribute.Int("quantity", params.Quantity),
+	)
+
+	keys := []string{
+		standbyQueueKey(params.ZoneID),
+		standbyEntriesKey(params.ZoneID),
+		standbyOffersKey(params.ZoneID),
+		standbyZonesKey,
+		standbyStatsKey(params.ZoneID),
+	}
+	args := []interface{}{
+		params.UserID,                            // ARGV[1]: user_id
+		params.ZoneID,                            // ARGV[2]: zone_id
+		params.EventID,                           // ARGV[3]: event_id
+		params.ShowID,                            // ARGV[4]: show_id
+		params.Quantity,                          // ARGV[5]: quantity
+		strings.Join(params.NotifyChannels, ","), // ARGV[6]: notify
+		params.Locale,                            // ARGV[7]: locale
+	}
+
+	result := r.client.EvalWithFallback(ctx, scriptJoinStandby, joinStandbyScript, keys, args...)
+	if result.Err() != nil {
+		span.RecordError(result.Err())
+		span.SetStatus(codes.Error, result.Err().Error())
+		return nil, fmt.Errorf("failed to execute join_standby script: %w", result.Err())
+	}
+
+	values, err := result.Slice()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to parse script result: %w", err)
+	}
+
+	if len(values) < 3 {
+		span.SetStatus(codes.Error, "unexpected result length")
+		return nil, fmt.Errorf("unexpected script result length: %d", len(values))
+	}
+
+	success, _ := toInt64(values[0])
+	if success == 1 {
+		position, _ := toInt64(values[1])
+		total, _ := toInt64(values[2])
+		span.SetAttributes(attribute.Int64("position", position))
+		span.SetStatus(codes.Ok, "")
+		return &JoinStandbyResult{
+			Success:      true,
+			Position:     position,
+			TotalWaiting: total,
+		}, nil
+	}
+
+	// Error case
+	errorCode, _ := values[1].(string)
+	errorMessage, _ := values[2].(string)
+	span.SetAttributes(attribute.String("error_code", errorCode))
+	span.SetStatus(codes.Error, errorCode)
+	return &JoinStandbyResult{
+		Success:      false,
+		ErrorCode:    errorCode,
+		ErrorMessage: errorMessage,
+	}, nil
+}
+
+// Leave removes a user from a standby list, returning any held seats to the pool.
+// Returns false if the user was not on the list.
+func (r *RedisStandbyRepository) Leave(ctx context.Context, zoneID, userID string) (bool, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.standby.leave")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("zone_id", zoneID),
+		attribute.String("user_id", userID),
+	)
+
+	keys := []string{
+		standbyQueueKey(zoneID),
+		standbyEntriesKey(zoneID),
+		standbyOffersKey(zoneID),
+		fmt.Sprintf("zone:availability:%s", zoneID),
+		standbyStatsKey(zoneID),
+	}
+
+	result := r.client.EvalWithFallback(ctx, scriptLeaveStandby, leaveStandbyScript, keys, userID)
+	if result.Err() != nil {
+		span.RecordError(result.Err())
+		span.SetStatus(codes.Error, result.Err().Error())
+		return false, fmt.Errorf("failed to execute leave_standby script: %w", result.Err())
+	}
+
+	values, err := result.Slice()
+	if err != nil || len(values) < 2 {
+		span.SetStatus(codes.Error, "unexpected result")
+		return false, fmt.Errorf("failed to parse script result: %v", values)
+	}
+
+	success, _ := toInt64(values[0])
+	if success != 1 {
+		span.SetStatus(codes.Ok, "not on standby")
+		return false, nil
+	}
+
+	released, _ := toInt64(values[1])
+	span.SetAttributes(attribute.Int64("released_seats", released))
+	span.SetStatus(codes.Ok, "")
+	return true, nil
+}
+
+// Get returns the user's standby entry or offer, nil if the user is not on the list
+func (r *RedisStandbyRepository) Get(ctx context.Context, zoneID, userID string) (*domain.StandbyEntry, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.standby.get")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("zone_id", zoneID),
+		attribute.String("user_id", userID),
+	)
+
+	// Held offer takes precedence over the waiting entry
+	if raw, err := r.client.HGet(ctx, standbyOffersKey(zoneID), userID).Result(); err == nil {
+		var rec standbyRecord
+		if err := json.Unmarshal([]byte(raw), &rec); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, fmt.Errorf("failed to decode standby offer: %w", err)
+		}
+		expiresAt := time.Unix(rec.ExpiresAt, 0)
+		span.SetStatus(codes.Ok, "")
+		return &domain.StandbyEntry{
+			ZoneID:         zoneID,
+			EventID:        rec.EventID,
+			ShowID:         rec.ShowID,
+			UserID:         userID,
+			Quantity:       rec.Quantity,
+			Status:         domain.StandbyStatusOffered,
+			JoinedAt:       unixFloatToTime(rec.JoinedAt),
+			NotifyChannels: splitNotifyChannels(rec.Notify),
+			OfferExpiresAt: &expiresAt,
+		}, nil
+	} else if err.Error() != "redis: nil" {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to get standby offer: %w", err)
+	}
+
+	raw, err := r.client.HGet(ctx, standbyEntriesKey(zoneID), userID).Result()
+	if err != nil {
+		if err.Error() == "redis: nil" {
+			span.SetStatus(codes.Ok, "not on standby")
+			return nil, nil
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to get standby entry: %w", err)
+	}
+
+	var rec standbyRecord
+	if err := json.Unmarshal([]byte(raw), &rec); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to decode standby entry: %w", err)
+	}
+
+	rank, err := r.client.ZRank(ctx, standbyQueueKey(zoneID), userID).Result()
+	if err != nil {
+		if err.Error() == "redis: nil" {
+			// Entry without queue membership was already served or dropped
+			span.SetStatus(codes.Ok, "not on standby")
+			return nil, nil
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to get standby position: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int64("position", rank+1))
+	span.SetStatus(codes.Ok, "")
+	return &domain.StandbyEntry{
+		ZoneID:         zoneID,
+		EventID:        rec.EventID,
+		ShowID:         rec.ShowID,
+		UserID:         userID,
+		Quantity:       rec.Quantity,
+		Status:         domain.StandbyStatusWaiting,
+		Position:       rank + 1,
+		JoinedAt:       unixFloatToTime(rec.JoinedAt),
+		NotifyChannels: splitNotifyChannels(rec.Notify),
+	}, nil
+}
+
+// OfferSeats reclaims lapsed offers and holds available seats for waiting users
+// in FIFO order, each for the given exclusive window
+func (r *RedisStandbyRepository) OfferSeats(ctx context.Context, zoneID string, window time.Duration) (*OfferResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.standby.offer_seats")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("zone_id", zoneID))
+
+	keys := []string{
+		fmt.Sprintf("zone:availability:%s", zoneID),
+		standbyQueueKey(zoneID),
+		standbyEntriesKey(zoneID),
+		standbyOffersKey(zoneID),
+		standbyZonesKey,
+		standbyStatsKey(zoneID),
+	}
+	args := []interface{}{
+		zoneID,                // ARGV[1]: zone_id
+		int(window.Seconds()), // ARGV[2]: window_seconds
+	}
+
+	result := r.client.EvalWithFallback(ctx, scriptOfferStandby, offerStandbyScript, keys, args...)
+	if result.Err() != nil {
+		span.RecordError(result.Err())
+		span.SetStatus(codes.Error, result.Err().Error())
+		return nil, fmt.Errorf("failed to execute offer_standby script: %w", result.Err())
+	}
+
+	values, err := result.Slice()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to parse script result: %w", err)
+	}
+
+	if len(values) < 2 {
+		span.SetStatus(codes.Error, "unexpected result length")
+		return nil, fmt.Errorf("unexpected script result length: %d", len(values))
+	}
+
+	success, _ := toInt64(values[0])
+	if success != 1 {
+		errorCode, _ := values[1].(string)
+		var errorMessage string
+		if len(values) > 2 {
+			errorMessage, _ = values[2].(string)
+		}
+		span.SetAttributes(attribute.String("error_code", errorCode))
+		span.SetStatus(codes.Error, errorCode)
+		return &OfferResult{
+			Success:      false,
+			ErrorCode:    errorCode,
+			ErrorMessage: errorMessage,
+		}, nil
+	}
+
+	reclaimed, _ := toInt64(values[1])
+	offers := make([]*domain.StandbyOffer, 0, (len(values)-2)/7)
+	for i := 2; i+6 < len(values); i += 7 {
+		userID, _ := values[i].(string)
+		quantity, _ := toInt64(values[i+1])
+		expiresAt, _ := toInt64(values[i+2])
+		eventID, _ := values[i+3].(string)
+		showID, _ := values[i+4].(string)
+		notify, _ := values[i+5].(string)
+		locale, _ := values[i+6].(string)
+		offers = append(offers, &domain.StandbyOffer{
+			ZoneID:         zoneID,
+			EventID:        eventID,
+			ShowID:         showID,
+			UserID:         userID,
+			Quantity:       int(quantity),
+			ExpiresAt:      time.Unix(expiresAt, 0),
+			NotifyChannels: splitNotifyChannels(notify),
+			Locale:         locale,
+		})
+	}
+
+	span.SetAttributes(
+		attribute.Int64("reclaimed_seats", reclaimed),
+		attribute.Int("offers", len(offers)),
+	)
+	span.SetStatus(codes.Ok, "")
+	return &OfferResult{
+		Success:   true,
+		Offers:    offers,
+		Reclaimed: reclaimed,
+	}, nil
+}
+
+// ClaimOffer turns a held offer into a reservation without touching zone availability
+func (r *RedisStandbyRepository) ClaimOffer(ctx context.Context, params ReserveParams) (*ReserveResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.standby.claim_offer")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("zone_id", params.ZoneID),
+		attribute.String("user_id", params.UserID),
+		attribute.String("event_id", params.EventID),
+		attribute.Int("quantity", params.Quantity),
+	)
+
+	bookingID := params.BookingID
+	if bookingID == "" {
+		bookingID = uuid.New().String()
+	}
+
+	keys := []string{
+		standbyOffersKey(params.ZoneID),
+		fmt.Sprintf("user:reservations:%s:%s", params.UserID, params.EventID),
+		fmt.Sprintf("reservation:%s", bookingID),
+		fmt.Sprintf("zone:availability:%s", params.ZoneID),
+		standbyStatsKey(params.ZoneID),
+		zoneHoldTTLKey(params.ZoneID),
+		eventHoldTTLKey(params.EventID),
+	}
+	args := []interface{}{
+		params.Quantity,   // ARGV[1]: quantity
+		params.MaxPerUser, // ARGV[2]: max_per_user
+		params.UserID,     // ARGV[3]: user_id
+		bookingID,         // ARGV[4]: booking_id
+		params.ZoneID,     // ARGV[5]: zone_id
+		params.EventID,    // ARGV[6]: event_id
+		params.Price,      // ARGV[7]: unit_price
+		params.TTLSeconds, // ARGV[8]: ttl_seconds
+	}
+
+	result := r.client.EvalWithFallback(ctx, scriptClaimStandby, claimStandbyScript, keys, args...)
+	if result.Err() != nil {
+		span.RecordError(result.Err())
+		span.SetStatus(codes.Error, result.Err().Error())
+		return nil, fmt.Errorf("failed to execute claim_standby script: %w", result.Err())
+	}
+
+	values, err := result.Slice()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to parse script result: %w", err)
+	}
+
+	if len(values) < 3 {
+		span.SetStatus(codes.Error, "unexpected result length")
+		return nil, fmt.Errorf("unexpected script result length: %d", len(values))
+	}
+
+	success, _ := toInt64(values[0])
+	if success == 1 {
+		availableSeats, _ := toInt64(values[1])
+		userReserved, _ := toInt64(values[2])
+		span.SetAttributes(attribute.String("booking_id", bookingID))
+		span.SetStatus(codes.Ok, "")
+		return &ReserveResult{
+			Success:        true,
+			BookingID:      bookingID,
+			AvailableSeats: availableSeats,
+			UserReserved:   userReserved,
+			TTLSeconds:     reservedTTL(values, params.TTLSeconds),
+		}, nil
+	}
+
+	// Error case
+	errorCode, _ := values[1].(string)
+	errorMessage, _ := values[2].(string)
+	span.SetAttributes(attribute.String("error_code", errorCode))
+	span.SetStatus(codes.Error, errorCode)
+	return &ReserveResult{
+		Success:      false,
+		ErrorCode:    errorCode,
+		ErrorMessage: errorMessage,
+	}, nil
+}
+
+// ListZones returns zones that currently have waiting users or held offers
+func (r *RedisStandbyRepository) ListZones(ctx context.Context) ([]string, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.standby.list_zones")
+	defer span.End()
+
+	zones, err := r.client.Client().SMembers(ctx, standbyZonesKey).Result()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to list standby zones: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("count", len(zones)))
+	span.SetStatus(codes.Ok, "")
+	return zones, nil
+}
+
+// Stats returns the zone's standby list size and conversion counters
+func (r *RedisStandbyRepository) Stats(ctx context.Context, zoneID string) (*domain.StandbyStats, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.standby.stats")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("zone_id", zoneID))
+
+	pipe := r.client.Client().Pipeline()
+	countersCmd := pipe.HGetAll(ctx, standbyStatsKey(zoneID))
+	waitingCmd := pipe.ZCard(ctx, standbyQueueKey(zoneID))
+	holdingCmd := pipe.HLen(ctx, standbyOffersKey(zoneID))
+	if _, err := pipe.Exec(ctx); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to get standby stats: %w", err)
+	}
+
+	counters := countersCmd.Val()
+	counter := func(field string) int64 {
+		n, _ := toInt64(counters[field])
+		return n
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return &domain.StandbyStats{
+		ZoneID:  zoneID,
+		Waiting: waitingCmd.Val(),
+		Holding: holdingCmd.Val(),
+		Joined:  counter("joined"),
+		Left:    counter("left"),
+		Offered: counter("offered"),
+		Claimed: counter("claimed"),
+		Lapsed:  counter("lapsed"),
+	}, nil
+}
+
+// splitNotifyChannels parses the comma-separated channels stored with an entry
+func splitNotifyChannels(notify string) []string {
+	if notify == "" {
+		return nil
+	}
+	return strings.Split(notify, ",")
+}
+
+// unixFloatToTime converts a Redis TIME-derived float timestamp to time.Time
+func unixFloatToTime(ts float64) time.Time {
+	sec := int64(ts)
+	return time.Unix(sec, int64((ts-float64(sec))*1e9))
+}
+
+// Ensure RedisStandbyRepository implements StandbyRepository
+var _ StandbyRepository = (*RedisStandbyRepository)(nil)
@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strconv"
+
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+)
+
+//go:embed scripts/stream_join_queue.lua
+var streamJoinQueueScript string
+
+//go:embed scripts/stream_queue_position.lua
+var streamQueuePositionScript string
+
+//go:embed scripts/stream_remove_queue.lua
+var streamRemoveQueueScript string
+
+//go:embed scripts/stream_pop_queue.lua
+var streamPopQueueScript string
+
+//go:embed scripts/stream_import_queue.lua
+var streamImportQueueScript string
+
+// Script names for caching
+const (
+	scriptStreamJoinQueue     = "stream_join_queue"
+	scriptStreamQueuePosition = "stream_queue_position"
+	scriptStreamRemoveQueue   = "stream_remove_queue"
+	scriptStreamPopQueue      = "stream_pop_queue"
+	scriptStreamImportQueue   = "stream_import_queue"
+)
+
+// streamQueueEventsKey is the set of event IDs that have a stream queue
+const streamQueueEventsKey = "qstream:events"
+
+// RedisStreamQueueBackend keeps the virtual queue in a Redis Stream per event.
+// Entries are append-only with explicit sequence IDs, so join order survives AOF
+// rewrites and replica promotion exactly as written (no timestamp score ties), and
+// positions are computed from sequence numbers in O(log n).
+type RedisStreamQueueBackend struct {
+	client *pkgredis.Client
+}
+
+// NewRedisStreamQueueBackend creates a new RedisStreamQueueBackend
+func NewRedisStreamQueueBackend(client *pkgredis.Client) *RedisStreamQueueBackend {
+	return &RedisStreamQueueBackend{client: client}
+}
+
+// Name returns the backend name
+func (b *RedisStreamQueueBackend) Name() string {
+	return QueueBackendStreams
+}
+
+// LoadScripts loads the stream queue scripts into Redis
+func (b *RedisStreamQueueBackend) LoadScripts(ctx context.Context) error {
+	scripts := map[string]string{
+		scriptStreamJoinQueue:     streamJoinQueueScript,
+		scriptStreamQueuePosition: streamQueuePositionScript,
+		scriptStreamRemoveQueue:   streamRemoveQueueScript,
+		scriptStreamPopQueue:      streamPopQueueScript,
+		scriptStreamImportQueue:   streamImportQueueScript,
+	}
+
+	for name, script := range scripts {
+		if _, err := b.client.LoadScript(ctx, name, script); err != nil {
+			return fmt.Errorf("failed to load script %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// keys returns the stream, members, left, sequence and events keys for an event
+func (b *RedisStreamQueueBackend) keys(eventID string) []string {
+	return []string{
+		fmt.Sprintf("qstream:%s", eventID),
+		fmt.Sprintf("qstream:members:%s", eventID),
+		fmt.Sprintf("qstream:left:%s", eventID),
+		fmt.Sprintf("qstream:seq:%s", eventID),
+		streamQueueEventsKey,
+	}
+}
+
+// Join appends a user to the stream
+func (b *RedisStreamQueueBackend) Join(ctx context.Context, params JoinQueueParams) (*JoinQueueResult, error) {
+	keys := append(b.keys(params.EventID), queueUserKey(params.EventID, params.UserID))
+	args := []interface{}{
+		params.UserID,       // ARGV[1]: user_id
+		params.EventID,      // ARGV[2]: event_id
+		params.Token,        // ARGV[3]: token
+		params.TTLSeconds,   // ARGV[4]: ttl_seconds
+		params.MaxQueueSize, // ARGV[5]: max_queue_size
+	}
+
+	result := b.client.EvalWithFallback(ctx, scriptStreamJoinQueue, streamJoinQueueScript, keys, args...)
+	if result.Err() != nil {
+		return nil, fmt.Errorf("failed to execute stream_join_queue script: %w", result.Err())
+	}
+
+	values, err := result.Slice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse script result: %w", err)
+	}
+	return parseJoinResult(values)
+}
+
+// Position returns the user's position from their sequence number
+func (b *RedisStreamQueueBackend) Position(ctx context.Context, eventID, userID string) (*QueuePositionResult, error) {
+	result := b.client.EvalWithFallback(ctx, scriptStreamQueuePosition, streamQueuePositionScript, b.keys(eventID)[:3], userID)
+	if result.Err() != nil {
+		return nil, fmt.Errorf("failed to execute stream_queue_position script: %w", result.Err())
+	}
+
+	values, err := result.Slice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse script result: %w", err)
+	}
+
+	if len(values) < 3 {
+		return &QueuePositionResult{IsInQueue: false}, nil
+	}
+
+	position, _ := toInt64(values[1])
+	total, _ := toInt64(values[2])
+	return &QueuePositionResult{
+		Position:     position,
+		TotalInQueue: total,
+		IsInQueue:    true,
+	}, nil
+}
+
+// Remove removes users from anywhere in the stream
+func (b *RedisStreamQueueBackend) Remove(ctx context.Context, eventID string, userIDs ...string) (int64, error) {
+	if len(userIDs) == 0 {
+		return 0, nil
+	}
+
+	args := append([]interface{}{eventID}, stringSliceToInterface(userIDs)...)
+	result := b.client.EvalWithFallback(ctx, scriptStreamRemoveQueue, streamRemoveQueueScript, b.keys(eventID), args...)
+	if result.Err() != nil {
+		return 0, fmt.Errorf("failed to remove from queue: %w", result.Err())
+	}
+	removed, _ := toInt64(result.Val())
+	return removed, nil
+}
+
+// Size returns the stream length
+func (b *RedisStreamQueueBackend) Size(ctx context.Context, eventID string) (int64, error) {
+	count, err := b.client.Client().XLen(ctx, b.keys(eventID)[0]).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get queue size: %w", err)
+	}
+	return count, nil
+}
+
+// Pop removes and returns the first N users of the stream
+func (b *RedisStreamQueueBackend) Pop(ctx context.Context, eventID string, count int64) ([]string, error) {
+	result := b.client.EvalWithFallback(ctx, scriptStreamPopQueue, streamPopQueueScript, b.keys(eventID), count, eventID)
+	if result.Err() != nil {
+		return nil, fmt.Errorf("failed to pop users from queue: %w", result.Err())
+	}
+
+	users, err := result.StringSlice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse script result: %w", err)
+	}
+	return users, nil
+}
+
+// EventIDs returns the events registered in the stream index set
+func (b *RedisStreamQueueBackend) EventIDs(ctx context.Context) ([]string, error) {
+	eventIDs, err := b.client.Client().SMembers(ctx, streamQueueEventsKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stream queues: %w", err)
+	}
+	return eventIDs, nil
+}
+
+// Entries returns the stream entries in order
+func (b *RedisStreamQueueBackend) Entries(ctx context.Context, eventID string) ([]QueueEntry, error) {
+	messages, err := b.client.Client().XRange(ctx, b.keys(eventID)[0], "-", "+").Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read queue: %w", err)
+	}
+
+	entries := make([]QueueEntry, 0, len(messages))
+	for _, msg := range messages {
+		userID, _ := msg.Values["user_id"].(string)
+		joinedAtStr, _ := msg.Values["joined_at"].(string)
+		joinedAt, _ := strconv.ParseFloat(joinedAtStr, 64)
+		entries = append(entries, QueueEntry{UserID: userID, JoinedAt: joinedAt})
+	}
+	return entries, nil
+}
+
+// Import appends entries to an empty stream; returns ErrQueueNotEmpty otherwise
+func (b *RedisStreamQueueBackend) Import(ctx context.Context, eventID string, entries []QueueEntry) (int64, error) {
+	if len(entries) == 0 {
+		return 0, nil
+	}
+
+	args := make([]interface{}, 0, len(entries)*2+1)
+	args = append(args, eventID)
+	for _, e := range entries {
+		args = append(args, e.UserID, strconv.FormatFloat(e.JoinedAt, 'f', 6, 64))
+	}
+
+	result := b.client.EvalWithFallback(ctx, scriptStreamImportQueue, streamImportQueueScript, b.keys(eventID), args...)
+	if result.Err() != nil {
+		return 0, fmt.Errorf("failed to execute stream_import_queue script: %w", result.Err())
+	}
+	imported, _ := toInt64(result.Val())
+	if imported < 0 {
+		return 0, ErrQueueNotEmpty
+	}
+	return imported, nil
+}
+
+// Ensure RedisStreamQueueBackend implements QueueBackend
+var _ QueueBackend = (*RedisStreamQueueBackend)(nil)
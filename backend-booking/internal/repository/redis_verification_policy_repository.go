@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+)
+
+// RedisVerificationPolicyRepository implements VerificationPolicyRepository using the
+// policies ticket-service syncs to Redis
+type RedisVerificationPolicyRepository struct {
+	client *pkgredis.Client
+}
+
+// NewRedisVerificationPolicyRepository creates a new RedisVerificationPolicyRepository
+func NewRedisVerificationPolicyRepository(client *pkgredis.Client) *RedisVerificationPolicyRepository {
+	return &RedisVerificationPolicyRepository{client: client}
+}
+
+// GetEventPolicy returns an event's verification policy; ticket-service only stores policies other than none
+func (r *RedisVerificationPolicyRepository) GetEventPolicy(ctx context.Context, eventID string) (string, error) {
+	policy, err := r.client.Get(ctx, eventVerificationPolicyKey(eventID)).Result()
+	if err != nil {
+		if err.Error() == "redis: nil" {
+			return domain.VerificationPolicyNone, nil
+		}
+		return "", fmt.Errorf("failed to get verification policy: %w", err)
+	}
+	return policy, nil
+}
+
+// eventVerificationPolicyKey returns the Redis key of an event's verification policy
+func eventVerificationPolicyKey(eventID string) string {
+	return fmt.Sprintf("event:verification_policy:%s", eventID)
+}
@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+//go:embed scripts/apply_zone_capacity.lua
+var applyZoneCapacityScript string
+
+// Script name for caching
+const scriptApplyZoneCapacity = "apply_zone_capacity"
+
+// zoneCapacityRetention is how long processed event markers and capacity statuses are kept
+const zoneCapacityRetention = 7 * 24 * time.Hour
+
+// RedisZoneCapacityRepository implements ZoneCapacityRepository using Redis
+type RedisZoneCapacityRepository struct {
+	client *pkgredis.Client
+}
+
+// NewRedisZoneCapacityRepository creates a new RedisZoneCapacityRepository
+func NewRedisZoneCapacityRepository(client *pkgredis.Client) *RedisZoneCapacityRepository {
+	return &RedisZoneCapacityRepository{client: client}
+}
+
+// LoadScripts loads the zone capacity Lua script into Redis
+func (r *RedisZoneCapacityRepository) LoadScripts(ctx context.Context) error {
+	if _, err := r.client.LoadScript(ctx, scriptApplyZoneCapacity, applyZoneCapacityScript); err != nil {
+		return fmt.Errorf("failed to load script %s: %w", scriptApplyZoneCapacity, err)
+	}
+	return nil
+}
+
+// ApplyCapacityChange atomically adjusts zone availability by the capacity delta
+func (r *RedisZoneCapacityRepository) ApplyCapacityChange(ctx context.Context, params ApplyCapacityChangeParams) (*ApplyCapacityChangeResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.zone_capacity.apply")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("event_id", params.EventID),
+		attribute.String("zone_id", params.ZoneID),
+		attribute.Int("delta", params.Delta),
+	)
+
+	// The other shards of a sharded zone cover reductions the primary counter cannot;
+	// a reshard in between is refused by the script and retried once
+	var values []interface{}
+	for attempt := 0; ; attempt++ {
+		shards, err := readZoneShardCount(ctx, r.client, params.ZoneID)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+
+		keys := append([]string{
+			zoneShardKey(params.ZoneID, 0),
+			fmt.Sprintf("zone:capacity:event:%s", params.EventID),
+			zoneShardLayoutKey(params.ZoneID),
+		}, zoneShardKeys(params.ZoneID, shards)[1:]...)
+
+		result := r.client.EvalWithFallback(ctx, scriptApplyZoneCapacity, applyZoneCapacityScript, keys,
+			params.Delta,
+			int64(zoneCapacityRetention.Seconds()),
+			shards,
+		)
+		if result.Err() != nil {
+			span.RecordError(result.Err())
+			span.SetStatus(codes.Error, result.Err().Error())
+			return nil, fmt.Errorf("failed to execute apply_zone_capacity script: %w", result.Err())
+		}
+
+		values, err = result.Slice()
+		if err != nil || len(values) < 2 {
+			span.SetStatus(codes.Error, "unexpected result")
+			return nil, fmt.Errorf("failed to parse script result: %v", values)
+		}
+
+		if code, _ := values[1].(string); code != "SHARD_LAYOUT_CHANGED" || attempt > 0 {
+			break
+		}
+	}
+
+	success, _ := toInt64(values[0])
+	if success != 1 {
+		res := &ApplyCapacityChangeResult{Success: false}
+		if code, ok := values[1].(string); ok {
+			res.ErrorCode = code
+		}
+		if len(values) > 2 {
+			if msg, ok := values[2].(string); ok {
+				res.ErrorMessage = msg
+			}
+		}
+		if len(values) > 3 {
+			res.AvailableSeats, _ = toInt64(values[3])
+		}
+		span.SetAttributes(attribute.String("error_code", res.ErrorCode))
+		span.SetStatus(codes.Ok, res.ErrorCode)
+		return res, nil
+	}
+
+	available, _ := toInt64(values[1])
+	span.SetAttributes(attribute.Int64("available_seats", available))
+	span.SetStatus(codes.Ok, "")
+	return &ApplyCapacityChangeResult{
+		Success:        true,
+		AvailableSeats: available,
+	}, nil
+}
+
+// SaveStatus records the outcome of the latest capacity change for a zone
+func (r *RedisZoneCapacityRepository) SaveStatus(ctx context.Context, status *domain.ZoneCapacityStatus) error {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.zone_capacity.save_status")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("zone_id", status.ZoneID),
+		attribute.String("status", status.Status),
+	)
+
+	data, err := json.Marshal(status)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to marshal capacity status: %w", err)
+	}
+
+	key := fmt.Sprintf("zone:capacity:status:%s", status.ZoneID)
+	if err := r.client.Set(ctx, key, data, zoneCapacityRetention).Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to save capacity status: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// SeedZone initializes zone availability and refreshes the zone hold TTL
+func (r *RedisZoneCapacityRepository) SeedZone(ctx context.Context, params SeedZoneParams) (bool, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.zone_capacity.seed")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("zone_id", params.ZoneID),
+		attribute.Int("available_seats", params.AvailableSeats),
+		attribute.Bool("overwrite", params.Overwrite),
+	)
+
+	key := fmt.Sprintf("zone:availability:%s", params.ZoneID)
+	seeded := true
+	if params.Overwrite {
+		if err := resetZoneAvailability(ctx, r.client, params.ZoneID, int64(params.AvailableSeats)); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return false, err
+		}
+	} else {
+		ok, err := r.client.SetNX(ctx, key, params.AvailableSeats, 0).Result()
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return false, fmt.Errorf("failed to seed zone availability: %w", err)
+		}
+		seeded = ok
+	}
+
+	var err error
+	if params.HoldTTLSeconds == nil || *params.HoldTTLSeconds <= 0 {
+		err = r.client.Del(ctx, zoneHoldTTLKey(params.ZoneID)).Err()
+	} else {
+		err = r.client.Set(ctx, zoneHoldTTLKey(params.ZoneID), *params.HoldTTLSeconds, 0).Err()
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return seeded, fmt.Errorf("failed to sync zone hold TTL: %w", err)
+	}
+
+	span.SetAttributes(attribute.Bool("seeded", seeded))
+	span.SetStatus(codes.Ok, "")
+	return seeded, nil
+}
+
+// RemoveZone drops a zone's availability counters, shard layout and hold TTL
+func (r *RedisZoneCapacityRepository) RemoveZone(ctx context.Context, zoneID string) error {
+	keys := append(zoneShardKeys(zoneID, domain.MaxZoneShards), zoneShardLayoutKey(zoneID), zoneHoldTTLKey(zoneID))
+	if err := r.client.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("failed to remove zone: %w", err)
+	}
+	return nil
+}
+
+var _ ZoneCapacityRepository = (*RedisZoneCapacityRepository)(nil)
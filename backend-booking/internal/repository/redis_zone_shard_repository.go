@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+//go:embed scripts/reshard_zone.lua
+var reshardZoneScript string
+
+// Script name for caching
+const scriptReshardZone = "reshard_zone"
+
+// zoneShardCacheTTL is how long a zone's shard count is cached per instance. A stale
+// count is refused by the scripts, so it only costs a retry after a reshard.
+const zoneShardCacheTTL = 5 * time.Second
+
+// RedisZoneShardRepository implements ZoneShardRepository using Redis
+type RedisZoneShardRepository struct {
+	client *pkgredis.Client
+}
+
+// NewRedisZoneShardRepository creates a new RedisZoneShardRepository
+func NewRedisZoneShardRepository(client *pkgredis.Client) *RedisZoneShardRepository {
+	return &RedisZoneShardRepository{client: client}
+}
+
+// LoadScripts loads the reshard Lua script into Redis
+func (r *RedisZoneShardRepository) LoadScripts(ctx context.Context) error {
+	if _, err := r.client.LoadScript(ctx, scriptReshardZone, reshardZoneScript); err != nil {
+		return fmt.Errorf("failed to load script %s: %w", scriptReshardZone, err)
+	}
+	return nil
+}
+
+// GetLayout returns how a zone's availability is split
+func (r *RedisZoneShardRepository) GetLayout(ctx context.Context, zoneID string) (*domain.ZoneShardLayout, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.zone_shard.get_layout")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("zone_id", zoneID))
+
+	shards, err := readZoneShardCount(ctx, r.client, zoneID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	values, err := r.client.Client().MGet(ctx, zoneShardKeys(zoneID, shards)...).Result()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to get zone shards: %w", err)
+	}
+	if values[0] == nil {
+		span.SetStatus(codes.Error, "zone not found")
+		return nil, domain.ErrZoneNotFound
+	}
+
+	layout := &domain.ZoneShardLayout{ZoneID: zoneID, Shards: shards, Available: make([]int64, shards)}
+	for i, v := range values {
+		if s, ok := v.(string); ok {
+			layout.Available[i], _ = strconv.ParseInt(s, 10, 64)
+		}
+		layout.Total += layout.Available[i]
+	}
+
+	span.SetAttributes(attribute.Int("shards", shards), attribute.Int64("available_seats", layout.Total))
+	span.SetStatus(codes.Ok, "")
+	return layout, nil
+}
+
+// Reshard splits a zone's availability evenly across the given number of shards
+func (r *RedisZoneShardRepository) Reshard(ctx context.Context, zoneID string, shards int) (*domain.ZoneShardLayout, error) {
+	ctx, span := telemetry.StartSpan(ctx, "repo.redis.zone_shard.reshard")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("zone_id", zoneID),
+		attribute.Int("shards", shards),
+	)
+
+	current, err := readZoneShardCount(ctx, r.client, zoneID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	keys := append([]string{zoneShardLayoutKey(zoneID)}, zoneShardKeys(zoneID, max(current, shards))...)
+	result := r.client.EvalWithFallback(ctx, scriptReshardZone, reshardZoneScript, keys, current, shards)
+	if result.Err() != nil {
+		span.RecordError(result.Err())
+		span.SetStatus(codes.Error, result.Err().Error())
+		return nil, fmt.Errorf("failed to execute reshard_zone script: %w", result.Err())
+	}
+
+	values, err := result.Slice()
+	if err != nil || len(values) < 3 {
+		span.SetStatus(codes.Error, "unexpected result")
+		return nil, fmt.Errorf("failed to parse script result: %v", values)
+	}
+
+	if success, _ := toInt64(values[0]); success != 1 {
+		errorCode, _ := values[1].(string)
+		span.SetAttributes(attribute.String("error_code", errorCode))
+		span.SetStatus(codes.Error, errorCode)
+		switch errorCode {
+		case "ZONE_NOT_FOUND":
+			return nil, domain.ErrZoneNotFound
+		case "SHARD_LAYOUT_CHANGED":
+			return nil, domain.ErrShardLayoutChanged
+		}
+		return nil, fmt.Errorf("reshard failed: %s", errorCode)
+	}
+
+	layout := &domain.ZoneShardLayout{ZoneID: zoneID, Shards: shards, Available: make([]int64, 0, shards)}
+	layout.Total, _ = toInt64(values[1])
+	for _, v := range values[2:] {
+		seats, _ := toInt64(v)
+		layout.Available = append(layout.Available, seats)
+	}
+
+	span.SetAttributes(attribute.Int64("available_seats", layout.Total))
+	span.SetStatus(codes.Ok, "")
+	return layout, nil
+}
+
+// ResetAvailability overwrites a zone's availability and empties its other shards
+func (r *RedisZoneShardRepository) ResetAvailability(ctx context.Context, zoneID string, seats int64) error {
+	return resetZoneAvailability(ctx, r.client, zoneID, seats)
+}
+
+// zoneShardLayoutKey returns the Redis key of a zone's shard count
+func zoneShardLayoutKey(zoneID string) string {
+	return fmt.Sprintf("zone:shards:%s", zoneID)
+}
+
+// zoneShardKey returns the Redis key of one availability shard; shard 0 is the primary counter
+func zoneShardKey(zoneID string, shard int) string {
+	if shard == 0 {
+		return fmt.Sprintf("zone:availability:%s", zoneID)
+	}
+	return fmt.Sprintf("zone:availability_shard:%s:%d", zoneID, shard)
+}
+
+// zoneShardKeys returns the keys of the first n availability shards of a zone
+func zoneShardKeys(zoneID string, n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = zoneShardKey(zoneID, i)
+	}
+	return keys
+}
+
+// readZoneShardCount reads a zone's shard count, 1 for zones that are not sharded
+func readZoneShardCount(ctx context.Context, client *pkgredis.Client, zoneID string) (int, error) {
+	shards, err := client.Get(ctx, zoneShardLayoutKey(zoneID)).Int()
+	if err != nil {
+		if err.Error() == "redis: nil" {
+			return 1, nil
+		}
+		return 0, fmt.Errorf("failed to get zone shard count: %w", err)
+	}
+	return min(max(shards, 1), domain.MaxZoneShards), nil
+}
+
+// resetZoneAvailability sets a zone's primary counter and empties every other shard
+// the zone may have, so the shards never count seats twice
+func resetZoneAvailability(ctx context.Context, client *pkgredis.Client, zoneID string, seats int64) error {
+	pipe := client.TxPipeline()
+	pipe.Set(ctx, zoneShardKey(zoneID, 0), seats, 0)
+	pipe.Del(ctx, zoneShardKeys(zoneID, domain.MaxZoneShards)[1:]...)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to set zone availability: %w", err)
+	}
+	return nil
+}
+
+// cachedShardCount is a zone shard count lookup
+type cachedShardCount struct {
+	shards    int
+	fetchedAt time.Time
+}
+
+// zoneShardCache caches zone shard counts so the reservation hot path does not read
+// the layout on every request
+type zoneShardCache struct {
+	client *pkgredis.Client
+	now    func() time.Time
+
+	mu      sync.RWMutex
+	entries map[string]cachedShardCount
+}
+
+// newZoneShardCache creates a new zone shard cache
+func newZoneShardCache(client *pkgredis.Client) *zoneShardCache {
+	return &zoneShardCache{
+		client:  client,
+		now:     time.Now,
+		entries: make(map[string]cachedShardCount),
+	}
+}
+
+// shards returns the cached shard count of a zone
+func (c *zoneShardCache) shards(ctx context.Context, zoneID string) (int, error) {
+	c.mu.RLock()
+	entry, ok := c.entries[zoneID]
+	c.mu.RUnlock()
+	if ok && c.now().Sub(entry.fetchedAt) < zoneShardCacheTTL {
+		return entry.shards, nil
+	}
+
+	shards, err := readZoneShardCount(ctx, c.client, zoneID)
+	if err != nil {
+		return 0, err
+	}
+	c.mu.Lock()
+	c.entries[zoneID] = cachedShardCount{shards: shards, fetchedAt: c.now()}
+	c.mu.Unlock()
+	return shards, nil
+}
+
+// invalidate drops a zone's cached shard count after a script refused it as stale
+func (c *zoneShardCache) invalidate(zoneID string) {
+	c.mu.Lock()
+	delete(c.entries, zoneID)
+	c.mu.Unlock()
+}
+
+// pickShard returns a random shard so concurrent reservations spread across counters
+func pickShard(shards int) int {
+	if shards <= 1 {
+		return 0
+	}
+	return rand.Intn(shards)
+}
+
+var _ ZoneShardRepository = (*RedisZoneShardRepository)(nil)
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestRedisZoneShardRepository_ShardedReservations(t *testing.T) {
+	skipIfNoIntegration(t)
+
+	ctx := context.Background()
+	client := getRedisClient(t)
+	defer client.Close()
+
+	reservationRepo := NewRedisReservationRepository(client)
+	shardRepo := NewRedisZoneShardRepository(client)
+	if err := reservationRepo.LoadScripts(ctx); err != nil {
+		t.Fatalf("Failed to load scripts: %v", err)
+	}
+
+	zoneID := "zone-sharded-001"
+	if err := reservationRepo.SetZoneAvailability(ctx, zoneID, 10); err != nil {
+		t.Fatalf("Failed to set zone availability: %v", err)
+	}
+
+	layout, err := shardRepo.Reshard(ctx, zoneID, 4)
+	if err != nil {
+		t.Fatalf("Reshard() error = %v", err)
+	}
+	if layout.Total != 10 || fmt.Sprint(layout.Available) != "[3 3 2 2]" {
+		t.Errorf("Reshard() = %d %v, want 10 split as [3 3 2 2]", layout.Total, layout.Available)
+	}
+
+	// Every shard can run dry; reservations rebalance until the zone is sold out
+	var bookingID string
+	for i := 0; i < 3; i++ {
+		result, err := reservationRepo.ReserveSeats(ctx, ReserveParams{
+			ZoneID:     zoneID,
+			UserID:     fmt.Sprintf("user-%d", i),
+			EventID:    "event-001",
+			Quantity:   3,
+			TTLSeconds: 600,
+			Price:      100,
+		})
+		if err != nil || !result.Success {
+			t.Fatalf("ReserveSeats() #%d = %+v, %v", i, result, err)
+		}
+		if want := int64(10 - 3*(i+1)); result.AvailableSeats != want {
+			t.Errorf("ReserveSeats() #%d available = %d, want %d", i, result.AvailableSeats, want)
+		}
+		bookingID = result.BookingID
+	}
+
+	result, err := reservationRepo.ReserveSeats(ctx, ReserveParams{
+		ZoneID: zoneID, UserID: "user-9", EventID: "event-001", Quantity: 2, TTLSeconds: 600,
+	})
+	if err != nil || result.ErrorCode != "INSUFFICIENT_STOCK" {
+		t.Fatalf("ReserveSeats() on 1 remaining seat = %+v, %v, want INSUFFICIENT_STOCK", result, err)
+	}
+
+	if _, err := reservationRepo.ReleaseSeats(ctx, bookingID, "user-2"); err != nil {
+		t.Fatalf("ReleaseSeats() error = %v", err)
+	}
+	if available, _ := reservationRepo.GetZoneAvailability(ctx, zoneID); available != 4 {
+		t.Errorf("GetZoneAvailability() = %d, want 4", available)
+	}
+
+	// Folding back keeps the total
+	layout, err = shardRepo.Reshard(ctx, zoneID, 1)
+	if err != nil {
+		t.Fatalf("Reshard(1) error = %v", err)
+	}
+	if layout.Total != 4 || len(layout.Available) != 1 {
+		t.Errorf("Reshard(1) = %d %v, want 4 on one counter", layout.Total, layout.Available)
+	}
+}
@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strings"
+
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+)
+
+//go:embed scripts/join_queue.lua
+var joinQueueScript string
+
+//go:embed scripts/import_queue.lua
+var importQueueScript string
+
+// Script names for caching
+const (
+	scriptJoinQueue   = "join_queue"
+	scriptImportQueue = "import_queue"
+)
+
+// RedisZSetQueueBackend keeps the virtual queue in a Sorted Set per event
+// (queue:{event_id}, score = join timestamp, member = user_id)
+type RedisZSetQueueBackend struct {
+	client *pkgredis.Client
+}
+
+// NewRedisZSetQueueBackend creates a new RedisZSetQueueBackend
+func NewRedisZSetQueueBackend(client *pkgredis.Client) *RedisZSetQueueBackend {
+	return &RedisZSetQueueBackend{client: client}
+}
+
+// Name returns the backend name
+func (b *RedisZSetQueueBackend) Name() string {
+	return QueueBackendZSet
+}
+
+// LoadScripts loads the Sorted Set queue scripts into Redis
+func (b *RedisZSetQueueBackend) LoadScripts(ctx context.Context) error {
+	scripts := map[string]string{
+		scriptJoinQueue:   joinQueueScript,
+		scriptImportQueue: importQueueScript,
+	}
+
+	for name, script := range scripts {
+		if _, err := b.client.LoadScript(ctx, name, script); err != nil {
+			return fmt.Errorf("failed to load script %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// queueKey is the Sorted Set holding an event's queue
+func (b *RedisZSetQueueBackend) queueKey(eventID string) string {
+	return fmt.Sprintf("queue:%s", eventID)
+}
+
+// Join adds a user to the Sorted Set
+func (b *RedisZSetQueueBackend) Join(ctx context.Context, params JoinQueueParams) (*JoinQueueResult, error) {
+	keys := []string{b.queueKey(params.EventID), queueUserKey(params.EventID, params.UserID)}
+	args := []interface{}{
+		params.UserID,       // ARGV[1]: user_id
+		params.EventID,      // ARGV[2]: event_id
+		params.Token,        // ARGV[3]: token
+		params.TTLSeconds,   // ARGV[4]: ttl_seconds
+		params.MaxQueueSize, // ARGV[5]: max_queue_size
+	}
+
+	result := b.client.EvalWithFallback(ctx, scriptJoinQueue, joinQueueScript, keys, args...)
+	if result.Err() != nil {
+		return nil, fmt.Errorf("failed to execute join_queue script: %w", result.Err())
+	}
+
+	values, err := result.Slice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse script result: %w", err)
+	}
+	return parseJoinResult(values)
+}
+
+// Position returns the user's rank in the Sorted Set
+func (b *RedisZSetQueueBackend) Position(ctx context.Context, eventID, userID string) (*QueuePositionResult, error) {
+	queueKey := b.queueKey(eventID)
+
+	// Get user's rank in sorted set (0-indexed)
+	rank, err := b.client.ZRank(ctx, queueKey, userID).Result()
+	if err != nil {
+		if err.Error() == "redis: nil" {
+			return &QueuePositionResult{IsInQueue: false}, nil
+		}
+		return nil, fmt.Errorf("failed to get queue position: %w", err)
+	}
+
+	total, err := b.client.ZCard(ctx, queueKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get queue size: %w", err)
+	}
+
+	return &QueuePositionResult{
+		Position:     rank + 1, // Convert to 1-indexed
+		TotalInQueue: total,
+		IsInQueue:    true,
+	}, nil
+}
+
+// Remove removes users from the Sorted Set
+func (b *RedisZSetQueueBackend) Remove(ctx context.Context, eventID string, userIDs ...string) (int64, error) {
+	if len(userIDs) == 0 {
+		return 0, nil
+	}
+	removed, err := b.client.ZRem(ctx, b.queueKey(eventID), stringSliceToInterface(userIDs)...).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to remove from queue: %w", err)
+	}
+	return removed, nil
+}
+
+// Size returns the Sorted Set cardinality
+func (b *RedisZSetQueueBackend) Size(ctx context.Context, eventID string) (int64, error) {
+	count, err := b.client.ZCard(ctx, b.queueKey(eventID)).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get queue size: %w", err)
+	}
+	return count, nil
+}
+
+// Pop removes the first N users (lowest scores = earliest joined)
+func (b *RedisZSetQueueBackend) Pop(ctx context.Context, eventID string, count int64) ([]string, error) {
+	queueKey := b.queueKey(eventID)
+
+	result, err := b.client.ZRange(ctx, queueKey, 0, count-1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users from queue: %w", err)
+	}
+
+	if len(result) == 0 {
+		return []string{}, nil
+	}
+
+	if _, err := b.client.ZRem(ctx, queueKey, stringSliceToInterface(result)...).Result(); err != nil {
+		return nil, fmt.Errorf("failed to remove users from queue: %w", err)
+	}
+
+	return result, nil
+}
+
+// EventIDs returns all event IDs that have a Sorted Set queue
+func (b *RedisZSetQueueBackend) EventIDs(ctx context.Context) ([]string, error) {
+	// Scan for all queue keys matching pattern "queue:*"
+	// But exclude user-specific keys "queue:user:*", "queue:pass:*" and config keys
+	var eventIDs []string
+	var cursor uint64
+
+	for {
+		keys, nextCursor, err := b.client.Scan(ctx, cursor, "queue:*", 100).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan queue keys: %w", err)
+		}
+
+		for _, key := range keys {
+			// Skip user-specific keys
+			if len(key) > 11 && key[6:10] == "user" {
+				continue
+			}
+			if len(key) > 11 && key[6:10] == "pass" {
+				continue
+			}
+			// Skip event queue config hashes "queue:config:*"
+			if strings.HasPrefix(key, "queue:config:") {
+				continue
+			}
+			// Extract event ID from "queue:{eventID}"
+			if len(key) > 6 {
+				eventID := key[6:] // Remove "queue:" prefix
+				eventIDs = append(eventIDs, eventID)
+			}
+		}
+
+		cursor = nextCursor
+		if cursor == 0 {
+			break
+		}
+	}
+
+	return eventIDs, nil
+}
+
+// Entries returns the Sorted Set members ordered by join time
+func (b *RedisZSetQueueBackend) Entries(ctx context.Context, eventID string) ([]QueueEntry, error) {
+	members, err := b.client.Client().ZRangeWithScores(ctx, b.queueKey(eventID), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read queue: %w", err)
+	}
+
+	entries := make([]QueueEntry, 0, len(members))
+	for _, m := range members {
+		userID, _ := m.Member.(string)
+		entries = append(entries, QueueEntry{UserID: userID, JoinedAt: m.Score})
+	}
+	return entries, nil
+}
+
+// Import adds entries scored by their join time, so they merge into an existing queue in order
+func (b *RedisZSetQueueBackend) Import(ctx context.Context, eventID string, entries []QueueEntry) (int64, error) {
+	if len(entries) == 0 {
+		return 0, nil
+	}
+
+	args := make([]interface{}, 0, len(entries)*2)
+	for _, e := range entries {
+		args = append(args, e.UserID, e.JoinedAt)
+	}
+
+	result := b.client.EvalWithFallback(ctx, scriptImportQueue, importQueueScript, []string{b.queueKey(eventID)}, args...)
+	if result.Err() != nil {
+		return 0, fmt.Errorf("failed to execute import_queue script: %w", result.Err())
+	}
+	imported, _ := toInt64(result.Val())
+	return imported, nil
+}
+
+// Ensure RedisZSetQueueBackend implements QueueBackend
+var _ QueueBackend = (*RedisZSetQueueBackend)(nil)
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// RefundBatchRepository defines the interface for refund batches of cancelled shows
+type RefundBatchRepository interface {
+	// CreateForShow creates the show's batch, or reuses the existing one, and adds an item for
+	// every confirmed booking of the show not yet in it. Failed items are reset to pending.
+	// Returns the batch with all its items.
+	CreateForShow(ctx context.Context, batch *domain.RefundBatch) (*domain.RefundBatch, error)
+
+	// GetByID retrieves a batch with all its items
+	GetByID(ctx context.Context, id string) (*domain.RefundBatch, error)
+
+	// ClaimPending returns up to limit pending items and pushes their next attempt back by lease,
+	// so concurrent workers do not send the same refund twice
+	ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*domain.RefundBatchItem, error)
+
+	// MarkRequested records that the refund command of a pending item was sent
+	MarkRequested(ctx context.Context, batchID, bookingID string) error
+
+	// RecordResult stores the refund outcome of a booking's outstanding item. A successful refund
+	// also marks the booking refunded. The batch is completed once no refund is outstanding.
+	// Returns false if the booking has no outstanding refund.
+	RecordResult(ctx context.Context, bookingID string, refunded bool, errMsg string) (bool, error)
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// ReportScheduleRepository defines the interface for organizer report schedules
+type ReportScheduleRepository interface {
+	// Upsert creates or replaces the tenant's schedule and resubscribes it.
+	// The unsubscribe token of an existing schedule is kept.
+	Upsert(ctx context.Context, schedule *domain.ReportSchedule) (*domain.ReportSchedule, error)
+
+	// GetByTenant retrieves the tenant's schedule
+	GetByTenant(ctx context.Context, tenantID string) (*domain.ReportSchedule, error)
+
+	// Delete removes the tenant's schedule
+	Delete(ctx context.Context, tenantID string) error
+
+	// Unsubscribe stops the reports of the schedule with the token
+	Unsubscribe(ctx context.Context, token string) (*domain.ReportSchedule, error)
+
+	// ClaimDue returns up to limit subscribed schedules due at now and hides them from
+	// other workers for lease
+	ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*domain.ReportSchedule, error)
+
+	// MarkSent records the report of the run at runAt and schedules the next one.
+	// A schedule changed since it was claimed is left alone.
+	MarkSent(ctx context.Context, tenantID string, runAt, nextRunAt time.Time) error
+}
+
+// SalesReportRepository defines the interface for the per-event sales aggregations of reports
+type SalesReportRepository interface {
+	// SummarizeEvents aggregates the tenant's bookings and refunds in [from, to) per event.
+	// Empty eventIDs covers every event with activity in the period.
+	SummarizeEvents(ctx context.Context, tenantID string, eventIDs []string, from, to time.Time) ([]*domain.EventSalesSummary, error)
+}
@@ -2,16 +2,19 @@ package repository
 
 import (
 	"context"
+	"time"
 )
 
 // ReserveResult represents the result of a seat reservation
 type ReserveResult struct {
-	Success          bool
-	BookingID        string
-	AvailableSeats   int64
-	UserReserved     int64
-	ErrorCode        string
-	ErrorMessage     string
+	Success        bool
+	Replayed       bool // The idempotency key already reserved BookingID; no seats were taken
+	BookingID      string
+	AvailableSeats int64
+	UserReserved   int64
+	TTLSeconds     int // Hold applied to the reservation (organizer-configured or the requested one)
+	ErrorCode      string
+	ErrorMessage   string
 }
 
 // ConfirmResult represents the result of confirming a booking
@@ -32,6 +35,56 @@ type ReleaseResult struct {
 	ErrorMessage    string
 }
 
+// ModifyPhase selects the step of a two-phase reservation change
+type ModifyPhase string
+
+const (
+	// ModifyHold takes the extra seats a change needs and records it as pending
+	ModifyHold ModifyPhase = "hold"
+	// ModifyCommit returns the seats no longer needed and applies the pending change
+	ModifyCommit ModifyPhase = "commit"
+	// ModifyAbort returns the held seats and drops the pending change
+	ModifyAbort ModifyPhase = "abort"
+)
+
+// ModifyParams contains parameters for a reservation change
+type ModifyParams struct {
+	Phase      ModifyPhase
+	BookingID  string
+	UserID     string
+	ZoneID     string  // Zone after the change (hold only)
+	Quantity   int     // Quantity after the change (hold only)
+	UnitPrice  float64 // Price per seat after the change (hold only)
+	MaxPerUser int
+	PendingTTL int // Seconds after which an unfinished hold can be taken over by a new change
+}
+
+// ModifyResult represents the result of a reservation change step
+type ModifyResult struct {
+	Success        bool
+	AvailableSeats int64 // Seats left in the zone after the change
+	UserReserved   int64
+	ErrorCode      string
+	ErrorMessage   string
+}
+
+// ExtendHoldParams contains parameters for extending a reservation hold
+type ExtendHoldParams struct {
+	BookingID string
+	UserID    string
+	EventID   string
+	Until     time.Time // New expiry; holds already expiring later are left alone
+}
+
+// ExtendHoldResult represents the result of extending a reservation hold
+type ExtendHoldResult struct {
+	Success      bool
+	Extended     bool      // The hold was pushed back (false = it already ran until ExpiresAt)
+	ExpiresAt    time.Time // Expiry of the hold after the call
+	ErrorCode    string
+	ErrorMessage string
+}
+
 // ReservationRepository defines the interface for Redis-based reservation operations
 type ReservationRepository interface {
 	// ReserveSeats atomically reserves seats using Lua script
@@ -43,20 +96,57 @@ type ReservationRepository interface {
 	// ReleaseSeats releases reserved seats back to inventory
 	ReleaseSeats(ctx context.Context, bookingID, userID string) (*ReleaseResult, error)
 
-	// GetZoneAvailability gets the current available seats for a zone
+	// ExtendHold pushes back the expiry of a reserved, unconfirmed reservation
+	ExtendHold(ctx context.Context, params ExtendHoldParams) (*ExtendHoldResult, error)
+
+	// ModifyReservation runs one phase of moving a reservation to another zone or quantity
+	ModifyReservation(ctx context.Context, params ModifyParams) (*ModifyResult, error)
+
+	// GetZoneAvailability gets the current available seats for a zone, summed over its shards
 	GetZoneAvailability(ctx context.Context, zoneID string) (int64, error)
 
-	// SetZoneAvailability sets the available seats for a zone (for initialization)
+	// SetZoneAvailability sets the available seats for a zone (for initialization),
+	// emptying the other shards of a sharded zone
 	SetZoneAvailability(ctx context.Context, zoneID string, seats int64) error
 }
 
+// ReleaseRequest identifies a reservation to release
+type ReleaseRequest struct {
+	BookingID string
+	UserID    string
+}
+
+// BatchReservationReleaser is implemented by reservation repositories that can release
+// many reservations in a few round trips. Callers fall back to ReleaseSeats otherwise.
+type BatchReservationReleaser interface {
+	// ReleaseSeatsBatch releases every request and returns a result or an error for each, in order
+	ReleaseSeatsBatch(ctx context.Context, requests []ReleaseRequest) ([]*ReleaseResult, []error)
+}
+
+// SoldSeatReleaser is implemented by reservation repositories that can put the seats of a
+// confirmed reservation back on sale. Without it a cancelled paid booking keeps its seats sold.
+type SoldSeatReleaser interface {
+	// ReleaseSoldSeats releases a reserved or confirmed reservation back to inventory
+	ReleaseSoldSeats(ctx context.Context, bookingID, userID string) (*ReleaseResult, error)
+}
+
 // ReserveParams contains parameters for seat reservation
 type ReserveParams struct {
-	ZoneID      string
-	UserID      string
-	EventID     string
-	Quantity    int
-	MaxPerUser  int
-	TTLSeconds  int
-	Price       float64
+	ZoneID     string
+	UserID     string
+	EventID    string
+	ShowID     string
+	Quantity   int
+	MaxPerUser int
+	TTLSeconds int // Default hold, overridden by a zone or event hold TTL
+	Price      float64
+
+	// IdempotencyKey indexes the reservation so retries get it back instead of
+	// reserving again (empty = no index)
+	IdempotencyKey        string
+	IdempotencyTTLSeconds int // How long the index is kept, at least the hold plus a minute
+
+	// BookingID reserves under this ID (empty = generate one), so a mirrored write lands
+	// on the same reservation key
+	BookingID string
 }
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// SalesSnapshotRepository defines the interface for the hourly zone snapshots of the reporting schema
+type SalesSnapshotRepository interface {
+	// AggregateZones sums the held and sold seats and the revenue of every zone with live
+	// bookings at the time at. Seat counts are left for the caller to fill in.
+	AggregateZones(ctx context.Context, at time.Time) ([]*domain.ZoneSalesSnapshot, error)
+
+	// SaveSnapshots upserts snapshots, replacing any taken for the same zone and hour
+	SaveSnapshots(ctx context.Context, snapshots []*domain.ZoneSalesSnapshot) error
+
+	// ListEventSnapshots returns the tenant's snapshots of an event in [from, to), oldest first
+	ListEventSnapshots(ctx context.Context, tenantID, eventID string, from, to time.Time) ([]*domain.ZoneSalesSnapshot, error)
+}
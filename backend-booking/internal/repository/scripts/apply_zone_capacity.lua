@@ -0,0 +1,77 @@
+--[[
+    Apply Zone Capacity Lua Script
+    ==============================
+    Atomically adjusts zone availability for a capacity change. Availability
+    already has reserved seats and standby offers deducted, so a reduction is
+    only safe while it does not exceed the current availability. The change is
+    applied to the primary counter of a sharded zone, drawing on the other shards
+    when a reduction takes it below zero.
+
+    Key Structure:
+    - KEYS[1]: zone:availability:{zone_id}  - Available seats count (string/integer)
+    - KEYS[2]: zone:capacity:event:{event_id} - Marker for processed capacity events
+    - KEYS[3]: zone:shards:{zone_id}        - Shard count of the zone (absent = not sharded)
+    - KEYS[4..]: the other shards of a sharded zone
+
+    Arguments:
+    - ARGV[1]: delta             - New total minus previous total
+    - ARGV[2]: marker_ttl        - TTL in seconds for the processed event marker
+    - ARGV[3]: shard_count       - Shard count the caller read (1 = not sharded)
+
+    Returns:
+    - Success: {1, new_available_seats}
+    - Error: {0, error_code, error_message[, available_seats]}
+
+    Error Codes:
+    - DUPLICATE_EVENT: Capacity event was already processed
+    - ZONE_NOT_LIVE: Zone is not tracked in Redis
+    - BELOW_COMMITTED: Reduction exceeds seats not sold or held (includes available_seats)
+    - SHARD_LAYOUT_CHANGED: The zone was resharded since the caller read its layout
+--]]
+
+local zone_availability_key = KEYS[1]
+local event_marker_key = KEYS[2]
+
+local delta = tonumber(ARGV[1])
+local marker_ttl = tonumber(ARGV[2]) or 604800
+local shard_count = tonumber(ARGV[3]) or 1
+
+if redis.call("EXISTS", event_marker_key) == 1 then
+    return {0, "DUPLICATE_EVENT", "Capacity change already processed"}
+end
+
+if KEYS[3] and (tonumber(redis.call("GET", KEYS[3])) or 1) ~= shard_count then
+    return {0, "SHARD_LAYOUT_CHANGED", "Zone was resharded concurrently"}
+end
+
+local available = redis.call("GET", zone_availability_key)
+if not available then
+    return {0, "ZONE_NOT_LIVE", "Zone is not tracked in Redis"}
+end
+available = tonumber(available)
+for i = 4, #KEYS do
+    available = available + (tonumber(redis.call("GET", KEYS[i])) or 0)
+end
+
+if available + delta < 0 then
+    redis.call("SET", event_marker_key, "rejected", "EX", marker_ttl)
+    return {0, "BELOW_COMMITTED", "Reduction exceeds seats not sold or held", available}
+end
+
+-- A reduction the primary counter cannot cover is taken from the other shards
+local primary = redis.call("INCRBY", zone_availability_key, delta)
+for i = 4, #KEYS do
+    if primary >= 0 then
+        break
+    end
+    local seats = tonumber(redis.call("GET", KEYS[i])) or 0
+    if seats > 0 then
+        local moved = math.min(seats, -primary)
+        redis.call("DECRBY", KEYS[i], moved)
+        primary = redis.call("INCRBY", zone_availability_key, moved)
+    end
+end
+local new_available = available + delta
+redis.call("SET", event_marker_key, "applied", "EX", marker_ttl)
+
+return {1, new_available}
@@ -0,0 +1,82 @@
+--[[
+    Assign Seats Lua Script
+    =======================
+    Atomically marks seats picked by best-available allocation as held by a reservation.
+    The seats were chosen from a bitmap read outside the script, so every bit is
+    re-checked here; if any was taken in the meantime nothing is changed and the
+    caller picks again.
+
+    Key Structure:
+    - KEYS[1]: zone:seats:{zone_id}             - Held seats bitmap (bit set = taken)
+    - KEYS[2]: reservation:{booking_id}         - Reservation record (hash)
+    - KEYS[3]: reservation:seats:{booking_id}   - Held seats of the reservation (hash),
+                                                  outlives the reservation so expired holds can be freed
+
+    Arguments:
+    - ARGV[1]: booking_id       - Booking ID (for validation)
+    - ARGV[2]: zone_id          - Zone ID
+    - ARGV[3]: seat_labels      - Comma separated seat labels, e.g. "A-4,A-5"
+    - ARGV[4..n]: offsets       - Bitmap offsets of the seats
+
+    Returns:
+    - Success: {1, seats_assigned, "OK"}
+    - Error: {0, error_code, error_message}
+
+    Error Codes:
+    - RESERVATION_NOT_FOUND: Reservation record does not exist
+    - INVALID_BOOKING_ID: Booking ID does not match
+    - INVALID_STATUS: Reservation is not 'reserved'
+    - ALREADY_ASSIGNED: Reservation already holds seats
+    - SEAT_TAKEN: A requested seat is already held
+--]]
+
+local seats_key = KEYS[1]
+local reservation_key = KEYS[2]
+local reservation_seats_key = KEYS[3]
+
+local booking_id = ARGV[1]
+local zone_id = ARGV[2]
+local seat_labels = ARGV[3]
+
+local reservation = redis.call("HMGET", reservation_key, "booking_id", "status", "seats", "event_id")
+if not reservation[1] then
+    return {0, "RESERVATION_NOT_FOUND", "Reservation does not exist or has expired"}
+end
+if reservation[1] ~= booking_id then
+    return {0, "INVALID_BOOKING_ID", "Booking ID does not match"}
+end
+if reservation[2] ~= "reserved" then
+    return {0, "INVALID_STATUS", "Reservation status is '" .. (reservation[2] or "unknown") .. "', expected 'reserved'"}
+end
+if reservation[3] and reservation[3] ~= "" then
+    return {0, "ALREADY_ASSIGNED", "Reservation already holds seats"}
+end
+
+-- Check every seat before taking any
+local offsets = {}
+for i = 4, #ARGV do
+    local offset = tonumber(ARGV[i])
+    if redis.call("GETBIT", seats_key, offset) == 1 then
+        return {0, "SEAT_TAKEN", "Seat at offset " .. offset .. " is already held"}
+    end
+    offsets[#offsets + 1] = offset
+end
+
+-- === ATOMIC ASSIGN ===
+
+for _, offset in ipairs(offsets) do
+    redis.call("SETBIT", seats_key, offset, 1)
+end
+
+local joined = table.concat(offsets, ",")
+redis.call("HSET", reservation_key, "seats", joined, "seat_labels", seat_labels)
+
+-- Keep the seat record an hour past the reservation so a release after expiry can still free the bits
+local ttl = redis.call("TTL", reservation_key)
+if ttl < 0 then
+    ttl = 600
+end
+redis.call("HSET", reservation_seats_key, "zone_id", zone_id, "event_id", reservation[4] or "", "seats", joined)
+redis.call("EXPIRE", reservation_seats_key, ttl + 3600)
+
+return {1, #offsets, "OK"}
@@ -0,0 +1,50 @@
+--[[
+    Claim Add-ons Lua Script
+    ========================
+    Atomically counts the add-ons of a reservation against each add-on's
+    inventory, all or none.
+
+    Key Structure:
+    - KEYS[1]: event:add_ons:{event_id}       - Units sold per add-on (hash add_on_id -> units)
+    - KEYS[2]: event:add_on_claims:{event_id} - Add-ons claimed per reservation (hash booking_id -> "add_on_id:quantity,...")
+
+    Arguments:
+    - ARGV[1]: booking_id - Reservation the add-ons are claimed for
+    - ARGV[2], ARGV[3], ARGV[4], ...: add_on_id, quantity, inventory triples
+      (inventory 0 = no limit)
+
+    Returns:
+    - Claimed: {1, ""}
+    - Not enough units left: {0, add_on_id}
+
+    A reservation that already claimed its add-ons claims nothing more, so a
+    retried reservation is not counted twice.
+--]]
+
+local sold_key = KEYS[1]
+local claims_key = KEYS[2]
+
+local booking_id = ARGV[1]
+
+if redis.call("HEXISTS", claims_key, booking_id) == 1 then
+    return {1, ""}
+end
+
+for i = 2, #ARGV, 3 do
+    local inventory = tonumber(ARGV[i + 2]) or 0
+    if inventory > 0 then
+        local sold = tonumber(redis.call("HGET", sold_key, ARGV[i])) or 0
+        if sold + tonumber(ARGV[i + 1]) > inventory then
+            return {0, ARGV[i]}
+        end
+    end
+end
+
+local claim = {}
+for i = 2, #ARGV, 3 do
+    redis.call("HINCRBY", sold_key, ARGV[i], tonumber(ARGV[i + 1]))
+    table.insert(claim, ARGV[i] .. ":" .. ARGV[i + 1])
+end
+redis.call("HSET", claims_key, booking_id, table.concat(claim, ","))
+
+return {1, ""}
@@ -0,0 +1,48 @@
+--[[
+    Claim Price Tier Lua Script
+    ===========================
+    Atomically picks the price tier a reservation sells at and counts its seats
+    against the tier's allocation.
+
+    Key Structure:
+    - KEYS[1]: zone:price_tiers:{zone_id}       - Seats sold per tier (hash tier_id -> seats)
+    - KEYS[2]: zone:price_tier_claims:{zone_id} - Tier claimed per reservation (hash booking_id -> "tier_id:quantity")
+
+    Arguments:
+    - ARGV[1]: booking_id - Reservation the seats are claimed for
+    - ARGV[2]: quantity   - Seats to claim
+    - ARGV[3], ARGV[4], ...: tier_id, allocation pairs of the active tiers, in the
+      order they are tried (allocation 0 = no limit)
+
+    Returns:
+    - Claimed: {tier_id, seats_sold_at_tier}
+    - No tier has seats left: {"", 0}
+
+    A reservation that already claimed a tier gets the same tier back, so a
+    retried reservation is not counted twice.
+--]]
+
+local tiers_key = KEYS[1]
+local claims_key = KEYS[2]
+
+local booking_id = ARGV[1]
+local quantity = tonumber(ARGV[2])
+
+local existing = redis.call("HGET", claims_key, booking_id)
+if existing then
+    local tier_id = string.match(existing, "^(.*):%d+$")
+    return {tier_id, tonumber(redis.call("HGET", tiers_key, tier_id)) or 0}
+end
+
+for i = 3, #ARGV, 2 do
+    local tier_id = ARGV[i]
+    local allocation = tonumber(ARGV[i + 1]) or 0
+    local sold = tonumber(redis.call("HGET", tiers_key, tier_id)) or 0
+    if allocation == 0 or sold + quantity <= allocation then
+        sold = redis.call("HINCRBY", tiers_key, tier_id, quantity)
+        redis.call("HSET", claims_key, booking_id, tier_id .. ":" .. quantity)
+        return {tier_id, sold}
+    end
+end
+
+return {"", 0}
@@ -0,0 +1,116 @@
+--[[
+    Claim Standby Lua Script
+    ========================
+    Atomically converts a held standby offer into a reservation.
+    The seats were already deducted from availability when the offer was
+    made, so availability is not decremented again.
+
+    Key Structure:
+    - KEYS[1]: standby:offers:{zone_id}                - Held offers per user (hash)
+    - KEYS[2]: user:reservations:{user_id}:{event_id}  - User's total reserved for this event
+    - KEYS[3]: reservation:{booking_id}                - Reservation record (hash)
+    - KEYS[4]: zone:availability:{zone_id}             - Available seats count
+    - KEYS[5]: standby:stats:{zone_id}                 - Conversion counters (hash)
+    - KEYS[6]: zone:hold_ttl:{zone_id}                 - Organizer hold TTL for the zone (optional)
+    - KEYS[7]: event:hold_ttl:{event_id}               - Organizer hold TTL for the event (optional)
+
+    Arguments:
+    - ARGV[1]: quantity           - Number of seats to reserve
+    - ARGV[2]: max_per_user       - Maximum seats allowed per user per event
+    - ARGV[3]: user_id            - User ID
+    - ARGV[4]: booking_id         - Booking ID (for reservation record)
+    - ARGV[5]: zone_id            - Zone ID
+    - ARGV[6]: event_id           - Event ID
+    - ARGV[7]: unit_price         - Price per seat
+    - ARGV[8]: ttl_seconds        - Reservation TTL (default 600 = 10 min), used when
+                                    neither the zone nor the event sets a hold TTL
+
+    Returns:
+    - Success: {1, remaining_seats, total_user_reserved, ttl_seconds}
+    - Error: {0, error_code, error_message}
+
+    Error Codes:
+    - NO_OFFER: No seats are held for the user
+    - OFFER_EXPIRED: The exclusive window has ended
+    - OFFER_MISMATCH: Request does not match the held offer
+    - USER_LIMIT_EXCEEDED: User has reached max reservation limit
+--]]
+
+local offers_key = KEYS[1]
+local user_reservations_key = KEYS[2]
+local reservation_key = KEYS[3]
+local zone_availability_key = KEYS[4]
+local stats_key = KEYS[5]
+local zone_hold_ttl_key = KEYS[6]
+local event_hold_ttl_key = KEYS[7]
+
+local quantity = tonumber(ARGV[1])
+local max_per_user = tonumber(ARGV[2])
+local user_id = ARGV[3]
+local booking_id = ARGV[4]
+local zone_id = ARGV[5]
+local event_id = ARGV[6]
+local unit_price = ARGV[7]
+local ttl_seconds = tonumber(ARGV[8]) or 600
+
+-- Organizer-configured hold: zone overrides event, both override the service default
+local hold_ttl = tonumber(redis.call("GET", zone_hold_ttl_key)) or tonumber(redis.call("GET", event_hold_ttl_key))
+if hold_ttl and hold_ttl > 0 then
+    ttl_seconds = hold_ttl
+end
+
+local offer = redis.call("HGET", offers_key, user_id)
+if not offer then
+    return {0, "NO_OFFER", "No seats are held for this user"}
+end
+local data = cjson.decode(offer)
+
+local timestamp = redis.call("TIME")
+if tonumber(data.expires_at) <= tonumber(timestamp[1]) then
+    -- Left for offer_standby to reclaim
+    return {0, "OFFER_EXPIRED", "Standby offer has expired"}
+end
+
+if data.quantity ~= quantity or data.event_id ~= event_id then
+    return {0, "OFFER_MISMATCH", "Held offer is for " .. data.quantity .. " seats of event " .. data.event_id}
+end
+
+-- Check user limit
+local user_reserved = tonumber(redis.call("GET", user_reservations_key)) or 0
+if max_per_user and max_per_user > 0 then
+    if (user_reserved + quantity) > max_per_user then
+        return {0, "USER_LIMIT_EXCEEDED", "User limit exceeded. Current: " .. user_reserved .. ", Requested: " .. quantity .. ", Max: " .. max_per_user}
+    end
+end
+
+-- === ATOMIC CLAIM ===
+
+-- 1. Release the offer (seats move from the hold into the reservation)
+redis.call("HDEL", offers_key, user_id)
+
+-- 2. Increment user's reserved count for this event
+local new_user_reserved = redis.call("INCRBY", user_reservations_key, quantity)
+redis.call("EXPIRE", user_reservations_key, ttl_seconds + 60)
+
+-- 3. Create reservation record
+local created_at = timestamp[1] .. "." .. timestamp[2]
+redis.call("HSET", reservation_key,
+    "booking_id", booking_id,
+    "user_id", user_id,
+    "zone_id", zone_id,
+    "event_id", event_id,
+    "show_id", data.show_id or "",
+    "quantity", quantity,
+    "unit_price", unit_price,
+    "status", "reserved",
+    "created_at", created_at,
+    "expires_at", timestamp[1] + ttl_seconds
+)
+redis.call("EXPIRE", reservation_key, ttl_seconds)
+
+-- 4. Count the conversion
+redis.call("HINCRBY", stats_key, "claimed", 1)
+
+local remaining = tonumber(redis.call("GET", zone_availability_key)) or 0
+
+return {1, remaining, new_user_reserved, ttl_seconds}
@@ -5,11 +5,14 @@
 
     Key Structure:
     - KEYS[1]: reservation:{booking_id}              - Reservation record (hash)
+    - KEYS[2]: reservation:seats:{booking_id}        - Held seats of the reservation (hash)
+    - KEYS[3]: zone:journal:{zone_id}                - Reservation journal of the zone (stream, optional)
 
     Arguments:
     - ARGV[1]: booking_id        - Booking ID (for validation)
     - ARGV[2]: user_id           - User ID (for validation)
     - ARGV[3]: payment_id        - Payment ID (optional, for tracking)
+    - ARGV[4]: journal_retention - Milliseconds journal entries are kept, 0 = no journal
 
     Returns:
     - Success: {1, "CONFIRMED", confirmed_at}
@@ -28,6 +31,7 @@ local reservation_key = KEYS[1]
 local booking_id = ARGV[1]
 local user_id = ARGV[2]
 local payment_id = ARGV[3] or ""
+local journal_retention = tonumber(ARGV[4]) or 0
 
 -- Get reservation record
 local reservation = redis.call("HGETALL", reservation_key)
@@ -77,5 +81,24 @@ redis.call("HSET", reservation_key,
 -- 2. Remove TTL - make reservation permanent
 redis.call("PERSIST", reservation_key)
 
+-- 3. Sold seats stay taken; drop the record used to free them after an expired hold
+if KEYS[2] then
+    redis.call("DEL", KEYS[2])
+end
+
+-- 4. Journal the confirmation; entries older than the retention are trimmed as new ones arrive
+if journal_retention > 0 and KEYS[3] then
+    local now_ms = tonumber(timestamp[1]) * 1000 + math.floor(tonumber(timestamp[2]) / 1000)
+    redis.call("XADD", KEYS[3], "MINID", "~", now_ms - journal_retention, "*",
+        "op", "confirm",
+        "booking_id", booking_id,
+        "user_id", user_id,
+        "event_id", reservation_data["event_id"] or "",
+        "quantity", reservation_data["quantity"] or "0",
+        "payment_id", payment_id
+    )
+    redis.call("PEXPIRE", KEYS[3], journal_retention)
+end
+
 -- Return success with confirmation timestamp
 return {1, "CONFIRMED", confirmed_at}
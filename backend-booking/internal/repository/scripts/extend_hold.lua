@@ -0,0 +1,86 @@
+--[[
+    Extend Hold Lua Script
+    ======================
+    Atomically pushes back the expiry of a reservation, e.g. while the payment for it
+    is still processing at the gateway. Holds already expiring at or after the new
+    expiry are left alone, so retries and concurrent callers never shorten a hold.
+
+    Key Structure:
+    - KEYS[1]: reservation:{booking_id}              - Reservation record (hash)
+    - KEYS[2]: user:reservations:{user_id}:{event_id} - User's total reserved for this event
+    - KEYS[3]: reservation:seats:{booking_id}        - Held seats of the reservation (hash)
+
+    Arguments:
+    - ARGV[1]: booking_id        - Booking ID (for validation)
+    - ARGV[2]: user_id           - User ID (for validation)
+    - ARGV[3]: until             - New expiry (unix seconds)
+
+    Returns:
+    - Success: {1, expires_at, extended}  - extended is 1 if the hold was pushed back
+    - Error: {0, error_code, error_message}
+
+    Error Codes:
+    - RESERVATION_NOT_FOUND: Reservation record does not exist (the hold already expired)
+    - INVALID_BOOKING_ID: Booking ID does not match
+    - INVALID_USER_ID: User ID does not match
+    - INVALID_STATUS: Reservation status is not 'reserved'
+--]]
+
+local reservation_key = KEYS[1]
+local user_reservations_key = KEYS[2]
+local seats_key = KEYS[3]
+
+local booking_id = ARGV[1]
+local user_id = ARGV[2]
+local until = tonumber(ARGV[3])
+
+local reservation = redis.call("HGETALL", reservation_key)
+if #reservation == 0 then
+    return {0, "RESERVATION_NOT_FOUND", "Reservation does not exist or has expired"}
+end
+
+local reservation_data = {}
+for i = 1, #reservation, 2 do
+    reservation_data[reservation[i]] = reservation[i + 1]
+end
+
+if reservation_data["booking_id"] ~= booking_id then
+    return {0, "INVALID_BOOKING_ID", "Booking ID does not match"}
+end
+
+if reservation_data["user_id"] ~= user_id then
+    return {0, "INVALID_USER_ID", "User ID does not match"}
+end
+
+if reservation_data["status"] ~= "reserved" then
+    return {0, "INVALID_STATUS", "Reservation status is '" .. (reservation_data["status"] or "unknown") .. "', expected 'reserved'"}
+end
+
+local expires_at = tonumber(reservation_data["expires_at"]) or 0
+if not until or until <= expires_at then
+    return {1, expires_at, 0}
+end
+
+local now = tonumber(redis.call("TIME")[1])
+local ttl_seconds = until - now
+if ttl_seconds <= 0 then
+    return {1, expires_at, 0}
+end
+
+-- === ATOMIC EXTEND ===
+
+-- 1. Push back the reservation record and its expiry
+redis.call("HSET", reservation_key, "expires_at", until)
+redis.call("EXPIRE", reservation_key, ttl_seconds)
+
+-- 2. Keep the user's reserved count for as long as the hold (same buffer as reserve)
+if redis.call("TTL", user_reservations_key) < ttl_seconds + 60 then
+    redis.call("EXPIRE", user_reservations_key, ttl_seconds + 60)
+end
+
+-- 3. Keep the held seats around long enough to be freed after the hold (same buffer as assign)
+if redis.call("EXISTS", seats_key) == 1 and redis.call("TTL", seats_key) < ttl_seconds + 3600 then
+    redis.call("EXPIRE", seats_key, ttl_seconds + 3600)
+end
+
+return {1, until, 1}
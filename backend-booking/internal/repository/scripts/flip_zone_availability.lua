@@ -0,0 +1,30 @@
+--[[
+    Flip Zone Availability Lua Script
+    =================================
+    Records a zone's availability level, reporting whether it changed. Every
+    instance observes its own reservations, so the shared level decides which
+    one announces a flip.
+
+    Key Structure:
+    - KEYS[1]: zone:availability_level:{zone_id} - Last announced level (string)
+
+    Arguments:
+    - ARGV[1]: level       - available, low or sold_out
+    - ARGV[2]: ttl_seconds - How long the level is kept after the last flip
+
+    Returns:
+    - The previous level ("" if unknown) when the level changed
+    - nil when it did not
+--]]
+
+local level_key = KEYS[1]
+local level = ARGV[1]
+local ttl_seconds = tonumber(ARGV[2]) or 86400
+
+local previous = redis.call("GET", level_key)
+if previous == level then
+    return nil
+end
+
+redis.call("SET", level_key, level, "EX", ttl_seconds)
+return previous or ""
@@ -0,0 +1,24 @@
+--[[
+    Import Queue Lua Script
+    =======================
+    Adds migrated users to a Sorted Set queue, keeping their original join time.
+    Users already in the queue are left where they are.
+
+    Key Structure:
+    - KEYS[1]: queue:{event_id} - Sorted Set (score = timestamp, member = user_id)
+
+    Arguments:
+    - ARGV[1..n]: user_id, joined_at pairs in join order
+
+    Returns:
+    - Number of users added
+--]]
+
+local queue_key = KEYS[1]
+
+local added = 0
+for i = 1, #ARGV, 2 do
+    added = added + redis.call("ZADD", queue_key, "NX", ARGV[i + 1], ARGV[i])
+end
+
+return added
@@ -0,0 +1,79 @@
+--[[
+    Join Standby Lua Script
+    =======================
+    Atomically adds a user to a zone's standby list.
+
+    Key Structure:
+    - KEYS[1]: standby:queue:{zone_id}    - Waiting users (sorted set, score = joined_at)
+    - KEYS[2]: standby:entries:{zone_id}  - Entry details per user (hash, JSON values)
+    - KEYS[3]: standby:offers:{zone_id}   - Held offers per user (hash, JSON values)
+    - KEYS[4]: standby:zones              - Zones with standby activity (set)
+    - KEYS[5]: standby:stats:{zone_id}    - Conversion counters (hash)
+
+    Arguments:
+    - ARGV[1]: user_id            - User ID
+    - ARGV[2]: zone_id            - Zone ID
+    - ARGV[3]: event_id           - Event ID
+    - ARGV[4]: show_id            - Show ID
+    - ARGV[5]: quantity           - Number of seats wanted
+    - ARGV[6]: notify             - Comma-separated invitation channels (e.g. "email,push")
+    - ARGV[7]: locale             - Language the invitation is written in (e.g. "th")
+
+    Returns:
+    - Success: {1, position, total_waiting}
+    - Error: {0, error_code, error_message}
+
+    Error Codes:
+    - INVALID_QUANTITY: Quantity must be positive
+    - ALREADY_ON_STANDBY: User is already waiting for this zone
+    - OFFER_PENDING: Seats are already held for the user
+--]]
+
+local queue_key = KEYS[1]
+local entries_key = KEYS[2]
+local offers_key = KEYS[3]
+local zones_key = KEYS[4]
+local stats_key = KEYS[5]
+
+local user_id = ARGV[1]
+local zone_id = ARGV[2]
+local event_id = ARGV[3]
+local show_id = ARGV[4]
+local quantity = tonumber(ARGV[5])
+local notify = ARGV[6] or ""
+local locale = ARGV[7] or ""
+
+-- Validate quantity
+if not quantity or quantity <= 0 then
+    return {0, "INVALID_QUANTITY", "Quantity must be a positive number"}
+end
+
+-- User already holds an offer for this zone
+if redis.call("HEXISTS", offers_key, user_id) == 1 then
+    return {0, "OFFER_PENDING", "Seats are already held for this user"}
+end
+
+-- User already waiting
+if redis.call("ZSCORE", queue_key, user_id) then
+    return {0, "ALREADY_ON_STANDBY", "User is already on the standby list"}
+end
+
+local timestamp = redis.call("TIME")
+local joined_at = tonumber(timestamp[1]) + tonumber(timestamp[2]) / 1000000
+
+redis.call("ZADD", queue_key, joined_at, user_id)
+redis.call("HSET", entries_key, user_id, cjson.encode({
+    event_id = event_id,
+    show_id = show_id,
+    quantity = quantity,
+    joined_at = joined_at,
+    notify = notify,
+    locale = locale
+}))
+redis.call("SADD", zones_key, zone_id)
+redis.call("HINCRBY", stats_key, "joined", 1)
+
+local rank = redis.call("ZRANK", queue_key, user_id)
+local total = redis.call("ZCARD", queue_key)
+
+return {1, rank + 1, total}
@@ -0,0 +1,45 @@
+--[[
+    Leave Standby Lua Script
+    ========================
+    Atomically removes a user from a zone's standby list. If seats are
+    held for the user they are returned to zone availability.
+
+    Key Structure:
+    - KEYS[1]: standby:queue:{zone_id}    - Waiting users (sorted set)
+    - KEYS[2]: standby:entries:{zone_id}  - Entry details per user (hash)
+    - KEYS[3]: standby:offers:{zone_id}   - Held offers per user (hash)
+    - KEYS[4]: zone:availability:{zone_id} - Available seats count
+    - KEYS[5]: standby:stats:{zone_id}     - Conversion counters (hash)
+
+    Arguments:
+    - ARGV[1]: user_id            - User ID
+
+    Returns:
+    - {1, released_seats} if the user was removed
+    - {0, "NOT_ON_STANDBY"} if the user was not on the list
+--]]
+
+local queue_key = KEYS[1]
+local entries_key = KEYS[2]
+local offers_key = KEYS[3]
+local zone_availability_key = KEYS[4]
+local stats_key = KEYS[5]
+
+local user_id = ARGV[1]
+
+local offer = redis.call("HGET", offers_key, user_id)
+if offer then
+    local data = cjson.decode(offer)
+    redis.call("INCRBY", zone_availability_key, data.quantity)
+    redis.call("HDEL", offers_key, user_id)
+    redis.call("HINCRBY", stats_key, "left", 1)
+    return {1, data.quantity}
+end
+
+if redis.call("ZREM", queue_key, user_id) == 1 then
+    redis.call("HDEL", entries_key, user_id)
+    redis.call("HINCRBY", stats_key, "left", 1)
+    return {1, 0}
+end
+
+return {0, "NOT_ON_STANDBY"}
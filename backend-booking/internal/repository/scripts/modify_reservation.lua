@@ -0,0 +1,191 @@
+--[[
+    Modify Reservation Lua Script
+    =============================
+    Moves a reservation to another zone or quantity in two phases, so the price
+    difference can be settled with payment-service in between:
+
+    - hold:   takes the extra seats the change needs and records it as pending
+    - commit: returns the seats the booking no longer needs and applies the change
+    - abort:  returns the held seats and drops the pending change
+
+    Key Structure:
+    - KEYS[1]: reservation:{booking_id}               - Reservation record (hash)
+    - KEYS[2]: zone:availability:{zone_id}            - Available seats of the current zone
+    - KEYS[3]: zone:availability:{new_zone_id}        - Available seats of the new zone (may equal KEYS[2])
+    - KEYS[4]: user:reservations:{user_id}:{event_id} - User's total reserved for this event
+    - KEYS[5]: zone:availability:{pending_zone_id}    - Available seats of the zone a pending change holds seats in
+
+    Arguments:
+    - ARGV[1]: mode              - hold, commit or abort
+    - ARGV[2]: user_id           - User ID (for validation)
+    - ARGV[3]: new_zone_id       - Zone after the change (hold only)
+    - ARGV[4]: new_quantity      - Quantity after the change (hold only)
+    - ARGV[5]: new_unit_price    - Price per seat after the change (hold only)
+    - ARGV[6]: max_per_user      - Maximum seats allowed per user per event (hold only)
+    - ARGV[7]: pending_ttl       - Seconds after which an unfinished hold is aborted by the next hold
+
+    Returns:
+    - Success: {1, available_seats_of_new_zone, user_reserved}
+    - Error: {0, error_code, error_message}
+
+    Error Codes:
+    - RESERVATION_NOT_FOUND: Reservation record does not exist
+    - INVALID_USER: User ID does not match
+    - INVALID_STATUS: Reservation is neither reserved nor confirmed
+    - ASSIGNED_SEATS: Reservation holds assigned seats
+    - MODIFICATION_IN_PROGRESS: Another change holds seats for this reservation
+    - NO_PENDING_CHANGE: Nothing to commit
+    - INVALID_QUANTITY: Quantity must be positive
+    - ZONE_NOT_FOUND: New zone availability key not found
+    - INSUFFICIENT_STOCK: Not enough seats available in the new zone
+    - USER_LIMIT_EXCEEDED: The change exceeds the user's limit
+--]]
+
+local reservation_key = KEYS[1]
+local zone_availability_key = KEYS[2]
+local new_zone_availability_key = KEYS[3]
+local user_reservations_key = KEYS[4]
+local pending_zone_availability_key = KEYS[5]
+
+local mode = ARGV[1]
+local user_id = ARGV[2]
+local pending_ttl = tonumber(ARGV[7]) or 60
+
+-- Get reservation record
+local reservation = redis.call("HGETALL", reservation_key)
+if #reservation == 0 then
+    return {0, "RESERVATION_NOT_FOUND", "Reservation does not exist or has expired"}
+end
+
+-- Convert HGETALL result to table
+local reservation_data = {}
+for i = 1, #reservation, 2 do
+    reservation_data[reservation[i]] = reservation[i + 1]
+end
+
+if reservation_data["user_id"] ~= user_id then
+    return {0, "INVALID_USER", "User ID does not match"}
+end
+
+local now = tonumber(redis.call("TIME")[1])
+local quantity = tonumber(reservation_data["quantity"]) or 0
+local pending_held = tonumber(reservation_data["pending_held"]) or 0
+
+-- Return the seats held by the pending change and forget it
+local function abort_pending()
+    if pending_held > 0 then
+        redis.call("INCRBY", pending_zone_availability_key, pending_held)
+    end
+    redis.call("HDEL", reservation_key, "pending_zone_id", "pending_quantity", "pending_unit_price", "pending_held", "pending_expires_at")
+    pending_held = 0
+end
+
+if mode == "abort" then
+    if reservation_data["pending_quantity"] then
+        abort_pending()
+    end
+    return {1, tonumber(redis.call("GET", pending_zone_availability_key)) or 0, tonumber(redis.call("GET", user_reservations_key)) or 0}
+end
+
+if mode == "commit" then
+    local new_zone_id = reservation_data["pending_zone_id"]
+    local new_quantity = tonumber(reservation_data["pending_quantity"])
+    if not new_quantity then
+        return {0, "NO_PENDING_CHANGE", "Reservation has no pending change"}
+    end
+
+    -- Seats of the old zone the booking no longer needs go back to inventory
+    local released = quantity
+    if new_zone_id == reservation_data["zone_id"] then
+        released = math.max(quantity - new_quantity, 0)
+    end
+    if released > 0 then
+        redis.call("INCRBY", zone_availability_key, released)
+    end
+
+    local user_reserved = tonumber(redis.call("GET", user_reservations_key)) or 0
+    if new_quantity ~= quantity then
+        user_reserved = math.max(user_reserved + new_quantity - quantity, 0)
+        if user_reserved > 0 then
+            redis.call("SET", user_reservations_key, user_reserved, "KEEPTTL")
+            if redis.call("TTL", user_reservations_key) < 0 then
+                redis.call("EXPIRE", user_reservations_key, 660) -- 10 min + 1 min buffer
+            end
+        else
+            redis.call("DEL", user_reservations_key)
+        end
+    end
+
+    redis.call("HSET", reservation_key,
+        "zone_id", new_zone_id,
+        "quantity", new_quantity,
+        "unit_price", reservation_data["pending_unit_price"]
+    )
+    redis.call("HDEL", reservation_key, "pending_zone_id", "pending_quantity", "pending_unit_price", "pending_held", "pending_expires_at")
+    return {1, tonumber(redis.call("GET", new_zone_availability_key)) or 0, user_reserved}
+end
+
+-- === HOLD ===
+
+local status = reservation_data["status"]
+if status ~= "reserved" and status ~= "confirmed" then
+    return {0, "INVALID_STATUS", "Reservation status is '" .. (status or "unknown") .. "', cannot modify"}
+end
+
+if reservation_data["seats"] and reservation_data["seats"] ~= "" then
+    return {0, "ASSIGNED_SEATS", "Reservation holds assigned seats"}
+end
+
+-- A hold left behind by a crashed request is aborted once it is stale
+if reservation_data["pending_quantity"] then
+    if (tonumber(reservation_data["pending_expires_at"]) or 0) > now then
+        return {0, "MODIFICATION_IN_PROGRESS", "Another change to this reservation is in progress"}
+    end
+    abort_pending()
+end
+
+local new_zone_id = ARGV[3]
+local new_quantity = tonumber(ARGV[4])
+local new_unit_price = ARGV[5]
+local max_per_user = tonumber(ARGV[6])
+
+if not new_quantity or new_quantity <= 0 then
+    return {0, "INVALID_QUANTITY", "Quantity must be a positive number"}
+end
+
+-- Seats to take: the whole new quantity in another zone, only the increase in the same zone
+local held = new_quantity
+if new_zone_id == reservation_data["zone_id"] then
+    held = math.max(new_quantity - quantity, 0)
+end
+
+local available = redis.call("GET", new_zone_availability_key)
+if not available then
+    return {0, "ZONE_NOT_FOUND", "Zone availability not initialized"}
+end
+available = tonumber(available)
+
+if available < held then
+    return {0, "INSUFFICIENT_STOCK", "Not enough seats available. Available: " .. available .. ", Requested: " .. held}
+end
+
+local user_reserved = tonumber(redis.call("GET", user_reservations_key)) or 0
+if max_per_user and max_per_user > 0 and new_quantity > quantity then
+    if (user_reserved + new_quantity - quantity) > max_per_user then
+        return {0, "USER_LIMIT_EXCEEDED", "User limit exceeded. Current: " .. user_reserved .. ", Requested: " .. new_quantity .. ", Max: " .. max_per_user}
+    end
+end
+
+if held > 0 then
+    available = redis.call("DECRBY", new_zone_availability_key, held)
+end
+
+redis.call("HSET", reservation_key,
+    "pending_zone_id", new_zone_id,
+    "pending_quantity", new_quantity,
+    "pending_unit_price", new_unit_price,
+    "pending_held", held,
+    "pending_expires_at", now + pending_ttl
+)
+
+return {1, available, user_reserved}
@@ -0,0 +1,118 @@
+--[[
+    Offer Standby Lua Script
+    ========================
+    Atomically hands released seats to standby users.
+
+    1. Lapsed offers are removed and their seats returned to availability.
+    2. Waiting users are served in FIFO order: seats are deducted from
+       availability and held for the user until the exclusive window ends.
+       Serving stops at the first user whose quantity does not fit, so
+       smaller requests further back cannot jump the line.
+
+    Key Structure:
+    - KEYS[1]: zone:availability:{zone_id} - Available seats count
+    - KEYS[2]: standby:queue:{zone_id}     - Waiting users (sorted set)
+    - KEYS[3]: standby:entries:{zone_id}   - Entry details per user (hash)
+    - KEYS[4]: standby:offers:{zone_id}    - Held offers per user (hash)
+    - KEYS[5]: standby:zones               - Zones with standby activity (set)
+    - KEYS[6]: standby:stats:{zone_id}     - Conversion counters (hash)
+
+    Arguments:
+    - ARGV[1]: zone_id            - Zone ID
+    - ARGV[2]: window_seconds     - Exclusive window for each offer
+
+    Returns:
+    - Success: {1, reclaimed_seats,
+                user_id_1, quantity_1, expires_at_1, event_id_1, show_id_1, notify_1, locale_1, ...}
+    - Error: {0, error_code, error_message}
+
+    Error Codes:
+    - ZONE_NOT_FOUND: Zone availability key not found
+--]]
+
+local zone_availability_key = KEYS[1]
+local queue_key = KEYS[2]
+local entries_key = KEYS[3]
+local offers_key = KEYS[4]
+local zones_key = KEYS[5]
+local stats_key = KEYS[6]
+
+local zone_id = ARGV[1]
+local window_seconds = tonumber(ARGV[2]) or 120
+
+local available = redis.call("GET", zone_availability_key)
+if not available then
+    return {0, "ZONE_NOT_FOUND", "Zone availability not initialized"}
+end
+available = tonumber(available)
+
+local timestamp = redis.call("TIME")
+local now = tonumber(timestamp[1])
+
+-- 1. Reclaim lapsed offers
+local reclaimed = 0
+local lapsed = 0
+local offers = redis.call("HGETALL", offers_key)
+for i = 1, #offers, 2 do
+    local data = cjson.decode(offers[i + 1])
+    if tonumber(data.expires_at) <= now then
+        reclaimed = reclaimed + data.quantity
+        lapsed = lapsed + 1
+        redis.call("HDEL", offers_key, offers[i])
+    end
+end
+if reclaimed > 0 then
+    available = redis.call("INCRBY", zone_availability_key, reclaimed)
+    redis.call("HINCRBY", stats_key, "lapsed", lapsed)
+end
+
+-- 2. Serve waiting users in FIFO order
+local result = {1, reclaimed}
+local expires_at = now + window_seconds
+while available > 0 do
+    local head = redis.call("ZRANGE", queue_key, 0, 0)
+    if #head == 0 then
+        break
+    end
+    local user_id = head[1]
+
+    local entry = redis.call("HGET", entries_key, user_id)
+    if not entry then
+        -- Orphaned queue member, drop it
+        redis.call("ZREM", queue_key, user_id)
+    else
+        local data = cjson.decode(entry)
+        if data.quantity > available then
+            break
+        end
+
+        available = redis.call("DECRBY", zone_availability_key, data.quantity)
+        redis.call("HSET", offers_key, user_id, cjson.encode({
+            event_id = data.event_id,
+            show_id = data.show_id,
+            quantity = data.quantity,
+            joined_at = data.joined_at,
+            notify = data.notify,
+            locale = data.locale,
+            expires_at = expires_at
+        }))
+        redis.call("ZREM", queue_key, user_id)
+        redis.call("HDEL", entries_key, user_id)
+        redis.call("HINCRBY", stats_key, "offered", 1)
+
+        table.insert(result, user_id)
+        table.insert(result, data.quantity)
+        table.insert(result, expires_at)
+        table.insert(result, data.event_id or "")
+        table.insert(result, data.show_id or "")
+        table.insert(result, data.notify or "")
+        table.insert(result, data.locale or "")
+    end
+end
+
+-- 3. Forget the zone once nobody is waiting and no seats are held
+if redis.call("ZCARD", queue_key) == 0 and redis.call("HLEN", offers_key) == 0 then
+    redis.call("SREM", zones_key, zone_id)
+end
+
+return result
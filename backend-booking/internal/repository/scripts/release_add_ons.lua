@@ -0,0 +1,31 @@
+--[[
+    Release Add-ons Lua Script
+    ==========================
+    Gives the add-ons a reservation claimed back to their inventory.
+    The release_seats script does the same when the reservation itself is released.
+
+    Key Structure:
+    - KEYS[1]: event:add_ons:{event_id}       - Units sold per add-on (hash add_on_id -> units)
+    - KEYS[2]: event:add_on_claims:{event_id} - Add-ons claimed per reservation (hash booking_id -> "add_on_id:quantity,...")
+
+    Arguments:
+    - ARGV[1]: booking_id - Reservation whose claim is released
+
+    Returns: units given back, 0 if the reservation claimed no add-ons
+--]]
+
+local claim = redis.call("HGET", KEYS[2], ARGV[1])
+if not claim then
+    return 0
+end
+
+local released = 0
+for add_on_id, quantity in string.gmatch(claim, "([^,:]+):(%d+)") do
+    quantity = tonumber(quantity)
+    if redis.call("HINCRBY", KEYS[1], add_on_id, -quantity) < 0 then
+        redis.call("HSET", KEYS[1], add_on_id, 0)
+    end
+    released = released + quantity
+end
+redis.call("HDEL", KEYS[2], ARGV[1])
+return released
@@ -0,0 +1,28 @@
+--[[
+    Release Price Tier Lua Script
+    =============================
+    Gives a reservation's seats back to the allocation of the price tier it claimed.
+    The release_seats script does the same when the reservation itself is released.
+
+    Key Structure:
+    - KEYS[1]: zone:price_tiers:{zone_id}       - Seats sold per tier (hash tier_id -> seats)
+    - KEYS[2]: zone:price_tier_claims:{zone_id} - Tier claimed per reservation (hash booking_id -> "tier_id:quantity")
+
+    Arguments:
+    - ARGV[1]: booking_id - Reservation whose claim is released
+
+    Returns: seats given back, 0 if the reservation claimed no tier
+--]]
+
+local claim = redis.call("HGET", KEYS[2], ARGV[1])
+if not claim then
+    return 0
+end
+
+local tier_id, quantity = string.match(claim, "^(.*):(%d+)$")
+quantity = tonumber(quantity)
+if redis.call("HINCRBY", KEYS[1], tier_id, -quantity) < 0 then
+    redis.call("HSET", KEYS[1], tier_id, 0)
+end
+redis.call("HDEL", KEYS[2], ARGV[1])
+return quantity
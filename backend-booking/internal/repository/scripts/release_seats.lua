@@ -7,32 +7,65 @@
     - KEYS[1]: zone:availability:{zone_id}           - Available seats count (string/integer)
     - KEYS[2]: user:reservations:{user_id}:{event_id} - User's total reserved for this event
     - KEYS[3]: reservation:{booking_id}              - Reservation record (hash)
+    - KEYS[4]: zone:seats:{zone_id}                  - Held seats bitmap (reserved seating zones)
+    - KEYS[5]: reservation:seats:{booking_id}        - Held seats of the reservation (hash)
+    - KEYS[6]: zone:journal:{zone_id}                - Reservation journal of the zone (stream, optional)
+    - KEYS[7]: zone:price_tiers:{zone_id}            - Seats sold per price tier (hash, optional)
+    - KEYS[8]: zone:price_tier_claims:{zone_id}      - Price tier claimed per reservation (hash, optional)
+    - KEYS[9]: event:add_ons:{event_id}              - Units sold per add-on (hash, optional)
+    - KEYS[10]: event:add_on_claims:{event_id}       - Add-ons claimed per reservation (hash, optional)
 
     Arguments:
     - ARGV[1]: booking_id        - Booking ID (for validation)
     - ARGV[2]: user_id           - User ID (for validation)
+    - ARGV[3]: sold              - "1" also releases a confirmed reservation (user cancellation)
+    - ARGV[4]: journal_retention - Milliseconds journal entries are kept, 0 = no journal
 
     Returns:
     - Success: {1, new_available_seats, new_user_reserved}
     - Error: {0, error_code, error_message}
 
+    Assigned seats are freed in the bitmap on release. If the reservation record
+    has already expired, its seats are still freed from KEYS[5] before returning
+    RESERVATION_NOT_FOUND. Seats sold at a price tier go back to the tier's allocation,
+    and add-ons sold with the reservation go back to their inventory.
+
     Error Codes:
     - RESERVATION_NOT_FOUND: Reservation record does not exist
     - INVALID_BOOKING_ID: Booking ID does not match
     - INVALID_USER_ID: User ID does not match
-    - ALREADY_RELEASED: Reservation already released or confirmed
+    - ALREADY_RELEASED: Reservation already released, or confirmed without ARGV[3]
 --]]
 
 local zone_availability_key = KEYS[1]
 local user_reservations_key = KEYS[2]
 local reservation_key = KEYS[3]
 
+local seats_key = KEYS[4]
+local reservation_seats_key = KEYS[5]
+
 local booking_id = ARGV[1]
 local user_id = ARGV[2]
+local sold = ARGV[3] == "1"
+local journal_retention = tonumber(ARGV[4]) or 0
+
+-- Clear the held seat bits listed in a comma separated offsets string
+local function free_seats(offsets)
+    if not seats_key or not offsets or offsets == "" then
+        return
+    end
+    for offset in string.gmatch(offsets, "%d+") do
+        redis.call("SETBIT", seats_key, tonumber(offset), 0)
+    end
+end
 
 -- Get reservation record
 local reservation = redis.call("HGETALL", reservation_key)
 if #reservation == 0 then
+    if reservation_seats_key then
+        free_seats(redis.call("HGET", reservation_seats_key, "seats"))
+        redis.call("DEL", reservation_seats_key)
+    end
     return {0, "RESERVATION_NOT_FOUND", "Reservation does not exist or has expired"}
 end
 
@@ -54,7 +87,7 @@ end
 
 -- Check if already released or confirmed
 local status = reservation_data["status"]
-if status ~= "reserved" then
+if status ~= "reserved" and not (sold and status == "confirmed") then
     return {0, "ALREADY_RELEASED", "Reservation status is '" .. (status or "unknown") .. "', cannot release"}
 end
 
@@ -87,8 +120,56 @@ else
     redis.call("DEL", user_reservations_key)
 end
 
--- 3. Delete reservation record
+-- 3. Free assigned seats (reserved seating zones only)
+free_seats(reservation_data["seats"])
+if reservation_seats_key then
+    redis.call("DEL", reservation_seats_key)
+end
+
+-- 4. Delete reservation record
 redis.call("DEL", reservation_key)
 
+-- 5. Give the seats back to the price tier they were claimed at
+if KEYS[8] then
+    local claim = redis.call("HGET", KEYS[8], booking_id)
+    if claim then
+        local tier_id, tier_quantity = string.match(claim, "^(.*):(%d+)$")
+        if redis.call("HINCRBY", KEYS[7], tier_id, -tonumber(tier_quantity)) < 0 then
+            redis.call("HSET", KEYS[7], tier_id, 0)
+        end
+        redis.call("HDEL", KEYS[8], booking_id)
+    end
+end
+
+-- 6. Give the add-ons back to their inventory
+if KEYS[10] then
+    local claim = redis.call("HGET", KEYS[10], booking_id)
+    if claim then
+        for add_on_id, add_on_quantity in string.gmatch(claim, "([^,:]+):(%d+)") do
+            if redis.call("HINCRBY", KEYS[9], add_on_id, -tonumber(add_on_quantity)) < 0 then
+                redis.call("HSET", KEYS[9], add_on_id, 0)
+            end
+        end
+        redis.call("HDEL", KEYS[10], booking_id)
+    end
+end
+
+-- 7. Journal the release; entries older than the retention are trimmed as new ones arrive
+if journal_retention > 0 and KEYS[6] then
+    local timestamp = redis.call("TIME")
+    local now_ms = tonumber(timestamp[1]) * 1000 + math.floor(tonumber(timestamp[2]) / 1000)
+    redis.call("XADD", KEYS[6], "MINID", "~", now_ms - journal_retention, "*",
+        "op", "release",
+        "booking_id", booking_id,
+        "user_id", user_id,
+        "event_id", reservation_data["event_id"] or "",
+        "quantity", quantity,
+        "available", new_available,
+        "user_reserved", new_user_reserved,
+        "status", status
+    )
+    redis.call("PEXPIRE", KEYS[6], journal_retention)
+end
+
 -- Return success with new available seats and user's new reserved count
 return {1, new_available, new_user_reserved}
@@ -1,12 +1,25 @@
 --[[
     Reserve Seats Lua Script
     ========================
-    Atomically reserves seats for a booking.
+    Atomically reserves seats for a booking. With an idempotency key the script
+    also answers retries from the Redis index, so the write-behind path needs no
+    PostgreSQL lookup before reserving.
+
+    A sharded zone splits its availability across several counters so concurrent
+    reservations do not all hit one key. The caller picks a shard; when it runs
+    short, seats are moved over from the other shards before reserving.
     
     Key Structure:
-    - KEYS[1]: zone:availability:{zone_id}      - Available seats count (string/integer)
+    - KEYS[1]: zone:availability:{zone_id}      - Available seats count (string/integer),
+                                                  or the picked shard of a sharded zone
     - KEYS[2]: user:reservations:{user_id}:{event_id} - User's total reserved for this event
     - KEYS[3]: reservation:{booking_id}         - Reservation record (hash)
+    - KEYS[4]: zone:hold_ttl:{zone_id}          - Organizer hold TTL for the zone (optional)
+    - KEYS[5]: event:hold_ttl:{event_id}        - Organizer hold TTL for the event (optional)
+    - KEYS[6]: booking:idempotency:{key}        - Booking ID reserved for an idempotency key (optional)
+    - KEYS[7]: zone:shards:{zone_id}            - Shard count of the zone (absent = not sharded)
+    - KEYS[8]: zone:journal:{zone_id}           - Reservation journal of the zone (stream, optional)
+    - KEYS[9..]: every shard of a sharded zone, starting with zone:availability:{zone_id}
     
     Arguments:
     - ARGV[1]: quantity           - Number of seats to reserve
@@ -17,10 +30,17 @@
     - ARGV[6]: event_id           - Event ID
     - ARGV[7]: show_id            - Show ID
     - ARGV[8]: unit_price         - Price per seat
-    - ARGV[9]: ttl_seconds        - Reservation TTL (default 600 = 10 min)
+    - ARGV[9]: ttl_seconds        - Reservation TTL (default 600 = 10 min), used when
+                                    neither the zone nor the event sets a hold TTL
+    - ARGV[10]: idempotency_key   - Idempotency key, empty to skip the index
+    - ARGV[11]: idempotency_ttl   - Seconds the index entry is kept (never shorter than the hold)
+    - ARGV[12]: shard_count       - Shard count the caller assumed (1 = not sharded)
+    - ARGV[13]: shard_index       - Index of the picked shard in KEYS[9..] (0-based)
+    - ARGV[14]: journal_retention - Milliseconds journal entries are kept, 0 = no journal
     
     Returns:
-    - Success: {1, remaining_seats, total_user_reserved}
+    - Success: {1, remaining_seats, total_user_reserved, ttl_seconds}
+    - Replay: {2, existing_booking_id, "IDEMPOTENT_REPLAY"}
     - Error: {0, error_code, error_message}
     
     Error Codes:
@@ -28,11 +48,14 @@
     - USER_LIMIT_EXCEEDED: User has reached max reservation limit
     - INVALID_QUANTITY: Quantity must be positive
     - ZONE_NOT_FOUND: Zone availability key not found
+    - SHARD_LAYOUT_CHANGED: The zone was resharded since the caller read its layout
 --]]
 
 local zone_availability_key = KEYS[1]
 local user_reservations_key = KEYS[2]
 local reservation_key = KEYS[3]
+local zone_hold_ttl_key = KEYS[4]
+local event_hold_ttl_key = KEYS[5]
 
 local quantity = tonumber(ARGV[1])
 local max_per_user = tonumber(ARGV[2])
@@ -43,22 +66,87 @@ local event_id = ARGV[6]
 local show_id = ARGV[7]
 local unit_price = ARGV[8]
 local ttl_seconds = tonumber(ARGV[9]) or 600
+local idempotency_key = ARGV[10] or ""
+local idempotency_ttl = tonumber(ARGV[11]) or 0
+local shard_count = tonumber(ARGV[12]) or 1
+local shard_index = tonumber(ARGV[13]) or 0
+local journal_retention = tonumber(ARGV[14]) or 0
+
+-- A retry of an earlier reservation gets the existing booking back without taking seats
+if idempotency_key ~= "" then
+    local existing = redis.call("GET", KEYS[6])
+    if existing then
+        return {2, existing, "IDEMPOTENT_REPLAY"}
+    end
+end
+
+-- Organizer-configured hold: zone overrides event, both override the service default
+local hold_ttl = tonumber(redis.call("GET", zone_hold_ttl_key)) or tonumber(redis.call("GET", event_hold_ttl_key))
+if hold_ttl and hold_ttl > 0 then
+    ttl_seconds = hold_ttl
+end
 
 -- Validate quantity
 if not quantity or quantity <= 0 then
     return {0, "INVALID_QUANTITY", "Quantity must be a positive number"}
 end
 
+-- Shards are picked from a cached layout; refuse once it is stale so seats never
+-- land in a shard the zone no longer has
+local layout = tonumber(redis.call("GET", KEYS[7])) or 1
+if layout ~= shard_count then
+    return {0, "SHARD_LAYOUT_CHANGED", "Zone has " .. layout .. " shards, caller assumed " .. shard_count}
+end
+
 -- Get current available seats
 local available = redis.call("GET", zone_availability_key)
-if not available then
-    return {0, "ZONE_NOT_FOUND", "Zone availability not initialized"}
-end
-available = tonumber(available)
+-- Seats left in the zone after this reservation (all shards together)
+local zone_remaining
+
+if shard_count > 1 then
+    -- The primary counter marks the zone as live; other shards start out empty
+    if redis.call("EXISTS", KEYS[9]) == 0 then
+        return {0, "ZONE_NOT_FOUND", "Zone availability not initialized"}
+    end
+
+    local counts = {}
+    local total = 0
+    for i = 1, shard_count do
+        counts[i] = tonumber(redis.call("GET", KEYS[8 + i])) or 0
+        total = total + counts[i]
+    end
+    if total < quantity then
+        return {0, "INSUFFICIENT_STOCK", "Not enough seats available. Available: " .. total .. ", Requested: " .. quantity}
+    end
+
+    -- Rebalance: top the picked shard up to its fair share from the others
+    available = counts[shard_index + 1]
+    if available < quantity then
+        local need = math.max(quantity, math.ceil(total / shard_count)) - available
+        for i = 1, shard_count do
+            if need <= 0 then
+                break
+            end
+            if i ~= shard_index + 1 and counts[i] > 0 then
+                local moved = math.min(counts[i], need)
+                redis.call("DECRBY", KEYS[8 + i], moved)
+                need = need - moved
+                available = available + moved
+            end
+        end
+        redis.call("SET", zone_availability_key, available)
+    end
+    zone_remaining = total - quantity
+else
+    if not available then
+        return {0, "ZONE_NOT_FOUND", "Zone availability not initialized"}
+    end
+    available = tonumber(available)
 
--- Check seat availability
-if available < quantity then
-    return {0, "INSUFFICIENT_STOCK", "Not enough seats available. Available: " .. available .. ", Requested: " .. quantity}
+    -- Check seat availability
+    if available < quantity then
+        return {0, "INSUFFICIENT_STOCK", "Not enough seats available. Available: " .. available .. ", Requested: " .. quantity}
+    end
 end
 
 -- Get user's current reservations for this event
@@ -76,6 +164,9 @@ end
 
 -- 1. Deduct seats from availability
 local remaining = redis.call("DECRBY", zone_availability_key, quantity)
+if zone_remaining then
+    remaining = zone_remaining
+end
 
 -- 2. Increment user's reserved count for this event
 local new_user_reserved = redis.call("INCRBY", user_reservations_key, quantity)
@@ -103,5 +194,30 @@ redis.call("HSET", reservation_key,
 -- 5. Set TTL on reservation
 redis.call("EXPIRE", reservation_key, ttl_seconds)
 
--- Return success with remaining seats and user's total reserved
-return {1, remaining, new_user_reserved}
+-- 6. Index the booking under its idempotency key
+if idempotency_key ~= "" then
+    if idempotency_ttl < ttl_seconds + 60 then
+        idempotency_ttl = ttl_seconds + 60
+    end
+    redis.call("HSET", reservation_key, "idempotency_key", idempotency_key)
+    redis.call("SET", KEYS[6], booking_id, "EX", idempotency_ttl)
+end
+
+-- 7. Journal the reservation; entries older than the retention are trimmed as new ones arrive
+if journal_retention > 0 then
+    local now_ms = tonumber(timestamp[1]) * 1000 + math.floor(tonumber(timestamp[2]) / 1000)
+    redis.call("XADD", KEYS[8], "MINID", "~", now_ms - journal_retention, "*",
+        "op", "reserve",
+        "booking_id", booking_id,
+        "user_id", user_id,
+        "event_id", event_id,
+        "quantity", quantity,
+        "available", remaining,
+        "user_reserved", new_user_reserved,
+        "shards", shard_count
+    )
+    redis.call("PEXPIRE", KEYS[8], journal_retention)
+end
+
+-- Return success with remaining seats, user's total reserved and the applied TTL
+return {1, remaining, new_user_reserved, ttl_seconds}
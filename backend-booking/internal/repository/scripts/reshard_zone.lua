@@ -0,0 +1,74 @@
+--[[
+    Reshard Zone Lua Script
+    =======================
+    Splits a zone's availability evenly across a new number of counters, or folds
+    it back into the primary counter when resharding to a single shard. The total
+    is unchanged, so it is safe while reservations are in flight.
+
+    Key Structure:
+    - KEYS[1]: zone:shards:{zone_id}          - Shard count of the zone (absent = not sharded)
+    - KEYS[2..]: shards of the zone, starting with zone:availability:{zone_id};
+                 as many as the larger of the current and the new shard count
+
+    Arguments:
+    - ARGV[1]: current_shards    - Shard count the caller read
+    - ARGV[2]: new_shards        - Shard count to split across
+
+    Returns:
+    - Success: {1, total_available, shard_1_available, shard_2_available, ...}
+    - Error: {0, error_code, error_message}
+
+    Error Codes:
+    - ZONE_NOT_FOUND: Zone availability key not found
+    - SHARD_LAYOUT_CHANGED: The zone was resharded since the caller read its layout
+--]]
+
+local layout_key = KEYS[1]
+local current_shards = tonumber(ARGV[1]) or 1
+local new_shards = tonumber(ARGV[2]) or 1
+
+local layout = tonumber(redis.call("GET", layout_key)) or 1
+if layout ~= current_shards then
+    return {0, "SHARD_LAYOUT_CHANGED", "Zone has " .. layout .. " shards, caller assumed " .. current_shards}
+end
+
+if redis.call("EXISTS", KEYS[2]) == 0 then
+    return {0, "ZONE_NOT_FOUND", "Zone availability not initialized"}
+end
+
+local total = 0
+for i = 1, current_shards do
+    total = total + (tonumber(redis.call("GET", KEYS[1 + i])) or 0)
+end
+
+-- Shards with a lower index take the remainder; a negative total stays on the primary
+local result = {1, total}
+local base = 0
+local extra = 0
+if total > 0 then
+    base = math.floor(total / new_shards)
+    extra = total % new_shards
+end
+for i = 1, new_shards do
+    local seats = base
+    if i <= extra then
+        seats = seats + 1
+    elseif i == 1 and total < 0 then
+        seats = total
+    end
+    redis.call("SET", KEYS[1 + i], seats)
+    table.insert(result, seats)
+end
+
+-- Shards the zone no longer has
+for i = new_shards + 1, current_shards do
+    redis.call("DEL", KEYS[1 + i])
+end
+
+if new_shards > 1 then
+    redis.call("SET", layout_key, new_shards)
+else
+    redis.call("DEL", layout_key)
+end
+
+return result
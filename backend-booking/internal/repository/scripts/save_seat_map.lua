@@ -0,0 +1,49 @@
+--[[
+    Save Seat Map Lua Script
+    ========================
+    Atomically replaces (or removes) the seat map of a zone and resets its seat bitmap.
+    Refuses while any seat in the current bitmap is held, since held seats are
+    addressed by bitmap offset and would point at different seats in a new layout.
+
+    Key Structure:
+    - KEYS[1]: zone:seatmap:{zone_id}     - Seat map layout (JSON string)
+    - KEYS[2]: zone:seats:{zone_id}       - Held seats bitmap (bit set = taken)
+
+    Arguments:
+    - ARGV[1]: layout          - Seat map JSON, empty to remove the seat map
+    - ARGV[2]: total_seats     - Number of seats in the layout
+
+    Returns:
+    - Success: {1, total_seats, "OK"}
+    - Error: {0, error_code, error_message}
+
+    Error Codes:
+    - SEAT_MAP_IN_USE: Seats are held under the current layout
+--]]
+
+local seat_map_key = KEYS[1]
+local seats_key = KEYS[2]
+
+local layout = ARGV[1]
+local total_seats = tonumber(ARGV[2]) or 0
+
+local held = redis.call("BITCOUNT", seats_key)
+if held > 0 then
+    return {0, "SEAT_MAP_IN_USE", "Seat map cannot change while " .. held .. " seats are held"}
+end
+
+redis.call("DEL", seats_key)
+
+if layout == "" then
+    redis.call("DEL", seat_map_key)
+    return {1, 0, "OK"}
+end
+
+redis.call("SET", seat_map_key, layout)
+
+-- Pre-size the bitmap so reads always cover the whole layout
+if total_seats > 0 then
+    redis.call("SETBIT", seats_key, total_seats - 1, 0)
+end
+
+return {1, total_seats, "OK"}
@@ -0,0 +1,48 @@
+--[[
+    Stream Import Queue Lua Script
+    ==============================
+    Appends migrated users to an empty stream queue in their original order.
+    Stream IDs only grow, so entries cannot be merged ahead of users already queued.
+
+    Key Structure:
+    - KEYS[1]: qstream:{event_id}          - Stream
+    - KEYS[2]: qstream:members:{event_id}  - Hash user_id -> seq
+    - KEYS[3]: qstream:left:{event_id}     - Sorted Set of seqs removed from the middle
+    - KEYS[4]: qstream:seq:{event_id}      - Sequence counter
+    - KEYS[5]: qstream:events              - Set of event IDs with a stream queue
+
+    Arguments:
+    - ARGV[1]: event_id
+    - ARGV[2..n]: user_id, joined_at pairs in join order
+
+    Returns:
+    - Number of users added, or -1 if the queue is not empty
+--]]
+
+local stream_key = KEYS[1]
+local members_key = KEYS[2]
+local seq_key = KEYS[4]
+local events_key = KEYS[5]
+
+local event_id = ARGV[1]
+
+if redis.call("XLEN", stream_key) > 0 then
+    return -1
+end
+
+local added = 0
+for i = 2, #ARGV, 2 do
+    local user_id = ARGV[i]
+    if redis.call("HEXISTS", members_key, user_id) == 0 then
+        local seq = redis.call("INCR", seq_key)
+        redis.call("XADD", stream_key, "0-" .. seq, "user_id", user_id, "joined_at", ARGV[i + 1])
+        redis.call("HSET", members_key, user_id, seq)
+        added = added + 1
+    end
+end
+
+if added > 0 then
+    redis.call("SADD", events_key, event_id)
+end
+
+return added
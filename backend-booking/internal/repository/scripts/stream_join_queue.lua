@@ -0,0 +1,93 @@
+--[[
+    Stream Join Queue Lua Script
+    ============================
+    Atomically appends a user to the virtual queue backed by a Redis Stream.
+
+    Entries use explicit IDs 0-{seq} from a per-event counter, so a user's position is
+    seq - head_seq + 1 minus the users who left in between (tracked in a Sorted Set).
+
+    Key Structure:
+    - KEYS[1]: qstream:{event_id}                - Stream (id = 0-{seq}, fields user_id, joined_at)
+    - KEYS[2]: qstream:members:{event_id}        - Hash user_id -> seq
+    - KEYS[3]: qstream:left:{event_id}           - Sorted Set of seqs removed from the middle
+    - KEYS[4]: qstream:seq:{event_id}            - Sequence counter
+    - KEYS[5]: qstream:events                    - Set of event IDs with a stream queue
+    - KEYS[6]: queue:user:{event_id}:{user_id}   - Hash with user queue info
+
+    Arguments:
+    - ARGV[1]: user_id           - User ID
+    - ARGV[2]: event_id          - Event ID
+    - ARGV[3]: token             - Unique queue token
+    - ARGV[4]: ttl_seconds       - TTL for queue entry (default 1800 = 30 min)
+    - ARGV[5]: max_queue_size    - Maximum queue size (0 = unlimited)
+
+    Returns:
+    - Success: {1, position, total_in_queue, joined_at_timestamp}
+    - Error: {0, error_code, error_message}
+
+    Error Codes:
+    - ALREADY_IN_QUEUE: User is already in the queue
+    - QUEUE_FULL: Queue has reached maximum capacity
+--]]
+
+local stream_key = KEYS[1]
+local members_key = KEYS[2]
+local left_key = KEYS[3]
+local seq_key = KEYS[4]
+local events_key = KEYS[5]
+local user_queue_key = KEYS[6]
+
+local user_id = ARGV[1]
+local event_id = ARGV[2]
+local token = ARGV[3]
+local ttl_seconds = tonumber(ARGV[4]) or 1800
+local max_queue_size = tonumber(ARGV[5]) or 0
+
+-- Check if user is already in queue
+local existing_seq = redis.call("HGET", members_key, user_id)
+if existing_seq then
+    local seq = tonumber(existing_seq)
+    local head = redis.call("XRANGE", stream_key, "-", "+", "COUNT", 1)
+    local head_seq = seq
+    if #head > 0 then
+        head_seq = tonumber(string.match(head[1][1], "^0%-(%d+)$"))
+    end
+    local position = seq - head_seq + 1 - redis.call("ZCOUNT", left_key, head_seq, seq)
+    return {0, "ALREADY_IN_QUEUE", "User is already in queue at position " .. position}
+end
+
+-- Check queue size limit
+if max_queue_size > 0 then
+    local current_size = redis.call("XLEN", stream_key)
+    if current_size >= max_queue_size then
+        return {0, "QUEUE_FULL", "Queue has reached maximum capacity of " .. max_queue_size}
+    end
+end
+
+-- Get current timestamp
+local timestamp = redis.call("TIME")
+local joined_at = tonumber(timestamp[1]) + (tonumber(timestamp[2]) / 1000000)
+local joined_at_str = string.format("%.6f", joined_at)
+
+-- Append user to the stream
+local seq = redis.call("INCR", seq_key)
+redis.call("XADD", stream_key, "0-" .. seq, "user_id", user_id, "joined_at", joined_at_str)
+redis.call("HSET", members_key, user_id, seq)
+redis.call("SADD", events_key, event_id)
+
+-- The new entry is the last one, so its position is the stream length
+local total = redis.call("XLEN", stream_key)
+
+-- Store user queue info
+local expires_at = timestamp[1] + ttl_seconds
+redis.call("HSET", user_queue_key,
+    "user_id", user_id,
+    "event_id", event_id,
+    "token", token,
+    "joined_at", joined_at_str,
+    "expires_at", expires_at,
+    "position", total
+)
+redis.call("EXPIRE", user_queue_key, ttl_seconds)
+
+return {1, total, total, joined_at_str}
@@ -0,0 +1,61 @@
+--[[
+    Stream Pop Queue Lua Script
+    ===========================
+    Removes and returns the first N users of a stream queue. Drops all keys once the
+    queue is empty.
+
+    Key Structure:
+    - KEYS[1]: qstream:{event_id}          - Stream
+    - KEYS[2]: qstream:members:{event_id}  - Hash user_id -> seq
+    - KEYS[3]: qstream:left:{event_id}     - Sorted Set of seqs removed from the middle
+    - KEYS[4]: qstream:seq:{event_id}      - Sequence counter
+    - KEYS[5]: qstream:events              - Set of event IDs with a stream queue
+
+    Arguments:
+    - ARGV[1]: count
+    - ARGV[2]: event_id
+
+    Returns:
+    - User IDs in queue order
+--]]
+
+local stream_key = KEYS[1]
+local members_key = KEYS[2]
+local left_key = KEYS[3]
+local seq_key = KEYS[4]
+local events_key = KEYS[5]
+
+local count = tonumber(ARGV[1]) or 0
+local event_id = ARGV[2]
+
+local users = {}
+if count <= 0 then
+    return users
+end
+
+local entries = redis.call("XRANGE", stream_key, "-", "+", "COUNT", count)
+local last_seq = nil
+for _, entry in ipairs(entries) do
+    local id = entry[1]
+    local fields = entry[2]
+    for i = 1, #fields, 2 do
+        if fields[i] == "user_id" then
+            table.insert(users, fields[i + 1])
+            redis.call("HDEL", members_key, fields[i + 1])
+        end
+    end
+    redis.call("XDEL", stream_key, id)
+    last_seq = tonumber(string.match(id, "^0%-(%d+)$"))
+end
+
+-- Seqs before the new head no longer affect positions
+if last_seq then
+    redis.call("ZREMRANGEBYSCORE", left_key, "-inf", last_seq)
+end
+
+if redis.call("XLEN", stream_key) == 0 then
+    redis.call("DEL", stream_key, members_key, left_key, seq_key)
+    redis.call("SREM", events_key, event_id)
+end
+
+return users
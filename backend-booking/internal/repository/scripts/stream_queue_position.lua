@@ -0,0 +1,38 @@
+--[[
+    Stream Queue Position Lua Script
+    ================================
+    Returns a user's position in a stream queue without reading the entries ahead of them.
+
+    Key Structure:
+    - KEYS[1]: qstream:{event_id}          - Stream
+    - KEYS[2]: qstream:members:{event_id}  - Hash user_id -> seq
+    - KEYS[3]: qstream:left:{event_id}     - Sorted Set of seqs removed from the middle
+
+    Arguments:
+    - ARGV[1]: user_id
+
+    Returns:
+    - In queue: {1, position, total_in_queue}
+    - Not in queue: {0}
+--]]
+
+local stream_key = KEYS[1]
+local members_key = KEYS[2]
+local left_key = KEYS[3]
+
+local member_seq = redis.call("HGET", members_key, ARGV[1])
+if not member_seq then
+    return {0}
+end
+
+local seq = tonumber(member_seq)
+local head = redis.call("XRANGE", stream_key, "-", "+", "COUNT", 1)
+if #head == 0 then
+    return {0}
+end
+
+local head_seq = tonumber(string.match(head[1][1], "^0%-(%d+)$"))
+local position = seq - head_seq + 1 - redis.call("ZCOUNT", left_key, head_seq, seq)
+local total = redis.call("XLEN", stream_key)
+
+return {1, position, total}
@@ -0,0 +1,45 @@
+--[[
+    Stream Remove Queue Lua Script
+    ==============================
+    Removes users from anywhere in a stream queue. Drops all keys once the queue is empty.
+
+    Key Structure:
+    - KEYS[1]: qstream:{event_id}          - Stream
+    - KEYS[2]: qstream:members:{event_id}  - Hash user_id -> seq
+    - KEYS[3]: qstream:left:{event_id}     - Sorted Set of seqs removed from the middle
+    - KEYS[4]: qstream:seq:{event_id}      - Sequence counter
+    - KEYS[5]: qstream:events              - Set of event IDs with a stream queue
+
+    Arguments:
+    - ARGV[1]: event_id
+    - ARGV[2..n]: user_ids to remove
+
+    Returns:
+    - Number of users removed
+--]]
+
+local stream_key = KEYS[1]
+local members_key = KEYS[2]
+local left_key = KEYS[3]
+local seq_key = KEYS[4]
+local events_key = KEYS[5]
+
+local event_id = ARGV[1]
+
+local removed = 0
+for i = 2, #ARGV do
+    local seq = redis.call("HGET", members_key, ARGV[i])
+    if seq then
+        redis.call("XDEL", stream_key, "0-" .. seq)
+        redis.call("HDEL", members_key, ARGV[i])
+        redis.call("ZADD", left_key, seq, seq)
+        removed = removed + 1
+    end
+end
+
+if redis.call("XLEN", stream_key) == 0 then
+    redis.call("DEL", stream_key, members_key, left_key, seq_key)
+    redis.call("SREM", events_key, event_id)
+end
+
+return removed
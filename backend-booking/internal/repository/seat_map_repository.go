@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// AssignSeatsResult represents the result of assigning seats to a reservation
+type AssignSeatsResult struct {
+	Success      bool
+	Assigned     int64
+	ErrorCode    string
+	ErrorMessage string
+}
+
+// SeatMapRepository defines the interface for reserved seating layouts and held seat bitmaps
+type SeatMapRepository interface {
+	// Save replaces a zone's seat map and resets its bitmap.
+	// Returns domain.ErrSeatMapInUse while any seat is held.
+	Save(ctx context.Context, seatMap *domain.SeatMap) error
+
+	// Delete removes a zone's seat map, returning the zone to general admission
+	Delete(ctx context.Context, zoneID string) error
+
+	// Get returns a zone's seat map, domain.ErrSeatMapNotFound for general admission zones
+	Get(ctx context.Context, zoneID string) (*domain.SeatMap, error)
+
+	// GetBitmap returns the held seats bitmap of a zone
+	GetBitmap(ctx context.Context, zoneID string) (domain.SeatBitmap, error)
+
+	// AssignSeats atomically marks seats as held by a reservation.
+	// Fails with SEAT_TAKEN, changing nothing, if any seat is already held.
+	AssignSeats(ctx context.Context, zoneID, bookingID string, seats []domain.Seat) (*AssignSeatsResult, error)
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// JoinStandbyParams contains parameters for joining a zone standby list
+type JoinStandbyParams struct {
+	ZoneID   string
+	EventID  string
+	ShowID   string
+	UserID   string
+	Quantity int
+	// NotifyChannels are how the user is invited when seats are held for them
+	NotifyChannels []string
+	// Locale is the language the invitation is written in
+	Locale string
+}
+
+// JoinStandbyResult represents the result of joining a standby list
+type JoinStandbyResult struct {
+	Success      bool
+	Position     int64
+	TotalWaiting int64
+	ErrorCode    string
+	ErrorMessage string
+}
+
+// OfferResult represents the result of offering released seats to standby users
+type OfferResult struct {
+	Success      bool
+	Offers       []*domain.StandbyOffer
+	Reclaimed    int64 // Seats returned to the pool from lapsed offers
+	ErrorCode    string
+	ErrorMessage string
+}
+
+// StandbyRepository defines the interface for per-zone standby lists
+type StandbyRepository interface {
+	// Join adds a user to the end of a zone's standby list
+	Join(ctx context.Context, params JoinStandbyParams) (*JoinStandbyResult, error)
+
+	// Leave removes a user from a standby list, returning any held seats to the pool
+	Leave(ctx context.Context, zoneID, userID string) (bool, error)
+
+	// Get returns the user's standby entry or offer, nil if not on the list
+	Get(ctx context.Context, zoneID, userID string) (*domain.StandbyEntry, error)
+
+	// OfferSeats reclaims lapsed offers and holds available seats for waiting users
+	// in FIFO order, each for the given exclusive window
+	OfferSeats(ctx context.Context, zoneID string, window time.Duration) (*OfferResult, error)
+
+	// ClaimOffer turns a held offer into a reservation without touching zone availability
+	ClaimOffer(ctx context.Context, params ReserveParams) (*ReserveResult, error)
+
+	// ListZones returns zones that currently have waiting users or held offers
+	ListZones(ctx context.Context) ([]string, error)
+
+	// Stats returns the zone's standby list size and conversion counters
+	Stats(ctx context.Context, zoneID string) (*domain.StandbyStats, error)
+}
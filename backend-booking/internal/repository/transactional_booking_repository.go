@@ -105,7 +105,7 @@ func (r *TransactionalBookingRepository) ConfirmWithOutbox(ctx context.Context,
 			payment_id = $3,
 			confirmed_at = $4,
 			updated_at = $5
-		WHERE id = $1 AND status = 'reserved'
+		WHERE id = $1 AND status IN ('reserved', 'pending_payment')
 	`
 
 	now := time.Now()
@@ -160,7 +160,7 @@ func (r *TransactionalBookingRepository) CancelWithOutbox(ctx context.Context, i
 			status = $2,
 			cancelled_at = $3,
 			updated_at = $4
-		WHERE id = $1 AND status = 'reserved'
+		WHERE id = $1 AND status IN ('reserved', 'pending_payment')
 	`
 
 	now := time.Now()
@@ -221,7 +221,7 @@ func (r *TransactionalBookingRepository) MarkAsExpiredWithOutbox(ctx context.Con
 			status = $2,
 			status_reason = $3,
 			updated_at = $4
-		WHERE id = $1 AND status = 'reserved'
+		WHERE id = $1 AND status IN ('reserved', 'pending_payment')
 	`
 
 	result, err := tx.Exec(ctx, query,
@@ -263,11 +263,13 @@ func (r *TransactionalBookingRepository) createBookingTx(ctx context.Context, tx
 		INSERT INTO bookings (
 			id, tenant_id, user_id, event_id, show_id, zone_id,
 			quantity, unit_price, total_amount, currency, status,
-			idempotency_key, reserved_at, reservation_expires_at, created_at, updated_at
+			idempotency_key, reserved_at, reservation_expires_at, created_at, updated_at,
+			seat_labels, add_ons
 		) VALUES (
 			$1, $2, $3, $4, $5, $6,
 			$7, $8, $9, $10, $11,
-			$12, $13, $14, $15, $16
+			$12, $13, $14, $15, $16,
+			$17, $18
 		)
 	`
 
@@ -288,6 +290,8 @@ func (r *TransactionalBookingRepository) createBookingTx(ctx context.Context, tx
 		booking.ExpiresAt,
 		booking.CreatedAt,
 		booking.UpdatedAt,
+		booking.SeatLabels,
+		nullAddOns(booking.AddOns),
 	)
 
 	if err != nil {
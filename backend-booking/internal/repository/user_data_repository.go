@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// UserDataRepository defines data access for user data export and anonymization (GDPR)
+type UserDataRepository interface {
+	// ListByUserID returns every booking owned by a user (no pagination, for export)
+	ListByUserID(ctx context.Context, userID string) ([]*domain.Booking, error)
+
+	// AnonymizeByUserID re-assigns a user's bookings to an anonymized ID and strips
+	// user-supplied identifiers. Returns the number of bookings anonymized.
+	AnonymizeByUserID(ctx context.Context, userID, anonymizedID string) (int64, error)
+}
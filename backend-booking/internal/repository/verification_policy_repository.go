@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+)
+
+// VerificationPolicyRepository defines the interface for reading event verification policies
+type VerificationPolicyRepository interface {
+	// GetEventPolicy returns an event's verification policy, domain.VerificationPolicyNone if it has none
+	GetEventPolicy(ctx context.Context, eventID string) (string, error)
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// WebhookSubscriptionRepository defines the interface for tenant webhook subscription access.
+// All reads and writes are scoped to a tenant so one organizer cannot see another's endpoints.
+type WebhookSubscriptionRepository interface {
+	// Create inserts a new subscription
+	Create(ctx context.Context, sub *domain.WebhookSubscription) error
+
+	// GetByID retrieves a tenant's subscription by its ID
+	GetByID(ctx context.Context, tenantID, id string) (*domain.WebhookSubscription, error)
+
+	// ListByTenant retrieves all subscriptions of a tenant, newest first
+	ListByTenant(ctx context.Context, tenantID string) ([]*domain.WebhookSubscription, error)
+
+	// ListActiveForEvent retrieves a tenant's active subscriptions to the given event type
+	ListActiveForEvent(ctx context.Context, tenantID string, eventType domain.WebhookEventType) ([]*domain.WebhookSubscription, error)
+
+	// Update saves the URL, event types, description and active flag of a subscription
+	Update(ctx context.Context, sub *domain.WebhookSubscription) error
+
+	// Delete removes a tenant's subscription and its delivery log
+	Delete(ctx context.Context, tenantID, id string) error
+}
+
+// WebhookDeliveryFilter holds optional filters for listing webhook deliveries
+type WebhookDeliveryFilter struct {
+	TenantID       string // Required
+	SubscriptionID string
+	EventID        string
+	EventType      domain.WebhookEventType
+	Status         domain.WebhookDeliveryStatus
+	Limit          int
+	Offset         int
+}
+
+// WebhookDeliveryRepository defines the interface for the webhook delivery log and retry queue
+type WebhookDeliveryRepository interface {
+	// Create inserts a new delivery; it returns false without error if the subscription
+	// already has a delivery for the same event (redelivered Kafka message)
+	Create(ctx context.Context, delivery *domain.WebhookDelivery) (bool, error)
+
+	// GetByID retrieves a tenant's delivery by its ID
+	GetByID(ctx context.Context, tenantID, id string) (*domain.WebhookDelivery, error)
+
+	// List retrieves deliveries matching the filter, newest first
+	List(ctx context.Context, filter *WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error)
+
+	// ClaimDue returns up to limit pending deliveries whose next attempt is due and pushes
+	// their next attempt back by lease, so concurrent workers do not send the same delivery twice
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*domain.WebhookDelivery, error)
+
+	// Update saves the attempt outcome of a delivery
+	Update(ctx context.Context, delivery *domain.WebhookDelivery) error
+}
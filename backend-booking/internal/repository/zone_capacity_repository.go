@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// ApplyCapacityChangeParams contains parameters for adjusting a zone's live capacity
+type ApplyCapacityChangeParams struct {
+	EventID string // Capacity change event ID, used to drop redelivered events
+	ZoneID  string
+	Delta   int // New total minus previous total
+}
+
+// ApplyCapacityChangeResult represents the result of adjusting a zone's live capacity
+type ApplyCapacityChangeResult struct {
+	Success        bool
+	AvailableSeats int64 // Availability after the change, or current availability when refused
+	ErrorCode      string
+	ErrorMessage   string
+}
+
+// SeedZoneParams contains parameters for seeding a zone's live inventory
+type SeedZoneParams struct {
+	ZoneID         string
+	AvailableSeats int
+	HoldTTLSeconds *int // nil = reservations use the event or default hold
+	Overwrite      bool // Replace an existing counter; only safe when no holds can be in flight
+}
+
+// ZoneCapacityRepository defines the interface for zone capacity changes on live inventory
+type ZoneCapacityRepository interface {
+	// ApplyCapacityChange atomically adjusts zone availability by the capacity delta,
+	// refusing reductions larger than the seats not sold or held
+	ApplyCapacityChange(ctx context.Context, params ApplyCapacityChangeParams) (*ApplyCapacityChangeResult, error)
+
+	// SaveStatus records the outcome of the latest capacity change for a zone
+	SaveStatus(ctx context.Context, status *domain.ZoneCapacityStatus) error
+
+	// SeedZone initializes zone availability and refreshes the zone hold TTL.
+	// An existing counter is left untouched unless Overwrite is set; returns true if it was written.
+	SeedZone(ctx context.Context, params SeedZoneParams) (bool, error)
+
+	// RemoveZone drops a zone's availability counters, shard layout and hold TTL
+	RemoveZone(ctx context.Context, zoneID string) error
+}
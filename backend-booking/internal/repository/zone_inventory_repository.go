@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// ZoneInventoryRepository defines read access to the zone inventory persisted in PostgreSQL,
+// which the inventory worker keeps in step with the Redis counters
+type ZoneInventoryRepository interface {
+	// GetAvailableSeats returns seat_zones.available_seats of a zone, or
+	// domain.ErrZoneNotFound if the zone has no row
+	GetAvailableSeats(ctx context.Context, zoneID string) (int64, error)
+
+	// GetZoneSeats returns the total and available seats of the zones, keyed by zone ID.
+	// Zones without a row are left out.
+	GetZoneSeats(ctx context.Context, zoneIDs []string) (map[string]domain.ZoneSeatCounts, error)
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// ZoneShardRepository defines the interface for splitting a zone's availability across counters
+type ZoneShardRepository interface {
+	// GetLayout returns how a zone's availability is split.
+	// Returns domain.ErrZoneNotFound if the zone is not live in Redis.
+	GetLayout(ctx context.Context, zoneID string) (*domain.ZoneShardLayout, error)
+
+	// Reshard splits a zone's availability evenly across the given number of shards;
+	// one shard folds it back into the primary counter.
+	// Returns domain.ErrShardLayoutChanged if another reshard ran concurrently.
+	Reshard(ctx context.Context, zoneID string, shards int) (*domain.ZoneShardLayout, error)
+
+	// ResetAvailability overwrites a zone's availability on its primary counter and
+	// empties its other shards, keeping the layout; reservations rebalance from there
+	ResetAvailability(ctx context.Context, zoneID string, seats int64) error
+}
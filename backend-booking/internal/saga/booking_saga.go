@@ -210,6 +210,7 @@ func (b *BookingSagaBuilder) Build() *pkgsaga.Definition {
 		Description: "Reserve seats in inventory",
 		Execute:     b.reserveSeatsExecute,
 		Compensate:  b.reserveSeatsCompensate,
+		Topic:       StepToCommandTopic(StepReserveSeats),
 		Timeout:     b.config.StepTimeout,
 		Retries:     b.config.MaxRetries,
 	})
@@ -220,6 +221,7 @@ func (b *BookingSagaBuilder) Build() *pkgsaga.Definition {
 		Description: "Process payment for booking",
 		Execute:     b.processPaymentExecute,
 		Compensate:  b.processPaymentCompensate,
+		Topic:       StepToCommandTopic(StepProcessPayment),
 		Timeout:     b.config.StepTimeout,
 		Retries:     b.config.MaxRetries,
 	})
@@ -230,6 +232,7 @@ func (b *BookingSagaBuilder) Build() *pkgsaga.Definition {
 		Description: "Confirm booking after payment",
 		Execute:     b.confirmBookingExecute,
 		Compensate:  nil, // No compensation needed - if this fails, payment will be refunded
+		Topic:       StepToCommandTopic(StepConfirmBooking),
 		Timeout:     b.config.StepTimeout,
 		Retries:     b.config.MaxRetries,
 	})
@@ -432,6 +435,7 @@ func (b *PostPaymentSagaBuilder) Build() *pkgsaga.Definition {
 		Description: "Confirm booking after payment success",
 		Execute:     nil, // Executed by saga_step_worker
 		Compensate:  nil, // Compensation handled separately (refund + release)
+		Topic:       StepToCommandTopic(StepConfirmBooking),
 		Timeout:     b.config.StepTimeout,
 		Retries:     b.config.MaxRetries,
 	})
@@ -445,6 +449,7 @@ func (b *PostPaymentSagaBuilder) Build() *pkgsaga.Definition {
 		Description: "Send booking confirmation notification",
 		Execute:     nil, // Executed by saga_step_worker (mock for now)
 		Compensate:  nil, // NON-CRITICAL: No compensation - just retry and DLQ
+		Topic:       StepToCommandTopic(StepSendNotification),
 		Timeout:     b.config.StepTimeout,
 		Retries:     5, // More retries for non-critical step
 	})
@@ -0,0 +1,96 @@
+package saga
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	pkgsaga "github.com/prohmpiriya/booking-rush-10k-rps/pkg/saga"
+	"gopkg.in/yaml.v3"
+)
+
+// NewStepHandlerRegistry creates the handler registry definition files are resolved against.
+// Steps of the compiled-in definitions keep their own functions; every step a worker
+// executes is also registered by name with its command topic, so a file can compose new
+// definitions from existing steps.
+func NewStepHandlerRegistry(defs ...*pkgsaga.Definition) *pkgsaga.HandlerRegistry {
+	registry := pkgsaga.NewHandlerRegistry()
+	for _, step := range []string{StepReserveSeats, StepProcessPayment, StepConfirmBooking, StepSendNotification} {
+		registry.Register(step, pkgsaga.StepHandler{Topic: StepToCommandTopic(step)})
+	}
+	for _, def := range defs {
+		registry.RegisterDefinition(def)
+	}
+	return registry
+}
+
+// ParseDefinitionFile parses a saga definition file, as YAML when the path ends in
+// .yaml or .yml and as JSON otherwise
+func ParseDefinitionFile(path string, data []byte) (*pkgsaga.DefinitionFile, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		var file pkgsaga.DefinitionFile
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("%w: %v", pkgsaga.ErrInvalidDefinitionSpec, err)
+		}
+		return &file, nil
+	default:
+		return pkgsaga.ParseDefinitionFile(data)
+	}
+}
+
+// DefinitionLoader loads saga definitions from a file into the orchestrator, at startup
+// and whenever a reload is requested
+type DefinitionLoader struct {
+	path         string
+	registry     *pkgsaga.HandlerRegistry
+	orchestrator *pkgsaga.Orchestrator
+	logger       Logger
+
+	// mu serializes reloads so two requests can't interleave their definitions
+	mu sync.Mutex
+}
+
+// NewDefinitionLoader creates a loader for the definition file at path
+func NewDefinitionLoader(path string, registry *pkgsaga.HandlerRegistry, orchestrator *pkgsaga.Orchestrator) *DefinitionLoader {
+	return &DefinitionLoader{
+		path:         path,
+		registry:     registry,
+		orchestrator: orchestrator,
+		logger:       &ZapLogger{},
+	}
+}
+
+// Path returns the definition file the loader reads
+func (l *DefinitionLoader) Path() string {
+	return l.path
+}
+
+// Reload reads the definition file and applies it to the orchestrator. Nothing is applied
+// if any definition in the file is invalid.
+func (l *DefinitionLoader) Reload(ctx context.Context) ([]*pkgsaga.Definition, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	data, err := os.ReadFile(l.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read saga definition file: %w", err)
+	}
+	file, err := ParseDefinitionFile(l.path, data)
+	if err != nil {
+		return nil, err
+	}
+	defs, err := file.Build(l.registry)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.orchestrator.ReloadDefinitions(defs); err != nil {
+		return nil, err
+	}
+
+	l.logger.InfoContext(ctx, "Saga definitions loaded", "path", l.path, "definitions", len(defs))
+	return defs, nil
+}
@@ -0,0 +1,93 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pkgsaga "github.com/prohmpiriya/booking-rush-10k-rps/pkg/saga"
+)
+
+func newTestDefinitionLoader(t *testing.T, path string) (*DefinitionLoader, *pkgsaga.Orchestrator) {
+	t.Helper()
+	orchestrator := pkgsaga.NewOrchestrator(&pkgsaga.OrchestratorConfig{})
+	if err := orchestrator.RegisterDefinition(NewPostPaymentSagaBuilder(nil).Build()); err != nil {
+		t.Fatalf("RegisterDefinition() error = %v", err)
+	}
+	registry := NewStepHandlerRegistry(orchestrator.Definitions()...)
+	return NewDefinitionLoader(path, registry, orchestrator), orchestrator
+}
+
+func TestDefinitionLoader_ReloadExampleFile(t *testing.T) {
+	loader, orchestrator := newTestDefinitionLoader(t, "../../cmd/saga-orchestrator/saga-definitions.example.yaml")
+
+	if _, err := loader.Reload(context.Background()); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+
+	def, err := orchestrator.GetDefinition(PostPaymentSagaName)
+	if err != nil {
+		t.Fatalf("GetDefinition() error = %v", err)
+	}
+	notify := def.Steps[1]
+	if notify.Retries != 5 || notify.Timeout != 30*time.Second || notify.Topic != TopicSagaSendNotificationCommand {
+		t.Errorf("send-notification step = %+v", notify)
+	}
+}
+
+func TestDefinitionLoader_Reload(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sagas.yaml")
+	loader, orchestrator := newTestDefinitionLoader(t, path)
+	ctx := context.Background()
+
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+
+	write(`
+definitions:
+  - name: post-payment-saga
+    steps:
+      - name: confirm-booking
+        timeout: 45s
+        retries: 6
+      - name: send-notification
+  - name: notify-only-saga
+    steps:
+      - name: notify
+        handler: send-notification
+`)
+	if _, err := loader.Reload(ctx); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	def, _ := orchestrator.GetDefinition(PostPaymentSagaName)
+	if def.Steps[0].Timeout != 45*time.Second || def.Steps[0].Retries != 6 {
+		t.Errorf("confirm-booking step = %+v, want 45s and 6 retries", def.Steps[0])
+	}
+	added, err := orchestrator.GetDefinition("notify-only-saga")
+	if err != nil || added.Steps[0].Topic != TopicSagaSendNotificationCommand {
+		t.Errorf("notify-only-saga = %+v (%v), want the send-notification topic", added, err)
+	}
+
+	// A file that drops a step is rejected and the running definitions stay
+	write(`
+definitions:
+  - name: post-payment-saga
+    steps:
+      - name: confirm-booking
+        retries: 1
+`)
+	if _, err := loader.Reload(ctx); !errors.Is(err, pkgsaga.ErrDefinitionStepsChanged) {
+		t.Fatalf("Reload() error = %v, want %v", err, pkgsaga.ErrDefinitionStepsChanged)
+	}
+	if def, _ := orchestrator.GetDefinition(PostPaymentSagaName); def.Steps[0].Retries != 6 {
+		t.Errorf("confirm-booking retries = %d after a rejected reload, want 6", def.Steps[0].Retries)
+	}
+}
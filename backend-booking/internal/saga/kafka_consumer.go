@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/metrics"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
 	pkgsaga "github.com/prohmpiriya/booking-rush-10k-rps/pkg/saga"
 )
@@ -283,6 +284,7 @@ func (c *SagaConsumer) advanceSaga(ctx context.Context, event *SagaEvent) error
 					nextStep.Timeout,
 					nextStep.Retries,
 				)
+				command.Topic = nextStep.Topic
 				if err := c.producer.SendCommand(ctx, command); err != nil {
 					return fmt.Errorf("failed to send next step command: %w", err)
 				}
@@ -305,6 +307,7 @@ func (c *SagaConsumer) advanceSaga(ctx context.Context, event *SagaEvent) error
 				if err := c.store.Update(ctx, instance); err != nil {
 					return fmt.Errorf("failed to update completed saga: %w", err)
 				}
+				metrics.RecordSagaCompleted(ctx, instance.DefinitionID, time.Since(instance.CreatedAt).Seconds())
 
 				// Send completed lifecycle event
 				completedEvent := NewSagaCompletedEvent(
@@ -336,6 +339,12 @@ func (c *SagaConsumer) triggerCompensation(ctx context.Context, event *SagaEvent
 		return fmt.Errorf("failed to update saga status: %w", err)
 	}
 
+	reason := "failed"
+	if event.ErrorCode == "TIMEOUT" {
+		reason = "timeout"
+	}
+	metrics.RecordSagaFailed(ctx, instance.DefinitionID, event.StepName, reason)
+
 	// Send failed lifecycle event
 	failedEvent := NewSagaFailedEvent(
 		instance.ID,
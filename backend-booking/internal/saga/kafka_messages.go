@@ -38,6 +38,7 @@ type SagaCommand struct {
 
 	// Command specific fields
 	IdempotencyKey string                 `json:"idempotency_key"`
+	Topic          string                 `json:"topic,omitempty"` // Overrides the step's default command topic
 	TimeoutAt      time.Time              `json:"timeout_at"`
 	RetryCount     int                    `json:"retry_count"`
 	MaxRetries     int                    `json:"max_retries"`
@@ -68,6 +69,25 @@ func NewSagaCommand(sagaID, sagaName, stepName string, stepIndex int, data map[s
 	}
 }
 
+// NewRetryCommand re-emits a dead-lettered command with its original payload as the
+// next attempt. The retry gets its own message ID and timeout and names who retried it.
+func NewRetryCommand(original *SagaCommand, retriedBy string, timeout time.Duration) *SagaCommand {
+	retry := *original
+	retry.MessageID = generateMessageID()
+	retry.Timestamp = time.Now()
+	retry.TimeoutAt = retry.Timestamp.Add(timeout)
+	retry.RetryCount = original.RetryCount + 1
+
+	retry.Headers = make(map[string]string, len(original.Headers)+2)
+	for k, v := range original.Headers {
+		retry.Headers[k] = v
+	}
+	retry.Headers["manual_retry"] = "true"
+	retry.Headers["retried_by"] = retriedBy
+
+	return &retry
+}
+
 // SagaEvent represents an event message published after step execution
 type SagaEvent struct {
 	SagaMessage
@@ -75,7 +75,10 @@ func NewKafkaSagaProducer(ctx context.Context, cfg *KafkaSagaProducerConfig) (*K
 
 // SendCommand sends a saga command to Kafka
 func (p *KafkaSagaProducer) SendCommand(ctx context.Context, command *SagaCommand) error {
-	topic := StepToCommandTopic(command.StepName)
+	topic := command.Topic
+	if topic == "" {
+		topic = StepToCommandTopic(command.StepName)
+	}
 	if topic == "" {
 		return fmt.Errorf("unknown step name: %s", command.StepName)
 	}
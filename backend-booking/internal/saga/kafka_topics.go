@@ -1,5 +1,11 @@
 package saga
 
+import (
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
+)
+
 // Kafka topic names for saga commands and events
 const (
 	// Command topics - sent by saga orchestrator to trigger step execution
@@ -93,6 +99,18 @@ func StepToCompensationTopic(stepName string) string {
 	}
 }
 
+// StepToCompensationStep maps saga step names to the name of their compensating step
+func StepToCompensationStep(stepName string) string {
+	switch stepName {
+	case StepReserveSeats:
+		return StepReleaseSeats
+	case StepProcessPayment:
+		return StepRefundPayment
+	default:
+		return ""
+	}
+}
+
 // StepToSuccessEventTopic maps saga step names to their success event topics
 func StepToSuccessEventTopic(stepName string) string {
 	switch stepName {
@@ -124,3 +142,26 @@ func StepToFailureEventTopic(stepName string) string {
 		return ""
 	}
 }
+
+// Retention of saga topics. Commands are only useful while the saga step is pending;
+// events are kept a week for debugging and replay; the DLQ is kept for manual replay.
+const (
+	sagaCommandRetention = 24 * time.Hour
+	sagaEventRetention   = 7 * 24 * time.Hour
+	sagaDLQRetention     = 30 * 24 * time.Hour
+)
+
+// TopicSpecs returns the topics owned by the booking saga, for provisioning at startup
+func TopicSpecs() []kafka.TopicSpec {
+	var specs []kafka.TopicSpec
+	for _, topic := range GetAllCommandTopics() {
+		specs = append(specs, kafka.TopicSpec{Name: topic, Retention: sagaCommandRetention})
+	}
+	for _, topic := range GetAllEventTopics() {
+		specs = append(specs, kafka.TopicSpec{Name: topic, Retention: sagaEventRetention})
+	}
+	return append(specs,
+		kafka.TopicSpec{Name: "saga.booking.timeout-check", Retention: sagaCommandRetention},
+		kafka.TopicSpec{Name: DLQTopic, Retention: sagaDLQRetention},
+	)
+}
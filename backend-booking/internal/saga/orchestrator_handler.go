@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/metrics"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
 	pkgsaga "github.com/prohmpiriya/booking-rush-10k-rps/pkg/saga"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -15,6 +18,9 @@ type OrchestratorEventHandler struct {
 	producer     SagaProducer
 	store        pkgsaga.Store
 	logger       Logger
+
+	// compensations records an audit trail of compensation runs (optional)
+	compensations repository.CompensationRepository
 }
 
 // NewOrchestratorEventHandler creates a new orchestrator event handler
@@ -31,6 +37,11 @@ func NewOrchestratorEventHandler(
 	}
 }
 
+// SetCompensationRepository enables persisting an audit record for every compensation run
+func (h *OrchestratorEventHandler) SetCompensationRepository(repo repository.CompensationRepository) {
+	h.compensations = repo
+}
+
 // HandleStepSuccess handles a successful step completion
 func (h *OrchestratorEventHandler) HandleStepSuccess(ctx context.Context, event *SagaEvent) error {
 	h.logger.InfoContext(ctx, "Handling step success",
@@ -63,6 +74,7 @@ func (h *OrchestratorEventHandler) HandleStepSuccess(ctx context.Context, event
 		FinishedAt: event.FinishedAt,
 		Duration:   event.Duration,
 	})
+	metrics.RecordSagaStep(ctx, instance.DefinitionID, event.StepName, string(pkgsaga.StepStatusCompleted), event.Duration.Seconds())
 
 	// Determine next step
 	nextStepName := h.getNextStep(event.StepName)
@@ -79,16 +91,21 @@ func (h *OrchestratorEventHandler) HandleStepSuccess(ctx context.Context, event
 		return fmt.Errorf("failed to update saga instance: %w", err)
 	}
 
-	// Send next step command
+	// Send next step command, tuned by the saga's definition
+	timeout, retries, topic := 30*time.Second, 2, ""
+	if step := h.definitionStep(instance.DefinitionID, nextStepName); step != nil {
+		timeout, retries, topic = step.Timeout, step.Retries, step.Topic
+	}
 	command := NewSagaCommand(
 		event.SagaID,
 		event.SagaName,
 		nextStepName,
 		event.StepIndex+1,
 		instance.GetData(),
-		30*time.Second,
-		2,
+		timeout,
+		retries,
 	)
+	command.Topic = topic
 
 	if err := h.producer.SendCommand(ctx, command); err != nil {
 		return fmt.Errorf("failed to send next step command: %w", err)
@@ -128,6 +145,8 @@ func (h *OrchestratorEventHandler) HandleStepFailure(ctx context.Context, event
 		FinishedAt: event.FinishedAt,
 		Duration:   event.Duration,
 	})
+	metrics.RecordSagaStep(ctx, instance.DefinitionID, event.StepName, string(pkgsaga.StepStatusFailed), event.Duration.Seconds())
+	metrics.RecordSagaFailed(ctx, instance.DefinitionID, event.StepName, "failed")
 
 	// Set error and start compensation
 	instance.SetError(fmt.Errorf("%s", event.ErrorMessage))
@@ -167,6 +186,7 @@ func (h *OrchestratorEventHandler) HandleTimeout(ctx context.Context, check *Tim
 	}
 
 	// Timeout occurred, start compensation
+	metrics.RecordSagaFailed(ctx, instance.DefinitionID, check.StepName, "timeout")
 	instance.SetError(fmt.Errorf("step %s timed out", check.StepName))
 	instance.SetStatus(pkgsaga.StatusCompensating)
 
@@ -179,6 +199,10 @@ func (h *OrchestratorEventHandler) HandleTimeout(ctx context.Context, check *Tim
 
 // startCompensation starts compensating from the given step index
 func (h *OrchestratorEventHandler) startCompensation(ctx context.Context, instance *pkgsaga.Instance, fromStep int) error {
+	data := &BookingSagaData{}
+	data.FromMap(instance.GetData())
+	record := domain.NewCompensation(instance.ID, data.BookingID, domain.CompensationTriggerAutomatic, instance.Error)
+
 	// Get completed steps that need compensation (reverse order)
 	for i := fromStep - 1; i >= 0; i-- {
 		stepName := h.getStepByIndex(i)
@@ -215,7 +239,9 @@ func (h *OrchestratorEventHandler) startCompensation(ctx context.Context, instan
 			"Step failed, compensating",
 		)
 
-		if err := h.producer.SendCompensationCommand(ctx, command); err != nil {
+		err := h.producer.SendCompensationCommand(ctx, command)
+		record.AddStep(stepName, StepToCompensationStep(stepName), err)
+		if err != nil {
 			h.logger.ErrorContext(ctx, "Failed to send compensation command",
 				"saga_id", instance.ID,
 				"step_name", stepName,
@@ -227,6 +253,8 @@ func (h *OrchestratorEventHandler) startCompensation(ctx context.Context, instan
 		}
 	}
 
+	h.recordCompensation(ctx, record)
+
 	// Mark saga as compensated
 	instance.SetStatus(pkgsaga.StatusCompensated)
 	now := time.Now()
@@ -235,6 +263,7 @@ func (h *OrchestratorEventHandler) startCompensation(ctx context.Context, instan
 	if err := h.store.Update(ctx, instance); err != nil {
 		return fmt.Errorf("failed to update compensated saga: %w", err)
 	}
+	metrics.RecordSagaCompensated(ctx, instance.DefinitionID, now.Sub(instance.CreatedAt).Seconds())
 
 	// Send compensated event
 	compensatedEvent := NewSagaCompensatedEvent(
@@ -250,6 +279,21 @@ func (h *OrchestratorEventHandler) startCompensation(ctx context.Context, instan
 	return nil
 }
 
+// recordCompensation persists the compensation audit record if a repository is configured.
+// Failures are logged only; the audit trail must never block compensation itself.
+func (h *OrchestratorEventHandler) recordCompensation(ctx context.Context, record *domain.Compensation) {
+	if h.compensations == nil {
+		return
+	}
+
+	record.Finish()
+	if err := h.compensations.Create(ctx, record); err != nil {
+		h.logger.WarnContext(ctx, "Failed to record compensation",
+			"saga_id", record.SagaID,
+			"error", err)
+	}
+}
+
 // completeSaga marks the saga as completed
 func (h *OrchestratorEventHandler) completeSaga(ctx context.Context, instance *pkgsaga.Instance) error {
 	instance.Complete()
@@ -257,6 +301,7 @@ func (h *OrchestratorEventHandler) completeSaga(ctx context.Context, instance *p
 	if err := h.store.Update(ctx, instance); err != nil {
 		return fmt.Errorf("failed to update completed saga: %w", err)
 	}
+	metrics.RecordSagaCompleted(ctx, instance.DefinitionID, time.Since(instance.CreatedAt).Seconds())
 
 	// Send completed event
 	completedEvent := NewSagaCompletedEvent(
@@ -274,6 +319,20 @@ func (h *OrchestratorEventHandler) completeSaga(ctx context.Context, instance *p
 	return nil
 }
 
+// definitionStep returns a step of a registered saga definition, or nil if either is unknown
+func (h *OrchestratorEventHandler) definitionStep(definitionID, stepName string) *pkgsaga.Step {
+	def, err := h.orchestrator.GetDefinition(definitionID)
+	if err != nil {
+		return nil
+	}
+	for _, step := range def.Steps {
+		if step.Name == stepName {
+			return step
+		}
+	}
+	return nil
+}
+
 // getNextStep returns the next step name after the given step
 func (h *OrchestratorEventHandler) getNextStep(currentStep string) string {
 	switch currentStep {
@@ -7,27 +7,18 @@ import (
 	"sync"
 	"time"
 
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/metrics"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/events"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
 	pkgsaga "github.com/prohmpiriya/booking-rush-10k-rps/pkg/saga"
 	"github.com/twmb/franz-go/pkg/kgo"
 )
 
-const (
-	// TopicPaymentSuccess is the Kafka topic for payment success events
-	TopicPaymentSuccess = "payment.success"
-)
+// TopicPaymentSuccess is the Kafka topic for payment success events
+const TopicPaymentSuccess = events.TopicPaymentSuccess
 
 // PaymentSuccessEvent represents a payment success event from payment service
-type PaymentSuccessEvent struct {
-	EventType             string    `json:"event_type"`
-	BookingID             string    `json:"booking_id"`
-	PaymentID             string    `json:"payment_id"`
-	StripePaymentIntentID string    `json:"stripe_payment_intent_id"`
-	UserID                string    `json:"user_id,omitempty"`
-	Amount                int64     `json:"amount"`
-	Currency              string    `json:"currency"`
-	Timestamp             time.Time `json:"timestamp"`
-}
+type PaymentSuccessEvent = events.PaymentSuccessEvent
 
 // PostPaymentSagaData contains data for the post-payment saga
 type PostPaymentSagaData struct {
@@ -61,6 +52,7 @@ type PaymentSuccessConsumerConfig struct {
 	Store            pkgsaga.Store
 	Producer         SagaProducer
 	Logger           pkgsaga.Logger
+	Orchestrator     *pkgsaga.Orchestrator // Tunes the first step from the registered definition (optional)
 	SessionTimeout   time.Duration
 	RebalanceTimeout time.Duration
 }
@@ -221,19 +213,27 @@ func (c *PaymentSuccessConsumer) startPostPaymentSaga(ctx context.Context, data
 	}
 
 	// Send first command (confirm-booking)
+	timeout, retries, topic := 30*time.Second, 3, ""
+	if c.config.Orchestrator != nil {
+		if def, err := c.config.Orchestrator.GetDefinition(PostPaymentSagaName); err == nil && len(def.Steps) > 0 {
+			timeout, retries, topic = def.Steps[0].Timeout, def.Steps[0].Retries, def.Steps[0].Topic
+		}
+	}
 	cmd := NewSagaCommand(
 		instance.ID,         // sagaID
 		PostPaymentSagaName, // sagaName
 		StepConfirmBooking,  // stepName
 		0,                   // stepIndex
 		data.ToMap(),        // data
-		30*time.Second,      // timeout
-		3,                   // maxRetries
+		timeout,             // timeout
+		retries,             // maxRetries
 	)
+	cmd.Topic = topic
 
 	if err := c.producer.SendCommand(ctx, cmd); err != nil {
 		return "", fmt.Errorf("failed to send confirm-booking command: %w", err)
 	}
 
+	metrics.RecordSagaStarted(ctx, PostPaymentSagaName)
 	return instance.ID, nil
 }
@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/metrics"
 	pkgsaga "github.com/prohmpiriya/booking-rush-10k-rps/pkg/saga"
 )
 
@@ -196,6 +197,8 @@ func (h *TimeoutHandler) handleExpiredTimeout(ctx context.Context, check *Timeou
 }
 
 func (h *TimeoutHandler) triggerTimeoutCompensation(ctx context.Context, check *TimeoutCheck, instance *pkgsaga.Instance) {
+	metrics.RecordSagaFailed(ctx, instance.DefinitionID, check.StepName, "timeout")
+
 	// Update saga status to compensating
 	instance.SetStatus(pkgsaga.StatusCompensating)
 	instance.SetError(fmt.Errorf("step %s timed out after %s", check.StepName, time.Since(check.TimeoutAt.Add(-time.Since(check.TimeoutAt)))))
@@ -303,6 +306,8 @@ func (h *TimeoutHandler) sendCompensationCommands(ctx context.Context, check *Ti
 		h.logger.ErrorContext(ctx, "Failed to update saga to compensated",
 			"saga_id", instance.ID,
 			"error", err)
+	} else {
+		metrics.RecordSagaCompensated(ctx, instance.DefinitionID, now.Sub(instance.CreatedAt).Seconds())
 	}
 
 	// Send compensated lifecycle event
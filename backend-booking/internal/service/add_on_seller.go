@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+)
+
+// AddOnSeller sells event add-ons with reservations: it prices the requested add-ons
+// from the event's cached add-on list and counts them against each add-on's own
+// inventory, separate from the zone's seats
+type AddOnSeller struct {
+	addOns    AddOnFetcher
+	addOnRepo repository.AddOnRepository
+}
+
+// NewAddOnSeller creates a new add-on seller reading add-ons through the zone catalog
+func NewAddOnSeller(addOns AddOnFetcher, addOnRepo repository.AddOnRepository) *AddOnSeller {
+	return &AddOnSeller{
+		addOns:    addOns,
+		addOnRepo: addOnRepo,
+	}
+}
+
+// Claim prices a reservation's add-ons and claims their units under the booking ID,
+// all or none. Repeated selections of an add-on are merged.
+func (s *AddOnSeller) Claim(ctx context.Context, eventID, bookingID string, selections []domain.AddOnSelection) ([]domain.BookingAddOn, error) {
+	if len(selections) == 0 {
+		return nil, nil
+	}
+	addOns, err := s.addOns.FetchAddOns(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch add-ons of event %s: %w", eventID, err)
+	}
+	byID := make(map[string]*EventAddOn, len(addOns))
+	for _, addOn := range addOns {
+		if addOn.IsActive {
+			byID[addOn.ID] = addOn
+		}
+	}
+
+	var order []string
+	quantities := make(map[string]int, len(selections))
+	for _, selection := range selections {
+		if err := selection.Validate(); err != nil {
+			return nil, err
+		}
+		if _, ok := byID[selection.AddOnID]; !ok {
+			return nil, fmt.Errorf("%w: %s", domain.ErrAddOnNotFound, selection.AddOnID)
+		}
+		if _, ok := quantities[selection.AddOnID]; !ok {
+			order = append(order, selection.AddOnID)
+		}
+		quantities[selection.AddOnID] += selection.Quantity
+	}
+
+	lines := make([]domain.BookingAddOn, 0, len(order))
+	claims := make([]repository.AddOnClaim, 0, len(order))
+	for _, addOnID := range order {
+		addOn, quantity := byID[addOnID], quantities[addOnID]
+		if addOn.MaxPerBooking > 0 && quantity > addOn.MaxPerBooking {
+			return nil, fmt.Errorf("%w: at most %d %s", domain.ErrAddOnLimitExceeded, addOn.MaxPerBooking, addOn.Name)
+		}
+		lines = append(lines, domain.BookingAddOn{
+			AddOnID:    addOn.ID,
+			Name:       addOn.Name,
+			Category:   addOn.Category,
+			Quantity:   quantity,
+			UnitPrice:  addOn.Price,
+			TotalPrice: addOn.Price * float64(quantity),
+		})
+		claims = append(claims, repository.AddOnClaim{AddOnID: addOn.ID, Quantity: quantity, Inventory: addOn.Inventory})
+	}
+
+	soldOut, err := s.addOnRepo.ClaimAddOns(ctx, eventID, bookingID, claims)
+	if err != nil {
+		return nil, err
+	}
+	if soldOut != "" {
+		return nil, fmt.Errorf("%w: %s", domain.ErrAddOnSoldOut, byID[soldOut].Name)
+	}
+	return lines, nil
+}
+
+// Release gives back a claim whose reservation was never made
+func (s *AddOnSeller) Release(ctx context.Context, eventID, bookingID string) error {
+	return s.addOnRepo.ReleaseAddOns(ctx, eventID, bookingID)
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+)
+
+// stubAddOnCatalog serves fixed add-ons per event
+type stubAddOnCatalog map[string][]*EventAddOn
+
+func (s stubAddOnCatalog) FetchAddOns(ctx context.Context, eventID string) ([]*EventAddOn, error) {
+	return s[eventID], nil
+}
+
+// memoryAddOnRepository counts add-on claims in memory the way the Lua scripts do
+type memoryAddOnRepository struct {
+	sold   map[string]int
+	claims map[string][]repository.AddOnClaim
+}
+
+func newMemoryAddOnRepository() *memoryAddOnRepository {
+	return &memoryAddOnRepository{sold: map[string]int{}, claims: map[string][]repository.AddOnClaim{}}
+}
+
+func (r *memoryAddOnRepository) ClaimAddOns(ctx context.Context, eventID, bookingID string, claims []repository.AddOnClaim) (string, error) {
+	if _, ok := r.claims[bookingID]; ok {
+		return "", nil
+	}
+	for _, claim := range claims {
+		if claim.Inventory > 0 && r.sold[claim.AddOnID]+claim.Quantity > claim.Inventory {
+			return claim.AddOnID, nil
+		}
+	}
+	for _, claim := range claims {
+		r.sold[claim.AddOnID] += claim.Quantity
+	}
+	r.claims[bookingID] = claims
+	return "", nil
+}
+
+func (r *memoryAddOnRepository) ReleaseAddOns(ctx context.Context, eventID, bookingID string) error {
+	for _, claim := range r.claims[bookingID] {
+		r.sold[claim.AddOnID] -= claim.Quantity
+	}
+	delete(r.claims, bookingID)
+	return nil
+}
+
+func testAddOnCatalog() stubAddOnCatalog {
+	return stubAddOnCatalog{"event-001": {
+		{ID: "parking", Name: "Parking", Category: "parking", Price: 200, Inventory: 2, MaxPerBooking: 1, IsActive: true},
+		{ID: "tshirt", Name: "T-shirt", Category: "merchandise", Price: 350, IsActive: true},
+		{ID: "poster", Name: "Poster", Price: 100},
+	}}
+}
+
+func TestAddOnSeller_Claim(t *testing.T) {
+	addOnRepo := newMemoryAddOnRepository()
+	seller := NewAddOnSeller(testAddOnCatalog(), addOnRepo)
+	ctx := context.Background()
+
+	lines, err := seller.Claim(ctx, "event-001", "booking-1", []domain.AddOnSelection{
+		{AddOnID: "tshirt", Quantity: 1},
+		{AddOnID: "parking", Quantity: 1},
+		{AddOnID: "tshirt", Quantity: 2},
+	})
+	if err != nil {
+		t.Fatalf("Claim() error = %v", err)
+	}
+	if len(lines) != 2 || lines[0].AddOnID != "tshirt" || lines[0].Quantity != 3 || lines[0].TotalPrice != 1050 {
+		t.Errorf("Claim() = %+v, want 3 t-shirts at 1050 then parking", lines)
+	}
+
+	tests := []struct {
+		name       string
+		selections []domain.AddOnSelection
+		wantErr    error
+	}{
+		{"inactive", []domain.AddOnSelection{{AddOnID: "poster", Quantity: 1}}, domain.ErrAddOnNotFound},
+		{"unknown", []domain.AddOnSelection{{AddOnID: "hotdog", Quantity: 1}}, domain.ErrAddOnNotFound},
+		{"invalid quantity", []domain.AddOnSelection{{AddOnID: "tshirt", Quantity: 11}}, domain.ErrInvalidAddOnQuantity},
+		{"per-booking limit", []domain.AddOnSelection{{AddOnID: "parking", Quantity: 1}, {AddOnID: "parking", Quantity: 1}}, domain.ErrAddOnLimitExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := seller.Claim(ctx, "event-001", "booking-x", tt.selections); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Claim() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// The last parking pass sells, then parking is sold out and nothing else is claimed
+	if _, err := seller.Claim(ctx, "event-001", "booking-2", []domain.AddOnSelection{{AddOnID: "parking", Quantity: 1}}); err != nil {
+		t.Fatalf("Claim() error = %v", err)
+	}
+	_, err = seller.Claim(ctx, "event-001", "booking-3", []domain.AddOnSelection{{AddOnID: "tshirt", Quantity: 1}, {AddOnID: "parking", Quantity: 1}})
+	if !errors.Is(err, domain.ErrAddOnSoldOut) {
+		t.Errorf("Claim() error = %v, want ErrAddOnSoldOut", err)
+	}
+	if addOnRepo.sold["tshirt"] != 3 {
+		t.Errorf("t-shirts sold = %d, want 3", addOnRepo.sold["tshirt"])
+	}
+
+	// Released parking sells again
+	if err := seller.Release(ctx, "event-001", "booking-1"); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if _, err := seller.Claim(ctx, "event-001", "booking-3", []domain.AddOnSelection{{AddOnID: "parking", Quantity: 1}}); err != nil {
+		t.Errorf("Claim() after release error = %v", err)
+	}
+}
+
+func TestBookingService_ReserveSeats_AddOns(t *testing.T) {
+	addOnRepo := newMemoryAddOnRepository()
+
+	soldOut := false
+	reservationRepo := &MockReservationRepository{
+		ReserveSeatsFunc: func(ctx context.Context, params repository.ReserveParams) (*repository.ReserveResult, error) {
+			if soldOut {
+				return &repository.ReserveResult{Success: false, ErrorCode: "INSUFFICIENT_STOCK"}, nil
+			}
+			if _, ok := addOnRepo.claims[params.BookingID]; !ok {
+				t.Errorf("reserved %s without an add-on claim under its booking ID", params.BookingID)
+			}
+			return &repository.ReserveResult{Success: true, BookingID: params.BookingID}, nil
+		},
+	}
+	catalog := &stubZoneCatalog{zones: []*ZoneInfo{{ID: "zone-001", ShowID: "show-001", Price: 1000}}}
+	svc := NewBookingService(&MockBookingRepository{}, reservationRepo, nil, nil, nil, nil, nil, nil, &BookingServiceConfig{
+		ZonePrices: catalog,
+		AddOns:     NewAddOnSeller(testAddOnCatalog(), addOnRepo),
+	})
+	reserve := func() (*dto.ReserveSeatsResponse, error) {
+		return svc.ReserveSeats(context.Background(), "user-001", &dto.ReserveSeatsRequest{
+			EventID:  "event-001",
+			ZoneID:   "zone-001",
+			ShowID:   "show-001",
+			Quantity: 2,
+			AddOns:   []domain.AddOnSelection{{AddOnID: "parking", Quantity: 1}, {AddOnID: "tshirt", Quantity: 2}},
+		})
+	}
+
+	// A failed reservation gives its add-ons back
+	soldOut = true
+	if _, err := reserve(); err == nil {
+		t.Fatal("ReserveSeats() expected an error for a sold-out zone")
+	}
+	if addOnRepo.sold["parking"] != 0 || addOnRepo.sold["tshirt"] != 0 {
+		t.Errorf("add-ons sold after a failed reservation = %v, want none", addOnRepo.sold)
+	}
+
+	soldOut = false
+	resp, err := reserve()
+	if err != nil {
+		t.Fatalf("ReserveSeats() unexpected error = %v", err)
+	}
+	if resp.TotalPrice != 2*1000+200+2*350 || len(resp.AddOns) != 2 {
+		t.Errorf("ReserveSeats() = %v with %d add-ons, want 2900 with 2", resp.TotalPrice, len(resp.AddOns))
+	}
+}
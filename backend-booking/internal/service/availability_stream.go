@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+)
+
+const (
+	// availabilitySubscriberBuffer is how many flips a slow stream may fall behind
+	// before further flips are dropped for it
+	availabilitySubscriberBuffer = 16
+
+	// availabilityResubscribeDelay is how long Run waits before resubscribing after
+	// the broadcaster's subscription ends
+	availabilityResubscribeDelay = time.Second
+)
+
+// AvailabilityStream fans zone availability flips out to the streams watching an
+// event. Each instance holds one Redis subscription however many frontends are connected.
+type AvailabilityStream struct {
+	broadcaster repository.AvailabilityBroadcaster
+
+	mu          sync.RWMutex
+	subscribers map[string]map[chan *domain.ZoneAvailabilityEvent]struct{}
+}
+
+// NewAvailabilityStream creates a new availability stream; call Run to start delivering flips
+func NewAvailabilityStream(broadcaster repository.AvailabilityBroadcaster) *AvailabilityStream {
+	return &AvailabilityStream{
+		broadcaster: broadcaster,
+		subscribers: make(map[string]map[chan *domain.ZoneAvailabilityEvent]struct{}),
+	}
+}
+
+// Run receives published flips until ctx is done, resubscribing if the subscription drops
+func (s *AvailabilityStream) Run(ctx context.Context) {
+	for {
+		events, closeEvents := s.broadcaster.Subscribe(ctx)
+		for event := range events {
+			s.deliver(event)
+		}
+		closeEvents()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(availabilityResubscribeDelay):
+			logger.Get().Warn(fmt.Sprintf("Availability stream: subscription to %s ended, resubscribing", repository.AvailabilityChannel))
+		}
+	}
+}
+
+// Subscribe delivers the flips of eventID's zones until cancel is called
+func (s *AvailabilityStream) Subscribe(eventID string) (<-chan *domain.ZoneAvailabilityEvent, func()) {
+	ch := make(chan *domain.ZoneAvailabilityEvent, availabilitySubscriberBuffer)
+
+	s.mu.Lock()
+	if s.subscribers[eventID] == nil {
+		s.subscribers[eventID] = make(map[chan *domain.ZoneAvailabilityEvent]struct{})
+	}
+	s.subscribers[eventID][ch] = struct{}{}
+	s.mu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			s.mu.Lock()
+			delete(s.subscribers[eventID], ch)
+			if len(s.subscribers[eventID]) == 0 {
+				delete(s.subscribers, eventID)
+			}
+			s.mu.Unlock()
+		})
+	}
+	return ch, cancel
+}
+
+// deliver sends a flip to every stream watching its event. A stream that is not
+// keeping up misses the flip rather than holding up the others.
+func (s *AvailabilityStream) deliver(event *domain.ZoneAvailabilityEvent) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for ch := range s.subscribers[event.EventID] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
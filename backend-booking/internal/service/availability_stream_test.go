@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// fakeAvailabilityBroadcaster delivers flips sent on events
+type fakeAvailabilityBroadcaster struct {
+	events chan *domain.ZoneAvailabilityEvent
+}
+
+func (f *fakeAvailabilityBroadcaster) Observe(ctx context.Context, eventID, zoneID string, available int64) error {
+	return nil
+}
+
+func (f *fakeAvailabilityBroadcaster) Subscribe(ctx context.Context) (<-chan *domain.ZoneAvailabilityEvent, func() error) {
+	return f.events, func() error { return nil }
+}
+
+func TestAvailabilityStream_DeliversByEvent(t *testing.T) {
+	broadcaster := &fakeAvailabilityBroadcaster{events: make(chan *domain.ZoneAvailabilityEvent)}
+	stream := NewAvailabilityStream(broadcaster)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go stream.Run(ctx)
+
+	watching, stopWatching := stream.Subscribe("event-1")
+	other, stopOther := stream.Subscribe("event-2")
+	defer stopOther()
+
+	broadcaster.events <- &domain.ZoneAvailabilityEvent{EventType: domain.ZoneSoldOutEventType, EventID: "event-1", ZoneID: "zone-1"}
+
+	select {
+	case event := <-watching:
+		if event.ZoneID != "zone-1" || event.EventType != domain.ZoneSoldOutEventType {
+			t.Errorf("unexpected flip: %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the flip on the event's stream")
+	}
+	select {
+	case event := <-other:
+		t.Errorf("expected no flip for another event, got %+v", event)
+	default:
+	}
+
+	// A cancelled stream is no longer delivered to, and a slow one does not block the rest
+	stopWatching()
+	for i := 0; i < availabilitySubscriberBuffer+1; i++ {
+		broadcaster.events <- &domain.ZoneAvailabilityEvent{EventType: domain.ZoneLowStockEventType, EventID: "event-2", ZoneID: "zone-2"}
+	}
+	// Run only takes the next flip once the previous one is delivered
+	broadcaster.events <- &domain.ZoneAvailabilityEvent{EventType: domain.ZoneLowStockEventType, EventID: "event-3", ZoneID: "zone-3"}
+	if len(watching) != 0 {
+		t.Errorf("expected no flips after cancel, got %d", len(watching))
+	}
+	if len(other) != availabilitySubscriberBuffer {
+		t.Errorf("expected %d buffered flips, got %d", availabilitySubscriberBuffer, len(other))
+	}
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/metrics"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+)
+
+// Availability verification outcomes, used in metrics
+const (
+	AvailabilityVerifyMatch    = "match"
+	AvailabilityVerifyDiverged = "diverged"
+	AvailabilityVerifyMissing  = "missing" // Zone has no seat_zones row
+	AvailabilityVerifyError    = "error"
+	AvailabilityVerifyDropped  = "dropped" // Queue full
+)
+
+// ZoneAvailabilityReader reads the live availability of a zone (ReservationRepository)
+type ZoneAvailabilityReader interface {
+	GetZoneAvailability(ctx context.Context, zoneID string) (int64, error)
+}
+
+// AvailabilityVerifierConfig contains configuration for the availability verifier
+type AvailabilityVerifierConfig struct {
+	// SamplePercent is the percentage of reads verified, from 0 to 100
+	SamplePercent float64
+	// Workers verify sampled reads in parallel (default: 2)
+	Workers int
+	// QueueSize caps sampled reads waiting for a worker; more are dropped (default: 256)
+	QueueSize int
+	// Timeout bounds each PostgreSQL read (default: 1s)
+	Timeout time.Duration
+}
+
+// availabilitySample is a Redis availability read waiting to be verified
+type availabilitySample struct {
+	zoneID    string
+	available int64
+}
+
+// AvailabilityVerifier compares a sample of Redis availability reads with seat_zones in
+// PostgreSQL in the background and records divergence by zone. PostgreSQL trails Redis by
+// the inventory worker's flush interval, so small divergences during sales are expected;
+// a divergence that persists or grows is drift the next reconciliation would correct.
+type AvailabilityVerifier struct {
+	inventory repository.ZoneInventoryRepository
+	percent   float64
+	workers   int
+	timeout   time.Duration
+	samples   chan availabilitySample
+}
+
+// NewAvailabilityVerifier creates a new availability verifier; call Run to start verifying
+func NewAvailabilityVerifier(inventory repository.ZoneInventoryRepository, cfg *AvailabilityVerifierConfig) *AvailabilityVerifier {
+	v := &AvailabilityVerifier{
+		inventory: inventory,
+		workers:   2,
+		timeout:   time.Second,
+	}
+	queueSize := 256
+	if cfg != nil {
+		v.percent = min(max(cfg.SamplePercent, 0), 100)
+		if cfg.Workers > 0 {
+			v.workers = cfg.Workers
+		}
+		if cfg.QueueSize > 0 {
+			queueSize = cfg.QueueSize
+		}
+		if cfg.Timeout > 0 {
+			v.timeout = cfg.Timeout
+		}
+	}
+	v.samples = make(chan availabilitySample, queueSize)
+	return v
+}
+
+// Run verifies sampled reads until ctx is done
+func (v *AvailabilityVerifier) Run(ctx context.Context) {
+	done := make(chan struct{})
+	for i := 0; i < v.workers; i++ {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case sample := <-v.samples:
+					v.verify(ctx, sample)
+				}
+			}
+		}()
+	}
+	for i := 0; i < v.workers; i++ {
+		<-done
+	}
+}
+
+// Sample queues a Redis availability read for verification if it is picked for the
+// sample. It never blocks; reads are dropped while the queue is full. A nil verifier
+// samples nothing.
+func (v *AvailabilityVerifier) Sample(zoneID string, available int64) {
+	if v == nil || v.percent <= 0 || rand.Float64()*100 >= v.percent {
+		return
+	}
+	select {
+	case v.samples <- availabilitySample{zoneID: zoneID, available: available}:
+	default:
+		metrics.RecordAvailabilityVerification(context.Background(), AvailabilityVerifyDropped)
+	}
+}
+
+// Reader returns reader with its successful reads sampled for verification
+func (v *AvailabilityVerifier) Reader(reader ZoneAvailabilityReader) ZoneAvailabilityReader {
+	return &verifiedAvailabilityReader{reader: reader, verifier: v}
+}
+
+// verify compares a sampled read with PostgreSQL and records the outcome
+func (v *AvailabilityVerifier) verify(ctx context.Context, sample availabilitySample) {
+	ctx, cancel := context.WithTimeout(ctx, v.timeout)
+	defer cancel()
+
+	stored, err := v.inventory.GetAvailableSeats(ctx, sample.zoneID)
+	if err != nil {
+		if errors.Is(err, domain.ErrZoneNotFound) {
+			metrics.RecordAvailabilityVerification(ctx, AvailabilityVerifyMissing)
+			return
+		}
+		metrics.RecordAvailabilityVerification(ctx, AvailabilityVerifyError)
+		logger.Get().Warn(fmt.Sprintf("Availability verifier: failed to read zone %s from PostgreSQL: %v", sample.zoneID, err))
+		return
+	}
+
+	outcome, direction, seats := compareAvailability(sample.available, stored)
+	metrics.RecordAvailabilityVerification(ctx, outcome)
+	if outcome == AvailabilityVerifyDiverged {
+		metrics.RecordAvailabilityDivergence(ctx, sample.zoneID, direction, seats)
+	}
+}
+
+// compareAvailability classifies a Redis read against the PostgreSQL value, returning the
+// outcome and, when they diverged, which side is higher and by how many seats
+func compareAvailability(redis, postgres int64) (string, string, int64) {
+	switch {
+	case redis > postgres:
+		return AvailabilityVerifyDiverged, "redis_higher", redis - postgres
+	case redis < postgres:
+		return AvailabilityVerifyDiverged, "redis_lower", postgres - redis
+	default:
+		return AvailabilityVerifyMatch, "", 0
+	}
+}
+
+// verifiedAvailabilityReader samples the reads of a ZoneAvailabilityReader
+type verifiedAvailabilityReader struct {
+	reader   ZoneAvailabilityReader
+	verifier *AvailabilityVerifier
+}
+
+// GetZoneAvailability reads availability and samples the read for verification
+func (r *verifiedAvailabilityReader) GetZoneAvailability(ctx context.Context, zoneID string) (int64, error) {
+	available, err := r.reader.GetZoneAvailability(ctx, zoneID)
+	if err == nil {
+		r.verifier.Sample(zoneID, available)
+	}
+	return available, err
+}
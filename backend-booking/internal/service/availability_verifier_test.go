@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// fakeZoneInventory reports the zones it is asked for on reads
+type fakeZoneInventory struct {
+	reads chan string
+}
+
+func (f *fakeZoneInventory) GetAvailableSeats(ctx context.Context, zoneID string) (int64, error) {
+	f.reads <- zoneID
+	return 10, nil
+}
+
+func (f *fakeZoneInventory) GetZoneSeats(ctx context.Context, zoneIDs []string) (map[string]domain.ZoneSeatCounts, error) {
+	return nil, errors.New("not implemented")
+}
+
+// stubAvailabilityReader returns a fixed availability or error
+type stubAvailabilityReader struct {
+	available int64
+	err       error
+}
+
+func (s *stubAvailabilityReader) GetZoneAvailability(ctx context.Context, zoneID string) (int64, error) {
+	return s.available, s.err
+}
+
+func TestAvailabilityVerifier_Sample(t *testing.T) {
+	inventory := &fakeZoneInventory{reads: make(chan string, 4)}
+
+	// Nothing is sampled at 0%, and a nil verifier is a no-op
+	NewAvailabilityVerifier(inventory, &AvailabilityVerifierConfig{SamplePercent: 0}).Sample("zone-1", 5)
+	var none *AvailabilityVerifier
+	none.Sample("zone-1", 5)
+
+	verifier := NewAvailabilityVerifier(inventory, &AvailabilityVerifierConfig{SamplePercent: 100, QueueSize: 1})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go verifier.Run(ctx)
+
+	reader := verifier.Reader(&stubAvailabilityReader{available: 7})
+	if available, err := reader.GetZoneAvailability(ctx, "zone-2"); err != nil || available != 7 {
+		t.Fatalf("GetZoneAvailability() = %d, %v, want 7, nil", available, err)
+	}
+
+	select {
+	case zoneID := <-inventory.reads:
+		if zoneID != "zone-2" {
+			t.Errorf("verified zone = %s, want zone-2", zoneID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the sampled read to be verified")
+	}
+
+	// Failed reads are not verified
+	failing := verifier.Reader(&stubAvailabilityReader{err: errors.New("redis down")})
+	if _, err := failing.GetZoneAvailability(ctx, "zone-3"); err == nil {
+		t.Error("expected the read error to be returned")
+	}
+	select {
+	case zoneID := <-inventory.reads:
+		t.Errorf("expected no verification of a failed read, got %s", zoneID)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestAvailabilityVerifier_SampleNeverBlocks(t *testing.T) {
+	// Without Run nothing drains the queue, so reads past its size are dropped
+	verifier := NewAvailabilityVerifier(&fakeZoneInventory{}, &AvailabilityVerifierConfig{SamplePercent: 100, QueueSize: 2})
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 10; i++ {
+			verifier.Sample("zone-1", 5)
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Sample blocked on a full queue")
+	}
+	if len(verifier.samples) != 2 {
+		t.Errorf("queued samples = %d, want 2", len(verifier.samples))
+	}
+}
+
+func TestCompareAvailability(t *testing.T) {
+	tests := []struct {
+		name      string
+		redis     int64
+		postgres  int64
+		outcome   string
+		direction string
+		seats     int64
+	}{
+		{"match", 40, 40, AvailabilityVerifyMatch, "", 0},
+		{"redis higher", 45, 40, AvailabilityVerifyDiverged, "redis_higher", 5},
+		{"redis lower", 38, 40, AvailabilityVerifyDiverged, "redis_lower", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outcome, direction, seats := compareAvailability(tt.redis, tt.postgres)
+			if outcome != tt.outcome || direction != tt.direction || seats != tt.seats {
+				t.Errorf("compareAvailability(%d, %d) = %s, %s, %d, want %s, %s, %d",
+					tt.redis, tt.postgres, outcome, direction, seats, tt.outcome, tt.direction, tt.seats)
+			}
+		})
+	}
+}
@@ -0,0 +1,307 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/metrics"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// BookingCancellationService lets users cancel their own bookings under the cancellation policy
+type BookingCancellationService interface {
+	// QuoteCancellation prices the cancellation of a booking now and signs the quote
+	QuoteCancellation(ctx context.Context, bookingID, userID string) (*dto.CancellationQuoteResponse, error)
+
+	// CancelBooking cancels a booking and refunds what the policy allows. A valid quote token
+	// refunds the quoted amount instead, even if a cheaper tier applies by now.
+	CancelBooking(ctx context.Context, bookingID, userID, quoteToken string) (*dto.CancelBookingResponse, error)
+}
+
+// BookingCancellationConfig contains configuration for booking cancellation
+type BookingCancellationConfig struct {
+	// Policy prices cancellations (nil = DefaultCancellationPolicy)
+	Policy *domain.CancellationPolicy
+	// QuoteTTL is how long a quoted refund is guaranteed
+	QuoteTTL time.Duration
+	// Secret signs quote tokens
+	Secret string
+}
+
+// bookingCancellationService implements BookingCancellationService
+type bookingCancellationService struct {
+	bookingService  BookingService
+	bookingRepo     repository.BookingRepository
+	reservationRepo repository.ReservationRepository
+	payments        PaymentAdjuster
+	eventPublisher  EventPublisher
+	standby         StandbyService
+	policy          *domain.CancellationPolicy
+	quoteTTL        time.Duration
+	secret          string
+}
+
+// NewBookingCancellationService creates a new booking cancellation service.
+// Reservations are cancelled by bookingService; without a payment adjuster confirmed
+// bookings can only be cancelled when nothing is refunded.
+func NewBookingCancellationService(
+	bookingService BookingService,
+	bookingRepo repository.BookingRepository,
+	reservationRepo repository.ReservationRepository,
+	payments PaymentAdjuster,
+	eventPublisher EventPublisher,
+	standby StandbyService,
+	cfg *BookingCancellationConfig,
+) BookingCancellationService {
+	s := &bookingCancellationService{
+		bookingService:  bookingService,
+		bookingRepo:     bookingRepo,
+		reservationRepo: reservationRepo,
+		payments:        payments,
+		eventPublisher:  eventPublisher,
+		standby:         standby,
+		policy:          domain.DefaultCancellationPolicy(),
+		quoteTTL:        10 * time.Minute,
+	}
+	if cfg != nil {
+		if cfg.Policy != nil {
+			s.policy = cfg.Policy
+		}
+		if cfg.QuoteTTL > 0 {
+			s.quoteTTL = cfg.QuoteTTL
+		}
+		s.secret = cfg.Secret
+	}
+	if s.eventPublisher == nil {
+		s.eventPublisher = NewNoOpEventPublisher()
+	}
+	return s
+}
+
+// QuoteCancellation prices the cancellation of a booking and signs a token guaranteeing it
+func (s *bookingCancellationService) QuoteCancellation(ctx context.Context, bookingID, userID string) (*dto.CancellationQuoteResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.booking.quote_cancellation")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("booking_id", bookingID),
+		attribute.String("user_id", userID),
+	)
+
+	booking, err := s.getOwnBooking(ctx, bookingID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	quote, err := s.quote(ctx, booking)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	token, expiresAt, err := SignCancellationQuote(s.secret, booking, userID, quote, s.quoteTTL)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.Float64("refund_percent", quote.RefundPercent),
+		attribute.Float64("refund_amount", quote.RefundAmount),
+	)
+	span.SetStatus(codes.Ok, "")
+	return &dto.CancellationQuoteResponse{
+		BookingID:     booking.ID,
+		Status:        booking.Status.String(),
+		Currency:      booking.Currency,
+		TotalPaid:     quote.TotalPaid,
+		RefundPercent: quote.RefundPercent,
+		Forfeited:     quote.Forfeited,
+		Fee:           quote.Fee,
+		RefundAmount:  quote.RefundAmount,
+		ShowStartsAt:  quote.ShowStartsAt,
+		QuoteToken:    token,
+		ExpiresAt:     expiresAt,
+	}, nil
+}
+
+// CancelBooking cancels a booking of the user. Confirmed bookings are refunded first
+// and only then cancelled, so a failed refund leaves the booking as it was.
+func (s *bookingCancellationService) CancelBooking(ctx context.Context, bookingID, userID, quoteToken string) (*dto.CancelBookingResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.booking.cancel_with_refund")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("booking_id", bookingID),
+		attribute.String("user_id", userID),
+		attribute.Bool("quoted", quoteToken != ""),
+	)
+
+	var claims *CancellationQuoteClaims
+	if quoteToken != "" {
+		var err error
+		if claims, err = ParseCancellationQuote(s.secret, quoteToken); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+	}
+
+	booking, err := s.getOwnBooking(ctx, bookingID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if claims != nil && !claims.Matches(booking, userID) {
+		span.SetStatus(codes.Error, "quote mismatch")
+		return nil, domain.ErrInvalidCancellationQuote
+	}
+
+	// The current quote also checks the booking can still be cancelled
+	quote, err := s.quote(ctx, booking)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if claims != nil {
+		quote = claims.Quote()
+	}
+
+	if !booking.IsConfirmed() {
+		// Nothing was paid; the reservation is released as before
+		released, err := s.bookingService.CancelBooking(ctx, bookingID, userID)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		span.SetStatus(codes.Ok, "")
+		return &dto.CancelBookingResponse{
+			BookingID:    released.BookingID,
+			Status:       released.Status,
+			Message:      released.Message,
+			QuoteApplied: claims != nil,
+		}, nil
+	}
+
+	// 1. Refund. The adjustment ID is fixed per booking, so a retried cancellation
+	// is not refunded twice.
+	if quote.RefundAmount > 0 {
+		if s.payments == nil {
+			span.SetStatus(codes.Error, "refund unavailable")
+			return nil, domain.ErrCancellationRefundFailed
+		}
+		adjustmentID := "cancel:" + booking.ID
+		if err := s.payments.AdjustPayment(ctx, booking.PaymentID, adjustmentID, -quote.RefundAmount, "booking_cancelled"); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, fmt.Errorf("%w: %v", domain.ErrCancellationRefundFailed, err)
+		}
+	}
+
+	// 2. Cancel the booking
+	now := time.Now()
+	booking.Status = domain.BookingStatusCancelled
+	booking.CancelledAt = &now
+	booking.UpdatedAt = now
+	if err := s.bookingRepo.Update(ctx, booking); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	// 3. Put the seats back on sale
+	s.releaseSoldSeats(ctx, booking)
+
+	if err := s.eventPublisher.PublishBookingCancelled(ctx, booking); err != nil {
+		span.RecordError(err)
+		logger.Get().Warn(fmt.Sprintf("Failed to publish booking.cancelled for %s: %v", booking.ID, err))
+	}
+	metrics.RecordCancellation(ctx, booking.EventID)
+
+	span.SetAttributes(
+		attribute.Float64("refund_amount", quote.RefundAmount),
+		attribute.Bool("quote_applied", claims != nil),
+	)
+	span.SetStatus(codes.Ok, "")
+	return &dto.CancelBookingResponse{
+		BookingID:    booking.ID,
+		Status:       booking.Status.String(),
+		Message:      "Booking cancelled successfully",
+		TotalPaid:    quote.TotalPaid,
+		Fee:          quote.Fee,
+		RefundAmount: quote.RefundAmount,
+		QuoteApplied: claims != nil,
+	}, nil
+}
+
+// getOwnBooking loads a booking and checks it belongs to the user
+func (s *bookingCancellationService) getOwnBooking(ctx context.Context, bookingID, userID string) (*domain.Booking, error) {
+	if bookingID == "" {
+		return nil, domain.ErrInvalidBookingID
+	}
+	if userID == "" {
+		return nil, domain.ErrInvalidUserID
+	}
+
+	booking, err := s.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if !booking.BelongsToUser(userID) {
+		return nil, domain.ErrInvalidUserID
+	}
+	return booking, nil
+}
+
+// quote prices the cancellation of the booking under the current policy
+func (s *bookingCancellationService) quote(ctx context.Context, booking *domain.Booking) (*domain.CancellationQuote, error) {
+	var showStartsAt *time.Time
+	if booking.ShowID != "" {
+		startsAt, err := s.bookingRepo.GetShowStartTime(ctx, booking.ShowID)
+		if err != nil {
+			return nil, err
+		}
+		showStartsAt = &startsAt
+	}
+	return s.policy.Quote(booking, showStartsAt, time.Now())
+}
+
+// releaseSoldSeats returns the seats of a cancelled confirmed booking to inventory and
+// offers them to the zone standby list. Failures are only logged: the booking is cancelled
+// and refunded either way.
+func (s *bookingCancellationService) releaseSoldSeats(ctx context.Context, booking *domain.Booking) {
+	releaser, ok := s.reservationRepo.(repository.SoldSeatReleaser)
+	if !ok {
+		return
+	}
+
+	result, err := releaser.ReleaseSoldSeats(ctx, booking.ID, booking.UserID)
+	if err != nil {
+		logger.Get().Warn(fmt.Sprintf("Failed to release seats of cancelled booking %s: %v", booking.ID, err))
+		return
+	}
+	if !result.Success {
+		logger.Get().Warn(fmt.Sprintf("Seats of cancelled booking %s not released: %s", booking.ID, result.ErrorCode))
+		return
+	}
+
+	if s.standby != nil {
+		go func(zoneID string) {
+			_, _ = s.standby.OfferReleasedSeats(context.Background(), zoneID)
+		}(booking.ZoneID)
+	}
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/middleware"
+)
+
+// MockSoldSeatReleaser is a MockReservationRepository that can release sold seats
+type MockSoldSeatReleaser struct {
+	*MockReservationRepository
+	Released []string
+}
+
+func (m *MockSoldSeatReleaser) ReleaseSoldSeats(ctx context.Context, bookingID, userID string) (*repository.ReleaseResult, error) {
+	m.Released = append(m.Released, bookingID)
+	return &repository.ReleaseResult{Success: true}, nil
+}
+
+type cancellationFixture struct {
+	svc       BookingCancellationService
+	booking   *domain.Booking
+	startsIn  time.Duration
+	updated   *domain.Booking
+	seats     *MockSoldSeatReleaser
+	adjuster  *MockPaymentAdjuster
+	publisher *MockEventPublisher
+}
+
+// newCancellationFixture creates a confirmed booking of 1000 paid with payment-1,
+// last updated at the same time in every fixture
+func newCancellationFixture() *cancellationFixture {
+	f := &cancellationFixture{
+		booking:   newModifiableBooking(domain.BookingStatusConfirmed),
+		startsIn:  8 * 24 * time.Hour,
+		seats:     &MockSoldSeatReleaser{MockReservationRepository: &MockReservationRepository{}},
+		adjuster:  &MockPaymentAdjuster{},
+		publisher: NewMockEventPublisher(),
+	}
+	f.booking.UpdatedAt = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	bookingRepo := &MockBookingRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Booking, error) {
+			b := *f.booking
+			return &b, nil
+		},
+		GetShowStartTimeFunc: func(ctx context.Context, showID string) (time.Time, error) {
+			return time.Now().Add(f.startsIn), nil
+		},
+		UpdateFunc: func(ctx context.Context, b *domain.Booking) error {
+			f.updated = b
+			return nil
+		},
+	}
+	bookingService := NewBookingService(bookingRepo, f.seats, nil, nil, nil, nil, nil, nil, nil)
+	f.svc = NewBookingCancellationService(bookingService, bookingRepo, f.seats, f.adjuster, f.publisher, nil, &BookingCancellationConfig{
+		Policy: &domain.CancellationPolicy{
+			Tiers: []domain.CancellationTier{
+				{Before: 7 * 24 * time.Hour, RefundPercent: 100},
+				{Before: 48 * time.Hour, RefundPercent: 50},
+			},
+			Fee: 20,
+		},
+		Secret: "test-secret",
+	})
+	return f
+}
+
+func TestBookingCancellationService_QuoteIsGuaranteed(t *testing.T) {
+	f := newCancellationFixture()
+
+	quote, err := f.svc.QuoteCancellation(context.Background(), "booking-1", "user-1")
+	if err != nil {
+		t.Fatalf("QuoteCancellation() error = %v", err)
+	}
+	if quote.RefundPercent != 100 || quote.Fee != 20 || quote.RefundAmount != 980 || quote.QuoteToken == "" {
+		t.Fatalf("QuoteCancellation() = %+v, want 980 refunded", quote)
+	}
+
+	// The show is now too close for a refund, but the quote still holds
+	f.startsIn = 3 * time.Hour
+	resp, err := f.svc.CancelBooking(context.Background(), "booking-1", "user-1", quote.QuoteToken)
+	if err != nil {
+		t.Fatalf("CancelBooking() error = %v", err)
+	}
+	if !resp.QuoteApplied || resp.RefundAmount != 980 || resp.Status != "cancelled" {
+		t.Errorf("CancelBooking() = %+v, want the quoted refund", resp)
+	}
+	if len(f.adjuster.Amounts) != 1 || f.adjuster.Amounts[0] != -980 {
+		t.Errorf("AdjustPayment amounts = %v, want [-980]", f.adjuster.Amounts)
+	}
+	if f.updated == nil || f.updated.Status != domain.BookingStatusCancelled || f.updated.CancelledAt == nil {
+		t.Errorf("Expected the booking to be saved as cancelled, got %+v", f.updated)
+	}
+	if len(f.seats.Released) != 1 || len(f.publisher.GetCancelledEvents()) != 1 {
+		t.Error("Expected the sold seats to be released and booking.cancelled published")
+	}
+}
+
+func TestBookingCancellationService_CancelWithoutQuote(t *testing.T) {
+	f := newCancellationFixture()
+	f.startsIn = 72 * time.Hour
+
+	resp, err := f.svc.CancelBooking(context.Background(), "booking-1", "user-1", "")
+	if err != nil {
+		t.Fatalf("CancelBooking() error = %v", err)
+	}
+	if resp.QuoteApplied || resp.RefundAmount != 480 {
+		t.Errorf("CancelBooking() = %+v, want 480 refunded under the current policy", resp)
+	}
+
+	// Nothing is refunded on the day, so payment service is not called
+	f = newCancellationFixture()
+	f.startsIn = time.Hour
+	if resp, err = f.svc.CancelBooking(context.Background(), "booking-1", "user-1", ""); err != nil || resp.RefundAmount != 0 {
+		t.Errorf("CancelBooking() = %+v, %v, want no refund", resp, err)
+	}
+	if len(f.adjuster.Amounts) != 0 {
+		t.Errorf("AdjustPayment amounts = %v, want none", f.adjuster.Amounts)
+	}
+}
+
+func TestBookingCancellationService_Reservation(t *testing.T) {
+	f := newCancellationFixture()
+	f.booking.Status = domain.BookingStatusReserved
+
+	resp, err := f.svc.CancelBooking(context.Background(), "booking-1", "user-1", "")
+	if err != nil {
+		t.Fatalf("CancelBooking() error = %v", err)
+	}
+	if resp.Status != "cancelled" || resp.RefundAmount != 0 || len(f.adjuster.Amounts) != 0 {
+		t.Errorf("CancelBooking() = %+v, want an unpaid cancellation", resp)
+	}
+	if len(f.seats.Released) != 0 {
+		t.Error("Reservations should be released by the booking service, not as sold seats")
+	}
+}
+
+func TestBookingCancellationService_Rejected(t *testing.T) {
+	f := newCancellationFixture()
+	quote, err := f.svc.QuoteCancellation(context.Background(), "booking-1", "user-1")
+	if err != nil {
+		t.Fatalf("QuoteCancellation() error = %v", err)
+	}
+	expired, _, err := SignCancellationQuote("test-secret", f.booking, "user-1", &domain.CancellationQuote{QuotedAt: time.Now().Add(-time.Hour)}, time.Minute)
+	if err != nil {
+		t.Fatalf("SignCancellationQuote() error = %v", err)
+	}
+	forged, _, _ := SignCancellationQuote("other-secret", f.booking, "user-1", &domain.CancellationQuote{QuotedAt: time.Now(), RefundAmount: 1000}, time.Minute)
+
+	tests := []struct {
+		name      string
+		token     string
+		setup     func(f *cancellationFixture)
+		wantError error
+	}{
+		{"expired quote", expired, nil, domain.ErrCancellationQuoteExpired},
+		{"forged quote", forged, nil, domain.ErrInvalidCancellationQuote},
+		{"booking changed since the quote", quote.QuoteToken, func(f *cancellationFixture) {
+			f.booking.UpdatedAt = f.booking.UpdatedAt.Add(time.Second)
+		}, domain.ErrInvalidCancellationQuote},
+		{"show started", "", func(f *cancellationFixture) { f.startsIn = -time.Minute }, domain.ErrCancellationClosed},
+		{"already cancelled", "", func(f *cancellationFixture) { f.booking.Status = domain.BookingStatusCancelled }, domain.ErrAlreadyReleased},
+		{"refund failed", "", func(f *cancellationFixture) { f.adjuster.Err = errors.New("payment not found") }, domain.ErrCancellationRefundFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newCancellationFixture()
+			if tt.setup != nil {
+				tt.setup(f)
+			}
+
+			_, err := f.svc.CancelBooking(context.Background(), "booking-1", "user-1", tt.token)
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("CancelBooking() error = %v, want %v", err, tt.wantError)
+			}
+			if f.updated != nil {
+				t.Error("Update should not be called when the cancellation is rejected")
+			}
+		})
+	}
+}
+
+// assertNotAccessToken fails the test if JWTMiddleware, configured with the JWT secret
+// token was derived from, accepts token as an access token
+func assertNotAccessToken(t *testing.T, secret, token string) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/me", middleware.JWTMiddleware(&middleware.JWTConfig{Secret: secret}), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("JWTMiddleware status = %d, want 401 for a token that is not an access token", w.Code)
+	}
+}
+
+func TestCancellationQuote_NotAnAccessToken(t *testing.T) {
+	f := newCancellationFixture()
+	quote, err := f.svc.QuoteCancellation(context.Background(), "booking-1", "user-1")
+	if err != nil {
+		t.Fatalf("QuoteCancellation() error = %v", err)
+	}
+	assertNotAccessToken(t, "test-secret", quote.QuoteToken)
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// BookingInsertBatcherConfig contains configuration for batching booking inserts
+type BookingInsertBatcherConfig struct {
+	MaxBatch     int           // Bookings inserted per statement
+	MaxDelay     time.Duration // Longest a booking waits for its batch to fill
+	Flushers     int           // Batches inserted in parallel (each holds one connection)
+	FlushTimeout time.Duration // Deadline of each batch insert
+}
+
+// DefaultBookingInsertBatcherConfig returns default configuration
+func DefaultBookingInsertBatcherConfig() *BookingInsertBatcherConfig {
+	return &BookingInsertBatcherConfig{
+		MaxBatch:     100,
+		MaxDelay:     2 * time.Millisecond,
+		Flushers:     4,
+		FlushTimeout: 5 * time.Second,
+	}
+}
+
+// BookingInsertBatcher inserts the bookings of concurrent reservations with one
+// statement per batch instead of one per request, so a burst of reservations holds a
+// few database connections instead of the whole pool. Each caller still learns whether
+// its own booking was stored: rows a batch skips or cannot store are inserted on their
+// own, with the errors of BookingRepository.Create.
+type BookingInsertBatcher struct {
+	repo    repository.BookingRepository
+	bulk    repository.BookingBulkInserter
+	config  *BookingInsertBatcherConfig
+	log     *logger.Logger
+	pending chan *pendingInsert
+	wg      sync.WaitGroup
+	mu      sync.RWMutex // Held for writing only while starting and stopping
+	running bool
+}
+
+// pendingInsert is a booking waiting for its batch
+type pendingInsert struct {
+	ctx     context.Context
+	booking *domain.Booking
+	done    chan error
+}
+
+// NewBookingInsertBatcher creates a batcher inserting bookings through repo, which must
+// implement repository.BookingBulkInserter
+func NewBookingInsertBatcher(repo repository.BookingRepository, cfg *BookingInsertBatcherConfig) (*BookingInsertBatcher, error) {
+	bulk, ok := repo.(repository.BookingBulkInserter)
+	if !ok {
+		return nil, errors.New("booking repository cannot insert bookings in bulk")
+	}
+
+	defaults := DefaultBookingInsertBatcherConfig()
+	if cfg == nil {
+		cfg = defaults
+	}
+	if cfg.MaxBatch <= 0 {
+		cfg.MaxBatch = defaults.MaxBatch
+	}
+	if cfg.MaxDelay <= 0 {
+		cfg.MaxDelay = defaults.MaxDelay
+	}
+	if cfg.Flushers <= 0 {
+		cfg.Flushers = defaults.Flushers
+	}
+	if cfg.FlushTimeout <= 0 {
+		cfg.FlushTimeout = defaults.FlushTimeout
+	}
+
+	return &BookingInsertBatcher{
+		repo:   repo,
+		bulk:   bulk,
+		config: cfg,
+		log:    logger.Get(),
+	}, nil
+}
+
+// Start starts the flushers
+func (b *BookingInsertBatcher) Start() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.running {
+		return fmt.Errorf("booking insert batcher already running")
+	}
+	b.running = true
+	b.pending = make(chan *pendingInsert, b.config.MaxBatch*b.config.Flushers)
+
+	for i := 0; i < b.config.Flushers; i++ {
+		b.wg.Add(1)
+		go b.run(b.pending)
+	}
+	b.log.Info(fmt.Sprintf("Batching booking inserts: max_batch=%d, max_delay=%v, flushers=%d",
+		b.config.MaxBatch, b.config.MaxDelay, b.config.Flushers))
+	return nil
+}
+
+// Stop inserts the bookings waiting for a batch and stops the flushers. Bookings
+// created afterwards are inserted one at a time.
+func (b *BookingInsertBatcher) Stop() {
+	b.mu.Lock()
+	if !b.running {
+		b.mu.Unlock()
+		return
+	}
+	b.running = false
+	close(b.pending)
+	b.mu.Unlock()
+
+	b.wg.Wait()
+	b.log.Info("Booking insert batcher stopped")
+}
+
+// Create inserts booking with the next batch and waits for the outcome. Like
+// BookingRepository.Create, a booking whose idempotency key is already stored is
+// replaced with the stored one and domain.ErrBookingAlreadyExists is returned.
+func (b *BookingInsertBatcher) Create(ctx context.Context, booking *domain.Booking) error {
+	p := &pendingInsert{ctx: ctx, booking: booking, done: make(chan error, 1)}
+
+	b.mu.RLock()
+	if !b.running {
+		b.mu.RUnlock()
+		return b.repo.Create(ctx, booking)
+	}
+	select {
+	case b.pending <- p:
+	case <-ctx.Done():
+		b.mu.RUnlock()
+		return ctx.Err()
+	}
+	b.mu.RUnlock()
+
+	// Once queued the booking may be inserted, so wait for the outcome even if ctx ends;
+	// flushes are bounded by MaxDelay plus FlushTimeout
+	return <-p.done
+}
+
+// run collects batches until pending is closed and drained
+func (b *BookingInsertBatcher) run(pending <-chan *pendingInsert) {
+	defer b.wg.Done()
+
+	batch := make([]*pendingInsert, 0, b.config.MaxBatch)
+	for first := range pending {
+		batch = append(batch[:0], first)
+		timer := time.NewTimer(b.config.MaxDelay)
+	fill:
+		for len(batch) < b.config.MaxBatch {
+			select {
+			case p, ok := <-pending:
+				if !ok {
+					break fill
+				}
+				batch = append(batch, p)
+			case <-timer.C:
+				break fill
+			}
+		}
+		timer.Stop()
+		b.flush(batch)
+	}
+}
+
+// flush inserts a batch and reports each booking's outcome to its caller
+func (b *BookingInsertBatcher) flush(batch []*pendingInsert) {
+	ctx, cancel := context.WithTimeout(context.Background(), b.config.FlushTimeout)
+	defer cancel()
+	ctx, span := telemetry.StartSpan(ctx, "service.booking.insert_batch")
+	defer span.End()
+
+	// Callers that gave up before the batch was sent never learn the outcome, so their
+	// bookings are not inserted
+	bookings := make([]*domain.Booking, 0, len(batch))
+	waiting := batch[:0]
+	for _, p := range batch {
+		if err := p.ctx.Err(); err != nil {
+			p.done <- err
+			continue
+		}
+		bookings = append(bookings, p.booking)
+		waiting = append(waiting, p)
+	}
+	if len(waiting) == 0 {
+		return
+	}
+	span.SetAttributes(attribute.Int("batch_size", len(waiting)))
+
+	inserted, err := b.bulk.CreateMany(ctx, bookings)
+	if err != nil {
+		// One bad row fails the whole statement; inserting each booking on its own
+		// fails only that one
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		b.log.Warn(fmt.Sprintf("Batch insert of %d bookings failed, inserting them one at a time: %v", len(waiting), err))
+		for _, p := range waiting {
+			p.done <- b.repo.Create(p.ctx, p.booking)
+		}
+		return
+	}
+
+	for _, p := range waiting {
+		if inserted[p.booking.ID] {
+			p.done <- nil
+			continue
+		}
+		// Skipped because its idempotency key is stored; Create answers with the stored booking
+		p.done <- b.repo.Create(p.ctx, p.booking)
+	}
+	span.SetAttributes(attribute.Int("inserted", len(inserted)))
+	span.SetStatus(codes.Ok, "")
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// MockBulkBookingRepository is a MockBookingRepository that inserts bookings in bulk
+type MockBulkBookingRepository struct {
+	*MockBookingRepository
+	mu             sync.Mutex
+	batches        [][]string
+	CreateManyFunc func(ctx context.Context, bookings []*domain.Booking) (map[string]bool, error)
+}
+
+func (m *MockBulkBookingRepository) CreateMany(ctx context.Context, bookings []*domain.Booking) (map[string]bool, error) {
+	m.mu.Lock()
+	ids := make([]string, len(bookings))
+	for i, b := range bookings {
+		ids[i] = b.ID
+	}
+	m.batches = append(m.batches, ids)
+	m.mu.Unlock()
+
+	if m.CreateManyFunc != nil {
+		return m.CreateManyFunc(ctx, bookings)
+	}
+	inserted := make(map[string]bool, len(bookings))
+	for _, b := range bookings {
+		inserted[b.ID] = true
+	}
+	return inserted, nil
+}
+
+// createConcurrently creates the bookings from one goroutine each and returns their errors by ID
+func createConcurrently(batcher *BookingInsertBatcher, ids ...string) map[string]error {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	errs := make(map[string]error, len(ids))
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			err := batcher.Create(context.Background(), &domain.Booking{ID: id, IdempotencyKey: "key-" + id})
+			mu.Lock()
+			errs[id] = err
+			mu.Unlock()
+		}(id)
+	}
+	wg.Wait()
+	return errs
+}
+
+func TestBookingInsertBatcher_Batches(t *testing.T) {
+	repo := &MockBulkBookingRepository{MockBookingRepository: &MockBookingRepository{
+		CreateFunc: func(ctx context.Context, booking *domain.Booking) error {
+			t.Errorf("Create(%s) called, want the booking inserted in bulk", booking.ID)
+			return nil
+		},
+	}}
+	batcher, err := NewBookingInsertBatcher(repo, &BookingInsertBatcherConfig{MaxBatch: 5, MaxDelay: 50 * time.Millisecond, Flushers: 1})
+	if err != nil {
+		t.Fatalf("NewBookingInsertBatcher() error = %v", err)
+	}
+	if err := batcher.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer batcher.Stop()
+
+	ids := make([]string, 10)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("booking-%d", i)
+	}
+	for id, err := range createConcurrently(batcher, ids...) {
+		if err != nil {
+			t.Errorf("Create(%s) error = %v", id, err)
+		}
+	}
+
+	total := 0
+	for _, batch := range repo.batches {
+		if len(batch) > 5 {
+			t.Errorf("batch of %d bookings, want at most 5", len(batch))
+		}
+		total += len(batch)
+	}
+	if total != 10 || len(repo.batches) >= 10 {
+		t.Errorf("batches = %v, want 10 bookings in fewer statements", repo.batches)
+	}
+}
+
+func TestBookingInsertBatcher_PerBookingOutcome(t *testing.T) {
+	stored := &domain.Booking{ID: "stored-booking", Status: domain.BookingStatusReserved}
+	errBadRow := errors.New("violates check constraint")
+
+	tests := []struct {
+		name       string
+		createMany func(ctx context.Context, bookings []*domain.Booking) (map[string]bool, error)
+		want       map[string]error
+	}{
+		{
+			name: "idempotency key already stored",
+			createMany: func(ctx context.Context, bookings []*domain.Booking) (map[string]bool, error) {
+				return map[string]bool{"booking-1": true}, nil
+			},
+			want: map[string]error{"booking-1": nil, "booking-2": domain.ErrBookingAlreadyExists},
+		},
+		{
+			name: "batch statement fails",
+			createMany: func(ctx context.Context, bookings []*domain.Booking) (map[string]bool, error) {
+				return nil, errBadRow
+			},
+			want: map[string]error{"booking-1": nil, "booking-2": errBadRow},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockBulkBookingRepository{
+				MockBookingRepository: &MockBookingRepository{
+					CreateFunc: func(ctx context.Context, booking *domain.Booking) error {
+						if booking.ID != "booking-2" {
+							return nil
+						}
+						if tt.name == "batch statement fails" {
+							return errBadRow
+						}
+						*booking = *stored
+						return domain.ErrBookingAlreadyExists
+					},
+				},
+				CreateManyFunc: tt.createMany,
+			}
+			batcher, _ := NewBookingInsertBatcher(repo, &BookingInsertBatcherConfig{MaxBatch: 2, MaxDelay: time.Second, Flushers: 1})
+			_ = batcher.Start()
+			defer batcher.Stop()
+
+			for id, err := range createConcurrently(batcher, "booking-1", "booking-2") {
+				if !errors.Is(err, tt.want[id]) {
+					t.Errorf("Create(%s) error = %v, want %v", id, err, tt.want[id])
+				}
+			}
+		})
+	}
+}
+
+func TestBookingInsertBatcher_NotRunning(t *testing.T) {
+	created := 0
+	repo := &MockBulkBookingRepository{MockBookingRepository: &MockBookingRepository{
+		CreateFunc: func(ctx context.Context, booking *domain.Booking) error {
+			created++
+			return nil
+		},
+	}}
+	batcher, _ := NewBookingInsertBatcher(repo, nil)
+
+	// Before Start and after Stop bookings are inserted one at a time
+	_ = batcher.Create(context.Background(), &domain.Booking{ID: "booking-1"})
+	_ = batcher.Start()
+	batcher.Stop()
+	_ = batcher.Create(context.Background(), &domain.Booking{ID: "booking-2"})
+
+	if created != 2 || len(repo.batches) != 0 {
+		t.Errorf("created %d bookings one at a time and %d batches, want 2 and 0", created, len(repo.batches))
+	}
+}
+
+func TestNewBookingInsertBatcher_RequiresBulkInserts(t *testing.T) {
+	if _, err := NewBookingInsertBatcher(&MockBookingRepository{}, nil); err == nil {
+		t.Error("NewBookingInsertBatcher() error = nil, want an error for a repository without bulk inserts")
+	}
+}
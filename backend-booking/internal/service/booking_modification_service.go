@@ -0,0 +1,355 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// Adjustments reported for a modification
+const (
+	AdjustmentNone     = "none"
+	AdjustmentCharged  = "charged"
+	AdjustmentRefunded = "refunded"
+)
+
+// BookingModificationService changes the zone or quantity of an existing booking
+type BookingModificationService interface {
+	// ModifyBooking swaps the seats of the booking and settles the price difference
+	ModifyBooking(ctx context.Context, bookingID, userID string, req *dto.ModifyBookingRequest) (*dto.ModifyBookingResponse, error)
+}
+
+// PaymentAdjuster settles price differences of paid bookings (payment-service)
+type PaymentAdjuster interface {
+	// AdjustPayment charges a positive amount or refunds a negative amount on the payment.
+	// Retries with the same adjustmentID are settled once.
+	AdjustPayment(ctx context.Context, paymentID, adjustmentID string, amount float64, reason string) error
+}
+
+// BookingModificationConfig contains configuration for booking modification
+type BookingModificationConfig struct {
+	MaxPerUser int
+	// PendingTTL bounds how long seats held for an unfinished change block the next one
+	PendingTTL time.Duration
+	// Cutoff is how long before the show starts modifications close
+	Cutoff time.Duration
+}
+
+// bookingModificationService implements BookingModificationService
+type bookingModificationService struct {
+	bookingRepo     repository.BookingRepository
+	reservationRepo repository.ReservationRepository
+	payments        PaymentAdjuster
+	eventPublisher  EventPublisher
+	maxPerUser      int
+	pendingTTL      time.Duration
+	cutoff          time.Duration
+}
+
+// NewBookingModificationService creates a new booking modification service.
+// Without a payment adjuster only unpaid reservations and price-neutral changes are allowed.
+func NewBookingModificationService(
+	bookingRepo repository.BookingRepository,
+	reservationRepo repository.ReservationRepository,
+	payments PaymentAdjuster,
+	eventPublisher EventPublisher,
+	cfg *BookingModificationConfig,
+) BookingModificationService {
+	s := &bookingModificationService{
+		bookingRepo:     bookingRepo,
+		reservationRepo: reservationRepo,
+		payments:        payments,
+		eventPublisher:  eventPublisher,
+		maxPerUser:      10,
+		pendingTTL:      time.Minute,
+	}
+	if cfg != nil {
+		if cfg.MaxPerUser > 0 {
+			s.maxPerUser = cfg.MaxPerUser
+		}
+		if cfg.PendingTTL > 0 {
+			s.pendingTTL = cfg.PendingTTL
+		}
+		s.cutoff = cfg.Cutoff
+	}
+	if s.eventPublisher == nil {
+		s.eventPublisher = NewNoOpEventPublisher()
+	}
+	return s
+}
+
+// ModifyBooking moves a booking to another zone or quantity.
+// New seats are held first, the price difference of a paid booking is charged or refunded,
+// and only then are the old seats returned; a failed payment step returns the held seats.
+func (s *bookingModificationService) ModifyBooking(ctx context.Context, bookingID, userID string, req *dto.ModifyBookingRequest) (*dto.ModifyBookingResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.booking.modify")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("booking_id", bookingID),
+		attribute.String("user_id", userID),
+	)
+
+	if bookingID == "" {
+		span.SetStatus(codes.Error, "invalid booking_id")
+		return nil, domain.ErrInvalidBookingID
+	}
+	if userID == "" {
+		span.SetStatus(codes.Error, "invalid user_id")
+		return nil, domain.ErrInvalidUserID
+	}
+	if req == nil {
+		span.SetStatus(codes.Error, "no modification")
+		return nil, domain.ErrNoModification
+	}
+
+	booking, err := s.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if !booking.BelongsToUser(userID) {
+		span.SetStatus(codes.Error, "invalid user")
+		return nil, domain.ErrInvalidUserID
+	}
+
+	previous := *booking
+	zoneID, quantity, unitPrice := s.target(booking, req)
+	if err := booking.Modify(zoneID, quantity, unitPrice); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.String("zone_id", booking.ZoneID),
+		attribute.Int("quantity", booking.Quantity),
+		attribute.String("previous_zone_id", previous.ZoneID),
+		attribute.Int("previous_quantity", previous.Quantity),
+	)
+
+	if booking.ShowID != "" {
+		startsAt, err := s.bookingRepo.GetShowStartTime(ctx, booking.ShowID)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		if !time.Now().Add(s.cutoff).Before(startsAt) {
+			span.SetStatus(codes.Error, "show started")
+			return nil, domain.ErrShowStarted
+		}
+	}
+
+	difference := booking.TotalPrice - previous.TotalPrice
+	paid := booking.Status == domain.BookingStatusConfirmed && booking.PaymentID != "" && difference != 0
+	if paid && s.payments == nil {
+		span.SetStatus(codes.Error, "payment adjustment unavailable")
+		return nil, domain.ErrPaymentAdjustmentFailed
+	}
+
+	// 1. Hold the seats the new zone or quantity needs
+	if err := s.modifyReservation(ctx, repository.ModifyParams{
+		Phase:      repository.ModifyHold,
+		BookingID:  booking.ID,
+		UserID:     userID,
+		ZoneID:     booking.ZoneID,
+		Quantity:   booking.Quantity,
+		UnitPrice:  booking.UnitPrice,
+		MaxPerUser: s.maxPerUser,
+		PendingTTL: int(s.pendingTTL.Seconds()),
+	}); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	// 2. Settle the price difference. The adjustment ID is derived from the state being
+	// changed, so a retried request is not charged twice.
+	adjustment := AdjustmentNone
+	if paid {
+		adjustmentID := fmt.Sprintf("modify:%s:%d", booking.ID, previous.UpdatedAt.UnixNano())
+		if err := s.payments.AdjustPayment(ctx, booking.PaymentID, adjustmentID, difference, "booking_modified"); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			s.abortModification(ctx, booking.ID, userID)
+			return nil, fmt.Errorf("%w: %v", domain.ErrPaymentAdjustmentFailed, err)
+		}
+		adjustment = AdjustmentCharged
+		if difference < 0 {
+			adjustment = AdjustmentRefunded
+		}
+	}
+
+	// 3. Return the old seats and apply the change
+	if err := s.modifyReservation(ctx, repository.ModifyParams{
+		Phase:     repository.ModifyCommit,
+		BookingID: booking.ID,
+		UserID:    userID,
+	}); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	booking.UpdatedAt = time.Now()
+	if err := s.bookingRepo.Update(ctx, booking); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if err := s.eventPublisher.PublishBookingModified(ctx, booking, &previous); err != nil {
+		span.RecordError(err)
+		logger.Get().Warn(fmt.Sprintf("Failed to publish booking.modified for %s: %v", booking.ID, err))
+	}
+
+	span.SetAttributes(
+		attribute.Float64("price_difference", difference),
+		attribute.String("adjustment", adjustment),
+	)
+	span.SetStatus(codes.Ok, "")
+	return &dto.ModifyBookingResponse{
+		Booking:            dto.FromDomain(booking),
+		PreviousZoneID:     previous.ZoneID,
+		PreviousQuantity:   previous.Quantity,
+		PreviousTotalPrice: previous.TotalPrice,
+		PriceDifference:    difference,
+		Adjustment:         adjustment,
+	}, nil
+}
+
+// target fills the fields the request leaves out from the booking. The same zone keeps its
+// price unless a new one is given; another zone falls back to the default reservation price.
+func (s *bookingModificationService) target(booking *domain.Booking, req *dto.ModifyBookingRequest) (string, int, float64) {
+	zoneID := booking.ZoneID
+	if req.ZoneID != "" {
+		zoneID = req.ZoneID
+	}
+	quantity := booking.Quantity
+	if req.Quantity > 0 {
+		quantity = req.Quantity
+	}
+
+	unitPrice := req.UnitPrice
+	if unitPrice <= 0 {
+		if zoneID == booking.ZoneID {
+			unitPrice = booking.UnitPrice
+		} else {
+			unitPrice = 100.00 // Default price for testing, as in ReserveSeats
+		}
+	}
+	return zoneID, quantity, unitPrice
+}
+
+// modifyReservation runs one phase of the reservation change and maps script errors
+func (s *bookingModificationService) modifyReservation(ctx context.Context, params repository.ModifyParams) error {
+	result, err := s.reservationRepo.ModifyReservation(ctx, params)
+	if err != nil {
+		return err
+	}
+	if result.Success {
+		return nil
+	}
+
+	switch result.ErrorCode {
+	case "INSUFFICIENT_STOCK":
+		return domain.ErrInsufficientSeats
+	case "USER_LIMIT_EXCEEDED":
+		return domain.ErrMaxTicketsExceeded
+	case "ZONE_NOT_FOUND":
+		return domain.ErrZoneNotFound
+	case "RESERVATION_NOT_FOUND":
+		return domain.ErrReservationNotFound
+	case "INVALID_USER":
+		return domain.ErrInvalidUserID
+	case "INVALID_QUANTITY":
+		return domain.ErrInvalidQuantity
+	case "ASSIGNED_SEATS":
+		return domain.ErrAssignedSeatsNotModified
+	case "MODIFICATION_IN_PROGRESS":
+		return domain.ErrModificationInProgress
+	case "INVALID_STATUS":
+		return domain.ErrModificationNotAllowed
+	default:
+		return fmt.Errorf("reservation change failed: %s: %s", result.ErrorCode, result.ErrorMessage)
+	}
+}
+
+// abortModification returns the seats held for a change that could not be paid for.
+// A failure is only logged: the next change to the booking aborts the stale hold.
+func (s *bookingModificationService) abortModification(ctx context.Context, bookingID, userID string) {
+	if err := s.modifyReservation(ctx, repository.ModifyParams{
+		Phase:     repository.ModifyAbort,
+		BookingID: bookingID,
+		UserID:    userID,
+	}); err != nil {
+		logger.Get().Warn(fmt.Sprintf("Failed to abort modification of %s: %v", bookingID, err))
+	}
+}
+
+// HTTPPaymentAdjuster adjusts payments via payment-service's internal API
+type HTTPPaymentAdjuster struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewHTTPPaymentAdjuster creates a new HTTP payment adjuster
+func NewHTTPPaymentAdjuster(paymentServiceURL string) *HTTPPaymentAdjuster {
+	return &HTTPPaymentAdjuster{
+		baseURL: strings.TrimSuffix(paymentServiceURL, "/"),
+		httpClient: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+	}
+}
+
+// AdjustPayment calls POST /internal/payments/:id/adjust
+func (a *HTTPPaymentAdjuster) AdjustPayment(ctx context.Context, paymentID, adjustmentID string, amount float64, reason string) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"adjustment_id": adjustmentID,
+		"amount":        amount,
+		"reason":        reason,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode adjustment: %w", err)
+	}
+
+	endpoint := a.baseURL + "/internal/payments/" + url.PathEscape(paymentID) + "/adjust"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to adjust payment: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp struct {
+			Error struct {
+				Code    string `json:"code"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&errResp)
+		return fmt.Errorf("payment adjustment rejected: status %d %s %s", resp.StatusCode, errResp.Error.Code, errResp.Error.Message)
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+)
+
+// MockPaymentAdjuster is a mock implementation of PaymentAdjuster
+type MockPaymentAdjuster struct {
+	Amounts []float64
+	Err     error
+}
+
+func (m *MockPaymentAdjuster) AdjustPayment(ctx context.Context, paymentID, adjustmentID string, amount float64, reason string) error {
+	if m.Err != nil {
+		return m.Err
+	}
+	m.Amounts = append(m.Amounts, amount)
+	return nil
+}
+
+func newModifiableBooking(status domain.BookingStatus) *domain.Booking {
+	now := time.Now()
+	return &domain.Booking{
+		ID:         "booking-1",
+		UserID:     "user-1",
+		EventID:    "event-1",
+		ShowID:     "show-1",
+		ZoneID:     "zone-a",
+		Quantity:   2,
+		UnitPrice:  500,
+		TotalPrice: 1000,
+		Status:     status,
+		PaymentID:  "payment-1",
+		ReservedAt: now,
+		ExpiresAt:  now.Add(10 * time.Minute),
+		UpdatedAt:  now,
+	}
+}
+
+func TestBookingModificationService_ModifyBooking(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         domain.BookingStatus
+		req            *dto.ModifyBookingRequest
+		wantTotal      float64
+		wantAdjustment string
+		wantAmounts    int
+	}{
+		{
+			name:           "upgrade of confirmed booking is charged",
+			status:         domain.BookingStatusConfirmed,
+			req:            &dto.ModifyBookingRequest{ZoneID: "zone-vip", UnitPrice: 800},
+			wantTotal:      1600,
+			wantAdjustment: AdjustmentCharged,
+			wantAmounts:    1,
+		},
+		{
+			name:           "fewer seats on confirmed booking are refunded",
+			status:         domain.BookingStatusConfirmed,
+			req:            &dto.ModifyBookingRequest{Quantity: 1},
+			wantTotal:      500,
+			wantAdjustment: AdjustmentRefunded,
+			wantAmounts:    1,
+		},
+		{
+			name:           "unpaid reservation is only repriced",
+			status:         domain.BookingStatusReserved,
+			req:            &dto.ModifyBookingRequest{Quantity: 4},
+			wantTotal:      2000,
+			wantAdjustment: AdjustmentNone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			booking := newModifiableBooking(tt.status)
+			var updated *domain.Booking
+			bookingRepo := &MockBookingRepository{
+				GetByIDFunc: func(ctx context.Context, id string) (*domain.Booking, error) { return booking, nil },
+				UpdateFunc: func(ctx context.Context, b *domain.Booking) error {
+					updated = b
+					return nil
+				},
+			}
+			var phases []repository.ModifyPhase
+			reservationRepo := &MockReservationRepository{
+				ModifyReservationFunc: func(ctx context.Context, params repository.ModifyParams) (*repository.ModifyResult, error) {
+					phases = append(phases, params.Phase)
+					return &repository.ModifyResult{Success: true}, nil
+				},
+			}
+			adjuster := &MockPaymentAdjuster{}
+			publisher := NewMockEventPublisher()
+			svc := NewBookingModificationService(bookingRepo, reservationRepo, adjuster, publisher, nil)
+
+			resp, err := svc.ModifyBooking(context.Background(), "booking-1", "user-1", tt.req)
+			if err != nil {
+				t.Fatalf("ModifyBooking() error = %v", err)
+			}
+			if resp.Booking.TotalPrice != tt.wantTotal || resp.PriceDifference != tt.wantTotal-1000 {
+				t.Errorf("ModifyBooking() total = %.2f diff = %.2f, want %.2f", resp.Booking.TotalPrice, resp.PriceDifference, tt.wantTotal)
+			}
+			if resp.Adjustment != tt.wantAdjustment || len(adjuster.Amounts) != tt.wantAmounts {
+				t.Errorf("ModifyBooking() adjustment = %s (%d calls), want %s", resp.Adjustment, len(adjuster.Amounts), tt.wantAdjustment)
+			}
+			if len(phases) != 2 || phases[0] != repository.ModifyHold || phases[1] != repository.ModifyCommit {
+				t.Errorf("ModifyReservation phases = %v, want hold then commit", phases)
+			}
+			if updated == nil || len(publisher.GetModifiedEvents()) != 1 {
+				t.Error("Expected the booking to be saved and booking.modified published")
+			}
+		})
+	}
+}
+
+func TestBookingModificationService_ModifyBookingPaymentFailure(t *testing.T) {
+	booking := newModifiableBooking(domain.BookingStatusConfirmed)
+	bookingRepo := &MockBookingRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Booking, error) { return booking, nil },
+		UpdateFunc: func(ctx context.Context, b *domain.Booking) error {
+			t.Error("Update should not be called when the payment fails")
+			return nil
+		},
+	}
+	var phases []repository.ModifyPhase
+	reservationRepo := &MockReservationRepository{
+		ModifyReservationFunc: func(ctx context.Context, params repository.ModifyParams) (*repository.ModifyResult, error) {
+			phases = append(phases, params.Phase)
+			return &repository.ModifyResult{Success: true}, nil
+		},
+	}
+	adjuster := &MockPaymentAdjuster{Err: errors.New("card declined")}
+	svc := NewBookingModificationService(bookingRepo, reservationRepo, adjuster, nil, nil)
+
+	_, err := svc.ModifyBooking(context.Background(), "booking-1", "user-1", &dto.ModifyBookingRequest{Quantity: 3})
+	if !errors.Is(err, domain.ErrPaymentAdjustmentFailed) {
+		t.Fatalf("ModifyBooking() error = %v, want ErrPaymentAdjustmentFailed", err)
+	}
+	if len(phases) != 2 || phases[1] != repository.ModifyAbort {
+		t.Errorf("ModifyReservation phases = %v, want hold then abort", phases)
+	}
+}
+
+func TestBookingModificationService_ModifyBookingRejected(t *testing.T) {
+	tests := []struct {
+		name      string
+		booking   func() *domain.Booking
+		startsIn  time.Duration
+		result    *repository.ModifyResult
+		req       *dto.ModifyBookingRequest
+		wantError error
+	}{
+		{
+			name: "other user",
+			booking: func() *domain.Booking {
+				b := newModifiableBooking(domain.BookingStatusConfirmed)
+				b.UserID = "user-2"
+				return b
+			},
+			req:       &dto.ModifyBookingRequest{Quantity: 3},
+			wantError: domain.ErrInvalidUserID,
+		},
+		{
+			name:      "cancelled booking",
+			booking:   func() *domain.Booking { return newModifiableBooking(domain.BookingStatusCancelled) },
+			req:       &dto.ModifyBookingRequest{Quantity: 3},
+			wantError: domain.ErrModificationNotAllowed,
+		},
+		{
+			name:      "same zone and quantity",
+			booking:   func() *domain.Booking { return newModifiableBooking(domain.BookingStatusConfirmed) },
+			req:       &dto.ModifyBookingRequest{ZoneID: "zone-a", Quantity: 2},
+			wantError: domain.ErrNoModification,
+		},
+		{
+			name:      "within the cutoff",
+			booking:   func() *domain.Booking { return newModifiableBooking(domain.BookingStatusConfirmed) },
+			startsIn:  30 * time.Minute,
+			req:       &dto.ModifyBookingRequest{Quantity: 3},
+			wantError: domain.ErrShowStarted,
+		},
+		{
+			name:      "new zone sold out",
+			booking:   func() *domain.Booking { return newModifiableBooking(domain.BookingStatusConfirmed) },
+			result:    &repository.ModifyResult{ErrorCode: "INSUFFICIENT_STOCK"},
+			req:       &dto.ModifyBookingRequest{ZoneID: "zone-vip"},
+			wantError: domain.ErrInsufficientSeats,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			booking := tt.booking()
+			startsIn := tt.startsIn
+			if startsIn == 0 {
+				startsIn = 24 * time.Hour
+			}
+			bookingRepo := &MockBookingRepository{
+				GetByIDFunc: func(ctx context.Context, id string) (*domain.Booking, error) { return booking, nil },
+				GetShowStartTimeFunc: func(ctx context.Context, showID string) (time.Time, error) {
+					return time.Now().Add(startsIn), nil
+				},
+			}
+			reservationRepo := &MockReservationRepository{
+				ModifyReservationFunc: func(ctx context.Context, params repository.ModifyParams) (*repository.ModifyResult, error) {
+					if tt.result != nil {
+						return tt.result, nil
+					}
+					return &repository.ModifyResult{Success: true}, nil
+				},
+			}
+			svc := NewBookingModificationService(bookingRepo, reservationRepo, &MockPaymentAdjuster{}, nil, &BookingModificationConfig{Cutoff: time.Hour})
+
+			if _, err := svc.ModifyBooking(context.Background(), "booking-1", "user-1", tt.req); !errors.Is(err, tt.wantError) {
+				t.Errorf("ModifyBooking() error = %v, want %v", err, tt.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+)
+
+// UserLookup resolves customer emails to user IDs (auth-service)
+type UserLookup interface {
+	// LookupUserIDsByEmail returns the IDs of users with the email. An empty tenantID searches all tenants.
+	LookupUserIDsByEmail(ctx context.Context, email, tenantID string) ([]string, error)
+}
+
+// PaymentLookup finds payments for the booking search (payment-service)
+type PaymentLookup interface {
+	// ListByBookingIDs returns the payments made for the given bookings
+	ListByBookingIDs(ctx context.Context, bookingIDs []string) ([]*dto.PaymentSummary, error)
+
+	// ListByCardLastFour returns the most recent payments made with a card ending in lastFour
+	ListByCardLastFour(ctx context.Context, tenantID, lastFour string, limit int) ([]*dto.PaymentSummary, error)
+}
+
+// HTTPUserLookup looks up users via auth-service's internal API
+type HTTPUserLookup struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewHTTPUserLookup creates a new HTTP user lookup
+func NewHTTPUserLookup(authServiceURL string) *HTTPUserLookup {
+	return &HTTPUserLookup{
+		baseURL: strings.TrimSuffix(authServiceURL, "/"),
+		httpClient: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+	}
+}
+
+// LookupUserIDsByEmail calls GET /internal/users?email=&tenant_id=
+func (l *HTTPUserLookup) LookupUserIDsByEmail(ctx context.Context, email, tenantID string) ([]string, error) {
+	query := url.Values{"email": {email}}
+	if tenantID != "" {
+		query.Set("tenant_id", tenantID)
+	}
+
+	var users []struct {
+		ID string `json:"id"`
+	}
+	if err := getInternalJSON(ctx, l.httpClient, l.baseURL+"/internal/users?"+query.Encode(), &users); err != nil {
+		return nil, fmt.Errorf("failed to look up users: %w", err)
+	}
+
+	ids := make([]string, len(users))
+	for i, u := range users {
+		ids[i] = u.ID
+	}
+	return ids, nil
+}
+
+// HTTPPaymentLookup looks up payments via payment-service's internal API
+type HTTPPaymentLookup struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewHTTPPaymentLookup creates a new HTTP payment lookup
+func NewHTTPPaymentLookup(paymentServiceURL string) *HTTPPaymentLookup {
+	return &HTTPPaymentLookup{
+		baseURL: strings.TrimSuffix(paymentServiceURL, "/"),
+		httpClient: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+	}
+}
+
+// ListByBookingIDs calls GET /internal/payments?booking_ids=
+func (l *HTTPPaymentLookup) ListByBookingIDs(ctx context.Context, bookingIDs []string) ([]*dto.PaymentSummary, error) {
+	query := url.Values{"booking_ids": {strings.Join(bookingIDs, ",")}}
+
+	var payments []*dto.PaymentSummary
+	if err := getInternalJSON(ctx, l.httpClient, l.baseURL+"/internal/payments?"+query.Encode(), &payments); err != nil {
+		return nil, fmt.Errorf("failed to look up payments: %w", err)
+	}
+	return payments, nil
+}
+
+// ListByCardLastFour calls GET /internal/payments?last4=&tenant_id=&limit=
+func (l *HTTPPaymentLookup) ListByCardLastFour(ctx context.Context, tenantID, lastFour string, limit int) ([]*dto.PaymentSummary, error) {
+	query := url.Values{"last4": {lastFour}, "limit": {strconv.Itoa(limit)}}
+	if tenantID != "" {
+		query.Set("tenant_id", tenantID)
+	}
+
+	var payments []*dto.PaymentSummary
+	if err := getInternalJSON(ctx, l.httpClient, l.baseURL+"/internal/payments?"+query.Encode(), &payments); err != nil {
+		return nil, fmt.Errorf("failed to look up payments: %w", err)
+	}
+	return payments, nil
+}
+
+// getInternalJSON fetches an internal endpoint returning { success: true, data: ... } into data
+func getInternalJSON(ctx context.Context, client *http.Client, endpoint string, data interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	response := struct {
+		Success bool        `json:"success"`
+		Data    interface{} `json:"data"`
+	}{Data: data}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if !response.Success {
+		return fmt.Errorf("API returned unsuccessful response")
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// Result limits for the booking search
+const (
+	DefaultBookingSearchLimit = 20
+	MaxBookingSearchLimit     = 100
+)
+
+// BookingSearchService finds bookings for support agents
+type BookingSearchService interface {
+	// SearchBookings returns bookings matching every filter in the request, with their payments
+	SearchBookings(ctx context.Context, req *dto.BookingSearchRequest) (*dto.BookingSearchResponse, error)
+}
+
+// bookingSearchService implements BookingSearchService
+type bookingSearchService struct {
+	repo     repository.BookingSearchRepository
+	users    UserLookup    // Optional - email search is unavailable without it
+	payments PaymentLookup // Optional - last4 search and payment cross-references are unavailable without it
+}
+
+// NewBookingSearchService creates a new booking search service
+func NewBookingSearchService(repo repository.BookingSearchRepository, users UserLookup, payments PaymentLookup) BookingSearchService {
+	return &bookingSearchService{
+		repo:     repo,
+		users:    users,
+		payments: payments,
+	}
+}
+
+// SearchBookings returns bookings matching every filter in the request, with their payments.
+// Email and last4 are resolved to user and booking IDs first, so the booking query only
+// hits indexed columns.
+func (s *bookingSearchService) SearchBookings(ctx context.Context, req *dto.BookingSearchRequest) (*dto.BookingSearchResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.booking_search.search")
+	defer span.End()
+
+	email := strings.TrimSpace(req.Email)
+	lastFour := strings.TrimSpace(req.LastFour)
+	filter := &repository.BookingSearchFilter{
+		TenantID:         req.TenantID,
+		ConfirmationCode: strings.TrimSpace(req.ConfirmationCode),
+		PaymentID:        strings.TrimSpace(req.PaymentID),
+		Limit:            req.Limit,
+	}
+
+	span.SetAttributes(
+		attribute.String("tenant_id", filter.TenantID),
+		attribute.Bool("by_email", email != ""),
+		attribute.Bool("by_last4", lastFour != ""),
+	)
+
+	if email == "" && lastFour == "" && filter.ConfirmationCode == "" && filter.PaymentID == "" {
+		span.SetStatus(codes.Error, "no search filter")
+		return nil, domain.ErrSearchFilterRequired
+	}
+	if lastFour != "" && !isCardLastFour(lastFour) {
+		span.SetStatus(codes.Error, "invalid last4")
+		return nil, domain.ErrInvalidCardLastFour
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultBookingSearchLimit
+	}
+	if filter.Limit > MaxBookingSearchLimit {
+		filter.Limit = MaxBookingSearchLimit
+	}
+
+	if email != "" {
+		if s.users == nil {
+			span.SetStatus(codes.Error, "user lookup not configured")
+			return nil, domain.ErrSearchLookupUnavailable
+		}
+		userIDs, err := s.users.LookupUserIDsByEmail(ctx, email, filter.TenantID)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		if len(userIDs) == 0 {
+			span.SetStatus(codes.Ok, "")
+			return emptyBookingSearchResponse(), nil
+		}
+		filter.UserIDs = userIDs
+	}
+
+	if lastFour != "" {
+		if s.payments == nil {
+			span.SetStatus(codes.Error, "payment lookup not configured")
+			return nil, domain.ErrSearchLookupUnavailable
+		}
+		cardPayments, err := s.payments.ListByCardLastFour(ctx, filter.TenantID, lastFour, MaxBookingSearchLimit)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		if len(cardPayments) == 0 {
+			span.SetStatus(codes.Ok, "")
+			return emptyBookingSearchResponse(), nil
+		}
+		filter.BookingIDs = make([]string, len(cardPayments))
+		for i, p := range cardPayments {
+			filter.BookingIDs[i] = p.BookingID
+		}
+	}
+
+	bookings, err := s.repo.Search(ctx, filter)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	response := emptyBookingSearchResponse()
+	byBooking := make(map[string]*dto.BookingSearchResult, len(bookings))
+	for _, b := range bookings {
+		result := &dto.BookingSearchResult{Booking: b, Payments: []*dto.PaymentSummary{}}
+		byBooking[b.ID] = result
+		response.Results = append(response.Results, result)
+	}
+	response.Count = len(response.Results)
+
+	// Cross-reference payments; bookings are still useful to support without them
+	if len(bookings) > 0 {
+		if s.payments == nil {
+			response.PaymentsUnavailable = true
+		} else if err := s.attachPayments(ctx, bookings, byBooking); err != nil {
+			span.RecordError(err)
+			logger.Get().Warn(fmt.Sprintf("Booking search returned without payments: %v", err))
+			response.PaymentsUnavailable = true
+		}
+	}
+
+	span.SetAttributes(attribute.Int("count", response.Count))
+	span.SetStatus(codes.Ok, "")
+	return response, nil
+}
+
+// attachPayments adds each booking's payments to its search result
+func (s *bookingSearchService) attachPayments(ctx context.Context, bookings []*domain.Booking, byBooking map[string]*dto.BookingSearchResult) error {
+	bookingIDs := make([]string, len(bookings))
+	for i, b := range bookings {
+		bookingIDs[i] = b.ID
+	}
+
+	payments, err := s.payments.ListByBookingIDs(ctx, bookingIDs)
+	if err != nil {
+		return err
+	}
+	for _, p := range payments {
+		if result, ok := byBooking[p.BookingID]; ok {
+			result.Payments = append(result.Payments, p)
+		}
+	}
+	return nil
+}
+
+// emptyBookingSearchResponse returns a response with no results
+func emptyBookingSearchResponse() *dto.BookingSearchResponse {
+	return &dto.BookingSearchResponse{Results: []*dto.BookingSearchResult{}}
+}
+
+// isCardLastFour reports whether s is exactly four digits
+func isCardLastFour(s string) bool {
+	if len(s) != 4 {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
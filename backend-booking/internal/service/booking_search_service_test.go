@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+)
+
+// MockBookingSearchRepository is a mock implementation of BookingSearchRepository
+type MockBookingSearchRepository struct {
+	Filter   *repository.BookingSearchFilter
+	Bookings []*domain.Booking
+}
+
+func (m *MockBookingSearchRepository) Search(ctx context.Context, filter *repository.BookingSearchFilter) ([]*domain.Booking, error) {
+	m.Filter = filter
+	return m.Bookings, nil
+}
+
+// MockUserLookup is a mock implementation of UserLookup
+type MockUserLookup struct {
+	UserIDs  map[string][]string // email -> user IDs
+	TenantID string
+}
+
+func (m *MockUserLookup) LookupUserIDsByEmail(ctx context.Context, email, tenantID string) ([]string, error) {
+	m.TenantID = tenantID
+	return m.UserIDs[email], nil
+}
+
+// MockPaymentLookup is a mock implementation of PaymentLookup
+type MockPaymentLookup struct {
+	Payments []*dto.PaymentSummary
+	Err      error
+}
+
+func (m *MockPaymentLookup) ListByBookingIDs(ctx context.Context, bookingIDs []string) ([]*dto.PaymentSummary, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	var result []*dto.PaymentSummary
+	for _, p := range m.Payments {
+		for _, id := range bookingIDs {
+			if p.BookingID == id {
+				result = append(result, p)
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *MockPaymentLookup) ListByCardLastFour(ctx context.Context, tenantID, lastFour string, limit int) ([]*dto.PaymentSummary, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	var result []*dto.PaymentSummary
+	for _, p := range m.Payments {
+		if p.CardLastFour == lastFour {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
+func TestBookingSearchService_SearchBookings_Validation(t *testing.T) {
+	svc := NewBookingSearchService(&MockBookingSearchRepository{}, &MockUserLookup{}, &MockPaymentLookup{})
+
+	tests := []struct {
+		name string
+		req  *dto.BookingSearchRequest
+		want error
+	}{
+		{"no filters", &dto.BookingSearchRequest{TenantID: "tenant-1"}, domain.ErrSearchFilterRequired},
+		{"blank filters", &dto.BookingSearchRequest{Email: "  ", ConfirmationCode: " "}, domain.ErrSearchFilterRequired},
+		{"short last4", &dto.BookingSearchRequest{LastFour: "424"}, domain.ErrInvalidCardLastFour},
+		{"non-digit last4", &dto.BookingSearchRequest{LastFour: "42a2"}, domain.ErrInvalidCardLastFour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := svc.SearchBookings(context.Background(), tt.req); !errors.Is(err, tt.want) {
+				t.Errorf("SearchBookings() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBookingSearchService_SearchBookings_ByEmail(t *testing.T) {
+	repo := &MockBookingSearchRepository{Bookings: []*domain.Booking{{ID: "booking-1", UserID: "user-1"}}}
+	users := &MockUserLookup{UserIDs: map[string][]string{"jane@example.com": {"user-1"}}}
+	payments := &MockPaymentLookup{Payments: []*dto.PaymentSummary{
+		{ID: "pay-1", BookingID: "booking-1", CardLastFour: "4242"},
+		{ID: "pay-2", BookingID: "booking-2", CardLastFour: "4242"},
+	}}
+	svc := NewBookingSearchService(repo, users, payments)
+
+	result, err := svc.SearchBookings(context.Background(), &dto.BookingSearchRequest{
+		TenantID: "tenant-1",
+		Email:    " jane@example.com ",
+		Limit:    500,
+	})
+	if err != nil {
+		t.Fatalf("SearchBookings() error = %v", err)
+	}
+
+	if users.TenantID != "tenant-1" {
+		t.Errorf("User lookup tenant = %q, want tenant-1", users.TenantID)
+	}
+	if len(repo.Filter.UserIDs) != 1 || repo.Filter.UserIDs[0] != "user-1" {
+		t.Errorf("Filter.UserIDs = %v, want [user-1]", repo.Filter.UserIDs)
+	}
+	if repo.Filter.Limit != MaxBookingSearchLimit {
+		t.Errorf("Filter.Limit = %d, want %d", repo.Filter.Limit, MaxBookingSearchLimit)
+	}
+	if result.Count != 1 || len(result.Results[0].Payments) != 1 || result.Results[0].Payments[0].ID != "pay-1" {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+}
+
+func TestBookingSearchService_SearchBookings_UnknownEmail(t *testing.T) {
+	repo := &MockBookingSearchRepository{}
+	svc := NewBookingSearchService(repo, &MockUserLookup{}, &MockPaymentLookup{})
+
+	result, err := svc.SearchBookings(context.Background(), &dto.BookingSearchRequest{Email: "nobody@example.com"})
+	if err != nil {
+		t.Fatalf("SearchBookings() error = %v", err)
+	}
+	if result.Count != 0 || repo.Filter != nil {
+		t.Errorf("Expected empty result without querying bookings, got %+v", result)
+	}
+}
+
+func TestBookingSearchService_SearchBookings_ByLastFour(t *testing.T) {
+	repo := &MockBookingSearchRepository{Bookings: []*domain.Booking{{ID: "booking-2"}}}
+	payments := &MockPaymentLookup{Payments: []*dto.PaymentSummary{
+		{ID: "pay-1", BookingID: "booking-1", CardLastFour: "1881"},
+		{ID: "pay-2", BookingID: "booking-2", CardLastFour: "4242"},
+	}}
+	svc := NewBookingSearchService(repo, nil, payments)
+
+	result, err := svc.SearchBookings(context.Background(), &dto.BookingSearchRequest{LastFour: "4242", ConfirmationCode: "ab12cd34"})
+	if err != nil {
+		t.Fatalf("SearchBookings() error = %v", err)
+	}
+	if len(repo.Filter.BookingIDs) != 1 || repo.Filter.BookingIDs[0] != "booking-2" {
+		t.Errorf("Filter.BookingIDs = %v, want [booking-2]", repo.Filter.BookingIDs)
+	}
+	if repo.Filter.ConfirmationCode != "ab12cd34" {
+		t.Errorf("Filter.ConfirmationCode = %q, want ab12cd34", repo.Filter.ConfirmationCode)
+	}
+	if result.Count != 1 || result.Results[0].Payments[0].ID != "pay-2" {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+}
+
+func TestBookingSearchService_SearchBookings_LookupUnavailable(t *testing.T) {
+	svc := NewBookingSearchService(&MockBookingSearchRepository{}, nil, nil)
+
+	for _, req := range []*dto.BookingSearchRequest{{Email: "jane@example.com"}, {LastFour: "4242"}} {
+		if _, err := svc.SearchBookings(context.Background(), req); !errors.Is(err, domain.ErrSearchLookupUnavailable) {
+			t.Errorf("SearchBookings(%+v) error = %v, want %v", req, err, domain.ErrSearchLookupUnavailable)
+		}
+	}
+}
+
+func TestBookingSearchService_SearchBookings_PaymentsFailure(t *testing.T) {
+	repo := &MockBookingSearchRepository{Bookings: []*domain.Booking{{ID: "booking-1", PaymentID: "pay-1"}}}
+	payments := &MockPaymentLookup{Err: errors.New("payment service unavailable")}
+	svc := NewBookingSearchService(repo, nil, payments)
+
+	result, err := svc.SearchBookings(context.Background(), &dto.BookingSearchRequest{PaymentID: "pay-1"})
+	if err != nil {
+		t.Fatalf("SearchBookings() error = %v", err)
+	}
+	if result.Count != 1 || !result.PaymentsUnavailable {
+		t.Errorf("Expected booking without payments, got %+v", result)
+	}
+}
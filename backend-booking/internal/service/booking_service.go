@@ -4,6 +4,8 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,6 +13,8 @@ import (
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/metrics"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/id"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -34,8 +38,8 @@ type BookingService interface {
 	// GetBooking retrieves a booking by ID
 	GetBooking(ctx context.Context, bookingID, userID string) (*dto.BookingResponse, error)
 
-	// GetUserBookings retrieves all bookings for a user
-	GetUserBookings(ctx context.Context, userID string, page, pageSize int) (*dto.PaginatedResponse, error)
+	// GetUserBookings retrieves a page of a user's bookings, newest first
+	GetUserBookings(ctx context.Context, userID string, page pagination.Params) (*dto.PaginatedResponse, error)
 
 	// GetUserBookingSummary retrieves user's booking summary for an event
 	GetUserBookingSummary(ctx context.Context, userID, eventID string) (*dto.UserBookingSummaryResponse, error)
@@ -45,6 +49,15 @@ type BookingService interface {
 
 	// ExpireReservations marks expired reservations as expired
 	ExpireReservations(ctx context.Context, limit int) (int, error)
+
+	// ExtendHold keeps a reservation whose payment is still processing from expiring,
+	// pushing its expiry back by the payment grace window
+	ExtendHold(ctx context.Context, bookingID, userID string) (*dto.HoldExtensionResponse, error)
+
+	// AwaitPayment hands a reservation off to a payment the customer completes at the
+	// gateway (3-D Secure, bank transfer), moving it to pending_payment and holding it
+	// for the external payment window
+	AwaitPayment(ctx context.Context, bookingID, userID, paymentID string) (*dto.HoldExtensionResponse, error)
 }
 
 // bookingService implements BookingService
@@ -53,9 +66,21 @@ type bookingService struct {
 	reservationRepo repository.ReservationRepository
 	eventPublisher  EventPublisher
 	zoneSyncer      ZoneSyncer
+	standby         StandbyService
+	seatMaps        SeatMapService
+	verification    VerificationGate
+	writeBehind     WriteBehindService
+	insertBatch     *BookingInsertBatcher
 	reservationTTL  time.Duration
 	maxPerUser      int
 	defaultCurrency string
+	queueAnalytics  QueueAnalyticsRecorder
+	zonePrices      ZoneFetcher
+	priceTiers      *ZonePricer
+	addOns          *AddOnSeller
+	graceWindow     time.Duration
+	graceMaxHold    time.Duration
+	externalWindow  time.Duration
 }
 
 // BookingServiceConfig contains configuration for booking service
@@ -63,6 +88,23 @@ type BookingServiceConfig struct {
 	ReservationTTL  time.Duration
 	MaxPerUser      int
 	DefaultCurrency string
+	// QueueAnalytics counts confirmations for queue funnels (optional)
+	QueueAnalytics QueueAnalyticsRecorder
+	// ZonePrices prices reservations at the zone's price (optional)
+	ZonePrices ZoneFetcher
+	// PriceTiers sells zone-priced reservations at the zone's scheduled price tiers (optional)
+	PriceTiers *ZonePricer
+	// AddOns sells event add-ons requested with reservations (optional)
+	AddOns *AddOnSeller
+	// PaymentGraceWindow is how far ExtendHold pushes the expiry of a hold past now
+	PaymentGraceWindow time.Duration
+	// PaymentGraceMaxHold caps how long after reserving a hold can be extended to
+	PaymentGraceMaxHold time.Duration
+	// ExternalPaymentWindow is how far AwaitPayment pushes the expiry of a hold past now,
+	// giving the customer time to finish paying at the gateway (default: 30m)
+	ExternalPaymentWindow time.Duration
+	// InsertBatcher inserts reserved bookings in batches instead of one per request (optional)
+	InsertBatcher *BookingInsertBatcher
 }
 
 // NewBookingService creates a new booking service
@@ -71,11 +113,23 @@ func NewBookingService(
 	reservationRepo repository.ReservationRepository,
 	eventPublisher EventPublisher,
 	zoneSyncer ZoneSyncer,
+	standby StandbyService,
+	seatMaps SeatMapService,
+	verification VerificationGate,
+	writeBehind WriteBehindService,
 	cfg *BookingServiceConfig,
 ) BookingService {
 	ttl := 10 * time.Minute
 	maxPerUser := 10
 	currency := "THB"
+	graceWindow := 10 * time.Minute
+	graceMaxHold := time.Hour
+	externalWindow := 30 * time.Minute
+	var queueAnalytics QueueAnalyticsRecorder
+	var zonePrices ZoneFetcher
+	var priceTiers *ZonePricer
+	var addOns *AddOnSeller
+	var insertBatch *BookingInsertBatcher
 	if cfg != nil {
 		if cfg.ReservationTTL > 0 {
 			ttl = cfg.ReservationTTL
@@ -86,6 +140,20 @@ func NewBookingService(
 		if cfg.DefaultCurrency != "" {
 			currency = cfg.DefaultCurrency
 		}
+		if cfg.PaymentGraceWindow > 0 {
+			graceWindow = cfg.PaymentGraceWindow
+		}
+		if cfg.PaymentGraceMaxHold > 0 {
+			graceMaxHold = cfg.PaymentGraceMaxHold
+		}
+		if cfg.ExternalPaymentWindow > 0 {
+			externalWindow = cfg.ExternalPaymentWindow
+		}
+		queueAnalytics = cfg.QueueAnalytics
+		zonePrices = cfg.ZonePrices
+		priceTiers = cfg.PriceTiers
+		addOns = cfg.AddOns
+		insertBatch = cfg.InsertBatcher
 	}
 	// Use NoOpEventPublisher if none provided
 	if eventPublisher == nil {
@@ -96,9 +164,21 @@ func NewBookingService(
 		reservationRepo: reservationRepo,
 		eventPublisher:  eventPublisher,
 		zoneSyncer:      zoneSyncer,
+		standby:         standby,
+		seatMaps:        seatMaps,
+		verification:    verification,
+		writeBehind:     writeBehind,
+		insertBatch:     insertBatch,
 		reservationTTL:  ttl,
 		maxPerUser:      maxPerUser,
 		defaultCurrency: currency,
+		queueAnalytics:  queueAnalytics,
+		zonePrices:      zonePrices,
+		priceTiers:      priceTiers,
+		addOns:          addOns,
+		graceWindow:     graceWindow,
+		graceMaxHold:    graceMaxHold,
+		externalWindow:  externalWindow,
 	}
 }
 
@@ -141,6 +221,14 @@ func (s *bookingService) ReserveSeats(ctx context.Context, userID string, req *d
 		attribute.Int("quantity", req.Quantity),
 	)
 
+	// Events can require a verified account, and high-risk users a recent challenge
+	if s.verification != nil {
+		if err := s.verification.Check(ctx, req.EventID, req.Verification); err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+	}
+
 	// Get tenant_id from show if not provided in request
 	tenantID := req.TenantID
 	if tenantID == "" {
@@ -151,17 +239,28 @@ func (s *bookingService) ReserveSeats(ctx context.Context, userID string, req *d
 		}
 	}
 
-	// Check idempotency key if provided
-	if req.IdempotencyKey != "" {
+	// A stored booking answers a retry only if it was made by this user and tenant
+	// for the same request
+	retry := &domain.Booking{
+		UserID:   userID,
+		TenantID: tenantID,
+		EventID:  req.EventID,
+		ShowID:   req.ShowID,
+		ZoneID:   req.ZoneID,
+		Quantity: req.Quantity,
+	}
+
+	// Check idempotency key if provided. With write-behind the reserve script answers
+	// retries from its Redis index instead, saving a PostgreSQL round trip.
+	if req.IdempotencyKey != "" && s.writeBehind == nil {
 		existingBooking, err := s.bookingRepo.GetByIdempotencyKey(ctx, req.IdempotencyKey)
 		if err == nil && existingBooking != nil {
+			if !existingBooking.SameRequest(retry) {
+				span.SetStatus(codes.Error, "idempotency key reused")
+				return nil, domain.ErrIdempotencyKeyReused
+			}
 			// Return existing booking for idempotent request
-			return &dto.ReserveSeatsResponse{
-				BookingID:  existingBooking.ID,
-				Status:     string(existingBooking.Status),
-				ExpiresAt:  existingBooking.ExpiresAt,
-				TotalPrice: existingBooking.TotalPrice,
-			}, nil
+			return toReserveSeatsResponse(existingBooking), nil
 		}
 		// If error is not ErrBookingNotFound, it's a real error
 		if err != nil && err != domain.ErrBookingNotFound {
@@ -169,35 +268,128 @@ func (s *bookingService) ReserveSeats(ctx context.Context, userID string, req *d
 		}
 	}
 
-	// Get unit price from zone (TODO: integrate with zone service)
-	unitPrice := req.UnitPrice
-	if unitPrice <= 0 {
+	// Price from the zone; the ticket service's price is authoritative
+	var unitPrice float64
+	if s.zonePrices != nil {
+		zone, err := s.zonePrices.FetchZone(ctx, req.ZoneID)
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, fmt.Errorf("failed to price zone %s: %w", req.ZoneID, err)
+		}
+		unitPrice = zone.Price
+	}
+
+	// A zone with a price schedule sells at its current tier; the tier's seats are
+	// claimed under the booking ID up front and given back if no reservation is made
+	var bookingID string
+	var tier *PriceTier
+	reserved := false
+	if s.priceTiers != nil {
+		bookingID = id.New()
+		claimed, err := s.priceTiers.Claim(ctx, req.ZoneID, bookingID, req.Quantity)
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, fmt.Errorf("failed to price zone %s: %w", req.ZoneID, err)
+		}
+		if claimed != nil {
+			tier = claimed
+			unitPrice = tier.Price
+			span.SetAttributes(attribute.String("price_tier", tier.Name))
+			defer func() {
+				if reserved {
+					return
+				}
+				if err := s.priceTiers.Release(ctx, req.ZoneID, bookingID); err != nil {
+					span.RecordError(err)
+				}
+			}()
+		}
+	}
+	if unitPrice <= 0 && tier == nil {
 		unitPrice = 100.00 // Default price for testing
 	}
 	totalPrice := unitPrice * float64(req.Quantity)
 
+	// Add-ons are claimed from their own inventory under the same booking ID and
+	// given back the same way
+	var addOns []domain.BookingAddOn
+	if len(req.AddOns) > 0 {
+		if s.addOns == nil {
+			span.SetStatus(codes.Error, "add-ons not available")
+			return nil, domain.ErrAddOnNotFound
+		}
+		if bookingID == "" {
+			bookingID = id.New()
+		}
+		claimed, err := s.addOns.Claim(ctx, req.EventID, bookingID, req.AddOns)
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		addOns = claimed
+		defer func() {
+			if reserved {
+				return
+			}
+			if err := s.addOns.Release(ctx, req.EventID, bookingID); err != nil {
+				span.RecordError(err)
+			}
+		}()
+		for _, addOn := range addOns {
+			totalPrice += addOn.TotalPrice
+		}
+		span.SetAttributes(attribute.Int("add_ons", len(addOns)))
+	}
+
 	// Reserve seats in Redis atomically
 	params := repository.ReserveParams{
+		BookingID:  bookingID,
 		ZoneID:     req.ZoneID,
 		UserID:     userID,
 		EventID:    req.EventID,
+		ShowID:     req.ShowID,
 		Quantity:   req.Quantity,
 		MaxPerUser: s.maxPerUser,
 		TTLSeconds: int(s.reservationTTL.Seconds()),
 		Price:      unitPrice,
 	}
+	if s.writeBehind != nil {
+		params.IdempotencyKey = req.IdempotencyKey
+		params.IdempotencyTTLSeconds = int(s.writeBehind.IdempotencyTTL().Seconds())
+	}
 
-	result, err := s.reservationRepo.ReserveSeats(ctx, params)
-	if err != nil {
-		return nil, err
+	// Seats held for the user from the standby list are claimed instead of
+	// competing for general availability
+	var result *repository.ReserveResult
+	var err error
+	if s.standby != nil {
+		if result, err = s.standby.ClaimOffer(ctx, params); err != nil {
+			return nil, err
+		}
+	}
+	if result == nil {
+		result, err = s.reservationRepo.ReserveSeats(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if result.Replayed {
+		span.AddEvent("idempotent_replay", trace.WithAttributes(attribute.String("booking_id", result.BookingID)))
+		return s.replayReservation(ctx, result.BookingID, retry)
 	}
 
 	if !result.Success {
 		switch result.ErrorCode {
 		case "INSUFFICIENT_STOCK":
+			if req.JoinStandby && s.standby != nil {
+				return s.joinStandby(ctx, userID, req)
+			}
 			return nil, domain.ErrInsufficientSeats
 		case "USER_LIMIT_EXCEEDED":
 			return nil, domain.ErrMaxTicketsExceeded
+		case "OFFER_MISMATCH":
+			return nil, domain.ErrStandbyOfferMismatch
 		case "ZONE_NOT_FOUND":
 			// Auto-sync zone from ticket service and retry once
 			if s.zoneSyncer != nil {
@@ -207,6 +399,9 @@ func (s *bookingService) ReserveSeats(ctx context.Context, userID string, req *d
 					if retryErr != nil {
 						return nil, retryErr
 					}
+					if retryResult.Replayed {
+						return s.replayReservation(ctx, retryResult.BookingID, retry)
+					}
 					if retryResult.Success {
 						result = retryResult
 						// Continue to create booking record below
@@ -232,6 +427,30 @@ func (s *bookingService) ReserveSeats(ctx context.Context, userID string, req *d
 	}
 
 createBooking:
+	// From here on releasing the reservation gives back its price tier and add-on claims
+	reserved = true
+
+	// Zones with a seat map get the best available seats assigned to the reservation
+	var seatLabels []string
+	if s.seatMaps != nil {
+		seats, err := s.seatMaps.AllocateSeats(ctx, req.ZoneID, result.BookingID, req.Quantity, req.SeatPreference)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			// Return the held quantity instead of keeping it until the TTL
+			s.abandonReservation(ctx, result.BookingID, userID, req.IdempotencyKey)
+			return nil, err
+		}
+		if len(seats) > 0 {
+			seatLabels = domain.SeatLabels(seats)
+		}
+	}
+
+	// The zone or event may configure its own hold; the script reports the one applied
+	holdTTL := s.reservationTTL
+	if result.TTLSeconds > 0 {
+		holdTTL = time.Duration(result.TTLSeconds) * time.Second
+	}
 
 	// Create booking record in PostgreSQL
 	now := time.Now()
@@ -248,13 +467,45 @@ createBooking:
 		Currency:       s.defaultCurrency,
 		Status:         domain.BookingStatusReserved,
 		IdempotencyKey: req.IdempotencyKey,
+		SeatLabels:     seatLabels,
+		AddOns:         addOns,
 		ReservedAt:     now,
-		ExpiresAt:      now.Add(s.reservationTTL),
+		ExpiresAt:      now.Add(holdTTL),
 		CreatedAt:      now,
 		UpdatedAt:      now,
 	}
 
-	if err := s.bookingRepo.Create(ctx, booking); err != nil {
+	if s.writeBehind != nil {
+		// Persisted by the write-behind worker, which also publishes booking.created
+		if err := s.writeBehind.Enqueue(ctx, booking); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			s.abandonReservation(ctx, result.BookingID, userID, req.IdempotencyKey)
+			return nil, err
+		}
+	} else if err := s.insertBooking(ctx, booking); err != nil {
+		if errors.Is(err, domain.ErrBookingAlreadyExists) {
+			// A concurrent request with the same idempotency key won the insert;
+			// booking now holds its row. Return our duplicate hold and answer
+			// with the stored booking.
+			span.AddEvent("idempotent_replay", trace.WithAttributes(
+				attribute.String("booking_id", booking.ID),
+				attribute.String("duplicate_booking_id", result.BookingID),
+			))
+			if _, releaseErr := s.reservationRepo.ReleaseSeats(ctx, result.BookingID, userID); releaseErr != nil {
+				span.RecordError(releaseErr)
+			}
+			span.SetStatus(codes.Ok, "")
+			return toReserveSeatsResponse(booking), nil
+		}
+		if errors.Is(err, domain.ErrIdempotencyKeyReused) {
+			// Another request won the insert with the same key; this hold is not its retry
+			if _, releaseErr := s.reservationRepo.ReleaseSeats(ctx, result.BookingID, userID); releaseErr != nil {
+				span.RecordError(releaseErr)
+			}
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
 		// If PostgreSQL insert fails, we should release Redis reservation
 		// But for now, let Redis TTL handle cleanup
 		span.RecordError(err)
@@ -263,7 +514,9 @@ createBooking:
 	}
 
 	// Publish booking created event (ProduceAsync is non-blocking, no need for extra goroutine)
-	_ = s.eventPublisher.PublishBookingCreated(ctx, booking)
+	if s.writeBehind == nil {
+		_ = s.eventPublisher.PublishBookingCreated(ctx, booking)
+	}
 
 	// Record metrics
 	metrics.RecordReservation(ctx, booking.EventID, userID, booking.ZoneID, booking.Quantity)
@@ -280,11 +533,79 @@ createBooking:
 
 	span.SetAttributes(attribute.String("booking_id", booking.ID))
 	span.SetStatus(codes.Ok, "")
+	resp := toReserveSeatsResponse(booking)
+	if tier != nil {
+		resp.PriceTier = tier.Name
+	}
+	return resp, nil
+}
+
+// insertBooking stores a reserved booking, batched with concurrent reservations when enabled
+func (s *bookingService) insertBooking(ctx context.Context, booking *domain.Booking) error {
+	if s.insertBatch != nil {
+		return s.insertBatch.Create(ctx, booking)
+	}
+	return s.bookingRepo.Create(ctx, booking)
+}
+
+// replayReservation answers a retried reservation with the booking its idempotency key reserved
+func (s *bookingService) replayReservation(ctx context.Context, bookingID string, retry *domain.Booking) (*dto.ReserveSeatsResponse, error) {
+	booking, err := s.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if !booking.SameRequest(retry) {
+		return nil, domain.ErrIdempotencyKeyReused
+	}
+	return toReserveSeatsResponse(booking), nil
+}
+
+// abandonReservation returns a hold that will not become a booking instead of keeping
+// it until the TTL, and lets a retry of the same idempotency key reserve again
+func (s *bookingService) abandonReservation(ctx context.Context, bookingID, userID, idempotencyKey string) {
+	span := trace.SpanFromContext(ctx)
+	if _, err := s.reservationRepo.ReleaseSeats(ctx, bookingID, userID); err != nil {
+		span.RecordError(err)
+	}
+	if s.writeBehind != nil {
+		if err := s.writeBehind.ForgetIdempotencyKey(ctx, idempotencyKey, bookingID); err != nil {
+			span.RecordError(err)
+		}
+	}
+}
+
+// toReserveSeatsResponse converts a reserved booking to the reserve response
+func toReserveSeatsResponse(booking *domain.Booking) *dto.ReserveSeatsResponse {
 	return &dto.ReserveSeatsResponse{
 		BookingID:  booking.ID,
 		Status:     string(booking.Status),
 		ExpiresAt:  booking.ExpiresAt,
 		TotalPrice: booking.TotalPrice,
+		Seats:      booking.SeatLabels,
+		AddOns:     booking.AddOns,
+	}
+}
+
+// joinStandby puts the user on the zone standby list after a sold-out reservation attempt
+func (s *bookingService) joinStandby(ctx context.Context, userID string, req *dto.ReserveSeatsRequest) (*dto.ReserveSeatsResponse, error) {
+	status, err := s.standby.JoinStandby(ctx, userID, &dto.JoinStandbyRequest{
+		EventID:        req.EventID,
+		ZoneID:         req.ZoneID,
+		ShowID:         req.ShowID,
+		Quantity:       req.Quantity,
+		NotifyChannels: req.StandbyNotify,
+	})
+	// Retried requests report the existing standby entry
+	if errors.Is(err, domain.ErrAlreadyOnStandby) {
+		status, err = s.standby.GetStandbyStatus(ctx, userID, req.ZoneID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.ReserveSeatsResponse{
+		Status:  "standby",
+		Standby: status,
 	}, nil
 }
 
@@ -336,6 +657,14 @@ func (s *bookingService) ConfirmBooking(ctx context.Context, bookingID, userID s
 		return nil, domain.ErrBookingExpired
 	}
 
+	// Checked again so a challenge that lapsed or a risk level raised since the hold still applies
+	if s.verification != nil && req != nil && req.Verification != nil {
+		if err := s.verification.Check(ctx, booking.EventID, req.Verification); err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+	}
+
 	paymentID := ""
 	if req != nil {
 		paymentID = req.PaymentID
@@ -397,6 +726,9 @@ func (s *bookingService) ConfirmBooking(ctx context.Context, bookingID, userID s
 	// Record metrics
 	durationSeconds := now.Sub(booking.ReservedAt).Seconds()
 	metrics.RecordConfirmation(ctx, booking.EventID, userID, durationSeconds)
+	if s.queueAnalytics != nil {
+		s.queueAnalytics.RecordStep(ctx, booking.EventID, domain.QueueStepConfirmed, 1)
+	}
 
 	// Add span event for booking confirmed
 	span.AddEvent("booking_confirmed", trace.WithAttributes(
@@ -415,6 +747,218 @@ func (s *bookingService) ConfirmBooking(ctx context.Context, bookingID, userID s
 	}, nil
 }
 
+// ExtendHold pushes the expiry of a reservation back to the payment grace window from now,
+// capped at the maximum hold after reserving. Holds are never shortened, so callers can
+// retry it, and confirmed bookings are returned as they are.
+func (s *bookingService) ExtendHold(ctx context.Context, bookingID, userID string) (*dto.HoldExtensionResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.booking.extend_hold")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("booking_id", bookingID),
+		attribute.String("user_id", userID),
+	)
+
+	if bookingID == "" {
+		span.SetStatus(codes.Error, "invalid booking_id")
+		return nil, domain.ErrInvalidBookingID
+	}
+	if userID == "" {
+		span.SetStatus(codes.Error, "invalid user_id")
+		return nil, domain.ErrInvalidUserID
+	}
+
+	booking, err := s.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if !booking.BelongsToUser(userID) {
+		span.SetStatus(codes.Error, "invalid user")
+		return nil, domain.ErrInvalidUserID
+	}
+
+	unchanged := &dto.HoldExtensionResponse{
+		BookingID: bookingID,
+		Status:    booking.Status.String(),
+		ExpiresAt: booking.ExpiresAt,
+	}
+	if booking.IsConfirmed() {
+		span.SetStatus(codes.Ok, "already confirmed")
+		return unchanged, nil
+	}
+	if booking.IsCancelled() {
+		span.SetStatus(codes.Error, "already released")
+		return nil, domain.ErrAlreadyReleased
+	}
+	if !booking.IsAwaitingPayment() || booking.IsExpired() {
+		span.SetStatus(codes.Error, "booking expired")
+		return nil, domain.ErrBookingExpired
+	}
+
+	until := s.holdLimit(booking, s.graceWindow)
+	if !until.After(booking.ExpiresAt) {
+		span.SetStatus(codes.Ok, "max hold reached")
+		return unchanged, nil
+	}
+
+	result, err := s.extendRedisHold(ctx, booking, until)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if err := s.bookingRepo.ExtendReservation(ctx, bookingID, result.ExpiresAt); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.Bool("extended", result.Extended),
+		attribute.String("expires_at", result.ExpiresAt.Format(time.RFC3339)),
+	)
+	span.SetStatus(codes.Ok, "")
+	return &dto.HoldExtensionResponse{
+		BookingID: bookingID,
+		Status:    booking.Status.String(),
+		ExpiresAt: result.ExpiresAt,
+		Extended:  result.Extended,
+	}, nil
+}
+
+// AwaitPayment hands a reservation off to a payment the customer completes at the gateway
+func (s *bookingService) AwaitPayment(ctx context.Context, bookingID, userID, paymentID string) (*dto.HoldExtensionResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.booking.await_payment")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("booking_id", bookingID),
+		attribute.String("user_id", userID),
+		attribute.String("payment_id", paymentID),
+	)
+
+	if bookingID == "" {
+		span.SetStatus(codes.Error, "invalid booking_id")
+		return nil, domain.ErrInvalidBookingID
+	}
+	if userID == "" {
+		span.SetStatus(codes.Error, "invalid user_id")
+		return nil, domain.ErrInvalidUserID
+	}
+	if paymentID == "" {
+		span.SetStatus(codes.Error, "invalid payment_id")
+		return nil, domain.ErrInvalidPaymentID
+	}
+
+	booking, err := s.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if !booking.BelongsToUser(userID) {
+		span.SetStatus(codes.Error, "invalid user")
+		return nil, domain.ErrInvalidUserID
+	}
+	if booking.IsConfirmed() {
+		// The customer finished paying before the handoff was recorded
+		span.SetStatus(codes.Ok, "already confirmed")
+		return &dto.HoldExtensionResponse{
+			BookingID: bookingID,
+			Status:    booking.Status.String(),
+			ExpiresAt: booking.ExpiresAt,
+		}, nil
+	}
+	if booking.IsCancelled() {
+		span.SetStatus(codes.Error, "already released")
+		return nil, domain.ErrAlreadyReleased
+	}
+	if err := booking.AwaitPayment(paymentID); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		if errors.Is(err, domain.ErrInvalidBookingStatus) {
+			return nil, domain.ErrBookingExpired
+		}
+		return nil, err
+	}
+
+	// The hold runs for the external payment window, still capped by the longest hold
+	expiresAt := booking.ExpiresAt
+	extended := false
+	if until := s.holdLimit(booking, s.externalWindow); until.After(booking.ExpiresAt) {
+		result, err := s.extendRedisHold(ctx, booking, until)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		expiresAt = result.ExpiresAt
+		extended = result.Extended
+	}
+
+	if err := s.bookingRepo.AwaitPayment(ctx, bookingID, paymentID, expiresAt); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if errors.Is(err, domain.ErrBookingNotFound) {
+			// Expired or cancelled since it was read
+			return nil, domain.ErrBookingExpired
+		}
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.Bool("extended", extended),
+		attribute.String("expires_at", expiresAt.Format(time.RFC3339)),
+	)
+	span.SetStatus(codes.Ok, "")
+	return &dto.HoldExtensionResponse{
+		BookingID: bookingID,
+		Status:    booking.Status.String(),
+		ExpiresAt: expiresAt,
+		Extended:  extended,
+	}, nil
+}
+
+// holdLimit returns how long a hold extended by window from now may run, capped at
+// PaymentGraceMaxHold after the booking was reserved
+func (s *bookingService) holdLimit(booking *domain.Booking, window time.Duration) time.Time {
+	until := time.Now().Add(window).Truncate(time.Second)
+	if limit := booking.ReservedAt.Add(s.graceMaxHold); until.After(limit) {
+		until = limit
+	}
+	return until
+}
+
+// extendRedisHold extends the booking's reservation in Redis first, so the seats are
+// still held when PostgreSQL records the new expiry
+func (s *bookingService) extendRedisHold(ctx context.Context, booking *domain.Booking, until time.Time) (*repository.ExtendHoldResult, error) {
+	result, err := s.reservationRepo.ExtendHold(ctx, repository.ExtendHoldParams{
+		BookingID: booking.ID,
+		UserID:    booking.UserID,
+		EventID:   booking.EventID,
+		Until:     until,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !result.Success {
+		switch result.ErrorCode {
+		case "RESERVATION_NOT_FOUND":
+			return nil, domain.ErrReservationNotFound
+		case "INVALID_USER_ID":
+			return nil, domain.ErrInvalidUserID
+		default:
+			return nil, domain.ErrInvalidBookingStatus
+		}
+	}
+	return result, nil
+}
+
 // CancelBooking cancels a reservation
 func (s *bookingService) CancelBooking(ctx context.Context, bookingID, userID string) (*dto.ReleaseBookingResponse, error) {
 	ctx, span := telemetry.StartSpan(ctx, "service.booking.cancel")
@@ -488,6 +1032,13 @@ func (s *bookingService) CancelBooking(ctx context.Context, bookingID, userID st
 		return nil, err
 	}
 
+	// Freed seats are offered to the zone standby list first
+	if s.standby != nil && releaseResult.Success {
+		go func(zoneID string) {
+			_, _ = s.standby.OfferReleasedSeats(context.Background(), zoneID)
+		}(booking.ZoneID)
+	}
+
 	// Update booking object for event publishing
 	booking.Status = domain.BookingStatusCancelled
 	now := time.Now()
@@ -561,15 +1112,16 @@ func (s *bookingService) GetBooking(ctx context.Context, bookingID, userID strin
 	return dto.FromDomain(booking), nil
 }
 
-// GetUserBookings retrieves all bookings for a user
-func (s *bookingService) GetUserBookings(ctx context.Context, userID string, page, pageSize int) (*dto.PaginatedResponse, error) {
+// GetUserBookings retrieves a page of a user's bookings, newest first
+func (s *bookingService) GetUserBookings(ctx context.Context, userID string, page pagination.Params) (*dto.PaginatedResponse, error) {
 	ctx, span := telemetry.StartSpan(ctx, "service.booking.list_user")
 	defer span.End()
 
 	span.SetAttributes(
 		attribute.String("user_id", userID),
-		attribute.Int("page", page),
-		attribute.Int("page_size", pageSize),
+		attribute.Int("limit", page.Limit),
+		attribute.Int("offset", page.Offset),
+		attribute.Bool("cursor", page.After != nil),
 	)
 
 	// Validate input
@@ -578,25 +1130,20 @@ func (s *bookingService) GetUserBookings(ctx context.Context, userID string, pag
 		return nil, domain.ErrInvalidUserID
 	}
 
-	if page < 1 {
-		page = 1
-	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 20
+	if page.Limit < 1 || page.Limit > pagination.MaxLimit {
+		page.Limit = pagination.DefaultLimit
 	}
 
-	offset := (page - 1) * pageSize
-	bookings, err := s.bookingRepo.GetByUserID(ctx, userID, pageSize+1, offset) // Fetch one extra to check if there are more
+	bookings, err := s.bookingRepo.GetByUserID(ctx, userID, page.Lookahead()) // Fetch one extra to check if there are more
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 		return nil, err
 	}
 
-	hasMore := len(bookings) > pageSize
-	if hasMore {
-		bookings = bookings[:pageSize]
-	}
+	bookings, info := pagination.Paginate(bookings, page.Limit, func(b *domain.Booking) pagination.Cursor {
+		return pagination.Cursor{Time: b.CreatedAt, ID: b.ID}
+	})
 
 	responses := make([]*dto.BookingResponse, len(bookings))
 	for i, b := range bookings {
@@ -607,8 +1154,9 @@ func (s *bookingService) GetUserBookings(ctx context.Context, userID string, pag
 	span.SetStatus(codes.Ok, "")
 	return &dto.PaginatedResponse{
 		Data:     responses,
-		Page:     page,
-		PageSize: pageSize,
+		Page:     page.Offset/page.Limit + 1,
+		PageSize: page.Limit,
+		Info:     info,
 	}, nil
 }
 
@@ -9,13 +9,14 @@ import (
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 )
 
 // MockBookingRepository is a mock implementation of BookingRepository
 type MockBookingRepository struct {
 	CreateFunc                 func(ctx context.Context, booking *domain.Booking) error
 	GetByIDFunc                func(ctx context.Context, id string) (*domain.Booking, error)
-	GetByUserIDFunc            func(ctx context.Context, userID string, limit, offset int) ([]*domain.Booking, error)
+	GetByUserIDFunc            func(ctx context.Context, userID string, page pagination.Params) ([]*domain.Booking, error)
 	UpdateFunc                 func(ctx context.Context, booking *domain.Booking) error
 	UpdateStatusFunc           func(ctx context.Context, id string, status domain.BookingStatus) error
 	DeleteFunc                 func(ctx context.Context, id string) error
@@ -23,9 +24,12 @@ type MockBookingRepository struct {
 	CancelFunc                 func(ctx context.Context, id string) error
 	GetExpiredReservationsFunc func(ctx context.Context, limit int) ([]*domain.Booking, error)
 	MarkAsExpiredFunc          func(ctx context.Context, id string) error
+	ExtendReservationFunc      func(ctx context.Context, id string, expiresAt time.Time) error
+	AwaitPaymentFunc           func(ctx context.Context, id, paymentID string, expiresAt time.Time) error
 	GetByIdempotencyKeyFunc    func(ctx context.Context, key string) (*domain.Booking, error)
 	CountByUserAndEventFunc    func(ctx context.Context, userID, eventID string) (int, error)
 	GetTenantIDByShowIDFunc    func(ctx context.Context, showID string) (string, error)
+	GetShowStartTimeFunc       func(ctx context.Context, showID string) (time.Time, error)
 }
 
 func (m *MockBookingRepository) Create(ctx context.Context, booking *domain.Booking) error {
@@ -42,9 +46,9 @@ func (m *MockBookingRepository) GetByID(ctx context.Context, id string) (*domain
 	return nil, domain.ErrBookingNotFound
 }
 
-func (m *MockBookingRepository) GetByUserID(ctx context.Context, userID string, limit, offset int) ([]*domain.Booking, error) {
+func (m *MockBookingRepository) GetByUserID(ctx context.Context, userID string, page pagination.Params) ([]*domain.Booking, error) {
 	if m.GetByUserIDFunc != nil {
-		return m.GetByUserIDFunc(ctx, userID, limit, offset)
+		return m.GetByUserIDFunc(ctx, userID, page)
 	}
 	return []*domain.Booking{}, nil
 }
@@ -98,6 +102,20 @@ func (m *MockBookingRepository) MarkAsExpired(ctx context.Context, id string) er
 	return nil
 }
 
+func (m *MockBookingRepository) ExtendReservation(ctx context.Context, id string, expiresAt time.Time) error {
+	if m.ExtendReservationFunc != nil {
+		return m.ExtendReservationFunc(ctx, id, expiresAt)
+	}
+	return nil
+}
+
+func (m *MockBookingRepository) AwaitPayment(ctx context.Context, id, paymentID string, expiresAt time.Time) error {
+	if m.AwaitPaymentFunc != nil {
+		return m.AwaitPaymentFunc(ctx, id, paymentID, expiresAt)
+	}
+	return nil
+}
+
 func (m *MockBookingRepository) GetByIdempotencyKey(ctx context.Context, key string) (*domain.Booking, error) {
 	if m.GetByIdempotencyKeyFunc != nil {
 		return m.GetByIdempotencyKeyFunc(ctx, key)
@@ -119,11 +137,20 @@ func (m *MockBookingRepository) GetTenantIDByShowID(ctx context.Context, showID
 	return "test-tenant-id", nil
 }
 
+func (m *MockBookingRepository) GetShowStartTime(ctx context.Context, showID string) (time.Time, error) {
+	if m.GetShowStartTimeFunc != nil {
+		return m.GetShowStartTimeFunc(ctx, showID)
+	}
+	return time.Now().Add(7 * 24 * time.Hour), nil
+}
+
 // MockReservationRepository is a mock implementation of ReservationRepository
 type MockReservationRepository struct {
 	ReserveSeatsFunc        func(ctx context.Context, params repository.ReserveParams) (*repository.ReserveResult, error)
 	ConfirmBookingFunc      func(ctx context.Context, bookingID, userID, paymentID string) (*repository.ConfirmResult, error)
 	ReleaseSeatsFunc        func(ctx context.Context, bookingID, userID string) (*repository.ReleaseResult, error)
+	ExtendHoldFunc          func(ctx context.Context, params repository.ExtendHoldParams) (*repository.ExtendHoldResult, error)
+	ModifyReservationFunc   func(ctx context.Context, params repository.ModifyParams) (*repository.ModifyResult, error)
 	GetZoneAvailabilityFunc func(ctx context.Context, zoneID string) (int64, error)
 	SetZoneAvailabilityFunc func(ctx context.Context, zoneID string, seats int64) error
 }
@@ -148,6 +175,17 @@ func (m *MockReservationRepository) ConfirmBooking(ctx context.Context, bookingI
 	}, nil
 }
 
+func (m *MockReservationRepository) ExtendHold(ctx context.Context, params repository.ExtendHoldParams) (*repository.ExtendHoldResult, error) {
+	if m.ExtendHoldFunc != nil {
+		return m.ExtendHoldFunc(ctx, params)
+	}
+	return &repository.ExtendHoldResult{
+		Success:   true,
+		Extended:  true,
+		ExpiresAt: params.Until,
+	}, nil
+}
+
 func (m *MockReservationRepository) ReleaseSeats(ctx context.Context, bookingID, userID string) (*repository.ReleaseResult, error) {
 	if m.ReleaseSeatsFunc != nil {
 		return m.ReleaseSeatsFunc(ctx, bookingID, userID)
@@ -157,6 +195,15 @@ func (m *MockReservationRepository) ReleaseSeats(ctx context.Context, bookingID,
 	}, nil
 }
 
+func (m *MockReservationRepository) ModifyReservation(ctx context.Context, params repository.ModifyParams) (*repository.ModifyResult, error) {
+	if m.ModifyReservationFunc != nil {
+		return m.ModifyReservationFunc(ctx, params)
+	}
+	return &repository.ModifyResult{
+		Success: true,
+	}, nil
+}
+
 func (m *MockReservationRepository) GetZoneAvailability(ctx context.Context, zoneID string) (int64, error) {
 	if m.GetZoneAvailabilityFunc != nil {
 		return m.GetZoneAvailabilityFunc(ctx, zoneID)
@@ -184,11 +231,10 @@ func TestBookingService_ReserveSeats(t *testing.T) {
 			name:   "successful reservation",
 			userID: "user-001",
 			req: &dto.ReserveSeatsRequest{
-				EventID:   "event-001",
-				ZoneID:    "zone-001",
-				ShowID:    "show-001",
-				Quantity:  2,
-				UnitPrice: 100.00,
+				EventID:  "event-001",
+				ZoneID:   "zone-001",
+				ShowID:   "show-001",
+				Quantity: 2,
 			},
 			setupMocks: func(br *MockBookingRepository, rr *MockReservationRepository) {
 				rr.ReserveSeatsFunc = func(ctx context.Context, params repository.ReserveParams) (*repository.ReserveResult, error) {
@@ -220,6 +266,12 @@ func TestBookingService_ReserveSeats(t *testing.T) {
 				br.GetByIdempotencyKeyFunc = func(ctx context.Context, key string) (*domain.Booking, error) {
 					return &domain.Booking{
 						ID:         "existing-booking-id",
+						TenantID:   "test-tenant-id",
+						UserID:     "user-001",
+						EventID:    "event-001",
+						ShowID:     "show-001",
+						ZoneID:     "zone-001",
+						Quantity:   2,
 						Status:     domain.BookingStatusReserved,
 						TotalPrice: 200.00,
 						ExpiresAt:  time.Now().Add(10 * time.Minute),
@@ -229,6 +281,58 @@ func TestBookingService_ReserveSeats(t *testing.T) {
 			wantErr:       nil,
 			wantBookingID: true,
 		},
+		{
+			name:   "idempotency key of another user's booking",
+			userID: "user-002",
+			req: &dto.ReserveSeatsRequest{
+				EventID:        "event-001",
+				ZoneID:         "zone-001",
+				ShowID:         "show-001",
+				Quantity:       2,
+				IdempotencyKey: "idempotency-key-123",
+			},
+			setupMocks: func(br *MockBookingRepository, rr *MockReservationRepository) {
+				br.GetByIdempotencyKeyFunc = func(ctx context.Context, key string) (*domain.Booking, error) {
+					return &domain.Booking{
+						ID:       "existing-booking-id",
+						TenantID: "test-tenant-id",
+						UserID:   "user-001",
+						EventID:  "event-001",
+						ShowID:   "show-001",
+						ZoneID:   "zone-001",
+						Quantity: 2,
+						Status:   domain.BookingStatusReserved,
+					}, nil
+				}
+			},
+			wantErr: domain.ErrIdempotencyKeyReused,
+		},
+		{
+			name:   "idempotency key of another request",
+			userID: "user-001",
+			req: &dto.ReserveSeatsRequest{
+				EventID:        "event-001",
+				ZoneID:         "zone-002",
+				ShowID:         "show-001",
+				Quantity:       2,
+				IdempotencyKey: "idempotency-key-123",
+			},
+			setupMocks: func(br *MockBookingRepository, rr *MockReservationRepository) {
+				br.GetByIdempotencyKeyFunc = func(ctx context.Context, key string) (*domain.Booking, error) {
+					return &domain.Booking{
+						ID:       "existing-booking-id",
+						TenantID: "test-tenant-id",
+						UserID:   "user-001",
+						EventID:  "event-001",
+						ShowID:   "show-001",
+						ZoneID:   "zone-001",
+						Quantity: 2,
+						Status:   domain.BookingStatusReserved,
+					}, nil
+				}
+			},
+			wantErr: domain.ErrIdempotencyKeyReused,
+		},
 		{
 			name:   "insufficient seats",
 			userID: "user-001",
@@ -322,7 +426,7 @@ func TestBookingService_ReserveSeats(t *testing.T) {
 				tt.setupMocks(bookingRepo, reservationRepo)
 			}
 
-			svc := NewBookingService(bookingRepo, reservationRepo, nil, nil, &BookingServiceConfig{
+			svc := NewBookingService(bookingRepo, reservationRepo, nil, nil, nil, nil, nil, nil, &BookingServiceConfig{
 				ReservationTTL: 10 * time.Minute,
 				MaxPerUser:     10,
 			})
@@ -348,6 +452,146 @@ func TestBookingService_ReserveSeats(t *testing.T) {
 	}
 }
 
+func TestBookingService_ReserveSeats_ConcurrentIdempotentInsert(t *testing.T) {
+	existing := &domain.Booking{
+		ID:             "existing-booking-id",
+		Status:         domain.BookingStatusReserved,
+		TotalPrice:     200.00,
+		IdempotencyKey: "idempotency-key-123",
+		ExpiresAt:      time.Now().Add(10 * time.Minute),
+	}
+
+	var released string
+	bookingRepo := &MockBookingRepository{
+		// The lookup misses because the other request has not inserted yet...
+		GetByIdempotencyKeyFunc: func(ctx context.Context, key string) (*domain.Booking, error) {
+			return nil, nil
+		},
+		// ...but it wins the insert
+		CreateFunc: func(ctx context.Context, booking *domain.Booking) error {
+			*booking = *existing
+			return domain.ErrBookingAlreadyExists
+		},
+	}
+	reservationRepo := &MockReservationRepository{
+		ReserveSeatsFunc: func(ctx context.Context, params repository.ReserveParams) (*repository.ReserveResult, error) {
+			return &repository.ReserveResult{Success: true, BookingID: "duplicate-booking-id"}, nil
+		},
+		ReleaseSeatsFunc: func(ctx context.Context, bookingID, userID string) (*repository.ReleaseResult, error) {
+			released = bookingID
+			return &repository.ReleaseResult{Success: true}, nil
+		},
+	}
+
+	svc := NewBookingService(bookingRepo, reservationRepo, nil, nil, nil, nil, nil, nil, nil)
+	resp, err := svc.ReserveSeats(context.Background(), "user-001", &dto.ReserveSeatsRequest{
+		EventID:        "event-001",
+		ZoneID:         "zone-001",
+		ShowID:         "show-001",
+		Quantity:       2,
+		IdempotencyKey: "idempotency-key-123",
+	})
+	if err != nil {
+		t.Fatalf("ReserveSeats() unexpected error = %v", err)
+	}
+	if resp.BookingID != existing.ID {
+		t.Errorf("ReserveSeats() booking ID = %s, want existing %s", resp.BookingID, existing.ID)
+	}
+	if released != "duplicate-booking-id" {
+		t.Errorf("Expected the duplicate reservation to be released, got %q", released)
+	}
+}
+
+func TestBookingService_ReserveSeats_HoldTTL(t *testing.T) {
+	tests := []struct {
+		name       string
+		appliedTTL int
+		want       time.Duration
+	}{
+		{name: "service default", appliedTTL: 0, want: 10 * time.Minute},
+		{name: "organizer hold", appliedTTL: 180, want: 3 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created *domain.Booking
+			bookingRepo := &MockBookingRepository{
+				CreateFunc: func(ctx context.Context, booking *domain.Booking) error {
+					created = booking
+					return nil
+				},
+			}
+			var requestedTTL int
+			reservationRepo := &MockReservationRepository{
+				ReserveSeatsFunc: func(ctx context.Context, params repository.ReserveParams) (*repository.ReserveResult, error) {
+					requestedTTL = params.TTLSeconds
+					return &repository.ReserveResult{Success: true, BookingID: "booking-001", TTLSeconds: tt.appliedTTL}, nil
+				},
+			}
+
+			svc := NewBookingService(bookingRepo, reservationRepo, nil, nil, nil, nil, nil, nil, nil)
+			resp, err := svc.ReserveSeats(context.Background(), "user-001", &dto.ReserveSeatsRequest{
+				EventID:  "event-001",
+				ZoneID:   "zone-001",
+				ShowID:   "show-001",
+				Quantity: 1,
+			})
+			if err != nil {
+				t.Fatalf("ReserveSeats() unexpected error = %v", err)
+			}
+			if requestedTTL != 600 {
+				t.Errorf("ReserveSeats() requested TTL = %d, want the service default 600", requestedTTL)
+			}
+			if got := created.ExpiresAt.Sub(created.ReservedAt); got != tt.want {
+				t.Errorf("Booking hold = %v, want %v", got, tt.want)
+			}
+			if !resp.ExpiresAt.Equal(created.ExpiresAt) {
+				t.Errorf("Response expires_at = %v, want %v", resp.ExpiresAt, created.ExpiresAt)
+			}
+		})
+	}
+}
+
+func TestBookingService_ReserveSeats_ZonePrice(t *testing.T) {
+	catalog := &stubZoneCatalog{zones: []*ZoneInfo{{ID: "zone-001", ShowID: "show-001", Price: 2500}}}
+
+	var reservedPrice float64
+	reservationRepo := &MockReservationRepository{
+		ReserveSeatsFunc: func(ctx context.Context, params repository.ReserveParams) (*repository.ReserveResult, error) {
+			reservedPrice = params.Price
+			return &repository.ReserveResult{Success: true, BookingID: "booking-001"}, nil
+		},
+	}
+	svc := NewBookingService(&MockBookingRepository{}, reservationRepo, nil, nil, nil, nil, nil, nil, &BookingServiceConfig{
+		ZonePrices: NewZoneCatalog(catalog, catalog, nil),
+	})
+	resp, err := svc.ReserveSeats(context.Background(), "user-001", &dto.ReserveSeatsRequest{
+		EventID:  "event-001",
+		ZoneID:   "zone-001",
+		ShowID:   "show-001",
+		Quantity: 2,
+	})
+	if err != nil {
+		t.Fatalf("ReserveSeats() unexpected error = %v", err)
+	}
+	if reservedPrice != 2500 || resp.TotalPrice != 5000 {
+		t.Errorf("Reserved at %v, total %v, want 2500 and 5000", reservedPrice, resp.TotalPrice)
+	}
+
+	// A zone ticket service does not know fails instead of taking a placeholder price
+	svc = NewBookingService(&MockBookingRepository{}, &MockReservationRepository{}, nil, nil, nil, nil, nil, nil, &BookingServiceConfig{
+		ZonePrices: catalog,
+	})
+	if _, err := svc.ReserveSeats(context.Background(), "user-001", &dto.ReserveSeatsRequest{
+		EventID:  "event-001",
+		ZoneID:   "zone-404",
+		ShowID:   "show-001",
+		Quantity: 1,
+	}); err == nil {
+		t.Error("ReserveSeats() expected an error for an unpriced zone")
+	}
+}
+
 func TestBookingService_ConfirmBooking(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -468,7 +712,7 @@ func TestBookingService_ConfirmBooking(t *testing.T) {
 				tt.setupMocks(bookingRepo, reservationRepo)
 			}
 
-			svc := NewBookingService(bookingRepo, reservationRepo, nil, nil, nil)
+			svc := NewBookingService(bookingRepo, reservationRepo, nil, nil, nil, nil, nil, nil, nil)
 
 			resp, err := svc.ConfirmBooking(context.Background(), tt.bookingID, tt.userID, tt.req)
 
@@ -491,6 +735,206 @@ func TestBookingService_ConfirmBooking(t *testing.T) {
 	}
 }
 
+func TestBookingService_ExtendHold(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name         string
+		booking      *domain.Booking
+		redisErrCode string
+		wantErr      error
+		wantUntil    time.Time // Zero = the hold is not extended
+	}{
+		{
+			name:      "extended by the grace window",
+			booking:   &domain.Booking{Status: domain.BookingStatusReserved, ReservedAt: now.Add(-5 * time.Minute), ExpiresAt: now.Add(2 * time.Minute)},
+			wantUntil: now.Add(10 * time.Minute),
+		},
+		{
+			name:      "capped at the max hold",
+			booking:   &domain.Booking{Status: domain.BookingStatusReserved, ReservedAt: now.Add(-55 * time.Minute), ExpiresAt: now.Add(2 * time.Minute)},
+			wantUntil: now.Add(5 * time.Minute),
+		},
+		{
+			name:    "max hold reached",
+			booking: &domain.Booking{Status: domain.BookingStatusReserved, ReservedAt: now.Add(-time.Hour), ExpiresAt: now.Add(30 * time.Second)},
+		},
+		{
+			name:    "already confirmed",
+			booking: &domain.Booking{Status: domain.BookingStatusConfirmed, ReservedAt: now.Add(-5 * time.Minute), ExpiresAt: now.Add(-time.Minute)},
+		},
+		{
+			name:    "cancelled",
+			booking: &domain.Booking{Status: domain.BookingStatusCancelled, ReservedAt: now.Add(-5 * time.Minute), ExpiresAt: now.Add(2 * time.Minute)},
+			wantErr: domain.ErrAlreadyReleased,
+		},
+		{
+			name:    "expired",
+			booking: &domain.Booking{Status: domain.BookingStatusReserved, ReservedAt: now.Add(-15 * time.Minute), ExpiresAt: now.Add(-5 * time.Minute)},
+			wantErr: domain.ErrBookingExpired,
+		},
+		{
+			name:         "hold gone from redis",
+			booking:      &domain.Booking{Status: domain.BookingStatusReserved, ReservedAt: now.Add(-5 * time.Minute), ExpiresAt: now.Add(2 * time.Minute)},
+			redisErrCode: "RESERVATION_NOT_FOUND",
+			wantErr:      domain.ErrReservationNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.booking.ID = "booking-123"
+			tt.booking.UserID = "user-001"
+			tt.booking.EventID = "event-001"
+
+			var redisUntil, pgUntil time.Time
+			bookingRepo := &MockBookingRepository{
+				GetByIDFunc: func(ctx context.Context, id string) (*domain.Booking, error) {
+					return tt.booking, nil
+				},
+				ExtendReservationFunc: func(ctx context.Context, id string, expiresAt time.Time) error {
+					pgUntil = expiresAt
+					return nil
+				},
+			}
+			reservationRepo := &MockReservationRepository{
+				ExtendHoldFunc: func(ctx context.Context, params repository.ExtendHoldParams) (*repository.ExtendHoldResult, error) {
+					if params.EventID != "event-001" {
+						t.Errorf("ExtendHold() event = %q, want event-001", params.EventID)
+					}
+					redisUntil = params.Until
+					if tt.redisErrCode != "" {
+						return &repository.ExtendHoldResult{ErrorCode: tt.redisErrCode}, nil
+					}
+					return &repository.ExtendHoldResult{Success: true, Extended: true, ExpiresAt: params.Until}, nil
+				},
+			}
+
+			svc := NewBookingService(bookingRepo, reservationRepo, nil, nil, nil, nil, nil, nil, &BookingServiceConfig{
+				PaymentGraceWindow:  10 * time.Minute,
+				PaymentGraceMaxHold: time.Hour,
+			})
+
+			resp, err := svc.ExtendHold(context.Background(), "booking-123", "user-001")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("ExtendHold() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExtendHold() unexpected error = %v", err)
+			}
+
+			if tt.wantUntil.IsZero() {
+				if !redisUntil.IsZero() || resp.Extended || !resp.ExpiresAt.Equal(tt.booking.ExpiresAt) {
+					t.Errorf("ExtendHold() = %+v (redis until %v), want the hold left at %v", resp, redisUntil, tt.booking.ExpiresAt)
+				}
+				return
+			}
+			if d := redisUntil.Sub(tt.wantUntil); d < -2*time.Second || d > 2*time.Second {
+				t.Errorf("ExtendHold() redis until = %v, want about %v", redisUntil, tt.wantUntil)
+			}
+			if !pgUntil.Equal(redisUntil) || !resp.ExpiresAt.Equal(redisUntil) || !resp.Extended {
+				t.Errorf("ExtendHold() = %+v with postgres until %v, want both at redis until %v", resp, pgUntil, redisUntil)
+			}
+		})
+	}
+}
+
+func TestBookingService_AwaitPayment(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		booking   *domain.Booking
+		wantErr   error
+		wantUntil time.Time // Zero = the hold keeps its expiry
+	}{
+		{
+			name:      "held for the external payment window",
+			booking:   &domain.Booking{Status: domain.BookingStatusReserved, ReservedAt: now.Add(-5 * time.Minute), ExpiresAt: now.Add(2 * time.Minute)},
+			wantUntil: now.Add(30 * time.Minute),
+		},
+		{
+			name:      "capped at the max hold",
+			booking:   &domain.Booking{Status: domain.BookingStatusReserved, ReservedAt: now.Add(-50 * time.Minute), ExpiresAt: now.Add(2 * time.Minute)},
+			wantUntil: now.Add(10 * time.Minute),
+		},
+		{
+			name:    "max hold reached",
+			booking: &domain.Booking{Status: domain.BookingStatusReserved, ReservedAt: now.Add(-time.Hour), ExpiresAt: now.Add(30 * time.Second)},
+		},
+		{
+			name:    "expired",
+			booking: &domain.Booking{Status: domain.BookingStatusReserved, ReservedAt: now.Add(-15 * time.Minute), ExpiresAt: now.Add(-5 * time.Minute)},
+			wantErr: domain.ErrBookingExpired,
+		},
+		{
+			name:    "cancelled",
+			booking: &domain.Booking{Status: domain.BookingStatusCancelled, ReservedAt: now.Add(-5 * time.Minute), ExpiresAt: now.Add(2 * time.Minute)},
+			wantErr: domain.ErrAlreadyReleased,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.booking.ID = "booking-123"
+			tt.booking.UserID = "user-001"
+			tt.booking.EventID = "event-001"
+
+			var redisUntil, pgUntil time.Time
+			var pgPaymentID string
+			bookingRepo := &MockBookingRepository{
+				GetByIDFunc: func(ctx context.Context, id string) (*domain.Booking, error) {
+					return tt.booking, nil
+				},
+				AwaitPaymentFunc: func(ctx context.Context, id, paymentID string, expiresAt time.Time) error {
+					pgPaymentID = paymentID
+					pgUntil = expiresAt
+					return nil
+				},
+			}
+			reservationRepo := &MockReservationRepository{
+				ExtendHoldFunc: func(ctx context.Context, params repository.ExtendHoldParams) (*repository.ExtendHoldResult, error) {
+					redisUntil = params.Until
+					return &repository.ExtendHoldResult{Success: true, Extended: true, ExpiresAt: params.Until}, nil
+				},
+			}
+
+			svc := NewBookingService(bookingRepo, reservationRepo, nil, nil, nil, nil, nil, nil, &BookingServiceConfig{
+				PaymentGraceMaxHold:   time.Hour,
+				ExternalPaymentWindow: 30 * time.Minute,
+			})
+
+			resp, err := svc.AwaitPayment(context.Background(), "booking-123", "user-001", "payment-1")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("AwaitPayment() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AwaitPayment() unexpected error = %v", err)
+			}
+			if resp.Status != string(domain.BookingStatusPendingPayment) || pgPaymentID != "payment-1" {
+				t.Errorf("AwaitPayment() = %+v with payment %q, want pending_payment with payment-1", resp, pgPaymentID)
+			}
+
+			if tt.wantUntil.IsZero() {
+				if !redisUntil.IsZero() || !pgUntil.Equal(tt.booking.ExpiresAt) {
+					t.Errorf("AwaitPayment() redis until %v, postgres until %v, want the hold left at %v", redisUntil, pgUntil, tt.booking.ExpiresAt)
+				}
+				return
+			}
+			if d := redisUntil.Sub(tt.wantUntil); d < -2*time.Second || d > 2*time.Second {
+				t.Errorf("AwaitPayment() redis until = %v, want about %v", redisUntil, tt.wantUntil)
+			}
+			if !pgUntil.Equal(redisUntil) || !resp.ExpiresAt.Equal(redisUntil) {
+				t.Errorf("AwaitPayment() = %+v with postgres until %v, want both at redis until %v", resp, pgUntil, redisUntil)
+			}
+		})
+	}
+}
+
 func TestBookingService_CancelBooking(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -574,7 +1018,7 @@ func TestBookingService_CancelBooking(t *testing.T) {
 				tt.setupMocks(bookingRepo, reservationRepo)
 			}
 
-			svc := NewBookingService(bookingRepo, reservationRepo, nil, nil, nil)
+			svc := NewBookingService(bookingRepo, reservationRepo, nil, nil, nil, nil, nil, nil, nil)
 
 			resp, err := svc.CancelBooking(context.Background(), tt.bookingID, tt.userID)
 
@@ -660,7 +1104,7 @@ func TestBookingService_GetBooking(t *testing.T) {
 				tt.setupMocks(bookingRepo)
 			}
 
-			svc := NewBookingService(bookingRepo, reservationRepo, nil, nil, nil)
+			svc := NewBookingService(bookingRepo, reservationRepo, nil, nil, nil, nil, nil, nil, nil)
 
 			resp, err := svc.GetBooking(context.Background(), tt.bookingID, tt.userID)
 
@@ -685,21 +1129,20 @@ func TestBookingService_GetBooking(t *testing.T) {
 
 func TestBookingService_GetUserBookings(t *testing.T) {
 	tests := []struct {
-		name       string
-		userID     string
-		page       int
-		pageSize   int
-		setupMocks func(*MockBookingRepository)
-		wantErr    error
-		wantCount  int
+		name        string
+		userID      string
+		page        pagination.Params
+		setupMocks  func(*MockBookingRepository)
+		wantErr     error
+		wantCount   int
+		wantHasMore bool
 	}{
 		{
-			name:     "successful get with results",
-			userID:   "user-001",
-			page:     1,
-			pageSize: 10,
+			name:   "successful get with results",
+			userID: "user-001",
+			page:   pagination.Params{Limit: 10},
 			setupMocks: func(br *MockBookingRepository) {
-				br.GetByUserIDFunc = func(ctx context.Context, userID string, limit, offset int) ([]*domain.Booking, error) {
+				br.GetByUserIDFunc = func(ctx context.Context, userID string, page pagination.Params) ([]*domain.Booking, error) {
 					return []*domain.Booking{
 						{ID: "booking-1", UserID: userID},
 						{ID: "booking-2", UserID: userID},
@@ -710,12 +1153,11 @@ func TestBookingService_GetUserBookings(t *testing.T) {
 			wantCount: 2,
 		},
 		{
-			name:     "empty results",
-			userID:   "user-001",
-			page:     1,
-			pageSize: 10,
+			name:   "empty results",
+			userID: "user-001",
+			page:   pagination.Params{Limit: 10},
 			setupMocks: func(br *MockBookingRepository) {
-				br.GetByUserIDFunc = func(ctx context.Context, userID string, limit, offset int) ([]*domain.Booking, error) {
+				br.GetByUserIDFunc = func(ctx context.Context, userID string, page pagination.Params) ([]*domain.Booking, error) {
 					return []*domain.Booking{}, nil
 				}
 			},
@@ -723,21 +1165,19 @@ func TestBookingService_GetUserBookings(t *testing.T) {
 			wantCount: 0,
 		},
 		{
-			name:     "missing user ID",
-			userID:   "",
-			page:     1,
-			pageSize: 10,
-			wantErr:  domain.ErrInvalidUserID,
+			name:    "missing user ID",
+			userID:  "",
+			page:    pagination.Params{Limit: 10},
+			wantErr: domain.ErrInvalidUserID,
 		},
 		{
-			name:     "default pagination values",
-			userID:   "user-001",
-			page:     0,
-			pageSize: 0,
+			name:   "default pagination values",
+			userID: "user-001",
+			page:   pagination.Params{},
 			setupMocks: func(br *MockBookingRepository) {
-				br.GetByUserIDFunc = func(ctx context.Context, userID string, limit, offset int) ([]*domain.Booking, error) {
-					if limit != 21 || offset != 0 { // 20 + 1 for checking more
-						t.Errorf("Expected limit=21, offset=0, got limit=%d, offset=%d", limit, offset)
+				br.GetByUserIDFunc = func(ctx context.Context, userID string, page pagination.Params) ([]*domain.Booking, error) {
+					if page.Limit != 21 || page.Offset != 0 { // 20 + 1 for checking more
+						t.Errorf("Expected limit=21, offset=0, got limit=%d, offset=%d", page.Limit, page.Offset)
 					}
 					return []*domain.Booking{}, nil
 				}
@@ -745,6 +1185,27 @@ func TestBookingService_GetUserBookings(t *testing.T) {
 			wantErr:   nil,
 			wantCount: 0,
 		},
+		{
+			name:   "more results after cursor",
+			userID: "user-001",
+			page:   pagination.Params{Limit: 2, After: &pagination.Cursor{Time: time.Now(), ID: "booking-0"}},
+			setupMocks: func(br *MockBookingRepository) {
+				br.GetByUserIDFunc = func(ctx context.Context, userID string, page pagination.Params) ([]*domain.Booking, error) {
+					if page.After == nil || page.After.ID != "booking-0" {
+						t.Errorf("Expected cursor to be passed to the repository, got %+v", page.After)
+					}
+					now := time.Now()
+					return []*domain.Booking{
+						{ID: "booking-1", UserID: userID, CreatedAt: now},
+						{ID: "booking-2", UserID: userID, CreatedAt: now.Add(-time.Minute)},
+						{ID: "booking-3", UserID: userID, CreatedAt: now.Add(-2 * time.Minute)},
+					}, nil
+				}
+			},
+			wantErr:     nil,
+			wantCount:   2,
+			wantHasMore: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -756,9 +1217,9 @@ func TestBookingService_GetUserBookings(t *testing.T) {
 				tt.setupMocks(bookingRepo)
 			}
 
-			svc := NewBookingService(bookingRepo, reservationRepo, nil, nil, nil)
+			svc := NewBookingService(bookingRepo, reservationRepo, nil, nil, nil, nil, nil, nil, nil)
 
-			resp, err := svc.GetUserBookings(context.Background(), tt.userID, tt.page, tt.pageSize)
+			resp, err := svc.GetUserBookings(context.Background(), tt.userID, tt.page)
 
 			if tt.wantErr != nil {
 				if !errors.Is(err, tt.wantErr) {
@@ -781,6 +1242,15 @@ func TestBookingService_GetUserBookings(t *testing.T) {
 			if len(data) != tt.wantCount {
 				t.Errorf("GetUserBookings() count = %d, want %d", len(data), tt.wantCount)
 			}
+			if resp.HasMore != tt.wantHasMore {
+				t.Errorf("GetUserBookings() has_more = %v, want %v", resp.HasMore, tt.wantHasMore)
+			}
+			if tt.wantHasMore {
+				next, err := pagination.DecodeCursor(resp.NextCursor)
+				if err != nil || next.ID != "booking-2" {
+					t.Errorf("GetUserBookings() next cursor = %+v (%v), want cursor at booking-2", next, err)
+				}
+			}
 		})
 	}
 }
@@ -855,7 +1325,7 @@ func TestBookingService_ExpireReservations(t *testing.T) {
 				tt.setupMocks(bookingRepo)
 			}
 
-			svc := NewBookingService(bookingRepo, reservationRepo, nil, nil, nil)
+			svc := NewBookingService(bookingRepo, reservationRepo, nil, nil, nil, nil, nil, nil, nil)
 
 			count, err := svc.ExpireReservations(context.Background(), tt.limit)
 
@@ -880,7 +1350,7 @@ func TestBookingService_ExpireReservations(t *testing.T) {
 
 func TestBookingServiceConfig(t *testing.T) {
 	t.Run("default config", func(t *testing.T) {
-		svc := NewBookingService(nil, nil, nil, nil, nil)
+		svc := NewBookingService(nil, nil, nil, nil, nil, nil, nil, nil, nil)
 		impl := svc.(*bookingService)
 
 		if impl.reservationTTL != 10*time.Minute {
@@ -895,7 +1365,7 @@ func TestBookingServiceConfig(t *testing.T) {
 	})
 
 	t.Run("custom config", func(t *testing.T) {
-		svc := NewBookingService(nil, nil, nil, nil, &BookingServiceConfig{
+		svc := NewBookingService(nil, nil, nil, nil, nil, nil, nil, nil, &BookingServiceConfig{
 			ReservationTTL:  5 * time.Minute,
 			MaxPerUser:      4,
 			DefaultCurrency: "USD",
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	pkgsaga "github.com/prohmpiriya/booking-rush-10k-rps/pkg/saga"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const (
+	// DefaultBookingStatusSourceTimeout is how long a section is waited for before it is reported unavailable
+	DefaultBookingStatusSourceTimeout = time.Second
+	// DefaultBookingStatusCacheTTL is how long a full status is cached per instance
+	DefaultBookingStatusCacheTTL = 2 * time.Second
+
+	// maxCachedBookingStatuses bounds the status cache; expired entries are dropped when it fills
+	maxCachedBookingStatuses = 10000
+)
+
+// BookingStatusService aggregates a booking's state across services for "where is my order"
+type BookingStatusService interface {
+	// GetFullStatus returns the booking with its payment, saga and ticket issuance.
+	// Only the booking itself is required; other sections report unavailable on failure.
+	GetFullStatus(ctx context.Context, bookingID, userID string) (*dto.BookingFullStatusResponse, error)
+}
+
+// BookingStatusConfig contains configuration for booking status service
+type BookingStatusConfig struct {
+	// SourceTimeout bounds each call to another service (default: 1s)
+	SourceTimeout time.Duration
+	// CacheTTL bounds how stale a status can be; frontends poll this endpoint (default: 2s)
+	CacheTTL time.Duration
+}
+
+// cachedBookingStatus is a complete full status lookup
+type cachedBookingStatus struct {
+	status    *dto.BookingFullStatusResponse
+	userID    string
+	fetchedAt time.Time
+}
+
+// bookingStatusService implements BookingStatusService
+type bookingStatusService struct {
+	bookingRepo   repository.BookingRepository
+	payments      PaymentLookup
+	sagas         pkgsaga.BookingLookupStore
+	sourceTimeout time.Duration
+	cacheTTL      time.Duration
+	now           func() time.Time
+
+	mu    sync.RWMutex
+	cache map[string]cachedBookingStatus
+}
+
+// NewBookingStatusService creates a new booking status service. payments and sagas may
+// be nil, their sections are then always unavailable.
+func NewBookingStatusService(bookingRepo repository.BookingRepository, payments PaymentLookup, sagas pkgsaga.BookingLookupStore, cfg *BookingStatusConfig) BookingStatusService {
+	s := &bookingStatusService{
+		bookingRepo:   bookingRepo,
+		payments:      payments,
+		sagas:         sagas,
+		sourceTimeout: DefaultBookingStatusSourceTimeout,
+		cacheTTL:      DefaultBookingStatusCacheTTL,
+		now:           time.Now,
+		cache:         make(map[string]cachedBookingStatus),
+	}
+	if cfg != nil {
+		if cfg.SourceTimeout > 0 {
+			s.sourceTimeout = cfg.SourceTimeout
+		}
+		if cfg.CacheTTL > 0 {
+			s.cacheTTL = cfg.CacheTTL
+		}
+	}
+	return s
+}
+
+// GetFullStatus returns the booking with its payment, saga and ticket issuance
+func (s *bookingStatusService) GetFullStatus(ctx context.Context, bookingID, userID string) (*dto.BookingFullStatusResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.booking_status.get_full")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("booking_id", bookingID),
+		attribute.String("user_id", userID),
+	)
+
+	if bookingID == "" {
+		span.SetStatus(codes.Error, "invalid booking_id")
+		return nil, domain.ErrInvalidBookingID
+	}
+	if userID == "" {
+		span.SetStatus(codes.Error, "invalid user_id")
+		return nil, domain.ErrInvalidUserID
+	}
+
+	if status, ok := s.cached(bookingID, userID); ok {
+		span.SetAttributes(attribute.Bool("cached", true))
+		span.SetStatus(codes.Ok, "")
+		return status, nil
+	}
+
+	booking, err := s.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if !booking.BelongsToUser(userID) {
+		span.SetStatus(codes.Error, "invalid user")
+		return nil, domain.ErrInvalidUserID
+	}
+
+	status := &dto.BookingFullStatusResponse{
+		Booking:   dto.FromDomain(booking),
+		Ticket:    ticketState(booking),
+		FetchedAt: s.now(),
+	}
+
+	// Payment and saga come from other stores; fetch them side by side
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		status.Payment = s.paymentState(ctx, bookingID)
+	}()
+	go func() {
+		defer wg.Done()
+		status.Saga = s.sagaState(ctx, bookingID)
+	}()
+	wg.Wait()
+
+	status.Partial = status.Payment.State == dto.StatusSectionUnavailable || status.Saga.State == dto.StatusSectionUnavailable
+
+	// Partial statuses are not cached, so a recovered service shows up on the next poll
+	if !status.Partial {
+		s.store(bookingID, userID, status)
+	}
+
+	span.SetAttributes(
+		attribute.String("payment_state", status.Payment.State),
+		attribute.String("saga_state", status.Saga.State),
+		attribute.Bool("partial", status.Partial),
+	)
+	span.SetStatus(codes.Ok, "")
+	return status, nil
+}
+
+// paymentState fetches the booking's payments from payment service
+func (s *bookingStatusService) paymentState(ctx context.Context, bookingID string) *dto.BookingPaymentState {
+	if s.payments == nil {
+		return &dto.BookingPaymentState{State: dto.StatusSectionUnavailable}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.sourceTimeout)
+	defer cancel()
+
+	payments, err := s.payments.ListByBookingIDs(ctx, []string{bookingID})
+	if err != nil {
+		logger.Get().Warn(fmt.Sprintf("Booking status: payments of booking %s unavailable: %v", bookingID, err))
+		return &dto.BookingPaymentState{State: dto.StatusSectionUnavailable}
+	}
+	if len(payments) == 0 {
+		return &dto.BookingPaymentState{State: dto.StatusSectionNone}
+	}
+
+	sort.Slice(payments, func(i, j int) bool {
+		return payments[i].CreatedAt.After(payments[j].CreatedAt)
+	})
+	return &dto.BookingPaymentState{
+		State:    dto.StatusSectionOK,
+		Status:   payments[0].Status,
+		Payments: payments,
+	}
+}
+
+// sagaState fetches the latest saga run for the booking
+func (s *bookingStatusService) sagaState(ctx context.Context, bookingID string) *dto.BookingSagaState {
+	if s.sagas == nil {
+		return &dto.BookingSagaState{State: dto.StatusSectionUnavailable}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.sourceTimeout)
+	defer cancel()
+
+	instance, err := s.sagas.GetByBookingID(ctx, bookingID)
+	if err != nil {
+		// Bookings reserved on the fast path only start a saga once paid
+		if errors.Is(err, pkgsaga.ErrSagaNotFound) {
+			return &dto.BookingSagaState{State: dto.StatusSectionNone}
+		}
+		logger.Get().Warn(fmt.Sprintf("Booking status: saga of booking %s unavailable: %v", bookingID, err))
+		return &dto.BookingSagaState{State: dto.StatusSectionUnavailable}
+	}
+
+	updatedAt := instance.UpdatedAt
+	return &dto.BookingSagaState{
+		State:       dto.StatusSectionOK,
+		SagaID:      instance.ID,
+		Name:        instance.DefinitionID,
+		Status:      string(instance.Status),
+		CurrentStep: instance.CurrentStep,
+		Error:       instance.Error,
+		UpdatedAt:   &updatedAt,
+		CompletedAt: instance.CompletedAt,
+	}
+}
+
+// ticketState derives ticket issuance from the booking: a confirmed booking's
+// confirmation code is its ticket
+func ticketState(booking *domain.Booking) *dto.BookingTicketState {
+	state := &dto.BookingTicketState{State: dto.StatusSectionOK}
+	switch booking.Status {
+	case domain.BookingStatusConfirmed:
+		state.Status = dto.TicketStatusIssued
+		state.ConfirmationCode = booking.ConfirmationCode
+		state.Seats = booking.SeatLabels
+		state.IssuedAt = booking.ConfirmedAt
+	case domain.BookingStatusReserved, domain.BookingStatusPendingPayment:
+		state.Status = dto.TicketStatusPending
+	default:
+		state.Status = dto.TicketStatusVoid
+	}
+	return state
+}
+
+// cached returns the status cached for the booking, if still fresh and owned by userID
+func (s *bookingStatusService) cached(bookingID, userID string) (*dto.BookingFullStatusResponse, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entry, ok := s.cache[bookingID]
+	if !ok || entry.userID != userID || s.now().Sub(entry.fetchedAt) >= s.cacheTTL {
+		return nil, false
+	}
+	return entry.status, true
+}
+
+// store caches a complete status, dropping expired entries when the cache is full
+func (s *bookingStatusService) store(bookingID, userID string, status *dto.BookingFullStatusResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if len(s.cache) >= maxCachedBookingStatuses {
+		for id, entry := range s.cache {
+			if now.Sub(entry.fetchedAt) >= s.cacheTTL {
+				delete(s.cache, id)
+			}
+		}
+		if len(s.cache) >= maxCachedBookingStatuses {
+			s.cache = make(map[string]cachedBookingStatus)
+		}
+	}
+	s.cache[bookingID] = cachedBookingStatus{status: status, userID: userID, fetchedAt: now}
+}
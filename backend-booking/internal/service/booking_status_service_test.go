@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	pkgsaga "github.com/prohmpiriya/booking-rush-10k-rps/pkg/saga"
+)
+
+// failingSagaLookup is a saga store that cannot be reached
+type failingSagaLookup struct{}
+
+func (failingSagaLookup) GetByBookingID(ctx context.Context, bookingID string) (*pkgsaga.Instance, error) {
+	return nil, errors.New("connection refused")
+}
+
+func newStatusTestBookingRepo(calls *int) *MockBookingRepository {
+	confirmedAt := time.Now()
+	return &MockBookingRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Booking, error) {
+			*calls++
+			if id != "booking-1" {
+				return nil, domain.ErrBookingNotFound
+			}
+			return &domain.Booking{
+				ID:               "booking-1",
+				UserID:           "user-1",
+				Status:           domain.BookingStatusConfirmed,
+				ConfirmationCode: "BK-ABC123",
+				SeatLabels:       []string{"A-1"},
+				ConfirmedAt:      &confirmedAt,
+			}, nil
+		},
+	}
+}
+
+func TestBookingStatusService_GetFullStatus(t *testing.T) {
+	calls := 0
+	payments := &MockPaymentLookup{Payments: []*dto.PaymentSummary{
+		{ID: "pay-1", BookingID: "booking-1", Status: "failed", CreatedAt: time.Now().Add(-time.Minute)},
+		{ID: "pay-2", BookingID: "booking-1", Status: "succeeded", CreatedAt: time.Now()},
+	}}
+	sagas := pkgsaga.NewMemoryStore()
+	instance := pkgsaga.NewInstance("post-payment-saga", map[string]interface{}{"booking_id": "booking-1"})
+	instance.SetStatus(pkgsaga.StatusCompleted)
+	sagas.Save(context.Background(), instance)
+
+	svc := NewBookingStatusService(newStatusTestBookingRepo(&calls), payments, sagas, nil)
+
+	status, err := svc.GetFullStatus(context.Background(), "booking-1", "user-1")
+	if err != nil {
+		t.Fatalf("GetFullStatus() error = %v", err)
+	}
+	if status.Partial {
+		t.Error("expected a complete status")
+	}
+	if status.Payment.State != dto.StatusSectionOK || status.Payment.Status != "succeeded" || len(status.Payment.Payments) != 2 {
+		t.Errorf("expected the latest payment first, got %+v", status.Payment)
+	}
+	if status.Saga.State != dto.StatusSectionOK || status.Saga.SagaID != instance.ID || status.Saga.Status != string(pkgsaga.StatusCompleted) {
+		t.Errorf("unexpected saga section: %+v", status.Saga)
+	}
+	if status.Ticket.Status != dto.TicketStatusIssued || status.Ticket.ConfirmationCode != "BK-ABC123" {
+		t.Errorf("unexpected ticket section: %+v", status.Ticket)
+	}
+
+	// Polling again within the TTL is served from the cache, but not to another user
+	if _, err := svc.GetFullStatus(context.Background(), "booking-1", "user-1"); err != nil {
+		t.Fatalf("GetFullStatus() cached error = %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected the second poll to be cached, booking fetched %d times", calls)
+	}
+	if _, err := svc.GetFullStatus(context.Background(), "booking-1", "user-2"); !errors.Is(err, domain.ErrInvalidUserID) {
+		t.Errorf("GetFullStatus() other user error = %v, want ErrInvalidUserID", err)
+	}
+}
+
+func TestBookingStatusService_GetFullStatus_PartialFailure(t *testing.T) {
+	calls := 0
+	svc := NewBookingStatusService(
+		newStatusTestBookingRepo(&calls),
+		&MockPaymentLookup{Err: errors.New("payment service down")},
+		failingSagaLookup{},
+		nil,
+	)
+
+	status, err := svc.GetFullStatus(context.Background(), "booking-1", "user-1")
+	if err != nil {
+		t.Fatalf("GetFullStatus() error = %v", err)
+	}
+	if !status.Partial {
+		t.Error("expected a partial status")
+	}
+	if status.Payment.State != dto.StatusSectionUnavailable || status.Saga.State != dto.StatusSectionUnavailable {
+		t.Errorf("expected payment and saga unavailable, got %+v and %+v", status.Payment, status.Saga)
+	}
+	if status.Booking == nil || status.Ticket.Status != dto.TicketStatusIssued {
+		t.Errorf("expected the booking and ticket despite the failures, got %+v", status)
+	}
+
+	// Partial statuses are refetched on the next poll
+	if _, err := svc.GetFullStatus(context.Background(), "booking-1", "user-1"); err != nil {
+		t.Fatalf("GetFullStatus() error = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected a partial status not to be cached, booking fetched %d times", calls)
+	}
+
+	if _, err := svc.GetFullStatus(context.Background(), "missing", "user-1"); !errors.Is(err, domain.ErrBookingNotFound) {
+		t.Errorf("GetFullStatus() missing booking error = %v, want ErrBookingNotFound", err)
+	}
+}
+
+func TestBookingStatusService_NoPaymentOrSaga(t *testing.T) {
+	calls := 0
+	svc := NewBookingStatusService(newStatusTestBookingRepo(&calls), &MockPaymentLookup{}, pkgsaga.NewMemoryStore(), nil)
+
+	status, err := svc.GetFullStatus(context.Background(), "booking-1", "user-1")
+	if err != nil {
+		t.Fatalf("GetFullStatus() error = %v", err)
+	}
+	if status.Partial || status.Payment.State != dto.StatusSectionNone || status.Saga.State != dto.StatusSectionNone {
+		t.Errorf("expected empty payment and saga sections, got %+v and %+v", status.Payment, status.Saga)
+	}
+}
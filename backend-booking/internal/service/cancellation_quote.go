@@ -0,0 +1,108 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// CancellationQuotePurpose is the purpose claim of every cancellation quote token
+const CancellationQuotePurpose = "cancellation_quote"
+
+// CancellationQuoteClaims represents the claims of a cancellation quote token.
+// The token guarantees its amounts for the booking as it was when quoted. The holder
+// is not a user_id claim, so the token never passes as an access token.
+type CancellationQuoteClaims struct {
+	BookingID     string  `json:"booking_id"`
+	UserID        string  `json:"quote_holder"`
+	Version       int64   `json:"version"` // Booking UpdatedAt in Unix nanoseconds
+	TotalPaid     float64 `json:"total_paid"`
+	RefundPercent float64 `json:"refund_percent"`
+	Forfeited     float64 `json:"forfeited"`
+	Fee           float64 `json:"fee"`
+	RefundAmount  float64 `json:"refund_amount"`
+	Purpose       string  `json:"purpose"`
+	jwt.RegisteredClaims
+}
+
+// Matches reports whether the token was issued for this booking in its current state
+func (c *CancellationQuoteClaims) Matches(booking *domain.Booking, userID string) bool {
+	return c.BookingID == booking.ID && c.UserID == userID && c.Version == booking.UpdatedAt.UnixNano()
+}
+
+// Quote returns the guaranteed amounts of the token
+func (c *CancellationQuoteClaims) Quote() *domain.CancellationQuote {
+	return &domain.CancellationQuote{
+		BookingID:     c.BookingID,
+		TotalPaid:     c.TotalPaid,
+		RefundPercent: c.RefundPercent,
+		Forfeited:     c.Forfeited,
+		Fee:           c.Fee,
+		RefundAmount:  c.RefundAmount,
+		QuotedAt:      c.IssuedAt.Time,
+	}
+}
+
+// SignCancellationQuote signs a quote of booking for userID that is honored until ttl passes
+func SignCancellationQuote(secret string, booking *domain.Booking, userID string, quote *domain.CancellationQuote, ttl time.Duration) (string, time.Time, error) {
+	expiresAt := quote.QuotedAt.Add(ttl)
+
+	claims := CancellationQuoteClaims{
+		BookingID:     booking.ID,
+		UserID:        userID,
+		Version:       booking.UpdatedAt.UnixNano(),
+		TotalPaid:     quote.TotalPaid,
+		RefundPercent: quote.RefundPercent,
+		Forfeited:     quote.Forfeited,
+		Fee:           quote.Fee,
+		RefundAmount:  quote.RefundAmount,
+		Purpose:       CancellationQuotePurpose,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(quote.QuotedAt),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signedToken, err := token.SignedString(purposeKey(secret, CancellationQuotePurpose))
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign cancellation quote: %w", err)
+	}
+	return signedToken, expiresAt, nil
+}
+
+// ParseCancellationQuote verifies the signature and expiry of a cancellation quote token.
+// It does not check which booking the quote is for.
+func ParseCancellationQuote(secret, quoteToken string) (*CancellationQuoteClaims, error) {
+	token, err := jwt.ParseWithClaims(quoteToken, &CancellationQuoteClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return purposeKey(secret, CancellationQuotePurpose), nil
+	})
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, domain.ErrCancellationQuoteExpired
+		}
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidCancellationQuote, err)
+	}
+
+	claims, ok := token.Claims.(*CancellationQuoteClaims)
+	if !ok || !token.Valid || claims.Purpose != CancellationQuotePurpose {
+		return nil, domain.ErrInvalidCancellationQuote
+	}
+	return claims, nil
+}
+
+// purposeKey derives the key tokens of one purpose are signed with from the JWT secret,
+// so they cannot be verified as access tokens or as tokens of another purpose
+func purposeKey(secret, purpose string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,599 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/middleware"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// checkoutNamespace derives checkout IDs from client idempotency keys
+var checkoutNamespace = uuid.MustParse("5b0c9a3e-7f3d-4c1e-9a51-2f6e8d4b7c10")
+
+// CartService manages multi-show carts and checks them out as one payment
+type CartService interface {
+	// GetCart returns the user's cart, empty if the user has none
+	GetCart(ctx context.Context, userID string) (*dto.CartResponse, error)
+
+	// AddItem adds seats for a show and zone, replacing the quantity if the zone is already in the cart
+	AddItem(ctx context.Context, userID, tenantID string, req *dto.AddCartItemRequest) (*dto.CartResponse, error)
+
+	// RemoveItem removes an item from the cart
+	RemoveItem(ctx context.Context, userID, itemID string) (*dto.CartResponse, error)
+
+	// ClearCart removes every item from the cart
+	ClearCart(ctx context.Context, userID string) error
+
+	// Checkout reserves every cart item. By default one failed item releases the others;
+	// with AllowPartial the reserved items are kept and failures are reported per item.
+	Checkout(ctx context.Context, userID string, req *dto.CheckoutRequest) (*dto.CheckoutResponse, error)
+
+	// GetCheckout retrieves a checkout
+	GetCheckout(ctx context.Context, checkoutID, userID string) (*dto.CheckoutResponse, error)
+
+	// ConfirmCheckout confirms every reserved item with the checkout's payment
+	ConfirmCheckout(ctx context.Context, checkoutID, userID string, req *dto.ConfirmCheckoutRequest) (*dto.CheckoutResponse, error)
+
+	// CancelCheckout releases every reserved item
+	CancelCheckout(ctx context.Context, checkoutID, userID string) (*dto.CheckoutResponse, error)
+}
+
+// CartServiceConfig contains configuration for cart service
+type CartServiceConfig struct {
+	// CartTTL is how long an untouched cart is kept
+	CartTTL time.Duration
+	// CheckoutTTL is how long a checkout stays retrievable
+	CheckoutTTL time.Duration
+	// RequireQueuePass enforces virtual queue passes at checkout, like the reserve API
+	RequireQueuePass bool
+}
+
+// cartService implements CartService
+type cartService struct {
+	cartRepo         repository.CartRepository
+	bookingService   BookingService
+	queueService     QueueService
+	cartTTL          time.Duration
+	checkoutTTL      time.Duration
+	requireQueuePass bool
+	now              func() time.Time
+}
+
+// NewCartService creates a new cart service
+func NewCartService(
+	cartRepo repository.CartRepository,
+	bookingService BookingService,
+	queueService QueueService,
+	cfg *CartServiceConfig,
+) CartService {
+	s := &cartService{
+		cartRepo:       cartRepo,
+		bookingService: bookingService,
+		queueService:   queueService,
+		cartTTL:        24 * time.Hour,
+		checkoutTTL:    24 * time.Hour,
+		now:            time.Now,
+	}
+	if cfg != nil {
+		if cfg.CartTTL > 0 {
+			s.cartTTL = cfg.CartTTL
+		}
+		if cfg.CheckoutTTL > 0 {
+			s.checkoutTTL = cfg.CheckoutTTL
+		}
+		s.requireQueuePass = cfg.RequireQueuePass
+	}
+	return s
+}
+
+// GetCart returns the user's cart, empty if the user has none
+func (s *cartService) GetCart(ctx context.Context, userID string) (*dto.CartResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.cart.get")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", userID))
+
+	cart, err := s.loadCart(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("items", len(cart.Items)))
+	span.SetStatus(codes.Ok, "")
+	return dto.CartFromDomain(cart), nil
+}
+
+// AddItem adds seats for a show and zone, replacing the quantity if the zone is already in the cart
+func (s *cartService) AddItem(ctx context.Context, userID, tenantID string, req *dto.AddCartItemRequest) (*dto.CartResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.cart.add_item")
+	defer span.End()
+
+	// Validate inputs
+	if userID == "" {
+		span.SetStatus(codes.Error, "invalid user_id")
+		return nil, domain.ErrInvalidUserID
+	}
+	if req == nil || req.EventID == "" {
+		span.SetStatus(codes.Error, "invalid event_id")
+		return nil, domain.ErrInvalidEventID
+	}
+	if req.ZoneID == "" {
+		span.SetStatus(codes.Error, "invalid zone_id")
+		return nil, domain.ErrInvalidZoneID
+	}
+	if req.Quantity <= 0 {
+		span.SetStatus(codes.Error, "invalid quantity")
+		return nil, domain.ErrInvalidQuantity
+	}
+	if req.UnitPrice < 0 {
+		span.SetStatus(codes.Error, "invalid unit price")
+		return nil, domain.ErrInvalidUnitPrice
+	}
+	for i := range req.AddOns {
+		if err := req.AddOns[i].Validate(); err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+	}
+
+	span.SetAttributes(
+		attribute.String("user_id", userID),
+		attribute.String("event_id", req.EventID),
+		attribute.String("show_id", req.ShowID),
+		attribute.String("zone_id", req.ZoneID),
+		attribute.Int("quantity", req.Quantity),
+	)
+
+	cart, err := s.loadCart(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	now := s.now()
+	if item := cart.FindZoneItem(req.ShowID, req.ZoneID); item != nil {
+		item.Quantity = req.Quantity
+		item.UnitPrice = req.UnitPrice
+		item.AddOns = req.AddOns
+	} else {
+		if len(cart.Items) >= domain.MaxCartItems {
+			span.SetStatus(codes.Error, "cart full")
+			return nil, domain.ErrCartFull
+		}
+		cart.Items = append(cart.Items, &domain.CartItem{
+			ID:        uuid.New().String(),
+			EventID:   req.EventID,
+			ShowID:    req.ShowID,
+			ZoneID:    req.ZoneID,
+			TenantID:  tenantID,
+			Quantity:  req.Quantity,
+			UnitPrice: req.UnitPrice,
+			AddedAt:   now,
+			AddOns:    req.AddOns,
+		})
+	}
+	cart.UpdatedAt = now
+
+	if err := s.cartRepo.SaveCart(ctx, cart, s.cartTTL); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return dto.CartFromDomain(cart), nil
+}
+
+// RemoveItem removes an item from the cart
+func (s *cartService) RemoveItem(ctx context.Context, userID, itemID string) (*dto.CartResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.cart.remove_item")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", userID),
+		attribute.String("item_id", itemID),
+	)
+
+	cart, err := s.loadCart(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if !cart.RemoveItems(itemID) {
+		span.SetStatus(codes.Error, "item not found")
+		return nil, domain.ErrCartItemNotFound
+	}
+	cart.UpdatedAt = s.now()
+
+	if err := s.cartRepo.SaveCart(ctx, cart, s.cartTTL); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return dto.CartFromDomain(cart), nil
+}
+
+// ClearCart removes every item from the cart
+func (s *cartService) ClearCart(ctx context.Context, userID string) error {
+	ctx, span := telemetry.StartSpan(ctx, "service.cart.clear")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", userID))
+
+	if err := s.cartRepo.DeleteCart(ctx, userID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// Checkout reserves every cart item
+func (s *cartService) Checkout(ctx context.Context, userID string, req *dto.CheckoutRequest) (*dto.CheckoutResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.cart.checkout")
+	defer span.End()
+
+	if userID == "" {
+		span.SetStatus(codes.Error, "invalid user_id")
+		return nil, domain.ErrInvalidUserID
+	}
+	if req == nil {
+		req = &dto.CheckoutRequest{}
+	}
+
+	span.SetAttributes(
+		attribute.String("user_id", userID),
+		attribute.Bool("allow_partial", req.AllowPartial),
+	)
+
+	// A retried checkout with the same key returns the first result
+	checkoutID := uuid.New().String()
+	if req.IdempotencyKey != "" {
+		checkoutID = uuid.NewSHA1(checkoutNamespace, []byte(userID+":"+req.IdempotencyKey)).String()
+		existing, err := s.cartRepo.GetCheckout(ctx, checkoutID)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		if existing != nil {
+			span.SetAttributes(attribute.Bool("idempotent_replay", true))
+			span.SetStatus(codes.Ok, "")
+			return dto.CheckoutFromDomain(existing), nil
+		}
+	}
+	span.SetAttributes(attribute.String("checkout_id", checkoutID))
+
+	cart, err := s.loadCart(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if len(cart.Items) == 0 {
+		span.SetStatus(codes.Error, "cart empty")
+		return nil, domain.ErrCartEmpty
+	}
+
+	now := s.now()
+	checkout := &domain.Checkout{
+		ID:        checkoutID,
+		UserID:    userID,
+		CreatedAt: now,
+	}
+
+	// Reserve item by item; each reservation is atomic in Redis, and the checkout as a
+	// whole is made all-or-nothing by releasing what was reserved when an item fails
+	failed := 0
+	for _, cartItem := range cart.Items {
+		item := &domain.CheckoutItem{CartItem: *cartItem}
+		checkout.Items = append(checkout.Items, item)
+
+		// All or nothing: stop reserving after the first failure
+		if failed > 0 && !req.AllowPartial {
+			item.Status = domain.CheckoutItemStatusSkipped
+			continue
+		}
+
+		if err := s.validateQueuePass(ctx, userID, cartItem.EventID, cartItem.ZoneID, req.QueuePasses); err != nil {
+			failCheckoutItem(item, err)
+			failed++
+			continue
+		}
+
+		result, err := s.bookingService.ReserveSeats(ctx, userID, &dto.ReserveSeatsRequest{
+			EventID:  cartItem.EventID,
+			ZoneID:   cartItem.ZoneID,
+			ShowID:   cartItem.ShowID,
+			TenantID: cartItem.TenantID,
+			Quantity: cartItem.Quantity,
+			AddOns:   cartItem.AddOns,
+			// Stable per item so a checkout retried after a crash reuses its bookings
+			IdempotencyKey: checkoutID + ":" + cartItem.ID,
+			Verification:   req.Verification,
+		})
+		if err != nil {
+			failCheckoutItem(item, err)
+			failed++
+			continue
+		}
+
+		item.BookingID = result.BookingID
+		item.Status = domain.CheckoutItemStatusReserved
+		item.TotalPrice = result.TotalPrice
+		item.Seats = result.Seats
+		if checkout.ExpiresAt.IsZero() || result.ExpiresAt.Before(checkout.ExpiresAt) {
+			checkout.ExpiresAt = result.ExpiresAt
+		}
+	}
+
+	if failed > 0 && !req.AllowPartial {
+		s.releaseItems(ctx, userID, checkout)
+	}
+
+	reserved := checkout.ReservedItems()
+	switch {
+	case len(reserved) == 0:
+		checkout.Status = domain.CheckoutStatusFailed
+	case failed > 0:
+		checkout.Status = domain.CheckoutStatusPartiallyReserved
+	default:
+		checkout.Status = domain.CheckoutStatusReserved
+	}
+	for _, item := range reserved {
+		checkout.TotalPrice += item.TotalPrice
+	}
+	checkout.UpdatedAt = s.now()
+
+	if err := s.cartRepo.SaveCheckout(ctx, checkout, s.checkoutTTL); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		// Do not leave seats held for a checkout the user cannot see
+		s.releaseItems(ctx, userID, checkout)
+		return nil, err
+	}
+
+	// Reserved items leave the cart; failed ones stay so the user can adjust and retry
+	if len(reserved) > 0 {
+		s.finishCart(ctx, cart, checkout)
+	}
+
+	span.SetAttributes(
+		attribute.String("status", checkout.Status.String()),
+		attribute.Int("reserved_items", len(reserved)),
+		attribute.Int("failed_items", failed),
+	)
+	span.SetStatus(codes.Ok, "")
+	return dto.CheckoutFromDomain(checkout), nil
+}
+
+// GetCheckout retrieves a checkout
+func (s *cartService) GetCheckout(ctx context.Context, checkoutID, userID string) (*dto.CheckoutResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.cart.get_checkout")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("checkout_id", checkoutID),
+		attribute.String("user_id", userID),
+	)
+
+	checkout, err := s.loadCheckout(ctx, checkoutID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return dto.CheckoutFromDomain(checkout), nil
+}
+
+// ConfirmCheckout confirms every reserved item with the checkout's payment
+func (s *cartService) ConfirmCheckout(ctx context.Context, checkoutID, userID string, req *dto.ConfirmCheckoutRequest) (*dto.CheckoutResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.cart.confirm_checkout")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("checkout_id", checkoutID),
+		attribute.String("user_id", userID),
+	)
+
+	checkout, err := s.loadCheckout(ctx, checkoutID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	reserved := checkout.ReservedItems()
+	if len(reserved) == 0 {
+		// Already confirmed: answer retries with the outcome
+		if checkout.Status == domain.CheckoutStatusConfirmed || checkout.Status == domain.CheckoutStatusPartiallyConfirmed {
+			span.SetStatus(codes.Ok, "")
+			return dto.CheckoutFromDomain(checkout), nil
+		}
+		span.SetStatus(codes.Error, "checkout not pending")
+		return nil, domain.ErrCheckoutNotPending
+	}
+
+	paymentID := ""
+	var verification *middleware.Verification
+	if req != nil {
+		paymentID = req.PaymentID
+		verification = req.Verification
+	}
+	checkout.PaymentID = paymentID
+
+	failed := 0
+	for _, item := range reserved {
+		result, err := s.bookingService.ConfirmBooking(ctx, item.BookingID, userID, &dto.ConfirmBookingRequest{
+			PaymentID:    paymentID,
+			Verification: verification,
+		})
+		switch {
+		case err == nil:
+			item.Status = domain.CheckoutItemStatusConfirmed
+			item.ConfirmationCode = result.ConfirmationCode
+		case errors.Is(err, domain.ErrAlreadyConfirmed):
+			item.Status = domain.CheckoutItemStatusConfirmed
+		default:
+			span.RecordError(err)
+			failCheckoutItem(item, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		checkout.Status = domain.CheckoutStatusPartiallyConfirmed
+	} else {
+		checkout.Status = domain.CheckoutStatusConfirmed
+	}
+	checkout.UpdatedAt = s.now()
+
+	if err := s.cartRepo.SaveCheckout(ctx, checkout, s.checkoutTTL); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.String("status", checkout.Status.String()),
+		attribute.Int("failed_items", failed),
+	)
+	span.SetStatus(codes.Ok, "")
+	return dto.CheckoutFromDomain(checkout), nil
+}
+
+// CancelCheckout releases every reserved item
+func (s *cartService) CancelCheckout(ctx context.Context, checkoutID, userID string) (*dto.CheckoutResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.cart.cancel_checkout")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("checkout_id", checkoutID),
+		attribute.String("user_id", userID),
+	)
+
+	checkout, err := s.loadCheckout(ctx, checkoutID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if len(checkout.ReservedItems()) == 0 {
+		span.SetStatus(codes.Error, "checkout not pending")
+		return nil, domain.ErrCheckoutNotPending
+	}
+
+	s.releaseItems(ctx, userID, checkout)
+	checkout.Status = domain.CheckoutStatusCancelled
+	checkout.TotalPrice = 0
+	checkout.UpdatedAt = s.now()
+
+	if err := s.cartRepo.SaveCheckout(ctx, checkout, s.checkoutTTL); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return dto.CheckoutFromDomain(checkout), nil
+}
+
+// loadCart returns the user's cart, or a new empty one
+func (s *cartService) loadCart(ctx context.Context, userID string) (*domain.Cart, error) {
+	cart, err := s.cartRepo.GetCart(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if cart == nil {
+		cart = &domain.Cart{UserID: userID}
+	}
+	return cart, nil
+}
+
+// loadCheckout returns the user's checkout, hiding other users' checkouts as not found
+func (s *cartService) loadCheckout(ctx context.Context, checkoutID, userID string) (*domain.Checkout, error) {
+	checkout, err := s.cartRepo.GetCheckout(ctx, checkoutID)
+	if err != nil {
+		return nil, err
+	}
+	if checkout == nil || checkout.UserID != userID {
+		return nil, domain.ErrCheckoutNotFound
+	}
+	return checkout, nil
+}
+
+// validateQueuePass checks the queue pass for a zone of an event when passes are enforced
+func (s *cartService) validateQueuePass(ctx context.Context, userID, eventID, zoneID string, passes map[string]string) error {
+	if !s.requireQueuePass || s.queueService == nil {
+		return nil
+	}
+	return s.queueService.ValidateQueuePass(ctx, userID, eventID, zoneID, passes[eventID])
+}
+
+// releaseItems releases the reserved items of a checkout. Items that can no longer be
+// released (already expired or released) count as released.
+func (s *cartService) releaseItems(ctx context.Context, userID string, checkout *domain.Checkout) {
+	for _, item := range checkout.ReservedItems() {
+		_, err := s.bookingService.ReleaseBooking(ctx, item.BookingID, userID)
+		if err != nil && !errors.Is(err, domain.ErrAlreadyReleased) && !domain.IsExpiredError(err) {
+			failCheckoutItem(item, err)
+			continue
+		}
+		item.Status = domain.CheckoutItemStatusReleased
+	}
+}
+
+// finishCart removes reserved items from the cart and uses up their queue passes
+func (s *cartService) finishCart(ctx context.Context, cart *domain.Cart, checkout *domain.Checkout) {
+	var itemIDs []string
+	events := make(map[string]bool)
+	for _, item := range checkout.ReservedItems() {
+		itemIDs = append(itemIDs, item.ID)
+		events[item.EventID] = true
+	}
+
+	cart.RemoveItems(itemIDs...)
+	cart.UpdatedAt = s.now()
+	var err error
+	if len(cart.Items) == 0 {
+		err = s.cartRepo.DeleteCart(ctx, cart.UserID)
+	} else {
+		err = s.cartRepo.SaveCart(ctx, cart, s.cartTTL)
+	}
+	if err != nil {
+		// The checkout is saved; items left in the cart can simply be removed by the user
+		logger.Get().Warn("failed to remove checked out items from cart of user " + cart.UserID + ": " + err.Error())
+	}
+
+	if s.requireQueuePass && s.queueService != nil {
+		for eventID := range events {
+			_ = s.queueService.DeleteQueuePass(ctx, cart.UserID, eventID)
+		}
+	}
+}
+
+// failCheckoutItem records why an item could not be reserved, confirmed or released
+func failCheckoutItem(item *domain.CheckoutItem, err error) {
+	item.Status = domain.CheckoutItemStatusFailed
+	item.ErrorCode = bookingErrorCode(err)
+	item.ErrorMessage = err.Error()
+}
@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+)
+
+// MockCartRepository is an in-memory implementation of CartRepository
+type MockCartRepository struct {
+	carts     map[string]*domain.Cart
+	checkouts map[string]*domain.Checkout
+}
+
+func NewMockCartRepository() *MockCartRepository {
+	return &MockCartRepository{
+		carts:     make(map[string]*domain.Cart),
+		checkouts: make(map[string]*domain.Checkout),
+	}
+}
+
+func (m *MockCartRepository) GetCart(ctx context.Context, userID string) (*domain.Cart, error) {
+	cart, ok := m.carts[userID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *cart
+	copied.Items = append([]*domain.CartItem(nil), cart.Items...)
+	return &copied, nil
+}
+
+func (m *MockCartRepository) SaveCart(ctx context.Context, cart *domain.Cart, ttl time.Duration) error {
+	m.carts[cart.UserID] = cart
+	return nil
+}
+
+func (m *MockCartRepository) DeleteCart(ctx context.Context, userID string) error {
+	delete(m.carts, userID)
+	return nil
+}
+
+func (m *MockCartRepository) SaveCheckout(ctx context.Context, checkout *domain.Checkout, ttl time.Duration) error {
+	m.checkouts[checkout.ID] = checkout
+	return nil
+}
+
+func (m *MockCartRepository) GetCheckout(ctx context.Context, checkoutID string) (*domain.Checkout, error) {
+	return m.checkouts[checkoutID], nil
+}
+
+// stubCartBookingService reserves zones unless they are listed in soldOut
+type stubCartBookingService struct {
+	BookingService
+	soldOut   map[string]bool
+	reserved  map[string]string // booking ID -> zone ID
+	released  []string
+	confirmed []string
+	failZone  string // zone whose booking fails to confirm
+}
+
+func newStubCartBookingService(soldOutZones ...string) *stubCartBookingService {
+	s := &stubCartBookingService{soldOut: make(map[string]bool), reserved: make(map[string]string)}
+	for _, zone := range soldOutZones {
+		s.soldOut[zone] = true
+	}
+	return s
+}
+
+func (s *stubCartBookingService) ReserveSeats(ctx context.Context, userID string, req *dto.ReserveSeatsRequest) (*dto.ReserveSeatsResponse, error) {
+	if s.soldOut[req.ZoneID] {
+		return nil, domain.ErrInsufficientSeats
+	}
+	bookingID := "booking-" + req.ZoneID
+	s.reserved[bookingID] = req.ZoneID
+	return &dto.ReserveSeatsResponse{
+		BookingID:  bookingID,
+		Status:     "reserved",
+		ExpiresAt:  time.Now().Add(10 * time.Minute),
+		TotalPrice: 50 * float64(req.Quantity), // every test zone sells at 50
+	}, nil
+}
+
+func (s *stubCartBookingService) ReleaseBooking(ctx context.Context, bookingID, userID string) (*dto.ReleaseBookingResponse, error) {
+	s.released = append(s.released, bookingID)
+	return &dto.ReleaseBookingResponse{BookingID: bookingID, Status: "released"}, nil
+}
+
+func (s *stubCartBookingService) ConfirmBooking(ctx context.Context, bookingID, userID string, req *dto.ConfirmBookingRequest) (*dto.ConfirmBookingResponse, error) {
+	if s.reserved[bookingID] == s.failZone {
+		return nil, domain.ErrReservationExpired
+	}
+	s.confirmed = append(s.confirmed, bookingID)
+	return &dto.ConfirmBookingResponse{BookingID: bookingID, Status: "confirmed", ConfirmationCode: "CODE-" + bookingID}, nil
+}
+
+// fillCart adds one item per zone, each in its own show
+func fillCart(t *testing.T, svc CartService, zones ...string) {
+	t.Helper()
+	for _, zone := range zones {
+		_, err := svc.AddItem(context.Background(), "user-123", "tenant-1", &dto.AddCartItemRequest{
+			EventID:   "event-1",
+			ShowID:    "show-" + zone,
+			ZoneID:    zone,
+			Quantity:  2,
+			UnitPrice: 50,
+		})
+		if err != nil {
+			t.Fatalf("AddItem(%s) error = %v", zone, err)
+		}
+	}
+}
+
+func TestCartService_AddRemoveItems(t *testing.T) {
+	svc := NewCartService(NewMockCartRepository(), newStubCartBookingService(), nil, nil)
+	ctx := context.Background()
+
+	fillCart(t, svc, "zone-a", "zone-b")
+
+	// Same show and zone replaces the quantity
+	cart, err := svc.AddItem(ctx, "user-123", "", &dto.AddCartItemRequest{
+		EventID: "event-1", ShowID: "show-zone-a", ZoneID: "zone-a", Quantity: 4, UnitPrice: 50,
+	})
+	if err != nil {
+		t.Fatalf("AddItem() error = %v", err)
+	}
+	if len(cart.Items) != 2 || cart.Items[0].Quantity != 4 {
+		t.Errorf("Items = %+v, want 2 items with zone-a quantity 4", cart.Items)
+	}
+	if cart.TotalPrice != 300 {
+		t.Errorf("TotalPrice = %v, want 300", cart.TotalPrice)
+	}
+
+	cart, err = svc.RemoveItem(ctx, "user-123", cart.Items[0].ID)
+	if err != nil || len(cart.Items) != 1 || cart.Items[0].ZoneID != "zone-b" {
+		t.Errorf("RemoveItem() = %+v, %v, want only zone-b left", cart, err)
+	}
+
+	if _, err := svc.RemoveItem(ctx, "user-123", "missing"); !errors.Is(err, domain.ErrCartItemNotFound) {
+		t.Errorf("RemoveItem(missing) error = %v, want ErrCartItemNotFound", err)
+	}
+}
+
+func TestCartService_AddItemRejectsFullCart(t *testing.T) {
+	svc := NewCartService(NewMockCartRepository(), newStubCartBookingService(), nil, nil)
+
+	for i := 0; i < domain.MaxCartItems; i++ {
+		fillCart(t, svc, string(rune('a'+i)))
+	}
+	_, err := svc.AddItem(context.Background(), "user-123", "", &dto.AddCartItemRequest{
+		EventID: "event-1", ZoneID: "zone-overflow", Quantity: 1,
+	})
+	if !errors.Is(err, domain.ErrCartFull) {
+		t.Errorf("AddItem() error = %v, want ErrCartFull", err)
+	}
+}
+
+func TestCartService_CheckoutAllOrNothing(t *testing.T) {
+	repo := NewMockCartRepository()
+	bookings := newStubCartBookingService("zone-b")
+	svc := NewCartService(repo, bookings, nil, nil)
+	ctx := context.Background()
+
+	fillCart(t, svc, "zone-a", "zone-b", "zone-c")
+
+	result, err := svc.Checkout(ctx, "user-123", &dto.CheckoutRequest{})
+	if err != nil {
+		t.Fatalf("Checkout() error = %v", err)
+	}
+	if result.Status != "failed" || result.TotalPrice != 0 {
+		t.Errorf("Checkout() = %s total %v, want failed with nothing to pay", result.Status, result.TotalPrice)
+	}
+
+	want := []domain.CheckoutItemStatus{
+		domain.CheckoutItemStatusReleased,
+		domain.CheckoutItemStatusFailed,
+		domain.CheckoutItemStatusSkipped,
+	}
+	for i, item := range result.Items {
+		if item.Status != want[i] {
+			t.Errorf("Item %s status = %s, want %s", item.ZoneID, item.Status, want[i])
+		}
+	}
+	if result.Items[1].ErrorCode != "INSUFFICIENT_SEATS" {
+		t.Errorf("ErrorCode = %q, want INSUFFICIENT_SEATS", result.Items[1].ErrorCode)
+	}
+	if len(bookings.released) != 1 || bookings.released[0] != "booking-zone-a" {
+		t.Errorf("Released = %v, want [booking-zone-a]", bookings.released)
+	}
+
+	// The cart is kept so the user can adjust it
+	cart, _ := svc.GetCart(ctx, "user-123")
+	if len(cart.Items) != 3 {
+		t.Errorf("Cart items = %d, want 3", len(cart.Items))
+	}
+}
+
+func TestCartService_CheckoutPartialAndConfirm(t *testing.T) {
+	repo := NewMockCartRepository()
+	bookings := newStubCartBookingService("zone-b")
+	svc := NewCartService(repo, bookings, nil, nil)
+	ctx := context.Background()
+
+	fillCart(t, svc, "zone-a", "zone-b", "zone-c")
+
+	result, err := svc.Checkout(ctx, "user-123", &dto.CheckoutRequest{AllowPartial: true, IdempotencyKey: "key-1"})
+	if err != nil {
+		t.Fatalf("Checkout() error = %v", err)
+	}
+	if result.Status != "partially_reserved" || result.TotalPrice != 200 {
+		t.Errorf("Checkout() = %s total %v, want partially_reserved with 200 to pay", result.Status, result.TotalPrice)
+	}
+	if len(bookings.released) != 0 {
+		t.Errorf("Released = %v, want none", bookings.released)
+	}
+
+	// Only the failed item stays in the cart
+	cart, _ := svc.GetCart(ctx, "user-123")
+	if len(cart.Items) != 1 || cart.Items[0].ZoneID != "zone-b" {
+		t.Errorf("Cart items = %+v, want only zone-b", cart.Items)
+	}
+
+	// Retrying with the same key replays the checkout
+	replay, err := svc.Checkout(ctx, "user-123", &dto.CheckoutRequest{AllowPartial: true, IdempotencyKey: "key-1"})
+	if err != nil || replay.CheckoutID != result.CheckoutID {
+		t.Errorf("Replay = %+v, %v, want checkout %s", replay, err, result.CheckoutID)
+	}
+
+	bookings.failZone = "zone-c"
+	confirmed, err := svc.ConfirmCheckout(ctx, result.CheckoutID, "user-123", &dto.ConfirmCheckoutRequest{PaymentID: "pay-1"})
+	if err != nil {
+		t.Fatalf("ConfirmCheckout() error = %v", err)
+	}
+	if confirmed.Status != "partially_confirmed" || confirmed.PaymentID != "pay-1" {
+		t.Errorf("ConfirmCheckout() = %s payment %q, want partially_confirmed with pay-1", confirmed.Status, confirmed.PaymentID)
+	}
+	if confirmed.Items[0].Status != domain.CheckoutItemStatusConfirmed || confirmed.Items[0].ConfirmationCode == "" {
+		t.Errorf("zone-a = %+v, want confirmed with a code", confirmed.Items[0])
+	}
+	if confirmed.Items[2].Status != domain.CheckoutItemStatusFailed || confirmed.Items[2].ErrorCode != "EXPIRED" {
+		t.Errorf("zone-c = %+v, want failed with EXPIRED", confirmed.Items[2])
+	}
+
+	// Nothing left to confirm or cancel, but a repeated confirm returns the outcome
+	if again, err := svc.ConfirmCheckout(ctx, result.CheckoutID, "user-123", nil); err != nil || again.Status != "partially_confirmed" {
+		t.Errorf("Repeated ConfirmCheckout() = %+v, %v", again, err)
+	}
+	if _, err := svc.CancelCheckout(ctx, result.CheckoutID, "user-123"); !errors.Is(err, domain.ErrCheckoutNotPending) {
+		t.Errorf("CancelCheckout() error = %v, want ErrCheckoutNotPending", err)
+	}
+}
+
+func TestCartService_CancelCheckout(t *testing.T) {
+	repo := NewMockCartRepository()
+	bookings := newStubCartBookingService()
+	svc := NewCartService(repo, bookings, nil, nil)
+	ctx := context.Background()
+
+	fillCart(t, svc, "zone-a", "zone-b")
+	result, err := svc.Checkout(ctx, "user-123", nil)
+	if err != nil || result.Status != "reserved" {
+		t.Fatalf("Checkout() = %+v, %v", result, err)
+	}
+
+	// Other users cannot see or cancel the checkout
+	if _, err := svc.CancelCheckout(ctx, result.CheckoutID, "user-999"); !errors.Is(err, domain.ErrCheckoutNotFound) {
+		t.Errorf("CancelCheckout() by other user error = %v, want ErrCheckoutNotFound", err)
+	}
+
+	cancelled, err := svc.CancelCheckout(ctx, result.CheckoutID, "user-123")
+	if err != nil {
+		t.Fatalf("CancelCheckout() error = %v", err)
+	}
+	if cancelled.Status != "cancelled" || len(bookings.released) != 2 {
+		t.Errorf("CancelCheckout() = %s, released %v, want cancelled with 2 releases", cancelled.Status, bookings.released)
+	}
+}
+
+func TestCartService_CheckoutEmptyCart(t *testing.T) {
+	svc := NewCartService(NewMockCartRepository(), newStubCartBookingService(), nil, nil)
+
+	if _, err := svc.Checkout(context.Background(), "user-123", nil); !errors.Is(err, domain.ErrCartEmpty) {
+		t.Errorf("Checkout() error = %v, want ErrCartEmpty", err)
+	}
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/saga"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// CompensationService manages the compensation audit trail and manual compensation
+type CompensationService interface {
+	// ListCompensations returns compensation records matching the filter
+	ListCompensations(ctx context.Context, filter *repository.CompensationFilter) ([]*domain.Compensation, error)
+
+	// TriggerCompensation manually compensates a booking (refund + release)
+	TriggerCompensation(ctx context.Context, req *dto.TriggerCompensationRequest, requestedBy string) (*domain.Compensation, error)
+}
+
+// compensationService implements CompensationService
+type compensationService struct {
+	bookingRepo      repository.BookingRepository
+	compensationRepo repository.CompensationRepository
+	producer         saga.SagaProducer
+}
+
+// NewCompensationService creates a new compensation service
+func NewCompensationService(
+	bookingRepo repository.BookingRepository,
+	compensationRepo repository.CompensationRepository,
+	producer saga.SagaProducer,
+) CompensationService {
+	return &compensationService{
+		bookingRepo:      bookingRepo,
+		compensationRepo: compensationRepo,
+		producer:         producer,
+	}
+}
+
+// ListCompensations returns compensation records matching the filter
+func (s *compensationService) ListCompensations(ctx context.Context, filter *repository.CompensationFilter) ([]*domain.Compensation, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.compensation.list")
+	defer span.End()
+
+	compensations, err := s.compensationRepo.List(ctx, filter)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("count", len(compensations)))
+	span.SetStatus(codes.Ok, "")
+	return compensations, nil
+}
+
+// TriggerCompensation manually compensates a booking.
+// Confirmed bookings with a payment are refunded and released; reserved bookings are released only.
+func (s *compensationService) TriggerCompensation(ctx context.Context, req *dto.TriggerCompensationRequest, requestedBy string) (*domain.Compensation, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.compensation.trigger")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("booking_id", req.BookingID),
+		attribute.String("saga_id", req.SagaID),
+		attribute.String("requested_by", requestedBy),
+	)
+
+	if s.producer == nil {
+		err := fmt.Errorf("saga producer is not available")
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	booking, err := s.bookingRepo.GetByID(ctx, req.BookingID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	// Determine which saga steps must be compensated (reverse order: refund before release)
+	var steps []string
+	switch booking.Status {
+	case domain.BookingStatusConfirmed:
+		if booking.PaymentID != "" {
+			steps = append(steps, saga.StepProcessPayment)
+		}
+		steps = append(steps, saga.StepReserveSeats)
+	case domain.BookingStatusReserved, domain.BookingStatusPendingPayment:
+		steps = append(steps, saga.StepReserveSeats)
+	default:
+		span.SetStatus(codes.Error, "compensation not allowed")
+		return nil, domain.ErrCompensationNotAllowed
+	}
+
+	data := &saga.BookingSagaData{
+		BookingID:     booking.ID,
+		UserID:        booking.UserID,
+		TenantID:      booking.TenantID,
+		EventID:       booking.EventID,
+		ShowID:        booking.ShowID,
+		ZoneID:        booking.ZoneID,
+		Quantity:      booking.Quantity,
+		TotalPrice:    booking.TotalPrice,
+		Currency:      booking.Currency,
+		ReservationID: booking.ID,
+		PaymentID:     booking.PaymentID,
+	}
+
+	// Manual runs without a saga are keyed by booking ID so commands stay ordered per booking
+	messageKey := req.SagaID
+	if messageKey == "" {
+		messageKey = booking.ID
+	}
+
+	record := domain.NewCompensation(req.SagaID, booking.ID, domain.CompensationTriggerManual, req.Reason)
+	record.RequestedBy = requestedBy
+
+	for i, stepName := range steps {
+		command := saga.NewCompensationCommand(
+			messageKey,
+			saga.BookingSagaName,
+			stepName,
+			i,
+			data.ToMap(),
+			req.Reason,
+		)
+		record.AddStep(stepName, saga.StepToCompensationStep(stepName), s.producer.SendCompensationCommand(ctx, command))
+	}
+	record.Finish()
+
+	log := logger.Get()
+	if record.Status != domain.CompensationStatusFailed {
+		if err := s.bookingRepo.Cancel(ctx, booking.ID); err != nil {
+			log.Warn(fmt.Sprintf("Failed to cancel booking after manual compensation: booking_id=%s, error=%v", booking.ID, err))
+		}
+	} else {
+		record.Error = "no compensation command could be dispatched"
+	}
+
+	if err := s.compensationRepo.Create(ctx, record); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	log.Info(fmt.Sprintf("Manual compensation triggered: booking_id=%s, status=%s, requested_by=%s",
+		booking.ID, record.Status, requestedBy))
+
+	span.SetAttributes(attribute.String("status", record.Status.String()))
+	span.SetStatus(codes.Ok, "")
+	return record, nil
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/saga"
+)
+
+// MockCompensationRepository is a mock implementation of CompensationRepository
+type MockCompensationRepository struct {
+	Created  []*domain.Compensation
+	ListFunc func(ctx context.Context, filter *repository.CompensationFilter) ([]*domain.Compensation, error)
+}
+
+func (m *MockCompensationRepository) Create(ctx context.Context, c *domain.Compensation) error {
+	m.Created = append(m.Created, c)
+	return nil
+}
+
+func (m *MockCompensationRepository) GetByID(ctx context.Context, id string) (*domain.Compensation, error) {
+	for _, c := range m.Created {
+		if c.ID == id {
+			return c, nil
+		}
+	}
+	return nil, domain.ErrCompensationNotFound
+}
+
+func (m *MockCompensationRepository) List(ctx context.Context, filter *repository.CompensationFilter) ([]*domain.Compensation, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, filter)
+	}
+	return m.Created, nil
+}
+
+func TestCompensationService_TriggerCompensation_ConfirmedBooking(t *testing.T) {
+	cancelled := ""
+	bookingRepo := &MockBookingRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Booking, error) {
+			return &domain.Booking{
+				ID:        id,
+				UserID:    "user-1",
+				EventID:   "event-1",
+				ZoneID:    "zone-1",
+				Quantity:  2,
+				Status:    domain.BookingStatusConfirmed,
+				PaymentID: "pay-1",
+			}, nil
+		},
+		CancelFunc: func(ctx context.Context, id string) error {
+			cancelled = id
+			return nil
+		},
+	}
+	compRepo := &MockCompensationRepository{}
+	producer := saga.NewMockSagaProducer()
+	svc := NewCompensationService(bookingRepo, compRepo, producer)
+
+	result, err := svc.TriggerCompensation(context.Background(), &dto.TriggerCompensationRequest{
+		BookingID: "booking-1",
+		Reason:    "show cancelled",
+	}, "admin-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(producer.CompensationCommands) != 2 {
+		t.Fatalf("expected 2 compensation commands, got %d", len(producer.CompensationCommands))
+	}
+	if producer.CompensationCommands[0].StepName != saga.StepProcessPayment {
+		t.Errorf("first command should refund payment, got %s", producer.CompensationCommands[0].StepName)
+	}
+	if producer.CompensationCommands[1].StepName != saga.StepReserveSeats {
+		t.Errorf("second command should release seats, got %s", producer.CompensationCommands[1].StepName)
+	}
+	if result.Status != domain.CompensationStatusCompleted {
+		t.Errorf("Status = %v, want %v", result.Status, domain.CompensationStatusCompleted)
+	}
+	if result.Trigger != domain.CompensationTriggerManual || result.RequestedBy != "admin-1" {
+		t.Errorf("unexpected trigger/requested_by: %v/%s", result.Trigger, result.RequestedBy)
+	}
+	if len(compRepo.Created) != 1 {
+		t.Errorf("expected compensation to be recorded")
+	}
+	if cancelled != "booking-1" {
+		t.Errorf("expected booking to be cancelled")
+	}
+}
+
+func TestCompensationService_TriggerCompensation_ReservedBooking(t *testing.T) {
+	bookingRepo := &MockBookingRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Booking, error) {
+			return &domain.Booking{ID: id, UserID: "user-1", Status: domain.BookingStatusReserved}, nil
+		},
+	}
+	producer := saga.NewMockSagaProducer()
+	svc := NewCompensationService(bookingRepo, &MockCompensationRepository{}, producer)
+
+	result, err := svc.TriggerCompensation(context.Background(), &dto.TriggerCompensationRequest{
+		BookingID: "booking-1",
+		Reason:    "stuck reservation",
+	}, "admin-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(producer.CompensationCommands) != 1 || producer.CompensationCommands[0].StepName != saga.StepReserveSeats {
+		t.Fatalf("expected a single release-seats compensation, got %d commands", len(producer.CompensationCommands))
+	}
+	if len(result.Steps) != 1 || result.Steps[0].Compensation != saga.StepReleaseSeats {
+		t.Errorf("unexpected steps: %+v", result.Steps)
+	}
+}
+
+func TestCompensationService_TriggerCompensation_NotAllowed(t *testing.T) {
+	bookingRepo := &MockBookingRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Booking, error) {
+			return &domain.Booking{ID: id, Status: domain.BookingStatusCancelled}, nil
+		},
+	}
+	svc := NewCompensationService(bookingRepo, &MockCompensationRepository{}, saga.NewMockSagaProducer())
+
+	_, err := svc.TriggerCompensation(context.Background(), &dto.TriggerCompensationRequest{
+		BookingID: "booking-1",
+		Reason:    "duplicate",
+	}, "admin-1")
+	if !errors.Is(err, domain.ErrCompensationNotAllowed) {
+		t.Errorf("expected ErrCompensationNotAllowed, got %v", err)
+	}
+}
+
+func TestCompensationService_TriggerCompensation_ProducerFailure(t *testing.T) {
+	bookingCancelled := false
+	bookingRepo := &MockBookingRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Booking, error) {
+			return &domain.Booking{ID: id, Status: domain.BookingStatusReserved}, nil
+		},
+		CancelFunc: func(ctx context.Context, id string) error {
+			bookingCancelled = true
+			return nil
+		},
+	}
+	producer := saga.NewMockSagaProducer()
+	producer.ShouldFail = true
+	compRepo := &MockCompensationRepository{}
+	svc := NewCompensationService(bookingRepo, compRepo, producer)
+
+	result, err := svc.TriggerCompensation(context.Background(), &dto.TriggerCompensationRequest{
+		BookingID: "booking-1",
+		Reason:    "stuck reservation",
+	}, "admin-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Status != domain.CompensationStatusFailed {
+		t.Errorf("Status = %v, want %v", result.Status, domain.CompensationStatusFailed)
+	}
+	if bookingCancelled {
+		t.Error("booking should not be cancelled when no command was dispatched")
+	}
+	if len(compRepo.Created) != 1 {
+		t.Error("failed compensation should still be recorded")
+	}
+}
+
+func TestCompensationService_TriggerCompensation_NoProducer(t *testing.T) {
+	svc := NewCompensationService(&MockBookingRepository{}, &MockCompensationRepository{}, nil)
+
+	_, err := svc.TriggerCompensation(context.Background(), &dto.TriggerCompensationRequest{
+		BookingID: "booking-1",
+		Reason:    "test",
+	}, "admin-1")
+	if err == nil {
+		t.Error("expected error when saga producer is not available")
+	}
+}
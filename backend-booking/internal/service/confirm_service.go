@@ -0,0 +1,319 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/metrics"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// AsyncConfirmService queues booking confirmations so the confirm API answers with 202
+// and a status token instead of waiting for Redis, PostgreSQL and the event publish
+type AsyncConfirmService interface {
+	// EnqueueConfirm validates the booking and queues its confirmation. Submitting again
+	// while a job is queued, running or succeeded returns that job.
+	EnqueueConfirm(ctx context.Context, bookingID, userID, tenantID string, req *dto.ConfirmBookingRequest) (*dto.ConfirmStatusResponse, error)
+
+	// GetConfirmStatus returns the state of the booking's confirmation
+	GetConfirmStatus(ctx context.Context, bookingID, userID string) (*dto.ConfirmStatusResponse, error)
+
+	// ProcessNext confirms the next queued booking, waiting up to wait for one.
+	// Returns false if the queue stayed empty.
+	ProcessNext(ctx context.Context, wait time.Duration) (bool, error)
+}
+
+// AsyncConfirmServiceConfig contains configuration for async confirm service
+type AsyncConfirmServiceConfig struct {
+	// StatusTTL is how long a job and its outcome stay queryable
+	StatusTTL time.Duration
+	// MaxAttempts bounds retries of infrastructure failures; rejections are not retried
+	MaxAttempts int
+	// StaleAfter is when a queued or running job that made no progress may be resubmitted
+	StaleAfter time.Duration
+}
+
+// asyncConfirmService implements AsyncConfirmService
+type asyncConfirmService struct {
+	bookingService BookingService
+	bookingRepo    repository.BookingRepository
+	jobRepo        repository.ConfirmJobRepository
+	webhooks       WebhookService
+	verification   VerificationGate
+	statusTTL      time.Duration
+	maxAttempts    int
+	staleAfter     time.Duration
+}
+
+// NewAsyncConfirmService creates a new async confirm service. webhooks is optional and
+// notifies tenants of rejected confirmations; successes reach them as booking.confirmed.
+// verification is optional and gates confirmations at submission, as the worker has no caller.
+func NewAsyncConfirmService(
+	bookingService BookingService,
+	bookingRepo repository.BookingRepository,
+	jobRepo repository.ConfirmJobRepository,
+	webhooks WebhookService,
+	verification VerificationGate,
+	cfg *AsyncConfirmServiceConfig,
+) AsyncConfirmService {
+	s := &asyncConfirmService{
+		bookingService: bookingService,
+		bookingRepo:    bookingRepo,
+		jobRepo:        jobRepo,
+		webhooks:       webhooks,
+		verification:   verification,
+		statusTTL:      time.Hour,
+		maxAttempts:    3,
+		staleAfter:     2 * time.Minute,
+	}
+	if cfg != nil {
+		if cfg.StatusTTL > 0 {
+			s.statusTTL = cfg.StatusTTL
+		}
+		if cfg.MaxAttempts > 0 {
+			s.maxAttempts = cfg.MaxAttempts
+		}
+		if cfg.StaleAfter > 0 {
+			s.staleAfter = cfg.StaleAfter
+		}
+	}
+	return s
+}
+
+// EnqueueConfirm validates the booking and queues its confirmation
+func (s *asyncConfirmService) EnqueueConfirm(ctx context.Context, bookingID, userID, tenantID string, req *dto.ConfirmBookingRequest) (*dto.ConfirmStatusResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.confirm.enqueue")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("booking_id", bookingID),
+		attribute.String("user_id", userID),
+	)
+
+	if bookingID == "" {
+		span.SetStatus(codes.Error, "invalid booking_id")
+		return nil, domain.ErrInvalidBookingID
+	}
+	if userID == "" {
+		span.SetStatus(codes.Error, "invalid user_id")
+		return nil, domain.ErrInvalidUserID
+	}
+
+	// Reject what the worker would reject anyway so clients learn it right away;
+	// a primary key lookup keeps the latency flat
+	booking, err := s.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if !booking.BelongsToUser(userID) {
+		span.SetStatus(codes.Error, "invalid user")
+		return nil, domain.ErrInvalidUserID
+	}
+	if booking.IsCancelled() {
+		span.SetStatus(codes.Error, "already released")
+		return nil, domain.ErrAlreadyReleased
+	}
+	if booking.Status == domain.BookingStatusExpired || (booking.IsAwaitingPayment() && booking.IsExpired()) {
+		span.SetStatus(codes.Error, "booking expired")
+		return nil, domain.ErrBookingExpired
+	}
+	if s.verification != nil && req != nil {
+		if err := s.verification.Check(ctx, booking.EventID, req.Verification); err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+	}
+
+	existing, err := s.jobRepo.GetByBookingID(ctx, bookingID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if existing != nil && existing.UserID == userID &&
+		existing.Status != domain.ConfirmJobStatusFailed && !existing.IsStale(s.staleAfter) {
+		span.SetAttributes(attribute.String("job_status", existing.Status.String()))
+		span.SetStatus(codes.Ok, "")
+		return dto.ConfirmStatusFromDomain(existing), nil
+	}
+	// Confirmed synchronously or by an expired job
+	if booking.IsConfirmed() {
+		span.SetStatus(codes.Error, "already confirmed")
+		return nil, domain.ErrAlreadyConfirmed
+	}
+
+	now := time.Now()
+	job := &domain.ConfirmJob{
+		Token:     uuid.New().String(),
+		BookingID: bookingID,
+		UserID:    userID,
+		TenantID:  tenantID,
+		Status:    domain.ConfirmJobStatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if req != nil {
+		job.PaymentID = req.PaymentID
+	}
+
+	if existing != nil {
+		// Failed or stale: replace it with a fresh attempt
+		err = s.jobRepo.Requeue(ctx, job, s.statusTTL)
+	} else {
+		var created bool
+		created, existing, err = s.jobRepo.Create(ctx, job, s.statusTTL)
+		if err == nil && !created {
+			// Lost the race against a concurrent submit
+			span.SetStatus(codes.Ok, "")
+			return dto.ConfirmStatusFromDomain(existing), nil
+		}
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	metrics.RecordAsyncConfirm(ctx, "queued")
+	span.SetAttributes(attribute.String("status_token", job.Token))
+	span.SetStatus(codes.Ok, "")
+	return dto.ConfirmStatusFromDomain(job), nil
+}
+
+// GetConfirmStatus returns the state of the booking's confirmation
+func (s *asyncConfirmService) GetConfirmStatus(ctx context.Context, bookingID, userID string) (*dto.ConfirmStatusResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.confirm.get_status")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("booking_id", bookingID),
+		attribute.String("user_id", userID),
+	)
+
+	job, err := s.jobRepo.GetByBookingID(ctx, bookingID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	// Do not reveal other users' bookings
+	if job == nil || job.UserID != userID {
+		span.SetStatus(codes.Error, "confirm job not found")
+		return nil, domain.ErrConfirmJobNotFound
+	}
+
+	span.SetAttributes(attribute.String("job_status", job.Status.String()))
+	span.SetStatus(codes.Ok, "")
+	return dto.ConfirmStatusFromDomain(job), nil
+}
+
+// ProcessNext confirms the next queued booking, waiting up to wait for one
+func (s *asyncConfirmService) ProcessNext(ctx context.Context, wait time.Duration) (bool, error) {
+	job, err := s.jobRepo.Dequeue(ctx, wait)
+	if err != nil || job == nil {
+		return false, err
+	}
+
+	ctx, span := telemetry.StartSpan(ctx, "service.confirm.process")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("booking_id", job.BookingID),
+		attribute.String("status_token", job.Token),
+		attribute.Int("attempt", job.Attempts+1),
+	)
+
+	// A resubmitted job replaced this one while it was queued twice; the newer entry wins
+	if job.IsTerminal() {
+		span.SetStatus(codes.Ok, "already done")
+		return true, nil
+	}
+
+	job.Status = domain.ConfirmJobStatusProcessing
+	job.Attempts++
+	job.UpdatedAt = time.Now()
+	if err := s.jobRepo.Save(ctx, job, s.statusTTL); err != nil {
+		span.RecordError(err)
+	}
+
+	result, err := s.bookingService.ConfirmBooking(ctx, job.BookingID, job.UserID, &dto.ConfirmBookingRequest{
+		PaymentID: job.PaymentID,
+	})
+	switch {
+	case err == nil:
+		job.Status = domain.ConfirmJobStatusSucceeded
+		job.ConfirmationCode = result.ConfirmationCode
+		confirmedAt := result.ConfirmedAt
+		job.ConfirmedAt = &confirmedAt
+	case errors.Is(err, domain.ErrAlreadyConfirmed):
+		// Redelivered after a crash, or confirmed synchronously meanwhile
+		job.Status = domain.ConfirmJobStatusSucceeded
+		if booking, getErr := s.bookingRepo.GetByID(ctx, job.BookingID); getErr == nil {
+			job.ConfirmationCode = booking.ConfirmationCode
+			job.ConfirmedAt = booking.ConfirmedAt
+		}
+	case isConfirmRejection(err) || job.Attempts >= s.maxAttempts:
+		job.Status = domain.ConfirmJobStatusFailed
+		job.ErrorCode = bookingErrorCode(err)
+		job.ErrorMessage = err.Error()
+	default:
+		// Infrastructure failure; try again later
+		span.RecordError(err)
+		job.Status = domain.ConfirmJobStatusPending
+		job.UpdatedAt = time.Now()
+		if requeueErr := s.jobRepo.Requeue(ctx, job, s.statusTTL); requeueErr != nil {
+			span.RecordError(requeueErr)
+			span.SetStatus(codes.Error, requeueErr.Error())
+			return true, requeueErr
+		}
+		metrics.RecordAsyncConfirm(ctx, "retried")
+		span.SetStatus(codes.Error, err.Error())
+		return true, nil
+	}
+
+	job.UpdatedAt = time.Now()
+	if err := s.jobRepo.Save(ctx, job, s.statusTTL); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return true, err
+	}
+
+	metrics.RecordAsyncConfirm(ctx, job.Status.String())
+	span.SetAttributes(attribute.String("job_status", job.Status.String()))
+
+	if job.Status == domain.ConfirmJobStatusFailed {
+		s.notifyFailure(ctx, job)
+	}
+	span.SetStatus(codes.Ok, "")
+	return true, nil
+}
+
+// notifyFailure sends booking.confirm_failed to the tenant's webhook subscriptions
+func (s *asyncConfirmService) notifyFailure(ctx context.Context, job *domain.ConfirmJob) {
+	if s.webhooks == nil || job.TenantID == "" {
+		return
+	}
+	data := dto.ConfirmStatusFromDomain(job)
+	if _, err := s.webhooks.EnqueueEvent(ctx, job.TenantID, domain.WebhookEventBookingConfirmFailed,
+		job.Token, job.UpdatedAt, data); err != nil {
+		logger.Get().Warn("failed to enqueue confirm_failed webhook for booking " + job.BookingID + ": " + err.Error())
+	}
+}
+
+// isConfirmRejection reports errors that retrying cannot fix
+func isConfirmRejection(err error) bool {
+	return domain.IsNotFoundError(err) ||
+		domain.IsValidationError(err) ||
+		domain.IsConflictError(err) ||
+		domain.IsExpiredError(err)
+}
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+)
+
+// MockConfirmJobRepository is an in-memory implementation of ConfirmJobRepository
+type MockConfirmJobRepository struct {
+	jobs  map[string]*domain.ConfirmJob
+	queue []string
+}
+
+func NewMockConfirmJobRepository() *MockConfirmJobRepository {
+	return &MockConfirmJobRepository{jobs: make(map[string]*domain.ConfirmJob)}
+}
+
+func (m *MockConfirmJobRepository) Create(ctx context.Context, job *domain.ConfirmJob, ttl time.Duration) (bool, *domain.ConfirmJob, error) {
+	if existing, ok := m.jobs[job.BookingID]; ok {
+		copied := *existing
+		return false, &copied, nil
+	}
+	return true, nil, m.Requeue(ctx, job, ttl)
+}
+
+func (m *MockConfirmJobRepository) Requeue(ctx context.Context, job *domain.ConfirmJob, ttl time.Duration) error {
+	copied := *job
+	m.jobs[job.BookingID] = &copied
+	m.queue = append(m.queue, job.BookingID)
+	return nil
+}
+
+func (m *MockConfirmJobRepository) Dequeue(ctx context.Context, wait time.Duration) (*domain.ConfirmJob, error) {
+	if len(m.queue) == 0 {
+		return nil, nil
+	}
+	bookingID := m.queue[0]
+	m.queue = m.queue[1:]
+	return m.GetByBookingID(ctx, bookingID)
+}
+
+func (m *MockConfirmJobRepository) Save(ctx context.Context, job *domain.ConfirmJob, ttl time.Duration) error {
+	copied := *job
+	m.jobs[job.BookingID] = &copied
+	return nil
+}
+
+func (m *MockConfirmJobRepository) GetByBookingID(ctx context.Context, bookingID string) (*domain.ConfirmJob, error) {
+	job, ok := m.jobs[bookingID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *job
+	return &copied, nil
+}
+
+// stubConfirmBookingService confirms bookings through ConfirmFunc
+type stubConfirmBookingService struct {
+	BookingService
+	ConfirmFunc func(ctx context.Context, bookingID, userID string, req *dto.ConfirmBookingRequest) (*dto.ConfirmBookingResponse, error)
+}
+
+func (s *stubConfirmBookingService) ConfirmBooking(ctx context.Context, bookingID, userID string, req *dto.ConfirmBookingRequest) (*dto.ConfirmBookingResponse, error) {
+	return s.ConfirmFunc(ctx, bookingID, userID, req)
+}
+
+// recordingWebhookService records enqueued webhook events
+type recordingWebhookService struct {
+	WebhookService
+	events []domain.WebhookEventType
+}
+
+func (s *recordingWebhookService) EnqueueEvent(ctx context.Context, tenantID string, eventType domain.WebhookEventType, eventID string, occurredAt time.Time, data interface{}) (int, error) {
+	s.events = append(s.events, eventType)
+	return 1, nil
+}
+
+func newReservedBookingRepo() *MockBookingRepository {
+	return &MockBookingRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Booking, error) {
+			return &domain.Booking{
+				ID:        id,
+				UserID:    "user-123",
+				Status:    domain.BookingStatusReserved,
+				ExpiresAt: time.Now().Add(10 * time.Minute),
+			}, nil
+		},
+	}
+}
+
+func TestAsyncConfirmService_EnqueueAndProcess(t *testing.T) {
+	jobRepo := NewMockConfirmJobRepository()
+	calls := 0
+	bookingService := &stubConfirmBookingService{
+		ConfirmFunc: func(ctx context.Context, bookingID, userID string, req *dto.ConfirmBookingRequest) (*dto.ConfirmBookingResponse, error) {
+			calls++
+			if req.PaymentID != "pay-1" {
+				t.Errorf("PaymentID = %q, want pay-1", req.PaymentID)
+			}
+			return &dto.ConfirmBookingResponse{
+				BookingID:        bookingID,
+				Status:           "confirmed",
+				ConfirmedAt:      time.Now(),
+				ConfirmationCode: "ABC12345",
+			}, nil
+		},
+	}
+	svc := NewAsyncConfirmService(bookingService, newReservedBookingRepo(), jobRepo, nil, nil, nil)
+	ctx := context.Background()
+
+	status, err := svc.EnqueueConfirm(ctx, "booking-1", "user-123", "tenant-1", &dto.ConfirmBookingRequest{PaymentID: "pay-1"})
+	if err != nil {
+		t.Fatalf("EnqueueConfirm() error = %v", err)
+	}
+	if status.Status != "pending" || status.StatusToken == "" {
+		t.Fatalf("EnqueueConfirm() = %+v, want pending with a token", status)
+	}
+	if status.StatusURL != "/api/v1/bookings/booking-1/confirm-status" {
+		t.Errorf("StatusURL = %q", status.StatusURL)
+	}
+
+	// Resubmitting while queued returns the same job without queueing it again
+	again, err := svc.EnqueueConfirm(ctx, "booking-1", "user-123", "tenant-1", nil)
+	if err != nil || again.StatusToken != status.StatusToken {
+		t.Errorf("Resubmit = %+v, %v, want the queued job", again, err)
+	}
+	if len(jobRepo.queue) != 1 {
+		t.Errorf("Queue length = %d, want 1", len(jobRepo.queue))
+	}
+
+	processed, err := svc.ProcessNext(ctx, time.Second)
+	if !processed || err != nil {
+		t.Fatalf("ProcessNext() = %v, %v", processed, err)
+	}
+
+	got, err := svc.GetConfirmStatus(ctx, "booking-1", "user-123")
+	if err != nil {
+		t.Fatalf("GetConfirmStatus() error = %v", err)
+	}
+	if got.Status != "succeeded" || got.ConfirmationCode != "ABC12345" || got.ConfirmedAt == nil {
+		t.Errorf("GetConfirmStatus() = %+v, want succeeded with confirmation", got)
+	}
+	if calls != 1 {
+		t.Errorf("ConfirmBooking calls = %d, want 1", calls)
+	}
+
+	// Queue drained
+	if processed, _ := svc.ProcessNext(ctx, time.Second); processed {
+		t.Error("Expected empty queue")
+	}
+}
+
+func TestAsyncConfirmService_EnqueueRejectsSettledBookings(t *testing.T) {
+	tests := []struct {
+		name    string
+		booking *domain.Booking
+		wantErr error
+	}{
+		{
+			name:    "other user",
+			booking: &domain.Booking{UserID: "user-999", Status: domain.BookingStatusReserved, ExpiresAt: time.Now().Add(time.Minute)},
+			wantErr: domain.ErrInvalidUserID,
+		},
+		{
+			name:    "already confirmed",
+			booking: &domain.Booking{UserID: "user-123", Status: domain.BookingStatusConfirmed},
+			wantErr: domain.ErrAlreadyConfirmed,
+		},
+		{
+			name:    "cancelled",
+			booking: &domain.Booking{UserID: "user-123", Status: domain.BookingStatusCancelled},
+			wantErr: domain.ErrAlreadyReleased,
+		},
+		{
+			name:    "expired",
+			booking: &domain.Booking{UserID: "user-123", Status: domain.BookingStatusReserved, ExpiresAt: time.Now().Add(-time.Minute)},
+			wantErr: domain.ErrBookingExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bookingRepo := &MockBookingRepository{
+				GetByIDFunc: func(ctx context.Context, id string) (*domain.Booking, error) {
+					return tt.booking, nil
+				},
+			}
+			jobRepo := NewMockConfirmJobRepository()
+			svc := NewAsyncConfirmService(&stubConfirmBookingService{}, bookingRepo, jobRepo, nil, nil, nil)
+
+			_, err := svc.EnqueueConfirm(context.Background(), "booking-1", "user-123", "", nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("EnqueueConfirm() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(jobRepo.queue) != 0 {
+				t.Error("Expected nothing queued")
+			}
+		})
+	}
+}
+
+func TestAsyncConfirmService_ProcessFailures(t *testing.T) {
+	t.Run("rejection fails the job and notifies the tenant", func(t *testing.T) {
+		jobRepo := NewMockConfirmJobRepository()
+		webhooks := &recordingWebhookService{}
+		bookingService := &stubConfirmBookingService{
+			ConfirmFunc: func(ctx context.Context, bookingID, userID string, req *dto.ConfirmBookingRequest) (*dto.ConfirmBookingResponse, error) {
+				return nil, domain.ErrReservationExpired
+			},
+		}
+		svc := NewAsyncConfirmService(bookingService, newReservedBookingRepo(), jobRepo, webhooks, nil, nil)
+		ctx := context.Background()
+
+		if _, err := svc.EnqueueConfirm(ctx, "booking-1", "user-123", "tenant-1", nil); err != nil {
+			t.Fatalf("EnqueueConfirm() error = %v", err)
+		}
+		if _, err := svc.ProcessNext(ctx, time.Second); err != nil {
+			t.Fatalf("ProcessNext() error = %v", err)
+		}
+
+		got, _ := svc.GetConfirmStatus(ctx, "booking-1", "user-123")
+		if got.Status != "failed" || got.ErrorCode != "EXPIRED" {
+			t.Errorf("Status = %+v, want failed with EXPIRED", got)
+		}
+		if len(webhooks.events) != 1 || webhooks.events[0] != domain.WebhookEventBookingConfirmFailed {
+			t.Errorf("Webhook events = %v, want [booking.confirm_failed]", webhooks.events)
+		}
+
+		// A failed job can be submitted again
+		status, err := svc.EnqueueConfirm(ctx, "booking-1", "user-123", "tenant-1", nil)
+		if err != nil || status.Status != "pending" || status.StatusToken == got.StatusToken {
+			t.Errorf("Resubmit = %+v, %v, want a new pending job", status, err)
+		}
+	})
+
+	t.Run("transient errors retry until max attempts", func(t *testing.T) {
+		jobRepo := NewMockConfirmJobRepository()
+		calls := 0
+		bookingService := &stubConfirmBookingService{
+			ConfirmFunc: func(ctx context.Context, bookingID, userID string, req *dto.ConfirmBookingRequest) (*dto.ConfirmBookingResponse, error) {
+				calls++
+				return nil, errors.New("redis: connection refused")
+			},
+		}
+		svc := NewAsyncConfirmService(bookingService, newReservedBookingRepo(), jobRepo, nil, nil, &AsyncConfirmServiceConfig{MaxAttempts: 2})
+		ctx := context.Background()
+
+		svc.EnqueueConfirm(ctx, "booking-1", "user-123", "", nil)
+		for i := 0; i < 5; i++ {
+			svc.ProcessNext(ctx, time.Second)
+		}
+
+		if calls != 2 {
+			t.Errorf("ConfirmBooking calls = %d, want 2", calls)
+		}
+		got, _ := svc.GetConfirmStatus(ctx, "booking-1", "user-123")
+		if got.Status != "failed" || got.ErrorCode != "INTERNAL_ERROR" {
+			t.Errorf("Status = %+v, want failed with INTERNAL_ERROR", got)
+		}
+	})
+
+	t.Run("already confirmed counts as success", func(t *testing.T) {
+		jobRepo := NewMockConfirmJobRepository()
+		confirmedAt := time.Now()
+		bookingRepo := newReservedBookingRepo()
+		bookingService := &stubConfirmBookingService{
+			ConfirmFunc: func(ctx context.Context, bookingID, userID string, req *dto.ConfirmBookingRequest) (*dto.ConfirmBookingResponse, error) {
+				bookingRepo.GetByIDFunc = func(ctx context.Context, id string) (*domain.Booking, error) {
+					return &domain.Booking{ID: id, UserID: userID, Status: domain.BookingStatusConfirmed,
+						ConfirmationCode: "XYZ98765", ConfirmedAt: &confirmedAt}, nil
+				}
+				return nil, domain.ErrAlreadyConfirmed
+			},
+		}
+		svc := NewAsyncConfirmService(bookingService, bookingRepo, jobRepo, nil, nil, nil)
+		ctx := context.Background()
+
+		svc.EnqueueConfirm(ctx, "booking-1", "user-123", "", nil)
+		svc.ProcessNext(ctx, time.Second)
+
+		got, _ := svc.GetConfirmStatus(ctx, "booking-1", "user-123")
+		if got.Status != "succeeded" || got.ConfirmationCode != "XYZ98765" {
+			t.Errorf("Status = %+v, want succeeded with the booking's code", got)
+		}
+	})
+}
+
+func TestAsyncConfirmService_GetConfirmStatusHidesOtherUsers(t *testing.T) {
+	jobRepo := NewMockConfirmJobRepository()
+	svc := NewAsyncConfirmService(&stubConfirmBookingService{}, newReservedBookingRepo(), jobRepo, nil, nil, nil)
+	ctx := context.Background()
+
+	if _, err := svc.GetConfirmStatus(ctx, "booking-1", "user-123"); !errors.Is(err, domain.ErrConfirmJobNotFound) {
+		t.Errorf("GetConfirmStatus() without job error = %v, want ErrConfirmJobNotFound", err)
+	}
+
+	svc.EnqueueConfirm(ctx, "booking-1", "user-123", "", nil)
+	if _, err := svc.GetConfirmStatus(ctx, "booking-1", "user-999"); !errors.Is(err, domain.ErrConfirmJobNotFound) {
+		t.Errorf("GetConfirmStatus() for other user error = %v, want ErrConfirmJobNotFound", err)
+	}
+}
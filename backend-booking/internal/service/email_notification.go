@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/i18n"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/tenantconfig"
+)
+
+// DefaultEmailFromAddress and DefaultEmailFromName are the sender of emails from
+// tenants without a verified sender of their own
+const (
+	DefaultEmailFromAddress = "noreply@bookingrush.app"
+	DefaultEmailFromName    = "Booking Rush"
+)
+
+// TenantSettingsSource reads tenant settings; *tenantconfig.Client implements it
+type TenantSettingsSource interface {
+	Get(ctx context.Context, tenantID string) (*tenantconfig.Settings, error)
+}
+
+// Email is a notification email rendered for delivery, sent and branded as its tenant
+type Email struct {
+	From         string             `json:"from"`
+	FromName     string             `json:"from_name,omitempty"`
+	ReplyTo      string             `json:"reply_to,omitempty"`
+	LogoURL      string             `json:"logo_url,omitempty"`
+	PrimaryColor string             `json:"primary_color,omitempty"`
+	Notification *i18n.Notification `json:"notification"`
+}
+
+// RenderEmail renders an i18n template in locale as an email from identity
+func RenderEmail(identity tenantconfig.EmailIdentity, locale i18n.Locale, template string, params map[string]string) *Email {
+	return &Email{
+		From:         identity.FromAddress,
+		FromName:     identity.FromName,
+		ReplyTo:      identity.ReplyTo,
+		LogoURL:      identity.LogoURL,
+		PrimaryColor: identity.PrimaryColor,
+		Notification: i18n.RenderNotification(locale, template, params),
+	}
+}
+
+// NewBookingConfirmedEmail renders the confirmation email of a booking
+func NewBookingConfirmedEmail(identity tenantconfig.EmailIdentity, confirmationCode string) *Email {
+	return RenderEmail(identity, i18n.DefaultLocale, i18n.TemplateBookingConfirmed, map[string]string{"confirmation_code": confirmationCode})
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/tenantconfig"
+)
+
+func TestNewBookingConfirmedEmail(t *testing.T) {
+	identity := tenantconfig.EmailIdentity{
+		FromAddress:  "tickets@concerts.example.com",
+		FromName:     "Concerts Co",
+		ReplyTo:      "help@concerts.example.com",
+		LogoURL:      "https://cdn.example.com/logo.png",
+		PrimaryColor: "#ff0000",
+	}
+
+	email := NewBookingConfirmedEmail(identity, "BK-ABC123")
+
+	if email.From != identity.FromAddress || email.FromName != identity.FromName || email.ReplyTo != identity.ReplyTo {
+		t.Errorf("Expected the tenant's sender, got %+v", email)
+	}
+	if email.LogoURL != identity.LogoURL || email.PrimaryColor != identity.PrimaryColor {
+		t.Errorf("Expected the tenant's branding, got %+v", email)
+	}
+	if email.Notification == nil || !strings.Contains(email.Notification.Body, "BK-ABC123") {
+		t.Errorf("Expected the confirmation code in the body, got %+v", email.Notification)
+	}
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// bookingErrorCode maps a booking error to the code the booking API responds with, for
+// outcomes reported in a response body instead of the HTTP status
+func bookingErrorCode(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrZoneNotFound):
+		return "ZONE_NOT_FOUND"
+	case domain.IsNotFoundError(err):
+		return "NOT_FOUND"
+	case errors.Is(err, domain.ErrInvalidUserID):
+		return "FORBIDDEN"
+	case errors.Is(err, domain.ErrInsufficientSeats):
+		return "INSUFFICIENT_SEATS"
+	case errors.Is(err, domain.ErrMaxTicketsExceeded):
+		return "MAX_TICKETS_EXCEEDED"
+	case errors.Is(err, domain.ErrNoContiguousSeats):
+		return "NO_CONTIGUOUS_SEATS"
+	case errors.Is(err, domain.ErrSeatAllocationConflict):
+		return "SEAT_ALLOCATION_CONFLICT"
+	case errors.Is(err, domain.ErrAddOnNotFound),
+		errors.Is(err, domain.ErrInvalidAddOnQuantity),
+		errors.Is(err, domain.ErrAddOnLimitExceeded):
+		return "INVALID_ADD_ON"
+	case errors.Is(err, domain.ErrAddOnSoldOut):
+		return "ADD_ON_SOLD_OUT"
+	case errors.Is(err, domain.ErrAlreadyConfirmed):
+		return "ALREADY_CONFIRMED"
+	case errors.Is(err, domain.ErrAlreadyReleased):
+		return "ALREADY_RELEASED"
+	case domain.IsExpiredError(err):
+		return "EXPIRED"
+	case errors.Is(err, domain.ErrQueuePassRequired):
+		return "QUEUE_PASS_REQUIRED"
+	case errors.Is(err, domain.ErrInvalidQueuePass):
+		return "INVALID_QUEUE_PASS"
+	case errors.Is(err, domain.ErrQueuePassExpired):
+		return "QUEUE_PASS_EXPIRED"
+	case errors.Is(err, domain.ErrQueuePassUserMismatch),
+		errors.Is(err, domain.ErrQueuePassEventMismatch),
+		errors.Is(err, domain.ErrQueuePassZoneMismatch):
+		return "QUEUE_PASS_MISMATCH"
+	case errors.Is(err, domain.ErrQueuePassRevoked):
+		return "QUEUE_PASS_REVOKED"
+	case errors.Is(err, domain.ErrAccountNotVerified):
+		return "ACCOUNT_NOT_VERIFIED"
+	case errors.Is(err, domain.ErrVerificationChallengeRequired):
+		return "VERIFICATION_CHALLENGE_REQUIRED"
+	case domain.IsValidationError(err):
+		return "INVALID_REQUEST"
+	case domain.IsConflictError(err):
+		return "CONFLICT"
+	default:
+		return "INTERNAL_ERROR"
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/events"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
 	"go.uber.org/zap"
 )
@@ -26,6 +27,9 @@ type EventPublisher interface {
 	// PublishBookingExpired publishes a booking expired event
 	PublishBookingExpired(ctx context.Context, booking *domain.Booking) error
 
+	// PublishBookingModified publishes a booking modified event; previous is the booking before the change
+	PublishBookingModified(ctx context.Context, booking, previous *domain.Booking) error
+
 	// Close closes the event publisher
 	Close() error
 }
@@ -65,7 +69,7 @@ func NewKafkaEventPublisher(ctx context.Context, cfg *EventPublisherConfig) (*Ka
 
 	topic := cfg.Topic
 	if topic == "" {
-		topic = "booking-events"
+		topic = events.TopicBookingEvents
 	}
 
 	serviceName := cfg.ServiceName
@@ -118,6 +122,15 @@ func (p *KafkaEventPublisher) PublishBookingExpired(ctx context.Context, booking
 	return p.publishEvent(ctx, domain.BookingEventExpired, booking)
 }
 
+// PublishBookingModified publishes a booking modified event
+func (p *KafkaEventPublisher) PublishBookingModified(ctx context.Context, booking, previous *domain.Booking) error {
+	event, err := domain.NewBookingModifiedEvent(booking, previous, uuid.New().String())
+	if err != nil {
+		return err
+	}
+	return p.publish(event)
+}
+
 // Close closes the event publisher
 func (p *KafkaEventPublisher) Close() error {
 	if p.producer != nil {
@@ -128,8 +141,17 @@ func (p *KafkaEventPublisher) Close() error {
 
 // publishEvent publishes a booking event to Kafka asynchronously (fire-and-forget with logging)
 func (p *KafkaEventPublisher) publishEvent(ctx context.Context, eventType domain.BookingEventType, booking *domain.Booking) error {
-	eventID := uuid.New().String()
-	event := domain.NewBookingEvent(eventType, booking, eventID)
+	event, err := domain.NewBookingEvent(eventType, booking, uuid.New().String())
+	if err != nil {
+		return err
+	}
+	return p.publish(event)
+}
+
+// publish sends a booking event to Kafka asynchronously
+func (p *KafkaEventPublisher) publish(event *domain.BookingEvent) error {
+	eventType := event.EventType
+	eventID := event.EventID
 
 	value, err := json.Marshal(event)
 	if err != nil {
@@ -156,7 +178,7 @@ func (p *KafkaEventPublisher) publishEvent(ctx context.Context, eventType domain
 	// Error handling via callback - log but don't fail the request
 	p.producer.ProduceAsync(context.Background(), msg, func(err error) {
 		if err != nil && p.logger != nil {
-			p.logger.Error(fmt.Sprintf("failed to publish %s event for booking %s: %v", eventType, booking.ID, err))
+			p.logger.Error(fmt.Sprintf("failed to publish %s event for booking %s: %v", eventType, event.Key(), err))
 		}
 	})
 
@@ -221,6 +243,11 @@ func (p *NoOpEventPublisher) PublishBookingExpired(ctx context.Context, booking
 	return nil
 }
 
+// PublishBookingModified is a no-op
+func (p *NoOpEventPublisher) PublishBookingModified(ctx context.Context, booking, previous *domain.Booking) error {
+	return nil
+}
+
 // Close is a no-op
 func (p *NoOpEventPublisher) Close() error {
 	return nil
@@ -16,6 +16,7 @@ type MockEventPublisher struct {
 	confirmedEvents       []*domain.Booking
 	cancelledEvents       []*domain.Booking
 	expiredEvents         []*domain.Booking
+	modifiedEvents        []*domain.Booking
 	publishCreatedError   error
 	publishConfirmedError error
 	publishCancelledError error
@@ -71,6 +72,13 @@ func (m *MockEventPublisher) PublishBookingExpired(ctx context.Context, booking
 	return nil
 }
 
+func (m *MockEventPublisher) PublishBookingModified(ctx context.Context, booking, previous *domain.Booking) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.modifiedEvents = append(m.modifiedEvents, booking)
+	return nil
+}
+
 func (m *MockEventPublisher) Close() error {
 	return nil
 }
@@ -93,6 +101,12 @@ func (m *MockEventPublisher) GetCancelledEvents() []*domain.Booking {
 	return m.cancelledEvents
 }
 
+func (m *MockEventPublisher) GetModifiedEvents() []*domain.Booking {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.modifiedEvents
+}
+
 func (m *MockEventPublisher) GetExpiredEvents() []*domain.Booking {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -229,7 +243,10 @@ func TestBookingEvent(t *testing.T) {
 	}
 
 	t.Run("NewBookingEvent creates event with correct data", func(t *testing.T) {
-		event := domain.NewBookingEvent(domain.BookingEventCreated, booking, "event-id-123")
+		event, err := domain.NewBookingEvent(domain.BookingEventCreated, booking, "event-id-123")
+		if err != nil {
+			t.Fatalf("NewBookingEvent() error = %v", err)
+		}
 
 		if event.EventID != "event-id-123" {
 			t.Errorf("expected event ID 'event-id-123', got %s", event.EventID)
@@ -258,14 +275,20 @@ func TestBookingEvent(t *testing.T) {
 	})
 
 	t.Run("Event Topic returns correct topic", func(t *testing.T) {
-		event := domain.NewBookingEvent(domain.BookingEventCreated, booking, "event-id-123")
+		event, err := domain.NewBookingEvent(domain.BookingEventCreated, booking, "event-id-123")
+		if err != nil {
+			t.Fatalf("NewBookingEvent() error = %v", err)
+		}
 		if event.Topic() != "booking-events" {
 			t.Errorf("expected topic 'booking-events', got %s", event.Topic())
 		}
 	})
 
 	t.Run("Event Key returns booking ID", func(t *testing.T) {
-		event := domain.NewBookingEvent(domain.BookingEventCreated, booking, "event-id-123")
+		event, err := domain.NewBookingEvent(domain.BookingEventCreated, booking, "event-id-123")
+		if err != nil {
+			t.Fatalf("NewBookingEvent() error = %v", err)
+		}
 		if event.Key() != booking.ID {
 			t.Errorf("expected key %s, got %s", booking.ID, event.Key())
 		}
@@ -0,0 +1,326 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// IdleReservationService frees inventory held by customers who reserved but never started
+// paying. Their holders are reminded with a signed link that releases the seats in one
+// click, well before the hold would expire on its own.
+type IdleReservationService interface {
+	// RemindIdle reminds the holders of reservations without a payment for the idle
+	// period, reading up to limit reservations at a time. Returns the number of holders reminded.
+	RemindIdle(ctx context.Context, limit int) (int, error)
+
+	// RecordPaymentStarted records that a payment was created for the booking, so its
+	// holder is not reminded and its release link stops working
+	RecordPaymentStarted(ctx context.Context, bookingID string) error
+
+	// PreviewReleaseLink returns the reservation the token from a reminder would release,
+	// with the errors of ReleaseWithLink, without releasing it
+	PreviewReleaseLink(ctx context.Context, token string) (*dto.ReleaseLinkPreview, error)
+
+	// ReleaseWithLink releases the reservation of the token from a reminder
+	ReleaseWithLink(ctx context.Context, token string) (*dto.ReleaseBookingResponse, error)
+}
+
+// BookingReleaser releases a reservation on behalf of its holder
+type BookingReleaser interface {
+	ReleaseBooking(ctx context.Context, bookingID, userID string) (*dto.ReleaseBookingResponse, error)
+}
+
+// IdleReservationServiceConfig contains configuration for idle reservation service
+type IdleReservationServiceConfig struct {
+	// IdleAfter is how long a reservation goes without a payment before its holder is reminded
+	IdleAfter time.Duration
+	// MinRemaining skips holds expiring sooner than this; they are freed soon enough anyway
+	MinRemaining time.Duration
+	// PaymentMarkTTL is how long a started payment is remembered; it should outlive the
+	// longest hold, including the grace of redirect-based payments
+	PaymentMarkTTL time.Duration
+	// Secret signs release links
+	Secret string
+	// ReleaseURL is the public release endpoint linked from reminders;
+	// the token is added as the token query parameter
+	ReleaseURL string
+}
+
+// idleReservationService implements IdleReservationService
+type idleReservationService struct {
+	bookingRepo    repository.BookingRepository
+	idleRepo       repository.IdleReservationRepository
+	releaser       BookingReleaser
+	notifier       PushNotifier
+	idleAfter      time.Duration
+	minRemaining   time.Duration
+	paymentMarkTTL time.Duration
+	secret         string
+	releaseURL     string
+	now            func() time.Time
+
+	// cursor is the reserved_at of the last reservation read; reservations reserved
+	// before it were already considered
+	mu     sync.Mutex
+	cursor time.Time
+}
+
+// NewIdleReservationService creates a new idle reservation service.
+// notifier is only needed by RemindIdle and releaser only by ReleaseWithLink.
+// RemindIdle needs a bookingRepo that implements repository.IdleReservationFinder.
+func NewIdleReservationService(
+	bookingRepo repository.BookingRepository,
+	idleRepo repository.IdleReservationRepository,
+	releaser BookingReleaser,
+	notifier PushNotifier,
+	cfg *IdleReservationServiceConfig,
+) IdleReservationService {
+	s := &idleReservationService{
+		bookingRepo:    bookingRepo,
+		idleRepo:       idleRepo,
+		releaser:       releaser,
+		notifier:       notifier,
+		idleAfter:      3 * time.Minute,
+		minRemaining:   time.Minute,
+		paymentMarkTTL: 2 * time.Hour,
+		releaseURL:     "/api/v1/bookings/release-link",
+		now:            time.Now,
+	}
+	if cfg != nil {
+		if cfg.IdleAfter > 0 {
+			s.idleAfter = cfg.IdleAfter
+		}
+		if cfg.MinRemaining > 0 {
+			s.minRemaining = cfg.MinRemaining
+		}
+		if cfg.PaymentMarkTTL > 0 {
+			s.paymentMarkTTL = cfg.PaymentMarkTTL
+		}
+		if cfg.ReleaseURL != "" {
+			s.releaseURL = cfg.ReleaseURL
+		}
+		s.secret = cfg.Secret
+	}
+	return s
+}
+
+// RemindIdle reminds the holders of idle reservations
+func (s *idleReservationService) RemindIdle(ctx context.Context, limit int) (int, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.idle_reservation.remind")
+	defer span.End()
+
+	finder, ok := s.bookingRepo.(repository.IdleReservationFinder)
+	if !ok || s.notifier == nil || s.secret == "" {
+		err := fmt.Errorf("idle reservation reminders are not configured")
+		span.SetStatus(codes.Error, err.Error())
+		return 0, err
+	}
+	if limit <= 0 {
+		limit = 100
+	}
+
+	// Only one reminder pass runs at a time, so the cursor advances in order
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	idleBefore := now.Add(-s.idleAfter)
+	read, reminded := 0, 0
+	for {
+		bookings, err := finder.GetIdleReservations(ctx, s.cursor, idleBefore, limit)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return reminded, err
+		}
+		read += len(bookings)
+		reminded += s.remindBatch(ctx, bookings, now)
+
+		if len(bookings) > 0 {
+			s.cursor = bookings[len(bookings)-1].ReservedAt
+		}
+		if len(bookings) < limit || ctx.Err() != nil {
+			break
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("read", read),
+		attribute.Int("reminded", reminded),
+	)
+	span.SetStatus(codes.Ok, "")
+	return reminded, nil
+}
+
+// remindBatch reminds the holders of bookings that have not started paying.
+// Returns the number of holders reminded.
+func (s *idleReservationService) remindBatch(ctx context.Context, bookings []*domain.Booking, now time.Time) int {
+	if len(bookings) == 0 {
+		return 0
+	}
+
+	ids := make([]string, len(bookings))
+	for i, booking := range bookings {
+		ids[i] = booking.ID
+	}
+	started, err := s.idleRepo.PaymentStarted(ctx, ids)
+	if err != nil {
+		// Reminding a customer who is paying is worse than not reminding anyone
+		logger.Get().Error(fmt.Sprintf("Failed to check started payments of idle reservations: error=%v", err))
+		return 0
+	}
+
+	reminded := 0
+	for _, booking := range bookings {
+		remaining := booking.ExpiresAt.Sub(now)
+		if started[booking.ID] || remaining < s.minRemaining {
+			continue
+		}
+
+		// Several workers may read the same reservation; only the first reminds its holder
+		first, err := s.idleRepo.MarkReminded(ctx, booking.ID, remaining)
+		if err != nil {
+			logger.Get().Error(fmt.Sprintf("Failed to mark idle reservation reminded: booking_id=%s, error=%v", booking.ID, err))
+			continue
+		}
+		if !first {
+			continue
+		}
+
+		token, err := SignReleaseLink(s.secret, booking, now)
+		if err != nil {
+			logger.Get().Error(fmt.Sprintf("Failed to sign release link: booking_id=%s, error=%v", booking.ID, err))
+			continue
+		}
+		notification := NewIdleReservationNotification(booking.ID, booking.EventID, booking.Quantity, booking.ExpiresAt, s.releaseLink(token))
+		if err := s.notifier.Notify(ctx, booking.UserID, notification); err != nil {
+			logger.Get().Error(fmt.Sprintf("Failed to remind idle reservation holder: booking_id=%s, user_id=%s, error=%v", booking.ID, booking.UserID, err))
+			continue
+		}
+		reminded++
+	}
+	return reminded
+}
+
+// RecordPaymentStarted records that a payment was created for the booking
+func (s *idleReservationService) RecordPaymentStarted(ctx context.Context, bookingID string) error {
+	if bookingID == "" {
+		return domain.ErrInvalidBookingID
+	}
+	return s.idleRepo.MarkPaymentStarted(ctx, bookingID, s.paymentMarkTTL)
+}
+
+// ReleaseWithLink releases the reservation of a release link
+func (s *idleReservationService) ReleaseWithLink(ctx context.Context, token string) (*dto.ReleaseBookingResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.idle_reservation.release_with_link")
+	defer span.End()
+
+	if s.releaser == nil || s.secret == "" {
+		err := fmt.Errorf("release links are not configured")
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	booking, err := s.releasableBooking(ctx, token)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	span.SetAttributes(attribute.String("booking_id", booking.ID))
+
+	resp, err := s.releaser.ReleaseBooking(ctx, booking.ID, booking.UserID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return resp, nil
+}
+
+// PreviewReleaseLink returns the reservation a release link would release
+func (s *idleReservationService) PreviewReleaseLink(ctx context.Context, token string) (*dto.ReleaseLinkPreview, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.idle_reservation.preview_release_link")
+	defer span.End()
+
+	if s.releaser == nil || s.secret == "" {
+		err := fmt.Errorf("release links are not configured")
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	booking, err := s.releasableBooking(ctx, token)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	span.SetAttributes(attribute.String("booking_id", booking.ID))
+
+	span.SetStatus(codes.Ok, "")
+	return &dto.ReleaseLinkPreview{
+		BookingID: booking.ID,
+		EventID:   booking.EventID,
+		Quantity:  booking.Quantity,
+		ExpiresAt: booking.ExpiresAt,
+		Message:   "POST to this link to release the reservation",
+	}, nil
+}
+
+// releasableBooking returns the booking of a release link if the link still releases it
+func (s *idleReservationService) releasableBooking(ctx context.Context, token string) (*domain.Booking, error) {
+	claims, err := ParseReleaseLink(s.secret, token)
+	if err != nil {
+		return nil, err
+	}
+
+	booking, err := s.bookingRepo.GetByID(ctx, claims.BookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case booking.IsPendingPayment():
+		err = domain.ErrPaymentInProgress
+	case booking.IsConfirmed():
+		err = domain.ErrAlreadyConfirmed
+	case booking.IsCancelled() || booking.Status == domain.BookingStatusExpired:
+		err = domain.ErrAlreadyReleased
+	case !booking.IsReserved() || !claims.Matches(booking):
+		err = domain.ErrReleaseLinkOutdated
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The payment may have started after the reminder; the customer is not idle anymore
+	started, err := s.idleRepo.PaymentStarted(ctx, []string{booking.ID})
+	if err != nil {
+		return nil, err
+	}
+	if started[booking.ID] {
+		return nil, domain.ErrPaymentInProgress
+	}
+	return booking, nil
+}
+
+// releaseLink returns the release URL of a reminder
+func (s *idleReservationService) releaseLink(token string) string {
+	sep := "?"
+	if strings.Contains(s.releaseURL, "?") {
+		sep = "&"
+	}
+	return s.releaseURL + sep + "token=" + url.QueryEscape(token)
+}
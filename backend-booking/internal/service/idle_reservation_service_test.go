@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+)
+
+// MockIdleReservationFinder is a MockBookingRepository that serves idle reservations
+// reserved within the requested window
+type MockIdleReservationFinder struct {
+	*MockBookingRepository
+	Bookings []*domain.Booking
+}
+
+func (m *MockIdleReservationFinder) GetIdleReservations(ctx context.Context, reservedAfter, reservedBefore time.Time, limit int) ([]*domain.Booking, error) {
+	var idle []*domain.Booking
+	for _, b := range m.Bookings {
+		if b.ReservedAt.After(reservedAfter) && !b.ReservedAt.After(reservedBefore) && len(idle) < limit {
+			idle = append(idle, b)
+		}
+	}
+	return idle, nil
+}
+
+// memoryIdleReservationRepository keeps idle reservation marks in memory
+type memoryIdleReservationRepository struct {
+	started  map[string]bool
+	reminded map[string]bool
+}
+
+func newMemoryIdleReservationRepository() *memoryIdleReservationRepository {
+	return &memoryIdleReservationRepository{started: map[string]bool{}, reminded: map[string]bool{}}
+}
+
+func (r *memoryIdleReservationRepository) MarkPaymentStarted(ctx context.Context, bookingID string, ttl time.Duration) error {
+	r.started[bookingID] = true
+	return nil
+}
+
+func (r *memoryIdleReservationRepository) PaymentStarted(ctx context.Context, bookingIDs []string) (map[string]bool, error) {
+	started := make(map[string]bool)
+	for _, id := range bookingIDs {
+		if r.started[id] {
+			started[id] = true
+		}
+	}
+	return started, nil
+}
+
+func (r *memoryIdleReservationRepository) MarkReminded(ctx context.Context, bookingID string, ttl time.Duration) (bool, error) {
+	if r.reminded[bookingID] {
+		return false, nil
+	}
+	r.reminded[bookingID] = true
+	return true, nil
+}
+
+// recordingPushNotifier records the notifications it delivers by user
+type recordingPushNotifier struct {
+	sent map[string]*PushNotification
+}
+
+func (n *recordingPushNotifier) Notify(ctx context.Context, userID string, notification *PushNotification) error {
+	n.sent[userID] = notification
+	return nil
+}
+
+// recordingReleaser records the bookings it releases
+type recordingReleaser struct {
+	released []string
+}
+
+func (r *recordingReleaser) ReleaseBooking(ctx context.Context, bookingID, userID string) (*dto.ReleaseBookingResponse, error) {
+	r.released = append(r.released, bookingID)
+	return &dto.ReleaseBookingResponse{BookingID: bookingID, Status: string(domain.BookingStatusCancelled)}, nil
+}
+
+func newIdleBooking(id, userID string, reservedAt time.Time) *domain.Booking {
+	return &domain.Booking{
+		ID:         id,
+		UserID:     userID,
+		EventID:    "event-1",
+		Quantity:   2,
+		Status:     domain.BookingStatusReserved,
+		ReservedAt: reservedAt,
+		ExpiresAt:  reservedAt.Add(10 * time.Minute),
+		UpdatedAt:  reservedAt,
+	}
+}
+
+func TestIdleReservationService_RemindIdle(t *testing.T) {
+	now := time.Now()
+	finder := &MockIdleReservationFinder{
+		MockBookingRepository: &MockBookingRepository{},
+		Bookings: []*domain.Booking{
+			newIdleBooking("booking-1", "user-1", now.Add(-8*time.Minute)),
+			newIdleBooking("booking-2", "user-2", now.Add(-6*time.Minute)),
+			newIdleBooking("booking-3", "user-3", now.Add(-5*time.Minute)),
+			newIdleBooking("booking-4", "user-4", now.Add(-time.Minute)), // Not idle yet
+		},
+	}
+	idleRepo := newMemoryIdleReservationRepository()
+	notifier := &recordingPushNotifier{sent: map[string]*PushNotification{}}
+	svc := NewIdleReservationService(finder, idleRepo, nil, notifier, &IdleReservationServiceConfig{
+		IdleAfter:    3 * time.Minute,
+		MinRemaining: 3 * time.Minute,
+		Secret:       "test-secret",
+		ReleaseURL:   "https://book.example.com/release?src=push",
+	})
+
+	if err := svc.RecordPaymentStarted(context.Background(), "booking-3"); err != nil {
+		t.Fatalf("RecordPaymentStarted() error = %v", err)
+	}
+
+	reminded, err := svc.RemindIdle(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("RemindIdle() error = %v", err)
+	}
+	// booking-1 expires too soon and booking-3 is being paid for
+	if reminded != 1 || len(notifier.sent) != 1 || notifier.sent["user-2"] == nil {
+		t.Fatalf("RemindIdle() reminded %d holders (%v), want user-2", reminded, notifier.sent)
+	}
+
+	link := notifier.sent["user-2"].Params["release_url"]
+	if !strings.HasPrefix(link, "https://book.example.com/release?src=push&token=") {
+		t.Fatalf("release_url = %s, want the configured URL with a token", link)
+	}
+	parsed, _ := url.Parse(link)
+	claims, err := ParseReleaseLink("test-secret", parsed.Query().Get("token"))
+	if err != nil || claims.BookingID != "booking-2" {
+		t.Errorf("ParseReleaseLink() = %+v, %v, want booking-2", claims, err)
+	}
+
+	// Reservations already read are not reminded again
+	notifier.sent = map[string]*PushNotification{}
+	if reminded, err := svc.RemindIdle(context.Background(), 10); err != nil || reminded != 0 {
+		t.Errorf("second RemindIdle() = %d, %v, want no reminders", reminded, err)
+	}
+}
+
+func TestIdleReservationService_ReleaseWithLink(t *testing.T) {
+	booking := newIdleBooking("booking-1", "user-1", time.Now().Add(-5*time.Minute))
+	current := *booking
+	bookingRepo := &MockBookingRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Booking, error) {
+			b := current
+			return &b, nil
+		},
+	}
+	idleRepo := newMemoryIdleReservationRepository()
+	releaser := &recordingReleaser{}
+	svc := NewIdleReservationService(bookingRepo, idleRepo, releaser, nil, &IdleReservationServiceConfig{Secret: "test-secret"})
+
+	token, err := SignReleaseLink("test-secret", booking, time.Now())
+	if err != nil {
+		t.Fatalf("SignReleaseLink() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		change  func(b *domain.Booking)
+		started bool
+		wantErr error
+	}{
+		{name: "forged", token: token + "x", wantErr: domain.ErrInvalidReleaseLink},
+		{name: "hold changed", token: token, change: func(b *domain.Booking) { b.UpdatedAt = b.UpdatedAt.Add(time.Second) }, wantErr: domain.ErrReleaseLinkOutdated},
+		{name: "paying at gateway", token: token, change: func(b *domain.Booking) { b.Status = domain.BookingStatusPendingPayment }, wantErr: domain.ErrPaymentInProgress},
+		{name: "confirmed", token: token, change: func(b *domain.Booking) { b.Status = domain.BookingStatusConfirmed }, wantErr: domain.ErrAlreadyConfirmed},
+		{name: "expired", token: token, change: func(b *domain.Booking) { b.Status = domain.BookingStatusExpired }, wantErr: domain.ErrAlreadyReleased},
+		{name: "payment started", token: token, started: true, wantErr: domain.ErrPaymentInProgress},
+		{name: "released", token: token},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current = *booking
+			if tt.change != nil {
+				tt.change(&current)
+			}
+			idleRepo.started = map[string]bool{"booking-1": tt.started}
+			releaser.released = nil
+
+			if _, err := svc.PreviewReleaseLink(context.Background(), tt.token); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PreviewReleaseLink() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(releaser.released) != 0 {
+				t.Fatalf("PreviewReleaseLink() released %v, want nothing", releaser.released)
+			}
+
+			resp, err := svc.ReleaseWithLink(context.Background(), tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ReleaseWithLink() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if len(releaser.released) != 0 {
+					t.Errorf("released %v, want nothing", releaser.released)
+				}
+				return
+			}
+			if resp.BookingID != "booking-1" || len(releaser.released) != 1 {
+				t.Errorf("ReleaseWithLink() = %+v, released %v, want booking-1 released", resp, releaser.released)
+			}
+		})
+	}
+}
+
+func TestIdleReservationService_ExpiredLink(t *testing.T) {
+	booking := newIdleBooking("booking-1", "user-1", time.Now().Add(-time.Hour))
+	svc := NewIdleReservationService(&MockBookingRepository{}, newMemoryIdleReservationRepository(), &recordingReleaser{}, nil, &IdleReservationServiceConfig{Secret: "test-secret"})
+
+	token, err := SignReleaseLink("test-secret", booking, booking.ReservedAt)
+	if err != nil {
+		t.Fatalf("SignReleaseLink() error = %v", err)
+	}
+	if _, err := svc.ReleaseWithLink(context.Background(), token); !errors.Is(err, domain.ErrReleaseLinkExpired) {
+		t.Errorf("ReleaseWithLink() error = %v, want %v", err, domain.ErrReleaseLinkExpired)
+	}
+}
+
+func TestReleaseLink_NotAnAccessToken(t *testing.T) {
+	booking := newIdleBooking("booking-1", "user-1", time.Now().Add(-5*time.Minute))
+	token, err := SignReleaseLink("test-secret", booking, time.Now())
+	if err != nil {
+		t.Fatalf("SignReleaseLink() error = %v", err)
+	}
+	assertNotAccessToken(t, "test-secret", token)
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// ErrLoadTestBatchTooLarge is returned when more passes are requested than one batch allows
+var ErrLoadTestBatchTooLarge = errors.New("load test batch too large")
+
+// LoadTestService prepares and resets events for load-test runs
+type LoadTestService interface {
+	// IssueQueuePasses signs and stores queue passes for users, skipping the wait in the
+	// virtual queue; reservations validate them like passes released by the queue
+	IssueQueuePasses(ctx context.Context, eventID string, req *dto.IssueQueuePassesRequest) (*dto.IssueQueuePassesResponse, error)
+
+	// ResetEvent clears the queue, queue passes and per-user limits of an event and
+	// restores the availability of the given zones
+	ResetEvent(ctx context.Context, eventID string, req *dto.ResetLoadTestEventRequest) (*dto.ResetLoadTestEventResponse, error)
+}
+
+// LoadTestServiceConfig contains configuration for the load test service
+type LoadTestServiceConfig struct {
+	JWTSecret string        // Secret for signing queue passes, as in QueueServiceConfig
+	MaxBatch  int           // Most passes issued per request (default: 1000)
+	PassTTL   time.Duration // Lifetime of issued passes (default: 1 hour)
+}
+
+// loadTestService implements LoadTestService
+type loadTestService struct {
+	queueRepo       repository.QueueRepository
+	reservationRepo repository.ReservationRepository
+	loadTestRepo    repository.LoadTestRepository
+	jwtSecret       string
+	maxBatch        int
+	passTTL         time.Duration
+}
+
+// NewLoadTestService creates a new load test service
+func NewLoadTestService(
+	queueRepo repository.QueueRepository,
+	reservationRepo repository.ReservationRepository,
+	loadTestRepo repository.LoadTestRepository,
+	cfg *LoadTestServiceConfig,
+) LoadTestService {
+	s := &loadTestService{
+		queueRepo:       queueRepo,
+		reservationRepo: reservationRepo,
+		loadTestRepo:    loadTestRepo,
+		maxBatch:        1000,
+		passTTL:         time.Hour,
+	}
+	if cfg != nil {
+		s.jwtSecret = cfg.JWTSecret
+		if cfg.MaxBatch > 0 {
+			s.maxBatch = cfg.MaxBatch
+		}
+		if cfg.PassTTL > 0 {
+			s.passTTL = cfg.PassTTL
+		}
+	}
+	return s
+}
+
+// IssueQueuePasses signs and stores a queue pass for each user
+func (s *loadTestService) IssueQueuePasses(ctx context.Context, eventID string, req *dto.IssueQueuePassesRequest) (*dto.IssueQueuePassesResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.loadtest.issue_queue_passes")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("event_id", eventID),
+		attribute.Int("count", len(req.UserIDs)),
+	)
+
+	if eventID == "" {
+		span.SetStatus(codes.Error, "invalid event_id")
+		return nil, domain.ErrInvalidEventID
+	}
+	if len(req.UserIDs) > s.maxBatch {
+		span.SetStatus(codes.Error, "batch too large")
+		return nil, fmt.Errorf("%w: %d passes requested, at most %d per request", ErrLoadTestBatchTooLarge, len(req.UserIDs), s.maxBatch)
+	}
+
+	zoneIDs := req.ZoneIDs
+	if len(zoneIDs) == 0 {
+		if config, err := s.queueRepo.GetEventQueueConfig(ctx, eventID); err == nil && config != nil {
+			zoneIDs = config.PassZoneIDs
+		}
+	}
+
+	resp := &dto.IssueQueuePassesResponse{
+		EventID: eventID,
+		Passes:  make([]dto.IssuedQueuePass, 0, len(req.UserIDs)),
+	}
+	ttlSeconds := int(s.passTTL.Seconds())
+	for _, userID := range req.UserIDs {
+		queuePass, expiresAt, err := SignQueuePass(s.jwtSecret, "booking-service", userID, eventID, zoneIDs, s.passTTL)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		if err := s.queueRepo.StoreQueuePass(ctx, eventID, userID, queuePass, ttlSeconds); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, fmt.Errorf("failed to store queue pass: %w", err)
+		}
+		resp.ExpiresAt = expiresAt
+		resp.Passes = append(resp.Passes, dto.IssuedQueuePass{UserID: userID, QueuePass: queuePass})
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return resp, nil
+}
+
+// ResetEvent clears the Redis state of an event and restores zone availability
+func (s *loadTestService) ResetEvent(ctx context.Context, eventID string, req *dto.ResetLoadTestEventRequest) (*dto.ResetLoadTestEventResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.loadtest.reset_event")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("event_id", eventID))
+
+	if eventID == "" {
+		span.SetStatus(codes.Error, "invalid event_id")
+		return nil, domain.ErrInvalidEventID
+	}
+
+	deleted, err := s.loadTestRepo.ResetEvent(ctx, eventID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	resp := &dto.ResetLoadTestEventResponse{EventID: eventID, KeysDeleted: deleted}
+	if req != nil {
+		for zoneID, seats := range req.Zones {
+			if err := s.reservationRepo.SetZoneAvailability(ctx, zoneID, seats); err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				return nil, fmt.Errorf("failed to reset zone %s: %w", zoneID, err)
+			}
+			resp.ZonesReset++
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int64("keys_deleted", deleted),
+		attribute.Int("zones_reset", resp.ZonesReset),
+	)
+	span.SetStatus(codes.Ok, "")
+	return resp, nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockLoadTestRepository is a mock implementation of LoadTestRepository
+type MockLoadTestRepository struct {
+	Deleted  int64
+	EventIDs []string
+}
+
+func (m *MockLoadTestRepository) ResetEvent(ctx context.Context, eventID string) (int64, error) {
+	m.EventIDs = append(m.EventIDs, eventID)
+	return m.Deleted, nil
+}
+
+func TestLoadTestService_IssueQueuePasses(t *testing.T) {
+	queueRepo := new(MockQueueRepository)
+	queueRepo.On("GetEventQueueConfig", mock.Anything, "event-1").
+		Return(&repository.EventQueueConfig{PassZoneIDs: []string{"zone-a"}}, nil)
+	queueRepo.On("StoreQueuePass", mock.Anything, "event-1", mock.Anything, mock.Anything, 1800).Return(nil)
+
+	svc := NewLoadTestService(queueRepo, &MockReservationRepository{}, &MockLoadTestRepository{}, &LoadTestServiceConfig{
+		JWTSecret: "test-secret",
+		PassTTL:   30 * time.Minute,
+	})
+
+	resp, err := svc.IssueQueuePasses(context.Background(), "event-1", &dto.IssueQueuePassesRequest{UserIDs: []string{"user-1", "user-2"}})
+	if err != nil {
+		t.Fatalf("IssueQueuePasses() error = %v", err)
+	}
+	assert.Len(t, resp.Passes, 2)
+	queueRepo.AssertNumberOfCalls(t, "StoreQueuePass", 2)
+
+	// Passes are accepted like passes released by the queue, with the event's zones
+	claims, err := ParseQueuePass("test-secret", resp.Passes[1].QueuePass)
+	if err != nil {
+		t.Fatalf("ParseQueuePass() error = %v", err)
+	}
+	assert.Equal(t, "user-2", claims.UserID)
+	assert.Equal(t, "event-1", claims.EventID)
+	assert.Equal(t, []string{"zone-a"}, claims.ZoneIDs)
+}
+
+func TestLoadTestService_IssueQueuePassesBatchTooLarge(t *testing.T) {
+	svc := NewLoadTestService(new(MockQueueRepository), &MockReservationRepository{}, &MockLoadTestRepository{}, &LoadTestServiceConfig{
+		JWTSecret: "test-secret",
+		MaxBatch:  1,
+	})
+
+	_, err := svc.IssueQueuePasses(context.Background(), "event-1", &dto.IssueQueuePassesRequest{UserIDs: []string{"user-1", "user-2"}})
+	if !errors.Is(err, ErrLoadTestBatchTooLarge) {
+		t.Errorf("IssueQueuePasses() error = %v, want ErrLoadTestBatchTooLarge", err)
+	}
+}
+
+func TestLoadTestService_ResetEvent(t *testing.T) {
+	zones := map[string]int64{}
+	reservationRepo := &MockReservationRepository{
+		SetZoneAvailabilityFunc: func(ctx context.Context, zoneID string, seats int64) error {
+			zones[zoneID] = seats
+			return nil
+		},
+	}
+	loadTestRepo := &MockLoadTestRepository{Deleted: 42}
+	svc := NewLoadTestService(new(MockQueueRepository), reservationRepo, loadTestRepo, nil)
+
+	resp, err := svc.ResetEvent(context.Background(), "event-1", &dto.ResetLoadTestEventRequest{
+		Zones: map[string]int64{"zone-a": 100, "zone-b": 50},
+	})
+	if err != nil {
+		t.Fatalf("ResetEvent() error = %v", err)
+	}
+	assert.Equal(t, int64(42), resp.KeysDeleted)
+	assert.Equal(t, 2, resp.ZonesReset)
+	assert.Equal(t, map[string]int64{"zone-a": 100, "zone-b": 50}, zones)
+	assert.Equal(t, []string{"event-1"}, loadTestRepo.EventIDs)
+}
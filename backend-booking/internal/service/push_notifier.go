@@ -0,0 +1,252 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/i18n"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/push"
+)
+
+// PushNotification is a push notification for every device of a user,
+// rendered from an i18n template in each device's locale
+type PushNotification struct {
+	Template string
+	Params   map[string]string
+	// Data is handed to the app with the notification (e.g. the event to open)
+	Data map[string]string
+	// CollapseKey replaces an undelivered notification with the same key
+	CollapseKey string
+	// TTL is how long providers keep trying an offline device; a notification
+	// about a hold is useless once the hold expires
+	TTL time.Duration
+}
+
+// pushTimeLayout formats times in notifications, as in standby offers
+const pushTimeLayout = "2006-01-02 15:04 MST"
+
+// NewQueueTurnNotification tells a user released from the virtual queue that they can book
+func NewQueueTurnNotification(eventID string, expiresAt time.Time) *PushNotification {
+	return &PushNotification{
+		Template:    i18n.TemplateQueueTurn,
+		Params:      map[string]string{"expires_at": expiresAt.UTC().Format(pushTimeLayout)},
+		Data:        map[string]string{"type": "queue_turn", "event_id": eventID},
+		CollapseKey: "queue:" + eventID,
+		TTL:         time.Until(expiresAt),
+	}
+}
+
+// NewPaymentDueNotification reminds a user to pay for a reservation before it expires
+func NewPaymentDueNotification(bookingID, eventID string, quantity int, expiresAt time.Time) *PushNotification {
+	return &PushNotification{
+		Template: i18n.TemplatePaymentDue,
+		Params: map[string]string{
+			"quantity":   strconv.Itoa(quantity),
+			"expires_at": expiresAt.UTC().Format(pushTimeLayout),
+		},
+		Data:        map[string]string{"type": "payment_due", "booking_id": bookingID, "event_id": eventID},
+		CollapseKey: "booking:" + bookingID,
+		TTL:         time.Until(expiresAt),
+	}
+}
+
+// NewIdleReservationNotification offers a user who has not started paying to release their
+// reservation with one click
+func NewIdleReservationNotification(bookingID, eventID string, quantity int, expiresAt time.Time, releaseURL string) *PushNotification {
+	return &PushNotification{
+		Template: i18n.TemplateIdleReservation,
+		Params: map[string]string{
+			"quantity":    strconv.Itoa(quantity),
+			"expires_at":  expiresAt.UTC().Format(pushTimeLayout),
+			"release_url": releaseURL,
+		},
+		Data:        map[string]string{"type": "idle_reservation", "booking_id": bookingID, "event_id": eventID, "release_url": releaseURL},
+		CollapseKey: "booking:" + bookingID,
+		TTL:         time.Until(expiresAt),
+	}
+}
+
+// NewBookingConfirmedNotification tells a user their booking is confirmed
+func NewBookingConfirmedNotification(bookingID, confirmationCode string) *PushNotification {
+	return &PushNotification{
+		Template:    i18n.TemplateBookingConfirmed,
+		Params:      map[string]string{"confirmation_code": confirmationCode},
+		Data:        map[string]string{"type": "booking_confirmed", "booking_id": bookingID},
+		CollapseKey: "booking:" + bookingID,
+	}
+}
+
+// PushNotifier delivers push notifications to users' mobile devices
+type PushNotifier interface {
+	// Notify delivers a notification to all devices of a user
+	Notify(ctx context.Context, userID string, notification *PushNotification) error
+}
+
+// PushDevice is a device registered for push notifications in auth-service
+type PushDevice struct {
+	ID       string `json:"id"`
+	Platform string `json:"platform"`
+	Token    string `json:"token"`
+	Locale   string `json:"locale,omitempty"`
+}
+
+// DeviceDirectory looks up and prunes the devices users registered (auth-service)
+type DeviceDirectory interface {
+	// ListDevices returns the devices of a user
+	ListDevices(ctx context.Context, userID string) ([]PushDevice, error)
+	// RevokeTokens removes devices whose tokens the push providers rejected
+	RevokeTokens(ctx context.Context, tokens []string) error
+}
+
+// HTTPDeviceDirectory looks up devices via auth-service's internal API
+type HTTPDeviceDirectory struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewHTTPDeviceDirectory creates a new HTTP device directory
+func NewHTTPDeviceDirectory(authServiceURL string) *HTTPDeviceDirectory {
+	return &HTTPDeviceDirectory{
+		baseURL: strings.TrimSuffix(authServiceURL, "/"),
+		httpClient: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+	}
+}
+
+// ListDevices calls GET /internal/users/:id/devices
+func (d *HTTPDeviceDirectory) ListDevices(ctx context.Context, userID string) ([]PushDevice, error) {
+	var devices []PushDevice
+	if err := getInternalJSON(ctx, d.httpClient, d.baseURL+"/internal/users/"+url.PathEscape(userID)+"/devices", &devices); err != nil {
+		return nil, fmt.Errorf("failed to list devices: %w", err)
+	}
+	return devices, nil
+}
+
+// RevokeTokens calls POST /internal/devices/revoke
+func (d *HTTPDeviceDirectory) RevokeTokens(ctx context.Context, tokens []string) error {
+	body, err := json.Marshal(map[string][]string{"tokens": tokens})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.baseURL+"/internal/devices/revoke", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to revoke tokens: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to revoke tokens: unexpected status code: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// DevicePushNotifier delivers to each registered device of a user through the push
+// providers, retrying each device on its own and revoking tokens the providers reject
+type DevicePushNotifier struct {
+	directory  DeviceDirectory
+	dispatcher *push.Dispatcher
+	logger     Logger
+}
+
+// NewDevicePushNotifier creates a new device push notifier
+func NewDevicePushNotifier(directory DeviceDirectory, dispatcher *push.Dispatcher, logger Logger) *DevicePushNotifier {
+	return &DevicePushNotifier{
+		directory:  directory,
+		dispatcher: dispatcher,
+		logger:     logger,
+	}
+}
+
+// Notify delivers a notification to all devices of a user. It fails only when no
+// device could be reached for a reason other than a rejected token.
+func (n *DevicePushNotifier) Notify(ctx context.Context, userID string, notification *PushNotification) error {
+	devices, err := n.directory.ListDevices(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if len(devices) == 0 {
+		return nil
+	}
+
+	// Devices of one user may use different languages, so render once per locale
+	byLocale := make(map[i18n.Locale][]push.Device)
+	tokens := make(map[string]string, len(devices))
+	for _, device := range devices {
+		locale, ok := i18n.Parse(device.Locale)
+		if !ok {
+			locale = i18n.DefaultLocale
+		}
+		byLocale[locale] = append(byLocale[locale], push.Device{
+			ID:       device.ID,
+			Platform: push.Platform(device.Platform),
+			Token:    device.Token,
+		})
+		tokens[device.ID] = device.Token
+	}
+
+	var delivered int
+	var lastErr error
+	var invalid []string
+	for locale, localeDevices := range byLocale {
+		rendered := i18n.RenderNotification(locale, notification.Template, notification.Params)
+		results := n.dispatcher.Send(ctx, localeDevices, push.Message{
+			Title:       rendered.Subject,
+			Body:        rendered.Body,
+			Data:        notification.Data,
+			CollapseKey: notification.CollapseKey,
+			TTL:         notification.TTL,
+		})
+		for _, result := range results {
+			switch result.Status {
+			case push.StatusDelivered:
+				delivered++
+			case push.StatusInvalidToken:
+				invalid = append(invalid, tokens[result.DeviceID])
+			default:
+				lastErr = result.Err
+				if n.logger != nil {
+					n.logger.Warn(fmt.Sprintf("push to device %s of user %s failed after %d attempts: %v", result.DeviceID, userID, result.Attempts, result.Err))
+				}
+			}
+		}
+	}
+
+	if len(invalid) > 0 {
+		if err := n.directory.RevokeTokens(ctx, invalid); err != nil && n.logger != nil {
+			n.logger.Warn(fmt.Sprintf("failed to revoke %d rejected push tokens of user %s: %v", len(invalid), userID, err))
+		}
+	}
+
+	if delivered == 0 && lastErr != nil {
+		return fmt.Errorf("push to user %s failed: %w", userID, lastErr)
+	}
+	return nil
+}
+
+// NoOpPushNotifier is a no-op implementation of PushNotifier for testing
+type NoOpPushNotifier struct{}
+
+// NewNoOpPushNotifier creates a new no-op push notifier
+func NewNoOpPushNotifier() *NoOpPushNotifier {
+	return &NoOpPushNotifier{}
+}
+
+// Notify is a no-op
+func (n *NoOpPushNotifier) Notify(ctx context.Context, userID string, notification *PushNotification) error {
+	return nil
+}
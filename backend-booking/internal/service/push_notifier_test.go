@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/push"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/retry"
+)
+
+// fakeDeviceDirectory is a fake implementation of DeviceDirectory
+type fakeDeviceDirectory struct {
+	devices []PushDevice
+	revoked []string
+}
+
+func (d *fakeDeviceDirectory) ListDevices(ctx context.Context, userID string) ([]PushDevice, error) {
+	return d.devices, nil
+}
+
+func (d *fakeDeviceDirectory) RevokeTokens(ctx context.Context, tokens []string) error {
+	d.revoked = append(d.revoked, tokens...)
+	return nil
+}
+
+// fakePushProvider delivers to every token except the rejected ones
+type fakePushProvider struct {
+	platform push.Platform
+	rejected map[string]error
+	messages []*push.Message
+}
+
+func (p *fakePushProvider) Platform() push.Platform {
+	return p.platform
+}
+
+func (p *fakePushProvider) Send(ctx context.Context, msg *push.Message) error {
+	if err, ok := p.rejected[msg.Token]; ok {
+		return err
+	}
+	p.messages = append(p.messages, msg)
+	return nil
+}
+
+func TestDevicePushNotifier_Notify(t *testing.T) {
+	directory := &fakeDeviceDirectory{devices: []PushDevice{
+		{ID: "d1", Platform: "android", Token: "android-ok", Locale: "th"},
+		{ID: "d2", Platform: "android", Token: "android-stale", Locale: "en"},
+		{ID: "d3", Platform: "ios", Token: "ios-ok", Locale: "en"},
+	}}
+	android := &fakePushProvider{platform: push.PlatformAndroid, rejected: map[string]error{
+		"android-stale": retry.Permanent(push.ErrInvalidToken),
+	}}
+	ios := &fakePushProvider{platform: push.PlatformIOS}
+	dispatcher := push.NewDispatcher(&push.DispatcherConfig{InitialInterval: time.Millisecond}, android, ios)
+	notifier := NewDevicePushNotifier(directory, dispatcher, nil)
+
+	if err := notifier.Notify(context.Background(), "user-1", NewBookingConfirmedNotification("booking-1", "BR-123")); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if len(android.messages) != 1 || len(ios.messages) != 1 {
+		t.Fatalf("Expected one delivery per platform, got android=%d ios=%d", len(android.messages), len(ios.messages))
+	}
+	if android.messages[0].Title == ios.messages[0].Title {
+		t.Errorf("Expected the Thai and English devices to get localized titles, both got %q", ios.messages[0].Title)
+	}
+	if len(directory.revoked) != 1 || directory.revoked[0] != "android-stale" {
+		t.Errorf("Revoked tokens = %v, want [android-stale]", directory.revoked)
+	}
+}
+
+func TestDevicePushNotifier_NotifyAllFailed(t *testing.T) {
+	directory := &fakeDeviceDirectory{devices: []PushDevice{{ID: "d1", Platform: "ios", Token: "ios-1"}}}
+	ios := &fakePushProvider{platform: push.PlatformIOS, rejected: map[string]error{"ios-1": errors.New("gateway unavailable")}}
+	dispatcher := push.NewDispatcher(&push.DispatcherConfig{MaxRetries: 1, InitialInterval: time.Millisecond}, ios)
+	notifier := NewDevicePushNotifier(directory, dispatcher, nil)
+
+	if err := notifier.Notify(context.Background(), "user-1", NewQueueTurnNotification("event-1", time.Now())); err == nil {
+		t.Error("Notify() expected an error when no device was reached")
+	}
+	if len(directory.revoked) != 0 {
+		t.Errorf("Expected no tokens revoked on transient failures, got %v", directory.revoked)
+	}
+}
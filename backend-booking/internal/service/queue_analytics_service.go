@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const (
+	// DefaultQueueAnalyticsWindow is the window read when none is given
+	DefaultQueueAnalyticsWindow = time.Hour
+	// DefaultQueueAnalyticsInterval is the series interval used when none is given
+	DefaultQueueAnalyticsInterval = time.Minute
+)
+
+// QueueAnalyticsRecorder counts queue funnel steps as they happen. Recording is best
+// effort: failures are logged and never fail the queue or booking operation.
+type QueueAnalyticsRecorder interface {
+	// RecordStep adds count users to a funnel step of an event
+	RecordStep(ctx context.Context, eventID string, step domain.QueueFunnelStep, count int64)
+
+	// RecordDepth records the current queue size of an event
+	RecordDepth(ctx context.Context, eventID string, depth int64)
+}
+
+// QueueAnalyticsService records and reports per-event queue funnels
+type QueueAnalyticsService interface {
+	QueueAnalyticsRecorder
+
+	// GetAnalytics returns the queue funnel of an event over the last window, with a
+	// series of interval-long points. Zero values use the defaults.
+	GetAnalytics(ctx context.Context, eventID string, window, interval time.Duration) (*dto.QueueAnalyticsResponse, error)
+}
+
+// queueAnalyticsService implements QueueAnalyticsService
+type queueAnalyticsService struct {
+	repo      repository.QueueAnalyticsRepository
+	queueRepo repository.QueueRepository
+	now       func() time.Time
+}
+
+// NewQueueAnalyticsService creates a new queue analytics service. queueRepo reports the
+// current queue depth and may be nil for a recorder only.
+func NewQueueAnalyticsService(repo repository.QueueAnalyticsRepository, queueRepo repository.QueueRepository) QueueAnalyticsService {
+	return &queueAnalyticsService{
+		repo:      repo,
+		queueRepo: queueRepo,
+		now:       time.Now,
+	}
+}
+
+// RecordStep adds count users to a funnel step in the current bucket
+func (s *queueAnalyticsService) RecordStep(ctx context.Context, eventID string, step domain.QueueFunnelStep, count int64) {
+	if eventID == "" || count <= 0 {
+		return
+	}
+	if err := s.repo.IncrStep(ctx, eventID, step, s.bucket(), count); err != nil {
+		logger.Get().Warn(fmt.Sprintf("Failed to record queue analytics for event %s: %v", eventID, err))
+	}
+}
+
+// RecordDepth records the queue size in the current bucket
+func (s *queueAnalyticsService) RecordDepth(ctx context.Context, eventID string, depth int64) {
+	if eventID == "" {
+		return
+	}
+	if err := s.repo.SetDepth(ctx, eventID, s.bucket(), depth); err != nil {
+		logger.Get().Warn(fmt.Sprintf("Failed to record queue depth for event %s: %v", eventID, err))
+	}
+}
+
+// bucket returns the start of the current bucket
+func (s *queueAnalyticsService) bucket() time.Time {
+	return s.now().UTC().Truncate(domain.QueueAnalyticsBucketSize)
+}
+
+// GetAnalytics returns the queue funnel of an event over the last window
+func (s *queueAnalyticsService) GetAnalytics(ctx context.Context, eventID string, window, interval time.Duration) (*dto.QueueAnalyticsResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.queue_analytics.get")
+	defer span.End()
+
+	if eventID == "" {
+		span.SetStatus(codes.Error, "invalid event_id")
+		return nil, domain.ErrInvalidEventID
+	}
+	if window == 0 {
+		window = DefaultQueueAnalyticsWindow
+	}
+	if interval == 0 {
+		interval = DefaultQueueAnalyticsInterval
+	}
+	if window < domain.QueueAnalyticsBucketSize || window > domain.QueueAnalyticsMaxWindow ||
+		interval < domain.QueueAnalyticsBucketSize || interval > window || interval%domain.QueueAnalyticsBucketSize != 0 {
+		span.SetStatus(codes.Error, "invalid range")
+		return nil, domain.ErrInvalidQueueAnalyticsRange
+	}
+
+	span.SetAttributes(
+		attribute.String("event_id", eventID),
+		attribute.String("window", window.String()),
+		attribute.String("interval", interval.String()),
+	)
+
+	// The last bucket is the current, still filling one
+	now := s.now().UTC()
+	n := int(window / domain.QueueAnalyticsBucketSize)
+	from := now.Truncate(domain.QueueAnalyticsBucketSize).Add(-time.Duration(n-1) * domain.QueueAnalyticsBucketSize)
+
+	buckets, err := s.repo.GetBuckets(ctx, eventID, from, n)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	var depth int64
+	if s.queueRepo != nil {
+		depth, err = s.queueRepo.GetQueueSize(ctx, eventID)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, fmt.Errorf("failed to get queue size: %w", err)
+		}
+	}
+
+	resp := &dto.QueueAnalyticsResponse{
+		EventID:          eventID,
+		GeneratedAt:      now,
+		Since:            from,
+		Window:           window.String(),
+		Interval:         interval.String(),
+		CurrentDepth:     depth,
+		QueueFunnelStats: toQueueFunnelStats(buckets),
+		Series:           toQueueAnalyticsSeries(buckets, int(interval/domain.QueueAnalyticsBucketSize)),
+	}
+
+	span.SetAttributes(
+		attribute.Int64("joined", resp.Joined),
+		attribute.Int64("current_depth", resp.CurrentDepth),
+		attribute.Int("points", len(resp.Series)),
+	)
+	span.SetStatus(codes.Ok, "")
+	return resp, nil
+}
+
+// toQueueAnalyticsSeries groups buckets into points of perPoint buckets each; the
+// oldest point is aligned to the window start and the newest may be shorter
+func toQueueAnalyticsSeries(buckets []*domain.QueueAnalyticsBucket, perPoint int) []*dto.QueueAnalyticsPoint {
+	series := make([]*dto.QueueAnalyticsPoint, 0, (len(buckets)+perPoint-1)/perPoint)
+	for start := 0; start < len(buckets); start += perPoint {
+		end := start + perPoint
+		if end > len(buckets) {
+			end = len(buckets)
+		}
+		group := buckets[start:end]
+
+		point := &dto.QueueAnalyticsPoint{
+			Start:            group[0].Start,
+			QueueFunnelStats: toQueueFunnelStats(group),
+		}
+		for i := len(group) - 1; i >= 0; i-- {
+			if group[i].Depth >= 0 {
+				depth := group[i].Depth
+				point.Depth = &depth
+				break
+			}
+		}
+		series = append(series, point)
+	}
+	return series
+}
+
+// toQueueFunnelStats sums the funnel counts of buckets and derives their rates
+func toQueueFunnelStats(buckets []*domain.QueueAnalyticsBucket) dto.QueueFunnelStats {
+	var stats dto.QueueFunnelStats
+	for _, bucket := range buckets {
+		stats.Joined += bucket.Counts[domain.QueueStepJoined]
+		stats.Left += bucket.Counts[domain.QueueStepLeft]
+		stats.Admitted += bucket.Counts[domain.QueueStepAdmitted]
+		stats.Reserved += bucket.Counts[domain.QueueStepReserved]
+		stats.Confirmed += bucket.Counts[domain.QueueStepConfirmed]
+	}
+
+	if len(buckets) > 0 {
+		minutes := float64(len(buckets)) * domain.QueueAnalyticsBucketSize.Minutes()
+		stats.JoinsPerMinute = float64(stats.Joined) / minutes
+	}
+	stats.AbandonmentRate = funnelRate(stats.Left, stats.Joined)
+	stats.AdmitToReserveRate = funnelRate(stats.Reserved, stats.Admitted)
+	stats.ReserveToConfirmRate = funnelRate(stats.Confirmed, stats.Reserved)
+	return stats
+}
+
+// funnelRate returns the share of from that reached to, 0 when nothing reached from.
+// Counts are per bucket, so users who entered the window mid-funnel can push it above 1.
+func funnelRate(to, from int64) float64 {
+	if from == 0 {
+		return 0
+	}
+	return float64(to) / float64(from)
+}
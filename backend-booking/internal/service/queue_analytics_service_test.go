@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockQueueAnalyticsRepository is an in-memory implementation of QueueAnalyticsRepository
+type MockQueueAnalyticsRepository struct {
+	buckets map[string]map[int64]*domain.QueueAnalyticsBucket
+}
+
+func NewMockQueueAnalyticsRepository() *MockQueueAnalyticsRepository {
+	return &MockQueueAnalyticsRepository{buckets: make(map[string]map[int64]*domain.QueueAnalyticsBucket)}
+}
+
+func (m *MockQueueAnalyticsRepository) bucket(eventID string, start time.Time) *domain.QueueAnalyticsBucket {
+	if m.buckets[eventID] == nil {
+		m.buckets[eventID] = make(map[int64]*domain.QueueAnalyticsBucket)
+	}
+	bucket, ok := m.buckets[eventID][start.Unix()]
+	if !ok {
+		bucket = domain.NewQueueAnalyticsBucket(start)
+		m.buckets[eventID][start.Unix()] = bucket
+	}
+	return bucket
+}
+
+func (m *MockQueueAnalyticsRepository) IncrStep(ctx context.Context, eventID string, step domain.QueueFunnelStep, bucket time.Time, count int64) error {
+	m.bucket(eventID, bucket).Counts[step] += count
+	return nil
+}
+
+func (m *MockQueueAnalyticsRepository) SetDepth(ctx context.Context, eventID string, bucket time.Time, depth int64) error {
+	m.bucket(eventID, bucket).Depth = depth
+	return nil
+}
+
+func (m *MockQueueAnalyticsRepository) GetBuckets(ctx context.Context, eventID string, from time.Time, n int) ([]*domain.QueueAnalyticsBucket, error) {
+	buckets := make([]*domain.QueueAnalyticsBucket, n)
+	for i := range buckets {
+		start := from.Add(time.Duration(i) * domain.QueueAnalyticsBucketSize)
+		if bucket, ok := m.buckets[eventID][start.Unix()]; ok {
+			buckets[i] = bucket
+		} else {
+			buckets[i] = domain.NewQueueAnalyticsBucket(start)
+		}
+	}
+	return buckets, nil
+}
+
+func TestQueueAnalyticsService_GetAnalytics(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockQueueAnalyticsRepository()
+	queueRepo := new(MockQueueRepository)
+	queueRepo.On("GetQueueSize", ctx, "event-1").Return(int64(42), nil)
+
+	svc := NewQueueAnalyticsService(repo, queueRepo).(*queueAnalyticsService)
+	now := time.Date(2026, 10, 14, 12, 3, 30, 0, time.UTC)
+	at := func(minutesAgo int) {
+		svc.now = func() time.Time { return now.Add(-time.Duration(minutesAgo) * time.Minute) }
+	}
+
+	// Four minutes of traffic, oldest first
+	at(3)
+	svc.RecordStep(ctx, "event-1", domain.QueueStepJoined, 10)
+	svc.RecordDepth(ctx, "event-1", 10)
+	at(2)
+	svc.RecordStep(ctx, "event-1", domain.QueueStepJoined, 10)
+	svc.RecordStep(ctx, "event-1", domain.QueueStepLeft, 4)
+	svc.RecordStep(ctx, "event-1", domain.QueueStepAdmitted, 8)
+	svc.RecordDepth(ctx, "event-1", 8)
+	at(1)
+	svc.RecordStep(ctx, "event-1", domain.QueueStepReserved, 6)
+	at(0)
+	svc.RecordStep(ctx, "event-1", domain.QueueStepConfirmed, 3)
+	svc.RecordStep(ctx, "event-2", domain.QueueStepJoined, 100)
+
+	resp, err := svc.GetAnalytics(ctx, "event-1", 4*time.Minute, 2*time.Minute)
+	if err != nil {
+		t.Fatalf("GetAnalytics() error = %v", err)
+	}
+
+	if resp.CurrentDepth != 42 {
+		t.Errorf("CurrentDepth = %d, want 42", resp.CurrentDepth)
+	}
+	if !resp.Since.Equal(time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("Since = %v, want the start of the oldest minute", resp.Since)
+	}
+	if resp.Joined != 20 || resp.Left != 4 || resp.Admitted != 8 || resp.Reserved != 6 || resp.Confirmed != 3 {
+		t.Errorf("unexpected totals: %+v", resp.QueueFunnelStats)
+	}
+	assertRate(t, "JoinsPerMinute", resp.JoinsPerMinute, 5)
+	assertRate(t, "AbandonmentRate", resp.AbandonmentRate, 0.2)
+	assertRate(t, "AdmitToReserveRate", resp.AdmitToReserveRate, 0.75)
+	assertRate(t, "ReserveToConfirmRate", resp.ReserveToConfirmRate, 0.5)
+
+	if len(resp.Series) != 2 {
+		t.Fatalf("expected 2 points, got %d", len(resp.Series))
+	}
+	first, second := resp.Series[0], resp.Series[1]
+	if first.Joined != 20 || first.Depth == nil || *first.Depth != 8 {
+		t.Errorf("first point = %+v, want 20 joins and the last sampled depth of 8", first)
+	}
+	assertRate(t, "first JoinsPerMinute", first.JoinsPerMinute, 10)
+	if second.Reserved != 6 || second.Confirmed != 3 || second.Depth != nil {
+		t.Errorf("second point = %+v, want 6 reserved, 3 confirmed and no depth", second)
+	}
+	if !second.Start.Equal(resp.Since.Add(2 * time.Minute)) {
+		t.Errorf("second point starts at %v", second.Start)
+	}
+}
+
+func TestQueueAnalyticsService_GetAnalytics_Defaults(t *testing.T) {
+	ctx := context.Background()
+	svc := NewQueueAnalyticsService(NewMockQueueAnalyticsRepository(), nil)
+
+	resp, err := svc.GetAnalytics(ctx, "event-1", 0, 0)
+	if err != nil {
+		t.Fatalf("GetAnalytics() error = %v", err)
+	}
+	if resp.Window != "1h0m0s" || resp.Interval != "1m0s" || len(resp.Series) != 60 {
+		t.Errorf("expected an hour of minute points, got window=%s interval=%s points=%d", resp.Window, resp.Interval, len(resp.Series))
+	}
+	if resp.AbandonmentRate != 0 || resp.JoinsPerMinute != 0 {
+		t.Errorf("expected zero rates without traffic, got %+v", resp.QueueFunnelStats)
+	}
+}
+
+func TestQueueAnalyticsService_GetAnalytics_InvalidRange(t *testing.T) {
+	ctx := context.Background()
+	svc := NewQueueAnalyticsService(NewMockQueueAnalyticsRepository(), nil)
+
+	tests := []struct {
+		name     string
+		eventID  string
+		window   time.Duration
+		interval time.Duration
+		want     error
+	}{
+		{"missing event", "", time.Hour, time.Minute, domain.ErrInvalidEventID},
+		{"window too long", "event-1", 25 * time.Hour, time.Minute, domain.ErrInvalidQueueAnalyticsRange},
+		{"window too short", "event-1", 30 * time.Second, 0, domain.ErrInvalidQueueAnalyticsRange},
+		{"interval longer than window", "event-1", time.Hour, 2 * time.Hour, domain.ErrInvalidQueueAnalyticsRange},
+		{"partial minute interval", "event-1", time.Hour, 90 * time.Second, domain.ErrInvalidQueueAnalyticsRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.GetAnalytics(ctx, tt.eventID, tt.window, tt.interval)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("GetAnalytics() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueueService_RecordsFunnelSteps(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockQueueRepository)
+	analyticsRepo := NewMockQueueAnalyticsRepository()
+	svc := NewQueueService(mockRepo, &QueueServiceConfig{
+		JWTSecret: testJWTSecret,
+		Analytics: NewQueueAnalyticsService(analyticsRepo, nil),
+	})
+
+	mockRepo.On("JoinQueue", ctx, mock.Anything).Return(&repository.JoinQueueResult{Success: true, Position: 1, TotalInQueue: 1}, nil)
+	mockRepo.On("LeaveQueue", ctx, "event-1", "user-1", "token").Return(nil)
+	mockRepo.On("DeleteQueuePass", ctx, "event-1", "user-1").Return(nil)
+
+	if _, err := svc.JoinQueue(ctx, "user-1", &dto.JoinQueueRequest{EventID: "event-1"}); err != nil {
+		t.Fatalf("JoinQueue() error = %v", err)
+	}
+	if _, err := svc.LeaveQueue(ctx, "user-1", &dto.LeaveQueueRequest{EventID: "event-1", Token: "token"}); err != nil {
+		t.Fatalf("LeaveQueue() error = %v", err)
+	}
+	if err := svc.DeleteQueuePass(ctx, "user-1", "event-1"); err != nil {
+		t.Fatalf("DeleteQueuePass() error = %v", err)
+	}
+
+	counts := map[domain.QueueFunnelStep]int64{}
+	for _, bucket := range analyticsRepo.buckets["event-1"] {
+		for step, count := range bucket.Counts {
+			counts[step] += count
+		}
+	}
+	if counts[domain.QueueStepJoined] != 1 || counts[domain.QueueStepLeft] != 1 || counts[domain.QueueStepReserved] != 1 {
+		t.Errorf("unexpected funnel counts: %v", counts)
+	}
+}
+
+func assertRate(t *testing.T, name string, got, want float64) {
+	t.Helper()
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("%s = %v, want %v", name, got, want)
+	}
+}
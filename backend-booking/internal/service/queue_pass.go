@@ -0,0 +1,77 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// QueuePassPurpose is the purpose claim of every queue pass
+const QueuePassPurpose = "queue_pass"
+
+// QueuePassClaims represents the claims for a queue pass JWT
+type QueuePassClaims struct {
+	UserID  string   `json:"user_id"`
+	EventID string   `json:"event_id"`
+	ZoneIDs []string `json:"zone_ids,omitempty"` // Zones the pass admits to (empty = all zones of the event)
+	Purpose string   `json:"purpose"`
+	jwt.RegisteredClaims
+}
+
+// AllowsZone reports whether the pass admits the user to a zone
+func (c *QueuePassClaims) AllowsZone(zoneID string) bool {
+	return len(c.ZoneIDs) == 0 || slices.Contains(c.ZoneIDs, zoneID)
+}
+
+// SignQueuePass generates a signed JWT queue pass token scoped to an event and,
+// if zoneIDs is not empty, to those zones
+func SignQueuePass(secret, issuer, userID, eventID string, zoneIDs []string, ttl time.Duration) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+
+	claims := QueuePassClaims{
+		UserID:  userID,
+		EventID: eventID,
+		ZoneIDs: zoneIDs,
+		Purpose: QueuePassPurpose,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    issuer,
+			Subject:   userID,
+			ID:        generateQueueToken(), // Unique JWT ID
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signedToken, err := token.SignedString([]byte(secret))
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign queue pass: %w", err)
+	}
+
+	return signedToken, expiresAt, nil
+}
+
+// ParseQueuePass verifies the signature and expiry of a queue pass and returns its claims.
+// It does not check who or what the pass is for.
+func ParseQueuePass(secret, queuePass string) (*QueuePassClaims, error) {
+	token, err := jwt.ParseWithClaims(queuePass, &QueuePassClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidQueuePass, err)
+	}
+
+	claims, ok := token.Claims.(*QueuePassClaims)
+	if !ok || !token.Valid || claims.Purpose != QueuePassPurpose {
+		return nil, domain.ErrInvalidQueuePass
+	}
+	return claims, nil
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+)
+
+// QueuePassRevocationsConfig contains configuration for queue pass revocations
+type QueuePassRevocationsConfig struct {
+	// Capacity is the number of revocations the filter is sized for (default: 100000)
+	Capacity int
+	// FalsePositiveRate is the filter's target false positive rate (default: 0.001)
+	FalsePositiveRate float64
+	// TTL is how long a revocation lasts; it must outlive the passes it revokes (default: 1 hour)
+	TTL time.Duration
+	// RefreshInterval is how often the filter is rebuilt from Redis (default: 2 seconds)
+	RefreshInterval time.Duration
+}
+
+// QueuePassRevocations answers whether a queue pass was revoked from an in-memory bloom
+// filter, so valid passes are accepted without a Redis lookup. Only a filter hit, which
+// is either a revocation or a rare false positive, is confirmed in Redis.
+type QueuePassRevocations struct {
+	repo              repository.QueuePassRevocationRepository
+	capacity          int
+	falsePositiveRate float64
+	ttl               time.Duration
+	refreshInterval   time.Duration
+
+	mu     sync.RWMutex
+	filter *bloomFilter
+}
+
+// NewQueuePassRevocations creates a new revocation list. Call Run to keep it in sync
+// with revocations made by other instances.
+func NewQueuePassRevocations(repo repository.QueuePassRevocationRepository, cfg *QueuePassRevocationsConfig) *QueuePassRevocations {
+	r := &QueuePassRevocations{
+		repo:              repo,
+		capacity:          100000,
+		falsePositiveRate: 0.001,
+		ttl:               time.Hour,
+		refreshInterval:   2 * time.Second,
+	}
+	if cfg != nil {
+		if cfg.Capacity > 0 {
+			r.capacity = cfg.Capacity
+		}
+		if cfg.FalsePositiveRate > 0 && cfg.FalsePositiveRate < 1 {
+			r.falsePositiveRate = cfg.FalsePositiveRate
+		}
+		if cfg.TTL > 0 {
+			r.ttl = cfg.TTL
+		}
+		if cfg.RefreshInterval > 0 {
+			r.refreshInterval = cfg.RefreshInterval
+		}
+	}
+	r.filter = newBloomFilter(r.capacity, r.falsePositiveRate)
+	return r
+}
+
+// Revoke rejects the passes a user holds for an event, or every pass for the event
+// if userID is empty
+func (r *QueuePassRevocations) Revoke(ctx context.Context, eventID, userID string) error {
+	entry := queuePassRevocationEntry(eventID, userID)
+	if err := r.repo.Revoke(ctx, entry, time.Now(), r.ttl); err != nil {
+		return err
+	}
+
+	// Apply locally at once; other instances pick it up on their next refresh
+	r.mu.Lock()
+	r.filter.add(entry)
+	r.mu.Unlock()
+	return nil
+}
+
+// Check returns domain.ErrQueuePassRevoked if the pass was issued before a revocation
+// that covers it
+func (r *QueuePassRevocations) Check(ctx context.Context, claims *QueuePassClaims) error {
+	for _, entry := range []string{
+		queuePassRevocationEntry(claims.EventID, ""),
+		queuePassRevocationEntry(claims.EventID, claims.UserID),
+	} {
+		r.mu.RLock()
+		hit := r.filter.mayContain(entry)
+		r.mu.RUnlock()
+		if !hit {
+			continue
+		}
+
+		revokedAt, ok, err := r.repo.GetRevokedAt(ctx, entry)
+		if err != nil {
+			return err
+		}
+		// Issue times have second precision, so a pass from the second of the
+		// revocation is rejected too
+		if ok && (claims.IssuedAt == nil || !claims.IssuedAt.Time.After(revokedAt)) {
+			return domain.ErrQueuePassRevoked
+		}
+	}
+	return nil
+}
+
+// Refresh rebuilds the filter from the revocations stored in Redis. Rebuilding also
+// drops expired revocations, which a bloom filter cannot remove.
+func (r *QueuePassRevocations) Refresh(ctx context.Context) error {
+	entries, err := r.repo.ListRevoked(ctx)
+	if err != nil {
+		return err
+	}
+
+	filter := newBloomFilter(max(r.capacity, len(entries)), r.falsePositiveRate)
+	for _, entry := range entries {
+		filter.add(entry)
+	}
+
+	r.mu.Lock()
+	r.filter = filter
+	r.mu.Unlock()
+	return nil
+}
+
+// Run refreshes the filter every RefreshInterval until ctx is done
+func (r *QueuePassRevocations) Run(ctx context.Context) {
+	_ = r.Refresh(ctx)
+
+	ticker := time.NewTicker(r.refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// On error the previous filter stays in use until the next refresh
+			_ = r.Refresh(ctx)
+		}
+	}
+}
+
+// queuePassRevocationEntry names the revocation of a user's passes for an event, or of
+// all passes for the event
+func queuePassRevocationEntry(eventID, userID string) string {
+	if userID == "" {
+		return "event:" + eventID
+	}
+	return "user:" + eventID + ":" + userID
+}
+
+// bloomFilter is a fixed-size bloom filter over strings. It is not safe for
+// concurrent use; QueuePassRevocations guards it with its mutex.
+type bloomFilter struct {
+	bits   []uint64
+	size   uint64
+	hashes uint64
+}
+
+// newBloomFilter sizes a filter for capacity entries at the given false positive rate
+func newBloomFilter(capacity int, falsePositiveRate float64) *bloomFilter {
+	n := float64(max(capacity, 1))
+	size := uint64(math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	hashes := uint64(math.Max(1, math.Round(float64(size)/n*math.Ln2)))
+	return &bloomFilter{
+		bits:   make([]uint64, (size+63)/64),
+		size:   size,
+		hashes: hashes,
+	}
+}
+
+// add inserts a value
+func (f *bloomFilter) add(value string) {
+	h1, h2 := bloomHashes(value)
+	for i := uint64(0); i < f.hashes; i++ {
+		bit := (h1 + i*h2) % f.size
+		f.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// mayContain reports false only if value was never added
+func (f *bloomFilter) mayContain(value string) bool {
+	h1, h2 := bloomHashes(value)
+	for i := uint64(0); i < f.hashes; i++ {
+		bit := (h1 + i*h2) % f.size
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// bloomHashes derives the two hashes combined into the filter's k bit positions
+func bloomHashes(value string) (uint64, uint64) {
+	h := fnv.New64a()
+	h.Write([]byte(value))
+	sum := h.Sum64()
+	// A zero step would put every probe on the same bit
+	return sum & math.MaxUint32, sum>>32 | 1
+}
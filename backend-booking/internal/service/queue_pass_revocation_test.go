@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// memoryRevocationRepository is an in-memory QueuePassRevocationRepository
+type memoryRevocationRepository struct {
+	revokedAt map[string]time.Time
+	lookups   int
+}
+
+func newMemoryRevocationRepository() *memoryRevocationRepository {
+	return &memoryRevocationRepository{revokedAt: make(map[string]time.Time)}
+}
+
+func (r *memoryRevocationRepository) Revoke(ctx context.Context, entry string, revokedAt time.Time, ttl time.Duration) error {
+	r.revokedAt[entry] = revokedAt
+	return nil
+}
+
+func (r *memoryRevocationRepository) GetRevokedAt(ctx context.Context, entry string) (time.Time, bool, error) {
+	r.lookups++
+	revokedAt, ok := r.revokedAt[entry]
+	return revokedAt, ok, nil
+}
+
+func (r *memoryRevocationRepository) ListRevoked(ctx context.Context) ([]string, error) {
+	entries := make([]string, 0, len(r.revokedAt))
+	for entry := range r.revokedAt {
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func testQueuePassClaims(userID, eventID string, issuedAt time.Time) *QueuePassClaims {
+	return &QueuePassClaims{
+		UserID:           userID,
+		EventID:          eventID,
+		Purpose:          QueuePassPurpose,
+		RegisteredClaims: jwt.RegisteredClaims{IssuedAt: jwt.NewNumericDate(issuedAt)},
+	}
+}
+
+func TestBloomFilter(t *testing.T) {
+	filter := newBloomFilter(1000, 0.01)
+	for i := 0; i < 1000; i++ {
+		filter.add(fmt.Sprintf("user:event-1:user-%d", i))
+	}
+
+	for i := 0; i < 1000; i++ {
+		assert.True(t, filter.mayContain(fmt.Sprintf("user:event-1:user-%d", i)))
+	}
+
+	falsePositives := 0
+	for i := 0; i < 10000; i++ {
+		if filter.mayContain(fmt.Sprintf("user:event-2:user-%d", i)) {
+			falsePositives++
+		}
+	}
+	// 1% target; allow headroom for hash variance
+	assert.Less(t, falsePositives, 300)
+}
+
+func TestQueuePassRevocations_Check(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryRevocationRepository()
+	revocations := NewQueuePassRevocations(repo, nil)
+
+	// Nothing revoked: no Redis lookup at all
+	assert.NoError(t, revocations.Check(ctx, testQueuePassClaims("user-1", "event-1", time.Now())))
+	assert.Equal(t, 0, repo.lookups)
+
+	assert.NoError(t, revocations.Revoke(ctx, "event-1", "user-1"))
+
+	// Passes issued before the revocation are rejected, later ones are accepted
+	assert.ErrorIs(t, revocations.Check(ctx, testQueuePassClaims("user-1", "event-1", time.Now().Add(-time.Minute))), domain.ErrQueuePassRevoked)
+	assert.NoError(t, revocations.Check(ctx, testQueuePassClaims("user-1", "event-1", time.Now().Add(time.Minute))))
+
+	// Other users of the event are unaffected
+	assert.NoError(t, revocations.Check(ctx, testQueuePassClaims("user-2", "event-1", time.Now().Add(-time.Minute))))
+
+	// Revoking the event covers every user
+	assert.NoError(t, revocations.Revoke(ctx, "event-1", ""))
+	assert.ErrorIs(t, revocations.Check(ctx, testQueuePassClaims("user-2", "event-1", time.Now().Add(-time.Minute))), domain.ErrQueuePassRevoked)
+}
+
+func TestQueuePassRevocations_Refresh(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryRevocationRepository()
+	revocations := NewQueuePassRevocations(repo, nil)
+	issuedAt := time.Now().Add(-time.Minute)
+
+	// A revocation made by another instance is picked up on refresh
+	repo.revokedAt[queuePassRevocationEntry("event-1", "user-1")] = time.Now()
+	assert.NoError(t, revocations.Check(ctx, testQueuePassClaims("user-1", "event-1", issuedAt)))
+	assert.NoError(t, revocations.Refresh(ctx))
+	assert.ErrorIs(t, revocations.Check(ctx, testQueuePassClaims("user-1", "event-1", issuedAt)), domain.ErrQueuePassRevoked)
+
+	// An expired revocation leaves the filter on the next refresh
+	delete(repo.revokedAt, queuePassRevocationEntry("event-1", "user-1"))
+	assert.NoError(t, revocations.Refresh(ctx))
+	lookups := repo.lookups
+	assert.NoError(t, revocations.Check(ctx, testQueuePassClaims("user-1", "event-1", issuedAt)))
+	assert.Equal(t, lookups, repo.lookups)
+}
+
+func TestQueueService_ValidateQueuePass_Stateless(t *testing.T) {
+	ctx := context.Background()
+	secret := "test-secret-key"
+	mockRepo := new(MockQueueRepository)
+	revocations := NewQueuePassRevocations(newMemoryRevocationRepository(), nil)
+	service := NewQueueService(mockRepo, &QueueServiceConfig{
+		JWTSecret:       secret,
+		StatelessPasses: true,
+		Revocations:     revocations,
+	})
+
+	pass, _, err := SignQueuePass(secret, "queue-release-worker", "user-1", "event-1", []string{"zone-a"}, time.Minute)
+	assert.NoError(t, err)
+
+	// Verified without touching Redis
+	assert.NoError(t, service.ValidateQueuePass(ctx, "user-1", "event-1", "zone-a", pass))
+	mockRepo.AssertNotCalled(t, "ValidateQueuePass", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	assert.ErrorIs(t, service.ValidateQueuePass(ctx, "user-1", "event-1", "zone-b", pass), domain.ErrQueuePassZoneMismatch)
+	assert.ErrorIs(t, service.ValidateQueuePass(ctx, "user-2", "event-1", "zone-a", pass), domain.ErrQueuePassUserMismatch)
+	assert.ErrorIs(t, service.ValidateQueuePass(ctx, "user-1", "event-2", "zone-a", pass), domain.ErrQueuePassEventMismatch)
+
+	forged, _, err := SignQueuePass("other-secret", "queue-release-worker", "user-1", "event-1", nil, time.Minute)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, service.ValidateQueuePass(ctx, "user-1", "event-1", "zone-a", forged), domain.ErrInvalidQueuePass)
+
+	expired, _, err := SignQueuePass(secret, "queue-release-worker", "user-1", "event-1", nil, -time.Minute)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, service.ValidateQueuePass(ctx, "user-1", "event-1", "zone-a", expired), domain.ErrInvalidQueuePass)
+}
+
+func TestQueueService_RevokeQueuePasses(t *testing.T) {
+	ctx := context.Background()
+	secret := "test-secret-key"
+	mockRepo := new(MockQueueRepository)
+	service := NewQueueService(mockRepo, &QueueServiceConfig{
+		JWTSecret:       secret,
+		StatelessPasses: true,
+		Revocations:     NewQueuePassRevocations(newMemoryRevocationRepository(), nil),
+	})
+
+	pass, _, err := SignQueuePass(secret, "queue-release-worker", "user-1", "event-1", nil, time.Minute)
+	assert.NoError(t, err)
+
+	// The pass was issued before the revocation
+	mockRepo.On("DeleteQueuePass", mock.Anything, "event-1", "user-1").Return(nil)
+	assert.NoError(t, service.RevokeQueuePasses(ctx, "event-1", "user-1"))
+	assert.ErrorIs(t, service.ValidateQueuePass(ctx, "user-1", "event-1", "zone-a", pass), domain.ErrQueuePassRevoked)
+	mockRepo.AssertExpectations(t)
+
+	unconfigured := NewQueueService(mockRepo, &QueueServiceConfig{JWTSecret: secret})
+	assert.Error(t, unconfigured.RevokeQueuePasses(ctx, "event-1", "user-1"))
+}
@@ -8,7 +8,6 @@ import (
 	"strconv"
 	"time"
 
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
@@ -31,11 +30,16 @@ type QueueService interface {
 	// GetQueueStatus gets the queue status for an event
 	GetQueueStatus(ctx context.Context, eventID string) (*dto.QueueStatusResponse, error)
 
-	// ValidateQueuePass validates the queue pass JWT and checks Redis
-	ValidateQueuePass(ctx context.Context, userID, eventID, queuePass string) error
+	// ValidateQueuePass validates the queue pass JWT for a zone of the event and,
+	// unless passes are stateless, checks Redis
+	ValidateQueuePass(ctx context.Context, userID, eventID, zoneID, queuePass string) error
 
 	// DeleteQueuePass removes the queue pass after successful booking
 	DeleteQueuePass(ctx context.Context, userID, eventID string) error
+
+	// RevokeQueuePasses rejects the passes a user holds for an event, or every pass
+	// for the event if userID is empty. Passes issued afterwards are accepted.
+	RevokeQueuePasses(ctx context.Context, eventID, userID string) error
 }
 
 // queueService implements QueueService
@@ -46,6 +50,9 @@ type queueService struct {
 	estimatedWaitPerUser int64 // seconds per user in queue
 	queuePassTTL         time.Duration
 	jwtSecret            string
+	statelessPasses      bool
+	revocations          *QueuePassRevocations
+	analytics            QueueAnalyticsRecorder
 }
 
 // QueueServiceConfig contains configuration for queue service
@@ -55,6 +62,12 @@ type QueueServiceConfig struct {
 	EstimatedWaitPerUser int64
 	QueuePassTTL         time.Duration // TTL for queue pass token (default: 5 minutes)
 	JWTSecret            string        // Secret for signing queue pass JWT
+	// StatelessPasses verifies queue passes by signature alone, without a Redis lookup
+	StatelessPasses bool
+	// Revocations rejects revoked passes (nil = passes cannot be revoked)
+	Revocations *QueuePassRevocations
+	// Analytics counts joins, leaves and reservations for queue funnels (optional)
+	Analytics QueueAnalyticsRecorder
 }
 
 // NewQueueService creates a new queue service
@@ -67,6 +80,9 @@ func NewQueueService(
 	estimatedWait := int64(3) // 3 seconds per user
 	queuePassTTL := 5 * time.Minute
 	jwtSecret := "" // Must be provided via config
+	var statelessPasses bool
+	var revocations *QueuePassRevocations
+	var analytics QueueAnalyticsRecorder
 
 	if cfg != nil {
 		if cfg.QueueTTL > 0 {
@@ -82,6 +98,9 @@ func NewQueueService(
 			queuePassTTL = cfg.QueuePassTTL
 		}
 		jwtSecret = cfg.JWTSecret
+		statelessPasses = cfg.StatelessPasses
+		revocations = cfg.Revocations
+		analytics = cfg.Analytics
 	}
 
 	if jwtSecret == "" {
@@ -95,6 +114,9 @@ func NewQueueService(
 		estimatedWaitPerUser: estimatedWait,
 		queuePassTTL:         queuePassTTL,
 		jwtSecret:            jwtSecret,
+		statelessPasses:      statelessPasses,
+		revocations:          revocations,
+		analytics:            analytics,
 	}
 }
 
@@ -155,6 +177,8 @@ func (s *queueService) JoinQueue(ctx context.Context, userID string, req *dto.Jo
 		}
 	}
 
+	s.recordStep(ctx, req.EventID, domain.QueueStepJoined)
+
 	// Calculate estimated wait time
 	estimatedWait := result.Position * s.estimatedWaitPerUser
 
@@ -247,7 +271,7 @@ func (s *queueService) GetPosition(ctx context.Context, userID, eventID string)
 
 	// Generate queue pass when user is ready (position = 1)
 	if isReady {
-		queuePass, queuePassExpiresAt, err := s.generateQueuePass(userID, eventID)
+		queuePass, queuePassExpiresAt, err := s.generateQueuePass(ctx, userID, eventID)
 		if err != nil {
 			// Log error but don't fail the request
 			// The user can still see their position
@@ -304,6 +328,7 @@ func (s *queueService) LeaveQueue(ctx context.Context, userID string, req *dto.L
 		span.SetStatus(codes.Error, err.Error())
 		return nil, err
 	}
+	s.recordStep(ctx, req.EventID, domain.QueueStepLeft)
 
 	span.SetStatus(codes.Ok, "")
 	return &dto.LeaveQueueResponse{
@@ -365,50 +390,26 @@ func parseTimestamp(s string) (int64, error) {
 	return 0, fmt.Errorf("cannot parse timestamp: %s", s)
 }
 
-// QueuePassClaims represents the claims for a queue pass JWT
-type QueuePassClaims struct {
-	UserID  string `json:"user_id"`
-	EventID string `json:"event_id"`
-	Purpose string `json:"purpose"`
-	jwt.RegisteredClaims
-}
-
 // generateQueuePass generates a signed JWT queue pass token
-func (s *queueService) generateQueuePass(userID, eventID string) (string, time.Time, error) {
-	now := time.Now()
-	expiresAt := now.Add(s.queuePassTTL)
-
-	claims := QueuePassClaims{
-		UserID:  userID,
-		EventID: eventID,
-		Purpose: "queue_pass",
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expiresAt),
-			IssuedAt:  jwt.NewNumericDate(now),
-			NotBefore: jwt.NewNumericDate(now),
-			Issuer:    "booking-service",
-			Subject:   userID,
-			ID:        generateQueueToken(), // Unique JWT ID
-		},
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err := token.SignedString([]byte(s.jwtSecret))
-	if err != nil {
-		return "", time.Time{}, fmt.Errorf("failed to sign queue pass: %w", err)
+func (s *queueService) generateQueuePass(ctx context.Context, userID, eventID string) (string, time.Time, error) {
+	var zoneIDs []string
+	if config, err := s.queueRepo.GetEventQueueConfig(ctx, eventID); err == nil && config != nil {
+		zoneIDs = config.PassZoneIDs
 	}
-
-	return signedToken, expiresAt, nil
+	return SignQueuePass(s.jwtSecret, "booking-service", userID, eventID, zoneIDs, s.queuePassTTL)
 }
 
-// ValidateQueuePass validates the queue pass JWT and checks Redis
-func (s *queueService) ValidateQueuePass(ctx context.Context, userID, eventID, queuePass string) error {
+// ValidateQueuePass validates the queue pass JWT and checks Redis. Stateless passes
+// are only checked against the in-memory revocation filter.
+func (s *queueService) ValidateQueuePass(ctx context.Context, userID, eventID, zoneID, queuePass string) error {
 	ctx, span := telemetry.StartSpan(ctx, "service.queue.validate_pass")
 	defer span.End()
 
 	span.SetAttributes(
 		attribute.String("user_id", userID),
 		attribute.String("event_id", eventID),
+		attribute.String("zone_id", zoneID),
+		attribute.Bool("stateless", s.statelessPasses),
 	)
 
 	if queuePass == "" {
@@ -417,25 +418,13 @@ func (s *queueService) ValidateQueuePass(ctx context.Context, userID, eventID, q
 	}
 
 	// Parse and validate JWT
-	token, err := jwt.ParseWithClaims(queuePass, &QueuePassClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(s.jwtSecret), nil
-	})
-
+	claims, err := ParseQueuePass(s.jwtSecret, queuePass)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "invalid queue pass")
 		return domain.ErrInvalidQueuePass
 	}
 
-	claims, ok := token.Claims.(*QueuePassClaims)
-	if !ok || !token.Valid {
-		span.SetStatus(codes.Error, "invalid queue pass claims")
-		return domain.ErrInvalidQueuePass
-	}
-
 	// Verify claims match
 	if claims.UserID != userID {
 		span.SetStatus(codes.Error, "queue pass user mismatch")
@@ -447,9 +436,23 @@ func (s *queueService) ValidateQueuePass(ctx context.Context, userID, eventID, q
 		return domain.ErrQueuePassEventMismatch
 	}
 
-	if claims.Purpose != "queue_pass" {
-		span.SetStatus(codes.Error, "invalid queue pass purpose")
-		return domain.ErrInvalidQueuePass
+	if !claims.AllowsZone(zoneID) {
+		span.SetStatus(codes.Error, "queue pass zone mismatch")
+		return domain.ErrQueuePassZoneMismatch
+	}
+
+	if s.revocations != nil {
+		if err := s.revocations.Check(ctx, claims); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return err
+		}
+	}
+
+	// A stateless pass is trusted until it expires; its short TTL bounds reuse
+	if s.statelessPasses {
+		span.SetStatus(codes.Ok, "")
+		return nil
 	}
 
 	// Validate against Redis (check if not already used/expired)
@@ -485,6 +488,50 @@ func (s *queueService) DeleteQueuePass(ctx context.Context, userID, eventID stri
 		return err
 	}
 
+	// The pass is only deleted once it was used to reserve
+	s.recordStep(ctx, eventID, domain.QueueStepReserved)
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// recordStep counts a user reaching a funnel step, when analytics are enabled
+func (s *queueService) recordStep(ctx context.Context, eventID string, step domain.QueueFunnelStep) {
+	if s.analytics != nil {
+		s.analytics.RecordStep(ctx, eventID, step, 1)
+	}
+}
+
+// RevokeQueuePasses rejects the passes a user holds for an event, or every pass for the event
+func (s *queueService) RevokeQueuePasses(ctx context.Context, eventID, userID string) error {
+	ctx, span := telemetry.StartSpan(ctx, "service.queue.revoke_passes")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("event_id", eventID),
+		attribute.String("user_id", userID),
+	)
+
+	if eventID == "" {
+		span.SetStatus(codes.Error, "invalid event_id")
+		return domain.ErrInvalidEventID
+	}
+	if s.revocations == nil {
+		span.SetStatus(codes.Error, "revocation not configured")
+		return fmt.Errorf("queue pass revocation is not configured")
+	}
+
+	if err := s.revocations.Revoke(ctx, eventID, userID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	// The stored pass also counts against release capacity; free the slot
+	if userID != "" {
+		_ = s.queueRepo.DeleteQueuePass(ctx, eventID, userID)
+	}
+
 	span.SetStatus(codes.Ok, "")
 	return nil
 }
@@ -299,6 +299,7 @@ func TestQueueService_GetPosition_IsReady(t *testing.T) {
 
 	mockRepo.On("GetPosition", mock.Anything, "event-123", "user-123").Return(expectedResult, nil)
 	mockRepo.On("GetUserQueueInfo", mock.Anything, "event-123", "user-123").Return(userInfo, nil)
+	mockRepo.On("GetEventQueueConfig", mock.Anything, "event-123").Return(nil, nil)
 	mockRepo.On("StoreQueuePass", mock.Anything, "event-123", "user-123", mock.AnythingOfType("string"), 300).Return(nil)
 
 	result, err := service.GetPosition(context.Background(), "user-123", "event-123")
@@ -448,6 +449,7 @@ func TestQueueService_GetPosition_QueuePassGeneration(t *testing.T) {
 
 	mockRepo.On("GetPosition", mock.Anything, "event-456", "user-789").Return(expectedResult, nil)
 	mockRepo.On("GetUserQueueInfo", mock.Anything, "event-456", "user-789").Return(userInfo, nil)
+	mockRepo.On("GetEventQueueConfig", mock.Anything, "event-456").Return(nil, nil)
 	mockRepo.On("StoreQueuePass", mock.Anything, "event-456", "user-789", mock.AnythingOfType("string"), 300).Return(nil)
 
 	result, err := service.GetPosition(context.Background(), "user-789", "event-456")
@@ -514,6 +516,7 @@ func TestQueueService_GetPosition_QueuePassStoreFails(t *testing.T) {
 	mockRepo.On("GetPosition", mock.Anything, "event-123", "user-123").Return(expectedResult, nil)
 	mockRepo.On("GetUserQueueInfo", mock.Anything, "event-123", "user-123").Return(userInfo, nil)
 	// Simulate Redis store failure
+	mockRepo.On("GetEventQueueConfig", mock.Anything, "event-123").Return(nil, nil)
 	mockRepo.On("StoreQueuePass", mock.Anything, "event-123", "user-123", mock.AnythingOfType("string"), 300).Return(assert.AnError)
 
 	result, err := service.GetPosition(context.Background(), "user-123", "event-123")
@@ -545,6 +548,7 @@ func TestQueueService_QueuePassJWTFormat(t *testing.T) {
 
 	mockRepo.On("GetPosition", mock.Anything, "event-123", "user-123").Return(expectedResult, nil)
 	mockRepo.On("GetUserQueueInfo", mock.Anything, "event-123", "user-123").Return(userInfo, nil)
+	mockRepo.On("GetEventQueueConfig", mock.Anything, "event-123").Return(nil, nil)
 	mockRepo.On("StoreQueuePass", mock.Anything, "event-123", "user-123", mock.AnythingOfType("string"), 300).Return(nil)
 
 	result, err := service.GetPosition(context.Background(), "user-123", "event-123")
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/saga"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// RefundBatchService cancels shows and refunds their confirmed bookings in bulk
+type RefundBatchService interface {
+	// CancelShow marks the show cancelled in ticket service and queues a refund for every
+	// confirmed booking. Calling it again for the same show returns the same batch.
+	CancelShow(ctx context.Context, showID string, req *dto.CancelShowRequest, requestedBy string) (*domain.RefundBatch, error)
+
+	// GetBatch returns a batch with its per-booking results, optionally only the items in status
+	GetBatch(ctx context.Context, id string, status domain.RefundItemStatus) (*domain.RefundBatch, error)
+
+	// DispatchPending sends refund commands for up to limit pending items, hiding them from
+	// other workers for lease. Returns the number of commands sent.
+	DispatchPending(ctx context.Context, limit int, lease time.Duration) (int, error)
+
+	// RecordRefundResult stores the outcome of a payment.refunded or payment.refund_failed event.
+	// Returns false if the booking has no outstanding refund.
+	RecordRefundResult(ctx context.Context, bookingID string, refunded bool, errMsg string) (bool, error)
+}
+
+// refundBatchService implements RefundBatchService
+type refundBatchService struct {
+	batchRepo     repository.RefundBatchRepository
+	showCanceller ShowCanceller
+	producer      saga.SagaProducer
+}
+
+// NewRefundBatchService creates a new refund batch service.
+// showCanceller is only needed by CancelShow and producer only by DispatchPending.
+func NewRefundBatchService(
+	batchRepo repository.RefundBatchRepository,
+	showCanceller ShowCanceller,
+	producer saga.SagaProducer,
+) RefundBatchService {
+	return &refundBatchService{
+		batchRepo:     batchRepo,
+		showCanceller: showCanceller,
+		producer:      producer,
+	}
+}
+
+// CancelShow marks the show cancelled and queues refunds for its confirmed bookings.
+// The show is cancelled first so no further bookings are sold while the batch is built.
+func (s *refundBatchService) CancelShow(ctx context.Context, showID string, req *dto.CancelShowRequest, requestedBy string) (*domain.RefundBatch, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.refund_batch.cancel_show")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("show_id", showID),
+		attribute.String("requested_by", requestedBy),
+	)
+
+	if _, err := uuid.Parse(showID); err != nil {
+		span.SetStatus(codes.Error, "invalid show id")
+		return nil, domain.ErrInvalidShowID
+	}
+	if s.showCanceller == nil {
+		err := fmt.Errorf("%w: ticket service is not configured", domain.ErrShowCancelFailed)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if err := s.showCanceller.CancelShow(ctx, showID, requestedBy); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	reason := ""
+	if req != nil {
+		reason = req.Reason
+	}
+
+	batch, err := s.batchRepo.CreateForShow(ctx, domain.NewRefundBatch(showID, reason, requestedBy))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	logger.Get().Info(fmt.Sprintf("Show cancelled, refunds queued: show_id=%s, batch_id=%s, bookings=%d, requested_by=%s",
+		showID, batch.ID, batch.Summary.Total, requestedBy))
+
+	span.SetAttributes(
+		attribute.String("batch_id", batch.ID),
+		attribute.Int("bookings", batch.Summary.Total),
+	)
+	span.SetStatus(codes.Ok, "")
+	return batch, nil
+}
+
+// GetBatch returns a batch with its per-booking results.
+// The summary always covers the whole batch; status only filters the listed items.
+func (s *refundBatchService) GetBatch(ctx context.Context, id string, status domain.RefundItemStatus) (*domain.RefundBatch, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.refund_batch.get")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("batch_id", id),
+		attribute.String("status_filter", status.String()),
+	)
+
+	if status != "" && !status.IsValid() {
+		span.SetStatus(codes.Error, "invalid status")
+		return nil, domain.ErrInvalidRefundItemStatus
+	}
+
+	batch, err := s.batchRepo.GetByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if status != "" {
+		items := make([]*domain.RefundBatchItem, 0, len(batch.Items))
+		for _, item := range batch.Items {
+			if item.Status == status {
+				items = append(items, item)
+			}
+		}
+		batch.Items = items
+	}
+
+	span.SetAttributes(attribute.String("status", batch.Status.String()))
+	span.SetStatus(codes.Ok, "")
+	return batch, nil
+}
+
+// DispatchPending sends a refund-payment compensation command per claimed item.
+// Items whose command could not be sent stay pending and are claimed again after the lease.
+func (s *refundBatchService) DispatchPending(ctx context.Context, limit int, lease time.Duration) (int, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.refund_batch.dispatch_pending")
+	defer span.End()
+
+	if s.producer == nil {
+		err := fmt.Errorf("saga producer is not available")
+		span.SetStatus(codes.Error, err.Error())
+		return 0, err
+	}
+
+	items, err := s.batchRepo.ClaimPending(ctx, limit, lease)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return 0, err
+	}
+
+	log := logger.Get()
+	sent := 0
+	for _, item := range items {
+		data := &saga.BookingSagaData{
+			BookingID:  item.BookingID,
+			UserID:     item.UserID,
+			TenantID:   item.TenantID,
+			TotalPrice: item.Amount,
+			Currency:   item.Currency,
+			PaymentID:  item.PaymentID,
+		}
+
+		// Keyed by booking ID like manual compensations, so commands stay ordered per booking
+		command := saga.NewCompensationCommand(
+			item.BookingID,
+			saga.BookingSagaName,
+			saga.StepProcessPayment,
+			0,
+			data.ToMap(),
+			domain.DefaultShowCancelledReason,
+		)
+		if err := s.producer.SendCompensationCommand(ctx, command); err != nil {
+			log.Warn(fmt.Sprintf("Failed to send refund command: batch_id=%s, booking_id=%s, error=%v", item.BatchID, item.BookingID, err))
+			continue
+		}
+
+		if err := s.batchRepo.MarkRequested(ctx, item.BatchID, item.BookingID); err != nil {
+			// The refund is on its way; a resend after the lease is ignored by payment-service
+			log.Warn(fmt.Sprintf("Failed to mark refund requested: batch_id=%s, booking_id=%s, error=%v", item.BatchID, item.BookingID, err))
+		}
+		sent++
+	}
+
+	span.SetAttributes(
+		attribute.Int("claimed", len(items)),
+		attribute.Int("sent", sent),
+	)
+	span.SetStatus(codes.Ok, "")
+	return sent, nil
+}
+
+// RecordRefundResult stores the outcome of a refund reported by payment-service
+func (s *refundBatchService) RecordRefundResult(ctx context.Context, bookingID string, refunded bool, errMsg string) (bool, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.refund_batch.record_result")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("booking_id", bookingID),
+		attribute.Bool("refunded", refunded),
+	)
+
+	recorded, err := s.batchRepo.RecordResult(ctx, bookingID, refunded, errMsg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return false, err
+	}
+
+	span.SetAttributes(attribute.Bool("recorded", recorded))
+	span.SetStatus(codes.Ok, "")
+	return recorded, nil
+}
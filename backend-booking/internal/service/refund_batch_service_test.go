@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/saga"
+)
+
+const testShowID = "6f1c2b9e-8a4d-4b1e-9c3f-2d5e7a9b1c4d"
+
+// MockRefundBatchRepository is an in-memory RefundBatchRepository
+type MockRefundBatchRepository struct {
+	Batch     *domain.RefundBatch
+	Requested []string
+}
+
+func (m *MockRefundBatchRepository) CreateForShow(ctx context.Context, batch *domain.RefundBatch) (*domain.RefundBatch, error) {
+	if m.Batch == nil {
+		batch.ID = "batch-1"
+		m.Batch = batch
+	}
+	m.Batch.Summarize()
+	return m.Batch, nil
+}
+
+func (m *MockRefundBatchRepository) GetByID(ctx context.Context, id string) (*domain.RefundBatch, error) {
+	if m.Batch == nil || m.Batch.ID != id {
+		return nil, domain.ErrRefundBatchNotFound
+	}
+	copied := *m.Batch
+	copied.Summarize()
+	return &copied, nil
+}
+
+func (m *MockRefundBatchRepository) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*domain.RefundBatchItem, error) {
+	var items []*domain.RefundBatchItem
+	for _, item := range m.Batch.Items {
+		if item.Status == domain.RefundItemPending && len(items) < limit {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (m *MockRefundBatchRepository) MarkRequested(ctx context.Context, batchID, bookingID string) error {
+	m.Requested = append(m.Requested, bookingID)
+	for _, item := range m.Batch.Items {
+		if item.BookingID == bookingID && item.Status == domain.RefundItemPending {
+			item.Status = domain.RefundItemRequested
+		}
+	}
+	return nil
+}
+
+func (m *MockRefundBatchRepository) RecordResult(ctx context.Context, bookingID string, refunded bool, errMsg string) (bool, error) {
+	for _, item := range m.Batch.Items {
+		if item.BookingID != bookingID || item.Status.IsFinal() {
+			continue
+		}
+		item.Status = domain.RefundItemFailed
+		item.Error = errMsg
+		if refunded {
+			item.Status = domain.RefundItemRefunded
+			item.Error = ""
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// mockShowCanceller records cancelled shows
+type mockShowCanceller struct {
+	cancelled []string
+	err       error
+}
+
+func (m *mockShowCanceller) CancelShow(ctx context.Context, showID, requestedBy string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.cancelled = append(m.cancelled, showID)
+	return nil
+}
+
+func newTestRefundBatch() *domain.RefundBatch {
+	batch := domain.NewRefundBatch(testShowID, "", "admin-1")
+	batch.ID = "batch-1"
+	batch.Items = []*domain.RefundBatchItem{
+		{BatchID: "batch-1", BookingID: "booking-1", PaymentID: "pay-1", Amount: 1000, Currency: "THB", Status: domain.RefundItemPending},
+		{BatchID: "batch-1", BookingID: "booking-2", PaymentID: "pay-2", Amount: 500, Currency: "THB", Status: domain.RefundItemPending},
+		{BatchID: "batch-1", BookingID: "booking-3", Amount: 0, Currency: "THB", Status: domain.RefundItemSkipped},
+	}
+	return batch
+}
+
+func TestRefundBatchService_CancelShow(t *testing.T) {
+	repo := &MockRefundBatchRepository{}
+	canceller := &mockShowCanceller{}
+	svc := NewRefundBatchService(repo, canceller, nil)
+
+	batch, err := svc.CancelShow(context.Background(), testShowID, &dto.CancelShowRequest{}, "admin-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(canceller.cancelled) != 1 || canceller.cancelled[0] != testShowID {
+		t.Errorf("expected show to be cancelled in ticket service, got %v", canceller.cancelled)
+	}
+	if batch.ShowID != testShowID || batch.RequestedBy != "admin-1" {
+		t.Errorf("unexpected batch: %+v", batch)
+	}
+	if batch.Reason != domain.DefaultShowCancelledReason {
+		t.Errorf("Reason = %q, want %q", batch.Reason, domain.DefaultShowCancelledReason)
+	}
+}
+
+func TestRefundBatchService_CancelShow_TicketServiceFails(t *testing.T) {
+	repo := &MockRefundBatchRepository{}
+	svc := NewRefundBatchService(repo, &mockShowCanceller{err: domain.ErrShowNotFound}, nil)
+
+	if _, err := svc.CancelShow(context.Background(), testShowID, nil, "admin-1"); !errors.Is(err, domain.ErrShowNotFound) {
+		t.Fatalf("expected ErrShowNotFound, got %v", err)
+	}
+	if repo.Batch != nil {
+		t.Error("refunds must not be queued when the show could not be cancelled")
+	}
+}
+
+func TestRefundBatchService_CancelShow_InvalidShowID(t *testing.T) {
+	svc := NewRefundBatchService(&MockRefundBatchRepository{}, &mockShowCanceller{}, nil)
+
+	if _, err := svc.CancelShow(context.Background(), "not-a-uuid", nil, "admin-1"); !errors.Is(err, domain.ErrInvalidShowID) {
+		t.Errorf("expected ErrInvalidShowID, got %v", err)
+	}
+}
+
+func TestRefundBatchService_DispatchAndRecord(t *testing.T) {
+	repo := &MockRefundBatchRepository{Batch: newTestRefundBatch()}
+	producer := saga.NewMockSagaProducer()
+	svc := NewRefundBatchService(repo, nil, producer)
+	ctx := context.Background()
+
+	sent, err := svc.DispatchPending(ctx, 10, time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sent != 2 || len(producer.CompensationCommands) != 2 {
+		t.Fatalf("expected 2 refund commands, sent=%d commands=%d", sent, len(producer.CompensationCommands))
+	}
+	command := producer.CompensationCommands[0]
+	if command.StepName != saga.StepProcessPayment || command.SagaID != "booking-1" {
+		t.Errorf("unexpected command: step=%s key=%s", command.StepName, command.SagaID)
+	}
+	if len(repo.Requested) != 2 {
+		t.Errorf("expected both items marked requested, got %v", repo.Requested)
+	}
+
+	// A second poll finds nothing left to send
+	if sent, _ := svc.DispatchPending(ctx, 10, time.Minute); sent != 0 {
+		t.Errorf("expected no commands on second poll, got %d", sent)
+	}
+
+	if _, err := svc.RecordRefundResult(ctx, "booking-1", true, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := svc.RecordRefundResult(ctx, "booking-2", false, "gateway timeout"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	batch, err := svc.GetBatch(ctx, "batch-1", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if batch.Status != domain.RefundBatchPartial {
+		t.Errorf("Status = %v, want %v", batch.Status, domain.RefundBatchPartial)
+	}
+	if batch.Summary.Refunded != 1 || batch.Summary.Failed != 1 || batch.Summary.Skipped != 1 {
+		t.Errorf("unexpected summary: %+v", batch.Summary)
+	}
+
+	failed, err := svc.GetBatch(ctx, "batch-1", domain.RefundItemFailed)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(failed.Items) != 1 || failed.Items[0].BookingID != "booking-2" || failed.Items[0].Error != "gateway timeout" {
+		t.Errorf("unexpected failed items: %+v", failed.Items)
+	}
+	if failed.Summary.Total != 3 {
+		t.Errorf("summary must cover the whole batch, got total %d", failed.Summary.Total)
+	}
+}
+
+func TestRefundBatchService_DispatchPending_SendFailureStaysPending(t *testing.T) {
+	repo := &MockRefundBatchRepository{Batch: newTestRefundBatch()}
+	producer := saga.NewMockSagaProducer()
+	producer.ShouldFail = true
+	svc := NewRefundBatchService(repo, nil, producer)
+
+	sent, err := svc.DispatchPending(context.Background(), 10, time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sent != 0 || len(repo.Requested) != 0 {
+		t.Errorf("expected nothing marked requested, sent=%d requested=%v", sent, repo.Requested)
+	}
+}
+
+func TestRefundBatchService_GetBatch_InvalidStatus(t *testing.T) {
+	svc := NewRefundBatchService(&MockRefundBatchRepository{Batch: newTestRefundBatch()}, nil, nil)
+
+	if _, err := svc.GetBatch(context.Background(), "batch-1", "unknown"); !errors.Is(err, domain.ErrInvalidRefundItemStatus) {
+		t.Errorf("expected ErrInvalidRefundItemStatus, got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// ReleaseLinkPurpose is the purpose claim of every release link token
+const ReleaseLinkPurpose = "idle_release"
+
+// ReleaseLinkClaims represents the claims of a release link token. The token releases
+// the booking as it was when the link was sent; a hold extended or handed to a payment
+// since then invalidates it. The holder is not a user_id claim, so the token never
+// passes as an access token.
+type ReleaseLinkClaims struct {
+	BookingID string `json:"booking_id"`
+	UserID    string `json:"holder_id"`
+	Version   int64  `json:"version"` // Booking UpdatedAt in Unix nanoseconds
+	Purpose   string `json:"purpose"`
+	jwt.RegisteredClaims
+}
+
+// Matches reports whether the token was issued for this booking in its current state
+func (c *ReleaseLinkClaims) Matches(booking *domain.Booking) bool {
+	return c.BookingID == booking.ID && c.UserID == booking.UserID && c.Version == booking.UpdatedAt.UnixNano()
+}
+
+// SignReleaseLink signs a release link token for booking that is valid until the hold expires
+func SignReleaseLink(secret string, booking *domain.Booking, issuedAt time.Time) (string, error) {
+	claims := ReleaseLinkClaims{
+		BookingID: booking.ID,
+		UserID:    booking.UserID,
+		Version:   booking.UpdatedAt.UnixNano(),
+		Purpose:   ReleaseLinkPurpose,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(booking.ExpiresAt),
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signedToken, err := token.SignedString(purposeKey(secret, ReleaseLinkPurpose))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign release link: %w", err)
+	}
+	return signedToken, nil
+}
+
+// ParseReleaseLink verifies the signature and expiry of a release link token.
+// It does not check the booking is unchanged.
+func ParseReleaseLink(secret, linkToken string) (*ReleaseLinkClaims, error) {
+	token, err := jwt.ParseWithClaims(linkToken, &ReleaseLinkClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return purposeKey(secret, ReleaseLinkPurpose), nil
+	})
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, domain.ErrReleaseLinkExpired
+		}
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidReleaseLink, err)
+	}
+
+	claims, ok := token.Claims.(*ReleaseLinkClaims)
+	if !ok || !token.Valid || claims.Purpose != ReleaseLinkPurpose {
+		return nil, domain.ErrInvalidReleaseLink
+	}
+	return claims, nil
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/i18n"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
+)
+
+// DefaultReportTopic is the Kafka topic organizer report emails are published to
+const DefaultReportTopic = "booking.organizer-reports"
+
+// EventSalesReportRequested is the event type of a report email message
+const EventSalesReportRequested = "report.sales_summary"
+
+// reportDateLayout formats report periods in emails
+const reportDateLayout = "2006-01-02 15:04 MST"
+
+// ReportSender delivers sales summary emails to organizers
+type ReportSender interface {
+	// SendReport delivers the report to the schedule's email address
+	SendReport(ctx context.Context, schedule *domain.ReportSchedule, report *domain.SalesReport, unsubscribeURL string) error
+
+	// Close closes the sender
+	Close() error
+}
+
+// ReportSenderConfig contains configuration for the Kafka report sender
+type ReportSenderConfig struct {
+	Brokers     []string
+	Topic       string
+	ServiceName string
+	ClientID    string
+}
+
+// salesReportMessage is consumed by the notification pipeline that emails the report.
+// Report carries the per-event figures for the email's table.
+type salesReportMessage struct {
+	EventID        string              `json:"event_id"`
+	EventType      string              `json:"event_type"`
+	TenantID       string              `json:"tenant_id"`
+	Channel        string              `json:"channel"`
+	Destination    string              `json:"destination"`
+	Report         *domain.SalesReport `json:"report"`
+	UnsubscribeURL string              `json:"unsubscribe_url"`
+	OccurredAt     time.Time           `json:"occurred_at"`
+	// Notification is the summary to deliver, rendered in the organizer's locale
+	Notification *i18n.Notification `json:"notification"`
+}
+
+// KafkaReportSender implements ReportSender using Kafka
+type KafkaReportSender struct {
+	producer    *kafka.Producer
+	topic       string
+	serviceName string
+}
+
+// NewKafkaReportSender creates a new Kafka report sender
+func NewKafkaReportSender(ctx context.Context, cfg *ReportSenderConfig) (*KafkaReportSender, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("report sender config is required")
+	}
+
+	if len(cfg.Brokers) == 0 {
+		return nil, fmt.Errorf("kafka brokers are required")
+	}
+
+	topic := cfg.Topic
+	if topic == "" {
+		topic = DefaultReportTopic
+	}
+
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = "booking-service"
+	}
+
+	clientID := cfg.ClientID
+	if clientID == "" {
+		clientID = "booking-service-report-sender"
+	}
+
+	producer, err := kafka.NewProducer(ctx, &kafka.ProducerConfig{
+		Brokers:       cfg.Brokers,
+		ClientID:      clientID,
+		MaxRetries:    3,
+		RetryInterval: 2 * time.Second,
+		BatchSize:     100,
+		LingerMs:      10,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka producer: %w", err)
+	}
+
+	return &KafkaReportSender{
+		producer:    producer,
+		topic:       topic,
+		serviceName: serviceName,
+	}, nil
+}
+
+// SendReport publishes the report synchronously, so a failed send is retried on the next run
+func (s *KafkaReportSender) SendReport(ctx context.Context, schedule *domain.ReportSchedule, report *domain.SalesReport, unsubscribeURL string) error {
+	eventID := uuid.New().String()
+	now := time.Now()
+
+	value, err := json.Marshal(&salesReportMessage{
+		EventID:        eventID,
+		EventType:      EventSalesReportRequested,
+		TenantID:       schedule.TenantID,
+		Channel:        "email",
+		Destination:    schedule.Email,
+		Report:         report,
+		UnsubscribeURL: unsubscribeURL,
+		OccurredAt:     now,
+		Notification:   RenderSalesReport(schedule.Locale, report, unsubscribeURL),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+
+	msg := &kafka.Message{
+		Topic: s.topic,
+		Key:   []byte(schedule.TenantID),
+		Value: value,
+		Headers: map[string]string{
+			"event_type":   EventSalesReportRequested,
+			"event_id":     eventID,
+			"source":       s.serviceName,
+			"content_type": "application/json",
+		},
+		Timestamp: now,
+	}
+
+	if err := s.producer.Produce(ctx, msg); err != nil {
+		return fmt.Errorf("failed to publish report: %w", err)
+	}
+	return nil
+}
+
+// Close closes the report sender
+func (s *KafkaReportSender) Close() error {
+	if s.producer != nil {
+		s.producer.Close()
+	}
+	return nil
+}
+
+// RenderSalesReport renders the report's summary in locale
+func RenderSalesReport(locale string, report *domain.SalesReport, unsubscribeURL string) *i18n.Notification {
+	return i18n.RenderNotification(i18n.Locale(locale), i18n.TemplateSalesReport, map[string]string{
+		"from":            report.From.UTC().Format(reportDateLayout),
+		"to":              report.To.UTC().Format(reportDateLayout),
+		"events":          strconv.Itoa(len(report.Events)),
+		"bookings":        strconv.Itoa(report.Totals.Bookings),
+		"tickets":         strconv.Itoa(report.Totals.TicketsSold),
+		"revenue":         strconv.FormatFloat(report.Totals.Revenue, 'f', 2, 64),
+		"currency":        report.Totals.Currency,
+		"refunds":         strconv.Itoa(report.Totals.Refunds),
+		"unsubscribe_url": unsubscribeURL,
+	})
+}
@@ -0,0 +1,329 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// ReportService manages organizers' scheduled sales summary emails
+type ReportService interface {
+	// SetSchedule creates or replaces the tenant's schedule, resubscribing it if it was unsubscribed
+	SetSchedule(ctx context.Context, tenantID string, req *dto.ReportScheduleRequest) (*dto.ReportScheduleResponse, error)
+
+	// GetSchedule returns the tenant's schedule
+	GetSchedule(ctx context.Context, tenantID string) (*dto.ReportScheduleResponse, error)
+
+	// DeleteSchedule removes the tenant's schedule
+	DeleteSchedule(ctx context.Context, tenantID string) error
+
+	// Unsubscribe stops the reports of the schedule with the token from a report email
+	Unsubscribe(ctx context.Context, token string) error
+
+	// PreviewReport builds the report the tenant's schedule would send now, without sending it
+	PreviewReport(ctx context.Context, tenantID string) (*domain.SalesReport, error)
+
+	// SendDueReports sends the reports of up to limit due schedules, hiding them from other
+	// workers for lease. Returns the number of reports sent.
+	SendDueReports(ctx context.Context, limit int, lease time.Duration) (int, error)
+}
+
+// QueueStatusReader reads the state of an event's virtual queue
+type QueueStatusReader interface {
+	GetQueueStatus(ctx context.Context, eventID string) (*dto.QueueStatusResponse, error)
+}
+
+// ReportServiceConfig contains configuration for report service
+type ReportServiceConfig struct {
+	// UnsubscribeURL is the public unsubscribe endpoint linked from report emails;
+	// the token is added as the token query parameter
+	UnsubscribeURL  string
+	DefaultCurrency string
+}
+
+// reportService implements ReportService
+type reportService struct {
+	scheduleRepo    repository.ReportScheduleRepository
+	salesRepo       repository.SalesReportRepository
+	queues          QueueStatusReader
+	sender          ReportSender
+	unsubscribeURL  string
+	defaultCurrency string
+	now             func() time.Time
+}
+
+// NewReportService creates a new report service.
+// queues is optional (reports omit queue stats without it) and sender is only needed by SendDueReports.
+func NewReportService(
+	scheduleRepo repository.ReportScheduleRepository,
+	salesRepo repository.SalesReportRepository,
+	queues QueueStatusReader,
+	sender ReportSender,
+	cfg *ReportServiceConfig,
+) ReportService {
+	s := &reportService{
+		scheduleRepo:    scheduleRepo,
+		salesRepo:       salesRepo,
+		queues:          queues,
+		sender:          sender,
+		unsubscribeURL:  "/api/v1/reports/unsubscribe",
+		defaultCurrency: "THB",
+		now:             time.Now,
+	}
+	if cfg != nil {
+		if cfg.UnsubscribeURL != "" {
+			s.unsubscribeURL = cfg.UnsubscribeURL
+		}
+		if cfg.DefaultCurrency != "" {
+			s.defaultCurrency = cfg.DefaultCurrency
+		}
+	}
+	return s
+}
+
+// SetSchedule creates or replaces the tenant's schedule
+func (s *reportService) SetSchedule(ctx context.Context, tenantID string, req *dto.ReportScheduleRequest) (*dto.ReportScheduleResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.report.set_schedule")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("tenant_id", tenantID))
+
+	if req == nil {
+		span.SetStatus(codes.Error, "invalid schedule")
+		return nil, domain.ErrInvalidReportSchedule
+	}
+
+	now := s.now()
+	schedule := &domain.ReportSchedule{
+		TenantID:         tenantID,
+		Email:            strings.TrimSpace(req.Email),
+		Locale:           req.Locale,
+		Frequency:        domain.ReportFrequency(req.Frequency),
+		SendHour:         8,
+		Weekday:          time.Monday,
+		EventIDs:         req.EventIDs,
+		UnsubscribeToken: generateUnsubscribeToken(),
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if schedule.Locale == "" {
+		schedule.Locale = "en"
+	}
+	if req.SendHour != nil {
+		schedule.SendHour = *req.SendHour
+	}
+	if req.Weekday != nil {
+		schedule.Weekday = time.Weekday(*req.Weekday)
+	}
+	if err := schedule.Validate(); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	schedule.NextRunAt = schedule.NextRun(now)
+
+	saved, err := s.scheduleRepo.Upsert(ctx, schedule)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.String("frequency", saved.Frequency.String()),
+		attribute.String("next_run_at", saved.NextRunAt.Format(time.RFC3339)),
+	)
+	span.SetStatus(codes.Ok, "")
+	return dto.ReportScheduleFromDomain(saved), nil
+}
+
+// GetSchedule returns the tenant's schedule
+func (s *reportService) GetSchedule(ctx context.Context, tenantID string) (*dto.ReportScheduleResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.report.get_schedule")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("tenant_id", tenantID))
+
+	schedule, err := s.scheduleRepo.GetByTenant(ctx, tenantID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return dto.ReportScheduleFromDomain(schedule), nil
+}
+
+// DeleteSchedule removes the tenant's schedule
+func (s *reportService) DeleteSchedule(ctx context.Context, tenantID string) error {
+	ctx, span := telemetry.StartSpan(ctx, "service.report.delete_schedule")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("tenant_id", tenantID))
+
+	if err := s.scheduleRepo.Delete(ctx, tenantID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// Unsubscribe stops the reports of the schedule with the token
+func (s *reportService) Unsubscribe(ctx context.Context, token string) error {
+	ctx, span := telemetry.StartSpan(ctx, "service.report.unsubscribe")
+	defer span.End()
+
+	if token == "" {
+		span.SetStatus(codes.Error, "missing token")
+		return domain.ErrReportScheduleNotFound
+	}
+
+	schedule, err := s.scheduleRepo.Unsubscribe(ctx, token)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	logger.Get().Info(fmt.Sprintf("Organizer unsubscribed from sales reports: tenant_id=%s", schedule.TenantID))
+
+	span.SetAttributes(attribute.String("tenant_id", schedule.TenantID))
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// PreviewReport builds the report of the period ending now
+func (s *reportService) PreviewReport(ctx context.Context, tenantID string) (*domain.SalesReport, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.report.preview")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("tenant_id", tenantID))
+
+	schedule, err := s.scheduleRepo.GetByTenant(ctx, tenantID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	report, err := s.buildReport(ctx, schedule, s.now())
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return report, nil
+}
+
+// SendDueReports sends the reports of due schedules. A report that fails to build or send
+// stays claimed until the lease expires and is then retried.
+func (s *reportService) SendDueReports(ctx context.Context, limit int, lease time.Duration) (int, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.report.send_due")
+	defer span.End()
+
+	if s.sender == nil {
+		err := fmt.Errorf("report sender is not configured")
+		span.SetStatus(codes.Error, err.Error())
+		return 0, err
+	}
+
+	now := s.now()
+	schedules, err := s.scheduleRepo.ClaimDue(ctx, now, limit, lease)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return 0, err
+	}
+
+	sent := 0
+	for _, schedule := range schedules {
+		// The period ends at the scheduled time, so a late run still reports the intended period
+		runAt := schedule.NextRunAt
+		report, err := s.buildReport(ctx, schedule, runAt)
+		if err != nil {
+			span.RecordError(err)
+			logger.Get().Error(fmt.Sprintf("Failed to build sales report: tenant_id=%s, error=%v", schedule.TenantID, err))
+			continue
+		}
+
+		if err := s.sender.SendReport(ctx, schedule, report, s.unsubscribeLink(schedule.UnsubscribeToken)); err != nil {
+			span.RecordError(err)
+			logger.Get().Error(fmt.Sprintf("Failed to send sales report: tenant_id=%s, error=%v", schedule.TenantID, err))
+			continue
+		}
+		sent++
+
+		// Runs missed while the worker was down are skipped rather than sent in a burst
+		if err := s.scheduleRepo.MarkSent(ctx, schedule.TenantID, runAt, schedule.NextRun(now)); err != nil {
+			span.RecordError(err)
+			logger.Get().Error(fmt.Sprintf("Failed to mark sales report sent: tenant_id=%s, error=%v", schedule.TenantID, err))
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("claimed", len(schedules)),
+		attribute.Int("sent", sent),
+	)
+	span.SetStatus(codes.Ok, "")
+	return sent, nil
+}
+
+// buildReport aggregates the period of the run at runAt and adds each event's queue state
+func (s *reportService) buildReport(ctx context.Context, schedule *domain.ReportSchedule, runAt time.Time) (*domain.SalesReport, error) {
+	from, to := schedule.Period(runAt)
+	events, err := s.salesRepo.SummarizeEvents(ctx, schedule.TenantID, schedule.EventIDs, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.queues != nil {
+		for _, event := range events {
+			status, err := s.queues.GetQueueStatus(ctx, event.EventID)
+			if err != nil {
+				continue // Queue stats are informational
+			}
+			event.Queue = &domain.EventQueueSnapshot{Waiting: status.TotalInQueue, IsOpen: status.IsOpen}
+		}
+	}
+
+	report := domain.NewSalesReport(schedule, from, to, events)
+	if report.Totals.Currency == "" {
+		report.Totals.Currency = s.defaultCurrency
+	}
+	return report, nil
+}
+
+// unsubscribeLink returns the unsubscribe URL of a report email
+func (s *reportService) unsubscribeLink(token string) string {
+	sep := "?"
+	if strings.Contains(s.unsubscribeURL, "?") {
+		sep = "&"
+	}
+	return s.unsubscribeURL + sep + "token=" + url.QueryEscape(token)
+}
+
+// generateUnsubscribeToken generates a random unsubscribe token
+func generateUnsubscribeToken() string {
+	bytes := make([]byte, 24)
+	if _, err := rand.Read(bytes); err != nil {
+		return hex.EncodeToString([]byte(time.Now().String()))[:48]
+	}
+	return hex.EncodeToString(bytes)
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+)
+
+// memoryReportRepository keeps report schedules in memory and serves fixed sales figures
+type memoryReportRepository struct {
+	schedules map[string]*domain.ReportSchedule
+	sales     []*domain.EventSalesSummary
+	periods   [][2]time.Time
+}
+
+func (r *memoryReportRepository) Upsert(ctx context.Context, schedule *domain.ReportSchedule) (*domain.ReportSchedule, error) {
+	if existing, ok := r.schedules[schedule.TenantID]; ok {
+		schedule.UnsubscribeToken = existing.UnsubscribeToken
+	}
+	copied := *schedule
+	r.schedules[schedule.TenantID] = &copied
+	return &copied, nil
+}
+
+func (r *memoryReportRepository) GetByTenant(ctx context.Context, tenantID string) (*domain.ReportSchedule, error) {
+	if s, ok := r.schedules[tenantID]; ok {
+		return s, nil
+	}
+	return nil, domain.ErrReportScheduleNotFound
+}
+
+func (r *memoryReportRepository) Delete(ctx context.Context, tenantID string) error {
+	delete(r.schedules, tenantID)
+	return nil
+}
+
+func (r *memoryReportRepository) Unsubscribe(ctx context.Context, token string) (*domain.ReportSchedule, error) {
+	for _, s := range r.schedules {
+		if s.UnsubscribeToken == token {
+			now := time.Now()
+			s.UnsubscribedAt = &now
+			return s, nil
+		}
+	}
+	return nil, domain.ErrReportScheduleNotFound
+}
+
+func (r *memoryReportRepository) ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*domain.ReportSchedule, error) {
+	var due []*domain.ReportSchedule
+	for _, s := range r.schedules {
+		if s.IsSubscribed() && !s.NextRunAt.After(now) {
+			due = append(due, s)
+		}
+	}
+	return due, nil
+}
+
+func (r *memoryReportRepository) MarkSent(ctx context.Context, tenantID string, runAt, nextRunAt time.Time) error {
+	s := r.schedules[tenantID]
+	if s.NextRunAt.Equal(runAt) {
+		s.NextRunAt = nextRunAt
+		s.LastSentAt = &runAt
+	}
+	return nil
+}
+
+func (r *memoryReportRepository) SummarizeEvents(ctx context.Context, tenantID string, eventIDs []string, from, to time.Time) ([]*domain.EventSalesSummary, error) {
+	r.periods = append(r.periods, [2]time.Time{from, to})
+	return r.sales, nil
+}
+
+// recordingReportSender records sent reports, failing while err is set
+type recordingReportSender struct {
+	err     error
+	reports []*domain.SalesReport
+	links   []string
+}
+
+func (s *recordingReportSender) SendReport(ctx context.Context, schedule *domain.ReportSchedule, report *domain.SalesReport, unsubscribeURL string) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.reports = append(s.reports, report)
+	s.links = append(s.links, unsubscribeURL)
+	return nil
+}
+
+func (s *recordingReportSender) Close() error { return nil }
+
+// staticQueueStatus reports the same queue for every event
+type staticQueueStatus struct{}
+
+func (staticQueueStatus) GetQueueStatus(ctx context.Context, eventID string) (*dto.QueueStatusResponse, error) {
+	return &dto.QueueStatusResponse{EventID: eventID, TotalInQueue: 42, IsOpen: true}, nil
+}
+
+func TestReportService_SendDueReports(t *testing.T) {
+	repo := &memoryReportRepository{
+		schedules: map[string]*domain.ReportSchedule{},
+		sales: []*domain.EventSalesSummary{
+			{EventID: "event-1", Bookings: 3, ConfirmedBookings: 2, TicketsSold: 4, Revenue: 4000, Currency: "THB"},
+		},
+	}
+	sender := &recordingReportSender{err: errors.New("kafka unavailable")}
+	svc := NewReportService(repo, repo, staticQueueStatus{}, sender, &ReportServiceConfig{
+		UnsubscribeURL: "https://example.com/api/v1/reports/unsubscribe",
+	}).(*reportService)
+
+	// Wednesday 10:30 UTC; the daily report is sent at 08:00
+	now := time.Date(2026, 5, 6, 10, 30, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	schedule, err := svc.SetSchedule(context.Background(), "tenant-1", &dto.ReportScheduleRequest{
+		Email:     "organizer@example.com",
+		Frequency: "daily",
+	})
+	if err != nil {
+		t.Fatalf("SetSchedule() error = %v", err)
+	}
+	runAt := time.Date(2026, 5, 7, 8, 0, 0, 0, time.UTC)
+	if schedule.NextRunAt == nil || !schedule.NextRunAt.Equal(runAt) || schedule.Locale != "en" {
+		t.Fatalf("schedule = %+v, want next run %v", schedule, runAt)
+	}
+
+	// Not due yet
+	now = runAt.Add(-time.Minute)
+	if sent, _ := svc.SendDueReports(context.Background(), 10, time.Minute); sent != 0 {
+		t.Fatalf("sent = %d before the send time, want 0", sent)
+	}
+
+	// A failed send is not marked sent
+	now = runAt.Add(5 * time.Minute)
+	if sent, _ := svc.SendDueReports(context.Background(), 10, time.Minute); sent != 0 {
+		t.Fatalf("sent = %d with a failing sender, want 0", sent)
+	}
+	if !repo.schedules["tenant-1"].NextRunAt.Equal(runAt) {
+		t.Fatalf("next run moved after a failed send")
+	}
+
+	sender.err = nil
+	if sent, _ := svc.SendDueReports(context.Background(), 10, time.Minute); sent != 1 {
+		t.Fatalf("sent = %d, want 1", sent)
+	}
+
+	report := sender.reports[0]
+	if !report.From.Equal(runAt.AddDate(0, 0, -1)) || !report.To.Equal(runAt) {
+		t.Errorf("period = %v - %v, want the day before %v", report.From, report.To, runAt)
+	}
+	if report.Totals.Revenue != 4000 || report.Events[0].Queue == nil || report.Events[0].Queue.Waiting != 42 {
+		t.Errorf("unexpected report %+v", report)
+	}
+	token := repo.schedules["tenant-1"].UnsubscribeToken
+	if sender.links[0] != "https://example.com/api/v1/reports/unsubscribe?token="+token {
+		t.Errorf("unsubscribe link = %s", sender.links[0])
+	}
+	if next := repo.schedules["tenant-1"].NextRunAt; !next.Equal(runAt.AddDate(0, 0, 1)) {
+		t.Errorf("next run = %v, want %v", next, runAt.AddDate(0, 0, 1))
+	}
+
+	// Unsubscribed schedules are no longer sent
+	if err := svc.Unsubscribe(context.Background(), token); err != nil {
+		t.Fatalf("Unsubscribe() error = %v", err)
+	}
+	now = runAt.AddDate(0, 0, 2)
+	if sent, _ := svc.SendDueReports(context.Background(), 10, time.Minute); sent != 0 {
+		t.Errorf("sent = %d after unsubscribing, want 0", sent)
+	}
+	if err := svc.Unsubscribe(context.Background(), ""); !errors.Is(err, domain.ErrReportScheduleNotFound) {
+		t.Errorf("Unsubscribe(\"\") error = %v, want ErrReportScheduleNotFound", err)
+	}
+}
+
+func TestReportService_SetScheduleValidates(t *testing.T) {
+	repo := &memoryReportRepository{schedules: map[string]*domain.ReportSchedule{}}
+	svc := NewReportService(repo, repo, nil, nil, nil)
+
+	hour := 7
+	_, err := svc.SetSchedule(context.Background(), "tenant-1", &dto.ReportScheduleRequest{
+		Email:     "organizer@example.com",
+		Frequency: "monthly",
+		SendHour:  &hour,
+	})
+	if !errors.Is(err, domain.ErrInvalidReportSchedule) {
+		t.Errorf("SetSchedule() error = %v, want ErrInvalidReportSchedule", err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/metrics"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/saga"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
 	pkgsaga "github.com/prohmpiriya/booking-rush-10k-rps/pkg/saga"
@@ -113,6 +114,7 @@ func (s *KafkaSagaService) StartBookingSaga(ctx context.Context, data *saga.Book
 		log.Warn(fmt.Sprintf("Failed to update saga status: %v", err))
 	}
 
+	metrics.RecordSagaStarted(ctx, saga.BookingSagaName)
 	log.Info(fmt.Sprintf("Started booking saga: saga_id=%s, booking_id=%s", sagaID, data.BookingID))
 
 	span.SetStatus(codes.Ok, "")
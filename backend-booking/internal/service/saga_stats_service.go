@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	pkgsaga "github.com/prohmpiriya/booking-rush-10k-rps/pkg/saga"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// sagaStatsWindows are the rolling windows reported by the saga stats endpoint
+var sagaStatsWindows = []struct {
+	name     string
+	duration time.Duration
+}{
+	{"1h", time.Hour},
+	{"24h", 24 * time.Hour},
+}
+
+// SagaStatsService computes rolling saga aggregates from the saga store
+type SagaStatsService interface {
+	// GetStats returns saga outcome counts, compensation rate and step latencies for the last 1h and 24h
+	GetStats(ctx context.Context) (*dto.SagaStatsResponse, error)
+}
+
+// sagaStatsService implements SagaStatsService
+type sagaStatsService struct {
+	store pkgsaga.StatsStore
+	now   func() time.Time
+}
+
+// NewSagaStatsService creates a new saga stats service
+func NewSagaStatsService(store pkgsaga.StatsStore) SagaStatsService {
+	return &sagaStatsService{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// GetStats returns saga aggregates for each rolling window
+func (s *sagaStatsService) GetStats(ctx context.Context) (*dto.SagaStatsResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.saga_stats.get")
+	defer span.End()
+
+	if s.store == nil {
+		span.SetStatus(codes.Error, domain.ErrSagaStatsUnavailable.Error())
+		return nil, domain.ErrSagaStatsUnavailable
+	}
+
+	now := s.now()
+	resp := &dto.SagaStatsResponse{
+		GeneratedAt: now,
+		Windows:     make(map[string]*dto.SagaWindowStats, len(sagaStatsWindows)),
+	}
+
+	for _, window := range sagaStatsWindows {
+		stats, err := s.store.GetStats(ctx, now.Add(-window.duration))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, fmt.Errorf("failed to get saga stats for %s window: %w", window.name, err)
+		}
+
+		windowStats := toSagaWindowStats(stats)
+		resp.Windows[window.name] = windowStats
+		span.SetAttributes(
+			attribute.Int64("started_"+window.name, windowStats.Started),
+			attribute.Float64("compensation_rate_"+window.name, windowStats.CompensationRate),
+		)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return resp, nil
+}
+
+// toSagaWindowStats converts store aggregates to the response, summing outcomes across saga types
+func toSagaWindowStats(stats *pkgsaga.Stats) *dto.SagaWindowStats {
+	window := &dto.SagaWindowStats{
+		Since: stats.Since,
+		Sagas: make([]*dto.SagaDefinitionStats, 0, len(stats.Definitions)),
+		Steps: make([]*dto.SagaStepStats, 0, len(stats.Steps)),
+	}
+
+	for i := range stats.Definitions {
+		def := &stats.Definitions[i]
+		counts := toSagaOutcomeCounts(def.StatusCounts)
+
+		window.Started += counts.Started
+		window.InProgress += counts.InProgress
+		window.Completed += counts.Completed
+		window.Compensating += counts.Compensating
+		window.Compensated += counts.Compensated
+		window.Failed += counts.Failed
+
+		window.Sagas = append(window.Sagas, &dto.SagaDefinitionStats{
+			SagaName:          def.DefinitionID,
+			SagaOutcomeCounts: counts,
+			AvgDurationMs:     durationMs(def.AvgDuration),
+			P95DurationMs:     durationMs(def.P95Duration),
+		})
+	}
+	window.CompensationRate, window.SuccessRate = outcomeRates(&window.SagaOutcomeCounts)
+
+	for _, step := range stats.Steps {
+		window.Steps = append(window.Steps, &dto.SagaStepStats{
+			SagaName:      step.DefinitionID,
+			StepName:      step.StepName,
+			Status:        string(step.Status),
+			Count:         step.Count,
+			AvgDurationMs: durationMs(step.AvgDuration),
+			P95DurationMs: durationMs(step.P95Duration),
+			MaxDurationMs: durationMs(step.MaxDuration),
+		})
+	}
+
+	return window
+}
+
+// toSagaOutcomeCounts maps saga statuses to outcome counts
+func toSagaOutcomeCounts(statusCounts map[pkgsaga.Status]int64) dto.SagaOutcomeCounts {
+	var counts dto.SagaOutcomeCounts
+	for status, count := range statusCounts {
+		counts.Started += count
+		switch status {
+		case pkgsaga.StatusPending, pkgsaga.StatusRunning:
+			counts.InProgress += count
+		case pkgsaga.StatusCompleted:
+			counts.Completed += count
+		case pkgsaga.StatusCompensating:
+			counts.Compensating += count
+		case pkgsaga.StatusCompensated:
+			counts.Compensated += count
+		case pkgsaga.StatusFailed:
+			counts.Failed += count
+		}
+	}
+	counts.CompensationRate, counts.SuccessRate = outcomeRates(&counts)
+	return counts
+}
+
+// outcomeRates returns the compensation and success rates of started sagas
+func outcomeRates(counts *dto.SagaOutcomeCounts) (compensationRate, successRate float64) {
+	if counts.Started == 0 {
+		return 0, 0
+	}
+	started := float64(counts.Started)
+	return float64(counts.Compensating+counts.Compensated) / started, float64(counts.Completed) / started
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
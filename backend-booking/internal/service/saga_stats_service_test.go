@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	pkgsaga "github.com/prohmpiriya/booking-rush-10k-rps/pkg/saga"
+)
+
+// MockSagaStatsStore is a mock implementation of pkgsaga.StatsStore
+type MockSagaStatsStore struct {
+	Since        []time.Time
+	GetStatsFunc func(ctx context.Context, since time.Time) (*pkgsaga.Stats, error)
+}
+
+func (m *MockSagaStatsStore) GetStats(ctx context.Context, since time.Time) (*pkgsaga.Stats, error) {
+	m.Since = append(m.Since, since)
+	if m.GetStatsFunc != nil {
+		return m.GetStatsFunc(ctx, since)
+	}
+	return &pkgsaga.Stats{Since: since}, nil
+}
+
+func TestSagaStatsService_GetStats(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	store := &MockSagaStatsStore{
+		GetStatsFunc: func(ctx context.Context, since time.Time) (*pkgsaga.Stats, error) {
+			return &pkgsaga.Stats{
+				Since: since,
+				Definitions: []pkgsaga.DefinitionStats{
+					{
+						DefinitionID: "booking-saga",
+						StatusCounts: map[pkgsaga.Status]int64{
+							pkgsaga.StatusCompleted:    6,
+							pkgsaga.StatusCompensated:  2,
+							pkgsaga.StatusCompensating: 1,
+							pkgsaga.StatusRunning:      1,
+						},
+						AvgDuration: 1500 * time.Millisecond,
+						P95Duration: 3 * time.Second,
+					},
+					{
+						DefinitionID: "post-payment-saga",
+						StatusCounts: map[pkgsaga.Status]int64{
+							pkgsaga.StatusCompleted: 9,
+							pkgsaga.StatusFailed:    1,
+						},
+					},
+				},
+				Steps: []pkgsaga.StepStats{
+					{
+						DefinitionID: "booking-saga",
+						StepName:     "reserve-seats",
+						Status:       pkgsaga.StepStatusCompleted,
+						Count:        9,
+						AvgDuration:  20 * time.Millisecond,
+						P95Duration:  45 * time.Millisecond,
+						MaxDuration:  80 * time.Millisecond,
+					},
+				},
+			}, nil
+		},
+	}
+
+	svc := &sagaStatsService{store: store, now: func() time.Time { return now }}
+
+	resp, err := svc.GetStats(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(store.Since) != 2 {
+		t.Fatalf("expected 2 store queries, got %d", len(store.Since))
+	}
+	if !store.Since[0].Equal(now.Add(-time.Hour)) || !store.Since[1].Equal(now.Add(-24*time.Hour)) {
+		t.Errorf("unexpected window starts: %v", store.Since)
+	}
+
+	hour, ok := resp.Windows["1h"]
+	if !ok {
+		t.Fatal("expected 1h window")
+	}
+	if _, ok := resp.Windows["24h"]; !ok {
+		t.Fatal("expected 24h window")
+	}
+
+	if hour.Started != 20 {
+		t.Errorf("expected 20 started, got %d", hour.Started)
+	}
+	if hour.Completed != 15 || hour.Compensated != 2 || hour.Compensating != 1 || hour.Failed != 1 || hour.InProgress != 1 {
+		t.Errorf("unexpected outcome counts: %+v", hour.SagaOutcomeCounts)
+	}
+	if math.Abs(hour.CompensationRate-0.15) > 1e-9 {
+		t.Errorf("expected compensation rate 0.15, got %v", hour.CompensationRate)
+	}
+	if math.Abs(hour.SuccessRate-0.75) > 1e-9 {
+		t.Errorf("expected success rate 0.75, got %v", hour.SuccessRate)
+	}
+
+	if len(hour.Sagas) != 2 {
+		t.Fatalf("expected 2 saga types, got %d", len(hour.Sagas))
+	}
+	booking := hour.Sagas[0]
+	if booking.SagaName != "booking-saga" || booking.Started != 10 {
+		t.Errorf("unexpected booking saga stats: %+v", booking)
+	}
+	if math.Abs(booking.CompensationRate-0.3) > 1e-9 {
+		t.Errorf("expected booking compensation rate 0.3, got %v", booking.CompensationRate)
+	}
+	if booking.AvgDurationMs != 1500 || booking.P95DurationMs != 3000 {
+		t.Errorf("unexpected booking durations: avg=%v p95=%v", booking.AvgDurationMs, booking.P95DurationMs)
+	}
+
+	if len(hour.Steps) != 1 {
+		t.Fatalf("expected 1 step, got %d", len(hour.Steps))
+	}
+	step := hour.Steps[0]
+	if step.StepName != "reserve-seats" || step.Status != "completed" || step.Count != 9 {
+		t.Errorf("unexpected step stats: %+v", step)
+	}
+	if step.AvgDurationMs != 20 || step.P95DurationMs != 45 || step.MaxDurationMs != 80 {
+		t.Errorf("unexpected step durations: %+v", step)
+	}
+}
+
+func TestSagaStatsService_GetStats_EmptyWindow(t *testing.T) {
+	svc := NewSagaStatsService(&MockSagaStatsStore{})
+
+	resp, err := svc.GetStats(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	hour := resp.Windows["1h"]
+	if hour == nil {
+		t.Fatal("expected 1h window")
+	}
+	if hour.Started != 0 || hour.CompensationRate != 0 || hour.SuccessRate != 0 {
+		t.Errorf("expected zero stats, got %+v", hour.SagaOutcomeCounts)
+	}
+	if hour.Sagas == nil || hour.Steps == nil {
+		t.Error("expected empty slices, got nil")
+	}
+}
+
+func TestSagaStatsService_GetStats_NoStore(t *testing.T) {
+	svc := NewSagaStatsService(nil)
+
+	_, err := svc.GetStats(context.Background())
+	if !errors.Is(err, domain.ErrSagaStatsUnavailable) {
+		t.Errorf("expected ErrSagaStatsUnavailable, got %v", err)
+	}
+}
+
+func TestSagaStatsService_GetStats_StoreError(t *testing.T) {
+	storeErr := errors.New("connection refused")
+	svc := NewSagaStatsService(&MockSagaStatsStore{
+		GetStatsFunc: func(ctx context.Context, since time.Time) (*pkgsaga.Stats, error) {
+			return nil, storeErr
+		},
+	})
+
+	_, err := svc.GetStats(context.Background())
+	if !errors.Is(err, storeErr) {
+		t.Errorf("expected store error, got %v", err)
+	}
+}
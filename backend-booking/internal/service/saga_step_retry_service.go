@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/saga"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	pkgsaga "github.com/prohmpiriya/booking-rush-10k-rps/pkg/saga"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// SagaStepRetryService lets operators re-emit saga step commands that landed in the DLQ
+type SagaStepRetryService interface {
+	// RetryStep re-emits the dead-lettered command of a saga step with its original payload
+	// as the next attempt, and records who retried it
+	RetryStep(ctx context.Context, sagaID, stepName, retriedBy string) (*dto.SagaStepRetryResponse, error)
+}
+
+// sagaStepRetryService implements SagaStepRetryService
+type sagaStepRetryService struct {
+	producer    saga.SagaProducer
+	store       pkgsaga.Store
+	deadLetters pkgsaga.DeadLetterStore
+	stepTimeout time.Duration
+	now         func() time.Time
+}
+
+// NewSagaStepRetryService creates a new saga step retry service. Retried commands time out
+// after the saga step timeout. Retries are unavailable without a producer, a saga store
+// or a dead letter store.
+func NewSagaStepRetryService(producer saga.SagaProducer, store pkgsaga.Store, deadLetters pkgsaga.DeadLetterStore, cfg *SagaServiceConfig) SagaStepRetryService {
+	stepTimeout := 30 * time.Second
+	if cfg != nil && cfg.StepTimeout > 0 {
+		stepTimeout = cfg.StepTimeout
+	}
+	return &sagaStepRetryService{
+		producer:    producer,
+		store:       store,
+		deadLetters: deadLetters,
+		stepTimeout: stepTimeout,
+		now:         time.Now,
+	}
+}
+
+// RetryStep re-emits a dead-lettered step command. The dead letter is marked processed
+// once the command is sent, and the saga gets a running step result naming the operator.
+func (s *sagaStepRetryService) RetryStep(ctx context.Context, sagaID, stepName, retriedBy string) (*dto.SagaStepRetryResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.saga_step_retry.retry")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("saga_id", sagaID),
+		attribute.String("step_name", stepName),
+		attribute.String("retried_by", retriedBy),
+	)
+
+	if s.producer == nil || s.store == nil || s.deadLetters == nil {
+		span.SetStatus(codes.Error, domain.ErrSagaStepRetryUnavailable.Error())
+		return nil, domain.ErrSagaStepRetryUnavailable
+	}
+
+	topic := saga.StepToCommandTopic(stepName)
+	if topic == "" {
+		span.SetStatus(codes.Error, domain.ErrInvalidSagaStep.Error())
+		return nil, domain.ErrInvalidSagaStep
+	}
+
+	instance, err := s.store.Get(ctx, sagaID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	// Seats are released and payments refunded once compensation starts
+	switch instance.GetStatus() {
+	case pkgsaga.StatusCompensating, pkgsaga.StatusCompensated:
+		span.SetStatus(codes.Error, domain.ErrSagaStepRetryNotAllowed.Error())
+		return nil, domain.ErrSagaStepRetryNotAllowed
+	}
+
+	deadLetter, err := s.deadLetters.GetPendingDeadLetter(ctx, sagaID, stepName)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if errors.Is(err, pkgsaga.ErrDeadLetterNotFound) {
+			return nil, domain.ErrSagaStepNotDeadLettered
+		}
+		return nil, err
+	}
+
+	original, err := decodeDeadLetterCommand(deadLetter)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if original.Topic != "" {
+		topic = original.Topic
+	}
+
+	command := saga.NewRetryCommand(original, retriedBy, s.stepTimeout)
+	if err := s.producer.SendCommand(ctx, command); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to re-emit %s command: %w", stepName, err)
+	}
+
+	log := logger.Get()
+	retriedAt := s.now()
+
+	if err := s.deadLetters.MarkDeadLetterRetried(ctx, deadLetter.ID, retriedBy); err != nil {
+		// Another operator retried it first; the step may run twice
+		log.Warn(fmt.Sprintf("Failed to mark dead letter retried: dead_letter_id=%s, error=%v", deadLetter.ID, err))
+	}
+
+	instance.AddStepResult(&pkgsaga.StepResult{
+		StepName:  stepName,
+		Status:    pkgsaga.StepStatusRunning,
+		StartedAt: retriedAt,
+		Data: map[string]interface{}{
+			"manual_retry":   true,
+			"attempt":        command.RetryCount,
+			"message_id":     command.MessageID,
+			"dead_letter_id": deadLetter.ID,
+			"retried_by":     retriedBy,
+		},
+	})
+	if instance.GetStatus() == pkgsaga.StatusFailed {
+		instance.SetStatus(pkgsaga.StatusRunning)
+	}
+	if err := s.store.Update(ctx, instance); err != nil {
+		log.Warn(fmt.Sprintf("Failed to record saga step retry: saga_id=%s, step=%s, error=%v", sagaID, stepName, err))
+	}
+
+	log.Info(fmt.Sprintf("Retried saga step from DLQ: saga_id=%s, step=%s, attempt=%d, retried_by=%s",
+		sagaID, stepName, command.RetryCount, retriedBy))
+
+	span.SetAttributes(attribute.Int("attempt", command.RetryCount))
+	span.SetStatus(codes.Ok, "")
+	return &dto.SagaStepRetryResponse{
+		SagaID:       sagaID,
+		StepName:     stepName,
+		Topic:        topic,
+		MessageID:    command.MessageID,
+		Attempt:      command.RetryCount,
+		DeadLetterID: deadLetter.ID,
+		RetriedBy:    retriedBy,
+		RetriedAt:    retriedAt,
+	}, nil
+}
+
+// decodeDeadLetterCommand restores the step command stored in a dead letter
+func decodeDeadLetterCommand(deadLetter *pkgsaga.DeadLetter) (*saga.SagaCommand, error) {
+	raw, err := json.Marshal(deadLetter.MessageValue)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal dead letter %s: %w", deadLetter.ID, err)
+	}
+
+	var command saga.SagaCommand
+	if err := json.Unmarshal(raw, &command); err != nil {
+		return nil, fmt.Errorf("dead letter %s is not a saga command: %w", deadLetter.ID, err)
+	}
+	if command.SagaID != deadLetter.SagaID || command.StepName == "" {
+		return nil, fmt.Errorf("dead letter %s is not a saga command", deadLetter.ID)
+	}
+
+	return &command, nil
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/saga"
+	pkgsaga "github.com/prohmpiriya/booking-rush-10k-rps/pkg/saga"
+)
+
+// MockDeadLetterStore is an in-memory implementation of pkgsaga.DeadLetterStore
+type MockDeadLetterStore struct {
+	DeadLetters []*pkgsaga.DeadLetter
+}
+
+func (m *MockDeadLetterStore) GetPendingDeadLetter(ctx context.Context, sagaID, stepName string) (*pkgsaga.DeadLetter, error) {
+	for i := len(m.DeadLetters) - 1; i >= 0; i-- {
+		dl := m.DeadLetters[i]
+		if dl.SagaID == sagaID && dl.MessageValue["step_name"] == stepName && !dl.Processed {
+			return dl, nil
+		}
+	}
+	return nil, pkgsaga.ErrDeadLetterNotFound
+}
+
+func (m *MockDeadLetterStore) MarkDeadLetterRetried(ctx context.Context, id, retriedBy string) error {
+	for _, dl := range m.DeadLetters {
+		if dl.ID == id && !dl.Processed {
+			dl.Processed = true
+			dl.RetriedBy = retriedBy
+			return nil
+		}
+	}
+	return pkgsaga.ErrDeadLetterNotFound
+}
+
+// toMessageValue stores a command the way the DLQ handler does
+func toMessageValue(t *testing.T, command *saga.SagaCommand) map[string]interface{} {
+	t.Helper()
+	raw, err := json.Marshal(command)
+	if err != nil {
+		t.Fatalf("failed to marshal command: %v", err)
+	}
+	var value map[string]interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		t.Fatalf("failed to unmarshal command: %v", err)
+	}
+	return value
+}
+
+// newDeadLetteredSaga saves a completed saga whose notification command landed in the DLQ
+func newDeadLetteredSaga(t *testing.T, store pkgsaga.Store) (*pkgsaga.Instance, *MockDeadLetterStore) {
+	t.Helper()
+	data := map[string]interface{}{"booking_id": "booking-1", "user_id": "user-1"}
+	instance := pkgsaga.NewInstance(saga.BookingSagaName, data)
+	instance.Complete()
+	if err := store.Save(context.Background(), instance); err != nil {
+		t.Fatalf("failed to save saga: %v", err)
+	}
+
+	command := saga.NewSagaCommand(instance.ID, saga.BookingSagaName, saga.StepSendNotification, 3, data, 0, 2)
+	command.RetryCount = 1
+	return instance, &MockDeadLetterStore{DeadLetters: []*pkgsaga.DeadLetter{{
+		ID:           "dl-1",
+		SagaID:       instance.ID,
+		Topic:        saga.TopicSagaSendNotificationCommand,
+		MessageValue: toMessageValue(t, command),
+		ErrorMessage: "smtp timeout",
+		RetryCount:   3,
+	}}}
+}
+
+func TestSagaStepRetryService_RetryStep(t *testing.T) {
+	store := pkgsaga.NewMemoryStore()
+	instance, deadLetters := newDeadLetteredSaga(t, store)
+	producer := saga.NewMockSagaProducer()
+	svc := NewSagaStepRetryService(producer, store, deadLetters, nil)
+
+	retry, err := svc.RetryStep(context.Background(), instance.ID, saga.StepSendNotification, "admin-1")
+	if err != nil {
+		t.Fatalf("RetryStep() error = %v", err)
+	}
+	if retry.Attempt != 2 || retry.DeadLetterID != "dl-1" || retry.RetriedBy != "admin-1" {
+		t.Errorf("RetryStep() = %+v, want attempt 2 of dl-1 by admin-1", retry)
+	}
+
+	if len(producer.Commands) != 1 {
+		t.Fatalf("expected 1 command, got %d", len(producer.Commands))
+	}
+	command := producer.Commands[0]
+	if command.StepName != saga.StepSendNotification || command.RetryCount != 2 || command.MessageID != retry.MessageID {
+		t.Errorf("re-emitted command = %+v, want send-notification attempt 2", command)
+	}
+	if command.Data["booking_id"] != "booking-1" || command.Headers["retried_by"] != "admin-1" {
+		t.Errorf("re-emitted command data = %v, headers = %v, want original payload retried by admin-1", command.Data, command.Headers)
+	}
+
+	if dl := deadLetters.DeadLetters[0]; !dl.Processed || dl.RetriedBy != "admin-1" {
+		t.Errorf("dead letter = %+v, want processed by admin-1", dl)
+	}
+	updated, _ := store.Get(context.Background(), instance.ID)
+	last := updated.StepResults[len(updated.StepResults)-1]
+	if last.StepName != saga.StepSendNotification || last.Status != pkgsaga.StepStatusRunning || last.Data["retried_by"] != "admin-1" {
+		t.Errorf("last step result = %+v, want running retry by admin-1", last)
+	}
+
+	// The dead letter is consumed by the first retry
+	if _, err := svc.RetryStep(context.Background(), instance.ID, saga.StepSendNotification, "admin-2"); !errors.Is(err, domain.ErrSagaStepNotDeadLettered) {
+		t.Errorf("second RetryStep() error = %v, want ErrSagaStepNotDeadLettered", err)
+	}
+}
+
+func TestSagaStepRetryService_RetryStep_Rejected(t *testing.T) {
+	store := pkgsaga.NewMemoryStore()
+	instance, deadLetters := newDeadLetteredSaga(t, store)
+	producer := saga.NewMockSagaProducer()
+	svc := NewSagaStepRetryService(producer, store, deadLetters, nil)
+	ctx := context.Background()
+
+	if _, err := svc.RetryStep(ctx, instance.ID, saga.StepReleaseSeats, "admin-1"); !errors.Is(err, domain.ErrInvalidSagaStep) {
+		t.Errorf("RetryStep() of compensation step error = %v, want ErrInvalidSagaStep", err)
+	}
+	if _, err := svc.RetryStep(ctx, "missing", saga.StepSendNotification, "admin-1"); !errors.Is(err, pkgsaga.ErrSagaNotFound) {
+		t.Errorf("RetryStep() of unknown saga error = %v, want ErrSagaNotFound", err)
+	}
+
+	instance.SetStatus(pkgsaga.StatusCompensated)
+	if err := store.Update(ctx, instance); err != nil {
+		t.Fatalf("failed to update saga: %v", err)
+	}
+	if _, err := svc.RetryStep(ctx, instance.ID, saga.StepSendNotification, "admin-1"); !errors.Is(err, domain.ErrSagaStepRetryNotAllowed) {
+		t.Errorf("RetryStep() of compensated saga error = %v, want ErrSagaStepRetryNotAllowed", err)
+	}
+
+	if _, err := NewSagaStepRetryService(nil, store, deadLetters, nil).RetryStep(ctx, instance.ID, saga.StepSendNotification, "admin-1"); !errors.Is(err, domain.ErrSagaStepRetryUnavailable) {
+		t.Errorf("RetryStep() without producer error = %v, want ErrSagaStepRetryUnavailable", err)
+	}
+	if len(producer.Commands) != 0 {
+		t.Errorf("expected no commands, got %d", len(producer.Commands))
+	}
+}
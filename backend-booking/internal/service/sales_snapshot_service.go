@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const (
+	// DefaultSnapshotRange is how far back event snapshots are returned without a from time
+	DefaultSnapshotRange = 7 * 24 * time.Hour
+	// MaxSnapshotRange bounds the snapshots returned in one response
+	MaxSnapshotRange = 92 * 24 * time.Hour
+)
+
+// SalesSnapshotService materializes hourly per-zone seat and revenue snapshots into the
+// reporting schema and serves them to organizers' historical charts
+type SalesSnapshotService interface {
+	// TakeSnapshot records every zone with live bookings for the hour of at.
+	// Returns the number of zones recorded.
+	TakeSnapshot(ctx context.Context, at time.Time) (int, error)
+
+	// GetEventSnapshots returns the tenant's snapshots of an event in [from, to).
+	// A zero to is now and a zero from is DefaultSnapshotRange before to.
+	GetEventSnapshots(ctx context.Context, tenantID, eventID string, from, to time.Time) (*dto.EventSnapshotsResponse, error)
+}
+
+// salesSnapshotService implements SalesSnapshotService
+type salesSnapshotService struct {
+	snapshotRepo repository.SalesSnapshotRepository
+	inventory    repository.ZoneInventoryRepository
+	now          func() time.Time
+}
+
+// NewSalesSnapshotService creates a new sales snapshot service.
+// inventory is only needed by TakeSnapshot; without it snapshots have no seat counts.
+func NewSalesSnapshotService(snapshotRepo repository.SalesSnapshotRepository, inventory repository.ZoneInventoryRepository) SalesSnapshotService {
+	return &salesSnapshotService{
+		snapshotRepo: snapshotRepo,
+		inventory:    inventory,
+		now:          time.Now,
+	}
+}
+
+// TakeSnapshot aggregates live bookings per zone, adds the zones' seat counts and saves them
+func (s *salesSnapshotService) TakeSnapshot(ctx context.Context, at time.Time) (int, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.sales_snapshot.take")
+	defer span.End()
+
+	hour := domain.SnapshotHour(at)
+	span.SetAttributes(attribute.String("snapshot_at", hour.Format(time.RFC3339)))
+
+	snapshots, err := s.snapshotRepo.AggregateZones(ctx, at)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return 0, err
+	}
+	for _, snapshot := range snapshots {
+		snapshot.SnapshotAt = hour
+	}
+
+	if s.inventory != nil && len(snapshots) > 0 {
+		zoneIDs := make([]string, len(snapshots))
+		for i, snapshot := range snapshots {
+			zoneIDs[i] = snapshot.ZoneID
+		}
+		seats, err := s.inventory.GetZoneSeats(ctx, zoneIDs)
+		if err != nil {
+			// Sales are still worth keeping; seat counts stay NULL for this hour
+			span.RecordError(err)
+		}
+		for _, snapshot := range snapshots {
+			if counts, ok := seats[snapshot.ZoneID]; ok {
+				total, available := counts.Total, counts.Available
+				snapshot.TotalSeats, snapshot.AvailableSeats = &total, &available
+			}
+		}
+	}
+
+	if err := s.snapshotRepo.SaveSnapshots(ctx, snapshots); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return 0, err
+	}
+
+	span.SetAttributes(attribute.Int("zones", len(snapshots)))
+	span.SetStatus(codes.Ok, "")
+	return len(snapshots), nil
+}
+
+// GetEventSnapshots returns the tenant's snapshots of an event with hourly totals
+func (s *salesSnapshotService) GetEventSnapshots(ctx context.Context, tenantID, eventID string, from, to time.Time) (*dto.EventSnapshotsResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.sales_snapshot.get_event")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("tenant_id", tenantID),
+		attribute.String("event_id", eventID),
+	)
+
+	if eventID == "" {
+		span.SetStatus(codes.Error, "invalid event_id")
+		return nil, domain.ErrInvalidEventID
+	}
+	if to.IsZero() {
+		to = s.now()
+	}
+	if from.IsZero() {
+		from = to.Add(-DefaultSnapshotRange)
+	}
+	if !from.Before(to) || to.Sub(from) > MaxSnapshotRange {
+		span.SetStatus(codes.Error, "invalid range")
+		return nil, domain.ErrInvalidSnapshotRange
+	}
+	from, to = from.UTC(), to.UTC()
+
+	snapshots, err := s.snapshotRepo.ListEventSnapshots(ctx, tenantID, eventID, from, to)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to list event snapshots: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("snapshots", len(snapshots)))
+	span.SetStatus(codes.Ok, "")
+	return dto.EventSnapshotsFromDomain(eventID, from, to, snapshots), nil
+}
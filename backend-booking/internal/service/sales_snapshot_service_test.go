@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// memorySalesSnapshotRepository serves fixed zone aggregates and keeps saved snapshots in memory
+type memorySalesSnapshotRepository struct {
+	aggregates []*domain.ZoneSalesSnapshot
+	saved      []*domain.ZoneSalesSnapshot
+	ranges     [][2]time.Time
+}
+
+func (r *memorySalesSnapshotRepository) AggregateZones(ctx context.Context, at time.Time) ([]*domain.ZoneSalesSnapshot, error) {
+	return r.aggregates, nil
+}
+
+func (r *memorySalesSnapshotRepository) SaveSnapshots(ctx context.Context, snapshots []*domain.ZoneSalesSnapshot) error {
+	r.saved = append(r.saved, snapshots...)
+	return nil
+}
+
+func (r *memorySalesSnapshotRepository) ListEventSnapshots(ctx context.Context, tenantID, eventID string, from, to time.Time) ([]*domain.ZoneSalesSnapshot, error) {
+	r.ranges = append(r.ranges, [2]time.Time{from, to})
+	return r.saved, nil
+}
+
+// staticZoneInventory returns fixed seat counts, failing while err is set
+type staticZoneInventory struct {
+	seats map[string]domain.ZoneSeatCounts
+	err   error
+}
+
+func (s *staticZoneInventory) GetAvailableSeats(ctx context.Context, zoneID string) (int64, error) {
+	return s.seats[zoneID].Available, s.err
+}
+
+func (s *staticZoneInventory) GetZoneSeats(ctx context.Context, zoneIDs []string) (map[string]domain.ZoneSeatCounts, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.seats, nil
+}
+
+func TestSalesSnapshotService_TakeSnapshot(t *testing.T) {
+	repo := &memorySalesSnapshotRepository{aggregates: []*domain.ZoneSalesSnapshot{
+		{EventID: "event-1", ZoneID: "zone-a", HeldSeats: 4, SoldSeats: 10, Revenue: 5000},
+		{EventID: "event-1", ZoneID: "zone-b", SoldSeats: 2, Revenue: 800},
+	}}
+	inventory := &staticZoneInventory{seats: map[string]domain.ZoneSeatCounts{
+		"zone-a": {Total: 100, Available: 86},
+	}}
+	svc := NewSalesSnapshotService(repo, inventory)
+
+	at := time.Date(2026, 3, 1, 14, 0, 3, 0, time.UTC)
+	zones, err := svc.TakeSnapshot(context.Background(), at)
+	if err != nil || zones != 2 {
+		t.Fatalf("TakeSnapshot() = %d, %v, want 2, nil", zones, err)
+	}
+
+	hour := time.Date(2026, 3, 1, 14, 0, 0, 0, time.UTC)
+	for _, s := range repo.saved {
+		if !s.SnapshotAt.Equal(hour) {
+			t.Errorf("zone %s snapshot_at = %v, want %v", s.ZoneID, s.SnapshotAt, hour)
+		}
+	}
+	if a := repo.saved[0]; a.TotalSeats == nil || *a.TotalSeats != 100 || *a.AvailableSeats != 86 {
+		t.Errorf("zone-a seats = %v/%v, want 86/100", a.AvailableSeats, a.TotalSeats)
+	}
+	if b := repo.saved[1]; b.TotalSeats != nil || b.AvailableSeats != nil {
+		t.Error("expected no seat counts for a zone missing from seat_zones")
+	}
+
+	// Sales are still recorded when seat counts cannot be read
+	repo.saved = nil
+	inventory.err = errors.New("ticket db down")
+	repo.aggregates[0].TotalSeats, repo.aggregates[0].AvailableSeats = nil, nil
+	if zones, err := svc.TakeSnapshot(context.Background(), at); err != nil || zones != 2 {
+		t.Fatalf("TakeSnapshot() = %d, %v, want 2, nil", zones, err)
+	}
+	if repo.saved[0].TotalSeats != nil {
+		t.Error("expected no seat counts without inventory")
+	}
+}
+
+func TestSalesSnapshotService_GetEventSnapshots(t *testing.T) {
+	hour := time.Date(2026, 3, 1, 14, 0, 0, 0, time.UTC)
+	available := int64(86)
+	repo := &memorySalesSnapshotRepository{saved: []*domain.ZoneSalesSnapshot{
+		{SnapshotAt: hour, ZoneID: "zone-a", AvailableSeats: &available, HeldSeats: 4, SoldSeats: 10, Revenue: 5000},
+		{SnapshotAt: hour, ZoneID: "zone-b", SoldSeats: 2, Revenue: 800},
+		{SnapshotAt: hour.Add(time.Hour), ZoneID: "zone-a", SoldSeats: 12, Revenue: 6000},
+	}}
+	svc := NewSalesSnapshotService(repo, nil).(*salesSnapshotService)
+	now := hour.Add(3 * time.Hour)
+	svc.now = func() time.Time { return now }
+
+	resp, err := svc.GetEventSnapshots(context.Background(), "tenant-1", "event-1", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("GetEventSnapshots() error = %v", err)
+	}
+	if got := repo.ranges[0]; !got[0].Equal(now.Add(-DefaultSnapshotRange)) || !got[1].Equal(now) {
+		t.Errorf("range = %v, want the last 7 days", got)
+	}
+	if len(resp.Zones) != 3 || len(resp.Totals) != 2 {
+		t.Fatalf("got %d zones and %d totals, want 3 and 2", len(resp.Zones), len(resp.Totals))
+	}
+	first := resp.Totals[0]
+	if first.SoldSeats != 12 || first.HeldSeats != 4 || first.AvailableSeats != 86 || first.Revenue != 5800 {
+		t.Errorf("first hour totals = %+v", first)
+	}
+
+	tests := []struct {
+		name     string
+		eventID  string
+		from, to time.Time
+		want     error
+	}{
+		{"missing event", "", time.Time{}, time.Time{}, domain.ErrInvalidEventID},
+		{"from after to", "event-1", now, now.Add(-time.Hour), domain.ErrInvalidSnapshotRange},
+		{"range too long", "event-1", now.Add(-MaxSnapshotRange - time.Hour), now, domain.ErrInvalidSnapshotRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := svc.GetEventSnapshots(context.Background(), "tenant-1", tt.eventID, tt.from, tt.to); !errors.Is(err, tt.want) {
+				t.Errorf("GetEventSnapshots() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// SeatAlternativesService suggests what can still be reserved when a reservation
+// fails with insufficient seats
+type SeatAlternativesService interface {
+	// FindAlternatives returns the quantity still available in the zone and the nearest
+	// sibling zones of the show with seats left. showID may be empty.
+	FindAlternatives(ctx context.Context, showID, zoneID string, quantity int) (*dto.SeatAlternatives, error)
+}
+
+// SeatAlternativesConfig contains configuration for seat alternatives service
+type SeatAlternativesConfig struct {
+	// MaxZones caps the number of sibling zones suggested (default: 3)
+	MaxZones int
+	// MaxPerUser caps the suggested quantity in the requested zone (default: 10)
+	MaxPerUser int
+	// Verifier samples the availability reads for comparison with PostgreSQL (optional)
+	Verifier *AvailabilityVerifier
+}
+
+// seatAlternativesService implements SeatAlternativesService
+type seatAlternativesService struct {
+	fetcher         ZoneFetcher
+	lister          ShowZoneLister
+	reservationRepo repository.ReservationRepository
+	maxZones        int
+	maxPerUser      int
+	verifier        *AvailabilityVerifier
+
+	mu        sync.RWMutex
+	zoneShows map[string]string
+}
+
+// NewSeatAlternativesService creates a new seat alternatives service.
+// Zone names, prices and ordering come from ticket service (through a ZoneCatalog
+// on hot paths), availability from Redis.
+func NewSeatAlternativesService(fetcher ZoneFetcher, lister ShowZoneLister, reservationRepo repository.ReservationRepository, cfg *SeatAlternativesConfig) SeatAlternativesService {
+	s := &seatAlternativesService{
+		fetcher:         fetcher,
+		lister:          lister,
+		reservationRepo: reservationRepo,
+		maxZones:        3,
+		maxPerUser:      10,
+		zoneShows:       make(map[string]string),
+	}
+	if cfg != nil {
+		if cfg.MaxZones > 0 {
+			s.maxZones = cfg.MaxZones
+		}
+		if cfg.MaxPerUser > 0 {
+			s.maxPerUser = cfg.MaxPerUser
+		}
+		s.verifier = cfg.Verifier
+	}
+	return s
+}
+
+// FindAlternatives returns the quantity still available in the zone and the nearest sibling zones
+func (s *seatAlternativesService) FindAlternatives(ctx context.Context, showID, zoneID string, quantity int) (*dto.SeatAlternatives, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.seat_alternatives.find")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("zone_id", zoneID),
+		attribute.Int("quantity", quantity),
+	)
+
+	available, err := s.reservationRepo.GetZoneAvailability(ctx, zoneID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	s.verifier.Sample(zoneID, available)
+	if available < 0 {
+		available = 0
+	}
+
+	result := &dto.SeatAlternatives{
+		ZoneID:            zoneID,
+		RequestedQuantity: quantity,
+		AvailableInZone:   available,
+		MaxQuantity:       int(min(available, int64(s.maxPerUser))),
+		Zones:             []dto.ZoneAlternative{},
+	}
+
+	// Sibling zones are best effort: the remaining quantity is still worth returning
+	zones, err := s.siblingZones(ctx, showID, zoneID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Ok, "")
+		return result, nil
+	}
+
+	for _, zone := range zones {
+		if len(result.Zones) >= s.maxZones {
+			break
+		}
+		zoneAvailable, err := s.reservationRepo.GetZoneAvailability(ctx, zone.ID)
+		if err != nil {
+			// Zones not synced to Redis yet have not sold a seat, but their count is unknown
+			if !errors.Is(err, domain.ErrZoneNotFound) {
+				span.RecordError(err)
+			}
+			continue
+		}
+		s.verifier.Sample(zone.ID, zoneAvailable)
+		if zoneAvailable <= 0 {
+			continue
+		}
+		result.Zones = append(result.Zones, dto.ZoneAlternative{
+			ZoneID:         zone.ID,
+			Name:           zone.Name,
+			Price:          zone.Price,
+			AvailableSeats: zoneAvailable,
+			FitsQuantity:   zoneAvailable >= int64(quantity),
+		})
+	}
+
+	span.SetAttributes(attribute.Int("alternatives", len(result.Zones)))
+	span.SetStatus(codes.Ok, "")
+	return result, nil
+}
+
+// siblingZones returns the other active zones of the show, nearest to zoneID first.
+// Zones are nearest by sort order, then by price.
+func (s *seatAlternativesService) siblingZones(ctx context.Context, showID, zoneID string) ([]*ZoneInfo, error) {
+	if showID == "" {
+		var err error
+		if showID, err = s.showOf(ctx, zoneID); err != nil {
+			return nil, err
+		}
+	}
+
+	zones, err := s.lister.ListShowZones(ctx, showID)
+	if err != nil {
+		return nil, err
+	}
+
+	var requested *ZoneInfo
+	siblings := make([]*ZoneInfo, 0, len(zones))
+	for _, zone := range zones {
+		switch {
+		case zone.ID == zoneID:
+			requested = zone
+		case zone.IsActive:
+			siblings = append(siblings, zone)
+		}
+	}
+	if requested == nil {
+		// Deactivated zones are not listed; fall back to the show's own order
+		requested = &ZoneInfo{ID: zoneID}
+	}
+
+	sort.SliceStable(siblings, func(i, j int) bool {
+		di := abs(siblings[i].SortOrder - requested.SortOrder)
+		dj := abs(siblings[j].SortOrder - requested.SortOrder)
+		if di != dj {
+			return di < dj
+		}
+		return math.Abs(siblings[i].Price-requested.Price) < math.Abs(siblings[j].Price-requested.Price)
+	})
+	return siblings, nil
+}
+
+// showOf returns the show a zone belongs to; zones never move between shows
+func (s *seatAlternativesService) showOf(ctx context.Context, zoneID string) (string, error) {
+	s.mu.RLock()
+	showID, ok := s.zoneShows[zoneID]
+	s.mu.RUnlock()
+	if ok {
+		return showID, nil
+	}
+
+	zone, err := s.fetcher.FetchZone(ctx, zoneID)
+	if err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	s.zoneShows[zoneID] = zone.ShowID
+	s.mu.Unlock()
+	return zone.ShowID, nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
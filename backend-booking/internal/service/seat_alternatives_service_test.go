@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+)
+
+// stubZoneCatalog serves zones from memory and counts show listings
+type stubZoneCatalog struct {
+	zones    []*ZoneInfo
+	listErr  error
+	listings int
+}
+
+func (s *stubZoneCatalog) FetchZone(ctx context.Context, zoneID string) (*ZoneInfo, error) {
+	for _, zone := range s.zones {
+		if zone.ID == zoneID {
+			return zone, nil
+		}
+	}
+	return nil, errors.New("zone not found")
+}
+
+func (s *stubZoneCatalog) ListShowZones(ctx context.Context, showID string) ([]*ZoneInfo, error) {
+	s.listings++
+	if s.listErr != nil {
+		return nil, s.listErr
+	}
+	var zones []*ZoneInfo
+	for _, zone := range s.zones {
+		if zone.ShowID == showID {
+			zones = append(zones, zone)
+		}
+	}
+	return zones, nil
+}
+
+func TestSeatAlternativesService_FindAlternatives(t *testing.T) {
+	catalog := &stubZoneCatalog{zones: []*ZoneInfo{
+		{ID: "vip", ShowID: "show-1", Name: "VIP", Price: 5000, SortOrder: 0, IsActive: true},
+		{ID: "gold", ShowID: "show-1", Name: "Gold", Price: 3000, SortOrder: 1, IsActive: true},
+		{ID: "silver", ShowID: "show-1", Name: "Silver", Price: 2000, SortOrder: 2, IsActive: true},
+		{ID: "bronze", ShowID: "show-1", Name: "Bronze", Price: 1000, SortOrder: 3, IsActive: true},
+		{ID: "standing", ShowID: "show-1", Name: "Standing", Price: 800, SortOrder: 4, IsActive: true},
+		{ID: "closed", ShowID: "show-1", Name: "Closed", Price: 2900, SortOrder: 1, IsActive: false},
+	}}
+	availability := map[string]int64{"gold": 2, "vip": 1, "silver": 0, "bronze": 50, "standing": 500, "closed": 100}
+	reservations := &MockReservationRepository{
+		GetZoneAvailabilityFunc: func(ctx context.Context, zoneID string) (int64, error) {
+			seats, ok := availability[zoneID]
+			if !ok {
+				return 0, domain.ErrZoneNotFound
+			}
+			return seats, nil
+		},
+	}
+	zones := NewZoneCatalog(catalog, catalog, nil)
+	svc := NewSeatAlternativesService(zones, zones, reservations, &SeatAlternativesConfig{MaxZones: 2})
+
+	// The show is resolved from the zone when the request does not name it
+	result, err := svc.FindAlternatives(context.Background(), "", "gold", 4)
+	if err != nil {
+		t.Fatalf("FindAlternatives() error = %v", err)
+	}
+	if result.AvailableInZone != 2 || result.MaxQuantity != 2 {
+		t.Errorf("available = %d, max = %d, want 2 and 2", result.AvailableInZone, result.MaxQuantity)
+	}
+
+	// VIP and Silver are one step away, Silver is sold out; Bronze is next
+	if len(result.Zones) != 2 || result.Zones[0].ZoneID != "vip" || result.Zones[1].ZoneID != "bronze" {
+		t.Fatalf("zones = %+v, want vip then bronze", result.Zones)
+	}
+	if result.Zones[0].FitsQuantity || !result.Zones[1].FitsQuantity {
+		t.Errorf("fits = %v, %v, want false and true", result.Zones[0].FitsQuantity, result.Zones[1].FitsQuantity)
+	}
+
+	// The show's zones are cached by the catalog
+	if _, err := svc.FindAlternatives(context.Background(), "show-1", "silver", 1); err != nil {
+		t.Fatalf("FindAlternatives() error = %v", err)
+	}
+	if catalog.listings != 1 {
+		t.Errorf("listings = %d, want 1", catalog.listings)
+	}
+}
+
+func TestSeatAlternativesService_ZoneListUnavailable(t *testing.T) {
+	catalog := &stubZoneCatalog{listErr: errors.New("ticket service down")}
+	reservations := &MockReservationRepository{
+		GetZoneAvailabilityFunc: func(ctx context.Context, zoneID string) (int64, error) {
+			return 30, nil
+		},
+	}
+	svc := NewSeatAlternativesService(catalog, catalog, reservations, nil)
+
+	result, err := svc.FindAlternatives(context.Background(), "show-1", "gold", 40)
+	if err != nil {
+		t.Fatalf("FindAlternatives() error = %v", err)
+	}
+	if result.MaxQuantity != 10 || len(result.Zones) != 0 {
+		t.Errorf("max = %d, zones = %v, want 10 and none", result.MaxQuantity, result.Zones)
+	}
+}
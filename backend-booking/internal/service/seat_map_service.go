@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// DefaultSeatMapCacheTTL is how long a zone's layout (or lack of one) is cached per instance
+const DefaultSeatMapCacheTTL = 5 * time.Second
+
+// maxSeatAllocationAttempts bounds retries when a concurrent reservation takes the picked seats
+const maxSeatAllocationAttempts = 3
+
+// SeatMapService manages reserved seating layouts and best-available seat allocation
+type SeatMapService interface {
+	// SetSeatMap replaces a zone's seat map. Fails while any seat in the zone is held.
+	SetSeatMap(ctx context.Context, zoneID string, req *dto.SetSeatMapRequest) (*dto.SeatMapResponse, error)
+
+	// GetSeatMap returns a zone's seat map with held seats
+	GetSeatMap(ctx context.Context, zoneID string) (*dto.SeatMapResponse, error)
+
+	// DeleteSeatMap returns a zone to general admission
+	DeleteSeatMap(ctx context.Context, zoneID string) error
+
+	// AllocateSeats picks and holds the best available seats for a reservation.
+	// Returns nil seats for general admission zones.
+	AllocateSeats(ctx context.Context, zoneID, bookingID string, quantity int, pref *domain.SeatPreference) ([]domain.Seat, error)
+}
+
+// SeatMapServiceConfig contains configuration for seat map service
+type SeatMapServiceConfig struct {
+	// CacheTTL bounds how long other instances take to see a layout change.
+	// Set seat maps before a zone goes on sale.
+	CacheTTL time.Duration
+}
+
+// cachedSeatMap is a zone layout lookup; seatMap is nil for general admission zones
+type cachedSeatMap struct {
+	seatMap   *domain.SeatMap
+	fetchedAt time.Time
+}
+
+// seatMapService implements SeatMapService
+type seatMapService struct {
+	seatMapRepo repository.SeatMapRepository
+	cacheTTL    time.Duration
+	now         func() time.Time
+
+	mu    sync.RWMutex
+	cache map[string]cachedSeatMap
+}
+
+// NewSeatMapService creates a new seat map service
+func NewSeatMapService(seatMapRepo repository.SeatMapRepository, cfg *SeatMapServiceConfig) SeatMapService {
+	ttl := DefaultSeatMapCacheTTL
+	if cfg != nil && cfg.CacheTTL > 0 {
+		ttl = cfg.CacheTTL
+	}
+	return &seatMapService{
+		seatMapRepo: seatMapRepo,
+		cacheTTL:    ttl,
+		now:         time.Now,
+		cache:       make(map[string]cachedSeatMap),
+	}
+}
+
+// SetSeatMap replaces a zone's seat map
+func (s *seatMapService) SetSeatMap(ctx context.Context, zoneID string, req *dto.SetSeatMapRequest) (*dto.SeatMapResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.seat_map.set")
+	defer span.End()
+
+	if req == nil {
+		span.SetStatus(codes.Error, "invalid seat map")
+		return nil, domain.ErrInvalidSeatMap
+	}
+
+	seatMap := &domain.SeatMap{ZoneID: zoneID, Rows: req.Rows}
+	if err := seatMap.Validate(); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.String("zone_id", zoneID),
+		attribute.Int("total_seats", seatMap.TotalSeats()),
+	)
+
+	if err := s.seatMapRepo.Save(ctx, seatMap); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	s.invalidate(zoneID)
+
+	span.SetStatus(codes.Ok, "")
+	return dto.SeatMapFromDomain(seatMap, nil), nil
+}
+
+// GetSeatMap returns a zone's seat map with held seats
+func (s *seatMapService) GetSeatMap(ctx context.Context, zoneID string) (*dto.SeatMapResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.seat_map.get")
+	defer span.End()
+
+	if zoneID == "" {
+		span.SetStatus(codes.Error, "invalid zone_id")
+		return nil, domain.ErrInvalidZoneID
+	}
+
+	span.SetAttributes(attribute.String("zone_id", zoneID))
+
+	seatMap, err := s.seatMapRepo.Get(ctx, zoneID)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	bitmap, err := s.seatMapRepo.GetBitmap(ctx, zoneID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return dto.SeatMapFromDomain(seatMap, bitmap), nil
+}
+
+// DeleteSeatMap returns a zone to general admission
+func (s *seatMapService) DeleteSeatMap(ctx context.Context, zoneID string) error {
+	ctx, span := telemetry.StartSpan(ctx, "service.seat_map.delete")
+	defer span.End()
+
+	if zoneID == "" {
+		span.SetStatus(codes.Error, "invalid zone_id")
+		return domain.ErrInvalidZoneID
+	}
+
+	span.SetAttributes(attribute.String("zone_id", zoneID))
+
+	if err := s.seatMapRepo.Delete(ctx, zoneID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	s.invalidate(zoneID)
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// AllocateSeats picks and holds the best available seats for a reservation
+func (s *seatMapService) AllocateSeats(ctx context.Context, zoneID, bookingID string, quantity int, pref *domain.SeatPreference) ([]domain.Seat, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.seat_map.allocate")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("zone_id", zoneID),
+		attribute.String("booking_id", bookingID),
+		attribute.Int("quantity", quantity),
+	)
+
+	seatMap, err := s.layout(ctx, zoneID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if seatMap == nil {
+		span.SetStatus(codes.Ok, "general admission")
+		return nil, nil
+	}
+
+	// Picking runs against a snapshot of the bitmap; the assign script rejects
+	// the whole pick if another reservation took any of the seats meanwhile
+	for attempt := 1; attempt <= maxSeatAllocationAttempts; attempt++ {
+		bitmap, err := s.seatMapRepo.GetBitmap(ctx, zoneID)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+
+		seats, contiguous, err := seatMap.Allocate(bitmap, quantity, pref)
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+
+		result, err := s.seatMapRepo.AssignSeats(ctx, zoneID, bookingID, seats)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+
+		if result.Success {
+			span.SetAttributes(
+				attribute.StringSlice("seats", domain.SeatLabels(seats)),
+				attribute.Bool("contiguous", contiguous),
+				attribute.Int("attempts", attempt),
+			)
+			span.SetStatus(codes.Ok, "")
+			return seats, nil
+		}
+
+		switch result.ErrorCode {
+		case "SEAT_TAKEN":
+			continue
+		case "RESERVATION_NOT_FOUND":
+			span.SetStatus(codes.Error, result.ErrorCode)
+			return nil, domain.ErrReservationNotFound
+		default:
+			span.SetStatus(codes.Error, result.ErrorCode)
+			return nil, fmt.Errorf("failed to assign seats: %s", result.ErrorMessage)
+		}
+	}
+
+	span.SetStatus(codes.Error, "seat allocation conflict")
+	return nil, domain.ErrSeatAllocationConflict
+}
+
+// layout returns a zone's seat map from the cache, nil for general admission zones
+func (s *seatMapService) layout(ctx context.Context, zoneID string) (*domain.SeatMap, error) {
+	s.mu.RLock()
+	cached, ok := s.cache[zoneID]
+	s.mu.RUnlock()
+	if ok && s.now().Sub(cached.fetchedAt) < s.cacheTTL {
+		return cached.seatMap, nil
+	}
+
+	seatMap, err := s.seatMapRepo.Get(ctx, zoneID)
+	if err != nil && !errors.Is(err, domain.ErrSeatMapNotFound) {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.cache[zoneID] = cachedSeatMap{seatMap: seatMap, fetchedAt: s.now()}
+	s.mu.Unlock()
+	return seatMap, nil
+}
+
+// invalidate drops a zone's cached layout on this instance
+func (s *seatMapService) invalidate(zoneID string) {
+	s.mu.Lock()
+	delete(s.cache, zoneID)
+	s.mu.Unlock()
+}
@@ -0,0 +1,279 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+)
+
+// MockSeatMapRepository is a mock implementation of SeatMapRepository
+type MockSeatMapRepository struct {
+	SaveFunc        func(ctx context.Context, seatMap *domain.SeatMap) error
+	DeleteFunc      func(ctx context.Context, zoneID string) error
+	GetFunc         func(ctx context.Context, zoneID string) (*domain.SeatMap, error)
+	GetBitmapFunc   func(ctx context.Context, zoneID string) (domain.SeatBitmap, error)
+	AssignSeatsFunc func(ctx context.Context, zoneID, bookingID string, seats []domain.Seat) (*repository.AssignSeatsResult, error)
+}
+
+func (m *MockSeatMapRepository) Save(ctx context.Context, seatMap *domain.SeatMap) error {
+	if m.SaveFunc != nil {
+		return m.SaveFunc(ctx, seatMap)
+	}
+	return nil
+}
+
+func (m *MockSeatMapRepository) Delete(ctx context.Context, zoneID string) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, zoneID)
+	}
+	return nil
+}
+
+func (m *MockSeatMapRepository) Get(ctx context.Context, zoneID string) (*domain.SeatMap, error) {
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, zoneID)
+	}
+	return nil, domain.ErrSeatMapNotFound
+}
+
+func (m *MockSeatMapRepository) GetBitmap(ctx context.Context, zoneID string) (domain.SeatBitmap, error) {
+	if m.GetBitmapFunc != nil {
+		return m.GetBitmapFunc(ctx, zoneID)
+	}
+	return domain.SeatBitmap{}, nil
+}
+
+func (m *MockSeatMapRepository) AssignSeats(ctx context.Context, zoneID, bookingID string, seats []domain.Seat) (*repository.AssignSeatsResult, error) {
+	if m.AssignSeatsFunc != nil {
+		return m.AssignSeatsFunc(ctx, zoneID, bookingID, seats)
+	}
+	return &repository.AssignSeatsResult{Success: true, Assigned: int64(len(seats))}, nil
+}
+
+// singleRowSeatMap has one row of 6 seats with no aisles
+func singleRowSeatMap(zoneID string) *domain.SeatMap {
+	return &domain.SeatMap{ZoneID: zoneID, Rows: []domain.SeatRow{{Label: "A", Seats: 6}}}
+}
+
+func TestSeatMapService_AllocateSeats_GeneralAdmission(t *testing.T) {
+	lookups := 0
+	repo := &MockSeatMapRepository{
+		GetFunc: func(ctx context.Context, zoneID string) (*domain.SeatMap, error) {
+			lookups++
+			return nil, domain.ErrSeatMapNotFound
+		},
+		AssignSeatsFunc: func(ctx context.Context, zoneID, bookingID string, seats []domain.Seat) (*repository.AssignSeatsResult, error) {
+			t.Error("AssignSeats() should not be called for general admission zones")
+			return nil, errors.New("unexpected call")
+		},
+	}
+	svc := NewSeatMapService(repo, nil)
+
+	for i := 0; i < 2; i++ {
+		seats, err := svc.AllocateSeats(context.Background(), "zone-001", "booking-001", 2, nil)
+		if err != nil || seats != nil {
+			t.Fatalf("AllocateSeats() = %v, %v, want nil, nil", seats, err)
+		}
+	}
+	if lookups != 1 {
+		t.Errorf("Get() called %d times, want the layout cached after 1", lookups)
+	}
+}
+
+func TestSeatMapService_AllocateSeats_RetriesTakenSeats(t *testing.T) {
+	bitmap := domain.SeatBitmap{0}
+	var assigned [][]string
+	repo := &MockSeatMapRepository{
+		GetFunc: func(ctx context.Context, zoneID string) (*domain.SeatMap, error) {
+			return singleRowSeatMap(zoneID), nil
+		},
+		GetBitmapFunc: func(ctx context.Context, zoneID string) (domain.SeatBitmap, error) {
+			return append(domain.SeatBitmap{}, bitmap...), nil
+		},
+		AssignSeatsFunc: func(ctx context.Context, zoneID, bookingID string, seats []domain.Seat) (*repository.AssignSeatsResult, error) {
+			assigned = append(assigned, domain.SeatLabels(seats))
+			if len(assigned) == 1 {
+				// A concurrent reservation took the center seats A-3 and A-4
+				bitmap[0] = 0x30
+				return &repository.AssignSeatsResult{Success: false, ErrorCode: "SEAT_TAKEN"}, nil
+			}
+			return &repository.AssignSeatsResult{Success: true, Assigned: int64(len(seats))}, nil
+		},
+	}
+	svc := NewSeatMapService(repo, nil)
+
+	seats, err := svc.AllocateSeats(context.Background(), "zone-001", "booking-001", 2, nil)
+	if err != nil {
+		t.Fatalf("AllocateSeats() unexpected error = %v", err)
+	}
+	if len(assigned) != 2 {
+		t.Fatalf("AssignSeats() called %d times, want 2", len(assigned))
+	}
+	if !reflect.DeepEqual(assigned[0], []string{"A-3", "A-4"}) {
+		t.Errorf("first pick = %v, want [A-3 A-4]", assigned[0])
+	}
+	if got := domain.SeatLabels(seats); !reflect.DeepEqual(got, []string{"A-1", "A-2"}) {
+		t.Errorf("AllocateSeats() = %v, want [A-1 A-2]", got)
+	}
+}
+
+func TestSeatMapService_AllocateSeats_Conflict(t *testing.T) {
+	attempts := 0
+	repo := &MockSeatMapRepository{
+		GetFunc: func(ctx context.Context, zoneID string) (*domain.SeatMap, error) {
+			return singleRowSeatMap(zoneID), nil
+		},
+		AssignSeatsFunc: func(ctx context.Context, zoneID, bookingID string, seats []domain.Seat) (*repository.AssignSeatsResult, error) {
+			attempts++
+			return &repository.AssignSeatsResult{Success: false, ErrorCode: "SEAT_TAKEN"}, nil
+		},
+	}
+	svc := NewSeatMapService(repo, nil)
+
+	_, err := svc.AllocateSeats(context.Background(), "zone-001", "booking-001", 2, nil)
+	if !errors.Is(err, domain.ErrSeatAllocationConflict) {
+		t.Fatalf("AllocateSeats() error = %v, want %v", err, domain.ErrSeatAllocationConflict)
+	}
+	if attempts != maxSeatAllocationAttempts {
+		t.Errorf("AssignSeats() called %d times, want %d", attempts, maxSeatAllocationAttempts)
+	}
+}
+
+func TestSeatMapService_SetSeatMap(t *testing.T) {
+	var saved *domain.SeatMap
+	repo := &MockSeatMapRepository{
+		SaveFunc: func(ctx context.Context, seatMap *domain.SeatMap) error {
+			saved = seatMap
+			return nil
+		},
+		GetFunc: func(ctx context.Context, zoneID string) (*domain.SeatMap, error) {
+			if saved == nil {
+				return nil, domain.ErrSeatMapNotFound
+			}
+			return saved, nil
+		},
+	}
+	svc := NewSeatMapService(repo, nil)
+
+	// Cache the zone as general admission first
+	if seats, _ := svc.AllocateSeats(context.Background(), "zone-001", "booking-001", 1, nil); seats != nil {
+		t.Fatalf("AllocateSeats() = %v before the seat map is set", seats)
+	}
+
+	if _, err := svc.SetSeatMap(context.Background(), "zone-001", &dto.SetSeatMapRequest{
+		Rows: []domain.SeatRow{{Label: "A", Seats: 2}, {Label: "A", Seats: 2}},
+	}); !errors.Is(err, domain.ErrInvalidSeatMap) {
+		t.Fatalf("SetSeatMap() error = %v, want %v", err, domain.ErrInvalidSeatMap)
+	}
+
+	resp, err := svc.SetSeatMap(context.Background(), "zone-001", &dto.SetSeatMapRequest{
+		Rows: []domain.SeatRow{{Label: "A", Seats: 4}, {Label: "B", Seats: 6, AisleAfter: []int{3}}},
+	})
+	if err != nil {
+		t.Fatalf("SetSeatMap() unexpected error = %v", err)
+	}
+	if resp.TotalSeats != 10 || resp.AvailableSeats != 10 {
+		t.Errorf("SetSeatMap() = %+v, want 10 total and available seats", resp)
+	}
+
+	// The local cache is dropped so the new layout applies immediately
+	seats, err := svc.AllocateSeats(context.Background(), "zone-001", "booking-002", 1, nil)
+	if err != nil || len(seats) != 1 {
+		t.Fatalf("AllocateSeats() = %v, %v, want 1 seat", seats, err)
+	}
+}
+
+func TestSeatMapService_SetSeatMap_InUse(t *testing.T) {
+	repo := &MockSeatMapRepository{
+		SaveFunc: func(ctx context.Context, seatMap *domain.SeatMap) error {
+			return domain.ErrSeatMapInUse
+		},
+	}
+	svc := NewSeatMapService(repo, nil)
+
+	_, err := svc.SetSeatMap(context.Background(), "zone-001", &dto.SetSeatMapRequest{
+		Rows: []domain.SeatRow{{Label: "A", Seats: 4}},
+	})
+	if !errors.Is(err, domain.ErrSeatMapInUse) {
+		t.Errorf("SetSeatMap() error = %v, want %v", err, domain.ErrSeatMapInUse)
+	}
+}
+
+func TestBookingService_ReserveSeats_AssignsSeats(t *testing.T) {
+	var created *domain.Booking
+	bookingRepo := &MockBookingRepository{
+		CreateFunc: func(ctx context.Context, booking *domain.Booking) error {
+			created = booking
+			return nil
+		},
+	}
+	seatMapRepo := &MockSeatMapRepository{
+		GetFunc: func(ctx context.Context, zoneID string) (*domain.SeatMap, error) {
+			return singleRowSeatMap(zoneID), nil
+		},
+	}
+	svc := NewBookingService(bookingRepo, &MockReservationRepository{}, nil, nil, nil, NewSeatMapService(seatMapRepo, nil), nil, nil, nil)
+
+	resp, err := svc.ReserveSeats(context.Background(), "user-001", &dto.ReserveSeatsRequest{
+		EventID:  "event-001",
+		ZoneID:   "zone-001",
+		ShowID:   "show-001",
+		Quantity: 2,
+	})
+	if err != nil {
+		t.Fatalf("ReserveSeats() unexpected error = %v", err)
+	}
+
+	want := []string{"A-3", "A-4"}
+	if !reflect.DeepEqual(resp.Seats, want) {
+		t.Errorf("ReserveSeats() seats = %v, want %v", resp.Seats, want)
+	}
+	if created == nil || !reflect.DeepEqual(created.SeatLabels, want) {
+		t.Errorf("booking seat labels = %v, want %v", created, want)
+	}
+}
+
+func TestBookingService_ReserveSeats_ReleasesOnAllocationFailure(t *testing.T) {
+	released := ""
+	reservationRepo := &MockReservationRepository{
+		ReleaseSeatsFunc: func(ctx context.Context, bookingID, userID string) (*repository.ReleaseResult, error) {
+			released = bookingID
+			return &repository.ReleaseResult{Success: true}, nil
+		},
+	}
+	bookingRepo := &MockBookingRepository{
+		CreateFunc: func(ctx context.Context, booking *domain.Booking) error {
+			t.Error("Create() should not be called when seats cannot be allocated")
+			return nil
+		},
+	}
+	seatMapRepo := &MockSeatMapRepository{
+		GetFunc: func(ctx context.Context, zoneID string) (*domain.SeatMap, error) {
+			return singleRowSeatMap(zoneID), nil
+		},
+		GetBitmapFunc: func(ctx context.Context, zoneID string) (domain.SeatBitmap, error) {
+			// A-3 taken leaves no 4 contiguous seats
+			return domain.SeatBitmap{0x20}, nil
+		},
+	}
+	svc := NewBookingService(bookingRepo, reservationRepo, nil, nil, nil, NewSeatMapService(seatMapRepo, nil), nil, nil, nil)
+
+	_, err := svc.ReserveSeats(context.Background(), "user-001", &dto.ReserveSeatsRequest{
+		EventID:        "event-001",
+		ZoneID:         "zone-001",
+		ShowID:         "show-001",
+		Quantity:       4,
+		SeatPreference: &domain.SeatPreference{RequireContiguous: true},
+	})
+	if !errors.Is(err, domain.ErrNoContiguousSeats) {
+		t.Fatalf("ReserveSeats() error = %v, want %v", err, domain.ErrNoContiguousSeats)
+	}
+	if released != "test-booking-id" {
+		t.Errorf("ReleaseSeats() booking = %q, want test-booking-id", released)
+	}
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/middleware"
+)
+
+// ShowCanceller marks shows cancelled in ticket service
+type ShowCanceller interface {
+	// CancelShow sets the show's status to cancelled on behalf of an admin user
+	CancelShow(ctx context.Context, showID, requestedBy string) error
+}
+
+// HTTPShowCanceller cancels shows via the ticket service API. Requests are signed
+// like gateway requests, carrying the identity of the admin who cancelled the show.
+type HTTPShowCanceller struct {
+	baseURL    string
+	secret     string
+	httpClient *http.Client
+}
+
+// NewHTTPShowCanceller creates a new HTTP show canceller
+func NewHTTPShowCanceller(ticketServiceURL, internalAuthSecret string) *HTTPShowCanceller {
+	return &HTTPShowCanceller{
+		baseURL: ticketServiceURL,
+		secret:  internalAuthSecret,
+		httpClient: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+	}
+}
+
+// CancelShow sends PUT /api/v1/shows/:id with status cancelled
+func (c *HTTPShowCanceller) CancelShow(ctx context.Context, showID, requestedBy string) error {
+	url := fmt.Sprintf("%s/api/v1/shows/%s", c.baseURL, showID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, strings.NewReader(`{"status":"cancelled"}`))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	middleware.SignInternalRequest(req, c.secret, &middleware.InternalClaims{
+		UserID: requestedBy,
+		Roles:  []string{"admin"},
+	}, time.Now())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrShowCancelFailed, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return domain.ErrShowNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: unexpected status code: %d", domain.ErrShowCancelFailed, resp.StatusCode)
+	}
+	return nil
+}
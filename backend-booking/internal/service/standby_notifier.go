@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
+)
+
+// DefaultStandbyOfferTopic is the Kafka topic standby invitations are published to
+const DefaultStandbyOfferTopic = "booking.standby-offers"
+
+// StandbyNotifier invites standby users to claim seats held for them
+type StandbyNotifier interface {
+	// NotifyOffer publishes an invitation for a held offer
+	NotifyOffer(ctx context.Context, offer *domain.StandbyOffer) error
+
+	// Close closes the notifier
+	Close() error
+}
+
+// StandbyNotifierConfig contains configuration for the Kafka standby notifier
+type StandbyNotifierConfig struct {
+	Brokers     []string
+	Topic       string
+	ServiceName string
+	ClientID    string
+	Logger      Logger
+}
+
+// KafkaStandbyNotifier implements StandbyNotifier using Kafka.
+// The notification service delivers the invitation over the channels in the offer.
+type KafkaStandbyNotifier struct {
+	producer    *kafka.Producer
+	topic       string
+	serviceName string
+	logger      Logger
+}
+
+// NewKafkaStandbyNotifier creates a new Kafka standby notifier
+func NewKafkaStandbyNotifier(ctx context.Context, cfg *StandbyNotifierConfig) (*KafkaStandbyNotifier, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("standby notifier config is required")
+	}
+
+	if len(cfg.Brokers) == 0 {
+		return nil, fmt.Errorf("kafka brokers are required")
+	}
+
+	topic := cfg.Topic
+	if topic == "" {
+		topic = DefaultStandbyOfferTopic
+	}
+
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = "booking-service"
+	}
+
+	clientID := cfg.ClientID
+	if clientID == "" {
+		clientID = "booking-service-standby-notifier"
+	}
+
+	producer, err := kafka.NewProducer(ctx, &kafka.ProducerConfig{
+		Brokers:       cfg.Brokers,
+		ClientID:      clientID,
+		MaxRetries:    3,
+		RetryInterval: 2 * time.Second,
+		BatchSize:     100,
+		LingerMs:      10,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka producer: %w", err)
+	}
+
+	return &KafkaStandbyNotifier{
+		producer:    producer,
+		topic:       topic,
+		serviceName: serviceName,
+		logger:      cfg.Logger,
+	}, nil
+}
+
+// NotifyOffer publishes a standby offer event asynchronously (fire-and-forget with logging)
+func (n *KafkaStandbyNotifier) NotifyOffer(ctx context.Context, offer *domain.StandbyOffer) error {
+	eventID := uuid.New().String()
+	event := domain.NewStandbyOfferEvent(offer, eventID)
+
+	value, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	msg := &kafka.Message{
+		Topic: n.topic,
+		Key:   []byte(offer.UserID),
+		Value: value,
+		Headers: map[string]string{
+			"event_type":   domain.StandbyEventOffered,
+			"event_id":     eventID,
+			"source":       n.serviceName,
+			"content_type": "application/json",
+		},
+		Timestamp: time.Now(),
+	}
+
+	// The offer is already held, so a failed invitation must not fail the offer run
+	n.producer.ProduceAsync(context.Background(), msg, func(err error) {
+		if err != nil && n.logger != nil {
+			n.logger.Error(fmt.Sprintf("failed to publish standby offer for user %s (zone=%s): %v", offer.UserID, offer.ZoneID, err))
+		}
+	})
+
+	return nil
+}
+
+// Close closes the standby notifier
+func (n *KafkaStandbyNotifier) Close() error {
+	if n.producer != nil {
+		n.producer.Close()
+	}
+	return nil
+}
+
+// NoOpStandbyNotifier is a no-op implementation of StandbyNotifier for testing
+type NoOpStandbyNotifier struct{}
+
+// NewNoOpStandbyNotifier creates a new no-op standby notifier
+func NewNoOpStandbyNotifier() *NoOpStandbyNotifier {
+	return &NoOpStandbyNotifier{}
+}
+
+// NotifyOffer is a no-op
+func (n *NoOpStandbyNotifier) NotifyOffer(ctx context.Context, offer *domain.StandbyOffer) error {
+	return nil
+}
+
+// Close is a no-op
+func (n *NoOpStandbyNotifier) Close() error {
+	return nil
+}
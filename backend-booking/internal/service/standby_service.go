@@ -0,0 +1,307 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/i18n"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// DefaultStandbyOfferWindow is how long released seats are held for a standby user
+const DefaultStandbyOfferWindow = 2 * time.Minute
+
+// StandbyService manages per-zone standby lists for sold-out zones
+type StandbyService interface {
+	// JoinStandby adds the user to the zone standby list
+	JoinStandby(ctx context.Context, userID string, req *dto.JoinStandbyRequest) (*dto.StandbyStatusResponse, error)
+
+	// GetStandbyStatus returns the user's position or held offer for a zone
+	GetStandbyStatus(ctx context.Context, userID, zoneID string) (*dto.StandbyStatusResponse, error)
+
+	// LeaveStandby removes the user from the zone standby list, releasing any held seats
+	LeaveStandby(ctx context.Context, userID, zoneID string) error
+
+	// ClaimOffer reserves seats held for the user. Returns nil if no offer is held.
+	ClaimOffer(ctx context.Context, params repository.ReserveParams) (*repository.ReserveResult, error)
+
+	// OfferReleasedSeats hands freed seats in a zone to waiting users and invites them
+	OfferReleasedSeats(ctx context.Context, zoneID string) ([]*domain.StandbyOffer, error)
+
+	// GetStandbyStats returns the zone's standby list size and offer conversion
+	GetStandbyStats(ctx context.Context, zoneID string) (*dto.StandbyStatsResponse, error)
+}
+
+// StandbyServiceConfig contains configuration for standby service
+type StandbyServiceConfig struct {
+	// OfferWindow is the exclusive window a standby user has to claim released seats
+	OfferWindow time.Duration
+	// Notifier sends offer invitations (optional - offers are only logged without it)
+	Notifier StandbyNotifier
+}
+
+// standbyService implements StandbyService
+type standbyService struct {
+	standbyRepo repository.StandbyRepository
+	notifier    StandbyNotifier
+	offerWindow time.Duration
+}
+
+// NewStandbyService creates a new standby service
+func NewStandbyService(standbyRepo repository.StandbyRepository, cfg *StandbyServiceConfig) StandbyService {
+	window := DefaultStandbyOfferWindow
+	if cfg != nil && cfg.OfferWindow > 0 {
+		window = cfg.OfferWindow
+	}
+	var notifier StandbyNotifier
+	if cfg != nil {
+		notifier = cfg.Notifier
+	}
+	return &standbyService{
+		standbyRepo: standbyRepo,
+		notifier:    notifier,
+		offerWindow: window,
+	}
+}
+
+// JoinStandby adds the user to the zone standby list
+func (s *standbyService) JoinStandby(ctx context.Context, userID string, req *dto.JoinStandbyRequest) (*dto.StandbyStatusResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.standby.join")
+	defer span.End()
+
+	// Validate inputs
+	if userID == "" {
+		span.SetStatus(codes.Error, "invalid user_id")
+		return nil, domain.ErrInvalidUserID
+	}
+	if req == nil || req.Quantity <= 0 {
+		span.SetStatus(codes.Error, "invalid quantity")
+		return nil, domain.ErrInvalidQuantity
+	}
+	if req.EventID == "" {
+		span.SetStatus(codes.Error, "invalid event_id")
+		return nil, domain.ErrInvalidEventID
+	}
+	if req.ZoneID == "" {
+		span.SetStatus(codes.Error, "invalid zone_id")
+		return nil, domain.ErrInvalidZoneID
+	}
+
+	span.SetAttributes(
+		attribute.String("user_id", userID),
+		attribute.String("event_id", req.EventID),
+		attribute.String("zone_id", req.ZoneID),
+		attribute.Int("quantity", req.Quantity),
+	)
+
+	notifyChannels := req.NotifyChannels
+	if len(notifyChannels) == 0 {
+		notifyChannels = domain.DefaultStandbyNotifyChannels
+	}
+
+	result, err := s.standbyRepo.Join(ctx, repository.JoinStandbyParams{
+		ZoneID:         req.ZoneID,
+		EventID:        req.EventID,
+		ShowID:         req.ShowID,
+		UserID:         userID,
+		Quantity:       req.Quantity,
+		NotifyChannels: notifyChannels,
+		Locale:         i18n.FromContext(ctx).String(),
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if !result.Success {
+		switch result.ErrorCode {
+		case "ALREADY_ON_STANDBY", "OFFER_PENDING":
+			span.SetStatus(codes.Error, "already on standby")
+			return nil, domain.ErrAlreadyOnStandby
+		case "INVALID_QUANTITY":
+			span.SetStatus(codes.Error, "invalid quantity")
+			return nil, domain.ErrInvalidQuantity
+		default:
+			span.SetStatus(codes.Error, result.ErrorCode)
+			return nil, fmt.Errorf("failed to join standby: %s", result.ErrorMessage)
+		}
+	}
+
+	// Seats may already be free (e.g. released between the failed reserve and this call)
+	if _, err := s.OfferReleasedSeats(ctx, req.ZoneID); err != nil {
+		span.RecordError(err)
+	}
+
+	return s.GetStandbyStatus(ctx, userID, req.ZoneID)
+}
+
+// GetStandbyStatus returns the user's position or held offer for a zone
+func (s *standbyService) GetStandbyStatus(ctx context.Context, userID, zoneID string) (*dto.StandbyStatusResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.standby.get_status")
+	defer span.End()
+
+	if userID == "" {
+		span.SetStatus(codes.Error, "invalid user_id")
+		return nil, domain.ErrInvalidUserID
+	}
+	if zoneID == "" {
+		span.SetStatus(codes.Error, "invalid zone_id")
+		return nil, domain.ErrInvalidZoneID
+	}
+
+	span.SetAttributes(
+		attribute.String("user_id", userID),
+		attribute.String("zone_id", zoneID),
+	)
+
+	entry, err := s.standbyRepo.Get(ctx, zoneID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	// A lapsed offer is equivalent to having dropped off the list
+	if entry == nil || (entry.Status == domain.StandbyStatusOffered && !entry.IsOffered()) {
+		span.SetStatus(codes.Error, "not on standby")
+		return nil, domain.ErrNotOnStandby
+	}
+
+	span.SetAttributes(attribute.String("status", entry.Status.String()))
+	span.SetStatus(codes.Ok, "")
+	return dto.StandbyFromDomain(entry), nil
+}
+
+// LeaveStandby removes the user from the zone standby list, releasing any held seats
+func (s *standbyService) LeaveStandby(ctx context.Context, userID, zoneID string) error {
+	ctx, span := telemetry.StartSpan(ctx, "service.standby.leave")
+	defer span.End()
+
+	if userID == "" {
+		span.SetStatus(codes.Error, "invalid user_id")
+		return domain.ErrInvalidUserID
+	}
+	if zoneID == "" {
+		span.SetStatus(codes.Error, "invalid zone_id")
+		return domain.ErrInvalidZoneID
+	}
+
+	span.SetAttributes(
+		attribute.String("user_id", userID),
+		attribute.String("zone_id", zoneID),
+	)
+
+	removed, err := s.standbyRepo.Leave(ctx, zoneID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	if !removed {
+		span.SetStatus(codes.Error, "not on standby")
+		return domain.ErrNotOnStandby
+	}
+
+	// Seats held for this user go to the next in line
+	if _, err := s.OfferReleasedSeats(ctx, zoneID); err != nil {
+		span.RecordError(err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// ClaimOffer reserves seats held for the user. Returns nil if no offer is held,
+// in which case the caller falls back to the regular reservation path.
+func (s *standbyService) ClaimOffer(ctx context.Context, params repository.ReserveParams) (*repository.ReserveResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.standby.claim_offer")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user_id", params.UserID),
+		attribute.String("zone_id", params.ZoneID),
+	)
+
+	result, err := s.standbyRepo.ClaimOffer(ctx, params)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	// Lapsed offers are reclaimed by the next OfferReleasedSeats run
+	if !result.Success && (result.ErrorCode == "NO_OFFER" || result.ErrorCode == "OFFER_EXPIRED") {
+		span.SetStatus(codes.Ok, "no offer")
+		return nil, nil
+	}
+
+	span.SetAttributes(attribute.Bool("claimed", result.Success))
+	span.SetStatus(codes.Ok, "")
+	return result, nil
+}
+
+// OfferReleasedSeats hands freed seats in a zone to waiting users and invites them
+func (s *standbyService) OfferReleasedSeats(ctx context.Context, zoneID string) ([]*domain.StandbyOffer, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.standby.offer_released_seats")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("zone_id", zoneID))
+
+	result, err := s.standbyRepo.OfferSeats(ctx, zoneID, s.offerWindow)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if !result.Success {
+		span.SetStatus(codes.Error, result.ErrorCode)
+		return nil, fmt.Errorf("failed to offer standby seats: %s", result.ErrorMessage)
+	}
+
+	for _, offer := range result.Offers {
+		logger.Get().Info(fmt.Sprintf("Standby offer: zone=%s, user=%s, quantity=%d, expires_at=%s",
+			offer.ZoneID, offer.UserID, offer.Quantity, offer.ExpiresAt.Format(time.RFC3339)))
+		if s.notifier != nil {
+			if err := s.notifier.NotifyOffer(ctx, offer); err != nil {
+				span.RecordError(err)
+			}
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("offers", len(result.Offers)),
+		attribute.Int64("reclaimed_seats", result.Reclaimed),
+	)
+	span.SetStatus(codes.Ok, "")
+	return result.Offers, nil
+}
+
+// GetStandbyStats returns the zone's standby list size and offer conversion
+func (s *standbyService) GetStandbyStats(ctx context.Context, zoneID string) (*dto.StandbyStatsResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.standby.get_stats")
+	defer span.End()
+
+	if zoneID == "" {
+		span.SetStatus(codes.Error, "invalid zone_id")
+		return nil, domain.ErrInvalidZoneID
+	}
+
+	span.SetAttributes(attribute.String("zone_id", zoneID))
+
+	stats, err := s.standbyRepo.Stats(ctx, zoneID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return dto.StandbyStatsFromDomain(stats), nil
+}
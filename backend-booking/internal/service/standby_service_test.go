@@ -0,0 +1,446 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/i18n"
+)
+
+// MockStandbyRepository is a mock implementation of StandbyRepository
+type MockStandbyRepository struct {
+	JoinFunc       func(ctx context.Context, params repository.JoinStandbyParams) (*repository.JoinStandbyResult, error)
+	LeaveFunc      func(ctx context.Context, zoneID, userID string) (bool, error)
+	GetFunc        func(ctx context.Context, zoneID, userID string) (*domain.StandbyEntry, error)
+	OfferSeatsFunc func(ctx context.Context, zoneID string, window time.Duration) (*repository.OfferResult, error)
+	ClaimOfferFunc func(ctx context.Context, params repository.ReserveParams) (*repository.ReserveResult, error)
+	ListZonesFunc  func(ctx context.Context) ([]string, error)
+	StatsFunc      func(ctx context.Context, zoneID string) (*domain.StandbyStats, error)
+}
+
+func (m *MockStandbyRepository) Join(ctx context.Context, params repository.JoinStandbyParams) (*repository.JoinStandbyResult, error) {
+	if m.JoinFunc != nil {
+		return m.JoinFunc(ctx, params)
+	}
+	return &repository.JoinStandbyResult{Success: true, Position: 1, TotalWaiting: 1}, nil
+}
+
+func (m *MockStandbyRepository) Leave(ctx context.Context, zoneID, userID string) (bool, error) {
+	if m.LeaveFunc != nil {
+		return m.LeaveFunc(ctx, zoneID, userID)
+	}
+	return true, nil
+}
+
+func (m *MockStandbyRepository) Get(ctx context.Context, zoneID, userID string) (*domain.StandbyEntry, error) {
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, zoneID, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockStandbyRepository) OfferSeats(ctx context.Context, zoneID string, window time.Duration) (*repository.OfferResult, error) {
+	if m.OfferSeatsFunc != nil {
+		return m.OfferSeatsFunc(ctx, zoneID, window)
+	}
+	return &repository.OfferResult{Success: true}, nil
+}
+
+func (m *MockStandbyRepository) ClaimOffer(ctx context.Context, params repository.ReserveParams) (*repository.ReserveResult, error) {
+	if m.ClaimOfferFunc != nil {
+		return m.ClaimOfferFunc(ctx, params)
+	}
+	return &repository.ReserveResult{Success: false, ErrorCode: "NO_OFFER"}, nil
+}
+
+func (m *MockStandbyRepository) ListZones(ctx context.Context) ([]string, error) {
+	if m.ListZonesFunc != nil {
+		return m.ListZonesFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *MockStandbyRepository) Stats(ctx context.Context, zoneID string) (*domain.StandbyStats, error) {
+	if m.StatsFunc != nil {
+		return m.StatsFunc(ctx, zoneID)
+	}
+	return &domain.StandbyStats{ZoneID: zoneID}, nil
+}
+
+// recordingStandbyNotifier records the offers it was asked to notify
+type recordingStandbyNotifier struct {
+	NoOpStandbyNotifier
+	offers []*domain.StandbyOffer
+}
+
+func (n *recordingStandbyNotifier) NotifyOffer(ctx context.Context, offer *domain.StandbyOffer) error {
+	n.offers = append(n.offers, offer)
+	return nil
+}
+
+func TestStandbyService_JoinStandby(t *testing.T) {
+	offered := false
+	repo := &MockStandbyRepository{
+		OfferSeatsFunc: func(ctx context.Context, zoneID string, window time.Duration) (*repository.OfferResult, error) {
+			offered = true
+			if window != 30*time.Second {
+				t.Errorf("OfferSeats() window = %v, want 30s", window)
+			}
+			return &repository.OfferResult{Success: true}, nil
+		},
+		GetFunc: func(ctx context.Context, zoneID, userID string) (*domain.StandbyEntry, error) {
+			return &domain.StandbyEntry{
+				ZoneID:   zoneID,
+				EventID:  "event-001",
+				UserID:   userID,
+				Quantity: 2,
+				Status:   domain.StandbyStatusWaiting,
+				Position: 3,
+			}, nil
+		},
+	}
+	svc := NewStandbyService(repo, &StandbyServiceConfig{OfferWindow: 30 * time.Second})
+
+	resp, err := svc.JoinStandby(context.Background(), "user-001", &dto.JoinStandbyRequest{
+		EventID:  "event-001",
+		ZoneID:   "zone-001",
+		Quantity: 2,
+	})
+	if err != nil {
+		t.Fatalf("JoinStandby() unexpected error = %v", err)
+	}
+	if !offered {
+		t.Error("JoinStandby() should try to offer already free seats")
+	}
+	if resp.Status != "waiting" || resp.Position != 3 {
+		t.Errorf("JoinStandby() = %+v, want waiting at position 3", resp)
+	}
+}
+
+func TestStandbyService_JoinStandby_AlreadyOnStandby(t *testing.T) {
+	for _, code := range []string{"ALREADY_ON_STANDBY", "OFFER_PENDING"} {
+		repo := &MockStandbyRepository{
+			JoinFunc: func(ctx context.Context, params repository.JoinStandbyParams) (*repository.JoinStandbyResult, error) {
+				return &repository.JoinStandbyResult{Success: false, ErrorCode: code}, nil
+			},
+		}
+		svc := NewStandbyService(repo, nil)
+
+		_, err := svc.JoinStandby(context.Background(), "user-001", &dto.JoinStandbyRequest{
+			EventID:  "event-001",
+			ZoneID:   "zone-001",
+			Quantity: 1,
+		})
+		if !errors.Is(err, domain.ErrAlreadyOnStandby) {
+			t.Errorf("JoinStandby() with %s error = %v, want %v", code, err, domain.ErrAlreadyOnStandby)
+		}
+	}
+}
+
+func TestStandbyService_GetStandbyStatus(t *testing.T) {
+	future := time.Now().Add(time.Minute)
+	past := time.Now().Add(-time.Second)
+
+	tests := []struct {
+		name       string
+		entry      *domain.StandbyEntry
+		wantErr    error
+		wantStatus string
+	}{
+		{
+			name:    "not on standby",
+			entry:   nil,
+			wantErr: domain.ErrNotOnStandby,
+		},
+		{
+			name:       "active offer",
+			entry:      &domain.StandbyEntry{Status: domain.StandbyStatusOffered, OfferExpiresAt: &future},
+			wantStatus: "offered",
+		},
+		{
+			name:    "lapsed offer",
+			entry:   &domain.StandbyEntry{Status: domain.StandbyStatusOffered, OfferExpiresAt: &past},
+			wantErr: domain.ErrNotOnStandby,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockStandbyRepository{
+				GetFunc: func(ctx context.Context, zoneID, userID string) (*domain.StandbyEntry, error) {
+					return tt.entry, nil
+				},
+			}
+			svc := NewStandbyService(repo, nil)
+
+			resp, err := svc.GetStandbyStatus(context.Background(), "user-001", "zone-001")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetStandbyStatus() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetStandbyStatus() unexpected error = %v", err)
+			}
+			if resp.Status != tt.wantStatus {
+				t.Errorf("GetStandbyStatus() status = %s, want %s", resp.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestStandbyService_LeaveStandby(t *testing.T) {
+	offered := false
+	repo := &MockStandbyRepository{
+		OfferSeatsFunc: func(ctx context.Context, zoneID string, window time.Duration) (*repository.OfferResult, error) {
+			offered = true
+			return &repository.OfferResult{Success: true}, nil
+		},
+	}
+	svc := NewStandbyService(repo, nil)
+
+	if err := svc.LeaveStandby(context.Background(), "user-001", "zone-001"); err != nil {
+		t.Fatalf("LeaveStandby() unexpected error = %v", err)
+	}
+	if !offered {
+		t.Error("LeaveStandby() should pass released seats to the next user")
+	}
+
+	repo.LeaveFunc = func(ctx context.Context, zoneID, userID string) (bool, error) {
+		return false, nil
+	}
+	if err := svc.LeaveStandby(context.Background(), "user-001", "zone-001"); !errors.Is(err, domain.ErrNotOnStandby) {
+		t.Errorf("LeaveStandby() error = %v, want %v", err, domain.ErrNotOnStandby)
+	}
+}
+
+func TestStandbyService_ClaimOffer(t *testing.T) {
+	tests := []struct {
+		name      string
+		result    *repository.ReserveResult
+		wantNil   bool
+		wantClaim bool
+	}{
+		{name: "no offer", result: &repository.ReserveResult{ErrorCode: "NO_OFFER"}, wantNil: true},
+		{name: "lapsed offer", result: &repository.ReserveResult{ErrorCode: "OFFER_EXPIRED"}, wantNil: true},
+		{name: "mismatch", result: &repository.ReserveResult{ErrorCode: "OFFER_MISMATCH"}},
+		{name: "claimed", result: &repository.ReserveResult{Success: true, BookingID: "booking-1"}, wantClaim: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockStandbyRepository{
+				ClaimOfferFunc: func(ctx context.Context, params repository.ReserveParams) (*repository.ReserveResult, error) {
+					return tt.result, nil
+				},
+			}
+			svc := NewStandbyService(repo, nil)
+
+			result, err := svc.ClaimOffer(context.Background(), repository.ReserveParams{ZoneID: "zone-001", UserID: "user-001"})
+			if err != nil {
+				t.Fatalf("ClaimOffer() unexpected error = %v", err)
+			}
+			if (result == nil) != tt.wantNil {
+				t.Fatalf("ClaimOffer() = %+v, wantNil %v", result, tt.wantNil)
+			}
+			if result != nil && result.Success != tt.wantClaim {
+				t.Errorf("ClaimOffer() success = %v, want %v", result.Success, tt.wantClaim)
+			}
+		})
+	}
+}
+
+func TestBookingService_ReserveSeats_ClaimsStandbyOffer(t *testing.T) {
+	reservationRepo := &MockReservationRepository{
+		ReserveSeatsFunc: func(ctx context.Context, params repository.ReserveParams) (*repository.ReserveResult, error) {
+			t.Error("ReserveSeats() should not compete for general availability when an offer is held")
+			return nil, errors.New("unexpected call")
+		},
+	}
+	standbyRepo := &MockStandbyRepository{
+		ClaimOfferFunc: func(ctx context.Context, params repository.ReserveParams) (*repository.ReserveResult, error) {
+			return &repository.ReserveResult{Success: true, BookingID: "standby-booking"}, nil
+		},
+	}
+	svc := NewBookingService(&MockBookingRepository{}, reservationRepo, nil, nil, NewStandbyService(standbyRepo, nil), nil, nil, nil, nil)
+
+	resp, err := svc.ReserveSeats(context.Background(), "user-001", &dto.ReserveSeatsRequest{
+		EventID:  "event-001",
+		ZoneID:   "zone-001",
+		ShowID:   "show-001",
+		Quantity: 2,
+	})
+	if err != nil {
+		t.Fatalf("ReserveSeats() unexpected error = %v", err)
+	}
+	if resp.BookingID != "standby-booking" || resp.Standby != nil {
+		t.Errorf("ReserveSeats() = %+v, want claimed booking", resp)
+	}
+}
+
+func TestBookingService_ReserveSeats_JoinsStandbyWhenSoldOut(t *testing.T) {
+	reservationRepo := &MockReservationRepository{
+		ReserveSeatsFunc: func(ctx context.Context, params repository.ReserveParams) (*repository.ReserveResult, error) {
+			return &repository.ReserveResult{Success: false, ErrorCode: "INSUFFICIENT_STOCK"}, nil
+		},
+	}
+	var joined *repository.JoinStandbyParams
+	standbyRepo := &MockStandbyRepository{
+		JoinFunc: func(ctx context.Context, params repository.JoinStandbyParams) (*repository.JoinStandbyResult, error) {
+			joined = &params
+			return &repository.JoinStandbyResult{Success: true, Position: 1, TotalWaiting: 1}, nil
+		},
+		GetFunc: func(ctx context.Context, zoneID, userID string) (*domain.StandbyEntry, error) {
+			return &domain.StandbyEntry{ZoneID: zoneID, UserID: userID, Quantity: 2, Status: domain.StandbyStatusWaiting, Position: 1}, nil
+		},
+	}
+	svc := NewBookingService(&MockBookingRepository{}, reservationRepo, nil, nil, NewStandbyService(standbyRepo, nil), nil, nil, nil, nil)
+
+	req := &dto.ReserveSeatsRequest{
+		EventID:  "event-001",
+		ZoneID:   "zone-001",
+		ShowID:   "show-001",
+		Quantity: 2,
+	}
+
+	// Without opting in, a sold-out zone is still a hard failure
+	if _, err := svc.ReserveSeats(context.Background(), "user-001", req); !errors.Is(err, domain.ErrInsufficientSeats) {
+		t.Fatalf("ReserveSeats() error = %v, want %v", err, domain.ErrInsufficientSeats)
+	}
+	if joined != nil {
+		t.Fatal("ReserveSeats() joined standby without opt-in")
+	}
+
+	req.JoinStandby = true
+	resp, err := svc.ReserveSeats(context.Background(), "user-001", req)
+	if err != nil {
+		t.Fatalf("ReserveSeats() unexpected error = %v", err)
+	}
+	if resp.Status != "standby" || resp.Standby == nil || resp.Standby.Position != 1 {
+		t.Errorf("ReserveSeats() = %+v, want standby response", resp)
+	}
+	if joined == nil || joined.Quantity != 2 || joined.ZoneID != "zone-001" {
+		t.Errorf("Join() params = %+v", joined)
+	}
+}
+
+func TestStandbyService_JoinStandby_NotifyChannels(t *testing.T) {
+	tests := []struct {
+		name     string
+		channels []string
+		want     string
+	}{
+		{"defaults to email", nil, "email"},
+		{"chosen channels", []string{"sms", "push"}, "sms,push"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			repo := &MockStandbyRepository{
+				JoinFunc: func(ctx context.Context, params repository.JoinStandbyParams) (*repository.JoinStandbyResult, error) {
+					got = params.NotifyChannels
+					return &repository.JoinStandbyResult{Success: true, Position: 1, TotalWaiting: 1}, nil
+				},
+				GetFunc: func(ctx context.Context, zoneID, userID string) (*domain.StandbyEntry, error) {
+					return &domain.StandbyEntry{ZoneID: zoneID, UserID: userID, Status: domain.StandbyStatusWaiting, Position: 1}, nil
+				},
+			}
+			svc := NewStandbyService(repo, nil)
+
+			_, err := svc.JoinStandby(context.Background(), "user-001", &dto.JoinStandbyRequest{
+				EventID:        "event-001",
+				ZoneID:         "zone-001",
+				Quantity:       1,
+				NotifyChannels: tt.channels,
+			})
+			if err != nil {
+				t.Fatalf("JoinStandby() unexpected error = %v", err)
+			}
+			if strings.Join(got, ",") != tt.want {
+				t.Errorf("JoinStandby() notify channels = %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStandbyService_JoinStandby_StoresRequestLocale(t *testing.T) {
+	var got string
+	repo := &MockStandbyRepository{
+		JoinFunc: func(ctx context.Context, params repository.JoinStandbyParams) (*repository.JoinStandbyResult, error) {
+			got = params.Locale
+			return &repository.JoinStandbyResult{Success: true, Position: 1, TotalWaiting: 1}, nil
+		},
+		GetFunc: func(ctx context.Context, zoneID, userID string) (*domain.StandbyEntry, error) {
+			return &domain.StandbyEntry{ZoneID: zoneID, UserID: userID, Status: domain.StandbyStatusWaiting, Position: 1}, nil
+		},
+	}
+	svc := NewStandbyService(repo, nil)
+
+	ctx := i18n.WithLocale(context.Background(), i18n.Thai)
+	if _, err := svc.JoinStandby(ctx, "user-001", &dto.JoinStandbyRequest{EventID: "event-001", ZoneID: "zone-001", Quantity: 1}); err != nil {
+		t.Fatalf("JoinStandby() unexpected error = %v", err)
+	}
+	if got != "th" {
+		t.Errorf("JoinStandby() locale = %q, want th", got)
+	}
+
+	// The invitation is rendered in the stored locale
+	event := domain.NewStandbyOfferEvent(&domain.StandbyOffer{UserID: "user-001", Quantity: 2, Locale: got, ExpiresAt: time.Now()}, "evt-1")
+	if event.Notification == nil || event.Notification.Locale != i18n.Thai || event.Notification.Subject != "มีที่นั่งกันไว้ให้คุณแล้ว" {
+		t.Errorf("Unexpected notification %+v", event.Notification)
+	}
+}
+
+func TestStandbyService_OfferReleasedSeats_NotifiesOffers(t *testing.T) {
+	offers := []*domain.StandbyOffer{
+		{ZoneID: "zone-001", UserID: "user-001", Quantity: 2, ExpiresAt: time.Now().Add(time.Minute), NotifyChannels: []string{"push"}},
+		{ZoneID: "zone-001", UserID: "user-002", Quantity: 1, ExpiresAt: time.Now().Add(time.Minute)},
+	}
+	repo := &MockStandbyRepository{
+		OfferSeatsFunc: func(ctx context.Context, zoneID string, window time.Duration) (*repository.OfferResult, error) {
+			return &repository.OfferResult{Success: true, Offers: offers}, nil
+		},
+	}
+	notifier := &recordingStandbyNotifier{}
+	svc := NewStandbyService(repo, &StandbyServiceConfig{Notifier: notifier})
+
+	got, err := svc.OfferReleasedSeats(context.Background(), "zone-001")
+	if err != nil {
+		t.Fatalf("OfferReleasedSeats() unexpected error = %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("OfferReleasedSeats() returned %d offers, want 2", len(got))
+	}
+	if len(notifier.offers) != 2 || notifier.offers[0].UserID != "user-001" || notifier.offers[1].UserID != "user-002" {
+		t.Errorf("OfferReleasedSeats() notified %+v, want both offers in order", notifier.offers)
+	}
+}
+
+func TestStandbyService_GetStandbyStats(t *testing.T) {
+	repo := &MockStandbyRepository{
+		StatsFunc: func(ctx context.Context, zoneID string) (*domain.StandbyStats, error) {
+			return &domain.StandbyStats{ZoneID: zoneID, Waiting: 5, Offered: 4, Claimed: 3, Lapsed: 1}, nil
+		},
+	}
+	svc := NewStandbyService(repo, nil)
+
+	stats, err := svc.GetStandbyStats(context.Background(), "zone-001")
+	if err != nil {
+		t.Fatalf("GetStandbyStats() unexpected error = %v", err)
+	}
+	if stats.Waiting != 5 || stats.Claimed != 3 || stats.ConversionRate != 0.75 {
+		t.Errorf("GetStandbyStats() = %+v, want 5 waiting and 0.75 conversion", stats)
+	}
+
+	if _, err := svc.GetStandbyStats(context.Background(), ""); !errors.Is(err, domain.ErrInvalidZoneID) {
+		t.Errorf("GetStandbyStats() with empty zone error = %v, want %v", err, domain.ErrInvalidZoneID)
+	}
+}
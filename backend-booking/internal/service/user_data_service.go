@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// UserDataService exports and anonymizes booking data for a user (GDPR requests from auth-service)
+type UserDataService interface {
+	// ExportUserData returns every booking owned by the user
+	ExportUserData(ctx context.Context, userID string) ([]*domain.Booking, error)
+
+	// AnonymizeUserData re-assigns the user's bookings to anonymizedID.
+	// Safe to call repeatedly: a second call finds no bookings left for userID.
+	AnonymizeUserData(ctx context.Context, userID, anonymizedID string) (int64, error)
+}
+
+// userDataService implements UserDataService
+type userDataService struct {
+	userDataRepo repository.UserDataRepository
+}
+
+// NewUserDataService creates a new user data service
+func NewUserDataService(userDataRepo repository.UserDataRepository) UserDataService {
+	return &userDataService{
+		userDataRepo: userDataRepo,
+	}
+}
+
+// ExportUserData returns every booking owned by the user
+func (s *userDataService) ExportUserData(ctx context.Context, userID string) ([]*domain.Booking, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.user_data.export")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", userID))
+
+	if userID == "" {
+		span.SetStatus(codes.Error, "invalid user id")
+		return nil, domain.ErrInvalidUserID
+	}
+
+	bookings, err := s.userDataRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if bookings == nil {
+		bookings = []*domain.Booking{}
+	}
+
+	span.SetAttributes(attribute.Int("count", len(bookings)))
+	span.SetStatus(codes.Ok, "")
+	return bookings, nil
+}
+
+// AnonymizeUserData re-assigns the user's bookings to anonymizedID
+func (s *userDataService) AnonymizeUserData(ctx context.Context, userID, anonymizedID string) (int64, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.user_data.anonymize")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", userID))
+
+	if userID == "" || anonymizedID == "" || userID == anonymizedID {
+		span.SetStatus(codes.Error, "invalid user id")
+		return 0, domain.ErrInvalidUserID
+	}
+
+	count, err := s.userDataRepo.AnonymizeByUserID(ctx, userID, anonymizedID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return 0, err
+	}
+
+	span.SetAttributes(attribute.Int64("anonymized", count))
+	span.SetStatus(codes.Ok, "")
+	return count, nil
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/middleware"
+)
+
+// mockVerificationPolicyRepository is a mock implementation of VerificationPolicyRepository
+type mockVerificationPolicyRepository struct {
+	policies map[string]string
+}
+
+func (m *mockVerificationPolicyRepository) GetEventPolicy(ctx context.Context, eventID string) (string, error) {
+	if policy, ok := m.policies[eventID]; ok {
+		return policy, nil
+	}
+	return domain.VerificationPolicyNone, nil
+}
+
+func TestPolicyVerificationGate_Check(t *testing.T) {
+	now := time.Now()
+	gate := NewPolicyVerificationGate(&mockVerificationPolicyRepository{policies: map[string]string{
+		"event-email":    domain.VerificationPolicyEmail,
+		"event-verified": domain.VerificationPolicyVerified,
+	}}, &PolicyVerificationGateConfig{ChallengeMaxAge: 10 * time.Minute})
+
+	emailOnly := &middleware.Verification{EmailVerified: true, RiskLevel: middleware.RiskLevelNormal}
+	verified := &middleware.Verification{EmailVerified: true, PhoneVerified: true, RiskLevel: middleware.RiskLevelNormal}
+
+	tests := []struct {
+		name         string
+		eventID      string
+		verification *middleware.Verification
+		wantErr      error
+	}{
+		{"open event, unknown caller", "event-open", nil, nil},
+		{"email policy, unverified", "event-email", &middleware.Verification{RiskLevel: middleware.RiskLevelNormal}, domain.ErrAccountNotVerified},
+		{"email policy, email verified", "event-email", emailOnly, nil},
+		{"verified policy, email only", "event-verified", emailOnly, domain.ErrAccountNotVerified},
+		{"verified policy, fully verified", "event-verified", verified, nil},
+		{"high risk, never challenged", "event-open", &middleware.Verification{RiskLevel: middleware.RiskLevelHigh}, domain.ErrVerificationChallengeRequired},
+		{"high risk, stale challenge", "event-open", &middleware.Verification{
+			RiskLevel:  middleware.RiskLevelHigh,
+			VerifiedAt: now.Add(-time.Hour),
+		}, domain.ErrVerificationChallengeRequired},
+		{"high risk, recent challenge", "event-open", &middleware.Verification{
+			RiskLevel:  middleware.RiskLevelHigh,
+			VerifiedAt: now.Add(-time.Minute),
+		}, nil},
+		{"high risk, recent challenge, policy not met", "event-verified", &middleware.Verification{
+			EmailVerified: true,
+			RiskLevel:     middleware.RiskLevelHigh,
+			VerifiedAt:    now.Add(-time.Minute),
+		}, domain.ErrAccountNotVerified},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := gate.Check(context.Background(), tt.eventID, tt.verification)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Check() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBookingService_ReserveSeats_VerificationGate(t *testing.T) {
+	reserved := false
+	reservationRepo := &MockReservationRepository{
+		ReserveSeatsFunc: func(ctx context.Context, params repository.ReserveParams) (*repository.ReserveResult, error) {
+			reserved = true
+			return &repository.ReserveResult{Success: true, BookingID: "booking-001"}, nil
+		},
+	}
+	gate := NewPolicyVerificationGate(&mockVerificationPolicyRepository{policies: map[string]string{
+		"event-001": domain.VerificationPolicyVerified,
+	}}, nil)
+
+	svc := NewBookingService(&MockBookingRepository{}, reservationRepo, nil, nil, nil, nil, gate, nil, nil)
+	_, err := svc.ReserveSeats(context.Background(), "user-001", &dto.ReserveSeatsRequest{
+		EventID:      "event-001",
+		ZoneID:       "zone-001",
+		ShowID:       "show-001",
+		TenantID:     "tenant-001",
+		Quantity:     2,
+		Verification: &middleware.Verification{EmailVerified: true, RiskLevel: middleware.RiskLevelNormal},
+	})
+	if !errors.Is(err, domain.ErrAccountNotVerified) {
+		t.Fatalf("ReserveSeats() error = %v, want %v", err, domain.ErrAccountNotVerified)
+	}
+	if reserved {
+		t.Error("Expected no seats to be held for an unverified account")
+	}
+
+	if code := bookingErrorCode(err); code != "ACCOUNT_NOT_VERIFIED" {
+		t.Errorf("bookingErrorCode() = %s, want ACCOUNT_NOT_VERIFIED", code)
+	}
+}
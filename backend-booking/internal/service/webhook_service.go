@@ -0,0 +1,442 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// DefaultWebhookMaxAttempts is how many times a delivery is attempted before it is marked failed
+const DefaultWebhookMaxAttempts = 8
+
+// WebhookService manages tenant webhook subscriptions and their delivery log
+type WebhookService interface {
+	// CreateSubscription registers a new endpoint; the response carries the signing secret
+	CreateSubscription(ctx context.Context, tenantID string, req *dto.CreateWebhookSubscriptionRequest) (*dto.WebhookSubscriptionResponse, error)
+
+	// GetSubscription returns a tenant's subscription
+	GetSubscription(ctx context.Context, tenantID, id string) (*dto.WebhookSubscriptionResponse, error)
+
+	// ListSubscriptions returns all subscriptions of a tenant
+	ListSubscriptions(ctx context.Context, tenantID string) ([]*dto.WebhookSubscriptionResponse, error)
+
+	// UpdateSubscription changes a tenant's subscription
+	UpdateSubscription(ctx context.Context, tenantID, id string, req *dto.UpdateWebhookSubscriptionRequest) (*dto.WebhookSubscriptionResponse, error)
+
+	// DeleteSubscription removes a tenant's subscription
+	DeleteSubscription(ctx context.Context, tenantID, id string) error
+
+	// ListDeliveries returns a page of a tenant's delivery log
+	ListDeliveries(ctx context.Context, tenantID string, req *dto.WebhookDeliveryListRequest) (*dto.WebhookDeliveryListResponse, error)
+
+	// GetDelivery returns a single delivery including its payload
+	GetDelivery(ctx context.Context, tenantID, id string) (*dto.WebhookDeliveryResponse, error)
+
+	// RetryDelivery schedules a failed delivery for another round of attempts
+	RetryDelivery(ctx context.Context, tenantID, id string) (*dto.WebhookDeliveryResponse, error)
+
+	// EnqueueEvent queues a delivery of the event for every active subscription of the tenant
+	// to its type. Returns the number of deliveries created.
+	EnqueueEvent(ctx context.Context, tenantID string, eventType domain.WebhookEventType, eventID string, occurredAt time.Time, data interface{}) (int, error)
+}
+
+// WebhookServiceConfig contains configuration for webhook service
+type WebhookServiceConfig struct {
+	// MaxAttempts is the number of attempts per delivery, and per manual retry
+	MaxAttempts int
+}
+
+// webhookService implements WebhookService
+type webhookService struct {
+	subscriptionRepo repository.WebhookSubscriptionRepository
+	deliveryRepo     repository.WebhookDeliveryRepository
+	maxAttempts      int
+}
+
+// NewWebhookService creates a new webhook service
+func NewWebhookService(
+	subscriptionRepo repository.WebhookSubscriptionRepository,
+	deliveryRepo repository.WebhookDeliveryRepository,
+	cfg *WebhookServiceConfig,
+) WebhookService {
+	maxAttempts := DefaultWebhookMaxAttempts
+	if cfg != nil && cfg.MaxAttempts > 0 {
+		maxAttempts = cfg.MaxAttempts
+	}
+	return &webhookService{
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+		maxAttempts:      maxAttempts,
+	}
+}
+
+// CreateSubscription registers a new endpoint; the response carries the signing secret
+func (s *webhookService) CreateSubscription(ctx context.Context, tenantID string, req *dto.CreateWebhookSubscriptionRequest) (*dto.WebhookSubscriptionResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.webhook.create_subscription")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("tenant_id", tenantID))
+
+	if req == nil {
+		span.SetStatus(codes.Error, "invalid url")
+		return nil, domain.ErrInvalidWebhookURL
+	}
+
+	sub, err := domain.NewWebhookSubscription(tenantID, req.URL, toWebhookEventTypes(req.EventTypes), req.Description)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if err := s.subscriptionRepo.Create(ctx, sub); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.String("subscription_id", sub.ID))
+	span.SetStatus(codes.Ok, "")
+
+	resp := dto.WebhookSubscriptionFromDomain(sub)
+	resp.Secret = sub.Secret
+	return resp, nil
+}
+
+// GetSubscription returns a tenant's subscription
+func (s *webhookService) GetSubscription(ctx context.Context, tenantID, id string) (*dto.WebhookSubscriptionResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.webhook.get_subscription")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("tenant_id", tenantID),
+		attribute.String("subscription_id", id),
+	)
+
+	if tenantID == "" {
+		span.SetStatus(codes.Error, "invalid tenant_id")
+		return nil, domain.ErrInvalidTenantID
+	}
+
+	sub, err := s.subscriptionRepo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return dto.WebhookSubscriptionFromDomain(sub), nil
+}
+
+// ListSubscriptions returns all subscriptions of a tenant
+func (s *webhookService) ListSubscriptions(ctx context.Context, tenantID string) ([]*dto.WebhookSubscriptionResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.webhook.list_subscriptions")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("tenant_id", tenantID))
+
+	if tenantID == "" {
+		span.SetStatus(codes.Error, "invalid tenant_id")
+		return nil, domain.ErrInvalidTenantID
+	}
+
+	subs, err := s.subscriptionRepo.ListByTenant(ctx, tenantID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	result := make([]*dto.WebhookSubscriptionResponse, 0, len(subs))
+	for _, sub := range subs {
+		result = append(result, dto.WebhookSubscriptionFromDomain(sub))
+	}
+
+	span.SetAttributes(attribute.Int("count", len(result)))
+	span.SetStatus(codes.Ok, "")
+	return result, nil
+}
+
+// UpdateSubscription changes a tenant's subscription
+func (s *webhookService) UpdateSubscription(ctx context.Context, tenantID, id string, req *dto.UpdateWebhookSubscriptionRequest) (*dto.WebhookSubscriptionResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.webhook.update_subscription")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("tenant_id", tenantID),
+		attribute.String("subscription_id", id),
+	)
+
+	if tenantID == "" {
+		span.SetStatus(codes.Error, "invalid tenant_id")
+		return nil, domain.ErrInvalidTenantID
+	}
+
+	sub, err := s.subscriptionRepo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if req != nil {
+		if req.URL != nil {
+			if err := domain.ValidateWebhookURL(*req.URL); err != nil {
+				span.SetStatus(codes.Error, err.Error())
+				return nil, err
+			}
+			sub.URL = *req.URL
+		}
+		if req.EventTypes != nil {
+			eventTypes := toWebhookEventTypes(req.EventTypes)
+			if err := domain.ValidateWebhookEventTypes(eventTypes); err != nil {
+				span.SetStatus(codes.Error, err.Error())
+				return nil, err
+			}
+			sub.EventTypes = eventTypes
+		}
+		if req.Description != nil {
+			sub.Description = *req.Description
+		}
+		if req.Active != nil {
+			sub.Active = *req.Active
+		}
+	}
+	sub.UpdatedAt = time.Now()
+
+	if err := s.subscriptionRepo.Update(ctx, sub); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return dto.WebhookSubscriptionFromDomain(sub), nil
+}
+
+// DeleteSubscription removes a tenant's subscription
+func (s *webhookService) DeleteSubscription(ctx context.Context, tenantID, id string) error {
+	ctx, span := telemetry.StartSpan(ctx, "service.webhook.delete_subscription")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("tenant_id", tenantID),
+		attribute.String("subscription_id", id),
+	)
+
+	if tenantID == "" {
+		span.SetStatus(codes.Error, "invalid tenant_id")
+		return domain.ErrInvalidTenantID
+	}
+
+	if err := s.subscriptionRepo.Delete(ctx, tenantID, id); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// ListDeliveries returns a page of a tenant's delivery log
+func (s *webhookService) ListDeliveries(ctx context.Context, tenantID string, req *dto.WebhookDeliveryListRequest) (*dto.WebhookDeliveryListResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.webhook.list_deliveries")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("tenant_id", tenantID))
+
+	if tenantID == "" {
+		span.SetStatus(codes.Error, "invalid tenant_id")
+		return nil, domain.ErrInvalidTenantID
+	}
+	if req == nil {
+		req = &dto.WebhookDeliveryListRequest{}
+	}
+
+	filter := &repository.WebhookDeliveryFilter{
+		TenantID:       tenantID,
+		SubscriptionID: req.SubscriptionID,
+		EventID:        req.EventID,
+		Limit:          req.Limit,
+		Offset:         req.Offset,
+	}
+	if req.EventType != "" {
+		filter.EventType = domain.WebhookEventType(req.EventType)
+		if !filter.EventType.IsValid() {
+			span.SetStatus(codes.Error, "invalid event_type")
+			return nil, domain.ErrInvalidWebhookEventType
+		}
+	}
+	if req.Status != "" {
+		filter.Status = domain.WebhookDeliveryStatus(req.Status)
+		if !filter.Status.IsValid() {
+			span.SetStatus(codes.Error, "invalid status")
+			return nil, domain.ErrInvalidWebhookStatus
+		}
+	}
+
+	deliveries, err := s.deliveryRepo.List(ctx, filter)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	resp := &dto.WebhookDeliveryListResponse{
+		Deliveries: make([]*dto.WebhookDeliveryResponse, 0, len(deliveries)),
+		Limit:      filter.Limit,
+		Offset:     filter.Offset,
+	}
+	for _, d := range deliveries {
+		item := dto.WebhookDeliveryFromDomain(d)
+		item.Payload = nil // Payloads are only returned by GetDelivery
+		resp.Deliveries = append(resp.Deliveries, item)
+	}
+
+	span.SetAttributes(attribute.Int("count", len(resp.Deliveries)))
+	span.SetStatus(codes.Ok, "")
+	return resp, nil
+}
+
+// GetDelivery returns a single delivery including its payload
+func (s *webhookService) GetDelivery(ctx context.Context, tenantID, id string) (*dto.WebhookDeliveryResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.webhook.get_delivery")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("tenant_id", tenantID),
+		attribute.String("delivery_id", id),
+	)
+
+	if tenantID == "" {
+		span.SetStatus(codes.Error, "invalid tenant_id")
+		return nil, domain.ErrInvalidTenantID
+	}
+
+	d, err := s.deliveryRepo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return dto.WebhookDeliveryFromDomain(d), nil
+}
+
+// RetryDelivery schedules a failed delivery for another round of attempts
+func (s *webhookService) RetryDelivery(ctx context.Context, tenantID, id string) (*dto.WebhookDeliveryResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.webhook.retry_delivery")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("tenant_id", tenantID),
+		attribute.String("delivery_id", id),
+	)
+
+	if tenantID == "" {
+		span.SetStatus(codes.Error, "invalid tenant_id")
+		return nil, domain.ErrInvalidTenantID
+	}
+
+	d, err := s.deliveryRepo.GetByID(ctx, tenantID, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if err := d.ResetForRetry(s.maxAttempts); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if err := s.deliveryRepo.Update(ctx, d); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return dto.WebhookDeliveryFromDomain(d), nil
+}
+
+// EnqueueEvent queues a delivery of the event for every active subscription of the tenant
+// to its type. Returns the number of deliveries created.
+func (s *webhookService) EnqueueEvent(ctx context.Context, tenantID string, eventType domain.WebhookEventType, eventID string, occurredAt time.Time, data interface{}) (int, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.webhook.enqueue_event")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("tenant_id", tenantID),
+		attribute.String("event_type", eventType.String()),
+		attribute.String("event_id", eventID),
+	)
+
+	if tenantID == "" {
+		span.SetStatus(codes.Error, "invalid tenant_id")
+		return 0, domain.ErrInvalidTenantID
+	}
+	if !eventType.IsValid() {
+		span.SetStatus(codes.Error, "invalid event_type")
+		return 0, domain.ErrInvalidWebhookEventType
+	}
+
+	subs, err := s.subscriptionRepo.ListActiveForEvent(ctx, tenantID, eventType)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return 0, err
+	}
+	if len(subs) == 0 {
+		span.SetStatus(codes.Ok, "")
+		return 0, nil
+	}
+
+	payload, err := json.Marshal(&domain.WebhookPayload{
+		ID:        eventID,
+		Type:      eventType,
+		TenantID:  tenantID,
+		CreatedAt: occurredAt,
+		Data:      data,
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return 0, fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	created := 0
+	for _, sub := range subs {
+		ok, err := s.deliveryRepo.Create(ctx, domain.NewWebhookDelivery(sub, eventID, eventType, payload, s.maxAttempts))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return created, err
+		}
+		if ok {
+			created++
+		}
+	}
+
+	span.SetAttributes(attribute.Int("deliveries", created))
+	span.SetStatus(codes.Ok, "")
+	return created, nil
+}
+
+func toWebhookEventTypes(eventTypes []string) []domain.WebhookEventType {
+	out := make([]domain.WebhookEventType, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		out = append(out, domain.WebhookEventType(t))
+	}
+	return out
+}
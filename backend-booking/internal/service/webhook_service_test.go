@@ -0,0 +1,301 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+)
+
+// MockWebhookSubscriptionRepository is a mock implementation of WebhookSubscriptionRepository
+type MockWebhookSubscriptionRepository struct {
+	Created                []*domain.WebhookSubscription
+	Updated                []*domain.WebhookSubscription
+	GetByIDFunc            func(ctx context.Context, tenantID, id string) (*domain.WebhookSubscription, error)
+	ListByTenantFunc       func(ctx context.Context, tenantID string) ([]*domain.WebhookSubscription, error)
+	ListActiveForEventFunc func(ctx context.Context, tenantID string, eventType domain.WebhookEventType) ([]*domain.WebhookSubscription, error)
+	DeleteFunc             func(ctx context.Context, tenantID, id string) error
+}
+
+func (m *MockWebhookSubscriptionRepository) Create(ctx context.Context, sub *domain.WebhookSubscription) error {
+	sub.ID = fmt.Sprintf("sub-%d", len(m.Created)+1)
+	m.Created = append(m.Created, sub)
+	return nil
+}
+
+func (m *MockWebhookSubscriptionRepository) GetByID(ctx context.Context, tenantID, id string) (*domain.WebhookSubscription, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, tenantID, id)
+	}
+	return nil, domain.ErrWebhookSubscriptionNotFound
+}
+
+func (m *MockWebhookSubscriptionRepository) ListByTenant(ctx context.Context, tenantID string) ([]*domain.WebhookSubscription, error) {
+	if m.ListByTenantFunc != nil {
+		return m.ListByTenantFunc(ctx, tenantID)
+	}
+	return nil, nil
+}
+
+func (m *MockWebhookSubscriptionRepository) ListActiveForEvent(ctx context.Context, tenantID string, eventType domain.WebhookEventType) ([]*domain.WebhookSubscription, error) {
+	if m.ListActiveForEventFunc != nil {
+		return m.ListActiveForEventFunc(ctx, tenantID, eventType)
+	}
+	return nil, nil
+}
+
+func (m *MockWebhookSubscriptionRepository) Update(ctx context.Context, sub *domain.WebhookSubscription) error {
+	m.Updated = append(m.Updated, sub)
+	return nil
+}
+
+func (m *MockWebhookSubscriptionRepository) Delete(ctx context.Context, tenantID, id string) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, tenantID, id)
+	}
+	return nil
+}
+
+// MockWebhookDeliveryRepository is a mock implementation of WebhookDeliveryRepository
+type MockWebhookDeliveryRepository struct {
+	Created      []*domain.WebhookDelivery
+	Updated      []*domain.WebhookDelivery
+	Filters      []*repository.WebhookDeliveryFilter
+	CreateFunc   func(ctx context.Context, d *domain.WebhookDelivery) (bool, error)
+	GetByIDFunc  func(ctx context.Context, tenantID, id string) (*domain.WebhookDelivery, error)
+	ListFunc     func(ctx context.Context, filter *repository.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error)
+	ClaimDueFunc func(ctx context.Context, limit int, lease time.Duration) ([]*domain.WebhookDelivery, error)
+}
+
+func (m *MockWebhookDeliveryRepository) Create(ctx context.Context, d *domain.WebhookDelivery) (bool, error) {
+	m.Created = append(m.Created, d)
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, d)
+	}
+	return true, nil
+}
+
+func (m *MockWebhookDeliveryRepository) GetByID(ctx context.Context, tenantID, id string) (*domain.WebhookDelivery, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, tenantID, id)
+	}
+	return nil, domain.ErrWebhookDeliveryNotFound
+}
+
+func (m *MockWebhookDeliveryRepository) List(ctx context.Context, filter *repository.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error) {
+	m.Filters = append(m.Filters, filter)
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, filter)
+	}
+	return nil, nil
+}
+
+func (m *MockWebhookDeliveryRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*domain.WebhookDelivery, error) {
+	if m.ClaimDueFunc != nil {
+		return m.ClaimDueFunc(ctx, limit, lease)
+	}
+	return nil, nil
+}
+
+func (m *MockWebhookDeliveryRepository) Update(ctx context.Context, d *domain.WebhookDelivery) error {
+	m.Updated = append(m.Updated, d)
+	return nil
+}
+
+func TestWebhookService_CreateSubscription(t *testing.T) {
+	subRepo := &MockWebhookSubscriptionRepository{}
+	svc := NewWebhookService(subRepo, &MockWebhookDeliveryRepository{}, nil)
+
+	resp, err := svc.CreateSubscription(context.Background(), "tenant-1", &dto.CreateWebhookSubscriptionRequest{
+		URL:        "https://example.com/hooks",
+		EventTypes: []string{"booking.confirmed", "payment.refunded"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(subRepo.Created) != 1 || subRepo.Created[0].TenantID != "tenant-1" {
+		t.Fatalf("expected subscription created for tenant-1, got %+v", subRepo.Created)
+	}
+	if resp.Secret == "" || resp.Secret != subRepo.Created[0].Secret {
+		t.Error("expected the signing secret in the create response")
+	}
+	if len(resp.EventTypes) != 2 || !resp.Active {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+
+	_, err = svc.CreateSubscription(context.Background(), "tenant-1", &dto.CreateWebhookSubscriptionRequest{
+		URL:        "https://example.com/hooks",
+		EventTypes: []string{"booking.created"},
+	})
+	if !errors.Is(err, domain.ErrInvalidWebhookEventType) {
+		t.Errorf("expected ErrInvalidWebhookEventType, got %v", err)
+	}
+}
+
+func TestWebhookService_UpdateSubscription(t *testing.T) {
+	existing := &domain.WebhookSubscription{
+		ID:         "sub-1",
+		TenantID:   "tenant-1",
+		URL:        "https://example.com/old",
+		Secret:     "whsec_test",
+		EventTypes: []domain.WebhookEventType{domain.WebhookEventBookingConfirmed},
+		Active:     true,
+	}
+	subRepo := &MockWebhookSubscriptionRepository{
+		GetByIDFunc: func(ctx context.Context, tenantID, id string) (*domain.WebhookSubscription, error) {
+			if tenantID != "tenant-1" || id != "sub-1" {
+				return nil, domain.ErrWebhookSubscriptionNotFound
+			}
+			return existing, nil
+		},
+	}
+	svc := NewWebhookService(subRepo, &MockWebhookDeliveryRepository{}, nil)
+
+	newURL := "https://example.com/new"
+	inactive := false
+	resp, err := svc.UpdateSubscription(context.Background(), "tenant-1", "sub-1", &dto.UpdateWebhookSubscriptionRequest{
+		URL:    &newURL,
+		Active: &inactive,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.URL != newURL || resp.Active || resp.Secret != "" {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+	if len(subRepo.Updated) != 1 || len(subRepo.Updated[0].EventTypes) != 1 {
+		t.Errorf("expected event types to be unchanged, got %+v", subRepo.Updated)
+	}
+
+	_, err = svc.UpdateSubscription(context.Background(), "tenant-2", "sub-1", &dto.UpdateWebhookSubscriptionRequest{})
+	if !errors.Is(err, domain.ErrWebhookSubscriptionNotFound) {
+		t.Errorf("expected other tenants to get ErrWebhookSubscriptionNotFound, got %v", err)
+	}
+}
+
+func TestWebhookService_EnqueueEvent(t *testing.T) {
+	subRepo := &MockWebhookSubscriptionRepository{
+		ListActiveForEventFunc: func(ctx context.Context, tenantID string, eventType domain.WebhookEventType) ([]*domain.WebhookSubscription, error) {
+			return []*domain.WebhookSubscription{
+				{ID: "sub-1", TenantID: tenantID},
+				{ID: "sub-2", TenantID: tenantID},
+			}, nil
+		},
+	}
+	deliveryRepo := &MockWebhookDeliveryRepository{
+		CreateFunc: func(ctx context.Context, d *domain.WebhookDelivery) (bool, error) {
+			return d.SubscriptionID == "sub-1", nil // sub-2 already has this event
+		},
+	}
+	svc := NewWebhookService(subRepo, deliveryRepo, &WebhookServiceConfig{MaxAttempts: 3})
+
+	occurredAt := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	created, err := svc.EnqueueEvent(context.Background(), "tenant-1", domain.WebhookEventBookingCancelled, "evt-1", occurredAt,
+		map[string]string{"booking_id": "booking-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created != 1 {
+		t.Errorf("expected 1 new delivery, got %d", created)
+	}
+	if len(deliveryRepo.Created) != 2 {
+		t.Fatalf("expected 2 create calls, got %d", len(deliveryRepo.Created))
+	}
+
+	d := deliveryRepo.Created[0]
+	if d.EventID != "evt-1" || d.MaxAttempts != 3 || d.Status != domain.WebhookDeliveryPending {
+		t.Errorf("unexpected delivery: %+v", d)
+	}
+
+	var payload domain.WebhookPayload
+	if err := json.Unmarshal(d.Payload, &payload); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	if payload.ID != "evt-1" || payload.Type != domain.WebhookEventBookingCancelled || payload.TenantID != "tenant-1" {
+		t.Errorf("unexpected payload: %+v", payload)
+	}
+	if !strings.Contains(string(d.Payload), `"booking_id":"booking-1"`) {
+		t.Errorf("expected event data in payload, got %s", d.Payload)
+	}
+}
+
+func TestWebhookService_EnqueueEvent_NoSubscribers(t *testing.T) {
+	deliveryRepo := &MockWebhookDeliveryRepository{}
+	svc := NewWebhookService(&MockWebhookSubscriptionRepository{}, deliveryRepo, nil)
+
+	created, err := svc.EnqueueEvent(context.Background(), "tenant-1", domain.WebhookEventBookingConfirmed, "evt-1", time.Now(), nil)
+	if err != nil || created != 0 || len(deliveryRepo.Created) != 0 {
+		t.Errorf("expected no deliveries, got %d (err=%v)", created, err)
+	}
+
+	if _, err := svc.EnqueueEvent(context.Background(), "", domain.WebhookEventBookingConfirmed, "evt-1", time.Now(), nil); !errors.Is(err, domain.ErrInvalidTenantID) {
+		t.Errorf("expected ErrInvalidTenantID, got %v", err)
+	}
+}
+
+func TestWebhookService_ListDeliveries(t *testing.T) {
+	deliveryRepo := &MockWebhookDeliveryRepository{
+		ListFunc: func(ctx context.Context, filter *repository.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error) {
+			return []*domain.WebhookDelivery{
+				{ID: "d-1", Status: domain.WebhookDeliveryFailed, Payload: json.RawMessage(`{}`)},
+			}, nil
+		},
+	}
+	svc := NewWebhookService(&MockWebhookSubscriptionRepository{}, deliveryRepo, nil)
+
+	resp, err := svc.ListDeliveries(context.Background(), "tenant-1", &dto.WebhookDeliveryListRequest{
+		SubscriptionID: "sub-1",
+		Status:         "failed",
+		Limit:          10,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	filter := deliveryRepo.Filters[0]
+	if filter.TenantID != "tenant-1" || filter.SubscriptionID != "sub-1" || filter.Status != domain.WebhookDeliveryFailed || filter.Limit != 10 {
+		t.Errorf("unexpected filter: %+v", filter)
+	}
+	if len(resp.Deliveries) != 1 || resp.Deliveries[0].Payload != nil {
+		t.Errorf("expected one delivery without payload, got %+v", resp.Deliveries)
+	}
+
+	_, err = svc.ListDeliveries(context.Background(), "tenant-1", &dto.WebhookDeliveryListRequest{Status: "sent"})
+	if !errors.Is(err, domain.ErrInvalidWebhookStatus) {
+		t.Errorf("expected ErrInvalidWebhookStatus, got %v", err)
+	}
+}
+
+func TestWebhookService_RetryDelivery(t *testing.T) {
+	status := domain.WebhookDeliveryFailed
+	deliveryRepo := &MockWebhookDeliveryRepository{
+		GetByIDFunc: func(ctx context.Context, tenantID, id string) (*domain.WebhookDelivery, error) {
+			return &domain.WebhookDelivery{ID: id, TenantID: tenantID, Status: status, Attempts: 8, MaxAttempts: 8}, nil
+		},
+	}
+	svc := NewWebhookService(&MockWebhookSubscriptionRepository{}, deliveryRepo, nil)
+
+	resp, err := svc.RetryDelivery(context.Background(), "tenant-1", "d-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Status != "pending" || resp.MaxAttempts != 16 || resp.NextAttemptAt == nil {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+	if len(deliveryRepo.Updated) != 1 {
+		t.Errorf("expected delivery to be saved, got %d updates", len(deliveryRepo.Updated))
+	}
+
+	status = domain.WebhookDeliveryDelivered
+	if _, err := svc.RetryDelivery(context.Background(), "tenant-1", "d-1"); !errors.Is(err, domain.ErrWebhookDeliveryNotRetryable) {
+		t.Errorf("expected ErrWebhookDeliveryNotRetryable, got %v", err)
+	}
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// WriteBehindService persists reserved bookings after the reservation has been answered.
+// ReserveSeats queues the booking in Redis; a worker stores it in PostgreSQL and
+// publishes booking.created.
+type WriteBehindService interface {
+	// Enqueue queues a reserved booking for persistence
+	Enqueue(ctx context.Context, booking *domain.Booking) error
+
+	// Persist stores a queued booking and removes it from the queue. It is safe to
+	// call more than once for the same booking.
+	Persist(ctx context.Context, booking *domain.Booking) error
+
+	// ProcessNext persists the next queued booking, waiting up to wait for one.
+	// Returns false if the queue stayed empty.
+	ProcessNext(ctx context.Context, wait time.Duration) (bool, error)
+
+	// RecoverInFlight requeues bookings a stopped worker did not finish
+	RecoverInFlight(ctx context.Context) (int64, error)
+
+	// GetPending retrieves a booking that is not yet persisted, nil if there is none
+	GetPending(ctx context.Context, bookingID string) (*domain.Booking, error)
+
+	// ForgetIdempotencyKey lets a retry reserve again after a reservation was abandoned
+	ForgetIdempotencyKey(ctx context.Context, key, bookingID string) error
+
+	// IdempotencyTTL is how long the reserve script answers retries of an idempotency key
+	IdempotencyTTL() time.Duration
+}
+
+// WriteBehindServiceConfig contains configuration for write-behind service
+type WriteBehindServiceConfig struct {
+	// PendingTTL bounds how long a booking may wait in the queue
+	PendingTTL time.Duration
+	// IdempotencyTTL is how long retries are answered from Redis
+	IdempotencyTTL time.Duration
+}
+
+// writeBehindService implements WriteBehindService
+type writeBehindService struct {
+	bookingRepo     repository.BookingRepository
+	reservationRepo repository.ReservationRepository
+	queue           repository.BookingPersistQueue
+	eventPublisher  EventPublisher
+	pendingTTL      time.Duration
+	idempotencyTTL  time.Duration
+}
+
+// NewWriteBehindService creates a new write-behind service. bookingRepo must be the
+// underlying repository, not one wrapped by NewWriteBehindBookingRepository.
+func NewWriteBehindService(
+	bookingRepo repository.BookingRepository,
+	reservationRepo repository.ReservationRepository,
+	queue repository.BookingPersistQueue,
+	eventPublisher EventPublisher,
+	cfg *WriteBehindServiceConfig,
+) WriteBehindService {
+	s := &writeBehindService{
+		bookingRepo:     bookingRepo,
+		reservationRepo: reservationRepo,
+		queue:           queue,
+		eventPublisher:  eventPublisher,
+		pendingTTL:      24 * time.Hour,
+		idempotencyTTL:  24 * time.Hour,
+	}
+	if cfg != nil {
+		if cfg.PendingTTL > 0 {
+			s.pendingTTL = cfg.PendingTTL
+		}
+		if cfg.IdempotencyTTL > 0 {
+			s.idempotencyTTL = cfg.IdempotencyTTL
+		}
+	}
+	if s.eventPublisher == nil {
+		s.eventPublisher = NewNoOpEventPublisher()
+	}
+	return s
+}
+
+// Enqueue queues a reserved booking for persistence
+func (s *writeBehindService) Enqueue(ctx context.Context, booking *domain.Booking) error {
+	return s.queue.Enqueue(ctx, booking, s.pendingTTL)
+}
+
+// Persist stores a queued booking and removes it from the queue
+func (s *writeBehindService) Persist(ctx context.Context, booking *domain.Booking) error {
+	ctx, span := telemetry.StartSpan(ctx, "service.write_behind.persist")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("booking_id", booking.ID))
+
+	stored := *booking
+	err := s.bookingRepo.Create(ctx, &stored)
+	switch {
+	case err == nil:
+		_ = s.eventPublisher.PublishBookingCreated(ctx, &stored)
+	case errors.Is(err, domain.ErrBookingAlreadyExists):
+		// stored now holds the booking that owns the idempotency key. If that is
+		// another booking, the Redis index had expired and this hold is a duplicate.
+		if stored.ID != booking.ID {
+			span.AddEvent("duplicate_reservation")
+			if _, releaseErr := s.reservationRepo.ReleaseSeats(ctx, booking.ID, booking.UserID); releaseErr != nil {
+				span.RecordError(releaseErr)
+			}
+		}
+	case errors.Is(err, domain.ErrIdempotencyKeyReused):
+		// Another user's or request's booking owns the key, so this hold can't be stored
+		span.AddEvent("idempotency_key_reused")
+		if _, releaseErr := s.reservationRepo.ReleaseSeats(ctx, booking.ID, booking.UserID); releaseErr != nil {
+			span.RecordError(releaseErr)
+		}
+	default:
+		// A redelivered booking may have been stored by an earlier attempt or a read
+		if _, getErr := s.bookingRepo.GetByID(ctx, booking.ID); getErr != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return err
+		}
+	}
+
+	if err := s.queue.Ack(ctx, booking.ID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// ProcessNext persists the next queued booking, waiting up to wait for one
+func (s *writeBehindService) ProcessNext(ctx context.Context, wait time.Duration) (bool, error) {
+	booking, err := s.queue.Dequeue(ctx, wait)
+	if err != nil || booking == nil {
+		return false, err
+	}
+
+	if err := s.Persist(ctx, booking); err != nil {
+		if requeueErr := s.queue.Requeue(ctx, booking.ID); requeueErr != nil {
+			return true, requeueErr
+		}
+		return true, err
+	}
+	return true, nil
+}
+
+// RecoverInFlight requeues bookings a stopped worker did not finish
+func (s *writeBehindService) RecoverInFlight(ctx context.Context) (int64, error) {
+	return s.queue.RecoverInFlight(ctx)
+}
+
+// GetPending retrieves a booking that is not yet persisted, nil if there is none
+func (s *writeBehindService) GetPending(ctx context.Context, bookingID string) (*domain.Booking, error) {
+	return s.queue.GetPending(ctx, bookingID)
+}
+
+// ForgetIdempotencyKey lets a retry reserve again after a reservation was abandoned
+func (s *writeBehindService) ForgetIdempotencyKey(ctx context.Context, key, bookingID string) error {
+	return s.queue.ForgetIdempotencyKey(ctx, key, bookingID)
+}
+
+// IdempotencyTTL is how long the reserve script answers retries of an idempotency key
+func (s *writeBehindService) IdempotencyTTL() time.Duration {
+	return s.idempotencyTTL
+}
+
+// writeBehindBookingRepository serves bookings that are still queued for persistence
+type writeBehindBookingRepository struct {
+	repository.BookingRepository
+	writeBehind WriteBehindService
+}
+
+// NewWriteBehindBookingRepository wraps repo so that reading a booking still in the
+// write-behind queue persists it first. Callers can then update it like any other row.
+func NewWriteBehindBookingRepository(repo repository.BookingRepository, writeBehind WriteBehindService) repository.BookingRepository {
+	return &writeBehindBookingRepository{BookingRepository: repo, writeBehind: writeBehind}
+}
+
+// GetByID retrieves a booking by its ID, persisting it first if it is still queued
+func (r *writeBehindBookingRepository) GetByID(ctx context.Context, id string) (*domain.Booking, error) {
+	booking, err := r.BookingRepository.GetByID(ctx, id)
+	if !errors.Is(err, domain.ErrBookingNotFound) {
+		return booking, err
+	}
+
+	pending, pendingErr := r.writeBehind.GetPending(ctx, id)
+	if pendingErr != nil || pending == nil {
+		return nil, err
+	}
+	if err := r.writeBehind.Persist(ctx, pending); err != nil {
+		return nil, err
+	}
+	return r.BookingRepository.GetByID(ctx, id)
+}
@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+)
+
+// mockPersistQueue is an in-memory BookingPersistQueue
+type mockPersistQueue struct {
+	pending   map[string]*domain.Booking
+	queued    []string
+	inFlight  []string
+	acked     []string
+	requeued  []string
+	forgotten []string
+}
+
+func newMockPersistQueue() *mockPersistQueue {
+	return &mockPersistQueue{pending: make(map[string]*domain.Booking)}
+}
+
+func (q *mockPersistQueue) Enqueue(ctx context.Context, booking *domain.Booking, ttl time.Duration) error {
+	stored := *booking
+	q.pending[booking.ID] = &stored
+	q.queued = append(q.queued, booking.ID)
+	return nil
+}
+
+func (q *mockPersistQueue) Dequeue(ctx context.Context, wait time.Duration) (*domain.Booking, error) {
+	for len(q.queued) > 0 {
+		id := q.queued[0]
+		q.queued = q.queued[1:]
+		if booking, ok := q.pending[id]; ok {
+			q.inFlight = append(q.inFlight, id)
+			return booking, nil
+		}
+	}
+	return nil, nil
+}
+
+func (q *mockPersistQueue) Ack(ctx context.Context, bookingID string) error {
+	delete(q.pending, bookingID)
+	q.acked = append(q.acked, bookingID)
+	return nil
+}
+
+func (q *mockPersistQueue) Requeue(ctx context.Context, bookingID string) error {
+	q.queued = append(q.queued, bookingID)
+	q.requeued = append(q.requeued, bookingID)
+	return nil
+}
+
+func (q *mockPersistQueue) RecoverInFlight(ctx context.Context) (int64, error) {
+	recovered := int64(len(q.inFlight))
+	q.queued = append(q.queued, q.inFlight...)
+	q.inFlight = nil
+	return recovered, nil
+}
+
+func (q *mockPersistQueue) GetPending(ctx context.Context, bookingID string) (*domain.Booking, error) {
+	return q.pending[bookingID], nil
+}
+
+func (q *mockPersistQueue) ForgetIdempotencyKey(ctx context.Context, key, bookingID string) error {
+	q.forgotten = append(q.forgotten, key)
+	return nil
+}
+
+func TestWriteBehindService_ProcessNext(t *testing.T) {
+	var created []string
+	bookingRepo := &MockBookingRepository{
+		CreateFunc: func(ctx context.Context, booking *domain.Booking) error {
+			created = append(created, booking.ID)
+			return nil
+		},
+	}
+	queue := newMockPersistQueue()
+	publisher := NewMockEventPublisher()
+	svc := NewWriteBehindService(bookingRepo, &MockReservationRepository{}, queue, publisher, nil)
+	ctx := context.Background()
+
+	if err := svc.Enqueue(ctx, &domain.Booking{ID: "booking-001", UserID: "user-001"}); err != nil {
+		t.Fatalf("Enqueue() unexpected error = %v", err)
+	}
+
+	processed, err := svc.ProcessNext(ctx, time.Second)
+	if err != nil || !processed {
+		t.Fatalf("ProcessNext() = %v, %v, want true, nil", processed, err)
+	}
+	if len(created) != 1 || created[0] != "booking-001" {
+		t.Errorf("Expected booking-001 to be inserted, got %v", created)
+	}
+	if len(publisher.createdEvents) != 1 {
+		t.Errorf("Expected one booking.created event, got %d", len(publisher.createdEvents))
+	}
+	if len(queue.acked) != 1 {
+		t.Errorf("Expected the booking to be acked, got %v", queue.acked)
+	}
+
+	processed, err = svc.ProcessNext(ctx, time.Second)
+	if err != nil || processed {
+		t.Errorf("ProcessNext() on an empty queue = %v, %v, want false, nil", processed, err)
+	}
+}
+
+func TestWriteBehindService_Persist_DuplicateReleasesHold(t *testing.T) {
+	bookingRepo := &MockBookingRepository{
+		// The idempotency key already belongs to an older booking
+		CreateFunc: func(ctx context.Context, booking *domain.Booking) error {
+			*booking = domain.Booking{ID: "existing-booking-id", IdempotencyKey: booking.IdempotencyKey}
+			return domain.ErrBookingAlreadyExists
+		},
+	}
+	var released string
+	reservationRepo := &MockReservationRepository{
+		ReleaseSeatsFunc: func(ctx context.Context, bookingID, userID string) (*repository.ReleaseResult, error) {
+			released = bookingID
+			return &repository.ReleaseResult{Success: true}, nil
+		},
+	}
+	queue := newMockPersistQueue()
+	publisher := NewMockEventPublisher()
+	svc := NewWriteBehindService(bookingRepo, reservationRepo, queue, publisher, nil)
+
+	err := svc.Persist(context.Background(), &domain.Booking{ID: "booking-001", UserID: "user-001", IdempotencyKey: "key-1"})
+	if err != nil {
+		t.Fatalf("Persist() unexpected error = %v", err)
+	}
+	if released != "booking-001" {
+		t.Errorf("Expected the duplicate hold to be released, got %q", released)
+	}
+	if len(publisher.createdEvents) != 0 {
+		t.Errorf("Expected no booking.created event for a duplicate, got %d", len(publisher.createdEvents))
+	}
+	if len(queue.acked) != 1 {
+		t.Errorf("Expected the duplicate to be acked, got %v", queue.acked)
+	}
+}
+
+func TestWriteBehindService_Persist_Redelivered(t *testing.T) {
+	bookingRepo := &MockBookingRepository{
+		CreateFunc: func(ctx context.Context, booking *domain.Booking) error {
+			return errors.New("duplicate key value violates unique constraint \"bookings_pkey\"")
+		},
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Booking, error) {
+			return &domain.Booking{ID: id}, nil
+		},
+	}
+	queue := newMockPersistQueue()
+	svc := NewWriteBehindService(bookingRepo, &MockReservationRepository{}, queue, nil, nil)
+
+	if err := svc.Persist(context.Background(), &domain.Booking{ID: "booking-001"}); err != nil {
+		t.Fatalf("Persist() unexpected error = %v", err)
+	}
+	if len(queue.acked) != 1 {
+		t.Errorf("Expected an already stored booking to be acked, got %v", queue.acked)
+	}
+}
+
+func TestWriteBehindService_ProcessNext_RequeuesOnFailure(t *testing.T) {
+	dbErr := errors.New("connection refused")
+	bookingRepo := &MockBookingRepository{
+		CreateFunc: func(ctx context.Context, booking *domain.Booking) error {
+			return dbErr
+		},
+	}
+	queue := newMockPersistQueue()
+	svc := NewWriteBehindService(bookingRepo, &MockReservationRepository{}, queue, nil, nil)
+	ctx := context.Background()
+
+	svc.Enqueue(ctx, &domain.Booking{ID: "booking-001"})
+	if _, err := svc.ProcessNext(ctx, time.Second); !errors.Is(err, dbErr) {
+		t.Fatalf("ProcessNext() error = %v, want %v", err, dbErr)
+	}
+	if len(queue.requeued) != 1 || queue.pending["booking-001"] == nil {
+		t.Errorf("Expected the booking to stay pending and be requeued, got %v", queue.requeued)
+	}
+}
+
+func TestWriteBehindBookingRepository_GetByID_PersistsPending(t *testing.T) {
+	stored := make(map[string]*domain.Booking)
+	bookingRepo := &MockBookingRepository{
+		CreateFunc: func(ctx context.Context, booking *domain.Booking) error {
+			stored[booking.ID] = booking
+			return nil
+		},
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Booking, error) {
+			if booking, ok := stored[id]; ok {
+				return booking, nil
+			}
+			return nil, domain.ErrBookingNotFound
+		},
+	}
+	queue := newMockPersistQueue()
+	svc := NewWriteBehindService(bookingRepo, &MockReservationRepository{}, queue, nil, nil)
+	repo := NewWriteBehindBookingRepository(bookingRepo, svc)
+	ctx := context.Background()
+
+	svc.Enqueue(ctx, &domain.Booking{ID: "booking-001", Status: domain.BookingStatusReserved})
+
+	booking, err := repo.GetByID(ctx, "booking-001")
+	if err != nil {
+		t.Fatalf("GetByID() unexpected error = %v", err)
+	}
+	if booking.ID != "booking-001" || stored["booking-001"] == nil {
+		t.Errorf("Expected the pending booking to be persisted and returned, got %+v", booking)
+	}
+
+	if _, err := repo.GetByID(ctx, "booking-002"); !errors.Is(err, domain.ErrBookingNotFound) {
+		t.Errorf("GetByID() error = %v, want %v", err, domain.ErrBookingNotFound)
+	}
+}
+
+func TestBookingService_ReserveSeats_WriteBehind(t *testing.T) {
+	bookingRepo := &MockBookingRepository{
+		GetByIdempotencyKeyFunc: func(ctx context.Context, key string) (*domain.Booking, error) {
+			t.Error("Expected no PostgreSQL idempotency lookup")
+			return nil, domain.ErrBookingNotFound
+		},
+		CreateFunc: func(ctx context.Context, booking *domain.Booking) error {
+			t.Error("Expected no insert while reserving")
+			return nil
+		},
+	}
+	var params repository.ReserveParams
+	reservationRepo := &MockReservationRepository{
+		ReserveSeatsFunc: func(ctx context.Context, p repository.ReserveParams) (*repository.ReserveResult, error) {
+			params = p
+			return &repository.ReserveResult{Success: true, BookingID: "booking-001"}, nil
+		},
+	}
+	queue := newMockPersistQueue()
+	publisher := NewMockEventPublisher()
+	writeBehind := NewWriteBehindService(bookingRepo, reservationRepo, queue, publisher, &WriteBehindServiceConfig{IdempotencyTTL: time.Hour})
+
+	svc := NewBookingService(bookingRepo, reservationRepo, publisher, nil, nil, nil, nil, writeBehind, nil)
+	resp, err := svc.ReserveSeats(context.Background(), "user-001", &dto.ReserveSeatsRequest{
+		EventID:        "event-001",
+		ZoneID:         "zone-001",
+		ShowID:         "show-001",
+		Quantity:       2,
+		IdempotencyKey: "key-1",
+	})
+	if err != nil {
+		t.Fatalf("ReserveSeats() unexpected error = %v", err)
+	}
+	if resp.BookingID != "booking-001" {
+		t.Errorf("ReserveSeats() booking ID = %s, want booking-001", resp.BookingID)
+	}
+	if params.IdempotencyKey != "key-1" || params.IdempotencyTTLSeconds != 3600 || params.ShowID != "show-001" {
+		t.Errorf("Unexpected reserve params: %+v", params)
+	}
+	if queue.pending["booking-001"] == nil {
+		t.Error("Expected the booking to be queued for persistence")
+	}
+	if len(publisher.createdEvents) != 0 {
+		t.Errorf("Expected booking.created to wait for persistence, got %d events", len(publisher.createdEvents))
+	}
+}
+
+func TestBookingService_ReserveSeats_WriteBehindReplay(t *testing.T) {
+	existing := &domain.Booking{
+		ID:         "existing-booking-id",
+		TenantID:   "test-tenant-id",
+		UserID:     "user-001",
+		EventID:    "event-001",
+		ShowID:     "show-001",
+		ZoneID:     "zone-001",
+		Quantity:   2,
+		Status:     domain.BookingStatusReserved,
+		TotalPrice: 200.00,
+		ExpiresAt:  time.Now().Add(10 * time.Minute),
+	}
+	bookingRepo := &MockBookingRepository{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Booking, error) {
+			if id == existing.ID {
+				return existing, nil
+			}
+			return nil, domain.ErrBookingNotFound
+		},
+	}
+	reservationRepo := &MockReservationRepository{
+		ReserveSeatsFunc: func(ctx context.Context, params repository.ReserveParams) (*repository.ReserveResult, error) {
+			return &repository.ReserveResult{Replayed: true, BookingID: existing.ID}, nil
+		},
+	}
+	queue := newMockPersistQueue()
+	writeBehind := NewWriteBehindService(bookingRepo, reservationRepo, queue, nil, nil)
+
+	svc := NewBookingService(bookingRepo, reservationRepo, nil, nil, nil, nil, nil, writeBehind, nil)
+	resp, err := svc.ReserveSeats(context.Background(), "user-001", &dto.ReserveSeatsRequest{
+		EventID:        "event-001",
+		ZoneID:         "zone-001",
+		ShowID:         "show-001",
+		Quantity:       2,
+		IdempotencyKey: "key-1",
+	})
+	if err != nil {
+		t.Fatalf("ReserveSeats() unexpected error = %v", err)
+	}
+	if resp.BookingID != existing.ID {
+		t.Errorf("ReserveSeats() booking ID = %s, want existing %s", resp.BookingID, existing.ID)
+	}
+	if len(queue.queued) != 0 {
+		t.Errorf("Expected a replay to queue nothing, got %v", queue.queued)
+	}
+
+	// The Redis index is keyed by idempotency key alone; another user's booking is not replayed
+	if _, err := svc.ReserveSeats(context.Background(), "user-002", &dto.ReserveSeatsRequest{
+		EventID:        "event-001",
+		ZoneID:         "zone-001",
+		ShowID:         "show-001",
+		Quantity:       2,
+		IdempotencyKey: "key-1",
+	}); !errors.Is(err, domain.ErrIdempotencyKeyReused) {
+		t.Errorf("ReserveSeats() by another user error = %v, want %v", err, domain.ErrIdempotencyKeyReused)
+	}
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/cache"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+)
+
+// Zone catalog cache names, shared by every booking-service instance and the inventory worker
+const (
+	ZoneCacheName       = "zone"
+	ShowZonesCacheName  = "show_zones"
+	PriceTiersCacheName = "zone_price_tiers"
+	AddOnsCacheName     = "event_add_ons"
+)
+
+// ZoneCatalogConfig contains configuration for the zone catalog cache
+type ZoneCatalogConfig struct {
+	// Redis is the tier shared between instances (nil = per-instance cache only)
+	Redis *redis.Client
+	// LocalSize caps the zones and show zone lists held per instance
+	LocalSize int
+	// LocalTTL bounds how long an instance serves a zone without checking Redis
+	LocalTTL time.Duration
+	// RedisTTL bounds how long price and ordering changes take to show up when
+	// ticket-service's zone events are not delivered
+	RedisTTL time.Duration
+	// PriceTiers fetches zone price schedules (nil = zones sell at their base price)
+	PriceTiers PriceTierFetcher
+	// AddOns fetches event add-ons (nil = events sell no add-ons). Add-on changes
+	// show up within RedisTTL.
+	AddOns AddOnFetcher
+}
+
+// ZoneCatalog caches zone metadata and prices from ticket service in front of
+// the fetcher. Availability counts in the cached zones are stale by design;
+// live availability comes from the reservation repository.
+type ZoneCatalog struct {
+	zones *cache.Cache[*ZoneInfo]
+	shows *cache.Cache[[]*ZoneInfo]
+	tiers *cache.Cache[[]*PriceTier]
+	// addOns is keyed by event ID
+	addOns *cache.Cache[[]*EventAddOn]
+}
+
+// NewZoneCatalog creates a new zone catalog; call Run to receive invalidations from other instances
+func NewZoneCatalog(fetcher ZoneFetcher, lister ShowZoneLister, cfg *ZoneCatalogConfig) *ZoneCatalog {
+	if cfg == nil {
+		cfg = &ZoneCatalogConfig{}
+	}
+	catalog := &ZoneCatalog{
+		zones: cache.New(&cache.Config{
+			Name:      ZoneCacheName,
+			Redis:     cfg.Redis,
+			LocalSize: cfg.LocalSize,
+			LocalTTL:  cfg.LocalTTL,
+			RedisTTL:  cfg.RedisTTL,
+		}, fetcher.FetchZone),
+		shows: cache.New(&cache.Config{
+			Name:      ShowZonesCacheName,
+			Redis:     cfg.Redis,
+			LocalSize: cfg.LocalSize,
+			LocalTTL:  cfg.LocalTTL,
+			RedisTTL:  cfg.RedisTTL,
+		}, lister.ListShowZones),
+	}
+	if cfg.PriceTiers != nil {
+		catalog.tiers = cache.New(&cache.Config{
+			Name:      PriceTiersCacheName,
+			Redis:     cfg.Redis,
+			LocalSize: cfg.LocalSize,
+			LocalTTL:  cfg.LocalTTL,
+			RedisTTL:  cfg.RedisTTL,
+		}, cfg.PriceTiers.FetchPriceTiers)
+	}
+	if cfg.AddOns != nil {
+		catalog.addOns = cache.New(&cache.Config{
+			Name:      AddOnsCacheName,
+			Redis:     cfg.Redis,
+			LocalSize: cfg.LocalSize,
+			LocalTTL:  cfg.LocalTTL,
+			RedisTTL:  cfg.RedisTTL,
+		}, cfg.AddOns.FetchAddOns)
+	}
+	return catalog
+}
+
+// FetchZone returns a zone's metadata and price
+func (c *ZoneCatalog) FetchZone(ctx context.Context, zoneID string) (*ZoneInfo, error) {
+	return c.zones.Get(ctx, zoneID)
+}
+
+// ListShowZones returns the active zones of a show
+func (c *ZoneCatalog) ListShowZones(ctx context.Context, showID string) ([]*ZoneInfo, error) {
+	return c.shows.Get(ctx, showID)
+}
+
+// FetchPriceTiers returns a zone's price schedule, empty when the catalog has no tier fetcher
+func (c *ZoneCatalog) FetchPriceTiers(ctx context.Context, zoneID string) ([]*PriceTier, error) {
+	if c.tiers == nil {
+		return nil, nil
+	}
+	return c.tiers.Get(ctx, zoneID)
+}
+
+// FetchAddOns returns an event's add-ons, empty when the catalog has no add-on fetcher
+func (c *ZoneCatalog) FetchAddOns(ctx context.Context, eventID string) ([]*EventAddOn, error) {
+	if c.addOns == nil {
+		return nil, nil
+	}
+	return c.addOns.Get(ctx, eventID)
+}
+
+// Invalidate drops a zone, its price schedule and its show's zone list on every instance
+func (c *ZoneCatalog) Invalidate(ctx context.Context, zoneID, showID string) error {
+	if err := c.zones.Invalidate(ctx, zoneID); err != nil {
+		return err
+	}
+	if c.tiers != nil {
+		if err := c.tiers.Invalidate(ctx, zoneID); err != nil {
+			return err
+		}
+	}
+	if showID == "" {
+		return nil
+	}
+	return c.shows.Invalidate(ctx, showID)
+}
+
+// Run receives invalidations until ctx is done
+func (c *ZoneCatalog) Run(ctx context.Context) {
+	go c.shows.Run(ctx)
+	if c.tiers != nil {
+		go c.tiers.Run(ctx)
+	}
+	if c.addOns != nil {
+		go c.addOns.Run(ctx)
+	}
+	c.zones.Run(ctx)
+}
+
+// InvalidateZoneCatalog drops a zone, its price schedule and its show's zone list from
+// the catalog of every booking-service instance, for writers that do not run a catalog
+func InvalidateZoneCatalog(ctx context.Context, client *redis.Client, zoneID, showID string) error {
+	if err := cache.Invalidate(ctx, client, ZoneCacheName, zoneID); err != nil {
+		return err
+	}
+	if err := cache.Invalidate(ctx, client, PriceTiersCacheName, zoneID); err != nil {
+		return err
+	}
+	if showID == "" {
+		return nil
+	}
+	return cache.Invalidate(ctx, client, ShowZonesCacheName, showID)
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+)
+
+// ZonePricer resolves the price tier a reservation sells at from the zone's cached
+// price schedule and counts the reservation's seats against the tier's allocation,
+// so an early-bird tier stops selling once its seats are gone even inside its window
+type ZonePricer struct {
+	schedules PriceTierFetcher
+	tierRepo  repository.PriceTierRepository
+	now       func() time.Time
+}
+
+// NewZonePricer creates a new zone pricer reading schedules through the zone catalog
+func NewZonePricer(schedules PriceTierFetcher, tierRepo repository.PriceTierRepository) *ZonePricer {
+	return &ZonePricer{
+		schedules: schedules,
+		tierRepo:  tierRepo,
+		now:       time.Now,
+	}
+}
+
+// Claim returns the tier a reservation's seats are sold at: the first tier, in
+// schedule order, whose window is open and whose allocation has room for quantity.
+// Nil means the zone has no such tier and sells at its base price.
+func (p *ZonePricer) Claim(ctx context.Context, zoneID, bookingID string, quantity int) (*PriceTier, error) {
+	tiers, err := p.schedules.FetchPriceTiers(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := p.now()
+	active := make(map[string]*PriceTier, len(tiers))
+	allocations := make([]repository.PriceTierAllocation, 0, len(tiers))
+	for _, tier := range tiers {
+		if !tier.IsActiveAt(now) {
+			continue
+		}
+		active[tier.ID] = tier
+		allocations = append(allocations, repository.PriceTierAllocation{TierID: tier.ID, Allocation: tier.Allocation})
+	}
+	if len(allocations) == 0 {
+		return nil, nil
+	}
+
+	tierID, err := p.tierRepo.ClaimTier(ctx, zoneID, bookingID, quantity, allocations)
+	if err != nil {
+		return nil, err
+	}
+	return active[tierID], nil
+}
+
+// Release gives back a claim whose reservation was never made
+func (p *ZonePricer) Release(ctx context.Context, zoneID, bookingID string) error {
+	return p.tierRepo.ReleaseTier(ctx, zoneID, bookingID)
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+)
+
+// stubPriceSchedule serves fixed price tiers per zone
+type stubPriceSchedule map[string][]*PriceTier
+
+func (s stubPriceSchedule) FetchPriceTiers(ctx context.Context, zoneID string) ([]*PriceTier, error) {
+	return s[zoneID], nil
+}
+
+// memoryPriceTierRepository counts tier claims in memory the way the Lua scripts do
+type memoryPriceTierRepository struct {
+	sold   map[string]int
+	claims map[string]string
+	qty    map[string]int
+}
+
+func newMemoryPriceTierRepository() *memoryPriceTierRepository {
+	return &memoryPriceTierRepository{sold: map[string]int{}, claims: map[string]string{}, qty: map[string]int{}}
+}
+
+func (r *memoryPriceTierRepository) ClaimTier(ctx context.Context, zoneID, bookingID string, quantity int, tiers []repository.PriceTierAllocation) (string, error) {
+	if tierID, ok := r.claims[bookingID]; ok {
+		return tierID, nil
+	}
+	for _, tier := range tiers {
+		if tier.Allocation == 0 || r.sold[tier.TierID]+quantity <= tier.Allocation {
+			r.sold[tier.TierID] += quantity
+			r.claims[bookingID] = tier.TierID
+			r.qty[bookingID] = quantity
+			return tier.TierID, nil
+		}
+	}
+	return "", nil
+}
+
+func (r *memoryPriceTierRepository) ReleaseTier(ctx context.Context, zoneID, bookingID string) error {
+	if tierID, ok := r.claims[bookingID]; ok {
+		r.sold[tierID] -= r.qty[bookingID]
+		delete(r.claims, bookingID)
+	}
+	return nil
+}
+
+func TestZonePricer_Claim(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	past, future := now.Add(-time.Hour), now.Add(time.Hour)
+	schedule := stubPriceSchedule{
+		"zone-001": {
+			{ID: "ended", Name: "Pre-sale", Price: 1500, ValidUntil: &past},
+			{ID: "early", Name: "Early Bird", Price: 2000, Allocation: 3, ValidUntil: &future},
+			{ID: "regular", Name: "Regular", Price: 2500},
+			{ID: "last", Name: "Last Minute", Price: 3000, ValidFrom: &future},
+		},
+		"zone-002": {{ID: "late", Name: "Last Minute", Price: 3000, ValidFrom: &future}},
+	}
+	tierRepo := newMemoryPriceTierRepository()
+	pricer := NewZonePricer(schedule, tierRepo)
+	pricer.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	steps := []struct {
+		bookingID string
+		quantity  int
+		want      string
+	}{
+		{"booking-1", 2, "early"},
+		{"booking-2", 2, "regular"}, // Only one early-bird seat left
+		{"booking-3", 1, "early"},
+		{"booking-1", 2, "early"}, // A retried claim keeps its tier
+	}
+	for _, step := range steps {
+		tier, err := pricer.Claim(ctx, "zone-001", step.bookingID, step.quantity)
+		if err != nil {
+			t.Fatalf("Claim(%s) error = %v", step.bookingID, err)
+		}
+		if tier == nil || tier.ID != step.want {
+			t.Fatalf("Claim(%s) = %+v, want tier %s", step.bookingID, tier, step.want)
+		}
+	}
+
+	// Released seats sell at the early-bird price again
+	if err := pricer.Release(ctx, "zone-001", "booking-1"); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if tier, _ := pricer.Claim(ctx, "zone-001", "booking-4", 2); tier == nil || tier.ID != "early" {
+		t.Errorf("Claim() after release = %+v, want tier early", tier)
+	}
+
+	// A zone without an open tier sells at its base price
+	if tier, err := pricer.Claim(ctx, "zone-002", "booking-5", 1); err != nil || tier != nil {
+		t.Errorf("Claim() with no open tier = %+v, %v, want nil", tier, err)
+	}
+}
+
+func TestBookingService_ReserveSeats_PriceTier(t *testing.T) {
+	catalog := &stubZoneCatalog{zones: []*ZoneInfo{{ID: "zone-001", ShowID: "show-001", Price: 2500}}}
+	schedule := stubPriceSchedule{"zone-001": {
+		{ID: "early", Name: "Early Bird", Price: 2000, Allocation: 2},
+		{ID: "regular", Name: "Regular", Price: 2500},
+	}}
+	tierRepo := newMemoryPriceTierRepository()
+
+	soldOut := false
+	reservationRepo := &MockReservationRepository{
+		ReserveSeatsFunc: func(ctx context.Context, params repository.ReserveParams) (*repository.ReserveResult, error) {
+			if soldOut {
+				return &repository.ReserveResult{Success: false, ErrorCode: "INSUFFICIENT_STOCK"}, nil
+			}
+			if _, ok := tierRepo.claims[params.BookingID]; !ok {
+				t.Errorf("reserved %s without a tier claim under its booking ID", params.BookingID)
+			}
+			return &repository.ReserveResult{Success: true, BookingID: params.BookingID}, nil
+		},
+	}
+	svc := NewBookingService(&MockBookingRepository{}, reservationRepo, nil, nil, nil, nil, nil, nil, &BookingServiceConfig{
+		ZonePrices: catalog,
+		PriceTiers: NewZonePricer(schedule, tierRepo),
+	})
+	reserve := func() (*dto.ReserveSeatsResponse, error) {
+		return svc.ReserveSeats(context.Background(), "user-001", &dto.ReserveSeatsRequest{
+			EventID:  "event-001",
+			ZoneID:   "zone-001",
+			ShowID:   "show-001",
+			Quantity: 2,
+		})
+	}
+
+	// A failed reservation gives its early-bird seats back
+	soldOut = true
+	if _, err := reserve(); err == nil {
+		t.Fatal("ReserveSeats() expected an error for a sold-out zone")
+	}
+	if tierRepo.sold["early"] != 0 {
+		t.Errorf("early-bird seats sold after a failed reservation = %d, want 0", tierRepo.sold["early"])
+	}
+
+	soldOut = false
+	for _, want := range []struct {
+		tier  string
+		total float64
+	}{{"Early Bird", 4000}, {"Regular", 5000}} {
+		resp, err := reserve()
+		if err != nil {
+			t.Fatalf("ReserveSeats() unexpected error = %v", err)
+		}
+		if resp.PriceTier != want.tier || resp.TotalPrice != want.total {
+			t.Errorf("ReserveSeats() = %s at %v, want %s at %v", resp.PriceTier, resp.TotalPrice, want.tier, want.total)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// ZoneShardService manages counter sharding of hot zones. Reservations spread across a
+// sharded zone's counters and move seats between them when one runs short;
+// modifications and standby offers draw on the primary counter only.
+type ZoneShardService interface {
+	// GetZoneShards returns how a zone's availability is split
+	GetZoneShards(ctx context.Context, zoneID string) (*dto.ZoneShardsResponse, error)
+
+	// SetZoneShards splits a zone's availability evenly across the requested number of
+	// counters. Other instances pick up the new layout within a few seconds.
+	SetZoneShards(ctx context.Context, zoneID string, req *dto.SetZoneShardsRequest) (*dto.ZoneShardsResponse, error)
+
+	// ResetZoneAvailability overwrites a zone's availability, e.g. when syncing from ticket-service
+	ResetZoneAvailability(ctx context.Context, zoneID string, seats int64) error
+}
+
+// zoneShardService implements ZoneShardService
+type zoneShardService struct {
+	shardRepo repository.ZoneShardRepository
+}
+
+// NewZoneShardService creates a new zone shard service
+func NewZoneShardService(shardRepo repository.ZoneShardRepository) ZoneShardService {
+	return &zoneShardService{shardRepo: shardRepo}
+}
+
+// GetZoneShards returns how a zone's availability is split
+func (s *zoneShardService) GetZoneShards(ctx context.Context, zoneID string) (*dto.ZoneShardsResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.zone_shard.get")
+	defer span.End()
+
+	if zoneID == "" {
+		span.SetStatus(codes.Error, "invalid zone_id")
+		return nil, domain.ErrInvalidZoneID
+	}
+
+	span.SetAttributes(attribute.String("zone_id", zoneID))
+
+	layout, err := s.shardRepo.GetLayout(ctx, zoneID)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("shards", layout.Shards))
+	span.SetStatus(codes.Ok, "")
+	return dto.ZoneShardsFromDomain(layout), nil
+}
+
+// SetZoneShards splits a zone's availability evenly across the requested number of counters
+func (s *zoneShardService) SetZoneShards(ctx context.Context, zoneID string, req *dto.SetZoneShardsRequest) (*dto.ZoneShardsResponse, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.zone_shard.set")
+	defer span.End()
+
+	if zoneID == "" {
+		span.SetStatus(codes.Error, "invalid zone_id")
+		return nil, domain.ErrInvalidZoneID
+	}
+	if req == nil || req.Shards < 1 || req.Shards > domain.MaxZoneShards {
+		span.SetStatus(codes.Error, "invalid shard count")
+		return nil, domain.ErrInvalidShardCount
+	}
+
+	span.SetAttributes(
+		attribute.String("zone_id", zoneID),
+		attribute.Int("shards", req.Shards),
+	)
+
+	layout, err := s.shardRepo.Reshard(ctx, zoneID, req.Shards)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int64("available_seats", layout.Total))
+	span.SetStatus(codes.Ok, "")
+	return dto.ZoneShardsFromDomain(layout), nil
+}
+
+// ResetZoneAvailability overwrites a zone's availability
+func (s *zoneShardService) ResetZoneAvailability(ctx context.Context, zoneID string, seats int64) error {
+	return s.shardRepo.ResetAvailability(ctx, zoneID, seats)
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dto"
+)
+
+// MockZoneShardRepository is a mock implementation of ZoneShardRepository
+type MockZoneShardRepository struct {
+	Layouts map[string]*domain.ZoneShardLayout
+}
+
+func (m *MockZoneShardRepository) GetLayout(ctx context.Context, zoneID string) (*domain.ZoneShardLayout, error) {
+	layout, ok := m.Layouts[zoneID]
+	if !ok {
+		return nil, domain.ErrZoneNotFound
+	}
+	return layout, nil
+}
+
+func (m *MockZoneShardRepository) Reshard(ctx context.Context, zoneID string, shards int) (*domain.ZoneShardLayout, error) {
+	layout, err := m.GetLayout(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+	layout.Shards = shards
+	layout.Available = make([]int64, shards)
+	for i := range layout.Available {
+		layout.Available[i] = layout.Total / int64(shards)
+	}
+	layout.Available[0] += layout.Total % int64(shards)
+	return layout, nil
+}
+
+func (m *MockZoneShardRepository) ResetAvailability(ctx context.Context, zoneID string, seats int64) error {
+	m.Layouts[zoneID] = &domain.ZoneShardLayout{ZoneID: zoneID, Shards: 1, Available: []int64{seats}, Total: seats}
+	return nil
+}
+
+func TestZoneShardService_SetZoneShards(t *testing.T) {
+	repo := &MockZoneShardRepository{Layouts: map[string]*domain.ZoneShardLayout{}}
+	svc := NewZoneShardService(repo)
+	ctx := context.Background()
+
+	if err := svc.ResetZoneAvailability(ctx, "zone-a", 100); err != nil {
+		t.Fatalf("ResetZoneAvailability() error = %v", err)
+	}
+
+	resp, err := svc.SetZoneShards(ctx, "zone-a", &dto.SetZoneShardsRequest{Shards: 8})
+	if err != nil {
+		t.Fatalf("SetZoneShards() error = %v", err)
+	}
+	if resp.Shards != 8 || resp.AvailableSeats != 100 || len(resp.ShardSeats) != 8 {
+		t.Errorf("SetZoneShards() = %+v, want 100 seats over 8 shards", resp)
+	}
+
+	tests := []struct {
+		name    string
+		zoneID  string
+		shards  int
+		wantErr error
+	}{
+		{name: "zero shards", zoneID: "zone-a", shards: 0, wantErr: domain.ErrInvalidShardCount},
+		{name: "too many shards", zoneID: "zone-a", shards: domain.MaxZoneShards + 1, wantErr: domain.ErrInvalidShardCount},
+		{name: "missing zone id", zoneID: "", shards: 4, wantErr: domain.ErrInvalidZoneID},
+		{name: "zone not live", zoneID: "zone-b", shards: 4, wantErr: domain.ErrZoneNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := svc.SetZoneShards(ctx, tt.zoneID, &dto.SetZoneShardsRequest{Shards: tt.shards}); !errors.Is(err, tt.wantErr) {
+				t.Errorf("SetZoneShards() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ type ZoneInfo struct {
 	TotalSeats     int64   `json:"total_seats"`
 	AvailableSeats int64   `json:"available_seats"`
 	IsActive       bool    `json:"is_active"`
+	SortOrder      int     `json:"sort_order"`
 }
 
 // ZoneFetcher fetches zone data from ticket service
@@ -29,6 +30,56 @@ type ZoneFetcher interface {
 	FetchZone(ctx context.Context, zoneID string) (*ZoneInfo, error)
 }
 
+// ShowZoneLister lists the zones of a show from ticket service
+type ShowZoneLister interface {
+	// ListShowZones lists the active zones of a show
+	ListShowZones(ctx context.Context, showID string) ([]*ZoneInfo, error)
+}
+
+// PriceTier is a price a zone sells at during a validity window, for a limited
+// number of seats, as scheduled in ticket service
+type PriceTier struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Price      float64    `json:"price"`
+	Allocation int        `json:"allocation"` // Seats sold at this tier (0 = no limit)
+	ValidFrom  *time.Time `json:"valid_from,omitempty"`
+	ValidUntil *time.Time `json:"valid_until,omitempty"`
+	SortOrder  int        `json:"sort_order"`
+}
+
+// IsActiveAt reports whether the tier's validity window contains at
+func (t *PriceTier) IsActiveAt(at time.Time) bool {
+	if t.ValidFrom != nil && at.Before(*t.ValidFrom) {
+		return false
+	}
+	return t.ValidUntil == nil || at.Before(*t.ValidUntil)
+}
+
+// PriceTierFetcher fetches zone price schedules from ticket service
+type PriceTierFetcher interface {
+	// FetchPriceTiers fetches a zone's price tiers in the order they are tried
+	FetchPriceTiers(ctx context.Context, zoneID string) ([]*PriceTier, error)
+}
+
+// EventAddOn is an add-on item (parking, merchandise) sold with an event's tickets,
+// as defined in ticket service
+type EventAddOn struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	Category      string  `json:"category"`
+	Price         float64 `json:"price"`
+	Inventory     int     `json:"inventory"`       // Units for sale (0 = no limit)
+	MaxPerBooking int     `json:"max_per_booking"` // Units per booking (0 = no limit)
+	IsActive      bool    `json:"is_active"`
+}
+
+// AddOnFetcher fetches event add-ons from ticket service
+type AddOnFetcher interface {
+	// FetchAddOns fetches an event's add-ons, inactive ones included
+	FetchAddOns(ctx context.Context, eventID string) ([]*EventAddOn, error)
+}
+
 // ZoneSyncer handles syncing zone data to Redis with single-flight pattern
 type ZoneSyncer interface {
 	// SyncZone syncs zone availability to Redis (uses single-flight)
@@ -93,6 +144,122 @@ func (f *HTTPZoneFetcher) FetchZone(ctx context.Context, zoneID string) (*ZoneIn
 	return &response.Data, nil
 }
 
+// ListShowZones lists the active zones of a show from ticket service via HTTP
+func (f *HTTPZoneFetcher) ListShowZones(ctx context.Context, showID string) ([]*ZoneInfo, error) {
+	url := fmt.Sprintf("%s/api/v1/shows/%s/zones?is_active=true&limit=100", f.baseURL, showID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list zones: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	// Parse response - backend returns { success: true, data: [ZoneInfo], meta: {...} }
+	var response struct {
+		Success bool        `json:"success"`
+		Data    []*ZoneInfo `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if !response.Success {
+		return nil, fmt.Errorf("API returned unsuccessful response")
+	}
+
+	return response.Data, nil
+}
+
+// FetchPriceTiers fetches a zone's price schedule from ticket service via HTTP
+func (f *HTTPZoneFetcher) FetchPriceTiers(ctx context.Context, zoneID string) ([]*PriceTier, error) {
+	url := fmt.Sprintf("%s/api/v1/zones/%s/price-tiers", f.baseURL, zoneID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch price tiers: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("zone not found: %s", zoneID)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	// Parse response - backend returns { success: true, data: [PriceTier] }
+	var response struct {
+		Success bool         `json:"success"`
+		Data    []*PriceTier `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if !response.Success {
+		return nil, fmt.Errorf("API returned unsuccessful response")
+	}
+
+	return response.Data, nil
+}
+
+// FetchAddOns fetches an event's add-ons from ticket service via HTTP
+func (f *HTTPZoneFetcher) FetchAddOns(ctx context.Context, eventID string) ([]*EventAddOn, error) {
+	url := fmt.Sprintf("%s/api/v1/events/%s/add-ons", f.baseURL, eventID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch add-ons: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("event not found: %s", eventID)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	// Parse response - backend returns { success: true, data: [EventAddOn] }
+	var response struct {
+		Success bool          `json:"success"`
+		Data    []*EventAddOn `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if !response.Success {
+		return nil, fmt.Errorf("API returned unsuccessful response")
+	}
+
+	return response.Data, nil
+}
+
 // DefaultZoneSyncer implements ZoneSyncer with single-flight pattern
 type DefaultZoneSyncer struct {
 	fetcher         ZoneFetcher
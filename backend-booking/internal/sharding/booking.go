@@ -0,0 +1,256 @@
+// Package sharding spreads bookings over per-tenant Postgres shards. New bookings are
+// written to their tenant's shard. Lookups by booking ID or idempotency key start with
+// the shard of the tenant in the context (database.WithTenant) and fall through to the
+// others, so workers and cross-tenant callers need no tenant. Listings fan out to every
+// shard and merge.
+package sharding
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
+)
+
+// ShardBookingRepository is the booking repository of one shard
+type ShardBookingRepository interface {
+	repository.BookingRepository
+	repository.BookingBatchRepository
+	repository.IdleReservationFinder
+}
+
+// BookingRepository routes booking reads and writes to the shards of a resolver
+type BookingRepository struct {
+	resolver *database.ShardResolver
+	repos    map[string]ShardBookingRepository
+}
+
+// NewBookingRepository creates a BookingRepository with a repository per shard of resolver
+func NewBookingRepository(resolver *database.ShardResolver, open func(db *database.PostgresDB) ShardBookingRepository) *BookingRepository {
+	repos := make(map[string]ShardBookingRepository)
+	for _, name := range resolver.Shards() {
+		db, _ := resolver.Shard(name)
+		repos[name] = open(db)
+	}
+	return &BookingRepository{resolver: resolver, repos: repos}
+}
+
+// NewPostgresBookingRepository creates a BookingRepository over Postgres booking repositories
+func NewPostgresBookingRepository(resolver *database.ShardResolver) *BookingRepository {
+	return NewBookingRepository(resolver, func(db *database.PostgresDB) ShardBookingRepository {
+		return repository.NewPostgresBookingRepository(db.Pool())
+	})
+}
+
+// tenantRepo returns the repository of the shard of a tenant
+func (r *BookingRepository) tenantRepo(tenantID string) ShardBookingRepository {
+	return r.repos[r.resolver.ShardOf(tenantID)]
+}
+
+// find calls fn on the shards in lookup order until one has the booking
+func (r *BookingRepository) find(ctx context.Context, fn func(repo ShardBookingRepository) error) error {
+	for _, name := range r.resolver.LookupOrder(ctx) {
+		if err := fn(r.repos[name]); !errors.Is(err, domain.ErrBookingNotFound) {
+			return err
+		}
+	}
+	return domain.ErrBookingNotFound
+}
+
+// Create creates a booking on its tenant's shard
+func (r *BookingRepository) Create(ctx context.Context, booking *domain.Booking) error {
+	return r.tenantRepo(booking.TenantID).Create(ctx, booking)
+}
+
+// GetByID retrieves a booking from whichever shard has it
+func (r *BookingRepository) GetByID(ctx context.Context, id string) (*domain.Booking, error) {
+	var booking *domain.Booking
+	err := r.find(ctx, func(repo ShardBookingRepository) error {
+		var err error
+		booking, err = repo.GetByID(ctx, id)
+		return err
+	})
+	return booking, err
+}
+
+// GetByUserID retrieves a page of a user's bookings across shards, newest first
+func (r *BookingRepository) GetByUserID(ctx context.Context, userID string, page pagination.Params) ([]*domain.Booking, error) {
+	if len(r.repos) == 1 {
+		return r.repos[database.DefaultShard].GetByUserID(ctx, userID, page)
+	}
+
+	pages := make([][]*domain.Booking, 0, len(r.repos))
+	for _, name := range r.resolver.Shards() {
+		bookings, err := r.repos[name].GetByUserID(ctx, userID, page.Spread())
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, bookings)
+	}
+	return pagination.Merge(pages, page, func(b *domain.Booking) pagination.Cursor {
+		return pagination.Cursor{Time: b.CreatedAt, ID: b.ID}
+	}), nil
+}
+
+// Update updates a booking on its tenant's shard
+func (r *BookingRepository) Update(ctx context.Context, booking *domain.Booking) error {
+	return r.tenantRepo(booking.TenantID).Update(ctx, booking)
+}
+
+// UpdateStatus updates the status of a booking on whichever shard has it
+func (r *BookingRepository) UpdateStatus(ctx context.Context, id string, status domain.BookingStatus) error {
+	return r.find(ctx, func(repo ShardBookingRepository) error {
+		return repo.UpdateStatus(ctx, id, status)
+	})
+}
+
+// Delete deletes a booking from whichever shard has it
+func (r *BookingRepository) Delete(ctx context.Context, id string) error {
+	return r.find(ctx, func(repo ShardBookingRepository) error {
+		return repo.Delete(ctx, id)
+	})
+}
+
+// Confirm confirms a booking on whichever shard has it
+func (r *BookingRepository) Confirm(ctx context.Context, id, paymentID string) error {
+	return r.find(ctx, func(repo ShardBookingRepository) error {
+		return repo.Confirm(ctx, id, paymentID)
+	})
+}
+
+// Cancel cancels a booking on whichever shard has it
+func (r *BookingRepository) Cancel(ctx context.Context, id string) error {
+	return r.find(ctx, func(repo ShardBookingRepository) error {
+		return repo.Cancel(ctx, id)
+	})
+}
+
+// GetExpiredReservations gets up to limit expired reservations across shards, the
+// longest expired first
+func (r *BookingRepository) GetExpiredReservations(ctx context.Context, limit int) ([]*domain.Booking, error) {
+	var expired []*domain.Booking
+	for _, name := range r.resolver.Shards() {
+		bookings, err := r.repos[name].GetExpiredReservations(ctx, limit)
+		if err != nil {
+			return nil, err
+		}
+		expired = append(expired, bookings...)
+	}
+
+	if len(expired) > limit {
+		sort.SliceStable(expired, func(i, j int) bool { return expired[i].ExpiresAt.Before(expired[j].ExpiresAt) })
+		expired = expired[:limit]
+	}
+	return expired, nil
+}
+
+// GetIdleReservations gets up to limit idle reservations across shards, the oldest first
+func (r *BookingRepository) GetIdleReservations(ctx context.Context, reservedAfter, reservedBefore time.Time, limit int) ([]*domain.Booking, error) {
+	var idle []*domain.Booking
+	for _, name := range r.resolver.Shards() {
+		bookings, err := r.repos[name].GetIdleReservations(ctx, reservedAfter, reservedBefore, limit)
+		if err != nil {
+			return nil, err
+		}
+		idle = append(idle, bookings...)
+	}
+
+	sort.SliceStable(idle, func(i, j int) bool { return idle[i].ReservedAt.Before(idle[j].ReservedAt) })
+	if len(idle) > limit {
+		idle = idle[:limit]
+	}
+	return idle, nil
+}
+
+// MarkAsExpired marks a booking expired on whichever shard has it
+func (r *BookingRepository) MarkAsExpired(ctx context.Context, id string) error {
+	return r.find(ctx, func(repo ShardBookingRepository) error {
+		return repo.MarkAsExpired(ctx, id)
+	})
+}
+
+// ExtendReservation extends a reservation on every shard; only the one holding the
+// booking has a row to update
+func (r *BookingRepository) ExtendReservation(ctx context.Context, id string, expiresAt time.Time) error {
+	for _, name := range r.resolver.LookupOrder(ctx) {
+		if err := r.repos[name].ExtendReservation(ctx, id, expiresAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AwaitPayment hands a booking off to an external payment on whichever shard has it
+func (r *BookingRepository) AwaitPayment(ctx context.Context, id, paymentID string, expiresAt time.Time) error {
+	return r.find(ctx, func(repo ShardBookingRepository) error {
+		return repo.AwaitPayment(ctx, id, paymentID, expiresAt)
+	})
+}
+
+// GetByIdempotencyKey retrieves a booking by idempotency key from whichever shard has it,
+// or nil
+func (r *BookingRepository) GetByIdempotencyKey(ctx context.Context, key string) (*domain.Booking, error) {
+	for _, name := range r.resolver.LookupOrder(ctx) {
+		booking, err := r.repos[name].GetByIdempotencyKey(ctx, key)
+		if err != nil || booking != nil {
+			return booking, err
+		}
+	}
+	return nil, nil
+}
+
+// CountByUserAndEvent counts a user's bookings on an event across shards
+func (r *BookingRepository) CountByUserAndEvent(ctx context.Context, userID, eventID string) (int, error) {
+	total := 0
+	for _, name := range r.resolver.Shards() {
+		count, err := r.repos[name].CountByUserAndEvent(ctx, userID, eventID)
+		if err != nil {
+			return 0, err
+		}
+		total += count
+	}
+	return total, nil
+}
+
+// GetTenantIDByShowID retrieves the tenant of a show from the default shard, which
+// holds the show catalogue
+func (r *BookingRepository) GetTenantIDByShowID(ctx context.Context, showID string) (string, error) {
+	return r.repos[database.DefaultShard].GetTenantIDByShowID(ctx, showID)
+}
+
+// GetShowStartTime retrieves when a show starts from the default shard
+func (r *BookingRepository) GetShowStartTime(ctx context.Context, showID string) (time.Time, error) {
+	return r.repos[database.DefaultShard].GetShowStartTime(ctx, showID)
+}
+
+// GetByIDs retrieves the bookings with the given IDs across shards; unknown IDs are skipped
+func (r *BookingRepository) GetByIDs(ctx context.Context, ids []string) ([]*domain.Booking, error) {
+	var found []*domain.Booking
+	for _, name := range r.resolver.Shards() {
+		bookings, err := r.repos[name].GetByIDs(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		found = append(found, bookings...)
+	}
+	return found, nil
+}
+
+// CancelReserved cancels the given bookings still pending or reserved across shards and
+// returns the IDs it cancelled
+func (r *BookingRepository) CancelReserved(ctx context.Context, ids []string) ([]string, error) {
+	var cancelled []string
+	for _, name := range r.resolver.Shards() {
+		shardCancelled, err := r.repos[name].CancelReserved(ctx, ids)
+		if err != nil {
+			return cancelled, err
+		}
+		cancelled = append(cancelled, shardCancelled...)
+	}
+	return cancelled, nil
+}
@@ -0,0 +1,262 @@
+package sharding
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
+)
+
+// shardStore is an in-memory database.ShardStore
+type shardStore struct {
+	assignments []database.TenantShard
+}
+
+func (s *shardStore) ListTenantShards(ctx context.Context) ([]database.TenantShard, error) {
+	return s.assignments, nil
+}
+
+func (s *shardStore) SaveTenantShard(ctx context.Context, assignment database.TenantShard) error {
+	s.assignments = append(s.assignments, assignment)
+	return nil
+}
+
+// shardRepo keeps the bookings of one shard in memory
+type shardRepo struct {
+	repository.BookingRepository
+	bookings map[string]*domain.Booking
+	lookups  int
+}
+
+func newShardRepo(bookings ...*domain.Booking) *shardRepo {
+	repo := &shardRepo{bookings: make(map[string]*domain.Booking)}
+	for _, booking := range bookings {
+		repo.bookings[booking.ID] = booking
+	}
+	return repo
+}
+
+func (r *shardRepo) Create(ctx context.Context, booking *domain.Booking) error {
+	r.bookings[booking.ID] = booking
+	return nil
+}
+
+func (r *shardRepo) GetByID(ctx context.Context, id string) (*domain.Booking, error) {
+	r.lookups++
+	if booking, ok := r.bookings[id]; ok {
+		return booking, nil
+	}
+	return nil, domain.ErrBookingNotFound
+}
+
+func (r *shardRepo) Confirm(ctx context.Context, id, paymentID string) error {
+	booking, ok := r.bookings[id]
+	if !ok {
+		return domain.ErrBookingNotFound
+	}
+	booking.Status = domain.BookingStatusConfirmed
+	booking.PaymentID = paymentID
+	return nil
+}
+
+func (r *shardRepo) GetByUserID(ctx context.Context, userID string, page pagination.Params) ([]*domain.Booking, error) {
+	var bookings []*domain.Booking
+	for _, booking := range r.bookings {
+		if booking.UserID == userID {
+			bookings = append(bookings, booking)
+		}
+	}
+	return pagination.Merge([][]*domain.Booking{bookings}, page, func(b *domain.Booking) pagination.Cursor {
+		return pagination.Cursor{Time: b.CreatedAt, ID: b.ID}
+	}), nil
+}
+
+func (r *shardRepo) GetExpiredReservations(ctx context.Context, limit int) ([]*domain.Booking, error) {
+	var expired []*domain.Booking
+	for _, booking := range r.bookings {
+		if booking.Status == domain.BookingStatusReserved && len(expired) < limit {
+			expired = append(expired, booking)
+		}
+	}
+	return expired, nil
+}
+
+func (r *shardRepo) GetIdleReservations(ctx context.Context, reservedAfter, reservedBefore time.Time, limit int) ([]*domain.Booking, error) {
+	var idle []*domain.Booking
+	for _, booking := range r.bookings {
+		if booking.Status == domain.BookingStatusReserved && booking.ReservedAt.After(reservedAfter) && !booking.ReservedAt.After(reservedBefore) {
+			idle = append(idle, booking)
+		}
+	}
+	sort.Slice(idle, func(i, j int) bool { return idle[i].ReservedAt.Before(idle[j].ReservedAt) })
+	if len(idle) > limit {
+		idle = idle[:limit]
+	}
+	return idle, nil
+}
+
+func (r *shardRepo) GetByIDs(ctx context.Context, ids []string) ([]*domain.Booking, error) {
+	var found []*domain.Booking
+	for _, id := range ids {
+		if booking, ok := r.bookings[id]; ok {
+			found = append(found, booking)
+		}
+	}
+	return found, nil
+}
+
+func (r *shardRepo) CancelReserved(ctx context.Context, ids []string) ([]string, error) {
+	var cancelled []string
+	for _, id := range ids {
+		if booking, ok := r.bookings[id]; ok && booking.Status == domain.BookingStatusReserved {
+			booking.Status = domain.BookingStatusCancelled
+			cancelled = append(cancelled, id)
+		}
+	}
+	return cancelled, nil
+}
+
+// newTestRepository returns a BookingRepository over a default shard and an eu shard
+// holding the bookings of tenant-eu
+func newTestRepository(t *testing.T, defaultRepo, euRepo *shardRepo) *BookingRepository {
+	t.Helper()
+
+	defaultDB, euDB := &database.PostgresDB{}, &database.PostgresDB{}
+	resolver := database.NewShardResolver(defaultDB, &shardStore{assignments: []database.TenantShard{
+		{TenantID: "tenant-eu", Shard: "eu"},
+	}})
+	resolver.AddShard("eu", euDB)
+	if err := resolver.Load(context.Background()); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	return NewBookingRepository(resolver, func(db *database.PostgresDB) ShardBookingRepository {
+		if db == euDB {
+			return euRepo
+		}
+		return defaultRepo
+	})
+}
+
+func TestBookingRepository_RoutesByTenant(t *testing.T) {
+	ctx := context.Background()
+	defaultRepo, euRepo := newShardRepo(), newShardRepo()
+	repo := newTestRepository(t, defaultRepo, euRepo)
+
+	if err := repo.Create(ctx, &domain.Booking{ID: "b-eu", TenantID: "tenant-eu"}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := repo.Create(ctx, &domain.Booking{ID: "b-other", TenantID: "tenant-other"}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, ok := euRepo.bookings["b-eu"]; !ok {
+		t.Error("booking of tenant-eu was not created on the eu shard")
+	}
+	if _, ok := defaultRepo.bookings["b-other"]; !ok {
+		t.Error("booking of an unassigned tenant was not created on the default shard")
+	}
+
+	// Without a tenant the lookup falls through from the default shard
+	booking, err := repo.GetByID(ctx, "b-eu")
+	if err != nil || booking.ID != "b-eu" {
+		t.Fatalf("GetByID() = %v, %v", booking, err)
+	}
+	if defaultRepo.lookups != 1 {
+		t.Errorf("default shard lookups = %d, want 1", defaultRepo.lookups)
+	}
+
+	// With the tenant the lookup starts on its shard
+	if _, err := repo.GetByID(database.WithTenant(ctx, "tenant-eu"), "b-eu"); err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if defaultRepo.lookups != 1 {
+		t.Errorf("default shard lookups = %d, want 1 after a lookup with the tenant", defaultRepo.lookups)
+	}
+
+	if err := repo.Confirm(ctx, "b-eu", "pay-1"); err != nil {
+		t.Fatalf("Confirm() error = %v", err)
+	}
+	if euRepo.bookings["b-eu"].PaymentID != "pay-1" {
+		t.Error("Confirm() did not reach the eu shard")
+	}
+
+	if _, err := repo.GetByID(ctx, "missing"); !errors.Is(err, domain.ErrBookingNotFound) {
+		t.Errorf("GetByID() of a missing booking error = %v, want ErrBookingNotFound", err)
+	}
+}
+
+func TestBookingRepository_GetByUserID(t *testing.T) {
+	now := time.Now()
+	booking := func(id string, age time.Duration) *domain.Booking {
+		return &domain.Booking{ID: id, UserID: "user-1", CreatedAt: now.Add(-age)}
+	}
+	repo := newTestRepository(t,
+		newShardRepo(booking("b1", 0), booking("b3", 2*time.Minute)),
+		newShardRepo(booking("b2", time.Minute), booking("b4", 3*time.Minute)),
+	)
+
+	got, err := repo.GetByUserID(context.Background(), "user-1", pagination.Params{Limit: 2, Offset: 1})
+	if err != nil {
+		t.Fatalf("GetByUserID() error = %v", err)
+	}
+	if len(got) != 2 || got[0].ID != "b2" || got[1].ID != "b3" {
+		t.Errorf("GetByUserID() = %v, want b2, b3", got)
+	}
+}
+
+func TestBookingRepository_Batches(t *testing.T) {
+	ctx := context.Background()
+	reserved := func(id string, expired time.Duration) *domain.Booking {
+		return &domain.Booking{ID: id, Status: domain.BookingStatusReserved, ExpiresAt: time.Now().Add(-expired)}
+	}
+	repo := newTestRepository(t,
+		newShardRepo(reserved("b1", time.Minute)),
+		newShardRepo(reserved("b2", time.Hour), reserved("b3", 2*time.Hour)),
+	)
+
+	expired, err := repo.GetExpiredReservations(ctx, 2)
+	if err != nil {
+		t.Fatalf("GetExpiredReservations() error = %v", err)
+	}
+	if len(expired) != 2 || expired[0].ID != "b3" || expired[1].ID != "b2" {
+		t.Errorf("GetExpiredReservations() = %v, want b3, b2", expired)
+	}
+
+	cancelled, err := repo.CancelReserved(ctx, []string{"b1", "b2", "missing"})
+	if err != nil {
+		t.Fatalf("CancelReserved() error = %v", err)
+	}
+	if len(cancelled) != 2 {
+		t.Errorf("CancelReserved() = %v, want b1 and b2", cancelled)
+	}
+	found, err := repo.GetByIDs(ctx, []string{"b1", "b3"})
+	if err != nil || len(found) != 2 {
+		t.Errorf("GetByIDs() = %v, %v, want b1 and b3", found, err)
+	}
+}
+
+func TestBookingRepository_GetIdleReservations(t *testing.T) {
+	now := time.Now()
+	reserved := func(id string, ago time.Duration) *domain.Booking {
+		return &domain.Booking{ID: id, Status: domain.BookingStatusReserved, ReservedAt: now.Add(-ago)}
+	}
+	repo := newTestRepository(t,
+		newShardRepo(reserved("b1", 20*time.Minute), reserved("b4", time.Minute)),
+		newShardRepo(reserved("b2", 15*time.Minute), reserved("b3", 30*time.Minute)),
+	)
+
+	// b4 is not idle yet; the oldest two of the rest come first across shards
+	idle, err := repo.GetIdleReservations(context.Background(), now.Add(-time.Hour), now.Add(-5*time.Minute), 2)
+	if err != nil {
+		t.Fatalf("GetIdleReservations() error = %v", err)
+	}
+	if len(idle) != 2 || idle[0].ID != "b3" || idle[1].ID != "b1" {
+		t.Errorf("GetIdleReservations() = %v, want b3, b1", idle)
+	}
+}
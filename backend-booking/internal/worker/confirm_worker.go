@@ -0,0 +1,129 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+)
+
+// ConfirmWorkerConfig contains configuration for the confirm worker
+type ConfirmWorkerConfig struct {
+	// Concurrency is the number of confirmations processed in parallel
+	Concurrency int
+	// PollWait is how long each loop blocks waiting for a queued job
+	PollWait time.Duration
+	// ErrorBackoff is the pause after a queue error
+	ErrorBackoff time.Duration
+}
+
+// DefaultConfirmWorkerConfig returns default configuration
+func DefaultConfirmWorkerConfig() *ConfirmWorkerConfig {
+	return &ConfirmWorkerConfig{
+		Concurrency:  8,
+		PollWait:     time.Second,
+		ErrorBackoff: time.Second,
+	}
+}
+
+// ConfirmWorker drains the async confirmation queue
+type ConfirmWorker struct {
+	confirmService service.AsyncConfirmService
+	config         *ConfirmWorkerConfig
+	log            *logger.Logger
+	stopCh         chan struct{}
+	wg             sync.WaitGroup
+	mu             sync.Mutex
+	running        bool
+}
+
+// NewConfirmWorker creates a new confirm worker
+func NewConfirmWorker(confirmService service.AsyncConfirmService, config *ConfirmWorkerConfig) *ConfirmWorker {
+	defaults := DefaultConfirmWorkerConfig()
+	if config == nil {
+		config = defaults
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = defaults.Concurrency
+	}
+	if config.PollWait <= 0 {
+		config.PollWait = defaults.PollWait
+	}
+	if config.ErrorBackoff <= 0 {
+		config.ErrorBackoff = defaults.ErrorBackoff
+	}
+
+	return &ConfirmWorker{
+		confirmService: confirmService,
+		config:         config,
+		log:            logger.Get(),
+		stopCh:         make(chan struct{}),
+	}
+}
+
+// Start starts the confirm worker
+func (w *ConfirmWorker) Start(ctx context.Context) error {
+	w.mu.Lock()
+	if w.running {
+		w.mu.Unlock()
+		return fmt.Errorf("confirm worker already running")
+	}
+	w.running = true
+	w.mu.Unlock()
+
+	w.log.Info(fmt.Sprintf("Starting confirm worker with %d goroutines", w.config.Concurrency))
+
+	for i := 0; i < w.config.Concurrency; i++ {
+		w.wg.Add(1)
+		go w.run(ctx)
+	}
+
+	return nil
+}
+
+// Stop stops the confirm worker, waiting for in-flight confirmations to finish
+func (w *ConfirmWorker) Stop() {
+	w.mu.Lock()
+	if !w.running {
+		w.mu.Unlock()
+		return
+	}
+	w.running = false
+	w.mu.Unlock()
+
+	w.log.Info("Stopping confirm worker")
+	close(w.stopCh)
+	w.wg.Wait()
+	w.log.Info("Confirm worker stopped")
+}
+
+// run processes queued confirmations until stopped
+func (w *ConfirmWorker) run(ctx context.Context) {
+	defer w.wg.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.stopCh:
+			return
+		default:
+		}
+
+		// Jobs in flight finish on the background context so Stop does not strand them
+		// in processing; they would only be picked up again once stale
+		if _, err := w.confirmService.ProcessNext(context.WithoutCancel(ctx), w.config.PollWait); err != nil {
+			w.log.Error(fmt.Sprintf("Failed to process async confirmation: %v", err))
+			select {
+			case <-ctx.Done():
+				return
+			case <-w.stopCh:
+				return
+			case <-time.After(w.config.ErrorBackoff):
+			}
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
 )
 
@@ -17,13 +18,16 @@ type ExpiryWorkerConfig struct {
 	ScanInterval time.Duration
 	// BatchSize is the number of reservations to process in each scan
 	BatchSize int
+	// StandbyOfferWindow is how long released seats are held for a standby user
+	StandbyOfferWindow time.Duration
 }
 
 // DefaultExpiryWorkerConfig returns default configuration
 func DefaultExpiryWorkerConfig() *ExpiryWorkerConfig {
 	return &ExpiryWorkerConfig{
-		ScanInterval: 5 * time.Second, // Scan every 5 seconds
-		BatchSize:    100,
+		ScanInterval:       5 * time.Second, // Scan every 5 seconds
+		BatchSize:          100,
+		StandbyOfferWindow: 2 * time.Minute,
 	}
 }
 
@@ -32,6 +36,8 @@ type ExpiryWorker struct {
 	bookingRepo       *repository.PostgresBookingRepository
 	transactionalRepo *repository.TransactionalBookingRepository
 	reservationRepo   *repository.RedisReservationRepository
+	standbyRepo       repository.StandbyRepository
+	notifier          service.StandbyNotifier
 	config            *ExpiryWorkerConfig
 	log               *logger.Logger
 	stopCh            chan struct{}
@@ -51,16 +57,23 @@ func NewExpiryWorker(
 	bookingRepo *repository.PostgresBookingRepository,
 	transactionalRepo *repository.TransactionalBookingRepository,
 	reservationRepo *repository.RedisReservationRepository,
+	standbyRepo repository.StandbyRepository,
+	notifier service.StandbyNotifier,
 	config *ExpiryWorkerConfig,
 ) *ExpiryWorker {
 	if config == nil {
 		config = DefaultExpiryWorkerConfig()
 	}
+	if config.StandbyOfferWindow <= 0 {
+		config.StandbyOfferWindow = 2 * time.Minute
+	}
 
 	return &ExpiryWorker{
 		bookingRepo:       bookingRepo,
 		transactionalRepo: transactionalRepo,
 		reservationRepo:   reservationRepo,
+		standbyRepo:       standbyRepo,
+		notifier:          notifier,
 		config:            config,
 		log:               logger.Get(),
 		stopCh:            make(chan struct{}),
@@ -162,6 +175,7 @@ func (w *ExpiryWorker) expireBooking(ctx context.Context, booking *domain.Bookin
 		w.totalReleased++
 		w.log.Info(fmt.Sprintf("Released %d seats for booking %s, new availability: %d",
 			booking.Quantity, booking.ID, releaseResult.AvailableSeats))
+		w.offerStandby(ctx, booking.ZoneID)
 	} else if releaseResult.ErrorCode == "RESERVATION_NOT_FOUND" {
 		// Redis reservation already expired via TTL - this is expected
 		w.log.Debug(fmt.Sprintf("Redis reservation for booking %s already expired (TTL)", booking.ID))
@@ -186,6 +200,29 @@ func (w *ExpiryWorker) expireBooking(ctx context.Context, booking *domain.Bookin
 	return nil
 }
 
+// offerStandby hands seats freed by an expired hold to waiting standby users and invites them
+func (w *ExpiryWorker) offerStandby(ctx context.Context, zoneID string) {
+	if w.standbyRepo == nil {
+		return
+	}
+	result, err := w.standbyRepo.OfferSeats(ctx, zoneID, w.config.StandbyOfferWindow)
+	if err != nil {
+		w.log.Warn(fmt.Sprintf("Failed to offer standby seats for zone %s: %v", zoneID, err))
+		return
+	}
+	if len(result.Offers) > 0 {
+		w.log.Info(fmt.Sprintf("Offered released seats to %d standby users (zone=%s)", len(result.Offers), zoneID))
+	}
+	if w.notifier == nil {
+		return
+	}
+	for _, offer := range result.Offers {
+		if err := w.notifier.NotifyOffer(ctx, offer); err != nil {
+			w.log.Warn(fmt.Sprintf("Failed to notify standby user %s (zone=%s): %v", offer.UserID, zoneID, err))
+		}
+	}
+}
+
 // GetStats returns worker statistics
 func (w *ExpiryWorker) GetStats() *ExpiryWorkerStats {
 	w.mu.Lock()
@@ -33,7 +33,7 @@ func TestExpiryWorkerConfig_Custom(t *testing.T) {
 }
 
 func TestNewExpiryWorker_WithDefaultConfig(t *testing.T) {
-	worker := NewExpiryWorker(nil, nil, nil, nil)
+	worker := NewExpiryWorker(nil, nil, nil, nil, nil, nil)
 
 	if worker == nil {
 		t.Fatal("NewExpiryWorker() returned nil")
@@ -66,7 +66,7 @@ func TestNewExpiryWorker_WithCustomConfig(t *testing.T) {
 		BatchSize:    200,
 	}
 
-	worker := NewExpiryWorker(nil, nil, nil, customConfig)
+	worker := NewExpiryWorker(nil, nil, nil, nil, nil, customConfig)
 
 	if worker == nil {
 		t.Fatal("NewExpiryWorker() returned nil")
@@ -113,7 +113,7 @@ func TestExpiryWorkerStats(t *testing.T) {
 }
 
 func TestExpiryWorker_GetStats(t *testing.T) {
-	worker := NewExpiryWorker(nil, nil, nil, nil)
+	worker := NewExpiryWorker(nil, nil, nil, nil, nil, nil)
 
 	// Initial stats
 	stats := worker.GetStats()
@@ -136,7 +136,7 @@ func TestExpiryWorker_GetStats(t *testing.T) {
 }
 
 func TestExpiryWorker_StartStop(t *testing.T) {
-	worker := NewExpiryWorker(nil, nil, nil, &ExpiryWorkerConfig{
+	worker := NewExpiryWorker(nil, nil, nil, nil, nil, &ExpiryWorkerConfig{
 		ScanInterval: 100 * time.Millisecond,
 		BatchSize:    10,
 	})
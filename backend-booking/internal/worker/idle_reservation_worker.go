@@ -0,0 +1,151 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/events"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+)
+
+// IdleReservationWorkerConfig holds configuration for the idle reservation worker
+type IdleReservationWorkerConfig struct {
+	PaymentTopic string        // Topic carrying payment.created
+	PollInterval time.Duration // Interval between looking for idle reservations
+	BatchSize    int           // Max reservations read at a time
+}
+
+// DefaultIdleReservationWorkerConfig returns default configuration
+func DefaultIdleReservationWorkerConfig() *IdleReservationWorkerConfig {
+	return &IdleReservationWorkerConfig{
+		PaymentTopic: events.TopicPaymentEvents,
+		PollInterval: 15 * time.Second,
+		BatchSize:    500,
+	}
+}
+
+// IdleReservationWorker records the payments customers start and reminds the holders of
+// reservations nobody started paying for, offering them a one-click release.
+type IdleReservationWorker struct {
+	config      *IdleReservationWorkerConfig
+	consumer    *kafka.Consumer
+	idleService service.IdleReservationService
+	log         *logger.Logger
+}
+
+// NewIdleReservationWorker creates a new idle reservation worker
+func NewIdleReservationWorker(
+	cfg *IdleReservationWorkerConfig,
+	consumer *kafka.Consumer,
+	idleService service.IdleReservationService,
+	log *logger.Logger,
+) *IdleReservationWorker {
+	defaults := DefaultIdleReservationWorkerConfig()
+	if cfg == nil {
+		cfg = defaults
+	}
+	if cfg.PaymentTopic == "" {
+		cfg.PaymentTopic = defaults.PaymentTopic
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaults.PollInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaults.BatchSize
+	}
+
+	return &IdleReservationWorker{
+		config:      cfg,
+		consumer:    consumer,
+		idleService: idleService,
+		log:         log,
+	}
+}
+
+// Start begins consuming started payments and reminding idle holders until ctx is cancelled
+func (w *IdleReservationWorker) Start(ctx context.Context) {
+	if w.consumer != nil {
+		go w.consumeLoop(ctx)
+	}
+
+	ticker := time.NewTicker(w.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.log.Info("Idle reservation worker context cancelled")
+			return
+		case <-ticker.C:
+			w.remindIdle(ctx)
+		}
+	}
+}
+
+// consumeLoop continuously polls for started payments
+func (w *IdleReservationWorker) consumeLoop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			records, err := w.consumer.Poll(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				w.log.Error(fmt.Sprintf("Failed to poll Kafka: %v", err))
+				time.Sleep(time.Second)
+				continue
+			}
+
+			if len(records) == 0 {
+				continue
+			}
+
+			for _, record := range records {
+				if err := w.processRecord(ctx, record); err != nil {
+					w.log.Error(fmt.Sprintf("Failed to record started payment: %v", err))
+				}
+			}
+
+			// Marking a payment started twice is harmless, so redelivered events are fine
+			if err := w.consumer.CommitRecords(ctx, records); err != nil {
+				w.log.Error(fmt.Sprintf("Failed to commit offsets: %v", err))
+			}
+		}
+	}
+}
+
+// processRecord records the payment started by a payment.created event.
+// Other payment events are skipped.
+func (w *IdleReservationWorker) processRecord(ctx context.Context, record *kafka.Record) error {
+	var event events.PaymentEvent
+	if err := json.Unmarshal(record.Value, &event); err != nil {
+		return fmt.Errorf("failed to unmarshal payment event: %w", err)
+	}
+	if event.EventType != events.PaymentEventCreated || event.PaymentData == nil || event.PaymentData.BookingID == "" {
+		return nil
+	}
+
+	if err := w.idleService.RecordPaymentStarted(ctx, event.PaymentData.BookingID); err != nil {
+		return fmt.Errorf("failed to record payment started for booking %s: %w", event.PaymentData.BookingID, err)
+	}
+	return nil
+}
+
+// remindIdle reminds the holders of idle reservations
+func (w *IdleReservationWorker) remindIdle(ctx context.Context) {
+	reminded, err := w.idleService.RemindIdle(ctx, w.config.BatchSize)
+	if err != nil {
+		w.log.Error(fmt.Sprintf("Failed to remind idle reservation holders: %v", err))
+		return
+	}
+	if reminded > 0 {
+		w.log.Info(fmt.Sprintf("Reminded %d idle reservation holders", reminded))
+	}
+}
@@ -0,0 +1,270 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
+)
+
+// EventRebuildOptions configures a rebuild of Redis inventory from events
+type EventRebuildOptions struct {
+	Brokers []string
+	// Until is the point in time the counters are recovered to: events produced at
+	// or after it are not replayed (zero = every event retained)
+	Until time.Time
+	// ZoneIDs limits the rebuild to these zones (empty = every zone the events touch)
+	ZoneIDs []string
+	// DryRun reports what the rebuild would change without writing to Redis
+	DryRun bool
+}
+
+// replayedEvent is a decoded event of a replayed record
+type replayedEvent struct {
+	at       time.Time // Kafka record timestamp
+	booking  *domain.BookingEvent
+	zone     *domain.ZoneEvent
+	capacity *domain.ZoneCapacityChangedEvent
+}
+
+// RebuildRedisFromEvents recovers Redis inventory to a point in time by replaying
+// the booking and zone capacity topics from the earliest retained record up to
+// opts.Until. Unlike RebuildRedisFromDB it does not depend on seat_zones, so it can
+// undo counter damage already synced to PostgreSQL. A zone is only rebuilt when its
+// zone.created or zone.updated event is still retained. Holds and per-user counts
+// are not rebuilt, so sales of the zones should be paused while this runs.
+func (w *InventoryWorker) RebuildRedisFromEvents(ctx context.Context, opts *EventRebuildOptions) (*domain.InventoryRebuildReport, error) {
+	report := &domain.InventoryRebuildReport{Until: opts.Until, DryRun: opts.DryRun}
+	zoneFilter := make(map[string]bool, len(opts.ZoneIDs))
+	for _, zoneID := range opts.ZoneIDs {
+		zoneFilter[zoneID] = true
+	}
+
+	w.log.Info(fmt.Sprintf("Replaying %s and %s up to %s...", w.config.BookingTopic, w.config.CapacityTopic, formatUntil(opts.Until)))
+
+	var events []*replayedEvent
+	stats, err := kafka.Replay(ctx, &kafka.ReplayConfig{
+		Brokers:  opts.Brokers,
+		Topics:   []string{w.config.BookingTopic, w.config.CapacityTopic},
+		ClientID: "inventory-rebuild",
+		Until:    opts.Until,
+	}, func(record *kafka.Record) error {
+		event, err := w.decodeReplayRecord(record)
+		if err != nil {
+			report.Undecoded++
+			return nil
+		}
+		if event != nil && event.touches(zoneFilter) {
+			events = append(events, event)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to replay events: %w", err)
+	}
+	report.Records = stats.Records
+	report.Unfinished = stats.Unfinished
+
+	replay := foldReplayedEvents(events)
+	report.Events = replay.Applied()
+	report.Duplicates = replay.Duplicates()
+	report.Rejected = replay.Rejected()
+	report.LastEvent = replay.LastApplied()
+
+	zones := replay.Zones()
+	if len(zoneFilter) > 0 {
+		// Zones asked for that no retained event touched are reported as skipped
+		zones = make([]*domain.ReplayedZone, 0, len(opts.ZoneIDs))
+		for _, zoneID := range opts.ZoneIDs {
+			zone := replay.Zone(zoneID)
+			if zone == nil {
+				zone = &domain.ReplayedZone{ZoneID: zoneID}
+			}
+			zones = append(zones, zone)
+		}
+	}
+
+	database, err := w.zoneAvailability(ctx, zones)
+	if err != nil {
+		return nil, err
+	}
+	for _, zone := range zones {
+		plan, err := w.rebuildZone(ctx, zone, database, opts.DryRun)
+		if err != nil {
+			return report, err
+		}
+		if plan.Action == domain.InventoryRebuildSet || plan.Action == domain.InventoryRebuildRemove {
+			report.Changed++
+		}
+		report.Zones = append(report.Zones, plan)
+	}
+
+	verb := "changed"
+	if opts.DryRun {
+		verb = "would change"
+	}
+	w.log.Info(fmt.Sprintf("Redis rebuild from %d events %s %d of %d zones", report.Events, verb, report.Changed, len(report.Zones)))
+	return report, nil
+}
+
+// decodeReplayRecord decodes the event of a replayed record, returning nil for
+// event types that do not move inventory
+func (w *InventoryWorker) decodeReplayRecord(record *kafka.Record) (*replayedEvent, error) {
+	event := &replayedEvent{at: record.Timestamp}
+	eventType := recordEventType(record)
+
+	switch record.Topic {
+	case w.config.BookingTopic:
+		switch domain.BookingEventType(eventType) {
+		case domain.BookingEventCreated, domain.BookingEventConfirmed, domain.BookingEventCancelled,
+			domain.BookingEventExpired, domain.BookingEventModified:
+		default:
+			return nil, nil
+		}
+		event.booking = &domain.BookingEvent{}
+		if err := json.Unmarshal(record.Value, event.booking); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal booking event: %w", err)
+		}
+		if event.booking.BookingData == nil {
+			return nil, fmt.Errorf("booking event has no data")
+		}
+
+	case w.config.CapacityTopic:
+		if eventType == domain.ZoneCreatedEventType || eventType == domain.ZoneUpdatedEventType {
+			event.zone = &domain.ZoneEvent{}
+			if err := json.Unmarshal(record.Value, event.zone); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal zone event: %w", err)
+			}
+			break
+		}
+		event.capacity = &domain.ZoneCapacityChangedEvent{}
+		if err := json.Unmarshal(record.Value, event.capacity); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal capacity event: %w", err)
+		}
+
+	default:
+		return nil, nil
+	}
+	return event, nil
+}
+
+// touches reports whether the event moves the counter of a zone in filter
+// (an empty filter takes every zone)
+func (e *replayedEvent) touches(filter map[string]bool) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	switch {
+	case e.booking != nil:
+		return filter[e.booking.BookingData.ZoneID] || filter[e.booking.BookingData.PreviousZoneID]
+	case e.zone != nil:
+		return filter[e.zone.ZoneID]
+	case e.capacity != nil:
+		return filter[e.capacity.ZoneID]
+	}
+	return false
+}
+
+// foldReplayedEvents applies the events in the order they were produced. Partitions
+// are read concurrently, so a zone's lifecycle events would otherwise race its bookings.
+func foldReplayedEvents(events []*replayedEvent) *domain.InventoryReplay {
+	sort.SliceStable(events, func(i, j int) bool { return events[i].at.Before(events[j].at) })
+
+	replay := domain.NewInventoryReplay()
+	for _, event := range events {
+		switch {
+		case event.booking != nil:
+			replay.ApplyBookingEvent(event.booking)
+		case event.zone != nil:
+			replay.ApplyZoneEvent(event.zone)
+		case event.capacity != nil:
+			replay.ApplyCapacityChange(event.capacity)
+		}
+	}
+	return replay
+}
+
+// zoneAvailability returns seat_zones.available_seats of the zones that have a row
+func (w *InventoryWorker) zoneAvailability(ctx context.Context, zones []*domain.ReplayedZone) (map[string]int64, error) {
+	available := make(map[string]int64)
+	if w.db == nil || len(zones) == 0 {
+		return available, nil
+	}
+
+	zoneIDs := make([]string, len(zones))
+	for i, zone := range zones {
+		zoneIDs[i] = zone.ZoneID
+	}
+
+	rows, err := w.db.Pool().Query(ctx, `SELECT id::text, available_seats FROM seat_zones WHERE id::text = ANY($1)`, zoneIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query seat zones: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var zoneID string
+		var seats int64
+		if err := rows.Scan(&zoneID, &seats); err != nil {
+			return nil, fmt.Errorf("failed to scan zone row: %w", err)
+		}
+		available[zoneID] = seats
+	}
+	return available, rows.Err()
+}
+
+// rebuildZone plans the rebuild of a zone's counter against Redis and applies it
+// unless dryRun is set
+func (w *InventoryWorker) rebuildZone(ctx context.Context, zone *domain.ReplayedZone, database map[string]int64, dryRun bool) (domain.InventoryRebuildZone, error) {
+	var redis, db *int64
+	layout, err := w.shardRepo.GetLayout(ctx, zone.ZoneID)
+	switch {
+	case err == nil:
+		redis = &layout.Total
+	case !errors.Is(err, domain.ErrZoneNotFound):
+		return domain.InventoryRebuildZone{}, fmt.Errorf("failed to read zone %s from Redis: %w", zone.ZoneID, err)
+	}
+	if seats, ok := database[zone.ZoneID]; ok {
+		db = &seats
+	}
+
+	plan := domain.PlanInventoryRebuild(zone, redis, db)
+	if dryRun {
+		return plan, nil
+	}
+
+	switch plan.Action {
+	case domain.InventoryRebuildSet:
+		if redis != nil {
+			// Sharded zones rebalance from the primary counter
+			err = w.shardRepo.ResetAvailability(ctx, zone.ZoneID, *plan.Replayed)
+		} else {
+			_, err = w.capacityRepo.SeedZone(ctx, repository.SeedZoneParams{
+				ZoneID:         zone.ZoneID,
+				AvailableSeats: int(*plan.Replayed),
+				HoldTTLSeconds: zone.HoldTTLSeconds,
+				Overwrite:      true,
+			})
+		}
+	case domain.InventoryRebuildRemove:
+		err = w.capacityRepo.RemoveZone(ctx, zone.ZoneID)
+	}
+	if err != nil {
+		return plan, fmt.Errorf("failed to rebuild zone %s: %w", zone.ZoneID, err)
+	}
+	return plan, nil
+}
+
+// formatUntil formats a rebuild's target time for logs
+func formatUntil(until time.Time) string {
+	if until.IsZero() {
+		return "the latest event"
+	}
+	return until.Format(time.RFC3339)
+}
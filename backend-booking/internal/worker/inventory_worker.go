@@ -4,12 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
-	"github.com/jackc/pgx/v5"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/events"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
 	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
@@ -20,8 +23,13 @@ type InventoryWorkerConfig struct {
 	BatchInterval    time.Duration
 	MaxBatchSize     int
 	RebuildOnStartup bool
+	BookingTopic     string // Topic carrying booking events
+	CapacityTopic    string // Topic carrying zone capacity changes and zone lifecycle events from ticket-service
 }
 
+// RecordHandler handles a Kafka record routed to it by topic and event type
+type RecordHandler func(ctx context.Context, record *kafka.Record) error
+
 // ZoneInventoryDelta tracks changes to a zone's inventory
 type ZoneInventoryDelta struct {
 	ZoneID         string
@@ -30,13 +38,23 @@ type ZoneInventoryDelta struct {
 	CancelledDelta int // positive = seats cancelled (released back)
 }
 
-// InventoryWorker consumes booking events and syncs inventory to PostgreSQL
+// InventoryWorker consumes booking events and syncs inventory to PostgreSQL.
+// It also applies zone capacity changes, which must go through the live Redis
+// counter so that in-flight holds are accounted for, and seeds Redis for zones
+// as soon as ticket-service creates or updates them, invalidating the zone catalog.
 type InventoryWorker struct {
-	config   *InventoryWorkerConfig
-	consumer *kafka.Consumer
-	db       *database.PostgresDB
-	redis    *pkgredis.Client
-	log      *logger.Logger
+	config       *InventoryWorkerConfig
+	consumer     *kafka.Consumer
+	db           *database.PostgresDB
+	redis        *pkgredis.Client
+	capacityRepo repository.ZoneCapacityRepository
+	shardRepo    repository.ZoneShardRepository
+	log          *logger.Logger
+
+	// Handlers by topic and event type; records of other event types are skipped
+	// without being deserialized
+	handlersOnce sync.Once
+	handlers     map[string]map[string]RecordHandler
 
 	// Batch aggregation
 	mu     sync.Mutex
@@ -49,6 +67,8 @@ func NewInventoryWorker(
 	consumer *kafka.Consumer,
 	db *database.PostgresDB,
 	redis *pkgredis.Client,
+	capacityRepo repository.ZoneCapacityRepository,
+	shardRepo repository.ZoneShardRepository,
 	log *logger.Logger,
 ) *InventoryWorker {
 	if cfg.BatchInterval <= 0 {
@@ -57,14 +77,22 @@ func NewInventoryWorker(
 	if cfg.MaxBatchSize <= 0 {
 		cfg.MaxBatchSize = 1000
 	}
+	if cfg.BookingTopic == "" {
+		cfg.BookingTopic = events.TopicBookingEvents
+	}
+	if cfg.CapacityTopic == "" {
+		cfg.CapacityTopic = events.TopicZoneCapacityEvents
+	}
 
 	return &InventoryWorker{
-		config:   cfg,
-		consumer: consumer,
-		db:       db,
-		redis:    redis,
-		log:      log,
-		deltas:   make(map[string]*ZoneInventoryDelta),
+		config:       cfg,
+		consumer:     consumer,
+		db:           db,
+		redis:        redis,
+		capacityRepo: capacityRepo,
+		shardRepo:    shardRepo,
+		log:          log,
+		deltas:       make(map[string]*ZoneInventoryDelta),
 	}
 }
 
@@ -137,16 +165,88 @@ func (w *InventoryWorker) consumeLoop(ctx context.Context, flushCh chan<- struct
 	}
 }
 
+// Handle registers handler for records of eventType on topic, replacing any handler
+// registered before. An empty eventType registers the topic's fallback for event types
+// without a handler of their own.
+func (w *InventoryWorker) Handle(topic, eventType string, handler RecordHandler) {
+	w.handlersOnce.Do(w.registerDefaultHandlers)
+	if w.handlers[topic] == nil {
+		w.handlers[topic] = make(map[string]RecordHandler)
+	}
+	w.handlers[topic][eventType] = handler
+}
+
+// registerDefaultHandlers routes the booking events that move inventory and the zone
+// capacity and lifecycle events
+func (w *InventoryWorker) registerDefaultHandlers() {
+	w.handlers = make(map[string]map[string]RecordHandler)
+
+	bookingTopic := w.config.BookingTopic
+	if bookingTopic == "" {
+		bookingTopic = events.TopicBookingEvents
+	}
+	bookingHandler := func(ctx context.Context, record *kafka.Record) error {
+		return w.processRecord(record)
+	}
+	w.handlers[bookingTopic] = map[string]RecordHandler{
+		string(domain.BookingEventCreated):   bookingHandler,
+		string(domain.BookingEventConfirmed): bookingHandler,
+		string(domain.BookingEventCancelled): bookingHandler,
+		string(domain.BookingEventExpired):   bookingHandler,
+		string(domain.BookingEventModified):  bookingHandler,
+	}
+
+	// Capacity changes predate zone lifecycle events and are the topic's fallback
+	w.handlers[w.config.CapacityTopic] = map[string]RecordHandler{
+		domain.ZoneCreatedEventType: w.processZoneRecord,
+		domain.ZoneUpdatedEventType: w.processZoneRecord,
+		"":                          w.processCapacityRecord,
+	}
+}
+
+// handlerFor returns the handler of a record, or nil if the worker does not consume its
+// event type. Producers set the event_type header; the payload is only peeked at for
+// records published without it.
+func (w *InventoryWorker) handlerFor(record *kafka.Record) RecordHandler {
+	w.handlersOnce.Do(w.registerDefaultHandlers)
+	handlers := w.handlers[record.Topic]
+	if len(handlers) == 0 {
+		return nil
+	}
+
+	if handler, ok := handlers[recordEventType(record)]; ok {
+		return handler
+	}
+	return handlers[""]
+}
+
+// recordEventType returns the event_type header of a record, or the payload's
+// event_type for records published without it
+func recordEventType(record *kafka.Record) string {
+	if eventType, ok := record.Headers["event_type"]; ok {
+		return eventType
+	}
+	var envelope struct {
+		EventType string `json:"event_type"`
+	}
+	_ = json.Unmarshal(record.Value, &envelope)
+	return envelope.EventType
+}
+
 // processRecords processes a batch of Kafka records
-func (w *InventoryWorker) processRecords(_ context.Context, records []*kafka.Record) {
+func (w *InventoryWorker) processRecords(ctx context.Context, records []*kafka.Record) {
 	for _, record := range records {
-		if err := w.processRecord(record); err != nil {
+		handler := w.handlerFor(record)
+		if handler == nil {
+			continue
+		}
+		if err := handler(ctx, record); err != nil {
 			w.log.Error(fmt.Sprintf("Failed to process record: %v", err))
 		}
 	}
 }
 
-// processRecord processes a single Kafka record
+// processRecord processes a single booking event record
 func (w *InventoryWorker) processRecord(record *kafka.Record) error {
 	var event domain.BookingEvent
 	if err := json.Unmarshal(record.Value, &event); err != nil {
@@ -169,11 +269,7 @@ func (w *InventoryWorker) aggregateDelta(event *domain.BookingEvent) {
 	zoneID := event.BookingData.ZoneID
 	quantity := event.BookingData.Quantity
 
-	delta, exists := w.deltas[zoneID]
-	if !exists {
-		delta = &ZoneInventoryDelta{ZoneID: zoneID}
-		w.deltas[zoneID] = delta
-	}
+	delta := w.zoneDelta(zoneID)
 
 	switch event.EventType {
 	case domain.BookingEventCreated:
@@ -185,7 +281,26 @@ func (w *InventoryWorker) aggregateDelta(event *domain.BookingEvent) {
 	case domain.BookingEventCancelled, domain.BookingEventExpired:
 		// Seats released: decrease reserved, increase available
 		delta.CancelledDelta += quantity
+	case domain.BookingEventModified:
+		// Seats moved: the previous seats are undone and the new ones taken
+		previous := w.zoneDelta(event.BookingData.PreviousZoneID)
+		previous.ReservedDelta -= event.BookingData.PreviousQuantity
+		delta.ReservedDelta += quantity
+		if event.BookingData.Status == string(domain.BookingStatusConfirmed) {
+			previous.ConfirmedDelta -= event.BookingData.PreviousQuantity
+			delta.ConfirmedDelta += quantity
+		}
+	}
+}
+
+// zoneDelta returns the pending delta of a zone, creating it if needed. Callers hold w.mu.
+func (w *InventoryWorker) zoneDelta(zoneID string) *ZoneInventoryDelta {
+	delta, exists := w.deltas[zoneID]
+	if !exists {
+		delta = &ZoneInventoryDelta{ZoneID: zoneID}
+		w.deltas[zoneID] = delta
 	}
+	return delta
 }
 
 // flushBatch writes aggregated deltas to PostgreSQL
@@ -201,63 +316,80 @@ func (w *InventoryWorker) flushBatch(ctx context.Context) {
 	w.deltas = make(map[string]*ZoneInventoryDelta)
 	w.mu.Unlock()
 
-	w.log.Info(fmt.Sprintf("Flushing batch with %d zone updates", len(deltas)))
-
-	// Begin transaction
-	tx, err := w.db.BeginTx(ctx)
-	if err != nil {
-		w.log.Error(fmt.Sprintf("Failed to begin transaction: %v", err))
-		// Put deltas back for retry
-		w.restoreDeltas(deltas)
+	changes := zoneInventoryChanges(deltas)
+	if len(changes) == 0 {
+		// Every zone's events cancelled out (e.g. holds that expired within the batch)
 		return
 	}
 
-	// Update each zone
-	for zoneID, delta := range deltas {
-		if err := w.updateZoneInventory(ctx, tx, delta); err != nil {
-			w.log.Error(fmt.Sprintf("Failed to update zone %s: %v", zoneID, err))
-			tx.Rollback(ctx)
-			w.restoreDeltas(deltas)
-			return
-		}
-	}
+	w.log.Info(fmt.Sprintf("Flushing batch with %d zone updates", len(changes)))
 
-	// Commit transaction
-	if err := tx.Commit(ctx); err != nil {
-		w.log.Error(fmt.Sprintf("Failed to commit transaction: %v", err))
+	if err := w.updateZoneInventory(ctx, changes); err != nil {
+		w.log.Error(fmt.Sprintf("Failed to update zone inventory: %v", err))
+		// Put deltas back for retry
 		w.restoreDeltas(deltas)
 		return
 	}
 
-	w.log.Info(fmt.Sprintf("Successfully synced %d zones to PostgreSQL", len(deltas)))
+	w.log.Info(fmt.Sprintf("Successfully synced %d zones to PostgreSQL", len(changes)))
 }
 
-// updateZoneInventory updates a single zone's inventory in PostgreSQL
-func (w *InventoryWorker) updateZoneInventory(ctx context.Context, tx pgx.Tx, delta *ZoneInventoryDelta) error {
-	// Calculate net changes:
-	// - ReservedDelta: seats that got reserved (decrease available)
-	// - ConfirmedDelta: seats that moved from reserved to sold
-	// - CancelledDelta: seats that got released (increase available)
+// zoneInventoryChange is the net change of a zone's seat_zones counters
+type zoneInventoryChange struct {
+	ZoneID    string
+	Available int
+	Reserved  int
+	Sold      int
+}
 
-	// available_seats change: -reserved + cancelled
-	// reserved_seats change: +reserved - confirmed - cancelled
-	// sold_seats change: +confirmed
+// zoneInventoryChanges nets out each zone's delta, dropping zones left unchanged.
+// Changes are ordered by zone so concurrent flushes lock rows in the same order.
+func zoneInventoryChanges(deltas map[string]*ZoneInventoryDelta) []zoneInventoryChange {
+	changes := make([]zoneInventoryChange, 0, len(deltas))
+	for zoneID, delta := range deltas {
+		// - ReservedDelta: seats that got reserved (decrease available)
+		// - ConfirmedDelta: seats that moved from reserved to sold
+		// - CancelledDelta: seats that got released (increase available)
+		change := zoneInventoryChange{
+			ZoneID:    zoneID,
+			Available: -delta.ReservedDelta + delta.CancelledDelta,
+			Reserved:  delta.ReservedDelta - delta.ConfirmedDelta - delta.CancelledDelta,
+			Sold:      delta.ConfirmedDelta,
+		}
+		if zoneID == "" || (change.Available == 0 && change.Reserved == 0 && change.Sold == 0) {
+			continue
+		}
+		changes = append(changes, change)
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].ZoneID < changes[j].ZoneID })
+	return changes
+}
 
-	availableChange := -delta.ReservedDelta + delta.CancelledDelta
-	reservedChange := delta.ReservedDelta - delta.ConfirmedDelta - delta.CancelledDelta
-	soldChange := delta.ConfirmedDelta
+// updateZoneInventory applies the batch's zone changes to PostgreSQL in one statement
+func (w *InventoryWorker) updateZoneInventory(ctx context.Context, changes []zoneInventoryChange) error {
+	zoneIDs := make([]string, len(changes))
+	available := make([]int32, len(changes))
+	reserved := make([]int32, len(changes))
+	sold := make([]int32, len(changes))
+	for i, change := range changes {
+		zoneIDs[i] = change.ZoneID
+		available[i] = int32(change.Available)
+		reserved[i] = int32(change.Reserved)
+		sold[i] = int32(change.Sold)
+	}
 
 	query := `
-		UPDATE seat_zones
+		UPDATE seat_zones AS z
 		SET
-			available_seats = available_seats + $1,
-			reserved_seats = reserved_seats + $2,
-			sold_seats = sold_seats + $3,
+			available_seats = z.available_seats + d.available,
+			reserved_seats = z.reserved_seats + d.reserved,
+			sold_seats = z.sold_seats + d.sold,
 			updated_at = NOW()
-		WHERE id = $4
+		FROM unnest($1::uuid[], $2::int[], $3::int[], $4::int[]) AS d(id, available, reserved, sold)
+		WHERE z.id = d.id
 	`
 
-	_, err := tx.Exec(ctx, query, availableChange, reservedChange, soldChange, delta.ZoneID)
+	_, err := w.db.Pool().Exec(ctx, query, zoneIDs, available, reserved, sold)
 	return err
 }
 
@@ -278,6 +410,192 @@ func (w *InventoryWorker) restoreDeltas(deltas map[string]*ZoneInventoryDelta) {
 	}
 }
 
+// processCapacityRecord applies a zone capacity change and records its outcome
+func (w *InventoryWorker) processCapacityRecord(ctx context.Context, record *kafka.Record) error {
+	var event domain.ZoneCapacityChangedEvent
+	if err := json.Unmarshal(record.Value, &event); err != nil {
+		return fmt.Errorf("failed to unmarshal capacity event: %w", err)
+	}
+
+	if event.EventID == "" || event.ZoneID == "" {
+		return fmt.Errorf("capacity event is missing event_id or zone_id")
+	}
+
+	status, err := w.applyCapacityChange(ctx, &event)
+	if err != nil {
+		return err
+	}
+	if status == nil {
+		// Redelivered event, outcome already recorded
+		return nil
+	}
+
+	if status.Status == domain.ZoneCapacityChangeApplied {
+		w.log.Info(fmt.Sprintf("Zone %s capacity changed %d -> %d (available: %d)",
+			event.ZoneID, event.PreviousTotal, event.NewTotal, status.AvailableSeats))
+	} else {
+		w.log.Warn(fmt.Sprintf("Zone %s capacity change %d -> %d rejected: %s",
+			event.ZoneID, event.PreviousTotal, event.NewTotal, status.Reason))
+	}
+
+	return w.capacityRepo.SaveStatus(ctx, status)
+}
+
+// applyCapacityChange adjusts Redis first, since the live counter is the only place that
+// reflects in-flight holds, then persists the new capacity to seat_zones.
+// Returns nil status for redelivered events.
+func (w *InventoryWorker) applyCapacityChange(ctx context.Context, event *domain.ZoneCapacityChangedEvent) (*domain.ZoneCapacityStatus, error) {
+	status := &domain.ZoneCapacityStatus{
+		EventID:        event.EventID,
+		ZoneID:         event.ZoneID,
+		PreviousTotal:  event.PreviousTotal,
+		RequestedTotal: event.NewTotal,
+		UpdatedAt:      time.Now(),
+	}
+
+	result, err := w.capacityRepo.ApplyCapacityChange(ctx, repository.ApplyCapacityChangeParams{
+		EventID: event.EventID,
+		ZoneID:  event.ZoneID,
+		Delta:   event.Delta(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply capacity change for zone %s: %w", event.ZoneID, err)
+	}
+
+	if !result.Success {
+		switch result.ErrorCode {
+		case "DUPLICATE_EVENT":
+			return nil, nil
+		case "BELOW_COMMITTED":
+			committed := int64(event.PreviousTotal) - result.AvailableSeats
+			status.Status = domain.ZoneCapacityChangeRejected
+			status.AvailableSeats = int(result.AvailableSeats)
+			status.Reason = fmt.Sprintf("%d seats are sold or held, requested capacity is %d", committed, event.NewTotal)
+			return status, nil
+		case "ZONE_NOT_LIVE":
+			// Sales stopped since the change was requested - no holds to protect,
+			// seat_zones alone is authoritative
+			applied, err := w.updateZoneCapacity(ctx, event)
+			if err != nil {
+				return nil, fmt.Errorf("failed to update capacity for zone %s: %w", event.ZoneID, err)
+			}
+			if !applied {
+				status.Status = domain.ZoneCapacityChangeRejected
+				status.Reason = "zone capacity changed concurrently or is below sold and held seats"
+				return status, nil
+			}
+			status.Status = domain.ZoneCapacityChangeApplied
+			return status, nil
+		default:
+			return nil, fmt.Errorf("failed to apply capacity change for zone %s: %s", event.ZoneID, result.ErrorMessage)
+		}
+	}
+
+	// Pending booking deltas must land first so the reduced available_seats stays non-negative
+	w.flushBatch(ctx)
+
+	applied, err := w.updateZoneCapacity(ctx, event)
+	if err != nil || !applied {
+		reason := "zone capacity changed concurrently or is below sold and held seats"
+		if err != nil {
+			w.log.Error(fmt.Sprintf("Failed to persist capacity change for zone %s: %v", event.ZoneID, err))
+			reason = "failed to persist capacity change"
+		}
+		w.revertCapacityChange(ctx, event)
+		status.Status = domain.ZoneCapacityChangeRejected
+		status.AvailableSeats = int(result.AvailableSeats) - event.Delta()
+		status.Reason = reason
+		return status, nil
+	}
+
+	status.Status = domain.ZoneCapacityChangeApplied
+	status.AvailableSeats = int(result.AvailableSeats)
+	return status, nil
+}
+
+// updateZoneCapacity persists a capacity change to seat_zones. The previous total guards
+// against stale events; returns false if the row did not match or the change would drop
+// below sold and reserved seats.
+func (w *InventoryWorker) updateZoneCapacity(ctx context.Context, event *domain.ZoneCapacityChangedEvent) (bool, error) {
+	query := `
+		UPDATE seat_zones
+		SET
+			total_seats = $3,
+			available_seats = available_seats + $4,
+			updated_at = NOW()
+		WHERE id = $1
+			AND total_seats = $2
+			AND $3 >= sold_seats + reserved_seats
+			AND deleted_at IS NULL
+	`
+
+	result, err := w.db.Pool().Exec(ctx, query, event.ZoneID, event.PreviousTotal, event.NewTotal, event.Delta())
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// revertCapacityChange undoes the Redis adjustment when seat_zones could not be updated
+func (w *InventoryWorker) revertCapacityChange(ctx context.Context, event *domain.ZoneCapacityChangedEvent) {
+	result, err := w.capacityRepo.ApplyCapacityChange(ctx, repository.ApplyCapacityChangeParams{
+		EventID: event.EventID + ":revert",
+		ZoneID:  event.ZoneID,
+		Delta:   -event.Delta(),
+	})
+	if err != nil {
+		w.log.Error(fmt.Sprintf("Failed to revert capacity change for zone %s: %v", event.ZoneID, err))
+		return
+	}
+	if !result.Success {
+		// Seats added by the change were already reserved; the next rebuild reconciles
+		w.log.Error(fmt.Sprintf("Failed to revert capacity change for zone %s: %s", event.ZoneID, result.ErrorCode))
+	}
+}
+
+// processZoneRecord seeds a created or updated zone in Redis so its first reservation does
+// not have to fetch the zone from ticket-service
+func (w *InventoryWorker) processZoneRecord(ctx context.Context, record *kafka.Record) error {
+	var event domain.ZoneEvent
+	if err := json.Unmarshal(record.Value, &event); err != nil {
+		return fmt.Errorf("failed to unmarshal zone event: %w", err)
+	}
+
+	if event.ZoneID == "" {
+		return fmt.Errorf("zone event is missing zone_id")
+	}
+
+	// Booking-service instances drop the zone's cached price and their cached show zone list.
+	// Best effort: the catalog's TTLs bound how long a missed invalidation is served.
+	if err := service.InvalidateZoneCatalog(ctx, w.redis, event.ZoneID, event.ShowID); err != nil {
+		w.log.Warn(fmt.Sprintf("Failed to invalidate cached zone %s: %v", event.ZoneID, err))
+	}
+
+	if !event.IsActive {
+		// Inactive zones are not rebuilt either, so nothing may be reserved against them
+		if err := w.capacityRepo.RemoveZone(ctx, event.ZoneID); err != nil {
+			return fmt.Errorf("failed to remove zone %s: %w", event.ZoneID, err)
+		}
+		return nil
+	}
+
+	// Holds are only possible once the show is on sale; before that seat_zones is authoritative
+	seeded, err := w.capacityRepo.SeedZone(ctx, repository.SeedZoneParams{
+		ZoneID:         event.ZoneID,
+		AvailableSeats: event.AvailableSeats,
+		HoldTTLSeconds: event.HoldTTLSeconds,
+		Overwrite:      !event.OnSale,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to seed zone %s: %w", event.ZoneID, err)
+	}
+
+	if seeded {
+		w.log.Info(fmt.Sprintf("Zone %s seeded on %s (available: %d)", event.ZoneID, event.EventType, event.AvailableSeats))
+	}
+	return nil
+}
+
 // RebuildRedisFromDB rebuilds Redis inventory from PostgreSQL
 func (w *InventoryWorker) RebuildRedisFromDB(ctx context.Context) error {
 	w.log.Info("Starting Redis rebuild from PostgreSQL...")
@@ -305,10 +623,9 @@ func (w *InventoryWorker) RebuildRedisFromDB(ctx context.Context) error {
 			continue
 		}
 
-		// Set zone availability in Redis
-		key := fmt.Sprintf("zone:availability:%s", zoneID)
-		if err := w.redis.Set(ctx, key, availableSeats, 0).Err(); err != nil {
-			w.log.Error(fmt.Sprintf("Failed to set Redis key %s: %v", key, err))
+		// Set zone availability in Redis; sharded zones rebalance from the primary counter
+		if err := w.shardRepo.ResetAvailability(ctx, zoneID, availableSeats); err != nil {
+			w.log.Error(fmt.Sprintf("Failed to set availability of zone %s: %v", zoneID, err))
 			continue
 		}
 
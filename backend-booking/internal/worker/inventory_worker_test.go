@@ -1,12 +1,16 @@
 package worker
 
 import (
+	"context"
 	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
 )
 
 func TestAggregateDelta_BookingCreated(t *testing.T) {
@@ -135,6 +139,42 @@ func TestAggregateDelta_BookingExpired(t *testing.T) {
 	}
 }
 
+func TestAggregateDelta_BookingModified(t *testing.T) {
+	worker := &InventoryWorker{
+		config: &InventoryWorkerConfig{
+			BatchInterval: 5 * time.Second,
+			MaxBatchSize:  100,
+		},
+		deltas: make(map[string]*ZoneInventoryDelta),
+	}
+
+	// A confirmed booking of 2 seats in zone-1 moved to 3 seats in zone-2
+	event := &domain.BookingEvent{
+		EventType: domain.BookingEventModified,
+		BookingData: &domain.BookingEventData{
+			ZoneID:           "zone-2",
+			Quantity:         3,
+			Status:           string(domain.BookingStatusConfirmed),
+			PreviousZoneID:   "zone-1",
+			PreviousQuantity: 2,
+		},
+	}
+
+	worker.aggregateDelta(event)
+
+	previous, current := worker.deltas["zone-1"], worker.deltas["zone-2"]
+	if previous == nil || current == nil {
+		t.Fatalf("Expected deltas for both zones, got %v", worker.deltas)
+	}
+	// Available +2 and sold -2 in the old zone, available -3 and sold +3 in the new one
+	if previous.ReservedDelta != -2 || previous.ConfirmedDelta != -2 {
+		t.Errorf("Expected zone-1 ReservedDelta=-2 ConfirmedDelta=-2, got %+v", previous)
+	}
+	if current.ReservedDelta != 3 || current.ConfirmedDelta != 3 {
+		t.Errorf("Expected zone-2 ReservedDelta=3 ConfirmedDelta=3, got %+v", current)
+	}
+}
+
 func TestAggregateDelta_MultipleEventsForSameZone(t *testing.T) {
 	worker := &InventoryWorker{
 		config: &InventoryWorkerConfig{
@@ -460,3 +500,313 @@ func TestInventoryDeltaCalculation(t *testing.T) {
 			finalAvailable, finalReserved, finalSold, total)
 	}
 }
+
+// mockZoneCapacityRepository is a mock implementation of ZoneCapacityRepository
+type mockZoneCapacityRepository struct {
+	result   *repository.ApplyCapacityChangeResult
+	applied  []repository.ApplyCapacityChangeParams
+	statuses []*domain.ZoneCapacityStatus
+	seeded   []repository.SeedZoneParams
+	removed  []string
+}
+
+func (m *mockZoneCapacityRepository) ApplyCapacityChange(ctx context.Context, params repository.ApplyCapacityChangeParams) (*repository.ApplyCapacityChangeResult, error) {
+	m.applied = append(m.applied, params)
+	return m.result, nil
+}
+
+func (m *mockZoneCapacityRepository) SaveStatus(ctx context.Context, status *domain.ZoneCapacityStatus) error {
+	m.statuses = append(m.statuses, status)
+	return nil
+}
+
+func (m *mockZoneCapacityRepository) SeedZone(ctx context.Context, params repository.SeedZoneParams) (bool, error) {
+	m.seeded = append(m.seeded, params)
+	return true, nil
+}
+
+func (m *mockZoneCapacityRepository) RemoveZone(ctx context.Context, zoneID string) error {
+	m.removed = append(m.removed, zoneID)
+	return nil
+}
+
+func newCapacityRecord(t *testing.T, event *domain.ZoneCapacityChangedEvent) *kafka.Record {
+	t.Helper()
+	value, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("failed to marshal event: %v", err)
+	}
+	return &kafka.Record{Topic: "zone-capacity-events", Value: value}
+}
+
+func TestProcessCapacityRecord_BelowCommitted(t *testing.T) {
+	repo := &mockZoneCapacityRepository{
+		result: &repository.ApplyCapacityChangeResult{ErrorCode: "BELOW_COMMITTED", AvailableSeats: 5},
+	}
+	worker := &InventoryWorker{
+		config:       &InventoryWorkerConfig{CapacityTopic: "zone-capacity-events"},
+		capacityRepo: repo,
+		log:          logger.Get(),
+		deltas:       make(map[string]*ZoneInventoryDelta),
+	}
+
+	// 95 seats are sold or held, so reducing 100 -> 60 must be refused
+	worker.processRecords(context.Background(), []*kafka.Record{newCapacityRecord(t, &domain.ZoneCapacityChangedEvent{
+		EventID:       "cap-1",
+		ZoneID:        "zone-1",
+		PreviousTotal: 100,
+		NewTotal:      60,
+	})})
+
+	if len(repo.applied) != 1 || repo.applied[0].Delta != -40 {
+		t.Fatalf("Expected one apply with delta -40, got %+v", repo.applied)
+	}
+	if len(repo.statuses) != 1 {
+		t.Fatalf("Expected 1 saved status, got %d", len(repo.statuses))
+	}
+	status := repo.statuses[0]
+	if status.Status != domain.ZoneCapacityChangeRejected {
+		t.Errorf("Expected status rejected, got %s", status.Status)
+	}
+	if !strings.Contains(status.Reason, "95 seats are sold or held") {
+		t.Errorf("Expected reason to report committed seats, got %q", status.Reason)
+	}
+	if status.AvailableSeats != 5 || status.RequestedTotal != 60 {
+		t.Errorf("Expected available=5 requested=60, got available=%d requested=%d", status.AvailableSeats, status.RequestedTotal)
+	}
+}
+
+func TestProcessCapacityRecord_DuplicateEvent(t *testing.T) {
+	repo := &mockZoneCapacityRepository{
+		result: &repository.ApplyCapacityChangeResult{ErrorCode: "DUPLICATE_EVENT"},
+	}
+	worker := &InventoryWorker{
+		config:       &InventoryWorkerConfig{CapacityTopic: "zone-capacity-events"},
+		capacityRepo: repo,
+		log:          logger.Get(),
+		deltas:       make(map[string]*ZoneInventoryDelta),
+	}
+
+	err := worker.processCapacityRecord(context.Background(), newCapacityRecord(t, &domain.ZoneCapacityChangedEvent{
+		EventID:       "cap-1",
+		ZoneID:        "zone-1",
+		PreviousTotal: 100,
+		NewTotal:      120,
+	}))
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if len(repo.statuses) != 0 {
+		t.Errorf("Expected no status for a redelivered event, got %d", len(repo.statuses))
+	}
+}
+
+func TestProcessCapacityRecord_InvalidEvent(t *testing.T) {
+	worker := &InventoryWorker{
+		config:       &InventoryWorkerConfig{CapacityTopic: "zone-capacity-events"},
+		capacityRepo: &mockZoneCapacityRepository{},
+		deltas:       make(map[string]*ZoneInventoryDelta),
+	}
+
+	if err := worker.processCapacityRecord(context.Background(), &kafka.Record{Value: []byte("invalid json")}); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+	if err := worker.processCapacityRecord(context.Background(), newCapacityRecord(t, &domain.ZoneCapacityChangedEvent{NewTotal: 10})); err == nil {
+		t.Error("Expected error for missing zone_id")
+	}
+}
+
+func newZoneRecord(t *testing.T, event *domain.ZoneEvent) *kafka.Record {
+	t.Helper()
+	value, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("failed to marshal event: %v", err)
+	}
+	return &kafka.Record{
+		Topic:   "zone-capacity-events",
+		Value:   value,
+		Headers: map[string]string{"event_type": event.EventType},
+	}
+}
+
+func TestProcessZoneRecord(t *testing.T) {
+	holdTTL := 300
+	tests := []struct {
+		name          string
+		event         *domain.ZoneEvent
+		wantSeed      bool
+		wantOverwrite bool
+		wantRemove    bool
+	}{
+		{
+			name:     "created zone on sale is seeded without overwriting",
+			event:    &domain.ZoneEvent{EventType: domain.ZoneCreatedEventType, ZoneID: "zone-1", AvailableSeats: 100, HoldTTLSeconds: &holdTTL, IsActive: true, OnSale: true},
+			wantSeed: true,
+		},
+		{
+			name:          "updated zone not on sale overwrites the counter",
+			event:         &domain.ZoneEvent{EventType: domain.ZoneUpdatedEventType, ZoneID: "zone-1", AvailableSeats: 80, IsActive: true},
+			wantSeed:      true,
+			wantOverwrite: true,
+		},
+		{
+			name:       "deactivated zone is removed",
+			event:      &domain.ZoneEvent{EventType: domain.ZoneUpdatedEventType, ZoneID: "zone-1", AvailableSeats: 80, OnSale: true},
+			wantRemove: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockZoneCapacityRepository{}
+			worker := &InventoryWorker{
+				config:       &InventoryWorkerConfig{CapacityTopic: "zone-capacity-events"},
+				capacityRepo: repo,
+				log:          logger.Get(),
+				deltas:       make(map[string]*ZoneInventoryDelta),
+			}
+
+			worker.processRecords(context.Background(), []*kafka.Record{newZoneRecord(t, tt.event)})
+
+			if len(repo.applied) != 0 || len(repo.statuses) != 0 {
+				t.Errorf("Expected zone event not to be applied as a capacity change")
+			}
+			if tt.wantRemove != (len(repo.removed) == 1) {
+				t.Errorf("Expected remove=%v, got %v", tt.wantRemove, repo.removed)
+			}
+			if !tt.wantSeed {
+				if len(repo.seeded) != 0 {
+					t.Errorf("Expected no seed, got %+v", repo.seeded)
+				}
+				return
+			}
+			if len(repo.seeded) != 1 {
+				t.Fatalf("Expected 1 seed, got %d", len(repo.seeded))
+			}
+			seed := repo.seeded[0]
+			if seed.ZoneID != tt.event.ZoneID || seed.AvailableSeats != tt.event.AvailableSeats || seed.Overwrite != tt.wantOverwrite {
+				t.Errorf("Unexpected seed params: %+v", seed)
+			}
+			if (seed.HoldTTLSeconds == nil) != (tt.event.HoldTTLSeconds == nil) {
+				t.Errorf("Expected hold TTL %v, got %v", tt.event.HoldTTLSeconds, seed.HoldTTLSeconds)
+			}
+		})
+	}
+}
+
+func TestProcessZoneRecord_InvalidEvent(t *testing.T) {
+	repo := &mockZoneCapacityRepository{}
+	worker := &InventoryWorker{
+		config:       &InventoryWorkerConfig{CapacityTopic: "zone-capacity-events"},
+		capacityRepo: repo,
+		deltas:       make(map[string]*ZoneInventoryDelta),
+	}
+
+	if err := worker.processZoneRecord(context.Background(), &kafka.Record{Value: []byte("invalid json")}); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+	if err := worker.processZoneRecord(context.Background(), newZoneRecord(t, &domain.ZoneEvent{EventType: domain.ZoneCreatedEventType, IsActive: true})); err == nil {
+		t.Error("Expected error for missing zone_id")
+	}
+	if len(repo.seeded) != 0 {
+		t.Errorf("Expected no seed for invalid events, got %d", len(repo.seeded))
+	}
+}
+
+func newBookingRecord(t *testing.T, event *domain.BookingEvent, withHeader bool) *kafka.Record {
+	t.Helper()
+	value, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("failed to marshal event: %v", err)
+	}
+	record := &kafka.Record{Topic: "booking-events", Value: value}
+	if withHeader {
+		record.Headers = map[string]string{"event_type": string(event.EventType)}
+	}
+	return record
+}
+
+func TestProcessRecords_RoutesByEventType(t *testing.T) {
+	worker := &InventoryWorker{
+		config: &InventoryWorkerConfig{BookingTopic: "booking-events", CapacityTopic: "zone-capacity-events"},
+		log:    logger.Get(),
+		deltas: make(map[string]*ZoneInventoryDelta),
+	}
+
+	worker.processRecords(context.Background(), []*kafka.Record{
+		newBookingRecord(t, &domain.BookingEvent{
+			EventType:   domain.BookingEventCreated,
+			BookingData: &domain.BookingEventData{ZoneID: "zone-1", Quantity: 2},
+		}, true),
+		// Published without the header, routed by the payload's event_type
+		newBookingRecord(t, &domain.BookingEvent{
+			EventType:   domain.BookingEventConfirmed,
+			BookingData: &domain.BookingEventData{ZoneID: "zone-1", Quantity: 2},
+		}, false),
+		// Event types that do not move inventory are skipped without being decoded
+		{Topic: "booking-events", Value: []byte("not json"), Headers: map[string]string{"event_type": "booking.reminder"}},
+		{Topic: "other-events", Value: []byte("not json")},
+	})
+
+	if len(worker.deltas) != 1 {
+		t.Fatalf("Expected 1 delta, got %d", len(worker.deltas))
+	}
+	delta := worker.deltas["zone-1"]
+	if delta.ReservedDelta != 2 || delta.ConfirmedDelta != 2 {
+		t.Errorf("Expected reserved=2 confirmed=2, got %+v", delta)
+	}
+
+	if worker.handlerFor(&kafka.Record{Topic: "booking-events", Headers: map[string]string{"event_type": "booking.reminder"}}) != nil {
+		t.Error("Expected no handler for an event type the worker does not consume")
+	}
+}
+
+func TestHandle_RegistersEventTypeHandler(t *testing.T) {
+	worker := &InventoryWorker{
+		config: &InventoryWorkerConfig{BookingTopic: "booking-events", CapacityTopic: "zone-capacity-events"},
+		log:    logger.Get(),
+		deltas: make(map[string]*ZoneInventoryDelta),
+	}
+
+	var handled []string
+	worker.Handle("booking-events", "booking.reminder", func(ctx context.Context, record *kafka.Record) error {
+		handled = append(handled, record.Headers["event_type"])
+		return nil
+	})
+
+	worker.processRecords(context.Background(), []*kafka.Record{
+		{Topic: "booking-events", Value: []byte("{}"), Headers: map[string]string{"event_type": "booking.reminder"}},
+		newBookingRecord(t, &domain.BookingEvent{
+			EventType:   domain.BookingEventCreated,
+			BookingData: &domain.BookingEventData{ZoneID: "zone-1", Quantity: 1},
+		}, true),
+	})
+
+	if len(handled) != 1 {
+		t.Errorf("Expected registered handler to run once, got %d", len(handled))
+	}
+	// Default handlers stay registered alongside
+	if worker.deltas["zone-1"] == nil || worker.deltas["zone-1"].ReservedDelta != 1 {
+		t.Errorf("Expected booking.created to still be aggregated, got %+v", worker.deltas)
+	}
+}
+
+func TestZoneInventoryChanges(t *testing.T) {
+	changes := zoneInventoryChanges(map[string]*ZoneInventoryDelta{
+		"zone-b": {ZoneID: "zone-b", ReservedDelta: 3, ConfirmedDelta: 1},
+		"zone-a": {ZoneID: "zone-a", ReservedDelta: 2, CancelledDelta: 1},
+		// Holds that expired within the batch leave the zone unchanged
+		"zone-c": {ZoneID: "zone-c", ReservedDelta: 2, CancelledDelta: 2},
+		"":       {ReservedDelta: 1},
+	})
+
+	if len(changes) != 2 {
+		t.Fatalf("Expected 2 changes, got %+v", changes)
+	}
+	if changes[0] != (zoneInventoryChange{ZoneID: "zone-a", Available: -1, Reserved: 1}) {
+		t.Errorf("Unexpected change for zone-a: %+v", changes[0])
+	}
+	if changes[1] != (zoneInventoryChange{ZoneID: "zone-b", Available: -3, Reserved: 2, Sold: 1}) {
+		t.Errorf("Unexpected change for zone-b: %+v", changes[1])
+	}
+}
@@ -0,0 +1,134 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+)
+
+// PersistWorkerConfig contains configuration for the persist worker
+type PersistWorkerConfig struct {
+	// Concurrency is the number of bookings persisted in parallel
+	Concurrency int
+	// PollWait is how long each loop blocks waiting for a queued booking
+	PollWait time.Duration
+	// ErrorBackoff is the pause after a failed attempt
+	ErrorBackoff time.Duration
+}
+
+// DefaultPersistWorkerConfig returns default configuration
+func DefaultPersistWorkerConfig() *PersistWorkerConfig {
+	return &PersistWorkerConfig{
+		Concurrency:  4,
+		PollWait:     time.Second,
+		ErrorBackoff: time.Second,
+	}
+}
+
+// PersistWorker drains the write-behind queue of reserved bookings into PostgreSQL
+type PersistWorker struct {
+	writeBehind service.WriteBehindService
+	config      *PersistWorkerConfig
+	log         *logger.Logger
+	stopCh      chan struct{}
+	wg          sync.WaitGroup
+	mu          sync.Mutex
+	running     bool
+}
+
+// NewPersistWorker creates a new persist worker
+func NewPersistWorker(writeBehind service.WriteBehindService, config *PersistWorkerConfig) *PersistWorker {
+	defaults := DefaultPersistWorkerConfig()
+	if config == nil {
+		config = defaults
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = defaults.Concurrency
+	}
+	if config.PollWait <= 0 {
+		config.PollWait = defaults.PollWait
+	}
+	if config.ErrorBackoff <= 0 {
+		config.ErrorBackoff = defaults.ErrorBackoff
+	}
+
+	return &PersistWorker{
+		writeBehind: writeBehind,
+		config:      config,
+		log:         logger.Get(),
+		stopCh:      make(chan struct{}),
+	}
+}
+
+// Start requeues bookings a previous run left in flight and starts the persist worker
+func (w *PersistWorker) Start(ctx context.Context) error {
+	w.mu.Lock()
+	if w.running {
+		w.mu.Unlock()
+		return fmt.Errorf("persist worker already running")
+	}
+	w.running = true
+	w.mu.Unlock()
+
+	if recovered, err := w.writeBehind.RecoverInFlight(ctx); err != nil {
+		w.log.Error(fmt.Sprintf("Failed to recover in-flight bookings: %v", err))
+	} else if recovered > 0 {
+		w.log.Info(fmt.Sprintf("Requeued %d in-flight bookings", recovered))
+	}
+
+	w.log.Info(fmt.Sprintf("Starting persist worker with %d goroutines", w.config.Concurrency))
+
+	for i := 0; i < w.config.Concurrency; i++ {
+		w.wg.Add(1)
+		go w.run(ctx)
+	}
+
+	return nil
+}
+
+// Stop stops the persist worker, waiting for in-flight bookings to be stored
+func (w *PersistWorker) Stop() {
+	w.mu.Lock()
+	if !w.running {
+		w.mu.Unlock()
+		return
+	}
+	w.running = false
+	w.mu.Unlock()
+
+	w.log.Info("Stopping persist worker")
+	close(w.stopCh)
+	w.wg.Wait()
+	w.log.Info("Persist worker stopped")
+}
+
+// run persists queued bookings until stopped
+func (w *PersistWorker) run(ctx context.Context) {
+	defer w.wg.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.stopCh:
+			return
+		default:
+		}
+
+		// Bookings in flight finish on the background context so Stop does not strand them
+		if _, err := w.writeBehind.ProcessNext(context.WithoutCancel(ctx), w.config.PollWait); err != nil {
+			w.log.Error(fmt.Sprintf("Failed to persist reserved booking: %v", err))
+			select {
+			case <-ctx.Done():
+				return
+			case <-w.stopCh:
+				return
+			case <-time.After(w.config.ErrorBackoff):
+			}
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+)
+
+// ErrPushQueueFull is returned when a notification is dropped because the queue is full
+var ErrPushQueueFull = errors.New("push notification queue full")
+
+// PushWorkerConfig holds configuration for the push worker
+type PushWorkerConfig struct {
+	Workers   int           // Notifications delivered concurrently (default: 8)
+	QueueSize int           // Notifications waiting for a worker before new ones are dropped (default: 10000)
+	Timeout   time.Duration // Upper bound on delivering one notification, retries included (default: 15s)
+}
+
+// DefaultPushWorkerConfig returns default configuration
+func DefaultPushWorkerConfig() *PushWorkerConfig {
+	return &PushWorkerConfig{
+		Workers:   8,
+		QueueSize: 10000,
+		Timeout:   15 * time.Second,
+	}
+}
+
+// pushJob is a queued notification
+type pushJob struct {
+	userID       string
+	notification *service.PushNotification
+}
+
+// PushWorker delivers push notifications in the background so queue releases and saga
+// steps never wait on device lookups or provider retries. It implements service.PushNotifier.
+type PushWorker struct {
+	notifier service.PushNotifier
+	config   *PushWorkerConfig
+	jobs     chan pushJob
+	log      *logger.Logger
+	wg       sync.WaitGroup
+
+	// Stats
+	mu        sync.Mutex
+	delivered int64
+	failed    int64
+	dropped   int64
+}
+
+// NewPushWorker creates a new push worker delivering through notifier
+func NewPushWorker(notifier service.PushNotifier, config *PushWorkerConfig, log *logger.Logger) *PushWorker {
+	if config == nil {
+		config = DefaultPushWorkerConfig()
+	}
+	if config.Workers <= 0 {
+		config.Workers = 8
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = 10000
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 15 * time.Second
+	}
+
+	return &PushWorker{
+		notifier: notifier,
+		config:   config,
+		jobs:     make(chan pushJob, config.QueueSize),
+		log:      log,
+	}
+}
+
+// Start starts the delivery goroutines; they stop when ctx is cancelled
+func (w *PushWorker) Start(ctx context.Context) {
+	for i := 0; i < w.config.Workers; i++ {
+		w.wg.Add(1)
+		go w.run(ctx)
+	}
+	w.log.Info(fmt.Sprintf("Push worker started with %d workers", w.config.Workers))
+}
+
+// Wait blocks until the delivery goroutines have stopped
+func (w *PushWorker) Wait() {
+	w.wg.Wait()
+}
+
+// Notify queues a notification without blocking
+func (w *PushWorker) Notify(ctx context.Context, userID string, notification *service.PushNotification) error {
+	select {
+	case w.jobs <- pushJob{userID: userID, notification: notification}:
+		return nil
+	default:
+		w.mu.Lock()
+		w.dropped++
+		w.mu.Unlock()
+		return ErrPushQueueFull
+	}
+}
+
+func (w *PushWorker) run(ctx context.Context) {
+	defer w.wg.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-w.jobs:
+			w.deliver(ctx, job)
+		}
+	}
+}
+
+func (w *PushWorker) deliver(ctx context.Context, job pushJob) {
+	ctx, cancel := context.WithTimeout(ctx, w.config.Timeout)
+	defer cancel()
+
+	err := w.notifier.Notify(ctx, job.userID, job.notification)
+
+	w.mu.Lock()
+	if err != nil {
+		w.failed++
+	} else {
+		w.delivered++
+	}
+	w.mu.Unlock()
+
+	if err != nil {
+		w.log.Warn(fmt.Sprintf("Push notification %s to user %s failed: %v", job.notification.Template, job.userID, err))
+	}
+}
+
+// GetStats returns worker statistics
+func (w *PushWorker) GetStats() *PushWorkerStats {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return &PushWorkerStats{
+		Queued:    len(w.jobs),
+		Delivered: w.delivered,
+		Failed:    w.failed,
+		Dropped:   w.dropped,
+	}
+}
+
+// PushWorkerStats contains worker statistics
+type PushWorkerStats struct {
+	Queued    int   `json:"queued"`
+	Delivered int64 `json:"delivered"`
+	Failed    int64 `json:"failed"`
+	Dropped   int64 `json:"dropped"`
+}
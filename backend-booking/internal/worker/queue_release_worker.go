@@ -9,9 +9,11 @@ import (
 	"sync"
 	"time"
 
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/metrics"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/events"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
 )
@@ -26,6 +28,20 @@ type QueueReleaseWorkerConfig struct {
 	DefaultMaxConcurrent int
 	// DefaultQueuePassTTL is used when event config is not set (default: 5 minutes)
 	DefaultQueuePassTTL time.Duration
+	// MaxQueuePassTTL caps every pass TTL, keeping stateless passes short-lived (0 = no cap)
+	MaxQueuePassTTL time.Duration
+	// PushNotifier tells released users on mobile that it is their turn (optional)
+	PushNotifier service.PushNotifier
+	// Analytics counts admitted users and samples queue depth for queue funnels (optional)
+	Analytics service.QueueAnalyticsRecorder
+	// Leases give one replica at a time ownership of each event's releases, so several
+	// workers can run without double-releasing (optional; nil releases every event)
+	Leases repository.QueueReleaseLeaseRepository
+	// LeaseTTL is how long an unrenewed lease lasts, and so how soon another replica takes
+	// over the events of a dead one (default: 5 release intervals)
+	LeaseTTL time.Duration
+	// ReplicaName prefixes this worker's lease owner token, e.g. the pod name (optional)
+	ReplicaName string
 }
 
 // DefaultQueueReleaseWorkerConfig returns default configuration
@@ -39,6 +55,13 @@ func DefaultQueueReleaseWorkerConfig() *QueueReleaseWorkerConfig {
 	}
 }
 
+// Queue release lease changes, as recorded in metrics
+const (
+	QueueReleaseLeaseAcquired = "acquired"
+	QueueReleaseLeaseLost     = "lost"
+	QueueReleaseLeaseReleased = "released"
+)
+
 // ReleasedUser represents a user that has been released from the queue
 type ReleasedUser struct {
 	UserID           string
@@ -47,14 +70,6 @@ type ReleasedUser struct {
 	QueuePassExpires time.Time
 }
 
-// QueuePassReadyMessage is published when a user gets a queue pass
-type QueuePassReadyMessage struct {
-	UserID    string `json:"user_id"`
-	EventID   string `json:"event_id"`
-	QueuePass string `json:"queue_pass"`
-	ExpiresAt int64  `json:"expires_at"` // Unix timestamp
-}
-
 // QueueReleaseWorker releases users from the virtual queue in batches
 type QueueReleaseWorker struct {
 	config      *QueueReleaseWorkerConfig
@@ -73,6 +88,11 @@ type QueueReleaseWorker struct {
 	configCacheMu   sync.RWMutex
 	configCacheTTL  time.Duration
 	configCacheTime map[string]time.Time
+
+	// Events whose release lease this replica holds
+	leaseOwner  string
+	ownedEvents map[string]bool
+	ownedMu     sync.Mutex
 }
 
 // NewQueueReleaseWorker creates a new queue release worker
@@ -97,6 +117,15 @@ func NewQueueReleaseWorker(
 	if cfg.DefaultQueuePassTTL <= 0 {
 		cfg.DefaultQueuePassTTL = time.Duration(domain.DefaultQueuePassTTLMinutes) * time.Minute
 	}
+	if cfg.LeaseTTL <= 0 {
+		cfg.LeaseTTL = 5 * cfg.ReleaseInterval
+	}
+
+	// A fresh token per process, so a restarted replica never mistakes an old lease for its own
+	leaseOwner := generateUniqueID()
+	if cfg.ReplicaName != "" {
+		leaseOwner = cfg.ReplicaName + ":" + leaseOwner
+	}
 
 	return &QueueReleaseWorker{
 		config:          cfg,
@@ -106,6 +135,8 @@ func NewQueueReleaseWorker(
 		configCache:     make(map[string]*repository.EventQueueConfig),
 		configCacheTTL:  30 * time.Second, // Cache config for 30 seconds
 		configCacheTime: make(map[string]time.Time),
+		leaseOwner:      leaseOwner,
+		ownedEvents:     make(map[string]bool),
 	}
 }
 
@@ -121,6 +152,7 @@ func (w *QueueReleaseWorker) Start(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			w.log.Info("Queue release worker stopping...")
+			w.releaseLeases()
 			return
 		case <-ticker.C:
 			w.processAllQueues(ctx)
@@ -137,27 +169,133 @@ func (w *QueueReleaseWorker) processAllQueues(ctx context.Context) {
 		return
 	}
 
+	// Give up leases on events whose queues are gone
+	w.dropLeasesExcept(ctx, eventIDs)
+
 	if len(eventIDs) == 0 {
 		return
 	}
 
-	// Process each queue
+	// Process each queue this replica owns
 	for _, eventID := range eventIDs {
 		select {
 		case <-ctx.Done():
 			return
 		default:
-			w.releaseFromQueue(ctx, eventID)
+			if w.holdsLease(ctx, eventID) {
+				w.releaseFromQueue(ctx, eventID)
+			}
 		}
 	}
 }
 
+// holdsLease takes or renews the release lease of an event, returning whether this replica
+// may release its users. Always true without leases.
+func (w *QueueReleaseWorker) holdsLease(ctx context.Context, eventID string) bool {
+	if w.config.Leases == nil {
+		return true
+	}
+
+	acquired, err := w.config.Leases.Acquire(ctx, eventID, w.leaseOwner, w.config.LeaseTTL)
+	if err != nil {
+		// Without a renewal the lease may already have passed to another replica
+		w.log.Warn(fmt.Sprintf("Failed to renew queue release lease for %s: %v", eventID, err))
+		acquired = false
+	}
+
+	w.ownedMu.Lock()
+	owned := w.ownedEvents[eventID]
+	if acquired {
+		w.ownedEvents[eventID] = true
+	} else {
+		delete(w.ownedEvents, eventID)
+	}
+	w.ownedMu.Unlock()
+
+	switch {
+	case acquired && !owned:
+		w.log.Info(fmt.Sprintf("Acquired queue release lease for %s", eventID))
+		metrics.RecordQueueReleaseLeaseChange(ctx, eventID, QueueReleaseLeaseAcquired)
+	case !acquired && owned:
+		w.log.Warn(fmt.Sprintf("Lost queue release lease for %s", eventID))
+		metrics.RecordQueueReleaseLeaseChange(ctx, eventID, QueueReleaseLeaseLost)
+	}
+	return acquired
+}
+
+// dropLeasesExcept gives up the leases of owned events that are not in eventIDs
+func (w *QueueReleaseWorker) dropLeasesExcept(ctx context.Context, eventIDs []string) {
+	if w.config.Leases == nil {
+		return
+	}
+
+	active := make(map[string]bool, len(eventIDs))
+	for _, eventID := range eventIDs {
+		active[eventID] = true
+	}
+
+	w.ownedMu.Lock()
+	var stale []string
+	for eventID := range w.ownedEvents {
+		if !active[eventID] {
+			stale = append(stale, eventID)
+			delete(w.ownedEvents, eventID)
+		}
+	}
+	w.ownedMu.Unlock()
+
+	for _, eventID := range stale {
+		w.giveUpLease(ctx, eventID)
+	}
+}
+
+// releaseLeases gives up every owned lease on shutdown, so other replicas take over at
+// once instead of waiting for the leases to expire
+func (w *QueueReleaseWorker) releaseLeases() {
+	if w.config.Leases == nil {
+		return
+	}
+
+	w.ownedMu.Lock()
+	owned := w.ownedEvents
+	w.ownedEvents = make(map[string]bool)
+	w.ownedMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	for eventID := range owned {
+		w.giveUpLease(ctx, eventID)
+	}
+}
+
+func (w *QueueReleaseWorker) giveUpLease(ctx context.Context, eventID string) {
+	if err := w.config.Leases.Release(ctx, eventID, w.leaseOwner); err != nil {
+		w.log.Warn(fmt.Sprintf("Failed to give up queue release lease for %s: %v", eventID, err))
+		return
+	}
+	metrics.RecordQueueReleaseLeaseChange(ctx, eventID, QueueReleaseLeaseReleased)
+}
+
+// OwnedEvents returns the events whose release lease this replica holds
+func (w *QueueReleaseWorker) OwnedEvents() []string {
+	w.ownedMu.Lock()
+	defer w.ownedMu.Unlock()
+	eventIDs := make([]string, 0, len(w.ownedEvents))
+	for eventID := range w.ownedEvents {
+		eventIDs = append(eventIDs, eventID)
+	}
+	return eventIDs
+}
+
 // releaseFromQueue releases users from a specific event queue using dynamic capacity
 func (w *QueueReleaseWorker) releaseFromQueue(ctx context.Context, eventID string) {
+	// Sampled every run, including when the queue is at capacity
+	defer w.sampleQueueDepth(ctx, eventID)
+
 	// Get event queue config (cached)
 	config := w.getEventConfig(ctx, eventID)
 	maxConcurrent := config.MaxConcurrentBookings
-	queuePassTTL := time.Duration(config.QueuePassTTLMinutes) * time.Minute
+	queuePassTTL := w.capQueuePassTTL(time.Duration(config.QueuePassTTLMinutes) * time.Minute)
 
 	// Count current active queue passes
 	activeCount, err := w.queueRepo.CountActiveQueuePasses(ctx, eventID)
@@ -191,7 +329,7 @@ func (w *QueueReleaseWorker) releaseFromQueue(ctx context.Context, eventID strin
 	releasedCount := 0
 	ttlSeconds := int(queuePassTTL.Seconds())
 	for _, userID := range userIDs {
-		queuePass, expiresAt, err := w.generateQueuePassWithTTL(userID, eventID, queuePassTTL)
+		queuePass, expiresAt, err := w.generateQueuePassWithTTL(userID, eventID, config.PassZoneIDs, queuePassTTL)
 		if err != nil {
 			w.log.Error(fmt.Sprintf("Failed to generate queue pass for user %s: %v", userID, err))
 			continue
@@ -206,6 +344,7 @@ func (w *QueueReleaseWorker) releaseFromQueue(ctx context.Context, eventID strin
 		// Publish queue pass ready notification via Pub/Sub
 		// This allows SSE clients to receive real-time updates without polling
 		w.publishQueuePassReady(ctx, eventID, userID, queuePass, expiresAt)
+		w.notifyQueueTurn(ctx, eventID, userID, expiresAt)
 
 		releasedCount++
 		w.log.Debug(fmt.Sprintf("Released user %s from queue %s with pass expiring at %v",
@@ -218,6 +357,7 @@ func (w *QueueReleaseWorker) releaseFromQueue(ctx context.Context, eventID strin
 	w.lastReleaseTime = time.Now()
 	w.lastReleaseCount = releasedCount
 	w.mu.Unlock()
+	w.recordAdmitted(ctx, eventID, releasedCount)
 
 	if releasedCount > 0 {
 		w.log.Info(fmt.Sprintf("Successfully released %d/%d users from queue %s",
@@ -265,44 +405,24 @@ func (w *QueueReleaseWorker) getEventConfig(ctx context.Context, eventID string)
 }
 
 // QueuePassClaims represents the claims for a queue pass JWT
-type QueuePassClaims struct {
-	UserID  string `json:"user_id"`
-	EventID string `json:"event_id"`
-	Purpose string `json:"purpose"`
-	jwt.RegisteredClaims
-}
+type QueuePassClaims = service.QueuePassClaims
 
 // generateQueuePassWithTTL generates a signed JWT queue pass token with custom TTL
-func (w *QueueReleaseWorker) generateQueuePassWithTTL(userID, eventID string, ttl time.Duration) (string, time.Time, error) {
-	now := time.Now()
-	expiresAt := now.Add(ttl)
-
-	claims := QueuePassClaims{
-		UserID:  userID,
-		EventID: eventID,
-		Purpose: "queue_pass",
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expiresAt),
-			IssuedAt:  jwt.NewNumericDate(now),
-			NotBefore: jwt.NewNumericDate(now),
-			Issuer:    "queue-release-worker",
-			Subject:   userID,
-			ID:        generateUniqueID(),
-		},
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err := token.SignedString([]byte(w.config.JWTSecret))
-	if err != nil {
-		return "", time.Time{}, fmt.Errorf("failed to sign queue pass: %w", err)
-	}
+func (w *QueueReleaseWorker) generateQueuePassWithTTL(userID, eventID string, zoneIDs []string, ttl time.Duration) (string, time.Time, error) {
+	return service.SignQueuePass(w.config.JWTSecret, "queue-release-worker", userID, eventID, zoneIDs, w.capQueuePassTTL(ttl))
+}
 
-	return signedToken, expiresAt, nil
+// capQueuePassTTL applies MaxQueuePassTTL, so the stored pass and its JWT expire together
+func (w *QueueReleaseWorker) capQueuePassTTL(ttl time.Duration) time.Duration {
+	if w.config.MaxQueuePassTTL > 0 && ttl > w.config.MaxQueuePassTTL {
+		return w.config.MaxQueuePassTTL
+	}
+	return ttl
 }
 
 // generateQueuePass generates a signed JWT queue pass token with default TTL
 func (w *QueueReleaseWorker) generateQueuePass(userID, eventID string) (string, time.Time, error) {
-	return w.generateQueuePassWithTTL(userID, eventID, w.config.DefaultQueuePassTTL)
+	return w.generateQueuePassWithTTL(userID, eventID, nil, w.config.DefaultQueuePassTTL)
 }
 
 // generateUniqueID generates a unique ID for JWT
@@ -326,7 +446,7 @@ func (w *QueueReleaseWorker) ReleaseFromQueueOnce(ctx context.Context, eventID s
 	// Get event queue config (cached)
 	config := w.getEventConfig(ctx, eventID)
 	maxConcurrent := config.MaxConcurrentBookings
-	queuePassTTL := time.Duration(config.QueuePassTTLMinutes) * time.Minute
+	queuePassTTL := w.capQueuePassTTL(time.Duration(config.QueuePassTTLMinutes) * time.Minute)
 
 	// Count current active queue passes
 	activeCount, err := w.queueRepo.CountActiveQueuePasses(ctx, eventID)
@@ -353,7 +473,7 @@ func (w *QueueReleaseWorker) ReleaseFromQueueOnce(ctx context.Context, eventID s
 	var releasedUsers []ReleasedUser
 	ttlSeconds := int(queuePassTTL.Seconds())
 	for _, userID := range userIDs {
-		queuePass, expiresAt, err := w.generateQueuePassWithTTL(userID, eventID, queuePassTTL)
+		queuePass, expiresAt, err := w.generateQueuePassWithTTL(userID, eventID, config.PassZoneIDs, queuePassTTL)
 		if err != nil {
 			continue
 		}
@@ -376,6 +496,7 @@ func (w *QueueReleaseWorker) ReleaseFromQueueOnce(ctx context.Context, eventID s
 	w.lastReleaseTime = time.Now()
 	w.lastReleaseCount = len(releasedUsers)
 	w.mu.Unlock()
+	w.recordAdmitted(ctx, eventID, len(releasedUsers))
 
 	return releasedUsers, nil
 }
@@ -394,25 +515,16 @@ func (w *QueueReleaseWorker) GetDefaultMaxConcurrent() int {
 	return w.config.DefaultMaxConcurrent
 }
 
-// QueuePassChannelKey returns the Redis Pub/Sub channel key for queue pass notifications
-// Format: queue:pass:{event_id}:{user_id} (per-user channel)
-// This provides targeted delivery - each user only receives their own queue pass notification
-// Trade-off: More Redis connections (1 per SSE client) but no broadcast amplification
-func QueuePassChannelKey(eventID, userID string) string {
-	return fmt.Sprintf("queue:pass:%s:%s", eventID, userID)
-}
-
 // publishQueuePassReady publishes a queue pass ready notification via Redis Pub/Sub
 func (w *QueueReleaseWorker) publishQueuePassReady(ctx context.Context, eventID, userID, queuePass string, expiresAt time.Time) {
 	if w.redisClient == nil {
 		return
 	}
 
-	msg := QueuePassReadyMessage{
-		UserID:    userID,
-		EventID:   eventID,
-		QueuePass: queuePass,
-		ExpiresAt: expiresAt.Unix(),
+	msg, err := events.NewQueuePassReadyEvent(eventID, userID, queuePass, expiresAt)
+	if err != nil {
+		w.log.Error(fmt.Sprintf("Failed to build queue pass message: %v", err))
+		return
 	}
 
 	data, err := json.Marshal(msg)
@@ -421,7 +533,7 @@ func (w *QueueReleaseWorker) publishQueuePassReady(ctx context.Context, eventID,
 		return
 	}
 
-	channel := QueuePassChannelKey(eventID, userID)
+	channel := msg.Topic()
 	if err := w.redisClient.Publish(ctx, channel, data).Err(); err != nil {
 		w.log.Error(fmt.Sprintf("Failed to publish queue pass notification for user %s: %v", userID, err))
 		return
@@ -429,3 +541,34 @@ func (w *QueueReleaseWorker) publishQueuePassReady(ctx context.Context, eventID,
 
 	w.log.Debug(fmt.Sprintf("Published queue pass ready for user %s on channel %s", userID, channel))
 }
+
+// recordAdmitted counts users released with a queue pass, when analytics are enabled
+func (w *QueueReleaseWorker) recordAdmitted(ctx context.Context, eventID string, count int) {
+	if w.config.Analytics != nil && count > 0 {
+		w.config.Analytics.RecordStep(ctx, eventID, domain.QueueStepAdmitted, int64(count))
+	}
+}
+
+// sampleQueueDepth records how many users are still waiting, when analytics are enabled
+func (w *QueueReleaseWorker) sampleQueueDepth(ctx context.Context, eventID string) {
+	if w.config.Analytics == nil {
+		return
+	}
+	depth, err := w.queueRepo.GetQueueSize(ctx, eventID)
+	if err != nil {
+		w.log.Warn(fmt.Sprintf("Failed to sample queue depth for %s: %v", eventID, err))
+		return
+	}
+	w.config.Analytics.RecordDepth(ctx, eventID, depth)
+}
+
+// notifyQueueTurn sends a push notification to a released user's devices, for users
+// who left the app while waiting and have no SSE connection
+func (w *QueueReleaseWorker) notifyQueueTurn(ctx context.Context, eventID, userID string, expiresAt time.Time) {
+	if w.config.PushNotifier == nil {
+		return
+	}
+	if err := w.config.PushNotifier.Notify(ctx, userID, service.NewQueueTurnNotification(eventID, expiresAt)); err != nil {
+		w.log.Warn(fmt.Sprintf("Failed to queue push notification for user %s: %v", userID, err))
+	}
+}
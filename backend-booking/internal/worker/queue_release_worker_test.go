@@ -2,11 +2,15 @@ package worker
 
 import (
 	"context"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -332,12 +336,33 @@ func TestQueueReleaseWorker_GenerateQueuePass(t *testing.T) {
 		}
 		worker := NewQueueReleaseWorker(cfg, mockRepo, nil, nil)
 
-		queuePass, expiresAt, err := worker.generateQueuePassWithTTL("user-123", "event-456", 10*time.Minute)
+		queuePass, expiresAt, err := worker.generateQueuePassWithTTL("user-123", "event-456", nil, 10*time.Minute)
 
 		assert.NoError(t, err)
 		assert.NotEmpty(t, queuePass)
 		assert.WithinDuration(t, time.Now().Add(10*time.Minute), expiresAt, time.Second)
 	})
+
+	t.Run("scopes JWT to zones and caps TTL", func(t *testing.T) {
+		mockRepo := new(MockQueueRepository)
+		secret := "test-secret-key"
+		cfg := &QueueReleaseWorkerConfig{
+			JWTSecret:       secret,
+			MaxQueuePassTTL: 2 * time.Minute,
+		}
+		worker := NewQueueReleaseWorker(cfg, mockRepo, nil, nil)
+
+		queuePass, expiresAt, err := worker.generateQueuePassWithTTL("user-123", "event-456", []string{"zone-a"}, 10*time.Minute)
+
+		assert.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(2*time.Minute), expiresAt, time.Second)
+
+		claims, err := service.ParseQueuePass(secret, queuePass)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"zone-a"}, claims.ZoneIDs)
+		assert.True(t, claims.AllowsZone("zone-a"))
+		assert.False(t, claims.AllowsZone("zone-b"))
+	})
 }
 
 func TestQueueReleaseWorker_GetMetrics(t *testing.T) {
@@ -373,6 +398,150 @@ func TestQueueReleaseWorker_GetMetrics(t *testing.T) {
 	assert.Equal(t, 2, lastCount)
 }
 
+// recordingQueueAnalytics records queue funnel steps and depth samples in memory
+type recordingQueueAnalytics struct {
+	steps  map[domain.QueueFunnelStep]int64
+	depths []int64
+}
+
+func (r *recordingQueueAnalytics) RecordStep(ctx context.Context, eventID string, step domain.QueueFunnelStep, count int64) {
+	if r.steps == nil {
+		r.steps = make(map[domain.QueueFunnelStep]int64)
+	}
+	r.steps[step] += count
+}
+
+func (r *recordingQueueAnalytics) RecordDepth(ctx context.Context, eventID string, depth int64) {
+	r.depths = append(r.depths, depth)
+}
+
+func TestQueueReleaseWorker_RecordsAnalytics(t *testing.T) {
+	analytics := &recordingQueueAnalytics{}
+	mockRepo := new(MockQueueRepository)
+	worker := NewQueueReleaseWorker(&QueueReleaseWorkerConfig{
+		DefaultMaxConcurrent: 2,
+		DefaultQueuePassTTL:  5 * time.Minute,
+		JWTSecret:            "test-secret",
+		Analytics:            analytics,
+	}, mockRepo, nil, nil)
+
+	ctx := context.Background()
+	eventID := "event-123"
+	mockRepo.On("GetEventQueueConfig", ctx, eventID).Return(nil, nil)
+	mockRepo.On("CountActiveQueuePasses", ctx, eventID).Return(int64(0), nil).Once()
+	mockRepo.On("PopUsersFromQueue", ctx, eventID, int64(2)).Return([]string{"user-1", "user-2"}, nil)
+	mockRepo.On("StoreQueuePass", ctx, eventID, mock.AnythingOfType("string"), mock.AnythingOfType("string"), 300).Return(nil)
+
+	_, err := worker.ReleaseFromQueueOnce(ctx, eventID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), analytics.steps[domain.QueueStepAdmitted])
+
+	// At capacity nobody is admitted, but the depth is still sampled
+	mockRepo.On("CountActiveQueuePasses", ctx, eventID).Return(int64(2), nil)
+	mockRepo.On("GetQueueSize", ctx, eventID).Return(int64(7), nil)
+
+	worker.releaseFromQueue(ctx, eventID)
+	assert.Equal(t, int64(2), analytics.steps[domain.QueueStepAdmitted])
+	assert.Equal(t, []int64{7}, analytics.depths)
+}
+
+// memoryQueueReleaseLeases is an in-memory QueueReleaseLeaseRepository whose leases
+// only expire when a test expires them
+type memoryQueueReleaseLeases struct {
+	mu     sync.Mutex
+	owners map[string]string
+}
+
+func (m *memoryQueueReleaseLeases) Acquire(ctx context.Context, eventID, owner string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if current, ok := m.owners[eventID]; ok && current != owner {
+		return false, nil
+	}
+	m.owners[eventID] = owner
+	return true, nil
+}
+
+func (m *memoryQueueReleaseLeases) Release(ctx context.Context, eventID, owner string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.owners[eventID] == owner {
+		delete(m.owners, eventID)
+	}
+	return nil
+}
+
+func (m *memoryQueueReleaseLeases) expire(eventID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.owners, eventID)
+}
+
+func TestQueueReleaseWorker_Leases(t *testing.T) {
+	leases := &memoryQueueReleaseLeases{owners: make(map[string]string)}
+	newReplica := func(repo *MockQueueRepository) *QueueReleaseWorker {
+		return NewQueueReleaseWorker(&QueueReleaseWorkerConfig{
+			DefaultMaxConcurrent: 2,
+			DefaultQueuePassTTL:  5 * time.Minute,
+			JWTSecret:            "test-secret",
+			Leases:               leases,
+		}, repo, nil, logger.Get())
+	}
+
+	ctx := context.Background()
+	eventID := "event-123"
+	expectRelease := func(repo *MockQueueRepository) {
+		repo.On("GetAllQueueEventIDs", ctx).Return([]string{eventID}, nil)
+		repo.On("GetEventQueueConfig", ctx, eventID).Return(nil, nil)
+		repo.On("CountActiveQueuePasses", ctx, eventID).Return(int64(0), nil)
+		repo.On("PopUsersFromQueue", ctx, eventID, int64(2)).Return([]string{"user-1"}, nil)
+		repo.On("StoreQueuePass", ctx, eventID, "user-1", mock.AnythingOfType("string"), 300).Return(nil)
+	}
+
+	leaderRepo, standbyRepo := new(MockQueueRepository), new(MockQueueRepository)
+	expectRelease(leaderRepo)
+	expectRelease(standbyRepo)
+	leader, standby := newReplica(leaderRepo), newReplica(standbyRepo)
+
+	// Only the replica holding the lease releases users
+	leader.processAllQueues(ctx)
+	standby.processAllQueues(ctx)
+	leaderRepo.AssertNumberOfCalls(t, "PopUsersFromQueue", 1)
+	standbyRepo.AssertNotCalled(t, "PopUsersFromQueue", ctx, eventID, int64(2))
+	assert.Equal(t, []string{eventID}, leader.OwnedEvents())
+	assert.Empty(t, standby.OwnedEvents())
+
+	// When the leader stops renewing, the lease expires and the standby takes over
+	leases.expire(eventID)
+	standby.processAllQueues(ctx)
+	leader.processAllQueues(ctx)
+	standbyRepo.AssertNumberOfCalls(t, "PopUsersFromQueue", 1)
+	leaderRepo.AssertNumberOfCalls(t, "PopUsersFromQueue", 1)
+	assert.Empty(t, leader.OwnedEvents())
+
+	// Shutting down gives the lease up at once
+	standby.releaseLeases()
+	assert.Empty(t, standby.OwnedEvents())
+	leader.processAllQueues(ctx)
+	leaderRepo.AssertNumberOfCalls(t, "PopUsersFromQueue", 2)
+}
+
+func TestQueueReleaseWorker_DropsLeasesOfFinishedQueues(t *testing.T) {
+	leases := &memoryQueueReleaseLeases{owners: map[string]string{}}
+	worker := NewQueueReleaseWorker(&QueueReleaseWorkerConfig{
+		JWTSecret: "test-secret",
+		Leases:    leases,
+	}, new(MockQueueRepository), nil, logger.Get())
+
+	ctx := context.Background()
+	assert.True(t, worker.holdsLease(ctx, "event-1"))
+	assert.True(t, worker.holdsLease(ctx, "event-2"))
+
+	worker.dropLeasesExcept(ctx, []string{"event-2"})
+	assert.Equal(t, []string{"event-2"}, worker.OwnedEvents())
+	assert.NotContains(t, leases.owners, "event-1")
+}
+
 func TestDefaultQueueReleaseWorkerConfig(t *testing.T) {
 	cfg := DefaultQueueReleaseWorkerConfig()
 
@@ -0,0 +1,177 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/events"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+)
+
+// Refund outcome events published by payment-service
+const (
+	PaymentEventRefunded     = events.PaymentEventRefunded
+	PaymentEventRefundFailed = events.PaymentEventRefundFailed
+)
+
+// RefundBatchWorkerConfig holds configuration for the refund batch worker
+type RefundBatchWorkerConfig struct {
+	PaymentTopic string        // Topic carrying payment.refunded and payment.refund_failed
+	PollInterval time.Duration // Interval between polling for pending refunds
+	BatchSize    int           // Max refund commands sent per poll
+	ClaimLease   time.Duration // How long a claimed refund is hidden from other workers
+}
+
+// DefaultRefundBatchWorkerConfig returns default configuration
+func DefaultRefundBatchWorkerConfig() *RefundBatchWorkerConfig {
+	return &RefundBatchWorkerConfig{
+		PaymentTopic: events.TopicPaymentEvents,
+		PollInterval: time.Second,
+		BatchSize:    100,
+		ClaimLease:   time.Minute,
+	}
+}
+
+// RefundBatchWorker sends the refunds of cancelled shows to payment-service and records
+// the per-booking results reported back on the payment events topic.
+type RefundBatchWorker struct {
+	config        *RefundBatchWorkerConfig
+	consumer      *kafka.Consumer
+	refundService service.RefundBatchService
+	log           *logger.Logger
+}
+
+// NewRefundBatchWorker creates a new refund batch worker
+func NewRefundBatchWorker(
+	cfg *RefundBatchWorkerConfig,
+	consumer *kafka.Consumer,
+	refundService service.RefundBatchService,
+	log *logger.Logger,
+) *RefundBatchWorker {
+	defaults := DefaultRefundBatchWorkerConfig()
+	if cfg == nil {
+		cfg = defaults
+	}
+	if cfg.PaymentTopic == "" {
+		cfg.PaymentTopic = defaults.PaymentTopic
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaults.PollInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaults.BatchSize
+	}
+	if cfg.ClaimLease <= 0 {
+		cfg.ClaimLease = defaults.ClaimLease
+	}
+
+	return &RefundBatchWorker{
+		config:        cfg,
+		consumer:      consumer,
+		refundService: refundService,
+		log:           log,
+	}
+}
+
+// Start begins consuming refund results and dispatching pending refunds until ctx is cancelled
+func (w *RefundBatchWorker) Start(ctx context.Context) {
+	if w.consumer != nil {
+		go w.consumeLoop(ctx)
+	}
+
+	ticker := time.NewTicker(w.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.log.Info("Refund batch worker context cancelled")
+			return
+		case <-ticker.C:
+			w.dispatchPending(ctx)
+		}
+	}
+}
+
+// consumeLoop continuously polls for refund results
+func (w *RefundBatchWorker) consumeLoop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			records, err := w.consumer.Poll(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				w.log.Error(fmt.Sprintf("Failed to poll Kafka: %v", err))
+				time.Sleep(time.Second)
+				continue
+			}
+
+			if len(records) == 0 {
+				continue
+			}
+
+			for _, record := range records {
+				if err := w.processRecord(ctx, record); err != nil {
+					w.log.Error(fmt.Sprintf("Failed to record refund result: %v", err))
+				}
+			}
+
+			// Results are recorded once per item, so redelivered events are ignored
+			if err := w.consumer.CommitRecords(ctx, records); err != nil {
+				w.log.Error(fmt.Sprintf("Failed to commit offsets: %v", err))
+			}
+		}
+	}
+}
+
+// processRecord records the refund outcome carried by a payment event.
+// Other payment events and refunds of bookings outside a refund batch are skipped.
+func (w *RefundBatchWorker) processRecord(ctx context.Context, record *kafka.Record) error {
+	var event PaymentRefundedEvent
+	if err := json.Unmarshal(record.Value, &event); err != nil {
+		return fmt.Errorf("failed to unmarshal payment event: %w", err)
+	}
+
+	var refunded bool
+	switch event.EventType {
+	case PaymentEventRefunded:
+		refunded = true
+	case PaymentEventRefundFailed:
+		refunded = false
+	default:
+		return nil
+	}
+	if event.PaymentData == nil || event.PaymentData.BookingID == "" {
+		return nil
+	}
+
+	data := event.PaymentData
+	recorded, err := w.refundService.RecordRefundResult(ctx, data.BookingID, refunded, data.ErrorMessage)
+	if err != nil {
+		return fmt.Errorf("failed to record %s for booking %s: %w", event.EventType, data.BookingID, err)
+	}
+	if recorded && !refunded {
+		w.log.Warn(fmt.Sprintf("Refund failed: booking_id=%s, payment_id=%s, error=%s", data.BookingID, data.PaymentID, data.ErrorMessage))
+	}
+	return nil
+}
+
+// dispatchPending sends refund commands for pending items
+func (w *RefundBatchWorker) dispatchPending(ctx context.Context) {
+	sent, err := w.refundService.DispatchPending(ctx, w.config.BatchSize, w.config.ClaimLease)
+	if err != nil {
+		w.log.Error(fmt.Sprintf("Failed to dispatch pending refunds: %v", err))
+		return
+	}
+	if sent > 0 {
+		w.log.Info(fmt.Sprintf("Sent %d refund commands", sent))
+	}
+}
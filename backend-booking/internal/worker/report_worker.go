@@ -0,0 +1,90 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+)
+
+// ReportWorkerConfig holds configuration for the organizer report worker
+type ReportWorkerConfig struct {
+	PollInterval time.Duration // Interval between checks for due report schedules
+	BatchSize    int           // Max reports sent per poll
+	ClaimLease   time.Duration // How long a claimed schedule is hidden from other workers
+}
+
+// DefaultReportWorkerConfig returns default configuration
+func DefaultReportWorkerConfig() *ReportWorkerConfig {
+	return &ReportWorkerConfig{
+		PollInterval: time.Minute,
+		BatchSize:    50,
+		ClaimLease:   10 * time.Minute,
+	}
+}
+
+// ReportWorker sends organizers their scheduled sales summary emails
+type ReportWorker struct {
+	config        *ReportWorkerConfig
+	reportService service.ReportService
+	log           *logger.Logger
+}
+
+// NewReportWorker creates a new report worker
+func NewReportWorker(cfg *ReportWorkerConfig, reportService service.ReportService, log *logger.Logger) *ReportWorker {
+	defaults := DefaultReportWorkerConfig()
+	if cfg == nil {
+		cfg = defaults
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaults.PollInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaults.BatchSize
+	}
+	if cfg.ClaimLease <= 0 {
+		cfg.ClaimLease = defaults.ClaimLease
+	}
+
+	return &ReportWorker{
+		config:        cfg,
+		reportService: reportService,
+		log:           log,
+	}
+}
+
+// Start sends due reports every poll interval until ctx is cancelled
+func (w *ReportWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		w.sendDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			w.log.Info("Report worker context cancelled")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sendDue sends batches of due reports until none are left
+func (w *ReportWorker) sendDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		sent, err := w.reportService.SendDueReports(ctx, w.config.BatchSize, w.config.ClaimLease)
+		if err != nil {
+			w.log.Error(fmt.Sprintf("Failed to send due reports: %v", err))
+			return
+		}
+		if sent > 0 {
+			w.log.Info(fmt.Sprintf("Sent %d sales reports", sent))
+		}
+		if sent < w.config.BatchSize {
+			return
+		}
+	}
+}
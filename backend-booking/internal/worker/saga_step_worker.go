@@ -10,8 +10,11 @@ import (
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/saga"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/id"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/tenantconfig"
 )
 
 // SagaStepWorkerConfig contains configuration for the saga step worker
@@ -19,6 +22,15 @@ type SagaStepWorkerConfig struct {
 	WorkerCount   int
 	RetryAttempts int
 	RetryDelay    time.Duration
+	// PushNotifier sends payment-due and confirmation pushes to mobile devices (optional)
+	PushNotifier service.PushNotifier
+	// Downstream records the outcome of Postgres and Redis calls; a consumer using it as its
+	// health probe stops fetching commands while they fail (optional)
+	Downstream *kafka.ErrorRateProbe
+	// TenantSettings resolves each tenant's email sender and branding (optional)
+	TenantSettings service.TenantSettingsSource
+	// DefaultSender sends emails of tenants without a verified sender of their own
+	DefaultSender tenantconfig.EmailIdentity
 }
 
 // SagaStepWorker consumes saga commands and executes steps
@@ -168,6 +180,7 @@ func (w *SagaStepWorker) handleReserveSeats(ctx context.Context, record *kafka.R
 
 	for attempt := 0; attempt < w.config.RetryAttempts; attempt++ {
 		result, err := w.reservationRepo.ReserveSeats(ctx, params)
+		w.recordDownstream(err)
 		if err != nil {
 			execErr = err
 			time.Sleep(w.config.RetryDelay)
@@ -183,9 +196,13 @@ func (w *SagaStepWorker) handleReserveSeats(ctx context.Context, record *kafka.R
 
 		// Create booking record in PostgreSQL (status = reserved)
 		now := time.Now()
+		holdTTL := time.Duration(params.TTLSeconds) * time.Second
+		if result.TTLSeconds > 0 {
+			holdTTL = time.Duration(result.TTLSeconds) * time.Second
+		}
 		bookingID := result.BookingID
 		if bookingID == "" {
-			bookingID = uuid.New().String()
+			bookingID = id.New()
 		}
 
 		booking := &domain.Booking{
@@ -201,12 +218,14 @@ func (w *SagaStepWorker) handleReserveSeats(ctx context.Context, record *kafka.R
 			Currency:   data.Currency,
 			Status:     domain.BookingStatusReserved,
 			ReservedAt: now,
-			ExpiresAt:  now.Add(10 * time.Minute),
+			ExpiresAt:  now.Add(holdTTL),
 			CreatedAt:  now,
 			UpdatedAt:  now,
 		}
 
-		if err := w.bookingRepo.Create(ctx, booking); err != nil {
+		err = w.bookingRepo.Create(ctx, booking)
+		w.recordDownstream(err)
+		if err != nil {
 			log.Error(fmt.Sprintf("Failed to create booking in PostgreSQL: %v", err))
 			// Continue anyway - Redis reservation is the source of truth for availability
 		} else {
@@ -217,6 +236,7 @@ func (w *SagaStepWorker) handleReserveSeats(ctx context.Context, record *kafka.R
 			"reservation_id": bookingID,
 			"booking_id":     bookingID,
 			"reserved_at":    now.Format(time.RFC3339),
+			"expires_at":     booking.ExpiresAt.Format(time.RFC3339),
 		}
 		execErr = nil
 		break
@@ -252,6 +272,12 @@ func (w *SagaStepWorker) handleReserveSeats(ctx context.Context, record *kafka.R
 		if err := w.producer.SendStepSuccessEvent(ctx, event); err != nil {
 			log.Error(fmt.Sprintf("Failed to send success event: %v", err))
 		}
+
+		// Seats are held; the user now has until the hold expires to pay
+		expiresAtStr, _ := resultData["expires_at"].(string)
+		expiresAt, _ := time.Parse(time.RFC3339, expiresAtStr)
+		bookingID, _ := resultData["booking_id"].(string)
+		w.notifyPush(data.UserID, service.NewPaymentDueNotification(bookingID, data.EventID, data.Quantity, expiresAt))
 	}
 
 	return w.consumer.CommitRecords(ctx, []*kafka.Record{record})
@@ -275,6 +301,7 @@ func (w *SagaStepWorker) handleReleaseSeats(ctx context.Context, record *kafka.R
 
 	// Execute release
 	_, err := w.reservationRepo.ReleaseSeats(ctx, data.BookingID, data.UserID)
+	w.recordDownstream(err)
 	if err != nil {
 		log.Error(fmt.Sprintf("Failed to release seats: %v", err))
 	} else {
@@ -328,6 +355,7 @@ func (w *SagaStepWorker) handleConfirmBooking(ctx context.Context, record *kafka
 
 	// Step 1: Get booking from PostgreSQL
 	booking, err := w.bookingRepo.GetByID(ctx, bookingID)
+	w.recordDownstream(err)
 	if err != nil {
 		execErr = fmt.Errorf("failed to get booking: %w", err)
 	} else if booking == nil {
@@ -368,7 +396,9 @@ func (w *SagaStepWorker) handleConfirmBooking(ctx context.Context, record *kafka
 		}
 		booking.ConfirmationCode = confirmationCode
 
-		if err := w.bookingRepo.Update(ctx, booking); err != nil {
+		err := w.bookingRepo.Update(ctx, booking)
+		w.recordDownstream(err)
+		if err != nil {
 			execErr = fmt.Errorf("failed to update booking status: %w", err)
 		} else {
 			log.Info(fmt.Sprintf("Confirmed booking in PostgreSQL: booking_id=%s, confirmation_code=%s", bookingID, confirmationCode))
@@ -436,6 +466,9 @@ func (w *SagaStepWorker) handleSendNotification(ctx context.Context, record *kaf
 	bookingID, _ := command.Data["booking_id"].(string)
 	userID, _ := command.Data["user_id"].(string)
 	confirmationCode, _ := command.Data["confirmation_code"].(string)
+	tenantID, _ := command.Data["tenant_id"].(string)
+
+	email := service.NewBookingConfirmedEmail(w.emailIdentity(ctx, tenantID), confirmationCode)
 
 	var resultData map[string]interface{}
 	var execErr error
@@ -452,13 +485,21 @@ func (w *SagaStepWorker) handleSendNotification(ctx context.Context, record *kaf
 		// TODO: Replace with real notification service
 		notificationID := fmt.Sprintf("notif-%s", uuid.New().String()[:8])
 
-		log.Info(fmt.Sprintf("[MOCK] Sending booking confirmation email: booking_id=%s, user_id=%s, confirmation_code=%s",
-			bookingID, userID, confirmationCode))
+		log.Info(fmt.Sprintf("[MOCK] Sending booking confirmation email: booking_id=%s, user_id=%s, confirmation_code=%s, from=%s, reply_to=%s",
+			bookingID, userID, confirmationCode, email.From, email.ReplyTo))
+
+		// Push is delivered by the push worker, which retries each device on its own
+		pushStatus := "skipped"
+		if w.notifyPush(userID, service.NewBookingConfirmedNotification(bookingID, confirmationCode)) {
+			pushStatus = "queued"
+		}
 
 		// Simulate success (in production, check email service response)
 		resultData = map[string]interface{}{
 			"notification_id":   notificationID,
 			"notification_type": "email",
+			"from_address":      email.From,
+			"push_status":       pushStatus,
 			"booking_id":        bookingID,
 			"user_id":           userID,
 			"sent_at":           time.Now().Format(time.RFC3339),
@@ -527,3 +568,42 @@ func (w *SagaStepWorker) handleSendNotification(ctx context.Context, record *kaf
 
 	return w.consumer.CommitRecords(ctx, []*kafka.Record{record})
 }
+
+// recordDownstream counts a Postgres or Redis call towards the downstream error rate.
+// Domain errors mean the dependency answered, so they count as successes.
+func (w *SagaStepWorker) recordDownstream(err error) {
+	if w.config.Downstream == nil {
+		return
+	}
+	if domain.IsNotFoundError(err) || domain.IsValidationError(err) || domain.IsConflictError(err) || domain.IsExpiredError(err) {
+		err = nil
+	}
+	w.config.Downstream.Record(err)
+}
+
+// emailIdentity returns the sender and branding of a tenant's emails. A failed
+// settings lookup falls back to the default sender rather than delaying the email.
+func (w *SagaStepWorker) emailIdentity(ctx context.Context, tenantID string) tenantconfig.EmailIdentity {
+	if w.config.TenantSettings == nil || tenantID == "" {
+		return w.config.DefaultSender
+	}
+	settings, err := w.config.TenantSettings.Get(ctx, tenantID)
+	if err != nil {
+		logger.Get().Warn(fmt.Sprintf("Failed to load settings of tenant %s, using the default sender: %v", tenantID, err))
+		return w.config.DefaultSender
+	}
+	return tenantconfig.EmailIdentityFor(settings, w.config.DefaultSender)
+}
+
+// notifyPush queues a push notification for a user's devices, reporting whether it was queued.
+// Push is best-effort and never fails a step.
+func (w *SagaStepWorker) notifyPush(userID string, notification *service.PushNotification) bool {
+	if w.config.PushNotifier == nil || userID == "" {
+		return false
+	}
+	if err := w.config.PushNotifier.Notify(context.Background(), userID, notification); err != nil {
+		logger.Get().Warn(fmt.Sprintf("Failed to queue push notification for user %s: %v", userID, err))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+)
+
+// SalesSnapshotWorkerConfig holds configuration for the sales snapshot worker
+type SalesSnapshotWorkerConfig struct {
+	Interval time.Duration // Time between snapshots, aligned to multiples of it (default: 1 hour)
+	Timeout  time.Duration // Max time for one snapshot (default: 5 minutes)
+}
+
+// DefaultSalesSnapshotWorkerConfig returns default configuration
+func DefaultSalesSnapshotWorkerConfig() *SalesSnapshotWorkerConfig {
+	return &SalesSnapshotWorkerConfig{
+		Interval: time.Hour,
+		Timeout:  5 * time.Minute,
+	}
+}
+
+// SalesSnapshotWorker materializes per-zone seat and revenue snapshots at the top of each hour
+type SalesSnapshotWorker struct {
+	config          *SalesSnapshotWorkerConfig
+	snapshotService service.SalesSnapshotService
+	log             *logger.Logger
+}
+
+// NewSalesSnapshotWorker creates a new sales snapshot worker
+func NewSalesSnapshotWorker(cfg *SalesSnapshotWorkerConfig, snapshotService service.SalesSnapshotService, log *logger.Logger) *SalesSnapshotWorker {
+	defaults := DefaultSalesSnapshotWorkerConfig()
+	if cfg == nil {
+		cfg = defaults
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaults.Interval
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaults.Timeout
+	}
+
+	return &SalesSnapshotWorker{
+		config:          cfg,
+		snapshotService: snapshotService,
+		log:             log,
+	}
+}
+
+// Start takes a snapshot at every interval boundary until ctx is cancelled
+func (w *SalesSnapshotWorker) Start(ctx context.Context) {
+	for {
+		timer := time.NewTimer(untilNextSnapshot(time.Now(), w.config.Interval))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			w.log.Info("Sales snapshot worker context cancelled")
+			return
+		case at := <-timer.C:
+			w.snapshot(ctx, at)
+		}
+	}
+}
+
+// snapshot takes one snapshot, bounded by the configured timeout
+func (w *SalesSnapshotWorker) snapshot(ctx context.Context, at time.Time) {
+	ctx, cancel := context.WithTimeout(ctx, w.config.Timeout)
+	defer cancel()
+
+	zones, err := w.snapshotService.TakeSnapshot(ctx, at)
+	if err != nil {
+		w.log.Error(fmt.Sprintf("Failed to take sales snapshot: %v", err))
+		return
+	}
+	w.log.Info(fmt.Sprintf("Recorded sales snapshot of %d zones", zones))
+}
+
+// untilNextSnapshot returns the time from now to the next multiple of interval
+func untilNextSnapshot(now time.Time, interval time.Duration) time.Duration {
+	return now.Truncate(interval).Add(interval).Sub(now)
+}
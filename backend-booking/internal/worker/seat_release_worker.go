@@ -3,38 +3,54 @@ package worker
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"hash/fnv"
+	"sync"
 	"time"
 
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/events"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
 )
 
 // SeatReleaseEvent represents the event received from payment service
-type SeatReleaseEvent struct {
-	EventType   string `json:"event_type"`
-	BookingID   string `json:"booking_id"`
-	PaymentID   string `json:"payment_id"`
-	UserID      string `json:"user_id,omitempty"`
-	Reason      string `json:"reason"`
-	FailureCode string `json:"failure_code,omitempty"`
-	Message     string `json:"message,omitempty"`
-	Timestamp   string `json:"timestamp"`
-}
+type SeatReleaseEvent = events.SeatReleaseEvent
+
+// SeatReleaseReasonPaymentRefunded is the reason of seat releases for payments refunded at
+// the gateway, e.g. from the Stripe dashboard. Unlike the other reasons it also applies to
+// confirmed bookings, since the customer no longer pays for the seats.
+const SeatReleaseReasonPaymentRefunded = events.SeatReleaseReasonPaymentRefunded
 
 // SeatReleaseWorkerConfig contains configuration for the seat release worker
 type SeatReleaseWorkerConfig struct {
+	// WorkerCount is the number of lanes each partition's records are spread over.
+	// Records of one booking always go to the same lane, keeping them in order.
 	WorkerCount   int
 	RetryAttempts int
 	RetryDelay    time.Duration
+	// BatchSize caps how many bookings a lane reads, releases and cancels at once (default: 200)
+	BatchSize int
+	// StandbyOfferWindow is how long released seats are held for a standby user
+	StandbyOfferWindow time.Duration
+	// StandbySweepInterval is how often lapsed standby offers are reclaimed and re-offered
+	StandbySweepInterval time.Duration
 }
 
-// SeatReleaseWorker consumes seat release events and releases seats
+// SeatReleaseWorker consumes seat release events and releases seats.
+// Each poll is processed partition-parallel: a partition's records are hashed by booking
+// ID onto WorkerCount lanes, and every lane releases its bookings in batches (one
+// Postgres read, pipelined Redis releases, one Postgres update) so mass expiries after
+// big on-sales drain quickly. Offsets are committed once the whole poll is processed.
 type SeatReleaseWorker struct {
 	consumer        *kafka.Consumer
 	bookingRepo     repository.BookingRepository
 	reservationRepo repository.ReservationRepository
+	standbyRepo     repository.StandbyRepository
+	notifier        service.StandbyNotifier
 	config          *SeatReleaseWorkerConfig
 }
 
@@ -43,6 +59,8 @@ func NewSeatReleaseWorker(
 	consumer *kafka.Consumer,
 	bookingRepo repository.BookingRepository,
 	reservationRepo repository.ReservationRepository,
+	standbyRepo repository.StandbyRepository,
+	notifier service.StandbyNotifier,
 	config *SeatReleaseWorkerConfig,
 ) *SeatReleaseWorker {
 	if config == nil {
@@ -52,10 +70,27 @@ func NewSeatReleaseWorker(
 			RetryDelay:    time.Second,
 		}
 	}
+	if config.WorkerCount <= 0 {
+		config.WorkerCount = 1
+	}
+	if config.RetryAttempts <= 0 {
+		config.RetryAttempts = 1
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 200
+	}
+	if config.StandbyOfferWindow <= 0 {
+		config.StandbyOfferWindow = 2 * time.Minute
+	}
+	if config.StandbySweepInterval <= 0 {
+		config.StandbySweepInterval = 5 * time.Second
+	}
 	return &SeatReleaseWorker{
 		consumer:        consumer,
 		bookingRepo:     bookingRepo,
 		reservationRepo: reservationRepo,
+		standbyRepo:     standbyRepo,
+		notifier:        notifier,
 		config:          config,
 	}
 }
@@ -63,135 +98,372 @@ func NewSeatReleaseWorker(
 // Start starts the worker and begins consuming messages
 func (w *SeatReleaseWorker) Start(ctx context.Context) error {
 	log := logger.Get()
-	log.Info(fmt.Sprintf("Starting seat release worker with %d workers", w.config.WorkerCount))
+	log.Info(fmt.Sprintf("Starting seat release worker with %d lanes per partition (batch size %d)",
+		w.config.WorkerCount, w.config.BatchSize))
 
-	recordsCh := make(chan *kafka.Record, w.config.WorkerCount*10)
-
-	// Start worker goroutines
-	for i := 0; i < w.config.WorkerCount; i++ {
-		go w.worker(ctx, i, recordsCh)
+	// Reclaim lapsed standby offers even when no seats are being released
+	if w.standbyRepo != nil {
+		go w.sweepStandby(ctx)
 	}
 
 	// Poll for messages
-	return w.poll(ctx, recordsCh)
+	return w.poll(ctx)
 }
 
 // poll continuously polls for messages from Kafka
-func (w *SeatReleaseWorker) poll(ctx context.Context, recordsCh chan<- *kafka.Record) error {
+func (w *SeatReleaseWorker) poll(ctx context.Context) error {
 	log := logger.Get()
 
 	for {
 		select {
 		case <-ctx.Done():
-			close(recordsCh)
 			return ctx.Err()
 		default:
 			records, err := w.consumer.Poll(ctx)
 			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
 				log.Error(fmt.Sprintf("Failed to poll messages: %v", err))
 				time.Sleep(time.Second)
 				continue
 			}
 
-			for _, record := range records {
-				select {
-				case recordsCh <- record:
-				case <-ctx.Done():
-					close(recordsCh)
-					return ctx.Err()
-				}
+			if len(records) == 0 {
+				continue
+			}
+
+			w.processRecords(ctx, records)
+
+			// Failed releases are logged for manual investigation rather than retried forever
+			if err := w.consumer.CommitRecords(ctx, records); err != nil {
+				log.Error(fmt.Sprintf("Failed to commit offsets: %v", err))
 			}
 		}
 	}
 }
 
-// worker processes messages from the channel
-func (w *SeatReleaseWorker) worker(ctx context.Context, id int, recordsCh <-chan *kafka.Record) {
+// releaseLane identifies the lane of a partition that processes a booking
+type releaseLane struct {
+	partition int32
+	lane      uint32
+}
+
+// processRecords releases the seats of a polled batch, partitions and lanes in parallel
+func (w *SeatReleaseWorker) processRecords(ctx context.Context, records []*kafka.Record) {
 	log := logger.Get()
-	log.Info(fmt.Sprintf("Worker %d started", id))
 
-	for record := range recordsCh {
-		if err := w.processRecord(ctx, record); err != nil {
-			log.Error(fmt.Sprintf("Worker %d failed to process record: %v", id, err))
+	lanes := make(map[releaseLane][]*SeatReleaseEvent)
+	for _, record := range records {
+		var event SeatReleaseEvent
+		if err := json.Unmarshal(record.Value, &event); err != nil {
+			// Committed with the batch to avoid reprocessing malformed messages
+			log.Error(fmt.Sprintf("Failed to unmarshal event: %v", err))
+			continue
+		}
+		if event.BookingID == "" {
+			log.Warn("Seat release event without booking_id, skipping")
+			continue
 		}
+
+		key := releaseLane{partition: record.Partition, lane: w.laneFor(event.BookingID)}
+		lanes[key] = append(lanes[key], &event)
+	}
+
+	var wg sync.WaitGroup
+	for _, events := range lanes {
+		wg.Add(1)
+		go func(events []*SeatReleaseEvent) {
+			defer wg.Done()
+			w.processLane(ctx, events)
+		}(events)
 	}
+	wg.Wait()
+}
 
-	log.Info(fmt.Sprintf("Worker %d stopped", id))
+// laneFor hashes a booking ID onto one of WorkerCount lanes
+func (w *SeatReleaseWorker) laneFor(bookingID string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(bookingID))
+	return h.Sum32() % uint32(w.config.WorkerCount)
 }
 
-// processRecord processes a single Kafka record
-func (w *SeatReleaseWorker) processRecord(ctx context.Context, record *kafka.Record) error {
+// processLane releases a lane's bookings in order, BatchSize at a time.
+// Repeated events for a booking collapse into one release; later ones would be no-ops.
+func (w *SeatReleaseWorker) processLane(ctx context.Context, events []*SeatReleaseEvent) {
 	log := logger.Get()
 
-	var event SeatReleaseEvent
-	if err := json.Unmarshal(record.Value, &event); err != nil {
-		log.Error(fmt.Sprintf("Failed to unmarshal event: %v", err))
-		// Commit the record to avoid reprocessing malformed messages
-		return w.consumer.CommitRecords(ctx, []*kafka.Record{record})
+	seen := make(map[string]bool, len(events))
+	ids := make([]string, 0, len(events))
+	for _, event := range events {
+		if seen[event.BookingID] {
+			continue
+		}
+		seen[event.BookingID] = true
+		log.Info(fmt.Sprintf("Processing seat release: booking_id=%s, reason=%s", event.BookingID, event.Reason))
+		if event.Reason == SeatReleaseReasonPaymentRefunded && w.cancelRefundedBooking(ctx, event.BookingID) {
+			continue
+		}
+		ids = append(ids, event.BookingID)
+	}
+
+	for start := 0; start < len(ids); start += w.config.BatchSize {
+		end := start + w.config.BatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		w.releaseBatch(ctx, ids[start:end])
 	}
+}
 
-	log.Info(fmt.Sprintf("Processing seat release: booking_id=%s, reason=%s", event.BookingID, event.Reason))
+// releaseBatch releases a batch of bookings, retrying the ones that failed
+func (w *SeatReleaseWorker) releaseBatch(ctx context.Context, ids []string) {
+	log := logger.Get()
 
-	// Release seats with retry
-	var lastErr error
-	for attempt := 0; attempt < w.config.RetryAttempts; attempt++ {
-		if err := w.releaseSeats(ctx, &event); err != nil {
-			lastErr = err
-			log.Warn(fmt.Sprintf("Attempt %d failed to release seats for booking %s: %v", attempt+1, event.BookingID, err))
-			time.Sleep(w.config.RetryDelay)
-			continue
+	pending := ids
+	for attempt := 0; attempt < w.config.RetryAttempts && len(pending) > 0; attempt++ {
+		if attempt > 0 {
+			log.Warn(fmt.Sprintf("Attempt %d failed to release seats for %d bookings, retrying", attempt, len(pending)))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(w.config.RetryDelay):
+			}
 		}
-		lastErr = nil
-		break
+		pending = w.releaseSeats(ctx, pending)
 	}
 
-	if lastErr != nil {
-		log.Error(fmt.Sprintf("Failed to release seats after %d attempts: booking_id=%s, error=%v", w.config.RetryAttempts, event.BookingID, lastErr))
-		// Still commit to avoid infinite loop, but log the failure for manual investigation
-	} else {
-		log.Info(fmt.Sprintf("Successfully released seats: booking_id=%s", event.BookingID))
+	// Still committed to avoid an infinite loop, but logged for manual investigation
+	for _, id := range pending {
+		log.Error(fmt.Sprintf("Failed to release seats after %d attempts: booking_id=%s", w.config.RetryAttempts, id))
 	}
-
-	return w.consumer.CommitRecords(ctx, []*kafka.Record{record})
 }
 
-// releaseSeats releases the seats for a booking
-func (w *SeatReleaseWorker) releaseSeats(ctx context.Context, event *SeatReleaseEvent) error {
+// releaseSeats releases the seats for a batch of bookings and returns the IDs that failed
+func (w *SeatReleaseWorker) releaseSeats(ctx context.Context, ids []string) []string {
 	log := logger.Get()
 
-	// Get booking from database
-	booking, err := w.bookingRepo.GetByID(ctx, event.BookingID)
+	// Get bookings from database
+	bookings, err := w.getBookings(ctx, ids)
 	if err != nil {
-		return fmt.Errorf("failed to get booking: %w", err)
+		log.Warn(fmt.Sprintf("Failed to get %d bookings: %v", len(ids), err))
+		return ids
 	}
 
-	if booking == nil {
-		log.Warn(fmt.Sprintf("Booking not found: %s", event.BookingID))
-		return nil // Not an error, booking might have been already cancelled
+	found := make(map[string]bool, len(bookings))
+	releasable := make([]*domain.Booking, 0, len(bookings))
+	for _, booking := range bookings {
+		found[booking.ID] = true
+		// Check if booking is in a state that requires seat release
+		if booking.Status != "pending" && !booking.IsAwaitingPayment() {
+			log.Info(fmt.Sprintf("Booking %s already in status %s, skipping seat release", booking.ID, booking.Status))
+			continue
+		}
+		releasable = append(releasable, booking)
 	}
-
-	// Check if booking is in a state that requires seat release
-	if booking.Status != "pending" && booking.Status != "reserved" {
-		log.Info(fmt.Sprintf("Booking %s already in status %s, skipping seat release", event.BookingID, booking.Status))
+	for _, id := range ids {
+		if !found[id] {
+			log.Warn(fmt.Sprintf("Booking not found: %s", id)) // Not an error, booking might have been already cancelled
+		}
+	}
+	if len(releasable) == 0 {
 		return nil
 	}
 
 	// Release seats in Redis
-	_, err = w.reservationRepo.ReleaseSeats(ctx, booking.ID, booking.UserID)
+	errs := w.releaseReservations(ctx, releasable)
+
+	var failed []string
+	released := make([]*domain.Booking, 0, len(releasable))
+	for i, booking := range releasable {
+		if errs[i] != nil {
+			log.Warn(fmt.Sprintf("Failed to release seats in Redis for booking %s: %v", booking.ID, errs[i]))
+			failed = append(failed, booking.ID)
+			continue
+		}
+		released = append(released, booking)
+	}
+	if len(released) == 0 {
+		return failed
+	}
+
+	// Update booking status in database.
+	// Log but don't fail - Redis is the source of truth for availability
+	if err := w.cancelBookings(ctx, released); err != nil {
+		log.Error(fmt.Sprintf("Failed to update booking status in database: %v", err))
+	}
+
+	// Freed seats go to each zone's standby list first
+	zones := make(map[string]bool)
+	for _, booking := range released {
+		log.Info(fmt.Sprintf("Released %d seats for booking %s (zone=%s, show=%s)",
+			booking.Quantity, booking.ID, booking.ZoneID, booking.ShowID))
+		if !zones[booking.ZoneID] {
+			zones[booking.ZoneID] = true
+			w.offerStandby(ctx, booking.ZoneID)
+		}
+	}
+
+	return failed
+}
+
+// cancelRefundedBooking cancels a confirmed booking whose payment was refunded and puts its
+// seats back on sale. Returns false when the booking is not confirmed, leaving it to the
+// usual release of unpaid reservations.
+func (w *SeatReleaseWorker) cancelRefundedBooking(ctx context.Context, bookingID string) bool {
+	log := logger.Get()
+
+	booking, err := w.bookingRepo.GetByID(ctx, bookingID)
 	if err != nil {
-		return fmt.Errorf("failed to release seats in Redis: %w", err)
+		if !errors.Is(err, domain.ErrBookingNotFound) {
+			log.Warn(fmt.Sprintf("Failed to get refunded booking %s: %v", bookingID, err))
+		}
+		return false
+	}
+	if booking == nil || !booking.IsConfirmed() {
+		return false
 	}
 
-	// Update booking status in database
-	booking.Status = "cancelled"
-	booking.UpdatedAt = time.Now()
+	now := time.Now()
+	booking.Status = domain.BookingStatusCancelled
+	booking.CancelledAt = &now
+	booking.UpdatedAt = now
 	if err := w.bookingRepo.Update(ctx, booking); err != nil {
-		// Log but don't fail - Redis is the source of truth for availability
-		log.Error(fmt.Sprintf("Failed to update booking status in database: %v", err))
+		// Still committed to avoid an infinite loop, but logged for manual investigation
+		log.Error(fmt.Sprintf("Failed to cancel refunded booking %s: %v", bookingID, err))
+		return true
+	}
+
+	// Without a releaser the cancelled booking keeps its seats sold
+	if releaser, ok := w.reservationRepo.(repository.SoldSeatReleaser); ok {
+		result, err := releaser.ReleaseSoldSeats(ctx, booking.ID, booking.UserID)
+		switch {
+		case err != nil:
+			log.Warn(fmt.Sprintf("Failed to release seats of refunded booking %s: %v", booking.ID, err))
+		case !result.Success:
+			log.Warn(fmt.Sprintf("Seats of refunded booking %s not released: %s", booking.ID, result.ErrorCode))
+		default:
+			w.offerStandby(ctx, booking.ZoneID)
+		}
+	}
+
+	log.Info(fmt.Sprintf("Cancelled refunded booking %s (zone=%s, show=%s)", booking.ID, booking.ZoneID, booking.ShowID))
+	return true
+}
+
+// getBookings reads a batch of bookings in one query when the repository supports it
+func (w *SeatReleaseWorker) getBookings(ctx context.Context, ids []string) ([]*domain.Booking, error) {
+	if batch, ok := w.bookingRepo.(repository.BookingBatchRepository); ok {
+		return batch.GetByIDs(ctx, ids)
+	}
+
+	bookings := make([]*domain.Booking, 0, len(ids))
+	for _, id := range ids {
+		booking, err := w.bookingRepo.GetByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, domain.ErrBookingNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get booking: %w", err)
+		}
+		if booking != nil {
+			bookings = append(bookings, booking)
+		}
+	}
+	return bookings, nil
+}
+
+// releaseReservations releases the bookings' Redis reservations, pipelined when the
+// repository supports it, and returns an error for each booking, in order
+func (w *SeatReleaseWorker) releaseReservations(ctx context.Context, bookings []*domain.Booking) []error {
+	if batch, ok := w.reservationRepo.(repository.BatchReservationReleaser); ok {
+		requests := make([]repository.ReleaseRequest, len(bookings))
+		for i, booking := range bookings {
+			requests[i] = repository.ReleaseRequest{BookingID: booking.ID, UserID: booking.UserID}
+		}
+		_, errs := batch.ReleaseSeatsBatch(ctx, requests)
+		return errs
+	}
+
+	errs := make([]error, len(bookings))
+	for i, booking := range bookings {
+		_, errs[i] = w.reservationRepo.ReleaseSeats(ctx, booking.ID, booking.UserID)
+	}
+	return errs
+}
+
+// cancelBookings marks released bookings cancelled, in one statement when the repository supports it
+func (w *SeatReleaseWorker) cancelBookings(ctx context.Context, bookings []*domain.Booking) error {
+	if batch, ok := w.bookingRepo.(repository.BookingBatchRepository); ok {
+		ids := make([]string, len(bookings))
+		for i, booking := range bookings {
+			ids[i] = booking.ID
+		}
+		_, err := batch.CancelReserved(ctx, ids)
+		return err
+	}
+
+	var firstErr error
+	for _, booking := range bookings {
+		booking.Status = domain.BookingStatusCancelled
+		booking.UpdatedAt = time.Now()
+		if err := w.bookingRepo.Update(ctx, booking); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// sweepStandby periodically re-runs standby offers for zones with waiting users or held seats
+func (w *SeatReleaseWorker) sweepStandby(ctx context.Context) {
+	log := logger.Get()
+
+	ticker := time.NewTicker(w.config.StandbySweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			zones, err := w.standbyRepo.ListZones(ctx)
+			if err != nil {
+				log.Error(fmt.Sprintf("Failed to list standby zones: %v", err))
+				continue
+			}
+			for _, zoneID := range zones {
+				w.offerStandby(ctx, zoneID)
+			}
+		}
 	}
+}
 
-	log.Info(fmt.Sprintf("Released %d seats for booking %s (zone=%s, show=%s)",
-		booking.Quantity, booking.ID, booking.ZoneID, booking.ShowID))
+// offerStandby hands available seats in a zone to waiting standby users and invites them
+func (w *SeatReleaseWorker) offerStandby(ctx context.Context, zoneID string) {
+	if w.standbyRepo == nil || zoneID == "" {
+		return
+	}
+	log := logger.Get()
 
-	return nil
+	result, err := w.standbyRepo.OfferSeats(ctx, zoneID, w.config.StandbyOfferWindow)
+	if err != nil {
+		log.Error(fmt.Sprintf("Failed to offer standby seats for zone %s: %v", zoneID, err))
+		return
+	}
+	if !result.Success {
+		log.Warn(fmt.Sprintf("Could not offer standby seats for zone %s: %s - %s", zoneID, result.ErrorCode, result.ErrorMessage))
+		return
+	}
+	if result.Reclaimed > 0 {
+		log.Info(fmt.Sprintf("Reclaimed %d seats from lapsed standby offers (zone=%s)", result.Reclaimed, zoneID))
+	}
+	for _, offer := range result.Offers {
+		log.Info(fmt.Sprintf("Offered %d seats to standby user %s (zone=%s, expires_at=%s)",
+			offer.Quantity, offer.UserID, zoneID, offer.ExpiresAt.Format(time.RFC3339)))
+		if w.notifier != nil {
+			if err := w.notifier.NotifyOffer(ctx, offer); err != nil {
+				log.Warn(fmt.Sprintf("Failed to notify standby user %s (zone=%s): %v", offer.UserID, zoneID, err))
+			}
+		}
+	}
 }
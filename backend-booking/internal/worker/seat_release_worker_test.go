@@ -0,0 +1,208 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/events"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
+)
+
+// batchBookingRepository serves bookings from memory through the batch interface
+type batchBookingRepository struct {
+	repository.BookingRepository
+
+	mu        sync.Mutex
+	bookings  map[string]*domain.Booking
+	reads     int
+	cancelled []string
+}
+
+func (r *batchBookingRepository) GetByIDs(ctx context.Context, ids []string) ([]*domain.Booking, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reads++
+	var bookings []*domain.Booking
+	for _, id := range ids {
+		if booking, ok := r.bookings[id]; ok {
+			copied := *booking
+			bookings = append(bookings, &copied)
+		}
+	}
+	return bookings, nil
+}
+
+func (r *batchBookingRepository) GetByID(ctx context.Context, id string) (*domain.Booking, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	booking, ok := r.bookings[id]
+	if !ok {
+		return nil, domain.ErrBookingNotFound
+	}
+	copied := *booking
+	return &copied, nil
+}
+
+func (r *batchBookingRepository) Update(ctx context.Context, booking *domain.Booking) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *booking
+	r.bookings[booking.ID] = &copied
+	return nil
+}
+
+func (r *batchBookingRepository) CancelReserved(ctx context.Context, ids []string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cancelled = append(r.cancelled, ids...)
+	return ids, nil
+}
+
+// countingReservationRepository releases reservations one by one, failing some first attempts
+type countingReservationRepository struct {
+	repository.ReservationRepository
+
+	mu           sync.Mutex
+	released     map[string]int
+	failOnce     map[string]bool
+	soldReleased []string
+}
+
+func (r *countingReservationRepository) ReleaseSeats(ctx context.Context, bookingID, userID string) (*repository.ReleaseResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.failOnce[bookingID] {
+		delete(r.failOnce, bookingID)
+		return nil, errors.New("redis timeout")
+	}
+	r.released[bookingID]++
+	return &repository.ReleaseResult{Success: true}, nil
+}
+
+// ReleaseSoldSeats puts a confirmed reservation back on sale
+func (r *countingReservationRepository) ReleaseSoldSeats(ctx context.Context, bookingID, userID string) (*repository.ReleaseResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.soldReleased = append(r.soldReleased, bookingID)
+	return &repository.ReleaseResult{Success: true}, nil
+}
+
+func newSeatReleaseRecord(t *testing.T, partition int32, bookingID string) *kafka.Record {
+	t.Helper()
+	return newSeatReleaseRecordWithReason(t, partition, bookingID, events.SeatReleaseReasonPaymentFailed)
+}
+
+func newSeatReleaseRecordWithReason(t *testing.T, partition int32, bookingID string, reason events.SeatReleaseReason) *kafka.Record {
+	t.Helper()
+	value, err := json.Marshal(SeatReleaseEvent{EventType: events.SeatReleaseEventType, BookingID: bookingID, Reason: reason})
+	if err != nil {
+		t.Fatalf("failed to marshal event: %v", err)
+	}
+	return &kafka.Record{Partition: partition, Key: []byte(bookingID), Value: value}
+}
+
+func TestSeatReleaseWorker_ProcessRecords(t *testing.T) {
+	bookingRepo := &batchBookingRepository{bookings: map[string]*domain.Booking{
+		"b-1": {ID: "b-1", UserID: "u-1", ZoneID: "zone-1", Status: domain.BookingStatusReserved},
+		"b-2": {ID: "b-2", UserID: "u-2", ZoneID: "zone-1", Status: domain.BookingStatusReserved},
+		"b-3": {ID: "b-3", UserID: "u-3", ZoneID: "zone-2", Status: domain.BookingStatusReserved},
+		"b-4": {ID: "b-4", UserID: "u-4", ZoneID: "zone-2", Status: domain.BookingStatusConfirmed},
+	}}
+	reservationRepo := &countingReservationRepository{released: map[string]int{}}
+	w := NewSeatReleaseWorker(nil, bookingRepo, reservationRepo, nil, nil, &SeatReleaseWorkerConfig{
+		WorkerCount:   3,
+		RetryAttempts: 3,
+		BatchSize:     2,
+	})
+
+	records := []*kafka.Record{
+		newSeatReleaseRecord(t, 0, "b-1"),
+		newSeatReleaseRecord(t, 0, "b-1"), // Redelivered event for the same booking
+		newSeatReleaseRecord(t, 1, "b-2"),
+		newSeatReleaseRecord(t, 1, "b-3"),
+		newSeatReleaseRecord(t, 2, "b-4"),
+		newSeatReleaseRecord(t, 2, "missing"),
+		{Partition: 2, Value: []byte("not json")},
+	}
+	w.processRecords(context.Background(), records)
+
+	for _, id := range []string{"b-1", "b-2", "b-3"} {
+		if got := reservationRepo.released[id]; got != 1 {
+			t.Errorf("booking %s released %d times, want 1", id, got)
+		}
+	}
+	if got := reservationRepo.released["b-4"]; got != 0 {
+		t.Errorf("confirmed booking released %d times, want 0", got)
+	}
+
+	sort.Strings(bookingRepo.cancelled)
+	if len(bookingRepo.cancelled) != 3 || bookingRepo.cancelled[0] != "b-1" || bookingRepo.cancelled[2] != "b-3" {
+		t.Errorf("cancelled = %v, want b-1, b-2 and b-3", bookingRepo.cancelled)
+	}
+}
+
+func TestSeatReleaseWorker_RetriesFailedReleases(t *testing.T) {
+	bookingRepo := &batchBookingRepository{bookings: map[string]*domain.Booking{
+		"b-1": {ID: "b-1", UserID: "u-1", ZoneID: "zone-1", Status: domain.BookingStatusReserved},
+		"b-2": {ID: "b-2", UserID: "u-2", ZoneID: "zone-1", Status: domain.BookingStatusReserved},
+	}}
+	reservationRepo := &countingReservationRepository{
+		released: map[string]int{},
+		failOnce: map[string]bool{"b-2": true},
+	}
+	w := NewSeatReleaseWorker(nil, bookingRepo, reservationRepo, nil, nil, &SeatReleaseWorkerConfig{
+		WorkerCount:   1,
+		RetryAttempts: 2,
+		RetryDelay:    time.Millisecond,
+	})
+
+	w.processRecords(context.Background(), []*kafka.Record{
+		newSeatReleaseRecord(t, 0, "b-1"),
+		newSeatReleaseRecord(t, 0, "b-2"),
+	})
+
+	if reservationRepo.released["b-1"] != 1 || reservationRepo.released["b-2"] != 1 {
+		t.Errorf("released = %v, want each booking released once", reservationRepo.released)
+	}
+	// The first attempt reads both bookings, the retry only the failed one
+	if bookingRepo.reads != 2 || len(bookingRepo.cancelled) != 2 {
+		t.Errorf("reads = %d, cancelled = %v, want 2 reads and both cancelled", bookingRepo.reads, bookingRepo.cancelled)
+	}
+}
+
+func TestSeatReleaseWorker_CancelsRefundedBookings(t *testing.T) {
+	bookingRepo := &batchBookingRepository{bookings: map[string]*domain.Booking{
+		"b-1": {ID: "b-1", UserID: "u-1", ZoneID: "zone-1", Status: domain.BookingStatusConfirmed},
+		"b-2": {ID: "b-2", UserID: "u-2", ZoneID: "zone-1", Status: domain.BookingStatusReserved},
+		"b-3": {ID: "b-3", UserID: "u-3", ZoneID: "zone-1", Status: domain.BookingStatusConfirmed},
+	}}
+	reservationRepo := &countingReservationRepository{released: map[string]int{}}
+	w := NewSeatReleaseWorker(nil, bookingRepo, reservationRepo, nil, nil, &SeatReleaseWorkerConfig{WorkerCount: 1})
+
+	w.processRecords(context.Background(), []*kafka.Record{
+		newSeatReleaseRecordWithReason(t, 0, "b-1", SeatReleaseReasonPaymentRefunded),
+		newSeatReleaseRecordWithReason(t, 0, "b-2", SeatReleaseReasonPaymentRefunded),
+		newSeatReleaseRecord(t, 0, "b-3"), // Failed payments never cancel a paid booking
+	})
+
+	if b1 := bookingRepo.bookings["b-1"]; b1.Status != domain.BookingStatusCancelled || b1.CancelledAt == nil {
+		t.Errorf("refunded confirmed booking = %s, want cancelled", b1.Status)
+	}
+	if len(reservationRepo.soldReleased) != 1 || reservationRepo.soldReleased[0] != "b-1" {
+		t.Errorf("sold seats released = %v, want b-1", reservationRepo.soldReleased)
+	}
+	// A refunded reservation is released as usual
+	if reservationRepo.released["b-2"] != 1 || len(bookingRepo.cancelled) != 1 || bookingRepo.cancelled[0] != "b-2" {
+		t.Errorf("released = %v, cancelled = %v, want b-2 released", reservationRepo.released, bookingRepo.cancelled)
+	}
+	if bookingRepo.bookings["b-3"].Status != domain.BookingStatusConfirmed {
+		t.Errorf("confirmed booking with a failed payment = %s, want confirmed", bookingRepo.bookings["b-3"].Status)
+	}
+}
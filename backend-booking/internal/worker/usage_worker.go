@@ -0,0 +1,139 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/metering"
+)
+
+// UsageWorkerConfig holds configuration for the usage metering worker
+type UsageWorkerConfig struct {
+	// RetryInterval is the wait between attempts to write a batch (default: 2 seconds)
+	RetryInterval time.Duration
+}
+
+// UsageWorker counts booking events per tenant into the daily usage aggregates
+// shared with the API gateway. Every event counts as published, booking.created
+// also counts as a booking. Events are bucketed by the UTC day they occurred.
+type UsageWorker struct {
+	config   *UsageWorkerConfig
+	consumer *kafka.Consumer
+	store    metering.Store
+	log      *logger.Logger
+}
+
+// NewUsageWorker creates a new usage metering worker
+func NewUsageWorker(cfg *UsageWorkerConfig, consumer *kafka.Consumer, store metering.Store, log *logger.Logger) *UsageWorker {
+	if cfg == nil {
+		cfg = &UsageWorkerConfig{}
+	}
+	if cfg.RetryInterval <= 0 {
+		cfg.RetryInterval = 2 * time.Second
+	}
+
+	return &UsageWorker{
+		config:   cfg,
+		consumer: consumer,
+		store:    store,
+		log:      log,
+	}
+}
+
+// Start consumes booking events until ctx is cancelled
+func (w *UsageWorker) Start(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			records, err := w.consumer.Poll(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				w.log.Error(fmt.Sprintf("Failed to poll Kafka: %v", err))
+				time.Sleep(time.Second)
+				continue
+			}
+
+			if len(records) == 0 {
+				continue
+			}
+
+			// Offsets are only committed once the batch is stored, so a crash re-counts
+			// at most one batch instead of losing it
+			if !w.write(ctx, w.aggregate(records)) {
+				return
+			}
+			if err := w.consumer.CommitRecords(ctx, records); err != nil {
+				w.log.Error(fmt.Sprintf("Failed to commit offsets: %v", err))
+			}
+		}
+	}
+}
+
+// aggregate sums the usage of a batch of records by day and tenant
+func (w *UsageWorker) aggregate(records []*kafka.Record) map[int64]map[string]metering.Counts {
+	usage := make(map[int64]map[string]metering.Counts)
+	for _, record := range records {
+		var event domain.BookingEvent
+		if err := json.Unmarshal(record.Value, &event); err != nil {
+			w.log.Error(fmt.Sprintf("Failed to unmarshal booking event: %v", err))
+			continue
+		}
+		if event.BookingData == nil || event.BookingData.TenantID == "" {
+			continue
+		}
+
+		occurredAt := event.OccurredAt
+		if occurredAt.IsZero() {
+			occurredAt = time.Now()
+		}
+		day := metering.Day(occurredAt).Unix()
+
+		tenants, ok := usage[day]
+		if !ok {
+			tenants = make(map[string]metering.Counts)
+			usage[day] = tenants
+		}
+		counts, ok := tenants[event.BookingData.TenantID]
+		if !ok {
+			counts = make(metering.Counts)
+			tenants[event.BookingData.TenantID] = counts
+		}
+
+		counts[metering.MetricEventsPublished]++
+		if event.EventType == domain.BookingEventCreated {
+			counts[metering.MetricBookingsCreated]++
+		}
+	}
+	return usage
+}
+
+// write stores the usage of every day, retrying until it succeeds.
+// Returns false if ctx was cancelled first.
+func (w *UsageWorker) write(ctx context.Context, usage map[int64]map[string]metering.Counts) bool {
+	for day, tenants := range usage {
+		for {
+			err := w.store.Add(ctx, time.Unix(day, 0).UTC(), tenants)
+			if err == nil {
+				break
+			}
+			w.log.Error(fmt.Sprintf("Failed to store usage, retrying in %v: %v", w.config.RetryInterval, err))
+
+			select {
+			case <-ctx.Done():
+				return false
+			case <-time.After(w.config.RetryInterval):
+			}
+		}
+		delete(usage, day)
+	}
+	return true
+}
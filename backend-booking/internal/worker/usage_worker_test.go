@@ -0,0 +1,81 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/metering"
+)
+
+// flakyUsageStore fails the next failures writes, then stores normally
+type flakyUsageStore struct {
+	*metering.MemoryStore
+	failures int
+}
+
+func (s *flakyUsageStore) Add(ctx context.Context, day time.Time, usage map[string]metering.Counts) error {
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("redis unavailable")
+	}
+	return s.MemoryStore.Add(ctx, day, usage)
+}
+
+func newUsageRecord(t *testing.T, eventType domain.BookingEventType, tenantID string, occurredAt time.Time) *kafka.Record {
+	t.Helper()
+	value, err := json.Marshal(domain.BookingEvent{
+		EventType:   eventType,
+		OccurredAt:  occurredAt,
+		BookingData: &domain.BookingEventData{TenantID: tenantID},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal event: %v", err)
+	}
+	return &kafka.Record{Topic: "booking-events", Value: value}
+}
+
+func TestUsageWorker_AggregatesByTenantAndDay(t *testing.T) {
+	store := &flakyUsageStore{MemoryStore: metering.NewMemoryStore(0), failures: 1}
+	w := NewUsageWorker(&UsageWorkerConfig{RetryInterval: time.Millisecond}, nil, store, logger.Get())
+
+	today := time.Date(2026, 5, 2, 0, 30, 0, 0, time.UTC)
+	yesterday := today.Add(-time.Hour)
+	records := []*kafka.Record{
+		newUsageRecord(t, domain.BookingEventCreated, "tenant-1", today),
+		newUsageRecord(t, domain.BookingEventConfirmed, "tenant-1", today),
+		newUsageRecord(t, domain.BookingEventCreated, "tenant-1", yesterday),
+		newUsageRecord(t, domain.BookingEventCreated, "", today),
+		{Topic: "booking-events", Value: []byte("not json")},
+	}
+
+	if !w.write(context.Background(), w.aggregate(records)) {
+		t.Fatal("write() = false, want true")
+	}
+
+	usage, _ := store.Daily(context.Background(), "tenant-1", []time.Time{yesterday, today})
+	if usage[0].Counts[metering.MetricBookingsCreated] != 1 || usage[0].Counts[metering.MetricEventsPublished] != 1 {
+		t.Errorf("yesterday = %v, want 1 booking and 1 event", usage[0].Counts)
+	}
+	if usage[1].Counts[metering.MetricBookingsCreated] != 1 || usage[1].Counts[metering.MetricEventsPublished] != 2 {
+		t.Errorf("today = %v, want 1 booking and 2 events", usage[1].Counts)
+	}
+}
+
+func TestUsageWorker_WriteStopsOnCancel(t *testing.T) {
+	store := &flakyUsageStore{MemoryStore: metering.NewMemoryStore(0), failures: 1 << 30}
+	w := NewUsageWorker(&UsageWorkerConfig{RetryInterval: time.Millisecond}, nil, store, logger.Get())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	records := []*kafka.Record{newUsageRecord(t, domain.BookingEventCreated, "tenant-1", time.Now())}
+	if w.write(ctx, w.aggregate(records)) {
+		t.Error("write() = true, want false once the context is cancelled")
+	}
+}
@@ -0,0 +1,301 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/events"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+)
+
+// Webhook request headers sent with every delivery
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature" // t={unix},v1={hex hmac-sha256 of "{t}.{body}"}
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+	webhookUserAgent       = "BookingRush-Webhooks/1.0"
+)
+
+// WebhookWorkerConfig holds configuration for the webhook worker
+type WebhookWorkerConfig struct {
+	BookingTopic   string        // Topic carrying booking.confirmed and booking.cancelled
+	PaymentTopic   string        // Topic carrying payment.refunded from payment-service
+	PollInterval   time.Duration // Interval between polling for due deliveries
+	BatchSize      int           // Max deliveries sent per poll
+	RetryBaseDelay time.Duration // Delay after the first failed attempt, doubled per attempt
+	RetryMaxDelay  time.Duration // Upper bound on the retry delay
+	RequestTimeout time.Duration // Timeout for a single POST to a subscriber
+	ClaimLease     time.Duration // How long a claimed delivery is hidden from other workers
+}
+
+// DefaultWebhookWorkerConfig returns default configuration
+func DefaultWebhookWorkerConfig() *WebhookWorkerConfig {
+	return &WebhookWorkerConfig{
+		BookingTopic:   events.TopicBookingEvents,
+		PaymentTopic:   events.TopicPaymentEvents,
+		PollInterval:   time.Second,
+		BatchSize:      50,
+		RetryBaseDelay: 30 * time.Second,
+		RetryMaxDelay:  time.Hour,
+		RequestTimeout: 10 * time.Second,
+		ClaimLease:     time.Minute,
+	}
+}
+
+// PaymentRefundedEvent is the payment.refunded or payment.refund_failed event published
+// by payment-service
+type PaymentRefundedEvent = events.PaymentEvent
+
+// PaymentRefundedEventData contains the refunded payment
+type PaymentRefundedEventData = events.PaymentEventData
+
+// WebhookWorker turns booking lifecycle events into per-tenant webhook deliveries
+// and sends due deliveries with HMAC signatures, retrying failures with exponential backoff.
+type WebhookWorker struct {
+	config           *WebhookWorkerConfig
+	consumer         *kafka.Consumer
+	webhookService   service.WebhookService
+	subscriptionRepo repository.WebhookSubscriptionRepository
+	deliveryRepo     repository.WebhookDeliveryRepository
+	httpClient       *http.Client
+	log              *logger.Logger
+	now              func() time.Time
+}
+
+// NewWebhookWorker creates a new webhook worker
+func NewWebhookWorker(
+	cfg *WebhookWorkerConfig,
+	consumer *kafka.Consumer,
+	webhookService service.WebhookService,
+	subscriptionRepo repository.WebhookSubscriptionRepository,
+	deliveryRepo repository.WebhookDeliveryRepository,
+	log *logger.Logger,
+) *WebhookWorker {
+	defaults := DefaultWebhookWorkerConfig()
+	if cfg == nil {
+		cfg = defaults
+	}
+	if cfg.BookingTopic == "" {
+		cfg.BookingTopic = defaults.BookingTopic
+	}
+	if cfg.PaymentTopic == "" {
+		cfg.PaymentTopic = defaults.PaymentTopic
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaults.PollInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaults.BatchSize
+	}
+	if cfg.RetryBaseDelay <= 0 {
+		cfg.RetryBaseDelay = defaults.RetryBaseDelay
+	}
+	if cfg.RetryMaxDelay <= 0 {
+		cfg.RetryMaxDelay = defaults.RetryMaxDelay
+	}
+	if cfg.RequestTimeout <= 0 {
+		cfg.RequestTimeout = defaults.RequestTimeout
+	}
+	if cfg.ClaimLease <= 0 {
+		cfg.ClaimLease = defaults.ClaimLease
+	}
+
+	return &WebhookWorker{
+		config:           cfg,
+		consumer:         consumer,
+		webhookService:   webhookService,
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+		httpClient:       &http.Client{Timeout: cfg.RequestTimeout},
+		log:              log,
+		now:              time.Now,
+	}
+}
+
+// Start begins consuming events and dispatching due deliveries until ctx is cancelled
+func (w *WebhookWorker) Start(ctx context.Context) {
+	if w.consumer != nil {
+		go w.consumeLoop(ctx)
+	}
+
+	ticker := time.NewTicker(w.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.log.Info("Webhook worker context cancelled")
+			return
+		case <-ticker.C:
+			w.dispatchDue(ctx)
+		}
+	}
+}
+
+// consumeLoop continuously polls for new events
+func (w *WebhookWorker) consumeLoop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			records, err := w.consumer.Poll(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				w.log.Error(fmt.Sprintf("Failed to poll Kafka: %v", err))
+				time.Sleep(time.Second)
+				continue
+			}
+
+			if len(records) == 0 {
+				continue
+			}
+
+			for _, record := range records {
+				if err := w.processRecord(ctx, record); err != nil {
+					w.log.Error(fmt.Sprintf("Failed to enqueue webhook for record: %v", err))
+				}
+			}
+
+			// Commit offsets after the deliveries are stored; redelivered events are deduplicated
+			if err := w.consumer.CommitRecords(ctx, records); err != nil {
+				w.log.Error(fmt.Sprintf("Failed to commit offsets: %v", err))
+			}
+		}
+	}
+}
+
+// processRecord enqueues webhook deliveries for a single Kafka record.
+// Events without a tenant or of types nobody can subscribe to are skipped.
+func (w *WebhookWorker) processRecord(ctx context.Context, record *kafka.Record) error {
+	if record.Topic == w.config.PaymentTopic {
+		var event PaymentRefundedEvent
+		if err := json.Unmarshal(record.Value, &event); err != nil {
+			return fmt.Errorf("failed to unmarshal payment event: %w", err)
+		}
+		if domain.WebhookEventType(event.EventType) != domain.WebhookEventPaymentRefunded ||
+			event.PaymentData == nil || event.PaymentData.TenantID == "" {
+			return nil
+		}
+		return w.enqueue(ctx, event.PaymentData.TenantID, domain.WebhookEventPaymentRefunded, event.EventID, event.OccurredAt, event.PaymentData)
+	}
+
+	var event domain.BookingEvent
+	if err := json.Unmarshal(record.Value, &event); err != nil {
+		return fmt.Errorf("failed to unmarshal booking event: %w", err)
+	}
+
+	var eventType domain.WebhookEventType
+	switch event.EventType {
+	case domain.BookingEventConfirmed:
+		eventType = domain.WebhookEventBookingConfirmed
+	case domain.BookingEventCancelled:
+		eventType = domain.WebhookEventBookingCancelled
+	default:
+		return nil
+	}
+	if event.BookingData == nil || event.BookingData.TenantID == "" {
+		return nil
+	}
+
+	return w.enqueue(ctx, event.BookingData.TenantID, eventType, event.EventID, event.OccurredAt, event.BookingData)
+}
+
+func (w *WebhookWorker) enqueue(ctx context.Context, tenantID string, eventType domain.WebhookEventType, eventID string, occurredAt time.Time, data interface{}) error {
+	created, err := w.webhookService.EnqueueEvent(ctx, tenantID, eventType, eventID, occurredAt, data)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue %s %s: %w", eventType, eventID, err)
+	}
+	if created > 0 {
+		w.log.Info(fmt.Sprintf("Queued %d webhook deliveries for %s %s (tenant %s)", created, eventType, eventID, tenantID))
+	}
+	return nil
+}
+
+// dispatchDue claims due deliveries and sends them
+func (w *WebhookWorker) dispatchDue(ctx context.Context) {
+	deliveries, err := w.deliveryRepo.ClaimDue(ctx, w.config.BatchSize, w.config.ClaimLease)
+	if err != nil {
+		w.log.Error(fmt.Sprintf("Failed to claim due webhook deliveries: %v", err))
+		return
+	}
+
+	for _, d := range deliveries {
+		if ctx.Err() != nil {
+			return
+		}
+		w.deliver(ctx, d)
+	}
+}
+
+// deliver sends one delivery attempt and records its outcome
+func (w *WebhookWorker) deliver(ctx context.Context, d *domain.WebhookDelivery) {
+	sub, err := w.subscriptionRepo.GetByID(ctx, d.TenantID, d.SubscriptionID)
+	if err != nil && !domain.IsNotFoundError(err) {
+		// Leave the delivery pending; it is picked up again once the lease expires
+		w.log.Error(fmt.Sprintf("Failed to load webhook subscription %s: %v", d.SubscriptionID, err))
+		return
+	}
+
+	if sub == nil || !sub.Active {
+		// Disabled endpoints get no further attempts; the tenant can retry after re-enabling
+		d.MaxAttempts = d.Attempts + 1
+		d.MarkAttemptFailed(0, "subscription is inactive", 0)
+	} else {
+		statusCode, sendErr := w.send(ctx, sub, d)
+		if sendErr == nil {
+			d.MarkDelivered(statusCode)
+		} else {
+			delay := domain.WebhookRetryDelay(d.Attempts+1, w.config.RetryBaseDelay, w.config.RetryMaxDelay)
+			d.MarkAttemptFailed(statusCode, sendErr.Error(), delay)
+			if d.Status == domain.WebhookDeliveryFailed {
+				w.log.Warn(fmt.Sprintf("Webhook delivery %s to %s failed after %d attempts: %v", d.ID, sub.URL, d.Attempts, sendErr))
+			}
+		}
+	}
+
+	if err := w.deliveryRepo.Update(ctx, d); err != nil {
+		w.log.Error(fmt.Sprintf("Failed to update webhook delivery %s: %v", d.ID, err))
+	}
+}
+
+// send POSTs the signed payload; any non-2xx response is an error
+func (w *WebhookWorker) send(ctx context.Context, sub *domain.WebhookSubscription, d *domain.WebhookDelivery) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, w.config.RequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	timestamp := w.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", webhookUserAgent)
+	req.Header.Set(WebhookEventHeader, d.EventType.String())
+	req.Header.Set(WebhookDeliveryHeader, d.ID)
+	req.Header.Set(WebhookSignatureHeader, domain.WebhookSignatureHeader(sub.Secret, timestamp, d.Payload))
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10)) // Drain so the connection can be reused
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
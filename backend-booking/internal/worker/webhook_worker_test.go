@@ -0,0 +1,297 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+)
+
+// fakeWebhookSubscriptionRepo is an in-memory WebhookSubscriptionRepository
+type fakeWebhookSubscriptionRepo struct {
+	subs []*domain.WebhookSubscription
+}
+
+func (r *fakeWebhookSubscriptionRepo) Create(ctx context.Context, sub *domain.WebhookSubscription) error {
+	r.subs = append(r.subs, sub)
+	return nil
+}
+
+func (r *fakeWebhookSubscriptionRepo) GetByID(ctx context.Context, tenantID, id string) (*domain.WebhookSubscription, error) {
+	for _, s := range r.subs {
+		if s.TenantID == tenantID && s.ID == id {
+			return s, nil
+		}
+	}
+	return nil, domain.ErrWebhookSubscriptionNotFound
+}
+
+func (r *fakeWebhookSubscriptionRepo) ListByTenant(ctx context.Context, tenantID string) ([]*domain.WebhookSubscription, error) {
+	var out []*domain.WebhookSubscription
+	for _, s := range r.subs {
+		if s.TenantID == tenantID {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeWebhookSubscriptionRepo) ListActiveForEvent(ctx context.Context, tenantID string, eventType domain.WebhookEventType) ([]*domain.WebhookSubscription, error) {
+	var out []*domain.WebhookSubscription
+	for _, s := range r.subs {
+		if s.TenantID == tenantID && s.Subscribes(eventType) {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeWebhookSubscriptionRepo) Update(ctx context.Context, sub *domain.WebhookSubscription) error {
+	return nil
+}
+
+func (r *fakeWebhookSubscriptionRepo) Delete(ctx context.Context, tenantID, id string) error {
+	return nil
+}
+
+// fakeWebhookDeliveryRepo is an in-memory WebhookDeliveryRepository
+type fakeWebhookDeliveryRepo struct {
+	mu         sync.Mutex
+	deliveries []*domain.WebhookDelivery
+}
+
+func (r *fakeWebhookDeliveryRepo) Create(ctx context.Context, d *domain.WebhookDelivery) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.deliveries {
+		if existing.SubscriptionID == d.SubscriptionID && existing.EventID == d.EventID {
+			return false, nil
+		}
+	}
+	d.ID = "delivery-" + d.SubscriptionID + "-" + d.EventID
+	r.deliveries = append(r.deliveries, d)
+	return true, nil
+}
+
+func (r *fakeWebhookDeliveryRepo) GetByID(ctx context.Context, tenantID, id string) (*domain.WebhookDelivery, error) {
+	return nil, domain.ErrWebhookDeliveryNotFound
+}
+
+func (r *fakeWebhookDeliveryRepo) List(ctx context.Context, filter *repository.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error) {
+	return nil, nil
+}
+
+func (r *fakeWebhookDeliveryRepo) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*domain.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	var due []*domain.WebhookDelivery
+	for _, d := range r.deliveries {
+		if d.Status == domain.WebhookDeliveryPending && !d.NextAttemptAt.After(now) && len(due) < limit {
+			d.NextAttemptAt = now.Add(lease)
+			due = append(due, d)
+		}
+	}
+	return due, nil
+}
+
+func (r *fakeWebhookDeliveryRepo) Update(ctx context.Context, d *domain.WebhookDelivery) error {
+	return nil
+}
+
+func newTestWebhookWorker(subs []*domain.WebhookSubscription) (*WebhookWorker, *fakeWebhookDeliveryRepo) {
+	subRepo := &fakeWebhookSubscriptionRepo{subs: subs}
+	deliveryRepo := &fakeWebhookDeliveryRepo{}
+	svc := service.NewWebhookService(subRepo, deliveryRepo, &service.WebhookServiceConfig{MaxAttempts: 2})
+	w := NewWebhookWorker(&WebhookWorkerConfig{RetryBaseDelay: time.Minute}, nil, svc, subRepo, deliveryRepo, logger.Get())
+	w.now = func() time.Time { return time.Unix(1700000000, 0) }
+	return w, deliveryRepo
+}
+
+func bookingRecord(t *testing.T, eventType domain.BookingEventType, tenantID string) *kafka.Record {
+	t.Helper()
+	value, err := json.Marshal(&domain.BookingEvent{
+		EventID:     "evt-1",
+		EventType:   eventType,
+		OccurredAt:  time.Now(),
+		BookingData: &domain.BookingEventData{BookingID: "booking-1", TenantID: tenantID},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &kafka.Record{Topic: "booking-events", Value: value}
+}
+
+func TestWebhookWorker_ProcessRecord(t *testing.T) {
+	w, deliveryRepo := newTestWebhookWorker([]*domain.WebhookSubscription{
+		{ID: "sub-1", TenantID: "tenant-1", Active: true, EventTypes: []domain.WebhookEventType{domain.WebhookEventBookingConfirmed}},
+		{ID: "sub-2", TenantID: "tenant-1", Active: true, EventTypes: []domain.WebhookEventType{domain.WebhookEventPaymentRefunded}},
+		{ID: "sub-3", TenantID: "tenant-2", Active: true, EventTypes: []domain.WebhookEventType{domain.WebhookEventBookingConfirmed}},
+	})
+	ctx := context.Background()
+
+	// booking.created is not a webhook event; events without a tenant are skipped
+	for _, record := range []*kafka.Record{
+		bookingRecord(t, domain.BookingEventCreated, "tenant-1"),
+		bookingRecord(t, domain.BookingEventConfirmed, ""),
+	} {
+		if err := w.processRecord(ctx, record); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(deliveryRepo.deliveries) != 0 {
+		t.Fatalf("expected no deliveries, got %d", len(deliveryRepo.deliveries))
+	}
+
+	// Redelivered Kafka message must not queue a second delivery
+	for i := 0; i < 2; i++ {
+		if err := w.processRecord(ctx, bookingRecord(t, domain.BookingEventConfirmed, "tenant-1")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(deliveryRepo.deliveries) != 1 || deliveryRepo.deliveries[0].SubscriptionID != "sub-1" {
+		t.Fatalf("expected one delivery to sub-1, got %+v", deliveryRepo.deliveries)
+	}
+
+	refund, _ := json.Marshal(&PaymentRefundedEvent{
+		EventID:     "evt-2",
+		EventType:   "payment.refunded",
+		OccurredAt:  time.Now(),
+		PaymentData: &PaymentRefundedEventData{PaymentID: "pay-1", BookingID: "booking-1", TenantID: "tenant-1"},
+	})
+	if err := w.processRecord(ctx, &kafka.Record{Topic: "payment-events", Value: refund}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deliveryRepo.deliveries) != 2 || deliveryRepo.deliveries[1].SubscriptionID != "sub-2" {
+		t.Fatalf("expected refund delivery to sub-2, got %+v", deliveryRepo.deliveries)
+	}
+}
+
+func TestWebhookWorker_DispatchDue(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received []*http.Request
+		bodies   [][]byte
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		mu.Lock()
+		received = append(received, req)
+		bodies = append(bodies, body)
+		mu.Unlock()
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sub := &domain.WebhookSubscription{
+		ID:         "sub-1",
+		TenantID:   "tenant-1",
+		URL:        server.URL,
+		Secret:     "whsec_test",
+		Active:     true,
+		EventTypes: []domain.WebhookEventType{domain.WebhookEventBookingCancelled},
+	}
+	w, deliveryRepo := newTestWebhookWorker([]*domain.WebhookSubscription{sub})
+	ctx := context.Background()
+
+	if err := w.processRecord(ctx, bookingRecord(t, domain.BookingEventCancelled, "tenant-1")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	w.dispatchDue(ctx)
+
+	d := deliveryRepo.deliveries[0]
+	if d.Status != domain.WebhookDeliveryDelivered || d.Attempts != 1 || d.LastStatusCode != 200 {
+		t.Fatalf("unexpected delivery state: %+v", d)
+	}
+	if len(received) != 1 {
+		t.Fatalf("expected 1 request, got %d", len(received))
+	}
+
+	req := received[0]
+	if req.Header.Get(WebhookEventHeader) != "booking.cancelled" || req.Header.Get(WebhookDeliveryHeader) != d.ID {
+		t.Errorf("unexpected headers: %v", req.Header)
+	}
+	if got, want := req.Header.Get(WebhookSignatureHeader), domain.WebhookSignatureHeader("whsec_test", 1700000000, bodies[0]); got != want {
+		t.Errorf("signature = %q, want %q", got, want)
+	}
+}
+
+func TestWebhookWorker_DispatchDue_RetriesWithBackoff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	sub := &domain.WebhookSubscription{
+		ID:         "sub-1",
+		TenantID:   "tenant-1",
+		URL:        server.URL,
+		Secret:     "whsec_test",
+		Active:     true,
+		EventTypes: []domain.WebhookEventType{domain.WebhookEventBookingConfirmed},
+	}
+	w, deliveryRepo := newTestWebhookWorker([]*domain.WebhookSubscription{sub})
+	ctx := context.Background()
+
+	if err := w.processRecord(ctx, bookingRecord(t, domain.BookingEventConfirmed, "tenant-1")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	before := time.Now()
+	w.dispatchDue(ctx)
+
+	d := deliveryRepo.deliveries[0]
+	if d.Status != domain.WebhookDeliveryPending || d.Attempts != 1 || d.LastStatusCode != 503 {
+		t.Fatalf("expected pending after first failure, got %+v", d)
+	}
+	if d.NextAttemptAt.Before(before.Add(time.Minute)) {
+		t.Errorf("expected retry scheduled at least 1m later, got %v", d.NextAttemptAt)
+	}
+
+	// Not due yet, so nothing is sent
+	w.dispatchDue(ctx)
+	if d.Attempts != 1 {
+		t.Fatalf("expected no attempt before the retry is due, got %d attempts", d.Attempts)
+	}
+
+	d.NextAttemptAt = time.Now()
+	w.dispatchDue(ctx)
+	if d.Status != domain.WebhookDeliveryFailed || d.Attempts != 2 {
+		t.Errorf("expected failed after max attempts, got %s with %d attempts", d.Status, d.Attempts)
+	}
+}
+
+func TestWebhookWorker_DispatchDue_InactiveSubscription(t *testing.T) {
+	sub := &domain.WebhookSubscription{
+		ID:         "sub-1",
+		TenantID:   "tenant-1",
+		URL:        "http://127.0.0.1:0",
+		Active:     true,
+		EventTypes: []domain.WebhookEventType{domain.WebhookEventBookingConfirmed},
+	}
+	w, deliveryRepo := newTestWebhookWorker([]*domain.WebhookSubscription{sub})
+	ctx := context.Background()
+
+	if err := w.processRecord(ctx, bookingRecord(t, domain.BookingEventConfirmed, "tenant-1")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sub.Active = false
+	w.dispatchDue(ctx)
+
+	d := deliveryRepo.deliveries[0]
+	if d.Status != domain.WebhookDeliveryFailed || d.LastError != "subscription is inactive" {
+		t.Errorf("expected inactive subscription to fail the delivery, got %+v", d)
+	}
+}
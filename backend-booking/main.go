@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net/http"
@@ -9,25 +10,57 @@ import (
 	"os"
 	"os/signal"
 	"runtime"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/chaos"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/di"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/dualwrite"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/fastpath"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/handler"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/metrics"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/saga"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/sharding"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/worker"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/config"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/events"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/i18n"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/id"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/middleware"
 	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
 	pkgsaga "github.com/prohmpiriya/booking-rush-10k-rps/pkg/saga"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/smoke"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
 )
 
+// defaultSLOObjectives are the latency budgets used when SLO_OBJECTIVES is not set
+const defaultSLOObjectives = "POST /api/v1/bookings/reserve=p99:200ms,POST /api/v1/bookings/:id/confirm=p99:500ms"
+
+// defaultRequestBodyLimits are the per-route body limits used when REQUEST_BODY_ROUTE_LIMITS is not set
+const defaultRequestBodyLimits = "POST /api/v1/bookings/reserve=16KB,POST /api/v1/bookings/:id/confirm=16KB"
+
+// defaultRedisMemoryBudgets are the namespace budgets used when REDIS_MEMORY_BUDGETS is not set.
+// Queue sorted sets live as long as their event; everything else held per user must expire.
+const defaultRedisMemoryBudgets = "queue:=512MB,qstream:=512MB,queue:pass:=128MB+ttl,qanalytics:=64MB+ttl,idempotency:=128MB+ttl," +
+	"booking:idempotency:=128MB+ttl,booking:idle:=64MB+ttl,reservation:=512MB+ttl"
+
+// topicSpecs are the Kafka topics the booking service publishes to
+var topicSpecs = []kafka.TopicSpec{
+	{Name: events.TopicBookingEvents, Retention: 7 * 24 * time.Hour},
+	{Name: service.DefaultStandbyOfferTopic, Retention: 24 * time.Hour},
+}
+
 func main() {
+	smokeTest := flag.Bool("smoke", false, "check dependencies end to end and exit non-zero on failure")
+	flag.Parse()
+
 	// Optimize Go runtime for high concurrency
 	runtime.GOMAXPROCS(runtime.NumCPU())
 
@@ -39,7 +72,8 @@ func main() {
 
 	// Initialize logger with OTLP export support
 	logCfg := &logger.Config{
-		Level:        cfg.App.Environment,
+		Level:        cfg.Log.Level,
+		ModuleLevels: cfg.Log.ModuleLevels,
 		ServiceName:  "booking-service",
 		Development:  cfg.IsDevelopment(),
 		OTLPEnabled:  cfg.OTel.Enabled && cfg.OTel.LogExportEnabled,
@@ -54,16 +88,32 @@ func main() {
 	appLog := logger.Get()
 	appLog.Info("Starting Booking Service...")
 
+	required := []config.Requirement{config.RequireBookingDatabase, config.RequireRedis, config.RequireKafka, config.RequireJWT}
+	if err := cfg.ValidateFor(required...); err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
+	appLog.Info("Effective configuration: " + cfg.Summary(required...))
+
+	// Time-ordered primary keys for hot insert paths (ID_STRATEGY, ID_NODE_ID)
+	idGenerator, err := id.FromEnv()
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
+	id.SetDefault(idGenerator)
+
 	ctx := context.Background()
 
 	// Initialize OpenTelemetry
 	telemetryCfg := &telemetry.Config{
-		Enabled:        cfg.OTel.Enabled,
-		ServiceName:    "booking-service",
-		ServiceVersion: cfg.App.Version,
-		Environment:    cfg.App.Environment,
-		CollectorAddr:  cfg.OTel.CollectorAddr,
-		SampleRatio:    cfg.OTel.SampleRatio,
+		Enabled:         cfg.OTel.Enabled,
+		ServiceName:     "booking-service",
+		ServiceVersion:  cfg.App.Version,
+		Environment:     cfg.App.Environment,
+		CollectorAddr:   cfg.OTel.CollectorAddr,
+		SampleRatio:     cfg.OTel.SampleRatio,
+		DynamicSampling: cfg.OTel.DynamicSampling,
+		SlowThreshold:   cfg.OTel.SlowThreshold,
+		SamplingRoutes:  cfg.OTel.SamplingRoutes,
 	}
 	if _, err := telemetry.Init(ctx, telemetryCfg); err != nil {
 		appLog.Warn(fmt.Sprintf("Failed to initialize telemetry: %v", err))
@@ -72,24 +122,30 @@ func main() {
 	}
 	defer telemetry.Shutdown(ctx)
 
+	// Initialize booking and saga metrics (no-op when telemetry is disabled)
+	if err := metrics.Init(); err != nil {
+		appLog.Warn(fmt.Sprintf("Failed to initialize metrics: %v", err))
+	}
+
 	// Initialize database connection with optimized settings for 10k RPS
 	// Uses BookingDatabase config (Microservice - each service has its own database)
 	var db *database.PostgresDB
 	dbCfg := &database.PostgresConfig{
-		Host:            cfg.BookingDatabase.Host,
-		Port:            cfg.BookingDatabase.Port,
-		User:            cfg.BookingDatabase.User,
-		Password:        cfg.BookingDatabase.Password,
-		Database:        cfg.BookingDatabase.DBName,
-		SSLMode:         cfg.BookingDatabase.SSLMode,
-		MaxConns:        20,               // Optimized: Virtual Queue controls traffic, Redis handles inventory
-		MinConns:        5,
-		MaxConnLifetime: 30 * time.Minute, // Reduce to prevent stale connections
-		MaxConnIdleTime: 5 * time.Minute,  // Close idle connections sooner
-		ConnectTimeout:  5 * time.Second,  // Fast fail
-		MaxRetries:      3,
-		RetryInterval:   1 * time.Second,
-		EnableTracing:   cfg.OTel.Enabled,
+		Host:               cfg.BookingDatabase.Host,
+		Port:               cfg.BookingDatabase.Port,
+		User:               cfg.BookingDatabase.User,
+		Password:           cfg.BookingDatabase.Password,
+		Database:           cfg.BookingDatabase.DBName,
+		SSLMode:            cfg.BookingDatabase.SSLMode,
+		MaxConns:           20, // Optimized: Virtual Queue controls traffic, Redis handles inventory
+		MinConns:           5,
+		MaxConnLifetime:    30 * time.Minute, // Reduce to prevent stale connections
+		MaxConnIdleTime:    5 * time.Minute,  // Close idle connections sooner
+		ConnectTimeout:     5 * time.Second,  // Fast fail
+		MaxRetries:         3,
+		RetryInterval:      1 * time.Second,
+		EnableTracing:      cfg.OTel.Enabled,
+		SlowQueryThreshold: cfg.BookingDatabase.SlowQueryThreshold,
 	}
 	db, err = database.NewPostgres(ctx, dbCfg)
 	if err != nil {
@@ -98,23 +154,34 @@ func main() {
 	defer db.Close()
 	appLog.Info(fmt.Sprintf("Database connected (pool: min=%d, max=%d)", dbCfg.MinConns, dbCfg.MaxConns))
 
+	// Tenants assigned to a shard keep their bookings in that shard's database
+	tenantShards, err := database.OpenShardResolver(ctx, db, dbCfg, cfg.BookingDatabase.Shards, cfg.BookingDatabase.ShardRefreshInterval)
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("Tenant shards unavailable: %v", err))
+	}
+	if tenantShards != nil {
+		defer tenantShards.Close()
+		appLog.Info(fmt.Sprintf("Tenant shards enabled: %s", strings.Join(tenantShards.Shards(), ", ")))
+	}
+
 	// Initialize Redis connection with optimized settings for 10k RPS
 	var redisClient *pkgredis.Client
 	redisCfg := &pkgredis.Config{
-		Host:          cfg.Redis.Host,
-		Port:          cfg.Redis.Port,
-		Password:      cfg.Redis.Password,
-		DB:            cfg.Redis.DB,
-		PoolSize:      500, // Large pool for 10k RPS
-		MinIdleConns:  100, // Keep connections ready
-		MaxRetries:    3,
-		RetryInterval: 100 * time.Millisecond,
-		DialTimeout:   5 * time.Second,
-		ReadTimeout:   3 * time.Second,
-		WriteTimeout:  3 * time.Second,
-		PoolTimeout:   4 * time.Second,
-		EnableTracing: cfg.OTel.Enabled,
-		ServiceName:   "booking-service",
+		Host:                 cfg.Redis.Host,
+		Port:                 cfg.Redis.Port,
+		Password:             cfg.Redis.Password,
+		DB:                   cfg.Redis.DB,
+		PoolSize:             500, // Large pool for 10k RPS
+		MinIdleConns:         100, // Keep connections ready
+		MaxRetries:           3,
+		RetryInterval:        100 * time.Millisecond,
+		DialTimeout:          5 * time.Second,
+		ReadTimeout:          3 * time.Second,
+		WriteTimeout:         3 * time.Second,
+		PoolTimeout:          4 * time.Second,
+		EnableTracing:        cfg.OTel.Enabled,
+		SlowCommandThreshold: cfg.Redis.SlowCommandThreshold,
+		ServiceName:          "booking-service",
 	}
 	redisClient, err = pkgredis.NewClient(ctx, redisCfg)
 	if err != nil {
@@ -123,11 +190,26 @@ func main() {
 	defer redisClient.Close()
 	appLog.Info(fmt.Sprintf("Redis connected (pool: %d, minIdle: %d)", redisCfg.PoolSize, redisCfg.MinIdleConns))
 
+	// Per-module log levels shared by every instance, changeable without a restart
+	go redisClient.WatchLogLevels(ctx, cfg.Log.RedisKey+":booking-service", cfg.Log.PollInterval)
+
+	// Check the Kafka topics this service owns (missing topics are created when
+	// KAFKA_AUTO_CREATE_TOPICS is set, drift from the specs is only reported)
+	if err := kafka.ProvisionTopics(ctx, &kafka.TopicManagerConfig{
+		Brokers:           cfg.Kafka.Brokers,
+		ClientID:          "booking-service-admin",
+		Partitions:        int32(cfg.Kafka.TopicPartitions),
+		ReplicationFactor: int16(cfg.Kafka.TopicReplicationFactor),
+		AutoCreate:        cfg.Kafka.AutoCreateTopics,
+	}, topicSpecs); err != nil {
+		appLog.Warn(fmt.Sprintf("Kafka topic check failed: %v", err))
+	}
+
 	// Initialize Kafka event publisher
 	var eventPublisher service.EventPublisher
 	eventPubCfg := &service.EventPublisherConfig{
 		Brokers:     cfg.Kafka.Brokers,
-		Topic:       "booking-events",
+		Topic:       events.TopicBookingEvents,
 		ServiceName: "booking-service",
 		ClientID:    cfg.Kafka.ClientID,
 		Logger:      service.NewZapLoggerAdapter(appLog),
@@ -140,6 +222,20 @@ func main() {
 		appLog.Info("Kafka event publisher connected")
 	}
 
+	// Initialize standby offer notifier (invitations are published for the notification service)
+	var standbyNotifier service.StandbyNotifier
+	standbyNotifier, err = service.NewKafkaStandbyNotifier(ctx, &service.StandbyNotifierConfig{
+		Brokers:     cfg.Kafka.Brokers,
+		Topic:       service.DefaultStandbyOfferTopic,
+		ServiceName: "booking-service",
+		Logger:      service.NewZapLoggerAdapter(appLog),
+	})
+	if err != nil {
+		appLog.Warn(fmt.Sprintf("Kafka connection failed, standby offers will not be notified: %v", err))
+		standbyNotifier = service.NewNoOpStandbyNotifier()
+	}
+	defer standbyNotifier.Close()
+
 	// Initialize Saga producer and store for saga-based bookings
 	var sagaProducer saga.SagaProducer
 	var sagaStore pkgsaga.Store
@@ -160,9 +256,71 @@ func main() {
 	}
 
 	// Initialize repositories
-	bookingRepo := repository.NewPostgresBookingRepository(db.Pool())
+	var bookingRepo repository.BookingRepository = repository.NewPostgresBookingRepository(db.Pool())
+	if tenantShards != nil {
+		bookingRepo = sharding.NewPostgresBookingRepository(tenantShards)
+	}
 	reservationRepo := repository.NewRedisReservationRepository(redisClient)
-	queueRepo := repository.NewRedisQueueRepository(redisClient)
+	var journalRetention time.Duration
+	if cfg.Booking.ReservationJournal.Enabled {
+		journalRetention = cfg.Booking.ReservationJournal.Retention
+		reservationRepo.SetJournalRetention(journalRetention)
+		appLog.Info(fmt.Sprintf("Reservation journal enabled (retention: %s)", journalRetention))
+	}
+	queueBackend, err := repository.NewQueueBackendFromConfig(redisClient, cfg.Booking.Queue.Backend, cfg.Booking.Queue.MigrateFrom)
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid queue backend: %v", err))
+	}
+	queueRepo := repository.NewRedisQueueRepositoryWithBackend(redisClient, queueBackend)
+	compensationRepo := repository.NewPostgresCompensationRepository(db.Pool())
+	refundBatchRepo := repository.NewPostgresRefundBatchRepository(db.Pool())
+	userDataRepo := repository.NewPostgresUserDataRepository(db.Pool())
+	searchRepo := repository.NewPostgresBookingSearchRepository(db.Pool())
+	standbyRepo := repository.NewRedisStandbyRepository(redisClient)
+	queueAnalyticsRepo := repository.NewRedisQueueAnalyticsRepository(redisClient)
+	webhookSubRepo := repository.NewPostgresWebhookSubscriptionRepository(db.Pool())
+	webhookDelivRepo := repository.NewPostgresWebhookDeliveryRepository(db.Pool())
+	seatMapRepo := repository.NewRedisSeatMapRepository(redisClient)
+	cartRepo := repository.NewRedisCartRepository(redisClient)
+	verificationRepo := repository.NewRedisVerificationPolicyRepository(redisClient)
+	zoneShardRepo := repository.NewRedisZoneShardRepository(redisClient)
+	priceTierRepo := repository.NewRedisPriceTierRepository(redisClient)
+	addOnRepo := repository.NewRedisAddOnRepository(redisClient)
+	idleRepo := repository.NewRedisIdleReservationRepository(redisClient)
+	reportRepo := repository.NewPostgresReportRepository(db.Pool())
+
+	// Deploys gate on the self-check of every dependency before taking traffic
+	if *smokeTest {
+		suite := newSmokeSuite(&smokeDeps{
+			DB:    db,
+			Redis: redisClient,
+			Kafka: kafka.TopicManagerConfig{
+				Brokers:  cfg.Kafka.Brokers,
+				ClientID: "booking-service-smoke",
+			},
+			Scripts: []smoke.Check{
+				{Name: "reservation", Run: reservationRepo.LoadScripts},
+				{Name: "queue", Run: queueRepo.LoadScripts},
+				{Name: "standby", Run: standbyRepo.LoadScripts},
+				{Name: "seat map", Run: seatMapRepo.LoadScripts},
+				{Name: "zone shard", Run: zoneShardRepo.LoadScripts},
+				{Name: "price tier", Run: priceTierRepo.LoadScripts},
+				{Name: "add-on", Run: addOnRepo.LoadScripts},
+			},
+			Bookings:      bookingRepo,
+			Compensations: compensationRepo,
+			RefundBatches: refundBatchRepo,
+			UserData:      userDataRepo,
+			Search:        searchRepo,
+			Webhooks:      webhookSubRepo,
+			Deliveries:    webhookDelivRepo,
+			Reports:       reportRepo,
+		})
+		if err := suite.Run(ctx, os.Stdout); err != nil {
+			appLog.Fatal(fmt.Sprintf("Smoke test failed: %v", err))
+		}
+		return
+	}
 
 	// Pre-load Lua scripts into Redis
 	if err := reservationRepo.LoadScripts(ctx); err != nil {
@@ -170,11 +328,185 @@ func main() {
 	} else {
 		appLog.Info("Reservation Lua scripts pre-loaded into Redis")
 	}
+	if err := zoneShardRepo.LoadScripts(ctx); err != nil {
+		appLog.Warn(fmt.Sprintf("Failed to pre-load zone shard Lua script: %v", err))
+	}
+	if err := priceTierRepo.LoadScripts(ctx); err != nil {
+		appLog.Warn(fmt.Sprintf("Failed to pre-load price tier Lua scripts: %v", err))
+	}
+	if err := addOnRepo.LoadScripts(ctx); err != nil {
+		appLog.Warn(fmt.Sprintf("Failed to pre-load add-on Lua scripts: %v", err))
+	}
 
 	if err := queueRepo.LoadScripts(ctx); err != nil {
 		appLog.Warn(fmt.Sprintf("Failed to pre-load queue Lua scripts: %v", err))
 	} else {
-		appLog.Info("Queue Lua scripts pre-loaded into Redis")
+		appLog.Info(fmt.Sprintf("Queue Lua scripts pre-loaded into Redis (backend=%s)", queueBackend.Name()))
+	}
+
+	if err := standbyRepo.LoadScripts(ctx); err != nil {
+		appLog.Warn(fmt.Sprintf("Failed to pre-load standby Lua scripts: %v", err))
+	} else {
+		appLog.Info("Standby Lua scripts pre-loaded into Redis")
+	}
+
+	if err := seatMapRepo.LoadScripts(ctx); err != nil {
+		appLog.Warn(fmt.Sprintf("Failed to pre-load seat map Lua scripts: %v", err))
+	} else {
+		appLog.Info("Seat map Lua scripts pre-loaded into Redis")
+	}
+
+	// Zones crossing the low stock threshold, selling out or coming back are published
+	// on Redis pub/sub and streamed to frontends over SSE
+	var availabilityStream *service.AvailabilityStream
+	if cfg.Booking.AvailabilityBroadcast.Enabled {
+		availabilityBroadcaster := repository.NewRedisAvailabilityBroadcaster(redisClient, cfg.Booking.AvailabilityBroadcast.LowStockThreshold)
+		reservationRepo.SetAvailabilityBroadcaster(availabilityBroadcaster)
+		availabilityStream = service.NewAvailabilityStream(availabilityBroadcaster)
+		go availabilityStream.Run(ctx)
+		appLog.Info(fmt.Sprintf("Zone availability broadcast enabled (low stock threshold: %d)", cfg.Booking.AvailabilityBroadcast.LowStockThreshold))
+	}
+
+	// Sampled availability reads are compared with PostgreSQL to surface counter drift
+	// between syncs; the comparison runs in the background and never slows a read
+	var availabilityVerifier *service.AvailabilityVerifier
+	if cfg.Booking.AvailabilityVerify.Enabled() {
+		// seat_zones is in ticket_db; a small pool is enough for sampled reads
+		ticketDB, err := database.NewPostgres(ctx, &database.PostgresConfig{
+			Host:          cfg.TicketDatabase.Host,
+			Port:          cfg.TicketDatabase.Port,
+			User:          cfg.TicketDatabase.User,
+			Password:      cfg.TicketDatabase.Password,
+			Database:      cfg.TicketDatabase.DBName,
+			SSLMode:       cfg.TicketDatabase.SSLMode,
+			MaxConns:      4,
+			MinConns:      1,
+			MaxRetries:    3,
+			RetryInterval: 1 * time.Second,
+		})
+		if err != nil {
+			appLog.Warn(fmt.Sprintf("Availability read verification disabled: ticket database unavailable: %v", err))
+		} else {
+			defer ticketDB.Close()
+			availabilityVerifier = service.NewAvailabilityVerifier(
+				repository.NewPostgresZoneInventoryRepository(ticketDB.Pool()),
+				&service.AvailabilityVerifierConfig{
+					SamplePercent: cfg.Booking.AvailabilityVerify.SamplePercent,
+					Workers:       cfg.Booking.AvailabilityVerify.Workers,
+					QueueSize:     cfg.Booking.AvailabilityVerify.QueueSize,
+					Timeout:       cfg.Booking.AvailabilityVerify.Timeout,
+				},
+			)
+			go availabilityVerifier.Run(ctx)
+			appLog.Info(fmt.Sprintf("Availability read verification enabled (sample: %g%%)", cfg.Booking.AvailabilityVerify.SamplePercent))
+		}
+	}
+
+	// Shadow mode mirrors reservation writes to a new Redis cluster and compares the
+	// results; cutover serves from the new cluster and keeps mirroring to the old one
+	var containerReservationRepo repository.ReservationRepository = reservationRepo
+	var reservationShadow *dualwrite.ReservationRepository
+	if cfg.Booking.ReservationShadow.Enabled() {
+		var closeShadow func()
+		reservationShadow, closeShadow, err = dualwrite.Open(ctx, &cfg.Booking.ReservationShadow, reservationRepo, *redisCfg, journalRetention)
+		if err != nil {
+			appLog.Fatal(fmt.Sprintf("Reservation shadow init failed: %v", err))
+		}
+		defer closeShadow()
+		containerReservationRepo = reservationShadow
+		appLog.Info(fmt.Sprintf("Reservation shadow enabled: mode=%s, shadow=%s:%d",
+			cfg.Booking.ReservationShadow.Mode, cfg.Booking.ReservationShadow.Host, cfg.Booking.ReservationShadow.Port))
+	}
+
+	// Chaos mode injects Lua failures, Redis latency and Kafka publish failures for
+	// failure testing under load (config validation rejects it in production)
+	var chaosInjector *chaos.Injector
+	if cfg.Booking.Chaos.Enabled {
+		chaosInjector, err = chaos.NewInjector(chaos.Config{
+			LuaFailureRate:   cfg.Booking.Chaos.LuaFailureRate,
+			RedisLatencyRate: cfg.Booking.Chaos.RedisLatencyRate,
+			RedisLatency:     cfg.Booking.Chaos.RedisLatency,
+			KafkaFailureRate: cfg.Booking.Chaos.KafkaFailureRate,
+			Seed:             cfg.Booking.Chaos.Seed,
+		})
+		if err != nil {
+			appLog.Fatal(fmt.Sprintf("Invalid chaos config: %v", err))
+		}
+		containerReservationRepo = chaos.NewReservationRepository(containerReservationRepo, chaosInjector)
+		eventPublisher = chaos.NewEventPublisher(eventPublisher, chaosInjector)
+		if sagaStore != nil {
+			sagaProducer = chaos.NewSagaProducer(sagaProducer, chaosInjector)
+		}
+		appLog.Warn(fmt.Sprintf("CHAOS MODE ENABLED: lua_failure_rate=%v, redis_latency_rate=%v (%v), kafka_failure_rate=%v",
+			cfg.Booking.Chaos.LuaFailureRate, cfg.Booking.Chaos.RedisLatencyRate,
+			cfg.Booking.Chaos.RedisLatency, cfg.Booking.Chaos.KafkaFailureRate))
+	}
+
+	// Audit Redis memory per key namespace so queue entries, idempotency records and
+	// holds are flagged before an on-sale pushes Redis to maxmemory
+	var memoryGovernor *pkgredis.MemoryGovernor
+	if cfg.Redis.MemoryAuditEnabled {
+		spec := cfg.Redis.MemoryBudgets
+		if spec == "" {
+			spec = defaultRedisMemoryBudgets
+		}
+		budgets, err := pkgredis.ParseNamespaceBudgets(spec)
+		if err != nil {
+			appLog.Fatal(fmt.Sprintf("Invalid REDIS_MEMORY_BUDGETS: %v", err))
+		}
+		memoryGovernor = pkgredis.NewMemoryGovernor(redisClient, &pkgredis.MemoryGovernorConfig{
+			Budgets:  budgets,
+			Interval: cfg.Redis.MemoryAuditInterval,
+		})
+		go memoryGovernor.Run(ctx)
+		appLog.Info(fmt.Sprintf("Redis memory audits enabled (%d budgets, interval: %v)", len(budgets), cfg.Redis.MemoryAuditInterval))
+	}
+
+	// Load-test mode lets the harness issue queue passes in bulk and reset an event
+	// between runs (config validation rejects it in production)
+	var loadTestConfig *service.LoadTestServiceConfig
+	if cfg.LoadTest.Enabled {
+		loadTestConfig = &service.LoadTestServiceConfig{
+			JWTSecret: cfg.JWT.Secret,
+			MaxBatch:  cfg.LoadTest.MaxBatch,
+			PassTTL:   cfg.LoadTest.TokenTTL,
+		}
+		appLog.Warn(fmt.Sprintf("LOAD TEST MODE ENABLED: max_batch=%d, pass_ttl=%v", cfg.LoadTest.MaxBatch, cfg.LoadTest.TokenTTL))
+	}
+
+	// Async confirm queues confirmations in Redis and answers 202 with a status token;
+	// workers in this process drain the queue
+	var confirmJobRepo repository.ConfirmJobRepository
+	if cfg.Booking.AsyncConfirm.Enabled {
+		confirmJobRepo = repository.NewRedisConfirmJobRepository(redisClient)
+		appLog.Info(fmt.Sprintf("Async confirm enabled: workers=%d, default=%v",
+			cfg.Booking.AsyncConfirm.Workers, cfg.Booking.AsyncConfirm.Default))
+	}
+
+	// Write-behind answers reservations once seats are held in Redis; workers in this
+	// process insert the bookings into PostgreSQL and publish booking.created
+	var persistQueue repository.BookingPersistQueue
+	if cfg.Booking.WriteBehind.Enabled {
+		persistQueue = repository.NewRedisBookingPersistQueue(redisClient)
+		appLog.Info(fmt.Sprintf("Write-behind reservations enabled: workers=%d, idempotency_ttl=%v",
+			cfg.Booking.WriteBehind.Workers, cfg.Booking.WriteBehind.IdempotencyTTL))
+	}
+
+	// Batched inserts let a burst of reservations share a few pool connections instead of
+	// holding one each (not needed with write-behind, whose workers insert off the hot path)
+	var insertBatcher *service.BookingInsertBatcher
+	if cfg.Booking.InsertBatch.Enabled && !cfg.Booking.WriteBehind.Enabled {
+		insertBatcher, err = service.NewBookingInsertBatcher(bookingRepo, &service.BookingInsertBatcherConfig{
+			MaxBatch: cfg.Booking.InsertBatch.MaxSize,
+			MaxDelay: cfg.Booking.InsertBatch.MaxDelay,
+			Flushers: cfg.Booking.InsertBatch.Flushers,
+		})
+		if err != nil {
+			// Tenant-sharded repositories insert one booking at a time
+			appLog.Warn(fmt.Sprintf("Batched booking inserts disabled: %v", err))
+		} else if err := insertBatcher.Start(); err != nil {
+			appLog.Fatal(fmt.Sprintf("Failed to start booking insert batcher: %v", err))
+		}
 	}
 
 	// Booking flow architecture:
@@ -199,35 +531,156 @@ func main() {
 	requireQueuePass := cfg.Booking.RequireQueuePass
 	appLog.Info(fmt.Sprintf("Virtual Queue: RequireQueuePass=%v", requireQueuePass))
 
+	// Revoked queue passes are looked up in an in-memory bloom filter on every reserve;
+	// the filter is reloaded from Redis in the background
+	queuePassRevocations := service.NewQueuePassRevocations(
+		repository.NewRedisQueuePassRevocationRepository(redisClient),
+		&service.QueuePassRevocationsConfig{
+			TTL:             cfg.Booking.QueuePass.RevocationTTL,
+			RefreshInterval: cfg.Booking.QueuePass.RevocationRefresh,
+		},
+	)
+	go queuePassRevocations.Run(ctx)
+
+	// Stateless passes skip the Redis lookup, so they must expire quickly
+	var queuePassTTL time.Duration
+	if cfg.Booking.QueuePass.Stateless {
+		queuePassTTL = cfg.Booking.QueuePass.StatelessTTL
+		appLog.Info(fmt.Sprintf("Stateless queue passes enabled: ttl=%v", queuePassTTL))
+	}
+
 	container := di.NewContainer(&di.ContainerConfig{
-		DB:              db,
-		Redis:           redisClient,
-		BookingRepo:     bookingRepo,
-		ReservationRepo: reservationRepo,
-		QueueRepo:       queueRepo,
-		EventPublisher:  eventPublisher,
+		DB:                 db,
+		Redis:              redisClient,
+		BookingRepo:        bookingRepo,
+		ReservationRepo:    containerReservationRepo,
+		QueueRepo:          queueRepo,
+		CompensationRepo:   compensationRepo,
+		RefundBatchRepo:    refundBatchRepo,
+		UserDataRepo:       userDataRepo,
+		StandbyRepo:        standbyRepo,
+		QueueAnalyticsRepo: queueAnalyticsRepo,
+		WebhookSubRepo:     webhookSubRepo,
+		WebhookDelivRepo:   webhookDelivRepo,
+		SeatMapRepo:        seatMapRepo,
+		CartRepo:           cartRepo,
+		SearchRepo:         searchRepo,
+		ConfirmJobRepo:     confirmJobRepo,
+		VerificationRepo:   verificationRepo,
+		PersistQueue:       persistQueue,
+		ZoneShardRepo:      zoneShardRepo,
+		PriceTierRepo:      priceTierRepo,
+		AddOnRepo:          addOnRepo,
+		IdleRepo:           idleRepo,
+		ReportScheduleRepo: reportRepo,
+		SalesReportRepo:    reportRepo,
+		SalesSnapshotRepo:  repository.NewPostgresSalesSnapshotRepository(db.Pool()),
+		EventPublisher:     eventPublisher,
 		ServiceConfig: &service.BookingServiceConfig{
-			ReservationTTL: reservationTTL,
-			MaxPerUser:     maxPerUser,
+			ReservationTTL:        reservationTTL,
+			MaxPerUser:            maxPerUser,
+			PaymentGraceWindow:    cfg.Booking.PaymentGrace.Window,
+			PaymentGraceMaxHold:   cfg.Booking.PaymentGrace.MaxHold,
+			ExternalPaymentWindow: cfg.Booking.PaymentGrace.ExternalWindow,
+			InsertBatcher:         insertBatcher,
 		},
 		QueueServiceConfig: &service.QueueServiceConfig{
 			QueueTTL:             30 * time.Minute,
 			MaxQueueSize:         0, // Unlimited
 			EstimatedWaitPerUser: 3, // 3 seconds per user
+			QueuePassTTL:         queuePassTTL,
 			JWTSecret:            cfg.JWT.Secret,
+			StatelessPasses:      cfg.Booking.QueuePass.Stateless,
+			Revocations:          queuePassRevocations,
+		},
+		StandbyServiceConfig: &service.StandbyServiceConfig{
+			OfferWindow: 2 * time.Minute, // Exclusive window to claim released seats
+			Notifier:    standbyNotifier,
+		},
+		CartServiceConfig: &service.CartServiceConfig{
+			RequireQueuePass: requireQueuePass, // Checkout must not bypass the virtual queue
+		},
+		ModificationConfig: &service.BookingModificationConfig{
+			MaxPerUser: maxPerUser,
+			PendingTTL: time.Minute,   // Seats held for a change that never settles are taken back by the next one
+			Cutoff:     2 * time.Hour, // Changes close two hours before the show
+		},
+		CancellationConfig: &service.BookingCancellationConfig{
+			QuoteTTL: 10 * time.Minute, // Default policy: full refund a week out, half two days out
+			Secret:   cfg.JWT.Secret,   // Signs quote tokens
+		},
+		IdleConfig: &service.IdleReservationServiceConfig{
+			Secret: cfg.JWT.Secret, // Verifies release links sent by idle-reservation-worker
+		},
+		VerificationConfig: &service.PolicyVerificationGateConfig{
+			ChallengeMaxAge: 30 * time.Minute, // How long a passed challenge clears high-risk users
 		},
-		TicketServiceURL: cfg.Services.TicketServiceURL, // For auto-sync zone on ZONE_NOT_FOUND
-		SagaProducer:     sagaProducer,                 // For post-payment saga
-		SagaStore:        sagaStore,                    // For saga state persistence
+		BookingStatusConfig: &service.BookingStatusConfig{
+			SourceTimeout: time.Second,     // Slower payment or saga lookups are reported unavailable
+			CacheTTL:      2 * time.Second, // Frontends poll the full status while an order settles
+		},
+		ZoneCatalogConfig: &service.ZoneCatalogConfig{
+			LocalSize: cfg.Booking.ZoneCache.LocalSize,
+			LocalTTL:  cfg.Booking.ZoneCache.LocalTTL,
+			RedisTTL:  cfg.Booking.ZoneCache.RedisTTL,
+		},
+		TicketServiceURL:   cfg.Services.TicketServiceURL,  // For auto-sync zone on ZONE_NOT_FOUND
+		AuthServiceURL:     cfg.Services.AuthServiceURL,    // For booking search by email
+		PaymentServiceURL:  cfg.Services.PaymentServiceURL, // For booking search by card and payment cross-references
+		InternalAuthSecret: cfg.InternalAuth.Secret,        // For cancelling shows on behalf of an admin
+		SagaProducer:       sagaProducer,                   // For post-payment saga
+		SagaStore:          sagaStore,                      // For saga state persistence
+		SagaStatsStore:     pkgsaga.NewPostgresStore(db.Pool()),
 		SagaServiceConfig: &service.SagaServiceConfig{
 			StepTimeout: 30 * time.Second,
 			MaxRetries:  2,
 		},
+		WriteBehindConfig: &service.WriteBehindServiceConfig{
+			IdempotencyTTL: cfg.Booking.WriteBehind.IdempotencyTTL,
+		},
+		AsyncConfirmConfig: &service.AsyncConfirmServiceConfig{
+			StatusTTL:   cfg.Booking.AsyncConfirm.StatusTTL,
+			MaxAttempts: cfg.Booking.AsyncConfirm.MaxAttempts,
+		},
 		BookingHandlerConfig: &handler.BookingHandlerConfig{
-			RequireQueuePass: requireQueuePass,
+			RequireQueuePass:    requireQueuePass,
+			AsyncConfirmDefault: cfg.Booking.AsyncConfirm.Default,
 		},
+		ChaosInjector:      chaosInjector,
+		TenantShards:       tenantShards,
+		ReservationShadow:  reservationShadow,
+		LoadTestConfig:     loadTestConfig,
+		MemoryGovernor:     memoryGovernor,
+		AvailabilityStream: availabilityStream,
+		// Set only when availability reads are verified
+		AvailabilityVerifier: availabilityVerifier,
 	})
 
+	// Drop cached zones when the inventory worker sees ticket-service update them
+	if container.ZoneCatalog != nil {
+		go container.ZoneCatalog.Run(ctx)
+	}
+
+	var confirmWorker *worker.ConfirmWorker
+	if container.AsyncConfirmService != nil {
+		confirmWorker = worker.NewConfirmWorker(container.AsyncConfirmService, &worker.ConfirmWorkerConfig{
+			Concurrency: cfg.Booking.AsyncConfirm.Workers,
+		})
+		if err := confirmWorker.Start(ctx); err != nil {
+			appLog.Fatal(fmt.Sprintf("Failed to start confirm worker: %v", err))
+		}
+	}
+
+	var persistWorker *worker.PersistWorker
+	if container.WriteBehindService != nil {
+		persistWorker = worker.NewPersistWorker(container.WriteBehindService, &worker.PersistWorkerConfig{
+			Concurrency: cfg.Booking.WriteBehind.Workers,
+		})
+		if err := persistWorker.Start(ctx); err != nil {
+			appLog.Fatal(fmt.Sprintf("Failed to start persist worker: %v", err))
+		}
+	}
+
 	// Setup Gin with optimized settings
 	gin.SetMode(gin.ReleaseMode) // Always use release mode for performance
 	gin.DisableConsoleColor()
@@ -243,6 +696,47 @@ func main() {
 		router.Use(telemetry.TraceHeaderMiddleware())
 	}
 
+	// Locale negotiated by the gateway, used to localize notifications
+	router.Use(i18n.Middleware())
+
+	// Reject oversized, non-JSON and abusive JSON bodies before any handler binds them
+	if cfg.RequestBody.Enabled {
+		spec := cfg.RequestBody.RouteLimits
+		if spec == "" {
+			spec = defaultRequestBodyLimits
+		}
+		routeLimits, err := middleware.ParseRouteBodyLimits(spec)
+		if err != nil {
+			appLog.Fatal(fmt.Sprintf("Invalid REQUEST_BODY_ROUTE_LIMITS: %v", err))
+		}
+		router.Use(middleware.RequestBodyMiddleware(&middleware.RequestBodyConfig{
+			MaxBytes:      cfg.RequestBody.MaxBytes,
+			RouteMaxBytes: routeLimits,
+			MaxJSONDepth:  cfg.RequestBody.MaxJSONDepth,
+		}))
+	}
+
+	// Track per-route latency percentiles against their SLOs
+	if cfg.SLO.Enabled {
+		spec := cfg.SLO.Objectives
+		if spec == "" {
+			spec = defaultSLOObjectives
+		}
+		objectives, err := middleware.ParseSLOObjectives(spec)
+		if err != nil {
+			appLog.Fatal(fmt.Sprintf("Invalid SLO_OBJECTIVES: %v", err))
+		}
+		sloTracker := middleware.NewSLOTracker(&middleware.SLOConfig{
+			Objectives:    objectives,
+			Window:        cfg.SLO.Window,
+			BreachWindows: cfg.SLO.BreachWindows,
+			MinSamples:    cfg.SLO.MinSamples,
+		})
+		router.Use(sloTracker.Middleware())
+		go sloTracker.Run(ctx)
+		appLog.Info(fmt.Sprintf("Latency SLO tracking enabled (%d objectives, window: %v)", len(objectives), cfg.SLO.Window))
+	}
+
 	// Health check endpoints
 	router.GET("/health", container.HealthHandler.Health)
 	router.GET("/ready", container.HealthHandler.Ready)
@@ -261,6 +755,9 @@ func main() {
 		})
 	})
 
+	// Audit trail of admin changes that have no record of their own (audit_logs table)
+	auditLogger := middleware.NewAuditLogger(middleware.DefaultAuditConfig(db.Pool()))
+
 	// API routes
 	v1 := router.Group("/api/v1")
 	{
@@ -273,9 +770,16 @@ func main() {
 			})
 		})
 
+		// Verify identity headers signed by the API Gateway instead of re-validating the JWT
+		internalAuth := middleware.InternalAuthMiddleware(&middleware.InternalAuthConfig{
+			Secret:        cfg.InternalAuth.Secret,
+			MaxClockSkew:  cfg.InternalAuth.MaxClockSkew,
+			AllowUnsigned: cfg.InternalAuth.AllowUnsigned,
+		})
+
 		// Booking routes - simplified middleware for performance
 		bookings := v1.Group("/bookings")
-		bookings.Use(userIDMiddleware()) // Extract user_id from header
+		bookings.Use(internalAuth, userIDMiddleware()) // Extract user_id from header
 
 		// Configure idempotency middleware for write operations
 		idempotencyConfig := middleware.DefaultIdempotencyConfig(redisClient.Client())
@@ -285,19 +789,46 @@ func main() {
 			// Write operations with idempotency
 			bookings.POST("/reserve", middleware.IdempotencyMiddleware(idempotencyConfig), container.BookingHandler.ReserveSeats)
 			bookings.POST("/:id/confirm", middleware.IdempotencyMiddleware(idempotencyConfig), container.BookingHandler.ConfirmBooking)
+			bookings.GET("/:id/confirm-status", container.BookingHandler.GetConfirmStatus) // Poll async confirmations
 			bookings.POST("/:id/cancel", middleware.IdempotencyMiddleware(idempotencyConfig), container.BookingHandler.CancelBooking)
 			bookings.DELETE("/:id", middleware.IdempotencyMiddleware(idempotencyConfig), container.BookingHandler.ReleaseBooking)
+			bookings.PATCH("/:id", middleware.IdempotencyMiddleware(idempotencyConfig), container.BookingHandler.ModifyBooking)
 
 			// Read operations without idempotency
 			bookings.GET("", container.BookingHandler.GetUserBookings)
 			bookings.GET("/summary", container.BookingHandler.GetUserBookingSummary) // Must be before /:id
 			bookings.GET("/pending", container.BookingHandler.GetPendingBookings)
+			bookings.GET("/:id/cancellation-quote", container.BookingHandler.GetCancellationQuote) // Refund if cancelled now
+			bookings.GET("/:id/full", container.BookingHandler.GetBookingFull)                     // Booking, payment, saga and ticket in one call
+
+			// Zone availability flips (SSE)
+			if container.AvailabilityHandler != nil {
+				bookings.GET("/events/:event_id/availability/stream", container.AvailabilityHandler.StreamAvailability)
+			}
+
+			// Standby list for sold-out zones (join via POST /reserve with join_standby)
+			bookings.GET("/standby/:zone_id", container.StandbyHandler.GetStandbyStatus)
+			bookings.DELETE("/standby/:zone_id", container.StandbyHandler.LeaveStandby)
 			bookings.GET("/:id", container.BookingHandler.GetBooking)
 		}
 
+		// Cart routes - multi-show checkout with one consolidated payment
+		cart := v1.Group("/cart")
+		cart.Use(internalAuth, userIDMiddleware()) // Extract user_id from header
+		{
+			cart.GET("", container.CartHandler.GetCart)
+			cart.DELETE("", container.CartHandler.ClearCart)
+			cart.POST("/items", container.CartHandler.AddItem)
+			cart.DELETE("/items/:item_id", container.CartHandler.RemoveItem)
+			cart.POST("/checkout", middleware.IdempotencyMiddleware(idempotencyConfig), container.CartHandler.Checkout)
+			cart.GET("/checkouts/:id", container.CartHandler.GetCheckout)
+			cart.POST("/checkouts/:id/confirm", middleware.IdempotencyMiddleware(idempotencyConfig), container.CartHandler.ConfirmCheckout)
+			cart.POST("/checkouts/:id/cancel", middleware.IdempotencyMiddleware(idempotencyConfig), container.CartHandler.CancelCheckout)
+		}
+
 		// Queue routes - Virtual Queue for high-demand events
 		queue := v1.Group("/queue")
-		queue.Use(userIDMiddleware()) // Extract user_id from header
+		queue.Use(internalAuth, userIDMiddleware()) // Extract user_id from header
 		{
 			// Join queue (requires authentication)
 			queue.POST("/join", middleware.IdempotencyMiddleware(idempotencyConfig), container.QueueHandler.JoinQueue)
@@ -323,11 +854,231 @@ func main() {
 
 			// Get inventory status (PostgreSQL vs Redis)
 			admin.GET("/inventory-status", container.AdminHandler.GetInventoryStatus)
+
+			// Compensation audit trail and manual compensation (refund + release)
+			compensations := admin.Group("/compensations",
+				internalAuth,
+				userIDMiddleware(),
+				middleware.RequireRole(handler.CompensationRoles...),
+			)
+			compensations.GET("", container.CompensationHandler.ListCompensations)
+			compensations.POST("", container.CompensationHandler.TriggerCompensation)
+
+			// Emergency revocation of queue passes for an event or one user (organizers of
+			// the event only)
+			admin.POST("/queue/revoke-passes",
+				internalAuth,
+				userIDMiddleware(),
+				middleware.RequireRole(handler.EventManagerRoles...),
+				container.QueueHandler.RevokeQueuePasses,
+			)
+
+			// Join rate, depth, abandonment and admit/reserve/confirm conversion per event queue
+			// (organizers of the event only)
+			admin.GET("/queue/analytics/:event_id",
+				internalAuth,
+				userIDMiddleware(),
+				middleware.RequireRole(handler.EventManagerRoles...),
+				container.QueueAnalyticsHandler.GetQueueAnalytics,
+			)
+
+			// Rolling 1h/24h saga aggregates for SLO dashboards
+			admin.GET("/saga/stats",
+				internalAuth,
+				userIDMiddleware(),
+				middleware.RequireRole("admin", "super_admin"),
+				container.SagaStatsHandler.GetSagaStats,
+			)
+
+			// Re-emit a dead-lettered saga step command instead of replaying it with Kafka tools
+			admin.POST("/saga/:saga_id/steps/:step/retry",
+				internalAuth,
+				userIDMiddleware(),
+				middleware.RequireRole(handler.SagaStepRetryRoles...),
+				container.SagaStepRetryHandler.RetryStep,
+			)
+
+			// Dynamic trace sampling policy of this instance
+			sampling := admin.Group("/telemetry/sampling",
+				internalAuth,
+				userIDMiddleware(),
+				middleware.RequireRole("admin", "super_admin"),
+			)
+			sampling.GET("", telemetry.GetSamplingPolicyHandler())
+			sampling.PUT("", telemetry.UpdateSamplingPolicyHandler())
+
+			// Per-module log levels of this instance (LOG_LEVELS_REDIS_KEY reaches all of them)
+			logLevels := admin.Group("/logging/levels",
+				internalAuth,
+				userIDMiddleware(),
+				middleware.RequireRole("admin", "super_admin"),
+			)
+			logLevels.GET("", logger.GetModuleLevelsHandler())
+			logLevels.PUT("", logger.UpdateModuleLevelsHandler())
+
+			// Cached idempotent responses: look up by key, user or route, and invalidate
+			// wrong ones so the next retry runs again (invalidations are audited). Admins
+			// only reach their own tenant's records; super_admin reaches every tenant's.
+			idempotencyAdmin := middleware.NewIdempotencyAdmin(redisClient.Client())
+			idempotencyRecords := admin.Group("/idempotency-records",
+				internalAuth,
+				userIDMiddleware(),
+				middleware.RequireRole("admin", "super_admin"),
+			)
+			idempotencyRecords.GET("", idempotencyAdmin.FindRecordsHandler())
+			idempotencyRecords.DELETE("", middleware.AuditMiddleware(auditLogger), idempotencyAdmin.InvalidateRecordHandler())
+
+			// Reserved seating layouts for best-available seat allocation (organizers of the
+			// zone's event only)
+			seatMap := admin.Group("/zones/:id/seat-map",
+				internalAuth,
+				userIDMiddleware(),
+				middleware.RequireRole(handler.EventManagerRoles...),
+			)
+			seatMap.PUT("", container.SeatMapHandler.SetSeatMap)
+			seatMap.GET("", container.SeatMapHandler.GetSeatMap)
+			seatMap.DELETE("", container.SeatMapHandler.DeleteSeatMap)
+
+			// Counter sharding of hot zones; reads aggregate across shards
+			zoneShards := admin.Group("/zones/:id/shards",
+				internalAuth,
+				userIDMiddleware(),
+				middleware.RequireRole("admin", "super_admin"),
+			)
+			zoneShards.PUT("", container.ZoneShardHandler.SetZoneShards)
+			zoneShards.GET("", container.ZoneShardHandler.GetZoneShards)
+
+			// Standby list size and offer conversion per zone
+			admin.GET("/zones/:id/standby",
+				internalAuth,
+				userIDMiddleware(),
+				middleware.RequireRole("admin", "super_admin"),
+				container.StandbyHandler.GetStandbyStats,
+			)
+
+			// Cancel a show and refund its confirmed bookings in bulk
+			admin.POST("/shows/:id/cancel",
+				internalAuth,
+				userIDMiddleware(),
+				middleware.RequireRole(handler.RefundBatchRoles...),
+				container.RefundBatchHandler.CancelShow,
+			)
+			admin.GET("/refund-batches/:id",
+				internalAuth,
+				userIDMiddleware(),
+				middleware.RequireRole(handler.RefundBatchRoles...),
+				container.RefundBatchHandler.GetRefundBatch,
+			)
+
+			// Booking search for support agents (role from X-User-Role, set by API Gateway from JWT)
+			if container.SearchHandler != nil {
+				admin.GET("/bookings/search",
+					internalAuth,
+					userIDMiddleware(),
+					middleware.RequireRole(handler.BookingSearchRoles...),
+					container.SearchHandler.SearchBookings,
+				)
+			}
+
+			// Redis migration divergence and cutover switch (shadow mode only)
+			if container.ReservationShadowHandler != nil {
+				shadow := admin.Group("/reservation-shadow",
+					internalAuth,
+					userIDMiddleware(),
+					middleware.RequireRole("admin", "super_admin"),
+				)
+				shadow.GET("", container.ReservationShadowHandler.GetStats)
+				shadow.PUT("/mode", container.ReservationShadowHandler.SetMode)
+			}
+
+			// Estimated Redis memory per key namespace against its budget
+			if container.RedisMemoryHandler != nil {
+				admin.GET("/redis/memory",
+					internalAuth,
+					userIDMiddleware(),
+					middleware.RequireRole("admin", "super_admin"),
+					container.RedisMemoryHandler.GetMemoryReport,
+				)
+			}
+
+			// Tenant data residency: which database shard holds each tenant's bookings
+			if container.TenantShardHandler != nil {
+				admin.GET("/tenant-shards",
+					internalAuth,
+					userIDMiddleware(),
+					middleware.RequireRole(handler.TenantShardRoles...),
+					container.TenantShardHandler.List,
+				)
+				admin.PUT("/tenant-shards/:tenant_id",
+					internalAuth,
+					userIDMiddleware(),
+					middleware.RequireRole(handler.TenantShardRoles...),
+					container.TenantShardHandler.Assign,
+				)
+			}
+
+			// Injected fault counts (chaos mode only; platform operators only)
+			if container.ChaosHandler != nil {
+				chaosAdmin := admin.Group("/chaos",
+					internalAuth,
+					userIDMiddleware(),
+					middleware.RequireRole("super_admin"),
+				)
+				chaosAdmin.GET("", container.ChaosHandler.GetStats)
+			}
+		}
+
+		// Webhook routes - tenant endpoints for booking lifecycle notifications
+		webhookSubs := v1.Group("/webhook-subscriptions")
+		webhookSubs.Use(internalAuth, userIDMiddleware()) // Extract tenant_id from header
+		{
+			webhookSubs.POST("", container.WebhookHandler.CreateSubscription)
+			webhookSubs.GET("", container.WebhookHandler.ListSubscriptions)
+			webhookSubs.GET("/:id", container.WebhookHandler.GetSubscription)
+			webhookSubs.PATCH("/:id", container.WebhookHandler.UpdateSubscription)
+			webhookSubs.DELETE("/:id", container.WebhookHandler.DeleteSubscription)
+		}
+
+		// Webhook delivery log - filter by subscription/event/status and retry failed deliveries
+		webhookDeliveries := v1.Group("/webhook-deliveries")
+		webhookDeliveries.Use(internalAuth, userIDMiddleware()) // Extract tenant_id from header
+		{
+			webhookDeliveries.GET("", container.WebhookHandler.ListDeliveries)
+			webhookDeliveries.GET("/:id", container.WebhookHandler.GetDelivery)
+			webhookDeliveries.POST("/:id/retry", container.WebhookHandler.RetryDelivery)
+		}
+
+		// Organizer sales report schedule; reports are emailed by report-worker
+		if container.ReportHandler != nil {
+			reportSchedule := v1.Group("/report-schedule")
+			reportSchedule.Use(internalAuth, userIDMiddleware()) // Extract tenant_id from header
+			{
+				reportSchedule.PUT("", container.ReportHandler.SetSchedule)
+				reportSchedule.GET("", container.ReportHandler.GetSchedule)
+				reportSchedule.DELETE("", container.ReportHandler.DeleteSchedule)
+				reportSchedule.GET("/preview", container.ReportHandler.PreviewReport)
+			}
+
+			// Unsubscribe links in report emails (public, authorized by the token)
+			v1.GET("/reports/unsubscribe", container.ReportHandler.Unsubscribe)
+			v1.POST("/reports/unsubscribe", container.ReportHandler.Unsubscribe)
+		}
+
+		// Release links in idle reservation reminders (public, authorized by the token);
+		// GET only shows the reservation so link prefetchers cannot release it
+		if container.IdleService != nil {
+			v1.GET("/bookings/release-link", container.BookingHandler.PreviewReleaseLink)
+			v1.POST("/bookings/release-link", container.BookingHandler.ReleaseWithLink)
+		}
+
+		// Hourly seat and revenue snapshots for historical charts; taken by report-worker
+		if container.SalesSnapshotHandler != nil {
+			v1.GET("/reports/events/:event_id/snapshots", internalAuth, userIDMiddleware(), container.SalesSnapshotHandler.GetEventSnapshots)
 		}
 
 		// Saga routes - async booking via saga pattern
 		sagaRoutes := v1.Group("/saga")
-		sagaRoutes.Use(userIDMiddleware()) // Extract user_id from header
+		sagaRoutes.Use(internalAuth, userIDMiddleware()) // Extract user_id from header
 		{
 			// Start a new booking saga (async)
 			sagaRoutes.POST("/bookings", middleware.IdempotencyMiddleware(idempotencyConfig), container.SagaHandler.StartBookingSaga)
@@ -337,6 +1088,30 @@ func main() {
 		}
 	}
 
+	// Internal routes for service-to-service calls (not exposed via API gateway)
+	// Used by auth-service for GDPR data export and account deletion
+	internal := router.Group("/internal/users")
+	{
+		internal.GET("/:user_id/data", container.UserDataHandler.ExportUserData)
+		internal.POST("/:user_id/anonymize", container.UserDataHandler.AnonymizeUserData)
+
+		// Used by payment-service to validate payment amounts
+		internal.GET("/:user_id/bookings/:id", container.BookingHandler.GetInternalBooking)
+		// Used by payment-watchdog to keep holds alive while payments are processing
+		internal.POST("/:user_id/bookings/:id/extend-hold", container.BookingHandler.ExtendInternalHold)
+		// Used by payment-service when the customer pays at the gateway (3-D Secure, bank transfer)
+		internal.POST("/:user_id/bookings/:id/await-payment", container.BookingHandler.AwaitInternalPayment)
+	}
+
+	// Load-test orchestration (load-test mode only, not exposed via API gateway)
+	if container.LoadTestHandler != nil {
+		loadTest := router.Group("/internal/loadtest/events")
+		{
+			loadTest.POST("/:id/queue-passes", container.LoadTestHandler.IssueQueuePasses)
+			loadTest.POST("/:id/reset", container.LoadTestHandler.ResetEvent)
+		}
+	}
+
 	// Create HTTP server with optimized settings
 	// WriteTimeout set to 0 (disabled) because SSE streams need long-lived connections
 	addr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
@@ -350,6 +1125,45 @@ func main() {
 		MaxHeaderBytes:    1 << 20, // 1MB
 	}
 
+	// Minimal listener for reserve and availability, to compare against Gin in load tests
+	var fastSrv *http.Server
+	if cfg.Booking.FastPath.Enabled {
+		fastPort := cfg.Booking.FastPath.Port
+		if fastPort == 0 {
+			fastPort = cfg.Server.Port + 2000
+		}
+		var availabilityReader fastpath.AvailabilityReader = container.ReservationRepo
+		if availabilityVerifier != nil {
+			availabilityReader = availabilityVerifier.Reader(container.ReservationRepo)
+		}
+		fastSrv = &http.Server{
+			Addr: fmt.Sprintf("%s:%d", cfg.Server.Host, fastPort),
+			Handler: fastpath.NewHandler(
+				container.BookingService,
+				container.QueueService,
+				container.StandbyService,
+				availabilityReader,
+				&fastpath.Config{
+					InternalSecret:   cfg.InternalAuth.Secret,
+					MaxClockSkew:     cfg.InternalAuth.MaxClockSkew,
+					AllowUnsigned:    cfg.InternalAuth.AllowUnsigned,
+					RequireQueuePass: requireQueuePass,
+				},
+			),
+			ReadTimeout:       5 * time.Second,
+			WriteTimeout:      10 * time.Second, // No streaming routes on this listener
+			IdleTimeout:       120 * time.Second,
+			ReadHeaderTimeout: 2 * time.Second,
+			MaxHeaderBytes:    1 << 20, // 1MB
+		}
+		go func() {
+			appLog.Info(fmt.Sprintf("Fast path (net/http) listening on %s", fastSrv.Addr))
+			if err := fastSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				appLog.Error(fmt.Sprintf("Fast path server error: %v", err))
+			}
+		}()
+	}
+
 	// Start pprof server on separate port for profiling
 	go func() {
 		pprofAddr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port+1000)
@@ -380,6 +1194,29 @@ func main() {
 	if err := srv.Shutdown(ctx); err != nil {
 		appLog.Fatal(fmt.Sprintf("Server forced to shutdown: %v", err))
 	}
+	if fastSrv != nil {
+		if err := fastSrv.Shutdown(ctx); err != nil {
+			appLog.Error(fmt.Sprintf("Fast path server forced to shutdown: %v", err))
+		}
+	}
+
+	// Finish in-flight confirmations after the server stops accepting them
+	if confirmWorker != nil {
+		confirmWorker.Stop()
+	}
+
+	// Finish bookings being persisted; queued ones wait for the next worker to start
+	if persistWorker != nil {
+		persistWorker.Stop()
+	}
+
+	// Insert the reservations still waiting for a batch
+	if insertBatcher != nil {
+		insertBatcher.Stop()
+	}
+
+	// Write the audit entries still buffered
+	auditLogger.Close()
 
 	appLog.Info("Server exited gracefully")
 }
@@ -398,6 +1235,13 @@ func userIDMiddleware() gin.HandlerFunc {
 		tenantID := c.GetHeader("X-Tenant-ID")
 		if tenantID != "" {
 			c.Set("tenant_id", tenantID)
+			// Booking lookups try the tenant's shard first
+			c.Request = c.Request.WithContext(database.WithTenant(c.Request.Context(), tenantID))
+		}
+
+		// Extract role from header (set by API Gateway from JWT)
+		if role := c.GetHeader("X-User-Role"); role != "" {
+			c.Set("role", role)
 		}
 
 		c.Next()
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-booking/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/smoke"
+)
+
+// schemaVersion is the latest migration in scripts/migrations/booking. -smoke fails
+// until the booking database is migrated to it.
+const schemaVersion = 18
+
+// smokeDeps are the dependencies checked by -smoke
+type smokeDeps struct {
+	DB    *database.PostgresDB
+	Redis *pkgredis.Client
+	Kafka kafka.TopicManagerConfig
+
+	// Scripts load the Lua scripts of each Redis repository
+	Scripts []smoke.Check
+
+	Bookings      repository.BookingRepository
+	Compensations repository.CompensationRepository
+	RefundBatches repository.RefundBatchRepository
+	UserData      repository.UserDataRepository
+	Search        repository.BookingSearchRepository
+	Webhooks      repository.WebhookSubscriptionRepository
+	Deliveries    repository.WebhookDeliveryRepository
+	Reports       repository.ReportScheduleRepository
+}
+
+// newSmokeSuite builds the self-check run by -smoke. Tenant shards are not checked
+// one by one; the bookings read goes through the shard resolver like any request.
+func newSmokeSuite(d *smokeDeps) *smoke.Suite {
+	suite := smoke.NewSuite("booking-service", 0)
+
+	suite.Add("postgres", smoke.Postgres(d.DB))
+	suite.Add("migrations", smoke.Migrations(d.DB, schemaVersion))
+	suite.Add("redis", smoke.Redis(d.Redis))
+	for _, script := range d.Scripts {
+		suite.Add("redis "+script.Name+" scripts", script.Run)
+	}
+	suite.Add("kafka topics", smoke.KafkaTopics(d.Kafka, topicSpecs))
+
+	// One read per repository; the probe ID matches nothing
+	suite.Add("bookings repository", func(ctx context.Context) error {
+		_, err := d.Bookings.GetByID(ctx, smoke.ProbeID)
+		return smoke.Expect(err, domain.ErrBookingNotFound)
+	})
+	suite.Add("compensations repository", func(ctx context.Context) error {
+		_, err := d.Compensations.GetByID(ctx, smoke.ProbeID)
+		return smoke.Expect(err, domain.ErrCompensationNotFound)
+	})
+	suite.Add("refund batches repository", func(ctx context.Context) error {
+		_, err := d.RefundBatches.GetByID(ctx, smoke.ProbeID)
+		return smoke.Expect(err, domain.ErrRefundBatchNotFound)
+	})
+	suite.Add("user data repository", func(ctx context.Context) error {
+		_, err := d.UserData.ListByUserID(ctx, smoke.ProbeID)
+		return err
+	})
+	suite.Add("booking search repository", func(ctx context.Context) error {
+		_, err := d.Search.Search(ctx, &repository.BookingSearchFilter{BookingIDs: []string{smoke.ProbeID}, Limit: 1})
+		return err
+	})
+	suite.Add("webhook subscriptions repository", func(ctx context.Context) error {
+		_, err := d.Webhooks.GetByID(ctx, smoke.ProbeID, smoke.ProbeID)
+		return smoke.Expect(err, domain.ErrWebhookSubscriptionNotFound)
+	})
+	suite.Add("webhook deliveries repository", func(ctx context.Context) error {
+		_, err := d.Deliveries.GetByID(ctx, smoke.ProbeID, smoke.ProbeID)
+		return smoke.Expect(err, domain.ErrWebhookDeliveryNotFound)
+	})
+	suite.Add("report schedules repository", func(ctx context.Context) error {
+		_, err := d.Reports.GetByTenant(ctx, smoke.ProbeID)
+		return smoke.Expect(err, domain.ErrReportScheduleNotFound)
+	})
+	return suite
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/client"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/gateway"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/sharding"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/config"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+)
+
+func main() {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Initialize logger with OTLP export support
+	logCfg := &logger.Config{
+		Level:        cfg.Log.Level,
+		ModuleLevels: cfg.Log.ModuleLevels,
+		ServiceName:  "payment-watchdog",
+		Development:  cfg.IsDevelopment(),
+		OTLPEnabled:  cfg.OTel.Enabled && cfg.OTel.LogExportEnabled,
+		OTLPEndpoint: cfg.OTel.CollectorAddr,
+		OTLPInsecure: true,
+	}
+	if err := logger.Init(logCfg); err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer logger.Sync()
+
+	appLog := logger.Get()
+	appLog.Info("Starting Payment Watchdog...")
+
+	required := []config.Requirement{config.RequirePaymentDatabase, config.RequireKafka}
+	if err := cfg.ValidateFor(required...); err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
+	appLog.Info("Effective configuration: " + cfg.Summary(required...))
+
+	env := config.NewEnv()
+	interval := env.Duration("PAYMENT_WATCHDOG_INTERVAL", time.Minute)
+	stuckAfter := env.Duration("PAYMENT_STUCK_AFTER", service.DefaultPaymentStuckAfter)
+	batchSize := env.Int("PAYMENT_WATCHDOG_BATCH_SIZE", service.DefaultPaymentWatchdogBatchSize)
+	holdGraceAfter := env.Duration("PAYMENT_HOLD_GRACE_AFTER", service.DefaultPaymentHoldGraceAfter)
+	actionTimeout := env.Duration("PAYMENT_ACTION_TIMEOUT", service.DefaultPaymentActionTimeout)
+	bookingServiceURL := env.String("BOOKING_SERVICE_URL", "http://localhost:8083")
+	if err := env.Err(); err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Initialize database connection
+	dbCfg := &database.PostgresConfig{
+		Host:          cfg.PaymentDatabase.Host,
+		Port:          cfg.PaymentDatabase.Port,
+		User:          cfg.PaymentDatabase.User,
+		Password:      cfg.PaymentDatabase.Password,
+		Database:      cfg.PaymentDatabase.DBName,
+		SSLMode:       cfg.PaymentDatabase.SSLMode,
+		MaxConns:      5,
+		MinConns:      1,
+		MaxRetries:    3,
+		RetryInterval: 2 * time.Second,
+	}
+	db, err := database.NewPostgres(ctx, dbCfg)
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("Failed to connect to database: %v", err))
+	}
+	defer db.Close()
+	appLog.Info("Database connected")
+
+	// Initialize payment gateway (must match the one that charged the payments)
+	var paymentGateway gateway.PaymentGateway
+	if os.Getenv("PAYMENT_GATEWAY") == "stripe" {
+		stripeSecretKey := os.Getenv("STRIPE_SECRET_KEY")
+		if stripeSecretKey != "" {
+			paymentGateway, _ = gateway.NewPaymentGateway("stripe", &gateway.GatewayConfig{
+				SecretKey:   stripeSecretKey,
+				Environment: os.Getenv("STRIPE_ENVIRONMENT"),
+			})
+		}
+	}
+	if paymentGateway == nil {
+		paymentGateway = gateway.NewMockGatewayWithConfig(0.95, 100)
+		appLog.Info("Using mock payment gateway")
+	}
+
+	// Initialize Kafka producer for the payment.success and payment.seat-release events
+	producer, err := kafka.NewProducer(ctx, &kafka.ProducerConfig{
+		Brokers:  cfg.Kafka.Brokers,
+		ClientID: "payment-watchdog-producer",
+	})
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("Failed to create Kafka producer: %v", err))
+	}
+	defer producer.Close()
+	appLog.Info("Kafka producer connected")
+
+	// Resolved payments go through the payment service so the ledger posts their charges
+	var paymentRepo sharding.ShardPaymentRepository = repository.NewPostgresPaymentRepository(db)
+	tenantShards, err := database.OpenShardResolver(ctx, db, dbCfg, cfg.PaymentDatabase.Shards, cfg.PaymentDatabase.ShardRefreshInterval)
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("Tenant shards unavailable: %v", err))
+	}
+	if tenantShards != nil {
+		defer tenantShards.Close()
+		paymentRepo = sharding.NewPostgresPaymentRepository(tenantShards)
+	}
+	paymentService := service.NewPaymentService(paymentRepo, paymentGateway, &service.PaymentServiceConfig{
+		Currency: "THB",
+		Ledger:   service.NewLedgerService(repository.NewPostgresLedgerRepository(db), paymentRepo),
+	})
+	watchdog := service.NewPaymentWatchdogService(paymentRepo, paymentRepo, paymentService, paymentGateway, &service.PaymentWatchdogConfig{
+		StuckAfter:     stuckAfter,
+		BatchSize:      batchSize,
+		HoldGraceAfter: holdGraceAfter,
+		ActionTimeout:  actionTimeout,
+		BookingClient:  client.NewHTTPBookingClient(bookingServiceURL),
+		Publisher:      service.NewKafkaPaymentOutcomePublisher(producer),
+	})
+
+	// Start watchdog
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			// Holds first, so payments still processing are not expired before they resolve
+			holds, err := watchdog.ExtendHolds(ctx)
+			if err != nil {
+				appLog.Error(fmt.Sprintf("Payment watchdog hold extension failed: %v", err))
+			} else if holds.Checked > 0 {
+				appLog.Info(fmt.Sprintf("Payment watchdog: checked %d processing payments, extended %d holds, %d released, %d errors",
+					holds.Checked, holds.Extended, holds.Released, holds.Errors))
+			}
+
+			result, err := watchdog.ReconcileStuck(ctx)
+			if err != nil {
+				appLog.Error(fmt.Sprintf("Payment watchdog run failed: %v", err))
+			} else if result.Checked > 0 {
+				appLog.Info(fmt.Sprintf("Payment watchdog: checked %d stuck payments, resolved %v, %d errors",
+					result.Checked, result.Resolved, result.Errors))
+			}
+
+			actions, err := watchdog.ExpireActions(ctx)
+			if err != nil {
+				appLog.Error(fmt.Sprintf("Payment watchdog action expiry failed: %v", err))
+			} else if actions.Checked > 0 {
+				appLog.Info(fmt.Sprintf("Payment watchdog: checked %d payments requiring action, resolved %v, %d errors",
+					actions.Checked, actions.Resolved, actions.Errors))
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	appLog.Info(fmt.Sprintf("Payment Watchdog started (interval: %s, holds extended after: %s, stuck after: %s, actions expire after: %s)", interval, holdGraceAfter, stuckAfter, actionTimeout))
+
+	// Wait for interrupt signal
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	appLog.Info("Shutting down watchdog...")
+	cancel()
+
+	// Give an in-flight run time to finish
+	time.Sleep(2 * time.Second)
+	appLog.Info("Watchdog exited gracefully")
+}
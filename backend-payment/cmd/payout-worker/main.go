@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/gateway"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/config"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/storage"
+)
+
+func main() {
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Initialize logger with OTLP export support
+	logCfg := &logger.Config{
+		Level:        cfg.Log.Level,
+		ModuleLevels: cfg.Log.ModuleLevels,
+		ServiceName:  "payout-worker",
+		Development:  cfg.IsDevelopment(),
+		OTLPEnabled:  cfg.OTel.Enabled && cfg.OTel.LogExportEnabled,
+		OTLPEndpoint: cfg.OTel.CollectorAddr,
+		OTLPInsecure: true,
+	}
+	if err := logger.Init(logCfg); err != nil {
+		log.Fatalf("Failed to initialize logger: %v", err)
+	}
+	defer logger.Sync()
+
+	appLog := logger.Get()
+	appLog.Info("Starting Payout Worker...")
+
+	required := []config.Requirement{config.RequirePaymentDatabase}
+	if err := cfg.ValidateFor(required...); err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
+	appLog.Info("Effective configuration: " + cfg.Summary(required...))
+
+	env := config.NewEnv()
+	interval := env.Duration("PAYOUT_INTERVAL", 10*time.Minute)
+	settlementDelay := env.Duration("PAYOUT_SETTLEMENT_DELAY", service.DefaultPayoutSettlementDelay)
+	batchSize := env.Int("PAYOUT_BATCH_SIZE", service.DefaultPayoutBatchSize)
+	storageCfg := &storage.Config{
+		Backend:  env.String("STORAGE_BACKEND", storage.BackendNone),
+		LocalDir: env.String("STORAGE_LOCAL_DIR", "./data/files"),
+		S3: storage.S3Config{
+			Endpoint:  env.String("STORAGE_S3_ENDPOINT", ""),
+			Region:    env.String("STORAGE_S3_REGION", "ap-southeast-1"),
+			Bucket:    env.String("STORAGE_S3_BUCKET", ""),
+			AccessKey: env.String("STORAGE_S3_ACCESS_KEY", ""),
+			SecretKey: env.String("STORAGE_S3_SECRET_KEY", ""),
+			PathStyle: env.Bool("STORAGE_S3_PATH_STYLE", false),
+		},
+		EncryptionKeys: env.String("STORAGE_ENCRYPTION_KEYS", ""),
+	}
+	if err := env.Err(); err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Initialize database connection
+	dbCfg := &database.PostgresConfig{
+		Host:          cfg.PaymentDatabase.Host,
+		Port:          cfg.PaymentDatabase.Port,
+		User:          cfg.PaymentDatabase.User,
+		Password:      cfg.PaymentDatabase.Password,
+		Database:      cfg.PaymentDatabase.DBName,
+		SSLMode:       cfg.PaymentDatabase.SSLMode,
+		MaxConns:      5,
+		MinConns:      1,
+		MaxRetries:    3,
+		RetryInterval: 2 * time.Second,
+	}
+	db, err := database.NewPostgres(ctx, dbCfg)
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("Failed to connect to database: %v", err))
+	}
+	defer db.Close()
+	appLog.Info("Database connected")
+
+	// Initialize payment gateway (its payout API sends the gateway payouts)
+	var paymentGateway gateway.PaymentGateway
+	if os.Getenv("PAYMENT_GATEWAY") == "stripe" {
+		stripeSecretKey := os.Getenv("STRIPE_SECRET_KEY")
+		if stripeSecretKey != "" {
+			paymentGateway, _ = gateway.NewPaymentGateway("stripe", &gateway.GatewayConfig{
+				SecretKey:   stripeSecretKey,
+				Environment: os.Getenv("STRIPE_ENVIRONMENT"),
+			})
+		}
+	}
+	if paymentGateway == nil {
+		paymentGateway = gateway.NewMockGatewayWithConfig(1.0, 100)
+		appLog.Info("Using mock payment gateway")
+	}
+
+	// Bank transfer files go to file storage; bank_file payouts fail without it
+	fileStore, err := storage.Open(storageCfg)
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("File storage unavailable: %v", err))
+	}
+	if fileStore == nil {
+		appLog.Warn("File storage not configured; bank_file payouts will fail")
+	}
+
+	payouts := service.NewPayoutService(
+		repository.NewPostgresPayoutRepository(db),
+		repository.NewPostgresLedgerRepository(db),
+		paymentGateway,
+		&service.PayoutServiceConfig{
+			SettlementDelay: settlementDelay,
+			BatchSize:       batchSize,
+			Files:           fileStore,
+		},
+	)
+
+	// Start payout runs
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			result, err := payouts.RunDue(ctx)
+			if err != nil {
+				appLog.Error(fmt.Sprintf("Payout run failed: %v", err))
+			} else if result.Due > 0 {
+				appLog.Info(fmt.Sprintf("Payout run: %d due, %d submitted, %d exported, %d failed, %d skipped, %d errors",
+					result.Due, result.Submitted, result.Exported, result.Failed, result.Skipped, result.Errors))
+				if result.BankFile != "" {
+					appLog.Info("Bank transfer file written: " + result.BankFile)
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	appLog.Info(fmt.Sprintf("Payout Worker started (interval: %s, settlement delay: %s)", interval, settlementDelay))
+
+	// Wait for interrupt signal
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	appLog.Info("Shutting down payout worker...")
+	cancel()
+
+	// Give an in-flight run time to finish
+	time.Sleep(2 * time.Second)
+	appLog.Info("Payout worker exited gracefully")
+}
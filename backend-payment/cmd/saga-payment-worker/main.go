@@ -10,9 +10,14 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/client"
+	paymentconsumer "github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/consumer"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/gateway"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/repository"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/service"
-	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/gateway"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/sharding"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/config"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
@@ -25,6 +30,7 @@ const (
 	TopicPaymentProcessedEvent = "saga.booking.payment-processed.event"
 	TopicPaymentFailedEvent    = "saga.booking.payment-failed.event"
 	TopicPaymentRefundedEvent  = "saga.booking.payment-refunded.event"
+	TopicBookingConfirmedEvent = "saga.booking.booking-confirmed.event"
 )
 
 // SagaCommand represents a saga command message
@@ -72,11 +78,15 @@ func main() {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
-	// Initialize logger
+	// Initialize logger with OTLP export support
 	logCfg := &logger.Config{
-		Level:       cfg.App.Environment,
-		ServiceName: "saga-payment-worker",
-		Development: cfg.IsDevelopment(),
+		Level:        cfg.Log.Level,
+		ModuleLevels: cfg.Log.ModuleLevels,
+		ServiceName:  "saga-payment-worker",
+		Development:  cfg.IsDevelopment(),
+		OTLPEnabled:  cfg.OTel.Enabled && cfg.OTel.LogExportEnabled,
+		OTLPEndpoint: cfg.OTel.CollectorAddr,
+		OTLPInsecure: true,
 	}
 	if err := logger.Init(logCfg); err != nil {
 		log.Fatalf("Failed to initialize logger: %v", err)
@@ -86,6 +96,12 @@ func main() {
 	appLog := logger.Get()
 	appLog.Info("Starting Saga Payment Worker...")
 
+	required := []config.Requirement{config.RequirePaymentDatabase, config.RequireKafka}
+	if err := cfg.ValidateFor(required...); err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
+	appLog.Info("Effective configuration: " + cfg.Summary(required...))
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -126,10 +142,26 @@ func main() {
 		appLog.Info("Using mock payment gateway")
 	}
 
-	// Initialize payment repository and service
-	paymentRepo := repository.NewPostgresPaymentRepository(db)
+	// Initialize payment repository and service; charges and refunds are posted to the ledger
+	var paymentRepo repository.PaymentRepository = repository.NewPostgresPaymentRepository(db)
+	tenantShards, err := database.OpenShardResolver(ctx, db, dbCfg, cfg.PaymentDatabase.Shards, cfg.PaymentDatabase.ShardRefreshInterval)
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("Tenant shards unavailable: %v", err))
+	}
+	if tenantShards != nil {
+		defer tenantShards.Close()
+		paymentRepo = sharding.NewPostgresPaymentRepository(tenantShards)
+	}
+	// Saga payment amounts are checked against the booking total like API payments
+	bookingServiceURL := os.Getenv("BOOKING_SERVICE_URL")
+	if bookingServiceURL == "" {
+		bookingServiceURL = "http://localhost:8083"
+	}
 	paymentService := service.NewPaymentService(paymentRepo, paymentGateway, &service.PaymentServiceConfig{
-		Currency: "THB",
+		Currency:      "THB",
+		CaptureMode:   service.CaptureMode(os.Getenv("PAYMENT_CAPTURE_MODE")),
+		Ledger:        service.NewLedgerService(repository.NewPostgresLedgerRepository(db), paymentRepo),
+		BookingClient: client.NewHTTPBookingClient(bookingServiceURL),
 	})
 
 	// Initialize Kafka consumer
@@ -139,6 +171,7 @@ func main() {
 		Topics: []string{
 			TopicProcessPaymentCommand,
 			TopicRefundPaymentCommand,
+			TopicBookingConfirmedEvent, // Captures authorized payments
 		},
 		ClientID:       "saga-payment-worker",
 		MaxRetries:     3,
@@ -205,6 +238,8 @@ func processRecord(ctx context.Context, record *kafka.Record, paymentService ser
 		handleProcessPayment(ctx, record, paymentService, producer, consumer, appLog)
 	case TopicRefundPaymentCommand:
 		handleRefundPayment(ctx, record, paymentService, producer, consumer, appLog)
+	case TopicBookingConfirmedEvent:
+		handleBookingConfirmed(ctx, record, paymentService, consumer, appLog)
 	default:
 		appLog.Warn(fmt.Sprintf("Unknown topic: %s", record.Topic))
 		consumer.CommitRecords(ctx, []*kafka.Record{record})
@@ -257,7 +292,10 @@ func handleProcessPayment(ctx context.Context, record *kafka.Record, paymentServ
 		processedPayment, err := paymentService.ProcessPayment(ctx, payment.ID)
 		if err != nil {
 			execErr = err
-		} else if processedPayment.Status != "succeeded" {
+		} else if processedPayment.Status == domain.PaymentStatusProcessing {
+			// The gateway timed out; payment-watchdog refunds the charge if it went through
+			execErr = fmt.Errorf("payment gateway timed out, payment %s left for reconciliation", processedPayment.ID)
+		} else if !processedPayment.IsSuccessful() && !processedPayment.IsAuthorized() {
 			execErr = fmt.Errorf("payment failed: %s", processedPayment.ErrorMessage)
 		} else {
 			resultData = map[string]interface{}{
@@ -323,11 +361,68 @@ func handleRefundPayment(ctx context.Context, record *kafka.Record, paymentServi
 
 	paymentID := getString(command.OriginalStepData, "payment_id")
 	if paymentID != "" {
-		_, err := paymentService.RefundPayment(ctx, paymentID, command.Reason)
-		if err != nil {
+		var event *paymentconsumer.PaymentEvent
+		var eventErr error
+		if payment, err := paymentService.GetPayment(ctx, paymentID); err == nil &&
+			(payment.Status == domain.PaymentStatusRefunded || payment.Status == domain.PaymentStatusVoided) {
+			// Redelivered or resent command: report the earlier refund instead of refunding twice
+			appLog.Info(fmt.Sprintf("Payment already %s: payment_id=%s", payment.Status, paymentID))
+			event, eventErr = paymentconsumer.NewPaymentRefundedEvent(payment, uuid.New().String())
+		} else if payment, err := paymentService.RefundPayment(ctx, paymentID, command.Reason); err != nil {
+			// Saga compensations skip the refund approval policy on purpose: the booking
+			// this payment was for could not be confirmed, so the money is returned
+			// without waiting for an admin
 			appLog.Error(fmt.Sprintf("Failed to refund payment: %v", err))
+			event, eventErr = paymentconsumer.NewPaymentRefundFailedEvent(paymentID, getString(command.OriginalStepData, "booking_id"), err.Error(), uuid.New().String())
+		} else {
+			// Authorized payments are voided rather than refunded; downstream consumers
+			// treat both as the customer's money being returned
+			appLog.Info(fmt.Sprintf("Payment %s: payment_id=%s", payment.Status, paymentID))
+			event, eventErr = paymentconsumer.NewPaymentRefundedEvent(payment, uuid.New().String())
+		}
+
+		// Notify downstream consumers (tenant webhooks, show refund batches) of the outcome
+		if eventErr != nil {
+			appLog.Error(fmt.Sprintf("Failed to build refund outcome event: %v", eventErr))
 		} else {
-			appLog.Info(fmt.Sprintf("Payment refunded: payment_id=%s", paymentID))
+			headers := map[string]string{
+				"event_type": string(event.EventType),
+				"source":     "payment-service",
+			}
+			if err := producer.ProduceJSON(ctx, event.Topic(), event.Key(), event, headers); err != nil {
+				appLog.Error(fmt.Sprintf("Failed to publish %s event: %v", event.EventType, err))
+			}
+		}
+	}
+
+	consumer.CommitRecords(ctx, []*kafka.Record{record})
+}
+
+// handleBookingConfirmed captures the authorized payment of a confirmed booking. Payments
+// charged in auto-capture mode are already captured, so the capture is a no-op for them.
+func handleBookingConfirmed(ctx context.Context, record *kafka.Record, paymentService service.PaymentService, consumer *kafka.Consumer, appLog *logger.Logger) {
+	var event SagaEvent
+	if err := json.Unmarshal(record.Value, &event); err != nil {
+		appLog.Error(fmt.Sprintf("Failed to unmarshal event: %v", err))
+		consumer.CommitRecords(ctx, []*kafka.Record{record})
+		return
+	}
+
+	paymentID := getString(event.Data, "payment_id")
+	if paymentID == "" {
+		if bookingID := getString(event.Data, "booking_id"); bookingID != "" {
+			if payment, err := paymentService.GetPaymentByBookingID(ctx, bookingID); err == nil {
+				paymentID = payment.ID
+			}
+		}
+	}
+
+	if event.Success && paymentID != "" {
+		if payment, err := paymentService.CapturePayment(ctx, paymentID); err != nil {
+			// The authorization stays in place until it expires, so it can still be captured manually
+			appLog.Error(fmt.Sprintf("Failed to capture payment: saga_id=%s, payment_id=%s: %v", event.SagaID, paymentID, err))
+		} else if payment.Status == domain.PaymentStatusCaptured {
+			appLog.Info(fmt.Sprintf("Payment captured: saga_id=%s, payment_id=%s", event.SagaID, paymentID))
 		}
 	}
 
@@ -1,13 +1,23 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 )
 
+// ErrBookingNotFound is returned when the booking does not exist or belongs to another user
+var ErrBookingNotFound = errors.New("booking not found")
+
+// ErrBookingReleased is returned when the booking hold was already cancelled or expired
+var ErrBookingReleased = errors.New("booking already released")
+
 // BookingDetails contains enriched booking information for notifications
 type BookingDetails struct {
 	BookingID        string  `json:"booking_id"`
@@ -30,10 +40,38 @@ type BookingDetails struct {
 	VenueAddress     string  `json:"venue_address,omitempty"`
 }
 
+// BookingTotal is the amount due for a booking, as recorded by booking service
+type BookingTotal struct {
+	BookingID  string  `json:"id"`
+	UserID     string  `json:"user_id"`
+	EventID    string  `json:"event_id"`
+	Status     string  `json:"status"`
+	TotalPrice float64 `json:"total_price"`
+	Currency   string  `json:"currency"`
+}
+
+// BookingHold is a booking's reservation hold after a request to extend it
+type BookingHold struct {
+	BookingID string    `json:"booking_id"`
+	Status    string    `json:"status"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Extended  bool      `json:"extended"`
+}
+
 // BookingClient is a client for the booking service
 type BookingClient interface {
 	// GetBookingDetails fetches enriched booking details by ID
 	GetBookingDetails(ctx context.Context, bookingID string, authToken string) (*BookingDetails, error)
+
+	// GetBookingTotal fetches the total of a user's booking via the internal API
+	GetBookingTotal(ctx context.Context, bookingID, userID string) (*BookingTotal, error)
+
+	// ExtendBookingHold pushes back the expiry of a user's reserved booking via the internal API
+	ExtendBookingHold(ctx context.Context, bookingID, userID string) (*BookingHold, error)
+
+	// AwaitBookingPayment hands a user's reserved booking off to a payment the customer
+	// completes at the gateway, holding it for booking-service's external payment window
+	AwaitBookingPayment(ctx context.Context, bookingID, userID, paymentID string) (*BookingHold, error)
 }
 
 // HTTPBookingClient implements BookingClient using HTTP
@@ -45,7 +83,7 @@ type HTTPBookingClient struct {
 // NewHTTPBookingClient creates a new HTTP booking client
 func NewHTTPBookingClient(baseURL string) *HTTPBookingClient {
 	return &HTTPBookingClient{
-		baseURL: baseURL,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
@@ -54,9 +92,9 @@ func NewHTTPBookingClient(baseURL string) *HTTPBookingClient {
 
 // GetBookingDetails fetches enriched booking details from booking service
 func (c *HTTPBookingClient) GetBookingDetails(ctx context.Context, bookingID string, authToken string) (*BookingDetails, error) {
-	url := fmt.Sprintf("%s/api/v1/bookings/%s/details", c.baseURL, bookingID)
+	endpoint := fmt.Sprintf("%s/api/v1/bookings/%s/details", c.baseURL, bookingID)
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -93,6 +131,115 @@ func (c *HTTPBookingClient) GetBookingDetails(ctx context.Context, bookingID str
 	return apiResponse.Data, nil
 }
 
+// GetBookingTotal calls GET /internal/users/:user_id/bookings/:id on booking service
+func (c *HTTPBookingClient) GetBookingTotal(ctx context.Context, bookingID, userID string) (*BookingTotal, error) {
+	endpoint := fmt.Sprintf("%s/internal/users/%s/bookings/%s", c.baseURL, url.PathEscape(userID), url.PathEscape(bookingID))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch booking: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Booking service answers 403 for another user's booking
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusForbidden {
+		return nil, ErrBookingNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("booking service returned status %d", resp.StatusCode)
+	}
+
+	var apiResponse struct {
+		Success bool          `json:"success"`
+		Data    *BookingTotal `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if !apiResponse.Success || apiResponse.Data == nil {
+		return nil, fmt.Errorf("booking service returned unsuccessful response")
+	}
+
+	return apiResponse.Data, nil
+}
+
+// ExtendBookingHold calls POST /internal/users/:user_id/bookings/:id/extend-hold on booking service
+func (c *HTTPBookingClient) ExtendBookingHold(ctx context.Context, bookingID, userID string) (*BookingHold, error) {
+	endpoint := fmt.Sprintf("%s/internal/users/%s/bookings/%s/extend-hold", c.baseURL, url.PathEscape(userID), url.PathEscape(bookingID))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extend booking hold: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return decodeBookingHold(resp)
+}
+
+// AwaitBookingPayment calls POST /internal/users/:user_id/bookings/:id/await-payment on booking service
+func (c *HTTPBookingClient) AwaitBookingPayment(ctx context.Context, bookingID, userID, paymentID string) (*BookingHold, error) {
+	endpoint := fmt.Sprintf("%s/internal/users/%s/bookings/%s/await-payment", c.baseURL, url.PathEscape(userID), url.PathEscape(bookingID))
+
+	body, err := json.Marshal(map[string]string{"payment_id": paymentID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hand off booking hold: %w", err)
+	}
+	defer resp.Body.Close()
+
+	return decodeBookingHold(resp)
+}
+
+// decodeBookingHold reads booking service's answer to a change of a booking hold
+func decodeBookingHold(resp *http.Response) (*BookingHold, error) {
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusForbidden:
+		return nil, ErrBookingNotFound
+	case http.StatusConflict, http.StatusGone:
+		return nil, ErrBookingReleased
+	default:
+		return nil, fmt.Errorf("booking service returned status %d", resp.StatusCode)
+	}
+
+	var apiResponse struct {
+		Success bool         `json:"success"`
+		Data    *BookingHold `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if !apiResponse.Success || apiResponse.Data == nil {
+		return nil, fmt.Errorf("booking service returned unsuccessful response")
+	}
+
+	return apiResponse.Data, nil
+}
+
 // NoOpBookingClient is a no-op implementation for testing or when booking service is unavailable
 type NoOpBookingClient struct{}
 
@@ -105,3 +252,18 @@ func NewNoOpBookingClient() *NoOpBookingClient {
 func (c *NoOpBookingClient) GetBookingDetails(ctx context.Context, bookingID string, authToken string) (*BookingDetails, error) {
 	return nil, nil
 }
+
+// GetBookingTotal returns nil (amounts are not validated)
+func (c *NoOpBookingClient) GetBookingTotal(ctx context.Context, bookingID, userID string) (*BookingTotal, error) {
+	return nil, nil
+}
+
+// ExtendBookingHold returns nil (holds are not extended)
+func (c *NoOpBookingClient) ExtendBookingHold(ctx context.Context, bookingID, userID string) (*BookingHold, error) {
+	return nil, nil
+}
+
+// AwaitBookingPayment returns nil (holds are not handed off)
+func (c *NoOpBookingClient) AwaitBookingPayment(ctx context.Context, bookingID, userID, paymentID string) (*BookingHold, error) {
+	return nil, nil
+}
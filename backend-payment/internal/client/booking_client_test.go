@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHTTPBookingClient_GetBookingTotal(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/internal/users/user-1/bookings/booking-1":
+			w.Write([]byte(`{"success":true,"data":{"id":"booking-1","user_id":"user-1","status":"reserved","total_price":200.5,"currency":"THB"}}`))
+		case "/internal/users/user-2/bookings/booking-1":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := NewHTTPBookingClient(server.URL + "/")
+	ctx := context.Background()
+
+	total, err := c.GetBookingTotal(ctx, "booking-1", "user-1")
+	if err != nil {
+		t.Fatalf("GetBookingTotal() error = %v", err)
+	}
+	if total.TotalPrice != 200.5 || total.Currency != "THB" {
+		t.Errorf("GetBookingTotal() = %+v", total)
+	}
+
+	if _, err := c.GetBookingTotal(ctx, "booking-1", "user-2"); !errors.Is(err, ErrBookingNotFound) {
+		t.Errorf("another user's booking error = %v, want ErrBookingNotFound", err)
+	}
+	if _, err := c.GetBookingTotal(ctx, "booking-1/../../../other", "user-1"); !errors.Is(err, ErrBookingNotFound) {
+		t.Errorf("path traversal error = %v, want ErrBookingNotFound", err)
+	}
+}
+
+func TestHTTPBookingClient_AwaitBookingPayment(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			PaymentID string `json:"payment_id"`
+		}
+		if r.Method != http.MethodPost || json.NewDecoder(r.Body).Decode(&body) != nil || body.PaymentID != "payment-1" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.URL.Path {
+		case "/internal/users/user-1/bookings/booking-1/await-payment":
+			w.Write([]byte(`{"success":true,"data":{"booking_id":"booking-1","expires_at":"2026-01-01T00:30:00Z"}}`))
+		case "/internal/users/user-1/bookings/booking-2/await-payment":
+			w.WriteHeader(http.StatusGone)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := NewHTTPBookingClient(server.URL)
+	ctx := context.Background()
+
+	hold, err := c.AwaitBookingPayment(ctx, "booking-1", "user-1", "payment-1")
+	if err != nil {
+		t.Fatalf("AwaitBookingPayment() error = %v", err)
+	}
+	if hold == nil || hold.BookingID != "booking-1" {
+		t.Errorf("AwaitBookingPayment() = %+v", hold)
+	}
+
+	if _, err := c.AwaitBookingPayment(ctx, "booking-2", "user-1", "payment-1"); !errors.Is(err, ErrBookingReleased) {
+		t.Errorf("released booking error = %v, want ErrBookingReleased", err)
+	}
+	if _, err := c.AwaitBookingPayment(ctx, "booking-3", "user-1", "payment-1"); !errors.Is(err, ErrBookingNotFound) {
+		t.Errorf("unknown booking error = %v, want ErrBookingNotFound", err)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/events"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
 )
@@ -44,8 +45,8 @@ func DefaultBookingConsumerConfig() *BookingConsumerConfig {
 	return &BookingConsumerConfig{
 		Brokers:        []string{"localhost:9092"},
 		GroupID:        "payment-service",
-		Topic:          "booking-events",
-		PaymentTopic:   "payment-events",
+		Topic:          events.TopicBookingEvents,
+		PaymentTopic:   events.TopicPaymentEvents,
 		MaxRetries:     3,
 		RetryInterval:  2 * time.Second,
 		ProcessTimeout: 30 * time.Second,
@@ -254,8 +255,16 @@ func (c *BookingConsumer) handleBookingCreated(ctx context.Context, event *Booki
 		return c.publishPaymentEvent(ctx, PaymentEventFailed, payment, data.BookingID, data.UserID, err.Error())
 	}
 
-	// Publish payment result event
-	if processedPayment.Status == domain.PaymentStatusSucceeded {
+	// The customer finishes paying at the gateway; the result is published once the
+	// payment completes or the payment watchdog abandons it
+	if processedPayment.RequiresAction() {
+		c.logger.InfoContext(ctx, fmt.Sprintf("Payment requires action: payment_id=%s, gateway_payment_id=%s",
+			processedPayment.ID, processedPayment.GatewayPaymentID))
+		return nil
+	}
+
+	// Publish payment result event; an authorized payment is captured once the booking is confirmed
+	if processedPayment.IsSuccessful() || processedPayment.IsAuthorized() {
 		c.logger.InfoContext(ctx, fmt.Sprintf("Payment successful: payment_id=%s, gateway_payment_id=%s",
 			processedPayment.ID, processedPayment.GatewayPaymentID))
 		return c.publishPaymentEvent(ctx, PaymentEventSuccess, processedPayment, data.BookingID, data.UserID, "")
@@ -282,6 +291,7 @@ func (c *BookingConsumer) publishPaymentEvent(
 
 	if payment != nil {
 		eventData.PaymentID = payment.ID
+		eventData.TenantID = payment.TenantID
 		eventData.Amount = payment.Amount
 		eventData.Currency = payment.Currency
 		eventData.Status = string(payment.Status)
@@ -293,12 +303,10 @@ func (c *BookingConsumer) publishPaymentEvent(
 		}
 	}
 
-	event := &PaymentEvent{
-		EventID:     uuid.New().String(),
-		EventType:   eventType,
-		OccurredAt:  time.Now(),
-		Version:     1,
-		PaymentData: eventData,
+	event, err := events.NewPaymentEvent(eventType, uuid.New().String(), eventData)
+	if err != nil {
+		c.logger.ErrorContext(ctx, fmt.Sprintf("Failed to build payment event: %v", err))
+		return err
 	}
 
 	headers := map[string]string{
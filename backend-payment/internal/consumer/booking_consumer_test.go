@@ -6,12 +6,28 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/client"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/gateway"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/repository"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 )
 
+// bookingTotals answers booking totals so payment amounts pass validation
+type bookingTotals struct {
+	client.NoOpBookingClient
+	totals map[string]float64
+}
+
+func (b *bookingTotals) GetBookingTotal(ctx context.Context, bookingID, userID string) (*client.BookingTotal, error) {
+	total, ok := b.totals[bookingID]
+	if !ok {
+		return nil, client.ErrBookingNotFound
+	}
+	return &client.BookingTotal{BookingID: bookingID, UserID: userID, TotalPrice: total, Currency: "THB"}, nil
+}
+
 // mockPaymentService implements service.PaymentService for testing
 type mockPaymentService struct {
 	createPaymentFunc  func(ctx context.Context, req *service.CreatePaymentRequest) (*domain.Payment, error)
@@ -43,7 +59,15 @@ func (m *mockPaymentService) GetPaymentByBookingID(ctx context.Context, bookingI
 	return nil, nil
 }
 
-func (m *mockPaymentService) GetUserPayments(ctx context.Context, userID string, limit, offset int) ([]*domain.Payment, error) {
+func (m *mockPaymentService) GetUserPayments(ctx context.Context, userID string, page pagination.Params) ([]*domain.Payment, pagination.Info, error) {
+	return nil, pagination.Info{}, nil
+}
+
+func (m *mockPaymentService) CapturePayment(ctx context.Context, paymentID string) (*domain.Payment, error) {
+	return nil, nil
+}
+
+func (m *mockPaymentService) VoidPayment(ctx context.Context, paymentID string, reason string) (*domain.Payment, error) {
 	return nil, nil
 }
 
@@ -51,6 +75,10 @@ func (m *mockPaymentService) RefundPayment(ctx context.Context, paymentID string
 	return nil, nil
 }
 
+func (m *mockPaymentService) AdjustPayment(ctx context.Context, req *service.AdjustPaymentRequest) (*domain.Payment, error) {
+	return nil, nil
+}
+
 func (m *mockPaymentService) CancelPayment(ctx context.Context, paymentID string) (*domain.Payment, error) {
 	return nil, nil
 }
@@ -179,6 +207,65 @@ func TestPaymentEventKey(t *testing.T) {
 	}
 }
 
+func TestNewPaymentRefundedEvent(t *testing.T) {
+	refundedAt := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	refundAmount := 1500.0
+	payment := &domain.Payment{
+		ID:           "pay-123",
+		TenantID:     "tenant-1",
+		BookingID:    "booking-456",
+		UserID:       "user-789",
+		Amount:       2000.0,
+		Currency:     "THB",
+		Status:       domain.PaymentStatusRefunded,
+		RefundAmount: &refundAmount,
+		RefundedAt:   &refundedAt,
+	}
+
+	event, err := NewPaymentRefundedEvent(payment, "evt-123")
+	if err != nil {
+		t.Fatalf("NewPaymentRefundedEvent() error = %v", err)
+	}
+
+	if event.EventType != PaymentEventRefunded || event.EventID != "evt-123" {
+		t.Errorf("unexpected event: %+v", event)
+	}
+	data := event.PaymentData
+	if data.TenantID != "tenant-1" || data.BookingID != "booking-456" || data.PaymentID != "pay-123" {
+		t.Errorf("unexpected event data: %+v", data)
+	}
+	if data.Amount != 1500.0 {
+		t.Errorf("Expected refunded amount 1500, got %v", data.Amount)
+	}
+	if !data.ProcessedAt.Equal(refundedAt) {
+		t.Errorf("Expected processed_at %v, got %v", refundedAt, data.ProcessedAt)
+	}
+	if event.Key() != "booking-456" {
+		t.Errorf("Expected key 'booking-456', got '%s'", event.Key())
+	}
+}
+
+func TestNewPaymentRefundFailedEvent(t *testing.T) {
+	event, err := NewPaymentRefundFailedEvent("pay-123", "booking-456", "gateway timeout", "evt-123")
+	if err != nil {
+		t.Fatalf("NewPaymentRefundFailedEvent() error = %v", err)
+	}
+
+	if event.EventType != PaymentEventRefundFailed || event.EventID != "evt-123" {
+		t.Errorf("unexpected event: %+v", event)
+	}
+	data := event.PaymentData
+	if data.PaymentID != "pay-123" || data.BookingID != "booking-456" {
+		t.Errorf("unexpected event data: %+v", data)
+	}
+	if data.ErrorMessage != "gateway timeout" || data.ErrorCode == "" {
+		t.Errorf("Expected error details, got code=%q message=%q", data.ErrorCode, data.ErrorMessage)
+	}
+	if event.Key() != "booking-456" {
+		t.Errorf("Expected key 'booking-456', got '%s'", event.Key())
+	}
+}
+
 func TestDefaultBookingConsumerConfig(t *testing.T) {
 	cfg := DefaultBookingConsumerConfig()
 
@@ -210,7 +297,10 @@ func TestPaymentServiceIntegration(t *testing.T) {
 	})
 
 	// Create payment service
-	svc := service.NewPaymentService(repo, gw, nil)
+	svc := service.NewPaymentService(repo, gw, &service.PaymentServiceConfig{
+		Currency:      "THB",
+		BookingClient: &bookingTotals{totals: map[string]float64{"booking-123": 1000}},
+	})
 
 	ctx := context.Background()
 
@@ -260,7 +350,10 @@ func TestPaymentServiceFailure(t *testing.T) {
 	})
 
 	// Create payment service
-	svc := service.NewPaymentService(repo, gw, nil)
+	svc := service.NewPaymentService(repo, gw, &service.PaymentServiceConfig{
+		Currency:      "THB",
+		BookingClient: &bookingTotals{totals: map[string]float64{"booking-123": 1000}},
+	})
 
 	ctx := context.Background()
 
@@ -20,51 +20,191 @@ type Container struct {
 	PaymentGateway gateway.PaymentGateway
 
 	// Repositories
-	PaymentRepo repository.PaymentRepository
+	PaymentRepo       repository.PaymentRepository
+	UserDataRepo      repository.UserDataRepository
+	PaymentSearchRepo repository.PaymentSearchRepository
+	InvoiceRepo       repository.InvoiceRepository
+	RefundReviewRepo  repository.RefundReviewRepository
+	LedgerRepo        repository.LedgerRepository
+	PayoutRepo        repository.PayoutRepository
+	RefundRequestRepo repository.RefundRequestRepository
+	MethodPolicyRepo  repository.PaymentMethodPolicyRepository
 
 	// Services
-	PaymentService service.PaymentService
+	PaymentService       service.PaymentService
+	UserDataService      service.UserDataService
+	PaymentSearchService service.PaymentSearchService
+	InvoiceService       service.InvoiceService
+	RefundService        service.RefundReconciliationService
+	LedgerService        service.LedgerService
+	PayoutService        service.PayoutService
+	RefundRequestService service.RefundRequestService
+	MethodPolicyService  service.PaymentMethodPolicyService
+	ReconcilerService    service.PaymentWatchdogService
 
 	// Handlers
-	HealthHandler  *handler.HealthHandler
-	PaymentHandler *handler.PaymentHandler
-	WebhookHandler *handler.WebhookHandler
+	HealthHandler        *handler.HealthHandler
+	PaymentHandler       *handler.PaymentHandler
+	WebhookHandler       *handler.WebhookHandler
+	UserDataHandler      *handler.UserDataHandler
+	SearchHandler        *handler.PaymentSearchHandler
+	InvoiceHandler       *handler.InvoiceHandler
+	RefundHandler        *handler.RefundReviewHandler
+	LedgerHandler        *handler.LedgerHandler
+	PayoutHandler        *handler.PayoutHandler
+	TenantShardHandler   *handler.TenantShardHandler
+	RefundRequestHandler *handler.RefundRequestHandler
+	MethodPolicyHandler  *handler.PaymentMethodPolicyHandler
 }
 
 // ContainerConfig contains configuration for building the container
 type ContainerConfig struct {
-	DB                   *database.PostgresDB
-	Redis                *redis.Client
-	PaymentRepo          repository.PaymentRepository
-	PaymentGateway       gateway.PaymentGateway
-	KafkaProducer        *kafka.Producer
-	ServiceConfig        *service.PaymentServiceConfig
-	StripeWebhookSecret  string
-	AuthServiceURL       string
+	DB                  *database.PostgresDB
+	Redis               *redis.Client
+	PaymentRepo         repository.PaymentRepository
+	UserDataRepo        repository.UserDataRepository
+	PaymentSearchRepo   repository.PaymentSearchRepository
+	InvoiceRepo         repository.InvoiceRepository
+	RefundReviewRepo    repository.RefundReviewRepository
+	LedgerRepo          repository.LedgerRepository
+	PayoutRepo          repository.PayoutRepository
+	RefundRequestRepo   repository.RefundRequestRepository
+	MethodPolicyRepo    repository.PaymentMethodPolicyRepository
+	TenantShards        *database.ShardResolver // Set only with tenant shards; PaymentRepo already routes by it
+	PaymentGateway      gateway.PaymentGateway
+	KafkaProducer       *kafka.Producer
+	ServiceConfig       *service.PaymentServiceConfig
+	InvoiceConfig       *service.InvoiceServiceConfig
+	PayoutConfig        *service.PayoutServiceConfig
+	RefundApproval      *service.RefundRequestServiceConfig // Notifier is set from KafkaProducer
+	StripeWebhookSecret string
+	AuthServiceURL      string
 }
 
 // NewContainer creates a new dependency injection container
 func NewContainer(cfg *ContainerConfig) *Container {
 	c := &Container{
-		DB:             cfg.DB,
-		Redis:          cfg.Redis,
-		PaymentRepo:    cfg.PaymentRepo,
-		PaymentGateway: cfg.PaymentGateway,
+		DB:                cfg.DB,
+		Redis:             cfg.Redis,
+		PaymentRepo:       cfg.PaymentRepo,
+		UserDataRepo:      cfg.UserDataRepo,
+		PaymentSearchRepo: cfg.PaymentSearchRepo,
+		InvoiceRepo:       cfg.InvoiceRepo,
+		RefundReviewRepo:  cfg.RefundReviewRepo,
+		LedgerRepo:        cfg.LedgerRepo,
+		PayoutRepo:        cfg.PayoutRepo,
+		RefundRequestRepo: cfg.RefundRequestRepo,
+		MethodPolicyRepo:  cfg.MethodPolicyRepo,
+		PaymentGateway:    cfg.PaymentGateway,
 	}
 
 	// Initialize handlers
 	c.HealthHandler = handler.NewHealthHandler(c.DB, c.Redis)
 
+	// Initialize the double-entry ledger charges, refunds, fees and payouts are posted to
+	var ledger service.LedgerRecorder
+	if c.LedgerRepo != nil && c.PaymentRepo != nil {
+		c.LedgerService = service.NewLedgerService(c.LedgerRepo, c.PaymentRepo)
+		c.LedgerHandler = handler.NewLedgerHandler(c.LedgerService)
+		ledger = c.LedgerService
+	}
+
+	// Initialize the payment methods accepted per tenant and event
+	if c.MethodPolicyRepo != nil {
+		c.MethodPolicyService = service.NewPaymentMethodPolicyService(c.MethodPolicyRepo)
+		c.MethodPolicyHandler = handler.NewPaymentMethodPolicyHandler(c.MethodPolicyService)
+	}
+
 	// Initialize PaymentService if repository and gateway are provided
 	if c.PaymentRepo != nil && c.PaymentGateway != nil {
-		c.PaymentService = service.NewPaymentService(c.PaymentRepo, c.PaymentGateway, cfg.ServiceConfig)
+		serviceConfig := cfg.ServiceConfig
+		if serviceConfig != nil && (ledger != nil || c.MethodPolicyService != nil || cfg.KafkaProducer != nil) {
+			withDeps := *serviceConfig
+			if ledger != nil {
+				withDeps.Ledger = ledger
+			}
+			if c.MethodPolicyService != nil {
+				withDeps.MethodPolicies = c.MethodPolicyService
+			}
+			if cfg.KafkaProducer != nil {
+				withDeps.CreatedPublisher = service.NewKafkaPaymentCreatedPublisher(cfg.KafkaProducer)
+			}
+			serviceConfig = &withDeps
+		}
+		c.PaymentService = service.NewPaymentService(c.PaymentRepo, c.PaymentGateway, serviceConfig)
 		c.PaymentHandler = handler.NewPaymentHandler(c.PaymentService, c.PaymentGateway, cfg.AuthServiceURL)
+		if c.MethodPolicyService != nil {
+			c.PaymentHandler.UseMethodPolicies(c.MethodPolicyService)
+		}
+
+		// Initialize resolution of payments requiring action when the customer returns
+		// from the gateway; payment-watchdog resolves the ones that never return
+		if stuckRepo, ok := c.PaymentRepo.(repository.StuckPaymentRepository); ok {
+			reconcilerConfig := &service.PaymentWatchdogConfig{}
+			if serviceConfig != nil {
+				reconcilerConfig.BookingClient = serviceConfig.BookingClient
+			}
+			if cfg.KafkaProducer != nil {
+				reconcilerConfig.Publisher = service.NewKafkaPaymentOutcomePublisher(cfg.KafkaProducer)
+			}
+			c.ReconcilerService = service.NewPaymentWatchdogService(stuckRepo, c.PaymentRepo, c.PaymentService, c.PaymentGateway, reconcilerConfig)
+			c.PaymentHandler.UseReconciliation(c.ReconcilerService)
+		}
+
+		// Initialize reconciliation of refunds made at the gateway
+		if c.RefundReviewRepo != nil {
+			c.RefundService = service.NewRefundReconciliationService(c.PaymentRepo, c.RefundReviewRepo, ledger)
+			c.RefundHandler = handler.NewRefundReviewHandler(c.RefundService)
+		}
+
+		// Initialize the approval workflow of refunds requested through the API
+		if c.RefundRequestRepo != nil {
+			refundConfig := &service.RefundRequestServiceConfig{}
+			if cfg.RefundApproval != nil {
+				*refundConfig = *cfg.RefundApproval
+			}
+			if cfg.KafkaProducer != nil {
+				refundConfig.Notifier = service.NewKafkaRefundNotifier(cfg.KafkaProducer)
+			}
+			c.RefundRequestService = service.NewRefundRequestService(c.RefundRequestRepo, c.PaymentService, refundConfig)
+			c.RefundRequestHandler = handler.NewRefundRequestHandler(c.RefundRequestService)
+			c.PaymentHandler.UseRefundApproval(c.RefundRequestService)
+		}
 
 		// Initialize WebhookHandler if webhook secret is provided
 		if cfg.StripeWebhookSecret != "" {
-			c.WebhookHandler = handler.NewWebhookHandler(c.PaymentService, cfg.StripeWebhookSecret, cfg.KafkaProducer)
+			c.WebhookHandler = handler.NewWebhookHandler(c.PaymentService, c.RefundService, cfg.StripeWebhookSecret, cfg.KafkaProducer)
 		}
 	}
 
+	// Initialize user data export/anonymization for GDPR requests coordinated by auth-service
+	if c.UserDataRepo != nil {
+		c.UserDataService = service.NewUserDataService(c.UserDataRepo)
+		c.UserDataHandler = handler.NewUserDataHandler(c.UserDataService)
+	}
+
+	// Initialize payment lookups for the support booking search in booking-service
+	if c.PaymentSearchRepo != nil {
+		c.PaymentSearchService = service.NewPaymentSearchService(c.PaymentSearchRepo)
+		c.SearchHandler = handler.NewPaymentSearchHandler(c.PaymentSearchService)
+	}
+
+	// Initialize tax invoices and the monthly e-Tax export
+	if c.InvoiceRepo != nil && c.PaymentRepo != nil {
+		c.InvoiceService = service.NewInvoiceService(c.InvoiceRepo, c.PaymentRepo, cfg.InvoiceConfig)
+		c.InvoiceHandler = handler.NewInvoiceHandler(c.InvoiceService)
+	}
+
+	// Initialize payout schedules and history; the payout worker makes the payouts
+	if c.PayoutRepo != nil && c.LedgerRepo != nil && c.PaymentGateway != nil {
+		c.PayoutService = service.NewPayoutService(c.PayoutRepo, c.LedgerRepo, c.PaymentGateway, cfg.PayoutConfig)
+		c.PayoutHandler = handler.NewPayoutHandler(c.PayoutService)
+	}
+
+	// Initialize tenant shard assignment when payments are sharded by tenant
+	if cfg.TenantShards != nil {
+		c.TenantShardHandler = handler.NewTenantShardHandler(cfg.TenantShards)
+	}
+
 	return c
 }
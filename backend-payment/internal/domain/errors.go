@@ -13,4 +13,53 @@ var (
 	ErrRefundFailed         = errors.New("refund processing failed")
 	ErrInvalidPaymentMethod = errors.New("invalid payment method")
 	ErrDuplicateTransaction = errors.New("duplicate transaction")
+	ErrInvalidUserID        = errors.New("invalid user id")
+	ErrInvalidCardLastFour  = errors.New("card last four must be 4 digits")
+	ErrInvalidSearchFilter  = errors.New("booking_ids or last4 is required")
+	ErrBookingNotFound      = errors.New("booking not found")
+	ErrAmountMismatch       = errors.New("payment amount does not match booking total")
+	ErrRefundExceedsAmount  = errors.New("refund exceeds the refundable amount")
+
+	// Refund reconciliation errors
+	ErrRefundReviewNotFound = errors.New("refund review not found")
+	ErrRefundReviewResolved = errors.New("refund review is already resolved")
+
+	// Refund approval errors
+	ErrRefundRequestNotFound        = errors.New("refund request not found")
+	ErrRefundRequestExists          = errors.New("an open refund request already exists for this payment")
+	ErrRefundRequestNotPending      = errors.New("refund request is not pending approval")
+	ErrRefundRequestNotApproved     = errors.New("refund request is not approved")
+	ErrRefundRequestChanged         = errors.New("refund request was changed by another request")
+	ErrRefundRejectReasonRequired   = errors.New("a reason is required to reject a refund")
+	ErrInvalidRefundApprovalPolicy  = errors.New("refund approval threshold must not be negative")
+	ErrRefundApprovalPolicyNotFound = errors.New("refund approval policy not found")
+
+	// Tax invoice errors
+	ErrInvalidTaxInfo       = errors.New("tax invoice requires company name, address and a 5-digit branch code")
+	ErrInvalidTaxID         = errors.New("tax id must be a valid 13-digit Thai taxpayer id")
+	ErrTaxInfoRequired      = errors.New("buyer tax details are required for a tax invoice")
+	ErrPaymentNotCharged    = errors.New("tax invoices can only be issued for charged payments")
+	ErrInvoiceNotFound      = errors.New("invoice not found")
+	ErrInvoiceAlreadyExists = errors.New("invoice already issued for this payment")
+	ErrInvalidInvoiceMonth  = errors.New("month must be formatted as YYYY-MM")
+	ErrFileStorageDisabled  = errors.New("file storage is not configured")
+
+	// Ledger errors
+	ErrInvalidLedgerTransaction    = errors.New("invalid ledger transaction")
+	ErrUnbalancedLedgerTransaction = errors.New("ledger transaction debits and credits do not balance")
+	ErrLedgerTransactionExists     = errors.New("ledger transaction already recorded")
+	ErrLedgerTransactionNotFound   = errors.New("ledger transaction not found")
+	ErrInvalidLedgerFilter         = errors.New("invalid ledger filter")
+
+	// Payout errors
+	ErrInvalidPayoutSchedule  = errors.New("invalid payout schedule")
+	ErrPayoutScheduleNotFound = errors.New("payout schedule not found")
+	ErrPayoutNotFound         = errors.New("payout not found")
+	ErrPayoutExists           = errors.New("payout already created for this period")
+	ErrInvalidPayoutFilter    = errors.New("invalid payout filter")
+
+	// Payment method policy errors
+	ErrPaymentMethodNotAllowed     = errors.New("payment method is not accepted for this event")
+	ErrInvalidPaymentMethodPolicy  = errors.New("a payment method policy must allow at least one method")
+	ErrPaymentMethodPolicyNotFound = errors.New("payment method policy not found")
 )
@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Thai VAT rules for full tax invoices
+const (
+	// VATRate is the Thai VAT rate; ticket prices already include it
+	VATRate = 0.07
+
+	// HeadOfficeBranch is the branch code of a head office on Thai tax invoices
+	HeadOfficeBranch = "00000"
+)
+
+// InvoiceLocation is the timezone invoice periods and issue dates are counted in
+// (Asia/Bangkok, fixed so the service does not depend on tzdata)
+var InvoiceLocation = time.FixedZone("ICT", 7*60*60)
+
+// TaxInfo holds the buyer details a full Thai tax invoice must show
+type TaxInfo struct {
+	CompanyName string `json:"company_name"`
+	TaxID       string `json:"tax_id"`      // 13-digit taxpayer identification number
+	BranchCode  string `json:"branch_code"` // 5 digits, 00000 for head office
+	Address     string `json:"address"`
+}
+
+// Normalize trims the buyer details and strips the dashes customers often type in tax IDs
+func (t *TaxInfo) Normalize() {
+	t.CompanyName = strings.TrimSpace(t.CompanyName)
+	t.TaxID = strings.NewReplacer("-", "", " ", "").Replace(t.TaxID)
+	t.BranchCode = strings.TrimSpace(t.BranchCode)
+	if t.BranchCode == "" {
+		t.BranchCode = HeadOfficeBranch
+	}
+	t.Address = strings.TrimSpace(t.Address)
+}
+
+// Validate checks the buyer details required on a full tax invoice
+func (t *TaxInfo) Validate() error {
+	if t.CompanyName == "" || t.Address == "" {
+		return ErrInvalidTaxInfo
+	}
+	if !isTaxID(t.TaxID) {
+		return ErrInvalidTaxID
+	}
+	if len(t.BranchCode) != 5 || !isDigits(t.BranchCode) {
+		return ErrInvalidTaxInfo
+	}
+	return nil
+}
+
+// isTaxID reports whether s is a valid 13-digit Thai taxpayer ID, including its check digit
+func isTaxID(s string) bool {
+	if len(s) != 13 || !isDigits(s) {
+		return false
+	}
+	sum := 0
+	for i := 0; i < 12; i++ {
+		sum += int(s[i]-'0') * (13 - i)
+	}
+	return (11-sum%11)%10 == int(s[12]-'0')
+}
+
+// isDigits reports whether s consists only of ASCII digits
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Invoice is a full tax invoice (ใบกำกับภาษีเต็มรูป) issued for a charged payment
+type Invoice struct {
+	ID        string    `json:"id"`
+	TenantID  string    `json:"tenant_id"`
+	PaymentID string    `json:"payment_id"`
+	BookingID string    `json:"booking_id"`
+	Period    string    `json:"period"`   // YYYYMM the running number belongs to
+	Sequence  int64     `json:"sequence"` // Running number within the tenant and period
+	Number    string    `json:"number"`
+	Buyer     TaxInfo   `json:"buyer"`
+	Currency  string    `json:"currency"`
+	Subtotal  float64   `json:"subtotal"` // Amount before VAT
+	VATRate   float64   `json:"vat_rate"`
+	VATAmount float64   `json:"vat_amount"`
+	Total     float64   `json:"total"` // Amount the buyer paid, VAT included
+	IssuedAt  time.Time `json:"issued_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewInvoice prepares a tax invoice for the amount charged on a payment. The running
+// number is assigned by the repository when the invoice is saved.
+func NewInvoice(payment *Payment, buyer TaxInfo, issuedAt time.Time) (*Invoice, error) {
+	if !payment.IsSuccessful() {
+		return nil, ErrPaymentNotCharged
+	}
+	total := payment.RefundableAmount()
+	if toMinorUnits(total) <= 0 {
+		return nil, ErrPaymentNotCharged
+	}
+
+	// Prices include VAT, so the tax is extracted from the total
+	vat := roundSatang(total * VATRate / (1 + VATRate))
+	return &Invoice{
+		ID:        uuid.New().String(),
+		TenantID:  payment.TenantID,
+		PaymentID: payment.ID,
+		BookingID: payment.BookingID,
+		Period:    InvoicePeriod(issuedAt),
+		Buyer:     buyer,
+		Currency:  payment.Currency,
+		Subtotal:  roundSatang(total - vat),
+		VATRate:   VATRate,
+		VATAmount: vat,
+		Total:     roundSatang(total),
+		IssuedAt:  issuedAt.UTC(),
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// AssignNumber sets the running number of the invoice within its tenant and period
+func (i *Invoice) AssignNumber(sequence int64) {
+	i.Sequence = sequence
+	i.Number = fmt.Sprintf("INV-%s-%06d", i.Period, sequence)
+}
+
+// InvoicePeriod returns the YYYYMM tax month t falls in, in Thai time
+func InvoicePeriod(t time.Time) string {
+	return t.In(InvoiceLocation).Format("200601")
+}
+
+// ParseInvoiceMonth parses a YYYY-MM month and returns the start and end of it in Thai time
+func ParseInvoiceMonth(month string) (time.Time, time.Time, error) {
+	start, err := time.ParseInLocation("2006-01", month, InvoiceLocation)
+	if err != nil {
+		return time.Time{}, time.Time{}, ErrInvalidInvoiceMonth
+	}
+	return start, start.AddDate(0, 1, 0), nil
+}
+
+// roundSatang rounds an amount to two decimal places
+func roundSatang(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
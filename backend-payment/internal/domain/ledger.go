@@ -0,0 +1,254 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/id"
+)
+
+// LedgerAccount is an account of a tenant's ledger
+type LedgerAccount string
+
+const (
+	// LedgerAccountGatewayClearing (asset) holds the tenant's funds at the gateway that
+	// have not been paid out. Its balance should match the gateway's settlement balance.
+	LedgerAccountGatewayClearing LedgerAccount = "gateway_clearing"
+	// LedgerAccountTenantPayable (liability) is the revenue owed to the tenant
+	LedgerAccountTenantPayable LedgerAccount = "tenant_payable"
+	// LedgerAccountProcessingFees (expense) is what the gateway has charged in fees
+	LedgerAccountProcessingFees LedgerAccount = "processing_fees"
+)
+
+// LedgerAccounts lists the accounts of every tenant ledger
+var LedgerAccounts = []LedgerAccount{
+	LedgerAccountGatewayClearing,
+	LedgerAccountTenantPayable,
+	LedgerAccountProcessingFees,
+}
+
+// IsValid reports whether a is a ledger account
+func (a LedgerAccount) IsValid() bool {
+	for _, account := range LedgerAccounts {
+		if a == account {
+			return true
+		}
+	}
+	return false
+}
+
+// DebitNormal reports whether debits increase the account's balance (assets and expenses)
+func (a LedgerAccount) DebitNormal() bool {
+	return a != LedgerAccountTenantPayable
+}
+
+// LedgerDirection is the side of an entry
+type LedgerDirection string
+
+const (
+	LedgerDebit  LedgerDirection = "debit"
+	LedgerCredit LedgerDirection = "credit"
+)
+
+// LedgerTransactionType is the business event a ledger transaction records
+type LedgerTransactionType string
+
+const (
+	LedgerTransactionPayment LedgerTransactionType = "payment" // Charge captured from a customer
+	LedgerTransactionRefund  LedgerTransactionType = "refund"  // Charge returned to a customer
+	LedgerTransactionFee     LedgerTransactionType = "fee"     // Processing fee withheld by the gateway
+	LedgerTransactionPayout  LedgerTransactionType = "payout"  // Funds paid out to the tenant's bank
+	// LedgerTransactionFeeRecovery charges processing fees to the tenant, withheld from a payout
+	LedgerTransactionFeeRecovery LedgerTransactionType = "fee_recovery"
+)
+
+// ledgerPostings maps each transaction type to the accounts it debits and credits
+var ledgerPostings = map[LedgerTransactionType]struct{ debit, credit LedgerAccount }{
+	LedgerTransactionPayment: {LedgerAccountGatewayClearing, LedgerAccountTenantPayable},
+	LedgerTransactionRefund:  {LedgerAccountTenantPayable, LedgerAccountGatewayClearing},
+	LedgerTransactionFee:     {LedgerAccountProcessingFees, LedgerAccountGatewayClearing},
+	LedgerTransactionPayout:  {LedgerAccountTenantPayable, LedgerAccountGatewayClearing},
+
+	LedgerTransactionFeeRecovery: {LedgerAccountTenantPayable, LedgerAccountProcessingFees},
+}
+
+// IsValid reports whether t is a ledger transaction type
+func (t LedgerTransactionType) IsValid() bool {
+	_, ok := ledgerPostings[t]
+	return ok
+}
+
+// LedgerEntry is one side of a ledger transaction. Amounts are in minor units
+// (satang/cents) so balances add up exactly.
+type LedgerEntry struct {
+	ID            string          `json:"id"`
+	TransactionID string          `json:"transaction_id"`
+	TenantID      string          `json:"tenant_id"`
+	Account       LedgerAccount   `json:"account"`
+	Direction     LedgerDirection `json:"direction"`
+	Amount        int64           `json:"amount"`
+	Currency      string          `json:"currency"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+
+	// Copied from the transaction so entry listings can be matched against settlement files
+	Type      LedgerTransactionType `json:"type"`
+	PaymentID string                `json:"payment_id,omitempty"`
+	Reference string                `json:"reference,omitempty"`
+}
+
+// LedgerTransaction is a set of entries that debit and credit the same total
+type LedgerTransaction struct {
+	ID             string                `json:"id"`
+	TenantID       string                `json:"tenant_id"`
+	Type           LedgerTransactionType `json:"type"`
+	PaymentID      string                `json:"payment_id,omitempty"`
+	Reference      string                `json:"reference,omitempty"` // Gateway ID of the charge, fee or payout
+	IdempotencyKey string                `json:"idempotency_key"`
+	Currency       string                `json:"currency"`
+	Description    string                `json:"description,omitempty"`
+	Entries        []*LedgerEntry        `json:"entries"`
+	OccurredAt     time.Time             `json:"occurred_at"`
+	CreatedAt      time.Time             `json:"created_at"`
+}
+
+// NewLedgerTransaction creates a balanced transaction of a major-unit amount, debiting
+// and crediting the accounts of its type. idempotencyKey identifies the business event
+// so it is recorded once however often it is reported.
+func NewLedgerTransaction(tenantID string, txType LedgerTransactionType, amount float64, currency, idempotencyKey string, occurredAt time.Time) (*LedgerTransaction, error) {
+	posting, ok := ledgerPostings[txType]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown type %q", ErrInvalidLedgerTransaction, txType)
+	}
+	if tenantID == "" || idempotencyKey == "" {
+		return nil, fmt.Errorf("%w: tenant_id and idempotency key are required", ErrInvalidLedgerTransaction)
+	}
+	minor := toMinorUnits(amount)
+	if minor <= 0 {
+		return nil, fmt.Errorf("%w: amount must be positive", ErrInvalidLedgerTransaction)
+	}
+	if currency == "" {
+		currency = "THB"
+	}
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+
+	tx := &LedgerTransaction{
+		ID:             id.New(),
+		TenantID:       tenantID,
+		Type:           txType,
+		IdempotencyKey: idempotencyKey,
+		Currency:       currency,
+		OccurredAt:     occurredAt.UTC(),
+		CreatedAt:      time.Now().UTC(),
+	}
+	tx.Entries = []*LedgerEntry{
+		tx.newEntry(posting.debit, LedgerDebit, minor),
+		tx.newEntry(posting.credit, LedgerCredit, minor),
+	}
+	return tx, nil
+}
+
+// newEntry creates an entry of the transaction
+func (t *LedgerTransaction) newEntry(account LedgerAccount, direction LedgerDirection, amount int64) *LedgerEntry {
+	return &LedgerEntry{
+		ID:            id.New(),
+		TransactionID: t.ID,
+		TenantID:      t.TenantID,
+		Account:       account,
+		Direction:     direction,
+		Amount:        amount,
+		Currency:      t.Currency,
+		OccurredAt:    t.OccurredAt,
+		Type:          t.Type,
+	}
+}
+
+// SetPayment links the transaction and its entries to a payment and its gateway reference
+func (t *LedgerTransaction) SetPayment(paymentID, reference string) {
+	t.PaymentID = paymentID
+	t.Reference = reference
+	for _, entry := range t.Entries {
+		entry.PaymentID = paymentID
+		entry.Reference = reference
+	}
+}
+
+// Validate checks that the transaction debits and credits the same positive total in
+// its own tenant and currency
+func (t *LedgerTransaction) Validate() error {
+	var debits, credits int64
+	for _, entry := range t.Entries {
+		if entry.Amount <= 0 || !entry.Account.IsValid() ||
+			entry.TenantID != t.TenantID || entry.Currency != t.Currency {
+			return ErrInvalidLedgerTransaction
+		}
+		switch entry.Direction {
+		case LedgerDebit:
+			debits += entry.Amount
+		case LedgerCredit:
+			credits += entry.Amount
+		default:
+			return ErrInvalidLedgerTransaction
+		}
+	}
+	if debits == 0 || debits != credits {
+		return ErrUnbalancedLedgerTransaction
+	}
+	return nil
+}
+
+// PaymentLedgerKey is the idempotency key of a payment's charges. It changes with the
+// charged total, so each additional charge of a booking change is recorded once.
+func PaymentLedgerKey(p *Payment) string {
+	return fmt.Sprintf("payment:%s:%d", p.ID, toMinorUnits(p.Amount))
+}
+
+// RefundLedgerKey is the idempotency key of a payment's refunds. It changes with the
+// refunded total, so a refund reported by both this service and the gateway webhook
+// is recorded once.
+func RefundLedgerKey(p *Payment) string {
+	return fmt.Sprintf("refund:%s:%d", p.ID, toMinorUnits(p.RefundedAmount()))
+}
+
+// LedgerBalance is the balance of a tenant's account in one currency
+type LedgerBalance struct {
+	TenantID string        `json:"tenant_id"`
+	Account  LedgerAccount `json:"account"`
+	Currency string        `json:"currency"`
+	Debits   int64         `json:"debits"`
+	Credits  int64         `json:"credits"`
+}
+
+// Balance returns the balance on the account's normal side, in minor units
+func (b *LedgerBalance) Balance() int64 {
+	if b.Account.DebitNormal() {
+		return b.Debits - b.Credits
+	}
+	return b.Credits - b.Debits
+}
+
+// LedgerEntryFilter selects the entries of a tenant's ledger
+type LedgerEntryFilter struct {
+	TenantID  string
+	Account   LedgerAccount         // All accounts when empty
+	Type      LedgerTransactionType // All types when empty
+	PaymentID string
+	From      time.Time // Inclusive, unbounded when zero
+	To        time.Time // Exclusive, unbounded when zero
+}
+
+// Matches reports whether an entry is selected by the filter
+func (f *LedgerEntryFilter) Matches(entry *LedgerEntry) bool {
+	return entry.TenantID == f.TenantID &&
+		(f.Account == "" || entry.Account == f.Account) &&
+		(f.Type == "" || entry.Type == f.Type) &&
+		(f.PaymentID == "" || entry.PaymentID == f.PaymentID) &&
+		(f.From.IsZero() || !entry.OccurredAt.Before(f.From)) &&
+		(f.To.IsZero() || entry.OccurredAt.Before(f.To))
+}
+
+// MinorToMajor converts an amount in minor units to major units
+func MinorToMajor(amount int64) float64 {
+	return float64(amount) / 100
+}
@@ -2,24 +2,34 @@ package domain
 
 import (
 	"errors"
+	"fmt"
+	"math"
 	"time"
 
-	"github.com/google/uuid"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/id"
 )
 
 // PaymentStatus represents the status of a payment (matches DB ENUM)
 type PaymentStatus string
 
 const (
-	PaymentStatusPending       PaymentStatus = "pending"
-	PaymentStatusProcessing    PaymentStatus = "processing"
-	PaymentStatusSucceeded     PaymentStatus = "succeeded"
-	PaymentStatusFailed        PaymentStatus = "failed"
-	PaymentStatusCancelled     PaymentStatus = "cancelled"
-	PaymentStatusRefundPending PaymentStatus = "refund_pending"
-	PaymentStatusRefunded      PaymentStatus = "refunded"
+	PaymentStatusPending        PaymentStatus = "pending"
+	PaymentStatusProcessing     PaymentStatus = "processing"
+	PaymentStatusRequiresAction PaymentStatus = "requires_action" // Customer paying at the gateway (3-D Secure, bank transfer)
+	PaymentStatusAuthorized     PaymentStatus = "authorized"      // Card hold placed, not yet captured
+	PaymentStatusCaptured       PaymentStatus = "captured"        // Authorized hold captured
+	PaymentStatusSucceeded      PaymentStatus = "succeeded"
+	PaymentStatusFailed         PaymentStatus = "failed"
+	PaymentStatusCancelled      PaymentStatus = "cancelled"
+	PaymentStatusVoided         PaymentStatus = "voided" // Authorized hold released without capture
+	PaymentStatusRefundPending  PaymentStatus = "refund_pending"
+	PaymentStatusRefunded       PaymentStatus = "refunded"
 )
 
+// MetadataRedirectURL is the metadata key of the gateway page a payment requiring
+// action sends the customer to
+const MetadataRedirectURL = "redirect_url"
+
 // PaymentMethod represents the method of payment (matches DB ENUM)
 type PaymentMethod string
 
@@ -59,6 +69,7 @@ type Payment struct {
 	ErrorMessage      string            `json:"error_message,omitempty"`
 	RetryCount        int               `json:"retry_count"`
 	Metadata          map[string]string `json:"metadata,omitempty"`
+	TaxInfo           *TaxInfo          `json:"tax_info,omitempty"` // Buyer details for a full tax invoice
 	CreatedAt         time.Time         `json:"created_at"`
 	UpdatedAt         time.Time         `json:"updated_at"`
 }
@@ -83,7 +94,7 @@ func NewPayment(tenantID, bookingID, userID string, amount float64, currency str
 
 	now := time.Now().UTC()
 	return &Payment{
-		ID:          uuid.New().String(),
+		ID:          id.New(),
 		TenantID:    tenantID,
 		BookingID:   bookingID,
 		UserID:      userID,
@@ -109,10 +120,34 @@ func (p *Payment) MarkProcessing() error {
 	return nil
 }
 
+// RequireAction marks a processing payment as waiting for the customer to finish paying
+// at the gateway, such as 3-D Secure or a bank transfer. redirectURL is the gateway page
+// to send them to, if the gateway gave one.
+func (p *Payment) RequireAction(gatewayPaymentID, redirectURL string) error {
+	if p.Status != PaymentStatusProcessing {
+		return errors.New("payment must be processing to require action")
+	}
+	p.Status = PaymentStatusRequiresAction
+	p.GatewayPaymentID = gatewayPaymentID
+	if redirectURL != "" {
+		if p.Metadata == nil {
+			p.Metadata = make(map[string]string)
+		}
+		p.Metadata[MetadataRedirectURL] = redirectURL
+	}
+	p.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// RedirectURL returns the gateway page where the customer completes a payment requiring action
+func (p *Payment) RedirectURL() string {
+	return p.Metadata[MetadataRedirectURL]
+}
+
 // Complete marks the payment as succeeded
 func (p *Payment) Complete(gatewayPaymentID string) error {
-	if p.Status != PaymentStatusProcessing && p.Status != PaymentStatusPending {
-		return errors.New("payment must be pending or processing to complete")
+	if !p.IsInFlight() {
+		return errors.New("payment must be pending, processing or requiring action to complete")
 	}
 	now := time.Now().UTC()
 	p.Status = PaymentStatusSucceeded
@@ -122,10 +157,48 @@ func (p *Payment) Complete(gatewayPaymentID string) error {
 	return nil
 }
 
+// Authorize marks the payment as authorized: the gateway holds the amount on the card
+// until it is captured or voided
+func (p *Payment) Authorize(gatewayPaymentID string) error {
+	if !p.IsInFlight() {
+		return errors.New("payment must be pending, processing or requiring action to authorize")
+	}
+	now := time.Now().UTC()
+	p.Status = PaymentStatusAuthorized
+	p.GatewayPaymentID = gatewayPaymentID
+	p.UpdatedAt = now
+	p.ProcessedAt = &now
+	return nil
+}
+
+// Capture marks an authorized payment as captured
+func (p *Payment) Capture() error {
+	if p.Status != PaymentStatusAuthorized {
+		return errors.New("only authorized payments can be captured")
+	}
+	p.Status = PaymentStatusCaptured
+	p.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// Void marks an authorized payment as voided. The customer was never charged, so the
+// refund fields record when and why the hold was released.
+func (p *Payment) Void(reason string) error {
+	if p.Status != PaymentStatusAuthorized {
+		return errors.New("only authorized payments can be voided")
+	}
+	now := time.Now().UTC()
+	p.Status = PaymentStatusVoided
+	p.RefundReason = reason
+	p.RefundedAt = &now
+	p.UpdatedAt = now
+	return nil
+}
+
 // Fail marks the payment as failed
 func (p *Payment) Fail(errorCode, errorMessage string) error {
-	if p.Status != PaymentStatusPending && p.Status != PaymentStatusProcessing {
-		return errors.New("payment can only fail from pending or processing status")
+	if !p.IsInFlight() {
+		return errors.New("payment can only fail from pending, processing or requires_action status")
 	}
 	p.Status = PaymentStatusFailed
 	p.ErrorCode = errorCode
@@ -137,7 +210,7 @@ func (p *Payment) Fail(errorCode, errorMessage string) error {
 
 // Refund marks the payment as refunded
 func (p *Payment) Refund(amount float64, reason string) error {
-	if p.Status != PaymentStatusSucceeded {
+	if !p.IsSuccessful() {
 		return errors.New("only succeeded payments can be refunded")
 	}
 	now := time.Now().UTC()
@@ -149,9 +222,80 @@ func (p *Payment) Refund(amount float64, reason string) error {
 	return nil
 }
 
+// PartialRefund records a refund of part of the payment. Refunds accumulate, and the
+// payment becomes refunded once nothing is left to refund.
+func (p *Payment) PartialRefund(amount float64, reason string) error {
+	if !p.IsSuccessful() {
+		return errors.New("only succeeded payments can be refunded")
+	}
+	if amount <= 0 || toMinorUnits(amount) > toMinorUnits(p.RefundableAmount()) {
+		return ErrRefundExceedsAmount
+	}
+	now := time.Now().UTC()
+	refunded := p.RefundedAmount() + amount
+	p.RefundAmount = &refunded
+	p.RefundReason = reason
+	p.RefundedAt = &now
+	p.UpdatedAt = now
+	if toMinorUnits(refunded) >= toMinorUnits(p.Amount) {
+		p.Status = PaymentStatusRefunded
+	}
+	return nil
+}
+
+// SyncRefundedAmount records refunds made directly at the gateway, such as from the
+// Stripe dashboard, given the total the gateway has refunded on the payment. Refunds
+// already recorded are not counted again, so it returns only the newly refunded amount.
+func (p *Payment) SyncRefundedAmount(totalRefunded float64, reason string) (float64, error) {
+	missing := toMinorUnits(totalRefunded) - toMinorUnits(p.RefundedAmount())
+	if missing <= 0 {
+		return 0, nil
+	}
+	if !p.IsSuccessful() {
+		return 0, fmt.Errorf("%w: cannot refund %s payment", ErrInvalidPaymentStatus, p.Status)
+	}
+	amount := float64(missing) / 100
+	if err := p.PartialRefund(amount, reason); err != nil {
+		return 0, err
+	}
+	return amount, nil
+}
+
+// AddCharge records an additional charge for the same booking, such as the price
+// difference of an upgrade
+func (p *Payment) AddCharge(amount float64) error {
+	if !p.IsSuccessful() {
+		return errors.New("only succeeded payments can be charged again")
+	}
+	if amount <= 0 {
+		return ErrInvalidAmount
+	}
+	p.Amount += amount
+	p.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// RefundedAmount returns the amount refunded so far
+func (p *Payment) RefundedAmount() float64 {
+	if p.RefundAmount == nil {
+		return 0
+	}
+	return *p.RefundAmount
+}
+
+// RefundableAmount returns the charged amount that has not been refunded yet
+func (p *Payment) RefundableAmount() float64 {
+	return p.Amount - p.RefundedAmount()
+}
+
+// toMinorUnits converts an amount to satang/cents so float rounding can't affect comparisons
+func toMinorUnits(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
 // MarkRefundPending marks the payment as refund pending
 func (p *Payment) MarkRefundPending() error {
-	if p.Status != PaymentStatusSucceeded {
+	if !p.IsSuccessful() {
 		return errors.New("only succeeded payments can have pending refund")
 	}
 	p.Status = PaymentStatusRefundPending
@@ -159,10 +303,11 @@ func (p *Payment) MarkRefundPending() error {
 	return nil
 }
 
-// Cancel marks the payment as cancelled
+// Cancel marks the payment as cancelled. Payments requiring action can be cancelled
+// once the customer abandons them, after the gateway cancels its side.
 func (p *Payment) Cancel() error {
-	if p.Status != PaymentStatusPending {
-		return errors.New("only pending payments can be cancelled")
+	if p.Status != PaymentStatusPending && p.Status != PaymentStatusRequiresAction {
+		return errors.New("only pending payments or payments requiring action can be cancelled")
 	}
 	p.Status = PaymentStatusCancelled
 	p.UpdatedAt = time.Now().UTC()
@@ -172,14 +317,33 @@ func (p *Payment) Cancel() error {
 // IsFinal returns true if the payment is in a final state
 func (p *Payment) IsFinal() bool {
 	return p.Status == PaymentStatusSucceeded ||
+		p.Status == PaymentStatusCaptured ||
 		p.Status == PaymentStatusFailed ||
 		p.Status == PaymentStatusRefunded ||
-		p.Status == PaymentStatusCancelled
+		p.Status == PaymentStatusCancelled ||
+		p.Status == PaymentStatusVoided
 }
 
-// IsSuccessful returns true if the payment was successful
+// IsSuccessful returns true if the payment was successful (charged or captured)
 func (p *Payment) IsSuccessful() bool {
-	return p.Status == PaymentStatusSucceeded
+	return p.Status == PaymentStatusSucceeded || p.Status == PaymentStatusCaptured
+}
+
+// IsInFlight returns true if the payment has not been settled with the gateway yet
+func (p *Payment) IsInFlight() bool {
+	return p.Status == PaymentStatusPending ||
+		p.Status == PaymentStatusProcessing ||
+		p.Status == PaymentStatusRequiresAction
+}
+
+// RequiresAction returns true if the customer still has to finish paying at the gateway
+func (p *Payment) RequiresAction() bool {
+	return p.Status == PaymentStatusRequiresAction
+}
+
+// IsAuthorized returns true if the payment holds an uncaptured authorization
+func (p *Payment) IsAuthorized() bool {
+	return p.Status == PaymentStatusAuthorized
 }
 
 // SetGatewayInfo sets gateway-related information
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// PaymentMethods lists every payment method in the order clients show them
+var PaymentMethods = []PaymentMethod{
+	PaymentMethodCreditCard,
+	PaymentMethodDebitCard,
+	PaymentMethodBankTransfer,
+	PaymentMethodPromptPay,
+	PaymentMethodWallet,
+	PaymentMethodCash,
+}
+
+// IsValid returns true if m is a known payment method
+func (m PaymentMethod) IsValid() bool {
+	for _, method := range PaymentMethods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// IsCard returns true for the methods paid with a card saved at the gateway
+func (m PaymentMethod) IsCard() bool {
+	return m == PaymentMethodCreditCard || m == PaymentMethodDebitCard
+}
+
+// PaymentMethodPolicy restricts the payment methods accepted for a tenant's events.
+// A policy with an EventID applies to that event only; one without is the tenant's
+// default for events that have no policy of their own.
+type PaymentMethodPolicy struct {
+	TenantID       string          `json:"tenant_id"`
+	EventID        string          `json:"event_id,omitempty"` // Tenant default when empty
+	AllowedMethods []PaymentMethod `json:"allowed_methods"`
+	UpdatedBy      string          `json:"updated_by,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// NewPaymentMethodPolicy creates a policy allowing only the given methods. Methods are
+// deduplicated and kept in the order of PaymentMethods.
+func NewPaymentMethodPolicy(tenantID, eventID string, methods []PaymentMethod, updatedBy string) (*PaymentMethodPolicy, error) {
+	if strings.TrimSpace(tenantID) == "" || len(methods) == 0 {
+		return nil, ErrInvalidPaymentMethodPolicy
+	}
+	requested := make(map[PaymentMethod]bool, len(methods))
+	for _, method := range methods {
+		if !method.IsValid() {
+			return nil, ErrInvalidPaymentMethod
+		}
+		requested[method] = true
+	}
+
+	allowed := make([]PaymentMethod, 0, len(requested))
+	for _, method := range PaymentMethods {
+		if requested[method] {
+			allowed = append(allowed, method)
+		}
+	}
+
+	now := time.Now().UTC()
+	return &PaymentMethodPolicy{
+		TenantID:       tenantID,
+		EventID:        eventID,
+		AllowedMethods: allowed,
+		UpdatedBy:      updatedBy,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}, nil
+}
+
+// DefaultPaymentMethodPolicy allows every payment method. It applies when neither the
+// event nor the tenant has a policy.
+func DefaultPaymentMethodPolicy(tenantID, eventID string) *PaymentMethodPolicy {
+	return &PaymentMethodPolicy{
+		TenantID:       tenantID,
+		EventID:        eventID,
+		AllowedMethods: append([]PaymentMethod(nil), PaymentMethods...),
+	}
+}
+
+// IsDefault returns true if the policy was not configured by an admin
+func (p *PaymentMethodPolicy) IsDefault() bool {
+	return p.UpdatedAt.IsZero()
+}
+
+// Allows returns true if payments with method are accepted
+func (p *PaymentMethodPolicy) Allows(method PaymentMethod) bool {
+	for _, allowed := range p.AllowedMethods {
+		if method == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsCards returns true if payments with a saved card are accepted
+func (p *PaymentMethodPolicy) AllowsCards() bool {
+	return p.Allows(PaymentMethodCreditCard) || p.Allows(PaymentMethodDebitCard)
+}
+
+// AllowedList returns the allowed methods as a comma-separated list for error messages
+func (p *PaymentMethodPolicy) AllowedList() string {
+	names := make([]string, len(p.AllowedMethods))
+	for i, method := range p.AllowedMethods {
+		names[i] = string(method)
+	}
+	return strings.Join(names, ", ")
+}
@@ -172,6 +172,99 @@ func TestPayment_Complete(t *testing.T) {
 	}
 }
 
+func TestPayment_RequireAction(t *testing.T) {
+	payment, _ := NewPayment("tenant-123", "booking-123", "user-123", 100.00, "THB", PaymentMethodCreditCard)
+
+	// Only a charge in progress can ask for customer action
+	if err := payment.RequireAction("pi_123", "https://hooks.stripe.com/3ds"); err == nil {
+		t.Error("Expected error when requiring action on pending payment")
+	}
+
+	payment.MarkProcessing()
+	if err := payment.RequireAction("pi_123", "https://hooks.stripe.com/3ds"); err != nil {
+		t.Fatalf("Unexpected error requiring action: %v", err)
+	}
+	if !payment.RequiresAction() || payment.GatewayPaymentID != "pi_123" || payment.RedirectURL() != "https://hooks.stripe.com/3ds" {
+		t.Errorf("Expected requires_action with pi_123 and the redirect URL, got %s with %s and %q", payment.Status, payment.GatewayPaymentID, payment.RedirectURL())
+	}
+	if payment.IsFinal() {
+		t.Error("Payment requiring action should not be final")
+	}
+
+	// The customer returns from the gateway
+	if err := payment.Complete("pi_123"); err != nil {
+		t.Errorf("Unexpected error completing payment requiring action: %v", err)
+	}
+
+	// Or never does, and the payment is cancelled
+	abandoned, _ := NewPayment("tenant-123", "booking-456", "user-123", 100.00, "THB", PaymentMethodBankTransfer)
+	abandoned.MarkProcessing()
+	abandoned.RequireAction("pi_456", "")
+	if err := abandoned.Cancel(); err != nil {
+		t.Errorf("Unexpected error cancelling payment requiring action: %v", err)
+	}
+}
+
+func TestPayment_AuthorizeCapture(t *testing.T) {
+	payment, _ := NewPayment("tenant-123", "booking-123", "user-123", 100.00, "THB", PaymentMethodCreditCard)
+
+	// Can't capture before authorizing
+	if err := payment.Capture(); err == nil {
+		t.Error("Expected error when capturing pending payment")
+	}
+
+	payment.MarkProcessing()
+	if err := payment.Authorize("pi_123"); err != nil {
+		t.Fatalf("Unexpected error authorizing: %v", err)
+	}
+	if payment.Status != PaymentStatusAuthorized || payment.GatewayPaymentID != "pi_123" {
+		t.Errorf("Expected authorized with pi_123, got %s with %s", payment.Status, payment.GatewayPaymentID)
+	}
+	if payment.IsFinal() || payment.IsSuccessful() {
+		t.Error("Authorized payment should be neither final nor successful")
+	}
+
+	if err := payment.Capture(); err != nil {
+		t.Fatalf("Unexpected error capturing: %v", err)
+	}
+	if payment.Status != PaymentStatusCaptured {
+		t.Errorf("Expected status captured, got %s", payment.Status)
+	}
+	if !payment.IsFinal() || !payment.IsSuccessful() {
+		t.Error("Captured payment should be final and successful")
+	}
+
+	// Captured payments are refunded, not voided
+	if err := payment.Void("booking failed"); err == nil {
+		t.Error("Expected error when voiding captured payment")
+	}
+	if err := payment.Refund(100.00, "customer request"); err != nil {
+		t.Errorf("Unexpected error refunding captured payment: %v", err)
+	}
+}
+
+func TestPayment_Void(t *testing.T) {
+	payment, _ := NewPayment("tenant-123", "booking-123", "user-123", 100.00, "THB", PaymentMethodCreditCard)
+	payment.Authorize("pi_123")
+
+	if err := payment.Void("booking confirmation failed"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if payment.Status != PaymentStatusVoided {
+		t.Errorf("Expected status voided, got %s", payment.Status)
+	}
+	if payment.RefundReason != "booking confirmation failed" || payment.RefundedAt == nil {
+		t.Error("Expected void reason and time to be recorded")
+	}
+	if !payment.IsFinal() || payment.IsSuccessful() {
+		t.Error("Voided payment should be final and not successful")
+	}
+
+	if err := payment.Capture(); err == nil {
+		t.Error("Expected error when capturing voided payment")
+	}
+}
+
 func TestPayment_Fail(t *testing.T) {
 	payment, _ := NewPayment("tenant-123", "booking-123", "user-123", 100.00, "THB", PaymentMethodCreditCard)
 
@@ -220,6 +313,67 @@ func TestPayment_Refund(t *testing.T) {
 	}
 }
 
+func TestPayment_PartialRefundAndAddCharge(t *testing.T) {
+	payment, _ := NewPayment("tenant-123", "booking-123", "user-123", 300.00, "THB", PaymentMethodCreditCard)
+	payment.Complete("pi_123")
+
+	// An upgrade adds to the charged amount
+	if err := payment.AddCharge(150.00); err != nil {
+		t.Fatalf("AddCharge() error = %v", err)
+	}
+	if payment.Amount != 450.00 {
+		t.Errorf("Expected amount 450.00, got %.2f", payment.Amount)
+	}
+
+	// Partial refunds accumulate without refunding the payment
+	if err := payment.PartialRefund(100.00, "booking_modified"); err != nil {
+		t.Fatalf("PartialRefund() error = %v", err)
+	}
+	if payment.Status != PaymentStatusSucceeded || payment.RefundableAmount() != 350.00 {
+		t.Errorf("Expected succeeded payment with 350.00 refundable, got %s with %.2f", payment.Status, payment.RefundableAmount())
+	}
+	if err := payment.PartialRefund(350.01, "booking_modified"); err != ErrRefundExceedsAmount {
+		t.Errorf("Expected ErrRefundExceedsAmount, got %v", err)
+	}
+
+	// Refunding the rest refunds the payment
+	if err := payment.PartialRefund(350.00, "booking_modified"); err != nil {
+		t.Fatalf("PartialRefund() error = %v", err)
+	}
+	if payment.Status != PaymentStatusRefunded || payment.RefundedAmount() != 450.00 {
+		t.Errorf("Expected refunded payment with 450.00 refunded, got %s with %.2f", payment.Status, payment.RefundedAmount())
+	}
+}
+
+func TestPayment_SyncRefundedAmount(t *testing.T) {
+	payment, _ := NewPayment("tenant-123", "booking-123", "user-123", 300.00, "THB", PaymentMethodCreditCard)
+	payment.Complete("pi_123")
+	payment.PartialRefund(50.00, "booking_modified")
+
+	// Refunds we made ourselves are already recorded
+	if amount, err := payment.SyncRefundedAmount(50.00, "gateway_refund"); err != nil || amount != 0 {
+		t.Errorf("SyncRefundedAmount(50) = %.2f, %v, want nothing new", amount, err)
+	}
+
+	// A dashboard refund on top of it is recorded once
+	if amount, err := payment.SyncRefundedAmount(120.00, "gateway_refund"); err != nil || amount != 70.00 {
+		t.Fatalf("SyncRefundedAmount(120) = %.2f, %v, want 70.00", amount, err)
+	}
+	if payment.Status != PaymentStatusSucceeded || payment.RefundedAmount() != 120.00 {
+		t.Errorf("Expected succeeded payment with 120.00 refunded, got %s with %.2f", payment.Status, payment.RefundedAmount())
+	}
+
+	if _, err := payment.SyncRefundedAmount(300.01, "gateway_refund"); err != ErrRefundExceedsAmount {
+		t.Errorf("Expected ErrRefundExceedsAmount, got %v", err)
+	}
+	if amount, err := payment.SyncRefundedAmount(300.00, "gateway_refund"); err != nil || amount != 180.00 {
+		t.Fatalf("SyncRefundedAmount(300) = %.2f, %v, want 180.00", amount, err)
+	}
+	if payment.Status != PaymentStatusRefunded {
+		t.Errorf("Expected refunded payment, got %s", payment.Status)
+	}
+}
+
 func TestPayment_Cancel(t *testing.T) {
 	payment, _ := NewPayment("tenant-123", "booking-123", "user-123", 100.00, "THB", PaymentMethodCreditCard)
 
@@ -0,0 +1,203 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/id"
+)
+
+// PayoutFrequency is how often a tenant is paid out
+type PayoutFrequency string
+
+const (
+	PayoutDaily   PayoutFrequency = "daily"   // Every day at midnight
+	PayoutWeekly  PayoutFrequency = "weekly"  // Mondays at midnight
+	PayoutMonthly PayoutFrequency = "monthly" // The 1st of the month at midnight
+)
+
+// IsValid reports whether f is a payout frequency
+func (f PayoutFrequency) IsValid() bool {
+	return f == PayoutDaily || f == PayoutWeekly || f == PayoutMonthly
+}
+
+// Next returns the first payout time of the frequency after t, at midnight Thai time
+func (f PayoutFrequency) Next(t time.Time) time.Time {
+	local := t.In(InvoiceLocation)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, InvoiceLocation)
+	switch f {
+	case PayoutWeekly:
+		days := (8 - int(midnight.Weekday())) % 7
+		if days == 0 {
+			days = 7
+		}
+		return midnight.AddDate(0, 0, days).UTC()
+	case PayoutMonthly:
+		return time.Date(local.Year(), local.Month()+1, 1, 0, 0, 0, 0, InvoiceLocation).UTC()
+	default:
+		return midnight.AddDate(0, 0, 1).UTC()
+	}
+}
+
+// PayoutMethod is how a payout reaches the tenant's bank
+type PayoutMethod string
+
+const (
+	// PayoutMethodGateway pays out through the payment gateway's payout API
+	PayoutMethodGateway PayoutMethod = "gateway"
+	// PayoutMethodBankFile lists the payout in a bulk transfer file for finance to upload to the bank
+	PayoutMethodBankFile PayoutMethod = "bank_file"
+)
+
+// IsValid reports whether m is a payout method
+func (m PayoutMethod) IsValid() bool {
+	return m == PayoutMethodGateway || m == PayoutMethodBankFile
+}
+
+// PayoutSchedule is how and when a tenant's settled revenue is paid out. Bank details
+// are kept only as the token the gateway or bank vault issued for the account, with
+// the last four digits for display.
+type PayoutSchedule struct {
+	TenantID         string          `json:"tenant_id"`
+	Frequency        PayoutFrequency `json:"frequency"`
+	Method           PayoutMethod    `json:"method"`
+	Currency         string          `json:"currency"`
+	BankAccountToken string          `json:"bank_account_token"`
+	BankCode         string          `json:"bank_code,omitempty"`
+	AccountHolder    string          `json:"account_holder"`
+	AccountLast4     string          `json:"account_last4,omitempty"`
+	MinimumAmount    int64           `json:"minimum_amount"` // Minor units; smaller balances carry over
+	Enabled          bool            `json:"enabled"`
+	NextPayoutAt     time.Time       `json:"next_payout_at"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+}
+
+// Validate checks the schedule's settings, rejecting raw account numbers given as tokens
+func (s *PayoutSchedule) Validate() error {
+	switch {
+	case s.TenantID == "":
+		return fmt.Errorf("%w: tenant_id is required", ErrInvalidPayoutSchedule)
+	case !s.Frequency.IsValid():
+		return fmt.Errorf("%w: unknown frequency %q", ErrInvalidPayoutSchedule, s.Frequency)
+	case !s.Method.IsValid():
+		return fmt.Errorf("%w: unknown method %q", ErrInvalidPayoutSchedule, s.Method)
+	case s.BankAccountToken == "" || s.AccountHolder == "":
+		return fmt.Errorf("%w: bank_account_token and account_holder are required", ErrInvalidPayoutSchedule)
+	case strings.Trim(s.BankAccountToken, "0123456789- ") == "":
+		return fmt.Errorf("%w: bank_account_token must be a tokenized account, not an account number", ErrInvalidPayoutSchedule)
+	case s.AccountLast4 != "" && (len(s.AccountLast4) != 4 || !isDigits(s.AccountLast4)):
+		return fmt.Errorf("%w: account_last4 must be 4 digits", ErrInvalidPayoutSchedule)
+	case s.MinimumAmount < 0:
+		return fmt.Errorf("%w: minimum_amount must not be negative", ErrInvalidPayoutSchedule)
+	}
+	return nil
+}
+
+// PayoutMinimum converts a schedule's minimum payout in major units to minor units
+func PayoutMinimum(amount float64) int64 {
+	return toMinorUnits(amount)
+}
+
+// PayoutStatus is where a payout is on its way to the tenant's bank
+type PayoutStatus string
+
+const (
+	PayoutStatusPending   PayoutStatus = "pending"   // Created; not yet sent
+	PayoutStatusSubmitted PayoutStatus = "submitted" // Accepted by the gateway's payout API
+	PayoutStatusExported  PayoutStatus = "exported"  // Listed in a bank transfer file
+	PayoutStatusFailed    PayoutStatus = "failed"    // Not sent; the balance carries over to the next payout
+)
+
+// IsValid reports whether s is a payout status
+func (s PayoutStatus) IsValid() bool {
+	switch s {
+	case PayoutStatusPending, PayoutStatusSubmitted, PayoutStatusExported, PayoutStatusFailed:
+		return true
+	}
+	return false
+}
+
+// Payout is a tenant's settled revenue, less the processing fees withheld, sent to
+// their bank. Amounts are in minor units like the ledger they are computed from.
+type Payout struct {
+	ID               string       `json:"id"`
+	TenantID         string       `json:"tenant_id"`
+	Method           PayoutMethod `json:"method"`
+	Status           PayoutStatus `json:"status"`
+	Currency         string       `json:"currency"`
+	GrossAmount      int64        `json:"gross_amount"` // Tenant payable balance at PeriodEnd
+	FeeAmount        int64        `json:"fee_amount"`   // Processing fees withheld
+	Amount           int64        `json:"amount"`       // Paid out: GrossAmount - FeeAmount
+	PeriodEnd        time.Time    `json:"period_end"`   // Entries before it are settled into the payout
+	BankAccountToken string       `json:"bank_account_token"`
+	Reference        string       `json:"reference,omitempty"` // Gateway payout ID
+	BankFileKey      string       `json:"bank_file_key,omitempty"`
+	FailureReason    string       `json:"failure_reason,omitempty"`
+	LedgerPosted     bool         `json:"ledger_posted"`
+	CreatedAt        time.Time    `json:"created_at"`
+	UpdatedAt        time.Time    `json:"updated_at"`
+}
+
+// NewPayout creates a pending payout of a tenant's payable balance less its unrecovered fees
+func NewPayout(schedule *PayoutSchedule, gross, fees int64, periodEnd time.Time) *Payout {
+	now := time.Now().UTC()
+	return &Payout{
+		ID:               id.New(),
+		TenantID:         schedule.TenantID,
+		Method:           schedule.Method,
+		Status:           PayoutStatusPending,
+		Currency:         schedule.Currency,
+		GrossAmount:      gross,
+		FeeAmount:        fees,
+		Amount:           gross - fees,
+		PeriodEnd:        periodEnd.UTC(),
+		BankAccountToken: schedule.BankAccountToken,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+}
+
+// MarkSubmitted records the gateway's acceptance of the payout
+func (p *Payout) MarkSubmitted(reference string) {
+	p.Status = PayoutStatusSubmitted
+	p.Reference = reference
+	p.UpdatedAt = time.Now().UTC()
+}
+
+// MarkExported records the bank transfer file the payout is listed in
+func (p *Payout) MarkExported(fileKey string) {
+	p.Status = PayoutStatusExported
+	p.BankFileKey = fileKey
+	p.UpdatedAt = time.Now().UTC()
+}
+
+// MarkFailed records why the payout was not sent
+func (p *Payout) MarkFailed(reason string) {
+	p.Status = PayoutStatusFailed
+	p.FailureReason = reason
+	p.UpdatedAt = time.Now().UTC()
+}
+
+// IsSent reports whether the money has left, so the payout must be in the ledger
+func (p *Payout) IsSent() bool {
+	return p.Status == PayoutStatusSubmitted || p.Status == PayoutStatusExported
+}
+
+// PayoutLedgerKey is the idempotency key of the ledger transaction of a payout of txType
+// (payout or fee_recovery)
+func PayoutLedgerKey(p *Payout, txType LedgerTransactionType) string {
+	return fmt.Sprintf("%s:%s", txType, p.ID)
+}
+
+// PayoutFilter selects payouts
+type PayoutFilter struct {
+	TenantID string
+	Status   PayoutStatus // All statuses when empty
+}
+
+// Matches reports whether a payout is selected by the filter
+func (f *PayoutFilter) Matches(p *Payout) bool {
+	return p.TenantID == f.TenantID && (f.Status == "" || p.Status == f.Status)
+}
@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefundRequestStatus represents where a refund request is on its way to the gateway
+type RefundRequestStatus string
+
+const (
+	// RefundRequestStatusPendingApproval means the refund is above the tenant's threshold
+	// and waits for an admin to approve or reject it
+	RefundRequestStatusPendingApproval RefundRequestStatus = "pending_approval"
+	// RefundRequestStatusApproved means the refund may be sent to the gateway
+	RefundRequestStatusApproved  RefundRequestStatus = "approved"
+	RefundRequestStatusRejected  RefundRequestStatus = "rejected"
+	RefundRequestStatusCompleted RefundRequestStatus = "completed"
+	RefundRequestStatusFailed    RefundRequestStatus = "failed"
+)
+
+// RefundAutoApprover is recorded as the approver of refunds at or below the threshold
+const RefundAutoApprover = "system"
+
+// RefundRequest is a request to refund what is left of a payment. Refunds above the
+// tenant's approval threshold wait for an admin; only approved requests reach the gateway.
+type RefundRequest struct {
+	ID             string              `json:"id"`
+	TenantID       string              `json:"tenant_id"`
+	PaymentID      string              `json:"payment_id"`
+	BookingID      string              `json:"booking_id"`
+	Amount         float64             `json:"amount"` // Refundable amount when requested
+	Currency       string              `json:"currency"`
+	Reason         string              `json:"reason"`
+	Status         RefundRequestStatus `json:"status"`
+	RequestedBy    string              `json:"requested_by,omitempty"`
+	DecidedBy      string              `json:"decided_by,omitempty"`
+	DecisionReason string              `json:"decision_reason,omitempty"`
+	FailureReason  string              `json:"failure_reason,omitempty"`
+	DecidedAt      *time.Time          `json:"decided_at,omitempty"`
+	CompletedAt    *time.Time          `json:"completed_at,omitempty"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+}
+
+// NewRefundRequest creates a request to refund what is left of a payment. Requests that
+// need approval start pending; the others are approved by the system right away.
+func NewRefundRequest(payment *Payment, reason, requestedBy string, needsApproval bool) *RefundRequest {
+	now := time.Now().UTC()
+	r := &RefundRequest{
+		ID:          uuid.New().String(),
+		TenantID:    payment.TenantID,
+		PaymentID:   payment.ID,
+		BookingID:   payment.BookingID,
+		Amount:      payment.RefundableAmount(),
+		Currency:    payment.Currency,
+		Reason:      reason,
+		Status:      RefundRequestStatusPendingApproval,
+		RequestedBy: requestedBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if !needsApproval {
+		r.Status = RefundRequestStatusApproved
+		r.DecidedBy = RefundAutoApprover
+		r.DecidedAt = &now
+	}
+	return r
+}
+
+// IsOpen returns true while the request may still reach the gateway
+func (r *RefundRequest) IsOpen() bool {
+	return r.Status == RefundRequestStatusPendingApproval || r.Status == RefundRequestStatusApproved
+}
+
+// Approve lets a pending refund go to the gateway
+func (r *RefundRequest) Approve(decidedBy, reason string) error {
+	return r.decide(RefundRequestStatusApproved, decidedBy, reason)
+}
+
+// Reject closes a pending refund without refunding the payment; a reason is required
+// so the customer can be told why
+func (r *RefundRequest) Reject(decidedBy, reason string) error {
+	if strings.TrimSpace(reason) == "" {
+		return ErrRefundRejectReasonRequired
+	}
+	return r.decide(RefundRequestStatusRejected, decidedBy, reason)
+}
+
+// decide records an admin's decision on a pending refund
+func (r *RefundRequest) decide(status RefundRequestStatus, decidedBy, reason string) error {
+	if r.Status != RefundRequestStatusPendingApproval {
+		return ErrRefundRequestNotPending
+	}
+	now := time.Now().UTC()
+	r.Status = status
+	r.DecidedBy = decidedBy
+	r.DecisionReason = reason
+	r.DecidedAt = &now
+	r.UpdatedAt = now
+	return nil
+}
+
+// Complete marks an approved refund as made at the gateway
+func (r *RefundRequest) Complete() error {
+	if r.Status != RefundRequestStatusApproved {
+		return ErrRefundRequestNotApproved
+	}
+	now := time.Now().UTC()
+	r.Status = RefundRequestStatusCompleted
+	r.CompletedAt = &now
+	r.UpdatedAt = now
+	return nil
+}
+
+// Fail marks an approved refund the gateway could not make. The payment can be
+// requested again.
+func (r *RefundRequest) Fail(reason string) error {
+	if r.Status != RefundRequestStatusApproved {
+		return ErrRefundRequestNotApproved
+	}
+	r.Status = RefundRequestStatusFailed
+	r.FailureReason = reason
+	r.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// RefundRequestFilter selects refund requests in listings
+type RefundRequestFilter struct {
+	TenantID  string              // All tenants when empty
+	PaymentID string              // All payments when empty
+	Status    RefundRequestStatus // All statuses when empty
+}
+
+// Matches returns true if the request is selected by the filter
+func (f *RefundRequestFilter) Matches(r *RefundRequest) bool {
+	return (f.TenantID == "" || r.TenantID == f.TenantID) &&
+		(f.PaymentID == "" || r.PaymentID == f.PaymentID) &&
+		(f.Status == "" || r.Status == f.Status)
+}
+
+// RefundApprovalPolicy is a tenant's refund approval threshold. Refunds above it wait
+// for an admin; a threshold of zero sends every refund straight to the gateway.
+type RefundApprovalPolicy struct {
+	TenantID  string    `json:"tenant_id"`
+	Threshold float64   `json:"threshold"`
+	UpdatedBy string    `json:"updated_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NeedsApproval returns true if a refund of amount must wait for an admin
+func (p *RefundApprovalPolicy) NeedsApproval(amount float64) bool {
+	return p.Threshold > 0 && toMinorUnits(amount) > toMinorUnits(p.Threshold)
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefundReviewStatus represents whether a flagged refund still needs attention
+type RefundReviewStatus string
+
+const (
+	RefundReviewStatusOpen     RefundReviewStatus = "open"
+	RefundReviewStatusResolved RefundReviewStatus = "resolved"
+)
+
+// RefundReviewReason explains why a gateway refund could not be reconciled automatically
+type RefundReviewReason string
+
+const (
+	// RefundReviewReasonUnmatched means no payment matches the refunded charge
+	RefundReviewReasonUnmatched RefundReviewReason = "unmatched_payment"
+	// RefundReviewReasonExceedsAmount means the gateway refunded more than the payment's refundable amount
+	RefundReviewReasonExceedsAmount RefundReviewReason = "exceeds_refundable_amount"
+	// RefundReviewReasonPaymentStatus means the matched payment is in a status that can't be refunded
+	RefundReviewReasonPaymentStatus RefundReviewReason = "invalid_payment_status"
+)
+
+// RefundReview is a refund made at the gateway, e.g. from the Stripe dashboard, that
+// could not be applied to a payment and is kept for finance to resolve by hand
+type RefundReview struct {
+	ID               string             `json:"id"`
+	GatewayChargeID  string             `json:"gateway_charge_id"`
+	GatewayPaymentID string             `json:"gateway_payment_id,omitempty"`
+	PaymentID        string             `json:"payment_id,omitempty"` // Set when a payment matched
+	AmountRefunded   float64            `json:"amount_refunded"`      // Total the gateway has refunded on the charge
+	Currency         string             `json:"currency"`
+	Reason           RefundReviewReason `json:"reason"`
+	Detail           string             `json:"detail,omitempty"`
+	Status           RefundReviewStatus `json:"status"`
+	ResolvedBy       string             `json:"resolved_by,omitempty"`
+	ResolutionNote   string             `json:"resolution_note,omitempty"`
+	ResolvedAt       *time.Time         `json:"resolved_at,omitempty"`
+	CreatedAt        time.Time          `json:"created_at"`
+	UpdatedAt        time.Time          `json:"updated_at"`
+}
+
+// NewRefundReview creates an open review of a refunded gateway charge
+func NewRefundReview(chargeID, gatewayPaymentID, paymentID string, amountRefunded float64, currency string, reason RefundReviewReason, detail string) *RefundReview {
+	now := time.Now().UTC()
+	return &RefundReview{
+		ID:               uuid.New().String(),
+		GatewayChargeID:  chargeID,
+		GatewayPaymentID: gatewayPaymentID,
+		PaymentID:        paymentID,
+		AmountRefunded:   amountRefunded,
+		Currency:         currency,
+		Reason:           reason,
+		Detail:           detail,
+		Status:           RefundReviewStatusOpen,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+}
+
+// Resolve closes the review once the refund has been handled by hand
+func (r *RefundReview) Resolve(resolvedBy, note string) error {
+	if r.Status == RefundReviewStatusResolved {
+		return ErrRefundReviewResolved
+	}
+	now := time.Now().UTC()
+	r.Status = RefundReviewStatusResolved
+	r.ResolvedBy = resolvedBy
+	r.ResolutionNote = note
+	r.ResolvedAt = &now
+	r.UpdatedAt = now
+	return nil
+}
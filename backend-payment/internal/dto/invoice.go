@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+)
+
+// IssueInvoiceRequest represents a request to issue the tax invoice of a payment
+type IssueInvoiceRequest struct {
+	// Buyer details, required unless they were given when the payment was created
+	TaxInvoice *domain.TaxInfo `json:"tax_invoice,omitempty"`
+}
+
+// InvoiceResponse represents a tax invoice response
+type InvoiceResponse struct {
+	ID        string         `json:"id"`
+	Number    string         `json:"number"`
+	TenantID  string         `json:"tenant_id"`
+	PaymentID string         `json:"payment_id"`
+	BookingID string         `json:"booking_id"`
+	Buyer     domain.TaxInfo `json:"buyer"`
+	Currency  string         `json:"currency"`
+	Subtotal  float64        `json:"subtotal"`
+	VATRate   float64        `json:"vat_rate"`
+	VATAmount float64        `json:"vat_amount"`
+	Total     float64        `json:"total"`
+	IssuedAt  time.Time      `json:"issued_at"`
+}
+
+// FromInvoice converts a domain Invoice to InvoiceResponse
+func FromInvoice(i *domain.Invoice) *InvoiceResponse {
+	return &InvoiceResponse{
+		ID:        i.ID,
+		Number:    i.Number,
+		TenantID:  i.TenantID,
+		PaymentID: i.PaymentID,
+		BookingID: i.BookingID,
+		Buyer:     i.Buyer,
+		Currency:  i.Currency,
+		Subtotal:  i.Subtotal,
+		VATRate:   i.VATRate,
+		VATAmount: i.VATAmount,
+		Total:     i.Total,
+		IssuedAt:  i.IssuedAt,
+	}
+}
+
+// InvoiceExportResponse represents a monthly e-Tax export kept in file storage
+type InvoiceExportResponse struct {
+	TenantID    string    `json:"tenant_id"`
+	Month       string    `json:"month"`
+	Count       int       `json:"count"`
+	DownloadURL string    `json:"download_url"`
+	ExpiresAt   time.Time `json:"expires_at"` // When the download URL stops working
+}
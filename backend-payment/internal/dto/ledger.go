@@ -0,0 +1,130 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
+)
+
+// PostLedgerTransactionRequest represents a gateway fee or payout to record, e.g. from a
+// settlement file
+type PostLedgerTransactionRequest struct {
+	TenantID       string     `json:"tenant_id" binding:"required"`
+	Type           string     `json:"type" binding:"required,oneof=fee payout"`
+	Amount         float64    `json:"amount" binding:"required,gt=0"`
+	Currency       string     `json:"currency"`
+	PaymentID      string     `json:"payment_id,omitempty"` // Payment a fee was charged on
+	Reference      string     `json:"reference"`            // Gateway ID of the fee or payout
+	IdempotencyKey string     `json:"idempotency_key,omitempty"`
+	Description    string     `json:"description,omitempty"`
+	OccurredAt     *time.Time `json:"occurred_at,omitempty"`
+}
+
+// LedgerEntryResponse represents one side of a ledger transaction
+type LedgerEntryResponse struct {
+	ID            string    `json:"id"`
+	TransactionID string    `json:"transaction_id"`
+	Type          string    `json:"type"`
+	Account       string    `json:"account"`
+	Direction     string    `json:"direction"`
+	Amount        float64   `json:"amount"`
+	Currency      string    `json:"currency"`
+	PaymentID     string    `json:"payment_id,omitempty"`
+	Reference     string    `json:"reference,omitempty"`
+	OccurredAt    time.Time `json:"occurred_at"`
+}
+
+// FromLedgerEntry converts a domain LedgerEntry to LedgerEntryResponse
+func FromLedgerEntry(e *domain.LedgerEntry) *LedgerEntryResponse {
+	return &LedgerEntryResponse{
+		ID:            e.ID,
+		TransactionID: e.TransactionID,
+		Type:          string(e.Type),
+		Account:       string(e.Account),
+		Direction:     string(e.Direction),
+		Amount:        domain.MinorToMajor(e.Amount),
+		Currency:      e.Currency,
+		PaymentID:     e.PaymentID,
+		Reference:     e.Reference,
+		OccurredAt:    e.OccurredAt,
+	}
+}
+
+// LedgerEntryListResponse represents a page of ledger entries
+type LedgerEntryListResponse struct {
+	Entries []*LedgerEntryResponse `json:"entries"`
+	Total   int                    `json:"total"` // Number of entries on this page
+	pagination.Info
+}
+
+// LedgerTransactionResponse represents a ledger transaction and its entries
+type LedgerTransactionResponse struct {
+	ID             string                 `json:"id"`
+	TenantID       string                 `json:"tenant_id"`
+	Type           string                 `json:"type"`
+	PaymentID      string                 `json:"payment_id,omitempty"`
+	Reference      string                 `json:"reference,omitempty"`
+	IdempotencyKey string                 `json:"idempotency_key"`
+	Currency       string                 `json:"currency"`
+	Description    string                 `json:"description,omitempty"`
+	Entries        []*LedgerEntryResponse `json:"entries"`
+	OccurredAt     time.Time              `json:"occurred_at"`
+	CreatedAt      time.Time              `json:"created_at"`
+}
+
+// FromLedgerTransaction converts a domain LedgerTransaction to LedgerTransactionResponse
+func FromLedgerTransaction(t *domain.LedgerTransaction) *LedgerTransactionResponse {
+	entries := make([]*LedgerEntryResponse, len(t.Entries))
+	for i, entry := range t.Entries {
+		entries[i] = FromLedgerEntry(entry)
+	}
+	return &LedgerTransactionResponse{
+		ID:             t.ID,
+		TenantID:       t.TenantID,
+		Type:           string(t.Type),
+		PaymentID:      t.PaymentID,
+		Reference:      t.Reference,
+		IdempotencyKey: t.IdempotencyKey,
+		Currency:       t.Currency,
+		Description:    t.Description,
+		Entries:        entries,
+		OccurredAt:     t.OccurredAt,
+		CreatedAt:      t.CreatedAt,
+	}
+}
+
+// LedgerBalanceResponse represents the balance of a tenant's account in one currency
+type LedgerBalanceResponse struct {
+	Account  string  `json:"account"`
+	Currency string  `json:"currency"`
+	Debits   float64 `json:"debits"`
+	Credits  float64 `json:"credits"`
+	Balance  float64 `json:"balance"` // On the account's normal side
+}
+
+// LedgerBalancesResponse represents a tenant's account balances at a point in time
+type LedgerBalancesResponse struct {
+	TenantID string                   `json:"tenant_id"`
+	AsOf     time.Time                `json:"as_of"`
+	Balances []*LedgerBalanceResponse `json:"balances"`
+}
+
+// FromLedgerBalances converts domain LedgerBalances to LedgerBalancesResponse
+func FromLedgerBalances(tenantID string, asOf time.Time, balances []*domain.LedgerBalance) *LedgerBalancesResponse {
+	responses := make([]*LedgerBalanceResponse, len(balances))
+	for i, b := range balances {
+		responses[i] = &LedgerBalanceResponse{
+			Account:  string(b.Account),
+			Currency: b.Currency,
+			Debits:   domain.MinorToMajor(b.Debits),
+			Credits:  domain.MinorToMajor(b.Credits),
+			Balance:  domain.MinorToMajor(b.Balance()),
+		}
+	}
+	return &LedgerBalancesResponse{
+		TenantID: tenantID,
+		AsOf:     asOf,
+		Balances: responses,
+	}
+}
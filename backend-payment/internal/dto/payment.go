@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 )
 
 // CreatePaymentRequest represents a request to create a payment
@@ -13,6 +14,11 @@ type CreatePaymentRequest struct {
 	Currency  string               `json:"currency" binding:"required"`
 	Method    domain.PaymentMethod `json:"method" binding:"required"`
 	Metadata  map[string]string    `json:"metadata,omitempty"`
+	// Buyer details for a full tax invoice, when the customer asks for one
+	TaxInvoice *domain.TaxInfo `json:"tax_invoice,omitempty"`
+	// Event of the booking, used for its payment method policy when booking totals
+	// are not checked; otherwise the booking's own event applies
+	EventID string `json:"event_id,omitempty"`
 }
 
 // ProcessPaymentRequest represents a request to process a payment
@@ -31,26 +37,36 @@ type RefundPaymentRequest struct {
 	Reason    string  `json:"reason,omitempty"`
 }
 
+// AdjustPaymentRequest represents a price difference to settle after a booking change
+type AdjustPaymentRequest struct {
+	AdjustmentID string  `json:"adjustment_id" binding:"required"`
+	Amount       float64 `json:"amount" binding:"required"` // Positive charges, negative refunds
+	Reason       string  `json:"reason,omitempty"`
+}
+
 // PaymentResponse represents a payment response
 type PaymentResponse struct {
-	ID                string               `json:"id"`
-	TenantID          string               `json:"tenant_id"`
-	BookingID         string               `json:"booking_id"`
-	UserID            string               `json:"user_id"`
-	Amount            float64              `json:"amount"`
-	Currency          string               `json:"currency"`
-	Status            domain.PaymentStatus `json:"status"`
-	Method            domain.PaymentMethod `json:"method,omitempty"`
-	Gateway           string               `json:"gateway,omitempty"`
-	GatewayPaymentID  string               `json:"gateway_payment_id,omitempty"`
-	CardLastFour      string               `json:"card_last_four,omitempty"`
-	CardBrand         string               `json:"card_brand,omitempty"`
-	ErrorCode         string               `json:"error_code,omitempty"`
-	ErrorMessage      string               `json:"error_message,omitempty"`
-	Metadata          map[string]string    `json:"metadata,omitempty"`
-	CreatedAt         time.Time            `json:"created_at"`
-	UpdatedAt         time.Time            `json:"updated_at"`
-	ProcessedAt       *time.Time           `json:"processed_at,omitempty"`
+	ID               string               `json:"id"`
+	TenantID         string               `json:"tenant_id"`
+	BookingID        string               `json:"booking_id"`
+	UserID           string               `json:"user_id"`
+	Amount           float64              `json:"amount"`
+	Currency         string               `json:"currency"`
+	Status           domain.PaymentStatus `json:"status"`
+	Method           domain.PaymentMethod `json:"method,omitempty"`
+	Gateway          string               `json:"gateway,omitempty"`
+	GatewayPaymentID string               `json:"gateway_payment_id,omitempty"`
+	CardLastFour     string               `json:"card_last_four,omitempty"`
+	CardBrand        string               `json:"card_brand,omitempty"`
+	ErrorCode        string               `json:"error_code,omitempty"`
+	ErrorMessage     string               `json:"error_message,omitempty"`
+	RefundAmount     *float64             `json:"refund_amount,omitempty"`
+	RedirectURL      string               `json:"redirect_url,omitempty"` // Where the customer finishes a payment requiring action
+	Metadata         map[string]string    `json:"metadata,omitempty"`
+	TaxInfo          *domain.TaxInfo      `json:"tax_info,omitempty"`
+	CreatedAt        time.Time            `json:"created_at"`
+	UpdatedAt        time.Time            `json:"updated_at"`
+	ProcessedAt      *time.Time           `json:"processed_at,omitempty"`
 }
 
 // FromPayment converts a domain Payment to PaymentResponse
@@ -70,7 +86,10 @@ func FromPayment(p *domain.Payment) *PaymentResponse {
 		CardBrand:        p.CardBrand,
 		ErrorCode:        p.ErrorCode,
 		ErrorMessage:     p.ErrorMessage,
+		RefundAmount:     p.RefundAmount,
+		RedirectURL:      p.RedirectURL(),
 		Metadata:         p.Metadata,
+		TaxInfo:          p.TaxInfo,
 		CreatedAt:        p.CreatedAt,
 		UpdatedAt:        p.UpdatedAt,
 		ProcessedAt:      p.ProcessedAt,
@@ -80,7 +99,8 @@ func FromPayment(p *domain.Payment) *PaymentResponse {
 // PaymentListResponse represents a list of payments
 type PaymentListResponse struct {
 	Payments []*PaymentResponse `json:"payments"`
-	Total    int                `json:"total"`
+	Total    int                `json:"total"` // Number of payments on this page
+	pagination.Info
 }
 
 // PaymentIntentMetadata contains enriched booking data for notifications
@@ -105,6 +125,7 @@ type CreatePaymentIntentRequest struct {
 	Amount    float64                `json:"amount" binding:"required,gt=0"`
 	Currency  string                 `json:"currency"`
 	Metadata  *PaymentIntentMetadata `json:"metadata,omitempty"`
+	EventID   string                 `json:"event_id,omitempty"` // See CreatePaymentRequest.EventID
 }
 
 // PaymentIntentResponse represents a Stripe PaymentIntent response
@@ -148,4 +169,7 @@ type PaymentMethodResponse struct {
 type PaymentMethodsListResponse struct {
 	PaymentMethods []*PaymentMethodResponse `json:"payment_methods"`
 	Total          int                      `json:"total"`
+	// Payment methods accepted for the tenant or the event given by ?event_id=,
+	// so clients render only these options
+	AllowedMethods []domain.PaymentMethod `json:"allowed_methods"`
 }
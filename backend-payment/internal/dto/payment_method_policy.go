@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+)
+
+// SetPaymentMethodPolicyRequest represents the payment methods accepted for a tenant or event
+type SetPaymentMethodPolicyRequest struct {
+	AllowedMethods []domain.PaymentMethod `json:"allowed_methods" binding:"required,min=1"`
+	UpdatedBy      string                 `json:"updated_by"`
+}
+
+// PaymentMethodPolicyResponse represents the payment methods accepted for a tenant or event
+type PaymentMethodPolicyResponse struct {
+	TenantID       string                 `json:"tenant_id"`
+	EventID        string                 `json:"event_id,omitempty"` // Empty for the tenant default
+	AllowedMethods []domain.PaymentMethod `json:"allowed_methods"`
+	IsDefault      bool                   `json:"is_default"` // No policy is set; every method is accepted
+	UpdatedBy      string                 `json:"updated_by,omitempty"`
+	UpdatedAt      *time.Time             `json:"updated_at,omitempty"`
+}
+
+// FromPaymentMethodPolicy converts a domain PaymentMethodPolicy to PaymentMethodPolicyResponse
+func FromPaymentMethodPolicy(p *domain.PaymentMethodPolicy) *PaymentMethodPolicyResponse {
+	resp := &PaymentMethodPolicyResponse{
+		TenantID:       p.TenantID,
+		EventID:        p.EventID,
+		AllowedMethods: p.AllowedMethods,
+		IsDefault:      p.IsDefault(),
+		UpdatedBy:      p.UpdatedBy,
+	}
+	if !p.IsDefault() {
+		resp.UpdatedAt = &p.UpdatedAt
+	}
+	return resp
+}
+
+// FromPaymentMethodPolicies converts domain PaymentMethodPolicies to responses
+func FromPaymentMethodPolicies(policies []*domain.PaymentMethodPolicy) []*PaymentMethodPolicyResponse {
+	resp := make([]*PaymentMethodPolicyResponse, len(policies))
+	for i, p := range policies {
+		resp[i] = FromPaymentMethodPolicy(p)
+	}
+	return resp
+}
@@ -0,0 +1,105 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
+)
+
+// SetPayoutScheduleRequest represents a tenant's payout settings. The bank account is
+// given as the token the gateway or bank vault issued for it, never its number.
+type SetPayoutScheduleRequest struct {
+	Frequency        string  `json:"frequency" binding:"required,oneof=daily weekly monthly"`
+	Method           string  `json:"method" binding:"required,oneof=gateway bank_file"`
+	Currency         string  `json:"currency"`
+	BankAccountToken string  `json:"bank_account_token" binding:"required"`
+	BankCode         string  `json:"bank_code"`
+	AccountHolder    string  `json:"account_holder" binding:"required"`
+	AccountLast4     string  `json:"account_last4"`
+	MinimumAmount    float64 `json:"minimum_amount" binding:"gte=0"`
+	Enabled          *bool   `json:"enabled"` // Defaults to true
+}
+
+// PayoutScheduleResponse represents a tenant's payout schedule
+type PayoutScheduleResponse struct {
+	TenantID         string    `json:"tenant_id"`
+	Frequency        string    `json:"frequency"`
+	Method           string    `json:"method"`
+	Currency         string    `json:"currency"`
+	BankAccountToken string    `json:"bank_account_token"`
+	BankCode         string    `json:"bank_code,omitempty"`
+	AccountHolder    string    `json:"account_holder"`
+	AccountLast4     string    `json:"account_last4,omitempty"`
+	MinimumAmount    float64   `json:"minimum_amount"`
+	Enabled          bool      `json:"enabled"`
+	NextPayoutAt     time.Time `json:"next_payout_at"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// FromPayoutSchedule converts a domain PayoutSchedule to PayoutScheduleResponse
+func FromPayoutSchedule(s *domain.PayoutSchedule) *PayoutScheduleResponse {
+	return &PayoutScheduleResponse{
+		TenantID:         s.TenantID,
+		Frequency:        string(s.Frequency),
+		Method:           string(s.Method),
+		Currency:         s.Currency,
+		BankAccountToken: s.BankAccountToken,
+		BankCode:         s.BankCode,
+		AccountHolder:    s.AccountHolder,
+		AccountLast4:     s.AccountLast4,
+		MinimumAmount:    domain.MinorToMajor(s.MinimumAmount),
+		Enabled:          s.Enabled,
+		NextPayoutAt:     s.NextPayoutAt,
+		CreatedAt:        s.CreatedAt,
+		UpdatedAt:        s.UpdatedAt,
+	}
+}
+
+// PayoutResponse represents a payout to a tenant's bank
+type PayoutResponse struct {
+	ID            string    `json:"id"`
+	TenantID      string    `json:"tenant_id"`
+	Method        string    `json:"method"`
+	Status        string    `json:"status"`
+	Currency      string    `json:"currency"`
+	GrossAmount   float64   `json:"gross_amount"`
+	FeeAmount     float64   `json:"fee_amount"`
+	Amount        float64   `json:"amount"`
+	PeriodEnd     time.Time `json:"period_end"`
+	Reference     string    `json:"reference,omitempty"`
+	BankFileKey   string    `json:"bank_file_key,omitempty"`
+	FailureReason string    `json:"failure_reason,omitempty"`
+	LedgerPosted  bool      `json:"ledger_posted"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// FromPayout converts a domain Payout to PayoutResponse
+func FromPayout(p *domain.Payout) *PayoutResponse {
+	return &PayoutResponse{
+		ID:            p.ID,
+		TenantID:      p.TenantID,
+		Method:        string(p.Method),
+		Status:        string(p.Status),
+		Currency:      p.Currency,
+		GrossAmount:   domain.MinorToMajor(p.GrossAmount),
+		FeeAmount:     domain.MinorToMajor(p.FeeAmount),
+		Amount:        domain.MinorToMajor(p.Amount),
+		PeriodEnd:     p.PeriodEnd,
+		Reference:     p.Reference,
+		BankFileKey:   p.BankFileKey,
+		FailureReason: p.FailureReason,
+		LedgerPosted:  p.LedgerPosted,
+		CreatedAt:     p.CreatedAt,
+		UpdatedAt:     p.UpdatedAt,
+	}
+}
+
+// PayoutListResponse represents a page of a tenant's payout history
+type PayoutListResponse struct {
+	Payouts []*PayoutResponse `json:"payouts"`
+	Total   int               `json:"total"` // Number of payouts on this page
+	pagination.Info
+}
@@ -0,0 +1,84 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+)
+
+// DecideRefundRequest represents an admin's approval or rejection of a pending refund.
+// A reason is required to reject; the decision is recorded under the caller's user ID.
+type DecideRefundRequest struct {
+	Reason string `json:"reason"`
+}
+
+// SetRefundApprovalPolicyRequest represents a tenant's refund approval threshold.
+// Refunds above it need approval; 0 disables approval for the tenant.
+type SetRefundApprovalPolicyRequest struct {
+	Threshold *float64 `json:"threshold" binding:"required,gte=0"`
+}
+
+// RefundRequestResponse represents a refund request
+type RefundRequestResponse struct {
+	ID             string     `json:"id"`
+	TenantID       string     `json:"tenant_id"`
+	PaymentID      string     `json:"payment_id"`
+	BookingID      string     `json:"booking_id"`
+	Amount         float64    `json:"amount"`
+	Currency       string     `json:"currency"`
+	Reason         string     `json:"reason"`
+	Status         string     `json:"status"`
+	RequestedBy    string     `json:"requested_by,omitempty"`
+	DecidedBy      string     `json:"decided_by,omitempty"`
+	DecisionReason string     `json:"decision_reason,omitempty"`
+	FailureReason  string     `json:"failure_reason,omitempty"`
+	DecidedAt      *time.Time `json:"decided_at,omitempty"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// FromRefundRequest converts a domain RefundRequest to RefundRequestResponse
+func FromRefundRequest(r *domain.RefundRequest) *RefundRequestResponse {
+	return &RefundRequestResponse{
+		ID:             r.ID,
+		TenantID:       r.TenantID,
+		PaymentID:      r.PaymentID,
+		BookingID:      r.BookingID,
+		Amount:         r.Amount,
+		Currency:       r.Currency,
+		Reason:         r.Reason,
+		Status:         string(r.Status),
+		RequestedBy:    r.RequestedBy,
+		DecidedBy:      r.DecidedBy,
+		DecisionReason: r.DecisionReason,
+		FailureReason:  r.FailureReason,
+		DecidedAt:      r.DecidedAt,
+		CompletedAt:    r.CompletedAt,
+		CreatedAt:      r.CreatedAt,
+		UpdatedAt:      r.UpdatedAt,
+	}
+}
+
+// RefundApprovalPolicyResponse represents a tenant's refund approval threshold
+type RefundApprovalPolicyResponse struct {
+	TenantID  string     `json:"tenant_id"`
+	Threshold float64    `json:"threshold"`
+	IsDefault bool       `json:"is_default"` // The tenant has no policy of its own
+	UpdatedBy string     `json:"updated_by,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// FromRefundApprovalPolicy converts a domain RefundApprovalPolicy to RefundApprovalPolicyResponse
+func FromRefundApprovalPolicy(p *domain.RefundApprovalPolicy) *RefundApprovalPolicyResponse {
+	resp := &RefundApprovalPolicyResponse{
+		TenantID:  p.TenantID,
+		Threshold: p.Threshold,
+		IsDefault: p.UpdatedAt.IsZero(),
+		UpdatedBy: p.UpdatedBy,
+	}
+	if !p.UpdatedAt.IsZero() {
+		resp.UpdatedAt = &p.UpdatedAt
+	}
+	return resp
+}
@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+)
+
+// ResolveRefundReviewRequest represents a request to close a flagged refund
+type ResolveRefundReviewRequest struct {
+	ResolvedBy string `json:"resolved_by" binding:"required"`
+	Note       string `json:"note"`
+}
+
+// RefundReviewResponse represents a gateway refund flagged for manual review
+type RefundReviewResponse struct {
+	ID               string     `json:"id"`
+	GatewayChargeID  string     `json:"gateway_charge_id"`
+	GatewayPaymentID string     `json:"gateway_payment_id,omitempty"`
+	PaymentID        string     `json:"payment_id,omitempty"`
+	AmountRefunded   float64    `json:"amount_refunded"`
+	Currency         string     `json:"currency"`
+	Reason           string     `json:"reason"`
+	Detail           string     `json:"detail,omitempty"`
+	Status           string     `json:"status"`
+	ResolvedBy       string     `json:"resolved_by,omitempty"`
+	ResolutionNote   string     `json:"resolution_note,omitempty"`
+	ResolvedAt       *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// FromRefundReview converts a domain RefundReview to RefundReviewResponse
+func FromRefundReview(r *domain.RefundReview) *RefundReviewResponse {
+	return &RefundReviewResponse{
+		ID:               r.ID,
+		GatewayChargeID:  r.GatewayChargeID,
+		GatewayPaymentID: r.GatewayPaymentID,
+		PaymentID:        r.PaymentID,
+		AmountRefunded:   r.AmountRefunded,
+		Currency:         r.Currency,
+		Reason:           string(r.Reason),
+		Detail:           r.Detail,
+		Status:           string(r.Status),
+		ResolvedBy:       r.ResolvedBy,
+		ResolutionNote:   r.ResolutionNote,
+		ResolvedAt:       r.ResolvedAt,
+		CreatedAt:        r.CreatedAt,
+		UpdatedAt:        r.UpdatedAt,
+	}
+}
@@ -46,5 +46,8 @@ func NewMockGatewayWithConfig(successRate float64, delayMs int) PaymentGateway {
 			"expired_card",
 			"processing_error",
 		},
+		MagicAmounts:   DefaultMagicAmounts(),
+		SlowResponseMs: 5000,
+		TimeoutMs:      30000,
 	})
 }
@@ -2,8 +2,15 @@ package gateway
 
 import (
 	"context"
+	"errors"
 )
 
+// ErrTransactionNotFound is returned when the gateway has no such transaction
+var ErrTransactionNotFound = errors.New("transaction not found")
+
+// ErrGatewayTimeout is returned when a gateway call times out, leaving its outcome unknown
+var ErrGatewayTimeout = errors.New("payment gateway timeout")
+
 // PaymentGateway defines the interface for payment processing
 type PaymentGateway interface {
 	// Charge processes a payment charge
@@ -12,9 +19,20 @@ type PaymentGateway interface {
 	// Refund processes a refund
 	Refund(ctx context.Context, transactionID string, amount float64) error
 
+	// Capture captures a charge made with AuthorizeOnly
+	Capture(ctx context.Context, transactionID string, amount float64) error
+
+	// Void releases a charge made with AuthorizeOnly without capturing it, or abandons
+	// one still waiting for customer action
+	Void(ctx context.Context, transactionID string) error
+
 	// GetTransaction retrieves transaction details
 	GetTransaction(ctx context.Context, transactionID string) (*TransactionInfo, error)
 
+	// FindTransaction finds the latest transaction created for a payment by the
+	// payment_id in its metadata, for charges whose response was lost
+	FindTransaction(ctx context.Context, paymentID string) (*TransactionInfo, error)
+
 	// CreatePaymentIntent creates a Stripe PaymentIntent and returns client_secret
 	CreatePaymentIntent(ctx context.Context, req *PaymentIntentRequest) (*PaymentIntentResponse, error)
 
@@ -30,6 +48,10 @@ type PaymentGateway interface {
 	// ListPaymentMethods lists saved payment methods for a customer
 	ListPaymentMethods(ctx context.Context, customerID string) ([]*PaymentMethodInfo, error)
 
+	// CreatePayout sends funds to a tenant's tokenized bank account. PayoutID is the
+	// idempotency key, so a retried payout is only sent once.
+	CreatePayout(ctx context.Context, req *PayoutRequest) (*PayoutResponse, error)
+
 	// Name returns the gateway name
 	Name() string
 }
@@ -43,6 +65,9 @@ type ChargeRequest struct {
 	Description string
 	Metadata    map[string]string
 
+	// AuthorizeOnly places a hold on the card for Capture or Void instead of charging it
+	AuthorizeOnly bool
+
 	// Card details (for direct card payments)
 	CardToken string
 
@@ -59,6 +84,12 @@ type ChargeResponse struct {
 	FailureReason string
 	FailureCode   string
 	Metadata      map[string]string
+
+	// RequiresAction is set when the customer must finish paying at the gateway, such as
+	// 3-D Secure authentication or a bank transfer
+	RequiresAction bool
+	// RedirectURL is the gateway page the customer finishes paying on, if any
+	RedirectURL string
 }
 
 // TransactionInfo represents transaction details
@@ -135,3 +166,19 @@ type PaymentMethodInfo struct {
 	ExpYear   int64  `json:"exp_year"`   // Expiration year
 	IsDefault bool   `json:"is_default"` // Is default payment method
 }
+
+// PayoutRequest represents a payout to a tenant's bank account
+type PayoutRequest struct {
+	PayoutID    string
+	Amount      float64
+	Currency    string
+	Destination string // Tokenized bank account
+	Description string
+	Metadata    map[string]string
+}
+
+// PayoutResponse represents a payout accepted by the gateway
+type PayoutResponse struct {
+	PayoutID string // Gateway payout ID
+	Status   string
+}
@@ -0,0 +1,365 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/metrics"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// HedgedGatewayConfig holds configuration for the hedged gateway
+type HedgedGatewayConfig struct {
+	// HedgeReads sends a second identical read when the first is slower than usual
+	HedgeReads bool
+
+	// HedgePercentile is the percentile of recent read latencies after which
+	// the second read is sent (0.95 hedges the slowest 5% of reads)
+	HedgePercentile float64
+
+	// HedgeMinDelay and HedgeMaxDelay bound the wait before the second read.
+	// HedgeMaxDelay is also used until LatencyWindow has enough samples.
+	HedgeMinDelay time.Duration
+	HedgeMaxDelay time.Duration
+
+	// LatencyWindow is the number of recent reads of each operation the percentile is taken over
+	LatencyWindow int
+
+	// ReadBudget bounds a read, hedges included (0 = no budget)
+	ReadBudget time.Duration
+
+	// WriteBudget bounds a call that changes state at the gateway (0 = no budget).
+	// A call over budget returns ErrGatewayTimeout with its outcome unknown, so
+	// charges are left processing for the webhook or payment watchdog to confirm.
+	WriteBudget time.Duration
+}
+
+// DefaultHedgedGatewayConfig returns default configuration
+func DefaultHedgedGatewayConfig() *HedgedGatewayConfig {
+	return &HedgedGatewayConfig{
+		HedgeReads:      true,
+		HedgePercentile: 0.95,
+		HedgeMinDelay:   50 * time.Millisecond,
+		HedgeMaxDelay:   time.Second,
+		LatencyWindow:   200,
+		ReadBudget:      3 * time.Second,
+		WriteBudget:     10 * time.Second,
+	}
+}
+
+// minHedgeSamples is the number of samples a latency window needs before its percentile is used
+const minHedgeSamples = 20
+
+// HedgedGateway wraps a PaymentGateway to bound its latency. Idempotent reads
+// are hedged: when a read is slower than the configured percentile of recent
+// reads, an identical one is sent and the first answer wins. Every call is
+// abandoned at its timeout budget with ErrGatewayTimeout.
+type HedgedGateway struct {
+	inner   PaymentGateway
+	config  *HedgedGatewayConfig
+	windows map[string]*latencyWindow // read operation -> recent latencies
+}
+
+// Hedged read operations
+const (
+	opGetTransaction       = "get_transaction"
+	opFindTransaction      = "find_transaction"
+	opConfirmPaymentIntent = "confirm_payment_intent"
+	opListPaymentMethods   = "list_payment_methods"
+)
+
+// NewHedgedGateway creates a new hedged gateway around inner
+func NewHedgedGateway(inner PaymentGateway, config *HedgedGatewayConfig) *HedgedGateway {
+	if config == nil {
+		config = DefaultHedgedGatewayConfig()
+	}
+	if config.HedgePercentile <= 0 || config.HedgePercentile >= 1 {
+		config.HedgePercentile = 0.95
+	}
+	if config.LatencyWindow < minHedgeSamples {
+		config.LatencyWindow = minHedgeSamples
+	}
+	if config.HedgeMaxDelay < config.HedgeMinDelay {
+		config.HedgeMaxDelay = config.HedgeMinDelay
+	}
+
+	windows := make(map[string]*latencyWindow)
+	for _, op := range []string{opGetTransaction, opFindTransaction, opConfirmPaymentIntent, opListPaymentMethods} {
+		windows[op] = &latencyWindow{samples: make([]time.Duration, 0, config.LatencyWindow)}
+	}
+	return &HedgedGateway{inner: inner, config: config, windows: windows}
+}
+
+// Charge processes a payment charge within the write budget
+func (g *HedgedGateway) Charge(ctx context.Context, req *ChargeRequest) (*ChargeResponse, error) {
+	return withBudget(ctx, "charge", g.config.WriteBudget, func(ctx context.Context) (*ChargeResponse, error) {
+		return g.inner.Charge(ctx, req)
+	})
+}
+
+// Refund processes a refund within the write budget
+func (g *HedgedGateway) Refund(ctx context.Context, transactionID string, amount float64) error {
+	_, err := withBudget(ctx, "refund", g.config.WriteBudget, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, g.inner.Refund(ctx, transactionID, amount)
+	})
+	return err
+}
+
+// Capture captures a charge made with AuthorizeOnly within the write budget
+func (g *HedgedGateway) Capture(ctx context.Context, transactionID string, amount float64) error {
+	_, err := withBudget(ctx, "capture", g.config.WriteBudget, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, g.inner.Capture(ctx, transactionID, amount)
+	})
+	return err
+}
+
+// Void releases a charge made with AuthorizeOnly within the write budget
+func (g *HedgedGateway) Void(ctx context.Context, transactionID string) error {
+	_, err := withBudget(ctx, "void", g.config.WriteBudget, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, g.inner.Void(ctx, transactionID)
+	})
+	return err
+}
+
+// GetTransaction retrieves transaction details, hedged
+func (g *HedgedGateway) GetTransaction(ctx context.Context, transactionID string) (*TransactionInfo, error) {
+	return hedge(ctx, g, opGetTransaction, func(ctx context.Context) (*TransactionInfo, error) {
+		return g.inner.GetTransaction(ctx, transactionID)
+	})
+}
+
+// FindTransaction finds the latest transaction created for a payment, hedged
+func (g *HedgedGateway) FindTransaction(ctx context.Context, paymentID string) (*TransactionInfo, error) {
+	return hedge(ctx, g, opFindTransaction, func(ctx context.Context) (*TransactionInfo, error) {
+		return g.inner.FindTransaction(ctx, paymentID)
+	})
+}
+
+// CreatePaymentIntent creates a PaymentIntent within the write budget
+func (g *HedgedGateway) CreatePaymentIntent(ctx context.Context, req *PaymentIntentRequest) (*PaymentIntentResponse, error) {
+	return withBudget(ctx, "create_payment_intent", g.config.WriteBudget, func(ctx context.Context) (*PaymentIntentResponse, error) {
+		return g.inner.CreatePaymentIntent(ctx, req)
+	})
+}
+
+// ConfirmPaymentIntent retrieves the status of a PaymentIntent the client
+// completed, hedged; an intent is confirmed client-side, so this only reads it
+func (g *HedgedGateway) ConfirmPaymentIntent(ctx context.Context, paymentIntentID string) (*PaymentIntentResponse, error) {
+	return hedge(ctx, g, opConfirmPaymentIntent, func(ctx context.Context) (*PaymentIntentResponse, error) {
+		return g.inner.ConfirmPaymentIntent(ctx, paymentIntentID)
+	})
+}
+
+// CreateCustomer creates a customer within the write budget
+func (g *HedgedGateway) CreateCustomer(ctx context.Context, req *CreateCustomerRequest) (*CustomerResponse, error) {
+	return withBudget(ctx, "create_customer", g.config.WriteBudget, func(ctx context.Context) (*CustomerResponse, error) {
+		return g.inner.CreateCustomer(ctx, req)
+	})
+}
+
+// CreatePortalSession creates a Customer Portal session within the write budget
+func (g *HedgedGateway) CreatePortalSession(ctx context.Context, req *PortalSessionRequest) (*PortalSessionResponse, error) {
+	return withBudget(ctx, "create_portal_session", g.config.WriteBudget, func(ctx context.Context) (*PortalSessionResponse, error) {
+		return g.inner.CreatePortalSession(ctx, req)
+	})
+}
+
+// ListPaymentMethods lists saved payment methods for a customer, hedged
+func (g *HedgedGateway) ListPaymentMethods(ctx context.Context, customerID string) ([]*PaymentMethodInfo, error) {
+	return hedge(ctx, g, opListPaymentMethods, func(ctx context.Context) ([]*PaymentMethodInfo, error) {
+		return g.inner.ListPaymentMethods(ctx, customerID)
+	})
+}
+
+// CreatePayout sends a payout within the write budget
+func (g *HedgedGateway) CreatePayout(ctx context.Context, req *PayoutRequest) (*PayoutResponse, error) {
+	return withBudget(ctx, "create_payout", g.config.WriteBudget, func(ctx context.Context) (*PayoutResponse, error) {
+		return g.inner.CreatePayout(ctx, req)
+	})
+}
+
+// Name returns the name of the wrapped gateway
+func (g *HedgedGateway) Name() string {
+	return g.inner.Name()
+}
+
+// hedgeDelay returns how long a read of op waits before it is hedged
+func (g *HedgedGateway) hedgeDelay(op string) time.Duration {
+	delay, ok := g.windows[op].percentile(g.config.HedgePercentile)
+	if !ok {
+		return g.config.HedgeMaxDelay
+	}
+	if delay < g.config.HedgeMinDelay {
+		return g.config.HedgeMinDelay
+	}
+	if delay > g.config.HedgeMaxDelay {
+		return g.config.HedgeMaxDelay
+	}
+	return delay
+}
+
+// attemptResult is the answer of one attempt of a hedged read
+type attemptResult[T any] struct {
+	value T
+	err   error
+	hedge bool
+}
+
+// hedge runs the read call within the read budget, sending a second attempt
+// if the first has not answered after the hedge delay. The first success, or
+// ErrTransactionNotFound, wins and cancels the other attempt; other errors
+// wait for the attempt still running.
+func hedge[T any](ctx context.Context, g *HedgedGateway, op string, call func(context.Context) (T, error)) (T, error) {
+	ctx, span := telemetry.StartSpan(ctx, "gateway.hedged."+op)
+	defer span.End()
+
+	ctx, cancel := budgetContext(ctx, g.config.ReadBudget)
+	defer cancel()
+
+	window := g.windows[op]
+	results := make(chan attemptResult[T], 2)
+	attempt := func(hedge bool) {
+		start := time.Now()
+		value, err := call(ctx)
+		// Attempts cancelled because the other one answered say nothing about latency
+		if !errors.Is(err, context.Canceled) {
+			window.observe(time.Since(start))
+		}
+		results <- attemptResult[T]{value: value, err: err, hedge: hedge}
+	}
+
+	go attempt(false)
+	inflight := 1
+
+	var hedgeC <-chan time.Time
+	if g.config.HedgeReads {
+		delay := g.hedgeDelay(op)
+		span.SetAttributes(attribute.Int64("hedge_delay_ms", delay.Milliseconds()))
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		hedgeC = timer.C
+	}
+
+	hedged := false
+	for {
+		select {
+		case <-hedgeC:
+			hedgeC = nil
+			hedged = true
+			inflight++
+			span.SetAttributes(attribute.Bool("hedged", true))
+			go attempt(true)
+
+		case r := <-results:
+			inflight--
+			if r.err == nil || errors.Is(r.err, ErrTransactionNotFound) || inflight == 0 {
+				if hedged {
+					winner := "primary"
+					if r.hedge {
+						winner = "hedge"
+					}
+					span.SetAttributes(attribute.String("winner", winner))
+					metrics.RecordGatewayHedge(ctx, op, winner)
+				}
+				if errors.Is(r.err, context.DeadlineExceeded) {
+					r.err = budgetError(ctx, op, g.config.ReadBudget)
+				}
+				if r.err != nil {
+					span.RecordError(r.err)
+				}
+				return r.value, r.err
+			}
+
+		case <-ctx.Done():
+			var zero T
+			err := budgetError(ctx, op, g.config.ReadBudget)
+			span.RecordError(err)
+			return zero, err
+		}
+	}
+}
+
+// withBudget runs call, abandoning it with ErrGatewayTimeout once the budget
+// is spent even if the gateway client does not honour the context deadline
+func withBudget[T any](ctx context.Context, op string, budget time.Duration, call func(context.Context) (T, error)) (T, error) {
+	if budget <= 0 {
+		return call(ctx)
+	}
+
+	ctx, cancel := budgetContext(ctx, budget)
+	defer cancel()
+
+	results := make(chan attemptResult[T], 1)
+	go func() {
+		value, err := call(ctx)
+		results <- attemptResult[T]{value: value, err: err}
+	}()
+
+	select {
+	case r := <-results:
+		if errors.Is(r.err, context.DeadlineExceeded) {
+			return r.value, budgetError(ctx, op, budget)
+		}
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero T
+		return zero, budgetError(ctx, op, budget)
+	}
+}
+
+// budgetContext returns ctx bounded by budget, or ctx as is for no budget
+func budgetContext(ctx context.Context, budget time.Duration) (context.Context, context.CancelFunc) {
+	if budget <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, budget)
+}
+
+// budgetError reports why a call was abandoned: a cancelled caller is passed
+// through, a deadline becomes ErrGatewayTimeout
+func budgetError(ctx context.Context, op string, budget time.Duration) error {
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return ctx.Err()
+	}
+	metrics.RecordGatewayBudgetExceeded(ctx, op)
+	return fmt.Errorf("%w: %s exceeded its %s budget", ErrGatewayTimeout, op, budget)
+}
+
+// latencyWindow keeps the latencies of the most recent calls of an operation
+type latencyWindow struct {
+	mu      sync.Mutex
+	samples []time.Duration
+	next    int
+}
+
+// observe adds a call latency, replacing the oldest once the window is full
+func (w *latencyWindow) observe(d time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.samples) < cap(w.samples) {
+		w.samples = append(w.samples, d)
+		return
+	}
+	w.samples[w.next] = d
+	w.next = (w.next + 1) % len(w.samples)
+}
+
+// percentile returns the p-th percentile of the window, or false until it has enough samples
+func (w *latencyWindow) percentile(p float64) (time.Duration, bool) {
+	w.mu.Lock()
+	if len(w.samples) < minHedgeSamples {
+		w.mu.Unlock()
+		return 0, false
+	}
+	sorted := make([]time.Duration, len(w.samples))
+	copy(sorted, w.samples)
+	w.mu.Unlock()
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[int(p*float64(len(sorted)-1))], true
+}
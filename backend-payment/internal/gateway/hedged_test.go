@@ -0,0 +1,197 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// slowGateway answers reads after the delay of each call in turn
+type slowGateway struct {
+	PaymentGateway
+	delays    []time.Duration
+	calls     atomic.Int32
+	cancelled atomic.Int32
+}
+
+func (g *slowGateway) delay() time.Duration {
+	n := int(g.calls.Add(1)) - 1
+	if n < len(g.delays) {
+		return g.delays[n]
+	}
+	return g.delays[len(g.delays)-1]
+}
+
+func (g *slowGateway) GetTransaction(ctx context.Context, transactionID string) (*TransactionInfo, error) {
+	select {
+	case <-ctx.Done():
+		g.cancelled.Add(1)
+		return nil, ctx.Err()
+	case <-time.After(g.delay()):
+		return &TransactionInfo{TransactionID: transactionID, Status: "succeeded"}, nil
+	}
+}
+
+// Charge ignores ctx, like a client without deadline support
+func (g *slowGateway) Charge(ctx context.Context, req *ChargeRequest) (*ChargeResponse, error) {
+	time.Sleep(g.delay())
+	return &ChargeResponse{Success: true, TransactionID: "txn-1"}, nil
+}
+
+func newTestHedgedGateway(inner PaymentGateway) *HedgedGateway {
+	return NewHedgedGateway(inner, &HedgedGatewayConfig{
+		HedgeReads:    true,
+		HedgeMinDelay: 20 * time.Millisecond,
+		HedgeMaxDelay: 20 * time.Millisecond,
+		ReadBudget:    time.Second,
+		WriteBudget:   50 * time.Millisecond,
+	})
+}
+
+func TestHedgedGateway_GetTransaction_HedgeWins(t *testing.T) {
+	inner := &slowGateway{delays: []time.Duration{500 * time.Millisecond, 0}}
+	gw := newTestHedgedGateway(inner)
+
+	start := time.Now()
+	txn, err := gw.GetTransaction(context.Background(), "pi_123")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if txn.TransactionID != "pi_123" {
+		t.Errorf("Expected transaction pi_123, got %s", txn.TransactionID)
+	}
+	if elapsed := time.Since(start); elapsed > 300*time.Millisecond {
+		t.Errorf("Expected the hedge to answer before the slow read, took %s", elapsed)
+	}
+	if calls := inner.calls.Load(); calls != 2 {
+		t.Errorf("Expected 2 calls, got %d", calls)
+	}
+
+	// The slow read is cancelled once the hedge answers
+	deadline := time.Now().Add(time.Second)
+	for inner.cancelled.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if inner.cancelled.Load() != 1 {
+		t.Error("Expected the slow read to be cancelled")
+	}
+}
+
+func TestHedgedGateway_GetTransaction_FastReadNotHedged(t *testing.T) {
+	inner := &slowGateway{delays: []time.Duration{0}}
+	gw := newTestHedgedGateway(inner)
+
+	if _, err := gw.GetTransaction(context.Background(), "pi_123"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if calls := inner.calls.Load(); calls != 1 {
+		t.Errorf("Expected 1 call, got %d", calls)
+	}
+}
+
+func TestHedgedGateway_GetTransaction_ReadBudget(t *testing.T) {
+	inner := &slowGateway{delays: []time.Duration{5 * time.Second}}
+	gw := NewHedgedGateway(inner, &HedgedGatewayConfig{
+		HedgeReads:    true,
+		HedgeMinDelay: 20 * time.Millisecond,
+		HedgeMaxDelay: 20 * time.Millisecond,
+		ReadBudget:    100 * time.Millisecond,
+	})
+
+	start := time.Now()
+	_, err := gw.GetTransaction(context.Background(), "pi_123")
+	if !errors.Is(err, ErrGatewayTimeout) {
+		t.Fatalf("Expected ErrGatewayTimeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the read to stop at its budget, took %s", elapsed)
+	}
+}
+
+func TestHedgedGateway_GetTransaction_CallerCancelled(t *testing.T) {
+	inner := &slowGateway{delays: []time.Duration{5 * time.Second}}
+	gw := newTestHedgedGateway(inner)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(30*time.Millisecond, cancel)
+
+	_, err := gw.GetTransaction(ctx, "pi_123")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if errors.Is(err, ErrGatewayTimeout) {
+		t.Error("Expected a cancelled caller not to be reported as a gateway timeout")
+	}
+}
+
+func TestHedgedGateway_Charge_WriteBudget(t *testing.T) {
+	inner := &slowGateway{delays: []time.Duration{time.Second}}
+	gw := newTestHedgedGateway(inner)
+
+	start := time.Now()
+	_, err := gw.Charge(context.Background(), &ChargeRequest{PaymentID: "pay-123", Amount: 100})
+	if !errors.Is(err, ErrGatewayTimeout) {
+		t.Fatalf("Expected ErrGatewayTimeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected the charge to be abandoned at its budget, took %s", elapsed)
+	}
+	if calls := inner.calls.Load(); calls != 1 {
+		t.Errorf("Expected writes never to be hedged, got %d calls", calls)
+	}
+}
+
+func TestLatencyWindow_Percentile(t *testing.T) {
+	w := &latencyWindow{samples: make([]time.Duration, 0, 100)}
+	for i := 1; i < minHedgeSamples; i++ {
+		w.observe(time.Duration(i) * time.Millisecond)
+	}
+	if _, ok := w.percentile(0.9); ok {
+		t.Error("Expected no percentile before the window has enough samples")
+	}
+
+	for i := minHedgeSamples; i <= 100; i++ {
+		w.observe(time.Duration(i) * time.Millisecond)
+	}
+	if p, _ := w.percentile(0.9); p != 90*time.Millisecond {
+		t.Errorf("Expected p90 of 90ms, got %s", p)
+	}
+
+	// Once full, new samples replace the oldest
+	for i := 0; i < 100; i++ {
+		w.observe(time.Millisecond)
+	}
+	if p, _ := w.percentile(0.9); p != time.Millisecond {
+		t.Errorf("Expected p90 of 1ms after the window rolled over, got %s", p)
+	}
+}
+
+func TestMockGateway_ConfirmPaymentIntent_SettlesOnce(t *testing.T) {
+	var events []*MockWebhookEvent
+	gw := newScenarioGateway(&events)
+	ctx := context.Background()
+
+	intent, err := gw.CreatePaymentIntent(ctx, &PaymentIntentRequest{
+		Amount:   1500,
+		Currency: "THB",
+		Metadata: map[string]string{"payment_id": "pay-123", MetadataSandboxScenario: ScenarioSuccess},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		confirmed, err := gw.ConfirmPaymentIntent(ctx, intent.PaymentIntentID)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if confirmed.Status != "succeeded" {
+			t.Errorf("Expected status 'succeeded', got '%s'", confirmed.Status)
+		}
+	}
+	if len(events) != 1 {
+		t.Errorf("Expected one webhook for a re-confirmed intent, got %d", len(events))
+	}
+}
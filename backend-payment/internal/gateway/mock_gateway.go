@@ -22,11 +22,16 @@ func randomAlphanumeric(length int) string {
 	return string(b)
 }
 
-// MockGateway implements PaymentGateway for testing and load testing
+// MockGateway implements PaymentGateway for testing and load testing.
+// Requests can select a SandboxScenario to exercise failure paths deterministically.
 type MockGateway struct {
-	config       *MockGatewayConfig
-	transactions sync.Map
-	mu           sync.RWMutex
+	config            *MockGatewayConfig
+	transactions      sync.Map
+	scenarios         sync.Map // payment intent ID -> *SandboxScenario
+	payouts           sync.Map // payout ID -> *PayoutResponse
+	authorizeOnAction sync.Map // transaction ID -> true for AuthorizeOnly charges requiring action
+	mu                sync.RWMutex
+	confirmMu         sync.Mutex // settles concurrent confirmations of an intent once
 }
 
 // MockGatewayConfig holds configuration for the mock gateway
@@ -39,6 +44,18 @@ type MockGatewayConfig struct {
 
 	// FailureReasons is a list of possible failure reasons
 	FailureReasons []string
+
+	// MagicAmounts maps amounts in satang to sandbox scenario names
+	MagicAmounts map[int64]string
+
+	// SlowResponseMs is the extra delay of the slow_response scenario
+	SlowResponseMs int
+
+	// TimeoutMs is how long the timeout scenario waits before returning ErrGatewayTimeout
+	TimeoutMs int
+
+	// WebhookHandler receives the webhook of each payment outcome (nil = no webhooks)
+	WebhookHandler func(ctx context.Context, event *MockWebhookEvent)
 }
 
 // DefaultMockGatewayConfig returns default configuration
@@ -53,6 +70,9 @@ func DefaultMockGatewayConfig() *MockGatewayConfig {
 			"processing_error",
 			"fraud_detected",
 		},
+		MagicAmounts:   DefaultMagicAmounts(),
+		SlowResponseMs: 5000,
+		TimeoutMs:      30000,
 	}
 }
 
@@ -82,41 +102,37 @@ func (g *MockGateway) Charge(ctx context.Context, req *ChargeRequest) (*ChargeRe
 	}
 
 	// Simulate processing delay
-	if g.config.DelayMs > 0 {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		case <-time.After(time.Duration(g.config.DelayMs) * time.Millisecond):
-		}
+	scenario := g.resolveScenario(req.Amount, req.Metadata)
+	if err := g.simulateLatency(ctx, scenario); err != nil {
+		return nil, err
 	}
 
 	// Generate transaction ID
 	transactionID := fmt.Sprintf("mock_txn_%s", uuid.New().String()[:8])
 
-	// Determine success or failure
-	success := rand.Float64() < g.config.SuccessRate
-
 	resp := &ChargeResponse{
 		TransactionID: transactionID,
 		Metadata:      req.Metadata,
 	}
 
-	if success {
-		resp.Success = true
-		resp.Status = "completed"
-
-		// Store transaction
-		g.transactions.Store(transactionID, &TransactionInfo{
-			TransactionID: transactionID,
-			Status:        "completed",
-			Amount:        req.Amount,
-			Currency:      req.Currency,
-			Method:        req.Method,
-			CreatedAt:     time.Now().Format(time.RFC3339),
-			Metadata:      req.Metadata,
-		})
-	} else {
-		resp.Success = false
+	switch {
+	case scenario != nil && scenario.RequiresAction:
+		resp.Status = "requires_action"
+		resp.RequiresAction = true
+		resp.RedirectURL = mockRedirectURLPrefix + transactionID
+		resp.FailureReason = "3-D Secure authentication required"
+		resp.FailureCode = "authentication_required"
+		g.storeTransaction(req, resp)
+		if req.AuthorizeOnly {
+			g.authorizeOnAction.Store(transactionID, true)
+		}
+	case scenario != nil && scenario.DeclineCode != "":
+		resp.Status = "failed"
+		resp.FailureReason = scenario.DeclineCode
+		resp.FailureCode = scenario.DeclineCode
+	case scenario != nil || rand.Float64() < g.config.SuccessRate:
+		g.completeCharge(req, resp)
+	default:
 		resp.Status = "failed"
 
 		// Pick a random failure reason
@@ -130,9 +146,52 @@ func (g *MockGateway) Charge(ctx context.Context, req *ChargeRequest) (*ChargeRe
 		}
 	}
 
+	g.deliverWebhook(ctx, scenario, &MockWebhookEvent{
+		Type:          webhookTypeForStatus(resp.Status),
+		PaymentID:     req.PaymentID,
+		TransactionID: transactionID,
+		Amount:        req.Amount,
+		Currency:      req.Currency,
+		FailureCode:   resp.FailureCode,
+		Metadata:      req.Metadata,
+	})
+
 	return resp, nil
 }
 
+// mockRedirectURLPrefix is where the mock gateway sends customers to complete an action
+const mockRedirectURLPrefix = "https://mock-gateway.local/authenticate/"
+
+// completeCharge marks the charge successful and stores the transaction.
+// Authorize-only charges are stored as authorized until captured or voided.
+func (g *MockGateway) completeCharge(req *ChargeRequest, resp *ChargeResponse) {
+	resp.Success = true
+	resp.Status = "completed"
+	if req.AuthorizeOnly {
+		resp.Status = "authorized"
+	}
+	g.storeTransaction(req, resp)
+}
+
+// storeTransaction stores the charge with the response's status
+func (g *MockGateway) storeTransaction(req *ChargeRequest, resp *ChargeResponse) {
+	// Tagged with the payment like Stripe's metadata, so FindTransaction can look it up
+	metadata := map[string]string{"payment_id": req.PaymentID}
+	for k, v := range req.Metadata {
+		metadata[k] = v
+	}
+
+	g.transactions.Store(resp.TransactionID, &TransactionInfo{
+		TransactionID: resp.TransactionID,
+		Status:        resp.Status,
+		Amount:        req.Amount,
+		Currency:      req.Currency,
+		Method:        req.Method,
+		CreatedAt:     time.Now().Format(time.RFC3339),
+		Metadata:      metadata,
+	})
+}
+
 // Refund processes a mock refund
 func (g *MockGateway) Refund(ctx context.Context, transactionID string, amount float64) error {
 	if transactionID == "" {
@@ -162,6 +221,102 @@ func (g *MockGateway) Refund(ctx context.Context, transactionID string, amount f
 	return nil
 }
 
+// Capture captures a mock authorized charge
+func (g *MockGateway) Capture(ctx context.Context, transactionID string, amount float64) error {
+	return g.settleAuthorization(ctx, transactionID, "completed")
+}
+
+// Void releases a mock authorized charge, or cancels one still requiring action
+func (g *MockGateway) Void(ctx context.Context, transactionID string) error {
+	if g.cancelAction(transactionID) {
+		return nil
+	}
+	return g.settleAuthorization(ctx, transactionID, "voided")
+}
+
+// cancelAction cancels a transaction requiring action, like Stripe cancels such an
+// intent. Returns false if the transaction does not require action.
+func (g *MockGateway) cancelAction(transactionID string) bool {
+	txn, ok := g.transactions.Load(transactionID)
+	if !ok {
+		return false
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	info := txn.(*TransactionInfo)
+	if info.Status != "requires_action" {
+		return false
+	}
+	info.Status = "canceled"
+	g.authorizeOnAction.Delete(transactionID)
+	return true
+}
+
+// CompleteAction simulates the customer finishing a charge that required action, such
+// as passing 3-D Secure on the redirect page, and delivers the outcome webhook
+func (g *MockGateway) CompleteAction(ctx context.Context, transactionID string) error {
+	txn, ok := g.transactions.Load(transactionID)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrTransactionNotFound, transactionID)
+	}
+
+	g.mu.Lock()
+	info := txn.(*TransactionInfo)
+	if info.Status != "requires_action" {
+		g.mu.Unlock()
+		return fmt.Errorf("transaction %s is %s, not requires_action", transactionID, info.Status)
+	}
+	info.Status = "completed"
+	if _, ok := g.authorizeOnAction.LoadAndDelete(transactionID); ok {
+		info.Status = "authorized"
+	}
+	event := &MockWebhookEvent{
+		Type:          webhookTypeForStatus(info.Status),
+		PaymentID:     info.Metadata["payment_id"],
+		TransactionID: transactionID,
+		Amount:        info.Amount,
+		Currency:      info.Currency,
+		Metadata:      info.Metadata,
+	}
+	g.mu.Unlock()
+
+	g.deliverWebhook(ctx, nil, event)
+	return nil
+}
+
+// settleAuthorization moves an authorized transaction to its final status
+func (g *MockGateway) settleAuthorization(ctx context.Context, transactionID, status string) error {
+	if transactionID == "" {
+		return fmt.Errorf("transaction ID is required")
+	}
+
+	// Simulate processing delay
+	if g.config.DelayMs > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(g.config.DelayMs) * time.Millisecond):
+		}
+	}
+
+	txn, ok := g.transactions.Load(transactionID)
+	if !ok {
+		return fmt.Errorf("transaction not found: %s", transactionID)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	info := txn.(*TransactionInfo)
+	if info.Status != "authorized" {
+		return fmt.Errorf("transaction %s is %s, not authorized", transactionID, info.Status)
+	}
+	info.Status = status
+	return nil
+}
+
 // GetTransaction retrieves transaction details
 func (g *MockGateway) GetTransaction(ctx context.Context, transactionID string) (*TransactionInfo, error) {
 	if transactionID == "" {
@@ -170,12 +325,48 @@ func (g *MockGateway) GetTransaction(ctx context.Context, transactionID string)
 
 	txn, ok := g.transactions.Load(transactionID)
 	if !ok {
-		return nil, fmt.Errorf("transaction not found: %s", transactionID)
+		return nil, fmt.Errorf("%w: %s", ErrTransactionNotFound, transactionID)
 	}
 
 	return txn.(*TransactionInfo), nil
 }
 
+// FindTransaction finds the latest stored transaction of a payment
+func (g *MockGateway) FindTransaction(ctx context.Context, paymentID string) (*TransactionInfo, error) {
+	if paymentID == "" {
+		return nil, fmt.Errorf("payment ID is required")
+	}
+
+	var latest *TransactionInfo
+	g.transactions.Range(func(_, value any) bool {
+		txn := value.(*TransactionInfo)
+		if txn.Metadata["payment_id"] == paymentID && (latest == nil || txn.CreatedAt > latest.CreatedAt) {
+			latest = txn
+		}
+		return true
+	})
+	if latest == nil {
+		return nil, ErrTransactionNotFound
+	}
+	return latest, nil
+}
+
+// CreatePayout accepts a mock payout; a retried payout returns the one already accepted
+func (g *MockGateway) CreatePayout(ctx context.Context, req *PayoutRequest) (*PayoutResponse, error) {
+	if req == nil || req.PayoutID == "" {
+		return nil, fmt.Errorf("payout request with a payout ID is required")
+	}
+	if req.Amount <= 0 || req.Destination == "" {
+		return nil, fmt.Errorf("payout amount and destination are required")
+	}
+
+	resp, _ := g.payouts.LoadOrStore(req.PayoutID, &PayoutResponse{
+		PayoutID: "po_mock_" + randomAlphanumeric(24),
+		Status:   "pending",
+	})
+	return resp.(*PayoutResponse), nil
+}
+
 // Name returns the gateway name
 func (g *MockGateway) Name() string {
 	return "mock"
@@ -221,6 +412,11 @@ func (g *MockGateway) CreatePaymentIntent(ctx context.Context, req *PaymentInten
 	paymentIntentID := fmt.Sprintf("pi_mock_%s", randomAlphanumeric(24))
 	clientSecret := fmt.Sprintf("%s_secret_%s", paymentIntentID, randomAlphanumeric(24))
 
+	// The scenario applies when the intent is confirmed
+	if scenario := g.resolveScenario(req.Amount, req.Metadata); scenario != nil {
+		g.scenarios.Store(paymentIntentID, scenario)
+	}
+
 	// Store mock payment intent
 	g.transactions.Store(paymentIntentID, &TransactionInfo{
 		TransactionID: paymentIntentID,
@@ -246,38 +442,73 @@ func (g *MockGateway) ConfirmPaymentIntent(ctx context.Context, paymentIntentID
 		return nil, fmt.Errorf("payment intent ID is required")
 	}
 
+	var scenario *SandboxScenario
+	if s, ok := g.scenarios.Load(paymentIntentID); ok {
+		scenario = s.(*SandboxScenario)
+	}
+
 	// Simulate processing delay
-	if g.config.DelayMs > 0 {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		case <-time.After(time.Duration(g.config.DelayMs) * time.Millisecond):
-		}
+	if err := g.simulateLatency(ctx, scenario); err != nil {
+		return nil, err
 	}
 
+	g.confirmMu.Lock()
+
 	// Get the payment intent
 	txn, ok := g.transactions.Load(paymentIntentID)
 	if !ok {
+		g.confirmMu.Unlock()
 		return nil, fmt.Errorf("payment intent not found: %s", paymentIntentID)
 	}
 
 	info := txn.(*TransactionInfo)
 
-	// Determine success or failure
-	success := rand.Float64() < g.config.SuccessRate
+	// A succeeded intent stays succeeded, as it does at Stripe, so confirming
+	// it again (e.g. a hedged read) neither re-rolls it nor resends the webhook
+	if info.Status == "succeeded" {
+		g.confirmMu.Unlock()
+		return &PaymentIntentResponse{
+			PaymentIntentID: paymentIntentID,
+			Status:          info.Status,
+			Amount:          info.Amount,
+			Currency:        info.Currency,
+		}, nil
+	}
 
-	if success {
+	// Determine success or failure
+	failureCode := ""
+	switch {
+	case scenario != nil && scenario.RequiresAction:
+		info.Status = "requires_action"
+		failureCode = "authentication_required"
+	case scenario != nil && scenario.DeclineCode != "":
+		info.Status = "failed"
+		failureCode = scenario.DeclineCode
+	case scenario != nil || rand.Float64() < g.config.SuccessRate:
 		info.Status = "succeeded"
-	} else {
+	default:
 		info.Status = "failed"
+		failureCode = "card_declined"
 	}
 
 	g.transactions.Store(paymentIntentID, info)
+	status := info.Status
+	g.confirmMu.Unlock()
+
+	g.deliverWebhook(ctx, scenario, &MockWebhookEvent{
+		Type:          webhookTypeForStatus(status),
+		PaymentID:     info.Metadata["payment_id"],
+		TransactionID: paymentIntentID,
+		Amount:        info.Amount,
+		Currency:      info.Currency,
+		FailureCode:   failureCode,
+		Metadata:      info.Metadata,
+	})
 
 	return &PaymentIntentResponse{
 		PaymentIntentID: paymentIntentID,
 		ClientSecret:    "",
-		Status:          info.Status,
+		Status:          status,
 		Amount:          info.Amount,
 		Currency:        info.Currency,
 	}, nil
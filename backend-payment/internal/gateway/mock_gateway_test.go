@@ -2,6 +2,7 @@ package gateway
 
 import (
 	"context"
+	"errors"
 	"testing"
 )
 
@@ -132,6 +133,71 @@ func TestMockGateway_Refund_NotFound(t *testing.T) {
 	}
 }
 
+func TestMockGateway_AuthorizeCapture(t *testing.T) {
+	gw := NewMockGateway(&MockGatewayConfig{
+		SuccessRate: 1.0,
+		DelayMs:     0,
+	})
+
+	ctx := context.Background()
+	resp, err := gw.Charge(ctx, &ChargeRequest{
+		PaymentID:     "pay-123",
+		Amount:        1000.00,
+		Currency:      "THB",
+		Method:        "credit_card",
+		AuthorizeOnly: true,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !resp.Success || resp.Status != "authorized" {
+		t.Fatalf("Expected successful authorization, got success=%v status=%s", resp.Success, resp.Status)
+	}
+
+	if err := gw.Capture(ctx, resp.TransactionID, 1000.00); err != nil {
+		t.Fatalf("Unexpected error capturing: %v", err)
+	}
+	txn, _ := gw.GetTransaction(ctx, resp.TransactionID)
+	if txn.Status != "completed" {
+		t.Errorf("Expected status 'completed', got '%s'", txn.Status)
+	}
+
+	// A captured charge can no longer be voided
+	if err := gw.Void(ctx, resp.TransactionID); err == nil {
+		t.Error("Expected error voiding a captured charge")
+	}
+}
+
+func TestMockGateway_Void(t *testing.T) {
+	gw := NewMockGateway(&MockGatewayConfig{
+		SuccessRate: 1.0,
+		DelayMs:     0,
+	})
+
+	ctx := context.Background()
+	resp, _ := gw.Charge(ctx, &ChargeRequest{
+		PaymentID:     "pay-123",
+		Amount:        1000.00,
+		Currency:      "THB",
+		AuthorizeOnly: true,
+	})
+
+	if err := gw.Void(ctx, resp.TransactionID); err != nil {
+		t.Fatalf("Unexpected error voiding: %v", err)
+	}
+	txn, _ := gw.GetTransaction(ctx, resp.TransactionID)
+	if txn.Status != "voided" {
+		t.Errorf("Expected status 'voided', got '%s'", txn.Status)
+	}
+
+	if err := gw.Capture(ctx, resp.TransactionID, 1000.00); err == nil {
+		t.Error("Expected error capturing a voided charge")
+	}
+	if err := gw.Void(ctx, "non-existent"); err == nil {
+		t.Error("Expected error for non-existent transaction")
+	}
+}
+
 func TestMockGateway_GetTransaction(t *testing.T) {
 	gw := NewMockGateway(&MockGatewayConfig{
 		SuccessRate: 1.0,
@@ -175,6 +241,28 @@ func TestMockGateway_GetTransaction_NotFound(t *testing.T) {
 	}
 }
 
+func TestMockGateway_FindTransaction(t *testing.T) {
+	gw := NewMockGateway(&MockGatewayConfig{
+		SuccessRate: 1.0,
+		DelayMs:     0,
+	})
+
+	ctx := context.Background()
+	resp, _ := gw.Charge(ctx, &ChargeRequest{PaymentID: "pay-123", Amount: 500.00, Currency: "THB"})
+
+	txn, err := gw.FindTransaction(ctx, "pay-123")
+	if err != nil {
+		t.Fatalf("Failed to find transaction: %v", err)
+	}
+	if txn.TransactionID != resp.TransactionID {
+		t.Errorf("Expected transaction %s, got %s", resp.TransactionID, txn.TransactionID)
+	}
+
+	if _, err := gw.FindTransaction(ctx, "pay-other"); !errors.Is(err, ErrTransactionNotFound) {
+		t.Errorf("Expected %v, got %v", ErrTransactionNotFound, err)
+	}
+}
+
 func TestMockGateway_SetSuccessRate(t *testing.T) {
 	gw := NewMockGateway(&MockGatewayConfig{
 		SuccessRate: 0.5,
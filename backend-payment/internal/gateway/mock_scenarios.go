@@ -0,0 +1,186 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// MetadataSandboxScenario is the request metadata key that selects a sandbox scenario.
+// Besides the scenario names below it accepts "decline:<code>" for any decline code.
+const MetadataSandboxScenario = "sandbox_scenario"
+
+// Sandbox scenario names
+const (
+	ScenarioSuccess           = "success"
+	ScenarioCardDeclined      = "card_declined"
+	ScenarioInsufficientFunds = "insufficient_funds"
+	ScenarioExpiredCard       = "expired_card"
+	ScenarioFraudDetected     = "fraud_detected"
+	ScenarioProcessingError   = "processing_error"
+	ScenarioRequires3DS       = "requires_3ds"
+	ScenarioSlowResponse      = "slow_response"
+	ScenarioTimeout           = "timeout"
+	ScenarioDuplicateWebhook  = "duplicate_webhook"
+)
+
+// declinePrefix selects a decline with an arbitrary code, e.g. "decline:do_not_honor"
+const declinePrefix = "decline:"
+
+// Mock webhook event types, named after their Stripe counterparts
+const (
+	WebhookPaymentSucceeded      = "payment_intent.succeeded"
+	WebhookPaymentFailed         = "payment_intent.payment_failed"
+	WebhookPaymentRequiresAction = "payment_intent.requires_action"
+	WebhookPaymentAuthorized     = "payment_intent.amount_capturable_updated"
+)
+
+// SandboxScenario describes how the mock gateway answers a single request
+type SandboxScenario struct {
+	Name string
+
+	// DeclineCode fails the payment with this code
+	DeclineCode string
+
+	// RequiresAction asks for 3-D Secure authentication instead of completing the payment
+	RequiresAction bool
+
+	// Slow adds MockGatewayConfig.SlowResponseMs to the processing delay
+	Slow bool
+
+	// Timeout waits MockGatewayConfig.TimeoutMs and returns ErrGatewayTimeout
+	Timeout bool
+
+	// DuplicateWebhook delivers the outcome webhook twice with the same event ID
+	DuplicateWebhook bool
+}
+
+// sandboxScenarios are the scenarios that can be selected by name
+var sandboxScenarios = map[string]SandboxScenario{
+	ScenarioSuccess:           {Name: ScenarioSuccess},
+	ScenarioCardDeclined:      {Name: ScenarioCardDeclined, DeclineCode: "card_declined"},
+	ScenarioInsufficientFunds: {Name: ScenarioInsufficientFunds, DeclineCode: "insufficient_funds"},
+	ScenarioExpiredCard:       {Name: ScenarioExpiredCard, DeclineCode: "expired_card"},
+	ScenarioFraudDetected:     {Name: ScenarioFraudDetected, DeclineCode: "fraud_detected"},
+	ScenarioProcessingError:   {Name: ScenarioProcessingError, DeclineCode: "processing_error"},
+	ScenarioRequires3DS:       {Name: ScenarioRequires3DS, RequiresAction: true},
+	ScenarioSlowResponse:      {Name: ScenarioSlowResponse, Slow: true},
+	ScenarioTimeout:           {Name: ScenarioTimeout, Timeout: true},
+	ScenarioDuplicateWebhook:  {Name: ScenarioDuplicateWebhook, DuplicateWebhook: true},
+}
+
+// DefaultMagicAmounts maps amounts in satang to scenarios.
+// They are all below one baht so they never collide with real ticket prices.
+func DefaultMagicAmounts() map[int64]string {
+	return map[int64]string{
+		2:  ScenarioCardDeclined,
+		5:  ScenarioInsufficientFunds,
+		6:  ScenarioExpiredCard,
+		7:  ScenarioFraudDetected,
+		8:  ScenarioProcessingError,
+		30: ScenarioRequires3DS,
+		50: ScenarioSlowResponse,
+		90: ScenarioTimeout,
+		99: ScenarioDuplicateWebhook,
+	}
+}
+
+// LookupSandboxScenario returns the scenario with the given name
+func LookupSandboxScenario(name string) (SandboxScenario, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if code := strings.TrimPrefix(name, declinePrefix); code != name {
+		if code == "" {
+			return SandboxScenario{}, false
+		}
+		return SandboxScenario{Name: name, DeclineCode: code}, true
+	}
+
+	scenario, ok := sandboxScenarios[name]
+	return scenario, ok
+}
+
+// MockWebhookEvent is the webhook the mock gateway delivers when a payment reaches an outcome
+type MockWebhookEvent struct {
+	ID            string
+	Type          string
+	PaymentID     string
+	TransactionID string
+	Amount        float64
+	Currency      string
+	FailureCode   string
+	Metadata      map[string]string
+	CreatedAt     time.Time
+}
+
+// resolveScenario picks the scenario for a request. Metadata takes precedence over magic
+// amounts; nil means the request follows the configured success rate.
+func (g *MockGateway) resolveScenario(amount float64, metadata map[string]string) *SandboxScenario {
+	if name := metadata[MetadataSandboxScenario]; name != "" {
+		if scenario, ok := LookupSandboxScenario(name); ok {
+			return &scenario
+		}
+	}
+
+	if name, ok := g.config.MagicAmounts[int64(math.Round(amount*100))]; ok {
+		if scenario, ok := LookupSandboxScenario(name); ok {
+			return &scenario
+		}
+	}
+	return nil
+}
+
+// simulateLatency waits for the processing delay of the request and fails timed out requests
+func (g *MockGateway) simulateLatency(ctx context.Context, scenario *SandboxScenario) error {
+	delay := time.Duration(g.config.DelayMs) * time.Millisecond
+	if scenario != nil && scenario.Slow {
+		delay += time.Duration(g.config.SlowResponseMs) * time.Millisecond
+	}
+	if scenario != nil && scenario.Timeout {
+		delay += time.Duration(g.config.TimeoutMs) * time.Millisecond
+	}
+
+	if delay > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+
+	if scenario != nil && scenario.Timeout {
+		return ErrGatewayTimeout
+	}
+	return nil
+}
+
+// deliverWebhook hands the outcome event to the configured webhook handler
+func (g *MockGateway) deliverWebhook(ctx context.Context, scenario *SandboxScenario, event *MockWebhookEvent) {
+	if g.config.WebhookHandler == nil {
+		return
+	}
+
+	event.ID = fmt.Sprintf("evt_mock_%s", randomAlphanumeric(24))
+	event.CreatedAt = time.Now()
+
+	g.config.WebhookHandler(ctx, event)
+	if scenario != nil && scenario.DuplicateWebhook {
+		duplicate := *event
+		g.config.WebhookHandler(ctx, &duplicate)
+	}
+}
+
+// webhookTypeForStatus returns the webhook event type for a payment status
+func webhookTypeForStatus(status string) string {
+	switch status {
+	case "completed", "succeeded":
+		return WebhookPaymentSucceeded
+	case "requires_action":
+		return WebhookPaymentRequiresAction
+	case "authorized":
+		return WebhookPaymentAuthorized
+	default:
+		return WebhookPaymentFailed
+	}
+}
@@ -0,0 +1,195 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func newScenarioGateway(events *[]*MockWebhookEvent) *MockGateway {
+	return NewMockGateway(&MockGatewayConfig{
+		SuccessRate:  0.0, // Scenarios must not depend on the success rate
+		MagicAmounts: DefaultMagicAmounts(),
+		WebhookHandler: func(ctx context.Context, event *MockWebhookEvent) {
+			*events = append(*events, event)
+		},
+	})
+}
+
+func TestMockGateway_Charge_Scenarios(t *testing.T) {
+	tests := []struct {
+		name        string
+		amount      float64
+		scenario    string
+		wantSuccess bool
+		wantStatus  string
+		wantCode    string
+		wantWebhook string
+	}{
+		{"success by metadata", 1000, ScenarioSuccess, true, "completed", "", WebhookPaymentSucceeded},
+		{"decline by metadata", 1000, ScenarioInsufficientFunds, false, "failed", "insufficient_funds", WebhookPaymentFailed},
+		{"custom decline code", 1000, "decline:do_not_honor", false, "failed", "do_not_honor", WebhookPaymentFailed},
+		{"decline by magic amount", 0.02, "", false, "failed", "card_declined", WebhookPaymentFailed},
+		{"3ds by magic amount", 0.30, "", false, "requires_action", "authentication_required", WebhookPaymentRequiresAction},
+		{"metadata overrides magic amount", 0.02, ScenarioSuccess, true, "completed", "", WebhookPaymentSucceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var events []*MockWebhookEvent
+			gw := newScenarioGateway(&events)
+
+			req := &ChargeRequest{PaymentID: "pay-123", Amount: tt.amount, Currency: "THB"}
+			if tt.scenario != "" {
+				req.Metadata = map[string]string{MetadataSandboxScenario: tt.scenario}
+			}
+
+			resp, err := gw.Charge(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if resp.Success != tt.wantSuccess || resp.Status != tt.wantStatus || resp.FailureCode != tt.wantCode {
+				t.Errorf("Expected success=%v status=%s code=%s, got success=%v status=%s code=%s",
+					tt.wantSuccess, tt.wantStatus, tt.wantCode, resp.Success, resp.Status, resp.FailureCode)
+			}
+			if resp.RequiresAction != (tt.wantStatus == "requires_action") {
+				t.Errorf("Expected RequiresAction=%v", tt.wantStatus == "requires_action")
+			}
+			if len(events) != 1 || events[0].Type != tt.wantWebhook || events[0].PaymentID != "pay-123" {
+				t.Errorf("Expected one %s webhook, got %+v", tt.wantWebhook, events)
+			}
+		})
+	}
+}
+
+func TestMockGateway_Charge_UnknownScenarioFollowsSuccessRate(t *testing.T) {
+	gw := NewMockGateway(&MockGatewayConfig{SuccessRate: 1.0})
+
+	resp, err := gw.Charge(context.Background(), &ChargeRequest{
+		Amount:   1000,
+		Metadata: map[string]string{MetadataSandboxScenario: "no_such_scenario"},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Error("Expected successful charge")
+	}
+}
+
+func TestMockGateway_Charge_Timeout(t *testing.T) {
+	var events []*MockWebhookEvent
+	gw := newScenarioGateway(&events)
+
+	_, err := gw.Charge(context.Background(), &ChargeRequest{Amount: 0.90})
+	if !errors.Is(err, ErrGatewayTimeout) {
+		t.Errorf("Expected %v, got %v", ErrGatewayTimeout, err)
+	}
+	if len(events) != 0 {
+		t.Errorf("Expected no webhook for a timed out charge, got %d", len(events))
+	}
+}
+
+func TestMockGateway_Charge_SlowResponseRespectsContext(t *testing.T) {
+	gw := NewMockGateway(&MockGatewayConfig{SlowResponseMs: 60000})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := gw.Charge(ctx, &ChargeRequest{
+		Amount:   1000,
+		Metadata: map[string]string{MetadataSandboxScenario: ScenarioSlowResponse},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestMockGateway_Charge_DuplicateWebhook(t *testing.T) {
+	var events []*MockWebhookEvent
+	gw := newScenarioGateway(&events)
+
+	resp, err := gw.Charge(context.Background(), &ChargeRequest{PaymentID: "pay-123", Amount: 0.99})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !resp.Success {
+		t.Error("Expected successful charge")
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 webhooks, got %d", len(events))
+	}
+	if events[0].ID == "" || events[0].ID != events[1].ID {
+		t.Errorf("Expected duplicate webhooks to share an event ID, got %q and %q", events[0].ID, events[1].ID)
+	}
+}
+
+func TestMockGateway_RequiresActionCompleteAndVoid(t *testing.T) {
+	var events []*MockWebhookEvent
+	gw := newScenarioGateway(&events)
+	ctx := context.Background()
+	requires3DS := map[string]string{MetadataSandboxScenario: ScenarioRequires3DS}
+
+	resp, err := gw.Charge(ctx, &ChargeRequest{PaymentID: "pay-returned", Amount: 1000, Metadata: requires3DS})
+	if err != nil || !resp.RequiresAction || resp.RedirectURL == "" {
+		t.Fatalf("Charge() = %+v, %v, want requires action with a redirect URL", resp, err)
+	}
+
+	// The customer completes the action on the redirect page
+	if err := gw.CompleteAction(ctx, resp.TransactionID); err != nil {
+		t.Fatalf("CompleteAction() error = %v", err)
+	}
+	if txn, _ := gw.GetTransaction(ctx, resp.TransactionID); txn.Status != "completed" {
+		t.Errorf("Expected completed transaction, got %s", txn.Status)
+	}
+	if last := events[len(events)-1]; last.Type != WebhookPaymentSucceeded || last.PaymentID != "pay-returned" {
+		t.Errorf("Expected succeeded webhook for pay-returned, got %+v", last)
+	}
+
+	// An abandoned action is cancelled by Void and cannot be completed afterwards
+	abandoned, _ := gw.Charge(ctx, &ChargeRequest{PaymentID: "pay-abandoned", Amount: 1000, Metadata: requires3DS})
+	if err := gw.Void(ctx, abandoned.TransactionID); err != nil {
+		t.Fatalf("Void() error = %v", err)
+	}
+	if txn, _ := gw.GetTransaction(ctx, abandoned.TransactionID); txn.Status != "canceled" {
+		t.Errorf("Expected canceled transaction, got %s", txn.Status)
+	}
+	if err := gw.CompleteAction(ctx, abandoned.TransactionID); err == nil {
+		t.Error("Expected error completing a cancelled action")
+	}
+}
+
+func TestMockGateway_ConfirmPaymentIntent_Scenario(t *testing.T) {
+	var events []*MockWebhookEvent
+	gw := newScenarioGateway(&events)
+	ctx := context.Background()
+
+	intent, err := gw.CreatePaymentIntent(ctx, &PaymentIntentRequest{
+		Amount:   1500,
+		Currency: "THB",
+		Metadata: map[string]string{"payment_id": "pay-123", MetadataSandboxScenario: ScenarioRequires3DS},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	confirmed, err := gw.ConfirmPaymentIntent(ctx, intent.PaymentIntentID)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if confirmed.Status != "requires_action" {
+		t.Errorf("Expected status 'requires_action', got '%s'", confirmed.Status)
+	}
+	if len(events) != 1 || events[0].Type != WebhookPaymentRequiresAction || events[0].PaymentID != "pay-123" {
+		t.Errorf("Expected one requires_action webhook for pay-123, got %+v", events)
+	}
+}
+
+func TestLookupSandboxScenario(t *testing.T) {
+	if _, ok := LookupSandboxScenario("decline:"); ok {
+		t.Error("Expected decline without a code to be rejected")
+	}
+	if s, ok := LookupSandboxScenario(" Card_Declined "); !ok || s.DeclineCode != "card_declined" {
+		t.Errorf("Expected card_declined scenario, got %+v (%v)", s, ok)
+	}
+}
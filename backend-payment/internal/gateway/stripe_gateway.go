@@ -2,13 +2,17 @@ package gateway
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"strings"
 
 	"github.com/stripe/stripe-go/v82"
 	"github.com/stripe/stripe-go/v82/billingportal/session"
 	"github.com/stripe/stripe-go/v82/customer"
 	"github.com/stripe/stripe-go/v82/paymentintent"
 	"github.com/stripe/stripe-go/v82/paymentmethod"
+	"github.com/stripe/stripe-go/v82/payout"
 	"github.com/stripe/stripe-go/v82/refund"
 )
 
@@ -71,9 +75,21 @@ func (g *StripeGateway) Charge(ctx context.Context, req *ChargeRequest) (*Charge
 		params.Description = stripe.String(req.Description)
 	}
 
+	// Authorize-only charges stop at requires_capture until Capture or Void
+	if req.AuthorizeOnly {
+		params.CaptureMethod = stripe.String(string(stripe.PaymentIntentCaptureMethodManual))
+	}
+
 	// Create payment intent
+	params.Context = ctx
 	pi, err := paymentintent.New(params)
 	if err != nil {
+		// A network error or timeout leaves the charge's outcome unknown, unlike a
+		// decline; return it so the payment isn't recorded as failed
+		var stripeErr *stripe.Error
+		if !errors.As(err, &stripeErr) {
+			return nil, fmt.Errorf("failed to create payment intent: %w", err)
+		}
 		return &ChargeResponse{
 			Success:       false,
 			FailureReason: err.Error(),
@@ -93,9 +109,17 @@ func (g *StripeGateway) Charge(ctx context.Context, req *ChargeRequest) (*Charge
 	switch pi.Status {
 	case stripe.PaymentIntentStatusSucceeded:
 		resp.Success = true
+	case stripe.PaymentIntentStatusRequiresCapture:
+		resp.Success = true
+		resp.Status = "authorized"
+	case stripe.PaymentIntentStatusRequiresAction:
+		// The customer finishes paying on a page of the bank or Stripe
+		resp.RequiresAction = true
+		resp.RedirectURL = nextActionURL(pi.NextAction)
+		resp.FailureReason = "payment_requires_action"
+		resp.FailureCode = string(pi.Status)
 	case stripe.PaymentIntentStatusRequiresPaymentMethod,
-		stripe.PaymentIntentStatusRequiresConfirmation,
-		stripe.PaymentIntentStatusRequiresAction:
+		stripe.PaymentIntentStatusRequiresConfirmation:
 		// These statuses mean the payment needs more steps
 		resp.Success = false
 		resp.FailureReason = "payment_requires_action"
@@ -119,6 +143,21 @@ func (g *StripeGateway) Charge(ctx context.Context, req *ChargeRequest) (*Charge
 	return resp, nil
 }
 
+// nextActionURL returns the page the customer completes a payment intent's next action
+// on: the 3-D Secure redirect, or the hosted instructions of a bank transfer
+func nextActionURL(action *stripe.PaymentIntentNextAction) string {
+	switch {
+	case action == nil:
+		return ""
+	case action.RedirectToURL != nil:
+		return action.RedirectToURL.URL
+	case action.DisplayBankTransferInstructions != nil:
+		return action.DisplayBankTransferInstructions.HostedInstructionsURL
+	default:
+		return ""
+	}
+}
+
 // Refund processes a refund through Stripe
 func (g *StripeGateway) Refund(ctx context.Context, transactionID string, amount float64) error {
 	if transactionID == "" {
@@ -141,17 +180,88 @@ func (g *StripeGateway) Refund(ctx context.Context, transactionID string, amount
 	return nil
 }
 
+// Capture captures an authorized PaymentIntent through Stripe
+func (g *StripeGateway) Capture(ctx context.Context, transactionID string, amount float64) error {
+	if transactionID == "" {
+		return fmt.Errorf("transaction ID is required")
+	}
+
+	params := &stripe.PaymentIntentCaptureParams{
+		AmountToCapture: stripe.Int64(int64(amount * 100)),
+	}
+
+	if _, err := paymentintent.Capture(transactionID, params); err != nil {
+		return fmt.Errorf("failed to capture payment intent: %w", err)
+	}
+
+	return nil
+}
+
+// Void cancels an authorized PaymentIntent through Stripe, releasing the hold on the card
+func (g *StripeGateway) Void(ctx context.Context, transactionID string) error {
+	if transactionID == "" {
+		return fmt.Errorf("transaction ID is required")
+	}
+
+	params := &stripe.PaymentIntentCancelParams{
+		CancellationReason: stripe.String(string(stripe.PaymentIntentCancellationReasonAbandoned)),
+	}
+
+	if _, err := paymentintent.Cancel(transactionID, params); err != nil {
+		return fmt.Errorf("failed to cancel payment intent: %w", err)
+	}
+
+	return nil
+}
+
 // GetTransaction retrieves transaction details from Stripe
 func (g *StripeGateway) GetTransaction(ctx context.Context, transactionID string) (*TransactionInfo, error) {
 	if transactionID == "" {
 		return nil, fmt.Errorf("transaction ID is required")
 	}
 
-	pi, err := paymentintent.Get(transactionID, nil)
+	pi, err := paymentintent.Get(transactionID, &stripe.PaymentIntentParams{Params: stripe.Params{Context: ctx}})
 	if err != nil {
+		var stripeErr *stripe.Error
+		if errors.As(err, &stripeErr) && stripeErr.Code == stripe.ErrorCodeResourceMissing {
+			return nil, fmt.Errorf("%w: %s", ErrTransactionNotFound, transactionID)
+		}
 		return nil, fmt.Errorf("failed to get payment intent: %w", err)
 	}
 
+	return transactionInfo(pi), nil
+}
+
+// FindTransaction searches Stripe for the PaymentIntent created for a payment. Search
+// results can lag up to a minute behind, so only look for payments older than that.
+func (g *StripeGateway) FindTransaction(ctx context.Context, paymentID string) (*TransactionInfo, error) {
+	if paymentID == "" {
+		return nil, fmt.Errorf("payment ID is required")
+	}
+
+	params := &stripe.PaymentIntentSearchParams{}
+	params.Context = ctx
+	params.Query = fmt.Sprintf("metadata['payment_id']:'%s'", strings.ReplaceAll(paymentID, "'", "\\'"))
+
+	var latest *stripe.PaymentIntent
+	iter := paymentintent.Search(params)
+	for iter.Next() {
+		if pi := iter.PaymentIntent(); latest == nil || pi.Created > latest.Created {
+			latest = pi
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to search payment intents: %w", err)
+	}
+	if latest == nil {
+		return nil, ErrTransactionNotFound
+	}
+
+	return transactionInfo(latest), nil
+}
+
+// transactionInfo converts a PaymentIntent to TransactionInfo
+func transactionInfo(pi *stripe.PaymentIntent) *TransactionInfo {
 	return &TransactionInfo{
 		TransactionID: pi.ID,
 		Status:        string(pi.Status),
@@ -159,6 +269,39 @@ func (g *StripeGateway) GetTransaction(ctx context.Context, transactionID string
 		Currency:      string(pi.Currency),
 		CreatedAt:     fmt.Sprintf("%d", pi.Created),
 		Metadata:      pi.Metadata,
+	}
+}
+
+// CreatePayout sends a Stripe payout to a bank account token (ba_...) of the account.
+// The payout ID is the idempotency key, so Stripe sends a retried payout once.
+func (g *StripeGateway) CreatePayout(ctx context.Context, req *PayoutRequest) (*PayoutResponse, error) {
+	if req == nil || req.PayoutID == "" {
+		return nil, fmt.Errorf("payout request with a payout ID is required")
+	}
+
+	params := &stripe.PayoutParams{
+		Amount:      stripe.Int64(int64(math.Round(req.Amount * 100))),
+		Currency:    stripe.String(strings.ToLower(req.Currency)),
+		Destination: stripe.String(req.Destination),
+		Metadata:    map[string]string{"payout_id": req.PayoutID},
+	}
+	for k, v := range req.Metadata {
+		params.Metadata[k] = v
+	}
+	if req.Description != "" {
+		params.Description = stripe.String(req.Description)
+	}
+	params.Context = ctx
+	params.SetIdempotencyKey("payout-" + req.PayoutID)
+
+	po, err := payout.New(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create payout: %w", err)
+	}
+
+	return &PayoutResponse{
+		PayoutID: po.ID,
+		Status:   string(po.Status),
 	}, nil
 }
 
@@ -220,7 +363,7 @@ func (g *StripeGateway) ConfirmPaymentIntent(ctx context.Context, paymentIntentI
 	}
 
 	// Get the payment intent to check its status
-	pi, err := paymentintent.Get(paymentIntentID, nil)
+	pi, err := paymentintent.Get(paymentIntentID, &stripe.PaymentIntentParams{Params: stripe.Params{Context: ctx}})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get payment intent: %w", err)
 	}
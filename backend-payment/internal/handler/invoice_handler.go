@@ -0,0 +1,263 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// InvoiceHandler handles tax invoice requests from customers and the monthly e-Tax export for finance
+type InvoiceHandler struct {
+	invoiceService service.InvoiceService
+}
+
+// NewInvoiceHandler creates a new InvoiceHandler
+func NewInvoiceHandler(invoiceService service.InvoiceService) *InvoiceHandler {
+	return &InvoiceHandler{invoiceService: invoiceService}
+}
+
+// IssueInvoice handles POST /payments/:id/invoice
+// Issues the tax invoice of a charged payment, or returns the one already issued
+func (h *InvoiceHandler) IssueInvoice(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.invoice.issue")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	// The body is optional when the buyer details were given with the payment
+	var req dto.IssueInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "validation error")
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	userID := c.GetHeader("X-User-ID")
+	if userID == "" {
+		userID = c.GetString("user_id")
+	}
+	if userID == "" {
+		span.SetStatus(codes.Error, "user_id required")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse("UNAUTHORIZED", "user_id is required"))
+		return
+	}
+
+	paymentID := c.Param("id")
+	span.SetAttributes(attribute.String("payment_id", paymentID))
+
+	invoice, err := h.invoiceService.IssueInvoice(ctx, &service.IssueInvoiceRequest{
+		PaymentID: paymentID,
+		UserID:    userID,
+		Buyer:     req.TaxInvoice,
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusCreated, dto.NewSuccessResponse(dto.FromInvoice(invoice)))
+}
+
+// GetPaymentInvoice handles GET /payments/:id/invoice
+func (h *InvoiceHandler) GetPaymentInvoice(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.invoice.get_by_payment")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	span.SetAttributes(attribute.String("payment_id", c.Param("id")))
+
+	invoice, err := h.invoiceService.GetInvoiceByPaymentID(ctx, c.Param("id"))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.FromInvoice(invoice)))
+}
+
+// DownloadReceipt handles GET /payments/:id/invoice/receipt
+// Redirects to a signed URL of the printable receipt of the payment's tax invoice
+func (h *InvoiceHandler) DownloadReceipt(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.invoice.receipt")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	userID := c.GetHeader("X-User-ID")
+	if userID == "" {
+		userID = c.GetString("user_id")
+	}
+	if userID == "" {
+		span.SetStatus(codes.Error, "user_id required")
+		c.JSON(http.StatusUnauthorized, dto.NewErrorResponse("UNAUTHORIZED", "user_id is required"))
+		return
+	}
+
+	paymentID := c.Param("id")
+	span.SetAttributes(attribute.String("payment_id", paymentID))
+
+	url, err := h.invoiceService.ReceiptURL(ctx, paymentID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.Redirect(http.StatusFound, url)
+}
+
+// GetInvoice handles GET /internal/invoices/:id
+func (h *InvoiceHandler) GetInvoice(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.invoice.get")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	span.SetAttributes(attribute.String("invoice_id", c.Param("id")))
+
+	invoice, err := h.invoiceService.GetInvoice(ctx, c.Param("id"))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.FromInvoice(invoice)))
+}
+
+// ListInvoices handles GET /internal/invoices?tenant_id=&month=YYYY-MM
+func (h *InvoiceHandler) ListInvoices(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.invoice.list")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	tenantID := c.Query("tenant_id")
+	if tenantID == "" {
+		span.SetStatus(codes.Error, "tenant_id required")
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", "tenant_id is required"))
+		return
+	}
+
+	invoices, err := h.invoiceService.ListMonth(ctx, tenantID, c.Query("month"))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	responses := make([]*dto.InvoiceResponse, len(invoices))
+	for i, invoice := range invoices {
+		responses[i] = dto.FromInvoice(invoice)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(responses))
+}
+
+// ExportInvoices handles GET /internal/invoices/export?tenant_id=&month=YYYY-MM
+// Streams the month's invoices as CSV in the e-Tax column layout
+func (h *InvoiceHandler) ExportInvoices(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.invoice.export")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	tenantID, month := c.Query("tenant_id"), c.Query("month")
+	if tenantID == "" {
+		span.SetStatus(codes.Error, "tenant_id required")
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", "tenant_id is required"))
+		return
+	}
+	if _, _, err := domain.ParseInvoiceMonth(month); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="etax-invoices-%s.csv"`, month))
+	count, err := h.invoiceService.ExportMonth(ctx, tenantID, month, c.Writer)
+	if err != nil {
+		// Headers may already be sent, so the error can only be recorded
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	span.SetAttributes(attribute.Int("count", count))
+	span.SetStatus(codes.Ok, "")
+}
+
+// StoreExport handles POST /internal/invoices/exports?tenant_id=&month=YYYY-MM
+// Keeps the month's e-Tax CSV in file storage and returns a signed download URL
+func (h *InvoiceHandler) StoreExport(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.invoice.store_export")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	tenantID, month := c.Query("tenant_id"), c.Query("month")
+	if tenantID == "" {
+		span.SetStatus(codes.Error, "tenant_id required")
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", "tenant_id is required"))
+		return
+	}
+
+	export, err := h.invoiceService.StoreExport(ctx, tenantID, month)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("count", export.Count))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusCreated, dto.NewSuccessResponse(&dto.InvoiceExportResponse{
+		TenantID:    tenantID,
+		Month:       month,
+		Count:       export.Count,
+		DownloadURL: export.DownloadURL,
+		ExpiresAt:   export.ExpiresAt,
+	}))
+}
+
+// handleError maps invoice errors to HTTP responses
+func (h *InvoiceHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrPaymentNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("NOT_FOUND", "payment not found"))
+	case errors.Is(err, domain.ErrInvoiceNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("NOT_FOUND", "invoice not found"))
+	case errors.Is(err, domain.ErrInvalidUserID):
+		c.JSON(http.StatusForbidden, dto.NewErrorResponse("FORBIDDEN", "payment belongs to another user"))
+	case errors.Is(err, domain.ErrPaymentNotCharged):
+		c.JSON(http.StatusConflict, dto.NewErrorResponse("PAYMENT_NOT_CHARGED", err.Error()))
+	case errors.Is(err, domain.ErrTaxInfoRequired),
+		errors.Is(err, domain.ErrInvalidTaxInfo),
+		errors.Is(err, domain.ErrInvalidTaxID):
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("INVALID_TAX_INFO", err.Error()))
+	case errors.Is(err, domain.ErrInvalidInvoiceMonth):
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", err.Error()))
+	case errors.Is(err, domain.ErrFileStorageDisabled):
+		c.JSON(http.StatusNotImplemented, dto.NewErrorResponse("FILE_STORAGE_DISABLED", err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("INVOICE_FAILED", err.Error()))
+	}
+}
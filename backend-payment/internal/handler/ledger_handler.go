@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// LedgerHandler serves tenant ledgers to finance tooling reconciling gateway settlements
+type LedgerHandler struct {
+	ledgerService service.LedgerService
+}
+
+// NewLedgerHandler creates a new LedgerHandler
+func NewLedgerHandler(ledgerService service.LedgerService) *LedgerHandler {
+	return &LedgerHandler{ledgerService: ledgerService}
+}
+
+// PostTransaction handles POST /internal/ledger/transactions
+// Records a gateway fee or payout; posting the same idempotency key again returns the
+// transaction already recorded
+func (h *LedgerHandler) PostTransaction(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.ledger.post")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	var req dto.PostLedgerTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "validation error")
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	postReq := &service.PostLedgerRequest{
+		TenantID:       req.TenantID,
+		Type:           domain.LedgerTransactionType(req.Type),
+		Amount:         req.Amount,
+		Currency:       req.Currency,
+		PaymentID:      req.PaymentID,
+		Reference:      req.Reference,
+		IdempotencyKey: req.IdempotencyKey,
+		Description:    req.Description,
+	}
+	if req.OccurredAt != nil {
+		postReq.OccurredAt = *req.OccurredAt
+	}
+
+	tx, err := h.ledgerService.PostTransaction(ctx, postReq)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetAttributes(attribute.String("transaction_id", tx.ID))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusCreated, dto.NewSuccessResponse(dto.FromLedgerTransaction(tx)))
+}
+
+// GetTransaction handles GET /internal/ledger/transactions/:id
+func (h *LedgerHandler) GetTransaction(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.ledger.get_transaction")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	span.SetAttributes(attribute.String("transaction_id", c.Param("id")))
+
+	tx, err := h.ledgerService.GetTransaction(ctx, c.Param("id"))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.FromLedgerTransaction(tx)))
+}
+
+// GetBalances handles GET /internal/ledger/balances?tenant_id=&as_of=RFC3339
+// Returns the balance of every account of the tenant, over the entries before as_of
+func (h *LedgerHandler) GetBalances(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.ledger.get_balances")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	tenantID := c.Query("tenant_id")
+	if tenantID == "" {
+		span.SetStatus(codes.Error, "tenant_id required")
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", "tenant_id is required"))
+		return
+	}
+	asOf, err := parseOptionalTime(c.Query("as_of"))
+	if err != nil {
+		span.SetStatus(codes.Error, "invalid as_of")
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", "as_of must be an RFC 3339 time"))
+		return
+	}
+
+	balances, err := h.ledgerService.GetBalances(ctx, tenantID, asOf)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	if asOf.IsZero() {
+		asOf = time.Now().UTC()
+	}
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.FromLedgerBalances(tenantID, asOf, balances)))
+}
+
+// ListEntries handles GET /internal/ledger/entries?tenant_id=&account=&type=&payment_id=&from=&to=&cursor=&limit=
+// Returns a page of the tenant's entries, newest first; from and to are RFC 3339 times
+func (h *LedgerHandler) ListEntries(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.ledger.list_entries")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	filter := &domain.LedgerEntryFilter{
+		TenantID:  c.Query("tenant_id"),
+		Account:   domain.LedgerAccount(c.Query("account")),
+		Type:      domain.LedgerTransactionType(c.Query("type")),
+		PaymentID: c.Query("payment_id"),
+	}
+	if filter.TenantID == "" {
+		span.SetStatus(codes.Error, "tenant_id required")
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", "tenant_id is required"))
+		return
+	}
+	var fromErr, toErr error
+	filter.From, fromErr = parseOptionalTime(c.Query("from"))
+	filter.To, toErr = parseOptionalTime(c.Query("to"))
+	if fromErr != nil || toErr != nil {
+		span.SetStatus(codes.Error, "invalid time range")
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", "from and to must be RFC 3339 times"))
+		return
+	}
+
+	// Parse pagination; cursor takes precedence over offset
+	limit := pagination.DefaultLimit
+	offset := 0
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= pagination.MaxLimit {
+			limit = parsed
+		}
+	}
+	if o := c.Query("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		}
+	}
+
+	page, err := pagination.NewParams(c.Query("cursor"), limit, offset)
+	if err != nil {
+		span.SetStatus(codes.Error, "invalid cursor")
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("INVALID_CURSOR", "cursor is invalid"))
+		return
+	}
+
+	entries, info, err := h.ledgerService.ListEntries(ctx, filter, page)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	responses := make([]*dto.LedgerEntryResponse, len(entries))
+	for i, entry := range entries {
+		responses[i] = dto.FromLedgerEntry(entry)
+	}
+
+	span.SetAttributes(attribute.Int("count", len(responses)))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(&dto.LedgerEntryListResponse{
+		Entries: responses,
+		Total:   len(responses),
+		Info:    info,
+	}))
+}
+
+// handleError maps ledger errors to HTTP responses
+func (h *LedgerHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrLedgerTransactionNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("NOT_FOUND", "ledger transaction not found"))
+	case errors.Is(err, domain.ErrPaymentNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("NOT_FOUND", "payment not found"))
+	case errors.Is(err, domain.ErrInvalidLedgerTransaction),
+		errors.Is(err, domain.ErrUnbalancedLedgerTransaction),
+		errors.Is(err, domain.ErrInvalidLedgerFilter):
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("LEDGER_FAILED", err.Error()))
+	}
+}
+
+// parseOptionalTime parses an RFC 3339 query parameter, the zero time when it is empty
+func parseOptionalTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
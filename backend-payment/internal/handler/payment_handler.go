@@ -15,9 +15,11 @@ import (
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/dto"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/gateway"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // PaymentHandler handles payment HTTP endpoints
@@ -25,6 +27,9 @@ type PaymentHandler struct {
 	paymentService service.PaymentService
 	paymentGateway gateway.PaymentGateway
 	authServiceURL string
+	refundRequests service.RefundRequestService   // Optional refund approval workflow
+	methodPolicies service.PaymentMethodResolver  // Optional payment method restrictions
+	reconciler     service.PaymentWatchdogService // Optional; resolves payments on the customer's return from the gateway
 }
 
 // NewPaymentHandler creates a new PaymentHandler
@@ -36,6 +41,24 @@ func NewPaymentHandler(paymentService service.PaymentService, paymentGateway gat
 	}
 }
 
+// UseRefundApproval sends refunds requested through the API through the approval
+// workflow, so refunds above the tenant's threshold wait for an admin
+func (h *PaymentHandler) UseRefundApproval(refundRequests service.RefundRequestService) {
+	h.refundRequests = refundRequests
+}
+
+// UseMethodPolicies reports the payment methods accepted per tenant and event when
+// saved payment methods are listed
+func (h *PaymentHandler) UseMethodPolicies(methodPolicies service.PaymentMethodResolver) {
+	h.methodPolicies = methodPolicies
+}
+
+// UseReconciliation resolves a payment requiring action from the gateway's record
+// when the customer returns from the gateway, instead of waiting for the webhook
+func (h *PaymentHandler) UseReconciliation(reconciler service.PaymentWatchdogService) {
+	h.reconciler = reconciler
+}
+
 // CreatePayment handles POST /payments
 // Creates a new payment and optionally processes it immediately
 func (h *PaymentHandler) CreatePayment(c *gin.Context) {
@@ -90,6 +113,8 @@ func (h *PaymentHandler) CreatePayment(c *gin.Context) {
 		Currency:  req.Currency,
 		Method:    req.Method,
 		Metadata:  req.Metadata,
+		TaxInfo:   req.TaxInvoice,
+		EventID:   req.EventID,
 	}
 
 	payment, err := h.paymentService.CreatePayment(ctx, svcReq)
@@ -101,6 +126,9 @@ func (h *PaymentHandler) CreatePayment(c *gin.Context) {
 			return
 		}
 		span.SetStatus(codes.Error, err.Error())
+		if h.handleCreateValidationError(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("CREATE_FAILED", err.Error()))
 		return
 	}
@@ -209,11 +237,11 @@ func (h *PaymentHandler) GetUserPayments(c *gin.Context) {
 		return
 	}
 
-	// Parse pagination
-	limit := 20
+	// Parse pagination; cursor takes precedence over offset
+	limit := pagination.DefaultLimit
 	offset := 0
 	if l := c.Query("limit"); l != "" {
-		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 100 {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= pagination.MaxLimit {
 			limit = parsed
 		}
 	}
@@ -223,13 +251,21 @@ func (h *PaymentHandler) GetUserPayments(c *gin.Context) {
 		}
 	}
 
+	page, err := pagination.NewParams(c.Query("cursor"), limit, offset)
+	if err != nil {
+		span.SetStatus(codes.Error, "invalid cursor")
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("INVALID_CURSOR", "cursor is invalid"))
+		return
+	}
+
 	span.SetAttributes(
 		attribute.String("user_id", userID),
-		attribute.Int("limit", limit),
-		attribute.Int("offset", offset),
+		attribute.Int("limit", page.Limit),
+		attribute.Int("offset", page.Offset),
+		attribute.Bool("cursor", page.After != nil),
 	)
 
-	payments, err := h.paymentService.GetUserPayments(ctx, userID, limit, offset)
+	payments, info, err := h.paymentService.GetUserPayments(ctx, userID, page)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
@@ -248,6 +284,7 @@ func (h *PaymentHandler) GetUserPayments(c *gin.Context) {
 	c.JSON(http.StatusOK, dto.NewSuccessResponse(&dto.PaymentListResponse{
 		Payments: paymentResponses,
 		Total:    len(paymentResponses),
+		Info:     info,
 	}))
 }
 
@@ -290,8 +327,52 @@ func (h *PaymentHandler) ProcessPayment(c *gin.Context) {
 	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.FromPayment(payment)))
 }
 
+// ReturnPayment handles POST /payments/:id/return
+// Called when the customer comes back from the gateway's redirect page. A payment still
+// requiring action is resolved from the gateway, which confirms or releases the booking;
+// the payment is returned as it stands afterwards.
+func (h *PaymentHandler) ReturnPayment(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.payment.return")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	paymentID := c.Param("id")
+	if paymentID == "" {
+		span.SetStatus(codes.Error, "payment_id required")
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", "payment_id is required"))
+		return
+	}
+
+	span.SetAttributes(attribute.String("payment_id", paymentID))
+
+	payment, err := h.paymentService.GetPayment(ctx, paymentID)
+	if err == nil && payment.RequiresAction() && h.reconciler != nil {
+		var resolution service.StuckPaymentResolution
+		if resolution, err = h.reconciler.ReconcilePayment(ctx, payment); err == nil {
+			span.SetAttributes(attribute.String("resolution", string(resolution)))
+			payment, err = h.paymentService.GetPayment(ctx, paymentID)
+		}
+	}
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, domain.ErrPaymentNotFound) {
+			span.SetStatus(codes.Error, "not found")
+			c.JSON(http.StatusNotFound, dto.NewErrorResponse("NOT_FOUND", "payment not found"))
+			return
+		}
+		span.SetStatus(codes.Error, err.Error())
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("RETURN_FAILED", err.Error()))
+		return
+	}
+
+	span.SetAttributes(attribute.String("status", string(payment.Status)))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.FromPayment(payment)))
+}
+
 // RefundPayment handles POST /payments/:id/refund
-// Refunds a completed payment
+// Refunds a completed payment. With refund approval, a refund above the tenant's
+// threshold returns 202 with the refund request pending approval.
 func (h *PaymentHandler) RefundPayment(c *gin.Context) {
 	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.payment.refund")
 	defer span.End()
@@ -317,9 +398,36 @@ func (h *PaymentHandler) RefundPayment(c *gin.Context) {
 
 	span.SetAttributes(attribute.String("reason", reason))
 
-	payment, err := h.paymentService.RefundPayment(ctx, paymentID, reason)
+	var payment *domain.Payment
+	var err error
+	if h.refundRequests != nil {
+		requestedBy := c.GetHeader("X-User-ID")
+		if requestedBy == "" {
+			requestedBy = c.GetString("user_id")
+		}
+		var request *domain.RefundRequest
+		request, payment, err = h.refundRequests.RequestRefund(ctx, &service.RequestRefundRequest{
+			PaymentID:   paymentID,
+			Reason:      reason,
+			RequestedBy: requestedBy,
+		})
+		if err == nil && request.Status == domain.RefundRequestStatusPendingApproval {
+			// Above the tenant's threshold: nothing is refunded until an admin approves
+			span.SetAttributes(attribute.String("refund_request_id", request.ID))
+			span.SetStatus(codes.Ok, "")
+			c.JSON(http.StatusAccepted, dto.NewSuccessResponse(dto.FromRefundRequest(request)))
+			return
+		}
+	} else {
+		payment, err = h.paymentService.RefundPayment(ctx, paymentID, reason)
+	}
 	if err != nil {
 		span.RecordError(err)
+		if errors.Is(err, domain.ErrRefundRequestExists) {
+			span.SetStatus(codes.Error, "refund already requested")
+			c.JSON(http.StatusConflict, dto.NewErrorResponse("REFUND_REQUESTED", err.Error()))
+			return
+		}
 		if errors.Is(err, domain.ErrPaymentNotFound) {
 			span.SetStatus(codes.Error, "not found")
 			c.JSON(http.StatusNotFound, dto.NewErrorResponse("NOT_FOUND", "payment not found"))
@@ -339,6 +447,58 @@ func (h *PaymentHandler) RefundPayment(c *gin.Context) {
 	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.FromPayment(payment)))
 }
 
+// AdjustPayment handles POST /internal/payments/:id/adjust
+// Settles the price difference of a modified booking (called by booking-service)
+func (h *PaymentHandler) AdjustPayment(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.payment.adjust")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	paymentID := c.Param("id")
+	span.SetAttributes(attribute.String("payment_id", paymentID))
+
+	var req dto.AdjustPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.SetStatus(codes.Error, "invalid request")
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	reason := req.Reason
+	if reason == "" {
+		reason = "booking_modified"
+	}
+
+	payment, err := h.paymentService.AdjustPayment(ctx, &service.AdjustPaymentRequest{
+		PaymentID:    paymentID,
+		AdjustmentID: req.AdjustmentID,
+		Amount:       req.Amount,
+		Reason:       reason,
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		switch {
+		case errors.Is(err, domain.ErrPaymentNotFound):
+			c.JSON(http.StatusNotFound, dto.NewErrorResponse("NOT_FOUND", "payment not found"))
+		case errors.Is(err, domain.ErrInvalidAmount):
+			c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", err.Error()))
+		case errors.Is(err, domain.ErrInvalidPaymentStatus):
+			c.JSON(http.StatusConflict, dto.NewErrorResponse("INVALID_STATUS", "payment cannot be adjusted in current status"))
+		case errors.Is(err, domain.ErrRefundExceedsAmount):
+			c.JSON(http.StatusConflict, dto.NewErrorResponse("REFUND_EXCEEDS_AMOUNT", err.Error()))
+		case errors.Is(err, domain.ErrPaymentFailed):
+			c.JSON(http.StatusPaymentRequired, dto.NewErrorResponse("PAYMENT_FAILED", err.Error()))
+		default:
+			c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("ADJUSTMENT_FAILED", err.Error()))
+		}
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.FromPayment(payment)))
+}
+
 // CancelPayment handles POST /payments/:id/cancel
 // Cancels a pending payment
 func (h *PaymentHandler) CancelPayment(c *gin.Context) {
@@ -435,6 +595,7 @@ func (h *PaymentHandler) CreatePaymentIntent(c *gin.Context) {
 		Amount:    req.Amount,
 		Currency:  currency,
 		Method:    domain.PaymentMethodCreditCard,
+		EventID:   req.EventID,
 	}
 
 	payment, err := h.paymentService.CreatePayment(ctx, svcReq)
@@ -451,6 +612,9 @@ func (h *PaymentHandler) CreatePaymentIntent(c *gin.Context) {
 		} else {
 			span.RecordError(err)
 			span.SetStatus(codes.Error, err.Error())
+			if h.handleCreateValidationError(c, err) {
+				return
+			}
 			c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("CREATE_FAILED", err.Error()))
 			return
 		}
@@ -516,6 +680,10 @@ func (h *PaymentHandler) CreatePaymentIntent(c *gin.Context) {
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
+		if errors.Is(err, gateway.ErrGatewayTimeout) {
+			c.JSON(http.StatusServiceUnavailable, dto.NewErrorResponse("GATEWAY_TIMEOUT", "payment gateway is slow to respond, please try again"))
+			return
+		}
 		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("PAYMENT_INTENT_FAILED", err.Error()))
 		return
 	}
@@ -554,6 +722,13 @@ func (h *PaymentHandler) ConfirmPaymentIntent(c *gin.Context) {
 
 	// Verify PaymentIntent status with Stripe
 	intentResp, err := h.paymentGateway.ConfirmPaymentIntent(ctx, req.PaymentIntentID)
+	if errors.Is(err, gateway.ErrGatewayTimeout) {
+		// The intent was completed client-side, so its outcome reaches us by
+		// webhook (or the payment watchdog); the client polls the payment for it
+		span.RecordError(err)
+		h.acceptPendingConfirmation(c, span, req.PaymentID, req.PaymentIntentID)
+		return
+	}
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
@@ -613,6 +788,29 @@ func (h *PaymentHandler) ConfirmPaymentIntent(c *gin.Context) {
 	}))
 }
 
+// acceptPendingConfirmation answers a confirmation the gateway was too slow to
+// report on with 202 and the payment as it stands
+func (h *PaymentHandler) acceptPendingConfirmation(c *gin.Context, span trace.Span, paymentID, paymentIntentID string) {
+	payment, err := h.paymentService.GetPayment(c.Request.Context(), paymentID)
+	if err != nil {
+		span.SetStatus(codes.Error, "payment not found")
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("NOT_FOUND", "payment not found"))
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("status", string(payment.Status)),
+		attribute.Bool("confirmation_pending", true),
+	)
+	span.SetStatus(codes.Ok, "confirmation pending")
+	c.JSON(http.StatusAccepted, dto.NewSuccessResponse(map[string]interface{}{
+		"payment_id":           payment.ID,
+		"status":               payment.Status,
+		"payment_intent_id":    paymentIntentID,
+		"confirmation_pending": true,
+	}))
+}
+
 // CreatePortalSession handles POST /payments/portal
 // Creates a Stripe Customer Portal session for managing payment methods
 func (h *PaymentHandler) CreatePortalSession(c *gin.Context) {
@@ -760,8 +958,9 @@ func (h *PaymentHandler) updateStripeCustomerID(authServiceURL, userID, stripeCu
 	return nil
 }
 
-// ListPaymentMethods handles GET /payments/methods
-// Returns saved payment methods for the current user
+// ListPaymentMethods handles GET /payments/methods?event_id=
+// Returns saved payment methods for the current user along with the payment methods
+// accepted for the event. Saved cards are left out when the event does not accept cards.
 func (h *PaymentHandler) ListPaymentMethods(c *gin.Context) {
 	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.payment.list_methods")
 	defer span.End()
@@ -780,6 +979,15 @@ func (h *PaymentHandler) ListPaymentMethods(c *gin.Context) {
 
 	span.SetAttributes(attribute.String("user_id", userID))
 
+	policy, err := h.resolveMethodPolicy(c)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("LIST_METHODS_FAILED", err.Error()))
+		return
+	}
+	span.SetAttributes(attribute.String("allowed_methods", policy.AllowedList()))
+
 	// Get Stripe Customer ID from Auth Service
 	stripeCustomerID, err := h.getStripeCustomerID(h.authServiceURL, userID)
 	if err != nil {
@@ -789,13 +997,14 @@ func (h *PaymentHandler) ListPaymentMethods(c *gin.Context) {
 		return
 	}
 
-	// If user doesn't have a Stripe Customer ID, return empty list
-	if stripeCustomerID == "" {
+	// If user doesn't have a Stripe Customer ID or can't pay by card, return empty list
+	if stripeCustomerID == "" || !policy.AllowsCards() {
 		span.SetAttributes(attribute.Int("count", 0))
 		span.SetStatus(codes.Ok, "")
 		c.JSON(http.StatusOK, dto.NewSuccessResponse(&dto.PaymentMethodsListResponse{
 			PaymentMethods: []*dto.PaymentMethodResponse{},
 			Total:          0,
+			AllowedMethods: policy.AllowedMethods,
 		}))
 		return
 	}
@@ -830,5 +1039,39 @@ func (h *PaymentHandler) ListPaymentMethods(c *gin.Context) {
 	c.JSON(http.StatusOK, dto.NewSuccessResponse(&dto.PaymentMethodsListResponse{
 		PaymentMethods: methodResponses,
 		Total:          len(methodResponses),
+		AllowedMethods: policy.AllowedMethods,
 	}))
 }
+
+// resolveMethodPolicy returns the payment methods accepted for the tenant and the
+// event given by ?event_id=. Every method is accepted without policies or a tenant.
+func (h *PaymentHandler) resolveMethodPolicy(c *gin.Context) (*domain.PaymentMethodPolicy, error) {
+	tenantID := c.GetHeader("X-Tenant-ID")
+	if tenantID == "" {
+		tenantID = c.GetString("tenant_id")
+	}
+	eventID := c.Query("event_id")
+	if h.methodPolicies == nil || tenantID == "" {
+		return domain.DefaultPaymentMethodPolicy(tenantID, eventID), nil
+	}
+	return h.methodPolicies.ResolvePolicy(c.Request.Context(), tenantID, eventID)
+}
+
+// handleCreateValidationError responds to payments rejected by the booking total check,
+// for invalid tax invoice details or for a payment method the event does not accept.
+// It returns false for other errors.
+func (h *PaymentHandler) handleCreateValidationError(c *gin.Context, err error) bool {
+	switch {
+	case errors.Is(err, domain.ErrBookingNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("BOOKING_NOT_FOUND", "booking not found"))
+	case errors.Is(err, domain.ErrAmountMismatch):
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("AMOUNT_MISMATCH", "payment amount does not match the booking total"))
+	case errors.Is(err, domain.ErrInvalidTaxInfo), errors.Is(err, domain.ErrInvalidTaxID):
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("INVALID_TAX_INFO", err.Error()))
+	case errors.Is(err, domain.ErrPaymentMethodNotAllowed):
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", err.Error()))
+	default:
+		return false
+	}
+	return true
+}
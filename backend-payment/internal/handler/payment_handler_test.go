@@ -13,6 +13,7 @@ import (
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/dto"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/gateway"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 )
 
 // mockPaymentService implements service.PaymentService for testing
@@ -71,7 +72,7 @@ func (m *mockPaymentService) GetPaymentByBookingID(ctx context.Context, bookingI
 	return nil, domain.ErrPaymentNotFound
 }
 
-func (m *mockPaymentService) GetUserPayments(ctx context.Context, userID string, limit, offset int) ([]*domain.Payment, error) {
+func (m *mockPaymentService) GetUserPayments(ctx context.Context, userID string, page pagination.Params) ([]*domain.Payment, pagination.Info, error) {
 	var result []*domain.Payment
 	for _, p := range m.payments {
 		if p.UserID == userID {
@@ -79,14 +80,36 @@ func (m *mockPaymentService) GetUserPayments(ctx context.Context, userID string,
 		}
 	}
 	// Apply pagination
-	if offset >= len(result) {
-		return []*domain.Payment{}, nil
+	if page.Offset >= len(result) {
+		return []*domain.Payment{}, pagination.Info{}, nil
 	}
-	end := offset + limit
+	end := page.Offset + page.Limit
 	if end > len(result) {
 		end = len(result)
 	}
-	return result[offset:end], nil
+	return result[page.Offset:end], pagination.Info{HasMore: end < len(result)}, nil
+}
+
+func (m *mockPaymentService) CapturePayment(ctx context.Context, paymentID string) (*domain.Payment, error) {
+	payment, ok := m.payments[paymentID]
+	if !ok {
+		return nil, domain.ErrPaymentNotFound
+	}
+	if err := payment.Capture(); err != nil {
+		return nil, domain.ErrInvalidPaymentStatus
+	}
+	return payment, nil
+}
+
+func (m *mockPaymentService) VoidPayment(ctx context.Context, paymentID string, reason string) (*domain.Payment, error) {
+	payment, ok := m.payments[paymentID]
+	if !ok {
+		return nil, domain.ErrPaymentNotFound
+	}
+	if err := payment.Void(reason); err != nil {
+		return nil, domain.ErrInvalidPaymentStatus
+	}
+	return payment, nil
 }
 
 func (m *mockPaymentService) RefundPayment(ctx context.Context, paymentID string, reason string) (*domain.Payment, error) {
@@ -100,6 +123,17 @@ func (m *mockPaymentService) RefundPayment(ctx context.Context, paymentID string
 	return payment, nil
 }
 
+func (m *mockPaymentService) AdjustPayment(ctx context.Context, req *service.AdjustPaymentRequest) (*domain.Payment, error) {
+	payment, ok := m.payments[req.PaymentID]
+	if !ok {
+		return nil, domain.ErrPaymentNotFound
+	}
+	if req.Amount > 0 {
+		return payment, payment.AddCharge(req.Amount)
+	}
+	return payment, payment.PartialRefund(-req.Amount, req.Reason)
+}
+
 func (m *mockPaymentService) CancelPayment(ctx context.Context, paymentID string) (*domain.Payment, error) {
 	payment, ok := m.payments[paymentID]
 	if !ok {
@@ -138,6 +172,14 @@ func (m *mockPaymentGateway) Refund(ctx context.Context, transactionID string, a
 	return nil
 }
 
+func (m *mockPaymentGateway) Capture(ctx context.Context, transactionID string, amount float64) error {
+	return nil
+}
+
+func (m *mockPaymentGateway) Void(ctx context.Context, transactionID string) error {
+	return nil
+}
+
 func (m *mockPaymentGateway) GetTransaction(ctx context.Context, transactionID string) (*gateway.TransactionInfo, error) {
 	return &gateway.TransactionInfo{
 		TransactionID: transactionID,
@@ -145,6 +187,10 @@ func (m *mockPaymentGateway) GetTransaction(ctx context.Context, transactionID s
 	}, nil
 }
 
+func (m *mockPaymentGateway) FindTransaction(ctx context.Context, paymentID string) (*gateway.TransactionInfo, error) {
+	return nil, gateway.ErrTransactionNotFound
+}
+
 func (m *mockPaymentGateway) CreatePaymentIntent(ctx context.Context, req *gateway.PaymentIntentRequest) (*gateway.PaymentIntentResponse, error) {
 	return &gateway.PaymentIntentResponse{
 		PaymentIntentID: "pi_mock_" + req.PaymentID,
@@ -190,6 +236,10 @@ func (m *mockPaymentGateway) ListPaymentMethods(ctx context.Context, customerID
 	}, nil
 }
 
+func (m *mockPaymentGateway) CreatePayout(ctx context.Context, req *gateway.PayoutRequest) (*gateway.PayoutResponse, error) {
+	return &gateway.PayoutResponse{PayoutID: "po_mock_" + req.PayoutID, Status: "pending"}, nil
+}
+
 func (m *mockPaymentGateway) Name() string {
 	return "mock"
 }
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// PaymentMethodPolicyHandler serves the payment methods accepted per tenant and event
+// to back office tooling
+type PaymentMethodPolicyHandler struct {
+	policies service.PaymentMethodPolicyService
+}
+
+// NewPaymentMethodPolicyHandler creates a new PaymentMethodPolicyHandler
+func NewPaymentMethodPolicyHandler(policies service.PaymentMethodPolicyService) *PaymentMethodPolicyHandler {
+	return &PaymentMethodPolicyHandler{policies: policies}
+}
+
+// ListPolicies handles GET /internal/payment-method-policies/:tenant_id
+func (h *PaymentMethodPolicyHandler) ListPolicies(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.payment_method_policy.list")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	span.SetAttributes(attribute.String("tenant_id", c.Param("tenant_id")))
+
+	policies, err := h.policies.ListPolicies(ctx, c.Param("tenant_id"))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("count", len(policies)))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.FromPaymentMethodPolicies(policies)))
+}
+
+// GetEventPolicy handles GET /internal/payment-method-policies/:tenant_id/events/:event_id
+// and returns the policy that applies to the event, which may be the tenant default
+func (h *PaymentMethodPolicyHandler) GetEventPolicy(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.payment_method_policy.get_event")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	span.SetAttributes(
+		attribute.String("tenant_id", c.Param("tenant_id")),
+		attribute.String("event_id", c.Param("event_id")),
+	)
+
+	policy, err := h.policies.ResolvePolicy(ctx, c.Param("tenant_id"), c.Param("event_id"))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.FromPaymentMethodPolicy(policy)))
+}
+
+// SetPolicy handles PUT /internal/payment-method-policies/:tenant_id for the tenant
+// default and PUT /internal/payment-method-policies/:tenant_id/events/:event_id for an event
+func (h *PaymentMethodPolicyHandler) SetPolicy(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.payment_method_policy.set")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	span.SetAttributes(
+		attribute.String("tenant_id", c.Param("tenant_id")),
+		attribute.String("event_id", c.Param("event_id")),
+	)
+
+	var req dto.SetPaymentMethodPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "validation error")
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	policy, err := h.policies.SetPolicy(ctx, c.Param("tenant_id"), c.Param("event_id"), req.AllowedMethods, req.UpdatedBy)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.FromPaymentMethodPolicy(policy)))
+}
+
+// DeletePolicy handles DELETE /internal/payment-method-policies/:tenant_id for the tenant
+// default and DELETE /internal/payment-method-policies/:tenant_id/events/:event_id for an event
+func (h *PaymentMethodPolicyHandler) DeletePolicy(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.payment_method_policy.delete")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	span.SetAttributes(
+		attribute.String("tenant_id", c.Param("tenant_id")),
+		attribute.String("event_id", c.Param("event_id")),
+	)
+
+	if err := h.policies.DeletePolicy(ctx, c.Param("tenant_id"), c.Param("event_id")); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(nil))
+}
+
+// handleError maps payment method policy errors to HTTP responses
+func (h *PaymentMethodPolicyHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrPaymentMethodPolicyNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("NOT_FOUND", "payment method policy not found"))
+	case errors.Is(err, domain.ErrInvalidPaymentMethodPolicy), errors.Is(err, domain.ErrInvalidPaymentMethod):
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("INTERNAL_ERROR", err.Error()))
+	}
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// PaymentSearchHandler handles internal payment lookups from booking-service's support search
+type PaymentSearchHandler struct {
+	searchService service.PaymentSearchService
+}
+
+// NewPaymentSearchHandler creates a new PaymentSearchHandler
+func NewPaymentSearchHandler(searchService service.PaymentSearchService) *PaymentSearchHandler {
+	return &PaymentSearchHandler{searchService: searchService}
+}
+
+// SearchPayments handles GET /internal/payments?booking_ids=a,b or ?last4=1234&tenant_id=&limit=
+func (h *PaymentSearchHandler) SearchPayments(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.payment_search.search")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	var (
+		payments []*domain.Payment
+		err      error
+	)
+	if bookingIDs := splitQueryList(c.Query("booking_ids")); len(bookingIDs) > 0 {
+		payments, err = h.searchService.ListByBookingIDs(ctx, bookingIDs)
+	} else if lastFour := c.Query("last4"); lastFour != "" {
+		limit, _ := strconv.Atoi(c.Query("limit"))
+		span.SetAttributes(attribute.String("tenant_id", c.Query("tenant_id")))
+		payments, err = h.searchService.ListByCardLastFour(ctx, c.Query("tenant_id"), lastFour, limit)
+	} else {
+		err = domain.ErrInvalidSearchFilter
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if errors.Is(err, domain.ErrInvalidSearchFilter) || errors.Is(err, domain.ErrInvalidCardLastFour) {
+			c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", err.Error()))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("SEARCH_FAILED", err.Error()))
+		return
+	}
+
+	paymentResponses := make([]*dto.PaymentResponse, len(payments))
+	for i, p := range payments {
+		paymentResponses[i] = dto.FromPayment(p)
+	}
+
+	span.SetAttributes(attribute.Int("count", len(paymentResponses)))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(paymentResponses))
+}
+
+// splitQueryList splits a comma-separated query value, dropping empty items
+func splitQueryList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// PayoutHandler serves tenant payout schedules and payout history to back office tooling
+type PayoutHandler struct {
+	payoutService service.PayoutService
+}
+
+// NewPayoutHandler creates a new PayoutHandler
+func NewPayoutHandler(payoutService service.PayoutService) *PayoutHandler {
+	return &PayoutHandler{payoutService: payoutService}
+}
+
+// SetSchedule handles PUT /internal/payout-schedules/:tenant_id
+func (h *PayoutHandler) SetSchedule(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.payout.set_schedule")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	span.SetAttributes(attribute.String("tenant_id", c.Param("tenant_id")))
+
+	var req dto.SetPayoutScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "validation error")
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+	schedule, err := h.payoutService.SetSchedule(ctx, &service.SetPayoutScheduleRequest{
+		TenantID:         c.Param("tenant_id"),
+		Frequency:        domain.PayoutFrequency(req.Frequency),
+		Method:           domain.PayoutMethod(req.Method),
+		Currency:         req.Currency,
+		BankAccountToken: req.BankAccountToken,
+		BankCode:         req.BankCode,
+		AccountHolder:    req.AccountHolder,
+		AccountLast4:     req.AccountLast4,
+		MinimumAmount:    req.MinimumAmount,
+		Enabled:          enabled,
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.FromPayoutSchedule(schedule)))
+}
+
+// GetSchedule handles GET /internal/payout-schedules/:tenant_id
+func (h *PayoutHandler) GetSchedule(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.payout.get_schedule")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	span.SetAttributes(attribute.String("tenant_id", c.Param("tenant_id")))
+
+	schedule, err := h.payoutService.GetSchedule(ctx, c.Param("tenant_id"))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.FromPayoutSchedule(schedule)))
+}
+
+// ListPayouts handles GET /internal/payouts?tenant_id=&status=&cursor=&limit=
+// Returns a page of the tenant's payout history, newest first
+func (h *PayoutHandler) ListPayouts(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.payout.list")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	filter := &domain.PayoutFilter{
+		TenantID: c.Query("tenant_id"),
+		Status:   domain.PayoutStatus(c.Query("status")),
+	}
+	if filter.TenantID == "" {
+		span.SetStatus(codes.Error, "tenant_id required")
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", "tenant_id is required"))
+		return
+	}
+
+	// Parse pagination; cursor takes precedence over offset
+	limit := pagination.DefaultLimit
+	offset := 0
+	if l := c.Query("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= pagination.MaxLimit {
+			limit = parsed
+		}
+	}
+	if o := c.Query("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		}
+	}
+
+	page, err := pagination.NewParams(c.Query("cursor"), limit, offset)
+	if err != nil {
+		span.SetStatus(codes.Error, "invalid cursor")
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("INVALID_CURSOR", "cursor is invalid"))
+		return
+	}
+
+	payouts, info, err := h.payoutService.ListPayouts(ctx, filter, page)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	responses := make([]*dto.PayoutResponse, len(payouts))
+	for i, payout := range payouts {
+		responses[i] = dto.FromPayout(payout)
+	}
+
+	span.SetAttributes(attribute.Int("count", len(responses)))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(&dto.PayoutListResponse{
+		Payouts: responses,
+		Total:   len(responses),
+		Info:    info,
+	}))
+}
+
+// GetPayout handles GET /internal/payouts/:id
+func (h *PayoutHandler) GetPayout(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.payout.get")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	span.SetAttributes(attribute.String("payout_id", c.Param("id")))
+
+	payout, err := h.payoutService.GetPayout(ctx, c.Param("id"))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.FromPayout(payout)))
+}
+
+// handleError maps payout errors to HTTP responses
+func (h *PayoutHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrPayoutNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("NOT_FOUND", "payout not found"))
+	case errors.Is(err, domain.ErrPayoutScheduleNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("NOT_FOUND", "payout schedule not found"))
+	case errors.Is(err, domain.ErrInvalidPayoutSchedule),
+		errors.Is(err, domain.ErrInvalidPayoutFilter):
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", err.Error()))
+	case errors.Is(err, domain.ErrFileStorageDisabled):
+		c.JSON(http.StatusNotImplemented, dto.NewErrorResponse("FILE_STORAGE_DISABLED", err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("PAYOUT_FAILED", err.Error()))
+	}
+}
@@ -0,0 +1,287 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/middleware"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// RefundApproverRoles may work through the refund approval queue and set thresholds
+var RefundApproverRoles = []string{"admin", "super_admin"}
+
+// RefundRequestHandler serves the refund approval queue and tenants' approval thresholds
+// to back office tooling
+type RefundRequestHandler struct {
+	refundRequests service.RefundRequestService
+}
+
+// NewRefundRequestHandler creates a new RefundRequestHandler
+func NewRefundRequestHandler(refundRequests service.RefundRequestService) *RefundRequestHandler {
+	return &RefundRequestHandler{refundRequests: refundRequests}
+}
+
+// ListRequests handles GET /internal/refund-requests?tenant_id=&payment_id=&status=
+// tenant_id is only honoured for super_admin; other admins see their own tenant's requests.
+func (h *RefundRequestHandler) ListRequests(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.refund_request.list")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	tenantID, ok := approverTenant(c, c.Query("tenant_id"))
+	if !ok {
+		span.SetStatus(codes.Error, "no tenant")
+		return
+	}
+
+	filter := &domain.RefundRequestFilter{
+		TenantID:  tenantID,
+		PaymentID: c.Query("payment_id"),
+		Status:    domain.RefundRequestStatus(c.Query("status")),
+	}
+	switch filter.Status {
+	case "", domain.RefundRequestStatusPendingApproval, domain.RefundRequestStatusApproved,
+		domain.RefundRequestStatusRejected, domain.RefundRequestStatusCompleted, domain.RefundRequestStatusFailed:
+	default:
+		span.SetStatus(codes.Error, "invalid status")
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", "status must be pending_approval, approved, rejected, completed or failed"))
+		return
+	}
+
+	requests, err := h.refundRequests.ListRefundRequests(ctx, filter)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	responses := make([]*dto.RefundRequestResponse, len(requests))
+	for i, request := range requests {
+		responses[i] = dto.FromRefundRequest(request)
+	}
+
+	span.SetAttributes(attribute.Int("result_count", len(responses)))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(responses))
+}
+
+// GetRequest handles GET /internal/refund-requests/:id
+func (h *RefundRequestHandler) GetRequest(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.refund_request.get")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	requestID := c.Param("id")
+	span.SetAttributes(attribute.String("refund_request_id", requestID))
+
+	request, ok := h.tenantRequest(c, requestID)
+	if !ok {
+		span.SetStatus(codes.Error, "refund request not available")
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.FromRefundRequest(request)))
+}
+
+// ApproveRequest handles POST /internal/refund-requests/:id/approve
+// Approves a pending refund and sends it to the gateway
+func (h *RefundRequestHandler) ApproveRequest(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.refund_request.approve")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	var req dto.DecideRefundRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "validation error")
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	requestID, decidedBy := c.Param("id"), c.GetString(middleware.ContextKeyUserID)
+	span.SetAttributes(
+		attribute.String("refund_request_id", requestID),
+		attribute.String("decided_by", decidedBy),
+	)
+	if _, ok := h.tenantRequest(c, requestID); !ok {
+		span.SetStatus(codes.Error, "refund request not available")
+		return
+	}
+
+	request, err := h.refundRequests.ApproveRefund(ctx, requestID, decidedBy, req.Reason)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		// Approved but the gateway refund failed; the request records why
+		if request != nil {
+			c.JSON(http.StatusBadGateway, dto.NewErrorResponse("REFUND_FAILED", err.Error()))
+			return
+		}
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.FromRefundRequest(request)))
+}
+
+// RejectRequest handles POST /internal/refund-requests/:id/reject
+func (h *RefundRequestHandler) RejectRequest(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.refund_request.reject")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	var req dto.DecideRefundRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "validation error")
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	requestID, decidedBy := c.Param("id"), c.GetString(middleware.ContextKeyUserID)
+	span.SetAttributes(
+		attribute.String("refund_request_id", requestID),
+		attribute.String("decided_by", decidedBy),
+	)
+	if _, ok := h.tenantRequest(c, requestID); !ok {
+		span.SetStatus(codes.Error, "refund request not available")
+		return
+	}
+
+	request, err := h.refundRequests.RejectRefund(ctx, requestID, decidedBy, req.Reason)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.FromRefundRequest(request)))
+}
+
+// GetPolicy handles GET /internal/refund-approval-policies/:tenant_id
+func (h *RefundRequestHandler) GetPolicy(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.refund_request.get_policy")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	span.SetAttributes(attribute.String("tenant_id", c.Param("tenant_id")))
+	if !ownTenant(c, c.Param("tenant_id")) {
+		span.SetStatus(codes.Error, "another tenant")
+		return
+	}
+
+	policy, err := h.refundRequests.GetPolicy(ctx, c.Param("tenant_id"))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.FromRefundApprovalPolicy(policy)))
+}
+
+// SetPolicy handles PUT /internal/refund-approval-policies/:tenant_id
+func (h *RefundRequestHandler) SetPolicy(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.refund_request.set_policy")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	span.SetAttributes(attribute.String("tenant_id", c.Param("tenant_id")))
+	if !ownTenant(c, c.Param("tenant_id")) {
+		span.SetStatus(codes.Error, "another tenant")
+		return
+	}
+
+	var req dto.SetRefundApprovalPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "validation error")
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	policy, err := h.refundRequests.SetPolicy(ctx, c.Param("tenant_id"), *req.Threshold, c.GetString(middleware.ContextKeyUserID))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.FromRefundApprovalPolicy(policy)))
+}
+
+// approverTenant returns the tenant whose refunds the caller may act on: the requested
+// one (any tenant when empty) for super_admin, the caller's own tenant otherwise. It
+// responds 403 when an admin has no tenant.
+func approverTenant(c *gin.Context, requested string) (string, bool) {
+	if role, _ := middleware.GetRole(c); role == "super_admin" {
+		return requested, true
+	}
+	tenantID, _ := middleware.GetTenantID(c)
+	if tenantID == "" {
+		c.JSON(http.StatusForbidden, dto.NewErrorResponse("FORBIDDEN", "refund approval requires a tenant"))
+		return "", false
+	}
+	return tenantID, true
+}
+
+// ownTenant checks that the caller may act on tenantID's refunds, responding 403 if not
+func ownTenant(c *gin.Context, tenantID string) bool {
+	allowed, ok := approverTenant(c, tenantID)
+	if !ok {
+		return false
+	}
+	if allowed != tenantID {
+		c.JSON(http.StatusForbidden, dto.NewErrorResponse("FORBIDDEN", "refunds of another tenant"))
+		return false
+	}
+	return true
+}
+
+// tenantRequest loads a refund request the caller may act on and writes the error
+// response if there is none. Another tenant's request is reported as not found.
+func (h *RefundRequestHandler) tenantRequest(c *gin.Context, requestID string) (*domain.RefundRequest, bool) {
+	tenantID, ok := approverTenant(c, "")
+	if !ok {
+		return nil, false
+	}
+	request, err := h.refundRequests.GetRefundRequest(c.Request.Context(), requestID)
+	if err == nil && tenantID != "" && request.TenantID != tenantID {
+		err = domain.ErrRefundRequestNotFound
+	}
+	if err != nil {
+		h.handleError(c, err)
+		return nil, false
+	}
+	return request, true
+}
+
+// handleError maps refund request errors to HTTP responses
+func (h *RefundRequestHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrRefundRequestNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("NOT_FOUND", "refund request not found"))
+	case errors.Is(err, domain.ErrRefundRequestNotPending), errors.Is(err, domain.ErrRefundRequestChanged):
+		c.JSON(http.StatusConflict, dto.NewErrorResponse("ALREADY_DECIDED", err.Error()))
+	case errors.Is(err, domain.ErrRefundRejectReasonRequired), errors.Is(err, domain.ErrInvalidRefundApprovalPolicy):
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("INTERNAL_ERROR", err.Error()))
+	}
+}
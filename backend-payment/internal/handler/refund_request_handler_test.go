@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/middleware"
+)
+
+// mockRefundRequestService implements service.RefundRequestService for testing
+type mockRefundRequestService struct {
+	service.RefundRequestService
+	requests  map[string]*domain.RefundRequest
+	filter    *domain.RefundRequestFilter
+	decidedBy string
+	policies  map[string]string // Tenant ID to the user who set its threshold
+}
+
+func (m *mockRefundRequestService) GetRefundRequest(ctx context.Context, requestID string) (*domain.RefundRequest, error) {
+	request, ok := m.requests[requestID]
+	if !ok {
+		return nil, domain.ErrRefundRequestNotFound
+	}
+	return request, nil
+}
+
+func (m *mockRefundRequestService) ListRefundRequests(ctx context.Context, filter *domain.RefundRequestFilter) ([]*domain.RefundRequest, error) {
+	m.filter = filter
+	return nil, nil
+}
+
+func (m *mockRefundRequestService) ApproveRefund(ctx context.Context, requestID, decidedBy, reason string) (*domain.RefundRequest, error) {
+	m.decidedBy = decidedBy
+	return m.requests[requestID], nil
+}
+
+func (m *mockRefundRequestService) SetPolicy(ctx context.Context, tenantID string, threshold float64, updatedBy string) (*domain.RefundApprovalPolicy, error) {
+	m.policies[tenantID] = updatedBy
+	return &domain.RefundApprovalPolicy{TenantID: tenantID, Threshold: threshold, UpdatedBy: updatedBy}, nil
+}
+
+func setupRefundRequestRouter(svc service.RefundRequestService, role, tenantID string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.ContextKeyUserID, "admin-1")
+		c.Set(middleware.ContextKeyRole, role)
+		c.Set(middleware.ContextKeyTenantID, tenantID)
+		c.Next()
+	})
+
+	h := NewRefundRequestHandler(svc)
+	router.GET("/internal/refund-requests", h.ListRequests)
+	router.GET("/internal/refund-requests/:id", h.GetRequest)
+	router.POST("/internal/refund-requests/:id/approve", h.ApproveRequest)
+	router.PUT("/internal/refund-approval-policies/:tenant_id", h.SetPolicy)
+	return router
+}
+
+func TestRefundRequestHandler_TenantScoping(t *testing.T) {
+	newService := func() *mockRefundRequestService {
+		return &mockRefundRequestService{
+			requests: map[string]*domain.RefundRequest{
+				"request-1": {ID: "request-1", TenantID: "tenant-1", Status: domain.RefundRequestStatusPendingApproval},
+			},
+			policies: map[string]string{},
+		}
+	}
+	serve := func(router *gin.Engine, method, path string, body interface{}) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(body)
+		req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("admins list their own tenant", func(t *testing.T) {
+		svc := newService()
+		w := serve(setupRefundRequestRouter(svc, "admin", "tenant-1"), http.MethodGet, "/internal/refund-requests?tenant_id=tenant-2", nil)
+		if w.Code != http.StatusOK || svc.filter.TenantID != "tenant-1" {
+			t.Errorf("list = %d for tenant %q, want 200 for tenant-1", w.Code, svc.filter.TenantID)
+		}
+	})
+
+	t.Run("super admins list any tenant", func(t *testing.T) {
+		svc := newService()
+		w := serve(setupRefundRequestRouter(svc, "super_admin", ""), http.MethodGet, "/internal/refund-requests?tenant_id=tenant-2", nil)
+		if w.Code != http.StatusOK || svc.filter.TenantID != "tenant-2" {
+			t.Errorf("list = %d for tenant %q, want 200 for tenant-2", w.Code, svc.filter.TenantID)
+		}
+	})
+
+	t.Run("admins without a tenant are refused", func(t *testing.T) {
+		w := serve(setupRefundRequestRouter(newService(), "admin", ""), http.MethodGet, "/internal/refund-requests", nil)
+		if w.Code != http.StatusForbidden {
+			t.Errorf("list = %d, want %d", w.Code, http.StatusForbidden)
+		}
+	})
+
+	t.Run("another tenant's request is not found", func(t *testing.T) {
+		svc := newService()
+		router := setupRefundRequestRouter(svc, "admin", "tenant-2")
+		if w := serve(router, http.MethodGet, "/internal/refund-requests/request-1", nil); w.Code != http.StatusNotFound {
+			t.Errorf("get = %d, want %d", w.Code, http.StatusNotFound)
+		}
+		if w := serve(router, http.MethodPost, "/internal/refund-requests/request-1/approve", map[string]string{}); w.Code != http.StatusNotFound || svc.decidedBy != "" {
+			t.Errorf("approve = %d decided by %q, want %d and no decision", w.Code, svc.decidedBy, http.StatusNotFound)
+		}
+	})
+
+	t.Run("decisions are recorded under the verified caller", func(t *testing.T) {
+		svc := newService()
+		w := serve(setupRefundRequestRouter(svc, "admin", "tenant-1"), http.MethodPost, "/internal/refund-requests/request-1/approve",
+			map[string]string{"decided_by": "someone-else"})
+		if w.Code != http.StatusOK || svc.decidedBy != "admin-1" {
+			t.Errorf("approve = %d decided by %q, want 200 by admin-1", w.Code, svc.decidedBy)
+		}
+	})
+
+	t.Run("admins set only their own tenant's threshold", func(t *testing.T) {
+		svc := newService()
+		router := setupRefundRequestRouter(svc, "admin", "tenant-1")
+		if w := serve(router, http.MethodPut, "/internal/refund-approval-policies/tenant-2", map[string]float64{"threshold": 0}); w.Code != http.StatusForbidden {
+			t.Errorf("set another tenant's policy = %d, want %d", w.Code, http.StatusForbidden)
+		}
+		if w := serve(router, http.MethodPut, "/internal/refund-approval-policies/tenant-1", map[string]float64{"threshold": 5000}); w.Code != http.StatusOK || svc.policies["tenant-1"] != "admin-1" {
+			t.Errorf("set own policy = %d by %q, want 200 by admin-1", w.Code, svc.policies["tenant-1"])
+		}
+		if _, ok := svc.policies["tenant-2"]; ok {
+			t.Error("another tenant's policy was set")
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// RefundReviewHandler serves the queue of gateway refunds flagged for manual review
+type RefundReviewHandler struct {
+	refundService service.RefundReconciliationService
+}
+
+// NewRefundReviewHandler creates a new RefundReviewHandler
+func NewRefundReviewHandler(refundService service.RefundReconciliationService) *RefundReviewHandler {
+	return &RefundReviewHandler{refundService: refundService}
+}
+
+// ListReviews handles GET /internal/refund-reviews?status=open|resolved
+func (h *RefundReviewHandler) ListReviews(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.refund_review.list")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	status := domain.RefundReviewStatus(c.Query("status"))
+	switch status {
+	case "", domain.RefundReviewStatusOpen, domain.RefundReviewStatusResolved:
+	default:
+		span.SetStatus(codes.Error, "invalid status")
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", "status must be open or resolved"))
+		return
+	}
+
+	reviews, err := h.refundService.ListReviews(ctx, status)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	responses := make([]*dto.RefundReviewResponse, len(reviews))
+	for i, review := range reviews {
+		responses[i] = dto.FromRefundReview(review)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(responses))
+}
+
+// ResolveReview handles POST /internal/refund-reviews/:id/resolve
+func (h *RefundReviewHandler) ResolveReview(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.refund_review.resolve")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	var req dto.ResolveRefundReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "validation error")
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	reviewID := c.Param("id")
+	span.SetAttributes(attribute.String("review_id", reviewID))
+
+	review, err := h.refundService.ResolveReview(ctx, reviewID, req.ResolvedBy, req.Note)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err)
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(dto.FromRefundReview(review)))
+}
+
+// handleError maps refund review errors to HTTP responses
+func (h *RefundReviewHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrRefundReviewNotFound):
+		c.JSON(http.StatusNotFound, dto.NewErrorResponse("NOT_FOUND", "refund review not found"))
+	case errors.Is(err, domain.ErrRefundReviewResolved):
+		c.JSON(http.StatusConflict, dto.NewErrorResponse("ALREADY_RESOLVED", err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("INTERNAL_ERROR", err.Error()))
+	}
+}
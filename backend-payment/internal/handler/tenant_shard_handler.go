@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// TenantShardHandler shows and assigns the database shards of tenants to back office tooling
+type TenantShardHandler struct {
+	resolver *database.ShardResolver
+}
+
+// NewTenantShardHandler creates a new TenantShardHandler
+func NewTenantShardHandler(resolver *database.ShardResolver) *TenantShardHandler {
+	return &TenantShardHandler{resolver: resolver}
+}
+
+// List handles GET /internal/tenant-shards
+func (h *TenantShardHandler) List(c *gin.Context) {
+	_, span := telemetry.StartSpan(c.Request.Context(), "handler.tenant_shards.list")
+	defer span.End()
+
+	resp := dto.TenantShardsResponse{
+		Shards:      h.resolver.Shards(),
+		Assignments: h.resolver.Assignments(),
+	}
+	span.SetAttributes(attribute.Int("assignments", len(resp.Assignments)))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(resp))
+}
+
+// Assign handles PUT /internal/tenant-shards/:tenant_id
+// Existing payments of the tenant are not moved, and other replicas follow within the
+// shard refresh interval
+func (h *TenantShardHandler) Assign(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.tenant_shards.assign")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	tenantID := c.Param("tenant_id")
+	span.SetAttributes(attribute.String("tenant_id", tenantID))
+
+	var req dto.AssignTenantShardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "validation error")
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	assignment, err := h.resolver.Assign(ctx, tenantID, req.Shard)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if errors.Is(err, database.ErrUnknownShard) {
+			c.JSON(http.StatusBadRequest, dto.NewErrorResponse("UNKNOWN_SHARD", err.Error()))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("INTERNAL_ERROR", "Failed to assign tenant shard"))
+		return
+	}
+
+	span.SetAttributes(attribute.String("shard", assignment.Shard))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(assignment))
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// UserDataHandler handles internal user data export/anonymization requests from auth-service
+type UserDataHandler struct {
+	userDataService service.UserDataService
+}
+
+// NewUserDataHandler creates a new UserDataHandler
+func NewUserDataHandler(userDataService service.UserDataService) *UserDataHandler {
+	return &UserDataHandler{userDataService: userDataService}
+}
+
+// ExportUserData handles GET /internal/users/:user_id/data
+func (h *UserDataHandler) ExportUserData(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.user_data.export")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	userID := c.Param("user_id")
+	span.SetAttributes(attribute.String("user_id", userID))
+
+	payments, err := h.userDataService.ExportUserData(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if errors.Is(err, domain.ErrInvalidUserID) {
+			c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", err.Error()))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("EXPORT_FAILED", err.Error()))
+		return
+	}
+
+	// Convert to response
+	paymentResponses := make([]*dto.PaymentResponse, len(payments))
+	for i, p := range payments {
+		paymentResponses[i] = dto.FromPayment(p)
+	}
+
+	span.SetAttributes(attribute.Int("count", len(paymentResponses)))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(paymentResponses))
+}
+
+// AnonymizeUserData handles POST /internal/users/:user_id/anonymize
+func (h *UserDataHandler) AnonymizeUserData(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.user_data.anonymize")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	userID := c.Param("user_id")
+	span.SetAttributes(attribute.String("user_id", userID))
+
+	var req dto.AnonymizeUserDataRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "validation error")
+		c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", err.Error()))
+		return
+	}
+
+	count, err := h.userDataService.AnonymizeUserData(ctx, userID, req.AnonymizedID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if errors.Is(err, domain.ErrInvalidUserID) {
+			c.JSON(http.StatusBadRequest, dto.NewErrorResponse("VALIDATION_ERROR", err.Error()))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dto.NewErrorResponse("ANONYMIZE_FAILED", err.Error()))
+		return
+	}
+
+	span.SetAttributes(attribute.Int64("anonymized", count))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, dto.NewSuccessResponse(&dto.AnonymizeUserDataResponse{
+		UserID:       userID,
+		AnonymizedID: req.AnonymizedID,
+		Anonymized:   count,
+	}))
+}
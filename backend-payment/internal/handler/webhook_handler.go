@@ -6,11 +6,12 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"time"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/dto"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/events"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
 	"github.com/stripe/stripe-go/v82"
@@ -20,14 +21,16 @@ import (
 // WebhookHandler handles Stripe webhook events
 type WebhookHandler struct {
 	paymentService service.PaymentService
+	refundService  service.RefundReconciliationService
 	webhookSecret  string
 	kafkaProducer  *kafka.Producer
 }
 
 // NewWebhookHandler creates a new WebhookHandler
-func NewWebhookHandler(paymentService service.PaymentService, webhookSecret string, kafkaProducer *kafka.Producer) *WebhookHandler {
+func NewWebhookHandler(paymentService service.PaymentService, refundService service.RefundReconciliationService, webhookSecret string, kafkaProducer *kafka.Producer) *WebhookHandler {
 	return &WebhookHandler{
 		paymentService: paymentService,
+		refundService:  refundService,
 		webhookSecret:  webhookSecret,
 		kafkaProducer:  kafkaProducer,
 	}
@@ -114,30 +117,26 @@ func (h *WebhookHandler) handlePaymentIntentSucceeded(c *gin.Context, event stri
 	// Publish payment.success event to trigger post-payment saga
 	// This will confirm the booking and remove TTL from Redis
 	if bookingID != "" {
-		// Extract enriched metadata from Stripe PaymentIntent
-		metadata := paymentIntent.Metadata
-		h.publishPaymentSuccessEvent(c.Request.Context(), &dto.PaymentSuccessEvent{
-			EventType:             "payment.success",
-			BookingID:             bookingID,
-			PaymentID:             paymentID,
-			StripePaymentIntentID: paymentIntent.ID,
-			UserID:                userID,
-			Amount:                paymentIntent.Amount,
-			Currency:              string(paymentIntent.Currency),
+		event, err := events.NewPaymentSuccessEvent(bookingID, paymentID, paymentIntent.ID, userID, paymentIntent.Amount, string(paymentIntent.Currency))
+		if err != nil {
+			log.Error(fmt.Sprintf("Failed to build payment success event: %v", err))
+		} else {
 			// Enriched data from Stripe metadata
-			UserEmail:    metadata["user_email"],
-			EventID:      metadata["event_id"],
-			EventName:    metadata["event_name"],
-			ShowID:       metadata["show_id"],
-			ShowDate:     metadata["show_date"],
-			ZoneID:       metadata["zone_id"],
-			ZoneName:     metadata["zone_name"],
-			Quantity:     parseIntFromMetadata(metadata["quantity"]),
-			UnitPrice:    parseFloatFromMetadata(metadata["unit_price"]),
-			TotalPrice:   float64(paymentIntent.Amount) / 100, // Convert from satang to baht
-			VenueName:    metadata["venue_name"],
-			VenueAddress: metadata["venue_address"],
-		})
+			metadata := paymentIntent.Metadata
+			event.UserEmail = metadata["user_email"]
+			event.EventID = metadata["event_id"]
+			event.EventName = metadata["event_name"]
+			event.ShowID = metadata["show_id"]
+			event.ShowDate = metadata["show_date"]
+			event.ZoneID = metadata["zone_id"]
+			event.ZoneName = metadata["zone_name"]
+			event.Quantity = parseIntFromMetadata(metadata["quantity"])
+			event.UnitPrice = parseFloatFromMetadata(metadata["unit_price"])
+			event.TotalPrice = float64(paymentIntent.Amount) / 100 // Convert from satang to baht
+			event.VenueName = metadata["venue_name"]
+			event.VenueAddress = metadata["venue_address"]
+			h.publishPaymentSuccessEvent(c.Request.Context(), event)
+		}
 	}
 
 	c.JSON(http.StatusOK, gin.H{"received": true})
@@ -217,7 +216,9 @@ func (h *WebhookHandler) handlePaymentIntentCanceled(c *gin.Context, event strip
 	c.JSON(http.StatusOK, gin.H{"received": true})
 }
 
-// handleChargeRefunded handles refunded charge
+// handleChargeRefunded reconciles a refunded charge with its payment. The charge reports
+// the total refunded so far, which covers refunds this service made as well as ones made
+// from the Stripe dashboard; only the part not yet recorded is applied.
 func (h *WebhookHandler) handleChargeRefunded(c *gin.Context, event stripe.Event) {
 	log := logger.Get()
 
@@ -228,23 +229,46 @@ func (h *WebhookHandler) handleChargeRefunded(c *gin.Context, event stripe.Event
 		return
 	}
 
-	paymentID := charge.Metadata["payment_id"]
-	bookingID := charge.Metadata["booking_id"]
+	refund := &service.GatewayRefund{
+		ChargeID:       charge.ID,
+		PaymentID:      charge.Metadata["payment_id"],
+		AmountRefunded: float64(charge.AmountRefunded) / 100, // Convert from satang to baht
+		Currency:       strings.ToUpper(string(charge.Currency)),
+	}
+	if charge.PaymentIntent != nil {
+		refund.GatewayPaymentID = charge.PaymentIntent.ID
+	}
 
-	log.Info(fmt.Sprintf("Charge refunded: payment_id=%s, booking_id=%s, amount_refunded=%d",
-		paymentID, bookingID, charge.AmountRefunded))
+	log.Info(fmt.Sprintf("Charge refunded: charge_id=%s, payment_intent=%s, payment_id=%s, amount_refunded=%d",
+		refund.ChargeID, refund.GatewayPaymentID, refund.PaymentID, charge.AmountRefunded))
 
-	// Refund the payment if we have payment_id
-	if paymentID != "" {
-		_, err := h.paymentService.RefundPayment(c.Request.Context(), paymentID, "stripe_webhook_refund")
-		if err != nil {
-			log.Error(fmt.Sprintf("Failed to refund payment %s: %v", paymentID, err))
-		}
+	if h.refundService == nil {
+		log.Warn(fmt.Sprintf("Refund reconciliation not configured, charge %s not reconciled", charge.ID))
+		c.JSON(http.StatusOK, gin.H{"received": true})
+		return
 	}
 
-	// Trigger seat release via Kafka event to booking-service
-	if bookingID != "" {
-		h.publishSeatReleaseEvent(c.Request.Context(), bookingID, paymentID, dto.SeatReleaseReasonPaymentRefunded, "PAYMENT_REFUNDED", "Payment was refunded")
+	result, err := h.refundService.ReconcileRefund(c.Request.Context(), refund)
+	if err != nil {
+		// Not acknowledged, so Stripe delivers the event again
+		log.Error(fmt.Sprintf("Failed to reconcile refund of charge %s: %v", charge.ID, err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reconcile refund"})
+		return
+	}
+
+	switch {
+	case result.Review != nil:
+		log.Warn(fmt.Sprintf("Refund of charge %s flagged for manual review: review_id=%s, reason=%s, payment_id=%s",
+			charge.ID, result.Review.ID, result.Review.Reason, result.Review.PaymentID))
+	case result.Recorded > 0:
+		log.Info(fmt.Sprintf("Recorded gateway refund of %.2f on payment %s, status: %s",
+			result.Recorded, result.Payment.ID, result.Payment.Status))
+	}
+
+	// A fully refunded payment no longer pays for its booking, so booking-service
+	// releases the seats and cancels it. Partial refunds keep the booking.
+	if result.FullyRefunded {
+		h.publishSeatReleaseEvent(c.Request.Context(), result.Payment.BookingID, result.Payment.ID, dto.SeatReleaseReasonPaymentRefunded, "PAYMENT_REFUNDED", "Payment was refunded")
 	}
 
 	c.JSON(http.StatusOK, gin.H{"received": true})
@@ -259,14 +283,10 @@ func (h *WebhookHandler) publishSeatReleaseEvent(ctx context.Context, bookingID,
 		return
 	}
 
-	event := &dto.SeatReleaseEvent{
-		EventType:   "seat_release",
-		BookingID:   bookingID,
-		PaymentID:   paymentID,
-		Reason:      reason,
-		FailureCode: failureCode,
-		Message:     message,
-		Timestamp:   time.Now().UTC(),
+	event, err := events.NewSeatReleaseEvent(bookingID, paymentID, "", reason, failureCode, message)
+	if err != nil {
+		log.Error(fmt.Sprintf("Failed to build seat release event: %v", err))
+		return
 	}
 
 	if err := h.kafkaProducer.ProduceJSON(ctx, dto.TopicSeatRelease, event.Key(), event, nil); err != nil {
@@ -307,9 +327,6 @@ func (h *WebhookHandler) publishPaymentSuccessEvent(ctx context.Context, event *
 		return
 	}
 
-	// Set timestamp
-	event.Timestamp = time.Now().UTC()
-
 	if err := h.kafkaProducer.ProduceJSON(ctx, dto.TopicPaymentSuccess, event.Key(), event, nil); err != nil {
 		log.Error(fmt.Sprintf("Failed to publish payment success event: %v", err))
 		return
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/service"
+	"github.com/stripe/stripe-go/v82"
+	"github.com/stripe/stripe-go/v82/webhook"
+)
+
+const testWebhookSecret = "whsec_test"
+
+// signedStripeEvent builds a webhook request the way Stripe signs it
+func signedStripeEvent(t *testing.T, eventType string, object any) *http.Request {
+	t.Helper()
+
+	raw, err := json.Marshal(object)
+	if err != nil {
+		t.Fatalf("failed to marshal event object: %v", err)
+	}
+	payload, err := json.Marshal(map[string]any{
+		"id":          "evt_test",
+		"object":      "event",
+		"type":        eventType,
+		"api_version": stripe.APIVersion,
+		"data":        map[string]json.RawMessage{"object": raw},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal event: %v", err)
+	}
+
+	now := time.Now()
+	signature := webhook.ComputeSignature(now, payload, testWebhookSecret)
+	req, _ := http.NewRequest(http.MethodPost, "/webhooks/stripe", bytes.NewBuffer(payload))
+	req.Header.Set("Stripe-Signature", fmt.Sprintf("t=%d,v1=%x", now.Unix(), signature))
+	return req
+}
+
+func TestWebhookHandler_ChargeRefunded(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	paymentRepo := repository.NewMemoryPaymentRepository()
+	payment, _ := domain.NewPayment("tenant-1", "booking-1", "user-1", 1000, "THB", domain.PaymentMethodCreditCard)
+	payment.Complete("pi_1")
+	paymentRepo.Create(ctx, payment)
+
+	refunds := service.NewRefundReconciliationService(paymentRepo, repository.NewMemoryRefundReviewRepository(), nil)
+	h := NewWebhookHandler(nil, refunds, testWebhookSecret, nil)
+	router := gin.New()
+	router.POST("/webhooks/stripe", h.HandleStripeWebhook)
+
+	charge := map[string]any{
+		"id":              "ch_1",
+		"object":          "charge",
+		"amount":          100000,
+		"amount_refunded": 25000, // Partial refund from the Stripe dashboard
+		"currency":        "thb",
+		"payment_intent":  "pi_1",
+	}
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, signedStripeEvent(t, "charge.refunded", charge))
+	if resp.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, resp.Code, resp.Body.String())
+	}
+
+	stored, _ := paymentRepo.GetByID(ctx, payment.ID)
+	if stored.Status != domain.PaymentStatusSucceeded || stored.RefundedAmount() != 250 {
+		t.Errorf("expected succeeded payment with 250.00 refunded, got %s with %.2f", stored.Status, stored.RefundedAmount())
+	}
+
+	// A charge nothing matches is flagged, not refunded
+	charge["id"], charge["payment_intent"] = "ch_2", "pi_unknown"
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, signedStripeEvent(t, "charge.refunded", charge))
+	if resp.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, resp.Code, resp.Body.String())
+	}
+	reviews, _ := refunds.ListReviews(ctx, domain.RefundReviewStatusOpen)
+	if len(reviews) != 1 || reviews[0].GatewayChargeID != "ch_2" || reviews[0].AmountRefunded != 250 {
+		t.Errorf("expected ch_2 flagged for review, got %+v", reviews)
+	}
+}
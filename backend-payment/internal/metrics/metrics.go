@@ -15,6 +15,13 @@ var (
 	PaymentsFailed    *telemetry.Counter
 	PaymentsRefunded  *telemetry.Counter
 	PaymentsCancelled *telemetry.Counter
+	PaymentsCaptured  *telemetry.Counter
+	PaymentsVoided    *telemetry.Counter
+	PaymentsUnstuck   *telemetry.Counter
+
+	// Gateway call counters
+	GatewayHedges         *telemetry.Counter
+	GatewayBudgetExceeded *telemetry.Counter
 
 	// Webhook counters
 	WebhooksReceived  *telemetry.Counter
@@ -95,6 +102,52 @@ func initMetrics() error {
 		return err
 	}
 
+	PaymentsCaptured, err = telemetry.NewCounter(telemetry.MetricOpts{
+		Name:        "payment_captured_total",
+		Description: "Total number of authorized payments captured",
+		Unit:        "1",
+	})
+	if err != nil {
+		return err
+	}
+
+	PaymentsVoided, err = telemetry.NewCounter(telemetry.MetricOpts{
+		Name:        "payment_voided_total",
+		Description: "Total number of authorized payments voided",
+		Unit:        "1",
+	})
+	if err != nil {
+		return err
+	}
+
+	PaymentsUnstuck, err = telemetry.NewCounter(telemetry.MetricOpts{
+		Name:        "payment_stuck_resolved_total",
+		Description: "Total number of payments stuck processing resolved by the payment watchdog",
+		Unit:        "1",
+	})
+	if err != nil {
+		return err
+	}
+
+	// Gateway call counters
+	GatewayHedges, err = telemetry.NewCounter(telemetry.MetricOpts{
+		Name:        "payment_gateway_hedges_total",
+		Description: "Total number of hedged gateway reads by the attempt that answered",
+		Unit:        "1",
+	})
+	if err != nil {
+		return err
+	}
+
+	GatewayBudgetExceeded, err = telemetry.NewCounter(telemetry.MetricOpts{
+		Name:        "payment_gateway_budget_exceeded_total",
+		Description: "Total number of gateway calls abandoned at their timeout budget",
+		Unit:        "1",
+	})
+	if err != nil {
+		return err
+	}
+
 	// Webhook counters
 	WebhooksReceived, err = telemetry.NewCounter(telemetry.MetricOpts{
 		Name:        "payment_webhooks_received_total",
@@ -267,6 +320,53 @@ func RecordPaymentCancelled(ctx context.Context, bookingID string) {
 	}
 }
 
+// RecordPaymentCaptured records an authorized payment capture metric
+func RecordPaymentCaptured(ctx context.Context, bookingID string, amount float64) {
+	if PaymentsCaptured != nil {
+		PaymentsCaptured.Inc(ctx,
+			attribute.String("booking_id", bookingID),
+		)
+	}
+}
+
+// RecordPaymentVoided records an authorized payment void metric
+func RecordPaymentVoided(ctx context.Context, bookingID, reason string) {
+	if PaymentsVoided != nil {
+		PaymentsVoided.Inc(ctx,
+			attribute.String("booking_id", bookingID),
+			attribute.String("reason", reason),
+		)
+	}
+}
+
+// RecordStuckPaymentResolved records a stuck payment resolved by the payment watchdog
+func RecordStuckPaymentResolved(ctx context.Context, resolution string) {
+	if PaymentsUnstuck != nil {
+		PaymentsUnstuck.Inc(ctx,
+			attribute.String("resolution", resolution),
+		)
+	}
+}
+
+// RecordGatewayHedge records a gateway read that sent a second attempt and which attempt answered
+func RecordGatewayHedge(ctx context.Context, operation, winner string) {
+	if GatewayHedges != nil {
+		GatewayHedges.Inc(ctx,
+			attribute.String("operation", operation),
+			attribute.String("winner", winner),
+		)
+	}
+}
+
+// RecordGatewayBudgetExceeded records a gateway call abandoned at its timeout budget
+func RecordGatewayBudgetExceeded(ctx context.Context, operation string) {
+	if GatewayBudgetExceeded != nil {
+		GatewayBudgetExceeded.Inc(ctx,
+			attribute.String("operation", operation),
+		)
+	}
+}
+
 // RecordWebhookReceived records a webhook receipt metric
 func RecordWebhookReceived(ctx context.Context, eventType string) {
 	if WebhooksReceived != nil {
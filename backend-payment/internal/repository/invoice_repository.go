@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+)
+
+// InvoiceRepository stores tax invoices and hands out their running numbers
+type InvoiceRepository interface {
+	// Create assigns the next running number of the invoice's tenant and period and saves
+	// the invoice. Returns ErrInvoiceAlreadyExists if the payment already has an invoice.
+	Create(ctx context.Context, invoice *domain.Invoice) error
+
+	// GetByID retrieves an invoice by its ID
+	GetByID(ctx context.Context, id string) (*domain.Invoice, error)
+
+	// GetByPaymentID retrieves the invoice issued for a payment
+	GetByPaymentID(ctx context.Context, paymentID string) (*domain.Invoice, error)
+
+	// ListIssued returns a tenant's invoices issued in [from, to), ordered by number
+	ListIssued(ctx context.Context, tenantID string, from, to time.Time) ([]*domain.Invoice, error)
+}
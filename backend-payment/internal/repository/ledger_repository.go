@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
+)
+
+// LedgerRepository stores double-entry ledger transactions
+type LedgerRepository interface {
+	// Create saves a transaction and its entries atomically. Returns
+	// ErrLedgerTransactionExists if a transaction with the same idempotency key exists.
+	Create(ctx context.Context, tx *domain.LedgerTransaction) error
+
+	// GetByID retrieves a transaction and its entries by ID
+	GetByID(ctx context.Context, id string) (*domain.LedgerTransaction, error)
+
+	// GetByIdempotencyKey retrieves the transaction recorded for a business event
+	GetByIdempotencyKey(ctx context.Context, key string) (*domain.LedgerTransaction, error)
+
+	// GetBalances returns a tenant's balance per account and currency over the entries
+	// that occurred before asOf (all entries when zero)
+	GetBalances(ctx context.Context, tenantID string, asOf time.Time) ([]*domain.LedgerBalance, error)
+
+	// ListEntries returns a page of the entries selected by filter, newest first
+	ListEntries(ctx context.Context, filter *domain.LedgerEntryFilter, page pagination.Params) ([]*domain.LedgerEntry, error)
+}
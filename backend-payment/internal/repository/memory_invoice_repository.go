@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+)
+
+// MemoryInvoiceRepository implements InvoiceRepository using in-memory storage
+// This is useful for testing and development
+type MemoryInvoiceRepository struct {
+	invoices  map[string]*domain.Invoice
+	byPayment map[string]string // paymentID -> invoiceID
+	sequences map[string]int64  // tenantID/period -> last running number
+	mu        sync.RWMutex
+}
+
+// NewMemoryInvoiceRepository creates a new in-memory invoice repository
+func NewMemoryInvoiceRepository() *MemoryInvoiceRepository {
+	return &MemoryInvoiceRepository{
+		invoices:  make(map[string]*domain.Invoice),
+		byPayment: make(map[string]string),
+		sequences: make(map[string]int64),
+	}
+}
+
+// Create assigns the next running number of the invoice's tenant and period and saves it
+func (r *MemoryInvoiceRepository) Create(ctx context.Context, invoice *domain.Invoice) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.byPayment[invoice.PaymentID]; exists {
+		return domain.ErrInvoiceAlreadyExists
+	}
+
+	key := invoice.TenantID + "/" + invoice.Period
+	r.sequences[key]++
+	invoice.AssignNumber(r.sequences[key])
+
+	inv := *invoice
+	r.invoices[invoice.ID] = &inv
+	r.byPayment[invoice.PaymentID] = invoice.ID
+	return nil
+}
+
+// GetByID retrieves an invoice by its ID
+func (r *MemoryInvoiceRepository) GetByID(ctx context.Context, id string) (*domain.Invoice, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	invoice, exists := r.invoices[id]
+	if !exists {
+		return nil, domain.ErrInvoiceNotFound
+	}
+	inv := *invoice
+	return &inv, nil
+}
+
+// GetByPaymentID retrieves the invoice issued for a payment
+func (r *MemoryInvoiceRepository) GetByPaymentID(ctx context.Context, paymentID string) (*domain.Invoice, error) {
+	r.mu.RLock()
+	id, exists := r.byPayment[paymentID]
+	r.mu.RUnlock()
+	if !exists {
+		return nil, domain.ErrInvoiceNotFound
+	}
+	return r.GetByID(ctx, id)
+}
+
+// ListIssued returns a tenant's invoices issued in [from, to), ordered by number
+func (r *MemoryInvoiceRepository) ListIssued(ctx context.Context, tenantID string, from, to time.Time) ([]*domain.Invoice, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var invoices []*domain.Invoice
+	for _, invoice := range r.invoices {
+		if invoice.TenantID != tenantID || invoice.IssuedAt.Before(from) || !invoice.IssuedAt.Before(to) {
+			continue
+		}
+		inv := *invoice
+		invoices = append(invoices, &inv)
+	}
+	sort.Slice(invoices, func(i, j int) bool {
+		if invoices[i].Period != invoices[j].Period {
+			return invoices[i].Period < invoices[j].Period
+		}
+		return invoices[i].Sequence < invoices[j].Sequence
+	})
+	return invoices, nil
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
+)
+
+// MemoryLedgerRepository implements LedgerRepository using in-memory storage
+// This is useful for testing and development
+type MemoryLedgerRepository struct {
+	transactions map[string]*domain.LedgerTransaction
+	byKey        map[string]string // idempotency key -> transactionID
+	entries      []*domain.LedgerEntry
+	mu           sync.RWMutex
+}
+
+// NewMemoryLedgerRepository creates a new in-memory ledger repository
+func NewMemoryLedgerRepository() *MemoryLedgerRepository {
+	return &MemoryLedgerRepository{
+		transactions: make(map[string]*domain.LedgerTransaction),
+		byKey:        make(map[string]string),
+	}
+}
+
+// Create saves a transaction and its entries
+func (r *MemoryLedgerRepository) Create(ctx context.Context, tx *domain.LedgerTransaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.byKey[tx.IdempotencyKey]; exists {
+		return domain.ErrLedgerTransactionExists
+	}
+
+	r.transactions[tx.ID] = copyLedgerTransaction(tx)
+	r.byKey[tx.IdempotencyKey] = tx.ID
+	for _, entry := range tx.Entries {
+		e := *entry
+		r.entries = append(r.entries, &e)
+	}
+	return nil
+}
+
+// GetByID retrieves a transaction by its ID
+func (r *MemoryLedgerRepository) GetByID(ctx context.Context, id string) (*domain.LedgerTransaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tx, exists := r.transactions[id]
+	if !exists {
+		return nil, domain.ErrLedgerTransactionNotFound
+	}
+	return copyLedgerTransaction(tx), nil
+}
+
+// GetByIdempotencyKey retrieves the transaction recorded for a business event
+func (r *MemoryLedgerRepository) GetByIdempotencyKey(ctx context.Context, key string) (*domain.LedgerTransaction, error) {
+	r.mu.RLock()
+	id, exists := r.byKey[key]
+	r.mu.RUnlock()
+	if !exists {
+		return nil, domain.ErrLedgerTransactionNotFound
+	}
+	return r.GetByID(ctx, id)
+}
+
+// GetBalances returns a tenant's balance per account and currency
+func (r *MemoryLedgerRepository) GetBalances(ctx context.Context, tenantID string, asOf time.Time) ([]*domain.LedgerBalance, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	balances := make(map[[2]string]*domain.LedgerBalance)
+	for _, entry := range r.entries {
+		if entry.TenantID != tenantID || (!asOf.IsZero() && !entry.OccurredAt.Before(asOf)) {
+			continue
+		}
+		key := [2]string{string(entry.Account), entry.Currency}
+		balance, ok := balances[key]
+		if !ok {
+			balance = &domain.LedgerBalance{TenantID: tenantID, Account: entry.Account, Currency: entry.Currency}
+			balances[key] = balance
+		}
+		if entry.Direction == domain.LedgerDebit {
+			balance.Debits += entry.Amount
+		} else {
+			balance.Credits += entry.Amount
+		}
+	}
+
+	// Same order as the Postgres repository
+	result := make([]*domain.LedgerBalance, 0, len(balances))
+	for _, balance := range balances {
+		result = append(result, balance)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Account != result[j].Account {
+			return result[i].Account < result[j].Account
+		}
+		return result[i].Currency < result[j].Currency
+	})
+	return result, nil
+}
+
+// ListEntries returns a page of the entries selected by filter, newest first
+func (r *MemoryLedgerRepository) ListEntries(ctx context.Context, filter *domain.LedgerEntryFilter, page pagination.Params) ([]*domain.LedgerEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var entries []*domain.LedgerEntry
+	for _, entry := range r.entries {
+		if filter.Matches(entry) && (page.After == nil || page.After.Admits(entry.OccurredAt, entry.ID)) {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].OccurredAt.Equal(entries[j].OccurredAt) {
+			return entries[i].ID > entries[j].ID
+		}
+		return entries[i].OccurredAt.After(entries[j].OccurredAt)
+	})
+
+	start := page.Offset
+	if start >= len(entries) {
+		return []*domain.LedgerEntry{}, nil
+	}
+	end := start + page.Limit
+	if end > len(entries) {
+		end = len(entries)
+	}
+
+	result := make([]*domain.LedgerEntry, 0, end-start)
+	for _, entry := range entries[start:end] {
+		e := *entry
+		result = append(result, &e)
+	}
+	return result, nil
+}
+
+// copyLedgerTransaction copies a transaction and its entries
+func copyLedgerTransaction(tx *domain.LedgerTransaction) *domain.LedgerTransaction {
+	t := *tx
+	t.Entries = make([]*domain.LedgerEntry, len(tx.Entries))
+	for i, entry := range tx.Entries {
+		e := *entry
+		t.Entries[i] = &e
+	}
+	return &t
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+)
+
+// paymentMethodPolicyKey identifies the policy of a tenant or event
+type paymentMethodPolicyKey struct {
+	tenantID string
+	eventID  string
+}
+
+// MemoryPaymentMethodPolicyRepository implements PaymentMethodPolicyRepository using in-memory storage
+// This is useful for testing and development
+type MemoryPaymentMethodPolicyRepository struct {
+	policies map[paymentMethodPolicyKey]*domain.PaymentMethodPolicy
+	mu       sync.RWMutex
+}
+
+// NewMemoryPaymentMethodPolicyRepository creates a new in-memory payment method policy repository
+func NewMemoryPaymentMethodPolicyRepository() *MemoryPaymentMethodPolicyRepository {
+	return &MemoryPaymentMethodPolicyRepository{
+		policies: make(map[paymentMethodPolicyKey]*domain.PaymentMethodPolicy),
+	}
+}
+
+// Save creates or replaces the policy of a tenant or event
+func (r *MemoryPaymentMethodPolicyRepository) Save(ctx context.Context, policy *domain.PaymentMethodPolicy) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := paymentMethodPolicyKey{tenantID: policy.TenantID, eventID: policy.EventID}
+	if existing, exists := r.policies[key]; exists {
+		policy.CreatedAt = existing.CreatedAt
+	}
+	r.policies[key] = copyPaymentMethodPolicy(policy)
+	return nil
+}
+
+// Get retrieves the policy of a tenant or event
+func (r *MemoryPaymentMethodPolicyRepository) Get(ctx context.Context, tenantID, eventID string) (*domain.PaymentMethodPolicy, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	policy, exists := r.policies[paymentMethodPolicyKey{tenantID: tenantID, eventID: eventID}]
+	if !exists {
+		return nil, domain.ErrPaymentMethodPolicyNotFound
+	}
+	return copyPaymentMethodPolicy(policy), nil
+}
+
+// Delete removes the policy of a tenant or event
+func (r *MemoryPaymentMethodPolicyRepository) Delete(ctx context.Context, tenantID, eventID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := paymentMethodPolicyKey{tenantID: tenantID, eventID: eventID}
+	if _, exists := r.policies[key]; !exists {
+		return domain.ErrPaymentMethodPolicyNotFound
+	}
+	delete(r.policies, key)
+	return nil
+}
+
+// ListByTenant returns a tenant's policies, the tenant default first
+func (r *MemoryPaymentMethodPolicyRepository) ListByTenant(ctx context.Context, tenantID string) ([]*domain.PaymentMethodPolicy, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var policies []*domain.PaymentMethodPolicy
+	for key, policy := range r.policies {
+		if key.tenantID == tenantID {
+			policies = append(policies, copyPaymentMethodPolicy(policy))
+		}
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].EventID < policies[j].EventID
+	})
+	return policies, nil
+}
+
+// copyPaymentMethodPolicy copies a policy so callers can't change the stored one
+func copyPaymentMethodPolicy(policy *domain.PaymentMethodPolicy) *domain.PaymentMethodPolicy {
+	p := *policy
+	p.AllowedMethods = append([]domain.PaymentMethod(nil), policy.AllowedMethods...)
+	return &p
+}
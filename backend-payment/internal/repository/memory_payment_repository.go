@@ -2,9 +2,12 @@ package repository
 
 import (
 	"context"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 )
 
 // MemoryPaymentRepository implements PaymentRepository using in-memory storage
@@ -47,6 +50,9 @@ func (r *MemoryPaymentRepository) Create(ctx context.Context, payment *domain.Pa
 	r.payments[payment.ID] = &p
 	r.byBooking[payment.BookingID] = payment.ID
 	r.byUser[payment.UserID] = append(r.byUser[payment.UserID], payment.ID)
+	if payment.GatewayPaymentID != "" {
+		r.byGatewayPayment[payment.GatewayPaymentID] = payment.ID
+	}
 
 	return nil
 }
@@ -85,8 +91,8 @@ func (r *MemoryPaymentRepository) GetByBookingID(ctx context.Context, bookingID
 	return &p, nil
 }
 
-// GetByUserID retrieves all payments for a user
-func (r *MemoryPaymentRepository) GetByUserID(ctx context.Context, userID string, limit, offset int) ([]*domain.Payment, error) {
+// GetByUserID retrieves a page of a user's payments, newest first
+func (r *MemoryPaymentRepository) GetByUserID(ctx context.Context, userID string, page pagination.Params) ([]*domain.Payment, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
@@ -95,19 +101,49 @@ func (r *MemoryPaymentRepository) GetByUserID(ctx context.Context, userID string
 		return []*domain.Payment{}, nil
 	}
 
+	// Same order as the Postgres repository so cursors behave identically
+	payments := make([]*domain.Payment, 0, len(paymentIDs))
+	for _, id := range paymentIDs {
+		if payment, exists := r.payments[id]; exists {
+			if page.After == nil || page.After.Admits(payment.CreatedAt, payment.ID) {
+				payments = append(payments, payment)
+			}
+		}
+	}
+	sort.Slice(payments, func(i, j int) bool {
+		if payments[i].CreatedAt.Equal(payments[j].CreatedAt) {
+			return payments[i].ID > payments[j].ID
+		}
+		return payments[i].CreatedAt.After(payments[j].CreatedAt)
+	})
+
 	// Apply pagination
-	start := offset
-	if start >= len(paymentIDs) {
+	start := page.Offset
+	if start >= len(payments) {
 		return []*domain.Payment{}, nil
 	}
 
-	end := start + limit
-	if end > len(paymentIDs) {
-		end = len(paymentIDs)
+	end := start + page.Limit
+	if end > len(payments) {
+		end = len(payments)
 	}
 
 	result := make([]*domain.Payment, 0, end-start)
-	for _, id := range paymentIDs[start:end] {
+	for _, payment := range payments[start:end] {
+		p := *payment
+		result = append(result, &p)
+	}
+
+	return result, nil
+}
+
+// ListByUserID returns every payment made by a user
+func (r *MemoryPaymentRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.Payment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*domain.Payment, 0, len(r.byUser[userID]))
+	for _, id := range r.byUser[userID] {
 		if payment, exists := r.payments[id]; exists {
 			p := *payment
 			result = append(result, &p)
@@ -117,6 +153,113 @@ func (r *MemoryPaymentRepository) GetByUserID(ctx context.Context, userID string
 	return result, nil
 }
 
+// ListByBookingIDs returns the payments made for any of the given bookings
+func (r *MemoryPaymentRepository) ListByBookingIDs(ctx context.Context, bookingIDs []string) ([]*domain.Payment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*domain.Payment, 0, len(bookingIDs))
+	for _, bookingID := range bookingIDs {
+		if payment, exists := r.payments[r.byBooking[bookingID]]; exists {
+			p := *payment
+			result = append(result, &p)
+		}
+	}
+
+	return result, nil
+}
+
+// ListByCardLastFour returns the most recent payments made with a card ending in lastFour
+func (r *MemoryPaymentRepository) ListByCardLastFour(ctx context.Context, tenantID, lastFour string, limit int) ([]*domain.Payment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*domain.Payment, 0)
+	for _, payment := range r.payments {
+		if payment.CardLastFour != lastFour || (tenantID != "" && payment.TenantID != tenantID) {
+			continue
+		}
+		p := *payment
+		result = append(result, &p)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result, nil
+}
+
+// ListStuckProcessing returns payments processing since before cutoff, oldest first
+func (r *MemoryPaymentRepository) ListStuckProcessing(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Payment, error) {
+	return r.listInStatusSince(domain.PaymentStatusProcessing, cutoff, limit), nil
+}
+
+// ListRequiringAction returns payments requiring action since before cutoff, oldest first
+func (r *MemoryPaymentRepository) ListRequiringAction(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Payment, error) {
+	return r.listInStatusSince(domain.PaymentStatusRequiresAction, cutoff, limit), nil
+}
+
+// listInStatusSince returns copies of the payments in status since before cutoff, oldest first
+func (r *MemoryPaymentRepository) listInStatusSince(status domain.PaymentStatus, cutoff time.Time, limit int) []*domain.Payment {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]*domain.Payment, 0)
+	for _, payment := range r.payments {
+		if payment.Status != status || !payment.UpdatedAt.Before(cutoff) {
+			continue
+		}
+		p := *payment
+		result = append(result, &p)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].UpdatedAt.Before(result[j].UpdatedAt)
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result
+}
+
+// AnonymizeByUserID re-assigns a user's payments to an anonymized ID and clears customer and card details
+func (r *MemoryPaymentRepository) AnonymizeByUserID(ctx context.Context, userID, anonymizedID string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	paymentIDs := r.byUser[userID]
+	var count int64
+	for _, id := range paymentIDs {
+		payment, exists := r.payments[id]
+		if !exists {
+			continue
+		}
+		if payment.IdempotencyKey != "" {
+			delete(r.byIdempotency, payment.IdempotencyKey)
+		}
+		payment.UserID = anonymizedID
+		payment.GatewayCustomerID = ""
+		payment.GatewayResponse = nil
+		payment.IdempotencyKey = ""
+		payment.CardLastFour = ""
+		payment.CardBrand = ""
+		payment.Metadata = nil
+		payment.TaxInfo = nil
+		payment.UpdatedAt = time.Now()
+		count++
+	}
+
+	delete(r.byUser, userID)
+	r.byUser[anonymizedID] = append(r.byUser[anonymizedID], paymentIDs...)
+
+	return count, nil
+}
+
 // Update updates an existing payment
 func (r *MemoryPaymentRepository) Update(ctx context.Context, payment *domain.Payment) error {
 	r.mu.Lock()
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 )
 
 func TestNewMemoryPaymentRepository(t *testing.T) {
@@ -106,7 +107,7 @@ func TestMemoryPaymentRepository_GetByUserID(t *testing.T) {
 	repo.Create(ctx, payment2)
 	repo.Create(ctx, payment3)
 
-	payments, err := repo.GetByUserID(ctx, "user-456", 10, 0)
+	payments, err := repo.GetByUserID(ctx, "user-456", pagination.Params{Limit: 10})
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
@@ -127,22 +128,32 @@ func TestMemoryPaymentRepository_GetByUserID_Pagination(t *testing.T) {
 	}
 
 	// Get first page
-	page1, _ := repo.GetByUserID(ctx, "user-456", 2, 0)
+	page1, _ := repo.GetByUserID(ctx, "user-456", pagination.Params{Limit: 2})
 	if len(page1) != 2 {
 		t.Errorf("Expected 2 payments on page 1, got %d", len(page1))
 	}
 
 	// Get second page
-	page2, _ := repo.GetByUserID(ctx, "user-456", 2, 2)
+	page2, _ := repo.GetByUserID(ctx, "user-456", pagination.Params{Limit: 2, Offset: 2})
 	if len(page2) != 2 {
 		t.Errorf("Expected 2 payments on page 2, got %d", len(page2))
 	}
 
 	// Get last page
-	page3, _ := repo.GetByUserID(ctx, "user-456", 2, 4)
+	page3, _ := repo.GetByUserID(ctx, "user-456", pagination.Params{Limit: 2, Offset: 4})
 	if len(page3) != 1 {
 		t.Errorf("Expected 1 payment on page 3, got %d", len(page3))
 	}
+
+	// Cursor after the last payment of page 1 returns page 2
+	last := page1[len(page1)-1]
+	cursorPage, _ := repo.GetByUserID(ctx, "user-456", pagination.Params{
+		Limit: 2,
+		After: &pagination.Cursor{Time: last.CreatedAt, ID: last.ID},
+	})
+	if len(cursorPage) != 2 || cursorPage[0].ID != page2[0].ID || cursorPage[1].ID != page2[1].ID {
+		t.Errorf("Expected cursor page to match page 2")
+	}
 }
 
 func TestMemoryPaymentRepository_Update(t *testing.T) {
@@ -203,6 +214,44 @@ func TestMemoryPaymentRepository_GetByGatewayPaymentID(t *testing.T) {
 	}
 }
 
+func TestMemoryPaymentRepository_AnonymizeByUserID(t *testing.T) {
+	repo := NewMemoryPaymentRepository()
+	ctx := context.Background()
+
+	payment, _ := domain.NewPayment("tenant-123", "booking-123", "user-456", 1000.00, "THB", domain.PaymentMethodCreditCard)
+	payment.GatewayCustomerID = "cus_123"
+	payment.CardLastFour = "4242"
+	payment.CardBrand = "visa"
+	repo.Create(ctx, payment)
+
+	count, err := repo.AnonymizeByUserID(ctx, "user-456", "anon-789")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 payment anonymized, got %d", count)
+	}
+
+	remaining, _ := repo.ListByUserID(ctx, "user-456")
+	if len(remaining) != 0 {
+		t.Errorf("Expected no payments left for original user, got %d", len(remaining))
+	}
+
+	anonymized, _ := repo.ListByUserID(ctx, "anon-789")
+	if len(anonymized) != 1 {
+		t.Fatalf("Expected 1 payment for anonymized user, got %d", len(anonymized))
+	}
+	if anonymized[0].GatewayCustomerID != "" || anonymized[0].CardLastFour != "" || anonymized[0].CardBrand != "" {
+		t.Error("Expected customer and card details to be cleared")
+	}
+
+	// Second run is a no-op
+	count, _ = repo.AnonymizeByUserID(ctx, "user-456", "anon-789")
+	if count != 0 {
+		t.Errorf("Expected second anonymization to affect 0 payments, got %d", count)
+	}
+}
+
 func TestMemoryPaymentRepository_Clear(t *testing.T) {
 	repo := NewMemoryPaymentRepository()
 	ctx := context.Background()
@@ -220,3 +269,56 @@ func TestMemoryPaymentRepository_Clear(t *testing.T) {
 		t.Error("Expected count 0 after clear")
 	}
 }
+
+func TestMemoryPaymentRepository_ListByBookingIDs(t *testing.T) {
+	repo := NewMemoryPaymentRepository()
+	ctx := context.Background()
+
+	for _, bookingID := range []string{"booking-1", "booking-2", "booking-3"} {
+		payment, _ := domain.NewPayment("tenant-123", bookingID, "user-456", 1000.00, "THB", domain.PaymentMethodCreditCard)
+		repo.Create(ctx, payment)
+	}
+
+	payments, err := repo.ListByBookingIDs(ctx, []string{"booking-1", "booking-3", "booking-missing"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(payments) != 2 {
+		t.Fatalf("Expected 2 payments, got %d", len(payments))
+	}
+	if payments[0].BookingID != "booking-1" || payments[1].BookingID != "booking-3" {
+		t.Errorf("Unexpected bookings: %s, %s", payments[0].BookingID, payments[1].BookingID)
+	}
+}
+
+func TestMemoryPaymentRepository_ListByCardLastFour(t *testing.T) {
+	repo := NewMemoryPaymentRepository()
+	ctx := context.Background()
+
+	cards := []struct{ tenantID, bookingID, lastFour string }{
+		{"tenant-1", "booking-1", "4242"},
+		{"tenant-1", "booking-2", "4242"},
+		{"tenant-2", "booking-3", "4242"},
+		{"tenant-1", "booking-4", "1881"},
+	}
+	for _, card := range cards {
+		payment, _ := domain.NewPayment(card.tenantID, card.bookingID, "user-456", 1000.00, "THB", domain.PaymentMethodCreditCard)
+		payment.SetCardInfo(card.lastFour, "visa")
+		repo.Create(ctx, payment)
+	}
+
+	all, _ := repo.ListByCardLastFour(ctx, "", "4242", 10)
+	if len(all) != 3 {
+		t.Errorf("Expected 3 payments across tenants, got %d", len(all))
+	}
+
+	tenant, _ := repo.ListByCardLastFour(ctx, "tenant-1", "4242", 10)
+	if len(tenant) != 2 {
+		t.Errorf("Expected 2 payments for tenant-1, got %d", len(tenant))
+	}
+
+	limited, _ := repo.ListByCardLastFour(ctx, "", "4242", 1)
+	if len(limited) != 1 {
+		t.Errorf("Expected limit to cap results at 1, got %d", len(limited))
+	}
+}
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
+)
+
+// MemoryPayoutRepository implements PayoutRepository using in-memory storage
+// This is useful for testing and development
+type MemoryPayoutRepository struct {
+	schedules map[string]*domain.PayoutSchedule // tenantID -> schedule
+	payouts   map[string]*domain.Payout
+	mu        sync.RWMutex
+}
+
+// NewMemoryPayoutRepository creates a new in-memory payout repository
+func NewMemoryPayoutRepository() *MemoryPayoutRepository {
+	return &MemoryPayoutRepository{
+		schedules: make(map[string]*domain.PayoutSchedule),
+		payouts:   make(map[string]*domain.Payout),
+	}
+}
+
+// SaveSchedule creates or replaces a tenant's payout schedule
+func (r *MemoryPayoutRepository) SaveSchedule(ctx context.Context, schedule *domain.PayoutSchedule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.schedules[schedule.TenantID]; ok {
+		schedule.CreatedAt = existing.CreatedAt
+	}
+	s := *schedule
+	r.schedules[schedule.TenantID] = &s
+	return nil
+}
+
+// GetSchedule retrieves a tenant's payout schedule
+func (r *MemoryPayoutRepository) GetSchedule(ctx context.Context, tenantID string) (*domain.PayoutSchedule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schedule, ok := r.schedules[tenantID]
+	if !ok {
+		return nil, domain.ErrPayoutScheduleNotFound
+	}
+	s := *schedule
+	return &s, nil
+}
+
+// ListDueSchedules returns up to limit enabled schedules due at now, oldest due first
+func (r *MemoryPayoutRepository) ListDueSchedules(ctx context.Context, now time.Time, limit int) ([]*domain.PayoutSchedule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var due []*domain.PayoutSchedule
+	for _, schedule := range r.schedules {
+		if schedule.Enabled && !schedule.NextPayoutAt.After(now) {
+			s := *schedule
+			due = append(due, &s)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].NextPayoutAt.Before(due[j].NextPayoutAt) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+// ClaimSchedule moves a schedule due at due on to next
+func (r *MemoryPayoutRepository) ClaimSchedule(ctx context.Context, tenantID string, due, next time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	schedule, ok := r.schedules[tenantID]
+	if !ok || !schedule.NextPayoutAt.Equal(due) {
+		return false, nil
+	}
+	schedule.NextPayoutAt = next
+	schedule.UpdatedAt = time.Now().UTC()
+	return true, nil
+}
+
+// Create saves a payout
+func (r *MemoryPayoutRepository) Create(ctx context.Context, payout *domain.Payout) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.payouts {
+		if existing.TenantID == payout.TenantID && existing.Currency == payout.Currency && existing.PeriodEnd.Equal(payout.PeriodEnd) {
+			return domain.ErrPayoutExists
+		}
+	}
+	p := *payout
+	r.payouts[payout.ID] = &p
+	return nil
+}
+
+// Update updates an existing payout
+func (r *MemoryPayoutRepository) Update(ctx context.Context, payout *domain.Payout) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.payouts[payout.ID]; !ok {
+		return domain.ErrPayoutNotFound
+	}
+	p := *payout
+	r.payouts[payout.ID] = &p
+	return nil
+}
+
+// GetByID retrieves a payout by its ID
+func (r *MemoryPayoutRepository) GetByID(ctx context.Context, id string) (*domain.Payout, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	payout, ok := r.payouts[id]
+	if !ok {
+		return nil, domain.ErrPayoutNotFound
+	}
+	p := *payout
+	return &p, nil
+}
+
+// List returns a page of the payouts selected by filter, newest first
+func (r *MemoryPayoutRepository) List(ctx context.Context, filter *domain.PayoutFilter, page pagination.Params) ([]*domain.Payout, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var payouts []*domain.Payout
+	for _, payout := range r.payouts {
+		if filter.Matches(payout) && (page.After == nil || page.After.Admits(payout.CreatedAt, payout.ID)) {
+			payouts = append(payouts, payout)
+		}
+	}
+	sort.Slice(payouts, func(i, j int) bool {
+		if payouts[i].CreatedAt.Equal(payouts[j].CreatedAt) {
+			return payouts[i].ID > payouts[j].ID
+		}
+		return payouts[i].CreatedAt.After(payouts[j].CreatedAt)
+	})
+
+	start := page.Offset
+	if start >= len(payouts) {
+		return []*domain.Payout{}, nil
+	}
+	end := start + page.Limit
+	if end > len(payouts) {
+		end = len(payouts)
+	}
+
+	result := make([]*domain.Payout, 0, end-start)
+	for _, payout := range payouts[start:end] {
+		p := *payout
+		result = append(result, &p)
+	}
+	return result, nil
+}
+
+// ListUnsettled returns a tenant's payouts still pending or not yet posted to the ledger
+func (r *MemoryPayoutRepository) ListUnsettled(ctx context.Context, tenantID string) ([]*domain.Payout, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var payouts []*domain.Payout
+	for _, payout := range r.payouts {
+		if payout.TenantID == tenantID && payout.Status != domain.PayoutStatusFailed && !payout.LedgerPosted {
+			p := *payout
+			payouts = append(payouts, &p)
+		}
+	}
+	sort.Slice(payouts, func(i, j int) bool { return payouts[i].CreatedAt.Before(payouts[j].CreatedAt) })
+	return payouts, nil
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+)
+
+// MemoryRefundRequestRepository implements RefundRequestRepository using in-memory storage
+// This is useful for testing and development
+type MemoryRefundRequestRepository struct {
+	requests map[string]*domain.RefundRequest
+	policies map[string]*domain.RefundApprovalPolicy // tenantID -> policy
+	mu       sync.RWMutex
+}
+
+// NewMemoryRefundRequestRepository creates a new in-memory refund request repository
+func NewMemoryRefundRequestRepository() *MemoryRefundRequestRepository {
+	return &MemoryRefundRequestRepository{
+		requests: make(map[string]*domain.RefundRequest),
+		policies: make(map[string]*domain.RefundApprovalPolicy),
+	}
+}
+
+// Create saves a new refund request
+func (r *MemoryRefundRequestRepository) Create(ctx context.Context, request *domain.RefundRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.requests {
+		if existing.PaymentID == request.PaymentID && existing.IsOpen() {
+			return domain.ErrRefundRequestExists
+		}
+	}
+	rr := *request
+	r.requests[request.ID] = &rr
+	return nil
+}
+
+// Update saves a refund request that was in status from
+func (r *MemoryRefundRequestRepository) Update(ctx context.Context, request *domain.RefundRequest, from domain.RefundRequestStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.requests[request.ID]
+	if !exists {
+		return domain.ErrRefundRequestNotFound
+	}
+	if existing.Status != from {
+		return domain.ErrRefundRequestChanged
+	}
+	rr := *request
+	r.requests[request.ID] = &rr
+	return nil
+}
+
+// GetByID retrieves a refund request by its ID
+func (r *MemoryRefundRequestRepository) GetByID(ctx context.Context, id string) (*domain.RefundRequest, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	request, exists := r.requests[id]
+	if !exists {
+		return nil, domain.ErrRefundRequestNotFound
+	}
+	rr := *request
+	return &rr, nil
+}
+
+// List returns the refund requests selected by filter, newest first
+func (r *MemoryRefundRequestRepository) List(ctx context.Context, filter *domain.RefundRequestFilter) ([]*domain.RefundRequest, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var requests []*domain.RefundRequest
+	for _, request := range r.requests {
+		if !filter.Matches(request) {
+			continue
+		}
+		rr := *request
+		requests = append(requests, &rr)
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].CreatedAt.After(requests[j].CreatedAt)
+	})
+	return requests, nil
+}
+
+// SavePolicy creates or replaces a tenant's refund approval policy
+func (r *MemoryRefundRequestRepository) SavePolicy(ctx context.Context, policy *domain.RefundApprovalPolicy) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, exists := r.policies[policy.TenantID]; exists {
+		policy.CreatedAt = existing.CreatedAt
+	}
+	p := *policy
+	r.policies[policy.TenantID] = &p
+	return nil
+}
+
+// GetPolicy retrieves a tenant's refund approval policy
+func (r *MemoryRefundRequestRepository) GetPolicy(ctx context.Context, tenantID string) (*domain.RefundApprovalPolicy, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	policy, exists := r.policies[tenantID]
+	if !exists {
+		return nil, domain.ErrRefundApprovalPolicyNotFound
+	}
+	p := *policy
+	return &p, nil
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+)
+
+// MemoryRefundReviewRepository implements RefundReviewRepository using in-memory storage
+// This is useful for testing and development
+type MemoryRefundReviewRepository struct {
+	reviews  map[string]*domain.RefundReview
+	byCharge map[string]string // gatewayChargeID -> reviewID
+	mu       sync.RWMutex
+}
+
+// NewMemoryRefundReviewRepository creates a new in-memory refund review repository
+func NewMemoryRefundReviewRepository() *MemoryRefundReviewRepository {
+	return &MemoryRefundReviewRepository{
+		reviews:  make(map[string]*domain.RefundReview),
+		byCharge: make(map[string]string),
+	}
+}
+
+// Save records the review of a refunded gateway charge
+func (r *MemoryRefundReviewRepository) Save(ctx context.Context, review *domain.RefundReview) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if id, exists := r.byCharge[review.GatewayChargeID]; exists {
+		existing := r.reviews[id]
+		review.ID = existing.ID
+		review.CreatedAt = existing.CreatedAt
+		if existing.AmountRefunded == review.AmountRefunded {
+			review.Status = existing.Status
+			review.ResolvedBy = existing.ResolvedBy
+			review.ResolutionNote = existing.ResolutionNote
+			review.ResolvedAt = existing.ResolvedAt
+		}
+	}
+
+	rv := *review
+	r.reviews[review.ID] = &rv
+	r.byCharge[review.GatewayChargeID] = review.ID
+	return nil
+}
+
+// GetByID retrieves a review by its ID
+func (r *MemoryRefundReviewRepository) GetByID(ctx context.Context, id string) (*domain.RefundReview, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	review, exists := r.reviews[id]
+	if !exists {
+		return nil, domain.ErrRefundReviewNotFound
+	}
+	rv := *review
+	return &rv, nil
+}
+
+// List returns the reviews in a status (all when empty), newest first
+func (r *MemoryRefundReviewRepository) List(ctx context.Context, status domain.RefundReviewStatus) ([]*domain.RefundReview, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var reviews []*domain.RefundReview
+	for _, review := range r.reviews {
+		if status != "" && review.Status != status {
+			continue
+		}
+		rv := *review
+		reviews = append(reviews, &rv)
+	}
+	sort.Slice(reviews, func(i, j int) bool {
+		return reviews[i].CreatedAt.After(reviews[j].CreatedAt)
+	})
+	return reviews, nil
+}
+
+// Update updates an existing review
+func (r *MemoryRefundReviewRepository) Update(ctx context.Context, review *domain.RefundReview) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.reviews[review.ID]; !exists {
+		return domain.ErrRefundReviewNotFound
+	}
+	rv := *review
+	r.reviews[review.ID] = &rv
+	return nil
+}
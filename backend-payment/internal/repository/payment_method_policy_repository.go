@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+)
+
+// PaymentMethodPolicyRepository stores the payment methods accepted per tenant and event.
+// An empty event ID refers to the tenant's default policy.
+type PaymentMethodPolicyRepository interface {
+	// Save creates or replaces the policy of a tenant or event
+	Save(ctx context.Context, policy *domain.PaymentMethodPolicy) error
+
+	// Get retrieves the policy of a tenant or event. Returns ErrPaymentMethodPolicyNotFound
+	// if it has none.
+	Get(ctx context.Context, tenantID, eventID string) (*domain.PaymentMethodPolicy, error)
+
+	// Delete removes the policy of a tenant or event. Returns ErrPaymentMethodPolicyNotFound
+	// if it has none.
+	Delete(ctx context.Context, tenantID, eventID string) error
+
+	// ListByTenant returns a tenant's policies, the tenant default first
+	ListByTenant(ctx context.Context, tenantID string) ([]*domain.PaymentMethodPolicy, error)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 )
 
 // PaymentRepository defines the interface for payment data access
@@ -17,8 +18,8 @@ type PaymentRepository interface {
 	// GetByBookingID retrieves a payment by booking ID
 	GetByBookingID(ctx context.Context, bookingID string) (*domain.Payment, error)
 
-	// GetByUserID retrieves all payments for a user
-	GetByUserID(ctx context.Context, userID string, limit, offset int) ([]*domain.Payment, error)
+	// GetByUserID retrieves a page of a user's payments, newest first
+	GetByUserID(ctx context.Context, userID string, page pagination.Params) ([]*domain.Payment, error)
 
 	// Update updates an existing payment
 	Update(ctx context.Context, payment *domain.Payment) error
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+)
+
+// PaymentSearchRepository defines payment lookups for the support booking search in booking-service
+type PaymentSearchRepository interface {
+	// ListByBookingIDs returns the payments made for any of the given bookings
+	ListByBookingIDs(ctx context.Context, bookingIDs []string) ([]*domain.Payment, error)
+
+	// ListByCardLastFour returns the most recent payments made with a card ending in lastFour.
+	// An empty tenantID searches all tenants.
+	ListByCardLastFour(ctx context.Context, tenantID, lastFour string, limit int) ([]*domain.Payment, error)
+}
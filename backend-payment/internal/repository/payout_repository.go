@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
+)
+
+// PayoutRepository stores tenant payout schedules and the payouts made on them
+type PayoutRepository interface {
+	// SaveSchedule creates or replaces a tenant's payout schedule
+	SaveSchedule(ctx context.Context, schedule *domain.PayoutSchedule) error
+
+	// GetSchedule retrieves a tenant's payout schedule
+	GetSchedule(ctx context.Context, tenantID string) (*domain.PayoutSchedule, error)
+
+	// ListDueSchedules returns up to limit enabled schedules due at now, oldest due first
+	ListDueSchedules(ctx context.Context, now time.Time, limit int) ([]*domain.PayoutSchedule, error)
+
+	// ClaimSchedule moves a schedule due at due on to next. It reports false when another
+	// worker claimed it first or the schedule changed, so each payout is made once.
+	ClaimSchedule(ctx context.Context, tenantID string, due, next time.Time) (bool, error)
+
+	// Create saves a payout. Returns ErrPayoutExists if the tenant already has a payout
+	// in the currency for the same period end.
+	Create(ctx context.Context, payout *domain.Payout) error
+
+	// Update updates an existing payout
+	Update(ctx context.Context, payout *domain.Payout) error
+
+	// GetByID retrieves a payout by its ID
+	GetByID(ctx context.Context, id string) (*domain.Payout, error)
+
+	// List returns a page of the payouts selected by filter, newest first
+	List(ctx context.Context, filter *domain.PayoutFilter, page pagination.Params) ([]*domain.Payout, error)
+
+	// ListUnsettled returns a tenant's payouts still pending or not yet posted to the
+	// ledger, oldest first
+	ListUnsettled(ctx context.Context, tenantID string) ([]*domain.Payout, error)
+}
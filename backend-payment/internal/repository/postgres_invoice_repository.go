@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+)
+
+// PostgresInvoiceRepository implements InvoiceRepository using PostgreSQL
+type PostgresInvoiceRepository struct {
+	db *database.PostgresDB
+}
+
+// NewPostgresInvoiceRepository creates a new PostgreSQL invoice repository
+func NewPostgresInvoiceRepository(db *database.PostgresDB) *PostgresInvoiceRepository {
+	return &PostgresInvoiceRepository{db: db}
+}
+
+// Create assigns the next running number and saves the invoice in one transaction, so a
+// failed insert does not leave a gap in the numbering
+func (r *PostgresInvoiceRepository) Create(ctx context.Context, invoice *domain.Invoice) error {
+	tx, err := r.db.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// The row lock on the sequence serializes concurrent invoices of the same tenant and period
+	var sequence int64
+	err = tx.QueryRow(ctx, `
+		INSERT INTO invoice_sequences (tenant_id, period, last_number)
+		VALUES ($1, $2, 1)
+		ON CONFLICT (tenant_id, period)
+		DO UPDATE SET last_number = invoice_sequences.last_number + 1
+		RETURNING last_number`,
+		invoice.TenantID, invoice.Period,
+	).Scan(&sequence)
+	if err != nil {
+		return fmt.Errorf("failed to allocate invoice number: %w", err)
+	}
+	invoice.AssignNumber(sequence)
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO invoices (
+			id, tenant_id, booking_id, payment_id, period, sequence, number,
+			buyer_name, buyer_tax_id, buyer_branch_code, buyer_address,
+			currency, subtotal, vat_rate, vat_amount, total, issued_at, created_at
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18
+		)`,
+		invoice.ID,
+		invoice.TenantID,
+		invoice.BookingID,
+		invoice.PaymentID,
+		invoice.Period,
+		invoice.Sequence,
+		invoice.Number,
+		invoice.Buyer.CompanyName,
+		invoice.Buyer.TaxID,
+		invoice.Buyer.BranchCode,
+		invoice.Buyer.Address,
+		invoice.Currency,
+		invoice.Subtotal,
+		invoice.VATRate,
+		invoice.VATAmount,
+		invoice.Total,
+		invoice.IssuedAt,
+		invoice.CreatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolationCode {
+			return domain.ErrInvoiceAlreadyExists
+		}
+		return fmt.Errorf("failed to create invoice: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// invoiceColumns defines the columns to select for invoice queries
+const invoiceColumns = `
+	id, tenant_id, booking_id, payment_id, period, sequence, number,
+	buyer_name, buyer_tax_id, buyer_branch_code, buyer_address,
+	currency, subtotal, vat_rate, vat_amount, total, issued_at, created_at
+`
+
+// GetByID retrieves an invoice by its ID
+func (r *PostgresInvoiceRepository) GetByID(ctx context.Context, id string) (*domain.Invoice, error) {
+	query := `SELECT ` + invoiceColumns + ` FROM invoices WHERE id = $1`
+	return scanInvoice(r.db.Pool().QueryRow(ctx, query, id))
+}
+
+// GetByPaymentID retrieves the invoice issued for a payment
+func (r *PostgresInvoiceRepository) GetByPaymentID(ctx context.Context, paymentID string) (*domain.Invoice, error) {
+	query := `SELECT ` + invoiceColumns + ` FROM invoices WHERE payment_id = $1`
+	return scanInvoice(r.db.Pool().QueryRow(ctx, query, paymentID))
+}
+
+// ListIssued returns a tenant's invoices issued in [from, to), ordered by number
+func (r *PostgresInvoiceRepository) ListIssued(ctx context.Context, tenantID string, from, to time.Time) ([]*domain.Invoice, error) {
+	query := `SELECT ` + invoiceColumns + ` FROM invoices
+		WHERE tenant_id = $1 AND issued_at >= $2 AND issued_at < $3
+		ORDER BY period, sequence`
+
+	rows, err := r.db.Pool().Query(ctx, query, tenantID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invoices: %w", err)
+	}
+	defer rows.Close()
+
+	var invoices []*domain.Invoice
+	for rows.Next() {
+		invoice, err := scanInvoice(rows)
+		if err != nil {
+			return nil, err
+		}
+		invoices = append(invoices, invoice)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate invoices: %w", err)
+	}
+	return invoices, nil
+}
+
+// scanInvoice scans a single invoice from a row
+func scanInvoice(row pgx.Row) (*domain.Invoice, error) {
+	var invoice domain.Invoice
+	err := row.Scan(
+		&invoice.ID,
+		&invoice.TenantID,
+		&invoice.BookingID,
+		&invoice.PaymentID,
+		&invoice.Period,
+		&invoice.Sequence,
+		&invoice.Number,
+		&invoice.Buyer.CompanyName,
+		&invoice.Buyer.TaxID,
+		&invoice.Buyer.BranchCode,
+		&invoice.Buyer.Address,
+		&invoice.Currency,
+		&invoice.Subtotal,
+		&invoice.VATRate,
+		&invoice.VATAmount,
+		&invoice.Total,
+		&invoice.IssuedAt,
+		&invoice.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrInvoiceNotFound
+		}
+		return nil, fmt.Errorf("failed to scan invoice: %w", err)
+	}
+	return &invoice, nil
+}
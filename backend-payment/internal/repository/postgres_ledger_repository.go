@@ -0,0 +1,258 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
+)
+
+// PostgresLedgerRepository implements LedgerRepository using PostgreSQL
+type PostgresLedgerRepository struct {
+	db *database.PostgresDB
+}
+
+// NewPostgresLedgerRepository creates a new PostgreSQL ledger repository
+func NewPostgresLedgerRepository(db *database.PostgresDB) *PostgresLedgerRepository {
+	return &PostgresLedgerRepository{db: db}
+}
+
+// Create saves the transaction and its entries in one database transaction, so the
+// ledger never holds half of a posting
+func (r *PostgresLedgerRepository) Create(ctx context.Context, ltx *domain.LedgerTransaction) error {
+	tx, err := r.db.BeginTx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO ledger_transactions (
+			id, tenant_id, type, payment_id, reference, idempotency_key,
+			currency, description, occurred_at, created_at
+		) VALUES (
+			$1, $2, $3, NULLIF($4, '')::uuid, $5, $6, $7, $8, $9, $10
+		)`,
+		ltx.ID,
+		ltx.TenantID,
+		string(ltx.Type),
+		ltx.PaymentID,
+		ltx.Reference,
+		ltx.IdempotencyKey,
+		ltx.Currency,
+		ltx.Description,
+		ltx.OccurredAt,
+		ltx.CreatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolationCode {
+			return domain.ErrLedgerTransactionExists
+		}
+		return fmt.Errorf("failed to create ledger transaction: %w", err)
+	}
+
+	for _, entry := range ltx.Entries {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO ledger_entries (
+				id, transaction_id, tenant_id, account, direction, amount, currency, occurred_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			entry.ID,
+			entry.TransactionID,
+			entry.TenantID,
+			string(entry.Account),
+			string(entry.Direction),
+			entry.Amount,
+			entry.Currency,
+			entry.OccurredAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create ledger entry: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ledgerTransactionColumns defines the columns to select for ledger transaction queries
+const ledgerTransactionColumns = `
+	id, tenant_id, type, COALESCE(payment_id::text, ''), reference, idempotency_key,
+	currency, description, occurred_at, created_at
+`
+
+// ledgerEntryColumns defines the columns to select for ledger entry queries, joined
+// with the transaction as t
+const ledgerEntryColumns = `
+	e.id, e.transaction_id, e.tenant_id, e.account, e.direction, e.amount, e.currency, e.occurred_at,
+	t.type, COALESCE(t.payment_id::text, ''), t.reference
+`
+
+// GetByID retrieves a transaction and its entries by ID
+func (r *PostgresLedgerRepository) GetByID(ctx context.Context, id string) (*domain.LedgerTransaction, error) {
+	query := `SELECT ` + ledgerTransactionColumns + ` FROM ledger_transactions WHERE id = $1`
+	return r.getTransaction(ctx, query, id)
+}
+
+// GetByIdempotencyKey retrieves the transaction recorded for a business event
+func (r *PostgresLedgerRepository) GetByIdempotencyKey(ctx context.Context, key string) (*domain.LedgerTransaction, error) {
+	query := `SELECT ` + ledgerTransactionColumns + ` FROM ledger_transactions WHERE idempotency_key = $1`
+	return r.getTransaction(ctx, query, key)
+}
+
+// getTransaction retrieves the transaction selected by query and loads its entries
+func (r *PostgresLedgerRepository) getTransaction(ctx context.Context, query string, arg string) (*domain.LedgerTransaction, error) {
+	var ltx domain.LedgerTransaction
+	var txType string
+	err := r.db.Pool().QueryRow(ctx, query, arg).Scan(
+		&ltx.ID,
+		&ltx.TenantID,
+		&txType,
+		&ltx.PaymentID,
+		&ltx.Reference,
+		&ltx.IdempotencyKey,
+		&ltx.Currency,
+		&ltx.Description,
+		&ltx.OccurredAt,
+		&ltx.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrLedgerTransactionNotFound
+		}
+		return nil, fmt.Errorf("failed to scan ledger transaction: %w", err)
+	}
+	ltx.Type = domain.LedgerTransactionType(txType)
+
+	entries, err := r.queryEntries(ctx, `SELECT `+ledgerEntryColumns+`
+		FROM ledger_entries e JOIN ledger_transactions t ON t.id = e.transaction_id
+		WHERE e.transaction_id = $1
+		ORDER BY e.direction DESC, e.id`, ltx.ID)
+	if err != nil {
+		return nil, err
+	}
+	ltx.Entries = entries
+	return &ltx, nil
+}
+
+// GetBalances returns a tenant's balance per account and currency
+func (r *PostgresLedgerRepository) GetBalances(ctx context.Context, tenantID string, asOf time.Time) ([]*domain.LedgerBalance, error) {
+	where := "tenant_id = $1"
+	args := []interface{}{tenantID}
+	if !asOf.IsZero() {
+		where += " AND occurred_at < $2"
+		args = append(args, asOf)
+	}
+	query := `
+		SELECT account, currency,
+			COALESCE(SUM(amount) FILTER (WHERE direction = 'debit'), 0),
+			COALESCE(SUM(amount) FILTER (WHERE direction = 'credit'), 0)
+		FROM ledger_entries
+		WHERE ` + where + `
+		GROUP BY account, currency
+		ORDER BY account, currency`
+
+	rows, err := r.db.Pool().Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ledger balances: %w", err)
+	}
+	defer rows.Close()
+
+	balances := []*domain.LedgerBalance{}
+	for rows.Next() {
+		balance := &domain.LedgerBalance{TenantID: tenantID}
+		var account string
+		if err := rows.Scan(&account, &balance.Currency, &balance.Debits, &balance.Credits); err != nil {
+			return nil, fmt.Errorf("failed to scan ledger balance: %w", err)
+		}
+		balance.Account = domain.LedgerAccount(account)
+		balances = append(balances, balance)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate ledger balances: %w", err)
+	}
+	return balances, nil
+}
+
+// ListEntries returns a page of the entries selected by filter, newest first
+func (r *PostgresLedgerRepository) ListEntries(ctx context.Context, filter *domain.LedgerEntryFilter, page pagination.Params) ([]*domain.LedgerEntry, error) {
+	where := "e.tenant_id = $1"
+	args := []interface{}{filter.TenantID}
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		where += fmt.Sprintf(" AND "+condition, len(args))
+	}
+	if filter.Account != "" {
+		addCondition("e.account = $%d", string(filter.Account))
+	}
+	if filter.Type != "" {
+		addCondition("t.type = $%d", string(filter.Type))
+	}
+	if filter.PaymentID != "" {
+		addCondition("t.payment_id = $%d", filter.PaymentID)
+	}
+	if !filter.From.IsZero() {
+		addCondition("e.occurred_at >= $%d", filter.From)
+	}
+	if !filter.To.IsZero() {
+		addCondition("e.occurred_at < $%d", filter.To)
+	}
+	if page.After != nil {
+		where += " AND " + pagination.Keyset("e.occurred_at", "e.id", len(args)+1)
+		args = append(args, page.After.Time, page.After.ID)
+	}
+	args = append(args, page.Limit, page.Offset)
+
+	query := fmt.Sprintf(`SELECT `+ledgerEntryColumns+`
+		FROM ledger_entries e JOIN ledger_transactions t ON t.id = e.transaction_id
+		WHERE %s
+		ORDER BY e.occurred_at DESC, e.id DESC LIMIT $%d OFFSET $%d`, where, len(args)-1, len(args))
+	return r.queryEntries(ctx, query, args...)
+}
+
+// queryEntries runs a query selecting ledgerEntryColumns
+func (r *PostgresLedgerRepository) queryEntries(ctx context.Context, query string, args ...interface{}) ([]*domain.LedgerEntry, error) {
+	rows, err := r.db.Pool().Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ledger entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*domain.LedgerEntry{}
+	for rows.Next() {
+		var entry domain.LedgerEntry
+		var account, direction, txType string
+		err := rows.Scan(
+			&entry.ID,
+			&entry.TransactionID,
+			&entry.TenantID,
+			&account,
+			&direction,
+			&entry.Amount,
+			&entry.Currency,
+			&entry.OccurredAt,
+			&txType,
+			&entry.PaymentID,
+			&entry.Reference,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan ledger entry: %w", err)
+		}
+		entry.Account = domain.LedgerAccount(account)
+		entry.Direction = domain.LedgerDirection(direction)
+		entry.Type = domain.LedgerTransactionType(txType)
+		entries = append(entries, &entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate ledger entries: %w", err)
+	}
+	return entries, nil
+}
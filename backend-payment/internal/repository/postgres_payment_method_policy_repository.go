@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+)
+
+// PostgresPaymentMethodPolicyRepository implements PaymentMethodPolicyRepository using PostgreSQL
+type PostgresPaymentMethodPolicyRepository struct {
+	db *database.PostgresDB
+}
+
+// NewPostgresPaymentMethodPolicyRepository creates a new PostgreSQL payment method policy repository
+func NewPostgresPaymentMethodPolicyRepository(db *database.PostgresDB) *PostgresPaymentMethodPolicyRepository {
+	return &PostgresPaymentMethodPolicyRepository{db: db}
+}
+
+// paymentMethodPolicyColumns defines the columns of payment method policy queries.
+// The enum array is read as text so it scans into strings.
+const paymentMethodPolicyColumns = `
+	tenant_id, event_id, allowed_methods::text[], updated_by, created_at, updated_at
+`
+
+// Save creates or replaces the policy of a tenant or event
+func (r *PostgresPaymentMethodPolicyRepository) Save(ctx context.Context, policy *domain.PaymentMethodPolicy) error {
+	query := `
+		INSERT INTO payment_method_policies (tenant_id, event_id, allowed_methods, updated_by, created_at, updated_at)
+		VALUES ($1, $2, $3::payment_method[], $4, $5, $6)
+		ON CONFLICT (tenant_id, event_id) DO UPDATE SET
+			allowed_methods = EXCLUDED.allowed_methods,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at
+		RETURNING created_at`
+
+	methods := make([]string, len(policy.AllowedMethods))
+	for i, method := range policy.AllowedMethods {
+		methods[i] = string(method)
+	}
+
+	err := r.db.Pool().QueryRow(ctx, query,
+		policy.TenantID,
+		policy.EventID,
+		methods,
+		policy.UpdatedBy,
+		policy.CreatedAt,
+		policy.UpdatedAt,
+	).Scan(&policy.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save payment method policy: %w", err)
+	}
+	return nil
+}
+
+// Get retrieves the policy of a tenant or event
+func (r *PostgresPaymentMethodPolicyRepository) Get(ctx context.Context, tenantID, eventID string) (*domain.PaymentMethodPolicy, error) {
+	query := `SELECT ` + paymentMethodPolicyColumns + `
+		FROM payment_method_policies WHERE tenant_id = $1 AND event_id = $2`
+
+	policy, err := scanPaymentMethodPolicy(r.db.Pool().QueryRow(ctx, query, tenantID, eventID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrPaymentMethodPolicyNotFound
+		}
+		return nil, fmt.Errorf("failed to get payment method policy: %w", err)
+	}
+	return policy, nil
+}
+
+// Delete removes the policy of a tenant or event
+func (r *PostgresPaymentMethodPolicyRepository) Delete(ctx context.Context, tenantID, eventID string) error {
+	query := `DELETE FROM payment_method_policies WHERE tenant_id = $1 AND event_id = $2`
+
+	tag, err := r.db.Pool().Exec(ctx, query, tenantID, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to delete payment method policy: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrPaymentMethodPolicyNotFound
+	}
+	return nil
+}
+
+// ListByTenant returns a tenant's policies, the tenant default first
+func (r *PostgresPaymentMethodPolicyRepository) ListByTenant(ctx context.Context, tenantID string) ([]*domain.PaymentMethodPolicy, error) {
+	query := `SELECT ` + paymentMethodPolicyColumns + `
+		FROM payment_method_policies WHERE tenant_id = $1 ORDER BY event_id`
+
+	rows, err := r.db.Pool().Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payment method policies: %w", err)
+	}
+	defer rows.Close()
+
+	var policies []*domain.PaymentMethodPolicy
+	for rows.Next() {
+		policy, err := scanPaymentMethodPolicy(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan payment method policy: %w", err)
+		}
+		policies = append(policies, policy)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate payment method policies: %w", err)
+	}
+	return policies, nil
+}
+
+// scanPaymentMethodPolicy scans a row selected with paymentMethodPolicyColumns
+func scanPaymentMethodPolicy(row pgx.Row) (*domain.PaymentMethodPolicy, error) {
+	var policy domain.PaymentMethodPolicy
+	var methods []string
+	err := row.Scan(
+		&policy.TenantID,
+		&policy.EventID,
+		&methods,
+		&policy.UpdatedBy,
+		&policy.CreatedAt,
+		&policy.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	policy.AllowedMethods = make([]domain.PaymentMethod, len(methods))
+	for i, method := range methods {
+		policy.AllowedMethods[i] = domain.PaymentMethod(method)
+	}
+	return &policy, nil
+}
@@ -5,11 +5,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 )
 
 // PostgreSQL error code for unique violation
@@ -33,9 +35,9 @@ func (r *PostgresPaymentRepository) Create(ctx context.Context, payment *domain.
 			gateway, gateway_payment_id, gateway_charge_id, gateway_customer_id, gateway_response,
 			idempotency_key, card_last_four, card_brand,
 			initiated_at, processed_at, refund_amount, refund_reason, refunded_at,
-			error_code, error_message, retry_count, metadata, created_at, updated_at
+			error_code, error_message, retry_count, metadata, created_at, updated_at, tax_info
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28
 		)`
 
 	metadataJSON, err := json.Marshal(payment.Metadata)
@@ -48,6 +50,13 @@ func (r *PostgresPaymentRepository) Create(ctx context.Context, payment *domain.
 		return fmt.Errorf("failed to marshal gateway_response: %w", err)
 	}
 
+	var taxInfoJSON []byte
+	if payment.TaxInfo != nil {
+		if taxInfoJSON, err = json.Marshal(payment.TaxInfo); err != nil {
+			return fmt.Errorf("failed to marshal tax_info: %w", err)
+		}
+	}
+
 	// Handle nullable method
 	var method *string
 	if payment.Method != "" {
@@ -83,6 +92,7 @@ func (r *PostgresPaymentRepository) Create(ctx context.Context, payment *domain.
 		metadataJSON,
 		payment.CreatedAt,
 		payment.UpdatedAt,
+		taxInfoJSON,
 	)
 
 	if err != nil {
@@ -110,7 +120,7 @@ const selectColumns = `
 	gateway, gateway_payment_id, gateway_charge_id, gateway_customer_id, gateway_response,
 	idempotency_key, card_last_four, card_brand,
 	initiated_at, processed_at, refund_amount, refund_reason, refunded_at,
-	error_code, error_message, retry_count, metadata, created_at, updated_at
+	error_code, error_message, retry_count, metadata, created_at, updated_at, tax_info
 `
 
 // GetByID retrieves a payment by its ID
@@ -125,11 +135,17 @@ func (r *PostgresPaymentRepository) GetByBookingID(ctx context.Context, bookingI
 	return r.scanPayment(r.db.Pool().QueryRow(ctx, query, bookingID))
 }
 
-// GetByUserID retrieves all payments for a user
-func (r *PostgresPaymentRepository) GetByUserID(ctx context.Context, userID string, limit, offset int) ([]*domain.Payment, error) {
-	query := `SELECT ` + selectColumns + ` FROM payments WHERE user_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3`
+// GetByUserID retrieves a page of a user's payments, newest first
+func (r *PostgresPaymentRepository) GetByUserID(ctx context.Context, userID string, page pagination.Params) ([]*domain.Payment, error) {
+	where := "user_id = $1"
+	args := []interface{}{userID, page.Limit, page.Offset}
+	if page.After != nil {
+		where += " AND " + pagination.Keyset("created_at", "id", 4)
+		args = append(args, page.After.Time, page.After.ID)
+	}
+	query := `SELECT ` + selectColumns + ` FROM payments WHERE ` + where + ` ORDER BY created_at DESC, id DESC LIMIT $2 OFFSET $3`
 
-	rows, err := r.db.Pool().Query(ctx, query, userID, limit, offset)
+	rows, err := r.db.Pool().Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query payments: %w", err)
 	}
@@ -151,6 +167,126 @@ func (r *PostgresPaymentRepository) GetByUserID(ctx context.Context, userID stri
 	return payments, nil
 }
 
+// ListByUserID returns every payment made by a user
+func (r *PostgresPaymentRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.Payment, error) {
+	query := `SELECT ` + selectColumns + ` FROM payments WHERE user_id = $1 ORDER BY created_at DESC`
+
+	rows, err := r.db.Pool().Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query payments: %w", err)
+	}
+	defer rows.Close()
+
+	payments := []*domain.Payment{}
+	for rows.Next() {
+		payment, err := r.scanPaymentFromRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, payment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate payments: %w", err)
+	}
+
+	return payments, nil
+}
+
+// ListByBookingIDs returns the payments made for any of the given bookings
+func (r *PostgresPaymentRepository) ListByBookingIDs(ctx context.Context, bookingIDs []string) ([]*domain.Payment, error) {
+	if len(bookingIDs) == 0 {
+		return []*domain.Payment{}, nil
+	}
+
+	query := `SELECT ` + selectColumns + ` FROM payments WHERE booking_id::text = ANY($1) ORDER BY created_at DESC`
+
+	return r.queryPayments(ctx, query, bookingIDs)
+}
+
+// ListByCardLastFour returns the most recent payments made with a card ending in lastFour
+// (uses idx_payments_card_last_four)
+func (r *PostgresPaymentRepository) ListByCardLastFour(ctx context.Context, tenantID, lastFour string, limit int) ([]*domain.Payment, error) {
+	query := `SELECT ` + selectColumns + ` FROM payments
+		WHERE card_last_four = $1 AND ($2 = '' OR tenant_id::text = $2)
+		ORDER BY created_at DESC
+		LIMIT $3`
+
+	return r.queryPayments(ctx, query, lastFour, tenantID, limit)
+}
+
+// ListStuckProcessing returns payments processing since before cutoff, oldest first
+// (uses idx_payments_status)
+func (r *PostgresPaymentRepository) ListStuckProcessing(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Payment, error) {
+	query := `SELECT ` + selectColumns + ` FROM payments
+		WHERE status = 'processing' AND updated_at < $1
+		ORDER BY updated_at
+		LIMIT $2`
+
+	return r.queryPayments(ctx, query, cutoff, limit)
+}
+
+// ListRequiringAction returns payments requiring action since before cutoff, oldest first
+// (uses idx_payments_status)
+func (r *PostgresPaymentRepository) ListRequiringAction(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Payment, error) {
+	query := `SELECT ` + selectColumns + ` FROM payments
+		WHERE status = 'requires_action' AND updated_at < $1
+		ORDER BY updated_at
+		LIMIT $2`
+
+	return r.queryPayments(ctx, query, cutoff, limit)
+}
+
+// queryPayments runs a query returning payment rows
+func (r *PostgresPaymentRepository) queryPayments(ctx context.Context, query string, args ...interface{}) ([]*domain.Payment, error) {
+	rows, err := r.db.Pool().Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query payments: %w", err)
+	}
+	defer rows.Close()
+
+	payments := []*domain.Payment{}
+	for rows.Next() {
+		payment, err := r.scanPaymentFromRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, payment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate payments: %w", err)
+	}
+
+	return payments, nil
+}
+
+// AnonymizeByUserID re-assigns a user's payments to an anonymized ID.
+// Gateway payment/charge IDs are kept for refunds and reconciliation; customer, card,
+// raw gateway response, metadata and buyer tax details are cleared because they may
+// contain PII. Issued invoices keep their buyer snapshot as a legal record.
+func (r *PostgresPaymentRepository) AnonymizeByUserID(ctx context.Context, userID, anonymizedID string) (int64, error) {
+	query := `
+		UPDATE payments
+		SET user_id = $2,
+		    gateway_customer_id = NULL,
+		    gateway_response = NULL,
+		    idempotency_key = NULL,
+		    card_last_four = NULL,
+		    card_brand = NULL,
+		    metadata = '{}',
+		    tax_info = NULL,
+		    updated_at = NOW()
+		WHERE user_id = $1`
+
+	result, err := r.db.Pool().Exec(ctx, query, userID, anonymizedID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to anonymize payments: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
 // Update updates an existing payment
 func (r *PostgresPaymentRepository) Update(ctx context.Context, payment *domain.Payment) error {
 	query := `
@@ -173,7 +309,8 @@ func (r *PostgresPaymentRepository) Update(ctx context.Context, payment *domain.
 		    error_message = $17,
 		    retry_count = $18,
 		    metadata = $19,
-		    updated_at = $20
+		    updated_at = $20,
+		    amount = $21
 		WHERE id = $1`
 
 	metadataJSON, err := json.Marshal(payment.Metadata)
@@ -214,6 +351,7 @@ func (r *PostgresPaymentRepository) Update(ctx context.Context, payment *domain.
 		payment.RetryCount,
 		metadataJSON,
 		payment.UpdatedAt,
+		payment.Amount,
 	)
 
 	if err != nil {
@@ -244,7 +382,7 @@ func (r *PostgresPaymentRepository) scanPayment(row pgx.Row) (*domain.Payment, e
 	var payment domain.Payment
 	var status string
 	var method *string
-	var metadataJSON, gatewayResponseJSON []byte
+	var metadataJSON, gatewayResponseJSON, taxInfoJSON []byte
 	var gateway, gatewayPaymentID, gatewayChargeID, gatewayCustomerID *string
 	var idempotencyKey, cardLastFour, cardBrand *string
 	var refundReason, errorCode, errorMessage *string
@@ -277,6 +415,7 @@ func (r *PostgresPaymentRepository) scanPayment(row pgx.Row) (*domain.Payment, e
 		&metadataJSON,
 		&payment.CreatedAt,
 		&payment.UpdatedAt,
+		&taxInfoJSON,
 	)
 
 	if err != nil {
@@ -335,6 +474,12 @@ func (r *PostgresPaymentRepository) scanPayment(row pgx.Row) (*domain.Payment, e
 		}
 	}
 
+	if len(taxInfoJSON) > 0 {
+		if err := json.Unmarshal(taxInfoJSON, &payment.TaxInfo); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal tax_info: %w", err)
+		}
+	}
+
 	return &payment, nil
 }
 
@@ -343,7 +488,7 @@ func (r *PostgresPaymentRepository) scanPaymentFromRows(rows pgx.Rows) (*domain.
 	var payment domain.Payment
 	var status string
 	var method *string
-	var metadataJSON, gatewayResponseJSON []byte
+	var metadataJSON, gatewayResponseJSON, taxInfoJSON []byte
 	var gateway, gatewayPaymentID, gatewayChargeID, gatewayCustomerID *string
 	var idempotencyKey, cardLastFour, cardBrand *string
 	var refundReason, errorCode, errorMessage *string
@@ -376,6 +521,7 @@ func (r *PostgresPaymentRepository) scanPaymentFromRows(rows pgx.Rows) (*domain.
 		&metadataJSON,
 		&payment.CreatedAt,
 		&payment.UpdatedAt,
+		&taxInfoJSON,
 	)
 
 	if err != nil {
@@ -431,5 +577,11 @@ func (r *PostgresPaymentRepository) scanPaymentFromRows(rows pgx.Rows) (*domain.
 		}
 	}
 
+	if len(taxInfoJSON) > 0 {
+		if err := json.Unmarshal(taxInfoJSON, &payment.TaxInfo); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal tax_info: %w", err)
+		}
+	}
+
 	return &payment, nil
 }
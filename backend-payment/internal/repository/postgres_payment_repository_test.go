@@ -8,6 +8,7 @@ import (
 
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 )
 
 func skipIfNoIntegration(t *testing.T) {
@@ -168,7 +169,7 @@ func TestPostgresPaymentRepository_GetByUserID(t *testing.T) {
 		repo.Create(ctx, payment)
 	}
 
-	payments, err := repo.GetByUserID(ctx, testUserID, 10, 0)
+	payments, err := repo.GetByUserID(ctx, testUserID, pagination.Params{Limit: 10})
 	if err != nil {
 		t.Fatalf("Failed to get payments by user ID: %v", err)
 	}
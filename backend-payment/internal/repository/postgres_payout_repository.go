@@ -0,0 +1,304 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
+)
+
+// PostgresPayoutRepository implements PayoutRepository using PostgreSQL
+type PostgresPayoutRepository struct {
+	db *database.PostgresDB
+}
+
+// NewPostgresPayoutRepository creates a new PostgreSQL payout repository
+func NewPostgresPayoutRepository(db *database.PostgresDB) *PostgresPayoutRepository {
+	return &PostgresPayoutRepository{db: db}
+}
+
+// payoutScheduleColumns defines the columns to select for payout schedule queries
+const payoutScheduleColumns = `
+	tenant_id, frequency, method, currency, bank_account_token, bank_code, account_holder,
+	account_last4, minimum_amount, enabled, next_payout_at, created_at, updated_at
+`
+
+// SaveSchedule creates or replaces a tenant's payout schedule
+func (r *PostgresPayoutRepository) SaveSchedule(ctx context.Context, schedule *domain.PayoutSchedule) error {
+	query := `
+		INSERT INTO payout_schedules (` + payoutScheduleColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (tenant_id) DO UPDATE SET
+			frequency = EXCLUDED.frequency,
+			method = EXCLUDED.method,
+			currency = EXCLUDED.currency,
+			bank_account_token = EXCLUDED.bank_account_token,
+			bank_code = EXCLUDED.bank_code,
+			account_holder = EXCLUDED.account_holder,
+			account_last4 = EXCLUDED.account_last4,
+			minimum_amount = EXCLUDED.minimum_amount,
+			enabled = EXCLUDED.enabled,
+			next_payout_at = EXCLUDED.next_payout_at,
+			updated_at = EXCLUDED.updated_at
+		RETURNING created_at`
+
+	err := r.db.Pool().QueryRow(ctx, query,
+		schedule.TenantID,
+		string(schedule.Frequency),
+		string(schedule.Method),
+		schedule.Currency,
+		schedule.BankAccountToken,
+		schedule.BankCode,
+		schedule.AccountHolder,
+		schedule.AccountLast4,
+		schedule.MinimumAmount,
+		schedule.Enabled,
+		schedule.NextPayoutAt,
+		schedule.CreatedAt,
+		schedule.UpdatedAt,
+	).Scan(&schedule.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save payout schedule: %w", err)
+	}
+	return nil
+}
+
+// GetSchedule retrieves a tenant's payout schedule
+func (r *PostgresPayoutRepository) GetSchedule(ctx context.Context, tenantID string) (*domain.PayoutSchedule, error) {
+	query := `SELECT ` + payoutScheduleColumns + ` FROM payout_schedules WHERE tenant_id = $1`
+	return scanPayoutSchedule(r.db.Pool().QueryRow(ctx, query, tenantID))
+}
+
+// ListDueSchedules returns up to limit enabled schedules due at now, oldest due first
+func (r *PostgresPayoutRepository) ListDueSchedules(ctx context.Context, now time.Time, limit int) ([]*domain.PayoutSchedule, error) {
+	query := `SELECT ` + payoutScheduleColumns + ` FROM payout_schedules
+		WHERE enabled AND next_payout_at <= $1
+		ORDER BY next_payout_at
+		LIMIT $2`
+
+	rows, err := r.db.Pool().Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due payout schedules: %w", err)
+	}
+	defer rows.Close()
+
+	var schedules []*domain.PayoutSchedule
+	for rows.Next() {
+		schedule, err := scanPayoutSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate payout schedules: %w", err)
+	}
+	return schedules, nil
+}
+
+// ClaimSchedule moves a schedule due at due on to next, only if no other worker did
+func (r *PostgresPayoutRepository) ClaimSchedule(ctx context.Context, tenantID string, due, next time.Time) (bool, error) {
+	result, err := r.db.Pool().Exec(ctx, `
+		UPDATE payout_schedules SET next_payout_at = $3, updated_at = NOW()
+		WHERE tenant_id = $1 AND next_payout_at = $2 AND enabled`,
+		tenantID, due, next)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim payout schedule: %w", err)
+	}
+	return result.RowsAffected() == 1, nil
+}
+
+// payoutColumns defines the columns to select for payout queries
+const payoutColumns = `
+	id, tenant_id, method, status, currency, gross_amount, fee_amount, amount, period_end,
+	bank_account_token, reference, bank_file_key, failure_reason, ledger_posted, created_at, updated_at
+`
+
+// Create saves a payout
+func (r *PostgresPayoutRepository) Create(ctx context.Context, payout *domain.Payout) error {
+	query := `
+		INSERT INTO payouts (` + payoutColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`
+
+	_, err := r.db.Pool().Exec(ctx, query,
+		payout.ID,
+		payout.TenantID,
+		string(payout.Method),
+		string(payout.Status),
+		payout.Currency,
+		payout.GrossAmount,
+		payout.FeeAmount,
+		payout.Amount,
+		payout.PeriodEnd,
+		payout.BankAccountToken,
+		payout.Reference,
+		payout.BankFileKey,
+		payout.FailureReason,
+		payout.LedgerPosted,
+		payout.CreatedAt,
+		payout.UpdatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolationCode {
+			return domain.ErrPayoutExists
+		}
+		return fmt.Errorf("failed to create payout: %w", err)
+	}
+	return nil
+}
+
+// Update updates an existing payout
+func (r *PostgresPayoutRepository) Update(ctx context.Context, payout *domain.Payout) error {
+	query := `
+		UPDATE payouts SET
+			status = $2,
+			reference = $3,
+			bank_file_key = $4,
+			failure_reason = $5,
+			ledger_posted = $6,
+			updated_at = $7
+		WHERE id = $1`
+
+	result, err := r.db.Pool().Exec(ctx, query,
+		payout.ID,
+		string(payout.Status),
+		payout.Reference,
+		payout.BankFileKey,
+		payout.FailureReason,
+		payout.LedgerPosted,
+		payout.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update payout: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrPayoutNotFound
+	}
+	return nil
+}
+
+// GetByID retrieves a payout by its ID
+func (r *PostgresPayoutRepository) GetByID(ctx context.Context, id string) (*domain.Payout, error) {
+	query := `SELECT ` + payoutColumns + ` FROM payouts WHERE id = $1`
+	return scanPayout(r.db.Pool().QueryRow(ctx, query, id))
+}
+
+// List returns a page of the payouts selected by filter, newest first
+func (r *PostgresPayoutRepository) List(ctx context.Context, filter *domain.PayoutFilter, page pagination.Params) ([]*domain.Payout, error) {
+	where := "tenant_id = $1"
+	args := []interface{}{filter.TenantID}
+	if filter.Status != "" {
+		args = append(args, string(filter.Status))
+		where += fmt.Sprintf(" AND status = $%d", len(args))
+	}
+	if page.After != nil {
+		where += " AND " + pagination.Keyset("created_at", "id", len(args)+1)
+		args = append(args, page.After.Time, page.After.ID)
+	}
+	args = append(args, page.Limit, page.Offset)
+
+	query := fmt.Sprintf(`SELECT `+payoutColumns+` FROM payouts
+		WHERE %s
+		ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d`, where, len(args)-1, len(args))
+	return r.queryPayouts(ctx, query, args...)
+}
+
+// ListUnsettled returns a tenant's payouts still pending or not yet posted to the ledger
+func (r *PostgresPayoutRepository) ListUnsettled(ctx context.Context, tenantID string) ([]*domain.Payout, error) {
+	query := `SELECT ` + payoutColumns + ` FROM payouts
+		WHERE tenant_id = $1 AND NOT ledger_posted AND status <> 'failed'
+		ORDER BY created_at`
+	return r.queryPayouts(ctx, query, tenantID)
+}
+
+// queryPayouts runs a query selecting payoutColumns
+func (r *PostgresPayoutRepository) queryPayouts(ctx context.Context, query string, args ...interface{}) ([]*domain.Payout, error) {
+	rows, err := r.db.Pool().Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query payouts: %w", err)
+	}
+	defer rows.Close()
+
+	payouts := []*domain.Payout{}
+	for rows.Next() {
+		payout, err := scanPayout(rows)
+		if err != nil {
+			return nil, err
+		}
+		payouts = append(payouts, payout)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate payouts: %w", err)
+	}
+	return payouts, nil
+}
+
+// scanPayoutSchedule scans a single payout schedule from a row
+func scanPayoutSchedule(row pgx.Row) (*domain.PayoutSchedule, error) {
+	var schedule domain.PayoutSchedule
+	var frequency, method string
+	err := row.Scan(
+		&schedule.TenantID,
+		&frequency,
+		&method,
+		&schedule.Currency,
+		&schedule.BankAccountToken,
+		&schedule.BankCode,
+		&schedule.AccountHolder,
+		&schedule.AccountLast4,
+		&schedule.MinimumAmount,
+		&schedule.Enabled,
+		&schedule.NextPayoutAt,
+		&schedule.CreatedAt,
+		&schedule.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrPayoutScheduleNotFound
+		}
+		return nil, fmt.Errorf("failed to scan payout schedule: %w", err)
+	}
+	schedule.Frequency = domain.PayoutFrequency(frequency)
+	schedule.Method = domain.PayoutMethod(method)
+	return &schedule, nil
+}
+
+// scanPayout scans a single payout from a row
+func scanPayout(row pgx.Row) (*domain.Payout, error) {
+	var payout domain.Payout
+	var method, status string
+	err := row.Scan(
+		&payout.ID,
+		&payout.TenantID,
+		&method,
+		&status,
+		&payout.Currency,
+		&payout.GrossAmount,
+		&payout.FeeAmount,
+		&payout.Amount,
+		&payout.PeriodEnd,
+		&payout.BankAccountToken,
+		&payout.Reference,
+		&payout.BankFileKey,
+		&payout.FailureReason,
+		&payout.LedgerPosted,
+		&payout.CreatedAt,
+		&payout.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrPayoutNotFound
+		}
+		return nil, fmt.Errorf("failed to scan payout: %w", err)
+	}
+	payout.Method = domain.PayoutMethod(method)
+	payout.Status = domain.PayoutStatus(status)
+	return &payout, nil
+}
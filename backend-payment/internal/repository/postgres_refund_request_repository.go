@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+)
+
+// PostgresRefundRequestRepository implements RefundRequestRepository using PostgreSQL
+type PostgresRefundRequestRepository struct {
+	db *database.PostgresDB
+}
+
+// NewPostgresRefundRequestRepository creates a new PostgreSQL refund request repository
+func NewPostgresRefundRequestRepository(db *database.PostgresDB) *PostgresRefundRequestRepository {
+	return &PostgresRefundRequestRepository{db: db}
+}
+
+// refundRequestColumns defines the columns of refund request queries
+const refundRequestColumns = `
+	id, tenant_id, payment_id, booking_id, amount, currency, reason, status, requested_by,
+	decided_by, decision_reason, failure_reason, decided_at, completed_at, created_at, updated_at
+`
+
+// Create saves a new refund request. The partial unique index on open requests turns a
+// second request for the same payment into ErrRefundRequestExists.
+func (r *PostgresRefundRequestRepository) Create(ctx context.Context, request *domain.RefundRequest) error {
+	query := `
+		INSERT INTO refund_requests (` + refundRequestColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`
+
+	_, err := r.db.Pool().Exec(ctx, query,
+		request.ID,
+		request.TenantID,
+		request.PaymentID,
+		request.BookingID,
+		request.Amount,
+		request.Currency,
+		request.Reason,
+		string(request.Status),
+		request.RequestedBy,
+		request.DecidedBy,
+		request.DecisionReason,
+		request.FailureReason,
+		request.DecidedAt,
+		request.CompletedAt,
+		request.CreatedAt,
+		request.UpdatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolationCode {
+			return domain.ErrRefundRequestExists
+		}
+		return fmt.Errorf("failed to create refund request: %w", err)
+	}
+	return nil
+}
+
+// Update saves a refund request that was in status from
+func (r *PostgresRefundRequestRepository) Update(ctx context.Context, request *domain.RefundRequest, from domain.RefundRequestStatus) error {
+	query := `
+		UPDATE refund_requests SET
+			status = $2,
+			decided_by = $3,
+			decision_reason = $4,
+			failure_reason = $5,
+			decided_at = $6,
+			completed_at = $7,
+			updated_at = $8
+		WHERE id = $1 AND status = $9`
+
+	result, err := r.db.Pool().Exec(ctx, query,
+		request.ID,
+		string(request.Status),
+		request.DecidedBy,
+		request.DecisionReason,
+		request.FailureReason,
+		request.DecidedAt,
+		request.CompletedAt,
+		request.UpdatedAt,
+		string(from),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update refund request: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		if _, err := r.GetByID(ctx, request.ID); err != nil {
+			return err
+		}
+		return domain.ErrRefundRequestChanged
+	}
+	return nil
+}
+
+// GetByID retrieves a refund request by its ID
+func (r *PostgresRefundRequestRepository) GetByID(ctx context.Context, id string) (*domain.RefundRequest, error) {
+	query := `SELECT ` + refundRequestColumns + ` FROM refund_requests WHERE id = $1`
+	return scanRefundRequest(r.db.Pool().QueryRow(ctx, query, id))
+}
+
+// List returns the refund requests selected by filter, newest first
+func (r *PostgresRefundRequestRepository) List(ctx context.Context, filter *domain.RefundRequestFilter) ([]*domain.RefundRequest, error) {
+	query := `SELECT ` + refundRequestColumns + ` FROM refund_requests
+		WHERE ($1 = '' OR tenant_id::text = $1)
+			AND ($2 = '' OR payment_id::text = $2)
+			AND ($3 = '' OR status = $3)
+		ORDER BY created_at DESC`
+
+	rows, err := r.db.Pool().Query(ctx, query, filter.TenantID, filter.PaymentID, string(filter.Status))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list refund requests: %w", err)
+	}
+	defer rows.Close()
+
+	var requests []*domain.RefundRequest
+	for rows.Next() {
+		request, err := scanRefundRequest(rows)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, request)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate refund requests: %w", err)
+	}
+	return requests, nil
+}
+
+// SavePolicy creates or replaces a tenant's refund approval policy
+func (r *PostgresRefundRequestRepository) SavePolicy(ctx context.Context, policy *domain.RefundApprovalPolicy) error {
+	query := `
+		INSERT INTO refund_approval_policies (tenant_id, threshold, updated_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (tenant_id) DO UPDATE SET
+			threshold = EXCLUDED.threshold,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at
+		RETURNING created_at`
+
+	err := r.db.Pool().QueryRow(ctx, query,
+		policy.TenantID,
+		policy.Threshold,
+		policy.UpdatedBy,
+		policy.CreatedAt,
+		policy.UpdatedAt,
+	).Scan(&policy.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save refund approval policy: %w", err)
+	}
+	return nil
+}
+
+// GetPolicy retrieves a tenant's refund approval policy
+func (r *PostgresRefundRequestRepository) GetPolicy(ctx context.Context, tenantID string) (*domain.RefundApprovalPolicy, error) {
+	query := `
+		SELECT tenant_id, threshold, updated_by, created_at, updated_at
+		FROM refund_approval_policies WHERE tenant_id = $1`
+
+	var policy domain.RefundApprovalPolicy
+	err := r.db.Pool().QueryRow(ctx, query, tenantID).Scan(
+		&policy.TenantID,
+		&policy.Threshold,
+		&policy.UpdatedBy,
+		&policy.CreatedAt,
+		&policy.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrRefundApprovalPolicyNotFound
+		}
+		return nil, fmt.Errorf("failed to get refund approval policy: %w", err)
+	}
+	return &policy, nil
+}
+
+// scanRefundRequest scans a single refund request from a row
+func scanRefundRequest(row pgx.Row) (*domain.RefundRequest, error) {
+	var request domain.RefundRequest
+	var status string
+	err := row.Scan(
+		&request.ID,
+		&request.TenantID,
+		&request.PaymentID,
+		&request.BookingID,
+		&request.Amount,
+		&request.Currency,
+		&request.Reason,
+		&status,
+		&request.RequestedBy,
+		&request.DecidedBy,
+		&request.DecisionReason,
+		&request.FailureReason,
+		&request.DecidedAt,
+		&request.CompletedAt,
+		&request.CreatedAt,
+		&request.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrRefundRequestNotFound
+		}
+		return nil, fmt.Errorf("failed to scan refund request: %w", err)
+	}
+	request.Status = domain.RefundRequestStatus(status)
+	return &request, nil
+}
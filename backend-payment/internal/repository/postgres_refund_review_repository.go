@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+)
+
+// PostgresRefundReviewRepository implements RefundReviewRepository using PostgreSQL
+type PostgresRefundReviewRepository struct {
+	db *database.PostgresDB
+}
+
+// NewPostgresRefundReviewRepository creates a new PostgreSQL refund review repository
+func NewPostgresRefundReviewRepository(db *database.PostgresDB) *PostgresRefundReviewRepository {
+	return &PostgresRefundReviewRepository{db: db}
+}
+
+// Save records the review of a refunded gateway charge. Webhook retries for the same
+// charge update the existing row instead of flagging the refund twice.
+func (r *PostgresRefundReviewRepository) Save(ctx context.Context, review *domain.RefundReview) error {
+	query := `
+		INSERT INTO refund_reviews (
+			id, gateway_charge_id, gateway_payment_id, payment_id, amount_refunded, currency,
+			reason, detail, status, created_at, updated_at
+		) VALUES (
+			$1, $2, $3, NULLIF($4, '')::uuid, $5, $6, $7, $8, $9, $10, $11
+		)
+		ON CONFLICT (gateway_charge_id) DO UPDATE SET
+			gateway_payment_id = EXCLUDED.gateway_payment_id,
+			payment_id = EXCLUDED.payment_id,
+			reason = EXCLUDED.reason,
+			detail = EXCLUDED.detail,
+			status = CASE WHEN refund_reviews.amount_refunded = EXCLUDED.amount_refunded
+				THEN refund_reviews.status ELSE EXCLUDED.status END,
+			resolved_by = CASE WHEN refund_reviews.amount_refunded = EXCLUDED.amount_refunded
+				THEN refund_reviews.resolved_by ELSE NULL END,
+			resolution_note = CASE WHEN refund_reviews.amount_refunded = EXCLUDED.amount_refunded
+				THEN refund_reviews.resolution_note ELSE NULL END,
+			resolved_at = CASE WHEN refund_reviews.amount_refunded = EXCLUDED.amount_refunded
+				THEN refund_reviews.resolved_at ELSE NULL END,
+			amount_refunded = EXCLUDED.amount_refunded,
+			updated_at = EXCLUDED.updated_at
+		RETURNING id, status, COALESCE(resolved_by, ''), COALESCE(resolution_note, ''), resolved_at, created_at`
+
+	var status string
+	err := r.db.Pool().QueryRow(ctx, query,
+		review.ID,
+		review.GatewayChargeID,
+		review.GatewayPaymentID,
+		review.PaymentID,
+		review.AmountRefunded,
+		review.Currency,
+		string(review.Reason),
+		review.Detail,
+		string(review.Status),
+		review.CreatedAt,
+		review.UpdatedAt,
+	).Scan(&review.ID, &status, &review.ResolvedBy, &review.ResolutionNote, &review.ResolvedAt, &review.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save refund review: %w", err)
+	}
+	review.Status = domain.RefundReviewStatus(status)
+	return nil
+}
+
+// refundReviewColumns defines the columns to select for refund review queries
+const refundReviewColumns = `
+	id, gateway_charge_id, gateway_payment_id, COALESCE(payment_id::text, ''), amount_refunded, currency,
+	reason, detail, status, COALESCE(resolved_by, ''), COALESCE(resolution_note, ''), resolved_at,
+	created_at, updated_at
+`
+
+// GetByID retrieves a review by its ID
+func (r *PostgresRefundReviewRepository) GetByID(ctx context.Context, id string) (*domain.RefundReview, error) {
+	query := `SELECT ` + refundReviewColumns + ` FROM refund_reviews WHERE id = $1`
+	return scanRefundReview(r.db.Pool().QueryRow(ctx, query, id))
+}
+
+// List returns the reviews in a status (all when empty), newest first
+func (r *PostgresRefundReviewRepository) List(ctx context.Context, status domain.RefundReviewStatus) ([]*domain.RefundReview, error) {
+	query := `SELECT ` + refundReviewColumns + ` FROM refund_reviews
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY created_at DESC`
+
+	rows, err := r.db.Pool().Query(ctx, query, string(status))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list refund reviews: %w", err)
+	}
+	defer rows.Close()
+
+	var reviews []*domain.RefundReview
+	for rows.Next() {
+		review, err := scanRefundReview(rows)
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, review)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate refund reviews: %w", err)
+	}
+	return reviews, nil
+}
+
+// Update updates an existing review
+func (r *PostgresRefundReviewRepository) Update(ctx context.Context, review *domain.RefundReview) error {
+	query := `
+		UPDATE refund_reviews SET
+			status = $2,
+			resolved_by = NULLIF($3, ''),
+			resolution_note = NULLIF($4, ''),
+			resolved_at = $5,
+			updated_at = $6
+		WHERE id = $1`
+
+	result, err := r.db.Pool().Exec(ctx, query,
+		review.ID,
+		string(review.Status),
+		review.ResolvedBy,
+		review.ResolutionNote,
+		review.ResolvedAt,
+		review.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update refund review: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrRefundReviewNotFound
+	}
+	return nil
+}
+
+// scanRefundReview scans a single refund review from a row
+func scanRefundReview(row pgx.Row) (*domain.RefundReview, error) {
+	var review domain.RefundReview
+	var reason, status string
+	err := row.Scan(
+		&review.ID,
+		&review.GatewayChargeID,
+		&review.GatewayPaymentID,
+		&review.PaymentID,
+		&review.AmountRefunded,
+		&review.Currency,
+		&reason,
+		&review.Detail,
+		&status,
+		&review.ResolvedBy,
+		&review.ResolutionNote,
+		&review.ResolvedAt,
+		&review.CreatedAt,
+		&review.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrRefundReviewNotFound
+		}
+		return nil, fmt.Errorf("failed to scan refund review: %w", err)
+	}
+	review.Reason = domain.RefundReviewReason(reason)
+	review.Status = domain.RefundReviewStatus(status)
+	return &review, nil
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+)
+
+// RefundRequestRepository stores refund requests and the tenants' approval thresholds
+type RefundRequestRepository interface {
+	// Create saves a new refund request. Returns ErrRefundRequestExists if the payment
+	// already has an open request, so a refund can't be requested twice.
+	Create(ctx context.Context, request *domain.RefundRequest) error
+
+	// Update saves a refund request that was in status from. Returns
+	// ErrRefundRequestChanged if its status changed since it was read, so two admins
+	// deciding on the same request can't both send it to the gateway.
+	Update(ctx context.Context, request *domain.RefundRequest, from domain.RefundRequestStatus) error
+
+	// GetByID retrieves a refund request by its ID
+	GetByID(ctx context.Context, id string) (*domain.RefundRequest, error)
+
+	// List returns the refund requests selected by filter, newest first
+	List(ctx context.Context, filter *domain.RefundRequestFilter) ([]*domain.RefundRequest, error)
+
+	// SavePolicy creates or replaces a tenant's refund approval policy
+	SavePolicy(ctx context.Context, policy *domain.RefundApprovalPolicy) error
+
+	// GetPolicy retrieves a tenant's refund approval policy
+	GetPolicy(ctx context.Context, tenantID string) (*domain.RefundApprovalPolicy, error)
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+)
+
+// RefundReviewRepository stores gateway refunds flagged for manual review
+type RefundReviewRepository interface {
+	// Save records the review of a refunded gateway charge. A charge that is already
+	// flagged keeps its review, updated with the latest amount and reason; a resolved
+	// review is reopened when the gateway has refunded more since.
+	Save(ctx context.Context, review *domain.RefundReview) error
+
+	// GetByID retrieves a review by its ID
+	GetByID(ctx context.Context, id string) (*domain.RefundReview, error)
+
+	// List returns the reviews in a status (all when empty), newest first
+	List(ctx context.Context, status domain.RefundReviewStatus) ([]*domain.RefundReview, error)
+
+	// Update updates an existing review
+	Update(ctx context.Context, review *domain.RefundReview) error
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+)
+
+// StuckPaymentRepository defines the lookups of the payment watchdog
+type StuckPaymentRepository interface {
+	// ListStuckProcessing returns payments processing since before cutoff, oldest first
+	ListStuckProcessing(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Payment, error)
+
+	// ListRequiringAction returns payments waiting on the customer at the gateway since
+	// before cutoff, oldest first
+	ListRequiringAction(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Payment, error)
+}
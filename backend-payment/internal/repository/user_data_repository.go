@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+)
+
+// UserDataRepository defines data access for user data export and anonymization (GDPR)
+type UserDataRepository interface {
+	// ListByUserID returns every payment made by a user (no pagination, for export)
+	ListByUserID(ctx context.Context, userID string) ([]*domain.Payment, error)
+
+	// AnonymizeByUserID re-assigns a user's payments to an anonymized ID and clears
+	// customer and card details. Returns the number of payments anonymized.
+	AnonymizeByUserID(ctx context.Context, userID, anonymizedID string) (int64, error)
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"bytes"
+	"html/template"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+)
+
+// receiptTemplate renders a printable receipt/tax invoice (ใบเสร็จรับเงิน/ใบกำกับภาษี)
+var receiptTemplate = template.Must(template.New("receipt").Parse(`<!DOCTYPE html>
+<html lang="th">
+<head>
+<meta charset="utf-8">
+<title>{{.Invoice.Number}}</title>
+<style>
+body { font-family: sans-serif; max-width: 720px; margin: 2em auto; color: #222; }
+table { width: 100%; border-collapse: collapse; margin-top: 1em; }
+th, td { text-align: left; padding: 0.4em; border-bottom: 1px solid #ddd; }
+td.amount { text-align: right; }
+</style>
+</head>
+<body>
+<h1>ใบเสร็จรับเงิน/ใบกำกับภาษี<br><small>Receipt/Tax Invoice</small></h1>
+<p>เลขที่ (No.) {{.Invoice.Number}}<br>วันที่ (Date) {{.IssueDate}}</p>
+<h2>ผู้ขาย (Seller)</h2>
+<p>{{.Seller.SellerName}}<br>เลขประจำตัวผู้เสียภาษี (Tax ID) {{.Seller.SellerTaxID}} สาขา (Branch) {{.Seller.SellerBranch}}<br>{{.Seller.SellerAddress}}</p>
+<h2>ผู้ซื้อ (Buyer)</h2>
+<p>{{.Invoice.Buyer.CompanyName}}<br>เลขประจำตัวผู้เสียภาษี (Tax ID) {{.Invoice.Buyer.TaxID}} สาขา (Branch) {{.Invoice.Buyer.BranchCode}}<br>{{.Invoice.Buyer.Address}}</p>
+<table>
+<tr><th>รายการ (Description)</th><th></th></tr>
+<tr><td>บัตรเข้างาน การจอง {{.Invoice.BookingID}} (Tickets, booking {{.Invoice.BookingID}})</td><td class="amount">{{.Subtotal}}</td></tr>
+<tr><td>ภาษีมูลค่าเพิ่ม {{.VATRate}}% (VAT)</td><td class="amount">{{.VATAmount}}</td></tr>
+<tr><th>รวมทั้งสิ้น (Total, {{.Invoice.Currency}})</th><td class="amount"><strong>{{.Total}}</strong></td></tr>
+</table>
+</body>
+</html>
+`))
+
+// receiptView holds the formatted values of a rendered receipt
+type receiptView struct {
+	Invoice   *domain.Invoice
+	Seller    *InvoiceServiceConfig
+	IssueDate string
+	Subtotal  string
+	VATRate   string
+	VATAmount string
+	Total     string
+}
+
+// renderReceipt renders the receipt of an invoice as HTML
+func (s *invoiceServiceImpl) renderReceipt(invoice *domain.Invoice) ([]byte, error) {
+	var buf bytes.Buffer
+	err := receiptTemplate.Execute(&buf, receiptView{
+		Invoice:   invoice,
+		Seller:    s.config,
+		IssueDate: invoice.IssuedAt.In(domain.InvoiceLocation).Format("2006-01-02"),
+		Subtotal:  formatAmount(invoice.Subtotal),
+		VATRate:   formatAmount(invoice.VATRate * 100),
+		VATAmount: formatAmount(invoice.VATAmount),
+		Total:     formatAmount(invoice.Total),
+	})
+	return buf.Bytes(), err
+}
@@ -0,0 +1,418 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/storage"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// ETaxDocumentType is the e-Tax document type code of a receipt/tax invoice
+// (ใบเสร็จรับเงิน/ใบกำกับภาษี), issued once the payment has been received
+const ETaxDocumentType = "T03"
+
+// ETaxExportHeader lists the columns of the monthly export, named after the e-Tax
+// Invoice & e-Receipt data elements so finance can map them onto the Revenue
+// Department upload without renaming
+var ETaxExportHeader = []string{
+	"DocumentTypeCode",
+	"DocumentID",
+	"IssueDate",
+	"SellerTaxID",
+	"SellerBranchID",
+	"SellerName",
+	"SellerAddress",
+	"BuyerName",
+	"BuyerTaxID",
+	"BuyerBranchID",
+	"BuyerAddress",
+	"Currency",
+	"BasisAmount",
+	"TaxRate",
+	"TaxAmount",
+	"GrandTotal",
+	"ReferenceID",
+}
+
+// Object storage prefixes of the files the invoice service keeps
+const (
+	InvoiceExportPrefix = "etax-exports/"
+	ReceiptPrefix       = "receipts/"
+)
+
+// InvoiceServiceConfig holds the seller details printed on every tax invoice
+type InvoiceServiceConfig struct {
+	SellerName    string
+	SellerTaxID   string
+	SellerBranch  string // Defaults to head office (00000)
+	SellerAddress string
+
+	// Files keeps stored exports and rendered receipts; without it they are not available
+	Files       storage.Store
+	DownloadTTL time.Duration // How long a signed download URL is valid
+}
+
+// StoredExport is a monthly e-Tax export kept in file storage
+type StoredExport struct {
+	Key         string
+	Count       int
+	DownloadURL string
+	ExpiresAt   time.Time
+}
+
+// IssueInvoiceRequest represents a request to issue a tax invoice for a payment (internal)
+type IssueInvoiceRequest struct {
+	PaymentID string
+	UserID    string          // Must own the payment when set
+	Buyer     *domain.TaxInfo // Overrides the buyer details captured with the payment
+}
+
+// InvoiceService issues full tax invoices for charged payments and exports them for e-Tax filing
+type InvoiceService interface {
+	// IssueInvoice issues the tax invoice of a payment. Issuing again returns the
+	// invoice already issued, so each payment uses one running number.
+	IssueInvoice(ctx context.Context, req *IssueInvoiceRequest) (*domain.Invoice, error)
+
+	// GetInvoice retrieves an invoice by ID
+	GetInvoice(ctx context.Context, invoiceID string) (*domain.Invoice, error)
+
+	// GetInvoiceByPaymentID retrieves the invoice issued for a payment
+	GetInvoiceByPaymentID(ctx context.Context, paymentID string) (*domain.Invoice, error)
+
+	// ListMonth returns a tenant's invoices issued in a YYYY-MM month (Thai time)
+	ListMonth(ctx context.Context, tenantID, month string) ([]*domain.Invoice, error)
+
+	// ExportMonth writes a tenant's invoices of a YYYY-MM month as e-Tax CSV and
+	// returns the number of invoices written
+	ExportMonth(ctx context.Context, tenantID, month string, w io.Writer) (int, error)
+
+	// StoreExport keeps a tenant's e-Tax CSV of a YYYY-MM month in file storage and
+	// returns a signed URL to download it from
+	StoreExport(ctx context.Context, tenantID, month string) (*StoredExport, error)
+
+	// ReceiptURL renders the receipt of a payment's tax invoice into file storage and
+	// returns a signed URL to it. userID must own the payment when set.
+	ReceiptURL(ctx context.Context, paymentID, userID string) (string, error)
+}
+
+// invoiceServiceImpl implements InvoiceService
+type invoiceServiceImpl struct {
+	invoiceRepo repository.InvoiceRepository
+	paymentRepo repository.PaymentRepository
+	config      *InvoiceServiceConfig
+	now         func() time.Time
+}
+
+// NewInvoiceService creates a new InvoiceService
+func NewInvoiceService(invoiceRepo repository.InvoiceRepository, paymentRepo repository.PaymentRepository, config *InvoiceServiceConfig) InvoiceService {
+	if config == nil {
+		config = &InvoiceServiceConfig{}
+	}
+	if config.SellerBranch == "" {
+		config.SellerBranch = domain.HeadOfficeBranch
+	}
+	if config.DownloadTTL == 0 {
+		config.DownloadTTL = 15 * time.Minute
+	}
+	return &invoiceServiceImpl{
+		invoiceRepo: invoiceRepo,
+		paymentRepo: paymentRepo,
+		config:      config,
+		now:         time.Now,
+	}
+}
+
+// IssueInvoice issues the tax invoice of a payment
+func (s *invoiceServiceImpl) IssueInvoice(ctx context.Context, req *IssueInvoiceRequest) (*domain.Invoice, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.invoice.issue")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("payment_id", req.PaymentID))
+
+	payment, err := s.paymentRepo.GetByID(ctx, req.PaymentID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if req.UserID != "" && payment.UserID != req.UserID {
+		span.SetStatus(codes.Error, "payment belongs to another user")
+		return nil, domain.ErrInvalidUserID
+	}
+
+	if existing, err := s.invoiceRepo.GetByPaymentID(ctx, payment.ID); err == nil {
+		span.SetAttributes(attribute.String("invoice_number", existing.Number))
+		span.SetStatus(codes.Ok, "already issued")
+		return existing, nil
+	} else if !errors.Is(err, domain.ErrInvoiceNotFound) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	buyer := req.Buyer
+	if buyer == nil {
+		buyer = payment.TaxInfo
+	}
+	if buyer == nil {
+		span.SetStatus(codes.Error, "no buyer tax details")
+		return nil, domain.ErrTaxInfoRequired
+	}
+	buyer.Normalize()
+	if err := buyer.Validate(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	invoice, err := domain.NewInvoice(payment, *buyer, s.now())
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if err := s.invoiceRepo.Create(ctx, invoice); err != nil {
+		// A concurrent request issued it first
+		if errors.Is(err, domain.ErrInvoiceAlreadyExists) {
+			return s.invoiceRepo.GetByPaymentID(ctx, payment.ID)
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to save invoice: %w", err)
+	}
+
+	span.SetAttributes(attribute.String("invoice_number", invoice.Number))
+	span.SetStatus(codes.Ok, "")
+	return invoice, nil
+}
+
+// GetInvoice retrieves an invoice by ID
+func (s *invoiceServiceImpl) GetInvoice(ctx context.Context, invoiceID string) (*domain.Invoice, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.invoice.get")
+	defer span.End()
+
+	invoice, err := s.invoiceRepo.GetByID(ctx, invoiceID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return invoice, nil
+}
+
+// GetInvoiceByPaymentID retrieves the invoice issued for a payment
+func (s *invoiceServiceImpl) GetInvoiceByPaymentID(ctx context.Context, paymentID string) (*domain.Invoice, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.invoice.get_by_payment")
+	defer span.End()
+
+	invoice, err := s.invoiceRepo.GetByPaymentID(ctx, paymentID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return invoice, nil
+}
+
+// ListMonth returns a tenant's invoices issued in a YYYY-MM month
+func (s *invoiceServiceImpl) ListMonth(ctx context.Context, tenantID, month string) ([]*domain.Invoice, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.invoice.list_month")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("tenant_id", tenantID), attribute.String("month", month))
+
+	from, to, err := domain.ParseInvoiceMonth(month)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	invoices, err := s.invoiceRepo.ListIssued(ctx, tenantID, from, to)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("count", len(invoices)))
+	span.SetStatus(codes.Ok, "")
+	return invoices, nil
+}
+
+// ExportMonth writes a tenant's invoices of a YYYY-MM month as e-Tax CSV
+func (s *invoiceServiceImpl) ExportMonth(ctx context.Context, tenantID, month string, w io.Writer) (int, error) {
+	invoices, err := s.ListMonth(ctx, tenantID, month)
+	if err != nil {
+		return 0, err
+	}
+
+	// UTF-8 BOM so spreadsheet tools read Thai names and addresses correctly
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return 0, err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(ETaxExportHeader); err != nil {
+		return 0, err
+	}
+	for _, invoice := range invoices {
+		if err := cw.Write(s.exportRow(invoice)); err != nil {
+			return 0, err
+		}
+	}
+	cw.Flush()
+	return len(invoices), cw.Error()
+}
+
+// StoreExport keeps a tenant's e-Tax CSV of a YYYY-MM month in file storage
+func (s *invoiceServiceImpl) StoreExport(ctx context.Context, tenantID, month string) (*StoredExport, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.invoice.store_export")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("tenant_id", tenantID), attribute.String("month", month))
+
+	if s.config.Files == nil {
+		span.SetStatus(codes.Error, "file storage disabled")
+		return nil, domain.ErrFileStorageDisabled
+	}
+
+	var buf bytes.Buffer
+	count, err := s.ExportMonth(ctx, tenantID, month, &buf)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	// Timestamped so an export taken before late invoices were issued is not overwritten
+	now := s.now()
+	key := fmt.Sprintf("%s%s/etax-invoices-%s-%s.csv", InvoiceExportPrefix, tenantID, month, now.UTC().Format("20060102T150405Z"))
+	if _, err := s.config.Files.Put(ctx, key, &buf, storage.PutOptions{
+		ContentType:        "text/csv; charset=utf-8",
+		ContentDisposition: fmt.Sprintf(`attachment; filename="etax-invoices-%s.csv"`, month),
+		Metadata:           map[string]string{"tenant-id": tenantID, "month": month},
+	}); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to store export: %w", err)
+	}
+
+	url, err := s.config.Files.SignedURL(ctx, key, s.config.DownloadTTL)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to sign export url: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("count", count))
+	span.SetStatus(codes.Ok, "")
+	return &StoredExport{
+		Key:         key,
+		Count:       count,
+		DownloadURL: url,
+		ExpiresAt:   now.Add(s.config.DownloadTTL),
+	}, nil
+}
+
+// ReceiptURL renders the receipt of a payment's tax invoice and returns a signed URL to it
+func (s *invoiceServiceImpl) ReceiptURL(ctx context.Context, paymentID, userID string) (string, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.invoice.receipt_url")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("payment_id", paymentID))
+
+	if s.config.Files == nil {
+		span.SetStatus(codes.Error, "file storage disabled")
+		return "", domain.ErrFileStorageDisabled
+	}
+
+	payment, err := s.paymentRepo.GetByID(ctx, paymentID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", err
+	}
+	if userID != "" && payment.UserID != userID {
+		span.SetStatus(codes.Error, "payment belongs to another user")
+		return "", domain.ErrInvalidUserID
+	}
+	invoice, err := s.invoiceRepo.GetByPaymentID(ctx, payment.ID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", err
+	}
+
+	body, err := s.renderReceipt(invoice)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", fmt.Errorf("failed to render receipt: %w", err)
+	}
+
+	// Rendered on every request: the lifecycle rule expires receipts, the invoice is the record
+	key := ReceiptPrefix + invoice.TenantID + "/" + invoice.ID + ".html"
+	if _, err := s.config.Files.Put(ctx, key, bytes.NewReader(body), storage.PutOptions{
+		ContentType:        "text/html; charset=utf-8",
+		ContentDisposition: fmt.Sprintf(`inline; filename="%s.html"`, invoice.Number),
+	}); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", fmt.Errorf("failed to store receipt: %w", err)
+	}
+
+	url, err := s.config.Files.SignedURL(ctx, key, s.config.DownloadTTL)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", fmt.Errorf("failed to sign receipt url: %w", err)
+	}
+
+	span.SetAttributes(attribute.String("invoice_number", invoice.Number))
+	span.SetStatus(codes.Ok, "")
+	return url, nil
+}
+
+// exportRow formats an invoice in the ETaxExportHeader column order
+func (s *invoiceServiceImpl) exportRow(invoice *domain.Invoice) []string {
+	return []string{
+		ETaxDocumentType,
+		invoice.Number,
+		invoice.IssuedAt.In(domain.InvoiceLocation).Format("2006-01-02"),
+		s.config.SellerTaxID,
+		s.config.SellerBranch,
+		s.config.SellerName,
+		s.config.SellerAddress,
+		invoice.Buyer.CompanyName,
+		invoice.Buyer.TaxID,
+		invoice.Buyer.BranchCode,
+		invoice.Buyer.Address,
+		invoice.Currency,
+		formatAmount(invoice.Subtotal),
+		formatAmount(invoice.VATRate * 100),
+		formatAmount(invoice.VATAmount),
+		formatAmount(invoice.Total),
+		invoice.BookingID,
+	}
+}
+
+// formatAmount formats an amount with two decimal places
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
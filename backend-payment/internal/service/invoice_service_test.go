@@ -0,0 +1,291 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/storage"
+)
+
+func newTestTaxInfo() *domain.TaxInfo {
+	return &domain.TaxInfo{
+		CompanyName: "Acme Events Co., Ltd.",
+		TaxID:       "0-1055-56000-00-9",
+		Address:     "99 Sukhumvit Rd, Bangkok 10110",
+	}
+}
+
+// newChargedPayment saves a succeeded payment for a booking
+func newChargedPayment(t *testing.T, repo repository.PaymentRepository, bookingID string, amount float64, taxInfo *domain.TaxInfo) *domain.Payment {
+	t.Helper()
+
+	payment, err := domain.NewPayment("tenant-1", bookingID, "user-1", amount, "THB", domain.PaymentMethodCreditCard)
+	if err != nil {
+		t.Fatalf("NewPayment() error = %v", err)
+	}
+	payment.TaxInfo = taxInfo
+	if err := payment.Complete("pi_" + bookingID); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if err := repo.Create(context.Background(), payment); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	return payment
+}
+
+func TestInvoiceService_IssueInvoice(t *testing.T) {
+	paymentRepo := repository.NewMemoryPaymentRepository()
+	svc := NewInvoiceService(repository.NewMemoryInvoiceRepository(), paymentRepo, nil)
+	ctx := context.Background()
+
+	first := newChargedPayment(t, paymentRepo, "booking-1", 1070, newTestTaxInfo())
+	invoice, err := svc.IssueInvoice(ctx, &IssueInvoiceRequest{PaymentID: first.ID, UserID: "user-1"})
+	if err != nil {
+		t.Fatalf("IssueInvoice() error = %v", err)
+	}
+	period := domain.InvoicePeriod(time.Now())
+	if invoice.Number != "INV-"+period+"-000001" {
+		t.Errorf("IssueInvoice() number = %s, want the first number of %s", invoice.Number, period)
+	}
+	if invoice.Total != 1070 || invoice.VATAmount != 70 || invoice.Subtotal != 1000 {
+		t.Errorf("IssueInvoice() amounts = %.2f + %.2f VAT = %.2f, want 1000.00 + 70.00 = 1070.00", invoice.Subtotal, invoice.VATAmount, invoice.Total)
+	}
+	if invoice.Buyer.TaxID != "0105556000009" || invoice.Buyer.BranchCode != domain.HeadOfficeBranch {
+		t.Errorf("IssueInvoice() buyer = %+v, want normalized tax id and head office branch", invoice.Buyer)
+	}
+
+	// Issuing again keeps the running number
+	again, err := svc.IssueInvoice(ctx, &IssueInvoiceRequest{PaymentID: first.ID})
+	if err != nil || again.Number != invoice.Number {
+		t.Errorf("IssueInvoice() again = %v, %v, want %s", again, err, invoice.Number)
+	}
+
+	// Buyer details given at issue time are used when the payment has none
+	second := newChargedPayment(t, paymentRepo, "booking-2", 500, nil)
+	invoice, err = svc.IssueInvoice(ctx, &IssueInvoiceRequest{PaymentID: second.ID, Buyer: newTestTaxInfo()})
+	if err != nil {
+		t.Fatalf("IssueInvoice() with buyer error = %v", err)
+	}
+	if invoice.Sequence != 2 {
+		t.Errorf("IssueInvoice() sequence = %d, want 2", invoice.Sequence)
+	}
+}
+
+func TestInvoiceService_IssueInvoiceRejected(t *testing.T) {
+	paymentRepo := repository.NewMemoryPaymentRepository()
+	svc := NewInvoiceService(repository.NewMemoryInvoiceRepository(), paymentRepo, nil)
+
+	badTaxID := newTestTaxInfo()
+	badTaxID.TaxID = "0105556000001"
+
+	pending, err := domain.NewPayment("tenant-1", "booking-pending", "user-1", 100, "THB", domain.PaymentMethodCreditCard)
+	if err != nil {
+		t.Fatalf("NewPayment() error = %v", err)
+	}
+	pending.TaxInfo = newTestTaxInfo()
+	if err := paymentRepo.Create(context.Background(), pending); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		req       *IssueInvoiceRequest
+		wantError error
+	}{
+		{
+			name:      "no buyer details",
+			req:       &IssueInvoiceRequest{PaymentID: newChargedPayment(t, paymentRepo, "booking-1", 100, nil).ID},
+			wantError: domain.ErrTaxInfoRequired,
+		},
+		{
+			name:      "tax id check digit",
+			req:       &IssueInvoiceRequest{PaymentID: newChargedPayment(t, paymentRepo, "booking-2", 100, nil).ID, Buyer: badTaxID},
+			wantError: domain.ErrInvalidTaxID,
+		},
+		{
+			name:      "other user",
+			req:       &IssueInvoiceRequest{PaymentID: newChargedPayment(t, paymentRepo, "booking-3", 100, newTestTaxInfo()).ID, UserID: "user-2"},
+			wantError: domain.ErrInvalidUserID,
+		},
+		{
+			name:      "payment not charged",
+			req:       &IssueInvoiceRequest{PaymentID: pending.ID},
+			wantError: domain.ErrPaymentNotCharged,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := svc.IssueInvoice(context.Background(), tt.req); !errors.Is(err, tt.wantError) {
+				t.Errorf("IssueInvoice() error = %v, want %v", err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestInvoiceService_ExportMonth(t *testing.T) {
+	paymentRepo := repository.NewMemoryPaymentRepository()
+	svc := NewInvoiceService(repository.NewMemoryInvoiceRepository(), paymentRepo, &InvoiceServiceConfig{
+		SellerName:  "Booking Rush Co., Ltd.",
+		SellerTaxID: "0107537001234",
+	})
+	ctx := context.Background()
+
+	for _, bookingID := range []string{"booking-1", "booking-2"} {
+		payment := newChargedPayment(t, paymentRepo, bookingID, 2140, newTestTaxInfo())
+		if _, err := svc.IssueInvoice(ctx, &IssueInvoiceRequest{PaymentID: payment.ID}); err != nil {
+			t.Fatalf("IssueInvoice() error = %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	month := time.Now().In(domain.InvoiceLocation).Format("2006-01")
+	count, err := svc.ExportMonth(ctx, "tenant-1", month, &buf)
+	if err != nil || count != 2 {
+		t.Fatalf("ExportMonth() = %d, %v, want 2 invoices", count, err)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(buf.String(), "\ufeff"))).ReadAll()
+	if err != nil {
+		t.Fatalf("export is not valid CSV: %v", err)
+	}
+	if len(records) != 3 || len(records[1]) != len(ETaxExportHeader) {
+		t.Fatalf("export has %d rows, want header and 2 invoices", len(records))
+	}
+	row := records[1]
+	want := map[string]string{
+		"DocumentTypeCode": ETaxDocumentType,
+		"SellerTaxID":      "0107537001234",
+		"SellerBranchID":   domain.HeadOfficeBranch,
+		"BasisAmount":      "2000.00",
+		"TaxRate":          "7.00",
+		"TaxAmount":        "140.00",
+		"GrandTotal":       "2140.00",
+		"ReferenceID":      "booking-1",
+	}
+	for i, column := range ETaxExportHeader {
+		if value, ok := want[column]; ok && row[i] != value {
+			t.Errorf("export %s = %q, want %q", column, row[i], value)
+		}
+	}
+
+	if _, err := svc.ExportMonth(ctx, "tenant-1", "2026/10", &buf); !errors.Is(err, domain.ErrInvalidInvoiceMonth) {
+		t.Errorf("ExportMonth() with bad month error = %v, want ErrInvalidInvoiceMonth", err)
+	}
+}
+
+// newTestFileStore creates a local file store signing URLs under files.test
+func newTestFileStore(t *testing.T) storage.Store {
+	t.Helper()
+
+	signer, err := storage.NewURLSigner("https://files.test/api/v1/files/payment", "test-signing-key")
+	if err != nil {
+		t.Fatalf("NewURLSigner() error = %v", err)
+	}
+	store, err := storage.NewLocalStore(t.TempDir(), signer)
+	if err != nil {
+		t.Fatalf("NewLocalStore() error = %v", err)
+	}
+	return store
+}
+
+// readStored reads the object a signed URL of the test file store points at
+func readStored(t *testing.T, store storage.Store, signedURL string) (string, *storage.Object) {
+	t.Helper()
+
+	u, err := url.Parse(signedURL)
+	if err != nil {
+		t.Fatalf("signed url %q is invalid: %v", signedURL, err)
+	}
+	body, object, err := store.Get(context.Background(), strings.TrimPrefix(u.Path, "/api/v1/files/payment/"))
+	if err != nil {
+		t.Fatalf("signed url %q points at no stored file: %v", signedURL, err)
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("failed to read stored file: %v", err)
+	}
+	return string(data), object
+}
+
+func TestInvoiceService_StoreExport(t *testing.T) {
+	paymentRepo := repository.NewMemoryPaymentRepository()
+	files := newTestFileStore(t)
+	svc := NewInvoiceService(repository.NewMemoryInvoiceRepository(), paymentRepo, &InvoiceServiceConfig{Files: files})
+	ctx := context.Background()
+
+	payment := newChargedPayment(t, paymentRepo, "booking-1", 1070, newTestTaxInfo())
+	if _, err := svc.IssueInvoice(ctx, &IssueInvoiceRequest{PaymentID: payment.ID}); err != nil {
+		t.Fatalf("IssueInvoice() error = %v", err)
+	}
+
+	month := time.Now().In(domain.InvoiceLocation).Format("2006-01")
+	export, err := svc.StoreExport(ctx, "tenant-1", month)
+	if err != nil {
+		t.Fatalf("StoreExport() error = %v", err)
+	}
+	if export.Count != 1 || !strings.HasPrefix(export.Key, InvoiceExportPrefix+"tenant-1/") {
+		t.Errorf("StoreExport() = %+v, want 1 invoice under the tenant's export prefix", export)
+	}
+	if time.Until(export.ExpiresAt) <= 0 {
+		t.Errorf("StoreExport() expires at %v, want a future expiry", export.ExpiresAt)
+	}
+
+	content, object := readStored(t, files, export.DownloadURL)
+	if !strings.Contains(content, "booking-1") || !strings.HasPrefix(object.ContentType, "text/csv") {
+		t.Errorf("stored export = %q (%s), want the e-Tax CSV", content, object.ContentType)
+	}
+
+	if _, err := svc.StoreExport(ctx, "tenant-1", "2026/10"); !errors.Is(err, domain.ErrInvalidInvoiceMonth) {
+		t.Errorf("StoreExport() with bad month error = %v, want ErrInvalidInvoiceMonth", err)
+	}
+}
+
+func TestInvoiceService_ReceiptURL(t *testing.T) {
+	paymentRepo := repository.NewMemoryPaymentRepository()
+	files := newTestFileStore(t)
+	svc := NewInvoiceService(repository.NewMemoryInvoiceRepository(), paymentRepo, &InvoiceServiceConfig{
+		SellerName: "Booking Rush Co., Ltd.",
+		Files:      files,
+	})
+	ctx := context.Background()
+
+	payment := newChargedPayment(t, paymentRepo, "booking-1", 1070, newTestTaxInfo())
+	if _, err := svc.ReceiptURL(ctx, payment.ID, "user-1"); !errors.Is(err, domain.ErrInvoiceNotFound) {
+		t.Errorf("ReceiptURL() before issuing error = %v, want ErrInvoiceNotFound", err)
+	}
+	invoice, err := svc.IssueInvoice(ctx, &IssueInvoiceRequest{PaymentID: payment.ID})
+	if err != nil {
+		t.Fatalf("IssueInvoice() error = %v", err)
+	}
+
+	signedURL, err := svc.ReceiptURL(ctx, payment.ID, "user-1")
+	if err != nil {
+		t.Fatalf("ReceiptURL() error = %v", err)
+	}
+	content, _ := readStored(t, files, signedURL)
+	for _, want := range []string{invoice.Number, "Booking Rush Co., Ltd.", "Acme Events Co., Ltd.", "1070.00"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("receipt does not show %q", want)
+		}
+	}
+
+	if _, err := svc.ReceiptURL(ctx, payment.ID, "user-2"); !errors.Is(err, domain.ErrInvalidUserID) {
+		t.Errorf("ReceiptURL() of another user's payment error = %v, want ErrInvalidUserID", err)
+	}
+
+	disabled := NewInvoiceService(repository.NewMemoryInvoiceRepository(), paymentRepo, nil)
+	if _, err := disabled.ReceiptURL(ctx, payment.ID, "user-1"); !errors.Is(err, domain.ErrFileStorageDisabled) {
+		t.Errorf("ReceiptURL() without file storage error = %v, want ErrFileStorageDisabled", err)
+	}
+}
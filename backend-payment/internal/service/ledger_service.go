@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// PostLedgerRequest represents a gateway fee or payout to record in a tenant's ledger (internal)
+type PostLedgerRequest struct {
+	TenantID       string
+	Type           domain.LedgerTransactionType // fee or payout
+	Amount         float64
+	Currency       string
+	PaymentID      string // Payment the fee was charged on, optional
+	Reference      string // Gateway ID of the fee or payout
+	IdempotencyKey string // Defaults to type:reference
+	Description    string
+	OccurredAt     time.Time // Defaults to now
+}
+
+// LedgerRecorder posts the ledger transactions of payment events. Posting is best
+// effort: the money has already moved at the gateway, so failures are logged and
+// never fail the payment operation.
+type LedgerRecorder interface {
+	// RecordCharge posts the amount charged on a payment since it was last recorded
+	RecordCharge(ctx context.Context, payment *domain.Payment, amount float64)
+
+	// RecordRefund posts the amount refunded on a payment since it was last recorded
+	RecordRefund(ctx context.Context, payment *domain.Payment, amount float64)
+}
+
+// LedgerService keeps the double-entry ledger of every tenant
+type LedgerService interface {
+	LedgerRecorder
+
+	// PostTransaction records a gateway fee or payout. Posting the same idempotency key
+	// again returns the transaction already recorded.
+	PostTransaction(ctx context.Context, req *PostLedgerRequest) (*domain.LedgerTransaction, error)
+
+	// GetTransaction retrieves a ledger transaction and its entries
+	GetTransaction(ctx context.Context, transactionID string) (*domain.LedgerTransaction, error)
+
+	// GetBalances returns a tenant's account balances as of a time (now when zero)
+	GetBalances(ctx context.Context, tenantID string, asOf time.Time) ([]*domain.LedgerBalance, error)
+
+	// ListEntries retrieves a page of a tenant's ledger entries, newest first
+	ListEntries(ctx context.Context, filter *domain.LedgerEntryFilter, page pagination.Params) ([]*domain.LedgerEntry, pagination.Info, error)
+}
+
+// ledgerServiceImpl implements LedgerService
+type ledgerServiceImpl struct {
+	ledgerRepo  repository.LedgerRepository
+	paymentRepo repository.PaymentRepository
+}
+
+// NewLedgerService creates a new LedgerService. paymentRepo checks the payments fees
+// are posted against.
+func NewLedgerService(ledgerRepo repository.LedgerRepository, paymentRepo repository.PaymentRepository) LedgerService {
+	return &ledgerServiceImpl{
+		ledgerRepo:  ledgerRepo,
+		paymentRepo: paymentRepo,
+	}
+}
+
+// RecordCharge posts a payment transaction for an amount charged on a payment
+func (s *ledgerServiceImpl) RecordCharge(ctx context.Context, payment *domain.Payment, amount float64) {
+	s.recordPayment(ctx, payment, domain.LedgerTransactionPayment, amount, domain.PaymentLedgerKey(payment))
+}
+
+// RecordRefund posts a refund transaction for an amount refunded on a payment
+func (s *ledgerServiceImpl) RecordRefund(ctx context.Context, payment *domain.Payment, amount float64) {
+	s.recordPayment(ctx, payment, domain.LedgerTransactionRefund, amount, domain.RefundLedgerKey(payment))
+}
+
+// recordPayment posts a transaction of a payment, logging failures
+func (s *ledgerServiceImpl) recordPayment(ctx context.Context, payment *domain.Payment, txType domain.LedgerTransactionType, amount float64, key string) {
+	ctx, span := telemetry.StartSpan(ctx, "service.ledger.record")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("payment_id", payment.ID),
+		attribute.String("type", string(txType)),
+		attribute.Float64("amount", amount),
+	)
+
+	tx, err := domain.NewLedgerTransaction(payment.TenantID, txType, amount, payment.Currency, key, time.Now())
+	if err == nil {
+		tx.SetPayment(payment.ID, payment.GatewayPaymentID)
+		err = s.create(ctx, tx)
+	}
+	if err != nil && !errors.Is(err, domain.ErrLedgerTransactionExists) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		logger.Get().Error(fmt.Sprintf("Failed to record %s of payment %s in the ledger: %v", txType, payment.ID, err))
+		return
+	}
+
+	span.SetAttributes(attribute.Bool("already_recorded", err != nil))
+	span.SetStatus(codes.Ok, "")
+}
+
+// create validates and saves a transaction
+func (s *ledgerServiceImpl) create(ctx context.Context, tx *domain.LedgerTransaction) error {
+	if err := tx.Validate(); err != nil {
+		return err
+	}
+	return s.ledgerRepo.Create(ctx, tx)
+}
+
+// PostTransaction records a gateway fee or payout
+func (s *ledgerServiceImpl) PostTransaction(ctx context.Context, req *PostLedgerRequest) (*domain.LedgerTransaction, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.ledger.post")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("tenant_id", req.TenantID),
+		attribute.String("type", string(req.Type)),
+		attribute.String("reference", req.Reference),
+		attribute.Float64("amount", req.Amount),
+	)
+
+	// Payments and refunds are posted by the payment flow itself
+	if req.Type != domain.LedgerTransactionFee && req.Type != domain.LedgerTransactionPayout {
+		err := fmt.Errorf("%w: only fee and payout transactions can be posted", domain.ErrInvalidLedgerTransaction)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	key := req.IdempotencyKey
+	if key == "" && req.Reference != "" {
+		key = string(req.Type) + ":" + req.Reference
+	}
+	tx, err := domain.NewLedgerTransaction(req.TenantID, req.Type, req.Amount, req.Currency, key, req.OccurredAt)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	tx.Description = req.Description
+
+	if req.PaymentID != "" {
+		payment, err := s.paymentRepo.GetByID(ctx, req.PaymentID)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		if payment.TenantID != req.TenantID {
+			span.SetStatus(codes.Error, "payment belongs to another tenant")
+			return nil, fmt.Errorf("%w: payment belongs to another tenant", domain.ErrInvalidLedgerTransaction)
+		}
+	}
+	tx.SetPayment(req.PaymentID, req.Reference)
+
+	if err := s.create(ctx, tx); err != nil {
+		// Already posted, e.g. a settlement file imported twice
+		if errors.Is(err, domain.ErrLedgerTransactionExists) {
+			span.SetAttributes(attribute.Bool("already_recorded", true))
+			span.SetStatus(codes.Ok, "")
+			return s.ledgerRepo.GetByIdempotencyKey(ctx, key)
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to save ledger transaction: %w", err)
+	}
+
+	span.SetAttributes(attribute.String("transaction_id", tx.ID))
+	span.SetStatus(codes.Ok, "")
+	return tx, nil
+}
+
+// GetTransaction retrieves a ledger transaction and its entries
+func (s *ledgerServiceImpl) GetTransaction(ctx context.Context, transactionID string) (*domain.LedgerTransaction, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.ledger.get_transaction")
+	defer span.End()
+
+	tx, err := s.ledgerRepo.GetByID(ctx, transactionID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return tx, nil
+}
+
+// GetBalances returns a tenant's account balances as of a time
+func (s *ledgerServiceImpl) GetBalances(ctx context.Context, tenantID string, asOf time.Time) ([]*domain.LedgerBalance, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.ledger.get_balances")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("tenant_id", tenantID))
+
+	if tenantID == "" {
+		span.SetStatus(codes.Error, "tenant_id required")
+		return nil, fmt.Errorf("%w: tenant_id is required", domain.ErrInvalidLedgerFilter)
+	}
+
+	balances, err := s.ledgerRepo.GetBalances(ctx, tenantID, asOf)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("count", len(balances)))
+	span.SetStatus(codes.Ok, "")
+	return balances, nil
+}
+
+// ListEntries retrieves a page of a tenant's ledger entries, newest first
+func (s *ledgerServiceImpl) ListEntries(ctx context.Context, filter *domain.LedgerEntryFilter, page pagination.Params) ([]*domain.LedgerEntry, pagination.Info, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.ledger.list_entries")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("tenant_id", filter.TenantID),
+		attribute.String("account", string(filter.Account)),
+		attribute.String("type", string(filter.Type)),
+		attribute.Int("limit", page.Limit),
+		attribute.Bool("cursor", page.After != nil),
+	)
+
+	if filter.TenantID == "" {
+		span.SetStatus(codes.Error, "tenant_id required")
+		return nil, pagination.Info{}, fmt.Errorf("%w: tenant_id is required", domain.ErrInvalidLedgerFilter)
+	}
+	if (filter.Account != "" && !filter.Account.IsValid()) || (filter.Type != "" && !filter.Type.IsValid()) {
+		span.SetStatus(codes.Error, "unknown account or type")
+		return nil, pagination.Info{}, fmt.Errorf("%w: unknown account or type", domain.ErrInvalidLedgerFilter)
+	}
+
+	if page.Limit <= 0 {
+		page.Limit = pagination.DefaultLimit
+	}
+	if page.Limit > pagination.MaxLimit {
+		page.Limit = pagination.MaxLimit
+	}
+
+	entries, err := s.ledgerRepo.ListEntries(ctx, filter, page.Lookahead())
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, pagination.Info{}, err
+	}
+
+	entries, info := pagination.Paginate(entries, page.Limit, func(e *domain.LedgerEntry) pagination.Cursor {
+		return pagination.Cursor{Time: e.OccurredAt, ID: e.ID}
+	})
+
+	span.SetAttributes(
+		attribute.Int("result_count", len(entries)),
+		attribute.Bool("has_more", info.HasMore),
+	)
+	span.SetStatus(codes.Ok, "")
+	return entries, info, nil
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/gateway"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
+)
+
+// newLedgerTestServices wires a payment service that posts to a ledger, like the container does
+func newLedgerTestServices(t *testing.T) (PaymentService, LedgerService, RefundReconciliationService, *domain.Payment) {
+	t.Helper()
+
+	paymentRepo := repository.NewMemoryPaymentRepository()
+	ledger := NewLedgerService(repository.NewMemoryLedgerRepository(), paymentRepo)
+	svc := NewPaymentService(paymentRepo, gateway.NewMockGateway(&gateway.MockGatewayConfig{SuccessRate: 1.0}), &PaymentServiceConfig{
+		Currency:      "THB",
+		Ledger:        ledger,
+		BookingClient: bookingsTotalling(1500),
+	})
+	refunds := NewRefundReconciliationService(paymentRepo, repository.NewMemoryRefundReviewRepository(), ledger)
+
+	ctx := context.Background()
+	payment, err := svc.CreatePayment(ctx, &CreatePaymentRequest{
+		TenantID:  "tenant-1",
+		BookingID: "booking-1",
+		UserID:    "user-1",
+		Amount:    1500,
+		Currency:  "THB",
+		Method:    domain.PaymentMethodCreditCard,
+	})
+	if err != nil {
+		t.Fatalf("CreatePayment() error = %v", err)
+	}
+	if payment, err = svc.ProcessPayment(ctx, payment.ID); err != nil {
+		t.Fatalf("ProcessPayment() error = %v", err)
+	}
+	return svc, ledger, refunds, payment
+}
+
+// ledgerBalances returns a tenant's balances in minor units keyed by account
+func ledgerBalances(t *testing.T, ledger LedgerService, tenantID string) map[domain.LedgerAccount]int64 {
+	t.Helper()
+
+	balances, err := ledger.GetBalances(context.Background(), tenantID, time.Time{})
+	if err != nil {
+		t.Fatalf("GetBalances() error = %v", err)
+	}
+	result := make(map[domain.LedgerAccount]int64)
+	var debits, credits int64
+	for _, balance := range balances {
+		result[balance.Account] = balance.Balance()
+		debits += balance.Debits
+		credits += balance.Credits
+	}
+	if debits != credits {
+		t.Errorf("ledger is out of balance: debits %d, credits %d", debits, credits)
+	}
+	return result
+}
+
+func TestLedgerService_PostsPaymentLifecycle(t *testing.T) {
+	svc, ledger, refunds, payment := newLedgerTestServices(t)
+	ctx := context.Background()
+
+	// The webhook of the same charge is not posted twice
+	if _, err := svc.CompletePaymentFromWebhook(ctx, payment.ID, payment.GatewayPaymentID); err != nil {
+		t.Fatalf("CompletePaymentFromWebhook() error = %v", err)
+	}
+	if _, err := svc.AdjustPayment(ctx, &AdjustPaymentRequest{PaymentID: payment.ID, AdjustmentID: "mod-1", Amount: 500}); err != nil {
+		t.Fatalf("AdjustPayment() charge error = %v", err)
+	}
+	if _, err := svc.AdjustPayment(ctx, &AdjustPaymentRequest{PaymentID: payment.ID, AdjustmentID: "mod-2", Amount: -800}); err != nil {
+		t.Fatalf("AdjustPayment() refund error = %v", err)
+	}
+
+	// The charge.refunded webhook of the refund above is already in the ledger, a
+	// further refund made from the gateway dashboard is not
+	if _, err := refunds.ReconcileRefund(ctx, &GatewayRefund{PaymentID: payment.ID, AmountRefunded: 800}); err != nil {
+		t.Fatalf("ReconcileRefund() in sync error = %v", err)
+	}
+	if _, err := refunds.ReconcileRefund(ctx, &GatewayRefund{PaymentID: payment.ID, AmountRefunded: 900}); err != nil {
+		t.Fatalf("ReconcileRefund() error = %v", err)
+	}
+
+	if _, err := ledger.PostTransaction(ctx, &PostLedgerRequest{
+		TenantID: "tenant-1", Type: domain.LedgerTransactionFee, Amount: 60, PaymentID: payment.ID, Reference: "txn_fee_1",
+	}); err != nil {
+		t.Fatalf("PostTransaction() fee error = %v", err)
+	}
+	if _, err := ledger.PostTransaction(ctx, &PostLedgerRequest{
+		TenantID: "tenant-1", Type: domain.LedgerTransactionPayout, Amount: 1000, Reference: "po_1",
+	}); err != nil {
+		t.Fatalf("PostTransaction() payout error = %v", err)
+	}
+
+	// Charged 2000, refunded 900, fees 60, paid out 1000
+	balances := ledgerBalances(t, ledger, "tenant-1")
+	if balances[domain.LedgerAccountGatewayClearing] != 4000 {
+		t.Errorf("gateway_clearing = %d, want 4000", balances[domain.LedgerAccountGatewayClearing])
+	}
+	if balances[domain.LedgerAccountTenantPayable] != 10000 {
+		t.Errorf("tenant_payable = %d, want 10000", balances[domain.LedgerAccountTenantPayable])
+	}
+	if balances[domain.LedgerAccountProcessingFees] != 6000 {
+		t.Errorf("processing_fees = %d, want 6000", balances[domain.LedgerAccountProcessingFees])
+	}
+
+	charges, _, err := ledger.ListEntries(ctx, &domain.LedgerEntryFilter{
+		TenantID: "tenant-1", Type: domain.LedgerTransactionPayment, Account: domain.LedgerAccountGatewayClearing,
+	}, pagination.Params{})
+	if err != nil {
+		t.Fatalf("ListEntries() error = %v", err)
+	}
+	if len(charges) != 2 || charges[0].Amount != 50000 || charges[1].Amount != 150000 {
+		t.Errorf("expected the 500 and 1500 charges newest first, got %+v", charges)
+	}
+
+	if others := ledgerBalances(t, ledger, "tenant-2"); len(others) != 0 {
+		t.Errorf("expected an empty ledger for another tenant, got %v", others)
+	}
+}
+
+func TestLedgerService_RefundPayment(t *testing.T) {
+	svc, ledger, _, payment := newLedgerTestServices(t)
+
+	if _, err := svc.RefundPayment(context.Background(), payment.ID, "customer request"); err != nil {
+		t.Fatalf("RefundPayment() error = %v", err)
+	}
+
+	balances := ledgerBalances(t, ledger, "tenant-1")
+	if balances[domain.LedgerAccountGatewayClearing] != 0 || balances[domain.LedgerAccountTenantPayable] != 0 {
+		t.Errorf("expected a fully refunded payment to net to zero, got %v", balances)
+	}
+}
+
+func TestLedgerService_PostTransaction(t *testing.T) {
+	_, ledger, _, payment := newLedgerTestServices(t)
+	ctx := context.Background()
+
+	payout := &PostLedgerRequest{TenantID: "tenant-1", Type: domain.LedgerTransactionPayout, Amount: 100, Reference: "po_1"}
+	first, err := ledger.PostTransaction(ctx, payout)
+	if err != nil {
+		t.Fatalf("PostTransaction() error = %v", err)
+	}
+	if len(first.Entries) != 2 || first.Entries[0].Direction != domain.LedgerDebit || first.Entries[0].Account != domain.LedgerAccountTenantPayable {
+		t.Errorf("unexpected payout entries: %+v", first.Entries)
+	}
+
+	// A settlement file imported twice posts the payout once
+	again, err := ledger.PostTransaction(ctx, payout)
+	if err != nil {
+		t.Fatalf("PostTransaction() repeat error = %v", err)
+	}
+	if again.ID != first.ID {
+		t.Errorf("expected the payout already posted, got transaction %s", again.ID)
+	}
+
+	tests := []struct {
+		name string
+		req  *PostLedgerRequest
+		want error
+	}{
+		{"payment type", &PostLedgerRequest{TenantID: "tenant-1", Type: domain.LedgerTransactionPayment, Amount: 100, Reference: "ch_1"}, domain.ErrInvalidLedgerTransaction},
+		{"zero amount", &PostLedgerRequest{TenantID: "tenant-1", Type: domain.LedgerTransactionFee, Amount: 0.001, Reference: "fee_1"}, domain.ErrInvalidLedgerTransaction},
+		{"no reference or key", &PostLedgerRequest{TenantID: "tenant-1", Type: domain.LedgerTransactionFee, Amount: 10}, domain.ErrInvalidLedgerTransaction},
+		{"unknown payment", &PostLedgerRequest{TenantID: "tenant-1", Type: domain.LedgerTransactionFee, Amount: 10, Reference: "fee_2", PaymentID: "missing"}, domain.ErrPaymentNotFound},
+		{"payment of another tenant", &PostLedgerRequest{TenantID: "tenant-2", Type: domain.LedgerTransactionFee, Amount: 10, Reference: "fee_3", PaymentID: payment.ID}, domain.ErrInvalidLedgerTransaction},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ledger.PostTransaction(ctx, tt.req); !errors.Is(err, tt.want) {
+				t.Errorf("PostTransaction() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLedgerService_ListEntriesPages(t *testing.T) {
+	_, ledger, _, _ := newLedgerTestServices(t)
+	ctx := context.Background()
+
+	for _, reference := range []string{"po_1", "po_2"} {
+		if _, err := ledger.PostTransaction(ctx, &PostLedgerRequest{TenantID: "tenant-1", Type: domain.LedgerTransactionPayout, Amount: 10, Reference: reference}); err != nil {
+			t.Fatalf("PostTransaction() error = %v", err)
+		}
+	}
+
+	// One charge and two payouts post six entries
+	seen := make(map[string]bool)
+	page := pagination.Params{Limit: 4}
+	for i := 0; ; i++ {
+		entries, info, err := ledger.ListEntries(ctx, &domain.LedgerEntryFilter{TenantID: "tenant-1"}, page)
+		if err != nil {
+			t.Fatalf("ListEntries() error = %v", err)
+		}
+		for _, entry := range entries {
+			seen[entry.ID] = true
+		}
+		if !info.HasMore {
+			break
+		}
+		if i > 2 {
+			t.Fatal("pagination did not end")
+		}
+		page.After, _ = pagination.DecodeCursor(info.NextCursor)
+	}
+	if len(seen) != 6 {
+		t.Errorf("expected 6 distinct entries over all pages, got %d", len(seen))
+	}
+
+	if _, _, err := ledger.ListEntries(ctx, &domain.LedgerEntryFilter{TenantID: "tenant-1", Account: "cash"}, pagination.Params{}); !errors.Is(err, domain.ErrInvalidLedgerFilter) {
+		t.Errorf("ListEntries() unknown account error = %v, want ErrInvalidLedgerFilter", err)
+	}
+}
+
+func TestLedgerTransaction_Validate(t *testing.T) {
+	tx, err := domain.NewLedgerTransaction("tenant-1", domain.LedgerTransactionFee, 12.5, "THB", "fee:1", time.Time{})
+	if err != nil {
+		t.Fatalf("NewLedgerTransaction() error = %v", err)
+	}
+	if err := tx.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	tx.Entries[1].Amount--
+	if err := tx.Validate(); !errors.Is(err, domain.ErrUnbalancedLedgerTransaction) {
+		t.Errorf("Validate() error = %v, want ErrUnbalancedLedgerTransaction", err)
+	}
+}
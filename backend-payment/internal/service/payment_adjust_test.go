@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+)
+
+func TestPaymentService_AdjustPayment(t *testing.T) {
+	svc, _, payment := processTestPayment(t, "")
+	ctx := context.Background()
+
+	// Upgrade charges the difference on the same payment
+	adjusted, err := svc.AdjustPayment(ctx, &AdjustPaymentRequest{
+		PaymentID:    payment.ID,
+		AdjustmentID: "mod-1",
+		Amount:       500,
+		Reason:       "booking_modified",
+	})
+	if err != nil {
+		t.Fatalf("AdjustPayment() charge error = %v", err)
+	}
+	if adjusted.Amount != 2000 || adjusted.Status != domain.PaymentStatusSucceeded {
+		t.Errorf("AdjustPayment() charge = %.2f %s, want 2000.00 succeeded", adjusted.Amount, adjusted.Status)
+	}
+
+	// A retried adjustment is settled once
+	if adjusted, err = svc.AdjustPayment(ctx, &AdjustPaymentRequest{PaymentID: payment.ID, AdjustmentID: "mod-1", Amount: 500}); err != nil {
+		t.Fatalf("AdjustPayment() retry error = %v", err)
+	}
+	if adjusted.Amount != 2000 {
+		t.Errorf("AdjustPayment() retry amount = %.2f, want 2000.00", adjusted.Amount)
+	}
+
+	// Downgrade refunds part of the payment
+	adjusted, err = svc.AdjustPayment(ctx, &AdjustPaymentRequest{
+		PaymentID:    payment.ID,
+		AdjustmentID: "mod-2",
+		Amount:       -800,
+		Reason:       "booking_modified",
+	})
+	if err != nil {
+		t.Fatalf("AdjustPayment() refund error = %v", err)
+	}
+	if adjusted.RefundedAmount() != 800 || adjusted.Status != domain.PaymentStatusSucceeded {
+		t.Errorf("AdjustPayment() refund = %.2f %s, want 800.00 refunded on a succeeded payment", adjusted.RefundedAmount(), adjusted.Status)
+	}
+
+	if _, err := svc.AdjustPayment(ctx, &AdjustPaymentRequest{PaymentID: payment.ID, AdjustmentID: "mod-3", Amount: -1500}); !errors.Is(err, domain.ErrRefundExceedsAmount) {
+		t.Errorf("AdjustPayment() over-refund error = %v, want ErrRefundExceedsAmount", err)
+	}
+
+	// A full refund afterwards only returns what is left
+	refunded, err := svc.RefundPayment(ctx, payment.ID, "customer request")
+	if err != nil {
+		t.Fatalf("RefundPayment() error = %v", err)
+	}
+	if refunded.RefundedAmount() != 2000 || refunded.Status != domain.PaymentStatusRefunded {
+		t.Errorf("RefundPayment() = %.2f %s, want 2000.00 refunded", refunded.RefundedAmount(), refunded.Status)
+	}
+}
+
+func TestPaymentService_AdjustPaymentRequiresChargedPayment(t *testing.T) {
+	svc, _, payment := processTestPayment(t, CaptureModeManual)
+
+	_, err := svc.AdjustPayment(context.Background(), &AdjustPaymentRequest{PaymentID: payment.ID, AdjustmentID: "mod-1", Amount: 100})
+	if !errors.Is(err, domain.ErrInvalidPaymentStatus) {
+		t.Errorf("AdjustPayment() on authorized payment error = %v, want ErrInvalidPaymentStatus", err)
+	}
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/client"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/gateway"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/repository"
+)
+
+// mockBookingClient returns fixed booking totals keyed by booking ID, falling back
+// to anyTotal for the bookings it has no total for
+type mockBookingClient struct {
+	client.NoOpBookingClient
+	totals   map[string]*client.BookingTotal
+	anyTotal *client.BookingTotal
+	err      error
+}
+
+// bookingsTotalling is a booking client whose bookings of user-1 all total amount THB
+func bookingsTotalling(amount float64) *mockBookingClient {
+	return &mockBookingClient{anyTotal: &client.BookingTotal{UserID: "user-1", TotalPrice: amount, Currency: "THB"}}
+}
+
+func (m *mockBookingClient) GetBookingTotal(ctx context.Context, bookingID, userID string) (*client.BookingTotal, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	total, ok := m.totals[bookingID]
+	if !ok && m.anyTotal != nil {
+		fallback := *m.anyTotal
+		fallback.BookingID = bookingID
+		total, ok = &fallback, true
+	}
+	if !ok || total.UserID != userID {
+		return nil, client.ErrBookingNotFound
+	}
+	return total, nil
+}
+
+func TestPaymentService_CreatePayment_ValidatesBookingTotal(t *testing.T) {
+	bookings := &mockBookingClient{totals: map[string]*client.BookingTotal{
+		"booking-1": {BookingID: "booking-1", UserID: "user-1", TotalPrice: 1500.10, Currency: "THB"},
+	}}
+
+	tests := []struct {
+		name      string
+		bookingID string
+		userID    string
+		amount    float64
+		currency  string
+		wantErr   error
+	}{
+		{"matching amount", "booking-1", "user-1", 1500.1, "THB", nil},
+		{"currency case-insensitive", "booking-1", "user-1", 1500.10, "thb", nil},
+		{"under-payment", "booking-1", "user-1", 1.00, "THB", domain.ErrAmountMismatch},
+		{"over-payment", "booking-1", "user-1", 1500.11, "THB", domain.ErrAmountMismatch},
+		{"other currency", "booking-1", "user-1", 1500.10, "USD", domain.ErrAmountMismatch},
+		{"unknown booking", "booking-2", "user-1", 1500.10, "THB", domain.ErrBookingNotFound},
+		{"another user's booking", "booking-1", "user-2", 1500.10, "THB", domain.ErrBookingNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewPaymentService(repository.NewMemoryPaymentRepository(), gateway.NewMockGatewayWithConfig(1.0, 0), &PaymentServiceConfig{
+				Currency:      "THB",
+				BookingClient: bookings,
+			})
+
+			_, err := svc.CreatePayment(context.Background(), &CreatePaymentRequest{
+				TenantID:  "tenant-1",
+				BookingID: tt.bookingID,
+				UserID:    tt.userID,
+				Amount:    tt.amount,
+				Currency:  tt.currency,
+				Method:    domain.PaymentMethodCreditCard,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CreatePayment() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPaymentService_CreatePayment_BookingLookupFails(t *testing.T) {
+	svc := NewPaymentService(repository.NewMemoryPaymentRepository(), gateway.NewMockGatewayWithConfig(1.0, 0), &PaymentServiceConfig{
+		Currency:      "THB",
+		BookingClient: &mockBookingClient{err: errors.New("connection refused")},
+	})
+
+	// Payments are rejected rather than trusting the client amount
+	_, err := svc.CreatePayment(context.Background(), &CreatePaymentRequest{
+		TenantID:  "tenant-1",
+		BookingID: "booking-1",
+		UserID:    "user-1",
+		Amount:    100,
+		Currency:  "THB",
+		Method:    domain.PaymentMethodCreditCard,
+	})
+	if err == nil {
+		t.Error("CreatePayment() expected error when booking service is unreachable")
+	}
+}
+
+func TestPaymentService_CreatePayment_UnresolvedBookingFailsClosed(t *testing.T) {
+	tests := []struct {
+		name     string
+		bookings client.BookingClient
+	}{
+		{"no booking client", nil},
+		{"booking client without the booking", client.NewNoOpBookingClient()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewPaymentService(repository.NewMemoryPaymentRepository(), gateway.NewMockGatewayWithConfig(1.0, 0), &PaymentServiceConfig{
+				Currency:      "THB",
+				BookingClient: tt.bookings,
+			})
+
+			_, err := svc.CreatePayment(context.Background(), &CreatePaymentRequest{
+				TenantID:  "tenant-1",
+				BookingID: "booking-1",
+				UserID:    "user-1",
+				Amount:    100,
+				Currency:  "THB",
+				Method:    domain.PaymentMethodCreditCard,
+			})
+			if err == nil {
+				t.Error("CreatePayment() expected error when the booking total can't be resolved")
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/gateway"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/repository"
+)
+
+// processTestPayment creates and processes a payment with the given capture mode
+func processTestPayment(t *testing.T, mode CaptureMode) (PaymentService, *gateway.MockGateway, *domain.Payment) {
+	t.Helper()
+
+	gw := gateway.NewMockGateway(&gateway.MockGatewayConfig{SuccessRate: 1.0})
+	svc := NewPaymentService(repository.NewMemoryPaymentRepository(), gw, &PaymentServiceConfig{
+		Currency:      "THB",
+		CaptureMode:   mode,
+		BookingClient: bookingsTotalling(1500),
+	})
+
+	ctx := context.Background()
+	payment, err := svc.CreatePayment(ctx, &CreatePaymentRequest{
+		TenantID:  "tenant-1",
+		BookingID: "booking-1",
+		UserID:    "user-1",
+		Amount:    1500,
+		Currency:  "THB",
+		Method:    domain.PaymentMethodCreditCard,
+	})
+	if err != nil {
+		t.Fatalf("CreatePayment() error = %v", err)
+	}
+	payment, err = svc.ProcessPayment(ctx, payment.ID)
+	if err != nil {
+		t.Fatalf("ProcessPayment() error = %v", err)
+	}
+	return svc, gw, payment
+}
+
+func TestPaymentService_ManualCapture(t *testing.T) {
+	svc, gw, payment := processTestPayment(t, CaptureModeManual)
+	ctx := context.Background()
+
+	if payment.Status != domain.PaymentStatusAuthorized {
+		t.Fatalf("ProcessPayment() status = %s, want authorized", payment.Status)
+	}
+	txn, _ := gw.GetTransaction(ctx, payment.GatewayPaymentID)
+	if txn.Status != "authorized" {
+		t.Errorf("gateway transaction status = %s, want authorized", txn.Status)
+	}
+
+	captured, err := svc.CapturePayment(ctx, payment.ID)
+	if err != nil {
+		t.Fatalf("CapturePayment() error = %v", err)
+	}
+	if captured.Status != domain.PaymentStatusCaptured {
+		t.Errorf("CapturePayment() status = %s, want captured", captured.Status)
+	}
+	txn, _ = gw.GetTransaction(ctx, payment.GatewayPaymentID)
+	if txn.Status != "completed" {
+		t.Errorf("gateway transaction status = %s, want completed", txn.Status)
+	}
+
+	// Redelivered capture is a no-op
+	if _, err := svc.CapturePayment(ctx, payment.ID); err != nil {
+		t.Errorf("second CapturePayment() error = %v", err)
+	}
+	if _, err := svc.VoidPayment(ctx, payment.ID, "too late"); !errors.Is(err, domain.ErrInvalidPaymentStatus) {
+		t.Errorf("VoidPayment() on captured payment error = %v, want ErrInvalidPaymentStatus", err)
+	}
+}
+
+func TestPaymentService_RefundVoidsAuthorizedPayment(t *testing.T) {
+	svc, gw, payment := processTestPayment(t, CaptureModeManual)
+	ctx := context.Background()
+
+	refunded, err := svc.RefundPayment(ctx, payment.ID, "confirmation failed")
+	if err != nil {
+		t.Fatalf("RefundPayment() error = %v", err)
+	}
+	if refunded.Status != domain.PaymentStatusVoided {
+		t.Errorf("RefundPayment() status = %s, want voided", refunded.Status)
+	}
+	txn, _ := gw.GetTransaction(ctx, payment.GatewayPaymentID)
+	if txn.Status != "voided" {
+		t.Errorf("gateway transaction status = %s, want voided", txn.Status)
+	}
+
+	if _, err := svc.CapturePayment(ctx, payment.ID); !errors.Is(err, domain.ErrInvalidPaymentStatus) {
+		t.Errorf("CapturePayment() on voided payment error = %v, want ErrInvalidPaymentStatus", err)
+	}
+}
+
+func TestPaymentService_AutoCaptureSkipsCapture(t *testing.T) {
+	svc, _, payment := processTestPayment(t, "")
+	ctx := context.Background()
+
+	if payment.Status != domain.PaymentStatusSucceeded {
+		t.Fatalf("ProcessPayment() status = %s, want succeeded", payment.Status)
+	}
+
+	captured, err := svc.CapturePayment(ctx, payment.ID)
+	if err != nil {
+		t.Fatalf("CapturePayment() error = %v", err)
+	}
+	if captured.Status != domain.PaymentStatusSucceeded {
+		t.Errorf("CapturePayment() status = %s, want succeeded", captured.Status)
+	}
+
+	refunded, err := svc.RefundPayment(ctx, payment.ID, "customer request")
+	if err != nil {
+		t.Fatalf("RefundPayment() error = %v", err)
+	}
+	if refunded.Status != domain.PaymentStatusRefunded {
+		t.Errorf("RefundPayment() status = %s, want refunded", refunded.Status)
+	}
+}
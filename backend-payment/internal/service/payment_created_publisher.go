@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/events"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
+)
+
+// PaymentCreatedPublisher tells booking-service the customer started paying for a
+// booking, so the reservation is no longer suggested for early release
+type PaymentCreatedPublisher interface {
+	// PublishPaymentCreated publishes payment.created to payment-events
+	PublishPaymentCreated(ctx context.Context, payment *domain.Payment) error
+}
+
+// KafkaPaymentCreatedPublisher implements PaymentCreatedPublisher using Kafka
+type KafkaPaymentCreatedPublisher struct {
+	producer *kafka.Producer
+}
+
+// NewKafkaPaymentCreatedPublisher creates a new KafkaPaymentCreatedPublisher
+func NewKafkaPaymentCreatedPublisher(producer *kafka.Producer) *KafkaPaymentCreatedPublisher {
+	return &KafkaPaymentCreatedPublisher{producer: producer}
+}
+
+// PublishPaymentCreated publishes payment.created to payment-events
+func (p *KafkaPaymentCreatedPublisher) PublishPaymentCreated(ctx context.Context, payment *domain.Payment) error {
+	event, err := NewPaymentCreatedEvent(payment)
+	if err != nil {
+		return err
+	}
+	headers := map[string]string{
+		"event_type": string(event.EventType),
+		"source":     "payment-service",
+	}
+	return p.producer.ProduceJSON(ctx, event.Topic(), event.Key(), event, headers)
+}
+
+// NewPaymentCreatedEvent builds the payment.created event of a new payment
+func NewPaymentCreatedEvent(payment *domain.Payment) (*events.PaymentEvent, error) {
+	return events.NewPaymentEvent(events.PaymentEventCreated, uuid.New().String(), &events.PaymentEventData{
+		PaymentID:   payment.ID,
+		BookingID:   payment.BookingID,
+		TenantID:    payment.TenantID,
+		UserID:      payment.UserID,
+		Amount:      payment.Amount,
+		Currency:    payment.Currency,
+		Status:      string(payment.Status),
+		Method:      string(payment.Method),
+		ProcessedAt: payment.CreatedAt,
+	})
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/gateway"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/events"
+)
+
+// recordingCreatedPublisher records the payments it is asked to publish
+type recordingCreatedPublisher struct {
+	published []*domain.Payment
+	err       error
+}
+
+func (p *recordingCreatedPublisher) PublishPaymentCreated(ctx context.Context, payment *domain.Payment) error {
+	p.published = append(p.published, payment)
+	return p.err
+}
+
+func TestPaymentService_CreatePayment_PublishesPaymentCreated(t *testing.T) {
+	ctx := context.Background()
+	publisher := &recordingCreatedPublisher{}
+	svc := NewPaymentService(repository.NewMemoryPaymentRepository(), gateway.NewMockGatewayWithConfig(1.0, 0), &PaymentServiceConfig{
+		Currency:         "THB",
+		CreatedPublisher: publisher,
+		BookingClient:    bookingsTotalling(500),
+	})
+
+	payment, err := svc.CreatePayment(ctx, &CreatePaymentRequest{
+		TenantID:  "tenant-1",
+		BookingID: "booking-1",
+		UserID:    "user-1",
+		Amount:    500,
+		Currency:  "THB",
+		Method:    domain.PaymentMethodCreditCard,
+	})
+	if err != nil {
+		t.Fatalf("CreatePayment() error = %v", err)
+	}
+	if len(publisher.published) != 1 || publisher.published[0].ID != payment.ID {
+		t.Fatalf("published %d payments, want payment %s", len(publisher.published), payment.ID)
+	}
+
+	// A payment that already exists is not announced again
+	if _, err := svc.CreatePayment(ctx, &CreatePaymentRequest{TenantID: "tenant-1", BookingID: "booking-1", UserID: "user-1", Amount: 500, Currency: "THB", Method: domain.PaymentMethodCreditCard}); !errors.Is(err, domain.ErrPaymentAlreadyExists) {
+		t.Fatalf("CreatePayment() error = %v, want %v", err, domain.ErrPaymentAlreadyExists)
+	}
+	if len(publisher.published) != 1 {
+		t.Errorf("published %d payments, want 1", len(publisher.published))
+	}
+
+	// Failing to publish does not fail the payment
+	publisher.err = errors.New("kafka unavailable")
+	if _, err := svc.CreatePayment(ctx, &CreatePaymentRequest{TenantID: "tenant-1", BookingID: "booking-2", UserID: "user-1", Amount: 500, Currency: "THB", Method: domain.PaymentMethodCreditCard}); err != nil {
+		t.Errorf("CreatePayment() error = %v, want nil when publishing fails", err)
+	}
+}
+
+func TestNewPaymentCreatedEvent(t *testing.T) {
+	payment, err := domain.NewPayment("tenant-1", "booking-1", "user-1", 500, "THB", domain.PaymentMethodPromptPay)
+	if err != nil {
+		t.Fatalf("NewPayment() error = %v", err)
+	}
+
+	event, err := NewPaymentCreatedEvent(payment)
+	if err != nil {
+		t.Fatalf("NewPaymentCreatedEvent() error = %v", err)
+	}
+	if event.EventType != events.PaymentEventCreated || event.Topic() != events.TopicPaymentEvents || event.Key() != "booking-1" {
+		t.Errorf("event = %s on %s keyed %s, want payment.created on payment-events keyed booking-1", event.EventType, event.Topic(), event.Key())
+	}
+	if event.PaymentData.PaymentID != payment.ID || event.PaymentData.UserID != "user-1" || event.PaymentData.Method != string(domain.PaymentMethodPromptPay) {
+		t.Errorf("event data = %+v, want the payment", event.PaymentData)
+	}
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// PaymentMethodResolver resolves the payment methods accepted for an event
+type PaymentMethodResolver interface {
+	// ResolvePolicy returns the event's policy, else the tenant's default policy, else
+	// a default policy allowing every method. eventID may be empty when the event is
+	// not known.
+	ResolvePolicy(ctx context.Context, tenantID, eventID string) (*domain.PaymentMethodPolicy, error)
+}
+
+// PaymentMethodPolicyService manages the payment methods accepted per tenant and event
+type PaymentMethodPolicyService interface {
+	PaymentMethodResolver
+
+	// GetPolicy returns the policy set for a tenant (empty eventID) or event. Returns
+	// ErrPaymentMethodPolicyNotFound if none is set.
+	GetPolicy(ctx context.Context, tenantID, eventID string) (*domain.PaymentMethodPolicy, error)
+
+	// SetPolicy creates or replaces the methods accepted for a tenant (empty eventID) or event
+	SetPolicy(ctx context.Context, tenantID, eventID string, methods []domain.PaymentMethod, updatedBy string) (*domain.PaymentMethodPolicy, error)
+
+	// DeletePolicy removes the policy of a tenant or event, so the next broader policy applies
+	DeletePolicy(ctx context.Context, tenantID, eventID string) error
+
+	// ListPolicies returns a tenant's policies, the tenant default first
+	ListPolicies(ctx context.Context, tenantID string) ([]*domain.PaymentMethodPolicy, error)
+}
+
+// paymentMethodPolicyServiceImpl implements PaymentMethodPolicyService
+type paymentMethodPolicyServiceImpl struct {
+	repo repository.PaymentMethodPolicyRepository
+}
+
+// NewPaymentMethodPolicyService creates a new PaymentMethodPolicyService
+func NewPaymentMethodPolicyService(repo repository.PaymentMethodPolicyRepository) PaymentMethodPolicyService {
+	return &paymentMethodPolicyServiceImpl{repo: repo}
+}
+
+// ResolvePolicy returns the most specific policy that applies to an event
+func (s *paymentMethodPolicyServiceImpl) ResolvePolicy(ctx context.Context, tenantID, eventID string) (*domain.PaymentMethodPolicy, error) {
+	scopes := []string{""}
+	if eventID != "" {
+		scopes = []string{eventID, ""}
+	}
+	for _, scope := range scopes {
+		policy, err := s.repo.Get(ctx, tenantID, scope)
+		if err == nil {
+			return policy, nil
+		}
+		if !errors.Is(err, domain.ErrPaymentMethodPolicyNotFound) {
+			return nil, err
+		}
+	}
+	return domain.DefaultPaymentMethodPolicy(tenantID, eventID), nil
+}
+
+// GetPolicy returns the policy set for a tenant or event
+func (s *paymentMethodPolicyServiceImpl) GetPolicy(ctx context.Context, tenantID, eventID string) (*domain.PaymentMethodPolicy, error) {
+	return s.repo.Get(ctx, tenantID, eventID)
+}
+
+// SetPolicy creates or replaces the methods accepted for a tenant or event
+func (s *paymentMethodPolicyServiceImpl) SetPolicy(ctx context.Context, tenantID, eventID string, methods []domain.PaymentMethod, updatedBy string) (*domain.PaymentMethodPolicy, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.payment_method_policy.set")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("tenant_id", tenantID),
+		attribute.String("event_id", eventID),
+		attribute.Int("allowed_methods", len(methods)),
+	)
+
+	policy, err := domain.NewPaymentMethodPolicy(tenantID, strings.TrimSpace(eventID), methods, updatedBy)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if err := s.repo.Save(ctx, policy); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return policy, nil
+}
+
+// DeletePolicy removes the policy of a tenant or event
+func (s *paymentMethodPolicyServiceImpl) DeletePolicy(ctx context.Context, tenantID, eventID string) error {
+	return s.repo.Delete(ctx, tenantID, eventID)
+}
+
+// ListPolicies returns a tenant's policies, the tenant default first
+func (s *paymentMethodPolicyServiceImpl) ListPolicies(ctx context.Context, tenantID string) ([]*domain.PaymentMethodPolicy, error) {
+	return s.repo.ListByTenant(ctx, tenantID)
+}
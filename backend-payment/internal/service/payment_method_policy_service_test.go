@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/client"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/gateway"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/repository"
+)
+
+func TestPaymentMethodPolicyService_ResolvePolicy(t *testing.T) {
+	ctx := context.Background()
+	policies := NewPaymentMethodPolicyService(repository.NewMemoryPaymentMethodPolicyRepository())
+
+	// Without policies every method is accepted
+	policy, err := policies.ResolvePolicy(ctx, "tenant-1", "event-1")
+	if err != nil || !policy.IsDefault() || len(policy.AllowedMethods) != len(domain.PaymentMethods) {
+		t.Fatalf("ResolvePolicy() = %+v, %v, want the default policy", policy, err)
+	}
+
+	if _, err := policies.SetPolicy(ctx, "tenant-1", "", []domain.PaymentMethod{domain.PaymentMethodPromptPay, domain.PaymentMethodCreditCard}, "admin-1"); err != nil {
+		t.Fatalf("SetPolicy() error = %v", err)
+	}
+	if _, err := policies.SetPolicy(ctx, "tenant-1", "event-1", []domain.PaymentMethod{domain.PaymentMethodBankTransfer}, "admin-1"); err != nil {
+		t.Fatalf("SetPolicy() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		eventID string
+		allowed []domain.PaymentMethod
+	}{
+		{"event policy", "event-1", []domain.PaymentMethod{domain.PaymentMethodBankTransfer}},
+		{"tenant default", "event-2", []domain.PaymentMethod{domain.PaymentMethodCreditCard, domain.PaymentMethodPromptPay}},
+		{"unknown event", "", []domain.PaymentMethod{domain.PaymentMethodCreditCard, domain.PaymentMethodPromptPay}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, err := policies.ResolvePolicy(ctx, "tenant-1", tt.eventID)
+			if err != nil {
+				t.Fatalf("ResolvePolicy() error = %v", err)
+			}
+			if policy.AllowedList() != (&domain.PaymentMethodPolicy{AllowedMethods: tt.allowed}).AllowedList() {
+				t.Errorf("allowed methods = %s, want %v", policy.AllowedList(), tt.allowed)
+			}
+		})
+	}
+
+	// Removing the event policy falls back to the tenant default
+	if err := policies.DeletePolicy(ctx, "tenant-1", "event-1"); err != nil {
+		t.Fatalf("DeletePolicy() error = %v", err)
+	}
+	if policy, _ := policies.ResolvePolicy(ctx, "tenant-1", "event-1"); policy.EventID != "" {
+		t.Errorf("expected the tenant default after deleting the event policy, got %+v", policy)
+	}
+	if err := policies.DeletePolicy(ctx, "tenant-1", "event-1"); !errors.Is(err, domain.ErrPaymentMethodPolicyNotFound) {
+		t.Errorf("DeletePolicy() error = %v, want %v", err, domain.ErrPaymentMethodPolicyNotFound)
+	}
+}
+
+func TestPaymentMethodPolicyService_SetPolicyValidates(t *testing.T) {
+	policies := NewPaymentMethodPolicyService(repository.NewMemoryPaymentMethodPolicyRepository())
+
+	tests := []struct {
+		name    string
+		methods []domain.PaymentMethod
+		wantErr error
+	}{
+		{"no methods", nil, domain.ErrInvalidPaymentMethodPolicy},
+		{"unknown method", []domain.PaymentMethod{"crypto"}, domain.ErrInvalidPaymentMethod},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := policies.SetPolicy(context.Background(), "tenant-1", "event-1", tt.methods, "admin-1"); !errors.Is(err, tt.wantErr) {
+				t.Errorf("SetPolicy() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPaymentService_CreatePayment_EnforcesMethodPolicy(t *testing.T) {
+	ctx := context.Background()
+	policies := NewPaymentMethodPolicyService(repository.NewMemoryPaymentMethodPolicyRepository())
+	_, _ = policies.SetPolicy(ctx, "tenant-1", "event-transfer", []domain.PaymentMethod{domain.PaymentMethodBankTransfer}, "admin-1")
+	_, _ = policies.SetPolicy(ctx, "tenant-1", "", []domain.PaymentMethod{domain.PaymentMethodCreditCard, domain.PaymentMethodDebitCard}, "admin-1")
+
+	bookings := &mockBookingClient{totals: map[string]*client.BookingTotal{
+		"booking-transfer": {BookingID: "booking-transfer", UserID: "user-1", EventID: "event-transfer", TotalPrice: 500, Currency: "THB"},
+		"booking-other":    {BookingID: "booking-other", UserID: "user-1", EventID: "event-other", TotalPrice: 500, Currency: "THB"},
+	}}
+
+	tests := []struct {
+		name      string
+		bookingID string
+		eventID   string // Claimed by the client; the booking's event wins
+		method    domain.PaymentMethod
+		wantErr   error
+	}{
+		{"event allows bank transfer", "booking-transfer", "", domain.PaymentMethodBankTransfer, nil},
+		{"event rejects cards", "booking-transfer", "", domain.PaymentMethodCreditCard, domain.ErrPaymentMethodNotAllowed},
+		{"claimed event is ignored", "booking-transfer", "event-other", domain.PaymentMethodCreditCard, domain.ErrPaymentMethodNotAllowed},
+		{"tenant default allows cards", "booking-other", "", domain.PaymentMethodDebitCard, nil},
+		{"tenant default rejects promptpay", "booking-other", "", domain.PaymentMethodPromptPay, domain.ErrPaymentMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewPaymentService(repository.NewMemoryPaymentRepository(), gateway.NewMockGatewayWithConfig(1.0, 0), &PaymentServiceConfig{
+				Currency:       "THB",
+				BookingClient:  bookings,
+				MethodPolicies: policies,
+			})
+
+			_, err := svc.CreatePayment(ctx, &CreatePaymentRequest{
+				TenantID:  "tenant-1",
+				BookingID: tt.bookingID,
+				UserID:    "user-1",
+				Amount:    500,
+				Currency:  "THB",
+				Method:    tt.method,
+				EventID:   tt.eventID,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CreatePayment() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
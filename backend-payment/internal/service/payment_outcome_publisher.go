@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
+)
+
+// PaymentOutcomePublisher tells booking-service how a payment ended, like the Stripe
+// webhook does: a success confirms the booking, a seat release cancels it
+type PaymentOutcomePublisher interface {
+	// PublishPaymentSuccess publishes to payment.success
+	PublishPaymentSuccess(ctx context.Context, event *dto.PaymentSuccessEvent) error
+
+	// PublishSeatRelease publishes to payment.seat-release
+	PublishSeatRelease(ctx context.Context, event *dto.SeatReleaseEvent) error
+}
+
+// KafkaPaymentOutcomePublisher implements PaymentOutcomePublisher using Kafka
+type KafkaPaymentOutcomePublisher struct {
+	producer *kafka.Producer
+}
+
+// NewKafkaPaymentOutcomePublisher creates a new KafkaPaymentOutcomePublisher
+func NewKafkaPaymentOutcomePublisher(producer *kafka.Producer) *KafkaPaymentOutcomePublisher {
+	return &KafkaPaymentOutcomePublisher{
+		producer: producer,
+	}
+}
+
+// PublishPaymentSuccess publishes to payment.success
+func (p *KafkaPaymentOutcomePublisher) PublishPaymentSuccess(ctx context.Context, event *dto.PaymentSuccessEvent) error {
+	if err := event.Validate(); err != nil {
+		return err
+	}
+	return p.producer.ProduceJSON(ctx, event.Topic(), event.Key(), event, nil)
+}
+
+// PublishSeatRelease publishes to payment.seat-release
+func (p *KafkaPaymentOutcomePublisher) PublishSeatRelease(ctx context.Context, event *dto.SeatReleaseEvent) error {
+	if err := event.Validate(); err != nil {
+		return err
+	}
+	return p.producer.ProduceJSON(ctx, event.Topic(), event.Key(), event, nil)
+}
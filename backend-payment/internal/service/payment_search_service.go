@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// Limits for card last four searches
+const (
+	DefaultPaymentSearchLimit = 20
+	MaxPaymentSearchLimit     = 100
+)
+
+// PaymentSearchService looks up payments for the support booking search in booking-service
+type PaymentSearchService interface {
+	// ListByBookingIDs returns the payments made for the given bookings
+	ListByBookingIDs(ctx context.Context, bookingIDs []string) ([]*domain.Payment, error)
+
+	// ListByCardLastFour returns the most recent payments made with a card ending in lastFour
+	ListByCardLastFour(ctx context.Context, tenantID, lastFour string, limit int) ([]*domain.Payment, error)
+}
+
+// paymentSearchServiceImpl implements PaymentSearchService
+type paymentSearchServiceImpl struct {
+	repo repository.PaymentSearchRepository
+}
+
+// NewPaymentSearchService creates a new PaymentSearchService
+func NewPaymentSearchService(repo repository.PaymentSearchRepository) PaymentSearchService {
+	return &paymentSearchServiceImpl{repo: repo}
+}
+
+// ListByBookingIDs returns the payments made for the given bookings
+func (s *paymentSearchServiceImpl) ListByBookingIDs(ctx context.Context, bookingIDs []string) ([]*domain.Payment, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.payment_search.by_booking_ids")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("booking_ids", len(bookingIDs)))
+
+	if len(bookingIDs) == 0 {
+		span.SetStatus(codes.Error, "no booking ids")
+		return nil, domain.ErrInvalidSearchFilter
+	}
+	if len(bookingIDs) > MaxPaymentSearchLimit {
+		bookingIDs = bookingIDs[:MaxPaymentSearchLimit]
+	}
+
+	payments, err := s.repo.ListByBookingIDs(ctx, bookingIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("count", len(payments)))
+	span.SetStatus(codes.Ok, "")
+	return payments, nil
+}
+
+// ListByCardLastFour returns the most recent payments made with a card ending in lastFour
+func (s *paymentSearchServiceImpl) ListByCardLastFour(ctx context.Context, tenantID, lastFour string, limit int) ([]*domain.Payment, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.payment_search.by_card_last_four")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("tenant_id", tenantID))
+
+	if !isCardLastFour(lastFour) {
+		span.SetStatus(codes.Error, "invalid card last four")
+		return nil, domain.ErrInvalidCardLastFour
+	}
+	if limit <= 0 {
+		limit = DefaultPaymentSearchLimit
+	}
+	if limit > MaxPaymentSearchLimit {
+		limit = MaxPaymentSearchLimit
+	}
+
+	payments, err := s.repo.ListByCardLastFour(ctx, tenantID, lastFour, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("count", len(payments)))
+	span.SetStatus(codes.Ok, "")
+	return payments, nil
+}
+
+// isCardLastFour reports whether s is exactly four digits
+func isCardLastFour(s string) bool {
+	if len(s) != 4 {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
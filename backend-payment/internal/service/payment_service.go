@@ -3,7 +3,9 @@ package service
 import (
 	"context"
 
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/client"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 )
 
 // CreatePaymentRequest represents a request to create a payment (internal)
@@ -15,6 +17,18 @@ type CreatePaymentRequest struct {
 	Currency  string
 	Method    domain.PaymentMethod
 	Metadata  map[string]string
+	TaxInfo   *domain.TaxInfo // Optional buyer details for a full tax invoice
+	// EventID selects the event's payment method policy. The booking's event is used
+	// instead when the booking total is checked.
+	EventID string
+}
+
+// AdjustPaymentRequest represents a price difference to settle after a booking change (internal)
+type AdjustPaymentRequest struct {
+	PaymentID    string
+	AdjustmentID string  // Identifies the change so retries settle it once
+	Amount       float64 // Positive charges the difference, negative refunds it
+	Reason       string
 }
 
 // PaymentService defines the interface for payment business logic
@@ -38,16 +52,38 @@ type PaymentService interface {
 	// GetPaymentByBookingID retrieves a payment by booking ID
 	GetPaymentByBookingID(ctx context.Context, bookingID string) (*domain.Payment, error)
 
-	// GetUserPayments retrieves all payments for a user
-	GetUserPayments(ctx context.Context, userID string, limit, offset int) ([]*domain.Payment, error)
+	// GetUserPayments retrieves a page of a user's payments, newest first
+	GetUserPayments(ctx context.Context, userID string, page pagination.Params) ([]*domain.Payment, pagination.Info, error)
+
+	// CapturePayment captures an authorized payment. Payments charged in auto-capture
+	// mode are returned unchanged.
+	CapturePayment(ctx context.Context, paymentID string) (*domain.Payment, error)
 
-	// RefundPayment refunds a payment
+	// VoidPayment releases an authorized payment without charging the customer
+	VoidPayment(ctx context.Context, paymentID string, reason string) (*domain.Payment, error)
+
+	// RefundPayment refunds what is left of a payment. Authorized payments are voided instead.
 	RefundPayment(ctx context.Context, paymentID string, reason string) (*domain.Payment, error)
 
+	// AdjustPayment charges or partially refunds the price difference of a modified
+	// booking on its succeeded payment
+	AdjustPayment(ctx context.Context, req *AdjustPaymentRequest) (*domain.Payment, error)
+
 	// CancelPayment cancels a pending payment
 	CancelPayment(ctx context.Context, paymentID string) (*domain.Payment, error)
 }
 
+// CaptureMode selects when a payment's funds are captured
+type CaptureMode string
+
+const (
+	// CaptureModeAuto charges the card when the payment is processed
+	CaptureModeAuto CaptureMode = "auto"
+	// CaptureModeManual only authorizes the card when the payment is processed;
+	// the booking saga captures it after confirmation or voids it on failure
+	CaptureModeManual CaptureMode = "manual"
+)
+
 // PaymentServiceConfig holds configuration for the payment service
 type PaymentServiceConfig struct {
 	// Gateway type: "mock" or "stripe"
@@ -59,10 +95,25 @@ type PaymentServiceConfig struct {
 	GatewayWebhookSecret string
 
 	// Processing options
-	AutoCapture bool
+	CaptureMode CaptureMode // Empty means CaptureModeAuto
 	Currency    string
 
 	// Mock gateway settings
 	MockSuccessRate float64 // 0.0 to 1.0, default 0.95 (95% success)
 	MockDelayMs     int     // Simulated processing delay in milliseconds
+
+	// BookingClient looks up booking totals so payment amounts can't be chosen
+	// by the client. Payments are rejected without it.
+	BookingClient client.BookingClient
+
+	// Ledger posts the double-entry transactions of charges and refunds. Nil disables it.
+	Ledger LedgerRecorder
+
+	// MethodPolicies restricts the payment methods accepted per tenant and event.
+	// Nil accepts every method.
+	MethodPolicies PaymentMethodResolver
+
+	// CreatedPublisher tells booking-service a customer started paying for a booking.
+	// Nil disables it.
+	CreatedPublisher PaymentCreatedPublisher
 }
@@ -2,14 +2,20 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"net"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/client"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/gateway"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/metrics"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -65,6 +71,28 @@ func (s *paymentServiceImpl) CreatePayment(ctx context.Context, req *CreatePayme
 		attribute.String("method", string(req.Method)),
 	)
 
+	if err := s.validateAmount(ctx, req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if err := s.validateMethod(ctx, req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	// Reject incomplete buyer details now rather than when the invoice is issued
+	if req.TaxInfo != nil {
+		req.TaxInfo.Normalize()
+		if err := req.TaxInfo.Validate(); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+	}
+
 	// Check if payment already exists for this booking
 	existing, err := s.repo.GetByBookingID(ctx, req.BookingID)
 	if err == nil && existing != nil {
@@ -92,6 +120,7 @@ func (s *paymentServiceImpl) CreatePayment(ctx context.Context, req *CreatePayme
 	if req.Metadata != nil {
 		payment.Metadata = req.Metadata
 	}
+	payment.TaxInfo = req.TaxInfo
 
 	// Save to repository
 	if err := s.repo.Create(ctx, payment); err != nil {
@@ -103,6 +132,14 @@ func (s *paymentServiceImpl) CreatePayment(ctx context.Context, req *CreatePayme
 	// Record metrics
 	metrics.RecordPaymentCreated(ctx, payment.BookingID, string(payment.Method), payment.Currency, payment.Amount)
 
+	// The payment is created either way; without the event booking-service may still
+	// suggest releasing the reservation
+	if s.config.CreatedPublisher != nil {
+		if err := s.config.CreatedPublisher.PublishPaymentCreated(ctx, payment); err != nil {
+			span.RecordError(fmt.Errorf("failed to publish payment.created: %w", err))
+		}
+	}
+
 	// Add span event for payment created
 	span.AddEvent("payment_created", trace.WithAttributes(
 		attribute.String("payment_id", payment.ID),
@@ -117,6 +154,56 @@ func (s *paymentServiceImpl) CreatePayment(ctx context.Context, req *CreatePayme
 	return payment, nil
 }
 
+// validateAmount checks the requested amount and currency against the booking
+// total recorded by booking service. A booking that can't be resolved fails the
+// payment rather than trusting the requested amount.
+func (s *paymentServiceImpl) validateAmount(ctx context.Context, req *CreatePaymentRequest) error {
+	if s.config.BookingClient == nil {
+		return errors.New("failed to verify booking total: no booking client configured")
+	}
+
+	booking, err := s.config.BookingClient.GetBookingTotal(ctx, req.BookingID, req.UserID)
+	if err != nil {
+		if errors.Is(err, client.ErrBookingNotFound) {
+			return domain.ErrBookingNotFound
+		}
+		return fmt.Errorf("failed to verify booking total: %w", err)
+	}
+	if booking == nil {
+		return domain.ErrBookingNotFound
+	}
+	// The booking's event is authoritative for the payment method policy
+	if booking.EventID != "" {
+		req.EventID = booking.EventID
+	}
+
+	// Compare in minor units so float rounding can't cause false mismatches
+	if math.Round(req.Amount*100) != math.Round(booking.TotalPrice*100) {
+		return domain.ErrAmountMismatch
+	}
+	if req.Currency != "" && booking.Currency != "" && !strings.EqualFold(req.Currency, booking.Currency) {
+		return domain.ErrAmountMismatch
+	}
+	return nil
+}
+
+// validateMethod checks the payment method against the policy of the booking's event,
+// falling back to the tenant's policy
+func (s *paymentServiceImpl) validateMethod(ctx context.Context, req *CreatePaymentRequest) error {
+	if s.config.MethodPolicies == nil {
+		return nil
+	}
+
+	policy, err := s.config.MethodPolicies.ResolvePolicy(ctx, req.TenantID, req.EventID)
+	if err != nil {
+		return fmt.Errorf("failed to resolve payment method policy: %w", err)
+	}
+	if !policy.Allows(req.Method) {
+		return fmt.Errorf("%w: %s is not accepted, use one of: %s", domain.ErrPaymentMethodNotAllowed, req.Method, policy.AllowedList())
+	}
+	return nil
+}
+
 // ProcessPayment processes a payment by ID
 func (s *paymentServiceImpl) ProcessPayment(ctx context.Context, paymentID string) (*domain.Payment, error) {
 	ctx, span := telemetry.StartSpan(ctx, "service.payment.process")
@@ -154,16 +241,25 @@ func (s *paymentServiceImpl) ProcessPayment(ctx context.Context, paymentID strin
 		return nil, fmt.Errorf("failed to update payment status: %w", err)
 	}
 
-	// Process through gateway
+	// Process through gateway; in manual capture mode the card is only authorized
 	chargeReq := &gateway.ChargeRequest{
-		PaymentID: payment.ID,
-		Amount:    payment.Amount,
-		Currency:  payment.Currency,
-		Method:    string(payment.Method),
-		Metadata:  payment.Metadata,
+		PaymentID:     payment.ID,
+		Amount:        payment.Amount,
+		Currency:      payment.Currency,
+		Method:        string(payment.Method),
+		Metadata:      payment.Metadata,
+		AuthorizeOnly: s.config.CaptureMode == CaptureModeManual,
 	}
 
 	chargeResp, err := s.gateway.Charge(ctx, chargeReq)
+	if err != nil && isGatewayTimeout(err) {
+		// The card may have been charged before the call timed out, so the payment is
+		// left processing for the payment watchdog to resolve from the gateway's record
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("failure_reason", "GATEWAY_TIMEOUT"))
+		span.SetStatus(codes.Ok, "")
+		return payment, nil
+	}
 	if err != nil {
 		// Mark as failed with error details
 		span.RecordError(err)
@@ -176,8 +272,34 @@ func (s *paymentServiceImpl) ProcessPayment(ctx context.Context, paymentID strin
 		return payment, nil
 	}
 
+	if chargeResp.RequiresAction {
+		// The customer finishes paying at the gateway; the charge completes by webhook
+		// or on their return, else the payment watchdog abandons it
+		if err := s.awaitAction(ctx, payment, chargeResp); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		span.SetAttributes(
+			attribute.String("transaction_id", chargeResp.TransactionID),
+			attribute.String("status", string(payment.Status)),
+		)
+		span.SetStatus(codes.Ok, "")
+		return payment, nil
+	}
+
 	// Update payment based on gateway response
-	if chargeResp.Success {
+	if chargeResp.Success && chargeReq.AuthorizeOnly {
+		if err := payment.Authorize(chargeResp.TransactionID); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, fmt.Errorf("failed to authorize payment: %w", err)
+		}
+		span.SetAttributes(
+			attribute.String("transaction_id", chargeResp.TransactionID),
+			attribute.String("status", "authorized"),
+		)
+	} else if chargeResp.Success {
 		if err := payment.Complete(chargeResp.TransactionID); err != nil {
 			span.RecordError(err)
 			span.SetStatus(codes.Error, err.Error())
@@ -210,6 +332,10 @@ func (s *paymentServiceImpl) ProcessPayment(ctx context.Context, paymentID strin
 	durationSeconds := time.Since(startTime).Seconds()
 	if chargeResp.Success {
 		metrics.RecordPaymentProcessed(ctx, payment.BookingID, string(payment.Method), payment.Currency, durationSeconds)
+		// Authorized payments reach the ledger when they are captured
+		if payment.IsSuccessful() {
+			s.recordCharge(ctx, payment, payment.Amount)
+		}
 		// Add span event for payment completed
 		span.AddEvent("payment_completed", trace.WithAttributes(
 			attribute.String("payment_id", payment.ID),
@@ -267,34 +393,42 @@ func (s *paymentServiceImpl) GetPaymentByBookingID(ctx context.Context, bookingI
 	return payment, nil
 }
 
-// GetUserPayments retrieves all payments for a user
-func (s *paymentServiceImpl) GetUserPayments(ctx context.Context, userID string, limit, offset int) ([]*domain.Payment, error) {
+// GetUserPayments retrieves a page of a user's payments, newest first
+func (s *paymentServiceImpl) GetUserPayments(ctx context.Context, userID string, page pagination.Params) ([]*domain.Payment, pagination.Info, error) {
 	ctx, span := telemetry.StartSpan(ctx, "service.payment.get_user_payments")
 	defer span.End()
 
 	span.SetAttributes(
 		attribute.String("user_id", userID),
-		attribute.Int("limit", limit),
-		attribute.Int("offset", offset),
+		attribute.Int("limit", page.Limit),
+		attribute.Int("offset", page.Offset),
+		attribute.Bool("cursor", page.After != nil),
 	)
 
-	if limit <= 0 {
-		limit = 20
+	if page.Limit <= 0 {
+		page.Limit = pagination.DefaultLimit
 	}
-	if limit > 100 {
-		limit = 100
+	if page.Limit > pagination.MaxLimit {
+		page.Limit = pagination.MaxLimit
 	}
 
-	payments, err := s.repo.GetByUserID(ctx, userID, limit, offset)
+	payments, err := s.repo.GetByUserID(ctx, userID, page.Lookahead())
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
-		return nil, err
+		return nil, pagination.Info{}, err
 	}
 
-	span.SetAttributes(attribute.Int("result_count", len(payments)))
+	payments, info := pagination.Paginate(payments, page.Limit, func(p *domain.Payment) pagination.Cursor {
+		return pagination.Cursor{Time: p.CreatedAt, ID: p.ID}
+	})
+
+	span.SetAttributes(
+		attribute.Int("result_count", len(payments)),
+		attribute.Bool("has_more", info.HasMore),
+	)
 	span.SetStatus(codes.Ok, "")
-	return payments, nil
+	return payments, info, nil
 }
 
 // RefundPayment refunds a payment
@@ -320,8 +454,22 @@ func (s *paymentServiceImpl) RefundPayment(ctx context.Context, paymentID string
 		attribute.Float64("amount", payment.Amount),
 	)
 
-	// Process refund through gateway using GatewayPaymentID
-	if err := s.gateway.Refund(ctx, payment.GatewayPaymentID, payment.Amount); err != nil {
+	// Nothing was captured yet, so release the hold instead of refunding
+	if payment.IsAuthorized() {
+		span.SetAttributes(attribute.Bool("voided", true))
+		if err := s.voidAuthorized(ctx, payment, reason); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		span.SetStatus(codes.Ok, "")
+		return payment, nil
+	}
+
+	// Process refund through gateway using GatewayPaymentID. Earlier partial refunds
+	// of booking changes are not refunded twice.
+	refunded := payment.RefundableAmount()
+	if err := s.gateway.Refund(ctx, payment.GatewayPaymentID, refunded); err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 		return nil, fmt.Errorf("failed to process refund: %w", err)
@@ -343,11 +491,317 @@ func (s *paymentServiceImpl) RefundPayment(ctx context.Context, paymentID string
 
 	// Record metrics
 	metrics.RecordPaymentRefunded(ctx, payment.BookingID, reason, payment.Amount)
+	s.recordRefund(ctx, payment, refunded)
 
 	span.SetStatus(codes.Ok, "")
 	return payment, nil
 }
 
+// adjustmentMetadataKey returns the metadata key recording a settled adjustment
+func adjustmentMetadataKey(adjustmentID string) string {
+	return "adjustment:" + adjustmentID
+}
+
+// AdjustPayment charges or partially refunds the price difference of a modified booking.
+// The difference is settled against the booking's existing payment, so the booking keeps
+// a single payment whose amount and refunds add up to what the customer paid.
+func (s *paymentServiceImpl) AdjustPayment(ctx context.Context, req *AdjustPaymentRequest) (*domain.Payment, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.payment.adjust")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("payment_id", req.PaymentID),
+		attribute.String("adjustment_id", req.AdjustmentID),
+		attribute.Float64("amount", req.Amount),
+	)
+
+	if req.AdjustmentID == "" || math.Round(req.Amount*100) == 0 {
+		span.SetStatus(codes.Error, "invalid adjustment")
+		return nil, domain.ErrInvalidAmount
+	}
+
+	payment, err := s.repo.GetByID(ctx, req.PaymentID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.String("booking_id", payment.BookingID))
+
+	// A retried adjustment was already settled
+	metadataKey := adjustmentMetadataKey(req.AdjustmentID)
+	if _, ok := payment.Metadata[metadataKey]; ok {
+		span.SetAttributes(attribute.Bool("replayed", true))
+		span.SetStatus(codes.Ok, "")
+		return payment, nil
+	}
+
+	if !payment.IsSuccessful() {
+		err := fmt.Errorf("%w: cannot adjust %s payment", domain.ErrInvalidPaymentStatus, payment.Status)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	var reference string
+	if req.Amount > 0 {
+		chargeResp, err := s.gateway.Charge(ctx, &gateway.ChargeRequest{
+			PaymentID:   payment.ID,
+			Amount:      req.Amount,
+			Currency:    payment.Currency,
+			Method:      string(payment.Method),
+			Description: req.Reason,
+			Metadata: map[string]string{
+				"adjusts_payment_id": payment.ID,
+				"adjustment_id":      req.AdjustmentID,
+			},
+			CustomerID: payment.GatewayCustomerID,
+		})
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			metrics.RecordPaymentFailed(ctx, payment.BookingID, string(payment.Method), "GATEWAY_ERROR")
+			return nil, fmt.Errorf("%w: %v", domain.ErrPaymentFailed, err)
+		}
+		if !chargeResp.Success {
+			span.SetStatus(codes.Error, chargeResp.FailureReason)
+			metrics.RecordPaymentFailed(ctx, payment.BookingID, string(payment.Method), chargeResp.FailureReason)
+			return nil, fmt.Errorf("%w: %s", domain.ErrPaymentFailed, chargeResp.FailureReason)
+		}
+		if err := payment.AddCharge(req.Amount); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		reference = chargeResp.TransactionID
+	} else {
+		refund := -req.Amount
+		if math.Round(refund*100) > math.Round(payment.RefundableAmount()*100) {
+			span.SetStatus(codes.Error, domain.ErrRefundExceedsAmount.Error())
+			return nil, domain.ErrRefundExceedsAmount
+		}
+		if err := s.gateway.Refund(ctx, payment.GatewayPaymentID, refund); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, fmt.Errorf("%w: %v", domain.ErrRefundFailed, err)
+		}
+		if err := payment.PartialRefund(refund, req.Reason); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		reference = payment.GatewayPaymentID
+		metrics.RecordPaymentRefunded(ctx, payment.BookingID, req.Reason, refund)
+	}
+
+	if payment.Metadata == nil {
+		payment.Metadata = make(map[string]string)
+	}
+	payment.Metadata[metadataKey] = fmt.Sprintf("%.2f:%s", req.Amount, reference)
+
+	if err := s.repo.Update(ctx, payment); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to update payment: %w", err)
+	}
+
+	if req.Amount > 0 {
+		s.recordCharge(ctx, payment, req.Amount)
+	} else {
+		s.recordRefund(ctx, payment, -req.Amount)
+	}
+
+	span.AddEvent("payment_adjusted", trace.WithAttributes(
+		attribute.Float64("new_amount", payment.Amount),
+		attribute.Float64("refunded_amount", payment.RefundedAmount()),
+	))
+	span.SetStatus(codes.Ok, "")
+	return payment, nil
+}
+
+// CapturePayment captures an authorized payment. Payments charged in auto-capture
+// mode are returned unchanged.
+func (s *paymentServiceImpl) CapturePayment(ctx context.Context, paymentID string) (*domain.Payment, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.payment.capture")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("payment_id", paymentID))
+
+	// Get payment
+	payment, err := s.repo.GetByID(ctx, paymentID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.String("booking_id", payment.BookingID),
+		attribute.String("current_status", string(payment.Status)),
+	)
+
+	// Already charged: auto-capture mode or a redelivered capture
+	if payment.IsSuccessful() {
+		span.SetAttributes(attribute.Bool("skipped_already_captured", true))
+		span.SetStatus(codes.Ok, "")
+		return payment, nil
+	}
+	if !payment.IsAuthorized() {
+		err := fmt.Errorf("%w: cannot capture %s payment", domain.ErrInvalidPaymentStatus, payment.Status)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	// Capture through gateway using GatewayPaymentID
+	if err := s.gateway.Capture(ctx, payment.GatewayPaymentID, payment.Amount); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to capture payment: %w", err)
+	}
+
+	if err := payment.Capture(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to mark payment as captured: %w", err)
+	}
+
+	// Update in repository
+	if err := s.repo.Update(ctx, payment); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to update payment: %w", err)
+	}
+
+	// Record metrics
+	metrics.RecordPaymentCaptured(ctx, payment.BookingID, payment.Amount)
+	s.recordCharge(ctx, payment, payment.Amount)
+
+	span.SetStatus(codes.Ok, "")
+	return payment, nil
+}
+
+// VoidPayment releases an authorized payment without charging the customer
+func (s *paymentServiceImpl) VoidPayment(ctx context.Context, paymentID string, reason string) (*domain.Payment, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.payment.void")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("payment_id", paymentID),
+		attribute.String("void_reason", reason),
+	)
+
+	// Get payment
+	payment, err := s.repo.GetByID(ctx, paymentID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.String("booking_id", payment.BookingID),
+		attribute.String("current_status", string(payment.Status)),
+	)
+
+	if !payment.IsAuthorized() {
+		err := fmt.Errorf("%w: cannot void %s payment", domain.ErrInvalidPaymentStatus, payment.Status)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if err := s.voidAuthorized(ctx, payment, reason); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return payment, nil
+}
+
+// voidAuthorized voids an authorized payment at the gateway and saves it as voided
+func (s *paymentServiceImpl) voidAuthorized(ctx context.Context, payment *domain.Payment, reason string) error {
+	if err := s.gateway.Void(ctx, payment.GatewayPaymentID); err != nil {
+		return fmt.Errorf("failed to void payment: %w", err)
+	}
+
+	if err := payment.Void(reason); err != nil {
+		return fmt.Errorf("failed to mark payment as voided: %w", err)
+	}
+
+	if err := s.repo.Update(ctx, payment); err != nil {
+		return fmt.Errorf("failed to update payment: %w", err)
+	}
+
+	metrics.RecordPaymentVoided(ctx, payment.BookingID, reason)
+	return nil
+}
+
+// awaitAction saves a payment waiting for the customer to finish paying at the gateway
+// and hands the booking's hold off to it. A booking released in the meantime can no
+// longer be paid for, so the charge is voided and the payment cancelled.
+func (s *paymentServiceImpl) awaitAction(ctx context.Context, payment *domain.Payment, chargeResp *gateway.ChargeResponse) error {
+	if err := payment.RequireAction(chargeResp.TransactionID, chargeResp.RedirectURL); err != nil {
+		return fmt.Errorf("failed to mark payment as requiring action: %w", err)
+	}
+
+	released := false
+	if s.config.BookingClient != nil {
+		_, err := s.config.BookingClient.AwaitBookingPayment(ctx, payment.BookingID, payment.UserID, payment.ID)
+		switch {
+		case errors.Is(err, client.ErrBookingNotFound), errors.Is(err, client.ErrBookingReleased):
+			released = true
+		case err != nil:
+			// The booking keeps its regular hold, which the payment watchdog extends
+			trace.SpanFromContext(ctx).RecordError(err)
+		}
+	}
+
+	if released {
+		// A charge that cannot be voided stays requiring action for the watchdog to retry
+		if err := s.gateway.Void(ctx, payment.GatewayPaymentID); err != nil {
+			trace.SpanFromContext(ctx).RecordError(err)
+			released = false
+		} else if err := payment.Cancel(); err != nil {
+			return fmt.Errorf("failed to cancel payment: %w", err)
+		}
+	}
+
+	if err := s.repo.Update(ctx, payment); err != nil {
+		return fmt.Errorf("failed to update payment: %w", err)
+	}
+	if released {
+		metrics.RecordPaymentCancelled(ctx, payment.BookingID)
+	}
+	return nil
+}
+
+// isGatewayTimeout reports whether a gateway call timed out, leaving its outcome unknown
+func isGatewayTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, gateway.ErrGatewayTimeout) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// recordCharge posts an amount charged on a payment to the ledger, if one is configured
+func (s *paymentServiceImpl) recordCharge(ctx context.Context, payment *domain.Payment, amount float64) {
+	if s.config.Ledger != nil {
+		s.config.Ledger.RecordCharge(ctx, payment, amount)
+	}
+}
+
+// recordRefund posts an amount refunded on a payment to the ledger, if one is configured
+func (s *paymentServiceImpl) recordRefund(ctx context.Context, payment *domain.Payment, amount float64) {
+	if s.config.Ledger != nil {
+		s.config.Ledger.RecordRefund(ctx, payment, amount)
+	}
+}
+
 // CancelPayment cancels a pending payment
 func (s *paymentServiceImpl) CancelPayment(ctx context.Context, paymentID string) (*domain.Payment, error) {
 	ctx, span := telemetry.StartSpan(ctx, "service.payment.cancel")
@@ -420,8 +874,14 @@ func (s *paymentServiceImpl) CompletePaymentFromWebhook(ctx context.Context, pay
 		return payment, nil
 	}
 
-	// Complete payment directly (no gateway call needed - Stripe already processed it)
-	if err := payment.Complete(gatewayPaymentID); err != nil {
+	// Complete payment directly (no gateway call needed - Stripe already processed it).
+	// An authorized payment was captured at the gateway before CapturePayment saved it.
+	if payment.IsAuthorized() {
+		err = payment.Capture()
+	} else {
+		err = payment.Complete(gatewayPaymentID)
+	}
+	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 		return nil, fmt.Errorf("failed to complete payment: %w", err)
@@ -434,6 +894,9 @@ func (s *paymentServiceImpl) CompletePaymentFromWebhook(ctx context.Context, pay
 		return nil, fmt.Errorf("failed to update payment: %w", err)
 	}
 
+	// ProcessPayment usually recorded the charge already, which the ledger skips
+	s.recordCharge(ctx, payment, payment.Amount)
+
 	span.SetAttributes(attribute.String("new_status", string(payment.Status)))
 	span.SetStatus(codes.Ok, "")
 	return payment, nil
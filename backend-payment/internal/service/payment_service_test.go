@@ -10,6 +10,7 @@ import (
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/gateway"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/repository"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 )
 
 func skipIfNoIntegration(t *testing.T) {
@@ -205,7 +206,7 @@ func TestPaymentService_GetUserPayments_Integration(t *testing.T) {
 	}
 
 	// Get all payments
-	payments, err := svc.GetUserPayments(ctx, testUserID, 10, 0)
+	payments, _, err := svc.GetUserPayments(ctx, testUserID, pagination.Params{Limit: 10})
 	if err != nil {
 		t.Fatalf("Failed to get user payments: %v", err)
 	}
@@ -215,7 +216,7 @@ func TestPaymentService_GetUserPayments_Integration(t *testing.T) {
 	}
 
 	// Test pagination
-	page1, err := svc.GetUserPayments(ctx, testUserID, 2, 0)
+	page1, info, err := svc.GetUserPayments(ctx, testUserID, pagination.Params{Limit: 2})
 	if err != nil {
 		t.Fatalf("Failed to get page 1: %v", err)
 	}
@@ -224,7 +225,11 @@ func TestPaymentService_GetUserPayments_Integration(t *testing.T) {
 		t.Errorf("Expected 2 payments on page 1, got %d", len(page1))
 	}
 
-	page2, err := svc.GetUserPayments(ctx, testUserID, 2, 2)
+	if !info.HasMore {
+		t.Error("Expected more payments after page 1")
+	}
+
+	page2, _, err := svc.GetUserPayments(ctx, testUserID, pagination.Params{Limit: 2, Offset: 2})
 	if err != nil {
 		t.Fatalf("Failed to get page 2: %v", err)
 	}
@@ -232,6 +237,19 @@ func TestPaymentService_GetUserPayments_Integration(t *testing.T) {
 	if len(page2) != 2 {
 		t.Errorf("Expected 2 payments on page 2, got %d", len(page2))
 	}
+
+	// Cursor continues after the last payment of page 1
+	after, err := pagination.DecodeCursor(info.NextCursor)
+	if err != nil {
+		t.Fatalf("Failed to decode next cursor: %v", err)
+	}
+	cursorPage, _, err := svc.GetUserPayments(ctx, testUserID, pagination.Params{Limit: 2, After: after})
+	if err != nil {
+		t.Fatalf("Failed to get page after cursor: %v", err)
+	}
+	if len(cursorPage) != 2 || cursorPage[0].ID != page2[0].ID {
+		t.Errorf("Expected cursor page to match page 2")
+	}
 }
 
 func TestPaymentService_RefundPayment_Integration(t *testing.T) {
@@ -0,0 +1,512 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/client"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/gateway"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/metrics"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/events"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const (
+	// DefaultPaymentStuckAfter is how long a payment may be processing before the
+	// watchdog asks the gateway about it
+	DefaultPaymentStuckAfter = 10 * time.Minute
+	// DefaultPaymentWatchdogBatchSize is how many stuck payments are resolved per run
+	DefaultPaymentWatchdogBatchSize = 100
+	// DefaultPaymentHoldGraceAfter is how long a payment may be processing before the
+	// watchdog starts extending its booking's hold
+	DefaultPaymentHoldGraceAfter = time.Minute
+	// DefaultPaymentActionTimeout is how long a customer has to finish paying at the
+	// gateway before the watchdog abandons the payment
+	DefaultPaymentActionTimeout = 30 * time.Minute
+
+	// PaymentWatchdogRefundReason is recorded on charges refunded because their
+	// booking was released while the payment was stuck
+	PaymentWatchdogRefundReason = "payment_watchdog"
+
+	// stuckPaymentNotChargedCode is recorded on stuck payments the gateway has no charge for
+	stuckPaymentNotChargedCode = "GATEWAY_TIMEOUT"
+	// actionExpiredCode is recorded on payments abandoned because the customer never
+	// finished paying at the gateway
+	actionExpiredCode = "PAYMENT_ACTION_EXPIRED"
+)
+
+// StuckPaymentResolution is what the watchdog did with a stuck payment
+type StuckPaymentResolution string
+
+const (
+	StuckPaymentSucceeded  StuckPaymentResolution = "succeeded"  // Charged; the booking is confirmed
+	StuckPaymentAuthorized StuckPaymentResolution = "authorized" // Card held; the booking is confirmed
+	StuckPaymentFailed     StuckPaymentResolution = "failed"     // Not charged; the seats are released
+	StuckPaymentRefunded   StuckPaymentResolution = "refunded"   // Charged for a released booking; refunded or voided
+	StuckPaymentPending    StuckPaymentResolution = "pending"    // Still in progress at the gateway; checked again next run
+	StuckPaymentAbandoned  StuckPaymentResolution = "abandoned"  // Customer never finished paying; voided and the seats released
+)
+
+// PaymentWatchdogConfig contains configuration for the payment watchdog
+type PaymentWatchdogConfig struct {
+	// StuckAfter is how long a payment may be processing before it is resolved (default: 10m)
+	StuckAfter time.Duration
+	// BatchSize bounds the payments resolved per run (default: 100)
+	BatchSize int
+	// HoldGraceAfter is how long a payment may be processing before its booking's hold
+	// is extended, so a delayed webhook does not expire a paid booking (default: 1m)
+	HoldGraceAfter time.Duration
+	// ActionTimeout is how long a payment may wait for the customer to finish paying at
+	// the gateway before it is abandoned. Keep it within booking-service's external
+	// payment window, which bounds how long the booking is held (default: 30m).
+	ActionTimeout time.Duration
+	// BookingClient tells whether a charged payment's booking is still waiting for it.
+	// Nil confirms every charged payment and leaves it to booking-service.
+	BookingClient client.BookingClient
+	// Publisher confirms or releases the bookings of resolved payments. Nil disables it.
+	Publisher PaymentOutcomePublisher
+}
+
+// PaymentWatchdogResult summarizes a watchdog run
+type PaymentWatchdogResult struct {
+	Checked  int
+	Resolved map[StuckPaymentResolution]int
+	Errors   int
+}
+
+// PaymentHoldResult summarizes a run extending the holds of processing payments
+type PaymentHoldResult struct {
+	Checked  int
+	Extended int // Holds pushed back; the others already ran as long as booking-service allows
+	Released int // Bookings already released; ReconcileStuck refunds them if charged, payments requiring action are abandoned
+	Errors   int
+}
+
+// PaymentWatchdogService resolves payments stuck processing, such as when the gateway
+// call timed out after charging, from the gateway's record of the charge
+type PaymentWatchdogService interface {
+	// ReconcileStuck resolves a batch of the payments processing for longer than StuckAfter.
+	// Payments that fail to resolve are left processing for the next run.
+	ReconcileStuck(ctx context.Context) (*PaymentWatchdogResult, error)
+
+	// ReconcilePayment resolves one payment processing or requiring action
+	ReconcilePayment(ctx context.Context, payment *domain.Payment) (StuckPaymentResolution, error)
+
+	// ExtendHolds extends the booking holds of a batch of the payments processing for longer
+	// than HoldGraceAfter, until the webhook or ReconcileStuck resolves them. Bookings of
+	// payments requiring action are handed off to them again.
+	ExtendHolds(ctx context.Context) (*PaymentHoldResult, error)
+
+	// ExpireActions abandons a batch of the payments requiring action for longer than
+	// ActionTimeout that the gateway has not completed: the charge is voided and the
+	// booking's seats released
+	ExpireActions(ctx context.Context) (*PaymentWatchdogResult, error)
+}
+
+// paymentWatchdogServiceImpl implements PaymentWatchdogService
+type paymentWatchdogServiceImpl struct {
+	stuckRepo      repository.StuckPaymentRepository
+	paymentRepo    repository.PaymentRepository
+	paymentService PaymentService
+	gateway        gateway.PaymentGateway
+	bookingClient  client.BookingClient
+	publisher      PaymentOutcomePublisher
+	stuckAfter     time.Duration
+	batchSize      int
+	holdGraceAfter time.Duration
+	actionTimeout  time.Duration
+	now            func() time.Time
+}
+
+// NewPaymentWatchdogService creates a new PaymentWatchdogService. Payments are moved
+// through paymentService so metrics and the ledger see the same transitions as a webhook.
+func NewPaymentWatchdogService(stuckRepo repository.StuckPaymentRepository, paymentRepo repository.PaymentRepository, paymentService PaymentService, gw gateway.PaymentGateway, cfg *PaymentWatchdogConfig) PaymentWatchdogService {
+	s := &paymentWatchdogServiceImpl{
+		stuckRepo:      stuckRepo,
+		paymentRepo:    paymentRepo,
+		paymentService: paymentService,
+		gateway:        gw,
+		stuckAfter:     DefaultPaymentStuckAfter,
+		batchSize:      DefaultPaymentWatchdogBatchSize,
+		holdGraceAfter: DefaultPaymentHoldGraceAfter,
+		actionTimeout:  DefaultPaymentActionTimeout,
+		now:            time.Now,
+	}
+	if cfg != nil {
+		if cfg.StuckAfter > 0 {
+			s.stuckAfter = cfg.StuckAfter
+		}
+		if cfg.BatchSize > 0 {
+			s.batchSize = cfg.BatchSize
+		}
+		if cfg.HoldGraceAfter > 0 {
+			s.holdGraceAfter = cfg.HoldGraceAfter
+		}
+		if cfg.ActionTimeout > 0 {
+			s.actionTimeout = cfg.ActionTimeout
+		}
+		s.bookingClient = cfg.BookingClient
+		s.publisher = cfg.Publisher
+	}
+	return s
+}
+
+// ReconcileStuck resolves a batch of the payments processing for longer than StuckAfter
+func (s *paymentWatchdogServiceImpl) ReconcileStuck(ctx context.Context) (*PaymentWatchdogResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.payment_watchdog.reconcile_stuck")
+	defer span.End()
+
+	cutoff := s.now().Add(-s.stuckAfter)
+	payments, err := s.stuckRepo.ListStuckProcessing(ctx, cutoff, s.batchSize)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to list stuck payments: %w", err)
+	}
+
+	result := &PaymentWatchdogResult{
+		Checked:  len(payments),
+		Resolved: make(map[StuckPaymentResolution]int),
+	}
+	for _, payment := range payments {
+		resolution, err := s.ReconcilePayment(ctx, payment)
+		if err != nil {
+			result.Errors++
+			logger.Get().Error(fmt.Sprintf("Payment watchdog: failed to resolve payment %s of booking %s: %v", payment.ID, payment.BookingID, err))
+			continue
+		}
+		result.Resolved[resolution]++
+	}
+
+	span.SetAttributes(
+		attribute.Int("checked", result.Checked),
+		attribute.Int("errors", result.Errors),
+	)
+	span.SetStatus(codes.Ok, "")
+	return result, nil
+}
+
+// ExtendHolds extends the booking holds of a batch of the payments processing for longer
+// than HoldGraceAfter. Booking-service caps how long a hold can be kept, so a payment
+// that never resolves cannot hold its seats forever.
+func (s *paymentWatchdogServiceImpl) ExtendHolds(ctx context.Context) (*PaymentHoldResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.payment_watchdog.extend_holds")
+	defer span.End()
+
+	result := &PaymentHoldResult{}
+	if s.bookingClient == nil {
+		span.SetStatus(codes.Ok, "no booking client")
+		return result, nil
+	}
+
+	cutoff := s.now().Add(-s.holdGraceAfter)
+	payments, err := s.stuckRepo.ListStuckProcessing(ctx, cutoff, s.batchSize)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to list processing payments: %w", err)
+	}
+
+	// A hand-off that failed in ProcessPayment is retried; it is a no-op once done
+	awaiting, err := s.stuckRepo.ListRequiringAction(ctx, cutoff, s.batchSize)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to list payments requiring action: %w", err)
+	}
+
+	result.Checked = len(payments) + len(awaiting)
+	for _, payment := range payments {
+		hold, err := s.bookingClient.ExtendBookingHold(ctx, payment.BookingID, payment.UserID)
+		s.countHold(result, payment, hold, err)
+	}
+	for _, payment := range awaiting {
+		hold, err := s.bookingClient.AwaitBookingPayment(ctx, payment.BookingID, payment.UserID, payment.ID)
+		s.countHold(result, payment, hold, err)
+		if errors.Is(err, client.ErrBookingNotFound) || errors.Is(err, client.ErrBookingReleased) {
+			// The seats are gone, so the customer must not be able to finish paying
+			if _, err := s.abandon(ctx, payment, false); err != nil {
+				result.Errors++
+				logger.Get().Error(fmt.Sprintf("Payment watchdog: failed to abandon payment %s of released booking %s: %v", payment.ID, payment.BookingID, err))
+			}
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("checked", result.Checked),
+		attribute.Int("extended", result.Extended),
+		attribute.Int("errors", result.Errors),
+	)
+	span.SetStatus(codes.Ok, "")
+	return result, nil
+}
+
+// countHold adds the outcome of extending a payment's booking hold to result
+func (s *paymentWatchdogServiceImpl) countHold(result *PaymentHoldResult, payment *domain.Payment, hold *client.BookingHold, err error) {
+	switch {
+	case errors.Is(err, client.ErrBookingNotFound), errors.Is(err, client.ErrBookingReleased):
+		result.Released++
+	case err != nil:
+		result.Errors++
+		logger.Get().Error(fmt.Sprintf("Payment watchdog: failed to extend hold of booking %s for payment %s: %v", payment.BookingID, payment.ID, err))
+	case hold != nil && hold.Extended:
+		result.Extended++
+	}
+}
+
+// ExpireActions abandons a batch of the payments requiring action for longer than
+// ActionTimeout. Each is reconciled first, as the customer may have finished paying
+// without the webhook arriving.
+func (s *paymentWatchdogServiceImpl) ExpireActions(ctx context.Context) (*PaymentWatchdogResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.payment_watchdog.expire_actions")
+	defer span.End()
+
+	cutoff := s.now().Add(-s.actionTimeout)
+	payments, err := s.stuckRepo.ListRequiringAction(ctx, cutoff, s.batchSize)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to list payments requiring action: %w", err)
+	}
+
+	result := &PaymentWatchdogResult{
+		Checked:  len(payments),
+		Resolved: make(map[StuckPaymentResolution]int),
+	}
+	for _, payment := range payments {
+		resolution, err := s.ReconcilePayment(ctx, payment)
+		if err == nil && resolution == StuckPaymentPending {
+			resolution, err = s.abandon(ctx, payment, true)
+		}
+		if err != nil {
+			result.Errors++
+			logger.Get().Error(fmt.Sprintf("Payment watchdog: failed to expire payment %s of booking %s: %v", payment.ID, payment.BookingID, err))
+			continue
+		}
+		result.Resolved[resolution]++
+	}
+
+	span.SetAttributes(
+		attribute.Int("checked", result.Checked),
+		attribute.Int("errors", result.Errors),
+	)
+	span.SetStatus(codes.Ok, "")
+	return result, nil
+}
+
+// abandon voids the gateway charge of a payment requiring action and cancels it. The
+// seats are released when the booking still holds them; a failed void leaves the
+// payment requiring action for the next run.
+func (s *paymentWatchdogServiceImpl) abandon(ctx context.Context, payment *domain.Payment, releaseSeats bool) (StuckPaymentResolution, error) {
+	if payment.GatewayPaymentID != "" {
+		if err := s.gateway.Void(ctx, payment.GatewayPaymentID); err != nil {
+			return "", fmt.Errorf("failed to void gateway charge: %w", err)
+		}
+	}
+	if _, err := s.paymentService.CancelPayment(ctx, payment.ID); err != nil {
+		return "", err
+	}
+	if releaseSeats {
+		s.publishSeatRelease(ctx, payment, actionExpiredCode, "customer did not finish paying at the gateway")
+	}
+
+	metrics.RecordStuckPaymentResolved(ctx, string(StuckPaymentAbandoned))
+	return StuckPaymentAbandoned, nil
+}
+
+// ReconcilePayment resolves one payment processing or requiring action
+func (s *paymentWatchdogServiceImpl) ReconcilePayment(ctx context.Context, payment *domain.Payment) (StuckPaymentResolution, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.payment_watchdog.reconcile")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("payment_id", payment.ID),
+		attribute.String("booking_id", payment.BookingID),
+	)
+
+	if payment.Status != domain.PaymentStatusProcessing && !payment.RequiresAction() {
+		err := fmt.Errorf("%w: payment is %s", domain.ErrInvalidPaymentStatus, payment.Status)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", err
+	}
+
+	txn, err := s.lookupTransaction(ctx, payment)
+	if err != nil && !errors.Is(err, gateway.ErrTransactionNotFound) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", fmt.Errorf("failed to look up gateway transaction: %w", err)
+	}
+
+	var resolution StuckPaymentResolution
+	switch {
+	case txn == nil:
+		// The charge never reached the gateway
+		resolution, err = s.fail(ctx, payment, stuckPaymentNotChargedCode, "no charge at the gateway")
+	default:
+		span.SetAttributes(
+			attribute.String("transaction_id", txn.TransactionID),
+			attribute.String("gateway_status", txn.Status),
+		)
+		switch transactionOutcome(txn.Status) {
+		case StuckPaymentSucceeded:
+			resolution, err = s.charged(ctx, payment, txn, false)
+		case StuckPaymentAuthorized:
+			resolution, err = s.charged(ctx, payment, txn, true)
+		case StuckPaymentFailed:
+			resolution, err = s.fail(ctx, payment, "PAYMENT_FAILED", "gateway status "+txn.Status)
+		default:
+			resolution = StuckPaymentPending
+		}
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", err
+	}
+
+	metrics.RecordStuckPaymentResolved(ctx, string(resolution))
+	span.SetAttributes(attribute.String("resolution", string(resolution)))
+	span.SetStatus(codes.Ok, "")
+	return resolution, nil
+}
+
+// lookupTransaction fetches the payment's charge from the gateway. A payment without
+// a gateway ID lost the charge response, so the charge is searched for by payment ID.
+func (s *paymentWatchdogServiceImpl) lookupTransaction(ctx context.Context, payment *domain.Payment) (*gateway.TransactionInfo, error) {
+	if payment.GatewayPaymentID != "" {
+		return s.gateway.GetTransaction(ctx, payment.GatewayPaymentID)
+	}
+	return s.gateway.FindTransaction(ctx, payment.ID)
+}
+
+// transactionOutcome maps a gateway transaction status to how the payment resolves.
+// Statuses of both Stripe PaymentIntents and the mock gateway are recognized.
+func transactionOutcome(status string) StuckPaymentResolution {
+	switch status {
+	case "succeeded", "completed":
+		return StuckPaymentSucceeded
+	case "requires_capture", "authorized":
+		return StuckPaymentAuthorized
+	case "canceled", "failed", "requires_payment_method":
+		return StuckPaymentFailed
+	default:
+		// processing, requires_action, requires_confirmation
+		return StuckPaymentPending
+	}
+}
+
+// fail marks a payment the gateway did not charge as failed and releases its seats
+func (s *paymentWatchdogServiceImpl) fail(ctx context.Context, payment *domain.Payment, code, message string) (StuckPaymentResolution, error) {
+	if _, err := s.paymentService.FailPaymentFromWebhook(ctx, payment.ID, code, message); err != nil {
+		return "", err
+	}
+	s.publishSeatRelease(ctx, payment, code, message)
+	return StuckPaymentFailed, nil
+}
+
+// charged records a charge the gateway made. If the booking was released in the
+// meantime, the customer paid for seats they no longer hold, so the charge is refunded.
+func (s *paymentWatchdogServiceImpl) charged(ctx context.Context, payment *domain.Payment, txn *gateway.TransactionInfo, authorized bool) (StuckPaymentResolution, error) {
+	// Before the payment leaves processing, so a failed lookup is retried next run
+	awaiting, err := s.bookingAwaitingPayment(ctx, payment)
+	if err != nil {
+		return "", err
+	}
+
+	resolution := StuckPaymentSucceeded
+	if authorized {
+		resolution = StuckPaymentAuthorized
+		if err := payment.Authorize(txn.TransactionID); err != nil {
+			return "", err
+		}
+		if err := s.paymentRepo.Update(ctx, payment); err != nil {
+			return "", fmt.Errorf("failed to update payment: %w", err)
+		}
+	} else if _, err := s.paymentService.CompletePaymentFromWebhook(ctx, payment.ID, txn.TransactionID); err != nil {
+		return "", err
+	}
+
+	switch awaiting {
+	case bookingAwaiting:
+		s.publishPaymentSuccess(ctx, payment, txn)
+	case bookingReleased:
+		if _, err := s.paymentService.RefundPayment(ctx, payment.ID, PaymentWatchdogRefundReason); err != nil {
+			return "", fmt.Errorf("charged for released booking, refund it manually: %w", err)
+		}
+		resolution = StuckPaymentRefunded
+	}
+	return resolution, nil
+}
+
+// bookingState is where a charged payment's booking stands
+type bookingState int
+
+const (
+	bookingAwaiting  bookingState = iota // Reserved or pending payment, waiting for the payment
+	bookingConfirmed                     // Already confirmed, e.g. by the webhook
+	bookingReleased                      // Expired, cancelled or gone
+)
+
+// bookingAwaitingPayment looks up the booking of a charged payment
+func (s *paymentWatchdogServiceImpl) bookingAwaitingPayment(ctx context.Context, payment *domain.Payment) (bookingState, error) {
+	if s.bookingClient == nil {
+		return bookingAwaiting, nil
+	}
+
+	booking, err := s.bookingClient.GetBookingTotal(ctx, payment.BookingID, payment.UserID)
+	if errors.Is(err, client.ErrBookingNotFound) {
+		return bookingReleased, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up booking: %w", err)
+	}
+
+	switch {
+	case booking == nil || booking.Status == "reserved" || booking.Status == "pending_payment":
+		return bookingAwaiting, nil
+	case booking.Status == "confirmed":
+		return bookingConfirmed, nil
+	default:
+		return bookingReleased, nil
+	}
+}
+
+// publishPaymentSuccess asks booking-service to confirm the booking
+func (s *paymentWatchdogServiceImpl) publishPaymentSuccess(ctx context.Context, payment *domain.Payment, txn *gateway.TransactionInfo) {
+	if s.publisher == nil {
+		return
+	}
+	event, err := events.NewPaymentSuccessEvent(payment.BookingID, payment.ID, txn.TransactionID, payment.UserID,
+		int64(math.Round(payment.Amount*100)), payment.Currency)
+	if err == nil {
+		event.TotalPrice = payment.Amount
+		err = s.publisher.PublishPaymentSuccess(ctx, event)
+	}
+	if err != nil {
+		logger.Get().Error(fmt.Sprintf("Payment watchdog: failed to publish payment success of booking %s: %v", payment.BookingID, err))
+	}
+}
+
+// publishSeatRelease asks booking-service to release the booking's seats
+func (s *paymentWatchdogServiceImpl) publishSeatRelease(ctx context.Context, payment *domain.Payment, code, message string) {
+	if s.publisher == nil {
+		return
+	}
+	event, err := events.NewSeatReleaseEvent(payment.BookingID, payment.ID, payment.UserID, dto.SeatReleaseReasonPaymentFailed, code, message)
+	if err == nil {
+		err = s.publisher.PublishSeatRelease(ctx, event)
+	}
+	if err != nil {
+		logger.Get().Error(fmt.Sprintf("Payment watchdog: failed to publish seat release of booking %s: %v", payment.BookingID, err))
+	}
+}
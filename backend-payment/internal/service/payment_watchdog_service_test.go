@@ -0,0 +1,323 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/client"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/gateway"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/repository"
+)
+
+// recordingOutcomePublisher records the booking events of resolved payments
+type recordingOutcomePublisher struct {
+	successes []*dto.PaymentSuccessEvent
+	releases  []*dto.SeatReleaseEvent
+}
+
+func (p *recordingOutcomePublisher) PublishPaymentSuccess(ctx context.Context, event *dto.PaymentSuccessEvent) error {
+	p.successes = append(p.successes, event)
+	return nil
+}
+
+func (p *recordingOutcomePublisher) PublishSeatRelease(ctx context.Context, event *dto.SeatReleaseEvent) error {
+	p.releases = append(p.releases, event)
+	return nil
+}
+
+// newStuckPayment stores a payment that started processing age ago. With charged set,
+// the gateway charged it but the response was lost.
+func newStuckPayment(t *testing.T, repo repository.PaymentRepository, gw gateway.PaymentGateway, bookingID string, age time.Duration, charged bool) *domain.Payment {
+	t.Helper()
+
+	payment, err := domain.NewPayment("tenant-1", bookingID, "user-1", 1000, "THB", domain.PaymentMethodCreditCard)
+	if err != nil {
+		t.Fatalf("NewPayment() error = %v", err)
+	}
+	if err := payment.MarkProcessing(); err != nil {
+		t.Fatalf("MarkProcessing() error = %v", err)
+	}
+	payment.UpdatedAt = time.Now().Add(-age)
+	if err := repo.Create(context.Background(), payment); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if charged {
+		resp, err := gw.Charge(context.Background(), &gateway.ChargeRequest{PaymentID: payment.ID, Amount: payment.Amount, Currency: payment.Currency})
+		if err != nil || !resp.Success {
+			t.Fatalf("Charge() = %+v, %v", resp, err)
+		}
+	}
+	return payment
+}
+
+func TestPaymentWatchdogService_ReconcileStuck(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryPaymentRepository()
+	gw := gateway.NewMockGatewayWithConfig(1.0, 0)
+	publisher := &recordingOutcomePublisher{}
+	bookings := &mockBookingClient{totals: map[string]*client.BookingTotal{
+		"booking-reserved":  {BookingID: "booking-reserved", UserID: "user-1", Status: "reserved"},
+		"booking-expired":   {BookingID: "booking-expired", UserID: "user-1", Status: "expired"},
+		"booking-uncharged": {BookingID: "booking-uncharged", UserID: "user-1", Status: "reserved"},
+	}}
+	svc := NewPaymentWatchdogService(repo, repo, NewPaymentService(repo, gw, nil), gw, &PaymentWatchdogConfig{
+		StuckAfter:    10 * time.Minute,
+		BookingClient: bookings,
+		Publisher:     publisher,
+	})
+
+	confirmed := newStuckPayment(t, repo, gw, "booking-reserved", time.Hour, true)
+	refunded := newStuckPayment(t, repo, gw, "booking-expired", time.Hour, true)
+	failed := newStuckPayment(t, repo, gw, "booking-uncharged", time.Hour, false)
+	recent := newStuckPayment(t, repo, gw, "booking-recent", time.Minute, true)
+
+	result, err := svc.ReconcileStuck(ctx)
+	if err != nil {
+		t.Fatalf("ReconcileStuck() error = %v", err)
+	}
+	if result.Checked != 3 || result.Errors != 0 {
+		t.Fatalf("ReconcileStuck() = %+v, want 3 checked without errors", result)
+	}
+	want := map[StuckPaymentResolution]int{StuckPaymentSucceeded: 1, StuckPaymentRefunded: 1, StuckPaymentFailed: 1}
+	for resolution, count := range want {
+		if result.Resolved[resolution] != count {
+			t.Errorf("resolved %s = %d, want %d", resolution, result.Resolved[resolution], count)
+		}
+	}
+
+	wantStatus := map[string]domain.PaymentStatus{
+		confirmed.ID: domain.PaymentStatusSucceeded,
+		refunded.ID:  domain.PaymentStatusRefunded,
+		failed.ID:    domain.PaymentStatusFailed,
+		recent.ID:    domain.PaymentStatusProcessing,
+	}
+	for id, status := range wantStatus {
+		if stored, _ := repo.GetByID(ctx, id); stored.Status != status {
+			t.Errorf("payment of booking %s is %s, want %s", stored.BookingID, stored.Status, status)
+		}
+	}
+
+	// The reserved booking is confirmed and the uncharged one released
+	if len(publisher.successes) != 1 || publisher.successes[0].BookingID != "booking-reserved" || publisher.successes[0].Amount != 100000 {
+		t.Errorf("payment success events = %+v, want booking-reserved for 100000 satang", publisher.successes)
+	}
+	if len(publisher.releases) != 1 || publisher.releases[0].BookingID != "booking-uncharged" || publisher.releases[0].Reason != dto.SeatReleaseReasonPaymentFailed {
+		t.Errorf("seat release events = %+v, want booking-uncharged", publisher.releases)
+	}
+}
+
+func TestPaymentWatchdogService_BookingLookupFailure(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryPaymentRepository()
+	gw := gateway.NewMockGatewayWithConfig(1.0, 0)
+	svc := NewPaymentWatchdogService(repo, repo, NewPaymentService(repo, gw, nil), gw, &PaymentWatchdogConfig{
+		BookingClient: &mockBookingClient{err: errors.New("booking service down")},
+	})
+
+	payment := newStuckPayment(t, repo, gw, "booking-1", time.Hour, true)
+
+	result, err := svc.ReconcileStuck(ctx)
+	if err != nil {
+		t.Fatalf("ReconcileStuck() error = %v", err)
+	}
+	if result.Errors != 1 {
+		t.Errorf("ReconcileStuck() = %+v, want 1 error", result)
+	}
+
+	// Without knowing the booking's state the charge is not settled either way
+	if stored, _ := repo.GetByID(ctx, payment.ID); stored.Status != domain.PaymentStatusProcessing {
+		t.Errorf("payment is %s, want processing until the booking can be looked up", stored.Status)
+	}
+}
+
+// holdExtendingBookingClient records hold extensions and hand-offs and answers them
+// from fixed outcomes
+type holdExtendingBookingClient struct {
+	mockBookingClient
+	errs      map[string]error
+	extended  []string
+	handedOff []string
+}
+
+func (c *holdExtendingBookingClient) ExtendBookingHold(ctx context.Context, bookingID, userID string) (*client.BookingHold, error) {
+	if err := c.errs[bookingID]; err != nil {
+		return nil, err
+	}
+	c.extended = append(c.extended, bookingID)
+	return &client.BookingHold{BookingID: bookingID, Status: "reserved", ExpiresAt: time.Now().Add(10 * time.Minute), Extended: true}, nil
+}
+
+func (c *holdExtendingBookingClient) AwaitBookingPayment(ctx context.Context, bookingID, userID, paymentID string) (*client.BookingHold, error) {
+	if err := c.errs[bookingID]; err != nil {
+		return nil, err
+	}
+	c.handedOff = append(c.handedOff, bookingID)
+	return &client.BookingHold{BookingID: bookingID, Status: "pending_payment", ExpiresAt: time.Now().Add(30 * time.Minute), Extended: true}, nil
+}
+
+func TestPaymentWatchdogService_ExtendHolds(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryPaymentRepository()
+	gw := gateway.NewMockGatewayWithConfig(1.0, 0)
+	bookings := &holdExtendingBookingClient{errs: map[string]error{
+		"booking-released": client.ErrBookingReleased,
+		"booking-down":     errors.New("booking service down"),
+	}}
+	svc := NewPaymentWatchdogService(repo, repo, NewPaymentService(repo, gw, nil), gw, &PaymentWatchdogConfig{
+		HoldGraceAfter: time.Minute,
+		BookingClient:  bookings,
+	})
+
+	awaiting := newStuckPayment(t, repo, gw, "booking-awaiting", 5*time.Minute, false)
+	newStuckPayment(t, repo, gw, "booking-released", 5*time.Minute, false)
+	newStuckPayment(t, repo, gw, "booking-down", 5*time.Minute, false)
+	newStuckPayment(t, repo, gw, "booking-just-started", 10*time.Second, false)
+
+	result, err := svc.ExtendHolds(ctx)
+	if err != nil {
+		t.Fatalf("ExtendHolds() error = %v", err)
+	}
+	if result.Checked != 3 || result.Extended != 1 || result.Released != 1 || result.Errors != 1 {
+		t.Errorf("ExtendHolds() = %+v, want 3 checked, 1 extended, 1 released, 1 error", result)
+	}
+	if len(bookings.extended) != 1 || bookings.extended[0] != "booking-awaiting" {
+		t.Errorf("extended holds = %v, want booking-awaiting only", bookings.extended)
+	}
+
+	// Extending a hold leaves the payment for the webhook or ReconcileStuck to resolve
+	if stored, _ := repo.GetByID(ctx, awaiting.ID); stored.Status != domain.PaymentStatusProcessing {
+		t.Errorf("payment is %s, want processing", stored.Status)
+	}
+}
+
+func TestPaymentService_ProcessPayment_TimeoutLeavesProcessing(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryPaymentRepository()
+	gw := gateway.NewMockGateway(&gateway.MockGatewayConfig{
+		SuccessRate:  1.0,
+		MagicAmounts: gateway.DefaultMagicAmounts(),
+		TimeoutMs:    1,
+	})
+	svc := NewPaymentService(repo, gw, &PaymentServiceConfig{Currency: "THB", BookingClient: bookingsTotalling(0.90)})
+
+	// 0.90 THB is the mock gateway's timeout scenario
+	payment, err := svc.CreatePayment(ctx, &CreatePaymentRequest{TenantID: "tenant-1", BookingID: "booking-1", UserID: "user-1", Amount: 0.90, Currency: "THB"})
+	if err != nil {
+		t.Fatalf("CreatePayment() error = %v", err)
+	}
+	processed, err := svc.ProcessPayment(ctx, payment.ID)
+	if err != nil {
+		t.Fatalf("ProcessPayment() error = %v", err)
+	}
+	if processed.Status != domain.PaymentStatusProcessing {
+		t.Errorf("timed out payment is %s, want processing for the watchdog", processed.Status)
+	}
+}
+
+// newActionPayment processes a payment the mock gateway asks 3-D Secure for and backdates
+// it by age
+func newActionPayment(t *testing.T, repo repository.PaymentRepository, svc PaymentService, bookingID string, age time.Duration) *domain.Payment {
+	t.Helper()
+	ctx := context.Background()
+
+	// 0.30 THB is the mock gateway's 3-D Secure scenario
+	payment, err := svc.CreatePayment(ctx, &CreatePaymentRequest{TenantID: "tenant-1", BookingID: bookingID, UserID: "user-1", Amount: 0.30, Currency: "THB"})
+	if err != nil {
+		t.Fatalf("CreatePayment() error = %v", err)
+	}
+	payment, err = svc.ProcessPayment(ctx, payment.ID)
+	if err != nil {
+		t.Fatalf("ProcessPayment() error = %v", err)
+	}
+	payment.UpdatedAt = time.Now().Add(-age)
+	if err := repo.Update(ctx, payment); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	return payment
+}
+
+func TestPaymentService_ProcessPayment_RequiresAction(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryPaymentRepository()
+	gw := gateway.NewMockGateway(&gateway.MockGatewayConfig{SuccessRate: 1.0, MagicAmounts: gateway.DefaultMagicAmounts()})
+	bookings := &holdExtendingBookingClient{
+		mockBookingClient: *bookingsTotalling(0.30),
+		errs:              map[string]error{"booking-released": client.ErrBookingReleased},
+	}
+	svc := NewPaymentService(repo, gw, &PaymentServiceConfig{Currency: "THB", BookingClient: bookings})
+
+	awaiting := newActionPayment(t, repo, svc, "booking-1", 0)
+	if !awaiting.RequiresAction() || awaiting.RedirectURL() == "" {
+		t.Fatalf("payment is %s with redirect %q, want requires_action with a redirect URL", awaiting.Status, awaiting.RedirectURL())
+	}
+	if len(bookings.handedOff) != 1 || bookings.handedOff[0] != "booking-1" {
+		t.Errorf("handed off bookings = %v, want booking-1", bookings.handedOff)
+	}
+
+	// A booking released before the hand-off cannot be paid for any more
+	released := newActionPayment(t, repo, svc, "booking-released", 0)
+	if released.Status != domain.PaymentStatusCancelled {
+		t.Errorf("payment of released booking is %s, want cancelled", released.Status)
+	}
+	if txn, err := gw.GetTransaction(ctx, released.GatewayPaymentID); err != nil || transactionOutcome(txn.Status) != StuckPaymentFailed {
+		t.Errorf("gateway transaction = %+v, %v, want it voided", txn, err)
+	}
+}
+
+func TestPaymentWatchdogService_ExpireActions(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryPaymentRepository()
+	gw := gateway.NewMockGateway(&gateway.MockGatewayConfig{SuccessRate: 1.0, MagicAmounts: gateway.DefaultMagicAmounts()})
+	paymentService := NewPaymentService(repo, gw, &PaymentServiceConfig{Currency: "THB", BookingClient: bookingsTotalling(0.30)})
+	publisher := &recordingOutcomePublisher{}
+	svc := NewPaymentWatchdogService(repo, repo, paymentService, gw, &PaymentWatchdogConfig{
+		ActionTimeout: 30 * time.Minute,
+		BookingClient: &mockBookingClient{totals: map[string]*client.BookingTotal{
+			"booking-paid": {BookingID: "booking-paid", UserID: "user-1", Status: "pending_payment"},
+		}},
+		Publisher: publisher,
+	})
+
+	paid := newActionPayment(t, repo, paymentService, "booking-paid", time.Hour)
+	abandoned := newActionPayment(t, repo, paymentService, "booking-abandoned", time.Hour)
+	recent := newActionPayment(t, repo, paymentService, "booking-recent", time.Minute)
+
+	// The customer finished paying but the webhook never arrived
+	if err := gw.CompleteAction(ctx, paid.GatewayPaymentID); err != nil {
+		t.Fatalf("CompleteAction() error = %v", err)
+	}
+
+	result, err := svc.ExpireActions(ctx)
+	if err != nil {
+		t.Fatalf("ExpireActions() error = %v", err)
+	}
+	if result.Checked != 2 || result.Errors != 0 || result.Resolved[StuckPaymentSucceeded] != 1 || result.Resolved[StuckPaymentAbandoned] != 1 {
+		t.Fatalf("ExpireActions() = %+v, want 1 succeeded and 1 abandoned", result)
+	}
+
+	wantStatus := map[string]domain.PaymentStatus{
+		paid.ID:      domain.PaymentStatusSucceeded,
+		abandoned.ID: domain.PaymentStatusCancelled,
+		recent.ID:    domain.PaymentStatusRequiresAction,
+	}
+	for id, status := range wantStatus {
+		if stored, _ := repo.GetByID(ctx, id); stored.Status != status {
+			t.Errorf("payment of booking %s is %s, want %s", stored.BookingID, stored.Status, status)
+		}
+	}
+	if txn, err := gw.GetTransaction(ctx, abandoned.GatewayPaymentID); err != nil || transactionOutcome(txn.Status) != StuckPaymentFailed {
+		t.Errorf("abandoned gateway transaction = %+v, %v, want it voided", txn, err)
+	}
+
+	if len(publisher.successes) != 1 || publisher.successes[0].BookingID != "booking-paid" {
+		t.Errorf("payment success events = %+v, want booking-paid", publisher.successes)
+	}
+	if len(publisher.releases) != 1 || publisher.releases[0].BookingID != "booking-abandoned" || publisher.releases[0].FailureCode != actionExpiredCode {
+		t.Errorf("seat release events = %+v, want booking-abandoned", publisher.releases)
+	}
+}
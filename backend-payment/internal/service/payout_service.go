@@ -0,0 +1,582 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/gateway"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/storage"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const (
+	// DefaultPayoutSettlementDelay is how long charges take to settle at the gateway;
+	// a payout only includes ledger entries older than this at its due time
+	DefaultPayoutSettlementDelay = 2 * 24 * time.Hour
+	// DefaultPayoutBatchSize is how many due schedules are paid out per run
+	DefaultPayoutBatchSize = 100
+
+	// PayoutFilePrefix is the object storage prefix of bank transfer files
+	PayoutFilePrefix = "payout-files/"
+)
+
+// BankFileHeader is the header row of a bank transfer file
+var BankFileHeader = []string{
+	"payout_id",
+	"tenant_id",
+	"bank_code",
+	"account_holder",
+	"account_token",
+	"amount",
+	"currency",
+	"period_end",
+}
+
+// PayoutServiceConfig contains configuration for tenant payouts
+type PayoutServiceConfig struct {
+	// SettlementDelay keeps unsettled charges out of a payout (default: 2 days)
+	SettlementDelay time.Duration
+	// BatchSize bounds the schedules paid out per run (default: 100)
+	BatchSize int
+	// Files keeps bank transfer files; without it the bank_file method is not available
+	Files storage.Store
+}
+
+// SetPayoutScheduleRequest represents a tenant's payout settings (internal)
+type SetPayoutScheduleRequest struct {
+	TenantID         string
+	Frequency        domain.PayoutFrequency
+	Method           domain.PayoutMethod
+	Currency         string // Defaults to THB
+	BankAccountToken string
+	BankCode         string
+	AccountHolder    string
+	AccountLast4     string
+	MinimumAmount    float64
+	Enabled          bool
+}
+
+// PayoutRunResult summarizes a payout run
+type PayoutRunResult struct {
+	Due       int
+	Submitted int    // Sent through the gateway payout API
+	Exported  int    // Listed in the bank transfer file
+	Failed    int    // Not sent; the balance carries over
+	Skipped   int    // Nothing to pay, below the minimum, or claimed by another worker
+	Errors    int    // Left due for the next run
+	BankFile  string // Key of the bank transfer file, if one was written
+}
+
+// PayoutService pays tenants their settled revenue on the schedule they configure.
+// A payout is the tenant_payable balance at the end of its period less the processing
+// fees not yet recovered, read from the ledger it then posts to.
+type PayoutService interface {
+	// SetSchedule creates or replaces a tenant's payout schedule
+	SetSchedule(ctx context.Context, req *SetPayoutScheduleRequest) (*domain.PayoutSchedule, error)
+
+	// GetSchedule retrieves a tenant's payout schedule
+	GetSchedule(ctx context.Context, tenantID string) (*domain.PayoutSchedule, error)
+
+	// RunDue makes the payouts of a batch of due schedules
+	RunDue(ctx context.Context) (*PayoutRunResult, error)
+
+	// GetPayout retrieves a payout
+	GetPayout(ctx context.Context, payoutID string) (*domain.Payout, error)
+
+	// ListPayouts retrieves a page of a tenant's payouts, newest first
+	ListPayouts(ctx context.Context, filter *domain.PayoutFilter, page pagination.Params) ([]*domain.Payout, pagination.Info, error)
+}
+
+// payoutServiceImpl implements PayoutService
+type payoutServiceImpl struct {
+	payoutRepo      repository.PayoutRepository
+	ledgerRepo      repository.LedgerRepository
+	gateway         gateway.PaymentGateway
+	files           storage.Store
+	settlementDelay time.Duration
+	batchSize       int
+	now             func() time.Time
+}
+
+// bankTransfer is a payout listed in a bank transfer file
+type bankTransfer struct {
+	payout   *domain.Payout
+	schedule *domain.PayoutSchedule
+}
+
+// NewPayoutService creates a new PayoutService
+func NewPayoutService(payoutRepo repository.PayoutRepository, ledgerRepo repository.LedgerRepository, gw gateway.PaymentGateway, cfg *PayoutServiceConfig) PayoutService {
+	s := &payoutServiceImpl{
+		payoutRepo:      payoutRepo,
+		ledgerRepo:      ledgerRepo,
+		gateway:         gw,
+		settlementDelay: DefaultPayoutSettlementDelay,
+		batchSize:       DefaultPayoutBatchSize,
+		now:             time.Now,
+	}
+	if cfg != nil {
+		if cfg.SettlementDelay > 0 {
+			s.settlementDelay = cfg.SettlementDelay
+		}
+		if cfg.BatchSize > 0 {
+			s.batchSize = cfg.BatchSize
+		}
+		s.files = cfg.Files
+	}
+	return s
+}
+
+// SetSchedule creates or replaces a tenant's payout schedule. The next payout keeps
+// its date unless the frequency changed.
+func (s *payoutServiceImpl) SetSchedule(ctx context.Context, req *SetPayoutScheduleRequest) (*domain.PayoutSchedule, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.payout.set_schedule")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("tenant_id", req.TenantID),
+		attribute.String("frequency", string(req.Frequency)),
+		attribute.String("method", string(req.Method)),
+	)
+
+	now := s.now().UTC()
+	schedule := &domain.PayoutSchedule{
+		TenantID:         req.TenantID,
+		Frequency:        req.Frequency,
+		Method:           req.Method,
+		Currency:         req.Currency,
+		BankAccountToken: req.BankAccountToken,
+		BankCode:         req.BankCode,
+		AccountHolder:    req.AccountHolder,
+		AccountLast4:     req.AccountLast4,
+		MinimumAmount:    domain.PayoutMinimum(req.MinimumAmount),
+		Enabled:          req.Enabled,
+		NextPayoutAt:     req.Frequency.Next(now),
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+	if schedule.Currency == "" {
+		schedule.Currency = "THB"
+	}
+	if err := schedule.Validate(); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if schedule.Method == domain.PayoutMethodBankFile && s.files == nil {
+		span.SetStatus(codes.Error, "file storage disabled")
+		return nil, domain.ErrFileStorageDisabled
+	}
+
+	existing, err := s.payoutRepo.GetSchedule(ctx, req.TenantID)
+	switch {
+	case err == nil:
+		if existing.Frequency == schedule.Frequency {
+			schedule.NextPayoutAt = existing.NextPayoutAt
+		}
+	case !errors.Is(err, domain.ErrPayoutScheduleNotFound):
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if err := s.payoutRepo.SaveSchedule(ctx, schedule); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.String("next_payout_at", schedule.NextPayoutAt.Format(time.RFC3339)))
+	span.SetStatus(codes.Ok, "")
+	return schedule, nil
+}
+
+// GetSchedule retrieves a tenant's payout schedule
+func (s *payoutServiceImpl) GetSchedule(ctx context.Context, tenantID string) (*domain.PayoutSchedule, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.payout.get_schedule")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("tenant_id", tenantID))
+
+	schedule, err := s.payoutRepo.GetSchedule(ctx, tenantID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return schedule, nil
+}
+
+// RunDue makes the payouts of a batch of due schedules. Gateway payouts are sent one by
+// one; bank_file payouts are listed together in one transfer file.
+func (s *payoutServiceImpl) RunDue(ctx context.Context) (*PayoutRunResult, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.payout.run_due")
+	defer span.End()
+
+	now := s.now().UTC()
+	schedules, err := s.payoutRepo.ListDueSchedules(ctx, now, s.batchSize)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to list due payout schedules: %w", err)
+	}
+
+	result := &PayoutRunResult{Due: len(schedules)}
+	var sent []*domain.Payout
+	var transfers []bankTransfer
+	for _, schedule := range schedules {
+		payout, err := s.createPayout(ctx, schedule, now)
+		if err != nil {
+			result.Errors++
+			logger.Get().Error(fmt.Sprintf("Failed to pay out tenant %s: %v", schedule.TenantID, err))
+			continue
+		}
+		if payout == nil {
+			result.Skipped++
+			continue
+		}
+
+		if payout.Method == domain.PayoutMethodBankFile {
+			transfers = append(transfers, bankTransfer{payout: payout, schedule: schedule})
+			continue
+		}
+		if err := s.send(ctx, payout); err != nil {
+			// Outcome unknown: resent under the same ID before the tenant's next payout
+			result.Errors++
+			logger.Get().Error(fmt.Sprintf("Payout %s of tenant %s left pending: %v", payout.ID, payout.TenantID, err))
+			continue
+		}
+		if payout.Status == domain.PayoutStatusFailed {
+			result.Failed++
+			continue
+		}
+		result.Submitted++
+		sent = append(sent, payout)
+	}
+
+	if len(transfers) > 0 {
+		key, err := s.exportBankFile(ctx, transfers, now)
+		for _, transfer := range transfers {
+			if err != nil {
+				transfer.payout.MarkFailed(err.Error())
+				result.Failed++
+			} else {
+				transfer.payout.MarkExported(key)
+				result.Exported++
+				sent = append(sent, transfer.payout)
+			}
+			if updateErr := s.payoutRepo.Update(ctx, transfer.payout); updateErr != nil {
+				logger.Get().Error(fmt.Sprintf("Failed to update payout %s: %v", transfer.payout.ID, updateErr))
+			}
+		}
+		if err == nil {
+			result.BankFile = key
+		}
+	}
+
+	// Unposted payouts are posted again before the tenant's next payout
+	for _, payout := range sent {
+		if err := s.post(ctx, payout); err != nil {
+			logger.Get().Error(fmt.Sprintf("Failed to post payout %s to the ledger: %v", payout.ID, err))
+		}
+	}
+
+	span.SetAttributes(
+		attribute.Int("due", result.Due),
+		attribute.Int("submitted", result.Submitted),
+		attribute.Int("exported", result.Exported),
+		attribute.Int("failed", result.Failed),
+		attribute.Int("errors", result.Errors),
+	)
+	span.SetStatus(codes.Ok, "")
+	return result, nil
+}
+
+// createPayout claims a due schedule and creates its payout, returning nil when there
+// is nothing to pay. The tenant's earlier payouts are settled first, or their amount
+// would still be in the balance and be paid again.
+func (s *payoutServiceImpl) createPayout(ctx context.Context, schedule *domain.PayoutSchedule, now time.Time) (*domain.Payout, error) {
+	if err := s.settlePrevious(ctx, schedule.TenantID); err != nil {
+		return nil, err
+	}
+
+	claimed, err := s.payoutRepo.ClaimSchedule(ctx, schedule.TenantID, schedule.NextPayoutAt, schedule.Frequency.Next(now))
+	if err != nil || !claimed {
+		return nil, err
+	}
+
+	periodEnd := schedule.NextPayoutAt.Add(-s.settlementDelay)
+	balances, err := s.ledgerRepo.GetBalances(ctx, schedule.TenantID, periodEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ledger balances: %w", err)
+	}
+	var gross, fees int64
+	for _, balance := range balances {
+		if balance.Currency != schedule.Currency {
+			continue
+		}
+		switch balance.Account {
+		case domain.LedgerAccountTenantPayable:
+			gross = balance.Balance()
+		case domain.LedgerAccountProcessingFees:
+			fees = balance.Balance()
+		}
+	}
+	if fees < 0 {
+		fees = 0
+	}
+	if net := gross - fees; net <= 0 || net < schedule.MinimumAmount {
+		return nil, nil
+	}
+
+	payout := domain.NewPayout(schedule, gross, fees, periodEnd)
+	if err := s.payoutRepo.Create(ctx, payout); err != nil {
+		if errors.Is(err, domain.ErrPayoutExists) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return payout, nil
+}
+
+// settlePrevious resends a tenant's pending gateway payouts and posts sent ones the
+// ledger is missing
+func (s *payoutServiceImpl) settlePrevious(ctx context.Context, tenantID string) error {
+	payouts, err := s.payoutRepo.ListUnsettled(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to list unsettled payouts: %w", err)
+	}
+	for _, payout := range payouts {
+		if payout.Status == domain.PayoutStatusPending {
+			if payout.Method == domain.PayoutMethodBankFile {
+				// Its bank file was never written
+				payout.MarkFailed("bank transfer file was not written")
+				if err := s.payoutRepo.Update(ctx, payout); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := s.send(ctx, payout); err != nil {
+				return fmt.Errorf("payout %s still pending: %w", payout.ID, err)
+			}
+		}
+		if payout.IsSent() {
+			if err := s.post(ctx, payout); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// send sends a payout through the gateway payout API. Declined payouts are marked
+// failed; an error means the outcome is unknown and the payout stays pending.
+func (s *payoutServiceImpl) send(ctx context.Context, payout *domain.Payout) error {
+	ctx, span := telemetry.StartSpan(ctx, "service.payout.send")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("payout_id", payout.ID),
+		attribute.String("tenant_id", payout.TenantID),
+		attribute.Int64("amount", payout.Amount),
+	)
+
+	resp, err := s.gateway.CreatePayout(ctx, &gateway.PayoutRequest{
+		PayoutID:    payout.ID,
+		Amount:      domain.MinorToMajor(payout.Amount),
+		Currency:    payout.Currency,
+		Destination: payout.BankAccountToken,
+		Description: "Payout for period ending " + payout.PeriodEnd.In(domain.InvoiceLocation).Format("2006-01-02"),
+		Metadata:    map[string]string{"tenant_id": payout.TenantID},
+	})
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, gateway.ErrGatewayTimeout) || ctx.Err() != nil {
+			span.SetStatus(codes.Error, "outcome unknown")
+			return err
+		}
+		payout.MarkFailed(err.Error())
+	} else {
+		payout.MarkSubmitted(resp.PayoutID)
+	}
+
+	if err := s.payoutRepo.Update(ctx, payout); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	span.SetAttributes(attribute.String("status", string(payout.Status)))
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// post records a sent payout in the ledger: the fees withheld are recovered from the
+// tenant's payable, and the rest leaves the gateway. Both are dated at the period end,
+// so the next payout's balance includes them.
+func (s *payoutServiceImpl) post(ctx context.Context, payout *domain.Payout) error {
+	reference := payout.Reference
+	if reference == "" {
+		reference = payout.ID
+	}
+
+	postings := []struct {
+		txType domain.LedgerTransactionType
+		amount int64
+	}{
+		{domain.LedgerTransactionFeeRecovery, payout.FeeAmount},
+		{domain.LedgerTransactionPayout, payout.Amount},
+	}
+	for _, posting := range postings {
+		if posting.amount <= 0 {
+			continue
+		}
+		tx, err := domain.NewLedgerTransaction(payout.TenantID, posting.txType, domain.MinorToMajor(posting.amount),
+			payout.Currency, domain.PayoutLedgerKey(payout, posting.txType), payout.PeriodEnd)
+		if err != nil {
+			return err
+		}
+		tx.Description = "Payout " + payout.ID
+		tx.SetPayment("", reference)
+		if err := tx.Validate(); err != nil {
+			return err
+		}
+		if err := s.ledgerRepo.Create(ctx, tx); err != nil && !errors.Is(err, domain.ErrLedgerTransactionExists) {
+			return fmt.Errorf("failed to post %s: %w", posting.txType, err)
+		}
+	}
+
+	payout.LedgerPosted = true
+	payout.UpdatedAt = time.Now().UTC()
+	return s.payoutRepo.Update(ctx, payout)
+}
+
+// exportBankFile writes the transfers to a CSV bank transfer file in file storage. The
+// file carries account tokens; the bank's bulk transfer system resolves them.
+func (s *payoutServiceImpl) exportBankFile(ctx context.Context, transfers []bankTransfer, now time.Time) (string, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.payout.export_bank_file")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("count", len(transfers)))
+
+	if s.files == nil {
+		span.SetStatus(codes.Error, "file storage disabled")
+		return "", domain.ErrFileStorageDisabled
+	}
+
+	var buf bytes.Buffer
+	cw := csv.NewWriter(&buf)
+	if err := cw.Write(BankFileHeader); err != nil {
+		return "", err
+	}
+	for _, t := range transfers {
+		err := cw.Write([]string{
+			t.payout.ID,
+			t.payout.TenantID,
+			t.schedule.BankCode,
+			t.schedule.AccountHolder,
+			t.payout.BankAccountToken,
+			formatAmount(domain.MinorToMajor(t.payout.Amount)),
+			t.payout.Currency,
+			t.payout.PeriodEnd.Format(time.RFC3339),
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return "", err
+	}
+
+	stamp := now.Format("20060102T150405Z")
+	key := fmt.Sprintf("%s%s/payouts-%s.csv", PayoutFilePrefix, now.Format("2006-01"), stamp)
+	if _, err := s.files.Put(ctx, key, &buf, storage.PutOptions{
+		ContentType:        "text/csv; charset=utf-8",
+		ContentDisposition: fmt.Sprintf(`attachment; filename="payouts-%s.csv"`, stamp),
+		Metadata:           map[string]string{"count": fmt.Sprint(len(transfers))},
+	}); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return "", fmt.Errorf("failed to store bank transfer file: %w", err)
+	}
+
+	span.SetAttributes(attribute.String("key", key))
+	span.SetStatus(codes.Ok, "")
+	return key, nil
+}
+
+// GetPayout retrieves a payout
+func (s *payoutServiceImpl) GetPayout(ctx context.Context, payoutID string) (*domain.Payout, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.payout.get")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("payout_id", payoutID))
+
+	payout, err := s.payoutRepo.GetByID(ctx, payoutID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return payout, nil
+}
+
+// ListPayouts retrieves a page of a tenant's payouts, newest first
+func (s *payoutServiceImpl) ListPayouts(ctx context.Context, filter *domain.PayoutFilter, page pagination.Params) ([]*domain.Payout, pagination.Info, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.payout.list")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("tenant_id", filter.TenantID),
+		attribute.String("status", string(filter.Status)),
+		attribute.Int("limit", page.Limit),
+		attribute.Bool("cursor", page.After != nil),
+	)
+
+	if filter.TenantID == "" {
+		span.SetStatus(codes.Error, "tenant_id required")
+		return nil, pagination.Info{}, fmt.Errorf("%w: tenant_id is required", domain.ErrInvalidPayoutFilter)
+	}
+	if filter.Status != "" && !filter.Status.IsValid() {
+		span.SetStatus(codes.Error, "unknown status")
+		return nil, pagination.Info{}, fmt.Errorf("%w: unknown status %q", domain.ErrInvalidPayoutFilter, filter.Status)
+	}
+
+	if page.Limit <= 0 {
+		page.Limit = pagination.DefaultLimit
+	}
+	if page.Limit > pagination.MaxLimit {
+		page.Limit = pagination.MaxLimit
+	}
+
+	payouts, err := s.payoutRepo.List(ctx, filter, page.Lookahead())
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, pagination.Info{}, err
+	}
+
+	payouts, info := pagination.Paginate(payouts, page.Limit, func(p *domain.Payout) pagination.Cursor {
+		return pagination.Cursor{Time: p.CreatedAt, ID: p.ID}
+	})
+
+	span.SetAttributes(
+		attribute.Int("result_count", len(payouts)),
+		attribute.Bool("has_more", info.HasMore),
+	)
+	span.SetStatus(codes.Ok, "")
+	return payouts, info, nil
+}
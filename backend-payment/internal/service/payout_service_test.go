@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/gateway"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
+)
+
+// newPayoutTestService wires a payout service over the ledger of a charged 1,500 THB
+// payment with a 45 THB processing fee
+func newPayoutTestService(t *testing.T) (*payoutServiceImpl, LedgerService) {
+	t.Helper()
+
+	_, ledger, _, _ := newLedgerTestServices(t)
+	if _, err := ledger.PostTransaction(context.Background(), &PostLedgerRequest{
+		TenantID:  "tenant-1",
+		Type:      domain.LedgerTransactionFee,
+		Amount:    45,
+		Reference: "fee_1",
+	}); err != nil {
+		t.Fatalf("PostTransaction() fee error = %v", err)
+	}
+
+	impl := ledger.(*ledgerServiceImpl)
+	svc := NewPayoutService(repository.NewMemoryPayoutRepository(), impl.ledgerRepo,
+		gateway.NewMockGateway(&gateway.MockGatewayConfig{SuccessRate: 1.0}),
+		&PayoutServiceConfig{SettlementDelay: time.Second},
+	).(*payoutServiceImpl)
+	return svc, ledger
+}
+
+// setPayoutSchedule configures a daily gateway payout and moves the clock past its due time
+func setPayoutSchedule(t *testing.T, svc *payoutServiceImpl, minimum float64) *domain.PayoutSchedule {
+	t.Helper()
+
+	schedule, err := svc.SetSchedule(context.Background(), &SetPayoutScheduleRequest{
+		TenantID:         "tenant-1",
+		Frequency:        domain.PayoutDaily,
+		Method:           domain.PayoutMethodGateway,
+		BankAccountToken: "ba_tok_123",
+		AccountHolder:    "Rush Events Co., Ltd.",
+		AccountLast4:     "6789",
+		MinimumAmount:    minimum,
+		Enabled:          true,
+	})
+	if err != nil {
+		t.Fatalf("SetSchedule() error = %v", err)
+	}
+	due := schedule.NextPayoutAt.Add(time.Hour)
+	svc.now = func() time.Time { return due }
+	return schedule
+}
+
+func TestPayoutService_RunDue(t *testing.T) {
+	svc, ledger := newPayoutTestService(t)
+	ctx := context.Background()
+	setPayoutSchedule(t, svc, 0)
+
+	result, err := svc.RunDue(ctx)
+	if err != nil {
+		t.Fatalf("RunDue() error = %v", err)
+	}
+	if result.Due != 1 || result.Submitted != 1 {
+		t.Fatalf("RunDue() = %+v, want 1 due and submitted", result)
+	}
+
+	page, err := pagination.NewParams("", 10, 0)
+	if err != nil {
+		t.Fatalf("NewParams() error = %v", err)
+	}
+	payouts, _, err := svc.ListPayouts(ctx, &domain.PayoutFilter{TenantID: "tenant-1"}, page)
+	if err != nil {
+		t.Fatalf("ListPayouts() error = %v", err)
+	}
+	if len(payouts) != 1 {
+		t.Fatalf("expected 1 payout, got %d", len(payouts))
+	}
+	payout := payouts[0]
+	if payout.GrossAmount != 150000 || payout.FeeAmount != 4500 || payout.Amount != 145500 {
+		t.Errorf("payout amounts = %d - %d = %d, want 150000 - 4500 = 145500", payout.GrossAmount, payout.FeeAmount, payout.Amount)
+	}
+	if payout.Status != domain.PayoutStatusSubmitted || payout.Reference == "" || !payout.LedgerPosted {
+		t.Errorf("unexpected payout state: %+v", payout)
+	}
+
+	// The tenant is paid in full and the fees are recovered
+	balances := ledgerBalances(t, ledger, "tenant-1")
+	if balances[domain.LedgerAccountTenantPayable] != 0 || balances[domain.LedgerAccountProcessingFees] != 0 {
+		t.Errorf("expected payable and fees settled, got %v", balances)
+	}
+
+	// The schedule moved on, so a second run pays nothing
+	result, err = svc.RunDue(ctx)
+	if err != nil {
+		t.Fatalf("RunDue() repeat error = %v", err)
+	}
+	if result.Due != 0 {
+		t.Errorf("RunDue() repeat = %+v, want nothing due", result)
+	}
+}
+
+func TestPayoutService_RunDueBelowMinimum(t *testing.T) {
+	svc, ledger := newPayoutTestService(t)
+	ctx := context.Background()
+	setPayoutSchedule(t, svc, 2000)
+
+	result, err := svc.RunDue(ctx)
+	if err != nil {
+		t.Fatalf("RunDue() error = %v", err)
+	}
+	if result.Due != 1 || result.Skipped != 1 || result.Submitted != 0 {
+		t.Errorf("RunDue() = %+v, want the payout skipped", result)
+	}
+
+	// The balance carries over to the next payout
+	if balance := ledgerBalances(t, ledger, "tenant-1")[domain.LedgerAccountTenantPayable]; balance != 150000 {
+		t.Errorf("tenant payable = %d, want 150000", balance)
+	}
+	schedule, err := svc.GetSchedule(ctx, "tenant-1")
+	if err != nil {
+		t.Fatalf("GetSchedule() error = %v", err)
+	}
+	if !schedule.NextPayoutAt.After(svc.now()) {
+		t.Errorf("expected the next payout after %s, got %s", svc.now(), schedule.NextPayoutAt)
+	}
+}
+
+func TestPayoutService_SetScheduleValidation(t *testing.T) {
+	svc, _ := newPayoutTestService(t)
+	ctx := context.Background()
+
+	valid := SetPayoutScheduleRequest{
+		TenantID:         "tenant-1",
+		Frequency:        domain.PayoutWeekly,
+		Method:           domain.PayoutMethodGateway,
+		BankAccountToken: "ba_tok_123",
+		AccountHolder:    "Rush Events Co., Ltd.",
+	}
+	tests := []struct {
+		name   string
+		modify func(*SetPayoutScheduleRequest)
+		want   error
+	}{
+		{"unknown frequency", func(r *SetPayoutScheduleRequest) { r.Frequency = "hourly" }, domain.ErrInvalidPayoutSchedule},
+		{"raw account number", func(r *SetPayoutScheduleRequest) { r.BankAccountToken = "123-4-56789-0" }, domain.ErrInvalidPayoutSchedule},
+		{"bad last4", func(r *SetPayoutScheduleRequest) { r.AccountLast4 = "12a4" }, domain.ErrInvalidPayoutSchedule},
+		{"bank file without storage", func(r *SetPayoutScheduleRequest) { r.Method = domain.PayoutMethodBankFile }, domain.ErrFileStorageDisabled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid
+			tt.modify(&req)
+			if _, err := svc.SetSchedule(ctx, &req); !errors.Is(err, tt.want) {
+				t.Errorf("SetSchedule() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	schedule, err := svc.SetSchedule(ctx, &valid)
+	if err != nil {
+		t.Fatalf("SetSchedule() error = %v", err)
+	}
+	if schedule.NextPayoutAt.In(domain.InvoiceLocation).Weekday() != time.Monday {
+		t.Errorf("weekly payout due %s, want a Monday", schedule.NextPayoutAt)
+	}
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/metrics"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// GatewayRefundReason is recorded on payments refunded directly at the gateway
+const GatewayRefundReason = "gateway_refund"
+
+// GatewayRefund describes a refunded charge reported by the gateway (internal)
+type GatewayRefund struct {
+	ChargeID         string
+	GatewayPaymentID string  // PaymentIntent the charge belongs to
+	PaymentID        string  // From the charge metadata, when present
+	AmountRefunded   float64 // Total refunded on the charge so far, not just the latest refund
+	Currency         string
+}
+
+// RefundReconciliation is the outcome of reconciling a gateway refund
+type RefundReconciliation struct {
+	Payment       *domain.Payment      // Nil when no payment matched
+	Recorded      float64              // Refund newly recorded on the payment
+	FullyRefunded bool                 // The payment became fully refunded by this refund
+	Review        *domain.RefundReview // Set when the refund was flagged for manual review
+}
+
+// RefundReconciliationService keeps payments in line with refunds made at the gateway
+type RefundReconciliationService interface {
+	// ReconcileRefund applies a gateway refund to its payment. Refunds already recorded,
+	// such as the ones this service made, are skipped; refunds that can't be applied are
+	// flagged for manual review instead of returning an error.
+	ReconcileRefund(ctx context.Context, refund *GatewayRefund) (*RefundReconciliation, error)
+
+	// ListReviews returns the refunds flagged for review in a status (all when empty)
+	ListReviews(ctx context.Context, status domain.RefundReviewStatus) ([]*domain.RefundReview, error)
+
+	// ResolveReview marks a flagged refund as handled
+	ResolveReview(ctx context.Context, reviewID, resolvedBy, note string) (*domain.RefundReview, error)
+}
+
+// refundReconciliationServiceImpl implements RefundReconciliationService
+type refundReconciliationServiceImpl struct {
+	paymentRepo repository.PaymentRepository
+	reviewRepo  repository.RefundReviewRepository
+	ledger      LedgerRecorder
+}
+
+// NewRefundReconciliationService creates a new RefundReconciliationService. ledger posts
+// the reconciled refunds and may be nil.
+func NewRefundReconciliationService(paymentRepo repository.PaymentRepository, reviewRepo repository.RefundReviewRepository, ledger LedgerRecorder) RefundReconciliationService {
+	return &refundReconciliationServiceImpl{
+		paymentRepo: paymentRepo,
+		reviewRepo:  reviewRepo,
+		ledger:      ledger,
+	}
+}
+
+// ReconcileRefund applies a gateway refund to its payment
+func (s *refundReconciliationServiceImpl) ReconcileRefund(ctx context.Context, refund *GatewayRefund) (*RefundReconciliation, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.refund.reconcile")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("charge_id", refund.ChargeID),
+		attribute.String("gateway_payment_id", refund.GatewayPaymentID),
+		attribute.Float64("amount_refunded", refund.AmountRefunded),
+	)
+
+	payment, err := s.matchPayment(ctx, refund)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if payment == nil {
+		review, err := s.flag(ctx, refund, "", domain.RefundReviewReasonUnmatched, "no payment matches the charge")
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		span.SetAttributes(attribute.Bool("flagged", true))
+		span.SetStatus(codes.Ok, "")
+		return &RefundReconciliation{Review: review}, nil
+	}
+
+	span.SetAttributes(
+		attribute.String("payment_id", payment.ID),
+		attribute.String("booking_id", payment.BookingID),
+	)
+
+	recorded, err := payment.SyncRefundedAmount(refund.AmountRefunded, GatewayRefundReason)
+	if err != nil {
+		reason := domain.RefundReviewReasonPaymentStatus
+		if errors.Is(err, domain.ErrRefundExceedsAmount) {
+			reason = domain.RefundReviewReasonExceedsAmount
+		}
+		review, flagErr := s.flag(ctx, refund, payment.ID, reason, err.Error())
+		if flagErr != nil {
+			span.RecordError(flagErr)
+			span.SetStatus(codes.Error, flagErr.Error())
+			return nil, flagErr
+		}
+		span.SetAttributes(attribute.Bool("flagged", true))
+		span.SetStatus(codes.Ok, "")
+		return &RefundReconciliation{Payment: payment, Review: review}, nil
+	}
+
+	// Already in line, e.g. the webhook of a refund this service made
+	if recorded == 0 {
+		span.SetAttributes(attribute.Bool("in_sync", true))
+		span.SetStatus(codes.Ok, "")
+		return &RefundReconciliation{Payment: payment}, nil
+	}
+
+	if err := s.paymentRepo.Update(ctx, payment); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to update payment: %w", err)
+	}
+
+	metrics.RecordPaymentRefunded(ctx, payment.BookingID, GatewayRefundReason, recorded)
+	if s.ledger != nil {
+		s.ledger.RecordRefund(ctx, payment, recorded)
+	}
+
+	span.SetAttributes(
+		attribute.Float64("recorded", recorded),
+		attribute.String("new_status", string(payment.Status)),
+	)
+	span.SetStatus(codes.Ok, "")
+	return &RefundReconciliation{
+		Payment:       payment,
+		Recorded:      recorded,
+		FullyRefunded: payment.Status == domain.PaymentStatusRefunded,
+	}, nil
+}
+
+// matchPayment finds the payment of a refunded charge, by the payment ID in the charge
+// metadata first and by its PaymentIntent otherwise. Returns nil when nothing matches.
+func (s *refundReconciliationServiceImpl) matchPayment(ctx context.Context, refund *GatewayRefund) (*domain.Payment, error) {
+	if refund.PaymentID != "" {
+		payment, err := s.paymentRepo.GetByID(ctx, refund.PaymentID)
+		if err == nil {
+			return payment, nil
+		}
+		if !errors.Is(err, domain.ErrPaymentNotFound) {
+			return nil, fmt.Errorf("failed to get payment: %w", err)
+		}
+	}
+	if refund.GatewayPaymentID != "" {
+		payment, err := s.paymentRepo.GetByGatewayPaymentID(ctx, refund.GatewayPaymentID)
+		if err == nil {
+			return payment, nil
+		}
+		if !errors.Is(err, domain.ErrPaymentNotFound) {
+			return nil, fmt.Errorf("failed to get payment: %w", err)
+		}
+	}
+	return nil, nil
+}
+
+// flag records a refund for manual review
+func (s *refundReconciliationServiceImpl) flag(ctx context.Context, refund *GatewayRefund, paymentID string, reason domain.RefundReviewReason, detail string) (*domain.RefundReview, error) {
+	review := domain.NewRefundReview(refund.ChargeID, refund.GatewayPaymentID, paymentID, refund.AmountRefunded, refund.Currency, reason, detail)
+	if err := s.reviewRepo.Save(ctx, review); err != nil {
+		return nil, fmt.Errorf("failed to flag refund for review: %w", err)
+	}
+	return review, nil
+}
+
+// ListReviews returns the refunds flagged for review in a status
+func (s *refundReconciliationServiceImpl) ListReviews(ctx context.Context, status domain.RefundReviewStatus) ([]*domain.RefundReview, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.refund.list_reviews")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("status", string(status)))
+
+	reviews, err := s.reviewRepo.List(ctx, status)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("count", len(reviews)))
+	span.SetStatus(codes.Ok, "")
+	return reviews, nil
+}
+
+// ResolveReview marks a flagged refund as handled
+func (s *refundReconciliationServiceImpl) ResolveReview(ctx context.Context, reviewID, resolvedBy, note string) (*domain.RefundReview, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.refund.resolve_review")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("review_id", reviewID))
+
+	review, err := s.reviewRepo.GetByID(ctx, reviewID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if err := review.Resolve(resolvedBy, note); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if err := s.reviewRepo.Update(ctx, review); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, fmt.Errorf("failed to update refund review: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return review, nil
+}
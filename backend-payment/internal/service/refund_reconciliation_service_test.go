@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/repository"
+)
+
+func TestRefundReconciliationService_ReconcileRefund(t *testing.T) {
+	paymentRepo := repository.NewMemoryPaymentRepository()
+	svc := NewRefundReconciliationService(paymentRepo, repository.NewMemoryRefundReviewRepository(), nil)
+	ctx := context.Background()
+
+	// Charges created from a PaymentIntent carry no payment metadata, so the
+	// PaymentIntent is used to find the payment
+	payment := newChargedPayment(t, paymentRepo, "booking-1", 1000, nil)
+	result, err := svc.ReconcileRefund(ctx, &GatewayRefund{ChargeID: "ch_1", GatewayPaymentID: "pi_booking-1", AmountRefunded: 400})
+	if err != nil {
+		t.Fatalf("ReconcileRefund() error = %v", err)
+	}
+	if result.Payment == nil || result.Payment.ID != payment.ID || result.Recorded != 400 || result.FullyRefunded {
+		t.Fatalf("ReconcileRefund() = %+v, want 400.00 recorded on %s", result, payment.ID)
+	}
+
+	// A redelivered webhook doesn't refund twice
+	result, err = svc.ReconcileRefund(ctx, &GatewayRefund{ChargeID: "ch_1", PaymentID: payment.ID, AmountRefunded: 400})
+	if err != nil || result.Recorded != 0 || result.Review != nil {
+		t.Errorf("ReconcileRefund() again = %+v, %v, want nothing recorded", result, err)
+	}
+
+	// Refunding the rest from the dashboard refunds the payment
+	result, err = svc.ReconcileRefund(ctx, &GatewayRefund{ChargeID: "ch_1", PaymentID: payment.ID, AmountRefunded: 1000})
+	if err != nil {
+		t.Fatalf("ReconcileRefund() full error = %v", err)
+	}
+	if result.Recorded != 600 || !result.FullyRefunded {
+		t.Errorf("ReconcileRefund() full = %+v, want 600.00 recorded and fully refunded", result)
+	}
+	stored, _ := paymentRepo.GetByID(ctx, payment.ID)
+	if stored.Status != domain.PaymentStatusRefunded || stored.RefundedAmount() != 1000 || stored.RefundReason != GatewayRefundReason {
+		t.Errorf("stored payment = %s with %.2f refunded (%s), want refunded 1000.00", stored.Status, stored.RefundedAmount(), stored.RefundReason)
+	}
+}
+
+func TestRefundReconciliationService_FlagsForReview(t *testing.T) {
+	paymentRepo := repository.NewMemoryPaymentRepository()
+	reviewRepo := repository.NewMemoryRefundReviewRepository()
+	svc := NewRefundReconciliationService(paymentRepo, reviewRepo, nil)
+	ctx := context.Background()
+
+	payment := newChargedPayment(t, paymentRepo, "booking-1", 500, nil)
+
+	tests := []struct {
+		name       string
+		refund     *GatewayRefund
+		wantReason domain.RefundReviewReason
+	}{
+		{"unknown charge", &GatewayRefund{ChargeID: "ch_unknown", GatewayPaymentID: "pi_unknown", AmountRefunded: 100}, domain.RefundReviewReasonUnmatched},
+		{"more than was paid", &GatewayRefund{ChargeID: "ch_over", PaymentID: payment.ID, AmountRefunded: 600}, domain.RefundReviewReasonExceedsAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := svc.ReconcileRefund(ctx, tt.refund)
+			if err != nil {
+				t.Fatalf("ReconcileRefund() error = %v", err)
+			}
+			if result.Review == nil || result.Review.Reason != tt.wantReason || result.Recorded != 0 {
+				t.Errorf("ReconcileRefund() = %+v, want a %s review", result, tt.wantReason)
+			}
+		})
+	}
+
+	stored, _ := paymentRepo.GetByID(ctx, payment.ID)
+	if stored.RefundedAmount() != 0 {
+		t.Errorf("flagged refund was recorded: %.2f refunded", stored.RefundedAmount())
+	}
+
+	// Webhook retries keep one review per charge
+	if _, err := svc.ReconcileRefund(ctx, tests[0].refund); err != nil {
+		t.Fatalf("ReconcileRefund() retry error = %v", err)
+	}
+	open, _ := svc.ListReviews(ctx, domain.RefundReviewStatusOpen)
+	if len(open) != 2 {
+		t.Fatalf("ListReviews(open) = %d reviews, want 2", len(open))
+	}
+
+	resolved, err := svc.ResolveReview(ctx, open[0].ID, "finance-1", "refunded by hand")
+	if err != nil || resolved.Status != domain.RefundReviewStatusResolved {
+		t.Fatalf("ResolveReview() = %v, %v, want resolved", resolved, err)
+	}
+	if _, err := svc.ResolveReview(ctx, open[0].ID, "finance-1", ""); !errors.Is(err, domain.ErrRefundReviewResolved) {
+		t.Errorf("ResolveReview() again error = %v, want ErrRefundReviewResolved", err)
+	}
+	if _, err := svc.ResolveReview(ctx, "missing", "finance-1", ""); !errors.Is(err, domain.ErrRefundReviewNotFound) {
+		t.Errorf("ResolveReview(missing) error = %v, want ErrRefundReviewNotFound", err)
+	}
+
+	// A further refund on a resolved charge needs another look
+	again := *tests[0].refund
+	if open[0].GatewayChargeID != again.ChargeID {
+		again = *tests[1].refund
+	}
+	again.AmountRefunded += 50
+	if _, err := svc.ReconcileRefund(ctx, &again); err != nil {
+		t.Fatalf("ReconcileRefund() further refund error = %v", err)
+	}
+	if open, _ = svc.ListReviews(ctx, domain.RefundReviewStatusOpen); len(open) != 2 {
+		t.Errorf("ListReviews(open) = %d reviews after a further refund, want 2", len(open))
+	}
+}
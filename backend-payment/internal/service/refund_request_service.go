@@ -0,0 +1,381 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/events"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// RefundRequestServiceConfig contains configuration for the refund approval workflow
+type RefundRequestServiceConfig struct {
+	// DefaultThreshold applies to tenants without an approval policy. Refunds above it
+	// wait for an admin; 0 sends every refund straight to the gateway.
+	DefaultThreshold float64
+	// Notifier announces each step of a refund request and may be nil
+	Notifier RefundNotifier
+}
+
+// RequestRefundRequest represents a refund requested through the API (internal)
+type RequestRefundRequest struct {
+	PaymentID   string
+	Reason      string
+	RequestedBy string
+}
+
+// RefundRequestService puts refunds requested through the API through an approval
+// workflow. A refund above the tenant's threshold waits for an admin to approve or
+// reject it, and only approved refunds are sent to the gateway. Refunds made by saga
+// compensation and the payment watchdog call PaymentService.RefundPayment directly
+// and are not subject to approval.
+type RefundRequestService interface {
+	// RequestRefund requests a refund of what is left of a payment. A refund at or below
+	// the threshold is made right away and the refunded payment is returned with the
+	// request; one above it is left pending approval. Authorized payments are voided
+	// without approval since nothing was charged.
+	RequestRefund(ctx context.Context, req *RequestRefundRequest) (*domain.RefundRequest, *domain.Payment, error)
+
+	// ApproveRefund approves a pending refund and sends it to the gateway. A refund the
+	// gateway could not make is returned marked failed along with the error.
+	ApproveRefund(ctx context.Context, requestID, decidedBy, reason string) (*domain.RefundRequest, error)
+
+	// RejectRefund rejects a pending refund; the payment is left as it is
+	RejectRefund(ctx context.Context, requestID, decidedBy, reason string) (*domain.RefundRequest, error)
+
+	// GetRefundRequest retrieves a refund request
+	GetRefundRequest(ctx context.Context, requestID string) (*domain.RefundRequest, error)
+
+	// ListRefundRequests returns the refund requests selected by filter, newest first
+	ListRefundRequests(ctx context.Context, filter *domain.RefundRequestFilter) ([]*domain.RefundRequest, error)
+
+	// GetPolicy returns a tenant's refund approval policy, or the default threshold
+	// when the tenant has none
+	GetPolicy(ctx context.Context, tenantID string) (*domain.RefundApprovalPolicy, error)
+
+	// SetPolicy creates or replaces a tenant's refund approval threshold
+	SetPolicy(ctx context.Context, tenantID string, threshold float64, updatedBy string) (*domain.RefundApprovalPolicy, error)
+}
+
+// refundRequestServiceImpl implements RefundRequestService
+type refundRequestServiceImpl struct {
+	requestRepo      repository.RefundRequestRepository
+	paymentService   PaymentService
+	defaultThreshold float64
+	notifier         RefundNotifier
+}
+
+// NewRefundRequestService creates a new RefundRequestService
+func NewRefundRequestService(requestRepo repository.RefundRequestRepository, paymentService PaymentService, cfg *RefundRequestServiceConfig) RefundRequestService {
+	s := &refundRequestServiceImpl{
+		requestRepo:    requestRepo,
+		paymentService: paymentService,
+	}
+	if cfg != nil {
+		if cfg.DefaultThreshold > 0 {
+			s.defaultThreshold = cfg.DefaultThreshold
+		}
+		s.notifier = cfg.Notifier
+	}
+	return s
+}
+
+// RequestRefund requests a refund of what is left of a payment
+func (s *refundRequestServiceImpl) RequestRefund(ctx context.Context, req *RequestRefundRequest) (*domain.RefundRequest, *domain.Payment, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.refund_request.request")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("payment_id", req.PaymentID),
+		attribute.String("refund_reason", req.Reason),
+	)
+
+	payment, err := s.paymentService.GetPayment(ctx, req.PaymentID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, nil, err
+	}
+	if !payment.IsSuccessful() && !payment.IsAuthorized() {
+		span.SetStatus(codes.Error, "invalid status")
+		return nil, nil, fmt.Errorf("%w: cannot refund %s payment", domain.ErrInvalidPaymentStatus, payment.Status)
+	}
+
+	policy, err := s.GetPolicy(ctx, payment.TenantID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, nil, err
+	}
+
+	// Voiding an authorization returns nothing the customer was charged
+	needsApproval := !payment.IsAuthorized() && policy.NeedsApproval(payment.RefundableAmount())
+	request := domain.NewRefundRequest(payment, req.Reason, req.RequestedBy, needsApproval)
+	if err := s.requestRepo.Create(ctx, request); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, nil, err
+	}
+
+	span.SetAttributes(
+		attribute.String("refund_request_id", request.ID),
+		attribute.Float64("amount", request.Amount),
+		attribute.Float64("threshold", policy.Threshold),
+		attribute.Bool("needs_approval", needsApproval),
+	)
+
+	if needsApproval {
+		s.notify(ctx, dto.RefundEventRequested, request, payment)
+		span.SetStatus(codes.Ok, "")
+		return request, payment, nil
+	}
+
+	refunded, err := s.execute(ctx, request, payment)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return request, nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return request, refunded, nil
+}
+
+// ApproveRefund approves a pending refund and sends it to the gateway
+func (s *refundRequestServiceImpl) ApproveRefund(ctx context.Context, requestID, decidedBy, reason string) (*domain.RefundRequest, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.refund_request.approve")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("refund_request_id", requestID),
+		attribute.String("decided_by", decidedBy),
+	)
+
+	request, err := s.requestRepo.GetByID(ctx, requestID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if err := request.Approve(decidedBy, reason); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if err := s.requestRepo.Update(ctx, request, domain.RefundRequestStatusPendingApproval); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	payment, err := s.paymentService.GetPayment(ctx, request.PaymentID)
+	if err != nil {
+		// Approved requests can't be decided again; fail it so the payment can be requested anew
+		s.fail(ctx, request, nil, err)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return request, err
+	}
+	s.notify(ctx, dto.RefundEventApproved, request, payment)
+
+	if _, err := s.execute(ctx, request, payment); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return request, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return request, nil
+}
+
+// RejectRefund rejects a pending refund
+func (s *refundRequestServiceImpl) RejectRefund(ctx context.Context, requestID, decidedBy, reason string) (*domain.RefundRequest, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.refund_request.reject")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("refund_request_id", requestID),
+		attribute.String("decided_by", decidedBy),
+	)
+
+	request, err := s.requestRepo.GetByID(ctx, requestID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if err := request.Reject(decidedBy, reason); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if err := s.requestRepo.Update(ctx, request, domain.RefundRequestStatusPendingApproval); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	// The notification still goes out without the payment; it only adds the user
+	payment, _ := s.paymentService.GetPayment(ctx, request.PaymentID)
+	s.notify(ctx, dto.RefundEventRejected, request, payment)
+
+	span.SetStatus(codes.Ok, "")
+	return request, nil
+}
+
+// GetRefundRequest retrieves a refund request
+func (s *refundRequestServiceImpl) GetRefundRequest(ctx context.Context, requestID string) (*domain.RefundRequest, error) {
+	return s.requestRepo.GetByID(ctx, requestID)
+}
+
+// ListRefundRequests returns the refund requests selected by filter, newest first
+func (s *refundRequestServiceImpl) ListRefundRequests(ctx context.Context, filter *domain.RefundRequestFilter) ([]*domain.RefundRequest, error) {
+	return s.requestRepo.List(ctx, filter)
+}
+
+// GetPolicy returns a tenant's refund approval policy, or the default threshold
+func (s *refundRequestServiceImpl) GetPolicy(ctx context.Context, tenantID string) (*domain.RefundApprovalPolicy, error) {
+	policy, err := s.requestRepo.GetPolicy(ctx, tenantID)
+	if errors.Is(err, domain.ErrRefundApprovalPolicyNotFound) {
+		return &domain.RefundApprovalPolicy{TenantID: tenantID, Threshold: s.defaultThreshold}, nil
+	}
+	return policy, err
+}
+
+// SetPolicy creates or replaces a tenant's refund approval threshold
+func (s *refundRequestServiceImpl) SetPolicy(ctx context.Context, tenantID string, threshold float64, updatedBy string) (*domain.RefundApprovalPolicy, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.refund_request.set_policy")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("tenant_id", tenantID),
+		attribute.Float64("threshold", threshold),
+	)
+
+	if threshold < 0 {
+		span.SetStatus(codes.Error, "invalid threshold")
+		return nil, domain.ErrInvalidRefundApprovalPolicy
+	}
+
+	now := time.Now().UTC()
+	policy := &domain.RefundApprovalPolicy{
+		TenantID:  tenantID,
+		Threshold: threshold,
+		UpdatedBy: updatedBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.requestRepo.SavePolicy(ctx, policy); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return policy, nil
+}
+
+// execute sends an approved refund to the gateway and records how it went
+func (s *refundRequestServiceImpl) execute(ctx context.Context, request *domain.RefundRequest, payment *domain.Payment) (*domain.Payment, error) {
+	refunded, err := s.paymentService.RefundPayment(ctx, request.PaymentID, request.Reason)
+	if err != nil {
+		s.fail(ctx, request, payment, err)
+		return nil, err
+	}
+
+	if err := request.Complete(); err != nil {
+		return nil, err
+	}
+	if err := s.requestRepo.Update(ctx, request, domain.RefundRequestStatusApproved); err != nil {
+		// The money is already back with the customer; only the request is stale
+		logger.Get().Error(fmt.Sprintf("Refund request %s of payment %s refunded but not marked completed: %v", request.ID, request.PaymentID, err))
+	}
+	s.notify(ctx, dto.RefundEventRefunded, request, refunded)
+	return refunded, nil
+}
+
+// fail marks an approved refund as failed so the payment can be requested again
+func (s *refundRequestServiceImpl) fail(ctx context.Context, request *domain.RefundRequest, payment *domain.Payment, cause error) {
+	if err := request.Fail(cause.Error()); err != nil {
+		return
+	}
+	if err := s.requestRepo.Update(ctx, request, domain.RefundRequestStatusApproved); err != nil {
+		logger.Get().Error(fmt.Sprintf("Failed to mark refund request %s failed: %v", request.ID, err))
+	}
+	s.notify(ctx, dto.RefundEventFailed, request, payment)
+}
+
+// notify announces a step of a refund request. Notifications are best effort; the
+// request is already saved.
+func (s *refundRequestServiceImpl) notify(ctx context.Context, eventType dto.RefundEventType, request *domain.RefundRequest, payment *domain.Payment) {
+	if s.notifier == nil {
+		return
+	}
+	if err := s.notifier.NotifyRefund(ctx, eventType, request, payment); err != nil {
+		logger.Get().Error(fmt.Sprintf("Failed to publish %s of refund request %s: %v", eventType, request.ID, err))
+	}
+}
+
+// RefundNotifier announces the steps of refund requests to the rest of the platform
+type RefundNotifier interface {
+	// NotifyRefund publishes a refund request event. payment may be nil when it could
+	// not be read.
+	NotifyRefund(ctx context.Context, eventType dto.RefundEventType, request *domain.RefundRequest, payment *domain.Payment) error
+}
+
+// KafkaRefundNotifier implements RefundNotifier by publishing to payment-events
+type KafkaRefundNotifier struct {
+	producer *kafka.Producer
+}
+
+// NewKafkaRefundNotifier creates a new KafkaRefundNotifier
+func NewKafkaRefundNotifier(producer *kafka.Producer) *KafkaRefundNotifier {
+	return &KafkaRefundNotifier{producer: producer}
+}
+
+// NotifyRefund publishes a refund request event to payment-events
+func (n *KafkaRefundNotifier) NotifyRefund(ctx context.Context, eventType dto.RefundEventType, request *domain.RefundRequest, payment *domain.Payment) error {
+	event, err := NewRefundRequestEvent(eventType, request, payment)
+	if err != nil {
+		return err
+	}
+	headers := map[string]string{
+		"event_type": string(event.EventType),
+		"source":     "payment-service",
+	}
+	return n.producer.ProduceJSON(ctx, event.Topic(), event.Key(), event, headers)
+}
+
+// NewRefundRequestEvent builds the event of a step of a refund request
+func NewRefundRequestEvent(eventType dto.RefundEventType, request *domain.RefundRequest, payment *domain.Payment) (*dto.RefundRequestEvent, error) {
+	data := &dto.RefundRequestEventData{
+		RefundRequestID: request.ID,
+		PaymentID:       request.PaymentID,
+		BookingID:       request.BookingID,
+		TenantID:        request.TenantID,
+		Amount:          request.Amount,
+		Currency:        request.Currency,
+		RefundStatus:    string(request.Status),
+		Reason:          request.Reason,
+		RequestedBy:     request.RequestedBy,
+		DecidedBy:       request.DecidedBy,
+		DecisionReason:  request.DecisionReason,
+		ErrorMessage:    request.FailureReason,
+		ProcessedAt:     request.UpdatedAt,
+	}
+	if payment != nil {
+		data.UserID = payment.UserID
+		data.Status = string(payment.Status)
+	}
+	return events.NewRefundRequestEvent(eventType, uuid.New().String(), data)
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/repository"
+)
+
+// recordingRefundNotifier records the refund events it is asked to publish
+type recordingRefundNotifier struct {
+	events []dto.RefundEventType
+}
+
+func (n *recordingRefundNotifier) NotifyRefund(ctx context.Context, eventType dto.RefundEventType, request *domain.RefundRequest, payment *domain.Payment) error {
+	n.events = append(n.events, eventType)
+	return nil
+}
+
+func TestRefundRequestService_ApprovalWorkflow(t *testing.T) {
+	paymentService, _, payment := processTestPayment(t, CaptureModeAuto)
+	ctx := context.Background()
+	notifier := &recordingRefundNotifier{}
+	svc := NewRefundRequestService(repository.NewMemoryRefundRequestRepository(), paymentService, &RefundRequestServiceConfig{
+		DefaultThreshold: 1000,
+		Notifier:         notifier,
+	})
+
+	// 1500 is above the default threshold
+	request, _, err := svc.RequestRefund(ctx, &RequestRefundRequest{PaymentID: payment.ID, Reason: "customer_request", RequestedBy: "user-1"})
+	if err != nil {
+		t.Fatalf("RequestRefund() error = %v", err)
+	}
+	if request.Status != domain.RefundRequestStatusPendingApproval || request.Amount != 1500 {
+		t.Fatalf("RequestRefund() = %+v, want 1500 pending approval", request)
+	}
+	if got, _ := paymentService.GetPayment(ctx, payment.ID); got.Status != domain.PaymentStatusSucceeded {
+		t.Errorf("payment status = %s before approval, want succeeded", got.Status)
+	}
+	if _, _, err := svc.RequestRefund(ctx, &RequestRefundRequest{PaymentID: payment.ID}); !errors.Is(err, domain.ErrRefundRequestExists) {
+		t.Errorf("second RequestRefund() error = %v, want ErrRefundRequestExists", err)
+	}
+
+	if _, err := svc.RejectRefund(ctx, request.ID, "admin-1", " "); !errors.Is(err, domain.ErrRefundRejectReasonRequired) {
+		t.Errorf("RejectRefund() without reason error = %v, want ErrRefundRejectReasonRequired", err)
+	}
+
+	approved, err := svc.ApproveRefund(ctx, request.ID, "admin-1", "verified with the organizer")
+	if err != nil {
+		t.Fatalf("ApproveRefund() error = %v", err)
+	}
+	if approved.Status != domain.RefundRequestStatusCompleted || approved.DecidedBy != "admin-1" || approved.CompletedAt == nil {
+		t.Errorf("ApproveRefund() = %+v, want completed by admin-1", approved)
+	}
+	if got, _ := paymentService.GetPayment(ctx, payment.ID); got.Status != domain.PaymentStatusRefunded {
+		t.Errorf("payment status = %s after approval, want refunded", got.Status)
+	}
+	if _, err := svc.ApproveRefund(ctx, request.ID, "admin-2", ""); !errors.Is(err, domain.ErrRefundRequestNotPending) {
+		t.Errorf("second ApproveRefund() error = %v, want ErrRefundRequestNotPending", err)
+	}
+
+	want := []dto.RefundEventType{dto.RefundEventRequested, dto.RefundEventApproved, dto.RefundEventRefunded}
+	if len(notifier.events) != len(want) {
+		t.Fatalf("notified %v, want %v", notifier.events, want)
+	}
+	for i := range want {
+		if notifier.events[i] != want[i] {
+			t.Errorf("event %d = %s, want %s", i, notifier.events[i], want[i])
+		}
+	}
+}
+
+func TestRefundRequestService_TenantPolicy(t *testing.T) {
+	paymentService, _, payment := processTestPayment(t, CaptureModeAuto)
+	ctx := context.Background()
+	repo := repository.NewMemoryRefundRequestRepository()
+	svc := NewRefundRequestService(repo, paymentService, &RefundRequestServiceConfig{DefaultThreshold: 1000})
+
+	// The tenant's own threshold wins over the default
+	if _, err := svc.SetPolicy(ctx, payment.TenantID, 2000, "admin-1"); err != nil {
+		t.Fatalf("SetPolicy() error = %v", err)
+	}
+	if _, err := svc.SetPolicy(ctx, payment.TenantID, -1, "admin-1"); !errors.Is(err, domain.ErrInvalidRefundApprovalPolicy) {
+		t.Errorf("SetPolicy() negative error = %v, want ErrInvalidRefundApprovalPolicy", err)
+	}
+
+	request, refunded, err := svc.RequestRefund(ctx, &RequestRefundRequest{PaymentID: payment.ID, Reason: "customer_request"})
+	if err != nil {
+		t.Fatalf("RequestRefund() error = %v", err)
+	}
+	if request.Status != domain.RefundRequestStatusCompleted || request.DecidedBy != domain.RefundAutoApprover {
+		t.Errorf("RequestRefund() = %+v, want completed by the system", request)
+	}
+	if refunded.Status != domain.PaymentStatusRefunded {
+		t.Errorf("payment status = %s, want refunded", refunded.Status)
+	}
+
+	policy, err := svc.GetPolicy(ctx, "tenant-2")
+	if err != nil || policy.Threshold != 1000 {
+		t.Errorf("GetPolicy() of tenant without policy = %+v, %v, want default threshold", policy, err)
+	}
+}
+
+func TestRefundRequestService_Reject(t *testing.T) {
+	paymentService, _, payment := processTestPayment(t, CaptureModeAuto)
+	ctx := context.Background()
+	svc := NewRefundRequestService(repository.NewMemoryRefundRequestRepository(), paymentService, &RefundRequestServiceConfig{DefaultThreshold: 100})
+
+	request, _, err := svc.RequestRefund(ctx, &RequestRefundRequest{PaymentID: payment.ID, Reason: "customer_request"})
+	if err != nil {
+		t.Fatalf("RequestRefund() error = %v", err)
+	}
+	rejected, err := svc.RejectRefund(ctx, request.ID, "admin-1", "outside the refund window")
+	if err != nil {
+		t.Fatalf("RejectRefund() error = %v", err)
+	}
+	if rejected.Status != domain.RefundRequestStatusRejected || rejected.DecisionReason != "outside the refund window" {
+		t.Errorf("RejectRefund() = %+v, want rejected with reason", rejected)
+	}
+	if got, _ := paymentService.GetPayment(ctx, payment.ID); got.Status != domain.PaymentStatusSucceeded {
+		t.Errorf("payment status = %s after rejection, want succeeded", got.Status)
+	}
+
+	// A rejected request no longer blocks a new one
+	if _, _, err := svc.RequestRefund(ctx, &RequestRefundRequest{PaymentID: payment.ID}); err != nil {
+		t.Errorf("RequestRefund() after rejection error = %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// UserDataService exports and anonymizes payment data for a user (GDPR requests from auth-service)
+type UserDataService interface {
+	// ExportUserData returns every payment made by the user
+	ExportUserData(ctx context.Context, userID string) ([]*domain.Payment, error)
+
+	// AnonymizeUserData re-assigns the user's payments to anonymizedID.
+	// Safe to call repeatedly: a second call finds no payments left for userID.
+	AnonymizeUserData(ctx context.Context, userID, anonymizedID string) (int64, error)
+}
+
+// userDataServiceImpl implements UserDataService
+type userDataServiceImpl struct {
+	repo repository.UserDataRepository
+}
+
+// NewUserDataService creates a new UserDataService
+func NewUserDataService(repo repository.UserDataRepository) UserDataService {
+	return &userDataServiceImpl{repo: repo}
+}
+
+// ExportUserData returns every payment made by the user
+func (s *userDataServiceImpl) ExportUserData(ctx context.Context, userID string) ([]*domain.Payment, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.user_data.export")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", userID))
+
+	if userID == "" {
+		span.SetStatus(codes.Error, "invalid user id")
+		return nil, domain.ErrInvalidUserID
+	}
+
+	payments, err := s.repo.ListByUserID(ctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("count", len(payments)))
+	span.SetStatus(codes.Ok, "")
+	return payments, nil
+}
+
+// AnonymizeUserData re-assigns the user's payments to anonymizedID
+func (s *userDataServiceImpl) AnonymizeUserData(ctx context.Context, userID, anonymizedID string) (int64, error) {
+	ctx, span := telemetry.StartSpan(ctx, "service.user_data.anonymize")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user_id", userID))
+
+	if userID == "" || anonymizedID == "" || userID == anonymizedID {
+		span.SetStatus(codes.Error, "invalid user id")
+		return 0, domain.ErrInvalidUserID
+	}
+
+	count, err := s.repo.AnonymizeByUserID(ctx, userID, anonymizedID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return 0, err
+	}
+
+	span.SetAttributes(attribute.Int64("anonymized", count))
+	span.SetStatus(codes.Ok, "")
+	return count, nil
+}
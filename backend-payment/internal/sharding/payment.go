@@ -0,0 +1,224 @@
+// Package sharding spreads payments over per-tenant Postgres shards. New payments are
+// written to their tenant's shard. Lookups start with the shard of the tenant in the
+// context (database.WithTenant) and fall through to the others, so webhooks and workers
+// need no tenant. Listings fan out to every shard and merge.
+package sharding
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
+)
+
+// ShardPaymentRepository is the payment repository of one shard
+type ShardPaymentRepository interface {
+	repository.PaymentRepository
+	repository.UserDataRepository
+	repository.PaymentSearchRepository
+	repository.StuckPaymentRepository
+}
+
+// PaymentRepository routes payment reads and writes to the shards of a resolver
+type PaymentRepository struct {
+	resolver *database.ShardResolver
+	repos    map[string]ShardPaymentRepository
+}
+
+// NewPaymentRepository creates a PaymentRepository with a repository per shard of resolver
+func NewPaymentRepository(resolver *database.ShardResolver, open func(db *database.PostgresDB) ShardPaymentRepository) *PaymentRepository {
+	repos := make(map[string]ShardPaymentRepository)
+	for _, name := range resolver.Shards() {
+		db, _ := resolver.Shard(name)
+		repos[name] = open(db)
+	}
+	return &PaymentRepository{resolver: resolver, repos: repos}
+}
+
+// NewPostgresPaymentRepository creates a PaymentRepository over Postgres payment repositories
+func NewPostgresPaymentRepository(resolver *database.ShardResolver) *PaymentRepository {
+	return NewPaymentRepository(resolver, func(db *database.PostgresDB) ShardPaymentRepository {
+		return repository.NewPostgresPaymentRepository(db)
+	})
+}
+
+// find returns the payment of the first shard in lookup order that has it
+func (r *PaymentRepository) find(ctx context.Context, get func(repo ShardPaymentRepository) (*domain.Payment, error)) (*domain.Payment, error) {
+	for _, name := range r.resolver.LookupOrder(ctx) {
+		payment, err := get(r.repos[name])
+		if !errors.Is(err, domain.ErrPaymentNotFound) {
+			return payment, err
+		}
+	}
+	return nil, domain.ErrPaymentNotFound
+}
+
+// collect concatenates the payments listed by every shard
+func (r *PaymentRepository) collect(list func(repo ShardPaymentRepository) ([]*domain.Payment, error)) ([]*domain.Payment, error) {
+	all := []*domain.Payment{}
+	for _, name := range r.resolver.Shards() {
+		payments, err := list(r.repos[name])
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, payments...)
+	}
+	return all, nil
+}
+
+// newestFirst orders payments by creation, newest first, and keeps at most limit
+func newestFirst(payments []*domain.Payment, limit int) []*domain.Payment {
+	if len(payments) == 0 {
+		return payments
+	}
+	return pagination.Merge([][]*domain.Payment{payments}, pagination.Params{Limit: limit}, func(p *domain.Payment) pagination.Cursor {
+		return pagination.Cursor{Time: p.CreatedAt, ID: p.ID}
+	})
+}
+
+// oldestFirst sorts payments merged from shards by last update, oldest first, and
+// trims them to limit
+func oldestFirst(payments []*domain.Payment, limit int) []*domain.Payment {
+	sort.SliceStable(payments, func(i, j int) bool { return payments[i].UpdatedAt.Before(payments[j].UpdatedAt) })
+	if len(payments) > limit {
+		payments = payments[:limit]
+	}
+	return payments
+}
+
+// Create creates a payment on its tenant's shard
+func (r *PaymentRepository) Create(ctx context.Context, payment *domain.Payment) error {
+	return r.repos[r.resolver.ShardOf(payment.TenantID)].Create(ctx, payment)
+}
+
+// GetByID retrieves a payment from whichever shard has it
+func (r *PaymentRepository) GetByID(ctx context.Context, id string) (*domain.Payment, error) {
+	return r.find(ctx, func(repo ShardPaymentRepository) (*domain.Payment, error) {
+		return repo.GetByID(ctx, id)
+	})
+}
+
+// GetByBookingID retrieves the payment of a booking from whichever shard has it
+func (r *PaymentRepository) GetByBookingID(ctx context.Context, bookingID string) (*domain.Payment, error) {
+	return r.find(ctx, func(repo ShardPaymentRepository) (*domain.Payment, error) {
+		return repo.GetByBookingID(ctx, bookingID)
+	})
+}
+
+// GetByUserID retrieves a page of a user's payments across shards, newest first
+func (r *PaymentRepository) GetByUserID(ctx context.Context, userID string, page pagination.Params) ([]*domain.Payment, error) {
+	if len(r.repos) == 1 {
+		return r.repos[database.DefaultShard].GetByUserID(ctx, userID, page)
+	}
+
+	pages := make([][]*domain.Payment, 0, len(r.repos))
+	for _, name := range r.resolver.Shards() {
+		payments, err := r.repos[name].GetByUserID(ctx, userID, page.Spread())
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, payments)
+	}
+	return pagination.Merge(pages, page, func(p *domain.Payment) pagination.Cursor {
+		return pagination.Cursor{Time: p.CreatedAt, ID: p.ID}
+	}), nil
+}
+
+// Update updates a payment on its tenant's shard
+func (r *PaymentRepository) Update(ctx context.Context, payment *domain.Payment) error {
+	return r.repos[r.resolver.ShardOf(payment.TenantID)].Update(ctx, payment)
+}
+
+// GetByGatewayPaymentID retrieves a payment by gateway payment ID from whichever shard has it
+func (r *PaymentRepository) GetByGatewayPaymentID(ctx context.Context, gatewayPaymentID string) (*domain.Payment, error) {
+	return r.find(ctx, func(repo ShardPaymentRepository) (*domain.Payment, error) {
+		return repo.GetByGatewayPaymentID(ctx, gatewayPaymentID)
+	})
+}
+
+// GetByIdempotencyKey retrieves a payment by idempotency key from whichever shard has it
+func (r *PaymentRepository) GetByIdempotencyKey(ctx context.Context, idempotencyKey string) (*domain.Payment, error) {
+	return r.find(ctx, func(repo ShardPaymentRepository) (*domain.Payment, error) {
+		return repo.GetByIdempotencyKey(ctx, idempotencyKey)
+	})
+}
+
+// ListByUserID returns every payment made by a user across shards, newest first
+func (r *PaymentRepository) ListByUserID(ctx context.Context, userID string) ([]*domain.Payment, error) {
+	payments, err := r.collect(func(repo ShardPaymentRepository) ([]*domain.Payment, error) {
+		return repo.ListByUserID(ctx, userID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newestFirst(payments, len(payments)), nil
+}
+
+// AnonymizeByUserID anonymizes a user's payments on every shard
+func (r *PaymentRepository) AnonymizeByUserID(ctx context.Context, userID, anonymizedID string) (int64, error) {
+	var total int64
+	for _, name := range r.resolver.Shards() {
+		count, err := r.repos[name].AnonymizeByUserID(ctx, userID, anonymizedID)
+		if err != nil {
+			return total, err
+		}
+		total += count
+	}
+	return total, nil
+}
+
+// ListByBookingIDs returns the payments made for any of the given bookings across shards
+func (r *PaymentRepository) ListByBookingIDs(ctx context.Context, bookingIDs []string) ([]*domain.Payment, error) {
+	payments, err := r.collect(func(repo ShardPaymentRepository) ([]*domain.Payment, error) {
+		return repo.ListByBookingIDs(ctx, bookingIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newestFirst(payments, len(payments)), nil
+}
+
+// ListByCardLastFour returns the most recent payments made with a card ending in lastFour.
+// A tenant's search reads its shard only; an empty tenantID searches every shard.
+func (r *PaymentRepository) ListByCardLastFour(ctx context.Context, tenantID, lastFour string, limit int) ([]*domain.Payment, error) {
+	if tenantID != "" {
+		return r.repos[r.resolver.ShardOf(tenantID)].ListByCardLastFour(ctx, tenantID, lastFour, limit)
+	}
+
+	payments, err := r.collect(func(repo ShardPaymentRepository) ([]*domain.Payment, error) {
+		return repo.ListByCardLastFour(ctx, tenantID, lastFour, limit)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newestFirst(payments, limit), nil
+}
+
+// ListStuckProcessing returns payments processing since before cutoff across shards,
+// oldest first
+func (r *PaymentRepository) ListStuckProcessing(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Payment, error) {
+	payments, err := r.collect(func(repo ShardPaymentRepository) ([]*domain.Payment, error) {
+		return repo.ListStuckProcessing(ctx, cutoff, limit)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return oldestFirst(payments, limit), nil
+}
+
+// ListRequiringAction returns payments requiring action since before cutoff across
+// shards, oldest first
+func (r *PaymentRepository) ListRequiringAction(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Payment, error) {
+	payments, err := r.collect(func(repo ShardPaymentRepository) ([]*domain.Payment, error) {
+		return repo.ListRequiringAction(ctx, cutoff, limit)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return oldestFirst(payments, limit), nil
+}
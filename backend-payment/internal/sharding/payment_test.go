@@ -0,0 +1,119 @@
+package sharding
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
+)
+
+// shardStore is an in-memory database.ShardStore
+type shardStore struct {
+	assignments []database.TenantShard
+}
+
+func (s *shardStore) ListTenantShards(ctx context.Context) ([]database.TenantShard, error) {
+	return s.assignments, nil
+}
+
+func (s *shardStore) SaveTenantShard(ctx context.Context, assignment database.TenantShard) error {
+	s.assignments = append(s.assignments, assignment)
+	return nil
+}
+
+// newTestRepository returns a PaymentRepository over a default shard and an eu shard
+// holding the payments of tenant-eu
+func newTestRepository(t *testing.T) (*PaymentRepository, *repository.MemoryPaymentRepository, *repository.MemoryPaymentRepository) {
+	t.Helper()
+
+	defaultDB, euDB := &database.PostgresDB{}, &database.PostgresDB{}
+	resolver := database.NewShardResolver(defaultDB, &shardStore{assignments: []database.TenantShard{
+		{TenantID: "tenant-eu", Shard: "eu"},
+	}})
+	resolver.AddShard("eu", euDB)
+	if err := resolver.Load(context.Background()); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	defaultRepo, euRepo := repository.NewMemoryPaymentRepository(), repository.NewMemoryPaymentRepository()
+	repo := NewPaymentRepository(resolver, func(db *database.PostgresDB) ShardPaymentRepository {
+		if db == euDB {
+			return euRepo
+		}
+		return defaultRepo
+	})
+	return repo, defaultRepo, euRepo
+}
+
+func TestPaymentRepository_RoutesByTenant(t *testing.T) {
+	ctx := context.Background()
+	repo, defaultRepo, euRepo := newTestRepository(t)
+
+	for _, payment := range []*domain.Payment{
+		{ID: "p-eu", BookingID: "b-eu", TenantID: "tenant-eu", GatewayPaymentID: "pi_eu"},
+		{ID: "p-other", BookingID: "b-other", TenantID: "tenant-other"},
+	} {
+		if err := repo.Create(ctx, payment); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	if euRepo.Count() != 1 || defaultRepo.Count() != 1 {
+		t.Fatalf("shard counts = default %d, eu %d, want 1 each", defaultRepo.Count(), euRepo.Count())
+	}
+
+	// Webhooks carry no tenant, the lookup falls through to the eu shard
+	payment, err := repo.GetByGatewayPaymentID(ctx, "pi_eu")
+	if err != nil || payment.ID != "p-eu" {
+		t.Fatalf("GetByGatewayPaymentID() = %v, %v", payment, err)
+	}
+	payment.Status = domain.PaymentStatusSucceeded
+	if err := repo.Update(ctx, payment); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if stored, _ := euRepo.GetByID(ctx, "p-eu"); stored.Status != domain.PaymentStatusSucceeded {
+		t.Error("Update() did not reach the eu shard")
+	}
+
+	if _, err := repo.GetByBookingID(ctx, "missing"); !errors.Is(err, domain.ErrPaymentNotFound) {
+		t.Errorf("GetByBookingID() of a missing booking error = %v, want ErrPaymentNotFound", err)
+	}
+}
+
+func TestPaymentRepository_GetByUserID(t *testing.T) {
+	ctx := context.Background()
+	repo, _, _ := newTestRepository(t)
+
+	now := time.Now()
+	for i, tenantID := range []string{"tenant-other", "tenant-eu", "tenant-other", "tenant-eu"} {
+		n := strconv.Itoa(i + 1)
+		payment := &domain.Payment{
+			ID:        "p" + n,
+			BookingID: "b" + n,
+			UserID:    "user-1",
+			TenantID:  tenantID,
+			CreatedAt: now.Add(-time.Duration(i) * time.Minute),
+		}
+		if err := repo.Create(ctx, payment); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	got, err := repo.GetByUserID(ctx, "user-1", pagination.Params{Limit: 2, Offset: 1})
+	if err != nil {
+		t.Fatalf("GetByUserID() error = %v", err)
+	}
+	if len(got) != 2 || got[0].ID != "p2" || got[1].ID != "p3" {
+		t.Errorf("GetByUserID() = %v, want p2, p3", got)
+	}
+
+	anonymized, err := repo.AnonymizeByUserID(ctx, "user-1", "anon-1")
+	if err != nil || anonymized != 4 {
+		t.Errorf("AnonymizeByUserID() = %d, %v, want 4", anonymized, err)
+	}
+}
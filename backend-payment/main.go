@@ -2,31 +2,55 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
 	"runtime"
-	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/client"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/di"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/dto"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/gateway"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/handler"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/repository"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/sharding"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/config"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/id"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/middleware"
 	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/storage"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
 )
 
+// filesPath is where signed downloads of stored files are served; the gateway proxies it
+const filesPath = "/api/v1/files/payment"
+
+// defaultRequestBodyLimits are the per-route body limits used when REQUEST_BODY_ROUTE_LIMITS is not set.
+// The Stripe webhook keeps the default limit since Stripe event payloads can be large.
+const defaultRequestBodyLimits = "POST /api/v1/payments=16KB,POST /api/v1/payments/:id/process=16KB," +
+	"POST /api/v1/payments/intent=16KB,POST /api/v1/payments/intent/confirm=16KB"
+
+// topicSpecs are the Kafka topics the payment service publishes to
+var topicSpecs = []kafka.TopicSpec{
+	{Name: dto.TopicPaymentSuccess, Retention: 7 * 24 * time.Hour},
+	{Name: dto.TopicSeatRelease, Retention: 7 * 24 * time.Hour},
+}
+
 func main() {
+	smokeTest := flag.Bool("smoke", false, "check dependencies end to end and exit non-zero on failure")
+	flag.Parse()
+
 	// Optimize Go runtime for high concurrency
 	runtime.GOMAXPROCS(runtime.NumCPU())
 
@@ -38,7 +62,8 @@ func main() {
 
 	// Initialize logger with OTLP export support
 	logCfg := &logger.Config{
-		Level:        cfg.App.Environment,
+		Level:        cfg.Log.Level,
+		ModuleLevels: cfg.Log.ModuleLevels,
 		ServiceName:  "payment-service",
 		Development:  cfg.IsDevelopment(),
 		OTLPEnabled:  cfg.OTel.Enabled && cfg.OTel.LogExportEnabled,
@@ -53,16 +78,32 @@ func main() {
 	appLog := logger.Get()
 	appLog.Info("Starting Payment Service...")
 
+	required := []config.Requirement{config.RequirePaymentDatabase, config.RequireRedis, config.RequireKafka}
+	if err := cfg.ValidateFor(required...); err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
+	appLog.Info("Effective configuration: " + cfg.Summary(required...))
+
+	// Time-ordered primary keys for hot insert paths (ID_STRATEGY, ID_NODE_ID)
+	idGenerator, err := id.FromEnv()
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
+	id.SetDefault(idGenerator)
+
 	ctx := context.Background()
 
 	// Initialize OpenTelemetry
 	telemetryCfg := &telemetry.Config{
-		Enabled:        cfg.OTel.Enabled,
-		ServiceName:    "payment-service",
-		ServiceVersion: cfg.App.Version,
-		Environment:    cfg.App.Environment,
-		CollectorAddr:  cfg.OTel.CollectorAddr,
-		SampleRatio:    cfg.OTel.SampleRatio,
+		Enabled:         cfg.OTel.Enabled,
+		ServiceName:     "payment-service",
+		ServiceVersion:  cfg.App.Version,
+		Environment:     cfg.App.Environment,
+		CollectorAddr:   cfg.OTel.CollectorAddr,
+		SampleRatio:     cfg.OTel.SampleRatio,
+		DynamicSampling: cfg.OTel.DynamicSampling,
+		SlowThreshold:   cfg.OTel.SlowThreshold,
+		SamplingRoutes:  cfg.OTel.SamplingRoutes,
 	}
 	if _, err := telemetry.Init(ctx, telemetryCfg); err != nil {
 		appLog.Warn(fmt.Sprintf("Failed to initialize telemetry: %v", err))
@@ -75,20 +116,21 @@ func main() {
 	// Uses PaymentDatabase config (Microservice - each service has its own database)
 	var db *database.PostgresDB
 	dbCfg := &database.PostgresConfig{
-		Host:            cfg.PaymentDatabase.Host,
-		Port:            cfg.PaymentDatabase.Port,
-		User:            cfg.PaymentDatabase.User,
-		Password:        cfg.PaymentDatabase.Password,
-		Database:        cfg.PaymentDatabase.DBName,
-		SSLMode:         cfg.PaymentDatabase.SSLMode,
-		MaxConns:        10, // Optimized: async processing via Kafka
-		MinConns:        2,
-		MaxConnLifetime: 30 * time.Minute,
-		MaxConnIdleTime: 5 * time.Minute,
-		ConnectTimeout:  5 * time.Second,
-		MaxRetries:      3,
-		RetryInterval:   1 * time.Second,
-		EnableTracing:   cfg.OTel.Enabled,
+		Host:               cfg.PaymentDatabase.Host,
+		Port:               cfg.PaymentDatabase.Port,
+		User:               cfg.PaymentDatabase.User,
+		Password:           cfg.PaymentDatabase.Password,
+		Database:           cfg.PaymentDatabase.DBName,
+		SSLMode:            cfg.PaymentDatabase.SSLMode,
+		MaxConns:           10, // Optimized: async processing via Kafka
+		MinConns:           2,
+		MaxConnLifetime:    30 * time.Minute,
+		MaxConnIdleTime:    5 * time.Minute,
+		ConnectTimeout:     5 * time.Second,
+		MaxRetries:         3,
+		RetryInterval:      1 * time.Second,
+		EnableTracing:      cfg.OTel.Enabled,
+		SlowQueryThreshold: cfg.PaymentDatabase.SlowQueryThreshold,
 	}
 	db, err = database.NewPostgres(ctx, dbCfg)
 	if err != nil {
@@ -98,23 +140,37 @@ func main() {
 		appLog.Info(fmt.Sprintf("Database connected (pool: min=%d, max=%d)", dbCfg.MinConns, dbCfg.MaxConns))
 	}
 
+	// Tenants assigned to a shard keep their payments in that shard's database
+	var tenantShards *database.ShardResolver
+	if db != nil {
+		tenantShards, err = database.OpenShardResolver(ctx, db, dbCfg, cfg.PaymentDatabase.Shards, cfg.PaymentDatabase.ShardRefreshInterval)
+		if err != nil {
+			appLog.Fatal(fmt.Sprintf("Tenant shards unavailable: %v", err))
+		}
+		if tenantShards != nil {
+			defer tenantShards.Close()
+			appLog.Info(fmt.Sprintf("Tenant shards enabled: %s", strings.Join(tenantShards.Shards(), ", ")))
+		}
+	}
+
 	// Initialize Redis connection
 	var redisClient *pkgredis.Client
 	redisCfg := &pkgredis.Config{
-		Host:          cfg.Redis.Host,
-		Port:          cfg.Redis.Port,
-		Password:      cfg.Redis.Password,
-		DB:            cfg.Redis.DB,
-		PoolSize:      100,
-		MinIdleConns:  20,
-		MaxRetries:    3,
-		RetryInterval: 100 * time.Millisecond,
-		DialTimeout:   5 * time.Second,
-		ReadTimeout:   3 * time.Second,
-		WriteTimeout:  3 * time.Second,
-		PoolTimeout:   4 * time.Second,
-		EnableTracing: cfg.OTel.Enabled,
-		ServiceName:   "payment-service",
+		Host:                 cfg.Redis.Host,
+		Port:                 cfg.Redis.Port,
+		Password:             cfg.Redis.Password,
+		DB:                   cfg.Redis.DB,
+		PoolSize:             100,
+		MinIdleConns:         20,
+		MaxRetries:           3,
+		RetryInterval:        100 * time.Millisecond,
+		DialTimeout:          5 * time.Second,
+		ReadTimeout:          3 * time.Second,
+		WriteTimeout:         3 * time.Second,
+		PoolTimeout:          4 * time.Second,
+		EnableTracing:        cfg.OTel.Enabled,
+		SlowCommandThreshold: cfg.Redis.SlowCommandThreshold,
+		ServiceName:          "payment-service",
 	}
 	redisClient, err = pkgredis.NewClient(ctx, redisCfg)
 	if err != nil {
@@ -122,10 +178,14 @@ func main() {
 	} else {
 		defer redisClient.Close()
 		appLog.Info(fmt.Sprintf("Redis connected (pool: %d, minIdle: %d)", redisCfg.PoolSize, redisCfg.MinIdleConns))
+
+		// Per-module log levels shared by every instance, changeable without a restart
+		go redisClient.WatchLogLevels(ctx, cfg.Log.RedisKey+":payment-service", cfg.Log.PollInterval)
 	}
 
 	// Initialize payment gateway based on feature flag
-	gatewayType := getEnv("PAYMENT_GATEWAY", "mock")
+	env := config.NewEnv()
+	gatewayType := env.String("PAYMENT_GATEWAY", "mock")
 	var paymentGateway gateway.PaymentGateway
 	var gwErr error
 
@@ -137,7 +197,7 @@ func main() {
 		} else {
 			paymentGateway, gwErr = gateway.NewPaymentGateway("stripe", &gateway.GatewayConfig{
 				SecretKey:   stripeSecretKey,
-				Environment: getEnv("STRIPE_ENVIRONMENT", "test"),
+				Environment: env.String("STRIPE_ENVIRONMENT", "test"),
 			})
 			if gwErr != nil {
 				appLog.Warn(fmt.Sprintf("Failed to create Stripe gateway: %v, falling back to mock", gwErr))
@@ -147,24 +207,85 @@ func main() {
 	}
 
 	if gatewayType == "mock" || paymentGateway == nil {
-		successRate := getEnvFloat("MOCK_GATEWAY_SUCCESS_RATE", 0.95)
-		delayMs := getEnvInt("MOCK_GATEWAY_DELAY_MS", 100)
+		successRate := env.Float("MOCK_GATEWAY_SUCCESS_RATE", 0.95)
+		delayMs := env.Int("MOCK_GATEWAY_DELAY_MS", 100)
 		paymentGateway = gateway.NewMockGatewayWithConfig(successRate, delayMs)
 		appLog.Info(fmt.Sprintf("Using mock payment gateway (success_rate=%.2f, delay_ms=%d)", successRate, delayMs))
 	} else {
 		appLog.Info("Using Stripe payment gateway")
 	}
 
+	// Bound gateway latency: every call gets a timeout budget, and idempotent
+	// reads can be hedged with a second request when they are slower than usual
+	hedgeCfg := gateway.DefaultHedgedGatewayConfig()
+	hedgeCfg.HedgeReads = env.Bool("PAYMENT_GATEWAY_HEDGE_ENABLED", false)
+	hedgeCfg.HedgePercentile = env.Float("PAYMENT_GATEWAY_HEDGE_PERCENTILE", hedgeCfg.HedgePercentile)
+	hedgeCfg.HedgeMinDelay = env.Duration("PAYMENT_GATEWAY_HEDGE_MIN_DELAY", hedgeCfg.HedgeMinDelay)
+	hedgeCfg.HedgeMaxDelay = env.Duration("PAYMENT_GATEWAY_HEDGE_MAX_DELAY", hedgeCfg.HedgeMaxDelay)
+	hedgeCfg.ReadBudget = env.Duration("PAYMENT_GATEWAY_READ_TIMEOUT", hedgeCfg.ReadBudget)
+	hedgeCfg.WriteBudget = env.Duration("PAYMENT_GATEWAY_WRITE_TIMEOUT", hedgeCfg.WriteBudget)
+	paymentGateway = gateway.NewHedgedGateway(paymentGateway, hedgeCfg)
+	appLog.Info(fmt.Sprintf("Payment gateway budgets: read=%s write=%s (hedged reads: %t)", hedgeCfg.ReadBudget, hedgeCfg.WriteBudget, hedgeCfg.HedgeReads))
+
 	// Initialize payment repository
 	var paymentRepo repository.PaymentRepository
+	var userDataRepo repository.UserDataRepository
+	var paymentSearchRepo repository.PaymentSearchRepository
+	var invoiceRepo repository.InvoiceRepository
+	var refundReviewRepo repository.RefundReviewRepository
+	var ledgerRepo repository.LedgerRepository
+	var payoutRepo repository.PayoutRepository
+	var refundRequestRepo repository.RefundRequestRepository
+	var methodPolicyRepo repository.PaymentMethodPolicyRepository
 	if db != nil {
-		paymentRepo = repository.NewPostgresPaymentRepository(db)
+		postgresRepo := repository.NewPostgresPaymentRepository(db)
+		paymentRepo, userDataRepo, paymentSearchRepo = postgresRepo, postgresRepo, postgresRepo
+		if tenantShards != nil {
+			shardedRepo := sharding.NewPostgresPaymentRepository(tenantShards)
+			paymentRepo, userDataRepo, paymentSearchRepo = shardedRepo, shardedRepo, shardedRepo
+		}
+		invoiceRepo = repository.NewPostgresInvoiceRepository(db)
+		refundReviewRepo = repository.NewPostgresRefundReviewRepository(db)
+		ledgerRepo = repository.NewPostgresLedgerRepository(db)
+		payoutRepo = repository.NewPostgresPayoutRepository(db)
+		refundRequestRepo = repository.NewPostgresRefundRequestRepository(db)
+		methodPolicyRepo = repository.NewPostgresPaymentMethodPolicyRepository(db)
 		appLog.Info("Using PostgreSQL payment repository")
 	} else {
-		paymentRepo = repository.NewMemoryPaymentRepository()
+		memoryRepo := repository.NewMemoryPaymentRepository()
+		paymentRepo, userDataRepo, paymentSearchRepo = memoryRepo, memoryRepo, memoryRepo
+		invoiceRepo = repository.NewMemoryInvoiceRepository()
+		refundReviewRepo = repository.NewMemoryRefundReviewRepository()
+		ledgerRepo = repository.NewMemoryLedgerRepository()
+		payoutRepo = repository.NewMemoryPayoutRepository()
+		refundRequestRepo = repository.NewMemoryRefundRequestRepository()
+		methodPolicyRepo = repository.NewMemoryPaymentMethodPolicyRepository()
 		appLog.Warn("Using in-memory payment repository (data will not persist)")
 	}
 
+	// Deploys gate on the self-check of every dependency before taking traffic
+	if *smokeTest {
+		suite := newSmokeSuite(&smokeDeps{
+			DB:    db,
+			Redis: redisClient,
+			Kafka: kafka.TopicManagerConfig{
+				Brokers:  cfg.Kafka.Brokers,
+				ClientID: "payment-service-smoke",
+			},
+			Payments:      paymentRepo,
+			Invoices:      invoiceRepo,
+			RefundReviews: refundReviewRepo,
+			Ledger:        ledgerRepo,
+			Payouts:       payoutRepo,
+			Refunds:       refundRequestRepo,
+			Policies:      methodPolicyRepo,
+		})
+		if err := suite.Run(ctx, os.Stdout); err != nil {
+			appLog.Fatal(fmt.Sprintf("Smoke test failed: %v", err))
+		}
+		return
+	}
+
 	// Get Stripe webhook secret
 	stripeWebhookSecret := os.Getenv("STRIPE_WEBHOOK_SECRET")
 	if stripeWebhookSecret != "" {
@@ -177,6 +298,23 @@ func main() {
 		authServiceURL = "http://localhost:8081"
 	}
 
+	// Validate payment amounts against booking totals from booking-service
+	bookingServiceURL := env.String("BOOKING_SERVICE_URL", "http://localhost:8083")
+	bookingClient := client.NewHTTPBookingClient(bookingServiceURL)
+	appLog.Info(fmt.Sprintf("Payment amounts validated against booking totals (booking service: %s)", bookingServiceURL))
+
+	// Check the Kafka topics this service owns (missing topics are created when
+	// KAFKA_AUTO_CREATE_TOPICS is set, drift from the specs is only reported)
+	if err := kafka.ProvisionTopics(ctx, &kafka.TopicManagerConfig{
+		Brokers:           cfg.Kafka.Brokers,
+		ClientID:          "payment-service-admin",
+		Partitions:        int32(cfg.Kafka.TopicPartitions),
+		ReplicationFactor: int16(cfg.Kafka.TopicReplicationFactor),
+		AutoCreate:        cfg.Kafka.AutoCreateTopics,
+	}, topicSpecs); err != nil {
+		appLog.Warn(fmt.Sprintf("Kafka topic check failed: %v", err))
+	}
+
 	// Initialize Kafka producer for event publishing
 	var kafkaProducer *kafka.Producer
 	kafkaProducerCfg := &kafka.ProducerConfig{
@@ -191,11 +329,41 @@ func main() {
 		appLog.Info(fmt.Sprintf("Kafka producer connected (brokers: %v)", cfg.Kafka.Brokers))
 	}
 
+	// Stored e-Tax exports and receipts, available only when file storage is configured
+	storageCfg := storageConfig(env)
+	lifecycleInterval := env.Duration("STORAGE_LIFECYCLE_INTERVAL", time.Hour)
+	if err := env.Err(); err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
+	fileStore, err := storage.Open(storageCfg)
+	if err != nil {
+		appLog.Fatal(fmt.Sprintf("File storage unavailable: %v", err))
+	}
+	lifecycleCtx, stopLifecycle := context.WithCancel(ctx)
+	defer stopLifecycle()
+	if fileStore != nil {
+		appLog.Info(fmt.Sprintf("File storage enabled (backend: %s)", storageCfg.Backend))
+		go storage.RunLifecycle(lifecycleCtx, fileStore, []storage.LifecycleRule{
+			{Prefix: service.InvoiceExportPrefix, MaxAge: 7 * 24 * time.Hour},
+			{Prefix: service.ReceiptPrefix, MaxAge: 24 * time.Hour},
+			{Prefix: service.PayoutFilePrefix, MaxAge: 90 * 24 * time.Hour},
+		}, lifecycleInterval)
+	}
+
 	// Build dependency injection container
 	container := di.NewContainer(&di.ContainerConfig{
 		DB:                  db,
 		Redis:               redisClient,
 		PaymentRepo:         paymentRepo,
+		UserDataRepo:        userDataRepo,
+		PaymentSearchRepo:   paymentSearchRepo,
+		InvoiceRepo:         invoiceRepo,
+		RefundReviewRepo:    refundReviewRepo,
+		LedgerRepo:          ledgerRepo,
+		PayoutRepo:          payoutRepo,
+		RefundRequestRepo:   refundRequestRepo,
+		MethodPolicyRepo:    methodPolicyRepo,
+		TenantShards:        tenantShards,
 		PaymentGateway:      paymentGateway,
 		KafkaProducer:       kafkaProducer,
 		StripeWebhookSecret: stripeWebhookSecret,
@@ -203,8 +371,26 @@ func main() {
 		ServiceConfig: &service.PaymentServiceConfig{
 			Currency:        "THB",
 			GatewayType:     gatewayType,
-			MockSuccessRate: getEnvFloat("MOCK_GATEWAY_SUCCESS_RATE", 0.95),
-			MockDelayMs:     getEnvInt("MOCK_GATEWAY_DELAY_MS", 100),
+			CaptureMode:     service.CaptureMode(env.String("PAYMENT_CAPTURE_MODE", string(service.CaptureModeAuto))),
+			MockSuccessRate: env.Float("MOCK_GATEWAY_SUCCESS_RATE", 0.95),
+			MockDelayMs:     env.Int("MOCK_GATEWAY_DELAY_MS", 100),
+			BookingClient:   bookingClient,
+		},
+		// Seller details printed on tax invoices and in the e-Tax export
+		InvoiceConfig: &service.InvoiceServiceConfig{
+			SellerName:    env.String("INVOICE_SELLER_NAME", ""),
+			SellerTaxID:   env.String("INVOICE_SELLER_TAX_ID", ""),
+			SellerBranch:  env.String("INVOICE_SELLER_BRANCH", "00000"),
+			SellerAddress: env.String("INVOICE_SELLER_ADDRESS", ""),
+			Files:         fileStore,
+		},
+		// Tenants may only choose bank_file payouts when file storage is configured
+		PayoutConfig: &service.PayoutServiceConfig{
+			Files: fileStore,
+		},
+		// Refunds above the threshold wait for approval unless the tenant has its own
+		RefundApproval: &service.RefundRequestServiceConfig{
+			DefaultThreshold: env.Float("REFUND_APPROVAL_THRESHOLD", 0),
 		},
 	})
 
@@ -224,11 +410,39 @@ func main() {
 		router.Use(telemetry.TraceHeaderMiddleware())
 	}
 
+	// Reject oversized, non-JSON and abusive JSON bodies before any handler binds them
+	if cfg.RequestBody.Enabled {
+		spec := cfg.RequestBody.RouteLimits
+		if spec == "" {
+			spec = defaultRequestBodyLimits
+		}
+		routeLimits, err := middleware.ParseRouteBodyLimits(spec)
+		if err != nil {
+			appLog.Fatal(fmt.Sprintf("Invalid REQUEST_BODY_ROUTE_LIMITS: %v", err))
+		}
+		router.Use(middleware.RequestBodyMiddleware(&middleware.RequestBodyConfig{
+			MaxBytes:      cfg.RequestBody.MaxBytes,
+			RouteMaxBytes: routeLimits,
+			MaxJSONDepth:  cfg.RequestBody.MaxJSONDepth,
+		}))
+	}
+
 	// Health check endpoints
 	router.GET("/health", container.HealthHandler.Health)
 	router.GET("/ready", container.HealthHandler.Ready)
 
 	// API routes
+	// Signed downloads of local or encrypted files (authorized by the URL signature)
+	if fileStore != nil {
+		files, err := storage.NewServingHandler(fileStore, storageCfg)
+		if err != nil {
+			appLog.Fatal(fmt.Sprintf("File storage unavailable: %v", err))
+		}
+		if files != nil {
+			router.GET(filesPath+"/*key", gin.WrapH(http.StripPrefix(filesPath, files)))
+		}
+	}
+
 	v1 := router.Group("/api/v1")
 	{
 		// Status endpoint
@@ -243,6 +457,12 @@ func main() {
 		// Payment routes
 		if container.PaymentHandler != nil {
 			payments := v1.Group("/payments")
+			// Verify identity headers signed by the API Gateway
+			payments.Use(middleware.InternalAuthMiddleware(&middleware.InternalAuthConfig{
+				Secret:        cfg.InternalAuth.Secret,
+				MaxClockSkew:  cfg.InternalAuth.MaxClockSkew,
+				AllowUnsigned: cfg.InternalAuth.AllowUnsigned,
+			}))
 
 			// Configure idempotency middleware for write operations
 			var idempotencyConfig *middleware.IdempotencyConfig
@@ -265,12 +485,22 @@ func main() {
 					payments.POST("/:id/cancel", container.PaymentHandler.CancelPayment)
 				}
 
+				// Safe to repeat: the customer may come back from the gateway more than once
+				payments.POST("/:id/return", container.PaymentHandler.ReturnPayment)
+
 				// Read operations without idempotency
 				payments.GET("/:id", container.PaymentHandler.GetPayment)
 				payments.GET("/booking/:bookingId", container.PaymentHandler.GetPaymentByBookingID)
 				payments.GET("/user/:userId", container.PaymentHandler.GetUserPayments)
 				payments.GET("/methods", container.PaymentHandler.ListPaymentMethods)
 
+				// Full tax invoices
+				if container.InvoiceHandler != nil {
+					payments.POST("/:id/invoice", container.InvoiceHandler.IssueInvoice)
+					payments.GET("/:id/invoice", container.InvoiceHandler.GetPaymentInvoice)
+					payments.GET("/:id/invoice/receipt", container.InvoiceHandler.DownloadReceipt)
+				}
+
 				// Stripe PaymentIntent endpoints
 				if idempotencyConfig != nil {
 					payments.POST("/intent", middleware.IdempotencyMiddleware(idempotencyConfig), container.PaymentHandler.CreatePaymentIntent)
@@ -292,8 +522,125 @@ func main() {
 		}
 	}
 
+	// Internal routes for service-to-service calls (not exposed via API gateway)
+	// Used by auth-service for GDPR data export and account deletion
+	if container.UserDataHandler != nil {
+		internal := router.Group("/internal/users")
+		{
+			internal.GET("/:user_id/data", container.UserDataHandler.ExportUserData)
+			internal.POST("/:user_id/anonymize", container.UserDataHandler.AnonymizeUserData)
+		}
+	}
+
+	// Used by booking-service for the support booking search
+	if container.SearchHandler != nil {
+		router.GET("/internal/payments", container.SearchHandler.SearchPayments)
+	}
+
+	// Used by finance back office tooling for the monthly e-Tax filing
+	if container.InvoiceHandler != nil {
+		invoices := router.Group("/internal/invoices")
+		{
+			invoices.GET("", container.InvoiceHandler.ListInvoices)
+			invoices.GET("/export", container.InvoiceHandler.ExportInvoices)
+			invoices.POST("/exports", container.InvoiceHandler.StoreExport)
+			invoices.GET("/:id", container.InvoiceHandler.GetInvoice)
+		}
+	}
+
+	// Used by finance back office tooling to work through gateway refunds that
+	// could not be reconciled with a payment
+	if container.RefundHandler != nil {
+		reviews := router.Group("/internal/refund-reviews")
+		{
+			reviews.GET("", container.RefundHandler.ListReviews)
+			reviews.POST("/:id/resolve", container.RefundHandler.ResolveReview)
+		}
+	}
+
+	// Used by back office tooling to approve or reject refunds above the tenant's
+	// threshold and to set the thresholds. Callers are verified admins, limited to
+	// their own tenant unless super_admin.
+	if container.RefundRequestHandler != nil {
+		approvers := []gin.HandlerFunc{
+			middleware.InternalAuthMiddleware(&middleware.InternalAuthConfig{
+				Secret:        cfg.InternalAuth.Secret,
+				MaxClockSkew:  cfg.InternalAuth.MaxClockSkew,
+				AllowUnsigned: cfg.InternalAuth.AllowUnsigned,
+			}),
+			middleware.RequireRole(handler.RefundApproverRoles...),
+		}
+		refundRequests := router.Group("/internal/refund-requests", approvers...)
+		{
+			refundRequests.GET("", container.RefundRequestHandler.ListRequests)
+			refundRequests.GET("/:id", container.RefundRequestHandler.GetRequest)
+			refundRequests.POST("/:id/approve", container.RefundRequestHandler.ApproveRequest)
+			refundRequests.POST("/:id/reject", container.RefundRequestHandler.RejectRequest)
+		}
+		policies := router.Group("/internal/refund-approval-policies", approvers...)
+		{
+			policies.GET("/:tenant_id", container.RefundRequestHandler.GetPolicy)
+			policies.PUT("/:tenant_id", container.RefundRequestHandler.SetPolicy)
+		}
+	}
+
+	// Used by back office tooling to restrict the payment methods accepted per tenant
+	// and event
+	if container.MethodPolicyHandler != nil {
+		methodPolicies := router.Group("/internal/payment-method-policies")
+		{
+			methodPolicies.GET("/:tenant_id", container.MethodPolicyHandler.ListPolicies)
+			methodPolicies.PUT("/:tenant_id", container.MethodPolicyHandler.SetPolicy)
+			methodPolicies.DELETE("/:tenant_id", container.MethodPolicyHandler.DeletePolicy)
+			methodPolicies.GET("/:tenant_id/events/:event_id", container.MethodPolicyHandler.GetEventPolicy)
+			methodPolicies.PUT("/:tenant_id/events/:event_id", container.MethodPolicyHandler.SetPolicy)
+			methodPolicies.DELETE("/:tenant_id/events/:event_id", container.MethodPolicyHandler.DeletePolicy)
+		}
+	}
+
+	// Used by finance back office tooling to post gateway fees and payouts and to
+	// reconcile tenant balances against gateway settlement files
+	if container.LedgerHandler != nil {
+		ledger := router.Group("/internal/ledger")
+		{
+			ledger.GET("/balances", container.LedgerHandler.GetBalances)
+			ledger.GET("/entries", container.LedgerHandler.ListEntries)
+			ledger.POST("/transactions", container.LedgerHandler.PostTransaction)
+			ledger.GET("/transactions/:id", container.LedgerHandler.GetTransaction)
+		}
+	}
+
+	// Used by back office tooling to set up tenant payouts and show payout history
+	if container.PayoutHandler != nil {
+		schedules := router.Group("/internal/payout-schedules")
+		{
+			schedules.GET("/:tenant_id", container.PayoutHandler.GetSchedule)
+			schedules.PUT("/:tenant_id", container.PayoutHandler.SetSchedule)
+		}
+		payouts := router.Group("/internal/payouts")
+		{
+			payouts.GET("", container.PayoutHandler.ListPayouts)
+			payouts.GET("/:id", container.PayoutHandler.GetPayout)
+		}
+	}
+
+	// Used by back office tooling to place tenants on database shards
+	if container.TenantShardHandler != nil {
+		shards := router.Group("/internal/tenant-shards")
+		{
+			shards.GET("", container.TenantShardHandler.List)
+			shards.PUT("/:tenant_id", container.TenantShardHandler.Assign)
+		}
+	}
+
+	// Used by booking-service to settle the price difference of a modified booking
+	router.POST("/internal/payments/:id/adjust", container.PaymentHandler.AdjustPayment)
+
 	// Create HTTP server
-	port := getEnvInt("PORT", 8084)
+	port := env.Int("PORT", 8084)
+	if err := env.Err(); err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
 	addr := fmt.Sprintf("%s:%d", cfg.Server.Host, port)
 	srv := &http.Server{
 		Addr:              addr,
@@ -330,30 +677,21 @@ func main() {
 	appLog.Info("Server exited gracefully")
 }
 
-// getEnv returns environment variable or default value
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
-}
-
-// getEnvInt returns environment variable as int or default value
-func getEnvInt(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
-		if result, err := strconv.Atoi(value); err == nil {
-			return result
-		}
-	}
-	return defaultValue
-}
-
-// getEnvFloat returns environment variable as float64 or default value
-func getEnvFloat(key string, defaultValue float64) float64 {
-	if value := os.Getenv(key); value != "" {
-		if result, err := strconv.ParseFloat(value, 64); err == nil {
-			return result
-		}
+// storageConfig reads the object storage configuration shared by the services
+func storageConfig(env *config.Env) *storage.Config {
+	return &storage.Config{
+		Backend:  env.String("STORAGE_BACKEND", storage.BackendNone),
+		LocalDir: env.String("STORAGE_LOCAL_DIR", "./data/files"),
+		S3: storage.S3Config{
+			Endpoint:  env.String("STORAGE_S3_ENDPOINT", ""),
+			Region:    env.String("STORAGE_S3_REGION", "ap-southeast-1"),
+			Bucket:    env.String("STORAGE_S3_BUCKET", ""),
+			AccessKey: env.String("STORAGE_S3_ACCESS_KEY", ""),
+			SecretKey: env.String("STORAGE_S3_SECRET_KEY", ""),
+			PathStyle: env.Bool("STORAGE_S3_PATH_STYLE", false),
+		},
+		PublicURL:      env.String("STORAGE_PUBLIC_URL", "http://localhost:8080") + filesPath,
+		SigningKey:     env.String("STORAGE_SIGNING_KEY", ""),
+		EncryptionKeys: env.String("STORAGE_ENCRYPTION_KEYS", ""),
 	}
-	return defaultValue
 }
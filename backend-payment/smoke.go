@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-payment/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/smoke"
+)
+
+// schemaVersion is the latest migration in scripts/migrations/payment. -smoke fails
+// until the payment database is migrated to it.
+const schemaVersion = 12
+
+// smokeDeps are the dependencies checked by -smoke
+type smokeDeps struct {
+	DB            *database.PostgresDB // Nil when the service fell back to memory
+	Redis         *pkgredis.Client     // Nil when Redis is unavailable
+	Kafka         kafka.TopicManagerConfig
+	Payments      repository.PaymentRepository
+	Invoices      repository.InvoiceRepository
+	RefundReviews repository.RefundReviewRepository
+	Ledger        repository.LedgerRepository
+	Payouts       repository.PayoutRepository
+	Refunds       repository.RefundRequestRepository
+	Policies      repository.PaymentMethodPolicyRepository
+}
+
+// newSmokeSuite builds the self-check run by -smoke. The in-memory fallbacks the
+// service starts with when PostgreSQL or Redis is down fail the check.
+func newSmokeSuite(d *smokeDeps) *smoke.Suite {
+	suite := smoke.NewSuite("payment-service", 0)
+
+	if d.DB == nil {
+		suite.Add("postgres", func(ctx context.Context) error {
+			return errors.New("not connected, payments would only be kept in memory")
+		})
+	} else {
+		suite.Add("postgres", smoke.Postgres(d.DB))
+		suite.Add("migrations", smoke.Migrations(d.DB, schemaVersion))
+	}
+	if d.Redis == nil {
+		suite.Add("redis", func(ctx context.Context) error {
+			return errors.New("not connected, idempotency keys would not be checked")
+		})
+	} else {
+		suite.Add("redis", smoke.Redis(d.Redis))
+	}
+	suite.Add("kafka topics", smoke.KafkaTopics(d.Kafka, topicSpecs))
+
+	// One read per repository; the probe ID matches nothing
+	suite.Add("payments repository", func(ctx context.Context) error {
+		_, err := d.Payments.GetByID(ctx, smoke.ProbeID)
+		return smoke.Expect(err, domain.ErrPaymentNotFound)
+	})
+	suite.Add("invoices repository", func(ctx context.Context) error {
+		_, err := d.Invoices.GetByID(ctx, smoke.ProbeID)
+		return smoke.Expect(err, domain.ErrInvoiceNotFound)
+	})
+	suite.Add("refund reviews repository", func(ctx context.Context) error {
+		_, err := d.RefundReviews.GetByID(ctx, smoke.ProbeID)
+		return smoke.Expect(err, domain.ErrRefundReviewNotFound)
+	})
+	suite.Add("ledger repository", func(ctx context.Context) error {
+		_, err := d.Ledger.GetByID(ctx, smoke.ProbeID)
+		return smoke.Expect(err, domain.ErrLedgerTransactionNotFound)
+	})
+	suite.Add("payouts repository", func(ctx context.Context) error {
+		_, err := d.Payouts.GetByID(ctx, smoke.ProbeID)
+		return smoke.Expect(err, domain.ErrPayoutNotFound)
+	})
+	suite.Add("refund requests repository", func(ctx context.Context) error {
+		_, err := d.Refunds.GetByID(ctx, smoke.ProbeID)
+		return smoke.Expect(err, domain.ErrRefundRequestNotFound)
+	})
+	suite.Add("payment method policies repository", func(ctx context.Context) error {
+		_, err := d.Policies.Get(ctx, smoke.ProbeID, "")
+		return smoke.Expect(err, domain.ErrPaymentMethodPolicyNotFound)
+	})
+	return suite
+}
@@ -15,34 +15,57 @@ type Container struct {
 	Redis *redis.Client
 
 	// Repositories
-	EventRepo    repository.EventRepository
-	VenueRepo    repository.VenueRepository
-	ShowRepo     repository.ShowRepository
-	ShowZoneRepo repository.ShowZoneRepository
+	EventRepo     repository.EventRepository
+	VenueRepo     repository.VenueRepository
+	ZoneRepo      repository.ZoneRepository
+	LayoutRepo    repository.VenueLayoutRepository
+	ShowRepo      repository.ShowRepository
+	ShowZoneRepo  repository.ShowZoneRepository
+	TeamRepo      repository.TeamMemberRepository
+	PresaleRepo   repository.PresaleRepository
+	TemplateRepo  repository.EventTemplateRepository
+	PriceTierRepo repository.ZonePriceTierRepository
+	AddOnRepo     repository.EventAddOnRepository
+	// RedemptionRepo is nil when Redis is unavailable
+	RedemptionRepo repository.PresaleRedemptionRepository
 	// SeatRepo       repository.SeatRepository
 	// TicketTypeRepo repository.TicketTypeRepository
 
 	// Services
-	ZoneSyncer      service.ZoneSyncer
-	EventService    service.EventService
-	ShowService     service.ShowService
-	ShowZoneService service.ShowZoneService
+	ZoneSyncer       service.ZoneSyncer
+	EventService     service.EventService
+	ShowService      service.ShowService
+	ShowZoneService  service.ShowZoneService
+	CacheWarmer      service.CacheWarmer
+	AccessService    service.EventAccessService
+	PresaleService   service.PresaleService
+	TemplateService  service.EventTemplateService
+	VenueService     service.VenueService
+	PriceTierService service.PriceTierService
+	AddOnService     service.AddOnService
 	// TicketService service.TicketService
-	// VenueService  service.VenueService
 
 	// Handlers
-	HealthHandler   *handler.HealthHandler
-	EventHandler    *handler.EventHandler
-	ShowHandler     *handler.ShowHandler
-	ShowZoneHandler *handler.ShowZoneHandler
+	HealthHandler      *handler.HealthHandler
+	EventHandler       *handler.EventHandler
+	ShowHandler        *handler.ShowHandler
+	ShowZoneHandler    *handler.ShowZoneHandler
+	CacheWarmupHandler *handler.CacheWarmupHandler
+	TeamHandler        *handler.TeamHandler
+	PresaleHandler     *handler.PresaleHandler
+	TemplateHandler    *handler.EventTemplateHandler
+	VenueHandler       *handler.VenueHandler
+	PriceTierHandler   *handler.PriceTierHandler
+	AddOnHandler       *handler.AddOnHandler
 	// TicketHandler *handler.TicketHandler
-	// VenueHandler  *handler.VenueHandler
 }
 
 // ContainerConfig contains configuration for building the container
 type ContainerConfig struct {
-	DB    *database.PostgresDB
-	Redis *redis.Client
+	DB                *database.PostgresDB
+	Redis             *redis.Client
+	CapacityPublisher service.CapacityEventPublisher
+	CacheWarmer       *service.CacheWarmerConfig
 }
 
 // NewContainer creates a new dependency injection container
@@ -54,34 +77,58 @@ func NewContainer(cfg *ContainerConfig) *Container {
 
 	// Initialize repositories
 	pgEventRepo := repository.NewPostgresEventRepository(c.DB.Pool())
+	pgShowRepo := repository.NewPostgresShowRepository(c.DB.Pool())
+	pgShowZoneRepo := repository.NewPostgresShowZoneRepository(c.DB.Pool())
 
 	// Wrap with cache if Redis is available
 	if c.Redis != nil {
 		c.EventRepo = repository.NewCachedEventRepository(pgEventRepo, c.Redis)
+		c.ShowRepo = repository.NewCachedShowRepository(pgShowRepo, c.Redis)
+		c.ShowZoneRepo = repository.NewCachedShowZoneRepository(pgShowZoneRepo, c.Redis)
+		c.RedemptionRepo = repository.NewRedisPresaleRedemptionRepository(c.Redis)
 	} else {
 		c.EventRepo = pgEventRepo
+		c.ShowRepo = pgShowRepo
+		c.ShowZoneRepo = pgShowZoneRepo
 	}
 	c.VenueRepo = repository.NewPostgresVenueRepository(c.DB.Pool())
-	c.ShowRepo = repository.NewPostgresShowRepository(c.DB.Pool())
-	c.ShowZoneRepo = repository.NewPostgresShowZoneRepository(c.DB.Pool())
+	c.ZoneRepo = repository.NewPostgresZoneRepository(c.DB.Pool())
+	c.LayoutRepo = repository.NewPostgresVenueLayoutRepository(c.DB.Pool())
+	c.TeamRepo = repository.NewPostgresTeamMemberRepository(c.DB.Pool())
+	c.PresaleRepo = repository.NewPostgresPresaleRepository(c.DB.Pool())
+	c.TemplateRepo = repository.NewPostgresEventTemplateRepository(c.DB.Pool())
+	c.PriceTierRepo = repository.NewPostgresZonePriceTierRepository(c.DB.Pool())
+	c.AddOnRepo = repository.NewPostgresEventAddOnRepository(c.DB.Pool())
 	// c.SeatRepo = repository.NewPostgresSeatRepository(c.DB.Pool())
 	// c.TicketTypeRepo = repository.NewPostgresTicketTypeRepository(c.DB.Pool())
 
 	// Initialize services
 	c.ZoneSyncer = service.NewZoneSyncer(c.ShowZoneRepo, c.ShowRepo, c.Redis)
-	c.EventService = service.NewEventService(c.EventRepo)
+	c.EventService = service.NewEventService(c.EventRepo, c.ZoneSyncer)
 	c.ShowService = service.NewShowService(c.ShowRepo, c.EventRepo, c.ZoneSyncer)
-	c.ShowZoneService = service.NewShowZoneService(c.ShowZoneRepo, c.ShowRepo, c.ZoneSyncer)
+	c.ShowZoneService = service.NewShowZoneService(c.ShowZoneRepo, c.ShowRepo, c.ZoneSyncer, cfg.CapacityPublisher)
+	c.AccessService = service.NewEventAccessService(c.EventRepo, c.TeamRepo)
+	c.PresaleService = service.NewPresaleService(c.PresaleRepo, c.RedemptionRepo, c.AccessService)
+	c.TemplateService = service.NewEventTemplateService(c.TemplateRepo, c.EventRepo, c.ShowRepo, c.ShowZoneRepo, c.AccessService, c.ZoneSyncer, cfg.CapacityPublisher)
+	c.CacheWarmer = service.NewCacheWarmer(c.EventRepo, c.ShowRepo, c.ShowZoneRepo, c.ZoneSyncer, cfg.CacheWarmer)
+	c.VenueService = service.NewVenueService(c.VenueRepo, c.ZoneRepo, c.LayoutRepo)
+	c.PriceTierService = service.NewPriceTierService(c.PriceTierRepo, c.ShowZoneRepo, c.ShowRepo, cfg.CapacityPublisher)
+	c.AddOnService = service.NewAddOnService(c.AddOnRepo, c.EventRepo, c.AccessService)
 	// c.TicketService = service.NewTicketService(c.TicketTypeRepo, c.EventRepo)
-	// c.VenueService = service.NewVenueService(c.VenueRepo, c.ZoneRepo, c.SeatRepo)
 
 	// Initialize handlers
 	c.HealthHandler = handler.NewHealthHandler(c.DB)
-	c.EventHandler = handler.NewEventHandler(c.EventService, c.ShowService)
-	c.ShowHandler = handler.NewShowHandler(c.ShowService, c.EventService)
-	c.ShowZoneHandler = handler.NewShowZoneHandler(c.ShowZoneService, c.ShowService)
+	c.EventHandler = handler.NewEventHandler(c.EventService, c.ShowService, c.AccessService)
+	c.ShowHandler = handler.NewShowHandler(c.ShowService, c.EventService, c.AccessService)
+	c.ShowZoneHandler = handler.NewShowZoneHandler(c.ShowZoneService, c.ShowService, c.AccessService)
+	c.CacheWarmupHandler = handler.NewCacheWarmupHandler(c.CacheWarmer)
+	c.TeamHandler = handler.NewTeamHandler(c.AccessService)
+	c.PresaleHandler = handler.NewPresaleHandler(c.PresaleService)
+	c.TemplateHandler = handler.NewEventTemplateHandler(c.TemplateService)
+	c.VenueHandler = handler.NewVenueHandler(c.VenueService)
+	c.PriceTierHandler = handler.NewPriceTierHandler(c.PriceTierService, c.ShowZoneService, c.ShowService, c.AccessService)
+	c.AddOnHandler = handler.NewAddOnHandler(c.AddOnService)
 	// c.TicketHandler = handler.NewTicketHandler(c.TicketService)
-	// c.VenueHandler = handler.NewVenueHandler(c.VenueService)
 
 	return c
 }
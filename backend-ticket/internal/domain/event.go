@@ -4,37 +4,67 @@ import "time"
 
 // Event represents an event in the system
 type Event struct {
-	ID                string     `json:"id"`
-	TenantID          string     `json:"tenant_id"`
-	OrganizerID       string     `json:"organizer_id"`
-	CategoryID        *string    `json:"category_id,omitempty"`
-	Name              string     `json:"name"`
-	Slug              string     `json:"slug"`
-	Description       string     `json:"description"`
-	ShortDescription  string     `json:"short_description"`
-	PosterURL         string     `json:"poster_url"`
-	BannerURL         string     `json:"banner_url"`
-	Gallery           []string   `json:"gallery"`
-	VenueName         string     `json:"venue_name"`
-	VenueAddress      string     `json:"venue_address"`
-	City              string     `json:"city"`
-	Country           string     `json:"country"`
-	Latitude          *float64   `json:"latitude,omitempty"`
-	Longitude         *float64   `json:"longitude,omitempty"`
-	MaxTicketsPerUser int        `json:"max_tickets_per_user"`
-	BookingStartAt    *time.Time `json:"booking_start_at,omitempty"`
-	BookingEndAt      *time.Time `json:"booking_end_at,omitempty"`
-	Status            string     `json:"status"` // draft, published, cancelled, completed
-	IsFeatured        bool       `json:"is_featured"`
-	IsPublic          bool       `json:"is_public"`
-	MetaTitle         string     `json:"meta_title"`
-	MetaDescription   string     `json:"meta_description"`
-	Settings          string     `json:"settings"`  // JSON string
-	MinPrice          float64    `json:"min_price"` // Minimum ticket price from all shows
-	PublishedAt       *time.Time `json:"published_at,omitempty"`
-	CreatedAt         time.Time  `json:"created_at"`
-	UpdatedAt         time.Time  `json:"updated_at"`
-	DeletedAt         *time.Time `json:"deleted_at,omitempty"`
+	ID                string   `json:"id"`
+	TenantID          string   `json:"tenant_id"`
+	OrganizerID       string   `json:"organizer_id"`
+	CategoryID        *string  `json:"category_id,omitempty"`
+	Name              string   `json:"name"`
+	Slug              string   `json:"slug"`
+	Description       string   `json:"description"`
+	ShortDescription  string   `json:"short_description"`
+	PosterURL         string   `json:"poster_url"`
+	BannerURL         string   `json:"banner_url"`
+	Gallery           []string `json:"gallery"`
+	VenueName         string   `json:"venue_name"`
+	VenueAddress      string   `json:"venue_address"`
+	City              string   `json:"city"`
+	Country           string   `json:"country"`
+	Latitude          *float64 `json:"latitude,omitempty"`
+	Longitude         *float64 `json:"longitude,omitempty"`
+	MaxTicketsPerUser int      `json:"max_tickets_per_user"`
+	HoldTTLSeconds    *int     `json:"hold_ttl_seconds,omitempty"` // Reservation hold for all zones (nil = service default)
+	// VerificationPolicy is the account verification booking-service requires to reserve and confirm
+	VerificationPolicy string     `json:"verification_policy"`
+	BookingStartAt     *time.Time `json:"booking_start_at,omitempty"`
+	BookingEndAt       *time.Time `json:"booking_end_at,omitempty"`
+	Status             string     `json:"status"` // draft, published, cancelled, completed
+	IsFeatured         bool       `json:"is_featured"`
+	IsPublic           bool       `json:"is_public"`
+	MetaTitle          string     `json:"meta_title"`
+	MetaDescription    string     `json:"meta_description"`
+	Settings           string     `json:"settings"`  // JSON string
+	MinPrice           float64    `json:"min_price"` // Minimum ticket price from all shows
+	PublishedAt        *time.Time `json:"published_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+	DeletedAt          *time.Time `json:"deleted_at,omitempty"`
+}
+
+// Bounds for organizer-configured reservation holds
+const (
+	MinHoldTTLSeconds = 60
+	MaxHoldTTLSeconds = 3600
+)
+
+// ValidHoldTTL reports whether seconds is an allowed reservation hold
+func ValidHoldTTL(seconds int) bool {
+	return seconds >= MinHoldTTLSeconds && seconds <= MaxHoldTTLSeconds
+}
+
+// Verification policies enforced by booking-service against the caller's token claims
+const (
+	VerificationPolicyNone     = "none"     // Any signed-in account
+	VerificationPolicyEmail    = "email"    // Verified email address
+	VerificationPolicyVerified = "verified" // Verified email address and phone number
+)
+
+// ValidVerificationPolicy reports whether policy is a known verification policy
+func ValidVerificationPolicy(policy string) bool {
+	switch policy {
+	case VerificationPolicyNone, VerificationPolicyEmail, VerificationPolicyVerified:
+		return true
+	}
+	return false
 }
 
 // EventStatus constants
@@ -47,13 +77,15 @@ const (
 
 // Venue represents a venue where events are held
 type Venue struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name"`
-	Address   string    `json:"address"`
-	Capacity  int       `json:"capacity"`
-	TenantID  string    `json:"tenant_id"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Address  string `json:"address"`
+	Capacity int    `json:"capacity"`
+	TenantID string `json:"tenant_id"`
+	// ActiveLayoutVersion is the seat-map version served to buyers, 0 if none is active
+	ActiveLayoutVersion int       `json:"active_layout_version"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
 }
 
 // Zone represents a zone/section within a venue
@@ -176,4 +208,11 @@ type ShowZone struct {
 	CreatedAt      time.Time  `json:"created_at"`
 	UpdatedAt      time.Time  `json:"updated_at"`
 	DeletedAt      *time.Time `json:"deleted_at,omitempty"`
+
+	// HoldTTLSeconds is the reservation hold for this zone, overriding the event's (nil = inherit)
+	HoldTTLSeconds *int `json:"hold_ttl_seconds,omitempty"`
+
+	// PendingTotalSeats is set (not persisted) when a capacity change for an on-sale zone
+	// has been handed to the inventory worker and is not yet reflected in TotalSeats
+	PendingTotalSeats *int `json:"pending_total_seats,omitempty"`
 }
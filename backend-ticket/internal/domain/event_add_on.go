@@ -0,0 +1,36 @@
+package domain
+
+import "time"
+
+// Add-on categories
+const (
+	AddOnCategoryParking     = "parking"
+	AddOnCategoryMerchandise = "merchandise"
+	AddOnCategoryOther       = "other"
+)
+
+// IsValidAddOnCategory checks if category is a known add-on category
+func IsValidAddOnCategory(category string) bool {
+	switch category {
+	case AddOnCategoryParking, AddOnCategoryMerchandise, AddOnCategoryOther:
+		return true
+	}
+	return false
+}
+
+// EventAddOn is an item sold with an event's tickets, such as parking or merchandise.
+// Its units are counted by booking-service at reserve time, separately from seats.
+type EventAddOn struct {
+	ID            string    `json:"id"`
+	EventID       string    `json:"event_id"`
+	Name          string    `json:"name"` // e.g. "Parking", "Tour T-Shirt"
+	Description   string    `json:"description"`
+	Category      string    `json:"category"`
+	Price         float64   `json:"price"`
+	Inventory     int       `json:"inventory"`       // Units for sale (0 = no limit)
+	MaxPerBooking int       `json:"max_per_booking"` // Units per booking (0 = no limit)
+	IsActive      bool      `json:"is_active"`       // Inactive add-ons are listed but not sold
+	SortOrder     int       `json:"sort_order"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
@@ -0,0 +1,182 @@
+package domain
+
+import "time"
+
+// EventTemplate is a reusable event setup saved by an organizer of a tenant
+type EventTemplate struct {
+	ID            string          `json:"id"`
+	TenantID      string          `json:"tenant_id"`
+	Name          string          `json:"name"`
+	Description   string          `json:"description"`
+	SourceEventID string          `json:"source_event_id,omitempty"`
+	Blueprint     *EventBlueprint `json:"blueprint"`
+	CreatedBy     string          `json:"created_by,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// EventBlueprint is the copyable part of an event: its settings, shows, zones and pricing.
+// Dates are kept as they were on the source event and shifted when the blueprint is used.
+type EventBlueprint struct {
+	Name               string           `json:"name"`
+	Description        string           `json:"description"`
+	ShortDescription   string           `json:"short_description"`
+	CategoryID         *string          `json:"category_id,omitempty"`
+	PosterURL          string           `json:"poster_url"`
+	BannerURL          string           `json:"banner_url"`
+	Gallery            []string         `json:"gallery"`
+	VenueName          string           `json:"venue_name"`
+	VenueAddress       string           `json:"venue_address"`
+	City               string           `json:"city"`
+	Country            string           `json:"country"`
+	Latitude           *float64         `json:"latitude,omitempty"`
+	Longitude          *float64         `json:"longitude,omitempty"`
+	MaxTicketsPerUser  int              `json:"max_tickets_per_user"`
+	HoldTTLSeconds     *int             `json:"hold_ttl_seconds,omitempty"`
+	VerificationPolicy string           `json:"verification_policy"`
+	BookingStartAt     *time.Time       `json:"booking_start_at,omitempty"`
+	BookingEndAt       *time.Time       `json:"booking_end_at,omitempty"`
+	IsPublic           bool             `json:"is_public"`
+	MetaTitle          string           `json:"meta_title"`
+	MetaDescription    string           `json:"meta_description"`
+	Settings           string           `json:"settings"` // JSON string
+	Shows              []*ShowBlueprint `json:"shows"`
+}
+
+// ShowBlueprint is the copyable part of a show
+type ShowBlueprint struct {
+	Name        string           `json:"name"`
+	ShowDate    time.Time        `json:"show_date"`
+	StartTime   time.Time        `json:"start_time"`
+	EndTime     time.Time        `json:"end_time"`
+	DoorsOpenAt *time.Time       `json:"doors_open_at,omitempty"`
+	SaleStartAt *time.Time       `json:"sale_start_at,omitempty"`
+	SaleEndAt   *time.Time       `json:"sale_end_at,omitempty"`
+	Zones       []*ZoneBlueprint `json:"zones"`
+}
+
+// ZoneBlueprint is the copyable part of a show zone; sales counts are not copied
+type ZoneBlueprint struct {
+	Name           string     `json:"name"`
+	Description    string     `json:"description"`
+	Color          string     `json:"color"`
+	Price          float64    `json:"price"`
+	Currency       string     `json:"currency"`
+	TotalSeats     int        `json:"total_seats"`
+	MinPerOrder    int        `json:"min_per_order"`
+	MaxPerOrder    int        `json:"max_per_order"`
+	IsActive       bool       `json:"is_active"`
+	SortOrder      int        `json:"sort_order"`
+	SaleStartAt    *time.Time `json:"sale_start_at,omitempty"`
+	SaleEndAt      *time.Time `json:"sale_end_at,omitempty"`
+	HoldTTLSeconds *int       `json:"hold_ttl_seconds,omitempty"`
+}
+
+// NewEventBlueprint captures the setup of an event. zones maps show IDs to their zones.
+func NewEventBlueprint(event *Event, shows []*Show, zones map[string][]*ShowZone) *EventBlueprint {
+	b := &EventBlueprint{
+		Name:               event.Name,
+		Description:        event.Description,
+		ShortDescription:   event.ShortDescription,
+		CategoryID:         event.CategoryID,
+		PosterURL:          event.PosterURL,
+		BannerURL:          event.BannerURL,
+		Gallery:            event.Gallery,
+		VenueName:          event.VenueName,
+		VenueAddress:       event.VenueAddress,
+		City:               event.City,
+		Country:            event.Country,
+		Latitude:           event.Latitude,
+		Longitude:          event.Longitude,
+		MaxTicketsPerUser:  event.MaxTicketsPerUser,
+		HoldTTLSeconds:     event.HoldTTLSeconds,
+		VerificationPolicy: event.VerificationPolicy,
+		BookingStartAt:     event.BookingStartAt,
+		BookingEndAt:       event.BookingEndAt,
+		IsPublic:           event.IsPublic,
+		MetaTitle:          event.MetaTitle,
+		MetaDescription:    event.MetaDescription,
+		Settings:           event.Settings,
+		Shows:              make([]*ShowBlueprint, 0, len(shows)),
+	}
+
+	for _, show := range shows {
+		sb := &ShowBlueprint{
+			Name:        show.Name,
+			ShowDate:    show.ShowDate,
+			StartTime:   show.StartTime,
+			EndTime:     show.EndTime,
+			DoorsOpenAt: show.DoorsOpenAt,
+			SaleStartAt: show.SaleStartAt,
+			SaleEndAt:   show.SaleEndAt,
+			Zones:       make([]*ZoneBlueprint, 0, len(zones[show.ID])),
+		}
+		for _, zone := range zones[show.ID] {
+			sb.Zones = append(sb.Zones, &ZoneBlueprint{
+				Name:           zone.Name,
+				Description:    zone.Description,
+				Color:          zone.Color,
+				Price:          zone.Price,
+				Currency:       zone.Currency,
+				TotalSeats:     zone.TotalSeats,
+				MinPerOrder:    zone.MinPerOrder,
+				MaxPerOrder:    zone.MaxPerOrder,
+				IsActive:       zone.IsActive,
+				SortOrder:      zone.SortOrder,
+				SaleStartAt:    zone.SaleStartAt,
+				SaleEndAt:      zone.SaleEndAt,
+				HoldTTLSeconds: zone.HoldTTLSeconds,
+			})
+		}
+		b.Shows = append(b.Shows, sb)
+	}
+	return b
+}
+
+// FirstShowDate returns the date of the earliest show, false if the blueprint has no shows
+func (b *EventBlueprint) FirstShowDate() (time.Time, bool) {
+	var first time.Time
+	for _, show := range b.Shows {
+		if first.IsZero() || show.ShowDate.Before(first) {
+			first = show.ShowDate
+		}
+	}
+	return first, !first.IsZero()
+}
+
+// Shift returns a copy of the blueprint with every date moved by days.
+// Calendar days are added, so local show times stay the same across DST changes.
+func (b *EventBlueprint) Shift(days int) *EventBlueprint {
+	shifted := *b
+	shifted.BookingStartAt = shiftDays(b.BookingStartAt, days)
+	shifted.BookingEndAt = shiftDays(b.BookingEndAt, days)
+	shifted.Shows = make([]*ShowBlueprint, len(b.Shows))
+
+	for i, show := range b.Shows {
+		s := *show
+		s.ShowDate = show.ShowDate.AddDate(0, 0, days)
+		s.StartTime = show.StartTime.AddDate(0, 0, days)
+		s.EndTime = show.EndTime.AddDate(0, 0, days)
+		s.DoorsOpenAt = shiftDays(show.DoorsOpenAt, days)
+		s.SaleStartAt = shiftDays(show.SaleStartAt, days)
+		s.SaleEndAt = shiftDays(show.SaleEndAt, days)
+		s.Zones = make([]*ZoneBlueprint, len(show.Zones))
+		for j, zone := range show.Zones {
+			z := *zone
+			z.SaleStartAt = shiftDays(zone.SaleStartAt, days)
+			z.SaleEndAt = shiftDays(zone.SaleEndAt, days)
+			s.Zones[j] = &z
+		}
+		shifted.Shows[i] = &s
+	}
+	return &shifted
+}
+
+// shiftDays moves an optional time by days
+func shiftDays(t *time.Time, days int) *time.Time {
+	if t == nil {
+		return nil
+	}
+	shifted := t.AddDate(0, 0, days)
+	return &shifted
+}
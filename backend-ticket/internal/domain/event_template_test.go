@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEventBlueprint_Shift(t *testing.T) {
+	saleEnd := time.Date(2026, 3, 13, 18, 0, 0, 0, time.UTC)
+	b := &EventBlueprint{Shows: []*ShowBlueprint{{
+		ShowDate: time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC),
+		Zones:    []*ZoneBlueprint{{Name: "GA", SaleEndAt: &saleEnd}},
+	}}}
+
+	shifted := b.Shift(-3)
+	if got := shifted.Shows[0].ShowDate.Format("2006-01-02"); got != "2026-03-10" {
+		t.Errorf("show date = %s", got)
+	}
+	if got := shifted.Shows[0].Zones[0].SaleEndAt; !got.Equal(saleEnd.AddDate(0, 0, -3)) {
+		t.Errorf("zone sale end = %v", got)
+	}
+	if b.Shows[0].ShowDate.Day() != 13 || !b.Shows[0].Zones[0].SaleEndAt.Equal(saleEnd) {
+		t.Error("Shift modified the original blueprint")
+	}
+}
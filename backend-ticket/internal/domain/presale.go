@@ -0,0 +1,64 @@
+package domain
+
+import "time"
+
+// PresaleBatch is a set of presale access codes generated together for an event.
+// Every code of the batch shares the usage limit and validity window.
+type PresaleBatch struct {
+	ID             string    `json:"id"`
+	EventID        string    `json:"event_id"`
+	TenantID       string    `json:"tenant_id"`
+	Name           string    `json:"name"`
+	MaxUsesPerCode int       `json:"max_uses_per_code"`
+	ValidFrom      time.Time `json:"valid_from"`
+	ValidUntil     time.Time `json:"valid_until"`
+	CreatedBy      string    `json:"created_by"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// PresaleCode is a single presale access code. MaxUses, ValidFrom and ValidUntil
+// come from the code's batch.
+type PresaleCode struct {
+	ID            string    `json:"id"`
+	BatchID       string    `json:"batch_id"`
+	EventID       string    `json:"event_id"`
+	Code          string    `json:"code"`
+	MaxUses       int       `json:"max_uses"`
+	ValidFrom     time.Time `json:"valid_from"`
+	ValidUntil    time.Time `json:"valid_until"`
+	RedeemedCount int       `json:"redeemed_count"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// IsActiveAt checks whether t falls inside the code's validity window
+func (c *PresaleCode) IsActiveAt(t time.Time) bool {
+	return !t.Before(c.ValidFrom) && t.Before(c.ValidUntil)
+}
+
+// PresaleBatchStats summarizes redemptions of a presale batch
+type PresaleBatchStats struct {
+	Batch         *PresaleBatch `json:"batch"`
+	Codes         int           `json:"codes"`
+	CodesRedeemed int           `json:"codes_redeemed"` // Codes used at least once
+	Redemptions   int           `json:"redemptions"`
+}
+
+// Capacity returns the total number of redemptions the batch allows
+func (s *PresaleBatchStats) Capacity() int {
+	return s.Codes * s.Batch.MaxUsesPerCode
+}
+
+// RedemptionRate returns the share of the batch capacity that was redeemed (0-1)
+func (s *PresaleBatchStats) RedemptionRate() float64 {
+	if s.Capacity() == 0 {
+		return 0
+	}
+	return float64(s.Redemptions) / float64(s.Capacity())
+}
+
+// PresaleRedemption is the outcome of redeeming a presale code for a user
+type PresaleRedemption struct {
+	Redeemed        bool  // False if the code has no uses left
+	AlreadyRedeemed bool  // The user had redeemed the code before; no use was consumed
+	Uses            int64 // Uses of the code after this redemption
+}
@@ -0,0 +1,63 @@
+package domain
+
+import "time"
+
+// Permission constants for event management. Event organizers hold all of them on
+// their own events; team members hold the ones granted to them on the tenant's events.
+const (
+	PermissionEventEdit    = "events:edit"
+	PermissionEventDelete  = "events:delete"
+	PermissionEventPublish = "events:publish"
+	PermissionTeamManage   = "team:manage"
+)
+
+// AllPermissions lists every grantable permission
+var AllPermissions = []string{
+	PermissionEventEdit,
+	PermissionEventDelete,
+	PermissionEventPublish,
+	PermissionTeamManage,
+}
+
+// IsValidPermission checks whether p is a known permission
+func IsValidPermission(p string) bool {
+	for _, known := range AllPermissions {
+		if p == known {
+			return true
+		}
+	}
+	return false
+}
+
+// TeamMember is a user who co-manages the events of a tenant
+type TeamMember struct {
+	ID          string    `json:"id"`
+	TenantID    string    `json:"tenant_id"`
+	UserID      string    `json:"user_id"`
+	Permissions []string  `json:"permissions"`
+	AddedBy     string    `json:"added_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// HasPermission checks whether the member was granted p
+func (m *TeamMember) HasPermission(p string) bool {
+	for _, granted := range m.Permissions {
+		if granted == p {
+			return true
+		}
+	}
+	return false
+}
+
+// Actor is the authenticated caller, taken from JWT claims
+type Actor struct {
+	UserID   string
+	TenantID string
+	Role     string
+}
+
+// IsAdmin reports whether the actor bypasses ownership checks
+func (a *Actor) IsAdmin() bool {
+	return a.Role == "admin" || a.Role == "super_admin"
+}
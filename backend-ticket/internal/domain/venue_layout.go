@@ -0,0 +1,288 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Default spacing of seats placed without coordinates, in layout units
+const (
+	LayoutSeatSpacing    = 1.0
+	LayoutRowSpacing     = 1.0
+	LayoutSectionSpacing = 2.0
+)
+
+// VenueLayout is one uploaded version of a venue's seat map
+type VenueLayout struct {
+	ID        string      `json:"id"`
+	VenueID   string      `json:"venue_id"`
+	Version   int         `json:"version"`
+	Layout    *SeatLayout `json:"layout,omitempty"` // Not loaded when listing versions
+	SeatCount int         `json:"seat_count"`
+	Notes     string      `json:"notes,omitempty"`
+	CreatedBy string      `json:"created_by,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// SeatLayout is a seat map: sections of rows of seats, each section selling seats of one zone
+type SeatLayout struct {
+	Sections []*LayoutSection `json:"sections"`
+}
+
+// LayoutSection is a block of rows sold as part of a venue zone
+type LayoutSection struct {
+	ID     string       `json:"id"`
+	Name   string       `json:"name"`
+	ZoneID string       `json:"zone_id"`
+	Rows   []*LayoutRow `json:"rows"`
+}
+
+// LayoutRow is a labelled row of seats
+type LayoutRow struct {
+	Label string        `json:"label"`
+	Seats []*LayoutSeat `json:"seats"`
+}
+
+// LayoutSeat is a seat of a row. Seats without coordinates are placed on a grid when rendered.
+type LayoutSeat struct {
+	Number     string   `json:"number"`
+	X          *float64 `json:"x,omitempty"`
+	Y          *float64 `json:"y,omitempty"`
+	Accessible bool     `json:"accessible,omitempty"`
+}
+
+// SeatCount returns the number of seats in the layout
+func (l *SeatLayout) SeatCount() int {
+	count := 0
+	for _, section := range l.Sections {
+		count += section.SeatCount()
+	}
+	return count
+}
+
+// SeatsByZone returns the number of seats each zone sells
+func (l *SeatLayout) SeatsByZone() map[string]int {
+	seats := make(map[string]int)
+	for _, section := range l.Sections {
+		seats[section.ZoneID] += section.SeatCount()
+	}
+	return seats
+}
+
+// SeatCount returns the number of seats in the section
+func (s *LayoutSection) SeatCount() int {
+	count := 0
+	for _, row := range s.Rows {
+		count += len(row.Seats)
+	}
+	return count
+}
+
+// Validate checks the layout against the zones of its venue and the venue capacity.
+// It returns the problems found keyed by their path in the layout, or nil if there are none.
+func (l *SeatLayout) Validate(zones []*Zone, venueCapacity int) map[string]string {
+	problems := make(map[string]string)
+	if len(l.Sections) == 0 {
+		problems["sections"] = "layout must have at least one section"
+		return problems
+	}
+
+	zonesByID := make(map[string]*Zone, len(zones))
+	for _, zone := range zones {
+		zonesByID[zone.ID] = zone
+	}
+
+	sectionIDs := make(map[string]bool)
+	for i, section := range l.Sections {
+		path := fmt.Sprintf("sections[%d]", i)
+		if section == nil {
+			problems[path] = "section is empty"
+			continue
+		}
+		switch id := strings.TrimSpace(section.ID); {
+		case id == "":
+			problems[path+".id"] = "section id is required"
+		case sectionIDs[id]:
+			problems[path+".id"] = fmt.Sprintf("section id %q is used more than once", id)
+		default:
+			sectionIDs[id] = true
+		}
+		if _, ok := zonesByID[section.ZoneID]; !ok {
+			problems[path+".zone_id"] = fmt.Sprintf("zone %q is not a zone of this venue", section.ZoneID)
+		}
+		if len(section.Rows) == 0 {
+			problems[path+".rows"] = "section must have at least one row"
+		}
+		section.validateRows(path, problems)
+	}
+
+	for zoneID, seats := range l.SeatsByZone() {
+		if zone, ok := zonesByID[zoneID]; ok && seats > zone.Capacity {
+			problems["zones."+zoneID] = fmt.Sprintf("zone %s has %d seats but a capacity of %d", zone.Name, seats, zone.Capacity)
+		}
+	}
+	if seats := l.SeatCount(); seats > venueCapacity {
+		problems["capacity"] = fmt.Sprintf("layout has %d seats but the venue holds %d", seats, venueCapacity)
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return problems
+}
+
+// validateRows checks row labels and seat numbers are set and unique within the section
+func (s *LayoutSection) validateRows(path string, problems map[string]string) {
+	labels := make(map[string]bool)
+	for j, row := range s.Rows {
+		rowPath := fmt.Sprintf("%s.rows[%d]", path, j)
+		if row == nil {
+			problems[rowPath] = "row is empty"
+			continue
+		}
+		switch label := strings.TrimSpace(row.Label); {
+		case label == "":
+			problems[rowPath+".label"] = "row label is required"
+		case labels[label]:
+			problems[rowPath+".label"] = fmt.Sprintf("row %q is used more than once", label)
+		default:
+			labels[label] = true
+		}
+		if len(row.Seats) == 0 {
+			problems[rowPath+".seats"] = "row must have at least one seat"
+		}
+
+		numbers := make(map[string]bool)
+		for k, seat := range row.Seats {
+			seatPath := fmt.Sprintf("%s.seats[%d]", rowPath, k)
+			if seat == nil {
+				problems[seatPath] = "seat is empty"
+				continue
+			}
+			switch number := strings.TrimSpace(seat.Number); {
+			case number == "":
+				problems[seatPath+".number"] = "seat number is required"
+			case numbers[number]:
+				problems[seatPath+".number"] = fmt.Sprintf("seat %q is used more than once in row %s", number, row.Label)
+			default:
+				numbers[number] = true
+			}
+			if (seat.X == nil) != (seat.Y == nil) {
+				problems[seatPath] = "seat needs both x and y, or neither"
+			}
+		}
+	}
+}
+
+// RenderedLayout is a seat map with every seat positioned, as drawn by the seat-selection UI
+type RenderedLayout struct {
+	VenueID   string             `json:"venue_id"`
+	VenueName string             `json:"venue_name"`
+	Version   int                `json:"version"`
+	SeatCount int                `json:"seat_count"`
+	Width     float64            `json:"width"`
+	Height    float64            `json:"height"`
+	Sections  []*RenderedSection `json:"sections"`
+}
+
+// RenderedSection is a section of a rendered layout with its zone
+type RenderedSection struct {
+	ID           string         `json:"id"`
+	Name         string         `json:"name"`
+	ZoneID       string         `json:"zone_id"`
+	ZoneName     string         `json:"zone_name"`
+	ZoneCapacity int            `json:"zone_capacity"`
+	SeatCount    int            `json:"seat_count"`
+	Rows         []*RenderedRow `json:"rows"`
+}
+
+// RenderedRow is a row of a rendered section
+type RenderedRow struct {
+	Label string          `json:"label"`
+	Seats []*RenderedSeat `json:"seats"`
+}
+
+// RenderedSeat is a positioned seat. ID is unique within the layout.
+type RenderedSeat struct {
+	ID         string  `json:"id"`
+	Number     string  `json:"number"`
+	X          float64 `json:"x"`
+	Y          float64 `json:"y"`
+	Accessible bool    `json:"accessible,omitempty"`
+}
+
+// Render positions the seats of a layout version of venue. Seats without coordinates are
+// placed in a grid, one row per line, with each section below the section before it.
+// The layout is shifted so that it starts at 0,0.
+func (v *VenueLayout) Render(venue *Venue, zones []*Zone) *RenderedLayout {
+	zonesByID := make(map[string]*Zone, len(zones))
+	for _, zone := range zones {
+		zonesByID[zone.ID] = zone
+	}
+
+	rendered := &RenderedLayout{
+		VenueID:   venue.ID,
+		VenueName: venue.Name,
+		Version:   v.Version,
+		Sections:  make([]*RenderedSection, 0, len(v.Layout.Sections)),
+	}
+
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	var seats []*RenderedSeat
+	top := 0.0
+	for _, section := range v.Layout.Sections {
+		out := &RenderedSection{
+			ID:        section.ID,
+			Name:      section.Name,
+			ZoneID:    section.ZoneID,
+			SeatCount: section.SeatCount(),
+			Rows:      make([]*RenderedRow, 0, len(section.Rows)),
+		}
+		if zone, ok := zonesByID[section.ZoneID]; ok {
+			out.ZoneName = zone.Name
+			out.ZoneCapacity = zone.Capacity
+		}
+
+		bottom := top
+		for j, row := range section.Rows {
+			gridY := top + float64(j)*LayoutRowSpacing
+			outRow := &RenderedRow{Label: row.Label, Seats: make([]*RenderedSeat, 0, len(row.Seats))}
+			for k, seat := range row.Seats {
+				x, y := float64(k)*LayoutSeatSpacing, gridY
+				if seat.X != nil && seat.Y != nil {
+					x, y = *seat.X, *seat.Y
+				}
+				rs := &RenderedSeat{
+					ID:         section.ID + "-" + row.Label + "-" + seat.Number,
+					Number:     seat.Number,
+					X:          x,
+					Y:          y,
+					Accessible: seat.Accessible,
+				}
+				outRow.Seats = append(outRow.Seats, rs)
+				seats = append(seats, rs)
+				minX, maxX = math.Min(minX, x), math.Max(maxX, x)
+				minY, maxY = math.Min(minY, y), math.Max(maxY, y)
+				bottom = math.Max(bottom, y)
+			}
+			out.Rows = append(out.Rows, outRow)
+		}
+		rendered.SeatCount += out.SeatCount
+		rendered.Sections = append(rendered.Sections, out)
+		top = bottom + LayoutSectionSpacing
+	}
+
+	if len(seats) == 0 {
+		return rendered
+	}
+	for _, seat := range seats {
+		seat.X -= minX
+		seat.Y -= minY
+	}
+	rendered.Width = maxX - minX + LayoutSeatSpacing
+	rendered.Height = maxY - minY + LayoutRowSpacing
+	return rendered
+}
@@ -0,0 +1,84 @@
+package domain
+
+import "testing"
+
+func newTestLayout() *SeatLayout {
+	x, y := 10.0, 20.0
+	return &SeatLayout{Sections: []*LayoutSection{
+		{ID: "A", Name: "Floor A", ZoneID: "zone-vip", Rows: []*LayoutRow{
+			{Label: "1", Seats: []*LayoutSeat{{Number: "1"}, {Number: "2", Accessible: true}}},
+			{Label: "2", Seats: []*LayoutSeat{{Number: "1"}}},
+		}},
+		{ID: "B", Name: "Balcony", ZoneID: "zone-ga", Rows: []*LayoutRow{
+			{Label: "1", Seats: []*LayoutSeat{{Number: "1", X: &x, Y: &y}}},
+		}},
+	}}
+}
+
+var testZones = []*Zone{
+	{ID: "zone-vip", Name: "VIP", Capacity: 3},
+	{ID: "zone-ga", Name: "GA", Capacity: 10},
+}
+
+func TestSeatLayout_Validate(t *testing.T) {
+	if problems := newTestLayout().Validate(testZones, 100); problems != nil {
+		t.Fatalf("Validate() = %v, want no problems", problems)
+	}
+
+	tests := []struct {
+		name     string
+		mutate   func(l *SeatLayout)
+		capacity int
+		wantPath string
+	}{
+		{"no sections", func(l *SeatLayout) { l.Sections = nil }, 100, "sections"},
+		{"unknown zone", func(l *SeatLayout) { l.Sections[1].ZoneID = "zone-other" }, 100, "sections[1].zone_id"},
+		{"duplicate section", func(l *SeatLayout) { l.Sections[1].ID = "A" }, 100, "sections[1].id"},
+		{"duplicate row", func(l *SeatLayout) { l.Sections[0].Rows[1].Label = "1" }, 100, "sections[0].rows[1].label"},
+		{"duplicate seat", func(l *SeatLayout) { l.Sections[0].Rows[0].Seats[1].Number = "1" }, 100, "sections[0].rows[0].seats[1].number"},
+		{"half a position", func(l *SeatLayout) { l.Sections[1].Rows[0].Seats[0].Y = nil }, 100, "sections[1].rows[0].seats[0]"},
+		{"over zone capacity", func(l *SeatLayout) {
+			l.Sections[0].Rows[1].Seats = append(l.Sections[0].Rows[1].Seats, &LayoutSeat{Number: "2"})
+		}, 100, "zones.zone-vip"},
+		{"over venue capacity", func(l *SeatLayout) {}, 3, "capacity"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layout := newTestLayout()
+			tt.mutate(layout)
+			problems := layout.Validate(testZones, tt.capacity)
+			if _, ok := problems[tt.wantPath]; !ok {
+				t.Errorf("Validate() = %v, want a problem at %s", problems, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestVenueLayout_Render(t *testing.T) {
+	layout := &VenueLayout{Version: 2, Layout: newTestLayout()}
+	rendered := layout.Render(&Venue{ID: "venue-1", Name: "Hall"}, testZones)
+
+	if rendered.Version != 2 || rendered.SeatCount != 4 || len(rendered.Sections) != 2 {
+		t.Fatalf("Render() = %+v, want version 2 with 4 seats in 2 sections", rendered)
+	}
+	if s := rendered.Sections[0]; s.ZoneName != "VIP" || s.ZoneCapacity != 3 || s.SeatCount != 3 {
+		t.Errorf("Section A = %+v, want zone VIP with 3 seats", s)
+	}
+
+	// Grid seats start at the origin, one seat or row apart
+	a12 := rendered.Sections[0].Rows[0].Seats[1]
+	if a12.ID != "A-1-2" || a12.X != LayoutSeatSpacing || a12.Y != 0 || !a12.Accessible {
+		t.Errorf("Seat A-1-2 = %+v", a12)
+	}
+	if a21 := rendered.Sections[0].Rows[1].Seats[0]; a21.X != 0 || a21.Y != LayoutRowSpacing {
+		t.Errorf("Seat A-2-1 = %+v", a21)
+	}
+	// Positioned seats keep their coordinates
+	if b11 := rendered.Sections[1].Rows[0].Seats[0]; b11.X != 10 || b11.Y != 20 {
+		t.Errorf("Seat B-1-1 = %+v, want 10,20", b11)
+	}
+	if rendered.Width != 11 || rendered.Height != 21 {
+		t.Errorf("Render() size = %vx%v, want 11x21", rendered.Width, rendered.Height)
+	}
+}
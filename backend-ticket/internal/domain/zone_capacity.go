@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/events"
+)
+
+// ZoneCapacityChangedEventType is the event type for zone capacity changes
+const ZoneCapacityChangedEventType = events.ZoneCapacityChangedEventType
+
+// ZoneCapacityChangedEvent is published when an organizer changes the capacity of a zone
+// that is on sale
+type ZoneCapacityChangedEvent = events.ZoneCapacityChangedEvent
+
+// Zone capacity change outcomes recorded by the inventory worker
+const (
+	ZoneCapacityChangeApplied  = "applied"
+	ZoneCapacityChangeRejected = "rejected"
+)
+
+// ZoneCapacityStatus is the outcome of the latest capacity change for a zone
+type ZoneCapacityStatus struct {
+	EventID        string    `json:"event_id"`
+	ZoneID         string    `json:"zone_id"`
+	Status         string    `json:"status"`
+	PreviousTotal  int       `json:"previous_total"`
+	RequestedTotal int       `json:"requested_total"`
+	AvailableSeats int       `json:"available_seats"`
+	Reason         string    `json:"reason,omitempty"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
@@ -0,0 +1,14 @@
+package domain
+
+import "github.com/prohmpiriya/booking-rush-10k-rps/pkg/events"
+
+// Zone lifecycle event types consumed by the inventory worker
+const (
+	ZoneCreatedEventType = events.ZoneCreatedEventType
+	ZoneUpdatedEventType = events.ZoneUpdatedEventType
+)
+
+// ZoneEvent is published when a zone is created or updated so the inventory worker can
+// seed the zone's Redis counters before the first booking, instead of booking-service
+// fetching the zone on ZONE_NOT_FOUND.
+type ZoneEvent = events.ZoneEvent
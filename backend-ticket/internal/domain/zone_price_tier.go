@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// ZonePriceTier is a price a zone sells at during a validity window, such as an
+// early-bird or last-minute price. A tier with an allocation sells at most that many
+// seats; booking-service counts them at reserve time.
+type ZonePriceTier struct {
+	ID         string     `json:"id"`
+	ZoneID     string     `json:"zone_id"`
+	Name       string     `json:"name"` // e.g. "Early Bird", "Last Minute"
+	Price      float64    `json:"price"`
+	Allocation int        `json:"allocation"`  // Seats sold at this tier (0 = no limit)
+	ValidFrom  *time.Time `json:"valid_from"`  // nil = from the start of sales
+	ValidUntil *time.Time `json:"valid_until"` // nil = until the end of sales
+	SortOrder  int        `json:"sort_order"`  // Lower tiers are tried first when windows overlap
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// IsActiveAt checks whether t falls inside the tier's validity window
+func (t *ZonePriceTier) IsActiveAt(at time.Time) bool {
+	if t.ValidFrom != nil && at.Before(*t.ValidFrom) {
+		return false
+	}
+	return t.ValidUntil == nil || at.Before(*t.ValidUntil)
+}
+
+// SortPriceTiers orders tiers the way booking-service tries them: by sort order, then
+// the cheaper tier first
+func SortPriceTiers(tiers []*ZonePriceTier) {
+	sort.SliceStable(tiers, func(i, j int) bool {
+		if tiers[i].SortOrder != tiers[j].SortOrder {
+			return tiers[i].SortOrder < tiers[j].SortOrder
+		}
+		return tiers[i].Price < tiers[j].Price
+	})
+}
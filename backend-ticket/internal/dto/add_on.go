@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"strings"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+)
+
+// CreateAddOnRequest represents the request to add an add-on item to an event
+type CreateAddOnRequest struct {
+	Name          string  `json:"name" binding:"required,max=100"`
+	Description   string  `json:"description" binding:"max=500"`
+	Category      string  `json:"category"` // parking, merchandise or other (default)
+	Price         float64 `json:"price" binding:"gte=0"`
+	Inventory     int     `json:"inventory"`       // Units for sale (0 = no limit)
+	MaxPerBooking int     `json:"max_per_booking"` // Units per booking (0 = no limit)
+	IsActive      *bool   `json:"is_active"`       // Omitted = active
+	SortOrder     int     `json:"sort_order"`
+}
+
+// Validate validates the CreateAddOnRequest
+func (r *CreateAddOnRequest) Validate() (bool, string) {
+	if strings.TrimSpace(r.Name) == "" {
+		return false, "Add-on name is required"
+	}
+	if r.Category != "" && !domain.IsValidAddOnCategory(r.Category) {
+		return false, "Category must be parking, merchandise or other"
+	}
+	if r.Price < 0 {
+		return false, "Price must be greater than or equal to 0"
+	}
+	if r.Inventory < 0 {
+		return false, "Inventory cannot be negative"
+	}
+	if r.MaxPerBooking < 0 {
+		return false, "Max per booking cannot be negative"
+	}
+	return true, ""
+}
+
+// UpdateAddOnRequest represents the request to replace an event's add-on. The inventory
+// may be lowered below the units already sold; the add-on then sells no more.
+type UpdateAddOnRequest CreateAddOnRequest
+
+// Validate validates the UpdateAddOnRequest
+func (r *UpdateAddOnRequest) Validate() (bool, string) {
+	return (*CreateAddOnRequest)(r).Validate()
+}
+
+// AddOnResponse represents an event add-on
+type AddOnResponse struct {
+	ID            string  `json:"id"`
+	EventID       string  `json:"event_id"`
+	Name          string  `json:"name"`
+	Description   string  `json:"description,omitempty"`
+	Category      string  `json:"category"`
+	Price         float64 `json:"price"`
+	Inventory     int     `json:"inventory"`
+	MaxPerBooking int     `json:"max_per_booking"`
+	IsActive      bool    `json:"is_active"`
+	SortOrder     int     `json:"sort_order"`
+	CreatedAt     string  `json:"created_at"`
+	UpdatedAt     string  `json:"updated_at"`
+}
@@ -0,0 +1,29 @@
+package dto
+
+// WarmUpRequest represents the request to warm event/show/zone caches.
+// An empty request uses the service's configured event IDs and on-sale window.
+type WarmUpRequest struct {
+	EventIDs    []string `json:"event_ids"`    // Events to warm; takes precedence over the window
+	WindowHours int      `json:"window_hours"` // Warm events with shows going on sale within this many hours
+}
+
+// Validate validates the WarmUpRequest
+func (r *WarmUpRequest) Validate() (bool, string) {
+	if r.WindowHours < 0 {
+		return false, "Window hours must not be negative"
+	}
+	if len(r.EventIDs) > 100 {
+		return false, "At most 100 event IDs can be warmed at once"
+	}
+	return true, ""
+}
+
+// WarmUpResponse summarizes a cache warm-up run
+type WarmUpResponse struct {
+	Events      int      `json:"events"`
+	Shows       int      `json:"shows"`
+	Zones       int      `json:"zones"`
+	ZonesSeeded int      `json:"zones_seeded"` // Zones added to Redis inventory that were not tracked yet
+	Errors      []string `json:"errors,omitempty"`
+	DurationMs  int64    `json:"duration_ms"`
+}
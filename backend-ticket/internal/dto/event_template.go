@@ -0,0 +1,87 @@
+package dto
+
+import (
+	"strings"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+)
+
+// MaxCloneOffsetDays bounds how far clone and template dates can be moved
+const MaxCloneOffsetDays = 3650
+
+// CloneEventRequest represents the request to copy an event into a new draft event.
+// Dates move by offset_days, or so the first show falls on first_show_date; with
+// neither the copy keeps the source dates.
+type CloneEventRequest struct {
+	Name          string `json:"name" binding:"omitempty,max=255"` // Defaults to the source name
+	OffsetDays    *int   `json:"offset_days"`
+	FirstShowDate string `json:"first_show_date"` // YYYY-MM-DD
+}
+
+// Validate validates the CloneEventRequest
+func (r *CloneEventRequest) Validate() (bool, string) {
+	if r.OffsetDays != nil && r.FirstShowDate != "" {
+		return false, "Use either offset_days or first_show_date, not both"
+	}
+	if r.OffsetDays != nil && (*r.OffsetDays < -MaxCloneOffsetDays || *r.OffsetDays > MaxCloneOffsetDays) {
+		return false, "Offset days must be between -3650 and 3650"
+	}
+	return validateFirstShowDate(r.FirstShowDate)
+}
+
+// CreateEventTemplateRequest represents the request to save an event's setup as a template
+type CreateEventTemplateRequest struct {
+	EventID     string `json:"event_id" binding:"required"`
+	Name        string `json:"name" binding:"required,max=255"`
+	Description string `json:"description"`
+}
+
+// Validate validates the CreateEventTemplateRequest
+func (r *CreateEventTemplateRequest) Validate() (bool, string) {
+	if r.EventID == "" {
+		return false, "Event ID is required"
+	}
+	if strings.TrimSpace(r.Name) == "" {
+		return false, "Template name is required"
+	}
+	return true, ""
+}
+
+// InstantiateEventTemplateRequest represents the request to create a draft event from a template
+type InstantiateEventTemplateRequest struct {
+	Name          string `json:"name" binding:"omitempty,max=255"` // Defaults to the template's event name
+	FirstShowDate string `json:"first_show_date"`                  // YYYY-MM-DD, required if the template has shows
+}
+
+// Validate validates the InstantiateEventTemplateRequest
+func (r *InstantiateEventTemplateRequest) Validate() (bool, string) {
+	return validateFirstShowDate(r.FirstShowDate)
+}
+
+// validateFirstShowDate checks an optional YYYY-MM-DD date
+func validateFirstShowDate(date string) (bool, string) {
+	if date == "" {
+		return true, ""
+	}
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return false, "Invalid first_show_date format, expected YYYY-MM-DD"
+	}
+	return true, ""
+}
+
+// EventTemplateResponse represents the response for an event template
+type EventTemplateResponse struct {
+	ID            string                 `json:"id"`
+	TenantID      string                 `json:"tenant_id"`
+	Name          string                 `json:"name"`
+	Description   string                 `json:"description"`
+	SourceEventID string                 `json:"source_event_id,omitempty"`
+	ShowCount     int                    `json:"show_count"`
+	ZoneCount     int                    `json:"zone_count"`
+	FirstShowDate *string                `json:"first_show_date,omitempty"`
+	Blueprint     *domain.EventBlueprint `json:"blueprint"`
+	CreatedBy     string                 `json:"created_by,omitempty"`
+	CreatedAt     string                 `json:"created_at"`
+	UpdatedAt     string                 `json:"updated_at"`
+}
@@ -0,0 +1,99 @@
+package dto
+
+import (
+	"strings"
+	"time"
+)
+
+// Limits for presale code batches
+const (
+	MaxPresaleBatchSize     = 10000
+	MaxPresaleCodePrefixLen = 8
+	DefaultPresaleMaxUses   = 1
+)
+
+// CreatePresaleBatchRequest represents the request to generate a batch of presale codes
+type CreatePresaleBatchRequest struct {
+	Name           string    `json:"name" binding:"required,max=100"`
+	Count          int       `json:"count" binding:"required"`
+	MaxUsesPerCode int       `json:"max_uses_per_code"` // Defaults to 1
+	Prefix         string    `json:"prefix"`            // Optional prefix, e.g. FANCLUB
+	ValidFrom      time.Time `json:"valid_from" binding:"required"`
+	ValidUntil     time.Time `json:"valid_until" binding:"required"`
+}
+
+// Validate validates the CreatePresaleBatchRequest
+func (r *CreatePresaleBatchRequest) Validate() (bool, string) {
+	if strings.TrimSpace(r.Name) == "" {
+		return false, "Batch name is required"
+	}
+	if r.Count <= 0 || r.Count > MaxPresaleBatchSize {
+		return false, "Count must be between 1 and 10000"
+	}
+	if r.MaxUsesPerCode < 0 {
+		return false, "Max uses per code cannot be negative"
+	}
+	if len(r.Prefix) > MaxPresaleCodePrefixLen {
+		return false, "Prefix must be at most 8 characters"
+	}
+	for _, ch := range r.Prefix {
+		if !(ch >= 'A' && ch <= 'Z' || ch >= 'a' && ch <= 'z' || ch >= '0' && ch <= '9') {
+			return false, "Prefix may only contain letters and digits"
+		}
+	}
+	if r.ValidFrom.IsZero() || r.ValidUntil.IsZero() {
+		return false, "Validity window is required"
+	}
+	if !r.ValidUntil.After(r.ValidFrom) {
+		return false, "Valid until must be after valid from"
+	}
+	return true, ""
+}
+
+// ValidatePresaleCodeRequest represents the request to check or redeem a presale code.
+// Booking service sends redeem=true on queue join or reserve; clients can check a
+// code with redeem=false before joining.
+type ValidatePresaleCodeRequest struct {
+	Code   string `json:"code" binding:"required"`
+	Redeem bool   `json:"redeem"`
+}
+
+// PresaleBatchResponse represents the response for a presale batch
+type PresaleBatchResponse struct {
+	ID             string   `json:"id"`
+	EventID        string   `json:"event_id"`
+	Name           string   `json:"name"`
+	MaxUsesPerCode int      `json:"max_uses_per_code"`
+	ValidFrom      string   `json:"valid_from"`
+	ValidUntil     string   `json:"valid_until"`
+	CreatedBy      string   `json:"created_by,omitempty"`
+	CreatedAt      string   `json:"created_at"`
+	Codes          []string `json:"codes,omitempty"` // Only returned when the batch is created
+}
+
+// PresaleCodeResponse represents the response for a presale code with its usage
+type PresaleCodeResponse struct {
+	Code          string `json:"code"`
+	MaxUses       int    `json:"max_uses"`
+	RedeemedCount int    `json:"redeemed_count"`
+}
+
+// PresaleBatchReportResponse represents redemption reporting for a presale batch
+type PresaleBatchReportResponse struct {
+	Batch          *PresaleBatchResponse `json:"batch"`
+	Codes          int                   `json:"codes"`
+	CodesRedeemed  int                   `json:"codes_redeemed"`
+	Redemptions    int                   `json:"redemptions"`
+	Capacity       int                   `json:"capacity"`
+	RedemptionRate float64               `json:"redemption_rate"` // Redemptions / capacity (0-1)
+}
+
+// PresaleValidationResponse represents the result of checking or redeeming a presale code
+type PresaleValidationResponse struct {
+	Valid           bool   `json:"valid"`
+	Redeemed        bool   `json:"redeemed"`         // A use is held for the user
+	AlreadyRedeemed bool   `json:"already_redeemed"` // The user had redeemed the code before
+	BatchID         string `json:"batch_id"`
+	UsesRemaining   int64  `json:"uses_remaining"`
+	ValidUntil      string `json:"valid_until"`
+}
@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"strings"
+	"time"
+)
+
+// CreatePriceTierRequest represents the request to add a price tier to a zone
+type CreatePriceTierRequest struct {
+	Name       string     `json:"name" binding:"required,max=100"`
+	Price      float64    `json:"price" binding:"gte=0"`
+	Allocation int        `json:"allocation"`  // Seats sold at this tier (0 = no limit)
+	ValidFrom  *time.Time `json:"valid_from"`  // Omitted = from the start of sales
+	ValidUntil *time.Time `json:"valid_until"` // Omitted = until the end of sales
+	SortOrder  int        `json:"sort_order"`
+}
+
+// Validate validates the CreatePriceTierRequest
+func (r *CreatePriceTierRequest) Validate() (bool, string) {
+	if strings.TrimSpace(r.Name) == "" {
+		return false, "Tier name is required"
+	}
+	return validatePriceTier(r.Price, r.Allocation, r.ValidFrom, r.ValidUntil)
+}
+
+// UpdatePriceTierRequest represents the request to replace a zone's price tier. The
+// allocation may be lowered below the seats already sold at the tier; the tier then
+// sells no more.
+type UpdatePriceTierRequest CreatePriceTierRequest
+
+// Validate validates the UpdatePriceTierRequest
+func (r *UpdatePriceTierRequest) Validate() (bool, string) {
+	return (*CreatePriceTierRequest)(r).Validate()
+}
+
+// validatePriceTier checks the fields shared by tier creation and updates
+func validatePriceTier(price float64, allocation int, validFrom, validUntil *time.Time) (bool, string) {
+	if price < 0 {
+		return false, "Price must be greater than or equal to 0"
+	}
+	if allocation < 0 {
+		return false, "Allocation cannot be negative"
+	}
+	if validFrom != nil && validUntil != nil && !validUntil.After(*validFrom) {
+		return false, "Valid until must be after valid from"
+	}
+	return true, ""
+}
+
+// PriceTierResponse represents a zone price tier
+type PriceTierResponse struct {
+	ID         string  `json:"id"`
+	ZoneID     string  `json:"zone_id"`
+	Name       string  `json:"name"`
+	Price      float64 `json:"price"`
+	Allocation int     `json:"allocation"`
+	ValidFrom  *string `json:"valid_from,omitempty"`
+	ValidUntil *string `json:"valid_until,omitempty"`
+	SortOrder  int     `json:"sort_order"`
+	CreatedAt  string  `json:"created_at"`
+	UpdatedAt  string  `json:"updated_at"`
+}
@@ -1,13 +1,16 @@
 package dto
 
+import "github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+
 // CreateShowZoneRequest represents the request to create a new show zone
 type CreateShowZoneRequest struct {
-	ShowID      string  `json:"-"` // Set from URL param
-	Name        string  `json:"name" binding:"required,min=1,max=200"`
-	Price       float64 `json:"price" binding:"required,gte=0"`
-	TotalSeats  int     `json:"total_seats" binding:"required,gt=0"`
-	Description string  `json:"description" binding:"omitempty,max=1000"`
-	SortOrder   int     `json:"sort_order" binding:"omitempty,gte=0"`
+	ShowID         string  `json:"-"` // Set from URL param
+	Name           string  `json:"name" binding:"required,min=1,max=200"`
+	Price          float64 `json:"price" binding:"required,gte=0"`
+	TotalSeats     int     `json:"total_seats" binding:"required,gt=0"`
+	Description    string  `json:"description" binding:"omitempty,max=1000"`
+	SortOrder      int     `json:"sort_order" binding:"omitempty,gte=0"`
+	HoldTTLSeconds *int    `json:"hold_ttl_seconds"` // Overrides the event's reservation hold (nil = inherit)
 }
 
 // Validate validates the CreateShowZoneRequest
@@ -21,22 +24,26 @@ func (r *CreateShowZoneRequest) Validate() (bool, string) {
 	if r.TotalSeats <= 0 {
 		return false, "Total seats must be greater than 0"
 	}
+	if r.HoldTTLSeconds != nil && !domain.ValidHoldTTL(*r.HoldTTLSeconds) {
+		return false, "Hold TTL must be between 60 and 3600 seconds"
+	}
 	return true, ""
 }
 
 // UpdateShowZoneRequest represents the request to update a show zone
 type UpdateShowZoneRequest struct {
-	Name        string   `json:"name" binding:"omitempty,min=1,max=200"`
-	Price       *float64 `json:"price" binding:"omitempty,gte=0"`
-	TotalSeats  *int     `json:"total_seats" binding:"omitempty,gt=0"`
-	Description string   `json:"description" binding:"omitempty,max=1000"`
-	SortOrder   *int     `json:"sort_order" binding:"omitempty,gte=0"`
-	IsActive    *bool    `json:"is_active" binding:"omitempty"`
+	Name           string   `json:"name" binding:"omitempty,min=1,max=200"`
+	Price          *float64 `json:"price" binding:"omitempty,gte=0"`
+	TotalSeats     *int     `json:"total_seats" binding:"omitempty,gt=0"`
+	Description    string   `json:"description" binding:"omitempty,max=1000"`
+	SortOrder      *int     `json:"sort_order" binding:"omitempty,gte=0"`
+	IsActive       *bool    `json:"is_active" binding:"omitempty"`
+	HoldTTLSeconds *int     `json:"hold_ttl_seconds"` // 0 inherits the event's reservation hold again
 }
 
 // Validate validates the UpdateShowZoneRequest
 func (r *UpdateShowZoneRequest) Validate() (bool, string) {
-	if r.Name == "" && r.Price == nil && r.TotalSeats == nil && r.Description == "" && r.SortOrder == nil && r.IsActive == nil {
+	if r.Name == "" && r.Price == nil && r.TotalSeats == nil && r.Description == "" && r.SortOrder == nil && r.IsActive == nil && r.HoldTTLSeconds == nil {
 		return false, "At least one field must be provided for update"
 	}
 	if r.Price != nil && *r.Price < 0 {
@@ -45,6 +52,9 @@ func (r *UpdateShowZoneRequest) Validate() (bool, string) {
 	if r.TotalSeats != nil && *r.TotalSeats <= 0 {
 		return false, "Total seats must be greater than 0"
 	}
+	if r.HoldTTLSeconds != nil && *r.HoldTTLSeconds != 0 && !domain.ValidHoldTTL(*r.HoldTTLSeconds) {
+		return false, "Hold TTL must be between 60 and 3600 seconds"
+	}
 	return true, ""
 }
 
@@ -69,6 +79,24 @@ type ShowZoneResponse struct {
 	SaleEndAt      *string `json:"sale_end_at,omitempty"`
 	CreatedAt      string  `json:"created_at"`
 	UpdatedAt      string  `json:"updated_at"`
+
+	// PendingTotalSeats is the requested capacity while the inventory worker applies it
+	PendingTotalSeats *int `json:"pending_total_seats,omitempty"`
+
+	// HoldTTLSeconds is the zone's reservation hold override (omitted = inherits the event's)
+	HoldTTLSeconds *int `json:"hold_ttl_seconds,omitempty"`
+}
+
+// ZoneCapacityStatusResponse represents the outcome of the latest capacity change for a zone
+type ZoneCapacityStatusResponse struct {
+	EventID        string `json:"event_id"`
+	ZoneID         string `json:"zone_id"`
+	Status         string `json:"status"` // applied, rejected
+	PreviousTotal  int    `json:"previous_total"`
+	RequestedTotal int    `json:"requested_total"`
+	AvailableSeats int    `json:"available_seats"`
+	Reason         string `json:"reason,omitempty"`
+	UpdatedAt      string `json:"updated_at"`
 }
 
 // ShowZoneListResponse represents a list of show zones
@@ -0,0 +1,31 @@
+package dto
+
+import "github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+
+// SetTeamMemberRequest represents the request to add a team member or change their permissions
+type SetTeamMemberRequest struct {
+	Permissions []string `json:"permissions" binding:"required"`
+}
+
+// Validate validates the SetTeamMemberRequest
+func (r *SetTeamMemberRequest) Validate() (bool, string) {
+	if len(r.Permissions) == 0 {
+		return false, "At least one permission is required"
+	}
+	for _, p := range r.Permissions {
+		if !domain.IsValidPermission(p) {
+			return false, "Unknown permission: " + p
+		}
+	}
+	return true, ""
+}
+
+// TeamMemberResponse represents the response for a team member
+type TeamMemberResponse struct {
+	UserID      string   `json:"user_id"`
+	TenantID    string   `json:"tenant_id"`
+	Permissions []string `json:"permissions"`
+	AddedBy     string   `json:"added_by,omitempty"`
+	CreatedAt   string   `json:"created_at"`
+	UpdatedAt   string   `json:"updated_at"`
+}
@@ -1,5 +1,7 @@
 package dto
 
+import "github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+
 // CreateVenueRequest represents the request to create a new venue
 type CreateVenueRequest struct {
 	Name     string `json:"name" binding:"required,min=1,max=200"`
@@ -42,18 +44,20 @@ func (r *UpdateVenueRequest) Validate() (bool, string) {
 
 // VenueResponse represents the response for a venue
 type VenueResponse struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
-	Address   string `json:"address"`
-	Capacity  int    `json:"capacity"`
-	TenantID  string `json:"tenant_id"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Address  string `json:"address"`
+	Capacity int    `json:"capacity"`
+	TenantID string `json:"tenant_id"`
+	// ActiveLayoutVersion is the seat-map version served to buyers, 0 if none is active
+	ActiveLayoutVersion int    `json:"active_layout_version"`
+	CreatedAt           string `json:"created_at"`
+	UpdatedAt           string `json:"updated_at"`
 }
 
 // CreateZoneRequest represents the request to create a new zone
 type CreateZoneRequest struct {
-	VenueID  string `json:"venue_id" binding:"required"`
+	VenueID  string `json:"-"` // Set from path
 	Name     string `json:"name" binding:"required,min=1,max=100"`
 	Capacity int    `json:"capacity" binding:"required,gte=1"`
 }
@@ -81,3 +85,54 @@ type ZoneResponse struct {
 	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
 }
+
+// UpdateZoneRequest represents the request to update a zone
+type UpdateZoneRequest struct {
+	Name     string `json:"name" binding:"omitempty,min=1,max=100"`
+	Capacity *int   `json:"capacity" binding:"omitempty,gte=1"`
+}
+
+// Validate validates the UpdateZoneRequest
+func (r *UpdateZoneRequest) Validate() (bool, string) {
+	if r.Name == "" && r.Capacity == nil {
+		return false, "At least one field must be provided for update"
+	}
+	if r.Capacity != nil && *r.Capacity < 1 {
+		return false, "Capacity must be at least 1"
+	}
+	return true, ""
+}
+
+// UploadVenueLayoutRequest represents the request to upload a new seat-map version
+type UploadVenueLayoutRequest struct {
+	Layout *domain.SeatLayout `json:"layout" binding:"required"`
+	Notes  string             `json:"notes" binding:"max=500"`
+	// Activate serves the new version to buyers right away (default true)
+	Activate *bool `json:"activate"`
+}
+
+// Validate validates the UploadVenueLayoutRequest; the layout itself is checked against the venue zones
+func (r *UploadVenueLayoutRequest) Validate() (bool, string) {
+	if r.Layout == nil {
+		return false, "Layout is required"
+	}
+	return true, ""
+}
+
+// ShouldActivate reports whether the uploaded version becomes the active one
+func (r *UploadVenueLayoutRequest) ShouldActivate() bool {
+	return r.Activate == nil || *r.Activate
+}
+
+// VenueLayoutResponse represents the response for a venue layout version
+type VenueLayoutResponse struct {
+	ID        string             `json:"id"`
+	VenueID   string             `json:"venue_id"`
+	Version   int                `json:"version"`
+	Active    bool               `json:"active"`
+	SeatCount int                `json:"seat_count"`
+	Notes     string             `json:"notes,omitempty"`
+	CreatedBy string             `json:"created_by,omitempty"`
+	CreatedAt string             `json:"created_at"`
+	Layout    *domain.SeatLayout `json:"layout,omitempty"`
+}
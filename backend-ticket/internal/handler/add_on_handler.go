@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/response"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// AddOnHandler handles event add-on HTTP requests
+type AddOnHandler struct {
+	addOnService service.AddOnService
+}
+
+// NewAddOnHandler creates a new AddOnHandler
+func NewAddOnHandler(addOnService service.AddOnService) *AddOnHandler {
+	return &AddOnHandler{
+		addOnService: addOnService,
+	}
+}
+
+// List handles GET /events/:id/add-ons - the event's add-ons, read by booking-service
+func (h *AddOnHandler) List(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.add_on.list")
+	defer span.End()
+
+	eventID := c.Param("id")
+	span.SetAttributes(attribute.String("event_id", eventID))
+
+	addOns, err := h.addOnService.ListAddOns(ctx, eventID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to list add-ons")
+		return
+	}
+
+	resp := make([]*dto.AddOnResponse, len(addOns))
+	for i, addOn := range addOns {
+		resp[i] = toAddOnResponse(addOn)
+	}
+
+	span.SetAttributes(attribute.Int("count", len(addOns)))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(resp))
+}
+
+// Create handles POST /events/:id/add-ons - adds an add-on to an event
+func (h *AddOnHandler) Create(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.add_on.create")
+	defer span.End()
+
+	eventID := c.Param("id")
+	span.SetAttributes(attribute.String("event_id", eventID))
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		span.SetStatus(codes.Error, "user ID not found in token")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User ID not found in token"))
+		return
+	}
+
+	var req dto.CreateAddOnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request body")
+		c.JSON(http.StatusBadRequest, response.BadRequest("Invalid request body"))
+		return
+	}
+	if valid, msg := req.Validate(); !valid {
+		span.SetStatus(codes.Error, "validation failed")
+		c.JSON(http.StatusBadRequest, response.BadRequest(msg))
+		return
+	}
+
+	addOn, err := h.addOnService.CreateAddOn(ctx, actor, eventID, &req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to create add-on")
+		return
+	}
+
+	span.SetAttributes(attribute.String("add_on_id", addOn.ID))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusCreated, response.Success(toAddOnResponse(addOn)))
+}
+
+// Update handles PUT /events/:id/add-ons/:add_on_id - replaces an add-on's settings
+func (h *AddOnHandler) Update(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.add_on.update")
+	defer span.End()
+
+	eventID, addOnID := c.Param("id"), c.Param("add_on_id")
+	span.SetAttributes(attribute.String("event_id", eventID), attribute.String("add_on_id", addOnID))
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		span.SetStatus(codes.Error, "user ID not found in token")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User ID not found in token"))
+		return
+	}
+
+	var req dto.UpdateAddOnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request body")
+		c.JSON(http.StatusBadRequest, response.BadRequest("Invalid request body"))
+		return
+	}
+	if valid, msg := req.Validate(); !valid {
+		span.SetStatus(codes.Error, "validation failed")
+		c.JSON(http.StatusBadRequest, response.BadRequest(msg))
+		return
+	}
+
+	addOn, err := h.addOnService.UpdateAddOn(ctx, actor, eventID, addOnID, &req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to update add-on")
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(toAddOnResponse(addOn)))
+}
+
+// Delete handles DELETE /events/:id/add-ons/:add_on_id - removes an add-on
+func (h *AddOnHandler) Delete(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.add_on.delete")
+	defer span.End()
+
+	eventID, addOnID := c.Param("id"), c.Param("add_on_id")
+	span.SetAttributes(attribute.String("event_id", eventID), attribute.String("add_on_id", addOnID))
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		span.SetStatus(codes.Error, "user ID not found in token")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User ID not found in token"))
+		return
+	}
+
+	if err := h.addOnService.DeleteAddOn(ctx, actor, eventID, addOnID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to delete add-on")
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(map[string]string{"message": "Add-on deleted successfully"}))
+}
+
+// handleError maps add-on service errors to HTTP responses
+func (h *AddOnHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrEventNotFound):
+		c.JSON(http.StatusNotFound, response.NotFound("Event not found"))
+	case errors.Is(err, service.ErrUnauthorized):
+		c.JSON(http.StatusForbidden, response.Forbidden("Missing permission "+domain.PermissionEventEdit+" for this event"))
+	case errors.Is(err, service.ErrAddOnNotFound):
+		c.JSON(http.StatusNotFound, response.NotFound("Add-on not found"))
+	case errors.Is(err, service.ErrInvalidAddOn):
+		c.JSON(http.StatusBadRequest, response.BadRequest(err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, response.InternalError(fallback))
+	}
+}
+
+// toAddOnResponse converts a domain add-on to response DTO
+func toAddOnResponse(addOn *domain.EventAddOn) *dto.AddOnResponse {
+	return &dto.AddOnResponse{
+		ID:            addOn.ID,
+		EventID:       addOn.EventID,
+		Name:          addOn.Name,
+		Description:   addOn.Description,
+		Category:      addOn.Category,
+		Price:         addOn.Price,
+		Inventory:     addOn.Inventory,
+		MaxPerBooking: addOn.MaxPerBooking,
+		IsActive:      addOn.IsActive,
+		SortOrder:     addOn.SortOrder,
+		CreatedAt:     addOn.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:     addOn.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
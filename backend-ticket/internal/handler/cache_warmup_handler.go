@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/response"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// warmUpRequestTimeout keeps an admin warm-up under the server write timeout.
+// A run cut short still reports what it warmed.
+const warmUpRequestTimeout = 8 * time.Second
+
+// CacheWarmupHandler handles admin cache warm-up requests
+type CacheWarmupHandler struct {
+	cacheWarmer service.CacheWarmer
+}
+
+// NewCacheWarmupHandler creates a new CacheWarmupHandler
+func NewCacheWarmupHandler(cacheWarmer service.CacheWarmer) *CacheWarmupHandler {
+	return &CacheWarmupHandler{
+		cacheWarmer: cacheWarmer,
+	}
+}
+
+// WarmUp handles POST /admin/cache/warm-up - pre-populates event/show/zone caches.
+// An empty body warms the configured events or upcoming on-sales.
+func (h *CacheWarmupHandler) WarmUp(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.cache_warmup.WarmUp")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	var req dto.WarmUpRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Invalid request body")
+		c.JSON(http.StatusBadRequest, response.BadRequest("Invalid request body"))
+		return
+	}
+
+	if valid, msg := req.Validate(); !valid {
+		span.SetStatus(codes.Error, msg)
+		c.JSON(http.StatusBadRequest, response.BadRequest(msg))
+		return
+	}
+
+	warmCtx, cancel := context.WithTimeout(ctx, warmUpRequestTimeout)
+	defer cancel()
+
+	result, err := h.cacheWarmer.WarmUp(warmCtx, &req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to warm up caches")
+		c.JSON(http.StatusInternalServerError, response.InternalError("Failed to warm up caches"))
+		return
+	}
+
+	span.SetAttributes(
+		attribute.Int("events", result.Events),
+		attribute.Int("shows", result.Shows),
+		attribute.Int("zones", result.Zones),
+		attribute.Int("zones_seeded", result.ZonesSeeded),
+		attribute.Int("errors", len(result.Errors)),
+	)
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(result))
+}
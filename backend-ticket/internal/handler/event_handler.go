@@ -10,6 +10,7 @@ import (
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/service"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/middleware"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/response"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
 	"go.opentelemetry.io/otel/attribute"
@@ -18,15 +19,17 @@ import (
 
 // EventHandler handles event-related HTTP requests
 type EventHandler struct {
-	eventService service.EventService
-	showService  service.ShowService
+	eventService  service.EventService
+	showService   service.ShowService
+	accessService service.EventAccessService
 }
 
 // NewEventHandler creates a new EventHandler
-func NewEventHandler(eventService service.EventService, showService service.ShowService) *EventHandler {
+func NewEventHandler(eventService service.EventService, showService service.ShowService, accessService service.EventAccessService) *EventHandler {
 	return &EventHandler{
-		eventService: eventService,
-		showService:  showService,
+		eventService:  eventService,
+		showService:   showService,
+		accessService: accessService,
 	}
 }
 
@@ -50,12 +53,22 @@ func (h *EventHandler) List(c *gin.Context) {
 		}
 	}
 
+	after, err := pagination.DecodeCursor(c.Query("cursor"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error("INVALID_CURSOR", "Invalid pagination cursor"))
+		return
+	}
+	if after != nil {
+		offset = 0
+	}
+
 	span.SetAttributes(
 		attribute.Int("limit", limit),
 		attribute.Int("offset", offset),
+		attribute.Bool("cursor", after != nil),
 	)
 
-	events, total, err := h.eventService.ListPublishedEvents(ctx, limit, offset)
+	events, total, info, err := h.eventService.ListPublishedEvents(ctx, pagination.Params{Limit: limit, Offset: offset, After: after})
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
@@ -73,7 +86,7 @@ func (h *EventHandler) List(c *gin.Context) {
 
 	span.SetAttributes(attribute.Int("total", total))
 	span.SetStatus(codes.Ok, "")
-	c.JSON(http.StatusOK, response.Paginated(eventResponses, offset/limit+1, limit, int64(total)))
+	c.JSON(http.StatusOK, response.CursorPaginated(eventResponses, offset/limit+1, limit, int64(total), info))
 }
 
 // GetBySlug handles GET /events/slug/:slug - retrieves an event by slug
@@ -202,12 +215,22 @@ func (h *EventHandler) ListMyEvents(c *gin.Context) {
 		}
 	}
 
+	after, err := pagination.DecodeCursor(c.Query("cursor"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, response.Error("INVALID_CURSOR", "Invalid pagination cursor"))
+		return
+	}
+	if after != nil {
+		offset = 0
+	}
+
 	filter := &dto.EventListFilter{
 		OrganizerID: userID,
 		Status:      c.Query("status"),
 		Search:      c.Query("search"),
 		Limit:       limit,
 		Offset:      offset,
+		After:       after,
 	}
 
 	span.SetAttributes(
@@ -217,7 +240,7 @@ func (h *EventHandler) ListMyEvents(c *gin.Context) {
 		attribute.String("search", filter.Search),
 	)
 
-	events, total, err := h.eventService.ListEvents(ctx, filter)
+	events, total, info, err := h.eventService.ListEvents(ctx, filter)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
@@ -235,7 +258,7 @@ func (h *EventHandler) ListMyEvents(c *gin.Context) {
 
 	span.SetAttributes(attribute.Int("total", total))
 	span.SetStatus(codes.Ok, "")
-	c.JSON(http.StatusOK, response.Paginated(eventResponses, offset/limit+1, limit, int64(total)))
+	c.JSON(http.StatusOK, response.CursorPaginated(eventResponses, offset/limit+1, limit, int64(total), info))
 }
 
 // Create handles POST /events - creates a new event (Organizer only)
@@ -316,6 +339,10 @@ func (h *EventHandler) Update(c *gin.Context) {
 
 	span.SetAttributes(attribute.String("event_id", id))
 
+	if !authorizeEvent(c, h.accessService, id, domain.PermissionEventEdit) {
+		return
+	}
+
 	var req dto.UpdateEventRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		span.RecordError(err)
@@ -367,6 +394,10 @@ func (h *EventHandler) Delete(c *gin.Context) {
 
 	span.SetAttributes(attribute.String("event_id", id))
 
+	if !authorizeEvent(c, h.accessService, id, domain.PermissionEventDelete) {
+		return
+	}
+
 	err := h.eventService.DeleteEvent(ctx, id)
 	if err != nil {
 		span.RecordError(err)
@@ -399,6 +430,10 @@ func (h *EventHandler) Publish(c *gin.Context) {
 
 	span.SetAttributes(attribute.String("event_id", id))
 
+	if !authorizeEvent(c, h.accessService, id, domain.PermissionEventPublish) {
+		return
+	}
+
 	event, err := h.eventService.PublishEvent(ctx, id)
 	if err != nil {
 		span.RecordError(err)
@@ -425,6 +460,79 @@ func (h *EventHandler) Publish(c *gin.Context) {
 	c.JSON(http.StatusOK, response.Success(toEventResponse(event, saleStatus)))
 }
 
+// CheckAccess handles GET /events/:id/access - succeeds if the caller may edit the event.
+// Booking-service checks organizers with it before they manage the event's queue.
+func (h *EventHandler) CheckAccess(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.event.CheckAccess")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	id := c.Param("id")
+	span.SetAttributes(attribute.String("event_id", id))
+
+	if !authorizeEvent(c, h.accessService, id, domain.PermissionEventEdit) {
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(gin.H{"event_id": id, "permission": domain.PermissionEventEdit}))
+}
+
+// authorizeEvent checks that the caller holds permission on the event and writes the
+// error response if not. Returns false when the request must stop. Handlers of an
+// event's shows, zones and price tiers check the parent event with it.
+func authorizeEvent(c *gin.Context, accessService service.EventAccessService, eventID, permission string) bool {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.event.authorize")
+	defer span.End()
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		span.SetStatus(codes.Error, "user ID not found in token")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User ID not found in token"))
+		return false
+	}
+
+	span.SetAttributes(
+		attribute.String("event_id", eventID),
+		attribute.String("user_id", actor.UserID),
+		attribute.String("permission", permission),
+	)
+
+	if _, err := accessService.AuthorizeEvent(ctx, actor, eventID, permission); err != nil {
+		span.RecordError(err)
+		switch {
+		case errors.Is(err, service.ErrEventNotFound):
+			span.SetStatus(codes.Error, "event not found")
+			c.JSON(http.StatusNotFound, response.NotFound("Event not found"))
+		case errors.Is(err, service.ErrUnauthorized):
+			span.SetStatus(codes.Error, "forbidden")
+			c.JSON(http.StatusForbidden, response.Forbidden("Missing permission "+permission+" for this event"))
+		default:
+			span.SetStatus(codes.Error, err.Error())
+			c.JSON(http.StatusInternalServerError, response.InternalError("Failed to check event permissions"))
+		}
+		return false
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return true
+}
+
+// actorFromContext builds the caller identity from JWT claims
+func actorFromContext(c *gin.Context) (*domain.Actor, bool) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok || userID == "" {
+		return nil, false
+	}
+	tenantID, _ := middleware.GetTenantID(c)
+	role, _ := middleware.GetRole(c)
+	return &domain.Actor{
+		UserID:   userID,
+		TenantID: tenantID,
+		Role:     role,
+	}, true
+}
+
 // calculateSaleStatus determines the aggregated sale status from shows
 // Priority: on_sale > scheduled > sold_out > completed > cancelled
 func calculateSaleStatus(shows []*domain.Show) string {
@@ -476,33 +584,35 @@ func calculateSaleStatus(shows []*domain.Show) string {
 // toEventResponse converts a domain event to response DTO
 func toEventResponse(event *domain.Event, saleStatus string) *dto.EventResponse {
 	resp := &dto.EventResponse{
-		ID:                event.ID,
-		TenantID:          event.TenantID,
-		OrganizerID:       event.OrganizerID,
-		CategoryID:        event.CategoryID,
-		Name:              event.Name,
-		Slug:              event.Slug,
-		Description:       event.Description,
-		ShortDescription:  event.ShortDescription,
-		PosterURL:         event.PosterURL,
-		BannerURL:         event.BannerURL,
-		Gallery:           event.Gallery,
-		VenueName:         event.VenueName,
-		VenueAddress:      event.VenueAddress,
-		City:              event.City,
-		Country:           event.Country,
-		Latitude:          event.Latitude,
-		Longitude:         event.Longitude,
-		MaxTicketsPerUser: event.MaxTicketsPerUser,
-		Status:            event.Status,
-		SaleStatus:        saleStatus,
-		IsFeatured:        event.IsFeatured,
-		IsPublic:          event.IsPublic,
-		MetaTitle:         event.MetaTitle,
-		MetaDescription:   event.MetaDescription,
-		MinPrice:          event.MinPrice,
-		CreatedAt:         event.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt:         event.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		ID:                 event.ID,
+		TenantID:           event.TenantID,
+		OrganizerID:        event.OrganizerID,
+		CategoryID:         event.CategoryID,
+		Name:               event.Name,
+		Slug:               event.Slug,
+		Description:        event.Description,
+		ShortDescription:   event.ShortDescription,
+		PosterURL:          event.PosterURL,
+		BannerURL:          event.BannerURL,
+		Gallery:            event.Gallery,
+		VenueName:          event.VenueName,
+		VenueAddress:       event.VenueAddress,
+		City:               event.City,
+		Country:            event.Country,
+		Latitude:           event.Latitude,
+		Longitude:          event.Longitude,
+		MaxTicketsPerUser:  event.MaxTicketsPerUser,
+		HoldTTLSeconds:     event.HoldTTLSeconds,
+		VerificationPolicy: event.VerificationPolicy,
+		Status:             event.Status,
+		SaleStatus:         saleStatus,
+		IsFeatured:         event.IsFeatured,
+		IsPublic:           event.IsPublic,
+		MetaTitle:          event.MetaTitle,
+		MetaDescription:    event.MetaDescription,
+		MinPrice:           event.MinPrice,
+		CreatedAt:          event.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:          event.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
 
 	if event.BookingStartAt != nil {
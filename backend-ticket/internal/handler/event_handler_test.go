@@ -14,6 +14,7 @@ import (
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/service"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/middleware"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 )
 
 // MockEventService is a mock implementation of EventService
@@ -64,12 +65,12 @@ func (m *MockEventService) GetEventBySlug(ctx context.Context, slug string) (*do
 	return nil, service.ErrEventNotFound
 }
 
-func (m *MockEventService) ListEvents(ctx context.Context, filter *dto.EventListFilter) ([]*domain.Event, int, error) {
+func (m *MockEventService) ListEvents(ctx context.Context, filter *dto.EventListFilter) ([]*domain.Event, int, pagination.Info, error) {
 	var events []*domain.Event
 	for _, e := range m.events {
 		events = append(events, e)
 	}
-	return events, len(events), nil
+	return events, len(events), pagination.Info{}, nil
 }
 
 func (m *MockEventService) UpdateEvent(ctx context.Context, id string, req *dto.UpdateEventRequest) (*domain.Event, error) {
@@ -106,14 +107,14 @@ func (m *MockEventService) PublishEvent(ctx context.Context, id string) (*domain
 	return event, nil
 }
 
-func (m *MockEventService) ListPublishedEvents(ctx context.Context, limit, offset int) ([]*domain.Event, int, error) {
+func (m *MockEventService) ListPublishedEvents(ctx context.Context, page pagination.Params) ([]*domain.Event, int, pagination.Info, error) {
 	var events []*domain.Event
 	for _, e := range m.events {
 		if e.Status == domain.EventStatusPublished {
 			events = append(events, e)
 		}
 	}
-	return events, len(events), nil
+	return events, len(events), pagination.Info{}, nil
 }
 
 // AddEvent adds an event to the mock service
@@ -140,9 +141,46 @@ func (m *MockShowServiceForEvent) DeleteShow(ctx context.Context, id string) err
 	return nil
 }
 
+// MockEventAccessService is a mock implementation of EventAccessService
+type MockEventAccessService struct {
+	authorizeErr error
+	authorized   []string // Event IDs access was checked on
+	members      []*domain.TeamMember
+	teamErr      error
+}
+
+func (m *MockEventAccessService) AuthorizeEvent(ctx context.Context, actor *domain.Actor, eventID, permission string) (*domain.Event, error) {
+	m.authorized = append(m.authorized, eventID)
+	return nil, m.authorizeErr
+}
+
+func (m *MockEventAccessService) ListTeamMembers(ctx context.Context, actor *domain.Actor) ([]*domain.TeamMember, error) {
+	return m.members, m.teamErr
+}
+
+func (m *MockEventAccessService) SetTeamMember(ctx context.Context, actor *domain.Actor, userID string, req *dto.SetTeamMemberRequest) (*domain.TeamMember, error) {
+	if m.teamErr != nil {
+		return nil, m.teamErr
+	}
+	return &domain.TeamMember{TenantID: actor.TenantID, UserID: userID, Permissions: req.Permissions}, nil
+}
+
+func (m *MockEventAccessService) RemoveTeamMember(ctx context.Context, actor *domain.Actor, userID string) error {
+	return m.teamErr
+}
+
+// withActor simulates the JWT middleware for an organizer
+func withActor(c *gin.Context) {
+	c.Set(middleware.ContextKeyUserID, "user-1")
+	c.Set(middleware.ContextKeyTenantID, "tenant-1")
+	c.Set(middleware.ContextKeyRole, "organizer")
+	c.Next()
+}
+
 func setupRouter(h *EventHandler) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(withActor)
 
 	events := router.Group("/events")
 	{
@@ -153,6 +191,7 @@ func setupRouter(h *EventHandler) *gin.Engine {
 		events.PUT("/:id", h.Update)
 		events.DELETE("/:id", h.Delete)
 		events.POST("/:id/publish", h.Publish)
+		events.GET("/:id/access", h.CheckAccess)
 	}
 
 	return router
@@ -160,7 +199,7 @@ func setupRouter(h *EventHandler) *gin.Engine {
 
 func TestEventHandler_List(t *testing.T) {
 	mockSvc := NewMockEventService()
-	handler := NewEventHandler(mockSvc, &MockShowServiceForEvent{})
+	handler := NewEventHandler(mockSvc, &MockShowServiceForEvent{}, &MockEventAccessService{})
 	router := setupRouter(handler)
 
 	// Add test event
@@ -181,11 +220,19 @@ func TestEventHandler_List(t *testing.T) {
 	if resp.Code != http.StatusOK {
 		t.Errorf("expected status %d, got %d", http.StatusOK, resp.Code)
 	}
+
+	req, _ = http.NewRequest(http.MethodGet, "/events?cursor=not-a-cursor", nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	if resp.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for invalid cursor, got %d", http.StatusBadRequest, resp.Code)
+	}
 }
 
 func TestEventHandler_GetBySlug(t *testing.T) {
 	mockSvc := NewMockEventService()
-	handler := NewEventHandler(mockSvc, &MockShowServiceForEvent{})
+	handler := NewEventHandler(mockSvc, &MockShowServiceForEvent{}, &MockEventAccessService{})
 	router := setupRouter(handler)
 
 	// Add test event
@@ -231,7 +278,7 @@ func TestEventHandler_GetBySlug(t *testing.T) {
 
 func TestEventHandler_GetByID(t *testing.T) {
 	mockSvc := NewMockEventService()
-	handler := NewEventHandler(mockSvc, &MockShowServiceForEvent{})
+	handler := NewEventHandler(mockSvc, &MockShowServiceForEvent{}, &MockEventAccessService{})
 	router := setupRouter(handler)
 
 	// Add test event
@@ -277,7 +324,7 @@ func TestEventHandler_GetByID(t *testing.T) {
 
 func TestEventHandler_Create(t *testing.T) {
 	mockSvc := NewMockEventService()
-	handler := NewEventHandler(mockSvc, &MockShowServiceForEvent{})
+	handler := NewEventHandler(mockSvc, &MockShowServiceForEvent{}, &MockEventAccessService{})
 
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -334,7 +381,7 @@ func TestEventHandler_Create(t *testing.T) {
 
 func TestEventHandler_Update(t *testing.T) {
 	mockSvc := NewMockEventService()
-	handler := NewEventHandler(mockSvc, &MockShowServiceForEvent{})
+	handler := NewEventHandler(mockSvc, &MockShowServiceForEvent{}, &MockEventAccessService{})
 	router := setupRouter(handler)
 
 	// Add test event
@@ -395,7 +442,7 @@ func TestEventHandler_Update(t *testing.T) {
 
 func TestEventHandler_Delete(t *testing.T) {
 	mockSvc := NewMockEventService()
-	handler := NewEventHandler(mockSvc, &MockShowServiceForEvent{})
+	handler := NewEventHandler(mockSvc, &MockShowServiceForEvent{}, &MockEventAccessService{})
 	router := setupRouter(handler)
 
 	// Add test event
@@ -441,7 +488,7 @@ func TestEventHandler_Delete(t *testing.T) {
 
 func TestEventHandler_Publish(t *testing.T) {
 	mockSvc := NewMockEventService()
-	handler := NewEventHandler(mockSvc, &MockShowServiceForEvent{})
+	handler := NewEventHandler(mockSvc, &MockShowServiceForEvent{}, &MockEventAccessService{})
 	router := setupRouter(handler)
 
 	// Add test events
@@ -497,3 +544,76 @@ func TestEventHandler_Publish(t *testing.T) {
 		})
 	}
 }
+
+func TestEventHandler_OwnershipChecks(t *testing.T) {
+	now := time.Now()
+	newEvent := func() *domain.Event {
+		return &domain.Event{
+			ID:          "event-1",
+			Name:        "Test Event",
+			Slug:        "test-event",
+			OrganizerID: "someone-else",
+			TenantID:    "tenant-1",
+			Status:      domain.EventStatusDraft,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+	}
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		authorizeErr error
+		wantStatus   int
+	}{
+		{"update forbidden", http.MethodPut, "/events/event-1", service.ErrUnauthorized, http.StatusForbidden},
+		{"delete forbidden", http.MethodDelete, "/events/event-1", service.ErrUnauthorized, http.StatusForbidden},
+		{"publish forbidden", http.MethodPost, "/events/event-1/publish", service.ErrUnauthorized, http.StatusForbidden},
+		{"other tenant hidden", http.MethodPut, "/events/event-1", service.ErrEventNotFound, http.StatusNotFound},
+		{"publish allowed", http.MethodPost, "/events/event-1/publish", nil, http.StatusOK},
+		{"access denied", http.MethodGet, "/events/event-1/access", service.ErrUnauthorized, http.StatusForbidden},
+		{"access of other tenant hidden", http.MethodGet, "/events/event-1/access", service.ErrEventNotFound, http.StatusNotFound},
+		{"access granted", http.MethodGet, "/events/event-1/access", nil, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := NewMockEventService()
+			mockSvc.AddEvent(newEvent())
+			handler := NewEventHandler(mockSvc, &MockShowServiceForEvent{}, &MockEventAccessService{authorizeErr: tt.authorizeErr})
+			router := setupRouter(handler)
+
+			req, _ := http.NewRequest(tt.method, tt.path, bytes.NewBufferString(`{"name":"Renamed"}`))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			if resp.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, resp.Code, resp.Body.String())
+			}
+			if tt.wantStatus == http.StatusForbidden {
+				if event, _ := mockSvc.GetEventByID(context.Background(), "event-1"); event.Name != "Test Event" || event.Status != domain.EventStatusDraft {
+					t.Errorf("event was modified despite the forbidden response: %+v", event)
+				}
+			}
+		})
+	}
+}
+
+func TestEventHandler_UpdateRequiresUser(t *testing.T) {
+	mockSvc := NewMockEventService()
+	handler := NewEventHandler(mockSvc, &MockShowServiceForEvent{}, &MockEventAccessService{})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.PUT("/events/:id", handler.Update)
+
+	req, _ := http.NewRequest(http.MethodPut, "/events/event-1", bytes.NewBufferString(`{}`))
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	if resp.Code != http.StatusUnauthorized {
+		t.Errorf("expected status %d, got %d", http.StatusUnauthorized, resp.Code)
+	}
+}
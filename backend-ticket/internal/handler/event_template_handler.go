@@ -0,0 +1,285 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/response"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// ErrCodeEventTemplateNotFound is returned when a template does not exist in the caller's tenant
+const ErrCodeEventTemplateNotFound = "EVENT_TEMPLATE_NOT_FOUND"
+
+// EventTemplateHandler handles event cloning and event template HTTP requests
+type EventTemplateHandler struct {
+	templateService service.EventTemplateService
+}
+
+// NewEventTemplateHandler creates a new EventTemplateHandler
+func NewEventTemplateHandler(templateService service.EventTemplateService) *EventTemplateHandler {
+	return &EventTemplateHandler{
+		templateService: templateService,
+	}
+}
+
+// Clone handles POST /events/:id/clone - copies an event with its shows and zones into a new draft event
+func (h *EventTemplateHandler) Clone(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.event_template.clone")
+	defer span.End()
+
+	eventID := c.Param("id")
+	actor, ok := actorFromContext(c)
+	if !ok {
+		span.SetStatus(codes.Error, "user ID not found in token")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User ID not found in token"))
+		return
+	}
+
+	var req dto.CloneEventRequest
+	// The body is optional; without one the clone keeps the source dates
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "invalid request body")
+			c.JSON(http.StatusBadRequest, response.BadRequest("Invalid request body"))
+			return
+		}
+	}
+	if valid, msg := req.Validate(); !valid {
+		span.SetStatus(codes.Error, "validation failed")
+		c.JSON(http.StatusBadRequest, response.BadRequest(msg))
+		return
+	}
+
+	span.SetAttributes(attribute.String("event_id", eventID))
+
+	event, err := h.templateService.CloneEvent(ctx, actor, eventID, &req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to clone event")
+		return
+	}
+
+	span.SetAttributes(attribute.String("clone_id", event.ID))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusCreated, response.Success(toEventResponse(event, domain.ShowStatusScheduled)))
+}
+
+// Create handles POST /events/templates - saves an event's setup as a template
+func (h *EventTemplateHandler) Create(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.event_template.create")
+	defer span.End()
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		span.SetStatus(codes.Error, "user ID not found in token")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User ID not found in token"))
+		return
+	}
+
+	var req dto.CreateEventTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request body")
+		c.JSON(http.StatusBadRequest, response.BadRequest("Invalid request body"))
+		return
+	}
+	if valid, msg := req.Validate(); !valid {
+		span.SetStatus(codes.Error, "validation failed")
+		c.JSON(http.StatusBadRequest, response.BadRequest(msg))
+		return
+	}
+
+	span.SetAttributes(attribute.String("event_id", req.EventID))
+
+	template, err := h.templateService.CreateTemplate(ctx, actor, &req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to create event template")
+		return
+	}
+
+	span.SetAttributes(attribute.String("template_id", template.ID))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusCreated, response.Success(toEventTemplateResponse(template)))
+}
+
+// List handles GET /events/templates - lists the templates of the caller's tenant
+func (h *EventTemplateHandler) List(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.event_template.list")
+	defer span.End()
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		span.SetStatus(codes.Error, "user ID not found in token")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User ID not found in token"))
+		return
+	}
+
+	templates, err := h.templateService.ListTemplates(ctx, actor)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to list event templates")
+		return
+	}
+
+	resp := make([]*dto.EventTemplateResponse, len(templates))
+	for i, template := range templates {
+		resp[i] = toEventTemplateResponse(template)
+	}
+
+	span.SetAttributes(attribute.Int("count", len(templates)))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(resp))
+}
+
+// Get handles GET /events/templates/:template_id - retrieves a template
+func (h *EventTemplateHandler) Get(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.event_template.get")
+	defer span.End()
+
+	templateID := c.Param("template_id")
+	actor, ok := actorFromContext(c)
+	if !ok {
+		span.SetStatus(codes.Error, "user ID not found in token")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User ID not found in token"))
+		return
+	}
+
+	span.SetAttributes(attribute.String("template_id", templateID))
+
+	template, err := h.templateService.GetTemplate(ctx, actor, templateID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to get event template")
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(toEventTemplateResponse(template)))
+}
+
+// Delete handles DELETE /events/templates/:template_id - deletes a template
+func (h *EventTemplateHandler) Delete(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.event_template.delete")
+	defer span.End()
+
+	templateID := c.Param("template_id")
+	actor, ok := actorFromContext(c)
+	if !ok {
+		span.SetStatus(codes.Error, "user ID not found in token")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User ID not found in token"))
+		return
+	}
+
+	span.SetAttributes(attribute.String("template_id", templateID))
+
+	if err := h.templateService.DeleteTemplate(ctx, actor, templateID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to delete event template")
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(map[string]string{"message": "Event template deleted successfully"}))
+}
+
+// Instantiate handles POST /events/templates/:template_id/instantiate - creates a draft event from a template
+func (h *EventTemplateHandler) Instantiate(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.event_template.instantiate")
+	defer span.End()
+
+	templateID := c.Param("template_id")
+	actor, ok := actorFromContext(c)
+	if !ok {
+		span.SetStatus(codes.Error, "user ID not found in token")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User ID not found in token"))
+		return
+	}
+
+	var req dto.InstantiateEventTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request body")
+		c.JSON(http.StatusBadRequest, response.BadRequest("Invalid request body"))
+		return
+	}
+	if valid, msg := req.Validate(); !valid {
+		span.SetStatus(codes.Error, "validation failed")
+		c.JSON(http.StatusBadRequest, response.BadRequest(msg))
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("template_id", templateID),
+		attribute.String("first_show_date", req.FirstShowDate),
+	)
+
+	event, err := h.templateService.InstantiateTemplate(ctx, actor, templateID, &req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to create event from template")
+		return
+	}
+
+	span.SetAttributes(attribute.String("event_id", event.ID))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusCreated, response.Success(toEventResponse(event, domain.ShowStatusScheduled)))
+}
+
+// handleError maps event template service errors to responses
+func (h *EventTemplateHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrEventNotFound):
+		c.JSON(http.StatusNotFound, response.NotFound("Event not found"))
+	case errors.Is(err, service.ErrEventTemplateNotFound):
+		c.JSON(http.StatusNotFound, response.Error(ErrCodeEventTemplateNotFound, "Event template not found"))
+	case errors.Is(err, service.ErrUnauthorized):
+		c.JSON(http.StatusForbidden, response.Forbidden("Not allowed to perform this action"))
+	case errors.Is(err, service.ErrFirstShowDateRequired):
+		c.JSON(http.StatusBadRequest, response.BadRequest("first_show_date is required for templates with shows"))
+	default:
+		c.JSON(http.StatusInternalServerError, response.InternalError(fallback))
+	}
+}
+
+// toEventTemplateResponse converts a domain event template to response DTO
+func toEventTemplateResponse(template *domain.EventTemplate) *dto.EventTemplateResponse {
+	resp := &dto.EventTemplateResponse{
+		ID:            template.ID,
+		TenantID:      template.TenantID,
+		Name:          template.Name,
+		Description:   template.Description,
+		SourceEventID: template.SourceEventID,
+		Blueprint:     template.Blueprint,
+		CreatedBy:     template.CreatedBy,
+		CreatedAt:     template.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:     template.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+
+	if template.Blueprint != nil {
+		resp.ShowCount = len(template.Blueprint.Shows)
+		for _, show := range template.Blueprint.Shows {
+			resp.ZoneCount += len(show.Zones)
+		}
+		if first, ok := template.Blueprint.FirstShowDate(); ok {
+			date := first.Format("2006-01-02")
+			resp.FirstShowDate = &date
+		}
+	}
+
+	return resp
+}
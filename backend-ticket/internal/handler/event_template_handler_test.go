@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/service"
+)
+
+// MockEventTemplateService is a mock implementation of EventTemplateService
+type MockEventTemplateService struct {
+	err error
+}
+
+func (m *MockEventTemplateService) CloneEvent(ctx context.Context, actor *domain.Actor, eventID string, req *dto.CloneEventRequest) (*domain.Event, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.Event{ID: "clone-1", OrganizerID: actor.UserID, Name: "Jazz Night (Copy)", Status: domain.EventStatusDraft}, nil
+}
+
+func (m *MockEventTemplateService) CreateTemplate(ctx context.Context, actor *domain.Actor, req *dto.CreateEventTemplateRequest) (*domain.EventTemplate, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.template(req.Name), nil
+}
+
+func (m *MockEventTemplateService) ListTemplates(ctx context.Context, actor *domain.Actor) ([]*domain.EventTemplate, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []*domain.EventTemplate{m.template("Weekly jazz")}, nil
+}
+
+func (m *MockEventTemplateService) GetTemplate(ctx context.Context, actor *domain.Actor, id string) (*domain.EventTemplate, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.template("Weekly jazz"), nil
+}
+
+func (m *MockEventTemplateService) DeleteTemplate(ctx context.Context, actor *domain.Actor, id string) error {
+	return m.err
+}
+
+func (m *MockEventTemplateService) InstantiateTemplate(ctx context.Context, actor *domain.Actor, id string, req *dto.InstantiateEventTemplateRequest) (*domain.Event, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.Event{ID: "event-2", OrganizerID: actor.UserID, Status: domain.EventStatusDraft}, nil
+}
+
+func (m *MockEventTemplateService) template(name string) *domain.EventTemplate {
+	return &domain.EventTemplate{
+		ID:       "template-1",
+		TenantID: "tenant-1",
+		Name:     name,
+		Blueprint: &domain.EventBlueprint{Shows: []*domain.ShowBlueprint{
+			{ShowDate: time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC), Zones: []*domain.ZoneBlueprint{{Name: "VIP"}, {Name: "GA"}}},
+			{ShowDate: time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC)},
+		}},
+	}
+}
+
+func setupEventTemplateRouter(h *EventTemplateHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(withActor)
+
+	events := router.Group("/events")
+	{
+		events.POST("/:id/clone", h.Clone)
+		events.GET("/templates", h.List)
+		events.POST("/templates", h.Create)
+		events.GET("/templates/:template_id", h.Get)
+		events.DELETE("/templates/:template_id", h.Delete)
+		events.POST("/templates/:template_id/instantiate", h.Instantiate)
+		events.GET("/:id", func(c *gin.Context) { c.Status(http.StatusTeapot) })
+	}
+
+	return router
+}
+
+func TestEventTemplateHandler_Clone(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{"no body", "", nil, http.StatusCreated},
+		{"offset", `{"offset_days":7}`, nil, http.StatusCreated},
+		{"offset and date", `{"offset_days":7,"first_show_date":"2026-04-10"}`, nil, http.StatusBadRequest},
+		{"invalid date", `{"first_show_date":"10/04/2026"}`, nil, http.StatusBadRequest},
+		{"not allowed", `{}`, service.ErrUnauthorized, http.StatusForbidden},
+		{"unknown event", `{}`, service.ErrEventNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupEventTemplateRouter(NewEventTemplateHandler(&MockEventTemplateService{err: tt.err}))
+
+			req, _ := http.NewRequest(http.MethodPost, "/events/event-1/clone", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			if resp.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, resp.Code, resp.Body.String())
+			}
+		})
+	}
+}
+
+func TestEventTemplateHandler_Get(t *testing.T) {
+	router := setupEventTemplateRouter(NewEventTemplateHandler(&MockEventTemplateService{}))
+
+	req, _ := http.NewRequest(http.MethodGet, "/events/templates/template-1", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	if resp.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, resp.Code)
+	}
+	var body struct {
+		Data dto.EventTemplateResponse `json:"data"`
+	}
+	if err := json.Unmarshal(resp.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if body.Data.ShowCount != 2 || body.Data.ZoneCount != 2 || body.Data.FirstShowDate == nil || *body.Data.FirstShowDate != "2026-03-13" {
+		t.Errorf("unexpected template: %+v", body.Data)
+	}
+
+	// Event IDs still route to the event handler
+	req, _ = http.NewRequest(http.MethodGet, "/events/event-1", nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusTeapot {
+		t.Errorf("expected /events/:id to be routed to the event handler, got %d", resp.Code)
+	}
+}
+
+func TestEventTemplateHandler_Instantiate(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{"valid", `{"first_show_date":"2026-05-01"}`, nil, http.StatusCreated},
+		{"invalid date", `{"first_show_date":"May 1"}`, nil, http.StatusBadRequest},
+		{"date required", `{}`, service.ErrFirstShowDateRequired, http.StatusBadRequest},
+		{"unknown template", `{"first_show_date":"2026-05-01"}`, service.ErrEventTemplateNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupEventTemplateRouter(NewEventTemplateHandler(&MockEventTemplateService{err: tt.err}))
+
+			req, _ := http.NewRequest(http.MethodPost, "/events/templates/template-1/instantiate", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			if resp.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, resp.Code, resp.Body.String())
+			}
+		})
+	}
+}
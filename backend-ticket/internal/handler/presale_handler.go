@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/response"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// Presale error codes
+const (
+	ErrCodePresaleNotStarted    = "PRESALE_NOT_STARTED"
+	ErrCodePresaleCodeExpired   = "PRESALE_CODE_EXPIRED"
+	ErrCodePresaleCodeExhausted = "PRESALE_CODE_EXHAUSTED"
+	ErrCodePresaleCodeNotFound  = "PRESALE_CODE_NOT_FOUND"
+	ErrCodePresaleBatchNotFound = "PRESALE_BATCH_NOT_FOUND"
+	ErrCodePresaleUnavailable   = "PRESALE_UNAVAILABLE"
+)
+
+// PresaleHandler handles presale access code HTTP requests
+type PresaleHandler struct {
+	presaleService service.PresaleService
+}
+
+// NewPresaleHandler creates a new PresaleHandler
+func NewPresaleHandler(presaleService service.PresaleService) *PresaleHandler {
+	return &PresaleHandler{
+		presaleService: presaleService,
+	}
+}
+
+// CreateBatch handles POST /events/:id/presale/batches - generates a batch of presale codes
+func (h *PresaleHandler) CreateBatch(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.presale.create_batch")
+	defer span.End()
+
+	eventID := c.Param("id")
+	actor, ok := actorFromContext(c)
+	if !ok {
+		span.SetStatus(codes.Error, "user ID not found in token")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User ID not found in token"))
+		return
+	}
+
+	var req dto.CreatePresaleBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request body")
+		c.JSON(http.StatusBadRequest, response.BadRequest("Invalid request body"))
+		return
+	}
+	if valid, msg := req.Validate(); !valid {
+		span.SetStatus(codes.Error, "validation failed")
+		c.JSON(http.StatusBadRequest, response.BadRequest(msg))
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("event_id", eventID),
+		attribute.Int("count", req.Count),
+	)
+
+	batch, presaleCodes, err := h.presaleService.CreateBatch(ctx, actor, eventID, &req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to create presale batch")
+		return
+	}
+
+	resp := toPresaleBatchResponse(batch)
+	resp.Codes = make([]string, len(presaleCodes))
+	for i, code := range presaleCodes {
+		resp.Codes[i] = code.Code
+	}
+
+	span.SetAttributes(attribute.String("batch_id", batch.ID))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusCreated, response.Success(resp))
+}
+
+// ListBatches handles GET /events/:id/presale/batches - reports redemption rates per batch
+func (h *PresaleHandler) ListBatches(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.presale.list_batches")
+	defer span.End()
+
+	eventID := c.Param("id")
+	actor, ok := actorFromContext(c)
+	if !ok {
+		span.SetStatus(codes.Error, "user ID not found in token")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User ID not found in token"))
+		return
+	}
+
+	span.SetAttributes(attribute.String("event_id", eventID))
+
+	stats, err := h.presaleService.ListBatchStats(ctx, actor, eventID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to list presale batches")
+		return
+	}
+
+	resp := make([]*dto.PresaleBatchReportResponse, len(stats))
+	for i, s := range stats {
+		resp[i] = &dto.PresaleBatchReportResponse{
+			Batch:          toPresaleBatchResponse(s.Batch),
+			Codes:          s.Codes,
+			CodesRedeemed:  s.CodesRedeemed,
+			Redemptions:    s.Redemptions,
+			Capacity:       s.Capacity(),
+			RedemptionRate: s.RedemptionRate(),
+		}
+	}
+
+	span.SetAttributes(attribute.Int("count", len(stats)))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(resp))
+}
+
+// ListCodes handles GET /events/:id/presale/batches/:batch_id/codes - lists a batch's codes
+func (h *PresaleHandler) ListCodes(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.presale.list_codes")
+	defer span.End()
+
+	eventID := c.Param("id")
+	batchID := c.Param("batch_id")
+	actor, ok := actorFromContext(c)
+	if !ok {
+		span.SetStatus(codes.Error, "user ID not found in token")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User ID not found in token"))
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("event_id", eventID),
+		attribute.String("batch_id", batchID),
+	)
+
+	presaleCodes, err := h.presaleService.ListBatchCodes(ctx, actor, eventID, batchID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to list presale codes")
+		return
+	}
+
+	resp := make([]*dto.PresaleCodeResponse, len(presaleCodes))
+	for i, code := range presaleCodes {
+		resp[i] = &dto.PresaleCodeResponse{
+			Code:          code.Code,
+			MaxUses:       code.MaxUses,
+			RedeemedCount: code.RedeemedCount,
+		}
+	}
+
+	span.SetAttributes(attribute.Int("count", len(presaleCodes)))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(resp))
+}
+
+// Validate handles POST /events/:id/presale/validate - checks a presale code for the
+// caller, consuming one of its uses if redeem is set (queue join / reserve)
+func (h *PresaleHandler) Validate(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.presale.validate")
+	defer span.End()
+
+	eventID := c.Param("id")
+	actor, ok := actorFromContext(c)
+	if !ok {
+		span.SetStatus(codes.Error, "user ID not found in token")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User ID not found in token"))
+		return
+	}
+
+	var req dto.ValidatePresaleCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request body")
+		c.JSON(http.StatusBadRequest, response.BadRequest("Presale code is required"))
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("event_id", eventID),
+		attribute.Bool("redeem", req.Redeem),
+	)
+
+	code, redemption, err := h.presaleService.ValidateCode(ctx, eventID, actor.UserID, req.Code, req.Redeem)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to validate presale code")
+		return
+	}
+
+	usesRemaining := int64(code.MaxUses) - redemption.Uses
+	if usesRemaining < 0 {
+		usesRemaining = 0
+	}
+
+	span.SetAttributes(attribute.String("batch_id", code.BatchID))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(&dto.PresaleValidationResponse{
+		Valid:           true,
+		Redeemed:        redemption.Redeemed || redemption.AlreadyRedeemed,
+		AlreadyRedeemed: redemption.AlreadyRedeemed,
+		BatchID:         code.BatchID,
+		UsesRemaining:   usesRemaining,
+		ValidUntil:      code.ValidUntil.Format("2006-01-02T15:04:05Z07:00"),
+	}))
+}
+
+// handleError maps presale service errors to responses
+func (h *PresaleHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrEventNotFound):
+		c.JSON(http.StatusNotFound, response.NotFound("Event not found"))
+	case errors.Is(err, service.ErrUnauthorized):
+		c.JSON(http.StatusForbidden, response.Forbidden("Missing permission "+domain.PermissionEventEdit+" for this event"))
+	case errors.Is(err, service.ErrPresaleBatchNotFound):
+		c.JSON(http.StatusNotFound, response.Error(ErrCodePresaleBatchNotFound, "Presale batch not found"))
+	case errors.Is(err, service.ErrPresaleCodeNotFound):
+		c.JSON(http.StatusNotFound, response.Error(ErrCodePresaleCodeNotFound, "Presale code not found"))
+	case errors.Is(err, service.ErrPresaleCodeNotActive):
+		c.JSON(http.StatusForbidden, response.Error(ErrCodePresaleNotStarted, "Presale code is not valid yet"))
+	case errors.Is(err, service.ErrPresaleCodeExpired):
+		c.JSON(http.StatusGone, response.Error(ErrCodePresaleCodeExpired, "Presale code has expired"))
+	case errors.Is(err, service.ErrPresaleCodeExhausted):
+		c.JSON(http.StatusConflict, response.Error(ErrCodePresaleCodeExhausted, "Presale code has no uses left"))
+	case errors.Is(err, service.ErrPresaleUnavailable):
+		c.JSON(http.StatusServiceUnavailable, response.Error(ErrCodePresaleUnavailable, "Presale redemption is temporarily unavailable"))
+	default:
+		c.JSON(http.StatusInternalServerError, response.InternalError(fallback))
+	}
+}
+
+// toPresaleBatchResponse converts a domain presale batch to response DTO
+func toPresaleBatchResponse(batch *domain.PresaleBatch) *dto.PresaleBatchResponse {
+	return &dto.PresaleBatchResponse{
+		ID:             batch.ID,
+		EventID:        batch.EventID,
+		Name:           batch.Name,
+		MaxUsesPerCode: batch.MaxUsesPerCode,
+		ValidFrom:      batch.ValidFrom.Format("2006-01-02T15:04:05Z07:00"),
+		ValidUntil:     batch.ValidUntil.Format("2006-01-02T15:04:05Z07:00"),
+		CreatedBy:      batch.CreatedBy,
+		CreatedAt:      batch.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
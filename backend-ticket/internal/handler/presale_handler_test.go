@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/service"
+)
+
+// MockPresaleService is a mock implementation of PresaleService
+type MockPresaleService struct {
+	err        error
+	redemption *domain.PresaleRedemption
+}
+
+func (m *MockPresaleService) CreateBatch(ctx context.Context, actor *domain.Actor, eventID string, req *dto.CreatePresaleBatchRequest) (*domain.PresaleBatch, []*domain.PresaleCode, error) {
+	if m.err != nil {
+		return nil, nil, m.err
+	}
+	batch := &domain.PresaleBatch{ID: "batch-1", EventID: eventID, Name: req.Name, MaxUsesPerCode: 1}
+	return batch, []*domain.PresaleCode{{Code: "FAN-ABC"}, {Code: "FAN-DEF"}}, nil
+}
+
+func (m *MockPresaleService) ListBatchStats(ctx context.Context, actor *domain.Actor, eventID string) ([]*domain.PresaleBatchStats, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	batch := &domain.PresaleBatch{ID: "batch-1", EventID: eventID, MaxUsesPerCode: 2}
+	return []*domain.PresaleBatchStats{{Batch: batch, Codes: 10, CodesRedeemed: 4, Redemptions: 5}}, nil
+}
+
+func (m *MockPresaleService) ListBatchCodes(ctx context.Context, actor *domain.Actor, eventID, batchID string) ([]*domain.PresaleCode, error) {
+	return []*domain.PresaleCode{{Code: "FAN-ABC", MaxUses: 1}}, m.err
+}
+
+func (m *MockPresaleService) ValidateCode(ctx context.Context, eventID, userID, code string, redeem bool) (*domain.PresaleCode, *domain.PresaleRedemption, error) {
+	if m.err != nil {
+		return nil, nil, m.err
+	}
+	return &domain.PresaleCode{BatchID: "batch-1", MaxUses: 3, ValidUntil: time.Now()}, m.redemption, nil
+}
+
+func setupPresaleRouter(h *PresaleHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(withActor)
+
+	events := router.Group("/events")
+	{
+		events.POST("/:id/presale/batches", h.CreateBatch)
+		events.GET("/:id/presale/batches", h.ListBatches)
+		events.GET("/:id/presale/batches/:batch_id/codes", h.ListCodes)
+		events.POST("/:id/presale/validate", h.Validate)
+	}
+
+	return router
+}
+
+func TestPresaleHandler_CreateBatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{"valid batch", `{"name":"Fan club","count":2,"valid_from":"2026-01-01T00:00:00Z","valid_until":"2026-01-02T00:00:00Z"}`, nil, http.StatusCreated},
+		{"window reversed", `{"name":"Fan club","count":2,"valid_from":"2026-01-02T00:00:00Z","valid_until":"2026-01-01T00:00:00Z"}`, nil, http.StatusBadRequest},
+		{"too many codes", `{"name":"Fan club","count":20000,"valid_from":"2026-01-01T00:00:00Z","valid_until":"2026-01-02T00:00:00Z"}`, nil, http.StatusBadRequest},
+		{"invalid prefix", `{"name":"Fan club","count":2,"prefix":"FAN-1","valid_from":"2026-01-01T00:00:00Z","valid_until":"2026-01-02T00:00:00Z"}`, nil, http.StatusBadRequest},
+		{"not allowed", `{"name":"Fan club","count":2,"valid_from":"2026-01-01T00:00:00Z","valid_until":"2026-01-02T00:00:00Z"}`, service.ErrUnauthorized, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupPresaleRouter(NewPresaleHandler(&MockPresaleService{err: tt.err}))
+
+			req, _ := http.NewRequest(http.MethodPost, "/events/event-1/presale/batches", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			if resp.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, resp.Code, resp.Body.String())
+			}
+		})
+	}
+}
+
+func TestPresaleHandler_ListBatches(t *testing.T) {
+	router := setupPresaleRouter(NewPresaleHandler(&MockPresaleService{}))
+
+	req, _ := http.NewRequest(http.MethodGet, "/events/event-1/presale/batches", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	if resp.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, resp.Code)
+	}
+	var body struct {
+		Data []dto.PresaleBatchReportResponse `json:"data"`
+	}
+	if err := json.Unmarshal(resp.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(body.Data) != 1 || body.Data[0].Capacity != 20 || body.Data[0].RedemptionRate != 0.25 {
+		t.Errorf("unexpected report: %+v", body.Data)
+	}
+}
+
+func TestPresaleHandler_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{"valid code", `{"code":"FAN-ABC","redeem":true}`, nil, http.StatusOK},
+		{"missing code", `{}`, nil, http.StatusBadRequest},
+		{"unknown code", `{"code":"NOPE"}`, service.ErrPresaleCodeNotFound, http.StatusNotFound},
+		{"not started", `{"code":"FAN-ABC"}`, service.ErrPresaleCodeNotActive, http.StatusForbidden},
+		{"expired", `{"code":"FAN-ABC"}`, service.ErrPresaleCodeExpired, http.StatusGone},
+		{"exhausted", `{"code":"FAN-ABC","redeem":true}`, service.ErrPresaleCodeExhausted, http.StatusConflict},
+		{"redis down", `{"code":"FAN-ABC","redeem":true}`, service.ErrPresaleUnavailable, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupPresaleRouter(NewPresaleHandler(&MockPresaleService{
+				err:        tt.err,
+				redemption: &domain.PresaleRedemption{Redeemed: true, Uses: 1},
+			}))
+
+			req, _ := http.NewRequest(http.MethodPost, "/events/event-1/presale/validate", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			if resp.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, resp.Code, resp.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/response"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// PriceTierHandler handles zone price tier HTTP requests
+type PriceTierHandler struct {
+	priceTierService service.PriceTierService
+	showZoneService  service.ShowZoneService
+	showService      service.ShowService
+	accessService    service.EventAccessService
+}
+
+// NewPriceTierHandler creates a new PriceTierHandler
+func NewPriceTierHandler(priceTierService service.PriceTierService, showZoneService service.ShowZoneService, showService service.ShowService, accessService service.EventAccessService) *PriceTierHandler {
+	return &PriceTierHandler{
+		priceTierService: priceTierService,
+		showZoneService:  showZoneService,
+		showService:      showService,
+		accessService:    accessService,
+	}
+}
+
+// List handles GET /zones/:id/price-tiers - the zone's price schedule, read by booking-service
+func (h *PriceTierHandler) List(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.price_tier.list")
+	defer span.End()
+
+	zoneID := c.Param("id")
+	span.SetAttributes(attribute.String("zone_id", zoneID))
+
+	tiers, err := h.priceTierService.ListPriceTiers(ctx, zoneID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to list price tiers")
+		return
+	}
+
+	resp := make([]*dto.PriceTierResponse, len(tiers))
+	for i, tier := range tiers {
+		resp[i] = toPriceTierResponse(tier)
+	}
+
+	span.SetAttributes(attribute.Int("count", len(tiers)))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(resp))
+}
+
+// Create handles POST /zones/:id/price-tiers - adds a price tier to a zone
+func (h *PriceTierHandler) Create(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.price_tier.create")
+	defer span.End()
+
+	zoneID := c.Param("id")
+	span.SetAttributes(attribute.String("zone_id", zoneID))
+
+	var req dto.CreatePriceTierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request body")
+		c.JSON(http.StatusBadRequest, response.BadRequest("Invalid request body"))
+		return
+	}
+	if valid, msg := req.Validate(); !valid {
+		span.SetStatus(codes.Error, "validation failed")
+		c.JSON(http.StatusBadRequest, response.BadRequest(msg))
+		return
+	}
+
+	if !authorizeZone(c, h.showZoneService, h.showService, h.accessService, zoneID, domain.PermissionEventEdit) {
+		return
+	}
+
+	tier, err := h.priceTierService.CreatePriceTier(ctx, zoneID, &req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to create price tier")
+		return
+	}
+
+	span.SetAttributes(attribute.String("tier_id", tier.ID))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusCreated, response.Success(toPriceTierResponse(tier)))
+}
+
+// Update handles PUT /zones/:id/price-tiers/:tier_id - replaces a price tier's settings
+func (h *PriceTierHandler) Update(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.price_tier.update")
+	defer span.End()
+
+	zoneID, tierID := c.Param("id"), c.Param("tier_id")
+	span.SetAttributes(attribute.String("zone_id", zoneID), attribute.String("tier_id", tierID))
+
+	var req dto.UpdatePriceTierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request body")
+		c.JSON(http.StatusBadRequest, response.BadRequest("Invalid request body"))
+		return
+	}
+	if valid, msg := req.Validate(); !valid {
+		span.SetStatus(codes.Error, "validation failed")
+		c.JSON(http.StatusBadRequest, response.BadRequest(msg))
+		return
+	}
+
+	if !authorizeZone(c, h.showZoneService, h.showService, h.accessService, zoneID, domain.PermissionEventEdit) {
+		return
+	}
+
+	tier, err := h.priceTierService.UpdatePriceTier(ctx, zoneID, tierID, &req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to update price tier")
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(toPriceTierResponse(tier)))
+}
+
+// Delete handles DELETE /zones/:id/price-tiers/:tier_id - removes a price tier
+func (h *PriceTierHandler) Delete(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.price_tier.delete")
+	defer span.End()
+
+	zoneID, tierID := c.Param("id"), c.Param("tier_id")
+	span.SetAttributes(attribute.String("zone_id", zoneID), attribute.String("tier_id", tierID))
+
+	if !authorizeZone(c, h.showZoneService, h.showService, h.accessService, zoneID, domain.PermissionEventEdit) {
+		return
+	}
+
+	if err := h.priceTierService.DeletePriceTier(ctx, zoneID, tierID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to delete price tier")
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(map[string]string{"message": "Price tier deleted successfully"}))
+}
+
+// handleError maps price tier service errors to HTTP responses
+func (h *PriceTierHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrShowZoneNotFound):
+		c.JSON(http.StatusNotFound, response.NotFound("Zone not found"))
+	case errors.Is(err, service.ErrPriceTierNotFound):
+		c.JSON(http.StatusNotFound, response.NotFound("Price tier not found"))
+	case errors.Is(err, service.ErrInvalidPriceTier):
+		c.JSON(http.StatusBadRequest, response.BadRequest(err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, response.InternalError(fallback))
+	}
+}
+
+// toPriceTierResponse converts a domain price tier to response DTO
+func toPriceTierResponse(tier *domain.ZonePriceTier) *dto.PriceTierResponse {
+	resp := &dto.PriceTierResponse{
+		ID:         tier.ID,
+		ZoneID:     tier.ZoneID,
+		Name:       tier.Name,
+		Price:      tier.Price,
+		Allocation: tier.Allocation,
+		SortOrder:  tier.SortOrder,
+		CreatedAt:  tier.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:  tier.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if tier.ValidFrom != nil {
+		t := tier.ValidFrom.Format("2006-01-02T15:04:05Z07:00")
+		resp.ValidFrom = &t
+	}
+	if tier.ValidUntil != nil {
+		t := tier.ValidUntil.Format("2006-01-02T15:04:05Z07:00")
+		resp.ValidUntil = &t
+	}
+	return resp
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/service"
+)
+
+// MockPriceTierService is a mock implementation of PriceTierService
+type MockPriceTierService struct {
+	writes int // Create, update and delete calls that reached the service
+}
+
+func (m *MockPriceTierService) ListPriceTiers(ctx context.Context, zoneID string) ([]*domain.ZonePriceTier, error) {
+	return nil, nil
+}
+
+func (m *MockPriceTierService) CreatePriceTier(ctx context.Context, zoneID string, req *dto.CreatePriceTierRequest) (*domain.ZonePriceTier, error) {
+	m.writes++
+	return &domain.ZonePriceTier{ID: "tier-1", ZoneID: zoneID, Name: req.Name, Price: req.Price, CreatedAt: time.Now(), UpdatedAt: time.Now()}, nil
+}
+
+func (m *MockPriceTierService) UpdatePriceTier(ctx context.Context, zoneID, tierID string, req *dto.UpdatePriceTierRequest) (*domain.ZonePriceTier, error) {
+	m.writes++
+	return &domain.ZonePriceTier{ID: tierID, ZoneID: zoneID, Name: req.Name, Price: req.Price, CreatedAt: time.Now(), UpdatedAt: time.Now()}, nil
+}
+
+func (m *MockPriceTierService) DeletePriceTier(ctx context.Context, zoneID, tierID string) error {
+	m.writes++
+	return nil
+}
+
+func TestPriceTierHandler_OwnershipChecks(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		authorizeErr error
+		wantStatus   int
+	}{
+		{"create forbidden", http.MethodPost, "/zones/zone-1/price-tiers", service.ErrUnauthorized, http.StatusForbidden},
+		{"update forbidden", http.MethodPut, "/zones/zone-1/price-tiers/tier-1", service.ErrUnauthorized, http.StatusForbidden},
+		{"delete forbidden", http.MethodDelete, "/zones/zone-1/price-tiers/tier-1", service.ErrUnauthorized, http.StatusForbidden},
+		{"other tenant hidden", http.MethodPost, "/zones/zone-1/price-tiers", service.ErrEventNotFound, http.StatusNotFound},
+		{"create allowed", http.MethodPost, "/zones/zone-1/price-tiers", nil, http.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockZoneSvc := NewMockShowZoneService()
+			mockZoneSvc.AddZone(&domain.ShowZone{ID: "zone-1", ShowID: "show-1", Name: "VIP Zone", Price: 100, TotalSeats: 50, AvailableSeats: 50})
+			mockShowSvc := NewMockShowServiceForZone()
+			mockShowSvc.AddShow(&domain.Show{ID: "show-1", EventID: "event-1"})
+			access := &MockEventAccessService{authorizeErr: tt.authorizeErr}
+			tierSvc := &MockPriceTierService{}
+			h := NewPriceTierHandler(tierSvc, mockZoneSvc, mockShowSvc, access)
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.Use(withActor)
+			router.POST("/zones/:id/price-tiers", h.Create)
+			router.PUT("/zones/:id/price-tiers/:tier_id", h.Update)
+			router.DELETE("/zones/:id/price-tiers/:tier_id", h.Delete)
+
+			req, _ := http.NewRequest(tt.method, tt.path, bytes.NewBufferString(`{"name":"Early Bird","price":50}`))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			if resp.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, resp.Code, resp.Body.String())
+			}
+			if len(access.authorized) != 1 || access.authorized[0] != "event-1" {
+				t.Errorf("access checked on %v, want the zone's event", access.authorized)
+			}
+			if tt.authorizeErr != nil && tierSvc.writes != 0 {
+				t.Errorf("price tiers were written %d times despite the rejected request", tierSvc.writes)
+			}
+		})
+	}
+}
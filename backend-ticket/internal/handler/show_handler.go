@@ -15,17 +15,20 @@ import (
 	"go.opentelemetry.io/otel/codes"
 )
 
-// ShowHandler handles show-related HTTP requests
+// ShowHandler handles show-related HTTP requests. Writes require events:edit on the
+// show's event.
 type ShowHandler struct {
-	showService  service.ShowService
-	eventService service.EventService
+	showService   service.ShowService
+	eventService  service.EventService
+	accessService service.EventAccessService
 }
 
 // NewShowHandler creates a new ShowHandler
-func NewShowHandler(showService service.ShowService, eventService service.EventService) *ShowHandler {
+func NewShowHandler(showService service.ShowService, eventService service.EventService, accessService service.EventAccessService) *ShowHandler {
 	return &ShowHandler{
-		showService:  showService,
-		eventService: eventService,
+		showService:   showService,
+		eventService:  eventService,
+		accessService: accessService,
 	}
 }
 
@@ -124,6 +127,10 @@ func (h *ShowHandler) Create(c *gin.Context) {
 		return
 	}
 
+	if !authorizeEvent(c, h.accessService, eventID, domain.PermissionEventEdit) {
+		return
+	}
+
 	show, err := h.showService.CreateShow(ctx, &req)
 	if err != nil {
 		span.RecordError(err)
@@ -213,6 +220,10 @@ func (h *ShowHandler) Update(c *gin.Context) {
 		return
 	}
 
+	if !authorizeShow(c, h.showService, h.accessService, id, domain.PermissionEventEdit) {
+		return
+	}
+
 	show, err := h.showService.UpdateShow(ctx, id, &req)
 	if err != nil {
 		span.RecordError(err)
@@ -252,6 +263,10 @@ func (h *ShowHandler) Delete(c *gin.Context) {
 		return
 	}
 
+	if !authorizeShow(c, h.showService, h.accessService, id, domain.PermissionEventEdit) {
+		return
+	}
+
 	err := h.showService.DeleteShow(ctx, id)
 	if err != nil {
 		span.RecordError(err)
@@ -269,6 +284,21 @@ func (h *ShowHandler) Delete(c *gin.Context) {
 	c.JSON(http.StatusOK, response.Success(map[string]string{"message": "Show deleted successfully"}))
 }
 
+// authorizeShow checks that the caller holds permission on the show's event and writes
+// the error response if not. Returns false when the request must stop.
+func authorizeShow(c *gin.Context, showService service.ShowService, accessService service.EventAccessService, showID, permission string) bool {
+	show, err := showService.GetShowByID(c.Request.Context(), showID)
+	if err != nil {
+		if errors.Is(err, service.ErrShowNotFound) {
+			c.JSON(http.StatusNotFound, response.NotFound("Show not found"))
+			return false
+		}
+		c.JSON(http.StatusInternalServerError, response.InternalError("Failed to get show"))
+		return false
+	}
+	return authorizeEvent(c, accessService, show.EventID, permission)
+}
+
 // isValidationError checks if error is a validation error (should return 400 BadRequest)
 func isValidationError(err error) bool {
 	msg := err.Error()
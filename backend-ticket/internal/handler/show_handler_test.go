@@ -13,6 +13,7 @@ import (
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 )
 
 // MockShowService is a mock implementation of ShowService
@@ -126,8 +127,8 @@ func (m *MockEventServiceForShow) GetEventBySlug(ctx context.Context, slug strin
 	return nil, service.ErrEventNotFound
 }
 
-func (m *MockEventServiceForShow) ListEvents(ctx context.Context, filter *dto.EventListFilter) ([]*domain.Event, int, error) {
-	return nil, 0, nil
+func (m *MockEventServiceForShow) ListEvents(ctx context.Context, filter *dto.EventListFilter) ([]*domain.Event, int, pagination.Info, error) {
+	return nil, 0, pagination.Info{}, nil
 }
 
 func (m *MockEventServiceForShow) UpdateEvent(ctx context.Context, id string, req *dto.UpdateEventRequest) (*domain.Event, error) {
@@ -142,14 +143,14 @@ func (m *MockEventServiceForShow) PublishEvent(ctx context.Context, id string) (
 	return nil, nil
 }
 
-func (m *MockEventServiceForShow) ListPublishedEvents(ctx context.Context, limit, offset int) ([]*domain.Event, int, error) {
+func (m *MockEventServiceForShow) ListPublishedEvents(ctx context.Context, page pagination.Params) ([]*domain.Event, int, pagination.Info, error) {
 	var events []*domain.Event
 	for _, e := range m.events {
 		if e.Status == domain.EventStatusPublished {
 			events = append(events, e)
 		}
 	}
-	return events, len(events), nil
+	return events, len(events), pagination.Info{}, nil
 }
 
 func (m *MockEventServiceForShow) AddEvent(event *domain.Event) {
@@ -159,6 +160,7 @@ func (m *MockEventServiceForShow) AddEvent(event *domain.Event) {
 func setupShowRouter(h *ShowHandler) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(withActor)
 
 	events := router.Group("/events")
 	{
@@ -179,7 +181,7 @@ func setupShowRouter(h *ShowHandler) *gin.Engine {
 func TestShowHandler_ListByEvent(t *testing.T) {
 	mockShowSvc := NewMockShowService()
 	mockEventSvc := NewMockEventServiceForShow()
-	handler := NewShowHandler(mockShowSvc, mockEventSvc)
+	handler := NewShowHandler(mockShowSvc, mockEventSvc, &MockEventAccessService{})
 	router := setupShowRouter(handler)
 
 	// Add test event
@@ -238,7 +240,7 @@ func TestShowHandler_ListByEvent(t *testing.T) {
 func TestShowHandler_Create(t *testing.T) {
 	mockShowSvc := NewMockShowService()
 	mockEventSvc := NewMockEventServiceForShow()
-	handler := NewShowHandler(mockShowSvc, mockEventSvc)
+	handler := NewShowHandler(mockShowSvc, mockEventSvc, &MockEventAccessService{})
 	router := setupShowRouter(handler)
 
 	tests := []struct {
@@ -288,7 +290,7 @@ func TestShowHandler_Create(t *testing.T) {
 func TestShowHandler_GetByID(t *testing.T) {
 	mockShowSvc := NewMockShowService()
 	mockEventSvc := NewMockEventServiceForShow()
-	handler := NewShowHandler(mockShowSvc, mockEventSvc)
+	handler := NewShowHandler(mockShowSvc, mockEventSvc, &MockEventAccessService{})
 	router := setupShowRouter(handler)
 
 	// Add test show
@@ -337,7 +339,7 @@ func TestShowHandler_GetByID(t *testing.T) {
 func TestShowHandler_Update(t *testing.T) {
 	mockShowSvc := NewMockShowService()
 	mockEventSvc := NewMockEventServiceForShow()
-	handler := NewShowHandler(mockShowSvc, mockEventSvc)
+	handler := NewShowHandler(mockShowSvc, mockEventSvc, &MockEventAccessService{})
 	router := setupShowRouter(handler)
 
 	// Add test show
@@ -401,7 +403,7 @@ func TestShowHandler_Update(t *testing.T) {
 func TestShowHandler_Delete(t *testing.T) {
 	mockShowSvc := NewMockShowService()
 	mockEventSvc := NewMockEventServiceForShow()
-	handler := NewShowHandler(mockShowSvc, mockEventSvc)
+	handler := NewShowHandler(mockShowSvc, mockEventSvc, &MockEventAccessService{})
 	router := setupShowRouter(handler)
 
 	// Add test show
@@ -446,3 +448,46 @@ func TestShowHandler_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestShowHandler_OwnershipChecks(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		authorizeErr error
+		wantStatus   int
+	}{
+		{"create forbidden", http.MethodPost, "/events/event-1/shows", service.ErrUnauthorized, http.StatusForbidden},
+		{"update forbidden", http.MethodPut, "/shows/show-1", service.ErrUnauthorized, http.StatusForbidden},
+		{"delete forbidden", http.MethodDelete, "/shows/show-1", service.ErrUnauthorized, http.StatusForbidden},
+		{"other tenant hidden", http.MethodPut, "/shows/show-1", service.ErrEventNotFound, http.StatusNotFound},
+		{"update allowed", http.MethodPut, "/shows/show-1", nil, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockShowSvc := NewMockShowService()
+			mockShowSvc.AddShow(&domain.Show{ID: "show-1", EventID: "event-1", Name: "Morning Show", Status: domain.ShowStatusScheduled})
+			access := &MockEventAccessService{authorizeErr: tt.authorizeErr}
+			router := setupShowRouter(NewShowHandler(mockShowSvc, NewMockEventServiceForShow(), access))
+
+			body := `{"name":"Renamed","show_date":"2024-12-25","start_time":"19:00:00","end_time":"22:00:00"}`
+			req, _ := http.NewRequest(tt.method, tt.path, bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			if resp.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, resp.Code, resp.Body.String())
+			}
+			if len(access.authorized) != 1 || access.authorized[0] != "event-1" {
+				t.Errorf("access checked on %v, want the show's event", access.authorized)
+			}
+			if tt.authorizeErr != nil {
+				if show, err := mockShowSvc.GetShowByID(context.Background(), "show-1"); err != nil || show.Name != "Morning Show" || len(mockShowSvc.shows) != 1 {
+					t.Errorf("shows were modified despite the rejected request: %+v", mockShowSvc.shows)
+				}
+			}
+		})
+	}
+}
@@ -14,17 +14,20 @@ import (
 	"go.opentelemetry.io/otel/codes"
 )
 
-// ShowZoneHandler handles show zone-related HTTP requests
+// ShowZoneHandler handles show zone-related HTTP requests. Writes and capacity status
+// reads require events:edit on the zone's event.
 type ShowZoneHandler struct {
 	showZoneService service.ShowZoneService
 	showService     service.ShowService
+	accessService   service.EventAccessService
 }
 
 // NewShowZoneHandler creates a new ShowZoneHandler
-func NewShowZoneHandler(showZoneService service.ShowZoneService, showService service.ShowService) *ShowZoneHandler {
+func NewShowZoneHandler(showZoneService service.ShowZoneService, showService service.ShowService, accessService service.EventAccessService) *ShowZoneHandler {
 	return &ShowZoneHandler{
 		showZoneService: showZoneService,
 		showService:     showService,
+		accessService:   accessService,
 	}
 }
 
@@ -109,6 +112,10 @@ func (h *ShowZoneHandler) Create(c *gin.Context) {
 		return
 	}
 
+	if !authorizeShow(c, h.showService, h.accessService, showID, domain.PermissionEventEdit) {
+		return
+	}
+
 	zone, err := h.showZoneService.CreateShowZone(ctx, &req)
 	if err != nil {
 		span.RecordError(err)
@@ -192,6 +199,10 @@ func (h *ShowZoneHandler) Update(c *gin.Context) {
 		return
 	}
 
+	if !authorizeZone(c, h.showZoneService, h.showService, h.accessService, id, domain.PermissionEventEdit) {
+		return
+	}
+
 	zone, err := h.showZoneService.UpdateShowZone(ctx, id, &req)
 	if err != nil {
 		span.RecordError(err)
@@ -200,15 +211,82 @@ func (h *ShowZoneHandler) Update(c *gin.Context) {
 			c.JSON(http.StatusNotFound, response.NotFound("Zone not found"))
 			return
 		}
+		if errors.Is(err, service.ErrCapacityBelowCommitted) {
+			span.SetStatus(codes.Error, "Capacity below committed seats")
+			c.JSON(http.StatusConflict, response.Error(response.ErrCodeConflict, err.Error()))
+			return
+		}
+		if errors.Is(err, service.ErrCapacityChangeUnavailable) {
+			span.SetStatus(codes.Error, "Capacity change unavailable")
+			c.JSON(http.StatusServiceUnavailable, response.Error(response.ErrCodeServiceUnavailable, service.ErrCapacityChangeUnavailable.Error()))
+			return
+		}
 		span.SetStatus(codes.Error, "Failed to update zone")
 		c.JSON(http.StatusInternalServerError, response.InternalError("Failed to update zone"))
 		return
 	}
 
 	span.SetStatus(codes.Ok, "")
+	// The capacity change is applied asynchronously by the inventory worker
+	if zone.PendingTotalSeats != nil {
+		c.JSON(http.StatusAccepted, response.Success(toShowZoneResponse(zone)))
+		return
+	}
 	c.JSON(http.StatusOK, response.Success(toShowZoneResponse(zone)))
 }
 
+// GetCapacityStatus handles GET /zones/:id/capacity-status - outcome of the latest capacity change
+func (h *ShowZoneHandler) GetCapacityStatus(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.show_zone.GetCapacityStatus")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	id := c.Param("id")
+	span.SetAttributes(attribute.String("zone_id", id))
+
+	if id == "" {
+		span.RecordError(errors.New("ID is required"))
+		span.SetStatus(codes.Error, "ID is required")
+		c.JSON(http.StatusBadRequest, response.BadRequest("ID is required"))
+		return
+	}
+
+	if !authorizeZone(c, h.showZoneService, h.showService, h.accessService, id, domain.PermissionEventEdit) {
+		return
+	}
+
+	status, err := h.showZoneService.GetCapacityStatus(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, service.ErrShowZoneNotFound) {
+			span.SetStatus(codes.Error, "Zone not found")
+			c.JSON(http.StatusNotFound, response.NotFound("Zone not found"))
+			return
+		}
+		if errors.Is(err, service.ErrCapacityStatusNotFound) {
+			span.SetStatus(codes.Error, "No capacity change recorded")
+			c.JSON(http.StatusNotFound, response.NotFound("No capacity change recorded"))
+			return
+		}
+		span.SetStatus(codes.Error, "Failed to get capacity status")
+		c.JSON(http.StatusInternalServerError, response.InternalError("Failed to get capacity status"))
+		return
+	}
+
+	span.SetAttributes(attribute.String("capacity_status", status.Status))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(&dto.ZoneCapacityStatusResponse{
+		EventID:        status.EventID,
+		ZoneID:         status.ZoneID,
+		Status:         status.Status,
+		PreviousTotal:  status.PreviousTotal,
+		RequestedTotal: status.RequestedTotal,
+		AvailableSeats: status.AvailableSeats,
+		Reason:         status.Reason,
+		UpdatedAt:      status.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}))
+}
+
 // Delete handles DELETE /zones/:id - soft deletes a zone
 func (h *ShowZoneHandler) Delete(c *gin.Context) {
 	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.show_zone.Delete")
@@ -225,6 +303,10 @@ func (h *ShowZoneHandler) Delete(c *gin.Context) {
 		return
 	}
 
+	if !authorizeZone(c, h.showZoneService, h.showService, h.accessService, id, domain.PermissionEventEdit) {
+		return
+	}
+
 	err := h.showZoneService.DeleteShowZone(ctx, id)
 	if err != nil {
 		span.RecordError(err)
@@ -242,6 +324,39 @@ func (h *ShowZoneHandler) Delete(c *gin.Context) {
 	c.JSON(http.StatusOK, response.Success(map[string]string{"message": "Zone deleted successfully"}))
 }
 
+// CheckAccess handles GET /zones/:id/access - succeeds if the caller may edit the zone's
+// event. Booking-service checks organizers with it before they manage the zone's seating.
+func (h *ShowZoneHandler) CheckAccess(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.show_zone.CheckAccess")
+	defer span.End()
+	c.Request = c.Request.WithContext(ctx)
+
+	id := c.Param("id")
+	span.SetAttributes(attribute.String("zone_id", id))
+
+	if !authorizeZone(c, h.showZoneService, h.showService, h.accessService, id, domain.PermissionEventEdit) {
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(gin.H{"zone_id": id, "permission": domain.PermissionEventEdit}))
+}
+
+// authorizeZone checks that the caller holds permission on the zone's event and writes
+// the error response if not. Returns false when the request must stop.
+func authorizeZone(c *gin.Context, showZoneService service.ShowZoneService, showService service.ShowService, accessService service.EventAccessService, zoneID, permission string) bool {
+	zone, err := showZoneService.GetShowZoneByID(c.Request.Context(), zoneID)
+	if err != nil {
+		if errors.Is(err, service.ErrShowZoneNotFound) {
+			c.JSON(http.StatusNotFound, response.NotFound("Zone not found"))
+			return false
+		}
+		c.JSON(http.StatusInternalServerError, response.InternalError("Failed to get zone"))
+		return false
+	}
+	return authorizeShow(c, showService, accessService, zone.ShowID, permission)
+}
+
 // ListActive handles GET /zones/active - lists all active zones for inventory sync
 func (h *ShowZoneHandler) ListActive(c *gin.Context) {
 	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.show_zone.ListActive")
@@ -285,6 +400,9 @@ func toShowZoneResponse(zone *domain.ShowZone) *dto.ShowZoneResponse {
 		SortOrder:      zone.SortOrder,
 		CreatedAt:      zone.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:      zone.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+
+		PendingTotalSeats: zone.PendingTotalSeats,
+		HoldTTLSeconds:    zone.HoldTTLSeconds,
 	}
 
 	if zone.SaleStartAt != nil {
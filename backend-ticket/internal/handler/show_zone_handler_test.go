@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -74,6 +75,9 @@ func (m *MockShowZoneService) UpdateShowZone(ctx context.Context, id string, req
 		zone.Price = *req.Price
 	}
 	if req.TotalSeats != nil {
+		if *req.TotalSeats < zone.SoldSeats+zone.ReservedSeats {
+			return nil, fmt.Errorf("%w: %d seats are sold or held", service.ErrCapacityBelowCommitted, zone.SoldSeats+zone.ReservedSeats)
+		}
 		zone.TotalSeats = *req.TotalSeats
 	}
 	if req.SortOrder != nil {
@@ -100,6 +104,13 @@ func (m *MockShowZoneService) ListActiveZones(ctx context.Context) ([]*domain.Sh
 	return zones, nil
 }
 
+func (m *MockShowZoneService) GetCapacityStatus(ctx context.Context, id string) (*domain.ZoneCapacityStatus, error) {
+	if _, ok := m.zones[id]; !ok {
+		return nil, service.ErrShowZoneNotFound
+	}
+	return nil, service.ErrCapacityStatusNotFound
+}
+
 func (m *MockShowZoneService) AddZone(zone *domain.ShowZone) {
 	m.zones[zone.ID] = zone
 }
@@ -146,6 +157,7 @@ func (m *MockShowServiceForZone) AddShow(show *domain.Show) {
 func setupShowZoneRouter(h *ShowZoneHandler) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(withActor)
 
 	shows := router.Group("/shows")
 	{
@@ -158,6 +170,7 @@ func setupShowZoneRouter(h *ShowZoneHandler) *gin.Engine {
 		zones.GET("/:id", h.GetByID)
 		zones.PUT("/:id", h.Update)
 		zones.DELETE("/:id", h.Delete)
+		zones.GET("/:id/access", h.CheckAccess)
 	}
 
 	return router
@@ -166,7 +179,7 @@ func setupShowZoneRouter(h *ShowZoneHandler) *gin.Engine {
 func TestShowZoneHandler_ListByShow(t *testing.T) {
 	mockZoneSvc := NewMockShowZoneService()
 	mockShowSvc := NewMockShowServiceForZone()
-	handler := NewShowZoneHandler(mockZoneSvc, mockShowSvc)
+	handler := NewShowZoneHandler(mockZoneSvc, mockShowSvc, &MockEventAccessService{})
 	router := setupShowZoneRouter(handler)
 
 	// Add test show
@@ -228,8 +241,9 @@ func TestShowZoneHandler_ListByShow(t *testing.T) {
 func TestShowZoneHandler_Create(t *testing.T) {
 	mockZoneSvc := NewMockShowZoneService()
 	mockShowSvc := NewMockShowServiceForZone()
-	handler := NewShowZoneHandler(mockZoneSvc, mockShowSvc)
+	handler := NewShowZoneHandler(mockZoneSvc, mockShowSvc, &MockEventAccessService{})
 	router := setupShowZoneRouter(handler)
+	mockShowSvc.AddShow(&domain.Show{ID: "show-1", EventID: "event-1"})
 
 	tests := []struct {
 		name       string
@@ -287,7 +301,7 @@ func TestShowZoneHandler_Create(t *testing.T) {
 func TestShowZoneHandler_GetByID(t *testing.T) {
 	mockZoneSvc := NewMockShowZoneService()
 	mockShowSvc := NewMockShowServiceForZone()
-	handler := NewShowZoneHandler(mockZoneSvc, mockShowSvc)
+	handler := NewShowZoneHandler(mockZoneSvc, mockShowSvc, &MockEventAccessService{})
 	router := setupShowZoneRouter(handler)
 
 	// Add test zone
@@ -337,8 +351,9 @@ func TestShowZoneHandler_GetByID(t *testing.T) {
 func TestShowZoneHandler_Update(t *testing.T) {
 	mockZoneSvc := NewMockShowZoneService()
 	mockShowSvc := NewMockShowServiceForZone()
-	handler := NewShowZoneHandler(mockZoneSvc, mockShowSvc)
+	handler := NewShowZoneHandler(mockZoneSvc, mockShowSvc, &MockEventAccessService{})
 	router := setupShowZoneRouter(handler)
+	mockShowSvc.AddShow(&domain.Show{ID: "show-1", EventID: "event-1"})
 
 	// Add test zone
 	now := time.Now()
@@ -348,7 +363,8 @@ func TestShowZoneHandler_Update(t *testing.T) {
 		Name:           "Original Name",
 		Price:          100.00,
 		TotalSeats:     50,
-		AvailableSeats: 50,
+		AvailableSeats: 20,
+		SoldSeats:      30,
 		SortOrder:      1,
 		CreatedAt:      now,
 		UpdatedAt:      now,
@@ -376,6 +392,14 @@ func TestShowZoneHandler_Update(t *testing.T) {
 			},
 			wantStatus: http.StatusOK,
 		},
+		{
+			name: "capacity below sold seats",
+			id:   "zone-1",
+			body: map[string]interface{}{
+				"total_seats": 10,
+			},
+			wantStatus: http.StatusConflict,
+		},
 		{
 			name:       "empty update",
 			id:         "zone-1",
@@ -410,8 +434,9 @@ func TestShowZoneHandler_Update(t *testing.T) {
 func TestShowZoneHandler_Delete(t *testing.T) {
 	mockZoneSvc := NewMockShowZoneService()
 	mockShowSvc := NewMockShowServiceForZone()
-	handler := NewShowZoneHandler(mockZoneSvc, mockShowSvc)
+	handler := NewShowZoneHandler(mockZoneSvc, mockShowSvc, &MockEventAccessService{})
 	router := setupShowZoneRouter(handler)
+	mockShowSvc.AddShow(&domain.Show{ID: "show-1", EventID: "event-1"})
 
 	// Add test zone
 	now := time.Now()
@@ -456,3 +481,49 @@ func TestShowZoneHandler_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestShowZoneHandler_OwnershipChecks(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		authorizeErr error
+		wantStatus   int
+	}{
+		{"create forbidden", http.MethodPost, "/shows/show-1/zones", service.ErrUnauthorized, http.StatusForbidden},
+		{"update forbidden", http.MethodPut, "/zones/zone-1", service.ErrUnauthorized, http.StatusForbidden},
+		{"delete forbidden", http.MethodDelete, "/zones/zone-1", service.ErrUnauthorized, http.StatusForbidden},
+		{"other tenant hidden", http.MethodDelete, "/zones/zone-1", service.ErrEventNotFound, http.StatusNotFound},
+		{"update allowed", http.MethodPut, "/zones/zone-1", nil, http.StatusOK},
+		{"access denied", http.MethodGet, "/zones/zone-1/access", service.ErrUnauthorized, http.StatusForbidden},
+		{"access granted", http.MethodGet, "/zones/zone-1/access", nil, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockZoneSvc := NewMockShowZoneService()
+			mockZoneSvc.AddZone(&domain.ShowZone{ID: "zone-1", ShowID: "show-1", Name: "VIP Zone", Price: 100, TotalSeats: 50, AvailableSeats: 50})
+			mockShowSvc := NewMockShowServiceForZone()
+			mockShowSvc.AddShow(&domain.Show{ID: "show-1", EventID: "event-1"})
+			access := &MockEventAccessService{authorizeErr: tt.authorizeErr}
+			router := setupShowZoneRouter(NewShowZoneHandler(mockZoneSvc, mockShowSvc, access))
+
+			req, _ := http.NewRequest(tt.method, tt.path, bytes.NewBufferString(`{"name":"Renamed","price":1,"total_seats":10}`))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			if resp.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, resp.Code, resp.Body.String())
+			}
+			if len(access.authorized) != 1 || access.authorized[0] != "event-1" {
+				t.Errorf("access checked on %v, want the zone's event", access.authorized)
+			}
+			if tt.authorizeErr != nil {
+				if zone, ok := mockZoneSvc.zones["zone-1"]; !ok || zone.Name != "VIP Zone" || len(mockZoneSvc.zones) != 1 {
+					t.Errorf("zones were modified despite the rejected request: %+v", mockZoneSvc.zones)
+				}
+			}
+		})
+	}
+}
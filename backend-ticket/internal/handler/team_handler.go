@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/response"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// TeamHandler handles tenant team member HTTP requests
+type TeamHandler struct {
+	accessService service.EventAccessService
+}
+
+// NewTeamHandler creates a new TeamHandler
+func NewTeamHandler(accessService service.EventAccessService) *TeamHandler {
+	return &TeamHandler{
+		accessService: accessService,
+	}
+}
+
+// List handles GET /team/members - lists the team members of the caller's tenant
+func (h *TeamHandler) List(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.team.list")
+	defer span.End()
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		span.SetStatus(codes.Error, "user ID not found in token")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User ID not found in token"))
+		return
+	}
+
+	span.SetAttributes(attribute.String("tenant_id", actor.TenantID))
+
+	members, err := h.accessService.ListTeamMembers(ctx, actor)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to list team members")
+		return
+	}
+
+	resp := make([]*dto.TeamMemberResponse, len(members))
+	for i, member := range members {
+		resp[i] = toTeamMemberResponse(member)
+	}
+
+	span.SetAttributes(attribute.Int("count", len(members)))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(resp))
+}
+
+// Set handles PUT /team/members/:user_id - adds a team member or replaces their permissions
+func (h *TeamHandler) Set(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.team.set")
+	defer span.End()
+
+	userID := c.Param("user_id")
+	if userID == "" {
+		span.SetStatus(codes.Error, "user_id required")
+		c.JSON(http.StatusBadRequest, response.BadRequest("User ID is required"))
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		span.SetStatus(codes.Error, "user ID not found in token")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User ID not found in token"))
+		return
+	}
+
+	var req dto.SetTeamMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request body")
+		c.JSON(http.StatusBadRequest, response.BadRequest("Invalid request body"))
+		return
+	}
+	if valid, msg := req.Validate(); !valid {
+		span.SetStatus(codes.Error, "validation failed")
+		c.JSON(http.StatusBadRequest, response.BadRequest(msg))
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("tenant_id", actor.TenantID),
+		attribute.String("member_user_id", userID),
+		attribute.StringSlice("permissions", req.Permissions),
+	)
+
+	member, err := h.accessService.SetTeamMember(ctx, actor, userID, &req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to save team member")
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(toTeamMemberResponse(member)))
+}
+
+// Remove handles DELETE /team/members/:user_id - removes a team member
+func (h *TeamHandler) Remove(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.team.remove")
+	defer span.End()
+
+	userID := c.Param("user_id")
+	if userID == "" {
+		span.SetStatus(codes.Error, "user_id required")
+		c.JSON(http.StatusBadRequest, response.BadRequest("User ID is required"))
+		return
+	}
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		span.SetStatus(codes.Error, "user ID not found in token")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User ID not found in token"))
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("tenant_id", actor.TenantID),
+		attribute.String("member_user_id", userID),
+	)
+
+	if err := h.accessService.RemoveTeamMember(ctx, actor, userID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to remove team member")
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(map[string]string{"message": "Team member removed successfully"}))
+}
+
+// handleError maps team service errors to responses
+func (h *TeamHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrUnauthorized):
+		c.JSON(http.StatusForbidden, response.Forbidden("Missing permission "+domain.PermissionTeamManage))
+	case errors.Is(err, service.ErrCannotModifySelf):
+		c.JSON(http.StatusBadRequest, response.BadRequest("You cannot change your own team membership"))
+	case errors.Is(err, service.ErrTeamMemberNotFound):
+		c.JSON(http.StatusNotFound, response.NotFound("Team member not found"))
+	default:
+		c.JSON(http.StatusInternalServerError, response.InternalError(fallback))
+	}
+}
+
+// toTeamMemberResponse converts a domain team member to response DTO
+func toTeamMemberResponse(member *domain.TeamMember) *dto.TeamMemberResponse {
+	return &dto.TeamMemberResponse{
+		UserID:      member.UserID,
+		TenantID:    member.TenantID,
+		Permissions: member.Permissions,
+		AddedBy:     member.AddedBy,
+		CreatedAt:   member.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:   member.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/service"
+)
+
+func setupTeamRouter(h *TeamHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(withActor)
+
+	team := router.Group("/team")
+	{
+		team.GET("/members", h.List)
+		team.PUT("/members/:user_id", h.Set)
+		team.DELETE("/members/:user_id", h.Remove)
+	}
+
+	return router
+}
+
+func TestTeamHandler_List(t *testing.T) {
+	handler := NewTeamHandler(&MockEventAccessService{
+		members: []*domain.TeamMember{
+			{TenantID: "tenant-1", UserID: "user-2", Permissions: []string{domain.PermissionEventEdit}},
+		},
+	})
+	router := setupTeamRouter(handler)
+
+	req, _ := http.NewRequest(http.MethodGet, "/team/members", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	if resp.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, resp.Code)
+	}
+}
+
+func TestTeamHandler_Set(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		teamErr    error
+		wantStatus int
+	}{
+		{"valid permissions", `{"permissions":["events:edit","events:publish"]}`, nil, http.StatusOK},
+		{"unknown permission", `{"permissions":["events:own"]}`, nil, http.StatusBadRequest},
+		{"empty permissions", `{"permissions":[]}`, nil, http.StatusBadRequest},
+		{"not a team manager", `{"permissions":["events:edit"]}`, service.ErrUnauthorized, http.StatusForbidden},
+		{"self modification", `{"permissions":["team:manage"]}`, service.ErrCannotModifySelf, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupTeamRouter(NewTeamHandler(&MockEventAccessService{teamErr: tt.teamErr}))
+
+			req, _ := http.NewRequest(http.MethodPut, "/team/members/user-2", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			if resp.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, resp.Code, resp.Body.String())
+			}
+		})
+	}
+}
+
+func TestTeamHandler_Remove(t *testing.T) {
+	tests := []struct {
+		name       string
+		teamErr    error
+		wantStatus int
+	}{
+		{"removed", nil, http.StatusOK},
+		{"not a member", service.ErrTeamMemberNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupTeamRouter(NewTeamHandler(&MockEventAccessService{teamErr: tt.teamErr}))
+
+			req, _ := http.NewRequest(http.MethodDelete, "/team/members/user-2", nil)
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			if resp.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, resp.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,615 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/service"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/response"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// Venue error codes
+const (
+	ErrCodeVenueNotFound       = "VENUE_NOT_FOUND"
+	ErrCodeZoneNotFound        = "ZONE_NOT_FOUND"
+	ErrCodeVenueLayoutNotFound = "VENUE_LAYOUT_NOT_FOUND"
+	ErrCodeNoActiveLayout      = "NO_ACTIVE_LAYOUT"
+)
+
+// VenueHandler handles venue, venue zone and seat-map layout HTTP requests
+type VenueHandler struct {
+	venueService service.VenueService
+}
+
+// NewVenueHandler creates a new VenueHandler
+func NewVenueHandler(venueService service.VenueService) *VenueHandler {
+	return &VenueHandler{
+		venueService: venueService,
+	}
+}
+
+// List handles GET /venues - lists the venues of the caller's tenant (admins may pass tenant_id)
+func (h *VenueHandler) List(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.venue.list")
+	defer span.End()
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		span.SetStatus(codes.Error, "user ID not found in token")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User ID not found in token"))
+		return
+	}
+
+	venues, err := h.venueService.GetVenuesByTenant(ctx, actor, c.Query("tenant_id"))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to list venues")
+		return
+	}
+
+	resp := make([]*dto.VenueResponse, len(venues))
+	for i, venue := range venues {
+		resp[i] = toVenueResponse(venue)
+	}
+
+	span.SetAttributes(attribute.Int("count", len(venues)))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(resp))
+}
+
+// Get handles GET /venues/:id - retrieves a venue
+func (h *VenueHandler) Get(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.venue.get")
+	defer span.End()
+
+	venueID := c.Param("id")
+	actor, ok := actorFromContext(c)
+	if !ok {
+		span.SetStatus(codes.Error, "user ID not found in token")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User ID not found in token"))
+		return
+	}
+
+	span.SetAttributes(attribute.String("venue_id", venueID))
+
+	venue, err := h.venueService.GetVenue(ctx, actor, venueID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to get venue")
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(toVenueResponse(venue)))
+}
+
+// Create handles POST /venues - creates a venue in the caller's tenant
+func (h *VenueHandler) Create(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.venue.create")
+	defer span.End()
+
+	actor, ok := actorFromContext(c)
+	if !ok {
+		span.SetStatus(codes.Error, "user ID not found in token")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User ID not found in token"))
+		return
+	}
+
+	var req dto.CreateVenueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request body")
+		c.JSON(http.StatusBadRequest, response.BadRequest("Invalid request body"))
+		return
+	}
+	if valid, msg := req.Validate(); !valid {
+		span.SetStatus(codes.Error, "validation failed")
+		c.JSON(http.StatusBadRequest, response.BadRequest(msg))
+		return
+	}
+
+	venue, err := h.venueService.CreateVenue(ctx, actor, &req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to create venue")
+		return
+	}
+
+	span.SetAttributes(attribute.String("venue_id", venue.ID))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusCreated, response.Success(toVenueResponse(venue)))
+}
+
+// Update handles PUT /venues/:id - updates a venue
+func (h *VenueHandler) Update(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.venue.update")
+	defer span.End()
+
+	venueID := c.Param("id")
+	actor, ok := actorFromContext(c)
+	if !ok {
+		span.SetStatus(codes.Error, "user ID not found in token")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User ID not found in token"))
+		return
+	}
+
+	var req dto.UpdateVenueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request body")
+		c.JSON(http.StatusBadRequest, response.BadRequest("Invalid request body"))
+		return
+	}
+	if valid, msg := req.Validate(); !valid {
+		span.SetStatus(codes.Error, "validation failed")
+		c.JSON(http.StatusBadRequest, response.BadRequest(msg))
+		return
+	}
+
+	span.SetAttributes(attribute.String("venue_id", venueID))
+
+	venue, err := h.venueService.UpdateVenue(ctx, actor, venueID, &req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to update venue")
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(toVenueResponse(venue)))
+}
+
+// Delete handles DELETE /venues/:id - deletes a venue with its zones and layouts
+func (h *VenueHandler) Delete(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.venue.delete")
+	defer span.End()
+
+	venueID := c.Param("id")
+	actor, ok := actorFromContext(c)
+	if !ok {
+		span.SetStatus(codes.Error, "user ID not found in token")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User ID not found in token"))
+		return
+	}
+
+	span.SetAttributes(attribute.String("venue_id", venueID))
+
+	if err := h.venueService.DeleteVenue(ctx, actor, venueID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to delete venue")
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(map[string]string{"message": "Venue deleted successfully"}))
+}
+
+// ListZones handles GET /venues/:id/zones - lists the zones of a venue
+func (h *VenueHandler) ListZones(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.venue.list_zones")
+	defer span.End()
+
+	venueID := c.Param("id")
+	actor, ok := actorFromContext(c)
+	if !ok {
+		span.SetStatus(codes.Error, "user ID not found in token")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User ID not found in token"))
+		return
+	}
+
+	span.SetAttributes(attribute.String("venue_id", venueID))
+
+	zones, err := h.venueService.ListZones(ctx, actor, venueID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to list zones")
+		return
+	}
+
+	resp := make([]*dto.ZoneResponse, len(zones))
+	for i, zone := range zones {
+		resp[i] = toZoneResponse(zone)
+	}
+
+	span.SetAttributes(attribute.Int("count", len(zones)))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(resp))
+}
+
+// CreateZone handles POST /venues/:id/zones - adds a zone to a venue
+func (h *VenueHandler) CreateZone(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.venue.create_zone")
+	defer span.End()
+
+	venueID := c.Param("id")
+	actor, ok := actorFromContext(c)
+	if !ok {
+		span.SetStatus(codes.Error, "user ID not found in token")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User ID not found in token"))
+		return
+	}
+
+	var req dto.CreateZoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request body")
+		c.JSON(http.StatusBadRequest, response.BadRequest("Invalid request body"))
+		return
+	}
+	req.VenueID = venueID
+	if valid, msg := req.Validate(); !valid {
+		span.SetStatus(codes.Error, "validation failed")
+		c.JSON(http.StatusBadRequest, response.BadRequest(msg))
+		return
+	}
+
+	span.SetAttributes(attribute.String("venue_id", venueID))
+
+	zone, err := h.venueService.CreateZone(ctx, actor, &req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to create zone")
+		return
+	}
+
+	span.SetAttributes(attribute.String("zone_id", zone.ID))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusCreated, response.Success(toZoneResponse(zone)))
+}
+
+// UpdateZone handles PUT /venues/:id/zones/:zone_id - updates a zone of a venue
+func (h *VenueHandler) UpdateZone(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.venue.update_zone")
+	defer span.End()
+
+	venueID := c.Param("id")
+	zoneID := c.Param("zone_id")
+	actor, ok := actorFromContext(c)
+	if !ok {
+		span.SetStatus(codes.Error, "user ID not found in token")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User ID not found in token"))
+		return
+	}
+
+	var req dto.UpdateZoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request body")
+		c.JSON(http.StatusBadRequest, response.BadRequest("Invalid request body"))
+		return
+	}
+	if valid, msg := req.Validate(); !valid {
+		span.SetStatus(codes.Error, "validation failed")
+		c.JSON(http.StatusBadRequest, response.BadRequest(msg))
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("venue_id", venueID),
+		attribute.String("zone_id", zoneID),
+	)
+
+	zone, err := h.venueService.UpdateZone(ctx, actor, venueID, zoneID, &req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to update zone")
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(toZoneResponse(zone)))
+}
+
+// DeleteZone handles DELETE /venues/:id/zones/:zone_id - deletes a zone the active layout does not use
+func (h *VenueHandler) DeleteZone(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.venue.delete_zone")
+	defer span.End()
+
+	venueID := c.Param("id")
+	zoneID := c.Param("zone_id")
+	actor, ok := actorFromContext(c)
+	if !ok {
+		span.SetStatus(codes.Error, "user ID not found in token")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User ID not found in token"))
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("venue_id", venueID),
+		attribute.String("zone_id", zoneID),
+	)
+
+	if err := h.venueService.DeleteZone(ctx, actor, venueID, zoneID); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to delete zone")
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(map[string]string{"message": "Zone deleted successfully"}))
+}
+
+// ListLayouts handles GET /venues/:id/layouts - lists the layout versions of a venue
+func (h *VenueHandler) ListLayouts(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.venue.list_layouts")
+	defer span.End()
+
+	venueID := c.Param("id")
+	actor, ok := actorFromContext(c)
+	if !ok {
+		span.SetStatus(codes.Error, "user ID not found in token")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User ID not found in token"))
+		return
+	}
+
+	span.SetAttributes(attribute.String("venue_id", venueID))
+
+	venue, err := h.venueService.GetVenue(ctx, actor, venueID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to list venue layouts")
+		return
+	}
+	layouts, err := h.venueService.ListLayouts(ctx, actor, venueID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to list venue layouts")
+		return
+	}
+
+	resp := make([]*dto.VenueLayoutResponse, len(layouts))
+	for i, layout := range layouts {
+		resp[i] = toVenueLayoutResponse(layout, venue.ActiveLayoutVersion)
+	}
+
+	span.SetAttributes(attribute.Int("count", len(layouts)))
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(resp))
+}
+
+// GetLayout handles GET /venues/:id/layouts/:version - retrieves a layout version with its seat map
+func (h *VenueHandler) GetLayout(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.venue.get_layout")
+	defer span.End()
+
+	venueID := c.Param("id")
+	actor, ok := actorFromContext(c)
+	if !ok {
+		span.SetStatus(codes.Error, "user ID not found in token")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User ID not found in token"))
+		return
+	}
+	version, ok := layoutVersionParam(c)
+	if !ok {
+		span.SetStatus(codes.Error, "invalid layout version")
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("venue_id", venueID),
+		attribute.Int("version", version),
+	)
+
+	venue, err := h.venueService.GetVenue(ctx, actor, venueID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to get venue layout")
+		return
+	}
+	layout, err := h.venueService.GetLayout(ctx, actor, venueID, version)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to get venue layout")
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(toVenueLayoutResponse(layout, venue.ActiveLayoutVersion)))
+}
+
+// UploadLayout handles POST /venues/:id/layouts - uploads a seat map as the venue's next layout version
+func (h *VenueHandler) UploadLayout(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.venue.upload_layout")
+	defer span.End()
+
+	venueID := c.Param("id")
+	actor, ok := actorFromContext(c)
+	if !ok {
+		span.SetStatus(codes.Error, "user ID not found in token")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User ID not found in token"))
+		return
+	}
+
+	var req dto.UploadVenueLayoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid request body")
+		c.JSON(http.StatusBadRequest, response.BadRequest("Invalid request body"))
+		return
+	}
+	if valid, msg := req.Validate(); !valid {
+		span.SetStatus(codes.Error, "validation failed")
+		c.JSON(http.StatusBadRequest, response.BadRequest(msg))
+		return
+	}
+
+	span.SetAttributes(attribute.String("venue_id", venueID))
+
+	layout, err := h.venueService.UploadLayout(ctx, actor, venueID, &req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to upload venue layout")
+		return
+	}
+
+	active := 0
+	if req.ShouldActivate() {
+		active = layout.Version
+	}
+
+	span.SetAttributes(
+		attribute.Int("version", layout.Version),
+		attribute.Int("seat_count", layout.SeatCount),
+	)
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusCreated, response.Success(toVenueLayoutResponse(layout, active)))
+}
+
+// ActivateLayout handles POST /venues/:id/layouts/:version/activate - serves a layout version to buyers
+func (h *VenueHandler) ActivateLayout(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.venue.activate_layout")
+	defer span.End()
+
+	venueID := c.Param("id")
+	actor, ok := actorFromContext(c)
+	if !ok {
+		span.SetStatus(codes.Error, "user ID not found in token")
+		c.JSON(http.StatusUnauthorized, response.Unauthorized("User ID not found in token"))
+		return
+	}
+	version, ok := layoutVersionParam(c)
+	if !ok {
+		span.SetStatus(codes.Error, "invalid layout version")
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("venue_id", venueID),
+		attribute.Int("version", version),
+	)
+
+	venue, err := h.venueService.ActivateLayout(ctx, actor, venueID, version)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to activate venue layout")
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(toVenueResponse(venue)))
+}
+
+// GetRenderedLayout handles GET /venues/:id/layout - public, the active seat map with every seat positioned
+func (h *VenueHandler) GetRenderedLayout(c *gin.Context) {
+	ctx, span := telemetry.StartSpan(c.Request.Context(), "handler.venue.get_rendered_layout")
+	defer span.End()
+
+	venueID := c.Param("id")
+	span.SetAttributes(attribute.String("venue_id", venueID))
+
+	layout, err := h.venueService.GetRenderedLayout(ctx, venueID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		h.handleError(c, err, "Failed to get venue layout")
+		return
+	}
+
+	span.SetAttributes(
+		attribute.Int("version", layout.Version),
+		attribute.Int("seat_count", layout.SeatCount),
+	)
+	span.SetStatus(codes.Ok, "")
+	c.JSON(http.StatusOK, response.Success(layout))
+}
+
+// handleError maps venue service errors to responses
+func (h *VenueHandler) handleError(c *gin.Context, err error, fallback string) {
+	var layoutErr *service.LayoutValidationError
+	switch {
+	case errors.As(err, &layoutErr):
+		c.JSON(http.StatusBadRequest, response.ValidationFailed(layoutErr.Problems))
+	case errors.Is(err, service.ErrVenueNotFound):
+		c.JSON(http.StatusNotFound, response.Error(ErrCodeVenueNotFound, "Venue not found"))
+	case errors.Is(err, service.ErrZoneNotFound):
+		c.JSON(http.StatusNotFound, response.Error(ErrCodeZoneNotFound, "Zone not found"))
+	case errors.Is(err, service.ErrVenueLayoutNotFound):
+		c.JSON(http.StatusNotFound, response.Error(ErrCodeVenueLayoutNotFound, "Venue layout not found"))
+	case errors.Is(err, service.ErrNoActiveLayout):
+		c.JSON(http.StatusNotFound, response.Error(ErrCodeNoActiveLayout, "Venue has no active layout"))
+	case errors.Is(err, service.ErrUnauthorized):
+		c.JSON(http.StatusForbidden, response.Forbidden("Not allowed to perform this action"))
+	case errors.Is(err, service.ErrVenueTenantRequired):
+		c.JSON(http.StatusForbidden, response.Forbidden("Venues can only be created by tenant members"))
+	case errors.Is(err, service.ErrZoneCapacityExceeded),
+		errors.Is(err, service.ErrZoneCapacityBelowLayout),
+		errors.Is(err, service.ErrVenueCapacityBelowLayout),
+		errors.Is(err, service.ErrZoneInUse):
+		c.JSON(http.StatusConflict, response.Error(response.ErrCodeConflict, err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, response.InternalError(fallback))
+	}
+}
+
+// layoutVersionParam parses the :version path parameter, responding 400 if it is not a version
+func layoutVersionParam(c *gin.Context) (int, bool) {
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version < 1 {
+		c.JSON(http.StatusBadRequest, response.BadRequest("Layout version must be a positive number"))
+		return 0, false
+	}
+	return version, true
+}
+
+// toVenueResponse converts a domain venue to response DTO
+func toVenueResponse(venue *domain.Venue) *dto.VenueResponse {
+	return &dto.VenueResponse{
+		ID:                  venue.ID,
+		Name:                venue.Name,
+		Address:             venue.Address,
+		Capacity:            venue.Capacity,
+		TenantID:            venue.TenantID,
+		ActiveLayoutVersion: venue.ActiveLayoutVersion,
+		CreatedAt:           venue.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:           venue.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// toZoneResponse converts a domain venue zone to response DTO
+func toZoneResponse(zone *domain.Zone) *dto.ZoneResponse {
+	return &dto.ZoneResponse{
+		ID:        zone.ID,
+		VenueID:   zone.VenueID,
+		Name:      zone.Name,
+		Capacity:  zone.Capacity,
+		CreatedAt: zone.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt: zone.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// toVenueLayoutResponse converts a domain venue layout to response DTO
+func toVenueLayoutResponse(layout *domain.VenueLayout, activeVersion int) *dto.VenueLayoutResponse {
+	return &dto.VenueLayoutResponse{
+		ID:        layout.ID,
+		VenueID:   layout.VenueID,
+		Version:   layout.Version,
+		Active:    layout.Version == activeVersion,
+		SeatCount: layout.SeatCount,
+		Notes:     layout.Notes,
+		CreatedBy: layout.CreatedBy,
+		CreatedAt: layout.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Layout:    layout.Layout,
+	}
+}
@@ -0,0 +1,257 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/service"
+)
+
+// MockVenueService is a mock implementation of VenueService
+type MockVenueService struct {
+	err error
+}
+
+func (m *MockVenueService) venue() *domain.Venue {
+	return &domain.Venue{ID: "venue-1", Name: "Hall", TenantID: "tenant-1", Capacity: 100, ActiveLayoutVersion: 2}
+}
+
+func (m *MockVenueService) layout(version int) *domain.VenueLayout {
+	return &domain.VenueLayout{ID: "layout-1", VenueID: "venue-1", Version: version, SeatCount: 1, Layout: &domain.SeatLayout{
+		Sections: []*domain.LayoutSection{{ID: "A", ZoneID: "zone-a", Rows: []*domain.LayoutRow{
+			{Label: "1", Seats: []*domain.LayoutSeat{{Number: "1"}}},
+		}}},
+	}}
+}
+
+func (m *MockVenueService) CreateVenue(ctx context.Context, actor *domain.Actor, req *dto.CreateVenueRequest) (*domain.Venue, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.venue(), nil
+}
+
+func (m *MockVenueService) GetVenue(ctx context.Context, actor *domain.Actor, id string) (*domain.Venue, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.venue(), nil
+}
+
+func (m *MockVenueService) GetVenuesByTenant(ctx context.Context, actor *domain.Actor, tenantID string) ([]*domain.Venue, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []*domain.Venue{m.venue()}, nil
+}
+
+func (m *MockVenueService) UpdateVenue(ctx context.Context, actor *domain.Actor, id string, req *dto.UpdateVenueRequest) (*domain.Venue, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.venue(), nil
+}
+
+func (m *MockVenueService) DeleteVenue(ctx context.Context, actor *domain.Actor, id string) error {
+	return m.err
+}
+
+func (m *MockVenueService) CreateZone(ctx context.Context, actor *domain.Actor, req *dto.CreateZoneRequest) (*domain.Zone, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.Zone{ID: "zone-a", VenueID: req.VenueID, Name: req.Name, Capacity: req.Capacity}, nil
+}
+
+func (m *MockVenueService) ListZones(ctx context.Context, actor *domain.Actor, venueID string) ([]*domain.Zone, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []*domain.Zone{{ID: "zone-a", VenueID: venueID, Name: "A", Capacity: 4}}, nil
+}
+
+func (m *MockVenueService) UpdateZone(ctx context.Context, actor *domain.Actor, venueID, zoneID string, req *dto.UpdateZoneRequest) (*domain.Zone, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.Zone{ID: zoneID, VenueID: venueID, Name: req.Name}, nil
+}
+
+func (m *MockVenueService) DeleteZone(ctx context.Context, actor *domain.Actor, venueID, zoneID string) error {
+	return m.err
+}
+
+func (m *MockVenueService) UploadLayout(ctx context.Context, actor *domain.Actor, venueID string, req *dto.UploadVenueLayoutRequest) (*domain.VenueLayout, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.layout(3), nil
+}
+
+func (m *MockVenueService) ListLayouts(ctx context.Context, actor *domain.Actor, venueID string) ([]*domain.VenueLayout, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []*domain.VenueLayout{m.layout(2), m.layout(1)}, nil
+}
+
+func (m *MockVenueService) GetLayout(ctx context.Context, actor *domain.Actor, venueID string, version int) (*domain.VenueLayout, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.layout(version), nil
+}
+
+func (m *MockVenueService) ActivateLayout(ctx context.Context, actor *domain.Actor, venueID string, version int) (*domain.Venue, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	venue := m.venue()
+	venue.ActiveLayoutVersion = version
+	return venue, nil
+}
+
+func (m *MockVenueService) GetRenderedLayout(ctx context.Context, venueID string) (*domain.RenderedLayout, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.layout(2).Render(m.venue(), nil), nil
+}
+
+func setupVenueRouter(h *VenueHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	venues := router.Group("/venues")
+	{
+		venues.GET("/:id/layout", h.GetRenderedLayout)
+
+		protected := venues.Group("")
+		protected.Use(withActor)
+		{
+			protected.GET("", h.List)
+			protected.GET("/:id", h.Get)
+			protected.POST("", h.Create)
+			protected.POST("/:id/zones", h.CreateZone)
+			protected.GET("/:id/layouts", h.ListLayouts)
+			protected.POST("/:id/layouts", h.UploadLayout)
+			protected.GET("/:id/layouts/:version", h.GetLayout)
+			protected.POST("/:id/layouts/:version/activate", h.ActivateLayout)
+		}
+	}
+
+	return router
+}
+
+func TestVenueHandler_UploadLayout(t *testing.T) {
+	layout := `{"layout":{"sections":[{"id":"A","zone_id":"zone-a","rows":[{"label":"1","seats":[{"number":"1"}]}]}]}}`
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{"valid", layout, nil, http.StatusCreated},
+		{"no layout", `{"notes":"empty"}`, nil, http.StatusBadRequest},
+		{"rejected layout", layout, &service.LayoutValidationError{Problems: map[string]string{"zones.zone-a": "zone A is full"}}, http.StatusBadRequest},
+		{"unknown venue", layout, service.ErrVenueNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupVenueRouter(NewVenueHandler(&MockVenueService{err: tt.err}))
+
+			req, _ := http.NewRequest(http.MethodPost, "/venues/venue-1/layouts", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp := httptest.NewRecorder()
+			router.ServeHTTP(resp, req)
+
+			if resp.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, resp.Code, resp.Body.String())
+			}
+		})
+	}
+}
+
+func TestVenueHandler_RejectedLayoutDetails(t *testing.T) {
+	problems := map[string]string{"sections[0].zone_id": `zone "zone-x" is not a zone of this venue`}
+	router := setupVenueRouter(NewVenueHandler(&MockVenueService{err: &service.LayoutValidationError{Problems: problems}}))
+
+	req, _ := http.NewRequest(http.MethodPost, "/venues/venue-1/layouts/1/activate", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	var body struct {
+		Error struct {
+			Code    string            `json:"code"`
+			Details map[string]string `json:"details"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(resp.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Code != http.StatusBadRequest || body.Error.Details["sections[0].zone_id"] == "" {
+		t.Errorf("expected the layout problems in a 400, got %d: %s", resp.Code, resp.Body.String())
+	}
+}
+
+func TestVenueHandler_Layouts(t *testing.T) {
+	router := setupVenueRouter(NewVenueHandler(&MockVenueService{}))
+
+	req, _ := http.NewRequest(http.MethodGet, "/venues/venue-1/layouts", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	var body struct {
+		Data []dto.VenueLayoutResponse `json:"data"`
+	}
+	if err := json.Unmarshal(resp.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(body.Data) != 2 || !body.Data[0].Active || body.Data[1].Active {
+		t.Errorf("expected v2 to be the active layout, got %s", resp.Body.String())
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, "/venues/venue-1/layouts/latest", nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d for a non-numeric version, got %d", http.StatusBadRequest, resp.Code)
+	}
+}
+
+func TestVenueHandler_GetRenderedLayout(t *testing.T) {
+	// The seat map is public
+	router := setupVenueRouter(NewVenueHandler(&MockVenueService{}))
+
+	req, _ := http.NewRequest(http.MethodGet, "/venues/venue-1/layout", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	if resp.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, resp.Code)
+	}
+	var body struct {
+		Data domain.RenderedLayout `json:"data"`
+	}
+	if err := json.Unmarshal(resp.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if body.Data.Version != 2 || body.Data.SeatCount != 1 || body.Data.Sections[0].Rows[0].Seats[0].ID != "A-1-1" {
+		t.Errorf("unexpected layout: %s", resp.Body.String())
+	}
+
+	router = setupVenueRouter(NewVenueHandler(&MockVenueService{err: service.ErrNoActiveLayout}))
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	if resp.Code != http.StatusNotFound {
+		t.Errorf("expected status %d without an active layout, got %d", http.StatusNotFound, resp.Code)
+	}
+}
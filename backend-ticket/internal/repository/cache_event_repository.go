@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
 )
 
@@ -137,9 +138,9 @@ func (r *CachedEventRepository) Delete(ctx context.Context, id string) error {
 }
 
 // ListPublished lists all published events with caching
-func (r *CachedEventRepository) ListPublished(ctx context.Context, limit, offset int) ([]*domain.Event, int, error) {
+func (r *CachedEventRepository) ListPublished(ctx context.Context, page pagination.Params) ([]*domain.Event, int, error) {
 	// Try cache first
-	cacheKey := fmt.Sprintf("%spublished:%d:%d", eventListKeyPrefix, limit, offset)
+	cacheKey := fmt.Sprintf("%spublished:%s", eventListKeyPrefix, pageCacheKey(page))
 	cached, err := r.cache.Get(ctx, cacheKey).Result()
 	if err == nil && cached != "" {
 		var result cachedEventList
@@ -149,7 +150,7 @@ func (r *CachedEventRepository) ListPublished(ctx context.Context, limit, offset
 	}
 
 	// Cache miss - get from database
-	events, total, err := r.repo.ListPublished(ctx, limit, offset)
+	events, total, err := r.repo.ListPublished(ctx, page)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -161,14 +162,14 @@ func (r *CachedEventRepository) ListPublished(ctx context.Context, limit, offset
 }
 
 // List lists events with filters and pagination (cached only for simple queries)
-func (r *CachedEventRepository) List(ctx context.Context, filter *EventFilter, limit, offset int) ([]*domain.Event, int, error) {
+func (r *CachedEventRepository) List(ctx context.Context, filter *EventFilter, page pagination.Params) ([]*domain.Event, int, error) {
 	// Only cache simple queries without filters or with only status filter
 	if filter == nil || (filter.TenantID == "" && filter.City == "" && filter.CategoryID == "" && filter.Search == "") {
 		status := ""
 		if filter != nil {
 			status = filter.Status
 		}
-		cacheKey := fmt.Sprintf("%sall:%s:%d:%d", eventListKeyPrefix, status, limit, offset)
+		cacheKey := fmt.Sprintf("%sall:%s:%s", eventListKeyPrefix, status, pageCacheKey(page))
 		cached, err := r.cache.Get(ctx, cacheKey).Result()
 		if err == nil && cached != "" {
 			var result cachedEventList
@@ -178,7 +179,7 @@ func (r *CachedEventRepository) List(ctx context.Context, filter *EventFilter, l
 		}
 
 		// Cache miss - get from database
-		events, total, err := r.repo.List(ctx, filter, limit, offset)
+		events, total, err := r.repo.List(ctx, filter, page)
 		if err != nil {
 			return nil, 0, err
 		}
@@ -190,7 +191,7 @@ func (r *CachedEventRepository) List(ctx context.Context, filter *EventFilter, l
 	}
 
 	// Complex queries bypass cache
-	return r.repo.List(ctx, filter, limit, offset)
+	return r.repo.List(ctx, filter, page)
 }
 
 // SlugExists checks if a slug already exists (bypass cache)
@@ -205,6 +206,14 @@ type cachedEventList struct {
 	Total  int             `json:"total"`
 }
 
+// pageCacheKey identifies a page of a list; cursor pages are keyed by the cursor itself
+func pageCacheKey(page pagination.Params) string {
+	if page.After != nil {
+		return fmt.Sprintf("%d:c:%s", page.Limit, page.After.Encode())
+	}
+	return fmt.Sprintf("%d:%d", page.Limit, page.Offset)
+}
+
 func (r *CachedEventRepository) cacheEvent(ctx context.Context, key string, event *domain.Event) {
 	data, err := json.Marshal(event)
 	if err != nil {
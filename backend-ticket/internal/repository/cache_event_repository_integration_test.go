@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
 )
 
@@ -145,7 +146,7 @@ func TestCachedEventRepository_Integration_ListPublished(t *testing.T) {
 	mockRepo.ResetCounts()
 
 	// First call - cache miss
-	events, total, err := cachedRepo.ListPublished(ctx, 10, 0)
+	events, total, err := cachedRepo.ListPublished(ctx, pagination.Params{Limit: 10})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -157,7 +158,7 @@ func TestCachedEventRepository_Integration_ListPublished(t *testing.T) {
 	}
 
 	// Second call - cache hit
-	events, total, err = cachedRepo.ListPublished(ctx, 10, 0)
+	events, total, err = cachedRepo.ListPublished(ctx, pagination.Params{Limit: 10})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 )
 
 // MockEventRepository is a mock implementation of EventRepository for testing
@@ -75,7 +76,7 @@ func (m *MockEventRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-func (m *MockEventRepository) ListPublished(ctx context.Context, limit, offset int) ([]*domain.Event, int, error) {
+func (m *MockEventRepository) ListPublished(ctx context.Context, page pagination.Params) ([]*domain.Event, int, error) {
 	m.listCount++
 	var events []*domain.Event
 	for _, e := range m.events {
@@ -86,7 +87,7 @@ func (m *MockEventRepository) ListPublished(ctx context.Context, limit, offset i
 	return events, len(events), nil
 }
 
-func (m *MockEventRepository) List(ctx context.Context, filter *EventFilter, limit, offset int) ([]*domain.Event, int, error) {
+func (m *MockEventRepository) List(ctx context.Context, filter *EventFilter, page pagination.Params) ([]*domain.Event, int, error) {
 	m.listCount++
 	var events []*domain.Event
 	for _, e := range m.events {
@@ -232,7 +233,7 @@ func TestCachedEventRepository_ListPublished(t *testing.T) {
 	ctx := context.Background()
 
 	// First call
-	events, total, err := mockRepo.ListPublished(ctx, 10, 0)
+	events, total, err := mockRepo.ListPublished(ctx, pagination.Params{Limit: 10})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -247,7 +248,7 @@ func TestCachedEventRepository_ListPublished(t *testing.T) {
 	}
 
 	// Second call
-	events, total, err = mockRepo.ListPublished(ctx, 10, 0)
+	events, total, err = mockRepo.ListPublished(ctx, pagination.Params{Limit: 10})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -405,7 +406,7 @@ func TestCachedEventRepository_List(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			events, total, err := mockRepo.List(ctx, tt.filter, 10, 0)
+			events, total, err := mockRepo.List(ctx, tt.filter, pagination.Params{Limit: 10})
 			if err != nil {
 				t.Fatalf("unexpected error: %v", err)
 			}
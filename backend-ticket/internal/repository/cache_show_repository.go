@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+)
+
+const (
+	// Cache key prefixes
+	showDetailKeyPrefix = "show:detail:"
+	showListKeyPrefix   = "show:list:"
+
+	// Default TTL for show caches
+	showCacheTTL = 5 * time.Minute
+)
+
+// CachedShowRepository wraps ShowRepository with Redis caching
+type CachedShowRepository struct {
+	repo  ShowRepository
+	cache *redis.Client
+}
+
+// NewCachedShowRepository creates a new CachedShowRepository
+func NewCachedShowRepository(repo ShowRepository, cache *redis.Client) *CachedShowRepository {
+	return &CachedShowRepository{
+		repo:  repo,
+		cache: cache,
+	}
+}
+
+// Create creates a new show and invalidates the event's list cache
+func (r *CachedShowRepository) Create(ctx context.Context, show *domain.Show) error {
+	if err := r.repo.Create(ctx, show); err != nil {
+		return err
+	}
+	r.invalidateListCaches(ctx, show.EventID)
+	return nil
+}
+
+// GetByID retrieves a show by ID with caching
+func (r *CachedShowRepository) GetByID(ctx context.Context, id string) (*domain.Show, error) {
+	// Try cache first
+	cacheKey := showDetailKeyPrefix + id
+	cached, err := r.cache.Get(ctx, cacheKey).Result()
+	if err == nil && cached != "" {
+		var show domain.Show
+		if err := json.Unmarshal([]byte(cached), &show); err == nil {
+			return &show, nil
+		}
+	}
+
+	// Cache miss - get from database
+	show, err := r.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if show == nil {
+		return nil, nil
+	}
+
+	// Store in cache
+	r.cacheValue(ctx, cacheKey, show)
+
+	return show, nil
+}
+
+// GetByEventID retrieves shows by event ID with caching
+func (r *CachedShowRepository) GetByEventID(ctx context.Context, eventID string, limit, offset int) ([]*domain.Show, int, error) {
+	// Try cache first
+	cacheKey := fmt.Sprintf("%s%s:%d:%d", showListKeyPrefix, eventID, limit, offset)
+	cached, err := r.cache.Get(ctx, cacheKey).Result()
+	if err == nil && cached != "" {
+		var result cachedShowList
+		if err := json.Unmarshal([]byte(cached), &result); err == nil {
+			return result.Shows, result.Total, nil
+		}
+	}
+
+	// Cache miss - get from database
+	shows, total, err := r.repo.GetByEventID(ctx, eventID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Store in cache
+	r.cacheValue(ctx, cacheKey, cachedShowList{Shows: shows, Total: total})
+
+	return shows, total, nil
+}
+
+// Update updates a show and invalidates caches
+func (r *CachedShowRepository) Update(ctx context.Context, show *domain.Show) error {
+	if err := r.repo.Update(ctx, show); err != nil {
+		return err
+	}
+	r.invalidateShowCaches(ctx, show.ID, show.EventID)
+	return nil
+}
+
+// Delete soft deletes a show and invalidates caches
+func (r *CachedShowRepository) Delete(ctx context.Context, id string) error {
+	// Get show first to know the event for list cache invalidation
+	show, err := r.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := r.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	eventID := ""
+	if show != nil {
+		eventID = show.EventID
+	}
+	r.invalidateShowCaches(ctx, id, eventID)
+
+	return nil
+}
+
+// --- Helper functions ---
+
+type cachedShowList struct {
+	Shows []*domain.Show `json:"shows"`
+	Total int            `json:"total"`
+}
+
+func (r *CachedShowRepository) cacheValue(ctx context.Context, key string, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	r.cache.Set(ctx, key, string(data), showCacheTTL)
+}
+
+func (r *CachedShowRepository) invalidateShowCaches(ctx context.Context, id, eventID string) {
+	r.cache.Del(ctx, showDetailKeyPrefix+id)
+	r.invalidateListCaches(ctx, eventID)
+}
+
+func (r *CachedShowRepository) invalidateListCaches(ctx context.Context, eventID string) {
+	if eventID == "" {
+		return
+	}
+	iter := r.cache.Client().Scan(ctx, 0, showListKeyPrefix+eventID+":*", 100).Iterator()
+	for iter.Next(ctx) {
+		r.cache.Del(ctx, iter.Val())
+	}
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+)
+
+const (
+	// Cache key prefix
+	showZoneListKeyPrefix = "zone:list:"
+
+	// Zone lists carry seat counts, so they are cached only briefly
+	showZoneCacheTTL = 30 * time.Second
+)
+
+// CachedShowZoneRepository wraps ShowZoneRepository with Redis caching of zone lists.
+// Single zone reads and ListActive bypass the cache because capacity changes and
+// inventory sync need fresh seat counts.
+type CachedShowZoneRepository struct {
+	repo  ShowZoneRepository
+	cache *redis.Client
+}
+
+// NewCachedShowZoneRepository creates a new CachedShowZoneRepository
+func NewCachedShowZoneRepository(repo ShowZoneRepository, cache *redis.Client) *CachedShowZoneRepository {
+	return &CachedShowZoneRepository{
+		repo:  repo,
+		cache: cache,
+	}
+}
+
+// Create creates a new show zone and invalidates the show's list cache
+func (r *CachedShowZoneRepository) Create(ctx context.Context, zone *domain.ShowZone) error {
+	if err := r.repo.Create(ctx, zone); err != nil {
+		return err
+	}
+	r.invalidateListCaches(ctx, zone.ShowID)
+	return nil
+}
+
+// GetByID retrieves a show zone by ID (bypass cache)
+func (r *CachedShowZoneRepository) GetByID(ctx context.Context, id string) (*domain.ShowZone, error) {
+	return r.repo.GetByID(ctx, id)
+}
+
+// GetByShowID retrieves zones for a show with caching
+func (r *CachedShowZoneRepository) GetByShowID(ctx context.Context, showID string, isActive *bool, limit, offset int) ([]*domain.ShowZone, int, error) {
+	// Try cache first
+	active := "all"
+	if isActive != nil {
+		active = fmt.Sprintf("%t", *isActive)
+	}
+	cacheKey := fmt.Sprintf("%s%s:%s:%d:%d", showZoneListKeyPrefix, showID, active, limit, offset)
+	cached, err := r.cache.Get(ctx, cacheKey).Result()
+	if err == nil && cached != "" {
+		var result cachedShowZoneList
+		if err := json.Unmarshal([]byte(cached), &result); err == nil {
+			return result.Zones, result.Total, nil
+		}
+	}
+
+	// Cache miss - get from database
+	zones, total, err := r.repo.GetByShowID(ctx, showID, isActive, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Store in cache
+	data, err := json.Marshal(cachedShowZoneList{Zones: zones, Total: total})
+	if err == nil {
+		r.cache.Set(ctx, cacheKey, string(data), showZoneCacheTTL)
+	}
+
+	return zones, total, nil
+}
+
+// Update updates a show zone and invalidates the show's list cache
+func (r *CachedShowZoneRepository) Update(ctx context.Context, zone *domain.ShowZone) error {
+	if err := r.repo.Update(ctx, zone); err != nil {
+		return err
+	}
+	r.invalidateListCaches(ctx, zone.ShowID)
+	return nil
+}
+
+// Delete soft deletes a show zone and invalidates the show's list cache
+func (r *CachedShowZoneRepository) Delete(ctx context.Context, id string) error {
+	return r.writeAndInvalidate(ctx, id, func() error {
+		return r.repo.Delete(ctx, id)
+	})
+}
+
+// UpdateAvailableSeats updates the available seats count and invalidates the show's list cache
+func (r *CachedShowZoneRepository) UpdateAvailableSeats(ctx context.Context, id string, availableSeats int) error {
+	return r.writeAndInvalidate(ctx, id, func() error {
+		return r.repo.UpdateAvailableSeats(ctx, id, availableSeats)
+	})
+}
+
+// ListActive retrieves all active zones (bypass cache)
+func (r *CachedShowZoneRepository) ListActive(ctx context.Context) ([]*domain.ShowZone, error) {
+	return r.repo.ListActive(ctx)
+}
+
+// --- Helper functions ---
+
+type cachedShowZoneList struct {
+	Zones []*domain.ShowZone `json:"zones"`
+	Total int                `json:"total"`
+}
+
+// writeAndInvalidate runs a write keyed only by zone ID, looking the zone up first
+// to know which show's list caches to drop
+func (r *CachedShowZoneRepository) writeAndInvalidate(ctx context.Context, id string, write func() error) error {
+	zone, err := r.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := write(); err != nil {
+		return err
+	}
+
+	if zone != nil {
+		r.invalidateListCaches(ctx, zone.ShowID)
+	}
+	return nil
+}
+
+func (r *CachedShowZoneRepository) invalidateListCaches(ctx context.Context, showID string) {
+	if showID == "" {
+		return
+	}
+	iter := r.cache.Client().Scan(ctx, 0, showZoneListKeyPrefix+showID+":*", 100).Iterator()
+	for iter.Next(ctx) {
+		r.cache.Del(ctx, iter.Val())
+	}
+}
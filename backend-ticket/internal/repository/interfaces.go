@@ -2,8 +2,10 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 )
 
 // EventRepository defines the interface for event data access
@@ -21,9 +23,9 @@ type EventRepository interface {
 	// Delete soft deletes an event by ID
 	Delete(ctx context.Context, id string) error
 	// ListPublished lists all published events with pagination
-	ListPublished(ctx context.Context, limit, offset int) ([]*domain.Event, int, error)
+	ListPublished(ctx context.Context, page pagination.Params) ([]*domain.Event, int, error)
 	// List lists events with filters and pagination
-	List(ctx context.Context, filter *EventFilter, limit, offset int) ([]*domain.Event, int, error)
+	List(ctx context.Context, filter *EventFilter, page pagination.Params) ([]*domain.Event, int, error)
 	// SlugExists checks if a slug already exists
 	SlugExists(ctx context.Context, slug string) (bool, error)
 }
@@ -38,6 +40,57 @@ type EventFilter struct {
 	Search      string
 }
 
+// TeamMemberRepository defines the interface for tenant team member data access
+type TeamMemberRepository interface {
+	// Upsert creates a team member or replaces the permissions of an existing one
+	Upsert(ctx context.Context, member *domain.TeamMember) error
+	// Get retrieves a team member by tenant and user, nil if not a member
+	Get(ctx context.Context, tenantID, userID string) (*domain.TeamMember, error)
+	// ListByTenant lists the team members of a tenant
+	ListByTenant(ctx context.Context, tenantID string) ([]*domain.TeamMember, error)
+	// Delete removes a team member, returns false if it did not exist
+	Delete(ctx context.Context, tenantID, userID string) (bool, error)
+}
+
+// EventTemplateRepository defines the interface for event template data access
+type EventTemplateRepository interface {
+	// Create creates a new template
+	Create(ctx context.Context, template *domain.EventTemplate) error
+	// GetByID retrieves a template by ID, nil if not found
+	GetByID(ctx context.Context, id string) (*domain.EventTemplate, error)
+	// ListByTenant lists the templates of a tenant, newest first
+	ListByTenant(ctx context.Context, tenantID string) ([]*domain.EventTemplate, error)
+	// Delete removes a template, returns false if it did not exist
+	Delete(ctx context.Context, id string) (bool, error)
+}
+
+// PresaleRepository defines the interface for presale batch and code data access
+type PresaleRepository interface {
+	// CreateBatch creates a batch together with its codes
+	CreateBatch(ctx context.Context, batch *domain.PresaleBatch, codes []*domain.PresaleCode) error
+	// GetBatch retrieves a batch by event and ID, nil if not found
+	GetBatch(ctx context.Context, eventID, batchID string) (*domain.PresaleBatch, error)
+	// GetCode retrieves a code of an event, nil if not found
+	GetCode(ctx context.Context, eventID, code string) (*domain.PresaleCode, error)
+	// ListCodesByBatch lists the codes of a batch
+	ListCodesByBatch(ctx context.Context, batchID string) ([]*domain.PresaleCode, error)
+	// UpdateRedeemedCount raises a code's redeemed count to count (never lowers it)
+	UpdateRedeemedCount(ctx context.Context, codeID string, count int64) error
+	// ListBatchStats lists the batches of an event with their redemption counts
+	ListBatchStats(ctx context.Context, eventID string) ([]*domain.PresaleBatchStats, error)
+}
+
+// PresaleRedemptionRepository defines the interface for live presale redemption counting
+type PresaleRedemptionRepository interface {
+	// Redeem atomically consumes a use of the code for the user unless it has none left.
+	// Redeeming a code the user already redeemed does not consume another use. The code's
+	// persisted redeemed count seeds the counter if it is not tracked yet.
+	Redeem(ctx context.Context, code *domain.PresaleCode, userID string, ttl time.Duration) (*domain.PresaleRedemption, error)
+	// Status returns the code's live use count and whether the user already redeemed it.
+	// The use count is -1 if the code is not tracked yet.
+	Status(ctx context.Context, codeID, userID string) (int64, bool, error)
+}
+
 // VenueRepository defines the interface for venue data access
 type VenueRepository interface {
 	// Create creates a new venue
@@ -66,6 +119,16 @@ type ZoneRepository interface {
 	Delete(ctx context.Context, id string) error
 }
 
+// VenueLayoutRepository defines the interface for venue layout version data access
+type VenueLayoutRepository interface {
+	// Create stores a layout as the next version of its venue and sets layout.Version
+	Create(ctx context.Context, layout *domain.VenueLayout) error
+	// GetVersion retrieves a layout version of a venue, or nil if it does not exist
+	GetVersion(ctx context.Context, venueID string, version int) (*domain.VenueLayout, error)
+	// ListByVenue lists the layout versions of a venue, newest first, without their layouts
+	ListByVenue(ctx context.Context, venueID string) ([]*domain.VenueLayout, error)
+}
+
 // SeatRepository defines the interface for seat data access
 type SeatRepository interface {
 	// Create creates a new seat
@@ -133,3 +196,31 @@ type ShowZoneRepository interface {
 	// ListActive retrieves all active zones (for inventory sync)
 	ListActive(ctx context.Context) ([]*domain.ShowZone, error)
 }
+
+// ZonePriceTierRepository defines the interface for zone price tier data access
+type ZonePriceTierRepository interface {
+	// Create creates a new price tier
+	Create(ctx context.Context, tier *domain.ZonePriceTier) error
+	// GetByID retrieves a price tier of a zone, nil if not found
+	GetByID(ctx context.Context, zoneID, id string) (*domain.ZonePriceTier, error)
+	// ListByZone lists the price tiers of a zone in the order booking-service tries them
+	ListByZone(ctx context.Context, zoneID string) ([]*domain.ZonePriceTier, error)
+	// Update updates a price tier
+	Update(ctx context.Context, tier *domain.ZonePriceTier) error
+	// Delete deletes a price tier of a zone, returns false if it did not exist
+	Delete(ctx context.Context, zoneID, id string) (bool, error)
+}
+
+// EventAddOnRepository defines the interface for event add-on data access
+type EventAddOnRepository interface {
+	// Create creates a new add-on
+	Create(ctx context.Context, addOn *domain.EventAddOn) error
+	// GetByID retrieves an add-on of an event, nil if not found
+	GetByID(ctx context.Context, eventID, id string) (*domain.EventAddOn, error)
+	// ListByEvent lists the add-ons of an event by sort order
+	ListByEvent(ctx context.Context, eventID string) ([]*domain.EventAddOn, error)
+	// Update updates an add-on
+	Update(ctx context.Context, addOn *domain.EventAddOn) error
+	// Delete deletes an add-on of an event, returns false if it did not exist
+	Delete(ctx context.Context, eventID, id string) (bool, error)
+}
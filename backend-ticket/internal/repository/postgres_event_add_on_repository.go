@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+)
+
+// eventAddOnColumns defines columns for event_add_ons table
+const eventAddOnColumns = `id, event_id, name, description, category, price, inventory,
+	max_per_booking, is_active, sort_order, created_at, updated_at`
+
+// PostgresEventAddOnRepository implements EventAddOnRepository using PostgreSQL
+type PostgresEventAddOnRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresEventAddOnRepository creates a new PostgresEventAddOnRepository
+func NewPostgresEventAddOnRepository(pool *pgxpool.Pool) *PostgresEventAddOnRepository {
+	return &PostgresEventAddOnRepository{pool: pool}
+}
+
+// scanAddOn scans a row into an EventAddOn struct
+func scanAddOn(row pgx.Row) (*domain.EventAddOn, error) {
+	addOn := &domain.EventAddOn{}
+	err := row.Scan(
+		&addOn.ID,
+		&addOn.EventID,
+		&addOn.Name,
+		&addOn.Description,
+		&addOn.Category,
+		&addOn.Price,
+		&addOn.Inventory,
+		&addOn.MaxPerBooking,
+		&addOn.IsActive,
+		&addOn.SortOrder,
+		&addOn.CreatedAt,
+		&addOn.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return addOn, nil
+}
+
+// Create creates a new add-on
+func (r *PostgresEventAddOnRepository) Create(ctx context.Context, addOn *domain.EventAddOn) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO event_add_ons (id, event_id, name, description, category, price, inventory,
+			max_per_booking, is_active, sort_order, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+		addOn.ID,
+		addOn.EventID,
+		addOn.Name,
+		addOn.Description,
+		addOn.Category,
+		addOn.Price,
+		addOn.Inventory,
+		addOn.MaxPerBooking,
+		addOn.IsActive,
+		addOn.SortOrder,
+		addOn.CreatedAt,
+		addOn.UpdatedAt,
+	)
+	return err
+}
+
+// GetByID retrieves an add-on of an event, nil if not found
+func (r *PostgresEventAddOnRepository) GetByID(ctx context.Context, eventID, id string) (*domain.EventAddOn, error) {
+	query := `SELECT ` + eventAddOnColumns + ` FROM event_add_ons WHERE event_id = $1 AND id = $2`
+	addOn, err := scanAddOn(r.pool.QueryRow(ctx, query, eventID, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return addOn, nil
+}
+
+// ListByEvent lists the add-ons of an event by sort order
+func (r *PostgresEventAddOnRepository) ListByEvent(ctx context.Context, eventID string) ([]*domain.EventAddOn, error) {
+	query := `SELECT ` + eventAddOnColumns + ` FROM event_add_ons
+		WHERE event_id = $1
+		ORDER BY sort_order ASC, created_at ASC`
+	rows, err := r.pool.Query(ctx, query, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	addOns := []*domain.EventAddOn{}
+	for rows.Next() {
+		addOn, err := scanAddOn(rows)
+		if err != nil {
+			return nil, err
+		}
+		addOns = append(addOns, addOn)
+	}
+	return addOns, rows.Err()
+}
+
+// Update updates an add-on
+func (r *PostgresEventAddOnRepository) Update(ctx context.Context, addOn *domain.EventAddOn) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE event_add_ons
+		SET name = $3, description = $4, category = $5, price = $6, inventory = $7,
+			max_per_booking = $8, is_active = $9, sort_order = $10, updated_at = $11
+		WHERE event_id = $1 AND id = $2`,
+		addOn.EventID,
+		addOn.ID,
+		addOn.Name,
+		addOn.Description,
+		addOn.Category,
+		addOn.Price,
+		addOn.Inventory,
+		addOn.MaxPerBooking,
+		addOn.IsActive,
+		addOn.SortOrder,
+		addOn.UpdatedAt,
+	)
+	return err
+}
+
+// Delete deletes an add-on of an event, returns false if it did not exist
+func (r *PostgresEventAddOnRepository) Delete(ctx context.Context, eventID, id string) (bool, error) {
+	result, err := r.pool.Exec(ctx, `DELETE FROM event_add_ons WHERE event_id = $1 AND id = $2`, eventID, id)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 )
 
 // PostgresEventRepository implements EventRepository using PostgreSQL
@@ -41,7 +42,8 @@ const eventColumns = `id, tenant_id, organizer_id, category_id, name, slug,
 	COALESCE(meta_description, '') as meta_description,
 	COALESCE(settings, '{}'::jsonb) as settings,
 	0 as min_price,
-	published_at, created_at, updated_at, deleted_at`
+	published_at, created_at, updated_at, deleted_at, hold_ttl_seconds,
+	COALESCE(verification_policy, 'none') as verification_policy`
 
 // eventColumnsWithPrice includes min_price for queries with price aggregation
 const eventColumnsWithPrice = `e.id, e.tenant_id, e.organizer_id, e.category_id, e.name, e.slug,
@@ -60,7 +62,8 @@ const eventColumnsWithPrice = `e.id, e.tenant_id, e.organizer_id, e.category_id,
 	COALESCE(e.meta_description, '') as meta_description,
 	COALESCE(e.settings, '{}'::jsonb) as settings,
 	COALESCE(MIN(sz.price), 0) as min_price,
-	e.published_at, e.created_at, e.updated_at, e.deleted_at`
+	e.published_at, e.created_at, e.updated_at, e.deleted_at, e.hold_ttl_seconds,
+	COALESCE(e.verification_policy, 'none') as verification_policy`
 
 // scanEvent scans a row into an Event struct
 func (r *PostgresEventRepository) scanEvent(row pgx.Row) (*domain.Event, error) {
@@ -100,6 +103,8 @@ func (r *PostgresEventRepository) scanEvent(row pgx.Row) (*domain.Event, error)
 		&event.CreatedAt,
 		&event.UpdatedAt,
 		&event.DeletedAt,
+		&event.HoldTTLSeconds,
+		&event.VerificationPolicy,
 	)
 	if err != nil {
 		return nil, err
@@ -161,6 +166,8 @@ func (r *PostgresEventRepository) scanEvents(rows pgx.Rows) ([]*domain.Event, er
 			&event.CreatedAt,
 			&event.UpdatedAt,
 			&event.DeletedAt,
+			&event.HoldTTLSeconds,
+			&event.VerificationPolicy,
 		)
 		if err != nil {
 			return nil, err
@@ -192,9 +199,9 @@ func (r *PostgresEventRepository) Create(ctx context.Context, event *domain.Even
 			short_description, poster_url, banner_url, gallery, venue_name, venue_address,
 			city, country, latitude, longitude, max_tickets_per_user, booking_start_at,
 			booking_end_at, status, is_featured, is_public, meta_title, meta_description,
-			settings, published_at, created_at, updated_at
+			settings, published_at, created_at, updated_at, hold_ttl_seconds, verification_policy
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31
 		)
 	`
 
@@ -234,6 +241,8 @@ func (r *PostgresEventRepository) Create(ctx context.Context, event *domain.Even
 		event.PublishedAt,
 		event.CreatedAt,
 		event.UpdatedAt,
+		event.HoldTTLSeconds,
+		event.VerificationPolicy,
 	)
 	return err
 }
@@ -291,7 +300,8 @@ func (r *PostgresEventRepository) Update(ctx context.Context, event *domain.Even
 			venue_address = $10, city = $11, country = $12, latitude = $13,
 			longitude = $14, max_tickets_per_user = $15, booking_start_at = $16,
 			booking_end_at = $17, status = $18, is_featured = $19, is_public = $20,
-			meta_title = $21, meta_description = $22, settings = $23, updated_at = $24
+			meta_title = $21, meta_description = $22, settings = $23, updated_at = $24,
+			hold_ttl_seconds = $25, verification_policy = $26
 		WHERE id = $1 AND deleted_at IS NULL
 	`
 
@@ -327,6 +337,8 @@ func (r *PostgresEventRepository) Update(ctx context.Context, event *domain.Even
 		event.MetaDescription,
 		settingsJSON,
 		event.UpdatedAt,
+		event.HoldTTLSeconds,
+		event.VerificationPolicy,
 	)
 	if err != nil {
 		return err
@@ -356,7 +368,7 @@ func (r *PostgresEventRepository) Delete(ctx context.Context, id string) error {
 }
 
 // ListPublished lists all published events with pagination and min price
-func (r *PostgresEventRepository) ListPublished(ctx context.Context, limit, offset int) ([]*domain.Event, int, error) {
+func (r *PostgresEventRepository) ListPublished(ctx context.Context, page pagination.Params) ([]*domain.Event, int, error) {
 	// Count total
 	countQuery := `SELECT COUNT(*) FROM events WHERE status = $1 AND deleted_at IS NULL AND is_public = true`
 	var total int
@@ -365,24 +377,32 @@ func (r *PostgresEventRepository) ListPublished(ctx context.Context, limit, offs
 		return nil, 0, err
 	}
 
+	whereClause := "e.status = $1 AND e.deleted_at IS NULL AND e.is_public = true"
+	args := []interface{}{domain.EventStatusPublished, page.Limit, page.Offset}
+	if page.After != nil {
+		whereClause += " AND " + pagination.Keyset("e.created_at", "e.id", 4)
+		args = append(args, page.After.Time, page.After.ID)
+	}
+
 	// Get events with min_price from seat_zones
 	query := fmt.Sprintf(`
 		SELECT %s
 		FROM events e
 		LEFT JOIN shows s ON s.event_id = e.id AND s.deleted_at IS NULL
 		LEFT JOIN seat_zones sz ON sz.show_id = s.id AND sz.deleted_at IS NULL
-		WHERE e.status = $1 AND e.deleted_at IS NULL AND e.is_public = true
+		WHERE %s
 		GROUP BY e.id, e.tenant_id, e.organizer_id, e.category_id, e.name, e.slug,
 			e.description, e.short_description, e.poster_url, e.banner_url, e.gallery,
 			e.venue_name, e.venue_address, e.city, e.country, e.latitude, e.longitude,
 			e.max_tickets_per_user, e.booking_start_at, e.booking_end_at, e.status,
 			e.is_featured, e.is_public, e.meta_title, e.meta_description, e.settings,
-			e.published_at, e.created_at, e.updated_at, e.deleted_at
-		ORDER BY e.created_at DESC
+			e.published_at, e.created_at, e.updated_at, e.deleted_at, e.hold_ttl_seconds,
+			e.verification_policy
+		ORDER BY e.created_at DESC, e.id DESC
 		LIMIT $2 OFFSET $3
-	`, eventColumnsWithPrice)
+	`, eventColumnsWithPrice, whereClause)
 
-	rows, err := r.pool.Query(ctx, query, domain.EventStatusPublished, limit, offset)
+	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -396,7 +416,7 @@ func (r *PostgresEventRepository) ListPublished(ctx context.Context, limit, offs
 }
 
 // List lists events with filters and pagination
-func (r *PostgresEventRepository) List(ctx context.Context, filter *EventFilter, limit, offset int) ([]*domain.Event, int, error) {
+func (r *PostgresEventRepository) List(ctx context.Context, filter *EventFilter, page pagination.Params) ([]*domain.Event, int, error) {
 	var conditions []string
 	var args []interface{}
 	argIndex := 1
@@ -446,15 +466,22 @@ func (r *PostgresEventRepository) List(ctx context.Context, filter *EventFilter,
 		return nil, 0, err
 	}
 
+	// The cursor narrows the page but not the total
+	if page.After != nil {
+		whereClause += " AND " + pagination.Keyset("created_at", "id", argIndex)
+		args = append(args, page.After.Time, page.After.ID)
+		argIndex += 2
+	}
+
 	// Get events
 	query := fmt.Sprintf(`
 		SELECT %s FROM events
 		WHERE %s
-		ORDER BY created_at DESC
+		ORDER BY created_at DESC, id DESC
 		LIMIT $%d OFFSET $%d
 	`, eventColumns, whereClause, argIndex, argIndex+1)
 
-	args = append(args, limit, offset)
+	args = append(args, page.Limit, page.Offset)
 
 	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
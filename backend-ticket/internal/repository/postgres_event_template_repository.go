@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+)
+
+// PostgresEventTemplateRepository implements EventTemplateRepository using PostgreSQL
+type PostgresEventTemplateRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresEventTemplateRepository creates a new PostgresEventTemplateRepository
+func NewPostgresEventTemplateRepository(pool *pgxpool.Pool) *PostgresEventTemplateRepository {
+	return &PostgresEventTemplateRepository{pool: pool}
+}
+
+const eventTemplateColumns = `id, tenant_id, name, COALESCE(description, '') as description,
+	COALESCE(source_event_id::text, '') as source_event_id, blueprint,
+	COALESCE(created_by::text, '') as created_by, created_at, updated_at`
+
+// scanEventTemplate scans a row into an EventTemplate struct
+func scanEventTemplate(row pgx.Row) (*domain.EventTemplate, error) {
+	template := &domain.EventTemplate{}
+	var blueprintJSON []byte
+	err := row.Scan(
+		&template.ID,
+		&template.TenantID,
+		&template.Name,
+		&template.Description,
+		&template.SourceEventID,
+		&blueprintJSON,
+		&template.CreatedBy,
+		&template.CreatedAt,
+		&template.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(blueprintJSON, &template.Blueprint); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// Create creates a new template
+func (r *PostgresEventTemplateRepository) Create(ctx context.Context, template *domain.EventTemplate) error {
+	blueprintJSON, err := json.Marshal(template.Blueprint)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO event_templates (id, tenant_id, name, description, source_event_id, blueprint,
+			created_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, '')::uuid, $6, NULLIF($7, '')::uuid, $8, $9)
+	`
+	_, err = r.pool.Exec(ctx, query,
+		template.ID,
+		template.TenantID,
+		template.Name,
+		template.Description,
+		template.SourceEventID,
+		blueprintJSON,
+		template.CreatedBy,
+		template.CreatedAt,
+		template.UpdatedAt,
+	)
+	return err
+}
+
+// GetByID retrieves a template by ID, nil if not found
+func (r *PostgresEventTemplateRepository) GetByID(ctx context.Context, id string) (*domain.EventTemplate, error) {
+	query := `SELECT ` + eventTemplateColumns + ` FROM event_templates WHERE id = $1`
+	template, err := scanEventTemplate(r.pool.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return template, nil
+}
+
+// ListByTenant lists the templates of a tenant, newest first
+func (r *PostgresEventTemplateRepository) ListByTenant(ctx context.Context, tenantID string) ([]*domain.EventTemplate, error) {
+	query := `SELECT ` + eventTemplateColumns + ` FROM event_templates WHERE tenant_id = $1 ORDER BY created_at DESC`
+	rows, err := r.pool.Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	templates := []*domain.EventTemplate{}
+	for rows.Next() {
+		template, err := scanEventTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, rows.Err()
+}
+
+// Delete removes a template, returns false if it did not exist
+func (r *PostgresEventTemplateRepository) Delete(ctx context.Context, id string) (bool, error) {
+	result, err := r.pool.Exec(ctx, `DELETE FROM event_templates WHERE id = $1`, id)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
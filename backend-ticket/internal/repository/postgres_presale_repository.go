@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+)
+
+// PostgresPresaleRepository implements PresaleRepository using PostgreSQL
+type PostgresPresaleRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresPresaleRepository creates a new PostgresPresaleRepository
+func NewPostgresPresaleRepository(pool *pgxpool.Pool) *PostgresPresaleRepository {
+	return &PostgresPresaleRepository{pool: pool}
+}
+
+const presaleBatchColumns = `b.id, b.event_id, b.tenant_id, b.name, b.max_uses_per_code, b.valid_from, b.valid_until,
+	COALESCE(b.created_by::text, '') as created_by, b.created_at`
+
+// presaleCodeColumns reads a code joined with its batch (alias b)
+const presaleCodeColumns = `c.id, c.batch_id, c.event_id, c.code, b.max_uses_per_code, b.valid_from, b.valid_until,
+	c.redeemed_count, c.created_at`
+
+// scanPresaleBatch scans a row into a PresaleBatch struct
+func scanPresaleBatch(row pgx.Row, dest ...any) (*domain.PresaleBatch, error) {
+	batch := &domain.PresaleBatch{}
+	err := row.Scan(append([]any{
+		&batch.ID,
+		&batch.EventID,
+		&batch.TenantID,
+		&batch.Name,
+		&batch.MaxUsesPerCode,
+		&batch.ValidFrom,
+		&batch.ValidUntil,
+		&batch.CreatedBy,
+		&batch.CreatedAt,
+	}, dest...)...)
+	if err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// scanPresaleCode scans a row into a PresaleCode struct
+func scanPresaleCode(row pgx.Row) (*domain.PresaleCode, error) {
+	code := &domain.PresaleCode{}
+	err := row.Scan(
+		&code.ID,
+		&code.BatchID,
+		&code.EventID,
+		&code.Code,
+		&code.MaxUses,
+		&code.ValidFrom,
+		&code.ValidUntil,
+		&code.RedeemedCount,
+		&code.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return code, nil
+}
+
+// CreateBatch creates a batch together with its codes in a single transaction
+func (r *PostgresPresaleRepository) CreateBatch(ctx context.Context, batch *domain.PresaleBatch, codes []*domain.PresaleCode) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO presale_batches (id, event_id, tenant_id, name, max_uses_per_code, valid_from, valid_until, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, '')::uuid, $9)`,
+		batch.ID,
+		batch.EventID,
+		batch.TenantID,
+		batch.Name,
+		batch.MaxUsesPerCode,
+		batch.ValidFrom,
+		batch.ValidUntil,
+		batch.CreatedBy,
+		batch.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert presale batch: %w", err)
+	}
+
+	rows := make([][]any, len(codes))
+	for i, code := range codes {
+		rows[i] = []any{code.ID, code.BatchID, code.EventID, code.Code, code.RedeemedCount, code.CreatedAt}
+	}
+	_, err = tx.CopyFrom(ctx,
+		pgx.Identifier{"presale_codes"},
+		[]string{"id", "batch_id", "event_id", "code", "redeemed_count", "created_at"},
+		pgx.CopyFromRows(rows),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert presale codes: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// GetBatch retrieves a batch by event and ID, nil if not found
+func (r *PostgresPresaleRepository) GetBatch(ctx context.Context, eventID, batchID string) (*domain.PresaleBatch, error) {
+	query := `SELECT ` + presaleBatchColumns + ` FROM presale_batches b WHERE b.event_id = $1 AND b.id = $2`
+	batch, err := scanPresaleBatch(r.pool.QueryRow(ctx, query, eventID, batchID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return batch, nil
+}
+
+// GetCode retrieves a code of an event, nil if not found
+func (r *PostgresPresaleRepository) GetCode(ctx context.Context, eventID, code string) (*domain.PresaleCode, error) {
+	query := `
+		SELECT ` + presaleCodeColumns + `
+		FROM presale_codes c
+		JOIN presale_batches b ON b.id = c.batch_id
+		WHERE c.event_id = $1 AND c.code = $2`
+	presaleCode, err := scanPresaleCode(r.pool.QueryRow(ctx, query, eventID, code))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return presaleCode, nil
+}
+
+// ListCodesByBatch lists the codes of a batch
+func (r *PostgresPresaleRepository) ListCodesByBatch(ctx context.Context, batchID string) ([]*domain.PresaleCode, error) {
+	query := `
+		SELECT ` + presaleCodeColumns + `
+		FROM presale_codes c
+		JOIN presale_batches b ON b.id = c.batch_id
+		WHERE c.batch_id = $1
+		ORDER BY c.code`
+	rows, err := r.pool.Query(ctx, query, batchID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	codes := []*domain.PresaleCode{}
+	for rows.Next() {
+		code, err := scanPresaleCode(rows)
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+	}
+	return codes, rows.Err()
+}
+
+// UpdateRedeemedCount raises a code's redeemed count to count. Redemptions sync out of
+// order, so a lower count never overwrites a higher one.
+func (r *PostgresPresaleRepository) UpdateRedeemedCount(ctx context.Context, codeID string, count int64) error {
+	_, err := r.pool.Exec(ctx,
+		`UPDATE presale_codes SET redeemed_count = GREATEST(redeemed_count, $2) WHERE id = $1`,
+		codeID, count,
+	)
+	return err
+}
+
+// ListBatchStats lists the batches of an event with their redemption counts
+func (r *PostgresPresaleRepository) ListBatchStats(ctx context.Context, eventID string) ([]*domain.PresaleBatchStats, error) {
+	query := `
+		SELECT ` + presaleBatchColumns + `,
+			COUNT(c.id),
+			COUNT(c.id) FILTER (WHERE c.redeemed_count > 0),
+			COALESCE(SUM(c.redeemed_count), 0)
+		FROM presale_batches b
+		LEFT JOIN presale_codes c ON c.batch_id = b.id
+		WHERE b.event_id = $1
+		GROUP BY b.id
+		ORDER BY b.created_at`
+	rows, err := r.pool.Query(ctx, query, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := []*domain.PresaleBatchStats{}
+	for rows.Next() {
+		s := &domain.PresaleBatchStats{}
+		batch, err := scanPresaleBatch(rows, &s.Codes, &s.CodesRedeemed, &s.Redemptions)
+		if err != nil {
+			return nil, err
+		}
+		s.Batch = batch
+		stats = append(stats, s)
+	}
+	return stats, rows.Err()
+}
@@ -18,7 +18,7 @@ const seatZoneColumns = `id, show_id, name, COALESCE(description, '') as descrip
 	COALESCE(sold_seats, 0) as sold_seats, COALESCE(min_per_order, 1) as min_per_order,
 	COALESCE(max_per_order, 10) as max_per_order, COALESCE(is_active, true) as is_active,
 	COALESCE(sort_order, 0) as sort_order, sale_start_at, sale_end_at,
-	created_at, updated_at, deleted_at`
+	created_at, updated_at, deleted_at, hold_ttl_seconds`
 
 // PostgresShowZoneRepository implements ShowZoneRepository using PostgreSQL
 type PostgresShowZoneRepository struct {
@@ -54,6 +54,7 @@ func (r *PostgresShowZoneRepository) scanZone(row pgx.Row) (*domain.ShowZone, er
 		&zone.CreatedAt,
 		&zone.UpdatedAt,
 		&zone.DeletedAt,
+		&zone.HoldTTLSeconds,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -69,8 +70,8 @@ func (r *PostgresShowZoneRepository) Create(ctx context.Context, zone *domain.Sh
 	query := `
 		INSERT INTO seat_zones (id, show_id, name, description, color, price, currency,
 			total_seats, available_seats, min_per_order, max_per_order, is_active,
-			sort_order, sale_start_at, sale_end_at, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+			sort_order, sale_start_at, sale_end_at, created_at, updated_at, hold_ttl_seconds)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
 	`
 	_, err := r.pool.Exec(ctx, query,
 		zone.ID,
@@ -90,6 +91,7 @@ func (r *PostgresShowZoneRepository) Create(ctx context.Context, zone *domain.Sh
 		zone.SaleEndAt,
 		zone.CreatedAt,
 		zone.UpdatedAt,
+		zone.HoldTTLSeconds,
 	)
 	return err
 }
@@ -157,6 +159,7 @@ func (r *PostgresShowZoneRepository) GetByShowID(ctx context.Context, showID str
 			&zone.CreatedAt,
 			&zone.UpdatedAt,
 			&zone.DeletedAt,
+			&zone.HoldTTLSeconds,
 		)
 		if err != nil {
 			return nil, 0, err
@@ -172,7 +175,7 @@ func (r *PostgresShowZoneRepository) Update(ctx context.Context, zone *domain.Sh
 		UPDATE seat_zones
 		SET name = $2, description = $3, color = $4, price = $5, total_seats = $6,
 			available_seats = $7, min_per_order = $8, max_per_order = $9, is_active = $10,
-			sort_order = $11, updated_at = $12
+			sort_order = $11, updated_at = $12, hold_ttl_seconds = $13
 		WHERE id = $1 AND deleted_at IS NULL
 	`
 	zone.UpdatedAt = time.Now()
@@ -189,6 +192,7 @@ func (r *PostgresShowZoneRepository) Update(ctx context.Context, zone *domain.Sh
 		zone.IsActive,
 		zone.SortOrder,
 		zone.UpdatedAt,
+		zone.HoldTTLSeconds,
 	)
 	if err != nil {
 		return err
@@ -270,6 +274,7 @@ func (r *PostgresShowZoneRepository) ListActive(ctx context.Context) ([]*domain.
 			&zone.CreatedAt,
 			&zone.UpdatedAt,
 			&zone.DeletedAt,
+			&zone.HoldTTLSeconds,
 		)
 		if err != nil {
 			return nil, err
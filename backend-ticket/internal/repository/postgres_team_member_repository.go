@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+)
+
+// PostgresTeamMemberRepository implements TeamMemberRepository using PostgreSQL
+type PostgresTeamMemberRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresTeamMemberRepository creates a new PostgresTeamMemberRepository
+func NewPostgresTeamMemberRepository(pool *pgxpool.Pool) *PostgresTeamMemberRepository {
+	return &PostgresTeamMemberRepository{pool: pool}
+}
+
+const teamMemberColumns = `id, tenant_id, user_id, permissions, COALESCE(added_by::text, '') as added_by, created_at, updated_at`
+
+// scanTeamMember scans a row into a TeamMember struct
+func scanTeamMember(row pgx.Row) (*domain.TeamMember, error) {
+	member := &domain.TeamMember{}
+	err := row.Scan(
+		&member.ID,
+		&member.TenantID,
+		&member.UserID,
+		&member.Permissions,
+		&member.AddedBy,
+		&member.CreatedAt,
+		&member.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if member.Permissions == nil {
+		member.Permissions = []string{}
+	}
+	return member, nil
+}
+
+// Upsert creates a team member or replaces the permissions of an existing one
+func (r *PostgresTeamMemberRepository) Upsert(ctx context.Context, member *domain.TeamMember) error {
+	query := `
+		INSERT INTO team_members (id, tenant_id, user_id, permissions, added_by, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, '')::uuid, $6, $7)
+		ON CONFLICT (tenant_id, user_id)
+		DO UPDATE SET permissions = EXCLUDED.permissions, updated_at = EXCLUDED.updated_at
+		RETURNING ` + teamMemberColumns
+	stored, err := scanTeamMember(r.pool.QueryRow(ctx, query,
+		member.ID,
+		member.TenantID,
+		member.UserID,
+		member.Permissions,
+		member.AddedBy,
+		member.CreatedAt,
+		member.UpdatedAt,
+	))
+	if err != nil {
+		return err
+	}
+	// An existing member keeps its ID, added_by and created_at
+	*member = *stored
+	return nil
+}
+
+// Get retrieves a team member by tenant and user, nil if not a member
+func (r *PostgresTeamMemberRepository) Get(ctx context.Context, tenantID, userID string) (*domain.TeamMember, error) {
+	query := `SELECT ` + teamMemberColumns + ` FROM team_members WHERE tenant_id = $1 AND user_id = $2`
+	member, err := scanTeamMember(r.pool.QueryRow(ctx, query, tenantID, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return member, nil
+}
+
+// ListByTenant lists the team members of a tenant
+func (r *PostgresTeamMemberRepository) ListByTenant(ctx context.Context, tenantID string) ([]*domain.TeamMember, error) {
+	query := `SELECT ` + teamMemberColumns + ` FROM team_members WHERE tenant_id = $1 ORDER BY created_at`
+	rows, err := r.pool.Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := []*domain.TeamMember{}
+	for rows.Next() {
+		member, err := scanTeamMember(rows)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	return members, rows.Err()
+}
+
+// Delete removes a team member, returns false if it did not exist
+func (r *PostgresTeamMemberRepository) Delete(ctx context.Context, tenantID, userID string) (bool, error) {
+	result, err := r.pool.Exec(ctx, `DELETE FROM team_members WHERE tenant_id = $1 AND user_id = $2`, tenantID, userID)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
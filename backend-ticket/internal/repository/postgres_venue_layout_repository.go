@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+)
+
+// PostgresVenueLayoutRepository implements VenueLayoutRepository using PostgreSQL
+type PostgresVenueLayoutRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresVenueLayoutRepository creates a new PostgresVenueLayoutRepository
+func NewPostgresVenueLayoutRepository(pool *pgxpool.Pool) *PostgresVenueLayoutRepository {
+	return &PostgresVenueLayoutRepository{pool: pool}
+}
+
+// Create stores a layout as the next version of its venue. The venue row is locked
+// while the version is numbered, so concurrent uploads get consecutive versions.
+func (r *PostgresVenueLayoutRepository) Create(ctx context.Context, layout *domain.VenueLayout) error {
+	layoutJSON, err := json.Marshal(layout.Layout)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT 1 FROM venues WHERE id = $1 FOR UPDATE`, layout.VenueID); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO venue_layouts (id, venue_id, version, layout, seat_count, notes, created_by, created_at)
+		SELECT $1, $2, COALESCE(MAX(version), 0) + 1, $3, $4, $5, NULLIF($6, '')::uuid, $7
+		FROM venue_layouts
+		WHERE venue_id = $2
+		RETURNING version
+	`
+	err = tx.QueryRow(ctx, query,
+		layout.ID,
+		layout.VenueID,
+		layoutJSON,
+		layout.SeatCount,
+		layout.Notes,
+		layout.CreatedBy,
+		layout.CreatedAt,
+	).Scan(&layout.Version)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// GetVersion retrieves a layout version of a venue, nil if not found
+func (r *PostgresVenueLayoutRepository) GetVersion(ctx context.Context, venueID string, version int) (*domain.VenueLayout, error) {
+	query := `
+		SELECT id, venue_id, version, layout, seat_count, COALESCE(notes, ''),
+			COALESCE(created_by::text, ''), created_at
+		FROM venue_layouts
+		WHERE venue_id = $1 AND version = $2
+	`
+	layout := &domain.VenueLayout{}
+	var layoutJSON []byte
+	err := r.pool.QueryRow(ctx, query, venueID, version).Scan(
+		&layout.ID,
+		&layout.VenueID,
+		&layout.Version,
+		&layoutJSON,
+		&layout.SeatCount,
+		&layout.Notes,
+		&layout.CreatedBy,
+		&layout.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(layoutJSON, &layout.Layout); err != nil {
+		return nil, err
+	}
+	return layout, nil
+}
+
+// ListByVenue lists the layout versions of a venue, newest first, without their layouts
+func (r *PostgresVenueLayoutRepository) ListByVenue(ctx context.Context, venueID string) ([]*domain.VenueLayout, error) {
+	query := `
+		SELECT id, venue_id, version, seat_count, COALESCE(notes, ''),
+			COALESCE(created_by::text, ''), created_at
+		FROM venue_layouts
+		WHERE venue_id = $1
+		ORDER BY version DESC
+	`
+	rows, err := r.pool.Query(ctx, query, venueID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	layouts := []*domain.VenueLayout{}
+	for rows.Next() {
+		layout := &domain.VenueLayout{}
+		err := rows.Scan(
+			&layout.ID,
+			&layout.VenueID,
+			&layout.Version,
+			&layout.SeatCount,
+			&layout.Notes,
+			&layout.CreatedBy,
+			&layout.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		layouts = append(layouts, layout)
+	}
+	return layouts, rows.Err()
+}
@@ -41,7 +41,7 @@ func (r *PostgresVenueRepository) Create(ctx context.Context, venue *domain.Venu
 // GetByID retrieves a venue by ID
 func (r *PostgresVenueRepository) GetByID(ctx context.Context, id string) (*domain.Venue, error) {
 	query := `
-		SELECT id, name, address, capacity, tenant_id, created_at, updated_at
+		SELECT id, name, address, capacity, tenant_id, COALESCE(active_layout_version, 0), created_at, updated_at
 		FROM venues
 		WHERE id = $1
 	`
@@ -52,6 +52,7 @@ func (r *PostgresVenueRepository) GetByID(ctx context.Context, id string) (*doma
 		&venue.Address,
 		&venue.Capacity,
 		&venue.TenantID,
+		&venue.ActiveLayoutVersion,
 		&venue.CreatedAt,
 		&venue.UpdatedAt,
 	)
@@ -67,7 +68,7 @@ func (r *PostgresVenueRepository) GetByID(ctx context.Context, id string) (*doma
 // GetByTenantID retrieves venues by tenant ID
 func (r *PostgresVenueRepository) GetByTenantID(ctx context.Context, tenantID string) ([]*domain.Venue, error) {
 	query := `
-		SELECT id, name, address, capacity, tenant_id, created_at, updated_at
+		SELECT id, name, address, capacity, tenant_id, COALESCE(active_layout_version, 0), created_at, updated_at
 		FROM venues
 		WHERE tenant_id = $1
 		ORDER BY created_at DESC
@@ -87,6 +88,7 @@ func (r *PostgresVenueRepository) GetByTenantID(ctx context.Context, tenantID st
 			&venue.Address,
 			&venue.Capacity,
 			&venue.TenantID,
+			&venue.ActiveLayoutVersion,
 			&venue.CreatedAt,
 			&venue.UpdatedAt,
 		)
@@ -102,7 +104,7 @@ func (r *PostgresVenueRepository) GetByTenantID(ctx context.Context, tenantID st
 func (r *PostgresVenueRepository) Update(ctx context.Context, venue *domain.Venue) error {
 	query := `
 		UPDATE venues
-		SET name = $2, address = $3, capacity = $4, updated_at = $5
+		SET name = $2, address = $3, capacity = $4, active_layout_version = NULLIF($5, 0), updated_at = $6
 		WHERE id = $1
 	`
 	venue.UpdatedAt = time.Now()
@@ -111,6 +113,7 @@ func (r *PostgresVenueRepository) Update(ctx context.Context, venue *domain.Venu
 		venue.Name,
 		venue.Address,
 		venue.Capacity,
+		venue.ActiveLayoutVersion,
 		venue.UpdatedAt,
 	)
 	if err != nil {
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+)
+
+// zonePriceTierColumns defines columns for zone_price_tiers table
+const zonePriceTierColumns = `id, zone_id, name, price, allocation, valid_from, valid_until,
+	sort_order, created_at, updated_at`
+
+// PostgresZonePriceTierRepository implements ZonePriceTierRepository using PostgreSQL
+type PostgresZonePriceTierRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresZonePriceTierRepository creates a new PostgresZonePriceTierRepository
+func NewPostgresZonePriceTierRepository(pool *pgxpool.Pool) *PostgresZonePriceTierRepository {
+	return &PostgresZonePriceTierRepository{pool: pool}
+}
+
+// scanPriceTier scans a row into a ZonePriceTier struct
+func scanPriceTier(row pgx.Row) (*domain.ZonePriceTier, error) {
+	tier := &domain.ZonePriceTier{}
+	err := row.Scan(
+		&tier.ID,
+		&tier.ZoneID,
+		&tier.Name,
+		&tier.Price,
+		&tier.Allocation,
+		&tier.ValidFrom,
+		&tier.ValidUntil,
+		&tier.SortOrder,
+		&tier.CreatedAt,
+		&tier.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return tier, nil
+}
+
+// Create creates a new price tier
+func (r *PostgresZonePriceTierRepository) Create(ctx context.Context, tier *domain.ZonePriceTier) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO zone_price_tiers (id, zone_id, name, price, allocation, valid_from, valid_until,
+			sort_order, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		tier.ID,
+		tier.ZoneID,
+		tier.Name,
+		tier.Price,
+		tier.Allocation,
+		tier.ValidFrom,
+		tier.ValidUntil,
+		tier.SortOrder,
+		tier.CreatedAt,
+		tier.UpdatedAt,
+	)
+	return err
+}
+
+// GetByID retrieves a price tier of a zone, nil if not found
+func (r *PostgresZonePriceTierRepository) GetByID(ctx context.Context, zoneID, id string) (*domain.ZonePriceTier, error) {
+	query := `SELECT ` + zonePriceTierColumns + ` FROM zone_price_tiers WHERE zone_id = $1 AND id = $2`
+	tier, err := scanPriceTier(r.pool.QueryRow(ctx, query, zoneID, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return tier, nil
+}
+
+// ListByZone lists the price tiers of a zone in the order booking-service tries them
+func (r *PostgresZonePriceTierRepository) ListByZone(ctx context.Context, zoneID string) ([]*domain.ZonePriceTier, error) {
+	query := `SELECT ` + zonePriceTierColumns + ` FROM zone_price_tiers
+		WHERE zone_id = $1
+		ORDER BY sort_order ASC, price ASC, created_at ASC`
+	rows, err := r.pool.Query(ctx, query, zoneID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tiers := []*domain.ZonePriceTier{}
+	for rows.Next() {
+		tier, err := scanPriceTier(rows)
+		if err != nil {
+			return nil, err
+		}
+		tiers = append(tiers, tier)
+	}
+	return tiers, rows.Err()
+}
+
+// Update updates a price tier
+func (r *PostgresZonePriceTierRepository) Update(ctx context.Context, tier *domain.ZonePriceTier) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE zone_price_tiers
+		SET name = $3, price = $4, allocation = $5, valid_from = $6, valid_until = $7,
+			sort_order = $8, updated_at = $9
+		WHERE zone_id = $1 AND id = $2`,
+		tier.ZoneID,
+		tier.ID,
+		tier.Name,
+		tier.Price,
+		tier.Allocation,
+		tier.ValidFrom,
+		tier.ValidUntil,
+		tier.SortOrder,
+		tier.UpdatedAt,
+	)
+	return err
+}
+
+// Delete deletes a price tier of a zone, returns false if it did not exist
+func (r *PostgresZonePriceTierRepository) Delete(ctx context.Context, zoneID, id string) (bool, error) {
+	result, err := r.pool.Exec(ctx, `DELETE FROM zone_price_tiers WHERE zone_id = $1 AND id = $2`, zoneID, id)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+)
+
+// PostgresZoneRepository implements ZoneRepository for venue zones using PostgreSQL
+type PostgresZoneRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresZoneRepository creates a new PostgresZoneRepository
+func NewPostgresZoneRepository(pool *pgxpool.Pool) *PostgresZoneRepository {
+	return &PostgresZoneRepository{pool: pool}
+}
+
+// Create creates a new zone
+func (r *PostgresZoneRepository) Create(ctx context.Context, zone *domain.Zone) error {
+	query := `
+		INSERT INTO venue_zones (id, venue_id, name, capacity, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := r.pool.Exec(ctx, query,
+		zone.ID,
+		zone.VenueID,
+		zone.Name,
+		zone.Capacity,
+		zone.CreatedAt,
+		zone.UpdatedAt,
+	)
+	return err
+}
+
+// GetByID retrieves a zone by ID
+func (r *PostgresZoneRepository) GetByID(ctx context.Context, id string) (*domain.Zone, error) {
+	query := `
+		SELECT id, venue_id, name, capacity, created_at, updated_at
+		FROM venue_zones
+		WHERE id = $1
+	`
+	zone := &domain.Zone{}
+	err := r.pool.QueryRow(ctx, query, id).Scan(
+		&zone.ID,
+		&zone.VenueID,
+		&zone.Name,
+		&zone.Capacity,
+		&zone.CreatedAt,
+		&zone.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return zone, nil
+}
+
+// GetByVenueID retrieves zones by venue ID
+func (r *PostgresZoneRepository) GetByVenueID(ctx context.Context, venueID string) ([]*domain.Zone, error) {
+	query := `
+		SELECT id, venue_id, name, capacity, created_at, updated_at
+		FROM venue_zones
+		WHERE venue_id = $1
+		ORDER BY created_at ASC
+	`
+	rows, err := r.pool.Query(ctx, query, venueID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var zones []*domain.Zone
+	for rows.Next() {
+		zone := &domain.Zone{}
+		err := rows.Scan(
+			&zone.ID,
+			&zone.VenueID,
+			&zone.Name,
+			&zone.Capacity,
+			&zone.CreatedAt,
+			&zone.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		zones = append(zones, zone)
+	}
+	return zones, rows.Err()
+}
+
+// Update updates a zone
+func (r *PostgresZoneRepository) Update(ctx context.Context, zone *domain.Zone) error {
+	query := `
+		UPDATE venue_zones
+		SET name = $2, capacity = $3, updated_at = $4
+		WHERE id = $1
+	`
+	zone.UpdatedAt = time.Now()
+	result, err := r.pool.Exec(ctx, query,
+		zone.ID,
+		zone.Name,
+		zone.Capacity,
+		zone.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return errors.New("zone not found")
+	}
+	return nil
+}
+
+// Delete deletes a zone by ID
+func (r *PostgresZoneRepository) Delete(ctx context.Context, id string) error {
+	query := `DELETE FROM venue_zones WHERE id = $1`
+	result, err := r.pool.Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return errors.New("zone not found")
+	}
+	return nil
+}
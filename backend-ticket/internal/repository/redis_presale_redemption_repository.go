@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+)
+
+//go:embed scripts/redeem_presale_code.lua
+var redeemPresaleCodeScript string
+
+// scriptRedeemPresaleCode is the cache name of the redemption script
+const scriptRedeemPresaleCode = "redeem_presale_code"
+
+// RedisPresaleRedemptionRepository implements PresaleRedemptionRepository using Redis.
+// Per-code use counters are presale:uses:{code_id}, redeeming users presale:users:{code_id}.
+type RedisPresaleRedemptionRepository struct {
+	client *redis.Client
+}
+
+// NewRedisPresaleRedemptionRepository creates a new RedisPresaleRedemptionRepository
+func NewRedisPresaleRedemptionRepository(client *redis.Client) *RedisPresaleRedemptionRepository {
+	return &RedisPresaleRedemptionRepository{client: client}
+}
+
+// LoadScripts pre-loads the redemption script into Redis
+func (r *RedisPresaleRedemptionRepository) LoadScripts(ctx context.Context) error {
+	if _, err := r.client.LoadScript(ctx, scriptRedeemPresaleCode, redeemPresaleCodeScript); err != nil {
+		return fmt.Errorf("failed to load script %s: %w", scriptRedeemPresaleCode, err)
+	}
+	return nil
+}
+
+func presaleUsesKey(codeID string) string {
+	return fmt.Sprintf("presale:uses:%s", codeID)
+}
+
+func presaleUsersKey(codeID string) string {
+	return fmt.Sprintf("presale:users:%s", codeID)
+}
+
+// Redeem atomically consumes a use of the code for the user
+func (r *RedisPresaleRedemptionRepository) Redeem(ctx context.Context, code *domain.PresaleCode, userID string, ttl time.Duration) (*domain.PresaleRedemption, error) {
+	keys := []string{presaleUsesKey(code.ID), presaleUsersKey(code.ID)}
+	args := []interface{}{
+		userID,                     // ARGV[1]: user_id
+		code.MaxUses,               // ARGV[2]: max_uses
+		code.RedeemedCount,         // ARGV[3]: seed_uses
+		int64(ttl/time.Second) + 1, // ARGV[4]: ttl_seconds
+	}
+
+	result := r.client.EvalWithFallback(ctx, scriptRedeemPresaleCode, redeemPresaleCodeScript, keys, args...)
+	if result.Err() != nil {
+		return nil, fmt.Errorf("failed to execute redeem_presale_code script: %w", result.Err())
+	}
+
+	values, err := result.Int64Slice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse script result: %w", err)
+	}
+	if len(values) < 3 {
+		return nil, fmt.Errorf("unexpected script result length: %d", len(values))
+	}
+
+	return &domain.PresaleRedemption{
+		Redeemed:        values[0] == 1,
+		Uses:            values[1],
+		AlreadyRedeemed: values[2] == 1,
+	}, nil
+}
+
+// Status returns the code's live use count (-1 if not tracked) and whether the user redeemed it
+func (r *RedisPresaleRedemptionRepository) Status(ctx context.Context, codeID, userID string) (int64, bool, error) {
+	pipe := r.client.Pipeline()
+	usesCmd := pipe.Get(ctx, presaleUsesKey(codeID))
+	redeemedCmd := pipe.SIsMember(ctx, presaleUsersKey(codeID), userID)
+	// A missing counter fails the GET with redis: nil; that is not an error here
+	if _, err := pipe.Exec(ctx); err != nil && err.Error() != "redis: nil" {
+		return 0, false, fmt.Errorf("failed to read presale code status: %w", err)
+	}
+
+	uses := int64(-1)
+	if val, err := usesCmd.Result(); err == nil {
+		uses, err = strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid use count for presale code %s: %w", codeID, err)
+		}
+	}
+	return uses, redeemedCmd.Val(), nil
+}
+
+// Ensure RedisPresaleRedemptionRepository implements PresaleRedemptionRepository
+var _ PresaleRedemptionRepository = (*RedisPresaleRedemptionRepository)(nil)
@@ -0,0 +1,46 @@
+--[[
+    Redeem Presale Code Lua Script
+    ==============================
+    Atomically consumes a use of a presale code for a user, enforcing the code's
+    usage limit. A user redeeming the same code again keeps their earlier redemption
+    and does not consume another use (e.g. re-joining the queue).
+
+    Key Structure:
+    - KEYS[1]: presale:uses:{code_id} - String (uses so far)
+    - KEYS[2]: presale:users:{code_id} - Set (user_ids that redeemed the code)
+
+    Arguments:
+    - ARGV[1]: user_id
+    - ARGV[2]: max_uses
+    - ARGV[3]: seed_uses (persisted count, used if the counter is not tracked yet)
+    - ARGV[4]: ttl_seconds
+
+    Returns:
+    - {1, uses, 0} on redemption
+    - {1, uses, 1} if the user already redeemed the code
+    - {0, uses, 0} if the code has no uses left
+--]]
+
+local uses_key = KEYS[1]
+local users_key = KEYS[2]
+local user_id = ARGV[1]
+local max_uses = tonumber(ARGV[2])
+local seed_uses = tonumber(ARGV[3])
+local ttl = tonumber(ARGV[4])
+
+local uses = tonumber(redis.call("GET", uses_key) or seed_uses)
+
+if redis.call("SISMEMBER", users_key, user_id) == 1 then
+    return {1, uses, 1}
+end
+
+if uses >= max_uses then
+    return {0, uses, 0}
+end
+
+uses = uses + 1
+redis.call("SET", uses_key, uses, "EX", ttl)
+redis.call("SADD", users_key, user_id)
+redis.call("EXPIRE", users_key, ttl)
+
+return {1, uses, 0}
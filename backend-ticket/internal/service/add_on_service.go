@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/repository"
+)
+
+// Add-on errors
+var (
+	ErrAddOnNotFound = errors.New("add-on not found")
+	ErrInvalidAddOn  = errors.New("invalid add-on")
+)
+
+// addOnService implements AddOnService
+type addOnService struct {
+	addOnRepo     repository.EventAddOnRepository
+	eventRepo     repository.EventRepository
+	accessService EventAccessService
+	now           func() time.Time
+}
+
+// NewAddOnService creates a new AddOnService. Changes reach booking-service when its
+// cached add-on list expires; units already sold are kept.
+func NewAddOnService(addOnRepo repository.EventAddOnRepository, eventRepo repository.EventRepository, accessService EventAccessService) AddOnService {
+	return &addOnService{
+		addOnRepo:     addOnRepo,
+		eventRepo:     eventRepo,
+		accessService: accessService,
+		now:           time.Now,
+	}
+}
+
+// ListAddOns lists an event's add-ons by sort order, inactive ones included
+func (s *addOnService) ListAddOns(ctx context.Context, eventID string) ([]*domain.EventAddOn, error) {
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if event == nil {
+		return nil, ErrEventNotFound
+	}
+	return s.addOnRepo.ListByEvent(ctx, eventID)
+}
+
+// CreateAddOn adds an add-on to an event; requires events:edit on the event
+func (s *addOnService) CreateAddOn(ctx context.Context, actor *domain.Actor, eventID string, req *dto.CreateAddOnRequest) (*domain.EventAddOn, error) {
+	if valid, msg := req.Validate(); !valid {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidAddOn, msg)
+	}
+	event, err := s.accessService.AuthorizeEvent(ctx, actor, eventID, domain.PermissionEventEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	addOn := &domain.EventAddOn{
+		ID:        uuid.New().String(),
+		EventID:   event.ID,
+		CreatedAt: now,
+	}
+	applyAddOnRequest(addOn, req, now)
+	if err := s.addOnRepo.Create(ctx, addOn); err != nil {
+		return nil, err
+	}
+	return addOn, nil
+}
+
+// UpdateAddOn replaces the settings of an event's add-on; requires events:edit on the event
+func (s *addOnService) UpdateAddOn(ctx context.Context, actor *domain.Actor, eventID, addOnID string, req *dto.UpdateAddOnRequest) (*domain.EventAddOn, error) {
+	if valid, msg := req.Validate(); !valid {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidAddOn, msg)
+	}
+	if _, err := s.accessService.AuthorizeEvent(ctx, actor, eventID, domain.PermissionEventEdit); err != nil {
+		return nil, err
+	}
+	addOn, err := s.addOnRepo.GetByID(ctx, eventID, addOnID)
+	if err != nil {
+		return nil, err
+	}
+	if addOn == nil {
+		return nil, ErrAddOnNotFound
+	}
+
+	applyAddOnRequest(addOn, (*dto.CreateAddOnRequest)(req), s.now())
+	if err := s.addOnRepo.Update(ctx, addOn); err != nil {
+		return nil, err
+	}
+	return addOn, nil
+}
+
+// DeleteAddOn removes an event's add-on; bookings keep the add-ons they were sold
+func (s *addOnService) DeleteAddOn(ctx context.Context, actor *domain.Actor, eventID, addOnID string) error {
+	if _, err := s.accessService.AuthorizeEvent(ctx, actor, eventID, domain.PermissionEventEdit); err != nil {
+		return err
+	}
+	deleted, err := s.addOnRepo.Delete(ctx, eventID, addOnID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrAddOnNotFound
+	}
+	return nil
+}
+
+// applyAddOnRequest copies the settings of a create or update request onto an add-on
+func applyAddOnRequest(addOn *domain.EventAddOn, req *dto.CreateAddOnRequest, now time.Time) {
+	addOn.Name = strings.TrimSpace(req.Name)
+	addOn.Description = strings.TrimSpace(req.Description)
+	addOn.Category = req.Category
+	if addOn.Category == "" {
+		addOn.Category = domain.AddOnCategoryOther
+	}
+	addOn.Price = req.Price
+	addOn.Inventory = req.Inventory
+	addOn.MaxPerBooking = req.MaxPerBooking
+	addOn.IsActive = req.IsActive == nil || *req.IsActive
+	addOn.SortOrder = req.SortOrder
+	addOn.UpdatedAt = now
+}
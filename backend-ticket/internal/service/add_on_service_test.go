@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
+)
+
+// MockEventAddOnRepository is a mock implementation of EventAddOnRepository
+type MockEventAddOnRepository struct {
+	addOns map[string]*domain.EventAddOn
+}
+
+func NewMockEventAddOnRepository() *MockEventAddOnRepository {
+	return &MockEventAddOnRepository{
+		addOns: make(map[string]*domain.EventAddOn),
+	}
+}
+
+func (m *MockEventAddOnRepository) Create(ctx context.Context, addOn *domain.EventAddOn) error {
+	m.addOns[addOn.ID] = addOn
+	return nil
+}
+
+func (m *MockEventAddOnRepository) GetByID(ctx context.Context, eventID, id string) (*domain.EventAddOn, error) {
+	if addOn, ok := m.addOns[id]; ok && addOn.EventID == eventID {
+		return addOn, nil
+	}
+	return nil, nil
+}
+
+func (m *MockEventAddOnRepository) ListByEvent(ctx context.Context, eventID string) ([]*domain.EventAddOn, error) {
+	var addOns []*domain.EventAddOn
+	for _, addOn := range m.addOns {
+		if addOn.EventID == eventID {
+			addOns = append(addOns, addOn)
+		}
+	}
+	return addOns, nil
+}
+
+func (m *MockEventAddOnRepository) Update(ctx context.Context, addOn *domain.EventAddOn) error {
+	m.addOns[addOn.ID] = addOn
+	return nil
+}
+
+func (m *MockEventAddOnRepository) Delete(ctx context.Context, eventID, id string) (bool, error) {
+	if addOn, ok := m.addOns[id]; ok && addOn.EventID == eventID {
+		delete(m.addOns, id)
+		return true, nil
+	}
+	return false, nil
+}
+
+func newAddOnTestService() (AddOnService, *MockEventAddOnRepository) {
+	accessService, _ := newAccessTestService()
+	eventRepo := NewMockEventRepository()
+	eventRepo.Create(context.Background(), &domain.Event{ID: "event-1", TenantID: "tenant-1", OrganizerID: "owner", CreatedAt: time.Now()})
+	addOnRepo := NewMockEventAddOnRepository()
+	return NewAddOnService(addOnRepo, eventRepo, accessService), addOnRepo
+}
+
+func TestAddOnService_CreateAddOn(t *testing.T) {
+	svc, addOnRepo := newAddOnTestService()
+	owner := &domain.Actor{UserID: "owner", TenantID: "tenant-1", Role: "organizer"}
+
+	addOn, err := svc.CreateAddOn(context.Background(), owner, "event-1", &dto.CreateAddOnRequest{
+		Name:          " Parking ",
+		Price:         200,
+		Inventory:     50,
+		MaxPerBooking: 1,
+	})
+	if err != nil {
+		t.Fatalf("CreateAddOn() error = %v", err)
+	}
+	if addOn.Name != "Parking" || addOn.Category != domain.AddOnCategoryOther || !addOn.IsActive {
+		t.Errorf("CreateAddOn() = %+v, want trimmed name, category other and active", addOn)
+	}
+	if _, ok := addOnRepo.addOns[addOn.ID]; !ok {
+		t.Error("CreateAddOn() did not store the add-on")
+	}
+
+	outsider := &domain.Actor{UserID: "outsider", TenantID: "tenant-1", Role: "organizer"}
+	_, err = svc.CreateAddOn(context.Background(), outsider, "event-1", &dto.CreateAddOnRequest{Name: "T-shirt", Price: 350})
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("CreateAddOn() by outsider error = %v, want ErrUnauthorized", err)
+	}
+
+	_, err = svc.CreateAddOn(context.Background(), owner, "event-1", &dto.CreateAddOnRequest{Name: "T-shirt", Category: "food"})
+	if !errors.Is(err, ErrInvalidAddOn) {
+		t.Errorf("CreateAddOn() with unknown category error = %v, want ErrInvalidAddOn", err)
+	}
+}
+
+func TestAddOnService_UpdateAndDeleteAddOn(t *testing.T) {
+	svc, _ := newAddOnTestService()
+	editor := &domain.Actor{UserID: "editor", TenantID: "tenant-1", Role: "organizer"}
+	ctx := context.Background()
+
+	addOn, err := svc.CreateAddOn(ctx, editor, "event-1", &dto.CreateAddOnRequest{Name: "T-shirt", Category: domain.AddOnCategoryMerchandise, Price: 350})
+	if err != nil {
+		t.Fatalf("CreateAddOn() error = %v", err)
+	}
+
+	inactive := false
+	updated, err := svc.UpdateAddOn(ctx, editor, "event-1", addOn.ID, &dto.UpdateAddOnRequest{Name: "T-shirt", Category: domain.AddOnCategoryMerchandise, Price: 300, IsActive: &inactive})
+	if err != nil {
+		t.Fatalf("UpdateAddOn() error = %v", err)
+	}
+	if updated.Price != 300 || updated.IsActive {
+		t.Errorf("UpdateAddOn() = %+v, want price 300 and inactive", updated)
+	}
+
+	if _, err := svc.UpdateAddOn(ctx, editor, "event-1", "missing", &dto.UpdateAddOnRequest{Name: "Cap"}); !errors.Is(err, ErrAddOnNotFound) {
+		t.Errorf("UpdateAddOn() of missing add-on error = %v, want ErrAddOnNotFound", err)
+	}
+
+	if err := svc.DeleteAddOn(ctx, editor, "event-1", addOn.ID); err != nil {
+		t.Fatalf("DeleteAddOn() error = %v", err)
+	}
+	if err := svc.DeleteAddOn(ctx, editor, "event-1", addOn.ID); !errors.Is(err, ErrAddOnNotFound) {
+		t.Errorf("DeleteAddOn() twice error = %v, want ErrAddOnNotFound", err)
+	}
+}
+
+func TestAddOnService_ListAddOns(t *testing.T) {
+	svc, _ := newAddOnTestService()
+	owner := &domain.Actor{UserID: "owner", TenantID: "tenant-1", Role: "organizer"}
+	ctx := context.Background()
+
+	if _, err := svc.CreateAddOn(ctx, owner, "event-1", &dto.CreateAddOnRequest{Name: "Parking", Category: domain.AddOnCategoryParking, Price: 200}); err != nil {
+		t.Fatalf("CreateAddOn() error = %v", err)
+	}
+
+	addOns, err := svc.ListAddOns(ctx, "event-1")
+	if err != nil || len(addOns) != 1 {
+		t.Errorf("ListAddOns() = %d add-ons, %v, want 1", len(addOns), err)
+	}
+	if _, err := svc.ListAddOns(ctx, "missing"); !errors.Is(err, ErrEventNotFound) {
+		t.Errorf("ListAddOns() of missing event error = %v, want ErrEventNotFound", err)
+	}
+}
@@ -0,0 +1,298 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
+)
+
+// Cache warm-up defaults
+const (
+	DefaultCacheWarmupWindow    = 24 * time.Hour
+	DefaultCacheWarmupMaxEvents = 500
+
+	// Page sizes match the handlers' default list queries so warmed keys are the ones clients hit
+	warmupPageSize          = 20
+	warmupPublishedPageSize = 50
+	warmupScanPageSize      = 100
+	maxWarmupErrors         = 20
+)
+
+// CacheWarmer pre-populates event/show/zone caches, e.g. after a deploy leaves Redis cold
+type CacheWarmer interface {
+	// WarmUp warms the requested events, or the events with upcoming on-sales when none are given.
+	// Individual failures are reported in the response and do not stop the run.
+	WarmUp(ctx context.Context, req *dto.WarmUpRequest) (*dto.WarmUpResponse, error)
+}
+
+// CacheWarmerConfig contains configuration for the cache warmer
+type CacheWarmerConfig struct {
+	// EventIDs are warmed when a request names no events; takes precedence over Window
+	EventIDs []string
+	// Window selects published events with a show on sale or going on sale within it
+	Window time.Duration
+	// MaxEvents bounds how many published events are scanned for the window
+	MaxEvents int
+}
+
+// cacheWarmer implements CacheWarmer by reading through the cached repositories
+type cacheWarmer struct {
+	eventRepo    repository.EventRepository
+	showRepo     repository.ShowRepository
+	showZoneRepo repository.ShowZoneRepository
+	zoneSyncer   ZoneSyncer
+	cfg          CacheWarmerConfig
+	now          func() time.Time
+}
+
+// NewCacheWarmer creates a new CacheWarmer
+func NewCacheWarmer(eventRepo repository.EventRepository, showRepo repository.ShowRepository, showZoneRepo repository.ShowZoneRepository, zoneSyncer ZoneSyncer, cfg *CacheWarmerConfig) CacheWarmer {
+	c := CacheWarmerConfig{}
+	if cfg != nil {
+		c = *cfg
+	}
+	if c.Window <= 0 {
+		c.Window = DefaultCacheWarmupWindow
+	}
+	if c.MaxEvents <= 0 {
+		c.MaxEvents = DefaultCacheWarmupMaxEvents
+	}
+	return &cacheWarmer{
+		eventRepo:    eventRepo,
+		showRepo:     showRepo,
+		showZoneRepo: showZoneRepo,
+		zoneSyncer:   zoneSyncer,
+		cfg:          c,
+		now:          time.Now,
+	}
+}
+
+// warmupRun collects the results of a single warm-up
+type warmupRun struct {
+	resp *dto.WarmUpResponse
+}
+
+func (r *warmupRun) fail(format string, args ...interface{}) {
+	if len(r.resp.Errors) < maxWarmupErrors {
+		r.resp.Errors = append(r.resp.Errors, fmt.Sprintf(format, args...))
+	}
+}
+
+// WarmUp warms event/show/zone caches
+func (w *cacheWarmer) WarmUp(ctx context.Context, req *dto.WarmUpRequest) (*dto.WarmUpResponse, error) {
+	if req == nil {
+		req = &dto.WarmUpRequest{}
+	}
+	if valid, msg := req.Validate(); !valid {
+		return nil, fmt.Errorf("invalid warm-up request: %s", msg)
+	}
+
+	start := w.now()
+	run := &warmupRun{resp: &dto.WarmUpResponse{}}
+
+	eventIDs := req.EventIDs
+	window := time.Duration(req.WindowHours) * time.Hour
+	if len(eventIDs) == 0 && window == 0 {
+		eventIDs = w.cfg.EventIDs
+		window = w.cfg.Window
+	}
+
+	if len(eventIDs) > 0 {
+		w.warmEventIDs(ctx, run, eventIDs)
+	} else {
+		w.warmUpcoming(ctx, run, start.Add(window))
+	}
+
+	if err := ctx.Err(); err != nil {
+		run.fail("warm-up stopped early: %v", err)
+	}
+	run.resp.DurationMs = w.now().Sub(start).Milliseconds()
+	return run.resp, nil
+}
+
+// warmEventIDs warms the given events and all their shows that are not over
+func (w *cacheWarmer) warmEventIDs(ctx context.Context, run *warmupRun, eventIDs []string) {
+	for _, id := range eventIDs {
+		if ctx.Err() != nil {
+			return
+		}
+
+		event, err := w.eventRepo.GetByID(ctx, id)
+		if err != nil {
+			run.fail("event %s: %v", id, err)
+			continue
+		}
+		if event == nil {
+			run.fail("event %s: %v", id, ErrEventNotFound)
+			continue
+		}
+
+		shows, err := w.loadShows(ctx, id)
+		if err != nil {
+			run.fail("shows for event %s: %v", id, err)
+			continue
+		}
+
+		var active []*domain.Show
+		for _, show := range shows {
+			if show.Status != domain.ShowStatusCancelled && show.Status != domain.ShowStatusCompleted {
+				active = append(active, show)
+			}
+		}
+		w.warmEvent(ctx, run, event, active)
+	}
+}
+
+// warmUpcoming warms the published event list and the published events with a show
+// on sale or going on sale before windowEnd
+func (w *cacheWarmer) warmUpcoming(ctx context.Context, run *warmupRun, windowEnd time.Time) {
+	// First page of the public event list is the hottest read after a deploy.
+	// The service fetches one extra row per page, so warm the same key it reads.
+	firstPage := pagination.Params{Limit: warmupPublishedPageSize}.Lookahead()
+	if _, _, err := w.eventRepo.ListPublished(ctx, firstPage); err != nil {
+		run.fail("published events: %v", err)
+	}
+
+	scanned := 0
+	for offset := 0; scanned < w.cfg.MaxEvents; offset += warmupScanPageSize {
+		events, total, err := w.eventRepo.ListPublished(ctx, pagination.Params{Limit: warmupScanPageSize, Offset: offset})
+		if err != nil {
+			run.fail("published events: %v", err)
+			return
+		}
+
+		for _, event := range events {
+			if ctx.Err() != nil || scanned >= w.cfg.MaxEvents {
+				return
+			}
+			scanned++
+
+			shows, err := w.loadShows(ctx, event.ID)
+			if err != nil {
+				run.fail("shows for event %s: %v", event.ID, err)
+				continue
+			}
+
+			var upcoming []*domain.Show
+			for _, show := range shows {
+				if w.isUpcoming(show, windowEnd) {
+					upcoming = append(upcoming, show)
+				}
+			}
+			if len(upcoming) > 0 {
+				w.warmEvent(ctx, run, event, upcoming)
+			}
+		}
+
+		if len(events) == 0 || offset+warmupScanPageSize >= total {
+			return
+		}
+	}
+}
+
+// isUpcoming reports whether a show is on sale or scheduled to go on sale before windowEnd
+func (w *cacheWarmer) isUpcoming(show *domain.Show, windowEnd time.Time) bool {
+	switch show.Status {
+	case domain.ShowStatusOnSale:
+		return true
+	case domain.ShowStatusScheduled:
+		return show.SaleStartAt != nil && show.SaleStartAt.Before(windowEnd)
+	default:
+		return false
+	}
+}
+
+// loadShows reads every show page of an event, which also warms the show list caches
+func (w *cacheWarmer) loadShows(ctx context.Context, eventID string) ([]*domain.Show, error) {
+	var shows []*domain.Show
+	seen := make(map[string]bool)
+	for offset := 0; ; offset += warmupPageSize {
+		page, total, err := w.showRepo.GetByEventID(ctx, eventID, warmupPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		for _, show := range page {
+			if !seen[show.ID] {
+				seen[show.ID] = true
+				shows = append(shows, show)
+			}
+		}
+		if len(page) == 0 || offset+warmupPageSize >= total {
+			return shows, nil
+		}
+	}
+}
+
+// warmEvent warms an event's detail caches and the given shows with their zones
+func (w *cacheWarmer) warmEvent(ctx context.Context, run *warmupRun, event *domain.Event, shows []*domain.Show) {
+	if _, err := w.eventRepo.GetByID(ctx, event.ID); err != nil {
+		run.fail("event %s: %v", event.ID, err)
+		return
+	}
+	if event.Slug != "" {
+		if _, err := w.eventRepo.GetBySlug(ctx, event.Slug); err != nil {
+			run.fail("event %s: %v", event.ID, err)
+		}
+	}
+	run.resp.Events++
+
+	if w.zoneSyncer != nil {
+		if err := w.zoneSyncer.SyncEventHoldTTL(ctx, event); err != nil {
+			run.fail("event %s hold ttl: %v", event.ID, err)
+		}
+		if err := w.zoneSyncer.SyncEventVerificationPolicy(ctx, event); err != nil {
+			run.fail("event %s verification policy: %v", event.ID, err)
+		}
+	}
+
+	for _, show := range shows {
+		if ctx.Err() != nil {
+			return
+		}
+		w.warmShow(ctx, run, show)
+	}
+}
+
+// warmShow warms a show's detail and zone list caches. Zones of on-sale shows missing
+// from Redis inventory are seeded from Postgres; tracked zones keep their live counts.
+func (w *cacheWarmer) warmShow(ctx context.Context, run *warmupRun, show *domain.Show) {
+	if _, err := w.showRepo.GetByID(ctx, show.ID); err != nil {
+		run.fail("show %s: %v", show.ID, err)
+		return
+	}
+	run.resp.Shows++
+
+	for offset := 0; ; offset += warmupPageSize {
+		zones, total, err := w.showZoneRepo.GetByShowID(ctx, show.ID, nil, warmupPageSize, offset)
+		if err != nil {
+			run.fail("zones for show %s: %v", show.ID, err)
+			return
+		}
+		run.resp.Zones += len(zones)
+
+		if show.Status == domain.ShowStatusOnSale && w.zoneSyncer != nil {
+			for _, zone := range zones {
+				if !zone.IsActive {
+					continue
+				}
+				seeded, err := w.zoneSyncer.SeedZone(ctx, zone)
+				if err != nil {
+					run.fail("seed zone %s: %v", zone.ID, err)
+					continue
+				}
+				if seeded {
+					run.resp.ZonesSeeded++
+				}
+			}
+		}
+
+		if len(zones) == 0 || offset+warmupPageSize >= total {
+			return
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
+)
+
+// newWarmupFixture creates two published events:
+//   - evt-onsale has an on-sale show with an active zone already tracked in Redis,
+//     an active zone that is not, and an inactive zone
+//   - evt-later has a show going on sale in 3 days and a cancelled show
+func newWarmupFixture(now time.Time) (*MockEventRepository, *MockShowRepository, *MockShowZoneRepository, *MockZoneSyncerForZone) {
+	eventRepo := NewMockEventRepository()
+	showRepo := NewMockShowRepository()
+	zoneRepo := NewMockShowZoneRepository()
+	syncer := NewMockZoneSyncerForZone()
+
+	eventRepo.Create(context.Background(), &domain.Event{ID: "evt-onsale", Slug: "onsale", Status: domain.EventStatusPublished})
+	eventRepo.Create(context.Background(), &domain.Event{ID: "evt-later", Slug: "later", Status: domain.EventStatusPublished})
+
+	later := now.Add(72 * time.Hour)
+	showRepo.Create(context.Background(), &domain.Show{ID: "show-onsale", EventID: "evt-onsale", Status: domain.ShowStatusOnSale})
+	showRepo.Create(context.Background(), &domain.Show{ID: "show-later", EventID: "evt-later", Status: domain.ShowStatusScheduled, SaleStartAt: &later})
+	showRepo.Create(context.Background(), &domain.Show{ID: "show-cancelled", EventID: "evt-later", Status: domain.ShowStatusCancelled})
+
+	zoneRepo.AddZone(&domain.ShowZone{ID: "zone-live", ShowID: "show-onsale", AvailableSeats: 100, IsActive: true})
+	zoneRepo.AddZone(&domain.ShowZone{ID: "zone-cold", ShowID: "show-onsale", AvailableSeats: 50, IsActive: true})
+	zoneRepo.AddZone(&domain.ShowZone{ID: "zone-off", ShowID: "show-onsale", AvailableSeats: 10, IsActive: false})
+	zoneRepo.AddZone(&domain.ShowZone{ID: "zone-later", ShowID: "show-later", AvailableSeats: 80, IsActive: true})
+
+	// zone-live has a live counter net of holds
+	syncer.availability["zone-live"] = 42
+
+	return eventRepo, showRepo, zoneRepo, syncer
+}
+
+func TestCacheWarmer_WarmUp_Window(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	eventRepo, showRepo, zoneRepo, syncer := newWarmupFixture(now)
+	warmer := NewCacheWarmer(eventRepo, showRepo, zoneRepo, syncer, &CacheWarmerConfig{Window: 24 * time.Hour}).(*cacheWarmer)
+	warmer.now = func() time.Time { return now }
+
+	resp, err := warmer.WarmUp(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("WarmUp() error = %v", err)
+	}
+
+	if resp.Events != 1 || resp.Shows != 1 || resp.Zones != 3 {
+		t.Errorf("WarmUp() warmed %d events, %d shows, %d zones, want 1, 1, 3", resp.Events, resp.Shows, resp.Zones)
+	}
+	if resp.ZonesSeeded != 1 {
+		t.Errorf("ZonesSeeded = %d, want 1", resp.ZonesSeeded)
+	}
+	if got := syncer.availability["zone-live"]; got != 42 {
+		t.Errorf("live zone availability = %d, want 42 (must not be overwritten)", got)
+	}
+	if got, ok := syncer.availability["zone-cold"]; !ok || got != 50 {
+		t.Errorf("cold zone availability = %d, %v, want 50 seeded", got, ok)
+	}
+	if _, ok := syncer.availability["zone-off"]; ok {
+		t.Error("inactive zone should not be seeded")
+	}
+	if len(resp.Errors) != 0 {
+		t.Errorf("Errors = %v, want none", resp.Errors)
+	}
+}
+
+func TestCacheWarmer_WarmUp_RequestWindow(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	eventRepo, showRepo, zoneRepo, syncer := newWarmupFixture(now)
+	warmer := NewCacheWarmer(eventRepo, showRepo, zoneRepo, syncer, nil).(*cacheWarmer)
+	warmer.now = func() time.Time { return now }
+
+	resp, err := warmer.WarmUp(context.Background(), &dto.WarmUpRequest{WindowHours: 96})
+	if err != nil {
+		t.Fatalf("WarmUp() error = %v", err)
+	}
+
+	// The scheduled show now falls in the window; its zones are warmed but not seeded
+	if resp.Events != 2 || resp.Shows != 2 || resp.Zones != 4 {
+		t.Errorf("WarmUp() warmed %d events, %d shows, %d zones, want 2, 2, 4", resp.Events, resp.Shows, resp.Zones)
+	}
+	if _, ok := syncer.availability["zone-later"]; ok {
+		t.Error("zone of a show not yet on sale should not be seeded")
+	}
+}
+
+func TestCacheWarmer_WarmUp_EventIDs(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	eventRepo, showRepo, zoneRepo, syncer := newWarmupFixture(now)
+	warmer := NewCacheWarmer(eventRepo, showRepo, zoneRepo, syncer, &CacheWarmerConfig{
+		EventIDs: []string{"evt-later", "evt-missing"},
+	})
+
+	resp, err := warmer.WarmUp(context.Background(), &dto.WarmUpRequest{})
+	if err != nil {
+		t.Fatalf("WarmUp() error = %v", err)
+	}
+
+	// Configured events are warmed regardless of the window, skipping cancelled shows
+	if resp.Events != 1 || resp.Shows != 1 || resp.Zones != 1 {
+		t.Errorf("WarmUp() warmed %d events, %d shows, %d zones, want 1, 1, 1", resp.Events, resp.Shows, resp.Zones)
+	}
+	if len(resp.Errors) != 1 {
+		t.Errorf("Errors = %v, want one error for the missing event", resp.Errors)
+	}
+}
+
+func TestCacheWarmer_WarmUp_InvalidRequest(t *testing.T) {
+	warmer := NewCacheWarmer(NewMockEventRepository(), NewMockShowRepository(), NewMockShowZoneRepository(), nil, nil)
+
+	if _, err := warmer.WarmUp(context.Background(), &dto.WarmUpRequest{WindowHours: -1}); err == nil {
+		t.Error("WarmUp() should reject a negative window")
+	}
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/events"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
+)
+
+// DefaultCapacityEventsTopic is the topic consumed by the inventory worker
+const DefaultCapacityEventsTopic = events.TopicZoneCapacityEvents
+
+// CapacityEventPublisher publishes zone capacity changes and zone lifecycle events for the
+// inventory worker. Both go to the same topic so events for a zone are applied in order.
+type CapacityEventPublisher interface {
+	// PublishCapacityChanged publishes a zone capacity changed event
+	PublishCapacityChanged(ctx context.Context, event *domain.ZoneCapacityChangedEvent) error
+	// PublishZoneEvent publishes a zone created or updated event
+	PublishZoneEvent(ctx context.Context, event *domain.ZoneEvent) error
+	// Close closes the publisher
+	Close() error
+}
+
+// CapacityEventPublisherConfig contains configuration for the capacity event publisher
+type CapacityEventPublisherConfig struct {
+	Brokers  []string
+	Topic    string
+	ClientID string
+}
+
+// kafkaCapacityEventPublisher implements CapacityEventPublisher using Kafka
+type kafkaCapacityEventPublisher struct {
+	producer *kafka.Producer
+	topic    string
+}
+
+// NewKafkaCapacityEventPublisher creates a new Kafka capacity event publisher
+func NewKafkaCapacityEventPublisher(ctx context.Context, cfg *CapacityEventPublisherConfig) (CapacityEventPublisher, error) {
+	if cfg == nil || len(cfg.Brokers) == 0 {
+		return nil, fmt.Errorf("kafka brokers are required")
+	}
+
+	topic := cfg.Topic
+	if topic == "" {
+		topic = DefaultCapacityEventsTopic
+	}
+
+	clientID := cfg.ClientID
+	if clientID == "" {
+		clientID = "ticket-service-producer"
+	}
+
+	producer, err := kafka.NewProducer(ctx, &kafka.ProducerConfig{
+		Brokers:       cfg.Brokers,
+		ClientID:      clientID,
+		MaxRetries:    3,
+		RetryInterval: 2 * time.Second,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka producer: %w", err)
+	}
+
+	return &kafkaCapacityEventPublisher{
+		producer: producer,
+		topic:    topic,
+	}, nil
+}
+
+// PublishCapacityChanged publishes synchronously so the organizer learns about a failed
+// hand-off instead of assuming the change is in flight.
+// Events are keyed by zone ID so changes to the same zone are applied in order.
+func (p *kafkaCapacityEventPublisher) PublishCapacityChanged(ctx context.Context, event *domain.ZoneCapacityChangedEvent) error {
+	headers := map[string]string{
+		"event_type":   event.EventType,
+		"event_id":     event.EventID,
+		"source":       "ticket-service",
+		"content_type": "application/json",
+	}
+	return p.producer.ProduceJSON(ctx, p.topic, event.ZoneID, event, headers)
+}
+
+// PublishZoneEvent publishes a zone lifecycle event, keyed by zone ID like capacity changes
+func (p *kafkaCapacityEventPublisher) PublishZoneEvent(ctx context.Context, event *domain.ZoneEvent) error {
+	headers := map[string]string{
+		"event_type":   event.EventType,
+		"event_id":     event.EventID,
+		"source":       "ticket-service",
+		"content_type": "application/json",
+	}
+	return p.producer.ProduceJSON(ctx, p.topic, event.ZoneID, event, headers)
+}
+
+// Close closes the publisher
+func (p *kafkaCapacityEventPublisher) Close() error {
+	p.producer.Close()
+	return nil
+}
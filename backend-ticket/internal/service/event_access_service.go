@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/repository"
+)
+
+// Team errors
+var (
+	ErrTeamMemberNotFound = errors.New("team member not found")
+	ErrCannotModifySelf   = errors.New("cannot change your own team membership")
+)
+
+// eventAccessService implements EventAccessService
+type eventAccessService struct {
+	eventRepo      repository.EventRepository
+	teamMemberRepo repository.TeamMemberRepository
+}
+
+// NewEventAccessService creates a new EventAccessService
+func NewEventAccessService(eventRepo repository.EventRepository, teamMemberRepo repository.TeamMemberRepository) EventAccessService {
+	return &eventAccessService{
+		eventRepo:      eventRepo,
+		teamMemberRepo: teamMemberRepo,
+	}
+}
+
+// AuthorizeEvent returns the event if the actor holds permission on it.
+// Admins can manage every event and organizers their own. Other users of the event's
+// tenant need a team membership granting the permission. Events of another tenant are
+// reported as not found so their existence is not leaked.
+func (s *eventAccessService) AuthorizeEvent(ctx context.Context, actor *domain.Actor, eventID, permission string) (*domain.Event, error) {
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if event == nil {
+		return nil, ErrEventNotFound
+	}
+
+	if actor.IsAdmin() || event.OrganizerID == actor.UserID {
+		return event, nil
+	}
+	if actor.TenantID == "" || event.TenantID != actor.TenantID {
+		return nil, ErrEventNotFound
+	}
+
+	member, err := s.teamMemberRepo.Get(ctx, actor.TenantID, actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil || !member.HasPermission(permission) {
+		return nil, ErrUnauthorized
+	}
+	return event, nil
+}
+
+// ListTeamMembers lists the team members of the actor's tenant
+func (s *eventAccessService) ListTeamMembers(ctx context.Context, actor *domain.Actor) ([]*domain.TeamMember, error) {
+	if err := s.authorizeTeam(ctx, actor); err != nil {
+		return nil, err
+	}
+	return s.teamMemberRepo.ListByTenant(ctx, actor.TenantID)
+}
+
+// SetTeamMember adds a user to the actor's tenant team or replaces their permissions
+func (s *eventAccessService) SetTeamMember(ctx context.Context, actor *domain.Actor, userID string, req *dto.SetTeamMemberRequest) (*domain.TeamMember, error) {
+	if valid, msg := req.Validate(); !valid {
+		return nil, errors.New(msg)
+	}
+	if err := s.authorizeTeam(ctx, actor); err != nil {
+		return nil, err
+	}
+	// Managers must not be able to lock themselves out or escalate their own rights
+	if userID == actor.UserID && !actor.IsAdmin() {
+		return nil, ErrCannotModifySelf
+	}
+
+	now := time.Now()
+	member := &domain.TeamMember{
+		ID:          uuid.New().String(),
+		TenantID:    actor.TenantID,
+		UserID:      userID,
+		Permissions: dedupePermissions(req.Permissions),
+		AddedBy:     actor.UserID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.teamMemberRepo.Upsert(ctx, member); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// RemoveTeamMember removes a user from the actor's tenant team
+func (s *eventAccessService) RemoveTeamMember(ctx context.Context, actor *domain.Actor, userID string) error {
+	if err := s.authorizeTeam(ctx, actor); err != nil {
+		return err
+	}
+	if userID == actor.UserID && !actor.IsAdmin() {
+		return ErrCannotModifySelf
+	}
+
+	removed, err := s.teamMemberRepo.Delete(ctx, actor.TenantID, userID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrTeamMemberNotFound
+	}
+	return nil
+}
+
+// authorizeTeam checks that the actor may manage their tenant's team: admins, or
+// members granted team:manage
+func (s *eventAccessService) authorizeTeam(ctx context.Context, actor *domain.Actor) error {
+	if actor.TenantID == "" {
+		return ErrUnauthorized
+	}
+	if actor.IsAdmin() {
+		return nil
+	}
+	member, err := s.teamMemberRepo.Get(ctx, actor.TenantID, actor.UserID)
+	if err != nil {
+		return err
+	}
+	if member == nil || !member.HasPermission(domain.PermissionTeamManage) {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// dedupePermissions removes duplicate permissions, keeping the first occurrence
+func dedupePermissions(permissions []string) []string {
+	seen := make(map[string]bool, len(permissions))
+	result := make([]string, 0, len(permissions))
+	for _, p := range permissions {
+		if !seen[p] {
+			seen[p] = true
+			result = append(result, p)
+		}
+	}
+	return result
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
+)
+
+// MockTeamMemberRepository is a mock implementation of TeamMemberRepository
+type MockTeamMemberRepository struct {
+	members map[string]*domain.TeamMember // key: tenantID/userID
+}
+
+func NewMockTeamMemberRepository() *MockTeamMemberRepository {
+	return &MockTeamMemberRepository{
+		members: make(map[string]*domain.TeamMember),
+	}
+}
+
+func (m *MockTeamMemberRepository) Upsert(ctx context.Context, member *domain.TeamMember) error {
+	if existing, ok := m.members[member.TenantID+"/"+member.UserID]; ok {
+		existing.Permissions = member.Permissions
+		*member = *existing
+		return nil
+	}
+	m.members[member.TenantID+"/"+member.UserID] = member
+	return nil
+}
+
+func (m *MockTeamMemberRepository) Get(ctx context.Context, tenantID, userID string) (*domain.TeamMember, error) {
+	return m.members[tenantID+"/"+userID], nil
+}
+
+func (m *MockTeamMemberRepository) ListByTenant(ctx context.Context, tenantID string) ([]*domain.TeamMember, error) {
+	var members []*domain.TeamMember
+	for _, member := range m.members {
+		if member.TenantID == tenantID {
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
+func (m *MockTeamMemberRepository) Delete(ctx context.Context, tenantID, userID string) (bool, error) {
+	if _, ok := m.members[tenantID+"/"+userID]; !ok {
+		return false, nil
+	}
+	delete(m.members, tenantID+"/"+userID)
+	return true, nil
+}
+
+func newAccessTestService() (EventAccessService, *MockTeamMemberRepository) {
+	eventRepo := NewMockEventRepository()
+	eventRepo.Create(context.Background(), &domain.Event{
+		ID:          "event-1",
+		TenantID:    "tenant-1",
+		OrganizerID: "owner",
+		Slug:        "event-1",
+		Status:      domain.EventStatusDraft,
+		CreatedAt:   time.Now(),
+	})
+	teamRepo := NewMockTeamMemberRepository()
+	teamRepo.members["tenant-1/editor"] = &domain.TeamMember{
+		TenantID:    "tenant-1",
+		UserID:      "editor",
+		Permissions: []string{domain.PermissionEventEdit},
+	}
+	teamRepo.members["tenant-1/manager"] = &domain.TeamMember{
+		TenantID:    "tenant-1",
+		UserID:      "manager",
+		Permissions: []string{domain.PermissionTeamManage},
+	}
+	return NewEventAccessService(eventRepo, teamRepo), teamRepo
+}
+
+func TestEventAccessService_AuthorizeEvent(t *testing.T) {
+	svc, _ := newAccessTestService()
+
+	tests := []struct {
+		name       string
+		actor      domain.Actor
+		eventID    string
+		permission string
+		wantErr    error
+	}{
+		{"organizer owns event", domain.Actor{UserID: "owner", TenantID: "tenant-1", Role: "organizer"}, "event-1", domain.PermissionEventDelete, nil},
+		{"admin bypasses ownership", domain.Actor{UserID: "root", TenantID: "tenant-9", Role: "admin"}, "event-1", domain.PermissionEventPublish, nil},
+		{"team member with permission", domain.Actor{UserID: "editor", TenantID: "tenant-1", Role: "organizer"}, "event-1", domain.PermissionEventEdit, nil},
+		{"team member without permission", domain.Actor{UserID: "editor", TenantID: "tenant-1", Role: "organizer"}, "event-1", domain.PermissionEventPublish, ErrUnauthorized},
+		{"organizer of same tenant not on team", domain.Actor{UserID: "stranger", TenantID: "tenant-1", Role: "organizer"}, "event-1", domain.PermissionEventEdit, ErrUnauthorized},
+		{"organizer of another tenant", domain.Actor{UserID: "editor", TenantID: "tenant-2", Role: "organizer"}, "event-1", domain.PermissionEventEdit, ErrEventNotFound},
+		{"missing event", domain.Actor{UserID: "owner", TenantID: "tenant-1", Role: "organizer"}, "missing", domain.PermissionEventEdit, ErrEventNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.AuthorizeEvent(context.Background(), &tt.actor, tt.eventID, tt.permission)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("AuthorizeEvent() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEventAccessService_SetTeamMember(t *testing.T) {
+	svc, teamRepo := newAccessTestService()
+	manager := &domain.Actor{UserID: "manager", TenantID: "tenant-1", Role: "organizer"}
+	req := &dto.SetTeamMemberRequest{Permissions: []string{domain.PermissionEventPublish, domain.PermissionEventPublish}}
+
+	member, err := svc.SetTeamMember(context.Background(), manager, "editor", req)
+	if err != nil {
+		t.Fatalf("SetTeamMember() error = %v", err)
+	}
+	if len(member.Permissions) != 1 || member.Permissions[0] != domain.PermissionEventPublish {
+		t.Errorf("Permissions = %v, want [events:publish]", member.Permissions)
+	}
+	if stored := teamRepo.members["tenant-1/editor"]; stored.HasPermission(domain.PermissionEventEdit) {
+		t.Errorf("expected permissions to be replaced, got %v", stored.Permissions)
+	}
+
+	editor := &domain.Actor{UserID: "editor", TenantID: "tenant-1", Role: "organizer"}
+	if _, err := svc.SetTeamMember(context.Background(), editor, "someone", req); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("SetTeamMember() by non-manager error = %v, want ErrUnauthorized", err)
+	}
+	if _, err := svc.SetTeamMember(context.Background(), manager, "manager", req); !errors.Is(err, ErrCannotModifySelf) {
+		t.Errorf("SetTeamMember() on self error = %v, want ErrCannotModifySelf", err)
+	}
+}
+
+func TestEventAccessService_RemoveTeamMember(t *testing.T) {
+	svc, teamRepo := newAccessTestService()
+	manager := &domain.Actor{UserID: "manager", TenantID: "tenant-1", Role: "organizer"}
+
+	if err := svc.RemoveTeamMember(context.Background(), manager, "editor"); err != nil {
+		t.Fatalf("RemoveTeamMember() error = %v", err)
+	}
+	if _, ok := teamRepo.members["tenant-1/editor"]; ok {
+		t.Error("expected editor to be removed")
+	}
+	if err := svc.RemoveTeamMember(context.Background(), manager, "editor"); !errors.Is(err, ErrTeamMemberNotFound) {
+		t.Errorf("RemoveTeamMember() on missing member error = %v, want ErrTeamMemberNotFound", err)
+	}
+}
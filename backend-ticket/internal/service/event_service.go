@@ -12,6 +12,7 @@ import (
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 )
 
 // Common errors
@@ -24,13 +25,15 @@ var (
 
 // eventService implements EventService
 type eventService struct {
-	eventRepo repository.EventRepository
+	eventRepo  repository.EventRepository
+	zoneSyncer ZoneSyncer
 }
 
 // NewEventService creates a new EventService
-func NewEventService(eventRepo repository.EventRepository) EventService {
+func NewEventService(eventRepo repository.EventRepository, zoneSyncer ZoneSyncer) EventService {
 	return &eventService{
-		eventRepo: eventRepo,
+		eventRepo:  eventRepo,
+		zoneSyncer: zoneSyncer,
 	}
 }
 
@@ -57,44 +60,56 @@ func (s *eventService) CreateEvent(ctx context.Context, req *dto.CreateEventRequ
 	}
 
 	event := &domain.Event{
-		ID:                uuid.New().String(),
-		TenantID:          req.TenantID,
-		OrganizerID:       req.OrganizerID,
-		CategoryID:        req.CategoryID,
-		Name:              req.Name,
-		Slug:              slug,
-		Description:       req.Description,
-		ShortDescription:  req.ShortDescription,
-		PosterURL:         req.PosterURL,
-		BannerURL:         req.BannerURL,
-		Gallery:           req.Gallery,
-		VenueName:         req.VenueName,
-		VenueAddress:      req.VenueAddress,
-		City:              req.City,
-		Country:           req.Country,
-		Latitude:          req.Latitude,
-		Longitude:         req.Longitude,
-		MaxTicketsPerUser: maxTickets,
-		BookingStartAt:    req.BookingStartAt,
-		BookingEndAt:      req.BookingEndAt,
-		Status:            domain.EventStatusDraft,
-		IsFeatured:        false,
-		IsPublic:          true,
-		MetaTitle:         req.MetaTitle,
-		MetaDescription:   req.MetaDescription,
-		Settings:          "{}",
-		CreatedAt:         now,
-		UpdatedAt:         now,
+		ID:                 uuid.New().String(),
+		TenantID:           req.TenantID,
+		OrganizerID:        req.OrganizerID,
+		CategoryID:         req.CategoryID,
+		Name:               req.Name,
+		Slug:               slug,
+		Description:        req.Description,
+		ShortDescription:   req.ShortDescription,
+		PosterURL:          req.PosterURL,
+		BannerURL:          req.BannerURL,
+		Gallery:            req.Gallery,
+		VenueName:          req.VenueName,
+		VenueAddress:       req.VenueAddress,
+		City:               req.City,
+		Country:            req.Country,
+		Latitude:           req.Latitude,
+		Longitude:          req.Longitude,
+		MaxTicketsPerUser:  maxTickets,
+		HoldTTLSeconds:     req.HoldTTLSeconds,
+		VerificationPolicy: req.VerificationPolicy,
+		BookingStartAt:     req.BookingStartAt,
+		BookingEndAt:       req.BookingEndAt,
+		Status:             domain.EventStatusDraft,
+		IsFeatured:         false,
+		IsPublic:           true,
+		MetaTitle:          req.MetaTitle,
+		MetaDescription:    req.MetaDescription,
+		Settings:           "{}",
+		CreatedAt:          now,
+		UpdatedAt:          now,
 	}
 
 	if event.Gallery == nil {
 		event.Gallery = []string{}
 	}
+	if event.VerificationPolicy == "" {
+		event.VerificationPolicy = domain.VerificationPolicyNone
+	}
 
 	if err := s.eventRepo.Create(ctx, event); err != nil {
 		return nil, err
 	}
 
+	if event.HoldTTLSeconds != nil && s.zoneSyncer != nil {
+		_ = s.zoneSyncer.SyncEventHoldTTL(ctx, event)
+	}
+	if event.VerificationPolicy != domain.VerificationPolicyNone && s.zoneSyncer != nil {
+		_ = s.zoneSyncer.SyncEventVerificationPolicy(ctx, event)
+	}
+
 	return event, nil
 }
 
@@ -123,7 +138,7 @@ func (s *eventService) GetEventBySlug(ctx context.Context, slug string) (*domain
 }
 
 // ListEvents lists events with filters and pagination
-func (s *eventService) ListEvents(ctx context.Context, filter *dto.EventListFilter) ([]*domain.Event, int, error) {
+func (s *eventService) ListEvents(ctx context.Context, filter *dto.EventListFilter) ([]*domain.Event, int, pagination.Info, error) {
 	filter.SetDefaults()
 
 	repoFilter := &repository.EventFilter{
@@ -135,18 +150,37 @@ func (s *eventService) ListEvents(ctx context.Context, filter *dto.EventListFilt
 		Search:      filter.Search,
 	}
 
-	return s.eventRepo.List(ctx, repoFilter, filter.Limit, filter.Offset)
+	page := pagination.Params{Limit: filter.Limit, Offset: filter.Offset, After: filter.After}
+	events, total, err := s.eventRepo.List(ctx, repoFilter, page.Lookahead())
+	if err != nil {
+		return nil, 0, pagination.Info{}, err
+	}
+
+	events, info := pagination.Paginate(events, page.Limit, eventCursor)
+	return events, total, info, nil
 }
 
 // ListPublishedEvents lists all published public events
-func (s *eventService) ListPublishedEvents(ctx context.Context, limit, offset int) ([]*domain.Event, int, error) {
-	if limit <= 0 || limit > 100 {
-		limit = 50
+func (s *eventService) ListPublishedEvents(ctx context.Context, page pagination.Params) ([]*domain.Event, int, pagination.Info, error) {
+	if page.Limit <= 0 || page.Limit > 100 {
+		page.Limit = 50
 	}
-	if offset < 0 {
-		offset = 0
+	if page.Offset < 0 || page.After != nil {
+		page.Offset = 0
 	}
-	return s.eventRepo.ListPublished(ctx, limit, offset)
+
+	events, total, err := s.eventRepo.ListPublished(ctx, page.Lookahead())
+	if err != nil {
+		return nil, 0, pagination.Info{}, err
+	}
+
+	events, info := pagination.Paginate(events, page.Limit, eventCursor)
+	return events, total, info, nil
+}
+
+// eventCursor returns the position of an event in list order
+func eventCursor(event *domain.Event) pagination.Cursor {
+	return pagination.Cursor{Time: event.CreatedAt, ID: event.ID}
 }
 
 // UpdateEvent updates an event
@@ -215,6 +249,17 @@ func (s *eventService) UpdateEvent(ctx context.Context, id string, req *dto.Upda
 	if req.MaxTicketsPerUser != nil {
 		event.MaxTicketsPerUser = *req.MaxTicketsPerUser
 	}
+	if req.HoldTTLSeconds != nil {
+		if *req.HoldTTLSeconds == 0 {
+			event.HoldTTLSeconds = nil
+		} else {
+			holdTTL := *req.HoldTTLSeconds
+			event.HoldTTLSeconds = &holdTTL
+		}
+	}
+	if req.VerificationPolicy != nil {
+		event.VerificationPolicy = *req.VerificationPolicy
+	}
 	if req.BookingStartAt != nil {
 		event.BookingStartAt = req.BookingStartAt
 	}
@@ -256,6 +301,15 @@ func (s *eventService) UpdateEvent(ctx context.Context, id string, req *dto.Upda
 		return nil, err
 	}
 
+	// New reservations in zones without their own hold pick up the change
+	if req.HoldTTLSeconds != nil && s.zoneSyncer != nil {
+		_ = s.zoneSyncer.SyncEventHoldTTL(ctx, event)
+	}
+	// Applies to reservations and confirmations made from now on
+	if req.VerificationPolicy != nil && s.zoneSyncer != nil {
+		_ = s.zoneSyncer.SyncEventVerificationPolicy(ctx, event)
+	}
+
 	return event, nil
 }
 
@@ -327,11 +381,16 @@ func generateSlug(s string) string {
 
 // ensureUniqueSlug ensures the slug is unique by appending a number if needed
 func (s *eventService) ensureUniqueSlug(ctx context.Context, slug string) (string, error) {
+	return uniqueSlug(ctx, s.eventRepo, slug)
+}
+
+// uniqueSlug returns slug, or slug with a suffix if an event already uses it
+func uniqueSlug(ctx context.Context, eventRepo repository.EventRepository, slug string) (string, error) {
 	baseSlug := slug
 	counter := 1
 
 	for {
-		exists, err := s.eventRepo.SlugExists(ctx, slug)
+		exists, err := eventRepo.SlugExists(ctx, slug)
 		if err != nil {
 			return "", err
 		}
@@ -8,6 +8,7 @@ import (
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 )
 
 // MockEventRepository is a mock implementation of EventRepository
@@ -85,17 +86,21 @@ func (m *MockEventRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-func (m *MockEventRepository) ListPublished(ctx context.Context, limit, offset int) ([]*domain.Event, int, error) {
+func (m *MockEventRepository) ListPublished(ctx context.Context, page pagination.Params) ([]*domain.Event, int, error) {
 	var events []*domain.Event
 	for _, e := range m.events {
 		if e.Status == domain.EventStatusPublished && e.DeletedAt == nil {
 			events = append(events, e)
 		}
 	}
-	return events, len(events), nil
+	total := len(events)
+	if page.Limit > 0 && len(events) > page.Limit {
+		events = events[:page.Limit]
+	}
+	return events, total, nil
 }
 
-func (m *MockEventRepository) List(ctx context.Context, filter *repository.EventFilter, limit, offset int) ([]*domain.Event, int, error) {
+func (m *MockEventRepository) List(ctx context.Context, filter *repository.EventFilter, page pagination.Params) ([]*domain.Event, int, error) {
 	var events []*domain.Event
 	for _, e := range m.events {
 		if e.DeletedAt != nil {
@@ -119,7 +124,7 @@ func (m *MockEventRepository) SlugExists(ctx context.Context, slug string) (bool
 
 func TestEventService_CreateEvent(t *testing.T) {
 	eventRepo := NewMockEventRepository()
-	svc := NewEventService(eventRepo)
+	svc := NewEventService(eventRepo, nil)
 
 	ctx := context.Background()
 
@@ -148,6 +153,17 @@ func TestEventService_CreateEvent(t *testing.T) {
 			wantErr: true,
 			errMsg:  "Event name is required",
 		},
+		{
+			name: "unknown verification policy",
+			req: &dto.CreateEventRequest{
+				Name:               "Test Concert",
+				VenueName:          "Test Venue",
+				TenantID:           "tenant-1",
+				VerificationPolicy: "passport",
+			},
+			wantErr: true,
+			errMsg:  "Verification policy must be one of: none, email, verified",
+		},
 	}
 
 	for _, tt := range tests {
@@ -170,6 +186,9 @@ func TestEventService_CreateEvent(t *testing.T) {
 				if event != nil && event.Status != domain.EventStatusDraft {
 					t.Errorf("expected status %q, got %q", domain.EventStatusDraft, event.Status)
 				}
+				if event != nil && event.VerificationPolicy != domain.VerificationPolicyNone {
+					t.Errorf("expected verification policy %q, got %q", domain.VerificationPolicyNone, event.VerificationPolicy)
+				}
 			}
 		})
 	}
@@ -177,7 +196,7 @@ func TestEventService_CreateEvent(t *testing.T) {
 
 func TestEventService_GetEventByID(t *testing.T) {
 	eventRepo := NewMockEventRepository()
-	svc := NewEventService(eventRepo)
+	svc := NewEventService(eventRepo, nil)
 
 	ctx := context.Background()
 
@@ -233,7 +252,7 @@ func TestEventService_GetEventByID(t *testing.T) {
 
 func TestEventService_GetEventBySlug(t *testing.T) {
 	eventRepo := NewMockEventRepository()
-	svc := NewEventService(eventRepo)
+	svc := NewEventService(eventRepo, nil)
 
 	ctx := context.Background()
 
@@ -289,7 +308,7 @@ func TestEventService_GetEventBySlug(t *testing.T) {
 
 func TestEventService_UpdateEvent(t *testing.T) {
 	eventRepo := NewMockEventRepository()
-	svc := NewEventService(eventRepo)
+	svc := NewEventService(eventRepo, nil)
 
 	ctx := context.Background()
 
@@ -361,7 +380,7 @@ func TestEventService_UpdateEvent(t *testing.T) {
 
 func TestEventService_DeleteEvent(t *testing.T) {
 	eventRepo := NewMockEventRepository()
-	svc := NewEventService(eventRepo)
+	svc := NewEventService(eventRepo, nil)
 
 	ctx := context.Background()
 
@@ -414,7 +433,7 @@ func TestEventService_DeleteEvent(t *testing.T) {
 
 func TestEventService_PublishEvent(t *testing.T) {
 	eventRepo := NewMockEventRepository()
-	svc := NewEventService(eventRepo)
+	svc := NewEventService(eventRepo, nil)
 
 	ctx := context.Background()
 
@@ -488,6 +507,42 @@ func TestEventService_PublishEvent(t *testing.T) {
 	}
 }
 
+func TestEventService_ListPublishedEvents(t *testing.T) {
+	eventRepo := NewMockEventRepository()
+	svc := NewEventService(eventRepo, nil)
+
+	ctx := context.Background()
+
+	now := time.Now()
+	for i, id := range []string{"event-1", "event-2", "event-3"} {
+		eventRepo.events[id] = &domain.Event{
+			ID:        id,
+			Status:    domain.EventStatusPublished,
+			CreatedAt: now.Add(-time.Duration(i) * time.Minute),
+		}
+	}
+
+	events, total, info, err := svc.ListPublishedEvents(ctx, pagination.Params{Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 2 || total != 3 || !info.HasMore {
+		t.Fatalf("expected 2 of 3 events with more, got %d of %d, has_more=%v", len(events), total, info.HasMore)
+	}
+	next, err := pagination.DecodeCursor(info.NextCursor)
+	if err != nil || next.ID != events[1].ID {
+		t.Errorf("expected next cursor at %s, got %+v (%v)", events[1].ID, next, err)
+	}
+
+	events, _, info, err = svc.ListPublishedEvents(ctx, pagination.Params{Limit: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 3 || info.HasMore || info.NextCursor != "" {
+		t.Errorf("expected last page of 3 events, got %d, %+v", len(events), info)
+	}
+}
+
 func TestGenerateSlug(t *testing.T) {
 	tests := []struct {
 		input    string
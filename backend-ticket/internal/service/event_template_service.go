@@ -0,0 +1,383 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+)
+
+// Event template errors
+var (
+	ErrEventTemplateNotFound = errors.New("event template not found")
+	ErrFirstShowDateRequired = errors.New("first_show_date is required for templates with shows")
+)
+
+const (
+	// blueprintListLimit bounds the shows of an event and the zones of a show that are copied
+	blueprintListLimit = 1000
+	// cloneNameSuffix is appended to the source name when a clone request has no name
+	cloneNameSuffix = " (Copy)"
+)
+
+// eventTemplateService implements EventTemplateService
+type eventTemplateService struct {
+	templateRepo      repository.EventTemplateRepository
+	eventRepo         repository.EventRepository
+	showRepo          repository.ShowRepository
+	showZoneRepo      repository.ShowZoneRepository
+	accessService     EventAccessService
+	zoneSyncer        ZoneSyncer
+	capacityPublisher CapacityEventPublisher
+	now               func() time.Time
+}
+
+// NewEventTemplateService creates a new EventTemplateService. zoneSyncer and
+// capacityPublisher may be nil.
+func NewEventTemplateService(
+	templateRepo repository.EventTemplateRepository,
+	eventRepo repository.EventRepository,
+	showRepo repository.ShowRepository,
+	showZoneRepo repository.ShowZoneRepository,
+	accessService EventAccessService,
+	zoneSyncer ZoneSyncer,
+	capacityPublisher CapacityEventPublisher,
+) EventTemplateService {
+	return &eventTemplateService{
+		templateRepo:      templateRepo,
+		eventRepo:         eventRepo,
+		showRepo:          showRepo,
+		showZoneRepo:      showZoneRepo,
+		accessService:     accessService,
+		zoneSyncer:        zoneSyncer,
+		capacityPublisher: capacityPublisher,
+		now:               time.Now,
+	}
+}
+
+// CloneEvent copies an event the actor may edit, with its shows, zones, pricing and
+// reservation settings, into a new draft event owned by the actor
+func (s *eventTemplateService) CloneEvent(ctx context.Context, actor *domain.Actor, eventID string, req *dto.CloneEventRequest) (*domain.Event, error) {
+	if valid, msg := req.Validate(); !valid {
+		return nil, errors.New(msg)
+	}
+	event, err := s.accessService.AuthorizeEvent(ctx, actor, eventID, domain.PermissionEventEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	blueprint, err := s.snapshot(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	days := 0
+	switch {
+	case req.OffsetDays != nil:
+		days = *req.OffsetDays
+	case req.FirstShowDate != "":
+		if days, err = offsetToDate(blueprint, req.FirstShowDate); err != nil {
+			return nil, err
+		}
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = event.Name + cloneNameSuffix
+	}
+
+	clone, err := s.materialize(ctx, actor, event.TenantID, blueprint.Shift(days), name)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Get().Info(fmt.Sprintf("Event %s cloned to draft event %s", event.ID, clone.ID))
+	return clone, nil
+}
+
+// CreateTemplate saves the setup of an event the actor may edit as a template of the event's tenant
+func (s *eventTemplateService) CreateTemplate(ctx context.Context, actor *domain.Actor, req *dto.CreateEventTemplateRequest) (*domain.EventTemplate, error) {
+	if valid, msg := req.Validate(); !valid {
+		return nil, errors.New(msg)
+	}
+	event, err := s.accessService.AuthorizeEvent(ctx, actor, req.EventID, domain.PermissionEventEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	blueprint, err := s.snapshot(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	template := &domain.EventTemplate{
+		ID:            uuid.New().String(),
+		TenantID:      event.TenantID,
+		Name:          strings.TrimSpace(req.Name),
+		Description:   req.Description,
+		SourceEventID: event.ID,
+		Blueprint:     blueprint,
+		CreatedBy:     actor.UserID,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := s.templateRepo.Create(ctx, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// ListTemplates lists the templates of the actor's tenant
+func (s *eventTemplateService) ListTemplates(ctx context.Context, actor *domain.Actor) ([]*domain.EventTemplate, error) {
+	if actor.TenantID == "" {
+		return []*domain.EventTemplate{}, nil
+	}
+	return s.templateRepo.ListByTenant(ctx, actor.TenantID)
+}
+
+// GetTemplate retrieves a template of the actor's tenant
+func (s *eventTemplateService) GetTemplate(ctx context.Context, actor *domain.Actor, id string) (*domain.EventTemplate, error) {
+	template, err := s.templateRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Templates of another tenant are reported as not found so their existence is not leaked
+	if template == nil || (!actor.IsAdmin() && (actor.TenantID == "" || template.TenantID != actor.TenantID)) {
+		return nil, ErrEventTemplateNotFound
+	}
+	return template, nil
+}
+
+// DeleteTemplate deletes a template; only its creator and admins may delete it
+func (s *eventTemplateService) DeleteTemplate(ctx context.Context, actor *domain.Actor, id string) error {
+	template, err := s.GetTemplate(ctx, actor, id)
+	if err != nil {
+		return err
+	}
+	if !actor.IsAdmin() && template.CreatedBy != actor.UserID {
+		return ErrUnauthorized
+	}
+
+	deleted, err := s.templateRepo.Delete(ctx, template.ID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrEventTemplateNotFound
+	}
+	return nil
+}
+
+// InstantiateTemplate creates a draft event owned by the actor from a template of the
+// actor's tenant, moving the template's dates so the first show falls on first_show_date
+func (s *eventTemplateService) InstantiateTemplate(ctx context.Context, actor *domain.Actor, id string, req *dto.InstantiateEventTemplateRequest) (*domain.Event, error) {
+	if valid, msg := req.Validate(); !valid {
+		return nil, errors.New(msg)
+	}
+	template, err := s.GetTemplate(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	days := 0
+	if len(template.Blueprint.Shows) > 0 {
+		if req.FirstShowDate == "" {
+			return nil, ErrFirstShowDateRequired
+		}
+		if days, err = offsetToDate(template.Blueprint, req.FirstShowDate); err != nil {
+			return nil, err
+		}
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = template.Blueprint.Name
+	}
+
+	event, err := s.materialize(ctx, actor, template.TenantID, template.Blueprint.Shift(days), name)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Get().Info(fmt.Sprintf("Draft event %s created from template %s", event.ID, template.ID))
+	return event, nil
+}
+
+// snapshot captures the event with its shows and all of their zones
+func (s *eventTemplateService) snapshot(ctx context.Context, event *domain.Event) (*domain.EventBlueprint, error) {
+	shows, _, err := s.showRepo.GetByEventID(ctx, event.ID, blueprintListLimit, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	zones := make(map[string][]*domain.ShowZone, len(shows))
+	for _, show := range shows {
+		showZones, _, err := s.showZoneRepo.GetByShowID(ctx, show.ID, nil, blueprintListLimit, 0)
+		if err != nil {
+			return nil, err
+		}
+		zones[show.ID] = showZones
+	}
+
+	return domain.NewEventBlueprint(event, shows, zones), nil
+}
+
+// materialize creates a draft event with the blueprint's shows and zones. A partly
+// created event is deleted again if a show or zone cannot be created.
+func (s *eventTemplateService) materialize(ctx context.Context, actor *domain.Actor, tenantID string, blueprint *domain.EventBlueprint, name string) (*domain.Event, error) {
+	slug, err := uniqueSlug(ctx, s.eventRepo, generateSlug(name))
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	event := &domain.Event{
+		ID:                 uuid.New().String(),
+		TenantID:           tenantID,
+		OrganizerID:        actor.UserID,
+		CategoryID:         blueprint.CategoryID,
+		Name:               name,
+		Slug:               slug,
+		Description:        blueprint.Description,
+		ShortDescription:   blueprint.ShortDescription,
+		PosterURL:          blueprint.PosterURL,
+		BannerURL:          blueprint.BannerURL,
+		Gallery:            blueprint.Gallery,
+		VenueName:          blueprint.VenueName,
+		VenueAddress:       blueprint.VenueAddress,
+		City:               blueprint.City,
+		Country:            blueprint.Country,
+		Latitude:           blueprint.Latitude,
+		Longitude:          blueprint.Longitude,
+		MaxTicketsPerUser:  blueprint.MaxTicketsPerUser,
+		HoldTTLSeconds:     blueprint.HoldTTLSeconds,
+		VerificationPolicy: blueprint.VerificationPolicy,
+		BookingStartAt:     blueprint.BookingStartAt,
+		BookingEndAt:       blueprint.BookingEndAt,
+		Status:             domain.EventStatusDraft,
+		IsFeatured:         false,
+		IsPublic:           blueprint.IsPublic,
+		MetaTitle:          blueprint.MetaTitle,
+		MetaDescription:    blueprint.MetaDescription,
+		Settings:           blueprint.Settings,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+	if event.Gallery == nil {
+		event.Gallery = []string{}
+	}
+	if event.VerificationPolicy == "" {
+		event.VerificationPolicy = domain.VerificationPolicyNone
+	}
+	if event.Settings == "" {
+		event.Settings = "{}"
+	}
+
+	if err := s.eventRepo.Create(ctx, event); err != nil {
+		return nil, err
+	}
+
+	var zones []*domain.ShowZone
+	for _, sb := range blueprint.Shows {
+		show := &domain.Show{
+			ID:          uuid.New().String(),
+			EventID:     event.ID,
+			Name:        sb.Name,
+			ShowDate:    sb.ShowDate,
+			StartTime:   sb.StartTime,
+			EndTime:     sb.EndTime,
+			DoorsOpenAt: sb.DoorsOpenAt,
+			Status:      domain.ShowStatusScheduled,
+			SaleStartAt: sb.SaleStartAt,
+			SaleEndAt:   sb.SaleEndAt,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		for _, zb := range sb.Zones {
+			show.TotalCapacity += zb.TotalSeats
+		}
+		if err := s.showRepo.Create(ctx, show); err != nil {
+			s.discard(ctx, event)
+			return nil, err
+		}
+
+		for _, zb := range sb.Zones {
+			zone := &domain.ShowZone{
+				ID:             uuid.New().String(),
+				ShowID:         show.ID,
+				Name:           zb.Name,
+				Description:    zb.Description,
+				Color:          zb.Color,
+				Price:          zb.Price,
+				Currency:       zb.Currency,
+				TotalSeats:     zb.TotalSeats,
+				AvailableSeats: zb.TotalSeats, // Initially all seats are available
+				MinPerOrder:    zb.MinPerOrder,
+				MaxPerOrder:    zb.MaxPerOrder,
+				IsActive:       zb.IsActive,
+				SortOrder:      zb.SortOrder,
+				SaleStartAt:    zb.SaleStartAt,
+				SaleEndAt:      zb.SaleEndAt,
+				HoldTTLSeconds: zb.HoldTTLSeconds,
+				CreatedAt:      now,
+				UpdatedAt:      now,
+			}
+			if err := s.showZoneRepo.Create(ctx, zone); err != nil {
+				s.discard(ctx, event)
+				return nil, err
+			}
+			zones = append(zones, zone)
+		}
+	}
+
+	if s.zoneSyncer != nil {
+		if event.HoldTTLSeconds != nil {
+			_ = s.zoneSyncer.SyncEventHoldTTL(ctx, event)
+		}
+		if event.VerificationPolicy != domain.VerificationPolicyNone {
+			_ = s.zoneSyncer.SyncEventVerificationPolicy(ctx, event)
+		}
+	}
+	// New shows are scheduled, so zones are not synced to Redis until a show goes on sale
+	for _, zone := range zones {
+		s.publishZoneCreated(ctx, zone)
+	}
+
+	return event, nil
+}
+
+// discard deletes a partly created event
+func (s *eventTemplateService) discard(ctx context.Context, event *domain.Event) {
+	if err := s.eventRepo.Delete(ctx, event.ID); err != nil {
+		logger.Get().Error(fmt.Sprintf("Failed to delete partly created event %s: %v", event.ID, err))
+	}
+}
+
+// publishZoneCreated lets the inventory worker know about a new zone (best effort)
+func (s *eventTemplateService) publishZoneCreated(ctx context.Context, zone *domain.ShowZone) {
+	publishZoneEvent(ctx, s.capacityPublisher, domain.ZoneCreatedEventType, zone, false)
+}
+
+// offsetToDate returns the number of days that moves the blueprint's first show to date (YYYY-MM-DD)
+func offsetToDate(blueprint *domain.EventBlueprint, date string) (int, error) {
+	target, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return 0, errors.New("invalid first_show_date format, expected YYYY-MM-DD")
+	}
+	first, ok := blueprint.FirstShowDate()
+	if !ok {
+		return 0, nil
+	}
+
+	firstDay := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, time.UTC)
+	return int(math.Round(target.Sub(firstDay).Hours() / 24)), nil
+}
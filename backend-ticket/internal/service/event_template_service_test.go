@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
+)
+
+// MockEventTemplateRepository is a mock implementation of EventTemplateRepository
+type MockEventTemplateRepository struct {
+	templates map[string]*domain.EventTemplate
+}
+
+func NewMockEventTemplateRepository() *MockEventTemplateRepository {
+	return &MockEventTemplateRepository{
+		templates: make(map[string]*domain.EventTemplate),
+	}
+}
+
+func (m *MockEventTemplateRepository) Create(ctx context.Context, template *domain.EventTemplate) error {
+	m.templates[template.ID] = template
+	return nil
+}
+
+func (m *MockEventTemplateRepository) GetByID(ctx context.Context, id string) (*domain.EventTemplate, error) {
+	return m.templates[id], nil
+}
+
+func (m *MockEventTemplateRepository) ListByTenant(ctx context.Context, tenantID string) ([]*domain.EventTemplate, error) {
+	templates := []*domain.EventTemplate{}
+	for _, t := range m.templates {
+		if t.TenantID == tenantID {
+			templates = append(templates, t)
+		}
+	}
+	return templates, nil
+}
+
+func (m *MockEventTemplateRepository) Delete(ctx context.Context, id string) (bool, error) {
+	_, ok := m.templates[id]
+	delete(m.templates, id)
+	return ok, nil
+}
+
+type templateFixture struct {
+	svc       EventTemplateService
+	events    *MockEventRepository
+	shows     *MockShowRepository
+	zones     *MockShowZoneRepository
+	templates *MockEventTemplateRepository
+}
+
+// newTemplateFixture creates event-1 (tenant-1, owner) with a Friday and a Saturday show
+func newTemplateFixture() *templateFixture {
+	f := &templateFixture{
+		events:    NewMockEventRepository(),
+		shows:     NewMockShowRepository(),
+		zones:     NewMockShowZoneRepository(),
+		templates: NewMockEventTemplateRepository(),
+	}
+
+	holdTTL := 300
+	bookingStart := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	f.events.Create(context.Background(), &domain.Event{
+		ID:                 "event-1",
+		TenantID:           "tenant-1",
+		OrganizerID:        "owner",
+		Name:               "Jazz Night",
+		Slug:               "jazz-night",
+		Status:             domain.EventStatusPublished,
+		IsFeatured:         true,
+		MaxTicketsPerUser:  4,
+		HoldTTLSeconds:     &holdTTL,
+		VerificationPolicy: domain.VerificationPolicyEmail,
+		BookingStartAt:     &bookingStart,
+		Settings:           `{"queue_enabled":true}`,
+		CreatedAt:          time.Now(),
+	})
+
+	for i, date := range []time.Time{
+		time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC),
+	} {
+		showID := []string{"show-fri", "show-sat"}[i]
+		saleStart := date.AddDate(0, 0, -7)
+		f.shows.AddShow(&domain.Show{
+			ID:          showID,
+			EventID:     "event-1",
+			Name:        "Evening",
+			ShowDate:    date,
+			StartTime:   date.Add(20 * time.Hour),
+			EndTime:     date.Add(23 * time.Hour),
+			Status:      domain.ShowStatusOnSale,
+			SaleStartAt: &saleStart,
+			SoldCount:   120,
+		})
+		f.zones.AddZone(&domain.ShowZone{
+			ID:             showID + "-vip",
+			ShowID:         showID,
+			Name:           "VIP",
+			Price:          2500,
+			Currency:       "THB",
+			TotalSeats:     100,
+			AvailableSeats: 10,
+			SoldSeats:      90,
+			MaxPerOrder:    4,
+			IsActive:       true,
+		})
+	}
+
+	access := NewEventAccessService(f.events, NewMockTeamMemberRepository())
+	f.svc = NewEventTemplateService(f.templates, f.events, f.shows, f.zones, access, nil, nil)
+	return f
+}
+
+// showsOf returns the shows of an event keyed by show date
+func (f *templateFixture) showsOf(eventID string) map[string]*domain.Show {
+	shows, _, _ := f.shows.GetByEventID(context.Background(), eventID, 100, 0)
+	byDate := make(map[string]*domain.Show, len(shows))
+	for _, show := range shows {
+		byDate[show.ShowDate.Format("2006-01-02")] = show
+	}
+	return byDate
+}
+
+func TestEventTemplateService_CloneEvent(t *testing.T) {
+	f := newTemplateFixture()
+	owner := &domain.Actor{UserID: "owner", TenantID: "tenant-1", Role: "organizer"}
+	offset := 7
+
+	clone, err := f.svc.CloneEvent(context.Background(), owner, "event-1", &dto.CloneEventRequest{OffsetDays: &offset})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if clone.ID == "event-1" || clone.Status != domain.EventStatusDraft || clone.IsFeatured || clone.PublishedAt != nil {
+		t.Errorf("expected a new unfeatured draft event, got %+v", clone)
+	}
+	if clone.Name != "Jazz Night (Copy)" || clone.Slug == "jazz-night" {
+		t.Errorf("name = %q, slug = %q", clone.Name, clone.Slug)
+	}
+	if clone.MaxTicketsPerUser != 4 || clone.HoldTTLSeconds == nil || *clone.HoldTTLSeconds != 300 ||
+		clone.VerificationPolicy != domain.VerificationPolicyEmail || clone.Settings != `{"queue_enabled":true}` {
+		t.Errorf("reservation settings not copied: %+v", clone)
+	}
+	if want := time.Date(2026, 3, 8, 10, 0, 0, 0, time.UTC); clone.BookingStartAt == nil || !clone.BookingStartAt.Equal(want) {
+		t.Errorf("booking start = %v, want %v", clone.BookingStartAt, want)
+	}
+
+	shows := f.showsOf(clone.ID)
+	if len(shows) != 2 {
+		t.Fatalf("expected 2 cloned shows, got %d", len(shows))
+	}
+	fri, ok := shows["2026-03-20"]
+	if !ok {
+		t.Fatalf("expected a show on 2026-03-20, got %v", shows)
+	}
+	if fri.Status != domain.ShowStatusScheduled || fri.SoldCount != 0 || fri.TotalCapacity != 100 {
+		t.Errorf("cloned show = %+v", fri)
+	}
+	if want := time.Date(2026, 3, 20, 20, 0, 0, 0, time.UTC); !fri.StartTime.Equal(want) {
+		t.Errorf("start time = %v, want %v", fri.StartTime, want)
+	}
+	if want := time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC); fri.SaleStartAt == nil || !fri.SaleStartAt.Equal(want) {
+		t.Errorf("sale start = %v, want %v", fri.SaleStartAt, want)
+	}
+
+	zones, _, _ := f.zones.GetByShowID(context.Background(), fri.ID, nil, 100, 0)
+	if len(zones) != 1 {
+		t.Fatalf("expected 1 cloned zone, got %d", len(zones))
+	}
+	if z := zones[0]; z.Price != 2500 || z.Currency != "THB" || z.TotalSeats != 100 || z.AvailableSeats != 100 || z.SoldSeats != 0 || z.MaxPerOrder != 4 {
+		t.Errorf("cloned zone = %+v", z)
+	}
+
+	// The source event is untouched
+	if source := f.showsOf("event-1"); len(source) != 2 || source["2026-03-13"] == nil {
+		t.Errorf("source shows changed: %v", source)
+	}
+}
+
+func TestEventTemplateService_CloneEvent_FirstShowDateAndAccess(t *testing.T) {
+	f := newTemplateFixture()
+	owner := &domain.Actor{UserID: "owner", TenantID: "tenant-1", Role: "organizer"}
+
+	clone, err := f.svc.CloneEvent(context.Background(), owner, "event-1", &dto.CloneEventRequest{Name: "Jazz Night April", FirstShowDate: "2026-04-10"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	shows := f.showsOf(clone.ID)
+	if shows["2026-04-10"] == nil || shows["2026-04-11"] == nil {
+		t.Errorf("expected shows on 2026-04-10 and 2026-04-11, got %v", shows)
+	}
+	if clone.Name != "Jazz Night April" {
+		t.Errorf("name = %q", clone.Name)
+	}
+
+	outsider := &domain.Actor{UserID: "stranger", TenantID: "tenant-2", Role: "organizer"}
+	if _, err := f.svc.CloneEvent(context.Background(), outsider, "event-1", &dto.CloneEventRequest{}); !errors.Is(err, ErrEventNotFound) {
+		t.Errorf("expected ErrEventNotFound for another tenant, got %v", err)
+	}
+}
+
+func TestEventTemplateService_Templates(t *testing.T) {
+	f := newTemplateFixture()
+	owner := &domain.Actor{UserID: "owner", TenantID: "tenant-1", Role: "organizer"}
+	colleague := &domain.Actor{UserID: "colleague", TenantID: "tenant-1", Role: "organizer"}
+	outsider := &domain.Actor{UserID: "stranger", TenantID: "tenant-2", Role: "organizer"}
+
+	template, err := f.svc.CreateTemplate(context.Background(), owner, &dto.CreateEventTemplateRequest{EventID: "event-1", Name: "Weekly jazz"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if template.TenantID != "tenant-1" || template.SourceEventID != "event-1" || len(template.Blueprint.Shows) != 2 {
+		t.Errorf("template = %+v", template)
+	}
+
+	if list, _ := f.svc.ListTemplates(context.Background(), colleague); len(list) != 1 {
+		t.Errorf("expected the tenant's template to be listed, got %d", len(list))
+	}
+	if _, err := f.svc.GetTemplate(context.Background(), outsider, template.ID); !errors.Is(err, ErrEventTemplateNotFound) {
+		t.Errorf("expected ErrEventTemplateNotFound for another tenant, got %v", err)
+	}
+
+	// Any organizer of the tenant can use the template and owns the new event
+	if _, err := f.svc.InstantiateTemplate(context.Background(), colleague, template.ID, &dto.InstantiateEventTemplateRequest{}); !errors.Is(err, ErrFirstShowDateRequired) {
+		t.Errorf("expected ErrFirstShowDateRequired, got %v", err)
+	}
+	event, err := f.svc.InstantiateTemplate(context.Background(), colleague, template.ID, &dto.InstantiateEventTemplateRequest{FirstShowDate: "2026-05-01"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.OrganizerID != "colleague" || event.TenantID != "tenant-1" || event.Name != "Jazz Night" || event.Status != domain.EventStatusDraft {
+		t.Errorf("event = %+v", event)
+	}
+	if shows := f.showsOf(event.ID); shows["2026-05-01"] == nil || shows["2026-05-02"] == nil {
+		t.Errorf("expected shows on 2026-05-01 and 2026-05-02, got %v", shows)
+	}
+
+	// Only the creator deletes it
+	if err := f.svc.DeleteTemplate(context.Background(), colleague, template.ID); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+	if err := f.svc.DeleteTemplate(context.Background(), owner, template.ID); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := f.svc.GetTemplate(context.Background(), owner, template.ID); !errors.Is(err, ErrEventTemplateNotFound) {
+		t.Errorf("expected deleted template to be gone, got %v", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 )
 
 // EventService defines the interface for event business logic
@@ -16,9 +17,9 @@ type EventService interface {
 	// GetEventBySlug retrieves an event by slug
 	GetEventBySlug(ctx context.Context, slug string) (*domain.Event, error)
 	// ListEvents lists events with filters and pagination
-	ListEvents(ctx context.Context, filter *dto.EventListFilter) ([]*domain.Event, int, error)
+	ListEvents(ctx context.Context, filter *dto.EventListFilter) ([]*domain.Event, int, pagination.Info, error)
 	// ListPublishedEvents lists all published public events
-	ListPublishedEvents(ctx context.Context, limit, offset int) ([]*domain.Event, int, error)
+	ListPublishedEvents(ctx context.Context, page pagination.Params) ([]*domain.Event, int, pagination.Info, error)
 	// UpdateEvent updates an event
 	UpdateEvent(ctx context.Context, id string, req *dto.UpdateEventRequest) (*domain.Event, error)
 	// DeleteEvent soft deletes an event
@@ -27,6 +28,46 @@ type EventService interface {
 	PublishEvent(ctx context.Context, id string) (*domain.Event, error)
 }
 
+// EventTemplateService defines the interface for event cloning and reusable event templates
+type EventTemplateService interface {
+	// CloneEvent copies an event the actor may edit, with its shows and zones, into a new draft event
+	CloneEvent(ctx context.Context, actor *domain.Actor, eventID string, req *dto.CloneEventRequest) (*domain.Event, error)
+	// CreateTemplate saves the setup of an event the actor may edit as a template
+	CreateTemplate(ctx context.Context, actor *domain.Actor, req *dto.CreateEventTemplateRequest) (*domain.EventTemplate, error)
+	// ListTemplates lists the templates of the actor's tenant
+	ListTemplates(ctx context.Context, actor *domain.Actor) ([]*domain.EventTemplate, error)
+	// GetTemplate retrieves a template of the actor's tenant
+	GetTemplate(ctx context.Context, actor *domain.Actor, id string) (*domain.EventTemplate, error)
+	// DeleteTemplate deletes a template the actor created
+	DeleteTemplate(ctx context.Context, actor *domain.Actor, id string) error
+	// InstantiateTemplate creates a new draft event from a template
+	InstantiateTemplate(ctx context.Context, actor *domain.Actor, id string, req *dto.InstantiateEventTemplateRequest) (*domain.Event, error)
+}
+
+// EventAccessService defines the interface for event ownership and tenant team access
+type EventAccessService interface {
+	// AuthorizeEvent returns the event if the actor holds permission on it
+	AuthorizeEvent(ctx context.Context, actor *domain.Actor, eventID, permission string) (*domain.Event, error)
+	// ListTeamMembers lists the team members of the actor's tenant
+	ListTeamMembers(ctx context.Context, actor *domain.Actor) ([]*domain.TeamMember, error)
+	// SetTeamMember adds a user to the actor's tenant team or replaces their permissions
+	SetTeamMember(ctx context.Context, actor *domain.Actor, userID string, req *dto.SetTeamMemberRequest) (*domain.TeamMember, error)
+	// RemoveTeamMember removes a user from the actor's tenant team
+	RemoveTeamMember(ctx context.Context, actor *domain.Actor, userID string) error
+}
+
+// PresaleService defines the interface for presale access codes
+type PresaleService interface {
+	// CreateBatch generates a batch of presale codes for an event the actor may edit
+	CreateBatch(ctx context.Context, actor *domain.Actor, eventID string, req *dto.CreatePresaleBatchRequest) (*domain.PresaleBatch, []*domain.PresaleCode, error)
+	// ListBatchStats lists an event's presale batches with redemption counts
+	ListBatchStats(ctx context.Context, actor *domain.Actor, eventID string) ([]*domain.PresaleBatchStats, error)
+	// ListBatchCodes lists the codes of a presale batch with their redemption counts
+	ListBatchCodes(ctx context.Context, actor *domain.Actor, eventID, batchID string) ([]*domain.PresaleCode, error)
+	// ValidateCode checks a presale code for a user and, if redeem is set, consumes one of its uses
+	ValidateCode(ctx context.Context, eventID, userID, code string, redeem bool) (*domain.PresaleCode, *domain.PresaleRedemption, error)
+}
+
 // TicketService defines the interface for ticket business logic
 type TicketService interface {
 	// CreateTicketType creates a new ticket type for an event
@@ -45,18 +86,39 @@ type TicketService interface {
 	CheckAvailability(ctx context.Context, eventID string, ticketTypeID string, quantity int) (*dto.AvailabilityResponse, error)
 }
 
-// VenueService defines the interface for venue business logic
+// VenueService defines the interface for venue business logic.
+// Venues, their zones and layouts are managed by the venue's tenant; admins manage any venue.
 type VenueService interface {
-	// CreateVenue creates a new venue
-	CreateVenue(ctx context.Context, req *dto.CreateVenueRequest) (*domain.Venue, error)
+	// CreateVenue creates a new venue in the actor's tenant
+	CreateVenue(ctx context.Context, actor *domain.Actor, req *dto.CreateVenueRequest) (*domain.Venue, error)
 	// GetVenue retrieves a venue by ID
-	GetVenue(ctx context.Context, id string) (*domain.Venue, error)
-	// GetVenuesByTenant retrieves venues by tenant ID
-	GetVenuesByTenant(ctx context.Context, tenantID string) ([]*domain.Venue, error)
+	GetVenue(ctx context.Context, actor *domain.Actor, id string) (*domain.Venue, error)
+	// GetVenuesByTenant retrieves venues by tenant ID; only admins may list another tenant
+	GetVenuesByTenant(ctx context.Context, actor *domain.Actor, tenantID string) ([]*domain.Venue, error)
 	// UpdateVenue updates a venue
-	UpdateVenue(ctx context.Context, id string, req *dto.UpdateVenueRequest) (*domain.Venue, error)
-	// DeleteVenue deletes a venue
-	DeleteVenue(ctx context.Context, id string) error
+	UpdateVenue(ctx context.Context, actor *domain.Actor, id string, req *dto.UpdateVenueRequest) (*domain.Venue, error)
+	// DeleteVenue deletes a venue with its zones and layouts
+	DeleteVenue(ctx context.Context, actor *domain.Actor, id string) error
+
+	// CreateZone adds a zone to a venue
+	CreateZone(ctx context.Context, actor *domain.Actor, req *dto.CreateZoneRequest) (*domain.Zone, error)
+	// ListZones lists the zones of a venue
+	ListZones(ctx context.Context, actor *domain.Actor, venueID string) ([]*domain.Zone, error)
+	// UpdateZone updates a zone of a venue
+	UpdateZone(ctx context.Context, actor *domain.Actor, venueID, zoneID string, req *dto.UpdateZoneRequest) (*domain.Zone, error)
+	// DeleteZone deletes a zone the active layout does not use
+	DeleteZone(ctx context.Context, actor *domain.Actor, venueID, zoneID string) error
+
+	// UploadLayout validates a seat map against the venue zones and stores it as a new version
+	UploadLayout(ctx context.Context, actor *domain.Actor, venueID string, req *dto.UploadVenueLayoutRequest) (*domain.VenueLayout, error)
+	// ListLayouts lists the layout versions of a venue, newest first
+	ListLayouts(ctx context.Context, actor *domain.Actor, venueID string) ([]*domain.VenueLayout, error)
+	// GetLayout retrieves a layout version of a venue
+	GetLayout(ctx context.Context, actor *domain.Actor, venueID string, version int) (*domain.VenueLayout, error)
+	// ActivateLayout makes a layout version the one served to buyers
+	ActivateLayout(ctx context.Context, actor *domain.Actor, venueID string, version int) (*domain.Venue, error)
+	// GetRenderedLayout renders the active layout of a venue for seat selection
+	GetRenderedLayout(ctx context.Context, venueID string) (*domain.RenderedLayout, error)
 }
 
 // ShowService defines the interface for show business logic
@@ -87,4 +149,30 @@ type ShowZoneService interface {
 	DeleteShowZone(ctx context.Context, id string) error
 	// ListActiveZones lists all active zones for inventory sync
 	ListActiveZones(ctx context.Context) ([]*domain.ShowZone, error)
+	// GetCapacityStatus returns the outcome of the latest capacity change for a zone
+	GetCapacityStatus(ctx context.Context, id string) (*domain.ZoneCapacityStatus, error)
+}
+
+// PriceTierService defines the interface for time-windowed zone price tiers
+type PriceTierService interface {
+	// ListPriceTiers lists a zone's price tiers in the order booking-service tries them
+	ListPriceTiers(ctx context.Context, zoneID string) ([]*domain.ZonePriceTier, error)
+	// CreatePriceTier adds a price tier to a zone
+	CreatePriceTier(ctx context.Context, zoneID string, req *dto.CreatePriceTierRequest) (*domain.ZonePriceTier, error)
+	// UpdatePriceTier replaces the settings of a zone's price tier
+	UpdatePriceTier(ctx context.Context, zoneID, tierID string, req *dto.UpdatePriceTierRequest) (*domain.ZonePriceTier, error)
+	// DeletePriceTier removes a zone's price tier
+	DeletePriceTier(ctx context.Context, zoneID, tierID string) error
+}
+
+// AddOnService defines the interface for add-on items sold with an event's tickets
+type AddOnService interface {
+	// ListAddOns lists an event's add-ons, read by booking-service to price reservations
+	ListAddOns(ctx context.Context, eventID string) ([]*domain.EventAddOn, error)
+	// CreateAddOn adds an add-on to an event the actor may edit
+	CreateAddOn(ctx context.Context, actor *domain.Actor, eventID string, req *dto.CreateAddOnRequest) (*domain.EventAddOn, error)
+	// UpdateAddOn replaces the settings of an event's add-on
+	UpdateAddOn(ctx context.Context, actor *domain.Actor, eventID, addOnID string, req *dto.UpdateAddOnRequest) (*domain.EventAddOn, error)
+	// DeleteAddOn removes an event's add-on
+	DeleteAddOn(ctx context.Context, actor *domain.Actor, eventID, addOnID string) error
 }
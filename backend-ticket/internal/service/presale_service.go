@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+)
+
+// Presale errors
+var (
+	ErrPresaleBatchNotFound = errors.New("presale batch not found")
+	ErrPresaleCodeNotFound  = errors.New("presale code not found")
+	ErrPresaleCodeNotActive = errors.New("presale code is not valid yet")
+	ErrPresaleCodeExpired   = errors.New("presale code has expired")
+	ErrPresaleCodeExhausted = errors.New("presale code has no uses left")
+	ErrPresaleUnavailable   = errors.New("presale redemption is unavailable")
+)
+
+const (
+	// presaleCodeAlphabet leaves out 0/O and 1/I so codes can be typed from print.
+	// 32 symbols, so a random byte maps to a symbol without bias.
+	presaleCodeAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+	presaleCodeLength   = 10
+
+	// Live redemption state outlives the validity window so late retries stay idempotent
+	presaleRedemptionRetention = 7 * 24 * time.Hour
+)
+
+// presaleService implements PresaleService
+type presaleService struct {
+	presaleRepo    repository.PresaleRepository
+	redemptionRepo repository.PresaleRedemptionRepository
+	accessService  EventAccessService
+	now            func() time.Time
+}
+
+// NewPresaleService creates a new PresaleService. redemptionRepo may be nil when Redis
+// is unavailable; codes can then be checked but not redeemed.
+func NewPresaleService(presaleRepo repository.PresaleRepository, redemptionRepo repository.PresaleRedemptionRepository, accessService EventAccessService) PresaleService {
+	return &presaleService{
+		presaleRepo:    presaleRepo,
+		redemptionRepo: redemptionRepo,
+		accessService:  accessService,
+		now:            time.Now,
+	}
+}
+
+// CreateBatch generates a batch of presale codes for an event the actor may edit
+func (s *presaleService) CreateBatch(ctx context.Context, actor *domain.Actor, eventID string, req *dto.CreatePresaleBatchRequest) (*domain.PresaleBatch, []*domain.PresaleCode, error) {
+	if valid, msg := req.Validate(); !valid {
+		return nil, nil, errors.New(msg)
+	}
+	event, err := s.accessService.AuthorizeEvent(ctx, actor, eventID, domain.PermissionEventEdit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	maxUses := req.MaxUsesPerCode
+	if maxUses == 0 {
+		maxUses = dto.DefaultPresaleMaxUses
+	}
+
+	now := s.now()
+	batch := &domain.PresaleBatch{
+		ID:             uuid.New().String(),
+		EventID:        event.ID,
+		TenantID:       event.TenantID,
+		Name:           strings.TrimSpace(req.Name),
+		MaxUsesPerCode: maxUses,
+		ValidFrom:      req.ValidFrom,
+		ValidUntil:     req.ValidUntil,
+		CreatedBy:      actor.UserID,
+		CreatedAt:      now,
+	}
+
+	values, err := generatePresaleCodes(req.Prefix, req.Count)
+	if err != nil {
+		return nil, nil, err
+	}
+	codes := make([]*domain.PresaleCode, len(values))
+	for i, value := range values {
+		codes[i] = &domain.PresaleCode{
+			ID:         uuid.New().String(),
+			BatchID:    batch.ID,
+			EventID:    batch.EventID,
+			Code:       value,
+			MaxUses:    batch.MaxUsesPerCode,
+			ValidFrom:  batch.ValidFrom,
+			ValidUntil: batch.ValidUntil,
+			CreatedAt:  now,
+		}
+	}
+
+	if err := s.presaleRepo.CreateBatch(ctx, batch, codes); err != nil {
+		return nil, nil, err
+	}
+	return batch, codes, nil
+}
+
+// ListBatchStats lists an event's presale batches with redemption counts
+func (s *presaleService) ListBatchStats(ctx context.Context, actor *domain.Actor, eventID string) ([]*domain.PresaleBatchStats, error) {
+	if _, err := s.accessService.AuthorizeEvent(ctx, actor, eventID, domain.PermissionEventEdit); err != nil {
+		return nil, err
+	}
+	return s.presaleRepo.ListBatchStats(ctx, eventID)
+}
+
+// ListBatchCodes lists the codes of a presale batch with their redemption counts
+func (s *presaleService) ListBatchCodes(ctx context.Context, actor *domain.Actor, eventID, batchID string) ([]*domain.PresaleCode, error) {
+	if _, err := s.accessService.AuthorizeEvent(ctx, actor, eventID, domain.PermissionEventEdit); err != nil {
+		return nil, err
+	}
+	batch, err := s.presaleRepo.GetBatch(ctx, eventID, batchID)
+	if err != nil {
+		return nil, err
+	}
+	if batch == nil {
+		return nil, ErrPresaleBatchNotFound
+	}
+	return s.presaleRepo.ListCodesByBatch(ctx, batch.ID)
+}
+
+// ValidateCode checks a presale code for a user and, if redeem is set, atomically
+// consumes one of its uses. Redeeming a code the user already redeemed succeeds
+// without consuming another use.
+func (s *presaleService) ValidateCode(ctx context.Context, eventID, userID, code string, redeem bool) (*domain.PresaleCode, *domain.PresaleRedemption, error) {
+	presaleCode, err := s.presaleRepo.GetCode(ctx, eventID, normalizePresaleCode(code))
+	if err != nil {
+		return nil, nil, err
+	}
+	if presaleCode == nil {
+		return nil, nil, ErrPresaleCodeNotFound
+	}
+
+	now := s.now()
+	if now.Before(presaleCode.ValidFrom) {
+		return presaleCode, nil, ErrPresaleCodeNotActive
+	}
+	if !presaleCode.IsActiveAt(now) {
+		return presaleCode, nil, ErrPresaleCodeExpired
+	}
+
+	if redeem {
+		return s.redeem(ctx, presaleCode, userID, now)
+	}
+	return s.check(ctx, presaleCode, userID)
+}
+
+// redeem consumes a use of the code in Redis and syncs the count to Postgres
+func (s *presaleService) redeem(ctx context.Context, code *domain.PresaleCode, userID string, now time.Time) (*domain.PresaleCode, *domain.PresaleRedemption, error) {
+	if s.redemptionRepo == nil {
+		return code, nil, ErrPresaleUnavailable
+	}
+
+	ttl := code.ValidUntil.Sub(now) + presaleRedemptionRetention
+	redemption, err := s.redemptionRepo.Redeem(ctx, code, userID, ttl)
+	if err != nil {
+		return code, nil, err
+	}
+	if !redemption.Redeemed {
+		return code, redemption, ErrPresaleCodeExhausted
+	}
+
+	if !redemption.AlreadyRedeemed {
+		// Redis holds the authoritative count; Postgres only backs reporting and
+		// reseeding, so a failed sync doesn't fail the redemption
+		if err := s.presaleRepo.UpdateRedeemedCount(ctx, code.ID, redemption.Uses); err != nil {
+			logger.Get().Warn(fmt.Sprintf("Failed to sync redeemed count for presale code %s: %v", code.ID, err))
+		}
+	}
+	return code, redemption, nil
+}
+
+// check reports whether the user could redeem the code, without consuming a use
+func (s *presaleService) check(ctx context.Context, code *domain.PresaleCode, userID string) (*domain.PresaleCode, *domain.PresaleRedemption, error) {
+	redemption := &domain.PresaleRedemption{Uses: int64(code.RedeemedCount)}
+	if s.redemptionRepo != nil {
+		uses, redeemed, err := s.redemptionRepo.Status(ctx, code.ID, userID)
+		if err != nil {
+			return code, nil, err
+		}
+		if uses >= 0 {
+			redemption.Uses = uses
+		}
+		redemption.AlreadyRedeemed = redeemed
+	}
+
+	if !redemption.AlreadyRedeemed && redemption.Uses >= int64(code.MaxUses) {
+		return code, redemption, ErrPresaleCodeExhausted
+	}
+	return code, redemption, nil
+}
+
+// normalizePresaleCode makes code lookups case-insensitive; codes are stored upper-case
+func normalizePresaleCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// generatePresaleCodes generates count distinct random codes, e.g. FANCLUB-7KQ2M9XH4T
+func generatePresaleCodes(prefix string, count int) ([]string, error) {
+	prefix = normalizePresaleCode(prefix)
+	if prefix != "" {
+		prefix += "-"
+	}
+
+	seen := make(map[string]bool, count)
+	codes := make([]string, 0, count)
+	buf := make([]byte, presaleCodeLength)
+	for len(codes) < count {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, fmt.Errorf("failed to generate presale code: %w", err)
+		}
+		for i, b := range buf {
+			buf[i] = presaleCodeAlphabet[int(b)%len(presaleCodeAlphabet)]
+		}
+		code := prefix + string(buf)
+		if !seen[code] {
+			seen[code] = true
+			codes = append(codes, code)
+		}
+	}
+	return codes, nil
+}
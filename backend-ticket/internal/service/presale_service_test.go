@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
+)
+
+// MockPresaleRepository is a mock implementation of PresaleRepository
+type MockPresaleRepository struct {
+	batches map[string]*domain.PresaleBatch
+	codes   map[string]*domain.PresaleCode // key: eventID/code
+}
+
+func NewMockPresaleRepository() *MockPresaleRepository {
+	return &MockPresaleRepository{
+		batches: make(map[string]*domain.PresaleBatch),
+		codes:   make(map[string]*domain.PresaleCode),
+	}
+}
+
+func (m *MockPresaleRepository) CreateBatch(ctx context.Context, batch *domain.PresaleBatch, codes []*domain.PresaleCode) error {
+	m.batches[batch.ID] = batch
+	for _, code := range codes {
+		m.codes[code.EventID+"/"+code.Code] = code
+	}
+	return nil
+}
+
+func (m *MockPresaleRepository) GetBatch(ctx context.Context, eventID, batchID string) (*domain.PresaleBatch, error) {
+	if batch, ok := m.batches[batchID]; ok && batch.EventID == eventID {
+		return batch, nil
+	}
+	return nil, nil
+}
+
+func (m *MockPresaleRepository) GetCode(ctx context.Context, eventID, code string) (*domain.PresaleCode, error) {
+	return m.codes[eventID+"/"+code], nil
+}
+
+func (m *MockPresaleRepository) ListCodesByBatch(ctx context.Context, batchID string) ([]*domain.PresaleCode, error) {
+	var codes []*domain.PresaleCode
+	for _, code := range m.codes {
+		if code.BatchID == batchID {
+			codes = append(codes, code)
+		}
+	}
+	return codes, nil
+}
+
+func (m *MockPresaleRepository) UpdateRedeemedCount(ctx context.Context, codeID string, count int64) error {
+	for _, code := range m.codes {
+		if code.ID == codeID && int64(code.RedeemedCount) < count {
+			code.RedeemedCount = int(count)
+		}
+	}
+	return nil
+}
+
+func (m *MockPresaleRepository) ListBatchStats(ctx context.Context, eventID string) ([]*domain.PresaleBatchStats, error) {
+	var stats []*domain.PresaleBatchStats
+	for _, batch := range m.batches {
+		if batch.EventID == eventID {
+			stats = append(stats, &domain.PresaleBatchStats{Batch: batch})
+		}
+	}
+	return stats, nil
+}
+
+// MockPresaleRedemptionRepository is an in-memory PresaleRedemptionRepository
+type MockPresaleRedemptionRepository struct {
+	uses  map[string]int64
+	users map[string]map[string]bool
+}
+
+func NewMockPresaleRedemptionRepository() *MockPresaleRedemptionRepository {
+	return &MockPresaleRedemptionRepository{
+		uses:  make(map[string]int64),
+		users: make(map[string]map[string]bool),
+	}
+}
+
+func (m *MockPresaleRedemptionRepository) Redeem(ctx context.Context, code *domain.PresaleCode, userID string, ttl time.Duration) (*domain.PresaleRedemption, error) {
+	uses, ok := m.uses[code.ID]
+	if !ok {
+		uses = int64(code.RedeemedCount)
+	}
+	if m.users[code.ID][userID] {
+		return &domain.PresaleRedemption{Redeemed: true, AlreadyRedeemed: true, Uses: uses}, nil
+	}
+	if uses >= int64(code.MaxUses) {
+		return &domain.PresaleRedemption{Uses: uses}, nil
+	}
+	m.uses[code.ID] = uses + 1
+	if m.users[code.ID] == nil {
+		m.users[code.ID] = make(map[string]bool)
+	}
+	m.users[code.ID][userID] = true
+	return &domain.PresaleRedemption{Redeemed: true, Uses: uses + 1}, nil
+}
+
+func (m *MockPresaleRedemptionRepository) Status(ctx context.Context, codeID, userID string) (int64, bool, error) {
+	uses, ok := m.uses[codeID]
+	if !ok {
+		uses = -1
+	}
+	return uses, m.users[codeID][userID], nil
+}
+
+func newPresaleTestService(now time.Time) (*presaleService, *MockPresaleRepository, *MockPresaleRedemptionRepository) {
+	accessService, _ := newAccessTestService()
+	presaleRepo := NewMockPresaleRepository()
+	redemptionRepo := NewMockPresaleRedemptionRepository()
+	svc := NewPresaleService(presaleRepo, redemptionRepo, accessService).(*presaleService)
+	svc.now = func() time.Time { return now }
+	return svc, presaleRepo, redemptionRepo
+}
+
+func createTestBatch(t *testing.T, svc *presaleService, now time.Time, maxUses int) []*domain.PresaleCode {
+	t.Helper()
+	owner := &domain.Actor{UserID: "owner", TenantID: "tenant-1", Role: "organizer"}
+	_, codes, err := svc.CreateBatch(context.Background(), owner, "event-1", &dto.CreatePresaleBatchRequest{
+		Name:           "Fan club",
+		Count:          3,
+		MaxUsesPerCode: maxUses,
+		Prefix:         "fan",
+		ValidFrom:      now.Add(-time.Hour),
+		ValidUntil:     now.Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("CreateBatch() error = %v", err)
+	}
+	return codes
+}
+
+func TestPresaleService_CreateBatch(t *testing.T) {
+	now := time.Now()
+	svc, presaleRepo, _ := newPresaleTestService(now)
+
+	codes := createTestBatch(t, svc, now, 0)
+	if len(codes) != 3 || len(presaleRepo.codes) != 3 {
+		t.Fatalf("Expected 3 stored codes, got %d", len(presaleRepo.codes))
+	}
+	for _, code := range codes {
+		if !strings.HasPrefix(code.Code, "FAN-") || len(code.Code) != len("FAN-")+presaleCodeLength {
+			t.Errorf("Code = %q, want FAN- followed by %d characters", code.Code, presaleCodeLength)
+		}
+		if code.MaxUses != dto.DefaultPresaleMaxUses {
+			t.Errorf("MaxUses = %d, want default %d", code.MaxUses, dto.DefaultPresaleMaxUses)
+		}
+	}
+
+	stranger := &domain.Actor{UserID: "stranger", TenantID: "tenant-1", Role: "organizer"}
+	req := &dto.CreatePresaleBatchRequest{Name: "x", Count: 1, ValidFrom: now, ValidUntil: now.Add(time.Hour)}
+	if _, _, err := svc.CreateBatch(context.Background(), stranger, "event-1", req); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("CreateBatch() by stranger error = %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestPresaleService_ValidateCode_Redeem(t *testing.T) {
+	now := time.Now()
+	svc, presaleRepo, _ := newPresaleTestService(now)
+	code := createTestBatch(t, svc, now, 2)[0]
+	ctx := context.Background()
+
+	// Lookup is case-insensitive
+	_, redemption, err := svc.ValidateCode(ctx, "event-1", "user-1", " "+strings.ToLower(code.Code), true)
+	if err != nil || !redemption.Redeemed || redemption.Uses != 1 {
+		t.Fatalf("ValidateCode(redeem) = %+v, %v; want first use", redemption, err)
+	}
+
+	// Redeeming again for the same user does not consume another use
+	_, redemption, err = svc.ValidateCode(ctx, "event-1", "user-1", code.Code, true)
+	if err != nil || !redemption.AlreadyRedeemed || redemption.Uses != 1 {
+		t.Errorf("ValidateCode(redeem again) = %+v, %v; want already redeemed", redemption, err)
+	}
+
+	if _, _, err := svc.ValidateCode(ctx, "event-1", "user-2", code.Code, true); err != nil {
+		t.Fatalf("ValidateCode(user-2) error = %v", err)
+	}
+	if _, _, err := svc.ValidateCode(ctx, "event-1", "user-3", code.Code, true); !errors.Is(err, ErrPresaleCodeExhausted) {
+		t.Errorf("ValidateCode(user-3) error = %v, want ErrPresaleCodeExhausted", err)
+	}
+	if got := presaleRepo.codes["event-1/"+code.Code].RedeemedCount; got != 2 {
+		t.Errorf("Persisted redeemed count = %d, want 2", got)
+	}
+
+	// Checking without redeeming reports exhaustion, except to users holding a use
+	if _, _, err := svc.ValidateCode(ctx, "event-1", "user-3", code.Code, false); !errors.Is(err, ErrPresaleCodeExhausted) {
+		t.Errorf("ValidateCode(check) error = %v, want ErrPresaleCodeExhausted", err)
+	}
+	if _, redemption, err := svc.ValidateCode(ctx, "event-1", "user-2", code.Code, false); err != nil || !redemption.AlreadyRedeemed {
+		t.Errorf("ValidateCode(check own) = %+v, %v; want already redeemed", redemption, err)
+	}
+}
+
+func TestPresaleService_ValidateCode_Errors(t *testing.T) {
+	now := time.Now()
+	svc, _, _ := newPresaleTestService(now)
+	code := createTestBatch(t, svc, now, 1)[0]
+	ctx := context.Background()
+
+	if _, _, err := svc.ValidateCode(ctx, "event-1", "user-1", "NOPE", false); !errors.Is(err, ErrPresaleCodeNotFound) {
+		t.Errorf("unknown code error = %v, want ErrPresaleCodeNotFound", err)
+	}
+	if _, _, err := svc.ValidateCode(ctx, "event-2", "user-1", code.Code, false); !errors.Is(err, ErrPresaleCodeNotFound) {
+		t.Errorf("code of another event error = %v, want ErrPresaleCodeNotFound", err)
+	}
+
+	svc.now = func() time.Time { return now.Add(-2 * time.Hour) }
+	if _, _, err := svc.ValidateCode(ctx, "event-1", "user-1", code.Code, true); !errors.Is(err, ErrPresaleCodeNotActive) {
+		t.Errorf("early code error = %v, want ErrPresaleCodeNotActive", err)
+	}
+	svc.now = func() time.Time { return now.Add(time.Hour) }
+	if _, _, err := svc.ValidateCode(ctx, "event-1", "user-1", code.Code, true); !errors.Is(err, ErrPresaleCodeExpired) {
+		t.Errorf("expired code error = %v, want ErrPresaleCodeExpired", err)
+	}
+
+	// Without Redis codes can be checked but not redeemed
+	svc.now = func() time.Time { return now }
+	svc.redemptionRepo = nil
+	if _, _, err := svc.ValidateCode(ctx, "event-1", "user-1", code.Code, false); err != nil {
+		t.Errorf("check without Redis error = %v", err)
+	}
+	if _, _, err := svc.ValidateCode(ctx, "event-1", "user-1", code.Code, true); !errors.Is(err, ErrPresaleUnavailable) {
+		t.Errorf("redeem without Redis error = %v, want ErrPresaleUnavailable", err)
+	}
+}
+
+func TestGeneratePresaleCodes(t *testing.T) {
+	codes, err := generatePresaleCodes("", 500)
+	if err != nil {
+		t.Fatalf("generatePresaleCodes() error = %v", err)
+	}
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		if seen[code] {
+			t.Fatalf("Duplicate code %q", code)
+		}
+		seen[code] = true
+		if strings.Trim(code, presaleCodeAlphabet) != "" {
+			t.Errorf("Code %q has characters outside the alphabet", code)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/repository"
+)
+
+// Price tier errors
+var (
+	ErrPriceTierNotFound = errors.New("price tier not found")
+	ErrInvalidPriceTier  = errors.New("invalid price tier")
+)
+
+// priceTierService implements PriceTierService
+type priceTierService struct {
+	tierRepo     repository.ZonePriceTierRepository
+	showZoneRepo repository.ShowZoneRepository
+	showRepo     repository.ShowRepository
+	publisher    CapacityEventPublisher
+}
+
+// NewPriceTierService creates a new PriceTierService. Tier changes are announced as
+// zone updates so booking-service drops its cached price schedule; without a publisher
+// they show up when the cache expires.
+func NewPriceTierService(tierRepo repository.ZonePriceTierRepository, showZoneRepo repository.ShowZoneRepository, showRepo repository.ShowRepository, publisher CapacityEventPublisher) PriceTierService {
+	return &priceTierService{
+		tierRepo:     tierRepo,
+		showZoneRepo: showZoneRepo,
+		showRepo:     showRepo,
+		publisher:    publisher,
+	}
+}
+
+// ListPriceTiers lists a zone's price tiers in the order booking-service tries them
+func (s *priceTierService) ListPriceTiers(ctx context.Context, zoneID string) ([]*domain.ZonePriceTier, error) {
+	if _, err := s.getZone(ctx, zoneID); err != nil {
+		return nil, err
+	}
+	tiers, err := s.tierRepo.ListByZone(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+	domain.SortPriceTiers(tiers)
+	return tiers, nil
+}
+
+// CreatePriceTier adds a price tier to a zone
+func (s *priceTierService) CreatePriceTier(ctx context.Context, zoneID string, req *dto.CreatePriceTierRequest) (*domain.ZonePriceTier, error) {
+	if valid, msg := req.Validate(); !valid {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPriceTier, msg)
+	}
+	zone, err := s.getZone(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	tier := &domain.ZonePriceTier{
+		ID:         uuid.New().String(),
+		ZoneID:     zone.ID,
+		Name:       strings.TrimSpace(req.Name),
+		Price:      req.Price,
+		Allocation: req.Allocation,
+		ValidFrom:  req.ValidFrom,
+		ValidUntil: req.ValidUntil,
+		SortOrder:  req.SortOrder,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := s.tierRepo.Create(ctx, tier); err != nil {
+		return nil, err
+	}
+
+	s.announce(ctx, zone)
+	return tier, nil
+}
+
+// UpdatePriceTier replaces the settings of a zone's price tier
+func (s *priceTierService) UpdatePriceTier(ctx context.Context, zoneID, tierID string, req *dto.UpdatePriceTierRequest) (*domain.ZonePriceTier, error) {
+	if valid, msg := req.Validate(); !valid {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPriceTier, msg)
+	}
+	zone, err := s.getZone(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+	tier, err := s.tierRepo.GetByID(ctx, zoneID, tierID)
+	if err != nil {
+		return nil, err
+	}
+	if tier == nil {
+		return nil, ErrPriceTierNotFound
+	}
+
+	tier.Name = strings.TrimSpace(req.Name)
+	tier.Price = req.Price
+	tier.Allocation = req.Allocation
+	tier.ValidFrom = req.ValidFrom
+	tier.ValidUntil = req.ValidUntil
+	tier.SortOrder = req.SortOrder
+	tier.UpdatedAt = time.Now()
+	if err := s.tierRepo.Update(ctx, tier); err != nil {
+		return nil, err
+	}
+
+	s.announce(ctx, zone)
+	return tier, nil
+}
+
+// DeletePriceTier removes a zone's price tier; the zone sells at its other tiers or base price
+func (s *priceTierService) DeletePriceTier(ctx context.Context, zoneID, tierID string) error {
+	zone, err := s.getZone(ctx, zoneID)
+	if err != nil {
+		return err
+	}
+	deleted, err := s.tierRepo.Delete(ctx, zoneID, tierID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrPriceTierNotFound
+	}
+
+	s.announce(ctx, zone)
+	return nil
+}
+
+// getZone retrieves the zone a tier belongs to
+func (s *priceTierService) getZone(ctx context.Context, zoneID string) (*domain.ShowZone, error) {
+	zone, err := s.showZoneRepo.GetByID(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+	if zone == nil {
+		return nil, ErrShowZoneNotFound
+	}
+	return zone, nil
+}
+
+// announce publishes a zone update so booking-service reprices the zone's next reservations
+func (s *priceTierService) announce(ctx context.Context, zone *domain.ShowZone) {
+	show, err := s.showRepo.GetByID(ctx, zone.ShowID)
+	onSale := err == nil && show != nil && show.Status == domain.ShowStatusOnSale
+	publishZoneEvent(ctx, s.publisher, domain.ZoneUpdatedEventType, zone, onSale)
+}
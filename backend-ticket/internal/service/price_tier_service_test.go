@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
+)
+
+// MockZonePriceTierRepository is an in-memory ZonePriceTierRepository
+type MockZonePriceTierRepository struct {
+	tiers map[string]*domain.ZonePriceTier
+}
+
+func NewMockZonePriceTierRepository() *MockZonePriceTierRepository {
+	return &MockZonePriceTierRepository{tiers: make(map[string]*domain.ZonePriceTier)}
+}
+
+func (m *MockZonePriceTierRepository) Create(ctx context.Context, tier *domain.ZonePriceTier) error {
+	m.tiers[tier.ID] = tier
+	return nil
+}
+
+func (m *MockZonePriceTierRepository) GetByID(ctx context.Context, zoneID, id string) (*domain.ZonePriceTier, error) {
+	if tier, ok := m.tiers[id]; ok && tier.ZoneID == zoneID {
+		return tier, nil
+	}
+	return nil, nil
+}
+
+func (m *MockZonePriceTierRepository) ListByZone(ctx context.Context, zoneID string) ([]*domain.ZonePriceTier, error) {
+	var tiers []*domain.ZonePriceTier
+	for _, tier := range m.tiers {
+		if tier.ZoneID == zoneID {
+			tiers = append(tiers, tier)
+		}
+	}
+	return tiers, nil
+}
+
+func (m *MockZonePriceTierRepository) Update(ctx context.Context, tier *domain.ZonePriceTier) error {
+	m.tiers[tier.ID] = tier
+	return nil
+}
+
+func (m *MockZonePriceTierRepository) Delete(ctx context.Context, zoneID, id string) (bool, error) {
+	if tier, ok := m.tiers[id]; ok && tier.ZoneID == zoneID {
+		delete(m.tiers, id)
+		return true, nil
+	}
+	return false, nil
+}
+
+func TestPriceTierService(t *testing.T) {
+	ctx := context.Background()
+	zoneRepo := NewMockShowZoneRepository()
+	showRepo := NewMockShowRepoForZone()
+	showRepo.AddShow(&domain.Show{ID: "show-1", Status: domain.ShowStatusOnSale})
+	zoneRepo.AddZone(&domain.ShowZone{ID: "zone-1", ShowID: "show-1", Price: 3000, TotalSeats: 100, AvailableSeats: 100, IsActive: true})
+	publisher := &MockCapacityEventPublisher{}
+	svc := NewPriceTierService(NewMockZonePriceTierRepository(), zoneRepo, showRepo, publisher)
+
+	earlyUntil := time.Now().Add(7 * 24 * time.Hour)
+	early, err := svc.CreatePriceTier(ctx, "zone-1", &dto.CreatePriceTierRequest{
+		Name:       " Early Bird ",
+		Price:      2500,
+		Allocation: 20,
+		ValidUntil: &earlyUntil,
+	})
+	if err != nil {
+		t.Fatalf("CreatePriceTier() error = %v", err)
+	}
+	if early.Name != "Early Bird" || early.ZoneID != "zone-1" {
+		t.Errorf("unexpected tier: %+v", early)
+	}
+	if _, err := svc.CreatePriceTier(ctx, "zone-1", &dto.CreatePriceTierRequest{Name: "Regular", Price: 3000, SortOrder: 1}); err != nil {
+		t.Fatalf("CreatePriceTier() error = %v", err)
+	}
+
+	// Booking-service drops its cached schedule on the zone update
+	if len(publisher.zoneEvents) != 2 {
+		t.Fatalf("expected 2 zone events, got %d", len(publisher.zoneEvents))
+	}
+	if event := publisher.zoneEvents[0]; event.EventType != domain.ZoneUpdatedEventType || event.ZoneID != "zone-1" || !event.OnSale {
+		t.Errorf("unexpected zone event: %+v", event)
+	}
+
+	tiers, err := svc.ListPriceTiers(ctx, "zone-1")
+	if err != nil {
+		t.Fatalf("ListPriceTiers() error = %v", err)
+	}
+	if len(tiers) != 2 || tiers[0].ID != early.ID {
+		t.Errorf("expected the early-bird tier first, got %+v", tiers)
+	}
+
+	updated, err := svc.UpdatePriceTier(ctx, "zone-1", early.ID, &dto.UpdatePriceTierRequest{Name: "Early Bird", Price: 2400, Allocation: 10})
+	if err != nil {
+		t.Fatalf("UpdatePriceTier() error = %v", err)
+	}
+	if updated.Price != 2400 || updated.Allocation != 10 || updated.ValidUntil != nil {
+		t.Errorf("unexpected updated tier: %+v", updated)
+	}
+
+	if err := svc.DeletePriceTier(ctx, "zone-1", early.ID); err != nil {
+		t.Fatalf("DeletePriceTier() error = %v", err)
+	}
+	if err := svc.DeletePriceTier(ctx, "zone-1", early.ID); !errors.Is(err, ErrPriceTierNotFound) {
+		t.Errorf("DeletePriceTier() again error = %v, want ErrPriceTierNotFound", err)
+	}
+}
+
+func TestPriceTierService_Errors(t *testing.T) {
+	ctx := context.Background()
+	zoneRepo := NewMockShowZoneRepository()
+	zoneRepo.AddZone(&domain.ShowZone{ID: "zone-1", ShowID: "show-1", IsActive: true})
+	svc := NewPriceTierService(NewMockZonePriceTierRepository(), zoneRepo, NewMockShowRepoForZone(), nil)
+
+	from := time.Now()
+	until := from.Add(-time.Hour)
+	tests := []struct {
+		name   string
+		zoneID string
+		req    *dto.CreatePriceTierRequest
+		want   error
+	}{
+		{"unknown zone", "zone-2", &dto.CreatePriceTierRequest{Name: "Early Bird", Price: 100}, ErrShowZoneNotFound},
+		{"negative allocation", "zone-1", &dto.CreatePriceTierRequest{Name: "Early Bird", Price: 100, Allocation: -1}, ErrInvalidPriceTier},
+		{"window ends before it starts", "zone-1", &dto.CreatePriceTierRequest{Name: "Early Bird", Price: 100, ValidFrom: &from, ValidUntil: &until}, ErrInvalidPriceTier},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := svc.CreatePriceTier(ctx, tt.zoneID, tt.req); !errors.Is(err, tt.want) {
+				t.Errorf("CreatePriceTier() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := svc.UpdatePriceTier(ctx, "zone-1", "missing", &dto.UpdatePriceTierRequest{Name: "Regular"}); !errors.Is(err, ErrPriceTierNotFound) {
+		t.Errorf("UpdatePriceTier() error = %v, want ErrPriceTierNotFound", err)
+	}
+}
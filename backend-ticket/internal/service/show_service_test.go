@@ -8,6 +8,7 @@ import (
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 )
 
 // MockShowRepository is a mock implementation of ShowRepository
@@ -111,11 +112,11 @@ func (m *MockEventRepoForShow) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-func (m *MockEventRepoForShow) ListPublished(ctx context.Context, limit, offset int) ([]*domain.Event, int, error) {
+func (m *MockEventRepoForShow) ListPublished(ctx context.Context, page pagination.Params) ([]*domain.Event, int, error) {
 	return nil, 0, nil
 }
 
-func (m *MockEventRepoForShow) List(ctx context.Context, filter *repository.EventFilter, limit, offset int) ([]*domain.Event, int, error) {
+func (m *MockEventRepoForShow) List(ctx context.Context, filter *repository.EventFilter, page pagination.Params) ([]*domain.Event, int, error) {
 	return nil, 0, nil
 }
 
@@ -142,10 +143,34 @@ func (m *MockZoneSyncerForShow) SyncZone(ctx context.Context, zone *domain.ShowZ
 	return nil
 }
 
+func (m *MockZoneSyncerForShow) SeedZone(ctx context.Context, zone *domain.ShowZone) (bool, error) {
+	return false, nil
+}
+
 func (m *MockZoneSyncerForShow) RemoveZone(ctx context.Context, zoneID string) error {
 	return nil
 }
 
+func (m *MockZoneSyncerForShow) GetAvailability(ctx context.Context, zoneID string) (int, bool, error) {
+	return 0, false, nil
+}
+
+func (m *MockZoneSyncerForShow) GetCapacityStatus(ctx context.Context, zoneID string) (*domain.ZoneCapacityStatus, error) {
+	return nil, nil
+}
+
+func (m *MockZoneSyncerForShow) SyncZoneHoldTTL(ctx context.Context, zone *domain.ShowZone) error {
+	return nil
+}
+
+func (m *MockZoneSyncerForShow) SyncEventHoldTTL(ctx context.Context, event *domain.Event) error {
+	return nil
+}
+
+func (m *MockZoneSyncerForShow) SyncEventVerificationPolicy(ctx context.Context, event *domain.Event) error {
+	return nil
+}
+
 func TestShowService_CreateShow(t *testing.T) {
 	mockShowRepo := NewMockShowRepository()
 	mockEventRepo := NewMockEventRepoForShow()
@@ -3,32 +3,42 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/events"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
 )
 
 // ShowZoneService errors
 var (
-	ErrShowZoneNotFound = errors.New("show zone not found")
+	ErrShowZoneNotFound          = errors.New("show zone not found")
+	ErrCapacityBelowCommitted    = errors.New("zone capacity cannot be reduced below sold and held seats")
+	ErrCapacityChangeUnavailable = errors.New("capacity changes for on-sale zones are temporarily unavailable")
+	ErrCapacityStatusNotFound    = errors.New("no capacity change recorded for zone")
 )
 
 // showZoneService implements the ShowZoneService interface
 type showZoneService struct {
-	showZoneRepo repository.ShowZoneRepository
-	showRepo     repository.ShowRepository
-	zoneSyncer   ZoneSyncer
+	showZoneRepo      repository.ShowZoneRepository
+	showRepo          repository.ShowRepository
+	zoneSyncer        ZoneSyncer
+	capacityPublisher CapacityEventPublisher
 }
 
-// NewShowZoneService creates a new ShowZoneService
-func NewShowZoneService(showZoneRepo repository.ShowZoneRepository, showRepo repository.ShowRepository, zoneSyncer ZoneSyncer) ShowZoneService {
+// NewShowZoneService creates a new ShowZoneService.
+// capacityPublisher may be nil, in which case capacity changes are refused for zones
+// whose inventory is live in Redis.
+func NewShowZoneService(showZoneRepo repository.ShowZoneRepository, showRepo repository.ShowRepository, zoneSyncer ZoneSyncer, capacityPublisher CapacityEventPublisher) ShowZoneService {
 	return &showZoneService{
-		showZoneRepo: showZoneRepo,
-		showRepo:     showRepo,
-		zoneSyncer:   zoneSyncer,
+		showZoneRepo:      showZoneRepo,
+		showRepo:          showRepo,
+		zoneSyncer:        zoneSyncer,
+		capacityPublisher: capacityPublisher,
 	}
 }
 
@@ -59,6 +69,7 @@ func (s *showZoneService) CreateShowZone(ctx context.Context, req *dto.CreateSho
 		AvailableSeats: req.TotalSeats, // Initially all seats are available
 		Description:    req.Description,
 		SortOrder:      req.SortOrder,
+		HoldTTLSeconds: req.HoldTTLSeconds,
 		IsActive:       true, // Default to active
 		CreatedAt:      now,
 		UpdatedAt:      now,
@@ -69,10 +80,13 @@ func (s *showZoneService) CreateShowZone(ctx context.Context, req *dto.CreateSho
 	}
 
 	// Only sync to Redis if show is on_sale
-	if show.Status == domain.ShowStatusOnSale && zone.IsActive {
+	onSale := show.Status == domain.ShowStatusOnSale
+	if onSale && zone.IsActive {
 		_ = s.zoneSyncer.SyncZone(ctx, zone)
 	}
 
+	s.publishZoneEvent(ctx, domain.ZoneCreatedEventType, zone, onSale)
+
 	return zone, nil
 }
 
@@ -104,7 +118,10 @@ func (s *showZoneService) ListZonesByShow(ctx context.Context, showID string, fi
 	return s.showZoneRepo.GetByShowID(ctx, showID, filter.IsActive, filter.Limit, filter.Offset)
 }
 
-// UpdateShowZone updates a show zone
+// UpdateShowZone updates a show zone.
+// Capacity changes for zones that are live in Redis are not written here: the live counter
+// already has holds deducted, so the change is published for the inventory worker to apply
+// atomically and the returned zone carries PendingTotalSeats.
 func (s *showZoneService) UpdateShowZone(ctx context.Context, id string, req *dto.UpdateShowZoneRequest) (*domain.ShowZone, error) {
 	// Validate request
 	if valid, msg := req.Validate(); !valid {
@@ -120,6 +137,42 @@ func (s *showZoneService) UpdateShowZone(ctx context.Context, id string, req *dt
 		return nil, ErrShowZoneNotFound
 	}
 
+	show, err := s.showRepo.GetByID(ctx, zone.ShowID)
+	onSale := err == nil && show != nil && show.Status == domain.ShowStatusOnSale
+
+	// A zone is live when its inventory counter is tracked in Redis
+	live := false
+	liveAvailable := 0
+	if onSale && zone.IsActive {
+		liveAvailable, live, err = s.zoneSyncer.GetAvailability(ctx, zone.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var capacityEvent *domain.ZoneCapacityChangedEvent
+	if req.TotalSeats != nil && *req.TotalSeats != zone.TotalSeats {
+		if live {
+			capacityEvent, err = s.prepareCapacityChange(zone, *req.TotalSeats, liveAvailable)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			committed := zone.SoldSeats + zone.ReservedSeats
+			if *req.TotalSeats < committed {
+				return nil, capacityBelowCommittedError(*req.TotalSeats, committed)
+			}
+
+			// Calculate the difference and adjust available seats
+			diff := *req.TotalSeats - zone.TotalSeats
+			zone.TotalSeats = *req.TotalSeats
+			zone.AvailableSeats += diff
+			if zone.AvailableSeats < 0 {
+				zone.AvailableSeats = 0
+			}
+		}
+	}
+
 	// Update fields
 	if req.Name != "" {
 		zone.Name = req.Name
@@ -127,15 +180,6 @@ func (s *showZoneService) UpdateShowZone(ctx context.Context, id string, req *dt
 	if req.Price != nil {
 		zone.Price = *req.Price
 	}
-	if req.TotalSeats != nil {
-		// Calculate the difference and adjust available seats
-		diff := *req.TotalSeats - zone.TotalSeats
-		zone.TotalSeats = *req.TotalSeats
-		zone.AvailableSeats += diff
-		if zone.AvailableSeats < 0 {
-			zone.AvailableSeats = 0
-		}
-	}
 	if req.Description != "" {
 		zone.Description = req.Description
 	}
@@ -145,25 +189,111 @@ func (s *showZoneService) UpdateShowZone(ctx context.Context, id string, req *dt
 	if req.IsActive != nil {
 		zone.IsActive = *req.IsActive
 	}
+	if req.HoldTTLSeconds != nil {
+		if *req.HoldTTLSeconds == 0 {
+			zone.HoldTTLSeconds = nil
+		} else {
+			holdTTL := *req.HoldTTLSeconds
+			zone.HoldTTLSeconds = &holdTTL
+		}
+	}
 
 	if err := s.showZoneRepo.Update(ctx, zone); err != nil {
 		return nil, err
 	}
 
+	// Publish after the update so the worker's seat_zones write is not overwritten
+	if capacityEvent != nil {
+		if err := s.capacityPublisher.PublishCapacityChanged(ctx, capacityEvent); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrCapacityChangeUnavailable, err)
+		}
+		pending := capacityEvent.NewTotal
+		zone.PendingTotalSeats = &pending
+	}
+
 	// Only sync to Redis if show is on_sale
-	show, err := s.showRepo.GetByID(ctx, zone.ShowID)
-	if err == nil && show != nil && show.Status == domain.ShowStatusOnSale {
-		if zone.IsActive {
-			_ = s.zoneSyncer.SyncZone(ctx, zone)
-		} else {
+	if onSale {
+		if !zone.IsActive {
 			// Remove from Redis if zone is deactivated
 			_ = s.zoneSyncer.RemoveZone(ctx, zone.ID)
+		} else if !live {
+			// Seed zones not yet tracked; overwriting a live counter would drop in-flight holds
+			_ = s.zoneSyncer.SyncZone(ctx, zone)
+		} else if req.HoldTTLSeconds != nil {
+			// New reservations pick up the changed hold, existing holds keep theirs
+			_ = s.zoneSyncer.SyncZoneHoldTTL(ctx, zone)
 		}
 	}
 
+	s.publishZoneEvent(ctx, domain.ZoneUpdatedEventType, zone, onSale)
+
 	return zone, nil
 }
 
+// publishZoneEvent lets the inventory worker seed the zone in booking Redis ahead of the first
+// reservation. Best effort: booking-service still fetches zones it does not know on demand.
+func (s *showZoneService) publishZoneEvent(ctx context.Context, eventType string, zone *domain.ShowZone, onSale bool) {
+	publishZoneEvent(ctx, s.capacityPublisher, eventType, zone, onSale)
+}
+
+// publishZoneEvent publishes a zone lifecycle event, doing nothing without a publisher
+func publishZoneEvent(ctx context.Context, publisher CapacityEventPublisher, eventType string, zone *domain.ShowZone, onSale bool) {
+	if publisher == nil {
+		return
+	}
+
+	event, err := events.NewZoneEvent(eventType, uuid.New().String(), zone.ID, zone.ShowID)
+	if err != nil {
+		logger.Get().Error(fmt.Sprintf("Failed to build %s event for zone %s: %v", eventType, zone.ID, err))
+		return
+	}
+	event.TotalSeats = zone.TotalSeats
+	event.AvailableSeats = zone.AvailableSeats
+	event.HoldTTLSeconds = zone.HoldTTLSeconds
+	event.IsActive = zone.IsActive
+	event.OnSale = onSale
+	_ = publisher.PublishZoneEvent(ctx, event)
+}
+
+// prepareCapacityChange validates a capacity change for a live zone against its sold and
+// held seats and builds the event for the inventory worker
+func (s *showZoneService) prepareCapacityChange(zone *domain.ShowZone, newTotal, liveAvailable int) (*domain.ZoneCapacityChangedEvent, error) {
+	committed := zone.TotalSeats - liveAvailable
+	if newTotal < committed {
+		return nil, capacityBelowCommittedError(newTotal, committed)
+	}
+	if s.capacityPublisher == nil {
+		return nil, ErrCapacityChangeUnavailable
+	}
+
+	return events.NewZoneCapacityChangedEvent(uuid.New().String(), zone.ID, zone.ShowID, zone.TotalSeats, newTotal)
+}
+
+// capacityBelowCommittedError explains why a capacity reduction was refused
+func capacityBelowCommittedError(requested, committed int) error {
+	return fmt.Errorf("%w: %d seats are sold or held, requested capacity is %d", ErrCapacityBelowCommitted, committed, requested)
+}
+
+// GetCapacityStatus returns the outcome of the latest capacity change applied by the inventory worker
+func (s *showZoneService) GetCapacityStatus(ctx context.Context, id string) (*domain.ZoneCapacityStatus, error) {
+	zone, err := s.showZoneRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if zone == nil {
+		return nil, ErrShowZoneNotFound
+	}
+
+	status, err := s.zoneSyncer.GetCapacityStatus(ctx, zone.ID)
+	if err != nil {
+		return nil, err
+	}
+	if status == nil {
+		return nil, ErrCapacityStatusNotFound
+	}
+	return status, nil
+}
+
 // DeleteShowZone soft deletes a show zone
 func (s *showZoneService) DeleteShowZone(ctx context.Context, id string) error {
 	// Check if zone exists
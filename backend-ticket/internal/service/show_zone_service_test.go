@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -137,7 +138,7 @@ func (m *MockShowRepoForZone) AddShow(show *domain.Show) {
 func TestShowZoneService_CreateShowZone(t *testing.T) {
 	mockZoneRepo := NewMockShowZoneRepository()
 	mockShowRepo := NewMockShowRepoForZone()
-	svc := NewShowZoneService(mockZoneRepo, mockShowRepo, nil)
+	svc := NewShowZoneService(mockZoneRepo, mockShowRepo, nil, nil)
 
 	// Add test show
 	now := time.Now()
@@ -232,7 +233,7 @@ func TestShowZoneService_CreateShowZone(t *testing.T) {
 func TestShowZoneService_GetShowZoneByID(t *testing.T) {
 	mockZoneRepo := NewMockShowZoneRepository()
 	mockShowRepo := NewMockShowRepoForZone()
-	svc := NewShowZoneService(mockZoneRepo, mockShowRepo, nil)
+	svc := NewShowZoneService(mockZoneRepo, mockShowRepo, nil, nil)
 
 	// Add test zone
 	now := time.Now()
@@ -287,7 +288,7 @@ func TestShowZoneService_GetShowZoneByID(t *testing.T) {
 func TestShowZoneService_ListZonesByShow(t *testing.T) {
 	mockZoneRepo := NewMockShowZoneRepository()
 	mockShowRepo := NewMockShowRepoForZone()
-	svc := NewShowZoneService(mockZoneRepo, mockShowRepo, nil)
+	svc := NewShowZoneService(mockZoneRepo, mockShowRepo, nil, nil)
 
 	// Add test show
 	now := time.Now()
@@ -373,7 +374,7 @@ func TestShowZoneService_ListZonesByShow(t *testing.T) {
 func TestShowZoneService_UpdateShowZone(t *testing.T) {
 	mockZoneRepo := NewMockShowZoneRepository()
 	mockShowRepo := NewMockShowRepoForZone()
-	svc := NewShowZoneService(mockZoneRepo, mockShowRepo, nil)
+	svc := NewShowZoneService(mockZoneRepo, mockShowRepo, nil, nil)
 
 	// Add test zone
 	now := time.Now()
@@ -467,10 +468,280 @@ func TestShowZoneService_UpdateShowZone(t *testing.T) {
 	}
 }
 
+// MockZoneSyncerForZone is a mock ZoneSyncer backed by an in-memory availability map
+type MockZoneSyncerForZone struct {
+	availability map[string]int
+	holdTTLs     map[string]int
+	synced       []string
+}
+
+func NewMockZoneSyncerForZone() *MockZoneSyncerForZone {
+	return &MockZoneSyncerForZone{
+		availability: make(map[string]int),
+		holdTTLs:     make(map[string]int),
+	}
+}
+
+func (m *MockZoneSyncerForZone) SyncByShowID(ctx context.Context, showID string) error {
+	return nil
+}
+
+func (m *MockZoneSyncerForZone) RemoveByShowID(ctx context.Context, showID string) error {
+	return nil
+}
+
+func (m *MockZoneSyncerForZone) SyncZone(ctx context.Context, zone *domain.ShowZone) error {
+	m.synced = append(m.synced, zone.ID)
+	m.availability[zone.ID] = zone.AvailableSeats
+	return nil
+}
+
+func (m *MockZoneSyncerForZone) SeedZone(ctx context.Context, zone *domain.ShowZone) (bool, error) {
+	if _, ok := m.availability[zone.ID]; ok {
+		return false, nil
+	}
+	m.synced = append(m.synced, zone.ID)
+	m.availability[zone.ID] = zone.AvailableSeats
+	return true, nil
+}
+
+func (m *MockZoneSyncerForZone) RemoveZone(ctx context.Context, zoneID string) error {
+	delete(m.availability, zoneID)
+	return nil
+}
+
+func (m *MockZoneSyncerForZone) GetAvailability(ctx context.Context, zoneID string) (int, bool, error) {
+	available, ok := m.availability[zoneID]
+	return available, ok, nil
+}
+
+func (m *MockZoneSyncerForZone) GetCapacityStatus(ctx context.Context, zoneID string) (*domain.ZoneCapacityStatus, error) {
+	return nil, nil
+}
+
+func (m *MockZoneSyncerForZone) SyncZoneHoldTTL(ctx context.Context, zone *domain.ShowZone) error {
+	if zone.HoldTTLSeconds == nil {
+		delete(m.holdTTLs, zone.ID)
+		return nil
+	}
+	m.holdTTLs[zone.ID] = *zone.HoldTTLSeconds
+	return nil
+}
+
+func (m *MockZoneSyncerForZone) SyncEventHoldTTL(ctx context.Context, event *domain.Event) error {
+	return nil
+}
+
+func (m *MockZoneSyncerForZone) SyncEventVerificationPolicy(ctx context.Context, event *domain.Event) error {
+	return nil
+}
+
+// MockCapacityEventPublisher records published capacity change and zone events
+type MockCapacityEventPublisher struct {
+	events     []*domain.ZoneCapacityChangedEvent
+	zoneEvents []*domain.ZoneEvent
+}
+
+func (m *MockCapacityEventPublisher) PublishCapacityChanged(ctx context.Context, event *domain.ZoneCapacityChangedEvent) error {
+	m.events = append(m.events, event)
+	return nil
+}
+
+func (m *MockCapacityEventPublisher) PublishZoneEvent(ctx context.Context, event *domain.ZoneEvent) error {
+	m.zoneEvents = append(m.zoneEvents, event)
+	return nil
+}
+
+func (m *MockCapacityEventPublisher) Close() error {
+	return nil
+}
+
+func TestShowZoneService_UpdateShowZone_Capacity(t *testing.T) {
+	now := time.Now()
+	setup := func(status string) (*MockShowZoneRepository, *MockShowRepoForZone, *MockZoneSyncerForZone) {
+		mockZoneRepo := NewMockShowZoneRepository()
+		mockShowRepo := NewMockShowRepoForZone()
+		mockShowRepo.AddShow(&domain.Show{ID: "show-1", Status: status})
+		mockZoneRepo.AddZone(&domain.ShowZone{
+			ID:             "zone-1",
+			ShowID:         "show-1",
+			Name:           "VIP Zone",
+			TotalSeats:     100,
+			AvailableSeats: 40,
+			ReservedSeats:  10,
+			SoldSeats:      50,
+			IsActive:       true,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		})
+		return mockZoneRepo, mockShowRepo, NewMockZoneSyncerForZone()
+	}
+	seats := func(n int) *int { return &n }
+
+	t.Run("live zone publishes change and keeps stored capacity", func(t *testing.T) {
+		zoneRepo, showRepo, syncer := setup(domain.ShowStatusOnSale)
+		syncer.availability["zone-1"] = 30 // 70 seats sold or held, including holds not yet in PostgreSQL
+		publisher := &MockCapacityEventPublisher{}
+		svc := NewShowZoneService(zoneRepo, showRepo, syncer, publisher)
+
+		zone, err := svc.UpdateShowZone(context.Background(), "zone-1", &dto.UpdateShowZoneRequest{TotalSeats: seats(80)})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(publisher.events) != 1 {
+			t.Fatalf("expected 1 capacity event, got %d", len(publisher.events))
+		}
+		event := publisher.events[0]
+		if event.PreviousTotal != 100 || event.NewTotal != 80 || event.Delta() != -20 {
+			t.Errorf("unexpected event: previous=%d new=%d delta=%d", event.PreviousTotal, event.NewTotal, event.Delta())
+		}
+		if zone.TotalSeats != 100 || zone.AvailableSeats != 40 {
+			t.Errorf("expected stored capacity untouched, got total=%d available=%d", zone.TotalSeats, zone.AvailableSeats)
+		}
+		if zone.PendingTotalSeats == nil || *zone.PendingTotalSeats != 80 {
+			t.Errorf("expected pending total 80, got %v", zone.PendingTotalSeats)
+		}
+		if len(syncer.synced) != 0 || syncer.availability["zone-1"] != 30 {
+			t.Errorf("expected live counter to be left to the inventory worker, synced=%v", syncer.synced)
+		}
+	})
+
+	t.Run("live zone refuses reduction below sold and held", func(t *testing.T) {
+		zoneRepo, showRepo, syncer := setup(domain.ShowStatusOnSale)
+		syncer.availability["zone-1"] = 30
+		publisher := &MockCapacityEventPublisher{}
+		svc := NewShowZoneService(zoneRepo, showRepo, syncer, publisher)
+
+		_, err := svc.UpdateShowZone(context.Background(), "zone-1", &dto.UpdateShowZoneRequest{TotalSeats: seats(65)})
+		if !errors.Is(err, ErrCapacityBelowCommitted) {
+			t.Fatalf("expected ErrCapacityBelowCommitted, got %v", err)
+		}
+		if len(publisher.events) != 0 {
+			t.Errorf("expected no capacity event, got %d", len(publisher.events))
+		}
+	})
+
+	t.Run("live zone without publisher", func(t *testing.T) {
+		zoneRepo, showRepo, syncer := setup(domain.ShowStatusOnSale)
+		syncer.availability["zone-1"] = 30
+		svc := NewShowZoneService(zoneRepo, showRepo, syncer, nil)
+
+		_, err := svc.UpdateShowZone(context.Background(), "zone-1", &dto.UpdateShowZoneRequest{TotalSeats: seats(120)})
+		if !errors.Is(err, ErrCapacityChangeUnavailable) {
+			t.Fatalf("expected ErrCapacityChangeUnavailable, got %v", err)
+		}
+	})
+
+	t.Run("zone not on sale is updated directly", func(t *testing.T) {
+		zoneRepo, showRepo, syncer := setup(domain.ShowStatusScheduled)
+		svc := NewShowZoneService(zoneRepo, showRepo, syncer, nil)
+
+		if _, err := svc.UpdateShowZone(context.Background(), "zone-1", &dto.UpdateShowZoneRequest{TotalSeats: seats(50)}); !errors.Is(err, ErrCapacityBelowCommitted) {
+			t.Fatalf("expected ErrCapacityBelowCommitted, got %v", err)
+		}
+
+		zone, err := svc.UpdateShowZone(context.Background(), "zone-1", &dto.UpdateShowZoneRequest{TotalSeats: seats(70)})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if zone.TotalSeats != 70 || zone.AvailableSeats != 10 || zone.PendingTotalSeats != nil {
+			t.Errorf("expected total=70 available=10, got total=%d available=%d", zone.TotalSeats, zone.AvailableSeats)
+		}
+	})
+}
+
+func TestShowZoneService_UpdateShowZone_HoldTTL(t *testing.T) {
+	mockZoneRepo := NewMockShowZoneRepository()
+	mockShowRepo := NewMockShowRepoForZone()
+	mockShowRepo.AddShow(&domain.Show{ID: "show-1", Status: domain.ShowStatusOnSale})
+	mockZoneRepo.AddZone(&domain.ShowZone{
+		ID:             "zone-1",
+		ShowID:         "show-1",
+		Name:           "VIP Zone",
+		TotalSeats:     100,
+		AvailableSeats: 100,
+		IsActive:       true,
+	})
+	syncer := NewMockZoneSyncerForZone()
+	syncer.availability["zone-1"] = 60
+	svc := NewShowZoneService(mockZoneRepo, mockShowRepo, syncer, nil)
+	seconds := func(n int) *int { return &n }
+
+	zone, err := svc.UpdateShowZone(context.Background(), "zone-1", &dto.UpdateShowZoneRequest{HoldTTLSeconds: seconds(300)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if zone.HoldTTLSeconds == nil || *zone.HoldTTLSeconds != 300 {
+		t.Errorf("expected hold TTL 300, got %v", zone.HoldTTLSeconds)
+	}
+	if syncer.holdTTLs["zone-1"] != 300 {
+		t.Errorf("expected live zone hold TTL to be synced, got %v", syncer.holdTTLs)
+	}
+	if len(syncer.synced) != 0 || syncer.availability["zone-1"] != 60 {
+		t.Errorf("expected live counter untouched, synced=%v", syncer.synced)
+	}
+
+	// 0 falls back to the event's hold
+	zone, err = svc.UpdateShowZone(context.Background(), "zone-1", &dto.UpdateShowZoneRequest{HoldTTLSeconds: seconds(0)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if zone.HoldTTLSeconds != nil {
+		t.Errorf("expected hold TTL to be cleared, got %d", *zone.HoldTTLSeconds)
+	}
+	if _, ok := syncer.holdTTLs["zone-1"]; ok {
+		t.Error("expected synced hold TTL to be removed")
+	}
+
+	if _, err := svc.UpdateShowZone(context.Background(), "zone-1", &dto.UpdateShowZoneRequest{HoldTTLSeconds: seconds(30)}); err == nil {
+		t.Error("expected error for hold TTL below the minimum")
+	}
+}
+
+func TestShowZoneService_PublishesZoneEvents(t *testing.T) {
+	mockZoneRepo := NewMockShowZoneRepository()
+	mockShowRepo := NewMockShowRepoForZone()
+	mockShowRepo.AddShow(&domain.Show{ID: "show-1", Status: domain.ShowStatusScheduled})
+	publisher := &MockCapacityEventPublisher{}
+	svc := NewShowZoneService(mockZoneRepo, mockShowRepo, NewMockZoneSyncerForZone(), publisher)
+
+	zone, err := svc.CreateShowZone(context.Background(), &dto.CreateShowZoneRequest{
+		ShowID:     "show-1",
+		Name:       "VIP Zone",
+		Price:      100.00,
+		TotalSeats: 50,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(publisher.zoneEvents) != 1 {
+		t.Fatalf("expected 1 zone event, got %d", len(publisher.zoneEvents))
+	}
+	created := publisher.zoneEvents[0]
+	if created.EventType != domain.ZoneCreatedEventType || created.ZoneID != zone.ID || created.AvailableSeats != 50 || !created.IsActive || created.OnSale {
+		t.Errorf("unexpected zone created event: %+v", created)
+	}
+
+	inactive := false
+	if _, err := svc.UpdateShowZone(context.Background(), zone.ID, &dto.UpdateShowZoneRequest{IsActive: &inactive}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(publisher.zoneEvents) != 2 {
+		t.Fatalf("expected 2 zone events, got %d", len(publisher.zoneEvents))
+	}
+	updated := publisher.zoneEvents[1]
+	if updated.EventType != domain.ZoneUpdatedEventType || updated.IsActive || updated.EventID == created.EventID {
+		t.Errorf("unexpected zone updated event: %+v", updated)
+	}
+	if len(publisher.events) != 0 {
+		t.Errorf("expected no capacity events, got %d", len(publisher.events))
+	}
+}
+
 func TestShowZoneService_DeleteShowZone(t *testing.T) {
 	mockZoneRepo := NewMockShowZoneRepository()
 	mockShowRepo := NewMockShowRepoForZone()
-	svc := NewShowZoneService(mockZoneRepo, mockShowRepo, nil)
+	svc := NewShowZoneService(mockZoneRepo, mockShowRepo, nil, nil)
 
 	// Add test zone
 	now := time.Now()
@@ -0,0 +1,436 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+)
+
+// Venue errors
+var (
+	ErrVenueNotFound            = errors.New("venue not found")
+	ErrVenueTenantRequired      = errors.New("venues must belong to a tenant")
+	ErrZoneNotFound             = errors.New("zone not found")
+	ErrZoneCapacityExceeded     = errors.New("zone capacities would exceed the venue capacity")
+	ErrZoneCapacityBelowLayout  = errors.New("zone capacity cannot be reduced below the seats of the active layout")
+	ErrZoneInUse                = errors.New("zone is used by the active layout")
+	ErrVenueLayoutNotFound      = errors.New("venue layout not found")
+	ErrNoActiveLayout           = errors.New("venue has no active layout")
+	ErrInvalidLayout            = errors.New("invalid venue layout")
+	ErrVenueCapacityBelowLayout = errors.New("venue capacity cannot be reduced below the seats of the active layout")
+)
+
+// LayoutValidationError lists the problems of a rejected layout, keyed by their path in the layout
+type LayoutValidationError struct {
+	Problems map[string]string
+}
+
+// Error implements error
+func (e *LayoutValidationError) Error() string {
+	return fmt.Sprintf("%s: %d problem(s)", ErrInvalidLayout, len(e.Problems))
+}
+
+// Unwrap makes errors.Is(err, ErrInvalidLayout) hold
+func (e *LayoutValidationError) Unwrap() error {
+	return ErrInvalidLayout
+}
+
+// venueService implements VenueService
+type venueService struct {
+	venueRepo  repository.VenueRepository
+	zoneRepo   repository.ZoneRepository
+	layoutRepo repository.VenueLayoutRepository
+	now        func() time.Time
+}
+
+// NewVenueService creates a new VenueService
+func NewVenueService(
+	venueRepo repository.VenueRepository,
+	zoneRepo repository.ZoneRepository,
+	layoutRepo repository.VenueLayoutRepository,
+) VenueService {
+	return &venueService{
+		venueRepo:  venueRepo,
+		zoneRepo:   zoneRepo,
+		layoutRepo: layoutRepo,
+		now:        time.Now,
+	}
+}
+
+// CreateVenue creates a new venue in the actor's tenant
+func (s *venueService) CreateVenue(ctx context.Context, actor *domain.Actor, req *dto.CreateVenueRequest) (*domain.Venue, error) {
+	if valid, msg := req.Validate(); !valid {
+		return nil, errors.New(msg)
+	}
+	if actor.TenantID == "" {
+		return nil, ErrVenueTenantRequired
+	}
+
+	now := s.now()
+	venue := &domain.Venue{
+		ID:        uuid.New().String(),
+		Name:      strings.TrimSpace(req.Name),
+		Address:   strings.TrimSpace(req.Address),
+		Capacity:  req.Capacity,
+		TenantID:  actor.TenantID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.venueRepo.Create(ctx, venue); err != nil {
+		return nil, err
+	}
+	return venue, nil
+}
+
+// GetVenue retrieves a venue of the actor's tenant
+func (s *venueService) GetVenue(ctx context.Context, actor *domain.Actor, id string) (*domain.Venue, error) {
+	venue, err := s.venueRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Venues of another tenant are reported as not found so their existence is not leaked
+	if venue == nil || (!actor.IsAdmin() && (actor.TenantID == "" || venue.TenantID != actor.TenantID)) {
+		return nil, ErrVenueNotFound
+	}
+	return venue, nil
+}
+
+// GetVenuesByTenant lists the venues of a tenant, the actor's own if tenantID is empty
+func (s *venueService) GetVenuesByTenant(ctx context.Context, actor *domain.Actor, tenantID string) ([]*domain.Venue, error) {
+	if tenantID == "" {
+		tenantID = actor.TenantID
+	}
+	if tenantID != actor.TenantID && !actor.IsAdmin() {
+		return nil, ErrUnauthorized
+	}
+	if tenantID == "" {
+		return []*domain.Venue{}, nil
+	}
+
+	venues, err := s.venueRepo.GetByTenantID(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if venues == nil {
+		venues = []*domain.Venue{}
+	}
+	return venues, nil
+}
+
+// UpdateVenue updates a venue. Its capacity cannot drop below its zones or its active layout.
+func (s *venueService) UpdateVenue(ctx context.Context, actor *domain.Actor, id string, req *dto.UpdateVenueRequest) (*domain.Venue, error) {
+	if valid, msg := req.Validate(); !valid {
+		return nil, errors.New(msg)
+	}
+	venue, err := s.GetVenue(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if name := strings.TrimSpace(req.Name); name != "" {
+		venue.Name = name
+	}
+	if address := strings.TrimSpace(req.Address); address != "" {
+		venue.Address = address
+	}
+	if req.Capacity != nil && *req.Capacity != venue.Capacity {
+		zones, err := s.zoneRepo.GetByVenueID(ctx, venue.ID)
+		if err != nil {
+			return nil, err
+		}
+		if zoneCapacity(zones) > *req.Capacity {
+			return nil, ErrZoneCapacityExceeded
+		}
+		active, err := s.activeLayout(ctx, venue)
+		if err != nil {
+			return nil, err
+		}
+		if active != nil && active.SeatCount > *req.Capacity {
+			return nil, ErrVenueCapacityBelowLayout
+		}
+		venue.Capacity = *req.Capacity
+	}
+
+	if err := s.venueRepo.Update(ctx, venue); err != nil {
+		return nil, err
+	}
+	return venue, nil
+}
+
+// DeleteVenue deletes a venue; its zones and layouts are deleted with it
+func (s *venueService) DeleteVenue(ctx context.Context, actor *domain.Actor, id string) error {
+	venue, err := s.GetVenue(ctx, actor, id)
+	if err != nil {
+		return err
+	}
+	if err := s.venueRepo.Delete(ctx, venue.ID); err != nil {
+		return err
+	}
+	logger.Get().Info(fmt.Sprintf("Venue %s deleted by %s", venue.ID, actor.UserID))
+	return nil
+}
+
+// CreateZone adds a zone to a venue as long as the zones still fit the venue capacity
+func (s *venueService) CreateZone(ctx context.Context, actor *domain.Actor, req *dto.CreateZoneRequest) (*domain.Zone, error) {
+	if valid, msg := req.Validate(); !valid {
+		return nil, errors.New(msg)
+	}
+	venue, err := s.GetVenue(ctx, actor, req.VenueID)
+	if err != nil {
+		return nil, err
+	}
+
+	zones, err := s.zoneRepo.GetByVenueID(ctx, venue.ID)
+	if err != nil {
+		return nil, err
+	}
+	if zoneCapacity(zones)+req.Capacity > venue.Capacity {
+		return nil, ErrZoneCapacityExceeded
+	}
+
+	now := s.now()
+	zone := &domain.Zone{
+		ID:        uuid.New().String(),
+		VenueID:   venue.ID,
+		Name:      strings.TrimSpace(req.Name),
+		Capacity:  req.Capacity,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.zoneRepo.Create(ctx, zone); err != nil {
+		return nil, err
+	}
+	return zone, nil
+}
+
+// ListZones lists the zones of a venue
+func (s *venueService) ListZones(ctx context.Context, actor *domain.Actor, venueID string) ([]*domain.Zone, error) {
+	venue, err := s.GetVenue(ctx, actor, venueID)
+	if err != nil {
+		return nil, err
+	}
+	zones, err := s.zoneRepo.GetByVenueID(ctx, venue.ID)
+	if err != nil {
+		return nil, err
+	}
+	if zones == nil {
+		zones = []*domain.Zone{}
+	}
+	return zones, nil
+}
+
+// UpdateZone updates a zone. Its capacity must fit the venue and hold the zone's seats in the active layout.
+func (s *venueService) UpdateZone(ctx context.Context, actor *domain.Actor, venueID, zoneID string, req *dto.UpdateZoneRequest) (*domain.Zone, error) {
+	if valid, msg := req.Validate(); !valid {
+		return nil, errors.New(msg)
+	}
+	venue, zone, err := s.getZone(ctx, actor, venueID, zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	if name := strings.TrimSpace(req.Name); name != "" {
+		zone.Name = name
+	}
+	if req.Capacity != nil && *req.Capacity != zone.Capacity {
+		zones, err := s.zoneRepo.GetByVenueID(ctx, venue.ID)
+		if err != nil {
+			return nil, err
+		}
+		if zoneCapacity(zones)-zone.Capacity+*req.Capacity > venue.Capacity {
+			return nil, ErrZoneCapacityExceeded
+		}
+		active, err := s.activeLayout(ctx, venue)
+		if err != nil {
+			return nil, err
+		}
+		if active != nil && active.Layout.SeatsByZone()[zone.ID] > *req.Capacity {
+			return nil, ErrZoneCapacityBelowLayout
+		}
+		zone.Capacity = *req.Capacity
+	}
+
+	if err := s.zoneRepo.Update(ctx, zone); err != nil {
+		return nil, err
+	}
+	return zone, nil
+}
+
+// DeleteZone deletes a zone unless the active layout sells seats in it
+func (s *venueService) DeleteZone(ctx context.Context, actor *domain.Actor, venueID, zoneID string) error {
+	venue, zone, err := s.getZone(ctx, actor, venueID, zoneID)
+	if err != nil {
+		return err
+	}
+	active, err := s.activeLayout(ctx, venue)
+	if err != nil {
+		return err
+	}
+	if active != nil {
+		if _, used := active.Layout.SeatsByZone()[zone.ID]; used {
+			return ErrZoneInUse
+		}
+	}
+	return s.zoneRepo.Delete(ctx, zone.ID)
+}
+
+// UploadLayout validates a seat map against the venue zones and capacity and stores it as
+// the venue's next layout version, activating it unless the request says otherwise
+func (s *venueService) UploadLayout(ctx context.Context, actor *domain.Actor, venueID string, req *dto.UploadVenueLayoutRequest) (*domain.VenueLayout, error) {
+	if valid, msg := req.Validate(); !valid {
+		return nil, errors.New(msg)
+	}
+	venue, err := s.GetVenue(ctx, actor, venueID)
+	if err != nil {
+		return nil, err
+	}
+	zones, err := s.zoneRepo.GetByVenueID(ctx, venue.ID)
+	if err != nil {
+		return nil, err
+	}
+	if problems := req.Layout.Validate(zones, venue.Capacity); problems != nil {
+		return nil, &LayoutValidationError{Problems: problems}
+	}
+
+	layout := &domain.VenueLayout{
+		ID:        uuid.New().String(),
+		VenueID:   venue.ID,
+		Layout:    req.Layout,
+		SeatCount: req.Layout.SeatCount(),
+		Notes:     strings.TrimSpace(req.Notes),
+		CreatedBy: actor.UserID,
+		CreatedAt: s.now(),
+	}
+	if err := s.layoutRepo.Create(ctx, layout); err != nil {
+		return nil, err
+	}
+
+	if req.ShouldActivate() {
+		venue.ActiveLayoutVersion = layout.Version
+		if err := s.venueRepo.Update(ctx, venue); err != nil {
+			return nil, err
+		}
+	}
+
+	logger.Get().Info(fmt.Sprintf("Venue %s layout v%d uploaded with %d seats (active=%t)",
+		venue.ID, layout.Version, layout.SeatCount, req.ShouldActivate()))
+	return layout, nil
+}
+
+// ListLayouts lists the layout versions of a venue, newest first
+func (s *venueService) ListLayouts(ctx context.Context, actor *domain.Actor, venueID string) ([]*domain.VenueLayout, error) {
+	venue, err := s.GetVenue(ctx, actor, venueID)
+	if err != nil {
+		return nil, err
+	}
+	return s.layoutRepo.ListByVenue(ctx, venue.ID)
+}
+
+// GetLayout retrieves a layout version of a venue
+func (s *venueService) GetLayout(ctx context.Context, actor *domain.Actor, venueID string, version int) (*domain.VenueLayout, error) {
+	venue, err := s.GetVenue(ctx, actor, venueID)
+	if err != nil {
+		return nil, err
+	}
+	layout, err := s.layoutRepo.GetVersion(ctx, venue.ID, version)
+	if err != nil {
+		return nil, err
+	}
+	if layout == nil {
+		return nil, ErrVenueLayoutNotFound
+	}
+	return layout, nil
+}
+
+// ActivateLayout makes a layout version the one served to buyers. The version is checked
+// against the zones again, as they may have changed since it was uploaded.
+func (s *venueService) ActivateLayout(ctx context.Context, actor *domain.Actor, venueID string, version int) (*domain.Venue, error) {
+	venue, err := s.GetVenue(ctx, actor, venueID)
+	if err != nil {
+		return nil, err
+	}
+	layout, err := s.layoutRepo.GetVersion(ctx, venue.ID, version)
+	if err != nil {
+		return nil, err
+	}
+	if layout == nil {
+		return nil, ErrVenueLayoutNotFound
+	}
+	zones, err := s.zoneRepo.GetByVenueID(ctx, venue.ID)
+	if err != nil {
+		return nil, err
+	}
+	if problems := layout.Layout.Validate(zones, venue.Capacity); problems != nil {
+		return nil, &LayoutValidationError{Problems: problems}
+	}
+
+	venue.ActiveLayoutVersion = layout.Version
+	if err := s.venueRepo.Update(ctx, venue); err != nil {
+		return nil, err
+	}
+	return venue, nil
+}
+
+// GetRenderedLayout renders the active layout of a venue for seat selection
+func (s *venueService) GetRenderedLayout(ctx context.Context, venueID string) (*domain.RenderedLayout, error) {
+	venue, err := s.venueRepo.GetByID(ctx, venueID)
+	if err != nil {
+		return nil, err
+	}
+	if venue == nil {
+		return nil, ErrVenueNotFound
+	}
+	layout, err := s.activeLayout(ctx, venue)
+	if err != nil {
+		return nil, err
+	}
+	if layout == nil {
+		return nil, ErrNoActiveLayout
+	}
+	zones, err := s.zoneRepo.GetByVenueID(ctx, venue.ID)
+	if err != nil {
+		return nil, err
+	}
+	return layout.Render(venue, zones), nil
+}
+
+// getZone retrieves a venue of the actor's tenant and one of its zones
+func (s *venueService) getZone(ctx context.Context, actor *domain.Actor, venueID, zoneID string) (*domain.Venue, *domain.Zone, error) {
+	venue, err := s.GetVenue(ctx, actor, venueID)
+	if err != nil {
+		return nil, nil, err
+	}
+	zone, err := s.zoneRepo.GetByID(ctx, zoneID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if zone == nil || zone.VenueID != venue.ID {
+		return nil, nil, ErrZoneNotFound
+	}
+	return venue, zone, nil
+}
+
+// activeLayout returns the active layout version of a venue, nil if none is active
+func (s *venueService) activeLayout(ctx context.Context, venue *domain.Venue) (*domain.VenueLayout, error) {
+	if venue.ActiveLayoutVersion == 0 {
+		return nil, nil
+	}
+	return s.layoutRepo.GetVersion(ctx, venue.ID, venue.ActiveLayoutVersion)
+}
+
+// zoneCapacity returns the total capacity of zones
+func zoneCapacity(zones []*domain.Zone) int {
+	total := 0
+	for _, zone := range zones {
+		total += zone.Capacity
+	}
+	return total
+}
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/dto"
+)
+
+// MockVenueRepository is a mock implementation of VenueRepository
+type MockVenueRepository struct {
+	venues map[string]*domain.Venue
+}
+
+func NewMockVenueRepository() *MockVenueRepository {
+	return &MockVenueRepository{venues: make(map[string]*domain.Venue)}
+}
+
+func (m *MockVenueRepository) Create(ctx context.Context, venue *domain.Venue) error {
+	m.venues[venue.ID] = venue
+	return nil
+}
+
+func (m *MockVenueRepository) GetByID(ctx context.Context, id string) (*domain.Venue, error) {
+	if venue, ok := m.venues[id]; ok {
+		v := *venue
+		return &v, nil
+	}
+	return nil, nil
+}
+
+func (m *MockVenueRepository) GetByTenantID(ctx context.Context, tenantID string) ([]*domain.Venue, error) {
+	var venues []*domain.Venue
+	for _, v := range m.venues {
+		if v.TenantID == tenantID {
+			venues = append(venues, v)
+		}
+	}
+	return venues, nil
+}
+
+func (m *MockVenueRepository) Update(ctx context.Context, venue *domain.Venue) error {
+	v := *venue
+	m.venues[venue.ID] = &v
+	return nil
+}
+
+func (m *MockVenueRepository) Delete(ctx context.Context, id string) error {
+	delete(m.venues, id)
+	return nil
+}
+
+// MockZoneRepository is a mock implementation of ZoneRepository
+type MockZoneRepository struct {
+	zones map[string]*domain.Zone
+}
+
+func NewMockZoneRepository() *MockZoneRepository {
+	return &MockZoneRepository{zones: make(map[string]*domain.Zone)}
+}
+
+func (m *MockZoneRepository) Create(ctx context.Context, zone *domain.Zone) error {
+	m.zones[zone.ID] = zone
+	return nil
+}
+
+func (m *MockZoneRepository) GetByID(ctx context.Context, id string) (*domain.Zone, error) {
+	if zone, ok := m.zones[id]; ok {
+		z := *zone
+		return &z, nil
+	}
+	return nil, nil
+}
+
+func (m *MockZoneRepository) GetByVenueID(ctx context.Context, venueID string) ([]*domain.Zone, error) {
+	var zones []*domain.Zone
+	for _, z := range m.zones {
+		if z.VenueID == venueID {
+			zones = append(zones, z)
+		}
+	}
+	sort.Slice(zones, func(i, j int) bool { return zones[i].ID < zones[j].ID })
+	return zones, nil
+}
+
+func (m *MockZoneRepository) Update(ctx context.Context, zone *domain.Zone) error {
+	z := *zone
+	m.zones[zone.ID] = &z
+	return nil
+}
+
+func (m *MockZoneRepository) Delete(ctx context.Context, id string) error {
+	delete(m.zones, id)
+	return nil
+}
+
+// MockVenueLayoutRepository is a mock implementation of VenueLayoutRepository
+type MockVenueLayoutRepository struct {
+	layouts []*domain.VenueLayout
+}
+
+func (m *MockVenueLayoutRepository) Create(ctx context.Context, layout *domain.VenueLayout) error {
+	layout.Version = 1
+	for _, l := range m.layouts {
+		if l.VenueID == layout.VenueID && l.Version >= layout.Version {
+			layout.Version = l.Version + 1
+		}
+	}
+	m.layouts = append(m.layouts, layout)
+	return nil
+}
+
+func (m *MockVenueLayoutRepository) GetVersion(ctx context.Context, venueID string, version int) (*domain.VenueLayout, error) {
+	for _, l := range m.layouts {
+		if l.VenueID == venueID && l.Version == version {
+			return l, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockVenueLayoutRepository) ListByVenue(ctx context.Context, venueID string) ([]*domain.VenueLayout, error) {
+	layouts := []*domain.VenueLayout{}
+	for i := len(m.layouts) - 1; i >= 0; i-- {
+		if m.layouts[i].VenueID == venueID {
+			layouts = append(layouts, m.layouts[i])
+		}
+	}
+	return layouts, nil
+}
+
+type venueFixture struct {
+	svc     VenueService
+	venues  *MockVenueRepository
+	zones   *MockZoneRepository
+	layouts *MockVenueLayoutRepository
+	owner   *domain.Actor
+}
+
+// newVenueFixture creates venue-1 (tenant-1, capacity 100) with zone-a (capacity 4) and zone-b (capacity 50)
+func newVenueFixture() *venueFixture {
+	f := &venueFixture{
+		venues:  NewMockVenueRepository(),
+		zones:   NewMockZoneRepository(),
+		layouts: &MockVenueLayoutRepository{},
+		owner:   &domain.Actor{UserID: "owner", TenantID: "tenant-1", Role: "organizer"},
+	}
+	f.svc = NewVenueService(f.venues, f.zones, f.layouts)
+
+	f.venues.Create(context.Background(), &domain.Venue{ID: "venue-1", Name: "Hall", TenantID: "tenant-1", Capacity: 100})
+	f.zones.Create(context.Background(), &domain.Zone{ID: "zone-a", VenueID: "venue-1", Name: "A", Capacity: 4})
+	f.zones.Create(context.Background(), &domain.Zone{ID: "zone-b", VenueID: "venue-1", Name: "B", Capacity: 50})
+	return f
+}
+
+// seatMap returns a layout with the given number of seats in one row of zone-a
+func seatMap(seats int) *domain.SeatLayout {
+	row := &domain.LayoutRow{Label: "1"}
+	for i := 1; i <= seats; i++ {
+		row.Seats = append(row.Seats, &domain.LayoutSeat{Number: string(rune('0' + i))})
+	}
+	return &domain.SeatLayout{Sections: []*domain.LayoutSection{
+		{ID: "A", Name: "Section A", ZoneID: "zone-a", Rows: []*domain.LayoutRow{row}},
+	}}
+}
+
+func TestVenueService_TenantIsolation(t *testing.T) {
+	f := newVenueFixture()
+	ctx := context.Background()
+	other := &domain.Actor{UserID: "other", TenantID: "tenant-2", Role: "organizer"}
+	admin := &domain.Actor{UserID: "admin", Role: "admin"}
+
+	if _, err := f.svc.GetVenue(ctx, other, "venue-1"); !errors.Is(err, ErrVenueNotFound) {
+		t.Errorf("GetVenue() by another tenant error = %v, want ErrVenueNotFound", err)
+	}
+	if _, err := f.svc.GetVenue(ctx, admin, "venue-1"); err != nil {
+		t.Errorf("GetVenue() by admin error = %v", err)
+	}
+	if _, err := f.svc.GetVenuesByTenant(ctx, other, "tenant-1"); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("GetVenuesByTenant() of another tenant error = %v, want ErrUnauthorized", err)
+	}
+	if _, err := f.svc.UploadLayout(ctx, other, "venue-1", &dto.UploadVenueLayoutRequest{Layout: seatMap(1)}); !errors.Is(err, ErrVenueNotFound) {
+		t.Errorf("UploadLayout() by another tenant error = %v, want ErrVenueNotFound", err)
+	}
+
+	venue, err := f.svc.CreateVenue(ctx, other, &dto.CreateVenueRequest{Name: "Arena", Address: "1 Road", Capacity: 10})
+	if err != nil || venue.TenantID != "tenant-2" {
+		t.Errorf("CreateVenue() = %+v, %v, want a venue of tenant-2", venue, err)
+	}
+	if _, err := f.svc.CreateVenue(ctx, admin, &dto.CreateVenueRequest{Name: "Arena", Address: "1 Road", Capacity: 10}); !errors.Is(err, ErrVenueTenantRequired) {
+		t.Errorf("CreateVenue() without tenant error = %v, want ErrVenueTenantRequired", err)
+	}
+}
+
+func TestVenueService_ZoneCapacity(t *testing.T) {
+	f := newVenueFixture()
+	ctx := context.Background()
+
+	if _, err := f.svc.CreateZone(ctx, f.owner, &dto.CreateZoneRequest{VenueID: "venue-1", Name: "C", Capacity: 47}); !errors.Is(err, ErrZoneCapacityExceeded) {
+		t.Errorf("CreateZone() over venue capacity error = %v, want ErrZoneCapacityExceeded", err)
+	}
+	if _, err := f.svc.CreateZone(ctx, f.owner, &dto.CreateZoneRequest{VenueID: "venue-1", Name: "C", Capacity: 46}); err != nil {
+		t.Errorf("CreateZone() error = %v", err)
+	}
+
+	capacity := 99
+	if _, err := f.svc.UpdateVenue(ctx, f.owner, "venue-1", &dto.UpdateVenueRequest{Capacity: &capacity}); !errors.Is(err, ErrZoneCapacityExceeded) {
+		t.Errorf("UpdateVenue() below zones error = %v, want ErrZoneCapacityExceeded", err)
+	}
+	if _, err := f.svc.UpdateZone(ctx, f.owner, "venue-2", "zone-a", &dto.UpdateZoneRequest{Name: "VIP"}); !errors.Is(err, ErrVenueNotFound) {
+		t.Errorf("UpdateZone() of another venue error = %v, want ErrVenueNotFound", err)
+	}
+}
+
+func TestVenueService_UploadLayout(t *testing.T) {
+	f := newVenueFixture()
+	ctx := context.Background()
+
+	// Zone A holds 4 seats
+	_, err := f.svc.UploadLayout(ctx, f.owner, "venue-1", &dto.UploadVenueLayoutRequest{Layout: seatMap(5)})
+	var layoutErr *LayoutValidationError
+	if !errors.As(err, &layoutErr) || layoutErr.Problems["zones.zone-a"] == "" {
+		t.Fatalf("UploadLayout() over zone capacity error = %v, want a zone-a problem", err)
+	}
+	if !errors.Is(err, ErrInvalidLayout) {
+		t.Error("LayoutValidationError should match ErrInvalidLayout")
+	}
+
+	v1, err := f.svc.UploadLayout(ctx, f.owner, "venue-1", &dto.UploadVenueLayoutRequest{Layout: seatMap(4), Notes: "first"})
+	if err != nil {
+		t.Fatalf("UploadLayout() error = %v", err)
+	}
+	noActivate := false
+	v2, err := f.svc.UploadLayout(ctx, f.owner, "venue-1", &dto.UploadVenueLayoutRequest{Layout: seatMap(2), Activate: &noActivate})
+	if err != nil {
+		t.Fatalf("UploadLayout() error = %v", err)
+	}
+	if v1.Version != 1 || v2.Version != 2 || v1.SeatCount != 4 || v1.CreatedBy != "owner" {
+		t.Errorf("Versions = %+v, %+v, want v1 with 4 seats and v2", v1, v2)
+	}
+
+	// v1 stays active until v2 is activated
+	rendered, err := f.svc.GetRenderedLayout(ctx, "venue-1")
+	if err != nil || rendered.Version != 1 || rendered.SeatCount != 4 {
+		t.Fatalf("GetRenderedLayout() = %+v, %v, want v1", rendered, err)
+	}
+	if _, err := f.svc.ActivateLayout(ctx, f.owner, "venue-1", 2); err != nil {
+		t.Fatalf("ActivateLayout() error = %v", err)
+	}
+	if rendered, _ = f.svc.GetRenderedLayout(ctx, "venue-1"); rendered.Version != 2 {
+		t.Errorf("GetRenderedLayout() version = %d, want 2", rendered.Version)
+	}
+	if _, err := f.svc.ActivateLayout(ctx, f.owner, "venue-1", 3); !errors.Is(err, ErrVenueLayoutNotFound) {
+		t.Errorf("ActivateLayout() of a missing version error = %v, want ErrVenueLayoutNotFound", err)
+	}
+
+	layouts, _ := f.svc.ListLayouts(ctx, f.owner, "venue-1")
+	if len(layouts) != 2 || layouts[0].Version != 2 {
+		t.Errorf("ListLayouts() = %d versions, want 2 newest first", len(layouts))
+	}
+}
+
+func TestVenueService_ActiveLayoutProtectsZones(t *testing.T) {
+	f := newVenueFixture()
+	ctx := context.Background()
+
+	if _, err := f.svc.GetRenderedLayout(ctx, "venue-1"); !errors.Is(err, ErrNoActiveLayout) {
+		t.Errorf("GetRenderedLayout() without layout error = %v, want ErrNoActiveLayout", err)
+	}
+	if _, err := f.svc.UploadLayout(ctx, f.owner, "venue-1", &dto.UploadVenueLayoutRequest{Layout: seatMap(3)}); err != nil {
+		t.Fatalf("UploadLayout() error = %v", err)
+	}
+
+	capacity := 2
+	if _, err := f.svc.UpdateZone(ctx, f.owner, "venue-1", "zone-a", &dto.UpdateZoneRequest{Capacity: &capacity}); !errors.Is(err, ErrZoneCapacityBelowLayout) {
+		t.Errorf("UpdateZone() below layout error = %v, want ErrZoneCapacityBelowLayout", err)
+	}
+	if err := f.svc.DeleteZone(ctx, f.owner, "venue-1", "zone-a"); !errors.Is(err, ErrZoneInUse) {
+		t.Errorf("DeleteZone() used by layout error = %v, want ErrZoneInUse", err)
+	}
+	if err := f.svc.DeleteZone(ctx, f.owner, "venue-1", "zone-b"); err != nil {
+		t.Errorf("DeleteZone() of an unused zone error = %v", err)
+	}
+}
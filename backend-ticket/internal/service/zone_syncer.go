@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/domain"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/repository"
@@ -17,8 +19,25 @@ type ZoneSyncer interface {
 	RemoveByShowID(ctx context.Context, showID string) error
 	// SyncZone syncs a single zone to Redis
 	SyncZone(ctx context.Context, zone *domain.ShowZone) error
+	// SeedZone syncs a single zone to Redis only if it is not tracked yet, so live
+	// counters are never overwritten. Returns true if the zone was seeded.
+	SeedZone(ctx context.Context, zone *domain.ShowZone) (bool, error)
 	// RemoveZone removes a single zone from Redis
 	RemoveZone(ctx context.Context, zoneID string) error
+	// GetAvailability returns the live available seats for a zone (net of holds).
+	// The boolean is false if the zone is not tracked in Redis.
+	GetAvailability(ctx context.Context, zoneID string) (int, bool, error)
+	// GetCapacityStatus returns the outcome of the latest capacity change for a zone, nil if none
+	GetCapacityStatus(ctx context.Context, zoneID string) (*domain.ZoneCapacityStatus, error)
+	// SyncZoneHoldTTL syncs a zone's reservation hold to Redis without touching its
+	// inventory counter. Removes it when the zone has none.
+	SyncZoneHoldTTL(ctx context.Context, zone *domain.ShowZone) error
+	// SyncEventHoldTTL syncs an event's reservation hold to Redis, zones without
+	// their own hold use it. Removes it when the event has none.
+	SyncEventHoldTTL(ctx context.Context, event *domain.Event) error
+	// SyncEventVerificationPolicy syncs the account verification an event requires to Redis,
+	// read by the booking service on reserve and confirm. Removes it for VerificationPolicyNone.
+	SyncEventVerificationPolicy(ctx context.Context, event *domain.Event) error
 }
 
 // zoneSyncer implements ZoneSyncer
@@ -89,7 +108,25 @@ func (s *zoneSyncer) SyncZone(ctx context.Context, zone *domain.ShowZone) error
 	}
 
 	key := fmt.Sprintf("zone:availability:%s", zone.ID)
-	return s.redis.Set(ctx, key, zone.AvailableSeats, 0).Err()
+	if err := s.redis.Set(ctx, key, zone.AvailableSeats, 0).Err(); err != nil {
+		return err
+	}
+	return s.SyncZoneHoldTTL(ctx, zone)
+}
+
+// SeedZone syncs a single zone to Redis if it is not tracked yet
+func (s *zoneSyncer) SeedZone(ctx context.Context, zone *domain.ShowZone) (bool, error) {
+	if s.redis == nil {
+		return false, nil
+	}
+
+	key := fmt.Sprintf("zone:availability:%s", zone.ID)
+	seeded, err := s.redis.SetNX(ctx, key, zone.AvailableSeats, 0).Result()
+	if err != nil {
+		return false, err
+	}
+	// The hold is configuration, not a live counter, so it is always refreshed
+	return seeded, s.SyncZoneHoldTTL(ctx, zone)
 }
 
 // RemoveZone removes a single zone from Redis
@@ -99,5 +136,104 @@ func (s *zoneSyncer) RemoveZone(ctx context.Context, zoneID string) error {
 	}
 
 	key := fmt.Sprintf("zone:availability:%s", zoneID)
-	return s.redis.Del(ctx, key).Err()
+	return s.redis.Del(ctx, key, zoneHoldTTLKey(zoneID)).Err()
+}
+
+// GetAvailability returns the live available seats for a zone
+func (s *zoneSyncer) GetAvailability(ctx context.Context, zoneID string) (int, bool, error) {
+	if s.redis == nil {
+		return 0, false, nil
+	}
+
+	key := fmt.Sprintf("zone:availability:%s", zoneID)
+	val, err := s.redis.Get(ctx, key).Result()
+	if err != nil {
+		if err.Error() == "redis: nil" {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+
+	available, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid availability for zone %s: %w", zoneID, err)
+	}
+	return available, true, nil
+}
+
+// GetCapacityStatus returns the outcome of the latest capacity change for a zone
+func (s *zoneSyncer) GetCapacityStatus(ctx context.Context, zoneID string) (*domain.ZoneCapacityStatus, error) {
+	if s.redis == nil {
+		return nil, nil
+	}
+
+	// Written by the inventory worker after each capacity change
+	key := fmt.Sprintf("zone:capacity:status:%s", zoneID)
+	val, err := s.redis.Get(ctx, key).Result()
+	if err != nil {
+		if err.Error() == "redis: nil" {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var status domain.ZoneCapacityStatus
+	if err := json.Unmarshal([]byte(val), &status); err != nil {
+		return nil, fmt.Errorf("invalid capacity status for zone %s: %w", zoneID, err)
+	}
+	return &status, nil
+}
+
+// SyncZoneHoldTTL syncs a zone's reservation hold to Redis
+func (s *zoneSyncer) SyncZoneHoldTTL(ctx context.Context, zone *domain.ShowZone) error {
+	if s.redis == nil {
+		return nil
+	}
+
+	return s.syncHoldTTL(ctx, zoneHoldTTLKey(zone.ID), zone.HoldTTLSeconds)
+}
+
+// SyncEventHoldTTL syncs an event's reservation hold to Redis
+func (s *zoneSyncer) SyncEventHoldTTL(ctx context.Context, event *domain.Event) error {
+	if s.redis == nil {
+		return nil
+	}
+
+	return s.syncHoldTTL(ctx, eventHoldTTLKey(event.ID), event.HoldTTLSeconds)
+}
+
+// SyncEventVerificationPolicy syncs the account verification an event requires to Redis
+func (s *zoneSyncer) SyncEventVerificationPolicy(ctx context.Context, event *domain.Event) error {
+	if s.redis == nil {
+		return nil
+	}
+
+	key := eventVerificationPolicyKey(event.ID)
+	if event.VerificationPolicy == "" || event.VerificationPolicy == domain.VerificationPolicyNone {
+		return s.redis.Del(ctx, key).Err()
+	}
+	return s.redis.Set(ctx, key, event.VerificationPolicy, 0).Err()
+}
+
+// syncHoldTTL stores a reservation hold read by the booking service's reserve script
+func (s *zoneSyncer) syncHoldTTL(ctx context.Context, key string, seconds *int) error {
+	if seconds == nil || *seconds <= 0 {
+		return s.redis.Del(ctx, key).Err()
+	}
+	return s.redis.Set(ctx, key, *seconds, 0).Err()
+}
+
+// zoneHoldTTLKey returns the Redis key of a zone's reservation hold in seconds
+func zoneHoldTTLKey(zoneID string) string {
+	return fmt.Sprintf("zone:hold_ttl:%s", zoneID)
+}
+
+// eventHoldTTLKey returns the Redis key of an event's reservation hold in seconds
+func eventHoldTTLKey(eventID string) string {
+	return fmt.Sprintf("event:hold_ttl:%s", eventID)
+}
+
+// eventVerificationPolicyKey returns the Redis key of an event's verification policy
+func eventVerificationPolicyKey(eventID string) string {
+	return fmt.Sprintf("event:verification_policy:%s", eventID)
 }
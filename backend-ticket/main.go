@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net/http"
@@ -12,15 +13,25 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/di"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/service"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/config"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/middleware"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
 )
 
+// topicSpecs are the Kafka topics the ticket service publishes to
+var topicSpecs = []kafka.TopicSpec{
+	{Name: service.DefaultCapacityEventsTopic, Retention: 7 * 24 * time.Hour},
+}
+
 func main() {
+	smokeTest := flag.Bool("smoke", false, "check dependencies end to end and exit non-zero on failure")
+	flag.Parse()
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
@@ -29,7 +40,8 @@ func main() {
 
 	// Initialize logger with OTLP export support
 	logCfg := &logger.Config{
-		Level:        cfg.App.Environment,
+		Level:        cfg.Log.Level,
+		ModuleLevels: cfg.Log.ModuleLevels,
 		ServiceName:  "ticket-service",
 		Development:  cfg.IsDevelopment(),
 		OTLPEnabled:  cfg.OTel.Enabled && cfg.OTel.LogExportEnabled,
@@ -44,16 +56,25 @@ func main() {
 	appLog := logger.Get()
 	appLog.Info("Starting Ticket Service...")
 
+	required := []config.Requirement{config.RequireTicketDatabase, config.RequireRedis, config.RequireKafka, config.RequireJWT}
+	if err := cfg.ValidateFor(required...); err != nil {
+		appLog.Fatal(fmt.Sprintf("Invalid configuration: %v", err))
+	}
+	appLog.Info("Effective configuration: " + cfg.Summary(required...))
+
 	ctx := context.Background()
 
 	// Initialize OpenTelemetry
 	telemetryCfg := &telemetry.Config{
-		Enabled:        cfg.OTel.Enabled,
-		ServiceName:    "ticket-service",
-		ServiceVersion: cfg.App.Version,
-		Environment:    cfg.App.Environment,
-		CollectorAddr:  cfg.OTel.CollectorAddr,
-		SampleRatio:    cfg.OTel.SampleRatio,
+		Enabled:         cfg.OTel.Enabled,
+		ServiceName:     "ticket-service",
+		ServiceVersion:  cfg.App.Version,
+		Environment:     cfg.App.Environment,
+		CollectorAddr:   cfg.OTel.CollectorAddr,
+		SampleRatio:     cfg.OTel.SampleRatio,
+		DynamicSampling: cfg.OTel.DynamicSampling,
+		SlowThreshold:   cfg.OTel.SlowThreshold,
+		SamplingRoutes:  cfg.OTel.SamplingRoutes,
 	}
 	if _, err := telemetry.Init(ctx, telemetryCfg); err != nil {
 		appLog.Warn(fmt.Sprintf("Failed to initialize telemetry: %v", err))
@@ -65,20 +86,21 @@ func main() {
 	// Initialize database connection (uses TicketDatabase config)
 	var db *database.PostgresDB
 	dbCfg := &database.PostgresConfig{
-		Host:            cfg.TicketDatabase.Host,
-		Port:            cfg.TicketDatabase.Port,
-		User:            cfg.TicketDatabase.User,
-		Password:        cfg.TicketDatabase.Password,
-		Database:        cfg.TicketDatabase.DBName,
-		SSLMode:         cfg.TicketDatabase.SSLMode,
-		MaxConns:        10, // Optimized: uses Redis cache heavily
-		MinConns:        2,
-		MaxConnLifetime: 30 * time.Minute,
-		MaxConnIdleTime: 5 * time.Minute,
-		ConnectTimeout:  5 * time.Second,
-		MaxRetries:      3,
-		RetryInterval:   1 * time.Second,
-		EnableTracing:   cfg.OTel.Enabled,
+		Host:               cfg.TicketDatabase.Host,
+		Port:               cfg.TicketDatabase.Port,
+		User:               cfg.TicketDatabase.User,
+		Password:           cfg.TicketDatabase.Password,
+		Database:           cfg.TicketDatabase.DBName,
+		SSLMode:            cfg.TicketDatabase.SSLMode,
+		MaxConns:           10, // Optimized: uses Redis cache heavily
+		MinConns:           2,
+		MaxConnLifetime:    30 * time.Minute,
+		MaxConnIdleTime:    5 * time.Minute,
+		ConnectTimeout:     5 * time.Second,
+		MaxRetries:         3,
+		RetryInterval:      1 * time.Second,
+		EnableTracing:      cfg.OTel.Enabled,
+		SlowQueryThreshold: cfg.TicketDatabase.SlowQueryThreshold,
 	}
 	db, err = database.NewPostgres(ctx, dbCfg)
 	if err != nil {
@@ -90,19 +112,20 @@ func main() {
 	// Initialize Redis connection (optional - cache will be disabled if connection fails)
 	var redisClient *redis.Client
 	redisCfg := &redis.Config{
-		Host:          cfg.Redis.Host,
-		Port:          cfg.Redis.Port,
-		Password:      cfg.Redis.Password,
-		DB:            cfg.Redis.DB,
-		PoolSize:      cfg.Redis.PoolSize,
-		MinIdleConns:  cfg.Redis.MinIdleConns,
-		DialTimeout:   cfg.Redis.DialTimeout,
-		ReadTimeout:   cfg.Redis.ReadTimeout,
-		WriteTimeout:  cfg.Redis.WriteTimeout,
-		MaxRetries:    3,
-		RetryInterval: time.Second,
-		EnableTracing: cfg.OTel.Enabled,
-		ServiceName:   "ticket-service",
+		Host:                 cfg.Redis.Host,
+		Port:                 cfg.Redis.Port,
+		Password:             cfg.Redis.Password,
+		DB:                   cfg.Redis.DB,
+		PoolSize:             cfg.Redis.PoolSize,
+		MinIdleConns:         cfg.Redis.MinIdleConns,
+		DialTimeout:          cfg.Redis.DialTimeout,
+		ReadTimeout:          cfg.Redis.ReadTimeout,
+		WriteTimeout:         cfg.Redis.WriteTimeout,
+		MaxRetries:           3,
+		RetryInterval:        time.Second,
+		EnableTracing:        cfg.OTel.Enabled,
+		SlowCommandThreshold: cfg.Redis.SlowCommandThreshold,
+		ServiceName:          "ticket-service",
 	}
 	redisClient, err = redis.NewClient(ctx, redisCfg)
 	if err != nil {
@@ -111,14 +134,76 @@ func main() {
 	} else {
 		defer redisClient.Close()
 		appLog.Info(fmt.Sprintf("Redis connected (%s)", redisCfg.Addr()))
+
+		// Per-module log levels shared by every instance, changeable without a restart
+		go redisClient.WatchLogLevels(ctx, cfg.Log.RedisKey+":ticket-service", cfg.Log.PollInterval)
+	}
+
+	// Check the Kafka topics this service owns (missing topics are created when
+	// KAFKA_AUTO_CREATE_TOPICS is set, drift from the specs is only reported)
+	if err := kafka.ProvisionTopics(ctx, &kafka.TopicManagerConfig{
+		Brokers:           cfg.Kafka.Brokers,
+		ClientID:          "ticket-service-admin",
+		Partitions:        int32(cfg.Kafka.TopicPartitions),
+		ReplicationFactor: int16(cfg.Kafka.TopicReplicationFactor),
+		AutoCreate:        cfg.Kafka.AutoCreateTopics,
+	}, topicSpecs); err != nil {
+		appLog.Warn(fmt.Sprintf("Kafka topic check failed: %v", err))
+	}
+
+	// Initialize Kafka publisher for zone capacity changes (optional - capacity changes
+	// for on-sale zones are refused without it)
+	capacityPublisher, err := service.NewKafkaCapacityEventPublisher(ctx, &service.CapacityEventPublisherConfig{
+		Brokers:  cfg.Kafka.Brokers,
+		ClientID: cfg.Kafka.ClientID,
+	})
+	if err != nil {
+		appLog.Warn(fmt.Sprintf("Kafka connection failed (capacity changes for on-sale zones disabled): %v", err))
+		capacityPublisher = nil
+	} else {
+		defer capacityPublisher.Close()
+		appLog.Info("Kafka capacity event publisher connected")
 	}
 
 	// Build dependency injection container
 	container := di.NewContainer(&di.ContainerConfig{
-		DB:    db,
-		Redis: redisClient,
+		DB:                db,
+		Redis:             redisClient,
+		CapacityPublisher: capacityPublisher,
+		CacheWarmer: &service.CacheWarmerConfig{
+			EventIDs: cfg.Ticket.CacheWarmupEventIDs,
+			Window:   cfg.Ticket.CacheWarmupWindow,
+		},
 	})
 
+	// Deploys gate on the self-check of every dependency before taking traffic
+	if *smokeTest {
+		suite := newSmokeSuite(container, kafka.TopicManagerConfig{
+			Brokers:  cfg.Kafka.Brokers,
+			ClientID: "ticket-service-smoke",
+		})
+		if err := suite.Run(ctx, os.Stdout); err != nil {
+			appLog.Fatal(fmt.Sprintf("Smoke test failed: %v", err))
+		}
+		return
+	}
+
+	// Warm caches before taking traffic so a deploy doesn't send on-sale reads straight to Postgres
+	if cfg.Ticket.CacheWarmupEnabled && redisClient != nil {
+		warmCtx, cancelWarm := context.WithTimeout(ctx, cfg.Ticket.CacheWarmupTimeout)
+		result, err := container.CacheWarmer.WarmUp(warmCtx, nil)
+		cancelWarm()
+		if err != nil {
+			appLog.Warn(fmt.Sprintf("Cache warm-up failed: %v", err))
+		} else {
+			appLog.Info(fmt.Sprintf("Cache warm-up done in %dms (events=%d, shows=%d, zones=%d, zones_seeded=%d, errors=%d)",
+				result.DurationMs, result.Events, result.Shows, result.Zones, result.ZonesSeeded, len(result.Errors)))
+			for _, e := range result.Errors {
+				appLog.Warn(fmt.Sprintf("Cache warm-up: %s", e))
+			}
+		}
+	}
+
 	// Setup Gin
 	if cfg.IsDevelopment() {
 		gin.SetMode(gin.DebugMode)
@@ -146,8 +231,20 @@ func main() {
 			"/health",
 			"/ready",
 		},
+		TenantIsolation: &middleware.TenantIsolation{
+			Issuer:        cfg.JWT.Issuer,
+			RequireClaims: cfg.JWT.RequireTenantClaims,
+		},
 	}
 
+	// Requests from the API gateway carry signed identity headers; direct callers send a JWT
+	authMiddleware := middleware.InternalAuthMiddleware(&middleware.InternalAuthConfig{
+		Secret:       cfg.InternalAuth.Secret,
+		MaxClockSkew: cfg.InternalAuth.MaxClockSkew,
+		JWT:          jwtConfig,
+		SkipPaths:    jwtConfig.SkipPaths,
+	})
+
 	// API routes
 	v1 := router.Group("/api/v1")
 	{
@@ -159,9 +256,10 @@ func main() {
 			events.GET("/slug/:slug", container.EventHandler.GetBySlug)
 			events.GET("/slug/:slug/shows", container.ShowHandler.ListByEvent)
 
-			// Protected endpoints (Organizer/Admin only)
+			// Protected endpoints (Organizer/Admin only; update/delete/publish also
+			// check event ownership or tenant team permissions)
 			protected := events.Group("")
-			protected.Use(middleware.JWTMiddleware(jwtConfig))
+			protected.Use(authMiddleware)
 			protected.Use(middleware.RequireRole("admin", "organizer"))
 			{
 				protected.GET("/my", container.EventHandler.ListMyEvents)
@@ -170,8 +268,41 @@ func main() {
 				protected.DELETE("/:id", container.EventHandler.Delete)
 				protected.POST("/:id/publish", container.EventHandler.Publish)
 				protected.POST("/:id/shows", container.ShowHandler.Create)
+
+				// Succeeds if the caller may edit the event (checked by booking-service)
+				protected.GET("/:id/access", container.EventHandler.CheckAccess)
+
+				// Cloning and templates (clone and saving a template require events:edit on
+				// the source event; templates are shared within the tenant)
+				protected.POST("/:id/clone", container.TemplateHandler.Clone)
+				protected.GET("/templates", container.TemplateHandler.List)
+				protected.POST("/templates", container.TemplateHandler.Create)
+				protected.GET("/templates/:template_id", container.TemplateHandler.Get)
+				protected.DELETE("/templates/:template_id", container.TemplateHandler.Delete)
+				protected.POST("/templates/:template_id/instantiate", container.TemplateHandler.Instantiate)
+
+				// Presale access codes (requires events:edit on the event)
+				protected.POST("/:id/presale/batches", container.PresaleHandler.CreateBatch)
+				protected.GET("/:id/presale/batches", container.PresaleHandler.ListBatches)
+				protected.GET("/:id/presale/batches/:batch_id/codes", container.PresaleHandler.ListCodes)
+
+				// Add-on items sold with the event's tickets (requires events:edit on the event)
+				protected.POST("/:id/add-ons", container.AddOnHandler.Create)
+				protected.PUT("/:id/add-ons/:add_on_id", container.AddOnHandler.Update)
+				protected.DELETE("/:id/add-ons/:add_on_id", container.AddOnHandler.Delete)
+			}
+
+			// Presale code check/redemption for any signed-in user (called by booking
+			// service with redeem=true on queue join or reserve)
+			authenticated := events.Group("")
+			authenticated.Use(authMiddleware)
+			{
+				authenticated.POST("/:id/presale/validate", container.PresaleHandler.Validate)
 			}
 
+			// Add-ons sold with the event's tickets, read by booking-service
+			events.GET("/:id/add-ons", container.AddOnHandler.List)
+
 			// RESTful: GET /events/:id returns event by UUID
 			events.GET("/:id", container.EventHandler.GetByID)
 		}
@@ -183,9 +314,10 @@ func main() {
 			shows.GET("/:id", container.ShowHandler.GetByID)
 			shows.GET("/:id/zones", container.ShowZoneHandler.ListByShow)
 
-			// Protected endpoints (Organizer/Admin only)
+			// Protected endpoints (Organizer/Admin only; also check ownership of or tenant
+			// team permissions on the show's event)
 			protectedShows := shows.Group("")
-			protectedShows.Use(middleware.JWTMiddleware(jwtConfig))
+			protectedShows.Use(authMiddleware)
 			protectedShows.Use(middleware.RequireRole("admin", "organizer"))
 			{
 				protectedShows.PUT("/:id", container.ShowHandler.Update)
@@ -200,17 +332,43 @@ func main() {
 			// Public endpoints (note: /active must come before /:id to avoid route conflict)
 			zones.GET("/active", container.ShowZoneHandler.ListActive)
 			zones.GET("/:id", container.ShowZoneHandler.GetByID)
+			// Price schedule booking-service prices reservations from
+			zones.GET("/:id/price-tiers", container.PriceTierHandler.List)
 
-			// Protected endpoints (Organizer/Admin only)
+			// Protected endpoints (Organizer/Admin only; also check ownership of or tenant
+			// team permissions on the zone's event)
 			protectedZones := zones.Group("")
-			protectedZones.Use(middleware.JWTMiddleware(jwtConfig))
+			protectedZones.Use(authMiddleware)
 			protectedZones.Use(middleware.RequireRole("admin", "organizer"))
 			{
 				protectedZones.PUT("/:id", container.ShowZoneHandler.Update)
 				protectedZones.DELETE("/:id", container.ShowZoneHandler.Delete)
+				protectedZones.GET("/:id/capacity-status", container.ShowZoneHandler.GetCapacityStatus)
+				protectedZones.GET("/:id/access", container.ShowZoneHandler.CheckAccess)
+				protectedZones.POST("/:id/price-tiers", container.PriceTierHandler.Create)
+				protectedZones.PUT("/:id/price-tiers/:tier_id", container.PriceTierHandler.Update)
+				protectedZones.DELETE("/:id/price-tiers/:tier_id", container.PriceTierHandler.Delete)
 			}
 		}
 
+		// Tenant team members who co-manage the tenant's events (tenant from JWT)
+		team := v1.Group("/team")
+		team.Use(authMiddleware)
+		team.Use(middleware.RequireRole("admin", "organizer"))
+		{
+			team.GET("/members", container.TeamHandler.List)
+			team.PUT("/members/:user_id", container.TeamHandler.Set)
+			team.DELETE("/members/:user_id", container.TeamHandler.Remove)
+		}
+
+		// Admin endpoints
+		admin := v1.Group("/admin")
+		admin.Use(authMiddleware)
+		admin.Use(middleware.RequireRole("admin"))
+		{
+			admin.POST("/cache/warm-up", container.CacheWarmupHandler.WarmUp)
+		}
+
 		// Tickets endpoints (to be implemented)
 		_ = v1.Group("/tickets")
 		// {
@@ -222,15 +380,35 @@ func main() {
 		// 	tickets.POST("/availability", container.TicketHandler.CheckAvailability)
 		// }
 
-		// Venues endpoints (to be implemented)
-		_ = v1.Group("/venues")
-		// {
-		// 	venues.GET("", container.VenueHandler.List)
-		// 	venues.GET("/:id", container.VenueHandler.Get)
-		// 	venues.POST("", container.VenueHandler.Create)
-		// 	venues.PUT("/:id", container.VenueHandler.Update)
-		// 	venues.DELETE("/:id", container.VenueHandler.Delete)
-		// }
+		// Venues endpoints - public seat map, tenant-managed venues, zones and layouts
+		venues := v1.Group("/venues")
+		{
+			// Public endpoint: the active layout rendered for the seat-selection UI
+			venues.GET("/:id/layout", container.VenueHandler.GetRenderedLayout)
+
+			// Protected endpoints (Organizer/Admin only; venues are managed by their tenant)
+			protectedVenues := venues.Group("")
+			protectedVenues.Use(authMiddleware)
+			protectedVenues.Use(middleware.RequireRole("admin", "organizer"))
+			{
+				protectedVenues.GET("", container.VenueHandler.List)
+				protectedVenues.GET("/:id", container.VenueHandler.Get)
+				protectedVenues.POST("", container.VenueHandler.Create)
+				protectedVenues.PUT("/:id", container.VenueHandler.Update)
+				protectedVenues.DELETE("/:id", container.VenueHandler.Delete)
+
+				protectedVenues.GET("/:id/zones", container.VenueHandler.ListZones)
+				protectedVenues.POST("/:id/zones", container.VenueHandler.CreateZone)
+				protectedVenues.PUT("/:id/zones/:zone_id", container.VenueHandler.UpdateZone)
+				protectedVenues.DELETE("/:id/zones/:zone_id", container.VenueHandler.DeleteZone)
+
+				// Uploading adds a layout version, active unless "activate": false
+				protectedVenues.GET("/:id/layouts", container.VenueHandler.ListLayouts)
+				protectedVenues.POST("/:id/layouts", container.VenueHandler.UploadLayout)
+				protectedVenues.GET("/:id/layouts/:version", container.VenueHandler.GetLayout)
+				protectedVenues.POST("/:id/layouts/:version/activate", container.VenueHandler.ActivateLayout)
+			}
+		}
 	}
 
 	// Create HTTP server
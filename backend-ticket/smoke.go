@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/di"
+	"github.com/prohmpiriya/booking-rush-10k-rps/backend-ticket/internal/repository"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/smoke"
+)
+
+// schemaVersion is the latest migration in scripts/migrations/ticket. -smoke fails
+// until the ticket database is migrated to it.
+const schemaVersion = 13
+
+// newSmokeSuite builds the self-check run by -smoke. Redis is optional while the
+// service runs (caching is disabled without it) but required to pass the check.
+// Repositories return nil for the probe ID, so any error fails the round trip.
+func newSmokeSuite(container *di.Container, kafkaCfg kafka.TopicManagerConfig) *smoke.Suite {
+	suite := smoke.NewSuite("ticket-service", 0)
+
+	suite.Add("postgres", smoke.Postgres(container.DB))
+	suite.Add("migrations", smoke.Migrations(container.DB, schemaVersion))
+	if container.Redis == nil {
+		suite.Add("redis", func(ctx context.Context) error {
+			return errors.New("not connected, caching and presale redemption would be disabled")
+		})
+	} else {
+		suite.Add("redis", smoke.Redis(container.Redis))
+		suite.Add("redis scripts", repository.NewRedisPresaleRedemptionRepository(container.Redis).LoadScripts)
+	}
+	suite.Add("kafka topics", smoke.KafkaTopics(kafkaCfg, topicSpecs))
+
+	// One read per repository; the probe ID matches nothing
+	suite.Add("events repository", func(ctx context.Context) error {
+		_, err := container.EventRepo.GetByID(ctx, smoke.ProbeID)
+		return err
+	})
+	suite.Add("venues repository", func(ctx context.Context) error {
+		_, err := container.VenueRepo.GetByID(ctx, smoke.ProbeID)
+		return err
+	})
+	suite.Add("zones repository", func(ctx context.Context) error {
+		_, err := container.ZoneRepo.GetByID(ctx, smoke.ProbeID)
+		return err
+	})
+	suite.Add("venue layouts repository", func(ctx context.Context) error {
+		_, err := container.LayoutRepo.GetVersion(ctx, smoke.ProbeID, 1)
+		return err
+	})
+	suite.Add("shows repository", func(ctx context.Context) error {
+		_, err := container.ShowRepo.GetByID(ctx, smoke.ProbeID)
+		return err
+	})
+	suite.Add("show zones repository", func(ctx context.Context) error {
+		_, err := container.ShowZoneRepo.GetByID(ctx, smoke.ProbeID)
+		return err
+	})
+	suite.Add("team members repository", func(ctx context.Context) error {
+		_, err := container.TeamRepo.Get(ctx, smoke.ProbeID, smoke.ProbeID)
+		return err
+	})
+	suite.Add("presales repository", func(ctx context.Context) error {
+		_, err := container.PresaleRepo.GetBatch(ctx, smoke.ProbeID, smoke.ProbeID)
+		return err
+	})
+	suite.Add("event templates repository", func(ctx context.Context) error {
+		_, err := container.TemplateRepo.GetByID(ctx, smoke.ProbeID)
+		return err
+	})
+	suite.Add("price tiers repository", func(ctx context.Context) error {
+		_, err := container.PriceTierRepo.GetByID(ctx, smoke.ProbeID, smoke.ProbeID)
+		return err
+	})
+	suite.Add("add-ons repository", func(ctx context.Context) error {
+		_, err := container.AddOnRepo.GetByID(ctx, smoke.ProbeID, smoke.ProbeID)
+		return err
+	})
+	if container.RedemptionRepo != nil {
+		suite.Add("presale redemptions repository", func(ctx context.Context) error {
+			_, _, err := container.RedemptionRepo.Status(ctx, smoke.ProbeID, smoke.ProbeID)
+			return err
+		})
+	}
+	return suite
+}
@@ -0,0 +1,270 @@
+// Package cache provides a two-tier read-through cache: a process-local LRU with a
+// short TTL in front of a shared Redis tier. Writers invalidate keys over Redis
+// pub/sub so every instance drops its local copy; the local TTL bounds staleness
+// when an invalidation is missed.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+)
+
+// Defaults for Config
+const (
+	DefaultLocalSize = 10000
+	DefaultLocalTTL  = 5 * time.Second
+	DefaultRedisTTL  = 5 * time.Minute
+)
+
+// resubscribeDelay is how long Run waits before resubscribing to invalidations
+const resubscribeDelay = time.Second
+
+// Loader loads the value of a key from its origin on a miss in both tiers
+type Loader[V any] func(ctx context.Context, key string) (V, error)
+
+// Config contains configuration for a cache
+type Config struct {
+	// Name namespaces the cache: values are stored under cache:<name>:<key> and
+	// invalidations are published on cache:invalidate:<name>
+	Name string
+	// Redis is the shared tier (nil = process-local only)
+	Redis *redis.Client
+	// LocalSize caps the entries held in process (default 10000)
+	LocalSize int
+	// LocalTTL bounds how long a value stays in process (default 5 seconds)
+	LocalTTL time.Duration
+	// RedisTTL bounds how long a value stays in Redis (default 5 minutes)
+	RedisTTL time.Duration
+}
+
+// Stats counts where lookups were answered from
+type Stats struct {
+	LocalHits  int64 `json:"local_hits"`
+	RedisHits  int64 `json:"redis_hits"`
+	Loads      int64 `json:"loads"`
+	LoadErrors int64 `json:"load_errors"`
+}
+
+// call is a load in flight, shared by concurrent lookups of the same key
+type call[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// Cache reads values from the local LRU, then Redis, then the loader. Concurrent
+// misses on the same key share one load. Values are JSON encoded in Redis.
+type Cache[V any] struct {
+	name     string
+	redis    *redis.Client
+	redisTTL time.Duration
+	local    *LRU[V]
+	load     Loader[V]
+
+	mu       sync.Mutex
+	inflight map[string]*call[V]
+
+	// invalidations counts invalidations received; a load that overlaps one does
+	// not cache its result, which may predate the change
+	invalidations atomic.Uint64
+
+	localHits  atomic.Int64
+	redisHits  atomic.Int64
+	loads      atomic.Int64
+	loadErrors atomic.Int64
+}
+
+// New creates a new cache loading misses with load. Call Run to receive
+// invalidations published by other instances.
+func New[V any](cfg *Config, load Loader[V]) *Cache[V] {
+	c := &Cache[V]{
+		name:     cfg.Name,
+		redis:    cfg.Redis,
+		redisTTL: cfg.RedisTTL,
+		local:    NewLRU[V](cfg.LocalSize, cfg.LocalTTL),
+		load:     load,
+		inflight: make(map[string]*call[V]),
+	}
+	if c.redisTTL <= 0 {
+		c.redisTTL = DefaultRedisTTL
+	}
+	return c
+}
+
+// Get returns the value of key
+func (c *Cache[V]) Get(ctx context.Context, key string) (V, error) {
+	if value, ok := c.local.Get(key); ok {
+		c.localHits.Add(1)
+		return value, nil
+	}
+
+	c.mu.Lock()
+	if inflight, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		select {
+		case <-inflight.done:
+			return inflight.value, inflight.err
+		case <-ctx.Done():
+			var zero V
+			return zero, ctx.Err()
+		}
+	}
+	inflight := &call[V]{done: make(chan struct{})}
+	c.inflight[key] = inflight
+	c.mu.Unlock()
+
+	inflight.value, inflight.err = c.fetch(ctx, key)
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	c.mu.Unlock()
+	close(inflight.done)
+
+	return inflight.value, inflight.err
+}
+
+// Invalidate drops keys from this instance and Redis, and tells the other instances to drop them
+func (c *Cache[V]) Invalidate(ctx context.Context, keys ...string) error {
+	c.drop(keys)
+	return Invalidate(ctx, c.redis, c.name, keys...)
+}
+
+// Stats returns the lookup counters since the cache was created
+func (c *Cache[V]) Stats() Stats {
+	return Stats{
+		LocalHits:  c.localHits.Load(),
+		RedisHits:  c.redisHits.Load(),
+		Loads:      c.loads.Load(),
+		LoadErrors: c.loadErrors.Load(),
+	}
+}
+
+// Run receives invalidations until ctx is done, resubscribing if the subscription
+// drops. Local entries are purged on resubscription since invalidations may have
+// been missed in between. Returns at once for a process-local cache.
+func (c *Cache[V]) Run(ctx context.Context) {
+	if c.redis == nil {
+		return
+	}
+
+	channel := invalidationChannel(c.name)
+	for {
+		pubsub := c.redis.Subscribe(ctx, channel)
+		messages := pubsub.Channel()
+	receive:
+		for {
+			select {
+			case <-ctx.Done():
+				pubsub.Close()
+				return
+			case msg, ok := <-messages:
+				if !ok {
+					break receive
+				}
+				var keys []string
+				if err := json.Unmarshal([]byte(msg.Payload), &keys); err != nil {
+					continue
+				}
+				c.drop(keys)
+			}
+		}
+		pubsub.Close()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(resubscribeDelay):
+			logger.Get().Warn(fmt.Sprintf("Cache %s: subscription to %s ended, resubscribing", c.name, channel))
+			c.invalidations.Add(1)
+			c.local.Purge()
+		}
+	}
+}
+
+// fetch reads key from Redis, then the loader, and fills the tiers it missed.
+// Redis is only a cache; any miss or error falls through to the loader.
+func (c *Cache[V]) fetch(ctx context.Context, key string) (V, error) {
+	generation := c.invalidations.Load()
+
+	if c.redis != nil {
+		if data, err := c.redis.Get(ctx, valueKey(c.name, key)).Bytes(); err == nil {
+			var value V
+			if err := json.Unmarshal(data, &value); err == nil {
+				c.redisHits.Add(1)
+				if c.invalidations.Load() == generation {
+					c.local.Set(key, value)
+				}
+				return value, nil
+			}
+		}
+	}
+
+	c.loads.Add(1)
+	value, err := c.load(ctx, key)
+	if err != nil {
+		c.loadErrors.Add(1)
+		return value, err
+	}
+
+	if c.invalidations.Load() != generation {
+		return value, nil
+	}
+	c.local.Set(key, value)
+	if c.redis != nil {
+		// A Redis failure only costs a later load
+		if data, err := json.Marshal(value); err == nil {
+			c.redis.Set(ctx, valueKey(c.name, key), data, c.redisTTL)
+		}
+	}
+	return value, nil
+}
+
+// drop removes keys from the local tier
+func (c *Cache[V]) drop(keys []string) {
+	c.invalidations.Add(1)
+	for _, key := range keys {
+		c.local.Delete(key)
+	}
+}
+
+// Invalidate drops keys of the named cache from Redis and tells every instance
+// running the cache to drop its local copy. For writers that never read the cache.
+func Invalidate(ctx context.Context, client *redis.Client, name string, keys ...string) error {
+	if client == nil || len(keys) == 0 {
+		return nil
+	}
+
+	redisKeys := make([]string, len(keys))
+	for i, key := range keys {
+		redisKeys[i] = valueKey(name, key)
+	}
+	if err := client.Del(ctx, redisKeys...).Err(); err != nil {
+		return fmt.Errorf("failed to delete cached %s values: %w", name, err)
+	}
+
+	payload, err := json.Marshal(keys)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s invalidation: %w", name, err)
+	}
+	if err := client.Publish(ctx, invalidationChannel(name), payload).Err(); err != nil {
+		return fmt.Errorf("failed to publish %s invalidation: %w", name, err)
+	}
+	return nil
+}
+
+// valueKey returns the Redis key of a cached value
+func valueKey(name, key string) string {
+	return fmt.Sprintf("cache:%s:%s", name, key)
+}
+
+// invalidationChannel returns the pub/sub channel of a cache's invalidations
+func invalidationChannel(name string) string {
+	return fmt.Sprintf("cache:invalidate:%s", name)
+}
@@ -0,0 +1,207 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+)
+
+type zone struct {
+	ID    string  `json:"id"`
+	Price float64 `json:"price"`
+}
+
+func TestCache_Get_CachesLocally(t *testing.T) {
+	var loads int32
+	c := New(&Config{Name: "zone"}, func(ctx context.Context, key string) (*zone, error) {
+		atomic.AddInt32(&loads, 1)
+		return &zone{ID: key, Price: 1500}, nil
+	})
+
+	for i := 0; i < 3; i++ {
+		value, err := c.Get(context.Background(), "zone-1")
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if value.ID != "zone-1" || value.Price != 1500 {
+			t.Errorf("Get() = %+v, want zone-1 at 1500", value)
+		}
+	}
+	if loads != 1 {
+		t.Errorf("loads = %d, want 1", loads)
+	}
+	if stats := c.Stats(); stats.LocalHits != 2 || stats.Loads != 1 {
+		t.Errorf("Stats() = %+v, want 2 local hits and 1 load", stats)
+	}
+}
+
+func TestCache_Get_SharesConcurrentLoads(t *testing.T) {
+	var loads int32
+	release := make(chan struct{})
+	c := New(&Config{Name: "zone"}, func(ctx context.Context, key string) (int, error) {
+		atomic.AddInt32(&loads, 1)
+		<-release
+		return 42, nil
+	})
+
+	var wg sync.WaitGroup
+	results := make([]int, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = c.Get(context.Background(), "zone-1")
+		}(i)
+	}
+
+	// Let the goroutines pile up on the first load
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if loads != 1 {
+		t.Errorf("loads = %d, want 1", loads)
+	}
+	for i, result := range results {
+		if result != 42 {
+			t.Errorf("results[%d] = %d, want 42", i, result)
+		}
+	}
+}
+
+func TestCache_Get_LoadErrorNotCached(t *testing.T) {
+	var loads int32
+	c := New(&Config{Name: "zone"}, func(ctx context.Context, key string) (int, error) {
+		if atomic.AddInt32(&loads, 1) == 1 {
+			return 0, errors.New("ticket service down")
+		}
+		return 7, nil
+	})
+
+	if _, err := c.Get(context.Background(), "zone-1"); err == nil {
+		t.Fatal("Expected the load error")
+	}
+	value, err := c.Get(context.Background(), "zone-1")
+	if err != nil || value != 7 {
+		t.Errorf("Get() = %d, %v, want 7 after the origin recovers", value, err)
+	}
+	if stats := c.Stats(); stats.LoadErrors != 1 {
+		t.Errorf("LoadErrors = %d, want 1", stats.LoadErrors)
+	}
+}
+
+func TestCache_Invalidate(t *testing.T) {
+	price := 1000.0
+	c := New(&Config{Name: "zone"}, func(ctx context.Context, key string) (float64, error) {
+		return price, nil
+	})
+	ctx := context.Background()
+
+	if value, _ := c.Get(ctx, "zone-1"); value != 1000 {
+		t.Fatalf("Get() = %v, want 1000", value)
+	}
+
+	price = 1200
+	if value, _ := c.Get(ctx, "zone-1"); value != 1000 {
+		t.Errorf("Get() = %v, want the cached 1000 before invalidation", value)
+	}
+	if err := c.Invalidate(ctx, "zone-1"); err != nil {
+		t.Fatalf("Invalidate() error = %v", err)
+	}
+	if value, _ := c.Get(ctx, "zone-1"); value != 1200 {
+		t.Errorf("Get() = %v, want 1200 after invalidation", value)
+	}
+}
+
+func TestCache_InvalidateDuringLoad(t *testing.T) {
+	var loads int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	c := New(&Config{Name: "zone"}, func(ctx context.Context, key string) (int32, error) {
+		n := atomic.AddInt32(&loads, 1)
+		if n == 1 {
+			close(started)
+			<-release
+		}
+		return n, nil
+	})
+	ctx := context.Background()
+
+	done := make(chan int32)
+	go func() {
+		value, _ := c.Get(ctx, "zone-1")
+		done <- value
+	}()
+	<-started
+	c.Invalidate(ctx, "zone-1")
+	close(release)
+
+	if value := <-done; value != 1 {
+		t.Errorf("Get() = %d, want the in-flight load's result", value)
+	}
+	// The overlapping load may predate the change, so it was not cached
+	if value, _ := c.Get(ctx, "zone-1"); value != 2 {
+		t.Errorf("Get() = %d, want a fresh load", value)
+	}
+}
+
+func TestCache_Redis_Integration(t *testing.T) {
+	if os.Getenv("INTEGRATION_TEST") != "true" {
+		t.Skip("Skipping integration test. Set INTEGRATION_TEST=true to run")
+	}
+
+	cfg := redis.DefaultConfig()
+	if host := os.Getenv("TEST_REDIS_HOST"); host != "" {
+		cfg.Host = host
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client, err := redis.NewClient(ctx, cfg)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer client.Close()
+
+	name := fmt.Sprintf("test_zone_%d", time.Now().UnixNano())
+	var loads int32
+	load := func(ctx context.Context, key string) (*zone, error) {
+		atomic.AddInt32(&loads, 1)
+		return &zone{ID: key, Price: 900}, nil
+	}
+	first := New(&Config{Name: name, Redis: client, LocalTTL: time.Minute}, load)
+	second := New(&Config{Name: name, Redis: client, LocalTTL: time.Minute}, load)
+	go second.Run(ctx)
+	time.Sleep(100 * time.Millisecond) // Let the subscription start
+
+	// The second instance is answered from Redis
+	if _, err := first.Get(ctx, "zone-1"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if value, err := second.Get(ctx, "zone-1"); err != nil || value.Price != 900 {
+		t.Fatalf("Get() = %+v, %v, want 900", value, err)
+	}
+	if loads != 1 || second.Stats().RedisHits != 1 {
+		t.Errorf("loads = %d, redis hits = %d, want 1 and 1", loads, second.Stats().RedisHits)
+	}
+
+	// An invalidation from the first instance reaches the second
+	if err := first.Invalidate(ctx, "zone-1"); err != nil {
+		t.Fatalf("Invalidate() error = %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if _, err := second.Get(ctx, "zone-1"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if loads != 2 {
+		t.Errorf("loads = %d, want 2 after the invalidation", loads)
+	}
+	first.Invalidate(ctx, "zone-1")
+}
@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// lruEntry is a value of the LRU with its expiry
+type lruEntry[V any] struct {
+	key       string
+	value     V
+	expiresAt time.Time
+}
+
+// LRU is a size-bounded, process-local cache whose entries expire after a TTL.
+// The least recently used entry is evicted once the cache is full.
+type LRU[V any] struct {
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List // Front = most recently used
+	entries map[string]*list.Element
+}
+
+// NewLRU creates an LRU holding up to size entries for ttl each
+func NewLRU[V any](size int, ttl time.Duration) *LRU[V] {
+	if size <= 0 {
+		size = DefaultLocalSize
+	}
+	if ttl <= 0 {
+		ttl = DefaultLocalTTL
+	}
+	return &LRU[V]{
+		size:    size,
+		ttl:     ttl,
+		now:     time.Now,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Get returns the value of key if it is cached and not expired
+func (l *LRU[V]) Get(key string) (V, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var zero V
+	elem, ok := l.entries[key]
+	if !ok {
+		return zero, false
+	}
+	entry := elem.Value.(*lruEntry[V])
+	if !l.now().Before(entry.expiresAt) {
+		l.removeElement(elem)
+		return zero, false
+	}
+	l.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+// Set caches value under key for the LRU's TTL
+func (l *LRU[V]) Set(key string, value V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	expiresAt := l.now().Add(l.ttl)
+	if elem, ok := l.entries[key]; ok {
+		entry := elem.Value.(*lruEntry[V])
+		entry.value = value
+		entry.expiresAt = expiresAt
+		l.order.MoveToFront(elem)
+		return
+	}
+
+	l.entries[key] = l.order.PushFront(&lruEntry[V]{key: key, value: value, expiresAt: expiresAt})
+	for l.order.Len() > l.size {
+		l.removeElement(l.order.Back())
+	}
+}
+
+// Delete drops key from the LRU
+func (l *LRU[V]) Delete(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if elem, ok := l.entries[key]; ok {
+		l.removeElement(elem)
+	}
+}
+
+// Purge drops every entry
+func (l *LRU[V]) Purge() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.order.Init()
+	l.entries = make(map[string]*list.Element)
+}
+
+// Len returns the number of entries, including expired ones not evicted yet
+func (l *LRU[V]) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.order.Len()
+}
+
+func (l *LRU[V]) removeElement(elem *list.Element) {
+	l.order.Remove(elem)
+	delete(l.entries, elem.Value.(*lruEntry[V]).key)
+}
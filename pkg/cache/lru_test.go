@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	lru := NewLRU[int](2, time.Minute)
+	lru.Set("a", 1)
+	lru.Set("b", 2)
+
+	// Reading a makes b the least recently used
+	if _, ok := lru.Get("a"); !ok {
+		t.Fatal("Expected a to be cached")
+	}
+	lru.Set("c", 3)
+
+	if _, ok := lru.Get("b"); ok {
+		t.Error("Expected b to be evicted")
+	}
+	if value, ok := lru.Get("a"); !ok || value != 1 {
+		t.Errorf("Get(a) = %d, %v, want 1, true", value, ok)
+	}
+	if value, ok := lru.Get("c"); !ok || value != 3 {
+		t.Errorf("Get(c) = %d, %v, want 3, true", value, ok)
+	}
+	if lru.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", lru.Len())
+	}
+}
+
+func TestLRU_Expires(t *testing.T) {
+	now := time.Now()
+	lru := NewLRU[string](10, time.Second)
+	lru.now = func() time.Time { return now }
+
+	lru.Set("zone-1", "VIP")
+	if _, ok := lru.Get("zone-1"); !ok {
+		t.Fatal("Expected zone-1 to be cached")
+	}
+
+	now = now.Add(time.Second)
+	if _, ok := lru.Get("zone-1"); ok {
+		t.Error("Expected zone-1 to expire after the TTL")
+	}
+	if lru.Len() != 0 {
+		t.Errorf("Len() = %d, want the expired entry removed", lru.Len())
+	}
+}
+
+func TestLRU_SetRefreshes(t *testing.T) {
+	now := time.Now()
+	lru := NewLRU[int](10, time.Second)
+	lru.now = func() time.Time { return now }
+
+	lru.Set("a", 1)
+	now = now.Add(800 * time.Millisecond)
+	lru.Set("a", 2)
+	now = now.Add(800 * time.Millisecond)
+
+	if value, ok := lru.Get("a"); !ok || value != 2 {
+		t.Errorf("Get(a) = %d, %v, want 2, true", value, ok)
+	}
+}
+
+func TestLRU_DeleteAndPurge(t *testing.T) {
+	lru := NewLRU[int](10, time.Minute)
+	lru.Set("a", 1)
+	lru.Set("b", 2)
+
+	lru.Delete("a")
+	if _, ok := lru.Get("a"); ok {
+		t.Error("Expected a to be deleted")
+	}
+
+	lru.Purge()
+	if _, ok := lru.Get("b"); ok || lru.Len() != 0 {
+		t.Error("Expected Purge to drop every entry")
+	}
+}
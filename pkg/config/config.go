@@ -10,26 +10,183 @@ import (
 
 // Config holds all application configuration
 type Config struct {
-	App             AppConfig              `mapstructure:"app"`
-	Server          ServerConfig           `mapstructure:"server"`
-	AuthDatabase    DatabaseConfig         `mapstructure:"auth_database"`    // Auth service database (required for auth-service)
-	TicketDatabase  DatabaseConfig         `mapstructure:"ticket_database"`  // Ticket service database (required for ticket-service)
-	BookingDatabase DatabaseConfig         `mapstructure:"booking_database"` // Booking service database
-	PaymentDatabase DatabaseConfig         `mapstructure:"payment_database"` // Payment service database
-	Redis           RedisConfig            `mapstructure:"redis"`
-	Kafka           KafkaConfig            `mapstructure:"kafka"`
-	MongoDB         MongoDBConfig          `mapstructure:"mongodb"`
-	JWT             JWTConfig              `mapstructure:"jwt"`
-	OTel            OTelConfig             `mapstructure:"otel"`
-	Services        ServicesConfig         `mapstructure:"services"`
-	Booking         BookingServiceConfig   `mapstructure:"booking"` // Booking service specific config
+	App             AppConfig            `mapstructure:"app"`
+	Server          ServerConfig         `mapstructure:"server"`
+	AuthDatabase    DatabaseConfig       `mapstructure:"auth_database"`    // Auth service database (required for auth-service)
+	TicketDatabase  DatabaseConfig       `mapstructure:"ticket_database"`  // Ticket service database (required for ticket-service)
+	BookingDatabase DatabaseConfig       `mapstructure:"booking_database"` // Booking service database
+	PaymentDatabase DatabaseConfig       `mapstructure:"payment_database"` // Payment service database
+	Redis           RedisConfig          `mapstructure:"redis"`
+	Kafka           KafkaConfig          `mapstructure:"kafka"`
+	MongoDB         MongoDBConfig        `mapstructure:"mongodb"`
+	JWT             JWTConfig            `mapstructure:"jwt"`
+	InternalAuth    InternalAuthConfig   `mapstructure:"internal_auth"` // Gateway-signed identity headers
+	OTel            OTelConfig           `mapstructure:"otel"`
+	Log             LogConfig            `mapstructure:"log"`          // Log levels, per module and changeable at runtime
+	SLO             SLOConfig            `mapstructure:"slo"`          // Per-route latency objectives
+	RequestBody     RequestBodyConfig    `mapstructure:"request_body"` // Body size, content-type and JSON shape limits
+	LoadTest        LoadTestConfig       `mapstructure:"load_test"`    // Load-test orchestration endpoints (never in production)
+	Services        ServicesConfig       `mapstructure:"services"`
+	Booking         BookingServiceConfig `mapstructure:"booking"` // Booking service specific config
+	Ticket          TicketServiceConfig  `mapstructure:"ticket"`  // Ticket service specific config
 }
 
 // BookingServiceConfig holds booking service specific settings
 type BookingServiceConfig struct {
-	MaxTicketsPerUser     int  `mapstructure:"max_tickets_per_user"`    // Maximum tickets per user per event (0 = unlimited)
-	ReservationTTLMinutes int  `mapstructure:"reservation_ttl_minutes"` // Reservation TTL in minutes
-	RequireQueuePass      bool `mapstructure:"require_queue_pass"`      // Require queue pass for booking (virtual queue enforcement)
+	MaxTicketsPerUser     int                         `mapstructure:"max_tickets_per_user"`    // Maximum tickets per user per event (0 = unlimited)
+	ReservationTTLMinutes int                         `mapstructure:"reservation_ttl_minutes"` // Reservation TTL in minutes
+	RequireQueuePass      bool                        `mapstructure:"require_queue_pass"`      // Require queue pass for booking (virtual queue enforcement)
+	Chaos                 ChaosConfig                 `mapstructure:"chaos"`                   // Fault injection for load tests (never in production)
+	AsyncConfirm          AsyncConfirmConfig          `mapstructure:"async_confirm"`           // Queue confirmations and answer 202 with a status token
+	WriteBehind           WriteBehindConfig           `mapstructure:"write_behind"`            // Persist reservations after answering them
+	InsertBatch           InsertBatchConfig           `mapstructure:"insert_batch"`            // Insert reserved bookings in batches under burst load
+	Queue                 QueueBackendConfig          `mapstructure:"queue"`                   // Virtual queue storage backend
+	QueuePass             QueuePassConfig             `mapstructure:"queue_pass"`              // How queue passes are verified and revoked
+	FastPath              FastPathConfig              `mapstructure:"fast_path"`               // Minimal reserve listener for load-test comparisons
+	AvailabilityBroadcast AvailabilityBroadcastConfig `mapstructure:"availability_broadcast"`  // Push sold-out and low-stock flips to frontends
+	ZoneCache             ZoneCacheConfig             `mapstructure:"zone_cache"`              // Cache zone metadata and prices from ticket-service
+	PaymentGrace          PaymentGraceConfig          `mapstructure:"payment_grace"`           // Keep holds alive while their payment is processing
+	ReservationJournal    ReservationJournalConfig    `mapstructure:"reservation_journal"`     // Per-zone log of reservation changes for forensics
+
+	ReservationShadow ReservationShadowConfig `mapstructure:"reservation_shadow"` // Dual-write reservations to a second Redis during a migration
+	// Compare sampled availability reads against PostgreSQL
+	AvailabilityVerify AvailabilityVerifyConfig `mapstructure:"availability_verify"`
+}
+
+// Reservation shadow modes
+const (
+	ReservationShadowOff     = "off"     // Primary Redis only
+	ReservationShadowShadow  = "shadow"  // Primary serves, writes are mirrored to the shadow and compared
+	ReservationShadowCutover = "cutover" // Shadow serves, writes are mirrored back to the primary for rollback
+)
+
+// ReservationShadowConfig holds settings for mirroring reservation writes to a new Redis
+// cluster. The shadow must start as a copy of the primary; mirrored calls run in the
+// background and never fail a request.
+type ReservationShadowConfig struct {
+	Mode      string        `mapstructure:"mode"`       // off (default), shadow or cutover
+	Host      string        `mapstructure:"host"`       // Shadow Redis host
+	Port      int           `mapstructure:"port"`       // Shadow Redis port
+	Password  string        `mapstructure:"password"`   // Shadow Redis password
+	DB        int           `mapstructure:"db"`         // Shadow Redis database
+	Timeout   time.Duration `mapstructure:"timeout"`    // Upper bound on a mirrored call
+	Workers   int           `mapstructure:"workers"`    // Mirrored calls run in parallel, in order per booking
+	QueueSize int           `mapstructure:"queue_size"` // Mirrored calls waiting per worker before new ones are dropped
+}
+
+// Enabled reports whether reservation writes are mirrored
+func (c *ReservationShadowConfig) Enabled() bool {
+	return c.Mode == ReservationShadowShadow || c.Mode == ReservationShadowCutover
+}
+
+// FastPathConfig holds settings for the minimal net/http listener serving only reserve
+// and zone availability, to compare against the Gin router in load tests
+type FastPathConfig struct {
+	Enabled bool `mapstructure:"enabled"` // Start the listener next to the Gin server
+	Port    int  `mapstructure:"port"`    // Listener port (0 = SERVER_PORT + 2000)
+}
+
+// AvailabilityBroadcastConfig holds settings for zone availability threshold events,
+// published over Redis pub/sub when a reservation or release crosses a threshold
+type AvailabilityBroadcastConfig struct {
+	Enabled           bool  `mapstructure:"enabled"`             // Publish flips and serve the SSE stream
+	LowStockThreshold int64 `mapstructure:"low_stock_threshold"` // Seats left at or below which a zone is low on stock
+}
+
+// AvailabilityVerifyConfig holds settings for shadow read verification: a sample of zone
+// availability reads is compared in the background with seat_zones in PostgreSQL, so
+// counter drift shows up in metrics between syncs instead of at the next reconciliation
+type AvailabilityVerifyConfig struct {
+	SamplePercent float64       `mapstructure:"sample_percent"` // Percentage of availability reads verified (0 = disabled)
+	Workers       int           `mapstructure:"workers"`        // Verifications run in parallel
+	QueueSize     int           `mapstructure:"queue_size"`     // Sampled reads waiting before new ones are dropped
+	Timeout       time.Duration `mapstructure:"timeout"`        // Upper bound on a PostgreSQL read
+}
+
+// Enabled reports whether availability reads are verified
+func (c *AvailabilityVerifyConfig) Enabled() bool {
+	return c.SamplePercent > 0
+}
+
+// ZoneCacheConfig holds settings for the zone catalog cache: an in-process LRU in front
+// of Redis, invalidated over pub/sub when ticket-service updates a zone
+type ZoneCacheConfig struct {
+	LocalSize int           `mapstructure:"local_size"` // Zones and show zone lists held per instance
+	LocalTTL  time.Duration `mapstructure:"local_ttl"`  // How long an instance serves a zone without checking Redis
+	RedisTTL  time.Duration `mapstructure:"redis_ttl"`  // Upper bound on staleness when zone events are not delivered
+}
+
+// PaymentGraceConfig holds settings for extending holds whose payment is still processing,
+// so a delayed gateway webhook does not expire a booking the customer already paid for
+type PaymentGraceConfig struct {
+	Window  time.Duration `mapstructure:"window"`   // How far past now each extension pushes the hold
+	MaxHold time.Duration `mapstructure:"max_hold"` // Longest a hold can be kept after reserving
+	// How far past now a hold is pushed when the customer pays at the gateway (3-D Secure, bank transfer)
+	ExternalWindow time.Duration `mapstructure:"external_window"`
+}
+
+// ReservationJournalConfig holds settings for the reservation journal: a Redis Stream per
+// zone the reservation scripts append each reserve, confirm and release to, so counter
+// incidents can be reconstructed (cmd/reservation-journal)
+type ReservationJournalConfig struct {
+	Enabled   bool          `mapstructure:"enabled"`
+	Retention time.Duration `mapstructure:"retention"` // Entries older than this are trimmed
+}
+
+// QueuePassConfig holds settings for queue pass verification
+type QueuePassConfig struct {
+	Stateless         bool          `mapstructure:"stateless"`          // Verify passes by signature alone, without a Redis lookup per reserve
+	StatelessTTL      time.Duration `mapstructure:"stateless_ttl"`      // Upper bound on pass lifetime while stateless
+	RevocationTTL     time.Duration `mapstructure:"revocation_ttl"`     // How long an emergency revocation lasts
+	RevocationRefresh time.Duration `mapstructure:"revocation_refresh"` // How often instances reload revocations into their bloom filter
+}
+
+// QueueBackendConfig selects where the virtual queue order is stored
+type QueueBackendConfig struct {
+	Backend     string `mapstructure:"backend"`      // zset (Sorted Set, default) or streams (Redis Streams)
+	MigrateFrom string `mapstructure:"migrate_from"` // Previous backend still drained during an online migration
+}
+
+// AsyncConfirmConfig holds settings for asynchronous booking confirmation
+type AsyncConfirmConfig struct {
+	Enabled     bool          `mapstructure:"enabled"`      // Run confirm workers and accept async confirm requests
+	Default     bool          `mapstructure:"default"`      // Confirm asynchronously unless the client asks for ?async=false
+	Workers     int           `mapstructure:"workers"`      // Confirmations processed in parallel per instance
+	StatusTTL   time.Duration `mapstructure:"status_ttl"`   // How long confirm status stays queryable
+	MaxAttempts int           `mapstructure:"max_attempts"` // Attempts before an infrastructure failure fails the job
+}
+
+// WriteBehindConfig holds settings for persisting reservations off the reserve hot path
+type WriteBehindConfig struct {
+	Enabled        bool          `mapstructure:"enabled"`         // Queue reserved bookings in Redis instead of inserting them while reserving
+	Workers        int           `mapstructure:"workers"`         // Bookings persisted in parallel per instance
+	IdempotencyTTL time.Duration `mapstructure:"idempotency_ttl"` // How long retries are answered from the Redis idempotency index
+}
+
+// InsertBatchConfig holds settings for batching the inserts of reserved bookings
+type InsertBatchConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`   // Insert concurrent reservations with one multi-row statement
+	MaxSize  int           `mapstructure:"max_size"`  // Bookings inserted per statement
+	MaxDelay time.Duration `mapstructure:"max_delay"` // Longest a reservation waits for its batch to fill
+	Flushers int           `mapstructure:"flushers"`  // Batches inserted in parallel (each holds one pool connection)
+}
+
+// ChaosConfig holds fault injection settings for failure testing. Rates are probabilities in [0, 1].
+type ChaosConfig struct {
+	Enabled          bool          `mapstructure:"enabled"`
+	LuaFailureRate   float64       `mapstructure:"lua_failure_rate"`   // Fail reservation Lua scripts, before or after they run
+	RedisLatencyRate float64       `mapstructure:"redis_latency_rate"` // Delay reservation Redis calls
+	RedisLatency     time.Duration `mapstructure:"redis_latency"`      // Delay added to affected Redis calls
+	KafkaFailureRate float64       `mapstructure:"kafka_failure_rate"` // Fail Kafka publishes
+	Seed             int64         `mapstructure:"seed"`               // Random seed for reproducible runs (0 = time based)
+}
+
+// TicketServiceConfig holds ticket service specific settings
+type TicketServiceConfig struct {
+	CacheWarmupEnabled  bool          `mapstructure:"cache_warmup_enabled"`   // Warm event/show/zone caches on startup
+	CacheWarmupEventIDs []string      `mapstructure:"cache_warmup_event_ids"` // Events to warm; empty warms upcoming on-sales in the window
+	CacheWarmupWindow   time.Duration `mapstructure:"cache_warmup_window"`    // How far ahead to look for on-sales
+	CacheWarmupTimeout  time.Duration `mapstructure:"cache_warmup_timeout"`   // Upper bound on startup delay
 }
 
 // ServicesConfig holds URLs of other microservices
@@ -68,6 +225,12 @@ type DatabaseConfig struct {
 	MaxIdleConns    int           `mapstructure:"max_idle_conns"`
 	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
 	ConnMaxIdleTime time.Duration `mapstructure:"conn_max_idle_time"`
+
+	SlowQueryThreshold time.Duration `mapstructure:"slow_query_threshold"` // Log and count queries at least this slow (0 disables)
+
+	// Tenant shards besides this database, as name=host:port/dbname,... (booking and payment only)
+	Shards               string        `mapstructure:"shards"`
+	ShardRefreshInterval time.Duration `mapstructure:"shard_refresh_interval"` // Reload of tenant shard assignments
 }
 
 // DSN returns the PostgreSQL connection string
@@ -89,6 +252,12 @@ type RedisConfig struct {
 	DialTimeout  time.Duration `mapstructure:"dial_timeout"`
 	ReadTimeout  time.Duration `mapstructure:"read_timeout"`
 	WriteTimeout time.Duration `mapstructure:"write_timeout"`
+
+	SlowCommandThreshold time.Duration `mapstructure:"slow_command_threshold"` // Log and count commands at least this slow (0 disables)
+
+	MemoryAuditEnabled  bool          `mapstructure:"memory_audit_enabled"`  // Periodically sample memory use per key namespace
+	MemoryAuditInterval time.Duration `mapstructure:"memory_audit_interval"` // Time between memory audits
+	MemoryBudgets       string        `mapstructure:"memory_budgets"`        // "prefix=size[+ttl],..." (empty = the service's defaults)
 }
 
 // Addr returns the Redis address
@@ -101,6 +270,10 @@ type KafkaConfig struct {
 	Brokers       []string `mapstructure:"brokers"`
 	ConsumerGroup string   `mapstructure:"consumer_group"`
 	ClientID      string   `mapstructure:"client_id"`
+	// AutoCreateTopics creates missing topics at startup; existing topics are only checked for drift
+	AutoCreateTopics       bool `mapstructure:"auto_create_topics"`
+	TopicPartitions        int  `mapstructure:"topic_partitions"`         // Default partitions of provisioned topics
+	TopicReplicationFactor int  `mapstructure:"topic_replication_factor"` // Default replication factor of provisioned topics
 }
 
 // MongoDBConfig holds MongoDB connection settings
@@ -111,10 +284,19 @@ type MongoDBConfig struct {
 
 // JWTConfig holds JWT settings
 type JWTConfig struct {
-	Secret           string        `mapstructure:"secret"`
-	AccessTokenTTL   time.Duration `mapstructure:"access_token_ttl"`
-	RefreshTokenTTL  time.Duration `mapstructure:"refresh_token_ttl"`
-	Issuer           string        `mapstructure:"issuer"`
+	Secret          string        `mapstructure:"secret"`
+	AccessTokenTTL  time.Duration `mapstructure:"access_token_ttl"`
+	RefreshTokenTTL time.Duration `mapstructure:"refresh_token_ttl"`
+	Issuer          string        `mapstructure:"issuer"`
+	// RequireTenantClaims rejects access tokens without the per-tenant iss and aud claims
+	RequireTenantClaims bool `mapstructure:"require_tenant_claims"`
+}
+
+// InternalAuthConfig holds settings for identity headers signed by the API gateway
+type InternalAuthConfig struct {
+	Secret        string        `mapstructure:"secret"`         // HMAC key shared by the gateway and services (defaults to the JWT secret)
+	MaxClockSkew  time.Duration `mapstructure:"max_clock_skew"` // Oldest signature a service accepts
+	AllowUnsigned bool          `mapstructure:"allow_unsigned"` // Trust unsigned identity headers from callers bypassing the gateway
 }
 
 // OTelConfig holds OpenTelemetry settings
@@ -123,10 +305,47 @@ type OTelConfig struct {
 	ServiceName   string  `mapstructure:"service_name"`
 	CollectorAddr string  `mapstructure:"collector_addr"`
 	SampleRatio   float64 `mapstructure:"sample_ratio"`
+	// Dynamic sampling settings (replace SampleRatio for healthy, fast traces)
+	DynamicSampling bool          `mapstructure:"dynamic_sampling"`
+	SlowThreshold   time.Duration `mapstructure:"sampling_slow_threshold"` // Traces at least this slow are always kept
+	SamplingRoutes  string        `mapstructure:"sampling_routes"`         // Per-route ratios as "fragment=ratio,..."
 	// Log export settings
 	LogExportEnabled bool `mapstructure:"log_export_enabled"` // Enable OTLP log export (in addition to stdout)
 }
 
+// LogConfig holds log level settings
+type LogConfig struct {
+	Level        string        `mapstructure:"level"`         // Default level: debug, info, warn or error
+	ModuleLevels string        `mapstructure:"module_levels"` // Per-module overrides as "module=level,..."
+	PollInterval time.Duration `mapstructure:"poll_interval"` // Interval between reading overrides shared through Redis (0 disables)
+	RedisKey     string        `mapstructure:"redis_key"`     // Hash of module to level shared by all instances; the service name is appended
+}
+
+// SLOConfig holds per-route latency objective settings
+type SLOConfig struct {
+	Enabled       bool          `mapstructure:"enabled"`
+	Objectives    string        `mapstructure:"objectives"`     // "METHOD /path=p99:200ms,..." (empty = the service's defaults)
+	Window        time.Duration `mapstructure:"window"`         // Length of each evaluation window
+	BreachWindows int           `mapstructure:"breach_windows"` // Consecutive breached windows before warning
+	MinSamples    int64         `mapstructure:"min_samples"`    // Windows with fewer requests are not evaluated
+}
+
+// RequestBodyConfig holds request body limits enforced before handlers bind the body
+type RequestBodyConfig struct {
+	Enabled      bool   `mapstructure:"enabled"`
+	MaxBytes     int64  `mapstructure:"max_bytes"`      // Largest body on routes without their own limit
+	RouteLimits  string `mapstructure:"route_limits"`   // "METHOD /path=16KB,..." (empty = the service's defaults)
+	MaxJSONDepth int    `mapstructure:"max_json_depth"` // Deepest nesting of JSON objects and arrays
+}
+
+// LoadTestConfig holds settings for the load-test orchestration endpoints, which mint
+// synthetic user tokens and queue passes and reset event state between runs
+type LoadTestConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	MaxBatch int           `mapstructure:"max_batch"` // Most users or passes minted per request
+	TokenTTL time.Duration `mapstructure:"token_ttl"` // Lifetime of synthetic user tokens and passes
+}
+
 // Load loads configuration from environment variables and .env file
 func Load() (*Config, error) {
 	v := viper.New()
@@ -221,6 +440,7 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("AUTH_DATABASE_MAX_IDLE_CONNS", 10)
 	v.SetDefault("AUTH_DATABASE_CONN_MAX_LIFETIME", "1h")
 	v.SetDefault("AUTH_DATABASE_CONN_MAX_IDLE_TIME", "30m")
+	v.SetDefault("AUTH_DATABASE_SLOW_QUERY_THRESHOLD", "250ms")
 
 	// Ticket Database (ticket-service)
 	v.SetDefault("TICKET_DATABASE_HOST", "localhost")
@@ -233,6 +453,7 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("TICKET_DATABASE_MAX_IDLE_CONNS", 10)
 	v.SetDefault("TICKET_DATABASE_CONN_MAX_LIFETIME", "1h")
 	v.SetDefault("TICKET_DATABASE_CONN_MAX_IDLE_TIME", "30m")
+	v.SetDefault("TICKET_DATABASE_SLOW_QUERY_THRESHOLD", "250ms")
 
 	// Booking Database (booking-service)
 	v.SetDefault("BOOKING_DATABASE_HOST", "localhost")
@@ -245,6 +466,9 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("BOOKING_DATABASE_MAX_IDLE_CONNS", 10)
 	v.SetDefault("BOOKING_DATABASE_CONN_MAX_LIFETIME", "1h")
 	v.SetDefault("BOOKING_DATABASE_CONN_MAX_IDLE_TIME", "30m")
+	v.SetDefault("BOOKING_DATABASE_SLOW_QUERY_THRESHOLD", "250ms")
+	v.SetDefault("BOOKING_DATABASE_SHARDS", "")
+	v.SetDefault("BOOKING_DATABASE_SHARD_REFRESH_INTERVAL", "30s")
 
 	// Payment Database (payment-service)
 	v.SetDefault("PAYMENT_DATABASE_HOST", "localhost")
@@ -257,6 +481,9 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("PAYMENT_DATABASE_MAX_IDLE_CONNS", 10)
 	v.SetDefault("PAYMENT_DATABASE_CONN_MAX_LIFETIME", "1h")
 	v.SetDefault("PAYMENT_DATABASE_CONN_MAX_IDLE_TIME", "30m")
+	v.SetDefault("PAYMENT_DATABASE_SLOW_QUERY_THRESHOLD", "250ms")
+	v.SetDefault("PAYMENT_DATABASE_SHARDS", "")
+	v.SetDefault("PAYMENT_DATABASE_SHARD_REFRESH_INTERVAL", "30s")
 
 	// Redis defaults
 	v.SetDefault("REDIS_HOST", "localhost")
@@ -268,11 +495,18 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("REDIS_DIAL_TIMEOUT", "5s")
 	v.SetDefault("REDIS_READ_TIMEOUT", "3s")
 	v.SetDefault("REDIS_WRITE_TIMEOUT", "3s")
+	v.SetDefault("REDIS_SLOW_COMMAND_THRESHOLD", "50ms")
+	v.SetDefault("REDIS_MEMORY_AUDIT_ENABLED", true)
+	v.SetDefault("REDIS_MEMORY_AUDIT_INTERVAL", "5m")
+	v.SetDefault("REDIS_MEMORY_BUDGETS", "")
 
 	// Kafka defaults
 	v.SetDefault("KAFKA_BROKERS", "localhost:9092")
 	v.SetDefault("KAFKA_CONSUMER_GROUP", "booking-rush")
 	v.SetDefault("KAFKA_CLIENT_ID", "booking-rush")
+	v.SetDefault("KAFKA_AUTO_CREATE_TOPICS", false)
+	v.SetDefault("KAFKA_TOPIC_PARTITIONS", 6)
+	v.SetDefault("KAFKA_TOPIC_REPLICATION_FACTOR", 1)
 
 	// MongoDB defaults
 	v.SetDefault("MONGODB_URI", "mongodb://localhost:27017")
@@ -283,18 +517,107 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("JWT_ACCESS_TOKEN_TTL", "15m")
 	v.SetDefault("JWT_REFRESH_TOKEN_TTL", "168h") // 7 days
 	v.SetDefault("JWT_ISSUER", "booking-rush")
+	v.SetDefault("JWT_REQUIRE_TENANT_CLAIMS", false) // Enable once tokens minted before tenant claims have expired
+
+	// Internal auth defaults
+	v.SetDefault("INTERNAL_AUTH_SECRET", "")
+	v.SetDefault("INTERNAL_AUTH_MAX_CLOCK_SKEW", "30s")
+	v.SetDefault("INTERNAL_AUTH_ALLOW_UNSIGNED", true) // Load tests call booking-service directly
 
 	// OTel defaults
 	v.SetDefault("OTEL_ENABLED", true)
 	v.SetDefault("OTEL_SERVICE_NAME", "booking-rush")
 	v.SetDefault("OTEL_COLLECTOR_ADDR", "localhost:4317")
 	v.SetDefault("OTEL_SAMPLE_RATIO", 1.0)
+	v.SetDefault("OTEL_DYNAMIC_SAMPLING", false)
+	v.SetDefault("OTEL_SAMPLING_SLOW_THRESHOLD", "1s")
+	v.SetDefault("OTEL_SAMPLING_ROUTES", "/availability=0.01")
 	v.SetDefault("OTEL_LOG_EXPORT_ENABLED", false) // Disabled by default, enable to send logs to Loki via OTel
 
+	// Log level defaults
+	v.SetDefault("LOG_LEVEL", "info")
+	v.SetDefault("LOG_MODULE_LEVELS", "")
+	v.SetDefault("LOG_LEVELS_POLL_INTERVAL", "10s")
+	v.SetDefault("LOG_LEVELS_REDIS_KEY", "log:levels")
+
+	// SLO defaults
+	v.SetDefault("SLO_ENABLED", true)
+	v.SetDefault("SLO_OBJECTIVES", "")
+	v.SetDefault("SLO_WINDOW", "1m")
+	v.SetDefault("SLO_BREACH_WINDOWS", 3)
+	v.SetDefault("SLO_MIN_SAMPLES", 20)
+
+	// Request body defaults
+	v.SetDefault("REQUEST_BODY_ENABLED", true)
+	v.SetDefault("REQUEST_BODY_MAX_BYTES", "1MB")
+	v.SetDefault("REQUEST_BODY_ROUTE_LIMITS", "")
+	v.SetDefault("REQUEST_BODY_MAX_JSON_DEPTH", 32)
+
+	// Load test defaults
+	v.SetDefault("LOAD_TEST_ENABLED", false)
+	v.SetDefault("LOAD_TEST_MAX_BATCH", 1000)
+	v.SetDefault("LOAD_TEST_TOKEN_TTL", "1h")
+
 	// Booking service defaults
-	v.SetDefault("MAX_TICKETS_PER_USER", 10)        // Default 10 tickets per user per event
-	v.SetDefault("RESERVATION_TTL_MINUTES", 10)    // Default 10 minutes reservation TTL
-	v.SetDefault("REQUIRE_QUEUE_PASS", false)      // Default: don't require queue pass (for backward compatibility)
+	v.SetDefault("MAX_TICKETS_PER_USER", 10)    // Default 10 tickets per user per event
+	v.SetDefault("RESERVATION_TTL_MINUTES", 10) // Default 10 minutes reservation TTL
+	v.SetDefault("REQUIRE_QUEUE_PASS", false)   // Default: don't require queue pass (for backward compatibility)
+	v.SetDefault("CHAOS_ENABLED", false)
+	v.SetDefault("CHAOS_LUA_FAILURE_RATE", 0.0)
+	v.SetDefault("CHAOS_REDIS_LATENCY_RATE", 0.0)
+	v.SetDefault("CHAOS_REDIS_LATENCY", "200ms")
+	v.SetDefault("CHAOS_KAFKA_FAILURE_RATE", 0.0)
+	v.SetDefault("CHAOS_SEED", 0)
+	v.SetDefault("ASYNC_CONFIRM_ENABLED", false)
+	v.SetDefault("ASYNC_CONFIRM_DEFAULT", false)
+	v.SetDefault("ASYNC_CONFIRM_WORKERS", 8)
+	v.SetDefault("ASYNC_CONFIRM_STATUS_TTL", "1h")
+	v.SetDefault("ASYNC_CONFIRM_MAX_ATTEMPTS", 3)
+	v.SetDefault("WRITE_BEHIND_ENABLED", false)
+	v.SetDefault("WRITE_BEHIND_WORKERS", 4)
+	v.SetDefault("WRITE_BEHIND_IDEMPOTENCY_TTL", "24h")
+	v.SetDefault("BOOKING_INSERT_BATCH_ENABLED", false)
+	v.SetDefault("BOOKING_INSERT_BATCH_MAX_SIZE", 100)
+	v.SetDefault("BOOKING_INSERT_BATCH_MAX_DELAY", "2ms")
+	v.SetDefault("BOOKING_INSERT_BATCH_FLUSHERS", 4)
+	v.SetDefault("QUEUE_BACKEND", "zset")
+	v.SetDefault("QUEUE_BACKEND_MIGRATE_FROM", "")
+	v.SetDefault("QUEUE_PASS_STATELESS", false)
+	v.SetDefault("QUEUE_PASS_STATELESS_TTL", "2m")
+	v.SetDefault("QUEUE_PASS_REVOCATION_TTL", "1h")
+	v.SetDefault("QUEUE_PASS_REVOCATION_REFRESH", "2s")
+	v.SetDefault("FAST_PATH_ENABLED", false)
+	v.SetDefault("FAST_PATH_PORT", 0)
+	v.SetDefault("AVAILABILITY_BROADCAST_ENABLED", true)
+	v.SetDefault("AVAILABILITY_LOW_STOCK_THRESHOLD", 10)
+	v.SetDefault("ZONE_CACHE_LOCAL_SIZE", 10000)
+	v.SetDefault("ZONE_CACHE_LOCAL_TTL", "5s")
+	v.SetDefault("ZONE_CACHE_REDIS_TTL", "5m")
+	v.SetDefault("PAYMENT_GRACE_WINDOW", "10m")
+	v.SetDefault("PAYMENT_GRACE_MAX_HOLD", "1h")
+	v.SetDefault("PAYMENT_EXTERNAL_WINDOW", "30m")
+	v.SetDefault("RESERVATION_JOURNAL_ENABLED", false)
+	v.SetDefault("RESERVATION_JOURNAL_RETENTION", "24h")
+	v.SetDefault("RESERVATION_SHADOW_MODE", ReservationShadowOff)
+	v.SetDefault("RESERVATION_SHADOW_REDIS_HOST", "")
+	v.SetDefault("RESERVATION_SHADOW_REDIS_PORT", 6379)
+	v.SetDefault("RESERVATION_SHADOW_REDIS_PASSWORD", "")
+	v.SetDefault("RESERVATION_SHADOW_REDIS_DB", 0)
+	v.SetDefault("RESERVATION_SHADOW_TIMEOUT", "500ms")
+	v.SetDefault("RESERVATION_SHADOW_WORKERS", 16)
+	v.SetDefault("RESERVATION_SHADOW_QUEUE_SIZE", 1024)
+	v.SetDefault("AVAILABILITY_VERIFY_SAMPLE_PERCENT", 0.0)
+	v.SetDefault("AVAILABILITY_VERIFY_WORKERS", 2)
+	v.SetDefault("AVAILABILITY_VERIFY_QUEUE_SIZE", 256)
+	v.SetDefault("AVAILABILITY_VERIFY_TIMEOUT", "1s")
+	v.SetDefault("AUTH_SERVICE_URL", "")
+	v.SetDefault("PAYMENT_SERVICE_URL", "")
+
+	// Ticket service defaults
+	v.SetDefault("CACHE_WARMUP_ENABLED", true)
+	v.SetDefault("CACHE_WARMUP_EVENT_IDS", "")
+	v.SetDefault("CACHE_WARMUP_WINDOW", "24h")
+	v.SetDefault("CACHE_WARMUP_TIMEOUT", "30s")
 }
 
 func bindConfig(v *viper.Viper, cfg *Config) error {
@@ -326,6 +649,7 @@ func bindConfig(v *viper.Viper, cfg *Config) error {
 	cfg.AuthDatabase.MaxIdleConns = v.GetInt("AUTH_DATABASE_MAX_IDLE_CONNS")
 	cfg.AuthDatabase.ConnMaxLifetime = v.GetDuration("AUTH_DATABASE_CONN_MAX_LIFETIME")
 	cfg.AuthDatabase.ConnMaxIdleTime = v.GetDuration("AUTH_DATABASE_CONN_MAX_IDLE_TIME")
+	cfg.AuthDatabase.SlowQueryThreshold = v.GetDuration("AUTH_DATABASE_SLOW_QUERY_THRESHOLD")
 
 	// Ticket Database (ticket-service)
 	cfg.TicketDatabase.Host = v.GetString("TICKET_DATABASE_HOST")
@@ -338,6 +662,7 @@ func bindConfig(v *viper.Viper, cfg *Config) error {
 	cfg.TicketDatabase.MaxIdleConns = v.GetInt("TICKET_DATABASE_MAX_IDLE_CONNS")
 	cfg.TicketDatabase.ConnMaxLifetime = v.GetDuration("TICKET_DATABASE_CONN_MAX_LIFETIME")
 	cfg.TicketDatabase.ConnMaxIdleTime = v.GetDuration("TICKET_DATABASE_CONN_MAX_IDLE_TIME")
+	cfg.TicketDatabase.SlowQueryThreshold = v.GetDuration("TICKET_DATABASE_SLOW_QUERY_THRESHOLD")
 
 	// Booking Database (booking-service)
 	cfg.BookingDatabase.Host = v.GetString("BOOKING_DATABASE_HOST")
@@ -350,6 +675,9 @@ func bindConfig(v *viper.Viper, cfg *Config) error {
 	cfg.BookingDatabase.MaxIdleConns = v.GetInt("BOOKING_DATABASE_MAX_IDLE_CONNS")
 	cfg.BookingDatabase.ConnMaxLifetime = v.GetDuration("BOOKING_DATABASE_CONN_MAX_LIFETIME")
 	cfg.BookingDatabase.ConnMaxIdleTime = v.GetDuration("BOOKING_DATABASE_CONN_MAX_IDLE_TIME")
+	cfg.BookingDatabase.SlowQueryThreshold = v.GetDuration("BOOKING_DATABASE_SLOW_QUERY_THRESHOLD")
+	cfg.BookingDatabase.Shards = v.GetString("BOOKING_DATABASE_SHARDS")
+	cfg.BookingDatabase.ShardRefreshInterval = v.GetDuration("BOOKING_DATABASE_SHARD_REFRESH_INTERVAL")
 
 	// Payment Database (payment-service)
 	cfg.PaymentDatabase.Host = v.GetString("PAYMENT_DATABASE_HOST")
@@ -362,6 +690,9 @@ func bindConfig(v *viper.Viper, cfg *Config) error {
 	cfg.PaymentDatabase.MaxIdleConns = v.GetInt("PAYMENT_DATABASE_MAX_IDLE_CONNS")
 	cfg.PaymentDatabase.ConnMaxLifetime = v.GetDuration("PAYMENT_DATABASE_CONN_MAX_LIFETIME")
 	cfg.PaymentDatabase.ConnMaxIdleTime = v.GetDuration("PAYMENT_DATABASE_CONN_MAX_IDLE_TIME")
+	cfg.PaymentDatabase.SlowQueryThreshold = v.GetDuration("PAYMENT_DATABASE_SLOW_QUERY_THRESHOLD")
+	cfg.PaymentDatabase.Shards = v.GetString("PAYMENT_DATABASE_SHARDS")
+	cfg.PaymentDatabase.ShardRefreshInterval = v.GetDuration("PAYMENT_DATABASE_SHARD_REFRESH_INTERVAL")
 
 	// Redis
 	cfg.Redis.Host = v.GetString("REDIS_HOST")
@@ -373,12 +704,19 @@ func bindConfig(v *viper.Viper, cfg *Config) error {
 	cfg.Redis.DialTimeout = v.GetDuration("REDIS_DIAL_TIMEOUT")
 	cfg.Redis.ReadTimeout = v.GetDuration("REDIS_READ_TIMEOUT")
 	cfg.Redis.WriteTimeout = v.GetDuration("REDIS_WRITE_TIMEOUT")
+	cfg.Redis.SlowCommandThreshold = v.GetDuration("REDIS_SLOW_COMMAND_THRESHOLD")
+	cfg.Redis.MemoryAuditEnabled = v.GetBool("REDIS_MEMORY_AUDIT_ENABLED")
+	cfg.Redis.MemoryAuditInterval = v.GetDuration("REDIS_MEMORY_AUDIT_INTERVAL")
+	cfg.Redis.MemoryBudgets = v.GetString("REDIS_MEMORY_BUDGETS")
 
 	// Kafka
 	brokersStr := v.GetString("KAFKA_BROKERS")
 	cfg.Kafka.Brokers = strings.Split(brokersStr, ",")
 	cfg.Kafka.ConsumerGroup = v.GetString("KAFKA_CONSUMER_GROUP")
 	cfg.Kafka.ClientID = v.GetString("KAFKA_CLIENT_ID")
+	cfg.Kafka.AutoCreateTopics = v.GetBool("KAFKA_AUTO_CREATE_TOPICS")
+	cfg.Kafka.TopicPartitions = v.GetInt("KAFKA_TOPIC_PARTITIONS")
+	cfg.Kafka.TopicReplicationFactor = v.GetInt("KAFKA_TOPIC_REPLICATION_FACTOR")
 
 	// MongoDB
 	cfg.MongoDB.URI = v.GetString("MONGODB_URI")
@@ -389,22 +727,127 @@ func bindConfig(v *viper.Viper, cfg *Config) error {
 	cfg.JWT.AccessTokenTTL = v.GetDuration("JWT_ACCESS_TOKEN_TTL")
 	cfg.JWT.RefreshTokenTTL = v.GetDuration("JWT_REFRESH_TOKEN_TTL")
 	cfg.JWT.Issuer = v.GetString("JWT_ISSUER")
+	cfg.JWT.RequireTenantClaims = v.GetBool("JWT_REQUIRE_TENANT_CLAIMS")
+
+	// Internal auth
+	cfg.InternalAuth.Secret = v.GetString("INTERNAL_AUTH_SECRET")
+	if cfg.InternalAuth.Secret == "" {
+		cfg.InternalAuth.Secret = cfg.JWT.Secret
+	}
+	cfg.InternalAuth.MaxClockSkew = v.GetDuration("INTERNAL_AUTH_MAX_CLOCK_SKEW")
+	cfg.InternalAuth.AllowUnsigned = v.GetBool("INTERNAL_AUTH_ALLOW_UNSIGNED")
 
 	// OTel
 	cfg.OTel.Enabled = v.GetBool("OTEL_ENABLED")
 	cfg.OTel.ServiceName = v.GetString("OTEL_SERVICE_NAME")
 	cfg.OTel.CollectorAddr = v.GetString("OTEL_COLLECTOR_ADDR")
 	cfg.OTel.SampleRatio = v.GetFloat64("OTEL_SAMPLE_RATIO")
+	cfg.OTel.DynamicSampling = v.GetBool("OTEL_DYNAMIC_SAMPLING")
+	cfg.OTel.SlowThreshold = v.GetDuration("OTEL_SAMPLING_SLOW_THRESHOLD")
+	cfg.OTel.SamplingRoutes = v.GetString("OTEL_SAMPLING_ROUTES")
 	cfg.OTel.LogExportEnabled = v.GetBool("OTEL_LOG_EXPORT_ENABLED")
 
+	// Log levels
+	cfg.Log.Level = v.GetString("LOG_LEVEL")
+	cfg.Log.ModuleLevels = v.GetString("LOG_MODULE_LEVELS")
+	cfg.Log.PollInterval = v.GetDuration("LOG_LEVELS_POLL_INTERVAL")
+	cfg.Log.RedisKey = v.GetString("LOG_LEVELS_REDIS_KEY")
+
+	// SLO
+	cfg.SLO.Enabled = v.GetBool("SLO_ENABLED")
+	cfg.SLO.Objectives = v.GetString("SLO_OBJECTIVES")
+	cfg.SLO.Window = v.GetDuration("SLO_WINDOW")
+	cfg.SLO.BreachWindows = v.GetInt("SLO_BREACH_WINDOWS")
+	cfg.SLO.MinSamples = v.GetInt64("SLO_MIN_SAMPLES")
+
+	// Request body
+	cfg.RequestBody.Enabled = v.GetBool("REQUEST_BODY_ENABLED")
+	cfg.RequestBody.MaxBytes = int64(v.GetSizeInBytes("REQUEST_BODY_MAX_BYTES"))
+	cfg.RequestBody.RouteLimits = v.GetString("REQUEST_BODY_ROUTE_LIMITS")
+	cfg.RequestBody.MaxJSONDepth = v.GetInt("REQUEST_BODY_MAX_JSON_DEPTH")
+
+	// Load test
+	cfg.LoadTest.Enabled = v.GetBool("LOAD_TEST_ENABLED")
+	cfg.LoadTest.MaxBatch = v.GetInt("LOAD_TEST_MAX_BATCH")
+	cfg.LoadTest.TokenTTL = v.GetDuration("LOAD_TEST_TOKEN_TTL")
+
 	// Booking service config
 	cfg.Booking.MaxTicketsPerUser = v.GetInt("MAX_TICKETS_PER_USER")
 	cfg.Booking.ReservationTTLMinutes = v.GetInt("RESERVATION_TTL_MINUTES")
 	cfg.Booking.RequireQueuePass = v.GetBool("REQUIRE_QUEUE_PASS")
+	cfg.Booking.Chaos.Enabled = v.GetBool("CHAOS_ENABLED")
+	cfg.Booking.Chaos.LuaFailureRate = v.GetFloat64("CHAOS_LUA_FAILURE_RATE")
+	cfg.Booking.Chaos.RedisLatencyRate = v.GetFloat64("CHAOS_REDIS_LATENCY_RATE")
+	cfg.Booking.Chaos.RedisLatency = v.GetDuration("CHAOS_REDIS_LATENCY")
+	cfg.Booking.Chaos.KafkaFailureRate = v.GetFloat64("CHAOS_KAFKA_FAILURE_RATE")
+	cfg.Booking.Chaos.Seed = v.GetInt64("CHAOS_SEED")
+	cfg.Booking.AsyncConfirm.Enabled = v.GetBool("ASYNC_CONFIRM_ENABLED")
+	cfg.Booking.AsyncConfirm.Default = v.GetBool("ASYNC_CONFIRM_DEFAULT")
+	cfg.Booking.AsyncConfirm.Workers = v.GetInt("ASYNC_CONFIRM_WORKERS")
+	cfg.Booking.AsyncConfirm.StatusTTL = v.GetDuration("ASYNC_CONFIRM_STATUS_TTL")
+	cfg.Booking.AsyncConfirm.MaxAttempts = v.GetInt("ASYNC_CONFIRM_MAX_ATTEMPTS")
+	cfg.Booking.WriteBehind.Enabled = v.GetBool("WRITE_BEHIND_ENABLED")
+	cfg.Booking.WriteBehind.Workers = v.GetInt("WRITE_BEHIND_WORKERS")
+	cfg.Booking.WriteBehind.IdempotencyTTL = v.GetDuration("WRITE_BEHIND_IDEMPOTENCY_TTL")
+	cfg.Booking.InsertBatch.Enabled = v.GetBool("BOOKING_INSERT_BATCH_ENABLED")
+	cfg.Booking.InsertBatch.MaxSize = v.GetInt("BOOKING_INSERT_BATCH_MAX_SIZE")
+	cfg.Booking.InsertBatch.MaxDelay = v.GetDuration("BOOKING_INSERT_BATCH_MAX_DELAY")
+	cfg.Booking.InsertBatch.Flushers = v.GetInt("BOOKING_INSERT_BATCH_FLUSHERS")
+	cfg.Booking.Queue.Backend = v.GetString("QUEUE_BACKEND")
+	cfg.Booking.Queue.MigrateFrom = v.GetString("QUEUE_BACKEND_MIGRATE_FROM")
+	cfg.Booking.QueuePass.Stateless = v.GetBool("QUEUE_PASS_STATELESS")
+	cfg.Booking.QueuePass.StatelessTTL = v.GetDuration("QUEUE_PASS_STATELESS_TTL")
+	cfg.Booking.QueuePass.RevocationTTL = v.GetDuration("QUEUE_PASS_REVOCATION_TTL")
+	cfg.Booking.QueuePass.RevocationRefresh = v.GetDuration("QUEUE_PASS_REVOCATION_REFRESH")
+	cfg.Booking.FastPath.Enabled = v.GetBool("FAST_PATH_ENABLED")
+	cfg.Booking.FastPath.Port = v.GetInt("FAST_PATH_PORT")
+	cfg.Booking.AvailabilityBroadcast.Enabled = v.GetBool("AVAILABILITY_BROADCAST_ENABLED")
+	cfg.Booking.AvailabilityBroadcast.LowStockThreshold = v.GetInt64("AVAILABILITY_LOW_STOCK_THRESHOLD")
+	cfg.Booking.ZoneCache.LocalSize = v.GetInt("ZONE_CACHE_LOCAL_SIZE")
+	cfg.Booking.ZoneCache.LocalTTL = v.GetDuration("ZONE_CACHE_LOCAL_TTL")
+	cfg.Booking.ZoneCache.RedisTTL = v.GetDuration("ZONE_CACHE_REDIS_TTL")
+	cfg.Booking.PaymentGrace.Window = v.GetDuration("PAYMENT_GRACE_WINDOW")
+	cfg.Booking.PaymentGrace.MaxHold = v.GetDuration("PAYMENT_GRACE_MAX_HOLD")
+	cfg.Booking.PaymentGrace.ExternalWindow = v.GetDuration("PAYMENT_EXTERNAL_WINDOW")
+	cfg.Booking.ReservationJournal.Enabled = v.GetBool("RESERVATION_JOURNAL_ENABLED")
+	cfg.Booking.ReservationJournal.Retention = v.GetDuration("RESERVATION_JOURNAL_RETENTION")
+	cfg.Booking.ReservationShadow.Mode = v.GetString("RESERVATION_SHADOW_MODE")
+	cfg.Booking.ReservationShadow.Host = v.GetString("RESERVATION_SHADOW_REDIS_HOST")
+	cfg.Booking.ReservationShadow.Port = v.GetInt("RESERVATION_SHADOW_REDIS_PORT")
+	cfg.Booking.ReservationShadow.Password = v.GetString("RESERVATION_SHADOW_REDIS_PASSWORD")
+	cfg.Booking.ReservationShadow.DB = v.GetInt("RESERVATION_SHADOW_REDIS_DB")
+	cfg.Booking.ReservationShadow.Timeout = v.GetDuration("RESERVATION_SHADOW_TIMEOUT")
+	cfg.Booking.ReservationShadow.Workers = v.GetInt("RESERVATION_SHADOW_WORKERS")
+	cfg.Booking.ReservationShadow.QueueSize = v.GetInt("RESERVATION_SHADOW_QUEUE_SIZE")
+	cfg.Booking.AvailabilityVerify.SamplePercent = v.GetFloat64("AVAILABILITY_VERIFY_SAMPLE_PERCENT")
+	cfg.Booking.AvailabilityVerify.Workers = v.GetInt("AVAILABILITY_VERIFY_WORKERS")
+	cfg.Booking.AvailabilityVerify.QueueSize = v.GetInt("AVAILABILITY_VERIFY_QUEUE_SIZE")
+	cfg.Booking.AvailabilityVerify.Timeout = v.GetDuration("AVAILABILITY_VERIFY_TIMEOUT")
+
+	// Service URLs (booking search resolves emails and card numbers through these)
+	cfg.Services.AuthServiceURL = v.GetString("AUTH_SERVICE_URL")
+	cfg.Services.PaymentServiceURL = v.GetString("PAYMENT_SERVICE_URL")
+
+	// Ticket service config
+	cfg.Ticket.CacheWarmupEnabled = v.GetBool("CACHE_WARMUP_ENABLED")
+	cfg.Ticket.CacheWarmupEventIDs = splitList(v.GetString("CACHE_WARMUP_EVENT_IDS"))
+	cfg.Ticket.CacheWarmupWindow = v.GetDuration("CACHE_WARMUP_WINDOW")
+	cfg.Ticket.CacheWarmupTimeout = v.GetDuration("CACHE_WARMUP_TIMEOUT")
 
 	return nil
 }
 
+// splitList splits a comma separated value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // Validate validates the configuration
 func (c *Config) Validate() error {
 	if c.App.Name == "" {
@@ -424,6 +867,16 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("JWT secret must be changed in production")
 	}
 
+	// Chaos mode injects failures into live traffic and is for load-test environments only
+	if c.App.Environment == "production" && c.Booking.Chaos.Enabled {
+		return fmt.Errorf("CHAOS_ENABLED must not be set in production")
+	}
+
+	// Load-test endpoints mint valid user tokens without credentials
+	if c.App.Environment == "production" && c.LoadTest.Enabled {
+		return fmt.Errorf("LOAD_TEST_ENABLED must not be set in production")
+	}
+
 	return nil
 }
 
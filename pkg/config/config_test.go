@@ -95,6 +95,30 @@ func TestLoad_WithEnvOverride(t *testing.T) {
 	}
 }
 
+func TestLoad_TicketCacheWarmup(t *testing.T) {
+	os.Setenv("CACHE_WARMUP_EVENT_IDS", " event-1, ,event-2 ")
+	os.Setenv("CACHE_WARMUP_WINDOW", "6h")
+	defer func() {
+		os.Unsetenv("CACHE_WARMUP_EVENT_IDS")
+		os.Unsetenv("CACHE_WARMUP_WINDOW")
+	}()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	if !cfg.Ticket.CacheWarmupEnabled {
+		t.Error("Ticket.CacheWarmupEnabled = false, want true by default")
+	}
+	if len(cfg.Ticket.CacheWarmupEventIDs) != 2 || cfg.Ticket.CacheWarmupEventIDs[0] != "event-1" || cfg.Ticket.CacheWarmupEventIDs[1] != "event-2" {
+		t.Errorf("Ticket.CacheWarmupEventIDs = %v, want [event-1 event-2]", cfg.Ticket.CacheWarmupEventIDs)
+	}
+	if cfg.Ticket.CacheWarmupWindow.Hours() != 6 {
+		t.Errorf("Ticket.CacheWarmupWindow = %v, want 6h", cfg.Ticket.CacheWarmupWindow)
+	}
+}
+
 func TestDatabaseConfig_DSN(t *testing.T) {
 	cfg := DatabaseConfig{
 		Host:     "localhost",
@@ -183,6 +207,26 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "chaos mode in production",
+			cfg: Config{
+				App:     AppConfig{Name: "test", Environment: "production"},
+				Server:  ServerConfig{Port: 8080},
+				JWT:     JWTConfig{Secret: "secret"},
+				Booking: BookingServiceConfig{Chaos: ChaosConfig{Enabled: true}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "load test mode in production",
+			cfg: Config{
+				App:      AppConfig{Name: "test", Environment: "production"},
+				Server:   ServerConfig{Port: 8080},
+				JWT:      JWTConfig{Secret: "secret"},
+				LoadTest: LoadTestConfig{Enabled: true},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
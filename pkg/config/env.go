@@ -0,0 +1,102 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Env reads service specific settings that are not part of Config. Unset variables use
+// the default; malformed ones also use it but are recorded, so the service can refuse
+// to start with Err instead of silently running on a default.
+type Env struct {
+	problems []string
+	seen     map[string]bool
+}
+
+// NewEnv creates an environment reader
+func NewEnv() *Env {
+	return &Env{seen: make(map[string]bool)}
+}
+
+// String returns the variable or the default when it is unset
+func (e *Env) String(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// Int returns the variable as an int
+func (e *Env) Int(key string, defaultValue int) int {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return defaultValue
+	}
+	result, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		e.invalid(key, value, "an integer")
+		return defaultValue
+	}
+	return result
+}
+
+// Float returns the variable as a float64
+func (e *Env) Float(key string, defaultValue float64) float64 {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return defaultValue
+	}
+	result, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		e.invalid(key, value, "a number")
+		return defaultValue
+	}
+	return result
+}
+
+// Bool returns the variable as a bool (true/false, 1/0)
+func (e *Env) Bool(key string, defaultValue bool) bool {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return defaultValue
+	}
+	result, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		e.invalid(key, value, "true or false")
+		return defaultValue
+	}
+	return result
+}
+
+// Duration returns the variable as a time.Duration
+func (e *Env) Duration(key string, defaultValue time.Duration) time.Duration {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return defaultValue
+	}
+	result, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil {
+		e.invalid(key, value, "a duration such as 500ms or 5m")
+		return defaultValue
+	}
+	return result
+}
+
+func (e *Env) invalid(key, value, want string) {
+	if e.seen[key] {
+		return
+	}
+	e.seen[key] = true
+	e.problems = append(e.problems, fmt.Sprintf("%s must be %s, got %q", key, want, value))
+}
+
+// Err returns the malformed variables read so far, or nil
+func (e *Env) Err() error {
+	if len(e.problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: e.problems}
+}
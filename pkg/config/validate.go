@@ -0,0 +1,197 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Requirement is a group of settings a binary cannot start without
+type Requirement int
+
+const (
+	RequireAuthDatabase Requirement = iota
+	RequireTicketDatabase
+	RequireBookingDatabase
+	RequirePaymentDatabase
+	RequireRedis
+	RequireKafka
+	RequireJWT
+)
+
+// ValidationError lists every problem found by ValidateFor
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%d configuration problem(s):\n  - %s", len(e.Problems), strings.Join(e.Problems, "\n  - "))
+}
+
+// ValidateFor checks the general settings plus the settings behind each requirement
+// and reports all problems at once, so a misconfigured deployment is fixed in one go.
+// Services call it right after loading the configuration, before connecting to anything,
+// so missing or malformed settings stop startup instead of surfacing as connection errors.
+func (c *Config) ValidateFor(requirements ...Requirement) error {
+	var problems []string
+	if err := c.Validate(); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	for _, req := range requirements {
+		switch req {
+		case RequireAuthDatabase:
+			problems = append(problems, databaseProblems("AUTH_DATABASE", &c.AuthDatabase, c.ValidateAuthDatabase)...)
+		case RequireTicketDatabase:
+			problems = append(problems, databaseProblems("TICKET_DATABASE", &c.TicketDatabase, c.ValidateTicketDatabase)...)
+		case RequireBookingDatabase:
+			problems = append(problems, databaseProblems("BOOKING_DATABASE", &c.BookingDatabase, c.ValidateBookingDatabase)...)
+		case RequirePaymentDatabase:
+			problems = append(problems, databaseProblems("PAYMENT_DATABASE", &c.PaymentDatabase, c.ValidatePaymentDatabase)...)
+		case RequireRedis:
+			if c.Redis.Host == "" {
+				problems = append(problems, "REDIS_HOST is required")
+			}
+			if !validPort(c.Redis.Port) {
+				problems = append(problems, fmt.Sprintf("REDIS_PORT must be between 1 and 65535, got %d", c.Redis.Port))
+			}
+			if c.Redis.PoolSize <= 0 {
+				problems = append(problems, fmt.Sprintf("REDIS_POOL_SIZE must be positive, got %d", c.Redis.PoolSize))
+			}
+			problems = append(problems, c.Booking.ReservationShadow.problems()...)
+		case RequireKafka:
+			if len(splitList(strings.Join(c.Kafka.Brokers, ","))) == 0 {
+				problems = append(problems, "KAFKA_BROKERS is required (comma separated host:port list)")
+			}
+			if c.Kafka.TopicPartitions <= 0 {
+				problems = append(problems, fmt.Sprintf("KAFKA_TOPIC_PARTITIONS must be positive, got %d", c.Kafka.TopicPartitions))
+			}
+			if c.Kafka.TopicReplicationFactor <= 0 {
+				problems = append(problems, fmt.Sprintf("KAFKA_TOPIC_REPLICATION_FACTOR must be positive, got %d", c.Kafka.TopicReplicationFactor))
+			}
+		case RequireJWT:
+			if c.JWT.AccessTokenTTL <= 0 {
+				problems = append(problems, fmt.Sprintf("JWT_ACCESS_TOKEN_TTL must be a positive duration such as 15m, got %s", c.JWT.AccessTokenTTL))
+			}
+			if c.JWT.RefreshTokenTTL < c.JWT.AccessTokenTTL {
+				problems = append(problems, "JWT_REFRESH_TOKEN_TTL must not be shorter than JWT_ACCESS_TOKEN_TTL")
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// problems checks the shadow Redis settings when reservation writes are mirrored
+func (c *ReservationShadowConfig) problems() []string {
+	switch c.Mode {
+	case "", ReservationShadowOff:
+		return nil
+	case ReservationShadowShadow, ReservationShadowCutover:
+	default:
+		return []string{fmt.Sprintf("RESERVATION_SHADOW_MODE must be off, shadow or cutover, got %q", c.Mode)}
+	}
+
+	var problems []string
+	if c.Host == "" {
+		problems = append(problems, "RESERVATION_SHADOW_REDIS_HOST is required when RESERVATION_SHADOW_MODE is "+c.Mode)
+	}
+	if !validPort(c.Port) {
+		problems = append(problems, fmt.Sprintf("RESERVATION_SHADOW_REDIS_PORT must be between 1 and 65535, got %d", c.Port))
+	}
+	if c.Timeout <= 0 {
+		problems = append(problems, fmt.Sprintf("RESERVATION_SHADOW_TIMEOUT must be a positive duration, got %s", c.Timeout))
+	}
+	if c.Workers <= 0 || c.QueueSize <= 0 {
+		problems = append(problems, "RESERVATION_SHADOW_WORKERS and RESERVATION_SHADOW_QUEUE_SIZE must be positive")
+	}
+	return problems
+}
+
+// databaseProblems runs the existing required-field check of a database and adds range checks
+func databaseProblems(prefix string, db *DatabaseConfig, validate func() error) []string {
+	var problems []string
+	if err := validate(); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if !validPort(db.Port) {
+		problems = append(problems, fmt.Sprintf("%s_PORT must be between 1 and 65535, got %d", prefix, db.Port))
+	}
+	if db.User == "" {
+		problems = append(problems, prefix+"_USER is required")
+	}
+	return problems
+}
+
+func validPort(port int) bool {
+	return port > 0 && port <= 65535
+}
+
+// Summary returns the effective settings behind the requirements as space separated
+// KEY=value pairs for the startup log. Passwords and secrets are redacted.
+func (c *Config) Summary(requirements ...Requirement) string {
+	settings := []string{
+		"APP_ENVIRONMENT=" + c.App.Environment,
+		"APP_VERSION=" + c.App.Version,
+		fmt.Sprintf("SERVER_PORT=%d", c.Server.Port),
+		fmt.Sprintf("OTEL_ENABLED=%t", c.OTel.Enabled),
+	}
+	if c.OTel.Enabled {
+		settings = append(settings, "OTEL_COLLECTOR_ADDR="+c.OTel.CollectorAddr)
+		if c.OTel.DynamicSampling {
+			settings = append(settings, "OTEL_SAMPLING_ROUTES="+c.OTel.SamplingRoutes)
+		}
+	}
+
+	for _, req := range requirements {
+		switch req {
+		case RequireAuthDatabase:
+			settings = append(settings, "AUTH_DATABASE="+c.AuthDatabase.redactedURL())
+		case RequireTicketDatabase:
+			settings = append(settings, "TICKET_DATABASE="+c.TicketDatabase.redactedURL())
+		case RequireBookingDatabase:
+			settings = append(settings, "BOOKING_DATABASE="+c.BookingDatabase.redactedURL())
+		case RequirePaymentDatabase:
+			settings = append(settings, "PAYMENT_DATABASE="+c.PaymentDatabase.redactedURL())
+		case RequireRedis:
+			settings = append(settings,
+				"REDIS="+c.Redis.Addr(),
+				"REDIS_PASSWORD="+redact(c.Redis.Password),
+				fmt.Sprintf("REDIS_POOL_SIZE=%d", c.Redis.PoolSize),
+			)
+			if shadow := &c.Booking.ReservationShadow; shadow.Enabled() {
+				settings = append(settings,
+					"RESERVATION_SHADOW_MODE="+shadow.Mode,
+					fmt.Sprintf("RESERVATION_SHADOW_REDIS=%s:%d", shadow.Host, shadow.Port),
+					"RESERVATION_SHADOW_REDIS_PASSWORD="+redact(shadow.Password),
+				)
+			}
+		case RequireKafka:
+			settings = append(settings,
+				"KAFKA_BROKERS="+strings.Join(c.Kafka.Brokers, ","),
+				fmt.Sprintf("KAFKA_AUTO_CREATE_TOPICS=%t", c.Kafka.AutoCreateTopics),
+			)
+		case RequireJWT:
+			settings = append(settings,
+				"JWT_SECRET="+redact(c.JWT.Secret),
+				"JWT_ACCESS_TOKEN_TTL="+c.JWT.AccessTokenTTL.String(),
+			)
+		}
+	}
+	return strings.Join(settings, " ")
+}
+
+// redactedURL returns the connection target of a database without its password
+func (d *DatabaseConfig) redactedURL() string {
+	return fmt.Sprintf("%s:%s@%s:%d/%s?sslmode=%s", d.User, redact(d.Password), d.Host, d.Port, d.DBName, d.SSLMode)
+}
+
+// redact hides a secret, only telling whether it is set
+func redact(secret string) string {
+	if secret == "" {
+		return "(unset)"
+	}
+	return "***"
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfig_ValidateFor(t *testing.T) {
+	os.Unsetenv("KAFKA_BROKERS")
+	os.Unsetenv("BOOKING_DATABASE_HOST")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if err := cfg.ValidateFor(RequireBookingDatabase, RequireRedis, RequireKafka, RequireJWT); err != nil {
+		t.Fatalf("ValidateFor() on defaults error = %v", err)
+	}
+
+	cfg.BookingDatabase.Host = ""
+	cfg.Kafka.Brokers = []string{""}
+	cfg.Redis.Port = 0
+
+	err = cfg.ValidateFor(RequireBookingDatabase, RequireKafka)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("ValidateFor() error = %v, want *ValidationError", err)
+	}
+	// Redis was not required, so its port is not reported
+	if len(validationErr.Problems) != 2 {
+		t.Fatalf("Expected 2 problems, got %v", validationErr.Problems)
+	}
+	for _, want := range []string{"BOOKING_DATABASE_HOST", "KAFKA_BROKERS"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to mention %s, got %q", want, err)
+		}
+	}
+}
+
+func TestConfig_ValidateFor_ReservationShadow(t *testing.T) {
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	cfg.Booking.ReservationShadow.Mode = ReservationShadowShadow
+	err = cfg.ValidateFor(RequireRedis)
+	if err == nil || !strings.Contains(err.Error(), "RESERVATION_SHADOW_REDIS_HOST") {
+		t.Fatalf("ValidateFor() error = %v, want a missing shadow host", err)
+	}
+
+	cfg.Booking.ReservationShadow.Host = "redis-new"
+	if err := cfg.ValidateFor(RequireRedis); err != nil {
+		t.Fatalf("ValidateFor() error = %v", err)
+	}
+
+	cfg.Booking.ReservationShadow.Mode = "mirror"
+	if err := cfg.ValidateFor(RequireRedis); err == nil || !strings.Contains(err.Error(), "RESERVATION_SHADOW_MODE") {
+		t.Errorf("ValidateFor() error = %v, want an invalid mode", err)
+	}
+}
+
+func TestConfig_Summary(t *testing.T) {
+	cfg := &Config{
+		App:             AppConfig{Environment: "staging"},
+		Server:          ServerConfig{Port: 8083},
+		BookingDatabase: DatabaseConfig{Host: "db", Port: 5432, User: "booking", Password: "hunter2", DBName: "booking_db", SSLMode: "require"},
+		Redis:           RedisConfig{Host: "redis", Port: 6379},
+		JWT:             JWTConfig{Secret: "jwt-secret", AccessTokenTTL: 15 * time.Minute},
+	}
+
+	summary := cfg.Summary(RequireBookingDatabase, RequireRedis, RequireJWT)
+	for _, secret := range []string{"hunter2", "jwt-secret"} {
+		if strings.Contains(summary, secret) {
+			t.Errorf("Summary leaks %q: %s", secret, summary)
+		}
+	}
+	for _, want := range []string{
+		"BOOKING_DATABASE=booking:***@db:5432/booking_db?sslmode=require",
+		"REDIS=redis:6379",
+		"REDIS_PASSWORD=(unset)",
+		"JWT_SECRET=***",
+	} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("Summary missing %q: %s", want, summary)
+		}
+	}
+}
+
+func TestEnv(t *testing.T) {
+	t.Setenv("TEST_ENV_INT", "42")
+	t.Setenv("TEST_ENV_BAD_INT", "forty-two")
+	t.Setenv("TEST_ENV_DURATION", "250ms")
+	t.Setenv("TEST_ENV_BOOL", "false")
+
+	env := NewEnv()
+	if got := env.Int("TEST_ENV_INT", 1); got != 42 {
+		t.Errorf("Int() = %d, want 42", got)
+	}
+	if got := env.Duration("TEST_ENV_DURATION", time.Second); got != 250*time.Millisecond {
+		t.Errorf("Duration() = %s, want 250ms", got)
+	}
+	if got := env.Bool("TEST_ENV_BOOL", true); got {
+		t.Error("Bool() = true, want false")
+	}
+	if got := env.String("TEST_ENV_UNSET", "fallback"); got != "fallback" {
+		t.Errorf("String() = %q, want fallback", got)
+	}
+	if err := env.Err(); err != nil {
+		t.Fatalf("Err() = %v, want nil", err)
+	}
+
+	// Malformed values fall back to the default and are reported once
+	env.Int("TEST_ENV_BAD_INT", 7)
+	if got := env.Int("TEST_ENV_BAD_INT", 7); got != 7 {
+		t.Errorf("Int() on malformed value = %d, want default 7", got)
+	}
+	var validationErr *ValidationError
+	if !errors.As(env.Err(), &validationErr) || len(validationErr.Problems) != 1 {
+		t.Fatalf("Err() = %v, want one problem", env.Err())
+	}
+	if !strings.Contains(validationErr.Problems[0], "TEST_ENV_BAD_INT") {
+		t.Errorf("Problem does not name the variable: %s", validationErr.Problems[0])
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/exaring/otelpgx"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -31,6 +30,9 @@ type PostgresConfig struct {
 	// Telemetry configuration
 	EnableTracing bool
 	ServiceName   string
+
+	// SlowQueryThreshold logs and counts queries that take at least this long (0 disables)
+	SlowQueryThreshold time.Duration
 }
 
 // DefaultPostgresConfig returns default configuration
@@ -50,6 +52,8 @@ func DefaultPostgresConfig() *PostgresConfig {
 		ConnectTimeout:  10 * time.Second,
 		MaxRetries:      3,
 		RetryInterval:   2 * time.Second,
+
+		SlowQueryThreshold: 500 * time.Millisecond,
 	}
 }
 
@@ -85,12 +89,9 @@ func NewPostgres(ctx context.Context, cfg *PostgresConfig) (*PostgresDB, error)
 	poolConfig.MaxConnIdleTime = cfg.MaxConnIdleTime
 	poolConfig.ConnConfig.ConnectTimeout = cfg.ConnectTimeout
 
-	// Enable OpenTelemetry tracing if configured
-	if cfg.EnableTracing {
-		opts := []otelpgx.Option{
-			otelpgx.WithIncludeQueryParameters(),
-		}
-		poolConfig.ConnConfig.Tracer = otelpgx.NewTracer(opts...)
+	// Enable OpenTelemetry tracing and slow query logging if configured
+	if tracer := newQueryTracer(cfg); tracer != nil {
+		poolConfig.ConnConfig.Tracer = tracer
 	}
 
 	// Connect with retry logic
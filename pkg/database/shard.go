@@ -0,0 +1,361 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+)
+
+// DefaultShard is the shard of tenants without an assignment, the service's own database
+const DefaultShard = "default"
+
+var (
+	// ErrUnknownShard is returned when a tenant is assigned to a shard that is not configured
+	ErrUnknownShard = errors.New("unknown database shard")
+	// ErrInvalidShardSpec is returned for a malformed shard list
+	ErrInvalidShardSpec = errors.New("invalid database shard spec")
+)
+
+// ShardConfig locates the database of a shard. Credentials and pool settings are
+// shared with the default shard.
+type ShardConfig struct {
+	Name     string
+	Host     string
+	Port     int
+	Database string
+}
+
+// ParseShardConfigs parses a comma-separated list of name=host:port/database shards,
+// e.g. "eu=pg-eu:5432/booking_db,apac=pg-apac:5432/booking_db". An empty spec has no shards.
+func ParseShardConfigs(spec string) ([]ShardConfig, error) {
+	var shards []ShardConfig
+	seen := make(map[string]bool)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, location, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || name == DefaultShard {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidShardSpec, entry)
+		}
+		address, dbName, ok := strings.Cut(strings.TrimSpace(location), "/")
+		if !ok || dbName == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidShardSpec, entry)
+		}
+		host, portStr, ok := strings.Cut(address, ":")
+		if !ok || host == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidShardSpec, entry)
+		}
+		port, err := strconv.Atoi(portStr)
+		if err != nil || port <= 0 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidShardSpec, entry)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("%w: duplicate shard %q", ErrInvalidShardSpec, name)
+		}
+		seen[name] = true
+
+		shards = append(shards, ShardConfig{Name: name, Host: host, Port: port, Database: dbName})
+	}
+	return shards, nil
+}
+
+// ForShard returns a copy of the configuration connecting to shard
+func (c *PostgresConfig) ForShard(shard ShardConfig) *PostgresConfig {
+	cfg := *c
+	cfg.Host = shard.Host
+	cfg.Port = shard.Port
+	cfg.Database = shard.Database
+	return &cfg
+}
+
+// TenantShard assigns a tenant's data to a shard
+type TenantShard struct {
+	TenantID   string    `json:"tenant_id"`
+	Shard      string    `json:"shard"`
+	AssignedAt time.Time `json:"assigned_at"`
+}
+
+// ShardStore persists tenant shard assignments
+type ShardStore interface {
+	// ListTenantShards returns every assignment
+	ListTenantShards(ctx context.Context) ([]TenantShard, error)
+
+	// SaveTenantShard creates or replaces the assignment of a tenant
+	SaveTenantShard(ctx context.Context, assignment TenantShard) error
+}
+
+// PostgresShardStore keeps assignments in the tenant_shards table of the default shard
+type PostgresShardStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresShardStore creates a PostgresShardStore
+func NewPostgresShardStore(pool *pgxpool.Pool) *PostgresShardStore {
+	return &PostgresShardStore{pool: pool}
+}
+
+// ListTenantShards returns every assignment
+func (s *PostgresShardStore) ListTenantShards(ctx context.Context) ([]TenantShard, error) {
+	rows, err := s.pool.Query(ctx, `SELECT tenant_id::text, shard, assigned_at FROM tenant_shards`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenant shards: %w", err)
+	}
+	defer rows.Close()
+
+	var assignments []TenantShard
+	for rows.Next() {
+		var assignment TenantShard
+		if err := rows.Scan(&assignment.TenantID, &assignment.Shard, &assignment.AssignedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan tenant shard: %w", err)
+		}
+		assignments = append(assignments, assignment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tenant shards: %w", err)
+	}
+	return assignments, nil
+}
+
+// SaveTenantShard creates or replaces the assignment of a tenant
+func (s *PostgresShardStore) SaveTenantShard(ctx context.Context, assignment TenantShard) error {
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO tenant_shards (tenant_id, shard, assigned_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (tenant_id) DO UPDATE SET shard = EXCLUDED.shard, assigned_at = EXCLUDED.assigned_at
+	`, assignment.TenantID, assignment.Shard, assignment.AssignedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save tenant shard: %w", err)
+	}
+	return nil
+}
+
+// ShardResolver picks the database of a tenant. Tenants without an assignment live on
+// the default shard. Assignments are loaded from a ShardStore and refreshed periodically,
+// so every replica converges on an assignment made through any of them.
+type ShardResolver struct {
+	store ShardStore
+
+	mu      sync.RWMutex
+	shards  map[string]*PostgresDB
+	tenants map[string]TenantShard
+}
+
+// NewShardResolver creates a resolver over the default shard
+func NewShardResolver(defaultDB *PostgresDB, store ShardStore) *ShardResolver {
+	return &ShardResolver{
+		store:   store,
+		shards:  map[string]*PostgresDB{DefaultShard: defaultDB},
+		tenants: make(map[string]TenantShard),
+	}
+}
+
+// OpenShardResolver connects to the shards of spec (see ParseShardConfigs) with base's
+// credentials and pool settings, loads the assignments kept on the default shard and
+// refreshes them every refreshInterval until ctx is cancelled. It returns nil for an
+// empty spec. Shards connected here are closed by Close.
+func OpenShardResolver(ctx context.Context, defaultDB *PostgresDB, base *PostgresConfig, spec string, refreshInterval time.Duration) (*ShardResolver, error) {
+	shards, err := ParseShardConfigs(spec)
+	if err != nil || len(shards) == 0 {
+		return nil, err
+	}
+
+	resolver := NewShardResolver(defaultDB, NewPostgresShardStore(defaultDB.Pool()))
+	for _, shard := range shards {
+		db, err := NewPostgres(ctx, base.ForShard(shard))
+		if err != nil {
+			resolver.Close()
+			return nil, fmt.Errorf("failed to connect to shard %s: %w", shard.Name, err)
+		}
+		resolver.AddShard(shard.Name, db)
+	}
+	if err := resolver.Load(ctx); err != nil {
+		resolver.Close()
+		return nil, err
+	}
+	go resolver.RunRefresh(ctx, refreshInterval)
+	return resolver, nil
+}
+
+// AddShard adds a shard tenants can be assigned to
+func (r *ShardResolver) AddShard(name string, db *PostgresDB) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.shards[name] = db
+}
+
+// Load replaces the assignments with the store's. Nothing changes when an assignment
+// names a shard that is not configured, as routing its tenant elsewhere would split its data.
+func (r *ShardResolver) Load(ctx context.Context) error {
+	assignments, err := r.store.ListTenantShards(ctx)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tenants := make(map[string]TenantShard, len(assignments))
+	for _, assignment := range assignments {
+		if _, ok := r.shards[assignment.Shard]; !ok {
+			return fmt.Errorf("%w: tenant %s is assigned to %s", ErrUnknownShard, assignment.TenantID, assignment.Shard)
+		}
+		tenants[assignment.TenantID] = assignment
+	}
+	r.tenants = tenants
+	return nil
+}
+
+// RunRefresh reloads the assignments every interval until ctx is cancelled, keeping
+// the previous assignments when a reload fails. A non-positive interval never reloads.
+func (r *ShardResolver) RunRefresh(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Load(ctx); err != nil && ctx.Err() == nil {
+				logger.Get().WarnContext(ctx, fmt.Sprintf("Failed to refresh tenant shards: %v", err))
+			}
+		}
+	}
+}
+
+// Assign moves a tenant to shard, DefaultShard included. Existing rows are not copied:
+// assign tenants before they start selling, or move their data first.
+func (r *ShardResolver) Assign(ctx context.Context, tenantID, shard string) (*TenantShard, error) {
+	r.mu.RLock()
+	_, known := r.shards[shard]
+	r.mu.RUnlock()
+	if !known {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownShard, shard)
+	}
+
+	assignment := TenantShard{TenantID: tenantID, Shard: shard, AssignedAt: time.Now()}
+	if err := r.store.SaveTenantShard(ctx, assignment); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.tenants[tenantID] = assignment
+	r.mu.Unlock()
+	return &assignment, nil
+}
+
+// ShardOf returns the shard of a tenant
+func (r *ShardResolver) ShardOf(tenantID string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if assignment, ok := r.tenants[tenantID]; ok {
+		return assignment.Shard
+	}
+	return DefaultShard
+}
+
+// DB returns the database of a tenant
+func (r *ShardResolver) DB(tenantID string) *PostgresDB {
+	name := r.ShardOf(tenantID)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.shards[name]
+}
+
+// Shard returns the database of a shard
+func (r *ShardResolver) Shard(name string) (*PostgresDB, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	db, ok := r.shards[name]
+	return db, ok
+}
+
+// Shards returns the shard names, the default shard first
+func (r *ShardResolver) Shards() []string {
+	r.mu.RLock()
+	names := make([]string, 0, len(r.shards))
+	for name := range r.shards {
+		if name != DefaultShard {
+			names = append(names, name)
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Strings(names)
+	return append([]string{DefaultShard}, names...)
+}
+
+// LookupOrder returns the shard names in the order to search for a row of unknown
+// tenant: the shard of the tenant in ctx first, then the others as in Shards
+func (r *ShardResolver) LookupOrder(ctx context.Context) []string {
+	names := r.Shards()
+	tenantID := TenantFromContext(ctx)
+	if tenantID == "" {
+		return names
+	}
+
+	first := r.ShardOf(tenantID)
+	ordered := append(make([]string, 0, len(names)), first)
+	for _, name := range names {
+		if name != first {
+			ordered = append(ordered, name)
+		}
+	}
+	return ordered
+}
+
+// Assignments returns every assignment ordered by tenant
+func (r *ShardResolver) Assignments() []TenantShard {
+	r.mu.RLock()
+	assignments := make([]TenantShard, 0, len(r.tenants))
+	for _, assignment := range r.tenants {
+		assignments = append(assignments, assignment)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(assignments, func(i, j int) bool { return assignments[i].TenantID < assignments[j].TenantID })
+	return assignments
+}
+
+// Close closes the shards other than the default one, which belongs to the caller
+func (r *ShardResolver) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for name, db := range r.shards {
+		if name != DefaultShard {
+			db.Close()
+		}
+	}
+}
+
+type tenantContextKey struct{}
+
+// WithTenant returns a context carrying the tenant a request acts for, so shard
+// lookups of rows of unknown tenant start with that tenant's shard
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	if tenantID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, tenantContextKey{}, tenantID)
+}
+
+// TenantFromContext returns the tenant set by WithTenant, or ""
+func TenantFromContext(ctx context.Context) string {
+	tenantID, _ := ctx.Value(tenantContextKey{}).(string)
+	return tenantID
+}
@@ -0,0 +1,174 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// memoryShardStore is an in-memory ShardStore
+type memoryShardStore struct {
+	assignments map[string]TenantShard
+	saveErr     error
+}
+
+func (s *memoryShardStore) ListTenantShards(ctx context.Context) ([]TenantShard, error) {
+	assignments := make([]TenantShard, 0, len(s.assignments))
+	for _, assignment := range s.assignments {
+		assignments = append(assignments, assignment)
+	}
+	return assignments, nil
+}
+
+func (s *memoryShardStore) SaveTenantShard(ctx context.Context, assignment TenantShard) error {
+	if s.saveErr != nil {
+		return s.saveErr
+	}
+	s.assignments[assignment.TenantID] = assignment
+	return nil
+}
+
+func TestParseShardConfigs(t *testing.T) {
+	shards, err := ParseShardConfigs(" eu=pg-eu:5432/booking_db, apac=pg-apac:6432/booking_apac ,")
+	if err != nil {
+		t.Fatalf("ParseShardConfigs() error = %v", err)
+	}
+	want := []ShardConfig{
+		{Name: "eu", Host: "pg-eu", Port: 5432, Database: "booking_db"},
+		{Name: "apac", Host: "pg-apac", Port: 6432, Database: "booking_apac"},
+	}
+	if !reflect.DeepEqual(shards, want) {
+		t.Errorf("ParseShardConfigs() = %v, want %v", shards, want)
+	}
+
+	if shards, err := ParseShardConfigs(""); err != nil || len(shards) != 0 {
+		t.Errorf("ParseShardConfigs(\"\") = %v, %v, want no shards", shards, err)
+	}
+
+	for _, spec := range []string{
+		"eu",
+		"=pg-eu:5432/booking_db",
+		"default=pg:5432/booking_db",
+		"eu=pg-eu:5432",
+		"eu=pg-eu/booking_db",
+		"eu=pg-eu:port/booking_db",
+		"eu=pg-eu:5432/booking_db,eu=pg-eu2:5432/booking_db",
+	} {
+		if _, err := ParseShardConfigs(spec); !errors.Is(err, ErrInvalidShardSpec) {
+			t.Errorf("ParseShardConfigs(%q) error = %v, want ErrInvalidShardSpec", spec, err)
+		}
+	}
+}
+
+func TestPostgresConfig_ForShard(t *testing.T) {
+	base := DefaultPostgresConfig()
+	base.User = "booking"
+	cfg := base.ForShard(ShardConfig{Name: "eu", Host: "pg-eu", Port: 6432, Database: "booking_eu"})
+
+	if cfg.Host != "pg-eu" || cfg.Port != 6432 || cfg.Database != "booking_eu" || cfg.User != "booking" {
+		t.Errorf("ForShard() = %+v", cfg)
+	}
+	if base.Host != "localhost" {
+		t.Errorf("ForShard() modified the base config: host = %s", base.Host)
+	}
+}
+
+func TestShardResolver(t *testing.T) {
+	ctx := context.Background()
+	defaultDB, eu, apac := &PostgresDB{}, &PostgresDB{}, &PostgresDB{}
+	store := &memoryShardStore{assignments: map[string]TenantShard{
+		"tenant-eu": {TenantID: "tenant-eu", Shard: "eu"},
+	}}
+
+	resolver := NewShardResolver(defaultDB, store)
+	resolver.AddShard("eu", eu)
+	resolver.AddShard("apac", apac)
+	if err := resolver.Load(ctx); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if got := resolver.Shards(); !reflect.DeepEqual(got, []string{DefaultShard, "apac", "eu"}) {
+		t.Errorf("Shards() = %v", got)
+	}
+	if resolver.DB("tenant-eu") != eu {
+		t.Error("DB(tenant-eu) is not the eu shard")
+	}
+	if resolver.DB("tenant-other") != defaultDB {
+		t.Error("DB() of an unassigned tenant is not the default shard")
+	}
+
+	assignment, err := resolver.Assign(ctx, "tenant-apac", "apac")
+	if err != nil {
+		t.Fatalf("Assign() error = %v", err)
+	}
+	if assignment.Shard != "apac" || store.assignments["tenant-apac"].Shard != "apac" {
+		t.Errorf("Assign() = %+v, stored %+v", assignment, store.assignments["tenant-apac"])
+	}
+	if resolver.ShardOf("tenant-apac") != "apac" {
+		t.Errorf("ShardOf(tenant-apac) = %s, want apac", resolver.ShardOf("tenant-apac"))
+	}
+	if got := resolver.Assignments(); len(got) != 2 || got[0].TenantID != "tenant-apac" || got[1].TenantID != "tenant-eu" {
+		t.Errorf("Assignments() = %v", got)
+	}
+
+	if _, err := resolver.Assign(ctx, "tenant-apac", "us"); !errors.Is(err, ErrUnknownShard) {
+		t.Errorf("Assign() to an unknown shard error = %v, want ErrUnknownShard", err)
+	}
+	store.saveErr = errors.New("db down")
+	if _, err := resolver.Assign(ctx, "tenant-apac", DefaultShard); err == nil {
+		t.Error("Assign() error = nil when the store fails")
+	}
+	if resolver.ShardOf("tenant-apac") != "apac" {
+		t.Error("a failed assignment changed the shard")
+	}
+}
+
+func TestShardResolver_LoadUnknownShard(t *testing.T) {
+	store := &memoryShardStore{assignments: map[string]TenantShard{
+		"tenant-eu": {TenantID: "tenant-eu", Shard: "eu"},
+	}}
+	resolver := NewShardResolver(&PostgresDB{}, store)
+	resolver.AddShard("eu", &PostgresDB{})
+	if err := resolver.Load(context.Background()); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	store.assignments["tenant-us"] = TenantShard{TenantID: "tenant-us", Shard: "us"}
+	if err := resolver.Load(context.Background()); !errors.Is(err, ErrUnknownShard) {
+		t.Errorf("Load() error = %v, want ErrUnknownShard", err)
+	}
+	if resolver.ShardOf("tenant-eu") != "eu" {
+		t.Error("a failed load dropped the previous assignments")
+	}
+}
+
+func TestShardResolver_LookupOrder(t *testing.T) {
+	store := &memoryShardStore{assignments: map[string]TenantShard{
+		"tenant-eu": {TenantID: "tenant-eu", Shard: "eu"},
+	}}
+	resolver := NewShardResolver(&PostgresDB{}, store)
+	resolver.AddShard("eu", &PostgresDB{})
+	resolver.AddShard("apac", &PostgresDB{})
+	if err := resolver.Load(context.Background()); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		tenant string
+		want   []string
+	}{
+		{name: "no tenant", want: []string{DefaultShard, "apac", "eu"}},
+		{name: "assigned tenant", tenant: "tenant-eu", want: []string{"eu", DefaultShard, "apac"}},
+		{name: "unassigned tenant", tenant: "tenant-other", want: []string{DefaultShard, "apac", "eu"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := WithTenant(context.Background(), tt.tenant)
+			if got := resolver.LookupOrder(ctx); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LookupOrder() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
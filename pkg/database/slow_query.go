@@ -0,0 +1,137 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/exaring/otelpgx"
+	"github.com/jackc/pgx/v5"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// maxLoggedStatementLen caps the SQL logged for a slow query
+const maxLoggedStatementLen = 1024
+
+// slowQueryKey holds the in-flight query between TraceQueryStart and TraceQueryEnd
+type slowQueryKey struct{}
+
+type slowQueryStart struct {
+	sql   string
+	start time.Time
+}
+
+// SlowQueryTracer logs queries slower than a threshold with their statement, duration and
+// trace_id, and counts them per operation in the db.slow_queries metric.
+// Query parameters are never logged because they may contain PII.
+type SlowQueryTracer struct {
+	threshold time.Duration
+	counter   *telemetry.Counter
+	now       func() time.Time
+	report    func(ctx context.Context, operation, statement string, duration time.Duration)
+}
+
+// NewSlowQueryTracer creates a tracer reporting queries that take at least threshold
+func NewSlowQueryTracer(threshold time.Duration) *SlowQueryTracer {
+	t := &SlowQueryTracer{threshold: threshold, now: time.Now}
+	// Without the counter slow queries are still logged
+	t.counter, _ = telemetry.NewCounter(telemetry.MetricOpts{
+		Name:        "db.slow_queries",
+		Description: "Queries slower than the slow query threshold",
+		Unit:        "{query}",
+	})
+	t.report = t.logSlowQuery
+	return t
+}
+
+// TraceQueryStart records the statement and start time
+func (t *SlowQueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, slowQueryKey{}, slowQueryStart{sql: data.SQL, start: t.now()})
+}
+
+// TraceQueryEnd reports the query if it took at least the threshold
+func (t *SlowQueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryEndData) {
+	q, ok := ctx.Value(slowQueryKey{}).(slowQueryStart)
+	if !ok {
+		return
+	}
+	if duration := t.now().Sub(q.start); duration >= t.threshold {
+		t.report(ctx, queryOperation(q.sql), q.sql, duration)
+	}
+}
+
+// logSlowQuery logs a slow query with the caller's trace_id and increments the slow counter
+func (t *SlowQueryTracer) logSlowQuery(ctx context.Context, operation, statement string, duration time.Duration) {
+	logger.Get().WarnContext(ctx, "Slow query",
+		zap.String("operation", operation),
+		zap.String("statement", truncateStatement(statement)),
+		zap.Duration("duration", duration),
+		zap.Duration("threshold", t.threshold),
+	)
+	if t.counter != nil {
+		t.counter.Inc(ctx, attribute.String("db.operation", operation))
+	}
+}
+
+// tracedSlowQueryTracer runs the slow query tracer inside the OpenTelemetry query span.
+// Embedding keeps otelpgx's batch, copy, prepare and connect tracing.
+type tracedSlowQueryTracer struct {
+	*otelpgx.Tracer
+	slow *SlowQueryTracer
+}
+
+// TraceQueryStart starts the query span, then the slow query timer
+func (t *tracedSlowQueryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	ctx = t.Tracer.TraceQueryStart(ctx, conn, data)
+	return t.slow.TraceQueryStart(ctx, conn, data)
+}
+
+// TraceQueryEnd reports a slow query before ending the query span
+func (t *tracedSlowQueryTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	t.slow.TraceQueryEnd(ctx, conn, data)
+	t.Tracer.TraceQueryEnd(ctx, conn, data)
+}
+
+// newQueryTracer builds the pool's query tracer from the tracing and slow query settings.
+// Returns nil when both are disabled.
+func newQueryTracer(cfg *PostgresConfig) pgx.QueryTracer {
+	var otelTracer *otelpgx.Tracer
+	if cfg.EnableTracing {
+		otelTracer = otelpgx.NewTracer(otelpgx.WithIncludeQueryParameters())
+	}
+
+	if cfg.SlowQueryThreshold <= 0 {
+		if otelTracer == nil {
+			return nil
+		}
+		return otelTracer
+	}
+
+	slow := NewSlowQueryTracer(cfg.SlowQueryThreshold)
+	if otelTracer == nil {
+		return slow
+	}
+	return &tracedSlowQueryTracer{Tracer: otelTracer, slow: slow}
+}
+
+// queryOperation returns the statement's leading keyword (SELECT, INSERT, ...) as a
+// low-cardinality metric label
+func queryOperation(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "UNKNOWN"
+	}
+	return strings.ToUpper(strings.TrimLeft(fields[0], "("))
+}
+
+// truncateStatement collapses whitespace and caps the statement length for logging
+func truncateStatement(sql string) string {
+	statement := strings.Join(strings.Fields(sql), " ")
+	if len(statement) > maxLoggedStatementLen {
+		return statement[:maxLoggedStatementLen] + "..."
+	}
+	return statement
+}
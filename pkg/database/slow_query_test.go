@@ -0,0 +1,128 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type slowQueryReport struct {
+	operation string
+	statement string
+	duration  time.Duration
+}
+
+// newTestSlowQueryTracer returns a tracer whose queries take the given duration
+func newTestSlowQueryTracer(threshold, queryDuration time.Duration, reports *[]slowQueryReport) *SlowQueryTracer {
+	tracer := NewSlowQueryTracer(threshold)
+	now := time.Now()
+	tracer.now = func() time.Time {
+		current := now
+		now = now.Add(queryDuration)
+		return current
+	}
+	tracer.report = func(_ context.Context, operation, statement string, duration time.Duration) {
+		*reports = append(*reports, slowQueryReport{operation, statement, duration})
+	}
+	return tracer
+}
+
+func TestSlowQueryTracer(t *testing.T) {
+	tests := []struct {
+		name          string
+		queryDuration time.Duration
+		wantReport    bool
+	}{
+		{"fast query", 10 * time.Millisecond, false},
+		{"at threshold", 100 * time.Millisecond, true},
+		{"slow query", 300 * time.Millisecond, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reports []slowQueryReport
+			tracer := newTestSlowQueryTracer(100*time.Millisecond, tt.queryDuration, &reports)
+
+			sql := "select * from bookings where id = $1"
+			ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: sql})
+			tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+
+			if !tt.wantReport {
+				if len(reports) != 0 {
+					t.Errorf("Expected no report, got %+v", reports)
+				}
+				return
+			}
+			if len(reports) != 1 {
+				t.Fatalf("Expected 1 report, got %d", len(reports))
+			}
+			if reports[0].operation != "SELECT" || reports[0].statement != sql || reports[0].duration != tt.queryDuration {
+				t.Errorf("Report = %+v", reports[0])
+			}
+		})
+	}
+}
+
+func TestSlowQueryTracer_EndWithoutStart(t *testing.T) {
+	var reports []slowQueryReport
+	tracer := newTestSlowQueryTracer(time.Millisecond, time.Second, &reports)
+
+	tracer.TraceQueryEnd(context.Background(), nil, pgx.TraceQueryEndData{})
+	if len(reports) != 0 {
+		t.Errorf("Expected no report without a started query, got %+v", reports)
+	}
+}
+
+func TestNewQueryTracer(t *testing.T) {
+	tests := []struct {
+		name      string
+		tracing   bool
+		threshold time.Duration
+		wantType  string
+	}{
+		{"disabled", false, 0, "<nil>"},
+		{"tracing only", true, 0, "*otelpgx.Tracer"},
+		{"slow queries only", false, time.Second, "*database.SlowQueryTracer"},
+		{"tracing and slow queries", true, time.Second, "*database.tracedSlowQueryTracer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracer := newQueryTracer(&PostgresConfig{EnableTracing: tt.tracing, SlowQueryThreshold: tt.threshold})
+			if got := fmt.Sprintf("%T", tracer); got != tt.wantType {
+				t.Errorf("Tracer type = %s, want %s", got, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestQueryOperation(t *testing.T) {
+	tests := map[string]string{
+		"select 1":                          "SELECT",
+		"\n\t  UPDATE bookings SET x = 1":   "UPDATE",
+		"(select 1) union (select 2)":       "SELECT",
+		"WITH cte AS (SELECT 1) SELECT * x": "WITH",
+		"":                                  "UNKNOWN",
+	}
+	for sql, want := range tests {
+		if got := queryOperation(sql); got != want {
+			t.Errorf("queryOperation(%q) = %q, want %q", sql, got, want)
+		}
+	}
+}
+
+func TestTruncateStatement(t *testing.T) {
+	if got := truncateStatement("SELECT *\n\t FROM   bookings"); got != "SELECT * FROM bookings" {
+		t.Errorf("truncateStatement() = %q", got)
+	}
+
+	long := "SELECT " + strings.Repeat("x", 2*maxLoggedStatementLen)
+	got := truncateStatement(long)
+	if len(got) != maxLoggedStatementLen+len("...") || !strings.HasSuffix(got, "...") {
+		t.Errorf("truncateStatement() length = %d, want %d", len(got), maxLoggedStatementLen+3)
+	}
+}
@@ -0,0 +1,119 @@
+package events
+
+import "time"
+
+// BookingEventType is the type of a booking lifecycle event
+type BookingEventType string
+
+// Booking lifecycle events, published by booking-service to booking-events
+const (
+	BookingEventCreated   BookingEventType = "booking.created"
+	BookingEventConfirmed BookingEventType = "booking.confirmed"
+	BookingEventCancelled BookingEventType = "booking.cancelled"
+	BookingEventExpired   BookingEventType = "booking.expired"
+	BookingEventModified  BookingEventType = "booking.modified"
+)
+
+// BookingEventTypes lists every booking event type
+var BookingEventTypes = []BookingEventType{
+	BookingEventCreated,
+	BookingEventConfirmed,
+	BookingEventCancelled,
+	BookingEventExpired,
+	BookingEventModified,
+}
+
+// IsValid returns true if t is a known booking event type
+func (t BookingEventType) IsValid() bool {
+	for _, known := range BookingEventTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// BookingEvent is a booking lifecycle event
+type BookingEvent struct {
+	EventID     string            `json:"event_id"`
+	EventType   BookingEventType  `json:"event_type"`
+	OccurredAt  time.Time         `json:"occurred_at"`
+	Version     int               `json:"version"`
+	BookingData *BookingEventData `json:"data"`
+}
+
+// BookingEventData is the booking as it stands after the change
+type BookingEventData struct {
+	BookingID        string     `json:"booking_id"`
+	TenantID         string     `json:"tenant_id,omitempty"`
+	UserID           string     `json:"user_id"`
+	EventID          string     `json:"event_id"`
+	ShowID           string     `json:"show_id,omitempty"`
+	ZoneID           string     `json:"zone_id"`
+	Quantity         int        `json:"quantity"`
+	UnitPrice        float64    `json:"unit_price"`
+	TotalPrice       float64    `json:"total_price"`
+	Currency         string     `json:"currency"`
+	Status           string     `json:"status"`
+	PaymentID        string     `json:"payment_id,omitempty"`
+	ConfirmationCode string     `json:"confirmation_code,omitempty"`
+	ReservedAt       time.Time  `json:"reserved_at"`
+	ConfirmedAt      *time.Time `json:"confirmed_at,omitempty"`
+	CancelledAt      *time.Time `json:"cancelled_at,omitempty"`
+	ExpiresAt        time.Time  `json:"expires_at"`
+
+	// Zone and quantity before the change (booking.modified only)
+	PreviousZoneID   string `json:"previous_zone_id,omitempty"`
+	PreviousQuantity int    `json:"previous_quantity,omitempty"`
+}
+
+// NewBookingEvent creates a booking event. The booking, user, event and zone are
+// required; consumers key projections and seat counters by them.
+func NewBookingEvent(eventType BookingEventType, eventID string, data *BookingEventData) (*BookingEvent, error) {
+	event := &BookingEvent{
+		EventID:     eventID,
+		EventType:   eventType,
+		OccurredAt:  now(),
+		Version:     Version,
+		BookingData: data,
+	}
+	if err := event.Validate(); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// Validate checks the event carries what consumers rely on
+func (e *BookingEvent) Validate() error {
+	if !e.EventType.IsValid() {
+		return invalid(string(e.EventType), "unknown booking event type")
+	}
+	if e.BookingData == nil {
+		return invalid(string(e.EventType), "data is required")
+	}
+	d := e.BookingData
+	if err := require(string(e.EventType), "event_id", e.EventID, "booking_id", d.BookingID, "user_id", d.UserID,
+		"data.event_id", d.EventID, "zone_id", d.ZoneID); err != nil {
+		return err
+	}
+	if d.Quantity <= 0 {
+		return invalid(string(e.EventType), "quantity must be positive")
+	}
+	if e.EventType == BookingEventModified && d.PreviousZoneID == "" {
+		return invalid(string(e.EventType), "previous_zone_id is required")
+	}
+	return nil
+}
+
+// Topic returns the Kafka topic of booking events
+func (e *BookingEvent) Topic() string {
+	return TopicBookingEvents
+}
+
+// Key returns the partition key of the event, the booking ID
+func (e *BookingEvent) Key() string {
+	if e.BookingData != nil {
+		return e.BookingData.BookingID
+	}
+	return e.EventID
+}
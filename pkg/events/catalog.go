@@ -0,0 +1,77 @@
+package events
+
+// Domain is the part of the system an event belongs to
+type Domain string
+
+const (
+	DomainBooking   Domain = "booking"
+	DomainPayment   Domain = "payment"
+	DomainQueue     Domain = "queue"
+	DomainInventory Domain = "inventory"
+)
+
+// Entry describes an event type of the catalog
+type Entry struct {
+	Type        string
+	Domain      Domain
+	Topic       string // Kafka topic, or the Redis Pub/Sub channel pattern
+	Publisher   string // Service publishing the event
+	Description string
+	newPayload  func() any
+}
+
+// Payload returns a zero payload of the event, to decode into or describe
+func (e Entry) Payload() any {
+	return e.newPayload()
+}
+
+// catalog lists every event type, grouped by domain
+var catalog = []Entry{
+	{string(BookingEventCreated), DomainBooking, TopicBookingEvents, "booking-service", "A reservation was made and its seats are held", newBookingEvent},
+	{string(BookingEventConfirmed), DomainBooking, TopicBookingEvents, "booking-service", "The booking was paid for and its seats are sold", newBookingEvent},
+	{string(BookingEventCancelled), DomainBooking, TopicBookingEvents, "booking-service", "The booking was cancelled and its seats released", newBookingEvent},
+	{string(BookingEventExpired), DomainBooking, TopicBookingEvents, "booking-service", "The reservation was not paid in time and its seats were released", newBookingEvent},
+	{string(BookingEventModified), DomainBooking, TopicBookingEvents, "booking-service", "The booking moved zone or changed quantity; the previous ones are included", newBookingEvent},
+
+	{string(PaymentEventCreated), DomainPayment, TopicPaymentEvents, "payment-service", "A payment was created for a booking; the customer is paying", newPaymentEvent},
+	{string(PaymentEventSuccess), DomainPayment, TopicPaymentEvents, "payment-service", "A saga payment succeeded", newPaymentEvent},
+	{string(PaymentEventFailed), DomainPayment, TopicPaymentEvents, "payment-service", "A saga payment failed; payment_id is empty if it could not be created", newPaymentEvent},
+	{string(PaymentEventRefunded), DomainPayment, TopicPaymentEvents, "payment-service", "A payment was refunded, by the saga or an approved refund request", newPaymentEvent},
+	{string(PaymentEventRefundFailed), DomainPayment, TopicPaymentEvents, "payment-service", "A refund could not be processed", newPaymentEvent},
+	{string(PaymentEventRefundRequested), DomainPayment, TopicPaymentEvents, "payment-service", "A refund above the tenant's threshold waits for approval", newRefundRequestEvent},
+	{string(PaymentEventRefundApproved), DomainPayment, TopicPaymentEvents, "payment-service", "An admin approved a refund request", newRefundRequestEvent},
+	{string(PaymentEventRefundRejected), DomainPayment, TopicPaymentEvents, "payment-service", "An admin rejected a refund request", newRefundRequestEvent},
+	{PaymentSuccessEventType, DomainPayment, TopicPaymentSuccess, "payment-service", "A payment succeeded; starts the post-payment saga confirming the booking", newPaymentSuccessEvent},
+	{SeatReleaseEventType, DomainPayment, TopicSeatRelease, "payment-service", "The booking's payment did not go through or was refunded; its seats are released", newSeatReleaseEvent},
+
+	{QueuePassReadyEventType, DomainQueue, QueuePassChannel("{event_id}", "{user_id}"), "booking-service", "The virtual queue released the user with a queue pass", newQueuePassReadyEvent},
+
+	{ZoneCreatedEventType, DomainInventory, TopicZoneCapacityEvents, "ticket-service", "A zone was created; its Redis counters are seeded", newZoneEvent},
+	{ZoneUpdatedEventType, DomainInventory, TopicZoneCapacityEvents, "ticket-service", "A zone's seats or settings changed", newZoneEvent},
+	{ZoneCapacityChangedEventType, DomainInventory, TopicZoneCapacityEvents, "ticket-service", "An organizer changed the capacity of a zone on sale", newZoneCapacityChangedEvent},
+}
+
+func newBookingEvent() any             { return &BookingEvent{BookingData: &BookingEventData{}} }
+func newPaymentEvent() any             { return &PaymentEvent{PaymentData: &PaymentEventData{}} }
+func newRefundRequestEvent() any       { return &RefundRequestEvent{Data: &RefundRequestEventData{}} }
+func newPaymentSuccessEvent() any      { return &PaymentSuccessEvent{} }
+func newSeatReleaseEvent() any         { return &SeatReleaseEvent{} }
+func newQueuePassReadyEvent() any      { return &QueuePassReadyEvent{} }
+func newZoneEvent() any                { return &ZoneEvent{} }
+func newZoneCapacityChangedEvent() any { return &ZoneCapacityChangedEvent{} }
+
+// Catalog returns every event type, grouped by domain
+func Catalog() []Entry {
+	return append([]Entry(nil), catalog...)
+}
+
+// Lookup returns the catalog entry of an event type published to topic. payment.success
+// is published to both payment-events and payment.success, with different payloads.
+func Lookup(topic, eventType string) (Entry, bool) {
+	for _, entry := range catalog {
+		if entry.Topic == topic && entry.Type == eventType {
+			return entry, true
+		}
+	}
+	return Entry{}, false
+}
@@ -0,0 +1,107 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func TestCatalog(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, entry := range Catalog() {
+		key := entry.Topic + " " + entry.Type
+		if seen[key] {
+			t.Errorf("%s is listed twice", key)
+		}
+		seen[key] = true
+
+		if entry.Domain == "" || entry.Publisher == "" || entry.Description == "" {
+			t.Errorf("%s is not fully described", key)
+		}
+		if entry.Payload() == nil {
+			t.Errorf("%s has no payload", key)
+		}
+		if found, ok := Lookup(entry.Topic, entry.Type); !ok || found.Type != entry.Type {
+			t.Errorf("Lookup(%s) = %+v, %v", key, found, ok)
+		}
+	}
+
+	// Every booking and payment event type is in the catalog
+	for _, eventType := range BookingEventTypes {
+		if _, ok := Lookup(TopicBookingEvents, string(eventType)); !ok {
+			t.Errorf("%s is missing from the catalog", eventType)
+		}
+	}
+	for _, eventType := range append(PaymentEventTypes, RefundRequestEventTypes...) {
+		if _, ok := Lookup(TopicPaymentEvents, string(eventType)); !ok {
+			t.Errorf("%s is missing from the catalog", eventType)
+		}
+	}
+
+	if _, ok := Lookup(TopicBookingEvents, string(PaymentEventSuccess)); ok {
+		t.Error("Lookup() found a payment event on booking-events")
+	}
+}
+
+func TestEntrySchema(t *testing.T) {
+	entry, ok := Lookup(TopicBookingEvents, string(BookingEventModified))
+	if !ok {
+		t.Fatal("booking.modified is missing from the catalog")
+	}
+	schema := entry.Schema()
+
+	properties := schema["properties"].(map[string]any)
+	if eventType := properties["event_type"].(map[string]any); eventType["const"] != string(BookingEventModified) {
+		t.Errorf("event_type = %v, want const %s", eventType, BookingEventModified)
+	}
+	if occurredAt := properties["occurred_at"].(map[string]any); occurredAt["format"] != "date-time" {
+		t.Errorf("occurred_at = %v, want a date-time string", occurredAt)
+	}
+
+	// Fields without omitempty are required, the others optional
+	data := properties["data"].(map[string]any)["anyOf"].([]any)[0].(map[string]any)
+	required := make(map[string]bool)
+	for _, name := range data["required"].([]string) {
+		required[name] = true
+	}
+	if !required["booking_id"] || !required["zone_id"] || required["previous_zone_id"] || required["tenant_id"] {
+		t.Errorf("data required = %v", data["required"])
+	}
+}
+
+func TestWriteSchemas(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteSchemas(&buf); err != nil {
+		t.Fatalf("WriteSchemas() error = %v", err)
+	}
+
+	var exported struct {
+		Events []ExportedEvent `json:"events"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &exported); err != nil {
+		t.Fatalf("WriteSchemas() wrote invalid JSON: %v", err)
+	}
+	if len(exported.Events) != len(catalog) {
+		t.Fatalf("exported %d events, want %d", len(exported.Events), len(catalog))
+	}
+	for _, event := range exported.Events {
+		if event.Schema["type"] != "object" || event.Schema["title"] != event.Type {
+			t.Errorf("%s schema = %v", event.Type, event.Schema)
+		}
+	}
+}
+
+func TestSchemasFileIsCurrent(t *testing.T) {
+	committed, err := os.ReadFile("schemas.json")
+	if err != nil {
+		t.Fatalf("failed to read schemas.json: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := WriteSchemas(&buf); err != nil {
+		t.Fatalf("WriteSchemas() error = %v", err)
+	}
+	if !bytes.Equal(committed, buf.Bytes()) {
+		t.Error("schemas.json is out of date, run make event-schemas")
+	}
+}
@@ -0,0 +1,31 @@
+// Command event-schemas writes the domain events catalog with the JSON Schema of each
+// event type, to stdout or the file given with -o
+package main
+
+import (
+	"flag"
+	"io"
+	"log"
+	"os"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/events"
+)
+
+func main() {
+	output := flag.String("o", "", "file to write the schemas to (default: stdout)")
+	flag.Parse()
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			log.Fatalf("Failed to create %s: %v", *output, err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if err := events.WriteSchemas(w); err != nil {
+		log.Fatalf("Failed to write event schemas: %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+// Package events is the catalog of the domain events services exchange over Kafka and
+// Redis Pub/Sub: their payloads, event types and topics. Publishers build events with
+// the New* constructors, which reject events missing the fields consumers rely on, and
+// consumers decode into the same types, so a payload is defined in one place.
+package events
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidEvent is returned by the constructors for events consumers could not process
+var ErrInvalidEvent = errors.New("invalid event")
+
+// Topics and channels events are published to
+const (
+	TopicBookingEvents       = "booking-events"
+	TopicPaymentEvents       = "payment-events"
+	TopicPaymentSuccess      = "payment.success"
+	TopicSeatRelease         = "payment.seat-release"
+	TopicZoneCapacityEvents  = "zone-capacity-events"
+	queuePassChannelTemplate = "queue:pass:%s:%s"
+)
+
+// QueuePassChannel returns the Redis Pub/Sub channel a user's queue pass for an event is
+// published on. Each user gets a channel of their own, so a release is not broadcast to
+// every waiting client.
+func QueuePassChannel(eventID, userID string) string {
+	return fmt.Sprintf(queuePassChannelTemplate, eventID, userID)
+}
+
+// Version is the payload version of the enveloped events
+const Version = 1
+
+// invalid returns an ErrInvalidEvent naming the event type and what is wrong with it
+func invalid(eventType, problem string) error {
+	return fmt.Errorf("%w: %s: %s", ErrInvalidEvent, eventType, problem)
+}
+
+// require returns an error naming the first of fields whose value is empty
+func require(eventType string, fields ...string) error {
+	for i := 0; i+1 < len(fields); i += 2 {
+		if fields[i+1] == "" {
+			return invalid(eventType, fields[i]+" is required")
+		}
+	}
+	return nil
+}
+
+// now returns the time events are stamped with
+func now() time.Time {
+	return time.Now().UTC()
+}
@@ -0,0 +1,160 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func validBookingData() *BookingEventData {
+	return &BookingEventData{
+		BookingID: "booking-1",
+		UserID:    "user-1",
+		EventID:   "event-1",
+		ZoneID:    "zone-1",
+		Quantity:  2,
+		Status:    "reserved",
+	}
+}
+
+func TestNewBookingEvent(t *testing.T) {
+	tests := []struct {
+		name      string
+		eventType BookingEventType
+		eventID   string
+		modify    func(d *BookingEventData)
+		wantErr   bool
+	}{
+		{"valid", BookingEventCreated, "evt-1", nil, false},
+		{"unknown type", "booking.paid", "evt-1", nil, true},
+		{"missing event ID", BookingEventCreated, "", nil, true},
+		{"missing booking", BookingEventConfirmed, "evt-1", func(d *BookingEventData) { d.BookingID = "" }, true},
+		{"missing zone", BookingEventCancelled, "evt-1", func(d *BookingEventData) { d.ZoneID = "" }, true},
+		{"no seats", BookingEventExpired, "evt-1", func(d *BookingEventData) { d.Quantity = 0 }, true},
+		{"modified without previous zone", BookingEventModified, "evt-1", nil, true},
+		{"modified", BookingEventModified, "evt-1", func(d *BookingEventData) { d.PreviousZoneID = "zone-0" }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := validBookingData()
+			if tt.modify != nil {
+				tt.modify(data)
+			}
+			event, err := NewBookingEvent(tt.eventType, tt.eventID, data)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidEvent) {
+					t.Errorf("NewBookingEvent() error = %v, want %v", err, ErrInvalidEvent)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewBookingEvent() error = %v", err)
+			}
+			if event.Version != Version || event.OccurredAt.IsZero() {
+				t.Errorf("event not stamped: %+v", event)
+			}
+			if event.Topic() != TopicBookingEvents || event.Key() != "booking-1" {
+				t.Errorf("Topic(), Key() = %s, %s", event.Topic(), event.Key())
+			}
+		})
+	}
+}
+
+func TestNewPaymentEvent(t *testing.T) {
+	tests := []struct {
+		name      string
+		eventType PaymentEventType
+		data      *PaymentEventData
+		wantErr   bool
+	}{
+		{"created", PaymentEventCreated, &PaymentEventData{PaymentID: "pay-1", BookingID: "booking-1"}, false},
+		{"success", PaymentEventSuccess, &PaymentEventData{PaymentID: "pay-1", BookingID: "booking-1"}, false},
+		{"failed before the payment was created", PaymentEventFailed, &PaymentEventData{BookingID: "booking-1"}, false},
+		{"refunded without payment", PaymentEventRefunded, &PaymentEventData{BookingID: "booking-1"}, true},
+		{"missing booking", PaymentEventSuccess, &PaymentEventData{PaymentID: "pay-1"}, true},
+		{"refund request type", PaymentEventRefundRequested, &PaymentEventData{PaymentID: "pay-1", BookingID: "booking-1"}, true},
+		{"no data", PaymentEventSuccess, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPaymentEvent(tt.eventType, "evt-1", tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewPaymentEvent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewRefundRequestEvent(t *testing.T) {
+	data := &RefundRequestEventData{RefundRequestID: "rr-1", PaymentID: "pay-1", BookingID: "booking-1"}
+	for _, eventType := range RefundRequestEventTypes {
+		if _, err := NewRefundRequestEvent(eventType, "evt-1", data); err != nil {
+			t.Errorf("NewRefundRequestEvent(%s) error = %v", eventType, err)
+		}
+	}
+	if _, err := NewRefundRequestEvent(PaymentEventSuccess, "evt-1", data); !errors.Is(err, ErrInvalidEvent) {
+		t.Errorf("NewRefundRequestEvent(payment.success) error = %v, want %v", err, ErrInvalidEvent)
+	}
+	if _, err := NewRefundRequestEvent(PaymentEventRefundApproved, "evt-1", &RefundRequestEventData{PaymentID: "pay-1", BookingID: "booking-1"}); !errors.Is(err, ErrInvalidEvent) {
+		t.Errorf("NewRefundRequestEvent() without request ID error = %v, want %v", err, ErrInvalidEvent)
+	}
+}
+
+func TestNewSeatReleaseEvent(t *testing.T) {
+	event, err := NewSeatReleaseEvent("booking-1", "pay-1", "user-1", SeatReleaseReasonPaymentRefunded, "", "refunded at the gateway")
+	if err != nil {
+		t.Fatalf("NewSeatReleaseEvent() error = %v", err)
+	}
+	if event.EventType != SeatReleaseEventType || event.Timestamp.IsZero() || event.Topic() != TopicSeatRelease {
+		t.Errorf("NewSeatReleaseEvent() = %+v", event)
+	}
+
+	if _, err := NewSeatReleaseEvent("booking-1", "pay-1", "user-1", "payment_lost", "", ""); !errors.Is(err, ErrInvalidEvent) {
+		t.Errorf("unknown reason error = %v, want %v", err, ErrInvalidEvent)
+	}
+	if _, err := NewSeatReleaseEvent("", "pay-1", "user-1", SeatReleaseReasonPaymentFailed, "", ""); !errors.Is(err, ErrInvalidEvent) {
+		t.Errorf("missing booking error = %v, want %v", err, ErrInvalidEvent)
+	}
+}
+
+func TestNewZoneCapacityChangedEvent(t *testing.T) {
+	event, err := NewZoneCapacityChangedEvent("evt-1", "zone-1", "show-1", 100, 80)
+	if err != nil {
+		t.Fatalf("NewZoneCapacityChangedEvent() error = %v", err)
+	}
+	if event.Delta() != -20 || event.Key() != "zone-1" {
+		t.Errorf("Delta(), Key() = %d, %s", event.Delta(), event.Key())
+	}
+	if _, err := NewZoneCapacityChangedEvent("evt-1", "zone-1", "show-1", 100, -1); !errors.Is(err, ErrInvalidEvent) {
+		t.Errorf("negative capacity error = %v, want %v", err, ErrInvalidEvent)
+	}
+	if _, err := NewZoneEvent(ZoneCapacityChangedEventType, "evt-1", "zone-1", "show-1"); !errors.Is(err, ErrInvalidEvent) {
+		t.Errorf("NewZoneEvent(capacity changed) error = %v, want %v", err, ErrInvalidEvent)
+	}
+}
+
+func TestQueuePassReadyEvent(t *testing.T) {
+	expiresAt := time.Unix(1700000000, 0)
+	event, err := NewQueuePassReadyEvent("event-1", "user-1", "pass-1", expiresAt)
+	if err != nil {
+		t.Fatalf("NewQueuePassReadyEvent() error = %v", err)
+	}
+	if event.Topic() != "queue:pass:event-1:user-1" || !event.ExpiresAtTime().Equal(expiresAt) {
+		t.Errorf("Topic(), ExpiresAtTime() = %s, %s", event.Topic(), event.ExpiresAtTime())
+	}
+
+	// Clients read expires_at as Unix seconds
+	body, _ := json.Marshal(event)
+	var decoded map[string]any
+	_ = json.Unmarshal(body, &decoded)
+	if decoded["expires_at"] != float64(1700000000) {
+		t.Errorf("expires_at = %v, want Unix seconds", decoded["expires_at"])
+	}
+
+	if _, err := NewQueuePassReadyEvent("event-1", "user-1", "", expiresAt); !errors.Is(err, ErrInvalidEvent) {
+		t.Errorf("missing queue pass error = %v, want %v", err, ErrInvalidEvent)
+	}
+}
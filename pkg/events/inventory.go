@@ -0,0 +1,119 @@
+package events
+
+import "time"
+
+// Zone inventory events, published by ticket-service to zone-capacity-events. They share
+// a topic, keyed by zone, so the inventory worker applies a zone's events in order.
+const (
+	ZoneCreatedEventType         = "zone.created"
+	ZoneUpdatedEventType         = "zone.updated"
+	ZoneCapacityChangedEventType = "zone.capacity_changed"
+)
+
+// ZoneEvent is published when a zone is created or updated, so the inventory worker can
+// seed the zone's Redis counters before the first reservation
+type ZoneEvent struct {
+	EventID        string    `json:"event_id"`
+	EventType      string    `json:"event_type"`
+	ZoneID         string    `json:"zone_id"`
+	ShowID         string    `json:"show_id"`
+	TotalSeats     int       `json:"total_seats"`
+	AvailableSeats int       `json:"available_seats"`
+	HoldTTLSeconds *int      `json:"hold_ttl_seconds,omitempty"`
+	IsActive       bool      `json:"is_active"`
+	OnSale         bool      `json:"on_sale"` // Whether the show was on sale, i.e. the live counter may have holds
+	OccurredAt     time.Time `json:"occurred_at"`
+}
+
+// NewZoneEvent creates a zone created or updated event. The caller fills in the zone's
+// seats and settings.
+func NewZoneEvent(eventType, eventID, zoneID, showID string) (*ZoneEvent, error) {
+	event := &ZoneEvent{
+		EventID:    eventID,
+		EventType:  eventType,
+		ZoneID:     zoneID,
+		ShowID:     showID,
+		OccurredAt: now(),
+	}
+	if err := event.Validate(); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// Validate checks the event carries what consumers rely on
+func (e *ZoneEvent) Validate() error {
+	if e.EventType != ZoneCreatedEventType && e.EventType != ZoneUpdatedEventType {
+		return invalid(e.EventType, "unknown zone event type")
+	}
+	if e.TotalSeats < 0 || e.AvailableSeats < 0 {
+		return invalid(e.EventType, "seats must not be negative")
+	}
+	return require(e.EventType, "event_id", e.EventID, "zone_id", e.ZoneID)
+}
+
+// Topic returns the Kafka topic of zone events
+func (e *ZoneEvent) Topic() string {
+	return TopicZoneCapacityEvents
+}
+
+// Key returns the partition key of the event, the zone ID
+func (e *ZoneEvent) Key() string {
+	return e.ZoneID
+}
+
+// ZoneCapacityChangedEvent is published when an organizer changes the capacity of a zone
+// that is on sale. The inventory worker applies it to the live Redis counter, accounting
+// for in-flight holds, and then to seat_zones.
+type ZoneCapacityChangedEvent struct {
+	EventID       string    `json:"event_id"`
+	EventType     string    `json:"event_type"`
+	ZoneID        string    `json:"zone_id"`
+	ShowID        string    `json:"show_id"`
+	PreviousTotal int       `json:"previous_total"`
+	NewTotal      int       `json:"new_total"`
+	RequestedAt   time.Time `json:"requested_at"`
+}
+
+// NewZoneCapacityChangedEvent creates a capacity change event for a zone
+func NewZoneCapacityChangedEvent(eventID, zoneID, showID string, previousTotal, newTotal int) (*ZoneCapacityChangedEvent, error) {
+	event := &ZoneCapacityChangedEvent{
+		EventID:       eventID,
+		EventType:     ZoneCapacityChangedEventType,
+		ZoneID:        zoneID,
+		ShowID:        showID,
+		PreviousTotal: previousTotal,
+		NewTotal:      newTotal,
+		RequestedAt:   now(),
+	}
+	if err := event.Validate(); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// Validate checks the event carries what consumers rely on
+func (e *ZoneCapacityChangedEvent) Validate() error {
+	if e.EventType != ZoneCapacityChangedEventType {
+		return invalid(e.EventType, "unknown zone capacity event type")
+	}
+	if e.NewTotal < 0 {
+		return invalid(e.EventType, "new_total must not be negative")
+	}
+	return require(e.EventType, "event_id", e.EventID, "zone_id", e.ZoneID)
+}
+
+// Delta returns the change in total seats
+func (e *ZoneCapacityChangedEvent) Delta() int {
+	return e.NewTotal - e.PreviousTotal
+}
+
+// Topic returns the Kafka topic of zone capacity events
+func (e *ZoneCapacityChangedEvent) Topic() string {
+	return TopicZoneCapacityEvents
+}
+
+// Key returns the partition key of the event, the zone ID
+func (e *ZoneCapacityChangedEvent) Key() string {
+	return e.ZoneID
+}
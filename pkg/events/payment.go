@@ -0,0 +1,335 @@
+package events
+
+import "time"
+
+// PaymentEventType is the type of a payment lifecycle event
+type PaymentEventType string
+
+// Payment lifecycle events, published by payment-service to payment-events
+const (
+	PaymentEventCreated      PaymentEventType = "payment.created" // The customer started paying for the booking
+	PaymentEventSuccess      PaymentEventType = "payment.success"
+	PaymentEventFailed       PaymentEventType = "payment.failed"
+	PaymentEventRefunded     PaymentEventType = "payment.refunded"
+	PaymentEventRefundFailed PaymentEventType = "payment.refund_failed"
+
+	// Steps of the refund approval workflow, published as RefundRequestEvent
+	PaymentEventRefundRequested PaymentEventType = "payment.refund_requested"
+	PaymentEventRefundApproved  PaymentEventType = "payment.refund_approved"
+	PaymentEventRefundRejected  PaymentEventType = "payment.refund_rejected"
+)
+
+// PaymentEventTypes lists the types of PaymentEvent
+var PaymentEventTypes = []PaymentEventType{
+	PaymentEventCreated,
+	PaymentEventSuccess,
+	PaymentEventFailed,
+	PaymentEventRefunded,
+	PaymentEventRefundFailed,
+}
+
+// RefundRequestEventTypes lists the types of RefundRequestEvent. payment.refunded and
+// payment.refund_failed close a refund request as well as a saga refund.
+var RefundRequestEventTypes = []PaymentEventType{
+	PaymentEventRefundRequested,
+	PaymentEventRefundApproved,
+	PaymentEventRefundRejected,
+	PaymentEventRefunded,
+	PaymentEventRefundFailed,
+}
+
+// isOneOf returns true if t is in types
+func (t PaymentEventType) isOneOf(types []PaymentEventType) bool {
+	for _, known := range types {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// PaymentEvent is a payment lifecycle event
+type PaymentEvent struct {
+	EventID     string            `json:"event_id"`
+	EventType   PaymentEventType  `json:"event_type"`
+	OccurredAt  time.Time         `json:"occurred_at"`
+	Version     int               `json:"version"`
+	PaymentData *PaymentEventData `json:"data"`
+}
+
+// PaymentEventData is the payment as it stands after the change
+type PaymentEventData struct {
+	PaymentID        string    `json:"payment_id"`
+	BookingID        string    `json:"booking_id"`
+	TenantID         string    `json:"tenant_id,omitempty"`
+	UserID           string    `json:"user_id"`
+	Amount           float64   `json:"amount"`
+	Currency         string    `json:"currency"`
+	Status           string    `json:"status"`
+	Method           string    `json:"method"`
+	GatewayPaymentID string    `json:"gateway_payment_id,omitempty"`
+	ErrorCode        string    `json:"error_code,omitempty"`
+	ErrorMessage     string    `json:"error_message,omitempty"`
+	ProcessedAt      time.Time `json:"processed_at"`
+}
+
+// NewPaymentEvent creates a payment event. The booking is required; a failure to
+// create the payment has no payment ID yet.
+func NewPaymentEvent(eventType PaymentEventType, eventID string, data *PaymentEventData) (*PaymentEvent, error) {
+	event := &PaymentEvent{
+		EventID:     eventID,
+		EventType:   eventType,
+		OccurredAt:  now(),
+		Version:     Version,
+		PaymentData: data,
+	}
+	if err := event.Validate(); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// Validate checks the event carries what consumers rely on
+func (e *PaymentEvent) Validate() error {
+	if !e.EventType.isOneOf(PaymentEventTypes) {
+		return invalid(string(e.EventType), "unknown payment event type")
+	}
+	if e.PaymentData == nil {
+		return invalid(string(e.EventType), "data is required")
+	}
+	if err := require(string(e.EventType), "event_id", e.EventID, "booking_id", e.PaymentData.BookingID); err != nil {
+		return err
+	}
+	if e.EventType != PaymentEventFailed && e.PaymentData.PaymentID == "" {
+		return invalid(string(e.EventType), "payment_id is required")
+	}
+	return nil
+}
+
+// Topic returns the Kafka topic of payment events
+func (e *PaymentEvent) Topic() string {
+	return TopicPaymentEvents
+}
+
+// Key returns the partition key of the event, the booking ID, so a booking's payment
+// events are read in order
+func (e *PaymentEvent) Key() string {
+	if e.PaymentData != nil {
+		return e.PaymentData.BookingID
+	}
+	return e.EventID
+}
+
+// RefundRequestEvent is published to payment-events at each step of a refund request.
+// The payment.refunded and payment.refund_failed events carry the same fields as the
+// PaymentEvent ones, so tenant webhooks and refund batches read them alike.
+type RefundRequestEvent struct {
+	EventID    string                  `json:"event_id"`
+	EventType  PaymentEventType        `json:"event_type"`
+	OccurredAt time.Time               `json:"occurred_at"`
+	Version    int                     `json:"version"`
+	Data       *RefundRequestEventData `json:"data"`
+}
+
+// RefundRequestEventData is the refund request in the event
+type RefundRequestEventData struct {
+	RefundRequestID string    `json:"refund_request_id"`
+	PaymentID       string    `json:"payment_id"`
+	BookingID       string    `json:"booking_id"`
+	TenantID        string    `json:"tenant_id,omitempty"`
+	UserID          string    `json:"user_id,omitempty"`
+	Amount          float64   `json:"amount"`
+	Currency        string    `json:"currency"`
+	Status          string    `json:"status,omitempty"` // Payment status
+	RefundStatus    string    `json:"refund_status"`
+	Reason          string    `json:"reason,omitempty"`
+	RequestedBy     string    `json:"requested_by,omitempty"`
+	DecidedBy       string    `json:"decided_by,omitempty"`
+	DecisionReason  string    `json:"decision_reason,omitempty"`
+	ErrorMessage    string    `json:"error_message,omitempty"` // Set on payment.refund_failed
+	ProcessedAt     time.Time `json:"processed_at"`
+}
+
+// NewRefundRequestEvent creates an event for a step of a refund request
+func NewRefundRequestEvent(eventType PaymentEventType, eventID string, data *RefundRequestEventData) (*RefundRequestEvent, error) {
+	event := &RefundRequestEvent{
+		EventID:    eventID,
+		EventType:  eventType,
+		OccurredAt: now(),
+		Version:    Version,
+		Data:       data,
+	}
+	if err := event.Validate(); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// Validate checks the event carries what consumers rely on
+func (e *RefundRequestEvent) Validate() error {
+	if !e.EventType.isOneOf(RefundRequestEventTypes) {
+		return invalid(string(e.EventType), "unknown refund request event type")
+	}
+	if e.Data == nil {
+		return invalid(string(e.EventType), "data is required")
+	}
+	return require(string(e.EventType), "event_id", e.EventID, "refund_request_id", e.Data.RefundRequestID,
+		"payment_id", e.Data.PaymentID, "booking_id", e.Data.BookingID)
+}
+
+// Topic returns the Kafka topic of refund request events
+func (e *RefundRequestEvent) Topic() string {
+	return TopicPaymentEvents
+}
+
+// Key returns the partition key of the event, the booking ID
+func (e *RefundRequestEvent) Key() string {
+	if e.Data != nil {
+		return e.Data.BookingID
+	}
+	return e.EventID
+}
+
+// PaymentSuccessEventType is the event type of PaymentSuccessEvent
+const PaymentSuccessEventType = "payment.success"
+
+// PaymentSuccessEvent is published to payment.success when a payment succeeds, starting
+// the post-payment saga that confirms the booking. It carries booking details for the
+// notification service.
+type PaymentSuccessEvent struct {
+	EventType             string    `json:"event_type"`
+	BookingID             string    `json:"booking_id"`
+	PaymentID             string    `json:"payment_id"`
+	StripePaymentIntentID string    `json:"stripe_payment_intent_id"`
+	UserID                string    `json:"user_id,omitempty"`
+	Amount                int64     `json:"amount"` // In minor units
+	Currency              string    `json:"currency"`
+	Timestamp             time.Time `json:"timestamp"`
+
+	// Booking details for the notification service
+	UserEmail        string  `json:"user_email,omitempty"`
+	EventID          string  `json:"event_id,omitempty"`
+	EventName        string  `json:"event_name,omitempty"`
+	ShowID           string  `json:"show_id,omitempty"`
+	ShowDate         string  `json:"show_date,omitempty"`
+	ZoneID           string  `json:"zone_id,omitempty"`
+	ZoneName         string  `json:"zone_name,omitempty"`
+	Quantity         int     `json:"quantity,omitempty"`
+	UnitPrice        float64 `json:"unit_price,omitempty"`
+	TotalPrice       float64 `json:"total_price,omitempty"`
+	ConfirmationCode string  `json:"confirmation_code,omitempty"`
+	VenueName        string  `json:"venue_name,omitempty"`
+	VenueAddress     string  `json:"venue_address,omitempty"`
+}
+
+// NewPaymentSuccessEvent creates a payment success event for a booking. Callers add the
+// booking details they know before publishing it.
+func NewPaymentSuccessEvent(bookingID, paymentID, gatewayPaymentID, userID string, amount int64, currency string) (*PaymentSuccessEvent, error) {
+	event := &PaymentSuccessEvent{
+		EventType:             PaymentSuccessEventType,
+		BookingID:             bookingID,
+		PaymentID:             paymentID,
+		StripePaymentIntentID: gatewayPaymentID,
+		UserID:                userID,
+		Amount:                amount,
+		Currency:              currency,
+		Timestamp:             now(),
+	}
+	if err := event.Validate(); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// Validate checks the event carries what consumers rely on
+func (e *PaymentSuccessEvent) Validate() error {
+	if e.EventType != PaymentSuccessEventType {
+		return invalid(e.EventType, "unknown payment success event type")
+	}
+	return require(e.EventType, "booking_id", e.BookingID)
+}
+
+// Topic returns the Kafka topic of payment success events
+func (e *PaymentSuccessEvent) Topic() string {
+	return TopicPaymentSuccess
+}
+
+// Key returns the partition key of the event, the booking ID
+func (e *PaymentSuccessEvent) Key() string {
+	return e.BookingID
+}
+
+// SeatReleaseEventType is the event type of SeatReleaseEvent
+const SeatReleaseEventType = "seat_release"
+
+// SeatReleaseReason is why payment-service asks for a booking's seats to be released
+type SeatReleaseReason string
+
+const (
+	SeatReleaseReasonPaymentFailed   SeatReleaseReason = "payment_failed"
+	SeatReleaseReasonPaymentCanceled SeatReleaseReason = "payment_canceled"
+	// SeatReleaseReasonPaymentRefunded releases the seats of a payment refunded at the
+	// gateway. Unlike the other reasons it also applies to confirmed bookings.
+	SeatReleaseReasonPaymentRefunded SeatReleaseReason = "payment_refunded"
+)
+
+// IsValid returns true if r is a known reason
+func (r SeatReleaseReason) IsValid() bool {
+	switch r {
+	case SeatReleaseReasonPaymentFailed, SeatReleaseReasonPaymentCanceled, SeatReleaseReasonPaymentRefunded:
+		return true
+	}
+	return false
+}
+
+// SeatReleaseEvent is published to payment.seat-release when a booking's seats must be
+// released because its payment did not go through or was refunded
+type SeatReleaseEvent struct {
+	EventType   string            `json:"event_type"`
+	BookingID   string            `json:"booking_id"`
+	PaymentID   string            `json:"payment_id"`
+	UserID      string            `json:"user_id,omitempty"`
+	Reason      SeatReleaseReason `json:"reason"`
+	FailureCode string            `json:"failure_code,omitempty"`
+	Message     string            `json:"message,omitempty"`
+	Timestamp   time.Time         `json:"timestamp"`
+}
+
+// NewSeatReleaseEvent creates a seat release event for a booking
+func NewSeatReleaseEvent(bookingID, paymentID, userID string, reason SeatReleaseReason, failureCode, message string) (*SeatReleaseEvent, error) {
+	event := &SeatReleaseEvent{
+		EventType:   SeatReleaseEventType,
+		BookingID:   bookingID,
+		PaymentID:   paymentID,
+		UserID:      userID,
+		Reason:      reason,
+		FailureCode: failureCode,
+		Message:     message,
+		Timestamp:   now(),
+	}
+	if err := event.Validate(); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// Validate checks the event carries what consumers rely on
+func (e *SeatReleaseEvent) Validate() error {
+	if e.EventType != SeatReleaseEventType {
+		return invalid(e.EventType, "unknown seat release event type")
+	}
+	if !e.Reason.IsValid() {
+		return invalid(e.EventType, "unknown reason "+string(e.Reason))
+	}
+	return require(e.EventType, "booking_id", e.BookingID)
+}
+
+// Topic returns the Kafka topic of seat release events
+func (e *SeatReleaseEvent) Topic() string {
+	return TopicSeatRelease
+}
+
+// Key returns the partition key of the event, the booking ID
+func (e *SeatReleaseEvent) Key() string {
+	return e.BookingID
+}
@@ -0,0 +1,49 @@
+package events
+
+import "time"
+
+// QueuePassReadyEventType is the event type of QueuePassReadyEvent
+const QueuePassReadyEventType = "queue.pass_ready"
+
+// QueuePassReadyEvent is published on the user's QueuePassChannel when the virtual queue
+// releases them with a queue pass, ending their wait on the position stream
+type QueuePassReadyEvent struct {
+	EventType string `json:"event_type"`
+	UserID    string `json:"user_id"`
+	EventID   string `json:"event_id"`
+	QueuePass string `json:"queue_pass"`
+	ExpiresAt int64  `json:"expires_at"` // Unix timestamp
+}
+
+// NewQueuePassReadyEvent creates a queue pass ready event for a user
+func NewQueuePassReadyEvent(eventID, userID, queuePass string, expiresAt time.Time) (*QueuePassReadyEvent, error) {
+	event := &QueuePassReadyEvent{
+		EventType: QueuePassReadyEventType,
+		UserID:    userID,
+		EventID:   eventID,
+		QueuePass: queuePass,
+		ExpiresAt: expiresAt.Unix(),
+	}
+	if err := event.Validate(); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// Validate checks the event carries what consumers rely on
+func (e *QueuePassReadyEvent) Validate() error {
+	if e.EventType != QueuePassReadyEventType {
+		return invalid(e.EventType, "unknown queue event type")
+	}
+	return require(e.EventType, "user_id", e.UserID, "event_id", e.EventID, "queue_pass", e.QueuePass)
+}
+
+// Topic returns the Redis Pub/Sub channel of the event
+func (e *QueuePassReadyEvent) Topic() string {
+	return QueuePassChannel(e.EventID, e.UserID)
+}
+
+// ExpiresAtTime returns when the queue pass expires
+func (e *QueuePassReadyEvent) ExpiresAtTime() time.Time {
+	return time.Unix(e.ExpiresAt, 0)
+}
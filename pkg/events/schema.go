@@ -0,0 +1,119 @@
+package events
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// schemaDialect is the JSON Schema version of the exported schemas
+const schemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema returns the JSON Schema of the event's payload. Fields without omitempty are
+// required, and event_type is fixed to the entry's type.
+func (e Entry) Schema() map[string]any {
+	schema := schemaOf(reflect.TypeOf(e.Payload()))
+	schema["$schema"] = schemaDialect
+	schema["title"] = e.Type
+	schema["description"] = e.Description
+	if properties, ok := schema["properties"].(map[string]any); ok {
+		if _, ok := properties["event_type"]; ok {
+			properties["event_type"] = map[string]any{"const": e.Type}
+		}
+	}
+	return schema
+}
+
+// ExportedEvent is an event type of the catalog in the schema export
+type ExportedEvent struct {
+	Type        string         `json:"type"`
+	Domain      Domain         `json:"domain"`
+	Topic       string         `json:"topic"`
+	Publisher   string         `json:"publisher"`
+	Description string         `json:"description"`
+	Schema      map[string]any `json:"schema"`
+}
+
+// WriteSchemas writes the catalog with the JSON Schema of each event type, for consumers
+// outside the Go services such as the notification service
+func WriteSchemas(w io.Writer) error {
+	exported := make([]ExportedEvent, 0, len(catalog))
+	for _, entry := range catalog {
+		exported = append(exported, ExportedEvent{
+			Type:        entry.Type,
+			Domain:      entry.Domain,
+			Topic:       entry.Topic,
+			Publisher:   entry.Publisher,
+			Description: entry.Description,
+			Schema:      entry.Schema(),
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(map[string]any{"events": exported})
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaOf returns the JSON Schema of values of type t as encoding/json marshals them
+func schemaOf(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": schemaOf(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaOf(t.Elem())}
+	case reflect.Struct:
+		return structSchema(t)
+	default:
+		return map[string]any{}
+	}
+}
+
+// structSchema returns the JSON Schema of a struct from its json tags
+func structSchema(t reflect.Type) map[string]any {
+	properties := make(map[string]any)
+	required := []string{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := schemaOf(field.Type)
+		if field.Type.Kind() == reflect.Pointer {
+			// encoding/json writes null for nil pointers without omitempty
+			property = map[string]any{"anyOf": []any{property, map[string]any{"type": "null"}}}
+		}
+		properties[name] = property
+		if !strings.Contains(options, "omitempty") {
+			required = append(required, name)
+		}
+	}
+	return map[string]any{"type": "object", "properties": properties, "required": required}
+}
@@ -0,0 +1,211 @@
+package i18n
+
+// Notification templates. Each has "<template>.subject" and "<template>.body" messages.
+const (
+	TemplateVerificationCodeEmail = "notification.verification_code.email"
+	TemplateVerificationCodeSMS   = "notification.verification_code.sms"
+	TemplateStandbyOffer          = "notification.standby_offer"
+	TemplateQueueTurn             = "notification.queue_turn"
+	TemplatePaymentDue            = "notification.payment_due"
+	TemplateIdleReservation       = "notification.idle_reservation"
+	TemplateBookingConfirmed      = "notification.booking_confirmed"
+	TemplateSalesReport           = "notification.sales_report"
+)
+
+// catalogs holds the messages of each locale, keyed by error code or message key.
+// Error codes match the "code" field of error responses; a code missing from a
+// locale falls back to English.
+var catalogs = map[Locale]map[string]string{
+	English: {
+		// Common errors
+		"BAD_REQUEST":          "The request is invalid",
+		"INVALID_REQUEST":      "The request is invalid",
+		"VALIDATION_FAILED":    "Validation failed",
+		"VALIDATION_ERROR":     "Validation failed",
+		"UNAUTHORIZED":         "Authentication required",
+		"FORBIDDEN":            "Access denied",
+		"NOT_FOUND":            "Resource not found",
+		"METHOD_NOT_ALLOWED":   "Method not allowed",
+		"CONFLICT":             "The request conflicts with the current state of the resource",
+		"UNPROCESSABLE_ENTITY": "The request could not be processed",
+		"TOO_MANY_REQUESTS":    "Too many requests, please try again later",
+		"INTERNAL_ERROR":       "An internal error occurred",
+		"SERVICE_UNAVAILABLE":  "Service temporarily unavailable",
+
+		// Authentication
+		"INVALID_TOKEN":                   "Invalid or malformed token",
+		"TOKEN_EXPIRED":                   "Your session has expired, please sign in again",
+		"MISSING_TOKEN":                   "Authentication required",
+		"INVALID_CREDENTIALS":             "Invalid email or password",
+		"USER_EXISTS":                     "User with this email already exists",
+		"USER_INACTIVE":                   "User account is inactive",
+		"INVALID_EMAIL":                   "Invalid email address",
+		"WEAK_PASSWORD":                   "Password is too weak",
+		"INVALID_CODE":                    "The verification code is incorrect",
+		"CHALLENGE_EXPIRED":               "The verification code has expired, please request a new one",
+		"CHALLENGE_LOCKED":                "Too many incorrect attempts, please request a new code",
+		"VERIFICATION_CHALLENGE_REQUIRED": "Please verify your account before continuing",
+		"OAUTH_PROVIDER_NOT_CONFIGURED":   "Sign-in with this provider is not available",
+		"INVALID_OAUTH_STATE":             "The sign-in expired or was not started here, please start again",
+		"OAUTH_EXCHANGE_FAILED":           "The provider did not confirm the sign-in, please start again",
+		"OAUTH_EMAIL_REQUIRED":            "Allow access to your email address to sign in with this provider",
+		"OAUTH_ACCOUNT_EXISTS":            "An account with this email exists, sign in with your password and link the provider from your account",
+		"OAUTH_IDENTITY_LINKED":           "This provider account is linked to another user",
+
+		// Booking
+		"INSUFFICIENT_STOCK":       "Insufficient stock available",
+		"INSUFFICIENT_SEATS":       "Not enough seats available",
+		"NO_CONTIGUOUS_SEATS":      "Not enough seats available next to each other",
+		"MAX_TICKETS_EXCEEDED":     "You have reached the maximum number of tickets for this event",
+		"MAX_LIMIT_REACHED":        "Limit reached",
+		"BOOKING_EXPIRED":          "The booking has expired",
+		"EXPIRED":                  "The reservation has expired",
+		"ALREADY_CONFIRMED":        "The booking is already confirmed",
+		"ALREADY_RELEASED":         "The booking has already been released",
+		"PAYMENT_FAILED":           "Payment failed",
+		"DUPLICATE_ENTRY":          "The entry already exists",
+		"RESOURCE_LOCKED":          "The resource is locked, please try again",
+		"REQUEST_IN_PROGRESS":      "The request is already being processed",
+		"IDEMPOTENCY_KEY_REUSED":   "The idempotency key was used for a different request",
+		"MODIFICATION_NOT_ALLOWED": "This booking can no longer be changed",
+		"MODIFICATION_IN_PROGRESS": "Another change to this booking is in progress, please try again",
+		"SHOW_STARTED":             "Changes are closed for this show",
+		"ASSIGNED_SEATS":           "Bookings with assigned seats cannot be changed",
+
+		// Virtual queue
+		"QUEUE_REQUIRED":         "High traffic detected. Please join the queue first.",
+		"QUEUE_PASS_REQUIRED":    "A queue pass is required",
+		"QUEUE_PASS_EXPIRED":     "Your queue pass has expired, please rejoin the queue",
+		"QUEUE_PASS_REVOKED":     "Your queue pass is no longer valid, please rejoin the queue",
+		"QUEUE_PASS_MISMATCH":    "The queue pass does not belong to this event",
+		"INVALID_QUEUE_PASS":     "Invalid queue pass",
+		"QUEUE_NOT_OPEN":         "The queue is not open yet",
+		"QUEUE_FULL":             "The queue is full, please try again later",
+		"NOT_IN_QUEUE":           "You are not in the queue",
+		"NOT_ON_STANDBY":         "You are not on the standby list",
+		"STANDBY_OFFER_MISMATCH": "The seats held for you do not match this request",
+
+		// Gateway
+		"ROUTE_NOT_FOUND":                   "No route configured for this path",
+		"SERVICE_NOT_CONFIGURED":            "Backend service not configured",
+		"GATEWAY_TIMEOUT":                   "Backend service timed out",
+		"BAD_GATEWAY":                       "Backend service unavailable",
+		"MAINTENANCE_MODE":                  "The service is undergoing scheduled maintenance. Please try again later.",
+		"TOO_MANY_REQUESTS.retry_after":     "Rate limit exceeded. Please retry after {seconds} second(s).",
+		"TOO_MANY_REQUESTS.server_capacity": "Server is at capacity. Please retry in a moment.",
+		"QUOTA_EXCEEDED":                    "Your plan's daily {metric} quota has been used up. It resets at {reset}.",
+
+		// Notifications
+		TemplateVerificationCodeEmail + ".subject": "Your Booking Rush verification code",
+		TemplateVerificationCodeEmail + ".body":    "Your verification code is {code}. It expires in {minutes} minutes. If you did not request it, you can ignore this email.",
+		TemplateVerificationCodeSMS + ".body":      "Booking Rush code: {code} (expires in {minutes} min)",
+		TemplateStandbyOffer + ".subject":          "Seats are being held for you",
+		TemplateStandbyOffer + ".body":             "{quantity} seat(s) are being held for you until {expires_at}. Complete your booking before then to keep them.",
+		TemplateQueueTurn + ".subject":             "It's your turn",
+		TemplateQueueTurn + ".body":                "You can book now. Your place is held until {expires_at}.",
+		TemplatePaymentDue + ".subject":            "Complete your payment",
+		TemplatePaymentDue + ".body":               "Your {quantity} seat(s) are reserved until {expires_at}. Pay before then to keep them.",
+		TemplateIdleReservation + ".subject":       "Still want your seats?",
+		TemplateIdleReservation + ".body":          "Your {quantity} seat(s) are held until {expires_at}. If you no longer need them, release them for other fans: {release_url}",
+		TemplateBookingConfirmed + ".subject":      "Booking confirmed",
+		TemplateBookingConfirmed + ".body":         "Your booking is confirmed. Confirmation code: {confirmation_code}",
+		TemplateSalesReport + ".subject":           "Your sales summary for {from} - {to}",
+		TemplateSalesReport + ".body":              "{events} event(s): {bookings} bookings, {tickets} tickets sold, {revenue} {currency} revenue and {refunds} refunds. Unsubscribe from these reports: {unsubscribe_url}",
+	},
+	Thai: {
+		// Common errors
+		"BAD_REQUEST":          "คำขอไม่ถูกต้อง",
+		"INVALID_REQUEST":      "คำขอไม่ถูกต้อง",
+		"VALIDATION_FAILED":    "ข้อมูลไม่ถูกต้อง",
+		"VALIDATION_ERROR":     "ข้อมูลไม่ถูกต้อง",
+		"UNAUTHORIZED":         "กรุณาเข้าสู่ระบบ",
+		"FORBIDDEN":            "คุณไม่มีสิทธิ์เข้าถึง",
+		"NOT_FOUND":            "ไม่พบข้อมูลที่ต้องการ",
+		"METHOD_NOT_ALLOWED":   "ไม่รองรับวิธีการเรียกนี้",
+		"CONFLICT":             "คำขอขัดแย้งกับสถานะปัจจุบันของข้อมูล",
+		"UNPROCESSABLE_ENTITY": "ไม่สามารถดำเนินการตามคำขอได้",
+		"TOO_MANY_REQUESTS":    "มีคำขอมากเกินไป กรุณาลองใหม่ภายหลัง",
+		"INTERNAL_ERROR":       "เกิดข้อผิดพลาดภายในระบบ",
+		"SERVICE_UNAVAILABLE":  "บริการไม่พร้อมใช้งานชั่วคราว",
+
+		// Authentication
+		"INVALID_TOKEN":                   "โทเค็นไม่ถูกต้อง",
+		"TOKEN_EXPIRED":                   "เซสชันหมดอายุ กรุณาเข้าสู่ระบบอีกครั้ง",
+		"MISSING_TOKEN":                   "กรุณาเข้าสู่ระบบ",
+		"INVALID_CREDENTIALS":             "อีเมลหรือรหัสผ่านไม่ถูกต้อง",
+		"USER_EXISTS":                     "อีเมลนี้ถูกใช้งานแล้ว",
+		"USER_INACTIVE":                   "บัญชีผู้ใช้ถูกระงับการใช้งาน",
+		"INVALID_EMAIL":                   "อีเมลไม่ถูกต้อง",
+		"WEAK_PASSWORD":                   "รหัสผ่านไม่ปลอดภัยพอ",
+		"INVALID_CODE":                    "รหัสยืนยันไม่ถูกต้อง",
+		"CHALLENGE_EXPIRED":               "รหัสยืนยันหมดอายุ กรุณาขอรหัสใหม่",
+		"CHALLENGE_LOCKED":                "กรอกรหัสผิดหลายครั้งเกินไป กรุณาขอรหัสใหม่",
+		"VERIFICATION_CHALLENGE_REQUIRED": "กรุณายืนยันบัญชีก่อนดำเนินการต่อ",
+		"OAUTH_PROVIDER_NOT_CONFIGURED":   "ไม่สามารถเข้าสู่ระบบด้วยผู้ให้บริการนี้ได้",
+		"INVALID_OAUTH_STATE":             "การเข้าสู่ระบบหมดอายุหรือไม่ได้เริ่มจากที่นี่ กรุณาเริ่มใหม่อีกครั้ง",
+		"OAUTH_EXCHANGE_FAILED":           "ผู้ให้บริการไม่ยืนยันการเข้าสู่ระบบ กรุณาเริ่มใหม่อีกครั้ง",
+		"OAUTH_EMAIL_REQUIRED":            "กรุณาอนุญาตให้เข้าถึงอีเมลเพื่อเข้าสู่ระบบด้วยผู้ให้บริการนี้",
+		"OAUTH_ACCOUNT_EXISTS":            "อีเมลนี้มีบัญชีอยู่แล้ว กรุณาเข้าสู่ระบบด้วยรหัสผ่านแล้วเชื่อมต่อผู้ให้บริการจากหน้าบัญชี",
+		"OAUTH_IDENTITY_LINKED":           "บัญชีผู้ให้บริการนี้เชื่อมต่อกับผู้ใช้อื่นแล้ว",
+
+		// Booking
+		"INSUFFICIENT_STOCK":       "จำนวนคงเหลือไม่เพียงพอ",
+		"INSUFFICIENT_SEATS":       "ที่นั่งว่างไม่เพียงพอ",
+		"NO_CONTIGUOUS_SEATS":      "ไม่มีที่นั่งติดกันเพียงพอ",
+		"MAX_TICKETS_EXCEEDED":     "คุณซื้อบัตรครบจำนวนสูงสุดสำหรับงานนี้แล้ว",
+		"MAX_LIMIT_REACHED":        "ถึงขีดจำกัดแล้ว",
+		"BOOKING_EXPIRED":          "การจองหมดอายุแล้ว",
+		"EXPIRED":                  "การจองที่นั่งหมดเวลาแล้ว",
+		"ALREADY_CONFIRMED":        "การจองนี้ได้รับการยืนยันแล้ว",
+		"ALREADY_RELEASED":         "การจองนี้ถูกยกเลิกไปแล้ว",
+		"PAYMENT_FAILED":           "การชำระเงินไม่สำเร็จ",
+		"DUPLICATE_ENTRY":          "มีข้อมูลนี้อยู่แล้ว",
+		"RESOURCE_LOCKED":          "ข้อมูลกำลังถูกใช้งาน กรุณาลองใหม่",
+		"REQUEST_IN_PROGRESS":      "คำขอนี้กำลังดำเนินการอยู่",
+		"IDEMPOTENCY_KEY_REUSED":   "Idempotency key นี้ถูกใช้กับคำขออื่นแล้ว",
+		"MODIFICATION_NOT_ALLOWED": "ไม่สามารถแก้ไขการจองนี้ได้แล้ว",
+		"MODIFICATION_IN_PROGRESS": "การจองนี้กำลังถูกแก้ไขอยู่ กรุณาลองใหม่",
+		"SHOW_STARTED":             "ปิดรับการแก้ไขการจองสำหรับรอบการแสดงนี้แล้ว",
+		"ASSIGNED_SEATS":           "ไม่สามารถแก้ไขการจองที่มีการระบุที่นั่งได้",
+
+		// Virtual queue
+		"QUEUE_REQUIRED":         "มีผู้ใช้งานจำนวนมาก กรุณาเข้าคิวก่อน",
+		"QUEUE_PASS_REQUIRED":    "ต้องมีบัตรคิวก่อนทำรายการ",
+		"QUEUE_PASS_EXPIRED":     "บัตรคิวหมดอายุ กรุณาเข้าคิวใหม่",
+		"QUEUE_PASS_REVOKED":     "บัตรคิวใช้งานไม่ได้แล้ว กรุณาเข้าคิวใหม่",
+		"QUEUE_PASS_MISMATCH":    "บัตรคิวไม่ตรงกับงานนี้",
+		"INVALID_QUEUE_PASS":     "บัตรคิวไม่ถูกต้อง",
+		"QUEUE_NOT_OPEN":         "คิวยังไม่เปิด",
+		"QUEUE_FULL":             "คิวเต็มแล้ว กรุณาลองใหม่ภายหลัง",
+		"NOT_IN_QUEUE":           "คุณไม่ได้อยู่ในคิว",
+		"NOT_ON_STANDBY":         "คุณไม่ได้อยู่ในรายชื่อสำรอง",
+		"STANDBY_OFFER_MISMATCH": "ที่นั่งที่กันไว้ให้คุณไม่ตรงกับคำขอนี้",
+
+		// Gateway
+		"ROUTE_NOT_FOUND":                   "ไม่พบเส้นทางที่ร้องขอ",
+		"SERVICE_NOT_CONFIGURED":            "ยังไม่ได้ตั้งค่าบริการปลายทาง",
+		"GATEWAY_TIMEOUT":                   "บริการปลายทางตอบสนองช้าเกินไป",
+		"BAD_GATEWAY":                       "บริการปลายทางไม่พร้อมใช้งาน",
+		"MAINTENANCE_MODE":                  "ระบบอยู่ระหว่างการปรับปรุงตามกำหนด กรุณาลองใหม่ภายหลัง",
+		"TOO_MANY_REQUESTS.retry_after":     "มีคำขอมากเกินไป กรุณาลองใหม่ในอีก {seconds} วินาที",
+		"TOO_MANY_REQUESTS.server_capacity": "ระบบมีผู้ใช้งานเต็ม กรุณาลองใหม่อีกครู่",
+		"QUOTA_EXCEEDED":                    "โควตา {metric} รายวันของแพ็กเกจคุณหมดแล้ว จะรีเซ็ตเมื่อ {reset}",
+
+		// Notifications
+		TemplateVerificationCodeEmail + ".subject": "รหัสยืนยัน Booking Rush ของคุณ",
+		TemplateVerificationCodeEmail + ".body":    "รหัสยืนยันของคุณคือ {code} รหัสจะหมดอายุใน {minutes} นาที หากคุณไม่ได้ขอรหัสนี้ โปรดเพิกเฉยต่ออีเมลฉบับนี้",
+		TemplateVerificationCodeSMS + ".body":      "รหัส Booking Rush: {code} (หมดอายุใน {minutes} นาที)",
+		TemplateStandbyOffer + ".subject":          "มีที่นั่งกันไว้ให้คุณแล้ว",
+		TemplateStandbyOffer + ".body":             "เรากันที่นั่งไว้ให้คุณ {quantity} ที่ ถึง {expires_at} กรุณาทำการจองให้เสร็จก่อนเวลาดังกล่าว",
+		TemplateQueueTurn + ".subject":             "ถึงคิวของคุณแล้ว",
+		TemplateQueueTurn + ".body":                "คุณสามารถจองได้แล้ว สิทธิ์ของคุณจะถูกเก็บไว้ถึง {expires_at}",
+		TemplatePaymentDue + ".subject":            "กรุณาชำระเงินให้เสร็จสิ้น",
+		TemplatePaymentDue + ".body":               "ที่นั่ง {quantity} ที่ของคุณถูกจองไว้ถึง {expires_at} กรุณาชำระเงินก่อนเวลาดังกล่าวเพื่อรักษาที่นั่ง",
+		TemplateIdleReservation + ".subject":       "ยังต้องการที่นั่งอยู่หรือไม่",
+		TemplateIdleReservation + ".body":          "ที่นั่ง {quantity} ที่ของคุณถูกจองไว้ถึง {expires_at} หากไม่ต้องการแล้ว สามารถคืนที่นั่งให้ผู้อื่นได้ที่ {release_url}",
+		TemplateBookingConfirmed + ".subject":      "ยืนยันการจองแล้ว",
+		TemplateBookingConfirmed + ".body":         "การจองของคุณได้รับการยืนยันแล้ว รหัสยืนยัน: {confirmation_code}",
+		TemplateSalesReport + ".subject":           "สรุปยอดขาย {from} - {to}",
+		TemplateSalesReport + ".body":              "{events} งาน: จอง {bookings} รายการ ขายได้ {tickets} ใบ รายได้ {revenue} {currency} คืนเงิน {refunds} รายการ ยกเลิกการรับรายงานได้ที่ {unsubscribe_url}",
+	},
+}
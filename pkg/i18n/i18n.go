@@ -0,0 +1,160 @@
+package i18n
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Locale is a supported language tag, e.g. "en" or "th"
+type Locale string
+
+const (
+	English Locale = "en"
+	Thai    Locale = "th"
+
+	// DefaultLocale is used when the client accepts none of the supported locales.
+	// Services write their messages in English, so it is also the catalog's source language.
+	DefaultLocale = English
+)
+
+// HeaderLocale carries the locale negotiated by the API gateway to backend services
+const HeaderLocale = "X-Locale"
+
+// Supported lists the locales with a message catalog
+var Supported = []Locale{English, Thai}
+
+// String returns the language tag
+func (l Locale) String() string {
+	return string(l)
+}
+
+// IsSupported reports whether the locale has a message catalog
+func (l Locale) IsSupported() bool {
+	_, ok := catalogs[l]
+	return ok
+}
+
+// Parse returns the supported locale matching a language tag such as "th" or "th-TH".
+// Returns false if the tag's language has no catalog.
+func Parse(tag string) (Locale, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	locale := Locale(tag)
+	return locale, locale.IsSupported()
+}
+
+// Negotiate picks the supported locale the client prefers most from an Accept-Language
+// header value, e.g. "th-TH,th;q=0.9,en;q=0.8". Returns DefaultLocale if none matches.
+func Negotiate(acceptLanguage string) Locale {
+	type candidate struct {
+		locale Locale
+		q      float64
+		order  int
+	}
+
+	var candidates []candidate
+	for i, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		if strings.TrimSpace(tag) == "*" {
+			candidates = append(candidates, candidate{locale: DefaultLocale, q: q, order: i})
+			continue
+		}
+		if locale, ok := Parse(tag); ok {
+			candidates = append(candidates, candidate{locale: locale, q: q, order: i})
+		}
+	}
+	if len(candidates) == 0 {
+		return DefaultLocale
+	}
+
+	// Highest q wins; ties go to the tag listed first
+	sort.SliceStable(candidates, func(a, b int) bool {
+		return candidates[a].q > candidates[b].q
+	})
+	return candidates[0].locale
+}
+
+// localeKey is the context key for the request locale
+type localeKey struct{}
+
+// WithLocale returns a context carrying the locale
+func WithLocale(ctx context.Context, locale Locale) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// FromContext returns the locale of the request, or DefaultLocale if none was set
+func FromContext(ctx context.Context) Locale {
+	if locale, ok := ctx.Value(localeKey{}).(Locale); ok {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// Lookup returns the message for code in locale, falling back to DefaultLocale.
+// Returns false if neither catalog has the code.
+func Lookup(locale Locale, code string) (string, bool) {
+	if msg, ok := catalogs[locale][code]; ok {
+		return msg, true
+	}
+	msg, ok := catalogs[DefaultLocale][code]
+	return msg, ok
+}
+
+// T returns the message for code in locale, or fallback if the code has no message
+func T(locale Locale, code, fallback string) string {
+	if msg, ok := Lookup(locale, code); ok {
+		return msg
+	}
+	return fallback
+}
+
+// Render returns the message for code in locale with its {placeholders} replaced by params
+func Render(locale Locale, code string, params map[string]string) string {
+	msg, _ := Lookup(locale, code)
+	if len(params) == 0 {
+		return msg
+	}
+
+	pairs := make([]string, 0, len(params)*2)
+	for name, value := range params {
+		pairs = append(pairs, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(msg)
+}
+
+// Notification is a notification template rendered in the recipient's locale
+type Notification struct {
+	Template string `json:"template"`
+	Locale   Locale `json:"locale"`
+	Subject  string `json:"subject,omitempty"`
+	Body     string `json:"body"`
+}
+
+// RenderNotification renders the template's subject and body messages
+// ("<template>.subject" and "<template>.body") in locale
+func RenderNotification(locale Locale, template string, params map[string]string) *Notification {
+	if !locale.IsSupported() {
+		locale = DefaultLocale
+	}
+	return &Notification{
+		Template: template,
+		Locale:   locale,
+		Subject:  Render(locale, template+".subject", params),
+		Body:     Render(locale, template+".body", params),
+	}
+}
@@ -0,0 +1,101 @@
+package i18n
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		header string
+		want   Locale
+	}{
+		{"", English},
+		{"th", Thai},
+		{"th-TH,th;q=0.9,en;q=0.8", Thai},
+		{"en-US,en;q=0.9,th;q=0.8", English},
+		{"fr-FR,th;q=0.5", Thai},
+		{"th;q=0.2,en;q=0.7", English},
+		{"de,fr", English},
+		{"th;q=0", English},
+		{"*", English},
+	}
+
+	for _, tt := range tests {
+		if got := Negotiate(tt.header); got != tt.want {
+			t.Errorf("Negotiate(%q) = %s, want %s", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestCatalogsCoverEnglish(t *testing.T) {
+	for locale, catalog := range catalogs {
+		for code := range catalog {
+			if _, ok := catalogs[English][code]; !ok {
+				t.Errorf("%s message %q has no English source", locale, code)
+			}
+		}
+	}
+	for code := range catalogs[English] {
+		if _, ok := catalogs[Thai][code]; !ok {
+			t.Errorf("Thai catalog is missing %q", code)
+		}
+	}
+}
+
+func TestLookupFallsBackToEnglish(t *testing.T) {
+	if msg, ok := Lookup(Locale("fr"), "NOT_FOUND"); !ok || msg != "Resource not found" {
+		t.Errorf("Expected English fallback, got %q (%v)", msg, ok)
+	}
+	if got := T(Thai, "SOMETHING_NEW", "fallback"); got != "fallback" {
+		t.Errorf("Expected fallback for unknown code, got %q", got)
+	}
+}
+
+func TestRenderNotification(t *testing.T) {
+	n := RenderNotification(Thai, TemplateVerificationCodeSMS, map[string]string{"code": "123456", "minutes": "10"})
+	if n.Locale != Thai || n.Body != "รหัส Booking Rush: 123456 (หมดอายุใน 10 นาที)" {
+		t.Errorf("Unexpected notification %+v", n)
+	}
+
+	n = RenderNotification(Locale("fr"), TemplateStandbyOffer, map[string]string{"quantity": "2", "expires_at": "20:00"})
+	if n.Locale != English || n.Subject != "Seats are being held for you" {
+		t.Errorf("Expected English notification for unsupported locale, got %+v", n)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Middleware())
+	var got Locale
+	router.GET("/", func(c *gin.Context) {
+		got = FromContext(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	// The gateway's negotiated locale wins over the client's header
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(HeaderLocale, "th")
+	req.Header.Set("Accept-Language", "en")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if got != Thai || w.Header().Get("Content-Language") != "th" {
+		t.Errorf("Expected th, got %s (Content-Language %q)", got, w.Header().Get("Content-Language"))
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Language", "th-TH")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	if got != Thai {
+		t.Errorf("Expected Accept-Language to be negotiated, got %s", got)
+	}
+
+	if FromContext(context.Background()) != DefaultLocale {
+		t.Error("Expected default locale without a request locale")
+	}
+}
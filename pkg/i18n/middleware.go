@@ -0,0 +1,30 @@
+package i18n
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// ContextKeyLocale is the gin context key holding the request locale
+const ContextKeyLocale = "locale"
+
+// RequestLocale returns the locale of a request: the X-Locale header set by the gateway
+// if it names a supported locale, otherwise the negotiated Accept-Language
+func RequestLocale(c *gin.Context) Locale {
+	if locale, ok := Parse(c.GetHeader(HeaderLocale)); ok {
+		return locale
+	}
+	return Negotiate(c.GetHeader("Accept-Language"))
+}
+
+// Middleware stores the request locale in the gin and request contexts, so services and
+// notifications triggered by the request can use FromContext
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locale := RequestLocale(c)
+		c.Set(ContextKeyLocale, locale)
+		c.Request = c.Request.WithContext(WithLocale(c.Request.Context(), locale))
+		c.Header("Content-Language", locale.String())
+		c.Writer.Header().Add("Vary", "Accept-Language")
+		c.Next()
+	}
+}
@@ -0,0 +1,174 @@
+package id
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewGenerator(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         *Config
+		wantVersion uuid.Version
+		wantErr     bool
+	}{
+		{"default", nil, 7, false},
+		{"uuidv7", &Config{Strategy: StrategyUUIDv7}, 7, false},
+		{"uuidv4", &Config{Strategy: StrategyUUIDv4}, 4, false},
+		{"snowflake", &Config{Strategy: StrategySnowflake, NodeID: 3}, 8, false},
+		{"node out of range", &Config{Strategy: StrategySnowflake, NodeID: MaxNodeID + 1}, 0, true},
+		{"unknown", &Config{Strategy: "ulid"}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := NewGenerator(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewGenerator() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			// Every strategy fits the existing UUID columns
+			u, err := uuid.Parse(g.NewID())
+			if err != nil {
+				t.Fatalf("NewID() is not a UUID: %v", err)
+			}
+			if u.Version() != tt.wantVersion || u.Variant() != uuid.RFC4122 {
+				t.Errorf("NewID() = %s, want a version %d RFC 9562 UUID", u, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("ID_STRATEGY", "Snowflake")
+	t.Setenv("ID_NODE_ID", "12")
+	g, err := FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv() error = %v", err)
+	}
+	if s, ok := g.(*Snowflake); !ok || s.node != 12 {
+		t.Errorf("FromEnv() = %T, want a snowflake generator for node 12", g)
+	}
+
+	t.Setenv("ID_NODE_ID", "twelve")
+	if _, err := FromEnv(); err == nil {
+		t.Error("FromEnv() accepted a malformed node id")
+	}
+}
+
+func TestTimeOrderedIDsSort(t *testing.T) {
+	snowflake, _ := NewSnowflake(1)
+	for name, g := range map[string]Generator{"uuidv7": UUIDv7{}, "snowflake": snowflake} {
+		t.Run(name, func(t *testing.T) {
+			ids := make([]string, 2000)
+			for i := range ids {
+				ids[i] = g.NewID()
+				if i%500 == 0 {
+					time.Sleep(2 * time.Millisecond)
+				}
+			}
+			if !sort.StringsAreSorted(ids) {
+				// UUIDv7 is only ordered across milliseconds; within one the random bits decide
+				if name == "snowflake" {
+					t.Fatal("snowflake IDs are not in generation order")
+				}
+				first, last := ids[0], ids[len(ids)-1]
+				if first >= last {
+					t.Errorf("first ID %s sorts after last %s", first, last)
+				}
+			}
+		})
+	}
+}
+
+func TestSnowflake_Unique(t *testing.T) {
+	s, _ := NewSnowflake(7)
+
+	var mu sync.Mutex
+	seen := make(map[int64]bool)
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 2000; i++ {
+				sf := s.Next()
+				mu.Lock()
+				if seen[sf] {
+					t.Errorf("duplicate snowflake %d", sf)
+				}
+				seen[sf] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSnowflake_ClockMovesBack(t *testing.T) {
+	s, _ := NewSnowflake(0)
+	start := SnowflakeEpoch.Add(time.Hour)
+	// The clock steps back a millisecond for a few reads before catching up
+	readings := []time.Duration{0, -time.Millisecond, -time.Millisecond, 0, time.Millisecond}
+	s.now = func() time.Time {
+		reading := readings[0]
+		if len(readings) > 1 {
+			readings = readings[1:]
+		}
+		return start.Add(reading)
+	}
+
+	first := s.Next()
+	if second := s.Next(); second <= first {
+		t.Errorf("Next() = %d after %d, want increasing when the clock moves back", second, first)
+	}
+}
+
+func TestTime(t *testing.T) {
+	before := time.Now().Add(-time.Millisecond)
+
+	s, _ := NewSnowflake(2)
+	for name, id := range map[string]string{"uuidv7": UUIDv7{}.NewID(), "snowflake": s.NewID()} {
+		got, ok := Time(id)
+		if !ok || got.Before(before) || got.After(time.Now().Add(time.Millisecond)) {
+			t.Errorf("Time(%s %s) = %v, %v, want about now", name, id, got, ok)
+		}
+	}
+
+	// Keys of existing rows carry no time
+	if _, ok := Time(UUIDv4{}.NewID()); ok {
+		t.Error("Time(uuidv4) reported a time")
+	}
+	if _, ok := Time("not-an-id"); ok {
+		t.Error("Time(invalid) reported a time")
+	}
+}
+
+func TestSnowflakeUUID_RoundTrip(t *testing.T) {
+	for _, sf := range []int64{0, 1, 1 << 22, 1<<63 - 1} {
+		got, ok := snowflakeFromUUID(SnowflakeUUID(sf))
+		if !ok || got != sf {
+			t.Errorf("snowflakeFromUUID(SnowflakeUUID(%d)) = %d, %v", sf, got, ok)
+		}
+	}
+}
+
+func TestSetDefault(t *testing.T) {
+	defer SetDefault(nil)
+
+	SetDefault(GeneratorFunc(func() string { return "fixed" }))
+	if got := New(); got != "fixed" {
+		t.Errorf("New() = %s, want the configured generator", got)
+	}
+
+	SetDefault(nil)
+	if u, err := uuid.Parse(New()); err != nil || u.Version() != 7 {
+		t.Errorf("New() after reset = %v, %v, want a UUIDv7", u, err)
+	}
+}
@@ -0,0 +1,120 @@
+package id
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Snowflake layout: 41 bits of milliseconds since SnowflakeEpoch, 10 bits of node ID and
+// 12 bits of sequence, which lasts until 2093 at up to 4096 IDs per millisecond per node
+const (
+	snowflakeNodeBits     = 10
+	snowflakeSequenceBits = 12
+
+	// MaxNodeID is the largest snowflake node ID
+	MaxNodeID = 1<<snowflakeNodeBits - 1
+
+	maxSequence = 1<<snowflakeSequenceBits - 1
+)
+
+// SnowflakeEpoch is the start of snowflake time
+var SnowflakeEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Snowflake generates snowflake IDs that increase strictly for one node
+type Snowflake struct {
+	mu       sync.Mutex
+	node     int64
+	lastMs   int64
+	sequence int64
+	now      func() time.Time
+}
+
+// NewSnowflake creates a snowflake generator for a node
+func NewSnowflake(node int64) (*Snowflake, error) {
+	if node < 0 || node > MaxNodeID {
+		return nil, fmt.Errorf("snowflake node id must be 0 to %d, got %d", MaxNodeID, node)
+	}
+	return &Snowflake{node: node, now: time.Now}, nil
+}
+
+// Next returns the next snowflake. When the sequence of a millisecond runs out, or the
+// clock moves backwards, it waits for the clock to pass the last millisecond used, so
+// IDs never repeat.
+func (s *Snowflake) Next() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ms := s.now().Sub(SnowflakeEpoch).Milliseconds()
+	if ms < s.lastMs {
+		ms = s.waitFor(s.lastMs)
+	}
+	if ms == s.lastMs {
+		s.sequence = (s.sequence + 1) & maxSequence
+		if s.sequence == 0 {
+			ms = s.waitFor(s.lastMs + 1)
+		}
+	} else {
+		s.sequence = 0
+	}
+	s.lastMs = ms
+
+	return ms<<(snowflakeNodeBits+snowflakeSequenceBits) | s.node<<snowflakeSequenceBits | s.sequence
+}
+
+// waitFor sleeps until the clock reaches a millisecond and returns the current one
+func (s *Snowflake) waitFor(target int64) int64 {
+	for {
+		ms := s.now().Sub(SnowflakeEpoch).Milliseconds()
+		if ms >= target {
+			return ms
+		}
+		time.Sleep(time.Duration(target-ms) * time.Millisecond)
+	}
+}
+
+// NewID returns the next snowflake as a UUIDv8
+func (s *Snowflake) NewID() string {
+	return SnowflakeUUID(s.Next()).String()
+}
+
+// SnowflakeUUID carries a snowflake in an RFC 9562 UUIDv8. The 64 bits are spread over
+// the bytes around the version and variant bits, most significant first, so the UUIDs
+// sort in snowflake order.
+func SnowflakeUUID(sf int64) uuid.UUID {
+	var u uuid.UUID
+	v := uint64(sf)
+	// Bits 63-16 in bytes 0-5
+	u[0] = byte(v >> 56)
+	u[1] = byte(v >> 48)
+	u[2] = byte(v >> 40)
+	u[3] = byte(v >> 32)
+	u[4] = byte(v >> 24)
+	u[5] = byte(v >> 16)
+	// Version 8 and bits 15-12
+	u[6] = 0x80 | byte(v>>12)&0x0f
+	// Bits 11-4
+	u[7] = byte(v >> 4)
+	// Variant 10 and bits 3-0
+	u[8] = 0x80 | byte(v)&0x0f
+	return u
+}
+
+// snowflakeFromUUID reads the snowflake back from a UUIDv8 made by SnowflakeUUID
+func snowflakeFromUUID(u uuid.UUID) (int64, bool) {
+	if u.Version() != 8 || u.Variant() != uuid.RFC4122 || binary.BigEndian.Uint64(u[8:])&^(0xff<<56) != 0 {
+		return 0, false
+	}
+	v := uint64(u[0])<<56 | uint64(u[1])<<48 | uint64(u[2])<<40 | uint64(u[3])<<32 |
+		uint64(u[4])<<24 | uint64(u[5])<<16 |
+		uint64(u[6]&0x0f)<<12 | uint64(u[7])<<4 | uint64(u[8]&0x0f)
+	return int64(v), true
+}
+
+// SnowflakeTime returns when a snowflake was generated
+func SnowflakeTime(sf int64) time.Time {
+	return SnowflakeEpoch.Add(time.Duration(sf>>(snowflakeNodeBits+snowflakeSequenceBits)) * time.Millisecond)
+}
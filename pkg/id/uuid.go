@@ -0,0 +1,50 @@
+package id
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UUIDv7 generates time-ordered RFC 9562 UUIDv7 IDs
+type UUIDv7 struct{}
+
+// NewID returns a new UUIDv7
+func (UUIDv7) NewID() string {
+	u, err := uuid.NewV7()
+	if err != nil {
+		// Only fails when the random source does, which uuid.New would panic on too
+		return uuid.New().String()
+	}
+	return u.String()
+}
+
+// UUIDv4 generates random RFC 9562 UUIDv4 IDs, the format used before time-ordered IDs
+type UUIDv4 struct{}
+
+// NewID returns a new UUIDv4
+func (UUIDv4) NewID() string {
+	return uuid.New().String()
+}
+
+// Time returns when a time-ordered ID was generated. It reports false for IDs without a
+// timestamp, such as UUIDv4 keys of rows created before the switch.
+func Time(s string) (time.Time, bool) {
+	u, err := uuid.Parse(s)
+	if err != nil {
+		return time.Time{}, false
+	}
+	switch u.Version() {
+	case 7:
+		sec, nsec := u.Time().UnixTime()
+		return time.Unix(sec, nsec).UTC(), true
+	case 8:
+		sf, ok := snowflakeFromUUID(u)
+		if !ok {
+			return time.Time{}, false
+		}
+		return SnowflakeTime(sf), true
+	default:
+		return time.Time{}, false
+	}
+}
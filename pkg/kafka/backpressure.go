@@ -0,0 +1,157 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// HealthProbe reports whether the downstream dependencies of a consumer can take work.
+// A consumer with a probe stops fetching while the probe fails and resumes once it passes.
+type HealthProbe interface {
+	// Healthy returns nil when records can be processed, or why they cannot
+	Healthy(ctx context.Context) error
+}
+
+// HealthProbeFunc adapts a function, such as a database or Redis ping, to a HealthProbe
+type HealthProbeFunc func(ctx context.Context) error
+
+// Healthy calls f
+func (f HealthProbeFunc) Healthy(ctx context.Context) error {
+	return f(ctx)
+}
+
+// AllHealthy combines probes; it fails with the first probe that fails
+func AllHealthy(probes ...HealthProbe) HealthProbe {
+	return HealthProbeFunc(func(ctx context.Context) error {
+		for _, probe := range probes {
+			if err := probe.Healthy(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ErrDownstreamUnhealthy is returned by an ErrorRateProbe whose error rate is over its threshold
+var ErrDownstreamUnhealthy = errors.New("downstream error rate is over threshold")
+
+// ErrorRateProbeConfig contains configuration for an error rate probe
+type ErrorRateProbeConfig struct {
+	// Window is how far back outcomes are counted
+	Window time.Duration
+	// Threshold is the share of failed calls (0-1) at which the probe fails
+	Threshold float64
+	// MinSamples is how many calls the window needs before the probe can fail
+	MinSamples int
+}
+
+// DefaultErrorRateProbeConfig fails when half of at least 20 calls in 30 seconds failed
+func DefaultErrorRateProbeConfig() *ErrorRateProbeConfig {
+	return &ErrorRateProbeConfig{
+		Window:     30 * time.Second,
+		Threshold:  0.5,
+		MinSamples: 20,
+	}
+}
+
+// errorRateBuckets is how many slices the window is counted in
+const errorRateBuckets = 10
+
+// errorRateBucket counts the outcomes of one slice of the window
+type errorRateBucket struct {
+	start  time.Time
+	total  int
+	failed int
+}
+
+// ErrorRateProbe is a HealthProbe that fails while too many recent downstream calls
+// (database writes, Redis scripts) failed. Outcomes are reported with Record.
+// While a consumer is paused no new outcomes arrive, so the probe recovers once the
+// failures age out of the window; if the outage is not over, the next failures trip it again.
+type ErrorRateProbe struct {
+	name       string
+	window     time.Duration
+	threshold  float64
+	minSamples int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	buckets [errorRateBuckets]errorRateBucket
+}
+
+// NewErrorRateProbe creates an error rate probe; name identifies the downstream in errors
+func NewErrorRateProbe(name string, cfg *ErrorRateProbeConfig) *ErrorRateProbe {
+	defaults := DefaultErrorRateProbeConfig()
+	p := &ErrorRateProbe{
+		name:       name,
+		window:     defaults.Window,
+		threshold:  defaults.Threshold,
+		minSamples: defaults.MinSamples,
+		now:        time.Now,
+	}
+	if cfg != nil {
+		if cfg.Window > 0 {
+			p.window = cfg.Window
+		}
+		if cfg.Threshold > 0 {
+			p.threshold = cfg.Threshold
+		}
+		if cfg.MinSamples > 0 {
+			p.minSamples = cfg.MinSamples
+		}
+	}
+	return p
+}
+
+// Record counts the outcome of a downstream call; a nil error is a success
+func (p *ErrorRateProbe) Record(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	b := p.bucket(p.now())
+	b.total++
+	if err != nil {
+		b.failed++
+	}
+}
+
+// Healthy fails with ErrDownstreamUnhealthy while the error rate is over the threshold
+func (p *ErrorRateProbe) Healthy(ctx context.Context) error {
+	total, failed := p.Counts()
+	if total < p.minSamples || total == 0 {
+		return nil
+	}
+	if rate := float64(failed) / float64(total); rate >= p.threshold {
+		return fmt.Errorf("%w: %s failed %d of %d calls (%.0f%%)", ErrDownstreamUnhealthy, p.name, failed, total, rate*100)
+	}
+	return nil
+}
+
+// Counts returns the calls and failed calls within the window
+func (p *ErrorRateProbe) Counts() (total, failed int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	cutoff := p.now().Add(-p.window)
+	for _, b := range p.buckets {
+		if b.start.After(cutoff) {
+			total += b.total
+			failed += b.failed
+		}
+	}
+	return total, failed
+}
+
+// bucket returns the bucket for t, resetting it if it last counted an older slice
+func (p *ErrorRateProbe) bucket(t time.Time) *errorRateBucket {
+	width := p.window / errorRateBuckets
+	start := t.Truncate(width)
+	b := &p.buckets[(start.UnixNano()/int64(width))%errorRateBuckets]
+	if !b.start.Equal(start) {
+		*b = errorRateBucket{start: start}
+	}
+	return b
+}
@@ -0,0 +1,114 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+func TestErrorRateProbe(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	probe := NewErrorRateProbe("postgres", &ErrorRateProbeConfig{
+		Window:     10 * time.Second,
+		Threshold:  0.5,
+		MinSamples: 4,
+	})
+	probe.now = func() time.Time { return now }
+	ctx := context.Background()
+	dbErr := errors.New("connection refused")
+
+	// Too few calls to judge
+	probe.Record(dbErr)
+	probe.Record(dbErr)
+	if err := probe.Healthy(ctx); err != nil {
+		t.Fatalf("Healthy() = %v, want nil below MinSamples", err)
+	}
+
+	probe.Record(nil)
+	probe.Record(dbErr)
+	if err := probe.Healthy(ctx); !errors.Is(err, ErrDownstreamUnhealthy) {
+		t.Fatalf("Healthy() = %v, want ErrDownstreamUnhealthy at 75%% errors", err)
+	}
+
+	// Failures age out of the window
+	now = now.Add(11 * time.Second)
+	if total, failed := probe.Counts(); total != 0 || failed != 0 {
+		t.Errorf("Counts() = %d, %d, want the window to be empty", total, failed)
+	}
+	if err := probe.Healthy(ctx); err != nil {
+		t.Errorf("Healthy() = %v, want nil once failures aged out", err)
+	}
+
+	for i := 0; i < 4; i++ {
+		probe.Record(nil)
+	}
+	probe.Record(dbErr)
+	if err := probe.Healthy(ctx); err != nil {
+		t.Errorf("Healthy() = %v, want nil at 20%% errors", err)
+	}
+}
+
+func TestAllHealthy(t *testing.T) {
+	down := errors.New("redis down")
+	probe := AllHealthy(
+		HealthProbeFunc(func(ctx context.Context) error { return nil }),
+		HealthProbeFunc(func(ctx context.Context) error { return down }),
+	)
+	if err := probe.Healthy(context.Background()); !errors.Is(err, down) {
+		t.Errorf("Healthy() = %v, want %v", err, down)
+	}
+	if err := AllHealthy().Healthy(context.Background()); err != nil {
+		t.Errorf("Healthy() with no probes = %v, want nil", err)
+	}
+}
+
+func TestConsumer_PausesWhileUnhealthy(t *testing.T) {
+	// The client is never connected; pausing and resuming are local
+	client, err := kgo.NewClient(kgo.SeedBrokers("localhost:9092"), kgo.ConsumeTopics("test-topic"))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer client.Close()
+
+	var healthErr error
+	c := &Consumer{
+		client:         client,
+		topics:         []string{"test-topic"},
+		health:         HealthProbeFunc(func(ctx context.Context) error { return healthErr }),
+		healthInterval: time.Millisecond,
+	}
+	ctx := context.Background()
+
+	if !c.checkHealth(ctx) || c.Paused() {
+		t.Fatal("Expected consumption to run while healthy")
+	}
+
+	healthErr = ErrDownstreamUnhealthy
+	time.Sleep(2 * time.Millisecond)
+	if c.checkHealth(ctx) || !c.Paused() {
+		t.Fatal("Expected consumption to pause while unhealthy")
+	}
+	if paused := client.PauseFetchTopics(); len(paused) != 1 {
+		t.Errorf("Paused topics = %v, want [test-topic]", paused)
+	}
+
+	start := time.Now()
+	if records, err := c.Poll(ctx); err != nil || records != nil {
+		t.Errorf("Poll() = %v, %v, want no records while paused", records, err)
+	}
+	if time.Since(start) < time.Millisecond {
+		t.Error("Poll() should wait a health check interval while paused")
+	}
+
+	healthErr = nil
+	time.Sleep(2 * time.Millisecond)
+	if !c.checkHealth(ctx) || c.Paused() {
+		t.Fatal("Expected consumption to resume once healthy")
+	}
+	if paused := client.PauseFetchTopics(); len(paused) != 0 {
+		t.Errorf("Paused topics = %v, want none", paused)
+	}
+}
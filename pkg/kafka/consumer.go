@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
 	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
 	"github.com/twmb/franz-go/pkg/kgo"
 	"go.opentelemetry.io/otel/trace"
@@ -16,19 +17,30 @@ type Consumer struct {
 	client *kgo.Client
 	mu     sync.RWMutex
 	closed bool
+
+	topics         []string
+	health         HealthProbe
+	healthInterval time.Duration
+	healthMu       sync.Mutex
+	lastCheck      time.Time
+	paused         bool
 }
 
 // ConsumerConfig contains configuration for the Kafka consumer
 type ConsumerConfig struct {
-	Brokers         []string
-	GroupID         string
-	Topics          []string
-	ClientID        string
-	MaxRetries      int
-	RetryInterval   time.Duration
-	SessionTimeout  time.Duration
+	Brokers          []string
+	GroupID          string
+	Topics           []string
+	ClientID         string
+	MaxRetries       int
+	RetryInterval    time.Duration
+	SessionTimeout   time.Duration
 	RebalanceTimeout time.Duration
-	AutoCommit      bool
+	AutoCommit       bool
+	// Health pauses fetching while downstream dependencies fail (optional)
+	Health HealthProbe
+	// HealthCheckInterval is how often Health is checked (default 5s)
+	HealthCheckInterval time.Duration
 }
 
 // NewConsumer creates a new Kafka consumer
@@ -106,12 +118,58 @@ func NewConsumer(ctx context.Context, cfg *ConsumerConfig) (*Consumer, error) {
 		return nil, fmt.Errorf("failed to create kafka consumer after %d retries: %w", maxRetries, err)
 	}
 
+	healthInterval := cfg.HealthCheckInterval
+	if healthInterval <= 0 {
+		healthInterval = 5 * time.Second
+	}
+
 	return &Consumer{
-		client: client,
+		client:         client,
+		topics:         cfg.Topics,
+		health:         cfg.Health,
+		healthInterval: healthInterval,
 	}, nil
 }
 
-// Poll fetches records from Kafka
+// Paused reports whether fetching is paused because the health probe failed
+func (c *Consumer) Paused() bool {
+	c.healthMu.Lock()
+	defer c.healthMu.Unlock()
+	return c.paused
+}
+
+// checkHealth runs the health probe at most once per interval, pausing the topics when
+// it fails and resuming them when it passes again. It reports whether to keep fetching.
+// The group session is kept while paused, so partitions are not rebalanced away.
+func (c *Consumer) checkHealth(ctx context.Context) bool {
+	if c.health == nil {
+		return true
+	}
+
+	c.healthMu.Lock()
+	defer c.healthMu.Unlock()
+
+	if time.Since(c.lastCheck) < c.healthInterval {
+		return !c.paused
+	}
+	c.lastCheck = time.Now()
+
+	err := c.health.Healthy(ctx)
+	switch {
+	case err != nil && !c.paused:
+		c.client.PauseFetchTopics(c.topics...)
+		c.paused = true
+		logger.Get().Warn(fmt.Sprintf("Pausing consumption of %v: %v", c.topics, err))
+	case err == nil && c.paused:
+		c.client.ResumeFetchTopics(c.topics...)
+		c.paused = false
+		logger.Get().Info(fmt.Sprintf("Resuming consumption of %v: downstream healthy", c.topics))
+	}
+	return !c.paused
+}
+
+// Poll fetches records from Kafka. While the health probe fails it waits one
+// health check interval and returns no records instead.
 func (c *Consumer) Poll(ctx context.Context) ([]*Record, error) {
 	c.mu.RLock()
 	if c.closed {
@@ -120,6 +178,14 @@ func (c *Consumer) Poll(ctx context.Context) ([]*Record, error) {
 	}
 	c.mu.RUnlock()
 
+	if !c.checkHealth(ctx) {
+		select {
+		case <-ctx.Done():
+		case <-time.After(c.healthInterval):
+		}
+		return nil, nil
+	}
+
 	fetches := c.client.PollFetches(ctx)
 	if errs := fetches.Errors(); len(errs) > 0 {
 		// Return first error
@@ -130,25 +196,30 @@ func (c *Consumer) Poll(ctx context.Context) ([]*Record, error) {
 
 	var records []*Record
 	fetches.EachRecord(func(r *kgo.Record) {
-		headers := make(map[string]string)
-		for _, h := range r.Headers {
-			headers[h.Key] = string(h.Value)
-		}
-
-		records = append(records, &Record{
-			Topic:     r.Topic,
-			Partition: r.Partition,
-			Offset:    r.Offset,
-			Key:       r.Key,
-			Value:     r.Value,
-			Headers:   headers,
-			Timestamp: r.Timestamp,
-		})
+		records = append(records, newRecord(r))
 	})
 
 	return records, nil
 }
 
+// newRecord converts a fetched record
+func newRecord(r *kgo.Record) *Record {
+	headers := make(map[string]string)
+	for _, h := range r.Headers {
+		headers[h.Key] = string(h.Value)
+	}
+
+	return &Record{
+		Topic:     r.Topic,
+		Partition: r.Partition,
+		Offset:    r.Offset,
+		Key:       r.Key,
+		Value:     r.Value,
+		Headers:   headers,
+		Timestamp: r.Timestamp,
+	}
+}
+
 // Record represents a consumed Kafka record
 type Record struct {
 	Topic     string
@@ -0,0 +1,276 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+// Special ListOffsets timestamps
+const (
+	listOffsetsLatest   = -1
+	listOffsetsEarliest = -2
+)
+
+// ReplayConfig contains configuration for replaying topics
+type ReplayConfig struct {
+	Brokers  []string
+	Topics   []string
+	ClientID string
+	// Until stops each partition before its first record timestamped at or after
+	// this time; zero replays up to the partitions' current end
+	Until time.Time
+	// IdleTimeout ends a replay that received no records for this long while
+	// partitions are short of their end, e.g. at a compacted tail (default 10s)
+	IdleTimeout time.Duration
+	Timeout     time.Duration // Per admin request (default 10s)
+}
+
+// ReplayStats summarizes a replay
+type ReplayStats struct {
+	Records     int       `json:"records"`
+	Partitions  int       `json:"partitions"` // Partitions with records to replay
+	FirstRecord time.Time `json:"first_record,omitempty"`
+	LastRecord  time.Time `json:"last_record,omitempty"`
+	// Unfinished names the partitions ("topic/partition") IdleTimeout cut short
+	Unfinished []string `json:"unfinished,omitempty"`
+}
+
+// offsetRange is the offsets [Start, End) of a partition to replay
+type offsetRange struct {
+	Start int64
+	End   int64
+}
+
+// Replay reads the retained records of the topics produced before cfg.Until and
+// calls fn with each, in offset order within a partition. No consumer group is
+// joined, so the offsets of live consumers are untouched. Replay stops at the
+// first error fn returns.
+func Replay(ctx context.Context, cfg *ReplayConfig, fn func(*Record) error) (*ReplayStats, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("replay config is required")
+	}
+	if len(cfg.Brokers) == 0 {
+		return nil, fmt.Errorf("at least one broker is required")
+	}
+	if len(cfg.Topics) == 0 {
+		return nil, fmt.Errorf("at least one topic is required")
+	}
+	if cfg.IdleTimeout <= 0 {
+		cfg.IdleTimeout = 10 * time.Second
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+
+	opts := []kgo.Opt{kgo.SeedBrokers(cfg.Brokers...)}
+	if cfg.ClientID != "" {
+		opts = append(opts, kgo.ClientID(cfg.ClientID))
+	}
+
+	admin, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka admin client: %w", err)
+	}
+	ranges, err := replayRanges(ctx, admin, cfg)
+	admin.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &ReplayStats{}
+	partitions := make(map[string]map[int32]kgo.Offset)
+	for topic, byPartition := range ranges {
+		partitions[topic] = make(map[int32]kgo.Offset)
+		for partition, r := range byPartition {
+			partitions[topic][partition] = kgo.NewOffset().At(r.Start)
+			stats.Partitions++
+		}
+	}
+	if stats.Partitions == 0 {
+		return stats, nil
+	}
+
+	client, err := kgo.NewClient(append(opts, kgo.ConsumePartitions(partitions))...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka replay client: %w", err)
+	}
+	defer client.Close()
+
+	remaining := stats.Partitions
+	for remaining > 0 {
+		pollCtx, cancel := context.WithTimeout(ctx, cfg.IdleTimeout)
+		fetches := client.PollFetches(pollCtx)
+		cancel()
+
+		if ctx.Err() != nil {
+			return stats, ctx.Err()
+		}
+		if fetches.Empty() && pollCtx.Err() != nil {
+			// Nothing arrived for IdleTimeout: the records left are not coming
+			stats.Unfinished = unfinishedPartitions(ranges)
+			return stats, nil
+		}
+		for _, fetchErr := range fetches.Errors() {
+			if errors.Is(fetchErr.Err, context.DeadlineExceeded) || errors.Is(fetchErr.Err, context.Canceled) {
+				continue
+			}
+			return stats, fmt.Errorf("replay error on topic %s partition %d: %w", fetchErr.Topic, fetchErr.Partition, fetchErr.Err)
+		}
+
+		var fnErr error
+		fetches.EachRecord(func(r *kgo.Record) {
+			if fnErr != nil {
+				return
+			}
+			byPartition := ranges[r.Topic]
+			pr, ok := byPartition[r.Partition]
+			if !ok {
+				return // Partition already replayed to its end
+			}
+			if r.Offset < pr.End {
+				if fnErr = fn(newRecord(r)); fnErr != nil {
+					return
+				}
+				stats.Records++
+				if stats.FirstRecord.IsZero() || r.Timestamp.Before(stats.FirstRecord) {
+					stats.FirstRecord = r.Timestamp
+				}
+				if r.Timestamp.After(stats.LastRecord) {
+					stats.LastRecord = r.Timestamp
+				}
+			}
+			if r.Offset >= pr.End-1 {
+				delete(byPartition, r.Partition)
+				remaining--
+			}
+		})
+		if fnErr != nil {
+			return stats, fnErr
+		}
+	}
+	return stats, nil
+}
+
+// replayRanges returns the offsets to replay of every partition of the topics,
+// leaving out partitions with nothing retained before cfg.Until
+func replayRanges(ctx context.Context, client adminClient, cfg *ReplayConfig) (map[string]map[int32]offsetRange, error) {
+	partitions, err := topicPartitions(ctx, client, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	earliest, err := listOffsets(ctx, client, cfg, partitions, listOffsetsEarliest)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := listOffsets(ctx, client, cfg, partitions, listOffsetsLatest)
+	if err != nil {
+		return nil, err
+	}
+	var until map[string]map[int32]int64
+	if !cfg.Until.IsZero() {
+		if until, err = listOffsets(ctx, client, cfg, partitions, cfg.Until.UnixMilli()); err != nil {
+			return nil, err
+		}
+	}
+
+	ranges := make(map[string]map[int32]offsetRange)
+	for topic, ids := range partitions {
+		ranges[topic] = make(map[int32]offsetRange)
+		for _, partition := range ids {
+			r := offsetRange{Start: earliest[topic][partition], End: latest[topic][partition]}
+			// -1 means no record is timestamped at or after Until: replay them all
+			if offset, ok := until[topic][partition]; ok && offset >= 0 && offset < r.End {
+				r.End = offset
+			}
+			if r.Start < r.End {
+				ranges[topic][partition] = r
+			}
+		}
+	}
+	return ranges, nil
+}
+
+// topicPartitions returns the partition IDs of the topics, failing on a missing topic
+func topicPartitions(ctx context.Context, client adminClient, cfg *ReplayConfig) (map[string][]int32, error) {
+	req := kmsg.NewPtrMetadataRequest()
+	for _, topic := range cfg.Topics {
+		req.Topics = append(req.Topics, kmsg.MetadataRequestTopic{Topic: kmsg.StringPtr(topic)})
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+	resp, err := client.Request(reqCtx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe kafka topics: %w", err)
+	}
+
+	partitions := make(map[string][]int32)
+	for _, topic := range resp.(*kmsg.MetadataResponse).Topics {
+		if topic.Topic == nil {
+			continue
+		}
+		if err := kerr.ErrorForCode(topic.ErrorCode); err != nil {
+			return nil, fmt.Errorf("failed to describe kafka topic %s: %w", *topic.Topic, err)
+		}
+		for _, p := range topic.Partitions {
+			partitions[*topic.Topic] = append(partitions[*topic.Topic], p.Partition)
+		}
+	}
+	return partitions, nil
+}
+
+// listOffsets returns the offset of every partition at timestamp, one of the
+// special listOffsets timestamps or milliseconds since the epoch
+func listOffsets(ctx context.Context, client adminClient, cfg *ReplayConfig, partitions map[string][]int32, timestamp int64) (map[string]map[int32]int64, error) {
+	req := kmsg.NewPtrListOffsetsRequest()
+	for topic, ids := range partitions {
+		reqTopic := kmsg.NewListOffsetsRequestTopic()
+		reqTopic.Topic = topic
+		for _, id := range ids {
+			reqPartition := kmsg.NewListOffsetsRequestTopicPartition()
+			reqPartition.Partition = id
+			reqPartition.Timestamp = timestamp
+			reqTopic.Partitions = append(reqTopic.Partitions, reqPartition)
+		}
+		req.Topics = append(req.Topics, reqTopic)
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+	resp, err := client.Request(reqCtx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list kafka offsets: %w", err)
+	}
+
+	offsets := make(map[string]map[int32]int64)
+	for _, topic := range resp.(*kmsg.ListOffsetsResponse).Topics {
+		offsets[topic.Topic] = make(map[int32]int64)
+		for _, p := range topic.Partitions {
+			if err := kerr.ErrorForCode(p.ErrorCode); err != nil {
+				return nil, fmt.Errorf("failed to list offsets of kafka topic %s partition %d: %w", topic.Topic, p.Partition, err)
+			}
+			offsets[topic.Topic][p.Partition] = p.Offset
+		}
+	}
+	return offsets, nil
+}
+
+// unfinishedPartitions names the partitions left in ranges as "topic/partition"
+func unfinishedPartitions(ranges map[string]map[int32]offsetRange) []string {
+	var names []string
+	for topic, byPartition := range ranges {
+		for partition := range byPartition {
+			names = append(names, fmt.Sprintf("%s/%d", topic, partition))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
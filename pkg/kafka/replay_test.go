@@ -0,0 +1,99 @@
+package kafka
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+// fakeOffsetsClient answers list offsets requests from per-partition record timestamps
+type fakeOffsetsClient struct {
+	*fakeAdminClient
+	start      map[string][]int64       // First retained offset by partition
+	timestamps map[string][][]time.Time // Timestamps of retained records by partition
+}
+
+func (f *fakeOffsetsClient) Request(ctx context.Context, req kmsg.Request) (kmsg.Response, error) {
+	listReq, ok := req.(*kmsg.ListOffsetsRequest)
+	if !ok {
+		return f.fakeAdminClient.Request(ctx, req)
+	}
+
+	resp := kmsg.NewPtrListOffsetsResponse()
+	for _, t := range listReq.Topics {
+		topic := kmsg.ListOffsetsResponseTopic{Topic: t.Topic}
+		for _, p := range t.Partitions {
+			start := f.start[t.Topic][p.Partition]
+			records := f.timestamps[t.Topic][p.Partition]
+			offset := int64(-1)
+			switch p.Timestamp {
+			case listOffsetsEarliest:
+				offset = start
+			case listOffsetsLatest:
+				offset = start + int64(len(records))
+			default:
+				for i, ts := range records {
+					if ts.UnixMilli() >= p.Timestamp {
+						offset = start + int64(i)
+						break
+					}
+				}
+			}
+			topic.Partitions = append(topic.Partitions, kmsg.ListOffsetsResponseTopicPartition{Partition: p.Partition, Offset: offset})
+		}
+		resp.Topics = append(resp.Topics, topic)
+	}
+	return resp, nil
+}
+
+func TestReplayRanges(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	client := &fakeOffsetsClient{
+		fakeAdminClient: &fakeAdminClient{topics: map[string]*fakeTopic{
+			"booking-events": {partitions: 3, replicas: 1},
+		}},
+		start: map[string][]int64{"booking-events": {0, 100, 7}},
+		timestamps: map[string][][]time.Time{"booking-events": {
+			{base, base.Add(time.Minute), base.Add(2 * time.Minute)},
+			{base.Add(-time.Hour)},
+			{}, // Everything retained was deleted
+		}},
+	}
+
+	tests := []struct {
+		name  string
+		until time.Time
+		want  map[int32]offsetRange
+	}{
+		{"to the end", time.Time{}, map[int32]offsetRange{0: {0, 3}, 1: {100, 101}}},
+		{"stops before until", base.Add(time.Minute), map[int32]offsetRange{0: {0, 1}, 1: {100, 101}}},
+		{"until before every record", base.Add(-2 * time.Hour), map[int32]offsetRange{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranges, err := replayRanges(context.Background(), client, &ReplayConfig{
+				Topics:  []string{"booking-events"},
+				Until:   tt.until,
+				Timeout: time.Second,
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := ranges["booking-events"]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected ranges %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestReplayRanges_MissingTopic(t *testing.T) {
+	client := &fakeOffsetsClient{fakeAdminClient: &fakeAdminClient{topics: map[string]*fakeTopic{}}}
+
+	_, err := replayRanges(context.Background(), client, &ReplayConfig{Topics: []string{"booking-events"}, Timeout: time.Second})
+	if err == nil {
+		t.Fatal("Expected an error for a missing topic")
+	}
+}
@@ -0,0 +1,390 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	"go.uber.org/zap"
+)
+
+// retentionConfig is the topic config holding TopicSpec.Retention
+const retentionConfig = "retention.ms"
+
+// TopicSpec declares a topic a service depends on
+type TopicSpec struct {
+	Name              string
+	Partitions        int32             // 0 uses TopicManagerConfig.Partitions
+	ReplicationFactor int16             // 0 uses TopicManagerConfig.ReplicationFactor
+	Retention         time.Duration     // 0 keeps the broker default
+	Configs           map[string]string // Extra topic configs, e.g. "cleanup.policy"
+}
+
+// desiredConfigs returns the topic configs the spec sets explicitly
+func (s TopicSpec) desiredConfigs() map[string]string {
+	configs := make(map[string]string, len(s.Configs)+1)
+	for name, value := range s.Configs {
+		configs[name] = value
+	}
+	if s.Retention > 0 {
+		configs[retentionConfig] = strconv.FormatInt(s.Retention.Milliseconds(), 10)
+	}
+	return configs
+}
+
+// TopicManagerConfig contains configuration for the topic manager
+type TopicManagerConfig struct {
+	Brokers           []string
+	ClientID          string
+	Partitions        int32 // Default partitions of specs that don't set them
+	ReplicationFactor int16 // Default replication factor of specs that don't set it
+	// AutoCreate creates missing topics; otherwise they are only reported
+	AutoCreate bool
+	Timeout    time.Duration // Per admin request
+}
+
+// TopicDrift describes a setting of an existing topic that differs from its spec
+type TopicDrift struct {
+	Topic   string
+	Setting string // "partitions", "replication_factor" or a config name
+	Want    string
+	Got     string
+}
+
+// String returns the drift as "topic setting: want X, got Y"
+func (d TopicDrift) String() string {
+	return fmt.Sprintf("%s %s: want %s, got %s", d.Topic, d.Setting, d.Want, d.Got)
+}
+
+// TopicReport is the outcome of TopicManager.Ensure
+type TopicReport struct {
+	Created []string     // Topics created by this run
+	Missing []string     // Topics that don't exist and were not created
+	Drift   []TopicDrift // Existing topics whose settings differ from their spec
+}
+
+// Err returns an error naming the missing topics, or nil if every topic exists
+func (r *TopicReport) Err() error {
+	if len(r.Missing) == 0 {
+		return nil
+	}
+	return fmt.Errorf("kafka topics missing: %s (set KAFKA_AUTO_CREATE_TOPICS=true to create them)", strings.Join(r.Missing, ", "))
+}
+
+// adminClient sends admin requests; satisfied by *kgo.Client
+type adminClient interface {
+	Request(ctx context.Context, req kmsg.Request) (kmsg.Response, error)
+	Close()
+}
+
+// TopicManager creates the topics services depend on and checks existing topics
+// against their declared settings. Drift is reported, never altered: changing
+// partitions or retention of a live topic is left to an operator.
+type TopicManager struct {
+	config *TopicManagerConfig
+	client adminClient
+}
+
+// NewTopicManager creates a topic manager connected to the configured brokers
+func NewTopicManager(ctx context.Context, cfg *TopicManagerConfig) (*TopicManager, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("topic manager config is required")
+	}
+
+	if len(cfg.Brokers) == 0 {
+		return nil, fmt.Errorf("at least one broker is required")
+	}
+
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(cfg.Brokers...),
+	}
+
+	if cfg.ClientID != "" {
+		opts = append(opts, kgo.ClientID(cfg.ClientID))
+	}
+
+	client, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka admin client: %w", err)
+	}
+
+	// Unreachable brokers are retried until the context ends, so bound the startup check
+	manager := newTopicManager(cfg, client)
+	pingCtx, cancel := context.WithTimeout(ctx, manager.config.Timeout)
+	defer cancel()
+	if err := client.Ping(pingCtx); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to connect to kafka: %w", err)
+	}
+
+	return manager, nil
+}
+
+func newTopicManager(cfg *TopicManagerConfig, client adminClient) *TopicManager {
+	if cfg.Partitions <= 0 {
+		cfg.Partitions = 1
+	}
+	if cfg.ReplicationFactor <= 0 {
+		cfg.ReplicationFactor = 1
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	return &TopicManager{config: cfg, client: client}
+}
+
+// Ensure checks that every spec'd topic exists with its declared settings, creating
+// missing topics when AutoCreate is set. Creating a topic that already exists is not
+// an error, so concurrent service startups can provision the same topics.
+func (m *TopicManager) Ensure(ctx context.Context, specs []TopicSpec) (*TopicReport, error) {
+	specs = m.withDefaults(specs)
+	report := &TopicReport{}
+
+	existing, err := m.describeTopics(ctx, specs)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing, present []TopicSpec
+	for _, spec := range specs {
+		if _, ok := existing[spec.Name]; ok {
+			present = append(present, spec)
+		} else {
+			missing = append(missing, spec)
+		}
+	}
+
+	if len(missing) > 0 {
+		if m.config.AutoCreate {
+			created, err := m.createTopics(ctx, missing)
+			if err != nil {
+				return nil, err
+			}
+			report.Created = created
+		} else {
+			for _, spec := range missing {
+				report.Missing = append(report.Missing, spec.Name)
+			}
+		}
+	}
+
+	for _, spec := range present {
+		topic := existing[spec.Name]
+		if got := topic.partitions; got != spec.Partitions {
+			report.Drift = append(report.Drift, TopicDrift{
+				Topic: spec.Name, Setting: "partitions",
+				Want: strconv.Itoa(int(spec.Partitions)), Got: strconv.Itoa(int(got)),
+			})
+		}
+		if got := topic.replicationFactor; got != spec.ReplicationFactor {
+			report.Drift = append(report.Drift, TopicDrift{
+				Topic: spec.Name, Setting: "replication_factor",
+				Want: strconv.Itoa(int(spec.ReplicationFactor)), Got: strconv.Itoa(int(got)),
+			})
+		}
+	}
+
+	configDrift, err := m.configDrift(ctx, present)
+	if err != nil {
+		return nil, err
+	}
+	report.Drift = append(report.Drift, configDrift...)
+	return report, nil
+}
+
+// Close closes the admin client
+func (m *TopicManager) Close() {
+	m.client.Close()
+}
+
+// withDefaults fills unset partitions and replication factors from the config
+func (m *TopicManager) withDefaults(specs []TopicSpec) []TopicSpec {
+	out := make([]TopicSpec, len(specs))
+	for i, spec := range specs {
+		if spec.Partitions <= 0 {
+			spec.Partitions = m.config.Partitions
+		}
+		if spec.ReplicationFactor <= 0 {
+			spec.ReplicationFactor = m.config.ReplicationFactor
+		}
+		out[i] = spec
+	}
+	return out
+}
+
+// topicLayout is the partitioning of an existing topic
+type topicLayout struct {
+	partitions        int32
+	replicationFactor int16
+}
+
+// describeTopics returns the layout of the spec'd topics that exist
+func (m *TopicManager) describeTopics(ctx context.Context, specs []TopicSpec) (map[string]topicLayout, error) {
+	req := kmsg.NewPtrMetadataRequest()
+	for _, spec := range specs {
+		req.Topics = append(req.Topics, kmsg.MetadataRequestTopic{Topic: kmsg.StringPtr(spec.Name)})
+	}
+
+	resp, err := m.request(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe kafka topics: %w", err)
+	}
+
+	existing := make(map[string]topicLayout)
+	for _, topic := range resp.(*kmsg.MetadataResponse).Topics {
+		if topic.Topic == nil {
+			continue
+		}
+		if err := kerr.ErrorForCode(topic.ErrorCode); err != nil {
+			if errors.Is(err, kerr.UnknownTopicOrPartition) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to describe kafka topic %s: %w", *topic.Topic, err)
+		}
+
+		layout := topicLayout{partitions: int32(len(topic.Partitions))}
+		if len(topic.Partitions) > 0 {
+			layout.replicationFactor = int16(len(topic.Partitions[0].Replicas))
+		}
+		existing[*topic.Topic] = layout
+	}
+	return existing, nil
+}
+
+// createTopics creates the topics and returns the names of those created by this call
+func (m *TopicManager) createTopics(ctx context.Context, specs []TopicSpec) ([]string, error) {
+	req := kmsg.NewPtrCreateTopicsRequest()
+	req.TimeoutMillis = int32(m.config.Timeout.Milliseconds())
+	for _, spec := range specs {
+		topic := kmsg.CreateTopicsRequestTopic{
+			Topic:             spec.Name,
+			NumPartitions:     spec.Partitions,
+			ReplicationFactor: spec.ReplicationFactor,
+		}
+		configs := spec.desiredConfigs()
+		for _, name := range sortedKeys(configs) {
+			topic.Configs = append(topic.Configs, kmsg.CreateTopicsRequestTopicConfig{
+				Name:  name,
+				Value: kmsg.StringPtr(configs[name]),
+			})
+		}
+		req.Topics = append(req.Topics, topic)
+	}
+
+	resp, err := m.request(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka topics: %w", err)
+	}
+
+	var created []string
+	for _, topic := range resp.(*kmsg.CreateTopicsResponse).Topics {
+		if err := kerr.ErrorForCode(topic.ErrorCode); err != nil {
+			// Another instance created it first
+			if errors.Is(err, kerr.TopicAlreadyExists) {
+				continue
+			}
+			return created, fmt.Errorf("failed to create kafka topic %s: %w", topic.Topic, err)
+		}
+		created = append(created, topic.Topic)
+	}
+	return created, nil
+}
+
+// configDrift compares the explicitly declared configs of existing topics with the broker
+func (m *TopicManager) configDrift(ctx context.Context, specs []TopicSpec) ([]TopicDrift, error) {
+	req := kmsg.NewPtrDescribeConfigsRequest()
+	desired := make(map[string]map[string]string)
+	for _, spec := range specs {
+		configs := spec.desiredConfigs()
+		if len(configs) == 0 {
+			continue
+		}
+		desired[spec.Name] = configs
+		req.Resources = append(req.Resources, kmsg.DescribeConfigsRequestResource{
+			ResourceType: kmsg.ConfigResourceTypeTopic,
+			ResourceName: spec.Name,
+			ConfigNames:  sortedKeys(configs),
+		})
+	}
+	if len(req.Resources) == 0 {
+		return nil, nil
+	}
+
+	resp, err := m.request(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe kafka topic configs: %w", err)
+	}
+
+	var drift []TopicDrift
+	for _, resource := range resp.(*kmsg.DescribeConfigsResponse).Resources {
+		if err := kerr.ErrorForCode(resource.ErrorCode); err != nil {
+			return nil, fmt.Errorf("failed to describe kafka topic %s configs: %w", resource.ResourceName, err)
+		}
+
+		actual := make(map[string]string, len(resource.Configs))
+		for _, config := range resource.Configs {
+			if config.Value != nil {
+				actual[config.Name] = *config.Value
+			}
+		}
+		for _, name := range sortedKeys(desired[resource.ResourceName]) {
+			want := desired[resource.ResourceName][name]
+			if got := actual[name]; got != want {
+				drift = append(drift, TopicDrift{Topic: resource.ResourceName, Setting: name, Want: want, Got: got})
+			}
+		}
+	}
+	return drift, nil
+}
+
+func (m *TopicManager) request(ctx context.Context, req kmsg.Request) (kmsg.Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.config.Timeout)
+	defer cancel()
+	return m.client.Request(ctx, req)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ProvisionTopics connects to Kafka, ensures the topics exist and logs what was created
+// and any configuration drift. Returns an error if topics are missing or the check failed.
+func ProvisionTopics(ctx context.Context, cfg *TopicManagerConfig, specs []TopicSpec) error {
+	manager, err := NewTopicManager(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer manager.Close()
+
+	report, err := manager.Ensure(ctx, specs)
+	if err != nil {
+		return err
+	}
+
+	log := logger.Get()
+	if len(report.Created) > 0 {
+		log.InfoContext(ctx, "Created kafka topics", zap.Strings("topics", report.Created))
+	}
+	for _, drift := range report.Drift {
+		log.WarnContext(ctx, "Kafka topic configuration drift",
+			zap.String("topic", drift.Topic),
+			zap.String("setting", drift.Setting),
+			zap.String("want", drift.Want),
+			zap.String("got", drift.Got),
+		)
+	}
+	return report.Err()
+}
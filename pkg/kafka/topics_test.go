@@ -0,0 +1,163 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kmsg"
+)
+
+// fakeTopic is a topic held by fakeAdminClient
+type fakeTopic struct {
+	partitions int
+	replicas   int
+	configs    map[string]string
+}
+
+// fakeAdminClient answers metadata, create topics and describe configs requests from memory
+type fakeAdminClient struct {
+	topics  map[string]*fakeTopic
+	created []string
+}
+
+func (f *fakeAdminClient) Request(ctx context.Context, req kmsg.Request) (kmsg.Response, error) {
+	switch req := req.(type) {
+	case *kmsg.MetadataRequest:
+		resp := kmsg.NewPtrMetadataResponse()
+		for _, t := range req.Topics {
+			topic := kmsg.MetadataResponseTopic{Topic: t.Topic}
+			existing, ok := f.topics[*t.Topic]
+			if !ok {
+				topic.ErrorCode = kerr.UnknownTopicOrPartition.Code
+			} else {
+				for i := 0; i < existing.partitions; i++ {
+					topic.Partitions = append(topic.Partitions, kmsg.MetadataResponseTopicPartition{
+						Partition: int32(i),
+						Replicas:  make([]int32, existing.replicas),
+					})
+				}
+			}
+			resp.Topics = append(resp.Topics, topic)
+		}
+		return resp, nil
+
+	case *kmsg.CreateTopicsRequest:
+		resp := kmsg.NewPtrCreateTopicsResponse()
+		for _, t := range req.Topics {
+			topic := kmsg.CreateTopicsResponseTopic{Topic: t.Topic}
+			if _, ok := f.topics[t.Topic]; ok {
+				topic.ErrorCode = kerr.TopicAlreadyExists.Code
+			} else {
+				configs := make(map[string]string)
+				for _, c := range t.Configs {
+					configs[c.Name] = *c.Value
+				}
+				f.topics[t.Topic] = &fakeTopic{partitions: int(t.NumPartitions), replicas: int(t.ReplicationFactor), configs: configs}
+				f.created = append(f.created, t.Topic)
+			}
+			resp.Topics = append(resp.Topics, topic)
+		}
+		return resp, nil
+
+	case *kmsg.DescribeConfigsRequest:
+		resp := kmsg.NewPtrDescribeConfigsResponse()
+		for _, r := range req.Resources {
+			resource := kmsg.DescribeConfigsResponseResource{ResourceName: r.ResourceName, ResourceType: r.ResourceType}
+			for _, name := range r.ConfigNames {
+				value, ok := f.topics[r.ResourceName].configs[name]
+				if !ok {
+					value = "604800000" // Broker default of 7 days
+				}
+				resource.Configs = append(resource.Configs, kmsg.DescribeConfigsResponseResourceConfig{Name: name, Value: kmsg.StringPtr(value)})
+			}
+			resp.Resources = append(resp.Resources, resource)
+		}
+		return resp, nil
+	}
+	return nil, fmt.Errorf("unexpected request %T", req)
+}
+
+func (f *fakeAdminClient) Close() {}
+
+var testTopicSpecs = []TopicSpec{
+	{Name: "booking-events", Retention: 72 * time.Hour},
+	{Name: "saga.booking.dlq", Partitions: 1, Retention: 30 * 24 * time.Hour},
+}
+
+func TestTopicManager_CreatesMissingTopics(t *testing.T) {
+	client := &fakeAdminClient{topics: map[string]*fakeTopic{}}
+	manager := newTopicManager(&TopicManagerConfig{Partitions: 6, ReplicationFactor: 3, AutoCreate: true}, client)
+
+	report, err := manager.Ensure(context.Background(), testTopicSpecs)
+	if err != nil {
+		t.Fatalf("Ensure() error = %v", err)
+	}
+	if len(report.Created) != 2 || report.Err() != nil {
+		t.Fatalf("Expected both topics created, got %+v", report)
+	}
+
+	events := client.topics["booking-events"]
+	if events.partitions != 6 || events.replicas != 3 || events.configs["retention.ms"] != "259200000" {
+		t.Errorf("booking-events created as %+v", events)
+	}
+	if dlq := client.topics["saga.booking.dlq"]; dlq.partitions != 1 {
+		t.Errorf("Expected spec partitions to override the default, got %d", dlq.partitions)
+	}
+
+	// A second run finds everything in place
+	report, err = manager.Ensure(context.Background(), testTopicSpecs)
+	if err != nil {
+		t.Fatalf("second Ensure() error = %v", err)
+	}
+	if len(report.Created) != 0 || len(report.Drift) != 0 {
+		t.Errorf("Expected no changes on second run, got %+v", report)
+	}
+}
+
+func TestTopicManager_ReportsMissingWithoutAutoCreate(t *testing.T) {
+	client := &fakeAdminClient{topics: map[string]*fakeTopic{}}
+	manager := newTopicManager(&TopicManagerConfig{Partitions: 6}, client)
+
+	report, err := manager.Ensure(context.Background(), testTopicSpecs)
+	if err != nil {
+		t.Fatalf("Ensure() error = %v", err)
+	}
+	if len(client.created) != 0 {
+		t.Errorf("Expected no topics created, got %v", client.created)
+	}
+	if len(report.Missing) != 2 || report.Err() == nil {
+		t.Errorf("Expected both topics reported missing, got %+v", report)
+	}
+}
+
+func TestTopicManager_ReportsDrift(t *testing.T) {
+	client := &fakeAdminClient{topics: map[string]*fakeTopic{
+		"booking-events":   {partitions: 3, replicas: 1, configs: map[string]string{}},
+		"saga.booking.dlq": {partitions: 1, replicas: 1, configs: map[string]string{"retention.ms": "2592000000"}},
+	}}
+	manager := newTopicManager(&TopicManagerConfig{Partitions: 6, ReplicationFactor: 1, AutoCreate: true}, client)
+
+	report, err := manager.Ensure(context.Background(), testTopicSpecs)
+	if err != nil {
+		t.Fatalf("Ensure() error = %v", err)
+	}
+	if len(client.created) != 0 {
+		t.Errorf("Expected existing topics to be left alone, got %v created", client.created)
+	}
+
+	want := map[string]TopicDrift{
+		"partitions":   {Topic: "booking-events", Setting: "partitions", Want: "6", Got: "3"},
+		"retention.ms": {Topic: "booking-events", Setting: "retention.ms", Want: "259200000", Got: "604800000"},
+	}
+	if len(report.Drift) != len(want) {
+		t.Fatalf("Expected %d drifts, got %v", len(want), report.Drift)
+	}
+	for _, drift := range report.Drift {
+		if drift != want[drift.Setting] {
+			t.Errorf("Unexpected drift %s", drift)
+		}
+	}
+}
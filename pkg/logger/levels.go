@@ -0,0 +1,239 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Module loggers are named after the part of the service they log for, e.g.
+// "booking.repository". A level set for a module also applies to its submodules
+// ("booking" covers "booking.repository") unless they have a level of their own.
+
+// levelSnapshot is an immutable view of the levels in effect, swapped atomically so
+// checking a log entry's level takes no lock
+type levelSnapshot struct {
+	base    zapcore.Level
+	modules map[string]zapcore.Level
+	min     zapcore.Level // Lowest level enabled for any module
+}
+
+// levelFor returns the level of the module or its nearest parent with a level
+func (s *levelSnapshot) levelFor(module string) zapcore.Level {
+	for module != "" {
+		if level, ok := s.modules[module]; ok {
+			return level
+		}
+		i := strings.LastIndexByte(module, '.')
+		if i < 0 {
+			break
+		}
+		module = module[:i]
+	}
+	return s.base
+}
+
+// levelRegistry holds the default level and the per-module overrides of all loggers.
+// Local overrides come from configuration and the admin endpoint of this instance;
+// shared overrides are read from a source common to all instances (e.g. a Redis hash)
+// and win over local ones for the same module.
+type levelRegistry struct {
+	mu       sync.Mutex
+	base     zapcore.Level
+	local    map[string]zapcore.Level
+	shared   map[string]zapcore.Level
+	snapshot atomic.Pointer[levelSnapshot]
+}
+
+// levels is shared by every logger built with New, so overrides apply to all of them
+var levels = newLevelRegistry()
+
+func newLevelRegistry() *levelRegistry {
+	r := &levelRegistry{base: zapcore.InfoLevel}
+	r.publish()
+	return r
+}
+
+// publish swaps in a snapshot of the current levels. Callers hold r.mu (or own r).
+func (r *levelRegistry) publish() {
+	s := &levelSnapshot{base: r.base, modules: make(map[string]zapcore.Level, len(r.local)+len(r.shared)), min: r.base}
+	for module, level := range r.local {
+		s.modules[module] = level
+	}
+	for module, level := range r.shared {
+		s.modules[module] = level
+	}
+	for _, level := range s.modules {
+		if level < s.min {
+			s.min = level
+		}
+	}
+	r.snapshot.Store(s)
+}
+
+func (r *levelRegistry) load() *levelSnapshot {
+	return r.snapshot.Load()
+}
+
+// reset replaces the default level and local overrides, dropping shared overrides
+func (r *levelRegistry) reset(base zapcore.Level, local map[string]zapcore.Level) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.base, r.local, r.shared = base, local, nil
+	r.publish()
+}
+
+func (r *levelRegistry) setLocal(local map[string]zapcore.Level) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.local = local
+	r.publish()
+}
+
+func (r *levelRegistry) setShared(shared map[string]zapcore.Level) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.shared = shared
+	r.publish()
+}
+
+// ParseLevel converts a level name to a zapcore.Level, rejecting unknown names
+func ParseLevel(level string) (zapcore.Level, error) {
+	switch level {
+	case "debug", "info", "warn", "error":
+		return parseLevel(level), nil
+	default:
+		return zapcore.InfoLevel, fmt.Errorf("unknown log level %q (want debug, info, warn or error)", level)
+	}
+}
+
+// ParseModuleLevels parses per-module levels given as "module=level,..."
+// (e.g. "booking.repository=debug,saga=warn")
+func ParseModuleLevels(spec string) (map[string]zapcore.Level, error) {
+	modules := make(map[string]zapcore.Level)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		module, name, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("module level %q must be module=level", entry)
+		}
+		module = strings.TrimSpace(module)
+		if module == "" {
+			return nil, fmt.Errorf("module name must not be empty")
+		}
+		level, err := ParseLevel(strings.TrimSpace(name))
+		if err != nil {
+			return nil, fmt.Errorf("module %s: %w", module, err)
+		}
+		modules[module] = level
+	}
+	return modules, nil
+}
+
+// SetModuleLevels replaces the local per-module overrides of this instance, given as
+// module to level name. They reset to the configured ones on restart.
+func SetModuleLevels(names map[string]string) error {
+	modules := make(map[string]zapcore.Level, len(names))
+	for module, name := range names {
+		if module == "" {
+			return fmt.Errorf("module name must not be empty")
+		}
+		level, err := ParseLevel(name)
+		if err != nil {
+			return fmt.Errorf("module %s: %w", module, err)
+		}
+		modules[module] = level
+	}
+	levels.setLocal(modules)
+	return nil
+}
+
+// ModuleLevels returns the default level and the per-module levels in effect
+func ModuleLevels() (string, map[string]string) {
+	s := levels.load()
+	modules := make(map[string]string, len(s.modules))
+	for module, level := range s.modules {
+		modules[module] = level.String()
+	}
+	return s.base.String(), modules
+}
+
+// ModuleLevelFunc reads per-module level names from a source shared by all instances
+type ModuleLevelFunc func(ctx context.Context) (map[string]string, error)
+
+// WatchModuleLevels applies the per-module levels read from source every interval until
+// ctx is cancelled, so one change reaches every instance without a restart. Entries with
+// an unknown level are skipped; when source fails the last levels read stay in effect.
+func WatchModuleLevels(ctx context.Context, source ModuleLevelFunc, interval time.Duration) {
+	apply := func() {
+		names, err := source(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				Get().WarnContext(ctx, fmt.Sprintf("Failed to read shared log levels: %v", err))
+			}
+			return
+		}
+		shared := make(map[string]zapcore.Level, len(names))
+		var invalid []string
+		for module, name := range names {
+			level, err := ParseLevel(name)
+			if err != nil || module == "" {
+				invalid = append(invalid, module+"="+name)
+				continue
+			}
+			shared[module] = level
+		}
+		if len(invalid) > 0 {
+			sort.Strings(invalid)
+			Get().WarnContext(ctx, fmt.Sprintf("Ignoring invalid shared log levels: %s", strings.Join(invalid, ",")))
+		}
+		levels.setShared(shared)
+	}
+
+	apply()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			apply()
+		}
+	}
+}
+
+// moduleLevelCore filters entries by the level of the module (zap logger name) that
+// logged them. The cores it wraps must enable every level.
+type moduleLevelCore struct {
+	zapcore.Core
+	levels *levelRegistry
+}
+
+// Enabled reports whether any module logs at level, so zap only builds entries that
+// may be written
+func (c *moduleLevelCore) Enabled(level zapcore.Level) bool {
+	return level >= c.levels.load().min
+}
+
+// With adds structured context to the wrapped core
+func (c *moduleLevelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &moduleLevelCore{Core: c.Core.With(fields), levels: c.levels}
+}
+
+// Check passes the entry on when its module logs at the entry's level
+func (c *moduleLevelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if ent.Level < c.levels.load().levelFor(ent.LoggerName) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
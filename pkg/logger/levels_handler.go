@@ -0,0 +1,50 @@
+package logger
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/response"
+)
+
+// ModuleLevelsRequest represents the request to replace the per-module log levels
+type ModuleLevelsRequest struct {
+	Modules map[string]string `json:"modules"` // Module to level; empty clears all overrides
+}
+
+// ModuleLevelsResponse represents the log levels in effect on this instance
+type ModuleLevelsResponse struct {
+	Level   string            `json:"level"`
+	Modules map[string]string `json:"modules"`
+}
+
+// GetModuleLevelsHandler returns the log levels in effect on this instance
+func GetModuleLevelsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, response.Success(moduleLevelsResponse()))
+	}
+}
+
+// UpdateModuleLevelsHandler replaces the per-module log levels of this instance.
+// They are not shared with other instances (see WatchModuleLevels) and reset to the
+// configured ones on restart.
+func UpdateModuleLevelsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req ModuleLevelsRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, response.Error("INVALID_REQUEST", "modules must map module names to levels"))
+			return
+		}
+		if err := SetModuleLevels(req.Modules); err != nil {
+			c.JSON(http.StatusBadRequest, response.Error("INVALID_REQUEST", err.Error()))
+			return
+		}
+
+		c.JSON(http.StatusOK, response.Success(moduleLevelsResponse()))
+	}
+}
+
+func moduleLevelsResponse() *ModuleLevelsResponse {
+	level, modules := ModuleLevels()
+	return &ModuleLevelsResponse{Level: level, Modules: modules}
+}
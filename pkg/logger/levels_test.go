@@ -0,0 +1,168 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// newModuleTestLogger returns a logger filtered by registry, writing to a buffer
+func newModuleTestLogger(registry *levelRegistry) (*Logger, *testBuffer) {
+	buf := &testBuffer{}
+	encoder := zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "message", NameKey: "logger"})
+	core := &moduleLevelCore{Core: zapcore.NewCore(encoder, buf, zapcore.DebugLevel), levels: registry}
+	return &Logger{Logger: zap.New(core), serviceName: "test-service"}, buf
+}
+
+func TestModuleLevels(t *testing.T) {
+	registry := newLevelRegistry()
+	registry.reset(zapcore.InfoLevel, map[string]zapcore.Level{"booking": zapcore.WarnLevel})
+	log, buf := newModuleTestLogger(registry)
+
+	tests := []struct {
+		name   string
+		module string
+		level  zapcore.Level
+		want   bool
+	}{
+		{"default level", "", zapcore.InfoLevel, true},
+		{"below default level", "", zapcore.DebugLevel, false},
+		{"module override", "booking", zapcore.InfoLevel, false},
+		{"inherited by submodule", "booking.repository", zapcore.InfoLevel, false},
+		{"submodule at module level", "booking.repository", zapcore.WarnLevel, true},
+		{"unrelated module", "payment", zapcore.InfoLevel, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			l := log
+			if tt.module != "" {
+				l = log.Module(tt.module)
+			}
+			if ce := l.Check(tt.level, "message"); ce != nil {
+				ce.Write()
+			}
+			if got := buf.Len() > 0; got != tt.want {
+				t.Errorf("logged = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Raising a submodule to debug leaves its parent and siblings alone
+	registry.setShared(map[string]zapcore.Level{"booking.repository": zapcore.DebugLevel})
+	buf.Reset()
+	log.Module("booking").Module("repository").Debug("query")
+	if !strings.Contains(buf.String(), `"logger":"booking.repository"`) {
+		t.Errorf("booking.repository debug entry not logged: %q", buf.String())
+	}
+	buf.Reset()
+	log.Module("booking.saga").Info("step")
+	log.Debug("root")
+	if buf.Len() != 0 {
+		t.Errorf("unexpected entries: %q", buf.String())
+	}
+}
+
+func TestParseModuleLevels(t *testing.T) {
+	modules, err := ParseModuleLevels(" booking.repository=debug, saga=warn ,")
+	if err != nil {
+		t.Fatalf("ParseModuleLevels() error = %v", err)
+	}
+	if len(modules) != 2 || modules["booking.repository"] != zapcore.DebugLevel || modules["saga"] != zapcore.WarnLevel {
+		t.Errorf("ParseModuleLevels() = %v", modules)
+	}
+
+	for _, spec := range []string{"booking", "=debug", "booking=verbose"} {
+		if _, err := ParseModuleLevels(spec); err == nil {
+			t.Errorf("ParseModuleLevels(%q) error = nil, want an error", spec)
+		}
+	}
+}
+
+func TestWatchModuleLevels(t *testing.T) {
+	defer levels.reset(zapcore.InfoLevel, nil)
+	levels.reset(zapcore.InfoLevel, map[string]zapcore.Level{"booking": zapcore.WarnLevel})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	source := func(ctx context.Context) (map[string]string, error) {
+		calls++
+		if calls > 1 {
+			cancel()
+			return nil, errors.New("redis down")
+		}
+		return map[string]string{"booking": "debug", "saga": "loud"}, nil
+	}
+	WatchModuleLevels(ctx, source, time.Millisecond)
+
+	// The shared level wins and stays in effect when the source fails
+	if _, modules := ModuleLevels(); modules["booking"] != "debug" || modules["saga"] != "" {
+		t.Errorf("ModuleLevels() = %v, want booking=debug only", modules)
+	}
+}
+
+func TestModuleLevelsHandler(t *testing.T) {
+	defer levels.reset(zapcore.InfoLevel, nil)
+	levels.reset(zapcore.InfoLevel, nil)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/levels", GetModuleLevelsHandler())
+	router.PUT("/levels", UpdateModuleLevelsHandler())
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{"set", `{"modules":{"booking.repository":"debug"}}`, http.StatusOK},
+		{"unknown level", `{"modules":{"booking.repository":"verbose"}}`, http.StatusBadRequest},
+		{"malformed", `{"modules":["booking"]}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/levels", strings.NewReader(tt.body)))
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/levels", nil))
+	if !strings.Contains(w.Body.String(), `"booking.repository":"debug"`) {
+		t.Errorf("GET = %s, want the level set before the rejected updates", w.Body.String())
+	}
+}
+
+func TestOTLPCore_WithFields(t *testing.T) {
+	core := NewOTLPCore(&Config{OTLPEndpoint: "127.0.0.1:1", ServiceName: "test-service", BatchSize: 100, BatchInterval: time.Hour}, zapcore.DebugLevel)
+	defer core.Close()
+
+	log := zap.New(core).With(zap.String("service", "test-service")).Named("booking.repository")
+	log.Debug("query", zap.String("booking_id", "booking-1"))
+
+	core.bufferMu.Lock()
+	defer core.bufferMu.Unlock()
+	if len(core.buffer) != 1 {
+		t.Fatalf("buffered %d records, want 1", len(core.buffer))
+	}
+	attrs := map[string]bool{}
+	for _, kv := range core.buffer[0].Attributes {
+		attrs[kv.Key] = true
+	}
+	for _, key := range []string{"service", "logger", "booking_id"} {
+		if !attrs[key] {
+			t.Errorf("record attributes %v miss %s", core.buffer[0].Attributes, key)
+		}
+	}
+}
@@ -42,6 +42,8 @@ type Config struct {
 	ServiceName string
 	Development bool   // if true, uses console encoder; if false, uses JSON encoder
 	OutputPath  string // stdout, stderr, or file path
+	// Per-module level overrides as "module=level,..." (see Logger.Module)
+	ModuleLevels string
 	// OTLP configuration for exporting logs to OTel Collector
 	OTLPEnabled   bool
 	OTLPEndpoint  string        // e.g., "otel-collector:4317"
@@ -90,6 +92,10 @@ func New(cfg *Config) (*Logger, error) {
 	}
 
 	level := parseLevel(cfg.Level)
+	moduleLevels, err := ParseModuleLevels(cfg.ModuleLevels)
+	if err != nil {
+		return nil, err
+	}
 
 	// Configure encoder for JSON output (structured logging)
 	encoderConfig := zapcore.EncoderConfig{
@@ -130,21 +136,23 @@ func New(cfg *Config) (*Logger, error) {
 		output = zapcore.AddSync(file)
 	}
 
-	// Create cores - always include stdout/file output
+	// Create cores - always include stdout/file output. They write every level; the
+	// module level core in front of them filters by the level of each module.
 	cores := []zapcore.Core{
-		zapcore.NewCore(encoder, output, level),
+		zapcore.NewCore(encoder, output, zapcore.DebugLevel),
 	}
 
 	// Add OTLP core if enabled
 	if cfg.OTLPEnabled && cfg.OTLPEndpoint != "" {
-		otlpCore := NewOTLPCore(cfg, level)
+		otlpCore := NewOTLPCore(cfg, zapcore.DebugLevel)
 		if otlpCore != nil {
 			cores = append(cores, otlpCore)
 		}
 	}
 
 	// Combine cores using Tee
-	core := zapcore.NewTee(cores...)
+	levels.reset(level, moduleLevels)
+	core := &moduleLevelCore{Core: zapcore.NewTee(cores...), levels: levels}
 
 	// Add caller skip for wrapper methods
 	zapLogger := zap.New(core,
@@ -230,6 +238,15 @@ func (l *Logger) WithFields(fields ...zap.Field) *Logger {
 	}
 }
 
+// Module returns a logger for a module of the service, e.g. "booking.repository".
+// Its level can be raised or lowered at runtime without touching other modules.
+func (l *Logger) Module(name string) *Logger {
+	return &Logger{
+		Logger:      l.Logger.Named(name),
+		serviceName: l.serviceName,
+	}
+}
+
 // WithService returns a logger with a different service name
 func (l *Logger) WithService(serviceName string) *Logger {
 	return &Logger{
@@ -325,6 +342,11 @@ func WithContext(ctx context.Context) *Logger {
 	return Get().WithContext(ctx)
 }
 
+// Module returns a logger for a module of the service from the global logger
+func Module(name string) *Logger {
+	return Get().Module(name)
+}
+
 // WithFields returns a logger with additional fields from the global logger
 func WithFields(fields ...zap.Field) *Logger {
 	return Get().WithFields(fields...)
@@ -120,10 +120,37 @@ func NewOTLPCore(cfg *Config, level zapcore.LevelEnabler) *OTLPCore {
 	return core
 }
 
-// With adds structured context to the Core
+// With adds structured context to the Core. The fields (e.g. service, or those of
+// Logger.WithFields) are exported as attributes of every record written through it.
 func (c *OTLPCore) With(fields []zapcore.Field) zapcore.Core {
-	// Return same core - fields will be added at log time
-	return c
+	return &otlpFieldsCore{OTLPCore: c, fields: append([]zapcore.Field(nil), fields...)}
+}
+
+// otlpFieldsCore is an OTLPCore with context fields, sharing its buffer
+type otlpFieldsCore struct {
+	*OTLPCore
+	fields []zapcore.Field
+}
+
+// With adds more structured context
+func (c *otlpFieldsCore) With(fields []zapcore.Field) zapcore.Core {
+	merged := make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	merged = append(append(merged, c.fields...), fields...)
+	return &otlpFieldsCore{OTLPCore: c.OTLPCore, fields: merged}
+}
+
+// Check adds this core, so Write receives the context fields
+func (c *otlpFieldsCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write exports the entry with the context fields before its own
+func (c *otlpFieldsCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	all := make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	return c.OTLPCore.Write(ent, append(append(all, c.fields...), fields...))
 }
 
 // Check determines whether the supplied Entry should be logged
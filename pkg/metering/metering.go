@@ -0,0 +1,88 @@
+// Package metering counts per-tenant usage into daily aggregates shared by every service.
+// The API gateway counts API calls, the usage worker counts bookings and published events
+// from Kafka, and both add to the same per-day counters. Quotas compare today's counters
+// with the limits of the tenant's plan.
+package metering
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Metrics counted per tenant
+const (
+	MetricAPICalls        = "api_calls"        // Authenticated requests through the API gateway
+	MetricBookingsCreated = "bookings_created" // booking.created events
+	MetricEventsPublished = "events_published" // Booking events published to Kafka
+)
+
+// Metering defaults
+const (
+	DefaultFlushInterval = 5 * time.Second
+	DefaultRetention     = 400 * 24 * time.Hour // Over a year of daily aggregates for invoicing
+	MaxRangeDays         = 92
+
+	// DateLayout formats the UTC day of an aggregate
+	DateLayout = "2006-01-02"
+)
+
+// ErrInvalidRange is returned when a usage query range is reversed or too long
+var ErrInvalidRange = errors.New("invalid usage range")
+
+// Counts holds usage counters by metric
+type Counts map[string]int64
+
+// add merges other into c
+func (c Counts) add(other Counts) {
+	for metric, n := range other {
+		c[metric] += n
+	}
+}
+
+// DailyUsage is a tenant's usage on one UTC day
+type DailyUsage struct {
+	Date   string `json:"date"` // YYYY-MM-DD
+	Counts Counts `json:"counts"`
+}
+
+// Store persists daily usage aggregates
+type Store interface {
+	// Add adds per-tenant counts to the aggregates of day
+	Add(ctx context.Context, day time.Time, usage map[string]Counts) error
+	// Daily returns a tenant's usage for each day of days
+	Daily(ctx context.Context, tenantID string, days []time.Time) ([]DailyUsage, error)
+}
+
+// Day returns the start of the UTC day containing t
+func Day(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+// Days returns the UTC days from from to to inclusive, capped at MaxRangeDays
+func Days(from, to time.Time) ([]time.Time, error) {
+	from, to = Day(from), Day(to)
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: %s is after %s", ErrInvalidRange, from.Format(DateLayout), to.Format(DateLayout))
+	}
+	n := int(to.Sub(from)/(24*time.Hour)) + 1
+	if n > MaxRangeDays {
+		return nil, fmt.Errorf("%w: at most %d days can be read at once", ErrInvalidRange, MaxRangeDays)
+	}
+
+	days := make([]time.Time, n)
+	for i := range days {
+		days[i] = from.AddDate(0, 0, i)
+	}
+	return days, nil
+}
+
+// Total sums daily usage by metric
+func Total(usage []DailyUsage) Counts {
+	total := make(Counts)
+	for _, day := range usage {
+		total.add(day.Counts)
+	}
+	return total
+}
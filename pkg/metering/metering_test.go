@@ -0,0 +1,151 @@
+package metering
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// failingStore fails every write until healed
+type failingStore struct {
+	*MemoryStore
+	fail bool
+}
+
+func (s *failingStore) Add(ctx context.Context, day time.Time, usage map[string]Counts) error {
+	if s.fail {
+		return errors.New("redis unavailable")
+	}
+	return s.MemoryStore.Add(ctx, day, usage)
+}
+
+func TestDays(t *testing.T) {
+	from := time.Date(2026, 1, 30, 18, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 2, 2, 1, 0, 0, 0, time.UTC)
+
+	days, err := Days(from, to)
+	if err != nil {
+		t.Fatalf("Days() error = %v", err)
+	}
+	if len(days) != 4 || days[0].Format(DateLayout) != "2026-01-30" || days[3].Format(DateLayout) != "2026-02-02" {
+		t.Errorf("Days() = %v, want 2026-01-30 to 2026-02-02", days)
+	}
+
+	if _, err := Days(to, from); !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("Days(reversed) error = %v, want %v", err, ErrInvalidRange)
+	}
+	if _, err := Days(from, from.AddDate(0, 0, MaxRangeDays)); !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("Days(too long) error = %v, want %v", err, ErrInvalidRange)
+	}
+}
+
+func TestRecorder_FlushAggregatesPerTenantAndDay(t *testing.T) {
+	store := NewMemoryStore(0)
+	recorder := NewRecorder(store, time.Hour)
+	defer recorder.Stop()
+
+	day := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	recorder.now = func() time.Time { return day }
+	recorder.Record("tenant-a", MetricAPICalls, 1)
+	recorder.Record("tenant-a", MetricAPICalls, 2)
+	recorder.Record("tenant-b", MetricAPICalls, 5)
+	recorder.Record("", MetricAPICalls, 1)
+
+	if pending := recorder.Pending("tenant-a"); pending[MetricAPICalls] != 3 {
+		t.Errorf("Pending() = %v, want 3 api calls", pending)
+	}
+	if err := recorder.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if pending := recorder.Pending("tenant-a"); len(pending) != 0 {
+		t.Errorf("Pending() after flush = %v, want empty", pending)
+	}
+
+	usage, _ := store.Daily(context.Background(), "tenant-a", []time.Time{Day(day)})
+	if usage[0].Counts[MetricAPICalls] != 3 {
+		t.Errorf("Daily() = %+v, want 3 api calls", usage)
+	}
+}
+
+func TestRecorder_RequeuesFailedFlushes(t *testing.T) {
+	store := &failingStore{MemoryStore: NewMemoryStore(0), fail: true}
+	recorder := NewRecorder(store, time.Hour)
+	defer recorder.Stop()
+
+	recorder.Record("tenant-a", MetricBookingsCreated, 2)
+	if err := recorder.Flush(context.Background()); err == nil {
+		t.Fatal("Flush() error = nil, want the store error")
+	}
+	recorder.Record("tenant-a", MetricBookingsCreated, 1)
+
+	store.fail = false
+	if err := recorder.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	usage, _ := store.Daily(context.Background(), "tenant-a", []time.Time{Day(time.Now())})
+	if got := Total(usage)[MetricBookingsCreated]; got != 3 {
+		t.Errorf("bookings_created = %d, want 3", got)
+	}
+}
+
+func TestParsePlans(t *testing.T) {
+	plans, err := ParsePlans(`{" Pro ":{"api_calls":100},"free":{"api_calls":10,"bookings_created":1}}`)
+	if err != nil {
+		t.Fatalf("ParsePlans() error = %v", err)
+	}
+	if plans["pro"][MetricAPICalls] != 100 || plans["free"][MetricBookingsCreated] != 1 {
+		t.Errorf("ParsePlans() = %v", plans)
+	}
+
+	if _, err := ParsePlans(`{"free":{"api_calls":-1}}`); err == nil {
+		t.Error("ParsePlans(negative) error = nil")
+	}
+	if _, err := ParsePlans(`not json`); err == nil {
+		t.Error("ParsePlans(invalid) error = nil")
+	}
+}
+
+func TestQuota_Check(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(0)
+	recorder := NewRecorder(store, time.Hour)
+	defer recorder.Stop()
+
+	_ = store.Add(ctx, time.Now(), map[string]Counts{"tenant-a": {MetricAPICalls: 8}})
+	recorder.Record("tenant-a", MetricAPICalls, 2)
+
+	quota := NewQuota(QuotaConfig{
+		Store:       store,
+		Recorder:    recorder,
+		Plans:       Plans{"free": {MetricAPICalls: 10}, "pro": {MetricAPICalls: 100}},
+		DefaultPlan: "free",
+		Lookup: PlanLookupFunc(func(ctx context.Context, tenantID string) (string, error) {
+			switch tenantID {
+			case "tenant-pro":
+				return "Pro", nil
+			case "tenant-down":
+				return "", errors.New("auth unavailable")
+			}
+			return "", nil
+		}),
+	})
+
+	status := quota.Check(ctx, "tenant-a", MetricAPICalls)
+	if status.Plan != "free" || status.Used != 10 || status.Remaining != 0 || !status.Exceeded {
+		t.Errorf("Check(tenant-a) = %+v, want free plan exceeded at 10", status)
+	}
+	if status.ResetAt != Day(time.Now()).AddDate(0, 0, 1) {
+		t.Errorf("ResetAt = %v, want the next UTC day", status.ResetAt)
+	}
+
+	if status := quota.Check(ctx, "tenant-pro", MetricAPICalls); status.Plan != "pro" || status.Limit != 100 || status.Exceeded {
+		t.Errorf("Check(tenant-pro) = %+v, want pro plan within limits", status)
+	}
+	if status := quota.Check(ctx, "tenant-down", MetricAPICalls); status.Plan != "free" || status.Exceeded {
+		t.Errorf("Check(tenant-down) = %+v, want the default plan", status)
+	}
+	if status := quota.Check(ctx, "tenant-a", MetricEventsPublished); status.Limit != 0 || status.Exceeded {
+		t.Errorf("Check(unlimited metric) = %+v, want unlimited", status)
+	}
+}
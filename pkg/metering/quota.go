@@ -0,0 +1,187 @@
+package metering
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultQuotaCacheTTL bounds how stale the stored part of a quota check can be
+const DefaultQuotaCacheTTL = 5 * time.Second
+
+// Plans maps a plan name to its daily limits by metric.
+// A metric without a limit (or with a limit of 0) is unlimited.
+type Plans map[string]Counts
+
+// ParsePlans parses plans from JSON, e.g. {"free":{"api_calls":10000},"pro":{"api_calls":1000000}}
+func ParsePlans(raw string) (Plans, error) {
+	plans := Plans{}
+	if strings.TrimSpace(raw) == "" {
+		return plans, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &plans); err != nil {
+		return nil, fmt.Errorf("failed to parse plans: %w", err)
+	}
+
+	normalized := make(Plans, len(plans))
+	for name, limits := range plans {
+		for metric, limit := range limits {
+			if limit < 0 {
+				return nil, fmt.Errorf("plan %q: %s limit must not be negative", name, metric)
+			}
+		}
+		normalized[strings.ToLower(strings.TrimSpace(name))] = limits
+	}
+	return normalized, nil
+}
+
+// PlanLookup resolves the plan a tenant is on.
+// An empty plan means the tenant is on the default plan.
+type PlanLookup interface {
+	Plan(ctx context.Context, tenantID string) (string, error)
+}
+
+// PlanLookupFunc adapts a function to PlanLookup
+type PlanLookupFunc func(ctx context.Context, tenantID string) (string, error)
+
+// Plan calls f
+func (f PlanLookupFunc) Plan(ctx context.Context, tenantID string) (string, error) {
+	return f(ctx, tenantID)
+}
+
+// QuotaConfig configures a Quota
+type QuotaConfig struct {
+	Store       Store
+	Recorder    *Recorder // Optional; adds usage not flushed yet
+	Plans       Plans
+	DefaultPlan string
+	Lookup      PlanLookup // Optional; every tenant is on DefaultPlan without it
+	CacheTTL    time.Duration
+}
+
+// QuotaStatus is a tenant's standing against one daily limit
+type QuotaStatus struct {
+	Plan      string
+	Metric    string
+	Limit     int64 // 0 = unlimited
+	Used      int64
+	Remaining int64
+	Exceeded  bool
+	ResetAt   time.Time // Start of the next UTC day
+}
+
+// Quota checks today's usage against the limits of the tenant's plan.
+// Checks fail open: a tenant is never rejected because usage or plans could not be read.
+type Quota struct {
+	store       Store
+	recorder    *Recorder
+	plans       Plans
+	defaultPlan string
+	lookup      PlanLookup
+	cacheTTL    time.Duration
+	now         func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cachedUsage
+}
+
+type cachedUsage struct {
+	day     int64
+	counts  Counts
+	expires time.Time
+}
+
+// NewQuota creates a quota checker
+func NewQuota(cfg QuotaConfig) *Quota {
+	cacheTTL := cfg.CacheTTL
+	if cacheTTL <= 0 {
+		cacheTTL = DefaultQuotaCacheTTL
+	}
+	plans := cfg.Plans
+	if plans == nil {
+		plans = Plans{}
+	}
+
+	return &Quota{
+		store:       cfg.Store,
+		recorder:    cfg.Recorder,
+		plans:       plans,
+		defaultPlan: strings.ToLower(strings.TrimSpace(cfg.DefaultPlan)),
+		lookup:      cfg.Lookup,
+		cacheTTL:    cacheTTL,
+		now:         time.Now,
+		cache:       make(map[string]cachedUsage),
+	}
+}
+
+// Plan returns the tenant's plan name and its daily limits
+func (q *Quota) Plan(ctx context.Context, tenantID string) (string, Counts) {
+	name := q.defaultPlan
+	if q.lookup != nil {
+		if plan, err := q.lookup.Plan(ctx, tenantID); err == nil && plan != "" {
+			name = strings.ToLower(strings.TrimSpace(plan))
+		}
+	}
+
+	limits, ok := q.plans[name]
+	if !ok {
+		// Unknown plans get the default plan's limits rather than none at all
+		limits = q.plans[q.defaultPlan]
+	}
+	return name, limits
+}
+
+// Check returns the tenant's standing against the daily limit of metric
+func (q *Quota) Check(ctx context.Context, tenantID, metric string) QuotaStatus {
+	now := q.now()
+	plan, limits := q.Plan(ctx, tenantID)
+	status := QuotaStatus{
+		Plan:    plan,
+		Metric:  metric,
+		Limit:   limits[metric],
+		ResetAt: Day(now).AddDate(0, 0, 1),
+	}
+	if status.Limit <= 0 {
+		return status
+	}
+
+	status.Used = q.used(ctx, tenantID, now)[metric]
+	if q.recorder != nil {
+		status.Used += q.recorder.Pending(tenantID)[metric]
+	}
+	status.Remaining = status.Limit - status.Used
+	if status.Remaining < 0 {
+		status.Remaining = 0
+	}
+	status.Exceeded = status.Used >= status.Limit
+	return status
+}
+
+// used returns the tenant's stored usage for today, cached for cacheTTL
+func (q *Quota) used(ctx context.Context, tenantID string, now time.Time) Counts {
+	day := Day(now)
+
+	q.mu.Lock()
+	cached, ok := q.cache[tenantID]
+	q.mu.Unlock()
+	if ok && cached.day == day.Unix() && now.Before(cached.expires) {
+		return cached.counts
+	}
+
+	usage, err := q.store.Daily(ctx, tenantID, []time.Time{day})
+	if err != nil || len(usage) == 0 {
+		// Fail open with what we last knew about today
+		if ok && cached.day == day.Unix() {
+			return cached.counts
+		}
+		return Counts{}
+	}
+
+	q.mu.Lock()
+	q.cache[tenantID] = cachedUsage{day: day.Unix(), counts: usage[0].Counts, expires: now.Add(q.cacheTTL)}
+	q.mu.Unlock()
+	return usage[0].Counts
+}
@@ -0,0 +1,145 @@
+package metering
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Recorder aggregates usage in memory and periodically flushes it to a Store, so the hot
+// path never waits on Redis
+type Recorder struct {
+	store         Store
+	flushInterval time.Duration
+	now           func() time.Time
+
+	mu      sync.Mutex
+	pending map[int64]map[string]Counts // day (unix seconds) -> tenant -> counts
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// NewRecorder creates a recorder and starts its background flush loop
+func NewRecorder(store Store, flushInterval time.Duration) *Recorder {
+	if flushInterval <= 0 {
+		flushInterval = DefaultFlushInterval
+	}
+
+	r := &Recorder{
+		store:         store,
+		flushInterval: flushInterval,
+		now:           time.Now,
+		pending:       make(map[int64]map[string]Counts),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+
+	go r.flushLoop()
+
+	return r
+}
+
+// Record counts n units of metric for a tenant today
+func (r *Recorder) Record(tenantID, metric string, n int64) {
+	if tenantID == "" || n == 0 {
+		return
+	}
+	day := Day(r.now()).Unix()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tenants, ok := r.pending[day]
+	if !ok {
+		tenants = make(map[string]Counts)
+		r.pending[day] = tenants
+	}
+	counts, ok := tenants[tenantID]
+	if !ok {
+		counts = make(Counts)
+		tenants[tenantID] = counts
+	}
+	counts[metric] += n
+}
+
+// Pending returns the tenant's counts recorded today that are not flushed yet
+func (r *Recorder) Pending(tenantID string) Counts {
+	day := Day(r.now()).Unix()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pending := make(Counts)
+	pending.add(r.pending[day][tenantID])
+	return pending
+}
+
+// Flush writes pending counts to the store.
+// Days that fail to flush are merged back so they are retried on the next flush.
+func (r *Recorder) Flush(ctx context.Context) error {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = make(map[int64]map[string]Counts)
+	r.mu.Unlock()
+
+	var firstErr error
+	for day, usage := range pending {
+		if err := r.store.Add(ctx, time.Unix(day, 0).UTC(), usage); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			r.requeue(day, usage)
+		}
+	}
+	return firstErr
+}
+
+// requeue merges counts that could not be flushed back into pending
+func (r *Recorder) requeue(day int64, usage map[string]Counts) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tenants, ok := r.pending[day]
+	if !ok {
+		r.pending[day] = usage
+		return
+	}
+	for tenantID, counts := range usage {
+		if existing, ok := tenants[tenantID]; ok {
+			existing.add(counts)
+		} else {
+			tenants[tenantID] = counts
+		}
+	}
+}
+
+// Stop stops the flush loop after a final flush
+func (r *Recorder) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.stop)
+		<-r.done
+	})
+}
+
+func (r *Recorder) flushLoop() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), r.flushInterval)
+			_ = r.Flush(ctx) // Failed days stay pending for the next tick
+			cancel()
+		case <-r.stop:
+			ctx, cancel := context.WithTimeout(context.Background(), r.flushInterval)
+			_ = r.Flush(ctx)
+			cancel()
+			return
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package metering
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	pkgredis "github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+	"github.com/redis/go-redis/v9"
+)
+
+// MemoryStore keeps usage in process memory.
+// Used when the gateway runs without Redis; usage is per instance and lost on restart.
+type MemoryStore struct {
+	mu        sync.Mutex
+	days      map[int64]map[string]Counts
+	retention time.Duration
+}
+
+// NewMemoryStore creates an in-memory usage store
+func NewMemoryStore(retention time.Duration) *MemoryStore {
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+	return &MemoryStore{
+		days:      make(map[int64]map[string]Counts),
+		retention: retention,
+	}
+}
+
+// Add adds counts to the day and drops days older than the retention period
+func (s *MemoryStore) Add(ctx context.Context, day time.Time, usage map[string]Counts) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := Day(day).Unix()
+	tenants, ok := s.days[key]
+	if !ok {
+		tenants = make(map[string]Counts)
+		s.days[key] = tenants
+	}
+	for tenantID, counts := range usage {
+		existing, ok := tenants[tenantID]
+		if !ok {
+			existing = make(Counts)
+			tenants[tenantID] = existing
+		}
+		existing.add(counts)
+	}
+
+	cutoff := Day(day).Add(-s.retention).Unix()
+	for k := range s.days {
+		if k < cutoff {
+			delete(s.days, k)
+		}
+	}
+	return nil
+}
+
+// Daily returns the tenant's usage for each day
+func (s *MemoryStore) Daily(ctx context.Context, tenantID string, days []time.Time) ([]DailyUsage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	usage := make([]DailyUsage, len(days))
+	for i, day := range days {
+		counts := make(Counts)
+		counts.add(s.days[Day(day).Unix()][tenantID])
+		usage[i] = DailyUsage{Date: day.Format(DateLayout), Counts: counts}
+	}
+	return usage, nil
+}
+
+// RedisStore keeps usage in Redis so every service instance adds to the same aggregates.
+//
+// Each tenant and day uses one hash:
+//
+//	usage:{tenant}:{YYYY-MM-DD}  hash  metric -> count
+type RedisStore struct {
+	client    *pkgredis.Client
+	retention time.Duration
+}
+
+// NewRedisStore creates a Redis-backed usage store
+func NewRedisStore(client *pkgredis.Client, retention time.Duration) *RedisStore {
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+	return &RedisStore{
+		client:    client,
+		retention: retention,
+	}
+}
+
+func (s *RedisStore) key(tenantID string, day time.Time) string {
+	return fmt.Sprintf("usage:%s:%s", tenantID, Day(day).Format(DateLayout))
+}
+
+// Add increments the day's counters of every tenant in a single pipeline
+func (s *RedisStore) Add(ctx context.Context, day time.Time, usage map[string]Counts) error {
+	// Keep days around for the full retention period after they end
+	ttl := s.retention + 24*time.Hour
+
+	pipe := s.client.Pipeline()
+	for tenantID, counts := range usage {
+		key := s.key(tenantID, day)
+		for metric, n := range counts {
+			pipe.HIncrBy(ctx, key, metric, n)
+		}
+		pipe.Expire(ctx, key, ttl)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to add usage: %w", err)
+	}
+	return nil
+}
+
+// Daily reads the tenant's hashes for each day in a single pipeline
+func (s *RedisStore) Daily(ctx context.Context, tenantID string, days []time.Time) ([]DailyUsage, error) {
+	pipe := s.client.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, len(days))
+	for i, day := range days {
+		cmds[i] = pipe.HGetAll(ctx, s.key(tenantID, day))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to read usage: %w", err)
+	}
+
+	usage := make([]DailyUsage, len(days))
+	for i, day := range days {
+		counts := make(Counts)
+		for metric, raw := range cmds[i].Val() {
+			if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+				counts[metric] = n
+			}
+		}
+		usage[i] = DailyUsage{Date: Day(day).Format(DateLayout), Counts: counts}
+	}
+	return usage, nil
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"go.uber.org/zap"
 )
 
 // AuditAction represents the type of action being audited
@@ -227,7 +229,11 @@ func (al *AuditLogger) worker() {
 				batch = make([]*AuditEntry, 0, al.config.BatchSize)
 			}
 		case <-al.ctx.Done():
-			// Flush remaining entries before exit
+			// Flush remaining entries before exit, including those still buffered;
+			// Close closes the buffer right after cancelling
+			for entry := range al.buffer {
+				batch = append(batch, entry)
+			}
 			if len(batch) > 0 {
 				al.flush(batch)
 			}
@@ -307,6 +313,10 @@ func (al *AuditLogger) flush(entries []*AuditEntry) {
 		_, err := al.config.DB.Exec(ctx, item.query, item.args...)
 		if err != nil {
 			// Log error but don't fail - audit logs should not block the application
+			logger.Get().ErrorContext(ctx, "Failed to write audit log",
+				zap.Error(err),
+				zap.Any("audit_id", item.args[0]),
+			)
 			continue
 		}
 	}
@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// bookingAuditMigration creates audit_logs in booking_db, where the booking service's
+// AuditLogger writes
+const bookingAuditMigration = "../../scripts/migrations/booking/000018_create_audit_logs.up.sql"
+
+// auditTestPool connects to the test database with a throwaway schema on the search
+// path, so the booking migration is applied next to (not over) any existing audit_logs
+func auditTestPool(t *testing.T) *pgxpool.Pool {
+	if os.Getenv("INTEGRATION_TEST") != "true" {
+		t.Skip("Skipping integration test - set INTEGRATION_TEST=true to run")
+	}
+
+	dsn := fmt.Sprintf("postgres://%s:%s@%s:5432/%s?sslmode=disable",
+		getEnvOrDefault("TEST_POSTGRES_USER", "postgres"),
+		os.Getenv("TEST_POSTGRES_PASSWORD"),
+		getEnvOrDefault("TEST_POSTGRES_HOST", "localhost"),
+		getEnvOrDefault("TEST_POSTGRES_DATABASE", "booking_db"),
+	)
+	schema := "audit_test_" + uuid.New().String()[:8]
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	admin, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		t.Skipf("Skipping integration test - PostgreSQL not available: %v", err)
+	}
+	if _, err := admin.Exec(ctx, "CREATE SCHEMA "+schema); err != nil {
+		admin.Close()
+		t.Skipf("Skipping integration test - PostgreSQL not available: %v", err)
+	}
+	t.Cleanup(func() {
+		admin.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE")
+		admin.Close()
+	})
+
+	config, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		t.Fatalf("ParseConfig() error = %v", err)
+	}
+	config.ConnConfig.RuntimeParams["search_path"] = schema
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		t.Fatalf("NewWithConfig() error = %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	migration, err := os.ReadFile(bookingAuditMigration)
+	if err != nil {
+		t.Fatalf("read migration: %v", err)
+	}
+	if _, err := pool.Exec(ctx, string(migration)); err != nil {
+		t.Fatalf("apply migration: %v", err)
+	}
+	return pool
+}
+
+func getEnvOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func TestAuditLogger_Integration_WritesBookingAuditLogs(t *testing.T) {
+	pool := auditTestPool(t)
+
+	auditLogger := NewAuditLogger(DefaultAuditConfig(pool))
+	tenantID, userID := uuid.New().String(), uuid.New().String()
+
+	router := gin.New()
+	router.DELETE("/api/v1/admin/idempotency-records", withIdentity(tenantID, userID), AuditMiddleware(auditLogger), func(c *gin.Context) {
+		SetAuditResourceType(c, "idempotency_record")
+		SetAuditResourceID(c, "key-00000001")
+		SetAuditOldValues(c, map[string]interface{}{"key": "key-00000001"})
+		SetAuditMetadata(c, map[string]interface{}{"reason": "stale sold out response"})
+		c.JSON(http.StatusOK, gin.H{"success": true})
+	})
+
+	req := httptest.NewRequest("DELETE", "/api/v1/admin/idempotency-records", nil)
+	req.Header.Set("X-Forwarded-For", "not-an-ip")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	// Close flushes the buffered entry
+	auditLogger.Close()
+
+	var action, resourceType, resourceID, reason string
+	err := pool.QueryRow(context.Background(), `
+		SELECT action, resource_type, resource_id, metadata->>'reason'
+		FROM audit_logs WHERE tenant_id = $1 AND user_id = $2
+	`, tenantID, userID).Scan(&action, &resourceType, &resourceID, &reason)
+	if err != nil {
+		t.Fatalf("audit row not written: %v", err)
+	}
+	if action != string(AuditActionDelete) || resourceType != "idempotency_record" || resourceID != "key-00000001" || reason != "stale sold out response" {
+		t.Errorf("audit row = %s %s %s %q", action, resourceType, resourceID, reason)
+	}
+}
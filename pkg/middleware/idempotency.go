@@ -7,8 +7,10 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -26,12 +28,18 @@ const (
 	DefaultIdempotencyTTL = 5 * time.Minute
 	// Redis key prefix for idempotency
 	IdempotencyKeyPrefix = "idempotency:"
+	// MinIdempotencyKeyLength and MaxIdempotencyKeyLength bound client idempotency keys
+	MinIdempotencyKeyLength = 8
+	MaxIdempotencyKeyLength = 255
+	// anonymousScope stands in for a missing tenant or user in record keys
+	anonymousScope = "-"
 )
 
 var (
 	ErrMissingIdempotencyKey = errors.New("missing idempotency key")
 	ErrDuplicateRequest      = errors.New("duplicate request")
 	ErrRequestInProgress     = errors.New("request in progress")
+	ErrInvalidIdempotencyKey = errors.New("invalid idempotency key")
 )
 
 // IdempotencyStatus represents the status of an idempotency record
@@ -42,9 +50,39 @@ const (
 	StatusCompleted  IdempotencyStatus = "completed"
 )
 
+// IdempotencyScope is who made an idempotent request and where. Records are kept per
+// scope, so two tenants or users sending the same key never see each other's responses.
+type IdempotencyScope struct {
+	TenantID string
+	UserID   string
+	Route    string // Method and route template, e.g. "POST /api/v1/bookings/:id/confirm"
+}
+
+// RedisKey returns the Redis key of the record of an idempotency key in the scope. The
+// route is hashed to keep keys short; tenant and user stay readable for operators.
+func (s IdempotencyScope) RedisKey(idempotencyKey string) string {
+	tenantID, userID := s.TenantID, s.UserID
+	if tenantID == "" {
+		tenantID = anonymousScope
+	}
+	if userID == "" {
+		userID = anonymousScope
+	}
+	return IdempotencyKeyPrefix + tenantID + ":" + userID + ":" + routeHash(s.Route) + ":" + idempotencyKey
+}
+
+// routeHash is the short hash standing in for a route in record keys
+func routeHash(route string) string {
+	sum := sha256.Sum256([]byte(route))
+	return hex.EncodeToString(sum[:6])
+}
+
 // IdempotencyRecord stores the state of an idempotent request
 type IdempotencyRecord struct {
 	Key          string            `json:"key"`
+	TenantID     string            `json:"tenant_id,omitempty"`
+	UserID       string            `json:"user_id,omitempty"`
+	Route        string            `json:"route,omitempty"`
 	Status       IdempotencyStatus `json:"status"`
 	RequestHash  string            `json:"request_hash"`
 	ResponseCode int               `json:"response_code"`
@@ -71,6 +109,11 @@ type IdempotencyConfig struct {
 	ProcessingTTL time.Duration
 	// KeyExtractor extracts idempotency key from request (default: from header)
 	KeyExtractor func(*gin.Context) string
+	// KeyValidator rejects malformed idempotency keys (default: ValidateIdempotencyKey)
+	KeyValidator func(string) error
+	// ScopeExtractor scopes records to the caller and route (default: tenant and user
+	// from the auth context, method and route template)
+	ScopeExtractor func(*gin.Context) IdempotencyScope
 	// SkipPaths is a list of paths that should skip idempotency check
 	SkipPaths []string
 	// Methods that require idempotency (default: POST, PUT, PATCH, DELETE)
@@ -90,6 +133,8 @@ func DefaultIdempotencyConfig(redis RedisClient) *IdempotencyConfig {
 		TTL:               DefaultIdempotencyTTL, // 24h
 		ProcessingTTL:     60 * time.Second,      // 60s (Dual-TTL Strategy)
 		KeyExtractor:      defaultKeyExtractor,
+		KeyValidator:      ValidateIdempotencyKey,
+		ScopeExtractor:    defaultScopeExtractor,
 		SkipPaths:         []string{},
 		RequiredMethods:   []string{"POST", "PUT", "PATCH", "DELETE"},
 		IncludeBodyInHash: true,
@@ -103,12 +148,46 @@ func defaultKeyExtractor(c *gin.Context) string {
 	return c.GetHeader(IdempotencyKeyHeader)
 }
 
+// defaultScopeExtractor scopes records to the authenticated tenant and user and the
+// matched route. Requests that matched no route fall back to their path.
+func defaultScopeExtractor(c *gin.Context) IdempotencyScope {
+	scope := IdempotencyScope{Route: c.Request.Method + " " + c.FullPath()}
+	if c.FullPath() == "" {
+		scope.Route = c.Request.Method + " " + c.Request.URL.Path
+	}
+	scope.TenantID, _ = GetTenantID(c)
+	scope.UserID, _ = GetUserID(c)
+	return scope
+}
+
+// ValidateIdempotencyKey accepts keys of 8 to 255 letters, digits, '-', '_' and '.',
+// which covers UUIDs, ULIDs and most client-generated tokens
+func ValidateIdempotencyKey(key string) error {
+	if len(key) < MinIdempotencyKeyLength || len(key) > MaxIdempotencyKeyLength {
+		return fmt.Errorf("%w: must be %d to %d characters", ErrInvalidIdempotencyKey, MinIdempotencyKeyLength, MaxIdempotencyKeyLength)
+	}
+	for _, r := range key {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+		default:
+			return fmt.Errorf("%w: only letters, digits, '-', '_' and '.' are allowed", ErrInvalidIdempotencyKey)
+		}
+	}
+	return nil
+}
+
 // IdempotencyMiddleware creates a new idempotency middleware
 func IdempotencyMiddleware(config *IdempotencyConfig) gin.HandlerFunc {
 	// Set default ProcessingTTL if not set
 	if config.ProcessingTTL == 0 {
 		config.ProcessingTTL = 60 * time.Second
 	}
+	if config.KeyValidator == nil {
+		config.KeyValidator = ValidateIdempotencyKey
+	}
+	if config.ScopeExtractor == nil {
+		config.ScopeExtractor = defaultScopeExtractor
+	}
 
 	return func(c *gin.Context) {
 		// Check if path should skip idempotency check
@@ -132,6 +211,11 @@ func IdempotencyMiddleware(config *IdempotencyConfig) gin.HandlerFunc {
 			return
 		}
 
+		if err := config.KeyValidator(idempotencyKey); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, response.Error("INVALID_IDEMPOTENCY_KEY", err.Error()))
+			return
+		}
+
 		// Store key in context
 		c.Set(ContextKeyIdempotencyKey, idempotencyKey)
 
@@ -146,8 +230,9 @@ func IdempotencyMiddleware(config *IdempotencyConfig) gin.HandlerFunc {
 		// Generate request hash
 		requestHash := generateRequestHash(c, bodyBytes, config)
 
-		// Build Redis key
-		redisKey := IdempotencyKeyPrefix + idempotencyKey
+		// Build Redis key, scoped to the caller and route
+		scope := config.ScopeExtractor(c)
+		redisKey := scope.RedisKey(idempotencyKey)
 
 		ctx := c.Request.Context()
 
@@ -162,13 +247,14 @@ func IdempotencyMiddleware(config *IdempotencyConfig) gin.HandlerFunc {
 		if existingRecord != nil {
 			// Check if request hash matches
 			if existingRecord.RequestHash != requestHash {
-				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, response.Error("IDEMPOTENCY_KEY_REUSED", "Idempotency key already used with different request"))
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, response.ErrorWithDetails("IDEMPOTENCY_KEY_REUSED",
+					"Idempotency key already used with different request", conflictDetails(existingRecord, scope)))
 				return
 			}
 
 			// Check status
 			if existingRecord.Status == StatusProcessing {
-				c.AbortWithStatusJSON(http.StatusConflict, response.Error("REQUEST_IN_PROGRESS", "A request with this idempotency key is already being processed"))
+				abortInProgress(c, existingRecord, scope, config.ProcessingTTL)
 				return
 			}
 
@@ -181,6 +267,9 @@ func IdempotencyMiddleware(config *IdempotencyConfig) gin.HandlerFunc {
 		// Create new processing record
 		record := &IdempotencyRecord{
 			Key:         idempotencyKey,
+			TenantID:    scope.TenantID,
+			UserID:      scope.UserID,
+			Route:       scope.Route,
 			Status:      StatusProcessing,
 			RequestHash: requestHash,
 			CreatedAt:   time.Now(),
@@ -192,7 +281,7 @@ func IdempotencyMiddleware(config *IdempotencyConfig) gin.HandlerFunc {
 			existingRecord, _ = getIdempotencyRecord(ctx, config.Redis, redisKey)
 			if existingRecord != nil {
 				if existingRecord.Status == StatusProcessing {
-					c.AbortWithStatusJSON(http.StatusConflict, response.Error("REQUEST_IN_PROGRESS", "A request with this idempotency key is already being processed"))
+					abortInProgress(c, existingRecord, scope, config.ProcessingTTL)
 					return
 				}
 				// Return cached response
@@ -223,6 +312,38 @@ func IdempotencyMiddleware(config *IdempotencyConfig) gin.HandlerFunc {
 	}
 }
 
+// abortInProgress answers 409 for a key whose first request is still being processed,
+// telling the client when the processing record expires and a retry can go through
+func abortInProgress(c *gin.Context, record *IdempotencyRecord, scope IdempotencyScope, processingTTL time.Duration) {
+	details := conflictDetails(record, scope)
+	retryAfter := int((processingTTL - time.Since(record.CreatedAt)).Round(time.Second).Seconds())
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+	details["retry_after_seconds"] = strconv.Itoa(retryAfter)
+	c.Header("Retry-After", strconv.Itoa(retryAfter))
+	c.AbortWithStatusJSON(http.StatusConflict, response.ErrorWithDetails("REQUEST_IN_PROGRESS",
+		"A request with this idempotency key is already being processed", details))
+}
+
+// conflictDetails describes the request an idempotency key was first used for, so a
+// client can tell a retry from a reused key. Only the caller's own records get here.
+func conflictDetails(record *IdempotencyRecord, scope IdempotencyScope) map[string]string {
+	details := map[string]string{
+		"idempotency_key":  record.Key,
+		"route":            scope.Route,
+		"original_status":  string(record.Status),
+		"first_request_at": record.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if record.Route != "" {
+		details["route"] = record.Route
+	}
+	if record.ResponseCode != 0 {
+		details["original_response_code"] = strconv.Itoa(record.ResponseCode)
+	}
+	return details
+}
+
 // RequireIdempotencyKey creates a middleware that enforces idempotency key presence
 func RequireIdempotencyKey() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -340,14 +461,12 @@ func saveIdempotencyRecord(ctx context.Context, redis RedisClient, key string, r
 	return redis.Set(ctx, key, string(data), ttl).Err()
 }
 
-// DeleteIdempotencyRecord deletes an idempotency record (for testing or cleanup)
-func DeleteIdempotencyRecord(ctx context.Context, redis RedisClient, idempotencyKey string) error {
-	redisKey := IdempotencyKeyPrefix + idempotencyKey
-	return redis.Del(ctx, redisKey).Err()
+// DeleteIdempotencyRecord deletes the idempotency record of a key in a scope (for testing or cleanup)
+func DeleteIdempotencyRecord(ctx context.Context, redis RedisClient, scope IdempotencyScope, idempotencyKey string) error {
+	return redis.Del(ctx, scope.RedisKey(idempotencyKey)).Err()
 }
 
-// CheckIdempotency checks if a request with the given key exists and returns its status
-func CheckIdempotency(ctx context.Context, redis RedisClient, idempotencyKey string) (*IdempotencyRecord, error) {
-	redisKey := IdempotencyKeyPrefix + idempotencyKey
-	return getIdempotencyRecord(ctx, redis, redisKey)
+// CheckIdempotency checks if a request with the given key exists in a scope and returns its status
+func CheckIdempotency(ctx context.Context, redis RedisClient, scope IdempotencyScope, idempotencyKey string) (*IdempotencyRecord, error) {
+	return getIdempotencyRecord(ctx, redis, scope.RedisKey(idempotencyKey))
 }
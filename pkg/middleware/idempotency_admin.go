@@ -0,0 +1,356 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/response"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultIdempotencyRecordLimit and MaxIdempotencyRecordLimit bound record lookups
+	DefaultIdempotencyRecordLimit = 50
+	MaxIdempotencyRecordLimit     = 200
+	// idempotencyScanCount is the number of keys each SCAN call examines
+	idempotencyScanCount = 500
+)
+
+var (
+	ErrIdempotencyRecordNotFound   = errors.New("idempotency record not found")
+	ErrIdempotencyRecordProcessing = errors.New("idempotency record is still processing")
+	ErrIdempotencyRecordChanged    = errors.New("idempotency record changed since it was looked up")
+)
+
+// deleteIfUnchangedScript deletes a record only if it still holds the value that was
+// inspected, so a record rewritten in the meantime is never invalidated by mistake
+var deleteIfUnchangedScript = `
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`
+
+// IdempotencyAdminRedis is the Redis access needed to inspect and invalidate records
+type IdempotencyAdminRedis interface {
+	Get(ctx context.Context, key string) *redis.StringCmd
+	TTL(ctx context.Context, key string) *redis.DurationCmd
+	Scan(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd
+	Eval(ctx context.Context, script string, keys []string, args ...interface{}) *redis.Cmd
+}
+
+// IdempotencyRecordQuery selects idempotency records. Empty fields match any value;
+// Route must be the exact method and route template, e.g. "POST /api/v1/bookings/reserve".
+type IdempotencyRecordQuery struct {
+	TenantID string
+	UserID   string
+	Route    string
+	Key      string
+	Limit    int
+}
+
+// IdempotencyRecordInfo describes a stored idempotency record and its cached response
+type IdempotencyRecordInfo struct {
+	Key          string            `json:"key"`
+	TenantID     string            `json:"tenant_id,omitempty"`
+	UserID       string            `json:"user_id,omitempty"`
+	Route        string            `json:"route,omitempty"`
+	Status       IdempotencyStatus `json:"status"`
+	RequestHash  string            `json:"request_hash"`
+	ResponseCode int               `json:"response_code,omitempty"`
+	ResponseSize int               `json:"response_size"`
+	ResponseBody string            `json:"response_body,omitempty"` // Only when asked for
+	CreatedAt    time.Time         `json:"created_at"`
+	CompletedAt  *time.Time        `json:"completed_at,omitempty"`
+	ExpiresAt    *time.Time        `json:"expires_at,omitempty"`
+}
+
+// IdempotencyAdmin looks up idempotency records and invalidates cached responses that
+// turned out wrong, so the next retry with the key runs the request again
+type IdempotencyAdmin struct {
+	redis IdempotencyAdminRedis
+}
+
+// NewIdempotencyAdmin creates idempotency admin tools over the records in redis
+func NewIdempotencyAdmin(redis IdempotencyAdminRedis) *IdempotencyAdmin {
+	return &IdempotencyAdmin{redis: redis}
+}
+
+// FindRecords returns up to query.Limit records matching the query. It scans the
+// idempotency keyspace, so it is meant for occasional support lookups only.
+func (a *IdempotencyAdmin) FindRecords(ctx context.Context, query IdempotencyRecordQuery, includeBody bool) ([]*IdempotencyRecordInfo, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = DefaultIdempotencyRecordLimit
+	}
+	if limit > MaxIdempotencyRecordLimit {
+		limit = MaxIdempotencyRecordLimit
+	}
+
+	records := make([]*IdempotencyRecordInfo, 0)
+	var cursor uint64
+	for {
+		keys, next, err := a.redis.Scan(ctx, cursor, query.pattern(), idempotencyScanCount).Result()
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			info, err := a.recordInfo(ctx, key, includeBody)
+			if errors.Is(err, redis.Nil) {
+				continue // Expired since the scan
+			}
+			if err != nil {
+				return nil, err
+			}
+			if !query.matches(info) {
+				continue
+			}
+			records = append(records, info)
+			if len(records) == limit {
+				return records, nil
+			}
+		}
+		if next == 0 {
+			return records, nil
+		}
+		cursor = next
+	}
+}
+
+// InvalidateRecord deletes the completed record of an idempotency key in a scope if its
+// request hash is still requestHash, and returns the deleted record. Records still
+// processing are refused: deleting one would let a retry run alongside the original.
+func (a *IdempotencyAdmin) InvalidateRecord(ctx context.Context, scope IdempotencyScope, idempotencyKey, requestHash string) (*IdempotencyRecord, error) {
+	redisKey := scope.RedisKey(idempotencyKey)
+	value, err := a.redis.Get(ctx, redisKey).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrIdempotencyRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var record IdempotencyRecord
+	if err := json.Unmarshal([]byte(value), &record); err != nil {
+		return nil, err
+	}
+	if record.Status != StatusCompleted {
+		return nil, ErrIdempotencyRecordProcessing
+	}
+	if record.RequestHash != requestHash {
+		return nil, ErrIdempotencyRecordChanged
+	}
+
+	deleted, err := a.redis.Eval(ctx, deleteIfUnchangedScript, []string{redisKey}, value).Int()
+	if err != nil {
+		return nil, err
+	}
+	if deleted == 0 {
+		return nil, ErrIdempotencyRecordChanged
+	}
+	return &record, nil
+}
+
+// recordInfo reads the record stored at a Redis key
+func (a *IdempotencyAdmin) recordInfo(ctx context.Context, redisKey string, includeBody bool) (*IdempotencyRecordInfo, error) {
+	value, err := a.redis.Get(ctx, redisKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	var record IdempotencyRecord
+	if err := json.Unmarshal([]byte(value), &record); err != nil {
+		return nil, err
+	}
+
+	info := &IdempotencyRecordInfo{
+		Key:          record.Key,
+		TenantID:     record.TenantID,
+		UserID:       record.UserID,
+		Route:        record.Route,
+		Status:       record.Status,
+		RequestHash:  record.RequestHash,
+		ResponseCode: record.ResponseCode,
+		ResponseSize: len(record.ResponseBody),
+		CreatedAt:    record.CreatedAt,
+		CompletedAt:  record.CompletedAt,
+	}
+	if includeBody {
+		info.ResponseBody = record.ResponseBody
+	}
+	if ttl, err := a.redis.TTL(ctx, redisKey).Result(); err == nil && ttl > 0 {
+		expiresAt := time.Now().Add(ttl)
+		info.ExpiresAt = &expiresAt
+	}
+	return info, nil
+}
+
+// pattern returns the SCAN pattern of the record keys the query may match
+func (q IdempotencyRecordQuery) pattern() string {
+	part := func(value string) string {
+		if value == "" {
+			return "*"
+		}
+		return escapeGlob(value)
+	}
+	route := "*"
+	if q.Route != "" {
+		route = routeHash(q.Route)
+	}
+	return IdempotencyKeyPrefix + part(q.TenantID) + ":" + part(q.UserID) + ":" + route + ":" + part(q.Key)
+}
+
+// matches reports whether a record matches the query. A '*' in the pattern also
+// matches ':', so scanned keys are checked against the record itself.
+func (q IdempotencyRecordQuery) matches(info *IdempotencyRecordInfo) bool {
+	return (q.TenantID == "" || q.TenantID == info.TenantID) &&
+		(q.UserID == "" || q.UserID == info.UserID) &&
+		(q.Route == "" || q.Route == info.Route) &&
+		(q.Key == "" || q.Key == info.Key)
+}
+
+// escapeGlob escapes the characters special to Redis glob patterns
+func escapeGlob(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// InvalidateIdempotencyRecordRequest identifies the record to invalidate. RequestHash
+// is the one returned by the lookup, so only the record that was inspected is deleted.
+type InvalidateIdempotencyRecordRequest struct {
+	TenantID    string `json:"tenant_id"`
+	UserID      string `json:"user_id"`
+	Route       string `json:"route" binding:"required"`
+	Key         string `json:"key" binding:"required"`
+	RequestHash string `json:"request_hash" binding:"required"`
+	Reason      string `json:"reason" binding:"required"`
+}
+
+// adminTenant returns the tenant whose records the caller may see: the requested one
+// (any tenant when empty) for super_admin, the caller's own tenant otherwise. It
+// responds 403 when a tenant admin has no tenant.
+func adminTenant(c *gin.Context, requested string) (string, bool) {
+	if role, _ := GetRole(c); role == "super_admin" {
+		return requested, true
+	}
+	tenantID, _ := GetTenantID(c)
+	if tenantID == "" {
+		c.JSON(http.StatusForbidden, response.Error("FORBIDDEN", "idempotency records require a tenant"))
+		return "", false
+	}
+	return tenantID, true
+}
+
+// FindRecordsHandler handles GET lookups of idempotency records by the tenant_id,
+// user_id, route and key query parameters. tenant_id is only honoured for
+// super_admin; other admins see their own tenant's records. Cached response bodies
+// are left out unless include_body=true, as they may hold customer data.
+func (a *IdempotencyAdmin) FindRecordsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantID, ok := adminTenant(c, c.Query("tenant_id"))
+		if !ok {
+			return
+		}
+		query := IdempotencyRecordQuery{
+			TenantID: tenantID,
+			UserID:   c.Query("user_id"),
+			Route:    c.Query("route"),
+			Key:      c.Query("key"),
+		}
+		if limit := c.Query("limit"); limit != "" {
+			n, err := strconv.Atoi(limit)
+			if err != nil || n <= 0 {
+				c.JSON(http.StatusBadRequest, response.Error("INVALID_REQUEST", "limit must be a positive number"))
+				return
+			}
+			query.Limit = n
+		}
+
+		records, err := a.FindRecords(c.Request.Context(), query, c.Query("include_body") == "true")
+		if err != nil {
+			_ = c.Error(err)
+			c.JSON(http.StatusServiceUnavailable, response.Error("SERVICE_UNAVAILABLE", "idempotency records unavailable"))
+			return
+		}
+		c.JSON(http.StatusOK, response.Success(gin.H{"records": records, "count": len(records)}))
+	}
+}
+
+// InvalidateRecordHandler handles DELETE of one idempotency record, of the caller's
+// tenant unless the caller is super_admin. Successful invalidations set the audit
+// context for AuditMiddleware and are logged; rejected ones are not audited.
+func (a *IdempotencyAdmin) InvalidateRecordHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req InvalidateIdempotencyRecordRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			SkipAudit(c)
+			c.JSON(http.StatusBadRequest, response.Error("INVALID_REQUEST", "route, key, request_hash and reason are required"))
+			return
+		}
+
+		tenantID, ok := adminTenant(c, req.TenantID)
+		if !ok {
+			SkipAudit(c)
+			return
+		}
+
+		scope := IdempotencyScope{TenantID: tenantID, UserID: req.UserID, Route: req.Route}
+		record, err := a.InvalidateRecord(c.Request.Context(), scope, req.Key, req.RequestHash)
+		if err != nil {
+			SkipAudit(c)
+			switch {
+			case errors.Is(err, ErrIdempotencyRecordNotFound):
+				c.JSON(http.StatusNotFound, response.Error("NOT_FOUND", err.Error()))
+			case errors.Is(err, ErrIdempotencyRecordProcessing):
+				c.JSON(http.StatusConflict, response.Error("REQUEST_IN_PROGRESS", err.Error()))
+			case errors.Is(err, ErrIdempotencyRecordChanged):
+				c.JSON(http.StatusConflict, response.Error("RECORD_CHANGED", err.Error()))
+			default:
+				_ = c.Error(err)
+				c.JSON(http.StatusServiceUnavailable, response.Error("SERVICE_UNAVAILABLE", "idempotency records unavailable"))
+			}
+			return
+		}
+
+		invalidatedBy, _ := GetUserID(c)
+		SetAuditResourceType(c, "idempotency_record")
+		SetAuditOldValues(c, map[string]interface{}{
+			"key":           record.Key,
+			"tenant_id":     record.TenantID,
+			"user_id":       record.UserID,
+			"route":         record.Route,
+			"request_hash":  record.RequestHash,
+			"response_code": record.ResponseCode,
+			"created_at":    record.CreatedAt,
+		})
+		SetAuditMetadata(c, map[string]interface{}{"reason": req.Reason})
+		logger.Get().InfoContext(c.Request.Context(), "Idempotency record invalidated",
+			zap.String("idempotency_key", record.Key),
+			zap.String("tenant_id", record.TenantID),
+			zap.String("user_id", record.UserID),
+			zap.String("route", record.Route),
+			zap.Int("response_code", record.ResponseCode),
+			zap.String("invalidated_by", invalidatedBy),
+			zap.String("reason", req.Reason),
+		)
+
+		c.JSON(http.StatusOK, response.Success(gin.H{
+			"message": fmt.Sprintf("Idempotency record %s invalidated; the next request with the key runs again", record.Key),
+		}))
+	}
+}
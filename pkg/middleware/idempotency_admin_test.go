@@ -0,0 +1,303 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+// MockAdminRedisClient adds the SCAN, TTL and compare-and-delete script support the
+// idempotency admin tools need to MockRedisClient
+type MockAdminRedisClient struct {
+	*MockRedisClient
+}
+
+func (m *MockAdminRedisClient) TTL(ctx context.Context, key string) *redis.DurationCmd {
+	cmd := redis.NewDurationCmd(ctx, time.Second)
+	if _, ok := m.data[key]; ok {
+		cmd.SetVal(time.Hour)
+	} else {
+		cmd.SetVal(-2 * time.Nanosecond)
+	}
+	return cmd
+}
+
+// Scan returns every matching key in one page; path.Match is close enough to Redis globs
+// for keys without '/'
+func (m *MockAdminRedisClient) Scan(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd {
+	cmd := redis.NewScanCmd(ctx, nil)
+	keys := make([]string, 0)
+	for key := range m.data {
+		if ok, _ := path.Match(match, key); ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	cmd.SetVal(keys, 0)
+	return cmd
+}
+
+// Eval runs deleteIfUnchangedScript, the only script the admin tools use
+func (m *MockAdminRedisClient) Eval(ctx context.Context, script string, keys []string, args ...interface{}) *redis.Cmd {
+	cmd := redis.NewCmd(ctx)
+	if value, ok := m.data[keys[0]]; ok && value == args[0] {
+		delete(m.data, keys[0])
+		cmd.SetVal(int64(1))
+	} else {
+		cmd.SetVal(int64(0))
+	}
+	return cmd
+}
+
+func storeIdempotencyRecord(m *MockRedisClient, record *IdempotencyRecord) {
+	scope := IdempotencyScope{TenantID: record.TenantID, UserID: record.UserID, Route: record.Route}
+	data, _ := json.Marshal(record)
+	m.Set(context.Background(), scope.RedisKey(record.Key), string(data), time.Hour)
+}
+
+// asAdmin sets the identity of an admin of tenantID (any tenant for super_admin)
+func asAdmin(tenantID, role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(ContextKeyTenantID, tenantID)
+		c.Set(ContextKeyUserID, "admin-1")
+		c.Set(ContextKeyRole, role)
+		c.Next()
+	}
+}
+
+func TestIdempotencyAdmin_FindRecords(t *testing.T) {
+	mockRedis := &MockAdminRedisClient{NewMockRedisClient()}
+	for _, record := range []*IdempotencyRecord{
+		{Key: "key-00000001", TenantID: "tenant-1", UserID: "user-1", Route: "POST /api/v1/bookings/reserve", Status: StatusCompleted, ResponseCode: 201, ResponseBody: `{"id":"booking-1"}`},
+		{Key: "key-00000002", TenantID: "tenant-1", UserID: "user-1", Route: "POST /api/v1/bookings/:id/confirm", Status: StatusProcessing},
+		{Key: "key-00000001", TenantID: "tenant-1", UserID: "user-2", Route: "POST /api/v1/bookings/reserve", Status: StatusCompleted, ResponseCode: 409},
+	} {
+		storeIdempotencyRecord(mockRedis.MockRedisClient, record)
+	}
+	admin := NewIdempotencyAdmin(mockRedis)
+
+	tests := []struct {
+		name  string
+		query IdempotencyRecordQuery
+		want  int
+	}{
+		{"by user", IdempotencyRecordQuery{UserID: "user-1"}, 2},
+		{"by key", IdempotencyRecordQuery{Key: "key-00000001"}, 2},
+		{"by route", IdempotencyRecordQuery{Route: "POST /api/v1/bookings/reserve"}, 2},
+		{"by key, user and route", IdempotencyRecordQuery{UserID: "user-2", Route: "POST /api/v1/bookings/reserve", Key: "key-00000001"}, 1},
+		{"glob characters are literal", IdempotencyRecordQuery{UserID: "user-*"}, 0},
+		{"limit", IdempotencyRecordQuery{Limit: 1}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := admin.FindRecords(context.Background(), tt.query, false)
+			if err != nil {
+				t.Fatalf("FindRecords() error = %v", err)
+			}
+			if len(records) != tt.want {
+				t.Errorf("FindRecords() = %d records, want %d", len(records), tt.want)
+			}
+		})
+	}
+
+	records, _ := admin.FindRecords(context.Background(), IdempotencyRecordQuery{UserID: "user-1", Key: "key-00000001"}, false)
+	if len(records) != 1 || records[0].ResponseSize != len(`{"id":"booking-1"}`) || records[0].ResponseBody != "" || records[0].ExpiresAt == nil {
+		t.Errorf("FindRecords() = %+v, want response metadata without the body", records[0])
+	}
+	records, _ = admin.FindRecords(context.Background(), IdempotencyRecordQuery{UserID: "user-1", Key: "key-00000001"}, true)
+	if len(records) != 1 || records[0].ResponseBody != `{"id":"booking-1"}` {
+		t.Errorf("FindRecords(includeBody) = %+v, want the cached response body", records[0])
+	}
+}
+
+func TestIdempotencyAdmin_InvalidateRecord(t *testing.T) {
+	scope := IdempotencyScope{TenantID: "tenant-1", UserID: "user-1", Route: "POST /api/v1/bookings/reserve"}
+
+	tests := []struct {
+		name        string
+		status      IdempotencyStatus
+		requestHash string
+		wantErr     error
+	}{
+		{"completed", StatusCompleted, "hash-1", nil},
+		{"still processing", StatusProcessing, "hash-1", ErrIdempotencyRecordProcessing},
+		{"replaced since lookup", StatusCompleted, "hash-0", ErrIdempotencyRecordChanged},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRedis := &MockAdminRedisClient{NewMockRedisClient()}
+			storeIdempotencyRecord(mockRedis.MockRedisClient, &IdempotencyRecord{
+				Key: "key-00000001", TenantID: scope.TenantID, UserID: scope.UserID, Route: scope.Route,
+				Status: tt.status, RequestHash: "hash-1",
+			})
+
+			_, err := NewIdempotencyAdmin(mockRedis).InvalidateRecord(context.Background(), scope, "key-00000001", tt.requestHash)
+			if err != tt.wantErr {
+				t.Fatalf("InvalidateRecord() error = %v, want %v", err, tt.wantErr)
+			}
+			_, getErr := mockRedis.Get(context.Background(), scope.RedisKey("key-00000001")).Result()
+			if deleted := getErr == redis.Nil; deleted != (tt.wantErr == nil) {
+				t.Errorf("record deleted = %v, want %v", deleted, tt.wantErr == nil)
+			}
+		})
+	}
+
+	mockRedis := &MockAdminRedisClient{NewMockRedisClient()}
+	if _, err := NewIdempotencyAdmin(mockRedis).InvalidateRecord(context.Background(), scope, "key-00000001", "hash-1"); err != ErrIdempotencyRecordNotFound {
+		t.Errorf("InvalidateRecord() of a missing record error = %v, want %v", err, ErrIdempotencyRecordNotFound)
+	}
+}
+
+func TestIdempotencyAdmin_InvalidateRecordHandler(t *testing.T) {
+	mockRedis := &MockAdminRedisClient{NewMockRedisClient()}
+	config := DefaultIdempotencyConfig(mockRedis)
+
+	auditConfig := DefaultAuditConfig(nil)
+	auditConfig.FlushInterval = 10 * time.Millisecond
+	auditLogger := NewAuditLogger(auditConfig)
+	auditLogger.SetTestMode(true)
+	defer auditLogger.Close()
+
+	requestCount := 0
+	router := setupIdempotencyTestRouter()
+	router.POST("/api/v1/bookings/reserve", withIdentity("tenant-1", "user-1"), IdempotencyMiddleware(config), func(c *gin.Context) {
+		requestCount++
+		c.JSON(http.StatusConflict, gin.H{"error": "zone sold out"})
+	})
+	admin := NewIdempotencyAdmin(mockRedis)
+	router.GET("/admin/idempotency-records", asAdmin("tenant-1", "admin"), admin.FindRecordsHandler())
+	router.DELETE("/admin/idempotency-records", asAdmin("tenant-1", "admin"), AuditMiddleware(auditLogger), admin.InvalidateRecordHandler())
+
+	reserve := func() {
+		req, _ := http.NewRequest("POST", "/api/v1/bookings/reserve", bytes.NewBufferString(`{"zone_id":"zone-1"}`))
+		req.Header.Set(IdempotencyKeyHeader, "key-00000001")
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	reserve()
+
+	// Look the wrong response up, then invalidate that record
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/admin/idempotency-records?user_id=user-1&key=key-00000001", nil))
+	var lookup struct {
+		Data struct {
+			Records []IdempotencyRecordInfo `json:"records"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &lookup); err != nil || len(lookup.Data.Records) != 1 {
+		t.Fatalf("lookup = %d %s, want one record", w.Code, w.Body.String())
+	}
+	record := lookup.Data.Records[0]
+	if record.Route != "POST /api/v1/bookings/reserve" || record.ResponseCode != http.StatusConflict {
+		t.Errorf("record = %+v, want the cached 409 of the reserve route", record)
+	}
+
+	invalidate := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("DELETE", "/admin/idempotency-records", strings.NewReader(body)))
+		return w
+	}
+	if w := invalidate(`{"tenant_id":"tenant-1","user_id":"user-1","route":"POST /api/v1/bookings/reserve","key":"key-00000001","request_hash":"` + record.RequestHash + `"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("invalidate without a reason status = %d, want 400", w.Code)
+	}
+	body := `{"tenant_id":"tenant-1","user_id":"user-1","route":"POST /api/v1/bookings/reserve","key":"key-00000001","request_hash":"` + record.RequestHash + `","reason":"stale sold out response"}`
+	if w := invalidate(body); w.Code != http.StatusOK {
+		t.Fatalf("invalidate status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+	if w := invalidate(body); w.Code != http.StatusNotFound {
+		t.Errorf("second invalidate status = %d, want 404", w.Code)
+	}
+
+	// The next retry with the key runs the request again
+	reserve()
+	if requestCount != 2 {
+		t.Errorf("handler ran %d times, want 2", requestCount)
+	}
+
+	// Only the successful invalidation is audited
+	time.Sleep(50 * time.Millisecond)
+	entries := auditLogger.GetTestEntries()
+	if len(entries) != 1 {
+		t.Fatalf("audited %d entries, want 1", len(entries))
+	}
+	entry := entries[0]
+	if entry.Action != AuditActionDelete || entry.ResourceType != "idempotency_record" || entry.UserID == nil || *entry.UserID != "admin-1" ||
+		entry.OldValues["key"] != "key-00000001" || entry.Metadata["reason"] != "stale sold out response" {
+		t.Errorf("audit entry = %+v", entry)
+	}
+}
+
+func TestIdempotencyAdmin_TenantScope(t *testing.T) {
+	mockRedis := &MockAdminRedisClient{NewMockRedisClient()}
+	for _, tenantID := range []string{"tenant-1", "tenant-2"} {
+		storeIdempotencyRecord(mockRedis.MockRedisClient, &IdempotencyRecord{
+			Key: "key-00000001", TenantID: tenantID, UserID: "user-1", Route: "POST /api/v1/bookings/reserve",
+			Status: StatusCompleted, RequestHash: "hash-1", ResponseCode: 409,
+		})
+	}
+	admin := NewIdempotencyAdmin(mockRedis)
+
+	find := func(tenantID, role, query string) (int, []string) {
+		router := setupIdempotencyTestRouter()
+		router.GET("/admin/idempotency-records", asAdmin(tenantID, role), admin.FindRecordsHandler())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/admin/idempotency-records"+query, nil))
+		var lookup struct {
+			Data struct {
+				Records []IdempotencyRecordInfo `json:"records"`
+			} `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &lookup)
+		tenants := make([]string, 0)
+		for _, record := range lookup.Data.Records {
+			tenants = append(tenants, record.TenantID)
+		}
+		sort.Strings(tenants)
+		return w.Code, tenants
+	}
+
+	tests := []struct {
+		name        string
+		tenantID    string
+		role        string
+		query       string
+		wantStatus  int
+		wantTenants []string
+	}{
+		{"admin sees own tenant", "tenant-1", "admin", "", http.StatusOK, []string{"tenant-1"}},
+		{"admin cannot ask for another tenant", "tenant-1", "admin", "?tenant_id=tenant-2", http.StatusOK, []string{"tenant-1"}},
+		{"admin without tenant", "", "admin", "?tenant_id=tenant-2", http.StatusForbidden, []string{}},
+		{"super_admin sees every tenant", "", "super_admin", "", http.StatusOK, []string{"tenant-1", "tenant-2"}},
+		{"super_admin picks a tenant", "", "super_admin", "?tenant_id=tenant-2", http.StatusOK, []string{"tenant-2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, tenants := find(tt.tenantID, tt.role, tt.query)
+			if status != tt.wantStatus || strings.Join(tenants, ",") != strings.Join(tt.wantTenants, ",") {
+				t.Errorf("lookup = %d %v, want %d %v", status, tenants, tt.wantStatus, tt.wantTenants)
+			}
+		})
+	}
+
+	// tenant_id is ignored for a tenant admin: the request reaches tenant-1's record, never tenant-2's
+	router := setupIdempotencyTestRouter()
+	router.DELETE("/admin/idempotency-records", asAdmin("tenant-1", "admin"), admin.InvalidateRecordHandler())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/admin/idempotency-records", strings.NewReader(
+		`{"tenant_id":"tenant-2","user_id":"user-1","route":"POST /api/v1/bookings/reserve","key":"key-00000001","request_hash":"hash-1","reason":"stale sold out response"}`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("invalidate status = %d, want 200 for the admin's own record: %s", w.Code, w.Body.String())
+	}
+	if _, tenants := find("", "super_admin", ""); strings.Join(tenants, ",") != "tenant-2" {
+		t.Errorf("records left = %v, want tenant-2's record untouched", tenants)
+	}
+}
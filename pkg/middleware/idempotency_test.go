@@ -424,9 +424,10 @@ func TestGenerateRequestHash(t *testing.T) {
 
 func TestCheckIdempotency(t *testing.T) {
 	mockRedis := NewMockRedisClient()
+	scope := IdempotencyScope{TenantID: "tenant-1", UserID: "user-1", Route: "POST /test"}
 
 	// Check non-existent key
-	record, err := CheckIdempotency(context.Background(), mockRedis, "non-existent")
+	record, err := CheckIdempotency(context.Background(), mockRedis, scope, "non-existent")
 	if record != nil {
 		t.Error("Expected nil record for non-existent key")
 	}
@@ -441,10 +442,10 @@ func TestCheckIdempotency(t *testing.T) {
 		RequestHash: "hash123",
 	}
 	data, _ := json.Marshal(storedRecord)
-	mockRedis.Set(context.Background(), IdempotencyKeyPrefix+"test-key", string(data), time.Hour)
+	mockRedis.Set(context.Background(), scope.RedisKey("test-key"), string(data), time.Hour)
 
 	// Check existing key
-	record, err = CheckIdempotency(context.Background(), mockRedis, "test-key")
+	record, err = CheckIdempotency(context.Background(), mockRedis, scope, "test-key")
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
@@ -458,24 +459,25 @@ func TestCheckIdempotency(t *testing.T) {
 
 func TestDeleteIdempotencyRecord(t *testing.T) {
 	mockRedis := NewMockRedisClient()
+	scope := IdempotencyScope{TenantID: "tenant-1", UserID: "user-1", Route: "POST /test"}
 
 	// Store a record
-	mockRedis.Set(context.Background(), IdempotencyKeyPrefix+"to-delete", "data", time.Hour)
+	mockRedis.Set(context.Background(), scope.RedisKey("to-delete"), "data", time.Hour)
 
 	// Verify it exists
-	result := mockRedis.Get(context.Background(), IdempotencyKeyPrefix+"to-delete")
+	result := mockRedis.Get(context.Background(), scope.RedisKey("to-delete"))
 	if result.Err() != nil {
 		t.Error("Record should exist before deletion")
 	}
 
 	// Delete it
-	err := DeleteIdempotencyRecord(context.Background(), mockRedis, "to-delete")
+	err := DeleteIdempotencyRecord(context.Background(), mockRedis, scope, "to-delete")
 	if err != nil {
 		t.Errorf("Delete failed: %v", err)
 	}
 
 	// Verify it's gone
-	result = mockRedis.Get(context.Background(), IdempotencyKeyPrefix+"to-delete")
+	result = mockRedis.Get(context.Background(), scope.RedisKey("to-delete"))
 	if result.Err() != redis.Nil {
 		t.Error("Record should not exist after deletion")
 	}
@@ -623,3 +625,120 @@ func TestIdempotencyMiddleware_DifferentErrorResponses(t *testing.T) {
 		t.Errorf("Responses should be identical. First: %s, Second: %s", w1.Body.String(), w2.Body.String())
 	}
 }
+
+// withIdentity sets the caller identity like the auth middlewares do
+func withIdentity(tenantID, userID string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(ContextKeyTenantID, tenantID)
+		c.Set(ContextKeyUserID, userID)
+		c.Next()
+	}
+}
+
+func TestIdempotencyMiddleware_ScopedByTenantAndUser(t *testing.T) {
+	mockRedis := NewMockRedisClient()
+	config := DefaultIdempotencyConfig(mockRedis)
+
+	requestCount := 0
+	handler := func(c *gin.Context) {
+		requestCount++
+		c.JSON(http.StatusOK, gin.H{"count": requestCount})
+	}
+	router := setupIdempotencyTestRouter()
+	router.POST("/a/test", withIdentity("tenant-a", "user-1"), IdempotencyMiddleware(config), handler)
+	router.POST("/b/test", withIdentity("tenant-b", "user-1"), IdempotencyMiddleware(config), handler)
+	router.POST("/a/other", withIdentity("tenant-a", "user-1"), IdempotencyMiddleware(config), handler)
+
+	send := func(path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("POST", path, bytes.NewBuffer([]byte(`{"key":"value"}`)))
+		req.Header.Set(IdempotencyKeyHeader, "shared-key-123")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// The same key from another tenant, or on another route, is a new request
+	for _, path := range []string{"/a/test", "/b/test", "/a/other", "/a/test"} {
+		if w := send(path); w.Code != http.StatusOK {
+			t.Fatalf("POST %s status = %d, want 200", path, w.Code)
+		}
+	}
+	if requestCount != 3 {
+		t.Errorf("handler ran %d times, want 3", requestCount)
+	}
+
+	scope := IdempotencyScope{TenantID: "tenant-b", UserID: "user-1", Route: "POST /b/test"}
+	record, err := CheckIdempotency(context.Background(), mockRedis, scope, "shared-key-123")
+	if err != nil || record.TenantID != "tenant-b" || record.Route != "POST /b/test" {
+		t.Errorf("CheckIdempotency() = %+v, %v, want tenant-b record", record, err)
+	}
+}
+
+func TestIdempotencyMiddleware_InvalidKey(t *testing.T) {
+	mockRedis := NewMockRedisClient()
+	config := DefaultIdempotencyConfig(mockRedis)
+
+	router := setupIdempotencyTestRouter()
+	router.POST("/test", IdempotencyMiddleware(config), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+
+	for _, key := range []string{"short", "has space-123", "tenant:key-123", string(bytes.Repeat([]byte("a"), MaxIdempotencyKeyLength+1))} {
+		req, _ := http.NewRequest("POST", "/test", nil)
+		req.Header.Set(IdempotencyKeyHeader, key)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("key %q: status = %d, want 400", key, w.Code)
+		}
+	}
+}
+
+func TestIdempotencyMiddleware_InProgressDiagnostics(t *testing.T) {
+	mockRedis := NewMockRedisClient()
+	config := DefaultIdempotencyConfig(mockRedis)
+	config.ProcessingTTL = 30 * time.Second
+
+	router := setupIdempotencyTestRouter()
+	router.POST("/test", withIdentity("tenant-a", "user-1"), IdempotencyMiddleware(config), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "success"})
+	})
+
+	// A first request still being processed
+	scope := IdempotencyScope{TenantID: "tenant-a", UserID: "user-1", Route: "POST /test"}
+	req, _ := http.NewRequest("POST", "/test", nil)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = req
+	c.Set(ContextKeyUserID, "user-1")
+	data, _ := json.Marshal(&IdempotencyRecord{
+		Key:         "pending-key-123",
+		Route:       scope.Route,
+		Status:      StatusProcessing,
+		RequestHash: generateRequestHash(c, nil, config),
+		CreatedAt:   time.Now().Add(-10 * time.Second),
+	})
+	mockRedis.Set(context.Background(), scope.RedisKey("pending-key-123"), string(data), time.Minute)
+
+	req, _ = http.NewRequest("POST", "/test", nil)
+	req.Header.Set(IdempotencyKeyHeader, "pending-key-123")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want 409", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "20" {
+		t.Errorf("Retry-After = %q, want 20", got)
+	}
+	var body struct {
+		Error struct {
+			Code    string            `json:"code"`
+			Details map[string]string `json:"details"`
+		} `json:"error"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &body)
+	if body.Error.Code != "REQUEST_IN_PROGRESS" || body.Error.Details["route"] != "POST /test" ||
+		body.Error.Details["original_status"] != "processing" || body.Error.Details["retry_after_seconds"] != "20" {
+		t.Errorf("unexpected conflict response: %s", w.Body.String())
+	}
+}
@@ -0,0 +1,252 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/response"
+)
+
+// Identity headers the API gateway forwards after validating the caller's JWT
+const (
+	HeaderUserID            = "X-User-ID"
+	HeaderUserEmail         = "X-User-Email"
+	HeaderUserRole          = "X-User-Role" // First entry of X-Roles, kept for services that read a single role
+	HeaderTenantID          = "X-Tenant-ID"
+	HeaderRoles             = "X-Roles" // Comma-separated
+	HeaderInternalTimestamp = "X-Internal-Timestamp"
+	HeaderInternalSignature = "X-Internal-Signature"
+)
+
+// ContextKeyRoles holds all roles of a caller authenticated through signed headers
+const ContextKeyRoles = "roles"
+
+// DefaultInternalClockSkew is how old a gateway signature may be before it is rejected
+const DefaultInternalClockSkew = 30 * time.Second
+
+var (
+	ErrMissingSignature = errors.New("missing internal signature")
+	ErrInvalidSignature = errors.New("invalid internal signature")
+	ErrSignatureExpired = errors.New("internal signature expired")
+)
+
+// identityHeaders are dropped from incoming requests so clients cannot spoof them
+var identityHeaders = []string{
+	HeaderUserID,
+	HeaderUserEmail,
+	HeaderUserRole,
+	HeaderTenantID,
+	HeaderRoles,
+	HeaderUserVerification,
+	HeaderInternalTimestamp,
+	HeaderInternalSignature,
+}
+
+// InternalClaims is the caller identity the gateway forwards to backend services
+type InternalClaims struct {
+	UserID   string
+	Email    string
+	TenantID string
+	Roles    []string
+	// Verification is the caller's account verification state (nil = not forwarded)
+	Verification *Verification
+}
+
+// Role returns the caller's primary role
+func (c *InternalClaims) Role() string {
+	if len(c.Roles) == 0 {
+		return ""
+	}
+	return c.Roles[0]
+}
+
+// StripIdentityHeaders removes identity headers set by the client
+func StripIdentityHeaders(h http.Header) {
+	for _, name := range identityHeaders {
+		h.Del(name)
+	}
+}
+
+// SignInternalRequest sets the identity headers and an HMAC signature over them.
+// The signature covers the method and path so it cannot be replayed against another endpoint.
+func SignInternalRequest(r *http.Request, secret string, claims *InternalClaims, now time.Time) {
+	StripIdentityHeaders(r.Header)
+
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	roles := strings.Join(claims.Roles, ",")
+	verification := ""
+	if claims.Verification != nil {
+		verification = claims.Verification.Encode()
+		r.Header.Set(HeaderUserVerification, verification)
+	}
+
+	r.Header.Set(HeaderUserID, claims.UserID)
+	if claims.Email != "" {
+		r.Header.Set(HeaderUserEmail, claims.Email)
+	}
+	if claims.TenantID != "" {
+		r.Header.Set(HeaderTenantID, claims.TenantID)
+	}
+	if roles != "" {
+		r.Header.Set(HeaderRoles, roles)
+		r.Header.Set(HeaderUserRole, claims.Role())
+	}
+	r.Header.Set(HeaderInternalTimestamp, timestamp)
+	r.Header.Set(HeaderInternalSignature, internalSignature(secret, r.Method, r.URL.Path, claims.UserID, claims.Email, claims.TenantID, roles, verification, timestamp))
+}
+
+// VerifyInternalRequest checks the gateway signature and returns the forwarded identity
+func VerifyInternalRequest(r *http.Request, secret string, maxSkew time.Duration, now time.Time) (*InternalClaims, error) {
+	signature := r.Header.Get(HeaderInternalSignature)
+	if signature == "" {
+		return nil, ErrMissingSignature
+	}
+	if maxSkew <= 0 {
+		maxSkew = DefaultInternalClockSkew
+	}
+
+	timestamp := r.Header.Get(HeaderInternalTimestamp)
+	signedAt, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+
+	userID := r.Header.Get(HeaderUserID)
+	email := r.Header.Get(HeaderUserEmail)
+	tenantID := r.Header.Get(HeaderTenantID)
+	roles := r.Header.Get(HeaderRoles)
+	verification := r.Header.Get(HeaderUserVerification)
+
+	expected := internalSignature(secret, r.Method, r.URL.Path, userID, email, tenantID, roles, verification, timestamp)
+	if userID == "" || !hmac.Equal([]byte(signature), []byte(expected)) {
+		return nil, ErrInvalidSignature
+	}
+
+	age := now.Sub(time.Unix(signedAt, 0))
+	if age > maxSkew || age < -maxSkew {
+		return nil, ErrSignatureExpired
+	}
+
+	claims := &InternalClaims{
+		UserID:   userID,
+		Email:    email,
+		TenantID: tenantID,
+	}
+	if roles != "" {
+		claims.Roles = strings.Split(roles, ",")
+	}
+	if verification != "" {
+		claims.Verification = ParseVerification(verification)
+	}
+	return claims, nil
+}
+
+// internalSignature computes the hex HMAC-SHA256 over the request line and identity
+func internalSignature(secret, method, path, userID, email, tenantID, roles, verification, timestamp string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strings.Join([]string{method, path, userID, email, tenantID, roles, verification, timestamp}, "\n")))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// InternalAuthConfig holds configuration for the internal auth middleware
+type InternalAuthConfig struct {
+	// Secret is the HMAC key shared with the API gateway
+	Secret string
+	// MaxClockSkew bounds the age of a signature (default DefaultInternalClockSkew)
+	MaxClockSkew time.Duration
+	// JWT validates a bearer token for callers that bypass the gateway (nil = signed headers only)
+	JWT *JWTConfig
+	// AllowUnsigned trusts plain identity headers when neither a signature nor a token is present.
+	// Only for services that are not reachable from outside (e.g. load tests hitting a service directly).
+	AllowUnsigned bool
+	// SkipPaths is a list of paths that should skip authentication
+	SkipPaths []string
+}
+
+// InternalAuthMiddleware authenticates requests forwarded by the API gateway.
+// Signed identity headers are verified with a single HMAC instead of re-validating the JWT.
+func InternalAuthMiddleware(config *InternalAuthConfig) gin.HandlerFunc {
+	var jwtMiddleware gin.HandlerFunc
+	if config.JWT != nil {
+		jwtMiddleware = JWTMiddleware(config.JWT)
+	}
+
+	return func(c *gin.Context) {
+		// Check if path should skip authentication
+		for _, path := range config.SkipPaths {
+			if c.Request.URL.Path == path {
+				c.Next()
+				return
+			}
+		}
+
+		if c.GetHeader(HeaderInternalSignature) != "" {
+			claims, err := VerifyInternalRequest(c.Request, config.Secret, config.MaxClockSkew, time.Now())
+			if err != nil {
+				if errors.Is(err, ErrSignatureExpired) {
+					c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error("SIGNATURE_EXPIRED", "Internal signature has expired"))
+					return
+				}
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error("INVALID_SIGNATURE", "Invalid internal signature"))
+				return
+			}
+
+			// Handlers reading the single-role header see the verified role
+			c.Request.Header.Set(HeaderUserRole, claims.Role())
+			setInternalClaims(c, claims)
+			c.Next()
+			return
+		}
+
+		if jwtMiddleware != nil && c.GetHeader("Authorization") != "" {
+			jwtMiddleware(c)
+			return
+		}
+
+		if config.AllowUnsigned {
+			if userID := c.GetHeader(HeaderUserID); userID != "" {
+				claims := &InternalClaims{
+					UserID:   userID,
+					Email:    c.GetHeader(HeaderUserEmail),
+					TenantID: c.GetHeader(HeaderTenantID),
+				}
+				if roles := c.GetHeader(HeaderRoles); roles != "" {
+					claims.Roles = strings.Split(roles, ",")
+				} else if role := c.GetHeader(HeaderUserRole); role != "" {
+					claims.Roles = []string{role}
+				}
+				if verification := c.GetHeader(HeaderUserVerification); verification != "" {
+					claims.Verification = ParseVerification(verification)
+				}
+				setInternalClaims(c, claims)
+			}
+			c.Next()
+			return
+		}
+
+		if jwtMiddleware != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error("MISSING_TOKEN", "Authorization header is required"))
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error("MISSING_SIGNATURE", "Internal signature is required"))
+	}
+}
+
+// setInternalClaims injects the caller identity using the same context keys as JWTMiddleware
+func setInternalClaims(c *gin.Context, claims *InternalClaims) {
+	c.Set(ContextKeyUserID, claims.UserID)
+	c.Set(ContextKeyEmail, claims.Email)
+	c.Set(ContextKeyRole, claims.Role())
+	c.Set(ContextKeyTenantID, claims.TenantID)
+	c.Set(ContextKeyRoles, claims.Roles)
+	if claims.Verification != nil {
+		c.Set(ContextKeyVerification, claims.Verification)
+	}
+}
@@ -0,0 +1,204 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testInternalSecret = "test-internal-secret"
+
+func signedRequest(method, path string, claims *InternalClaims, signedAt time.Time) *http.Request {
+	req := httptest.NewRequest(method, path, nil)
+	SignInternalRequest(req, testInternalSecret, claims, signedAt)
+	return req
+}
+
+func setupInternalAuthRouter(config *InternalAuthConfig) *gin.Engine {
+	router := gin.New()
+	router.Use(InternalAuthMiddleware(config))
+	router.Any("/protected", func(c *gin.Context) {
+		userID, _ := GetUserID(c)
+		role, _ := GetRole(c)
+		tenantID, _ := GetTenantID(c)
+		c.JSON(http.StatusOK, gin.H{
+			"user_id":   userID,
+			"role":      role,
+			"tenant_id": tenantID,
+			"header":    c.GetHeader(HeaderUserRole),
+		})
+	})
+	router.GET("/admin", RequireRole("admin"), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func TestVerifyInternalRequest(t *testing.T) {
+	claims := &InternalClaims{UserID: "user-123", Email: "a@example.com", TenantID: "tenant-1", Roles: []string{"organizer", "admin"}}
+	now := time.Now()
+
+	t.Run("valid signature", func(t *testing.T) {
+		got, err := VerifyInternalRequest(signedRequest("GET", "/api/v1/bookings", claims, now), testInternalSecret, 0, now)
+		if err != nil {
+			t.Fatalf("Expected valid signature, got %v", err)
+		}
+		if got.UserID != "user-123" || got.TenantID != "tenant-1" || got.Role() != "organizer" || len(got.Roles) != 2 {
+			t.Errorf("Unexpected claims: %+v", got)
+		}
+	})
+
+	tests := []struct {
+		name   string
+		mutate func(r *http.Request)
+		want   error
+	}{
+		{"missing signature", func(r *http.Request) { r.Header.Del(HeaderInternalSignature) }, ErrMissingSignature},
+		{"tampered user", func(r *http.Request) { r.Header.Set(HeaderUserID, "user-999") }, ErrInvalidSignature},
+		{"tampered roles", func(r *http.Request) { r.Header.Set(HeaderRoles, "admin") }, ErrInvalidSignature},
+		{"injected verification", func(r *http.Request) { r.Header.Set(HeaderUserVerification, "email_verified=true") }, ErrInvalidSignature},
+		{"other path", func(r *http.Request) { r.URL.Path = "/api/v1/admin" }, ErrInvalidSignature},
+		{"other method", func(r *http.Request) { r.Method = "DELETE" }, ErrInvalidSignature},
+		{"bad timestamp", func(r *http.Request) { r.Header.Set(HeaderInternalTimestamp, "soon") }, ErrInvalidSignature},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := signedRequest("GET", "/api/v1/bookings", claims, now)
+			tt.mutate(req)
+			if _, err := VerifyInternalRequest(req, testInternalSecret, 0, now); err != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+
+	t.Run("wrong secret", func(t *testing.T) {
+		req := signedRequest("GET", "/api/v1/bookings", claims, now)
+		if _, err := VerifyInternalRequest(req, "other-secret", 0, now); err != ErrInvalidSignature {
+			t.Errorf("Expected %v, got %v", ErrInvalidSignature, err)
+		}
+	})
+
+	t.Run("expired signature", func(t *testing.T) {
+		req := signedRequest("GET", "/api/v1/bookings", claims, now.Add(-time.Minute))
+		if _, err := VerifyInternalRequest(req, testInternalSecret, 30*time.Second, now); err != ErrSignatureExpired {
+			t.Errorf("Expected %v, got %v", ErrSignatureExpired, err)
+		}
+	})
+}
+
+func TestInternalAuthMiddleware(t *testing.T) {
+	claims := &InternalClaims{UserID: "user-123", TenantID: "tenant-1", Roles: []string{"organizer", "admin"}}
+	jwtConfig := &JWTConfig{Secret: testSecret}
+
+	tests := []struct {
+		name       string
+		config     *InternalAuthConfig
+		request    func() *http.Request
+		wantStatus int
+	}{
+		{
+			name:   "signed headers",
+			config: &InternalAuthConfig{Secret: testInternalSecret},
+			request: func() *http.Request {
+				return signedRequest("GET", "/protected", claims, time.Now())
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "forged signature is rejected even when unsigned headers are allowed",
+			config: &InternalAuthConfig{Secret: testInternalSecret, AllowUnsigned: true},
+			request: func() *http.Request {
+				req := signedRequest("GET", "/protected", claims, time.Now())
+				req.Header.Set(HeaderUserID, "user-999")
+				return req
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "bearer token fallback",
+			config: &InternalAuthConfig{Secret: testInternalSecret, JWT: jwtConfig},
+			request: func() *http.Request {
+				req := httptest.NewRequest("GET", "/protected", nil)
+				req.Header.Set("Authorization", "Bearer "+generateTestToken(jwt.MapClaims{
+					"user_id": "user-123",
+					"exp":     time.Now().Add(time.Hour).Unix(),
+				}, testSecret))
+				return req
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "missing credentials with token fallback",
+			config: &InternalAuthConfig{Secret: testInternalSecret, JWT: jwtConfig},
+			request: func() *http.Request {
+				req := httptest.NewRequest("GET", "/protected", nil)
+				req.Header.Set(HeaderUserID, "user-123")
+				return req
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "unsigned headers allowed",
+			config: &InternalAuthConfig{Secret: testInternalSecret, AllowUnsigned: true},
+			request: func() *http.Request {
+				req := httptest.NewRequest("GET", "/protected", nil)
+				req.Header.Set(HeaderUserID, "user-123")
+				return req
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "unsigned headers rejected",
+			config: &InternalAuthConfig{Secret: testInternalSecret},
+			request: func() *http.Request {
+				req := httptest.NewRequest("GET", "/protected", nil)
+				req.Header.Set(HeaderUserID, "user-123")
+				return req
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			setupInternalAuthRouter(tt.config).ServeHTTP(w, tt.request())
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestInternalAuthMiddleware_SetsVerifiedRole(t *testing.T) {
+	router := setupInternalAuthRouter(&InternalAuthConfig{Secret: testInternalSecret})
+
+	req := signedRequest("GET", "/protected", &InternalClaims{UserID: "user-123", Roles: []string{"organizer"}}, time.Now())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	want := `{"header":"organizer","role":"organizer","tenant_id":"","user_id":"user-123"}`
+	if w.Body.String() != want {
+		t.Errorf("Expected %s, got %s", want, w.Body.String())
+	}
+}
+
+func TestRequireRole_SecondaryRole(t *testing.T) {
+	router := setupInternalAuthRouter(&InternalAuthConfig{Secret: testInternalSecret})
+
+	req := signedRequest("GET", "/admin", &InternalClaims{UserID: "user-123", Roles: []string{"organizer", "admin"}}, time.Now())
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+}
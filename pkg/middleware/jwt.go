@@ -31,6 +31,8 @@ type JWTConfig struct {
 	Secret string
 	// SkipPaths is a list of paths that should skip JWT validation
 	SkipPaths []string
+	// TenantIsolation validates per-tenant issuer and audience claims; nil accepts tokens of any tenant
+	TenantIsolation *TenantIsolation
 }
 
 // JWTMiddleware creates a new JWT validation middleware
@@ -105,11 +107,26 @@ func JWTMiddleware(config *JWTConfig) gin.HandlerFunc {
 		role, _ := claims["role"].(string)
 		tenantID, _ := claims["tenant_id"].(string)
 
+		var audience []string
+		if config.TenantIsolation != nil {
+			audience, err = config.TenantIsolation.Verify(claims, tenantID)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error("INVALID_TOKEN", "Token was not issued for its tenant"))
+				return
+			}
+			if target := config.TenantIsolation.Target; target != nil && !MayAccessTenant(audience, tenantID, role, target(c)) {
+				c.AbortWithStatusJSON(http.StatusForbidden, response.Error("TENANT_MISMATCH", "Token was not issued for this tenant"))
+				return
+			}
+		}
+
 		// Inject user context into request
 		c.Set(ContextKeyUserID, userID)
 		c.Set(ContextKeyEmail, email)
 		c.Set(ContextKeyRole, role)
 		c.Set(ContextKeyTenantID, tenantID)
+		c.Set(ContextKeyAudience, audience)
+		c.Set(ContextKeyVerification, VerificationFromClaims(claims))
 
 		c.Next()
 	}
@@ -138,6 +155,18 @@ func RequireRole(roles ...string) gin.HandlerFunc {
 			}
 		}
 
+		// Callers forwarded by the gateway may carry more than one role
+		if userRoles, ok := c.Get(ContextKeyRoles); ok {
+			for _, userRole := range userRoles.([]string) {
+				for _, r := range roles {
+					if userRole == r {
+						c.Next()
+						return
+					}
+				}
+			}
+		}
+
 		c.AbortWithStatusJSON(http.StatusForbidden, response.Error("FORBIDDEN", "Insufficient permissions"))
 	}
 }
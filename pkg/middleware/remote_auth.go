@@ -0,0 +1,348 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/response"
+	"golang.org/x/sync/singleflight"
+)
+
+// Response headers of auth-service's POST /api/v1/auth/validate
+const (
+	HeaderAuthRevokedAt = "X-Auth-Revoked-At" // Unix seconds of the user's last token revocation
+)
+
+// validatePath is auth-service's token validation endpoint
+const validatePath = "/api/v1/auth/validate"
+
+var (
+	ErrTokenRevoked    = errors.New("token revoked")
+	ErrAuthUnavailable = errors.New("auth service unavailable")
+)
+
+// RemoteAuthConfig holds configuration for validating tokens against auth-service
+type RemoteAuthConfig struct {
+	// AuthServiceURL is the base URL of auth-service
+	AuthServiceURL string
+	// HTTPClient sends validation requests (default: 2s timeout)
+	HTTPClient *http.Client
+	// MaxAge caps how long a result is reused without asking auth-service; the max-age
+	// auth-service returns applies when it is shorter (default: 5s)
+	MaxAge time.Duration
+	// StaleTTL is how long an expired result is kept so it can be revalidated with
+	// If-None-Match instead of fetched again (default: 1m)
+	StaleTTL time.Duration
+	// MaxEntries bounds the number of cached results (default: 10000)
+	MaxEntries int
+	// SkipPaths is a list of paths that should skip validation
+	SkipPaths []string
+}
+
+// ValidatedToken is the caller identity auth-service returned for a token
+type ValidatedToken struct {
+	UserID       string
+	Email        string
+	Role         string
+	TenantID     string
+	Audience     []string // nil for tokens issued before tenant isolation
+	IssuedAt     time.Time
+	Verification *Verification
+}
+
+// cachedToken is a validation result kept by TokenValidator
+type cachedToken struct {
+	token      *ValidatedToken
+	etag       string
+	freshUntil time.Time
+	staleUntil time.Time
+}
+
+// TokenValidator validates access tokens with auth-service and caches the results by
+// token hash. Expired results are revalidated with their ETag, and a newer revocation
+// reported for a user drops that user's older tokens at once.
+type TokenValidator struct {
+	config *RemoteAuthConfig
+	now    func() time.Time
+	group  singleflight.Group
+
+	mu        sync.Mutex
+	entries   map[string]*cachedToken // token hash -> result
+	revokedAt map[string]time.Time    // user ID -> latest revocation seen
+}
+
+// NewTokenValidator creates a new TokenValidator
+func NewTokenValidator(config *RemoteAuthConfig) *TokenValidator {
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: 2 * time.Second}
+	}
+	if config.MaxAge == 0 {
+		config.MaxAge = 5 * time.Second
+	}
+	if config.StaleTTL == 0 {
+		config.StaleTTL = time.Minute
+	}
+	if config.MaxEntries == 0 {
+		config.MaxEntries = 10000
+	}
+	config.AuthServiceURL = strings.TrimRight(config.AuthServiceURL, "/")
+	return &TokenValidator{
+		config:    config,
+		now:       time.Now,
+		entries:   make(map[string]*cachedToken),
+		revokedAt: make(map[string]time.Time),
+	}
+}
+
+// Validate returns the identity of a token, asking auth-service only when no fresh
+// result is cached. Concurrent requests with the same token share one call.
+func (v *TokenValidator) Validate(ctx context.Context, token string) (*ValidatedToken, error) {
+	sum := sha256.Sum256([]byte(token))
+	key := hex.EncodeToString(sum[:])
+
+	entry := v.lookup(key)
+	if entry != nil && v.now().Before(entry.freshUntil) {
+		return entry.token, nil
+	}
+
+	result, err, _ := v.group.Do(key, func() (interface{}, error) {
+		return v.fetch(ctx, key, token, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*ValidatedToken), nil
+}
+
+// lookup returns the cached result of a token unless it is past its stale window or was
+// issued before the user's latest revocation
+func (v *TokenValidator) lookup(key string) *cachedToken {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	entry, ok := v.entries[key]
+	if !ok {
+		return nil
+	}
+	revokedAt := v.revokedAt[entry.token.UserID]
+	if !v.now().Before(entry.staleUntil) || entry.token.IssuedAt.Before(revokedAt.Truncate(time.Second)) {
+		delete(v.entries, key)
+		return nil
+	}
+	return entry
+}
+
+// fetch validates a token with auth-service, revalidating a stale result when there is one
+func (v *TokenValidator) fetch(ctx context.Context, key, token string, stale *cachedToken) (*ValidatedToken, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.config.AuthServiceURL+validatePath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrAuthUnavailable, err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if stale != nil {
+		req.Header.Set("If-None-Match", stale.etag)
+	}
+
+	resp, err := v.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrAuthUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && stale != nil:
+		v.store(key, stale.token, stale.etag, resp.Header)
+		return stale.token, nil
+	case resp.StatusCode == http.StatusOK:
+		validated, err := decodeValidatedToken(resp)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrAuthUnavailable, err)
+		}
+		v.store(key, validated, resp.Header.Get("ETag"), resp.Header)
+		return validated, nil
+	case resp.StatusCode == http.StatusUnauthorized:
+		v.forget(key)
+		return nil, validationError(resp)
+	default:
+		return nil, fmt.Errorf("%w: status %d", ErrAuthUnavailable, resp.StatusCode)
+	}
+}
+
+// store caches a result for the max-age auth-service allowed, and records the user's
+// latest revocation so older tokens of the user are dropped from the cache
+func (v *TokenValidator) store(key string, token *ValidatedToken, etag string, header http.Header) {
+	now := v.now()
+	maxAge := v.config.MaxAge
+	if seconds, ok := parseMaxAge(header.Get("Cache-Control")); ok && seconds < maxAge {
+		maxAge = seconds
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if unix, err := strconv.ParseInt(header.Get(HeaderAuthRevokedAt), 10, 64); err == nil {
+		if revokedAt := time.Unix(unix, 0); revokedAt.After(v.revokedAt[token.UserID]) {
+			v.revokedAt[token.UserID] = revokedAt
+		}
+	}
+
+	if len(v.entries) >= v.config.MaxEntries {
+		for k, entry := range v.entries {
+			if !now.Before(entry.staleUntil) {
+				delete(v.entries, k)
+			}
+		}
+		for k := range v.entries {
+			if len(v.entries) < v.config.MaxEntries {
+				break
+			}
+			delete(v.entries, k)
+		}
+	}
+	v.entries[key] = &cachedToken{
+		token:      token,
+		etag:       etag,
+		freshUntil: now.Add(maxAge),
+		staleUntil: now.Add(maxAge + v.config.StaleTTL),
+	}
+}
+
+// forget drops the cached result of a rejected token
+func (v *TokenValidator) forget(key string) {
+	v.mu.Lock()
+	delete(v.entries, key)
+	v.mu.Unlock()
+}
+
+// decodeValidatedToken reads the identity from a successful validation response
+func decodeValidatedToken(resp *http.Response) (*ValidatedToken, error) {
+	var body struct {
+		Data struct {
+			UserID        string     `json:"user_id"`
+			Email         string     `json:"email"`
+			Role          string     `json:"role"`
+			TenantID      string     `json:"tenant_id"`
+			Audience      []string   `json:"audience"`
+			EmailVerified bool       `json:"email_verified"`
+			PhoneVerified bool       `json:"phone_verified"`
+			RiskLevel     string     `json:"risk_level"`
+			VerifiedAt    *time.Time `json:"verified_at"`
+			IssuedAt      int64      `json:"issued_at"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode validation response: %w", err)
+	}
+	if body.Data.UserID == "" {
+		return nil, errors.New("validation response has no user_id")
+	}
+
+	data := body.Data
+	verification := &Verification{
+		EmailVerified: data.EmailVerified,
+		PhoneVerified: data.PhoneVerified,
+		RiskLevel:     data.RiskLevel,
+	}
+	if data.VerifiedAt != nil {
+		verification.VerifiedAt = *data.VerifiedAt
+	}
+	return &ValidatedToken{
+		UserID:       data.UserID,
+		Email:        data.Email,
+		Role:         data.Role,
+		TenantID:     data.TenantID,
+		Audience:     data.Audience,
+		IssuedAt:     time.Unix(data.IssuedAt, 0),
+		Verification: verification,
+	}, nil
+}
+
+// validationError maps a 401 from auth-service to the matching token error
+func validationError(resp *http.Response) error {
+	var body response.Response
+	if err := json.NewDecoder(resp.Body).Decode(&body); err == nil && body.Error != nil {
+		switch body.Error.Code {
+		case "TOKEN_EXPIRED":
+			return ErrTokenExpired
+		case "TOKEN_REVOKED":
+			return ErrTokenRevoked
+		}
+	}
+	return ErrInvalidToken
+}
+
+// parseMaxAge reads the max-age directive of a Cache-Control header
+func parseMaxAge(cacheControl string) (time.Duration, bool) {
+	for _, directive := range strings.Split(cacheControl, ",") {
+		directive = strings.TrimSpace(directive)
+		if strings.EqualFold(directive, "no-store") || strings.EqualFold(directive, "no-cache") {
+			return 0, true
+		}
+		if value, ok := strings.CutPrefix(directive, "max-age="); ok {
+			if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+				return time.Duration(seconds) * time.Second, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// RemoteAuthMiddleware authenticates requests by validating their bearer token with
+// auth-service, for services that are called without the API gateway in front. It sets
+// the same context keys as JWTMiddleware.
+func RemoteAuthMiddleware(validator *TokenValidator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, path := range validator.config.SkipPaths {
+			if c.Request.URL.Path == path {
+				c.Next()
+				return
+			}
+		}
+
+		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error("MISSING_TOKEN", "Authorization header is required"))
+			return
+		}
+
+		const bearerPrefix = "Bearer "
+		if !strings.HasPrefix(authHeader, bearerPrefix) || len(authHeader) == len(bearerPrefix) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error("INVALID_TOKEN", "Invalid authorization header format"))
+			return
+		}
+
+		validated, err := validator.Validate(c.Request.Context(), authHeader[len(bearerPrefix):])
+		if err != nil {
+			switch {
+			case errors.Is(err, ErrTokenExpired):
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error("TOKEN_EXPIRED", "Access token has expired"))
+			case errors.Is(err, ErrTokenRevoked):
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error("TOKEN_REVOKED", "Access token has been revoked"))
+			case errors.Is(err, ErrInvalidToken):
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response.Error("INVALID_TOKEN", "Invalid access token"))
+			default:
+				c.AbortWithStatusJSON(http.StatusServiceUnavailable, response.Error("AUTH_UNAVAILABLE", "Token validation is temporarily unavailable"))
+			}
+			return
+		}
+
+		c.Set(ContextKeyUserID, validated.UserID)
+		c.Set(ContextKeyEmail, validated.Email)
+		c.Set(ContextKeyRole, validated.Role)
+		c.Set(ContextKeyTenantID, validated.TenantID)
+		c.Set(ContextKeyAudience, validated.Audience)
+		c.Set(ContextKeyVerification, validated.Verification)
+
+		c.Next()
+	}
+}
@@ -0,0 +1,200 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeAuthService serves POST /api/v1/auth/validate the way auth-service does
+type fakeAuthService struct {
+	mu        sync.Mutex
+	issuedAt  int64
+	revokedAt int64
+	status    int
+	code      string
+	calls     atomic.Int32
+	notMod    atomic.Int32
+}
+
+func (f *fakeAuthService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.calls.Add(1)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.status != 0 {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(f.status)
+		_, _ = w.Write([]byte(`{"success":false,"error":{"code":"` + f.code + `","message":"rejected"}}`))
+		return
+	}
+
+	etag := `"etag-` + strconv.FormatInt(f.revokedAt, 10) + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "private, max-age=5")
+	if f.revokedAt != 0 {
+		w.Header().Set(HeaderAuthRevokedAt, strconv.FormatInt(f.revokedAt, 10))
+	}
+	if r.Header.Get("If-None-Match") == etag {
+		f.notMod.Add(1)
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`{"success":true,"data":{"user_id":"user-123","email":"test@example.com","role":"customer","tenant_id":"tenant-456","email_verified":true,"risk_level":"low","issued_at":` + strconv.FormatInt(f.issuedAt, 10) + `}}`))
+}
+
+func newTestValidator(t *testing.T, fake *fakeAuthService) (*TokenValidator, *time.Time) {
+	t.Helper()
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	now := time.Now()
+	v := NewTokenValidator(&RemoteAuthConfig{AuthServiceURL: server.URL + "/", SkipPaths: []string{"/health"}})
+	v.now = func() time.Time { return now }
+	return v, &now
+}
+
+func TestTokenValidator_Validate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("fresh result is reused", func(t *testing.T) {
+		fake := &fakeAuthService{issuedAt: time.Now().Unix()}
+		v, _ := newTestValidator(t, fake)
+
+		for i := 0; i < 3; i++ {
+			validated, err := v.Validate(ctx, "token-a")
+			if err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if validated.UserID != "user-123" || !validated.Verification.EmailVerified {
+				t.Errorf("Validate() = %+v", validated)
+			}
+		}
+		if fake.calls.Load() != 1 {
+			t.Errorf("auth-service called %d times, want 1", fake.calls.Load())
+		}
+	})
+
+	t.Run("stale result is revalidated with its ETag", func(t *testing.T) {
+		fake := &fakeAuthService{issuedAt: time.Now().Unix()}
+		v, now := newTestValidator(t, fake)
+
+		if _, err := v.Validate(ctx, "token-a"); err != nil {
+			t.Fatalf("Validate() error = %v", err)
+		}
+		*now = now.Add(6 * time.Second)
+		validated, err := v.Validate(ctx, "token-a")
+		if err != nil {
+			t.Fatalf("Validate() error = %v", err)
+		}
+		if validated.UserID != "user-123" || fake.notMod.Load() != 1 {
+			t.Errorf("Validate() = %+v after %d not-modified responses, want 1", validated, fake.notMod.Load())
+		}
+	})
+
+	t.Run("revocation drops older tokens of the user", func(t *testing.T) {
+		issuedAt := time.Now().Add(-time.Minute).Unix()
+		fake := &fakeAuthService{issuedAt: issuedAt}
+		v, _ := newTestValidator(t, fake)
+
+		if _, err := v.Validate(ctx, "token-old"); err != nil {
+			t.Fatalf("Validate() error = %v", err)
+		}
+
+		// A newer token of the same user reports the revocation
+		fake.mu.Lock()
+		fake.issuedAt = time.Now().Unix()
+		fake.revokedAt = issuedAt + 1
+		fake.mu.Unlock()
+		if _, err := v.Validate(ctx, "token-new"); err != nil {
+			t.Fatalf("Validate() error = %v", err)
+		}
+
+		fake.mu.Lock()
+		fake.status, fake.code = http.StatusUnauthorized, "TOKEN_REVOKED"
+		fake.mu.Unlock()
+		if _, err := v.Validate(ctx, "token-old"); !errors.Is(err, ErrTokenRevoked) {
+			t.Errorf("Validate() error = %v, want %v", err, ErrTokenRevoked)
+		}
+	})
+
+	t.Run("rejections are mapped", func(t *testing.T) {
+		tests := []struct {
+			status  int
+			code    string
+			wantErr error
+		}{
+			{http.StatusUnauthorized, "TOKEN_EXPIRED", ErrTokenExpired},
+			{http.StatusUnauthorized, "INVALID_TOKEN", ErrInvalidToken},
+			{http.StatusServiceUnavailable, "VALIDATION_UNAVAILABLE", ErrAuthUnavailable},
+		}
+		for _, tt := range tests {
+			fake := &fakeAuthService{status: tt.status, code: tt.code}
+			v, _ := newTestValidator(t, fake)
+			if _, err := v.Validate(ctx, "token-a"); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() %s error = %v, want %v", tt.code, err, tt.wantErr)
+			}
+		}
+	})
+}
+
+func TestRemoteAuthMiddleware(t *testing.T) {
+	fake := &fakeAuthService{issuedAt: time.Now().Unix()}
+	v, _ := newTestValidator(t, fake)
+
+	router := gin.New()
+	router.Use(RemoteAuthMiddleware(v))
+	router.GET("/protected", func(c *gin.Context) {
+		userID, _ := GetUserID(c)
+		tenantID, _ := GetTenantID(c)
+		c.JSON(http.StatusOK, gin.H{"user_id": userID, "tenant_id": tenantID})
+	})
+	router.GET("/health", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		name       string
+		path       string
+		auth       string
+		wantStatus int
+	}{
+		{"valid token", "/protected", "Bearer token-a", http.StatusOK},
+		{"missing token", "/protected", "", http.StatusUnauthorized},
+		{"malformed header", "/protected", "Basic abc", http.StatusUnauthorized},
+		{"skip path", "/health", "", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+
+	fake.mu.Lock()
+	fake.status, fake.code = http.StatusBadGateway, ""
+	fake.mu.Unlock()
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer token-b")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d when auth-service is down", w.Code, http.StatusServiceUnavailable)
+	}
+}
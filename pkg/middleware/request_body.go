@@ -0,0 +1,270 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/response"
+)
+
+// Error codes returned by RequestBodyMiddleware
+const (
+	ErrCodePayloadTooLarge      = "PAYLOAD_TOO_LARGE"
+	ErrCodeUnsupportedMediaType = "UNSUPPORTED_MEDIA_TYPE"
+	ErrCodeInvalidJSON          = "INVALID_JSON"
+)
+
+// Defaults of RequestBodyConfig
+const (
+	DefaultMaxBodyBytes = 1 << 20 // 1MB
+	DefaultMaxJSONDepth = 32
+)
+
+var (
+	ErrJSONTooDeep      = errors.New("json nests objects and arrays too deeply")
+	ErrJSONDuplicateKey = errors.New("json object repeats a key")
+	ErrJSONTrailingData = errors.New("json body holds more than one value")
+)
+
+// RequestBodyConfig holds configuration for request body enforcement
+type RequestBodyConfig struct {
+	// MaxBytes is the largest body accepted on routes without their own limit (default: 1MB)
+	MaxBytes int64
+	// RouteMaxBytes overrides MaxBytes per route, keyed by "METHOD /path" using the gin
+	// route pattern, e.g. "POST /api/v1/bookings/:id/confirm"
+	RouteMaxBytes map[string]int64
+	// ContentTypes are the media types bodies may have (default: application/json)
+	ContentTypes []string
+	// MaxJSONDepth is how deeply JSON objects and arrays may nest (default: 32)
+	MaxJSONDepth int
+	// AllowDuplicateKeys accepts JSON objects that repeat a key. encoding/json keeps the last
+	// value, so a repeated key can smuggle a value past a check that read the first one.
+	AllowDuplicateKeys bool
+}
+
+// DefaultRequestBodyConfig returns default configuration
+func DefaultRequestBodyConfig() *RequestBodyConfig {
+	return &RequestBodyConfig{
+		MaxBytes:     DefaultMaxBodyBytes,
+		ContentTypes: []string{"application/json"},
+		MaxJSONDepth: DefaultMaxJSONDepth,
+	}
+}
+
+// ParseRouteBodyLimits parses comma-separated limits of the form "METHOD /path=size", where
+// size is bytes or a KB/MB amount, e.g. "POST /api/v1/bookings/reserve=16KB,PUT /api/v1/admin/zones/:id/seat-map=4MB"
+func ParseRouteBodyLimits(spec string) (map[string]int64, error) {
+	limits := make(map[string]int64)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		sep := strings.LastIndex(entry, "=")
+		if sep <= 0 {
+			return nil, fmt.Errorf("invalid body limit %q: expected METHOD /path=size", entry)
+		}
+		route := strings.Join(strings.Fields(entry[:sep]), " ")
+		if len(strings.Fields(route)) != 2 {
+			return nil, fmt.Errorf("invalid body limit %q: route must be METHOD /path", entry)
+		}
+		size, err := parseBodySize(strings.TrimSpace(entry[sep+1:]))
+		if err != nil || size <= 0 {
+			return nil, fmt.Errorf("invalid body limit %q: size must be a positive number of bytes, KB or MB", entry)
+		}
+		limits[route] = size
+	}
+	return limits, nil
+}
+
+// parseBodySize parses "512", "16KB" or "1MB" (binary units)
+func parseBodySize(s string) (int64, error) {
+	multiplier := int64(1)
+	upper := strings.ToUpper(s)
+	for _, unit := range []struct {
+		suffix string
+		size   int64
+	}{{"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1}} {
+		if strings.HasSuffix(upper, unit.suffix) {
+			multiplier = unit.size
+			upper = strings.TrimSpace(upper[:len(upper)-len(unit.suffix)])
+			break
+		}
+	}
+	n, err := strconv.ParseInt(upper, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return n * multiplier, nil
+}
+
+// RequestBodyMiddleware rejects request bodies before they are bound: bodies over the
+// route's size limit (413), of a media type not in ContentTypes (415), and JSON that is
+// malformed, nested too deeply or repeats an object key (400). Requests without a body
+// pass through. Accepted bodies are buffered and handed on unchanged, so handlers that
+// verify signatures over the raw body still see the exact bytes.
+func RequestBodyMiddleware(config *RequestBodyConfig) gin.HandlerFunc {
+	if config == nil {
+		config = DefaultRequestBodyConfig()
+	}
+	if config.MaxBytes <= 0 {
+		config.MaxBytes = DefaultMaxBodyBytes
+	}
+	if len(config.ContentTypes) == 0 {
+		config.ContentTypes = []string{"application/json"}
+	}
+	if config.MaxJSONDepth <= 0 {
+		config.MaxJSONDepth = DefaultMaxJSONDepth
+	}
+	allowed := make(map[string]bool, len(config.ContentTypes))
+	for _, contentType := range config.ContentTypes {
+		allowed[strings.ToLower(contentType)] = true
+	}
+
+	return func(c *gin.Context) {
+		if c.Request.Body == nil || c.Request.Body == http.NoBody || c.Request.ContentLength == 0 {
+			c.Next()
+			return
+		}
+
+		limit := config.MaxBytes
+		if routeLimit, ok := config.RouteMaxBytes[c.Request.Method+" "+c.FullPath()]; ok {
+			limit = routeLimit
+		}
+		if c.Request.ContentLength > limit {
+			abortBodyTooLarge(c, limit)
+			return
+		}
+
+		// Chunked bodies have no length up front; read one byte past the limit to detect them
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, limit+1))
+		_ = c.Request.Body.Close()
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, response.Error(response.ErrCodeBadRequest, "failed to read request body"))
+			return
+		}
+		if int64(len(body)) > limit {
+			abortBodyTooLarge(c, limit)
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		if len(body) == 0 {
+			c.Next()
+			return
+		}
+
+		mediaType, _, err := mime.ParseMediaType(c.GetHeader("Content-Type"))
+		if err != nil || !allowed[mediaType] {
+			c.AbortWithStatusJSON(http.StatusUnsupportedMediaType, response.Error(ErrCodeUnsupportedMediaType,
+				"Content-Type must be one of "+strings.Join(config.ContentTypes, ", ")))
+			return
+		}
+
+		if mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+			if err := checkJSONShape(body, config.MaxJSONDepth, config.AllowDuplicateKeys); err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, response.Error(ErrCodeInvalidJSON, err.Error()))
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+func abortBodyTooLarge(c *gin.Context, limit int64) {
+	c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, response.Error(ErrCodePayloadTooLarge,
+		fmt.Sprintf("request body must be at most %d bytes", limit)))
+}
+
+// jsonFrame is an object or array being read by checkJSONShape
+type jsonFrame struct {
+	object    bool
+	expectKey bool // The next token of an object is a key or its closing brace
+	keys      map[string]struct{}
+}
+
+// checkJSONShape reads body token by token, without building values, and reports
+// syntax errors, nesting deeper than maxDepth, repeated object keys and trailing values
+func checkJSONShape(body []byte, maxDepth int, allowDuplicateKeys bool) error {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var stack []*jsonFrame
+	values := 0
+	// valueDone notes that a value ended in the innermost container, or at the top level
+	valueDone := func() {
+		if len(stack) == 0 {
+			values++
+			return
+		}
+		if top := stack[len(stack)-1]; top.object {
+			top.expectKey = true
+		}
+	}
+
+	for {
+		token, err := dec.Token()
+		if err == io.EOF {
+			if len(stack) > 0 {
+				return errors.New("malformed json: unexpected end of body")
+			}
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("malformed json: %w", err)
+		}
+		if values > 0 {
+			return ErrJSONTrailingData
+		}
+
+		if len(stack) > 0 {
+			if top := stack[len(stack)-1]; top.object && top.expectKey {
+				if key, ok := token.(string); ok {
+					if !allowDuplicateKeys {
+						if _, seen := top.keys[key]; seen {
+							return fmt.Errorf("%w: %q", ErrJSONDuplicateKey, key)
+						}
+						top.keys[key] = struct{}{}
+					}
+					top.expectKey = false
+					continue
+				}
+			}
+		}
+
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			if len(stack) >= maxDepth {
+				return fmt.Errorf("%w (max %d)", ErrJSONTooDeep, maxDepth)
+			}
+			frame := &jsonFrame{}
+			if token == json.Delim('{') {
+				frame.object = true
+				frame.expectKey = true
+				if !allowDuplicateKeys {
+					frame.keys = make(map[string]struct{})
+				}
+			}
+			stack = append(stack, frame)
+		case json.Delim('}'), json.Delim(']'):
+			stack = stack[:len(stack)-1]
+			valueDone()
+		default:
+			valueDone()
+		}
+	}
+
+	if values == 0 {
+		return errors.New("malformed json: empty body")
+	}
+	return nil
+}
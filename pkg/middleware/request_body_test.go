@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newRequestBodyRouter(config *RequestBodyConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestBodyMiddleware(config))
+	echo := func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.String(http.StatusOK, string(body))
+	}
+	router.POST("/bookings/reserve", echo)
+	router.POST("/bookings/:id/confirm", echo)
+	return router
+}
+
+func TestRequestBodyMiddleware(t *testing.T) {
+	router := newRequestBodyRouter(&RequestBodyConfig{
+		MaxBytes:      64,
+		RouteMaxBytes: map[string]int64{"POST /bookings/:id/confirm": 16},
+	})
+
+	tests := []struct {
+		name        string
+		path        string
+		contentType string
+		body        string
+		chunked     bool
+		wantStatus  int
+		wantCode    string
+	}{
+		{"valid json", "/bookings/reserve", "application/json", `{"zone_id":"a","quantity":2}`, false, http.StatusOK, ""},
+		{"json with charset", "/bookings/reserve", "application/json; charset=utf-8", `{"zone_id":"a"}`, false, http.StatusOK, ""},
+		{"no body", "/bookings/b-1/confirm", "", "", false, http.StatusOK, ""},
+		{"over default limit", "/bookings/reserve", "application/json", `{"zone_id":"` + strings.Repeat("a", 64) + `"}`, false, http.StatusRequestEntityTooLarge, ErrCodePayloadTooLarge},
+		{"over route limit", "/bookings/b-1/confirm", "application/json", `{"payment_id":"pay-123456"}`, false, http.StatusRequestEntityTooLarge, ErrCodePayloadTooLarge},
+		{"chunked over limit", "/bookings/reserve", "application/json", `{"zone_id":"` + strings.Repeat("a", 64) + `"}`, true, http.StatusRequestEntityTooLarge, ErrCodePayloadTooLarge},
+		{"missing content type", "/bookings/reserve", "", `{"zone_id":"a"}`, false, http.StatusUnsupportedMediaType, ErrCodeUnsupportedMediaType},
+		{"form body", "/bookings/reserve", "application/x-www-form-urlencoded", `zone_id=a`, false, http.StatusUnsupportedMediaType, ErrCodeUnsupportedMediaType},
+		{"malformed json", "/bookings/reserve", "application/json", `{"zone_id":`, false, http.StatusBadRequest, ErrCodeInvalidJSON},
+		{"duplicate key", "/bookings/reserve", "application/json", `{"quantity":1,"quantity":100}`, false, http.StatusBadRequest, ErrCodeInvalidJSON},
+		{"trailing value", "/bookings/reserve", "application/json", `{"quantity":1}{"quantity":2}`, false, http.StatusBadRequest, ErrCodeInvalidJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			if tt.body == "" {
+				req = httptest.NewRequest(http.MethodPost, tt.path, nil)
+			}
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantCode != "" && !strings.Contains(w.Body.String(), `"code":"`+tt.wantCode+`"`) {
+				t.Errorf("expected code %s, got %s", tt.wantCode, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK && w.Body.String() != tt.body {
+				t.Errorf("handler read %q, want the original body %q", w.Body.String(), tt.body)
+			}
+		})
+	}
+}
+
+func TestCheckJSONShape(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want error
+	}{
+		{"nested within depth", `{"a":[{"b":[1,2]},{"b":[]}],"c":{}}`, nil},
+		{"same key in sibling objects", `[{"id":1},{"id":2}]`, nil},
+		{"same key at different levels", `{"id":1,"seat":{"id":2}}`, nil},
+		{"scalar body", `"text"`, nil},
+		{"too deep", strings.Repeat("[", 5) + strings.Repeat("]", 5), ErrJSONTooDeep},
+		{"duplicate nested key", `{"seat":{"id":1,"id":2}}`, ErrJSONDuplicateKey},
+		{"duplicate key after nested value", `{"a":{"x":1},"a":2}`, ErrJSONDuplicateKey},
+		{"trailing data", `{} []`, ErrJSONTrailingData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkJSONShape([]byte(tt.body), 4, false)
+			if tt.want == nil && err != nil {
+				t.Fatalf("checkJSONShape() error = %v", err)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Fatalf("checkJSONShape() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if err := checkJSONShape([]byte(`{"a":1,"a":2}`), 4, true); err != nil {
+		t.Errorf("expected duplicate keys to be allowed, got %v", err)
+	}
+	if err := checkJSONShape([]byte(`[1,`), 4, false); err == nil {
+		t.Error("expected truncated json to be rejected")
+	}
+}
+
+func TestParseRouteBodyLimits(t *testing.T) {
+	limits, err := ParseRouteBodyLimits("POST /api/v1/bookings/reserve=16KB, PUT  /api/v1/admin/zones/:id/seat-map=4MB,POST /x=512")
+	if err != nil {
+		t.Fatalf("ParseRouteBodyLimits() error = %v", err)
+	}
+	if limits["POST /api/v1/bookings/reserve"] != 16<<10 || limits["PUT /api/v1/admin/zones/:id/seat-map"] != 4<<20 || limits["POST /x"] != 512 {
+		t.Errorf("unexpected limits: %v", limits)
+	}
+
+	for _, spec := range []string{"/api/v1/bookings/reserve=16KB", "POST /x", "POST /x=big", "POST /x=0"} {
+		if _, err := ParseRouteBodyLimits(spec); err == nil {
+			t.Errorf("expected %q to be rejected", spec)
+		}
+	}
+}
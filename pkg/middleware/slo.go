@@ -0,0 +1,350 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// Latency histogram layout: bucket i holds durations up to sloBucketGrowth^i microseconds,
+// so reported percentiles are at most 5% above the true value. 400 buckets reach ~5 minutes.
+const (
+	sloBucketGrowth = 1.05
+	sloBucketCount  = 400
+)
+
+var sloBucketLogGrowth = math.Log(sloBucketGrowth)
+
+// SLOObjective is a latency budget for one route, e.g. p99 of POST /api/v1/bookings/reserve under 200ms
+type SLOObjective struct {
+	Route      string        // "METHOD /path" using the gin route pattern, e.g. "POST /api/v1/bookings/:id/confirm"
+	Percentile float64       // Fraction of requests that must meet the threshold, e.g. 0.99
+	Threshold  time.Duration // Latency budget at the percentile
+}
+
+// Label returns the percentile as a metric label, e.g. "p99" or "p99.9"
+func (o SLOObjective) Label() string {
+	return "p" + strconv.FormatFloat(math.Round(o.Percentile*1e5)/1e3, 'f', -1, 64)
+}
+
+// ParseSLOObjectives parses comma-separated objectives of the form
+// "METHOD /path=p99:200ms", e.g. "POST /api/v1/bookings/reserve=p99:200ms,GET /health=p50:5ms"
+func ParseSLOObjectives(spec string) ([]SLOObjective, error) {
+	var objectives []SLOObjective
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		sep := strings.LastIndex(entry, "=")
+		if sep <= 0 {
+			return nil, fmt.Errorf("invalid SLO objective %q: expected METHOD /path=pNN:duration", entry)
+		}
+		route := strings.Join(strings.Fields(entry[:sep]), " ")
+		if len(strings.Fields(route)) != 2 {
+			return nil, fmt.Errorf("invalid SLO objective %q: route must be METHOD /path", entry)
+		}
+
+		budget := strings.SplitN(strings.TrimSpace(entry[sep+1:]), ":", 2)
+		if len(budget) != 2 || !strings.HasPrefix(budget[0], "p") {
+			return nil, fmt.Errorf("invalid SLO objective %q: budget must be pNN:duration", entry)
+		}
+		percentile, err := strconv.ParseFloat(budget[0][1:], 64)
+		if err != nil || percentile <= 0 || percentile >= 100 {
+			return nil, fmt.Errorf("invalid SLO objective %q: percentile must be between p0 and p100", entry)
+		}
+		threshold, err := time.ParseDuration(budget[1])
+		if err != nil || threshold <= 0 {
+			return nil, fmt.Errorf("invalid SLO objective %q: invalid threshold %q", entry, budget[1])
+		}
+
+		objectives = append(objectives, SLOObjective{
+			Route:      route,
+			Percentile: percentile / 100,
+			Threshold:  threshold,
+		})
+	}
+	return objectives, nil
+}
+
+// SLOConfig holds configuration for the latency SLO tracker
+type SLOConfig struct {
+	Objectives    []SLOObjective
+	Window        time.Duration // Length of each evaluation window
+	BreachWindows int           // Consecutive breached windows before a breach is reported
+	MinSamples    int64         // Windows with fewer requests are not evaluated
+	// OnBreach is called in addition to the warning log when a route breaches its SLO
+	OnBreach func(ctx context.Context, breach SLOBreach)
+}
+
+// DefaultSLOConfig returns default configuration
+func DefaultSLOConfig() *SLOConfig {
+	return &SLOConfig{
+		Window:        time.Minute,
+		BreachWindows: 3,
+		MinSamples:    20,
+	}
+}
+
+// SLOBreach describes a route that exceeded its latency budget for BreachWindows windows in a row
+type SLOBreach struct {
+	Route              string
+	Percentile         string
+	Observed           time.Duration
+	Threshold          time.Duration
+	Samples            int64
+	ConsecutiveWindows int
+}
+
+// latencyWindow is a lock-free histogram of the latencies observed in one window
+type latencyWindow struct {
+	buckets [sloBucketCount]atomic.Int64
+	count   atomic.Int64
+}
+
+func (w *latencyWindow) observe(d time.Duration) {
+	w.buckets[sloBucketIndex(d)].Add(1)
+	w.count.Add(1)
+}
+
+// percentile returns the upper bound of the bucket holding the p-th fraction of samples
+func (w *latencyWindow) percentile(p float64, count int64) time.Duration {
+	rank := int64(math.Ceil(p * float64(count)))
+	var seen int64
+	for i := range w.buckets {
+		seen += w.buckets[i].Load()
+		if seen >= rank {
+			return sloBucketUpperBound(i)
+		}
+	}
+	return sloBucketUpperBound(sloBucketCount - 1)
+}
+
+func sloBucketIndex(d time.Duration) int {
+	us := float64(d) / float64(time.Microsecond)
+	if us <= 1 {
+		return 0
+	}
+	i := int(math.Ceil(math.Log(us) / sloBucketLogGrowth))
+	if i >= sloBucketCount {
+		return sloBucketCount - 1
+	}
+	return i
+}
+
+func sloBucketUpperBound(i int) time.Duration {
+	return time.Duration(math.Pow(sloBucketGrowth, float64(i)) * float64(time.Microsecond))
+}
+
+// objectiveState tracks consecutive breaches of one objective
+type objectiveState struct {
+	SLOObjective
+	consecutive int
+}
+
+// routeSLO holds the current window and objectives of one route
+type routeSLO struct {
+	window     atomic.Pointer[latencyWindow]
+	objectives []*objectiveState
+}
+
+// SLOTracker measures rolling latency percentiles of the routes with an objective.
+// Each window's percentiles are exported as metrics when the window closes, and a route
+// that misses its budget for BreachWindows windows in a row is reported as a breach.
+// Routes without an objective are not measured, keeping metric cardinality bounded.
+type SLOTracker struct {
+	config *SLOConfig
+	routes map[string]*routeSLO
+	mu     sync.Mutex // Serializes window evaluation
+
+	latencyGauge     *telemetry.FloatGauge
+	thresholdGauge   *telemetry.FloatGauge
+	consecutiveGauge *telemetry.Gauge
+	breachCounter    *telemetry.Counter
+
+	report func(ctx context.Context, breach SLOBreach)
+}
+
+// NewSLOTracker creates a tracker for the configured objectives
+func NewSLOTracker(cfg *SLOConfig) *SLOTracker {
+	defaults := DefaultSLOConfig()
+	if cfg == nil {
+		cfg = defaults
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = defaults.Window
+	}
+	if cfg.BreachWindows <= 0 {
+		cfg.BreachWindows = defaults.BreachWindows
+	}
+	if cfg.MinSamples <= 0 {
+		cfg.MinSamples = defaults.MinSamples
+	}
+
+	t := &SLOTracker{
+		config: cfg,
+		routes: make(map[string]*routeSLO),
+	}
+	for _, objective := range cfg.Objectives {
+		route, ok := t.routes[objective.Route]
+		if !ok {
+			route = &routeSLO{}
+			route.window.Store(&latencyWindow{})
+			t.routes[objective.Route] = route
+		}
+		route.objectives = append(route.objectives, &objectiveState{SLOObjective: objective})
+	}
+
+	// Without metrics breaches are still logged
+	t.latencyGauge, _ = telemetry.NewFloatGauge(telemetry.MetricOpts{
+		Name:        "http.server.slo.latency",
+		Description: "Route latency at the SLO percentile over the last window",
+		Unit:        "ms",
+	})
+	t.thresholdGauge, _ = telemetry.NewFloatGauge(telemetry.MetricOpts{
+		Name:        "http.server.slo.threshold",
+		Description: "Route latency budget at the SLO percentile",
+		Unit:        "ms",
+	})
+	t.consecutiveGauge, _ = telemetry.NewGauge(telemetry.MetricOpts{
+		Name:        "http.server.slo.consecutive_breached_windows",
+		Description: "Consecutive windows in which the route exceeded its latency budget",
+		Unit:        "{window}",
+	})
+	t.breachCounter, _ = telemetry.NewCounter(telemetry.MetricOpts{
+		Name:        "http.server.slo.breaches",
+		Description: "Routes exceeding their latency budget for the configured number of consecutive windows",
+		Unit:        "{breach}",
+	})
+	t.report = t.logBreach
+	return t
+}
+
+// Middleware records the latency of every request to a route with an objective
+func (t *SLOTracker) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		// FullPath is only known after routing; unmatched requests are not tracked
+		if path := c.FullPath(); path != "" {
+			t.Observe(c.Request.Method+" "+path, time.Since(start))
+		}
+	}
+}
+
+// Observe records one request latency for route ("METHOD /path")
+func (t *SLOTracker) Observe(route string, d time.Duration) {
+	if r, ok := t.routes[route]; ok {
+		r.window.Load().observe(d)
+	}
+}
+
+// Run closes a window every Window until ctx is cancelled
+func (t *SLOTracker) Run(ctx context.Context) {
+	ticker := time.NewTicker(t.config.Window)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			t.Rotate(ctx)
+		}
+	}
+}
+
+// Rotate closes the current window of every route, starts a new one and evaluates the
+// closed window against each objective. Windows with fewer than MinSamples requests
+// leave the breach streak unchanged, since a handful of requests says little about p99.
+func (t *SLOTracker) Rotate(ctx context.Context) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for name, route := range t.routes {
+		window := route.window.Swap(&latencyWindow{})
+		count := window.count.Load()
+		if count < t.config.MinSamples {
+			continue
+		}
+
+		for _, objective := range route.objectives {
+			observed := window.percentile(objective.Percentile, count)
+			attrs := []attribute.KeyValue{
+				attribute.String("http.route", name),
+				attribute.String("slo.percentile", objective.Label()),
+			}
+			if t.latencyGauge != nil {
+				t.latencyGauge.Record(ctx, durationMillis(observed), attrs...)
+			}
+			if t.thresholdGauge != nil {
+				t.thresholdGauge.Record(ctx, durationMillis(objective.Threshold), attrs...)
+			}
+
+			if observed <= objective.Threshold {
+				if objective.consecutive >= t.config.BreachWindows {
+					logger.Get().InfoContext(ctx, "Latency SLO recovered",
+						zap.String("route", name),
+						zap.String("percentile", objective.Label()),
+						zap.Duration("observed", observed),
+						zap.Duration("threshold", objective.Threshold),
+						zap.Int("breached_windows", objective.consecutive),
+					)
+				}
+				objective.consecutive = 0
+			} else {
+				objective.consecutive++
+				// Reported once per streak; the gauge shows how long it has lasted
+				if objective.consecutive == t.config.BreachWindows {
+					if t.breachCounter != nil {
+						t.breachCounter.Inc(ctx, attrs...)
+					}
+					t.report(ctx, SLOBreach{
+						Route:              name,
+						Percentile:         objective.Label(),
+						Observed:           observed,
+						Threshold:          objective.Threshold,
+						Samples:            count,
+						ConsecutiveWindows: objective.consecutive,
+					})
+				}
+			}
+
+			if t.consecutiveGauge != nil {
+				t.consecutiveGauge.Record(ctx, int64(objective.consecutive), attrs...)
+			}
+		}
+	}
+}
+
+// logBreach logs a breach and passes it to the OnBreach hook
+func (t *SLOTracker) logBreach(ctx context.Context, breach SLOBreach) {
+	logger.Get().WarnContext(ctx, "Latency SLO breached",
+		zap.String("route", breach.Route),
+		zap.String("percentile", breach.Percentile),
+		zap.Duration("observed", breach.Observed),
+		zap.Duration("threshold", breach.Threshold),
+		zap.Int64("samples", breach.Samples),
+		zap.Int("consecutive_windows", breach.ConsecutiveWindows),
+	)
+	if t.config.OnBreach != nil {
+		t.config.OnBreach(ctx, breach)
+	}
+}
+
+func durationMillis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
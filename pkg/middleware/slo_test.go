@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const testSLORoute = "POST /api/v1/bookings/reserve"
+
+func newTestSLOTracker(breaches *[]SLOBreach) *SLOTracker {
+	tracker := NewSLOTracker(&SLOConfig{
+		Objectives:    []SLOObjective{{Route: testSLORoute, Percentile: 0.99, Threshold: 200 * time.Millisecond}},
+		BreachWindows: 3,
+		MinSamples:    10,
+	})
+	tracker.report = func(_ context.Context, breach SLOBreach) {
+		*breaches = append(*breaches, breach)
+	}
+	return tracker
+}
+
+// observeWindow fills one window with 100 requests, slow of them taking 500ms
+func observeWindow(tracker *SLOTracker, slow int) {
+	for i := 0; i < 100; i++ {
+		d := 50 * time.Millisecond
+		if i < slow {
+			d = 500 * time.Millisecond
+		}
+		tracker.Observe(testSLORoute, d)
+	}
+	tracker.Rotate(context.Background())
+}
+
+func TestParseSLOObjectives(t *testing.T) {
+	objectives, err := ParseSLOObjectives("POST /api/v1/bookings/reserve=p99:200ms, POST /api/v1/bookings/:id/confirm=p99.9:1s")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(objectives) != 2 {
+		t.Fatalf("Expected 2 objectives, got %d", len(objectives))
+	}
+
+	want := SLOObjective{Route: "POST /api/v1/bookings/reserve", Percentile: 0.99, Threshold: 200 * time.Millisecond}
+	if objectives[0] != want {
+		t.Errorf("Expected %+v, got %+v", want, objectives[0])
+	}
+	if objectives[1].Route != "POST /api/v1/bookings/:id/confirm" || objectives[1].Label() != "p99.9" {
+		t.Errorf("Unexpected objective %+v (label %s)", objectives[1], objectives[1].Label())
+	}
+
+	for _, spec := range []string{
+		"/api/v1/bookings/reserve=p99:200ms",
+		"POST /api/v1/bookings/reserve=99:200ms",
+		"POST /api/v1/bookings/reserve=p100:200ms",
+		"POST /api/v1/bookings/reserve=p99:fast",
+	} {
+		if _, err := ParseSLOObjectives(spec); err == nil {
+			t.Errorf("Expected error for %q", spec)
+		}
+	}
+}
+
+func TestLatencyWindow_Percentile(t *testing.T) {
+	window := &latencyWindow{}
+	for i := 1; i <= 100; i++ {
+		window.observe(time.Duration(i) * time.Millisecond)
+	}
+
+	for _, tc := range []struct {
+		p    float64
+		want time.Duration
+	}{
+		{0.5, 50 * time.Millisecond},
+		{0.99, 99 * time.Millisecond},
+	} {
+		got := window.percentile(tc.p, window.count.Load())
+		// Bucket upper bounds overestimate by at most the bucket growth
+		if got < tc.want || float64(got) > float64(tc.want)*sloBucketGrowth {
+			t.Errorf("p%v: expected ~%v, got %v", tc.p*100, tc.want, got)
+		}
+	}
+}
+
+func TestSLOTracker_BreachAfterConsecutiveWindows(t *testing.T) {
+	var breaches []SLOBreach
+	tracker := newTestSLOTracker(&breaches)
+
+	observeWindow(tracker, 5)
+	observeWindow(tracker, 5)
+	if len(breaches) != 0 {
+		t.Fatalf("Expected no breach before 3 windows, got %d", len(breaches))
+	}
+
+	observeWindow(tracker, 5)
+	if len(breaches) != 1 {
+		t.Fatalf("Expected one breach after 3 windows, got %d", len(breaches))
+	}
+	breach := breaches[0]
+	if breach.Route != testSLORoute || breach.Percentile != "p99" || breach.ConsecutiveWindows != 3 || breach.Samples != 100 {
+		t.Errorf("Unexpected breach %+v", breach)
+	}
+	if breach.Observed < 500*time.Millisecond {
+		t.Errorf("Expected observed p99 of at least 500ms, got %v", breach.Observed)
+	}
+
+	// An ongoing breach is reported once per streak
+	observeWindow(tracker, 5)
+	if len(breaches) != 1 {
+		t.Errorf("Expected breach to be reported once, got %d", len(breaches))
+	}
+}
+
+func TestSLOTracker_HealthyWindowResetsStreak(t *testing.T) {
+	var breaches []SLOBreach
+	tracker := newTestSLOTracker(&breaches)
+
+	observeWindow(tracker, 5)
+	observeWindow(tracker, 5)
+	observeWindow(tracker, 0) // p99 within budget
+	observeWindow(tracker, 5)
+	observeWindow(tracker, 5)
+	if len(breaches) != 0 {
+		t.Errorf("Expected healthy window to reset the streak, got %d breaches", len(breaches))
+	}
+
+	// Quiet windows are not evaluated and do not break the streak
+	tracker.Observe(testSLORoute, time.Millisecond)
+	tracker.Rotate(context.Background())
+	observeWindow(tracker, 5)
+	if len(breaches) != 1 {
+		t.Errorf("Expected breach after third slow window, got %d", len(breaches))
+	}
+}
+
+func TestSLOTracker_Middleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var breaches []SLOBreach
+	tracker := newTestSLOTracker(&breaches)
+
+	router := gin.New()
+	router.Use(tracker.Middleware())
+	router.POST("/api/v1/bookings/reserve", func(c *gin.Context) { c.Status(http.StatusCreated) })
+	router.GET("/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for i := 0; i < 20; i++ {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/v1/bookings/reserve", nil))
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+	}
+
+	if got := tracker.routes[testSLORoute].window.Load().count.Load(); got != 20 {
+		t.Errorf("Expected 20 reserve requests tracked, got %d", got)
+	}
+	if _, ok := tracker.routes["GET /health"]; ok {
+		t.Error("Routes without an objective must not be tracked")
+	}
+}
@@ -0,0 +1,149 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/response"
+)
+
+const (
+	// PlatformAudience is the audience of tokens of platform admins, valid for every tenant
+	PlatformAudience = "platform"
+	// UserAudience is the audience of tokens of other users outside any tenant, such as
+	// customers; it grants access to no tenant's resources
+	UserAudience = "users"
+	// PlatformRole is the role of platform admins
+	PlatformRole = "super_admin"
+)
+
+// ContextKeyAudience holds the audience ([]string) of the caller's token; nil for tokens
+// issued before tenant isolation
+const ContextKeyAudience = "audience"
+
+var (
+	ErrMissingTenantClaims    = errors.New("token has no issuer or audience")
+	ErrTenantIssuerMismatch   = errors.New("token issuer does not match its tenant")
+	ErrTenantAudienceMismatch = errors.New("token audience does not match its tenant")
+)
+
+// TenantIssuer returns the iss claim of tokens minted for tenantID under the platform
+// issuer, e.g. booking-rush/tenants/acme. Users outside any tenant get the platform issuer.
+func TenantIssuer(issuer, tenantID string) string {
+	if tenantID == "" {
+		return issuer
+	}
+	return strings.TrimSuffix(issuer, "/") + "/tenants/" + tenantID
+}
+
+// TenantAudience returns the aud claim of tokens minted for tenantID. Outside any tenant
+// only platform admins get the platform audience.
+func TenantAudience(tenantID, role string) string {
+	switch {
+	case tenantID != "":
+		return "tenant:" + tenantID
+	case role == PlatformRole:
+		return PlatformAudience
+	default:
+		return UserAudience
+	}
+}
+
+// TenantClaims returns the iss and aud claims of a token minted for a user of tenantID with role
+func TenantClaims(issuer, tenantID, role string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss": TenantIssuer(issuer, tenantID),
+		"aud": []string{TenantAudience(tenantID, role)},
+	}
+}
+
+// TenantIsolation validates the per-tenant issuer and audience of tokens, so a token
+// minted for one tenant is never accepted for another's resources even when a handler
+// forgets to compare tenant IDs itself.
+type TenantIsolation struct {
+	// Issuer is the platform issuer tenant issuers are derived from
+	Issuer string
+	// RequireClaims rejects tokens without iss and aud. Leave it off until tokens issued
+	// before isolation have expired.
+	RequireClaims bool
+	// Target returns the tenant whose resources the request acts on, "" for none
+	// (optional; see TenantFromParam and TenantFromHeader)
+	Target func(c *gin.Context) string
+}
+
+// Verify checks the token of a caller of tenantID was minted for that tenant.
+// Returns the token's audience, nil for a token without tenant claims.
+func (t *TenantIsolation) Verify(claims jwt.MapClaims, tenantID string) ([]string, error) {
+	issuer, _ := claims.GetIssuer()
+	audience, _ := claims.GetAudience()
+	role, _ := claims["role"].(string)
+	if issuer == "" && len(audience) == 0 {
+		if t.RequireClaims {
+			return nil, ErrMissingTenantClaims
+		}
+		return nil, nil
+	}
+	if issuer != TenantIssuer(t.Issuer, tenantID) {
+		return nil, ErrTenantIssuerMismatch
+	}
+	if !containsAudience(audience, TenantAudience(tenantID, role)) {
+		return nil, ErrTenantAudienceMismatch
+	}
+	return audience, nil
+}
+
+// MayAccessTenant reports whether a caller may act on target's resources. Tokens with an
+// audience must name target or the platform; older tokens fall back on their tenant ID
+// and role.
+func MayAccessTenant(audience []string, tenantID, role, target string) bool {
+	if target == "" {
+		return true
+	}
+	if audience == nil {
+		return tenantID == target || (tenantID == "" && role == PlatformRole)
+	}
+	return containsAudience(audience, PlatformAudience) || containsAudience(audience, TenantAudience(target, ""))
+}
+
+// TenantFromParam returns a Target reading the tenant from a route parameter
+func TenantFromParam(name string) func(c *gin.Context) string {
+	return func(c *gin.Context) string {
+		return c.Param(name)
+	}
+}
+
+// TenantFromHeader returns a Target reading the tenant from a request header
+func TenantFromHeader(name string) func(c *gin.Context) string {
+	return func(c *gin.Context) string {
+		return c.GetHeader(name)
+	}
+}
+
+// RequireTenantAccess rejects callers whose token was not minted for the tenant returned
+// by target. Use it after JWTMiddleware or an equivalent that sets the caller's tenant.
+func RequireTenantAccess(target func(c *gin.Context) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		audience, _ := c.Get(ContextKeyAudience)
+		aud, _ := audience.([]string)
+		tenantID, _ := GetTenantID(c)
+		role, _ := GetRole(c)
+		if !MayAccessTenant(aud, tenantID, role, target(c)) {
+			c.AbortWithStatusJSON(http.StatusForbidden, response.Error("TENANT_MISMATCH", "Token was not issued for this tenant"))
+			return
+		}
+		c.Next()
+	}
+}
+
+// containsAudience reports whether audience contains want
+func containsAudience(audience []string, want string) bool {
+	for _, aud := range audience {
+		if aud == want {
+			return true
+		}
+	}
+	return false
+}
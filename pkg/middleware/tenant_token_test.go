@@ -0,0 +1,188 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// tenantToken returns the claims of a token minted for a user of tenantID with role by the
+// test issuer
+func tenantToken(tenantID, role string) jwt.MapClaims {
+	claims := jwt.MapClaims{
+		"user_id":   "user-123",
+		"role":      role,
+		"tenant_id": tenantID,
+		"exp":       time.Now().Add(time.Hour).Unix(),
+	}
+	for name, value := range TenantClaims("booking-rush", tenantID, role) {
+		claims[name] = value
+	}
+	return claims
+}
+
+func TestTenantClaims(t *testing.T) {
+	claims := TenantClaims("booking-rush", "acme", "customer")
+	if claims["iss"] != "booking-rush/tenants/acme" {
+		t.Errorf("iss = %v, want booking-rush/tenants/acme", claims["iss"])
+	}
+	if aud := claims["aud"].([]string); len(aud) != 1 || aud[0] != "tenant:acme" {
+		t.Errorf("aud = %v, want [tenant:acme]", aud)
+	}
+
+	platform := TenantClaims("booking-rush", "", PlatformRole)
+	if platform["iss"] != "booking-rush" || platform["aud"].([]string)[0] != PlatformAudience {
+		t.Errorf("platform claims = %v, want the platform issuer and audience", platform)
+	}
+
+	customer := TenantClaims("booking-rush", "", "customer")
+	if customer["iss"] != "booking-rush" || customer["aud"].([]string)[0] != UserAudience {
+		t.Errorf("tenantless customer claims = %v, want the platform issuer and the user audience", customer)
+	}
+}
+
+func TestTenantIsolation_Verify(t *testing.T) {
+	isolation := &TenantIsolation{Issuer: "booking-rush"}
+
+	tests := []struct {
+		name     string
+		claims   jwt.MapClaims
+		tenantID string
+		require  bool
+		wantErr  error
+	}{
+		{name: "tenant token", claims: tenantToken("acme", "customer"), tenantID: "acme"},
+		{name: "platform token", claims: tenantToken("", PlatformRole), tenantID: ""},
+		{name: "tenantless customer token", claims: tenantToken("", "customer"), tenantID: ""},
+		{name: "role raised to platform admin", claims: func() jwt.MapClaims {
+			claims := tenantToken("", "customer")
+			claims["aud"] = []string{PlatformAudience}
+			return claims
+		}(), tenantID: "", wantErr: ErrTenantAudienceMismatch},
+		{name: "tenant_id changed", claims: tenantToken("acme", "customer"), tenantID: "globex", wantErr: ErrTenantIssuerMismatch},
+		{name: "audience of another tenant", claims: jwt.MapClaims{"iss": "booking-rush/tenants/acme", "aud": "tenant:globex"}, tenantID: "acme", wantErr: ErrTenantAudienceMismatch},
+		{name: "legacy token", claims: jwt.MapClaims{"user_id": "user-123"}, tenantID: "acme"},
+		{name: "legacy token rejected", claims: jwt.MapClaims{"user_id": "user-123"}, tenantID: "acme", require: true, wantErr: ErrMissingTenantClaims},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isolation.RequireClaims = tt.require
+			if _, err := isolation.Verify(tt.claims, tt.tenantID); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMayAccessTenant(t *testing.T) {
+	tests := []struct {
+		name     string
+		audience []string
+		tenantID string
+		role     string
+		target   string
+		want     bool
+	}{
+		{"no target", []string{"tenant:acme"}, "acme", "customer", "", true},
+		{"own tenant", []string{"tenant:acme"}, "acme", "customer", "acme", true},
+		{"other tenant", []string{"tenant:acme"}, "acme", "customer", "globex", false},
+		{"platform token", []string{PlatformAudience}, "", PlatformRole, "globex", true},
+		{"tenantless customer token", []string{UserAudience}, "", "customer", "globex", false},
+		{"legacy token of the tenant", nil, "acme", "customer", "acme", true},
+		{"legacy token of another tenant", nil, "acme", "customer", "globex", false},
+		{"legacy platform token", nil, "", PlatformRole, "globex", true},
+		{"legacy tenantless customer token", nil, "", "customer", "globex", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MayAccessTenant(tt.audience, tt.tenantID, tt.role, tt.target); got != tt.want {
+				t.Errorf("MayAccessTenant() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJWTMiddleware_TenantIsolation(t *testing.T) {
+	router := gin.New()
+	router.Use(JWTMiddleware(&JWTConfig{
+		Secret: testSecret,
+		TenantIsolation: &TenantIsolation{
+			Issuer: "booking-rush",
+			Target: TenantFromParam("tenant"),
+		},
+	}))
+	router.GET("/tenants/:tenant/settings", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	forged := tenantToken("acme", "customer")
+	forged["tenant_id"] = "globex"
+
+	tests := []struct {
+		name       string
+		claims     jwt.MapClaims
+		path       string
+		wantStatus int
+	}{
+		{"own tenant", tenantToken("acme", "customer"), "/tenants/acme/settings", http.StatusOK},
+		{"another tenant", tenantToken("acme", "customer"), "/tenants/globex/settings", http.StatusForbidden},
+		{"platform admin", tenantToken("", PlatformRole), "/tenants/globex/settings", http.StatusOK},
+		{"tenantless customer", tenantToken("", "customer"), "/tenants/globex/settings", http.StatusForbidden},
+		{"tenant_id not matching the issuer", forged, "/tenants/globex/settings", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("Authorization", "Bearer "+generateTestToken(tt.claims, testSecret))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestRequireTenantAccess(t *testing.T) {
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(ContextKeyTenantID, c.GetHeader("X-Caller-Tenant"))
+		c.Set(ContextKeyRole, c.GetHeader("X-Caller-Role"))
+		c.Next()
+	})
+	router.GET("/tenants/:id", RequireTenantAccess(TenantFromParam("id")), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		name       string
+		caller     string
+		role       string
+		path       string
+		wantStatus int
+	}{
+		{"own tenant", "acme", "customer", "/tenants/acme", http.StatusOK},
+		{"another tenant", "acme", "customer", "/tenants/globex", http.StatusForbidden},
+		{"platform caller", "", PlatformRole, "/tenants/globex", http.StatusOK},
+		{"tenantless customer", "", "customer", "/tenants/globex", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("X-Caller-Tenant", tt.caller)
+			req.Header.Set("X-Caller-Role", tt.role)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
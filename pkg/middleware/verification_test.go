@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestVerification_EncodeParse(t *testing.T) {
+	v := &Verification{EmailVerified: true, PhoneVerified: false, RiskLevel: RiskLevelHigh, VerifiedAt: time.Unix(1700000000, 0)}
+
+	got := ParseVerification(v.Encode())
+	if *got != *v {
+		t.Errorf("Expected %+v, got %+v", v, got)
+	}
+
+	if got := ParseVerification("%%%"); got.EmailVerified || got.RiskLevel != RiskLevelNormal {
+		t.Errorf("Expected malformed value to be unverified, got %+v", got)
+	}
+}
+
+func TestVerificationFromClaims(t *testing.T) {
+	v := &Verification{EmailVerified: true, PhoneVerified: true, RiskLevel: RiskLevelNormal, VerifiedAt: time.Unix(1700000000, 0)}
+
+	// Round trip through a signed token so numeric claims decode as JSON numbers
+	claims := jwt.MapClaims{"user_id": "user-123", "exp": time.Now().Add(time.Hour).Unix()}
+	for k, val := range v.JWTClaims() {
+		claims[k] = val
+	}
+	token, err := jwt.Parse(generateTestToken(claims, testSecret), func(*jwt.Token) (interface{}, error) {
+		return []byte(testSecret), nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to parse token: %v", err)
+	}
+	if got := VerificationFromClaims(token.Claims.(jwt.MapClaims)); *got != *v {
+		t.Errorf("Expected %+v, got %+v", v, got)
+	}
+
+	// Tokens issued before verification existed
+	if got := VerificationFromClaims(jwt.MapClaims{"user_id": "user-123"}); got.EmailVerified || got.IsHighRisk() || !got.VerifiedAt.IsZero() {
+		t.Errorf("Expected unverified normal-risk state, got %+v", got)
+	}
+}
+
+func TestInternalAuthMiddleware_ForwardsVerification(t *testing.T) {
+	router := gin.New()
+	router.Use(InternalAuthMiddleware(&InternalAuthConfig{Secret: testInternalSecret}))
+	router.GET("/protected", func(c *gin.Context) {
+		v, ok := GetVerification(c)
+		if !ok || !v.EmailVerified || !v.IsHighRisk() {
+			c.Status(http.StatusForbidden)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	claims := &InternalClaims{
+		UserID:       "user-123",
+		Roles:        []string{"customer"},
+		Verification: &Verification{EmailVerified: true, RiskLevel: RiskLevelHigh},
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, signedRequest("GET", "/protected", claims, time.Now()))
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+}
@@ -0,0 +1,153 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Limits applied to list endpoints
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// ErrInvalidCursor is returned when a cursor cannot be decoded
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// Cursor is the position of the last item of a page in (time DESC, id DESC) order.
+// It is handed to clients as an opaque string.
+type Cursor struct {
+	Time time.Time `json:"t"`
+	ID   string    `json:"id"`
+}
+
+// Encode returns the opaque string form of the cursor
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Admits reports whether an item sorted at (t, id) comes after the cursor, mirroring
+// Keyset for lists that are paged in memory
+func (c Cursor) Admits(t time.Time, id string) bool {
+	if t.Equal(c.Time) {
+		return id < c.ID
+	}
+	return t.Before(c.Time)
+}
+
+// DecodeCursor parses a cursor returned by Encode. An empty string yields a nil cursor.
+func DecodeCursor(s string) (*Cursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == "" || c.Time.IsZero() {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// Params selects a page of results.
+// After takes precedence over Offset, which is kept for clients still paging by number.
+type Params struct {
+	Limit  int
+	Offset int
+	After  *Cursor
+}
+
+// NewParams decodes the cursor and clamps limit and offset to valid values
+func NewParams(cursor string, limit, offset int) (Params, error) {
+	after, err := DecodeCursor(cursor)
+	if err != nil {
+		return Params{}, err
+	}
+
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	if offset < 0 || after != nil {
+		offset = 0
+	}
+
+	return Params{Limit: limit, Offset: offset, After: after}, nil
+}
+
+// Lookahead returns the params with room for one extra row, which tells Paginate
+// whether another page exists without a separate count
+func (p Params) Lookahead() Params {
+	p.Limit++
+	return p
+}
+
+// Spread returns the params to fetch from each of several sources whose rows are
+// combined with Merge: every source supplies the rows Offset skips as well
+func (p Params) Spread() Params {
+	p.Limit += p.Offset
+	p.Offset = 0
+	return p
+}
+
+// Keyset returns the SQL condition selecting rows after the cursor in (timeColumn DESC,
+// idColumn DESC) order, using placeholders $argIndex and $argIndex+1 for After.Time and After.ID
+func Keyset(timeColumn, idColumn string, argIndex int) string {
+	return fmt.Sprintf("(%s, %s) < ($%d, $%d)", timeColumn, idColumn, argIndex, argIndex+1)
+}
+
+// Info is the cursor block included in list responses
+type Info struct {
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
+// Paginate trims rows fetched with Params.Lookahead to limit and builds the cursor
+// for the next page from the last row kept
+func Paginate[T any](rows []T, limit int, cursorOf func(T) Cursor) ([]T, Info) {
+	if len(rows) <= limit {
+		return rows, Info{}
+	}
+
+	rows = rows[:limit]
+	return rows, Info{
+		NextCursor: cursorOf(rows[len(rows)-1]).Encode(),
+		HasMore:    true,
+	}
+}
+
+// Merge combines pages fetched with Params.Spread, each in (time DESC, id DESC) order,
+// into the page p selects across all of them
+func Merge[T any](pages [][]T, p Params, cursorOf func(T) Cursor) []T {
+	var rows []T
+	for _, page := range pages {
+		rows = append(rows, page...)
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := cursorOf(rows[i]), cursorOf(rows[j])
+		if a.Time.Equal(b.Time) {
+			return a.ID > b.ID
+		}
+		return a.Time.After(b.Time)
+	})
+
+	if p.Offset >= len(rows) {
+		return nil
+	}
+	rows = rows[p.Offset:]
+	if len(rows) > p.Limit {
+		rows = rows[:p.Limit]
+	}
+	return rows
+}
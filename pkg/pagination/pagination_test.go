@@ -0,0 +1,168 @@
+package pagination
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCursor_RoundTrip(t *testing.T) {
+	cursor := Cursor{Time: time.Date(2026, 3, 1, 12, 30, 0, 123456000, time.UTC), ID: "booking-1"}
+
+	decoded, err := DecodeCursor(cursor.Encode())
+	if err != nil {
+		t.Fatalf("DecodeCursor failed: %v", err)
+	}
+	if !decoded.Time.Equal(cursor.Time) || decoded.ID != cursor.ID {
+		t.Errorf("DecodeCursor = %+v, want %+v", decoded, cursor)
+	}
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	if c, err := DecodeCursor(""); c != nil || err != nil {
+		t.Errorf("DecodeCursor(\"\") = %v, %v, want nil, nil", c, err)
+	}
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"not base64", "!!!"},
+		{"not json", "bm90LWpzb24"},
+		{"missing id", Cursor{Time: time.Now()}.Encode()},
+		{"missing time", Cursor{ID: "booking-1"}.Encode()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeCursor(tt.cursor); err != ErrInvalidCursor {
+				t.Errorf("DecodeCursor = %v, want %v", err, ErrInvalidCursor)
+			}
+		})
+	}
+}
+
+func TestNewParams(t *testing.T) {
+	cursor := Cursor{Time: time.Now(), ID: "booking-1"}.Encode()
+
+	tests := []struct {
+		name       string
+		cursor     string
+		limit      int
+		offset     int
+		wantLimit  int
+		wantOffset int
+		wantAfter  bool
+	}{
+		{"defaults", "", 0, 0, DefaultLimit, 0, false},
+		{"limit capped", "", 500, 0, MaxLimit, 0, false},
+		{"negative offset", "", 10, -5, 10, 0, false},
+		{"offset kept without cursor", "", 10, 30, 10, 30, false},
+		{"cursor overrides offset", cursor, 10, 30, 10, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := NewParams(tt.cursor, tt.limit, tt.offset)
+			if err != nil {
+				t.Fatalf("NewParams failed: %v", err)
+			}
+			if params.Limit != tt.wantLimit || params.Offset != tt.wantOffset || (params.After != nil) != tt.wantAfter {
+				t.Errorf("NewParams = %+v", params)
+			}
+		})
+	}
+
+	if _, err := NewParams("garbage!", 10, 0); err != ErrInvalidCursor {
+		t.Errorf("NewParams with bad cursor = %v, want %v", err, ErrInvalidCursor)
+	}
+}
+
+func TestParams_Lookahead(t *testing.T) {
+	params := Params{Limit: 20}
+	if got := params.Lookahead().Limit; got != 21 {
+		t.Errorf("Lookahead().Limit = %d, want 21", got)
+	}
+	if params.Limit != 20 {
+		t.Errorf("Lookahead modified the original params")
+	}
+}
+
+func TestKeyset(t *testing.T) {
+	if got := Keyset("created_at", "id", 3); got != "(created_at, id) < ($3, $4)" {
+		t.Errorf("Keyset = %q", got)
+	}
+}
+
+func TestCursor_Admits(t *testing.T) {
+	now := time.Now()
+	cursor := Cursor{Time: now, ID: "m"}
+
+	tests := []struct {
+		name string
+		time time.Time
+		id   string
+		want bool
+	}{
+		{"older", now.Add(-time.Second), "z", true},
+		{"newer", now.Add(time.Second), "a", false},
+		{"same time lower id", now, "a", true},
+		{"same time higher id", now, "z", false},
+		{"cursor item itself", now, "m", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cursor.Admits(tt.time, tt.id); got != tt.want {
+				t.Errorf("Admits = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	type item struct {
+		id      string
+		created time.Time
+	}
+	now := time.Now()
+	rows := []item{{"a", now}, {"b", now.Add(-time.Second)}, {"c", now.Add(-2 * time.Second)}}
+	cursorOf := func(i item) Cursor { return Cursor{Time: i.created, ID: i.id} }
+
+	page, info := Paginate(rows, 2, cursorOf)
+	if len(page) != 2 || !info.HasMore {
+		t.Fatalf("Paginate = %d rows, has_more=%v, want 2 rows and more", len(page), info.HasMore)
+	}
+	next, err := DecodeCursor(info.NextCursor)
+	if err != nil || next.ID != "b" {
+		t.Errorf("NextCursor = %+v (%v), want cursor at b", next, err)
+	}
+
+	page, info = Paginate(rows, 3, cursorOf)
+	if len(page) != 3 || info.HasMore || info.NextCursor != "" {
+		t.Errorf("Paginate on last page = %d rows, %+v", len(page), info)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	type item struct {
+		id      string
+		created time.Time
+	}
+	now := time.Now()
+	cursorOf := func(i item) Cursor { return Cursor{Time: i.created, ID: i.id} }
+
+	page := Params{Limit: 2, Offset: 1}
+	if spread := page.Spread(); spread.Limit != 3 || spread.Offset != 0 {
+		t.Errorf("Spread() = %+v, want limit 3 offset 0", spread)
+	}
+
+	pages := [][]item{
+		{{"a", now}, {"d", now.Add(-3 * time.Second)}},
+		{{"c", now.Add(-time.Second)}, {"b", now.Add(-time.Second)}, {"e", now.Add(-4 * time.Second)}},
+	}
+	got := Merge(pages, page, cursorOf)
+	if len(got) != 2 || got[0].id != "c" || got[1].id != "b" {
+		t.Errorf("Merge() = %v, want c, b", got)
+	}
+
+	if got := Merge(pages, Params{Limit: 10, Offset: 5}, cursorOf); len(got) != 0 {
+		t.Errorf("Merge() past the end = %v, want none", got)
+	}
+}
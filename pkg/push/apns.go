@@ -0,0 +1,194 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/retry"
+)
+
+const (
+	APNsProductionEndpoint  = "https://api.push.apple.com"
+	APNsDevelopmentEndpoint = "https://api.sandbox.push.apple.com"
+
+	// apnsTokenRefresh is how long a provider token is reused; Apple rejects tokens
+	// older than an hour and throttles providers that refresh more than every 20 minutes
+	apnsTokenRefresh = 50 * time.Minute
+	// apnsMaxCollapseID is the longest apns-collapse-id Apple accepts
+	apnsMaxCollapseID = 64
+)
+
+// APNsConfig contains configuration for the APNs token-based provider
+type APNsConfig struct {
+	// TeamID, KeyID and PrivateKey (the PEM contents of the .p8 file) sign provider tokens
+	TeamID     string
+	KeyID      string
+	PrivateKey string
+	// Topic is the app's bundle ID
+	Topic string
+	// Production sends to the production gateway instead of the sandbox
+	Production bool
+	// Endpoint overrides the gateway (tests only)
+	Endpoint   string
+	HTTPClient *http.Client
+}
+
+// APNsProvider delivers to iOS devices through the APNs HTTP/2 API
+type APNsProvider struct {
+	teamID   string
+	keyID    string
+	key      *ecdsa.PrivateKey
+	topic    string
+	endpoint string
+	client   *http.Client
+
+	mu         sync.Mutex
+	token      string
+	tokenIssue time.Time
+}
+
+// NewAPNsProvider creates a new APNs provider
+func NewAPNsProvider(cfg *APNsConfig) (*APNsProvider, error) {
+	if cfg == nil || cfg.TeamID == "" || cfg.KeyID == "" || cfg.Topic == "" {
+		return nil, fmt.Errorf("apns team id, key id and topic are required")
+	}
+	key, err := jwt.ParseECPrivateKeyFromPEM([]byte(cfg.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid apns private key: %w", err)
+	}
+
+	p := &APNsProvider{
+		teamID:   cfg.TeamID,
+		keyID:    cfg.KeyID,
+		key:      key,
+		topic:    cfg.Topic,
+		endpoint: strings.TrimRight(cfg.Endpoint, "/"),
+		client:   cfg.HTTPClient,
+	}
+	if p.endpoint == "" {
+		p.endpoint = APNsDevelopmentEndpoint
+		if cfg.Production {
+			p.endpoint = APNsProductionEndpoint
+		}
+	}
+	if p.client == nil {
+		// The default transport negotiates HTTP/2 over TLS, which APNs requires
+		p.client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return p, nil
+}
+
+// Platform returns PlatformIOS
+func (p *APNsProvider) Platform() Platform {
+	return PlatformIOS
+}
+
+// Send delivers a message through APNs
+func (p *APNsProvider) Send(ctx context.Context, msg *Message) error {
+	providerToken, err := p.providerToken()
+	if err != nil {
+		return err
+	}
+
+	// Custom data sits next to "aps" in the payload
+	payload := map[string]interface{}{
+		"aps": map[string]interface{}{
+			"alert": map[string]string{"title": msg.Title, "body": msg.Body},
+			"sound": "default",
+		},
+	}
+	for k, v := range msg.Data {
+		if k != "aps" {
+			payload[k] = v
+		}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return retry.Permanent(fmt.Errorf("failed to marshal apns payload: %w", err))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint+"/3/device/"+msg.Token, bytes.NewReader(body))
+	if err != nil {
+		return retry.Permanent(err)
+	}
+	req.Header.Set("Authorization", "bearer "+providerToken)
+	req.Header.Set("apns-topic", p.topic)
+	req.Header.Set("apns-push-type", "alert")
+	req.Header.Set("apns-priority", "10")
+	if msg.TTL > 0 {
+		req.Header.Set("apns-expiration", strconv.FormatInt(time.Now().Add(msg.TTL).Unix(), 10))
+	}
+	if msg.CollapseKey != "" && len(msg.CollapseKey) <= apnsMaxCollapseID {
+		req.Header.Set("apns-collapse-id", msg.CollapseKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("apns request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var errResp struct {
+		Reason string `json:"reason"`
+	}
+	_ = json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&errResp)
+	err = fmt.Errorf("apns returned %d %s", resp.StatusCode, errResp.Reason)
+
+	switch {
+	case resp.StatusCode == http.StatusGone || errResp.Reason == "BadDeviceToken" || errResp.Reason == "DeviceTokenNotForTopic":
+		return retry.Permanent(fmt.Errorf("%w: %v", ErrInvalidToken, err))
+	case errResp.Reason == "ExpiredProviderToken":
+		p.resetToken()
+		return err
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return err
+	default:
+		return retry.Permanent(err)
+	}
+}
+
+// providerToken returns the cached provider token, signing a new one when it is due
+func (p *APNsProvider) providerToken() (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.token != "" && time.Since(p.tokenIssue) < apnsTokenRefresh {
+		return p.token, nil
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"iss": p.teamID,
+		"iat": now.Unix(),
+	})
+	token.Header["kid"] = p.keyID
+	signed, err := token.SignedString(p.key)
+	if err != nil {
+		return "", retry.Permanent(fmt.Errorf("failed to sign apns provider token: %w", err))
+	}
+
+	p.token = signed
+	p.tokenIssue = now
+	return p.token, nil
+}
+
+// resetToken drops the cached provider token
+func (p *APNsProvider) resetToken() {
+	p.mu.Lock()
+	p.token = ""
+	p.mu.Unlock()
+}
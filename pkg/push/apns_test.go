@@ -0,0 +1,78 @@
+package push
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestECKey(t *testing.T) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey() error = %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+func TestAPNsProvider_Send(t *testing.T) {
+	var payload map[string]interface{}
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		switch strings.TrimPrefix(r.URL.Path, "/3/device/") {
+		case "device-1":
+			w.WriteHeader(http.StatusOK)
+		case "uninstalled":
+			w.WriteHeader(http.StatusGone)
+			_, _ = w.Write([]byte(`{"reason":"Unregistered","timestamp":1700000000000}`))
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"reason":"ServiceUnavailable"}`))
+		}
+	}))
+	defer server.Close()
+
+	provider, err := NewAPNsProvider(&APNsConfig{
+		TeamID:     "TEAM123456",
+		KeyID:      "KEY1234567",
+		PrivateKey: newTestECKey(t),
+		Topic:      "com.bookingrush.app",
+		Endpoint:   server.URL,
+	})
+	if err != nil {
+		t.Fatalf("NewAPNsProvider() error = %v", err)
+	}
+
+	msg := &Message{Token: "device-1", Title: "Your turn", Body: "Book now", Data: map[string]string{"event_id": "event-1"}, CollapseKey: "queue:event-1"}
+	if err := provider.Send(context.Background(), msg); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if headers.Get("apns-topic") != "com.bookingrush.app" || headers.Get("apns-collapse-id") != "queue:event-1" || !strings.HasPrefix(headers.Get("Authorization"), "bearer ") {
+		t.Errorf("Send() headers = %v", headers)
+	}
+	if payload["event_id"] != "event-1" || payload["aps"] == nil {
+		t.Errorf("Send() payload = %v", payload)
+	}
+
+	if err := provider.Send(context.Background(), &Message{Token: "uninstalled", Body: "Book now"}); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Send() to uninstalled app error = %v, want ErrInvalidToken", err)
+	}
+	if err := provider.Send(context.Background(), &Message{Token: "busy", Body: "Book now"}); err == nil || errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Send() during outage error = %v, want a retryable error", err)
+	}
+}
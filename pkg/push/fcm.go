@@ -0,0 +1,268 @@
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/retry"
+)
+
+const (
+	defaultFCMEndpoint    = "https://fcm.googleapis.com"
+	defaultGoogleTokenURL = "https://oauth2.googleapis.com/token"
+	fcmScope              = "https://www.googleapis.com/auth/firebase.messaging"
+)
+
+// FCMConfig contains configuration for the FCM HTTP v1 provider
+type FCMConfig struct {
+	ProjectID string
+	// ClientEmail and PrivateKey (PEM) identify the service account allowed to send
+	ClientEmail string
+	PrivateKey  string
+	// Endpoint and TokenURL override the Google endpoints (tests only)
+	Endpoint   string
+	TokenURL   string
+	HTTPClient *http.Client
+}
+
+// FCMConfigFromServiceAccount reads a Firebase service account key file
+func FCMConfigFromServiceAccount(data []byte) (*FCMConfig, error) {
+	var key struct {
+		ProjectID   string `json:"project_id"`
+		ClientEmail string `json:"client_email"`
+		PrivateKey  string `json:"private_key"`
+		TokenURI    string `json:"token_uri"`
+	}
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, fmt.Errorf("invalid service account key: %w", err)
+	}
+	return &FCMConfig{
+		ProjectID:   key.ProjectID,
+		ClientEmail: key.ClientEmail,
+		PrivateKey:  key.PrivateKey,
+		TokenURL:    key.TokenURI,
+	}, nil
+}
+
+// FCMProvider delivers to Android devices through the FCM HTTP v1 API
+type FCMProvider struct {
+	projectID   string
+	clientEmail string
+	key         *rsa.PrivateKey
+	endpoint    string
+	tokenURL    string
+	client      *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	tokenExpiry time.Time
+}
+
+// NewFCMProvider creates a new FCM provider
+func NewFCMProvider(cfg *FCMConfig) (*FCMProvider, error) {
+	if cfg == nil || cfg.ProjectID == "" || cfg.ClientEmail == "" {
+		return nil, fmt.Errorf("fcm project id and client email are required")
+	}
+	key, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(cfg.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid fcm private key: %w", err)
+	}
+
+	p := &FCMProvider{
+		projectID:   cfg.ProjectID,
+		clientEmail: cfg.ClientEmail,
+		key:         key,
+		endpoint:    strings.TrimRight(cfg.Endpoint, "/"),
+		tokenURL:    cfg.TokenURL,
+		client:      cfg.HTTPClient,
+	}
+	if p.endpoint == "" {
+		p.endpoint = defaultFCMEndpoint
+	}
+	if p.tokenURL == "" {
+		p.tokenURL = defaultGoogleTokenURL
+	}
+	if p.client == nil {
+		p.client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return p, nil
+}
+
+// Platform returns PlatformAndroid
+func (p *FCMProvider) Platform() Platform {
+	return PlatformAndroid
+}
+
+// fcmRequest is the body of a messages:send request
+type fcmRequest struct {
+	Message fcmMessage `json:"message"`
+}
+
+type fcmMessage struct {
+	Token        string            `json:"token"`
+	Notification fcmNotification   `json:"notification"`
+	Data         map[string]string `json:"data,omitempty"`
+	Android      fcmAndroidConfig  `json:"android"`
+}
+
+type fcmNotification struct {
+	Title string `json:"title,omitempty"`
+	Body  string `json:"body"`
+}
+
+type fcmAndroidConfig struct {
+	Priority    string `json:"priority"`
+	CollapseKey string `json:"collapse_key,omitempty"`
+	TTL         string `json:"ttl,omitempty"`
+}
+
+// fcmErrorResponse is the error body of a failed request
+type fcmErrorResponse struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+		Details []struct {
+			ErrorCode string `json:"errorCode"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+// Send delivers a message through FCM
+func (p *FCMProvider) Send(ctx context.Context, msg *Message) error {
+	accessToken, err := p.token(ctx)
+	if err != nil {
+		return err
+	}
+
+	body := fcmRequest{Message: fcmMessage{
+		Token:        msg.Token,
+		Notification: fcmNotification{Title: msg.Title, Body: msg.Body},
+		Data:         msg.Data,
+		Android: fcmAndroidConfig{
+			Priority:    "high",
+			CollapseKey: msg.CollapseKey,
+		},
+	}}
+	if msg.TTL > 0 {
+		body.Message.Android.TTL = fmt.Sprintf("%ds", int(msg.TTL.Seconds()))
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return retry.Permanent(fmt.Errorf("failed to marshal fcm message: %w", err))
+	}
+
+	sendURL := fmt.Sprintf("%s/v1/projects/%s/messages:send", p.endpoint, url.PathEscape(p.projectID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sendURL, bytes.NewReader(payload))
+	if err != nil {
+		return retry.Permanent(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("fcm request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var errResp fcmErrorResponse
+	_ = json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&errResp)
+	errorCode := errResp.Error.Status
+	for _, detail := range errResp.Error.Details {
+		if detail.ErrorCode != "" {
+			errorCode = detail.ErrorCode
+		}
+	}
+	err = fmt.Errorf("fcm returned %d %s: %s", resp.StatusCode, errorCode, errResp.Error.Message)
+
+	switch {
+	case errorCode == "UNREGISTERED" || errorCode == "SENDER_ID_MISMATCH":
+		return retry.Permanent(fmt.Errorf("%w: %v", ErrInvalidToken, err))
+	case resp.StatusCode == http.StatusUnauthorized:
+		// The access token was revoked or expired early; fetch a new one on retry
+		p.resetToken()
+		return err
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return err
+	default:
+		return retry.Permanent(err)
+	}
+}
+
+// token returns a cached OAuth2 access token, exchanging a signed service account
+// assertion for a new one when it is about to expire
+func (p *FCMProvider) token(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.accessToken != "" && time.Now().Before(p.tokenExpiry) {
+		return p.accessToken, nil
+	}
+
+	now := time.Now()
+	assertion, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":   p.clientEmail,
+		"scope": fcmScope,
+		"aud":   p.tokenURL,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}).SignedString(p.key)
+	if err != nil {
+		return "", retry.Permanent(fmt.Errorf("failed to sign fcm assertion: %w", err))
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", retry.Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fcm token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fcm token request returned %d", resp.StatusCode)
+	}
+
+	var tokenResp struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil || tokenResp.AccessToken == "" {
+		return "", fmt.Errorf("invalid fcm token response: %v", err)
+	}
+
+	// Renew a minute early so a token never expires mid-request
+	p.accessToken = tokenResp.AccessToken
+	p.tokenExpiry = now.Add(time.Duration(tokenResp.ExpiresIn)*time.Second - time.Minute)
+	return p.accessToken, nil
+}
+
+// resetToken drops the cached access token
+func (p *FCMProvider) resetToken() {
+	p.mu.Lock()
+	p.accessToken = ""
+	p.mu.Unlock()
+}
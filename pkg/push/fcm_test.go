@@ -0,0 +1,83 @@
+package push
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func newTestRSAKey(t *testing.T) string {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+}
+
+func TestFCMProvider_Send(t *testing.T) {
+	var tokenRequests atomic.Int32
+	var sent fcmRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			tokenRequests.Add(1)
+			if r.FormValue("grant_type") != "urn:ietf:params:oauth:grant-type:jwt-bearer" || r.FormValue("assertion") == "" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"access_token": "access-1", "expires_in": 3600})
+		case "/v1/projects/booking-rush/messages:send":
+			if r.Header.Get("Authorization") != "Bearer access-1" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_ = json.NewDecoder(r.Body).Decode(&sent)
+			if sent.Message.Token == "stale" {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"error":{"code":404,"message":"Requested entity was not found.","status":"NOT_FOUND","details":[{"errorCode":"UNREGISTERED"}]}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"name":"projects/booking-rush/messages/1"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	provider, err := NewFCMProvider(&FCMConfig{
+		ProjectID:   "booking-rush",
+		ClientEmail: "push@booking-rush.iam.gserviceaccount.com",
+		PrivateKey:  newTestRSAKey(t),
+		Endpoint:    server.URL,
+		TokenURL:    server.URL + "/token",
+	})
+	if err != nil {
+		t.Fatalf("NewFCMProvider() error = %v", err)
+	}
+
+	msg := &Message{Token: "device-1", Title: "Your turn", Body: "Book now", Data: map[string]string{"event_id": "event-1"}}
+	if err := provider.Send(context.Background(), msg); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if sent.Message.Token != "device-1" || sent.Message.Data["event_id"] != "event-1" || sent.Message.Notification.Title != "Your turn" {
+		t.Errorf("Send() delivered %+v", sent.Message)
+	}
+
+	// The access token is reused, and an unregistered token is reported as invalid
+	err = provider.Send(context.Background(), &Message{Token: "stale", Body: "Book now"})
+	if !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Send() to unregistered token error = %v, want ErrInvalidToken", err)
+	}
+	if n := tokenRequests.Load(); n != 1 {
+		t.Errorf("Expected 1 token request, got %d", n)
+	}
+}
@@ -0,0 +1,60 @@
+package push
+
+import (
+	"fmt"
+	"os"
+)
+
+// ProvidersConfig points at the credentials of each push provider; a provider
+// whose credentials are not set is left out
+type ProvidersConfig struct {
+	// FCMCredentialsFile is the path of a Firebase service account key file
+	FCMCredentialsFile string
+	// APNsKeyFile is the path of the .p8 signing key
+	APNsKeyFile    string
+	APNsKeyID      string
+	APNsTeamID     string
+	APNsTopic      string
+	APNsProduction bool
+}
+
+// NewProviders builds the providers the configuration has credentials for
+func NewProviders(cfg *ProvidersConfig) ([]Provider, error) {
+	var providers []Provider
+
+	if cfg.FCMCredentialsFile != "" {
+		data, err := os.ReadFile(cfg.FCMCredentialsFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fcm credentials: %w", err)
+		}
+		fcmCfg, err := FCMConfigFromServiceAccount(data)
+		if err != nil {
+			return nil, err
+		}
+		fcm, err := NewFCMProvider(fcmCfg)
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, fcm)
+	}
+
+	if cfg.APNsKeyFile != "" {
+		key, err := os.ReadFile(cfg.APNsKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read apns key: %w", err)
+		}
+		apns, err := NewAPNsProvider(&APNsConfig{
+			TeamID:     cfg.APNsTeamID,
+			KeyID:      cfg.APNsKeyID,
+			PrivateKey: string(key),
+			Topic:      cfg.APNsTopic,
+			Production: cfg.APNsProduction,
+		})
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, apns)
+	}
+
+	return providers, nil
+}
@@ -0,0 +1,176 @@
+// Package push delivers mobile push notifications through FCM (Android) and APNs (iOS).
+package push
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/retry"
+)
+
+// Platform is the mobile platform a device token belongs to
+type Platform string
+
+const (
+	PlatformAndroid Platform = "android"
+	PlatformIOS     Platform = "ios"
+)
+
+var (
+	// ErrInvalidToken is returned when the provider no longer accepts a device token;
+	// the token should be removed so it is not tried again
+	ErrInvalidToken = errors.New("push token is no longer valid")
+	// ErrNoProvider is returned when no provider is configured for a device's platform
+	ErrNoProvider = errors.New("no push provider for platform")
+)
+
+// Message is a notification for a single device
+type Message struct {
+	Token string
+	Title string
+	Body  string
+	Data  map[string]string
+	// CollapseKey replaces an undelivered message with the same key (e.g. one per event)
+	CollapseKey string
+	// TTL is how long the provider keeps trying an offline device (0 = provider default)
+	TTL time.Duration
+}
+
+// Provider sends messages to the devices of one platform.
+// Errors wrapped with retry.Permanent are not retried.
+type Provider interface {
+	// Platform returns the platform the provider delivers to
+	Platform() Platform
+	// Send delivers a message to the device holding msg.Token
+	Send(ctx context.Context, msg *Message) error
+}
+
+// Device is a registered device to deliver to
+type Device struct {
+	ID       string
+	Platform Platform
+	Token    string
+}
+
+// Status is the outcome of a delivery to one device
+type Status string
+
+const (
+	StatusDelivered    Status = "delivered"
+	StatusInvalidToken Status = "invalid_token"
+	StatusFailed       Status = "failed"
+)
+
+// Result is the outcome of a delivery to one device
+type Result struct {
+	DeviceID string
+	Status   Status
+	Attempts int
+	Err      error
+}
+
+// DispatcherConfig contains configuration for the dispatcher
+type DispatcherConfig struct {
+	// MaxRetries is how often a failed delivery to a device is retried (default: 2)
+	MaxRetries int
+	// InitialInterval is the backoff before the first retry (default: 200ms)
+	InitialInterval time.Duration
+	// MaxInterval caps the backoff between retries (default: 2s)
+	MaxInterval time.Duration
+}
+
+// Dispatcher sends a notification to every device of a user through the provider of its platform
+type Dispatcher struct {
+	providers map[Platform]Provider
+	retry     *retry.Config
+}
+
+// NewDispatcher creates a new dispatcher; providers for the same platform replace earlier ones
+func NewDispatcher(cfg *DispatcherConfig, providers ...Provider) *Dispatcher {
+	if cfg == nil {
+		cfg = &DispatcherConfig{}
+	}
+	if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = 2
+	}
+	if cfg.InitialInterval <= 0 {
+		cfg.InitialInterval = 200 * time.Millisecond
+	}
+	if cfg.MaxInterval <= 0 {
+		cfg.MaxInterval = 2 * time.Second
+	}
+
+	d := &Dispatcher{
+		providers: make(map[Platform]Provider, len(providers)),
+		retry: &retry.Config{
+			MaxRetries:      cfg.MaxRetries,
+			InitialInterval: cfg.InitialInterval,
+			MaxInterval:     cfg.MaxInterval,
+			Multiplier:      2.0,
+			JitterFactor:    0.1,
+		},
+	}
+	for _, p := range providers {
+		d.providers[p.Platform()] = p
+	}
+	return d
+}
+
+// Enabled reports whether any provider is configured
+func (d *Dispatcher) Enabled() bool {
+	return len(d.providers) > 0
+}
+
+// Send delivers msg to each device concurrently, retrying each device on its own so a
+// slow or failing device does not hold back the others. msg.Token is set per device.
+func (d *Dispatcher) Send(ctx context.Context, devices []Device, msg Message) []Result {
+	results := make([]Result, len(devices))
+
+	var wg sync.WaitGroup
+	for i, device := range devices {
+		wg.Add(1)
+		go func(i int, device Device) {
+			defer wg.Done()
+			results[i] = d.sendToDevice(ctx, device, msg)
+		}(i, device)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// sendToDevice delivers msg to one device with retries
+func (d *Dispatcher) sendToDevice(ctx context.Context, device Device, msg Message) Result {
+	provider, ok := d.providers[device.Platform]
+	if !ok {
+		return Result{
+			DeviceID: device.ID,
+			Status:   StatusFailed,
+			Err:      fmt.Errorf("%w: %s", ErrNoProvider, device.Platform),
+		}
+	}
+
+	msg.Token = device.Token
+	res := retry.Do(ctx, d.retry, func(ctx context.Context) error {
+		return provider.Send(ctx, &msg)
+	})
+
+	result := Result{DeviceID: device.ID, Attempts: res.Attempts}
+	switch {
+	case res.Err == nil:
+		result.Status = StatusDelivered
+	case errors.Is(res.LastError, ErrInvalidToken):
+		result.Status = StatusInvalidToken
+		result.Err = res.LastError
+	default:
+		result.Status = StatusFailed
+		result.Err = res.LastError
+		if result.Err == nil {
+			result.Err = res.Err
+		}
+	}
+	return result
+}
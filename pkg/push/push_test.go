@@ -0,0 +1,72 @@
+package push
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/retry"
+)
+
+// fakeProvider fails each token a fixed number of times before succeeding
+type fakeProvider struct {
+	platform Platform
+	mu       sync.Mutex
+	failures map[string]int
+	errs     map[string]error
+	calls    map[string]int
+}
+
+func (p *fakeProvider) Platform() Platform { return p.platform }
+
+func (p *fakeProvider) Send(ctx context.Context, msg *Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls[msg.Token]++
+	if err, ok := p.errs[msg.Token]; ok {
+		return err
+	}
+	if p.calls[msg.Token] <= p.failures[msg.Token] {
+		return errors.New("temporarily unavailable")
+	}
+	return nil
+}
+
+func TestDispatcher_Send(t *testing.T) {
+	android := &fakeProvider{
+		platform: PlatformAndroid,
+		failures: map[string]int{"flaky": 1, "down": 10},
+		errs:     map[string]error{"gone": retry.Permanent(ErrInvalidToken)},
+		calls:    map[string]int{},
+	}
+	d := NewDispatcher(&DispatcherConfig{MaxRetries: 2, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond}, android)
+
+	results := d.Send(context.Background(), []Device{
+		{ID: "d1", Platform: PlatformAndroid, Token: "ok"},
+		{ID: "d2", Platform: PlatformAndroid, Token: "flaky"},
+		{ID: "d3", Platform: PlatformAndroid, Token: "down"},
+		{ID: "d4", Platform: PlatformAndroid, Token: "gone"},
+		{ID: "d5", Platform: PlatformIOS, Token: "no-provider"},
+	}, Message{Title: "Your turn", Body: "Book now"})
+
+	want := []struct {
+		status   Status
+		attempts int
+	}{
+		{StatusDelivered, 1},
+		{StatusDelivered, 2}, // Retried on its own
+		{StatusFailed, 3},    // 1 attempt + 2 retries
+		{StatusInvalidToken, 1},
+		{StatusFailed, 0},
+	}
+	for i, w := range want {
+		if results[i].Status != w.status || results[i].Attempts != w.attempts {
+			t.Errorf("results[%d] = %s after %d attempts, want %s after %d", i, results[i].Status, results[i].Attempts, w.status, w.attempts)
+		}
+	}
+	if !errors.Is(results[4].Err, ErrNoProvider) {
+		t.Errorf("results[4].Err = %v, want ErrNoProvider", results[4].Err)
+	}
+}
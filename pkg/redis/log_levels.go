@@ -0,0 +1,21 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+)
+
+// WatchLogLevels applies the per-module log levels stored in the hash key (module to
+// level, e.g. HSET log:levels:booking-service booking.repository debug) every interval
+// until ctx is cancelled. Deleting a field restores the module's configured level.
+// Returns immediately when key is empty or interval is not positive.
+func (c *Client) WatchLogLevels(ctx context.Context, key string, interval time.Duration) {
+	if key == "" || interval <= 0 {
+		return
+	}
+	logger.WatchModuleLevels(ctx, func(ctx context.Context) (map[string]string, error) {
+		return c.HGetAll(ctx, key).Result()
+	}, interval)
+}
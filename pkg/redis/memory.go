@@ -0,0 +1,558 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// NamespaceBudget is the memory budget of one key namespace, e.g. the queue entries under "queue:"
+type NamespaceBudget struct {
+	Prefix   string // Keys starting with Prefix belong to the namespace; the longest matching prefix wins
+	MaxBytes int64  // Estimated memory the namespace may use (0 = reported only)
+	// RequireTTL flags keys without an expiry. Such keys are never evicted under a volatile-*
+	// policy and outlive the on-sale that created them.
+	RequireTTL bool
+}
+
+// ParseNamespaceBudgets parses comma-separated budgets of the form "prefix=size", where size is
+// bytes or a KB/MB/GB amount and a trailing "+ttl" requires every key to expire,
+// e.g. "queue:=256MB,idempotency:=64MB+ttl,reservation:=512MB+ttl"
+func ParseNamespaceBudgets(spec string) ([]NamespaceBudget, error) {
+	var budgets []NamespaceBudget
+	seen := make(map[string]bool)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		sep := strings.LastIndex(entry, "=")
+		if sep <= 0 {
+			return nil, fmt.Errorf("invalid namespace budget %q: expected prefix=size", entry)
+		}
+		budget := NamespaceBudget{Prefix: strings.TrimSpace(entry[:sep])}
+		if seen[budget.Prefix] {
+			return nil, fmt.Errorf("invalid namespace budget %q: duplicate prefix", entry)
+		}
+		seen[budget.Prefix] = true
+
+		size := strings.TrimSpace(entry[sep+1:])
+		if strings.HasSuffix(strings.ToLower(size), "+ttl") {
+			budget.RequireTTL = true
+			size = strings.TrimSpace(size[:len(size)-len("+ttl")])
+		}
+		maxBytes, err := parseByteSize(size)
+		if err != nil {
+			return nil, fmt.Errorf("invalid namespace budget %q: %w", entry, err)
+		}
+		budget.MaxBytes = maxBytes
+
+		budgets = append(budgets, budget)
+	}
+	return budgets, nil
+}
+
+// parseByteSize parses "512", "64KB", "256MB" or "1GB" (binary units)
+func parseByteSize(s string) (int64, error) {
+	multiplier := int64(1)
+	upper := strings.ToUpper(s)
+	for _, unit := range []struct {
+		suffix string
+		size   int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1}} {
+		if strings.HasSuffix(upper, unit.suffix) {
+			multiplier = unit.size
+			upper = strings.TrimSpace(upper[:len(upper)-len(unit.suffix)])
+			break
+		}
+	}
+	n, err := strconv.ParseInt(upper, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return n * multiplier, nil
+}
+
+// MemoryGovernorConfig holds configuration for the Redis memory governor
+type MemoryGovernorConfig struct {
+	Budgets     []NamespaceBudget
+	Interval    time.Duration // Time between audits
+	SampleSize  int           // Keys per namespace whose memory and TTL are measured
+	ScanCount   int64         // COUNT hint of each SCAN call
+	MaxScanKeys int64         // Keys scanned per audit; larger keyspaces are extrapolated from DBSIZE
+	// OnAlert is called in addition to the warning log when a namespace starts violating its budget
+	OnAlert func(ctx context.Context, alert MemoryAlert)
+}
+
+// DefaultMemoryGovernorConfig returns default configuration
+func DefaultMemoryGovernorConfig() *MemoryGovernorConfig {
+	return &MemoryGovernorConfig{
+		Interval:    5 * time.Minute,
+		SampleSize:  100,
+		ScanCount:   1000,
+		MaxScanKeys: 200000,
+	}
+}
+
+// NamespaceUsage is the estimated memory use of one key namespace
+type NamespaceUsage struct {
+	Namespace      string  `json:"namespace"`
+	Keys           int64   `json:"keys"`         // Estimated keys in the namespace
+	SampledKeys    int     `json:"sampled_keys"` // Keys measured with MEMORY USAGE and PTTL
+	AvgKeyBytes    int64   `json:"avg_key_bytes"`
+	EstimatedBytes int64   `json:"estimated_bytes"`
+	NoTTLRatio     float64 `json:"no_ttl_ratio"` // Fraction of sampled keys without an expiry
+	BudgetBytes    int64   `json:"budget_bytes,omitempty"`
+	BudgetUsed     float64 `json:"budget_used,omitempty"` // EstimatedBytes / BudgetBytes
+	RequireTTL     bool    `json:"require_ttl,omitempty"`
+	OverBudget     bool    `json:"over_budget"`
+	MissingTTL     bool    `json:"missing_ttl"` // RequireTTL is set and sampled keys have no expiry
+}
+
+// MemoryReport is the result of one memory audit
+type MemoryReport struct {
+	AuditedAt      time.Time         `json:"audited_at"`
+	Duration       time.Duration     `json:"duration"`
+	UsedMemory     int64             `json:"used_memory"`
+	MaxMemory      int64             `json:"max_memory"` // 0 when Redis has no memory limit
+	EvictionPolicy string            `json:"eviction_policy"`
+	TotalKeys      int64             `json:"total_keys"`
+	ScannedKeys    int64             `json:"scanned_keys"`
+	Truncated      bool              `json:"truncated"` // The scan stopped at MaxScanKeys and counts are extrapolated
+	BudgetedBytes  int64             `json:"budgeted_bytes"`
+	Namespaces     []*NamespaceUsage `json:"namespaces"`
+	Warnings       []string          `json:"warnings,omitempty"`
+}
+
+// MemoryAlert describes a namespace that started exceeding its budget or holding keys without a TTL
+type MemoryAlert struct {
+	Namespace      string
+	Reason         string // "over_budget" or "missing_ttl"
+	EstimatedBytes int64
+	BudgetBytes    int64
+	Keys           int64
+	NoTTLRatio     float64
+}
+
+// keySample is the measured memory and TTL of one sampled key
+type keySample struct {
+	bytes int64
+	noTTL bool
+}
+
+// namespaceScan accumulates the keys of one namespace seen during a SCAN
+type namespaceScan struct {
+	keys    int64
+	sampled []string
+	samples []keySample
+}
+
+// memorySnapshot is the raw data collected by one audit
+type memorySnapshot struct {
+	info        map[string]string // INFO memory fields
+	totalKeys   int64
+	scannedKeys int64
+	truncated   bool
+	namespaces  map[string]*namespaceScan
+}
+
+// MemoryGovernor periodically audits Redis memory per key namespace. Each audit SCANs the
+// keyspace (bounded by MaxScanKeys), groups keys by budget prefix or otherwise by their
+// first segment, and measures a sample of each namespace with MEMORY USAGE and PTTL to
+// estimate its size and TTL coverage. Namespaces over budget or holding keys that never
+// expire are reported once per violation, so an on-sale is flagged before Redis reaches
+// maxmemory and starts evicting or rejecting writes.
+type MemoryGovernor struct {
+	client *redis.Client
+	config *MemoryGovernorConfig
+
+	mu         sync.Mutex
+	last       *MemoryReport
+	violations map[string]bool // "namespace/reason" currently alerted
+
+	bytesGauge   *telemetry.Gauge
+	keysGauge    *telemetry.Gauge
+	noTTLGauge   *telemetry.FloatGauge
+	alertCounter *telemetry.Counter
+
+	now     func() time.Time
+	collect func(ctx context.Context) (*memorySnapshot, error)
+	report  func(ctx context.Context, alert MemoryAlert)
+}
+
+// NewMemoryGovernor creates a memory governor for the client's database
+func NewMemoryGovernor(client *Client, cfg *MemoryGovernorConfig) *MemoryGovernor {
+	defaults := DefaultMemoryGovernorConfig()
+	if cfg == nil {
+		cfg = defaults
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaults.Interval
+	}
+	if cfg.SampleSize <= 0 {
+		cfg.SampleSize = defaults.SampleSize
+	}
+	if cfg.ScanCount <= 0 {
+		cfg.ScanCount = defaults.ScanCount
+	}
+	if cfg.MaxScanKeys <= 0 {
+		cfg.MaxScanKeys = defaults.MaxScanKeys
+	}
+
+	g := &MemoryGovernor{
+		config:     cfg,
+		violations: make(map[string]bool),
+		now:        time.Now,
+	}
+	if client != nil {
+		g.client = client.Client()
+	}
+
+	// Without metrics violations are still logged
+	g.bytesGauge, _ = telemetry.NewGauge(telemetry.MetricOpts{
+		Name:        "redis.namespace.memory",
+		Description: "Estimated memory used by the keys of a namespace",
+		Unit:        "By",
+	})
+	g.keysGauge, _ = telemetry.NewGauge(telemetry.MetricOpts{
+		Name:        "redis.namespace.keys",
+		Description: "Estimated number of keys in a namespace",
+		Unit:        "{key}",
+	})
+	g.noTTLGauge, _ = telemetry.NewFloatGauge(telemetry.MetricOpts{
+		Name:        "redis.namespace.no_ttl_ratio",
+		Description: "Fraction of sampled keys in a namespace without an expiry",
+		Unit:        "1",
+	})
+	g.alertCounter, _ = telemetry.NewCounter(telemetry.MetricOpts{
+		Name:        "redis.namespace.budget_alerts",
+		Description: "Namespaces that started exceeding their memory budget or holding keys without a TTL",
+		Unit:        "{alert}",
+	})
+	g.collect = g.scan
+	g.report = g.logAlert
+	return g
+}
+
+// Run audits memory every Interval until ctx is cancelled
+func (g *MemoryGovernor) Run(ctx context.Context) {
+	ticker := time.NewTicker(g.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := g.Audit(ctx); err != nil && ctx.Err() == nil {
+			logger.Get().WarnContext(ctx, "Redis memory audit failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// LastReport returns the most recent audit, or nil before the first one completes
+func (g *MemoryGovernor) LastReport() *MemoryReport {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.last
+}
+
+// Audit measures every namespace, records the metrics and reports new budget violations
+func (g *MemoryGovernor) Audit(ctx context.Context) (*MemoryReport, error) {
+	ctx, span := telemetry.StartSpan(ctx, "redis.memory.audit")
+	defer span.End()
+
+	start := g.now()
+	snapshot, err := g.collect(ctx)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	report := g.buildReport(snapshot)
+	report.AuditedAt = start
+	report.Duration = g.now().Sub(start)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	active := make(map[string]bool)
+	for _, ns := range report.Namespaces {
+		attrs := []attribute.KeyValue{attribute.String("redis.namespace", ns.Namespace)}
+		if g.bytesGauge != nil {
+			g.bytesGauge.Record(ctx, ns.EstimatedBytes, attrs...)
+		}
+		if g.keysGauge != nil {
+			g.keysGauge.Record(ctx, ns.Keys, attrs...)
+		}
+		if g.noTTLGauge != nil {
+			g.noTTLGauge.Record(ctx, ns.NoTTLRatio, attrs...)
+		}
+
+		for reason, violated := range map[string]bool{"over_budget": ns.OverBudget, "missing_ttl": ns.MissingTTL} {
+			if !violated {
+				continue
+			}
+			id := ns.Namespace + "/" + reason
+			active[id] = true
+			// Reported once per violation; the gauges show how it develops
+			if g.violations[id] {
+				continue
+			}
+			if g.alertCounter != nil {
+				g.alertCounter.Inc(ctx, append(attrs, attribute.String("reason", reason))...)
+			}
+			g.report(ctx, MemoryAlert{
+				Namespace:      ns.Namespace,
+				Reason:         reason,
+				EstimatedBytes: ns.EstimatedBytes,
+				BudgetBytes:    ns.BudgetBytes,
+				Keys:           ns.Keys,
+				NoTTLRatio:     ns.NoTTLRatio,
+			})
+		}
+	}
+	for id := range g.violations {
+		if !active[id] {
+			logger.Get().InfoContext(ctx, "Redis namespace back within budget", zap.String("violation", id))
+		}
+	}
+	g.violations = active
+	g.last = report
+
+	span.SetAttributes(
+		attribute.Int64("redis.scanned_keys", report.ScannedKeys),
+		attribute.Int("redis.namespaces", len(report.Namespaces)),
+		attribute.Int("redis.violations", len(active)),
+	)
+	return report, nil
+}
+
+// namespaceOf returns the budget prefix of key, or its first segment ("queue:") without one
+func (g *MemoryGovernor) namespaceOf(key string) string {
+	match := ""
+	for _, budget := range g.config.Budgets {
+		if strings.HasPrefix(key, budget.Prefix) && len(budget.Prefix) > len(match) {
+			match = budget.Prefix
+		}
+	}
+	if match != "" {
+		return match
+	}
+	if i := strings.Index(key, ":"); i >= 0 {
+		return key[:i+1]
+	}
+	return key
+}
+
+// scan collects the keyspace snapshot with SCAN, then measures the samples in one pipeline
+func (g *MemoryGovernor) scan(ctx context.Context) (*memorySnapshot, error) {
+	if g.client == nil {
+		return nil, fmt.Errorf("redis client is not configured")
+	}
+
+	info, err := g.client.Info(ctx, "memory").Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read memory info: %w", err)
+	}
+	totalKeys, err := g.client.DBSize(ctx).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key count: %w", err)
+	}
+
+	snapshot := &memorySnapshot{
+		info:       parseInfo(info),
+		totalKeys:  totalKeys,
+		namespaces: make(map[string]*namespaceScan),
+	}
+
+	var cursor uint64
+	for {
+		keys, next, err := g.client.Scan(ctx, cursor, "*", g.config.ScanCount).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan keys: %w", err)
+		}
+		for _, key := range keys {
+			name := g.namespaceOf(key)
+			ns, ok := snapshot.namespaces[name]
+			if !ok {
+				ns = &namespaceScan{}
+				snapshot.namespaces[name] = ns
+			}
+			ns.keys++
+			// SCAN returns keys in hash-slot order, so the first keys seen are a fair sample
+			if len(ns.sampled) < g.config.SampleSize {
+				ns.sampled = append(ns.sampled, key)
+			}
+		}
+		snapshot.scannedKeys += int64(len(keys))
+
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+		if snapshot.scannedKeys >= g.config.MaxScanKeys {
+			snapshot.truncated = true
+			break
+		}
+	}
+
+	pipe := g.client.Pipeline()
+	type pending struct {
+		ns    *namespaceScan
+		usage *redis.IntCmd
+		ttl   *redis.DurationCmd
+	}
+	var cmds []pending
+	for _, ns := range snapshot.namespaces {
+		for _, key := range ns.sampled {
+			cmds = append(cmds, pending{
+				ns:    ns,
+				usage: pipe.MemoryUsage(ctx, key, 5),
+				ttl:   pipe.PTTL(ctx, key),
+			})
+		}
+	}
+	if len(cmds) > 0 {
+		// Keys that expired since the scan fail individually and are skipped below
+		if _, err := pipe.Exec(ctx); err != nil && ctx.Err() != nil {
+			return nil, fmt.Errorf("failed to measure sampled keys: %w", err)
+		}
+	}
+	for _, cmd := range cmds {
+		bytes, err := cmd.usage.Result()
+		if err != nil {
+			continue
+		}
+		ttl, err := cmd.ttl.Result()
+		if err != nil || ttl == -2 { // -2: the key no longer exists
+			continue
+		}
+		cmd.ns.samples = append(cmd.ns.samples, keySample{bytes: bytes, noTTL: ttl == -1})
+	}
+
+	return snapshot, nil
+}
+
+// buildReport estimates each namespace's size from its samples and checks it against its budget
+func (g *MemoryGovernor) buildReport(snapshot *memorySnapshot) *MemoryReport {
+	report := &MemoryReport{
+		UsedMemory:     infoInt(snapshot.info, "used_memory"),
+		MaxMemory:      infoInt(snapshot.info, "maxmemory"),
+		EvictionPolicy: snapshot.info["maxmemory_policy"],
+		TotalKeys:      snapshot.totalKeys,
+		ScannedKeys:    snapshot.scannedKeys,
+		Truncated:      snapshot.truncated,
+		Namespaces:     []*NamespaceUsage{},
+	}
+
+	// A truncated scan saw only part of the keyspace; scale its counts up to DBSIZE
+	scale := 1.0
+	if snapshot.truncated && snapshot.scannedKeys > 0 && snapshot.totalKeys > snapshot.scannedKeys {
+		scale = float64(snapshot.totalKeys) / float64(snapshot.scannedKeys)
+	}
+
+	budgets := make(map[string]NamespaceBudget, len(g.config.Budgets))
+	for _, budget := range g.config.Budgets {
+		budgets[budget.Prefix] = budget
+		report.BudgetedBytes += budget.MaxBytes
+		// Budgeted namespaces are reported even when empty
+		if _, ok := snapshot.namespaces[budget.Prefix]; !ok {
+			snapshot.namespaces[budget.Prefix] = &namespaceScan{}
+		}
+	}
+
+	for name, ns := range snapshot.namespaces {
+		usage := &NamespaceUsage{
+			Namespace:   name,
+			Keys:        int64(float64(ns.keys) * scale),
+			SampledKeys: len(ns.samples),
+		}
+		if len(ns.samples) > 0 {
+			var total int64
+			var noTTL int
+			for _, s := range ns.samples {
+				total += s.bytes
+				if s.noTTL {
+					noTTL++
+				}
+			}
+			usage.AvgKeyBytes = total / int64(len(ns.samples))
+			usage.EstimatedBytes = usage.AvgKeyBytes * usage.Keys
+			usage.NoTTLRatio = float64(noTTL) / float64(len(ns.samples))
+		}
+
+		if budget, ok := budgets[name]; ok {
+			usage.BudgetBytes = budget.MaxBytes
+			usage.RequireTTL = budget.RequireTTL
+			if budget.MaxBytes > 0 {
+				usage.BudgetUsed = float64(usage.EstimatedBytes) / float64(budget.MaxBytes)
+				usage.OverBudget = usage.EstimatedBytes > budget.MaxBytes
+			}
+			usage.MissingTTL = budget.RequireTTL && usage.NoTTLRatio > 0
+		}
+		report.Namespaces = append(report.Namespaces, usage)
+	}
+	sort.Slice(report.Namespaces, func(i, j int) bool {
+		return report.Namespaces[i].EstimatedBytes > report.Namespaces[j].EstimatedBytes
+	})
+
+	// Budgets only protect reservations if Redis never has to evict to stay under maxmemory
+	if report.MaxMemory > 0 && report.BudgetedBytes > report.MaxMemory {
+		report.Warnings = append(report.Warnings, fmt.Sprintf(
+			"namespace budgets total %d bytes, more than maxmemory %d bytes", report.BudgetedBytes, report.MaxMemory))
+	}
+	if strings.HasPrefix(report.EvictionPolicy, "allkeys-") {
+		report.Warnings = append(report.Warnings, fmt.Sprintf(
+			"eviction policy %s may evict reservation holds and queue entries under memory pressure", report.EvictionPolicy))
+	}
+	return report
+}
+
+// logAlert logs an alert and passes it to the OnAlert hook
+func (g *MemoryGovernor) logAlert(ctx context.Context, alert MemoryAlert) {
+	logger.Get().WarnContext(ctx, "[ALERT] Redis namespace exceeds its memory budget",
+		zap.String("namespace", alert.Namespace),
+		zap.String("reason", alert.Reason),
+		zap.Int64("estimated_bytes", alert.EstimatedBytes),
+		zap.Int64("budget_bytes", alert.BudgetBytes),
+		zap.Int64("keys", alert.Keys),
+		zap.Float64("no_ttl_ratio", alert.NoTTLRatio),
+	)
+	if g.config.OnAlert != nil {
+		g.config.OnAlert(ctx, alert)
+	}
+}
+
+// parseInfo parses the "field:value" lines of an INFO reply
+func parseInfo(info string) map[string]string {
+	fields := make(map[string]string)
+	for _, line := range strings.Split(info, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if k, v, ok := strings.Cut(line, ":"); ok {
+			fields[k] = v
+		}
+	}
+	return fields
+}
+
+// infoInt returns an integer INFO field, or 0 if it is missing
+func infoInt(fields map[string]string, name string) int64 {
+	n, _ := strconv.ParseInt(fields[name], 10, 64)
+	return n
+}
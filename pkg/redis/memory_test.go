@@ -0,0 +1,181 @@
+package redis
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestParseNamespaceBudgets(t *testing.T) {
+	budgets, err := ParseNamespaceBudgets("queue:=256MB, booking:idempotency:=64mb+ttl,reservation:=1GB+TTL,misc:=512")
+	if err != nil {
+		t.Fatalf("ParseNamespaceBudgets() error = %v", err)
+	}
+
+	want := []NamespaceBudget{
+		{Prefix: "queue:", MaxBytes: 256 << 20},
+		{Prefix: "booking:idempotency:", MaxBytes: 64 << 20, RequireTTL: true},
+		{Prefix: "reservation:", MaxBytes: 1 << 30, RequireTTL: true},
+		{Prefix: "misc:", MaxBytes: 512},
+	}
+	if len(budgets) != len(want) {
+		t.Fatalf("got %d budgets, want %d", len(budgets), len(want))
+	}
+	for i := range want {
+		if budgets[i] != want[i] {
+			t.Errorf("budget %d = %+v, want %+v", i, budgets[i], want[i])
+		}
+	}
+
+	for _, spec := range []string{"queue:", "=1MB", "queue:=lots", "queue:=-1", "queue:=1MB,queue:=2MB"} {
+		if _, err := ParseNamespaceBudgets(spec); err == nil {
+			t.Errorf("ParseNamespaceBudgets(%q) expected error", spec)
+		}
+	}
+}
+
+// newTestMemoryGovernor returns a governor auditing the snapshots returned by snapshot
+func newTestMemoryGovernor(budgets []NamespaceBudget, snapshot func() *memorySnapshot, alerts *[]MemoryAlert) *MemoryGovernor {
+	g := NewMemoryGovernor(nil, &MemoryGovernorConfig{Budgets: budgets})
+	g.collect = func(context.Context) (*memorySnapshot, error) { return snapshot(), nil }
+	g.report = func(_ context.Context, alert MemoryAlert) { *alerts = append(*alerts, alert) }
+	return g
+}
+
+// samples returns n sampled keys of the given size
+func samples(n int, bytes int64, noTTL int) []keySample {
+	s := make([]keySample, n)
+	for i := range s {
+		s[i] = keySample{bytes: bytes, noTTL: i < noTTL}
+	}
+	return s
+}
+
+func TestMemoryGovernor_Audit(t *testing.T) {
+	queueKeys := int64(1000)
+	snapshot := func() *memorySnapshot {
+		return &memorySnapshot{
+			info: map[string]string{
+				"used_memory":      "5000000",
+				"maxmemory":        "2000000",
+				"maxmemory_policy": "volatile-lru",
+			},
+			totalKeys:   queueKeys + 20,
+			scannedKeys: queueKeys + 20,
+			namespaces: map[string]*namespaceScan{
+				"queue:":       {keys: queueKeys, samples: samples(10, 1000, 0)},
+				"reservation:": {keys: 20, samples: samples(10, 200, 1)},
+			},
+		}
+	}
+
+	var alerts []MemoryAlert
+	g := newTestMemoryGovernor([]NamespaceBudget{
+		{Prefix: "queue:", MaxBytes: 500000},
+		{Prefix: "reservation:", MaxBytes: 1 << 20, RequireTTL: true},
+		{Prefix: "booking:idempotency:", MaxBytes: 1 << 20, RequireTTL: true},
+	}, snapshot, &alerts)
+
+	report, err := g.Audit(context.Background())
+	if err != nil {
+		t.Fatalf("Audit() error = %v", err)
+	}
+
+	usage := make(map[string]*NamespaceUsage)
+	for _, ns := range report.Namespaces {
+		usage[ns.Namespace] = ns
+	}
+	queue := usage["queue:"]
+	if queue == nil || queue.EstimatedBytes != 1000000 || !queue.OverBudget || queue.BudgetUsed != 2 {
+		t.Errorf("queue usage = %+v, want 1000000 bytes over its budget", queue)
+	}
+	reservation := usage["reservation:"]
+	if reservation == nil || reservation.OverBudget || !reservation.MissingTTL || reservation.NoTTLRatio != 0.1 {
+		t.Errorf("reservation usage = %+v, want within budget with keys missing a TTL", reservation)
+	}
+	if idem := usage["booking:idempotency:"]; idem == nil || idem.Keys != 0 || idem.OverBudget || idem.MissingTTL {
+		t.Errorf("empty budgeted namespace = %+v, want reported without violations", idem)
+	}
+	if report.Namespaces[0].Namespace != "queue:" {
+		t.Errorf("namespaces not sorted by size: first is %s", report.Namespaces[0].Namespace)
+	}
+	if len(report.Warnings) != 1 || !strings.Contains(report.Warnings[0], "maxmemory") {
+		t.Errorf("warnings = %v, want budgets exceeding maxmemory", report.Warnings)
+	}
+
+	if len(alerts) != 2 {
+		t.Fatalf("alerts = %+v, want over_budget and missing_ttl", alerts)
+	}
+
+	// Ongoing violations are not reported again
+	if _, err := g.Audit(context.Background()); err != nil {
+		t.Fatalf("Audit() error = %v", err)
+	}
+	if len(alerts) != 2 {
+		t.Errorf("alerts = %d after a repeated violation, want 2", len(alerts))
+	}
+
+	// A namespace that recovered and exceeds its budget again is reported again
+	queueKeys = 100
+	g.Audit(context.Background())
+	queueKeys = 1000
+	g.Audit(context.Background())
+	if len(alerts) != 3 || alerts[2].Namespace != "queue:" || alerts[2].Reason != "over_budget" {
+		t.Errorf("alerts = %+v, want a new queue: over_budget alert", alerts)
+	}
+
+	if g.LastReport() == nil {
+		t.Error("LastReport() = nil after an audit")
+	}
+}
+
+func TestMemoryGovernor_TruncatedScanIsExtrapolated(t *testing.T) {
+	var alerts []MemoryAlert
+	g := newTestMemoryGovernor(nil, func() *memorySnapshot {
+		return &memorySnapshot{
+			info:        map[string]string{"maxmemory_policy": "allkeys-lru"},
+			totalKeys:   4000,
+			scannedKeys: 1000,
+			truncated:   true,
+			namespaces: map[string]*namespaceScan{
+				"zone:": {keys: 1000, samples: samples(5, 100, 5)},
+			},
+		}
+	}, &alerts)
+
+	report, err := g.Audit(context.Background())
+	if err != nil {
+		t.Fatalf("Audit() error = %v", err)
+	}
+	zone := report.Namespaces[0]
+	if zone.Keys != 4000 || zone.EstimatedBytes != 400000 || zone.NoTTLRatio != 1 {
+		t.Errorf("zone usage = %+v, want 4000 keys extrapolated from the scanned quarter", zone)
+	}
+	if zone.MissingTTL || len(alerts) != 0 {
+		t.Errorf("unbudgeted namespace raised alerts: %+v", alerts)
+	}
+	if len(report.Warnings) != 1 || !strings.Contains(report.Warnings[0], "allkeys-lru") {
+		t.Errorf("warnings = %v, want the allkeys eviction policy flagged", report.Warnings)
+	}
+}
+
+func TestMemoryGovernor_NamespaceOf(t *testing.T) {
+	g := NewMemoryGovernor(nil, &MemoryGovernorConfig{Budgets: []NamespaceBudget{
+		{Prefix: "queue:"},
+		{Prefix: "queue:pass:"},
+	}})
+
+	tests := map[string]string{
+		"queue:event-1":           "queue:",
+		"queue:pass:event-1:u1":   "queue:pass:",
+		"reservation:b1":          "reservation:",
+		"zone:availability:z1":    "zone:",
+		"standalone":              "standalone",
+		"booking:idempotency:abc": "booking:",
+	}
+	for key, want := range tests {
+		if got := g.namespaceOf(key); got != want {
+			t.Errorf("namespaceOf(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
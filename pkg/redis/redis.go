@@ -32,6 +32,9 @@ type Config struct {
 	// Telemetry configuration
 	EnableTracing bool
 	ServiceName   string
+
+	// SlowCommandThreshold logs and counts commands that take at least this long (0 disables)
+	SlowCommandThreshold time.Duration
 }
 
 // DefaultConfig returns default Redis configuration
@@ -48,6 +51,8 @@ func DefaultConfig() *Config {
 		WriteTimeout:  3 * time.Second,
 		MaxRetries:    3,
 		RetryInterval: time.Second,
+
+		SlowCommandThreshold: 50 * time.Millisecond,
 	}
 }
 
@@ -105,6 +110,11 @@ func NewClient(ctx context.Context, cfg *Config) (*Client, error) {
 		}
 	}
 
+	// Log slow commands (runs inside the tracing hook, so logs carry the command's trace_id)
+	if cfg.SlowCommandThreshold > 0 {
+		client.AddHook(NewSlowCommandHook(cfg.SlowCommandThreshold))
+	}
+
 	// Connect with retry logic
 	var lastErr error
 	for attempt := 0; attempt <= cfg.MaxRetries; attempt++ {
@@ -0,0 +1,117 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/telemetry"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// Limits for the command logged for a slow Redis call
+const (
+	maxLoggedArgs       = 8
+	maxLoggedCommandLen = 512
+)
+
+// SlowCommandHook logs Redis commands and pipelines slower than a threshold with the
+// command, duration and trace_id, and counts them per command in the redis.slow_commands
+// metric. Only the first few arguments are logged; values written by SET/HSET may be large.
+type SlowCommandHook struct {
+	threshold time.Duration
+	counter   *telemetry.Counter
+	now       func() time.Time
+	report    func(ctx context.Context, operation, command string, duration time.Duration)
+}
+
+// NewSlowCommandHook creates a hook reporting commands that take at least threshold
+func NewSlowCommandHook(threshold time.Duration) *SlowCommandHook {
+	h := &SlowCommandHook{threshold: threshold, now: time.Now}
+	// Without the counter slow commands are still logged
+	h.counter, _ = telemetry.NewCounter(telemetry.MetricOpts{
+		Name:        "redis.slow_commands",
+		Description: "Redis commands slower than the slow command threshold",
+		Unit:        "{command}",
+	})
+	h.report = h.logSlowCommand
+	return h
+}
+
+// DialHook passes dials through unchanged
+func (h *SlowCommandHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+// ProcessHook times a single command
+func (h *SlowCommandHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := h.now()
+		err := next(ctx, cmd)
+		if duration := h.now().Sub(start); duration >= h.threshold {
+			h.report(ctx, cmd.Name(), formatCommand(cmd), duration)
+		}
+		return err
+	}
+}
+
+// ProcessPipelineHook times a pipeline (or MULTI/EXEC transaction) as one operation
+func (h *SlowCommandHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := h.now()
+		err := next(ctx, cmds)
+		if duration := h.now().Sub(start); duration >= h.threshold {
+			names := make([]string, len(cmds))
+			for i, cmd := range cmds {
+				names[i] = cmd.Name()
+			}
+			h.report(ctx, "pipeline", truncateCommand(strings.Join(names, " ")), duration)
+		}
+		return err
+	}
+}
+
+// logSlowCommand logs a slow command with the caller's trace_id and increments the slow counter
+func (h *SlowCommandHook) logSlowCommand(ctx context.Context, operation, command string, duration time.Duration) {
+	logger.Get().WarnContext(ctx, "Slow Redis command",
+		zap.String("operation", operation),
+		zap.String("command", command),
+		zap.Duration("duration", duration),
+		zap.Duration("threshold", h.threshold),
+	)
+	if h.counter != nil {
+		h.counter.Inc(ctx, attribute.String("db.operation", operation))
+	}
+}
+
+// formatCommand renders the command name and its first arguments, e.g. "evalsha 3f2a... 2 queue:e1"
+func formatCommand(cmd redis.Cmder) string {
+	args := cmd.Args()
+	parts := make([]string, 0, maxLoggedArgs+1)
+	for i, arg := range args {
+		if i == maxLoggedArgs {
+			parts = append(parts, fmt.Sprintf("...(%d more)", len(args)-maxLoggedArgs))
+			break
+		}
+		parts = append(parts, fmt.Sprint(arg))
+	}
+	return truncateCommand(strings.Join(parts, " "))
+}
+
+// truncateCommand caps the command length for logging
+func truncateCommand(command string) string {
+	if len(command) > maxLoggedCommandLen {
+		return command[:maxLoggedCommandLen] + "..."
+	}
+	return command
+}
+
+// Ensure SlowCommandHook implements redis.Hook
+var _ redis.Hook = (*SlowCommandHook)(nil)
@@ -0,0 +1,114 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type slowCommandReport struct {
+	operation string
+	command   string
+	duration  time.Duration
+}
+
+// newTestSlowCommandHook returns a hook whose commands take the given duration
+func newTestSlowCommandHook(threshold, commandDuration time.Duration, reports *[]slowCommandReport) *SlowCommandHook {
+	hook := NewSlowCommandHook(threshold)
+	now := time.Now()
+	hook.now = func() time.Time {
+		current := now
+		now = now.Add(commandDuration)
+		return current
+	}
+	hook.report = func(_ context.Context, operation, command string, duration time.Duration) {
+		*reports = append(*reports, slowCommandReport{operation, command, duration})
+	}
+	return hook
+}
+
+func TestSlowCommandHook_ProcessHook(t *testing.T) {
+	tests := []struct {
+		name            string
+		commandDuration time.Duration
+		wantReport      bool
+	}{
+		{"fast command", time.Millisecond, false},
+		{"at threshold", 50 * time.Millisecond, true},
+		{"slow command", 200 * time.Millisecond, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reports []slowCommandReport
+			hook := newTestSlowCommandHook(50*time.Millisecond, tt.commandDuration, &reports)
+
+			wantErr := errors.New("boom")
+			process := hook.ProcessHook(func(ctx context.Context, cmd redis.Cmder) error { return wantErr })
+			cmd := redis.NewStringCmd(context.Background(), "get", "zone:z1:available")
+			if err := process(context.Background(), cmd); err != wantErr {
+				t.Errorf("ProcessHook() error = %v, want %v", err, wantErr)
+			}
+
+			if !tt.wantReport {
+				if len(reports) != 0 {
+					t.Errorf("Expected no report, got %+v", reports)
+				}
+				return
+			}
+			if len(reports) != 1 {
+				t.Fatalf("Expected 1 report, got %d", len(reports))
+			}
+			want := slowCommandReport{"get", "get zone:z1:available", tt.commandDuration}
+			if reports[0] != want {
+				t.Errorf("Report = %+v, want %+v", reports[0], want)
+			}
+		})
+	}
+}
+
+func TestSlowCommandHook_ProcessPipelineHook(t *testing.T) {
+	var reports []slowCommandReport
+	hook := newTestSlowCommandHook(50*time.Millisecond, 100*time.Millisecond, &reports)
+
+	process := hook.ProcessPipelineHook(func(ctx context.Context, cmds []redis.Cmder) error { return nil })
+	ctx := context.Background()
+	cmds := []redis.Cmder{
+		redis.NewStatusCmd(ctx, "multi"),
+		redis.NewIntCmd(ctx, "decrby", "zone:z1:available", 2),
+		redis.NewSliceCmd(ctx, "exec"),
+	}
+	if err := process(ctx, cmds); err != nil {
+		t.Fatalf("ProcessPipelineHook() error = %v", err)
+	}
+
+	if len(reports) != 1 {
+		t.Fatalf("Expected 1 report, got %d", len(reports))
+	}
+	if reports[0].operation != "pipeline" || reports[0].command != "multi decrby exec" {
+		t.Errorf("Report = %+v", reports[0])
+	}
+}
+
+func TestFormatCommand(t *testing.T) {
+	ctx := context.Background()
+	args := []interface{}{"evalsha", "abc123", 2}
+	for i := 0; i < 10; i++ {
+		args = append(args, "arg")
+	}
+
+	got := formatCommand(redis.NewCmd(ctx, args...))
+	want := "evalsha abc123 2 arg arg arg arg arg ...(5 more)"
+	if got != want {
+		t.Errorf("formatCommand() = %q, want %q", got, want)
+	}
+
+	long := formatCommand(redis.NewStatusCmd(ctx, "set", "key", strings.Repeat("v", 2*maxLoggedCommandLen)))
+	if len(long) != maxLoggedCommandLen+len("...") {
+		t.Errorf("formatCommand() length = %d, want %d", len(long), maxLoggedCommandLen+3)
+	}
+}
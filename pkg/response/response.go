@@ -1,7 +1,11 @@
 package response
 
 import (
+	"encoding/json"
 	"net/http"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/i18n"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 )
 
 // Response represents the standard API response structure
@@ -25,6 +29,9 @@ type Meta struct {
 	PerPage    int   `json:"per_page"`
 	Total      int64 `json:"total"`
 	TotalPages int   `json:"total_pages"`
+
+	// Set on cursor-paginated lists; adds next_cursor and has_more
+	*pagination.Info
 }
 
 // PaginationParams represents pagination input parameters
@@ -161,6 +168,14 @@ func Paginated(data interface{}, page, perPage int, total int64) *Response {
 	}
 }
 
+// CursorPaginated creates a paginated success response that also carries the cursor
+// for the next page
+func CursorPaginated(data interface{}, page, perPage int, total int64, info pagination.Info) *Response {
+	resp := Paginated(data, page, perPage, total)
+	resp.Meta.Info = &info
+	return resp
+}
+
 // PaginatedFromParams creates a paginated response using PaginationParams
 func PaginatedFromParams(data interface{}, params PaginationParams, total int64) *Response {
 	return Paginated(data, params.Page, params.PerPage, total)
@@ -233,3 +248,66 @@ func ServiceUnavailable(message string) *Response {
 	}
 	return Error(ErrCodeServiceUnavailable, message)
 }
+
+// --- Localization ---
+
+// Localize translates the error message into locale when the catalog has the error code.
+// Responses are written in English, so English responses keep their specific messages.
+func (r *Response) Localize(locale i18n.Locale) *Response {
+	if r == nil || r.Error == nil || locale == i18n.DefaultLocale {
+		return r
+	}
+	if msg, ok := i18n.Lookup(locale, r.Error.Code); ok {
+		r.Error.Message = msg
+	}
+	return r
+}
+
+// LocalizeErrorBody translates the message of a JSON error response body into locale.
+// It handles the standard {"error":{"code":...,"message":...}} shape and the flat
+// {"error":...,"code":...} shape, whose "error" field holds the message.
+// Returns false if the body was left unchanged.
+func LocalizeErrorBody(body []byte, locale i18n.Locale) ([]byte, bool) {
+	if locale == i18n.DefaultLocale {
+		return body, false
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return body, false
+	}
+
+	var code, messageKey string
+	target := fields
+	var nested map[string]json.RawMessage
+	if err := json.Unmarshal(fields["error"], &nested); err == nil && nested != nil {
+		// Standard shape
+		target = nested
+		messageKey = "message"
+		_ = json.Unmarshal(nested["code"], &code)
+	} else {
+		messageKey = "error"
+		_ = json.Unmarshal(fields["code"], &code)
+	}
+
+	msg, ok := i18n.Lookup(locale, code)
+	if code == "" || !ok {
+		return body, false
+	}
+	encoded, err := json.Marshal(msg)
+	if err != nil {
+		return body, false
+	}
+	target[messageKey] = encoded
+
+	if nested != nil {
+		if fields["error"], err = json.Marshal(nested); err != nil {
+			return body, false
+		}
+	}
+	localized, err := json.Marshal(fields)
+	if err != nil {
+		return body, false
+	}
+	return localized, true
+}
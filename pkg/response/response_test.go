@@ -3,7 +3,11 @@ package response
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
 	"testing"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/i18n"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/pagination"
 )
 
 func TestSuccess(t *testing.T) {
@@ -170,6 +174,23 @@ func TestPaginated_TotalPagesCalculation(t *testing.T) {
 	}
 }
 
+func TestCursorPaginated_JSONFormat(t *testing.T) {
+	resp := CursorPaginated([]string{"item1"}, 1, 1, 2, pagination.Info{NextCursor: "abc", HasMore: true})
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	if !strings.Contains(string(data), `"next_cursor":"abc","has_more":true`) {
+		t.Errorf("Expected cursor fields in meta, got %s", data)
+	}
+
+	data, _ = json.Marshal(Paginated(nil, 1, 10, 0))
+	if strings.Contains(string(data), "has_more") {
+		t.Errorf("Expected no cursor fields for offset pagination, got %s", data)
+	}
+}
+
 func TestPaginatedFromParams(t *testing.T) {
 	params := PaginationParams{Page: 2, PerPage: 15}
 	resp := PaginatedFromParams(nil, params, 100)
@@ -271,3 +292,86 @@ func TestValidationFailed(t *testing.T) {
 		t.Errorf("Expected 2 details, got %d", len(resp.Error.Details))
 	}
 }
+
+func TestResponse_Localize(t *testing.T) {
+	resp := Unauthorized("Token is missing").Localize(i18n.English)
+	if resp.Error.Message != "Token is missing" {
+		t.Errorf("Expected English message to be kept, got %q", resp.Error.Message)
+	}
+
+	resp = Unauthorized("Token is missing").Localize(i18n.Thai)
+	if resp.Error.Message != "กรุณาเข้าสู่ระบบ" {
+		t.Errorf("Expected Thai message, got %q", resp.Error.Message)
+	}
+
+	resp = Error("SOMETHING_NEW", "Something new").Localize(i18n.Thai)
+	if resp.Error.Message != "Something new" {
+		t.Errorf("Expected unknown code to keep its message, got %q", resp.Error.Message)
+	}
+}
+
+func TestLocalizeErrorBody(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		locale  i18n.Locale
+		changed bool
+		field   func(map[string]interface{}) interface{}
+		want    string
+	}{
+		{
+			name:    "standard shape",
+			body:    `{"success":false,"error":{"code":"NOT_FOUND","message":"Event not found"}}`,
+			locale:  i18n.Thai,
+			changed: true,
+			field:   func(m map[string]interface{}) interface{} { return m["error"].(map[string]interface{})["message"] },
+			want:    "ไม่พบข้อมูลที่ต้องการ",
+		},
+		{
+			name:    "flat shape",
+			body:    `{"error":"booking not found","code":"NOT_FOUND","message":"detail"}`,
+			locale:  i18n.Thai,
+			changed: true,
+			field:   func(m map[string]interface{}) interface{} { return m["error"] },
+			want:    "ไม่พบข้อมูลที่ต้องการ",
+		},
+		{
+			name:   "english is the source language",
+			body:   `{"success":false,"error":{"code":"NOT_FOUND","message":"Event not found"}}`,
+			locale: i18n.English,
+		},
+		{
+			name:   "unknown code",
+			body:   `{"success":false,"error":{"code":"SOMETHING_NEW","message":"Something new"}}`,
+			locale: i18n.Thai,
+		},
+		{
+			name:   "not json",
+			body:   `upstream error`,
+			locale: i18n.Thai,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed := LocalizeErrorBody([]byte(tt.body), tt.locale)
+			if changed != tt.changed {
+				t.Fatalf("Expected changed=%v, got %v (%s)", tt.changed, changed, got)
+			}
+			if !changed {
+				if string(got) != tt.body {
+					t.Errorf("Expected body unchanged, got %s", got)
+				}
+				return
+			}
+
+			var m map[string]interface{}
+			if err := json.Unmarshal(got, &m); err != nil {
+				t.Fatalf("Invalid JSON: %v", err)
+			}
+			if msg := tt.field(m); msg != tt.want {
+				t.Errorf("Expected %q, got %v", tt.want, msg)
+			}
+		})
+	}
+}
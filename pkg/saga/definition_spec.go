@@ -0,0 +1,193 @@
+package saga
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrInvalidDefinitionSpec is returned when a declarative saga definition is malformed
+	ErrInvalidDefinitionSpec = errors.New("invalid saga definition spec")
+	// ErrUnknownStepHandler is returned when a definition references a handler that was never registered
+	ErrUnknownStepHandler = errors.New("unknown saga step handler")
+	// ErrDefinitionStepsChanged is returned when a reload changes the steps of a registered
+	// definition; in-flight instances track their position by step index
+	ErrDefinitionStepsChanged = errors.New("saga definition steps changed")
+)
+
+// Duration is a time.Duration written as a Go duration string ("30s", "2m") in definition files
+type Duration time.Duration
+
+// MarshalText implements encoding.TextMarshaler
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (d *Duration) UnmarshalText(text []byte) error {
+	parsed, err := time.ParseDuration(string(text))
+	if err != nil {
+		return fmt.Errorf("invalid duration %q: %w", text, err)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// DefinitionFile is the top-level document of a saga definition file
+type DefinitionFile struct {
+	Definitions []DefinitionSpec `json:"definitions" yaml:"definitions"`
+}
+
+// DefinitionSpec is the declarative form of a Definition
+type DefinitionSpec struct {
+	Name        string     `json:"name" yaml:"name"`
+	Description string     `json:"description" yaml:"description"`
+	Timeout     Duration   `json:"timeout" yaml:"timeout"` // Defaults to 5m
+	Steps       []StepSpec `json:"steps" yaml:"steps"`
+}
+
+// StepSpec is the declarative form of a Step
+type StepSpec struct {
+	Name        string   `json:"name" yaml:"name"`
+	Description string   `json:"description" yaml:"description"`
+	Handler     string   `json:"handler" yaml:"handler"` // Registered handler name, defaults to Name
+	Topic       string   `json:"topic" yaml:"topic"`     // Overrides the handler's topic
+	Timeout     Duration `json:"timeout" yaml:"timeout"` // Defaults to 30s
+	Retries     int      `json:"retries" yaml:"retries"`
+}
+
+// StepHandler is the code a declarative step runs
+type StepHandler struct {
+	Execute    ExecuteFunc
+	Compensate CompensateFunc
+	Topic      string // Command topic for event-driven execution
+}
+
+// HandlerRegistry maps the handler names used in definition files to compiled-in step handlers
+type HandlerRegistry struct {
+	mu       sync.RWMutex
+	handlers map[string]StepHandler
+}
+
+// NewHandlerRegistry creates an empty HandlerRegistry
+func NewHandlerRegistry() *HandlerRegistry {
+	return &HandlerRegistry{handlers: make(map[string]StepHandler)}
+}
+
+// Register registers a step handler under a name
+func (r *HandlerRegistry) Register(name string, handler StepHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[name] = handler
+}
+
+// RegisterDefinition registers the steps of a compiled-in definition under
+// "<definition>.<step>", so a definition file can retune them without naming handlers
+func (r *HandlerRegistry) RegisterDefinition(def *Definition) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, step := range def.Steps {
+		r.handlers[def.Name+"."+step.Name] = StepHandler{
+			Execute:    step.Execute,
+			Compensate: step.Compensate,
+			Topic:      step.Topic,
+		}
+	}
+}
+
+// lookup resolves a handler of a definition, preferring the definition's own step
+func (r *HandlerRegistry) lookup(definition, name string) (StepHandler, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if handler, ok := r.handlers[definition+"."+name]; ok {
+		return handler, true
+	}
+	handler, ok := r.handlers[name]
+	return handler, ok
+}
+
+// ParseDefinitionFile parses a JSON saga definition file
+func ParseDefinitionFile(data []byte) (*DefinitionFile, error) {
+	var file DefinitionFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDefinitionSpec, err)
+	}
+	return &file, nil
+}
+
+// Build resolves every definition of the file against the registry
+func (f *DefinitionFile) Build(registry *HandlerRegistry) ([]*Definition, error) {
+	seen := make(map[string]bool, len(f.Definitions))
+	defs := make([]*Definition, 0, len(f.Definitions))
+	for i := range f.Definitions {
+		def, err := f.Definitions[i].Build(registry)
+		if err != nil {
+			return nil, err
+		}
+		if seen[def.Name] {
+			return nil, fmt.Errorf("%w: definition %s is declared twice", ErrInvalidDefinitionSpec, def.Name)
+		}
+		seen[def.Name] = true
+		defs = append(defs, def)
+	}
+	return defs, nil
+}
+
+// Build resolves the spec's step handlers and returns the Definition it describes
+func (s *DefinitionSpec) Build(registry *HandlerRegistry) (*Definition, error) {
+	if s.Name == "" {
+		return nil, fmt.Errorf("%w: definition name is required", ErrInvalidDefinitionSpec)
+	}
+	if len(s.Steps) == 0 {
+		return nil, fmt.Errorf("%w: definition %s has no steps", ErrInvalidDefinitionSpec, s.Name)
+	}
+	if s.Timeout < 0 {
+		return nil, fmt.Errorf("%w: definition %s has a negative timeout", ErrInvalidDefinitionSpec, s.Name)
+	}
+
+	def := NewDefinition(s.Name, s.Description)
+	if s.Timeout > 0 {
+		def.WithTimeout(time.Duration(s.Timeout))
+	}
+
+	seen := make(map[string]bool, len(s.Steps))
+	for _, spec := range s.Steps {
+		if spec.Name == "" {
+			return nil, fmt.Errorf("%w: definition %s has a step without a name", ErrInvalidDefinitionSpec, s.Name)
+		}
+		if seen[spec.Name] {
+			return nil, fmt.Errorf("%w: step %s of %s is declared twice", ErrInvalidDefinitionSpec, spec.Name, s.Name)
+		}
+		seen[spec.Name] = true
+		if spec.Timeout < 0 || spec.Retries < 0 {
+			return nil, fmt.Errorf("%w: step %s of %s has a negative timeout or retries", ErrInvalidDefinitionSpec, spec.Name, s.Name)
+		}
+
+		handlerName := spec.Handler
+		if handlerName == "" {
+			handlerName = spec.Name
+		}
+		handler, ok := registry.lookup(s.Name, handlerName)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s (step %s of %s)", ErrUnknownStepHandler, handlerName, spec.Name, s.Name)
+		}
+
+		topic := handler.Topic
+		if spec.Topic != "" {
+			topic = spec.Topic
+		}
+		def.AddStep(&Step{
+			Name:        spec.Name,
+			Description: spec.Description,
+			Execute:     handler.Execute,
+			Compensate:  handler.Compensate,
+			Topic:       topic,
+			Timeout:     time.Duration(spec.Timeout),
+			Retries:     spec.Retries,
+		})
+	}
+	return def, nil
+}
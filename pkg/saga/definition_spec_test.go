@@ -0,0 +1,111 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newSpecTestDefinition() *Definition {
+	def := NewDefinition("order-saga", "An order saga")
+	def.AddStep(&Step{
+		Name: "reserve",
+		Execute: func(ctx context.Context, data map[string]interface{}) (map[string]interface{}, error) {
+			return nil, nil
+		},
+		Topic:   "order.reserve.command",
+		Retries: 1,
+	})
+	def.AddStep(&Step{Name: "charge", Topic: "order.charge.command"})
+	return def
+}
+
+func TestParseDefinitionFile(t *testing.T) {
+	data := []byte(`{"definitions":[{"name":"order-saga","timeout":"2m","steps":[
+		{"name":"reserve","timeout":"10s","retries":4},
+		{"name":"charge","topic":"order.charge.v2.command"}]}]}`)
+
+	file, err := ParseDefinitionFile(data)
+	if err != nil {
+		t.Fatalf("ParseDefinitionFile() error = %v", err)
+	}
+
+	compiled := newSpecTestDefinition()
+	registry := NewHandlerRegistry()
+	registry.RegisterDefinition(compiled)
+	defs, err := file.Build(registry)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	def := defs[0]
+	if def.Timeout != 2*time.Minute || len(def.Steps) != 2 {
+		t.Fatalf("Build() = %+v", def)
+	}
+	reserve, charge := def.Steps[0], def.Steps[1]
+	if reserve.Timeout != 10*time.Second || reserve.Retries != 4 || reserve.Execute == nil || reserve.Topic != "order.reserve.command" {
+		t.Errorf("reserve step = %+v, want retuned compiled-in step", reserve)
+	}
+	if charge.Timeout != 30*time.Second || charge.Topic != "order.charge.v2.command" {
+		t.Errorf("charge step = %+v, want default timeout and overridden topic", charge)
+	}
+}
+
+func TestDefinitionSpec_BuildRejected(t *testing.T) {
+	registry := NewHandlerRegistry()
+	registry.Register("reserve", StepHandler{Topic: "order.reserve.command"})
+
+	tests := []struct {
+		name    string
+		spec    DefinitionSpec
+		wantErr error
+	}{
+		{"missing name", DefinitionSpec{Steps: []StepSpec{{Name: "reserve"}}}, ErrInvalidDefinitionSpec},
+		{"no steps", DefinitionSpec{Name: "new-saga"}, ErrInvalidDefinitionSpec},
+		{"duplicate step", DefinitionSpec{Name: "new-saga", Steps: []StepSpec{{Name: "reserve"}, {Name: "reserve"}}}, ErrInvalidDefinitionSpec},
+		{"negative retries", DefinitionSpec{Name: "new-saga", Steps: []StepSpec{{Name: "reserve", Retries: -1}}}, ErrInvalidDefinitionSpec},
+		{"unknown handler", DefinitionSpec{Name: "new-saga", Steps: []StepSpec{{Name: "ship"}}}, ErrUnknownStepHandler},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.spec.Build(registry); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Build() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := ParseDefinitionFile([]byte(`{"definitions":[{"name":"x","timeout":"soon"}]}`)); !errors.Is(err, ErrInvalidDefinitionSpec) {
+		t.Errorf("ParseDefinitionFile() error = %v, want %v", err, ErrInvalidDefinitionSpec)
+	}
+}
+
+func TestOrchestrator_ReloadDefinitions(t *testing.T) {
+	orchestrator := NewOrchestrator(&OrchestratorConfig{})
+	if err := orchestrator.RegisterDefinition(newSpecTestDefinition()); err != nil {
+		t.Fatalf("RegisterDefinition() error = %v", err)
+	}
+
+	retuned := newSpecTestDefinition()
+	retuned.Steps[0].Retries = 5
+	added := NewDefinition("refund-saga", "").AddStep(&Step{Name: "refund"})
+	if err := orchestrator.ReloadDefinitions([]*Definition{retuned, added}); err != nil {
+		t.Fatalf("ReloadDefinitions() error = %v", err)
+	}
+	if def, _ := orchestrator.GetDefinition("order-saga"); def.Steps[0].Retries != 5 {
+		t.Errorf("order-saga retries = %d, want 5", def.Steps[0].Retries)
+	}
+	if len(orchestrator.Definitions()) != 2 {
+		t.Errorf("Definitions() = %d, want 2", len(orchestrator.Definitions()))
+	}
+
+	// Reordered steps are rejected and nothing in the batch is applied
+	reordered := NewDefinition("order-saga", "").AddStep(&Step{Name: "charge"}).AddStep(&Step{Name: "reserve"})
+	other := NewDefinition("refund-saga", "").AddStep(&Step{Name: "refund", Retries: 9})
+	if err := orchestrator.ReloadDefinitions([]*Definition{other, reordered}); !errors.Is(err, ErrDefinitionStepsChanged) {
+		t.Fatalf("ReloadDefinitions() error = %v, want %v", err, ErrDefinitionStepsChanged)
+	}
+	if def, _ := orchestrator.GetDefinition("refund-saga"); def.Steps[0].Retries != 0 {
+		t.Error("ReloadDefinitions() applied part of a rejected batch")
+	}
+}
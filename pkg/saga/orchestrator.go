@@ -3,6 +3,7 @@ package saga
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 )
@@ -75,6 +76,49 @@ func (o *Orchestrator) RegisterDefinition(def *Definition) error {
 	return nil
 }
 
+// ReloadDefinitions registers new definitions and replaces registered ones with the same
+// name, all or nothing. A replacement may retune timeouts, retries and topics but must keep
+// the step names and order, since running instances resume by step index.
+func (o *Orchestrator) ReloadDefinitions(defs []*Definition) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for _, def := range defs {
+		current, exists := o.definitions[def.Name]
+		if !exists {
+			continue
+		}
+		if len(current.Steps) != len(def.Steps) {
+			return fmt.Errorf("%w: %s has %d steps, reload has %d", ErrDefinitionStepsChanged, def.Name, len(current.Steps), len(def.Steps))
+		}
+		for i, step := range def.Steps {
+			if current.Steps[i].Name != step.Name {
+				return fmt.Errorf("%w: %s step %d is %s, reload has %s", ErrDefinitionStepsChanged, def.Name, i, current.Steps[i].Name, step.Name)
+			}
+		}
+	}
+
+	for _, def := range defs {
+		_, replaced := o.definitions[def.Name]
+		o.definitions[def.Name] = def
+		o.logger.Info("Reloaded saga definition", "name", def.Name, "steps", len(def.Steps), "replaced", replaced)
+	}
+	return nil
+}
+
+// Definitions returns the registered saga definitions
+func (o *Orchestrator) Definitions() []*Definition {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	defs := make([]*Definition, 0, len(o.definitions))
+	for _, def := range o.definitions {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Name < defs[j].Name })
+	return defs
+}
+
 // GetDefinition retrieves a saga definition by name
 func (o *Orchestrator) GetDefinition(name string) (*Definition, error) {
 	o.mu.RLock()
@@ -210,6 +210,20 @@ func (s *PostgresStore) GetByDefinitionID(ctx context.Context, definitionID stri
 	return s.scanInstances(rows)
 }
 
+// GetByBookingID returns the most recently created saga run for a booking
+func (s *PostgresStore) GetByBookingID(ctx context.Context, bookingID string) (*Instance, error) {
+	query := `
+		SELECT id, definition_id, status, data, step_results,
+			   current_step, error, created_at, updated_at, completed_at
+		FROM saga_instances
+		WHERE data->>'booking_id' = $1
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	return s.scanInstance(ctx, s.pool.QueryRow(ctx, query, bookingID))
+}
+
 // SaveTransition records a state transition for audit trail
 func (s *PostgresStore) SaveTransition(ctx context.Context, sagaID string, fromStatus, toStatus Status, stepName, reason string) error {
 	query := `
@@ -233,6 +247,130 @@ func (s *PostgresStore) SaveTransition(ctx context.Context, sagaID string, fromS
 	return nil
 }
 
+// GetStats aggregates sagas created at or after since.
+// Step latencies are read from the step_results JSONB column; durations are stored in nanoseconds.
+func (s *PostgresStore) GetStats(ctx context.Context, since time.Time) (*Stats, error) {
+	stats := &Stats{
+		Since:       since,
+		Definitions: make([]DefinitionStats, 0),
+		Steps:       make([]StepStats, 0),
+	}
+
+	countQuery := `
+		SELECT definition_id, status, COUNT(*)
+		FROM saga_instances
+		WHERE created_at >= $1
+		GROUP BY definition_id, status
+	`
+
+	rows, err := s.pool.Query(ctx, countQuery, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count sagas by status: %w", err)
+	}
+
+	definitions := make(map[string]*DefinitionStats)
+	for rows.Next() {
+		var definitionID, status string
+		var count int64
+		if err := rows.Scan(&definitionID, &status, &count); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan saga status count: %w", err)
+		}
+
+		def, ok := definitions[definitionID]
+		if !ok {
+			def = &DefinitionStats{
+				DefinitionID: definitionID,
+				StatusCounts: make(map[Status]int64),
+			}
+			definitions[definitionID] = def
+		}
+		def.StatusCounts[Status(status)] = count
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to count sagas by status: %w", err)
+	}
+
+	durationQuery := `
+		SELECT definition_id,
+			   AVG(EXTRACT(EPOCH FROM (completed_at - created_at)))::float8,
+			   (percentile_disc(0.95) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM (completed_at - created_at))))::float8
+		FROM saga_instances
+		WHERE created_at >= $1 AND completed_at IS NOT NULL
+		GROUP BY definition_id
+	`
+
+	rows, err = s.pool.Query(ctx, durationQuery, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get saga durations: %w", err)
+	}
+
+	for rows.Next() {
+		var definitionID string
+		var avgSeconds, p95Seconds float64
+		if err := rows.Scan(&definitionID, &avgSeconds, &p95Seconds); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan saga durations: %w", err)
+		}
+
+		if def, ok := definitions[definitionID]; ok {
+			def.AvgDuration = time.Duration(avgSeconds * float64(time.Second))
+			def.P95Duration = time.Duration(p95Seconds * float64(time.Second))
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get saga durations: %w", err)
+	}
+
+	for _, def := range definitions {
+		stats.Definitions = append(stats.Definitions, *def)
+	}
+
+	stepQuery := `
+		SELECT i.definition_id,
+			   COALESCE(r->>'step_name', ''),
+			   COALESCE(r->>'status', ''),
+			   COUNT(*),
+			   AVG(COALESCE((r->>'duration')::bigint, 0))::float8,
+			   percentile_disc(0.95) WITHIN GROUP (ORDER BY COALESCE((r->>'duration')::bigint, 0)),
+			   MAX(COALESCE((r->>'duration')::bigint, 0))
+		FROM saga_instances i,
+			 jsonb_array_elements(CASE WHEN jsonb_typeof(i.step_results) = 'array' THEN i.step_results ELSE '[]'::jsonb END) r
+		WHERE i.created_at >= $1
+		GROUP BY 1, 2, 3
+	`
+
+	rows, err = s.pool.Query(ctx, stepQuery, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get saga step stats: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var step StepStats
+		var status string
+		var avgNanos float64
+		var p95Nanos, maxNanos int64
+		if err := rows.Scan(&step.DefinitionID, &step.StepName, &status, &step.Count, &avgNanos, &p95Nanos, &maxNanos); err != nil {
+			return nil, fmt.Errorf("failed to scan saga step stats: %w", err)
+		}
+
+		step.Status = StepStatus(status)
+		step.AvgDuration = time.Duration(avgNanos)
+		step.P95Duration = time.Duration(p95Nanos)
+		step.MaxDuration = time.Duration(maxNanos)
+		stats.Steps = append(stats.Steps, step)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get saga step stats: %w", err)
+	}
+
+	sortStats(stats)
+	return stats, nil
+}
+
 // scanInstance scans a single row into an Instance
 func (s *PostgresStore) scanInstance(ctx context.Context, row pgx.Row) (*Instance, error) {
 	var instance Instance
@@ -354,6 +492,7 @@ type DeadLetter struct {
 	CreatedAt    time.Time              `json:"created_at"`
 	ProcessedAt  *time.Time             `json:"processed_at,omitempty"`
 	Processed    bool                   `json:"processed"`
+	RetriedBy    string                 `json:"retried_by,omitempty"` // Operator who re-emitted the message
 }
 
 // SaveDeadLetter saves a message to the dead letter queue
@@ -458,3 +597,70 @@ func (s *PostgresStore) MarkDeadLetterProcessed(ctx context.Context, id string)
 
 	return nil
 }
+
+// GetPendingDeadLetter returns the newest unprocessed dead letter of a saga step.
+// Dead-lettered commands carry their step in the step_name field of the message.
+func (s *PostgresStore) GetPendingDeadLetter(ctx context.Context, sagaID, stepName string) (*DeadLetter, error) {
+	query := `
+		SELECT id, saga_id, topic, message_key, message_value, error_message, retry_count, created_at
+		FROM saga_dead_letters
+		WHERE saga_id = $1 AND message_value->>'step_name' = $2 AND processed = FALSE
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	var dl DeadLetter
+	var dlSagaID, messageKey *string
+	var messageJSON []byte
+
+	err := s.pool.QueryRow(ctx, query, sagaID, stepName).Scan(
+		&dl.ID,
+		&dlSagaID,
+		&dl.Topic,
+		&messageKey,
+		&messageJSON,
+		&dl.ErrorMessage,
+		&dl.RetryCount,
+		&dl.CreatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, ErrDeadLetterNotFound
+		}
+		return nil, fmt.Errorf("failed to get dead letter: %w", err)
+	}
+
+	if dlSagaID != nil {
+		dl.SagaID = *dlSagaID
+	}
+	if messageKey != nil {
+		dl.MessageKey = *messageKey
+	}
+	if len(messageJSON) > 0 {
+		if err := json.Unmarshal(messageJSON, &dl.MessageValue); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal message value: %w", err)
+		}
+	}
+
+	return &dl, nil
+}
+
+// MarkDeadLetterRetried marks a dead letter as processed by a manual retry.
+// Returns ErrDeadLetterNotFound if it was already processed, so a retry is only sent once.
+func (s *PostgresStore) MarkDeadLetterRetried(ctx context.Context, id, retriedBy string) error {
+	query := `
+		UPDATE saga_dead_letters
+		SET processed = TRUE, processed_at = NOW(), retried_by = $2
+		WHERE id = $1 AND processed = FALSE
+	`
+
+	result, err := s.pool.Exec(ctx, query, id, retriedBy)
+	if err != nil {
+		return fmt.Errorf("failed to mark dead letter as retried: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrDeadLetterNotFound
+	}
+
+	return nil
+}
@@ -47,6 +47,7 @@ type Step struct {
 	Description string         `json:"description"`
 	Execute     ExecuteFunc    `json:"-"`
 	Compensate  CompensateFunc `json:"-"`
+	Topic       string         `json:"topic,omitempty"` // Command topic for event-driven execution
 	Timeout     time.Duration  `json:"timeout"`
 	Retries     int            `json:"retries"`
 }
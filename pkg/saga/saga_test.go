@@ -230,6 +230,33 @@ func TestMemoryStoreGetByStatus(t *testing.T) {
 	}
 }
 
+func TestMemoryStoreGetByBookingID(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+
+	// A booking saga, then the post-payment saga of the same booking
+	booking := NewInstance("booking-saga", map[string]interface{}{"booking_id": "booking-1"})
+	booking.CreatedAt = time.Now().Add(-time.Minute)
+	store.Save(ctx, booking)
+
+	postPayment := NewInstance("post-payment-saga", map[string]interface{}{"booking_id": "booking-1"})
+	store.Save(ctx, postPayment)
+
+	store.Save(ctx, NewInstance("booking-saga", map[string]interface{}{"booking_id": "booking-2"}))
+
+	found, err := store.GetByBookingID(ctx, "booking-1")
+	if err != nil {
+		t.Fatalf("failed to get by booking ID: %v", err)
+	}
+	if found.ID != postPayment.ID {
+		t.Errorf("expected the latest saga %s, got %s", postPayment.ID, found.ID)
+	}
+
+	if _, err := store.GetByBookingID(ctx, "booking-3"); err != ErrSagaNotFound {
+		t.Errorf("expected ErrSagaNotFound, got %v", err)
+	}
+}
+
 func TestOrchestratorRegisterDefinition(t *testing.T) {
 	orch := NewOrchestrator(&OrchestratorConfig{})
 
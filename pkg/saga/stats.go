@@ -0,0 +1,157 @@
+package saga
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// StatsStore is implemented by stores that can aggregate saga outcomes over a time window
+type StatsStore interface {
+	// GetStats aggregates sagas created at or after since
+	GetStats(ctx context.Context, since time.Time) (*Stats, error)
+}
+
+// Stats aggregates saga outcomes and step latencies for sagas created since a point in time
+type Stats struct {
+	Since       time.Time         `json:"since"`
+	Definitions []DefinitionStats `json:"definitions"`
+	Steps       []StepStats       `json:"steps"`
+}
+
+// DefinitionStats aggregates saga instances of a single definition
+type DefinitionStats struct {
+	DefinitionID string           `json:"definition_id"`
+	StatusCounts map[Status]int64 `json:"status_counts"`
+	// AvgDuration and P95Duration cover finished sagas only (completed, failed or compensated)
+	AvgDuration time.Duration `json:"avg_duration"`
+	P95Duration time.Duration `json:"p95_duration"`
+}
+
+// Total returns the number of sagas across all statuses
+func (d *DefinitionStats) Total() int64 {
+	var total int64
+	for _, count := range d.StatusCounts {
+		total += count
+	}
+	return total
+}
+
+// StepStats aggregates recorded step results of a single step and outcome
+type StepStats struct {
+	DefinitionID string        `json:"definition_id"`
+	StepName     string        `json:"step_name"`
+	Status       StepStatus    `json:"status"`
+	Count        int64         `json:"count"`
+	AvgDuration  time.Duration `json:"avg_duration"`
+	P95Duration  time.Duration `json:"p95_duration"`
+	MaxDuration  time.Duration `json:"max_duration"`
+}
+
+// GetStats aggregates sagas created at or after since
+func (s *MemoryStore) GetStats(ctx context.Context, since time.Time) (*Stats, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	type stepKey struct {
+		definitionID string
+		stepName     string
+		status       StepStatus
+	}
+
+	definitions := make(map[string]*DefinitionStats)
+	sagaDurations := make(map[string][]time.Duration)
+	stepDurations := make(map[stepKey][]time.Duration)
+
+	for _, instance := range s.instances {
+		if instance.CreatedAt.Before(since) {
+			continue
+		}
+
+		def, ok := definitions[instance.DefinitionID]
+		if !ok {
+			def = &DefinitionStats{
+				DefinitionID: instance.DefinitionID,
+				StatusCounts: make(map[Status]int64),
+			}
+			definitions[instance.DefinitionID] = def
+		}
+		def.StatusCounts[instance.Status]++
+
+		if instance.CompletedAt != nil {
+			sagaDurations[instance.DefinitionID] = append(sagaDurations[instance.DefinitionID], instance.CompletedAt.Sub(instance.CreatedAt))
+		}
+
+		for _, result := range instance.StepResults {
+			key := stepKey{instance.DefinitionID, result.StepName, result.Status}
+			stepDurations[key] = append(stepDurations[key], result.Duration)
+		}
+	}
+
+	stats := &Stats{
+		Since:       since,
+		Definitions: make([]DefinitionStats, 0, len(definitions)),
+		Steps:       make([]StepStats, 0, len(stepDurations)),
+	}
+
+	for id, def := range definitions {
+		def.AvgDuration, def.P95Duration, _ = summarizeDurations(sagaDurations[id])
+		stats.Definitions = append(stats.Definitions, *def)
+	}
+
+	for key, durations := range stepDurations {
+		step := StepStats{
+			DefinitionID: key.definitionID,
+			StepName:     key.stepName,
+			Status:       key.status,
+			Count:        int64(len(durations)),
+		}
+		step.AvgDuration, step.P95Duration, step.MaxDuration = summarizeDurations(durations)
+		stats.Steps = append(stats.Steps, step)
+	}
+
+	sortStats(stats)
+	return stats, nil
+}
+
+// summarizeDurations returns the mean, 95th percentile and maximum of the given durations
+func summarizeDurations(durations []time.Duration) (avg, p95, max time.Duration) {
+	if len(durations) == 0 {
+		return 0, 0, 0
+	}
+
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+
+	// Nearest-rank percentile
+	rank := (95*len(sorted) + 99) / 100
+	return total / time.Duration(len(sorted)), sorted[rank-1], sorted[len(sorted)-1]
+}
+
+// sortStats orders definitions and steps so results are stable across stores
+func sortStats(stats *Stats) {
+	sort.Slice(stats.Definitions, func(i, j int) bool {
+		return stats.Definitions[i].DefinitionID < stats.Definitions[j].DefinitionID
+	})
+	sort.Slice(stats.Steps, func(i, j int) bool {
+		a, b := stats.Steps[i], stats.Steps[j]
+		if a.DefinitionID != b.DefinitionID {
+			return a.DefinitionID < b.DefinitionID
+		}
+		if a.StepName != b.StepName {
+			return a.StepName < b.StepName
+		}
+		return a.Status < b.Status
+	})
+}
+
+var (
+	_ StatsStore = (*MemoryStore)(nil)
+	_ StatsStore = (*PostgresStore)(nil)
+)
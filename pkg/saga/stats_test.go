@@ -0,0 +1,111 @@
+package saga
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreGetStats(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	now := time.Now()
+
+	completed := NewInstance("booking-saga", nil)
+	completed.Complete()
+	finishedAt := completed.CreatedAt.Add(2 * time.Second)
+	completed.CompletedAt = &finishedAt
+	completed.AddStepResult(&StepResult{StepName: "reserve-seats", Status: StepStatusCompleted, Duration: 100 * time.Millisecond})
+	completed.AddStepResult(&StepResult{StepName: "process-payment", Status: StepStatusCompleted, Duration: 300 * time.Millisecond})
+	store.Save(ctx, completed)
+
+	compensated := NewInstance("booking-saga", nil)
+	compensated.Status = StatusCompensated
+	compensatedAt := compensated.CreatedAt.Add(4 * time.Second)
+	compensated.CompletedAt = &compensatedAt
+	compensated.AddStepResult(&StepResult{StepName: "reserve-seats", Status: StepStatusCompleted, Duration: 200 * time.Millisecond})
+	compensated.AddStepResult(&StepResult{StepName: "process-payment", Status: StepStatusFailed, Duration: time.Second})
+	store.Save(ctx, compensated)
+
+	running := NewInstance("post-payment-saga", nil)
+	running.Status = StatusRunning
+	store.Save(ctx, running)
+
+	// Outside the window
+	old := NewInstance("booking-saga", nil)
+	old.CreatedAt = now.Add(-2 * time.Hour)
+	old.Status = StatusCompensated
+	store.Save(ctx, old)
+
+	stats, err := store.GetStats(ctx, now.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("failed to get stats: %v", err)
+	}
+
+	if len(stats.Definitions) != 2 {
+		t.Fatalf("expected 2 definitions, got %d", len(stats.Definitions))
+	}
+
+	booking := stats.Definitions[0]
+	if booking.DefinitionID != "booking-saga" {
+		t.Fatalf("expected booking-saga first, got '%s'", booking.DefinitionID)
+	}
+	if booking.Total() != 2 {
+		t.Errorf("expected 2 booking sagas in window, got %d", booking.Total())
+	}
+	if booking.StatusCounts[StatusCompleted] != 1 || booking.StatusCounts[StatusCompensated] != 1 {
+		t.Errorf("unexpected status counts: %v", booking.StatusCounts)
+	}
+	if booking.AvgDuration != 3*time.Second {
+		t.Errorf("expected avg duration 3s, got %v", booking.AvgDuration)
+	}
+	if booking.P95Duration != 4*time.Second {
+		t.Errorf("expected p95 duration 4s, got %v", booking.P95Duration)
+	}
+
+	if stats.Definitions[1].StatusCounts[StatusRunning] != 1 {
+		t.Errorf("expected 1 running post-payment saga, got %v", stats.Definitions[1].StatusCounts)
+	}
+
+	if len(stats.Steps) != 3 {
+		t.Fatalf("expected 3 step groups, got %d", len(stats.Steps))
+	}
+
+	// Sorted by definition, step name, then status
+	reserve := stats.Steps[2]
+	if reserve.StepName != "reserve-seats" || reserve.Status != StepStatusCompleted {
+		t.Fatalf("unexpected step order: %+v", stats.Steps)
+	}
+	if reserve.Count != 2 {
+		t.Errorf("expected 2 reserve-seats results, got %d", reserve.Count)
+	}
+	if reserve.AvgDuration != 150*time.Millisecond {
+		t.Errorf("expected avg 150ms, got %v", reserve.AvgDuration)
+	}
+	if reserve.MaxDuration != 200*time.Millisecond {
+		t.Errorf("expected max 200ms, got %v", reserve.MaxDuration)
+	}
+}
+
+func TestSummarizeDurations(t *testing.T) {
+	avg, p95, max := summarizeDurations(nil)
+	if avg != 0 || p95 != 0 || max != 0 {
+		t.Errorf("expected zero values for no durations, got %v %v %v", avg, p95, max)
+	}
+
+	durations := make([]time.Duration, 0, 20)
+	for i := 20; i >= 1; i-- {
+		durations = append(durations, time.Duration(i)*time.Millisecond)
+	}
+
+	avg, p95, max = summarizeDurations(durations)
+	if avg != 10500*time.Microsecond {
+		t.Errorf("expected avg 10.5ms, got %v", avg)
+	}
+	if p95 != 19*time.Millisecond {
+		t.Errorf("expected p95 19ms, got %v", p95)
+	}
+	if max != 20*time.Millisecond {
+		t.Errorf("expected max 20ms, got %v", max)
+	}
+}
@@ -16,6 +16,8 @@ var (
 	ErrSagaNotFound = errors.New("saga instance not found")
 	// ErrSagaAlreadyExists is returned when trying to create a duplicate saga
 	ErrSagaAlreadyExists = errors.New("saga instance already exists")
+	// ErrDeadLetterNotFound is returned when a step has no unprocessed dead letter
+	ErrDeadLetterNotFound = errors.New("dead letter not found")
 )
 
 // Store is the interface for persisting saga state
@@ -34,6 +36,29 @@ type Store interface {
 	GetPendingCompensations(ctx context.Context, limit int) ([]*Instance, error)
 }
 
+// BookingLookupStore is implemented by stores that can find the sagas run for a booking
+type BookingLookupStore interface {
+	// GetByBookingID returns the most recently created saga whose data carries the
+	// booking_id, or ErrSagaNotFound
+	GetByBookingID(ctx context.Context, bookingID string) (*Instance, error)
+}
+
+// DeadLetterStore is implemented by stores that keep dead-lettered saga messages
+type DeadLetterStore interface {
+	// GetPendingDeadLetter returns the newest unprocessed dead letter of a saga step,
+	// or ErrDeadLetterNotFound
+	GetPendingDeadLetter(ctx context.Context, sagaID, stepName string) (*DeadLetter, error)
+	// MarkDeadLetterRetried marks a dead letter processed and records who retried it.
+	// Returns ErrDeadLetterNotFound if it is missing or already processed.
+	MarkDeadLetterRetried(ctx context.Context, id, retriedBy string) error
+}
+
+var (
+	_ BookingLookupStore = (*MemoryStore)(nil)
+	_ BookingLookupStore = (*PostgresStore)(nil)
+	_ DeadLetterStore    = (*PostgresStore)(nil)
+)
+
 // MemoryStore is an in-memory implementation of Store for testing
 type MemoryStore struct {
 	mu        sync.RWMutex
@@ -156,6 +181,27 @@ func (s *MemoryStore) GetPendingCompensations(ctx context.Context, limit int) ([
 	return result, nil
 }
 
+// GetByBookingID returns the most recently created saga run for a booking
+func (s *MemoryStore) GetByBookingID(ctx context.Context, bookingID string) (*Instance, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var latest *Instance
+	for _, instance := range s.instances {
+		if id, _ := instance.Data["booking_id"].(string); id != bookingID {
+			continue
+		}
+		if latest == nil || instance.CreatedAt.After(latest.CreatedAt) {
+			latest = instance
+		}
+	}
+	if latest == nil {
+		return nil, ErrSagaNotFound
+	}
+
+	return s.deepCopy(latest)
+}
+
 // deepCopy creates a deep copy of a saga instance using JSON serialization
 func (s *MemoryStore) deepCopy(instance *Instance) (*Instance, error) {
 	data, err := json.Marshal(instance)
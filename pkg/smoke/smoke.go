@@ -0,0 +1,179 @@
+// Package smoke runs the end-to-end self-check behind a service's -smoke flag. A
+// deploy runs the binary with -smoke against the target environment and gates the
+// rollout on its exit status: migrations applied, Redis scripts loadable, Kafka
+// topics reachable and one round trip through every repository.
+package smoke
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/database"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/kafka"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+)
+
+// ProbeID is looked up by repository round trips. No record has it, so a working
+// repository answers with its not found error.
+const ProbeID = "00000000-0000-0000-0000-000000000000"
+
+// DefaultTimeout bounds each check when the suite sets none
+const DefaultTimeout = 10 * time.Second
+
+// Check is one self-check of a dependency
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// Suite is the list of checks of a service binary
+type Suite struct {
+	service string
+	timeout time.Duration
+	checks  []Check
+}
+
+// NewSuite creates an empty suite for a service. timeout bounds each check;
+// 0 uses DefaultTimeout.
+func NewSuite(service string, timeout time.Duration) *Suite {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Suite{service: service, timeout: timeout}
+}
+
+// Add appends a check
+func (s *Suite) Add(name string, run func(ctx context.Context) error) {
+	s.checks = append(s.checks, Check{Name: name, Run: run})
+}
+
+// Len returns the number of checks
+func (s *Suite) Len() int {
+	return len(s.checks)
+}
+
+// Run runs every check in order, writing one PASS or FAIL line per check to w.
+// A failed check does not stop the others, so one run reports every broken
+// dependency. Returns an error naming the failed checks.
+func (s *Suite) Run(ctx context.Context, w io.Writer) error {
+	var failed []string
+	for _, check := range s.checks {
+		checkCtx, cancel := context.WithTimeout(ctx, s.timeout)
+		start := time.Now()
+		err := check.Run(checkCtx)
+		elapsed := time.Since(start).Round(time.Millisecond)
+		cancel()
+
+		if err != nil {
+			failed = append(failed, check.Name)
+			fmt.Fprintf(w, "FAIL %s (%s): %v\n", check.Name, elapsed, err)
+			continue
+		}
+		fmt.Fprintf(w, "PASS %s (%s)\n", check.Name, elapsed)
+	}
+
+	if len(failed) > 0 {
+		fmt.Fprintf(w, "%s smoke test failed: %d of %d checks\n", s.service, len(failed), len(s.checks))
+		return fmt.Errorf("smoke checks failed: %s", strings.Join(failed, ", "))
+	}
+	fmt.Fprintf(w, "%s smoke test passed: %d checks\n", s.service, len(s.checks))
+	return nil
+}
+
+// Expect returns nil if err is nil or one of the expected errors, e.g. the not
+// found error of a round trip looking up ProbeID
+func Expect(err error, expected ...error) error {
+	if err == nil {
+		return nil
+	}
+	for _, target := range expected {
+		if errors.Is(err, target) {
+			return nil
+		}
+	}
+	return err
+}
+
+// Migrations checks that golang-migrate applied the schema up to at least minVersion
+// and did not leave it dirty after a failed migration
+func Migrations(db *database.PostgresDB, minVersion int64) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		var version int64
+		var dirty bool
+		err := db.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("no migrations applied, want version %d", minVersion)
+			}
+			return fmt.Errorf("failed to read schema_migrations: %w", err)
+		}
+		if dirty {
+			return fmt.Errorf("migration %d failed and left the schema dirty", version)
+		}
+		if version < minVersion {
+			return fmt.Errorf("schema at version %d, want at least %d", version, minVersion)
+		}
+		return nil
+	}
+}
+
+// Postgres checks that the database answers
+func Postgres(db *database.PostgresDB) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		return db.Ping(ctx)
+	}
+}
+
+// Redis checks that Redis answers
+func Redis(client *redis.Client) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		return client.Ping(ctx)
+	}
+}
+
+// KafkaTopics checks that the brokers are reachable and every topic exists. Topics
+// are never created, whatever cfg.AutoCreate says.
+func KafkaTopics(cfg kafka.TopicManagerConfig, specs []kafka.TopicSpec) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		cfg.AutoCreate = false
+		manager, err := kafka.NewTopicManager(ctx, &cfg)
+		if err != nil {
+			return err
+		}
+		defer manager.Close()
+
+		report, err := manager.Ensure(ctx, specs)
+		if err != nil {
+			return err
+		}
+		return report.Err()
+	}
+}
+
+// HTTP checks that a GET of url answers with a 2xx status, e.g. the /health of a
+// service the binary depends on
+func HTTP(url string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		_, _ = io.Copy(io.Discard, resp.Body)
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return fmt.Errorf("GET %s returned %d", url, resp.StatusCode)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,93 @@
+package smoke
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+var errNotFound = errors.New("not found")
+
+func TestSuite_Run(t *testing.T) {
+	suite := NewSuite("test-service", 50*time.Millisecond)
+	suite.Add("postgres", func(ctx context.Context) error { return nil })
+	suite.Add("redis scripts", func(ctx context.Context) error { return errors.New("NOSCRIPT") })
+	suite.Add("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	suite.Add("repository", func(ctx context.Context) error { return Expect(errNotFound, errNotFound) })
+
+	var out bytes.Buffer
+	err := suite.Run(context.Background(), &out)
+	if err == nil || err.Error() != "smoke checks failed: redis scripts, slow" {
+		t.Fatalf("Run() error = %v, want the failed checks named", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("expected a line per check and a summary, got:\n%s", out.String())
+	}
+	for i, prefix := range []string{"PASS postgres", "FAIL redis scripts", "FAIL slow", "PASS repository", "test-service smoke test failed: 2 of 4"} {
+		if !strings.HasPrefix(lines[i], prefix) {
+			t.Errorf("line %d = %q, want prefix %q", i, lines[i], prefix)
+		}
+	}
+}
+
+func TestSuite_RunPasses(t *testing.T) {
+	suite := NewSuite("test-service", 0)
+	suite.Add("postgres", func(ctx context.Context) error { return nil })
+
+	var out bytes.Buffer
+	if err := suite.Run(context.Background(), &out); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "test-service smoke test passed: 1 checks") {
+		t.Errorf("unexpected output:\n%s", out.String())
+	}
+}
+
+func TestExpect(t *testing.T) {
+	other := errors.New("connection refused")
+	tests := []struct {
+		name    string
+		err     error
+		wantErr bool
+	}{
+		{"nil", nil, false},
+		{"expected", errNotFound, false},
+		{"wrapped expected", fmt.Errorf("get booking: %w", errNotFound), false},
+		{"unexpected", other, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Expect(tt.err, errNotFound); (err != nil) != tt.wantErr {
+				t.Errorf("Expect() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHTTP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	if err := HTTP(server.URL + "/health")(context.Background()); err != nil {
+		t.Errorf("HTTP() error = %v, want nil", err)
+	}
+	if err := HTTP(server.URL + "/ready")(context.Background()); err == nil {
+		t.Error("expected an error for a 503")
+	}
+}
@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"fmt"
+)
+
+// Storage backends
+const (
+	BackendNone  = ""
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+// Config selects and configures the store of a service
+type Config struct {
+	// Backend is BackendLocal, BackendS3 (S3 or MinIO) or BackendNone to disable storage
+	Backend string
+
+	// LocalDir is the directory of the local backend
+	LocalDir string
+
+	S3 S3Config
+
+	// PublicURL is where the service's Handler is reachable, e.g.
+	// https://api.example.com/api/v1/files/payment. Local and encrypted objects are
+	// downloaded from there, signed with SigningKey.
+	PublicURL  string
+	SigningKey string
+
+	// EncryptionKeys are the master keys objects are encrypted with (see ParseKeyProvider);
+	// empty stores objects as they are
+	EncryptionKeys string
+}
+
+// Open creates the store of cfg. It returns nil when storage is disabled.
+func Open(cfg *Config) (Store, error) {
+	if cfg.Backend == BackendNone {
+		return nil, nil
+	}
+
+	var signer *URLSigner
+	if cfg.ServesDownloads() {
+		var err error
+		if signer, err = NewURLSigner(cfg.PublicURL, cfg.SigningKey); err != nil {
+			return nil, err
+		}
+	}
+
+	var store Store
+	switch cfg.Backend {
+	case BackendLocal:
+		local, err := NewLocalStore(cfg.LocalDir, signer)
+		if err != nil {
+			return nil, err
+		}
+		store = local
+	case BackendS3:
+		s3, err := NewS3Store(&cfg.S3)
+		if err != nil {
+			return nil, err
+		}
+		store = s3
+	default:
+		return nil, fmt.Errorf("storage: unknown backend %q", cfg.Backend)
+	}
+
+	if cfg.EncryptionKeys != "" {
+		keys, err := ParseKeyProvider(cfg.EncryptionKeys)
+		if err != nil {
+			return nil, err
+		}
+		store = NewEncryptedStore(store, keys, signer)
+	}
+	return store, nil
+}
+
+// ServesDownloads reports whether downloads go through the service's Handler. S3
+// presigns URLs to unencrypted objects itself.
+func (c *Config) ServesDownloads() bool {
+	return c.Backend == BackendLocal || (c.Backend != BackendNone && c.EncryptionKeys != "")
+}
+
+// NewServingHandler returns the Handler serving the downloads of a store opened with
+// cfg, or nil when the store does not need one
+func NewServingHandler(store Store, cfg *Config) (*Handler, error) {
+	if !cfg.ServesDownloads() {
+		return nil, nil
+	}
+	signer, err := NewURLSigner(cfg.PublicURL, cfg.SigningKey)
+	if err != nil {
+		return nil, err
+	}
+	return NewHandler(store, signer), nil
+}
@@ -0,0 +1,240 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Metadata names of the data key an encrypted object was sealed with
+const (
+	metaKeyID   = "encryption-key-id"
+	metaDataKey = "encryption-data-key"
+)
+
+// ErrUnknownKey is returned when an object was encrypted with a master key the provider does not have
+var ErrUnknownKey = errors.New("unknown encryption key")
+
+// DataKey is a per-object key, in plaintext for sealing and wrapped by a master key for storing
+type DataKey struct {
+	KeyID     string
+	Plaintext []byte
+	Wrapped   []byte
+}
+
+// KeyProvider issues and unwraps data keys like a KMS: master keys never leave it.
+// Implementations may call out to a cloud KMS.
+type KeyProvider interface {
+	// GenerateDataKey returns a new 256-bit data key wrapped by the current master key
+	GenerateDataKey(ctx context.Context) (*DataKey, error)
+
+	// DecryptDataKey unwraps a data key wrapped by master key keyID
+	DecryptDataKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+// LocalKeyProvider wraps data keys with AES-256-GCM master keys held in memory
+type LocalKeyProvider struct {
+	current string
+	keys    map[string][]byte
+}
+
+// ParseKeyProvider parses a comma-separated list of id=base64 32-byte master keys,
+// e.g. "2024-06=...,2024-01=...". The first key wraps new data keys; the others
+// still unwrap objects written before a rotation.
+func ParseKeyProvider(spec string) (*LocalKeyProvider, error) {
+	provider := &LocalKeyProvider{keys: make(map[string][]byte)}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, encoded, ok := strings.Cut(entry, "=")
+		if !ok || id == "" {
+			// The entry is not echoed, it may be a bare key
+			return nil, errors.New("storage: encryption keys must be id=base64key entries")
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != 32 {
+			return nil, fmt.Errorf("storage: encryption key %s must be 32 bytes of base64", id)
+		}
+		if _, exists := provider.keys[id]; exists {
+			return nil, fmt.Errorf("storage: duplicate encryption key %s", id)
+		}
+		provider.keys[id] = key
+		if provider.current == "" {
+			provider.current = id
+		}
+	}
+	if provider.current == "" {
+		return nil, errors.New("storage: no encryption keys")
+	}
+	return provider, nil
+}
+
+// GenerateDataKey returns a new data key wrapped by the current master key
+func (p *LocalKeyProvider) GenerateDataKey(ctx context.Context) (*DataKey, error) {
+	plaintext := make([]byte, 32)
+	if _, err := rand.Read(plaintext); err != nil {
+		return nil, err
+	}
+	wrapped, err := seal(p.keys[p.current], plaintext)
+	if err != nil {
+		return nil, err
+	}
+	return &DataKey{KeyID: p.current, Plaintext: plaintext, Wrapped: wrapped}, nil
+}
+
+// DecryptDataKey unwraps a data key wrapped by master key keyID
+func (p *LocalKeyProvider) DecryptDataKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	key, ok := p.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, keyID)
+	}
+	return open(key, wrapped)
+}
+
+// seal encrypts plaintext with AES-GCM, prefixing the random nonce
+func seal(key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// open decrypts a message sealed by seal
+func open(key, sealed []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("storage: encrypted data too short")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// EncryptedStore encrypts objects before they reach another store, each with its own
+// data key (envelope encryption). The store only ever sees ciphertext, so downloads
+// are decrypted by a Handler serving this store instead of going to it directly.
+type EncryptedStore struct {
+	store  Store
+	keys   KeyProvider
+	signer *URLSigner
+}
+
+// NewEncryptedStore creates an EncryptedStore over store
+func NewEncryptedStore(store Store, keys KeyProvider, signer *URLSigner) *EncryptedStore {
+	return &EncryptedStore{store: store, keys: keys, signer: signer}
+}
+
+// Put encrypts body and stores it under key
+func (s *EncryptedStore) Put(ctx context.Context, key string, body io.Reader, opts PutOptions) (*Object, error) {
+	plaintext, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	dataKey, err := s.keys.GenerateDataKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+	ciphertext, err := seal(dataKey.Plaintext, plaintext)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := make(map[string]string, len(opts.Metadata)+2)
+	for name, value := range opts.Metadata {
+		metadata[name] = value
+	}
+	metadata[metaKeyID] = dataKey.KeyID
+	metadata[metaDataKey] = base64.StdEncoding.EncodeToString(dataKey.Wrapped)
+	opts.Metadata = metadata
+
+	object, err := s.store.Put(ctx, key, bytes.NewReader(ciphertext), opts)
+	if err != nil {
+		return nil, err
+	}
+	return s.plainObject(object, int64(len(plaintext))), nil
+}
+
+// Get opens and decrypts the object stored under key. Objects stored before
+// encryption was enabled are returned as they are.
+func (s *EncryptedStore) Get(ctx context.Context, key string) (io.ReadCloser, *Object, error) {
+	body, object, err := s.store.Get(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyID, wrapped := object.Metadata[metaKeyID], object.Metadata[metaDataKey]
+	if keyID == "" || wrapped == "" {
+		return body, object, nil
+	}
+	defer body.Close()
+
+	wrappedKey, err := base64.StdEncoding.DecodeString(wrapped)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid data key of %s: %w", key, err)
+	}
+	dataKey, err := s.keys.DecryptDataKey(ctx, keyID, wrappedKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decrypt data key of %s: %w", key, err)
+	}
+	ciphertext, err := io.ReadAll(body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	plaintext, err := open(dataKey, ciphertext)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decrypt %s: %w", key, err)
+	}
+	return io.NopCloser(bytes.NewReader(plaintext)), s.plainObject(object, int64(len(plaintext))), nil
+}
+
+// plainObject describes object as its plaintext, without the data key metadata
+func (s *EncryptedStore) plainObject(object *Object, size int64) *Object {
+	plain := *object
+	plain.Size = size
+	plain.Metadata = make(map[string]string, len(object.Metadata))
+	for name, value := range object.Metadata {
+		if name != metaKeyID && name != metaDataKey {
+			plain.Metadata[name] = value
+		}
+	}
+	return &plain
+}
+
+// Delete removes the object stored under key
+func (s *EncryptedStore) Delete(ctx context.Context, key string) error {
+	return s.store.Delete(ctx, key)
+}
+
+// SignedURL returns a URL of the Handler decrypting this store
+func (s *EncryptedStore) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	return s.signer.Sign(key, ttl)
+}
+
+// DeleteBefore removes the objects under prefix last modified before before
+func (s *EncryptedStore) DeleteBefore(ctx context.Context, prefix string, before time.Time) (int, error) {
+	return s.store.DeleteBefore(ctx, prefix, before)
+}
@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, 32))
+}
+
+func TestParseKeyProvider(t *testing.T) {
+	provider, err := ParseKeyProvider("2024-06=" + testKey(1) + ", 2024-01=" + testKey(2))
+	if err != nil {
+		t.Fatalf("ParseKeyProvider() error = %v", err)
+	}
+	if provider.current != "2024-06" || len(provider.keys) != 2 {
+		t.Errorf("ParseKeyProvider() current = %s, keys = %d", provider.current, len(provider.keys))
+	}
+
+	for _, spec := range []string{"", testKey(1), "k=" + base64.StdEncoding.EncodeToString([]byte("short")), "k=" + testKey(1) + ",k=" + testKey(2)} {
+		_, err := ParseKeyProvider(spec)
+		if err == nil {
+			t.Errorf("ParseKeyProvider(%q) error = nil", spec)
+		} else if strings.Contains(err.Error(), testKey(1)) {
+			t.Errorf("ParseKeyProvider() error %q leaks the key", err)
+		}
+	}
+}
+
+func TestEncryptedStore(t *testing.T) {
+	ctx := context.Background()
+	local, signer := newTestLocalStore(t)
+	oldKeys, _ := ParseKeyProvider("2024-01=" + testKey(2))
+	if _, err := NewEncryptedStore(local, oldKeys, signer).Put(ctx, "exports/old.zip", strings.NewReader("old archive"), PutOptions{}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if _, err := local.Put(ctx, "exports/plain.zip", strings.NewReader("plain archive"), PutOptions{}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	// Rotated: new objects use 2024-06, 2024-01 still opens the old ones
+	keys, _ := ParseKeyProvider("2024-06=" + testKey(1) + ",2024-01=" + testKey(2))
+	store := NewEncryptedStore(local, keys, signer)
+	object, err := store.Put(ctx, "exports/new.zip", strings.NewReader("secret archive"), PutOptions{
+		Metadata: map[string]string{"user-id": "user-1"},
+	})
+	if err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if object.Size != int64(len("secret archive")) || object.Metadata[metaDataKey] != "" {
+		t.Errorf("Put() = %+v, want the plaintext size without key metadata", object)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(local.dir, "exports/new.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(raw, []byte("secret")) {
+		t.Error("stored object is not encrypted")
+	}
+
+	for key, want := range map[string]string{
+		"exports/new.zip":   "secret archive",
+		"exports/old.zip":   "old archive",
+		"exports/plain.zip": "plain archive",
+	} {
+		body, object, err := store.Get(ctx, key)
+		if err != nil {
+			t.Fatalf("Get(%s) error = %v", key, err)
+		}
+		data, _ := io.ReadAll(body)
+		body.Close()
+		if string(data) != want || object.Size != int64(len(want)) {
+			t.Errorf("Get(%s) = %q (size %d), want %q", key, data, object.Size, want)
+		}
+	}
+
+	// Without the master key the object cannot be opened
+	if _, _, err := NewEncryptedStore(local, oldKeys, signer).Get(ctx, "exports/new.zip"); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("Get() without the master key error = %v, want ErrUnknownKey", err)
+	}
+}
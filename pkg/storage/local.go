@@ -0,0 +1,201 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// metaSuffix names the sidecar file keeping an object's attributes
+const metaSuffix = ".meta.json"
+
+// localMeta is the content of a sidecar file
+type localMeta struct {
+	ContentType        string            `json:"content_type,omitempty"`
+	ContentDisposition string            `json:"content_disposition,omitempty"`
+	Metadata           map[string]string `json:"metadata,omitempty"`
+}
+
+// LocalStore keeps objects as files under a directory, for development and single-node
+// deployments. Downloads go through a Handler.
+type LocalStore struct {
+	dir    string
+	signer *URLSigner
+}
+
+// NewLocalStore creates a LocalStore under dir, creating it if needed
+func NewLocalStore(dir string, signer *URLSigner) (*LocalStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+	return &LocalStore{dir: dir, signer: signer}, nil
+}
+
+// path returns the file of key
+func (s *LocalStore) path(key string) (string, error) {
+	if err := ValidateKey(key); err != nil {
+		return "", err
+	}
+	if strings.HasSuffix(key, metaSuffix) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
+
+// Put stores body under key. The file is written next to its final name and renamed,
+// so readers never see a partial object.
+func (s *LocalStore) Put(ctx context.Context, key string, body io.Reader, opts PutOptions) (*Object, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create directory of %s: %w", key, err)
+	}
+
+	meta, err := json.Marshal(localMeta{
+		ContentType:        opts.ContentType,
+		ContentDisposition: opts.ContentDisposition,
+		Metadata:           opts.Metadata,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := writeFile(path+metaSuffix, strings.NewReader(string(meta))); err != nil {
+		return nil, fmt.Errorf("failed to store %s: %w", key, err)
+	}
+	if err := writeFile(path, body); err != nil {
+		return nil, fmt.Errorf("failed to store %s: %w", key, err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Object{
+		Key:                key,
+		Size:               info.Size(),
+		ContentType:        opts.ContentType,
+		ContentDisposition: opts.ContentDisposition,
+		Metadata:           opts.Metadata,
+		ModifiedAt:         info.ModTime(),
+	}, nil
+}
+
+// writeFile writes r to a temporary file and renames it to path
+func writeFile(path string, r io.Reader) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Get opens the object stored under key
+func (s *LocalStore) Get(ctx context.Context, key string) (io.ReadCloser, *Object, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+
+	var meta localMeta
+	if data, err := os.ReadFile(path + metaSuffix); err == nil {
+		_ = json.Unmarshal(data, &meta)
+	}
+	return file, &Object{
+		Key:                key,
+		Size:               info.Size(),
+		ContentType:        meta.ContentType,
+		ContentDisposition: meta.ContentDisposition,
+		Metadata:           meta.Metadata,
+		ModifiedAt:         info.ModTime(),
+	}, nil
+}
+
+// Delete removes the object stored under key
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	for _, name := range []string{path, path + metaSuffix} {
+		if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to delete %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// SignedURL returns a URL of the Handler serving this store
+func (s *LocalStore) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if _, err := s.path(key); err != nil {
+		return "", err
+	}
+	return s.signer.Sign(key, ttl)
+}
+
+// DeleteBefore removes the objects under prefix last modified before before
+func (s *LocalStore) DeleteBefore(ctx context.Context, prefix string, before time.Time) (int, error) {
+	deleted := 0
+	err := filepath.WalkDir(s.dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if entry.IsDir() || strings.HasSuffix(path, metaSuffix) || strings.HasPrefix(entry.Name(), ".upload-") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.dir, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil || !info.ModTime().Before(before) {
+			return nil
+		}
+		if err := s.Delete(ctx, key); err != nil {
+			return err
+		}
+		deleted++
+		return nil
+	})
+	return deleted, err
+}
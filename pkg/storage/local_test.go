@@ -0,0 +1,164 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestLocalStore(t *testing.T) (*LocalStore, *URLSigner) {
+	t.Helper()
+	signer, err := NewURLSigner("https://api.example.com/api/v1/files/payment/", "signing-key")
+	if err != nil {
+		t.Fatalf("NewURLSigner() error = %v", err)
+	}
+	store, err := NewLocalStore(t.TempDir(), signer)
+	if err != nil {
+		t.Fatalf("NewLocalStore() error = %v", err)
+	}
+	return store, signer
+}
+
+func TestValidateKey(t *testing.T) {
+	for _, key := range []string{"receipts/inv-1.html", "exports/tenant 1/2024-06.csv"} {
+		if err := ValidateKey(key); err != nil {
+			t.Errorf("ValidateKey(%q) error = %v", key, err)
+		}
+	}
+	for _, key := range []string{"", "/etc/passwd", "a/../b", "a//b", "./a", `a\b`, "a/"} {
+		if err := ValidateKey(key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("ValidateKey(%q) error = %v, want ErrInvalidKey", key, err)
+		}
+	}
+}
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.Background()
+	store, _ := newTestLocalStore(t)
+
+	object, err := store.Put(ctx, "receipts/inv-1.html", strings.NewReader("<p>receipt</p>"), PutOptions{
+		ContentType:        "text/html; charset=utf-8",
+		ContentDisposition: `inline; filename="inv-1.html"`,
+		Metadata:           map[string]string{"invoice-id": "inv-1"},
+	})
+	if err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if object.Size != 14 {
+		t.Errorf("Put() size = %d, want 14", object.Size)
+	}
+
+	body, object, err := store.Get(ctx, "receipts/inv-1.html")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	data, _ := io.ReadAll(body)
+	body.Close()
+	if string(data) != "<p>receipt</p>" || object.ContentType != "text/html; charset=utf-8" || object.Metadata["invoice-id"] != "inv-1" {
+		t.Errorf("Get() = %q, %+v", data, object)
+	}
+
+	if _, _, err := store.Get(ctx, "receipts/inv-1.html"+metaSuffix); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Get() of a sidecar error = %v, want ErrInvalidKey", err)
+	}
+
+	if err := store.Delete(ctx, "receipts/inv-1.html"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, _, err := store.Get(ctx, "receipts/inv-1.html"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after Delete() error = %v, want ErrNotFound", err)
+	}
+	if err := store.Delete(ctx, "receipts/inv-1.html"); err != nil {
+		t.Errorf("Delete() of a missing object error = %v", err)
+	}
+}
+
+func TestLocalStore_DeleteBefore(t *testing.T) {
+	ctx := context.Background()
+	store, _ := newTestLocalStore(t)
+
+	for _, key := range []string{"exports/old.csv", "exports/new.csv", "receipts/old.html"} {
+		if _, err := store.Put(ctx, key, strings.NewReader(key), PutOptions{}); err != nil {
+			t.Fatalf("Put() error = %v", err)
+		}
+	}
+	old := time.Now().Add(-48 * time.Hour)
+	for _, key := range []string{"exports/old.csv", "receipts/old.html"} {
+		if err := os.Chtimes(filepath.Join(store.dir, key), old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deleted, err := ApplyLifecycle(ctx, store, []LifecycleRule{{Prefix: "exports/", MaxAge: 24 * time.Hour}})
+	if err != nil || deleted != 1 {
+		t.Fatalf("ApplyLifecycle() = %d, %v, want 1", deleted, err)
+	}
+	if _, _, err := store.Get(ctx, "exports/old.csv"); !errors.Is(err, ErrNotFound) {
+		t.Error("expired export was kept")
+	}
+	for _, key := range []string{"exports/new.csv", "receipts/old.html"} {
+		if _, _, err := store.Get(ctx, key); err != nil {
+			t.Errorf("Get(%s) error = %v, want kept", key, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(store.dir, "exports/old.csv"+metaSuffix)); !os.IsNotExist(err) {
+		t.Error("sidecar of the expired export was kept")
+	}
+}
+
+func TestHandler(t *testing.T) {
+	ctx := context.Background()
+	store, signer := newTestLocalStore(t)
+	now := time.Now()
+	signer.now = func() time.Time { return now }
+
+	if _, err := store.Put(ctx, "exports/tenant 1/2024-06.csv", strings.NewReader("a,b\n"), PutOptions{
+		ContentType:        "text/csv",
+		ContentDisposition: `attachment; filename="2024-06.csv"`,
+	}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	signed, err := store.SignedURL(ctx, "exports/tenant 1/2024-06.csv", time.Minute)
+	if err != nil {
+		t.Fatalf("SignedURL() error = %v", err)
+	}
+	if !strings.HasPrefix(signed, "https://api.example.com/api/v1/files/payment/exports/tenant%201/2024-06.csv?") {
+		t.Errorf("SignedURL() = %s", signed)
+	}
+	u, _ := url.Parse(signed)
+	handler := http.StripPrefix("/api/v1/files/payment", NewHandler(store, signer))
+
+	serve := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	rec := serve(u.RequestURI())
+	if rec.Code != http.StatusOK || rec.Body.String() != "a,b\n" {
+		t.Fatalf("download = %d %q", rec.Code, rec.Body.String())
+	}
+	if rec.Header().Get("Content-Disposition") != `attachment; filename="2024-06.csv"` || rec.Header().Get("Content-Type") != "text/csv" {
+		t.Errorf("download headers = %v", rec.Header())
+	}
+
+	// The signature covers the key
+	tampered := strings.Replace(u.RequestURI(), "2024-06.csv", "2024-07.csv", 1)
+	if rec := serve(tampered); rec.Code != http.StatusForbidden {
+		t.Errorf("download of another key = %d, want 403", rec.Code)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if rec := serve(u.RequestURI()); rec.Code != http.StatusForbidden || !strings.Contains(rec.Body.String(), ErrURLExpired.Error()) {
+		t.Errorf("expired download = %d %q, want 403", rec.Code, rec.Body.String())
+	}
+}
@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidSignature is returned for download URLs not signed with the signer's key
+	ErrInvalidSignature = errors.New("invalid download signature")
+	// ErrURLExpired is returned for download URLs past their expiry
+	ErrURLExpired = errors.New("download URL expired")
+)
+
+// URLSigner signs download URLs served by a Handler, for stores that cannot presign
+// URLs themselves (local directories, encrypted objects)
+type URLSigner struct {
+	baseURL string
+	key     []byte
+	now     func() time.Time
+}
+
+// NewURLSigner creates a URLSigner for a Handler reachable at baseURL,
+// e.g. "https://api.example.com/api/v1/files/payment"
+func NewURLSigner(baseURL, key string) (*URLSigner, error) {
+	if key == "" {
+		return nil, errors.New("storage: url signing key is required")
+	}
+	return &URLSigner{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		key:     []byte(key),
+		now:     time.Now,
+	}, nil
+}
+
+// Sign returns the download URL of key valid for ttl
+func (s *URLSigner) Sign(key string, ttl time.Duration) (string, error) {
+	if err := ValidateKey(key); err != nil {
+		return "", err
+	}
+	expires := strconv.FormatInt(s.now().Add(ttl).Unix(), 10)
+	query := url.Values{"expires": {expires}, "signature": {s.signature(key, expires)}}
+	return s.baseURL + "/" + escapeKey(key) + "?" + query.Encode(), nil
+}
+
+// Verify checks the expiry and signature of a download of key
+func (s *URLSigner) Verify(key, expires, signature string) error {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || !hmac.Equal([]byte(signature), []byte(s.signature(key, expires))) {
+		return ErrInvalidSignature
+	}
+	if s.now().Unix() > expiresAt {
+		return ErrURLExpired
+	}
+	return nil
+}
+
+// signature is the hex HMAC-SHA256 of key and expires
+func (s *URLSigner) signature(key, expires string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// escapeKey escapes each segment of key for use in a URL path
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// Handler serves the objects of a store to URLs signed by a URLSigner. The object key
+// is the request path; mount it with http.StripPrefix under the signer's base URL.
+type Handler struct {
+	store  Store
+	signer *URLSigner
+}
+
+// NewHandler creates a Handler serving store
+func NewHandler(store Store, signer *URLSigner) *Handler {
+	return &Handler{store: store, signer: signer}
+}
+
+// ServeHTTP streams the object of a signed download URL
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	query := r.URL.Query()
+	if err := h.signer.Verify(key, query.Get("expires"), query.Get("signature")); err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	body, object, err := h.store.Get(r.Context(), key)
+	if errors.Is(err, ErrNotFound) || errors.Is(err, ErrInvalidKey) {
+		http.Error(w, ErrNotFound.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "failed to read file", http.StatusInternalServerError)
+		return
+	}
+	defer body.Close()
+
+	if object.ContentType != "" {
+		w.Header().Set("Content-Type", object.ContentType)
+	}
+	if object.ContentDisposition != "" {
+		w.Header().Set("Content-Disposition", object.ContentDisposition)
+	}
+	w.Header().Set("Content-Length", fmt.Sprint(object.Size))
+	w.Header().Set("Cache-Control", "private, no-store")
+	if r.Method == http.MethodHead {
+		return
+	}
+	_, _ = io.Copy(w, body)
+}
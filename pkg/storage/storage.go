@@ -0,0 +1,114 @@
+// Package storage keeps files such as exports and receipts in object storage (S3,
+// MinIO or a local directory) and hands out signed, expiring download URLs for them.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/logger"
+)
+
+var (
+	// ErrNotFound is returned when no object is stored under a key
+	ErrNotFound = errors.New("object not found")
+	// ErrInvalidKey is returned for keys that are empty, absolute or climb out of the store
+	ErrInvalidKey = errors.New("invalid object key")
+)
+
+// Object describes a stored object
+type Object struct {
+	Key                string
+	Size               int64
+	ContentType        string
+	ContentDisposition string
+	Metadata           map[string]string
+	ModifiedAt         time.Time
+}
+
+// PutOptions are the attributes an object is stored with
+type PutOptions struct {
+	ContentType string
+	// ContentDisposition is sent with downloads, e.g. `attachment; filename="export.csv"`
+	ContentDisposition string
+	// Metadata is kept with the object; names are lowercase
+	Metadata map[string]string
+}
+
+// Store keeps objects under slash-separated keys such as "receipts/<id>.html"
+type Store interface {
+	// Put stores body under key, replacing any object already there
+	Put(ctx context.Context, key string, body io.Reader, opts PutOptions) (*Object, error)
+
+	// Get opens the object stored under key; the caller closes the reader
+	Get(ctx context.Context, key string) (io.ReadCloser, *Object, error)
+
+	// Delete removes the object stored under key; a missing object is not an error
+	Delete(ctx context.Context, key string) error
+
+	// SignedURL returns a URL anyone can download the object from until ttl passes
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+
+	// DeleteBefore removes the objects under prefix last modified before before and
+	// returns how many it removed
+	DeleteBefore(ctx context.Context, prefix string, before time.Time) (int, error)
+}
+
+// ValidateKey checks that key is a relative slash-separated path without empty,
+// "." or ".." segments
+func ValidateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("%w: %q", ErrInvalidKey, key)
+		}
+	}
+	return nil
+}
+
+// LifecycleRule expires the objects under a prefix once they are older than MaxAge
+type LifecycleRule struct {
+	Prefix string
+	MaxAge time.Duration
+}
+
+// ApplyLifecycle deletes the objects the rules have expired and returns how many it deleted
+func ApplyLifecycle(ctx context.Context, store Store, rules []LifecycleRule) (int, error) {
+	deleted := 0
+	now := time.Now()
+	for _, rule := range rules {
+		count, err := store.DeleteBefore(ctx, rule.Prefix, now.Add(-rule.MaxAge))
+		deleted += count
+		if err != nil {
+			return deleted, fmt.Errorf("failed to expire %s: %w", rule.Prefix, err)
+		}
+	}
+	return deleted, nil
+}
+
+// RunLifecycle applies the rules now and then every interval until ctx is cancelled.
+// Failures are logged and retried on the next run.
+func RunLifecycle(ctx context.Context, store Store, rules []LifecycleRule, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if deleted, err := ApplyLifecycle(ctx, store, rules); err != nil && ctx.Err() == nil {
+			logger.Get().WarnContext(ctx, fmt.Sprintf("Failed to expire stored files: %v", err))
+		} else if deleted > 0 {
+			logger.Get().InfoContext(ctx, fmt.Sprintf("Expired %d stored files", deleted))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -96,6 +96,30 @@ func NewGaugeWithCallback(opts MetricOpts, callback func() int64, attrs ...attri
 	return gauge, nil
 }
 
+// FloatGauge wraps an OTel float64 gauge for ratios and other fractional values
+type FloatGauge struct {
+	gauge metric.Float64Gauge
+}
+
+// NewFloatGauge creates a new float64 gauge metric
+func NewFloatGauge(opts MetricOpts) (*FloatGauge, error) {
+	meter := GetMeter()
+	gauge, err := meter.Float64Gauge(
+		opts.Name,
+		metric.WithDescription(opts.Description),
+		metric.WithUnit(opts.Unit),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &FloatGauge{gauge: gauge}, nil
+}
+
+// Record sets the gauge to the given value
+func (g *FloatGauge) Record(ctx context.Context, value float64, attrs ...attribute.KeyValue) {
+	g.gauge.Record(ctx, value, metric.WithAttributes(attrs...))
+}
+
 // Histogram wraps an OTel histogram for easier use
 type Histogram struct {
 	histogram metric.Float64Histogram
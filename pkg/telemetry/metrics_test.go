@@ -130,6 +130,25 @@ func TestGauge_Record_Disabled(t *testing.T) {
 	gauge.Record(ctx, 100, attribute.String("key", "value"))
 }
 
+func TestFloatGauge_Record_Disabled(t *testing.T) {
+	cleanup := setupTelemetryDisabled(t)
+	defer cleanup()
+
+	gauge, err := NewFloatGauge(MetricOpts{
+		Name:        "test_float_gauge_record",
+		Description: "A test float gauge for Record",
+		Unit:        "1",
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, gauge)
+
+	ctx := context.Background()
+
+	// Should not panic
+	gauge.Record(ctx, 0.25)
+	gauge.Record(ctx, 0.5, attribute.String("key", "value"))
+}
+
 func TestNewHistogram_Disabled(t *testing.T) {
 	cleanup := setupTelemetryDisabled(t)
 	defer cleanup()
@@ -0,0 +1,268 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ErrDynamicSamplingDisabled is returned when the sampling policy is changed while
+// telemetry uses a fixed sample ratio
+var ErrDynamicSamplingDisabled = errors.New("dynamic trace sampling is not enabled")
+
+// Dynamic sampling defaults: keep requests slower than a second and 1% of healthy
+// availability reads, which dominate traffic during a rush
+const (
+	DefaultSlowThreshold  = time.Second
+	DefaultSamplingRoutes = "/availability=0.01"
+)
+
+// Limits on the spans held while their trace waits for its local root span
+const (
+	maxPendingTraces     = 10000
+	maxPendingTraceSpans = 256
+)
+
+// SamplingPolicy decides which traces are exported under dynamic sampling. Traces with
+// an error span or a local root span slower than SlowThreshold are always kept. Other
+// traces are kept at the ratio of the longest route fragment contained in their route,
+// or at DefaultRatio. Ratios are applied to the trace ID, so services with the same
+// policy keep the same healthy traces.
+type SamplingPolicy struct {
+	DefaultRatio  float64
+	SlowThreshold time.Duration      // Zero keeps no trace for being slow
+	Routes        map[string]float64 // Route fragment (e.g., "/availability") to ratio
+}
+
+// Validate checks that ratios are between 0 and 1
+func (p *SamplingPolicy) Validate() error {
+	if p.DefaultRatio < 0 || p.DefaultRatio > 1 {
+		return fmt.Errorf("default ratio %v must be between 0 and 1", p.DefaultRatio)
+	}
+	if p.SlowThreshold < 0 {
+		return fmt.Errorf("slow threshold must not be negative")
+	}
+	for route, ratio := range p.Routes {
+		if route == "" {
+			return fmt.Errorf("route fragment must not be empty")
+		}
+		if ratio < 0 || ratio > 1 {
+			return fmt.Errorf("ratio %v of %s must be between 0 and 1", ratio, route)
+		}
+	}
+	return nil
+}
+
+// Ratio returns the sample ratio of healthy traces of route
+func (p *SamplingPolicy) Ratio(route string) float64 {
+	ratio, matched := p.DefaultRatio, ""
+	for fragment, r := range p.Routes {
+		if len(fragment) > len(matched) && strings.Contains(route, fragment) {
+			ratio, matched = r, fragment
+		}
+	}
+	return ratio
+}
+
+// keep decides whether a finished trace is exported
+func (p *SamplingPolicy) keep(traceID trace.TraceID, route string, hasError bool, duration time.Duration) bool {
+	if hasError {
+		return true
+	}
+	if p.SlowThreshold > 0 && duration >= p.SlowThreshold {
+		return true
+	}
+	return traceIDBelowRatio(traceID, p.Ratio(route))
+}
+
+// traceIDBelowRatio compares the trace ID with the ratio the way TraceIDRatioBased does
+func traceIDBelowRatio(traceID trace.TraceID, ratio float64) bool {
+	if ratio >= 1 {
+		return true
+	}
+	if ratio <= 0 {
+		return false
+	}
+	bound := uint64(ratio * (1 << 63))
+	return binary.BigEndian.Uint64(traceID[8:16])>>1 < bound
+}
+
+// ParseSamplingRoutes parses per-route sample ratios from "fragment=ratio,..."
+// (e.g., "/availability=0.01,/health=0")
+func ParseSamplingRoutes(spec string) (map[string]float64, error) {
+	routes := make(map[string]float64)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		fragment, value, ok := strings.Cut(entry, "=")
+		fragment = strings.TrimSpace(fragment)
+		if !ok || fragment == "" {
+			return nil, fmt.Errorf("invalid route ratio %q, want fragment=ratio", entry)
+		}
+		ratio, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || ratio < 0 || ratio > 1 {
+			return nil, fmt.Errorf("invalid ratio in %q, want a number between 0 and 1", entry)
+		}
+		routes[fragment] = ratio
+	}
+	return routes, nil
+}
+
+// pendingTrace holds the ended spans of a trace whose local root span is still running
+type pendingTrace struct {
+	spans    []sdktrace.ReadOnlySpan
+	hasError bool
+}
+
+// tailSamplingProcessor holds the spans of each trace until its local root span ends,
+// then exports or drops them together according to the sampling policy
+type tailSamplingProcessor struct {
+	next    sdktrace.SpanProcessor
+	policy  atomic.Pointer[SamplingPolicy]
+	mu      sync.Mutex
+	pending map[trace.TraceID]*pendingTrace
+}
+
+// newTailSamplingProcessor creates a processor that passes kept traces to next
+func newTailSamplingProcessor(next sdktrace.SpanProcessor, policy SamplingPolicy) *tailSamplingProcessor {
+	p := &tailSamplingProcessor{
+		next:    next,
+		pending: make(map[trace.TraceID]*pendingTrace),
+	}
+	p.policy.Store(&policy)
+	return p
+}
+
+// OnStart passes the span to the next processor
+func (p *tailSamplingProcessor) OnStart(parent context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(parent, s)
+}
+
+// OnEnd buffers the span, or decides on the whole trace once its local root span ends
+func (p *tailSamplingProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	traceID := s.SpanContext().TraceID()
+	isError := s.Status().Code == codes.Error
+	localRoot := !s.Parent().IsValid() || s.Parent().IsRemote()
+
+	p.mu.Lock()
+	pt := p.pending[traceID]
+	if localRoot {
+		delete(p.pending, traceID)
+		p.mu.Unlock()
+
+		var spans []sdktrace.ReadOnlySpan
+		hasError := isError
+		if pt != nil {
+			spans, hasError = pt.spans, pt.hasError || isError
+		}
+		if p.policy.Load().keep(traceID, spanRoute(s), hasError, s.EndTime().Sub(s.StartTime())) {
+			for _, span := range spans {
+				p.next.OnEnd(span)
+			}
+			p.next.OnEnd(s)
+		}
+		return
+	}
+
+	if pt == nil {
+		// Make room by deciding on a trace whose root may never end here
+		var evicted *pendingTrace
+		var evictedID trace.TraceID
+		if len(p.pending) >= maxPendingTraces {
+			for id, t := range p.pending {
+				evictedID, evicted = id, t
+				delete(p.pending, id)
+				break
+			}
+		}
+		pt = &pendingTrace{}
+		p.pending[traceID] = pt
+		if evicted != nil {
+			defer p.flushTrace(evictedID, evicted)
+		}
+	}
+	pt.hasError = pt.hasError || isError
+	if len(pt.spans) < maxPendingTraceSpans {
+		pt.spans = append(pt.spans, s)
+	}
+	p.mu.Unlock()
+}
+
+// flushTrace decides on a trace without its local root span, by errors and the default ratio
+func (p *tailSamplingProcessor) flushTrace(traceID trace.TraceID, pt *pendingTrace) {
+	if !p.policy.Load().keep(traceID, "", pt.hasError, 0) {
+		return
+	}
+	for _, span := range pt.spans {
+		p.next.OnEnd(span)
+	}
+}
+
+// flushPending decides on every buffered trace
+func (p *tailSamplingProcessor) flushPending() {
+	p.mu.Lock()
+	pending := p.pending
+	p.pending = make(map[trace.TraceID]*pendingTrace)
+	p.mu.Unlock()
+
+	for traceID, pt := range pending {
+		p.flushTrace(traceID, pt)
+	}
+}
+
+// Shutdown flushes buffered traces and shuts down the next processor
+func (p *tailSamplingProcessor) Shutdown(ctx context.Context) error {
+	p.flushPending()
+	return p.next.Shutdown(ctx)
+}
+
+// ForceFlush flushes buffered traces and the next processor
+func (p *tailSamplingProcessor) ForceFlush(ctx context.Context) error {
+	p.flushPending()
+	return p.next.ForceFlush(ctx)
+}
+
+// spanRoute returns the HTTP route of a span, or its name for non-HTTP spans
+func spanRoute(s sdktrace.ReadOnlySpan) string {
+	for _, attr := range s.Attributes() {
+		if attr.Key == semconv.HTTPRouteKey {
+			return attr.Value.AsString()
+		}
+	}
+	return s.Name()
+}
+
+// SetSamplingPolicy replaces the dynamic sampling policy at runtime.
+// Returns ErrDynamicSamplingDisabled when telemetry uses a fixed sample ratio.
+func SetSamplingPolicy(policy SamplingPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	if globalTelemetry == nil || globalTelemetry.sampler == nil {
+		return ErrDynamicSamplingDisabled
+	}
+	globalTelemetry.sampler.policy.Store(&policy)
+	return nil
+}
+
+// GetSamplingPolicy returns the dynamic sampling policy, false when telemetry uses a
+// fixed sample ratio
+func GetSamplingPolicy() (SamplingPolicy, bool) {
+	if globalTelemetry == nil || globalTelemetry.sampler == nil {
+		return SamplingPolicy{}, false
+	}
+	return *globalTelemetry.sampler.policy.Load(), true
+}
@@ -0,0 +1,73 @@
+package telemetry
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/response"
+)
+
+// SamplingPolicyRequest represents the request to replace the dynamic sampling policy
+type SamplingPolicyRequest struct {
+	DefaultRatio    *float64           `json:"default_ratio" binding:"required"`
+	SlowThresholdMs int64              `json:"slow_threshold_ms"` // Zero keeps no trace for being slow
+	Routes          map[string]float64 `json:"routes"`
+}
+
+// SamplingPolicyResponse represents the dynamic sampling policy of this instance
+type SamplingPolicyResponse struct {
+	Enabled         bool               `json:"enabled"`
+	DefaultRatio    float64            `json:"default_ratio"`
+	SlowThresholdMs int64              `json:"slow_threshold_ms"`
+	Routes          map[string]float64 `json:"routes"`
+}
+
+// GetSamplingPolicyHandler returns the dynamic sampling policy of this instance
+func GetSamplingPolicyHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		policy, enabled := GetSamplingPolicy()
+		c.JSON(http.StatusOK, response.Success(samplingPolicyResponse(policy, enabled)))
+	}
+}
+
+// UpdateSamplingPolicyHandler replaces the dynamic sampling policy of this instance.
+// The policy is not shared with other instances and resets to the configured one on restart.
+func UpdateSamplingPolicyHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req SamplingPolicyRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, response.Error("INVALID_REQUEST", "default_ratio is required"))
+			return
+		}
+
+		policy := SamplingPolicy{
+			DefaultRatio:  *req.DefaultRatio,
+			SlowThreshold: time.Duration(req.SlowThresholdMs) * time.Millisecond,
+			Routes:        req.Routes,
+		}
+		if err := policy.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, response.Error("INVALID_REQUEST", err.Error()))
+			return
+		}
+		if err := SetSamplingPolicy(policy); err != nil {
+			c.JSON(http.StatusConflict, response.Error("DYNAMIC_SAMPLING_DISABLED", err.Error()))
+			return
+		}
+
+		c.JSON(http.StatusOK, response.Success(samplingPolicyResponse(policy, true)))
+	}
+}
+
+func samplingPolicyResponse(policy SamplingPolicy, enabled bool) *SamplingPolicyResponse {
+	routes := policy.Routes
+	if routes == nil {
+		routes = map[string]float64{}
+	}
+	return &SamplingPolicyResponse{
+		Enabled:         enabled,
+		DefaultRatio:    policy.DefaultRatio,
+		SlowThresholdMs: policy.SlowThreshold.Milliseconds(),
+		Routes:          routes,
+	}
+}
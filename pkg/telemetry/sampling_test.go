@@ -0,0 +1,116 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newTestTailSampler(policy SamplingPolicy) (trace.Tracer, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(newTailSamplingProcessor(recorder, policy)),
+		sdktrace.WithSampler(sdktrace.AlwaysSample()),
+	)
+	return provider.Tracer("test"), recorder
+}
+
+// runTrace ends a root span on route with one child span, and returns the trace ID
+func runTrace(tracer trace.Tracer, route string, duration time.Duration, childErr bool) trace.TraceID {
+	start := time.Now()
+	ctx, root := tracer.Start(context.Background(), "GET "+route,
+		trace.WithTimestamp(start),
+		trace.WithAttributes(semconv.HTTPRoute(route)),
+	)
+	_, child := tracer.Start(ctx, "redis.reserve")
+	if childErr {
+		child.SetStatus(codes.Error, "script failed")
+	}
+	child.End()
+	root.End(trace.WithTimestamp(start.Add(duration)))
+	return root.SpanContext().TraceID()
+}
+
+func TestTailSamplingProcessor(t *testing.T) {
+	tracer, recorder := newTestTailSampler(SamplingPolicy{
+		DefaultRatio:  1,
+		SlowThreshold: time.Second,
+		Routes:        map[string]float64{"/availability": 0},
+	})
+
+	runTrace(tracer, "/api/v1/events/:id/availability", 5*time.Millisecond, false)
+	assert.Empty(t, recorder.Ended(), "healthy availability trace should be dropped")
+
+	tests := []struct {
+		name     string
+		route    string
+		duration time.Duration
+		childErr bool
+	}{
+		{"error", "/api/v1/events/:id/availability", 5 * time.Millisecond, true},
+		{"slow", "/api/v1/events/:id/availability", 2 * time.Second, false},
+		{"default ratio", "/api/v1/bookings/reserve", 5 * time.Millisecond, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := len(recorder.Ended())
+			traceID := runTrace(tracer, tt.route, tt.duration, tt.childErr)
+
+			ended := recorder.Ended()[before:]
+			require.Len(t, ended, 2, "root and child span should both be kept")
+			for _, span := range ended {
+				assert.Equal(t, traceID, span.SpanContext().TraceID())
+			}
+		})
+	}
+}
+
+func TestSetSamplingPolicy(t *testing.T) {
+	_, err := Init(context.Background(), nil)
+	require.NoError(t, err)
+	assert.ErrorIs(t, SetSamplingPolicy(SamplingPolicy{DefaultRatio: 0.5}), ErrDynamicSamplingDisabled)
+
+	globalTelemetry.sampler = newTailSamplingProcessor(tracetest.NewSpanRecorder(), SamplingPolicy{DefaultRatio: 1})
+	defer func() { globalTelemetry.sampler = nil }()
+
+	assert.Error(t, SetSamplingPolicy(SamplingPolicy{DefaultRatio: 2}))
+	require.NoError(t, SetSamplingPolicy(SamplingPolicy{Routes: map[string]float64{"/health": 0}}))
+
+	policy, enabled := GetSamplingPolicy()
+	assert.True(t, enabled)
+	assert.Equal(t, 0.0, policy.Ratio("/health/ready"))
+}
+
+func TestSamplingPolicy_Ratio(t *testing.T) {
+	policy := SamplingPolicy{
+		DefaultRatio: 0.5,
+		Routes: map[string]float64{
+			"/availability":        0.01,
+			"/availability/stream": 0.2,
+		},
+	}
+
+	assert.Equal(t, 0.01, policy.Ratio("/api/v1/events/:id/availability"))
+	assert.Equal(t, 0.2, policy.Ratio("/api/v1/bookings/events/:event_id/availability/stream"))
+	assert.Equal(t, 0.5, policy.Ratio("/api/v1/bookings/reserve"))
+}
+
+func TestParseSamplingRoutes(t *testing.T) {
+	routes, err := ParseSamplingRoutes(" /availability=0.01, /health=0 ,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"/availability": 0.01, "/health": 0}, routes)
+
+	for _, spec := range []string{"/availability", "=0.1", "/availability=1.5", "/availability=abc"} {
+		_, err := ParseSamplingRoutes(spec)
+		assert.Error(t, err, spec)
+	}
+}
@@ -11,8 +11,8 @@ import (
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
-	"go.opentelemetry.io/otel/sdk/resource"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.27.0"
 	"go.opentelemetry.io/otel/trace"
@@ -29,6 +29,11 @@ type Config struct {
 	MetricInterval time.Duration // Interval for metric export (default: 15s)
 	// Trace-specific configuration
 	SampleRatio float64 // Sample ratio for traces (default: 1.0 = always sample)
+	// Dynamic sampling replaces SampleRatio: traces are kept or dropped when their local
+	// root span ends, see SamplingPolicy
+	DynamicSampling bool
+	SlowThreshold   time.Duration // Traces at least this slow are always kept (default: 1s)
+	SamplingRoutes  string        // Per-route ratios as "fragment=ratio,..." (e.g., "/availability=0.01")
 }
 
 // Telemetry holds the tracer provider, meter provider, tracer, and meter
@@ -39,6 +44,7 @@ type Telemetry struct {
 	meter          metric.Meter
 	config         *Config
 	resource       *resource.Resource
+	sampler        *tailSamplingProcessor // Set when dynamic sampling is enabled
 }
 
 var globalTelemetry *Telemetry
@@ -66,6 +72,9 @@ func Init(ctx context.Context, cfg *Config) (*Telemetry, error) {
 	if cfg.SampleRatio == 0 {
 		cfg.SampleRatio = 1.0 // Always sample by default
 	}
+	if cfg.SlowThreshold == 0 {
+		cfg.SlowThreshold = DefaultSlowThreshold
+	}
 
 	// Create resource with service information
 	res, err := createResource(cfg)
@@ -74,7 +83,7 @@ func Init(ctx context.Context, cfg *Config) (*Telemetry, error) {
 	}
 
 	// Create TracerProvider
-	tracerProvider, err := createTracerProvider(ctx, cfg, res)
+	tracerProvider, sampler, err := createTracerProvider(ctx, cfg, res)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create tracer provider: %w", err)
 	}
@@ -102,6 +111,7 @@ func Init(ctx context.Context, cfg *Config) (*Telemetry, error) {
 		meter:          meterProvider.Meter(cfg.ServiceName),
 		config:         cfg,
 		resource:       res,
+		sampler:        sampler,
 	}
 
 	return globalTelemetry, nil
@@ -123,15 +133,40 @@ func createResource(cfg *Config) (*resource.Resource, error) {
 	), nil
 }
 
-// createTracerProvider creates and configures the TracerProvider
-func createTracerProvider(ctx context.Context, cfg *Config, res *resource.Resource) (*sdktrace.TracerProvider, error) {
+// createTracerProvider creates and configures the TracerProvider, and the tail sampling
+// processor when dynamic sampling is enabled
+func createTracerProvider(ctx context.Context, cfg *Config, res *resource.Resource) (*sdktrace.TracerProvider, *tailSamplingProcessor, error) {
 	// Create OTLP trace exporter
 	traceExporter, err := otlptracegrpc.New(ctx,
 		otlptracegrpc.WithEndpoint(cfg.CollectorAddr),
 		otlptracegrpc.WithInsecure(), // Use insecure for internal network
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+		return nil, nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	// Every trace is recorded and the tail sampling processor decides what is exported
+	if cfg.DynamicSampling {
+		routes, err := ParseSamplingRoutes(cfg.SamplingRoutes)
+		if err != nil {
+			return nil, nil, err
+		}
+		policy := SamplingPolicy{
+			DefaultRatio:  cfg.SampleRatio,
+			SlowThreshold: cfg.SlowThreshold,
+			Routes:        routes,
+		}
+		if err := policy.Validate(); err != nil {
+			return nil, nil, fmt.Errorf("invalid sampling policy: %w", err)
+		}
+
+		sampler := newTailSamplingProcessor(sdktrace.NewBatchSpanProcessor(traceExporter), policy)
+		provider := sdktrace.NewTracerProvider(
+			sdktrace.WithSpanProcessor(sampler),
+			sdktrace.WithResource(res),
+			sdktrace.WithSampler(sdktrace.AlwaysSample()),
+		)
+		return provider, sampler, nil
 	}
 
 	// Create sampler based on config
@@ -151,7 +186,7 @@ func createTracerProvider(ctx context.Context, cfg *Config, res *resource.Resour
 		sdktrace.WithSampler(sampler),
 	)
 
-	return provider, nil
+	return provider, nil, nil
 }
 
 // createMeterProvider creates and configures the MeterProvider
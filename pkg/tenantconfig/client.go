@@ -0,0 +1,197 @@
+package tenantconfig
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prohmpiriya/booking-rush-10k-rps/pkg/redis"
+)
+
+// ErrTenantNotFound is returned when auth-service has no such tenant
+var ErrTenantNotFound = errors.New("tenant not found")
+
+// cacheKeyPrefix is the Redis key prefix of cached settings, shared by all services
+const cacheKeyPrefix = "tenant:settings:"
+
+// ClientConfig contains configuration for the settings client
+type ClientConfig struct {
+	// BaseURL is the auth-service address, e.g. http://auth-service:8081
+	BaseURL string
+	// Redis is the shared cache (nil = in-process cache only)
+	Redis *redis.Client
+	// CacheTTL bounds how long settings stay in Redis (default 10 minutes)
+	CacheTTL time.Duration
+	// LocalTTL bounds how long settings stay in process (default 30 seconds)
+	LocalTTL time.Duration
+	// HTTPClient is used to call auth-service (default: 5 second timeout)
+	HTTPClient *http.Client
+}
+
+type localEntry struct {
+	settings  *Settings
+	expiresAt time.Time
+}
+
+// Client reads tenant settings from the local cache, then Redis, then auth-service
+type Client struct {
+	baseURL    string
+	redis      *redis.Client
+	cacheTTL   time.Duration
+	localTTL   time.Duration
+	httpClient *http.Client
+
+	mu    sync.RWMutex
+	local map[string]localEntry
+}
+
+// NewClient creates a new settings client
+func NewClient(cfg *ClientConfig) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(cfg.BaseURL, "/"),
+		redis:      cfg.Redis,
+		cacheTTL:   cfg.CacheTTL,
+		localTTL:   cfg.LocalTTL,
+		httpClient: cfg.HTTPClient,
+		local:      make(map[string]localEntry),
+	}
+	if c.cacheTTL <= 0 {
+		c.cacheTTL = 10 * time.Minute
+	}
+	if c.localTTL <= 0 {
+		c.localTTL = 30 * time.Second
+	}
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{Timeout: 5 * time.Second}
+	}
+	return c
+}
+
+// Get returns the settings of a tenant
+func (c *Client) Get(ctx context.Context, tenantID string) (*Settings, error) {
+	if settings, ok := c.getLocal(tenantID); ok {
+		return settings, nil
+	}
+
+	// Redis is only a cache; any miss or error falls through to auth-service
+	if c.redis != nil {
+		if data, err := c.redis.Get(ctx, cacheKeyPrefix+tenantID).Bytes(); err == nil {
+			var settings Settings
+			if err := json.Unmarshal(data, &settings); err == nil {
+				c.setLocal(&settings)
+				return &settings, nil
+			}
+		}
+	}
+
+	settings, err := c.fetch(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	c.store(ctx, settings)
+	return settings, nil
+}
+
+// Invalidate drops the cached settings of a tenant
+func (c *Client) Invalidate(ctx context.Context, tenantID string) error {
+	c.mu.Lock()
+	delete(c.local, tenantID)
+	c.mu.Unlock()
+
+	if c.redis != nil {
+		return c.redis.Del(ctx, cacheKeyPrefix+tenantID).Err()
+	}
+	return nil
+}
+
+// Apply updates the caches from a change event. Events older than the cached
+// settings are ignored so redelivered messages cannot roll settings back.
+func (c *Client) Apply(ctx context.Context, event *ChangeEvent) error {
+	if event.Settings == nil {
+		return c.Invalidate(ctx, event.TenantID)
+	}
+
+	if cached, ok := c.getLocal(event.TenantID); ok && cached.Version > event.Version {
+		return nil
+	}
+	c.store(ctx, event.Settings)
+	return nil
+}
+
+// HandleMessage applies a change event consumed from TopicSettingsChanged
+func (c *Client) HandleMessage(ctx context.Context, value []byte) error {
+	event, err := DecodeChangeEvent(value)
+	if err != nil {
+		return err
+	}
+	return c.Apply(ctx, event)
+}
+
+// fetch calls GET /internal/tenants/:id/settings on auth-service
+func (c *Client) fetch(ctx context.Context, tenantID string) (*Settings, error) {
+	endpoint := fmt.Sprintf("%s/internal/tenants/%s/settings", c.baseURL, url.PathEscape(tenantID))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tenant settings: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrTenantNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("auth service returned status %d", resp.StatusCode)
+	}
+
+	var apiResponse struct {
+		Success bool      `json:"success"`
+		Data    *Settings `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if !apiResponse.Success || apiResponse.Data == nil {
+		return nil, fmt.Errorf("auth service returned no settings for tenant %s", tenantID)
+	}
+	return apiResponse.Data, nil
+}
+
+// store writes settings to both caches; a Redis failure only costs a later fetch
+func (c *Client) store(ctx context.Context, settings *Settings) {
+	c.setLocal(settings)
+
+	if c.redis != nil {
+		if data, err := json.Marshal(settings); err == nil {
+			c.redis.Set(ctx, cacheKeyPrefix+settings.TenantID, data, c.cacheTTL)
+		}
+	}
+}
+
+func (c *Client) getLocal(tenantID string) (*Settings, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.local[tenantID]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.settings, true
+}
+
+func (c *Client) setLocal(settings *Settings) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.local[settings.TenantID] = localEntry{settings: settings, expiresAt: time.Now().Add(c.localTTL)}
+}
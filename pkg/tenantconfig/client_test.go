@@ -0,0 +1,114 @@
+package tenantconfig
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func newSettingsServer(t *testing.T, calls *int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		if r.URL.Path != "/internal/tenants/tenant-1/settings" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"success": true,
+			"data":    Settings{TenantID: "tenant-1", Currency: "THB", MaxTicketsPerUser: 4, Version: 1},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient_Get_CachesLocally(t *testing.T) {
+	var calls int32
+	client := NewClient(&ClientConfig{BaseURL: newSettingsServer(t, &calls).URL})
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		settings, err := client.Get(ctx, "tenant-1")
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if settings.Currency != "THB" || settings.MaxTicketsPerUser != 4 {
+			t.Errorf("Unexpected settings: %+v", settings)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call to auth-service, got %d", calls)
+	}
+}
+
+func TestClient_Get_NotFound(t *testing.T) {
+	var calls int32
+	client := NewClient(&ClientConfig{BaseURL: newSettingsServer(t, &calls).URL})
+
+	if _, err := client.Get(context.Background(), "tenant-2"); !errors.Is(err, ErrTenantNotFound) {
+		t.Errorf("Expected %v, got %v", ErrTenantNotFound, err)
+	}
+}
+
+func TestClient_HandleMessage(t *testing.T) {
+	var calls int32
+	client := NewClient(&ClientConfig{BaseURL: newSettingsServer(t, &calls).URL})
+	ctx := context.Background()
+
+	if _, err := client.Get(ctx, "tenant-1"); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	apply := func(event ChangeEvent) {
+		t.Helper()
+		value, _ := json.Marshal(event)
+		if err := client.HandleMessage(ctx, value); err != nil {
+			t.Fatalf("HandleMessage failed: %v", err)
+		}
+	}
+
+	// A newer version replaces the cached settings without a fetch
+	apply(ChangeEvent{
+		EventType: EventSettingsUpdated,
+		TenantID:  "tenant-1",
+		Version:   3,
+		Settings:  &Settings{TenantID: "tenant-1", Currency: "USD", Version: 3},
+	})
+	if settings, _ := client.Get(ctx, "tenant-1"); settings.Currency != "USD" {
+		t.Errorf("Expected updated currency USD, got %s", settings.Currency)
+	}
+
+	// A redelivered older event is ignored
+	apply(ChangeEvent{
+		EventType: EventSettingsUpdated,
+		TenantID:  "tenant-1",
+		Version:   2,
+		Settings:  &Settings{TenantID: "tenant-1", Currency: "EUR", Version: 2},
+	})
+	if settings, _ := client.Get(ctx, "tenant-1"); settings.Currency != "USD" {
+		t.Errorf("Expected stale event to be ignored, got %s", settings.Currency)
+	}
+
+	// An event without settings invalidates, so the next read refetches
+	apply(ChangeEvent{EventType: EventSettingsReset, TenantID: "tenant-1", Version: 4})
+	if settings, _ := client.Get(ctx, "tenant-1"); settings.Currency != "THB" {
+		t.Errorf("Expected refetched currency THB, got %s", settings.Currency)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 calls to auth-service, got %d", calls)
+	}
+}
+
+func TestDecodeChangeEvent_Invalid(t *testing.T) {
+	if _, err := DecodeChangeEvent([]byte("not-json")); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+	if _, err := DecodeChangeEvent([]byte(`{"event_type":"tenant.settings.updated"}`)); err == nil {
+		t.Error("Expected error for missing tenant_id")
+	}
+}
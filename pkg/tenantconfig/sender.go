@@ -0,0 +1,45 @@
+package tenantconfig
+
+// EmailIdentity is who a notification email comes from and how it is branded
+type EmailIdentity struct {
+	FromAddress  string `json:"from_address"`
+	FromName     string `json:"from_name,omitempty"`
+	ReplyTo      string `json:"reply_to,omitempty"`
+	LogoURL      string `json:"logo_url,omitempty"`
+	PrimaryColor string `json:"primary_color,omitempty"`
+}
+
+// EmailIdentityFor returns the identity to send a tenant's emails with. The tenant's
+// from address is only used once its domain is verified, since mail from an
+// unverified domain fails SPF/DKIM and lands in spam; until then emails come from
+// fallback but still carry the tenant's name, reply-to and branding.
+func EmailIdentityFor(settings *Settings, fallback EmailIdentity) EmailIdentity {
+	identity := fallback
+	if settings == nil {
+		return identity
+	}
+
+	sender := settings.Sender
+	if sender.Verified && sender.FromAddress != "" {
+		identity.FromAddress = sender.FromAddress
+	}
+	switch {
+	case sender.FromName != "":
+		identity.FromName = sender.FromName
+	case settings.Branding.DisplayName != "":
+		identity.FromName = settings.Branding.DisplayName
+	}
+	switch {
+	case sender.ReplyTo != "":
+		identity.ReplyTo = sender.ReplyTo
+	case settings.Branding.SupportEmail != "":
+		identity.ReplyTo = settings.Branding.SupportEmail
+	}
+	if settings.Branding.LogoURL != "" {
+		identity.LogoURL = settings.Branding.LogoURL
+	}
+	if settings.Branding.PrimaryColor != "" {
+		identity.PrimaryColor = settings.Branding.PrimaryColor
+	}
+	return identity
+}
@@ -0,0 +1,37 @@
+package tenantconfig
+
+import "testing"
+
+func TestEmailIdentityFor(t *testing.T) {
+	fallback := EmailIdentity{FromAddress: "noreply@bookingrush.app", FromName: "Booking Rush"}
+
+	if got := EmailIdentityFor(nil, fallback); got != fallback {
+		t.Errorf("Expected the fallback without settings, got %+v", got)
+	}
+
+	settings := &Settings{
+		Branding: BrandingSettings{DisplayName: "Concerts Co", LogoURL: "https://cdn.example.com/logo.png", PrimaryColor: "#ff0000", SupportEmail: "help@concerts.example.com"},
+		Sender:   SenderSettings{FromAddress: "tickets@concerts.example.com"},
+	}
+
+	// An unverified from address is not used, the rest of the branding is
+	got := EmailIdentityFor(settings, fallback)
+	want := EmailIdentity{
+		FromAddress:  "noreply@bookingrush.app",
+		FromName:     "Concerts Co",
+		ReplyTo:      "help@concerts.example.com",
+		LogoURL:      "https://cdn.example.com/logo.png",
+		PrimaryColor: "#ff0000",
+	}
+	if got != want {
+		t.Errorf("EmailIdentityFor(unverified) = %+v, want %+v", got, want)
+	}
+
+	settings.Sender.Verified = true
+	settings.Sender.FromName = "Concerts Co Tickets"
+	settings.Sender.ReplyTo = "orders@concerts.example.com"
+	got = EmailIdentityFor(settings, fallback)
+	if got.FromAddress != "tickets@concerts.example.com" || got.FromName != "Concerts Co Tickets" || got.ReplyTo != "orders@concerts.example.com" {
+		t.Errorf("EmailIdentityFor(verified) = %+v", got)
+	}
+}
@@ -0,0 +1,80 @@
+// Package tenantconfig gives services read access to tenant settings owned by auth-service.
+// Reads go through an in-process cache and a shared Redis cache; change events published
+// by auth-service keep both fresh.
+package tenantconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// TopicSettingsChanged is the Kafka topic auth-service publishes settings changes to
+const TopicSettingsChanged = "tenant.settings-changed"
+
+// Change event types
+const (
+	EventSettingsUpdated = "tenant.settings.updated"
+	EventSettingsReset   = "tenant.settings.reset"
+)
+
+// Settings is the configuration of a tenant
+type Settings struct {
+	TenantID          string           `json:"tenant_id"`
+	Currency          string           `json:"currency"`
+	MaxTicketsPerUser int              `json:"max_tickets_per_user"` // 0 = service default
+	Plan              string           `json:"plan,omitempty"`       // Usage plan; "" = the gateway's default plan
+	Queue             QueueSettings    `json:"queue"`
+	Branding          BrandingSettings `json:"branding"`
+	Sender            SenderSettings   `json:"sender"`
+	Version           int64            `json:"version"`
+	UpdatedAt         time.Time        `json:"updated_at"`
+}
+
+// QueueSettings are the virtual queue defaults for the tenant's events
+type QueueSettings struct {
+	RequirePass    bool  `json:"require_pass"`
+	MaxSize        int64 `json:"max_size"`         // 0 = unlimited
+	PassTTLSeconds int   `json:"pass_ttl_seconds"` // 0 = service default
+}
+
+// BrandingSettings control how the tenant is presented to customers
+type BrandingSettings struct {
+	DisplayName  string `json:"display_name,omitempty"`
+	LogoURL      string `json:"logo_url,omitempty"`
+	PrimaryColor string `json:"primary_color,omitempty"`
+	SupportEmail string `json:"support_email,omitempty"`
+}
+
+// SenderSettings are the address the tenant's notification emails are sent from
+type SenderSettings struct {
+	FromAddress string `json:"from_address,omitempty"`
+	FromName    string `json:"from_name,omitempty"`
+	ReplyTo     string `json:"reply_to,omitempty"`
+	// Verified is set once the from address's domain proved ownership and
+	// authorized our mail servers (SPF and DKIM)
+	Verified bool `json:"verified"`
+}
+
+// ChangeEvent is published whenever a tenant's settings change.
+// Settings carries the new values so consumers can refresh without a round trip.
+type ChangeEvent struct {
+	EventID    string    `json:"event_id"`
+	EventType  string    `json:"event_type"`
+	TenantID   string    `json:"tenant_id"`
+	Version    int64     `json:"version"`
+	Settings   *Settings `json:"settings,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// DecodeChangeEvent parses a change event message value
+func DecodeChangeEvent(value []byte) (*ChangeEvent, error) {
+	var event ChangeEvent
+	if err := json.Unmarshal(value, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode tenant settings event: %w", err)
+	}
+	if event.TenantID == "" {
+		return nil, fmt.Errorf("tenant settings event has no tenant_id")
+	}
+	return &event, nil
+}
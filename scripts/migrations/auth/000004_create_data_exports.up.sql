@@ -0,0 +1,26 @@
+-- ============================================================================
+-- User Data Exports (GDPR right of access)
+-- ============================================================================
+-- One row per export request. The archive is compiled asynchronously from
+-- auth, booking and payment data and kept until expires_at.
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS data_exports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Lifecycle: 'pending', 'ready', 'failed'
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    error TEXT,
+
+    -- Compiled zip archive (NULL until ready)
+    archive BYTEA,
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_data_exports_user_id ON data_exports(user_id);
+CREATE INDEX IF NOT EXISTS idx_data_exports_expires_at ON data_exports(expires_at);
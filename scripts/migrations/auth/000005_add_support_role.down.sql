@@ -0,0 +1,4 @@
+-- PostgreSQL cannot drop an enum value; move support agents back to customers
+UPDATE users SET role = 'customer' WHERE role = 'support';
+
+DROP INDEX IF EXISTS idx_users_email_lower;
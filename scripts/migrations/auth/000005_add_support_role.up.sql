@@ -0,0 +1,11 @@
+-- ============================================================================
+-- Support Booking Search
+-- ============================================================================
+-- Support agents get their own role with read-only access to booking search.
+-- booking-service resolves customer emails through GET /internal/users?email=,
+-- matched case-insensitively.
+-- ============================================================================
+
+ALTER TYPE user_role ADD VALUE IF NOT EXISTS 'support';
+
+CREATE INDEX IF NOT EXISTS idx_users_email_lower ON users(lower(email));
@@ -0,0 +1,24 @@
+-- ============================================================================
+-- Tenant Settings
+-- ============================================================================
+-- Typed per-tenant configuration read by other services through
+-- pkg/tenantconfig. version increases on every change so cached copies can
+-- tell stale change events apart. A missing row means all defaults.
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS tenant_settings (
+    tenant_id UUID PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+
+    currency VARCHAR(3) NOT NULL DEFAULT 'THB',
+    max_tickets_per_user INTEGER NOT NULL DEFAULT 0,
+
+    -- {"require_pass": bool, "max_size": int, "pass_ttl_seconds": int}
+    queue JSONB NOT NULL DEFAULT '{}',
+    -- {"display_name", "logo_url", "primary_color", "support_email"}
+    branding JSONB NOT NULL DEFAULT '{}',
+
+    version BIGINT NOT NULL DEFAULT 1,
+    updated_by UUID,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
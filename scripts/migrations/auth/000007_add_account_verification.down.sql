@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS verification_challenges;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS verified_at,
+    DROP COLUMN IF EXISTS risk_level,
+    DROP COLUMN IF EXISTS phone_verified_at;
@@ -0,0 +1,35 @@
+-- ============================================================================
+-- Account Verification (anti-scalping)
+-- ============================================================================
+-- Events can require a verified email, or a verified email and phone, to book.
+-- High-risk accounts must pass a verification challenge shortly before booking.
+-- The verification state is issued in access tokens and enforced by
+-- booking-service.
+-- ============================================================================
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS phone_verified_at TIMESTAMP WITH TIME ZONE,
+    -- Set by fraud tooling through PUT /internal/users/:id/risk-level
+    ADD COLUMN IF NOT EXISTS risk_level VARCHAR(20) NOT NULL DEFAULT 'normal'
+        CONSTRAINT chk_users_risk_level CHECK (risk_level IN ('normal', 'high')),
+    -- Last passed verification challenge
+    ADD COLUMN IF NOT EXISTS verified_at TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE IF NOT EXISTS verification_challenges (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- 'email' or 'phone'; destination is the address the code was sent to
+    channel VARCHAR(10) NOT NULL CONSTRAINT chk_verification_challenges_channel CHECK (channel IN ('email', 'phone')),
+    destination VARCHAR(255) NOT NULL,
+
+    -- SHA-256 of the one-time code, never the code itself
+    code_hash VARCHAR(64) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    verified_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_verification_challenges_user_created ON verification_challenges(user_id, created_at DESC);
@@ -0,0 +1,21 @@
+-- ============================================================================
+-- Devices
+-- ============================================================================
+-- Mobile app installations registered for push notifications. A provider
+-- token identifies one installation, so it is unique across users: signing in
+-- as another user on the same phone moves the token. Tokens the providers
+-- reject are deleted by the notification pipeline.
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS devices (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    platform VARCHAR(10) NOT NULL CHECK (platform IN ('android', 'ios')),
+    token TEXT NOT NULL UNIQUE,
+    locale VARCHAR(10),
+    app_version VARCHAR(50),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_devices_user_id ON devices(user_id, last_seen_at DESC);
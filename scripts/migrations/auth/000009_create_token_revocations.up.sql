@@ -0,0 +1,12 @@
+-- ============================================================================
+-- Token Revocations
+-- ============================================================================
+-- Access tokens are stateless JWTs, so signing out of every session records
+-- when it happened instead: tokens of the user issued before revoked_at are
+-- rejected by /auth/validate. Only the latest revocation per user is kept.
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS token_revocations (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    revoked_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,9 @@
+-- ============================================================================
+-- Tenant Plans
+-- ============================================================================
+-- The usage plan a tenant is billed on. The API gateway looks the plan's
+-- daily quotas up in USAGE_PLANS; an empty plan means USAGE_DEFAULT_PLAN.
+-- ============================================================================
+
+ALTER TABLE tenant_settings
+    ADD COLUMN IF NOT EXISTS plan VARCHAR(50) NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+ALTER TABLE tenant_settings DROP COLUMN IF EXISTS sender;
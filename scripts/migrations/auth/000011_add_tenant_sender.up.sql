@@ -0,0 +1,11 @@
+-- ============================================================================
+-- Tenant Email Senders
+-- ============================================================================
+-- The address a tenant's notification emails come from. The from address is
+-- only used once its domain publishes the verification token and authorizes
+-- our mail servers (SPF and DKIM); until then emails use the default sender.
+-- ============================================================================
+
+-- {"from_address", "from_name", "reply_to", "verification_token", "verified_at"}
+ALTER TABLE tenant_settings
+    ADD COLUMN IF NOT EXISTS sender JSONB NOT NULL DEFAULT '{}';
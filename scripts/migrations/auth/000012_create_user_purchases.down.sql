@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS purchase_shows;
+DROP TABLE IF EXISTS user_purchases;
@@ -0,0 +1,35 @@
+-- ============================================================================
+-- User Purchases
+-- ============================================================================
+-- Purchase history projected from booking-events and payment-events, so the
+-- purchase summary is answered without calling booking or payment service.
+-- A row is keyed by booking and filled in by whichever event arrives first;
+-- *_event_at columns keep redelivered or reordered events from rolling a row
+-- back. Show start times are resolved once per show from ticket service.
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS user_purchases (
+    booking_id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    event_id UUID,
+    show_id UUID,
+    quantity INTEGER NOT NULL DEFAULT 0,
+    booking_amount DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    currency VARCHAR(3) NOT NULL DEFAULT 'THB',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'confirmed', 'cancelled')),
+    confirmed_at TIMESTAMP WITH TIME ZONE,
+    booking_event_at TIMESTAMP WITH TIME ZONE,
+    paid_amount DECIMAL(12, 2),
+    refunded_amount DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    payment_event_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_purchases_user_id ON user_purchases(user_id);
+
+CREATE TABLE IF NOT EXISTS purchase_shows (
+    show_id UUID PRIMARY KEY,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    resolved_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,8 @@
+-- ============================================================================
+-- Data export archives in object storage
+-- ============================================================================
+-- When object storage is configured the compiled archive is stored there and
+-- only its key is kept; archive stays NULL for those exports.
+-- ============================================================================
+
+ALTER TABLE data_exports ADD COLUMN IF NOT EXISTS archive_key TEXT;
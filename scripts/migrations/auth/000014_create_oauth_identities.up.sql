@@ -0,0 +1,34 @@
+-- ============================================================================
+-- Social Login (OAuth2 / OIDC)
+-- ============================================================================
+-- tenant_oauth_providers holds a tenant's own client credentials for Google,
+-- Facebook and LINE; tenants without a row use the platform's credentials.
+-- user_identities links a provider account (provider + subject) to a user, so
+-- a user can sign in with a password and any number of linked providers.
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS tenant_oauth_providers (
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    provider VARCHAR(20) NOT NULL CHECK (provider IN ('google', 'facebook', 'line')),
+    client_id VARCHAR(255) NOT NULL,
+    client_secret TEXT NOT NULL,
+    redirect_url TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    updated_by UUID,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, provider)
+);
+
+CREATE TABLE IF NOT EXISTS user_identities (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider VARCHAR(20) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    email VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_login_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_identities_subject ON user_identities(provider, subject);
+CREATE INDEX IF NOT EXISTS idx_user_identities_user_id ON user_identities(user_id);
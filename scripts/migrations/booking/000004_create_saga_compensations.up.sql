@@ -0,0 +1,33 @@
+-- ============================================================================
+-- Saga Compensations (audit trail)
+-- ============================================================================
+-- One row per compensation run (automatic from the orchestrator or manual
+-- from the admin API). Ties the refund + release steps of a saga together.
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS saga_compensations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    -- Saga / booking references (saga_id is NULL for manual runs without a saga)
+    saga_id UUID,
+    booking_id UUID,
+
+    -- How the compensation was started: 'automatic' or 'manual'
+    trigger_type VARCHAR(20) NOT NULL DEFAULT 'automatic',
+    requested_by VARCHAR(255), -- Admin user for manual runs
+
+    -- Why compensation ran and which steps were compensated
+    reason TEXT NOT NULL,
+    steps JSONB NOT NULL DEFAULT '[]',
+
+    -- Result: 'triggered', 'completed', 'partial', 'failed'
+    status VARCHAR(20) NOT NULL DEFAULT 'triggered',
+    error TEXT,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_saga_compensations_saga_id ON saga_compensations(saga_id);
+CREATE INDEX IF NOT EXISTS idx_saga_compensations_booking_id ON saga_compensations(booking_id);
+CREATE INDEX IF NOT EXISTS idx_saga_compensations_created_at ON saga_compensations(created_at DESC);
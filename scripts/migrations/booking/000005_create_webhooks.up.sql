@@ -0,0 +1,59 @@
+-- ============================================================================
+-- Tenant Webhooks
+-- ============================================================================
+-- Organizers subscribe their own endpoints to booking lifecycle events
+-- (booking.confirmed, booking.cancelled, payment.refunded). Every event sent
+-- to an endpoint is recorded in webhook_deliveries, which doubles as the
+-- retry queue for the webhook worker and as the delivery log for debugging.
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id VARCHAR(255) NOT NULL,
+
+    url TEXT NOT NULL,
+    secret VARCHAR(100) NOT NULL, -- HMAC-SHA256 signing key
+    event_types TEXT[] NOT NULL,
+    description TEXT,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_tenant_id ON webhook_subscriptions(tenant_id);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    subscription_id UUID NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    tenant_id VARCHAR(255) NOT NULL,
+
+    -- Source event (event_id is the Kafka event ID, stable across redeliveries)
+    event_id VARCHAR(255) NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+
+    -- Result: 'pending', 'delivered', 'failed'
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    max_attempts INTEGER NOT NULL DEFAULT 8,
+    last_status_code INTEGER,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    delivered_at TIMESTAMP WITH TIME ZONE,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    -- One delivery per event per subscription, so redelivered events are ignored
+    CONSTRAINT uq_webhook_deliveries_subscription_event UNIQUE (subscription_id, event_id)
+);
+
+-- Due deliveries polled by the webhook worker
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due
+    ON webhook_deliveries(next_attempt_at)
+    WHERE status = 'pending';
+
+-- Delivery log queries
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_tenant_created ON webhook_deliveries(tenant_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription_created ON webhook_deliveries(subscription_id, created_at DESC);
@@ -0,0 +1,10 @@
+-- ============================================================================
+-- Reserved Seating
+-- ============================================================================
+-- Zones with a seat map assign specific seats at reservation time
+-- (best-available allocation against the Redis seat bitmap). The labels are
+-- kept on the booking, e.g. {"C-11","C-12"}; general admission bookings leave
+-- the column NULL.
+-- ============================================================================
+
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS seat_labels TEXT[];
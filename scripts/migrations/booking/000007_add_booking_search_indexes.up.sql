@@ -0,0 +1,10 @@
+-- ============================================================================
+-- Support Booking Search
+-- ============================================================================
+-- GET /api/v1/admin/bookings/search matches confirmation codes case-insensitively.
+-- Email and card last four are resolved to user and booking IDs by auth-service
+-- and payment-service, then hit the existing user_id and primary key indexes;
+-- payment_id uses idx_bookings_payment_id.
+-- ============================================================================
+
+CREATE INDEX IF NOT EXISTS idx_bookings_confirmation_code_lower ON bookings(lower(confirmation_code));
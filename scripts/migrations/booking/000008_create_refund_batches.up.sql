@@ -0,0 +1,54 @@
+-- ============================================================================
+-- Refund Batches (cancelled shows)
+-- ============================================================================
+-- One batch per cancelled show, with one item per confirmed booking. The
+-- refund worker sends a refund command per pending item and records the
+-- payment.refunded / payment.refund_failed result, so the admin API can
+-- report per-booking progress.
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS refund_batches (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    show_id UUID NOT NULL,     -- Reference to ticket_db.shows (NO FK)
+
+    reason TEXT NOT NULL,
+    requested_by VARCHAR(255), -- Admin user who cancelled the show
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE, -- Set once no refund is outstanding
+
+    CONSTRAINT uq_refund_batches_show_id UNIQUE (show_id)
+);
+
+CREATE TABLE IF NOT EXISTS refund_batch_items (
+    batch_id UUID NOT NULL REFERENCES refund_batches(id) ON DELETE CASCADE,
+    booking_id UUID NOT NULL,
+
+    -- Copied from the booking so refunds can be sent without reading it again
+    tenant_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    payment_id VARCHAR(255),
+    amount DECIMAL(12, 2) NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'THB',
+
+    -- Result: 'pending', 'requested', 'refunded', 'failed', 'skipped'
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    requested_at TIMESTAMP WITH TIME ZONE,
+    refunded_at TIMESTAMP WITH TIME ZONE,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (batch_id, booking_id)
+);
+
+-- Pending items polled by the refund worker
+CREATE INDEX IF NOT EXISTS idx_refund_batch_items_due
+    ON refund_batch_items(next_attempt_at)
+    WHERE status = 'pending';
+
+-- Refund results are matched to items by booking
+CREATE INDEX IF NOT EXISTS idx_refund_batch_items_booking_id ON refund_batch_items(booking_id);
@@ -0,0 +1,41 @@
+-- ============================================================================
+-- Organizer Report Schedules
+-- ============================================================================
+-- One schedule per organizer (tenant) for the daily or weekly sales summary
+-- email. The report worker claims due schedules, sends the summary of the
+-- period that just ended and moves next_run_at to the following send time.
+-- Every email carries the unsubscribe token, which stops further reports
+-- without signing in.
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS report_schedules (
+    tenant_id VARCHAR(255) PRIMARY KEY,
+
+    email VARCHAR(255) NOT NULL,
+    locale VARCHAR(10) NOT NULL DEFAULT 'en',
+
+    -- 'daily' or 'weekly'; reports are sent at send_hour UTC (on weekday for weekly, 0 = Sunday)
+    frequency VARCHAR(10) NOT NULL,
+    send_hour SMALLINT NOT NULL DEFAULT 8 CHECK (send_hour BETWEEN 0 AND 23),
+    weekday SMALLINT NOT NULL DEFAULT 1 CHECK (weekday BETWEEN 0 AND 6),
+
+    -- Events to report on; empty reports every event with activity in the period
+    event_ids UUID[] NOT NULL DEFAULT '{}',
+
+    unsubscribe_token VARCHAR(64) NOT NULL,
+    unsubscribed_at TIMESTAMP WITH TIME ZONE,
+
+    next_run_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    claimed_until TIMESTAMP WITH TIME ZONE, -- Hides a schedule from other workers while its report is sent
+    last_sent_at TIMESTAMP WITH TIME ZONE,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_report_schedules_unsubscribe_token UNIQUE (unsubscribe_token)
+);
+
+-- Due schedules polled by the report worker
+CREATE INDEX IF NOT EXISTS idx_report_schedules_due
+    ON report_schedules(next_run_at)
+    WHERE unsubscribed_at IS NULL;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_saga_instances_booking_id;
@@ -0,0 +1,9 @@
+-- ============================================================================
+-- Saga Lookup by Booking
+-- ============================================================================
+-- The booking status endpoint shows the latest saga run for a booking. Sagas
+-- carry the booking in their data, so index the extracted booking_id.
+-- ============================================================================
+
+CREATE INDEX IF NOT EXISTS idx_saga_instances_booking_id
+    ON saga_instances ((data->>'booking_id'), created_at DESC);
@@ -0,0 +1,4 @@
+-- Rollback manual retry of dead-lettered saga steps
+
+DROP INDEX IF EXISTS idx_saga_dead_letters_saga_step;
+ALTER TABLE saga_dead_letters DROP COLUMN IF EXISTS retried_by;
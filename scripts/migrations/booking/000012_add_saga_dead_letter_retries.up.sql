@@ -0,0 +1,12 @@
+-- ============================================================================
+-- Manual Retry of Dead-Lettered Saga Steps
+-- ============================================================================
+-- Operators re-emit a dead-lettered step command from the admin API. The dead
+-- letter records who retried it; lookups go by saga and step name.
+-- ============================================================================
+
+ALTER TABLE saga_dead_letters ADD COLUMN IF NOT EXISTS retried_by VARCHAR(255);
+
+CREATE INDEX IF NOT EXISTS idx_saga_dead_letters_saga_step
+    ON saga_dead_letters (saga_id, (message_value->>'step_name'), created_at DESC)
+    WHERE processed = FALSE;
@@ -0,0 +1,3 @@
+-- Rollback booking add-ons
+
+ALTER TABLE bookings DROP COLUMN IF EXISTS add_ons;
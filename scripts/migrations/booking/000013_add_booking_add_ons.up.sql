@@ -0,0 +1,10 @@
+-- ============================================================================
+-- Booking Add-ons
+-- ============================================================================
+-- Add-on items (parking, merchandise) sold with a booking's tickets, as
+-- [{"add_on_id", "name", "category", "quantity", "unit_price", "total_price"}].
+-- Lines are priced when reserved and already included in total_amount.
+-- NULL when the booking has no add-ons.
+-- ============================================================================
+
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS add_ons JSONB;
@@ -0,0 +1,4 @@
+-- Rollback reporting zone snapshots
+
+DROP TABLE IF EXISTS reporting.zone_snapshots;
+DROP SCHEMA IF EXISTS reporting;
@@ -0,0 +1,31 @@
+-- ============================================================================
+-- Reporting: hourly zone snapshots
+-- ============================================================================
+-- report-worker materializes every zone's held, sold and available seats and
+-- its revenue at the top of each hour. Historical charts read these rows
+-- instead of aggregating bookings. Rows are upserted, so a re-run of the same
+-- hour (e.g. from another replica) replaces the earlier snapshot.
+-- total_seats and available_seats come from ticket_db.seat_zones and are NULL
+-- when the zone's inventory could not be read.
+-- ============================================================================
+
+CREATE SCHEMA IF NOT EXISTS reporting;
+
+CREATE TABLE IF NOT EXISTS reporting.zone_snapshots (
+    snapshot_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    tenant_id UUID NOT NULL,
+    event_id UUID NOT NULL,
+    show_id UUID NOT NULL,
+    zone_id UUID NOT NULL,
+    total_seats INT,
+    available_seats INT,
+    held_seats INT NOT NULL DEFAULT 0,
+    sold_seats INT NOT NULL DEFAULT 0,
+    revenue DECIMAL(14, 2) NOT NULL DEFAULT 0,
+    currency VARCHAR(3) NOT NULL DEFAULT 'THB',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (zone_id, snapshot_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_zone_snapshots_event
+    ON reporting.zone_snapshots(tenant_id, event_id, snapshot_at);
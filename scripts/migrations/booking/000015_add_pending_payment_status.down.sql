@@ -0,0 +1,23 @@
+-- Rollback pending_payment booking status
+-- PostgreSQL cannot drop enum values, so map rows back and recreate the type
+
+UPDATE bookings SET status = 'reserved' WHERE status = 'pending_payment';
+
+DROP INDEX IF EXISTS idx_bookings_pending_expired;
+
+ALTER TYPE booking_status RENAME TO booking_status_old;
+CREATE TYPE booking_status AS ENUM (
+    'pending',
+    'reserved',
+    'confirmed',
+    'cancelled',
+    'expired',
+    'refunded'
+);
+ALTER TABLE bookings ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE bookings ALTER COLUMN status TYPE booking_status USING status::text::booking_status;
+ALTER TABLE bookings ALTER COLUMN status SET DEFAULT 'pending';
+DROP TYPE booking_status_old;
+
+CREATE INDEX idx_bookings_pending_expired ON bookings(reservation_expires_at)
+    WHERE status = 'reserved' AND reservation_expires_at IS NOT NULL;
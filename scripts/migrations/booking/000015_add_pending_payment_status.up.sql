@@ -0,0 +1,3 @@
+-- Redirect payments (3-D Secure, bank transfer): the booking keeps its seats while
+-- the customer finishes paying at the gateway, until the webhook or the return confirms it
+ALTER TYPE booking_status ADD VALUE IF NOT EXISTS 'pending_payment' AFTER 'reserved';
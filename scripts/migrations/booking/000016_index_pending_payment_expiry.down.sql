@@ -0,0 +1,6 @@
+-- Rollback pending payment expiry index
+
+DROP INDEX IF EXISTS idx_bookings_pending_expired;
+
+CREATE INDEX idx_bookings_pending_expired ON bookings(reservation_expires_at)
+    WHERE status = 'reserved' AND reservation_expires_at IS NOT NULL;
@@ -0,0 +1,6 @@
+-- The expiry worker scans holds both reserved and pending payment. A new enum value
+-- cannot be used in the migration that adds it, so the partial index is replaced here.
+DROP INDEX IF EXISTS idx_bookings_pending_expired;
+
+CREATE INDEX idx_bookings_pending_expired ON bookings(reservation_expires_at)
+    WHERE status IN ('reserved', 'pending_payment') AND reservation_expires_at IS NOT NULL;
@@ -0,0 +1,3 @@
+-- Rollback idle reservation index
+
+DROP INDEX IF EXISTS idx_bookings_idle_reserved;
@@ -0,0 +1,4 @@
+-- The idle reservation worker lists holds no payment was started for, oldest first,
+-- to suggest their early release
+CREATE INDEX IF NOT EXISTS idx_bookings_idle_reserved ON bookings(reserved_at)
+    WHERE status = 'reserved' AND payment_id IS NULL;
@@ -0,0 +1,34 @@
+-- ============================================================================
+-- Audit logs
+-- ============================================================================
+-- Written by pkg/middleware.AuditLogger for audited admin actions of the
+-- booking service (e.g. idempotency record invalidation). Tenants and users
+-- live in auth_db, so unlike the shared schema's audit_logs the table has no
+-- foreign keys to them. Columns match the AuditLogger insert; action, the
+-- resource ID and the client IP are free text because they come from route
+-- handlers and request headers.
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id UUID PRIMARY KEY,
+    tenant_id UUID,
+    user_id UUID,
+    user_email VARCHAR(255),
+    user_role VARCHAR(50),
+    action VARCHAR(20) NOT NULL,
+    resource_type VARCHAR(100) NOT NULL,
+    resource_id VARCHAR(255),
+    ip_address VARCHAR(255),
+    user_agent TEXT,
+    request_id VARCHAR(100),
+    trace_id VARCHAR(100),
+    old_values JSONB,
+    new_values JSONB,
+    changes JSONB,
+    metadata JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_tenant_created ON audit_logs(tenant_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_user_created ON audit_logs(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_resource ON audit_logs(resource_type, resource_id, created_at DESC);
@@ -0,0 +1,3 @@
+-- Rollback card last four index
+
+DROP INDEX IF EXISTS idx_payments_card_last_four;
@@ -0,0 +1,3 @@
+-- Support booking search looks up recent payments by card last four digits
+CREATE INDEX IF NOT EXISTS idx_payments_card_last_four ON payments(card_last_four, created_at DESC)
+    WHERE card_last_four IS NOT NULL;
@@ -0,0 +1,26 @@
+-- Rollback authorize/capture payment statuses
+-- PostgreSQL cannot drop enum values, so map rows back and recreate the type
+
+UPDATE payments SET status = 'succeeded' WHERE status = 'captured';
+UPDATE payments SET status = 'cancelled' WHERE status = 'voided';
+UPDATE payments SET status = 'processing' WHERE status = 'authorized';
+
+DROP INDEX IF EXISTS idx_payments_pending;
+
+ALTER TYPE payment_status RENAME TO payment_status_old;
+CREATE TYPE payment_status AS ENUM (
+    'pending',
+    'processing',
+    'succeeded',
+    'failed',
+    'cancelled',
+    'refund_pending',
+    'refunded'
+);
+ALTER TABLE payments ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE payments ALTER COLUMN status TYPE payment_status USING status::text::payment_status;
+ALTER TABLE payments ALTER COLUMN status SET DEFAULT 'pending';
+DROP TYPE payment_status_old;
+
+CREATE INDEX idx_payments_pending ON payments(created_at)
+    WHERE status IN ('pending', 'processing');
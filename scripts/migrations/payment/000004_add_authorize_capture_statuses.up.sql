@@ -0,0 +1,5 @@
+-- Two-phase payments: the card is authorized when seats are reserved and only
+-- captured once the booking is confirmed, otherwise the authorization is voided
+ALTER TYPE payment_status ADD VALUE IF NOT EXISTS 'authorized' AFTER 'processing';
+ALTER TYPE payment_status ADD VALUE IF NOT EXISTS 'captured' AFTER 'authorized';
+ALTER TYPE payment_status ADD VALUE IF NOT EXISTS 'voided' AFTER 'cancelled';
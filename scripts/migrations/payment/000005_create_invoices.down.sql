@@ -0,0 +1,5 @@
+-- Rollback tax invoices
+
+DROP TABLE IF EXISTS invoices;
+DROP TABLE IF EXISTS invoice_sequences;
+ALTER TABLE payments DROP COLUMN IF EXISTS tax_info;
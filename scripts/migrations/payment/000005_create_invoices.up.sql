@@ -0,0 +1,54 @@
+-- ============================================================================
+-- Tax Invoices (Thai e-Tax)
+-- ============================================================================
+-- Full tax invoices for paid bookings. Buyer details captured at payment
+-- creation are kept on the payment; the invoice stores its own snapshot so it
+-- stays unchanged when the payment is anonymized.
+-- ============================================================================
+
+-- Buyer details (company name, tax ID, branch, address) for a full tax invoice
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS tax_info JSONB;
+
+-- Running invoice numbers per tenant and tax month
+CREATE TABLE IF NOT EXISTS invoice_sequences (
+    tenant_id UUID NOT NULL,
+    period CHAR(6) NOT NULL,              -- YYYYMM in Thai time
+    last_number BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (tenant_id, period)
+);
+
+CREATE TABLE IF NOT EXISTS invoices (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+
+    -- Cross-database references (NO FK constraints - validated at application level)
+    tenant_id UUID NOT NULL,              -- Reference to auth_db.tenants
+    booking_id UUID NOT NULL,             -- Reference to booking_db.bookings
+    payment_id UUID NOT NULL UNIQUE REFERENCES payments(id),
+
+    -- Running number
+    period CHAR(6) NOT NULL,
+    sequence BIGINT NOT NULL,
+    number VARCHAR(30) NOT NULL,
+
+    -- Buyer
+    buyer_name VARCHAR(255) NOT NULL,
+    buyer_tax_id VARCHAR(13) NOT NULL,
+    buyer_branch_code VARCHAR(5) NOT NULL DEFAULT '00000',
+    buyer_address TEXT NOT NULL,
+
+    -- Amounts (VAT included in total)
+    currency VARCHAR(3) DEFAULT 'THB',
+    subtotal DECIMAL(12, 2) NOT NULL,
+    vat_rate DECIMAL(5, 4) NOT NULL,
+    vat_amount DECIMAL(12, 2) NOT NULL,
+    total DECIMAL(12, 2) NOT NULL,
+
+    -- Timestamps
+    issued_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    UNIQUE (tenant_id, period, sequence)
+);
+
+-- Index for the monthly e-Tax export
+CREATE INDEX idx_invoices_tenant_issued ON invoices(tenant_id, issued_at);
@@ -0,0 +1,3 @@
+-- Rollback refund reviews
+
+DROP TABLE IF EXISTS refund_reviews;
@@ -0,0 +1,37 @@
+-- ============================================================================
+-- Refund Reviews
+-- ============================================================================
+-- Refunds made at the gateway (e.g. from the Stripe dashboard) are reconciled
+-- against payments from the charge.refunded webhook. Refunds that can't be
+-- applied automatically are kept here, one row per charge, for finance to
+-- resolve by hand.
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS refund_reviews (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+
+    -- Gateway references
+    gateway_charge_id VARCHAR(255) NOT NULL UNIQUE,
+    gateway_payment_id VARCHAR(255) NOT NULL DEFAULT '',
+    payment_id UUID REFERENCES payments(id),  -- Set when a payment matched
+
+    -- Total refunded on the charge, in major units
+    amount_refunded DECIMAL(12, 2) NOT NULL,
+    currency VARCHAR(3) DEFAULT 'THB',
+
+    reason VARCHAR(50) NOT NULL,
+    detail TEXT NOT NULL DEFAULT '',
+
+    -- Resolution
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'resolved')),
+    resolved_by VARCHAR(255),
+    resolution_note TEXT,
+    resolved_at TIMESTAMP WITH TIME ZONE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Index for the open review queue
+CREATE INDEX idx_refund_reviews_status_created ON refund_reviews(status, created_at DESC);
@@ -0,0 +1,4 @@
+-- Rollback double-entry ledger
+
+DROP TABLE IF EXISTS ledger_entries;
+DROP TABLE IF EXISTS ledger_transactions;
@@ -0,0 +1,59 @@
+-- ============================================================================
+-- Double-Entry Ledger
+-- ============================================================================
+-- Every payment, refund, gateway fee and payout posts a transaction whose
+-- entries debit and credit the same total to a tenant's accounts:
+--
+--   payment: Dr gateway_clearing  Cr tenant_payable
+--   refund:  Dr tenant_payable    Cr gateway_clearing
+--   fee:     Dr processing_fees   Cr gateway_clearing
+--   payout:  Dr tenant_payable    Cr gateway_clearing
+--
+-- gateway_clearing then tracks the funds held at the gateway, which finance
+-- reconciles against the gateway settlement files. Entries are never updated
+-- or deleted; mistakes are corrected with new transactions.
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS ledger_transactions (
+    id UUID PRIMARY KEY,
+
+    -- Cross-database reference (NO FK constraint - validated at application level)
+    tenant_id UUID NOT NULL,              -- Reference to auth_db.tenants
+
+    type VARCHAR(20) NOT NULL CHECK (type IN ('payment', 'refund', 'fee', 'payout')),
+    payment_id UUID REFERENCES payments(id),  -- Not set for payouts
+    reference VARCHAR(255) NOT NULL DEFAULT '',  -- Gateway ID of the charge, fee or payout
+
+    -- Identifies the business event so retries and webhooks post it once
+    idempotency_key VARCHAR(255) NOT NULL UNIQUE,
+
+    currency VARCHAR(3) NOT NULL DEFAULT 'THB',
+    description TEXT NOT NULL DEFAULT '',
+
+    -- Timestamps
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    id UUID PRIMARY KEY,
+    transaction_id UUID NOT NULL REFERENCES ledger_transactions(id),
+    tenant_id UUID NOT NULL,
+
+    account VARCHAR(30) NOT NULL CHECK (account IN ('gateway_clearing', 'tenant_payable', 'processing_fees')),
+    direction VARCHAR(6) NOT NULL CHECK (direction IN ('debit', 'credit')),
+
+    -- Minor units (satang/cents) so balances add up exactly
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    currency VARCHAR(3) NOT NULL DEFAULT 'THB',
+
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Index for balances and statements per account
+CREATE INDEX idx_ledger_entries_tenant_account ON ledger_entries(tenant_id, account, occurred_at);
+-- Index for the newest-first entry listing
+CREATE INDEX idx_ledger_entries_tenant_occurred ON ledger_entries(tenant_id, occurred_at DESC, id DESC);
+CREATE INDEX idx_ledger_entries_transaction ON ledger_entries(transaction_id);
+-- Index for the postings of a payment
+CREATE INDEX idx_ledger_transactions_payment ON ledger_transactions(payment_id) WHERE payment_id IS NOT NULL;
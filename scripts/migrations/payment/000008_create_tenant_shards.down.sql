@@ -0,0 +1,3 @@
+-- Rollback tenant shards
+
+DROP TABLE IF EXISTS tenant_shards;
@@ -0,0 +1,14 @@
+-- ============================================================================
+-- Tenant Shards
+-- ============================================================================
+-- Tenants needing data isolation get their own database (shard). The default
+-- shard keeps which tenant lives where; tenants without a row live on the
+-- default shard. The table is created on every shard by the shard migrations
+-- but is only read from the default one.
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS tenant_shards (
+    tenant_id UUID PRIMARY KEY,
+    shard VARCHAR(64) NOT NULL,
+    assigned_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
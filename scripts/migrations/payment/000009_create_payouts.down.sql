@@ -0,0 +1,10 @@
+-- Rollback tenant payouts
+
+DROP TABLE IF EXISTS payouts;
+DROP TABLE IF EXISTS payout_schedules;
+
+DELETE FROM ledger_entries WHERE transaction_id IN (SELECT id FROM ledger_transactions WHERE type = 'fee_recovery');
+DELETE FROM ledger_transactions WHERE type = 'fee_recovery';
+ALTER TABLE ledger_transactions DROP CONSTRAINT IF EXISTS ledger_transactions_type_check;
+ALTER TABLE ledger_transactions ADD CONSTRAINT ledger_transactions_type_check
+    CHECK (type IN ('payment', 'refund', 'fee', 'payout'));
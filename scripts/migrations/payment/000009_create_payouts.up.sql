@@ -0,0 +1,77 @@
+-- ============================================================================
+-- Tenant Payouts
+-- ============================================================================
+-- Tenants are paid their settled revenue on a schedule. A payout takes the
+-- tenant_payable balance at the end of its period, withholds the unrecovered
+-- processing fees, and posts to the ledger:
+--
+--   fee_recovery: Dr tenant_payable  Cr processing_fees
+--   payout:       Dr tenant_payable  Cr gateway_clearing
+--
+-- Bank details are stored only as the token the gateway or bank vault issued
+-- for the account.
+-- ============================================================================
+
+ALTER TABLE ledger_transactions DROP CONSTRAINT IF EXISTS ledger_transactions_type_check;
+ALTER TABLE ledger_transactions ADD CONSTRAINT ledger_transactions_type_check
+    CHECK (type IN ('payment', 'refund', 'fee', 'payout', 'fee_recovery'));
+
+CREATE TABLE IF NOT EXISTS payout_schedules (
+    -- Cross-database reference (NO FK constraint - validated at application level)
+    tenant_id UUID PRIMARY KEY,           -- Reference to auth_db.tenants
+
+    frequency VARCHAR(10) NOT NULL CHECK (frequency IN ('daily', 'weekly', 'monthly')),
+    method VARCHAR(20) NOT NULL CHECK (method IN ('gateway', 'bank_file')),
+    currency VARCHAR(3) NOT NULL DEFAULT 'THB',
+
+    -- Tokenized bank account; the account number itself is never stored
+    bank_account_token VARCHAR(255) NOT NULL,
+    bank_code VARCHAR(20) NOT NULL DEFAULT '',
+    account_holder VARCHAR(255) NOT NULL,
+    account_last4 VARCHAR(4) NOT NULL DEFAULT '',
+
+    -- Minor units; smaller balances carry over to the next payout
+    minimum_amount BIGINT NOT NULL DEFAULT 0 CHECK (minimum_amount >= 0),
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    next_payout_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS payouts (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL,
+
+    method VARCHAR(20) NOT NULL CHECK (method IN ('gateway', 'bank_file')),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('pending', 'submitted', 'exported', 'failed')),
+    currency VARCHAR(3) NOT NULL DEFAULT 'THB',
+
+    -- Minor units: amount = gross_amount - fee_amount
+    gross_amount BIGINT NOT NULL,
+    fee_amount BIGINT NOT NULL DEFAULT 0,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+
+    -- Ledger entries before period_end are settled into the payout
+    period_end TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    bank_account_token VARCHAR(255) NOT NULL,
+    reference VARCHAR(255) NOT NULL DEFAULT '',      -- Gateway payout ID
+    bank_file_key VARCHAR(500) NOT NULL DEFAULT '',  -- Bank transfer file in object storage
+    failure_reason TEXT NOT NULL DEFAULT '',
+    ledger_posted BOOLEAN NOT NULL DEFAULT FALSE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    UNIQUE (tenant_id, currency, period_end)
+);
+
+-- Index for the worker picking up due schedules
+CREATE INDEX idx_payout_schedules_due ON payout_schedules(next_payout_at) WHERE enabled;
+-- Index for the newest-first payout history
+CREATE INDEX idx_payouts_tenant_created ON payouts(tenant_id, created_at DESC, id DESC);
+-- Index for payouts still to be sent or posted to the ledger
+CREATE INDEX idx_payouts_unsettled ON payouts(tenant_id, created_at) WHERE NOT ledger_posted AND status <> 'failed';
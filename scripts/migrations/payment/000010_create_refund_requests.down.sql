@@ -0,0 +1,4 @@
+-- Rollback refund requests
+
+DROP TABLE IF EXISTS refund_approval_policies;
+DROP TABLE IF EXISTS refund_requests;
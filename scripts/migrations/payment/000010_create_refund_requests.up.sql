@@ -0,0 +1,60 @@
+-- ============================================================================
+-- Refund Requests
+-- ============================================================================
+-- Refunds requested through the API go through an approval workflow. A refund
+-- above the tenant's approval threshold waits as pending_approval until an
+-- admin approves or rejects it; only approved requests are sent to the
+-- gateway. Saga compensation and watchdog refunds are not subject to approval.
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS refund_requests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+
+    -- Cross-database reference (NO FK constraint - validated at application level)
+    tenant_id UUID NOT NULL,      -- Reference to auth_db.tenants
+    booking_id UUID NOT NULL,     -- Reference to booking_db.bookings
+    payment_id UUID NOT NULL,
+
+    -- Refundable amount when requested, in major units
+    amount DECIMAL(12, 2) NOT NULL,
+    currency VARCHAR(3) DEFAULT 'THB',
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+
+    status VARCHAR(20) NOT NULL DEFAULT 'pending_approval'
+        CHECK (status IN ('pending_approval', 'approved', 'rejected', 'completed', 'failed')),
+    requested_by VARCHAR(255) NOT NULL DEFAULT '',
+
+    -- Decision
+    decided_by VARCHAR(255) NOT NULL DEFAULT '',
+    decision_reason TEXT NOT NULL DEFAULT '',
+    decided_at TIMESTAMP WITH TIME ZONE,
+
+    -- Outcome at the gateway
+    failure_reason TEXT NOT NULL DEFAULT '',
+    completed_at TIMESTAMP WITH TIME ZONE,
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- A payment has at most one open request
+CREATE UNIQUE INDEX idx_refund_requests_open_payment ON refund_requests(payment_id)
+    WHERE status IN ('pending_approval', 'approved');
+
+-- Index for the approval queue
+CREATE INDEX idx_refund_requests_status_created ON refund_requests(status, created_at DESC);
+CREATE INDEX idx_refund_requests_tenant_created ON refund_requests(tenant_id, created_at DESC);
+
+-- Per-tenant approval thresholds; tenants without one use the service default
+CREATE TABLE IF NOT EXISTS refund_approval_policies (
+    tenant_id UUID PRIMARY KEY,   -- Reference to auth_db.tenants
+
+    -- Refunds above this amount need approval; 0 disables approval
+    threshold DECIMAL(12, 2) NOT NULL CHECK (threshold >= 0),
+    updated_by VARCHAR(255) NOT NULL DEFAULT '',
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
@@ -0,0 +1,3 @@
+-- Rollback payment method policies
+
+DROP TABLE IF EXISTS payment_method_policies;
@@ -0,0 +1,23 @@
+-- ============================================================================
+-- Payment Method Policies
+-- ============================================================================
+-- Some events accept only some payment methods, e.g. bank transfer only or
+-- cards only. A policy with an event_id applies to that event; one with an
+-- empty event_id is the tenant's default for events without their own. When
+-- neither exists every method is accepted.
+-- ============================================================================
+
+CREATE TABLE IF NOT EXISTS payment_method_policies (
+    -- Cross-database reference (NO FK constraint - validated at application level)
+    tenant_id UUID NOT NULL,                  -- Reference to auth_db.tenants
+    event_id VARCHAR(64) NOT NULL DEFAULT '', -- Reference to ticket_db.events; '' for the tenant default
+
+    allowed_methods payment_method[] NOT NULL CHECK (cardinality(allowed_methods) > 0),
+    updated_by VARCHAR(255) NOT NULL DEFAULT '',
+
+    -- Timestamps
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    PRIMARY KEY (tenant_id, event_id)
+);
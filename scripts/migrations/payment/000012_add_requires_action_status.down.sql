@@ -0,0 +1,28 @@
+-- Rollback requires_action payment status
+-- PostgreSQL cannot drop enum values, so map rows back and recreate the type.
+-- Processing payments are resolved from the gateway by payment-watchdog.
+
+UPDATE payments SET status = 'processing' WHERE status = 'requires_action';
+
+DROP INDEX IF EXISTS idx_payments_pending;
+
+ALTER TYPE payment_status RENAME TO payment_status_old;
+CREATE TYPE payment_status AS ENUM (
+    'pending',
+    'processing',
+    'authorized',
+    'captured',
+    'succeeded',
+    'failed',
+    'cancelled',
+    'voided',
+    'refund_pending',
+    'refunded'
+);
+ALTER TABLE payments ALTER COLUMN status DROP DEFAULT;
+ALTER TABLE payments ALTER COLUMN status TYPE payment_status USING status::text::payment_status;
+ALTER TABLE payments ALTER COLUMN status SET DEFAULT 'pending';
+DROP TYPE payment_status_old;
+
+CREATE INDEX idx_payments_pending ON payments(created_at)
+    WHERE status IN ('pending', 'processing');
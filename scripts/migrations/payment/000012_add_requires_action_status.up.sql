@@ -0,0 +1,3 @@
+-- Redirect payments (3-D Secure, bank transfer): the charge waits for the customer to
+-- finish paying at the gateway, and is cancelled if they never return
+ALTER TYPE payment_status ADD VALUE IF NOT EXISTS 'requires_action' AFTER 'processing';
@@ -0,0 +1,3 @@
+-- 000006_create_team_members.down.sql
+DROP TRIGGER IF EXISTS update_team_members_updated_at ON team_members;
+DROP TABLE IF EXISTS team_members;
@@ -0,0 +1,23 @@
+-- 000006_create_team_members.up.sql
+-- Ticket DB: Tenant team members who can co-manage the tenant's events
+-- Event organizers always have full rights on their own events; team members
+-- get the rights listed in permissions on every event of the tenant
+
+CREATE TABLE IF NOT EXISTS team_members (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL,      -- Reference only, NO FK (cross-database)
+    user_id UUID NOT NULL,        -- Reference only, NO FK (cross-database to auth_db.users)
+    permissions TEXT[] NOT NULL DEFAULT '{}', -- e.g. events:edit, events:publish, team:manage
+    added_by UUID,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CONSTRAINT uq_team_members_tenant_user UNIQUE (tenant_id, user_id)
+);
+
+CREATE INDEX idx_team_members_user_id ON team_members(user_id);
+
+CREATE TRIGGER update_team_members_updated_at
+    BEFORE UPDATE ON team_members
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,3 @@
+-- 000007_create_presale_codes.down.sql
+DROP TABLE IF EXISTS presale_codes;
+DROP TABLE IF EXISTS presale_batches;
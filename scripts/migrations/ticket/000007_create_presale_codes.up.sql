@@ -0,0 +1,34 @@
+-- 000007_create_presale_codes.up.sql
+-- Ticket DB: Presale access codes, generated in batches per event
+-- Live redemption counts are kept in Redis (atomic per-code limit); redeemed_count
+-- here is synced after each redemption and used for reporting
+
+CREATE TABLE IF NOT EXISTS presale_batches (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL,      -- Reference only, NO FK (cross-database)
+    name VARCHAR(100) NOT NULL,
+    max_uses_per_code INT NOT NULL,
+    valid_from TIMESTAMP WITH TIME ZONE NOT NULL,
+    valid_until TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_by UUID,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CONSTRAINT chk_presale_batches_max_uses_positive CHECK (max_uses_per_code > 0),
+    CONSTRAINT chk_presale_batches_window CHECK (valid_until > valid_from)
+);
+
+CREATE INDEX idx_presale_batches_event_id ON presale_batches(event_id);
+
+CREATE TABLE IF NOT EXISTS presale_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    batch_id UUID NOT NULL REFERENCES presale_batches(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL,
+    code VARCHAR(32) NOT NULL,    -- Stored upper-case
+    redeemed_count INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CONSTRAINT uq_presale_codes_event_code UNIQUE (event_id, code)
+);
+
+CREATE INDEX idx_presale_codes_batch_id ON presale_codes(batch_id);
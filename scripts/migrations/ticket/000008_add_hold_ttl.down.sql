@@ -0,0 +1,4 @@
+-- 000008_add_hold_ttl.down.sql
+
+ALTER TABLE seat_zones DROP COLUMN IF EXISTS hold_ttl_seconds;
+ALTER TABLE events DROP COLUMN IF EXISTS hold_ttl_seconds;
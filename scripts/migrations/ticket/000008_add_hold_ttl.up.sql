@@ -0,0 +1,11 @@
+-- 000008_add_hold_ttl.up.sql
+-- Ticket DB: Organizer-configured reservation hold TTL per event and zone
+-- NULL inherits (zone -> event -> booking service default)
+
+ALTER TABLE events
+ADD COLUMN IF NOT EXISTS hold_ttl_seconds INT
+CONSTRAINT chk_events_hold_ttl_range CHECK (hold_ttl_seconds BETWEEN 60 AND 3600);
+
+ALTER TABLE seat_zones
+ADD COLUMN IF NOT EXISTS hold_ttl_seconds INT
+CONSTRAINT chk_seat_zones_hold_ttl_range CHECK (hold_ttl_seconds BETWEEN 60 AND 3600);
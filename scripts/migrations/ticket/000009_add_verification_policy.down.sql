@@ -0,0 +1,3 @@
+-- 000009_add_verification_policy.down.sql
+
+ALTER TABLE events DROP COLUMN IF EXISTS verification_policy;
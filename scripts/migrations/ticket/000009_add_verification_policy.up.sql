@@ -0,0 +1,7 @@
+-- 000009_add_verification_policy.up.sql
+-- Ticket DB: Account verification booking-service requires per event
+-- none = any account, email = verified email, verified = verified email and phone
+
+ALTER TABLE events
+ADD COLUMN IF NOT EXISTS verification_policy VARCHAR(20) NOT NULL DEFAULT 'none'
+CONSTRAINT chk_events_verification_policy CHECK (verification_policy IN ('none', 'email', 'verified'));
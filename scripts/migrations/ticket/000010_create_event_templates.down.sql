@@ -0,0 +1,3 @@
+-- 000010_create_event_templates.down.sql
+DROP TRIGGER IF EXISTS update_event_templates_updated_at ON event_templates;
+DROP TABLE IF EXISTS event_templates;
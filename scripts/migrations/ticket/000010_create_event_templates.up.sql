@@ -0,0 +1,23 @@
+-- 000010_create_event_templates.up.sql
+-- Ticket DB: Reusable event setups saved by organizers
+-- blueprint holds the event settings, shows and zones (with pricing); instantiating a
+-- template shifts every date so the first show falls on the requested day
+
+CREATE TABLE IF NOT EXISTS event_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL,      -- Reference only, NO FK (cross-database)
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    source_event_id UUID,         -- Event the template was saved from, kept if the event is deleted
+    blueprint JSONB NOT NULL,
+    created_by UUID,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_event_templates_tenant_id ON event_templates(tenant_id, created_at);
+
+CREATE TRIGGER update_event_templates_updated_at
+    BEFORE UPDATE ON event_templates
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,6 @@
+-- 000011_create_venues.down.sql
+DROP TRIGGER IF EXISTS update_venue_zones_updated_at ON venue_zones;
+DROP TRIGGER IF EXISTS update_venues_updated_at ON venues;
+DROP TABLE IF EXISTS venue_layouts;
+DROP TABLE IF EXISTS venue_zones;
+DROP TABLE IF EXISTS venues;
@@ -0,0 +1,49 @@
+-- 000011_create_venues.up.sql
+-- Ticket DB: Venues, their zones and versioned seat-map layouts
+-- Uploading a layout adds a version; the venue serves its active version to the seat-selection UI
+
+CREATE TABLE IF NOT EXISTS venues (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id UUID NOT NULL,      -- Reference only, NO FK (cross-database)
+    name VARCHAR(200) NOT NULL,
+    address TEXT NOT NULL,
+    capacity INT NOT NULL CHECK (capacity > 0),
+    active_layout_version INT,    -- NULL until a layout is activated
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_venues_tenant_id ON venues(tenant_id, created_at);
+
+CREATE TABLE IF NOT EXISTS venue_zones (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    venue_id UUID NOT NULL REFERENCES venues(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    capacity INT NOT NULL CHECK (capacity > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_venue_zones_venue_id ON venue_zones(venue_id);
+
+CREATE TABLE IF NOT EXISTS venue_layouts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    venue_id UUID NOT NULL REFERENCES venues(id) ON DELETE CASCADE,
+    version INT NOT NULL,
+    layout JSONB NOT NULL,        -- Sections, rows and seats
+    seat_count INT NOT NULL,
+    notes TEXT,
+    created_by UUID,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (venue_id, version)
+);
+
+CREATE TRIGGER update_venues_updated_at
+    BEFORE UPDATE ON venues
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_venue_zones_updated_at
+    BEFORE UPDATE ON venue_zones
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
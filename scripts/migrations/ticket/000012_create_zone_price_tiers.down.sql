@@ -0,0 +1,2 @@
+-- 000012_create_zone_price_tiers.down.sql
+DROP TABLE IF EXISTS zone_price_tiers;
@@ -0,0 +1,23 @@
+-- 000012_create_zone_price_tiers.up.sql
+-- Ticket DB: Time-windowed price tiers per zone (early-bird, last-minute)
+-- A zone sells at the first active tier with seats left in its allocation, or at
+-- seat_zones.price when none is; the per-tier seat counts live in booking Redis
+
+CREATE TABLE IF NOT EXISTS zone_price_tiers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    zone_id UUID NOT NULL REFERENCES seat_zones(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    price DECIMAL(12, 2) NOT NULL,
+    allocation INT NOT NULL DEFAULT 0,    -- 0 = no limit
+    valid_from TIMESTAMP WITH TIME ZONE,  -- NULL = from the start of sales
+    valid_until TIMESTAMP WITH TIME ZONE, -- NULL = until the end of sales
+    sort_order INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CONSTRAINT chk_zone_price_tiers_price CHECK (price >= 0),
+    CONSTRAINT chk_zone_price_tiers_allocation CHECK (allocation >= 0),
+    CONSTRAINT chk_zone_price_tiers_window CHECK (valid_until IS NULL OR valid_from IS NULL OR valid_until > valid_from)
+);
+
+CREATE INDEX idx_zone_price_tiers_zone_id ON zone_price_tiers(zone_id, sort_order);
@@ -0,0 +1,2 @@
+-- 000013_create_event_add_ons.down.sql
+DROP TABLE IF EXISTS event_add_ons;
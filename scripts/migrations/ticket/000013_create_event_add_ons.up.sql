@@ -0,0 +1,25 @@
+-- 000013_create_event_add_ons.up.sql
+-- Ticket DB: Add-on items sold with an event's tickets (parking, merchandise)
+-- The units sold per add-on live in booking Redis, counted separately from seats
+
+CREATE TABLE IF NOT EXISTS event_add_ons (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    category VARCHAR(20) NOT NULL DEFAULT 'other',
+    price DECIMAL(12, 2) NOT NULL,
+    inventory INT NOT NULL DEFAULT 0,       -- 0 = no limit
+    max_per_booking INT NOT NULL DEFAULT 0, -- 0 = no limit
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    sort_order INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+
+    CONSTRAINT chk_event_add_ons_category CHECK (category IN ('parking', 'merchandise', 'other')),
+    CONSTRAINT chk_event_add_ons_price CHECK (price >= 0),
+    CONSTRAINT chk_event_add_ons_inventory CHECK (inventory >= 0),
+    CONSTRAINT chk_event_add_ons_max_per_booking CHECK (max_per_booking >= 0)
+);
+
+CREATE INDEX idx_event_add_ons_event_id ON event_add_ons(event_id, sort_order);